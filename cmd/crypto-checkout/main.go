@@ -2,12 +2,34 @@
 package main
 
 import (
+	"context"
 	"crypto-checkout/internal/application"
 	"flag"
+	"fmt"
+	"io"
 	"os"
 )
 
 func main() {
+	// Subcommands run instead of the server
+	commands := map[string]func(context.Context, []string, io.Writer) error{
+		"replay":        application.RunReplay,
+		"backup":        application.RunBackup,
+		"restore":       application.RunRestore,
+		"rotate-keys":   application.RunRotateKeys,
+		"event-schemas": application.RunEventSchemas,
+		"observability": application.RunObservability,
+	}
+	if len(os.Args) > 1 {
+		if run, ok := commands[os.Args[1]]; ok {
+			if err := run(context.Background(), os.Args[2:], os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[1], err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	// Parse command line flags
 	healthCheck := flag.Bool("health-check", false, "Run health check and exit")
 	flag.Parse()
@@ -2,11 +2,61 @@ package application
 
 import (
 	"context"
+	"crypto-checkout/internal/domain/approval"
+	"crypto-checkout/internal/domain/archive"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/automation"
+	"crypto-checkout/internal/domain/checkoutanalytics"
+	"crypto-checkout/internal/domain/compliance"
+	"crypto-checkout/internal/domain/confirmation"
+	"crypto-checkout/internal/domain/coupon"
+	"crypto-checkout/internal/domain/customer"
+	"crypto-checkout/internal/domain/deadletter"
+	"crypto-checkout/internal/domain/deposit"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/evidence"
+	"crypto-checkout/internal/domain/experiment"
+	"crypto-checkout/internal/domain/fee"
+	"crypto-checkout/internal/domain/geoblock"
 	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/invoicetemplate"
 	"crypto-checkout/internal/domain/merchant"
 	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/paymentlink"
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/platform"
+	"crypto-checkout/internal/domain/processor"
+	"crypto-checkout/internal/domain/reconciliation"
+	"crypto-checkout/internal/domain/review"
+	"crypto-checkout/internal/domain/saga"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/domain/slo"
+	"crypto-checkout/internal/domain/statement"
+	"crypto-checkout/internal/domain/tax"
+	"crypto-checkout/internal/domain/treasury"
+	"crypto-checkout/internal/domain/webhook"
+	"crypto-checkout/internal/infrastructure/addressproof"
+	"crypto-checkout/internal/infrastructure/chainscan"
 	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/internal/infrastructure/errorreporting"
 	"crypto-checkout/internal/infrastructure/events"
+	"crypto-checkout/internal/infrastructure/exchange"
+	"crypto-checkout/internal/infrastructure/feeoracle"
+	"crypto-checkout/internal/infrastructure/geoip"
+	"crypto-checkout/internal/infrastructure/health"
+	"crypto-checkout/internal/infrastructure/hotwallet"
+	"crypto-checkout/internal/infrastructure/logging"
+	"crypto-checkout/internal/infrastructure/mailer"
+	"crypto-checkout/internal/infrastructure/objectstore"
+	"crypto-checkout/internal/infrastructure/processors"
+	"crypto-checkout/internal/infrastructure/rates"
+	"crypto-checkout/internal/infrastructure/resilience"
+	"crypto-checkout/internal/infrastructure/rulecall"
+	"crypto-checkout/internal/infrastructure/runtimeconfig"
+	"crypto-checkout/internal/infrastructure/screening"
+	"crypto-checkout/internal/infrastructure/secrets"
+	"crypto-checkout/internal/infrastructure/supervisor"
+	"crypto-checkout/internal/infrastructure/webhooksender"
 	"crypto-checkout/internal/presentation/web"
 	"crypto-checkout/pkg/config"
 
@@ -16,22 +66,105 @@ import (
 
 func GetApp() *fx.App {
 	return fx.New(
-		fx.Provide(config.NewConfigProvider),
-		fx.Provide(NewLogger),
-		database.Module,
-		events.Module,
-		invoice.Module,
-		merchant.Module,
-		payment.Module,
-		web.Module,
+		fx.Provide(secrets.NewConfigProvider),
+		logging.Module,
+		fx.Provide(NewClock),
+		fx.Invoke(InstallClock),
+		logging.Named("addressproof", addressproof.Module),
+		logging.Named("approval", approval.Module),
+		logging.Named("archive", archive.Module),
+		logging.Named("audit", audit.Module),
+		logging.Named("automation", automation.Module),
+		logging.Named("chainscan", chainscan.Module),
+		logging.Named("checkoutanalytics", checkoutanalytics.Module),
+		logging.Named("compliance", compliance.Module),
+		logging.Named("confirmation", confirmation.Module),
+		logging.Named("coupon", coupon.Module),
+		logging.Named("customer", customer.Module),
+		logging.Named("database", database.Module),
+		logging.Named("deadletter", deadletter.Module),
+		logging.Named("deposit", deposit.Module),
+		logging.Named("detection", detection.Module),
+		logging.Named("errorreporting", errorreporting.Module),
+		logging.Named("evidence", evidence.Module),
+		logging.Named("events", events.Module),
+		logging.Named("exchange", exchange.Module),
+		logging.Named("experiment", experiment.Module),
+		logging.Named("fee", fee.Module),
+		logging.Named("feeoracle", feeoracle.Module),
+		logging.Named("geoblock", geoblock.Module),
+		logging.Named("geoip", geoip.Module),
+		logging.Named("health", health.Module),
+		logging.Named("hotwallet", hotwallet.Module),
+		logging.Named("invoice", invoice.Module),
+		logging.Named("mailer", mailer.Module),
+		logging.Named("merchant", merchant.Module),
+		logging.Named("objectstore", objectstore.Module),
+		logging.Named("payment", payment.Module),
+		logging.Named("paymentlink", paymentlink.Module),
+		logging.Named("invoicetemplate", invoicetemplate.Module),
+		logging.Named("payout", payout.Module),
+		logging.Named("reconciliation", reconciliation.Module),
+		logging.Named("platform", platform.Module),
+		logging.Named("processor", processor.Module),
+		logging.Named("processors", processors.Module),
+		logging.Named("treasury", treasury.Module),
+		logging.Named("rates", rates.Module),
+		logging.Named("resilience", resilience.Module),
+		logging.Named("review", review.Module),
+		logging.Named("rulecall", rulecall.Module),
+		logging.Named("runtimeconfig", runtimeconfig.Module),
+		logging.Named("saga", saga.Module),
+		logging.Named("screening", screening.Module),
+		logging.Named("secrets", secrets.Module),
+		logging.Named("settlement", settlement.Module),
+		logging.Named("slo", slo.Module),
+		logging.Named("statement", statement.Module),
+		logging.Named("supervisor", supervisor.Module),
+		logging.Named("tax", tax.Module),
+		logging.Named("webhook", webhook.Module),
+		logging.Named("webhooksender", webhooksender.Module),
+		logging.Named("web", web.Module),
 		fx.Invoke(StartApplication),
 		fx.Invoke(func(log *zap.Logger, graph fx.DotGraph) {
 			log.Info("Application modules loaded",
+				zap.String("approval_module", "approval-service"),
+				zap.String("audit_module", "audit-service"),
+				zap.String("automation_module", "automation-service"),
+				zap.String("chainscan_module", "chainscan"),
+				zap.String("compliance_module", "compliance-service"),
+				zap.String("confirmation_module", "confirmation"),
+				zap.String("coupon_module", "coupon-service"),
+				zap.String("customer_module", "customer-service"),
 				zap.String("database_module", "database"),
+				zap.String("detection_module", "detection-service"),
 				zap.String("events_module", "events"),
+				zap.String("exchange_module", "exchange"),
+				zap.String("fee_module", "fee-service"),
+				zap.String("feeoracle_module", "feeoracle"),
+				zap.String("health_module", "health"),
+				zap.String("hotwallet_module", "hotwallet"),
 				zap.String("invoice_module", "invoice-service"),
+				zap.String("logging_module", "logging"),
+				zap.String("mailer_module", "mailer"),
 				zap.String("merchant_module", "merchant-service"),
 				zap.String("payment_module", "payment-service"),
+				zap.String("payment_link_module", "payment-link-service"),
+				zap.String("payout_module", "payout-service"),
+				zap.String("platform_module", "platform-service"),
+				zap.String("processor_module", "processor-service"),
+				zap.String("processors_module", "processors"),
+				zap.String("treasury_module", "treasury-service"),
+				zap.String("rates_module", "rates"),
+				zap.String("review_module", "review-service"),
+				zap.String("rulecall_module", "rulecall"),
+				zap.String("saga_module", "saga-service"),
+				zap.String("screening_module", "screening"),
+				zap.String("secrets_module", "secrets"),
+				zap.String("settlement_module", "settlement-service"),
+				zap.String("tax_module", "tax-service"),
+				zap.String("webhook_module", "webhook-service"),
+				zap.String("webhooksender_module", "webhooksender"),
 				zap.String("web_module", "api"))
 
 			// Print dependency graph
@@ -40,22 +173,13 @@ func GetApp() *fx.App {
 	)
 }
 
-// NewLogger creates a new logger based on configuration.
+// NewLogger creates a new logger based on configuration, for the commands that run without the application.
 func NewLogger(cfg *config.Config) *zap.Logger {
-	var logger *zap.Logger
-	var err error
-
-	switch cfg.Log.Level {
-	case "debug":
-		logger, err = zap.NewDevelopment()
-	default:
-		logger, err = zap.NewProduction()
-	}
-
+	logger, err := logging.New(cfg.Log)
 	if err != nil {
 		panic(err)
 	}
-	return logger
+	return logger.Logger
 }
 
 // StartApplication starts the application with lifecycle management.
@@ -0,0 +1,177 @@
+package application
+
+import (
+	"context"
+	"crypto-checkout/internal/infrastructure/backup"
+	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/internal/infrastructure/secrets"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// BackupPassphraseEnv names the environment variable holding the passphrase that encrypts the API keys and
+// webhook secrets of a backup.
+const BackupPassphraseEnv = "CRYPTO_CHECKOUT_BACKUP_PASSPHRASE"
+
+// BackupOptions holds the arguments of the backup command.
+type BackupOptions struct {
+	MerchantID string
+	Out        string
+}
+
+// RestoreOptions holds the arguments of the restore command.
+type RestoreOptions struct {
+	In         string
+	OnConflict string
+}
+
+// ParseBackupOptions parses the arguments of the backup command.
+func ParseBackupOptions(args []string, output io.Writer) (BackupOptions, error) {
+	var options BackupOptions
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.StringVar(&options.MerchantID, "merchant", "", "ID of the merchant to back up")
+	flags.StringVar(&options.Out, "out", "", "Archive to write (.tar.zst)")
+	if err := flags.Parse(args); err != nil {
+		return options, err
+	}
+
+	if options.MerchantID == "" {
+		return options, errors.New("--merchant is required")
+	}
+	if options.Out == "" {
+		return options, errors.New("--out is required")
+	}
+	return options, nil
+}
+
+// ParseRestoreOptions parses the arguments of the restore command.
+func ParseRestoreOptions(args []string, output io.Writer) (RestoreOptions, error) {
+	var options RestoreOptions
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.StringVar(&options.In, "in", "", "Archive to restore (.tar.zst)")
+	flags.StringVar(&options.OnConflict, "on-conflict", backup.ConflictFail,
+		"How to treat rows that already exist: fail, skip or overwrite")
+	if err := flags.Parse(args); err != nil {
+		return options, err
+	}
+
+	if options.In == "" {
+		return options, errors.New("--in is required")
+	}
+	if err := backup.ValidateStrategy(options.OnConflict); err != nil {
+		return options, err
+	}
+	return options, nil
+}
+
+// RunBackup runs the backup command against the configured database.
+func RunBackup(ctx context.Context, args []string, stdout io.Writer) (err error) {
+	options, err := ParseBackupOptions(args, stdout)
+	if err != nil {
+		return err
+	}
+	passphrase := os.Getenv(BackupPassphraseEnv)
+	if passphrase == "" {
+		return fmt.Errorf("%s must be set to encrypt API keys and webhook secrets", BackupPassphraseEnv)
+	}
+
+	conn, err := openCommandDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	archive, err := backup.Export(ctx, conn.DB, options.MerchantID)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(options.Out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to close archive: %w", closeErr)
+		}
+		if err != nil {
+			_ = os.Remove(options.Out)
+		}
+	}()
+	if err = backup.Write(file, archive, passphrase); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "merchant %s backed up to %s\n", options.MerchantID, options.Out)
+	printCounts(stdout, archive.Manifest.Counts)
+	return nil
+}
+
+// RunRestore runs the restore command against the configured database.
+func RunRestore(ctx context.Context, args []string, stdout io.Writer) error {
+	options, err := ParseRestoreOptions(args, stdout)
+	if err != nil {
+		return err
+	}
+	passphrase := os.Getenv(BackupPassphraseEnv)
+	if passphrase == "" {
+		return fmt.Errorf("%s must be set to decrypt API keys and webhook secrets", BackupPassphraseEnv)
+	}
+
+	file, err := os.Open(options.In)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	archive, err := backup.Read(file, passphrase)
+	if err != nil {
+		return err
+	}
+
+	conn, err := openCommandDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	// Restoring into an empty deployment needs the tables
+	if err := conn.Migrate(); err != nil {
+		return err
+	}
+	if err := backup.Restore(ctx, conn.DB, archive, options.OnConflict); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "merchant %s restored from %s (backup of %s, on conflict: %s)\n",
+		archive.Manifest.MerchantID, options.In, archive.Manifest.CreatedAt.Format("2006-01-02 15:04:05 MST"),
+		options.OnConflict)
+	printCounts(stdout, archive.Manifest.Counts)
+	return nil
+}
+
+// openCommandDatabase connects a command to the configured database.
+func openCommandDatabase(ctx context.Context) (*database.Connection, error) {
+	cfg, _, err := secrets.LoadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return database.NewDatabaseConnection(cfg, NewLogger(cfg))
+}
+
+// printCounts prints the archived rows per table.
+func printCounts(stdout io.Writer, counts map[string]int) {
+	tables := make([]string, 0, len(counts))
+	for table := range counts {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	for _, table := range tables {
+		fmt.Fprintf(stdout, "  %s: %d\n", table, counts[table])
+	}
+}
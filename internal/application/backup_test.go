@@ -0,0 +1,41 @@
+package application_test
+
+import (
+	"crypto-checkout/internal/application"
+	"crypto-checkout/internal/infrastructure/backup"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupOptions(t *testing.T) {
+	t.Run("ParseBackupOptions", func(t *testing.T) {
+		options, err := application.ParseBackupOptions(
+			[]string{"--merchant", "m-1", "--out", "m-1.tar.zst"}, io.Discard)
+		require.NoError(t, err)
+		require.Equal(t, application.BackupOptions{MerchantID: "m-1", Out: "m-1.tar.zst"}, options)
+
+		_, err = application.ParseBackupOptions([]string{"--out", "m-1.tar.zst"}, io.Discard)
+		require.Error(t, err)
+		_, err = application.ParseBackupOptions([]string{"--merchant", "m-1"}, io.Discard)
+		require.Error(t, err)
+	})
+
+	t.Run("ParseRestoreOptions", func(t *testing.T) {
+		options, err := application.ParseRestoreOptions([]string{"--in", "m-1.tar.zst"}, io.Discard)
+		require.NoError(t, err)
+		require.Equal(t, application.RestoreOptions{In: "m-1.tar.zst", OnConflict: backup.ConflictFail}, options)
+
+		options, err = application.ParseRestoreOptions(
+			[]string{"--in", "m-1.tar.zst", "--on-conflict", "overwrite"}, io.Discard)
+		require.NoError(t, err)
+		require.Equal(t, backup.ConflictOverwrite, options.OnConflict)
+
+		_, err = application.ParseRestoreOptions([]string{"--in", "m-1.tar.zst", "--on-conflict", "merge"},
+			io.Discard)
+		require.ErrorIs(t, err, backup.ErrInvalidStrategy)
+		_, err = application.ParseRestoreOptions(nil, io.Discard)
+		require.Error(t, err)
+	})
+}
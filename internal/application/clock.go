@@ -0,0 +1,26 @@
+package application
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+
+	"go.uber.org/fx"
+)
+
+// NewClock provides the clock the application tells time by: the wall clock. Tests replace it with
+// fx.Replace to control time.
+func NewClock() shared.Clock {
+	return shared.SystemClock{}
+}
+
+// InstallClock makes the provided clock the one aggregates and services read through shared.Now, until the
+// application stops.
+func InstallClock(lc fx.Lifecycle, clock shared.Clock) {
+	restore := shared.SetClock(clock)
+	lc.Append(fx.Hook{
+		OnStop: func(context.Context) error {
+			restore()
+			return nil
+		},
+	})
+}
@@ -0,0 +1,29 @@
+package application_test
+
+import (
+	"crypto-checkout/internal/application"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/test/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestInstallClock(t *testing.T) {
+	clock := testutil.NewClock(time.Time{})
+	app := fxtest.New(t,
+		fx.Provide(application.NewClock),
+		fx.Replace(fx.Annotate(clock, fx.As(new(shared.Clock)))),
+		fx.Invoke(application.InstallClock),
+	)
+	app.RequireStart()
+	assert.Equal(t, testutil.Epoch, shared.Now())
+	clock.Advance(time.Hour)
+	assert.Equal(t, testutil.Epoch.Add(time.Hour), shared.Now())
+
+	app.RequireStop()
+	assert.WithinDuration(t, time.Now(), shared.Now(), time.Minute)
+}
@@ -0,0 +1,82 @@
+package application
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// EventSchemasOptions holds the arguments of the event-schemas command.
+type EventSchemasOptions struct {
+	Out string
+}
+
+// ParseEventSchemasOptions parses the arguments of the event-schemas command.
+func ParseEventSchemasOptions(args []string, output io.Writer) (EventSchemasOptions, error) {
+	var options EventSchemasOptions
+	flags := flag.NewFlagSet("event-schemas", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.StringVar(&options.Out, "out", "", "Directory to write one schema file per event type to")
+	if err := flags.Parse(args); err != nil {
+		return options, err
+	}
+	return options, nil
+}
+
+// RunEventSchemas runs the event-schemas command, exporting the JSON Schema of every published event type.
+func RunEventSchemas(_ context.Context, args []string, stdout io.Writer) error {
+	options, err := ParseEventSchemasOptions(args, stdout)
+	if err != nil {
+		return err
+	}
+	return ExportEventSchemas(shared.DefaultEventRegistry(), options, stdout)
+}
+
+// ExportEventSchemas exports the JSON Schema of the events of a registry. With an output directory, each schema
+// is written to <event type>.v<version>.json there; otherwise all schemas are printed, keyed by event type.
+func ExportEventSchemas(registry *shared.EventRegistry, options EventSchemasOptions, stdout io.Writer) error {
+	schemas := make(map[string]interface{})
+	for _, schema := range registry.Schemas() {
+		document, err := registry.JSONSchema(schema.Type)
+		if err != nil {
+			return err
+		}
+		if options.Out == "" {
+			schemas[schema.Type] = document
+			continue
+		}
+
+		path := filepath.Join(options.Out, fmt.Sprintf("%s.v%d.json", schema.Type, schema.Version))
+		if err := writeJSONFile(path, document); err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, path)
+	}
+	if options.Out != "" {
+		return nil
+	}
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(schemas)
+}
+
+// writeJSONFile writes a value as indented JSON, creating the file's directory.
+func writeJSONFile(path string, value interface{}) error {
+	encoded, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create schema directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(encoded, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+	return nil
+}
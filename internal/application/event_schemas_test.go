@@ -0,0 +1,53 @@
+package application_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/application"
+	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSchemas(t *testing.T) {
+	registry := shared.DefaultEventRegistry()
+
+	t.Run("ParseEventSchemasOptions", func(t *testing.T) {
+		options, err := application.ParseEventSchemasOptions([]string{"--out", "schemas"}, io.Discard)
+		require.NoError(t, err)
+		require.Equal(t, application.EventSchemasOptions{Out: "schemas"}, options)
+
+		_, err = application.ParseEventSchemasOptions([]string{"--format", "avro"}, io.Discard)
+		require.Error(t, err)
+	})
+
+	t.Run("PrintsAllSchemas", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, application.RunEventSchemas(context.Background(), nil, &out))
+
+		var schemas map[string]map[string]interface{}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &schemas))
+		require.Len(t, schemas, len(registry.Schemas()))
+		require.Equal(t, shared.EventTypeInvoiceCreated, schemas[shared.EventTypeInvoiceCreated]["title"])
+	})
+
+	t.Run("WritesOneFilePerEventType", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "schemas")
+		var out bytes.Buffer
+		require.NoError(t, application.ExportEventSchemas(registry, application.EventSchemasOptions{Out: dir}, &out))
+		require.Len(t, strings.Fields(out.String()), len(registry.Schemas()))
+
+		encoded, err := os.ReadFile(filepath.Join(dir, "payment.status_changed.v1.json"))
+		require.NoError(t, err)
+		var schema map[string]interface{}
+		require.NoError(t, json.Unmarshal(encoded, &schema))
+		require.Equal(t, shared.JSONSchemaDialect, schema["$schema"])
+		require.Equal(t, shared.EventTypePaymentStatusChanged, schema["title"])
+	})
+}
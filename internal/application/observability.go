@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"crypto-checkout/pkg/metrics"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Files written by the observability export command.
+const (
+	DashboardFile  = "grafana-dashboard.json"
+	AlertRulesFile = "prometheus-alerts.yml"
+)
+
+// ObservabilityOptions holds the arguments of the observability export command.
+type ObservabilityOptions struct {
+	Out string
+}
+
+// ParseObservabilityOptions parses the arguments of the observability command, whose only subcommand is export.
+func ParseObservabilityOptions(args []string, output io.Writer) (ObservabilityOptions, error) {
+	options := ObservabilityOptions{Out: "."}
+	if len(args) == 0 || args[0] != "export" {
+		return options, errors.New("usage: observability export [--out directory]")
+	}
+	flags := flag.NewFlagSet("observability export", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.StringVar(&options.Out, "out", options.Out, "Directory to write the Grafana dashboard and alert rules to")
+	if err := flags.Parse(args[1:]); err != nil {
+		return options, err
+	}
+	return options, nil
+}
+
+// RunObservability runs the observability command, exporting the Grafana dashboard and Prometheus alert rules of
+// the metrics this binary serves on /metrics.
+func RunObservability(_ context.Context, args []string, stdout io.Writer) error {
+	options, err := ParseObservabilityOptions(args, stdout)
+	if err != nil {
+		return err
+	}
+	return ExportObservability(metrics.Default(), options, stdout)
+}
+
+// ExportObservability writes a Grafana dashboard charting every metric of a registry and a Prometheus rule file
+// with its alerts to the output directory, and prints their paths.
+func ExportObservability(registry *metrics.Registry, options ObservabilityOptions, stdout io.Writer) error {
+	dashboard, err := registry.Dashboard("crypto-checkout", "Crypto Checkout")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(options.Out, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for name, content := range map[string][]byte{
+		DashboardFile:  dashboard,
+		AlertRulesFile: registry.PrometheusRules("crypto-checkout"),
+	} {
+		path := filepath.Join(options.Out, name)
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	fmt.Fprintln(stdout, filepath.Join(options.Out, DashboardFile))
+	fmt.Fprintln(stdout, filepath.Join(options.Out, AlertRulesFile))
+	return nil
+}
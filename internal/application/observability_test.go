@@ -0,0 +1,65 @@
+package application_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/application"
+	"crypto-checkout/pkg/metrics"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObservability(t *testing.T) {
+	t.Run("ParseObservabilityOptions", func(t *testing.T) {
+		options, err := application.ParseObservabilityOptions([]string{"export", "--out", "ops"}, io.Discard)
+		require.NoError(t, err)
+		require.Equal(t, application.ObservabilityOptions{Out: "ops"}, options)
+
+		options, err = application.ParseObservabilityOptions([]string{"export"}, io.Discard)
+		require.NoError(t, err)
+		require.Equal(t, ".", options.Out)
+
+		_, err = application.ParseObservabilityOptions(nil, io.Discard)
+		require.Error(t, err)
+		_, err = application.ParseObservabilityOptions([]string{"import"}, io.Discard)
+		require.Error(t, err)
+	})
+
+	t.Run("ExportsRegisteredMetrics", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "ops")
+		var out bytes.Buffer
+		require.NoError(t, application.RunObservability(context.Background(),
+			[]string{"export", "--out", dir}, &out))
+		require.Equal(t, []string{
+			filepath.Join(dir, application.DashboardFile),
+			filepath.Join(dir, application.AlertRulesFile),
+		}, strings.Fields(out.String()))
+
+		rules, err := os.ReadFile(filepath.Join(dir, application.AlertRulesFile))
+		require.NoError(t, err)
+		for _, alert := range []string{"WebhookBacklog", "BlockWatcherLagging", "PaymentConfirmationSLOBreached"} {
+			assert.Contains(t, string(rules), "- alert: "+alert+"\n")
+		}
+
+		encoded, err := os.ReadFile(filepath.Join(dir, application.DashboardFile))
+		require.NoError(t, err)
+		var dashboard struct {
+			Panels []struct {
+				Description string `json:"description"`
+			} `json:"panels"`
+		}
+		require.NoError(t, json.Unmarshal(encoded, &dashboard))
+		descs := metrics.Default().Descs()
+		require.Len(t, dashboard.Panels, len(descs))
+		for i, desc := range descs {
+			assert.Equal(t, desc.Help, dashboard.Panels[i].Description)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/infrastructure/database"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// AggregatePayment names the payment aggregate in the replay command.
+const AggregatePayment = "payment"
+
+// ErrCorruptedSnapshots is returned by a dry run that finds snapshots disagreeing with the event stream.
+var ErrCorruptedSnapshots = errors.New("snapshots do not match the event stream")
+
+// ReplayOptions holds the arguments of the replay command.
+type ReplayOptions struct {
+	Aggregate string
+	ID        string
+	DryRun    bool
+}
+
+// ParseReplayOptions parses the arguments of the replay command.
+func ParseReplayOptions(args []string, output io.Writer) (ReplayOptions, error) {
+	var options ReplayOptions
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.StringVar(&options.Aggregate, "aggregate", "", "Event-sourced aggregate to replay (payment)")
+	flags.StringVar(&options.ID, "id", "", "ID of the aggregate to replay")
+	flags.BoolVar(&options.DryRun, "dry-run", false, "Verify snapshots without rebuilding anything")
+	if err := flags.Parse(args); err != nil {
+		return options, err
+	}
+
+	if options.Aggregate != AggregatePayment {
+		return options, fmt.Errorf("unsupported aggregate %q: only %q is event sourced", options.Aggregate, AggregatePayment)
+	}
+	if options.ID == "" {
+		return options, errors.New("--id is required")
+	}
+	return options, nil
+}
+
+// RunReplay runs the replay command against the configured database.
+func RunReplay(ctx context.Context, args []string, stdout io.Writer) error {
+	options, err := ParseReplayOptions(args, stdout)
+	if err != nil {
+		return err
+	}
+
+	conn, err := openCommandDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	return Replay(ctx, database.NewPaymentRepository(conn.DB), options, stdout)
+}
+
+// Replay rebuilds the projection of a payment from its event stream and reports snapshots that do
+// not match the replayed state. Corrupted snapshots are repaired unless the run is dry.
+func Replay(ctx context.Context, repository payment.Repository, options ReplayOptions, stdout io.Writer) error {
+	result, err := payment.RebuildPayment(ctx, repository, options.ID, options.DryRun)
+	if err != nil {
+		return fmt.Errorf("failed to replay payment %s: %w", options.ID, err)
+	}
+
+	p := result.Payment
+	fmt.Fprintf(stdout, "payment %s: replayed %d events to version %d, status %s\n",
+		p.ID(), result.Events, p.Version(), p.Status())
+	fmt.Fprintf(stdout, "snapshots: %d checked, %d corrupted\n", result.Snapshots, len(result.Mismatches))
+
+	outcome := "repaired"
+	if options.DryRun {
+		outcome = "not repaired"
+	}
+	for _, mismatch := range result.Mismatches {
+		fmt.Fprintf(stdout, "  version %d differs in %s (%s)\n",
+			mismatch.Version, strings.Join(mismatch.Fields, ", "), outcome)
+	}
+
+	if options.DryRun {
+		if result.Corrupted() {
+			return ErrCorruptedSnapshots
+		}
+		return nil
+	}
+	fmt.Fprintln(stdout, "projection rebuilt")
+	return nil
+}
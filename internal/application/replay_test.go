@@ -0,0 +1,77 @@
+package application_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/application"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/pkg/config"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestReplay(t *testing.T) {
+	t.Run("ParseReplayOptions", func(t *testing.T) {
+		options, err := application.ParseReplayOptions(
+			[]string{"--aggregate", "payment", "--id", "p-1", "--dry-run"}, io.Discard)
+		require.NoError(t, err)
+		require.Equal(t, application.ReplayOptions{Aggregate: "payment", ID: "p-1", DryRun: true}, options)
+
+		_, err = application.ParseReplayOptions([]string{"--aggregate", "invoice", "--id", "i-1"}, io.Discard)
+		require.Error(t, err)
+		_, err = application.ParseReplayOptions([]string{"--aggregate", "payment"}, io.Discard)
+		require.Error(t, err)
+	})
+
+	t.Run("Replay", func(t *testing.T) {
+		conn, err := database.NewConnection(config.DatabaseConfig{URL: "file::memory:"}, zap.NewNop())
+		require.NoError(t, err)
+		require.NoError(t, conn.Migrate())
+		repo := database.NewPaymentRepository(conn.DB)
+		ctx := context.Background()
+
+		amount, _ := shared.NewMoneyWithCrypto("100.00", shared.CryptoCurrencyUSDT)
+		paymentAmount, _ := payment.NewPaymentAmount(amount, shared.CryptoCurrencyUSDT)
+		toAddress, _ := payment.NewPaymentAddress("TTestAddress123456789012345678901234567890", shared.NetworkTron)
+		transactionHash, _ := payment.NewTransactionHash(
+			"0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+		p, err := payment.NewPayment("replay-payment", "replay-invoice", paymentAmount,
+			"TSenderAddress123456789012345678901234567890", toAddress, transactionHash, 3)
+		require.NoError(t, err)
+		for count := 1; p.Version() < payment.SnapshotInterval; count++ {
+			require.NoError(t, p.UpdateConfirmations(nil, count))
+		}
+		require.NoError(t, repo.Save(ctx, p))
+
+		// Corrupt the snapshot
+		require.NoError(t, conn.DB.Model(&database.PaymentSnapshotModel{}).
+			Where("payment_id = ?", p.ID()).
+			Update("state", `{"status":"failed"}`).Error)
+
+		options := application.ReplayOptions{Aggregate: application.AggregatePayment, ID: "replay-payment", DryRun: true}
+		var out bytes.Buffer
+		err = application.Replay(ctx, repo, options, &out)
+		require.ErrorIs(t, err, application.ErrCorruptedSnapshots)
+		require.Contains(t, out.String(), "snapshots: 1 checked, 1 corrupted")
+		require.Contains(t, out.String(), "(not repaired)")
+
+		out.Reset()
+		options.DryRun = false
+		require.NoError(t, application.Replay(ctx, repo, options, &out))
+		require.Contains(t, out.String(), "(repaired)")
+		require.Contains(t, out.String(), "projection rebuilt")
+
+		out.Reset()
+		options.DryRun = true
+		require.NoError(t, application.Replay(ctx, repo, options, &out))
+		require.Contains(t, out.String(), "snapshots: 1 checked, 0 corrupted")
+
+		options.ID = "missing"
+		require.ErrorIs(t, application.Replay(ctx, repo, options, &out), payment.ErrPaymentNotFound)
+	})
+}
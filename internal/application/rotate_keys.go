@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/internal/infrastructure/secrets"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+)
+
+// RotateKeysOptions holds the arguments of the rotate-keys command.
+type RotateKeysOptions struct {
+	DryRun bool
+}
+
+// ParseRotateKeysOptions parses the arguments of the rotate-keys command.
+func ParseRotateKeysOptions(args []string, output io.Writer) (RotateKeysOptions, error) {
+	var options RotateKeysOptions
+	flags := flag.NewFlagSet("rotate-keys", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.BoolVar(&options.DryRun, "dry-run", false, "Only count the values not yet encrypted with the current key")
+	if err := flags.Parse(args); err != nil {
+		return options, err
+	}
+	return options, nil
+}
+
+// RunRotateKeys runs the rotate-keys command against the configured database. It encrypts every sensitive value
+// with the first key of the key file: values encrypted with an older key and values stored before encryption was
+// enabled. Once it reports nothing left to rotate, older keys can be removed from the key file.
+func RunRotateKeys(ctx context.Context, args []string, stdout io.Writer) error {
+	options, err := ParseRotateKeysOptions(args, stdout)
+	if err != nil {
+		return err
+	}
+
+	cfg, _, err := secrets.LoadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	keyring, err := database.NewColumnKeyring(cfg.Encryption)
+	if err != nil {
+		return err
+	}
+	if keyring == nil {
+		return errors.New("encryption.keys or encryption.key_file must be set to rotate encrypted columns")
+	}
+
+	conn, err := database.NewDatabaseConnection(cfg, NewLogger(cfg))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	counts, err := database.RotateEncryptedColumns(ctx, conn.DB, keyring, options.DryRun)
+	if options.DryRun {
+		fmt.Fprintf(stdout, "values to encrypt with key %s:\n", keyring.CurrentKeyID())
+	} else {
+		fmt.Fprintf(stdout, "values encrypted with key %s:\n", keyring.CurrentKeyID())
+	}
+	printCounts(stdout, counts)
+	return err
+}
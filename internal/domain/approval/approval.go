@@ -0,0 +1,245 @@
+package approval
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Vote is one approver's decision on a transfer.
+type Vote struct {
+	UserID   string    `json:"user_id"`
+	Decision Decision  `json:"decision"`
+	Note     string    `json:"note,omitempty"`
+	VotedAt  time.Time `json:"voted_at"`
+}
+
+// Approval holds an outgoing transfer until enough of the merchant's approvers have signed off on it.
+// The transfer is approved once the required number of approvers approve it, and rejected as soon as
+// any approver rejects it. Whoever requested the transfer cannot vote on it.
+type Approval struct {
+	id          string
+	merchantID  string
+	kind        Kind
+	reference   string
+	currency    string
+	amount      decimal.Decimal
+	reason      string
+	requestedBy string
+	required    int
+	status      Status
+	votes       []Vote
+	createdAt   time.Time
+	decidedAt   *time.Time
+	version     int
+}
+
+// NewApproval creates a pending approval for a transfer. The reference identifies what the transfer
+// is for: the invoice of a refund or the payout itself.
+func NewApproval(
+	id, merchantID string,
+	kind Kind,
+	reference, currency string,
+	amount decimal.Decimal,
+	reason, requestedBy string,
+	required int,
+) (*Approval, error) {
+	return RestoreApproval(
+		id, merchantID, kind, reference, currency, amount, reason, requestedBy, required, StatusPending, nil,
+		shared.Now().UTC(), nil, 0,
+	)
+}
+
+// RestoreApproval recreates an approval from storage at the version it was stored with.
+func RestoreApproval(
+	id, merchantID string,
+	kind Kind,
+	reference, currency string,
+	amount decimal.Decimal,
+	reason, requestedBy string,
+	required int,
+	status Status,
+	votes []Vote,
+	createdAt time.Time,
+	decidedAt *time.Time,
+	version int,
+) (*Approval, error) {
+	if id == "" {
+		return nil, errors.New("approval ID is required")
+	}
+	if merchantID == "" || reference == "" || currency == "" {
+		return nil, fmt.Errorf("%w: merchant, reference and currency are required", ErrInvalidRequest)
+	}
+	if !kind.IsValid() {
+		return nil, fmt.Errorf("%w: invalid kind %s", ErrInvalidRequest, kind)
+	}
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: transfer amount must be positive", ErrInvalidRequest)
+	}
+	if required < 1 {
+		return nil, fmt.Errorf("%w: at least one approval is required", ErrInvalidRequest)
+	}
+	if !status.IsValid() {
+		return nil, errors.New("invalid approval status")
+	}
+
+	return &Approval{
+		id:          id,
+		merchantID:  merchantID,
+		kind:        kind,
+		reference:   reference,
+		currency:    currency,
+		amount:      amount,
+		reason:      reason,
+		requestedBy: requestedBy,
+		required:    required,
+		status:      status,
+		votes:       votes,
+		createdAt:   createdAt,
+		decidedAt:   decidedAt,
+		version:     version,
+	}, nil
+}
+
+// ID returns the approval ID.
+func (a *Approval) ID() string {
+	return a.id
+}
+
+// MerchantID returns the merchant whose funds the transfer moves.
+func (a *Approval) MerchantID() string {
+	return a.merchantID
+}
+
+// Kind returns the kind of transfer awaiting approval.
+func (a *Approval) Kind() Kind {
+	return a.kind
+}
+
+// Reference returns the invoice of a refund or the payout awaiting approval.
+func (a *Approval) Reference() string {
+	return a.reference
+}
+
+// Currency returns the transfer currency.
+func (a *Approval) Currency() string {
+	return a.currency
+}
+
+// Amount returns the transfer amount.
+func (a *Approval) Amount() decimal.Decimal {
+	return a.amount
+}
+
+// Reason returns why the transfer was requested, such as a refund reason.
+func (a *Approval) Reason() string {
+	return a.reason
+}
+
+// RequestedBy returns who requested the transfer; empty for transfers the system started.
+func (a *Approval) RequestedBy() string {
+	return a.requestedBy
+}
+
+// Required returns how many approvers must approve the transfer.
+func (a *Approval) Required() int {
+	return a.required
+}
+
+// Status returns where the approval stands.
+func (a *Approval) Status() Status {
+	return a.status
+}
+
+// Votes returns the votes cast so far, in order.
+func (a *Approval) Votes() []Vote {
+	votes := make([]Vote, len(a.votes))
+	copy(votes, a.votes)
+	return votes
+}
+
+// Approvals returns how many approvers have approved the transfer.
+func (a *Approval) Approvals() int {
+	approvals := 0
+	for _, vote := range a.votes {
+		if vote.Decision == DecisionApprove {
+			approvals++
+		}
+	}
+	return approvals
+}
+
+// CreatedAt returns when the approval was requested.
+func (a *Approval) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+// DecidedAt returns when the approval was approved or rejected.
+func (a *Approval) DecidedAt() *time.Time {
+	return a.decidedAt
+}
+
+// Version returns the version the approval was loaded or last stored with. An update only applies while the
+// stored approval is still at this version, so that concurrent votes cannot overwrite each other.
+func (a *Approval) Version() int {
+	return a.version
+}
+
+// SetVersion records the version the approval was stored with.
+func (a *Approval) SetVersion(version int) {
+	a.version = version
+}
+
+// IsPending returns true if the approval still awaits votes.
+func (a *Approval) IsPending() bool {
+	return a.status == StatusPending
+}
+
+// Vote records an approver's decision, approving the transfer when it reaches quorum and rejecting
+// it on any rejection.
+func (a *Approval) Vote(userID string, decision Decision, note string) error {
+	if !a.IsPending() {
+		return ErrApprovalDecided
+	}
+	if userID == "" {
+		return fmt.Errorf("%w: approver is required", ErrInvalidRequest)
+	}
+	if !decision.IsValid() {
+		return fmt.Errorf("%w: invalid decision %s", ErrInvalidRequest, decision)
+	}
+	if userID == a.requestedBy {
+		return ErrSelfApproval
+	}
+	for _, vote := range a.votes {
+		if vote.UserID == userID {
+			return ErrAlreadyVoted
+		}
+	}
+
+	now := shared.Now().UTC()
+	a.votes = append(a.votes, Vote{UserID: userID, Decision: decision, Note: note, VotedAt: now})
+
+	switch {
+	case decision == DecisionReject:
+		a.status = StatusRejected
+		a.decidedAt = &now
+	case a.Approvals() >= a.required:
+		a.status = StatusApproved
+		a.decidedAt = &now
+	}
+	return nil
+}
+
+// Reopen withdraws the vote that approved the transfer and returns the approval to pending, for when the
+// approved transfer could not be carried out.
+func (a *Approval) Reopen() {
+	if a.status != StatusApproved || len(a.votes) == 0 {
+		return
+	}
+	a.votes = a.votes[:len(a.votes)-1]
+	a.status = StatusPending
+	a.decidedAt = nil
+}
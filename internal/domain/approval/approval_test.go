@@ -0,0 +1,250 @@
+package approval
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestApproval_VoteReachesQuorum(t *testing.T) {
+	approval, err := NewApproval(
+		"approval-1", "merchant-1", KindRefund, "invoice-1", "USDT", decimal.NewFromInt(12000), "damaged", "user-1", 2,
+	)
+	require.NoError(t, err)
+	assert.True(t, approval.IsPending())
+
+	require.ErrorIs(t, approval.Vote("user-1", DecisionApprove, ""), ErrSelfApproval)
+	require.NoError(t, approval.Vote("user-2", DecisionApprove, ""))
+	require.ErrorIs(t, approval.Vote("user-2", DecisionApprove, ""), ErrAlreadyVoted)
+	assert.True(t, approval.IsPending())
+	assert.Equal(t, 1, approval.Approvals())
+
+	require.NoError(t, approval.Vote("user-3", DecisionApprove, "confirmed with support"))
+	assert.Equal(t, StatusApproved, approval.Status())
+	assert.NotNil(t, approval.DecidedAt())
+	require.ErrorIs(t, approval.Vote("user-4", DecisionReject, ""), ErrApprovalDecided)
+}
+
+func TestApproval_SingleRejectionRejects(t *testing.T) {
+	approval, err := NewApproval(
+		"approval-1", "merchant-1", KindPayout, "payout-1", "USDT", decimal.NewFromInt(50000), "", "", 3,
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, approval.Vote("user-1", DecisionApprove, ""))
+	require.NoError(t, approval.Vote("user-2", DecisionReject, "unexpected wallet"))
+	assert.Equal(t, StatusRejected, approval.Status())
+	assert.Len(t, approval.Votes(), 2)
+}
+
+func TestNewApproval_Validation(t *testing.T) {
+	_, err := NewApproval(
+		"approval-1", "merchant-1", Kind("withdrawal"), "ref", "USDT", decimal.NewFromInt(1), "", "", 2,
+	)
+	require.ErrorIs(t, err, ErrInvalidRequest)
+
+	_, err = NewApproval("approval-1", "merchant-1", KindPayout, "ref", "USDT", decimal.Zero, "", "", 2)
+	require.ErrorIs(t, err, ErrInvalidRequest)
+
+	_, err = NewApproval("approval-1", "merchant-1", KindPayout, "ref", "USDT", decimal.NewFromInt(1), "", "", 0)
+	require.ErrorIs(t, err, ErrInvalidRequest)
+}
+
+type fakeRepository struct {
+	approvals map[string]*Approval
+	// beforeUpdate, when set, runs once before the next update is stored, as a concurrent request would.
+	beforeUpdate func()
+}
+
+func (r *fakeRepository) Save(_ context.Context, approval *Approval) error {
+	r.approvals[approval.ID()] = approval
+	return nil
+}
+
+// FindByID returns a copy, as a database would, so votes are kept only once they are updated.
+func (r *fakeRepository) FindByID(_ context.Context, id string) (*Approval, error) {
+	a, ok := r.approvals[id]
+	if !ok {
+		return nil, ErrApprovalNotFound
+	}
+	return RestoreApproval(
+		a.ID(), a.MerchantID(), a.Kind(), a.Reference(), a.Currency(), a.Amount(), a.Reason(), a.RequestedBy(),
+		a.Required(), a.Status(), a.Votes(), a.CreatedAt(), a.DecidedAt(), a.Version(),
+	)
+}
+
+func (r *fakeRepository) FindLatestByReference(_ context.Context, kind Kind, reference string) (*Approval, error) {
+	for _, approval := range r.approvals {
+		if approval.Kind() == kind && approval.Reference() == reference {
+			return approval, nil
+		}
+	}
+	return nil, ErrApprovalNotFound
+}
+
+func (r *fakeRepository) List(_ context.Context, req *ListApprovalsRequest) (*ListApprovalsResponse, error) {
+	return &ListApprovalsResponse{Limit: req.Limit}, nil
+}
+
+func (r *fakeRepository) Update(_ context.Context, approval *Approval) error {
+	if hook := r.beforeUpdate; hook != nil {
+		r.beforeUpdate = nil
+		hook()
+	}
+	if r.approvals[approval.ID()].Version() != approval.Version() {
+		return ErrConcurrentVote
+	}
+	approval.SetVersion(approval.Version() + 1)
+	r.approvals[approval.ID()] = approval
+	return nil
+}
+
+type fakeInvoices struct {
+	invoice.InvoiceService
+	refunds []*invoice.RefundInvoiceRequest
+	err     error
+}
+
+func (f *fakeInvoices) RefundInvoice(_ context.Context, req *invoice.RefundInvoiceRequest) (*invoice.Refund, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.refunds = append(f.refunds, req)
+	amount, err := shared.NewMoneyWithCrypto(req.Amount, shared.CryptoCurrencyUSDT)
+	if err != nil {
+		return nil, err
+	}
+	return invoice.NewRefund("refund-1", amount, req.Reason, req.RequestedBy, time.Now().UTC())
+}
+
+type fakeAudit struct {
+	audit.Service
+	actions []string
+}
+
+func (f *fakeAudit) Record(_ context.Context, req *audit.RecordRequest) error {
+	f.actions = append(f.actions, req.Action)
+	return nil
+}
+
+func newTestService(invoices *fakeInvoices, auditLog *fakeAudit) (Service, *fakeRepository) {
+	repository := &fakeRepository{approvals: map[string]*Approval{}}
+	policy := DefaultPolicy()
+	policy.Thresholds["USDT"] = decimal.NewFromInt(10000)
+	return NewService(repository, invoices, auditLog, policy, zap.NewNop()), repository
+}
+
+func TestService_RequireHoldsTransfersOverTheThreshold(t *testing.T) {
+	ctx := context.Background()
+	auditLog := &fakeAudit{}
+	service, repository := newTestService(&fakeInvoices{}, auditLog)
+
+	small, err := service.Require(ctx, &TransferRequest{
+		MerchantID: "merchant-1", Kind: KindPayout, Reference: "payout-1", Currency: "USDT",
+		Amount: decimal.NewFromInt(9999),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, small)
+
+	request := &TransferRequest{
+		MerchantID: "merchant-1", Kind: KindPayout, Reference: "payout-2", Currency: "USDT",
+		Amount: decimal.NewFromInt(10000),
+	}
+	held, err := service.Require(ctx, request)
+	require.NoError(t, err)
+	require.NotNil(t, held)
+	assert.Equal(t, StatusPending, held.Status())
+	assert.Equal(t, 2, held.Required())
+
+	// The same payout is not put to the approvers twice
+	again, err := service.Require(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, held.ID(), again.ID())
+	assert.Len(t, repository.approvals, 1)
+	assert.Equal(t, []string{"approval.requested"}, auditLog.actions)
+
+	_, err = service.Approve(ctx, &VoteRequest{MerchantID: "merchant-2", ApprovalID: held.ID(), UserID: "user-1"})
+	require.ErrorIs(t, err, ErrApprovalNotFound)
+}
+
+func TestService_ApproveRecordsRefundAtQuorum(t *testing.T) {
+	ctx := context.Background()
+	invoices := &fakeInvoices{}
+	auditLog := &fakeAudit{}
+	service, repository := newTestService(invoices, auditLog)
+
+	held, err := NewApproval(
+		"approval-1", "merchant-1", KindRefund, "invoice-1", "USDT", decimal.NewFromInt(12000), "damaged", "user-1", 2,
+	)
+	require.NoError(t, err)
+	require.NoError(t, repository.Save(ctx, held))
+
+	vote := func(userID string) (*Approval, error) {
+		return service.Approve(ctx, &VoteRequest{MerchantID: "merchant-1", ApprovalID: held.ID(), UserID: userID})
+	}
+
+	_, err = vote("user-2")
+	require.NoError(t, err)
+	assert.Empty(t, invoices.refunds)
+
+	t.Run("refund the invoice no longer allows leaves the approval pending", func(t *testing.T) {
+		invoices.err = invoice.ErrRefundExceedsPaid
+		_, err := vote("user-3")
+		require.ErrorIs(t, err, ErrExecutionFailed)
+		require.ErrorIs(t, err, invoice.ErrRefundExceedsPaid)
+		invoices.err = nil
+	})
+
+	approved, err := vote("user-3")
+	require.NoError(t, err)
+	assert.Equal(t, StatusApproved, approved.Status())
+	require.Len(t, invoices.refunds, 1)
+	assert.Equal(t, "invoice-1", invoices.refunds[0].InvoiceID)
+	assert.Equal(t, "12000", invoices.refunds[0].Amount)
+	assert.Equal(t, "user-1", invoices.refunds[0].RequestedBy)
+	assert.Equal(t, []string{"approval.approve", "approval.approve"}, auditLog.actions)
+}
+
+func TestService_ConcurrentVotesRecordOneRefund(t *testing.T) {
+	ctx := context.Background()
+	invoices := &fakeInvoices{}
+	service, repository := newTestService(invoices, &fakeAudit{})
+
+	held, err := NewApproval(
+		"approval-1", "merchant-1", KindRefund, "invoice-1", "USDT", decimal.NewFromInt(12000), "damaged", "user-1", 2,
+	)
+	require.NoError(t, err)
+	require.NoError(t, repository.Save(ctx, held))
+
+	vote := func(userID string) (*Approval, error) {
+		return service.Approve(ctx, &VoteRequest{MerchantID: "merchant-1", ApprovalID: held.ID(), UserID: userID})
+	}
+	_, err = vote("user-2")
+	require.NoError(t, err)
+
+	// Both approvers load the approval one vote short of quorum; user-4's vote is stored while user-3's is in flight
+	var concurrent *Approval
+	var concurrentErr error
+	repository.beforeUpdate = func() {
+		concurrent, concurrentErr = vote("user-4")
+	}
+	_, err = vote("user-3")
+	require.ErrorIs(t, err, ErrConcurrentVote)
+
+	require.NoError(t, concurrentErr)
+	assert.Equal(t, StatusApproved, concurrent.Status())
+	require.Len(t, invoices.refunds, 1)
+
+	stored, err := repository.FindByID(ctx, held.ID())
+	require.NoError(t, err)
+	assert.Equal(t, StatusApproved, stored.Status())
+	assert.Equal(t, 2, stored.Approvals())
+}
@@ -0,0 +1,15 @@
+package approval
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the approval service layer dependencies.
+var Module = fx.Module("approval-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+)
@@ -0,0 +1,55 @@
+// Package approval provides the M-of-N sign-off that refunds and payouts over a configured threshold
+// need from a merchant's team before their funds leave.
+package approval
+
+// Kind identifies the outgoing transfer an approval is for.
+type Kind string
+
+const (
+	// KindRefund - Refund of a paid invoice, recorded once approved
+	KindRefund Kind = "refund"
+	// KindPayout - Payout of settled funds, broadcast once approved
+	KindPayout Kind = "payout"
+)
+
+// IsValid returns true if the approval kind is valid.
+func (k Kind) IsValid() bool {
+	return k == KindRefund || k == KindPayout
+}
+
+// Status represents where an approval stands.
+type Status string
+
+const (
+	// StatusPending - Approval awaits votes; the transfer is held
+	StatusPending Status = "pending"
+	// StatusApproved - Quorum reached; the transfer proceeds
+	StatusApproved Status = "approved"
+	// StatusRejected - An approver rejected the transfer; it does not proceed
+	StatusRejected Status = "rejected"
+)
+
+// IsValid returns true if the approval status is valid.
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusApproved, StatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// Decision is an approver's vote on a transfer.
+type Decision string
+
+const (
+	// DecisionApprove - Approver signs off on the transfer
+	DecisionApprove Decision = "approve"
+	// DecisionReject - Approver vetoes the transfer
+	DecisionReject Decision = "reject"
+)
+
+// IsValid returns true if the decision is valid.
+func (d Decision) IsValid() bool {
+	return d == DecisionApprove || d == DecisionReject
+}
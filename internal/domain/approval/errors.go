@@ -0,0 +1,24 @@
+package approval
+
+import "errors"
+
+// Domain errors for approval operations
+var (
+	ErrApprovalNotFound = errors.New("approval not found")
+	ErrApprovalDecided  = errors.New("approval is already decided")
+	ErrSelfApproval     = errors.New("the requester of a transfer cannot vote on it")
+	ErrAlreadyVoted     = errors.New("approver has already voted on this transfer")
+	ErrExecutionFailed  = errors.New("approved transfer could not be carried out")
+	ErrInvalidRequest   = errors.New("invalid approval request")
+	ErrConcurrentVote   = errors.New("approval was changed by another vote")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeApprovalNotFound = "APPROVAL_NOT_FOUND"
+	ErrCodeApprovalDecided  = "APPROVAL_DECIDED"
+	ErrCodeSelfApproval     = "SELF_APPROVAL"
+	ErrCodeAlreadyVoted     = "ALREADY_VOTED"
+	ErrCodeExecutionFailed  = "APPROVAL_EXECUTION_FAILED"
+	ErrCodeConcurrentVote   = "APPROVAL_CONCURRENT_VOTE"
+)
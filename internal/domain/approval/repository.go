@@ -0,0 +1,44 @@
+package approval
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+)
+
+// Repository defines the interface for approval persistence.
+type Repository interface {
+	// Save persists a new approval.
+	Save(ctx context.Context, approval *Approval) error
+
+	// FindByID retrieves an approval by its ID.
+	FindByID(ctx context.Context, id string) (*Approval, error)
+
+	// FindLatestByReference retrieves the most recent approval of the given kind for a reference.
+	FindLatestByReference(ctx context.Context, kind Kind, reference string) (*Approval, error)
+
+	// List retrieves approvals matching the filter, newest first.
+	List(ctx context.Context, req *ListApprovalsRequest) (*ListApprovalsResponse, error)
+
+	// Update updates an existing approval and advances its version. It fails with ErrConcurrentVote if the
+	// stored approval is no longer at the version it was loaded with.
+	Update(ctx context.Context, approval *Approval) error
+}
+
+// ListApprovalsRequest represents the request to list a merchant's approvals.
+// Empty filter fields match all approvals.
+type ListApprovalsRequest struct {
+	MerchantID string
+	Kind       Kind
+	Status     Status
+	Limit      int
+	// Cursor continues a listing after the page that returned it.
+	Cursor *shared.Cursor
+}
+
+// ListApprovalsResponse represents the response from listing approvals.
+type ListApprovalsResponse struct {
+	Approvals []*Approval
+	Limit     int
+	// NextCursor continues the listing after this page, or is nil on the last page.
+	NextCursor *shared.Cursor
+}
@@ -0,0 +1,402 @@
+package approval
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultListLimit is the default page size for listing approvals.
+	defaultListLimit = 20
+	// maxListLimit is the maximum page size for listing approvals.
+	maxListLimit = 100
+)
+
+// Policy configures which transfers need approval and how many approvers must sign off.
+type Policy struct {
+	// Required is how many approvers must approve a transfer (the M of M-of-N).
+	Required int
+	// Thresholds are, per currency, the smallest transfer that needs approval. Transfers in a
+	// currency without a threshold never need approval.
+	Thresholds map[string]decimal.Decimal
+}
+
+// DefaultPolicy requires two approvals and has no thresholds, so no transfer needs approval.
+func DefaultPolicy() Policy {
+	return Policy{
+		Required:   2,
+		Thresholds: make(map[string]decimal.Decimal),
+	}
+}
+
+// Requires returns true if a transfer of the amount needs approval.
+func (p Policy) Requires(currency string, amount decimal.Decimal) bool {
+	threshold, ok := p.Thresholds[currency]
+	return ok && amount.GreaterThanOrEqual(threshold)
+}
+
+// Service defines the interface for transfer approvals.
+type Service interface {
+	// RequestRefund checks a refund and, when it is over the threshold, holds it for approval. It
+	// returns nil when the refund needs no approval and may be recorded right away.
+	RequestRefund(ctx context.Context, req *RefundRequest) (*Approval, error)
+
+	// Require returns the approval a transfer needs before it is broadcast, requesting it when there is
+	// none yet. It returns nil when the transfer is below the threshold.
+	Require(ctx context.Context, req *TransferRequest) (*Approval, error)
+
+	// GetApproval retrieves a merchant's approval.
+	GetApproval(ctx context.Context, req *GetApprovalRequest) (*Approval, error)
+
+	// ListApprovals lists a merchant's approvals.
+	ListApprovals(ctx context.Context, req *ListApprovalsRequest) (*ListApprovalsResponse, error)
+
+	// Approve records an approver's approval. A refund is recorded once the approval reaches quorum.
+	Approve(ctx context.Context, req *VoteRequest) (*Approval, error)
+
+	// Reject records an approver's rejection, which rejects the transfer.
+	Reject(ctx context.Context, req *VoteRequest) (*Approval, error)
+}
+
+// RefundRequest represents a refund a merchant asked for.
+type RefundRequest struct {
+	MerchantID string `validate:"required"`
+	InvoiceID  string `validate:"required"`
+	// Amount is the amount to refund in the invoice cryptocurrency; empty refunds the remaining amount.
+	Amount    string
+	Reason    string
+	Requester audit.Actor
+}
+
+// TransferRequest represents an outgoing transfer the system is about to broadcast.
+type TransferRequest struct {
+	MerchantID string          `validate:"required"`
+	Kind       Kind            `validate:"required"`
+	Reference  string          `validate:"required"`
+	Currency   string          `validate:"required"`
+	Amount     decimal.Decimal `validate:"-"`
+}
+
+// GetApprovalRequest represents the request to get an approval.
+type GetApprovalRequest struct {
+	MerchantID string `validate:"required"`
+	ApprovalID string `validate:"required"`
+}
+
+// VoteRequest represents an approver's decision on a transfer.
+type VoteRequest struct {
+	MerchantID string `validate:"required"`
+	ApprovalID string `validate:"required"`
+	UserID     string `validate:"required"`
+	Note       string `validate:"max=1000"`
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository     Repository
+	invoiceService invoice.InvoiceService
+	auditService   audit.Service
+	policy         Policy
+	logger         *zap.Logger
+}
+
+// NewService creates a new approval service.
+func NewService(
+	repository Repository,
+	invoiceService invoice.InvoiceService,
+	auditService audit.Service,
+	policy Policy,
+	logger *zap.Logger,
+) Service {
+	if policy.Required < 1 {
+		policy.Required = DefaultPolicy().Required
+	}
+	if policy.Thresholds == nil {
+		policy.Thresholds = make(map[string]decimal.Decimal)
+	}
+
+	return &ServiceImpl{
+		repository:     repository,
+		invoiceService: invoiceService,
+		auditService:   auditService,
+		policy:         policy,
+		logger:         logger,
+	}
+}
+
+// RequestRefund checks the refund against the invoice so a refund that cannot be recorded is refused
+// now rather than after approval.
+func (s *ServiceImpl) RequestRefund(ctx context.Context, req *RefundRequest) (*Approval, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: refund request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	inv, err := s.invoiceService.GetInvoice(ctx, req.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.MerchantID() != req.MerchantID {
+		return nil, shared.ErrNotFound
+	}
+	if err := invoice.CanRefund(inv); err != nil {
+		return nil, invoice.ErrCannotRefundInvoice
+	}
+	if _, err := inv.ConfirmedRefundAddress(); err != nil {
+		return nil, err
+	}
+
+	refundable, err := inv.RefundableAmount()
+	if err != nil {
+		return nil, err
+	}
+	amount := refundable.Amount()
+	if req.Amount != "" {
+		if amount, err = decimal.NewFromString(req.Amount); err != nil || !amount.IsPositive() {
+			return nil, invoice.ErrInvalidRefundAmount
+		}
+		if amount.GreaterThan(refundable.Amount()) {
+			return nil, invoice.ErrRefundExceedsPaid
+		}
+	}
+
+	currency := inv.CryptoCurrency().String()
+	if !s.policy.Requires(currency, amount) {
+		return nil, nil
+	}
+
+	approval, err := NewApproval(
+		uuid.NewString(), req.MerchantID, KindRefund, inv.ID(), currency, amount, req.Reason, req.Requester.ID,
+		s.policy.Required,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repository.Save(ctx, approval); err != nil {
+		return nil, fmt.Errorf("failed to save approval: %w", err)
+	}
+
+	s.logger.Info("Refund held for approval",
+		zap.String("approval_id", approval.ID()),
+		zap.String("invoice_id", inv.ID()),
+		zap.String("amount", amount.String()),
+		zap.String("currency", currency))
+	s.record(ctx, approval, req.Requester, "approval.requested", nil)
+
+	return approval, nil
+}
+
+// Require returns the latest approval of the transfer, so a rejected transfer stays rejected rather
+// than being put to the approvers again.
+func (s *ServiceImpl) Require(ctx context.Context, req *TransferRequest) (*Approval, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: transfer request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	if !s.policy.Requires(req.Currency, req.Amount) {
+		return nil, nil
+	}
+
+	existing, err := s.repository.FindLatestByReference(ctx, req.Kind, req.Reference)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, ErrApprovalNotFound) {
+		return nil, err
+	}
+
+	approval, err := NewApproval(
+		uuid.NewString(), req.MerchantID, req.Kind, req.Reference, req.Currency, req.Amount, "", "",
+		s.policy.Required,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repository.Save(ctx, approval); err != nil {
+		return nil, fmt.Errorf("failed to save approval: %w", err)
+	}
+
+	s.logger.Info("Transfer held for approval",
+		zap.String("approval_id", approval.ID()),
+		zap.String("kind", string(approval.Kind())),
+		zap.String("reference", approval.Reference()),
+		zap.String("amount", approval.Amount().String()),
+		zap.String("currency", approval.Currency()))
+	s.record(ctx, approval, audit.Actor{ID: "system", Type: audit.ActorTypeSystem}, "approval.requested", nil)
+
+	return approval, nil
+}
+
+// GetApproval retrieves a merchant's approval.
+func (s *ServiceImpl) GetApproval(ctx context.Context, req *GetApprovalRequest) (*Approval, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: get approval request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	return s.findMerchantApproval(ctx, req.MerchantID, req.ApprovalID)
+}
+
+// ListApprovals lists a merchant's approvals.
+func (s *ServiceImpl) ListApprovals(ctx context.Context, req *ListApprovalsRequest) (*ListApprovalsResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: list approvals request cannot be nil", ErrInvalidRequest)
+	}
+	if req.MerchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+	if req.Kind != "" && !req.Kind.IsValid() {
+		return nil, fmt.Errorf("%w: invalid kind %s", ErrInvalidRequest, req.Kind)
+	}
+	if req.Status != "" && !req.Status.IsValid() {
+		return nil, fmt.Errorf("%w: invalid status %s", ErrInvalidRequest, req.Status)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	return s.repository.List(ctx, &ListApprovalsRequest{
+		MerchantID: req.MerchantID,
+		Kind:       req.Kind,
+		Status:     req.Status,
+		Limit:      limit,
+		Cursor:     req.Cursor,
+	})
+}
+
+// Approve records an approval. The vote that brings a refund to quorum stores the approved approval before
+// recording the refund, so that of concurrent votes only the one stored first records it. A refund the invoice
+// no longer allows withdraws the vote again, leaving the approval pending for the approvers to reject.
+func (s *ServiceImpl) Approve(ctx context.Context, req *VoteRequest) (*Approval, error) {
+	return s.vote(ctx, req, DecisionApprove)
+}
+
+// Reject records a rejection.
+func (s *ServiceImpl) Reject(ctx context.Context, req *VoteRequest) (*Approval, error) {
+	return s.vote(ctx, req, DecisionReject)
+}
+
+// vote records an approver's decision and carries out a refund that reached quorum.
+func (s *ServiceImpl) vote(ctx context.Context, req *VoteRequest, decision Decision) (*Approval, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: vote request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	approval, err := s.findMerchantApproval(ctx, req.MerchantID, req.ApprovalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := approval.Vote(req.UserID, decision, req.Note); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(ctx, approval); err != nil {
+		return nil, fmt.Errorf("failed to update approval: %w", err)
+	}
+
+	details := make(map[string]interface{})
+	if approval.Status() == StatusApproved && approval.Kind() == KindRefund {
+		refund, err := s.invoiceService.RefundInvoice(ctx, &invoice.RefundInvoiceRequest{
+			InvoiceID:   approval.Reference(),
+			Amount:      approval.Amount().String(),
+			Reason:      approval.Reason(),
+			RequestedBy: approval.RequestedBy(),
+		})
+		if err != nil {
+			s.reopen(ctx, approval)
+			return nil, fmt.Errorf("%w: %w", ErrExecutionFailed, err)
+		}
+		details["refund_id"] = refund.ID()
+	}
+
+	s.logger.Info("Approval vote recorded",
+		zap.String("approval_id", approval.ID()),
+		zap.String("user_id", req.UserID),
+		zap.String("decision", string(decision)),
+		zap.String("status", string(approval.Status())))
+	actor := audit.Actor{ID: req.UserID, Type: audit.ActorTypeUser}
+	s.record(ctx, approval, actor, "approval."+string(decision), details)
+
+	return approval, nil
+}
+
+// reopen withdraws the approving vote of a transfer that could not be carried out. No other vote can have
+// been stored since, as the approval was decided, so this only fails if storage does.
+func (s *ServiceImpl) reopen(ctx context.Context, approval *Approval) {
+	approval.Reopen()
+	if err := s.repository.Update(ctx, approval); err != nil {
+		s.logger.Error("Failed to reopen approval after its transfer failed",
+			zap.String("approval_id", approval.ID()),
+			zap.Error(err))
+	}
+}
+
+// findMerchantApproval retrieves an approval, hiding other merchants' approvals.
+func (s *ServiceImpl) findMerchantApproval(ctx context.Context, merchantID, approvalID string) (*Approval, error) {
+	approval, err := s.repository.FindByID(ctx, approvalID)
+	if err != nil {
+		return nil, err
+	}
+	if approval.MerchantID() != merchantID {
+		return nil, ErrApprovalNotFound
+	}
+	return approval, nil
+}
+
+// record appends an audit entry for an approval, logging rather than failing when it cannot.
+func (s *ServiceImpl) record(
+	ctx context.Context,
+	approval *Approval,
+	actor audit.Actor,
+	action string,
+	details map[string]interface{},
+) {
+	if details == nil {
+		details = make(map[string]interface{})
+	}
+	details["kind"] = string(approval.Kind())
+	details["reference"] = approval.Reference()
+	details["amount"] = approval.Amount().String()
+	details["currency"] = approval.Currency()
+
+	if err := s.auditService.Record(ctx, &audit.RecordRequest{
+		MerchantID:   approval.MerchantID(),
+		Actor:        actor,
+		Action:       action,
+		ResourceType: "approval",
+		ResourceID:   approval.ID(),
+		After: map[string]interface{}{
+			"status":    string(approval.Status()),
+			"approvals": approval.Approvals(),
+			"required":  approval.Required(),
+		},
+		Details: details,
+	}); err != nil {
+		s.logger.Error("Failed to record audit entry",
+			zap.String("approval_id", approval.ID()),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,44 @@
+// Package archive moves terminal invoices that have not changed for months, with their payments and payment
+// events, out of the tables payment processing reads into archive tables. Archived invoices are still found by ID
+// and merchants can export them, but they can no longer change.
+package archive
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"encoding/json"
+	"time"
+)
+
+// ArchivedInvoice is an invoice moved to the archive, with the payments made to it and their events.
+type ArchivedInvoice struct {
+	Invoice       *invoice.Invoice
+	Payments      []Payment
+	PaymentEvents []PaymentEvent
+	ArchivedAt    time.Time
+}
+
+// Payment is a payment of an archived invoice as it was last recorded.
+type Payment struct {
+	ID            string
+	Network       string
+	TxHash        string
+	Amount        string
+	FromAddress   string
+	ToAddress     string
+	Status        string
+	Confirmations int
+	BlockNumber   *int64
+	// NetworkFee is empty when the fee was not recorded.
+	NetworkFee  string
+	DetectedAt  time.Time
+	ConfirmedAt *time.Time
+}
+
+// PaymentEvent is an event of the stream of a payment of an archived invoice.
+type PaymentEvent struct {
+	PaymentID  string
+	Version    int
+	Type       string
+	Data       json.RawMessage
+	OccurredAt time.Time
+}
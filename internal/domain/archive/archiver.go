@@ -0,0 +1,97 @@
+package archive
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Archiver moves terminal invoices due for the archive in the background.
+type Archiver struct {
+	service  Service
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+// NewArchiver creates a new background archiver running at the policy interval.
+func NewArchiver(service Service, policy Policy, logger *zap.Logger) *Archiver {
+	interval := policy.Interval
+	if interval <= 0 {
+		interval = DefaultPolicy().Interval
+	}
+	return &Archiver{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run archives invoices on every interval until the context is cancelled.
+func (a *Archiver) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		shared.Beat(ctx)
+		a.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick performs one archival round.
+func (a *Archiver) tick(ctx context.Context) {
+	archived, err := a.service.ArchiveInvoices(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			a.logger.Error("Failed to archive invoices", zap.Int("archived", archived), zap.Error(err))
+		}
+		return
+	}
+	if archived > 0 {
+		a.logger.Info("Archived invoices", zap.Int("count", archived))
+	}
+}
+
+// RegisterArchiver runs the archiver for the lifetime of the application. Nothing is started unless the policy
+// enables it.
+func RegisterArchiver(
+	lc fx.Lifecycle,
+	archiver *Archiver,
+	policy Policy,
+	workers shared.WorkerSupervisor,
+	logger *zap.Logger,
+) {
+	if !policy.Enabled {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			logger.Info("Starting invoice archiver", zap.Int("after_months", policy.AfterMonths))
+			go func() {
+				defer close(done)
+				workers.Supervise(ctx, "invoice_archiver", archiver.interval, archiver.Run)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,15 @@
+package archive
+
+import "go.uber.org/fx"
+
+// Module provides the archive service layer dependencies.
+var Module = fx.Module("archive-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+		NewArchiver,
+	),
+	fx.Invoke(RegisterArchiver),
+)
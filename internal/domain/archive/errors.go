@@ -0,0 +1,13 @@
+package archive
+
+import "errors"
+
+// Domain errors for archive operations
+var (
+	ErrInvalidRequest = errors.New("invalid archive request")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeInvalidRequest = "INVALID_ARCHIVE_REQUEST"
+)
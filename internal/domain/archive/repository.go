@@ -0,0 +1,36 @@
+package archive
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+)
+
+// Repository defines the interface for archive persistence.
+type Repository interface {
+	// ArchiveInvoices moves up to limit terminal invoices last updated before the cutoff, with their payments and
+	// payment events, to the archive in one transaction, and returns how many it moved.
+	ArchiveInvoices(ctx context.Context, cutoff time.Time, limit int) (int, error)
+
+	// List retrieves the archived invoices of a merchant, newest first by creation time.
+	List(ctx context.Context, req *ListArchivedInvoicesRequest) (*ListArchivedInvoicesResponse, error)
+}
+
+// ListArchivedInvoicesRequest represents the request to list a merchant's archived invoices. Nil bounds leave the
+// creation time open on that side.
+type ListArchivedInvoicesRequest struct {
+	MerchantID  string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Limit       int
+	// Cursor continues a listing after the page that returned it.
+	Cursor *shared.Cursor
+}
+
+// ListArchivedInvoicesResponse represents the response from listing archived invoices.
+type ListArchivedInvoicesResponse struct {
+	Invoices []*ArchivedInvoice
+	Limit    int
+	// NextCursor continues the listing after this page, or is nil on the last page.
+	NextCursor *shared.Cursor
+}
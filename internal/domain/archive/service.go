@@ -0,0 +1,119 @@
+package archive
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Limits of a page of archived invoices.
+const (
+	DefaultListLimit = 100
+	MaxListLimit     = 500
+)
+
+// Policy configures when terminal invoices are archived.
+type Policy struct {
+	// Enabled runs the archiver in the background.
+	Enabled bool
+	// AfterMonths is how many months a terminal invoice stays unchanged before it is archived.
+	AfterMonths int
+	// Interval is how often invoices due for the archive are looked for.
+	Interval time.Duration
+	// BatchSize is how many invoices are moved in one transaction.
+	BatchSize int
+}
+
+// DefaultPolicy archives invoices left unchanged for a year, checking hourly in batches of 500, once enabled.
+func DefaultPolicy() Policy {
+	return Policy{
+		AfterMonths: 12,
+		Interval:    time.Hour,
+		BatchSize:   500,
+	}
+}
+
+// Cutoff returns the time before which terminal invoices last changed are archived.
+func (p Policy) Cutoff(now time.Time) time.Time {
+	return now.AddDate(0, -p.AfterMonths, 0)
+}
+
+// Service defines the interface for archive operations.
+type Service interface {
+	// ArchiveInvoices moves every terminal invoice due for the archive, batch by batch, and returns how many it
+	// moved.
+	ArchiveInvoices(ctx context.Context) (int, error)
+
+	// ListArchivedInvoices lists a page of a merchant's archived invoices, newest first.
+	ListArchivedInvoices(ctx context.Context, req *ListArchivedInvoicesRequest) (*ListArchivedInvoicesResponse, error)
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository Repository
+	policy     Policy
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewService creates a new archive service.
+func NewService(repository Repository, policy Policy, logger *zap.Logger) Service {
+	defaults := DefaultPolicy()
+	if policy.AfterMonths <= 0 {
+		policy.AfterMonths = defaults.AfterMonths
+	}
+	if policy.Interval <= 0 {
+		policy.Interval = defaults.Interval
+	}
+	if policy.BatchSize <= 0 {
+		policy.BatchSize = defaults.BatchSize
+	}
+	return &ServiceImpl{
+		repository: repository,
+		policy:     policy,
+		logger:     logger,
+		now:        shared.Now,
+	}
+}
+
+// ArchiveInvoices moves every terminal invoice due for the archive, batch by batch, and returns how many it moved.
+func (s *ServiceImpl) ArchiveInvoices(ctx context.Context) (int, error) {
+	cutoff := s.policy.Cutoff(s.now().UTC())
+	archived := 0
+	for {
+		moved, err := s.repository.ArchiveInvoices(ctx, cutoff, s.policy.BatchSize)
+		archived += moved
+		if err != nil {
+			return archived, fmt.Errorf("failed to archive invoices: %w", err)
+		}
+		if moved < s.policy.BatchSize || ctx.Err() != nil {
+			return archived, nil
+		}
+	}
+}
+
+// ListArchivedInvoices lists a page of a merchant's archived invoices, newest first.
+func (s *ServiceImpl) ListArchivedInvoices(
+	ctx context.Context,
+	req *ListArchivedInvoicesRequest,
+) (*ListArchivedInvoicesResponse, error) {
+	if req.MerchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+	if req.CreatedFrom != nil && req.CreatedTo != nil && req.CreatedTo.Before(*req.CreatedFrom) {
+		return nil, fmt.Errorf("%w: created_to is before created_from", ErrInvalidRequest)
+	}
+
+	page := *req
+	if page.Limit <= 0 || page.Limit > MaxListLimit {
+		page.Limit = DefaultListLimit
+	}
+	resp, err := s.repository.List(ctx, &page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived invoices: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,67 @@
+package archive
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubRepository archives from a fixed number of due invoices.
+type stubRepository struct {
+	due     int
+	cutoffs []time.Time
+	listed  *ListArchivedInvoicesRequest
+}
+
+func (r *stubRepository) ArchiveInvoices(_ context.Context, cutoff time.Time, limit int) (int, error) {
+	r.cutoffs = append(r.cutoffs, cutoff)
+	moved := min(r.due, limit)
+	r.due -= moved
+	return moved, nil
+}
+
+func (r *stubRepository) List(_ context.Context, req *ListArchivedInvoicesRequest) (*ListArchivedInvoicesResponse, error) {
+	r.listed = req
+	return &ListArchivedInvoicesResponse{Limit: req.Limit}, nil
+}
+
+func TestServiceArchiveInvoices(t *testing.T) {
+	repo := &stubRepository{due: 5}
+	service := NewService(repo, Policy{AfterMonths: 6, BatchSize: 2}, zap.NewNop()).(*ServiceImpl)
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	archived, err := service.ArchiveInvoices(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 5, archived)
+	// Batches continue until one comes back short, all against the same cutoff
+	require.Len(t, repo.cutoffs, 3)
+	for _, cutoff := range repo.cutoffs {
+		assert.Equal(t, time.Date(2026, 4, 17, 12, 0, 0, 0, time.UTC), cutoff)
+	}
+}
+
+func TestServiceListArchivedInvoices(t *testing.T) {
+	repo := &stubRepository{}
+	service := NewService(repo, Policy{}, zap.NewNop())
+	ctx := context.Background()
+
+	_, err := service.ListArchivedInvoices(ctx, &ListArchivedInvoicesRequest{})
+	require.ErrorIs(t, err, ErrInvalidRequest)
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+	_, err = service.ListArchivedInvoices(ctx, &ListArchivedInvoicesRequest{
+		MerchantID: "merchant-1", CreatedFrom: &from, CreatedTo: &to,
+	})
+	require.ErrorIs(t, err, ErrInvalidRequest)
+
+	resp, err := service.ListArchivedInvoices(ctx, &ListArchivedInvoicesRequest{MerchantID: "merchant-1", Limit: 10000})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultListLimit, resp.Limit)
+	assert.Equal(t, "merchant-1", repo.listed.MerchantID)
+}
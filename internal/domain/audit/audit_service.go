@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Service defines the interface for audit trail operations.
+type Service interface {
+	// Record appends an audit entry for an action.
+	Record(ctx context.Context, req *RecordRequest) error
+
+	// ListEntries lists audit entries for a merchant.
+	ListEntries(ctx context.Context, req *ListEntriesRequest) (*ListEntriesResponse, error)
+}
+
+// RecordRequest represents the request to record an audited action.
+// When Origin is empty it is taken from the context (see WithOrigin).
+type RecordRequest struct {
+	MerchantID   string
+	Actor        Actor
+	Origin       Origin
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Before       map[string]interface{}
+	After        map[string]interface{}
+	Details      map[string]interface{}
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+// NewService creates a new audit service.
+func NewService(repository Repository, logger *zap.Logger) Service {
+	return &ServiceImpl{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// Record appends an audit entry for an action.
+func (s *ServiceImpl) Record(ctx context.Context, req *RecordRequest) error {
+	if req == nil {
+		return fmt.Errorf("%w: record request cannot be nil", ErrInvalidRequest)
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return fmt.Errorf("failed to generate audit entry ID: %w", err)
+	}
+
+	origin := req.Origin
+	if origin.IsZero() {
+		origin = OriginFromContext(ctx)
+	}
+
+	entry, err := NewEntry(
+		id,
+		req.MerchantID,
+		req.Actor,
+		origin,
+		req.Action,
+		req.ResourceType,
+		req.ResourceID,
+		Change{Before: req.Before, After: req.After},
+		req.Details,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+
+	if err := s.repository.Append(ctx, entry); err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+
+	s.logger.Debug("Audit entry recorded",
+		zap.String("merchant_id", entry.MerchantID()),
+		zap.String("actor_id", entry.Actor().ID),
+		zap.String("action", entry.Action()),
+		zap.String("request_id", origin.RequestID),
+	)
+
+	return nil
+}
+
+// ListEntries lists audit entries for a merchant.
+func (s *ServiceImpl) ListEntries(ctx context.Context, req *ListEntriesRequest) (*ListEntriesResponse, error) {
+	if req == nil || req.MerchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+	if req.ActorType != "" && !req.ActorType.IsValid() {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, ErrInvalidActor)
+	}
+	if req.From != nil && req.To != nil && req.From.After(*req.To) {
+		return nil, fmt.Errorf("%w: from must not be after to", ErrInvalidRequest)
+	}
+	if req.Offset < 0 || req.Cursor != nil {
+		req.Offset = 0
+	}
+
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	return s.repository.List(ctx, req)
+}
+
+// generateID generates a random ID.
+func generateID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
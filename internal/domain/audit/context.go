@@ -0,0 +1,17 @@
+package audit
+
+import "context"
+
+// originKey is the context key for the request origin.
+type originKey struct{}
+
+// WithOrigin returns a context carrying the request origin for audit entries recorded downstream.
+func WithOrigin(ctx context.Context, origin Origin) context.Context {
+	return context.WithValue(ctx, originKey{}, origin)
+}
+
+// OriginFromContext returns the request origin stored in the context, if any.
+func OriginFromContext(ctx context.Context) Origin {
+	origin, _ := ctx.Value(originKey{}).(Origin)
+	return origin
+}
@@ -0,0 +1,15 @@
+package audit
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the audit service layer dependencies.
+var Module = fx.Module("audit-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+)
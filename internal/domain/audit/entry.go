@@ -0,0 +1,177 @@
+// Package audit provides the append-only audit trail of actions performed on merchant resources.
+package audit
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"time"
+)
+
+// PlatformMerchantID owns the entries of platform-wide actions that belong to no merchant, such as
+// configuration reloads.
+const PlatformMerchantID = "00000000-0000-0000-0000-000000000000"
+
+// ActorType represents the kind of principal that performed an action.
+type ActorType string
+
+const (
+	// ActorTypeUser - Action performed by a merchant team member
+	ActorTypeUser ActorType = "user"
+	// ActorTypeAPIKey - Action performed via API key
+	ActorTypeAPIKey ActorType = "api_key"
+	// ActorTypeSystem - Action performed by the system
+	ActorTypeSystem ActorType = "system"
+)
+
+// IsValid returns true if the actor type is valid.
+func (t ActorType) IsValid() bool {
+	switch t {
+	case ActorTypeUser, ActorTypeAPIKey, ActorTypeSystem:
+		return true
+	default:
+		return false
+	}
+}
+
+// Actor identifies who performed an audited action.
+type Actor struct {
+	ID   string    `json:"id"`
+	Type ActorType `json:"type"`
+	Role string    `json:"role,omitempty"`
+}
+
+// Origin identifies the request an audited action came from.
+type Origin struct {
+	IPAddress string `json:"ip_address,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+// IsZero returns true if no origin information is set.
+func (o Origin) IsZero() bool {
+	return o == Origin{}
+}
+
+// Change holds snapshots of the affected resource before and after the action.
+type Change struct {
+	Before map[string]interface{} `json:"before,omitempty"`
+	After  map[string]interface{} `json:"after,omitempty"`
+}
+
+// Entry represents a single immutable audit log record.
+type Entry struct {
+	id           string
+	merchantID   string
+	actor        Actor
+	origin       Origin
+	action       string
+	resourceType string
+	resourceID   string
+	change       Change
+	details      map[string]interface{}
+	occurredAt   time.Time
+}
+
+// NewEntry creates a new audit entry occurring now.
+func NewEntry(
+	id, merchantID string,
+	actor Actor,
+	origin Origin,
+	action, resourceType, resourceID string,
+	change Change,
+	details map[string]interface{},
+) (*Entry, error) {
+	return RestoreEntry(
+		id, merchantID, actor, origin, action, resourceType, resourceID, change, details, shared.Now().UTC(),
+	)
+}
+
+// RestoreEntry recreates an audit entry from storage.
+func RestoreEntry(
+	id, merchantID string,
+	actor Actor,
+	origin Origin,
+	action, resourceType, resourceID string,
+	change Change,
+	details map[string]interface{},
+	occurredAt time.Time,
+) (*Entry, error) {
+	if id == "" {
+		return nil, errors.New("audit entry ID is required")
+	}
+	if merchantID == "" {
+		return nil, errors.New("merchant ID is required")
+	}
+	if !actor.Type.IsValid() {
+		return nil, ErrInvalidActor
+	}
+	if action == "" {
+		return nil, errors.New("action is required")
+	}
+	if details == nil {
+		details = make(map[string]interface{})
+	}
+
+	return &Entry{
+		id:           id,
+		merchantID:   merchantID,
+		actor:        actor,
+		origin:       origin,
+		action:       action,
+		resourceType: resourceType,
+		resourceID:   resourceID,
+		change:       change,
+		details:      details,
+		occurredAt:   occurredAt,
+	}, nil
+}
+
+// ID returns the entry ID.
+func (e *Entry) ID() string {
+	return e.id
+}
+
+// MerchantID returns the merchant the action was performed on.
+func (e *Entry) MerchantID() string {
+	return e.merchantID
+}
+
+// Actor returns who performed the action.
+func (e *Entry) Actor() Actor {
+	return e.actor
+}
+
+// Origin returns the request the action came from.
+func (e *Entry) Origin() Origin {
+	return e.origin
+}
+
+// Action returns the action name, e.g. "invoice.cancel".
+func (e *Entry) Action() string {
+	return e.action
+}
+
+// ResourceType returns the affected resource type.
+func (e *Entry) ResourceType() string {
+	return e.resourceType
+}
+
+// ResourceID returns the affected resource ID.
+func (e *Entry) ResourceID() string {
+	return e.resourceID
+}
+
+// Change returns the before and after snapshots of the affected resource.
+func (e *Entry) Change() Change {
+	return e.change
+}
+
+// Details returns additional context for the action.
+func (e *Entry) Details() map[string]interface{} {
+	return e.details
+}
+
+// OccurredAt returns when the action happened.
+func (e *Entry) OccurredAt() time.Time {
+	return e.occurredAt
+}
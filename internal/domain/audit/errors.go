@@ -0,0 +1,10 @@
+package audit
+
+import "errors"
+
+// Domain errors for audit operations
+var (
+	ErrInvalidActor   = errors.New("invalid audit actor")
+	ErrInvalidRequest = errors.New("invalid audit request")
+	ErrImmutable      = errors.New("audit entries are append-only")
+)
@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+)
+
+// Repository defines the interface for append-only audit log persistence.
+type Repository interface {
+	// Append stores a new audit entry. Entries are never updated or deleted.
+	Append(ctx context.Context, entry *Entry) error
+
+	// List retrieves audit entries matching the filter.
+	List(ctx context.Context, req *ListEntriesRequest) (*ListEntriesResponse, error)
+}
+
+// ListEntriesRequest represents the request to list audit entries.
+// Empty filter fields match all entries.
+type ListEntriesRequest struct {
+	MerchantID   string
+	ActorID      string
+	ActorType    ActorType
+	Action       string
+	ResourceType string
+	ResourceID   string
+	RequestID    string
+	From         *time.Time
+	To           *time.Time
+	Limit        int
+	// Cursor continues a listing after the page that returned it; Offset is ignored when it is set.
+	Cursor *shared.Cursor
+	// Deprecated: Offset pages shift when entries are recorded mid-listing; use Cursor.
+	Offset int
+}
+
+// ListEntriesResponse represents the response from listing audit entries.
+type ListEntriesResponse struct {
+	Entries []*Entry
+	Total   int
+	Limit   int
+	Offset  int
+	// NextCursor continues the listing after this page, or is nil on the last page.
+	NextCursor *shared.Cursor
+}
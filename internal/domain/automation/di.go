@@ -0,0 +1,16 @@
+package automation
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the automation rule service layer dependencies and subscribes the rule evaluator to events.
+var Module = fx.Module("automation-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+	fx.Invoke(RegisterRuleEvaluator),
+)
@@ -0,0 +1,18 @@
+package automation
+
+import "errors"
+
+// Domain errors for automation rule operations
+var (
+	ErrRuleNotFound   = errors.New("automation rule not found")
+	ErrNameTaken      = errors.New("an automation rule with this name already exists")
+	ErrTooManyRules   = errors.New("merchant has too many automation rules")
+	ErrInvalidRequest = errors.New("invalid automation rule request")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeRuleNotFound = "AUTOMATION_RULE_NOT_FOUND"
+	ErrCodeNameTaken    = "AUTOMATION_RULE_NAME_TAKEN"
+	ErrCodeTooManyRules = "AUTOMATION_RULE_LIMIT_REACHED"
+)
@@ -0,0 +1,149 @@
+package automation
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Subject is what a rule is evaluated against: the invoice an event concerns and, for payment triggers, the
+// payment.
+type Subject struct {
+	Invoice *invoice.Invoice
+	Payment *payment.Payment // Nil for invoice triggers
+}
+
+// ConditionResult is the outcome of one condition of a rule.
+type ConditionResult struct {
+	Condition Condition
+	Actual    string // The subject's value of the field
+	Present   bool   // Whether the subject has a value for the field; conditions on missing fields never match
+	Matched   bool
+}
+
+// Evaluation is the outcome of a rule against a subject.
+type Evaluation struct {
+	Matched    bool
+	Conditions []ConditionResult
+	Actions    []Action // The actions the rule takes: all of them when it matched, none otherwise
+}
+
+// Evaluate tests the rule's conditions against the subject without acting on it.
+func (r *Rule) Evaluate(subject Subject) *Evaluation {
+	return evaluate(r.definition, subject)
+}
+
+// evaluate tests a definition's conditions against a subject.
+func evaluate(definition Definition, subject Subject) *Evaluation {
+	evaluation := &Evaluation{
+		Matched:    true,
+		Conditions: make([]ConditionResult, len(definition.Conditions)),
+	}
+	for i, condition := range definition.Conditions {
+		actual, present := fieldValue(condition.Field, subject)
+		result := ConditionResult{Condition: condition, Actual: actual, Present: present}
+		result.Matched = present && compare(condition, actual)
+		evaluation.Conditions[i] = result
+		evaluation.Matched = evaluation.Matched && result.Matched
+	}
+	if evaluation.Matched {
+		evaluation.Actions = append([]Action(nil), definition.Actions...)
+	}
+	return evaluation
+}
+
+// fieldValue returns the subject's value of a condition field, and whether it has one.
+func fieldValue(field string, subject Subject) (string, bool) {
+	inv := subject.Invoice
+	if key, ok := strings.CutPrefix(field, metadataFieldPrefix); ok {
+		value, ok := inv.Metadata()[key]
+		if !ok {
+			return "", false
+		}
+		return metadataValue(value)
+	}
+
+	switch field {
+	case FieldTotal:
+		return inv.Pricing().Total().Amount().String(), true
+	case FieldSubtotal:
+		return inv.Pricing().Subtotal().Amount().String(), true
+	case FieldCurrency:
+		return inv.Pricing().Total().Currency(), true
+	case FieldCryptoCurrency:
+		return inv.CryptoCurrency().String(), true
+	case FieldNetwork:
+		network := inv.Network().String()
+		return network, network != ""
+	case FieldStatus:
+		return inv.Status().String(), true
+	case FieldCustomerID:
+		if inv.CustomerID() == nil {
+			return "", false
+		}
+		return *inv.CustomerID(), true
+	case FieldPaymentAmount:
+		if subject.Payment == nil {
+			return "", false
+		}
+		return subject.Payment.Amount().Amount().Amount().String(), true
+	default:
+		return "", false
+	}
+}
+
+// metadataValue returns a scalar metadata value as text. Objects and arrays have no value conditions can test.
+func metadataValue(value interface{}) (string, bool) {
+	switch value := value.(type) {
+	case string:
+		return value, true
+	case bool:
+		return strconv.FormatBool(value), true
+	case float64:
+		return decimal.NewFromFloat(value).String(), true
+	case int:
+		return strconv.Itoa(value), true
+	case int64:
+		return strconv.FormatInt(value, 10), true
+	case json.Number:
+		return value.String(), true
+	default:
+		return "", false
+	}
+}
+
+// compare tests a field value against a condition. Amounts, and values ordered or equal to a decimal, compare as
+// decimals; codes compare case-insensitively.
+func compare(condition Condition, actual string) bool {
+	expected, expectedErr := decimal.NewFromString(condition.Value)
+	value, valueErr := decimal.NewFromString(actual)
+	numeric := expectedErr == nil && valueErr == nil
+
+	switch condition.Operator {
+	case OperatorEqual, OperatorNotEqual:
+		var equal bool
+		switch {
+		case numeric && (numericFields[condition.Field] || strings.HasPrefix(condition.Field, metadataFieldPrefix)):
+			equal = value.Equal(expected)
+		case textFields[condition.Field]:
+			equal = strings.EqualFold(actual, condition.Value)
+		default:
+			equal = actual == condition.Value
+		}
+		return equal == (condition.Operator == OperatorEqual)
+	case OperatorGreaterThan:
+		return numeric && value.GreaterThan(expected)
+	case OperatorGreaterThanOrEqual:
+		return numeric && value.GreaterThanOrEqual(expected)
+	case OperatorLessThan:
+		return numeric && value.LessThan(expected)
+	case OperatorLessThanOrEqual:
+		return numeric && value.LessThanOrEqual(expected)
+	default:
+		return false
+	}
+}
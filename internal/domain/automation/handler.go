@@ -0,0 +1,299 @@
+package automation
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Call is a delivery of a rule's call_url action.
+type Call struct {
+	URL        string
+	Secret     string // Signs the body
+	DeliveryID string // The same for every attempt to deliver an event, so receivers can drop repeats
+	Body       []byte
+}
+
+// Caller delivers the calls of call_url actions.
+type Caller interface {
+	// Call posts the body to the URL, failing unless the receiver accepts it with a 2xx response.
+	Call(ctx context.Context, call *Call) error
+}
+
+// CallPayload is the JSON body call_url actions post.
+type CallPayload struct {
+	RuleID     string          `json:"rule_id"`
+	RuleName   string          `json:"rule_name"`
+	Trigger    Trigger         `json:"trigger"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Invoice    InvoicePayload  `json:"invoice"`
+	Payment    *PaymentPayload `json:"payment,omitempty"`
+}
+
+// InvoicePayload describes the invoice an event concerns in a call.
+type InvoicePayload struct {
+	ID             string                 `json:"id"`
+	Number         string                 `json:"number,omitempty"`
+	Status         string                 `json:"status"`
+	Total          string                 `json:"total"`
+	Currency       string                 `json:"currency"`
+	CryptoCurrency string                 `json:"crypto_currency"`
+	Network        string                 `json:"network,omitempty"`
+	CustomerID     *string                `json:"customer_id,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// PaymentPayload describes the payment a payment event concerns in a call.
+type PaymentPayload struct {
+	ID                    string `json:"id"`
+	Status                string `json:"status"`
+	Amount                string `json:"amount"`
+	Currency              string `json:"currency"`
+	TransactionHash       string `json:"transaction_hash"`
+	Confirmations         int    `json:"confirmations"`
+	RequiredConfirmations int    `json:"required_confirmations"`
+}
+
+// RuleEvaluator evaluates merchants' automation rules on the domain events of their triggers and takes the
+// actions of the rules that match.
+type RuleEvaluator struct {
+	repository Repository
+	invoices   invoice.InvoiceService
+	payments   payment.PaymentService
+	caller     Caller
+	logger     *zap.Logger
+}
+
+// NewRuleEvaluator creates a new rule evaluator. The caller may be nil, in which case call_url actions fail.
+func NewRuleEvaluator(
+	repository Repository,
+	invoices invoice.InvoiceService,
+	payments payment.PaymentService,
+	caller Caller,
+	logger *zap.Logger,
+) *RuleEvaluator {
+	return &RuleEvaluator{
+		repository: repository,
+		invoices:   invoices,
+		payments:   payments,
+		caller:     caller,
+		logger:     logger,
+	}
+}
+
+// RegisterRuleEvaluator subscribes a rule evaluator to invoice and payment events.
+func RegisterRuleEvaluator(
+	registry shared.EventHandlerRegistry,
+	repository Repository,
+	invoices invoice.InvoiceService,
+	payments payment.PaymentService,
+	caller Caller,
+	logger *zap.Logger,
+) {
+	registry.RegisterHandler(NewRuleEvaluator(repository, invoices, payments, caller, logger))
+}
+
+// EventTypes returns the events rule triggers are raised by.
+func (e *RuleEvaluator) EventTypes() []string {
+	return []string{
+		shared.EventTypeInvoiceCreated,
+		shared.EventTypeInvoiceStatusChanged,
+		shared.EventTypeInvoicePaid,
+		shared.EventTypeInvoiceExpired,
+		shared.EventTypeInvoiceCancelled,
+		shared.EventTypeInvoiceRefunded,
+		shared.EventTypePaymentDetected,
+		shared.EventTypePaymentStatusChanged,
+	}
+}
+
+// HandleEvent evaluates the rules of the event's trigger for the merchant of the invoice it concerns, and takes
+// the actions of those that match. Every matching rule is acted on; the failures are returned together so that
+// the event is retried. Actions are safe to repeat: tags and confirmation requirements are only added once, and
+// calls carry a delivery ID that stays the same across retries.
+func (e *RuleEvaluator) HandleEvent(ctx context.Context, event *shared.BaseDomainEvent) error {
+	trigger, ok := eventTrigger(event)
+	if !ok {
+		return nil
+	}
+
+	var subject Subject
+	var err error
+	if trigger.IsPayment() {
+		subject, err = loadPaymentSubject(ctx, e.invoices, e.payments, shared.PaymentID(event.AggregateID))
+	} else {
+		subject, err = loadInvoiceSubject(ctx, e.invoices, event.AggregateID)
+	}
+	if err != nil {
+		return err
+	}
+
+	rules, err := e.repository.ListEnabled(ctx, subject.Invoice.MerchantID(), trigger)
+	if err != nil {
+		return fmt.Errorf("failed to list automation rules: %w", err)
+	}
+
+	var errs []error
+	for _, rule := range rules {
+		evaluation := rule.Evaluate(subject)
+		if !evaluation.Matched {
+			continue
+		}
+		e.logger.Info("Automation rule matched",
+			zap.String("rule_id", rule.ID()),
+			zap.String("trigger", string(trigger)),
+			zap.String("invoice_id", subject.Invoice.ID()))
+
+		for i, action := range evaluation.Actions {
+			if err := e.act(ctx, rule, i, action, subject, event); err != nil {
+				errs = append(errs, fmt.Errorf("rule %s %s action: %w", rule.ID(), action.Type, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// act takes the action at an index of a matching rule's actions.
+func (e *RuleEvaluator) act(
+	ctx context.Context, rule *Rule, index int, action Action, subject Subject, event *shared.BaseDomainEvent,
+) error {
+	switch action.Type {
+	case ActionCallURL:
+		if e.caller == nil {
+			return errors.New("automation calls are not configured")
+		}
+		body, err := json.Marshal(callPayload(rule, subject, event.OccurredAt))
+		if err != nil {
+			return err
+		}
+		return e.caller.Call(ctx, &Call{
+			URL:        action.URL,
+			Secret:     rule.Secret(),
+			DeliveryID: deliveryID(rule, index, event),
+			Body:       body,
+		})
+	case ActionAddTag:
+		_, err := e.invoices.TagInvoice(ctx, subject.Invoice.ID(), action.Tag)
+		return err
+	case ActionRequireConfirmations:
+		err := e.payments.RequireConfirmations(ctx, subject.Payment.ID(), action.Confirmations)
+		if errors.Is(err, payment.ErrTerminalState) {
+			// The payment was confirmed or failed before the rule ran; there is nothing left to hold back
+			e.logger.Warn("Payment settled before the automation rule could raise its confirmations",
+				zap.String("rule_id", rule.ID()),
+				zap.String("payment_id", string(subject.Payment.ID())))
+			return nil
+		}
+		return err
+	default:
+		return fmt.Errorf("unsupported action %q", action.Type)
+	}
+}
+
+// eventTrigger returns the rule trigger an event raises, if any. Paid invoices and confirmed payments are
+// reported by status change events.
+func eventTrigger(event *shared.BaseDomainEvent) (Trigger, bool) {
+	var data struct {
+		Status string `json:"status"`
+	}
+	_ = shared.DecodeEventData(event, &data) // Events without a status only match unconditional triggers
+	status := data.Status
+
+	switch event.EventType {
+	case shared.EventTypeInvoiceCreated:
+		return TriggerInvoiceCreated, true
+	case shared.EventTypeInvoiceStatusChanged, shared.EventTypeInvoicePaid:
+		return TriggerInvoicePaid, status == invoice.StatusPaid.String()
+	case shared.EventTypeInvoiceExpired:
+		return TriggerInvoiceExpired, true
+	case shared.EventTypeInvoiceCancelled:
+		return TriggerInvoiceCancelled, true
+	case shared.EventTypeInvoiceRefunded:
+		return TriggerInvoiceRefunded, true
+	case shared.EventTypePaymentDetected:
+		return TriggerPaymentDetected, true
+	case shared.EventTypePaymentStatusChanged:
+		return TriggerPaymentConfirmed, status == payment.StatusConfirmed.String()
+	default:
+		return "", false
+	}
+}
+
+// loadInvoiceSubject loads the subject of an invoice event.
+func loadInvoiceSubject(ctx context.Context, invoices invoice.InvoiceService, invoiceID string) (Subject, error) {
+	inv, err := invoices.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		return Subject{}, fmt.Errorf("failed to get invoice %s: %w", invoiceID, err)
+	}
+	return Subject{Invoice: inv}, nil
+}
+
+// loadPaymentSubject loads the subject of a payment event: the payment and the invoice it pays.
+func loadPaymentSubject(
+	ctx context.Context,
+	invoices invoice.InvoiceService,
+	payments payment.PaymentService,
+	paymentID shared.PaymentID,
+) (Subject, error) {
+	p, err := payments.GetPayment(ctx, paymentID)
+	if err != nil {
+		return Subject{}, fmt.Errorf("failed to get payment %s: %w", paymentID, err)
+	}
+	subject, err := loadInvoiceSubject(ctx, invoices, string(p.InvoiceID()))
+	if err != nil {
+		return Subject{}, err
+	}
+	subject.Payment = p
+	return subject, nil
+}
+
+// callPayload describes a rule's subject for its calls.
+func callPayload(rule *Rule, subject Subject, occurredAt time.Time) *CallPayload {
+	inv := subject.Invoice
+	payload := &CallPayload{
+		RuleID:     rule.ID(),
+		RuleName:   rule.Name(),
+		Trigger:    rule.Trigger(),
+		OccurredAt: occurredAt,
+		Invoice: InvoicePayload{
+			ID:             inv.ID(),
+			Number:         inv.Number(),
+			Status:         inv.Status().String(),
+			Total:          inv.Pricing().Total().Amount().String(),
+			Currency:       inv.Pricing().Total().Currency(),
+			CryptoCurrency: inv.CryptoCurrency().String(),
+			Network:        inv.Network().String(),
+			CustomerID:     inv.CustomerID(),
+			Metadata:       inv.Metadata(),
+		},
+	}
+	if p := subject.Payment; p != nil {
+		payload.Payment = &PaymentPayload{
+			ID:                    string(p.ID()),
+			Status:                p.Status().String(),
+			Amount:                p.Amount().Amount().Amount().String(),
+			Currency:              p.Amount().Currency().String(),
+			TransactionHash:       p.TransactionHash().String(),
+			Confirmations:         p.Confirmations().Int(),
+			RequiredConfirmations: p.RequiredConfirmations(),
+		}
+	}
+	return payload
+}
+
+// deliveryID identifies the call of a rule's action for an event, derived from them so that retries reuse it.
+func deliveryID(rule *Rule, index int, event *shared.BaseDomainEvent) string {
+	sum := sha256.Sum256([]byte(rule.ID() + "\x00" + strconv.Itoa(index) + "\x00" + event.EventType + "\x00" +
+		event.AggregateID + "\x00" + strconv.FormatInt(event.OccurredAt.UnixNano(), 10)))
+	return hex.EncodeToString(sum[:16])
+}
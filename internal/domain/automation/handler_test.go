@@ -0,0 +1,165 @@
+package automation
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeRuleRepository struct {
+	Repository
+	rules []*Rule
+}
+
+func (r *fakeRuleRepository) ListEnabled(_ context.Context, merchantID string, trigger Trigger) ([]*Rule, error) {
+	var rules []*Rule
+	for _, rule := range r.rules {
+		if rule.MerchantID() == merchantID && rule.Trigger() == trigger && rule.Enabled() {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+type fakeInvoiceService struct {
+	invoice.InvoiceService
+	invoice *invoice.Invoice
+	tagged  []string
+}
+
+func (s *fakeInvoiceService) GetInvoice(_ context.Context, id string) (*invoice.Invoice, error) {
+	if id != s.invoice.ID() {
+		return nil, invoice.ErrInvoiceNotFound
+	}
+	return s.invoice, nil
+}
+
+func (s *fakeInvoiceService) TagInvoice(_ context.Context, _, tag string) (*invoice.Invoice, error) {
+	s.tagged = append(s.tagged, tag)
+	return s.invoice, nil
+}
+
+type fakeCaller struct {
+	calls []*Call
+	err   error
+}
+
+func (c *fakeCaller) Call(_ context.Context, call *Call) error {
+	c.calls = append(c.calls, call)
+	return c.err
+}
+
+func newTestEvaluator(t *testing.T, definitions ...Definition) (*RuleEvaluator, *fakeInvoiceService, *fakeCaller) {
+	t.Helper()
+	repository := &fakeRuleRepository{}
+	for i, definition := range definitions {
+		rule, err := NewRule("rule-"+string(rune('a'+i)), "merchant-1", "secret", definition)
+		require.NoError(t, err)
+		repository.rules = append(repository.rules, rule)
+	}
+	invoices := &fakeInvoiceService{invoice: newTestInvoice(t, "150.00", nil)}
+	caller := &fakeCaller{}
+	return NewRuleEvaluator(repository, invoices, nil, caller, zap.NewNop()), invoices, caller
+}
+
+func paidEvent() *shared.BaseDomainEvent {
+	return &shared.BaseDomainEvent{
+		EventType:     shared.EventTypeInvoiceStatusChanged,
+		AggregateID:   "invoice-1",
+		AggregateType: "invoice",
+		OccurredAt:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		EventData:     map[string]interface{}{"status": "paid"},
+	}
+}
+
+func TestRuleEvaluator_HandleEvent(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ActsOnMatchingRules", func(t *testing.T) {
+		small := newTestRuleDefinition()
+		small.Name = "Small orders"
+		small.Conditions = []Condition{{Field: FieldTotal, Operator: OperatorLessThan, Value: "100"}}
+		small.Actions = []Action{{Type: ActionAddTag, Tag: "small"}}
+		disabled := newTestRuleDefinition()
+		disabled.Name = "Disabled"
+		disabled.Enabled = false
+		evaluator, invoices, caller := newTestEvaluator(t, newTestRuleDefinition(), small, disabled)
+
+		require.NoError(t, evaluator.HandleEvent(ctx, paidEvent()))
+		assert.Equal(t, []string{"large"}, invoices.tagged)
+		require.Len(t, caller.calls, 1)
+		call := caller.calls[0]
+		assert.Equal(t, "https://merchant.example/hooks/large", call.URL)
+		assert.Equal(t, "secret", call.Secret)
+
+		var payload CallPayload
+		require.NoError(t, json.Unmarshal(call.Body, &payload))
+		assert.Equal(t, "rule-a", payload.RuleID)
+		assert.Equal(t, TriggerInvoicePaid, payload.Trigger)
+		assert.Equal(t, "invoice-1", payload.Invoice.ID)
+		assert.Equal(t, "150", payload.Invoice.Total)
+		assert.Nil(t, payload.Payment)
+
+		// A retry of the event is delivered with the same ID
+		require.NoError(t, evaluator.HandleEvent(ctx, paidEvent()))
+		require.Len(t, caller.calls, 2)
+		assert.Equal(t, call.DeliveryID, caller.calls[1].DeliveryID)
+	})
+
+	t.Run("IgnoresOtherStatusChanges", func(t *testing.T) {
+		evaluator, invoices, caller := newTestEvaluator(t, newTestRuleDefinition())
+
+		event := paidEvent()
+		event.EventData = map[string]interface{}{"status": "partial"}
+		require.NoError(t, evaluator.HandleEvent(ctx, event))
+		assert.Empty(t, invoices.tagged)
+		assert.Empty(t, caller.calls)
+	})
+
+	t.Run("ReportsFailedActions", func(t *testing.T) {
+		evaluator, invoices, caller := newTestEvaluator(t, newTestRuleDefinition())
+		caller.err = errors.New("connection refused")
+
+		err := evaluator.HandleEvent(ctx, paidEvent())
+		require.ErrorIs(t, err, caller.err)
+		assert.Equal(t, []string{"large"}, invoices.tagged, "the other actions are still taken")
+	})
+}
+
+func TestEventTrigger(t *testing.T) {
+	for name, test := range map[string]struct {
+		eventType string
+		status    string
+		trigger   Trigger
+		ok        bool
+	}{
+		"Created":          {shared.EventTypeInvoiceCreated, "", TriggerInvoiceCreated, true},
+		"Paid":             {shared.EventTypeInvoiceStatusChanged, invoice.StatusPaid.String(), TriggerInvoicePaid, true},
+		"OtherStatus":      {shared.EventTypeInvoiceStatusChanged, invoice.StatusPartial.String(), TriggerInvoicePaid, false},
+		"Expired":          {shared.EventTypeInvoiceExpired, "", TriggerInvoiceExpired, true},
+		"PaymentDetected":  {shared.EventTypePaymentDetected, "", TriggerPaymentDetected, true},
+		"PaymentConfirmed": {shared.EventTypePaymentStatusChanged, payment.StatusConfirmed.String(), TriggerPaymentConfirmed, true},
+		"PaymentFailed":    {shared.EventTypePaymentStatusChanged, payment.StatusFailed.String(), TriggerPaymentConfirmed, false},
+		"Unrelated":        {"merchant.created", "", "", false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			trigger, ok := eventTrigger(&shared.BaseDomainEvent{
+				EventType: test.eventType,
+				EventData: map[string]interface{}{"status": test.status},
+			})
+			assert.Equal(t, test.ok, ok)
+			if ok {
+				assert.Equal(t, test.trigger, trigger)
+			}
+		})
+	}
+}
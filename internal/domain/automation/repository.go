@@ -0,0 +1,24 @@
+package automation
+
+import "context"
+
+// Repository defines the interface for automation rule persistence.
+type Repository interface {
+	// Save persists a new rule, returning ErrNameTaken if the merchant already has a rule with the name.
+	Save(ctx context.Context, rule *Rule) error
+
+	// FindByID retrieves a rule by its ID.
+	FindByID(ctx context.Context, id string) (*Rule, error)
+
+	// ListByMerchant retrieves a merchant's rules ordered by name.
+	ListByMerchant(ctx context.Context, merchantID string) ([]*Rule, error)
+
+	// ListEnabled retrieves a merchant's enabled rules evaluated on the trigger, ordered by creation.
+	ListEnabled(ctx context.Context, merchantID string, trigger Trigger) ([]*Rule, error)
+
+	// Update updates an existing rule, returning ErrNameTaken if another of the merchant's rules has the name.
+	Update(ctx context.Context, rule *Rule) error
+
+	// Delete removes a rule.
+	Delete(ctx context.Context, id string) error
+}
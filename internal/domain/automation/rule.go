@@ -0,0 +1,397 @@
+// Package automation provides merchant-defined rules that act on invoices and payments as domain events occur.
+package automation
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// MaxRulesPerMerchant bounds the rules evaluated for every event of a merchant.
+	MaxRulesPerMerchant = 50
+	// MaxConditions is the number of conditions a rule may have.
+	MaxConditions = 10
+	// MaxActions is the number of actions a rule may take.
+	MaxActions = 5
+	// MaxTagLength is the length of the longest tag a rule may add to an invoice.
+	MaxTagLength = 40
+	// MaxRequiredConfirmations is the largest confirmation count a rule may require of a payment.
+	MaxRequiredConfirmations = 1000
+	// maxNameLength bounds the name merchants pick a rule by.
+	maxNameLength = 64
+	// maxURLLength matches the length of webhook endpoint URLs.
+	maxURLLength = 500
+	// metadataFieldPrefix prefixes the condition fields that read a key of the invoice metadata.
+	metadataFieldPrefix = "metadata."
+)
+
+// Trigger is the domain event a rule is evaluated on.
+type Trigger string
+
+// Triggers rules can be evaluated on.
+const (
+	TriggerInvoiceCreated   Trigger = "invoice.created"
+	TriggerInvoicePaid      Trigger = "invoice.paid"
+	TriggerInvoiceExpired   Trigger = "invoice.expired"
+	TriggerInvoiceCancelled Trigger = "invoice.cancelled"
+	TriggerInvoiceRefunded  Trigger = "invoice.refunded"
+	TriggerPaymentDetected  Trigger = "payment.detected"
+	TriggerPaymentConfirmed Trigger = "payment.confirmed"
+)
+
+// IsValid checks if the trigger is one rules can be evaluated on.
+func (t Trigger) IsValid() bool {
+	switch t {
+	case TriggerInvoiceCreated, TriggerInvoicePaid, TriggerInvoiceExpired, TriggerInvoiceCancelled,
+		TriggerInvoiceRefunded, TriggerPaymentDetected, TriggerPaymentConfirmed:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsPayment reports whether the trigger is a payment event, whose rules see the payment as well as its invoice.
+func (t Trigger) IsPayment() bool {
+	return t == TriggerPaymentDetected || t == TriggerPaymentConfirmed
+}
+
+// Operator compares the value of a condition's field with the condition's value.
+type Operator string
+
+// Operators conditions can compare with. The ordering operators compare decimal values.
+const (
+	OperatorEqual              Operator = "eq"
+	OperatorNotEqual           Operator = "ne"
+	OperatorGreaterThan        Operator = "gt"
+	OperatorGreaterThanOrEqual Operator = "gte"
+	OperatorLessThan           Operator = "lt"
+	OperatorLessThanOrEqual    Operator = "lte"
+)
+
+// IsValid checks if the operator is supported.
+func (o Operator) IsValid() bool {
+	return o == OperatorEqual || o == OperatorNotEqual || o.isOrdering()
+}
+
+// isOrdering reports whether the operator orders decimal values.
+func (o Operator) isOrdering() bool {
+	switch o {
+	case OperatorGreaterThan, OperatorGreaterThanOrEqual, OperatorLessThan, OperatorLessThanOrEqual:
+		return true
+	default:
+		return false
+	}
+}
+
+// Fields conditions can test. Metadata keys are tested as "metadata.<key>".
+const (
+	FieldTotal          = "total"
+	FieldSubtotal       = "subtotal"
+	FieldCurrency       = "currency"
+	FieldCryptoCurrency = "crypto_currency"
+	FieldNetwork        = "network"
+	FieldStatus         = "status"
+	FieldCustomerID     = "customer_id"
+	FieldPaymentAmount  = "payment_amount" // Payment triggers only
+)
+
+// numericFields are the fields holding decimal amounts.
+var numericFields = map[string]bool{
+	FieldTotal:         true,
+	FieldSubtotal:      true,
+	FieldPaymentAmount: true,
+}
+
+// textFields are the fields holding text, mapped to whether they hold codes, which compare case-insensitively.
+var textFields = map[string]bool{
+	FieldCurrency:       true,
+	FieldCryptoCurrency: true,
+	FieldNetwork:        true,
+	FieldStatus:         true,
+	FieldCustomerID:     false,
+}
+
+// Condition tests a field of the invoice or payment an event concerns.
+type Condition struct {
+	Field    string
+	Operator Operator
+	Value    string
+}
+
+// ActionType identifies what an action does.
+type ActionType string
+
+// Actions rules can take.
+const (
+	// ActionCallURL posts the event and the invoice to a URL of the merchant, signed with the rule's secret.
+	ActionCallURL ActionType = "call_url"
+	// ActionAddTag adds a tag to the invoice.
+	ActionAddTag ActionType = "add_tag"
+	// ActionRequireConfirmations raises the confirmations a detected payment needs before it is confirmed.
+	ActionRequireConfirmations ActionType = "require_confirmations"
+)
+
+// Action is something a rule does when its conditions match. Only the field of its type is set.
+type Action struct {
+	Type          ActionType
+	URL           string // call_url
+	Tag           string // add_tag
+	Confirmations int    // require_confirmations
+}
+
+// Definition is the content of a rule: when it runs and what it does.
+type Definition struct {
+	Name       string
+	Trigger    Trigger
+	Conditions []Condition // All must match; none matches every event
+	Actions    []Action
+	Enabled    bool
+}
+
+// Rule is a merchant's automation: actions taken when an event of its trigger matches its conditions.
+type Rule struct {
+	id         string
+	merchantID string
+	definition Definition
+	secret     string
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+// NewRule creates a new rule. The secret signs the calls of its call_url actions.
+func NewRule(id, merchantID, secret string, definition Definition) (*Rule, error) {
+	now := shared.Now().UTC()
+	return RestoreRule(id, merchantID, secret, normalizeDefinition(definition), now, now)
+}
+
+// RestoreRule recreates a rule from storage.
+func RestoreRule(id, merchantID, secret string, definition Definition, createdAt, updatedAt time.Time) (*Rule, error) {
+	if id == "" {
+		return nil, errors.New("rule ID is required")
+	}
+	if merchantID == "" {
+		return nil, errors.New("merchant ID is required")
+	}
+	if secret == "" {
+		return nil, errors.New("rule secret is required")
+	}
+	if err := ValidateDefinition(definition); err != nil {
+		return nil, err
+	}
+
+	return &Rule{
+		id:         id,
+		merchantID: merchantID,
+		definition: definition,
+		secret:     secret,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
+	}, nil
+}
+
+// ID returns the rule ID.
+func (r *Rule) ID() string {
+	return r.id
+}
+
+// MerchantID returns the merchant that owns the rule.
+func (r *Rule) MerchantID() string {
+	return r.merchantID
+}
+
+// Name returns the name the merchant picks the rule by.
+func (r *Rule) Name() string {
+	return r.definition.Name
+}
+
+// Trigger returns the event the rule is evaluated on.
+func (r *Rule) Trigger() Trigger {
+	return r.definition.Trigger
+}
+
+// Conditions returns the conditions that must all match for the rule to act.
+func (r *Rule) Conditions() []Condition {
+	return append([]Condition(nil), r.definition.Conditions...)
+}
+
+// Actions returns what the rule does when it matches.
+func (r *Rule) Actions() []Action {
+	return append([]Action(nil), r.definition.Actions...)
+}
+
+// Enabled reports whether the rule is evaluated on events.
+func (r *Rule) Enabled() bool {
+	return r.definition.Enabled
+}
+
+// Definition returns the content of the rule.
+func (r *Rule) Definition() Definition {
+	definition := r.definition
+	definition.Conditions = r.Conditions()
+	definition.Actions = r.Actions()
+	return definition
+}
+
+// Secret returns the secret signing the calls of the rule's call_url actions.
+func (r *Rule) Secret() string {
+	return r.secret
+}
+
+// CreatedAt returns when the rule was created.
+func (r *Rule) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// UpdatedAt returns when the rule was last changed.
+func (r *Rule) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// Update replaces the content of the rule.
+func (r *Rule) Update(definition Definition) error {
+	definition = normalizeDefinition(definition)
+	if err := ValidateDefinition(definition); err != nil {
+		return err
+	}
+
+	r.definition = definition
+	r.updatedAt = shared.Now().UTC()
+	return nil
+}
+
+// normalizeDefinition trims the definition and clears the fields its actions do not use.
+func normalizeDefinition(definition Definition) Definition {
+	definition.Name = strings.TrimSpace(definition.Name)
+
+	conditions := make([]Condition, len(definition.Conditions))
+	for i, condition := range definition.Conditions {
+		conditions[i] = Condition{
+			Field:    strings.TrimSpace(condition.Field),
+			Operator: Operator(strings.ToLower(strings.TrimSpace(string(condition.Operator)))),
+			Value:    strings.TrimSpace(condition.Value),
+		}
+	}
+	definition.Conditions = conditions
+
+	actions := make([]Action, len(definition.Actions))
+	for i, action := range definition.Actions {
+		normalized := Action{Type: action.Type}
+		switch action.Type {
+		case ActionCallURL:
+			normalized.URL = strings.TrimSpace(action.URL)
+		case ActionAddTag:
+			normalized.Tag = strings.TrimSpace(action.Tag)
+		case ActionRequireConfirmations:
+			normalized.Confirmations = action.Confirmations
+		}
+		actions[i] = normalized
+	}
+	definition.Actions = actions
+	return definition
+}
+
+// ValidateDefinition checks that a rule's trigger, conditions and actions are supported and fit together.
+func ValidateDefinition(definition Definition) error {
+	if definition.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(definition.Name) > maxNameLength {
+		return fmt.Errorf("name cannot exceed %d characters", maxNameLength)
+	}
+	if !definition.Trigger.IsValid() {
+		return fmt.Errorf("unsupported trigger %q", definition.Trigger)
+	}
+
+	if len(definition.Conditions) > MaxConditions {
+		return fmt.Errorf("a rule cannot have more than %d conditions", MaxConditions)
+	}
+	for i, condition := range definition.Conditions {
+		if err := validateCondition(condition, definition.Trigger); err != nil {
+			return fmt.Errorf("condition %d: %w", i+1, err)
+		}
+	}
+
+	if len(definition.Actions) == 0 {
+		return errors.New("at least one action is required")
+	}
+	if len(definition.Actions) > MaxActions {
+		return fmt.Errorf("a rule cannot take more than %d actions", MaxActions)
+	}
+	for i, action := range definition.Actions {
+		if err := validateAction(action, definition.Trigger); err != nil {
+			return fmt.Errorf("action %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// validateCondition checks that a condition tests a field the trigger provides with an operator and value that
+// suit it.
+func validateCondition(condition Condition, trigger Trigger) error {
+	if !condition.Operator.IsValid() {
+		return fmt.Errorf("unsupported operator %q", condition.Operator)
+	}
+
+	switch {
+	case strings.HasPrefix(condition.Field, metadataFieldPrefix):
+		if err := invoice.ValidateMetadataKey(strings.TrimPrefix(condition.Field, metadataFieldPrefix)); err != nil {
+			return err
+		}
+	case numericFields[condition.Field]:
+		if condition.Field == FieldPaymentAmount && !trigger.IsPayment() {
+			return fmt.Errorf("field %s is only available to payment triggers", condition.Field)
+		}
+	default:
+		if _, ok := textFields[condition.Field]; !ok {
+			return fmt.Errorf("unsupported field %q", condition.Field)
+		}
+		if condition.Operator.isOrdering() {
+			return fmt.Errorf("field %s can only be compared with eq or ne", condition.Field)
+		}
+	}
+
+	if numericFields[condition.Field] || condition.Operator.isOrdering() {
+		if _, err := decimal.NewFromString(condition.Value); err != nil {
+			return fmt.Errorf("value %q of field %s must be a decimal", condition.Value, condition.Field)
+		}
+	}
+	return nil
+}
+
+// validateAction checks that an action is complete and available to the trigger.
+func validateAction(action Action, trigger Trigger) error {
+	switch action.Type {
+	case ActionCallURL:
+		if len(action.URL) > maxURLLength {
+			return fmt.Errorf("url cannot exceed %d characters", maxURLLength)
+		}
+		parsed, err := url.Parse(action.URL)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			return errors.New("url must be an absolute https URL")
+		}
+	case ActionAddTag:
+		if action.Tag == "" {
+			return errors.New("tag is required")
+		}
+		if len(action.Tag) > MaxTagLength {
+			return fmt.Errorf("tag cannot exceed %d characters", MaxTagLength)
+		}
+	case ActionRequireConfirmations:
+		if trigger != TriggerPaymentDetected {
+			return fmt.Errorf("%s actions are only available to the %s trigger", action.Type, TriggerPaymentDetected)
+		}
+		if action.Confirmations < 1 || action.Confirmations > MaxRequiredConfirmations {
+			return fmt.Errorf("confirmations must be between 1 and %d", MaxRequiredConfirmations)
+		}
+	default:
+		return fmt.Errorf("unsupported action %q", action.Type)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package automation
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRuleDefinition() Definition {
+	return Definition{
+		Name:    " Large orders ",
+		Trigger: TriggerInvoicePaid,
+		Conditions: []Condition{
+			{Field: FieldTotal, Operator: "GT", Value: " 100 "},
+			{Field: FieldCryptoCurrency, Operator: OperatorEqual, Value: "usdt"},
+		},
+		Actions: []Action{
+			{Type: ActionCallURL, URL: "https://merchant.example/hooks/large", Tag: "ignored"},
+			{Type: ActionAddTag, Tag: " large "},
+		},
+		Enabled: true,
+	}
+}
+
+func newTestInvoice(t *testing.T, total string, metadata map[string]interface{}) *invoice.Invoice {
+	t.Helper()
+	unitPrice, err := shared.NewMoney(total, shared.CurrencyUSD)
+	require.NoError(t, err)
+	item, err := invoice.NewInvoiceItem("Order", "", "1", unitPrice)
+	require.NoError(t, err)
+	zero, err := shared.NewMoney("0", shared.CurrencyUSD)
+	require.NoError(t, err)
+	pricing, err := invoice.NewInvoicePricing(unitPrice, zero, unitPrice)
+	require.NoError(t, err)
+	address, err := shared.NewPaymentAddress("TTestAddress123456789012345678901234567890", shared.NetworkTron)
+	require.NoError(t, err)
+	rate, err := shared.NewExchangeRate("1.0", shared.CurrencyUSD, shared.CryptoCurrencyUSDT, "fixed", time.Hour)
+	require.NoError(t, err)
+	tolerance, err := invoice.NewPaymentTolerance("0.01", "1.0", invoice.OverpaymentActionCredit)
+	require.NoError(t, err)
+
+	inv, err := invoice.NewInvoice("invoice-1", "merchant-1", "Order", "", []*invoice.InvoiceItem{item}, pricing,
+		shared.CryptoCurrencyUSDT, address, rate, tolerance, invoice.NewInvoiceExpiration(time.Hour), metadata)
+	require.NoError(t, err)
+	return inv
+}
+
+func TestNewRule(t *testing.T) {
+	rule, err := NewRule("rule-1", "merchant-1", "secret", newTestRuleDefinition())
+	require.NoError(t, err)
+	assert.Equal(t, "Large orders", rule.Name())
+	assert.Equal(t, OperatorGreaterThan, rule.Conditions()[0].Operator)
+	assert.Equal(t, "100", rule.Conditions()[0].Value)
+	assert.Equal(t, Action{Type: ActionCallURL, URL: "https://merchant.example/hooks/large"}, rule.Actions()[0])
+	assert.Equal(t, "large", rule.Actions()[1].Tag)
+
+	for name, mutate := range map[string]func(*Definition){
+		"NoName":              func(d *Definition) { d.Name = " " },
+		"UnknownTrigger":      func(d *Definition) { d.Trigger = "invoice.viewed" },
+		"UnknownField":        func(d *Definition) { d.Conditions[0].Field = "title" },
+		"UnknownOperator":     func(d *Definition) { d.Conditions[0].Operator = "contains" },
+		"NonDecimalAmount":    func(d *Definition) { d.Conditions[0].Value = "lots" },
+		"OrderedText":         func(d *Definition) { d.Conditions[1].Operator = OperatorGreaterThan },
+		"PaymentFieldOnPaid":  func(d *Definition) { d.Conditions[0].Field = FieldPaymentAmount },
+		"InvalidMetadataKey":  func(d *Definition) { d.Conditions[1].Field = "metadata." },
+		"NoActions":           func(d *Definition) { d.Actions = nil },
+		"PlainHTTPURL":        func(d *Definition) { d.Actions[0].URL = "http://merchant.example/hooks" },
+		"RelativeURL":         func(d *Definition) { d.Actions[0].URL = "/hooks" },
+		"EmptyTag":            func(d *Definition) { d.Actions[1].Tag = "  " },
+		"UnknownAction":       func(d *Definition) { d.Actions[1].Type = "send_email" },
+		"ConfirmationsOnPaid": func(d *Definition) { d.Actions[1] = Action{Type: ActionRequireConfirmations, Confirmations: 5} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			definition := newTestRuleDefinition()
+			mutate(&definition)
+			_, err := NewRule("rule-1", "merchant-1", "secret", definition)
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("RequireConfirmations", func(t *testing.T) {
+		definition := newTestRuleDefinition()
+		definition.Trigger = TriggerPaymentDetected
+		definition.Conditions = []Condition{{Field: FieldPaymentAmount, Operator: OperatorGreaterThanOrEqual, Value: "50"}}
+		definition.Actions = []Action{{Type: ActionRequireConfirmations, Confirmations: 30}}
+		_, err := NewRule("rule-1", "merchant-1", "secret", definition)
+		require.NoError(t, err)
+
+		definition.Actions[0].Confirmations = MaxRequiredConfirmations + 1
+		_, err = NewRule("rule-1", "merchant-1", "secret", definition)
+		require.Error(t, err)
+	})
+}
+
+func TestRule_Evaluate(t *testing.T) {
+	rule, err := NewRule("rule-1", "merchant-1", "secret", newTestRuleDefinition())
+	require.NoError(t, err)
+
+	evaluation := rule.Evaluate(Subject{Invoice: newTestInvoice(t, "150.00", nil)})
+	assert.True(t, evaluation.Matched)
+	assert.Equal(t, rule.Actions(), evaluation.Actions)
+	require.Len(t, evaluation.Conditions, 2)
+	assert.Equal(t, "150", evaluation.Conditions[0].Actual)
+	assert.True(t, evaluation.Conditions[1].Matched, "crypto currencies compare case-insensitively")
+
+	evaluation = rule.Evaluate(Subject{Invoice: newTestInvoice(t, "100.00", nil)})
+	assert.False(t, evaluation.Matched)
+	assert.Empty(t, evaluation.Actions)
+	assert.False(t, evaluation.Conditions[0].Matched)
+	assert.True(t, evaluation.Conditions[1].Matched)
+
+	t.Run("Metadata", func(t *testing.T) {
+		definition := newTestRuleDefinition()
+		definition.Conditions = []Condition{
+			{Field: "metadata.plan", Operator: OperatorEqual, Value: "pro"},
+			{Field: "metadata.seats", Operator: OperatorGreaterThanOrEqual, Value: "10"},
+		}
+		rule, err := NewRule("rule-1", "merchant-1", "secret", definition)
+		require.NoError(t, err)
+
+		inv := newTestInvoice(t, "150.00", map[string]interface{}{"plan": "pro", "seats": float64(12)})
+		assert.True(t, rule.Evaluate(Subject{Invoice: inv}).Matched)
+
+		inv = newTestInvoice(t, "150.00", map[string]interface{}{"plan": "Pro", "seats": float64(12)})
+		assert.False(t, rule.Evaluate(Subject{Invoice: inv}).Matched, "metadata compares exactly")
+
+		evaluation := rule.Evaluate(Subject{Invoice: newTestInvoice(t, "150.00", nil)})
+		assert.False(t, evaluation.Matched)
+		assert.False(t, evaluation.Conditions[0].Present)
+	})
+
+	t.Run("MissingFieldNeverMatches", func(t *testing.T) {
+		definition := newTestRuleDefinition()
+		definition.Conditions = []Condition{{Field: FieldCustomerID, Operator: OperatorNotEqual, Value: "c-1"}}
+		rule, err := NewRule("rule-1", "merchant-1", "secret", definition)
+		require.NoError(t, err)
+
+		assert.False(t, rule.Evaluate(Subject{Invoice: newTestInvoice(t, "150.00", nil)}).Matched)
+	})
+
+	t.Run("NoConditions", func(t *testing.T) {
+		definition := newTestRuleDefinition()
+		definition.Conditions = nil
+		rule, err := NewRule("rule-1", "merchant-1", "secret", definition)
+		require.NoError(t, err)
+
+		assert.True(t, rule.Evaluate(Subject{Invoice: newTestInvoice(t, "1.00", nil)}).Matched)
+	})
+}
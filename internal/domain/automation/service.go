@@ -0,0 +1,268 @@
+package automation
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+// Service defines the interface for managing automation rules and trying them out.
+type Service interface {
+	// CreateRule creates an automation rule for a merchant.
+	CreateRule(ctx context.Context, req *CreateRuleRequest) (*Rule, error)
+
+	// ListRules lists a merchant's automation rules.
+	ListRules(ctx context.Context, merchantID string) ([]*Rule, error)
+
+	// GetRule retrieves a merchant's automation rule.
+	GetRule(ctx context.Context, merchantID, ruleID string) (*Rule, error)
+
+	// UpdateRule replaces the content of a merchant's automation rule.
+	UpdateRule(ctx context.Context, req *UpdateRuleRequest) (*Rule, error)
+
+	// DeleteRule removes a merchant's automation rule.
+	DeleteRule(ctx context.Context, merchantID, ruleID string) error
+
+	// TestRule evaluates a rule against one of the merchant's invoices, and for payment triggers one of its
+	// payments, as its trigger would, without taking any action.
+	TestRule(ctx context.Context, req *TestRuleRequest) (*Evaluation, error)
+}
+
+// CreateRuleRequest represents the request to create an automation rule.
+type CreateRuleRequest struct {
+	MerchantID string `validate:"required"`
+	Definition Definition
+}
+
+// UpdateRuleRequest represents the request to replace the content of an automation rule.
+type UpdateRuleRequest struct {
+	MerchantID string `validate:"required"`
+	RuleID     string `validate:"required"`
+	Definition Definition
+}
+
+// TestRuleRequest represents the request to evaluate a rule without acting on it. Either a saved rule or a
+// definition not saved yet is tested.
+type TestRuleRequest struct {
+	MerchantID string `validate:"required"`
+	RuleID     string `validate:"required_without=Definition"`
+	Definition *Definition
+	InvoiceID  string `validate:"required"`
+	PaymentID  string // Required for payment triggers; the payment must pay the invoice
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository Repository
+	invoices   invoice.InvoiceService
+	payments   payment.PaymentService
+	logger     *zap.Logger
+}
+
+// NewService creates a new automation rule service.
+func NewService(
+	repository Repository,
+	invoices invoice.InvoiceService,
+	payments payment.PaymentService,
+	logger *zap.Logger,
+) Service {
+	return &ServiceImpl{
+		repository: repository,
+		invoices:   invoices,
+		payments:   payments,
+		logger:     logger,
+	}
+}
+
+// CreateRule creates an automation rule for a merchant, with a fresh secret signing its calls.
+func (s *ServiceImpl) CreateRule(ctx context.Context, req *CreateRuleRequest) (*Rule, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: create automation rule request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	rules, err := s.repository.ListByMerchant(ctx, req.MerchantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) >= MaxRulesPerMerchant {
+		return nil, fmt.Errorf("%w: the limit is %d", ErrTooManyRules, MaxRulesPerMerchant)
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate automation rule ID: %w", err)
+	}
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate automation rule secret: %w", err)
+	}
+
+	rule, err := NewRule(id, req.MerchantID, secret, req.Definition)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	if err := s.repository.Save(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Automation rule created",
+		zap.String("rule_id", rule.ID()),
+		zap.String("merchant_id", rule.MerchantID()),
+		zap.String("trigger", string(rule.Trigger())))
+
+	return rule, nil
+}
+
+// ListRules lists a merchant's automation rules.
+func (s *ServiceImpl) ListRules(ctx context.Context, merchantID string) ([]*Rule, error) {
+	if merchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+
+	return s.repository.ListByMerchant(ctx, merchantID)
+}
+
+// GetRule retrieves a merchant's automation rule.
+func (s *ServiceImpl) GetRule(ctx context.Context, merchantID, ruleID string) (*Rule, error) {
+	if merchantID == "" || ruleID == "" {
+		return nil, fmt.Errorf("%w: merchant ID and rule ID are required", ErrInvalidRequest)
+	}
+
+	rule, err := s.repository.FindByID(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule.MerchantID() != merchantID {
+		return nil, ErrRuleNotFound
+	}
+
+	return rule, nil
+}
+
+// UpdateRule replaces the content of a merchant's automation rule. Its secret is kept.
+func (s *ServiceImpl) UpdateRule(ctx context.Context, req *UpdateRuleRequest) (*Rule, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: update automation rule request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	rule, err := s.GetRule(ctx, req.MerchantID, req.RuleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := rule.Update(req.Definition); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	if err := s.repository.Update(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// DeleteRule removes a merchant's automation rule.
+func (s *ServiceImpl) DeleteRule(ctx context.Context, merchantID, ruleID string) error {
+	rule, err := s.GetRule(ctx, merchantID, ruleID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.Delete(ctx, rule.ID()); err != nil {
+		return err
+	}
+
+	s.logger.Info("Automation rule deleted",
+		zap.String("rule_id", rule.ID()),
+		zap.String("merchant_id", rule.MerchantID()))
+
+	return nil
+}
+
+// TestRule evaluates a saved rule or a definition against one of the merchant's invoices and payments.
+func (s *ServiceImpl) TestRule(ctx context.Context, req *TestRuleRequest) (*Evaluation, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: test automation rule request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	var definition Definition
+	if req.RuleID != "" {
+		rule, err := s.GetRule(ctx, req.MerchantID, req.RuleID)
+		if err != nil {
+			return nil, err
+		}
+		definition = rule.Definition()
+	} else {
+		definition = normalizeDefinition(*req.Definition)
+		if err := ValidateDefinition(definition); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
+	}
+
+	subject, err := s.testSubject(ctx, req, definition.Trigger)
+	if err != nil {
+		return nil, err
+	}
+	return evaluate(definition, subject), nil
+}
+
+// testSubject loads the merchant's invoice, and payment for payment triggers, a rule is tested against.
+func (s *ServiceImpl) testSubject(ctx context.Context, req *TestRuleRequest, trigger Trigger) (Subject, error) {
+	inv, err := s.invoices.GetInvoice(ctx, req.InvoiceID)
+	if err != nil {
+		return Subject{}, err
+	}
+	if inv.MerchantID() != req.MerchantID {
+		return Subject{}, invoice.ErrInvoiceNotFound
+	}
+	subject := Subject{Invoice: inv}
+
+	if !trigger.IsPayment() {
+		return subject, nil
+	}
+	if req.PaymentID == "" {
+		return Subject{}, fmt.Errorf("%w: payment ID is required to test %s rules", ErrInvalidRequest, trigger)
+	}
+	p, err := s.payments.GetPayment(ctx, shared.PaymentID(req.PaymentID))
+	if err != nil {
+		return Subject{}, err
+	}
+	if string(p.InvoiceID()) != inv.ID() {
+		return Subject{}, fmt.Errorf("%w: payment %s does not pay invoice %s", ErrInvalidRequest, p.ID(), inv.ID())
+	}
+	subject.Payment = p
+	return subject, nil
+}
+
+func generateID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// generateSecret returns a random secret signing a rule's calls.
+func generateSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
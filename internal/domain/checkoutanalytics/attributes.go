@@ -0,0 +1,98 @@
+package checkoutanalytics
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"net/url"
+	"strings"
+)
+
+// Direct is counted as the referrer of checkout pages opened without one, or from the checkout site itself.
+const Direct = "direct"
+
+// browserFamilies maps user agent tokens to browser families, checked in order: in-app wallet browsers and
+// Chromium derivatives also announce the browsers they are built on.
+var browserFamilies = []struct {
+	token  string
+	family string
+}{
+	{"metamaskmobile", "metamask"},
+	{"coinbasewallet", "coinbase_wallet"},
+	{"trust/", "trust_wallet"},
+	{"edg", "edge"},
+	{"opr/", "opera"},
+	{"opera", "opera"},
+	{"samsungbrowser", "samsung"},
+	{"firefox/", "firefox"},
+	{"fxios/", "firefox"},
+	{"crios/", "chrome"},
+	{"chrome/", "chrome"},
+	{"chromium/", "chrome"},
+	{"safari/", "safari"},
+}
+
+// botTokens mark the user agents of crawlers, link previews and scripts.
+var botTokens = []string{"bot", "crawler", "spider", "headless", "preview", "curl/", "wget/", "python-", "go-http"}
+
+// ClassifyUserAgent returns the browser family and the device class (desktop, mobile, tablet or bot) of a user
+// agent. Only these classes are counted; the user agent itself is not kept.
+func ClassifyUserAgent(userAgent string) (browser, device string) {
+	ua := strings.ToLower(userAgent)
+	if strings.TrimSpace(ua) == "" {
+		return Unknown, Unknown
+	}
+	for _, token := range botTokens {
+		if strings.Contains(ua, token) {
+			return "bot", "bot"
+		}
+	}
+
+	browser = "other"
+	for _, candidate := range browserFamilies {
+		if strings.Contains(ua, candidate.token) {
+			browser = candidate.family
+			break
+		}
+	}
+
+	switch {
+	case strings.Contains(ua, "ipad") || strings.Contains(ua, "tablet") ||
+		(strings.Contains(ua, "android") && !strings.Contains(ua, "mobile")):
+		device = "tablet"
+	case strings.Contains(ua, "mobi") || strings.Contains(ua, "iphone") || strings.Contains(ua, "android"):
+		device = "mobile"
+	default:
+		device = "desktop"
+	}
+	return browser, device
+}
+
+// ReferrerHost returns the host of the page that linked to the checkout, without its path or query, which may
+// identify the customer. Checkouts opened without a referrer or from the checkout host itself count as Direct.
+func ReferrerHost(referrer, checkoutHost string) string {
+	if strings.TrimSpace(referrer) == "" {
+		return Direct
+	}
+	parsed, err := url.Parse(referrer)
+	if err != nil || parsed.Hostname() == "" {
+		return Unknown
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	own := checkoutHost
+	if h, _, found := strings.Cut(checkoutHost, ":"); found {
+		own = h
+	}
+	if host == strings.TrimPrefix(strings.ToLower(own), "www.") {
+		return Direct
+	}
+	return host
+}
+
+// NormalizeCountry returns an ISO 3166-1 alpha-2 country code in upper case, or Unknown for anything else.
+func NormalizeCountry(country string) string {
+	code, ok := shared.ParseCountryCode(country)
+	if !ok {
+		return Unknown
+	}
+	return code
+}
@@ -0,0 +1,72 @@
+package checkoutanalytics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyUserAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		browser   string
+		device    string
+	}{
+		{
+			name: "Chrome on Windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) " +
+				"Chrome/129.0.0.0 Safari/537.36",
+			browser: "chrome", device: "desktop",
+		},
+		{
+			name: "Edge on Windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) " +
+				"Chrome/129.0.0.0 Safari/537.36 Edg/129.0.0.0",
+			browser: "edge", device: "desktop",
+		},
+		{
+			name: "Safari on iPhone",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 18_0 like Mac OS X) AppleWebKit/605.1.15 " +
+				"(KHTML, like Gecko) Version/18.0 Mobile/15E148 Safari/604.1",
+			browser: "safari", device: "mobile",
+		},
+		{
+			name:      "Firefox on an Android tablet",
+			userAgent: "Mozilla/5.0 (Android 14; Tablet; rv:131.0) Gecko/131.0 Firefox/131.0",
+			browser:   "firefox", device: "tablet",
+		},
+		{
+			name: "MetaMask in-app browser",
+			userAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) " +
+				"Chrome/129.0.0.0 Mobile Safari/537.36 MetaMaskMobile",
+			browser: "metamask", device: "mobile",
+		},
+		{name: "Crawler", userAgent: "Mozilla/5.0 (compatible; Googlebot/2.1)", browser: "bot", device: "bot"},
+		{name: "Script", userAgent: "curl/8.5.0", browser: "bot", device: "bot"},
+		{name: "Missing", userAgent: "", browser: Unknown, device: Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			browser, device := ClassifyUserAgent(tt.userAgent)
+			assert.Equal(t, tt.browser, browser)
+			assert.Equal(t, tt.device, device)
+		})
+	}
+}
+
+func TestReferrerHost(t *testing.T) {
+	assert.Equal(t, Direct, ReferrerHost("", "pay.example.com"))
+	assert.Equal(t, "shop.example.org", ReferrerHost("https://www.Shop.example.org/cart?email=a@b.c", "pay.example.com"))
+	assert.Equal(t, Direct, ReferrerHost("https://pay.example.com/l/donate", "pay.example.com:443"))
+	assert.Equal(t, Unknown, ReferrerHost("not a url", "pay.example.com"))
+}
+
+func TestNormalizeCountry(t *testing.T) {
+	assert.Equal(t, "DE", NormalizeCountry(" de "))
+	assert.Equal(t, Unknown, NormalizeCountry("XX"))
+	assert.Equal(t, Unknown, NormalizeCountry("T1"))
+	assert.Equal(t, Unknown, NormalizeCountry("DEU"))
+	assert.Equal(t, Unknown, NormalizeCountry(""))
+}
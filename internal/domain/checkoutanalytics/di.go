@@ -0,0 +1,18 @@
+package checkoutanalytics
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the checkout analytics service layer dependencies.
+var Module = fx.Module("checkout-analytics-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+	fx.Invoke(
+		RegisterPaidInvoiceHandler,
+	),
+)
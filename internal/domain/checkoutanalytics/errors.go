@@ -0,0 +1,14 @@
+package checkoutanalytics
+
+import "errors"
+
+// Domain errors for checkout analytics operations
+var (
+	ErrFunnelNotFound = errors.New("checkout funnel not found")
+	ErrInvalidRequest = errors.New("invalid checkout analytics request")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeFunnelNotFound = "CHECKOUT_FUNNEL_NOT_FOUND"
+)
@@ -0,0 +1,99 @@
+package checkoutanalytics
+
+import (
+	"time"
+)
+
+// Step is a step customers go through on the checkout page.
+type Step string
+
+const (
+	StepViewed        Step = "viewed"
+	StepAddressCopied Step = "address_copied"
+	StepPaid          Step = "paid"
+)
+
+// String returns the string representation of the step.
+func (s Step) String() string {
+	return string(s)
+}
+
+// Funnel records how far the checkout of an invoice went. Only the time each step was first reached is kept, so
+// that the funnel says nothing about who viewed the page.
+type Funnel struct {
+	InvoiceID       string
+	MerchantID      string
+	Views           int // Times the checkout page was shown, reloads included
+	FirstViewedAt   time.Time
+	AddressCopiedAt *time.Time
+	PaidAt          *time.Time
+}
+
+// Reach records that the checkout reached a step at a time, returning false if it had already reached it.
+// Viewing is reached when the funnel starts.
+func (f *Funnel) Reach(step Step, at time.Time) bool {
+	var reached **time.Time
+	switch step {
+	case StepAddressCopied:
+		reached = &f.AddressCopiedAt
+	case StepPaid:
+		reached = &f.PaidAt
+	default:
+		return false
+	}
+	if *reached != nil {
+		return false
+	}
+	*reached = &at
+	return true
+}
+
+// TimeToPayment returns how long after the checkout page was first shown the invoice was paid, or nil if it
+// is not paid.
+func (f *Funnel) TimeToPayment() *time.Duration {
+	if f.PaidAt == nil {
+		return nil
+	}
+	duration := max(f.PaidAt.Sub(f.FirstViewedAt), 0)
+	return &duration
+}
+
+// Dimension is an attribute checkout views are counted by.
+type Dimension string
+
+const (
+	DimensionBrowser  Dimension = "browser"
+	DimensionDevice   Dimension = "device"
+	DimensionReferrer Dimension = "referrer"
+	DimensionCountry  Dimension = "country"
+)
+
+// Dimensions lists the attributes checkout views are counted by.
+var Dimensions = []Dimension{DimensionBrowser, DimensionDevice, DimensionReferrer, DimensionCountry}
+
+// String returns the string representation of the dimension.
+func (d Dimension) String() string {
+	return string(d)
+}
+
+// Unknown is counted for attributes that could not be told, such as the country of a private address.
+const Unknown = "unknown"
+
+// Summary is the checkout funnel of a merchant's invoices first viewed in a period.
+type Summary struct {
+	Viewed        int
+	AddressCopied int
+	Paid          int
+	// AverageTimeToPayment is the mean time from first view to payment of the paid invoices
+	AverageTimeToPayment time.Duration
+	// Breakdown counts the viewed checkouts by each dimension's values
+	Breakdown map[Dimension]map[string]int
+}
+
+// ConversionRate returns the share of viewed checkouts that were paid, from 0 to 1.
+func (s *Summary) ConversionRate() float64 {
+	if s.Viewed == 0 {
+		return 0
+	}
+	return float64(s.Paid) / float64(s.Viewed)
+}
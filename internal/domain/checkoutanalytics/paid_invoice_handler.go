@@ -0,0 +1,51 @@
+package checkoutanalytics
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// PaidInvoiceHandler completes the checkout funnel of invoices as they become paid.
+type PaidInvoiceHandler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewPaidInvoiceHandler creates a new paid invoice handler.
+func NewPaidInvoiceHandler(service Service, logger *zap.Logger) *PaidInvoiceHandler {
+	return &PaidInvoiceHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterPaidInvoiceHandler subscribes a paid invoice handler to invoice events.
+func RegisterPaidInvoiceHandler(registry shared.EventHandlerRegistry, service Service, logger *zap.Logger) {
+	registry.RegisterHandler(NewPaidInvoiceHandler(service, logger))
+}
+
+// EventTypes returns the invoice events that may report an invoice as paid.
+func (h *PaidInvoiceHandler) EventTypes() []string {
+	return []string{
+		shared.EventTypeInvoiceStatusChanged,
+		shared.EventTypeInvoicePaid,
+	}
+}
+
+// HandleEvent records the payment of the invoice an event reports as paid. Events for invoices in other states
+// are ignored.
+func (h *PaidInvoiceHandler) HandleEvent(ctx context.Context, event *shared.BaseDomainEvent) error {
+	var data shared.InvoiceEvent
+	if err := shared.DecodeEventData(event, &data); err != nil {
+		return fmt.Errorf("failed to decode invoice event: %w", err)
+	}
+	if data.Status != invoice.StatusPaid.String() {
+		return nil
+	}
+
+	return h.service.RecordPayment(ctx, event.AggregateID)
+}
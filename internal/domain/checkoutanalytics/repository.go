@@ -0,0 +1,26 @@
+package checkoutanalytics
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for checkout funnel and view count persistence.
+type Repository interface {
+	// RecordView starts the funnel of an invoice or counts another view of it, returning whether it was the
+	// first view.
+	RecordView(ctx context.Context, invoiceID, merchantID string, at time.Time) (bool, error)
+
+	// CountView adds a viewed checkout to the merchant's counts of the day for each dimension's value.
+	CountView(ctx context.Context, merchantID string, day time.Time, values map[Dimension]string) error
+
+	// FindByInvoice retrieves the funnel of an invoice, returning ErrFunnelNotFound if its checkout was not viewed.
+	FindByInvoice(ctx context.Context, invoiceID string) (*Funnel, error)
+
+	// UpdateSteps stores the steps a funnel reached.
+	UpdateSteps(ctx context.Context, funnel *Funnel) error
+
+	// Summarize summarizes the funnels of a merchant's invoices first viewed in [from, to). A zero bound leaves
+	// the period open on that side.
+	Summarize(ctx context.Context, merchantID string, from, to time.Time) (*Summary, error)
+}
@@ -0,0 +1,158 @@
+package checkoutanalytics
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Service defines the interface for checkout page analytics.
+type Service interface {
+	// RecordView records that an invoice's checkout page was shown.
+	RecordView(ctx context.Context, req *ViewRequest) error
+
+	// RecordAddressCopied records that the customer copied the payment address from an invoice's checkout page.
+	RecordAddressCopied(ctx context.Context, invoiceID string) error
+
+	// RecordPayment records that an invoice whose checkout page was shown was paid.
+	RecordPayment(ctx context.Context, invoiceID string) error
+
+	// GetInvoiceFunnel retrieves the checkout funnel of a merchant's invoice.
+	GetInvoiceFunnel(ctx context.Context, merchantID, invoiceID string) (*Funnel, error)
+
+	// GetSummary summarizes the checkout funnel of a merchant's invoices first viewed in [from, to).
+	GetSummary(ctx context.Context, merchantID string, from, to time.Time) (*Summary, error)
+}
+
+// ViewRequest describes a view of a checkout page. It is reduced to coarse attributes before it is counted:
+// neither the client address nor the user agent is kept.
+type ViewRequest struct {
+	InvoiceID  string
+	MerchantID string
+	UserAgent  string
+	Referrer   string
+	// Host is the host the checkout page was served on, so that links within the checkout count as direct
+	Host string
+	// Country is the ISO code of the country the viewer was located in, if known
+	Country string
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+// NewService creates a new checkout analytics service.
+func NewService(repository Repository, logger *zap.Logger) Service {
+	return &ServiceImpl{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// RecordView records that an invoice's checkout page was shown. A checkout is counted by browser, device,
+// referrer and country on its first view only, so reloads and polling do not skew the counts.
+func (s *ServiceImpl) RecordView(ctx context.Context, req *ViewRequest) error {
+	if req == nil || req.InvoiceID == "" || req.MerchantID == "" {
+		return fmt.Errorf("%w: invoice ID and merchant ID are required", ErrInvalidRequest)
+	}
+
+	now := shared.Now().UTC()
+	first, err := s.repository.RecordView(ctx, req.InvoiceID, req.MerchantID, now)
+	if err != nil || !first {
+		return err
+	}
+
+	browser, device := ClassifyUserAgent(req.UserAgent)
+	return s.repository.CountView(ctx, req.MerchantID, now, map[Dimension]string{
+		DimensionBrowser:  browser,
+		DimensionDevice:   device,
+		DimensionReferrer: ReferrerHost(req.Referrer, req.Host),
+		DimensionCountry:  NormalizeCountry(req.Country),
+	})
+}
+
+// RecordAddressCopied records that the customer copied the payment address from an invoice's checkout page.
+// Copies from checkouts that were not viewed are ignored.
+func (s *ServiceImpl) RecordAddressCopied(ctx context.Context, invoiceID string) error {
+	return s.reach(ctx, invoiceID, StepAddressCopied)
+}
+
+// RecordPayment records that an invoice whose checkout page was shown was paid. Invoices paid without their
+// checkout page being viewed, such as those paid from merchants' own checkouts, are ignored.
+func (s *ServiceImpl) RecordPayment(ctx context.Context, invoiceID string) error {
+	return s.reach(ctx, invoiceID, StepPaid)
+}
+
+// reach records that the checkout of an invoice reached a step.
+func (s *ServiceImpl) reach(ctx context.Context, invoiceID string, step Step) error {
+	if invoiceID == "" {
+		return fmt.Errorf("%w: invoice ID is required", ErrInvalidRequest)
+	}
+
+	funnel, err := s.repository.FindByInvoice(ctx, invoiceID)
+	if errors.Is(err, ErrFunnelNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !funnel.Reach(step, shared.Now().UTC()) {
+		return nil
+	}
+	if err := s.repository.UpdateSteps(ctx, funnel); err != nil {
+		return err
+	}
+
+	s.logger.Debug("Checkout funnel step reached",
+		zap.String("invoice_id", invoiceID),
+		zap.String("step", step.String()))
+	return nil
+}
+
+// GetInvoiceFunnel retrieves the checkout funnel of a merchant's invoice.
+func (s *ServiceImpl) GetInvoiceFunnel(ctx context.Context, merchantID, invoiceID string) (*Funnel, error) {
+	if merchantID == "" || invoiceID == "" {
+		return nil, fmt.Errorf("%w: merchant ID and invoice ID are required", ErrInvalidRequest)
+	}
+
+	funnel, err := s.repository.FindByInvoice(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if funnel.MerchantID != merchantID {
+		return nil, ErrFunnelNotFound
+	}
+	return funnel, nil
+}
+
+// GetSummary summarizes the checkout funnel of a merchant's invoices first viewed in [from, to). A zero bound
+// leaves the period open on that side.
+func (s *ServiceImpl) GetSummary(ctx context.Context, merchantID string, from, to time.Time) (*Summary, error) {
+	if merchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return nil, fmt.Errorf("%w: the period must end after it starts", ErrInvalidRequest)
+	}
+
+	summary, err := s.repository.Summarize(ctx, merchantID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if summary.Breakdown == nil {
+		summary.Breakdown = make(map[Dimension]map[string]int, len(Dimensions))
+	}
+	for _, dimension := range Dimensions {
+		if summary.Breakdown[dimension] == nil {
+			summary.Breakdown[dimension] = map[string]int{}
+		}
+	}
+	return summary, nil
+}
@@ -0,0 +1,136 @@
+package checkoutanalytics
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubRepository keeps funnels and view counts in memory.
+type stubRepository struct {
+	funnels map[string]*Funnel
+	counts  map[Dimension]map[string]int
+}
+
+func (r *stubRepository) RecordView(_ context.Context, invoiceID, merchantID string, at time.Time) (bool, error) {
+	if funnel, ok := r.funnels[invoiceID]; ok {
+		funnel.Views++
+		return false, nil
+	}
+	r.funnels[invoiceID] = &Funnel{InvoiceID: invoiceID, MerchantID: merchantID, Views: 1, FirstViewedAt: at}
+	return true, nil
+}
+
+func (r *stubRepository) CountView(_ context.Context, _ string, _ time.Time, values map[Dimension]string) error {
+	for dimension, value := range values {
+		if r.counts[dimension] == nil {
+			r.counts[dimension] = map[string]int{}
+		}
+		r.counts[dimension][value]++
+	}
+	return nil
+}
+
+func (r *stubRepository) FindByInvoice(_ context.Context, invoiceID string) (*Funnel, error) {
+	funnel, ok := r.funnels[invoiceID]
+	if !ok {
+		return nil, ErrFunnelNotFound
+	}
+	copied := *funnel
+	return &copied, nil
+}
+
+func (r *stubRepository) UpdateSteps(_ context.Context, funnel *Funnel) error {
+	r.funnels[funnel.InvoiceID] = funnel
+	return nil
+}
+
+func (r *stubRepository) Summarize(_ context.Context, merchantID string, _, _ time.Time) (*Summary, error) {
+	summary := &Summary{Breakdown: r.counts}
+	for _, funnel := range r.funnels {
+		if funnel.MerchantID != merchantID {
+			continue
+		}
+		summary.Viewed++
+		if funnel.AddressCopiedAt != nil {
+			summary.AddressCopied++
+		}
+		if funnel.PaidAt != nil {
+			summary.Paid++
+		}
+	}
+	return summary, nil
+}
+
+// fixedClock tells the time it is set to.
+type fixedClock struct{ now time.Time }
+
+func (c *fixedClock) Now() time.Time { return c.now }
+
+func TestService_Funnel(t *testing.T) {
+	ctx := context.Background()
+	clock := &fixedClock{now: time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)}
+	t.Cleanup(shared.SetClock(clock))
+	repository := &stubRepository{funnels: map[string]*Funnel{}, counts: map[Dimension]map[string]int{}}
+	service := NewService(repository, zap.NewNop())
+
+	view := &ViewRequest{
+		InvoiceID:  "inv-1",
+		MerchantID: "merchant-1",
+		UserAgent:  "Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0",
+		Referrer:   "https://shop.example.org/checkout",
+		Host:       "pay.example.com",
+		Country:    "nl",
+	}
+	require.NoError(t, service.RecordView(ctx, view))
+	require.NoError(t, service.RecordView(ctx, view), "reloads are views of the same checkout")
+	require.NoError(t, service.RecordView(ctx, &ViewRequest{
+		InvoiceID: "inv-2", MerchantID: "merchant-1", Country: "ch",
+	}))
+
+	require.NoError(t, service.RecordAddressCopied(ctx, "inv-1"))
+	require.NoError(t, service.RecordAddressCopied(ctx, "inv-unviewed"), "unviewed checkouts are ignored")
+	clock.now = clock.now.Add(4 * time.Minute)
+	require.NoError(t, service.RecordPayment(ctx, "inv-1"))
+	clock.now = clock.now.Add(time.Hour)
+	require.NoError(t, service.RecordPayment(ctx, "inv-1"), "the first payment is kept")
+
+	funnel, err := service.GetInvoiceFunnel(ctx, "merchant-1", "inv-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, funnel.Views)
+	require.NotNil(t, funnel.AddressCopiedAt)
+	require.NotNil(t, funnel.TimeToPayment())
+	assert.Equal(t, 4*time.Minute, *funnel.TimeToPayment())
+
+	_, err = service.GetInvoiceFunnel(ctx, "merchant-2", "inv-1")
+	require.ErrorIs(t, err, ErrFunnelNotFound, "funnels are scoped to their merchant")
+
+	summary, err := service.GetSummary(ctx, "merchant-1", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.Viewed)
+	assert.Equal(t, 1, summary.AddressCopied)
+	assert.Equal(t, 1, summary.Paid)
+	assert.InDelta(t, 0.5, summary.ConversionRate(), 0.0001)
+	assert.Equal(t, map[string]int{"firefox": 1, Unknown: 1}, summary.Breakdown[DimensionBrowser])
+	assert.Equal(t, map[string]int{"shop.example.org": 1, Direct: 1}, summary.Breakdown[DimensionReferrer])
+	assert.Equal(t, map[string]int{"NL": 1, "CH": 1}, summary.Breakdown[DimensionCountry])
+
+	_, err = service.GetSummary(ctx, "merchant-1", clock.now, clock.now)
+	require.ErrorIs(t, err, ErrInvalidRequest)
+}
+
+func TestService_UnknownCountry(t *testing.T) {
+	ctx := context.Background()
+	repository := &stubRepository{funnels: map[string]*Funnel{}, counts: map[Dimension]map[string]int{}}
+	service := NewService(repository, zap.NewNop())
+
+	require.NoError(t, service.RecordView(ctx, &ViewRequest{
+		InvoiceID: "inv-1", MerchantID: "merchant-1", Country: "T1",
+	}))
+	assert.Equal(t, map[string]int{Unknown: 1}, repository.counts[DimensionCountry])
+}
@@ -0,0 +1,131 @@
+package compliance
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"strings"
+	"time"
+)
+
+// BlocklistEntry is a blockchain address that must not be accepted as a payment sender.
+type BlocklistEntry struct {
+	id         string
+	merchantID string
+	address    string
+	network    string
+	source     BlocklistSource
+	reason     string
+	reference  string
+	createdBy  string
+	createdAt  time.Time
+}
+
+// NewBlocklistEntry creates a new blocklist entry. Entries without a merchant apply to all merchants.
+func NewBlocklistEntry(
+	id, merchantID, address, network string,
+	source BlocklistSource,
+	reason, reference, createdBy string,
+) (*BlocklistEntry, error) {
+	return RestoreBlocklistEntry(
+		id, merchantID, address, network, source, reason, reference, createdBy, shared.Now().UTC(),
+	)
+}
+
+// RestoreBlocklistEntry recreates a blocklist entry from storage.
+func RestoreBlocklistEntry(
+	id, merchantID, address, network string,
+	source BlocklistSource,
+	reason, reference, createdBy string,
+	createdAt time.Time,
+) (*BlocklistEntry, error) {
+	if id == "" {
+		return nil, errors.New("blocklist entry ID is required")
+	}
+	address = NormalizeAddress(address)
+	if address == "" {
+		return nil, errors.New("address is required")
+	}
+	if !source.IsValid() {
+		return nil, errors.New("invalid blocklist source")
+	}
+	if source == BlocklistSourceCustom && merchantID == "" {
+		return nil, errors.New("custom blocklist entries require a merchant")
+	}
+
+	return &BlocklistEntry{
+		id:         id,
+		merchantID: merchantID,
+		address:    address,
+		network:    strings.ToLower(strings.TrimSpace(network)),
+		source:     source,
+		reason:     reason,
+		reference:  reference,
+		createdBy:  createdBy,
+		createdAt:  createdAt,
+	}, nil
+}
+
+// NormalizeAddress returns the canonical form of an address for matching. EVM addresses are
+// case-insensitive and are lowercased; other formats such as base58 are case-sensitive.
+func NormalizeAddress(address string) string {
+	address = strings.TrimSpace(address)
+	if strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X") {
+		return strings.ToLower(address)
+	}
+	return address
+}
+
+// ID returns the entry ID.
+func (e *BlocklistEntry) ID() string {
+	return e.id
+}
+
+// MerchantID returns the merchant that owns a custom entry, or empty for global entries.
+func (e *BlocklistEntry) MerchantID() string {
+	return e.merchantID
+}
+
+// Address returns the normalized blocked address.
+func (e *BlocklistEntry) Address() string {
+	return e.address
+}
+
+// Network returns the network or asset code the address was listed for, if known.
+func (e *BlocklistEntry) Network() string {
+	return e.network
+}
+
+// Source returns where the entry came from.
+func (e *BlocklistEntry) Source() BlocklistSource {
+	return e.source
+}
+
+// Reason returns why the address is blocked.
+func (e *BlocklistEntry) Reason() string {
+	return e.reason
+}
+
+// Reference returns the identifier of the listing in its source, e.g. the SDN entry UID.
+func (e *BlocklistEntry) Reference() string {
+	return e.reference
+}
+
+// CreatedBy returns who added a custom entry.
+func (e *BlocklistEntry) CreatedBy() string {
+	return e.createdBy
+}
+
+// CreatedAt returns when the entry was added.
+func (e *BlocklistEntry) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// IsGlobal returns true if the entry applies to all merchants.
+func (e *BlocklistEntry) IsGlobal() bool {
+	return e.merchantID == ""
+}
+
+// AppliesTo returns true if the entry blocks payments to the merchant.
+func (e *BlocklistEntry) AppliesTo(merchantID string) bool {
+	return e.IsGlobal() || e.merchantID == merchantID
+}
@@ -0,0 +1,305 @@
+package compliance
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+// BlocklistService defines the interface for managing and checking address blocklists.
+type BlocklistService interface {
+	// Check returns the entry blocking payments from the address to the merchant, if any.
+	Check(ctx context.Context, merchantID, address string) (*BlocklistEntry, error)
+
+	// AddEntry adds an address to a merchant's custom blocklist.
+	AddEntry(ctx context.Context, req *AddBlocklistEntryRequest) (*BlocklistEntry, error)
+
+	// RemoveEntry removes an address from a merchant's custom blocklist.
+	RemoveEntry(ctx context.Context, req *RemoveBlocklistEntryRequest) (*BlocklistEntry, error)
+
+	// ListEntries lists blocklist entries visible to a merchant.
+	ListEntries(ctx context.Context, req *ListBlocklistRequest) (*ListBlocklistResponse, error)
+
+	// SyncSanctions replaces the global entries with the current sanctions list and returns their count.
+	SyncSanctions(ctx context.Context) (int, error)
+
+	// Reload rebuilds the in-memory index from storage, picking up changes made by other instances.
+	Reload(ctx context.Context) error
+}
+
+// AddBlocklistEntryRequest represents the request to block an address for a merchant.
+type AddBlocklistEntryRequest struct {
+	MerchantID string `validate:"required"`
+	Address    string `validate:"required,max=128"`
+	Network    string `validate:"max=20"`
+	Reason     string `validate:"max=500"`
+	CreatedBy  string
+}
+
+// RemoveBlocklistEntryRequest represents the request to unblock an address for a merchant.
+type RemoveBlocklistEntryRequest struct {
+	MerchantID string `validate:"required"`
+	EntryID    string `validate:"required"`
+}
+
+// BlocklistServiceImpl implements the BlocklistService interface. Checks are served from an
+// in-memory index keyed by normalized address that is swapped atomically on every reload.
+type BlocklistServiceImpl struct {
+	blocklist BlocklistRepository
+	sanctions SanctionsList
+	logger    *zap.Logger
+
+	mu       sync.RWMutex
+	index    map[string][]*BlocklistEntry
+	loaded   bool
+	syncedAt time.Time
+}
+
+// NewBlocklistService creates a new blocklist service.
+// The sanctions list may be nil, in which case only custom entries are enforced.
+func NewBlocklistService(
+	blocklist BlocklistRepository,
+	sanctions SanctionsList,
+	logger *zap.Logger,
+) *BlocklistServiceImpl {
+	return &BlocklistServiceImpl{
+		blocklist: blocklist,
+		sanctions: sanctions,
+		logger:    logger,
+		index:     make(map[string][]*BlocklistEntry),
+	}
+}
+
+// Check returns the entry blocking payments from the address to the merchant, if any.
+func (s *BlocklistServiceImpl) Check(ctx context.Context, merchantID, address string) (*BlocklistEntry, error) {
+	if err := s.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, entry := range s.index[NormalizeAddress(address)] {
+		if entry.AppliesTo(merchantID) {
+			return entry, nil
+		}
+	}
+	return nil, nil
+}
+
+// AddEntry adds an address to a merchant's custom blocklist.
+func (s *BlocklistServiceImpl) AddEntry(ctx context.Context, req *AddBlocklistEntryRequest) (*BlocklistEntry, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: add blocklist entry request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	existing, err := s.blocklist.List(ctx, &ListBlocklistRequest{
+		MerchantID: req.MerchantID,
+		Source:     BlocklistSourceCustom,
+		Address:    NormalizeAddress(req.Address),
+		Limit:      1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing entries: %w", err)
+	}
+	if existing.Total > 0 {
+		return nil, ErrBlocklistEntryExists
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate blocklist entry ID: %w", err)
+	}
+
+	entry, err := NewBlocklistEntry(
+		id, req.MerchantID, req.Address, req.Network, BlocklistSourceCustom, req.Reason, "", req.CreatedBy,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	if err := s.blocklist.Save(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to save blocklist entry: %w", err)
+	}
+
+	s.mu.Lock()
+	s.index[entry.Address()] = append(s.index[entry.Address()], entry)
+	s.mu.Unlock()
+
+	s.logger.Info("Address added to blocklist",
+		zap.String("merchant_id", entry.MerchantID()),
+		zap.String("entry_id", entry.ID()),
+	)
+	return entry, nil
+}
+
+// RemoveEntry removes an address from a merchant's custom blocklist.
+func (s *BlocklistServiceImpl) RemoveEntry(
+	ctx context.Context,
+	req *RemoveBlocklistEntryRequest,
+) (*BlocklistEntry, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: remove blocklist entry request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	entry, err := s.blocklist.FindByID(ctx, req.EntryID)
+	if err != nil {
+		return nil, err
+	}
+	// Global entries are managed by sanctions list ingestion only
+	if entry.MerchantID() != req.MerchantID {
+		return nil, ErrBlocklistEntryNotFound
+	}
+
+	if err := s.blocklist.Delete(ctx, entry.ID()); err != nil {
+		return nil, fmt.Errorf("failed to delete blocklist entry: %w", err)
+	}
+
+	s.mu.Lock()
+	remaining := s.index[entry.Address()][:0:0]
+	for _, indexed := range s.index[entry.Address()] {
+		if indexed.ID() != entry.ID() {
+			remaining = append(remaining, indexed)
+		}
+	}
+	if len(remaining) == 0 {
+		delete(s.index, entry.Address())
+	} else {
+		s.index[entry.Address()] = remaining
+	}
+	s.mu.Unlock()
+
+	return entry, nil
+}
+
+// ListEntries lists blocklist entries visible to a merchant.
+func (s *BlocklistServiceImpl) ListEntries(
+	ctx context.Context,
+	req *ListBlocklistRequest,
+) (*ListBlocklistResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: list blocklist request cannot be nil", ErrInvalidRequest)
+	}
+	if req.MerchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+	if req.Source != "" && !req.Source.IsValid() {
+		return nil, fmt.Errorf("%w: invalid source %s", ErrInvalidRequest, req.Source)
+	}
+
+	filter := *req
+	filter.Address = NormalizeAddress(req.Address)
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	filter.Offset = max(filter.Offset, 0)
+
+	return s.blocklist.List(ctx, &filter)
+}
+
+// SyncSanctions replaces the global entries with the current sanctions list and returns their count.
+func (s *BlocklistServiceImpl) SyncSanctions(ctx context.Context) (int, error) {
+	if s.sanctions == nil {
+		return 0, ErrSanctionsListDisabled
+	}
+
+	listed, err := s.sanctions.Fetch(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch %s list: %w", s.sanctions.Name(), err)
+	}
+	// An empty list almost certainly means a broken download; keep the previous entries
+	if len(listed) == 0 {
+		return 0, fmt.Errorf("%w: %s list is empty", ErrProviderFailure, s.sanctions.Name())
+	}
+
+	entries := make([]*BlocklistEntry, 0, len(listed))
+	seen := make(map[string]bool, len(listed))
+	for _, sanctioned := range listed {
+		address := NormalizeAddress(sanctioned.Address)
+		if address == "" || seen[address] {
+			continue
+		}
+		seen[address] = true
+
+		id, err := generateID()
+		if err != nil {
+			return 0, fmt.Errorf("failed to generate blocklist entry ID: %w", err)
+		}
+		entry, err := NewBlocklistEntry(
+			id, "", address, sanctioned.Network, BlocklistSourceOFAC, sanctioned.Name, sanctioned.Reference, s.sanctions.Name(),
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create blocklist entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := s.blocklist.ReplaceSource(ctx, BlocklistSourceOFAC, entries); err != nil {
+		return 0, fmt.Errorf("failed to store sanctions list: %w", err)
+	}
+
+	s.mu.Lock()
+	s.syncedAt = shared.Now().UTC()
+	s.mu.Unlock()
+
+	if err := s.Reload(ctx); err != nil {
+		return 0, err
+	}
+
+	s.logger.Info("Sanctions list synchronized",
+		zap.String("list", s.sanctions.Name()),
+		zap.Int("addresses", len(entries)),
+	)
+	return len(entries), nil
+}
+
+// Reload rebuilds the in-memory index from storage, picking up changes made by other instances.
+func (s *BlocklistServiceImpl) Reload(ctx context.Context) error {
+	entries, err := s.blocklist.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load blocklist: %w", err)
+	}
+
+	index := make(map[string][]*BlocklistEntry, len(entries))
+	for _, entry := range entries {
+		index[entry.Address()] = append(index[entry.Address()], entry)
+	}
+
+	s.mu.Lock()
+	s.index = index
+	s.loaded = true
+	s.mu.Unlock()
+	return nil
+}
+
+// SyncedAt returns when the sanctions list was last synchronized, or zero if never.
+func (s *BlocklistServiceImpl) SyncedAt() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.syncedAt
+}
+
+// ensureLoaded loads the index on first use.
+func (s *BlocklistServiceImpl) ensureLoaded(ctx context.Context) error {
+	s.mu.RLock()
+	loaded := s.loaded
+	s.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+	return s.Reload(ctx)
+}
@@ -0,0 +1,116 @@
+package compliance
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// BlocklistSchedule configures background maintenance of the blocklist index.
+type BlocklistSchedule struct {
+	// SyncInterval is how often the sanctions list is downloaded. Zero disables ingestion.
+	SyncInterval time.Duration
+	// ReloadInterval is how often the index is rebuilt from storage so that entries
+	// changed by other instances take effect without a restart.
+	ReloadInterval time.Duration
+}
+
+// DefaultBlocklistSchedule syncs sanctions daily and reloads the index every minute.
+func DefaultBlocklistSchedule() BlocklistSchedule {
+	return BlocklistSchedule{
+		SyncInterval:   24 * time.Hour,
+		ReloadInterval: time.Minute,
+	}
+}
+
+// BlocklistSyncer keeps the blocklist index current in the background.
+type BlocklistSyncer struct {
+	service  BlocklistService
+	schedule BlocklistSchedule
+	logger   *zap.Logger
+	lastSync time.Time
+}
+
+// NewBlocklistSyncer creates a new background blocklist syncer.
+func NewBlocklistSyncer(service BlocklistService, schedule BlocklistSchedule, logger *zap.Logger) *BlocklistSyncer {
+	if schedule.ReloadInterval <= 0 {
+		schedule.ReloadInterval = DefaultBlocklistSchedule().ReloadInterval
+	}
+	return &BlocklistSyncer{
+		service:  service,
+		schedule: schedule,
+		logger:   logger,
+	}
+}
+
+// Run reloads the index and syncs the sanctions list when due until the context is cancelled.
+// A failed sync is retried on the next reload tick.
+func (s *BlocklistSyncer) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.schedule.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		shared.Beat(ctx)
+		s.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick performs one maintenance round.
+func (s *BlocklistSyncer) tick(ctx context.Context) {
+	if s.schedule.SyncInterval > 0 && shared.Now().Sub(s.lastSync) >= s.schedule.SyncInterval {
+		_, err := s.service.SyncSanctions(ctx)
+		switch {
+		case err == nil:
+			s.lastSync = shared.Now()
+			return
+		case errors.Is(err, ErrSanctionsListDisabled):
+			s.schedule.SyncInterval = 0
+		case ctx.Err() == nil:
+			s.logger.Error("Failed to sync sanctions list", zap.Error(err))
+		}
+	}
+
+	if err := s.service.Reload(ctx); err != nil && ctx.Err() == nil {
+		s.logger.Error("Failed to reload blocklist", zap.Error(err))
+	}
+}
+
+// RegisterBlocklistSyncer runs the blocklist syncer for the lifetime of the application.
+func RegisterBlocklistSyncer(
+	lc fx.Lifecycle,
+	syncer *BlocklistSyncer,
+	workers shared.WorkerSupervisor,
+	logger *zap.Logger,
+) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			logger.Info("Starting blocklist syncer")
+			go func() {
+				defer close(done)
+				workers.Supervise(ctx, "blocklist_syncer", syncer.schedule.ReloadInterval, syncer.Run)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,47 @@
+package compliance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  string
+		expected string
+	}{
+		{"evm lowercased", "0xAbC123", "0xabc123"},
+		{"evm upper prefix", "0XABC", "0xabc"},
+		{"base58 preserved", "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"},
+		{"whitespace trimmed", "  1BoatSLRHtKNngkdXEeobR76b53LETtpyT\n", "1BoatSLRHtKNngkdXEeobR76b53LETtpyT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeAddress(tt.address))
+		})
+	}
+}
+
+func TestBlocklistEntry_AppliesTo(t *testing.T) {
+	global, err := NewBlocklistEntry("entry-1", "", "0xABC", "ETH", BlocklistSourceOFAC, "Lazarus Group", "12345", "")
+	require.NoError(t, err)
+	assert.Equal(t, "0xabc", global.Address())
+	assert.Equal(t, "eth", global.Network())
+	assert.True(t, global.IsGlobal())
+	assert.True(t, global.AppliesTo("merchant-1"))
+
+	custom, err := NewBlocklistEntry("entry-2", "merchant-1", "TAddr", "", BlocklistSourceCustom, "chargeback", "", "user-1")
+	require.NoError(t, err)
+	assert.True(t, custom.AppliesTo("merchant-1"))
+	assert.False(t, custom.AppliesTo("merchant-2"))
+
+	_, err = NewBlocklistEntry("entry-3", "", "TAddr", "", BlocklistSourceCustom, "", "", "")
+	require.Error(t, err, "custom entries require a merchant")
+
+	_, err = NewBlocklistEntry("entry-4", "merchant-1", "  ", "", BlocklistSourceCustom, "", "", "")
+	require.Error(t, err, "address is required")
+}
@@ -0,0 +1,27 @@
+package compliance
+
+import (
+	"crypto-checkout/internal/domain/payment"
+
+	"go.uber.org/fx"
+)
+
+// Module provides the compliance service layer dependencies.
+var Module = fx.Module("compliance-service",
+	fx.Provide(
+		fx.Annotate(
+			NewBlocklistService,
+			fx.As(new(BlocklistService)),
+		),
+		NewBlocklistSyncer,
+		fx.Annotate(
+			NewPaymentScreener,
+			fx.As(new(payment.Screener)),
+		),
+		fx.Annotate(
+			NewReviewService,
+			fx.As(new(ReviewService)),
+		),
+	),
+	fx.Invoke(RegisterBlocklistSyncer),
+)
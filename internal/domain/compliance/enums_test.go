@@ -0,0 +1,80 @@
+package compliance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRiskLevel_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		level    RiskLevel
+		expected bool
+	}{
+		{"low", RiskLevelLow, true},
+		{"medium", RiskLevelMedium, true},
+		{"high", RiskLevelHigh, true},
+		{"severe", RiskLevelSevere, true},
+		{"unknown", RiskLevelUnknown, true},
+		{"invalid", RiskLevel("critical"), false},
+		{"empty", RiskLevel(""), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.level.IsValid())
+		})
+	}
+}
+
+func TestRiskLevel_AtLeast(t *testing.T) {
+	tests := []struct {
+		name      string
+		level     RiskLevel
+		threshold RiskLevel
+		expected  bool
+	}{
+		{"low below high", RiskLevelLow, RiskLevelHigh, false},
+		{"medium below high", RiskLevelMedium, RiskLevelHigh, false},
+		{"high at high", RiskLevelHigh, RiskLevelHigh, true},
+		{"severe above high", RiskLevelSevere, RiskLevelHigh, true},
+		{"unknown ranks as high", RiskLevelUnknown, RiskLevelHigh, true},
+		{"unknown below severe", RiskLevelUnknown, RiskLevelSevere, false},
+		{"medium at medium", RiskLevelMedium, RiskLevelMedium, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.level.AtLeast(tt.threshold))
+		})
+	}
+}
+
+func TestScreeningStatus_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   ScreeningStatus
+		expected bool
+	}{
+		{"cleared", ScreeningStatusCleared, true},
+		{"held", ScreeningStatusHeld, true},
+		{"approved", ScreeningStatusApproved, true},
+		{"rejected", ScreeningStatusRejected, true},
+		{"invalid", ScreeningStatus("pending"), false},
+		{"empty", ScreeningStatus(""), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.status.IsValid())
+		})
+	}
+}
+
+func TestBlocklistSource_IsValid(t *testing.T) {
+	assert.True(t, BlocklistSourceOFAC.IsValid())
+	assert.True(t, BlocklistSourceCustom.IsValid())
+	assert.False(t, BlocklistSource("sanctions").IsValid())
+	assert.False(t, BlocklistSource("").IsValid())
+}
@@ -0,0 +1,23 @@
+package compliance
+
+import "errors"
+
+// Domain errors for compliance operations
+var (
+	ErrScreeningNotFound      = errors.New("screening not found")
+	ErrScreeningNotHeld       = errors.New("screening is not awaiting review")
+	ErrInvalidRiskLevel       = errors.New("invalid risk level")
+	ErrInvalidRequest         = errors.New("invalid compliance request")
+	ErrProviderFailure        = errors.New("screening provider request failed")
+	ErrBlocklistEntryNotFound = errors.New("blocklist entry not found")
+	ErrBlocklistEntryExists   = errors.New("address is already blocklisted")
+	ErrSanctionsListDisabled  = errors.New("sanctions list ingestion is not configured")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeScreeningNotFound      = "SCREENING_NOT_FOUND"
+	ErrCodeScreeningNotHeld       = "SCREENING_NOT_HELD"
+	ErrCodeBlocklistEntryNotFound = "BLOCKLIST_ENTRY_NOT_FOUND"
+	ErrCodeBlocklistEntryExists   = "BLOCKLIST_ENTRY_EXISTS"
+)
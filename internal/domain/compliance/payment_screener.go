@@ -0,0 +1,173 @@
+package compliance
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Policy configures when screened payments are held for review.
+type Policy struct {
+	// HoldThreshold is the lowest risk level that holds a payment for manual review.
+	HoldThreshold RiskLevel
+}
+
+// DefaultPolicy holds payments from high and severe risk senders.
+func DefaultPolicy() Policy {
+	return Policy{HoldThreshold: RiskLevelHigh}
+}
+
+// PaymentScreener checks the senders of detected payments against the blocklist and the
+// screening provider and records the outcome. It implements payment.Screener.
+type PaymentScreener struct {
+	screener    TransactionScreener
+	blocklist   BlocklistService
+	repository  Repository
+	invoiceRepo invoice.Repository
+	policy      Policy
+	logger      *zap.Logger
+}
+
+// NewPaymentScreener creates a new payment screener.
+// The transaction screener and blocklist may be nil, in which case that check is skipped.
+func NewPaymentScreener(
+	screener TransactionScreener,
+	blocklist BlocklistService,
+	repository Repository,
+	invoiceRepo invoice.Repository,
+	policy Policy,
+	logger *zap.Logger,
+) *PaymentScreener {
+	if !policy.HoldThreshold.IsValid() {
+		policy = DefaultPolicy()
+	}
+
+	return &PaymentScreener{
+		screener:    screener,
+		blocklist:   blocklist,
+		repository:  repository,
+		invoiceRepo: invoiceRepo,
+		policy:      policy,
+		logger:      logger,
+	}
+}
+
+// ScreenPayment screens the payment sender and returns true when the payment must be held.
+// Blocklisted senders are always held. Provider failures are recorded as held screenings so
+// the payment is reviewed manually.
+func (s *PaymentScreener) ScreenPayment(ctx context.Context, p *payment.Payment) (bool, error) {
+	if (s.screener == nil && s.blocklist == nil) || p == nil {
+		return false, nil
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return false, fmt.Errorf("failed to generate screening ID: %w", err)
+	}
+
+	merchantID := s.resolveMerchantID(ctx, p)
+	network := string(p.ToAddress().Network())
+
+	if s.blocklist != nil {
+		entry, err := s.blocklist.Check(ctx, merchantID, p.FromAddress())
+		if err != nil {
+			// Fail closed: a sender that cannot be checked is reviewed manually
+			s.logger.Error("Blocklist check failed", zap.String("payment_id", string(p.ID())), zap.Error(err))
+			screening, createErr := NewFailedScreening(
+				id, merchantID, string(p.ID()), string(p.InvoiceID()), p.FromAddress(), network, BlocklistProvider, err,
+			)
+			return s.save(ctx, p, screening, createErr)
+		}
+		if entry != nil {
+			screening, createErr := NewBlockedScreening(
+				id, merchantID, string(p.ID()), string(p.InvoiceID()), p.FromAddress(), network, entry,
+			)
+			return s.save(ctx, p, screening, createErr)
+		}
+	}
+
+	if s.screener == nil {
+		return false, nil
+	}
+
+	req := &ScreeningRequest{
+		Address:         p.FromAddress(),
+		Network:         network,
+		Asset:           string(p.Amount().Currency()),
+		Amount:          p.Amount().Amount().String(),
+		TransactionHash: p.TransactionHash().String(),
+	}
+
+	var screening *Screening
+	result, screenErr := s.screener.ScreenAddress(ctx, req)
+	if screenErr != nil {
+		s.logger.Error("Address screening failed",
+			zap.String("payment_id", string(p.ID())),
+			zap.String("provider", s.screener.Name()),
+			zap.Error(screenErr),
+		)
+		screening, err = NewFailedScreening(
+			id, merchantID, string(p.ID()), string(p.InvoiceID()), p.FromAddress(), network, s.screener.Name(), screenErr,
+		)
+	} else {
+		screening, err = NewScreening(
+			id, merchantID, string(p.ID()), string(p.InvoiceID()), p.FromAddress(), network, s.screener.Name(),
+			result, s.policy.HoldThreshold,
+		)
+	}
+	return s.save(ctx, p, screening, err)
+}
+
+// save persists the screening of the payment and returns whether the payment must be held.
+func (s *PaymentScreener) save(ctx context.Context, p *payment.Payment, screening *Screening, err error) (bool, error) {
+	if err != nil {
+		return true, fmt.Errorf("failed to create screening: %w", err)
+	}
+
+	if err := s.repository.Save(ctx, screening); err != nil {
+		return true, fmt.Errorf("failed to save screening: %w", err)
+	}
+
+	if screening.IsHeld() {
+		s.logger.Warn("Payment held for compliance review",
+			zap.String("payment_id", string(p.ID())),
+			zap.String("screening_id", screening.ID()),
+			zap.String("risk_level", string(screening.RiskLevel())),
+			zap.String("provider", screening.Provider()),
+		)
+	}
+
+	return screening.IsHeld(), nil
+}
+
+// resolveMerchantID finds the merchant that received the payment, so reviews can be scoped to it.
+func (s *PaymentScreener) resolveMerchantID(ctx context.Context, p *payment.Payment) string {
+	if s.invoiceRepo == nil {
+		return ""
+	}
+
+	inv, err := s.invoiceRepo.FindByID(ctx, string(p.InvoiceID()))
+	if err != nil {
+		s.logger.Warn("Failed to resolve merchant for screened payment",
+			zap.String("payment_id", string(p.ID())),
+			zap.Error(err),
+		)
+		return ""
+	}
+
+	return inv.MerchantID()
+}
+
+// generateID generates a random ID.
+func generateID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,79 @@
+package compliance
+
+import (
+	"context"
+)
+
+// Repository defines the interface for screening persistence.
+type Repository interface {
+	// Save persists a new screening.
+	Save(ctx context.Context, screening *Screening) error
+
+	// FindByID retrieves a screening by its ID.
+	FindByID(ctx context.Context, id string) (*Screening, error)
+
+	// FindByPaymentID retrieves the screening of a payment.
+	FindByPaymentID(ctx context.Context, paymentID string) (*Screening, error)
+
+	// List retrieves screenings matching the filter, newest first.
+	List(ctx context.Context, req *ListScreeningsRequest) (*ListScreeningsResponse, error)
+
+	// Update updates an existing screening.
+	Update(ctx context.Context, screening *Screening) error
+}
+
+// ListScreeningsRequest represents the request to list screenings.
+// Empty filter fields match all screenings.
+type ListScreeningsRequest struct {
+	MerchantID string
+	Status     ScreeningStatus
+	Limit      int
+	Offset     int
+}
+
+// ListScreeningsResponse represents the response from listing screenings.
+type ListScreeningsResponse struct {
+	Screenings []*Screening
+	Total      int
+	Limit      int
+	Offset     int
+}
+
+// BlocklistRepository defines the interface for blocklist persistence.
+type BlocklistRepository interface {
+	// Save saves a new blocklist entry.
+	Save(ctx context.Context, entry *BlocklistEntry) error
+
+	// FindByID finds a blocklist entry by ID.
+	FindByID(ctx context.Context, id string) (*BlocklistEntry, error)
+
+	// Delete removes a blocklist entry.
+	Delete(ctx context.Context, id string) error
+
+	// List retrieves blocklist entries matching the filter, newest first.
+	List(ctx context.Context, req *ListBlocklistRequest) (*ListBlocklistResponse, error)
+
+	// FindAll retrieves every blocklist entry, used to build the in-memory index.
+	FindAll(ctx context.Context) ([]*BlocklistEntry, error)
+
+	// ReplaceSource atomically replaces all global entries of a source with the given entries.
+	ReplaceSource(ctx context.Context, source BlocklistSource, entries []*BlocklistEntry) error
+}
+
+// ListBlocklistRequest represents the filter for listing blocklist entries.
+type ListBlocklistRequest struct {
+	MerchantID    string // Custom entries of this merchant
+	IncludeGlobal bool   // Also include entries that apply to all merchants
+	Source        BlocklistSource
+	Address       string
+	Limit         int
+	Offset        int
+}
+
+// ListBlocklistResponse represents a page of blocklist entries.
+type ListBlocklistResponse struct {
+	Entries []*BlocklistEntry
+	Total   int
+	Limit   int
+	Offset  int
+}
@@ -0,0 +1,241 @@
+package compliance
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultListLimit is the default page size for listing reviews.
+	defaultListLimit = 20
+	// maxListLimit is the maximum page size for listing reviews.
+	maxListLimit = 100
+)
+
+// ReviewService defines the interface for manual review of held payments.
+type ReviewService interface {
+	// ListReviews lists payment screenings for a merchant.
+	ListReviews(ctx context.Context, req *ListReviewsRequest) (*ListScreeningsResponse, error)
+
+	// GetReview retrieves a payment screening.
+	GetReview(ctx context.Context, req *GetReviewRequest) (*Screening, error)
+
+	// GetPaymentScreening retrieves the screening of a payment.
+	GetPaymentScreening(ctx context.Context, req *GetPaymentScreeningRequest) (*Screening, error)
+
+	// ApproveReview releases a held payment so it can be confirmed.
+	ApproveReview(ctx context.Context, req *ReviewDecisionRequest) (*Screening, error)
+
+	// RejectReview rejects a held payment and fails it.
+	RejectReview(ctx context.Context, req *ReviewDecisionRequest) (*Screening, error)
+}
+
+// ListReviewsRequest represents the request to list payment screenings.
+type ListReviewsRequest struct {
+	MerchantID string `validate:"required"`
+	Status     ScreeningStatus
+	Limit      int
+	Offset     int
+}
+
+// GetReviewRequest represents the request to get a payment screening.
+type GetReviewRequest struct {
+	MerchantID  string `validate:"required"`
+	ScreeningID string `validate:"required"`
+}
+
+// GetPaymentScreeningRequest represents the request to get the screening of a payment.
+type GetPaymentScreeningRequest struct {
+	MerchantID string `validate:"required"`
+	PaymentID  string `validate:"required"`
+}
+
+// ReviewDecisionRequest represents a reviewer's decision on a held payment.
+type ReviewDecisionRequest struct {
+	MerchantID  string `validate:"required"`
+	ScreeningID string `validate:"required"`
+	ReviewerID  string `validate:"required"`
+	Note        string `validate:"max=1000"`
+}
+
+// ReviewServiceImpl implements the ReviewService interface.
+type ReviewServiceImpl struct {
+	repository     Repository
+	paymentService payment.PaymentService
+	auditService   audit.Service
+	logger         *zap.Logger
+}
+
+// NewReviewService creates a new review service.
+func NewReviewService(
+	repository Repository,
+	paymentService payment.PaymentService,
+	auditService audit.Service,
+	logger *zap.Logger,
+) ReviewService {
+	return &ReviewServiceImpl{
+		repository:     repository,
+		paymentService: paymentService,
+		auditService:   auditService,
+		logger:         logger,
+	}
+}
+
+// ListReviews lists payment screenings for a merchant.
+func (s *ReviewServiceImpl) ListReviews(
+	ctx context.Context,
+	req *ListReviewsRequest,
+) (*ListScreeningsResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: list reviews request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	if req.Status != "" && !req.Status.IsValid() {
+		return nil, fmt.Errorf("%w: invalid status %s", ErrInvalidRequest, req.Status)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	offset := max(req.Offset, 0)
+
+	return s.repository.List(ctx, &ListScreeningsRequest{
+		MerchantID: req.MerchantID,
+		Status:     req.Status,
+		Limit:      limit,
+		Offset:     offset,
+	})
+}
+
+// GetReview retrieves a payment screening.
+func (s *ReviewServiceImpl) GetReview(ctx context.Context, req *GetReviewRequest) (*Screening, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: get review request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	return s.findMerchantScreening(ctx, req.MerchantID, req.ScreeningID)
+}
+
+// GetPaymentScreening retrieves the screening of a payment.
+func (s *ReviewServiceImpl) GetPaymentScreening(
+	ctx context.Context,
+	req *GetPaymentScreeningRequest,
+) (*Screening, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: get payment screening request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	screening, err := s.repository.FindByPaymentID(ctx, req.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	if screening.MerchantID() != req.MerchantID {
+		return nil, ErrScreeningNotFound
+	}
+	return screening, nil
+}
+
+// ApproveReview releases a held payment so it can be confirmed.
+func (s *ReviewServiceImpl) ApproveReview(ctx context.Context, req *ReviewDecisionRequest) (*Screening, error) {
+	return s.decide(ctx, req, "compliance.review_approved", func(screening *Screening) error {
+		if err := screening.Approve(req.ReviewerID, req.Note); err != nil {
+			return err
+		}
+		return s.paymentService.ReleasePayment(ctx, shared.PaymentID(screening.PaymentID()))
+	})
+}
+
+// RejectReview rejects a held payment and fails it.
+func (s *ReviewServiceImpl) RejectReview(ctx context.Context, req *ReviewDecisionRequest) (*Screening, error) {
+	return s.decide(ctx, req, "compliance.review_rejected", func(screening *Screening) error {
+		if err := screening.Reject(req.ReviewerID, req.Note); err != nil {
+			return err
+		}
+		return s.paymentService.UpdatePaymentStatus(ctx, shared.PaymentID(screening.PaymentID()), "fail")
+	})
+}
+
+// decide applies a review decision to a held screening, persists it and records it in the audit log.
+func (s *ReviewServiceImpl) decide(
+	ctx context.Context,
+	req *ReviewDecisionRequest,
+	action string,
+	apply func(*Screening) error,
+) (*Screening, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: review decision request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	screening, err := s.findMerchantScreening(ctx, req.MerchantID, req.ScreeningID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := screening.Status()
+	if err := apply(screening); err != nil {
+		if errors.Is(err, ErrScreeningNotHeld) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to apply review decision: %w", err)
+	}
+
+	if err := s.repository.Update(ctx, screening); err != nil {
+		return nil, fmt.Errorf("failed to update screening: %w", err)
+	}
+
+	if err := s.auditService.Record(ctx, &audit.RecordRequest{
+		MerchantID:   screening.MerchantID(),
+		Actor:        audit.Actor{ID: req.ReviewerID, Type: audit.ActorTypeUser},
+		Action:       action,
+		ResourceType: "payment",
+		ResourceID:   screening.PaymentID(),
+		Before:       map[string]interface{}{"status": string(before)},
+		After:        map[string]interface{}{"status": string(screening.Status()), "note": screening.ReviewNote()},
+		Details: map[string]interface{}{
+			"screening_id": screening.ID(),
+			"risk_level":   string(screening.RiskLevel()),
+		},
+	}); err != nil {
+		s.logger.Error("Failed to record audit entry",
+			zap.String("screening_id", screening.ID()),
+			zap.String("action", action),
+			zap.Error(err),
+		)
+	}
+
+	return screening, nil
+}
+
+// findMerchantScreening loads a screening and ensures it belongs to the merchant.
+func (s *ReviewServiceImpl) findMerchantScreening(ctx context.Context, merchantID, id string) (*Screening, error) {
+	screening, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if screening.MerchantID() != merchantID {
+		return nil, ErrScreeningNotFound
+	}
+	return screening, nil
+}
@@ -0,0 +1,46 @@
+package compliance
+
+import "context"
+
+// TransactionScreener screens blockchain addresses against an AML/KYT provider.
+type TransactionScreener interface {
+	// Name returns the provider name recorded with each screening.
+	Name() string
+
+	// ScreenAddress rates the risk of the sender address of a transaction.
+	ScreenAddress(ctx context.Context, req *ScreeningRequest) (*ScreeningResult, error)
+}
+
+// ScreeningRequest describes the transaction whose sender is screened.
+type ScreeningRequest struct {
+	Address         string
+	Network         string
+	Asset           string
+	Amount          string
+	TransactionHash string
+}
+
+// ScreeningResult is the provider's assessment of an address.
+type ScreeningResult struct {
+	RiskLevel  RiskLevel
+	RiskScore  float64
+	Categories []string
+	Reference  string // Provider-side identifier of the assessment
+}
+
+// SanctionsList fetches a published list of sanctioned blockchain addresses.
+type SanctionsList interface {
+	// Name returns the list name recorded with each entry.
+	Name() string
+
+	// Fetch downloads the current list.
+	Fetch(ctx context.Context) ([]SanctionedAddress, error)
+}
+
+// SanctionedAddress is an address published on a sanctions list.
+type SanctionedAddress struct {
+	Address   string
+	Network   string // Asset code the address was listed for, e.g. "eth"
+	Reference string // Identifier of the sanctioned party on the list
+	Name      string // Name of the sanctioned party
+}
@@ -0,0 +1,253 @@
+package compliance
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"time"
+)
+
+// BlocklistProvider is the provider name recorded for screenings that matched a blocklist.
+const BlocklistProvider = "blocklist"
+
+// Screening records the AML/KYT assessment of a payment sender and its manual review.
+type Screening struct {
+	id         string
+	merchantID string
+	paymentID  string
+	invoiceID  string
+	address    string
+	network    string
+	provider   string
+	riskLevel  RiskLevel
+	riskScore  float64
+	categories []string
+	reference  string
+	failure    string
+	status     ScreeningStatus
+	reviewedBy string
+	reviewNote string
+	screenedAt time.Time
+	reviewedAt *time.Time
+}
+
+// NewScreening creates a screening from a provider result. Results at or above the
+// hold threshold put the screening on hold for manual review.
+func NewScreening(
+	id, merchantID, paymentID, invoiceID, address, network, provider string,
+	result *ScreeningResult,
+	holdThreshold RiskLevel,
+) (*Screening, error) {
+	if result == nil {
+		return nil, errors.New("screening result is required")
+	}
+	if !result.RiskLevel.IsValid() {
+		return nil, ErrInvalidRiskLevel
+	}
+
+	status := ScreeningStatusCleared
+	if result.RiskLevel.AtLeast(holdThreshold) {
+		status = ScreeningStatusHeld
+	}
+
+	return RestoreScreening(
+		id, merchantID, paymentID, invoiceID, address, network, provider,
+		result.RiskLevel, result.RiskScore, result.Categories, result.Reference, "",
+		status, "", "", shared.Now().UTC(), nil,
+	)
+}
+
+// NewFailedScreening creates a held screening for a payment the provider could not assess.
+func NewFailedScreening(
+	id, merchantID, paymentID, invoiceID, address, network, provider string,
+	failure error,
+) (*Screening, error) {
+	reason := "screening unavailable"
+	if failure != nil {
+		reason = failure.Error()
+	}
+
+	return RestoreScreening(
+		id, merchantID, paymentID, invoiceID, address, network, provider,
+		RiskLevelUnknown, 0, nil, "", reason,
+		ScreeningStatusHeld, "", "", shared.Now().UTC(), nil,
+	)
+}
+
+// NewBlockedScreening creates a held screening for a payment whose sender is on a blocklist.
+func NewBlockedScreening(
+	id, merchantID, paymentID, invoiceID, address, network string,
+	entry *BlocklistEntry,
+) (*Screening, error) {
+	if entry == nil {
+		return nil, errors.New("blocklist entry is required")
+	}
+
+	return RestoreScreening(
+		id, merchantID, paymentID, invoiceID, address, network, BlocklistProvider,
+		RiskLevelSevere, 0, []string{string(entry.Source())}, entry.ID(), "",
+		ScreeningStatusHeld, "", "", shared.Now().UTC(), nil,
+	)
+}
+
+// RestoreScreening recreates a screening from storage.
+func RestoreScreening(
+	id, merchantID, paymentID, invoiceID, address, network, provider string,
+	riskLevel RiskLevel,
+	riskScore float64,
+	categories []string,
+	reference, failure string,
+	status ScreeningStatus,
+	reviewedBy, reviewNote string,
+	screenedAt time.Time,
+	reviewedAt *time.Time,
+) (*Screening, error) {
+	if id == "" {
+		return nil, errors.New("screening ID is required")
+	}
+	if paymentID == "" {
+		return nil, errors.New("payment ID is required")
+	}
+	if !riskLevel.IsValid() {
+		return nil, ErrInvalidRiskLevel
+	}
+	if !status.IsValid() {
+		return nil, errors.New("invalid screening status")
+	}
+	if categories == nil {
+		categories = []string{}
+	}
+
+	return &Screening{
+		id:         id,
+		merchantID: merchantID,
+		paymentID:  paymentID,
+		invoiceID:  invoiceID,
+		address:    address,
+		network:    network,
+		provider:   provider,
+		riskLevel:  riskLevel,
+		riskScore:  riskScore,
+		categories: categories,
+		reference:  reference,
+		failure:    failure,
+		status:     status,
+		reviewedBy: reviewedBy,
+		reviewNote: reviewNote,
+		screenedAt: screenedAt,
+		reviewedAt: reviewedAt,
+	}, nil
+}
+
+// ID returns the screening ID.
+func (s *Screening) ID() string {
+	return s.id
+}
+
+// MerchantID returns the merchant that received the payment.
+func (s *Screening) MerchantID() string {
+	return s.merchantID
+}
+
+// PaymentID returns the screened payment.
+func (s *Screening) PaymentID() string {
+	return s.paymentID
+}
+
+// InvoiceID returns the invoice the payment was made against.
+func (s *Screening) InvoiceID() string {
+	return s.invoiceID
+}
+
+// Address returns the screened sender address.
+func (s *Screening) Address() string {
+	return s.address
+}
+
+// Network returns the blockchain network of the address.
+func (s *Screening) Network() string {
+	return s.network
+}
+
+// Provider returns the screening provider name.
+func (s *Screening) Provider() string {
+	return s.provider
+}
+
+// RiskLevel returns the assessed risk level.
+func (s *Screening) RiskLevel() RiskLevel {
+	return s.riskLevel
+}
+
+// RiskScore returns the provider-specific risk score.
+func (s *Screening) RiskScore() float64 {
+	return s.riskScore
+}
+
+// Categories returns the risk categories reported by the provider, e.g. "sanctions".
+func (s *Screening) Categories() []string {
+	return s.categories
+}
+
+// Reference returns the provider-side identifier of the assessment.
+func (s *Screening) Reference() string {
+	return s.reference
+}
+
+// Failure returns why the provider could not assess the address, if it failed.
+func (s *Screening) Failure() string {
+	return s.failure
+}
+
+// Status returns the review status.
+func (s *Screening) Status() ScreeningStatus {
+	return s.status
+}
+
+// ReviewedBy returns who reviewed the held payment.
+func (s *Screening) ReviewedBy() string {
+	return s.reviewedBy
+}
+
+// ReviewNote returns the reviewer's note.
+func (s *Screening) ReviewNote() string {
+	return s.reviewNote
+}
+
+// ScreenedAt returns when the address was screened.
+func (s *Screening) ScreenedAt() time.Time {
+	return s.screenedAt
+}
+
+// ReviewedAt returns when the review was completed.
+func (s *Screening) ReviewedAt() *time.Time {
+	return s.reviewedAt
+}
+
+// IsHeld returns true if the payment awaits manual review.
+func (s *Screening) IsHeld() bool {
+	return s.status == ScreeningStatusHeld
+}
+
+// Approve releases the held payment.
+func (s *Screening) Approve(reviewer, note string) error {
+	return s.review(ScreeningStatusApproved, reviewer, note)
+}
+
+// Reject rejects the held payment.
+func (s *Screening) Reject(reviewer, note string) error {
+	return s.review(ScreeningStatusRejected, reviewer, note)
+}
+
+// review completes the manual review of a held screening.
+func (s *Screening) review(status ScreeningStatus, reviewer, note string) error {
+	if !s.IsHeld() {
+		return ErrScreeningNotHeld
+	}
+
+	now := shared.Now().UTC()
+	s.status = status
+	s.reviewedBy = reviewer
+	s.reviewNote = note
+	s.reviewedAt = &now
+	return nil
+}
@@ -0,0 +1,18 @@
+package confirmation
+
+import (
+	"crypto-checkout/internal/domain/detection"
+
+	"go.uber.org/fx"
+)
+
+// Module provides the confirmation time estimator, fed with the chain heads the watcher reads.
+var Module = fx.Module("confirmation",
+	fx.Provide(NewEstimator),
+	fx.Invoke(ObserveWatcher),
+)
+
+// ObserveWatcher has the estimator measure block intervals from the chain heads the watcher reads.
+func ObserveWatcher(watcher *detection.Watcher, estimator *Estimator) {
+	watcher.ObserveHeads(estimator)
+}
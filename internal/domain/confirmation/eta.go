@@ -0,0 +1,124 @@
+// Package confirmation estimates how long payments take to gather the confirmations they need.
+package confirmation
+
+import (
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"sync"
+	"time"
+)
+
+const (
+	// sampleWindow is how many chain heads are kept per network to measure the recent block interval.
+	sampleWindow = 20
+	// minObservedBlocks is how many blocks the kept heads must span before their interval replaces the
+	// network's nominal block time.
+	minObservedBlocks = 3
+)
+
+// ETA is the expected time until a payment has the confirmations it needs.
+type ETA struct {
+	Network shared.BlockchainNetwork
+	// Remaining is the number of confirmations still missing.
+	Remaining int
+	// BlockTime is the expected time between blocks; Observed tells whether it was measured on the chain
+	// rather than taken from the network's nominal block time.
+	BlockTime time.Duration
+	Observed  bool
+	Duration  time.Duration
+	At        time.Time
+}
+
+// headSample is a chain head and when it was read.
+type headSample struct {
+	head int64
+	at   time.Time
+}
+
+// Estimator computes the expected time to confirmation on each network as the block time times the
+// confirmations still missing. The block time is the average interval between the chain heads the watcher
+// read recently, or the network's nominal block time until enough blocks were seen.
+type Estimator struct {
+	mu      sync.RWMutex
+	samples map[shared.BlockchainNetwork][]headSample
+}
+
+// NewEstimator creates an estimator that has observed no chain heads yet.
+func NewEstimator() *Estimator {
+	return &Estimator{samples: make(map[shared.BlockchainNetwork][]headSample)}
+}
+
+// ObserveHead records the chain head of a network read at a time. A head behind the last one, as after a
+// reorganization or a switch to a node still syncing, starts the measurement again.
+func (e *Estimator) ObserveHead(network shared.BlockchainNetwork, head int64, at time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	samples := e.samples[network]
+	if n := len(samples); n > 0 {
+		last := samples[n-1]
+		if head == last.head {
+			return
+		}
+		if head < last.head || !at.After(last.at) {
+			samples = samples[:0]
+		}
+	}
+	samples = append(samples, headSample{head: head, at: at})
+	if len(samples) > sampleWindow {
+		samples = samples[len(samples)-sampleWindow:]
+	}
+	e.samples[network] = samples
+}
+
+// BlockTime returns the expected time between blocks of a network, and whether it was measured on the chain.
+func (e *Estimator) BlockTime(network shared.BlockchainNetwork) (time.Duration, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if samples := e.samples[network]; len(samples) > 1 {
+		first, last := samples[0], samples[len(samples)-1]
+		if blocks := last.head - first.head; blocks >= minObservedBlocks {
+			return last.at.Sub(first.at) / time.Duration(blocks), true
+		}
+	}
+	return payment.EstimateConfirmationTime(network, 1), false
+}
+
+// Estimate returns the expected time until a network adds the remaining confirmations.
+func (e *Estimator) Estimate(network shared.BlockchainNetwork, remaining int) ETA {
+	remaining = max(remaining, 0)
+	blockTime, observed := e.BlockTime(network)
+	duration := blockTime * time.Duration(remaining)
+	return ETA{
+		Network:   network,
+		Remaining: remaining,
+		BlockTime: blockTime,
+		Observed:  observed,
+		Duration:  duration,
+		At:        shared.Now().UTC().Add(duration),
+	}
+}
+
+// EstimatePayment returns the expected time until a payment is confirmed. It reports false for payments
+// that are no longer waiting for confirmations.
+func (e *Estimator) EstimatePayment(p *payment.Payment) (ETA, bool) {
+	if p.Status() != payment.StatusDetected && p.Status() != payment.StatusConfirming {
+		return ETA{}, false
+	}
+	return e.Estimate(p.ToAddress().Network(), p.RequiredConfirmations()-p.Confirmations().Int()), true
+}
+
+// EstimatePayments returns the expected time until the last of the payments still waiting for confirmations
+// is confirmed, and false when none is waiting.
+func (e *Estimator) EstimatePayments(payments []*payment.Payment) (ETA, bool) {
+	var latest ETA
+	found := false
+	for _, p := range payments {
+		eta, ok := e.EstimatePayment(p)
+		if ok && (!found || eta.At.After(latest.At)) {
+			latest, found = eta, true
+		}
+	}
+	return latest, found
+}
@@ -0,0 +1,161 @@
+package confirmation_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/confirmation"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/memory"
+	"crypto-checkout/test/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestEstimator_BlockTime(t *testing.T) {
+	t.Run("Nominal_Until_Enough_Blocks_Observed", func(t *testing.T) {
+		estimator := confirmation.NewEstimator()
+		blockTime, observed := estimator.BlockTime(shared.NetworkTron)
+		assert.Equal(t, 3*time.Second, blockTime)
+		assert.False(t, observed)
+
+		estimator.ObserveHead(shared.NetworkTron, 100, testutil.Epoch)
+		estimator.ObserveHead(shared.NetworkTron, 102, testutil.Epoch.Add(10*time.Second))
+		_, observed = estimator.BlockTime(shared.NetworkTron)
+		assert.False(t, observed)
+	})
+
+	t.Run("Averages_Observed_Intervals", func(t *testing.T) {
+		estimator := confirmation.NewEstimator()
+		estimator.ObserveHead(shared.NetworkEthereum, 100, testutil.Epoch)
+		// A head read again without a new block does not count as an interval
+		estimator.ObserveHead(shared.NetworkEthereum, 100, testutil.Epoch.Add(5*time.Second))
+		estimator.ObserveHead(shared.NetworkEthereum, 102, testutil.Epoch.Add(30*time.Second))
+		estimator.ObserveHead(shared.NetworkEthereum, 104, testutil.Epoch.Add(80*time.Second))
+
+		blockTime, observed := estimator.BlockTime(shared.NetworkEthereum)
+		assert.True(t, observed)
+		assert.Equal(t, 20*time.Second, blockTime)
+
+		// Other networks keep their nominal block time
+		blockTime, observed = estimator.BlockTime(shared.NetworkTron)
+		assert.Equal(t, 3*time.Second, blockTime)
+		assert.False(t, observed)
+	})
+
+	t.Run("Restarts_After_Head_Regression", func(t *testing.T) {
+		estimator := confirmation.NewEstimator()
+		estimator.ObserveHead(shared.NetworkEthereum, 100, testutil.Epoch)
+		estimator.ObserveHead(shared.NetworkEthereum, 110, testutil.Epoch.Add(time.Minute))
+		estimator.ObserveHead(shared.NetworkEthereum, 108, testutil.Epoch.Add(2*time.Minute))
+
+		_, observed := estimator.BlockTime(shared.NetworkEthereum)
+		assert.False(t, observed)
+	})
+
+	t.Run("Measures_Recent_Blocks_Only", func(t *testing.T) {
+		estimator := confirmation.NewEstimator()
+		at := testutil.Epoch
+		// Slow blocks first, then a long run of fast ones that pushes them out of the window
+		for head := int64(1); head <= 5; head++ {
+			at = at.Add(time.Minute)
+			estimator.ObserveHead(shared.NetworkBitcoin, head, at)
+		}
+		for head := int64(6); head <= 40; head++ {
+			at = at.Add(2 * time.Second)
+			estimator.ObserveHead(shared.NetworkBitcoin, head, at)
+		}
+
+		blockTime, observed := estimator.BlockTime(shared.NetworkBitcoin)
+		assert.True(t, observed)
+		assert.Equal(t, 2*time.Second, blockTime)
+	})
+}
+
+func TestEstimator_Estimate(t *testing.T) {
+	clock := testutil.NewClock(time.Time{})
+	testutil.UseClock(t, clock)
+	estimator := confirmation.NewEstimator()
+
+	eta := estimator.Estimate(shared.NetworkTron, 12)
+	assert.Equal(t, 12, eta.Remaining)
+	assert.Equal(t, 36*time.Second, eta.Duration)
+	assert.Equal(t, clock.Now().Add(36*time.Second), eta.At)
+
+	eta = estimator.Estimate(shared.NetworkTron, -1)
+	assert.Zero(t, eta.Remaining)
+	assert.Equal(t, clock.Now(), eta.At)
+}
+
+func TestEstimator_EstimatePayments(t *testing.T) {
+	clock := testutil.NewClock(time.Time{})
+	testutil.UseClock(t, clock)
+	estimator := confirmation.NewEstimator()
+
+	confirming, err := testutil.NewPaymentBuilder().
+		WithID("payment-1").
+		RequiringConfirmations(19).
+		WithConfirmations(4).
+		InStatus(payment.StatusConfirming).
+		Build()
+	require.NoError(t, err)
+	eta, ok := estimator.EstimatePayment(confirming)
+	require.True(t, ok)
+	assert.Equal(t, 15, eta.Remaining)
+	assert.Equal(t, shared.NetworkTron, eta.Network)
+	assert.Equal(t, 45*time.Second, eta.Duration)
+
+	detected, err := testutil.NewPaymentBuilder().WithID("payment-2").RequiringConfirmations(3).Build()
+	require.NoError(t, err)
+	confirmed, err := testutil.NewPaymentBuilder().
+		WithID("payment-3").
+		RequiringConfirmations(1).
+		WithConfirmations(1).
+		InStatus(payment.StatusConfirmed).
+		Build()
+	require.NoError(t, err)
+	_, ok = estimator.EstimatePayment(confirmed)
+	assert.False(t, ok)
+
+	// The invoice waits for the payment confirming last
+	eta, ok = estimator.EstimatePayments([]*payment.Payment{detected, confirming, confirmed})
+	require.True(t, ok)
+	assert.Equal(t, 15, eta.Remaining)
+
+	_, ok = estimator.EstimatePayments([]*payment.Payment{confirmed})
+	assert.False(t, ok)
+}
+
+func TestObserveWatcher(t *testing.T) {
+	ctx := context.Background()
+	clock := testutil.NewClock(time.Time{})
+	testutil.UseClock(t, clock)
+
+	tokens, err := shared.NewTokenRegistry(shared.DefaultTokens())
+	require.NoError(t, err)
+	invoiceService := invoice.NewInvoiceService(memory.NewInvoiceRepository(memory.NewPaymentRepository()), nil, nil,
+		nil, invoice.DefaultRequotePolicy(), tokens, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+	chain := testutil.NewChain(shared.NetworkEthereum, 1000)
+	watcher := detection.NewWatcher([]detection.TransferScanner{chain}, invoiceService, nil, tokens,
+		memory.NewBlockCursorRepository(), detection.WatchPolicy{Enabled: true, Interval: time.Hour}, zap.NewNop())
+
+	estimator := confirmation.NewEstimator()
+	confirmation.ObserveWatcher(watcher, estimator)
+
+	// The chain produces two blocks between each of the watcher's checks, a minute apart
+	watcher.Check(ctx)
+	for range 3 {
+		chain.Mine(2)
+		clock.Advance(time.Minute)
+		watcher.Check(ctx)
+	}
+
+	blockTime, observed := estimator.BlockTime(shared.NetworkEthereum)
+	assert.True(t, observed)
+	assert.Equal(t, 30*time.Second, blockTime)
+}
@@ -0,0 +1,162 @@
+// Package coupon provides merchant coupon codes that customers and merchants redeem for invoice discounts.
+package coupon
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// codePattern restricts coupon codes to what customers can reliably type on the checkout page.
+var codePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// Coupon is a merchant code that grants a discount on an invoice, optionally limited in uses and time.
+type Coupon struct {
+	id             string
+	merchantID     string
+	code           string
+	discount       *invoice.Discount
+	maxRedemptions int
+	redemptions    int
+	expiresAt      *time.Time
+	active         bool
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+// NewCoupon creates a new active coupon. A maxRedemptions of zero means unlimited uses.
+func NewCoupon(
+	id, merchantID, code string,
+	discount *invoice.Discount,
+	maxRedemptions int,
+	expiresAt *time.Time,
+) (*Coupon, error) {
+	now := shared.Now().UTC()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, errors.New("coupon expiration must be in the future")
+	}
+
+	return RestoreCoupon(id, merchantID, NormalizeCode(code), discount, maxRedemptions, 0, expiresAt, true, now, now)
+}
+
+// RestoreCoupon recreates a coupon from storage.
+func RestoreCoupon(
+	id, merchantID, code string,
+	discount *invoice.Discount,
+	maxRedemptions, redemptions int,
+	expiresAt *time.Time,
+	active bool,
+	createdAt, updatedAt time.Time,
+) (*Coupon, error) {
+	if id == "" {
+		return nil, errors.New("coupon ID is required")
+	}
+	if merchantID == "" {
+		return nil, errors.New("merchant ID is required")
+	}
+	if !codePattern.MatchString(code) {
+		return nil, errors.New("coupon code must be 3-32 letters, digits, dashes or underscores")
+	}
+	if discount == nil {
+		return nil, errors.New("coupon discount is required")
+	}
+	if maxRedemptions < 0 || redemptions < 0 {
+		return nil, errors.New("redemption counts cannot be negative")
+	}
+
+	return &Coupon{
+		id:             id,
+		merchantID:     merchantID,
+		code:           code,
+		discount:       discount,
+		maxRedemptions: maxRedemptions,
+		redemptions:    redemptions,
+		expiresAt:      expiresAt,
+		active:         active,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
+	}, nil
+}
+
+// NormalizeCode returns the canonical form of a coupon code; codes are case-insensitive.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// ID returns the coupon ID.
+func (c *Coupon) ID() string {
+	return c.id
+}
+
+// MerchantID returns the merchant that issued the coupon.
+func (c *Coupon) MerchantID() string {
+	return c.merchantID
+}
+
+// Code returns the normalized coupon code.
+func (c *Coupon) Code() string {
+	return c.code
+}
+
+// Discount returns the discount the coupon grants.
+func (c *Coupon) Discount() *invoice.Discount {
+	return c.discount
+}
+
+// MaxRedemptions returns how many times the coupon can be redeemed, or zero if unlimited.
+func (c *Coupon) MaxRedemptions() int {
+	return c.maxRedemptions
+}
+
+// Redemptions returns how many times the coupon has been redeemed.
+func (c *Coupon) Redemptions() int {
+	return c.redemptions
+}
+
+// ExpiresAt returns when the coupon stops being redeemable, if ever.
+func (c *Coupon) ExpiresAt() *time.Time {
+	return c.expiresAt
+}
+
+// IsActive returns true if the merchant has not deactivated the coupon.
+func (c *Coupon) IsActive() bool {
+	return c.active
+}
+
+// CreatedAt returns when the coupon was created.
+func (c *Coupon) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// UpdatedAt returns when the coupon was last changed.
+func (c *Coupon) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// CheckRedeemable returns why the coupon cannot be redeemed at the given time, or nil if it can.
+func (c *Coupon) CheckRedeemable(now time.Time) error {
+	if !c.active {
+		return ErrCouponInactive
+	}
+	if c.expiresAt != nil && !now.Before(*c.expiresAt) {
+		return ErrCouponExpired
+	}
+	if c.maxRedemptions > 0 && c.redemptions >= c.maxRedemptions {
+		return ErrCouponExhausted
+	}
+	return nil
+}
+
+// ToAppliedCoupon returns the coupon as applied to an invoice.
+func (c *Coupon) ToAppliedCoupon() (*invoice.AppliedCoupon, error) {
+	return invoice.NewAppliedCoupon(c.code, c.discount)
+}
+
+// Deactivate stops the coupon from being redeemed again.
+func (c *Coupon) Deactivate() {
+	c.active = false
+	c.updatedAt = shared.Now().UTC()
+}
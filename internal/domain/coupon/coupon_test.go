@@ -0,0 +1,55 @@
+package coupon
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDiscount(t *testing.T) *invoice.Discount {
+	discount, err := invoice.NewDiscount(invoice.DiscountTypePercentage, "10")
+	require.NoError(t, err)
+	return discount
+}
+
+func TestNewCoupon_Validation(t *testing.T) {
+	coupon, err := NewCoupon("coupon-1", "merchant-1", "  spring-10 ", newTestDiscount(t), 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "SPRING-10", coupon.Code())
+	assert.True(t, coupon.IsActive())
+
+	_, err = NewCoupon("coupon-1", "merchant-1", "no spaces", newTestDiscount(t), 0, nil)
+	require.Error(t, err)
+
+	_, err = NewCoupon("coupon-1", "merchant-1", "SPRING10", nil, 0, nil)
+	require.Error(t, err)
+
+	past := time.Now().Add(-time.Hour)
+	_, err = NewCoupon("coupon-1", "merchant-1", "SPRING10", newTestDiscount(t), 0, &past)
+	require.Error(t, err)
+}
+
+func TestCoupon_CheckRedeemable(t *testing.T) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(time.Hour)
+
+	coupon, err := RestoreCoupon(
+		"coupon-1", "merchant-1", "SPRING10", newTestDiscount(t), 2, 1, &expiresAt, true, now, now,
+	)
+	require.NoError(t, err)
+	require.NoError(t, coupon.CheckRedeemable(now))
+
+	require.ErrorIs(t, coupon.CheckRedeemable(expiresAt), ErrCouponExpired)
+
+	exhausted, err := RestoreCoupon(
+		"coupon-2", "merchant-1", "SPRING20", newTestDiscount(t), 2, 2, nil, true, now, now,
+	)
+	require.NoError(t, err)
+	require.ErrorIs(t, exhausted.CheckRedeemable(now), ErrCouponExhausted)
+
+	coupon.Deactivate()
+	require.ErrorIs(t, coupon.CheckRedeemable(now), ErrCouponInactive)
+}
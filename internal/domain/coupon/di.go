@@ -0,0 +1,15 @@
+package coupon
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the coupon service layer dependencies.
+var Module = fx.Module("coupon-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+)
@@ -0,0 +1,22 @@
+package coupon
+
+import "errors"
+
+// Domain errors for coupon operations
+var (
+	ErrCouponNotFound  = errors.New("coupon not found")
+	ErrCouponExists    = errors.New("a coupon with this code already exists")
+	ErrCouponInactive  = errors.New("coupon is no longer active")
+	ErrCouponExpired   = errors.New("coupon has expired")
+	ErrCouponExhausted = errors.New("coupon has reached its redemption limit")
+	ErrInvalidRequest  = errors.New("invalid coupon request")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeCouponNotFound  = "COUPON_NOT_FOUND"
+	ErrCodeCouponExists    = "COUPON_EXISTS"
+	ErrCodeCouponInactive  = "COUPON_INACTIVE"
+	ErrCodeCouponExpired   = "COUPON_EXPIRED"
+	ErrCodeCouponExhausted = "COUPON_EXHAUSTED"
+)
@@ -0,0 +1,28 @@
+package coupon
+
+import "context"
+
+// Repository defines the interface for coupon persistence.
+type Repository interface {
+	// Save persists a new coupon, returning ErrCouponExists if the merchant already uses the code.
+	Save(ctx context.Context, coupon *Coupon) error
+
+	// FindByID retrieves a coupon by its ID.
+	FindByID(ctx context.Context, id string) (*Coupon, error)
+
+	// FindByCode retrieves a merchant's coupon by its normalized code.
+	FindByCode(ctx context.Context, merchantID, code string) (*Coupon, error)
+
+	// ListByMerchant retrieves a merchant's coupons, newest first.
+	ListByMerchant(ctx context.Context, merchantID string) ([]*Coupon, error)
+
+	// Update updates an existing coupon.
+	Update(ctx context.Context, coupon *Coupon) error
+
+	// IncrementRedemptions atomically records a redemption, returning ErrCouponExhausted
+	// if the coupon reached its limit in the meantime.
+	IncrementRedemptions(ctx context.Context, id string) error
+
+	// DecrementRedemptions gives back a redemption that was not used.
+	DecrementRedemptions(ctx context.Context, id string) error
+}
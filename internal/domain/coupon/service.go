@@ -0,0 +1,227 @@
+package coupon
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+// Service defines the interface for managing and redeeming coupons.
+type Service interface {
+	// CreateCoupon creates a coupon for a merchant.
+	CreateCoupon(ctx context.Context, req *CreateCouponRequest) (*Coupon, error)
+
+	// ListCoupons lists a merchant's coupons.
+	ListCoupons(ctx context.Context, merchantID string) ([]*Coupon, error)
+
+	// GetCoupon retrieves a merchant's coupon.
+	GetCoupon(ctx context.Context, merchantID, couponID string) (*Coupon, error)
+
+	// DeactivateCoupon stops a merchant's coupon from being redeemed again.
+	DeactivateCoupon(ctx context.Context, merchantID, couponID string) (*Coupon, error)
+
+	// RedeemCoupon validates a merchant's coupon code and records a redemption.
+	RedeemCoupon(ctx context.Context, merchantID, code string) (*Coupon, error)
+
+	// ReleaseCoupon gives back a redemption that could not be applied.
+	ReleaseCoupon(ctx context.Context, couponID string) error
+
+	// ApplyToInvoice redeems a coupon code of the invoice's merchant and applies it to the invoice.
+	ApplyToInvoice(ctx context.Context, invoiceID, code string) (*invoice.Invoice, error)
+}
+
+// CreateCouponRequest represents the request to create a coupon.
+type CreateCouponRequest struct {
+	MerchantID     string               `validate:"required"`
+	Code           string               `validate:"required"`
+	DiscountType   invoice.DiscountType `validate:"required"`
+	DiscountValue  string               `validate:"required"`
+	MaxRedemptions int                  `validate:"min=0"`
+	ExpiresAt      *time.Time
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository     Repository
+	invoiceService invoice.InvoiceService
+	logger         *zap.Logger
+}
+
+// NewService creates a new coupon service.
+func NewService(repository Repository, invoiceService invoice.InvoiceService, logger *zap.Logger) Service {
+	return &ServiceImpl{
+		repository:     repository,
+		invoiceService: invoiceService,
+		logger:         logger,
+	}
+}
+
+// CreateCoupon creates a coupon for a merchant.
+func (s *ServiceImpl) CreateCoupon(ctx context.Context, req *CreateCouponRequest) (*Coupon, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: create coupon request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	discount, err := invoice.NewDiscount(req.DiscountType, req.DiscountValue)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate coupon ID: %w", err)
+	}
+
+	coupon, err := NewCoupon(id, req.MerchantID, req.Code, discount, req.MaxRedemptions, req.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	if err := s.repository.Save(ctx, coupon); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Coupon created",
+		zap.String("coupon_id", coupon.ID()),
+		zap.String("merchant_id", coupon.MerchantID()),
+		zap.String("code", coupon.Code()))
+
+	return coupon, nil
+}
+
+// ListCoupons lists a merchant's coupons.
+func (s *ServiceImpl) ListCoupons(ctx context.Context, merchantID string) ([]*Coupon, error) {
+	if merchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+
+	return s.repository.ListByMerchant(ctx, merchantID)
+}
+
+// GetCoupon retrieves a merchant's coupon.
+func (s *ServiceImpl) GetCoupon(ctx context.Context, merchantID, couponID string) (*Coupon, error) {
+	if merchantID == "" || couponID == "" {
+		return nil, fmt.Errorf("%w: merchant ID and coupon ID are required", ErrInvalidRequest)
+	}
+
+	coupon, err := s.repository.FindByID(ctx, couponID)
+	if err != nil {
+		return nil, err
+	}
+	if coupon.MerchantID() != merchantID {
+		return nil, ErrCouponNotFound
+	}
+
+	return coupon, nil
+}
+
+// DeactivateCoupon stops a merchant's coupon from being redeemed again.
+// Invoices the coupon was already applied to keep their discount.
+func (s *ServiceImpl) DeactivateCoupon(ctx context.Context, merchantID, couponID string) (*Coupon, error) {
+	coupon, err := s.GetCoupon(ctx, merchantID, couponID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !coupon.IsActive() {
+		return coupon, nil
+	}
+
+	coupon.Deactivate()
+	if err := s.repository.Update(ctx, coupon); err != nil {
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+// RedeemCoupon validates a merchant's coupon code and records a redemption.
+// Callers that fail to apply the coupon afterwards must release it.
+func (s *ServiceImpl) RedeemCoupon(ctx context.Context, merchantID, code string) (*Coupon, error) {
+	if merchantID == "" || code == "" {
+		return nil, fmt.Errorf("%w: merchant ID and coupon code are required", ErrInvalidRequest)
+	}
+
+	coupon, err := s.repository.FindByCode(ctx, merchantID, NormalizeCode(code))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := coupon.CheckRedeemable(shared.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.IncrementRedemptions(ctx, coupon.ID()); err != nil {
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+// ReleaseCoupon gives back a redemption that could not be applied.
+func (s *ServiceImpl) ReleaseCoupon(ctx context.Context, couponID string) error {
+	if err := s.repository.DecrementRedemptions(ctx, couponID); err != nil {
+		s.logger.Error("Failed to release coupon redemption",
+			zap.String("coupon_id", couponID),
+			zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ApplyToInvoice redeems a coupon code of the invoice's merchant and applies it to the invoice.
+// The redemption is released if the invoice can no longer take a coupon.
+func (s *ServiceImpl) ApplyToInvoice(ctx context.Context, invoiceID, code string) (*invoice.Invoice, error) {
+	if invoiceID == "" || code == "" {
+		return nil, fmt.Errorf("%w: invoice ID and coupon code are required", ErrInvalidRequest)
+	}
+
+	inv, err := s.invoiceService.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.Coupon() != nil {
+		return nil, invoice.ErrCouponAlreadyApplied
+	}
+
+	coupon, err := s.RedeemCoupon(ctx, inv.MerchantID(), code)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := coupon.ToAppliedCoupon()
+	if err == nil {
+		inv, err = s.invoiceService.ApplyCoupon(ctx, invoiceID, applied)
+	}
+	if err != nil {
+		if releaseErr := s.ReleaseCoupon(ctx, coupon.ID()); releaseErr != nil {
+			return nil, errors.Join(err, releaseErr)
+		}
+		return nil, err
+	}
+
+	s.logger.Info("Coupon applied to invoice",
+		zap.String("coupon_id", coupon.ID()),
+		zap.String("invoice_id", invoiceID))
+
+	return inv, nil
+}
+
+func generateID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
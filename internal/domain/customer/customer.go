@@ -0,0 +1,93 @@
+// Package customer provides optional accounts for the customers who pay invoices, signed in with an emailed
+// magic link. Customer accounts are a separate identity stack from merchant users and API keys: a customer
+// session grants access to the customer's own payment history, saved refund addresses and receipts only.
+package customer
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// maxEmailLength is the longest email address a customer account accepts.
+const maxEmailLength = 254
+
+// Customer is a payer who signed in to see their payments across the merchants of this deployment.
+type Customer struct {
+	id          string
+	email       string
+	createdAt   time.Time
+	lastLoginAt *time.Time
+}
+
+// NewCustomer creates a new customer account for a verified email address.
+func NewCustomer(id, email string) (*Customer, error) {
+	return RestoreCustomer(id, email, shared.Now().UTC(), nil)
+}
+
+// RestoreCustomer recreates a customer from storage.
+func RestoreCustomer(id, email string, createdAt time.Time, lastLoginAt *time.Time) (*Customer, error) {
+	if id == "" {
+		return nil, errors.New("customer ID is required")
+	}
+	normalized, err := NormalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Customer{
+		id:          id,
+		email:       normalized,
+		createdAt:   createdAt,
+		lastLoginAt: lastLoginAt,
+	}, nil
+}
+
+// ID returns the customer ID.
+func (c *Customer) ID() string {
+	return c.id
+}
+
+// Email returns the customer's lower-cased email address.
+func (c *Customer) Email() string {
+	return c.email
+}
+
+// CreatedAt returns when the account was created.
+func (c *Customer) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// LastLoginAt returns when the customer last signed in.
+func (c *Customer) LastLoginAt() *time.Time {
+	return c.lastLoginAt
+}
+
+// RecordLogin records that the customer signed in.
+func (c *Customer) RecordLogin() {
+	now := shared.Now().UTC()
+	c.lastLoginAt = &now
+}
+
+// NormalizeEmail validates an email address and lower-cases it, so that one address maps to one account.
+func NormalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || len(email) > maxEmailLength {
+		return "", ErrInvalidEmail
+	}
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return "", ErrInvalidEmail
+	}
+	return email, nil
+}
+
+// HashToken returns the stored form of a login link or session token; raw tokens are never stored.
+func HashToken(rawToken string) string {
+	sum := sha256.Sum256([]byte(rawToken))
+	return hex.EncodeToString(sum[:])
+}
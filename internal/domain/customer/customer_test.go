@@ -0,0 +1,79 @@
+package customer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCodeVerifier is the RFC 7636 appendix B example verifier.
+const testCodeVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+func TestNormalizeEmail(t *testing.T) {
+	email, err := NormalizeEmail("  Alice@Example.COM ")
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", email)
+
+	for _, invalid := range []string{"", "not-an-email", "Alice <alice@example.com>", strings.Repeat("a", 250) + "@x.io"} {
+		_, err := NormalizeEmail(invalid)
+		require.ErrorIs(t, err, ErrInvalidEmail, invalid)
+	}
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// RFC 7636 appendix B
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", CodeChallenge(testCodeVerifier))
+}
+
+func TestLoginLink_VerifyAndUse(t *testing.T) {
+	_, err := NewLoginLink("link-1", "alice@example.com", "raw-token", "too-short", time.Minute)
+	require.ErrorIs(t, err, ErrInvalidCodeChallenge)
+
+	link, err := NewLoginLink("link-1", "alice@example.com", "raw-token", CodeChallenge(testCodeVerifier), time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, HashToken("raw-token"), link.TokenHash())
+	assert.True(t, link.IsUsable())
+
+	assert.True(t, link.VerifyCodeVerifier(testCodeVerifier))
+	assert.False(t, link.VerifyCodeVerifier(strings.Repeat("a", 43)))
+	assert.False(t, link.VerifyCodeVerifier("short"))
+
+	link.Use()
+	assert.False(t, link.IsUsable())
+	require.NotNil(t, link.UsedAt())
+}
+
+func TestLoginLink_Expired(t *testing.T) {
+	createdAt := time.Now().UTC().Add(-time.Hour)
+	link, err := RestoreLoginLink(
+		"link-1", "alice@example.com", HashToken("raw-token"), CodeChallenge(testCodeVerifier),
+		createdAt.Add(DefaultLoginLinkTTL), createdAt, nil,
+	)
+	require.NoError(t, err)
+	assert.False(t, link.IsUsable())
+}
+
+func TestSession_Revoke(t *testing.T) {
+	session, err := NewSession("session-1", "customer-1", "cst_token", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, session.IsActive())
+
+	session.Revoke()
+	assert.False(t, session.IsActive())
+}
+
+func TestNewRefundAddress_Validation(t *testing.T) {
+	address, err := NewRefundAddress("address-1", "customer-1", "  0xabc  ", " Main wallet ")
+	require.NoError(t, err)
+	assert.Equal(t, "0xabc", address.Address())
+	assert.Equal(t, "Main wallet", address.Label())
+
+	_, err = NewRefundAddress("address-1", "customer-1", " ", "")
+	require.Error(t, err)
+
+	_, err = NewRefundAddress("address-1", "customer-1", "0xabc", strings.Repeat("x", 65))
+	require.Error(t, err)
+}
@@ -0,0 +1,15 @@
+package customer
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the customer account service layer dependencies.
+var Module = fx.Module("customer-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+)
@@ -0,0 +1,34 @@
+package customer
+
+import "errors"
+
+// Domain errors for customer account operations
+var (
+	ErrCustomerNotFound       = errors.New("customer not found")
+	ErrInvalidEmail           = errors.New("invalid email address")
+	ErrInvalidCodeChallenge   = errors.New("code challenge must be an S256 PKCE challenge")
+	ErrInvalidLoginLink       = errors.New("login link is invalid, expired or already used")
+	ErrLoginLinkNotFound      = errors.New("login link not found")
+	ErrSessionNotFound        = errors.New("customer session not found")
+	ErrInvalidSession         = errors.New("customer session is invalid or expired")
+	ErrInvoiceClaimed         = errors.New("invoice already belongs to another customer account")
+	ErrInvoiceNotClaimed      = errors.New("invoice is not in the customer's payment history")
+	ErrReceiptUnavailable     = errors.New("invoice has not been paid, so it has no receipt")
+	ErrRefundAddressNotFound  = errors.New("saved refund address not found")
+	ErrRefundAddressExists    = errors.New("refund address is already saved")
+	ErrTooManyRefundAddresses = errors.New("too many saved refund addresses")
+	ErrInvalidRequest         = errors.New("invalid customer request")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeInvalidCodeChallenge   = "INVALID_CODE_CHALLENGE"
+	ErrCodeInvalidLoginLink       = "INVALID_LOGIN_LINK"
+	ErrCodeInvalidSession         = "INVALID_CUSTOMER_SESSION"
+	ErrCodeInvoiceClaimed         = "INVOICE_CLAIMED"
+	ErrCodeInvoiceNotClaimed      = "INVOICE_NOT_CLAIMED"
+	ErrCodeReceiptUnavailable     = "RECEIPT_UNAVAILABLE"
+	ErrCodeRefundAddressNotFound  = "REFUND_ADDRESS_NOT_FOUND"
+	ErrCodeRefundAddressExists    = "REFUND_ADDRESS_EXISTS"
+	ErrCodeTooManyRefundAddresses = "TOO_MANY_REFUND_ADDRESSES"
+)
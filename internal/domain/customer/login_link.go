@@ -0,0 +1,146 @@
+package customer
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"regexp"
+	"time"
+)
+
+const (
+	// DefaultLoginLinkTTL is how long an emailed login link can be used.
+	DefaultLoginLinkTTL = 15 * time.Minute
+
+	// CodeChallengeMethodS256 is the only PKCE challenge method accepted: BASE64URL(SHA256(code_verifier)).
+	CodeChallengeMethodS256 = "S256"
+)
+
+var (
+	// codeChallengePattern matches an unpadded base64url SHA-256 digest.
+	codeChallengePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{43}$`)
+	// codeVerifierPattern matches an RFC 7636 code verifier.
+	codeVerifierPattern = regexp.MustCompile(`^[A-Za-z0-9._~-]{43,128}$`)
+)
+
+// LoginLink is a single-use magic link emailed to a customer. The link is bound to the browser that asked for it
+// with a PKCE code challenge: redeeming it takes the matching code verifier, which never leaves that browser, so a
+// link opened by a mail scanner or forwarded to someone else cannot start a session.
+type LoginLink struct {
+	id            string
+	email         string
+	tokenHash     string
+	codeChallenge string
+	expiresAt     time.Time
+	createdAt     time.Time
+	usedAt        *time.Time
+}
+
+// NewLoginLink creates a login link for the given raw token and S256 code challenge.
+func NewLoginLink(id, email, rawToken, codeChallenge string, ttl time.Duration) (*LoginLink, error) {
+	if rawToken == "" {
+		return nil, errors.New("login link token is required")
+	}
+	if ttl <= 0 {
+		ttl = DefaultLoginLinkTTL
+	}
+
+	now := shared.Now().UTC()
+	return RestoreLoginLink(id, email, HashToken(rawToken), codeChallenge, now.Add(ttl), now, nil)
+}
+
+// RestoreLoginLink recreates a login link from storage.
+func RestoreLoginLink(
+	id, email, tokenHash, codeChallenge string,
+	expiresAt, createdAt time.Time,
+	usedAt *time.Time,
+) (*LoginLink, error) {
+	if id == "" {
+		return nil, errors.New("login link ID is required")
+	}
+	normalized, err := NormalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if tokenHash == "" {
+		return nil, errors.New("token hash is required")
+	}
+	if !codeChallengePattern.MatchString(codeChallenge) {
+		return nil, ErrInvalidCodeChallenge
+	}
+
+	return &LoginLink{
+		id:            id,
+		email:         normalized,
+		tokenHash:     tokenHash,
+		codeChallenge: codeChallenge,
+		expiresAt:     expiresAt,
+		createdAt:     createdAt,
+		usedAt:        usedAt,
+	}, nil
+}
+
+// ID returns the login link ID.
+func (l *LoginLink) ID() string {
+	return l.id
+}
+
+// Email returns the address the link was sent to.
+func (l *LoginLink) Email() string {
+	return l.email
+}
+
+// TokenHash returns the hashed link token.
+func (l *LoginLink) TokenHash() string {
+	return l.tokenHash
+}
+
+// CodeChallenge returns the S256 PKCE code challenge the link is bound to.
+func (l *LoginLink) CodeChallenge() string {
+	return l.codeChallenge
+}
+
+// ExpiresAt returns when the link stops working.
+func (l *LoginLink) ExpiresAt() time.Time {
+	return l.expiresAt
+}
+
+// CreatedAt returns when the link was requested.
+func (l *LoginLink) CreatedAt() time.Time {
+	return l.createdAt
+}
+
+// UsedAt returns when the link was redeemed or burned.
+func (l *LoginLink) UsedAt() *time.Time {
+	return l.usedAt
+}
+
+// IsUsable reports whether the link has neither been used nor expired.
+func (l *LoginLink) IsUsable() bool {
+	return l.usedAt == nil && shared.Now().Before(l.expiresAt)
+}
+
+// VerifyCodeVerifier reports whether the code verifier matches the link's code challenge.
+func (l *LoginLink) VerifyCodeVerifier(codeVerifier string) bool {
+	if !codeVerifierPattern.MatchString(codeVerifier) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(CodeChallenge(codeVerifier)), []byte(l.codeChallenge)) == 1
+}
+
+// Use marks the link as used. A link is used once, whether or not it was redeemed successfully.
+func (l *LoginLink) Use() {
+	if l.usedAt != nil {
+		return
+	}
+	now := shared.Now().UTC()
+	l.usedAt = &now
+}
+
+// CodeChallenge returns the S256 code challenge of a code verifier.
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,78 @@
+package customer
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxRefundAddresses is how many refund addresses a customer can save.
+	MaxRefundAddresses = 20
+
+	// maxRefundAddressLabel is the longest label of a saved refund address.
+	maxRefundAddressLabel = 64
+)
+
+// RefundAddress is an address a customer saved to supply as the refund address of their invoices.
+type RefundAddress struct {
+	id         string
+	customerID string
+	address    string
+	label      string
+	createdAt  time.Time
+}
+
+// NewRefundAddress creates a saved refund address.
+func NewRefundAddress(id, customerID, address, label string) (*RefundAddress, error) {
+	return RestoreRefundAddress(id, customerID, address, label, shared.Now().UTC())
+}
+
+// RestoreRefundAddress recreates a saved refund address from storage.
+func RestoreRefundAddress(id, customerID, address, label string, createdAt time.Time) (*RefundAddress, error) {
+	if id == "" || customerID == "" {
+		return nil, errors.New("refund address ID and customer are required")
+	}
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return nil, errors.New("refund address is required")
+	}
+	label = strings.TrimSpace(label)
+	if len(label) > maxRefundAddressLabel {
+		return nil, errors.New("refund address label is too long")
+	}
+
+	return &RefundAddress{
+		id:         id,
+		customerID: customerID,
+		address:    address,
+		label:      label,
+		createdAt:  createdAt,
+	}, nil
+}
+
+// ID returns the saved refund address ID.
+func (a *RefundAddress) ID() string {
+	return a.id
+}
+
+// CustomerID returns the customer who saved the address.
+func (a *RefundAddress) CustomerID() string {
+	return a.customerID
+}
+
+// Address returns the blockchain address.
+func (a *RefundAddress) Address() string {
+	return a.address
+}
+
+// Label returns the customer's name for the address.
+func (a *RefundAddress) Label() string {
+	return a.label
+}
+
+// CreatedAt returns when the address was saved.
+func (a *RefundAddress) CreatedAt() time.Time {
+	return a.createdAt
+}
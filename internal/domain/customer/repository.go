@@ -0,0 +1,74 @@
+package customer
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for customer account persistence.
+type Repository interface {
+	// Save persists a new customer.
+	Save(ctx context.Context, customer *Customer) error
+
+	// Update updates an existing customer.
+	Update(ctx context.Context, customer *Customer) error
+
+	// FindByID retrieves a customer by ID.
+	FindByID(ctx context.Context, id string) (*Customer, error)
+
+	// FindByEmail retrieves a customer by their normalized email address.
+	FindByEmail(ctx context.Context, email string) (*Customer, error)
+
+	// ClaimInvoice adds an invoice to a customer's payment history. Claiming an invoice the customer already
+	// claimed does nothing; an invoice claimed by another customer returns ErrInvoiceClaimed.
+	ClaimInvoice(ctx context.Context, customerID, invoiceID string) error
+
+	// IsInvoiceClaimed reports whether the invoice is in the customer's payment history.
+	IsInvoiceClaimed(ctx context.Context, customerID, invoiceID string) (bool, error)
+
+	// ListClaimedInvoiceIDs lists the invoices in a customer's payment history, most recently claimed first.
+	ListClaimedInvoiceIDs(ctx context.Context, customerID string, limit, offset int) ([]string, error)
+}
+
+// LoginLinkRepository defines the interface for login link persistence.
+type LoginLinkRepository interface {
+	// Save persists a new login link.
+	Save(ctx context.Context, link *LoginLink) error
+
+	// FindByTokenHash retrieves a login link by its hashed token.
+	FindByTokenHash(ctx context.Context, tokenHash string) (*LoginLink, error)
+
+	// MarkUsed records that a login link was used, returning ErrLoginLinkNotFound if it already was, so a link
+	// redeemed twice at the same time starts a single session.
+	MarkUsed(ctx context.Context, link *LoginLink) error
+
+	// CountSince counts the login links requested for an email address since the given time.
+	CountSince(ctx context.Context, email string, since time.Time) (int64, error)
+}
+
+// SessionRepository defines the interface for customer session persistence.
+type SessionRepository interface {
+	// Save persists a new session.
+	Save(ctx context.Context, session *Session) error
+
+	// FindByTokenHash retrieves a session by its hashed token.
+	FindByTokenHash(ctx context.Context, tokenHash string) (*Session, error)
+
+	// Update updates an existing session.
+	Update(ctx context.Context, session *Session) error
+}
+
+// RefundAddressRepository defines the interface for saved refund address persistence.
+type RefundAddressRepository interface {
+	// Save persists a new saved refund address, returning ErrRefundAddressExists if the customer saved it already.
+	Save(ctx context.Context, address *RefundAddress) error
+
+	// FindByID retrieves a saved refund address by ID.
+	FindByID(ctx context.Context, id string) (*RefundAddress, error)
+
+	// ListByCustomer lists a customer's saved refund addresses, newest first.
+	ListByCustomer(ctx context.Context, customerID string) ([]*RefundAddress, error)
+
+	// Delete removes a saved refund address.
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,105 @@
+package customer
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultSessionTTL is how long a customer stays signed in.
+	DefaultSessionTTL = 7 * 24 * time.Hour
+
+	// SessionTokenPrefix marks customer session tokens, which no merchant authentication accepts.
+	SessionTokenPrefix = "cst_"
+)
+
+// Session is a signed-in customer. Its opaque token is only stored hashed and is revoked on logout.
+type Session struct {
+	id         string
+	customerID string
+	tokenHash  string
+	expiresAt  time.Time
+	createdAt  time.Time
+	revokedAt  *time.Time
+}
+
+// NewSession creates a session for the given raw token.
+func NewSession(id, customerID, rawToken string, ttl time.Duration) (*Session, error) {
+	if rawToken == "" {
+		return nil, errors.New("session token is required")
+	}
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+
+	now := shared.Now().UTC()
+	return RestoreSession(id, customerID, HashToken(rawToken), now.Add(ttl), now, nil)
+}
+
+// RestoreSession recreates a session from storage.
+func RestoreSession(
+	id, customerID, tokenHash string,
+	expiresAt, createdAt time.Time,
+	revokedAt *time.Time,
+) (*Session, error) {
+	if id == "" || customerID == "" {
+		return nil, errors.New("session ID and customer are required")
+	}
+	if tokenHash == "" {
+		return nil, errors.New("token hash is required")
+	}
+
+	return &Session{
+		id:         id,
+		customerID: customerID,
+		tokenHash:  tokenHash,
+		expiresAt:  expiresAt,
+		createdAt:  createdAt,
+		revokedAt:  revokedAt,
+	}, nil
+}
+
+// ID returns the session ID.
+func (s *Session) ID() string {
+	return s.id
+}
+
+// CustomerID returns the signed-in customer.
+func (s *Session) CustomerID() string {
+	return s.customerID
+}
+
+// TokenHash returns the hashed session token.
+func (s *Session) TokenHash() string {
+	return s.tokenHash
+}
+
+// ExpiresAt returns when the session ends.
+func (s *Session) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+// CreatedAt returns when the customer signed in.
+func (s *Session) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// RevokedAt returns when the customer signed out.
+func (s *Session) RevokedAt() *time.Time {
+	return s.revokedAt
+}
+
+// IsActive reports whether the session is neither revoked nor expired.
+func (s *Session) IsActive() bool {
+	return s.revokedAt == nil && shared.Now().Before(s.expiresAt)
+}
+
+// Revoke ends the session.
+func (s *Session) Revoke() {
+	if s.revokedAt != nil {
+		return
+	}
+	now := shared.Now().UTC()
+	s.revokedAt = &now
+}
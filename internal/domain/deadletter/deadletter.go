@@ -0,0 +1,132 @@
+package deadletter
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DeadLetter is an event an in-process handler failed on. The handler is identified by its type name,
+// which is how the event is routed back to it on replay.
+type DeadLetter struct {
+	id           string
+	handler      string
+	event        *shared.BaseDomainEvent
+	lastError    string
+	attempts     int
+	status       Status
+	createdAt    time.Time
+	lastFailedAt time.Time
+	replayedAt   *time.Time
+}
+
+// NewDeadLetter creates a pending dead letter for the first failure of a handler on an event.
+func NewDeadLetter(id, handler string, event *shared.BaseDomainEvent, cause error) (*DeadLetter, error) {
+	if cause == nil {
+		return nil, fmt.Errorf("%w: failure cause is required", ErrInvalidRequest)
+	}
+
+	now := shared.Now().UTC()
+	return RestoreDeadLetter(id, handler, event, cause.Error(), 1, StatusPending, now, now, nil)
+}
+
+// RestoreDeadLetter recreates a dead letter from storage.
+func RestoreDeadLetter(
+	id, handler string,
+	event *shared.BaseDomainEvent,
+	lastError string,
+	attempts int,
+	status Status,
+	createdAt, lastFailedAt time.Time,
+	replayedAt *time.Time,
+) (*DeadLetter, error) {
+	if id == "" {
+		return nil, errors.New("dead letter ID is required")
+	}
+	if handler == "" {
+		return nil, fmt.Errorf("%w: handler is required", ErrInvalidRequest)
+	}
+	if event == nil || event.EventType == "" {
+		return nil, fmt.Errorf("%w: event with a type is required", ErrInvalidRequest)
+	}
+	if !status.IsValid() {
+		return nil, fmt.Errorf("%w: invalid status %s", ErrInvalidRequest, status)
+	}
+
+	return &DeadLetter{
+		id:           id,
+		handler:      handler,
+		event:        event,
+		lastError:    lastError,
+		attempts:     attempts,
+		status:       status,
+		createdAt:    createdAt,
+		lastFailedAt: lastFailedAt,
+		replayedAt:   replayedAt,
+	}, nil
+}
+
+// ID returns the dead letter ID.
+func (d *DeadLetter) ID() string {
+	return d.id
+}
+
+// Handler returns the type name of the handler that failed.
+func (d *DeadLetter) Handler() string {
+	return d.handler
+}
+
+// Event returns the event the handler failed on.
+func (d *DeadLetter) Event() *shared.BaseDomainEvent {
+	return d.event
+}
+
+// LastError returns the error of the most recent failure.
+func (d *DeadLetter) LastError() string {
+	return d.lastError
+}
+
+// Attempts returns how many times the handler failed on the event, including replays.
+func (d *DeadLetter) Attempts() int {
+	return d.attempts
+}
+
+// Status returns the dead letter status.
+func (d *DeadLetter) Status() Status {
+	return d.status
+}
+
+// CreatedAt returns when the handler first failed on the event.
+func (d *DeadLetter) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+// LastFailedAt returns when the handler most recently failed on the event.
+func (d *DeadLetter) LastFailedAt() time.Time {
+	return d.lastFailedAt
+}
+
+// ReplayedAt returns when the event was replayed successfully, or nil if it has not been.
+func (d *DeadLetter) ReplayedAt() *time.Time {
+	return d.replayedAt
+}
+
+// recordFailure records another failure of the handler on the event.
+func (d *DeadLetter) recordFailure(cause error) {
+	d.attempts++
+	d.lastError = cause.Error()
+	d.lastFailedAt = shared.Now().UTC()
+}
+
+// markReplayed records that the handler processed the event on replay.
+func (d *DeadLetter) markReplayed() error {
+	if d.status == StatusReplayed {
+		return ErrAlreadyReplayed
+	}
+
+	now := shared.Now().UTC()
+	d.status = StatusReplayed
+	d.replayedAt = &now
+	return nil
+}
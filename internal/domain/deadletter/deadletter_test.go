@@ -0,0 +1,185 @@
+package deadletter
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryRepository keeps dead letters in memory, oldest first.
+type memoryRepository struct {
+	deadLetters []*DeadLetter
+}
+
+func (r *memoryRepository) Save(_ context.Context, d *DeadLetter) error {
+	r.deadLetters = append(r.deadLetters, d)
+	return nil
+}
+
+func (r *memoryRepository) FindByID(_ context.Context, id string) (*DeadLetter, error) {
+	for _, d := range r.deadLetters {
+		if d.ID() == id {
+			return d, nil
+		}
+	}
+	return nil, ErrDeadLetterNotFound
+}
+
+func (r *memoryRepository) List(_ context.Context, req *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	resp := &ListDeadLettersResponse{Limit: req.Limit}
+	for i := len(r.deadLetters) - 1; i >= 0; i-- {
+		if d := r.deadLetters[i]; req.Status == "" || d.Status() == req.Status {
+			resp.DeadLetters = append(resp.DeadLetters, d)
+		}
+	}
+	return resp, nil
+}
+
+func (r *memoryRepository) Update(context.Context, *DeadLetter) error {
+	return nil
+}
+
+func (r *memoryRepository) Purge(_ context.Context, req *PurgeDeadLettersRequest) (int, error) {
+	kept := r.deadLetters[:0]
+	for _, d := range r.deadLetters {
+		if req.Status == "" || d.Status() == req.Status {
+			continue
+		}
+		kept = append(kept, d)
+	}
+	purged := len(r.deadLetters) - len(kept)
+	r.deadLetters = kept
+	return purged, nil
+}
+
+// memoryRegistry holds handlers by event type.
+type memoryRegistry map[string][]shared.EventHandler
+
+func (r memoryRegistry) RegisterHandler(handler shared.EventHandler) {
+	for _, eventType := range handler.EventTypes() {
+		r[eventType] = append(r[eventType], handler)
+	}
+}
+
+func (r memoryRegistry) GetHandlers(eventType string) []shared.EventHandler {
+	return r[eventType]
+}
+
+func (r memoryRegistry) GetAllHandlers() map[string][]shared.EventHandler {
+	return r
+}
+
+// flakyHandler fails until it is told to recover, and records the invoices it handled.
+type flakyHandler struct {
+	failing bool
+	handled []string
+}
+
+func (h *flakyHandler) EventTypes() []string {
+	return []string{shared.EventTypeInvoicePaid}
+}
+
+func (h *flakyHandler) HandleEvent(_ context.Context, event *shared.BaseDomainEvent) error {
+	if h.failing {
+		return errors.New("settlement store unavailable")
+	}
+	h.handled = append(h.handled, event.AggregateID)
+	return nil
+}
+
+func paidEvent(invoiceID string) *shared.BaseDomainEvent {
+	return shared.CreateDomainEvent(shared.EventTypeInvoicePaid, invoiceID, "invoice",
+		map[string]interface{}{"status": "paid"}, nil)
+}
+
+func TestService_ReplayDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	repository := &memoryRepository{}
+	handler := &flakyHandler{failing: true}
+	registry := memoryRegistry{}
+	registry.RegisterHandler(handler)
+	recorder := NewRecorder(repository, zap.NewNop())
+	service := NewService(repository, registry, zap.NewNop())
+
+	event := paidEvent("invoice-1")
+	require.NoError(t, recorder.RecordFailure(ctx, handler, event, handler.HandleEvent(ctx, event)))
+	require.Len(t, repository.deadLetters, 1)
+	deadLetter := repository.deadLetters[0]
+	assert.Equal(t, "*deadletter.flakyHandler", deadLetter.Handler())
+	assert.Equal(t, StatusPending, deadLetter.Status())
+	assert.Equal(t, 1, deadLetter.Attempts())
+
+	_, err := service.ReplayDeadLetter(ctx, deadLetter.ID())
+	require.ErrorIs(t, err, ErrReplayFailed)
+	assert.Equal(t, StatusPending, deadLetter.Status())
+	assert.Equal(t, 2, deadLetter.Attempts())
+	assert.Equal(t, "settlement store unavailable", deadLetter.LastError())
+
+	handler.failing = false
+	replayed, err := service.ReplayDeadLetter(ctx, deadLetter.ID())
+	require.NoError(t, err)
+	assert.Equal(t, StatusReplayed, replayed.Status())
+	assert.NotNil(t, replayed.ReplayedAt())
+	assert.Equal(t, []string{"invoice-1"}, handler.handled)
+
+	_, err = service.ReplayDeadLetter(ctx, deadLetter.ID())
+	require.ErrorIs(t, err, ErrAlreadyReplayed)
+	assert.Len(t, handler.handled, 1)
+}
+
+func TestService_ReplayDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	repository := &memoryRepository{}
+	handler := &flakyHandler{failing: true}
+	registry := memoryRegistry{}
+	registry.RegisterHandler(handler)
+	recorder := NewRecorder(repository, zap.NewNop())
+	service := NewService(repository, registry, zap.NewNop())
+
+	cause := errors.New("settlement store unavailable")
+	for _, invoiceID := range []string{"invoice-1", "invoice-2", "invoice-3"} {
+		require.NoError(t, recorder.RecordFailure(ctx, handler, paidEvent(invoiceID), cause))
+	}
+
+	t.Run("failures stay pending", func(t *testing.T) {
+		resp, err := service.ReplayDeadLetters(ctx, &ReplayDeadLettersRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Replayed)
+		assert.Len(t, resp.Failed, 3)
+	})
+
+	t.Run("replays oldest first", func(t *testing.T) {
+		handler.failing = false
+		resp, err := service.ReplayDeadLetters(ctx, &ReplayDeadLettersRequest{})
+		require.NoError(t, err)
+		assert.Len(t, resp.Replayed, 3)
+		assert.Empty(t, resp.Failed)
+		assert.Equal(t, []string{"invoice-1", "invoice-2", "invoice-3"}, handler.handled)
+	})
+
+	t.Run("unregistered handler", func(t *testing.T) {
+		orphan := &flakyHandler{}
+		require.NoError(t, recorder.RecordFailure(ctx, struct{ shared.EventHandler }{orphan}, paidEvent("invoice-4"),
+			cause))
+		resp, err := service.ReplayDeadLetters(ctx, &ReplayDeadLettersRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Failed, 1)
+		_, err = service.ReplayDeadLetter(ctx, resp.Failed[0].ID())
+		require.ErrorIs(t, err, ErrHandlerNotRegistered)
+	})
+
+	t.Run("purge replayed", func(t *testing.T) {
+		purged, err := service.PurgeDeadLetters(ctx, &PurgeDeadLettersRequest{Status: StatusReplayed})
+		require.NoError(t, err)
+		assert.Equal(t, 3, purged)
+		require.Len(t, repository.deadLetters, 1)
+
+		_, err = service.PurgeDeadLetters(ctx, &PurgeDeadLettersRequest{Status: "gone"})
+		require.ErrorIs(t, err, ErrInvalidRequest)
+	})
+}
@@ -0,0 +1,21 @@
+package deadletter
+
+import (
+	"crypto-checkout/internal/domain/shared"
+
+	"go.uber.org/fx"
+)
+
+// Module provides the dead letter service layer dependencies.
+var Module = fx.Module("deadletter-service",
+	fx.Provide(
+		fx.Annotate(
+			NewRecorder,
+			fx.As(new(shared.DeadLetterRecorder)),
+		),
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+)
@@ -0,0 +1,19 @@
+// Package deadletter keeps the events in-process handlers failed on, such as settling a paid invoice
+// or scheduling its sweep, so that a transient outage does not silently drop the side effect. Dead
+// letters are listed to operators, who replay them once the cause is fixed or purge them.
+package deadletter
+
+// Status represents whether a dead letter still awaits replay.
+type Status string
+
+const (
+	// StatusPending - Handler failed on the event and it has not been replayed successfully yet
+	StatusPending Status = "pending"
+	// StatusReplayed - Handler processed the event on replay
+	StatusReplayed Status = "replayed"
+)
+
+// IsValid returns true if the dead letter status is valid.
+func (s Status) IsValid() bool {
+	return s == StatusPending || s == StatusReplayed
+}
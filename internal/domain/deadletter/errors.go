@@ -0,0 +1,20 @@
+package deadletter
+
+import "errors"
+
+// Domain errors for dead letter operations
+var (
+	ErrDeadLetterNotFound   = errors.New("dead letter not found")
+	ErrAlreadyReplayed      = errors.New("dead letter is already replayed")
+	ErrHandlerNotRegistered = errors.New("handler is no longer registered for the event type")
+	ErrReplayFailed         = errors.New("handler failed on replay")
+	ErrInvalidRequest       = errors.New("invalid dead letter request")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeDeadLetterNotFound   = "DEAD_LETTER_NOT_FOUND"
+	ErrCodeAlreadyReplayed      = "DEAD_LETTER_REPLAYED"
+	ErrCodeHandlerNotRegistered = "HANDLER_NOT_REGISTERED"
+	ErrCodeReplayFailed         = "REPLAY_FAILED"
+)
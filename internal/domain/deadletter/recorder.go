@@ -0,0 +1,54 @@
+package deadletter
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Recorder dead-letters the events in-process handlers fail on.
+// It implements shared.DeadLetterRecorder.
+type Recorder struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+// NewRecorder creates a new dead letter recorder.
+func NewRecorder(repository Repository, logger *zap.Logger) *Recorder {
+	return &Recorder{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// RecordFailure persists the event a handler failed on. The failure is recorded even if the context of
+// the operation that published the event has since been cancelled.
+func (r *Recorder) RecordFailure(
+	ctx context.Context,
+	handler shared.EventHandler,
+	event *shared.BaseDomainEvent,
+	cause error,
+) error {
+	deadLetter, err := NewDeadLetter(uuid.NewString(), handlerName(handler), event, cause)
+	if err != nil {
+		return err
+	}
+	if err := r.repository.Save(context.WithoutCancel(ctx), deadLetter); err != nil {
+		return fmt.Errorf("failed to save dead letter: %w", err)
+	}
+
+	r.logger.Warn("Dead-lettered event after handler failure",
+		zap.String("dead_letter_id", deadLetter.ID()),
+		zap.String("event_type", event.EventType),
+		zap.String("aggregate_id", event.AggregateID),
+		zap.String("handler_type", deadLetter.Handler()))
+	return nil
+}
+
+// handlerName identifies a handler by its type name, as the event handler registry logs it.
+func handlerName(handler shared.EventHandler) string {
+	return fmt.Sprintf("%T", handler)
+}
@@ -0,0 +1,52 @@
+package deadletter
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+)
+
+// Repository defines the interface for dead letter persistence.
+type Repository interface {
+	// Save persists a new dead letter.
+	Save(ctx context.Context, deadLetter *DeadLetter) error
+
+	// FindByID retrieves a dead letter by its ID.
+	FindByID(ctx context.Context, id string) (*DeadLetter, error)
+
+	// List retrieves dead letters matching the filter, newest first.
+	List(ctx context.Context, req *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+
+	// Update updates an existing dead letter.
+	Update(ctx context.Context, deadLetter *DeadLetter) error
+
+	// Purge deletes the dead letters matching the filter and returns how many were deleted.
+	Purge(ctx context.Context, req *PurgeDeadLettersRequest) (int, error)
+}
+
+// ListDeadLettersRequest represents the request to list dead letters.
+// Empty filter fields match all dead letters.
+type ListDeadLettersRequest struct {
+	Handler   string
+	EventType string
+	Status    Status
+	Limit     int
+	// Cursor continues a listing after the page that returned it.
+	Cursor *shared.Cursor
+}
+
+// ListDeadLettersResponse represents the response from listing dead letters.
+type ListDeadLettersResponse struct {
+	DeadLetters []*DeadLetter
+	Limit       int
+	// NextCursor continues the listing after this page, or is nil on the last page.
+	NextCursor *shared.Cursor
+}
+
+// PurgeDeadLettersRequest represents the request to purge dead letters.
+// Empty filter fields match all dead letters.
+type PurgeDeadLettersRequest struct {
+	ID        string
+	Handler   string
+	EventType string
+	Status    Status
+}
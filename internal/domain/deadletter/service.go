@@ -0,0 +1,231 @@
+package deadletter
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultListLimit is the default page size for listing dead letters.
+	defaultListLimit = 20
+	// maxListLimit is the maximum page size for listing dead letters.
+	maxListLimit = 100
+	// maxReplayBatch is the most dead letters a single bulk replay works through.
+	maxReplayBatch = 1000
+)
+
+// Service defines the interface for working the dead letters of failed event handlers.
+type Service interface {
+	// ListDeadLetters lists dead letters.
+	ListDeadLetters(ctx context.Context, req *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+
+	// GetDeadLetter retrieves a dead letter.
+	GetDeadLetter(ctx context.Context, id string) (*DeadLetter, error)
+
+	// ReplayDeadLetter hands a pending dead letter's event back to the handler that failed on it.
+	ReplayDeadLetter(ctx context.Context, id string) (*DeadLetter, error)
+
+	// ReplayDeadLetters replays the pending dead letters matching the filter, oldest first.
+	ReplayDeadLetters(ctx context.Context, req *ReplayDeadLettersRequest) (*ReplayDeadLettersResponse, error)
+
+	// PurgeDeadLetters deletes the dead letters matching the filter and returns how many were deleted.
+	PurgeDeadLetters(ctx context.Context, req *PurgeDeadLettersRequest) (int, error)
+}
+
+// ReplayDeadLettersRequest represents the request to replay pending dead letters in bulk.
+// Empty filter fields match all pending dead letters.
+type ReplayDeadLettersRequest struct {
+	Handler   string
+	EventType string
+}
+
+// ReplayDeadLettersResponse represents the outcome of a bulk replay.
+type ReplayDeadLettersResponse struct {
+	Replayed []*DeadLetter
+	// Failed are the dead letters the handler failed on again; they stay pending.
+	Failed []*DeadLetter
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository Repository
+	registry   shared.EventHandlerRegistry
+	logger     *zap.Logger
+}
+
+// NewService creates a new dead letter service. Dead letters are replayed to the handlers in the registry.
+func NewService(repository Repository, registry shared.EventHandlerRegistry, logger *zap.Logger) Service {
+	return &ServiceImpl{
+		repository: repository,
+		registry:   registry,
+		logger:     logger,
+	}
+}
+
+// ListDeadLetters lists dead letters.
+func (s *ServiceImpl) ListDeadLetters(
+	ctx context.Context,
+	req *ListDeadLettersRequest,
+) (*ListDeadLettersResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: list dead letters request cannot be nil", ErrInvalidRequest)
+	}
+	if req.Status != "" && !req.Status.IsValid() {
+		return nil, fmt.Errorf("%w: invalid status %s", ErrInvalidRequest, req.Status)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	return s.repository.List(ctx, &ListDeadLettersRequest{
+		Handler:   req.Handler,
+		EventType: req.EventType,
+		Status:    req.Status,
+		Limit:     limit,
+		Cursor:    req.Cursor,
+	})
+}
+
+// GetDeadLetter retrieves a dead letter.
+func (s *ServiceImpl) GetDeadLetter(ctx context.Context, id string) (*DeadLetter, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: dead letter ID is required", ErrInvalidRequest)
+	}
+
+	return s.repository.FindByID(ctx, id)
+}
+
+// ReplayDeadLetter hands the event back to the handler that failed on it. The dead letter is marked
+// replayed if the handler succeeds, and stays pending with the failure recorded if it fails again.
+func (s *ServiceImpl) ReplayDeadLetter(ctx context.Context, id string) (*DeadLetter, error) {
+	deadLetter, err := s.GetDeadLetter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return deadLetter, s.replay(ctx, deadLetter)
+}
+
+// ReplayDeadLetters replays the pending dead letters matching the filter, oldest first so that the
+// events of an aggregate reach the handler in the order they occurred. A failed replay does not stop
+// the others.
+func (s *ServiceImpl) ReplayDeadLetters(
+	ctx context.Context,
+	req *ReplayDeadLettersRequest,
+) (*ReplayDeadLettersResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: replay dead letters request cannot be nil", ErrInvalidRequest)
+	}
+
+	var pending []*DeadLetter
+	listReq := &ListDeadLettersRequest{
+		Handler:   req.Handler,
+		EventType: req.EventType,
+		Status:    StatusPending,
+		Limit:     maxListLimit,
+	}
+	for len(pending) < maxReplayBatch {
+		page, err := s.repository.List(ctx, listReq)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, page.DeadLetters...)
+		if page.NextCursor == nil {
+			break
+		}
+		listReq.Cursor = page.NextCursor
+	}
+	slices.Reverse(pending)
+
+	resp := &ReplayDeadLettersResponse{
+		Replayed: make([]*DeadLetter, 0, len(pending)),
+		Failed:   make([]*DeadLetter, 0),
+	}
+	for _, deadLetter := range pending {
+		if err := s.replay(ctx, deadLetter); err != nil {
+			s.logger.Warn("Dead letter replay failed",
+				zap.String("dead_letter_id", deadLetter.ID()),
+				zap.Error(err))
+			resp.Failed = append(resp.Failed, deadLetter)
+			continue
+		}
+		resp.Replayed = append(resp.Replayed, deadLetter)
+	}
+
+	return resp, nil
+}
+
+// PurgeDeadLetters deletes the dead letters matching the filter and returns how many were deleted.
+func (s *ServiceImpl) PurgeDeadLetters(ctx context.Context, req *PurgeDeadLettersRequest) (int, error) {
+	if req == nil {
+		return 0, fmt.Errorf("%w: purge dead letters request cannot be nil", ErrInvalidRequest)
+	}
+	if req.Status != "" && !req.Status.IsValid() {
+		return 0, fmt.Errorf("%w: invalid status %s", ErrInvalidRequest, req.Status)
+	}
+
+	purged, err := s.repository.Purge(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	s.logger.Info("Purged dead letters",
+		zap.String("dead_letter_id", req.ID),
+		zap.String("handler_type", req.Handler),
+		zap.String("event_type", req.EventType),
+		zap.String("status", string(req.Status)),
+		zap.Int("purged", purged))
+	return purged, nil
+}
+
+// replay hands a pending dead letter's event to the registered handler of the same type and saves the
+// outcome.
+func (s *ServiceImpl) replay(ctx context.Context, deadLetter *DeadLetter) error {
+	if deadLetter.Status() == StatusReplayed {
+		return ErrAlreadyReplayed
+	}
+
+	handler := s.findHandler(deadLetter)
+	if handler == nil {
+		return fmt.Errorf("%w: %s for %s", ErrHandlerNotRegistered, deadLetter.Handler(), deadLetter.Event().EventType)
+	}
+
+	handleErr := handler.HandleEvent(ctx, deadLetter.Event())
+	if handleErr != nil {
+		deadLetter.recordFailure(handleErr)
+	} else if err := deadLetter.markReplayed(); err != nil {
+		return err
+	}
+
+	if err := s.repository.Update(ctx, deadLetter); err != nil {
+		return err
+	}
+	if handleErr != nil {
+		return fmt.Errorf("%w: %w", ErrReplayFailed, handleErr)
+	}
+
+	s.logger.Info("Replayed dead letter",
+		zap.String("dead_letter_id", deadLetter.ID()),
+		zap.String("event_type", deadLetter.Event().EventType),
+		zap.String("handler_type", deadLetter.Handler()))
+	return nil
+}
+
+// findHandler returns the registered handler the dead letter was recorded for, or nil if there is none.
+func (s *ServiceImpl) findHandler(deadLetter *DeadLetter) shared.EventHandler {
+	for _, handler := range s.registry.GetHandlers(deadLetter.Event().EventType) {
+		if handlerName(handler) == deadLetter.Handler() {
+			return handler
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package deposit
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"time"
+)
+
+// Transfer is the on-chain transfer that brought the funds of a deposit.
+type Transfer struct {
+	Network         shared.BlockchainNetwork
+	TransactionHash string
+	FromAddress     string
+	ToAddress       string
+	Amount          string
+	Currency        shared.CryptoCurrency
+	// Memo is the memo or tag the sender attached to the transfer, if the network has one.
+	Memo          string
+	BlockNumber   int64
+	BlockHash     string
+	Confirmations int
+}
+
+// Deposit is a transfer to a payment address that could not be credited to the invoice owning the address.
+type Deposit struct {
+	id                string
+	merchantID        string
+	invoiceID         string
+	reason            Reason
+	transfer          Transfer
+	status            Status
+	attachedInvoiceID string
+	paymentID         string
+	resolvedBy        string
+	createdAt         time.Time
+	resolvedAt        *time.Time
+}
+
+// NewDeposit creates a new unmatched deposit for a transfer to the address of an invoice.
+func NewDeposit(id, merchantID, invoiceID string, reason Reason, transfer Transfer) (*Deposit, error) {
+	return RestoreDeposit(
+		id, merchantID, invoiceID, reason, transfer, StatusUnmatched, "", "", "", shared.Now().UTC(), nil,
+	)
+}
+
+// RestoreDeposit recreates a deposit from storage.
+func RestoreDeposit(
+	id, merchantID, invoiceID string,
+	reason Reason,
+	transfer Transfer,
+	status Status,
+	attachedInvoiceID, paymentID, resolvedBy string,
+	createdAt time.Time,
+	resolvedAt *time.Time,
+) (*Deposit, error) {
+	if id == "" {
+		return nil, errors.New("deposit ID is required")
+	}
+	if merchantID == "" {
+		return nil, errors.New("merchant ID is required")
+	}
+	if transfer.TransactionHash == "" {
+		return nil, errors.New("transaction hash is required")
+	}
+	if !transfer.Network.IsValid() {
+		return nil, errors.New("invalid network")
+	}
+	if !reason.IsValid() {
+		return nil, errors.New("invalid deposit reason")
+	}
+	if !status.IsValid() {
+		return nil, errors.New("invalid deposit status")
+	}
+
+	return &Deposit{
+		id:                id,
+		merchantID:        merchantID,
+		invoiceID:         invoiceID,
+		reason:            reason,
+		transfer:          transfer,
+		status:            status,
+		attachedInvoiceID: attachedInvoiceID,
+		paymentID:         paymentID,
+		resolvedBy:        resolvedBy,
+		createdAt:         createdAt,
+		resolvedAt:        resolvedAt,
+	}, nil
+}
+
+// ID returns the deposit ID.
+func (d *Deposit) ID() string {
+	return d.id
+}
+
+// MerchantID returns the merchant that owns the receiving address.
+func (d *Deposit) MerchantID() string {
+	return d.merchantID
+}
+
+// InvoiceID returns the closed invoice owning the receiving address.
+func (d *Deposit) InvoiceID() string {
+	return d.invoiceID
+}
+
+// Reason returns why the deposit could not be credited to the invoice owning the address.
+func (d *Deposit) Reason() Reason {
+	return d.reason
+}
+
+// Transfer returns the transfer that brought the funds.
+func (d *Deposit) Transfer() Transfer {
+	return d.transfer
+}
+
+// Status returns whether the deposit is unmatched, attached or refunded.
+func (d *Deposit) Status() Status {
+	return d.status
+}
+
+// AttachedInvoiceID returns the invoice the deposit was credited to, if attached.
+func (d *Deposit) AttachedInvoiceID() string {
+	return d.attachedInvoiceID
+}
+
+// PaymentID returns the payment created when the deposit was attached.
+func (d *Deposit) PaymentID() string {
+	return d.paymentID
+}
+
+// ResolvedBy returns who attached or refunded the deposit.
+func (d *Deposit) ResolvedBy() string {
+	return d.resolvedBy
+}
+
+// CreatedAt returns when the deposit was recorded.
+func (d *Deposit) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+// ResolvedAt returns when the deposit was attached or refunded.
+func (d *Deposit) ResolvedAt() *time.Time {
+	return d.resolvedAt
+}
+
+// IsUnmatched returns true if the deposit awaits the merchant.
+func (d *Deposit) IsUnmatched() bool {
+	return d.status == StatusUnmatched
+}
+
+// Advance records the block and a higher confirmation count reported for the transfer. It returns false
+// when nothing changed, since watchers may report a transfer many times and out of order.
+func (d *Deposit) Advance(blockNumber int64, blockHash string, confirmations int) bool {
+	changed := false
+	if blockHash != "" && d.transfer.BlockHash == "" {
+		d.transfer.BlockNumber = blockNumber
+		d.transfer.BlockHash = blockHash
+		changed = true
+	}
+	if confirmations > d.transfer.Confirmations {
+		d.transfer.Confirmations = confirmations
+		changed = true
+	}
+	return changed
+}
+
+// Attach records that the deposit was credited to an invoice with a payment.
+func (d *Deposit) Attach(invoiceID, paymentID, resolvedBy string) error {
+	if !d.IsUnmatched() {
+		return ErrDepositResolved
+	}
+	if invoiceID == "" || paymentID == "" {
+		return ErrInvalidRequest
+	}
+
+	d.status = StatusAttached
+	d.attachedInvoiceID = invoiceID
+	d.paymentID = paymentID
+	d.resolve(resolvedBy)
+	return nil
+}
+
+// Refund records that the deposit is to be returned to the sender.
+func (d *Deposit) Refund(resolvedBy string) error {
+	if !d.IsUnmatched() {
+		return ErrDepositResolved
+	}
+
+	d.status = StatusRefunded
+	d.resolve(resolvedBy)
+	return nil
+}
+
+// resolve records who resolved the deposit and when.
+func (d *Deposit) resolve(resolvedBy string) {
+	now := shared.Now().UTC()
+	d.resolvedBy = resolvedBy
+	d.resolvedAt = &now
+}
@@ -0,0 +1,84 @@
+package deposit
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTransfer() Transfer {
+	return Transfer{
+		Network:         shared.NetworkEthereum,
+		TransactionHash: "0xabc",
+		FromAddress:     "0x1111111111111111111111111111111111111111",
+		ToAddress:       "0x2222222222222222222222222222222222222222",
+		Amount:          "0.5",
+		Currency:        shared.CryptoCurrencyETH,
+	}
+}
+
+func TestReasonForInvoice(t *testing.T) {
+	tests := []struct {
+		status   invoice.InvoiceStatus
+		reason   Reason
+		expected bool
+	}{
+		{invoice.StatusExpired, ReasonInvoiceExpired, true},
+		{invoice.StatusCancelled, ReasonInvoiceCancelled, true},
+		{invoice.StatusPaid, ReasonInvoiceSettled, true},
+		{invoice.StatusRefunded, ReasonInvoiceSettled, true},
+		{invoice.StatusPending, "", false},
+		{invoice.StatusConfirming, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			reason, closed := ReasonForInvoice(tt.status)
+			assert.Equal(t, tt.expected, closed)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
+
+func TestDeposit_Resolve(t *testing.T) {
+	d, err := NewDeposit("deposit-1", "merchant-1", "invoice-1", ReasonInvoiceExpired, testTransfer())
+	require.NoError(t, err)
+	assert.True(t, d.IsUnmatched())
+
+	require.ErrorIs(t, d.Attach("", "payment-1", "user-1"), ErrInvalidRequest)
+	require.NoError(t, d.Attach("invoice-2", "payment-1", "user-1"))
+	assert.Equal(t, StatusAttached, d.Status())
+	assert.Equal(t, "invoice-2", d.AttachedInvoiceID())
+	assert.NotNil(t, d.ResolvedAt())
+
+	require.ErrorIs(t, d.Refund("user-2"), ErrDepositResolved)
+	require.ErrorIs(t, d.Attach("invoice-3", "payment-2", "user-2"), ErrDepositResolved)
+}
+
+func TestDeposit_Advance(t *testing.T) {
+	d, err := NewDeposit("deposit-1", "merchant-1", "invoice-1", ReasonInvoiceSettled, testTransfer())
+	require.NoError(t, err)
+
+	assert.True(t, d.Advance(100, "0xb0", 2))
+	// Late notifications with fewer confirmations leave the deposit as it is
+	assert.False(t, d.Advance(100, "0xb0", 1))
+	assert.True(t, d.Advance(100, "0xb0", 5))
+	assert.Equal(t, int64(100), d.Transfer().BlockNumber)
+	assert.Equal(t, 5, d.Transfer().Confirmations)
+}
+
+func TestNewDeposit_Validation(t *testing.T) {
+	_, err := NewDeposit("", "merchant-1", "invoice-1", ReasonInvoiceExpired, testTransfer())
+	require.Error(t, err)
+
+	_, err = NewDeposit("deposit-1", "merchant-1", "invoice-1", Reason("lost"), testTransfer())
+	require.Error(t, err)
+
+	transfer := testTransfer()
+	transfer.TransactionHash = ""
+	_, err = NewDeposit("deposit-1", "merchant-1", "invoice-1", ReasonInvoiceExpired, transfer)
+	require.Error(t, err)
+}
@@ -0,0 +1,14 @@
+package deposit
+
+import "go.uber.org/fx"
+
+// Module provides the deposit service layer dependencies.
+var Module = fx.Module("deposit-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+			fx.As(new(Recorder)),
+		),
+	),
+)
@@ -0,0 +1,79 @@
+// Package deposit keeps the funds that arrive at a payment address after its invoice was closed, such as
+// transfers to expired invoices or to addresses already settled and swept, until the merchant attaches them
+// to an invoice or refunds them to the sender.
+package deposit
+
+import "crypto-checkout/internal/domain/invoice"
+
+// Status represents whether a deposit still awaits the merchant.
+type Status string
+
+const (
+	// StatusUnmatched - Deposit is not attributed to any invoice
+	StatusUnmatched Status = "unmatched"
+	// StatusAttached - Deposit was credited to an invoice as a payment
+	StatusAttached Status = "attached"
+	// StatusRefunded - Deposit was returned to the sender
+	StatusRefunded Status = "refunded"
+)
+
+// IsValid returns true if the deposit status is valid.
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusUnmatched, StatusAttached, StatusRefunded:
+		return true
+	default:
+		return false
+	}
+}
+
+// Reason identifies why a deposit could not be credited to the invoice owning the address.
+type Reason string
+
+const (
+	// ReasonInvoiceExpired - Funds arrived after the invoice expired
+	ReasonInvoiceExpired Reason = "invoice_expired"
+	// ReasonInvoiceCancelled - Funds arrived after the invoice was cancelled
+	ReasonInvoiceCancelled Reason = "invoice_cancelled"
+	// ReasonInvoiceSettled - Funds arrived after the invoice was paid or refunded, usually at a swept address
+	ReasonInvoiceSettled Reason = "invoice_settled"
+)
+
+// IsValid returns true if the reason is valid.
+func (r Reason) IsValid() bool {
+	switch r {
+	case ReasonInvoiceExpired, ReasonInvoiceCancelled, ReasonInvoiceSettled:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReasonForInvoice returns why funds arriving for an invoice in the given status are unattributed, or false
+// when the invoice still accepts payments.
+func ReasonForInvoice(status invoice.InvoiceStatus) (Reason, bool) {
+	switch status {
+	case invoice.StatusExpired, invoice.StatusUnderpaidClosed:
+		return ReasonInvoiceExpired, true
+	case invoice.StatusCancelled:
+		return ReasonInvoiceCancelled, true
+	case invoice.StatusPaid, invoice.StatusPartiallyRefunded, invoice.StatusRefunded:
+		return ReasonInvoiceSettled, true
+	default:
+		return "", false
+	}
+}
+
+// Heuristic identifies how an open invoice was matched to a deposit.
+type Heuristic string
+
+const (
+	// HeuristicAddress - The invoice replaced the invoice owning the address
+	HeuristicAddress Heuristic = "address"
+	// HeuristicMemo - The transfer memo is the invoice's payment reference or names it by ID or number
+	HeuristicMemo Heuristic = "memo"
+	// HeuristicFingerprint - The deposit is exactly the invoice's fingerprinted amount
+	HeuristicFingerprint Heuristic = "fingerprint"
+	// HeuristicAmount - The deposit is exactly the amount the invoice still has due
+	HeuristicAmount Heuristic = "amount"
+)
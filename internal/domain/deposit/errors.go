@@ -0,0 +1,21 @@
+package deposit
+
+import "errors"
+
+// Domain errors for unattributed deposits
+var (
+	ErrDepositNotFound  = errors.New("deposit not found")
+	ErrDepositResolved  = errors.New("deposit is already attached or refunded")
+	ErrDuplicateDeposit = errors.New("deposit is already recorded for the transaction")
+	ErrInvalidRequest   = errors.New("invalid deposit request")
+	ErrInvoiceNotFound  = errors.New("invoice not found")
+	ErrAttachNotAllowed = errors.New("deposit cannot be attached to the invoice")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeDepositNotFound  = "DEPOSIT_NOT_FOUND"
+	ErrCodeDepositResolved  = "DEPOSIT_RESOLVED"
+	ErrCodeInvoiceNotFound  = "INVOICE_NOT_FOUND"
+	ErrCodeAttachNotAllowed = "ATTACH_NOT_ALLOWED"
+)
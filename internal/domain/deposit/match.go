@@ -0,0 +1,169 @@
+package deposit
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// maxSupersededHops bounds how far the chain of amended invoices is followed from the address owner.
+	maxSupersededHops = 10
+	// maxCandidates is how many open invoices of each status are considered for memo and amount matches.
+	maxCandidates = 100
+)
+
+// Match is an open invoice an unmatched deposit may belong to.
+type Match struct {
+	InvoiceID string
+	Number    string
+	Heuristic Heuristic
+	// AmountDue is the amount the invoice still expects, in the deposit's cryptocurrency.
+	AmountDue string
+}
+
+// SuggestMatches returns the open invoices an unmatched deposit may belong to, strongest match first.
+//
+// An invoice that replaced the address owner through amendments is the strongest match, as the customer
+// most likely paid the original invoice's address; then invoices the transfer memo names by payment
+// reference, ID or number; then invoices whose fingerprinted amount is exactly the deposit, and last invoices
+// whose amount due is. Only open invoices of the merchant in the deposit's
+// cryptocurrency are suggested, each once with its strongest heuristic.
+func (s *ServiceImpl) SuggestMatches(ctx context.Context, req *GetDepositRequest) ([]Match, error) {
+	deposit, err := s.GetDeposit(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if !deposit.IsUnmatched() {
+		return nil, nil
+	}
+
+	transfer := deposit.Transfer()
+	matches := make([]Match, 0)
+	seen := make(map[string]bool)
+	add := func(inv *invoice.Invoice, heuristic Heuristic) {
+		if seen[inv.ID()] || !acceptsPayment(inv.Status()) || inv.CryptoCurrency() != transfer.Currency {
+			return
+		}
+		seen[inv.ID()] = true
+		match := Match{InvoiceID: inv.ID(), Number: inv.Number(), Heuristic: heuristic}
+		if due, ok := amountDue(inv); ok {
+			match.AmountDue = due.String()
+		}
+		matches = append(matches, match)
+	}
+
+	successor, err := s.findSuccessor(ctx, deposit.InvoiceID())
+	if err != nil {
+		return nil, err
+	}
+	if successor != nil {
+		add(successor, HeuristicAddress)
+	}
+
+	candidates, err := s.openInvoices(ctx, deposit.MerchantID())
+	if err != nil {
+		return nil, err
+	}
+	// Deposits are recorded with valid amounts; an amount that fails to parse only skips the amount matches
+	amount, _ := shared.NewMoneyWithCrypto(transfer.Amount, transfer.Currency)
+	for _, inv := range candidates {
+		if match, ok := inv.MatchesTransfer(transfer.Memo, nil); ok && match == invoice.ReferenceMatchMemo {
+			add(inv, HeuristicMemo)
+		}
+	}
+	if memo := strings.ToLower(strings.TrimSpace(transfer.Memo)); memo != "" {
+		for _, inv := range candidates {
+			if strings.Contains(memo, strings.ToLower(inv.ID())) ||
+				(inv.Number() != "" && strings.Contains(memo, strings.ToLower(inv.Number()))) {
+				add(inv, HeuristicMemo)
+			}
+		}
+	}
+	if amount != nil {
+		for _, inv := range candidates {
+			if match, ok := inv.MatchesTransfer("", amount); ok && match == invoice.ReferenceMatchFingerprint {
+				add(inv, HeuristicFingerprint)
+			}
+		}
+		for _, inv := range candidates {
+			if due, ok := amountDue(inv); ok && due.Equal(amount.Amount()) {
+				add(inv, HeuristicAmount)
+			}
+		}
+	}
+	return matches, nil
+}
+
+// findSuccessor follows the amendments of the invoice owning the address to the invoice that replaced it,
+// or returns nil when it was not replaced.
+func (s *ServiceImpl) findSuccessor(ctx context.Context, invoiceID string) (*invoice.Invoice, error) {
+	owner, err := s.invoiceService.GetInvoice(ctx, invoiceID)
+	if errors.Is(err, shared.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	inv := owner
+	for hops := 0; inv.SupersededBy() != nil && hops < maxSupersededHops; hops++ {
+		next, err := s.invoiceService.GetInvoice(ctx, *inv.SupersededBy())
+		if errors.Is(err, shared.ErrNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get invoice: %w", err)
+		}
+		inv = next
+	}
+	if inv == owner {
+		return nil, nil
+	}
+	return inv, nil
+}
+
+// openInvoices returns the merchant's invoices that still accept payments, up to maxCandidates per status.
+func (s *ServiceImpl) openInvoices(ctx context.Context, merchantID string) ([]*invoice.Invoice, error) {
+	var invoices []*invoice.Invoice
+	for _, status := range []invoice.InvoiceStatus{invoice.StatusCreated, invoice.StatusPending, invoice.StatusPartial} {
+		resp, err := s.invoiceService.ListInvoices(ctx, &invoice.ListInvoicesRequest{
+			MerchantID: merchantID,
+			Status:     &status,
+			Limit:      maxCandidates,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list invoices: %w", err)
+		}
+		invoices = append(invoices, resp.Invoices...)
+	}
+	return invoices, nil
+}
+
+// acceptsPayment reports whether an invoice in the given status can be credited with a deposit.
+func acceptsPayment(status invoice.InvoiceStatus) bool {
+	switch status {
+	case invoice.StatusCreated, invoice.StatusPending, invoice.StatusPartial:
+		return true
+	default:
+		return false
+	}
+}
+
+// amountDue returns the amount an invoice still expects at its locked exchange rate.
+func amountDue(inv *invoice.Invoice) (decimal.Decimal, bool) {
+	locked, err := inv.LockedCryptoAmount()
+	if err != nil {
+		return decimal.Zero, false
+	}
+	due := locked.Amount()
+	if paid := inv.AmountPaid(); paid != nil {
+		due = due.Sub(paid.Amount())
+	}
+	return due, due.IsPositive()
+}
@@ -0,0 +1,41 @@
+package deposit
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+)
+
+// Repository defines the interface for deposit persistence.
+type Repository interface {
+	// Save persists a new deposit, failing with ErrDuplicateDeposit when the transaction is already recorded.
+	Save(ctx context.Context, deposit *Deposit) error
+
+	// FindByID retrieves a deposit by its ID.
+	FindByID(ctx context.Context, id string) (*Deposit, error)
+
+	// FindByTransaction retrieves the deposit recorded for a transaction on a network.
+	FindByTransaction(ctx context.Context, network shared.BlockchainNetwork, txHash string) (*Deposit, error)
+
+	// List retrieves deposits matching the filter, oldest first.
+	List(ctx context.Context, req *ListDepositsRequest) (*ListDepositsResponse, error)
+
+	// Update updates an existing deposit.
+	Update(ctx context.Context, deposit *Deposit) error
+}
+
+// ListDepositsRequest represents the request to list deposits.
+// An empty status matches all deposits.
+type ListDepositsRequest struct {
+	MerchantID string
+	Status     Status
+	Limit      int
+	Offset     int
+}
+
+// ListDepositsResponse represents the response from listing deposits.
+type ListDepositsResponse struct {
+	Deposits []*Deposit
+	Total    int
+	Limit    int
+	Offset   int
+}
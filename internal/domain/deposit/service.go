@@ -0,0 +1,471 @@
+package deposit
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultListLimit is the default page size for listing deposits.
+	defaultListLimit = 20
+	// maxListLimit is the maximum page size for listing deposits.
+	maxListLimit = 100
+)
+
+// Recorder keeps the transfers that payment detection cannot credit to the invoice owning the address.
+type Recorder interface {
+	// RecordDeposit records an unattributed deposit, or advances the confirmations of the deposit already
+	// recorded for the transaction, in which case it reports a duplicate.
+	RecordDeposit(ctx context.Context, req *RecordDepositRequest) (*Deposit, bool, error)
+}
+
+// Service defines the interface for attributing unmatched deposits.
+type Service interface {
+	Recorder
+
+	// ListDeposits lists deposits for a merchant.
+	ListDeposits(ctx context.Context, req *ListDepositsRequest) (*ListDepositsResponse, error)
+
+	// GetDeposit retrieves a deposit.
+	GetDeposit(ctx context.Context, req *GetDepositRequest) (*Deposit, error)
+
+	// SuggestMatches returns the open invoices an unmatched deposit may belong to, strongest match first.
+	SuggestMatches(ctx context.Context, req *GetDepositRequest) ([]Match, error)
+
+	// AttachDeposit credits an unmatched deposit to an open invoice as a payment.
+	AttachDeposit(ctx context.Context, req *AttachDepositRequest) (*Deposit, error)
+
+	// RefundDeposit requests that an unmatched deposit is returned to the sender.
+	RefundDeposit(ctx context.Context, req *RefundDepositRequest) (*Deposit, error)
+}
+
+// RecordDepositRequest represents a transfer to the address of a closed invoice.
+type RecordDepositRequest struct {
+	MerchantID string `validate:"required"`
+	InvoiceID  string `validate:"required"`
+	Reason     Reason `validate:"required"`
+	Transfer   Transfer
+}
+
+// GetDepositRequest represents the request to get a deposit.
+type GetDepositRequest struct {
+	MerchantID string `validate:"required"`
+	DepositID  string `validate:"required"`
+}
+
+// AttachDepositRequest represents the merchant's decision to credit a deposit to an invoice.
+type AttachDepositRequest struct {
+	MerchantID string `validate:"required"`
+	DepositID  string `validate:"required"`
+	InvoiceID  string `validate:"required"`
+	ResolvedBy string `validate:"required"`
+}
+
+// RefundDepositRequest represents the merchant's decision to return a deposit to the sender.
+type RefundDepositRequest struct {
+	MerchantID string `validate:"required"`
+	DepositID  string `validate:"required"`
+	ResolvedBy string `validate:"required"`
+	Note       string `validate:"max=1000"`
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository     Repository
+	invoiceService invoice.InvoiceService
+	paymentService payment.PaymentService
+	ids            shared.IDGenerator
+	confirmations  payment.ConfirmationPolicySource
+	auditService   audit.Service
+	eventBus       shared.EventBus
+	logger         *zap.Logger
+}
+
+// NewService creates a new deposit service. The ID generator may be nil, in which case attached payments
+// get random UUIDs, and the confirmation policy source may be nil, in which case the default policy applies.
+func NewService(
+	repository Repository,
+	invoiceService invoice.InvoiceService,
+	paymentService payment.PaymentService,
+	ids shared.IDGenerator,
+	confirmations payment.ConfirmationPolicySource,
+	auditService audit.Service,
+	eventBus shared.EventBus,
+	logger *zap.Logger,
+) Service {
+	if ids == nil {
+		ids = shared.RandomIDGenerator{}
+	}
+	return &ServiceImpl{
+		repository:     repository,
+		invoiceService: invoiceService,
+		paymentService: paymentService,
+		ids:            ids,
+		confirmations:  confirmations,
+		auditService:   auditService,
+		eventBus:       eventBus,
+		logger:         logger,
+	}
+}
+
+// RecordDeposit records an unattributed deposit, or advances the confirmations of the deposit already
+// recorded for the transaction, in which case it reports a duplicate.
+func (s *ServiceImpl) RecordDeposit(ctx context.Context, req *RecordDepositRequest) (*Deposit, bool, error) {
+	if req == nil {
+		return nil, false, fmt.Errorf("%w: record deposit request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, false, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	existing, err := s.repository.FindByTransaction(ctx, req.Transfer.Network, req.Transfer.TransactionHash)
+	if err == nil {
+		return s.advance(ctx, existing, req.Transfer)
+	}
+	if !errors.Is(err, ErrDepositNotFound) {
+		return nil, false, fmt.Errorf("failed to find deposit: %w", err)
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to generate deposit ID: %w", err)
+	}
+	deposit, err := NewDeposit(id, req.MerchantID, req.InvoiceID, req.Reason, req.Transfer)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	if err := s.repository.Save(ctx, deposit); errors.Is(err, ErrDuplicateDeposit) {
+		// Another notification of the transaction recorded it first
+		existing, err := s.repository.FindByTransaction(ctx, req.Transfer.Network, req.Transfer.TransactionHash)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to find deposit: %w", err)
+		}
+		return s.advance(ctx, existing, req.Transfer)
+	} else if err != nil {
+		return nil, false, fmt.Errorf("failed to save deposit: %w", err)
+	}
+
+	s.logger.Info("Recorded unattributed deposit",
+		zap.String("deposit_id", deposit.ID()),
+		zap.String("invoice_id", deposit.InvoiceID()),
+		zap.String("reason", string(deposit.Reason())),
+		zap.String("transaction_hash", req.Transfer.TransactionHash))
+	s.publish(ctx, shared.NewDepositUnattributedEvent(createDepositEventData(deposit)))
+
+	return deposit, false, nil
+}
+
+// advance applies the block and confirmations reported for a transfer to the deposit already recorded for it.
+func (s *ServiceImpl) advance(ctx context.Context, deposit *Deposit, transfer Transfer) (*Deposit, bool, error) {
+	if deposit.Advance(transfer.BlockNumber, transfer.BlockHash, transfer.Confirmations) {
+		if err := s.repository.Update(ctx, deposit); err != nil {
+			return nil, false, fmt.Errorf("failed to update deposit: %w", err)
+		}
+	}
+	return deposit, true, nil
+}
+
+// ListDeposits lists deposits for a merchant.
+func (s *ServiceImpl) ListDeposits(ctx context.Context, req *ListDepositsRequest) (*ListDepositsResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: list deposits request cannot be nil", ErrInvalidRequest)
+	}
+	if req.MerchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+	if req.Status != "" && !req.Status.IsValid() {
+		return nil, fmt.Errorf("%w: invalid status %s", ErrInvalidRequest, req.Status)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	return s.repository.List(ctx, &ListDepositsRequest{
+		MerchantID: req.MerchantID,
+		Status:     req.Status,
+		Limit:      limit,
+		Offset:     max(req.Offset, 0),
+	})
+}
+
+// GetDeposit retrieves a deposit.
+func (s *ServiceImpl) GetDeposit(ctx context.Context, req *GetDepositRequest) (*Deposit, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: get deposit request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	return s.findMerchantDeposit(ctx, req.MerchantID, req.DepositID)
+}
+
+// AttachDeposit credits an unmatched deposit to an open invoice as a payment.
+//
+// The payment is recorded for the transfer as if it had been sent to the invoice, so it goes through
+// screening, confirmation and the invoice's payment checks like any other payment; an underpayment is
+// queued for review as usual. Later notifications of the transfer advance the payment.
+func (s *ServiceImpl) AttachDeposit(ctx context.Context, req *AttachDepositRequest) (*Deposit, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: attach deposit request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	deposit, err := s.findMerchantDeposit(ctx, req.MerchantID, req.DepositID)
+	if err != nil {
+		return nil, err
+	}
+	if !deposit.IsUnmatched() {
+		return nil, ErrDepositResolved
+	}
+
+	inv, err := s.invoiceService.GetInvoice(ctx, req.InvoiceID)
+	if errors.Is(err, shared.ErrNotFound) || (err == nil && inv.MerchantID() != req.MerchantID) {
+		return nil, ErrInvoiceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+	transfer := deposit.Transfer()
+	if !acceptsPayment(inv.Status()) {
+		return nil, fmt.Errorf("%w: invoice is %s", ErrAttachNotAllowed, inv.Status())
+	}
+	if inv.CryptoCurrency() != transfer.Currency {
+		return nil, fmt.Errorf("%w: invoice expects %s, not %s",
+			ErrAttachNotAllowed, inv.CryptoCurrency(), transfer.Currency)
+	}
+
+	p, err := s.createPayment(ctx, inv, transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := deposit.Attach(inv.ID(), string(p.ID()), req.ResolvedBy); err != nil {
+		return nil, err
+	}
+	if err := s.repository.Update(ctx, deposit); err != nil {
+		return nil, fmt.Errorf("failed to update deposit: %w", err)
+	}
+
+	s.recordAudit(ctx, deposit, "deposit.attached", map[string]interface{}{
+		"invoice_id": inv.ID(),
+		"payment_id": string(p.ID()),
+	})
+	s.publish(ctx, shared.NewDepositAttachedEvent(createDepositEventData(deposit)))
+
+	return deposit, nil
+}
+
+// createPayment records the payment for a transfer against an invoice and credits the invoice with it.
+func (s *ServiceImpl) createPayment(
+	ctx context.Context,
+	inv *invoice.Invoice,
+	transfer Transfer,
+) (*payment.Payment, error) {
+	txHash, err := payment.NewTransactionHash(transfer.TransactionHash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAttachNotAllowed, err)
+	}
+	toAddress, err := payment.NewPaymentAddress(transfer.ToAddress, transfer.Network)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAttachNotAllowed, err)
+	}
+	amount, err := shared.NewMoneyWithCrypto(transfer.Amount, transfer.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAttachNotAllowed, err)
+	}
+	paymentAmount, err := payment.NewPaymentAmount(amount, transfer.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAttachNotAllowed, err)
+	}
+
+	paymentID, err := s.ids.NewID(ctx, shared.IDKindPayment, inv.MerchantID())
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.paymentService.CreatePayment(ctx, &payment.CreatePaymentRequest{
+		ID:                    shared.PaymentID(paymentID),
+		InvoiceID:             shared.InvoiceID(inv.ID()),
+		Amount:                paymentAmount,
+		FromAddress:           transfer.FromAddress,
+		ToAddress:             toAddress,
+		TransactionHash:       txHash,
+		RequiredConfirmations: s.confirmationPolicy().Required(amount, transfer.Network),
+	})
+	if err != nil {
+		var paymentErr *payment.PaymentError
+		if errors.As(err, &paymentErr) && paymentErr.Code == payment.ErrCodePaymentAlreadyExists {
+			return nil, fmt.Errorf("%w: %w", ErrAttachNotAllowed, err)
+		}
+		return nil, fmt.Errorf("failed to record payment: %w", err)
+	}
+	if p.Status() == payment.StatusHeld {
+		// Compliance review decides what happens to a held payment
+		return p, nil
+	}
+
+	// Underpayments and stale rate payments are queued for review and still advance
+	if err := s.invoiceService.ProcessPayment(ctx, inv.ID(), p); err != nil &&
+		!errors.Is(err, invoice.ErrUnderpayment) && !errors.Is(err, invoice.ErrStaleRate) {
+		return nil, fmt.Errorf("failed to credit invoice with payment: %w", err)
+	}
+	if transfer.BlockHash != "" {
+		if err := s.paymentService.UpdateBlockInfo(ctx, p.ID(), transfer.BlockNumber, transfer.BlockHash); err != nil {
+			return nil, fmt.Errorf("failed to record block of payment: %w", err)
+		}
+	}
+	if transfer.Confirmations > 0 {
+		if err := s.paymentService.UpdateConfirmations(ctx, p.ID(), transfer.Confirmations); err != nil {
+			return nil, fmt.Errorf("failed to record confirmations of payment: %w", err)
+		}
+	}
+	return p, nil
+}
+
+// RefundDeposit requests that an unmatched deposit is returned to the sender.
+func (s *ServiceImpl) RefundDeposit(ctx context.Context, req *RefundDepositRequest) (*Deposit, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: refund deposit request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	deposit, err := s.findMerchantDeposit(ctx, req.MerchantID, req.DepositID)
+	if err != nil {
+		return nil, err
+	}
+	if err := deposit.Refund(req.ResolvedBy); err != nil {
+		return nil, err
+	}
+	if err := s.repository.Update(ctx, deposit); err != nil {
+		return nil, fmt.Errorf("failed to update deposit: %w", err)
+	}
+
+	s.recordAudit(ctx, deposit, "deposit.refunded", map[string]interface{}{
+		"refund_address": deposit.Transfer().FromAddress,
+		"note":           req.Note,
+	})
+	s.publish(ctx, shared.NewDepositRefundRequestedEvent(shared.DepositRefundRequestedEvent{
+		DepositEvent:  createDepositEventData(deposit),
+		RefundAddress: deposit.Transfer().FromAddress,
+		RequestedBy:   req.ResolvedBy,
+		Note:          req.Note,
+	}))
+
+	return deposit, nil
+}
+
+// recordAudit records the merchant's resolution of a deposit.
+func (s *ServiceImpl) recordAudit(
+	ctx context.Context,
+	deposit *Deposit,
+	action string,
+	after map[string]interface{},
+) {
+	after["status"] = string(deposit.Status())
+	transfer := deposit.Transfer()
+	if err := s.auditService.Record(ctx, &audit.RecordRequest{
+		MerchantID:   deposit.MerchantID(),
+		Actor:        audit.Actor{ID: deposit.ResolvedBy(), Type: audit.ActorTypeUser},
+		Action:       action,
+		ResourceType: "deposit",
+		ResourceID:   deposit.ID(),
+		Before:       map[string]interface{}{"status": string(StatusUnmatched)},
+		After:        after,
+		Details: map[string]interface{}{
+			"invoice_id":       deposit.InvoiceID(),
+			"network":          string(transfer.Network),
+			"transaction_hash": transfer.TransactionHash,
+			"amount":           transfer.Amount,
+			"currency":         string(transfer.Currency),
+		},
+	}); err != nil {
+		s.logger.Error("Failed to record audit entry",
+			zap.String("deposit_id", deposit.ID()),
+			zap.Error(err),
+		)
+	}
+}
+
+// publish publishes a deposit event, logging rather than failing when the bus is unavailable.
+func (s *ServiceImpl) publish(ctx context.Context, event *shared.BaseDomainEvent) {
+	if s.eventBus == nil {
+		return
+	}
+
+	if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+		s.logger.Error("Failed to publish domain event",
+			zap.String("event_type", event.EventType),
+			zap.String("aggregate_id", event.AggregateID),
+			zap.Error(err),
+		)
+	}
+}
+
+// createDepositEventData returns the payload of deposit events with the deposit's transfer.
+func createDepositEventData(deposit *Deposit) shared.DepositEvent {
+	transfer := deposit.Transfer()
+	return shared.DepositEvent{
+		DepositID:         deposit.ID(),
+		MerchantID:        deposit.MerchantID(),
+		InvoiceID:         deposit.InvoiceID(),
+		Reason:            string(deposit.Reason()),
+		Status:            string(deposit.Status()),
+		Network:           string(transfer.Network),
+		TransactionHash:   transfer.TransactionHash,
+		FromAddress:       transfer.FromAddress,
+		ToAddress:         transfer.ToAddress,
+		Amount:            transfer.Amount,
+		Currency:          string(transfer.Currency),
+		AttachedInvoiceID: deposit.AttachedInvoiceID(),
+		PaymentID:         deposit.PaymentID(),
+		Timestamp:         shared.Now().UTC(),
+	}
+}
+
+// findMerchantDeposit loads a deposit and ensures it belongs to the merchant.
+func (s *ServiceImpl) findMerchantDeposit(ctx context.Context, merchantID, id string) (*Deposit, error) {
+	deposit, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if deposit.MerchantID() != merchantID {
+		return nil, ErrDepositNotFound
+	}
+	return deposit, nil
+}
+
+// confirmationPolicy returns the confirmation policy in force.
+func (s *ServiceImpl) confirmationPolicy() payment.ConfirmationPolicy {
+	if s.confirmations == nil {
+		return payment.DefaultConfirmationPolicy()
+	}
+	return s.confirmations.ConfirmationPolicy()
+}
+
+// generateID generates a unique deposit ID.
+func generateID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,379 @@
+package detection
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxBackfillBlocks bounds the blocks one backfill scans.
+	MaxBackfillBlocks = 100000
+	// scanChunkBlocks is how many blocks are read from a chain scanner at a time.
+	scanChunkBlocks = 100
+	// maxBackfills is how many backfills are remembered; the oldest finished ones are forgotten first.
+	maxBackfills = 100
+	// maxBackfillErrors bounds the transfer errors a backfill keeps.
+	maxBackfillErrors = 20
+)
+
+// BackfillStatus represents whether a backfill is still scanning.
+type BackfillStatus string
+
+const (
+	// BackfillStatusRunning - Blocks are being scanned
+	BackfillStatusRunning BackfillStatus = "running"
+	// BackfillStatusCompleted - Every block of the range was scanned
+	BackfillStatusCompleted BackfillStatus = "completed"
+	// BackfillStatusFailed - Scanning stopped at a chain scanner error; resume after ScannedThrough
+	BackfillStatusFailed BackfillStatus = "failed"
+)
+
+// ScanRequest asks a chain scanner for the transfers to a set of addresses in a range of blocks.
+type ScanRequest struct {
+	FromBlock int64
+	ToBlock   int64 // Inclusive
+	Addresses []string
+	Tokens    []shared.Token
+}
+
+// TransferScanner reads the transfers of tokens on one network from its chain.
+type TransferScanner interface {
+	// Network returns the network the scanner reads.
+	Network() shared.BlockchainNetwork
+
+	// LatestBlock returns the number of the newest block.
+	LatestBlock(ctx context.Context) (int64, error)
+
+	// ScanTransfers returns the successful transfers of the tokens to the addresses in the blocks, as
+	// notifications without a confirmation count. Addresses are returned as they were requested.
+	ScanTransfers(ctx context.Context, req *ScanRequest) ([]*Notification, error)
+}
+
+// BackfillRequest represents the request to rescan a range of blocks for missed payments.
+type BackfillRequest struct {
+	Network   shared.BlockchainNetwork
+	FromBlock int64
+	ToBlock   int64 // Inclusive
+}
+
+// Backfill is the progress of a rescan. Every transfer found is replayed through HandleNotification, so
+// transactions already recorded are deduplicated by hash and only advance their confirmations.
+type Backfill struct {
+	ID        string
+	Network   shared.BlockchainNetwork
+	FromBlock int64
+	ToBlock   int64
+	Status    BackfillStatus
+	// ScannedThrough is the last block scanned, or FromBlock-1 before the first one is.
+	ScannedThrough   int64
+	TransfersFound   int
+	PaymentsRecorded int
+	// DepositsRecorded are the transfers to closed invoices kept as unattributed deposits.
+	DepositsRecorded int
+	Duplicates       int
+	// Failures are the transfers the payment pipeline rejected; Errors keeps the first of their errors.
+	Failures int
+	Errors   []string
+	// Error is the chain scanner error a failed backfill stopped at.
+	Error      string
+	StartedAt  time.Time
+	FinishedAt *time.Time
+}
+
+// BlocksScanned returns how many blocks of the range have been scanned.
+func (b *Backfill) BlocksScanned() int64 {
+	return b.ScannedThrough - b.FromBlock + 1
+}
+
+// TotalBlocks returns how many blocks the range holds.
+func (b *Backfill) TotalBlocks() int64 {
+	return b.ToBlock - b.FromBlock + 1
+}
+
+// clone returns a copy of the backfill that later progress does not change.
+func (b *Backfill) clone() *Backfill {
+	c := *b
+	c.Errors = slices.Clone(b.Errors)
+	if b.FinishedAt != nil {
+		finishedAt := *b.FinishedAt
+		c.FinishedAt = &finishedAt
+	}
+	return &c
+}
+
+// BackfillService defines the interface for rescanning the chain for payments the watchers missed.
+type BackfillService interface {
+	// StartBackfill validates the request and starts scanning in the background.
+	StartBackfill(ctx context.Context, req *BackfillRequest) (*Backfill, error)
+
+	// GetBackfill returns the progress of a backfill.
+	GetBackfill(ctx context.Context, id string) (*Backfill, error)
+}
+
+// Backfiller rescans ranges of blocks for transfers to the payment addresses of invoices and replays them
+// through the detection pipeline. Backfills are kept in memory by the instance running them.
+type Backfiller struct {
+	scanners       map[shared.BlockchainNetwork]TransferScanner
+	invoiceService invoice.InvoiceService
+	detection      Service
+	tokens         *shared.TokenRegistry
+	logger         *zap.Logger
+	now            func() time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu        sync.Mutex
+	backfills map[string]*Backfill
+	order     []string
+}
+
+// NewBackfiller creates a backfiller scanning with the given chain scanners. Networks without a scanner
+// cannot be backfilled.
+func NewBackfiller(
+	scanners []TransferScanner,
+	invoiceService invoice.InvoiceService,
+	detection Service,
+	tokens *shared.TokenRegistry,
+	logger *zap.Logger,
+) *Backfiller {
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &Backfiller{
+		scanners:       make(map[shared.BlockchainNetwork]TransferScanner, len(scanners)),
+		invoiceService: invoiceService,
+		detection:      detection,
+		tokens:         tokens,
+		logger:         logger,
+		now:            shared.Now,
+		ctx:            ctx,
+		cancel:         cancel,
+		backfills:      make(map[string]*Backfill),
+	}
+	for _, scanner := range scanners {
+		b.scanners[scanner.Network()] = scanner
+	}
+	return b
+}
+
+// StartBackfill validates the request and starts scanning in the background.
+func (b *Backfiller) StartBackfill(ctx context.Context, req *BackfillRequest) (*Backfill, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", ErrInvalidBackfill)
+	}
+	if !req.Network.IsValid() {
+		return nil, fmt.Errorf("%w: unsupported network %q", ErrInvalidBackfill, req.Network)
+	}
+	if req.FromBlock < 0 || req.ToBlock < req.FromBlock {
+		return nil, fmt.Errorf("%w: invalid block range %d-%d", ErrInvalidBackfill, req.FromBlock, req.ToBlock)
+	}
+	if req.ToBlock-req.FromBlock+1 > MaxBackfillBlocks {
+		return nil, fmt.Errorf("%w: at most %d blocks may be scanned at once", ErrInvalidBackfill, MaxBackfillBlocks)
+	}
+	scanner, ok := b.scanners[req.Network]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrBackfillUnsupported, req.Network)
+	}
+
+	latest, err := scanner.LatestBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrScanFailed, err)
+	}
+	if req.ToBlock > latest {
+		return nil, fmt.Errorf("%w: block %d is beyond the latest block %d", ErrInvalidBackfill, req.ToBlock, latest)
+	}
+	addresses, err := b.invoiceService.GetPaymentAddresses(ctx, req.Network)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment addresses: %w", err)
+	}
+	tokens := networkTokens(b.tokens, req.Network)
+
+	backfill := &Backfill{
+		ID:             uuid.NewString(),
+		Network:        req.Network,
+		FromBlock:      req.FromBlock,
+		ToBlock:        req.ToBlock,
+		Status:         BackfillStatusRunning,
+		ScannedThrough: req.FromBlock - 1,
+		StartedAt:      b.now().UTC(),
+	}
+	b.remember(backfill)
+	b.logger.Info("Payment backfill started",
+		zap.String("backfill_id", backfill.ID),
+		zap.String("network", string(req.Network)),
+		zap.Int64("from_block", req.FromBlock),
+		zap.Int64("to_block", req.ToBlock),
+		zap.Int("addresses", len(addresses)))
+
+	snapshot := backfill.clone()
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.run(backfill, scanner, addresses, tokens)
+	}()
+	return snapshot, nil
+}
+
+// GetBackfill returns the progress of a backfill.
+func (b *Backfiller) GetBackfill(_ context.Context, id string) (*Backfill, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	backfill, ok := b.backfills[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrBackfillNotFound, id)
+	}
+	return backfill.clone(), nil
+}
+
+// Stop cancels the running backfills and waits for them to stop.
+func (b *Backfiller) Stop() {
+	b.cancel()
+	b.wg.Wait()
+}
+
+// run scans the range chunk by chunk, replaying the transfers found, until it is done or the scanner fails.
+func (b *Backfiller) run(backfill *Backfill, scanner TransferScanner, addresses []string, tokens []shared.Token) {
+	if len(addresses) == 0 || len(tokens) == 0 {
+		// Nothing on the network could have been paid
+		b.update(func() { backfill.ScannedThrough = backfill.ToBlock })
+		b.finish(backfill, nil)
+		return
+	}
+
+	for from := backfill.FromBlock; from <= backfill.ToBlock; from += scanChunkBlocks {
+		to := min(from+scanChunkBlocks-1, backfill.ToBlock)
+		if err := b.scanChunk(backfill, scanner, &ScanRequest{
+			FromBlock: from,
+			ToBlock:   to,
+			Addresses: addresses,
+			Tokens:    tokens,
+		}); err != nil {
+			b.finish(backfill, err)
+			return
+		}
+		b.update(func() { backfill.ScannedThrough = to })
+	}
+	b.finish(backfill, nil)
+}
+
+// scanChunk replays the transfers of one chunk of blocks, with the confirmations they have by now.
+func (b *Backfiller) scanChunk(backfill *Backfill, scanner TransferScanner, req *ScanRequest) error {
+	latest, err := scanner.LatestBlock(b.ctx)
+	if err != nil {
+		return err
+	}
+	notifications, err := scanner.ScanTransfers(b.ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for _, notification := range notifications {
+		result, err := replay(b.ctx, b.detection, notification, latest)
+		b.update(func() {
+			backfill.TransfersFound++
+			switch {
+			case err != nil:
+				backfill.Failures++
+				if len(backfill.Errors) < maxBackfillErrors {
+					backfill.Errors = append(backfill.Errors, notification.TransactionHash+": "+err.Error())
+				}
+			case result.Duplicate:
+				backfill.Duplicates++
+			case result.Deposit != nil:
+				backfill.DepositsRecorded++
+			default:
+				backfill.PaymentsRecorded++
+			}
+		})
+		if err != nil {
+			b.logger.Warn("Backfilled transfer was rejected",
+				zap.String("backfill_id", backfill.ID),
+				zap.String("transaction_hash", notification.TransactionHash),
+				zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// finish marks a backfill completed, or failed at the scanner error.
+func (b *Backfiller) finish(backfill *Backfill, err error) {
+	b.update(func() {
+		finishedAt := b.now().UTC()
+		backfill.FinishedAt = &finishedAt
+		backfill.Status = BackfillStatusCompleted
+		if err != nil {
+			backfill.Status = BackfillStatusFailed
+			backfill.Error = err.Error()
+		}
+	})
+
+	snapshot, _ := b.GetBackfill(b.ctx, backfill.ID)
+	fields := []zap.Field{
+		zap.String("backfill_id", snapshot.ID),
+		zap.String("status", string(snapshot.Status)),
+		zap.Int64("scanned_through", snapshot.ScannedThrough),
+		zap.Int("transfers_found", snapshot.TransfersFound),
+		zap.Int("payments_recorded", snapshot.PaymentsRecorded),
+		zap.Int("deposits_recorded", snapshot.DepositsRecorded),
+		zap.Int("duplicates", snapshot.Duplicates),
+		zap.Int("failures", snapshot.Failures),
+	}
+	if err != nil {
+		b.logger.Error("Payment backfill failed", append(fields, zap.Error(err))...)
+		return
+	}
+	b.logger.Info("Payment backfill completed", fields...)
+}
+
+// update changes a backfill under the lock readers take.
+func (b *Backfiller) update(change func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	change()
+}
+
+// remember keeps a new backfill, forgetting the oldest finished one when too many are kept.
+func (b *Backfiller) remember(backfill *Backfill) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.backfills[backfill.ID] = backfill
+	b.order = append(b.order, backfill.ID)
+	for i := 0; len(b.order) > maxBackfills && i < len(b.order); {
+		if b.backfills[b.order[i]].Status == BackfillStatusRunning {
+			i++
+			continue
+		}
+		delete(b.backfills, b.order[i])
+		b.order = slices.Delete(b.order, i, i+1)
+	}
+}
+
+// RegisterBackfiller stops the running backfills when the application stops. They are failed rather than
+// left running, so they can be resumed after their last scanned block.
+func RegisterBackfiller(lc fx.Lifecycle, backfiller *Backfiller) {
+	lc.Append(fx.Hook{
+		OnStop: func(stopCtx context.Context) error {
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				backfiller.Stop()
+			}()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
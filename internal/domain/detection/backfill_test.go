@@ -0,0 +1,203 @@
+package detection_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeScanner serves transfers by block from memory, failing when a scan reaches block failAt.
+type fakeScanner struct {
+	latest    int64
+	transfers map[int64][]*detection.Notification
+	failAt    int64
+
+	mu       sync.Mutex
+	requests []detection.ScanRequest
+}
+
+func (s *fakeScanner) Network() shared.BlockchainNetwork {
+	return shared.NetworkEthereum
+}
+
+func (s *fakeScanner) LatestBlock(context.Context) (int64, error) {
+	return s.latest, nil
+}
+
+func (s *fakeScanner) ScanTransfers(_ context.Context, req *detection.ScanRequest) ([]*detection.Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, *req)
+
+	var notifications []*detection.Notification
+	for block := req.FromBlock; block <= req.ToBlock; block++ {
+		if block == s.failAt {
+			return nil, errors.New("node unavailable")
+		}
+		notifications = append(notifications, s.transfers[block]...)
+	}
+	return notifications, nil
+}
+
+// stubAddresses serves the payment addresses of invoices.
+type stubAddresses struct {
+	invoice.InvoiceService
+	addresses []string
+}
+
+func (s *stubAddresses) GetPaymentAddresses(context.Context, shared.BlockchainNetwork) ([]string, error) {
+	return s.addresses, nil
+}
+
+// recordingDetection records the notifications replayed, treating repeated transaction hashes as duplicates.
+type recordingDetection struct {
+	mu       sync.Mutex
+	seen     map[string]bool
+	replayed []*detection.Notification
+	reject   string
+}
+
+func (d *recordingDetection) HandleNotification(
+	_ context.Context,
+	notification *detection.Notification,
+) (*detection.Result, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.replayed = append(d.replayed, notification)
+	if notification.TransactionHash == d.reject {
+		return nil, detection.ErrInvalidNotification
+	}
+	duplicate := d.seen[notification.TransactionHash]
+	d.seen[notification.TransactionHash] = true
+	return &detection.Result{Duplicate: duplicate}, nil
+}
+
+func transfer(hash string, block int64) *detection.Notification {
+	return &detection.Notification{
+		Network:         shared.NetworkEthereum,
+		TransactionHash: hash,
+		FromAddress:     "0x1111111111111111111111111111111111111111",
+		ToAddress:       "0x2222222222222222222222222222222222222222",
+		Amount:          "0.5",
+		Currency:        shared.CryptoCurrencyETH,
+		BlockNumber:     block,
+	}
+}
+
+func waitForBackfill(t *testing.T, backfiller *detection.Backfiller, id string) *detection.Backfill {
+	var backfill *detection.Backfill
+	require.Eventually(t, func() bool {
+		var err error
+		backfill, err = backfiller.GetBackfill(context.Background(), id)
+		require.NoError(t, err)
+		return backfill.Status != detection.BackfillStatusRunning
+	}, 5*time.Second, 10*time.Millisecond)
+	return backfill
+}
+
+func TestBackfiller(t *testing.T) {
+	ctx := context.Background()
+	tokens, err := shared.NewTokenRegistry(shared.DefaultTokens())
+	require.NoError(t, err)
+	addresses := &stubAddresses{addresses: []string{"0x2222222222222222222222222222222222222222"}}
+
+	newBackfiller := func(scanner *fakeScanner, replays *recordingDetection) *detection.Backfiller {
+		backfiller := detection.NewBackfiller([]detection.TransferScanner{scanner}, addresses, replays, tokens,
+			zap.NewNop())
+		t.Cleanup(backfiller.Stop)
+		return backfiller
+	}
+
+	t.Run("replays transfers and counts duplicates", func(t *testing.T) {
+		scanner := &fakeScanner{latest: 1000, transfers: map[int64][]*detection.Notification{
+			100: {transfer("0xaaa", 100)},
+			150: {transfer("0xbbb", 150), transfer("0xccc", 150)},
+			250: {transfer("0xddd", 250)},
+		}}
+		// 0xaaa was recorded by the watcher before it went down
+		replays := &recordingDetection{seen: map[string]bool{"0xaaa": true}, reject: "0xccc"}
+		backfiller := newBackfiller(scanner, replays)
+
+		started, err := backfiller.StartBackfill(ctx, &detection.BackfillRequest{
+			Network: shared.NetworkEthereum, FromBlock: 100, ToBlock: 250,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, detection.BackfillStatusRunning, started.Status)
+		assert.Equal(t, int64(151), started.TotalBlocks())
+
+		backfill := waitForBackfill(t, backfiller, started.ID)
+		assert.Equal(t, detection.BackfillStatusCompleted, backfill.Status)
+		assert.Equal(t, int64(250), backfill.ScannedThrough)
+		assert.Equal(t, int64(151), backfill.BlocksScanned())
+		assert.Equal(t, 4, backfill.TransfersFound)
+		assert.Equal(t, 2, backfill.PaymentsRecorded)
+		assert.Equal(t, 1, backfill.Duplicates)
+		assert.Equal(t, 1, backfill.Failures)
+		require.Len(t, backfill.Errors, 1)
+		assert.Contains(t, backfill.Errors[0], "0xccc")
+		assert.NotNil(t, backfill.FinishedAt)
+
+		// Scanned in chunks, for the addresses and tokens of the network
+		require.Len(t, scanner.requests, 2)
+		assert.Equal(t, int64(199), scanner.requests[0].ToBlock)
+		assert.Equal(t, int64(200), scanner.requests[1].FromBlock)
+		assert.Equal(t, addresses.addresses, scanner.requests[0].Addresses)
+		require.Len(t, scanner.requests[0].Tokens, 1)
+		assert.Equal(t, shared.CryptoCurrencyETH, scanner.requests[0].Tokens[0].Symbol)
+
+		// Confirmations are counted up to the latest block
+		require.Len(t, replays.replayed, 4)
+		assert.Equal(t, 901, replays.replayed[0].Confirmations)
+		assert.Empty(t, replays.replayed[0].MerchantID)
+	})
+
+	t.Run("stops at a scanner failure", func(t *testing.T) {
+		scanner := &fakeScanner{latest: 1000, failAt: 230, transfers: map[int64][]*detection.Notification{
+			120: {transfer("0xeee", 120)},
+		}}
+		backfiller := newBackfiller(scanner, &recordingDetection{seen: map[string]bool{}})
+
+		started, err := backfiller.StartBackfill(ctx, &detection.BackfillRequest{
+			Network: shared.NetworkEthereum, FromBlock: 100, ToBlock: 300,
+		})
+		require.NoError(t, err)
+
+		backfill := waitForBackfill(t, backfiller, started.ID)
+		assert.Equal(t, detection.BackfillStatusFailed, backfill.Status)
+		assert.Equal(t, int64(199), backfill.ScannedThrough)
+		assert.Equal(t, 1, backfill.PaymentsRecorded)
+		assert.Contains(t, backfill.Error, "node unavailable")
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		backfiller := newBackfiller(&fakeScanner{latest: 1000}, &recordingDetection{seen: map[string]bool{}})
+
+		for name, req := range map[string]*detection.BackfillRequest{
+			"reversed range":  {Network: shared.NetworkEthereum, FromBlock: 200, ToBlock: 100},
+			"negative block":  {Network: shared.NetworkEthereum, FromBlock: -1, ToBlock: 100},
+			"beyond latest":   {Network: shared.NetworkEthereum, FromBlock: 900, ToBlock: 1001},
+			"too many blocks": {Network: shared.NetworkEthereum, FromBlock: 0, ToBlock: detection.MaxBackfillBlocks},
+			"unknown network": {Network: "dogecoin", FromBlock: 0, ToBlock: 100},
+		} {
+			_, err := backfiller.StartBackfill(ctx, req)
+			require.ErrorIs(t, err, detection.ErrInvalidBackfill, name)
+		}
+
+		_, err := backfiller.StartBackfill(ctx, &detection.BackfillRequest{
+			Network: shared.NetworkBitcoin, FromBlock: 0, ToBlock: 100,
+		})
+		require.ErrorIs(t, err, detection.ErrBackfillUnsupported)
+
+		_, err = backfiller.GetBackfill(ctx, "missing")
+		require.ErrorIs(t, err, detection.ErrBackfillNotFound)
+	})
+}
@@ -0,0 +1,29 @@
+package detection
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+)
+
+// BlockCursor is the last block a watcher has processed on a network. Watchers resume from the block after
+// it when they restart.
+type BlockCursor struct {
+	Network   shared.BlockchainNetwork
+	Watcher   string
+	Block     int64
+	UpdatedAt time.Time
+}
+
+// CursorRepository defines the interface for persisting block cursors.
+type CursorRepository interface {
+	// FindCursor returns the cursor of a watcher on a network, or ErrCursorNotFound.
+	FindCursor(ctx context.Context, network shared.BlockchainNetwork, watcher string) (*BlockCursor, error)
+
+	// ListCursors returns the cursors of every watcher and network.
+	ListCursors(ctx context.Context) ([]*BlockCursor, error)
+
+	// AdvanceCursor stores the cursor, creating it if needed. Moving an existing cursor to an earlier block
+	// returns ErrCursorRegression and leaves it unchanged.
+	AdvanceCursor(ctx context.Context, cursor *BlockCursor) error
+}
@@ -0,0 +1,29 @@
+package detection
+
+import (
+	"crypto-checkout/internal/domain/shared"
+
+	"go.uber.org/fx"
+)
+
+// Module provides the payment detection service layer dependencies.
+var Module = fx.Module("detection-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+		fx.Annotate(
+			NewBackfiller,
+			fx.As(fx.Self()),
+			fx.As(new(BackfillService)),
+		),
+		NewWatcher,
+	),
+	fx.Invoke(RegisterBackfiller, RegisterWatcher, ReportWatcherErrors),
+)
+
+// ReportWatcherErrors has the watcher report the errors its checks fail with.
+func ReportWatcherErrors(watcher *Watcher, reporter shared.ErrorReporter) {
+	watcher.ReportErrors(reporter)
+}
@@ -0,0 +1,33 @@
+package detection
+
+import "errors"
+
+// Domain errors for transaction notifications
+var (
+	ErrInvalidNotification = errors.New("invalid transaction notification")
+	ErrUnknownAddress      = errors.New("transaction is not addressed to a known payment address")
+)
+
+// Domain errors for payment backfills
+var (
+	ErrInvalidBackfill     = errors.New("invalid backfill request")
+	ErrBackfillUnsupported = errors.New("no chain scanner is configured for the network")
+	ErrBackfillNotFound    = errors.New("backfill not found")
+	ErrScanFailed          = errors.New("chain scan failed")
+)
+
+// Domain errors for block cursors
+var (
+	ErrCursorNotFound   = errors.New("block cursor not found")
+	ErrCursorRegression = errors.New("block cursor cannot move backwards")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeInvalidNotification = "INVALID_NOTIFICATION"
+	ErrCodeUnknownAddress      = "UNKNOWN_ADDRESS"
+	ErrCodeInvalidBackfill     = "INVALID_BACKFILL"
+	ErrCodeBackfillUnsupported = "BACKFILL_UNSUPPORTED"
+	ErrCodeBackfillNotFound    = "BACKFILL_NOT_FOUND"
+	ErrCodeScannerUnavailable  = "SCANNER_UNAVAILABLE"
+)
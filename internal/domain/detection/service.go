@@ -0,0 +1,371 @@
+// Package detection feeds transactions reported by external chain watchers into the payment pipeline.
+package detection
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/deposit"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/slo"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+// Service defines the interface for detecting payments from transaction notifications.
+type Service interface {
+	// HandleNotification records the payment a notification reports, or advances it when the
+	// transaction was already reported. Transfers to the address of a closed invoice are recorded as
+	// unattributed deposits instead.
+	HandleNotification(ctx context.Context, notification *Notification) (*Result, error)
+}
+
+// Notification is a transaction seen on chain by an external watcher.
+type Notification struct {
+	// MerchantID restricts the notification to one merchant's invoices; empty allows any merchant.
+	MerchantID      string
+	Network         shared.BlockchainNetwork `validate:"required"`
+	TransactionHash string                   `validate:"required"`
+	FromAddress     string                   `validate:"required"`
+	ToAddress       string                   `validate:"required"`
+	Amount          string                   `validate:"required"`
+	Currency        shared.CryptoCurrency    `validate:"required"`
+	BlockNumber     int64                    `validate:"min=0"`
+	BlockHash       string
+	Confirmations   int `validate:"min=0"`
+	// Memo is the memo or tag the sender attached to the transfer, if the network has one.
+	Memo string
+	// Removed reports that a chain reorganization dropped the block holding the transaction.
+	Removed bool
+}
+
+// Result is the outcome of handling a notification.
+type Result struct {
+	// Payment is the payment recorded for the transaction, or nil when it was recorded as a deposit.
+	Payment   *payment.Payment
+	InvoiceID string
+	// Deposit is the unattributed deposit recorded for a transfer to the address of a closed invoice.
+	Deposit *deposit.Deposit
+	// Duplicate reports that the transaction had already been recorded by an earlier notification.
+	Duplicate bool
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	invoiceService invoice.InvoiceService
+	paymentService payment.PaymentService
+	ids            shared.IDGenerator
+	confirmations  payment.ConfirmationPolicySource
+	deposits       deposit.Recorder
+	logger         *zap.Logger
+}
+
+// NewService creates a new payment detection service. The ID generator may be nil, in which case payments
+// get random UUIDs, and the confirmation policy source may be nil, in which case the default policy applies.
+// The deposit recorder may be nil, in which case transfers to closed invoices are rejected when the invoice
+// refuses them.
+func NewService(
+	invoiceService invoice.InvoiceService,
+	paymentService payment.PaymentService,
+	ids shared.IDGenerator,
+	confirmations payment.ConfirmationPolicySource,
+	deposits deposit.Recorder,
+	logger *zap.Logger,
+) Service {
+	if ids == nil {
+		ids = shared.RandomIDGenerator{}
+	}
+	return &ServiceImpl{
+		invoiceService: invoiceService,
+		paymentService: paymentService,
+		ids:            ids,
+		confirmations:  confirmations,
+		deposits:       deposits,
+		logger:         logger,
+	}
+}
+
+// HandleNotification records the payment a notification reports, or advances it when the
+// transaction was already reported.
+//
+// Watchers retry until they are acknowledged, so the same transaction may be reported many times
+// and with growing confirmation counts; transactions are deduplicated by network and hash. A new payment
+// credits its invoice unless compliance screening holds it, in which case the review queue
+// decides what happens to it. Funds sent to the address of an expired, cancelled or settled invoice
+// are kept as an unattributed deposit for the merchant to attach to an invoice or refund. Invoices sharing
+// the address, as when exchanges pay from shared hot wallets, are told apart by the payment reference carried
+// in the transfer memo or fingerprinted amount.
+func (s *ServiceImpl) HandleNotification(ctx context.Context, notification *Notification) (*Result, error) {
+	if notification == nil {
+		return nil, fmt.Errorf("%w: notification cannot be nil", ErrInvalidNotification)
+	}
+	if err := validator.New().Struct(notification); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNotification, err)
+	}
+	if !notification.Network.IsValid() {
+		return nil, fmt.Errorf("%w: unsupported network %q", ErrInvalidNotification, notification.Network)
+	}
+
+	txHash, err := payment.NewTransactionHash(notification.TransactionHash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNotification, err)
+	}
+	toAddress, err := payment.NewPaymentAddress(notification.ToAddress, notification.Network)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNotification, err)
+	}
+
+	ctx = shared.WithMerchantID(ctx, notification.MerchantID)
+	inv, err := s.findInvoice(ctx, toAddress, notification)
+	if err != nil {
+		return nil, err
+	}
+
+	// A recorded transaction only advances, even when its invoice has closed since or the deposit it
+	// brought was attached to another invoice
+	recorded, err := s.findRecorded(ctx, txHash, toAddress)
+	if err != nil {
+		return nil, err
+	}
+	if recorded != nil {
+		return s.handleDuplicate(ctx, recorded, string(recorded.InvoiceID()), notification)
+	}
+	if notification.Removed {
+		return nil, fmt.Errorf("%w: removed transaction %s was never recorded",
+			ErrInvalidNotification, txHash.String())
+	}
+	if notification.Currency != inv.CryptoCurrency() {
+		return nil, fmt.Errorf("%w: invoice %s expects %s, not %s",
+			ErrInvalidNotification, inv.ID(), inv.CryptoCurrency(), notification.Currency)
+	}
+
+	amount, err := shared.NewMoneyWithCrypto(notification.Amount, notification.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNotification, err)
+	}
+	paymentAmount, err := payment.NewPaymentAmount(amount, notification.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNotification, err)
+	}
+
+	if reason, closed := deposit.ReasonForInvoice(inv.Status()); closed && s.deposits != nil {
+		return s.recordDeposit(ctx, inv, reason, notification)
+	}
+
+	paymentID, err := s.ids.NewID(ctx, shared.IDKindPayment, inv.MerchantID())
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.paymentService.CreatePayment(ctx, &payment.CreatePaymentRequest{
+		ID:                    shared.PaymentID(paymentID),
+		InvoiceID:             shared.InvoiceID(inv.ID()),
+		Amount:                paymentAmount,
+		FromAddress:           notification.FromAddress,
+		ToAddress:             toAddress,
+		TransactionHash:       txHash,
+		RequiredConfirmations: s.confirmationPolicy().Required(amount, notification.Network),
+	})
+	if isAlreadyExists(err) {
+		return nil, fmt.Errorf("%w: transaction %s was recorded for another invoice",
+			ErrInvalidNotification, txHash.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to record payment: %w", err)
+	}
+	if p.ID() != shared.PaymentID(paymentID) {
+		return s.handleDuplicate(ctx, p, inv.ID(), notification)
+	}
+	slo.RecordDetection(notification.Confirmations)
+
+	if p.Status() != payment.StatusHeld {
+		// Underpayments and stale rate payments are queued for review and still advance
+		if err := s.invoiceService.ProcessPayment(ctx, inv.ID(), p); err != nil &&
+			!errors.Is(err, invoice.ErrUnderpayment) && !errors.Is(err, invoice.ErrStaleRate) {
+			return nil, fmt.Errorf("failed to credit invoice with payment: %w", err)
+		}
+		if err := s.advance(ctx, p, notification); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Info("Payment detected from transaction notification",
+		zap.String("payment_id", string(p.ID())),
+		zap.String("invoice_id", inv.ID()),
+		zap.String("transaction_hash", txHash.String()))
+
+	return s.result(ctx, p.ID(), inv.ID(), false)
+}
+
+// handleDuplicate advances a payment that an earlier notification, possibly from another source, already
+// recorded, or takes it back when the notification reports the transaction removed by a reorganization.
+func (s *ServiceImpl) handleDuplicate(
+	ctx context.Context,
+	p *payment.Payment,
+	invoiceID string,
+	notification *Notification,
+) (*Result, error) {
+	if notification.Removed {
+		if err := s.remove(ctx, p); err != nil {
+			return nil, err
+		}
+		return s.result(ctx, p.ID(), invoiceID, true)
+	}
+	if err := s.advance(ctx, p, notification); err != nil {
+		return nil, err
+	}
+	return s.result(ctx, p.ID(), invoiceID, true)
+}
+
+// remove takes back a payment whose transaction a reorganization dropped. A payment still confirming is
+// orphaned and queued for review; a confirmed one is reversed, which undoes the credit of its invoice and the
+// invoice's settlement. Payments in other states are left alone.
+func (s *ServiceImpl) remove(ctx context.Context, p *payment.Payment) error {
+	var event string
+	switch p.Status() {
+	case payment.StatusConfirming:
+		event = "orphan"
+	case payment.StatusConfirmed:
+		event = "reverse"
+	default:
+		return nil
+	}
+
+	if err := s.paymentService.UpdatePaymentStatus(ctx, p.ID(), event); err != nil {
+		return fmt.Errorf("failed to take back payment removed by a reorganization: %w", err)
+	}
+
+	s.logger.Warn("Payment transaction removed by a chain reorganization",
+		zap.String("payment_id", string(p.ID())),
+		zap.String("invoice_id", string(p.InvoiceID())),
+		zap.String("previous_status", string(p.Status())),
+		zap.String("event", event))
+	return nil
+}
+
+// recordDeposit keeps a transfer to the address of a closed invoice as an unattributed deposit.
+func (s *ServiceImpl) recordDeposit(
+	ctx context.Context,
+	inv *invoice.Invoice,
+	reason deposit.Reason,
+	notification *Notification,
+) (*Result, error) {
+	d, duplicate, err := s.deposits.RecordDeposit(ctx, &deposit.RecordDepositRequest{
+		MerchantID: inv.MerchantID(),
+		InvoiceID:  inv.ID(),
+		Reason:     reason,
+		Transfer: deposit.Transfer{
+			Network:         notification.Network,
+			TransactionHash: notification.TransactionHash,
+			FromAddress:     notification.FromAddress,
+			ToAddress:       notification.ToAddress,
+			Amount:          notification.Amount,
+			Currency:        notification.Currency,
+			Memo:            notification.Memo,
+			BlockNumber:     notification.BlockNumber,
+			BlockHash:       notification.BlockHash,
+			Confirmations:   notification.Confirmations,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record deposit: %w", err)
+	}
+	return &Result{InvoiceID: inv.ID(), Deposit: d, Duplicate: duplicate}, nil
+}
+
+// advance applies the block and confirmation count a notification reports to a payment still
+// waiting for confirmations. Counts lower than those already recorded are ignored, since watchers
+// may deliver notifications out of order.
+func (s *ServiceImpl) advance(ctx context.Context, p *payment.Payment, notification *Notification) error {
+	if p.Status() != payment.StatusDetected && p.Status() != payment.StatusConfirming {
+		return nil
+	}
+
+	if notification.BlockHash != "" && p.BlockInfo() == nil {
+		if err := s.paymentService.UpdateBlockInfo(
+			ctx, p.ID(), notification.BlockNumber, notification.BlockHash,
+		); err != nil {
+			return fmt.Errorf("failed to record block of payment: %w", err)
+		}
+	}
+
+	if notification.Confirmations > p.Confirmations().Int() {
+		if err := s.paymentService.UpdateConfirmations(ctx, p.ID(), notification.Confirmations); err != nil {
+			return fmt.Errorf("failed to record confirmations of payment: %w", err)
+		}
+	}
+	return nil
+}
+
+// findInvoice returns the invoice expecting payment at an address on the address's network. Invoices sharing
+// the address are told apart by the payment reference the memo or amount of the transfer carries.
+func (s *ServiceImpl) findInvoice(
+	ctx context.Context,
+	address *payment.PaymentAddress,
+	notification *Notification,
+) (*invoice.Invoice, error) {
+	// An invalid amount is rejected once the invoice is found
+	amount, _ := shared.NewMoneyWithCrypto(notification.Amount, notification.Currency)
+	inv, err := s.invoiceService.FindInvoiceForTransfer(ctx, address, notification.Memo, amount)
+	if errors.Is(err, shared.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAddress, address.Address())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find invoice by payment address: %w", err)
+	}
+	if inv.PaymentAddress() == nil || inv.PaymentAddress().Network() != address.Network() {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAddress, address.Address())
+	}
+	return inv, nil
+}
+
+// findRecorded returns the payment already recorded for a transaction to an address, or nil.
+func (s *ServiceImpl) findRecorded(
+	ctx context.Context,
+	txHash *payment.TransactionHash,
+	toAddress *payment.PaymentAddress,
+) (*payment.Payment, error) {
+	p, err := s.paymentService.GetPaymentByTransactionHash(ctx, toAddress.Network(), txHash)
+	var paymentErr *payment.PaymentError
+	if errors.As(err, &paymentErr) && paymentErr.Code == payment.ErrCodePaymentNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payment: %w", err)
+	}
+	if p.ToAddress() == nil || p.ToAddress().Address() != toAddress.Address() {
+		// Recorded for a transfer to another address, which is rejected when the payment is created
+		return nil, nil
+	}
+	return p, nil
+}
+
+// result reloads a payment so the result reflects every update made while handling the notification.
+func (s *ServiceImpl) result(
+	ctx context.Context,
+	id shared.PaymentID,
+	invoiceID string,
+	duplicate bool,
+) (*Result, error) {
+	p, err := s.paymentService.GetPayment(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	return &Result{Payment: p, InvoiceID: invoiceID, Duplicate: duplicate}, nil
+}
+
+// isAlreadyExists reports whether err rejects a payment whose transaction was already recorded.
+func isAlreadyExists(err error) bool {
+	var paymentErr *payment.PaymentError
+	return errors.As(err, &paymentErr) && paymentErr.Code == payment.ErrCodePaymentAlreadyExists
+}
+
+// confirmationPolicy returns the confirmation policy in force.
+func (s *ServiceImpl) confirmationPolicy() payment.ConfirmationPolicy {
+	if s.confirmations == nil {
+		return payment.DefaultConfirmationPolicy()
+	}
+	return s.confirmations.ConfirmationPolicy()
+}
@@ -0,0 +1,358 @@
+package detection
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// ScannerWatcher names the cursors of the built-in watcher, which follows the chain with the chain scanners.
+const ScannerWatcher = "scanner"
+
+// WatcherLogger names the watcher's logger within the module's, so that its level and sampling can be set apart.
+const WatcherLogger = "watcher"
+
+// WatchPolicy configures the built-in chain watcher.
+type WatchPolicy struct {
+	Enabled bool
+	// Interval is how often each network is checked for new blocks.
+	Interval time.Duration
+	// Depth is how many blocks the watcher trails the chain head by. Transfers are reported once, with the
+	// confirmations they have at that depth, so it should cover the most confirmations a payment needs.
+	Depth int64
+	// MaxLag is the lag, in blocks behind the head, beyond which the watcher reports itself unready; zero never
+	// does.
+	MaxLag int64
+}
+
+// DefaultWatchPolicy checks for new blocks every 15 seconds, 20 blocks behind the head, and never reports the
+// watcher unready.
+func DefaultWatchPolicy() WatchPolicy {
+	return WatchPolicy{
+		Interval: 15 * time.Second,
+		Depth:    20,
+	}
+}
+
+// WatcherStatus is how far a watcher is behind the chain head on a network.
+type WatcherStatus struct {
+	Network shared.BlockchainNetwork
+	Watcher string
+	Head    int64
+	Cursor  int64
+	// Lag is the head minus the cursor, including the blocks the watcher trails the head by.
+	Lag       int64
+	CheckedAt time.Time
+	// Error is why the last check failed or the cursor did not move.
+	Error string
+}
+
+// HeadObserver is told the chain head of a network each time the watcher reads it, with the time it did.
+type HeadObserver interface {
+	ObserveHead(network shared.BlockchainNetwork, head int64, at time.Time)
+}
+
+// Watcher follows the chains with the chain scanners, replaying the transfers to payment addresses of invoices
+// through payment detection. Its progress is kept in block cursors, so it resumes where it stopped after a
+// restart; a new watcher starts at the chain head, and earlier blocks are backfilled on request.
+type Watcher struct {
+	scanners       []TransferScanner
+	invoiceService invoice.InvoiceService
+	detection      Service
+	tokens         *shared.TokenRegistry
+	cursors        CursorRepository
+	policy         WatchPolicy
+	logger         *zap.Logger
+	now            func() time.Time
+	heads          HeadObserver
+	reporter       shared.ErrorReporter
+
+	mu       sync.RWMutex
+	statuses map[shared.BlockchainNetwork]WatcherStatus
+}
+
+// NewWatcher creates a new chain watcher for the networks of the chain scanners.
+func NewWatcher(
+	scanners []TransferScanner,
+	invoiceService invoice.InvoiceService,
+	detection Service,
+	tokens *shared.TokenRegistry,
+	cursors CursorRepository,
+	policy WatchPolicy,
+	logger *zap.Logger,
+) *Watcher {
+	defaults := DefaultWatchPolicy()
+	if policy.Interval <= 0 {
+		policy.Interval = defaults.Interval
+	}
+	if policy.Depth < 0 {
+		policy.Depth = 0
+	}
+	return &Watcher{
+		scanners:       scanners,
+		invoiceService: invoiceService,
+		detection:      detection,
+		tokens:         tokens,
+		cursors:        cursors,
+		policy:         policy,
+		logger:         logger.Named(WatcherLogger),
+		now:            shared.Now,
+		statuses:       make(map[shared.BlockchainNetwork]WatcherStatus),
+	}
+}
+
+// ObserveHeads has the watcher tell an observer every chain head it reads.
+func (w *Watcher) ObserveHeads(observer HeadObserver) {
+	w.heads = observer
+}
+
+// ReportErrors has the watcher report the errors its checks fail with.
+func (w *Watcher) ReportErrors(reporter shared.ErrorReporter) {
+	w.reporter = reporter
+}
+
+// Enabled reports whether the watcher is switched on and has networks to watch.
+func (w *Watcher) Enabled() bool {
+	return w.policy.Enabled && len(w.scanners) > 0
+}
+
+// MaxLag returns the lag beyond which the watcher reports itself unready, or zero.
+func (w *Watcher) MaxLag() int64 {
+	return w.policy.MaxLag
+}
+
+// Networks returns the networks the watcher follows.
+func (w *Watcher) Networks() []shared.BlockchainNetwork {
+	networks := make([]shared.BlockchainNetwork, len(w.scanners))
+	for i, scanner := range w.scanners {
+		networks[i] = scanner.Network()
+	}
+	return networks
+}
+
+// Status returns how far the watcher is behind on each network it has checked, ordered by network.
+func (w *Watcher) Status() []WatcherStatus {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	statuses := make([]WatcherStatus, 0, len(w.statuses))
+	for _, status := range w.statuses {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Network < statuses[j].Network
+	})
+	return statuses
+}
+
+// Run follows every network on each interval until the context is cancelled.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.policy.Interval)
+	defer ticker.Stop()
+
+	for {
+		shared.Beat(ctx)
+		w.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check catches up with the head of every network once, as the watcher does on each interval.
+func (w *Watcher) Check(ctx context.Context) {
+	for _, scanner := range w.scanners {
+		if err := w.watch(ctx, scanner); err != nil && ctx.Err() == nil {
+			w.logger.Error("Failed to watch the chain",
+				zap.String("network", string(scanner.Network())), zap.Error(err))
+			w.recordError(scanner.Network(), err)
+			if w.reporter != nil {
+				w.reporter.CaptureError(ctx, err, map[string]string{
+					"network": string(scanner.Network()),
+					"watcher": ScannerWatcher,
+				})
+			}
+		}
+	}
+}
+
+// watch scans the blocks between the network's cursor and the depth the watcher trails the head by, moving
+// the cursor after each chunk.
+func (w *Watcher) watch(ctx context.Context, scanner TransferScanner) error {
+	network := scanner.Network()
+	head, err := scanner.LatestBlock(ctx)
+	if err != nil {
+		return err
+	}
+	if w.heads != nil {
+		w.heads.ObserveHead(network, head, w.now())
+	}
+	target := max(head-w.policy.Depth, 0)
+
+	cursor, err := w.cursors.FindCursor(ctx, network, ScannerWatcher)
+	if errors.Is(err, ErrCursorNotFound) {
+		cursor = &BlockCursor{Network: network, Watcher: ScannerWatcher, Block: target, UpdatedAt: w.now().UTC()}
+		if err := w.cursors.AdvanceCursor(ctx, cursor); err != nil {
+			return fmt.Errorf("failed to create block cursor: %w", err)
+		}
+		w.logger.Info("Started watching the chain", zap.String("network", string(network)),
+			zap.Int64("block", cursor.Block))
+	} else if err != nil {
+		return fmt.Errorf("failed to find block cursor: %w", err)
+	}
+
+	if target < cursor.Block {
+		// The node is behind the cursor, e.g. still syncing; scanning resumes once it catches up
+		w.record(network, head, cursor.Block,
+			fmt.Errorf("%w: chain head %d is behind block %d", ErrCursorRegression, head, cursor.Block))
+		return nil
+	}
+	w.record(network, head, cursor.Block, nil)
+	w.logger.Debug("Checked the chain", zap.String("network", string(network)),
+		zap.Int64("head", head), zap.Int64("block", cursor.Block))
+	if target == cursor.Block {
+		return nil
+	}
+
+	addresses, err := w.invoiceService.GetPaymentAddresses(ctx, network)
+	if err != nil {
+		return fmt.Errorf("failed to get payment addresses: %w", err)
+	}
+	tokens := networkTokens(w.tokens, network)
+
+	for cursor.Block < target {
+		from := cursor.Block + 1
+		to := min(from+scanChunkBlocks-1, target)
+		if len(addresses) > 0 && len(tokens) > 0 {
+			notifications, err := scanner.ScanTransfers(ctx, &ScanRequest{
+				FromBlock: from,
+				ToBlock:   to,
+				Addresses: addresses,
+				Tokens:    tokens,
+			})
+			if err != nil {
+				return err
+			}
+			w.logger.Debug("Scanned blocks", zap.String("network", string(network)),
+				zap.Int64("from", from), zap.Int64("to", to), zap.Int("transfers", len(notifications)))
+			for _, notification := range notifications {
+				_, err := replay(ctx, w.detection, notification, head)
+				if errors.Is(err, ErrInvalidNotification) || errors.Is(err, ErrUnknownAddress) {
+					w.logger.Warn("Watched transfer was rejected",
+						zap.String("network", string(network)),
+						zap.String("transaction_hash", notification.TransactionHash),
+						zap.Error(err))
+				} else if err != nil {
+					// The chunk is scanned again on the next check; transfers already recorded are duplicates
+					return fmt.Errorf("failed to replay transaction %s: %w", notification.TransactionHash, err)
+				}
+			}
+		}
+
+		next := &BlockCursor{Network: network, Watcher: ScannerWatcher, Block: to, UpdatedAt: w.now().UTC()}
+		if err := w.cursors.AdvanceCursor(ctx, next); errors.Is(err, ErrCursorRegression) {
+			// Another instance has moved the cursor further; the next check resumes from there
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to advance block cursor: %w", err)
+		}
+		cursor = next
+		w.record(network, head, cursor.Block, nil)
+		shared.Beat(ctx)
+	}
+	return nil
+}
+
+// record keeps the watcher's position on a network.
+func (w *Watcher) record(network shared.BlockchainNetwork, head, cursor int64, err error) {
+	status := WatcherStatus{
+		Network:   network,
+		Watcher:   ScannerWatcher,
+		Head:      head,
+		Cursor:    cursor,
+		Lag:       max(head-cursor, 0),
+		CheckedAt: w.now().UTC(),
+	}
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.statuses[network] = status
+}
+
+// recordError keeps the error a check of a network failed with, and the last position known.
+func (w *Watcher) recordError(network shared.BlockchainNetwork, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	status := w.statuses[network]
+	status.Network = network
+	status.Watcher = ScannerWatcher
+	status.CheckedAt = w.now().UTC()
+	status.Error = err.Error()
+	w.statuses[network] = status
+}
+
+// RegisterWatcher runs the watcher for the lifetime of the application when it is enabled.
+func RegisterWatcher(
+	lc fx.Lifecycle,
+	watcher *Watcher,
+	workers shared.WorkerSupervisor,
+	logger *zap.Logger,
+) {
+	if !watcher.Enabled() {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			logger.Info("Starting chain watcher", zap.Int("networks", len(watcher.scanners)))
+			go func() {
+				defer close(done)
+				workers.Supervise(ctx, "chain_watcher", watcher.policy.Interval, watcher.Run)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
+
+// replay hands a transfer found on chain to payment detection, with the confirmations it has at the latest
+// block.
+func replay(ctx context.Context, service Service, notification *Notification, latest int64) (*Result, error) {
+	notification.Confirmations = int(max(latest-notification.BlockNumber+1, 0))
+	return service.HandleNotification(ctx, notification)
+}
+
+// networkTokens returns the tokens of the registry on a network.
+func networkTokens(registry *shared.TokenRegistry, network shared.BlockchainNetwork) []shared.Token {
+	var tokens []shared.Token
+	for _, token := range registry.Tokens() {
+		if token.Network == network {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,173 @@
+package detection_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryCursors keeps block cursors in memory, refusing to move them backwards.
+type memoryCursors struct {
+	mu      sync.Mutex
+	cursors map[string]detection.BlockCursor
+}
+
+func (r *memoryCursors) key(network shared.BlockchainNetwork, watcher string) string {
+	return fmt.Sprintf("%s/%s", network, watcher)
+}
+
+func (r *memoryCursors) FindCursor(
+	_ context.Context,
+	network shared.BlockchainNetwork,
+	watcher string,
+) (*detection.BlockCursor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cursor, ok := r.cursors[r.key(network, watcher)]
+	if !ok {
+		return nil, detection.ErrCursorNotFound
+	}
+	return &cursor, nil
+}
+
+func (r *memoryCursors) ListCursors(context.Context) ([]*detection.BlockCursor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var cursors []*detection.BlockCursor
+	for _, cursor := range r.cursors {
+		cursors = append(cursors, &cursor)
+	}
+	return cursors, nil
+}
+
+func (r *memoryCursors) AdvanceCursor(_ context.Context, cursor *detection.BlockCursor) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := r.key(cursor.Network, cursor.Watcher)
+	if stored, ok := r.cursors[key]; ok && stored.Block > cursor.Block {
+		return detection.ErrCursorRegression
+	}
+	r.cursors[key] = *cursor
+	return nil
+}
+
+func (r *memoryCursors) block(t *testing.T) int64 {
+	cursor, err := r.FindCursor(context.Background(), shared.NetworkEthereum, detection.ScannerWatcher)
+	require.NoError(t, err)
+	return cursor.Block
+}
+
+func TestWatcher(t *testing.T) {
+	tokens, err := shared.NewTokenRegistry(shared.DefaultTokens())
+	require.NoError(t, err)
+	addresses := &stubAddresses{addresses: []string{"0x2222222222222222222222222222222222222222"}}
+	policy := detection.WatchPolicy{Enabled: true, Interval: time.Hour, Depth: 10, MaxLag: 50}
+
+	// watch runs the watcher until it reports a check matching done
+	watch := func(
+		scanner *fakeScanner,
+		replays *recordingDetection,
+		cursors *memoryCursors,
+		done func(detection.WatcherStatus) bool,
+	) detection.WatcherStatus {
+		watcher := detection.NewWatcher([]detection.TransferScanner{scanner}, addresses, replays, tokens, cursors,
+			policy, zap.NewNop())
+		require.True(t, watcher.Enabled())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			watcher.Run(ctx)
+		}()
+		defer func() {
+			cancel()
+			<-stopped
+		}()
+
+		var status detection.WatcherStatus
+		require.Eventually(t, func() bool {
+			statuses := watcher.Status()
+			if len(statuses) == 0 {
+				return false
+			}
+			status = statuses[0]
+			return done(status)
+		}, 5*time.Second, 10*time.Millisecond)
+		return status
+	}
+
+	t.Run("starts at the head and resumes from the cursor", func(t *testing.T) {
+		cursors := &memoryCursors{cursors: map[string]detection.BlockCursor{}}
+		replays := &recordingDetection{seen: map[string]bool{}}
+
+		// A new cursor starts behind the head by the depth, without scanning earlier blocks
+		status := watch(&fakeScanner{latest: 1000}, replays, cursors, func(s detection.WatcherStatus) bool {
+			return s.Cursor == 990
+		})
+		assert.Equal(t, int64(1000), status.Head)
+		assert.Equal(t, int64(10), status.Lag)
+		assert.Empty(t, status.Error)
+		assert.Equal(t, int64(990), cursors.block(t))
+
+		// After a restart, the blocks produced while the watcher was down are scanned
+		scanner := &fakeScanner{latest: 1250, transfers: map[int64][]*detection.Notification{
+			990:  {transfer("0xold", 990)},
+			1000: {transfer("0xaaa", 1000)},
+			1240: {transfer("0xbbb", 1240)},
+		}}
+		status = watch(scanner, replays, cursors, func(s detection.WatcherStatus) bool {
+			return s.Cursor == 1240
+		})
+		assert.Equal(t, int64(10), status.Lag)
+		assert.Equal(t, int64(1240), cursors.block(t))
+
+		require.Len(t, scanner.requests, 3)
+		assert.Equal(t, int64(991), scanner.requests[0].FromBlock)
+		assert.Equal(t, int64(1090), scanner.requests[0].ToBlock)
+		assert.Equal(t, int64(1240), scanner.requests[2].ToBlock)
+
+		require.Len(t, replays.replayed, 2)
+		assert.Equal(t, "0xaaa", replays.replayed[0].TransactionHash)
+		assert.Equal(t, 251, replays.replayed[0].Confirmations)
+		assert.Equal(t, "0xbbb", replays.replayed[1].TransactionHash)
+	})
+
+	t.Run("does not move the cursor back", func(t *testing.T) {
+		cursors := &memoryCursors{cursors: map[string]detection.BlockCursor{
+			"ethereum/scanner": {Network: shared.NetworkEthereum, Watcher: detection.ScannerWatcher, Block: 5000},
+		}}
+		scanner := &fakeScanner{latest: 3000}
+
+		// A node still syncing reports a head behind the cursor
+		status := watch(scanner, &recordingDetection{seen: map[string]bool{}}, cursors,
+			func(s detection.WatcherStatus) bool { return s.Error != "" })
+		assert.Contains(t, status.Error, detection.ErrCursorRegression.Error())
+		assert.Equal(t, int64(5000), status.Cursor)
+		assert.Equal(t, int64(0), status.Lag)
+		assert.Equal(t, int64(5000), cursors.block(t))
+		assert.Empty(t, scanner.requests)
+	})
+
+	t.Run("keeps the cursor before a failed chunk", func(t *testing.T) {
+		cursors := &memoryCursors{cursors: map[string]detection.BlockCursor{
+			"ethereum/scanner": {Network: shared.NetworkEthereum, Watcher: detection.ScannerWatcher, Block: 800},
+		}}
+		scanner := &fakeScanner{latest: 1000, failAt: 950}
+
+		status := watch(scanner, &recordingDetection{seen: map[string]bool{}}, cursors,
+			func(s detection.WatcherStatus) bool { return s.Error != "" })
+		assert.Contains(t, status.Error, "node unavailable")
+		assert.Equal(t, int64(900), status.Cursor)
+		assert.Equal(t, int64(100), status.Lag)
+		assert.Equal(t, int64(900), cursors.block(t))
+	})
+}
@@ -0,0 +1,108 @@
+// Package evidence assembles the evidence of an invoice for merchant and customer disputes into bundles whose
+// sections are hashed, timestamped and signed, and keeps the snapshots of the checkout page customers were shown.
+package evidence
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BundleVersion is the version of the bundle manifest format.
+const BundleVersion = 1
+
+// manifestHeader opens the manifest of every bundle.
+const manifestHeader = "crypto-checkout evidence bundle"
+
+// Section is one kind of evidence in a bundle, such as the invoice's payments, with the SHA-256 hash of its
+// content.
+type Section struct {
+	Name    string
+	Content []byte // JSON
+	SHA256  string // Hex-encoded
+}
+
+// NewSection creates a section, hashing its JSON content.
+func NewSection(name string, content []byte) Section {
+	sum := sha256.Sum256(content)
+	return Section{Name: name, Content: content, SHA256: hex.EncodeToString(sum[:])}
+}
+
+// Signature is the Ed25519 signature of a bundle's manifest.
+type Signature struct {
+	Algorithm string
+	KeyID     string
+	Value     []byte
+}
+
+// Bundle is the evidence of an invoice at the time it was generated. Its manifest lists the hash of every section;
+// the digest is the hash of the manifest and the signature, when signing keys are configured, signs the manifest.
+type Bundle struct {
+	Version     int
+	InvoiceID   string
+	MerchantID  string
+	GeneratedAt time.Time
+	Sections    []Section
+	Manifest    string
+	Digest      string // Hex-encoded SHA-256 of the manifest
+	Signature   *Signature
+}
+
+// NewBundle creates the bundle of an invoice's evidence sections, generated at the given time.
+func NewBundle(invoiceID, merchantID string, sections []Section, generatedAt time.Time) (*Bundle, error) {
+	if invoiceID == "" || merchantID == "" {
+		return nil, fmt.Errorf("%w: invoice and merchant IDs are required", ErrInvalidRequest)
+	}
+	seen := make(map[string]bool, len(sections))
+	for _, section := range sections {
+		if section.Name == "" || strings.ContainsAny(section.Name, " \n") || seen[section.Name] {
+			return nil, fmt.Errorf("%w: invalid section name %q", ErrInvalidRequest, section.Name)
+		}
+		seen[section.Name] = true
+	}
+
+	bundle := &Bundle{
+		Version:     BundleVersion,
+		InvoiceID:   invoiceID,
+		MerchantID:  merchantID,
+		GeneratedAt: generatedAt.UTC(),
+		Sections:    sections,
+	}
+	bundle.Manifest = bundle.manifest()
+	sum := sha256.Sum256([]byte(bundle.Manifest))
+	bundle.Digest = hex.EncodeToString(sum[:])
+	return bundle, nil
+}
+
+// manifest lists the bundle's identity, time and section hashes, one per line, in the order of the sections.
+func (b *Bundle) manifest() string {
+	var manifest strings.Builder
+	fmt.Fprintf(&manifest, "%s v%d\n", manifestHeader, b.Version)
+	fmt.Fprintf(&manifest, "invoice_id: %s\n", b.InvoiceID)
+	fmt.Fprintf(&manifest, "merchant_id: %s\n", b.MerchantID)
+	fmt.Fprintf(&manifest, "generated_at: %s\n", b.GeneratedAt.Format(time.RFC3339Nano))
+	for _, section := range b.Sections {
+		fmt.Fprintf(&manifest, "section %s sha256:%s\n", section.Name, section.SHA256)
+	}
+	return manifest.String()
+}
+
+// VerifyIntegrity checks that every section still matches its hash and the manifest its digest. It does not
+// check the signature; see Signer.Verify.
+func (b *Bundle) VerifyIntegrity() error {
+	for _, section := range b.Sections {
+		if NewSection(section.Name, section.Content).SHA256 != section.SHA256 {
+			return fmt.Errorf("%w: section %s does not match its hash", ErrTampered, section.Name)
+		}
+	}
+	if b.manifest() != b.Manifest {
+		return fmt.Errorf("%w: manifest does not match the sections", ErrTampered)
+	}
+	sum := sha256.Sum256([]byte(b.Manifest))
+	if hex.EncodeToString(sum[:]) != b.Digest {
+		return fmt.Errorf("%w: manifest does not match its digest", ErrTampered)
+	}
+	return nil
+}
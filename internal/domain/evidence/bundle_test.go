@@ -0,0 +1,127 @@
+package evidence
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKey(t *testing.T, id string) Key {
+	t.Helper()
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return Key{ID: id, PrivateKey: private}
+}
+
+func newTestBundle(t *testing.T) *Bundle {
+	t.Helper()
+	bundle, err := NewBundle("inv-1", "merchant-1", []Section{
+		NewSection("invoice", []byte(`{"id":"inv-1","total":"100.00"}`)),
+		NewSection("timeline", []byte(`[{"type":"invoice.created"}]`)),
+	}, time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	return bundle
+}
+
+func TestNewBundle(t *testing.T) {
+	bundle := newTestBundle(t)
+
+	assert.Equal(t, "crypto-checkout evidence bundle v1\n"+
+		"invoice_id: inv-1\n"+
+		"merchant_id: merchant-1\n"+
+		"generated_at: 2026-10-17T09:30:00Z\n"+
+		"section invoice sha256:"+bundle.Sections[0].SHA256+"\n"+
+		"section timeline sha256:"+bundle.Sections[1].SHA256+"\n", bundle.Manifest)
+	assert.Len(t, bundle.Digest, 64)
+	require.NoError(t, bundle.VerifyIntegrity())
+
+	t.Run("InvalidSectionNames", func(t *testing.T) {
+		for _, names := range [][]string{{""}, {"two words"}, {"invoice", "invoice"}} {
+			sections := make([]Section, len(names))
+			for i, name := range names {
+				sections[i] = NewSection(name, []byte(`{}`))
+			}
+			_, err := NewBundle("inv-1", "merchant-1", sections, time.Now())
+			require.ErrorIs(t, err, ErrInvalidRequest, names)
+		}
+	})
+}
+
+func TestBundle_VerifyIntegrity(t *testing.T) {
+	t.Run("AlteredSection", func(t *testing.T) {
+		bundle := newTestBundle(t)
+		bundle.Sections[0].Content = []byte(`{"id":"inv-1","total":"10.00"}`)
+		require.ErrorIs(t, bundle.VerifyIntegrity(), ErrTampered)
+	})
+
+	t.Run("RehashedSection", func(t *testing.T) {
+		bundle := newTestBundle(t)
+		bundle.Sections[0] = NewSection("invoice", []byte(`{"id":"inv-1","total":"10.00"}`))
+		require.ErrorIs(t, bundle.VerifyIntegrity(), ErrTampered)
+	})
+
+	t.Run("AlteredTime", func(t *testing.T) {
+		bundle := newTestBundle(t)
+		bundle.GeneratedAt = bundle.GeneratedAt.Add(-24 * time.Hour)
+		require.ErrorIs(t, bundle.VerifyIntegrity(), ErrTampered)
+	})
+}
+
+func TestSigner(t *testing.T) {
+	current, previous := newTestKey(t, "2026-10"), newTestKey(t, "2026-04")
+	signer, err := NewSigner(current, previous)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2026-10", "2026-04"}, signer.KeyIDs())
+
+	t.Run("SignsWithTheCurrentKey", func(t *testing.T) {
+		bundle := newTestBundle(t)
+		require.NoError(t, signer.Sign(bundle))
+		assert.Equal(t, SignatureAlgorithm, bundle.Signature.Algorithm)
+		assert.Equal(t, "2026-10", bundle.Signature.KeyID)
+		require.NoError(t, signer.Verify(bundle))
+		assert.True(t, ed25519.Verify(signer.PublicKeys()["2026-10"], []byte(bundle.Manifest), bundle.Signature.Value))
+	})
+
+	t.Run("VerifiesBundlesOfPreviousKeys", func(t *testing.T) {
+		old, err := NewSigner(previous)
+		require.NoError(t, err)
+		bundle := newTestBundle(t)
+		require.NoError(t, old.Sign(bundle))
+		require.NoError(t, signer.Verify(bundle))
+	})
+
+	t.Run("RejectsForgedSignatures", func(t *testing.T) {
+		bundle := newTestBundle(t)
+		require.NoError(t, signer.Sign(bundle))
+		bundle.Signature.Value[0] ^= 0xff
+		require.ErrorIs(t, signer.Verify(bundle), ErrTampered)
+
+		bundle = newTestBundle(t)
+		require.ErrorIs(t, signer.Verify(bundle), ErrTampered, "unsigned bundle")
+
+		forger, err := NewSigner(newTestKey(t, "forged"))
+		require.NoError(t, err)
+		require.NoError(t, forger.Sign(bundle))
+		require.ErrorIs(t, signer.Verify(bundle), ErrTampered, "unknown key")
+	})
+
+	t.Run("InvalidKeys", func(t *testing.T) {
+		_, err := NewSigner(Key{ID: "", PrivateKey: current.PrivateKey})
+		require.Error(t, err)
+		_, err = NewSigner(current, current)
+		require.Error(t, err)
+		_, err = NewSigner(Key{ID: "short", PrivateKey: current.PrivateKey[:32]})
+		require.Error(t, err)
+	})
+
+	t.Run("NoKeys", func(t *testing.T) {
+		none, err := NewSigner()
+		require.NoError(t, err)
+		assert.False(t, none.Enabled())
+		require.Error(t, none.Sign(newTestBundle(t)))
+	})
+}
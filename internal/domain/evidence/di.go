@@ -0,0 +1,15 @@
+package evidence
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the evidence service layer dependencies.
+var Module = fx.Module("evidence-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+)
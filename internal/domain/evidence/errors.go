@@ -0,0 +1,14 @@
+package evidence
+
+import "errors"
+
+// Domain errors for evidence operations
+var (
+	ErrInvalidRequest = errors.New("invalid evidence request")
+	ErrTampered       = errors.New("evidence bundle was altered")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeInvalidRequest = "INVALID_EVIDENCE_REQUEST"
+)
@@ -0,0 +1,15 @@
+package evidence
+
+import "context"
+
+// Repository defines the interface for checkout snapshot persistence.
+type Repository interface {
+	// SaveSnapshot persists a new checkout snapshot.
+	SaveSnapshot(ctx context.Context, snapshot *CheckoutSnapshot) error
+
+	// FindLatestSnapshot retrieves the latest snapshot of an invoice, or nil when it has none.
+	FindLatestSnapshot(ctx context.Context, invoiceID string) (*CheckoutSnapshot, error)
+
+	// ListSnapshots retrieves the snapshots of an invoice, oldest first.
+	ListSnapshots(ctx context.Context, invoiceID string) ([]*CheckoutSnapshot, error)
+}
@@ -0,0 +1,87 @@
+package evidence
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Service defines the interface for evidence operations.
+type Service interface {
+	// CaptureCheckout records the checkout page content shown for an invoice, unless it is the same as the
+	// latest snapshot's.
+	CaptureCheckout(ctx context.Context, invoiceID, merchantID string, content []byte) error
+
+	// ListSnapshots lists the checkout snapshots of an invoice, oldest first.
+	ListSnapshots(ctx context.Context, invoiceID string) ([]*CheckoutSnapshot, error)
+
+	// Seal creates the bundle of an invoice's evidence sections, timestamped now and signed when signing keys
+	// are configured.
+	Seal(ctx context.Context, invoiceID, merchantID string, sections []Section) (*Bundle, error)
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository Repository
+	signer     *Signer
+	logger     *zap.Logger
+}
+
+// NewService creates a new evidence service. The signer may be nil, in which case bundles are not signed.
+func NewService(repository Repository, signer *Signer, logger *zap.Logger) Service {
+	return &ServiceImpl{
+		repository: repository,
+		signer:     signer,
+		logger:     logger,
+	}
+}
+
+// CaptureCheckout records the checkout page content when it changed.
+func (s *ServiceImpl) CaptureCheckout(ctx context.Context, invoiceID, merchantID string, content []byte) error {
+	if invoiceID == "" || merchantID == "" || len(content) == 0 {
+		return fmt.Errorf("%w: invoice ID, merchant ID and content are required", ErrInvalidRequest)
+	}
+
+	snapshot := NewCheckoutSnapshot(invoiceID, merchantID, content, shared.Now())
+	latest, err := s.repository.FindLatestSnapshot(ctx, invoiceID)
+	if err != nil {
+		return fmt.Errorf("failed to find checkout snapshot: %w", err)
+	}
+	if latest != nil && latest.SHA256 == snapshot.SHA256 {
+		return nil
+	}
+	if err := s.repository.SaveSnapshot(ctx, snapshot); err != nil {
+		return fmt.Errorf("failed to save checkout snapshot: %w", err)
+	}
+	return nil
+}
+
+// ListSnapshots lists the checkout snapshots of an invoice.
+func (s *ServiceImpl) ListSnapshots(ctx context.Context, invoiceID string) ([]*CheckoutSnapshot, error) {
+	if invoiceID == "" {
+		return nil, fmt.Errorf("%w: invoice ID is required", ErrInvalidRequest)
+	}
+	return s.repository.ListSnapshots(ctx, invoiceID)
+}
+
+// Seal hashes, timestamps and signs the evidence of an invoice.
+func (s *ServiceImpl) Seal(_ context.Context, invoiceID, merchantID string, sections []Section) (*Bundle, error) {
+	bundle, err := NewBundle(invoiceID, merchantID, sections, shared.Now())
+	if err != nil {
+		return nil, err
+	}
+	if s.signer.Enabled() {
+		if err := s.signer.Sign(bundle); err != nil {
+			return nil, fmt.Errorf("failed to sign evidence bundle: %w", err)
+		}
+	}
+
+	s.logger.Info("Sealed evidence bundle",
+		zap.String("invoice_id", invoiceID),
+		zap.String("merchant_id", merchantID),
+		zap.String("digest", bundle.Digest),
+		zap.Bool("signed", bundle.Signature != nil))
+	return bundle, nil
+}
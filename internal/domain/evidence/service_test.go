@@ -0,0 +1,93 @@
+package evidence
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubRepository keeps snapshots in memory, oldest first.
+type stubRepository struct {
+	snapshots []*CheckoutSnapshot
+}
+
+func (r *stubRepository) SaveSnapshot(_ context.Context, snapshot *CheckoutSnapshot) error {
+	r.snapshots = append(r.snapshots, snapshot)
+	return nil
+}
+
+func (r *stubRepository) FindLatestSnapshot(_ context.Context, invoiceID string) (*CheckoutSnapshot, error) {
+	for i := len(r.snapshots) - 1; i >= 0; i-- {
+		if r.snapshots[i].InvoiceID == invoiceID {
+			return r.snapshots[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *stubRepository) ListSnapshots(_ context.Context, invoiceID string) ([]*CheckoutSnapshot, error) {
+	var snapshots []*CheckoutSnapshot
+	for _, snapshot := range r.snapshots {
+		if snapshot.InvoiceID == invoiceID {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots, nil
+}
+
+// fixedClock tells a fixed time.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+func TestService_CaptureCheckout(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	t.Cleanup(shared.SetClock(fixedClock(now)))
+	repository := &stubRepository{}
+	service := NewService(repository, nil, zap.NewNop())
+
+	require.NoError(t, service.CaptureCheckout(ctx, "inv-1", "merchant-1", []byte(`{"amount":"100"}`)))
+	require.NoError(t, service.CaptureCheckout(ctx, "inv-1", "merchant-1", []byte(`{"amount":"100"}`)))
+	require.NoError(t, service.CaptureCheckout(ctx, "inv-2", "merchant-1", []byte(`{"amount":"100"}`)))
+	require.NoError(t, service.CaptureCheckout(ctx, "inv-1", "merchant-1", []byte(`{"amount":"101"}`)))
+
+	snapshots, err := service.ListSnapshots(ctx, "inv-1")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2, "unchanged content is not captured again")
+	assert.JSONEq(t, `{"amount":"100"}`, string(snapshots[0].Content))
+	assert.JSONEq(t, `{"amount":"101"}`, string(snapshots[1].Content))
+	assert.Equal(t, now, snapshots[0].CapturedAt)
+
+	err = service.CaptureCheckout(ctx, "inv-1", "merchant-1", nil)
+	require.ErrorIs(t, err, ErrInvalidRequest)
+}
+
+func TestService_Seal(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	t.Cleanup(shared.SetClock(fixedClock(now)))
+	sections := []Section{NewSection("invoice", []byte(`{"id":"inv-1"}`))}
+
+	t.Run("Unsigned", func(t *testing.T) {
+		bundle, err := NewService(&stubRepository{}, nil, zap.NewNop()).Seal(ctx, "inv-1", "merchant-1", sections)
+		require.NoError(t, err)
+		assert.Equal(t, now, bundle.GeneratedAt)
+		assert.Nil(t, bundle.Signature)
+		require.NoError(t, bundle.VerifyIntegrity())
+	})
+
+	t.Run("Signed", func(t *testing.T) {
+		signer, err := NewSigner(newTestKey(t, "2026-10"))
+		require.NoError(t, err)
+		bundle, err := NewService(&stubRepository{}, signer, zap.NewNop()).Seal(ctx, "inv-1", "merchant-1", sections)
+		require.NoError(t, err)
+		require.NotNil(t, bundle.Signature)
+		require.NoError(t, signer.Verify(bundle))
+	})
+}
@@ -0,0 +1,102 @@
+package evidence
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+)
+
+// SignatureAlgorithm is the algorithm bundles are signed with.
+const SignatureAlgorithm = "Ed25519"
+
+// Key is an Ed25519 key bundles are signed with.
+type Key struct {
+	ID         string
+	PrivateKey ed25519.PrivateKey
+}
+
+// Signer signs bundle manifests. The first key signs; the others only verify, so that bundles signed before a
+// key rotation keep verifying.
+type Signer struct {
+	keys []Key
+}
+
+// NewSigner creates the signer of the given keys. Without keys, bundles are hashed but not signed.
+func NewSigner(keys ...Key) (*Signer, error) {
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, errors.New("evidence key ID is required")
+		}
+		if seen[key.ID] {
+			return nil, fmt.Errorf("evidence key %q is configured twice", key.ID)
+		}
+		if len(key.PrivateKey) != ed25519.PrivateKeySize {
+			return nil, fmt.Errorf("evidence key %q is not an Ed25519 private key", key.ID)
+		}
+		seen[key.ID] = true
+	}
+	return &Signer{keys: keys}, nil
+}
+
+// Enabled reports whether keys are configured.
+func (s *Signer) Enabled() bool {
+	return s != nil && len(s.keys) > 0
+}
+
+// Sign signs the bundle's manifest with the current key.
+func (s *Signer) Sign(bundle *Bundle) error {
+	if !s.Enabled() {
+		return errors.New("no evidence keys are configured")
+	}
+	key := s.keys[0]
+	bundle.Signature = &Signature{
+		Algorithm: SignatureAlgorithm,
+		KeyID:     key.ID,
+		Value:     ed25519.Sign(key.PrivateKey, []byte(bundle.Manifest)),
+	}
+	return nil
+}
+
+// Verify checks the bundle's integrity and its signature with the key that made it.
+func (s *Signer) Verify(bundle *Bundle) error {
+	if err := bundle.VerifyIntegrity(); err != nil {
+		return err
+	}
+	if bundle.Signature == nil {
+		return fmt.Errorf("%w: bundle is not signed", ErrTampered)
+	}
+	public, ok := s.PublicKeys()[bundle.Signature.KeyID]
+	if !ok {
+		return fmt.Errorf("%w: unknown key %q", ErrTampered, bundle.Signature.KeyID)
+	}
+	if !ed25519.Verify(public, []byte(bundle.Manifest), bundle.Signature.Value) {
+		return fmt.Errorf("%w: signature does not match the manifest", ErrTampered)
+	}
+	return nil
+}
+
+// PublicKeys returns the keys signatures are verified with, by ID.
+func (s *Signer) PublicKeys() map[string]ed25519.PublicKey {
+	keys := make(map[string]ed25519.PublicKey)
+	if s == nil {
+		return keys
+	}
+	for _, key := range s.keys {
+		public, _ := key.PrivateKey.Public().(ed25519.PublicKey)
+		keys[key.ID] = public
+	}
+	return keys
+}
+
+// KeyIDs returns the IDs of the keys, the signing key first.
+func (s *Signer) KeyIDs() []string {
+	if s == nil {
+		return nil
+	}
+	ids := make([]string, len(s.keys))
+	for i, key := range s.keys {
+		ids[i] = key.ID
+	}
+	return ids
+}
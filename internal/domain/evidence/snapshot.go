@@ -0,0 +1,34 @@
+package evidence
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CheckoutSnapshot records what the checkout page of an invoice showed the customer: amounts, address, rate and
+// expiry. A snapshot is kept whenever the content changes, so the snapshots of an invoice are what the customer
+// could have seen and when.
+type CheckoutSnapshot struct {
+	ID         string
+	InvoiceID  string
+	MerchantID string
+	Content    []byte // JSON
+	SHA256     string // Hex-encoded hash of the content
+	CapturedAt time.Time
+}
+
+// NewCheckoutSnapshot creates a snapshot of checkout page content captured at the given time.
+func NewCheckoutSnapshot(invoiceID, merchantID string, content []byte, capturedAt time.Time) *CheckoutSnapshot {
+	sum := sha256.Sum256(content)
+	return &CheckoutSnapshot{
+		ID:         uuid.New().String(),
+		InvoiceID:  invoiceID,
+		MerchantID: merchantID,
+		Content:    content,
+		SHA256:     hex.EncodeToString(sum[:]),
+		CapturedAt: capturedAt.UTC(),
+	}
+}
@@ -0,0 +1,44 @@
+package experiment
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Assignment records the variant a checkout session saw of an invoice's checkout page, and whether the
+// invoice was paid.
+type Assignment struct {
+	ExperimentID string
+	InvoiceID    string
+	SessionID    string
+	Variant      string
+	AssignedAt   time.Time
+	ConvertedAt  *time.Time
+	Amount       decimal.Decimal // Invoice total once converted, in the invoice currency
+	Currency     string
+}
+
+// VariantResult summarizes how a variant performed.
+type VariantResult struct {
+	Variant     string
+	Sessions    int // Distinct checkout sessions assigned the variant
+	Checkouts   int // Invoices viewed with the variant
+	Conversions int // Of those invoices, the paid ones
+	// Volume of the paid invoices by invoice currency
+	Volume map[string]decimal.Decimal
+}
+
+// ConversionRate returns the share of checkouts that were paid, from 0 to 1.
+func (r VariantResult) ConversionRate() float64 {
+	if r.Checkouts == 0 {
+		return 0
+	}
+	return float64(r.Conversions) / float64(r.Checkouts)
+}
+
+// Results is how each variant of an experiment performed.
+type Results struct {
+	Experiment *Experiment
+	Variants   []VariantResult
+}
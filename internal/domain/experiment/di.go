@@ -0,0 +1,18 @@
+package experiment
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the experiment service layer dependencies.
+var Module = fx.Module("experiment-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+	fx.Invoke(
+		RegisterPaidInvoiceHandler,
+	),
+)
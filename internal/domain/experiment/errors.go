@@ -0,0 +1,18 @@
+package experiment
+
+import "errors"
+
+// Domain errors for experiment operations
+var (
+	ErrExperimentNotFound = errors.New("experiment not found")
+	ErrExperimentRunning  = errors.New("the merchant already runs a checkout experiment")
+	ErrInvalidTransition  = errors.New("experiment cannot change to that status")
+	ErrInvalidRequest     = errors.New("invalid experiment request")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeExperimentNotFound = "EXPERIMENT_NOT_FOUND"
+	ErrCodeExperimentRunning  = "EXPERIMENT_RUNNING"
+	ErrCodeInvalidTransition  = "INVALID_EXPERIMENT_TRANSITION"
+)
@@ -0,0 +1,267 @@
+// Package experiment provides A/B experiments on the hosted checkout page: merchants define variants of its
+// copy, layout and currency ordering, checkout sessions are assigned a variant, and paid invoices count as
+// conversions of the variant their customer saw.
+package experiment
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Limits on experiment definitions.
+const (
+	MinVariants  = 2
+	MaxVariants  = 5
+	MaxWeight    = 100
+	copyKeyScope = "checkout."
+)
+
+// keyPattern restricts variant keys to what reads well in reports and analytics.
+var keyPattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+// Status is the lifecycle status of an experiment.
+type Status string
+
+// Experiment statuses. Drafts may be started once; a stopped experiment stays stopped.
+const (
+	StatusDraft   Status = "draft"
+	StatusRunning Status = "running"
+	StatusStopped Status = "stopped"
+)
+
+// String returns the string representation of the status.
+func (s Status) String() string {
+	return string(s)
+}
+
+// Layout is an arrangement of the checkout page.
+type Layout string
+
+// Checkout layouts.
+const (
+	// LayoutStandard shows the invoice details before the payment panel.
+	LayoutStandard Layout = "standard"
+	// LayoutPaymentFirst shows the payment panel before the invoice details.
+	LayoutPaymentFirst Layout = "payment_first"
+)
+
+// String returns the string representation of the layout.
+func (l Layout) String() string {
+	return string(l)
+}
+
+// IsValid reports whether the layout is known to the checkout page.
+func (l Layout) IsValid() bool {
+	return l == LayoutStandard || l == LayoutPaymentFirst
+}
+
+// Variant is one version of the checkout page in an experiment.
+type Variant struct {
+	Key    string
+	Weight int // Relative share of sessions assigned the variant
+	// Copy replaces checkout page messages by their ID, e.g. "checkout.pay_with"
+	Copy   map[string]string
+	Layout Layout
+	// CurrencyOrder lists currency symbols to offer first, in order; other currencies follow as usual
+	CurrencyOrder []string
+}
+
+// Experiment is a merchant's A/B test of checkout page variants.
+type Experiment struct {
+	id         string
+	merchantID string
+	name       string
+	status     Status
+	variants   []Variant
+	startedAt  *time.Time
+	stoppedAt  *time.Time
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+// NewExperiment creates a new draft experiment.
+func NewExperiment(id, merchantID, name string, variants []Variant) (*Experiment, error) {
+	now := shared.Now().UTC()
+	return RestoreExperiment(id, merchantID, name, StatusDraft, variants, nil, nil, now, now)
+}
+
+// RestoreExperiment recreates an experiment from storage.
+func RestoreExperiment(
+	id, merchantID, name string,
+	status Status,
+	variants []Variant,
+	startedAt, stoppedAt *time.Time,
+	createdAt, updatedAt time.Time,
+) (*Experiment, error) {
+	if id == "" {
+		return nil, errors.New("experiment ID is required")
+	}
+	if merchantID == "" {
+		return nil, errors.New("merchant ID is required")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return nil, errors.New("experiment name must be 1-100 characters")
+	}
+	switch status {
+	case StatusDraft, StatusRunning, StatusStopped:
+	default:
+		return nil, fmt.Errorf("unknown experiment status %q", status)
+	}
+	normalized, err := normalizeVariants(variants)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Experiment{
+		id:         id,
+		merchantID: merchantID,
+		name:       name,
+		status:     status,
+		variants:   normalized,
+		startedAt:  startedAt,
+		stoppedAt:  stoppedAt,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
+	}, nil
+}
+
+// normalizeVariants validates variants, defaulting their layout and upper-casing their currency symbols.
+func normalizeVariants(variants []Variant) ([]Variant, error) {
+	if len(variants) < MinVariants || len(variants) > MaxVariants {
+		return nil, fmt.Errorf("an experiment needs %d-%d variants", MinVariants, MaxVariants)
+	}
+
+	normalized := make([]Variant, len(variants))
+	keys := make(map[string]bool, len(variants))
+	for i, variant := range variants {
+		if !keyPattern.MatchString(variant.Key) {
+			return nil, fmt.Errorf("variant key %q must be 1-32 lowercase letters, digits, dashes or underscores",
+				variant.Key)
+		}
+		if keys[variant.Key] {
+			return nil, fmt.Errorf("variant key %q is used twice", variant.Key)
+		}
+		keys[variant.Key] = true
+
+		if variant.Weight < 1 || variant.Weight > MaxWeight {
+			return nil, fmt.Errorf("variant %q weight must be 1-%d", variant.Key, MaxWeight)
+		}
+		for id := range variant.Copy {
+			if !strings.HasPrefix(id, copyKeyScope) {
+				return nil, fmt.Errorf("variant %q can only replace %s* messages, not %q", variant.Key, copyKeyScope, id)
+			}
+		}
+		if variant.Layout == "" {
+			variant.Layout = LayoutStandard
+		}
+		if !variant.Layout.IsValid() {
+			return nil, fmt.Errorf("variant %q has unknown layout %q", variant.Key, variant.Layout)
+		}
+
+		order := make([]string, len(variant.CurrencyOrder))
+		for j, symbol := range variant.CurrencyOrder {
+			order[j] = strings.ToUpper(strings.TrimSpace(symbol))
+			if order[j] == "" {
+				return nil, fmt.Errorf("variant %q currency order has an empty symbol", variant.Key)
+			}
+		}
+		variant.CurrencyOrder = order
+		normalized[i] = variant
+	}
+	return normalized, nil
+}
+
+// ID returns the experiment ID.
+func (e *Experiment) ID() string {
+	return e.id
+}
+
+// MerchantID returns the merchant that runs the experiment.
+func (e *Experiment) MerchantID() string {
+	return e.merchantID
+}
+
+// Name returns the merchant's name for the experiment.
+func (e *Experiment) Name() string {
+	return e.name
+}
+
+// Status returns the experiment status.
+func (e *Experiment) Status() Status {
+	return e.status
+}
+
+// Variants returns the variants of the checkout page being compared.
+func (e *Experiment) Variants() []Variant {
+	return e.variants
+}
+
+// StartedAt returns when the experiment started assigning variants, if it has.
+func (e *Experiment) StartedAt() *time.Time {
+	return e.startedAt
+}
+
+// StoppedAt returns when the experiment stopped assigning variants, if it has.
+func (e *Experiment) StoppedAt() *time.Time {
+	return e.stoppedAt
+}
+
+// CreatedAt returns when the experiment was defined.
+func (e *Experiment) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// UpdatedAt returns when the experiment was last changed.
+func (e *Experiment) UpdatedAt() time.Time {
+	return e.updatedAt
+}
+
+// Start begins assigning variants to checkout sessions.
+func (e *Experiment) Start() error {
+	if e.status != StatusDraft {
+		return fmt.Errorf("%w: cannot start a %s experiment", ErrInvalidTransition, e.status)
+	}
+	now := shared.Now().UTC()
+	e.status = StatusRunning
+	e.startedAt = &now
+	e.updatedAt = now
+	return nil
+}
+
+// Stop ends the experiment. Checkouts assigned a variant keep counting conversions.
+func (e *Experiment) Stop() error {
+	if e.status != StatusRunning {
+		return fmt.Errorf("%w: cannot stop a %s experiment", ErrInvalidTransition, e.status)
+	}
+	now := shared.Now().UTC()
+	e.status = StatusStopped
+	e.stoppedAt = &now
+	e.updatedAt = now
+	return nil
+}
+
+// Assign returns the variant for a checkout session. Assignment is deterministic, so a session sees the same
+// variant on every visit and instance, and sessions are spread over the variants in proportion to their weights.
+func (e *Experiment) Assign(sessionID string) Variant {
+	total := 0
+	for _, variant := range e.variants {
+		total += variant.Weight
+	}
+
+	sum := sha256.Sum256([]byte(e.id + ":" + sessionID))
+	point := int(binary.BigEndian.Uint64(sum[:8]) % uint64(total))
+	for _, variant := range e.variants {
+		if point < variant.Weight {
+			return variant
+		}
+		point -= variant.Weight
+	}
+	return e.variants[len(e.variants)-1]
+}
@@ -0,0 +1,96 @@
+package experiment
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testVariants() []Variant {
+	return []Variant{
+		{Key: "control", Weight: 1},
+		{
+			Key:           "stablecoins-first",
+			Weight:        3,
+			Copy:          map[string]string{"checkout.pay_with": "Pay with a stablecoin"},
+			Layout:        LayoutPaymentFirst,
+			CurrencyOrder: []string{" usdt", "USDC"},
+		},
+	}
+}
+
+func TestNewExperiment_Validation(t *testing.T) {
+	experiment, err := NewExperiment("exp-1", "merchant-1", " Stablecoins first ", testVariants())
+	require.NoError(t, err)
+	assert.Equal(t, "Stablecoins first", experiment.Name())
+	assert.Equal(t, StatusDraft, experiment.Status())
+	assert.Equal(t, LayoutStandard, experiment.Variants()[0].Layout, "layout defaults to standard")
+	assert.Equal(t, []string{"USDT", "USDC"}, experiment.Variants()[1].CurrencyOrder)
+
+	invalid := map[string]func(variants []Variant) []Variant{
+		"single variant": func(variants []Variant) []Variant { return variants[:1] },
+		"duplicate key": func(variants []Variant) []Variant {
+			variants[1].Key = "control"
+			return variants
+		},
+		"uppercase key": func(variants []Variant) []Variant {
+			variants[0].Key = "Control"
+			return variants
+		},
+		"zero weight": func(variants []Variant) []Variant {
+			variants[0].Weight = 0
+			return variants
+		},
+		"copy outside checkout": func(variants []Variant) []Variant {
+			variants[1].Copy = map[string]string{"errors.INVOICE_NOT_FOUND": "Gone"}
+			return variants
+		},
+		"unknown layout": func(variants []Variant) []Variant {
+			variants[1].Layout = "sidebar"
+			return variants
+		},
+		"empty currency": func(variants []Variant) []Variant {
+			variants[1].CurrencyOrder = []string{" "}
+			return variants
+		},
+	}
+	for name, mutate := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewExperiment("exp-1", "merchant-1", "Test", mutate(testVariants()))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestExperiment_Transitions(t *testing.T) {
+	experiment, err := NewExperiment("exp-1", "merchant-1", "Test", testVariants())
+	require.NoError(t, err)
+
+	require.ErrorIs(t, experiment.Stop(), ErrInvalidTransition)
+	require.NoError(t, experiment.Start())
+	assert.Equal(t, StatusRunning, experiment.Status())
+	assert.NotNil(t, experiment.StartedAt())
+
+	require.ErrorIs(t, experiment.Start(), ErrInvalidTransition)
+	require.NoError(t, experiment.Stop())
+	assert.Equal(t, StatusStopped, experiment.Status())
+	assert.NotNil(t, experiment.StoppedAt())
+	require.ErrorIs(t, experiment.Start(), ErrInvalidTransition, "a stopped experiment cannot be restarted")
+}
+
+func TestExperiment_Assign(t *testing.T) {
+	experiment, err := NewExperiment("exp-1", "merchant-1", "Test", testVariants())
+	require.NoError(t, err)
+
+	assert.Equal(t, experiment.Assign("session-1").Key, experiment.Assign("session-1").Key,
+		"a session keeps its variant")
+
+	counts := map[string]int{}
+	for i := range 4000 {
+		counts[experiment.Assign(fmt.Sprintf("session-%d", i)).Key]++
+	}
+	assert.InDelta(t, 1000, counts["control"], 150)
+	assert.InDelta(t, 3000, counts["stablecoins-first"], 150)
+}
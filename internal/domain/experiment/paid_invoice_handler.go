@@ -0,0 +1,56 @@
+package experiment
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// PaidInvoiceHandler counts invoices as conversions of their checkout variant as they become paid.
+type PaidInvoiceHandler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewPaidInvoiceHandler creates a new paid invoice handler.
+func NewPaidInvoiceHandler(service Service, logger *zap.Logger) *PaidInvoiceHandler {
+	return &PaidInvoiceHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterPaidInvoiceHandler subscribes a paid invoice handler to invoice events.
+func RegisterPaidInvoiceHandler(registry shared.EventHandlerRegistry, service Service, logger *zap.Logger) {
+	registry.RegisterHandler(NewPaidInvoiceHandler(service, logger))
+}
+
+// EventTypes returns the invoice events that may report an invoice as paid.
+func (h *PaidInvoiceHandler) EventTypes() []string {
+	return []string{
+		shared.EventTypeInvoiceStatusChanged,
+		shared.EventTypeInvoicePaid,
+	}
+}
+
+// HandleEvent records the conversion of the invoice an event reports as paid. Events for invoices in other
+// states are ignored.
+func (h *PaidInvoiceHandler) HandleEvent(ctx context.Context, event *shared.BaseDomainEvent) error {
+	var data shared.InvoiceEvent
+	if err := shared.DecodeEventData(event, &data); err != nil {
+		return fmt.Errorf("failed to decode invoice event: %w", err)
+	}
+	if data.Status != invoice.StatusPaid.String() {
+		return nil
+	}
+
+	amount, err := decimal.NewFromString(data.TotalAmount)
+	if err != nil {
+		return fmt.Errorf("invalid invoice total %q: %w", data.TotalAmount, err)
+	}
+	return h.service.RecordConversion(ctx, event.AggregateID, amount, data.Currency)
+}
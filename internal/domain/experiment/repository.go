@@ -0,0 +1,39 @@
+package experiment
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Repository defines the interface for experiment persistence.
+type Repository interface {
+	// Save persists a new experiment.
+	Save(ctx context.Context, experiment *Experiment) error
+
+	// FindByID retrieves an experiment by its ID.
+	FindByID(ctx context.Context, id string) (*Experiment, error)
+
+	// FindRunning retrieves the merchant's running experiment, returning ErrExperimentNotFound if there is none.
+	FindRunning(ctx context.Context, merchantID string) (*Experiment, error)
+
+	// ListByMerchant retrieves a merchant's experiments, newest first.
+	ListByMerchant(ctx context.Context, merchantID string) ([]*Experiment, error)
+
+	// Update updates an existing experiment's status.
+	Update(ctx context.Context, experiment *Experiment) error
+}
+
+// AssignmentRepository defines the interface for persisting variant assignments.
+type AssignmentRepository interface {
+	// Assign records an assignment unless the experiment already assigned the invoice's checkout, returning
+	// the assignment in effect.
+	Assign(ctx context.Context, assignment *Assignment) (*Assignment, error)
+
+	// MarkConverted records that the invoice of unconverted assignments was paid.
+	MarkConverted(ctx context.Context, invoiceID string, amount decimal.Decimal, currency string, at time.Time) error
+
+	// Results summarizes the assignments of an experiment by variant.
+	Results(ctx context.Context, experimentID string) ([]VariantResult, error)
+}
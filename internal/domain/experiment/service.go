@@ -0,0 +1,275 @@
+package experiment
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// Service defines the interface for running checkout experiments.
+type Service interface {
+	// CreateExperiment defines a draft experiment for a merchant.
+	CreateExperiment(ctx context.Context, req *CreateExperimentRequest) (*Experiment, error)
+
+	// ListExperiments lists a merchant's experiments.
+	ListExperiments(ctx context.Context, merchantID string) ([]*Experiment, error)
+
+	// GetExperiment retrieves a merchant's experiment.
+	GetExperiment(ctx context.Context, merchantID, experimentID string) (*Experiment, error)
+
+	// StartExperiment starts a merchant's draft experiment. A merchant runs one experiment at a time.
+	StartExperiment(ctx context.Context, merchantID, experimentID string) (*Experiment, error)
+
+	// StopExperiment stops a merchant's running experiment.
+	StopExperiment(ctx context.Context, merchantID, experimentID string) (*Experiment, error)
+
+	// GetResults summarizes how each variant of a merchant's experiment performed.
+	GetResults(ctx context.Context, merchantID, experimentID string) (*Results, error)
+
+	// RunningExperiment returns the merchant's running experiment, or nil if the merchant runs none.
+	RunningExperiment(ctx context.Context, merchantID string) (*Experiment, error)
+
+	// AssignCheckout returns the variant of a running experiment to show a checkout session on an invoice's
+	// checkout page.
+	AssignCheckout(ctx context.Context, experiment *Experiment, invoiceID, sessionID string) (Variant, error)
+
+	// RecordConversion counts a paid invoice as a conversion of the variant its checkout was assigned.
+	RecordConversion(ctx context.Context, invoiceID string, amount decimal.Decimal, currency string) error
+}
+
+// CreateExperimentRequest represents the request to define an experiment.
+type CreateExperimentRequest struct {
+	MerchantID string    `validate:"required"`
+	Name       string    `validate:"required"`
+	Variants   []Variant `validate:"required"`
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository  Repository
+	assignments AssignmentRepository
+	logger      *zap.Logger
+}
+
+// NewService creates a new experiment service.
+func NewService(repository Repository, assignments AssignmentRepository, logger *zap.Logger) Service {
+	return &ServiceImpl{
+		repository:  repository,
+		assignments: assignments,
+		logger:      logger,
+	}
+}
+
+// CreateExperiment defines a draft experiment for a merchant.
+func (s *ServiceImpl) CreateExperiment(ctx context.Context, req *CreateExperimentRequest) (*Experiment, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: create experiment request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate experiment ID: %w", err)
+	}
+
+	experiment, err := NewExperiment(id, req.MerchantID, req.Name, req.Variants)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	if err := s.repository.Save(ctx, experiment); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Checkout experiment created",
+		zap.String("experiment_id", experiment.ID()),
+		zap.String("merchant_id", experiment.MerchantID()),
+		zap.Int("variants", len(experiment.Variants())))
+
+	return experiment, nil
+}
+
+// ListExperiments lists a merchant's experiments.
+func (s *ServiceImpl) ListExperiments(ctx context.Context, merchantID string) ([]*Experiment, error) {
+	if merchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+
+	return s.repository.ListByMerchant(ctx, merchantID)
+}
+
+// GetExperiment retrieves a merchant's experiment.
+func (s *ServiceImpl) GetExperiment(ctx context.Context, merchantID, experimentID string) (*Experiment, error) {
+	if merchantID == "" || experimentID == "" {
+		return nil, fmt.Errorf("%w: merchant ID and experiment ID are required", ErrInvalidRequest)
+	}
+
+	experiment, err := s.repository.FindByID(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+	if experiment.MerchantID() != merchantID {
+		return nil, ErrExperimentNotFound
+	}
+
+	return experiment, nil
+}
+
+// StartExperiment starts a merchant's draft experiment. A merchant runs one experiment at a time, so that each
+// checkout session is in a single experiment and conversions are not counted twice.
+func (s *ServiceImpl) StartExperiment(ctx context.Context, merchantID, experimentID string) (*Experiment, error) {
+	experiment, err := s.GetExperiment(ctx, merchantID, experimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	running, err := s.repository.FindRunning(ctx, merchantID)
+	switch {
+	case err == nil && running.ID() != experiment.ID():
+		return nil, ErrExperimentRunning
+	case err != nil && !errors.Is(err, ErrExperimentNotFound):
+		return nil, err
+	}
+
+	if err := experiment.Start(); err != nil {
+		return nil, err
+	}
+	if err := s.repository.Update(ctx, experiment); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Checkout experiment started",
+		zap.String("experiment_id", experiment.ID()),
+		zap.String("merchant_id", merchantID))
+
+	return experiment, nil
+}
+
+// StopExperiment stops a merchant's running experiment.
+func (s *ServiceImpl) StopExperiment(ctx context.Context, merchantID, experimentID string) (*Experiment, error) {
+	experiment, err := s.GetExperiment(ctx, merchantID, experimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := experiment.Stop(); err != nil {
+		return nil, err
+	}
+	if err := s.repository.Update(ctx, experiment); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Checkout experiment stopped",
+		zap.String("experiment_id", experiment.ID()),
+		zap.String("merchant_id", merchantID))
+
+	return experiment, nil
+}
+
+// GetResults summarizes how each variant of a merchant's experiment performed. Every variant is listed in the
+// order it was defined, including those no checkout was assigned yet.
+func (s *ServiceImpl) GetResults(ctx context.Context, merchantID, experimentID string) (*Results, error) {
+	experiment, err := s.GetExperiment(ctx, merchantID, experimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded, err := s.assignments.Results(ctx, experiment.ID())
+	if err != nil {
+		return nil, err
+	}
+	byVariant := make(map[string]VariantResult, len(recorded))
+	for _, result := range recorded {
+		byVariant[result.Variant] = result
+	}
+
+	results := &Results{Experiment: experiment, Variants: make([]VariantResult, len(experiment.Variants()))}
+	for i, variant := range experiment.Variants() {
+		result, ok := byVariant[variant.Key]
+		if !ok {
+			result = VariantResult{Variant: variant.Key}
+		}
+		if result.Volume == nil {
+			result.Volume = map[string]decimal.Decimal{}
+		}
+		results.Variants[i] = result
+	}
+	return results, nil
+}
+
+// RunningExperiment returns the merchant's running experiment, or nil if the merchant runs none.
+func (s *ServiceImpl) RunningExperiment(ctx context.Context, merchantID string) (*Experiment, error) {
+	if merchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+
+	experiment, err := s.repository.FindRunning(ctx, merchantID)
+	if errors.Is(err, ErrExperimentNotFound) {
+		return nil, nil
+	}
+	return experiment, err
+}
+
+// AssignCheckout returns the variant of a running experiment to show a checkout session on an invoice's checkout
+// page. An invoice keeps the variant it was first assigned, so that its conversion counts for the variant that
+// was shown.
+func (s *ServiceImpl) AssignCheckout(
+	ctx context.Context,
+	experiment *Experiment,
+	invoiceID, sessionID string,
+) (Variant, error) {
+	if experiment == nil || invoiceID == "" || sessionID == "" {
+		return Variant{}, fmt.Errorf("%w: experiment, invoice ID and session ID are required", ErrInvalidRequest)
+	}
+
+	variant := experiment.Assign(sessionID)
+	assignment, err := s.assignments.Assign(ctx, &Assignment{
+		ExperimentID: experiment.ID(),
+		InvoiceID:    invoiceID,
+		SessionID:    sessionID,
+		Variant:      variant.Key,
+		AssignedAt:   shared.Now().UTC(),
+	})
+	if err != nil {
+		return Variant{}, err
+	}
+
+	for _, assigned := range experiment.Variants() {
+		if assigned.Key == assignment.Variant {
+			return assigned, nil
+		}
+	}
+	return variant, nil
+}
+
+// RecordConversion counts a paid invoice as a conversion of the variant its checkout was assigned. Invoices no
+// experiment assigned are ignored.
+func (s *ServiceImpl) RecordConversion(
+	ctx context.Context,
+	invoiceID string,
+	amount decimal.Decimal,
+	currency string,
+) error {
+	if invoiceID == "" {
+		return fmt.Errorf("%w: invoice ID is required", ErrInvalidRequest)
+	}
+
+	return s.assignments.MarkConverted(ctx, invoiceID, amount, currency, shared.Now().UTC())
+}
+
+func generateID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,211 @@
+package experiment
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubRepository keeps experiments in memory.
+type stubRepository struct {
+	experiments map[string]*Experiment
+}
+
+func (r *stubRepository) Save(_ context.Context, experiment *Experiment) error {
+	r.experiments[experiment.ID()] = experiment
+	return nil
+}
+
+func (r *stubRepository) FindByID(_ context.Context, id string) (*Experiment, error) {
+	experiment, ok := r.experiments[id]
+	if !ok {
+		return nil, ErrExperimentNotFound
+	}
+	return experiment, nil
+}
+
+func (r *stubRepository) FindRunning(_ context.Context, merchantID string) (*Experiment, error) {
+	for _, experiment := range r.experiments {
+		if experiment.MerchantID() == merchantID && experiment.Status() == StatusRunning {
+			return experiment, nil
+		}
+	}
+	return nil, ErrExperimentNotFound
+}
+
+func (r *stubRepository) ListByMerchant(_ context.Context, merchantID string) ([]*Experiment, error) {
+	var experiments []*Experiment
+	for _, experiment := range r.experiments {
+		if experiment.MerchantID() == merchantID {
+			experiments = append(experiments, experiment)
+		}
+	}
+	return experiments, nil
+}
+
+func (r *stubRepository) Update(_ context.Context, experiment *Experiment) error {
+	r.experiments[experiment.ID()] = experiment
+	return nil
+}
+
+// stubAssignments keeps assignments in memory.
+type stubAssignments struct {
+	assignments []*Assignment
+}
+
+func (r *stubAssignments) Assign(_ context.Context, assignment *Assignment) (*Assignment, error) {
+	for _, existing := range r.assignments {
+		if existing.ExperimentID == assignment.ExperimentID && existing.InvoiceID == assignment.InvoiceID {
+			return existing, nil
+		}
+	}
+	r.assignments = append(r.assignments, assignment)
+	return assignment, nil
+}
+
+func (r *stubAssignments) MarkConverted(
+	_ context.Context,
+	invoiceID string,
+	amount decimal.Decimal,
+	currency string,
+	at time.Time,
+) error {
+	for _, assignment := range r.assignments {
+		if assignment.InvoiceID == invoiceID && assignment.ConvertedAt == nil {
+			assignment.ConvertedAt, assignment.Amount, assignment.Currency = &at, amount, currency
+		}
+	}
+	return nil
+}
+
+func (r *stubAssignments) Results(_ context.Context, experimentID string) ([]VariantResult, error) {
+	results := map[string]*VariantResult{}
+	sessions := map[string]map[string]bool{}
+	for _, assignment := range r.assignments {
+		if assignment.ExperimentID != experimentID {
+			continue
+		}
+		result, ok := results[assignment.Variant]
+		if !ok {
+			result = &VariantResult{Variant: assignment.Variant, Volume: map[string]decimal.Decimal{}}
+			results[assignment.Variant], sessions[assignment.Variant] = result, map[string]bool{}
+		}
+		sessions[assignment.Variant][assignment.SessionID] = true
+		result.Sessions = len(sessions[assignment.Variant])
+		result.Checkouts++
+		if assignment.ConvertedAt != nil {
+			result.Conversions++
+			result.Volume[assignment.Currency] = result.Volume[assignment.Currency].Add(assignment.Amount)
+		}
+	}
+
+	var list []VariantResult
+	for _, result := range results {
+		list = append(list, *result)
+	}
+	return list, nil
+}
+
+// fixedClock tells a fixed time.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+func newTestService() (Service, *stubAssignments) {
+	assignments := &stubAssignments{}
+	repository := &stubRepository{experiments: map[string]*Experiment{}}
+	return NewService(repository, assignments, zap.NewNop()), assignments
+}
+
+func TestService_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestService()
+
+	first, err := service.CreateExperiment(ctx, &CreateExperimentRequest{
+		MerchantID: "merchant-1", Name: "First", Variants: testVariants(),
+	})
+	require.NoError(t, err)
+	second, err := service.CreateExperiment(ctx, &CreateExperimentRequest{
+		MerchantID: "merchant-1", Name: "Second", Variants: testVariants(),
+	})
+	require.NoError(t, err)
+
+	_, err = service.CreateExperiment(ctx, &CreateExperimentRequest{
+		MerchantID: "merchant-1", Name: "Invalid", Variants: testVariants()[:1],
+	})
+	require.ErrorIs(t, err, ErrInvalidRequest)
+
+	_, err = service.GetExperiment(ctx, "merchant-2", first.ID())
+	require.ErrorIs(t, err, ErrExperimentNotFound, "experiments are scoped to their merchant")
+
+	_, err = service.StartExperiment(ctx, "merchant-1", first.ID())
+	require.NoError(t, err)
+	_, err = service.StartExperiment(ctx, "merchant-1", second.ID())
+	require.ErrorIs(t, err, ErrExperimentRunning)
+
+	stopped, err := service.StopExperiment(ctx, "merchant-1", first.ID())
+	require.NoError(t, err)
+	assert.Equal(t, StatusStopped, stopped.Status())
+	_, err = service.StartExperiment(ctx, "merchant-1", second.ID())
+	require.NoError(t, err)
+}
+
+func TestService_AssignAndConvert(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	t.Cleanup(shared.SetClock(fixedClock(now)))
+	service, assignments := newTestService()
+
+	running, err := service.RunningExperiment(ctx, "merchant-1")
+	require.NoError(t, err)
+	assert.Nil(t, running, "nothing is assigned without a running experiment")
+
+	experiment, err := service.CreateExperiment(ctx, &CreateExperimentRequest{
+		MerchantID: "merchant-1", Name: "Test", Variants: testVariants(),
+	})
+	require.NoError(t, err)
+	_, err = service.StartExperiment(ctx, "merchant-1", experiment.ID())
+	require.NoError(t, err)
+	running, err = service.RunningExperiment(ctx, "merchant-1")
+	require.NoError(t, err)
+	require.NotNil(t, running)
+
+	variant, err := service.AssignCheckout(ctx, running, "inv-1", "session-1")
+	require.NoError(t, err)
+	assert.Equal(t, experiment.Assign("session-1").Key, variant.Key)
+
+	// Another session on the same invoice sees the variant the invoice was first assigned
+	assignments.assignments[0].Variant = "control"
+	again, err := service.AssignCheckout(ctx, running, "inv-1", "session-2")
+	require.NoError(t, err)
+	assert.Equal(t, "control", again.Key)
+	require.Len(t, assignments.assignments, 1)
+
+	_, err = service.AssignCheckout(ctx, running, "inv-2", "session-1")
+	require.NoError(t, err)
+	assignments.assignments[1].Variant = "stablecoins-first"
+	require.NoError(t, service.RecordConversion(ctx, "inv-1", decimal.RequireFromString("25.00"), "USD"))
+	require.NoError(t, service.RecordConversion(ctx, "inv-unassigned", decimal.RequireFromString("5"), "USD"))
+
+	results, err := service.GetResults(ctx, "merchant-1", experiment.ID())
+	require.NoError(t, err)
+	require.Len(t, results.Variants, 2, "every variant is reported")
+	byKey := map[string]VariantResult{}
+	for _, result := range results.Variants {
+		byKey[result.Variant] = result
+	}
+	control := byKey["control"]
+	assert.Equal(t, 1, control.Checkouts)
+	assert.Equal(t, 1, control.Conversions)
+	assert.InDelta(t, 1.0, control.ConversionRate(), 0.0001)
+	assert.Equal(t, "25", control.Volume["USD"].String())
+	assert.Equal(t, 1, byKey["stablecoins-first"].Checkouts)
+	assert.Zero(t, byKey["stablecoins-first"].Conversions)
+	assert.Equal(t, now, *assignments.assignments[0].ConvertedAt)
+}
@@ -0,0 +1,15 @@
+package fee
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the fee service layer dependencies.
+var Module = fx.Module("fee-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+)
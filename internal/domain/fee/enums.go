@@ -0,0 +1,50 @@
+// Package fee estimates the network fees of the transfers the platform sends, decides when they
+// are cheap enough to broadcast and records what they actually cost.
+package fee
+
+// Speed represents how quickly a transfer should confirm, and so how much fee it is worth paying.
+type Speed string
+
+const (
+	// SpeedSlow - Confirmation within hours at the lowest fee
+	SpeedSlow Speed = "slow"
+	// SpeedStandard - Confirmation within about half an hour
+	SpeedStandard Speed = "standard"
+	// SpeedFast - Confirmation in the next few blocks
+	SpeedFast Speed = "fast"
+)
+
+// Speeds lists the speeds in increasing order of fee.
+var Speeds = []Speed{SpeedSlow, SpeedStandard, SpeedFast}
+
+// IsValid returns true if the speed is valid.
+func (s Speed) IsValid() bool {
+	switch s {
+	case SpeedSlow, SpeedStandard, SpeedFast:
+		return true
+	default:
+		return false
+	}
+}
+
+// Purpose represents why the platform sent a transfer whose fee was spent.
+type Purpose string
+
+const (
+	// PurposeSweep - Deposit address swept into the hot wallet
+	PurposeSweep Purpose = "sweep"
+	// PurposePayout - Settled funds paid out to a merchant
+	PurposePayout Purpose = "payout"
+	// PurposeColdTransfer - Hot wallet funds moved to cold storage
+	PurposeColdTransfer Purpose = "cold_transfer"
+)
+
+// IsValid returns true if the purpose is valid.
+func (p Purpose) IsValid() bool {
+	switch p {
+	case PurposeSweep, PurposePayout, PurposeColdTransfer:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,15 @@
+package fee
+
+import "errors"
+
+// Domain errors for fee operations
+var (
+	ErrInvalidRequest     = errors.New("invalid fee request")
+	ErrUnsupportedNetwork = errors.New("no fee oracle is configured for this network")
+	ErrOracleFailure      = errors.New("fee oracle request failed")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeEstimateUnavailable = "FEE_ESTIMATE_UNAVAILABLE"
+)
@@ -0,0 +1,47 @@
+package fee
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Oracle estimates the fee of a transfer on one network from the network's current conditions.
+type Oracle interface {
+	// Network returns the network the oracle estimates fees for.
+	Network() shared.BlockchainNetwork
+
+	// Estimate returns the fee of a typical transfer of the currency confirming at the speed.
+	Estimate(ctx context.Context, currency string, speed Speed) (*Estimate, error)
+}
+
+// Estimate is the expected fee of one transfer.
+type Estimate struct {
+	Network shared.BlockchainNetwork
+	// Currency is the currency transferred; FeeCurrency is the network's native currency the fee is paid in.
+	Currency    string
+	FeeCurrency string
+	Speed       Speed
+	// Rate is the fee rate the estimate is based on, in RateUnit: sun per energy on Tron, gwei per
+	// gas on Ethereum and satoshis per virtual byte on Bitcoin.
+	Rate     decimal.Decimal
+	RateUnit string
+	// Fee is the total fee of the transfer in FeeCurrency.
+	Fee         decimal.Decimal
+	EstimatedAt time.Time
+}
+
+// nativeCurrencies maps each network to the currency its fees are paid in.
+var nativeCurrencies = map[shared.BlockchainNetwork]string{
+	shared.NetworkTron:     "TRX",
+	shared.NetworkEthereum: "ETH",
+	shared.NetworkBitcoin:  "BTC",
+}
+
+// NativeCurrency returns the currency fees are paid in on a network, or an empty string for an
+// unknown network. Testnets pay fees in the test coin of their mainnet.
+func NativeCurrency(network shared.BlockchainNetwork) string {
+	return nativeCurrencies[network.Mainnet()]
+}
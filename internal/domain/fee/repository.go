@@ -0,0 +1,16 @@
+package fee
+
+import (
+	"context"
+	"time"
+)
+
+// SpendRepository defines the interface for fee spend persistence.
+type SpendRepository interface {
+	// Save records a fee spend. A transfer already recorded for the same purpose and reference is
+	// left unchanged.
+	Save(ctx context.Context, spend *Spend) error
+
+	// ListBetween lists the fee spends recorded in [from, to).
+	ListBetween(ctx context.Context, from, to time.Time) ([]*Spend, error)
+}
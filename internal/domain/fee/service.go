@@ -0,0 +1,317 @@
+package fee
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// estimateTTL is how long an estimate is reused before the oracle is asked again, so a round
+	// deciding on many transfers makes one oracle request per network.
+	estimateTTL = 30 * time.Second
+	// defaultReportPeriod is the period reported when a report request has no start.
+	defaultReportPeriod = 30 * 24 * time.Hour
+	// maxReportPeriod bounds the period of one report.
+	maxReportPeriod = 366 * 24 * time.Hour
+)
+
+// Strategy configures when transfers on one network are broadcast.
+type Strategy struct {
+	// Speed is the confirmation speed estimates are made for.
+	Speed Speed
+	// MaxFee defers broadcasts while a transfer would cost more, in the network's native currency.
+	// Zero broadcasts at any fee.
+	MaxFee decimal.Decimal
+	// MaxDelay is how long a broadcast may be deferred; older transfers are sent at any fee. Zero
+	// defers without limit.
+	MaxDelay time.Duration
+}
+
+// Policy configures fee estimation per network. Networks without a strategy use DefaultStrategy.
+type Policy struct {
+	Strategies map[shared.BlockchainNetwork]Strategy
+}
+
+// DefaultPolicy broadcasts every transfer right away, estimating at standard speed.
+func DefaultPolicy() Policy {
+	return Policy{Strategies: map[shared.BlockchainNetwork]Strategy{}}
+}
+
+// DefaultStrategy estimates at standard speed without deferring broadcasts.
+func DefaultStrategy() Strategy {
+	return Strategy{Speed: SpeedStandard}
+}
+
+// Service defines the interface for fee operations.
+type Service interface {
+	// Estimate returns the current fee of a transfer. Without a speed, the network strategy's speed is used.
+	Estimate(ctx context.Context, req *EstimateRequest) (*Estimate, error)
+
+	// Decide reports whether a transfer should be broadcast now or wait for lower fees.
+	Decide(ctx context.Context, req *BroadcastRequest) *Decision
+
+	// RecordSpend records the fee a confirmed transfer paid.
+	RecordSpend(ctx context.Context, req *SpendRequest) error
+
+	// Report totals the fees paid over a period per network and purpose.
+	Report(ctx context.Context, req *ReportRequest) (*Report, error)
+}
+
+// EstimateRequest represents the request for a fee estimate.
+type EstimateRequest struct {
+	Network  shared.BlockchainNetwork
+	Currency string
+	Speed    Speed
+}
+
+// BroadcastRequest asks whether a transfer should be broadcast now.
+type BroadcastRequest struct {
+	Network  shared.BlockchainNetwork
+	Currency string
+	// WaitingSince is when the transfer became ready to send; it bounds how long it is deferred.
+	WaitingSince time.Time
+}
+
+// Decision is whether to broadcast a transfer now.
+type Decision struct {
+	Broadcast bool
+	// Estimate is the fee the decision was based on, or nil when none was needed or available.
+	Estimate *Estimate
+	// Reason describes why a broadcast was deferred.
+	Reason string
+}
+
+// SpendRequest represents the fee paid by a confirmed transfer.
+type SpendRequest struct {
+	Purpose   Purpose
+	Reference string
+	Network   shared.BlockchainNetwork
+	TxHash    string
+	Amount    decimal.Decimal
+}
+
+// ReportRequest represents the request for a fee spend report over [From, To).
+type ReportRequest struct {
+	From time.Time
+	To   time.Time
+}
+
+// Report is the fee spend over a period.
+type Report struct {
+	From   time.Time
+	To     time.Time
+	Totals []*SpendTotal
+}
+
+// SpendTotal is the fee paid by the transfers of one purpose on one network.
+type SpendTotal struct {
+	Network     shared.BlockchainNetwork
+	Purpose     Purpose
+	FeeCurrency string
+	Transfers   int
+	Amount      decimal.Decimal
+}
+
+// cachedEstimate is an estimate kept for reuse until it expires.
+type cachedEstimate struct {
+	estimate  *Estimate
+	expiresAt time.Time
+}
+
+// estimateKey identifies the transfers an estimate applies to.
+type estimateKey struct {
+	network  shared.BlockchainNetwork
+	currency string
+	speed    Speed
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	oracles map[shared.BlockchainNetwork]Oracle
+	spends  SpendRepository
+	policy  Policy
+	logger  *zap.Logger
+
+	mu    sync.Mutex
+	cache map[estimateKey]cachedEstimate
+}
+
+// NewService creates a new fee service. Networks without an oracle have no estimates, and their
+// transfers are always broadcast right away.
+func NewService(oracles []Oracle, spends SpendRepository, policy Policy, logger *zap.Logger) Service {
+	byNetwork := make(map[shared.BlockchainNetwork]Oracle, len(oracles))
+	for _, oracle := range oracles {
+		byNetwork[oracle.Network()] = oracle
+	}
+	if policy.Strategies == nil {
+		policy.Strategies = map[shared.BlockchainNetwork]Strategy{}
+	}
+
+	return &ServiceImpl{
+		oracles: byNetwork,
+		spends:  spends,
+		policy:  policy,
+		logger:  logger,
+		cache:   make(map[estimateKey]cachedEstimate),
+	}
+}
+
+// Estimate returns the current fee of a transfer, reusing a recent estimate when there is one.
+func (s *ServiceImpl) Estimate(ctx context.Context, req *EstimateRequest) (*Estimate, error) {
+	if req == nil || req.Currency == "" {
+		return nil, fmt.Errorf("%w: a currency is required", ErrInvalidRequest)
+	}
+	speed := req.Speed
+	if speed == "" {
+		speed = s.strategy(req.Network).Speed
+	}
+	if !speed.IsValid() {
+		return nil, fmt.Errorf("%w: invalid speed %s", ErrInvalidRequest, speed)
+	}
+
+	oracle, ok := s.oracles[req.Network]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedNetwork, req.Network)
+	}
+
+	key := estimateKey{network: req.Network, currency: req.Currency, speed: speed}
+	now := shared.Now()
+	s.mu.Lock()
+	cached, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.estimate, nil
+	}
+
+	estimate, err := oracle.Estimate(ctx, req.Currency, speed)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cache[key] = cachedEstimate{estimate: estimate, expiresAt: now.Add(estimateTTL)}
+	s.mu.Unlock()
+	return estimate, nil
+}
+
+// Decide defers a transfer while its estimated fee exceeds the network's limit, until it has
+// waited longer than the strategy allows. Transfers are never held back for lack of an estimate.
+func (s *ServiceImpl) Decide(ctx context.Context, req *BroadcastRequest) *Decision {
+	strategy := s.strategy(req.Network)
+	if !strategy.MaxFee.IsPositive() {
+		return &Decision{Broadcast: true}
+	}
+	if strategy.MaxDelay > 0 && !req.WaitingSince.IsZero() && shared.Now().Sub(req.WaitingSince) >= strategy.MaxDelay {
+		return &Decision{Broadcast: true}
+	}
+
+	estimate, err := s.Estimate(ctx, &EstimateRequest{Network: req.Network, Currency: req.Currency})
+	if err != nil {
+		s.logger.Warn("Broadcasting without a fee estimate",
+			zap.String("network", req.Network.String()),
+			zap.String("currency", req.Currency),
+			zap.Error(err))
+		return &Decision{Broadcast: true}
+	}
+	if estimate.Fee.GreaterThan(strategy.MaxFee) {
+		return &Decision{
+			Estimate: estimate,
+			Reason: fmt.Sprintf("network fee %s %s exceeds the %s %s limit",
+				estimate.Fee.String(), estimate.FeeCurrency, strategy.MaxFee.String(), estimate.FeeCurrency),
+		}
+	}
+	return &Decision{Broadcast: true, Estimate: estimate}
+}
+
+// RecordSpend records the fee a confirmed transfer paid.
+func (s *ServiceImpl) RecordSpend(ctx context.Context, req *SpendRequest) error {
+	if req == nil {
+		return fmt.Errorf("%w: spend request cannot be nil", ErrInvalidRequest)
+	}
+
+	spend, err := NewSpend(uuid.NewString(), req.Purpose, req.Reference, req.Network, req.TxHash, req.Amount)
+	if err != nil {
+		return err
+	}
+	return s.spends.Save(ctx, spend)
+}
+
+// Report totals the fees paid over a period, ordered by network and purpose. A request without a
+// period reports the last 30 days.
+func (s *ServiceImpl) Report(ctx context.Context, req *ReportRequest) (*Report, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: report request cannot be nil", ErrInvalidRequest)
+	}
+	to := req.To
+	if to.IsZero() {
+		to = shared.Now().UTC()
+	}
+	from := req.From
+	if from.IsZero() {
+		from = to.Add(-defaultReportPeriod)
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: report start must be before its end", ErrInvalidRequest)
+	}
+	if to.Sub(from) > maxReportPeriod {
+		return nil, fmt.Errorf("%w: report period cannot exceed %d days", ErrInvalidRequest,
+			int(maxReportPeriod.Hours()/24))
+	}
+
+	spends, err := s.spends.ListBetween(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	type totalKey struct {
+		network     shared.BlockchainNetwork
+		purpose     Purpose
+		feeCurrency string
+	}
+	totals := make(map[totalKey]*SpendTotal)
+	report := &Report{From: from, To: to, Totals: []*SpendTotal{}}
+	for _, spend := range spends {
+		key := totalKey{spend.Network(), spend.Purpose(), spend.FeeCurrency()}
+		total, ok := totals[key]
+		if !ok {
+			total = &SpendTotal{
+				Network:     spend.Network(),
+				Purpose:     spend.Purpose(),
+				FeeCurrency: spend.FeeCurrency(),
+				Amount:      decimal.Zero,
+			}
+			totals[key] = total
+			report.Totals = append(report.Totals, total)
+		}
+		total.Transfers++
+		total.Amount = total.Amount.Add(spend.Amount())
+	}
+
+	sort.Slice(report.Totals, func(i, j int) bool {
+		if report.Totals[i].Network != report.Totals[j].Network {
+			return report.Totals[i].Network < report.Totals[j].Network
+		}
+		return report.Totals[i].Purpose < report.Totals[j].Purpose
+	})
+	return report, nil
+}
+
+// strategy returns the strategy of a network.
+func (s *ServiceImpl) strategy(network shared.BlockchainNetwork) Strategy {
+	strategy, ok := s.policy.Strategies[network]
+	if !ok {
+		return DefaultStrategy()
+	}
+	if strategy.Speed == "" {
+		strategy.Speed = SpeedStandard
+	}
+	return strategy
+}
@@ -0,0 +1,139 @@
+package fee
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeOracle struct {
+	network shared.BlockchainNetwork
+	fee     decimal.Decimal
+	err     error
+	calls   int
+}
+
+func (o *fakeOracle) Network() shared.BlockchainNetwork { return o.network }
+
+func (o *fakeOracle) Estimate(_ context.Context, currency string, speed Speed) (*Estimate, error) {
+	o.calls++
+	if o.err != nil {
+		return nil, o.err
+	}
+	return &Estimate{
+		Network:     o.network,
+		Currency:    currency,
+		FeeCurrency: NativeCurrency(o.network),
+		Speed:       speed,
+		Fee:         o.fee,
+		EstimatedAt: time.Now(),
+	}, nil
+}
+
+type fakeSpends struct {
+	spends []*Spend
+}
+
+func (r *fakeSpends) Save(_ context.Context, spend *Spend) error {
+	r.spends = append(r.spends, spend)
+	return nil
+}
+
+func (r *fakeSpends) ListBetween(_ context.Context, from, to time.Time) ([]*Spend, error) {
+	var result []*Spend
+	for _, spend := range r.spends {
+		if !spend.CreatedAt().Before(from) && spend.CreatedAt().Before(to) {
+			result = append(result, spend)
+		}
+	}
+	return result, nil
+}
+
+func TestService_DecideDefersExpensiveBroadcasts(t *testing.T) {
+	ctx := context.Background()
+	tron := &fakeOracle{network: shared.NetworkTron, fee: decimal.NewFromInt(42)}
+	ethereum := &fakeOracle{network: shared.NetworkEthereum, err: ErrOracleFailure}
+	service := NewService([]Oracle{tron, ethereum}, &fakeSpends{}, Policy{
+		Strategies: map[shared.BlockchainNetwork]Strategy{
+			shared.NetworkTron:     {MaxFee: decimal.NewFromInt(30), MaxDelay: time.Hour},
+			shared.NetworkEthereum: {MaxFee: decimal.RequireFromString("0.01")},
+		},
+	}, zap.NewNop())
+
+	decision := service.Decide(ctx, &BroadcastRequest{
+		Network: shared.NetworkTron, Currency: "USDT", WaitingSince: time.Now(),
+	})
+	assert.False(t, decision.Broadcast)
+	assert.Equal(t, "network fee 42 TRX exceeds the 30 TRX limit", decision.Reason)
+
+	// The estimate is reused within its TTL
+	service.Decide(ctx, &BroadcastRequest{Network: shared.NetworkTron, Currency: "USDT", WaitingSince: time.Now()})
+	assert.Equal(t, 1, tron.calls)
+
+	t.Run("transfer waiting past the maximum delay is sent at any fee", func(t *testing.T) {
+		decision := service.Decide(ctx, &BroadcastRequest{
+			Network: shared.NetworkTron, Currency: "USDT", WaitingSince: time.Now().Add(-2 * time.Hour),
+		})
+		assert.True(t, decision.Broadcast)
+	})
+
+	t.Run("transfer is not held back without an estimate", func(t *testing.T) {
+		decision := service.Decide(ctx, &BroadcastRequest{Network: shared.NetworkEthereum, Currency: "ETH"})
+		assert.True(t, decision.Broadcast)
+		assert.Nil(t, decision.Estimate)
+
+		decision = service.Decide(ctx, &BroadcastRequest{Network: shared.NetworkBitcoin, Currency: "BTC"})
+		assert.True(t, decision.Broadcast)
+	})
+
+	t.Run("estimate without an oracle", func(t *testing.T) {
+		_, err := service.Estimate(ctx, &EstimateRequest{Network: shared.NetworkBitcoin, Currency: "BTC"})
+		require.ErrorIs(t, err, ErrUnsupportedNetwork)
+	})
+}
+
+func TestService_ReportTotalsSpendPerNetworkAndPurpose(t *testing.T) {
+	ctx := context.Background()
+	spends := &fakeSpends{}
+	service := NewService(nil, spends, DefaultPolicy(), zap.NewNop())
+
+	for _, spend := range []struct {
+		purpose   Purpose
+		reference string
+		network   shared.BlockchainNetwork
+		amount    string
+	}{
+		{PurposeSweep, "sweep-1", shared.NetworkTron, "13.4"},
+		{PurposeSweep, "sweep-2", shared.NetworkTron, "6.6"},
+		{PurposePayout, "payout-1", shared.NetworkBitcoin, "0.0001"},
+	} {
+		require.NoError(t, service.RecordSpend(ctx, &SpendRequest{
+			Purpose:   spend.purpose,
+			Reference: spend.reference,
+			Network:   spend.network,
+			TxHash:    "tx-" + spend.reference,
+			Amount:    decimal.RequireFromString(spend.amount),
+		}))
+	}
+
+	report, err := service.Report(ctx, &ReportRequest{})
+	require.NoError(t, err)
+	require.Len(t, report.Totals, 2)
+
+	assert.Equal(t, shared.NetworkBitcoin, report.Totals[0].Network)
+	assert.Equal(t, "BTC", report.Totals[0].FeeCurrency)
+	assert.Equal(t, 1, report.Totals[0].Transfers)
+
+	assert.Equal(t, PurposeSweep, report.Totals[1].Purpose)
+	assert.Equal(t, 2, report.Totals[1].Transfers)
+	assert.Equal(t, "20", report.Totals[1].Amount.String())
+
+	_, err = service.Report(ctx, &ReportRequest{From: time.Now(), To: time.Now().Add(-time.Hour)})
+	require.ErrorIs(t, err, ErrInvalidRequest)
+}
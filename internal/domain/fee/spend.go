@@ -0,0 +1,116 @@
+package fee
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Spend records the network fee paid by one confirmed transfer.
+type Spend struct {
+	id          string
+	purpose     Purpose
+	reference   string
+	network     shared.BlockchainNetwork
+	txHash      string
+	amount      decimal.Decimal
+	feeCurrency string
+	createdAt   time.Time
+}
+
+// NewSpend creates the record of a fee paid by a transfer. The reference identifies what the
+// transfer was for, such as the payout or sweep ID.
+func NewSpend(
+	id string,
+	purpose Purpose,
+	reference string,
+	network shared.BlockchainNetwork,
+	txHash string,
+	amount decimal.Decimal,
+) (*Spend, error) {
+	return RestoreSpend(
+		id, purpose, reference, network, txHash, amount, NativeCurrency(network), shared.Now().UTC(),
+	)
+}
+
+// RestoreSpend recreates a fee spend from storage.
+func RestoreSpend(
+	id string,
+	purpose Purpose,
+	reference string,
+	network shared.BlockchainNetwork,
+	txHash string,
+	amount decimal.Decimal,
+	feeCurrency string,
+	createdAt time.Time,
+) (*Spend, error) {
+	if id == "" {
+		return nil, errors.New("fee spend ID is required")
+	}
+	if !purpose.IsValid() {
+		return nil, fmt.Errorf("%w: invalid purpose %s", ErrInvalidRequest, purpose)
+	}
+	if reference == "" || txHash == "" {
+		return nil, fmt.Errorf("%w: fee spend reference and transaction hash are required", ErrInvalidRequest)
+	}
+	if feeCurrency == "" {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedNetwork, network)
+	}
+	if amount.IsNegative() {
+		return nil, fmt.Errorf("%w: fee cannot be negative", ErrInvalidRequest)
+	}
+
+	return &Spend{
+		id:          id,
+		purpose:     purpose,
+		reference:   reference,
+		network:     network,
+		txHash:      txHash,
+		amount:      amount,
+		feeCurrency: feeCurrency,
+		createdAt:   createdAt,
+	}, nil
+}
+
+// ID returns the fee spend ID.
+func (s *Spend) ID() string {
+	return s.id
+}
+
+// Purpose returns why the transfer was sent.
+func (s *Spend) Purpose() Purpose {
+	return s.purpose
+}
+
+// Reference returns the ID of what the transfer was for.
+func (s *Spend) Reference() string {
+	return s.reference
+}
+
+// Network returns the network of the transfer.
+func (s *Spend) Network() shared.BlockchainNetwork {
+	return s.network
+}
+
+// TxHash returns the hash of the transfer.
+func (s *Spend) TxHash() string {
+	return s.txHash
+}
+
+// Amount returns the fee paid, in FeeCurrency.
+func (s *Spend) Amount() decimal.Decimal {
+	return s.amount
+}
+
+// FeeCurrency returns the currency the fee was paid in.
+func (s *Spend) FeeCurrency() string {
+	return s.feeCurrency
+}
+
+// CreatedAt returns when the fee was recorded.
+func (s *Spend) CreatedAt() time.Time {
+	return s.createdAt
+}
@@ -0,0 +1,15 @@
+package geoblock
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the geo-blocking service layer dependencies.
+var Module = fx.Module("geoblock-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+)
@@ -0,0 +1,15 @@
+package geoblock
+
+import "errors"
+
+// Domain errors for geo-blocking operations
+var (
+	ErrOverrideNotFound = errors.New("geo-block override not found")
+	ErrInvalidRequest   = errors.New("invalid geo-block request")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeGeoRestricted    = "GEO_RESTRICTED"
+	ErrCodeOverrideNotFound = "GEO_OVERRIDE_NOT_FOUND"
+)
@@ -0,0 +1,65 @@
+// Package geoblock keeps customers in the jurisdictions a merchant may not serve from its checkout: checkouts
+// viewed from countries the merchant restricts are refused and recorded, unless the merchant issued an override
+// token for the invoice.
+package geoblock
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Limits on override tokens.
+const (
+	DefaultOverrideTTL = 24 * time.Hour
+	MaxOverrideTTL     = 7 * 24 * time.Hour
+	overrideTokenBytes = 24
+	// OverrideTokenPrefix marks override tokens, so that they are recognized when leaked.
+	OverrideTokenPrefix = "gbo_"
+)
+
+// Block records the refused attempts to view an invoice's checkout from a restricted country.
+type Block struct {
+	InvoiceID      string
+	MerchantID     string
+	Country        string
+	Attempts       int
+	FirstBlockedAt time.Time
+	LastBlockedAt  time.Time
+}
+
+// Override lets a customer view an invoice's checkout from a restricted country until it expires. Only the hash
+// of its token is kept.
+type Override struct {
+	ID         string
+	InvoiceID  string
+	MerchantID string
+	TokenHash  string
+	IssuedBy   string
+	Reason     string
+	IssuedAt   time.Time
+	ExpiresAt  time.Time
+	UsedAt     *time.Time
+}
+
+// IsActive reports whether the override still lets its invoice's checkout be viewed at the time.
+func (o *Override) IsActive(at time.Time) bool {
+	return at.Before(o.ExpiresAt)
+}
+
+// NewOverrideToken generates an override token.
+func NewOverrideToken() (string, error) {
+	random := make([]byte, overrideTokenBytes)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate override token: %w", err)
+	}
+	return OverrideTokenPrefix + hex.EncodeToString(random), nil
+}
+
+// HashOverrideToken returns the hash overrides are stored and looked up by.
+func HashOverrideToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
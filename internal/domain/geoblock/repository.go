@@ -0,0 +1,32 @@
+package geoblock
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for geo-block and override persistence.
+type Repository interface {
+	// RecordBlock counts a refused attempt to view an invoice's checkout from a country, returning whether it was
+	// the first from that country.
+	RecordBlock(ctx context.Context, invoiceID, merchantID, country string, at time.Time) (bool, error)
+
+	// ListBlocks retrieves the blocks of an invoice's checkout, by country.
+	ListBlocks(ctx context.Context, invoiceID string) ([]*Block, error)
+
+	// SaveOverride persists a new override.
+	SaveOverride(ctx context.Context, override *Override) error
+
+	// FindOverride retrieves an override by the hash of its token, returning ErrOverrideNotFound if there is none.
+	FindOverride(ctx context.Context, tokenHash string) (*Override, error)
+
+	// MarkOverrideUsed records the first use of an override, returning whether it had not been used before.
+	MarkOverrideUsed(ctx context.Context, id string, at time.Time) (bool, error)
+}
+
+// MerchantRestrictions reads the countries merchants restrict their checkouts in.
+type MerchantRestrictions interface {
+	// RestrictedCountries returns the ISO 3166-1 alpha-2 codes of the countries the merchant's checkouts are
+	// refused in.
+	RestrictedCountries(ctx context.Context, merchantID string) ([]string, error)
+}
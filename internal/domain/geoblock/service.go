@@ -0,0 +1,250 @@
+package geoblock
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Service defines the interface for geo-blocking checkouts.
+type Service interface {
+	// Check decides whether an invoice's checkout may be shown to a client in a country.
+	Check(ctx context.Context, req *CheckRequest) (*Decision, error)
+
+	// IssueOverride issues a token that lets an invoice's checkout be viewed from restricted countries.
+	IssueOverride(ctx context.Context, req *IssueOverrideRequest) (*IssuedOverride, error)
+
+	// ListBlocks retrieves the blocks of a merchant's invoice's checkout, by country.
+	ListBlocks(ctx context.Context, merchantID, invoiceID string) ([]*Block, error)
+}
+
+// CheckRequest describes a client asking for an invoice's checkout.
+type CheckRequest struct {
+	InvoiceID  string
+	MerchantID string
+	// Country is the ISO code of the country the client was located in, if known
+	Country string
+	// OverrideToken is the override the client presented, if any
+	OverrideToken string
+}
+
+// Decision is the outcome of a check.
+type Decision struct {
+	Allowed bool
+	// Country is the country the client was located in, or "" if it is not known
+	Country string
+	// Overridden is set when the checkout is only allowed by an override
+	Overridden bool
+}
+
+// IssueOverrideRequest represents the request to issue an override.
+type IssueOverrideRequest struct {
+	InvoiceID  string
+	MerchantID string
+	Reason     string
+	// TTL is how long the override lasts; zero for DefaultOverrideTTL
+	TTL      time.Duration
+	IssuedBy audit.Actor
+}
+
+// IssuedOverride is a newly issued override and its token, which is only returned here.
+type IssuedOverride struct {
+	Override *Override
+	Token    string
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository   Repository
+	restrictions MerchantRestrictions
+	auditService audit.Service
+	logger       *zap.Logger
+}
+
+// NewService creates a new geo-blocking service.
+func NewService(
+	repository Repository,
+	restrictions MerchantRestrictions,
+	auditService audit.Service,
+	logger *zap.Logger,
+) Service {
+	return &ServiceImpl{
+		repository:   repository,
+		restrictions: restrictions,
+		auditService: auditService,
+		logger:       logger,
+	}
+}
+
+// Check decides whether an invoice's checkout may be shown to a client in a country. Clients that cannot be
+// located are allowed. Refused clients are counted, and the first refusal per country is audited; so is the first
+// use of an override.
+func (s *ServiceImpl) Check(ctx context.Context, req *CheckRequest) (*Decision, error) {
+	if req == nil || req.InvoiceID == "" || req.MerchantID == "" {
+		return nil, fmt.Errorf("%w: invoice ID and merchant ID are required", ErrInvalidRequest)
+	}
+
+	country, ok := shared.ParseCountryCode(req.Country)
+	if !ok {
+		return &Decision{Allowed: true}, nil
+	}
+	restricted, err := s.restrictions.RestrictedCountries(ctx, req.MerchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read restricted countries: %w", err)
+	}
+	if !slices.Contains(restricted, country) {
+		return &Decision{Allowed: true, Country: country}, nil
+	}
+
+	now := shared.Now().UTC()
+	override, err := s.override(ctx, req, now)
+	if err != nil {
+		return nil, err
+	}
+	if override != nil {
+		s.useOverride(ctx, override, country, now)
+		return &Decision{Allowed: true, Country: country, Overridden: true}, nil
+	}
+
+	first, err := s.repository.RecordBlock(ctx, req.InvoiceID, req.MerchantID, country, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record geo-block: %w", err)
+	}
+	if first {
+		s.record(ctx, req.MerchantID, "geo_block.blocked", req.InvoiceID, map[string]interface{}{
+			"country": country,
+		})
+	}
+	s.logger.Debug("Checkout refused in restricted country",
+		zap.String("invoice_id", req.InvoiceID),
+		zap.String("country", country))
+	return &Decision{Country: country}, nil
+}
+
+// override returns the active override the client presented for the invoice, or nil if there is none.
+func (s *ServiceImpl) override(ctx context.Context, req *CheckRequest, now time.Time) (*Override, error) {
+	if !strings.HasPrefix(req.OverrideToken, OverrideTokenPrefix) {
+		return nil, nil
+	}
+	override, err := s.repository.FindOverride(ctx, HashOverrideToken(req.OverrideToken))
+	if errors.Is(err, ErrOverrideNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find geo-block override: %w", err)
+	}
+	if override.InvoiceID != req.InvoiceID || !override.IsActive(now) {
+		return nil, nil
+	}
+	return override, nil
+}
+
+// useOverride records the first use of an override. Failures are logged, as the override was valid.
+func (s *ServiceImpl) useOverride(ctx context.Context, override *Override, country string, now time.Time) {
+	first, err := s.repository.MarkOverrideUsed(ctx, override.ID, now)
+	if err != nil {
+		s.logger.Error("Failed to mark geo-block override used",
+			zap.String("override_id", override.ID),
+			zap.Error(err))
+		return
+	}
+	if first {
+		s.record(ctx, override.MerchantID, "geo_block.override_used", override.InvoiceID, map[string]interface{}{
+			"country":     country,
+			"override_id": override.ID,
+		})
+	}
+}
+
+// IssueOverride issues a token that lets an invoice's checkout be viewed from restricted countries until the
+// override expires.
+func (s *ServiceImpl) IssueOverride(ctx context.Context, req *IssueOverrideRequest) (*IssuedOverride, error) {
+	if req == nil || req.InvoiceID == "" || req.MerchantID == "" {
+		return nil, fmt.Errorf("%w: invoice ID and merchant ID are required", ErrInvalidRequest)
+	}
+	ttl := req.TTL
+	if ttl == 0 {
+		ttl = DefaultOverrideTTL
+	}
+	if ttl < 0 || ttl > MaxOverrideTTL {
+		return nil, fmt.Errorf("%w: overrides last up to %s", ErrInvalidRequest, MaxOverrideTTL)
+	}
+
+	token, err := NewOverrideToken()
+	if err != nil {
+		return nil, err
+	}
+	now := shared.Now().UTC()
+	override := &Override{
+		ID:         uuid.New().String(),
+		InvoiceID:  req.InvoiceID,
+		MerchantID: req.MerchantID,
+		TokenHash:  HashOverrideToken(token),
+		IssuedBy:   req.IssuedBy.ID,
+		Reason:     strings.TrimSpace(req.Reason),
+		IssuedAt:   now,
+		ExpiresAt:  now.Add(ttl),
+	}
+	if err := s.repository.SaveOverride(ctx, override); err != nil {
+		return nil, fmt.Errorf("failed to save geo-block override: %w", err)
+	}
+
+	if err := s.auditService.Record(ctx, &audit.RecordRequest{
+		MerchantID:   req.MerchantID,
+		Actor:        req.IssuedBy,
+		Action:       "geo_block.override_issued",
+		ResourceType: "invoice",
+		ResourceID:   req.InvoiceID,
+		Details: map[string]interface{}{
+			"override_id": override.ID,
+			"reason":      override.Reason,
+			"expires_at":  override.ExpiresAt,
+		},
+	}); err != nil {
+		s.logger.Error("Failed to record audit entry",
+			zap.String("override_id", override.ID),
+			zap.Error(err))
+	}
+	return &IssuedOverride{Override: override, Token: token}, nil
+}
+
+// ListBlocks retrieves the blocks of a merchant's invoice's checkout, by country.
+func (s *ServiceImpl) ListBlocks(ctx context.Context, merchantID, invoiceID string) ([]*Block, error) {
+	if merchantID == "" || invoiceID == "" {
+		return nil, fmt.Errorf("%w: merchant ID and invoice ID are required", ErrInvalidRequest)
+	}
+
+	blocks, err := s.repository.ListBlocks(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(blocks, func(block *Block) bool { return block.MerchantID != merchantID }), nil
+}
+
+// record audits a geo-blocking event of an invoice's checkout on behalf of the system.
+func (s *ServiceImpl) record(
+	ctx context.Context,
+	merchantID, action, invoiceID string,
+	details map[string]interface{},
+) {
+	if err := s.auditService.Record(ctx, &audit.RecordRequest{
+		MerchantID:   merchantID,
+		Actor:        audit.Actor{ID: "system", Type: audit.ActorTypeSystem},
+		Action:       action,
+		ResourceType: "invoice",
+		ResourceID:   invoiceID,
+		Details:      details,
+	}); err != nil {
+		s.logger.Error("Failed to record audit entry",
+			zap.String("invoice_id", invoiceID),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,161 @@
+package geoblock
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubRepository keeps blocks and overrides in memory.
+type stubRepository struct {
+	blocks    map[string]*Block
+	overrides map[string]*Override
+}
+
+func (r *stubRepository) RecordBlock(
+	_ context.Context,
+	invoiceID, merchantID, country string,
+	at time.Time,
+) (bool, error) {
+	key := invoiceID + "/" + country
+	if block, ok := r.blocks[key]; ok {
+		block.Attempts++
+		block.LastBlockedAt = at
+		return false, nil
+	}
+	r.blocks[key] = &Block{
+		InvoiceID: invoiceID, MerchantID: merchantID, Country: country, Attempts: 1,
+		FirstBlockedAt: at, LastBlockedAt: at,
+	}
+	return true, nil
+}
+
+func (r *stubRepository) ListBlocks(_ context.Context, invoiceID string) ([]*Block, error) {
+	var blocks []*Block
+	for _, block := range r.blocks {
+		if block.InvoiceID == invoiceID {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks, nil
+}
+
+func (r *stubRepository) SaveOverride(_ context.Context, override *Override) error {
+	r.overrides[override.TokenHash] = override
+	return nil
+}
+
+func (r *stubRepository) FindOverride(_ context.Context, tokenHash string) (*Override, error) {
+	override, ok := r.overrides[tokenHash]
+	if !ok {
+		return nil, ErrOverrideNotFound
+	}
+	return override, nil
+}
+
+func (r *stubRepository) MarkOverrideUsed(_ context.Context, id string, at time.Time) (bool, error) {
+	for _, override := range r.overrides {
+		if override.ID == id && override.UsedAt == nil {
+			override.UsedAt = &at
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// stubRestrictions restricts every merchant in the same countries.
+type stubRestrictions []string
+
+func (r stubRestrictions) RestrictedCountries(context.Context, string) ([]string, error) {
+	return r, nil
+}
+
+type fakeAudit struct {
+	audit.Service
+	actions []string
+}
+
+func (f *fakeAudit) Record(_ context.Context, req *audit.RecordRequest) error {
+	f.actions = append(f.actions, req.Action)
+	return nil
+}
+
+// fixedClock tells the time it is set to.
+type fixedClock struct{ now time.Time }
+
+func (c *fixedClock) Now() time.Time { return c.now }
+
+func TestService_Check(t *testing.T) {
+	ctx := context.Background()
+	clock := &fixedClock{now: time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)}
+	t.Cleanup(shared.SetClock(clock))
+	repository := &stubRepository{blocks: map[string]*Block{}, overrides: map[string]*Override{}}
+	auditLog := &fakeAudit{}
+	service := NewService(repository, stubRestrictions{"KP", "IR"}, auditLog, zap.NewNop())
+
+	check := func(country, token string) *Decision {
+		t.Helper()
+		decision, err := service.Check(ctx, &CheckRequest{
+			InvoiceID: "inv-1", MerchantID: "merchant-1", Country: country, OverrideToken: token,
+		})
+		require.NoError(t, err)
+		return decision
+	}
+
+	assert.True(t, check("de", "").Allowed)
+	assert.True(t, check("", "").Allowed, "clients that cannot be located are allowed")
+	assert.True(t, check("XX", "").Allowed)
+	assert.False(t, check("kp", "").Allowed)
+	assert.False(t, check("KP", "").Allowed)
+	assert.False(t, check("IR", "gbo_unknown").Allowed)
+
+	blocks, err := service.ListBlocks(ctx, "merchant-1", "inv-1")
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	assert.Equal(t, 3, blocks[0].Attempts+blocks[1].Attempts)
+	blocks, err = service.ListBlocks(ctx, "merchant-2", "inv-1")
+	require.NoError(t, err)
+	assert.Empty(t, blocks, "blocks are scoped to their merchant")
+	assert.Equal(t, []string{"geo_block.blocked", "geo_block.blocked"}, auditLog.actions,
+		"the first block per country is audited")
+
+	issued, err := service.IssueOverride(ctx, &IssueOverrideRequest{
+		InvoiceID: "inv-1", MerchantID: "merchant-1", Reason: "verified customer",
+		IssuedBy: audit.Actor{ID: "user-1", Type: audit.ActorTypeUser},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, clock.now.Add(DefaultOverrideTTL), issued.Override.ExpiresAt)
+	assert.NotContains(t, issued.Override.TokenHash, issued.Token, "only the token's hash is kept")
+
+	decision := check("KP", issued.Token)
+	assert.True(t, decision.Allowed)
+	assert.True(t, decision.Overridden)
+	assert.True(t, check("KP", issued.Token).Allowed)
+	assert.Equal(t, []string{"geo_block.blocked", "geo_block.blocked", "geo_block.override_issued",
+		"geo_block.override_used"}, auditLog.actions, "the first use of an override is audited")
+
+	other, err := service.Check(ctx, &CheckRequest{
+		InvoiceID: "inv-2", MerchantID: "merchant-1", Country: "KP", OverrideToken: issued.Token,
+	})
+	require.NoError(t, err)
+	assert.False(t, other.Allowed, "overrides are bound to their invoice")
+
+	clock.now = clock.now.Add(DefaultOverrideTTL)
+	assert.False(t, check("KP", issued.Token).Allowed, "expired overrides are refused")
+}
+
+func TestService_IssueOverrideTTL(t *testing.T) {
+	repository := &stubRepository{blocks: map[string]*Block{}, overrides: map[string]*Override{}}
+	service := NewService(repository, stubRestrictions{}, &fakeAudit{}, zap.NewNop())
+
+	_, err := service.IssueOverride(context.Background(), &IssueOverrideRequest{
+		InvoiceID: "inv-1", MerchantID: "merchant-1", TTL: MaxOverrideTTL + time.Hour,
+	})
+	require.ErrorIs(t, err, ErrInvalidRequest)
+}
@@ -0,0 +1,101 @@
+package invoice
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+)
+
+// DefaultAmendReason is the cancellation reason recorded on an amended invoice when none is given.
+const DefaultAmendReason = "amended"
+
+// Supersedes returns the ID of the invoice this one replaced, or nil if it was not created by an amendment.
+func (i *Invoice) Supersedes() *string {
+	return i.supersedes
+}
+
+// SupersededBy returns the ID of the invoice that replaced this one, or nil if it was not amended.
+func (i *Invoice) SupersededBy() *string {
+	return i.supersededBy
+}
+
+// SetSupersedes records the invoice this one replaces (for creation and repository restoration).
+func (i *Invoice) SetSupersedes(id string) {
+	i.supersedes = &id
+}
+
+// SetSupersededBy records the invoice that replaced this one (for repository restoration).
+// Use Supersede to amend an invoice.
+func (i *Invoice) SetSupersededBy(id string) {
+	i.supersededBy = &id
+}
+
+// CanAmend returns true if the invoice can still be replaced: it has been finalized and no payment has been
+// received. Drafts are edited in place instead.
+func (i *Invoice) CanAmend() bool {
+	return (i.status == StatusCreated || i.status == StatusPending) && i.amountPaid == nil
+}
+
+// Supersede links the invoice to the invoice replacing it. The caller cancels the invoice afterwards.
+func (i *Invoice) Supersede(replacementID string) error {
+	if !i.CanAmend() {
+		return ErrCannotAmend
+	}
+	if replacementID == "" || replacementID == i.id {
+		return ErrInvalidInvoiceID
+	}
+
+	i.supersededBy = &replacementID
+	i.updatedAt = shared.Now().UTC()
+	return nil
+}
+
+// AmendInvoice cancels an unpaid finalized invoice and replaces it with an invoice carrying the changed terms.
+// The replacement keeps the original's coupon, is quoted at the current exchange rate, gets a fresh payment
+// address and is payable for the same window as the original. The original records the replacement as
+// superseded_by and the replacement records the original as supersedes, so the chain can be followed both ways.
+func (s *InvoiceServiceImpl) AmendInvoice(ctx context.Context, req *AmendInvoiceRequest) (*Invoice, error) {
+	if req == nil || req.InvoiceID == "" {
+		return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+	}
+
+	original, err := s.repository.FindByID(ctx, req.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if !original.CanAmend() {
+		return nil, ErrCannotAmend
+	}
+
+	// Reject invalid changes before anything is created or cancelled
+	terms, _, _, _, err := s.revisedPricing(ctx, original, &req.InvoiceChanges)
+	if err != nil {
+		return nil, err
+	}
+	terms.Supersedes = original.ID()
+
+	replacement, err := s.CreateInvoice(ctx, terms)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := original.Supersede(replacement.ID()); err != nil {
+		return nil, err
+	}
+
+	// Use FSM to transition the original to cancelled
+	fsm := NewInvoiceFSM(original)
+	if err := fsm.Event(ctx, "cancel"); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(ctx, original); err != nil {
+		return nil, err
+	}
+
+	reason := req.Reason
+	if reason == "" {
+		reason = DefaultAmendReason
+	}
+	s.publishInvoiceCancelled(ctx, original, reason)
+	return replacement, nil
+}
@@ -0,0 +1,45 @@
+package invoice_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoiceAmend(t *testing.T) {
+	t.Run("supersede links the replacement", func(t *testing.T) {
+		testInvoice := createTestInvoice()
+		require.True(t, testInvoice.CanAmend())
+		require.Nil(t, testInvoice.SupersededBy())
+
+		require.NoError(t, testInvoice.Supersede("replacement-id"))
+		require.Equal(t, "replacement-id", *testInvoice.SupersededBy())
+		require.NoError(t, invoice.NewInvoiceFSM(testInvoice).Event(context.Background(), "cancel"))
+		require.False(t, testInvoice.CanAmend())
+	})
+
+	t.Run("replacement must be another invoice", func(t *testing.T) {
+		testInvoice := createTestInvoice()
+		require.ErrorIs(t, testInvoice.Supersede(""), invoice.ErrInvalidInvoiceID)
+		require.ErrorIs(t, testInvoice.Supersede(testInvoice.ID()), invoice.ErrInvalidInvoiceID)
+		require.Nil(t, testInvoice.SupersededBy())
+	})
+
+	t.Run("invoice with a payment cannot be amended", func(t *testing.T) {
+		testInvoice := createTestInvoice()
+		paid, _ := shared.NewMoneyWithCrypto("0.001", shared.CryptoCurrencyBTC)
+		require.NoError(t, testInvoice.RecordPayment(paid))
+
+		require.False(t, testInvoice.CanAmend())
+		require.ErrorIs(t, testInvoice.Supersede("replacement-id"), invoice.ErrCannotAmend)
+	})
+
+	t.Run("drafts are edited instead", func(t *testing.T) {
+		draft := createDraftTestInvoice(t)
+		require.False(t, draft.CanAmend())
+		require.ErrorIs(t, draft.Supersede("replacement-id"), invoice.ErrCannotAmend)
+	})
+}
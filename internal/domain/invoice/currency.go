@@ -0,0 +1,44 @@
+package invoice
+
+import (
+	"crypto-checkout/internal/domain/shared"
+)
+
+// CanChangeCurrency returns true if the customer may still switch the invoice to another cryptocurrency.
+func (i *Invoice) CanChangeCurrency() bool {
+	if (i.status != StatusCreated && i.status != StatusPending) || i.amountPaid != nil {
+		return false
+	}
+	return i.expiration == nil || !i.expiration.IsExpired()
+}
+
+// ChangeCurrency switches an unpaid invoice to another cryptocurrency, quoted at the rate and paid to the address.
+// Re-quotes of the previous cryptocurrency are dropped, as their amounts no longer apply.
+func (i *Invoice) ChangeCurrency(
+	currency shared.CryptoCurrency,
+	address *shared.PaymentAddress,
+	rate *shared.ExchangeRate,
+) error {
+	if !currency.IsValid() {
+		return ErrInvalidCryptocurrency
+	}
+	if address == nil {
+		return ErrInvalidPaymentAddress
+	}
+	if rate == nil {
+		return ErrInvalidExchangeRate
+	}
+	if !i.CanChangeCurrency() {
+		return ErrCannotChangeCurrency
+	}
+	if rate.FromCurrency() != i.exchangeRate.FromCurrency() || rate.ToCurrency() != currency {
+		return ErrCurrencyMismatch
+	}
+
+	i.cryptoCurrency = currency
+	i.paymentAddress = address
+	i.exchangeRate = rate
+	i.requotes = nil
+	i.updatedAt = shared.Now().UTC()
+	return nil
+}
@@ -0,0 +1,126 @@
+package invoice_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestInvoiceChangeCurrency(t *testing.T) {
+	usdtAddress, err := shared.NewPaymentAddress("TQn9Y2khEsLJW1ChVWFMSMeRDow5KcbLSE", shared.NetworkTron)
+	require.NoError(t, err)
+	usdtRate, err := shared.NewExchangeRate("1.0", shared.CurrencyUSD, shared.CryptoCurrencyUSDT, "fresh-source",
+		30*time.Minute)
+	require.NoError(t, err)
+
+	t.Run("switch re-quotes the invoice and drops earlier re-quotes", func(t *testing.T) {
+		testInvoice := createRequotableInvoice(t)
+		_, err := testInvoice.Requote(newTestRate(t, "0.0000203"), decimal.RequireFromString("0.02"))
+		require.NoError(t, err)
+		require.True(t, testInvoice.CanChangeCurrency())
+
+		require.NoError(t, testInvoice.ChangeCurrency(shared.CryptoCurrencyUSDT, usdtAddress, usdtRate))
+
+		require.Equal(t, shared.CryptoCurrencyUSDT, testInvoice.CryptoCurrency())
+		require.Equal(t, shared.NetworkTron, testInvoice.PaymentAddress().Network())
+		require.Empty(t, testInvoice.Requotes())
+		amount, err := testInvoice.LockedCryptoAmount()
+		require.NoError(t, err)
+		require.Equal(t, "110", amount.Amount().String())
+	})
+
+	t.Run("rate for another currency is rejected", func(t *testing.T) {
+		testInvoice := createRequotableInvoice(t)
+
+		err := testInvoice.ChangeCurrency(shared.CryptoCurrencyETH, usdtAddress, usdtRate)
+		require.ErrorIs(t, err, invoice.ErrCurrencyMismatch)
+		require.Equal(t, shared.CryptoCurrencyBTC, testInvoice.CryptoCurrency())
+	})
+
+	t.Run("invoice with a payment cannot change currency", func(t *testing.T) {
+		testInvoice := createRequotableInvoice(t)
+		paid, _ := shared.NewMoneyWithCrypto("0.001", shared.CryptoCurrencyBTC)
+		require.NoError(t, testInvoice.RecordPayment(paid))
+		require.False(t, testInvoice.CanChangeCurrency())
+
+		err := testInvoice.ChangeCurrency(shared.CryptoCurrencyUSDT, usdtAddress, usdtRate)
+		require.ErrorIs(t, err, invoice.ErrCannotChangeCurrency)
+	})
+}
+
+type stubMerchantCurrencies map[string][]shared.TokenSelector
+
+func (s stubMerchantCurrencies) AcceptedCurrencies(
+	_ context.Context, merchantID string,
+) ([]shared.TokenSelector, error) {
+	return s[merchantID], nil
+}
+
+// TestMode puts merchants whose IDs start with "test-" in test mode.
+func (s stubMerchantCurrencies) TestMode(_ context.Context, merchantID string) (bool, error) {
+	return strings.HasPrefix(merchantID, "test-"), nil
+}
+
+func TestInvoiceService_AcceptedTokens(t *testing.T) {
+	ctx := context.Background()
+	service := invoice.NewInvoiceService(nil, nil, nil, nil, invoice.DefaultRequotePolicy(), nil, stubMerchantCurrencies{
+		"merchant-1": {{Symbol: shared.CryptoCurrencyETH}, {Symbol: shared.CryptoCurrencyUSDT}},
+	}, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+	t.Run("merchant settings narrow and order the tokens", func(t *testing.T) {
+		tokens, err := service.AcceptedTokens(ctx, "merchant-1")
+		require.NoError(t, err)
+		require.Len(t, tokens, 2)
+		require.Equal(t, shared.CryptoCurrencyETH, tokens[0].Symbol)
+		require.Equal(t, shared.CryptoCurrencyUSDT, tokens[1].Symbol)
+
+		_, err = service.ResolveToken(ctx, "merchant-1", shared.CryptoCurrencyBTC, "")
+		require.ErrorIs(t, err, invoice.ErrUnsupportedCryptocurrency)
+	})
+
+	t.Run("merchant without settings accepts every token", func(t *testing.T) {
+		tokens, err := service.AcceptedTokens(ctx, "merchant-2")
+		require.NoError(t, err)
+		require.Len(t, tokens, len(shared.DefaultTokens()))
+	})
+
+	t.Run("merchant in test mode is paid on testnets", func(t *testing.T) {
+		tokens, err := service.AcceptedTokens(ctx, "test-merchant")
+		require.NoError(t, err)
+		require.Len(t, tokens, len(shared.DefaultTestnetTokens()))
+		for _, token := range tokens {
+			require.True(t, token.Network.IsTestnet(), token.Network)
+		}
+
+		token, err := service.ResolveToken(ctx, "test-merchant", shared.CryptoCurrencyUSDT, "")
+		require.NoError(t, err)
+		require.Equal(t, shared.NetworkTronNile, token.Network)
+		token, err = service.ResolveToken(ctx, "test-merchant", shared.CryptoCurrencyUSDT, shared.NetworkTron)
+		require.NoError(t, err)
+		require.Equal(t, shared.NetworkTronNile, token.Network)
+
+		_, err = service.ResolveToken(ctx, "merchant-2", shared.CryptoCurrencyUSDT, shared.NetworkTronShasta)
+		require.ErrorIs(t, err, invoice.ErrUnsupportedCryptocurrency)
+	})
+
+	t.Run("live keys cannot create testnet invoices", func(t *testing.T) {
+		price, err := shared.NewMoney("10.00", shared.CurrencyUSD)
+		require.NoError(t, err)
+		_, err = service.CreateInvoice(ctx, &invoice.CreateInvoiceRequest{
+			MerchantID:     "test-merchant",
+			Title:          "Order",
+			Items:          []*invoice.CreateInvoiceItemRequest{{Name: "Item", Quantity: "1", UnitPrice: price}},
+			Currency:       shared.CurrencyUSD,
+			CryptoCurrency: shared.CryptoCurrencyUSDT,
+			LiveKey:        true,
+		})
+		require.ErrorIs(t, err, invoice.ErrLiveKeyTestnet)
+	})
+}
@@ -0,0 +1,237 @@
+package invoice
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// MaxCustomFields is the number of custom fields an invoice may carry.
+	MaxCustomFields = 10
+	// MaxCustomFieldLabelLength is the length, in characters, of the longest custom field label.
+	MaxCustomFieldLabelLength = 40
+	// MaxCustomFieldValueLength is the length, in characters, of the longest custom field value.
+	MaxCustomFieldValueLength = 200
+
+	// customFieldDateLayout is the layout of date custom field values.
+	customFieldDateLayout = "2006-01-02"
+)
+
+// CustomFieldType is the kind of value a custom field holds, which decides how it is validated and displayed.
+type CustomFieldType string
+
+const (
+	// CustomFieldTypeText - Free text, such as an order or seat number
+	CustomFieldTypeText CustomFieldType = "text"
+	// CustomFieldTypeNumber - A decimal number
+	CustomFieldTypeNumber CustomFieldType = "number"
+	// CustomFieldTypeDate - A calendar date written as YYYY-MM-DD
+	CustomFieldTypeDate CustomFieldType = "date"
+	// CustomFieldTypeURL - An absolute http or https URL, displayed as a link
+	CustomFieldTypeURL CustomFieldType = "url"
+)
+
+// IsValid returns true if the custom field type is valid.
+func (t CustomFieldType) IsValid() bool {
+	switch t {
+	case CustomFieldTypeText, CustomFieldTypeNumber, CustomFieldTypeDate, CustomFieldTypeURL:
+		return true
+	default:
+		return false
+	}
+}
+
+// CustomFieldVisibility is where a custom field is shown to the customer. Webhooks and the merchant API always
+// carry every field.
+type CustomFieldVisibility string
+
+const (
+	// CustomFieldVisibilityAll - Shown on the checkout page and on receipts
+	CustomFieldVisibilityAll CustomFieldVisibility = "all"
+	// CustomFieldVisibilityCheckout - Shown on the checkout page only
+	CustomFieldVisibilityCheckout CustomFieldVisibility = "checkout"
+	// CustomFieldVisibilityReceipt - Shown on receipts only
+	CustomFieldVisibilityReceipt CustomFieldVisibility = "receipt"
+)
+
+// IsValid returns true if the custom field visibility is valid.
+func (v CustomFieldVisibility) IsValid() bool {
+	switch v {
+	case CustomFieldVisibilityAll, CustomFieldVisibilityCheckout, CustomFieldVisibilityReceipt:
+		return true
+	default:
+		return false
+	}
+}
+
+// OnCheckout reports whether fields with the visibility are shown on the checkout page.
+func (v CustomFieldVisibility) OnCheckout() bool {
+	return v == CustomFieldVisibilityAll || v == CustomFieldVisibilityCheckout
+}
+
+// OnReceipt reports whether fields with the visibility are shown on receipts.
+func (v CustomFieldVisibility) OnReceipt() bool {
+	return v == CustomFieldVisibilityAll || v == CustomFieldVisibilityReceipt
+}
+
+// CustomField is a labelled value a merchant shows customers on an invoice, such as an order or seat number.
+// Unlike metadata, which is internal to the merchant, custom fields are meant to be seen.
+type CustomField struct {
+	label      string
+	value      string
+	fieldType  CustomFieldType
+	visibility CustomFieldVisibility
+}
+
+// NewCustomField creates a custom field, checking its value against its type. An empty type is text and an
+// empty visibility is all.
+func NewCustomField(
+	label, value string,
+	fieldType CustomFieldType,
+	visibility CustomFieldVisibility,
+) (*CustomField, error) {
+	if fieldType == "" {
+		fieldType = CustomFieldTypeText
+	}
+	if visibility == "" {
+		visibility = CustomFieldVisibilityAll
+	}
+	if !fieldType.IsValid() {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidCustomField, fieldType)
+	}
+	if !visibility.IsValid() {
+		return nil, fmt.Errorf("%w: unknown visibility %q", ErrInvalidCustomField, visibility)
+	}
+
+	label = strings.TrimSpace(label)
+	if err := validateCustomFieldText("label", label, MaxCustomFieldLabelLength); err != nil {
+		return nil, err
+	}
+	value = strings.TrimSpace(value)
+	if err := validateCustomFieldText(label, value, MaxCustomFieldValueLength); err != nil {
+		return nil, err
+	}
+	if err := validateCustomFieldValue(label, value, fieldType); err != nil {
+		return nil, err
+	}
+
+	return &CustomField{
+		label:      label,
+		value:      value,
+		fieldType:  fieldType,
+		visibility: visibility,
+	}, nil
+}
+
+// Label returns the label the field is shown with.
+func (f *CustomField) Label() string {
+	return f.label
+}
+
+// Value returns the value of the field.
+func (f *CustomField) Value() string {
+	return f.value
+}
+
+// Type returns the type of the field's value.
+func (f *CustomField) Type() CustomFieldType {
+	return f.fieldType
+}
+
+// Visibility returns where the field is shown to the customer.
+func (f *CustomField) Visibility() CustomFieldVisibility {
+	return f.visibility
+}
+
+// CustomFields returns the custom fields of the invoice, in the order the merchant gave them.
+func (i *Invoice) CustomFields() []*CustomField {
+	return i.customFields
+}
+
+// CheckoutFields returns the custom fields shown on the checkout page.
+func (i *Invoice) CheckoutFields() []*CustomField {
+	return filterCustomFields(i.customFields, CustomFieldVisibility.OnCheckout)
+}
+
+// ReceiptFields returns the custom fields shown on receipts.
+func (i *Invoice) ReceiptFields() []*CustomField {
+	return filterCustomFields(i.customFields, CustomFieldVisibility.OnReceipt)
+}
+
+// SetCustomFields sets the custom fields of the invoice (for creation and repository restoration).
+func (i *Invoice) SetCustomFields(fields []*CustomField) {
+	i.customFields = fields
+}
+
+// ValidateCustomFields checks that an invoice's custom fields are within the limit and have distinct labels.
+func ValidateCustomFields(fields []*CustomField) error {
+	if len(fields) > MaxCustomFields {
+		return fmt.Errorf("%w: at most %d custom fields are allowed", ErrInvalidCustomField, MaxCustomFields)
+	}
+	labels := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if field == nil {
+			return fmt.Errorf("%w: custom field cannot be empty", ErrInvalidCustomField)
+		}
+		key := strings.ToLower(field.Label())
+		if labels[key] {
+			return fmt.Errorf("%w: duplicate label %q", ErrInvalidCustomField, field.Label())
+		}
+		labels[key] = true
+	}
+	return nil
+}
+
+// filterCustomFields returns the fields whose visibility is accepted by shown.
+func filterCustomFields(fields []*CustomField, shown func(CustomFieldVisibility) bool) []*CustomField {
+	var visible []*CustomField
+	for _, field := range fields {
+		if shown(field.Visibility()) {
+			visible = append(visible, field)
+		}
+	}
+	return visible
+}
+
+// validateCustomFieldText checks that a label or value is present, short enough and printable on one line.
+func validateCustomFieldText(name, text string, maxLength int) error {
+	if text == "" {
+		return fmt.Errorf("%w: %s cannot be empty", ErrInvalidCustomField, name)
+	}
+	if !utf8.ValidString(text) || utf8.RuneCountInString(text) > maxLength {
+		return fmt.Errorf("%w: %s cannot exceed %d characters", ErrInvalidCustomField, name, maxLength)
+	}
+	for _, r := range text {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("%w: %s cannot contain control characters", ErrInvalidCustomField, name)
+		}
+	}
+	return nil
+}
+
+// validateCustomFieldValue checks that a value is of the field's type.
+func validateCustomFieldValue(label, value string, fieldType CustomFieldType) error {
+	switch fieldType {
+	case CustomFieldTypeNumber:
+		if _, err := decimal.NewFromString(value); err != nil {
+			return fmt.Errorf("%w: %s must be a number", ErrInvalidCustomField, label)
+		}
+	case CustomFieldTypeDate:
+		if _, err := time.Parse(customFieldDateLayout, value); err != nil {
+			return fmt.Errorf("%w: %s must be a date written as YYYY-MM-DD", ErrInvalidCustomField, label)
+		}
+	case CustomFieldTypeURL:
+		parsed, err := url.Parse(value)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return fmt.Errorf("%w: %s must be an absolute http or https URL", ErrInvalidCustomField, label)
+		}
+	case CustomFieldTypeText:
+	}
+	return nil
+}
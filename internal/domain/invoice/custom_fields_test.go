@@ -0,0 +1,96 @@
+package invoice_test
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomField(t *testing.T) {
+	t.Run("defaults to visible text", func(t *testing.T) {
+		field, err := invoice.NewCustomField("  Order number ", " A-1042 ", "", "")
+		require.NoError(t, err)
+		require.Equal(t, "Order number", field.Label())
+		require.Equal(t, "A-1042", field.Value())
+		require.Equal(t, invoice.CustomFieldTypeText, field.Type())
+		require.Equal(t, invoice.CustomFieldVisibilityAll, field.Visibility())
+	})
+
+	t.Run("checks values against their type", func(t *testing.T) {
+		valid := []struct {
+			fieldType invoice.CustomFieldType
+			value     string
+		}{
+			{invoice.CustomFieldTypeNumber, "12.50"},
+			{invoice.CustomFieldTypeDate, "2025-02-01"},
+			{invoice.CustomFieldTypeURL, "https://example.com/tickets/42"},
+		}
+		for _, tc := range valid {
+			_, err := invoice.NewCustomField("Field", tc.value, tc.fieldType, "")
+			require.NoError(t, err, tc.value)
+		}
+
+		invalid := []struct {
+			fieldType invoice.CustomFieldType
+			value     string
+		}{
+			{invoice.CustomFieldTypeNumber, "twelve"},
+			{invoice.CustomFieldTypeDate, "2025-02-30"},
+			{invoice.CustomFieldTypeDate, "01/02/2025"},
+			{invoice.CustomFieldTypeURL, "javascript:alert(1)"},
+			{invoice.CustomFieldTypeURL, "/tickets/42"},
+			{"color", "red"},
+		}
+		for _, tc := range invalid {
+			_, err := invoice.NewCustomField("Field", tc.value, tc.fieldType, "")
+			require.ErrorIs(t, err, invoice.ErrInvalidCustomField, tc.value)
+		}
+	})
+
+	t.Run("rejects empty, long and multi-line text", func(t *testing.T) {
+		for _, tc := range []struct{ label, value string }{
+			{"", "A-1042"},
+			{"Order", "  "},
+			{strings.Repeat("x", invoice.MaxCustomFieldLabelLength+1), "A-1042"},
+			{"Order", strings.Repeat("x", invoice.MaxCustomFieldValueLength+1)},
+			{"Order", "A-1042\nB-7"},
+		} {
+			_, err := invoice.NewCustomField(tc.label, tc.value, "", "")
+			require.ErrorIs(t, err, invoice.ErrInvalidCustomField)
+		}
+
+		_, err := invoice.NewCustomField("Order", "A-1042", "", "internal")
+		require.ErrorIs(t, err, invoice.ErrInvalidCustomField)
+	})
+
+	t.Run("ValidateCustomFields limits count and duplicate labels", func(t *testing.T) {
+		fields := make([]*invoice.CustomField, invoice.MaxCustomFields)
+		for i := range fields {
+			field, err := invoice.NewCustomField(strings.Repeat("L", i+1), "value", "", "")
+			require.NoError(t, err)
+			fields[i] = field
+		}
+		require.NoError(t, invoice.ValidateCustomFields(fields))
+
+		extra, _ := invoice.NewCustomField("Extra", "value", "", "")
+		require.ErrorIs(t, invoice.ValidateCustomFields(append(fields, extra)), invoice.ErrInvalidCustomField)
+
+		seat, _ := invoice.NewCustomField("Seat", "F12", "", "")
+		again, _ := invoice.NewCustomField("SEAT", "F13", "", "")
+		require.ErrorIs(t, invoice.ValidateCustomFields([]*invoice.CustomField{seat, again}), invoice.ErrInvalidCustomField)
+	})
+
+	t.Run("visibility decides checkout and receipt fields", func(t *testing.T) {
+		all, _ := invoice.NewCustomField("Order", "A-1042", "", invoice.CustomFieldVisibilityAll)
+		checkout, _ := invoice.NewCustomField("Seat", "F12", "", invoice.CustomFieldVisibilityCheckout)
+		receipt, _ := invoice.NewCustomField("Gate", "3", "", invoice.CustomFieldVisibilityReceipt)
+
+		inv := &invoice.Invoice{}
+		inv.SetCustomFields([]*invoice.CustomField{all, checkout, receipt})
+		require.Equal(t, []*invoice.CustomField{all, checkout, receipt}, inv.CustomFields())
+		require.Equal(t, []*invoice.CustomField{all, checkout}, inv.CheckoutFields())
+		require.Equal(t, []*invoice.CustomField{all, receipt}, inv.ReceiptFields())
+	})
+}
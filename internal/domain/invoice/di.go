@@ -11,5 +11,7 @@ var Module = fx.Module("invoice-service",
 			NewInvoiceService,
 			fx.As(new(InvoiceService)),
 		),
+		NewRequoter,
 	),
+	fx.Invoke(RegisterRequoter, RegisterRefundSenderHandler, RegisterPaymentReversalHandler),
 )
@@ -0,0 +1,257 @@
+package invoice
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// maxDiscountPercentage is the largest percentage discount that can be granted.
+var maxDiscountPercentage = decimal.NewFromInt(100)
+
+// Discount is a fixed amount or percentage taken off a line item, an invoice or through a coupon.
+type Discount struct {
+	discountType DiscountType
+	value        decimal.Decimal
+}
+
+// NewDiscount creates a new Discount.
+// Fixed values are in the invoice currency; percentage values range from 0 to 100.
+func NewDiscount(discountType DiscountType, value string) (*Discount, error) {
+	if !discountType.IsValid() {
+		return nil, fmt.Errorf("%w: unknown discount type %q", ErrInvalidDiscount, discountType)
+	}
+
+	parsed, err := decimal.NewFromString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid discount value format", ErrInvalidDiscount)
+	}
+
+	if !parsed.IsPositive() {
+		return nil, fmt.Errorf("%w: discount value must be positive", ErrInvalidDiscount)
+	}
+
+	if discountType == DiscountTypePercentage && parsed.GreaterThan(maxDiscountPercentage) {
+		return nil, fmt.Errorf("%w: percentage cannot exceed 100", ErrInvalidDiscount)
+	}
+
+	return &Discount{
+		discountType: discountType,
+		value:        parsed,
+	}, nil
+}
+
+// Type returns the discount type.
+func (d *Discount) Type() DiscountType {
+	return d.discountType
+}
+
+// Value returns the fixed amount or percentage.
+func (d *Discount) Value() decimal.Decimal {
+	return d.value
+}
+
+// AmountOf returns the amount the discount takes off the given amount, capped at the amount itself.
+func (d *Discount) AmountOf(amount *shared.Money) (*shared.Money, error) {
+	discount := d.value
+	if d.discountType == DiscountTypePercentage {
+		discount = amount.Amount().Mul(d.value).Div(maxDiscountPercentage)
+	}
+
+	discount = discount.Round(2)
+	if discount.GreaterThan(amount.Amount()) {
+		discount = amount.Amount()
+	}
+
+	return shared.NewMoney(discount.StringFixed(2), shared.Currency(amount.Currency()))
+}
+
+// String returns the string representation of the discount.
+func (d *Discount) String() string {
+	if d.discountType == DiscountTypePercentage {
+		return d.value.String() + "%"
+	}
+	return d.value.StringFixed(2)
+}
+
+// Equals returns true if this discount equals the other.
+func (d *Discount) Equals(other *Discount) bool {
+	if other == nil {
+		return false
+	}
+	return d.discountType == other.discountType && d.value.Equal(other.value)
+}
+
+// AppliedCoupon is a coupon code redeemed on an invoice together with the discount it granted.
+type AppliedCoupon struct {
+	code     string
+	discount *Discount
+}
+
+// NewAppliedCoupon creates a new AppliedCoupon.
+func NewAppliedCoupon(code string, discount *Discount) (*AppliedCoupon, error) {
+	if code == "" {
+		return nil, fmt.Errorf("%w: coupon code is required", ErrInvalidDiscount)
+	}
+	if discount == nil {
+		return nil, fmt.Errorf("%w: coupon discount is required", ErrInvalidDiscount)
+	}
+
+	return &AppliedCoupon{
+		code:     code,
+		discount: discount,
+	}, nil
+}
+
+// Code returns the coupon code.
+func (c *AppliedCoupon) Code() string {
+	return c.code
+}
+
+// Discount returns the discount granted by the coupon.
+func (c *AppliedCoupon) Discount() *Discount {
+	return c.discount
+}
+
+// calculateDiscounts returns the invoice-level and coupon discount amounts for a subtotal.
+// The coupon applies to what is left after the invoice-level discount.
+func calculateDiscounts(
+	subtotal *shared.Money,
+	discount *Discount,
+	coupon *AppliedCoupon,
+) (*shared.Money, *shared.Money, error) {
+	zero, err := shared.NewMoney("0.00", shared.Currency(subtotal.Currency()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	invoiceAmount, couponAmount := zero, zero
+	if discount != nil {
+		if invoiceAmount, err = discount.AmountOf(subtotal); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if coupon != nil {
+		remaining, err := subtotal.Subtract(invoiceAmount)
+		if err != nil {
+			return nil, nil, err
+		}
+		if couponAmount, err = coupon.Discount().AmountOf(remaining); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return invoiceAmount, couponAmount, nil
+}
+
+// Discount returns the invoice-level discount, or nil if none was granted.
+func (i *Invoice) Discount() *Discount {
+	return i.discount
+}
+
+// Coupon returns the coupon applied to the invoice, or nil if none was applied.
+func (i *Invoice) Coupon() *AppliedCoupon {
+	return i.coupon
+}
+
+// ItemDiscountAmount returns the sum of the line item discounts.
+func (i *Invoice) ItemDiscountAmount() *shared.Money {
+	total := decimal.Zero
+	for _, item := range i.items {
+		total = total.Add(item.DiscountAmount().Amount())
+	}
+	amount, _ := shared.NewMoney(total.StringFixed(2), shared.Currency(i.pricing.Subtotal().Currency()))
+	return amount
+}
+
+// InvoiceDiscountAmount returns the amount taken off the subtotal by the invoice-level discount.
+func (i *Invoice) InvoiceDiscountAmount() (*shared.Money, error) {
+	invoiceAmount, _, err := calculateDiscounts(i.pricing.Subtotal(), i.discount, i.coupon)
+	return invoiceAmount, err
+}
+
+// CouponDiscountAmount returns the amount taken off the subtotal by the applied coupon.
+func (i *Invoice) CouponDiscountAmount() (*shared.Money, error) {
+	_, couponAmount, err := calculateDiscounts(i.pricing.Subtotal(), i.discount, i.coupon)
+	return couponAmount, err
+}
+
+// ApplyCoupon applies a coupon discount to an unpaid invoice and reprices it.
+// Per-item tax lines are recalculated; a flat tax is scaled with the taxable amount so it keeps its rate. A fee
+// passed through to the customer is recalculated at its quoted percentage.
+func (i *Invoice) ApplyCoupon(coupon *AppliedCoupon) error {
+	if coupon == nil {
+		return fmt.Errorf("%w: coupon is required", ErrInvalidDiscount)
+	}
+	if i.coupon != nil {
+		return ErrCouponAlreadyApplied
+	}
+	if (i.status != StatusCreated && i.status != StatusPending) || i.amountPaid != nil {
+		return ErrCannotApplyCoupon
+	}
+	if i.expiration != nil && i.expiration.IsExpired() {
+		return ErrCannotApplyCoupon
+	}
+
+	subtotal := i.pricing.Subtotal()
+	invoiceAmount, couponAmount, err := calculateDiscounts(subtotal, i.discount, coupon)
+	if err != nil {
+		return err
+	}
+
+	discount, err := invoiceAmount.Add(couponAmount)
+	if err != nil {
+		return err
+	}
+
+	taxable, err := subtotal.Subtract(discount)
+	if err != nil {
+		return err
+	}
+
+	tax := i.pricing.Tax()
+	if i.hasTaxLines() {
+		if tax, err = recalculateTaxLines(i.items, discount); err != nil {
+			return err
+		}
+	} else if previousTaxable := i.pricing.TaxableAmount(); !previousTaxable.IsZero() {
+		scaled := tax.Amount().Mul(taxable.Amount()).Div(previousTaxable.Amount())
+		if tax, err = shared.NewMoney(scaled.StringFixed(2), shared.Currency(tax.Currency())); err != nil {
+			return err
+		}
+	}
+
+	total, err := taxable.Add(tax)
+	if err != nil {
+		return err
+	}
+
+	pricing, err := NewInvoicePricingWithDiscount(subtotal, discount, tax, total)
+	if err != nil {
+		return err
+	}
+	if i.feePassThrough != nil {
+		if pricing, err = i.feePassThrough.Price(pricing); err != nil {
+			return err
+		}
+	}
+
+	i.pricing = pricing
+	i.coupon = coupon
+	i.updatedAt = shared.Now().UTC()
+	return nil
+}
+
+// SetDiscount sets the invoice-level discount (for creation and repository restoration).
+// It does not reprice the invoice.
+func (i *Invoice) SetDiscount(discount *Discount) {
+	i.discount = discount
+}
+
+// SetCoupon sets the applied coupon (for creation and repository restoration).
+// It does not reprice the invoice; use ApplyCoupon for that.
+func (i *Invoice) SetCoupon(coupon *AppliedCoupon) {
+	i.coupon = coupon
+}
@@ -0,0 +1,113 @@
+package invoice_test
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCoupon(t *testing.T, discountType invoice.DiscountType, value string) *invoice.AppliedCoupon {
+	discount, err := invoice.NewDiscount(discountType, value)
+	require.NoError(t, err)
+	coupon, err := invoice.NewAppliedCoupon("SPRING10", discount)
+	require.NoError(t, err)
+	return coupon
+}
+
+func TestDiscount(t *testing.T) {
+	t.Run("validates type and value", func(t *testing.T) {
+		_, err := invoice.NewDiscount("bogus", "10")
+		require.ErrorIs(t, err, invoice.ErrInvalidDiscount)
+
+		_, err = invoice.NewDiscount(invoice.DiscountTypeFixed, "0")
+		require.ErrorIs(t, err, invoice.ErrInvalidDiscount)
+
+		_, err = invoice.NewDiscount(invoice.DiscountTypePercentage, "100.5")
+		require.ErrorIs(t, err, invoice.ErrInvalidDiscount)
+
+		_, err = invoice.NewDiscount(invoice.DiscountTypePercentage, "100")
+		require.NoError(t, err)
+	})
+
+	t.Run("AmountOf rounds percentages and caps at the amount", func(t *testing.T) {
+		amount, _ := shared.NewMoney("19.99", shared.CurrencyUSD)
+
+		percentage, _ := invoice.NewDiscount(invoice.DiscountTypePercentage, "15")
+		discount, err := percentage.AmountOf(amount)
+		require.NoError(t, err)
+		require.Equal(t, "3.00", discount.String())
+
+		fixed, _ := invoice.NewDiscount(invoice.DiscountTypeFixed, "25")
+		discount, err = fixed.AmountOf(amount)
+		require.NoError(t, err)
+		require.Equal(t, "19.99", discount.String())
+	})
+
+	t.Run("item discounts reduce the item total", func(t *testing.T) {
+		unitPrice, _ := shared.NewMoney("10.00", shared.CurrencyUSD)
+		discount, _ := invoice.NewDiscount(invoice.DiscountTypePercentage, "10")
+
+		item, err := invoice.NewInvoiceItemWithDiscount("Widget", "", "3", unitPrice, discount)
+		require.NoError(t, err)
+		require.Equal(t, "3.00", item.DiscountAmount().String())
+		require.Equal(t, "27.00", item.TotalPrice().String())
+	})
+
+	t.Run("pricing rejects a discount above the subtotal", func(t *testing.T) {
+		subtotal, _ := shared.NewMoney("10.00", shared.CurrencyUSD)
+		discount, _ := shared.NewMoney("11.00", shared.CurrencyUSD)
+		tax, _ := shared.NewMoney("0.00", shared.CurrencyUSD)
+
+		_, err := invoice.NewInvoicePricingWithDiscount(subtotal, discount, tax, subtotal)
+		require.Error(t, err)
+	})
+}
+
+func TestInvoiceApplyCoupon(t *testing.T) {
+	t.Run("reprices the invoice and scales tax", func(t *testing.T) {
+		testInvoice := createTestInvoice()
+
+		require.NoError(t, testInvoice.ApplyCoupon(newTestCoupon(t, invoice.DiscountTypePercentage, "10")))
+
+		pricing := testInvoice.Pricing()
+		require.Equal(t, "100.00", pricing.Subtotal().String())
+		require.Equal(t, "10.00", pricing.Discount().String())
+		require.Equal(t, "9.00", pricing.Tax().String())
+		require.Equal(t, "99.00", pricing.Total().String())
+		require.Equal(t, "SPRING10", testInvoice.Coupon().Code())
+
+		couponAmount, err := testInvoice.CouponDiscountAmount()
+		require.NoError(t, err)
+		require.Equal(t, "10.00", couponAmount.String())
+	})
+
+	t.Run("applies after the invoice-level discount", func(t *testing.T) {
+		testInvoice := createTestInvoice()
+		discount, _ := invoice.NewDiscount(invoice.DiscountTypeFixed, "20")
+		testInvoice.SetDiscount(discount)
+
+		require.NoError(t, testInvoice.ApplyCoupon(newTestCoupon(t, invoice.DiscountTypePercentage, "50")))
+
+		couponAmount, err := testInvoice.CouponDiscountAmount()
+		require.NoError(t, err)
+		require.Equal(t, "40.00", couponAmount.String())
+		require.Equal(t, "60.00", testInvoice.Pricing().Discount().String())
+	})
+
+	t.Run("rejects a second coupon", func(t *testing.T) {
+		testInvoice := createTestInvoice()
+		require.NoError(t, testInvoice.ApplyCoupon(newTestCoupon(t, invoice.DiscountTypeFixed, "5")))
+
+		err := testInvoice.ApplyCoupon(newTestCoupon(t, invoice.DiscountTypeFixed, "5"))
+		require.ErrorIs(t, err, invoice.ErrCouponAlreadyApplied)
+	})
+
+	t.Run("rejects paid invoices", func(t *testing.T) {
+		testInvoice := createPaidTestInvoice(t, "0.0022")
+
+		err := testInvoice.ApplyCoupon(newTestCoupon(t, invoice.DiscountTypeFixed, "5"))
+		require.ErrorIs(t, err, invoice.ErrCannotApplyCoupon)
+	})
+}
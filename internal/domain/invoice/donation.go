@@ -0,0 +1,99 @@
+package invoice
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+)
+
+// Type returns how the amount due on the invoice is determined.
+func (i *Invoice) Type() InvoiceType {
+	if i.invoiceType == "" {
+		return InvoiceTypeStandard
+	}
+	return i.invoiceType
+}
+
+// IsDonation returns true if the customer chooses the amount and any payment at or above the minimum settles it.
+func (i *Invoice) IsDonation() bool {
+	return i.Type() == InvoiceTypeDonation
+}
+
+// MinimumAmount returns the smallest amount that settles a donation invoice, or nil for standard invoices.
+func (i *Invoice) MinimumAmount() *shared.Money {
+	return i.minimumAmount
+}
+
+// MinimumCryptoAmount returns the minimum amount in the invoice cryptocurrency.
+// It is converted at the locked rate, even once the rate has expired, so late payments are judged
+// against the minimum the customer was shown.
+func (i *Invoice) MinimumCryptoAmount() (*shared.Money, error) {
+	if i.minimumAmount == nil {
+		return nil, ErrNotDonation
+	}
+	minimum := i.minimumAmount.Amount().Mul(i.exchangeRate.Rate())
+	return shared.NewMoneyWithCrypto(minimum.String(), i.cryptoCurrency)
+}
+
+// ChooseAmount reprices an unpaid donation invoice to the amount the customer chose on the checkout page.
+// The chosen amount is what the customer is asked to send; any payment at or above the minimum still settles it.
+func (i *Invoice) ChooseAmount(amount *shared.Money) error {
+	if !i.IsDonation() {
+		return ErrNotDonation
+	}
+	if amount == nil {
+		return ErrInvalidAmount
+	}
+	if (i.status != StatusCreated && i.status != StatusPending) || i.amountPaid != nil {
+		return ErrCannotChooseAmount
+	}
+	if i.expiration != nil && i.expiration.IsExpired() {
+		return ErrCannotChooseAmount
+	}
+	if amount.Currency() != i.minimumAmount.Currency() {
+		return ErrCurrencyMismatch
+	}
+	if amount.LessThan(i.minimumAmount) {
+		return fmt.Errorf("%w: at least %s %s", ErrBelowMinimumAmount, i.minimumAmount, i.minimumAmount.Currency())
+	}
+
+	items, pricing, err := donationItemsAndPricing(i.title, amount)
+	if err != nil {
+		return err
+	}
+
+	i.items = items
+	i.pricing = pricing
+	i.updatedAt = shared.Now().UTC()
+	return nil
+}
+
+// SetType sets the invoice type (for creation and repository restoration).
+func (i *Invoice) SetType(invoiceType InvoiceType) {
+	i.invoiceType = invoiceType
+}
+
+// SetMinimumAmount sets the donation minimum (for creation and repository restoration).
+// It does not reprice the invoice; use ChooseAmount for that.
+func (i *Invoice) SetMinimumAmount(minimum *shared.Money) {
+	i.minimumAmount = minimum
+}
+
+// donationItemsAndPricing prices a donation as a single untaxed, undiscounted item named after the invoice.
+func donationItemsAndPricing(title string, amount *shared.Money) ([]*InvoiceItem, *InvoicePricing, error) {
+	item, err := NewInvoiceItem(title, "", "1", amount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	zero, err := shared.NewMoney("0", shared.Currency(amount.Currency()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pricing, err := NewInvoicePricing(amount, zero, amount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []*InvoiceItem{item}, pricing, nil
+}
@@ -0,0 +1,76 @@
+package invoice_test
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createDonationTestInvoice(t *testing.T, minimum string) *invoice.Invoice {
+	testInvoice := createTestInvoice()
+	minimumAmount, err := shared.NewMoney(minimum, shared.CurrencyUSD)
+	require.NoError(t, err)
+	testInvoice.SetType(invoice.InvoiceTypeDonation)
+	testInvoice.SetMinimumAmount(minimumAmount)
+	return testInvoice
+}
+
+func TestInvoiceDonation(t *testing.T) {
+	t.Run("standard invoices reject a chosen amount", func(t *testing.T) {
+		testInvoice := createTestInvoice()
+		require.Equal(t, invoice.InvoiceTypeStandard, testInvoice.Type())
+		require.False(t, testInvoice.IsDonation())
+
+		amount, _ := shared.NewMoney("25.00", shared.CurrencyUSD)
+		require.ErrorIs(t, testInvoice.ChooseAmount(amount), invoice.ErrNotDonation)
+
+		_, err := testInvoice.MinimumCryptoAmount()
+		require.ErrorIs(t, err, invoice.ErrNotDonation)
+	})
+
+	t.Run("minimum converts at the locked rate", func(t *testing.T) {
+		testInvoice := createDonationTestInvoice(t, "10.00")
+
+		minimum, err := testInvoice.MinimumCryptoAmount()
+		require.NoError(t, err)
+		require.Equal(t, shared.CryptoCurrencyBTC.String(), minimum.Currency())
+		require.Equal(t, "500000", minimum.Amount().String())
+	})
+
+	t.Run("ChooseAmount reprices to a single untaxed item", func(t *testing.T) {
+		testInvoice := createDonationTestInvoice(t, "10.00")
+
+		amount, _ := shared.NewMoney("25.00", shared.CurrencyUSD)
+		require.NoError(t, testInvoice.ChooseAmount(amount))
+
+		require.Len(t, testInvoice.Items(), 1)
+		require.Equal(t, "Test Invoice", testInvoice.Items()[0].Name())
+		require.Equal(t, "25.00", testInvoice.Pricing().Subtotal().String())
+		require.Equal(t, "0.00", testInvoice.Pricing().Tax().String())
+		require.Equal(t, "25.00", testInvoice.Pricing().Total().String())
+	})
+
+	t.Run("ChooseAmount enforces the minimum and currency", func(t *testing.T) {
+		testInvoice := createDonationTestInvoice(t, "10.00")
+
+		below, _ := shared.NewMoney("9.99", shared.CurrencyUSD)
+		require.ErrorIs(t, testInvoice.ChooseAmount(below), invoice.ErrBelowMinimumAmount)
+
+		euros, _ := shared.NewMoney("50.00", shared.CurrencyEUR)
+		require.ErrorIs(t, testInvoice.ChooseAmount(euros), invoice.ErrCurrencyMismatch)
+
+		minimum, _ := shared.NewMoney("10.00", shared.CurrencyUSD)
+		require.NoError(t, testInvoice.ChooseAmount(minimum))
+	})
+
+	t.Run("ChooseAmount is closed once a payment is received", func(t *testing.T) {
+		testInvoice := createDonationTestInvoice(t, "10.00")
+		paid, _ := shared.NewMoneyWithCrypto("100000", shared.CryptoCurrencyBTC)
+		require.NoError(t, testInvoice.RecordPayment(paid))
+
+		amount, _ := shared.NewMoney("25.00", shared.CurrencyUSD)
+		require.ErrorIs(t, testInvoice.ChooseAmount(amount), invoice.ErrCannotChooseAmount)
+	})
+}
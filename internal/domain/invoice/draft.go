@@ -0,0 +1,408 @@
+package invoice
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// taxRatePrecision is the number of decimal places kept when a flat tax is converted to a rate.
+const taxRatePrecision = 6
+
+// DraftInvoiceValidation represents the validation structure for draft invoice creation.
+type DraftInvoiceValidation struct {
+	ID               string                 `validate:"required,min=1"      json:"id"`
+	MerchantID       string                 `validate:"required,min=1"      json:"merchant_id"`
+	Title            string                 `validate:"required,min=1"      json:"title"`
+	Items            []*InvoiceItem         `validate:"required,min=1,dive" json:"items"`
+	Pricing          *InvoicePricing        `validate:"required"            json:"pricing"`
+	CryptoCurrency   shared.CryptoCurrency  `validate:"required"            json:"crypto_currency"`
+	PaymentTolerance *PaymentTolerance      `validate:"required"            json:"payment_tolerance"`
+	Expiration       *InvoiceExpiration     `validate:"required"            json:"expiration"`
+	Metadata         map[string]interface{} `                               json:"metadata"`
+}
+
+// NewDraftInvoice creates an invoice in the draft status. A draft is paid on the given network once finalized;
+// until then it has no payment address or exchange rate, and its expiration only records how long the invoice
+// stays payable after finalization.
+func NewDraftInvoice(
+	id, merchantID, title, description string,
+	items []*InvoiceItem,
+	pricing *InvoicePricing,
+	cryptoCurrency shared.CryptoCurrency,
+	network shared.BlockchainNetwork,
+	paymentTolerance *PaymentTolerance,
+	expiration *InvoiceExpiration,
+	metadata map[string]interface{},
+) (*Invoice, error) {
+	validation := DraftInvoiceValidation{
+		ID:               id,
+		MerchantID:       merchantID,
+		Title:            title,
+		Items:            items,
+		Pricing:          pricing,
+		CryptoCurrency:   cryptoCurrency,
+		PaymentTolerance: paymentTolerance,
+		Expiration:       expiration,
+		Metadata:         metadata,
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(validation); err != nil {
+		return nil, err
+	}
+
+	now := shared.Now().UTC()
+	return &Invoice{
+		id:               id,
+		merchantID:       merchantID,
+		title:            title,
+		description:      description,
+		items:            items,
+		pricing:          pricing,
+		cryptoCurrency:   cryptoCurrency,
+		network:          network,
+		status:           StatusDraft,
+		invoiceType:      InvoiceTypeStandard,
+		paymentTolerance: paymentTolerance,
+		expiration:       expiration,
+		createdAt:        now,
+		updatedAt:        now,
+		metadata:         metadata,
+	}, nil
+}
+
+// IsDraft returns true if the invoice is still being prepared.
+func (i *Invoice) IsDraft() bool {
+	return i.status == StatusDraft
+}
+
+// Network returns the network the invoice is paid on: that of its payment address, or for a draft the network
+// resolved when it was created.
+func (i *Invoice) Network() shared.BlockchainNetwork {
+	if i.paymentAddress != nil {
+		return i.paymentAddress.Network()
+	}
+	return i.network
+}
+
+// SetNetwork sets the network of a draft invoice restored from storage.
+func (i *Invoice) SetNetwork(network shared.BlockchainNetwork) {
+	i.network = network
+}
+
+// DraftRevision holds the editable terms of a draft invoice, repriced by the caller.
+type DraftRevision struct {
+	Title           string
+	Description     string
+	CustomerID      *string
+	Items           []*InvoiceItem
+	Pricing         *InvoicePricing
+	TaxTreatment    *TaxTreatment // Nil for a flat tax
+	Expiration      *InvoiceExpiration
+	Metadata        map[string]interface{}
+	CustomFields    []*CustomField
+	SettlementSplit *SettlementSplit
+	FeePassThrough  *FeePassThrough // Nil when the merchant absorbs the platform fee
+}
+
+// Revise replaces the terms of a draft invoice.
+func (i *Invoice) Revise(revision *DraftRevision) error {
+	if !i.IsDraft() {
+		return ErrInvoiceNotDraft
+	}
+	if revision == nil || revision.Title == "" || revision.Pricing == nil || revision.Expiration == nil {
+		return ErrInvalidRequest
+	}
+	if len(revision.Items) == 0 {
+		return ErrNoItems
+	}
+
+	i.title = revision.Title
+	i.description = revision.Description
+	i.customerID = revision.CustomerID
+	i.items = revision.Items
+	i.pricing = revision.Pricing
+	i.taxTreatment = revision.TaxTreatment
+	i.expiration = revision.Expiration
+	i.metadata = revision.Metadata
+	i.customFields = revision.CustomFields
+	i.settlementSplit = revision.SettlementSplit
+	i.feePassThrough = revision.FeePassThrough
+	i.updatedAt = shared.Now().UTC()
+	return nil
+}
+
+// Finalize assigns the payment address and locked exchange rate of a draft invoice and starts its expiration
+// window. The invoice stays a draft until the finalize transition moves it to created.
+func (i *Invoice) Finalize(address *shared.PaymentAddress, rate *shared.ExchangeRate) error {
+	if !i.IsDraft() {
+		return ErrInvoiceNotDraft
+	}
+	if address == nil {
+		return ErrInvalidPaymentAddress
+	}
+	if rate == nil {
+		return ErrInvalidExchangeRate
+	}
+	if string(rate.FromCurrency()) != i.pricing.Total().Currency() || rate.ToCurrency() != i.cryptoCurrency {
+		return ErrCurrencyMismatch
+	}
+
+	i.paymentAddress = address
+	i.exchangeRate = rate
+	i.network = ""
+	i.expiration = NewInvoiceExpiration(i.expiration.Duration())
+	i.updatedAt = shared.Now().UTC()
+	return nil
+}
+
+// createDraft saves a draft invoice for the request. Drafts are not announced until they are finalized.
+func (s *InvoiceServiceImpl) createDraft(
+	ctx context.Context,
+	req *CreateInvoiceRequest,
+	token shared.Token,
+	items []*InvoiceItem,
+	pricing *InvoicePricing,
+	fee *FeePassThrough,
+) (*Invoice, error) {
+	if err := validateInvoiceText(req.Title, req.Description); err != nil {
+		return nil, err
+	}
+
+	invoiceID, err := s.ids.NewID(ctx, shared.IDKindInvoice, req.MerchantID)
+	if err != nil {
+		return nil, err
+	}
+	invoice, err := NewDraftInvoice(
+		invoiceID,
+		req.MerchantID,
+		req.Title,
+		req.Description,
+		items,
+		pricing,
+		req.CryptoCurrency,
+		token.Network,
+		s.getPaymentTolerance(req),
+		s.getExpiration(req),
+		req.Metadata,
+	)
+	if err != nil {
+		return nil, err
+	}
+	applyRequestTerms(invoice, req)
+	invoice.SetFeePassThrough(fee)
+
+	if err := s.repository.Save(ctx, invoice); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// UpdateDraft replaces the given terms of a draft invoice and reprices it.
+func (s *InvoiceServiceImpl) UpdateDraft(ctx context.Context, req *UpdateDraftRequest) (*Invoice, error) {
+	if req == nil || req.InvoiceID == "" {
+		return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+	}
+
+	invoice, err := s.repository.FindByID(ctx, req.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if !invoice.IsDraft() {
+		return nil, ErrInvoiceNotDraft
+	}
+
+	terms, items, pricing, fee, err := s.revisedPricing(ctx, invoice, &req.InvoiceChanges)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateMetadata(ctx, terms.MerchantID, terms.Metadata, terms.RecordedMetadata); err != nil {
+		return nil, err
+	}
+	if err := s.validateSettlementSplit(ctx, terms.MerchantID, terms.SettlementSplit, pricing.Total()); err != nil {
+		return nil, err
+	}
+
+	revision := &DraftRevision{
+		Title:           terms.Title,
+		Description:     terms.Description,
+		CustomerID:      terms.CustomerID,
+		Items:           items,
+		Pricing:         pricing,
+		Expiration:      s.getExpiration(terms),
+		Metadata:        terms.Metadata,
+		CustomFields:    terms.CustomFields,
+		SettlementSplit: terms.SettlementSplit,
+		FeePassThrough:  fee,
+	}
+	if terms.Tax == nil && terms.TaxPolicy != nil {
+		revision.TaxTreatment = terms.TaxPolicy.Treatment()
+	}
+	if err := invoice.Revise(revision); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(ctx, invoice); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// revisedTerms returns the terms of an invoice with the changes applied, keeping its coupon. A flat tax becomes
+// a rate when the items change, so it follows the new taxable amount.
+func revisedTerms(inv *Invoice, changes *InvoiceChanges) (*CreateInvoiceRequest, error) {
+	terms, err := termsRequest(inv)
+	if err != nil {
+		return nil, err
+	}
+	terms.Coupon = inv.Coupon()
+	if terms.Tax != nil {
+		terms.Tax = inv.Pricing().Tax()
+	}
+
+	if changes.Title != nil {
+		terms.Title = *changes.Title
+	}
+	if changes.Description != nil {
+		terms.Description = *changes.Description
+	}
+	if changes.CustomerID != nil {
+		terms.CustomerID = changes.CustomerID
+	}
+	if changes.Items != nil {
+		if terms.Tax != nil {
+			terms.TaxRate = flatTaxRate(inv.Pricing())
+			terms.Tax = nil
+		}
+		terms.Items = changes.Items
+	}
+	if changes.TaxRate != nil {
+		terms.Tax, terms.TaxPolicy, terms.TaxRate = nil, nil, *changes.TaxRate
+	}
+	if changes.TaxPolicy != nil {
+		terms.Tax, terms.TaxPolicy = nil, changes.TaxPolicy
+	}
+	if changes.ExpirationDuration != nil {
+		terms.ExpirationDuration = *changes.ExpirationDuration
+	}
+	if changes.Metadata != nil {
+		terms.Metadata = changes.Metadata
+	}
+	if changes.CustomFields != nil {
+		terms.CustomFields = changes.CustomFields
+	}
+	if changes.PassFee != nil {
+		terms.PassFee = *changes.PassFee
+	}
+	if changes.SplitShares != nil {
+		terms.SettlementSplit = nil
+		if len(changes.SplitShares) > 0 {
+			if terms.SettlementSplit, err = NewSettlementSplit(changes.SplitShares); err != nil {
+				return nil, invalidRequest(err)
+			}
+		}
+	}
+	return terms, nil
+}
+
+// revisedPricing returns the terms of an invoice with the changes applied, along with their items and pricing.
+// A fee passed through to the customer is quoted again.
+func (s *InvoiceServiceImpl) revisedPricing(
+	ctx context.Context,
+	inv *Invoice,
+	changes *InvoiceChanges,
+) (*CreateInvoiceRequest, []*InvoiceItem, *InvoicePricing, *FeePassThrough, error) {
+	terms, err := revisedTerms(inv, changes)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if err := s.validateCreateInvoiceRequest(terms); err != nil {
+		return nil, nil, nil, nil, invalidRequest(err)
+	}
+	if err := validateInvoiceText(terms.Title, terms.Description); err != nil {
+		return nil, nil, nil, nil, invalidRequest(err)
+	}
+
+	items, pricing, err := s.buildInvoiceItemsAndPricing(terms)
+	if err != nil {
+		return nil, nil, nil, nil, invalidRequest(err)
+	}
+	pricing, fee, err := s.passFeeThrough(ctx, terms, inv.Network(), pricing)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return terms, items, pricing, fee, nil
+}
+
+// flatTaxRate returns the rate a flat tax charges on the taxable amount, or an empty rate if nothing is taxable.
+func flatTaxRate(pricing *InvoicePricing) string {
+	taxable := pricing.TaxableAmount()
+	if taxable.IsZero() {
+		return ""
+	}
+	return pricing.Tax().Amount().DivRound(taxable.Amount(), taxRatePrecision).String()
+}
+
+// invalidRequest marks err as a problem with the request.
+func invalidRequest(err error) error {
+	if errors.Is(err, ErrInvalidRequest) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+}
+
+// FinalizeInvoice locks the exchange rate of a draft invoice, assigns its payment address and moves it to
+// created, after which it can be paid and expires like any other invoice.
+func (s *InvoiceServiceImpl) FinalizeInvoice(ctx context.Context, id string) (*Invoice, error) {
+	if id == "" {
+		return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+	}
+
+	invoice, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !invoice.IsDraft() {
+		return nil, ErrInvoiceNotDraft
+	}
+
+	token, err := s.ResolveToken(ctx, invoice.MerchantID(), invoice.CryptoCurrency(), invoice.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := s.getExchangeRate(ctx, shared.Currency(invoice.Pricing().Total().Currency()), token.Symbol)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrExchangeRateServiceError, err)
+	}
+	address, err := s.generatePaymentAddress(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := invoice.Finalize(address, rate); err != nil {
+		return nil, err
+	}
+	if err := assignPaymentReference(invoice, token); err != nil {
+		return nil, err
+	}
+
+	// Use FSM to transition from draft to created
+	fsm := NewInvoiceFSM(invoice)
+	if err := fsm.Event(ctx, "finalize"); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(ctx, invoice); err != nil {
+		return nil, err
+	}
+
+	s.publishInvoiceCreated(ctx, invoice)
+	return invoice, nil
+}
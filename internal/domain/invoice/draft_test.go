@@ -0,0 +1,106 @@
+package invoice_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createDraftTestInvoice creates a 110 USD BTC draft that stays payable for two hours once finalized.
+func createDraftTestInvoice(t *testing.T) *invoice.Invoice {
+	subtotal, _ := shared.NewMoney("100.00", shared.CurrencyUSD)
+	tax, _ := shared.NewMoney("10.00", shared.CurrencyUSD)
+	total, _ := shared.NewMoney("110.00", shared.CurrencyUSD)
+	pricing, err := invoice.NewInvoicePricing(subtotal, tax, total)
+	require.NoError(t, err)
+	item, err := invoice.NewInvoiceItem("Test Item", "A test item", "1", subtotal)
+	require.NoError(t, err)
+
+	draft, err := invoice.NewDraftInvoice("test-invoice-id", "test-merchant-id", "Test Invoice", "A test invoice",
+		[]*invoice.InvoiceItem{item}, pricing, shared.CryptoCurrencyBTC, shared.NetworkBitcoin,
+		invoice.DefaultPaymentTolerance(), invoice.NewInvoiceExpiration(2*time.Hour), nil)
+	require.NoError(t, err)
+	return draft
+}
+
+func TestDraftInvoice(t *testing.T) {
+	address, err := shared.NewPaymentAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", shared.NetworkBitcoin)
+	require.NoError(t, err)
+
+	t.Run("draft has no payment address or exchange rate", func(t *testing.T) {
+		draft := createDraftTestInvoice(t)
+		require.True(t, draft.IsDraft())
+		require.Nil(t, draft.PaymentAddress())
+		require.Nil(t, draft.ExchangeRate())
+		require.Equal(t, shared.NetworkBitcoin, draft.Network())
+		require.Equal(t, "Draft", invoice.GetInvoiceDisplayStatus(draft.Status()))
+
+		_, err := draft.LockedCryptoAmount()
+		require.ErrorIs(t, err, invoice.ErrInvalidExchangeRate)
+	})
+
+	t.Run("revise replaces the terms", func(t *testing.T) {
+		draft := createDraftTestInvoice(t)
+		subtotal, _ := shared.NewMoney("40.00", shared.CurrencyUSD)
+		tax, _ := shared.NewMoney("0.00", shared.CurrencyUSD)
+		pricing, err := invoice.NewInvoicePricing(subtotal, tax, subtotal)
+		require.NoError(t, err)
+		unitPrice, _ := shared.NewMoney("20.00", shared.CurrencyUSD)
+		item, err := invoice.NewInvoiceItem("Ebook", "", "2", unitPrice)
+		require.NoError(t, err)
+
+		require.NoError(t, draft.Revise(&invoice.DraftRevision{
+			Title:      "Revised",
+			Items:      []*invoice.InvoiceItem{item},
+			Pricing:    pricing,
+			Expiration: invoice.NewInvoiceExpiration(time.Hour),
+			Metadata:   map[string]interface{}{"order": "1001"},
+		}))
+		require.Equal(t, "Revised", draft.Title())
+		require.Equal(t, "40.00", draft.Pricing().Total().Amount().StringFixed(2))
+		require.Equal(t, time.Hour, draft.Expiration().Duration())
+		require.Equal(t, "1001", draft.Metadata()["order"])
+
+		require.ErrorIs(t, draft.Revise(&invoice.DraftRevision{Title: "No items", Pricing: pricing,
+			Expiration: invoice.NewInvoiceExpiration(time.Hour)}), invoice.ErrNoItems)
+	})
+
+	t.Run("finalize locks the rate and enters the lifecycle", func(t *testing.T) {
+		draft := createDraftTestInvoice(t)
+		require.Error(t, invoice.NewInvoiceFSM(draft).Event(context.Background(), "finalize"))
+
+		require.NoError(t, draft.Finalize(address, newTestRate(t, "0.00002")))
+		require.NoError(t, invoice.NewInvoiceFSM(draft).Event(context.Background(), "finalize"))
+		require.Equal(t, invoice.StatusCreated, draft.Status())
+		require.Equal(t, address, draft.PaymentAddress())
+		require.InDelta(t, (2 * time.Hour).Seconds(), time.Until(draft.Expiration().ExpiresAt()).Seconds(), 5)
+
+		locked, err := draft.LockedCryptoAmount()
+		require.NoError(t, err)
+		require.Equal(t, "0.0022", locked.Amount().String())
+
+		require.ErrorIs(t, draft.Finalize(address, newTestRate(t, "0.00002")), invoice.ErrInvoiceNotDraft)
+		require.ErrorIs(t, draft.Revise(&invoice.DraftRevision{}), invoice.ErrInvoiceNotDraft)
+	})
+
+	t.Run("finalize rejects a rate for another currency", func(t *testing.T) {
+		draft := createDraftTestInvoice(t)
+		usdtRate, err := shared.NewExchangeRate("1.0", shared.CurrencyUSD, shared.CryptoCurrencyUSDT, "test-source",
+			30*time.Minute)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, draft.Finalize(address, usdtRate), invoice.ErrCurrencyMismatch)
+		require.ErrorIs(t, draft.Finalize(nil, newTestRate(t, "0.00002")), invoice.ErrInvalidPaymentAddress)
+		require.True(t, draft.IsDraft())
+	})
+
+	t.Run("draft can be cancelled", func(t *testing.T) {
+		draft := createDraftTestInvoice(t)
+		require.NoError(t, invoice.NewInvoiceFSM(draft).Event(context.Background(), "cancel"))
+		require.Equal(t, invoice.StatusCancelled, draft.Status())
+	})
+}
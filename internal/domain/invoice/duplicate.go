@@ -0,0 +1,172 @@
+package invoice
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+)
+
+// DuplicateOfMetadataKey is the metadata key recording the invoice a duplicate was created from.
+const DuplicateOfMetadataKey = "duplicate_of"
+
+// DuplicateInvoice creates a new invoice with the items, discounts, taxes and customer of an existing invoice.
+// The duplicate is quoted at the current exchange rate, gets a fresh payment address and expires after the
+// same window as the original, and a platform fee passed through to the customer is quoted again. Coupons are not
+// carried over, since each use counts against the coupon's redemption limit.
+func (s *InvoiceServiceImpl) DuplicateInvoice(ctx context.Context, id string) (*Invoice, error) {
+	if id == "" {
+		return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+	}
+
+	original, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := duplicateRequest(original)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreateInvoice(ctx, req)
+}
+
+// duplicateRequest builds the request that recreates an invoice's terms. A draft is duplicated as a draft.
+func duplicateRequest(original *Invoice) (*CreateInvoiceRequest, error) {
+	req, err := termsRequest(original)
+	if err != nil {
+		return nil, err
+	}
+	if req.Metadata == nil {
+		req.Metadata = make(map[string]interface{}, 1)
+	}
+	req.Metadata[DuplicateOfMetadataKey] = original.ID()
+	if req.RecordedMetadata == nil {
+		req.RecordedMetadata = make(map[string]interface{}, 1)
+	}
+	req.RecordedMetadata[DuplicateOfMetadataKey] = original.ID()
+	req.Draft = original.IsDraft()
+	return req, nil
+}
+
+// termsRequest builds the request that prices an invoice's items, discounts and taxes again, without its coupon.
+func termsRequest(original *Invoice) (*CreateInvoiceRequest, error) {
+	pricing := original.Pricing()
+	req := &CreateInvoiceRequest{
+		MerchantID:         original.MerchantID(),
+		CustomerID:         original.CustomerID(),
+		Title:              original.Title(),
+		Description:        original.Description(),
+		Type:               original.Type(),
+		MinimumAmount:      original.MinimumAmount(),
+		Discount:           original.Discount(),
+		Currency:           shared.Currency(pricing.Subtotal().Currency()),
+		CryptoCurrency:     original.CryptoCurrency(),
+		Network:            original.Network(),
+		PaymentTolerance:   original.PaymentTolerance(),
+		ExpirationDuration: expirationWindow(original),
+		ReturnURL:          original.ReturnURL(),
+		CancelURL:          original.CancelURL(),
+		CustomFields:       original.CustomFields(),
+		SettlementSplit:    original.SettlementSplit(),
+		PassFee:            original.FeePassThrough() != nil,
+	}
+	if original.Metadata() != nil {
+		req.Metadata = make(map[string]interface{}, len(original.Metadata())+1)
+		for key, value := range original.Metadata() {
+			req.Metadata[key] = value
+		}
+		req.RecordedMetadata = recordedMetadata(original.Metadata())
+		// Rule tags record what happened to the original, not its terms
+		delete(req.Metadata, RuleTagsMetadataKey)
+		delete(req.RecordedMetadata, RuleTagsMetadataKey)
+	}
+
+	// Donations are priced by their minimum and take no items or taxes
+	if original.Type() == InvoiceTypeDonation {
+		return req, nil
+	}
+
+	req.Items = make([]*CreateInvoiceItemRequest, len(original.Items()))
+	for i, item := range original.Items() {
+		req.Items[i] = &CreateInvoiceItemRequest{
+			Name:        item.Name(),
+			Description: item.Description(),
+			Quantity:    item.Quantity().String(),
+			UnitPrice:   item.UnitPrice(),
+			Discount:    item.Discount(),
+			TaxCategory: item.TaxCategory(),
+		}
+	}
+
+	if treatment := original.TaxTreatment(); treatment != nil {
+		policy, err := NewTaxPolicy(treatment.Jurisdiction(), treatment.CustomerTaxID(), itemTaxRates(original.Items()))
+		if err != nil {
+			return nil, err
+		}
+		req.TaxPolicy = policy
+		return req, nil
+	}
+
+	tax, err := duplicateFlatTax(original)
+	if err != nil {
+		return nil, err
+	}
+	req.Tax = tax
+	return req, nil
+}
+
+// itemTaxRates returns the rates the items were taxed at, by tax category.
+func itemTaxRates(items []*InvoiceItem) map[string][]*TaxRate {
+	rates := make(map[string][]*TaxRate)
+	for _, item := range items {
+		if _, ok := rates[item.TaxCategory()]; ok || len(item.TaxLines()) == 0 {
+			continue
+		}
+		for _, line := range item.TaxLines() {
+			rates[item.TaxCategory()] = append(rates[item.TaxCategory()], line.Rate())
+		}
+	}
+	return rates
+}
+
+// duplicateFlatTax returns the flat tax of an invoice without its coupon. The tax is scaled with the
+// taxable amount so it keeps its rate.
+func duplicateFlatTax(original *Invoice) (*shared.Money, error) {
+	tax := original.Pricing().Tax()
+	if original.Coupon() == nil {
+		return tax, nil
+	}
+
+	previousTaxable := original.Pricing().TaxableAmount()
+	if previousTaxable.IsZero() {
+		return tax, nil
+	}
+
+	invoiceDiscount, err := original.InvoiceDiscountAmount()
+	if err != nil {
+		return nil, err
+	}
+	taxable, err := original.Pricing().Subtotal().Subtract(invoiceDiscount)
+	if err != nil {
+		return nil, err
+	}
+
+	scaled := tax.Amount().Mul(taxable.Amount()).Div(previousTaxable.Amount())
+	return shared.NewMoney(scaled.StringFixed(2), shared.Currency(tax.Currency()))
+}
+
+// expirationWindow returns how long the invoice was payable for, or zero to use the default.
+// A draft's expiration holds the window it will be payable for.
+func expirationWindow(original *Invoice) time.Duration {
+	if original.Expiration() == nil {
+		return 0
+	}
+	if original.IsDraft() {
+		return original.Expiration().Duration()
+	}
+	if window := original.Expiration().ExpiresAt().Sub(original.CreatedAt()); window > 0 {
+		return window
+	}
+	return 0
+}
@@ -4,6 +4,8 @@ package invoice
 type InvoiceStatus string
 
 const (
+	// StatusDraft - Invoice being prepared; editable, with no payment address or exchange rate
+	StatusDraft InvoiceStatus = "draft"
 	// StatusCreated - Invoice just created, not yet viewed
 	StatusCreated InvoiceStatus = "created"
 	// StatusPending - Waiting for payment
@@ -18,8 +20,12 @@ const (
 	StatusExpired InvoiceStatus = "expired"
 	// StatusCancelled - Manually cancelled
 	StatusCancelled InvoiceStatus = "cancelled"
+	// StatusPartiallyRefunded - Part of the payment refunded after completion
+	StatusPartiallyRefunded InvoiceStatus = "partially_refunded"
 	// StatusRefunded - Payment refunded after completion
 	StatusRefunded InvoiceStatus = "refunded"
+	// StatusUnderpaidClosed - Closed partially paid once the grace period after expiry lapsed
+	StatusUnderpaidClosed InvoiceStatus = "underpaid_closed"
 )
 
 // String returns the string representation of the invoice status.
@@ -30,14 +36,17 @@ func (s InvoiceStatus) String() string {
 // IsValid returns true if the invoice status is valid.
 func (s InvoiceStatus) IsValid() bool {
 	switch s {
-	case StatusCreated,
+	case StatusDraft,
+		StatusCreated,
 		StatusPending,
 		StatusPartial,
 		StatusConfirming,
 		StatusPaid,
 		StatusExpired,
 		StatusCancelled,
-		StatusRefunded:
+		StatusPartiallyRefunded,
+		StatusRefunded,
+		StatusUnderpaidClosed:
 		return true
 	default:
 		return false
@@ -47,7 +56,7 @@ func (s InvoiceStatus) IsValid() bool {
 // IsTerminal returns true if the status is a terminal state.
 func (s InvoiceStatus) IsTerminal() bool {
 	switch s {
-	case StatusPaid, StatusExpired, StatusCancelled, StatusRefunded:
+	case StatusPaid, StatusExpired, StatusCancelled, StatusPartiallyRefunded, StatusRefunded, StatusUnderpaidClosed:
 		return true
 	default:
 		return false
@@ -65,17 +74,30 @@ func (s InvoiceStatus) CanTransitionTo(target InvoiceStatus) bool {
 		return false
 	}
 
-	// Terminal states cannot transition to other states (except paid -> refunded)
+	// Terminal states cannot transition to other states (except refunds of paid invoices, paid invoices
+	// reopened when their settlement fails, and payments reversed by a deep reorganization)
+	reopened := target == StatusPending || target == StatusPartial
 	if s.IsTerminal() {
-		return s == StatusPaid && target == StatusRefunded
+		switch s {
+		case StatusPaid:
+			return target == StatusPartiallyRefunded || target == StatusRefunded || target == StatusConfirming ||
+				reopened
+		case StatusPartiallyRefunded:
+			return target == StatusRefunded || reopened
+		case StatusUnderpaidClosed:
+			return reopened
+		default:
+			return false
+		}
 	}
 
 	// Define valid transitions based on the state machine
 	validTransitions := map[InvoiceStatus][]InvoiceStatus{
+		StatusDraft:      {StatusCreated, StatusCancelled},
 		StatusCreated:    {StatusPending, StatusExpired, StatusCancelled},
 		StatusPending:    {StatusPartial, StatusConfirming, StatusExpired, StatusCancelled},
-		StatusPartial:    {StatusConfirming, StatusCancelled},
-		StatusConfirming: {StatusPaid, StatusPending}, // pending for blockchain reorg
+		StatusPartial:    {StatusConfirming, StatusCancelled, StatusUnderpaidClosed, StatusPending},
+		StatusConfirming: {StatusPaid, StatusPending, StatusPartial}, // pending or partial for blockchain reorg
 	}
 
 	if transitions, exists := validTransitions[s]; exists {
@@ -89,6 +111,31 @@ func (s InvoiceStatus) CanTransitionTo(target InvoiceStatus) bool {
 	return false
 }
 
+// InvoiceType represents how the amount due on an invoice is determined.
+type InvoiceType string
+
+const (
+	// InvoiceTypeStandard - A fixed total priced from the invoice items
+	InvoiceTypeStandard InvoiceType = "standard"
+	// InvoiceTypeDonation - An open amount chosen by the customer, settled by any payment at or above a minimum
+	InvoiceTypeDonation InvoiceType = "donation"
+)
+
+// String returns the string representation of the invoice type.
+func (t InvoiceType) String() string {
+	return string(t)
+}
+
+// IsValid returns true if the invoice type is valid.
+func (t InvoiceType) IsValid() bool {
+	switch t {
+	case InvoiceTypeStandard, InvoiceTypeDonation:
+		return true
+	default:
+		return false
+	}
+}
+
 // OverpaymentAction represents how to handle overpayments.
 type OverpaymentAction string
 
@@ -116,6 +163,58 @@ func (a OverpaymentAction) IsValid() bool {
 	}
 }
 
+// DiscountType represents how a discount value is applied.
+type DiscountType string
+
+const (
+	// DiscountTypeFixed - A fixed amount in the invoice currency
+	DiscountTypeFixed DiscountType = "fixed"
+	// DiscountTypePercentage - A percentage of the discounted amount
+	DiscountTypePercentage DiscountType = "percentage"
+)
+
+// String returns the string representation of the discount type.
+func (t DiscountType) String() string {
+	return string(t)
+}
+
+// IsValid returns true if the discount type is valid.
+func (t DiscountType) IsValid() bool {
+	switch t {
+	case DiscountTypeFixed, DiscountTypePercentage:
+		return true
+	default:
+		return false
+	}
+}
+
+// TaxType represents the kind of tax levied on an invoice item.
+type TaxType string
+
+const (
+	// TaxTypeVAT - Value added tax, as levied in the EU and UK
+	TaxTypeVAT TaxType = "vat"
+	// TaxTypeGST - Goods and services tax, as levied in Canada, Australia and India
+	TaxTypeGST TaxType = "gst"
+	// TaxTypeSalesTax - State, county or city sales tax, as levied in the US
+	TaxTypeSalesTax TaxType = "sales_tax"
+)
+
+// String returns the string representation of the tax type.
+func (t TaxType) String() string {
+	return string(t)
+}
+
+// IsValid returns true if the tax type is valid.
+func (t TaxType) IsValid() bool {
+	switch t {
+	case TaxTypeVAT, TaxTypeGST, TaxTypeSalesTax:
+		return true
+	default:
+		return false
+	}
+}
+
 // AuditEvent represents the type of audit event.
 type AuditEvent string
 
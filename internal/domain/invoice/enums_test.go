@@ -28,6 +28,7 @@ func TestInvoiceStatus(t *testing.T) {
 		require.True(t, invoice.StatusExpired.IsValid())
 		require.True(t, invoice.StatusCancelled.IsValid())
 		require.True(t, invoice.StatusRefunded.IsValid())
+		require.True(t, invoice.StatusUnderpaidClosed.IsValid())
 	})
 
 	t.Run("IsValid - invalid status", func(t *testing.T) {
@@ -40,6 +41,8 @@ func TestInvoiceStatus(t *testing.T) {
 		require.True(t, invoice.StatusExpired.IsTerminal())
 		require.True(t, invoice.StatusCancelled.IsTerminal())
 		require.True(t, invoice.StatusRefunded.IsTerminal())
+		require.True(t, invoice.StatusPartiallyRefunded.IsTerminal())
+		require.True(t, invoice.StatusUnderpaidClosed.IsTerminal())
 	})
 
 	t.Run("IsTerminal - non-terminal statuses", func(t *testing.T) {
@@ -75,9 +78,10 @@ func TestInvoiceStatus(t *testing.T) {
 		require.True(t, invoice.StatusPending.CanTransitionTo(invoice.StatusExpired))
 		require.True(t, invoice.StatusPending.CanTransitionTo(invoice.StatusCancelled))
 
-		// Partial -> Confirming, Cancelled
+		// Partial -> Confirming, Cancelled, Underpaid closed
 		require.True(t, invoice.StatusPartial.CanTransitionTo(invoice.StatusConfirming))
 		require.True(t, invoice.StatusPartial.CanTransitionTo(invoice.StatusCancelled))
+		require.True(t, invoice.StatusPartial.CanTransitionTo(invoice.StatusUnderpaidClosed))
 
 		// Confirming -> Paid, Pending (for blockchain reorg)
 		require.True(t, invoice.StatusConfirming.CanTransitionTo(invoice.StatusPaid))
@@ -85,6 +89,8 @@ func TestInvoiceStatus(t *testing.T) {
 
 		// Paid -> Refunded
 		require.True(t, invoice.StatusPaid.CanTransitionTo(invoice.StatusRefunded))
+		require.True(t, invoice.StatusPaid.CanTransitionTo(invoice.StatusPartiallyRefunded))
+		require.True(t, invoice.StatusPartiallyRefunded.CanTransitionTo(invoice.StatusRefunded))
 	})
 
 	t.Run("CanTransitionTo - invalid transitions", func(t *testing.T) {
@@ -98,6 +104,11 @@ func TestInvoiceStatus(t *testing.T) {
 		require.False(t, invoice.StatusExpired.CanTransitionTo(invoice.StatusPaid))
 		require.False(t, invoice.StatusCancelled.CanTransitionTo(invoice.StatusPaid))
 		require.False(t, invoice.StatusRefunded.CanTransitionTo(invoice.StatusPaid))
+		require.False(t, invoice.StatusPartiallyRefunded.CanTransitionTo(invoice.StatusPaid))
+		require.False(t, invoice.StatusUnderpaidClosed.CanTransitionTo(invoice.StatusRefunded))
+
+		// Only partially paid invoices are closed underpaid
+		require.False(t, invoice.StatusPending.CanTransitionTo(invoice.StatusUnderpaidClosed))
 
 		// Invalid target status
 		invalidStatus := invoice.InvoiceStatus("invalid")
@@ -8,49 +8,145 @@ import (
 // Invoice-specific domain errors
 var (
 	// Invoice creation errors
-	ErrInvalidInvoiceID        = errors.New("invalid invoice ID")
-	ErrInvalidMerchantID       = errors.New("invalid merchant ID")
-	ErrNoItems                 = errors.New("invoice must have at least one item")
-	ErrInvalidPricing          = errors.New("invalid pricing")
-	ErrInvalidCryptocurrency   = errors.New("invalid cryptocurrency")
-	ErrInvalidPaymentTolerance = errors.New("invalid payment tolerance")
-	ErrInvalidExpiration       = errors.New("invalid expiration")
+	ErrInvalidInvoiceID  = shared.NewError(shared.ErrValidation, ErrCodeInvalidInvoiceID, "invalid invoice ID")
+	ErrInvalidMerchantID = shared.NewError(shared.ErrValidation, ErrCodeInvalidMerchantID, "invalid merchant ID")
+	ErrNoItems           = shared.NewError(shared.ErrValidation, ErrCodeNoItems,
+		"invoice must have at least one item")
+	ErrInvalidPricing        = shared.NewError(shared.ErrValidation, ErrCodeInvalidPricing, "invalid pricing")
+	ErrInvalidCryptocurrency = shared.NewError(shared.ErrValidation, ErrCodeInvalidCryptocurrency,
+		"invalid cryptocurrency")
+	ErrInvalidPaymentTolerance = shared.NewError(shared.ErrValidation, ErrCodeInvalidPaymentTolerance,
+		"invalid payment tolerance")
+	ErrInvalidExpiration = shared.NewError(shared.ErrValidation, ErrCodeInvalidExpiration, "invalid expiration")
 
 	// Invoice status errors
-	ErrInvoiceAlreadyViewed = errors.New("invoice already marked as viewed")
-	ErrCannotViewInvoice    = errors.New("can only mark created invoices as viewed")
-	ErrCannotCancelInvoice  = errors.New("cannot cancel invoice in terminal state")
-	ErrCannotExpireInvoice  = errors.New("cannot auto-expire invoices with partial payments")
-	ErrCannotMarkAsPaid     = errors.New("can only mark confirming invoices as paid")
-	ErrCannotRefundInvoice  = errors.New("can only refund paid invoices")
+	ErrInvoiceAlreadyViewed = shared.NewError(shared.ErrConflict, ErrCodeInvoiceAlreadyViewed,
+		"invoice already marked as viewed")
+	ErrCannotViewInvoice = shared.NewError(shared.ErrInvalidState, ErrCodeCannotViewInvoice,
+		"can only mark created invoices as viewed")
+	ErrCannotCancelInvoice = shared.NewError(shared.ErrInvalidState, ErrCodeCannotCancelInvoice,
+		"cannot cancel invoice in terminal state")
+	ErrCannotExpireInvoice = shared.NewError(shared.ErrInvalidState, ErrCodeCannotExpireInvoice,
+		"cannot auto-expire invoices with partial payments")
+	ErrCannotMarkAsPaid = shared.NewError(shared.ErrInvalidState, ErrCodeCannotMarkAsPaid,
+		"can only mark confirming invoices as paid")
+	ErrCannotRefundInvoice = shared.NewError(shared.ErrInvalidState, ErrCodeCannotRefundInvoice,
+		"can only refund paid invoices")
+	ErrInvalidRefundAmount = shared.NewError(shared.ErrValidation, ErrCodeInvalidRefundAmount,
+		"refund amount must be greater than zero")
+	ErrRefundExceedsPaid = shared.NewError(shared.ErrValidation, ErrCodeRefundExceedsPaid,
+		"refund amount exceeds the amount paid minus refunds")
+	ErrCannotApplyCoupon = shared.NewError(shared.ErrInvalidState, ErrCodeCannotApplyCoupon,
+		"coupons can only be applied to unpaid invoices")
+	ErrCouponAlreadyApplied = shared.NewError(shared.ErrConflict, ErrCodeCouponAlreadyApplied,
+		"a coupon has already been applied to this invoice")
+	ErrNotDonation = shared.NewError(shared.ErrInvalidState, ErrCodeNotDonation,
+		"only donation invoices accept a customer-chosen amount")
+	ErrCannotChooseAmount = shared.NewError(shared.ErrInvalidState, ErrCodeCannotChooseAmount,
+		"the amount can only be chosen before any payment is received")
+	ErrBelowMinimumAmount = shared.NewError(shared.ErrValidation, ErrCodeBelowMinimumAmount,
+		"amount is below the minimum for this invoice")
+	ErrCannotRequote = shared.NewError(shared.ErrInvalidState, ErrCodeCannotRequote,
+		"only unpaid invoices with an expired exchange rate can be re-quoted")
+	ErrSlippageExceeded = shared.NewError(shared.ErrConflict, ErrCodeSlippageExceeded,
+		"exchange rate moved beyond the allowed slippage")
+	ErrCannotChangeCurrency = shared.NewError(shared.ErrInvalidState, ErrCodeCannotChangeCurrency,
+		"the cryptocurrency can only be changed before any payment is received")
+	ErrInvoiceNotDraft = shared.NewError(shared.ErrInvalidState, ErrCodeInvoiceNotDraft,
+		"only draft invoices can be edited or finalized")
+	ErrCannotAmend = shared.NewError(shared.ErrInvalidState, ErrCodeCannotAmend,
+		"only finalized invoices without payments can be amended")
+	ErrCannotExtend = shared.NewError(shared.ErrInvalidState, ErrCodeCannotExtend,
+		"only invoices awaiting payment can be extended")
+	ErrPartialPaymentLapsed = shared.NewError(shared.ErrInvalidState, ErrCodePartialPaymentLapsed,
+		"a partially paid invoice cannot be extended once it has expired")
+	ErrExtensionLimit = shared.NewError(shared.ErrValidation, ErrCodeExtensionLimit,
+		"extension exceeds the merchant's limit")
+	ErrCannotReversePayment = shared.NewError(shared.ErrInvalidState, ErrCodeCannotReversePayment,
+		"only invoices credited with a payment can have it reversed")
+	ErrCannotReopenInvoice = shared.NewError(shared.ErrInvalidState, ErrCodeCannotReopenInvoice,
+		"only paid invoices can be reopened")
+	ErrLiveKeyTestnet = shared.NewError(shared.ErrValidation, ErrCodeLiveKeyTestnet,
+		"live API keys cannot create invoices paid on a testnet")
+	ErrInvoiceArchived = shared.NewError(shared.ErrInvalidState, ErrCodeInvoiceArchived,
+		"archived invoices are read-only")
+	ErrConcurrentUpdate = shared.NewError(shared.ErrConflict, ErrCodeConcurrentUpdate,
+		"invoice was changed by another request; reload it and try again")
+
+	// Refund destination errors
+	ErrInvalidRefundAddress = shared.NewError(shared.ErrValidation, ErrCodeInvalidRefundAddress,
+		"invalid refund address")
+	ErrInvalidRefundSignature = shared.NewError(shared.ErrValidation, ErrCodeInvalidRefundSignature,
+		"refund address signature does not match the address")
+	ErrNoRefundDestination = shared.NewError(shared.ErrInvalidState, ErrCodeNoRefundDestination,
+		"no refund address has been captured or supplied")
+	ErrRefundDestinationMismatch = shared.NewError(shared.ErrValidation, ErrCodeRefundDestinationMismatch,
+		"address does not match the refund destination awaiting confirmation")
+	ErrRefundDestinationConfirmed = shared.NewError(shared.ErrConflict, ErrCodeRefundDestinationConfirmed,
+		"refund destination has already been confirmed")
+	ErrRefundDestinationUnconfirmed = shared.NewError(shared.ErrInvalidState, ErrCodeRefundDestinationUnconfirmed,
+		"refunds require a confirmed refund destination")
+
+	// Discount errors
+	ErrInvalidDiscount = shared.NewError(shared.ErrValidation, ErrCodeInvalidDiscount, "invalid discount")
+
+	// Tax errors
+	ErrInvalidTax = shared.NewError(shared.ErrValidation, ErrCodeInvalidTax, "invalid tax")
+
+	// Custom field errors
+	ErrInvalidCustomField = shared.NewError(shared.ErrValidation, ErrCodeInvalidCustomField, "invalid custom field")
+
+	// Settlement split errors
+	ErrInvalidSettlementSplit = shared.NewError(shared.ErrValidation, ErrCodeInvalidSettlementSplit,
+		"invalid settlement split")
+
+	// Fee pass-through errors
+	ErrInvalidFeePassThrough = shared.NewError(shared.ErrValidation, ErrCodeInvalidFeePassThrough,
+		"invalid fee pass-through")
 
 	// Invoice item errors
-	ErrInvalidItemName        = errors.New("invalid item name")
-	ErrInvalidItemDescription = errors.New("invalid item description")
-	ErrInvalidQuantity        = errors.New("invalid quantity")
-	ErrInvalidUnitPrice       = errors.New("invalid unit price")
-	ErrInvalidTotalPrice      = errors.New("invalid total price")
+	ErrInvalidItemName        = shared.NewError(shared.ErrValidation, ErrCodeInvalidItemName, "invalid item name")
+	ErrInvalidItemDescription = shared.NewError(shared.ErrValidation, ErrCodeInvalidItemDescription,
+		"invalid item description")
+	ErrInvalidQuantity   = shared.NewError(shared.ErrValidation, ErrCodeInvalidQuantity, "invalid quantity")
+	ErrInvalidUnitPrice  = shared.NewError(shared.ErrValidation, ErrCodeInvalidUnitPrice, "invalid unit price")
+	ErrInvalidTotalPrice = shared.NewError(shared.ErrValidation, ErrCodeInvalidTotalPrice, "invalid total price")
 
 	// Payment tolerance errors
-	ErrInvalidUnderpaymentThreshold = errors.New("invalid underpayment threshold")
-	ErrInvalidOverpaymentThreshold  = errors.New("invalid overpayment threshold")
-	ErrInvalidOverpaymentAction     = errors.New("invalid overpayment action")
+	ErrInvalidUnderpaymentThreshold = shared.NewError(shared.ErrValidation, ErrCodeInvalidUnderpaymentThreshold,
+		"invalid underpayment threshold")
+	ErrInvalidOverpaymentThreshold = shared.NewError(shared.ErrValidation, ErrCodeInvalidOverpaymentThreshold,
+		"invalid overpayment threshold")
+	ErrInvalidOverpaymentAction = shared.NewError(shared.ErrValidation, ErrCodeInvalidOverpaymentAction,
+		"invalid overpayment action")
 
 	// Payment errors
-	ErrInvalidPaymentID             = errors.New("invalid payment ID")
-	ErrInvalidFromAddress           = errors.New("invalid from address")
-	ErrInvalidToAddress             = errors.New("invalid to address")
-	ErrInvalidRequiredConfirmations = errors.New("invalid required confirmations")
-	ErrInvalidPaymentStatus         = errors.New("invalid payment status")
-	ErrInvalidPaymentTransition     = errors.New("invalid payment status transition")
-	ErrPaymentValidationFailed      = errors.New("payment validation failed")
-	ErrUnderpayment                 = errors.New("payment amount is below the minimum threshold")
+	ErrInvalidPaymentID = shared.NewError(shared.ErrValidation, ErrCodeInvalidPaymentID,
+		"invalid payment ID")
+	ErrInvalidFromAddress = shared.NewError(shared.ErrValidation, ErrCodeInvalidFromAddress,
+		"invalid from address")
+	ErrInvalidToAddress = shared.NewError(shared.ErrValidation, ErrCodeInvalidToAddress,
+		"invalid to address")
+	ErrInvalidRequiredConfirmations = shared.NewError(shared.ErrValidation, ErrCodeInvalidRequiredConfirmations,
+		"invalid required confirmations")
+	ErrInvalidPaymentStatus = shared.NewError(shared.ErrValidation, ErrCodeInvalidPaymentStatus,
+		"invalid payment status")
+	ErrInvalidPaymentTransition = shared.NewError(shared.ErrInvalidState, ErrCodeInvalidPaymentTransition,
+		"invalid payment status transition")
+	ErrPaymentValidationFailed = shared.NewError(shared.ErrValidation, ErrCodePaymentValidationFailed,
+		"payment validation failed")
+	ErrUnderpayment = errors.New("payment amount is below the minimum threshold")
+	ErrStaleRate    = errors.New("payment arrived after the exchange rate expired and awaits review")
 
 	// Service errors
-	ErrInvoiceNotFound            = errors.New("invoice not found")
-	ErrPaymentNotFound            = errors.New("payment not found")
-	ErrInvalidCreateRequest       = errors.New("invalid create invoice request")
-	ErrInvalidListRequest         = errors.New("invalid list invoices request")
+	ErrInvoiceNotFound      = shared.NewError(shared.ErrNotFound, ErrCodeInvoiceNotFound, "invoice not found")
+	ErrPaymentNotFound      = shared.NewError(shared.ErrNotFound, ErrCodePaymentNotFound, "payment not found")
+	ErrInvalidCreateRequest = shared.NewError(shared.ErrValidation, ErrCodeInvalidCreateRequest,
+		"invalid create invoice request")
+	ErrInvalidListRequest = shared.NewError(shared.ErrValidation, ErrCodeInvalidListRequest,
+		"invalid list invoices request")
+	ErrStatusBatchTooLarge = shared.NewError(shared.ErrValidation, ErrCodeStatusBatchTooLarge,
+		"too many invoice IDs in status batch")
 	ErrExchangeRateServiceError   = errors.New("exchange rate service error")
 	ErrPaymentAddressServiceError = errors.New("payment address service error")
 
@@ -82,13 +178,14 @@ var (
 	ErrInvalidAmountFormat = shared.ErrInvalidAmountFormat
 
 	// Payment and blockchain errors
-	ErrInvalidPaymentAddress    = shared.ErrInvalidPaymentAddress
-	ErrInvalidTransactionHash   = shared.ErrInvalidTransactionHash
-	ErrInvalidNetwork           = shared.ErrInvalidNetwork
-	ErrExpiredPaymentAddress    = shared.ErrExpiredPaymentAddress
-	ErrExpiredExchangeRate      = shared.ErrExpiredExchangeRate
-	ErrInvalidExchangeRate      = shared.ErrInvalidExchangeRate
-	ErrInvalidConfirmationCount = shared.ErrInvalidConfirmationCount
+	ErrInvalidPaymentAddress     = shared.ErrInvalidPaymentAddress
+	ErrInvalidTransactionHash    = shared.ErrInvalidTransactionHash
+	ErrInvalidNetwork            = shared.ErrInvalidNetwork
+	ErrExpiredPaymentAddress     = shared.ErrExpiredPaymentAddress
+	ErrExpiredExchangeRate       = shared.ErrExpiredExchangeRate
+	ErrInvalidExchangeRate       = shared.ErrInvalidExchangeRate
+	ErrInvalidConfirmationCount  = shared.ErrInvalidConfirmationCount
+	ErrUnsupportedCryptocurrency = shared.ErrUnsupportedToken
 
 	// Service and repository errors
 	ErrNotFound          = shared.ErrNotFound
@@ -127,6 +224,7 @@ const (
 	ErrCodeNoItems                      = "NO_ITEMS"
 	ErrCodeInvalidPricing               = "INVALID_PRICING"
 	ErrCodeInvalidCryptocurrency        = "INVALID_CRYPTOCURRENCY"
+	ErrCodeUnsupportedCryptocurrency    = "UNSUPPORTED_CRYPTOCURRENCY"
 	ErrCodeInvalidPaymentTolerance      = "INVALID_PAYMENT_TOLERANCE"
 	ErrCodeInvalidExpiration            = "INVALID_EXPIRATION"
 	ErrCodeInvoiceAlreadyViewed         = "INVOICE_ALREADY_VIEWED"
@@ -135,6 +233,37 @@ const (
 	ErrCodeCannotExpireInvoice          = "CANNOT_EXPIRE_INVOICE"
 	ErrCodeCannotMarkAsPaid             = "CANNOT_MARK_AS_PAID"
 	ErrCodeCannotRefundInvoice          = "CANNOT_REFUND_INVOICE"
+	ErrCodeInvalidRefundAmount          = "INVALID_REFUND_AMOUNT"
+	ErrCodeRefundExceedsPaid            = "REFUND_EXCEEDS_PAID"
+	ErrCodeCannotApplyCoupon            = "CANNOT_APPLY_COUPON"
+	ErrCodeCouponAlreadyApplied         = "COUPON_ALREADY_APPLIED"
+	ErrCodeNotDonation                  = "NOT_DONATION"
+	ErrCodeCannotChooseAmount           = "CANNOT_CHOOSE_AMOUNT"
+	ErrCodeBelowMinimumAmount           = "BELOW_MINIMUM_AMOUNT"
+	ErrCodeCannotRequote                = "CANNOT_REQUOTE"
+	ErrCodeSlippageExceeded             = "SLIPPAGE_EXCEEDED"
+	ErrCodeCannotChangeCurrency         = "CANNOT_CHANGE_CURRENCY"
+	ErrCodeInvoiceNotDraft              = "INVOICE_NOT_DRAFT"
+	ErrCodeCannotAmend                  = "CANNOT_AMEND"
+	ErrCodeCannotExtend                 = "CANNOT_EXTEND"
+	ErrCodePartialPaymentLapsed         = "PARTIAL_PAYMENT_LAPSED"
+	ErrCodeExtensionLimit               = "EXTENSION_LIMIT_EXCEEDED"
+	ErrCodeLiveKeyTestnet               = "LIVE_KEY_TESTNET"
+	ErrCodeInvoiceArchived              = "INVOICE_ARCHIVED"
+	ErrCodeConcurrentUpdate             = "INVOICE_CONCURRENT_UPDATE"
+	ErrCodeCannotReversePayment         = "CANNOT_REVERSE_PAYMENT"
+	ErrCodeCannotReopenInvoice          = "CANNOT_REOPEN_INVOICE"
+	ErrCodeInvalidRefundAddress         = "INVALID_REFUND_ADDRESS"
+	ErrCodeInvalidRefundSignature       = "INVALID_REFUND_SIGNATURE"
+	ErrCodeNoRefundDestination          = "NO_REFUND_DESTINATION"
+	ErrCodeRefundDestinationMismatch    = "REFUND_DESTINATION_MISMATCH"
+	ErrCodeRefundDestinationConfirmed   = "REFUND_DESTINATION_CONFIRMED"
+	ErrCodeRefundDestinationUnconfirmed = "REFUND_DESTINATION_UNCONFIRMED"
+	ErrCodeInvalidDiscount              = "INVALID_DISCOUNT"
+	ErrCodeInvalidTax                   = "INVALID_TAX"
+	ErrCodeInvalidCustomField           = "INVALID_CUSTOM_FIELD"
+	ErrCodeInvalidSettlementSplit       = "INVALID_SETTLEMENT_SPLIT"
+	ErrCodeInvalidFeePassThrough        = "INVALID_FEE_PASS_THROUGH"
 	ErrCodeInvalidItemName              = "INVALID_ITEM_NAME"
 	ErrCodeInvalidItemDescription       = "INVALID_ITEM_DESCRIPTION"
 	ErrCodeInvalidQuantity              = "INVALID_QUANTITY"
@@ -155,6 +284,7 @@ const (
 	ErrCodePaymentNotFound              = "PAYMENT_NOT_FOUND"
 	ErrCodeInvalidCreateRequest         = "INVALID_CREATE_REQUEST"
 	ErrCodeInvalidListRequest           = "INVALID_LIST_REQUEST"
+	ErrCodeStatusBatchTooLarge          = "STATUS_BATCH_TOO_LARGE"
 	ErrCodeExchangeRateServiceError     = "EXCHANGE_RATE_SERVICE_ERROR"
 	ErrCodePaymentAddressServiceError   = "PAYMENT_ADDRESS_SERVICE_ERROR"
 	ErrCodeInvoiceSaveError             = "INVOICE_SAVE_ERROR"
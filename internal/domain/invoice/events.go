@@ -1,17 +1,38 @@
 package invoice
 
-// Helper functions for common event data patterns
-func createInvoiceEventData(invoice *Invoice) map[string]interface{} {
-	cryptoAmount, _ := invoice.GetCryptoAmount()
+import (
+	"crypto-checkout/internal/domain/shared"
+)
 
-	return map[string]interface{}{
-		"invoice_id":    invoice.ID(),
-		"merchant_id":   invoice.MerchantID(),
-		"total_amount":  invoice.Pricing().Total(),
-		"crypto_amount": cryptoAmount,
-		"currency":      invoice.CryptoCurrency(),
-		"status":        invoice.Status().String(),
-		"expires_at":    invoice.Expiration().ExpiresAt(),
-		"description":   invoice.Description(),
+// Helper functions for common event data patterns
+func createInvoiceEventData(invoice *Invoice) shared.InvoiceEvent {
+	data := shared.InvoiceEvent{
+		InvoiceID:   invoice.ID(),
+		MerchantID:  invoice.MerchantID(),
+		TotalAmount: invoice.Pricing().Total().Amount().String(),
+		Currency:    invoice.CryptoCurrency().String(),
+		Status:      invoice.Status().String(),
+		ExpiresAt:   invoice.Expiration().ExpiresAt(),
+		Description: invoice.Description(),
+		Number:      invoice.Number(),
+		Timestamp:   shared.Now().UTC(),
+	}
+	if cryptoAmount, err := invoice.LockedCryptoAmount(); err == nil && cryptoAmount != nil {
+		data.CryptoAmount = cryptoAmount.Amount().String()
+	}
+	if invoice.Supersedes() != nil {
+		data.Supersedes = *invoice.Supersedes()
+	}
+	if invoice.SupersededBy() != nil {
+		data.SupersededBy = *invoice.SupersededBy()
+	}
+	for _, field := range invoice.CustomFields() {
+		data.CustomFields = append(data.CustomFields, shared.InvoiceCustomField{
+			Label:      field.Label(),
+			Value:      field.Value(),
+			Type:       string(field.Type()),
+			Visibility: string(field.Visibility()),
+		})
 	}
+	return data
 }
@@ -0,0 +1,221 @@
+package invoice
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ExtensionPolicy bounds how far a merchant's invoices can be extended.
+type ExtensionPolicy struct {
+	// MaxExtension is the total time an invoice's expiry can be pushed back by across all its extensions.
+	// Zero disallows extensions.
+	MaxExtension time.Duration
+}
+
+// DefaultExtensionPolicy lets invoices be extended by up to an hour in total.
+func DefaultExtensionPolicy() ExtensionPolicy {
+	return ExtensionPolicy{MaxExtension: time.Hour}
+}
+
+// MerchantExtensionPolicies provides each merchant's limits on extending invoices.
+type MerchantExtensionPolicies interface {
+	// ExtensionPolicy returns the merchant's extension limits, or DefaultExtensionPolicy if it has not set any.
+	ExtensionPolicy(ctx context.Context, merchantID string) (ExtensionPolicy, error)
+}
+
+// Extension records a push of an invoice's expiry.
+type Extension struct {
+	previousExpiresAt time.Time
+	expiresAt         time.Time
+	extendedAt        time.Time
+}
+
+// NewExtension creates a new extension record.
+func NewExtension(previousExpiresAt, expiresAt, extendedAt time.Time) (*Extension, error) {
+	if !expiresAt.After(previousExpiresAt) || !expiresAt.After(extendedAt) {
+		return nil, fmt.Errorf("%w: extension must move the expiry forward", ErrInvalidExpiration)
+	}
+
+	return &Extension{
+		previousExpiresAt: previousExpiresAt,
+		expiresAt:         expiresAt,
+		extendedAt:        extendedAt,
+	}, nil
+}
+
+// PreviousExpiresAt returns when the invoice expired before the extension.
+func (e *Extension) PreviousExpiresAt() time.Time {
+	return e.previousExpiresAt
+}
+
+// ExpiresAt returns when the invoice expires after the extension.
+func (e *Extension) ExpiresAt() time.Time {
+	return e.expiresAt
+}
+
+// ExtendedAt returns when the invoice was extended.
+func (e *Extension) ExtendedAt() time.Time {
+	return e.extendedAt
+}
+
+// Duration returns the payable time the extension added. An invoice extended after its expiry had passed
+// is extended from the time of the extension, so the lapsed time is not counted.
+func (e *Extension) Duration() time.Duration {
+	from := e.previousExpiresAt
+	if e.extendedAt.After(from) {
+		from = e.extendedAt
+	}
+	return e.expiresAt.Sub(from)
+}
+
+// Extensions returns the extensions of the invoice, oldest first.
+func (i *Invoice) Extensions() []*Extension {
+	return i.extensions
+}
+
+// SetExtensions sets the extensions (for repository restoration).
+func (i *Invoice) SetExtensions(extensions []*Extension) {
+	i.extensions = extensions
+}
+
+// ExtendedBy returns the total payable time added to the invoice by its extensions.
+func (i *Invoice) ExtendedBy() time.Duration {
+	var total time.Duration
+	for _, extension := range i.extensions {
+		total += extension.Duration()
+	}
+	return total
+}
+
+// CanExtend returns true if the invoice is awaiting payment, including invoices whose expiry passed before
+// the expiry job closed them. A partially paid invoice can only be extended before its expiry passes.
+func (i *Invoice) CanExtend() bool {
+	if i.expiration == nil {
+		return false
+	}
+	switch i.status {
+	case StatusCreated, StatusPending:
+		return true
+	case StatusPartial:
+		return !i.expiration.IsExpired()
+	default:
+		return false
+	}
+}
+
+// Extend pushes the expiry of the invoice back by the given duration, counted from now if the expiry has
+// already passed. The total extension of the invoice is bounded by the policy. A partially paid invoice
+// whose expiry has passed cannot be extended: its payments were made at a rate that has since lapsed.
+func (i *Invoice) Extend(by time.Duration, policy ExtensionPolicy) (*Extension, error) {
+	if by <= 0 {
+		return nil, fmt.Errorf("%w: extension must be positive", ErrInvalidExpiration)
+	}
+	if i.status == StatusPartial && i.expiration != nil && i.expiration.IsExpired() {
+		return nil, ErrPartialPaymentLapsed
+	}
+	if !i.CanExtend() {
+		return nil, ErrCannotExtend
+	}
+	if remaining := policy.MaxExtension - i.ExtendedBy(); by > remaining {
+		return nil, fmt.Errorf("%w: %s of extension remaining", ErrExtensionLimit, max(remaining, 0))
+	}
+
+	now := shared.Now().UTC()
+	previousExpiresAt := i.expiration.ExpiresAt()
+	from := previousExpiresAt
+	if now.After(from) {
+		from = now
+	}
+
+	extension, err := NewExtension(previousExpiresAt, from.Add(by), now)
+	if err != nil {
+		return nil, err
+	}
+
+	i.expiration = NewInvoiceExpirationWithTimeUnsafe(extension.ExpiresAt())
+	i.extensions = append(i.extensions, extension)
+	i.updatedAt = now
+	return extension, nil
+}
+
+// ExtendInvoice pushes the expiry of an invoice back, within the merchant's extension policy. An unpaid
+// invoice whose exchange rate has expired is re-quoted at the current rate, so the customer pays the amount
+// the extended invoice shows.
+func (s *InvoiceServiceImpl) ExtendInvoice(ctx context.Context, id string, by time.Duration) (*Invoice, error) {
+	if id == "" {
+		return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+	}
+
+	invoice, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.extensionPolicy(ctx, invoice.MerchantID())
+	if err != nil {
+		return nil, err
+	}
+
+	extension, err := invoice.Extend(by, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	var requote *Requote
+	if invoice.CanRequote() {
+		rate, err := s.getExchangeRate(ctx, invoice.ExchangeRate().FromCurrency(), invoice.CryptoCurrency())
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrExchangeRateServiceError, err)
+		}
+		if requote, err = invoice.RefreshRate(rate); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repository.Update(ctx, invoice); err != nil {
+		return nil, err
+	}
+
+	if requote != nil {
+		s.publishInvoiceRequoted(ctx, invoice, requote)
+	}
+	s.publishInvoiceExtended(ctx, invoice, extension)
+	return invoice, nil
+}
+
+// extensionPolicy returns the merchant's extension limits.
+func (s *InvoiceServiceImpl) extensionPolicy(ctx context.Context, merchantID string) (ExtensionPolicy, error) {
+	if s.extensions == nil {
+		return DefaultExtensionPolicy(), nil
+	}
+	return s.extensions.ExtensionPolicy(ctx, merchantID)
+}
+
+// publishInvoiceExtended publishes the event announcing a new expiry.
+func (s *InvoiceServiceImpl) publishInvoiceExtended(ctx context.Context, invoice *Invoice, extension *Extension) {
+	if s.eventBus == nil {
+		return
+	}
+
+	eventData := shared.InvoiceExtendedEvent{
+		InvoiceEvent:      createInvoiceEventData(invoice),
+		PreviousExpiresAt: extension.PreviousExpiresAt(),
+		ExtendedBySeconds: int64(extension.Duration() / time.Second),
+	}
+	eventData.Timestamp = extension.ExtendedAt()
+	event := shared.NewInvoiceExtendedEvent(eventData)
+	if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+		// Log error but don't fail the operation
+		if s.logger != nil {
+			s.logger.Error("Failed to publish domain event",
+				zap.String("event_type", shared.EventTypeInvoiceExtended),
+				zap.String("aggregate_id", invoice.ID()),
+				zap.Error(err),
+			)
+		}
+	}
+}
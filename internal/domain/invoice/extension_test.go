@@ -0,0 +1,107 @@
+package invoice_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoiceExtend(t *testing.T) {
+	policy := invoice.DefaultExtensionPolicy()
+
+	t.Run("extends from the current expiry", func(t *testing.T) {
+		testInvoice := createTestInvoice()
+		previous := testInvoice.Expiration().ExpiresAt()
+		require.True(t, testInvoice.CanExtend())
+
+		extension, err := testInvoice.Extend(20*time.Minute, policy)
+		require.NoError(t, err)
+		require.Equal(t, previous, extension.PreviousExpiresAt())
+		require.Equal(t, previous.Add(20*time.Minute), testInvoice.Expiration().ExpiresAt())
+		require.Equal(t, 20*time.Minute, testInvoice.ExtendedBy())
+
+		_, err = testInvoice.Extend(40*time.Minute, policy)
+		require.NoError(t, err)
+		require.Len(t, testInvoice.Extensions(), 2)
+		require.Equal(t, time.Hour, testInvoice.ExtendedBy())
+	})
+
+	t.Run("lapsed expiry is extended from now", func(t *testing.T) {
+		testInvoice := createTestInvoice()
+		lapsed := time.Now().UTC().Add(-10 * time.Minute)
+		testInvoice.SetExpiration(invoice.NewInvoiceExpirationWithTimeUnsafe(lapsed))
+		require.True(t, testInvoice.CanExtend())
+
+		extension, err := testInvoice.Extend(15*time.Minute, policy)
+		require.NoError(t, err)
+		require.Equal(t, lapsed, extension.PreviousExpiresAt())
+		require.Equal(t, 15*time.Minute, extension.Duration())
+		require.False(t, testInvoice.Expiration().IsExpired())
+		require.InDelta(t, (15 * time.Minute).Seconds(), testInvoice.Expiration().TimeRemaining().Seconds(), 5)
+	})
+
+	t.Run("total extension is bounded by the policy", func(t *testing.T) {
+		testInvoice := createTestInvoice()
+		_, err := testInvoice.Extend(45*time.Minute, policy)
+		require.NoError(t, err)
+		expiresAt := testInvoice.Expiration().ExpiresAt()
+
+		_, err = testInvoice.Extend(30*time.Minute, policy)
+		require.ErrorIs(t, err, invoice.ErrExtensionLimit)
+		require.Contains(t, err.Error(), "15m0s of extension remaining")
+		require.Equal(t, expiresAt, testInvoice.Expiration().ExpiresAt())
+		require.Len(t, testInvoice.Extensions(), 1)
+
+		_, err = createTestInvoice().Extend(time.Minute, invoice.ExtensionPolicy{})
+		require.ErrorIs(t, err, invoice.ErrExtensionLimit)
+	})
+
+	t.Run("extension must be positive", func(t *testing.T) {
+		_, err := createTestInvoice().Extend(0, policy)
+		require.ErrorIs(t, err, invoice.ErrInvalidExpiration)
+	})
+
+	t.Run("partially paid invoice only before its expiry", func(t *testing.T) {
+		testInvoice := createTestInvoice()
+		fsm := invoice.NewInvoiceFSM(testInvoice)
+		require.NoError(t, fsm.Event(context.Background(), "view"))
+		require.NoError(t, fsm.Event(context.Background(), "partial_payment"))
+
+		_, err := testInvoice.Extend(10*time.Minute, policy)
+		require.NoError(t, err)
+
+		testInvoice.SetExpiration(invoice.NewInvoiceExpirationWithTimeUnsafe(time.Now().UTC().Add(-time.Minute)))
+		require.False(t, testInvoice.CanExtend())
+		_, err = testInvoice.Extend(10*time.Minute, policy)
+		require.ErrorIs(t, err, invoice.ErrPartialPaymentLapsed)
+	})
+
+	t.Run("closed invoice cannot be extended", func(t *testing.T) {
+		testInvoice := createTestInvoice()
+		require.NoError(t, invoice.NewInvoiceFSM(testInvoice).Event(context.Background(), "cancel"))
+		require.False(t, testInvoice.CanExtend())
+
+		_, err := testInvoice.Extend(10*time.Minute, policy)
+		require.ErrorIs(t, err, invoice.ErrCannotExtend)
+		require.Empty(t, testInvoice.Extensions())
+
+		_, err = createDraftTestInvoice(t).Extend(10*time.Minute, policy)
+		require.ErrorIs(t, err, invoice.ErrCannotExtend)
+	})
+
+	t.Run("refreshing an expired rate ignores slippage", func(t *testing.T) {
+		testInvoice := createRequotableInvoice(t)
+
+		requote, err := testInvoice.RefreshRate(newTestRate(t, "0.000025"))
+		require.NoError(t, err)
+		require.Equal(t, "0.00275", requote.NewAmount().Amount().String())
+		require.Len(t, testInvoice.Requotes(), 1)
+		require.False(t, testInvoice.CanRequote())
+
+		_, err = testInvoice.RefreshRate(newTestRate(t, "0.000025"))
+		require.ErrorIs(t, err, invoice.ErrCannotRequote)
+	})
+}
@@ -0,0 +1,104 @@
+package invoice
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// maxFeePercentage is the fee percentage a passed-through fee must stay below, since the fee is grossed up.
+var maxFeePercentage = decimal.NewFromInt(100)
+
+// FeeQuoter quotes the platform fee charged when a merchant's invoice settles, so invoices can pass it through.
+type FeeQuoter interface {
+	// QuoteFeePercentage returns the fee percentage a settlement of the merchant's invoice, paid in a currency on a
+	// network, would be charged now.
+	QuoteFeePercentage(
+		ctx context.Context,
+		merchantID string,
+		network shared.BlockchainNetwork,
+		currency string,
+	) (decimal.Decimal, error)
+}
+
+// FeePassThrough is the platform fee a merchant adds on top of an invoice instead of absorbing it. The fee is
+// quoted when the invoice is priced and charged to the settlement at the quoted percentage, so the merchant
+// receives the price before the fee.
+type FeePassThrough struct {
+	percentage decimal.Decimal
+}
+
+// NewFeePassThrough creates a passed-through fee charged at a percentage, at least 0 and below 100.
+func NewFeePassThrough(percentage decimal.Decimal) (*FeePassThrough, error) {
+	if percentage.IsNegative() || !percentage.LessThan(maxFeePercentage) {
+		return nil, fmt.Errorf("%w: fee percentage must be at least 0 and below 100", ErrInvalidFeePassThrough)
+	}
+	return &FeePassThrough{percentage: percentage}, nil
+}
+
+// Percentage returns the fee percentage quoted for the invoice.
+func (f *FeePassThrough) Percentage() decimal.Decimal {
+	return f.percentage
+}
+
+// Amount returns the fee added to a price so that, once the settlement is charged the fee percentage on the
+// price and the fee together, the merchant still receives the price. It is rounded up to the cent.
+func (f *FeePassThrough) Amount(price *shared.Money) (*shared.Money, error) {
+	rate := f.percentage.Div(maxFeePercentage.Sub(f.percentage))
+	fee := price.Amount().Mul(rate).RoundCeil(2)
+	return shared.NewMoney(fee.StringFixed(2), shared.Currency(price.Currency()))
+}
+
+// Price adds the fee to a pricing, replacing the fee it had.
+func (f *FeePassThrough) Price(pricing *InvoicePricing) (*InvoicePricing, error) {
+	fee, err := f.Amount(pricing.TotalBeforeFee())
+	if err != nil {
+		return nil, err
+	}
+	return pricing.WithFee(fee)
+}
+
+// FeePassThrough returns the platform fee the customer pays on top of the price, or nil if the merchant absorbs
+// it.
+func (i *Invoice) FeePassThrough() *FeePassThrough {
+	return i.feePassThrough
+}
+
+// SetFeePassThrough sets the passed-through fee (for creation and repository restoration).
+// It does not reprice the invoice.
+func (i *Invoice) SetFeePassThrough(fee *FeePassThrough) {
+	i.feePassThrough = fee
+}
+
+// passFeeThrough quotes the platform fee of the request's merchant on the network the invoice is paid on and adds
+// it to the pricing, when the request passes the fee through. Without a fee quoter the fee cannot be quoted, and
+// passing it through fails.
+func (s *InvoiceServiceImpl) passFeeThrough(
+	ctx context.Context,
+	req *CreateInvoiceRequest,
+	network shared.BlockchainNetwork,
+	pricing *InvoicePricing,
+) (*InvoicePricing, *FeePassThrough, error) {
+	if !req.PassFee {
+		return pricing, nil, nil
+	}
+	if s.fees == nil {
+		return nil, nil, invalidRequest(fmt.Errorf("%w: platform fees are not quoted", ErrInvalidFeePassThrough))
+	}
+
+	percentage, err := s.fees.QuoteFeePercentage(ctx, req.MerchantID, network, string(req.CryptoCurrency))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to quote platform fee: %w", err)
+	}
+	fee, err := NewFeePassThrough(percentage)
+	if err != nil {
+		return nil, nil, invalidRequest(err)
+	}
+	priced, err := fee.Price(pricing)
+	if err != nil {
+		return nil, nil, err
+	}
+	return priced, fee, nil
+}
@@ -0,0 +1,76 @@
+package invoice_test
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFeePassThrough(t *testing.T, percentage string) *invoice.FeePassThrough {
+	t.Helper()
+	fee, err := invoice.NewFeePassThrough(decimal.RequireFromString(percentage))
+	require.NoError(t, err)
+	return fee
+}
+
+func TestFeePassThrough(t *testing.T) {
+	t.Run("percentage is at least 0 and below 100", func(t *testing.T) {
+		for _, percentage := range []string{"-1", "100", "150"} {
+			_, err := invoice.NewFeePassThrough(decimal.RequireFromString(percentage))
+			require.ErrorIs(t, err, invoice.ErrInvalidFeePassThrough, percentage)
+		}
+
+		_, err := invoice.NewFeePassThrough(decimal.Zero)
+		require.NoError(t, err)
+	})
+
+	t.Run("fee leaves the merchant the price after settlement", func(t *testing.T) {
+		fee := newTestFeePassThrough(t, "2.5")
+		for _, tc := range []struct{ price, fee string }{
+			{"100.00", "2.57"},
+			{"19.99", "0.52"},
+			{"0.01", "0.01"},
+		} {
+			price, _ := shared.NewMoney(tc.price, shared.CurrencyUSD)
+			amount, err := fee.Amount(price)
+			require.NoError(t, err)
+			require.Equal(t, tc.fee, amount.String(), tc.price)
+
+			gross := price.Amount().Add(amount.Amount())
+			net := gross.Sub(gross.Mul(fee.Percentage()).Div(decimal.NewFromInt(100)))
+			require.True(t, net.GreaterThanOrEqual(price.Amount()), tc.price)
+		}
+	})
+
+	t.Run("Price adds a fee line on top of the total", func(t *testing.T) {
+		pricing := createTestInvoice().Pricing()
+
+		priced, err := newTestFeePassThrough(t, "2.5").Price(pricing)
+		require.NoError(t, err)
+		require.Equal(t, "2.83", priced.Fee().String())
+		require.Equal(t, "112.83", priced.Total().String())
+		require.Equal(t, "110.00", priced.TotalBeforeFee().String())
+		require.Equal(t, pricing.Tax().String(), priced.Tax().String())
+		require.False(t, priced.Equals(pricing))
+
+		repriced, err := newTestFeePassThrough(t, "1").Price(priced)
+		require.NoError(t, err)
+		require.Equal(t, "1.12", repriced.Fee().String())
+		require.Equal(t, "111.12", repriced.Total().String())
+	})
+
+	t.Run("coupons recalculate the fee", func(t *testing.T) {
+		testInvoice := createTestInvoice()
+		testInvoice.SetFeePassThrough(newTestFeePassThrough(t, "2.5"))
+
+		require.NoError(t, testInvoice.ApplyCoupon(newTestCoupon(t, invoice.DiscountTypePercentage, "10")))
+
+		pricing := testInvoice.Pricing()
+		require.Equal(t, "99.00", pricing.TotalBeforeFee().String())
+		require.Equal(t, "2.54", pricing.Fee().String())
+		require.Equal(t, "101.54", pricing.Total().String())
+	})
+}
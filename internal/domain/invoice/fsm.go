@@ -2,7 +2,7 @@ package invoice
 
 import (
 	"context"
-	"errors"
+	"crypto-checkout/internal/domain/shared"
 	"time"
 
 	"github.com/looplab/fsm"
@@ -32,6 +32,10 @@ func NewInvoiceFSM(invoice *Invoice) *InvoiceFSM {
 // createInvoiceEvents defines the events and their transitions for invoice FSM.
 func createInvoiceEvents() fsm.Events {
 	return fsm.Events{
+		// From draft state
+		{Name: "finalize", Src: []string{"draft"}, Dst: "created"},
+		{Name: "cancel", Src: []string{"draft"}, Dst: "cancelled"},
+
 		// From created state
 		{Name: "view", Src: []string{"created"}, Dst: "pending"},
 		{Name: "expire", Src: []string{"created"}, Dst: "expired"},
@@ -46,13 +50,26 @@ func createInvoiceEvents() fsm.Events {
 		// From partial state
 		{Name: "full_payment", Src: []string{"partial"}, Dst: "confirming"},
 		{Name: "cancel", Src: []string{"partial"}, Dst: "cancelled"},
+		{Name: "close_underpaid", Src: []string{"partial"}, Dst: "underpaid_closed"},
 
 		// From confirming state
 		{Name: "confirm", Src: []string{"confirming"}, Dst: "paid"},
 		{Name: "reorg", Src: []string{"confirming"}, Dst: "pending"}, // blockchain reorganization
 
-		// From paid state
-		{Name: "refund", Src: []string{"paid"}, Dst: "refunded"},
+		// From paid and partially refunded states
+		{Name: "partial_refund", Src: []string{"paid"}, Dst: "partially_refunded"},
+		{Name: "refund", Src: []string{"paid", "partially_refunded"}, Dst: "refunded"},
+
+		// A paid invoice whose settlement could not be paid out goes back to awaiting confirmation
+		{Name: "reopen", Src: []string{"paid"}, Dst: "confirming"},
+
+		// A confirmed payment removed by a deep reorganization reopens the invoice
+		{Name: "reverse", Src: []string{"partial", "paid", "partially_refunded", "underpaid_closed"}, Dst: "pending"},
+		{
+			Name: "reverse_partial",
+			Src:  []string{"confirming", "paid", "partially_refunded", "underpaid_closed"},
+			Dst:  "partial",
+		},
 	}
 }
 
@@ -66,6 +83,13 @@ func createInvoiceCallbacks() fsm.Callbacks {
 				}
 			}
 		},
+		"before_close_underpaid": func(_ context.Context, e *fsm.Event) {
+			if len(e.Args) > 0 {
+				if err := canCloseUnderpaid(e.Args[0].(*Invoice)); err != nil {
+					e.Cancel(err)
+				}
+			}
+		},
 		"before_cancel": func(_ context.Context, e *fsm.Event) {
 			if len(e.Args) > 0 {
 				if err := canCancel(e.Args[0].(*Invoice)); err != nil {
@@ -73,6 +97,13 @@ func createInvoiceCallbacks() fsm.Callbacks {
 				}
 			}
 		},
+		"before_finalize": func(_ context.Context, e *fsm.Event) {
+			if len(e.Args) > 0 {
+				if err := canFinalize(e.Args[0].(*Invoice)); err != nil {
+					e.Cancel(err)
+				}
+			}
+		},
 		"before_confirm": func(_ context.Context, e *fsm.Event) {
 			if len(e.Args) > 0 {
 				if err := canMarkPaid(e.Args[0].(*Invoice)); err != nil {
@@ -80,6 +111,13 @@ func createInvoiceCallbacks() fsm.Callbacks {
 				}
 			}
 		},
+		"before_partial_refund": func(_ context.Context, e *fsm.Event) {
+			if len(e.Args) > 0 {
+				if err := canRefund(e.Args[0].(*Invoice)); err != nil {
+					e.Cancel(err)
+				}
+			}
+		},
 		"before_refund": func(_ context.Context, e *fsm.Event) {
 			if len(e.Args) > 0 {
 				if err := canRefund(e.Args[0].(*Invoice)); err != nil {
@@ -90,7 +128,7 @@ func createInvoiceCallbacks() fsm.Callbacks {
 		"enter_paid": func(_ context.Context, e *fsm.Event) {
 			if len(e.Args) > 0 {
 				invoice := e.Args[0].(*Invoice)
-				now := time.Now().UTC()
+				now := shared.Now().UTC()
 				if invoice.paidAt == nil {
 					invoice.paidAt = &now
 				}
@@ -104,7 +142,7 @@ func createInvoiceCallbacks() fsm.Callbacks {
 				invoice := e.Args[0].(*Invoice)
 				// Update invoice status to match FSM state
 				invoice.status = InvoiceStatus(e.Dst)
-				invoice.updatedAt = time.Now().UTC()
+				invoice.updatedAt = shared.Now().UTC()
 			}
 		},
 	}
@@ -135,7 +173,8 @@ func (ifs *InvoiceFSM) CanTransitionTo(target InvoiceStatus) bool {
 func (ifs *InvoiceFSM) TransitionTo(target InvoiceStatus) error {
 	eventName := ifs.getEventForTarget(target)
 	if eventName == "" {
-		return errors.New("invalid transition to " + target.String())
+		return shared.NewError(shared.ErrInvalidState, ErrCodeInvalidTransition,
+			"invalid transition to "+target.String())
 	}
 
 	ctx := context.Background()
@@ -185,6 +224,10 @@ func (ifs *InvoiceFSM) getEventForTarget(target InvoiceStatus) string {
 
 	// Map state transitions to events
 	transitionMap := map[string]map[string]string{
+		"draft": {
+			"created":   "finalize",
+			"cancelled": "cancel",
+		},
 		"created": {
 			"pending":   "view",
 			"expired":   "expire",
@@ -197,15 +240,31 @@ func (ifs *InvoiceFSM) getEventForTarget(target InvoiceStatus) string {
 			"cancelled":  "cancel",
 		},
 		"partial": {
-			"confirming": "full_payment",
-			"cancelled":  "cancel",
+			"confirming":       "full_payment",
+			"cancelled":        "cancel",
+			"underpaid_closed": "close_underpaid",
+			"pending":          "reverse",
 		},
 		"confirming": {
 			"paid":    "confirm",
 			"pending": "reorg",
+			"partial": "reverse_partial",
 		},
 		"paid": {
+			"partially_refunded": "partial_refund",
+			"refunded":           "refund",
+			"confirming":         "reopen",
+			"pending":            "reverse",
+			"partial":            "reverse_partial",
+		},
+		"partially_refunded": {
 			"refunded": "refund",
+			"pending":  "reverse",
+			"partial":  "reverse_partial",
+		},
+		"underpaid_closed": {
+			"pending": "reverse",
+			"partial": "reverse_partial",
 		},
 	}
 
@@ -224,6 +283,10 @@ func (ifs *InvoiceFSM) getTargetForEvent(event string) string {
 
 	// Map events to target states
 	eventMap := map[string]map[string]string{
+		"draft": {
+			"finalize": "created",
+			"cancel":   "cancelled",
+		},
 		"created": {
 			"view":   "pending",
 			"expire": "expired",
@@ -236,15 +299,31 @@ func (ifs *InvoiceFSM) getTargetForEvent(event string) string {
 			"cancel":          "cancelled",
 		},
 		"partial": {
-			"full_payment": "confirming",
-			"cancel":       "cancelled",
+			"full_payment":    "confirming",
+			"cancel":          "cancelled",
+			"close_underpaid": "underpaid_closed",
+			"reverse":         "pending",
 		},
 		"confirming": {
-			"confirm": "paid",
-			"reorg":   "pending",
+			"confirm":         "paid",
+			"reorg":           "pending",
+			"reverse_partial": "partial",
 		},
 		"paid": {
-			"refund": "refunded",
+			"partial_refund":  "partially_refunded",
+			"refund":          "refunded",
+			"reopen":          "confirming",
+			"reverse":         "pending",
+			"reverse_partial": "partial",
+		},
+		"partially_refunded": {
+			"refund":          "refunded",
+			"reverse":         "pending",
+			"reverse_partial": "partial",
+		},
+		"underpaid_closed": {
+			"reverse":         "pending",
+			"reverse_partial": "partial",
 		},
 	}
 
@@ -263,12 +342,28 @@ func (ifs *InvoiceFSM) getTargetForEvent(event string) string {
 func CanExpire(invoice *Invoice) error {
 	// Cannot expire invoices with partial payments
 	if invoice.Status() == StatusPartial {
-		return errors.New("cannot auto-expire invoices with partial payments")
+		return ErrCannotExpireInvoice
 	}
 
 	// Check if invoice has actually expired
 	if !invoice.Expiration().IsExpired() {
-		return errors.New("invoice has not expired yet")
+		return shared.NewError(shared.ErrInvalidState, ErrCodeInvalidTransition, "invoice has not expired yet")
+	}
+
+	return nil
+}
+
+// CanCloseUnderpaid checks if a partially paid invoice can be closed underpaid.
+func CanCloseUnderpaid(invoice *Invoice) error {
+	// Only partially paid invoices are closed underpaid
+	if invoice.Status() != StatusPartial {
+		return shared.NewError(shared.ErrInvalidState, ErrCodeInvalidTransition,
+			"can only close partially paid invoices underpaid")
+	}
+
+	// The customer has until the expiry to complete the payment
+	if invoice.Expiration() == nil || !invoice.Expiration().IsExpired() {
+		return shared.NewError(shared.ErrInvalidState, ErrCodeInvalidTransition, "invoice has not expired yet")
 	}
 
 	return nil
@@ -278,7 +373,18 @@ func CanExpire(invoice *Invoice) error {
 func CanCancel(invoice *Invoice) error {
 	// Cannot cancel invoices in terminal states
 	if invoice.Status().IsTerminal() {
-		return errors.New("cannot cancel invoice in terminal state")
+		return ErrCannotCancelInvoice
+	}
+
+	return nil
+}
+
+// CanFinalize checks if a draft invoice can be finalized.
+func CanFinalize(invoice *Invoice) error {
+	// A finalized invoice must be payable
+	if invoice.PaymentAddress() == nil || invoice.ExchangeRate() == nil {
+		return shared.NewError(shared.ErrInvalidState, ErrCodeInvalidTransition,
+			"cannot finalize an invoice without a payment address and exchange rate")
 	}
 
 	return nil
@@ -288,7 +394,7 @@ func CanCancel(invoice *Invoice) error {
 func CanMarkPaid(invoice *Invoice) error {
 	// Can only mark confirming invoices as paid
 	if invoice.Status() != StatusConfirming {
-		return errors.New("can only mark confirming invoices as paid")
+		return ErrCannotMarkAsPaid
 	}
 
 	return nil
@@ -296,9 +402,12 @@ func CanMarkPaid(invoice *Invoice) error {
 
 // CanRefund checks if an invoice can be refunded.
 func CanRefund(invoice *Invoice) error {
-	// Can only refund paid invoices
-	if invoice.Status() != StatusPaid {
-		return errors.New("can only refund paid invoices")
+	// Can only refund paid invoices, including those already partially refunded, and the payments
+	// received for invoices closed underpaid
+	switch invoice.Status() {
+	case StatusPaid, StatusPartiallyRefunded, StatusUnderpaidClosed:
+	default:
+		return ErrCannotRefundInvoice
 	}
 
 	return nil
@@ -309,10 +418,18 @@ func canExpire(invoice *Invoice) error {
 	return CanExpire(invoice)
 }
 
+func canCloseUnderpaid(invoice *Invoice) error {
+	return CanCloseUnderpaid(invoice)
+}
+
 func canCancel(invoice *Invoice) error {
 	return CanCancel(invoice)
 }
 
+func canFinalize(invoice *Invoice) error {
+	return CanFinalize(invoice)
+}
+
 func canMarkPaid(invoice *Invoice) error {
 	return CanMarkPaid(invoice)
 }
@@ -341,7 +458,7 @@ func NewStatusTransition(
 	return &StatusTransition{
 		FromStatus: from,
 		ToStatus:   to,
-		Timestamp:  time.Now().UTC(),
+		Timestamp:  shared.Now().UTC(),
 		Reason:     reason,
 		Actor:      actor,
 		Metadata:   metadata,
@@ -402,7 +519,7 @@ func (ism *InvoiceStateMachine) TransitionTo(
 
 	// Update the invoice status
 	ism.invoice.status = target
-	ism.invoice.updatedAt = time.Now().UTC()
+	ism.invoice.updatedAt = shared.Now().UTC()
 
 	return nil
 }
@@ -428,7 +545,7 @@ func (ism *InvoiceStateMachine) Event(
 
 	// Update the invoice status
 	ism.invoice.status = toStatus
-	ism.invoice.updatedAt = time.Now().UTC()
+	ism.invoice.updatedAt = shared.Now().UTC()
 
 	return nil
 }
@@ -592,6 +592,10 @@ func TestGuardConditions(t *testing.T) {
 
 // Helper function to create a test invoice
 func createTestInvoice() *invoice.Invoice {
+	return createTestInvoiceWithOverpaymentAction(invoice.OverpaymentActionRefund)
+}
+
+func createTestInvoiceWithOverpaymentAction(action invoice.OverpaymentAction) *invoice.Invoice {
 	// Create test money amounts
 	subtotal, _ := shared.NewMoney("100.00", shared.CurrencyUSD)
 	tax, _ := shared.NewMoney("10.00", shared.CurrencyUSD)
@@ -616,7 +620,7 @@ func createTestInvoice() *invoice.Invoice {
 	)
 
 	// Create test payment tolerance
-	tolerance, _ := invoice.NewPaymentTolerance("0.95", "1.05", invoice.OverpaymentActionRefund)
+	tolerance, _ := invoice.NewPaymentTolerance("0.95", "1.05", action)
 
 	// Create test expiration
 	expiration := invoice.NewInvoiceExpiration(24 * time.Hour)
@@ -642,3 +646,25 @@ func createTestInvoice() *invoice.Invoice {
 
 	return testInvoice
 }
+
+func BenchmarkInvoiceFSM_Transitions(b *testing.B) {
+	for b.Loop() {
+		fsm := invoice.NewInvoiceFSM(createTestInvoice())
+		for _, status := range []invoice.InvoiceStatus{
+			invoice.StatusPending, invoice.StatusConfirming, invoice.StatusPaid,
+		} {
+			if err := fsm.TransitionTo(status); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkInvoiceFSM_CanTransitionTo(b *testing.B) {
+	fsm := invoice.NewInvoiceFSM(createTestInvoice())
+
+	for b.Loop() {
+		_ = fsm.CanTransitionTo(invoice.StatusPending)
+		_ = fsm.CanTransitionTo(invoice.StatusPaid)
+	}
+}
@@ -39,7 +39,7 @@ func CalculateRequiredConfirmations(amount *shared.Money) (*shared.ConfirmationC
 
 // CalculateInvoiceExpiration calculates the expiration time for an invoice.
 func CalculateInvoiceExpiration(duration time.Duration) time.Time {
-	return time.Now().UTC().Add(duration)
+	return shared.Now().UTC().Add(duration)
 }
 
 // IsInvoiceExpired checks if an invoice has expired.
@@ -69,6 +69,8 @@ func FormatInvoiceAmount(amount *shared.Money) string {
 // GetInvoiceDisplayStatus returns a user-friendly status message.
 func GetInvoiceDisplayStatus(status InvoiceStatus) string {
 	switch status {
+	case StatusDraft:
+		return "Draft"
 	case StatusCreated:
 		return "Processing..."
 	case StatusPending:
@@ -83,8 +85,12 @@ func GetInvoiceDisplayStatus(status InvoiceStatus) string {
 		return "Expired"
 	case StatusCancelled:
 		return "Cancelled"
+	case StatusPartiallyRefunded:
+		return "Partially Refunded"
 	case StatusRefunded:
 		return "Refunded"
+	case StatusUnderpaidClosed:
+		return "Closed Underpaid"
 	default:
 		return "Unknown"
 	}
@@ -97,68 +103,28 @@ func GetInvoiceStatusColor(status InvoiceStatus) string {
 		return "#3B82F6" // Blue
 	case StatusPartial, StatusConfirming:
 		return "#F59E0B" // Yellow
-	case StatusPaid:
+	case StatusPaid, StatusPartiallyRefunded:
 		return "#10B981" // Green
-	case StatusExpired, StatusCancelled, StatusRefunded:
+	case StatusExpired, StatusCancelled, StatusRefunded, StatusUnderpaidClosed:
 		return "#EF4444" // Red
 	default:
 		return "#6B7280" // Gray
 	}
 }
 
-// ValidateInvoiceMetadata validates invoice metadata.
+// ValidateInvoiceMetadata validates invoice metadata against the DefaultMetadataPolicy.
 func ValidateInvoiceMetadata(metadata map[string]interface{}) error {
-	if metadata == nil {
-		return nil
-	}
-
-	// Check for reserved keys
-	reservedKeys := map[string]bool{
-		"id":          true,
-		"merchant_id": true,
-		"customer_id": true,
-		"status":      true,
-		"created_at":  true,
-		"updated_at":  true,
-		"paid_at":     true,
-		"expires_at":  true,
-	}
-
-	for key := range metadata {
-		if reservedKeys[key] {
-			return ErrInvalidInvoiceID
-		}
-	}
-
-	return nil
+	return DefaultMetadataPolicy().Validate(metadata)
 }
 
-// SanitizeInvoiceMetadata sanitizes invoice metadata by removing invalid entries.
+// SanitizeInvoiceMetadata sanitizes invoice metadata by removing reserved keys.
 func SanitizeInvoiceMetadata(metadata map[string]interface{}) map[string]interface{} {
-	if metadata == nil {
-		return make(map[string]interface{})
-	}
-
-	sanitized := make(map[string]interface{})
-
-	// Reserved keys that should not be in metadata
-	reservedKeys := map[string]bool{
-		"id":          true,
-		"merchant_id": true,
-		"customer_id": true,
-		"status":      true,
-		"created_at":  true,
-		"updated_at":  true,
-		"paid_at":     true,
-		"expires_at":  true,
-	}
-
+	sanitized := make(map[string]interface{}, len(metadata))
 	for key, value := range metadata {
-		if !reservedKeys[key] {
+		if !reservedMetadataKeys[key] {
 			sanitized[key] = value
 		}
 	}
-
 	return sanitized
 }
 
@@ -281,27 +247,28 @@ func GetInvoiceQRData(invoice *Invoice) string {
 		return ""
 	}
 
-	cryptoAmount, err := invoice.GetCryptoAmount()
+	cryptoAmount, err := invoice.PayableCryptoAmount()
 	if err != nil {
 		return ""
 	}
-
-	// Generate QR code data based on cryptocurrency
-	switch invoice.CryptoCurrency() {
-	case shared.CryptoCurrencyUSDT:
-		return generateUSDTQRData(invoice.PaymentAddress().Address(), cryptoAmount.String())
-	case shared.CryptoCurrencyBTC:
-		return generateBTCQRData(invoice.PaymentAddress().Address(), cryptoAmount.String())
-	case shared.CryptoCurrencyETH:
-		return generateETHQRData(invoice.PaymentAddress().Address(), cryptoAmount.String())
+	amount := invoice.FormatPayableAmount(cryptoAmount)
+
+	// Generate QR code data based on the network the invoice is paid on
+	switch invoice.PaymentAddress().Network().Mainnet() {
+	case shared.NetworkTron:
+		return generateTronQRData(invoice.PaymentAddress().Address(), amount)
+	case shared.NetworkBitcoin:
+		return generateBTCQRData(invoice.PaymentAddress().Address(), amount)
+	case shared.NetworkEthereum, shared.NetworkBSC:
+		return generateETHQRData(invoice.PaymentAddress().Address(), amount)
 	default:
 		return ""
 	}
 }
 
-// generateUSDTQRData generates QR code data for USDT payments.
-func generateUSDTQRData(address, amount string) string {
-	// Tron USDT QR format: tron:address?amount=amount
+// generateTronQRData generates QR code data for payments on Tron.
+func generateTronQRData(address, amount string) string {
+	// Tron QR format: tron:address?amount=amount
 	return "tron:" + address + "?amount=" + amount
 }
 
@@ -311,7 +278,7 @@ func generateBTCQRData(address, amount string) string {
 	return "bitcoin:" + address + "?amount=" + amount
 }
 
-// generateETHQRData generates QR code data for ETH payments.
+// generateETHQRData generates QR code data for payments on Ethereum and other EVM networks.
 func generateETHQRData(address, amount string) string {
 	// Ethereum QR format: ethereum:address?amount=amount
 	return "ethereum:" + address + "?amount=" + amount
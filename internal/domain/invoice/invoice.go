@@ -10,6 +10,7 @@ import (
 // Invoice represents the main invoice aggregate root.
 type Invoice struct {
 	id               string
+	number           string // Accounting number, allocated when the invoice is issued
 	merchantID       string
 	customerID       *string
 	title            string
@@ -18,6 +19,8 @@ type Invoice struct {
 	pricing          *InvoicePricing
 	cryptoCurrency   shared.CryptoCurrency
 	paymentAddress   *shared.PaymentAddress
+	paymentReference *PaymentReference        // Tells apart the invoices paid to one address
+	network          shared.BlockchainNetwork // Network of a draft, which has no payment address yet
 	status           InvoiceStatus
 	exchangeRate     *shared.ExchangeRate
 	paymentTolerance *PaymentTolerance
@@ -27,6 +30,26 @@ type Invoice struct {
 	paidAt           *time.Time
 	viewedAt         *time.Time
 	metadata         map[string]interface{}
+	customFields     []*CustomField // Labelled values shown to customers, unlike metadata
+	amountPaid       *shared.Money
+	refunds          []*Refund
+	requotes         []*Requote
+	extensions       []*Extension
+	staleRateChecks  []*StaleRateCheck
+	reversals        []*PaymentReversal
+	refundDest       *RefundDestination // Where refunds are sent, once captured or supplied
+	discount         *Discount
+	coupon           *AppliedCoupon
+	taxTreatment     *TaxTreatment
+	invoiceType      InvoiceType
+	minimumAmount    *shared.Money
+	supersedes       *string // Invoice this one replaced when it was amended
+	supersededBy     *string // Invoice that replaced this one when it was amended
+	returnURL        *string // Where customers are sent back to the merchant
+	cancelURL        *string // Where customers who give up on paying are sent
+	settlementSplit  *SettlementSplit
+	feePassThrough   *FeePassThrough // Platform fee the customer pays on top of the price
+	version          int             // Number of updates stored, checked so concurrent updates are not lost
 }
 
 // InvoiceValidation represents the validation structure for Invoice creation.
@@ -79,7 +102,7 @@ func NewInvoice(
 		return nil, err
 	}
 
-	now := time.Now().UTC()
+	now := shared.Now().UTC()
 	return &Invoice{
 		id:               id,
 		merchantID:       merchantID,
@@ -90,6 +113,7 @@ func NewInvoice(
 		cryptoCurrency:   cryptoCurrency,
 		paymentAddress:   paymentAddress,
 		status:           StatusCreated,
+		invoiceType:      InvoiceTypeStandard,
 		exchangeRate:     exchangeRate,
 		paymentTolerance: paymentTolerance,
 		expiration:       expiration,
@@ -117,7 +141,7 @@ func (i *Invoice) CustomerID() *string {
 // SetCustomerID sets the customer ID.
 func (i *Invoice) SetCustomerID(customerID string) {
 	i.customerID = &customerID
-	i.updatedAt = time.Now().UTC()
+	i.updatedAt = shared.Now().UTC()
 }
 
 // Title returns the invoice title.
@@ -198,31 +222,36 @@ func (i *Invoice) Metadata() map[string]interface{} {
 // SetViewedAt sets the viewed timestamp.
 func (i *Invoice) SetViewedAt(viewedAt *time.Time) {
 	i.viewedAt = viewedAt
-	i.updatedAt = time.Now().UTC()
+	i.updatedAt = shared.Now().UTC()
 }
 
 // SetStatus sets the invoice status.
 func (i *Invoice) SetStatus(status InvoiceStatus) {
 	i.status = status
-	i.updatedAt = time.Now().UTC()
+	i.updatedAt = shared.Now().UTC()
 }
 
 // SetPaidAt sets the paid timestamp.
 func (i *Invoice) SetPaidAt(paidAt *time.Time) {
 	i.paidAt = paidAt
-	i.updatedAt = time.Now().UTC()
+	i.updatedAt = shared.Now().UTC()
 }
 
 // SetExpiration sets the invoice expiration.
 func (i *Invoice) SetExpiration(expiration *InvoiceExpiration) {
 	i.expiration = expiration
-	i.updatedAt = time.Now().UTC()
+	i.updatedAt = shared.Now().UTC()
 }
 
 // SetMetadata sets the invoice metadata.
 func (i *Invoice) SetMetadata(metadata map[string]interface{}) {
 	i.metadata = metadata
-	i.updatedAt = time.Now().UTC()
+	i.updatedAt = shared.Now().UTC()
+}
+
+// SetCreatedAt sets the creation timestamp of an invoice restored from storage.
+func (i *Invoice) SetCreatedAt(createdAt time.Time) {
+	i.createdAt = createdAt
 }
 
 // SetUpdatedAt sets the updated timestamp.
@@ -230,6 +259,16 @@ func (i *Invoice) SetUpdatedAt(updatedAt time.Time) {
 	i.updatedAt = updatedAt
 }
 
+// Version returns the number of updates stored for the invoice when it was loaded.
+func (i *Invoice) Version() int {
+	return i.version
+}
+
+// SetVersion sets the version of an invoice restored from storage or just updated.
+func (i *Invoice) SetVersion(version int) {
+	i.version = version
+}
+
 // GetCryptoAmount returns the cryptocurrency amount for this invoice.
 func (i *Invoice) GetCryptoAmount() (*shared.Money, error) {
 	return i.exchangeRate.Convert(i.pricing.Total())
@@ -5,7 +5,7 @@ import (
 	"crypto-checkout/internal/domain/payment"
 	"crypto-checkout/internal/domain/shared"
 	"errors"
-	"strings"
+	"fmt"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -14,21 +14,79 @@ import (
 
 // InvoiceServiceImpl implements the InvoiceService interface.
 type InvoiceServiceImpl struct {
-	repository Repository
-	eventBus   shared.EventBus
-	logger     *zap.Logger
+	repository    Repository
+	eventBus      shared.EventBus
+	reviewQueue   ReviewQueue
+	rates         RateProvider
+	requotes      RequotePolicy
+	tokens        *shared.TokenRegistry
+	currencies    MerchantCurrencies
+	extensions    MerchantExtensionPolicies
+	underpayments MerchantUnderpaymentPolicies
+	metadata      MerchantMetadataPolicies
+	verifier      RefundAddressVerifier
+	beneficiaries MerchantBeneficiaries
+	fees          FeeQuoter
+	ids           shared.IDGenerator
+	logger        *zap.Logger
 }
 
 // NewInvoiceService creates a new InvoiceService implementation.
-func NewInvoiceService(repository Repository, eventBus shared.EventBus, logger *zap.Logger) InvoiceService {
+// The review queue may be nil, in which case underpayments outside tolerance, and stale rate payments routed to
+// review, are only rejected.
+// The token registry may be nil, in which case invoices are paid in the shared.DefaultTokens.
+// The merchant currencies may be nil, in which case merchants accept every token enabled for them.
+// The extension policies may be nil, in which case every merchant has the DefaultExtensionPolicy.
+// The metadata policies may be nil, in which case every merchant has the DefaultMetadataPolicy.
+// The refund address verifier may be nil, in which case customers cannot sign the refund addresses they supply.
+// The beneficiaries may be nil, in which case any other merchant can be named as a settlement split beneficiary.
+// The fee quoter may be nil, in which case invoices cannot pass the platform fee through to customers.
+// The ID generator may be nil, in which case invoices and refunds get random IDs.
+func NewInvoiceService(
+	repository Repository,
+	eventBus shared.EventBus,
+	reviewQueue ReviewQueue,
+	rates RateProvider,
+	requotes RequotePolicy,
+	tokens *shared.TokenRegistry,
+	currencies MerchantCurrencies,
+	extensions MerchantExtensionPolicies,
+	underpayments MerchantUnderpaymentPolicies,
+	metadata MerchantMetadataPolicies,
+	verifier RefundAddressVerifier,
+	beneficiaries MerchantBeneficiaries,
+	fees FeeQuoter,
+	ids shared.IDGenerator,
+	logger *zap.Logger,
+) InvoiceService {
 	logger.Info("Creating InvoiceService",
 		zap.Bool("eventBus_provided", eventBus != nil),
-		zap.Bool("repository_provided", repository != nil))
+		zap.Bool("repository_provided", repository != nil),
+		zap.Bool("review_queue_provided", reviewQueue != nil))
+
+	if tokens == nil {
+		tokens = shared.DefaultTokenRegistry()
+	}
+	if ids == nil {
+		ids = shared.RandomIDGenerator{}
+	}
 
 	return &InvoiceServiceImpl{
-		repository: repository,
-		eventBus:   eventBus,
-		logger:     logger,
+		repository:    repository,
+		eventBus:      eventBus,
+		reviewQueue:   reviewQueue,
+		rates:         rates,
+		requotes:      requotes,
+		tokens:        tokens,
+		currencies:    currencies,
+		extensions:    extensions,
+		underpayments: underpayments,
+		metadata:      metadata,
+		verifier:      verifier,
+		beneficiaries: beneficiaries,
+		fees:          fees,
+		ids:           ids,
+		logger:        logger,
 	}
 }
 
@@ -37,25 +95,50 @@ func (s *InvoiceServiceImpl) CreateInvoice(ctx context.Context, req *CreateInvoi
 	if err := s.validateCreateInvoiceRequest(req); err != nil {
 		return nil, err
 	}
+	if err := s.validateMetadata(ctx, req.MerchantID, req.Metadata, req.RecordedMetadata); err != nil {
+		return nil, err
+	}
+
+	token, err := s.ResolveToken(ctx, req.MerchantID, req.CryptoCurrency, req.Network)
+	if err != nil {
+		return nil, err
+	}
+	if req.LiveKey && token.Network.IsTestnet() {
+		return nil, fmt.Errorf("%w: %s", ErrLiveKeyTestnet, token.Network)
+	}
 
 	items, pricing, err := s.buildInvoiceItemsAndPricing(req)
 	if err != nil {
 		return nil, err
 	}
+	pricing, fee, err := s.passFeeThrough(ctx, req, token.Network, pricing)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateSettlementSplit(ctx, req.MerchantID, req.SettlementSplit, pricing.Total()); err != nil {
+		return nil, err
+	}
+
+	if req.Draft {
+		return s.createDraft(ctx, req, token, items, pricing, fee)
+	}
 
 	exchangeRate, err := s.getExchangeRate(ctx, req.Currency, req.CryptoCurrency)
 	if err != nil {
 		return nil, err
 	}
 
-	paymentAddress, err := s.generatePaymentAddress(ctx, req.CryptoCurrency)
+	paymentAddress, err := s.generatePaymentAddress(ctx, token)
 	if err != nil {
 		return nil, err
 	}
 
 	paymentTolerance := s.getPaymentTolerance(req)
 	expiration := s.getExpiration(req)
-	invoiceID := s.generateInvoiceID()
+	invoiceID, err := s.ids.NewID(ctx, shared.IDKindInvoice, req.MerchantID)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := s.validateInvoiceComponents(invoiceID, req, items, pricing, paymentAddress, exchangeRate, paymentTolerance, expiration); err != nil {
 		return nil, err
@@ -67,47 +150,93 @@ func (s *InvoiceServiceImpl) CreateInvoice(ctx context.Context, req *CreateInvoi
 	if err != nil {
 		return nil, err
 	}
+	invoice.SetFeePassThrough(fee)
+	if err := assignPaymentReference(invoice, token); err != nil {
+		return nil, err
+	}
 
 	if err := s.repository.Save(ctx, invoice); err != nil {
 		return nil, err
 	}
 
-	// Publish invoice created event
-	if s.eventBus != nil {
-		eventData := createInvoiceEventData(invoice)
-		eventData["timestamp"] = time.Now().UTC()
-		event := shared.CreateDomainEvent(shared.EventTypeInvoiceCreated, invoice.ID(), "Invoice", eventData, nil)
-		if err := s.eventBus.PublishEvent(ctx, event); err != nil {
-			// Log error but don't fail the operation
-			if s.logger != nil {
-				s.logger.Error("Failed to publish domain event",
-					zap.String("event_type", shared.EventTypeInvoiceCreated),
-					zap.String("aggregate_id", invoice.ID()),
-					zap.Error(err),
-				)
-			}
-		}
+	s.publishInvoiceCreated(ctx, invoice)
+	return invoice, nil
+}
+
+// publishInvoiceCreated publishes the event announcing a payable invoice.
+func (s *InvoiceServiceImpl) publishInvoiceCreated(ctx context.Context, invoice *Invoice) {
+	if s.eventBus == nil {
+		return
 	}
 
-	return invoice, nil
+	event := shared.NewInvoiceCreatedEvent(createInvoiceEventData(invoice))
+	if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+		// Log error but don't fail the operation
+		if s.logger != nil {
+			s.logger.Error("Failed to publish domain event",
+				zap.String("event_type", shared.EventTypeInvoiceCreated),
+				zap.String("aggregate_id", invoice.ID()),
+				zap.Error(err),
+			)
+		}
+	}
 }
 
 // validateCreateInvoiceRequest validates the basic request parameters.
 func (s *InvoiceServiceImpl) validateCreateInvoiceRequest(req *CreateInvoiceRequest) error {
 	if req == nil {
-		return errors.New("create invoice request cannot be nil")
+		return shared.NewFieldError("", "create invoice request cannot be nil")
 	}
 	if req.MerchantID == "" {
-		return errors.New("merchant ID is required")
+		return shared.NewFieldError("merchant_id", "merchant ID is required")
 	}
 	if req.Title == "" {
-		return errors.New("title is required")
+		return shared.NewFieldError("title", "title is required")
+	}
+	if req.Type != "" && !req.Type.IsValid() {
+		return fmt.Errorf("%w: unknown invoice type %q", ErrInvalidRequest, req.Type)
 	}
-	if len(req.Items) == 0 {
-		return errors.New("at least one item is required")
+	if req.Type == InvoiceTypeDonation {
+		if err := validateDonationRequest(req); err != nil {
+			return err
+		}
+	} else if len(req.Items) == 0 {
+		return shared.NewFieldError("items", "at least one item is required")
 	}
 	if !req.CryptoCurrency.IsValid() {
-		return errors.New("invalid cryptocurrency")
+		return shared.WrapFieldError("crypto_currency", ErrInvalidCryptocurrency)
+	}
+	if err := validateRedirectURL("return URL", req.ReturnURL); err != nil {
+		return err
+	}
+	if err := validateRedirectURL("cancel URL", req.CancelURL); err != nil {
+		return err
+	}
+	if err := ValidateCustomFields(req.CustomFields); err != nil {
+		return invalidRequest(err)
+	}
+	return nil
+}
+
+// validateDonationRequest checks a donation has a positive minimum and nothing that prices a fixed total.
+func validateDonationRequest(req *CreateInvoiceRequest) error {
+	if req.MinimumAmount == nil || req.MinimumAmount.IsZero() {
+		return fmt.Errorf("%w: donations require a positive minimum amount", ErrInvalidRequest)
+	}
+	if req.MinimumAmount.Currency() != req.Currency.String() {
+		return fmt.Errorf("%w: minimum amount must be in the invoice currency", ErrInvalidRequest)
+	}
+	if len(req.Items) > 0 {
+		return fmt.Errorf("%w: donations cannot have items", ErrInvalidRequest)
+	}
+	if req.Discount != nil || req.Coupon != nil {
+		return fmt.Errorf("%w: donations cannot be discounted", ErrInvalidRequest)
+	}
+	if req.Tax != nil || req.TaxPolicy != nil || req.TaxRate != "" {
+		return fmt.Errorf("%w: donations cannot be taxed", ErrInvalidRequest)
+	}
+	if req.PassFee {
+		return fmt.Errorf("%w: donations cannot pass the platform fee through", ErrInvalidRequest)
 	}
 	return nil
 }
@@ -116,19 +245,30 @@ func (s *InvoiceServiceImpl) validateCreateInvoiceRequest(req *CreateInvoiceRequ
 func (s *InvoiceServiceImpl) buildInvoiceItemsAndPricing(
 	req *CreateInvoiceRequest,
 ) ([]*InvoiceItem, *InvoicePricing, error) {
+	// Donations start at the minimum until the customer chooses an amount
+	if req.Type == InvoiceTypeDonation {
+		return donationItemsAndPricing(req.Title, req.MinimumAmount)
+	}
+
 	items := make([]*InvoiceItem, 0, len(req.Items))
 	subtotal := decimal.Zero
 
 	for _, itemReq := range req.Items {
-		item, err := NewInvoiceItem(
+		item, err := NewInvoiceItemWithDiscount(
 			itemReq.Name,
 			itemReq.Description,
 			itemReq.Quantity,
 			itemReq.UnitPrice,
+			itemReq.Discount,
 		)
 		if err != nil {
 			return nil, nil, err
 		}
+		category, err := NormalizeTaxCategory(itemReq.TaxCategory)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
+		item.SetTaxCategory(category)
 		items = append(items, item)
 		subtotal = subtotal.Add(item.TotalPrice().Amount())
 	}
@@ -138,22 +278,43 @@ func (s *InvoiceServiceImpl) buildInvoiceItemsAndPricing(
 		return nil, nil, err
 	}
 
+	invoiceDiscount, couponDiscount, err := calculateDiscounts(subtotalMoney, req.Discount, req.Coupon)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	discountMoney, err := invoiceDiscount.Add(couponDiscount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	taxableMoney, err := subtotalMoney.Subtract(discountMoney)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var taxMoney *shared.Money
-	if req.Tax != nil {
+	switch {
+	case req.Tax != nil:
 		taxMoney = req.Tax
-	} else {
-		taxMoney, err = shared.NewMoney("0.00", req.Currency)
+	case req.TaxPolicy != nil:
+		taxMoney, err = applyTaxPolicy(items, req.TaxPolicy, discountMoney)
 		if err != nil {
 			return nil, nil, err
 		}
+	default:
+		taxMoney, err = shared.NewTaxCalculator().CalculateTaxFromRate(req.TaxRate, taxableMoney)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
 	}
 
-	totalMoney, err := subtotalMoney.Add(taxMoney)
+	totalMoney, err := taxableMoney.Add(taxMoney)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	pricing, err := NewInvoicePricing(subtotalMoney, taxMoney, totalMoney)
+	pricing, err := NewInvoicePricingWithDiscount(subtotalMoney, discountMoney, taxMoney, totalMoney)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -190,37 +351,45 @@ func (s *InvoiceServiceImpl) validateInvoiceComponents(
 	expiration *InvoiceExpiration,
 ) error {
 	if invoiceID == "" {
-		return errors.New("invoice ID cannot be empty")
-	}
-	if len(req.Title) > 255 {
-		return errors.New("title cannot exceed 255 characters")
+		return shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
 	}
-	if len(req.Description) > 1000 {
-		return errors.New("description cannot exceed 1000 characters")
+	if err := validateInvoiceText(req.Title, req.Description); err != nil {
+		return err
 	}
 	if len(items) == 0 {
-		return errors.New("invoice must have at least one item")
+		return shared.WrapFieldError("items", ErrNoItems)
 	}
 	if pricing == nil {
-		return errors.New("pricing cannot be nil")
+		return shared.NewFieldError("pricing", "pricing cannot be nil")
 	}
 	if paymentAddress == nil {
-		return errors.New("payment address cannot be nil")
+		return shared.NewFieldError("payment_address", "payment address cannot be nil")
 	}
 	if exchangeRate == nil {
-		return errors.New("exchange rate cannot be nil")
+		return shared.NewFieldError("exchange_rate", "exchange rate cannot be nil")
 	}
 	if paymentTolerance == nil {
-		return errors.New("payment tolerance cannot be nil")
+		return shared.NewFieldError("payment_tolerance", "payment tolerance cannot be nil")
 	}
 	if expiration == nil {
-		return errors.New("expiration cannot be nil")
+		return shared.NewFieldError("expiration", "expiration cannot be nil")
 	}
 	if exchangeRate.IsExpired() {
-		return errors.New("exchange rate has expired")
+		return ErrExpiredExchangeRate
 	}
 	if paymentAddress.IsExpired() {
-		return errors.New("payment address has expired")
+		return ErrExpiredPaymentAddress
+	}
+	return nil
+}
+
+// validateInvoiceText checks the title and description fit their columns.
+func validateInvoiceText(title, description string) error {
+	if len(title) > 255 {
+		return shared.NewFieldError("title", "title cannot exceed 255 characters")
+	}
+	if len(description) > 1000 {
+		return shared.NewFieldError("description", "description cannot exceed 1000 characters")
 	}
 	return nil
 }
@@ -254,17 +423,40 @@ func (s *InvoiceServiceImpl) buildInvoice(
 		return nil, err
 	}
 
+	applyRequestTerms(invoice, req)
+	return invoice, nil
+}
+
+// applyRequestTerms sets the customer, type, redirect URLs, settlement split, discounts and tax treatment of the
+// request on a new invoice.
+func applyRequestTerms(invoice *Invoice, req *CreateInvoiceRequest) {
 	if req.CustomerID != nil {
 		invoice.SetCustomerID(*req.CustomerID)
 	}
 
-	return invoice, nil
+	if req.Type == InvoiceTypeDonation {
+		invoice.SetType(InvoiceTypeDonation)
+		invoice.SetMinimumAmount(req.MinimumAmount)
+	}
+
+	if req.Supersedes != "" {
+		invoice.SetSupersedes(req.Supersedes)
+	}
+
+	invoice.SetRedirectURLs(req.ReturnURL, req.CancelURL)
+	invoice.SetCustomFields(req.CustomFields)
+	invoice.SetSettlementSplit(req.SettlementSplit)
+	invoice.SetDiscount(req.Discount)
+	invoice.SetCoupon(req.Coupon)
+	if req.Tax == nil && req.TaxPolicy != nil {
+		invoice.SetTaxTreatment(req.TaxPolicy.Treatment())
+	}
 }
 
 // GetInvoice retrieves an invoice by ID.
 func (s *InvoiceServiceImpl) GetInvoice(ctx context.Context, id string) (*Invoice, error) {
 	if id == "" {
-		return nil, errors.New("invoice ID cannot be empty")
+		return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
 	}
 
 	return s.repository.FindByID(ctx, id)
@@ -276,7 +468,46 @@ func (s *InvoiceServiceImpl) GetInvoiceByPaymentAddress(
 	address *shared.PaymentAddress,
 ) (*Invoice, error) {
 	if address == nil {
-		return nil, errors.New("payment address cannot be nil")
+		return nil, shared.NewFieldError("payment_address", "payment address cannot be nil")
+	}
+
+	return s.repository.FindByPaymentAddress(ctx, address)
+}
+
+// FindInvoiceForTransfer retrieves the invoice a transfer to a payment address pays. Invoices sharing the
+// address are told apart by their payment reference: the invoice whose reference code is the transfer memo
+// comes first, then the invoice whose fingerprinted amount is the transfer amount. Without either, it is the
+// invoice found by the address alone.
+func (s *InvoiceServiceImpl) FindInvoiceForTransfer(
+	ctx context.Context,
+	address *shared.PaymentAddress,
+	memo string,
+	amount *shared.Money,
+) (*Invoice, error) {
+	if address == nil {
+		return nil, shared.NewFieldError("payment_address", "payment address cannot be nil")
+	}
+
+	if code := NormalizeReference(memo); code != "" {
+		invoice, err := s.repository.FindByPaymentReference(ctx, code)
+		if err != nil && !errors.Is(err, shared.ErrNotFound) {
+			return nil, err
+		}
+		if err == nil && address.Equals(invoice.PaymentAddress()) {
+			return invoice, nil
+		}
+	}
+
+	if amount != nil {
+		invoices, err := s.repository.FindPayableByPaymentAddress(ctx, address)
+		if err != nil {
+			return nil, err
+		}
+		for _, invoice := range invoices {
+			if match, ok := invoice.MatchesTransfer("", amount); ok && match == ReferenceMatchFingerprint {
+				return invoice, nil
+			}
+		}
 	}
 
 	return s.repository.FindByPaymentAddress(ctx, address)
@@ -290,31 +521,26 @@ func (s *InvoiceServiceImpl) ListInvoices(
 	if err := s.validateListInvoicesRequest(req); err != nil {
 		return nil, err
 	}
-
-	limit := s.normalizeLimit(req.Limit)
-	invoices, err := s.fetchInvoices(ctx, req)
-	if err != nil {
+	if err := s.validateMetadataFilters(ctx, req.MerchantID, req.Metadata); err != nil {
 		return nil, err
 	}
 
-	filteredInvoices := s.filterInvoices(invoices, req)
-	paginatedInvoices := s.paginateInvoices(filteredInvoices, req.Offset, limit)
+	page := *req
+	page.Limit = s.normalizeLimit(req.Limit)
+	if page.Offset < 0 || page.Cursor != nil {
+		page.Offset = 0
+	}
 
-	return &ListInvoicesResponse{
-		Invoices: paginatedInvoices,
-		Total:    len(filteredInvoices),
-		Limit:    limit,
-		Offset:   req.Offset,
-	}, nil
+	return s.repository.List(ctx, &page)
 }
 
 // validateListInvoicesRequest validates the list invoices request.
 func (s *InvoiceServiceImpl) validateListInvoicesRequest(req *ListInvoicesRequest) error {
 	if req == nil {
-		return errors.New("list invoices request cannot be nil")
+		return shared.NewFieldError("", "list invoices request cannot be nil")
 	}
 	if req.MerchantID == "" {
-		return errors.New("merchant ID is required")
+		return shared.NewFieldError("merchant_id", "merchant ID is required")
 	}
 	return nil
 }
@@ -330,79 +556,10 @@ func (s *InvoiceServiceImpl) normalizeLimit(limit int) int {
 	return limit
 }
 
-// fetchInvoices retrieves invoices from the repository.
-func (s *InvoiceServiceImpl) fetchInvoices(ctx context.Context, req *ListInvoicesRequest) ([]*Invoice, error) {
-	if req.Status != nil {
-		return s.repository.FindByStatus(ctx, *req.Status)
-	}
-	return s.repository.FindActive(ctx)
-}
-
-// filterInvoices applies filtering logic to the invoices.
-func (s *InvoiceServiceImpl) filterInvoices(invoices []*Invoice, req *ListInvoicesRequest) []*Invoice {
-	filteredInvoices := make([]*Invoice, 0)
-	for _, invoice := range invoices {
-		if s.shouldIncludeInvoice(invoice, req) {
-			filteredInvoices = append(filteredInvoices, invoice)
-		}
-	}
-	return filteredInvoices
-}
-
-// shouldIncludeInvoice determines if an invoice should be included in the results.
-func (s *InvoiceServiceImpl) shouldIncludeInvoice(invoice *Invoice, req *ListInvoicesRequest) bool {
-	// Filter by merchant ID
-	if invoice.MerchantID() != req.MerchantID {
-		return false
-	}
-
-	// Filter by customer ID if provided
-	if req.CustomerID != nil {
-		if invoice.CustomerID() == nil || *invoice.CustomerID() != *req.CustomerID {
-			return false
-		}
-	}
-
-	// Filter by date range if provided
-	if req.CreatedAfter != nil && invoice.CreatedAt().Before(*req.CreatedAfter) {
-		return false
-	}
-	if req.CreatedBefore != nil && invoice.CreatedAt().After(*req.CreatedBefore) {
-		return false
-	}
-
-	// Filter by search term if provided
-	if req.Search != nil {
-		if !s.matchesSearch(invoice, *req.Search) {
-			return false
-		}
-	}
-
-	return true
-}
-
-// paginateInvoices applies pagination to the filtered invoices.
-func (s *InvoiceServiceImpl) paginateInvoices(invoices []*Invoice, offset, limit int) []*Invoice {
-	start := offset
-	if start < 0 {
-		start = 0
-	}
-	end := start + limit
-	if end > len(invoices) {
-		end = len(invoices)
-	}
-
-	if start >= len(invoices) {
-		return []*Invoice{}
-	}
-
-	return invoices[start:end]
-}
-
 // MarkInvoiceAsViewed marks an invoice as viewed by the customer using FSM.
 func (s *InvoiceServiceImpl) MarkInvoiceAsViewed(ctx context.Context, id string) error {
 	if id == "" {
-		return errors.New("invoice ID cannot be empty")
+		return shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
 	}
 
 	invoice, err := s.repository.FindByID(ctx, id)
@@ -418,10 +575,10 @@ func (s *InvoiceServiceImpl) MarkInvoiceAsViewed(ctx context.Context, id string)
 
 	// Business logic validation
 	if invoice.Status() != StatusCreated {
-		return errors.New("can only mark created invoices as viewed")
+		return ErrCannotViewInvoice
 	}
 	if invoice.ViewedAt() != nil {
-		return errors.New("invoice already marked as viewed")
+		return ErrInvoiceAlreadyViewed
 	}
 
 	// Use FSM to transition from created to pending when viewed
@@ -431,7 +588,7 @@ func (s *InvoiceServiceImpl) MarkInvoiceAsViewed(ctx context.Context, id string)
 	}
 
 	// Mark as viewed (set viewedAt timestamp)
-	now := time.Now().UTC()
+	now := shared.Now().UTC()
 	invoice.SetViewedAt(&now)
 
 	if err := s.repository.Update(ctx, invoice); err != nil {
@@ -440,14 +597,13 @@ func (s *InvoiceServiceImpl) MarkInvoiceAsViewed(ctx context.Context, id string)
 
 	// Publish invoice status changed event
 	if s.eventBus != nil {
-		eventData := createInvoiceEventData(invoice)
-		eventData["from_status"] = StatusCreated.String()
-		eventData["to_status"] = invoice.Status().String()
-		eventData["reason"] = "viewed_by_customer"
-		eventData["viewed_at"] = now
-
-		eventData["timestamp"] = time.Now().UTC()
-		event := shared.CreateDomainEvent(shared.EventTypeInvoiceStatusChanged, invoice.ID(), "Invoice", eventData, nil)
+		event := shared.NewInvoiceStatusChangedEvent(shared.InvoiceStatusChangedEvent{
+			InvoiceEvent: createInvoiceEventData(invoice),
+			FromStatus:   StatusCreated.String(),
+			ToStatus:     invoice.Status().String(),
+			Reason:       "viewed_by_customer",
+			ViewedAt:     &now,
+		})
 		if err := s.eventBus.PublishEvent(ctx, event); err != nil {
 			// Log error but don't fail the operation
 			if s.logger != nil {
@@ -466,7 +622,7 @@ func (s *InvoiceServiceImpl) MarkInvoiceAsViewed(ctx context.Context, id string)
 // CancelInvoice cancels an invoice using FSM.
 func (s *InvoiceServiceImpl) CancelInvoice(ctx context.Context, id, reason string) error {
 	if id == "" {
-		return errors.New("invoice ID cannot be empty")
+		return shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
 	}
 
 	invoice, err := s.repository.FindByID(ctx, id)
@@ -476,7 +632,7 @@ func (s *InvoiceServiceImpl) CancelInvoice(ctx context.Context, id, reason strin
 
 	// Business logic validation
 	if invoice.Status().IsTerminal() {
-		return errors.New("cannot cancel invoice in terminal state")
+		return ErrCannotCancelInvoice
 	}
 
 	// Use FSM to transition to cancelled status
@@ -489,37 +645,41 @@ func (s *InvoiceServiceImpl) CancelInvoice(ctx context.Context, id, reason strin
 		return err
 	}
 
-	// Publish invoice cancelled event
-	if s.eventBus != nil {
-		eventData := createInvoiceEventData(invoice)
-		eventData["reason"] = reason
-		eventData["cancelled_at"] = time.Now().UTC()
+	s.publishInvoiceCancelled(ctx, invoice, reason)
+	return nil
+}
 
-		eventData["timestamp"] = time.Now().UTC()
-		event := shared.CreateDomainEvent(shared.EventTypeInvoiceCancelled, invoice.ID(), "Invoice", eventData, nil)
-		if err := s.eventBus.PublishEvent(ctx, event); err != nil {
-			// Log error but don't fail the operation
-			if s.logger != nil {
-				s.logger.Error("Failed to publish domain event",
-					zap.String("event_type", shared.EventTypeInvoiceCancelled),
-					zap.String("aggregate_id", invoice.ID()),
-					zap.Error(err),
-				)
-			}
-		}
+// publishInvoiceCancelled publishes the event announcing a cancelled invoice.
+func (s *InvoiceServiceImpl) publishInvoiceCancelled(ctx context.Context, invoice *Invoice, reason string) {
+	if s.eventBus == nil {
+		return
 	}
 
-	return nil
+	event := shared.NewInvoiceCancelledEvent(shared.InvoiceCancelledEvent{
+		InvoiceEvent: createInvoiceEventData(invoice),
+		Reason:       reason,
+		CancelledAt:  shared.Now().UTC(),
+	})
+	if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+		// Log error but don't fail the operation
+		if s.logger != nil {
+			s.logger.Error("Failed to publish domain event",
+				zap.String("event_type", shared.EventTypeInvoiceCancelled),
+				zap.String("aggregate_id", invoice.ID()),
+				zap.Error(err),
+			)
+		}
+	}
 }
 
 // ProcessPayment processes a payment for an invoice using FSM.
 func (s *InvoiceServiceImpl) ProcessPayment(ctx context.Context, invoiceID string, paymentTx *payment.Payment) error {
 	if invoiceID == "" {
-		return errors.New("invoice ID cannot be empty")
+		return shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
 	}
 
 	if paymentTx == nil {
-		return errors.New("payment cannot be nil")
+		return shared.NewFieldError("payment", "payment cannot be nil")
 	}
 
 	invoice, err := s.repository.FindByID(ctx, invoiceID)
@@ -527,9 +687,35 @@ func (s *InvoiceServiceImpl) ProcessPayment(ctx context.Context, invoiceID strin
 		return err
 	}
 
+	// A payment arriving after the locked rate expired takes the configured stale rate branch, which is
+	// recorded on the invoice whatever the outcome of the payment
+	if invoice.HasStaleRate() {
+		check, requote, err := s.guardStaleRate(ctx, invoice, paymentTx)
+		if err != nil {
+			return err
+		}
+		if err := s.repository.Update(ctx, invoice); err != nil {
+			return err
+		}
+		if requote != nil {
+			s.publishInvoiceRequoted(ctx, invoice, requote)
+		}
+		if check.Action() == StaleRateReview {
+			s.queueStaleRatePayment(ctx, invoice, paymentTx, check)
+			return fmt.Errorf("%w: %s", ErrStaleRate, check.Reason())
+		}
+	}
+
 	// Validate payment amount (business logic moved to service)
 	validationType, err := s.validatePaymentAmount(invoice, paymentTx.Amount().Amount())
 	if err != nil {
+		if errors.Is(err, ErrUnderpayment) {
+			s.queueUnderpayment(ctx, invoice, paymentTx)
+		}
+		return err
+	}
+
+	if err := invoice.RecordPayment(paymentTx.Amount().Amount()); err != nil {
 		return err
 	}
 
@@ -545,13 +731,13 @@ func (s *InvoiceServiceImpl) ProcessPayment(ctx context.Context, invoiceID strin
 
 	// Publish payment processed event
 	if s.eventBus != nil {
-		eventData := createInvoiceEventData(invoice)
-		eventData["payment_amount"] = paymentTx.Amount().Amount()
-		eventData["payment_validation"] = validationType
-		eventData["processed_at"] = time.Now().UTC()
-
-		eventData["timestamp"] = time.Now().UTC()
-		event := shared.CreateDomainEvent(shared.EventTypeInvoiceStatusChanged, invoice.ID(), "Invoice", eventData, nil)
+		processedAt := shared.Now().UTC()
+		event := shared.NewInvoiceStatusChangedEvent(shared.InvoiceStatusChangedEvent{
+			InvoiceEvent:      createInvoiceEventData(invoice),
+			PaymentAmount:     paymentTx.Amount().Amount().String(),
+			PaymentValidation: validationType,
+			ProcessedAt:       &processedAt,
+		})
 		if err := s.eventBus.PublishEvent(ctx, event); err != nil {
 			// Log error but don't fail the operation
 			if s.logger != nil {
@@ -584,9 +770,14 @@ func (s *InvoiceServiceImpl) ProcessExpiredInvoices(ctx context.Context) error {
 		if invoice.Status().IsTerminal() {
 			continue // Skip terminal invoices
 		}
-		// Special case: partial payments should not auto-expire
+		// Special case: partial payments are closed underpaid once the merchant's grace period passes
 		if invoice.Status() == StatusPartial {
-			continue // Skip partial payment invoices
+			if _, err := s.closeLapsedPartialInvoice(ctx, invoice); err != nil && s.logger != nil {
+				s.logger.Error("Failed to close underpaid invoice",
+					zap.String("invoice_id", invoice.ID()),
+					zap.Error(err))
+			}
+			continue
 		}
 		// Check if invoice has actually expired
 		if !invoice.Expiration().IsExpired() {
@@ -613,7 +804,7 @@ func (s *InvoiceServiceImpl) ProcessExpiredInvoices(ctx context.Context) error {
 // This is useful for testing and manual intervention.
 func (s *InvoiceServiceImpl) ProcessExpiredInvoice(ctx context.Context, id string) error {
 	if id == "" {
-		return errors.New("invoice ID cannot be empty")
+		return shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
 	}
 
 	invoice, err := s.repository.FindByID(ctx, id)
@@ -621,48 +812,43 @@ func (s *InvoiceServiceImpl) ProcessExpiredInvoice(ctx context.Context, id strin
 		return err
 	}
 
+	// Special case: partial payments are closed underpaid once the merchant's grace period passes
+	if invoice.Status() == StatusPartial {
+		_, err := s.closeLapsedPartialInvoice(ctx, invoice)
+		return err
+	}
+
 	// Business logic validation
 	if invoice.Expiration().IsExpired() && !invoice.Status().IsTerminal() {
-		// Special case: partial payments should not auto-expire
-		if invoice.Status() != StatusPartial {
-			// Use FSM to transition to expired status
-			fsm := NewInvoiceFSM(invoice)
-			if err := fsm.Event(ctx, "expire"); err != nil {
-				return err
-			}
+		// Use FSM to transition to expired status
+		fsm := NewInvoiceFSM(invoice)
+		if err := fsm.Event(ctx, "expire"); err != nil {
+			return err
+		}
 
-			if err := s.repository.Update(ctx, invoice); err != nil {
-				return err
-			}
+		if err := s.repository.Update(ctx, invoice); err != nil {
+			return err
+		}
 
-			// Publish invoice expired event
-			if s.eventBus != nil {
-				eventData := createInvoiceEventData(invoice)
-				eventData["expired_at"] = time.Now().UTC()
-				eventData["expires_at"] = invoice.Expiration().ExpiresAt()
-
-				eventData["timestamp"] = time.Now().UTC()
-				event := shared.CreateDomainEvent(
-					shared.EventTypeInvoiceExpired,
-					invoice.ID(),
-					"Invoice",
-					eventData,
-					nil,
-				)
-				if err := s.eventBus.PublishEvent(ctx, event); err != nil {
-					// Log error but don't fail the operation
-					if s.logger != nil {
-						s.logger.Error("Failed to publish domain event",
-							zap.String("event_type", shared.EventTypeInvoiceExpired),
-							zap.String("aggregate_id", invoice.ID()),
-							zap.Error(err),
-						)
-					}
+		// Publish invoice expired event
+		if s.eventBus != nil {
+			event := shared.NewInvoiceExpiredEvent(shared.InvoiceExpiredEvent{
+				InvoiceEvent: createInvoiceEventData(invoice),
+				ExpiredAt:    shared.Now().UTC(),
+			})
+			if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+				// Log error but don't fail the operation
+				if s.logger != nil {
+					s.logger.Error("Failed to publish domain event",
+						zap.String("event_type", shared.EventTypeInvoiceExpired),
+						zap.String("aggregate_id", invoice.ID()),
+						zap.Error(err),
+					)
 				}
 			}
-
-			return nil
 		}
+
+		return nil
 	}
 
 	return nil
@@ -671,7 +857,7 @@ func (s *InvoiceServiceImpl) ProcessExpiredInvoice(ctx context.Context, id strin
 // GetInvoiceStatus returns the current status of an invoice.
 func (s *InvoiceServiceImpl) GetInvoiceStatus(ctx context.Context, id string) (InvoiceStatus, error) {
 	if id == "" {
-		return "", errors.New("invoice ID cannot be empty")
+		return "", shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
 	}
 
 	invoice, err := s.repository.FindByID(ctx, id)
@@ -682,6 +868,63 @@ func (s *InvoiceServiceImpl) GetInvoiceStatus(ctx context.Context, id string) (I
 	return invoice.Status(), nil
 }
 
+// GetInvoiceStatuses returns the payment state of up to MaxStatusBatchSize invoices, keyed by invoice ID.
+func (s *InvoiceServiceImpl) GetInvoiceStatuses(ctx context.Context, ids []string) (map[string]*StatusSummary, error) {
+	unique := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) > MaxStatusBatchSize {
+		return nil, fmt.Errorf("%w: %d requested, at most %d allowed",
+			ErrStatusBatchTooLarge, len(unique), MaxStatusBatchSize)
+	}
+
+	summaries, err := s.repository.FindStatusSummaries(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]*StatusSummary, len(summaries))
+	for _, summary := range summaries {
+		statuses[summary.InvoiceID] = summary
+	}
+	return statuses, nil
+}
+
+// GetInvoiceStatusSummary returns the payment state of an invoice.
+func (s *InvoiceServiceImpl) GetInvoiceStatusSummary(ctx context.Context, id string) (*StatusSummary, error) {
+	if id == "" {
+		return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+	}
+
+	summaries, err := s.repository.FindStatusSummaries(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(summaries) == 0 {
+		return nil, fmt.Errorf("%w: invoice %s", ErrNotFound, id)
+	}
+	return summaries[0], nil
+}
+
+// GetPaymentAddresses returns the payment addresses of the invoices on a network, whatever their status.
+func (s *InvoiceServiceImpl) GetPaymentAddresses(
+	ctx context.Context,
+	network shared.BlockchainNetwork,
+) ([]string, error) {
+	if !network.IsValid() {
+		return nil, fmt.Errorf("%w: %q", shared.ErrInvalidNetwork, network)
+	}
+	return s.repository.FindPaymentAddresses(ctx, network)
+}
+
 // UpdateInvoiceStatus updates the status of an invoice using FSM.
 func (s *InvoiceServiceImpl) UpdateInvoiceStatus(
 	ctx context.Context,
@@ -690,11 +933,11 @@ func (s *InvoiceServiceImpl) UpdateInvoiceStatus(
 	reason string,
 ) error {
 	if id == "" {
-		return errors.New("invoice ID cannot be empty")
+		return shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
 	}
 
 	if !newStatus.IsValid() {
-		return errors.New("invalid invoice status")
+		return shared.NewFieldError("status", "invalid invoice status")
 	}
 
 	invoice, err := s.repository.FindByID(ctx, id)
@@ -714,14 +957,14 @@ func (s *InvoiceServiceImpl) UpdateInvoiceStatus(
 
 	// Publish invoice status changed event
 	if s.eventBus != nil {
-		eventData := createInvoiceEventData(invoice)
-		eventData["from_status"] = invoice.Status().String()
-		eventData["to_status"] = newStatus.String()
-		eventData["reason"] = reason
-		eventData["updated_at"] = time.Now().UTC()
-
-		eventData["timestamp"] = time.Now().UTC()
-		event := shared.CreateDomainEvent(shared.EventTypeInvoiceStatusChanged, invoice.ID(), "Invoice", eventData, nil)
+		updatedAt := shared.Now().UTC()
+		event := shared.NewInvoiceStatusChangedEvent(shared.InvoiceStatusChangedEvent{
+			InvoiceEvent: createInvoiceEventData(invoice),
+			FromStatus:   invoice.Status().String(),
+			ToStatus:     newStatus.String(),
+			Reason:       reason,
+			UpdatedAt:    &updatedAt,
+		})
 		if err := s.eventBus.PublishEvent(ctx, event); err != nil {
 			// Log error but don't fail the operation
 			if s.logger != nil {
@@ -737,6 +980,306 @@ func (s *InvoiceServiceImpl) UpdateInvoiceStatus(
 	return nil
 }
 
+// RefundInvoice refunds part or all of the amount paid for an invoice.
+// The invoice moves to partially refunded until the whole amount paid has been refunded.
+func (s *InvoiceServiceImpl) RefundInvoice(ctx context.Context, req *RefundInvoiceRequest) (*Refund, error) {
+	if req == nil || req.InvoiceID == "" {
+		return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+	}
+
+	invoice, err := s.repository.FindByID(ctx, req.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var amount *shared.Money
+	if req.Amount == "" {
+		amount, err = invoice.RefundableAmount()
+	} else {
+		amount, err = shared.NewMoneyWithCrypto(req.Amount, invoice.CryptoCurrency())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRefundAmount, err)
+	}
+
+	if err := CanRefund(invoice); err != nil {
+		return nil, ErrCannotRefundInvoice
+	}
+	destination := req.Destination
+	if destination == "" {
+		if destination, err = invoice.ConfirmedRefundAddress(); err != nil {
+			return nil, err
+		}
+	}
+
+	refundID, err := s.ids.NewID(ctx, shared.IDKindRefund, invoice.MerchantID())
+	if err != nil {
+		return nil, err
+	}
+	refund, err := NewRefund(refundID, amount, req.Reason, req.RequestedBy, shared.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	refund.SetDestination(destination)
+	if err := invoice.AddRefund(refund); err != nil {
+		return nil, err
+	}
+
+	remaining, err := invoice.RefundableAmount()
+	if err != nil {
+		return nil, err
+	}
+	target := StatusPartiallyRefunded
+	if remaining.IsZero() {
+		target = StatusRefunded
+	}
+	// An invoice closed underpaid stays closed as the payments it received are refunded
+	if invoice.Status() != target && invoice.Status() != StatusUnderpaidClosed {
+		if err := NewInvoiceFSM(invoice).TransitionTo(target); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repository.Update(ctx, invoice); err != nil {
+		return nil, err
+	}
+
+	s.publishInvoiceRefunded(ctx, invoice, refund)
+	return refund, nil
+}
+
+// publishInvoiceRefunded publishes the event announcing a refund of an invoice.
+func (s *InvoiceServiceImpl) publishInvoiceRefunded(ctx context.Context, invoice *Invoice, refund *Refund) {
+	if s.eventBus == nil {
+		return
+	}
+
+	eventData := shared.InvoiceRefundedEvent{
+		InvoiceEvent:   createInvoiceEventData(invoice),
+		RefundID:       refund.ID(),
+		RefundAmount:   refund.Amount().Amount().String(),
+		RefundedAmount: invoice.RefundedAmount().Amount().String(),
+		Reason:         refund.Reason(),
+		RefundAddress:  refund.Destination(),
+	}
+	if remaining, err := invoice.RefundableAmount(); err == nil {
+		eventData.RefundableAmount = remaining.Amount().String()
+	}
+	event := shared.NewInvoiceRefundedEvent(eventData)
+	if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+		// Log error but don't fail the operation
+		if s.logger != nil {
+			s.logger.Error("Failed to publish domain event",
+				zap.String("event_type", shared.EventTypeInvoiceRefunded),
+				zap.String("aggregate_id", invoice.ID()),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// ApplyCoupon applies a redeemed coupon to an unpaid invoice and reprices it.
+func (s *InvoiceServiceImpl) ApplyCoupon(ctx context.Context, invoiceID string, coupon *AppliedCoupon) (*Invoice, error) {
+	if invoiceID == "" {
+		return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+	}
+
+	invoice, err := s.repository.FindByID(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := invoice.ApplyCoupon(coupon); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(ctx, invoice); err != nil {
+		return nil, err
+	}
+
+	if s.eventBus != nil {
+		event := shared.NewInvoiceCouponAppliedEvent(shared.InvoiceCouponAppliedEvent{
+			InvoiceEvent:   createInvoiceEventData(invoice),
+			CouponCode:     coupon.Code(),
+			DiscountAmount: invoice.Pricing().Discount().String(),
+		})
+		if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+			// Log error but don't fail the operation
+			if s.logger != nil {
+				s.logger.Error("Failed to publish domain event",
+					zap.String("event_type", shared.EventTypeInvoiceCouponApplied),
+					zap.String("aggregate_id", invoice.ID()),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+
+	return invoice, nil
+}
+
+// ChooseDonationAmount reprices an unpaid donation invoice to the amount chosen by the customer.
+func (s *InvoiceServiceImpl) ChooseDonationAmount(
+	ctx context.Context,
+	invoiceID string,
+	amount *shared.Money,
+) (*Invoice, error) {
+	if invoiceID == "" {
+		return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+	}
+
+	invoice, err := s.repository.FindByID(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := invoice.ChooseAmount(amount); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(ctx, invoice); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// ChangeCurrency re-quotes an unpaid invoice in another of the merchant's accepted cryptocurrencies and
+// assigns it a payment address on that cryptocurrency's network.
+func (s *InvoiceServiceImpl) ChangeCurrency(
+	ctx context.Context,
+	invoiceID string,
+	symbol shared.CryptoCurrency,
+	network shared.BlockchainNetwork,
+) (*Invoice, error) {
+	if invoiceID == "" {
+		return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+	}
+
+	invoice, err := s.repository.FindByID(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if !invoice.CanChangeCurrency() {
+		return nil, ErrCannotChangeCurrency
+	}
+
+	token, err := s.ResolveToken(ctx, invoice.MerchantID(), symbol, network)
+	if err != nil {
+		return nil, err
+	}
+	current := invoice.PaymentAddress()
+	if token.Symbol == invoice.CryptoCurrency() && current != nil && token.Network == current.Network() {
+		return invoice, nil
+	}
+
+	rate, err := s.getExchangeRate(ctx, invoice.ExchangeRate().FromCurrency(), token.Symbol)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrExchangeRateServiceError, err)
+	}
+	address, err := s.generatePaymentAddress(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := invoice.ChangeCurrency(token.Symbol, address, rate); err != nil {
+		return nil, err
+	}
+	if err := assignPaymentReference(invoice, token); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(ctx, invoice); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// RequoteExpiredRates refreshes the expired exchange rate of every unpaid invoice, skipping invoices whose
+// rate moved by more than maxSlippage, and returns the number of invoices re-quoted.
+// Skipped invoices keep their expired rate, so payments are still judged against the amount last quoted,
+// and are retried on the next call in case the rate has come back within the bound.
+func (s *InvoiceServiceImpl) RequoteExpiredRates(ctx context.Context, maxSlippage decimal.Decimal) (int, error) {
+	invoices, err := s.repository.FindRequotable(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	requoted := 0
+	for _, invoice := range invoices {
+		if err := s.requoteInvoice(ctx, invoice, maxSlippage); err != nil {
+			if s.logger != nil {
+				s.logger.Warn("Failed to re-quote invoice",
+					zap.String("invoice_id", invoice.ID()),
+					zap.Error(err),
+				)
+			}
+			continue
+		}
+		requoted++
+	}
+
+	return requoted, nil
+}
+
+// requoteInvoice locks a fresh exchange rate on an invoice and notifies the checkout page of the new amount.
+func (s *InvoiceServiceImpl) requoteInvoice(ctx context.Context, invoice *Invoice, maxSlippage decimal.Decimal) error {
+	rate, err := s.getExchangeRate(ctx, invoice.ExchangeRate().FromCurrency(), invoice.CryptoCurrency())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrExchangeRateServiceError, err)
+	}
+
+	requote, err := invoice.Requote(rate, maxSlippage)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.Update(ctx, invoice); err != nil {
+		return err
+	}
+
+	if s.logger != nil {
+		s.logger.Info("Invoice re-quoted",
+			zap.String("invoice_id", invoice.ID()),
+			zap.String("previous_rate", requote.PreviousRate().String()),
+			zap.String("new_rate", requote.NewRate().String()),
+		)
+	}
+
+	s.publishInvoiceRequoted(ctx, invoice, requote)
+	return nil
+}
+
+// publishInvoiceRequoted publishes the event announcing the new amount of a re-quoted invoice.
+func (s *InvoiceServiceImpl) publishInvoiceRequoted(ctx context.Context, invoice *Invoice, requote *Requote) {
+	if s.eventBus == nil {
+		return
+	}
+
+	eventData := shared.InvoiceRequotedEvent{
+		InvoiceEvent:         createInvoiceEventData(invoice),
+		PreviousRate:         requote.PreviousRate().String(),
+		NewRate:              requote.NewRate().String(),
+		PreviousCryptoAmount: requote.PreviousAmount().String(),
+		NewCryptoAmount:      requote.NewAmount().String(),
+		Slippage:             requote.Slippage().String(),
+		RateExpiresAt:        invoice.ExchangeRate().ExpiresAt(),
+	}
+	eventData.Timestamp = requote.RequotedAt()
+	event := shared.NewInvoiceRequotedEvent(eventData)
+	if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+		// Log error but don't fail the operation
+		if s.logger != nil {
+			s.logger.Error("Failed to publish domain event",
+				zap.String("event_type", shared.EventTypeInvoiceRequoted),
+				zap.String("aggregate_id", invoice.ID()),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
 // Helper methods
 
 func (s *InvoiceServiceImpl) getExchangeRate(
@@ -744,13 +1287,7 @@ func (s *InvoiceServiceImpl) getExchangeRate(
 	from shared.Currency,
 	to shared.CryptoCurrency,
 ) (*shared.ExchangeRate, error) {
-	// This would typically call an exchange rate service
-	// For now, we'll return a mock rate
-	rate := "1.0" // Mock rate
-	source := "mock_provider"
-	duration := 30 * time.Minute
-
-	exchangeRate, err := shared.NewExchangeRate(rate, from, to, source, duration)
+	exchangeRate, err := s.rates.GetRate(ctx, from, to)
 	if err != nil && s.logger != nil {
 		s.logger.Error("Failed to get exchange rate",
 			zap.String("currency", string(from)),
@@ -761,90 +1298,93 @@ func (s *InvoiceServiceImpl) getExchangeRate(
 	return exchangeRate, err
 }
 
+// AcceptedTokens returns the tokens a merchant's invoices may be paid in, in the merchant's order of preference:
+// the tokens on testnets for merchants in test mode, and on mainnets otherwise.
+func (s *InvoiceServiceImpl) AcceptedTokens(ctx context.Context, merchantID string) ([]shared.Token, error) {
+	if s.currencies == nil {
+		return shared.TokensForMode(s.tokens.TokensFor(merchantID), false), nil
+	}
+
+	testMode, err := s.currencies.TestMode(ctx, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test mode: %w", err)
+	}
+	tokens := shared.TokensForMode(s.tokens.TokensFor(merchantID), testMode)
+
+	selectors, err := s.currencies.AcceptedCurrencies(ctx, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accepted currencies: %w", err)
+	}
+	if len(selectors) == 0 {
+		return tokens, nil
+	}
+	return shared.SelectTokens(tokens, selectors), nil
+}
+
+// ResolveToken returns the token a merchant's invoice in the cryptocurrency is paid in.
+func (s *InvoiceServiceImpl) ResolveToken(
+	ctx context.Context,
+	merchantID string,
+	symbol shared.CryptoCurrency,
+	network shared.BlockchainNetwork,
+) (shared.Token, error) {
+	if network != "" && !network.IsValid() {
+		return shared.Token{}, fmt.Errorf("%w: unknown network %q", ErrInvalidRequest, network)
+	}
+
+	tokens, err := s.AcceptedTokens(ctx, merchantID)
+	if err != nil {
+		return shared.Token{}, err
+	}
+	return shared.ResolveToken(tokens, symbol, network)
+}
+
 func (s *InvoiceServiceImpl) generatePaymentAddress(
 	ctx context.Context,
-	currency shared.CryptoCurrency,
+	token shared.Token,
 ) (*shared.PaymentAddress, error) {
-	// This would typically call a payment address service
+	// This would typically call a payment address service for the token's network
 	// For now, we'll return a mock address
-	var network shared.BlockchainNetwork
-	switch currency {
-	case shared.CryptoCurrencyUSDT:
-		network = shared.NetworkTron
-	case shared.CryptoCurrencyBTC:
-		network = shared.NetworkBitcoin
-	case shared.CryptoCurrencyETH:
-		network = shared.NetworkEthereum
-	default:
-		err := errors.New("unsupported cryptocurrency for address generation")
-		if s.logger != nil {
-			s.logger.Error("Failed to generate payment address",
-				zap.String("crypto_currency", string(currency)),
-				zap.Error(err),
-			)
-		}
-		return nil, err
-	}
-
-	// Mock address
 	address := "TQn9Y2khEsLMWn1aXKURNC62XLFPqpTUcN"
-	paymentAddress, err := shared.NewPaymentAddress(address, network)
+	paymentAddress, err := shared.NewPaymentAddress(address, token.Network)
 	if err != nil && s.logger != nil {
 		s.logger.Error("Failed to generate payment address",
-			zap.String("crypto_currency", string(currency)),
+			zap.String("crypto_currency", string(token.Symbol)),
+			zap.String("network", string(token.Network)),
 			zap.Error(err),
 		)
 	}
 	return paymentAddress, err
 }
 
-func (s *InvoiceServiceImpl) generateInvoiceID() string {
-	// This would typically use a proper ID generator
-	// For now, we'll use a simple timestamp-based ID
-	return "inv_" + time.Now().Format("20060102150405")
-}
-
-func (s *InvoiceServiceImpl) matchesSearch(invoice *Invoice, searchTerm string) bool {
-	// Simple search implementation
-	searchTerm = strings.ToLower(searchTerm)
-
-	if strings.Contains(strings.ToLower(invoice.Title()), searchTerm) {
-		return true
-	}
-
-	if strings.Contains(strings.ToLower(invoice.Description()), searchTerm) {
-		return true
-	}
-
-	// Search in metadata
-	for key, value := range invoice.Metadata() {
-		if strings.Contains(strings.ToLower(key), searchTerm) {
-			return true
-		}
-		if str, ok := value.(string); ok {
-			if strings.Contains(strings.ToLower(str), searchTerm) {
-				return true
-			}
-		}
+// assignPaymentReference gives an invoice a fresh payment reference for the token it is paid in.
+func assignPaymentReference(invoice *Invoice, token shared.Token) error {
+	reference, err := NewPaymentReference(token)
+	if err != nil {
+		return fmt.Errorf("failed to generate payment reference: %w", err)
 	}
-
-	return false
+	invoice.SetPaymentReference(reference)
+	return nil
 }
 
 // validatePaymentAmount validates if a payment amount is acceptable (business logic moved from domain).
 func (s *InvoiceServiceImpl) validatePaymentAmount(invoice *Invoice, paymentAmount *shared.Money) (string, error) {
 	if paymentAmount == nil {
-		return "", errors.New("payment amount cannot be nil")
+		return "", shared.NewFieldError("amount", "payment amount cannot be nil")
 	}
 
-	requiredAmount, err := invoice.GetCryptoAmount()
+	if invoice.IsDonation() {
+		return s.validateDonationPayment(invoice, paymentAmount)
+	}
+
+	requiredAmount, err := invoice.LockedCryptoAmount()
 	if err != nil {
 		return "", err
 	}
 
 	// Check currency match
 	if paymentAmount.Currency() != requiredAmount.Currency() {
-		return "", errors.New("payment currency does not match invoice currency")
+		return "", shared.NewFieldError("currency", "payment currency does not match invoice currency")
 	}
 
 	// Check if payment is sufficient
@@ -854,12 +1394,54 @@ func (s *InvoiceServiceImpl) validatePaymentAmount(invoice *Invoice, paymentAmou
 
 	// Check if underpayment is within tolerance
 	if invoice.PaymentTolerance().IsUnderpayment(requiredAmount, paymentAmount) {
-		return "", errors.New("payment amount is below the minimum threshold")
+		return "", ErrUnderpayment
+	}
+
+	return "partial", nil
+}
+
+// validateDonationPayment judges the cumulative amount received for a donation against its minimum.
+// Donations have no underpayment: anything short of the minimum is a partial payment awaiting more.
+func (s *InvoiceServiceImpl) validateDonationPayment(invoice *Invoice, paymentAmount *shared.Money) (string, error) {
+	minimumAmount, err := invoice.MinimumCryptoAmount()
+	if err != nil {
+		return "", err
+	}
+
+	if paymentAmount.Currency() != minimumAmount.Currency() {
+		return "", shared.NewFieldError("currency", "payment currency does not match invoice currency")
+	}
+
+	received := paymentAmount.Amount()
+	if paid := invoice.AmountPaid(); paid != nil {
+		received = received.Add(paid.Amount())
 	}
 
+	if received.GreaterThanOrEqual(minimumAmount.Amount()) {
+		return "sufficient", nil
+	}
 	return "partial", nil
 }
 
+// queueUnderpayment hands a payment below the underpayment tolerance to operators for review.
+func (s *InvoiceServiceImpl) queueUnderpayment(ctx context.Context, invoice *Invoice, paymentTx *payment.Payment) {
+	if s.reviewQueue == nil {
+		return
+	}
+
+	requiredAmount, err := invoice.LockedCryptoAmount()
+	if err == nil {
+		err = s.reviewQueue.EnqueueUnderpayment(ctx, invoice, paymentTx, requiredAmount)
+	}
+	if err != nil && s.logger != nil {
+		s.logger.Error("Failed to queue underpayment for review",
+			zap.String("invoice_id", invoice.ID()),
+			zap.String("payment_id", string(paymentTx.ID())),
+			zap.Error(err),
+		)
+	}
+}
+
 // processPaymentWithFSM processes payment using FSM to reduce cyclomatic complexity.
 func (s *InvoiceServiceImpl) processPaymentWithFSM(ctx context.Context, invoice *Invoice, validationType string) error {
 	fsm := NewInvoiceFSM(invoice)
@@ -5,6 +5,8 @@ import (
 	"crypto-checkout/internal/domain/payment"
 	"crypto-checkout/internal/domain/shared"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // InvoiceService defines the interface for invoice business operations.
@@ -12,12 +14,43 @@ type InvoiceService interface {
 	// CreateInvoice creates a new invoice with the given parameters.
 	CreateInvoice(ctx context.Context, req *CreateInvoiceRequest) (*Invoice, error)
 
+	// DuplicateInvoice creates a new invoice with the items and pricing terms of an existing invoice,
+	// quoted at the current rate with a fresh payment address and expiration.
+	DuplicateInvoice(ctx context.Context, id string) (*Invoice, error)
+
+	// UpdateDraft replaces the given terms of a draft invoice and reprices it.
+	UpdateDraft(ctx context.Context, req *UpdateDraftRequest) (*Invoice, error)
+
+	// FinalizeInvoice locks the exchange rate of a draft invoice, assigns its payment address and moves it to
+	// created, after which it can be paid.
+	FinalizeInvoice(ctx context.Context, id string) (*Invoice, error)
+
+	// AmendInvoice cancels an unpaid finalized invoice and replaces it with an invoice carrying the changed terms.
+	// The two are linked so that the original points to its replacement.
+	AmendInvoice(ctx context.Context, req *AmendInvoiceRequest) (*Invoice, error)
+
+	// ExtendInvoice pushes the expiry of an invoice back, within the merchant's extension policy, re-quoting
+	// an unpaid invoice whose exchange rate has expired.
+	ExtendInvoice(ctx context.Context, id string, by time.Duration) (*Invoice, error)
+
+	// TagInvoice records a tag on an invoice, under the reserved RuleTagsMetadataKey of its metadata.
+	TagInvoice(ctx context.Context, id, tag string) (*Invoice, error)
+
 	// GetInvoice retrieves an invoice by ID.
 	GetInvoice(ctx context.Context, id string) (*Invoice, error)
 
 	// GetInvoiceByPaymentAddress retrieves an invoice by payment address.
 	GetInvoiceByPaymentAddress(ctx context.Context, address *shared.PaymentAddress) (*Invoice, error)
 
+	// FindInvoiceForTransfer retrieves the invoice a transfer to a payment address pays, telling the invoices
+	// sharing the address apart by the payment reference the transfer memo or amount carries.
+	FindInvoiceForTransfer(
+		ctx context.Context,
+		address *shared.PaymentAddress,
+		memo string,
+		amount *shared.Money,
+	) (*Invoice, error)
+
 	// ListInvoices retrieves invoices with the given filters.
 	ListInvoices(ctx context.Context, req *ListInvoicesRequest) (*ListInvoicesResponse, error)
 
@@ -30,17 +63,103 @@ type InvoiceService interface {
 	// ProcessPayment processes a payment for an invoice.
 	ProcessPayment(ctx context.Context, invoiceID string, payment *payment.Payment) error
 
+	// ReversePayment takes back a confirmed payment whose transaction a deep reorganization removed, reopening
+	// the invoice as pending or partial. A payment already reversed returns its existing reversal; invoices
+	// that were not credited with payments fail with ErrCannotReversePayment.
+	ReversePayment(ctx context.Context, invoiceID string, payment *payment.Payment) (*PaymentReversal, error)
+
+	// ReopenInvoice moves a paid invoice back to confirming, keeping the amount paid, when its settlement could
+	// not be carried through. Invoices that are not paid fail with ErrCannotReopenInvoice.
+	ReopenInvoice(ctx context.Context, id, reason string) (*Invoice, error)
+
 	// GetExpiredInvoices retrieves invoices that have expired.
 	GetExpiredInvoices(ctx context.Context) ([]*Invoice, error)
 
-	// ProcessExpiredInvoices processes expired invoices.
+	// ProcessExpiredInvoices expires the invoices past their expiry and closes underpaid the partially paid
+	// ones whose grace period has passed.
 	ProcessExpiredInvoices(ctx context.Context) error
 
 	// GetInvoiceStatus returns the current status of an invoice.
 	GetInvoiceStatus(ctx context.Context, id string) (InvoiceStatus, error)
 
+	// GetInvoiceStatuses returns the payment state of up to MaxStatusBatchSize invoices, keyed by invoice ID.
+	// Invoices that do not exist are left out.
+	GetInvoiceStatuses(ctx context.Context, ids []string) (map[string]*StatusSummary, error)
+
+	// GetInvoiceStatusSummary returns the payment state of an invoice.
+	GetInvoiceStatusSummary(ctx context.Context, id string) (*StatusSummary, error)
+
+	// GetPaymentAddresses returns the payment addresses of the invoices on a network, whatever their status.
+	GetPaymentAddresses(ctx context.Context, network shared.BlockchainNetwork) ([]string, error)
+
 	// UpdateInvoiceStatus updates the status of an invoice.
 	UpdateInvoiceStatus(ctx context.Context, id string, newStatus InvoiceStatus, reason string) error
+
+	// RefundInvoice refunds part or all of the amount paid for an invoice to its confirmed refund destination.
+	RefundInvoice(ctx context.Context, req *RefundInvoiceRequest) (*Refund, error)
+
+	// CaptureRefundSender proposes the dominant sender of an invoice's confirmed payments as its refund address.
+	CaptureRefundSender(ctx context.Context, invoiceID, address string) error
+
+	// SupplyRefundAddress records the refund address a customer supplied, verifying its signature if one is given.
+	SupplyRefundAddress(ctx context.Context, req *SupplyRefundAddressRequest) (*Invoice, error)
+
+	// ConfirmRefundDestination confirms the refund address proposed for an invoice so refunds can be sent to it.
+	ConfirmRefundDestination(ctx context.Context, invoiceID, address, confirmedBy string) (*Invoice, error)
+
+	// ApplyCoupon applies a redeemed coupon to an unpaid invoice and reprices it.
+	ApplyCoupon(ctx context.Context, invoiceID string, coupon *AppliedCoupon) (*Invoice, error)
+
+	// ChooseDonationAmount reprices an unpaid donation invoice to the amount chosen by the customer.
+	ChooseDonationAmount(ctx context.Context, invoiceID string, amount *shared.Money) (*Invoice, error)
+
+	// RequoteExpiredRates refreshes the expired exchange rate of every unpaid invoice, skipping invoices whose
+	// rate moved by more than maxSlippage, and returns the number of invoices re-quoted.
+	RequoteExpiredRates(ctx context.Context, maxSlippage decimal.Decimal) (int, error)
+
+	// ChangeCurrency re-quotes an unpaid invoice in another of the merchant's accepted cryptocurrencies and
+	// assigns it a payment address on that cryptocurrency's network.
+	ChangeCurrency(
+		ctx context.Context, invoiceID string, symbol shared.CryptoCurrency, network shared.BlockchainNetwork,
+	) (*Invoice, error)
+
+	// AcceptedTokens returns the tokens a merchant's invoices may be paid in, in the merchant's order of preference.
+	AcceptedTokens(ctx context.Context, merchantID string) ([]shared.Token, error)
+
+	// ResolveToken returns the token a merchant's invoice in the cryptocurrency is paid in. An empty network
+	// selects the cryptocurrency's default network.
+	ResolveToken(
+		ctx context.Context, merchantID string, symbol shared.CryptoCurrency, network shared.BlockchainNetwork,
+	) (shared.Token, error)
+}
+
+// ReviewQueue receives payments that need operator action before the invoice can proceed.
+type ReviewQueue interface {
+	// EnqueueUnderpayment queues a payment that falls short of the invoice beyond its underpayment tolerance.
+	EnqueueUnderpayment(ctx context.Context, invoice *Invoice, payment *payment.Payment, required *shared.Money) error
+	// EnqueueStaleRate queues a payment that arrived after the invoice's exchange rate expired.
+	EnqueueStaleRate(
+		ctx context.Context,
+		invoice *Invoice,
+		payment *payment.Payment,
+		required *shared.Money,
+		reason string,
+	) error
+}
+
+// RateProvider quotes exchange rates from fiat currencies to cryptocurrencies.
+type RateProvider interface {
+	// GetRate returns a rate locked for the provider's quote validity.
+	GetRate(ctx context.Context, from shared.Currency, to shared.CryptoCurrency) (*shared.ExchangeRate, error)
+}
+
+// MerchantCurrencies provides the cryptocurrencies each merchant has chosen to accept.
+type MerchantCurrencies interface {
+	// AcceptedCurrencies returns the merchant's accepted cryptocurrencies in order of preference,
+	// or nil if the merchant accepts every token.
+	AcceptedCurrencies(ctx context.Context, merchantID string) ([]shared.TokenSelector, error)
+	// TestMode returns whether the merchant's invoices are paid on testnets.
+	TestMode(ctx context.Context, merchantID string) (bool, error)
 }
 
 // CreateInvoiceRequest represents the request to create a new invoice.
@@ -49,16 +168,64 @@ type CreateInvoiceRequest struct {
 	CustomerID         *string
 	Title              string
 	Description        string
+	Type               InvoiceType   // Defaults to InvoiceTypeStandard
+	MinimumAmount      *shared.Money // Smallest amount that settles a donation; donations take no items, discounts or taxes
 	Items              []*CreateInvoiceItemRequest
-	Tax                *shared.Money
+	Discount           *Discount      // Invoice-level discount, applied to the subtotal
+	Coupon             *AppliedCoupon // Coupon already redeemed by the caller, applied after Discount
+	Tax                *shared.Money  // Fixed tax amount; takes precedence over TaxPolicy and TaxRate
+	TaxPolicy          *TaxPolicy     // Per-item taxes of the customer's jurisdiction; takes precedence over TaxRate
+	TaxRate            string         // Tax rate as decimal, applied to the discounted subtotal
 	Currency           shared.Currency
 	CryptoCurrency     shared.CryptoCurrency
+	Network            shared.BlockchainNetwork // Network of the cryptocurrency; empty selects its default network
 	PaymentTolerance   *PaymentTolerance
 	ExpirationDuration time.Duration
 	Metadata           map[string]interface{}
+	CustomFields       []*CustomField   // Shown to customers on the checkout page and receipts
+	SettlementSplit    *SettlementSplit // Merchants sharing the settlement; nil leaves it all to the merchant
+	PassFee            bool             // Adds the platform fee on top of the total for the customer to pay
 	WebhookURL         *string
 	ReturnURL          *string
 	CancelURL          *string
+	// Draft creates an editable invoice with no payment address or exchange rate; see FinalizeInvoice.
+	Draft bool
+	// Supersedes is the ID of the invoice this one replaces; see AmendInvoice.
+	Supersedes string
+	// LiveKey marks invoices created with a live API key, which cannot be paid on a testnet.
+	LiveKey bool
+
+	// RecordedMetadata holds the reserved keys the service creating the invoice records in Metadata, such as the
+	// template or payment link it was created from; the merchant's metadata rules do not apply to them.
+	RecordedMetadata map[string]interface{}
+}
+
+// InvoiceChanges holds the terms of an invoice to replace. Nil fields are left unchanged.
+type InvoiceChanges struct {
+	Title              *string
+	Description        *string
+	CustomerID         *string
+	Items              []*CreateInvoiceItemRequest
+	TaxRate            *string    // Replaces the tax of the invoice with a rate applied to the discounted subtotal
+	TaxPolicy          *TaxPolicy // Replaces the tax of the invoice with per-item taxes; takes precedence over TaxRate
+	ExpirationDuration *time.Duration
+	Metadata           map[string]interface{}
+	CustomFields       []*CustomField // Replaces the custom fields; an empty slice removes them
+	SplitShares        []*SplitShare  // Replaces the settlement split; an empty slice removes it
+	PassFee            *bool          // Whether the customer pays the platform fee on top of the total
+}
+
+// UpdateDraftRequest represents a request to edit a draft invoice.
+type UpdateDraftRequest struct {
+	InvoiceID string
+	InvoiceChanges
+}
+
+// AmendInvoiceRequest represents a request to replace a finalized invoice with an amended one.
+type AmendInvoiceRequest struct {
+	InvoiceID string
+	Reason    string // Recorded as the cancellation reason of the original invoice
+	InvoiceChanges
 }
 
 // CreateInvoiceItemRequest represents a request to create an invoice item.
@@ -67,18 +234,46 @@ type CreateInvoiceItemRequest struct {
 	Description string
 	Quantity    string
 	UnitPrice   *shared.Money
+	Discount    *Discount
+	TaxCategory string // Selects the TaxPolicy rates; defaults to DefaultTaxCategory
+}
+
+// RefundInvoiceRequest represents a request to refund a paid invoice.
+type RefundInvoiceRequest struct {
+	InvoiceID string
+	// Amount is the amount to refund in the invoice cryptocurrency; empty refunds the remaining amount.
+	Amount      string
+	Reason      string
+	RequestedBy string
+	// Destination is the address to refund; empty refunds to the invoice's confirmed refund destination.
+	Destination string
+}
+
+// SupplyRefundAddressRequest represents a refund address supplied by a customer on the checkout page.
+type SupplyRefundAddressRequest struct {
+	InvoiceID string
+	Address   string
+	// Signature is the customer's signature of RefundAddressMessage with the address; empty leaves it unverified.
+	Signature string
 }
 
-// ListInvoicesRequest represents the request to list invoices.
+// ListInvoicesRequest represents the request to list invoices, newest first.
+// Without a status only drafts and active invoices are listed.
 type ListInvoicesRequest struct {
-	MerchantID    string
-	Status        *InvoiceStatus
-	CustomerID    *string
-	Limit         int
+	MerchantID string
+	Status     *InvoiceStatus
+	CustomerID *string
+	Limit      int
+	// Cursor continues a listing after the page that returned it; Offset is ignored when it is set.
+	Cursor *shared.Cursor
+	// Deprecated: Offset pages shift when invoices are created mid-listing; use Cursor.
 	Offset        int
 	CreatedAfter  *time.Time
 	CreatedBefore *time.Time
 	Search        *string
+	// Metadata lists only invoices whose metadata has each value at its key; the merchant must have declared
+	// the keys filterable.
+	Metadata map[string]string
 }
 
 // ListInvoicesResponse represents the response to list invoices.
@@ -87,4 +282,6 @@ type ListInvoicesResponse struct {
 	Total    int
 	Limit    int
 	Offset   int
+	// NextCursor continues the listing after this page, or is nil on the last page.
+	NextCursor *shared.Cursor
 }
@@ -0,0 +1,237 @@
+package invoice
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	// DefaultMaxMetadataBytes is the largest metadata, in bytes of JSON, an invoice may carry unless the merchant
+	// sets its own limit.
+	DefaultMaxMetadataBytes = 8 * 1024
+	// MaxMetadataBytesLimit is the largest metadata limit a merchant may set.
+	MaxMetadataBytesLimit = 64 * 1024
+	// MaxMetadataKeys is the number of top-level keys metadata may have.
+	MaxMetadataKeys = 50
+	// MaxMetadataKeyLength is the length of the longest metadata key.
+	MaxMetadataKeyLength = 40
+	// MaxFilterableMetadataKeys is the number of metadata keys a merchant may declare filterable.
+	MaxFilterableMetadataKeys = 10
+	// MaxFilterableMetadataValueLength is the length of the longest metadata value invoices can be filtered by.
+	MaxFilterableMetadataValueLength = 255
+)
+
+// reservedMetadataKeys are the keys merchants cannot set: the invoice's own fields, which integrations could
+// confuse with the metadata, and the keys the service records itself.
+var reservedMetadataKeys = map[string]bool{
+	"id":                   true,
+	"merchant_id":          true,
+	"customer_id":          true,
+	"status":               true,
+	"created_at":           true,
+	"updated_at":           true,
+	"paid_at":              true,
+	"expires_at":           true,
+	DuplicateOfMetadataKey: true,
+	"invoice_template_id":  true, // Recorded by invoice templates
+	"payment_link_id":      true, // Recorded by payment links
+	"payment_link_slug":    true,
+	RuleTagsMetadataKey:    true, // Recorded by automation rules
+}
+
+// IsReservedMetadataKey reports whether merchants are kept from setting a metadata key.
+func IsReservedMetadataKey(key string) bool {
+	return reservedMetadataKeys[key]
+}
+
+// MetadataPolicy holds a merchant's rules for the metadata of its invoices.
+type MetadataPolicy struct {
+	// Schema validates metadata; nil accepts any metadata within the limits.
+	Schema *MetadataSchema
+	// MaxBytes is the largest metadata in bytes of JSON; zero for DefaultMaxMetadataBytes.
+	MaxBytes int
+	// FilterableKeys are the metadata keys invoices can be filtered by in list queries.
+	FilterableKeys []string
+}
+
+// DefaultMetadataPolicy accepts any metadata within the default limits and allows no filtering.
+func DefaultMetadataPolicy() MetadataPolicy {
+	return MetadataPolicy{MaxBytes: DefaultMaxMetadataBytes}
+}
+
+// MerchantMetadataPolicies provides each merchant's rules for invoice metadata.
+type MerchantMetadataPolicies interface {
+	// MetadataPolicy returns the merchant's metadata rules, or DefaultMetadataPolicy if it has not set any.
+	MetadataPolicy(ctx context.Context, merchantID string) (MetadataPolicy, error)
+}
+
+// Validate checks metadata against the limits, the reserved keys and the schema.
+func (p MetadataPolicy) Validate(metadata map[string]interface{}) error {
+	if len(metadata) == 0 {
+		return nil
+	}
+	if len(metadata) > MaxMetadataKeys {
+		return fmt.Errorf("%w: metadata has %d keys, more than %d", ErrInvalidRequest, len(metadata), MaxMetadataKeys)
+	}
+
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := ValidateMetadataKey(key); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
+		if reservedMetadataKeys[key] {
+			return fmt.Errorf("%w: metadata key %q is reserved", ErrInvalidRequest, key)
+		}
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("%w: metadata is not JSON: %w", ErrInvalidRequest, err)
+	}
+	maxBytes := p.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxMetadataBytes
+	}
+	if len(encoded) > maxBytes {
+		return fmt.Errorf("%w: metadata is %d bytes, more than %d", ErrInvalidRequest, len(encoded), maxBytes)
+	}
+
+	if p.Schema == nil {
+		return nil
+	}
+	return p.Schema.validate(encoded)
+}
+
+// IsFilterable reports whether invoices can be filtered by the metadata key.
+func (p MetadataPolicy) IsFilterable(key string) bool {
+	return slices.Contains(p.FilterableKeys, key)
+}
+
+// ValidateMetadataKey checks that a metadata key is not empty and not too long.
+func ValidateMetadataKey(key string) error {
+	if strings.TrimSpace(key) == "" {
+		return shared.NewFieldError("metadata", "metadata keys cannot be empty")
+	}
+	if len(key) > MaxMetadataKeyLength {
+		return fmt.Errorf("metadata key %q is longer than %d characters", key, MaxMetadataKeyLength)
+	}
+	return nil
+}
+
+// MetadataSchema is a JSON Schema invoice metadata must match. The keywords of the OpenAPI 3.0 schema object
+// are supported: type, properties, required, additionalProperties, enum, pattern, format, minimum, maximum,
+// minLength, maxLength, items, allOf, anyOf, oneOf and not.
+type MetadataSchema struct {
+	schema *openapi3.Schema
+}
+
+// CompileMetadataSchema parses a metadata schema, which must describe an object.
+func CompileMetadataSchema(raw json.RawMessage) (*MetadataSchema, error) {
+	var schema openapi3.Schema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, fmt.Errorf("metadata schema is not a JSON Schema: %w", err)
+	}
+	if err := schema.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid metadata schema: %w", err)
+	}
+	if schema.Type != nil && !schema.Type.Is(openapi3.TypeObject) {
+		return nil, shared.NewFieldError("schema", "metadata schema must describe an object")
+	}
+	return &MetadataSchema{schema: &schema}, nil
+}
+
+// validate checks JSON-encoded metadata against the schema.
+func (s *MetadataSchema) validate(encoded []byte) error {
+	// Decode again so numbers and nested values have the types of parsed JSON the schema validates
+	var value interface{}
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return fmt.Errorf("%w: metadata is not JSON: %w", ErrInvalidRequest, err)
+	}
+	if err := s.schema.VisitJSON(value); err != nil {
+		return fmt.Errorf("%w: metadata does not match the merchant's schema: %w", ErrInvalidRequest, err)
+	}
+	return nil
+}
+
+// recordedMetadata returns the reserved keys of an invoice's metadata, which only the service records.
+func recordedMetadata(metadata map[string]interface{}) map[string]interface{} {
+	var recorded map[string]interface{}
+	for key, value := range metadata {
+		if reservedMetadataKeys[key] {
+			if recorded == nil {
+				recorded = make(map[string]interface{})
+			}
+			recorded[key] = value
+		}
+	}
+	return recorded
+}
+
+// metadataPolicy returns the merchant's metadata rules.
+func (s *InvoiceServiceImpl) metadataPolicy(ctx context.Context, merchantID string) (MetadataPolicy, error) {
+	if s.metadata == nil {
+		return DefaultMetadataPolicy(), nil
+	}
+	return s.metadata.MetadataPolicy(ctx, merchantID)
+}
+
+// validateMetadata checks the metadata of an invoice against the merchant's rules. Reserved keys the service
+// recorded on the invoice being recreated, unchanged, are not the merchant's and pass.
+func (s *InvoiceServiceImpl) validateMetadata(
+	ctx context.Context, merchantID string, metadata, recorded map[string]interface{},
+) error {
+	if len(metadata) == 0 {
+		return nil
+	}
+	policy, err := s.metadataPolicy(ctx, merchantID)
+	if err != nil {
+		return err
+	}
+
+	merchantMetadata := metadata
+	if len(recorded) > 0 {
+		merchantMetadata = make(map[string]interface{}, len(metadata))
+		for key, value := range metadata {
+			if recordedValue, ok := recorded[key]; !ok || !reflect.DeepEqual(value, recordedValue) {
+				merchantMetadata[key] = value
+			}
+		}
+	}
+	return policy.Validate(merchantMetadata)
+}
+
+// validateMetadataFilters checks that the merchant declared each metadata key invoices are filtered by
+// filterable.
+func (s *InvoiceServiceImpl) validateMetadataFilters(
+	ctx context.Context, merchantID string, filters map[string]string,
+) error {
+	if len(filters) == 0 {
+		return nil
+	}
+	policy, err := s.metadataPolicy(ctx, merchantID)
+	if err != nil {
+		return err
+	}
+	for key, value := range filters {
+		if !policy.IsFilterable(key) {
+			return fmt.Errorf("%w: metadata key %q is not filterable", ErrInvalidRequest, key)
+		}
+		if len(value) > MaxFilterableMetadataValueLength {
+			return fmt.Errorf("%w: metadata filter %q is longer than %d characters",
+				ErrInvalidRequest, key, MaxFilterableMetadataValueLength)
+		}
+	}
+	return nil
+}
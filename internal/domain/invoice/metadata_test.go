@@ -0,0 +1,118 @@
+package invoice_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type stubMetadataPolicies map[string]invoice.MetadataPolicy
+
+func (s stubMetadataPolicies) MetadataPolicy(_ context.Context, merchantID string) (invoice.MetadataPolicy, error) {
+	if policy, ok := s[merchantID]; ok {
+		return policy, nil
+	}
+	return invoice.DefaultMetadataPolicy(), nil
+}
+
+func TestMetadataPolicy_Validate(t *testing.T) {
+	policy := invoice.DefaultMetadataPolicy()
+
+	t.Run("metadata within the limits is accepted", func(t *testing.T) {
+		require.NoError(t, policy.Validate(nil))
+		require.NoError(t, policy.Validate(map[string]interface{}{"order_id": "A-1", "tags": []interface{}{"vip"}}))
+	})
+
+	t.Run("reserved keys are rejected", func(t *testing.T) {
+		for _, key := range []string{"status", "merchant_id", invoice.DuplicateOfMetadataKey, "payment_link_id"} {
+			err := policy.Validate(map[string]interface{}{key: "x"})
+			require.ErrorIs(t, err, invoice.ErrInvalidRequest, key)
+		}
+	})
+
+	t.Run("keys must be short and not blank", func(t *testing.T) {
+		require.ErrorIs(t, policy.Validate(map[string]interface{}{" ": "x"}), invoice.ErrInvalidRequest)
+		long := strings.Repeat("k", invoice.MaxMetadataKeyLength+1)
+		require.ErrorIs(t, policy.Validate(map[string]interface{}{long: "x"}), invoice.ErrInvalidRequest)
+	})
+
+	t.Run("too many keys are rejected", func(t *testing.T) {
+		metadata := make(map[string]interface{}, invoice.MaxMetadataKeys+1)
+		for i := 0; i <= invoice.MaxMetadataKeys; i++ {
+			metadata[fmt.Sprintf("key_%d", i)] = i
+		}
+		require.ErrorIs(t, policy.Validate(metadata), invoice.ErrInvalidRequest)
+	})
+
+	t.Run("metadata larger than the limit is rejected", func(t *testing.T) {
+		small := invoice.MetadataPolicy{MaxBytes: 32}
+		require.NoError(t, small.Validate(map[string]interface{}{"note": "short"}))
+		err := small.Validate(map[string]interface{}{"note": strings.Repeat("x", 32)})
+		require.ErrorIs(t, err, invoice.ErrInvalidRequest)
+	})
+}
+
+func TestMetadataSchema(t *testing.T) {
+	schema, err := invoice.CompileMetadataSchema(json.RawMessage(`{
+		"type": "object",
+		"required": ["order_id"],
+		"properties": {
+			"order_id": {"type": "string", "pattern": "^[A-Z]-[0-9]+$"},
+			"priority": {"type": "integer", "minimum": 1, "maximum": 5}
+		},
+		"additionalProperties": false
+	}`))
+	require.NoError(t, err)
+	policy := invoice.MetadataPolicy{Schema: schema}
+
+	require.NoError(t, policy.Validate(map[string]interface{}{"order_id": "A-1", "priority": 3}))
+	for name, metadata := range map[string]map[string]interface{}{
+		"missing required key": {"priority": 3},
+		"pattern mismatch":     {"order_id": "a1"},
+		"out of range":         {"order_id": "A-1", "priority": 9},
+		"unknown key":          {"order_id": "A-1", "color": "red"},
+	} {
+		require.ErrorIs(t, policy.Validate(metadata), invoice.ErrInvalidRequest, name)
+	}
+
+	t.Run("schemas must describe objects", func(t *testing.T) {
+		_, err := invoice.CompileMetadataSchema(json.RawMessage(`{"type": "string"}`))
+		require.Error(t, err)
+		_, err = invoice.CompileMetadataSchema(json.RawMessage(`{"type": 5}`))
+		require.Error(t, err)
+		_, err = invoice.CompileMetadataSchema(json.RawMessage(`not json`))
+		require.Error(t, err)
+	})
+}
+
+func TestInvoiceService_MetadataFilters(t *testing.T) {
+	ctx := context.Background()
+	service := invoice.NewInvoiceService(nil, nil, nil, nil, invoice.DefaultRequotePolicy(), nil, nil, nil, nil,
+		stubMetadataPolicies{"merchant-1": {FilterableKeys: []string{"order_id"}}}, nil, nil, nil, nil, zap.NewNop())
+
+	t.Run("undeclared keys cannot be filtered by", func(t *testing.T) {
+		_, err := service.ListInvoices(ctx, &invoice.ListInvoicesRequest{
+			MerchantID: "merchant-1", Metadata: map[string]string{"channel": "web"},
+		})
+		require.ErrorIs(t, err, invoice.ErrInvalidRequest)
+
+		_, err = service.ListInvoices(ctx, &invoice.ListInvoicesRequest{
+			MerchantID: "merchant-2", Metadata: map[string]string{"order_id": "A-1"},
+		})
+		require.ErrorIs(t, err, invoice.ErrInvalidRequest)
+	})
+
+	t.Run("filter values are bounded", func(t *testing.T) {
+		_, err := service.ListInvoices(ctx, &invoice.ListInvoicesRequest{
+			MerchantID: "merchant-1",
+			Metadata:   map[string]string{"order_id": strings.Repeat("x", invoice.MaxFilterableMetadataValueLength+1)},
+		})
+		require.ErrorIs(t, err, invoice.ErrInvalidRequest)
+	})
+}
@@ -0,0 +1,63 @@
+package invoice
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Resets of the accounting numbers invoices receive when they are issued, selected with invoice_numbers.reset.
+const (
+	// NumberResetNever numbers each merchant's invoices in one sequence: 000001, 000002, ...
+	NumberResetNever = "never"
+	// NumberResetYearly restarts each merchant's sequence every calendar year (UTC): 2026-000001, 2026-000002, ...
+	NumberResetYearly = "yearly"
+)
+
+// ErrInvalidNumberReset is returned for a number reset that does not exist.
+var ErrInvalidNumberReset = errors.New("invalid invoice number reset")
+
+// ValidateNumberReset reports whether a number reset is supported.
+func ValidateNumberReset(reset string) error {
+	switch reset {
+	case NumberResetNever, NumberResetYearly:
+		return nil
+	default:
+		return fmt.Errorf("%w %q: use %s or %s", ErrInvalidNumberReset, reset, NumberResetNever, NumberResetYearly)
+	}
+}
+
+// NumberPeriod returns the period an invoice issued at a time is numbered in: its year for yearly resets,
+// and 0 for a sequence that never resets.
+func NumberPeriod(reset string, issuedAt time.Time) int {
+	if reset == NumberResetYearly {
+		return issuedAt.UTC().Year()
+	}
+	return 0
+}
+
+// FormatNumber formats the accounting number of the sequence-th invoice of a period.
+func FormatNumber(period int, sequence int64) string {
+	if period == 0 {
+		return fmt.Sprintf("%06d", sequence)
+	}
+	return fmt.Sprintf("%d-%06d", period, sequence)
+}
+
+// Number returns the accounting number of the invoice, or "" for a draft or an invoice issued before
+// invoices were numbered. Numbers are gapless per merchant, unlike IDs.
+func (i *Invoice) Number() string {
+	return i.number
+}
+
+// SetNumber records the accounting number of the invoice (for the repository, which allocates numbers
+// when it saves an issued invoice).
+func (i *Invoice) SetNumber(number string) {
+	i.number = number
+}
+
+// NeedsNumber reports whether saving the invoice should allocate its accounting number: it has been issued
+// and has none yet.
+func (i *Invoice) NeedsNumber() bool {
+	return !i.IsDraft() && i.number == ""
+}
@@ -0,0 +1,33 @@
+package invoice_test
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoiceNumbers(t *testing.T) {
+	issuedAt := time.Date(2026, time.December, 31, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*60*60))
+
+	t.Run("never resets", func(t *testing.T) {
+		period := invoice.NumberPeriod(invoice.NumberResetNever, issuedAt)
+		assert.Equal(t, 0, period)
+		assert.Equal(t, "000123", invoice.FormatNumber(period, 123))
+		assert.Equal(t, "1234567", invoice.FormatNumber(period, 1234567))
+	})
+
+	t.Run("yearly resets use the UTC year", func(t *testing.T) {
+		period := invoice.NumberPeriod(invoice.NumberResetYearly, issuedAt)
+		assert.Equal(t, 2027, period)
+		assert.Equal(t, "2027-000001", invoice.FormatNumber(period, 1))
+	})
+
+	t.Run("resets are validated", func(t *testing.T) {
+		require.NoError(t, invoice.ValidateNumberReset(invoice.NumberResetNever))
+		require.NoError(t, invoice.ValidateNumberReset(invoice.NumberResetYearly))
+		require.ErrorIs(t, invoice.ValidateNumberReset("monthly"), invoice.ErrInvalidNumberReset)
+	})
+}
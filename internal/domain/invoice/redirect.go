@@ -0,0 +1,35 @@
+package invoice
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// ReturnURL returns where the checkout page sends customers back to the merchant, or nil if it has no return link.
+func (i *Invoice) ReturnURL() *string {
+	return i.returnURL
+}
+
+// CancelURL returns where the checkout page sends customers who give up on paying, or nil if it has no cancel
+// link.
+func (i *Invoice) CancelURL() *string {
+	return i.cancelURL
+}
+
+// SetRedirectURLs records where customers are sent back to the merchant (for creation and repository restoration).
+func (i *Invoice) SetRedirectURLs(returnURL, cancelURL *string) {
+	i.returnURL = returnURL
+	i.cancelURL = cancelURL
+}
+
+// validateRedirectURL checks that a return or cancel URL is an absolute http or https URL.
+func validateRedirectURL(name string, raw *string) error {
+	if raw == nil {
+		return nil
+	}
+	parsed, err := url.Parse(*raw)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("%w: %s must be an absolute http or https URL", ErrInvalidRequest, name)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package invoice
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"crypto/rand"
+	"math/big"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// PaymentReferenceLength is the number of characters of a payment reference.
+	PaymentReferenceLength = 8
+	// FingerprintDigits is the number of final digits of a fingerprinted amount that encode its fingerprint.
+	FingerprintDigits = 3
+	// maxFingerprintPlaces is the most decimal places a fingerprinted amount has; exchanges round withdrawals
+	// of finer amounts, which would erase the fingerprint.
+	maxFingerprintPlaces = 8
+	// maxFingerprint is the largest fingerprint that fits in FingerprintDigits digits.
+	maxFingerprint = 999
+)
+
+// referenceAlphabet is Crockford's base32, which leaves out the letters most easily mistaken for digits.
+const referenceAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// PaymentReference identifies the invoice a transfer pays when its address alone does not, such as when
+// an exchange pays from a shared hot wallet to an address several invoices share.
+//
+// On networks whose transfers carry a memo, customers attach the reference code as the memo. Elsewhere the
+// amount due is fingerprinted instead: it is rounded up and its final FingerprintDigits digits are replaced
+// by a number unique enough to tell the invoices sharing an address apart. A fingerprinted amount is never
+// below the amount due, so payments of it are judged as usual.
+type PaymentReference struct {
+	code        string
+	fingerprint int   // 1 to 999; 0 when the amount is not fingerprinted
+	places      int32 // Decimal places of the fingerprinted amount
+}
+
+// NewPaymentReference creates a random payment reference for an invoice paid in the token. The amount is
+// fingerprinted when the token's network has no memos and its precision leaves room for the fingerprint.
+func NewPaymentReference(token shared.Token) (*PaymentReference, error) {
+	code, err := randomReferenceCode()
+	if err != nil {
+		return nil, err
+	}
+	reference := &PaymentReference{code: code}
+
+	places := min(token.Decimals, maxFingerprintPlaces)
+	if token.Network.SupportsMemo() || places <= FingerprintDigits {
+		return reference, nil
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(maxFingerprint))
+	if err != nil {
+		return nil, err
+	}
+	reference.fingerprint = int(n.Int64()) + 1
+	reference.places = places
+	return reference, nil
+}
+
+// RestorePaymentReference restores a payment reference from storage.
+func RestorePaymentReference(code string, fingerprint int, places int32) *PaymentReference {
+	return &PaymentReference{code: code, fingerprint: fingerprint, places: places}
+}
+
+// Code returns the reference code customers attach as the memo of their transfer.
+func (r *PaymentReference) Code() string {
+	return r.code
+}
+
+// Fingerprint returns the number encoded in the final digits of the amount due, or 0 when it is not
+// fingerprinted.
+func (r *PaymentReference) Fingerprint() int {
+	return r.fingerprint
+}
+
+// Places returns the decimal places of the fingerprinted amount, or 0 when it is not fingerprinted.
+func (r *PaymentReference) Places() int32 {
+	return r.places
+}
+
+// IsFingerprinted returns true if the amount due is fingerprinted.
+func (r *PaymentReference) IsFingerprinted() bool {
+	return r.fingerprint > 0
+}
+
+// Apply returns the amount customers are asked to pay for an amount due: the amount itself, or its
+// fingerprinted form.
+func (r *PaymentReference) Apply(amount decimal.Decimal) decimal.Decimal {
+	if !r.IsFingerprinted() {
+		return amount
+	}
+	rounded := amount.RoundCeil(r.places - FingerprintDigits)
+	return rounded.Add(decimal.New(int64(r.fingerprint), -r.places))
+}
+
+// MatchesMemo returns true if a transfer memo is the reference code. Case and surrounding whitespace are
+// ignored, as exchanges do not always preserve them.
+func (r *PaymentReference) MatchesMemo(memo string) bool {
+	return r.code != "" && NormalizeReference(memo) == r.code
+}
+
+// NormalizeReference returns the form of a transfer memo reference codes are compared with.
+func NormalizeReference(memo string) string {
+	return strings.ToUpper(strings.TrimSpace(memo))
+}
+
+// randomReferenceCode returns a random reference code of PaymentReferenceLength characters.
+func randomReferenceCode() (string, error) {
+	alphabetSize := big.NewInt(int64(len(referenceAlphabet)))
+	code := make([]byte, PaymentReferenceLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		code[i] = referenceAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// PaymentReference returns the reference that identifies the invoice's payments, or nil for a draft or an
+// invoice issued before invoices had references.
+func (i *Invoice) PaymentReference() *PaymentReference {
+	return i.paymentReference
+}
+
+// SetPaymentReference records the reference that identifies the invoice's payments (for issuance, currency
+// changes and repository restoration).
+func (i *Invoice) SetPaymentReference(reference *PaymentReference) {
+	i.paymentReference = reference
+}
+
+// PaymentMemo returns the memo customers attach to their transfer, or "" when the invoice's network has no
+// memos.
+func (i *Invoice) PaymentMemo() string {
+	if i.paymentReference == nil || i.paymentAddress == nil || !i.paymentAddress.Network().SupportsMemo() {
+		return ""
+	}
+	return i.paymentReference.code
+}
+
+// PayableCryptoAmount returns the amount customers are asked to pay: the amount due at the locked rate,
+// fingerprinted when the invoice's reference fingerprints it.
+func (i *Invoice) PayableCryptoAmount() (*shared.Money, error) {
+	locked, err := i.LockedCryptoAmount()
+	if err != nil || i.paymentReference == nil || !i.paymentReference.IsFingerprinted() {
+		return locked, err
+	}
+	return shared.NewMoneyWithCrypto(i.paymentReference.Apply(locked.Amount()).String(), i.cryptoCurrency)
+}
+
+// FormatPayableAmount formats a payable amount of the invoice for customers. A fingerprinted amount keeps every
+// decimal place of its fingerprint; other amounts are shown like any money.
+func (i *Invoice) FormatPayableAmount(amount *shared.Money) string {
+	if i.paymentReference == nil || !i.paymentReference.IsFingerprinted() {
+		return amount.String()
+	}
+	return amount.Amount().StringFixed(i.paymentReference.places)
+}
+
+// MatchesTransfer returns how a transfer's memo or amount identifies the invoice, if it does: by its reference
+// code as the memo, or by its fingerprinted amount.
+func (i *Invoice) MatchesTransfer(memo string, amount *shared.Money) (ReferenceMatch, bool) {
+	if i.paymentReference == nil {
+		return "", false
+	}
+	if memo != "" && i.paymentReference.MatchesMemo(memo) {
+		return ReferenceMatchMemo, true
+	}
+	if amount == nil || !i.paymentReference.IsFingerprinted() || amount.Currency() != i.cryptoCurrency.String() {
+		return "", false
+	}
+	payable, err := i.PayableCryptoAmount()
+	if err != nil || !payable.Amount().Equal(amount.Amount()) {
+		return "", false
+	}
+	return ReferenceMatchFingerprint, true
+}
+
+// ReferenceMatch is the part of a transfer that identified an invoice by its payment reference.
+type ReferenceMatch string
+
+const (
+	// ReferenceMatchMemo - The transfer memo is the reference code
+	ReferenceMatchMemo ReferenceMatch = "memo"
+	// ReferenceMatchFingerprint - The transfer amount is the fingerprinted amount due
+	ReferenceMatchFingerprint ReferenceMatch = "fingerprint"
+)
@@ -0,0 +1,67 @@
+package invoice_test
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentReference(t *testing.T) {
+	tokens := shared.DefaultTokens()
+	usdtOnTron, bitcoin := tokens[0], tokens[1]
+
+	t.Run("networks with memos are not fingerprinted", func(t *testing.T) {
+		reference, err := invoice.NewPaymentReference(usdtOnTron)
+		require.NoError(t, err)
+		require.Len(t, reference.Code(), invoice.PaymentReferenceLength)
+		require.False(t, reference.IsFingerprinted())
+		require.Equal(t, "12.5", reference.Apply(decimal.RequireFromString("12.5")).String())
+	})
+
+	t.Run("networks without memos are fingerprinted", func(t *testing.T) {
+		reference, err := invoice.NewPaymentReference(bitcoin)
+		require.NoError(t, err)
+		require.True(t, reference.IsFingerprinted())
+		require.GreaterOrEqual(t, reference.Fingerprint(), 1)
+		require.LessOrEqual(t, reference.Fingerprint(), 999)
+		require.Equal(t, int32(8), reference.Places())
+	})
+
+	t.Run("fingerprinting rounds up and replaces the final digits", func(t *testing.T) {
+		reference := invoice.RestorePaymentReference("7K3QX9PA", 417, 8)
+		require.Equal(t, "0.00220417", reference.Apply(decimal.RequireFromString("0.0022")).String())
+		require.Equal(t, "0.00224417", reference.Apply(decimal.RequireFromString("0.002233")).String())
+	})
+
+	t.Run("memos match the code whatever their case", func(t *testing.T) {
+		reference := invoice.RestorePaymentReference("7K3QX9PA", 0, 0)
+		require.True(t, reference.MatchesMemo(" 7k3qx9pa "))
+		require.False(t, reference.MatchesMemo("7K3QX9PB"))
+		require.False(t, reference.MatchesMemo(""))
+	})
+
+	t.Run("transfers match by memo or fingerprinted amount", func(t *testing.T) {
+		testInvoice := createRequotableInvoice(t)
+		testInvoice.SetPaymentReference(invoice.RestorePaymentReference("7K3QX9PA", 417, 8))
+
+		payable, err := testInvoice.PayableCryptoAmount()
+		require.NoError(t, err)
+		require.Equal(t, "0.00220417", testInvoice.FormatPayableAmount(payable))
+
+		match, ok := testInvoice.MatchesTransfer("7k3qx9pa", nil)
+		require.True(t, ok)
+		require.Equal(t, invoice.ReferenceMatchMemo, match)
+
+		match, ok = testInvoice.MatchesTransfer("", payable)
+		require.True(t, ok)
+		require.Equal(t, invoice.ReferenceMatchFingerprint, match)
+
+		locked, err := testInvoice.LockedCryptoAmount()
+		require.NoError(t, err)
+		_, ok = testInvoice.MatchesTransfer("", locked)
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,168 @@
+package invoice
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Refund represents funds returned to the customer for a paid invoice.
+type Refund struct {
+	id          string
+	amount      *shared.Money
+	reason      string
+	requestedBy string
+	destination string // Address the refund is sent to
+	createdAt   time.Time
+}
+
+// NewRefund creates a new refund.
+func NewRefund(id string, amount *shared.Money, reason, requestedBy string, createdAt time.Time) (*Refund, error) {
+	if id == "" {
+		return nil, shared.NewFieldError("refund_id", "refund ID is required")
+	}
+	if amount == nil || amount.IsZero() {
+		return nil, ErrInvalidRefundAmount
+	}
+
+	return &Refund{
+		id:          id,
+		amount:      amount,
+		reason:      reason,
+		requestedBy: requestedBy,
+		createdAt:   createdAt,
+	}, nil
+}
+
+// ID returns the refund ID.
+func (r *Refund) ID() string {
+	return r.id
+}
+
+// Amount returns the refunded amount in the invoice cryptocurrency.
+func (r *Refund) Amount() *shared.Money {
+	return r.amount
+}
+
+// Reason returns why the refund was issued.
+func (r *Refund) Reason() string {
+	return r.reason
+}
+
+// RequestedBy returns who requested the refund.
+func (r *Refund) RequestedBy() string {
+	return r.requestedBy
+}
+
+// Destination returns the address the refund is sent to, or an empty string for refunds recorded before
+// refund destinations were required.
+func (r *Refund) Destination() string {
+	return r.destination
+}
+
+// SetDestination sets the address the refund is sent to.
+func (r *Refund) SetDestination(destination string) {
+	r.destination = destination
+}
+
+// CreatedAt returns when the refund was issued.
+func (r *Refund) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// AmountPaid returns the total amount received for the invoice, or nil if no payment was recorded.
+func (i *Invoice) AmountPaid() *shared.Money {
+	return i.amountPaid
+}
+
+// RecordPayment adds a received payment to the amount paid.
+func (i *Invoice) RecordPayment(amount *shared.Money) error {
+	if amount == nil {
+		return ErrInvalidAmount
+	}
+	if amount.Currency() != i.cryptoCurrency.String() {
+		return ErrCurrencyMismatch
+	}
+
+	total := amount.Amount()
+	if i.amountPaid != nil {
+		total = total.Add(i.amountPaid.Amount())
+	}
+
+	paid, err := shared.NewMoneyWithCrypto(total.String(), i.cryptoCurrency)
+	if err != nil {
+		return err
+	}
+	i.amountPaid = paid
+	i.updatedAt = shared.Now().UTC()
+	return nil
+}
+
+// Refunds returns the refunds issued for the invoice, oldest first.
+func (i *Invoice) Refunds() []*Refund {
+	return i.refunds
+}
+
+// RefundedAmount returns the cumulative amount refunded.
+func (i *Invoice) RefundedAmount() *shared.Money {
+	total := decimal.Zero
+	for _, refund := range i.refunds {
+		total = total.Add(refund.Amount().Amount())
+	}
+	refunded, _ := shared.NewMoneyWithCrypto(total.String(), i.cryptoCurrency)
+	return refunded
+}
+
+// RefundableAmount returns the amount paid minus the amount already refunded.
+// Invoices settled before payments were recorded are refundable up to the invoice crypto amount
+// at the locked rate, even once the rate has expired.
+func (i *Invoice) RefundableAmount() (*shared.Money, error) {
+	paid := i.amountPaid
+	if paid == nil {
+		var err error
+		if paid, err = i.LockedCryptoAmount(); err != nil {
+			return nil, err
+		}
+	}
+
+	refundable, err := paid.Subtract(i.RefundedAmount())
+	if errors.Is(err, shared.ErrNegativeAmount) {
+		return shared.NewMoneyWithCrypto("0", i.cryptoCurrency)
+	}
+	return refundable, err
+}
+
+// AddRefund records a refund after checking it against the refundable amount.
+// The caller transitions the invoice to partially refunded or refunded afterwards.
+func (i *Invoice) AddRefund(refund *Refund) error {
+	if err := CanRefund(i); err != nil {
+		return ErrCannotRefundInvoice
+	}
+	if refund.Amount().Currency() != i.cryptoCurrency.String() {
+		return ErrCurrencyMismatch
+	}
+
+	refundable, err := i.RefundableAmount()
+	if err != nil {
+		return err
+	}
+	if refundable.LessThan(refund.Amount()) {
+		return ErrRefundExceedsPaid
+	}
+
+	i.refunds = append(i.refunds, refund)
+	i.updatedAt = shared.Now().UTC()
+	return nil
+}
+
+// SetAmountPaid sets the amount paid (for repository restoration).
+func (i *Invoice) SetAmountPaid(amountPaid *shared.Money) {
+	i.amountPaid = amountPaid
+}
+
+// SetRefunds sets the refunds (for repository restoration).
+func (i *Invoice) SetRefunds(refunds []*Refund) {
+	i.refunds = refunds
+}
@@ -0,0 +1,273 @@
+package invoice
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RefundDestinationSource is where the refund address of an invoice came from.
+type RefundDestinationSource string
+
+const (
+	// RefundSourceSender - The address that sent most of the confirmed payments for the invoice
+	RefundSourceSender RefundDestinationSource = "sender"
+	// RefundSourceCustomer - An address the customer supplied on the checkout page
+	RefundSourceCustomer RefundDestinationSource = "customer"
+)
+
+// IsValid returns true if the refund destination source is valid.
+func (s RefundDestinationSource) IsValid() bool {
+	return s == RefundSourceSender || s == RefundSourceCustomer
+}
+
+// RefundDestination is the address refunds of an invoice are sent to. Captured and supplied addresses are
+// only proposals: refunds are sent once the merchant has confirmed the address.
+type RefundDestination struct {
+	address     string
+	source      RefundDestinationSource
+	verified    bool // The customer proved control of the address by signing a message with it
+	capturedAt  time.Time
+	confirmedBy string
+	confirmedAt *time.Time
+}
+
+// NewRefundDestination creates an unconfirmed refund destination.
+func NewRefundDestination(
+	address string,
+	source RefundDestinationSource,
+	verified bool,
+	capturedAt time.Time,
+) (*RefundDestination, error) {
+	if strings.TrimSpace(address) == "" {
+		return nil, ErrInvalidRefundAddress
+	}
+	if !source.IsValid() {
+		return nil, fmt.Errorf("invalid refund destination source: %s", source)
+	}
+
+	return &RefundDestination{
+		address:    address,
+		source:     source,
+		verified:   verified,
+		capturedAt: capturedAt,
+	}, nil
+}
+
+// Address returns the address refunds are sent to.
+func (d *RefundDestination) Address() string {
+	return d.address
+}
+
+// Source returns where the address came from.
+func (d *RefundDestination) Source() RefundDestinationSource {
+	return d.source
+}
+
+// Verified returns true if the customer signed a message with the address.
+func (d *RefundDestination) Verified() bool {
+	return d.verified
+}
+
+// CapturedAt returns when the address was captured or supplied.
+func (d *RefundDestination) CapturedAt() time.Time {
+	return d.capturedAt
+}
+
+// ConfirmedBy returns who confirmed the address, or an empty string if it is unconfirmed.
+func (d *RefundDestination) ConfirmedBy() string {
+	return d.confirmedBy
+}
+
+// ConfirmedAt returns when the address was confirmed, or nil if it is unconfirmed.
+func (d *RefundDestination) ConfirmedAt() *time.Time {
+	return d.confirmedAt
+}
+
+// IsConfirmed returns true if the merchant confirmed the address.
+func (d *RefundDestination) IsConfirmed() bool {
+	return d.confirmedAt != nil
+}
+
+// SetConfirmation sets who confirmed the address and when (for repository restoration).
+func (d *RefundDestination) SetConfirmation(confirmedBy string, confirmedAt time.Time) {
+	d.confirmedBy = confirmedBy
+	d.confirmedAt = &confirmedAt
+}
+
+// RefundDestination returns where refunds of the invoice are sent, or nil if no address was captured or supplied.
+func (i *Invoice) RefundDestination() *RefundDestination {
+	return i.refundDest
+}
+
+// SetRefundDestination sets the refund destination (for repository restoration).
+func (i *Invoice) SetRefundDestination(destination *RefundDestination) {
+	i.refundDest = destination
+}
+
+// CaptureRefundSender proposes the dominant sender of the invoice's confirmed payments as its refund address.
+// An address the customer supplied or the merchant confirmed is kept. It reports whether the destination changed.
+func (i *Invoice) CaptureRefundSender(address string, now time.Time) (bool, error) {
+	if current := i.refundDest; current != nil {
+		if current.IsConfirmed() || current.source == RefundSourceCustomer || current.address == address {
+			return false, nil
+		}
+	}
+
+	destination, err := NewRefundDestination(address, RefundSourceSender, false, now)
+	if err != nil {
+		return false, err
+	}
+	i.refundDest = destination
+	i.updatedAt = now
+	return true, nil
+}
+
+// SupplyRefundAddress proposes an address the customer supplied as the invoice's refund address, in place of
+// the captured sender. Verified reports whether the customer signed a message with the address.
+func (i *Invoice) SupplyRefundAddress(address string, verified bool, now time.Time) error {
+	if i.status == StatusDraft {
+		return ErrInvoiceNotFound
+	}
+	if i.refundDest != nil && i.refundDest.IsConfirmed() {
+		return ErrRefundDestinationConfirmed
+	}
+
+	destination, err := NewRefundDestination(address, RefundSourceCustomer, verified, now)
+	if err != nil {
+		return err
+	}
+	i.refundDest = destination
+	i.updatedAt = now
+	return nil
+}
+
+// ConfirmRefundDestination confirms the proposed refund address. The address must match the proposal so the
+// merchant confirms the address they reviewed rather than one supplied since. Confirming again is a no-op.
+func (i *Invoice) ConfirmRefundDestination(address, confirmedBy string, now time.Time) error {
+	if i.refundDest == nil {
+		return ErrNoRefundDestination
+	}
+	if i.refundDest.address != address {
+		return ErrRefundDestinationMismatch
+	}
+	if i.refundDest.IsConfirmed() {
+		return nil
+	}
+
+	i.refundDest.SetConfirmation(confirmedBy, now)
+	i.updatedAt = now
+	return nil
+}
+
+// ConfirmedRefundAddress returns the address refunds of the invoice are sent to once the merchant confirmed it.
+func (i *Invoice) ConfirmedRefundAddress() (string, error) {
+	if i.refundDest == nil || !i.refundDest.IsConfirmed() {
+		return "", ErrRefundDestinationUnconfirmed
+	}
+	return i.refundDest.address, nil
+}
+
+// RefundAddressMessage returns the message a customer signs with a refund address to prove they control it.
+func RefundAddressMessage(invoiceID, address string) string {
+	return fmt.Sprintf("Refund invoice %s to %s", invoiceID, address)
+}
+
+// RefundAddressVerifier checks that a customer controls an address by a message signed with it.
+type RefundAddressVerifier interface {
+	// VerifySignature returns nil if the signature of the message was made with the key of the address.
+	VerifySignature(network shared.BlockchainNetwork, address, message, signature string) error
+}
+
+// CaptureRefundSender proposes the dominant sender of an invoice's confirmed payments as its refund address.
+func (s *InvoiceServiceImpl) CaptureRefundSender(ctx context.Context, invoiceID, address string) error {
+	if invoiceID == "" {
+		return shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+	}
+
+	invoice, err := s.repository.FindByID(ctx, invoiceID)
+	if err != nil {
+		return err
+	}
+
+	changed, err := invoice.CaptureRefundSender(address, shared.Now().UTC())
+	if err != nil || !changed {
+		return err
+	}
+	return s.repository.Update(ctx, invoice)
+}
+
+// SupplyRefundAddress records the refund address a customer supplied, verifying its signature if one is given.
+func (s *InvoiceServiceImpl) SupplyRefundAddress(ctx context.Context, req *SupplyRefundAddressRequest) (*Invoice, error) {
+	if req == nil || req.InvoiceID == "" {
+		return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+	}
+
+	invoice, err := s.repository.FindByID(ctx, req.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	address := strings.TrimSpace(req.Address)
+	if _, err := shared.NewPaymentAddress(address, invoice.Network()); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRefundAddress, err)
+	}
+
+	verified := false
+	if req.Signature != "" {
+		if s.verifier == nil {
+			return nil, fmt.Errorf("%w: signatures are not checked", ErrInvalidRefundSignature)
+		}
+		message := RefundAddressMessage(invoice.ID(), address)
+		if err := s.verifier.VerifySignature(invoice.Network(), address, message, req.Signature); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRefundSignature, err)
+		}
+		verified = true
+	}
+
+	if err := invoice.SupplyRefundAddress(address, verified, shared.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := s.repository.Update(ctx, invoice); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// ConfirmRefundDestination confirms the refund address proposed for an invoice.
+func (s *InvoiceServiceImpl) ConfirmRefundDestination(
+	ctx context.Context, invoiceID, address, confirmedBy string,
+) (*Invoice, error) {
+	if invoiceID == "" {
+		return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+	}
+
+	invoice, err := s.repository.FindByID(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	alreadyConfirmed := invoice.RefundDestination() != nil && invoice.RefundDestination().IsConfirmed()
+	if err := invoice.ConfirmRefundDestination(address, confirmedBy, shared.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if alreadyConfirmed {
+		return invoice, nil
+	}
+
+	if err := s.repository.Update(ctx, invoice); err != nil {
+		return nil, err
+	}
+	if s.logger != nil {
+		s.logger.Info("Refund destination confirmed",
+			zap.String("invoice_id", invoice.ID()),
+			zap.String("source", string(invoice.RefundDestination().Source())),
+			zap.String("confirmed_by", confirmedBy),
+		)
+	}
+	return invoice, nil
+}
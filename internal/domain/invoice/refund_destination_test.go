@@ -0,0 +1,178 @@
+package invoice_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	testSenderAddress   = "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"
+	testCustomerAddress = "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"
+)
+
+// stubVerifier accepts the signature "valid" and rejects any other.
+type stubVerifier struct{}
+
+func (stubVerifier) VerifySignature(_ shared.BlockchainNetwork, _, _, signature string) error {
+	if signature != "valid" {
+		return errors.New("signature was made with another address")
+	}
+	return nil
+}
+
+// newRefundTestPayment returns a payment of the amount from the sender in the given status.
+func newRefundTestPayment(
+	t *testing.T, id, sender, amount string, status payment.PaymentStatus,
+) *payment.Payment {
+	p := newStaleRateTestPayment(t, amount)
+	restored, err := payment.NewPayment(shared.PaymentID(id), p.InvoiceID(), p.Amount(), sender, p.ToAddress(),
+		p.TransactionHash(), 1)
+	require.NoError(t, err)
+	restored.SetStatus(status)
+	return restored
+}
+
+func TestInvoice_RefundDestination(t *testing.T) {
+	now := time.Now().UTC()
+
+	t.Run("refunds need a confirmed destination", func(t *testing.T) {
+		inv := createPaidTestInvoice(t, "0.002")
+		_, err := inv.ConfirmedRefundAddress()
+		require.ErrorIs(t, err, invoice.ErrRefundDestinationUnconfirmed)
+		require.ErrorIs(t, inv.ConfirmRefundDestination(testSenderAddress, "user-1", now),
+			invoice.ErrNoRefundDestination)
+
+		changed, err := inv.CaptureRefundSender(testSenderAddress, now)
+		require.NoError(t, err)
+		require.True(t, changed)
+		_, err = inv.ConfirmedRefundAddress()
+		require.ErrorIs(t, err, invoice.ErrRefundDestinationUnconfirmed)
+
+		require.ErrorIs(t, inv.ConfirmRefundDestination(testCustomerAddress, "user-1", now),
+			invoice.ErrRefundDestinationMismatch)
+		require.NoError(t, inv.ConfirmRefundDestination(testSenderAddress, "user-1", now))
+		address, err := inv.ConfirmedRefundAddress()
+		require.NoError(t, err)
+		require.Equal(t, testSenderAddress, address)
+		require.Equal(t, "user-1", inv.RefundDestination().ConfirmedBy())
+	})
+
+	t.Run("customer address replaces the sender", func(t *testing.T) {
+		inv := createPaidTestInvoice(t, "0.002")
+		_, err := inv.CaptureRefundSender(testSenderAddress, now)
+		require.NoError(t, err)
+		require.NoError(t, inv.SupplyRefundAddress(testCustomerAddress, true, now))
+		require.Equal(t, invoice.RefundSourceCustomer, inv.RefundDestination().Source())
+		require.True(t, inv.RefundDestination().Verified())
+
+		// A later sender does not override the customer's choice
+		changed, err := inv.CaptureRefundSender(testSenderAddress, now)
+		require.NoError(t, err)
+		require.False(t, changed)
+		require.Equal(t, testCustomerAddress, inv.RefundDestination().Address())
+	})
+
+	t.Run("confirmed destination is kept", func(t *testing.T) {
+		inv := createPaidTestInvoice(t, "0.002")
+		_, err := inv.CaptureRefundSender(testSenderAddress, now)
+		require.NoError(t, err)
+		require.NoError(t, inv.ConfirmRefundDestination(testSenderAddress, "user-1", now))
+
+		require.ErrorIs(t, inv.SupplyRefundAddress(testCustomerAddress, false, now),
+			invoice.ErrRefundDestinationConfirmed)
+		changed, err := inv.CaptureRefundSender("3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", now)
+		require.NoError(t, err)
+		require.False(t, changed)
+		require.Equal(t, testSenderAddress, inv.RefundDestination().Address())
+	})
+}
+
+func TestDominantSender(t *testing.T) {
+	require.Empty(t, invoice.DominantSender(nil))
+
+	payments := []*payment.Payment{
+		newRefundTestPayment(t, "payment-1", "1Small", "0.0005", payment.StatusConfirmed),
+		newRefundTestPayment(t, "payment-2", "1Large", "0.0004", payment.StatusConfirmed),
+		newRefundTestPayment(t, "payment-3", "1Large", "0.0004", payment.StatusConfirmed),
+		newRefundTestPayment(t, "payment-4", "1Pending", "0.01", payment.StatusConfirming),
+	}
+	require.Equal(t, "1Large", invoice.DominantSender(payments))
+
+	// Ties go to the earliest sender
+	tied := []*payment.Payment{payments[1], newRefundTestPayment(t, "payment-5", "1Other", "0.0004",
+		payment.StatusConfirmed)}
+	require.Equal(t, "1Large", invoice.DominantSender(tied))
+}
+
+func TestInvoiceService_RefundDestination(t *testing.T) {
+	ctx := context.Background()
+
+	newService := func(inv *invoice.Invoice, verifier invoice.RefundAddressVerifier) invoice.InvoiceService {
+		repository := &stubRepository{invoices: map[string]*invoice.Invoice{inv.ID(): inv}}
+		return invoice.NewInvoiceService(repository, nil, nil, nil, invoice.DefaultRequotePolicy(), nil, nil,
+			nil, nil, nil, verifier, nil, nil, nil, zap.NewNop())
+	}
+
+	t.Run("refund waits for confirmation", func(t *testing.T) {
+		inv := createPaidTestInvoice(t, "0.002")
+		service := newService(inv, nil)
+
+		require.NoError(t, service.CaptureRefundSender(ctx, inv.ID(), testSenderAddress))
+		_, err := service.RefundInvoice(ctx, &invoice.RefundInvoiceRequest{InvoiceID: inv.ID()})
+		require.ErrorIs(t, err, invoice.ErrRefundDestinationUnconfirmed)
+
+		_, err = service.ConfirmRefundDestination(ctx, inv.ID(), testSenderAddress, "user-1")
+		require.NoError(t, err)
+		refund, err := service.RefundInvoice(ctx, &invoice.RefundInvoiceRequest{InvoiceID: inv.ID()})
+		require.NoError(t, err)
+		require.Equal(t, testSenderAddress, refund.Destination())
+	})
+
+	t.Run("explicit destination", func(t *testing.T) {
+		inv := createPaidTestInvoice(t, "0.002")
+		refund, err := newService(inv, nil).RefundInvoice(ctx, &invoice.RefundInvoiceRequest{
+			InvoiceID:   inv.ID(),
+			Destination: testSenderAddress,
+		})
+		require.NoError(t, err)
+		require.Equal(t, testSenderAddress, refund.Destination())
+	})
+
+	t.Run("customer supplies an address", func(t *testing.T) {
+		inv := createPaidTestInvoice(t, "0.002")
+		service := newService(inv, stubVerifier{})
+
+		_, err := service.SupplyRefundAddress(ctx, &invoice.SupplyRefundAddressRequest{
+			InvoiceID: inv.ID(), Address: "short",
+		})
+		require.ErrorIs(t, err, invoice.ErrInvalidRefundAddress)
+
+		_, err = service.SupplyRefundAddress(ctx, &invoice.SupplyRefundAddressRequest{
+			InvoiceID: inv.ID(), Address: testCustomerAddress, Signature: "forged",
+		})
+		require.ErrorIs(t, err, invoice.ErrInvalidRefundSignature)
+
+		updated, err := service.SupplyRefundAddress(ctx, &invoice.SupplyRefundAddressRequest{
+			InvoiceID: inv.ID(), Address: testCustomerAddress, Signature: "valid",
+		})
+		require.NoError(t, err)
+		require.Equal(t, testCustomerAddress, updated.RefundDestination().Address())
+		require.True(t, updated.RefundDestination().Verified())
+	})
+
+	t.Run("signatures need a verifier", func(t *testing.T) {
+		inv := createPaidTestInvoice(t, "0.002")
+		_, err := newService(inv, nil).SupplyRefundAddress(ctx, &invoice.SupplyRefundAddressRequest{
+			InvoiceID: inv.ID(), Address: testCustomerAddress, Signature: "valid",
+		})
+		require.ErrorIs(t, err, invoice.ErrInvalidRefundSignature)
+	})
+}
@@ -0,0 +1,94 @@
+package invoice
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// RefundSenderHandler captures the dominant sender of an invoice's confirmed payments as its refund address.
+type RefundSenderHandler struct {
+	service  InvoiceService
+	payments payment.Repository
+	logger   *zap.Logger
+}
+
+// NewRefundSenderHandler creates a new refund sender handler.
+func NewRefundSenderHandler(service InvoiceService, payments payment.Repository, logger *zap.Logger) *RefundSenderHandler {
+	return &RefundSenderHandler{
+		service:  service,
+		payments: payments,
+		logger:   logger,
+	}
+}
+
+// RegisterRefundSenderHandler subscribes a refund sender handler to payment events.
+func RegisterRefundSenderHandler(
+	registry shared.EventHandlerRegistry,
+	service InvoiceService,
+	payments payment.Repository,
+	logger *zap.Logger,
+) {
+	registry.RegisterHandler(NewRefundSenderHandler(service, payments, logger))
+}
+
+// EventTypes returns the payment events that may confirm a payment.
+func (h *RefundSenderHandler) EventTypes() []string {
+	return []string{shared.EventTypePaymentStatusChanged}
+}
+
+// HandleEvent proposes the address that sent the largest share of the invoice's confirmed payments as its
+// refund address once one of them is confirmed. Events for payments in other states are ignored.
+func (h *RefundSenderHandler) HandleEvent(ctx context.Context, event *shared.BaseDomainEvent) error {
+	var data shared.PaymentStatusChangedEvent
+	if err := shared.DecodeEventData(event, &data); err != nil {
+		return fmt.Errorf("failed to decode payment event: %w", err)
+	}
+	if data.Status != payment.StatusConfirmed.String() || data.InvoiceID == "" {
+		return nil
+	}
+	invoiceID := data.InvoiceID
+
+	payments, err := h.payments.FindByInvoiceID(ctx, shared.InvoiceID(invoiceID))
+	if err != nil {
+		return fmt.Errorf("failed to load payments for refund sender: %w", err)
+	}
+	sender := DominantSender(payments)
+	if sender == "" {
+		return nil
+	}
+
+	if err := h.service.CaptureRefundSender(ctx, invoiceID, sender); err != nil {
+		return fmt.Errorf("failed to capture refund sender: %w", err)
+	}
+	h.logger.Debug("Captured refund sender", zap.String("invoice_id", invoiceID))
+	return nil
+}
+
+// DominantSender returns the address that sent the largest total of the confirmed payments, preferring the
+// earliest sender on a tie, or an empty string if none of the payments is confirmed.
+func DominantSender(payments []*payment.Payment) string {
+	totals := make(map[string]decimal.Decimal)
+	var senders []string
+	for _, p := range payments {
+		if p.Status() != payment.StatusConfirmed || p.FromAddress() == "" {
+			continue
+		}
+		if _, seen := totals[p.FromAddress()]; !seen {
+			senders = append(senders, p.FromAddress())
+		}
+		totals[p.FromAddress()] = totals[p.FromAddress()].Add(p.Amount().Amount().Amount())
+	}
+
+	dominant := ""
+	for _, sender := range senders {
+		if dominant == "" || totals[sender].GreaterThan(totals[dominant]) {
+			dominant = sender
+		}
+	}
+	return dominant
+}
@@ -0,0 +1,144 @@
+package invoice_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/memory"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func createPaidTestInvoice(t *testing.T, amountPaid string) *invoice.Invoice {
+	testInvoice := createTestInvoice()
+	paid, err := shared.NewMoneyWithCrypto(amountPaid, shared.CryptoCurrencyBTC)
+	require.NoError(t, err)
+	require.NoError(t, testInvoice.RecordPayment(paid))
+
+	fsm := invoice.NewInvoiceFSM(testInvoice)
+	require.NoError(t, fsm.TransitionTo(invoice.StatusPending))
+	require.NoError(t, fsm.TransitionTo(invoice.StatusConfirming))
+	require.NoError(t, fsm.TransitionTo(invoice.StatusPaid))
+	return testInvoice
+}
+
+func newTestRefund(t *testing.T, id, amount string) *invoice.Refund {
+	money, err := shared.NewMoneyWithCrypto(amount, shared.CryptoCurrencyBTC)
+	require.NoError(t, err)
+	refund, err := invoice.NewRefund(id, money, "returned item", "user-1", time.Now().UTC())
+	require.NoError(t, err)
+	return refund
+}
+
+func TestInvoiceRefunds(t *testing.T) {
+	t.Run("RecordPayment accumulates amount paid", func(t *testing.T) {
+		testInvoice := createTestInvoice()
+		require.Nil(t, testInvoice.AmountPaid())
+
+		first, _ := shared.NewMoneyWithCrypto("0.0010", shared.CryptoCurrencyBTC)
+		second, _ := shared.NewMoneyWithCrypto("0.00125", shared.CryptoCurrencyBTC)
+		require.NoError(t, testInvoice.RecordPayment(first))
+		require.NoError(t, testInvoice.RecordPayment(second))
+		require.Equal(t, "0.00225", testInvoice.AmountPaid().Amount().String())
+
+		usdt, _ := shared.NewMoneyWithCrypto("1", shared.CryptoCurrencyUSDT)
+		require.ErrorIs(t, testInvoice.RecordPayment(usdt), invoice.ErrCurrencyMismatch)
+	})
+
+	t.Run("partial refunds reduce the refundable amount", func(t *testing.T) {
+		testInvoice := createPaidTestInvoice(t, "0.0022")
+
+		require.NoError(t, testInvoice.AddRefund(newTestRefund(t, "ref-1", "0.0010")))
+		require.NoError(t, invoice.NewInvoiceFSM(testInvoice).TransitionTo(invoice.StatusPartiallyRefunded))
+		require.NoError(t, testInvoice.AddRefund(newTestRefund(t, "ref-2", "0.00025")))
+
+		require.Len(t, testInvoice.Refunds(), 2)
+		require.Equal(t, "0.00125", testInvoice.RefundedAmount().Amount().String())
+
+		refundable, err := testInvoice.RefundableAmount()
+		require.NoError(t, err)
+		require.Equal(t, "0.00095", refundable.Amount().String())
+
+		require.ErrorIs(t, testInvoice.AddRefund(newTestRefund(t, "ref-3", "0.001")), invoice.ErrRefundExceedsPaid)
+		require.Len(t, testInvoice.Refunds(), 2)
+
+		require.NoError(t, testInvoice.AddRefund(newTestRefund(t, "ref-3", "0.00095")))
+		require.NoError(t, invoice.NewInvoiceFSM(testInvoice).TransitionTo(invoice.StatusRefunded))
+		require.Equal(t, invoice.StatusRefunded, testInvoice.Status())
+	})
+
+	t.Run("unpaid invoices cannot be refunded", func(t *testing.T) {
+		testInvoice := createTestInvoice()
+		require.ErrorIs(t, testInvoice.AddRefund(newTestRefund(t, "ref-1", "0.001")), invoice.ErrCannotRefundInvoice)
+	})
+
+	t.Run("zero refund is rejected", func(t *testing.T) {
+		zero, _ := shared.NewMoneyWithCrypto("0", shared.CryptoCurrencyBTC)
+		_, err := invoice.NewRefund("ref-1", zero, "", "", time.Now().UTC())
+		require.ErrorIs(t, err, invoice.ErrInvalidRefundAmount)
+	})
+}
+
+// snapshotRepository holds every lookup until all the callers expected have loaded the invoice, so they all
+// work on the same snapshot.
+type snapshotRepository struct {
+	invoice.Repository
+	loaded *sync.WaitGroup
+}
+
+func (r *snapshotRepository) FindByID(ctx context.Context, id string) (*invoice.Invoice, error) {
+	inv, err := r.Repository.FindByID(ctx, id)
+	r.loaded.Done()
+	r.loaded.Wait()
+	return inv, err
+}
+
+func TestInvoiceService_ConcurrentRefunds(t *testing.T) {
+	ctx := context.Background()
+	repository := memory.NewInvoiceRepository(nil)
+	paid := createPaidTestInvoice(t, "0.002")
+	require.NoError(t, repository.Save(ctx, paid))
+
+	// Each refund fits what is left on the snapshot, but together they exceed the amount paid
+	const attempts = 3
+	var loaded sync.WaitGroup
+	loaded.Add(attempts)
+	service := invoice.NewInvoiceService(&snapshotRepository{Repository: repository, loaded: &loaded}, nil, nil,
+		nil, invoice.DefaultRequotePolicy(), nil, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+	var wg sync.WaitGroup
+	errs := make([]error, attempts)
+	for i := range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = service.RefundInvoice(ctx, &invoice.RefundInvoiceRequest{
+				InvoiceID:   paid.ID(),
+				Amount:      "0.0008",
+				Destination: testSenderAddress,
+			})
+		}()
+	}
+	wg.Wait()
+
+	// The first refund stored wins; the others were checked against a stale snapshot and are turned away
+	issued := 0
+	for _, err := range errs {
+		if err == nil {
+			issued++
+			continue
+		}
+		require.ErrorIs(t, err, invoice.ErrConcurrentUpdate)
+	}
+	require.Equal(t, 1, issued)
+
+	stored, err := repository.FindByID(ctx, paid.ID())
+	require.NoError(t, err)
+	require.Len(t, stored.Refunds(), 1)
+	require.Equal(t, "0.0008", stored.RefundedAmount().Amount().String())
+	require.Equal(t, invoice.StatusPartiallyRefunded, stored.Status())
+}
@@ -0,0 +1,92 @@
+package invoice
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// CanReopen checks that the invoice is paid, so it can go back to awaiting confirmation.
+func CanReopen(invoice *Invoice) error {
+	if invoice.Status() != StatusPaid {
+		return ErrCannotReopenInvoice
+	}
+	return nil
+}
+
+// Reopen moves a paid invoice back to confirming, keeping the amount paid. It undoes marking the invoice paid
+// when the settlement of its payment could not be carried through.
+func (i *Invoice) Reopen(now time.Time) error {
+	if err := CanReopen(i); err != nil {
+		return err
+	}
+	if err := NewInvoiceFSM(i).TransitionTo(StatusConfirming); err != nil {
+		return err
+	}
+	i.paidAt = nil
+	i.updatedAt = now
+	return nil
+}
+
+// ReopenInvoice moves a paid invoice back to confirming, recording why.
+func (s *InvoiceServiceImpl) ReopenInvoice(ctx context.Context, id, reason string) (*Invoice, error) {
+	if id == "" {
+		return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+	}
+
+	invoice, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := shared.Now().UTC()
+	if err := invoice.Reopen(now); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(ctx, invoice); err != nil {
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.Warn("Invoice reopened",
+			zap.String("invoice_id", invoice.ID()),
+			zap.String("reason", reason))
+	}
+	s.publishInvoiceReopened(ctx, invoice, reason, now)
+	return invoice, nil
+}
+
+// publishInvoiceReopened publishes the status change of a reopened invoice.
+func (s *InvoiceServiceImpl) publishInvoiceReopened(
+	ctx context.Context,
+	invoice *Invoice,
+	reason string,
+	reopenedAt time.Time,
+) {
+	if s.eventBus == nil {
+		return
+	}
+
+	eventData := shared.InvoiceStatusChangedEvent{
+		InvoiceEvent: createInvoiceEventData(invoice),
+		FromStatus:   StatusPaid.String(),
+		ToStatus:     invoice.Status().String(),
+		Reason:       reason,
+		UpdatedAt:    &reopenedAt,
+	}
+	eventData.Timestamp = reopenedAt
+	event := shared.NewInvoiceStatusChangedEvent(eventData)
+	if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+		// Log error but don't fail the operation
+		if s.logger != nil {
+			s.logger.Error("Failed to publish domain event",
+				zap.String("event_type", shared.EventTypeInvoiceStatusChanged),
+				zap.String("aggregate_id", invoice.ID()),
+				zap.Error(err),
+			)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package invoice_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestInvoice_Reopen(t *testing.T) {
+	now := time.Now().UTC()
+
+	t.Run("paid invoice goes back to confirming", func(t *testing.T) {
+		inv := createPaidTestInvoice(t, "0.002")
+		require.NotNil(t, inv.PaidAt())
+
+		require.NoError(t, inv.Reopen(now))
+		require.Equal(t, invoice.StatusConfirming, inv.Status())
+		require.Nil(t, inv.PaidAt())
+		require.Equal(t, "0.002", inv.AmountPaid().Amount().String())
+
+		// Confirming the payment again marks it paid
+		require.NoError(t, invoice.NewInvoiceFSM(inv).TransitionTo(invoice.StatusPaid))
+		require.NotNil(t, inv.PaidAt())
+	})
+
+	t.Run("only paid invoices are reopened", func(t *testing.T) {
+		inv := createPaidTestInvoice(t, "0.002")
+		require.NoError(t, inv.AddRefund(newTestRefund(t, "refund-1", "0.002")))
+		require.NoError(t, invoice.NewInvoiceFSM(inv).TransitionTo(invoice.StatusRefunded))
+
+		require.ErrorIs(t, inv.Reopen(now), invoice.ErrCannotReopenInvoice)
+		require.ErrorIs(t, createTestInvoice().Reopen(now), invoice.ErrCannotReopenInvoice)
+	})
+}
+
+func TestInvoiceService_ReopenInvoice(t *testing.T) {
+	ctx := context.Background()
+	inv := createPaidTestInvoice(t, "0.002")
+	events := &recordingEventBus{}
+	repository := &stubRepository{invoices: map[string]*invoice.Invoice{inv.ID(): inv}}
+	service := invoice.NewInvoiceService(repository, events, nil, nil, invoice.DefaultRequotePolicy(), nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+	reopened, err := service.ReopenInvoice(ctx, inv.ID(), "payout failed")
+	require.NoError(t, err)
+	require.Equal(t, invoice.StatusConfirming, reopened.Status())
+
+	require.Len(t, events.events, 1)
+	require.Equal(t, shared.EventTypeInvoiceStatusChanged, events.events[0].EventType)
+	data := events.events[0].EventData.(shared.InvoiceStatusChangedEvent)
+	require.Equal(t, "paid", data.FromStatus)
+	require.Equal(t, "confirming", data.ToStatus)
+	require.Equal(t, "payout failed", data.Reason)
+	require.NoError(t, shared.DefaultEventRegistry().Validate(events.events[0]))
+
+	_, err = service.ReopenInvoice(ctx, inv.ID(), "payout failed")
+	require.ErrorIs(t, err, invoice.ErrCannotReopenInvoice)
+	require.Len(t, events.events, 1)
+}
@@ -16,16 +16,29 @@ type Repository interface {
 	// FindByPaymentAddress retrieves an invoice by its payment address.
 	FindByPaymentAddress(ctx context.Context, address *shared.PaymentAddress) (*Invoice, error)
 
+	// FindByPaymentReference retrieves an invoice by the code of its payment reference.
+	FindByPaymentReference(ctx context.Context, code string) (*Invoice, error)
+
+	// FindPayableByPaymentAddress retrieves the invoices paid to an address that still accept payments.
+	FindPayableByPaymentAddress(ctx context.Context, address *shared.PaymentAddress) ([]*Invoice, error)
+
 	// FindByStatus retrieves all invoices with the given status.
 	FindByStatus(ctx context.Context, status InvoiceStatus) ([]*Invoice, error)
 
 	// FindActive retrieves all active (non-terminal) invoices.
 	FindActive(ctx context.Context) ([]*Invoice, error)
 
+	// List retrieves a page of invoices matching the request, newest first.
+	List(ctx context.Context, req *ListInvoicesRequest) (*ListInvoicesResponse, error)
+
 	// FindExpired retrieves all expired invoices.
 	FindExpired(ctx context.Context) ([]*Invoice, error)
 
-	// Update updates an existing invoice in the data store.
+	// FindRequotable retrieves the unpaid, unexpired invoices whose exchange rate has expired.
+	FindRequotable(ctx context.Context) ([]*Invoice, error)
+
+	// Update updates an existing invoice in the data store and advances its version. It fails with
+	// ErrConcurrentUpdate if the invoice has been updated since it was loaded.
 	Update(ctx context.Context, invoice *Invoice) error
 
 	// Delete removes an invoice from the data store.
@@ -33,4 +46,12 @@ type Repository interface {
 
 	// Exists checks if an invoice with the given ID exists.
 	Exists(ctx context.Context, id string) (bool, error)
+
+	// FindStatusSummaries retrieves the payment state of the invoices with the given IDs in a single query.
+	// IDs that match no invoice are left out of the result.
+	FindStatusSummaries(ctx context.Context, ids []string) ([]*StatusSummary, error)
+
+	// FindPaymentAddresses retrieves the payment addresses of the invoices on a network, whatever their status.
+	// Invoices stored before their network was recorded are on their cryptocurrency's default network.
+	FindPaymentAddresses(ctx context.Context, network shared.BlockchainNetwork) ([]string, error)
 }
@@ -0,0 +1,188 @@
+package invoice
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Requote records the replacement of an expired exchange rate on an unpaid invoice.
+type Requote struct {
+	previousRate   decimal.Decimal
+	newRate        decimal.Decimal
+	previousAmount *shared.Money
+	newAmount      *shared.Money
+	source         string
+	requotedAt     time.Time
+}
+
+// NewRequote creates a new re-quote record.
+func NewRequote(
+	previousRate, newRate decimal.Decimal,
+	previousAmount, newAmount *shared.Money,
+	source string,
+	requotedAt time.Time,
+) (*Requote, error) {
+	if !previousRate.IsPositive() || !newRate.IsPositive() {
+		return nil, ErrInvalidExchangeRate
+	}
+	if previousAmount == nil || newAmount == nil {
+		return nil, ErrInvalidAmount
+	}
+	if source == "" {
+		return nil, shared.NewFieldError("rate_source", "rate source is required")
+	}
+
+	return &Requote{
+		previousRate:   previousRate,
+		newRate:        newRate,
+		previousAmount: previousAmount,
+		newAmount:      newAmount,
+		source:         source,
+		requotedAt:     requotedAt,
+	}, nil
+}
+
+// PreviousRate returns the expired rate that was replaced.
+func (r *Requote) PreviousRate() decimal.Decimal {
+	return r.previousRate
+}
+
+// NewRate returns the rate locked by the re-quote.
+func (r *Requote) NewRate() decimal.Decimal {
+	return r.newRate
+}
+
+// PreviousAmount returns the crypto amount due at the previous rate.
+func (r *Requote) PreviousAmount() *shared.Money {
+	return r.previousAmount
+}
+
+// NewAmount returns the crypto amount due at the new rate.
+func (r *Requote) NewAmount() *shared.Money {
+	return r.newAmount
+}
+
+// Source returns the provider of the new rate.
+func (r *Requote) Source() string {
+	return r.source
+}
+
+// RequotedAt returns when the rate was replaced.
+func (r *Requote) RequotedAt() time.Time {
+	return r.requotedAt
+}
+
+// Slippage returns the relative change from the previous rate to the new one, as a non-negative fraction.
+func (r *Requote) Slippage() decimal.Decimal {
+	return rateSlippage(r.previousRate, r.newRate)
+}
+
+// LockedCryptoAmount returns the amount due in the invoice cryptocurrency at the locked rate,
+// even once the rate has expired, so payments are judged against the amount the customer was shown.
+func (i *Invoice) LockedCryptoAmount() (*shared.Money, error) {
+	if i.exchangeRate == nil {
+		return nil, ErrInvalidExchangeRate
+	}
+	amount := i.pricing.Total().Amount().Mul(i.exchangeRate.Rate())
+	return shared.NewMoneyWithCrypto(amount.String(), i.cryptoCurrency)
+}
+
+// Requotes returns the re-quotes of the invoice, oldest first.
+func (i *Invoice) Requotes() []*Requote {
+	return i.requotes
+}
+
+// CanRequote returns true if the invoice is unpaid and unexpired but its exchange rate has expired.
+func (i *Invoice) CanRequote() bool {
+	if (i.status != StatusCreated && i.status != StatusPending) || i.amountPaid != nil {
+		return false
+	}
+	if i.expiration != nil && i.expiration.IsExpired() {
+		return false
+	}
+	return i.exchangeRate.IsExpired()
+}
+
+// Requote replaces the expired exchange rate of an unpaid invoice with a fresh one, repricing the crypto amount.
+// The rate is rejected if it moved from the expired rate by more than maxSlippage, a fraction such as 0.02 for 2%.
+func (i *Invoice) Requote(rate *shared.ExchangeRate, maxSlippage decimal.Decimal) (*Requote, error) {
+	if rate == nil {
+		return nil, ErrInvalidExchangeRate
+	}
+	if !i.CanRequote() {
+		return nil, ErrCannotRequote
+	}
+	if rate.FromCurrency() != i.exchangeRate.FromCurrency() || rate.ToCurrency() != i.cryptoCurrency {
+		return nil, ErrCurrencyMismatch
+	}
+
+	slippage := rateSlippage(i.exchangeRate.Rate(), rate.Rate())
+	if slippage.GreaterThan(maxSlippage) {
+		return nil, fmt.Errorf("%w: %s%% exceeds %s%%",
+			ErrSlippageExceeded, percent(slippage), percent(maxSlippage))
+	}
+
+	return i.relock(rate)
+}
+
+// RefreshRate replaces the expired exchange rate of an unpaid invoice with a fresh one however far the rate
+// moved. It is used when the merchant extends the invoice, which accepts the current rate.
+func (i *Invoice) RefreshRate(rate *shared.ExchangeRate) (*Requote, error) {
+	if rate == nil {
+		return nil, ErrInvalidExchangeRate
+	}
+	if !i.CanRequote() {
+		return nil, ErrCannotRequote
+	}
+	if rate.FromCurrency() != i.exchangeRate.FromCurrency() || rate.ToCurrency() != i.cryptoCurrency {
+		return nil, ErrCurrencyMismatch
+	}
+
+	return i.relock(rate)
+}
+
+// relock locks the rate on the invoice and records the re-quote.
+func (i *Invoice) relock(rate *shared.ExchangeRate) (*Requote, error) {
+	previousAmount, err := i.LockedCryptoAmount()
+	if err != nil {
+		return nil, err
+	}
+	previousRate := i.exchangeRate.Rate()
+
+	i.exchangeRate = rate
+	newAmount, err := i.LockedCryptoAmount()
+	if err != nil {
+		return nil, err
+	}
+
+	now := shared.Now().UTC()
+	requote, err := NewRequote(previousRate, rate.Rate(), previousAmount, newAmount, rate.Source(), now)
+	if err != nil {
+		return nil, err
+	}
+
+	i.requotes = append(i.requotes, requote)
+	i.updatedAt = now
+	return requote, nil
+}
+
+// SetRequotes sets the re-quotes (for repository restoration).
+func (i *Invoice) SetRequotes(requotes []*Requote) {
+	i.requotes = requotes
+}
+
+// rateSlippage returns |newRate - previousRate| / previousRate.
+func rateSlippage(previousRate, newRate decimal.Decimal) decimal.Decimal {
+	if previousRate.IsZero() {
+		return decimal.Zero
+	}
+	return newRate.Sub(previousRate).Abs().Div(previousRate)
+}
+
+// percent formats a fraction as a percentage with up to two decimal places.
+func percent(fraction decimal.Decimal) string {
+	return fraction.Mul(decimal.NewFromInt(100)).Round(2).String()
+}
@@ -0,0 +1,107 @@
+package invoice_test
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+// createRequotableInvoice creates an unpaid 110 USD invoice whose BTC rate was locked an hour ago and has expired.
+func createRequotableInvoice(t *testing.T) *invoice.Invoice {
+	subtotal, _ := shared.NewMoney("100.00", shared.CurrencyUSD)
+	tax, _ := shared.NewMoney("10.00", shared.CurrencyUSD)
+	total, _ := shared.NewMoney("110.00", shared.CurrencyUSD)
+	pricing, err := invoice.NewInvoicePricing(subtotal, tax, total)
+	require.NoError(t, err)
+	item, err := invoice.NewInvoiceItem("Test Item", "A test item", "1", subtotal)
+	require.NoError(t, err)
+	paymentAddress, err := shared.NewPaymentAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", shared.NetworkBitcoin)
+	require.NoError(t, err)
+	tolerance, err := invoice.NewPaymentTolerance("0.95", "1.05", invoice.OverpaymentActionRefund)
+	require.NoError(t, err)
+
+	lockedAt := time.Now().UTC().Add(-time.Hour)
+	rate, err := shared.RestoreExchangeRate("0.00002", shared.CurrencyUSD, shared.CryptoCurrencyBTC, "test-source",
+		lockedAt, lockedAt.Add(30*time.Minute))
+	require.NoError(t, err)
+
+	testInvoice, err := invoice.NewInvoice("test-invoice-id", "test-merchant-id", "Test Invoice", "A test invoice",
+		[]*invoice.InvoiceItem{item}, pricing, shared.CryptoCurrencyBTC, paymentAddress, rate, tolerance,
+		invoice.NewInvoiceExpiration(24*time.Hour), nil)
+	require.NoError(t, err)
+	return testInvoice
+}
+
+func newTestRate(t *testing.T, rate string) *shared.ExchangeRate {
+	exchangeRate, err := shared.NewExchangeRate(rate, shared.CurrencyUSD, shared.CryptoCurrencyBTC, "fresh-source",
+		30*time.Minute)
+	require.NoError(t, err)
+	return exchangeRate
+}
+
+func TestInvoiceRequote(t *testing.T) {
+	maxSlippage := decimal.RequireFromString("0.02")
+
+	t.Run("expired rate within slippage reprices the invoice", func(t *testing.T) {
+		testInvoice := createRequotableInvoice(t)
+		require.True(t, testInvoice.CanRequote())
+
+		_, err := testInvoice.GetCryptoAmount()
+		require.Error(t, err)
+		locked, err := testInvoice.LockedCryptoAmount()
+		require.NoError(t, err)
+		require.Equal(t, "0.0022", locked.Amount().String())
+
+		requote, err := testInvoice.Requote(newTestRate(t, "0.0000203"), maxSlippage)
+		require.NoError(t, err)
+		require.Equal(t, "0.015", requote.Slippage().String())
+		require.Equal(t, "0.0022", requote.PreviousAmount().Amount().String())
+		require.Equal(t, "0.002233", requote.NewAmount().Amount().String())
+		require.Equal(t, "fresh-source", requote.Source())
+		require.Len(t, testInvoice.Requotes(), 1)
+
+		amount, err := testInvoice.GetCryptoAmount()
+		require.NoError(t, err)
+		require.Equal(t, "0.002233", amount.Amount().String())
+		require.False(t, testInvoice.CanRequote())
+	})
+
+	t.Run("rate moved beyond slippage is rejected", func(t *testing.T) {
+		testInvoice := createRequotableInvoice(t)
+
+		_, err := testInvoice.Requote(newTestRate(t, "0.000021"), maxSlippage)
+		require.ErrorIs(t, err, invoice.ErrSlippageExceeded)
+		require.Empty(t, testInvoice.Requotes())
+		require.Equal(t, "0.00002", testInvoice.ExchangeRate().Rate().String())
+	})
+
+	t.Run("rate still locked cannot be re-quoted", func(t *testing.T) {
+		testInvoice := createTestInvoice()
+
+		_, err := testInvoice.Requote(newTestRate(t, "50000"), maxSlippage)
+		require.ErrorIs(t, err, invoice.ErrCannotRequote)
+	})
+
+	t.Run("invoice with a payment cannot be re-quoted", func(t *testing.T) {
+		testInvoice := createRequotableInvoice(t)
+		paid, _ := shared.NewMoneyWithCrypto("0.001", shared.CryptoCurrencyBTC)
+		require.NoError(t, testInvoice.RecordPayment(paid))
+
+		_, err := testInvoice.Requote(newTestRate(t, "0.00002"), maxSlippage)
+		require.ErrorIs(t, err, invoice.ErrCannotRequote)
+	})
+
+	t.Run("rate for another currency is rejected", func(t *testing.T) {
+		testInvoice := createRequotableInvoice(t)
+		ethRate, err := shared.NewExchangeRate("0.0003", shared.CurrencyUSD, shared.CryptoCurrencyETH, "fresh-source",
+			30*time.Minute)
+		require.NoError(t, err)
+
+		_, err = testInvoice.Requote(ethRate, maxSlippage)
+		require.ErrorIs(t, err, invoice.ErrCurrencyMismatch)
+	})
+}
@@ -0,0 +1,111 @@
+package invoice
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// RequotePolicy configures the background re-quoting of expired exchange rates.
+type RequotePolicy struct {
+	// Interval is how often invoices are checked for expired rates.
+	Interval time.Duration
+	// MaxSlippage is the largest relative rate change accepted without merchant action, e.g. 0.02 for 2%.
+	MaxSlippage decimal.Decimal
+	// StalePayments is how payments arriving after the invoice's rate expired are handled.
+	StalePayments StaleRateAction
+}
+
+// DefaultRequotePolicy checks for expired rates every minute, accepts moves of up to 2%, and accepts payments
+// arriving after the rate expired if the rate moved within that bound.
+func DefaultRequotePolicy() RequotePolicy {
+	return RequotePolicy{
+		Interval:      time.Minute,
+		MaxSlippage:   decimal.RequireFromString("0.02"),
+		StalePayments: StaleRateAccept,
+	}
+}
+
+// Requoter refreshes the exchange rate of unpaid invoices in the background once their locked rate expires.
+type Requoter struct {
+	service InvoiceService
+	policy  RequotePolicy
+	logger  *zap.Logger
+}
+
+// NewRequoter creates a new background requoter.
+func NewRequoter(service InvoiceService, policy RequotePolicy, logger *zap.Logger) *Requoter {
+	if policy.Interval <= 0 {
+		policy.Interval = DefaultRequotePolicy().Interval
+	}
+	return &Requoter{
+		service: service,
+		policy:  policy,
+		logger:  logger,
+	}
+}
+
+// Run re-quotes invoices with expired rates on every interval until the context is cancelled.
+func (r *Requoter) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.policy.Interval)
+	defer ticker.Stop()
+
+	for {
+		shared.Beat(ctx)
+		r.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick performs one re-quoting round.
+func (r *Requoter) tick(ctx context.Context) {
+	requoted, err := r.service.RequoteExpiredRates(ctx, r.policy.MaxSlippage)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.Error("Failed to re-quote invoices with expired rates", zap.Error(err))
+		}
+		return
+	}
+	if requoted > 0 {
+		r.logger.Info("Re-quoted invoices with expired rates", zap.Int("count", requoted))
+	}
+}
+
+// RegisterRequoter runs the requoter for the lifetime of the application.
+func RegisterRequoter(
+	lc fx.Lifecycle,
+	requoter *Requoter,
+	workers shared.WorkerSupervisor,
+	logger *zap.Logger,
+) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			logger.Info("Starting invoice requoter")
+			go func() {
+				defer close(done)
+				workers.Supervise(ctx, "invoice_requoter", requoter.policy.Interval, requoter.Run)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
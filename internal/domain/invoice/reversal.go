@@ -0,0 +1,261 @@
+package invoice
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// PaymentReversalReason is the reason recorded when a reorganization removes a confirmed payment.
+const PaymentReversalReason = "Payment transaction removed from the chain by a reorganization after it was confirmed"
+
+// PaymentReversal records a confirmed payment taken back from the invoice because a chain reorganization
+// deeper than its required confirmations removed the transaction.
+type PaymentReversal struct {
+	paymentID       string
+	transactionHash string
+	amount          *shared.Money
+	previousStatus  InvoiceStatus
+	status          InvoiceStatus
+	reason          string
+	reversedAt      time.Time
+}
+
+// NewPaymentReversal creates a new payment reversal record.
+func NewPaymentReversal(
+	paymentID, transactionHash string,
+	amount *shared.Money,
+	previousStatus, status InvoiceStatus,
+	reason string,
+	reversedAt time.Time,
+) (*PaymentReversal, error) {
+	if paymentID == "" {
+		return nil, shared.NewFieldError("payment_id", "payment ID is required")
+	}
+	if amount == nil || !amount.Amount().IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+	if !previousStatus.IsValid() || !status.IsValid() {
+		return nil, ErrInvalidStatus
+	}
+
+	return &PaymentReversal{
+		paymentID:       paymentID,
+		transactionHash: transactionHash,
+		amount:          amount,
+		previousStatus:  previousStatus,
+		status:          status,
+		reason:          reason,
+		reversedAt:      reversedAt,
+	}, nil
+}
+
+// PaymentID returns the payment that was reversed.
+func (r *PaymentReversal) PaymentID() string {
+	return r.paymentID
+}
+
+// TransactionHash returns the transaction the reorganization removed.
+func (r *PaymentReversal) TransactionHash() string {
+	return r.transactionHash
+}
+
+// Amount returns the amount taken back from the amount paid.
+func (r *PaymentReversal) Amount() *shared.Money {
+	return r.amount
+}
+
+// PreviousStatus returns the invoice status before the reversal.
+func (r *PaymentReversal) PreviousStatus() InvoiceStatus {
+	return r.previousStatus
+}
+
+// Status returns the invoice status the reversal moved it to.
+func (r *PaymentReversal) Status() InvoiceStatus {
+	return r.status
+}
+
+// Reason explains why the payment was reversed.
+func (r *PaymentReversal) Reason() string {
+	return r.reason
+}
+
+// ReversedAt returns when the payment was reversed.
+func (r *PaymentReversal) ReversedAt() time.Time {
+	return r.reversedAt
+}
+
+// PaymentReversals returns the payments reversed on the invoice, oldest first.
+func (i *Invoice) PaymentReversals() []*PaymentReversal {
+	return i.reversals
+}
+
+// SetPaymentReversals sets the payment reversals (for repository restoration).
+func (i *Invoice) SetPaymentReversals(reversals []*PaymentReversal) {
+	i.reversals = reversals
+}
+
+// PaymentReversal returns the reversal of a payment, or nil if the payment was not reversed.
+func (i *Invoice) PaymentReversal(paymentID string) *PaymentReversal {
+	for _, reversal := range i.reversals {
+		if reversal.PaymentID() == paymentID {
+			return reversal
+		}
+	}
+	return nil
+}
+
+// CanReversePayment checks that the invoice has been credited with payments that can be taken back.
+// Refunded invoices have returned what they received, so their payments are not reversed.
+func CanReversePayment(invoice *Invoice) error {
+	switch invoice.Status() {
+	case StatusPartial, StatusConfirming, StatusPaid, StatusPartiallyRefunded, StatusUnderpaidClosed:
+		return nil
+	default:
+		return ErrCannotReversePayment
+	}
+}
+
+// ReversePayment takes a confirmed payment removed by a reorganization back from the amount paid and reopens
+// the invoice: partial if other payments remain, pending otherwise. A payment already reversed returns its
+// existing reversal.
+func (i *Invoice) ReversePayment(
+	paymentID, transactionHash string,
+	amount *shared.Money,
+	now time.Time,
+) (*PaymentReversal, error) {
+	if existing := i.PaymentReversal(paymentID); existing != nil {
+		return existing, nil
+	}
+	if err := CanReversePayment(i); err != nil {
+		return nil, err
+	}
+	if amount == nil {
+		return nil, ErrInvalidAmount
+	}
+	if amount.Currency() != i.cryptoCurrency.String() {
+		return nil, ErrCurrencyMismatch
+	}
+
+	paid := i.amountPaid
+	if paid == nil {
+		var err error
+		if paid, err = i.LockedCryptoAmount(); err != nil {
+			return nil, err
+		}
+	}
+	remaining, err := paid.Subtract(amount)
+	if errors.Is(err, shared.ErrNegativeAmount) {
+		remaining, err = shared.NewMoneyWithCrypto("0", i.cryptoCurrency)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	target := StatusPending
+	if remaining.Amount().IsPositive() {
+		target = StatusPartial
+	}
+	reversal, err := NewPaymentReversal(
+		paymentID, transactionHash, amount, i.status, target, PaymentReversalReason, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.status != target {
+		if err := NewInvoiceFSM(i).TransitionTo(target); err != nil {
+			return nil, err
+		}
+	}
+	i.amountPaid = remaining
+	i.paidAt = nil
+	i.reversals = append(i.reversals, reversal)
+	i.updatedAt = now
+	return reversal, nil
+}
+
+// ReversePayment takes back a confirmed payment of the invoice that a deep reorganization removed.
+func (s *InvoiceServiceImpl) ReversePayment(
+	ctx context.Context,
+	invoiceID string,
+	paymentTx *payment.Payment,
+) (*PaymentReversal, error) {
+	if invoiceID == "" {
+		return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+	}
+	if paymentTx == nil {
+		return nil, shared.NewFieldError("payment", "payment cannot be nil")
+	}
+
+	invoice, err := s.repository.FindByID(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if existing := invoice.PaymentReversal(string(paymentTx.ID())); existing != nil {
+		return existing, nil
+	}
+
+	transactionHash := ""
+	if paymentTx.TransactionHash() != nil {
+		transactionHash = paymentTx.TransactionHash().String()
+	}
+	reversal, err := invoice.ReversePayment(
+		string(paymentTx.ID()), transactionHash, paymentTx.Amount().Amount(), shared.Now().UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(ctx, invoice); err != nil {
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.Warn("Payment reversed by a chain reorganization",
+			zap.String("invoice_id", invoice.ID()),
+			zap.String("payment_id", reversal.PaymentID()),
+			zap.String("amount", reversal.Amount().String()),
+			zap.String("status", reversal.Status().String()))
+	}
+	s.publishPaymentReversed(ctx, invoice, reversal)
+	return reversal, nil
+}
+
+// publishPaymentReversed publishes the event announcing that a payment of an invoice was reversed.
+func (s *InvoiceServiceImpl) publishPaymentReversed(
+	ctx context.Context,
+	invoice *Invoice,
+	reversal *PaymentReversal,
+) {
+	if s.eventBus == nil {
+		return
+	}
+
+	eventData := shared.InvoicePaymentReversedEvent{
+		InvoiceEvent:    createInvoiceEventData(invoice),
+		PaymentID:       reversal.PaymentID(),
+		TransactionHash: reversal.TransactionHash(),
+		ReversedAmount:  reversal.Amount().Amount().String(),
+		PreviousStatus:  reversal.PreviousStatus().String(),
+		Reason:          reversal.Reason(),
+	}
+	if paid := invoice.AmountPaid(); paid != nil {
+		eventData.AmountPaid = paid.Amount().String()
+	}
+	event := shared.NewInvoicePaymentReversedEvent(eventData)
+	if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+		// Log error but don't fail the operation
+		if s.logger != nil {
+			s.logger.Error("Failed to publish domain event",
+				zap.String("event_type", shared.EventTypeInvoiceReversal),
+				zap.String("aggregate_id", invoice.ID()),
+				zap.Error(err),
+			)
+		}
+	}
+}
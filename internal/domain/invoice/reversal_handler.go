@@ -0,0 +1,76 @@
+package invoice
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// PaymentReversalHandler takes back the payments of invoices whose transactions a deep reorganization removed.
+type PaymentReversalHandler struct {
+	service  InvoiceService
+	payments payment.Repository
+	logger   *zap.Logger
+}
+
+// NewPaymentReversalHandler creates a new payment reversal handler.
+func NewPaymentReversalHandler(
+	service InvoiceService,
+	payments payment.Repository,
+	logger *zap.Logger,
+) *PaymentReversalHandler {
+	return &PaymentReversalHandler{
+		service:  service,
+		payments: payments,
+		logger:   logger,
+	}
+}
+
+// RegisterPaymentReversalHandler subscribes a payment reversal handler to payment events.
+func RegisterPaymentReversalHandler(
+	registry shared.EventHandlerRegistry,
+	service InvoiceService,
+	payments payment.Repository,
+	logger *zap.Logger,
+) {
+	registry.RegisterHandler(NewPaymentReversalHandler(service, payments, logger))
+}
+
+// EventTypes returns the payment events that may report a payment as reversed.
+func (h *PaymentReversalHandler) EventTypes() []string {
+	return []string{shared.EventTypePaymentStatusChanged}
+}
+
+// HandleEvent reverses the payment an event reports as reversed on its invoice. Events for payments in other
+// states are ignored, as are reversals of invoices that were never credited with payments or refunded them.
+func (h *PaymentReversalHandler) HandleEvent(ctx context.Context, event *shared.BaseDomainEvent) error {
+	var data shared.PaymentStatusChangedEvent
+	if err := shared.DecodeEventData(event, &data); err != nil {
+		return fmt.Errorf("failed to decode payment event: %w", err)
+	}
+	if data.Status != payment.StatusReversed.String() || data.InvoiceID == "" {
+		return nil
+	}
+	invoiceID := data.InvoiceID
+
+	p, err := h.payments.FindByID(ctx, event.AggregateID)
+	if err != nil {
+		return fmt.Errorf("failed to load reversed payment: %w", err)
+	}
+
+	_, err = h.service.ReversePayment(ctx, invoiceID, p)
+	if errors.Is(err, ErrCannotReversePayment) {
+		h.logger.Warn("Payment reversed on an invoice no longer holding it",
+			zap.String("invoice_id", invoiceID),
+			zap.String("payment_id", event.AggregateID))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reverse payment: %w", err)
+	}
+	return nil
+}
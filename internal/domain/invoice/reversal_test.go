@@ -0,0 +1,93 @@
+package invoice_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestInvoice_ReversePayment(t *testing.T) {
+	now := time.Now().UTC()
+	btc := func(amount string) *shared.Money {
+		money, err := shared.NewMoneyWithCrypto(amount, shared.CryptoCurrencyBTC)
+		require.NoError(t, err)
+		return money
+	}
+
+	t.Run("only payment reopens the invoice as pending", func(t *testing.T) {
+		inv := createPaidTestInvoice(t, "0.002")
+
+		reversal, err := inv.ReversePayment("payment-1", "0xabc", btc("0.002"), now)
+		require.NoError(t, err)
+		require.Equal(t, invoice.StatusPending, inv.Status())
+		require.Equal(t, invoice.StatusPaid, reversal.PreviousStatus())
+		require.True(t, inv.AmountPaid().Amount().IsZero())
+		require.Nil(t, inv.PaidAt())
+		require.Len(t, inv.PaymentReversals(), 1)
+
+		again, err := inv.ReversePayment("payment-1", "0xabc", btc("0.002"), now)
+		require.NoError(t, err)
+		require.Same(t, reversal, again)
+		require.Len(t, inv.PaymentReversals(), 1)
+	})
+
+	t.Run("remaining payments leave the invoice partial", func(t *testing.T) {
+		inv := createPaidTestInvoice(t, "0.002")
+
+		reversal, err := inv.ReversePayment("payment-1", "0xabc", btc("0.0005"), now)
+		require.NoError(t, err)
+		require.Equal(t, invoice.StatusPartial, inv.Status())
+		require.Equal(t, invoice.StatusPartial, reversal.Status())
+		require.Equal(t, "0.0015", inv.AmountPaid().Amount().String())
+	})
+
+	t.Run("refunded invoices are not reversed", func(t *testing.T) {
+		inv := createPaidTestInvoice(t, "0.002")
+		require.NoError(t, inv.AddRefund(newTestRefund(t, "refund-1", "0.002")))
+		require.NoError(t, invoice.NewInvoiceFSM(inv).TransitionTo(invoice.StatusRefunded))
+
+		_, err := inv.ReversePayment("payment-1", "0xabc", btc("0.002"), now)
+		require.ErrorIs(t, err, invoice.ErrCannotReversePayment)
+	})
+
+	t.Run("currency must match", func(t *testing.T) {
+		inv := createPaidTestInvoice(t, "0.002")
+		usdt, err := shared.NewMoneyWithCrypto("0.002", shared.CryptoCurrencyUSDT)
+		require.NoError(t, err)
+
+		_, err = inv.ReversePayment("payment-1", "0xabc", usdt, now)
+		require.ErrorIs(t, err, invoice.ErrCurrencyMismatch)
+	})
+}
+
+func TestInvoiceService_ReversePayment(t *testing.T) {
+	ctx := context.Background()
+	inv := createPaidTestInvoice(t, "0.002")
+	events := &recordingEventBus{}
+	repository := &stubRepository{invoices: map[string]*invoice.Invoice{inv.ID(): inv}}
+	service := invoice.NewInvoiceService(repository, events, nil, nil, invoice.DefaultRequotePolicy(), nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+	reversed := newRefundTestPayment(t, "payment-1", testSenderAddress, "0.002", payment.StatusReversed)
+
+	reversal, err := service.ReversePayment(ctx, inv.ID(), reversed)
+	require.NoError(t, err)
+	require.Equal(t, "payment-1", reversal.PaymentID())
+	require.Equal(t, invoice.StatusPending, inv.Status())
+
+	require.Len(t, events.events, 1)
+	require.Equal(t, shared.EventTypeInvoiceReversal, events.events[0].EventType)
+	data := events.events[0].EventData.(shared.InvoicePaymentReversedEvent)
+	require.Equal(t, "0.002", data.ReversedAmount)
+	require.Equal(t, "paid", data.PreviousStatus)
+	require.Equal(t, "pending", data.Status)
+
+	_, err = service.ReversePayment(ctx, inv.ID(), reversed)
+	require.NoError(t, err)
+	require.Len(t, events.events, 1)
+}
@@ -0,0 +1,203 @@
+package invoice
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// MaxSplitShares is the number of beneficiaries a settlement can be split between.
+	MaxSplitShares = 10
+
+	// splitFractionPrecision is the number of decimal places kept when a fixed share is turned into a fraction.
+	splitFractionPrecision = 16
+)
+
+// maxSplitPercentage is the percentage of an invoice all shares together must stay below.
+var maxSplitPercentage = decimal.NewFromInt(100)
+
+// SplitShareType represents how a beneficiary's share of a settlement is expressed.
+type SplitShareType string
+
+const (
+	// SplitShareTypePercentage - A percentage of what the invoice settles for
+	SplitShareTypePercentage SplitShareType = "percentage"
+	// SplitShareTypeFixed - A fixed amount in the invoice currency
+	SplitShareTypeFixed SplitShareType = "fixed"
+)
+
+// IsValid returns true if the split share type is valid.
+func (t SplitShareType) IsValid() bool {
+	switch t {
+	case SplitShareTypePercentage, SplitShareTypeFixed:
+		return true
+	default:
+		return false
+	}
+}
+
+// SplitShare is the part of an invoice's settlement a beneficiary merchant receives.
+type SplitShare struct {
+	beneficiaryID string
+	shareType     SplitShareType
+	value         decimal.Decimal
+}
+
+// NewSplitShare creates a beneficiary's share of a settlement.
+// Fixed values are in the invoice currency; percentage values are above 0 and below 100.
+func NewSplitShare(beneficiaryID string, shareType SplitShareType, value string) (*SplitShare, error) {
+	if beneficiaryID == "" {
+		return nil, fmt.Errorf("%w: beneficiary is required", ErrInvalidSettlementSplit)
+	}
+	if !shareType.IsValid() {
+		return nil, fmt.Errorf("%w: unknown share type %q", ErrInvalidSettlementSplit, shareType)
+	}
+
+	parsed, err := decimal.NewFromString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid share value format", ErrInvalidSettlementSplit)
+	}
+	if !parsed.IsPositive() {
+		return nil, fmt.Errorf("%w: share value must be positive", ErrInvalidSettlementSplit)
+	}
+	if shareType == SplitShareTypePercentage && !parsed.LessThan(maxSplitPercentage) {
+		return nil, fmt.Errorf("%w: share percentage must be below 100", ErrInvalidSettlementSplit)
+	}
+
+	return &SplitShare{
+		beneficiaryID: beneficiaryID,
+		shareType:     shareType,
+		value:         parsed,
+	}, nil
+}
+
+// BeneficiaryID returns the merchant receiving the share.
+func (s *SplitShare) BeneficiaryID() string {
+	return s.beneficiaryID
+}
+
+// Type returns how the share is expressed.
+func (s *SplitShare) Type() SplitShareType {
+	return s.shareType
+}
+
+// Value returns the fixed amount or percentage.
+func (s *SplitShare) Value() decimal.Decimal {
+	return s.value
+}
+
+// SettlementSplit divides what an invoice settles for between the invoice's merchant and beneficiary merchants,
+// as marketplaces do with their sellers. Each beneficiary receives its share in a settlement of its own; the
+// invoice's merchant keeps the rest.
+type SettlementSplit struct {
+	shares []*SplitShare
+}
+
+// NewSettlementSplit creates a settlement split between distinct beneficiaries.
+func NewSettlementSplit(shares []*SplitShare) (*SettlementSplit, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("%w: at least one share is required", ErrInvalidSettlementSplit)
+	}
+	if len(shares) > MaxSplitShares {
+		return nil, fmt.Errorf("%w: at most %d shares are allowed", ErrInvalidSettlementSplit, MaxSplitShares)
+	}
+
+	beneficiaries := make(map[string]bool, len(shares))
+	for _, share := range shares {
+		if share == nil {
+			return nil, fmt.Errorf("%w: share cannot be empty", ErrInvalidSettlementSplit)
+		}
+		if beneficiaries[share.BeneficiaryID()] {
+			return nil, fmt.Errorf("%w: beneficiary %s has more than one share",
+				ErrInvalidSettlementSplit, share.BeneficiaryID())
+		}
+		beneficiaries[share.BeneficiaryID()] = true
+	}
+
+	return &SettlementSplit{shares: shares}, nil
+}
+
+// Shares returns the beneficiaries' shares, in the order the merchant gave them.
+func (s *SettlementSplit) Shares() []*SplitShare {
+	return s.shares
+}
+
+// Fractions returns the part of the invoice each share stands for, in the order of Shares. A fixed share is
+// its amount over the invoice total. The shares together must leave part of the invoice to the merchant.
+func (s *SettlementSplit) Fractions(total *shared.Money) ([]decimal.Decimal, error) {
+	if total == nil || !total.Amount().IsPositive() {
+		return nil, fmt.Errorf("%w: splits require a positive invoice total", ErrInvalidSettlementSplit)
+	}
+
+	fractions := make([]decimal.Decimal, len(s.shares))
+	sum := decimal.Zero
+	for i, share := range s.shares {
+		if share.Type() == SplitShareTypePercentage {
+			fractions[i] = share.Value().Div(maxSplitPercentage)
+		} else {
+			fractions[i] = share.Value().DivRound(total.Amount(), splitFractionPrecision)
+		}
+		sum = sum.Add(fractions[i])
+	}
+	if !sum.LessThan(decimal.NewFromInt(1)) {
+		return nil, fmt.Errorf("%w: shares add up to %s%% of the invoice, leaving nothing to the merchant",
+			ErrInvalidSettlementSplit, sum.Mul(maxSplitPercentage).Round(2).String())
+	}
+	return fractions, nil
+}
+
+// SettlementSplit returns how the invoice's settlement is split between beneficiaries, or nil if the merchant
+// receives all of it.
+func (i *Invoice) SettlementSplit() *SettlementSplit {
+	return i.settlementSplit
+}
+
+// SetSettlementSplit sets the settlement split of the invoice (for creation and repository restoration).
+func (i *Invoice) SetSettlementSplit(split *SettlementSplit) {
+	i.settlementSplit = split
+}
+
+// MerchantBeneficiaries tells which merchants can receive shares of other merchants' settlements.
+type MerchantBeneficiaries interface {
+	// CanReceiveSplits reports whether the merchant exists and is active, so it can be a beneficiary.
+	CanReceiveSplits(ctx context.Context, merchantID string) (bool, error)
+}
+
+// validateSettlementSplit checks that a merchant's invoice totalling total can be split as requested: its
+// beneficiaries are other merchants able to receive shares, and the shares leave part of the invoice to the
+// merchant.
+func (s *InvoiceServiceImpl) validateSettlementSplit(
+	ctx context.Context,
+	merchantID string,
+	split *SettlementSplit,
+	total *shared.Money,
+) error {
+	if split == nil {
+		return nil
+	}
+	if _, err := split.Fractions(total); err != nil {
+		return invalidRequest(err)
+	}
+
+	for _, share := range split.Shares() {
+		if share.BeneficiaryID() == merchantID {
+			return invalidRequest(fmt.Errorf("%w: the invoice's merchant cannot be a beneficiary",
+				ErrInvalidSettlementSplit))
+		}
+		if s.beneficiaries == nil {
+			continue
+		}
+		ok, err := s.beneficiaries.CanReceiveSplits(ctx, share.BeneficiaryID())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return invalidRequest(fmt.Errorf("%w: beneficiary %s is not an active merchant",
+				ErrInvalidSettlementSplit, share.BeneficiaryID()))
+		}
+	}
+	return nil
+}
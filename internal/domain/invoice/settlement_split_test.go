@@ -0,0 +1,85 @@
+package invoice_test
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettlementSplit(t *testing.T) {
+	share := func(beneficiaryID string, shareType invoice.SplitShareType, value string) *invoice.SplitShare {
+		t.Helper()
+		s, err := invoice.NewSplitShare(beneficiaryID, shareType, value)
+		require.NoError(t, err)
+		return s
+	}
+	total, err := shared.NewMoney("200.00", shared.CurrencyUSD)
+	require.NoError(t, err)
+
+	t.Run("rejects invalid shares", func(t *testing.T) {
+		for _, tc := range []struct {
+			beneficiaryID string
+			shareType     invoice.SplitShareType
+			value         string
+		}{
+			{"", invoice.SplitShareTypePercentage, "10"},
+			{"seller-1", "ratio", "10"},
+			{"seller-1", invoice.SplitShareTypeFixed, "ten"},
+			{"seller-1", invoice.SplitShareTypeFixed, "0"},
+			{"seller-1", invoice.SplitShareTypePercentage, "-5"},
+			{"seller-1", invoice.SplitShareTypePercentage, "100"},
+		} {
+			_, err := invoice.NewSplitShare(tc.beneficiaryID, tc.shareType, tc.value)
+			require.ErrorIs(t, err, invoice.ErrInvalidSettlementSplit, tc.value)
+		}
+	})
+
+	t.Run("requires distinct beneficiaries within the limit", func(t *testing.T) {
+		_, err := invoice.NewSettlementSplit(nil)
+		require.ErrorIs(t, err, invoice.ErrInvalidSettlementSplit)
+
+		_, err = invoice.NewSettlementSplit([]*invoice.SplitShare{
+			share("seller-1", invoice.SplitShareTypePercentage, "10"),
+			share("seller-1", invoice.SplitShareTypeFixed, "5"),
+		})
+		require.ErrorIs(t, err, invoice.ErrInvalidSettlementSplit)
+
+		shares := make([]*invoice.SplitShare, invoice.MaxSplitShares+1)
+		for i := range shares {
+			shares[i] = share(string(rune('a'+i)), invoice.SplitShareTypePercentage, "1")
+		}
+		_, err = invoice.NewSettlementSplit(shares)
+		require.ErrorIs(t, err, invoice.ErrInvalidSettlementSplit)
+	})
+
+	t.Run("fractions of the invoice total", func(t *testing.T) {
+		split, err := invoice.NewSettlementSplit([]*invoice.SplitShare{
+			share("seller-1", invoice.SplitShareTypePercentage, "80"),
+			share("courier-1", invoice.SplitShareTypeFixed, "10"),
+		})
+		require.NoError(t, err)
+
+		fractions, err := split.Fractions(total)
+		require.NoError(t, err)
+		require.Len(t, fractions, 2)
+		require.Equal(t, "0.8", fractions[0].String())
+		require.Equal(t, "0.05", fractions[1].String())
+
+		_, err = split.Fractions(nil)
+		require.ErrorIs(t, err, invoice.ErrInvalidSettlementSplit)
+	})
+
+	t.Run("shares leave part of the invoice to the merchant", func(t *testing.T) {
+		split, err := invoice.NewSettlementSplit([]*invoice.SplitShare{
+			share("seller-1", invoice.SplitShareTypePercentage, "90"),
+			share("courier-1", invoice.SplitShareTypeFixed, "20"),
+		})
+		require.NoError(t, err)
+
+		_, err = split.Fractions(total)
+		require.ErrorIs(t, err, invoice.ErrInvalidSettlementSplit)
+		require.ErrorContains(t, err, "100%")
+	})
+}
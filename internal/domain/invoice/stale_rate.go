@@ -0,0 +1,225 @@
+package invoice
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// StaleRateAction is how a payment arriving after the invoice's exchange rate expired is handled.
+type StaleRateAction string
+
+const (
+	// StaleRateAccept - Judge the payment against the locked amount if the rate has since moved by no more
+	// than the maximum slippage, and route it to review otherwise
+	StaleRateAccept StaleRateAction = "accept"
+	// StaleRateReview - Route the payment to review without crediting it
+	StaleRateReview StaleRateAction = "review"
+	// StaleRateRequote - Lock the current rate on the invoice and judge the payment against the new amount
+	StaleRateRequote StaleRateAction = "requote"
+)
+
+// IsValid returns true if the stale rate action is valid.
+func (a StaleRateAction) IsValid() bool {
+	switch a {
+	case StaleRateAccept, StaleRateReview, StaleRateRequote:
+		return true
+	default:
+		return false
+	}
+}
+
+// StaleRateCheck records how a payment that arrived after the invoice's exchange rate expired was handled.
+type StaleRateCheck struct {
+	paymentID   string
+	action      StaleRateAction
+	lockedRate  decimal.Decimal
+	currentRate decimal.Decimal
+	reason      string
+	checkedAt   time.Time
+}
+
+// NewStaleRateCheck creates a new stale rate check record. The current rate is zero if it could not be quoted.
+func NewStaleRateCheck(
+	paymentID string,
+	action StaleRateAction,
+	lockedRate, currentRate decimal.Decimal,
+	reason string,
+	checkedAt time.Time,
+) (*StaleRateCheck, error) {
+	if paymentID == "" {
+		return nil, shared.NewFieldError("payment_id", "payment ID is required")
+	}
+	if !action.IsValid() {
+		return nil, fmt.Errorf("invalid stale rate action: %s", action)
+	}
+	if !lockedRate.IsPositive() || currentRate.IsNegative() {
+		return nil, ErrInvalidExchangeRate
+	}
+
+	return &StaleRateCheck{
+		paymentID:   paymentID,
+		action:      action,
+		lockedRate:  lockedRate,
+		currentRate: currentRate,
+		reason:      reason,
+		checkedAt:   checkedAt,
+	}, nil
+}
+
+// PaymentID returns the payment that was checked.
+func (c *StaleRateCheck) PaymentID() string {
+	return c.paymentID
+}
+
+// Action returns the branch taken for the payment.
+func (c *StaleRateCheck) Action() StaleRateAction {
+	return c.action
+}
+
+// LockedRate returns the expired rate the payment arrived under.
+func (c *StaleRateCheck) LockedRate() decimal.Decimal {
+	return c.lockedRate
+}
+
+// CurrentRate returns the rate quoted when the payment arrived, or zero if none could be quoted.
+func (c *StaleRateCheck) CurrentRate() decimal.Decimal {
+	return c.currentRate
+}
+
+// Slippage returns the relative change from the locked rate to the current one, or zero if no rate was quoted.
+func (c *StaleRateCheck) Slippage() decimal.Decimal {
+	if c.currentRate.IsZero() {
+		return decimal.Zero
+	}
+	return rateSlippage(c.lockedRate, c.currentRate)
+}
+
+// Reason explains why the branch was taken.
+func (c *StaleRateCheck) Reason() string {
+	return c.reason
+}
+
+// CheckedAt returns when the payment was checked.
+func (c *StaleRateCheck) CheckedAt() time.Time {
+	return c.checkedAt
+}
+
+// StaleRateChecks returns the stale rate checks of the invoice, oldest first.
+func (i *Invoice) StaleRateChecks() []*StaleRateCheck {
+	return i.staleRateChecks
+}
+
+// SetStaleRateChecks sets the stale rate checks (for repository restoration).
+func (i *Invoice) SetStaleRateChecks(checks []*StaleRateCheck) {
+	i.staleRateChecks = checks
+}
+
+// HasStaleRate returns true if the invoice still awaits payment but the exchange rate it was quoted at has
+// expired.
+func (i *Invoice) HasStaleRate() bool {
+	if i.exchangeRate == nil || !i.exchangeRate.IsExpired() {
+		return false
+	}
+	return i.status == StatusCreated || i.status == StatusPending || i.status == StatusPartial
+}
+
+// recordStaleRateCheck appends a stale rate check to the invoice.
+func (i *Invoice) recordStaleRateCheck(check *StaleRateCheck) {
+	i.staleRateChecks = append(i.staleRateChecks, check)
+	i.updatedAt = check.CheckedAt()
+}
+
+// guardStaleRate applies the configured stale rate action to a payment arriving after the invoice's exchange
+// rate expired, and records the branch taken on the invoice. The accept branch falls back to review when the
+// rate moved beyond the maximum slippage, and both it and the requote branch fall back to review when no
+// current rate can be quoted. It returns the check, and the re-quote when the current rate was locked.
+func (s *InvoiceServiceImpl) guardStaleRate(
+	ctx context.Context,
+	invoice *Invoice,
+	paymentTx *payment.Payment,
+) (*StaleRateCheck, *Requote, error) {
+	action := s.requotes.StalePayments
+	if !action.IsValid() {
+		action = DefaultRequotePolicy().StalePayments
+	}
+	maxSlippage := s.requotes.MaxSlippage
+	lockedRate := invoice.ExchangeRate().Rate()
+	currentRate := decimal.Zero
+	reason := "stale rate payments are reviewed"
+	var rate *shared.ExchangeRate
+	var requote *Requote
+
+	if action != StaleRateReview {
+		var err error
+		rate, err = s.getExchangeRate(ctx, invoice.ExchangeRate().FromCurrency(), invoice.CryptoCurrency())
+		if err != nil {
+			action, reason = StaleRateReview, "current rate unavailable"
+		} else {
+			currentRate = rate.Rate()
+		}
+	}
+
+	switch action {
+	case StaleRateRequote:
+		var err error
+		if requote, err = invoice.relock(rate); err != nil {
+			return nil, nil, err
+		}
+		reason = "current rate locked"
+	case StaleRateAccept:
+		slippage := rateSlippage(lockedRate, currentRate)
+		if slippage.GreaterThan(maxSlippage) {
+			action = StaleRateReview
+			reason = fmt.Sprintf("rate moved %s%%, beyond %s%%", percent(slippage), percent(maxSlippage))
+		} else {
+			reason = fmt.Sprintf("rate moved %s%%, within %s%%", percent(slippage), percent(maxSlippage))
+		}
+	}
+
+	check, err := NewStaleRateCheck(string(paymentTx.ID()), action, lockedRate, currentRate, reason,
+		shared.Now().UTC())
+	if err != nil {
+		return nil, nil, err
+	}
+	invoice.recordStaleRateCheck(check)
+
+	if s.logger != nil {
+		s.logger.Info("Payment arrived after exchange rate expired",
+			zap.String("invoice_id", invoice.ID()),
+			zap.String("payment_id", check.PaymentID()),
+			zap.String("action", string(action)),
+			zap.String("reason", reason),
+		)
+	}
+	return check, requote, nil
+}
+
+// queueStaleRatePayment opens a review for a payment that arrived after the invoice's exchange rate expired.
+func (s *InvoiceServiceImpl) queueStaleRatePayment(
+	ctx context.Context,
+	invoice *Invoice,
+	paymentTx *payment.Payment,
+	check *StaleRateCheck,
+) {
+	if s.reviewQueue == nil {
+		return
+	}
+
+	requiredAmount, err := invoice.LockedCryptoAmount()
+	if err == nil {
+		err = s.reviewQueue.EnqueueStaleRate(ctx, invoice, paymentTx, requiredAmount, check.Reason())
+	}
+	if err != nil && s.logger != nil {
+		s.logger.Error("Failed to queue stale rate payment for review",
+			zap.String("invoice_id", invoice.ID()),
+			zap.String("payment_id", string(paymentTx.ID())),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,174 @@
+package invoice_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubRepository keeps invoices in memory; only the methods used by payment processing are implemented.
+type stubRepository struct {
+	invoice.Repository
+	invoices map[string]*invoice.Invoice
+}
+
+func (r *stubRepository) FindByID(_ context.Context, id string) (*invoice.Invoice, error) {
+	inv, ok := r.invoices[id]
+	if !ok {
+		return nil, invoice.ErrInvoiceNotFound
+	}
+	return inv, nil
+}
+
+func (r *stubRepository) Update(_ context.Context, inv *invoice.Invoice) error {
+	r.invoices[inv.ID()] = inv
+	return nil
+}
+
+// stubRates quotes a fixed USD to BTC rate, or fails when no rate is set.
+type stubRates string
+
+func (r stubRates) GetRate(
+	_ context.Context,
+	from shared.Currency,
+	to shared.CryptoCurrency,
+) (*shared.ExchangeRate, error) {
+	if r == "" {
+		return nil, errors.New("rate provider unavailable")
+	}
+	return shared.NewExchangeRate(string(r), from, to, "fresh-source", 30*time.Minute)
+}
+
+// stubReviewQueue records the payments queued for review.
+type stubReviewQueue struct {
+	staleRate []string
+}
+
+func (q *stubReviewQueue) EnqueueUnderpayment(
+	context.Context, *invoice.Invoice, *payment.Payment, *shared.Money,
+) error {
+	return nil
+}
+
+func (q *stubReviewQueue) EnqueueStaleRate(
+	_ context.Context, _ *invoice.Invoice, p *payment.Payment, _ *shared.Money, _ string,
+) error {
+	q.staleRate = append(q.staleRate, string(p.ID()))
+	return nil
+}
+
+func newStaleRateTestPayment(t *testing.T, amount string) *payment.Payment {
+	money, err := shared.NewMoneyWithCrypto(amount, shared.CryptoCurrencyBTC)
+	require.NoError(t, err)
+	paymentAmount, err := payment.NewPaymentAmount(money, shared.CryptoCurrencyBTC)
+	require.NoError(t, err)
+	toAddress, err := payment.NewPaymentAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", shared.NetworkBitcoin)
+	require.NoError(t, err)
+	transactionHash, err := payment.NewTransactionHash(
+		"0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	require.NoError(t, err)
+
+	p, err := payment.NewPayment("payment-1", "test-invoice-id", paymentAmount,
+		"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", toAddress, transactionHash, 1)
+	require.NoError(t, err)
+	return p
+}
+
+func TestInvoiceService_StaleRatePayments(t *testing.T) {
+	ctx := context.Background()
+
+	newService := func(action invoice.StaleRateAction, rate string) (invoice.InvoiceService, *stubReviewQueue) {
+		inv := createRequotableInvoice(t)
+		require.NoError(t, invoice.NewInvoiceFSM(inv).Event(ctx, "view"))
+
+		policy := invoice.DefaultRequotePolicy()
+		policy.StalePayments = action
+		queue := &stubReviewQueue{}
+		repository := &stubRepository{invoices: map[string]*invoice.Invoice{inv.ID(): inv}}
+		return invoice.NewInvoiceService(repository, nil, queue, stubRates(rate), policy, nil, nil, nil, nil,
+			nil, nil, nil, nil, nil, zap.NewNop()), queue
+	}
+
+	staleRateCheck := func(t *testing.T, service invoice.InvoiceService) *invoice.StaleRateCheck {
+		inv, err := service.GetInvoice(ctx, "test-invoice-id")
+		require.NoError(t, err)
+		require.Len(t, inv.StaleRateChecks(), 1)
+		require.Equal(t, "payment-1", inv.StaleRateChecks()[0].PaymentID())
+		return inv.StaleRateChecks()[0]
+	}
+
+	t.Run("accept within slippage judges the locked amount", func(t *testing.T) {
+		service, queue := newService(invoice.StaleRateAccept, "0.0000203")
+
+		require.NoError(t, service.ProcessPayment(ctx, "test-invoice-id", newStaleRateTestPayment(t, "0.0022")))
+		check := staleRateCheck(t, service)
+		require.Equal(t, invoice.StaleRateAccept, check.Action())
+		require.Equal(t, "0.015", check.Slippage().String())
+		require.Equal(t, "rate moved 1.5%, within 2%", check.Reason())
+		require.Empty(t, queue.staleRate)
+
+		status, err := service.GetInvoiceStatus(ctx, "test-invoice-id")
+		require.NoError(t, err)
+		require.Equal(t, invoice.StatusConfirming, status)
+	})
+
+	t.Run("accept beyond slippage routes to review", func(t *testing.T) {
+		service, queue := newService(invoice.StaleRateAccept, "0.000025")
+
+		err := service.ProcessPayment(ctx, "test-invoice-id", newStaleRateTestPayment(t, "0.0022"))
+		require.ErrorIs(t, err, invoice.ErrStaleRate)
+		check := staleRateCheck(t, service)
+		require.Equal(t, invoice.StaleRateReview, check.Action())
+		require.Equal(t, "rate moved 25%, beyond 2%", check.Reason())
+		require.Equal(t, []string{"payment-1"}, queue.staleRate)
+
+		inv, err := service.GetInvoice(ctx, "test-invoice-id")
+		require.NoError(t, err)
+		require.Nil(t, inv.AmountPaid())
+		require.Equal(t, invoice.StatusPending, inv.Status())
+	})
+
+	t.Run("review routes every stale payment", func(t *testing.T) {
+		service, queue := newService(invoice.StaleRateReview, "0.00002")
+
+		err := service.ProcessPayment(ctx, "test-invoice-id", newStaleRateTestPayment(t, "0.0022"))
+		require.ErrorIs(t, err, invoice.ErrStaleRate)
+		check := staleRateCheck(t, service)
+		require.Equal(t, invoice.StaleRateReview, check.Action())
+		require.True(t, check.CurrentRate().IsZero())
+		require.Len(t, queue.staleRate, 1)
+	})
+
+	t.Run("requote judges the amount at the current rate", func(t *testing.T) {
+		service, queue := newService(invoice.StaleRateRequote, "0.000025")
+
+		require.NoError(t, service.ProcessPayment(ctx, "test-invoice-id", newStaleRateTestPayment(t, "0.0022")))
+		check := staleRateCheck(t, service)
+		require.Equal(t, invoice.StaleRateRequote, check.Action())
+		require.Empty(t, queue.staleRate)
+
+		inv, err := service.GetInvoice(ctx, "test-invoice-id")
+		require.NoError(t, err)
+		require.Len(t, inv.Requotes(), 1)
+		require.Equal(t, "0.00275", inv.Requotes()[0].NewAmount().Amount().String())
+		require.Equal(t, invoice.StatusPartial, inv.Status())
+	})
+
+	t.Run("unavailable rate routes to review", func(t *testing.T) {
+		service, queue := newService(invoice.StaleRateRequote, "")
+
+		err := service.ProcessPayment(ctx, "test-invoice-id", newStaleRateTestPayment(t, "0.0022"))
+		require.ErrorIs(t, err, invoice.ErrStaleRate)
+		check := staleRateCheck(t, service)
+		require.Equal(t, invoice.StaleRateReview, check.Action())
+		require.Equal(t, "current rate unavailable", check.Reason())
+		require.Len(t, queue.staleRate, 1)
+	})
+}
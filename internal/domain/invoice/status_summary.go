@@ -0,0 +1,43 @@
+package invoice
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// MaxStatusBatchSize is the largest number of invoices whose status can be read in one batch.
+const MaxStatusBatchSize = 500
+
+// StatusSummary is the payment state of an invoice, read in bulk without loading the aggregate.
+// Merchants poll it to follow many invoices at once.
+type StatusSummary struct {
+	InvoiceID string
+	Status    InvoiceStatus
+	// AmountDue is the amount in the invoice cryptocurrency that settles the invoice; for donations, the minimum.
+	AmountDue decimal.Decimal
+	// AmountPaid is the cumulative amount received in the invoice cryptocurrency.
+	AmountPaid decimal.Decimal
+	// Confirmations is the highest confirmation count among the invoice's payments.
+	Confirmations int
+	// ExpiresAt is when the invoice stops accepting payment, or nil when it does not expire.
+	ExpiresAt *time.Time
+}
+
+// RemainingAmount returns the amount still to be paid, or zero once the amount due is reached.
+func (s *StatusSummary) RemainingAmount() decimal.Decimal {
+	remaining := s.AmountDue.Sub(s.AmountPaid)
+	if remaining.IsNegative() {
+		return decimal.Zero
+	}
+	return remaining
+}
+
+// ProgressPercentage returns the share of the amount due received so far, from 0 to 100.
+func (s *StatusSummary) ProgressPercentage() decimal.Decimal {
+	if !s.AmountDue.IsPositive() {
+		return decimal.Zero
+	}
+	progress := s.AmountPaid.Div(s.AmountDue).Mul(decimal.NewFromInt(100)).Round(2)
+	return decimal.Min(progress, decimal.NewFromInt(100))
+}
@@ -0,0 +1,67 @@
+package invoice
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"slices"
+)
+
+// RuleTagsMetadataKey is the metadata key recording the tags merchants' automation rules added to an invoice.
+const RuleTagsMetadataKey = "rule_tags"
+
+// Tags returns the tags automation rules added to the invoice, in the order they were added.
+func (i *Invoice) Tags() []string {
+	var tags []string
+	switch recorded := i.metadata[RuleTagsMetadataKey].(type) {
+	case []string:
+		tags = append(tags, recorded...)
+	case []interface{}: // As decoded from storage
+		for _, tag := range recorded {
+			if tag, ok := tag.(string); ok {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// AddTag records a tag on the invoice, reporting whether it did not have the tag yet.
+func (i *Invoice) AddTag(tag string) bool {
+	tags := i.Tags()
+	if slices.Contains(tags, tag) {
+		return false
+	}
+
+	metadata := make(map[string]interface{}, len(i.metadata)+1)
+	for key, value := range i.metadata {
+		metadata[key] = value
+	}
+	metadata[RuleTagsMetadataKey] = append(tags, tag)
+	i.metadata = metadata
+	i.updatedAt = shared.Now().UTC()
+	return true
+}
+
+// TagInvoice records a tag on an invoice. Tagging an invoice with a tag it has changes nothing.
+func (s *InvoiceServiceImpl) TagInvoice(ctx context.Context, id, tag string) (*Invoice, error) {
+	if id == "" {
+		return nil, shared.NewFieldError("invoice_id", "invoice ID cannot be empty")
+	}
+	if tag == "" {
+		return nil, fmt.Errorf("%w: tag cannot be empty", ErrInvalidRequest)
+	}
+
+	invoice, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !invoice.AddTag(tag) {
+		return invoice, nil
+	}
+
+	if err := s.repository.Update(ctx, invoice); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
@@ -0,0 +1,35 @@
+package invoice_test
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoice_AddTag(t *testing.T) {
+	inv := createTestInvoice()
+	require.Empty(t, inv.Tags())
+
+	require.True(t, inv.AddTag("vip"))
+	require.True(t, inv.AddTag("large"))
+	require.False(t, inv.AddTag("vip"))
+	require.Equal(t, []string{"vip", "large"}, inv.Tags())
+
+	t.Run("tags decoded from storage", func(t *testing.T) {
+		var metadata map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(`{"rule_tags":["vip"]}`), &metadata))
+		require.Equal(t, []interface{}{"vip"}, metadata[invoice.RuleTagsMetadataKey])
+
+		stored := createTestInvoice()
+		stored.SetMetadata(metadata)
+		require.Equal(t, []string{"vip"}, stored.Tags())
+		require.False(t, stored.AddTag("vip"))
+	})
+
+	t.Run("merchants cannot set rule tags", func(t *testing.T) {
+		err := invoice.DefaultMetadataPolicy().Validate(map[string]interface{}{invoice.RuleTagsMetadataKey: "vip"})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,400 @@
+package invoice
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultTaxCategory is the tax category of items that do not name one.
+const DefaultTaxCategory = "standard"
+
+// taxCategoryPattern restricts tax categories to short identifiers such as "standard", "reduced" or "digital".
+var taxCategoryPattern = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+// NormalizeTaxCategory returns the canonical form of a tax category, defaulting to DefaultTaxCategory.
+func NormalizeTaxCategory(category string) (string, error) {
+	category = strings.ToLower(strings.TrimSpace(category))
+	if category == "" {
+		return DefaultTaxCategory, nil
+	}
+	if !taxCategoryPattern.MatchString(category) {
+		return "", fmt.Errorf("%w: tax category must be 1-32 lowercase letters, digits or underscores", ErrInvalidTax)
+	}
+	return category, nil
+}
+
+// TaxRate is a single tax a jurisdiction levies, such as a country's VAT or a state's sales tax.
+type TaxRate struct {
+	name          string
+	taxType       TaxType
+	jurisdiction  string
+	rate          decimal.Decimal
+	reverseCharge bool
+}
+
+// NewTaxRate creates a new TaxRate. The rate is a decimal fraction, e.g. "0.19" for 19%.
+// A reverse-charged rate is shown on the invoice but the customer accounts for the tax, so none is charged.
+func NewTaxRate(name string, taxType TaxType, jurisdiction, rate string, reverseCharge bool) (*TaxRate, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: tax name is required", ErrInvalidTax)
+	}
+	if !taxType.IsValid() {
+		return nil, fmt.Errorf("%w: unknown tax type %q", ErrInvalidTax, taxType)
+	}
+	if jurisdiction == "" {
+		return nil, fmt.Errorf("%w: tax jurisdiction is required", ErrInvalidTax)
+	}
+
+	parsed, err := decimal.NewFromString(rate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid tax rate format", ErrInvalidTax)
+	}
+	if parsed.IsNegative() || parsed.GreaterThan(decimal.NewFromInt(1)) {
+		return nil, fmt.Errorf("%w: tax rate must be between 0 and 1", ErrInvalidTax)
+	}
+
+	return &TaxRate{
+		name:          name,
+		taxType:       taxType,
+		jurisdiction:  jurisdiction,
+		rate:          parsed,
+		reverseCharge: reverseCharge,
+	}, nil
+}
+
+// Name returns the name shown on the invoice, e.g. "VAT" or "CA State Sales Tax".
+func (r *TaxRate) Name() string {
+	return r.name
+}
+
+// Type returns the tax type.
+func (r *TaxRate) Type() TaxType {
+	return r.taxType
+}
+
+// Jurisdiction returns the country or subdivision levying the tax, e.g. "DE" or "US-CA".
+func (r *TaxRate) Jurisdiction() string {
+	return r.jurisdiction
+}
+
+// Rate returns the tax rate as a decimal fraction.
+func (r *TaxRate) Rate() decimal.Decimal {
+	return r.rate
+}
+
+// IsReverseCharge returns true if the customer accounts for the tax instead of paying it.
+func (r *TaxRate) IsReverseCharge() bool {
+	return r.reverseCharge
+}
+
+// TaxPolicy is the set of taxes that applies to an invoice, by item tax category.
+// It is resolved from the merchant's jurisdiction rules for the customer's location.
+type TaxPolicy struct {
+	jurisdiction  string
+	customerTaxID string
+	rates         map[string][]*TaxRate
+}
+
+// NewTaxPolicy creates a new TaxPolicy. Categories without rates are not taxed.
+func NewTaxPolicy(jurisdiction, customerTaxID string, rates map[string][]*TaxRate) (*TaxPolicy, error) {
+	if jurisdiction == "" {
+		return nil, fmt.Errorf("%w: tax jurisdiction is required", ErrInvalidTax)
+	}
+	if rates == nil {
+		rates = make(map[string][]*TaxRate)
+	}
+
+	return &TaxPolicy{
+		jurisdiction:  jurisdiction,
+		customerTaxID: customerTaxID,
+		rates:         rates,
+	}, nil
+}
+
+// Jurisdiction returns the customer's tax jurisdiction.
+func (p *TaxPolicy) Jurisdiction() string {
+	return p.jurisdiction
+}
+
+// CustomerTaxID returns the customer's tax ID, or an empty string for consumers.
+func (p *TaxPolicy) CustomerTaxID() string {
+	return p.customerTaxID
+}
+
+// RatesFor returns the taxes levied on items of a tax category.
+func (p *TaxPolicy) RatesFor(category string) []*TaxRate {
+	return p.rates[category]
+}
+
+// Treatment returns how the policy taxes the invoice.
+func (p *TaxPolicy) Treatment() *TaxTreatment {
+	reverseCharge := false
+	for _, rates := range p.rates {
+		for _, rate := range rates {
+			reverseCharge = reverseCharge || rate.reverseCharge
+		}
+	}
+	return NewTaxTreatment(p.jurisdiction, p.customerTaxID, reverseCharge)
+}
+
+// TaxTreatment records the jurisdiction and customer an invoice was taxed for.
+type TaxTreatment struct {
+	jurisdiction  string
+	customerTaxID string
+	reverseCharge bool
+}
+
+// NewTaxTreatment creates a new TaxTreatment.
+func NewTaxTreatment(jurisdiction, customerTaxID string, reverseCharge bool) *TaxTreatment {
+	return &TaxTreatment{
+		jurisdiction:  jurisdiction,
+		customerTaxID: customerTaxID,
+		reverseCharge: reverseCharge,
+	}
+}
+
+// Jurisdiction returns the customer's tax jurisdiction.
+func (t *TaxTreatment) Jurisdiction() string {
+	return t.jurisdiction
+}
+
+// CustomerTaxID returns the customer's tax ID, or an empty string for consumers.
+func (t *TaxTreatment) CustomerTaxID() string {
+	return t.customerTaxID
+}
+
+// IsReverseCharge returns true if any tax on the invoice is reverse charged to the customer.
+func (t *TaxTreatment) IsReverseCharge() bool {
+	return t.reverseCharge
+}
+
+// TaxLine is a tax levied on an invoice item, or the sum of one tax across the invoice.
+type TaxLine struct {
+	rate          *TaxRate
+	taxableAmount *shared.Money
+	amount        *shared.Money
+}
+
+// NewTaxLine calculates the tax a rate levies on a taxable amount.
+// Reverse-charged lines keep their rate for the invoice but charge nothing.
+func NewTaxLine(rate *TaxRate, taxableAmount *shared.Money) (*TaxLine, error) {
+	if rate == nil || taxableAmount == nil {
+		return nil, fmt.Errorf("%w: tax rate and taxable amount are required", ErrInvalidTax)
+	}
+
+	amount := decimal.Zero
+	if !rate.reverseCharge {
+		amount = taxableAmount.Amount().Mul(rate.rate)
+	}
+
+	money, err := shared.NewMoney(amount.StringFixed(2), shared.Currency(taxableAmount.Currency()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaxLine{
+		rate:          rate,
+		taxableAmount: taxableAmount,
+		amount:        money,
+	}, nil
+}
+
+// RestoreTaxLine recreates a tax line from storage without recalculating it.
+func RestoreTaxLine(rate *TaxRate, taxableAmount, amount *shared.Money) (*TaxLine, error) {
+	if rate == nil || taxableAmount == nil || amount == nil {
+		return nil, fmt.Errorf("%w: tax rate and amounts are required", ErrInvalidTax)
+	}
+
+	return &TaxLine{
+		rate:          rate,
+		taxableAmount: taxableAmount,
+		amount:        amount,
+	}, nil
+}
+
+// Rate returns the tax rate of the line.
+func (l *TaxLine) Rate() *TaxRate {
+	return l.rate
+}
+
+// TaxableAmount returns the amount the tax was calculated on.
+func (l *TaxLine) TaxableAmount() *shared.Money {
+	return l.taxableAmount
+}
+
+// Amount returns the tax charged.
+func (l *TaxLine) Amount() *shared.Money {
+	return l.amount
+}
+
+// TaxCategory returns the tax category of the item.
+func (ii *InvoiceItem) TaxCategory() string {
+	if ii.taxCategory == "" {
+		return DefaultTaxCategory
+	}
+	return ii.taxCategory
+}
+
+// TaxLines returns the taxes levied on the item, or nil if the invoice uses a flat tax.
+func (ii *InvoiceItem) TaxLines() []*TaxLine {
+	return ii.taxLines
+}
+
+// SetTaxCategory sets the tax category of the item.
+func (ii *InvoiceItem) SetTaxCategory(category string) {
+	ii.taxCategory = category
+}
+
+// SetTaxLines sets the taxes levied on the item (for repository restoration).
+func (ii *InvoiceItem) SetTaxLines(lines []*TaxLine) {
+	ii.taxLines = lines
+}
+
+// TaxTreatment returns the jurisdiction and customer the invoice was taxed for,
+// or nil if the invoice uses a flat tax.
+func (i *Invoice) TaxTreatment() *TaxTreatment {
+	return i.taxTreatment
+}
+
+// SetTaxTreatment sets the tax treatment (for creation and repository restoration).
+func (i *Invoice) SetTaxTreatment(treatment *TaxTreatment) {
+	i.taxTreatment = treatment
+}
+
+// TaxSummary returns the tax lines of all items summed per tax, in the order they first appear.
+// VAT invoices must show the taxable amount and tax for each rate.
+func (i *Invoice) TaxSummary() []*TaxLine {
+	var (
+		summary []*TaxLine
+		index   = make(map[string]int)
+	)
+
+	for _, item := range i.items {
+		for _, line := range item.taxLines {
+			key := strings.Join([]string{
+				line.rate.name,
+				line.rate.taxType.String(),
+				line.rate.jurisdiction,
+				line.rate.rate.String(),
+				fmt.Sprint(line.rate.reverseCharge),
+			}, "|")
+
+			pos, ok := index[key]
+			if !ok {
+				index[key] = len(summary)
+				summary = append(summary, line)
+				continue
+			}
+
+			taxable, err := summary[pos].taxableAmount.Add(line.taxableAmount)
+			if err != nil {
+				continue
+			}
+			amount, err := summary[pos].amount.Add(line.amount)
+			if err != nil {
+				continue
+			}
+			summary[pos] = &TaxLine{rate: line.rate, taxableAmount: taxable, amount: amount}
+		}
+	}
+
+	return summary
+}
+
+// hasTaxLines returns true if the invoice was taxed per item rather than with a flat tax.
+func (i *Invoice) hasTaxLines() bool {
+	for _, item := range i.items {
+		if len(item.taxLines) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTaxPolicy levies the policy's taxes on each item and returns the total tax.
+// The invoice-level discount is shared across items in proportion to their totals.
+func applyTaxPolicy(items []*InvoiceItem, policy *TaxPolicy, discount *shared.Money) (*shared.Money, error) {
+	return taxItems(items, discount, func(item *InvoiceItem) []*TaxRate {
+		return policy.RatesFor(item.TaxCategory())
+	})
+}
+
+// recalculateTaxLines recalculates the existing tax lines of each item after the invoice-level
+// discount changed and returns the total tax.
+func recalculateTaxLines(items []*InvoiceItem, discount *shared.Money) (*shared.Money, error) {
+	return taxItems(items, discount, func(item *InvoiceItem) []*TaxRate {
+		rates := make([]*TaxRate, len(item.taxLines))
+		for j, line := range item.taxLines {
+			rates[j] = line.rate
+		}
+		return rates
+	})
+}
+
+// taxItems sets the tax lines of each item from its rates and returns the total tax.
+func taxItems(
+	items []*InvoiceItem,
+	discount *shared.Money,
+	ratesFor func(item *InvoiceItem) []*TaxRate,
+) (*shared.Money, error) {
+	taxableAmounts, err := allocateDiscount(items, discount)
+	if err != nil {
+		return nil, err
+	}
+
+	total := decimal.Zero
+	for j, item := range items {
+		rates := ratesFor(item)
+		lines := make([]*TaxLine, 0, len(rates))
+		for _, rate := range rates {
+			line, err := NewTaxLine(rate, taxableAmounts[j])
+			if err != nil {
+				return nil, err
+			}
+			lines = append(lines, line)
+			total = total.Add(line.amount.Amount())
+		}
+		item.taxLines = lines
+	}
+
+	return shared.NewMoney(total.StringFixed(2), shared.Currency(discount.Currency()))
+}
+
+// allocateDiscount returns the taxable amount of each item once the invoice-level discount is shared
+// across items in proportion to their totals. The last item absorbs rounding so the shares add up.
+func allocateDiscount(items []*InvoiceItem, discount *shared.Money) ([]*shared.Money, error) {
+	subtotal := decimal.Zero
+	for _, item := range items {
+		subtotal = subtotal.Add(item.totalPrice.Amount())
+	}
+
+	remaining := discount.Amount()
+	taxableAmounts := make([]*shared.Money, len(items))
+	for j, item := range items {
+		share := remaining
+		if j < len(items)-1 {
+			share = decimal.Zero
+			if subtotal.IsPositive() {
+				share = discount.Amount().Mul(item.totalPrice.Amount()).Div(subtotal).Round(2)
+			}
+			remaining = remaining.Sub(share)
+		}
+
+		taxable := item.totalPrice.Amount().Sub(share)
+		if taxable.IsNegative() {
+			taxable = decimal.Zero
+		}
+
+		money, err := shared.NewMoney(taxable.StringFixed(2), shared.Currency(item.totalPrice.Currency()))
+		if err != nil {
+			return nil, err
+		}
+		taxableAmounts[j] = money
+	}
+
+	return taxableAmounts, nil
+}
@@ -0,0 +1,96 @@
+package invoice_test
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTaxRate(t *testing.T, name, rate string, reverseCharge bool) *invoice.TaxRate {
+	taxRate, err := invoice.NewTaxRate(name, invoice.TaxTypeVAT, "DE", rate, reverseCharge)
+	require.NoError(t, err)
+	return taxRate
+}
+
+func TestTaxRate(t *testing.T) {
+	_, err := invoice.NewTaxRate("VAT", invoice.TaxTypeVAT, "DE", "1.5", false)
+	require.ErrorIs(t, err, invoice.ErrInvalidTax)
+
+	_, err = invoice.NewTaxRate("VAT", invoice.TaxType("excise"), "DE", "0.19", false)
+	require.ErrorIs(t, err, invoice.ErrInvalidTax)
+
+	_, err = invoice.NewTaxRate("", invoice.TaxTypeVAT, "DE", "0.19", false)
+	require.ErrorIs(t, err, invoice.ErrInvalidTax)
+
+	category, err := invoice.NormalizeTaxCategory(" Reduced ")
+	require.NoError(t, err)
+	require.Equal(t, "reduced", category)
+
+	category, err = invoice.NormalizeTaxCategory("")
+	require.NoError(t, err)
+	require.Equal(t, invoice.DefaultTaxCategory, category)
+}
+
+func TestTaxLine(t *testing.T) {
+	taxable, _ := shared.NewMoney("33.33", shared.CurrencyUSD)
+
+	line, err := invoice.NewTaxLine(newTestTaxRate(t, "VAT", "0.19", false), taxable)
+	require.NoError(t, err)
+	require.Equal(t, "6.33", line.Amount().String())
+
+	reverseCharged, err := invoice.NewTaxLine(newTestTaxRate(t, "VAT", "0.19", true), taxable)
+	require.NoError(t, err)
+	require.Equal(t, "0.00", reverseCharged.Amount().String())
+	require.Equal(t, "33.33", reverseCharged.TaxableAmount().String())
+}
+
+func TestInvoiceTaxSummary(t *testing.T) {
+	testInvoice := createTestInvoice()
+	vat := newTestTaxRate(t, "VAT", "0.10", false)
+
+	first, _ := shared.NewMoney("60.00", shared.CurrencyUSD)
+	second, _ := shared.NewMoney("40.00", shared.CurrencyUSD)
+	firstLine, err := invoice.NewTaxLine(vat, first)
+	require.NoError(t, err)
+	secondLine, err := invoice.NewTaxLine(vat, second)
+	require.NoError(t, err)
+
+	unitPrice, _ := shared.NewMoney("40.00", shared.CurrencyUSD)
+	extra, err := invoice.NewInvoiceItem("Extra", "", "1", unitPrice)
+	require.NoError(t, err)
+	extra.SetTaxLines([]*invoice.TaxLine{secondLine})
+	testInvoice.Items()[0].SetTaxLines([]*invoice.TaxLine{firstLine})
+
+	summaryInvoice, err := invoice.NewInvoice(
+		"tax-invoice", "merchant-1", "Taxed", "",
+		[]*invoice.InvoiceItem{testInvoice.Items()[0], extra},
+		testInvoice.Pricing(), testInvoice.CryptoCurrency(), testInvoice.PaymentAddress(),
+		testInvoice.ExchangeRate(), testInvoice.PaymentTolerance(), testInvoice.Expiration(), nil,
+	)
+	require.NoError(t, err)
+
+	summary := summaryInvoice.TaxSummary()
+	require.Len(t, summary, 1)
+	require.Equal(t, "100.00", summary[0].TaxableAmount().String())
+	require.Equal(t, "10.00", summary[0].Amount().String())
+}
+
+func TestInvoiceApplyCoupon_RecalculatesTaxLines(t *testing.T) {
+	testInvoice := createTestInvoice()
+
+	taxable, _ := shared.NewMoney("100.00", shared.CurrencyUSD)
+	line, err := invoice.NewTaxLine(newTestTaxRate(t, "VAT", "0.10", false), taxable)
+	require.NoError(t, err)
+	testInvoice.Items()[0].SetTaxLines([]*invoice.TaxLine{line})
+
+	require.NoError(t, testInvoice.ApplyCoupon(newTestCoupon(t, invoice.DiscountTypeFixed, "25")))
+
+	lines := testInvoice.Items()[0].TaxLines()
+	require.Len(t, lines, 1)
+	require.Equal(t, "75.00", lines[0].TaxableAmount().String())
+	require.Equal(t, "7.50", lines[0].Amount().String())
+	require.Equal(t, "7.50", testInvoice.Pricing().Tax().String())
+	require.Equal(t, "82.50", testInvoice.Pricing().Total().String())
+}
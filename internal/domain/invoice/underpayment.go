@@ -0,0 +1,153 @@
+package invoice
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// UnderpaidRefundReason is the reason recorded on the refunds of invoices closed underpaid.
+const UnderpaidRefundReason = "Partial payment not completed within the grace period"
+
+// UnderpaymentPolicy bounds how long a partially paid invoice stays open after its expiry.
+type UnderpaymentPolicy struct {
+	// GracePeriod is how long after its expiry a partially paid invoice can still be paid in full before it
+	// is closed underpaid. Zero closes it as soon as it expires.
+	GracePeriod time.Duration
+}
+
+// DefaultUnderpaymentPolicy gives customers a day after the expiry to complete a partial payment.
+func DefaultUnderpaymentPolicy() UnderpaymentPolicy {
+	return UnderpaymentPolicy{GracePeriod: 24 * time.Hour}
+}
+
+// MerchantUnderpaymentPolicies provides each merchant's grace period for completing partial payments.
+type MerchantUnderpaymentPolicies interface {
+	// UnderpaymentPolicy returns the merchant's grace period, or DefaultUnderpaymentPolicy if it has not set one.
+	UnderpaymentPolicy(ctx context.Context, merchantID string) (UnderpaymentPolicy, error)
+}
+
+// UnderpaymentResolution is what becomes of the payments received for an invoice closed underpaid.
+type UnderpaymentResolution string
+
+const (
+	// UnderpaymentResolutionRefund - The payments received are refunded to the customer
+	UnderpaymentResolutionRefund UnderpaymentResolution = "refund"
+	// UnderpaymentResolutionCredit - The payments received are credited to the merchant
+	UnderpaymentResolutionCredit UnderpaymentResolution = "credit"
+)
+
+// String returns the string representation of the underpayment resolution.
+func (r UnderpaymentResolution) String() string {
+	return string(r)
+}
+
+// UnderpaymentResolution returns what becomes of the payments received for the invoice if it is closed
+// underpaid: they are refunded when its payment tolerance refunds overpayments, and credited to the
+// merchant otherwise.
+func (i *Invoice) UnderpaymentResolution() UnderpaymentResolution {
+	if i.paymentTolerance != nil && i.paymentTolerance.OverpaymentAction() == OverpaymentActionRefund {
+		return UnderpaymentResolutionRefund
+	}
+	return UnderpaymentResolutionCredit
+}
+
+// PartialPaymentLapsed returns true if the invoice is partially paid and the grace period after its expiry
+// has passed as of now.
+func (i *Invoice) PartialPaymentLapsed(policy UnderpaymentPolicy, now time.Time) bool {
+	if i.status != StatusPartial || i.expiration == nil {
+		return false
+	}
+	return !now.Before(i.expiration.ExpiresAt().Add(policy.GracePeriod))
+}
+
+// closeLapsedPartialInvoice closes a partially paid invoice whose grace period has passed, then refunds the
+// payments received to its confirmed refund destination or leaves them to be credited to the merchant.
+// It reports whether the invoice was closed.
+func (s *InvoiceServiceImpl) closeLapsedPartialInvoice(ctx context.Context, invoice *Invoice) (bool, error) {
+	policy, err := s.underpaymentPolicy(ctx, invoice.MerchantID())
+	if err != nil {
+		return false, err
+	}
+
+	now := shared.Now().UTC()
+	if !invoice.PartialPaymentLapsed(policy, now) {
+		return false, nil
+	}
+
+	if err := NewInvoiceFSM(invoice).Event(ctx, "close_underpaid"); err != nil {
+		return false, err
+	}
+
+	// Without a confirmed refund destination the payments stay refundable until the merchant confirms one
+	resolution := invoice.UnderpaymentResolution()
+	var refund *Refund
+	destination, unconfirmed := invoice.ConfirmedRefundAddress()
+	if paid := invoice.AmountPaid(); resolution == UnderpaymentResolutionRefund && unconfirmed == nil &&
+		paid != nil && !paid.IsZero() {
+		refundID, err := s.ids.NewID(ctx, shared.IDKindRefund, invoice.MerchantID())
+		if err != nil {
+			return false, err
+		}
+		if refund, err = NewRefund(refundID, paid, UnderpaidRefundReason, "", now); err != nil {
+			return false, err
+		}
+		refund.SetDestination(destination)
+		if err := invoice.AddRefund(refund); err != nil {
+			return false, fmt.Errorf("failed to refund underpaid invoice: %w", err)
+		}
+	}
+
+	if err := s.repository.Update(ctx, invoice); err != nil {
+		return false, err
+	}
+
+	s.publishInvoiceUnderpaidClosed(ctx, invoice, resolution, policy)
+	if refund != nil {
+		s.publishInvoiceRefunded(ctx, invoice, refund)
+	}
+	return true, nil
+}
+
+// underpaymentPolicy returns the merchant's grace period for completing partial payments.
+func (s *InvoiceServiceImpl) underpaymentPolicy(ctx context.Context, merchantID string) (UnderpaymentPolicy, error) {
+	if s.underpayments == nil {
+		return DefaultUnderpaymentPolicy(), nil
+	}
+	return s.underpayments.UnderpaymentPolicy(ctx, merchantID)
+}
+
+// publishInvoiceUnderpaidClosed publishes the status change of an invoice closed underpaid. Settlement
+// credits the payments received to the merchant when the resolution is a credit.
+func (s *InvoiceServiceImpl) publishInvoiceUnderpaidClosed(
+	ctx context.Context, invoice *Invoice, resolution UnderpaymentResolution, policy UnderpaymentPolicy,
+) {
+	if s.eventBus == nil {
+		return
+	}
+
+	closedAt := invoice.UpdatedAt()
+	eventData := shared.InvoiceStatusChangedEvent{
+		InvoiceEvent:       createInvoiceEventData(invoice),
+		Resolution:         resolution.String(),
+		GracePeriodSeconds: int64(policy.GracePeriod / time.Second),
+		ClosedAt:           &closedAt,
+	}
+	if paid := invoice.AmountPaid(); paid != nil {
+		eventData.AmountPaid = paid.Amount().String()
+	}
+	event := shared.NewInvoiceStatusChangedEvent(eventData)
+	if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+		// Log error but don't fail the operation
+		if s.logger != nil {
+			s.logger.Error("Failed to publish domain event",
+				zap.String("event_type", shared.EventTypeInvoiceStatusChanged),
+				zap.String("aggregate_id", invoice.ID()),
+				zap.Error(err),
+			)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package invoice_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubUnderpaymentPolicies returns a fixed grace period for every merchant.
+type stubUnderpaymentPolicies time.Duration
+
+func (p stubUnderpaymentPolicies) UnderpaymentPolicy(context.Context, string) (invoice.UnderpaymentPolicy, error) {
+	return invoice.UnderpaymentPolicy{GracePeriod: time.Duration(p)}, nil
+}
+
+func (r *stubRepository) FindExpired(context.Context) ([]*invoice.Invoice, error) {
+	var expired []*invoice.Invoice
+	for _, inv := range r.invoices {
+		if inv.Expiration().IsExpired() {
+			expired = append(expired, inv)
+		}
+	}
+	return expired, nil
+}
+
+// recordingEventBus records the events published; only publishing is implemented.
+type recordingEventBus struct {
+	shared.EventBus
+	events []*shared.BaseDomainEvent
+}
+
+func (b *recordingEventBus) PublishEvent(_ context.Context, event *shared.BaseDomainEvent) error {
+	b.events = append(b.events, event)
+	return nil
+}
+
+// createLapsedPartialInvoice returns a partially paid invoice that expired the given time ago.
+func createLapsedPartialInvoice(t *testing.T, action invoice.OverpaymentAction, ago time.Duration) *invoice.Invoice {
+	inv := createTestInvoiceWithOverpaymentAction(action)
+	fsm := invoice.NewInvoiceFSM(inv)
+	require.NoError(t, fsm.Event(context.Background(), "view"))
+	require.NoError(t, fsm.Event(context.Background(), "partial_payment"))
+
+	paid, err := shared.NewMoneyWithCrypto("0.001", shared.CryptoCurrencyBTC)
+	require.NoError(t, err)
+	require.NoError(t, inv.RecordPayment(paid))
+	inv.SetExpiration(invoice.NewInvoiceExpirationWithTimeUnsafe(time.Now().UTC().Add(-ago)))
+	return inv
+}
+
+// confirmRefundSender captures testSenderAddress as the refund destination of the invoice and confirms it.
+func confirmRefundSender(t *testing.T, inv *invoice.Invoice) {
+	now := time.Now().UTC()
+	_, err := inv.CaptureRefundSender(testSenderAddress, now)
+	require.NoError(t, err)
+	require.NoError(t, inv.ConfirmRefundDestination(testSenderAddress, "user-1", now))
+}
+
+func TestInvoice_PartialPaymentLapsed(t *testing.T) {
+	now := time.Now().UTC()
+	inv := createLapsedPartialInvoice(t, invoice.OverpaymentActionRefund, 10*time.Minute)
+
+	require.False(t, inv.PartialPaymentLapsed(invoice.UnderpaymentPolicy{GracePeriod: time.Hour}, now))
+	require.True(t, inv.PartialPaymentLapsed(invoice.UnderpaymentPolicy{GracePeriod: 5 * time.Minute}, now))
+	require.True(t, inv.PartialPaymentLapsed(invoice.UnderpaymentPolicy{}, now))
+
+	t.Run("only partially paid invoices lapse", func(t *testing.T) {
+		pending := createTestInvoice()
+		pending.SetExpiration(invoice.NewInvoiceExpirationWithTimeUnsafe(now.Add(-time.Hour)))
+		require.False(t, pending.PartialPaymentLapsed(invoice.UnderpaymentPolicy{}, now))
+	})
+
+	t.Run("resolution follows the overpayment action", func(t *testing.T) {
+		require.Equal(t, invoice.UnderpaymentResolutionRefund, inv.UnderpaymentResolution())
+		credited := createTestInvoiceWithOverpaymentAction(invoice.OverpaymentActionCredit)
+		require.Equal(t, invoice.UnderpaymentResolutionCredit, credited.UnderpaymentResolution())
+		donated := createTestInvoiceWithOverpaymentAction(invoice.OverpaymentActionDonate)
+		require.Equal(t, invoice.UnderpaymentResolutionCredit, donated.UnderpaymentResolution())
+	})
+}
+
+func TestInvoiceService_CloseUnderpaidInvoices(t *testing.T) {
+	ctx := context.Background()
+
+	newService := func(inv *invoice.Invoice, grace time.Duration) (invoice.InvoiceService, *recordingEventBus) {
+		events := &recordingEventBus{}
+		repository := &stubRepository{invoices: map[string]*invoice.Invoice{inv.ID(): inv}}
+		return invoice.NewInvoiceService(repository, events, nil, nil, invoice.DefaultRequotePolicy(), nil, nil,
+			nil, stubUnderpaymentPolicies(grace), nil, nil, nil, nil, nil, zap.NewNop()), events
+	}
+
+	t.Run("stays partial within the grace period", func(t *testing.T) {
+		inv := createLapsedPartialInvoice(t, invoice.OverpaymentActionRefund, 10*time.Minute)
+		service, events := newService(inv, time.Hour)
+
+		require.NoError(t, service.ProcessExpiredInvoices(ctx))
+		require.Equal(t, invoice.StatusPartial, inv.Status())
+		require.Empty(t, events.events)
+	})
+
+	t.Run("refunds the payments received", func(t *testing.T) {
+		inv := createLapsedPartialInvoice(t, invoice.OverpaymentActionRefund, 2*time.Hour)
+		confirmRefundSender(t, inv)
+		service, events := newService(inv, time.Hour)
+
+		require.NoError(t, service.ProcessExpiredInvoices(ctx))
+		require.Equal(t, invoice.StatusUnderpaidClosed, inv.Status())
+		require.True(t, inv.Status().IsTerminal())
+		require.Len(t, inv.Refunds(), 1)
+		require.Equal(t, "0.001", inv.Refunds()[0].Amount().Amount().String())
+		require.Equal(t, invoice.UnderpaidRefundReason, inv.Refunds()[0].Reason())
+		require.Equal(t, testSenderAddress, inv.Refunds()[0].Destination())
+
+		require.Len(t, events.events, 2)
+		closed := events.events[0].EventData.(shared.InvoiceStatusChangedEvent)
+		require.Equal(t, shared.EventTypeInvoiceStatusChanged, events.events[0].EventType)
+		require.Equal(t, "underpaid_closed", closed.Status)
+		require.Equal(t, "refund", closed.Resolution)
+		require.Equal(t, int64(3600), closed.GracePeriodSeconds)
+		require.Equal(t, shared.EventTypeInvoiceRefunded, events.events[1].EventType)
+	})
+
+	t.Run("holds the refund until a destination is confirmed", func(t *testing.T) {
+		inv := createLapsedPartialInvoice(t, invoice.OverpaymentActionRefund, 2*time.Hour)
+		service, events := newService(inv, time.Hour)
+
+		require.NoError(t, service.ProcessExpiredInvoices(ctx))
+		require.Equal(t, invoice.StatusUnderpaidClosed, inv.Status())
+		require.Empty(t, inv.Refunds())
+		require.Len(t, events.events, 1)
+
+		confirmRefundSender(t, inv)
+		refund, err := service.RefundInvoice(ctx, &invoice.RefundInvoiceRequest{InvoiceID: inv.ID()})
+		require.NoError(t, err)
+		require.Equal(t, "0.001", refund.Amount().Amount().String())
+		require.Equal(t, testSenderAddress, refund.Destination())
+	})
+
+	t.Run("credits the payments received", func(t *testing.T) {
+		inv := createLapsedPartialInvoice(t, invoice.OverpaymentActionCredit, 2*time.Hour)
+		service, events := newService(inv, time.Hour)
+
+		require.NoError(t, service.ProcessExpiredInvoices(ctx))
+		require.Equal(t, invoice.StatusUnderpaidClosed, inv.Status())
+		require.Empty(t, inv.Refunds())
+
+		require.Len(t, events.events, 1)
+		closed := events.events[0].EventData.(shared.InvoiceStatusChangedEvent)
+		require.Equal(t, "credit", closed.Resolution)
+		require.Equal(t, "0.001", closed.AmountPaid)
+
+		// A credited invoice can still be refunded, and stays closed
+		confirmRefundSender(t, inv)
+		refund, err := service.RefundInvoice(ctx, &invoice.RefundInvoiceRequest{InvoiceID: inv.ID(), Amount: "0.0004"})
+		require.NoError(t, err)
+		require.Equal(t, "0.0004", refund.Amount().Amount().String())
+		require.Equal(t, invoice.StatusUnderpaidClosed, inv.Status())
+	})
+}
@@ -21,37 +21,37 @@ func NewPaymentTolerance(
 	overpaymentAction OverpaymentAction,
 ) (*PaymentTolerance, error) {
 	if underpaymentThreshold == "" {
-		return nil, errors.New("underpayment threshold cannot be empty")
+		return nil, shared.NewFieldError("underpayment_threshold", "underpayment threshold cannot be empty")
 	}
 
 	if overpaymentThreshold == "" {
-		return nil, errors.New("overpayment threshold cannot be empty")
+		return nil, shared.NewFieldError("overpayment_threshold", "overpayment threshold cannot be empty")
 	}
 
 	if !overpaymentAction.IsValid() {
-		return nil, errors.New("invalid overpayment action")
+		return nil, shared.WrapFieldError("overpayment_action", ErrInvalidOverpaymentAction)
 	}
 
 	underpayment, err := decimal.NewFromString(underpaymentThreshold)
 	if err != nil {
-		return nil, errors.New("invalid underpayment threshold format")
+		return nil, shared.NewFieldError("underpayment_threshold", "invalid underpayment threshold format")
 	}
 
 	overpayment, err := decimal.NewFromString(overpaymentThreshold)
 	if err != nil {
-		return nil, errors.New("invalid overpayment threshold format")
+		return nil, shared.NewFieldError("overpayment_threshold", "invalid overpayment threshold format")
 	}
 
 	if underpayment.IsNegative() {
-		return nil, errors.New("underpayment threshold cannot be negative")
+		return nil, shared.NewFieldError("underpayment_threshold", "underpayment threshold cannot be negative")
 	}
 
 	if overpayment.IsNegative() {
-		return nil, errors.New("overpayment threshold cannot be negative")
+		return nil, shared.NewFieldError("overpayment_threshold", "overpayment threshold cannot be negative")
 	}
 
 	if underpayment.GreaterThan(decimal.NewFromFloat(1.0)) {
-		return nil, errors.New("underpayment threshold cannot be greater than 1.0")
+		return nil, shared.NewFieldError("underpayment_threshold", "underpayment threshold cannot be greater than 1.0")
 	}
 
 	return &PaymentTolerance{
@@ -147,42 +147,95 @@ func (pt *PaymentTolerance) Equals(other *PaymentTolerance) bool {
 // InvoicePricing represents the pricing breakdown of an invoice.
 type InvoicePricing struct {
 	subtotal *shared.Money
+	discount *shared.Money
 	tax      *shared.Money
+	fee      *shared.Money
 	total    *shared.Money
 }
 
-// NewInvoicePricing creates a new InvoicePricing.
+// NewInvoicePricing creates a new InvoicePricing without an invoice-level discount.
 func NewInvoicePricing(subtotal, tax, total *shared.Money) (*InvoicePricing, error) {
 	if subtotal == nil {
-		return nil, errors.New("subtotal cannot be nil")
+		return nil, shared.NewFieldError("subtotal", "subtotal cannot be nil")
+	}
+
+	discount, err := shared.NewMoney("0.00", shared.Currency(subtotal.Currency()))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewInvoicePricingWithDiscount(subtotal, discount, tax, total)
+}
+
+// NewInvoicePricingWithDiscount creates a new InvoicePricing with an invoice-level discount.
+// The subtotal is the sum of the line item totals after their own discounts.
+func NewInvoicePricingWithDiscount(subtotal, discount, tax, total *shared.Money) (*InvoicePricing, error) {
+	if subtotal == nil {
+		return nil, shared.NewFieldError("subtotal", "subtotal cannot be nil")
+	}
+
+	fee, err := shared.NewMoney("0.00", shared.Currency(subtotal.Currency()))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewInvoicePricingWithFee(subtotal, discount, tax, fee, total)
+}
+
+// NewInvoicePricingWithFee creates a new InvoicePricing whose total includes the platform fee passed through to
+// the customer.
+func NewInvoicePricingWithFee(subtotal, discount, tax, fee, total *shared.Money) (*InvoicePricing, error) {
+	if subtotal == nil {
+		return nil, shared.NewFieldError("subtotal", "subtotal cannot be nil")
+	}
+
+	if discount == nil {
+		return nil, shared.NewFieldError("discount", "discount cannot be nil")
 	}
 
 	if tax == nil {
-		return nil, errors.New("tax cannot be nil")
+		return nil, shared.NewFieldError("tax", "tax cannot be nil")
+	}
+
+	if fee == nil {
+		return nil, shared.NewFieldError("fee", "fee cannot be nil")
 	}
 
 	if total == nil {
-		return nil, errors.New("total cannot be nil")
+		return nil, shared.NewFieldError("total", "total cannot be nil")
 	}
 
 	// Validate currency consistency
-	if subtotal.Currency() != tax.Currency() || subtotal.Currency() != total.Currency() {
-		return nil, errors.New("all amounts must have the same currency")
+	if subtotal.Currency() != tax.Currency() || subtotal.Currency() != total.Currency() ||
+		subtotal.Currency() != discount.Currency() || subtotal.Currency() != fee.Currency() {
+		return nil, shared.NewFieldError("currency", "all amounts must have the same currency")
+	}
+
+	// Validate that total = subtotal + tax - discount + fee
+	taxable, err := subtotal.Subtract(discount)
+	if err != nil {
+		return nil, shared.NewFieldError("discount", "discount cannot exceed subtotal")
 	}
 
-	// Validate that total = subtotal + tax
-	calculatedTotal, err := subtotal.Add(tax)
+	calculatedTotal, err := taxable.Add(tax)
+	if err != nil {
+		return nil, errors.New("failed to calculate total")
+	}
+
+	calculatedTotal, err = calculatedTotal.Add(fee)
 	if err != nil {
 		return nil, errors.New("failed to calculate total")
 	}
 
 	if !calculatedTotal.Equals(total) {
-		return nil, errors.New("total must equal subtotal plus tax")
+		return nil, shared.NewFieldError("total", "total must equal subtotal plus tax minus discount plus fee")
 	}
 
 	return &InvoicePricing{
 		subtotal: subtotal,
+		discount: discount,
 		tax:      tax,
+		fee:      fee,
 		total:    total,
 	}, nil
 }
@@ -192,19 +245,68 @@ func (ip *InvoicePricing) Subtotal() *shared.Money {
 	return ip.subtotal
 }
 
+// Discount returns the invoice-level discount amount, including any coupon.
+func (ip *InvoicePricing) Discount() *shared.Money {
+	return ip.discount
+}
+
+// TaxableAmount returns the subtotal minus the invoice-level discount.
+func (ip *InvoicePricing) TaxableAmount() *shared.Money {
+	taxable, err := ip.subtotal.Subtract(ip.discount)
+	if err != nil {
+		return ip.subtotal
+	}
+	return taxable
+}
+
 // Tax returns the tax amount.
 func (ip *InvoicePricing) Tax() *shared.Money {
 	return ip.tax
 }
 
+// Fee returns the platform fee passed through to the customer, zero unless the merchant passes it through.
+func (ip *InvoicePricing) Fee() *shared.Money {
+	return ip.fee
+}
+
 // Total returns the total amount.
 func (ip *InvoicePricing) Total() *shared.Money {
 	return ip.total
 }
 
+// TotalBeforeFee returns the total without the fee passed through to the customer.
+func (ip *InvoicePricing) TotalBeforeFee() *shared.Money {
+	total, err := ip.total.Subtract(ip.fee)
+	if err != nil {
+		return ip.total
+	}
+	return total
+}
+
+// WithFee returns the pricing with a fee passed through to the customer added to the total, replacing any fee
+// it had.
+func (ip *InvoicePricing) WithFee(fee *shared.Money) (*InvoicePricing, error) {
+	if fee == nil {
+		return nil, shared.NewFieldError("fee", "fee cannot be nil")
+	}
+	total, err := ip.TotalBeforeFee().Add(fee)
+	if err != nil {
+		return nil, err
+	}
+	return NewInvoicePricingWithFee(ip.subtotal, ip.discount, ip.tax, fee, total)
+}
+
 // String returns the string representation of the invoice pricing.
 func (ip *InvoicePricing) String() string {
-	return "Subtotal: " + ip.subtotal.String() + ", Tax: " + ip.tax.String() + ", Total: " + ip.total.String()
+	fee := ""
+	if !ip.fee.IsZero() {
+		fee = ", Fee: " + ip.fee.String()
+	}
+	if !ip.discount.IsZero() {
+		return "Subtotal: " + ip.subtotal.String() + ", Discount: " + ip.discount.String() +
+			", Tax: " + ip.tax.String() + fee + ", Total: " + ip.total.String()
+	}
+	return "Subtotal: " + ip.subtotal.String() + ", Tax: " + ip.tax.String() + fee + ", Total: " + ip.total.String()
 }
 
 // Equals returns true if this invoice pricing equals the other.
@@ -213,62 +315,95 @@ func (ip *InvoicePricing) Equals(other *InvoicePricing) bool {
 		return false
 	}
 	return ip.subtotal.Equals(other.subtotal) &&
+		ip.discount.Equals(other.discount) &&
 		ip.tax.Equals(other.tax) &&
+		ip.fee.Equals(other.fee) &&
 		ip.total.Equals(other.total)
 }
 
 // InvoiceItem represents a line item in an invoice.
 type InvoiceItem struct {
-	name        string
-	description string
-	quantity    decimal.Decimal
-	unitPrice   *shared.Money
-	totalPrice  *shared.Money
+	name           string
+	description    string
+	quantity       decimal.Decimal
+	unitPrice      *shared.Money
+	discount       *Discount
+	discountAmount *shared.Money
+	totalPrice     *shared.Money
+	taxCategory    string
+	taxLines       []*TaxLine
 }
 
 // NewInvoiceItem creates a new InvoiceItem.
 func NewInvoiceItem(name, description, quantity string, unitPrice *shared.Money) (*InvoiceItem, error) {
+	return NewInvoiceItemWithDiscount(name, description, quantity, unitPrice, nil)
+}
+
+// NewInvoiceItemWithDiscount creates a new InvoiceItem with an optional line item discount.
+// The discount applies to the line total, not to each unit.
+func NewInvoiceItemWithDiscount(
+	name, description, quantity string,
+	unitPrice *shared.Money,
+	discount *Discount,
+) (*InvoiceItem, error) {
 	if name == "" {
-		return nil, errors.New("item name cannot be empty")
+		return nil, shared.NewFieldError("name", "item name cannot be empty")
 	}
 
 	if len(name) > 255 {
-		return nil, errors.New("item name cannot exceed 255 characters")
+		return nil, shared.NewFieldError("name", "item name cannot exceed 255 characters")
 	}
 
 	if len(description) > 1000 {
-		return nil, errors.New("item description cannot exceed 1000 characters")
+		return nil, shared.NewFieldError("description", "item description cannot exceed 1000 characters")
 	}
 
 	if quantity == "" {
-		return nil, errors.New("quantity cannot be empty")
+		return nil, shared.NewFieldError("quantity", "quantity cannot be empty")
 	}
 
 	if unitPrice == nil {
-		return nil, errors.New("unit price cannot be nil")
+		return nil, shared.NewFieldError("unit_price", "unit price cannot be nil")
 	}
 
 	qty, err := decimal.NewFromString(quantity)
 	if err != nil {
-		return nil, errors.New("invalid quantity format")
+		return nil, shared.NewFieldError("quantity", "invalid quantity format")
 	}
 
 	if qty.LessThanOrEqual(decimal.Zero) {
-		return nil, errors.New("quantity must be positive")
+		return nil, shared.NewFieldError("quantity", "quantity must be positive")
 	}
 
 	// Calculate total price
-	totalPrice, err := unitPrice.Multiply(qty)
+	grossPrice, err := unitPrice.Multiply(qty)
+	if err != nil {
+		return nil, errors.New("failed to calculate total price")
+	}
+
+	discountAmount, err := shared.NewMoney("0.00", shared.Currency(unitPrice.Currency()))
+	if err != nil {
+		return nil, err
+	}
+	if discount != nil {
+		if discountAmount, err = discount.AmountOf(grossPrice); err != nil {
+			return nil, err
+		}
+	}
+
+	totalPrice, err := grossPrice.Subtract(discountAmount)
 	if err != nil {
 		return nil, errors.New("failed to calculate total price")
 	}
 
 	return &InvoiceItem{
-		name:        name,
-		description: description,
-		quantity:    qty,
-		unitPrice:   unitPrice,
-		totalPrice:  totalPrice,
+		name:           name,
+		description:    description,
+		quantity:       qty,
+		unitPrice:      unitPrice,
+		discount:       discount,
+		discountAmount: discountAmount,
+		totalPrice:     totalPrice,
 	}, nil
 }
 
@@ -292,7 +427,17 @@ func (ii *InvoiceItem) UnitPrice() *shared.Money {
 	return ii.unitPrice
 }
 
-// TotalPrice returns the total price.
+// Discount returns the line item discount, or nil if none was granted.
+func (ii *InvoiceItem) Discount() *Discount {
+	return ii.discount
+}
+
+// DiscountAmount returns the amount taken off the line total by the item discount.
+func (ii *InvoiceItem) DiscountAmount() *shared.Money {
+	return ii.discountAmount
+}
+
+// TotalPrice returns the total price after the item discount.
 func (ii *InvoiceItem) TotalPrice() *shared.Money {
 	return ii.totalPrice
 }
@@ -311,6 +456,7 @@ func (ii *InvoiceItem) Equals(other *InvoiceItem) bool {
 		ii.description == other.description &&
 		ii.quantity.Equal(other.quantity) &&
 		ii.unitPrice.Equals(other.unitPrice) &&
+		ii.discountAmount.Equals(other.discountAmount) &&
 		ii.totalPrice.Equals(other.totalPrice)
 }
 
@@ -322,7 +468,7 @@ type InvoiceExpiration struct {
 
 // NewInvoiceExpiration creates a new InvoiceExpiration.
 func NewInvoiceExpiration(duration time.Duration) *InvoiceExpiration {
-	expiresAt := time.Now().UTC().Add(duration)
+	expiresAt := shared.Now().UTC().Add(duration)
 	return &InvoiceExpiration{
 		expiresAt: expiresAt,
 		duration:  duration,
@@ -331,11 +477,11 @@ func NewInvoiceExpiration(duration time.Duration) *InvoiceExpiration {
 
 // NewInvoiceExpirationWithTime creates a new InvoiceExpiration with a specific expiration time.
 func NewInvoiceExpirationWithTime(expiresAt time.Time) (*InvoiceExpiration, error) {
-	if expiresAt.Before(time.Now().UTC()) {
-		return nil, errors.New("expiration time must be in the future")
+	if expiresAt.Before(shared.Now().UTC()) {
+		return nil, shared.NewFieldError("expires_at", "expiration time must be in the future")
 	}
 
-	duration := time.Until(expiresAt)
+	duration := expiresAt.Sub(shared.Now())
 	return &InvoiceExpiration{
 		expiresAt: expiresAt,
 		duration:  duration,
@@ -345,7 +491,7 @@ func NewInvoiceExpirationWithTime(expiresAt time.Time) (*InvoiceExpiration, erro
 // NewInvoiceExpirationWithTimeUnsafe creates a new InvoiceExpiration with a specific expiration time without validation.
 // This is used for loading existing invoices from the database, including expired ones.
 func NewInvoiceExpirationWithTimeUnsafe(expiresAt time.Time) *InvoiceExpiration {
-	duration := time.Until(expiresAt)
+	duration := expiresAt.Sub(shared.Now())
 	return &InvoiceExpiration{
 		expiresAt: expiresAt,
 		duration:  duration,
@@ -364,12 +510,12 @@ func (ie *InvoiceExpiration) Duration() time.Duration {
 
 // IsExpired returns true if the invoice has expired.
 func (ie *InvoiceExpiration) IsExpired() bool {
-	return time.Now().UTC().After(ie.expiresAt)
+	return shared.Now().UTC().After(ie.expiresAt)
 }
 
 // TimeRemaining returns the time remaining until expiration.
 func (ie *InvoiceExpiration) TimeRemaining() time.Duration {
-	remaining := time.Until(ie.expiresAt)
+	remaining := ie.expiresAt.Sub(shared.Now())
 	if remaining < 0 {
 		return 0
 	}
@@ -3,6 +3,7 @@ package invoice_test
 import (
 	"crypto-checkout/internal/domain/invoice"
 	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/test/testutil"
 	"testing"
 	"time"
 
@@ -375,7 +376,8 @@ func TestInvoiceExpiration(t *testing.T) {
 	})
 
 	t.Run("Equals - same expiration", func(t *testing.T) {
-		// Use the same duration to ensure both have the same duration
+		// Both expirations are counted from the same instant of a stopped clock
+		testutil.UseClock(t, testutil.NewClock(time.Time{}))
 		duration := 30 * time.Minute
 		expiration1 := invoice.NewInvoiceExpiration(duration)
 		expiration2 := invoice.NewInvoiceExpiration(duration)
@@ -384,6 +386,18 @@ func TestInvoiceExpiration(t *testing.T) {
 		require.True(t, expiration1.Equals(expiration2))
 	})
 
+	t.Run("IsExpired - clock passes expiry", func(t *testing.T) {
+		clock := testutil.NewClock(time.Time{})
+		testutil.UseClock(t, clock)
+		expiration := invoice.NewInvoiceExpiration(30 * time.Minute)
+
+		clock.Advance(29 * time.Minute)
+		require.False(t, expiration.IsExpired())
+		require.Equal(t, time.Minute, expiration.TimeRemaining())
+		clock.Advance(2 * time.Minute)
+		require.True(t, expiration.IsExpired())
+	})
+
 	t.Run("Equals - different expiration", func(t *testing.T) {
 		expiration1 := invoice.NewInvoiceExpiration(30 * time.Minute)
 		expiration2 := invoice.NewInvoiceExpiration(60 * time.Minute)
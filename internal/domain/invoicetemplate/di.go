@@ -0,0 +1,15 @@
+package invoicetemplate
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the invoice template service layer dependencies.
+var Module = fx.Module("invoice-template-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+)
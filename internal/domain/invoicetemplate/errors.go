@@ -0,0 +1,16 @@
+package invoicetemplate
+
+import "errors"
+
+// Domain errors for invoice template operations
+var (
+	ErrTemplateNotFound = errors.New("invoice template not found")
+	ErrNameTaken        = errors.New("an invoice template with this name already exists")
+	ErrInvalidRequest   = errors.New("invalid invoice template request")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeTemplateNotFound = "INVOICE_TEMPLATE_NOT_FOUND"
+	ErrCodeNameTaken        = "INVOICE_TEMPLATE_NAME_TAKEN"
+)
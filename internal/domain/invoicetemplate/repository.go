@@ -0,0 +1,22 @@
+package invoicetemplate
+
+import "context"
+
+// Repository defines the interface for invoice template persistence.
+type Repository interface {
+	// Save persists a new template, returning ErrNameTaken if the merchant already has a template with the name.
+	Save(ctx context.Context, template *Template) error
+
+	// FindByID retrieves a template by its ID.
+	FindByID(ctx context.Context, id string) (*Template, error)
+
+	// ListByMerchant retrieves a merchant's templates ordered by name.
+	ListByMerchant(ctx context.Context, merchantID string) ([]*Template, error)
+
+	// Update updates an existing template, returning ErrNameTaken if another of the merchant's templates
+	// has the name.
+	Update(ctx context.Context, template *Template) error
+
+	// Delete removes a template.
+	Delete(ctx context.Context, id string) error
+}
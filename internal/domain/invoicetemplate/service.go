@@ -0,0 +1,317 @@
+package invoicetemplate
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+// TemplateIDMetadataKey is the metadata key recording the template an invoice was created from.
+const TemplateIDMetadataKey = "invoice_template_id"
+
+// Service defines the interface for managing invoice templates and creating invoices from them.
+type Service interface {
+	// CreateTemplate creates an invoice template for a merchant.
+	CreateTemplate(ctx context.Context, req *CreateTemplateRequest) (*Template, error)
+
+	// ListTemplates lists a merchant's invoice templates.
+	ListTemplates(ctx context.Context, merchantID string) ([]*Template, error)
+
+	// GetTemplate retrieves a merchant's invoice template.
+	GetTemplate(ctx context.Context, merchantID, templateID string) (*Template, error)
+
+	// UpdateTemplate replaces the content of a merchant's invoice template.
+	UpdateTemplate(ctx context.Context, req *UpdateTemplateRequest) (*Template, error)
+
+	// DeleteTemplate removes a merchant's invoice template. Invoices already created from it are kept.
+	DeleteTemplate(ctx context.Context, merchantID, templateID string) error
+
+	// CreateInvoice creates a fresh invoice from a merchant's invoice template with the overrides applied.
+	CreateInvoice(ctx context.Context, req *CreateInvoiceRequest) (*invoice.Invoice, error)
+}
+
+// CreateTemplateRequest represents the request to create an invoice template.
+type CreateTemplateRequest struct {
+	MerchantID string `validate:"required"`
+	Definition Definition
+}
+
+// UpdateTemplateRequest represents the request to replace the content of an invoice template.
+type UpdateTemplateRequest struct {
+	MerchantID string `validate:"required"`
+	TemplateID string `validate:"required"`
+	Definition Definition
+}
+
+// CreateInvoiceRequest represents the request to create an invoice from a template.
+type CreateInvoiceRequest struct {
+	MerchantID string `validate:"required"`
+	TemplateID string `validate:"required"`
+	Overrides  Overrides
+}
+
+// Overrides change the invoice created from a template. Zero values keep the template's value.
+type Overrides struct {
+	Title          string
+	Description    string
+	CustomerID     *string
+	Items          []Item // Replace the template's items
+	CryptoCurrency shared.CryptoCurrency
+	Network        shared.BlockchainNetwork
+	TaxRate        *string
+	ExpiresIn      time.Duration
+	Metadata       map[string]interface{} // Merged over the template's metadata
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository     Repository
+	invoiceService invoice.InvoiceService
+	logger         *zap.Logger
+}
+
+// NewService creates a new invoice template service.
+func NewService(repository Repository, invoiceService invoice.InvoiceService, logger *zap.Logger) Service {
+	return &ServiceImpl{
+		repository:     repository,
+		invoiceService: invoiceService,
+		logger:         logger,
+	}
+}
+
+// CreateTemplate creates an invoice template for a merchant.
+func (s *ServiceImpl) CreateTemplate(ctx context.Context, req *CreateTemplateRequest) (*Template, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: create invoice template request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invoice template ID: %w", err)
+	}
+
+	template, err := NewTemplate(id, req.MerchantID, req.Definition)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	if err := s.checkToken(ctx, template); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Save(ctx, template); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Invoice template created",
+		zap.String("template_id", template.ID()),
+		zap.String("merchant_id", template.MerchantID()),
+		zap.String("name", template.Name()))
+
+	return template, nil
+}
+
+// ListTemplates lists a merchant's invoice templates.
+func (s *ServiceImpl) ListTemplates(ctx context.Context, merchantID string) ([]*Template, error) {
+	if merchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+
+	return s.repository.ListByMerchant(ctx, merchantID)
+}
+
+// GetTemplate retrieves a merchant's invoice template.
+func (s *ServiceImpl) GetTemplate(ctx context.Context, merchantID, templateID string) (*Template, error) {
+	if merchantID == "" || templateID == "" {
+		return nil, fmt.Errorf("%w: merchant ID and template ID are required", ErrInvalidRequest)
+	}
+
+	template, err := s.repository.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.MerchantID() != merchantID {
+		return nil, ErrTemplateNotFound
+	}
+
+	return template, nil
+}
+
+// UpdateTemplate replaces the content of a merchant's invoice template.
+func (s *ServiceImpl) UpdateTemplate(ctx context.Context, req *UpdateTemplateRequest) (*Template, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: update invoice template request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	template, err := s.GetTemplate(ctx, req.MerchantID, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if err := template.Update(req.Definition); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	if err := s.checkToken(ctx, template); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// DeleteTemplate removes a merchant's invoice template.
+func (s *ServiceImpl) DeleteTemplate(ctx context.Context, merchantID, templateID string) error {
+	template, err := s.GetTemplate(ctx, merchantID, templateID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.Delete(ctx, template.ID()); err != nil {
+		return err
+	}
+
+	s.logger.Info("Invoice template deleted",
+		zap.String("template_id", template.ID()),
+		zap.String("merchant_id", template.MerchantID()))
+
+	return nil
+}
+
+// CreateInvoice creates a fresh invoice with its own payment address from a merchant's invoice template.
+func (s *ServiceImpl) CreateInvoice(ctx context.Context, req *CreateInvoiceRequest) (*invoice.Invoice, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: create invoice request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	template, err := s.GetTemplate(ctx, req.MerchantID, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	invoiceReq, err := template.invoiceRequest(req.Overrides)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	inv, err := s.invoiceService.CreateInvoice(ctx, invoiceReq)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Invoice created from template",
+		zap.String("template_id", template.ID()),
+		zap.String("invoice_id", inv.ID()))
+
+	return inv, nil
+}
+
+// checkToken checks the merchant accepts the template's cryptocurrency on its network.
+func (s *ServiceImpl) checkToken(ctx context.Context, template *Template) error {
+	_, err := s.invoiceService.ResolveToken(ctx, template.MerchantID(), template.CryptoCurrency(), template.Network())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	return nil
+}
+
+// invoiceRequest builds the request that creates an invoice from the template with the overrides applied.
+func (t *Template) invoiceRequest(overrides Overrides) (*invoice.CreateInvoiceRequest, error) {
+	req := &invoice.CreateInvoiceRequest{
+		MerchantID:         t.merchantID,
+		CustomerID:         overrides.CustomerID,
+		Title:              t.definition.Title,
+		Description:        t.definition.Description,
+		Currency:           t.definition.Currency,
+		CryptoCurrency:     t.definition.CryptoCurrency,
+		Network:            t.definition.Network,
+		TaxRate:            t.definition.TaxRate,
+		ExpirationDuration: t.definition.ExpiresIn,
+		Metadata:           make(map[string]interface{}, len(t.definition.Metadata)+len(overrides.Metadata)+1),
+	}
+
+	if overrides.Title != "" {
+		if len(overrides.Title) > maxTitleLength {
+			return nil, fmt.Errorf("title cannot exceed %d characters", maxTitleLength)
+		}
+		req.Title = overrides.Title
+	}
+	if overrides.Description != "" {
+		if len(overrides.Description) > maxDescriptionLength {
+			return nil, fmt.Errorf("description cannot exceed %d characters", maxDescriptionLength)
+		}
+		req.Description = overrides.Description
+	}
+	if overrides.CryptoCurrency != "" {
+		// A cryptocurrency override selects its own network unless one is given
+		req.CryptoCurrency = overrides.CryptoCurrency
+		req.Network = ""
+	}
+	if overrides.Network != "" {
+		req.Network = overrides.Network
+	}
+	if overrides.TaxRate != nil {
+		if err := validateTaxRate(*overrides.TaxRate); err != nil {
+			return nil, err
+		}
+		req.TaxRate = *overrides.TaxRate
+	}
+	if overrides.ExpiresIn < 0 {
+		return nil, errors.New("expiration cannot be negative")
+	}
+	if overrides.ExpiresIn > 0 {
+		req.ExpirationDuration = overrides.ExpiresIn
+	}
+
+	items := t.definition.Items
+	if len(overrides.Items) > 0 {
+		var err error
+		if items, err = normalizeItems(overrides.Items); err != nil {
+			return nil, err
+		}
+	}
+	req.Items = make([]*invoice.CreateInvoiceItemRequest, len(items))
+	for i, item := range items {
+		itemReq, err := invoiceItem(item, req.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i+1, err)
+		}
+		req.Items[i] = itemReq
+	}
+
+	for key, value := range t.definition.Metadata {
+		req.Metadata[key] = value
+	}
+	for key, value := range overrides.Metadata {
+		req.Metadata[key] = value
+	}
+	req.Metadata[TemplateIDMetadataKey] = t.id
+	req.RecordedMetadata = map[string]interface{}{TemplateIDMetadataKey: t.id}
+
+	return req, nil
+}
+
+func generateID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,275 @@
+// Package invoicetemplate provides named merchant invoice templates that are instantiated into fresh invoices.
+package invoicetemplate
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// maxNameLength bounds the name merchants pick a template by.
+	maxNameLength = 64
+	// maxTitleLength and maxDescriptionLength match the invoice columns the template's invoices are stored in.
+	maxTitleLength       = 255
+	maxDescriptionLength = 1000
+)
+
+// Item is a line item of the invoices created from a template.
+type Item struct {
+	Name        string
+	Description string
+	Quantity    string
+	UnitPrice   string // Price in the template currency
+	TaxCategory string // Defaults to invoice.DefaultTaxCategory
+}
+
+// Definition is the content of a template: everything an invoice created from it starts with.
+type Definition struct {
+	Name           string
+	Title          string
+	Description    string
+	Items          []Item
+	Currency       shared.Currency          // Defaults to USD
+	CryptoCurrency shared.CryptoCurrency    // Defaults to USDT
+	Network        shared.BlockchainNetwork // Empty selects the cryptocurrency's default network
+	TaxRate        string                   // Flat tax rate as decimal, applied to the subtotal
+	ExpiresIn      time.Duration            // Zero uses the invoice default
+	Metadata       map[string]interface{}
+}
+
+// Template is a named, reusable invoice a merchant reissues to customers.
+type Template struct {
+	id         string
+	merchantID string
+	definition Definition
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+// NewTemplate creates a new template, applying the definition's defaults.
+func NewTemplate(id, merchantID string, definition Definition) (*Template, error) {
+	definition, err := normalizeDefinition(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	now := shared.Now().UTC()
+	return RestoreTemplate(id, merchantID, definition, now, now)
+}
+
+// RestoreTemplate recreates a template from storage.
+func RestoreTemplate(id, merchantID string, definition Definition, createdAt, updatedAt time.Time) (*Template, error) {
+	if id == "" {
+		return nil, errors.New("template ID is required")
+	}
+	if merchantID == "" {
+		return nil, errors.New("merchant ID is required")
+	}
+	if err := validateDefinition(definition); err != nil {
+		return nil, err
+	}
+
+	return &Template{
+		id:         id,
+		merchantID: merchantID,
+		definition: definition,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
+	}, nil
+}
+
+// ID returns the template ID.
+func (t *Template) ID() string {
+	return t.id
+}
+
+// MerchantID returns the merchant that owns the template.
+func (t *Template) MerchantID() string {
+	return t.merchantID
+}
+
+// Name returns the name the merchant picks the template by.
+func (t *Template) Name() string {
+	return t.definition.Name
+}
+
+// Title returns the title of the template's invoices.
+func (t *Template) Title() string {
+	return t.definition.Title
+}
+
+// Description returns the description of the template's invoices.
+func (t *Template) Description() string {
+	return t.definition.Description
+}
+
+// Items returns the line items of the template's invoices.
+func (t *Template) Items() []Item {
+	return append([]Item(nil), t.definition.Items...)
+}
+
+// Currency returns the fiat currency of the template's invoices.
+func (t *Template) Currency() shared.Currency {
+	return t.definition.Currency
+}
+
+// CryptoCurrency returns the cryptocurrency the template's invoices are paid in.
+func (t *Template) CryptoCurrency() shared.CryptoCurrency {
+	return t.definition.CryptoCurrency
+}
+
+// Network returns the network the template's invoices are paid on, or empty for the default network.
+func (t *Template) Network() shared.BlockchainNetwork {
+	return t.definition.Network
+}
+
+// TaxRate returns the flat tax rate of the template's invoices, or empty for no tax.
+func (t *Template) TaxRate() string {
+	return t.definition.TaxRate
+}
+
+// ExpiresIn returns how long the template's invoices are payable for, or zero for the invoice default.
+func (t *Template) ExpiresIn() time.Duration {
+	return t.definition.ExpiresIn
+}
+
+// Metadata returns the metadata of the template's invoices.
+func (t *Template) Metadata() map[string]interface{} {
+	return t.definition.Metadata
+}
+
+// CreatedAt returns when the template was created.
+func (t *Template) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// UpdatedAt returns when the template was last changed.
+func (t *Template) UpdatedAt() time.Time {
+	return t.updatedAt
+}
+
+// Update replaces the content of the template.
+func (t *Template) Update(definition Definition) error {
+	definition, err := normalizeDefinition(definition)
+	if err != nil {
+		return err
+	}
+	if err := validateDefinition(definition); err != nil {
+		return err
+	}
+
+	t.definition = definition
+	t.updatedAt = shared.Now().UTC()
+	return nil
+}
+
+// normalizeDefinition trims the name and applies the default currencies and tax categories.
+func normalizeDefinition(definition Definition) (Definition, error) {
+	definition.Name = strings.TrimSpace(definition.Name)
+	if definition.Currency == "" {
+		definition.Currency = shared.CurrencyUSD
+	}
+	if definition.CryptoCurrency == "" {
+		definition.CryptoCurrency = shared.CryptoCurrencyUSDT
+	}
+
+	items, err := normalizeItems(definition.Items)
+	if err != nil {
+		return Definition{}, err
+	}
+	definition.Items = items
+	return definition, nil
+}
+
+// normalizeItems applies the default tax category to each item.
+func normalizeItems(items []Item) ([]Item, error) {
+	normalized := make([]Item, len(items))
+	for i, item := range items {
+		category, err := invoice.NormalizeTaxCategory(item.TaxCategory)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i+1, err)
+		}
+		item.TaxCategory = category
+		normalized[i] = item
+	}
+	return normalized, nil
+}
+
+// validateDefinition checks a template can create valid invoices.
+func validateDefinition(definition Definition) error {
+	if definition.Name == "" || len(definition.Name) > maxNameLength {
+		return fmt.Errorf("template name must be 1-%d characters", maxNameLength)
+	}
+	if definition.Title == "" || len(definition.Title) > maxTitleLength {
+		return fmt.Errorf("template title must be 1-%d characters", maxTitleLength)
+	}
+	if len(definition.Description) > maxDescriptionLength {
+		return fmt.Errorf("template description cannot exceed %d characters", maxDescriptionLength)
+	}
+	if !definition.Currency.IsValid() {
+		return shared.ErrInvalidCurrency
+	}
+	if !definition.CryptoCurrency.IsValid() {
+		return errors.New("invalid cryptocurrency")
+	}
+	if err := validateTaxRate(definition.TaxRate); err != nil {
+		return err
+	}
+	if definition.ExpiresIn < 0 {
+		return errors.New("expiration cannot be negative")
+	}
+	return validateItems(definition.Items, definition.Currency)
+}
+
+// validateItems checks the items price a valid invoice in the currency.
+func validateItems(items []Item, currency shared.Currency) error {
+	if len(items) == 0 {
+		return errors.New("at least one item is required")
+	}
+	for i, item := range items {
+		if _, err := invoiceItem(item, currency); err != nil {
+			return fmt.Errorf("item %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// validateTaxRate checks an optional flat tax rate is a non-negative decimal.
+func validateTaxRate(rate string) error {
+	if rate == "" {
+		return nil
+	}
+	value, err := decimal.NewFromString(rate)
+	if err != nil {
+		return errors.New("invalid tax rate format")
+	}
+	if value.IsNegative() {
+		return errors.New("tax rate cannot be negative")
+	}
+	return nil
+}
+
+// invoiceItem converts a template item to an invoice item request in the currency.
+func invoiceItem(item Item, currency shared.Currency) (*invoice.CreateInvoiceItemRequest, error) {
+	unitPrice, err := shared.NewMoney(item.UnitPrice, currency)
+	if err != nil {
+		return nil, invoice.ErrInvalidUnitPrice
+	}
+	if _, err := invoice.NewInvoiceItem(item.Name, item.Description, item.Quantity, unitPrice); err != nil {
+		return nil, err
+	}
+
+	return &invoice.CreateInvoiceItemRequest{
+		Name:        item.Name,
+		Description: item.Description,
+		Quantity:    item.Quantity,
+		UnitPrice:   unitPrice,
+		TaxCategory: item.TaxCategory,
+	}, nil
+}
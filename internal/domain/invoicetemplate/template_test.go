@@ -0,0 +1,103 @@
+package invoicetemplate
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDefinition() Definition {
+	return Definition{
+		Name:  " Monthly retainer ",
+		Title: "Consulting retainer",
+		Items: []Item{
+			{Name: "Consulting", Quantity: "10", UnitPrice: "150.00"},
+			{Name: "Ebook", Quantity: "1", UnitPrice: "20.00", TaxCategory: "Digital"},
+		},
+		TaxRate:   "0.10",
+		ExpiresIn: time.Hour,
+		Metadata:  map[string]interface{}{"project": "alpha"},
+	}
+}
+
+func TestNewTemplate(t *testing.T) {
+	template, err := NewTemplate("template-1", "merchant-1", newTestDefinition())
+	require.NoError(t, err)
+	assert.Equal(t, "Monthly retainer", template.Name())
+	assert.Equal(t, shared.CurrencyUSD, template.Currency())
+	assert.Equal(t, shared.CryptoCurrencyUSDT, template.CryptoCurrency())
+	assert.Equal(t, invoice.DefaultTaxCategory, template.Items()[0].TaxCategory)
+	assert.Equal(t, "digital", template.Items()[1].TaxCategory)
+
+	for name, mutate := range map[string]func(*Definition){
+		"NoName":          func(d *Definition) { d.Name = "  " },
+		"NoTitle":         func(d *Definition) { d.Title = "" },
+		"NoItems":         func(d *Definition) { d.Items = nil },
+		"InvalidQuantity": func(d *Definition) { d.Items[0].Quantity = "0" },
+		"InvalidPrice":    func(d *Definition) { d.Items[0].UnitPrice = "free" },
+		"NegativeTaxRate": func(d *Definition) { d.TaxRate = "-0.1" },
+		"InvalidCrypto":   func(d *Definition) { d.CryptoCurrency = "usdt" },
+		"NegativeExpiry":  func(d *Definition) { d.ExpiresIn = -time.Minute },
+	} {
+		definition := newTestDefinition()
+		mutate(&definition)
+		_, err := NewTemplate("template-1", "merchant-1", definition)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestTemplate_InvoiceRequest(t *testing.T) {
+	template, err := NewTemplate("template-1", "merchant-1", newTestDefinition())
+	require.NoError(t, err)
+
+	t.Run("TemplateValues", func(t *testing.T) {
+		req, err := template.invoiceRequest(Overrides{})
+		require.NoError(t, err)
+		assert.Equal(t, "merchant-1", req.MerchantID)
+		assert.Equal(t, "Consulting retainer", req.Title)
+		require.Len(t, req.Items, 2)
+		assert.Equal(t, "150.00", req.Items[0].UnitPrice.String())
+		assert.Equal(t, "0.10", req.TaxRate)
+		assert.Equal(t, time.Hour, req.ExpirationDuration)
+		assert.Equal(t, "alpha", req.Metadata["project"])
+		assert.Equal(t, "template-1", req.Metadata[TemplateIDMetadataKey])
+	})
+
+	t.Run("Overrides", func(t *testing.T) {
+		customer := "customer-1"
+		noTax := "0"
+		req, err := template.invoiceRequest(Overrides{
+			Title:          "March retainer",
+			CustomerID:     &customer,
+			Items:          []Item{{Name: "Consulting", Quantity: "12", UnitPrice: "150.00"}},
+			CryptoCurrency: shared.CryptoCurrencyBTC,
+			TaxRate:        &noTax,
+			Metadata:       map[string]interface{}{"month": "march"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "March retainer", req.Title)
+		assert.Equal(t, &customer, req.CustomerID)
+		require.Len(t, req.Items, 1)
+		assert.Equal(t, "12", req.Items[0].Quantity)
+		assert.Equal(t, shared.CryptoCurrencyBTC, req.CryptoCurrency)
+		assert.Equal(t, "0", req.TaxRate)
+		assert.Equal(t, "alpha", req.Metadata["project"])
+		assert.Equal(t, "march", req.Metadata["month"])
+
+		// The template itself is unchanged
+		assert.Len(t, template.Items(), 2)
+		assert.NotContains(t, template.Metadata(), "month")
+	})
+
+	t.Run("InvalidOverrides", func(t *testing.T) {
+		negative := "-1"
+		_, err := template.invoiceRequest(Overrides{TaxRate: &negative})
+		require.Error(t, err)
+		_, err = template.invoiceRequest(Overrides{Items: []Item{{Name: "Consulting", Quantity: "1"}}})
+		require.Error(t, err)
+	})
+}
@@ -1,7 +1,7 @@
 package merchant
 
 import (
-	"errors"
+	"crypto-checkout/internal/domain/shared"
 	"fmt"
 	"time"
 
@@ -41,22 +41,22 @@ func NewAPIKey(
 	expiresAt *time.Time,
 ) (*APIKey, error) {
 	if id == "" {
-		return nil, errors.New("API key ID is required")
+		return nil, shared.NewFieldError("id", "API key ID is required")
 	}
 	if merchantID == "" {
-		return nil, errors.New("merchant ID is required")
+		return nil, shared.NewFieldError("merchant_id", "merchant ID is required")
 	}
 	if rawKey == "" {
-		return nil, errors.New("API key value is required")
+		return nil, shared.NewFieldError("key", "API key value is required")
 	}
 	if !keyType.IsValid() {
 		return nil, fmt.Errorf("invalid key type: %s", keyType)
 	}
 	if len(permissions) == 0 {
-		return nil, errors.New("at least one permission is required")
+		return nil, shared.NewFieldError("permissions", "at least one permission is required")
 	}
 	if len(name) > 100 {
-		return nil, errors.New("name cannot exceed 100 characters")
+		return nil, shared.NewFieldError("name", "name cannot exceed 100 characters")
 	}
 
 	keyHash, err := NewAPIKeyHash(rawKey)
@@ -64,7 +64,7 @@ func NewAPIKey(
 		return nil, fmt.Errorf("failed to hash API key: %w", err)
 	}
 
-	now := time.Now()
+	now := shared.Now()
 	apiKey := &APIKey{
 		id:          id,
 		merchantID:  merchantID,
@@ -106,28 +106,28 @@ func NewAPIKeyFromHash(
 	lastUsedAt, expiresAt *time.Time,
 ) (*APIKey, error) {
 	if id == "" {
-		return nil, errors.New("API key ID is required")
+		return nil, shared.NewFieldError("id", "API key ID is required")
 	}
 	if merchantID == "" {
-		return nil, errors.New("merchant ID is required")
+		return nil, shared.NewFieldError("merchant_id", "merchant ID is required")
 	}
 	if keyHash == nil {
-		return nil, errors.New("key hash is required")
+		return nil, shared.NewFieldError("key_hash", "key hash is required")
 	}
 	if !keyType.IsValid() {
 		return nil, fmt.Errorf("invalid key type: %s", keyType)
 	}
 	if len(permissions) == 0 {
-		return nil, errors.New("at least one permission is required")
+		return nil, shared.NewFieldError("permissions", "at least one permission is required")
 	}
 	if !status.IsValid() {
 		return nil, fmt.Errorf("invalid status: %s", status)
 	}
 	if len(name) > 100 {
-		return nil, errors.New("name cannot exceed 100 characters")
+		return nil, shared.NewFieldError("name", "name cannot exceed 100 characters")
 	}
 
-	now := time.Now()
+	now := shared.Now()
 	apiKey := &APIKey{
 		id:          id,
 		merchantID:  merchantID,
@@ -212,7 +212,7 @@ func (k *APIKey) CreatedAt() time.Time {
 // UpdateName updates the API key name.
 func (k *APIKey) UpdateName(name string) error {
 	if len(name) > 100 {
-		return errors.New("name cannot exceed 100 characters")
+		return shared.NewFieldError("name", "name cannot exceed 100 characters")
 	}
 
 	k.name = name
@@ -222,7 +222,7 @@ func (k *APIKey) UpdateName(name string) error {
 // UpdatePermissions updates the API key permissions.
 func (k *APIKey) UpdatePermissions(permissions []string) error {
 	if len(permissions) == 0 {
-		return errors.New("at least one permission is required")
+		return shared.NewFieldError("permissions", "at least one permission is required")
 	}
 
 	k.permissions = permissions
@@ -232,7 +232,7 @@ func (k *APIKey) UpdatePermissions(permissions []string) error {
 // Revoke revokes the API key.
 func (k *APIKey) Revoke() error {
 	if k.status == KeyStatusRevoked {
-		return errors.New("API key is already revoked")
+		return shared.NewError(shared.ErrConflict, ErrCodeInvalidStatusTransition, "API key is already revoked")
 	}
 
 	k.status = KeyStatusRevoked
@@ -242,11 +242,11 @@ func (k *APIKey) Revoke() error {
 // Activate activates the API key.
 func (k *APIKey) Activate() error {
 	if k.status == KeyStatusActive {
-		return errors.New("API key is already active")
+		return shared.NewError(shared.ErrConflict, ErrCodeInvalidStatusTransition, "API key is already active")
 	}
 
 	if k.status == KeyStatusExpired {
-		return errors.New("cannot activate expired API key")
+		return shared.NewError(shared.ErrInvalidState, ErrCodeAPIKeyExpired, "cannot activate expired API key")
 	}
 
 	k.status = KeyStatusActive
@@ -255,7 +255,7 @@ func (k *APIKey) Activate() error {
 
 // MarkAsUsed marks the API key as used at the current time.
 func (k *APIKey) MarkAsUsed() {
-	now := time.Now()
+	now := shared.Now()
 	k.lastUsedAt = &now
 }
 
@@ -266,7 +266,7 @@ func (k *APIKey) IsActive() bool {
 	}
 
 	// Check if expired
-	if k.expiresAt != nil && time.Now().After(*k.expiresAt) {
+	if k.expiresAt != nil && shared.Now().After(*k.expiresAt) {
 		k.status = KeyStatusExpired
 		return false
 	}
@@ -279,7 +279,7 @@ func (k *APIKey) IsExpired() bool {
 	if k.expiresAt == nil {
 		return false
 	}
-	return time.Now().After(*k.expiresAt)
+	return shared.Now().After(*k.expiresAt)
 }
 
 // HasPermission checks if the API key has a specific permission.
@@ -295,7 +295,7 @@ func (k *APIKey) HasPermission(permission string) bool {
 // ValidatePermission checks if the API key has the required permission.
 func (k *APIKey) ValidatePermission(requiredPermission string) error {
 	if !k.IsActive() {
-		return errors.New("API key is not active")
+		return ErrAPIKeyNotActive
 	}
 
 	if !k.HasPermission(requiredPermission) {
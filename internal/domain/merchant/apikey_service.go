@@ -2,9 +2,9 @@ package merchant
 
 import (
 	"context"
+	"crypto-checkout/internal/domain/shared"
 	"crypto/rand"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"time"
 
@@ -32,7 +32,7 @@ func (s *APIKeyServiceImpl) CreateAPIKey(
 	req *CreateAPIKeyRequest,
 ) (*CreateAPIKeyResponse, error) {
 	if req == nil {
-		return nil, errors.New("create API key request cannot be nil")
+		return nil, shared.NewFieldError("", "create API key request cannot be nil")
 	}
 
 	// Validate request
@@ -100,7 +100,7 @@ func (s *APIKeyServiceImpl) GetAPIKey(
 	req *GetAPIKeyRequest,
 ) (*GetAPIKeyResponse, error) {
 	if req == nil {
-		return nil, errors.New("get API key request cannot be nil")
+		return nil, shared.NewFieldError("", "get API key request cannot be nil")
 	}
 
 	// Validate request
@@ -116,7 +116,7 @@ func (s *APIKeyServiceImpl) GetAPIKey(
 	}
 
 	if apiKey == nil {
-		return nil, errors.New("API key not found")
+		return nil, ErrAPIKeyNotFound
 	}
 
 	return &GetAPIKeyResponse{
@@ -130,7 +130,7 @@ func (s *APIKeyServiceImpl) ListAPIKeys(
 	req *ListAPIKeysRequest,
 ) (*ListAPIKeysResponse, error) {
 	if req == nil {
-		return nil, errors.New("list API keys request cannot be nil")
+		return nil, shared.NewFieldError("", "list API keys request cannot be nil")
 	}
 
 	// Validate request
@@ -197,7 +197,7 @@ func (s *APIKeyServiceImpl) UpdateAPIKey(
 	req *UpdateAPIKeyRequest,
 ) (*UpdateAPIKeyResponse, error) {
 	if req == nil {
-		return nil, errors.New("update API key request cannot be nil")
+		return nil, shared.NewFieldError("", "update API key request cannot be nil")
 	}
 
 	// Validate request
@@ -213,7 +213,7 @@ func (s *APIKeyServiceImpl) UpdateAPIKey(
 	}
 
 	if apiKey == nil {
-		return nil, errors.New("API key not found")
+		return nil, ErrAPIKeyNotFound
 	}
 
 	// Update fields
@@ -249,7 +249,7 @@ func (s *APIKeyServiceImpl) RevokeAPIKey(
 	req *RevokeAPIKeyRequest,
 ) (*RevokeAPIKeyResponse, error) {
 	if req == nil {
-		return nil, errors.New("revoke API key request cannot be nil")
+		return nil, shared.NewFieldError("", "revoke API key request cannot be nil")
 	}
 
 	// Validate request
@@ -265,7 +265,7 @@ func (s *APIKeyServiceImpl) RevokeAPIKey(
 	}
 
 	if apiKey == nil {
-		return nil, errors.New("API key not found")
+		return nil, ErrAPIKeyNotFound
 	}
 
 	// Revoke the API key
@@ -295,7 +295,7 @@ func (s *APIKeyServiceImpl) ValidateAPIKey(
 	req *ValidateAPIKeyRequest,
 ) (*ValidateAPIKeyResponse, error) {
 	if req == nil {
-		return nil, errors.New("validate API key request cannot be nil")
+		return nil, shared.NewFieldError("", "validate API key request cannot be nil")
 	}
 
 	// Validate request
@@ -326,7 +326,7 @@ func (s *APIKeyServiceImpl) ValidateAPIKey(
 	}
 
 	// Check if API key is expired
-	if apiKey.ExpiresAt() != nil && apiKey.ExpiresAt().Before(time.Now()) {
+	if apiKey.ExpiresAt() != nil && apiKey.ExpiresAt().Before(shared.Now()) {
 		return &ValidateAPIKeyResponse{Valid: false}, nil
 	}
 
@@ -0,0 +1,29 @@
+package merchant
+
+import (
+	"context"
+	"errors"
+)
+
+// Beneficiaries tells which merchants can receive shares of other merchants' settlements.
+type Beneficiaries struct {
+	repository MerchantRepository
+}
+
+// NewBeneficiaries creates a new beneficiaries reader.
+func NewBeneficiaries(repository MerchantRepository) *Beneficiaries {
+	return &Beneficiaries{repository: repository}
+}
+
+// CanReceiveSplits reports whether the merchant exists and is active. Merchants pending verification, suspended
+// or closed cannot be named as beneficiaries of new settlement splits.
+func (b *Beneficiaries) CanReceiveSplits(ctx context.Context, merchantID string) (bool, error) {
+	merchant, err := b.repository.FindByID(ctx, merchantID)
+	if errors.Is(err, ErrMerchantNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return merchant.IsActive(), nil
+}
@@ -0,0 +1,57 @@
+package merchant
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+)
+
+// AcceptedCurrencies reads the cryptocurrencies each merchant accepts from its settings.
+type AcceptedCurrencies struct {
+	repository MerchantRepository
+}
+
+// NewAcceptedCurrencies creates a new accepted currencies reader.
+func NewAcceptedCurrencies(repository MerchantRepository) *AcceptedCurrencies {
+	return &AcceptedCurrencies{repository: repository}
+}
+
+// AcceptedCurrencies returns the merchant's accepted cryptocurrencies in order of preference,
+// or nil if the merchant has not chosen any and so accepts every token.
+func (a *AcceptedCurrencies) AcceptedCurrencies(
+	ctx context.Context,
+	merchantID string,
+) ([]shared.TokenSelector, error) {
+	merchant, err := a.repository.FindByID(ctx, merchantID)
+	if errors.Is(err, ErrMerchantNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if merchant.Settings() == nil {
+		return nil, nil
+	}
+
+	currencies := merchant.Settings().AcceptedCurrencies
+	selectors := make([]shared.TokenSelector, 0, len(currencies))
+	for _, currency := range currencies {
+		selectors = append(selectors, shared.TokenSelector{
+			Symbol:  shared.CryptoCurrency(currency.Symbol),
+			Network: shared.BlockchainNetwork(currency.Network),
+		})
+	}
+	return selectors, nil
+}
+
+// TestMode returns whether the merchant is in test mode. Unknown merchants are not.
+func (a *AcceptedCurrencies) TestMode(ctx context.Context, merchantID string) (bool, error) {
+	merchant, err := a.repository.FindByID(ctx, merchantID)
+	if errors.Is(err, ErrMerchantNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return merchant.Settings() != nil && merchant.Settings().TestMode, nil
+}
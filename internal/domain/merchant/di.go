@@ -1,6 +1,9 @@
 package merchant
 
 import (
+	"crypto-checkout/internal/domain/geoblock"
+	"crypto-checkout/internal/domain/invoice"
+
 	"go.uber.org/fx"
 )
 
@@ -19,5 +22,40 @@ var Module = fx.Module("merchant-service",
 			NewWebhookEndpointService,
 			fx.As(new(WebhookEndpointService)),
 		),
+		fx.Annotate(
+			NewTeamService,
+			fx.As(new(TeamService)),
+		),
+		fx.Annotate(
+			NewSessionService,
+			fx.As(new(SessionService)),
+		),
+		fx.Annotate(
+			NewAcceptedCurrencies,
+			fx.As(new(invoice.MerchantCurrencies)),
+		),
+		fx.Annotate(
+			NewExtensionPolicies,
+			fx.As(new(invoice.MerchantExtensionPolicies)),
+		),
+		fx.Annotate(
+			NewUnderpaymentPolicies,
+			fx.As(new(invoice.MerchantUnderpaymentPolicies)),
+		),
+		fx.Annotate(
+			NewMetadataPolicies,
+			fx.As(new(invoice.MerchantMetadataPolicies)),
+		),
+		fx.Annotate(
+			NewBeneficiaries,
+			fx.As(new(invoice.MerchantBeneficiaries)),
+		),
+		fx.Annotate(
+			NewCountryRestrictions,
+			fx.As(new(geoblock.MerchantRestrictions)),
+		),
+		NewCheckoutLocales,
+		NewReportingTimeZones,
+		NewAllowedOrigins,
 	),
 )
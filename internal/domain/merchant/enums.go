@@ -93,3 +93,60 @@ func (b BackoffStrategy) IsValid() bool {
 		return false
 	}
 }
+
+// UserRole represents the role of a team member within a merchant account.
+type UserRole string
+
+const (
+	RoleOwner     UserRole = "owner"
+	RoleAdmin     UserRole = "admin"
+	RoleDeveloper UserRole = "developer"
+	RoleViewer    UserRole = "viewer"
+)
+
+// UserStatus represents the current status of a team member.
+type UserStatus string
+
+const (
+	UserStatusActive   UserStatus = "active"
+	UserStatusDisabled UserStatus = "disabled"
+)
+
+// InvitationStatus represents the current status of a team invitation.
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+)
+
+// IsValid validates if the user role is valid.
+func (r UserRole) IsValid() bool {
+	switch r {
+	case RoleOwner, RoleAdmin, RoleDeveloper, RoleViewer:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValid validates if the user status is valid.
+func (s UserStatus) IsValid() bool {
+	switch s {
+	case UserStatusActive, UserStatusDisabled:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValid validates if the invitation status is valid.
+func (s InvitationStatus) IsValid() bool {
+	switch s {
+	case InvitationStatusPending, InvitationStatusAccepted, InvitationStatusRevoked:
+		return true
+	default:
+		return false
+	}
+}
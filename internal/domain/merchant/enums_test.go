@@ -109,3 +109,25 @@ func TestBackoffStrategy_IsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRole_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     UserRole
+		expected bool
+	}{
+		{"owner", RoleOwner, true},
+		{"admin", RoleAdmin, true},
+		{"developer", RoleDeveloper, true},
+		{"viewer", RoleViewer, true},
+		{"invalid", UserRole("invalid"), false},
+		{"empty", UserRole(""), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.role.IsValid()
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
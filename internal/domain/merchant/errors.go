@@ -1,46 +1,97 @@
 package merchant
 
-import "errors"
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+)
 
 // Domain errors for merchant operations
 var (
 	// Merchant creation errors
-	ErrInvalidMerchantID       = errors.New("invalid merchant ID")
-	ErrInvalidBusinessName     = errors.New("invalid business name")
-	ErrInvalidContactEmail     = errors.New("invalid contact email")
-	ErrInvalidMerchantSettings = errors.New("invalid merchant settings")
-	ErrMerchantAlreadyExists   = errors.New("merchant already exists")
-	ErrMerchantNotFound        = errors.New("merchant not found")
+	ErrInvalidMerchantID   = shared.NewError(shared.ErrValidation, ErrCodeInvalidMerchantID, "invalid merchant ID")
+	ErrInvalidBusinessName = shared.NewError(shared.ErrValidation, ErrCodeInvalidBusinessName,
+		"invalid business name")
+	ErrInvalidContactEmail = shared.NewError(shared.ErrValidation, ErrCodeInvalidContactEmail,
+		"invalid contact email")
+	ErrInvalidMerchantSettings = shared.NewError(shared.ErrValidation, ErrCodeInvalidMerchantSettings,
+		"invalid merchant settings")
+	ErrMerchantAlreadyExists = shared.NewError(shared.ErrConflict, ErrCodeMerchantAlreadyExists,
+		"merchant already exists")
+	ErrMerchantNotFound = shared.NewError(shared.ErrNotFound, ErrCodeMerchantNotFound, "merchant not found")
 
 	// API key errors
-	ErrInvalidAPIKeyID     = errors.New("invalid API key ID")
-	ErrInvalidAPIKeyType   = errors.New("invalid API key type")
-	ErrInvalidPermissions  = errors.New("invalid permissions")
-	ErrAPIKeyNotFound      = errors.New("API key not found")
-	ErrAPIKeyAlreadyExists = errors.New("API key already exists")
-	ErrAPIKeyLimitExceeded = errors.New("API key limit exceeded")
-	ErrAPIKeyNotActive     = errors.New("API key is not active")
-	ErrAPIKeyExpired       = errors.New("API key has expired")
+	ErrInvalidAPIKeyID     = shared.NewError(shared.ErrValidation, ErrCodeInvalidAPIKeyID, "invalid API key ID")
+	ErrInvalidAPIKeyType   = shared.NewError(shared.ErrValidation, ErrCodeInvalidAPIKeyType, "invalid API key type")
+	ErrInvalidPermissions  = shared.NewError(shared.ErrValidation, ErrCodeInvalidPermissions, "invalid permissions")
+	ErrAPIKeyNotFound      = shared.NewError(shared.ErrNotFound, ErrCodeAPIKeyNotFound, "API key not found")
+	ErrAPIKeyAlreadyExists = shared.NewError(shared.ErrConflict, ErrCodeAPIKeyAlreadyExists, "API key already exists")
+	ErrAPIKeyLimitExceeded = shared.NewError(shared.ErrConflict, ErrCodeAPIKeyLimitExceeded, "API key limit exceeded")
+	ErrAPIKeyNotActive     = shared.NewError(shared.ErrInvalidState, ErrCodeAPIKeyNotActive, "API key is not active")
+	ErrAPIKeyExpired       = shared.NewError(shared.ErrInvalidState, ErrCodeAPIKeyExpired, "API key has expired")
 
 	// Webhook endpoint errors
-	ErrInvalidWebhookEndpointID     = errors.New("invalid webhook endpoint ID")
-	ErrInvalidWebhookURL            = errors.New("invalid webhook URL")
-	ErrInvalidWebhookSecret         = errors.New("invalid webhook secret")
-	ErrInvalidWebhookEvents         = errors.New("invalid webhook events")
-	ErrWebhookEndpointNotFound      = errors.New("webhook endpoint not found")
-	ErrWebhookEndpointLimitExceeded = errors.New("webhook endpoint limit exceeded")
+	ErrInvalidWebhookEndpointID = shared.NewError(shared.ErrValidation, ErrCodeInvalidWebhookEndpointID,
+		"invalid webhook endpoint ID")
+	ErrInvalidWebhookURL = shared.NewError(shared.ErrValidation, ErrCodeInvalidWebhookURL,
+		"invalid webhook URL")
+	ErrInvalidWebhookSecret = shared.NewError(shared.ErrValidation, ErrCodeInvalidWebhookSecret,
+		"invalid webhook secret")
+	ErrInvalidWebhookEvents = shared.NewError(shared.ErrValidation, ErrCodeInvalidWebhookEvents,
+		"invalid webhook events")
+	ErrWebhookEndpointNotFound = shared.NewError(shared.ErrNotFound, ErrCodeWebhookEndpointNotFound,
+		"webhook endpoint not found")
+	ErrWebhookEndpointLimitExceeded = shared.NewError(shared.ErrConflict, ErrCodeWebhookEndpointLimitExceeded,
+		"webhook endpoint limit exceeded")
+	ErrUnsupportedWebhookAPIVersion = shared.NewError(shared.ErrValidation, ErrCodeUnsupportedWebhookAPIVersion,
+		"unsupported webhook API version")
+
+	// Team member errors
+	ErrInvalidUserEmail  = shared.NewError(shared.ErrValidation, ErrCodeInvalidContactEmail, "invalid user email")
+	ErrInvalidRole       = shared.NewError(shared.ErrValidation, ErrCodeInvalidRole, "invalid role")
+	ErrUserNotFound      = shared.NewError(shared.ErrNotFound, ErrCodeUserNotFound, "user not found")
+	ErrUserAlreadyExists = shared.NewError(shared.ErrConflict, ErrCodeUserAlreadyExists, "user already exists")
+	ErrLastOwner         = shared.NewError(shared.ErrInvalidState, ErrCodeLastOwner,
+		"merchant must keep at least one owner")
+	ErrRoleNotAssignable  = errors.New("role cannot be assigned by this user")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrInvitationNotFound = shared.NewError(shared.ErrNotFound, ErrCodeInvitationNotFound, "invitation not found")
+	ErrInvitationExpired  = shared.NewError(shared.ErrInvalidState, ErrCodeInvitationExpired,
+		"invitation has expired")
+	ErrInvitationNotPending = shared.NewError(shared.ErrInvalidState, ErrCodeInvitationNotPending,
+		"invitation is not pending")
+
+	// Session errors
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrWeakPassword       = shared.NewError(shared.ErrValidation, ErrCodeWeakPassword, "password is too weak")
+	ErrTOTPRequired       = errors.New("two-factor authentication code required")
+	ErrInvalidTOTPCode    = errors.New("invalid two-factor authentication code")
+	ErrTOTPNotConfigured  = errors.New("two-factor authentication is not configured")
+	ErrTOTPAlreadyEnabled = shared.NewError(shared.ErrConflict, ErrCodeTOTPAlreadyEnabled,
+		"two-factor authentication is already enabled; disable it with your password first")
+	ErrMerchantRequired = shared.NewError(shared.ErrValidation, ErrCodeMerchantRequired,
+		"merchant must be specified for this account")
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenExpired  = errors.New("refresh token has expired")
+	ErrRefreshTokenReused   = errors.New("refresh token reuse detected")
+	ErrRefreshTokenRevoked  = errors.New("refresh token has been revoked")
+	ErrInvalidSession       = errors.New("session is invalid or has expired")
 
 	// Business rule errors
-	ErrMerchantNotActive           = errors.New("merchant is not active")
-	ErrMerchantSuspended           = errors.New("merchant is suspended")
-	ErrMerchantPendingVerification = errors.New("merchant is pending verification")
-	ErrPlanLimitExceeded           = errors.New("plan limit exceeded")
-	ErrInvalidStatusTransition     = errors.New("invalid status transition")
+	ErrMerchantNotActive = shared.NewError(shared.ErrInvalidState, ErrCodeMerchantNotActive,
+		"merchant is not active")
+	ErrMerchantSuspended = shared.NewError(shared.ErrInvalidState, ErrCodeMerchantSuspended,
+		"merchant is suspended")
+	ErrMerchantPendingVerification = shared.NewError(shared.ErrInvalidState, ErrCodeMerchantPendingVerification,
+		"merchant is pending verification")
+	ErrPlanLimitExceeded = shared.NewError(shared.ErrConflict, ErrCodePlanLimitExceeded,
+		"plan limit exceeded")
+	ErrInvalidStatusTransition = shared.NewError(shared.ErrInvalidState, ErrCodeInvalidStatusTransition,
+		"invalid status transition")
 
 	// Validation errors
-	ErrValidationFailed = errors.New("validation failed")
-	ErrRequiredField    = errors.New("required field is missing")
-	ErrInvalidFormat    = errors.New("invalid format")
+	ErrValidationFailed = shared.ErrValidationFailed
+	ErrRequiredField    = shared.NewError(shared.ErrValidation, ErrCodeRequiredField, "required field is missing")
+	ErrInvalidFormat    = shared.NewError(shared.ErrValidation, ErrCodeInvalidFormat, "invalid format")
 )
 
 // Error codes for API responses
@@ -67,6 +118,24 @@ const (
 	ErrCodeInvalidWebhookEvents         = "INVALID_WEBHOOK_EVENTS"
 	ErrCodeWebhookEndpointNotFound      = "WEBHOOK_ENDPOINT_NOT_FOUND"
 	ErrCodeWebhookEndpointLimitExceeded = "WEBHOOK_ENDPOINT_LIMIT_EXCEEDED"
+	ErrCodeUnsupportedWebhookAPIVersion = "UNSUPPORTED_WEBHOOK_API_VERSION"
+
+	ErrCodeInvalidRole          = "INVALID_ROLE"
+	ErrCodeUserNotFound         = "USER_NOT_FOUND"
+	ErrCodeUserAlreadyExists    = "USER_ALREADY_EXISTS"
+	ErrCodeLastOwner            = "LAST_OWNER"
+	ErrCodePermissionDenied     = "PERMISSION_DENIED"
+	ErrCodeInvitationNotFound   = "INVITATION_NOT_FOUND"
+	ErrCodeInvitationExpired    = "INVITATION_EXPIRED"
+	ErrCodeInvitationNotPending = "INVITATION_NOT_PENDING"
+
+	ErrCodeInvalidCredentials  = "INVALID_CREDENTIALS"
+	ErrCodeWeakPassword        = "WEAK_PASSWORD"
+	ErrCodeTOTPRequired        = "TOTP_REQUIRED"
+	ErrCodeInvalidTOTPCode     = "INVALID_TOTP_CODE"
+	ErrCodeTOTPAlreadyEnabled  = "TOTP_ALREADY_ENABLED"
+	ErrCodeMerchantRequired    = "MERCHANT_REQUIRED"
+	ErrCodeInvalidRefreshToken = "INVALID_REFRESH_TOKEN" //nolint:gosec // This is an error code constant, not a credential
 
 	ErrCodeMerchantNotActive           = "MERCHANT_NOT_ACTIVE"
 	ErrCodeMerchantSuspended           = "MERCHANT_SUSPENDED"
@@ -0,0 +1,36 @@
+package merchant
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"errors"
+	"time"
+)
+
+// ExtensionPolicies reads the limits on extending invoices each merchant has set in its settings.
+type ExtensionPolicies struct {
+	repository MerchantRepository
+}
+
+// NewExtensionPolicies creates a new extension policies reader.
+func NewExtensionPolicies(repository MerchantRepository) *ExtensionPolicies {
+	return &ExtensionPolicies{repository: repository}
+}
+
+// ExtensionPolicy returns the merchant's limits on extending invoices, or invoice.DefaultExtensionPolicy if the
+// merchant has not set any.
+func (p *ExtensionPolicies) ExtensionPolicy(ctx context.Context, merchantID string) (invoice.ExtensionPolicy, error) {
+	merchant, err := p.repository.FindByID(ctx, merchantID)
+	if errors.Is(err, ErrMerchantNotFound) {
+		return invoice.DefaultExtensionPolicy(), nil
+	}
+	if err != nil {
+		return invoice.ExtensionPolicy{}, err
+	}
+	if merchant.Settings() == nil || merchant.Settings().MaxInvoiceExtensionMinutes == nil {
+		return invoice.DefaultExtensionPolicy(), nil
+	}
+
+	minutes := *merchant.Settings().MaxInvoiceExtensionMinutes
+	return invoice.ExtensionPolicy{MaxExtension: time.Duration(minutes) * time.Minute}, nil
+}
@@ -0,0 +1,162 @@
+package merchant
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultInvitationTTL is how long an invitation token stays valid.
+const DefaultInvitationTTL = 7 * 24 * time.Hour
+
+// Invitation represents a pending invite for a new team member.
+type Invitation struct {
+	id         string
+	merchantID string
+	email      string
+	role       UserRole
+	tokenHash  *APIKeyHash
+	invitedBy  string
+	status     InvitationStatus
+	expiresAt  time.Time
+	createdAt  time.Time
+	acceptedAt *time.Time
+}
+
+// NewInvitation creates a new pending invitation for the given raw token.
+func NewInvitation(id, merchantID, email string, role UserRole, rawToken, invitedBy string) (*Invitation, error) {
+	tokenHash, err := NewAPIKeyHash(rawToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash invitation token: %w", err)
+	}
+
+	now := shared.Now()
+	return RestoreInvitation(
+		id, merchantID, email, role, tokenHash, invitedBy,
+		InvitationStatusPending, now.Add(DefaultInvitationTTL), now, nil,
+	)
+}
+
+// RestoreInvitation recreates an invitation from storage.
+func RestoreInvitation(
+	id, merchantID, email string,
+	role UserRole,
+	tokenHash *APIKeyHash,
+	invitedBy string,
+	status InvitationStatus,
+	expiresAt, createdAt time.Time,
+	acceptedAt *time.Time,
+) (*Invitation, error) {
+	if id == "" {
+		return nil, shared.NewFieldError("id", "invitation ID is required")
+	}
+	if merchantID == "" {
+		return nil, shared.NewFieldError("merchant_id", "merchant ID is required")
+	}
+	if !isValidEmail(email) {
+		return nil, ErrInvalidUserEmail
+	}
+	if !role.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRole, role)
+	}
+	if tokenHash == nil {
+		return nil, shared.NewFieldError("token_hash", "token hash is required")
+	}
+	if !status.IsValid() {
+		return nil, fmt.Errorf("invalid invitation status: %s", status)
+	}
+
+	return &Invitation{
+		id:         id,
+		merchantID: merchantID,
+		email:      strings.ToLower(email),
+		role:       role,
+		tokenHash:  tokenHash,
+		invitedBy:  invitedBy,
+		status:     status,
+		expiresAt:  expiresAt,
+		createdAt:  createdAt,
+		acceptedAt: acceptedAt,
+	}, nil
+}
+
+// ID returns the invitation ID.
+func (i *Invitation) ID() string {
+	return i.id
+}
+
+// MerchantID returns the merchant ID.
+func (i *Invitation) MerchantID() string {
+	return i.merchantID
+}
+
+// Email returns the invitee email.
+func (i *Invitation) Email() string {
+	return i.email
+}
+
+// Role returns the role granted on acceptance.
+func (i *Invitation) Role() UserRole {
+	return i.role
+}
+
+// TokenHash returns the hashed invitation token.
+func (i *Invitation) TokenHash() *APIKeyHash {
+	return i.tokenHash
+}
+
+// InvitedBy returns the ID of the user who sent the invitation.
+func (i *Invitation) InvitedBy() string {
+	return i.invitedBy
+}
+
+// Status returns the invitation status.
+func (i *Invitation) Status() InvitationStatus {
+	return i.status
+}
+
+// ExpiresAt returns the expiration timestamp.
+func (i *Invitation) ExpiresAt() time.Time {
+	return i.expiresAt
+}
+
+// CreatedAt returns the creation timestamp.
+func (i *Invitation) CreatedAt() time.Time {
+	return i.createdAt
+}
+
+// AcceptedAt returns the acceptance timestamp.
+func (i *Invitation) AcceptedAt() *time.Time {
+	return i.acceptedAt
+}
+
+// IsExpired checks if the invitation is past its expiration.
+func (i *Invitation) IsExpired() bool {
+	return shared.Now().After(i.expiresAt)
+}
+
+// Accept marks the invitation as accepted.
+func (i *Invitation) Accept() error {
+	if i.status != InvitationStatusPending {
+		return ErrInvitationNotPending
+	}
+	if i.IsExpired() {
+		return ErrInvitationExpired
+	}
+
+	now := shared.Now()
+	i.status = InvitationStatusAccepted
+	i.acceptedAt = &now
+	return nil
+}
+
+// Revoke cancels a pending invitation.
+func (i *Invitation) Revoke() error {
+	if i.status != InvitationStatusPending {
+		return ErrInvitationNotPending
+	}
+
+	i.status = InvitationStatusRevoked
+	return nil
+}
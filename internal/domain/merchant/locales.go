@@ -0,0 +1,38 @@
+package merchant
+
+import (
+	"context"
+	"crypto-checkout/pkg/i18n"
+	"errors"
+)
+
+// CheckoutLocales reads the checkout page language each merchant has chosen from its settings.
+type CheckoutLocales struct {
+	repository MerchantRepository
+}
+
+// NewCheckoutLocales creates a new checkout locales reader.
+func NewCheckoutLocales(repository MerchantRepository) *CheckoutLocales {
+	return &CheckoutLocales{repository: repository}
+}
+
+// DefaultLocale returns the merchant's default checkout locale, or i18n.DefaultLocale if the merchant has not
+// chosen one.
+func (l *CheckoutLocales) DefaultLocale(ctx context.Context, merchantID string) (i18n.Locale, error) {
+	merchant, err := l.repository.FindByID(ctx, merchantID)
+	if errors.Is(err, ErrMerchantNotFound) {
+		return i18n.DefaultLocale, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if merchant.Settings() == nil {
+		return i18n.DefaultLocale, nil
+	}
+
+	locale, ok := i18n.ParseLocale(merchant.Settings().DefaultLocale)
+	if !ok {
+		return i18n.DefaultLocale, nil
+	}
+	return locale, nil
+}
@@ -1,7 +1,7 @@
 package merchant
 
 import (
-	"errors"
+	"crypto-checkout/internal/domain/shared"
 	"fmt"
 	"time"
 
@@ -34,19 +34,19 @@ func NewMerchant(
 	settings *MerchantSettings,
 ) (*Merchant, error) {
 	if id == "" {
-		return nil, errors.New("merchant ID is required")
+		return nil, shared.NewFieldError("merchant_id", "merchant ID is required")
 	}
 	if businessName == "" {
-		return nil, errors.New("business name is required")
+		return nil, shared.NewFieldError("business_name", "business name is required")
 	}
 	if contactEmail == "" {
-		return nil, errors.New("contact email is required")
+		return nil, shared.NewFieldError("contact_email", "contact email is required")
 	}
 	if settings == nil {
-		return nil, errors.New("merchant settings are required")
+		return nil, shared.NewFieldError("settings", "merchant settings are required")
 	}
 
-	now := time.Now()
+	now := shared.Now()
 	merchant := &Merchant{
 		id:           id,
 		businessName: businessName,
@@ -112,41 +112,44 @@ func (m *Merchant) UpdatedAt() time.Time {
 // UpdateBusinessName updates the business name.
 func (m *Merchant) UpdateBusinessName(name string) error {
 	if name == "" {
-		return errors.New("business name cannot be empty")
+		return shared.NewFieldError("business_name", "business name cannot be empty")
 	}
 	if len(name) < 2 || len(name) > 255 {
-		return errors.New("business name must be between 2 and 255 characters")
+		return shared.NewFieldError("business_name", "business name must be between 2 and 255 characters")
 	}
 
 	m.businessName = name
-	m.updatedAt = time.Now()
+	m.updatedAt = shared.Now()
 	return nil
 }
 
 // UpdateContactEmail updates the contact email.
 func (m *Merchant) UpdateContactEmail(email string) error {
 	if email == "" {
-		return errors.New("contact email cannot be empty")
+		return shared.NewFieldError("contact_email", "contact email cannot be empty")
 	}
 
 	// Basic email validation
 	if !isValidEmail(email) {
-		return errors.New("invalid email format")
+		return shared.NewFieldError("contact_email", "invalid email format")
 	}
 
 	m.contactEmail = email
-	m.updatedAt = time.Now()
+	m.updatedAt = shared.Now()
 	return nil
 }
 
 // UpdateSettings updates the merchant settings.
 func (m *Merchant) UpdateSettings(settings *MerchantSettings) error {
 	if settings == nil {
-		return errors.New("settings cannot be nil")
+		return shared.NewFieldError("settings", "settings cannot be nil")
+	}
+	if err := settings.Validate(); err != nil {
+		return err
 	}
 
 	m.settings = settings
-	m.updatedAt = time.Now()
+	m.updatedAt = shared.Now()
 	return nil
 }
 
@@ -158,11 +161,12 @@ func (m *Merchant) ChangeStatus(newStatus MerchantStatus) error {
 
 	// Business rule: cannot change to active without verification
 	if newStatus == StatusActive && m.status != StatusPendingVerification {
-		return errors.New("merchant must be in pending verification status to be activated")
+		return shared.NewError(shared.ErrInvalidState, ErrCodeInvalidStatusTransition,
+			"merchant must be in pending verification status to be activated")
 	}
 
 	m.status = newStatus
-	m.updatedAt = time.Now()
+	m.updatedAt = shared.Now()
 	return nil
 }
 
@@ -174,7 +178,8 @@ func (m *Merchant) IsActive() bool {
 // CanCreateAPIKey checks if the merchant can create a new API key.
 func (m *Merchant) CanCreateAPIKey(currentCount int) error {
 	if !m.IsActive() {
-		return errors.New("merchant must be active to create API keys")
+		return shared.NewError(shared.ErrInvalidState, ErrCodeMerchantNotActive,
+			"merchant must be active to create API keys")
 	}
 
 	// For now, allow unlimited API keys - this can be configured via settings later
@@ -185,7 +190,8 @@ func (m *Merchant) CanCreateAPIKey(currentCount int) error {
 // CanCreateWebhookEndpoint checks if the merchant can create a new webhook endpoint.
 func (m *Merchant) CanCreateWebhookEndpoint(currentCount int) error {
 	if !m.IsActive() {
-		return errors.New("merchant must be active to create webhook endpoints")
+		return shared.NewError(shared.ErrInvalidState, ErrCodeMerchantNotActive,
+			"merchant must be active to create webhook endpoints")
 	}
 
 	// For now, allow unlimited webhook endpoints - this can be configured via settings later
@@ -2,9 +2,9 @@ package merchant
 
 import (
 	"context"
+	"crypto-checkout/internal/domain/shared"
 	"crypto/rand"
 	"encoding/hex"
-	"errors"
 	"fmt"
 
 	"github.com/go-playground/validator/v10"
@@ -31,7 +31,7 @@ func (s *MerchantServiceImpl) CreateMerchant(
 	req *CreateMerchantRequest,
 ) (*CreateMerchantResponse, error) {
 	if req == nil {
-		return nil, errors.New("create merchant request cannot be nil")
+		return nil, shared.NewFieldError("", "create merchant request cannot be nil")
 	}
 
 	// Validate request
@@ -43,7 +43,8 @@ func (s *MerchantServiceImpl) CreateMerchant(
 	// Check if merchant with email already exists
 	existingMerchant, err := s.merchantRepo.FindByEmail(ctx, req.ContactEmail)
 	if err == nil && existingMerchant != nil {
-		return nil, errors.New("merchant with this email already exists")
+		return nil, shared.NewError(shared.ErrConflict, ErrCodeMerchantAlreadyExists,
+			"merchant with this email already exists")
 	}
 
 	// Generate merchant ID
@@ -82,7 +83,7 @@ func (s *MerchantServiceImpl) CreateMerchant(
 // GetMerchant retrieves a merchant by ID.
 func (s *MerchantServiceImpl) GetMerchant(ctx context.Context, req *GetMerchantRequest) (*GetMerchantResponse, error) {
 	if req == nil {
-		return nil, errors.New("get merchant request cannot be nil")
+		return nil, shared.NewFieldError("", "get merchant request cannot be nil")
 	}
 
 	// Validate request
@@ -108,7 +109,7 @@ func (s *MerchantServiceImpl) UpdateMerchant(
 	req *UpdateMerchantRequest,
 ) (*UpdateMerchantResponse, error) {
 	if req == nil {
-		return nil, errors.New("update merchant request cannot be nil")
+		return nil, shared.NewFieldError("", "update merchant request cannot be nil")
 	}
 
 	// Validate request
@@ -134,7 +135,8 @@ func (s *MerchantServiceImpl) UpdateMerchant(
 		// Check if email is already taken by another merchant
 		existingMerchant, err := s.merchantRepo.FindByEmail(ctx, *req.ContactEmail)
 		if err == nil && existingMerchant != nil && existingMerchant.ID() != merchant.ID() {
-			return nil, errors.New("email is already taken by another merchant")
+			return nil, shared.NewError(shared.ErrConflict, ErrCodeMerchantAlreadyExists,
+				"email is already taken by another merchant")
 		}
 
 		if err := merchant.UpdateContactEmail(*req.ContactEmail); err != nil {
@@ -168,7 +170,7 @@ func (s *MerchantServiceImpl) ChangeMerchantStatus(
 	req *ChangeMerchantStatusRequest,
 ) (*ChangeMerchantStatusResponse, error) {
 	if req == nil {
-		return nil, errors.New("change merchant status request cannot be nil")
+		return nil, shared.NewFieldError("", "change merchant status request cannot be nil")
 	}
 
 	// Validate request
@@ -210,7 +212,7 @@ func (s *MerchantServiceImpl) ListMerchants(
 	req *ListMerchantsRequest,
 ) (*ListMerchantsResponse, error) {
 	if req == nil {
-		return nil, errors.New("list merchants request cannot be nil")
+		return nil, shared.NewFieldError("", "list merchants request cannot be nil")
 	}
 
 	// Set default values
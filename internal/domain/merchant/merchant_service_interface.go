@@ -2,6 +2,8 @@ package merchant
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 )
 
 // MerchantService defines the interface for merchant business operations.
@@ -73,6 +75,60 @@ type WebhookEndpointService interface {
 	TestWebhookEndpoint(ctx context.Context, req *TestWebhookEndpointRequest) (*TestWebhookEndpointResponse, error)
 }
 
+// TeamService defines the interface for merchant team membership and role operations.
+type TeamService interface {
+	// InviteUser invites a new team member to a merchant.
+	InviteUser(ctx context.Context, req *InviteUserRequest) (*InviteUserResponse, error)
+
+	// AcceptInvitation accepts an invitation and creates the team member.
+	AcceptInvitation(ctx context.Context, req *AcceptInvitationRequest) (*AcceptInvitationResponse, error)
+
+	// ListInvitations lists invitations for a merchant.
+	ListInvitations(ctx context.Context, req *ListInvitationsRequest) (*ListInvitationsResponse, error)
+
+	// RevokeInvitation revokes a pending invitation.
+	RevokeInvitation(ctx context.Context, req *RevokeInvitationRequest) (*RevokeInvitationResponse, error)
+
+	// GetUser retrieves a team member by ID.
+	GetUser(ctx context.Context, req *GetUserRequest) (*GetUserResponse, error)
+
+	// ListUsers lists team members for a merchant.
+	ListUsers(ctx context.Context, req *ListUsersRequest) (*ListUsersResponse, error)
+
+	// ChangeUserRole changes the role of a team member.
+	ChangeUserRole(ctx context.Context, req *ChangeUserRoleRequest) (*ChangeUserRoleResponse, error)
+
+	// RemoveUser removes a team member from a merchant.
+	RemoveUser(ctx context.Context, req *RemoveUserRequest) (*RemoveUserResponse, error)
+
+	// CheckPermission checks whether a team member holds a permission for a merchant.
+	CheckPermission(ctx context.Context, req *CheckPermissionRequest) (*CheckPermissionResponse, error)
+}
+
+// SessionService defines the interface for dashboard user authentication.
+type SessionService interface {
+	// Login verifies user credentials and starts a new session.
+	Login(ctx context.Context, req *LoginRequest) (*SessionResponse, error)
+
+	// Refresh rotates a refresh token and continues the session.
+	Refresh(ctx context.Context, req *RefreshSessionRequest) (*SessionResponse, error)
+
+	// Logout revokes the session of a refresh token.
+	Logout(ctx context.Context, req *LogoutRequest) error
+
+	// Authenticate checks that the user of a session access token is still active with the session's role.
+	Authenticate(ctx context.Context, req *AuthenticateSessionRequest) (*User, error)
+
+	// SetupTOTP generates a pending TOTP secret for a user.
+	SetupTOTP(ctx context.Context, req *SetupTOTPRequest) (*SetupTOTPResponse, error)
+
+	// EnableTOTP confirms the pending TOTP secret and enables two-factor authentication.
+	EnableTOTP(ctx context.Context, req *EnableTOTPRequest) error
+
+	// DisableTOTP disables two-factor authentication for a user.
+	DisableTOTP(ctx context.Context, req *DisableTOTPRequest) error
+}
+
 // Request/Response DTOs for Merchant operations
 
 // CreateMerchantRequest represents the request to create a merchant.
@@ -209,11 +265,12 @@ type CreateWebhookEndpointRequest struct {
 	URL          string            `json:"url"                   validate:"required,url"`
 	Events       []string          `json:"events"                validate:"required,min=1"`
 	Secret       string            `json:"secret"                validate:"required,min=32"`
-	MaxRetries   int               `json:"max_retries"           validate:"min=0,max=10"`
-	RetryBackoff string            `json:"retry_backoff"         validate:"required"`
+	MaxRetries   *int              `json:"max_retries,omitempty" validate:"omitempty,min=0,max=10"`
+	RetryBackoff string            `json:"retry_backoff,omitempty"`
 	Timeout      int               `json:"timeout"               validate:"min=5,max=60"`
 	AllowedIPs   []string          `json:"allowed_ips,omitempty"`
 	Headers      map[string]string `json:"headers,omitempty"`
+	APIVersion   string            `json:"api_version,omitempty"`
 }
 
 // CreateWebhookEndpointResponse represents the response from creating a webhook endpoint.
@@ -258,6 +315,7 @@ type UpdateWebhookEndpointRequest struct {
 	Timeout      *int              `json:"timeout,omitempty"       validate:"omitempty,min=5,max=60"`
 	AllowedIPs   []string          `json:"allowed_ips,omitempty"`
 	Headers      map[string]string `json:"headers,omitempty"`
+	APIVersion   *string           `json:"api_version,omitempty"`
 }
 
 // UpdateWebhookEndpointResponse represents the response from updating a webhook endpoint.
@@ -282,8 +340,185 @@ type TestWebhookEndpointRequest struct {
 
 // TestWebhookEndpointResponse represents the response from testing a webhook endpoint.
 type TestWebhookEndpointResponse struct {
-	Success      bool   `json:"success"`
-	ResponseCode int    `json:"response_code"`
-	ResponseTime int    `json:"response_time_ms"`
-	Error        string `json:"error,omitempty"`
+	Success      bool            `json:"success"`
+	ResponseCode int             `json:"response_code"`
+	ResponseTime int             `json:"response_time_ms"`
+	Error        string          `json:"error,omitempty"`
+	Payload      json.RawMessage `json:"payload,omitempty"` // The test webhook, in the endpoint's API version
+}
+
+// Request/Response DTOs for Team operations
+//
+// Actor user IDs (InvitedBy, ChangedBy, ...) are empty when the action is
+// performed with an API key; role restrictions then do not apply and access is
+// governed by the API key permissions instead.
+
+// InviteUserRequest represents the request to invite a team member.
+type InviteUserRequest struct {
+	MerchantID string   `json:"merchant_id"          validate:"required"`
+	Email      string   `json:"email"                validate:"required,email"`
+	Role       UserRole `json:"role"                 validate:"required"`
+	InvitedBy  string   `json:"invited_by,omitempty"`
+}
+
+// InviteUserResponse represents the response from inviting a team member.
+type InviteUserResponse struct {
+	Invitation *Invitation `json:"invitation"`
+	Token      string      `json:"token"` // Only returned once during creation
+}
+
+// AcceptInvitationRequest represents the request to accept an invitation.
+type AcceptInvitationRequest struct {
+	Token    string `json:"token"          validate:"required"`
+	Name     string `json:"name,omitempty" validate:"omitempty,max=255"`
+	Password string `json:"password"       validate:"required,min=8,max=72"`
+}
+
+// AcceptInvitationResponse represents the response from accepting an invitation.
+type AcceptInvitationResponse struct {
+	User *User `json:"user"`
+}
+
+// ListInvitationsRequest represents the request to list invitations.
+type ListInvitationsRequest struct {
+	MerchantID string `json:"merchant_id" validate:"required"`
+}
+
+// ListInvitationsResponse represents the response from listing invitations.
+type ListInvitationsResponse struct {
+	Invitations []*Invitation `json:"invitations"`
+	Total       int           `json:"total"`
+}
+
+// RevokeInvitationRequest represents the request to revoke an invitation.
+type RevokeInvitationRequest struct {
+	MerchantID   string `json:"merchant_id"          validate:"required"`
+	InvitationID string `json:"invitation_id"        validate:"required"`
+	RevokedBy    string `json:"revoked_by,omitempty"`
+}
+
+// RevokeInvitationResponse represents the response from revoking an invitation.
+type RevokeInvitationResponse struct {
+	Invitation *Invitation `json:"invitation"`
+}
+
+// GetUserRequest represents the request to get a team member.
+type GetUserRequest struct {
+	MerchantID string `json:"merchant_id" validate:"required"`
+	UserID     string `json:"user_id"     validate:"required"`
+}
+
+// GetUserResponse represents the response from getting a team member.
+type GetUserResponse struct {
+	User *User `json:"user"`
+}
+
+// ListUsersRequest represents the request to list team members.
+type ListUsersRequest struct {
+	MerchantID string `json:"merchant_id" validate:"required"`
+}
+
+// ListUsersResponse represents the response from listing team members.
+type ListUsersResponse struct {
+	Users []*User `json:"users"`
+	Total int     `json:"total"`
+}
+
+// ChangeUserRoleRequest represents the request to change a team member role.
+type ChangeUserRoleRequest struct {
+	MerchantID string   `json:"merchant_id"          validate:"required"`
+	UserID     string   `json:"user_id"              validate:"required"`
+	Role       UserRole `json:"role"                 validate:"required"`
+	ChangedBy  string   `json:"changed_by,omitempty"`
+}
+
+// ChangeUserRoleResponse represents the response from changing a team member role.
+type ChangeUserRoleResponse struct {
+	User *User `json:"user"`
+}
+
+// RemoveUserRequest represents the request to remove a team member.
+type RemoveUserRequest struct {
+	MerchantID string `json:"merchant_id"          validate:"required"`
+	UserID     string `json:"user_id"              validate:"required"`
+	RemovedBy  string `json:"removed_by,omitempty"`
+}
+
+// RemoveUserResponse represents the response from removing a team member.
+type RemoveUserResponse struct {
+	Success bool `json:"success"`
+}
+
+// CheckPermissionRequest represents the request to check a team member permission.
+type CheckPermissionRequest struct {
+	MerchantID string `json:"merchant_id" validate:"required"`
+	UserID     string `json:"user_id"     validate:"required"`
+	Permission string `json:"permission"  validate:"required"`
+}
+
+// CheckPermissionResponse represents the response from checking a team member permission.
+type CheckPermissionResponse struct {
+	Allowed bool  `json:"allowed"`
+	User    *User `json:"user,omitempty"`
+}
+
+// Request/Response DTOs for Session operations
+
+// LoginRequest represents the request to log in a dashboard user.
+type LoginRequest struct {
+	Email      string `json:"email"                 validate:"required,email"`
+	Password   string `json:"password"              validate:"required"`
+	TOTPCode   string `json:"totp_code,omitempty"`
+	MerchantID string `json:"merchant_id,omitempty"`
+}
+
+// RefreshSessionRequest represents the request to rotate a refresh token.
+type RefreshSessionRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// AuthenticateSessionRequest represents the identity carried by a session access token.
+type AuthenticateSessionRequest struct {
+	UserID     string   `json:"user_id"     validate:"required"`
+	MerchantID string   `json:"merchant_id" validate:"required"`
+	Role       UserRole `json:"role"        validate:"required"`
+}
+
+// LogoutRequest represents the request to end a session.
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// SessionResponse represents an authenticated dashboard session.
+type SessionResponse struct {
+	User             *User     `json:"user"`
+	RefreshToken     string    `json:"refresh_token"` // Only returned once per rotation
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// SetupTOTPRequest represents the request to start TOTP enrollment.
+type SetupTOTPRequest struct {
+	MerchantID string `json:"merchant_id" validate:"required"`
+	UserID     string `json:"user_id"     validate:"required"`
+	Issuer     string `json:"issuer"`
+}
+
+// SetupTOTPResponse represents the pending TOTP enrollment.
+type SetupTOTPResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// EnableTOTPRequest represents the request to confirm TOTP enrollment.
+type EnableTOTPRequest struct {
+	MerchantID string `json:"merchant_id" validate:"required"`
+	UserID     string `json:"user_id"     validate:"required"`
+	Code       string `json:"code"        validate:"required,len=6"`
+}
+
+// DisableTOTPRequest represents the request to disable TOTP.
+type DisableTOTPRequest struct {
+	MerchantID string `json:"merchant_id" validate:"required"`
+	UserID     string `json:"user_id"     validate:"required"`
+	Password   string `json:"password"    validate:"required"`
 }
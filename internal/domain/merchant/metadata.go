@@ -0,0 +1,46 @@
+package merchant
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"errors"
+)
+
+// MetadataPolicies reads the rules for invoice metadata each merchant has set in its settings.
+type MetadataPolicies struct {
+	repository MerchantRepository
+}
+
+// NewMetadataPolicies creates a new metadata policies reader.
+func NewMetadataPolicies(repository MerchantRepository) *MetadataPolicies {
+	return &MetadataPolicies{repository: repository}
+}
+
+// MetadataPolicy returns the merchant's rules for invoice metadata, or invoice.DefaultMetadataPolicy if the
+// merchant has not set any.
+func (p *MetadataPolicies) MetadataPolicy(ctx context.Context, merchantID string) (invoice.MetadataPolicy, error) {
+	merchant, err := p.repository.FindByID(ctx, merchantID)
+	if errors.Is(err, ErrMerchantNotFound) {
+		return invoice.DefaultMetadataPolicy(), nil
+	}
+	if err != nil {
+		return invoice.MetadataPolicy{}, err
+	}
+	settings := merchant.Settings()
+	if settings == nil {
+		return invoice.DefaultMetadataPolicy(), nil
+	}
+
+	policy := invoice.DefaultMetadataPolicy()
+	if settings.MaxMetadataBytes != nil {
+		policy.MaxBytes = *settings.MaxMetadataBytes
+	}
+	policy.FilterableKeys = settings.FilterableMetadataKeys
+	if len(settings.MetadataSchema) > 0 {
+		policy.Schema, err = invoice.CompileMetadataSchema(settings.MetadataSchema)
+		if err != nil {
+			return invoice.MetadataPolicy{}, err
+		}
+	}
+	return policy, nil
+}
@@ -0,0 +1,58 @@
+package merchant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// AllowedOrigins reads the sites each merchant allows to call the public API from browsers, as the embedded payment
+// widget does, from its settings.
+type AllowedOrigins struct {
+	repository MerchantRepository
+}
+
+// NewAllowedOrigins creates a new allowed origins reader.
+func NewAllowedOrigins(repository MerchantRepository) *AllowedOrigins {
+	return &AllowedOrigins{repository: repository}
+}
+
+// AllowsOrigin reports whether the merchant allows pages of the origin, such as "https://shop.example.com", to
+// call the public API for its invoices and payment links. Merchants that have not listed any origins allow none.
+func (o *AllowedOrigins) AllowsOrigin(ctx context.Context, merchantID, origin string) (bool, error) {
+	normalized, err := NormalizeOrigin(origin)
+	if err != nil {
+		return false, nil
+	}
+
+	merchant, err := o.repository.FindByID(ctx, merchantID)
+	if errors.Is(err, ErrMerchantNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if merchant.Settings() == nil {
+		return false, nil
+	}
+
+	for _, allowed := range merchant.Settings().AllowedOrigins {
+		if allowedOrigin, err := NormalizeOrigin(allowed); err == nil && allowedOrigin == normalized {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// NormalizeOrigin returns the form origins are compared in: the lower-case scheme and host of an http or https URL
+// without path, query or fragment. A trailing slash is accepted.
+func NormalizeOrigin(origin string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSuffix(strings.TrimSpace(origin), "/"))
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" ||
+		parsed.Path != "" || parsed.RawQuery != "" || parsed.Fragment != "" || parsed.User != nil {
+		return "", fmt.Errorf("%w: invalid origin %q", ErrInvalidMerchantSettings, origin)
+	}
+	return strings.ToLower(parsed.Scheme + "://" + parsed.Host), nil
+}
@@ -0,0 +1,99 @@
+package merchant
+
+// Permission scopes shared by API keys, access tokens and team roles.
+const (
+	PermissionAll                = "*"
+	PermissionInvoicesCreate     = "invoices:create"
+	PermissionInvoicesRead       = "invoices:read"
+	PermissionInvoicesCancel     = "invoices:cancel"
+	PermissionInvoicesRefund     = "invoices:refund"
+	PermissionAnalyticsRead      = "analytics:read"
+	PermissionAPIKeysManage      = "api_keys:manage" //nolint:gosec // This is a permission scope, not a credential
+	PermissionWebhooksManage     = "webhooks:manage"
+	PermissionSettingsManage     = "settings:manage"
+	PermissionTeamManage         = "team:manage"
+	PermissionTeamRead           = "team:read"
+	PermissionAdminOperations    = "admin:operations"
+	PermissionAuditRead          = "audit:read"
+	PermissionComplianceReview   = "compliance:review"
+	PermissionBlocklistManage    = "blocklist:manage"
+	PermissionReviewsManage      = "reviews:manage"
+	PermissionCouponsManage      = "coupons:manage"
+	PermissionTaxManage          = "tax:manage"
+	PermissionPaymentLinksManage = "payment_links:manage"
+	PermissionSettlementsRead    = "settlements:read"
+	PermissionPayoutsManage      = "payouts:manage"
+	PermissionTransfersApprove   = "transfers:approve"
+)
+
+// rolePermissions maps each team role to the permission scopes it grants.
+var rolePermissions = map[UserRole][]string{
+	RoleOwner: {PermissionAll},
+	RoleAdmin: {
+		PermissionInvoicesCreate,
+		PermissionInvoicesRead,
+		PermissionInvoicesCancel,
+		PermissionInvoicesRefund,
+		PermissionAnalyticsRead,
+		PermissionAPIKeysManage,
+		PermissionWebhooksManage,
+		PermissionSettingsManage,
+		PermissionTeamManage,
+		PermissionTeamRead,
+		PermissionAdminOperations,
+		PermissionAuditRead,
+		PermissionComplianceReview,
+		PermissionBlocklistManage,
+		PermissionReviewsManage,
+		PermissionCouponsManage,
+		PermissionTaxManage,
+		PermissionPaymentLinksManage,
+		PermissionSettlementsRead,
+		PermissionPayoutsManage,
+		PermissionTransfersApprove,
+	},
+	RoleDeveloper: {
+		PermissionInvoicesCreate,
+		PermissionInvoicesRead,
+		PermissionAnalyticsRead,
+		PermissionAPIKeysManage,
+		PermissionWebhooksManage,
+		PermissionTeamRead,
+	},
+	RoleViewer: {
+		PermissionInvoicesRead,
+		PermissionAnalyticsRead,
+		PermissionTeamRead,
+		PermissionSettlementsRead,
+	},
+}
+
+// Permissions returns the permission scopes granted by the role.
+func (r UserRole) Permissions() []string {
+	permissions := rolePermissions[r]
+	result := make([]string, len(permissions))
+	copy(result, permissions)
+	return result
+}
+
+// HasPermission checks if the role grants the given permission scope.
+func (r UserRole) HasPermission(permission string) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission || p == PermissionAll {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAssign checks if a member with this role may grant the target role to someone else.
+func (r UserRole) CanAssign(target UserRole) bool {
+	switch r {
+	case RoleOwner:
+		return target.IsValid()
+	case RoleAdmin:
+		return target == RoleAdmin || target == RoleDeveloper || target == RoleViewer
+	default:
+		return false
+	}
+}
@@ -0,0 +1,85 @@
+package merchant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRole_HasPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       UserRole
+		permission string
+		expected   bool
+	}{
+		{"owner has everything", RoleOwner, PermissionAdminOperations, true},
+		{"admin manages team", RoleAdmin, PermissionTeamManage, true},
+		{"developer creates invoices", RoleDeveloper, PermissionInvoicesCreate, true},
+		{"developer cannot cancel invoices", RoleDeveloper, PermissionInvoicesCancel, false},
+		{"developer cannot manage team", RoleDeveloper, PermissionTeamManage, false},
+		{"admin reads audit log", RoleAdmin, PermissionAuditRead, true},
+		{"developer cannot read audit log", RoleDeveloper, PermissionAuditRead, false},
+		{"viewer reads invoices", RoleViewer, PermissionInvoicesRead, true},
+		{"viewer cannot create invoices", RoleViewer, PermissionInvoicesCreate, false},
+		{"unknown role has nothing", UserRole("guest"), PermissionInvoicesRead, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.role.HasPermission(tt.permission))
+		})
+	}
+}
+
+func TestUserRole_CanAssign(t *testing.T) {
+	assert.True(t, RoleOwner.CanAssign(RoleOwner))
+	assert.True(t, RoleAdmin.CanAssign(RoleViewer))
+	assert.False(t, RoleAdmin.CanAssign(RoleOwner))
+	assert.False(t, RoleDeveloper.CanAssign(RoleViewer))
+	assert.False(t, RoleOwner.CanAssign(UserRole("guest")))
+}
+
+func TestUser_HasPermission(t *testing.T) {
+	user, err := NewUser("user_1", "merchant_1", "Dev@Example.com", "Dev", RoleDeveloper)
+	require.NoError(t, err)
+	assert.Equal(t, "dev@example.com", user.Email())
+	assert.True(t, user.HasPermission(PermissionInvoicesCreate))
+
+	require.NoError(t, user.Disable())
+	assert.False(t, user.HasPermission(PermissionInvoicesCreate))
+}
+
+func TestNewUser_InvalidRole(t *testing.T) {
+	_, err := NewUser("user_1", "merchant_1", "dev@example.com", "Dev", UserRole("guest"))
+	require.ErrorIs(t, err, ErrInvalidRole)
+}
+
+func TestInvitation_Accept(t *testing.T) {
+	invitation, err := NewInvitation("inv_1", "merchant_1", "new@example.com", RoleViewer, "inv_token", "user_1")
+	require.NoError(t, err)
+
+	expected, err := NewAPIKeyHash("inv_token")
+	require.NoError(t, err)
+	assert.True(t, invitation.TokenHash().Equals(expected))
+
+	require.NoError(t, invitation.Accept())
+	assert.Equal(t, InvitationStatusAccepted, invitation.Status())
+	assert.NotNil(t, invitation.AcceptedAt())
+	require.ErrorIs(t, invitation.Accept(), ErrInvitationNotPending)
+}
+
+func TestInvitation_AcceptExpired(t *testing.T) {
+	hash, err := NewAPIKeyHash("inv_token")
+	require.NoError(t, err)
+
+	past := time.Now().Add(-time.Hour)
+	invitation, err := RestoreInvitation(
+		"inv_1", "merchant_1", "new@example.com", RoleViewer, hash, "user_1",
+		InvitationStatusPending, past, past.Add(-DefaultInvitationTTL), nil,
+	)
+	require.NoError(t, err)
+	require.ErrorIs(t, invitation.Accept(), ErrInvitationExpired)
+}
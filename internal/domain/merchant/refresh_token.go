@@ -0,0 +1,119 @@
+package merchant
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"time"
+)
+
+// DefaultRefreshTokenTTL is how long a dashboard refresh token stays valid.
+const DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+// RefreshToken represents a single-use dashboard session refresh token.
+// Tokens issued from the same login share a family so that reuse of a rotated
+// token can revoke the whole session.
+type RefreshToken struct {
+	id         string
+	userID     string
+	merchantID string
+	familyID   string
+	tokenHash  *APIKeyHash
+	expiresAt  time.Time
+	createdAt  time.Time
+	revokedAt  *time.Time
+}
+
+// NewRefreshToken creates a new refresh token for the given raw token value.
+func NewRefreshToken(id, userID, merchantID, familyID, rawToken string) (*RefreshToken, error) {
+	tokenHash, err := NewAPIKeyHash(rawToken)
+	if err != nil {
+		return nil, err
+	}
+
+	now := shared.Now()
+	return RestoreRefreshToken(id, userID, merchantID, familyID, tokenHash, now.Add(DefaultRefreshTokenTTL), now, nil)
+}
+
+// RestoreRefreshToken recreates a refresh token from storage.
+func RestoreRefreshToken(
+	id, userID, merchantID, familyID string,
+	tokenHash *APIKeyHash,
+	expiresAt, createdAt time.Time,
+	revokedAt *time.Time,
+) (*RefreshToken, error) {
+	if id == "" || userID == "" || merchantID == "" || familyID == "" {
+		return nil, shared.NewFieldError("", "refresh token ID, user, merchant and family are required")
+	}
+	if tokenHash == nil {
+		return nil, shared.NewFieldError("token_hash", "token hash is required")
+	}
+
+	return &RefreshToken{
+		id:         id,
+		userID:     userID,
+		merchantID: merchantID,
+		familyID:   familyID,
+		tokenHash:  tokenHash,
+		expiresAt:  expiresAt,
+		createdAt:  createdAt,
+		revokedAt:  revokedAt,
+	}, nil
+}
+
+// ID returns the refresh token ID.
+func (t *RefreshToken) ID() string {
+	return t.id
+}
+
+// UserID returns the owning user ID.
+func (t *RefreshToken) UserID() string {
+	return t.userID
+}
+
+// MerchantID returns the merchant ID of the session.
+func (t *RefreshToken) MerchantID() string {
+	return t.merchantID
+}
+
+// FamilyID returns the session family ID shared by rotated tokens.
+func (t *RefreshToken) FamilyID() string {
+	return t.familyID
+}
+
+// TokenHash returns the hashed token value.
+func (t *RefreshToken) TokenHash() *APIKeyHash {
+	return t.tokenHash
+}
+
+// ExpiresAt returns the expiration timestamp.
+func (t *RefreshToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+// CreatedAt returns the creation timestamp.
+func (t *RefreshToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// RevokedAt returns when the token was revoked or rotated.
+func (t *RefreshToken) RevokedAt() *time.Time {
+	return t.revokedAt
+}
+
+// IsRevoked checks if the token has been revoked or already rotated.
+func (t *RefreshToken) IsRevoked() bool {
+	return t.revokedAt != nil
+}
+
+// IsExpired checks if the token is past its expiration.
+func (t *RefreshToken) IsExpired() bool {
+	return shared.Now().After(t.expiresAt)
+}
+
+// Revoke marks the token as used or revoked.
+func (t *RefreshToken) Revoke() {
+	if t.revokedAt != nil {
+		return
+	}
+	now := shared.Now()
+	t.revokedAt = &now
+}
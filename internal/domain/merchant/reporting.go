@@ -0,0 +1,73 @@
+package merchant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ParseReportingTimeZone returns the location of an IANA time zone name, or UTC for an empty name.
+func ParseReportingTimeZone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	// Local would follow the time zone of the server rather than the merchant's
+	if name == "Local" {
+		return nil, fmt.Errorf("%w: unknown reporting time zone %q", ErrInvalidMerchantSettings, name)
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown reporting time zone %q", ErrInvalidMerchantSettings, name)
+	}
+	return location, nil
+}
+
+// ReportingTimeZones reads the time zone each merchant reports in from its settings.
+type ReportingTimeZones struct {
+	repository MerchantRepository
+}
+
+// NewReportingTimeZones creates a new reporting time zones reader.
+func NewReportingTimeZones(repository MerchantRepository) *ReportingTimeZones {
+	return &ReportingTimeZones{repository: repository}
+}
+
+// Location returns the merchant's reporting time zone, or UTC if the merchant is unknown or has not chosen one.
+func (z *ReportingTimeZones) Location(ctx context.Context, merchantID string) (*time.Location, error) {
+	merchant, err := z.repository.FindByID(ctx, merchantID)
+	if errors.Is(err, ErrMerchantNotFound) {
+		return time.UTC, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if merchant.Settings() == nil {
+		return time.UTC, nil
+	}
+
+	location, err := ParseReportingTimeZone(merchant.Settings().ReportingTimeZone)
+	if err != nil {
+		// Settings saved before the zone was removed from the time zone database report in UTC
+		return time.UTC, nil
+	}
+	return location, nil
+}
+
+// DayRange returns the period covering the calendar days from start to end, inclusive, in the location. The
+// year, month and day of the dates are used and their time and location are ignored; either may be nil to leave
+// that side of the period open.
+func DayRange(start, end *time.Time, location *time.Location) (from, to *time.Time) {
+	if start != nil {
+		y, m, d := start.Date()
+		midnight := time.Date(y, m, d, 0, 0, 0, 0, location)
+		from = &midnight
+	}
+	if end != nil {
+		// The next midnight, less a nanosecond, as days are not always 24 hours long
+		y, m, d := end.Date()
+		last := time.Date(y, m, d+1, 0, 0, 0, 0, location).Add(-time.Nanosecond)
+		to = &last
+	}
+	return from, to
+}
@@ -73,6 +73,69 @@ type WebhookEndpointRepository interface {
 	CountByMerchantID(ctx context.Context, merchantID string) (int, error)
 }
 
+// UserRepository defines the interface for team member data persistence.
+type UserRepository interface {
+	// Save saves a user to the repository.
+	Save(ctx context.Context, user *User) error
+
+	// FindByID finds a user by its ID.
+	FindByID(ctx context.Context, id string) (*User, error)
+
+	// FindByEmail finds a user of a merchant by email.
+	FindByEmail(ctx context.Context, merchantID, email string) (*User, error)
+
+	// FindAllByEmail finds users with the email across all merchants.
+	FindAllByEmail(ctx context.Context, email string) ([]*User, error)
+
+	// FindByMerchantID finds all users for a merchant.
+	FindByMerchantID(ctx context.Context, merchantID string) ([]*User, error)
+
+	// Update updates an existing user.
+	Update(ctx context.Context, user *User) error
+
+	// Delete deletes a user by its ID.
+	Delete(ctx context.Context, id string) error
+
+	// CountByRole counts active users of a merchant with the given role.
+	CountByRole(ctx context.Context, merchantID string, role UserRole) (int, error)
+}
+
+// InvitationRepository defines the interface for team invitation data persistence.
+type InvitationRepository interface {
+	// Save saves an invitation to the repository.
+	Save(ctx context.Context, invitation *Invitation) error
+
+	// FindByID finds an invitation by its ID.
+	FindByID(ctx context.Context, id string) (*Invitation, error)
+
+	// FindByTokenHash finds an invitation by its token hash.
+	FindByTokenHash(ctx context.Context, hash *APIKeyHash) (*Invitation, error)
+
+	// FindByMerchantID finds all invitations for a merchant.
+	FindByMerchantID(ctx context.Context, merchantID string) ([]*Invitation, error)
+
+	// Update updates an existing invitation.
+	Update(ctx context.Context, invitation *Invitation) error
+}
+
+// RefreshTokenRepository defines the interface for dashboard session refresh token persistence.
+type RefreshTokenRepository interface {
+	// Save saves a refresh token to the repository.
+	Save(ctx context.Context, token *RefreshToken) error
+
+	// FindByHash finds a refresh token by its hash.
+	FindByHash(ctx context.Context, hash *APIKeyHash) (*RefreshToken, error)
+
+	// Update updates an existing refresh token.
+	Update(ctx context.Context, token *RefreshToken) error
+
+	// RevokeFamily revokes all active tokens in a session family.
+	RevokeFamily(ctx context.Context, familyID string) error
+
+	// RevokeByUserID revokes all active tokens of a user.
+	RevokeByUserID(ctx context.Context, userID string) error
+}
+
 // ListMerchantsRequest represents the request to list merchants.
 type ListMerchantsRequest struct {
 	Status *MerchantStatus `json:"status,omitempty"`
@@ -0,0 +1,40 @@
+package merchant
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+)
+
+// CountryRestrictions reads the countries each merchant refuses checkouts in from its settings.
+type CountryRestrictions struct {
+	repository MerchantRepository
+}
+
+// NewCountryRestrictions creates a new country restrictions reader.
+func NewCountryRestrictions(repository MerchantRepository) *CountryRestrictions {
+	return &CountryRestrictions{repository: repository}
+}
+
+// RestrictedCountries returns the upper-case country codes the merchant refuses checkouts in. Unknown merchants
+// and merchants that have not listed any countries restrict none.
+func (r *CountryRestrictions) RestrictedCountries(ctx context.Context, merchantID string) ([]string, error) {
+	merchant, err := r.repository.FindByID(ctx, merchantID)
+	if errors.Is(err, ErrMerchantNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if merchant.Settings() == nil {
+		return nil, nil
+	}
+
+	countries := make([]string, 0, len(merchant.Settings().RestrictedCountries))
+	for _, country := range merchant.Settings().RestrictedCountries {
+		if code, ok := shared.ParseCountryCode(country); ok {
+			countries = append(countries, code)
+		}
+	}
+	return countries, nil
+}
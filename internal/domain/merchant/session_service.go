@@ -0,0 +1,370 @@
+package merchant
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/shared"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+// defaultTOTPIssuer is the issuer shown in authenticator apps when none is given.
+const defaultTOTPIssuer = "Crypto Checkout"
+
+// SessionServiceImpl implements the SessionService interface.
+type SessionServiceImpl struct {
+	userRepo         UserRepository
+	refreshTokenRepo RefreshTokenRepository
+	auditService     audit.Service
+	logger           *zap.Logger
+}
+
+// NewSessionService creates a new session service.
+func NewSessionService(
+	userRepo UserRepository,
+	refreshTokenRepo RefreshTokenRepository,
+	auditService audit.Service,
+	logger *zap.Logger,
+) SessionService {
+	return &SessionServiceImpl{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		auditService:     auditService,
+		logger:           logger,
+	}
+}
+
+// Login verifies user credentials and starts a new session.
+func (s *SessionServiceImpl) Login(ctx context.Context, req *LoginRequest) (*SessionResponse, error) {
+	if req == nil {
+		return nil, shared.NewFieldError("", "login request cannot be nil")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	user, err := s.findLoginUser(ctx, strings.ToLower(req.Email), req.MerchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive() || !user.VerifyPassword(req.Password) {
+		s.logger.Debug("Login rejected", zap.String("user_id", user.ID()))
+		return nil, ErrInvalidCredentials
+	}
+
+	if user.TOTPEnabled() {
+		if req.TOTPCode == "" {
+			return nil, ErrTOTPRequired
+		}
+		if !ValidateTOTP(user.TOTPSecret(), req.TOTPCode, shared.Now()) {
+			return nil, ErrInvalidTOTPCode
+		}
+	}
+
+	familyID, err := generateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate session ID: %w", err)
+	}
+
+	response, err := s.issueRefreshToken(ctx, user, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, user, "session.login", map[string]interface{}{"two_factor": user.TOTPEnabled()})
+
+	return response, nil
+}
+
+// Refresh rotates a refresh token and continues the session.
+func (s *SessionServiceImpl) Refresh(ctx context.Context, req *RefreshSessionRequest) (*SessionResponse, error) {
+	if req == nil || req.RefreshToken == "" {
+		return nil, ErrRefreshTokenNotFound
+	}
+
+	token, err := s.findRefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// A rotated token being presented again means it leaked; end the whole session
+	if token.IsRevoked() {
+		if err := s.refreshTokenRepo.RevokeFamily(ctx, token.FamilyID()); err != nil {
+			return nil, fmt.Errorf("failed to revoke session: %w", err)
+		}
+		s.logger.Warn("Refresh token reuse detected, session revoked",
+			zap.String("user_id", token.UserID()),
+			zap.String("family_id", token.FamilyID()),
+		)
+		return nil, ErrRefreshTokenReused
+	}
+	if token.IsExpired() {
+		return nil, ErrRefreshTokenExpired
+	}
+
+	user, err := s.userRepo.FindByID(ctx, token.UserID())
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if !user.IsActive() {
+		return nil, ErrInvalidCredentials
+	}
+
+	token.Revoke()
+	if err := s.refreshTokenRepo.Update(ctx, token); err != nil {
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	return s.issueRefreshToken(ctx, user, token.FamilyID())
+}
+
+// Authenticate checks that the user of a session access token is still active with the session's role.
+// A disabled or removed user, or a changed role, ends the session before its access token expires.
+func (s *SessionServiceImpl) Authenticate(ctx context.Context, req *AuthenticateSessionRequest) (*User, error) {
+	if req == nil || req.UserID == "" || req.MerchantID == "" {
+		return nil, ErrInvalidSession
+	}
+
+	user, err := s.findMerchantUser(ctx, req.MerchantID, req.UserID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrInvalidSession
+		}
+		return nil, err
+	}
+	if !user.IsActive() || user.Role() != req.Role {
+		return nil, ErrInvalidSession
+	}
+
+	return user, nil
+}
+
+// Logout revokes the session of a refresh token.
+func (s *SessionServiceImpl) Logout(ctx context.Context, req *LogoutRequest) error {
+	if req == nil || req.RefreshToken == "" {
+		return ErrRefreshTokenNotFound
+	}
+
+	token, err := s.findRefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, token.FamilyID()); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return nil
+}
+
+// SetupTOTP generates a pending TOTP secret for a user whose two-factor authentication is not enabled.
+func (s *SessionServiceImpl) SetupTOTP(ctx context.Context, req *SetupTOTPRequest) (*SetupTOTPResponse, error) {
+	if req == nil {
+		return nil, shared.NewFieldError("", "setup TOTP request cannot be nil")
+	}
+
+	user, err := s.findMerchantUser(ctx, req.MerchantID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate TOTP secret: %w", err)
+	}
+
+	if err := user.SetTOTPSecret(secret); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	issuer := req.Issuer
+	if issuer == "" {
+		issuer = defaultTOTPIssuer
+	}
+
+	return &SetupTOTPResponse{
+		Secret:          secret,
+		ProvisioningURI: TOTPProvisioningURI(secret, issuer, user.Email()),
+	}, nil
+}
+
+// EnableTOTP confirms the pending TOTP secret and enables two-factor authentication.
+func (s *SessionServiceImpl) EnableTOTP(ctx context.Context, req *EnableTOTPRequest) error {
+	if req == nil {
+		return shared.NewFieldError("", "enable TOTP request cannot be nil")
+	}
+
+	user, err := s.findMerchantUser(ctx, req.MerchantID, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := user.EnableTOTP(req.Code, shared.Now()); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	s.record(ctx, user, "session.totp_enabled", nil)
+	return nil
+}
+
+// DisableTOTP disables two-factor authentication for a user.
+func (s *SessionServiceImpl) DisableTOTP(ctx context.Context, req *DisableTOTPRequest) error {
+	if req == nil {
+		return shared.NewFieldError("", "disable TOTP request cannot be nil")
+	}
+
+	user, err := s.findMerchantUser(ctx, req.MerchantID, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !user.VerifyPassword(req.Password) {
+		return ErrInvalidCredentials
+	}
+
+	user.DisableTOTP()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	s.record(ctx, user, "session.totp_disabled", nil)
+	return nil
+}
+
+// findLoginUser resolves the user for a login email, optionally scoped to a merchant.
+func (s *SessionServiceImpl) findLoginUser(ctx context.Context, email, merchantID string) (*User, error) {
+	if merchantID != "" {
+		user, err := s.userRepo.FindByEmail(ctx, merchantID, email)
+		if err != nil {
+			if errors.Is(err, ErrUserNotFound) {
+				return nil, ErrInvalidCredentials
+			}
+			return nil, fmt.Errorf("failed to find user: %w", err)
+		}
+		return user, nil
+	}
+
+	users, err := s.userRepo.FindAllByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	switch len(users) {
+	case 0:
+		return nil, ErrInvalidCredentials
+	case 1:
+		return users[0], nil
+	default:
+		return nil, ErrMerchantRequired
+	}
+}
+
+// findMerchantUser loads a user and verifies it belongs to the merchant.
+func (s *SessionServiceImpl) findMerchantUser(ctx context.Context, merchantID, userID string) (*User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user.MerchantID() != merchantID {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// findRefreshToken looks up a refresh token by its raw value.
+func (s *SessionServiceImpl) findRefreshToken(ctx context.Context, rawToken string) (*RefreshToken, error) {
+	hash, err := NewAPIKeyHash(rawToken)
+	if err != nil {
+		return nil, ErrRefreshTokenNotFound
+	}
+
+	token, err := s.refreshTokenRepo.FindByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, ErrRefreshTokenNotFound) {
+			return nil, ErrRefreshTokenNotFound
+		}
+		return nil, fmt.Errorf("failed to find refresh token: %w", err)
+	}
+	return token, nil
+}
+
+// issueRefreshToken creates and stores a new refresh token in the session family.
+func (s *SessionServiceImpl) issueRefreshToken(
+	ctx context.Context,
+	user *User,
+	familyID string,
+) (*SessionResponse, error) {
+	tokenID, err := generateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token ID: %w", err)
+	}
+
+	rawToken, err := generateRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	token, err := NewRefreshToken(tokenID, user.ID(), user.MerchantID(), familyID, rawToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	if err := s.refreshTokenRepo.Save(ctx, token); err != nil {
+		return nil, fmt.Errorf("failed to save refresh token: %w", err)
+	}
+
+	return &SessionResponse{
+		User:             user,
+		RefreshToken:     rawToken,
+		RefreshExpiresAt: token.ExpiresAt(),
+	}, nil
+}
+
+// record appends an audit entry for a session action, logging rather than failing on errors.
+func (s *SessionServiceImpl) record(ctx context.Context, user *User, action string, details map[string]interface{}) {
+	if err := s.auditService.Record(ctx, &audit.RecordRequest{
+		MerchantID:   user.MerchantID(),
+		Actor:        audit.Actor{ID: user.ID(), Type: audit.ActorTypeUser, Role: string(user.Role())},
+		Action:       action,
+		ResourceType: "user",
+		ResourceID:   user.ID(),
+		Details:      details,
+	}); err != nil {
+		s.logger.Error("Failed to record audit entry",
+			zap.String("user_id", user.ID()),
+			zap.String("action", action),
+			zap.Error(err),
+		)
+	}
+}
+
+// generateRefreshToken generates a new opaque refresh token.
+func generateRefreshToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return "rt_" + hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,465 @@
+package merchant
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/shared"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+// TeamServiceImpl implements the TeamService interface.
+type TeamServiceImpl struct {
+	userRepo       UserRepository
+	invitationRepo InvitationRepository
+	auditService   audit.Service
+	logger         *zap.Logger
+}
+
+// NewTeamService creates a new team service.
+func NewTeamService(
+	userRepo UserRepository,
+	invitationRepo InvitationRepository,
+	auditService audit.Service,
+	logger *zap.Logger,
+) TeamService {
+	return &TeamServiceImpl{
+		userRepo:       userRepo,
+		invitationRepo: invitationRepo,
+		auditService:   auditService,
+		logger:         logger,
+	}
+}
+
+// InviteUser invites a new team member to a merchant.
+func (s *TeamServiceImpl) InviteUser(ctx context.Context, req *InviteUserRequest) (*InviteUserResponse, error) {
+	if req == nil {
+		return nil, shared.NewFieldError("", "invite user request cannot be nil")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if !req.Role.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRole, req.Role)
+	}
+
+	if err := s.authorizeRoleGrant(ctx, req.MerchantID, req.InvitedBy, req.Role); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.userRepo.FindByEmail(ctx, req.MerchantID, strings.ToLower(req.Email))
+	if err != nil && !errors.Is(err, ErrUserNotFound) {
+		return nil, fmt.Errorf("failed to check existing user: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrUserAlreadyExists
+	}
+
+	invitationID, err := generateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invitation ID: %w", err)
+	}
+
+	token, err := generateInvitationToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+
+	invitation, err := NewInvitation(invitationID, req.MerchantID, req.Email, req.Role, token, req.InvitedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	if err := s.invitationRepo.Save(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to save invitation: %w", err)
+	}
+
+	s.record(ctx, req.MerchantID, req.InvitedBy, "team.invitation_created", "invitation", invitation.ID(),
+		map[string]interface{}{"email": invitation.Email(), "role": string(invitation.Role())})
+
+	s.logger.Info("Team invitation created",
+		zap.String("merchant_id", req.MerchantID),
+		zap.String("invitation_id", invitation.ID()),
+		zap.String("role", string(invitation.Role())),
+	)
+
+	return &InviteUserResponse{
+		Invitation: invitation,
+		Token:      token,
+	}, nil
+}
+
+// AcceptInvitation accepts an invitation and creates the team member.
+func (s *TeamServiceImpl) AcceptInvitation(
+	ctx context.Context,
+	req *AcceptInvitationRequest,
+) (*AcceptInvitationResponse, error) {
+	if req == nil {
+		return nil, shared.NewFieldError("", "accept invitation request cannot be nil")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	tokenHash, err := NewAPIKeyHash(req.Token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash invitation token: %w", err)
+	}
+
+	invitation, err := s.invitationRepo.FindByTokenHash(ctx, tokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find invitation: %w", err)
+	}
+
+	if err := invitation.Accept(); err != nil {
+		return nil, err
+	}
+
+	userID, err := generateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate user ID: %w", err)
+	}
+
+	user, err := NewUser(userID, invitation.MerchantID(), invitation.Email(), req.Name, invitation.Role())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	if err := user.SetPassword(req.Password); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.Save(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	if err := s.invitationRepo.Update(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to update invitation: %w", err)
+	}
+
+	s.record(ctx, user.MerchantID(), user.ID(), "team.invitation_accepted", "user", user.ID(),
+		map[string]interface{}{"invitation_id": invitation.ID(), "role": string(user.Role())})
+
+	return &AcceptInvitationResponse{User: user}, nil
+}
+
+// ListInvitations lists invitations for a merchant.
+func (s *TeamServiceImpl) ListInvitations(
+	ctx context.Context,
+	req *ListInvitationsRequest,
+) (*ListInvitationsResponse, error) {
+	if req == nil || req.MerchantID == "" {
+		return nil, shared.NewFieldError("merchant_id", "merchant ID is required")
+	}
+
+	invitations, err := s.invitationRepo.FindByMerchantID(ctx, req.MerchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+
+	return &ListInvitationsResponse{
+		Invitations: invitations,
+		Total:       len(invitations),
+	}, nil
+}
+
+// RevokeInvitation revokes a pending invitation.
+func (s *TeamServiceImpl) RevokeInvitation(
+	ctx context.Context,
+	req *RevokeInvitationRequest,
+) (*RevokeInvitationResponse, error) {
+	if req == nil {
+		return nil, shared.NewFieldError("", "revoke invitation request cannot be nil")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	invitation, err := s.invitationRepo.FindByID(ctx, req.InvitationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find invitation: %w", err)
+	}
+	if invitation.MerchantID() != req.MerchantID {
+		return nil, ErrInvitationNotFound
+	}
+
+	if err := s.authorizeRoleGrant(ctx, req.MerchantID, req.RevokedBy, invitation.Role()); err != nil {
+		return nil, err
+	}
+
+	if err := invitation.Revoke(); err != nil {
+		return nil, err
+	}
+
+	if err := s.invitationRepo.Update(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to update invitation: %w", err)
+	}
+
+	s.record(ctx, req.MerchantID, req.RevokedBy, "team.invitation_revoked", "invitation", invitation.ID(), nil)
+
+	return &RevokeInvitationResponse{Invitation: invitation}, nil
+}
+
+// GetUser retrieves a team member by ID.
+func (s *TeamServiceImpl) GetUser(ctx context.Context, req *GetUserRequest) (*GetUserResponse, error) {
+	if req == nil {
+		return nil, shared.NewFieldError("", "get user request cannot be nil")
+	}
+
+	user, err := s.findMerchantUser(ctx, req.MerchantID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetUserResponse{User: user}, nil
+}
+
+// ListUsers lists team members for a merchant.
+func (s *TeamServiceImpl) ListUsers(ctx context.Context, req *ListUsersRequest) (*ListUsersResponse, error) {
+	if req == nil || req.MerchantID == "" {
+		return nil, shared.NewFieldError("merchant_id", "merchant ID is required")
+	}
+
+	users, err := s.userRepo.FindByMerchantID(ctx, req.MerchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return &ListUsersResponse{
+		Users: users,
+		Total: len(users),
+	}, nil
+}
+
+// ChangeUserRole changes the role of a team member.
+func (s *TeamServiceImpl) ChangeUserRole(
+	ctx context.Context,
+	req *ChangeUserRoleRequest,
+) (*ChangeUserRoleResponse, error) {
+	if req == nil {
+		return nil, shared.NewFieldError("", "change user role request cannot be nil")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if !req.Role.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRole, req.Role)
+	}
+
+	user, err := s.findMerchantUser(ctx, req.MerchantID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The actor must be allowed to both grant the new role and manage the current one
+	if err := s.authorizeRoleGrant(ctx, req.MerchantID, req.ChangedBy, req.Role); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeRoleGrant(ctx, req.MerchantID, req.ChangedBy, user.Role()); err != nil {
+		return nil, err
+	}
+
+	if user.Role() == RoleOwner && req.Role != RoleOwner {
+		if err := s.ensureAnotherOwner(ctx, req.MerchantID); err != nil {
+			return nil, err
+		}
+	}
+
+	previousRole := user.Role()
+	if err := user.ChangeRole(req.Role); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	s.recordChange(ctx, req.MerchantID, req.ChangedBy, "team.role_changed", "user", user.ID(), audit.Change{
+		Before: map[string]interface{}{"role": string(previousRole)},
+		After:  map[string]interface{}{"role": string(user.Role())},
+	})
+
+	return &ChangeUserRoleResponse{User: user}, nil
+}
+
+// RemoveUser removes a team member from a merchant.
+func (s *TeamServiceImpl) RemoveUser(ctx context.Context, req *RemoveUserRequest) (*RemoveUserResponse, error) {
+	if req == nil {
+		return nil, shared.NewFieldError("", "remove user request cannot be nil")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	user, err := s.findMerchantUser(ctx, req.MerchantID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeRoleGrant(ctx, req.MerchantID, req.RemovedBy, user.Role()); err != nil {
+		return nil, err
+	}
+
+	if user.Role() == RoleOwner {
+		if err := s.ensureAnotherOwner(ctx, req.MerchantID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.userRepo.Delete(ctx, user.ID()); err != nil {
+		return nil, fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	s.recordChange(ctx, req.MerchantID, req.RemovedBy, "team.user_removed", "user", user.ID(), audit.Change{
+		Before: map[string]interface{}{"email": user.Email(), "role": string(user.Role())},
+	})
+
+	return &RemoveUserResponse{Success: true}, nil
+}
+
+// CheckPermission checks whether a team member holds a permission for a merchant.
+func (s *TeamServiceImpl) CheckPermission(
+	ctx context.Context,
+	req *CheckPermissionRequest,
+) (*CheckPermissionResponse, error) {
+	if req == nil {
+		return nil, shared.NewFieldError("", "check permission request cannot be nil")
+	}
+
+	user, err := s.findMerchantUser(ctx, req.MerchantID, req.UserID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return &CheckPermissionResponse{Allowed: false}, nil
+		}
+		return nil, err
+	}
+
+	return &CheckPermissionResponse{
+		Allowed: user.HasPermission(req.Permission),
+		User:    user,
+	}, nil
+}
+
+// findMerchantUser loads a user and verifies it belongs to the merchant.
+func (s *TeamServiceImpl) findMerchantUser(ctx context.Context, merchantID, userID string) (*User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user.MerchantID() != merchantID {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// authorizeRoleGrant checks that the acting user may manage members holding the target role.
+// An empty actor ID denotes an API key caller, which is authorized by its key permissions.
+func (s *TeamServiceImpl) authorizeRoleGrant(ctx context.Context, merchantID, actorID string, target UserRole) error {
+	if actorID == "" {
+		return nil
+	}
+
+	actor, err := s.findMerchantUser(ctx, merchantID, actorID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return ErrPermissionDenied
+		}
+		return err
+	}
+
+	if !actor.HasPermission(PermissionTeamManage) {
+		return ErrPermissionDenied
+	}
+	if !actor.Role().CanAssign(target) {
+		return fmt.Errorf("%w: %s", ErrRoleNotAssignable, target)
+	}
+	return nil
+}
+
+// ensureAnotherOwner guards against removing or demoting the last owner of a merchant.
+func (s *TeamServiceImpl) ensureAnotherOwner(ctx context.Context, merchantID string) error {
+	owners, err := s.userRepo.CountByRole(ctx, merchantID, RoleOwner)
+	if err != nil {
+		return fmt.Errorf("failed to count owners: %w", err)
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
+
+// record appends an audit entry, logging rather than failing on errors.
+func (s *TeamServiceImpl) record(
+	ctx context.Context,
+	merchantID, actorID, action, resourceType, resourceID string,
+	details map[string]interface{},
+) {
+	s.recordEntry(ctx, merchantID, actorID, action, resourceType, resourceID, audit.Change{}, details)
+}
+
+// recordChange appends an audit entry with before and after snapshots of the resource.
+func (s *TeamServiceImpl) recordChange(
+	ctx context.Context,
+	merchantID, actorID, action, resourceType, resourceID string,
+	change audit.Change,
+) {
+	s.recordEntry(ctx, merchantID, actorID, action, resourceType, resourceID, change, nil)
+}
+
+// recordEntry appends an audit entry, logging rather than failing on errors.
+func (s *TeamServiceImpl) recordEntry(
+	ctx context.Context,
+	merchantID, actorID, action, resourceType, resourceID string,
+	change audit.Change,
+	details map[string]interface{},
+) {
+	actor := audit.Actor{ID: actorID, Type: audit.ActorTypeUser}
+	if actorID == "" {
+		actor = audit.Actor{ID: "api_key", Type: audit.ActorTypeAPIKey}
+	}
+
+	if err := s.auditService.Record(ctx, &audit.RecordRequest{
+		MerchantID:   merchantID,
+		Actor:        actor,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Before:       change.Before,
+		After:        change.After,
+		Details:      details,
+	}); err != nil {
+		s.logger.Error("Failed to record audit entry",
+			zap.String("merchant_id", merchantID),
+			zap.String("action", action),
+			zap.Error(err),
+		)
+	}
+}
+
+// generateInvitationToken generates a new invitation token.
+func generateInvitationToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return "inv_" + hex.EncodeToString(bytes), nil
+}
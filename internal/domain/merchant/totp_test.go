@@ -0,0 +1,72 @@
+package merchant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	now := time.Unix(1_700_000_000, 0)
+	code, err := GenerateTOTPCode(secret, now)
+	require.NoError(t, err)
+	assert.Len(t, code, 6)
+
+	assert.True(t, ValidateTOTP(secret, code, now))
+	assert.True(t, ValidateTOTP(secret, code, now.Add(30*time.Second)), "adjacent step is accepted")
+	assert.False(t, ValidateTOTP(secret, code, now.Add(2*time.Minute)), "stale code is rejected")
+	assert.False(t, ValidateTOTP(secret, "abc123", now))
+	assert.False(t, ValidateTOTP("not-base32!", code, now))
+}
+
+func TestTOTPCode_RFC6238Vector(t *testing.T) {
+	// RFC 6238 test secret "12345678901234567890", truncated to 6 digits.
+	code := totpCode([]byte("12345678901234567890"), uint64(59/30))
+	assert.Equal(t, "287082", code)
+}
+
+func TestUser_Password(t *testing.T) {
+	user, err := NewUser("user_1", "merchant_1", "dev@example.com", "Dev", RoleDeveloper)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, user.SetPassword("short"), ErrWeakPassword)
+	require.NoError(t, user.SetPassword("correct horse battery"))
+
+	assert.True(t, user.VerifyPassword("correct horse battery"))
+	assert.False(t, user.VerifyPassword("wrong password"))
+}
+
+func TestUser_EnableTOTP(t *testing.T) {
+	user, err := NewUser("user_1", "merchant_1", "dev@example.com", "Dev", RoleDeveloper)
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.ErrorIs(t, user.EnableTOTP("123456", now), ErrTOTPNotConfigured)
+
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+	require.NoError(t, user.SetTOTPSecret(secret))
+
+	require.ErrorIs(t, user.EnableTOTP("000000x", now), ErrInvalidTOTPCode)
+
+	code, err := GenerateTOTPCode(secret, now)
+	require.NoError(t, err)
+	require.NoError(t, user.EnableTOTP(code, now))
+	assert.True(t, user.TOTPEnabled())
+
+	// A new setup cannot replace the enabled secret and turn two-factor authentication off
+	other, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+	require.ErrorIs(t, user.SetTOTPSecret(other), ErrTOTPAlreadyEnabled)
+	assert.True(t, user.TOTPEnabled())
+	assert.Equal(t, secret, user.TOTPSecret())
+
+	user.DisableTOTP()
+	assert.False(t, user.TOTPEnabled())
+	assert.Empty(t, user.TOTPSecret())
+}
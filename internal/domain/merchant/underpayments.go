@@ -0,0 +1,39 @@
+package merchant
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"errors"
+	"time"
+)
+
+// UnderpaymentPolicies reads the grace period for completing partial payments each merchant has set in its
+// settings.
+type UnderpaymentPolicies struct {
+	repository MerchantRepository
+}
+
+// NewUnderpaymentPolicies creates a new underpayment policies reader.
+func NewUnderpaymentPolicies(repository MerchantRepository) *UnderpaymentPolicies {
+	return &UnderpaymentPolicies{repository: repository}
+}
+
+// UnderpaymentPolicy returns the merchant's grace period for completing partial payments, or
+// invoice.DefaultUnderpaymentPolicy if the merchant has not set one.
+func (p *UnderpaymentPolicies) UnderpaymentPolicy(
+	ctx context.Context, merchantID string,
+) (invoice.UnderpaymentPolicy, error) {
+	merchant, err := p.repository.FindByID(ctx, merchantID)
+	if errors.Is(err, ErrMerchantNotFound) {
+		return invoice.DefaultUnderpaymentPolicy(), nil
+	}
+	if err != nil {
+		return invoice.UnderpaymentPolicy{}, err
+	}
+	if merchant.Settings() == nil || merchant.Settings().PartialPaymentGraceMinutes == nil {
+		return invoice.DefaultUnderpaymentPolicy(), nil
+	}
+
+	minutes := *merchant.Settings().PartialPaymentGraceMinutes
+	return invoice.UnderpaymentPolicy{GracePeriod: time.Duration(minutes) * time.Minute}, nil
+}
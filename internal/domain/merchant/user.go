@@ -0,0 +1,221 @@
+package merchant
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MinPasswordLength is the minimum accepted length for team member passwords.
+const MinPasswordLength = 8
+
+// User represents a team member entity within the Merchant aggregate.
+type User struct {
+	id           string
+	merchantID   string
+	email        string
+	name         string
+	role         UserRole
+	status       UserStatus
+	passwordHash string
+	totpSecret   string
+	totpEnabled  bool
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+// NewUser creates a new active team member with validation.
+func NewUser(id, merchantID, email, name string, role UserRole) (*User, error) {
+	now := shared.Now()
+	return RestoreUser(id, merchantID, email, name, role, UserStatusActive, "", "", false, now, now)
+}
+
+// RestoreUser recreates a team member from storage.
+func RestoreUser(
+	id, merchantID, email, name string,
+	role UserRole,
+	status UserStatus,
+	passwordHash, totpSecret string,
+	totpEnabled bool,
+	createdAt, updatedAt time.Time,
+) (*User, error) {
+	if id == "" {
+		return nil, shared.NewFieldError("user_id", "user ID is required")
+	}
+	if merchantID == "" {
+		return nil, shared.NewFieldError("merchant_id", "merchant ID is required")
+	}
+	if !isValidEmail(email) {
+		return nil, ErrInvalidUserEmail
+	}
+	if len(name) > 255 {
+		return nil, shared.NewFieldError("name", "name cannot exceed 255 characters")
+	}
+	if !role.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRole, role)
+	}
+	if !status.IsValid() {
+		return nil, fmt.Errorf("invalid user status: %s", status)
+	}
+
+	return &User{
+		id:           id,
+		merchantID:   merchantID,
+		email:        strings.ToLower(email),
+		name:         name,
+		role:         role,
+		status:       status,
+		passwordHash: passwordHash,
+		totpSecret:   totpSecret,
+		totpEnabled:  totpEnabled && totpSecret != "",
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}, nil
+}
+
+// ID returns the user ID.
+func (u *User) ID() string {
+	return u.id
+}
+
+// MerchantID returns the merchant ID.
+func (u *User) MerchantID() string {
+	return u.merchantID
+}
+
+// Email returns the user email.
+func (u *User) Email() string {
+	return u.email
+}
+
+// Name returns the user display name.
+func (u *User) Name() string {
+	return u.name
+}
+
+// Role returns the user role.
+func (u *User) Role() UserRole {
+	return u.role
+}
+
+// Status returns the user status.
+func (u *User) Status() UserStatus {
+	return u.status
+}
+
+// PasswordHash returns the bcrypt password hash.
+func (u *User) PasswordHash() string {
+	return u.passwordHash
+}
+
+// TOTPSecret returns the base32 TOTP secret, if enrolled.
+func (u *User) TOTPSecret() string {
+	return u.totpSecret
+}
+
+// TOTPEnabled checks if two-factor authentication is required at login.
+func (u *User) TOTPEnabled() bool {
+	return u.totpEnabled
+}
+
+// CreatedAt returns the creation timestamp.
+func (u *User) CreatedAt() time.Time {
+	return u.createdAt
+}
+
+// UpdatedAt returns the last update timestamp.
+func (u *User) UpdatedAt() time.Time {
+	return u.updatedAt
+}
+
+// IsActive checks if the user is active.
+func (u *User) IsActive() bool {
+	return u.status == UserStatusActive
+}
+
+// HasPermission checks if the user's role grants the permission and the user is active.
+func (u *User) HasPermission(permission string) bool {
+	return u.IsActive() && u.role.HasPermission(permission)
+}
+
+// ChangeRole changes the user role.
+func (u *User) ChangeRole(role UserRole) error {
+	if !role.IsValid() {
+		return fmt.Errorf("%w: %s", ErrInvalidRole, role)
+	}
+
+	u.role = role
+	u.updatedAt = shared.Now()
+	return nil
+}
+
+// Disable deactivates the user.
+func (u *User) Disable() error {
+	if u.status == UserStatusDisabled {
+		return shared.NewError(shared.ErrConflict, ErrCodeInvalidStatusTransition, "user is already disabled")
+	}
+
+	u.status = UserStatusDisabled
+	u.updatedAt = shared.Now()
+	return nil
+}
+
+// SetPassword hashes and stores a new password.
+func (u *User) SetPassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return fmt.Errorf("%w: must be at least %d characters", ErrWeakPassword, MinPasswordLength)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	u.passwordHash = string(hash)
+	u.updatedAt = shared.Now()
+	return nil
+}
+
+// VerifyPassword checks a password against the stored hash.
+func (u *User) VerifyPassword(password string) bool {
+	if u.passwordHash == "" {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(u.passwordHash), []byte(password)) == nil
+}
+
+// SetTOTPSecret stores a pending TOTP secret; it takes effect once EnableTOTP is called. The secret of enabled
+// two-factor authentication is kept: it is only replaced after DisableTOTP, which requires the password.
+func (u *User) SetTOTPSecret(secret string) error {
+	if u.totpEnabled {
+		return ErrTOTPAlreadyEnabled
+	}
+
+	u.totpSecret = secret
+	u.updatedAt = shared.Now()
+	return nil
+}
+
+// EnableTOTP enables two-factor authentication after verifying a code for the pending secret.
+func (u *User) EnableTOTP(code string, at time.Time) error {
+	if u.totpSecret == "" {
+		return ErrTOTPNotConfigured
+	}
+	if !ValidateTOTP(u.totpSecret, code, at) {
+		return ErrInvalidTOTPCode
+	}
+
+	u.totpEnabled = true
+	u.updatedAt = shared.Now()
+	return nil
+}
+
+// DisableTOTP turns off two-factor authentication and discards the secret.
+func (u *User) DisableTOTP() {
+	u.totpSecret = ""
+	u.totpEnabled = false
+	u.updatedAt = shared.Now()
+}
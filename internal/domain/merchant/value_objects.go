@@ -1,12 +1,20 @@
 package merchant
 
 import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/pkg/i18n"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"time"
 )
 
+// MaxPartialPaymentGraceMinutes is the longest grace period, 30 days, a merchant can give customers to complete
+// a partial payment.
+const MaxPartialPaymentGraceMinutes = 30 * 24 * 60
+
 // MerchantSettings represents configuration preferences for a merchant.
 type MerchantSettings struct {
 	DefaultCurrency       string                 `json:"default_currency"`
@@ -16,6 +24,108 @@ type MerchantSettings struct {
 	PaymentTolerance      *PaymentTolerance      `json:"payment_tolerance"`
 	WebhookSettings       *WebhookSettings       `json:"webhook_settings"`
 	CustomFields          map[string]interface{} `json:"custom_fields"`
+	AcceptedCurrencies    []AcceptedCurrency     `json:"accepted_currencies,omitempty"` // Empty accepts every token
+	DefaultLocale         string                 `json:"default_locale,omitempty"`      // Checkout page language
+	// Total minutes an invoice's expiry can be extended by; nil for the default, zero disallows extensions
+	MaxInvoiceExtensionMinutes *int `json:"max_invoice_extension_minutes,omitempty"`
+	// Minutes after its expiry a partially paid invoice can still be paid in full before it is closed underpaid
+	// and the payments received are refunded or credited per the payment tolerance; nil for the default
+	PartialPaymentGraceMinutes *int `json:"partial_payment_grace_minutes,omitempty"`
+	// Sites allowed to call the public API from browsers and embed the payment widget, e.g.
+	// "https://shop.example.com"; empty allows none
+	AllowedOrigins []string `json:"allowed_origins,omitempty"`
+	// JSON Schema the metadata of the merchant's invoices must match; empty accepts any metadata
+	MetadataSchema json.RawMessage `json:"metadata_schema,omitempty" swaggertype:"object"`
+	// Largest invoice metadata in bytes of JSON; nil for invoice.DefaultMaxMetadataBytes
+	MaxMetadataBytes *int `json:"max_metadata_bytes,omitempty"`
+	// Metadata keys invoices can be filtered by when listed, e.g. "order_id"
+	FilterableMetadataKeys []string `json:"filterable_metadata_keys,omitempty"`
+	// Invoices are paid in testnet coins on testnets, and cannot be created with live API keys
+	TestMode bool `json:"test_mode,omitempty"`
+	// IANA time zone, e.g. "Europe/Berlin", whose calendar days bound the merchant's reports; empty for UTC
+	ReportingTimeZone string `json:"reporting_time_zone,omitempty"`
+	// ISO 3166-1 alpha-2 codes of the countries the checkout page refuses customers in, e.g. "KP"
+	RestrictedCountries []string `json:"restricted_countries,omitempty"`
+}
+
+// Validate checks the accepted currencies, that the default locale, if set, is one the checkout page is
+// translated into, that the invoice extension limit and partial payment grace period are within bounds, that the
+// allowed origins are origins, that the reporting time zone is known, that the restricted countries are country
+// codes and that the metadata schema, limit and filterable keys are valid.
+func (s *MerchantSettings) Validate() error {
+	for _, currency := range s.AcceptedCurrencies {
+		if err := currency.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.DefaultLocale != "" && !i18n.IsSupported(s.DefaultLocale) {
+		return fmt.Errorf("%w: unsupported default locale %q", ErrInvalidMerchantSettings, s.DefaultLocale)
+	}
+	if s.MaxInvoiceExtensionMinutes != nil && *s.MaxInvoiceExtensionMinutes < 0 {
+		return fmt.Errorf("%w: max invoice extension cannot be negative", ErrInvalidMerchantSettings)
+	}
+	if s.PartialPaymentGraceMinutes != nil &&
+		(*s.PartialPaymentGraceMinutes < 0 || *s.PartialPaymentGraceMinutes > MaxPartialPaymentGraceMinutes) {
+		return fmt.Errorf("%w: partial payment grace period must be between 0 and %d minutes",
+			ErrInvalidMerchantSettings, MaxPartialPaymentGraceMinutes)
+	}
+	for _, origin := range s.AllowedOrigins {
+		if _, err := NormalizeOrigin(origin); err != nil {
+			return err
+		}
+	}
+	if _, err := ParseReportingTimeZone(s.ReportingTimeZone); err != nil {
+		return err
+	}
+	for _, country := range s.RestrictedCountries {
+		if _, ok := shared.ParseCountryCode(country); !ok {
+			return fmt.Errorf("%w: invalid restricted country %q", ErrInvalidMerchantSettings, country)
+		}
+	}
+	return s.validateMetadataSettings()
+}
+
+// validateMetadataSettings checks the metadata schema, limit and filterable keys.
+func (s *MerchantSettings) validateMetadataSettings() error {
+	if len(s.MetadataSchema) > 0 {
+		if _, err := invoice.CompileMetadataSchema(s.MetadataSchema); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidMerchantSettings, err)
+		}
+	}
+	if s.MaxMetadataBytes != nil && (*s.MaxMetadataBytes < 1 || *s.MaxMetadataBytes > invoice.MaxMetadataBytesLimit) {
+		return fmt.Errorf("%w: max metadata bytes must be between 1 and %d",
+			ErrInvalidMerchantSettings, invoice.MaxMetadataBytesLimit)
+	}
+	if len(s.FilterableMetadataKeys) > invoice.MaxFilterableMetadataKeys {
+		return fmt.Errorf("%w: at most %d metadata keys can be filterable",
+			ErrInvalidMerchantSettings, invoice.MaxFilterableMetadataKeys)
+	}
+	for _, key := range s.FilterableMetadataKeys {
+		if err := invoice.ValidateMetadataKey(key); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidMerchantSettings, err)
+		}
+		if invoice.IsReservedMetadataKey(key) {
+			return fmt.Errorf("%w: metadata key %q is reserved", ErrInvalidMerchantSettings, key)
+		}
+	}
+	return nil
+}
+
+// AcceptedCurrency is a cryptocurrency the merchant accepts, in order of preference.
+type AcceptedCurrency struct {
+	Symbol  string `json:"symbol"`
+	Network string `json:"network,omitempty"` // Empty accepts the symbol on every network
+}
+
+// Validate checks that the accepted currency names a valid symbol and network.
+func (c AcceptedCurrency) Validate() error {
+	if !shared.CryptoCurrency(c.Symbol).IsValid() {
+		return fmt.Errorf("%w: invalid accepted currency %q", ErrInvalidMerchantSettings, c.Symbol)
+	}
+	if c.Network != "" && !shared.BlockchainNetwork(c.Network).IsValid() {
+		return fmt.Errorf("%w: %s has unknown network %q", ErrInvalidMerchantSettings, c.Symbol, c.Network)
+	}
+	return nil
 }
 
 // PaymentTolerance represents under/overpayment handling configuration.
@@ -1,7 +1,10 @@
 package merchant
 
 import (
+	"crypto-checkout/internal/domain/invoice"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -107,3 +110,164 @@ func TestWebhookEndpointConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeOrigin(t *testing.T) {
+	tests := []struct {
+		origin    string
+		expected  string
+		expectErr bool
+	}{
+		{origin: "https://shop.example.com", expected: "https://shop.example.com"},
+		{origin: "HTTPS://Shop.Example.com/", expected: "https://shop.example.com"},
+		{origin: "http://localhost:3000", expected: "http://localhost:3000"},
+		{origin: "https://shop.example.com/checkout", expectErr: true},
+		{origin: "shop.example.com", expectErr: true},
+		{origin: "ftp://shop.example.com", expectErr: true},
+		{origin: "*", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			normalized, err := NormalizeOrigin(tt.origin)
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidMerchantSettings)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, normalized)
+		})
+	}
+}
+
+func TestMerchantSettings_ValidateMetadata(t *testing.T) {
+	maxBytes := func(n int) *int { return &n }
+	tests := []struct {
+		name      string
+		settings  MerchantSettings
+		expectErr bool
+	}{
+		{
+			name: "Valid",
+			settings: MerchantSettings{
+				MetadataSchema:         json.RawMessage(`{"type": "object", "required": ["order_id"]}`),
+				MaxMetadataBytes:       maxBytes(1024),
+				FilterableMetadataKeys: []string{"order_id"},
+			},
+		},
+		{name: "SchemaNotJSON", settings: MerchantSettings{MetadataSchema: json.RawMessage(`{`)}, expectErr: true},
+		{
+			name:      "SchemaNotObject",
+			settings:  MerchantSettings{MetadataSchema: json.RawMessage(`{"type": "array"}`)},
+			expectErr: true,
+		},
+		{name: "ZeroMaxBytes", settings: MerchantSettings{MaxMetadataBytes: maxBytes(0)}, expectErr: true},
+		{
+			name:      "MaxBytesOverLimit",
+			settings:  MerchantSettings{MaxMetadataBytes: maxBytes(invoice.MaxMetadataBytesLimit + 1)},
+			expectErr: true,
+		},
+		{
+			name:      "ReservedFilterableKey",
+			settings:  MerchantSettings{FilterableMetadataKeys: []string{"status"}},
+			expectErr: true,
+		},
+		{
+			name: "TooManyFilterableKeys",
+			settings: MerchantSettings{FilterableMetadataKeys: []string{
+				"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k",
+			}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidMerchantSettings)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMerchantSettings_ValidatePartialPaymentGrace(t *testing.T) {
+	minutes := func(n int) *int { return &n }
+	tests := []struct {
+		name      string
+		grace     *int
+		expectErr bool
+	}{
+		{name: "Default"},
+		{name: "CloseAtExpiry", grace: minutes(0)},
+		{name: "Longest", grace: minutes(MaxPartialPaymentGraceMinutes)},
+		{name: "Negative", grace: minutes(-1), expectErr: true},
+		{name: "OverLimit", grace: minutes(MaxPartialPaymentGraceMinutes + 1), expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := MerchantSettings{PartialPaymentGraceMinutes: tt.grace}
+			err := settings.Validate()
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidMerchantSettings)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMerchantSettings_ValidateReportingTimeZone(t *testing.T) {
+	tests := []struct {
+		name      string
+		timeZone  string
+		expectErr bool
+	}{
+		{name: "Default"},
+		{name: "UTC", timeZone: "UTC"},
+		{name: "IANA", timeZone: "America/New_York"},
+		{name: "Unknown", timeZone: "Mars/Olympus_Mons", expectErr: true},
+		{name: "ServerLocal", timeZone: "Local", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := MerchantSettings{ReportingTimeZone: tt.timeZone}
+			err := settings.Validate()
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidMerchantSettings)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMerchantSettings_ValidateRestrictedCountries(t *testing.T) {
+	settings := MerchantSettings{RestrictedCountries: []string{"KP", "ir"}}
+	require.NoError(t, settings.Validate())
+
+	settings.RestrictedCountries = append(settings.RestrictedCountries, "North Korea")
+	assert.ErrorIs(t, settings.Validate(), ErrInvalidMerchantSettings)
+}
+
+func TestDayRange(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// Dates bind as UTC midnights
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC)
+
+	from, to := DayRange(&start, &end, berlin)
+	require.NotNil(t, from)
+	require.NotNil(t, to)
+	assert.Equal(t, time.Date(2026, 2, 28, 23, 0, 0, 0, time.UTC), from.UTC())
+	// Clocks go forward on the last day, so it ends 23 hours after it began
+	assert.Equal(t, time.Date(2026, 3, 29, 22, 0, 0, 0, time.UTC).Add(-time.Nanosecond), to.UTC())
+
+	from, to = DayRange(nil, &end, time.UTC)
+	assert.Nil(t, from)
+	assert.Equal(t, time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond), *to)
+}
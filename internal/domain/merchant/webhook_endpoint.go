@@ -1,13 +1,19 @@
 package merchant
 
 import (
-	"errors"
+	"crypto-checkout/internal/domain/shared"
 	"fmt"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 )
 
+// How long a rotated-out webhook secret keeps signing webhooks next to its replacement.
+const (
+	DefaultSecretOverlap = 24 * time.Hour
+	MaxSecretOverlap     = 7 * 24 * time.Hour
+)
+
 // WebhookEndpoint represents a webhook endpoint entity within the Merchant aggregate.
 type WebhookEndpoint struct {
 	id           string
@@ -21,8 +27,13 @@ type WebhookEndpoint struct {
 	timeout      int
 	allowedIPs   []string
 	headers      map[string]string
+	apiVersion   WebhookAPIVersion
 	createdAt    time.Time
 	updatedAt    time.Time
+
+	// previousSecret is the secret rotated out, which signs webhooks as well until previousSecretExpiresAt
+	previousSecret          string
+	previousSecretExpiresAt time.Time
 }
 
 // WebhookEndpointValidation represents the validation structure for WebhookEndpoint creation.
@@ -50,31 +61,31 @@ func NewWebhookEndpoint(
 	headers map[string]string,
 ) (*WebhookEndpoint, error) {
 	if id == "" {
-		return nil, errors.New("webhook endpoint ID is required")
+		return nil, shared.NewFieldError("id", "webhook endpoint ID is required")
 	}
 	if merchantID == "" {
-		return nil, errors.New("merchant ID is required")
+		return nil, shared.NewFieldError("merchant_id", "merchant ID is required")
 	}
 	if url == "" {
-		return nil, errors.New("URL is required")
+		return nil, shared.NewFieldError("url", "URL is required")
 	}
 	if len(events) == 0 {
-		return nil, errors.New("at least one event type is required")
+		return nil, shared.NewFieldError("events", "at least one event type is required")
 	}
 	if len(secret) < 32 {
-		return nil, errors.New("secret must be at least 32 characters")
+		return nil, shared.NewFieldError("secret", "secret must be at least 32 characters")
 	}
 	if maxRetries < 0 || maxRetries > 10 {
-		return nil, errors.New("max retries must be between 0 and 10")
+		return nil, shared.NewFieldError("max_retries", "max retries must be between 0 and 10")
 	}
 	if !retryBackoff.IsValid() {
 		return nil, fmt.Errorf("invalid retry backoff strategy: %s", retryBackoff)
 	}
 	if timeout < 5 || timeout > 60 {
-		return nil, errors.New("timeout must be between 5 and 60 seconds")
+		return nil, shared.NewFieldError("timeout", "timeout must be between 5 and 60 seconds")
 	}
 
-	now := time.Now()
+	now := shared.Now()
 	endpoint := &WebhookEndpoint{
 		id:           id,
 		merchantID:   merchantID,
@@ -87,6 +98,7 @@ func NewWebhookEndpoint(
 		timeout:      timeout,
 		allowedIPs:   allowedIPs,
 		headers:      headers,
+		apiVersion:   LatestWebhookAPIVersion,
 		createdAt:    now,
 		updatedAt:    now,
 	}
@@ -137,6 +149,20 @@ func (w *WebhookEndpoint) Secret() string {
 	return w.secret
 }
 
+// PreviousSecret returns the secret rotated out while it still signs webhooks next to the current one, and
+// an empty string once its overlap is over.
+func (w *WebhookEndpoint) PreviousSecret() string {
+	if w.previousSecret == "" || !shared.Now().Before(w.previousSecretExpiresAt) {
+		return ""
+	}
+	return w.previousSecret
+}
+
+// PreviousSecretExpiresAt returns when the secret rotated out stops signing webhooks; zero if none was.
+func (w *WebhookEndpoint) PreviousSecretExpiresAt() time.Time {
+	return w.previousSecretExpiresAt
+}
+
 // Status returns the endpoint status.
 func (w *WebhookEndpoint) Status() EndpointStatus {
 	return w.status
@@ -167,6 +193,11 @@ func (w *WebhookEndpoint) Headers() map[string]string {
 	return w.headers
 }
 
+// APIVersion returns the version of the payloads sent to the endpoint.
+func (w *WebhookEndpoint) APIVersion() WebhookAPIVersion {
+	return w.apiVersion
+}
+
 // CreatedAt returns the creation timestamp.
 func (w *WebhookEndpoint) CreatedAt() time.Time {
 	return w.createdAt
@@ -180,64 +211,109 @@ func (w *WebhookEndpoint) UpdatedAt() time.Time {
 // UpdateURL updates the webhook URL.
 func (w *WebhookEndpoint) UpdateURL(url string) error {
 	if url == "" {
-		return errors.New("URL cannot be empty")
+		return shared.NewFieldError("url", "URL cannot be empty")
 	}
 	w.url = url
-	w.updatedAt = time.Now()
+	w.updatedAt = shared.Now()
 	return nil
 }
 
 // UpdateEvents updates the subscribed events.
 func (w *WebhookEndpoint) UpdateEvents(events []string) error {
 	if len(events) == 0 {
-		return errors.New("at least one event type is required")
+		return shared.NewFieldError("events", "at least one event type is required")
 	}
 	w.events = events
-	w.updatedAt = time.Now()
+	w.updatedAt = shared.Now()
 	return nil
 }
 
-// UpdateSecret updates the webhook secret.
+// UpdateSecret replaces the webhook secret at once; a secret still overlapping from a rotation stops signing
+// webhooks as well.
 func (w *WebhookEndpoint) UpdateSecret(secret string) error {
 	if len(secret) < 32 {
-		return errors.New("secret must be at least 32 characters")
+		return shared.NewFieldError("secret", "secret must be at least 32 characters")
+	}
+	w.secret = secret
+	w.previousSecret = ""
+	w.previousSecretExpiresAt = time.Time{}
+	w.updatedAt = shared.Now()
+	return nil
+}
+
+// RotateSecret replaces the webhook secret, keeping the current one signing webhooks as well for the overlap,
+// so that the receiver can switch to the new secret without rejecting webhooks in between. Without an overlap
+// the current secret stops signing webhooks at once.
+func (w *WebhookEndpoint) RotateSecret(secret string, overlap time.Duration) error {
+	if len(secret) < 32 {
+		return shared.NewFieldError("secret", "secret must be at least 32 characters")
+	}
+	if secret == w.secret {
+		return shared.NewFieldError("secret", "secret must differ from the current secret")
+	}
+	if overlap < 0 || overlap > MaxSecretOverlap {
+		return shared.NewFieldError("overlap", fmt.Sprintf("overlap must be between 0 and %s", MaxSecretOverlap))
+	}
+
+	now := shared.Now()
+	w.previousSecret = ""
+	w.previousSecretExpiresAt = time.Time{}
+	if overlap > 0 {
+		w.previousSecret = w.secret
+		w.previousSecretExpiresAt = now.Add(overlap)
 	}
 	w.secret = secret
-	w.updatedAt = time.Now()
+	w.updatedAt = now
 	return nil
 }
 
+// RestorePreviousSecret sets the secret rotated out and the end of its overlap, as stored.
+func (w *WebhookEndpoint) RestorePreviousSecret(secret string, expiresAt time.Time) {
+	w.previousSecret = secret
+	w.previousSecretExpiresAt = expiresAt
+}
+
 // UpdateMaxRetries updates the maximum retry count.
 func (w *WebhookEndpoint) UpdateMaxRetries(maxRetries int) error {
 	if maxRetries < 0 || maxRetries > 10 {
-		return errors.New("max retries must be between 0 and 10")
+		return shared.NewFieldError("max_retries", "max retries must be between 0 and 10")
 	}
 	w.maxRetries = maxRetries
-	w.updatedAt = time.Now()
+	w.updatedAt = shared.Now()
 	return nil
 }
 
 // UpdateTimeout updates the request timeout.
 func (w *WebhookEndpoint) UpdateTimeout(timeout int) error {
 	if timeout < 5 || timeout > 60 {
-		return errors.New("timeout must be between 5 and 60 seconds")
+		return shared.NewFieldError("timeout", "timeout must be between 5 and 60 seconds")
 	}
 	w.timeout = timeout
-	w.updatedAt = time.Now()
+	w.updatedAt = shared.Now()
 	return nil
 }
 
 // UpdateAllowedIPs updates the allowed IP addresses.
 func (w *WebhookEndpoint) UpdateAllowedIPs(allowedIPs []string) error {
 	w.allowedIPs = allowedIPs
-	w.updatedAt = time.Now()
+	w.updatedAt = shared.Now()
 	return nil
 }
 
 // UpdateHeaders updates the custom headers.
 func (w *WebhookEndpoint) UpdateHeaders(headers map[string]string) error {
 	w.headers = headers
-	w.updatedAt = time.Now()
+	w.updatedAt = shared.Now()
+	return nil
+}
+
+// UpdateAPIVersion moves the endpoint to another payload version.
+func (w *WebhookEndpoint) UpdateAPIVersion(version WebhookAPIVersion) error {
+	if !version.IsValid() {
+		return fmt.Errorf("%w: %s", ErrUnsupportedWebhookAPIVersion, version)
+	}
+	w.apiVersion = version
+	w.updatedAt = shared.Now()
 	return nil
 }
 
@@ -247,7 +323,7 @@ func (w *WebhookEndpoint) ChangeStatus(newStatus EndpointStatus) error {
 		return fmt.Errorf("invalid status: %s", newStatus)
 	}
 	w.status = newStatus
-	w.updatedAt = time.Now()
+	w.updatedAt = shared.Now()
 	return nil
 }
 
@@ -269,7 +345,7 @@ func (w *WebhookEndpoint) IsFailed() bool {
 // SubscribeToEvent adds an event to the subscription list.
 func (w *WebhookEndpoint) SubscribeToEvent(event string) error {
 	if event == "" {
-		return errors.New("event cannot be empty")
+		return shared.NewFieldError("event", "event cannot be empty")
 	}
 
 	// Check if already subscribed
@@ -280,26 +356,26 @@ func (w *WebhookEndpoint) SubscribeToEvent(event string) error {
 	}
 
 	w.events = append(w.events, event)
-	w.updatedAt = time.Now()
+	w.updatedAt = shared.Now()
 	return nil
 }
 
 // UnsubscribeFromEvent removes an event from the subscription list.
 func (w *WebhookEndpoint) UnsubscribeFromEvent(event string) error {
 	if event == "" {
-		return errors.New("event cannot be empty")
+		return shared.NewFieldError("event", "event cannot be empty")
 	}
 
 	// Find and remove the event
 	for i, e := range w.events {
 		if e == event {
 			w.events = append(w.events[:i], w.events[i+1:]...)
-			w.updatedAt = time.Now()
+			w.updatedAt = shared.Now()
 			return nil
 		}
 	}
 
-	return errors.New("event not found in subscription list")
+	return shared.NewFieldError("event", "event not found in subscription list")
 }
 
 // IsSubscribedToEvent checks if the endpoint is subscribed to a specific event.
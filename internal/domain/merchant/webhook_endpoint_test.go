@@ -0,0 +1,53 @@
+package merchant
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// movingClock tells the time it is moved to.
+type movingClock struct{ now time.Time }
+
+func (c *movingClock) Now() time.Time { return c.now }
+
+func TestWebhookEndpoint_RotateSecret(t *testing.T) {
+	clock := &movingClock{now: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	t.Cleanup(shared.SetClock(clock))
+
+	const first = "whsec_0123456789abcdef0123456789abcdef"
+	const second = "whsec_fedcba9876543210fedcba9876543210"
+	endpoint, err := NewWebhookEndpoint("whe-1", "merchant-1", "https://merchant.example/webhook",
+		[]string{"invoice.paid"}, first, 3, BackoffStrategyExponential, 10, nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, endpoint.RotateSecret(second, 24*time.Hour))
+	assert.Equal(t, second, endpoint.Secret())
+	assert.Equal(t, first, endpoint.PreviousSecret())
+	assert.Equal(t, clock.now.Add(24*time.Hour), endpoint.PreviousSecretExpiresAt())
+
+	clock.now = clock.now.Add(24 * time.Hour)
+	assert.Empty(t, endpoint.PreviousSecret(), "the overlap is over")
+
+	t.Run("replacing the secret ends the overlap", func(t *testing.T) {
+		require.NoError(t, endpoint.RotateSecret(first, time.Hour))
+		require.NoError(t, endpoint.UpdateSecret(second))
+		assert.Empty(t, endpoint.PreviousSecret())
+		assert.True(t, endpoint.PreviousSecretExpiresAt().IsZero())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, rotate := range map[string]func() error{
+			"short secret":    func() error { return endpoint.RotateSecret("whsec_short", time.Hour) },
+			"same secret":     func() error { return endpoint.RotateSecret(second, time.Hour) },
+			"negative":        func() error { return endpoint.RotateSecret(first, -time.Hour) },
+			"longer than max": func() error { return endpoint.RotateSecret(first, MaxSecretOverlap+time.Second) },
+		} {
+			require.ErrorIs(t, rotate(), shared.ErrValidation, name)
+		}
+		assert.Equal(t, second, endpoint.Secret())
+	})
+}
@@ -0,0 +1,159 @@
+package merchant
+
+import (
+	"bytes"
+	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WebhookAPIVersion is a dated version of the webhook payload schema. Endpoints stay on the version they were
+// created with, so payload changes only reach the consumers that move their endpoint to a newer version.
+type WebhookAPIVersion string
+
+const (
+	// WebhookAPIVersion20250115 is the original payload schema.
+	WebhookAPIVersion20250115 WebhookAPIVersion = "2025-01-15"
+	// WebhookAPIVersion20261017 adds api_version to the envelope and the number, supersedes and superseded_by
+	// fields to invoice events.
+	WebhookAPIVersion20261017 WebhookAPIVersion = "2026-10-17"
+	// WebhookAPIVersion20261101 adds the custom_fields of invoices to invoice events.
+	WebhookAPIVersion20261101 WebhookAPIVersion = "2026-11-01"
+
+	// LatestWebhookAPIVersion is the version of endpoints created without one.
+	LatestWebhookAPIVersion = WebhookAPIVersion20261101
+	// LegacyWebhookAPIVersion is the version of endpoints stored before versions were introduced.
+	LegacyWebhookAPIVersion = WebhookAPIVersion20250115
+
+	// WebhookTestEventType is the type of the payload sent when testing a webhook endpoint.
+	WebhookTestEventType = "webhook.test"
+)
+
+// String returns the string representation of the version.
+func (v WebhookAPIVersion) String() string {
+	return string(v)
+}
+
+// IsValid checks if the version is supported.
+func (v WebhookAPIVersion) IsValid() bool {
+	if v == LegacyWebhookAPIVersion {
+		return true
+	}
+	for _, change := range webhookVersionChanges {
+		if change.version == v {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportedWebhookAPIVersions returns the supported versions, oldest first.
+func SupportedWebhookAPIVersions() []WebhookAPIVersion {
+	versions := []WebhookAPIVersion{LegacyWebhookAPIVersion}
+	for _, change := range webhookVersionChanges {
+		versions = append(versions, change.version)
+	}
+	return versions
+}
+
+// WebhookPayload is the body of a webhook notification.
+type WebhookPayload struct {
+	ID         string                 `json:"id"`
+	Type       string                 `json:"type"`
+	APIVersion WebhookAPIVersion      `json:"api_version,omitempty"`
+	Created    time.Time              `json:"created"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+// webhookVersionChange describes what a version changed in the payloads. Downgrade undoes the change, turning a
+// payload of the version into one of the version before it.
+type webhookVersionChange struct {
+	version   WebhookAPIVersion
+	downgrade func(payload *WebhookPayload)
+}
+
+// webhookVersionChanges lists every version after the legacy one, oldest first. Payloads are built in the latest
+// schema and downgraded one version at a time, so a change to the payloads - even adding a field - comes with a new
+// version whose downgrade takes it out again. The compatibility tests in webhook_payloads_test.go fail otherwise.
+var webhookVersionChanges = []webhookVersionChange{
+	{
+		version: WebhookAPIVersion20261017,
+		downgrade: func(payload *WebhookPayload) {
+			payload.APIVersion = ""
+			if strings.HasPrefix(payload.Type, "invoice.") {
+				delete(payload.Data, "number")
+				delete(payload.Data, "supersedes")
+				delete(payload.Data, "superseded_by")
+			}
+		},
+	},
+	{
+		version: WebhookAPIVersion20261101,
+		downgrade: func(payload *WebhookPayload) {
+			payload.APIVersion = WebhookAPIVersion20261017
+			if strings.HasPrefix(payload.Type, "invoice.") {
+				delete(payload.Data, "custom_fields")
+			}
+		},
+	},
+}
+
+// NewWebhookPayload renders a domain event as a webhook payload of the given version.
+func NewWebhookPayload(
+	version WebhookAPIVersion,
+	id string,
+	event *shared.BaseDomainEvent,
+) (*WebhookPayload, error) {
+	if !version.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedWebhookAPIVersion, version)
+	}
+	if event == nil {
+		return nil, shared.NewFieldError("event", "webhook event cannot be nil")
+	}
+
+	// Event data is copied through JSON so that downgrades work on plain values and never touch the event; numbers
+	// are kept as written rather than rounded through float64
+	data := make(map[string]interface{})
+	if event.EventData != nil {
+		encoded, err := json.Marshal(event.EventData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode webhook event data: %w", err)
+		}
+		decoder := json.NewDecoder(bytes.NewReader(encoded))
+		decoder.UseNumber()
+		if err := decoder.Decode(&data); err != nil {
+			return nil, fmt.Errorf("webhook event data must be a JSON object: %w", err)
+		}
+	}
+
+	payload := &WebhookPayload{
+		ID:         id,
+		Type:       event.EventType,
+		APIVersion: LatestWebhookAPIVersion,
+		Created:    event.OccurredAt.UTC().Truncate(time.Second),
+		Data:       data,
+	}
+	for i := len(webhookVersionChanges) - 1; i >= 0 && webhookVersionChanges[i].version > version; i-- {
+		webhookVersionChanges[i].downgrade(payload)
+	}
+	return payload, nil
+}
+
+// NewTestWebhookEvent returns the synthetic event sent when testing a webhook endpoint.
+func NewTestWebhookEvent(endpoint *WebhookEndpoint) *shared.BaseDomainEvent {
+	return shared.CreateDomainEvent(WebhookTestEventType, endpoint.ID(), "webhook_endpoint", map[string]interface{}{
+		"endpoint_id": endpoint.ID(),
+		"merchant_id": endpoint.MerchantID(),
+	}, nil)
+}
+
+// RenderWebhookPayload renders a domain event as the JSON body of a webhook of the given version.
+func RenderWebhookPayload(version WebhookAPIVersion, id string, event *shared.BaseDomainEvent) ([]byte, error) {
+	payload, err := NewWebhookPayload(version, id, event)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(payload)
+}
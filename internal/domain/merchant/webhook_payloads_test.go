@@ -0,0 +1,154 @@
+package merchant
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookPayloadFixtures are events carrying every field their payloads have today. A field added to an event is
+// added here too: the golden payloads of the versions before it must not change, which takes a version change that
+// removes the field again.
+func webhookPayloadFixtures() map[string]*shared.BaseDomainEvent {
+	occurredAt := time.Date(2025, 1, 15, 10, 18, 30, 500, time.UTC)
+	event := func(eventType, aggregateType, aggregateID string, data map[string]interface{}) *shared.BaseDomainEvent {
+		event := shared.CreateDomainEvent(eventType, aggregateID, aggregateType, data, nil)
+		event.OccurredAt = occurredAt
+		return event
+	}
+
+	return map[string]*shared.BaseDomainEvent{
+		"invoice_paid": event(shared.EventTypeInvoicePaid, "invoice", "inv_abc123", map[string]interface{}{
+			"invoice_id":    "inv_abc123",
+			"merchant_id":   "mer_123",
+			"total_amount":  "16.49",
+			"crypto_amount": "16.490000",
+			"currency":      "USDT",
+			"status":        "paid",
+			"expires_at":    "2025-01-15T10:30:00Z",
+			"description":   "VPN Service Order",
+			"number":        "INV-2025-000042",
+			"supersedes":    "inv_abc122",
+			"custom_fields": []interface{}{
+				map[string]interface{}{"label": "Order", "value": "A-1042", "type": "text", "visibility": "all"},
+			},
+		}),
+		"settlement_completed": event(shared.EventTypeSettlementCompleted, "settlement", "set_456", map[string]interface{}{
+			"settlement": map[string]interface{}{
+				"id":                  "set_456",
+				"invoice_id":          "inv_abc123",
+				"gross_amount":        "16.49",
+				"platform_fee_amount": "0.1649",
+				"net_amount":          "16.3251",
+				"settled_at":          "2025-01-15T10:18:30Z",
+			},
+			"invoice": map[string]interface{}{
+				"id":       "inv_abc123",
+				"title":    "VPN Service Order",
+				"status":   "paid",
+				"metadata": map[string]interface{}{"order_id": "ord_789"},
+			},
+		}),
+		"payment_confirmation": event(shared.EventTypePaymentConfirmation, "payment", "pay_789", map[string]interface{}{
+			"payment_id":             "pay_789",
+			"invoice_id":             "inv_abc123",
+			"amount":                 "16.49",
+			"status":                 "confirming",
+			"transaction_hash":       "0x4f9e1c2b3a5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f7",
+			"from_address":           "TSenderAddress1234567890123456789012",
+			"to_address":             "TTestAddress123456789012345678901234567890",
+			"detected_at":            "2025-01-15T10:17:30Z",
+			"confirmations":          5,
+			"block_number":           68123456,
+			"timestamp":              "2025-01-15T10:18:30Z",
+			"required_confirmations": 19,
+			"block_hash":             "0000000004101a40e1e8c4c2a3b7d5f6e9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4",
+			"estimated_finality_at":  "2025-01-15T10:19:12Z",
+		}),
+		"webhook_test": event(WebhookTestEventType, "webhook_endpoint", "whe_def456", map[string]interface{}{
+			"endpoint_id": "whe_def456",
+			"merchant_id": "mer_123",
+		}),
+	}
+}
+
+// TestWebhookPayloadCompatibility renders every fixture in every supported version and compares it with the golden
+// payload in testdata/webhook_payloads/<version>/<fixture>.json. Golden payloads of released versions never change.
+func TestWebhookPayloadCompatibility(t *testing.T) {
+	for _, version := range SupportedWebhookAPIVersions() {
+		for name, event := range webhookPayloadFixtures() {
+			t.Run(version.String()+"/"+name, func(t *testing.T) {
+				golden, err := os.ReadFile(filepath.Join("testdata", "webhook_payloads", version.String(), name+".json"))
+				require.NoError(t, err, "every supported version needs a golden payload of every fixture")
+
+				payload, err := RenderWebhookPayload(version, "evt_123", event)
+				require.NoError(t, err)
+				assert.JSONEq(t, string(golden), string(payload))
+			})
+		}
+	}
+}
+
+func TestWebhookPayloads(t *testing.T) {
+	t.Run("LatestVersion_CarriesAllEventData", func(t *testing.T) {
+		event := webhookPayloadFixtures()["invoice_paid"]
+		payload, err := NewWebhookPayload(LatestWebhookAPIVersion, "evt_123", event)
+		require.NoError(t, err)
+		assert.Equal(t, LatestWebhookAPIVersion, payload.APIVersion)
+
+		expected, err := json.Marshal(event.EventData)
+		require.NoError(t, err)
+		actual, err := json.Marshal(payload.Data)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(expected), string(actual))
+	})
+
+	t.Run("Downgrade_LeavesEventUntouched", func(t *testing.T) {
+		event := webhookPayloadFixtures()["invoice_paid"]
+		_, err := NewWebhookPayload(WebhookAPIVersion20250115, "evt_123", event)
+		require.NoError(t, err)
+		assert.Contains(t, event.EventData, "number")
+	})
+
+	t.Run("Numbers_KeepTheirPrecision", func(t *testing.T) {
+		event := shared.CreateDomainEvent("payment.detected", "pay_1", "payment", map[string]interface{}{
+			"amount_base_units": uint64(12345678901234567890),
+		}, nil)
+		payload, err := RenderWebhookPayload(LatestWebhookAPIVersion, "evt_123", event)
+		require.NoError(t, err)
+		assert.Contains(t, string(payload), `"amount_base_units":12345678901234567890`)
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		_, err := RenderWebhookPayload("2024-01-01", "evt_123", webhookPayloadFixtures()["webhook_test"])
+		require.ErrorIs(t, err, ErrUnsupportedWebhookAPIVersion)
+	})
+
+	t.Run("SupportedVersions_OldestFirst", func(t *testing.T) {
+		versions := SupportedWebhookAPIVersions()
+		assert.Equal(t, LegacyWebhookAPIVersion, versions[0])
+		assert.Equal(t, LatestWebhookAPIVersion, versions[len(versions)-1])
+		for i := 1; i < len(versions); i++ {
+			assert.Less(t, versions[i-1], versions[i])
+		}
+	})
+}
+
+func TestWebhookEndpoint_APIVersion(t *testing.T) {
+	endpoint, err := NewWebhookEndpoint("whe_1", "mer_1", "https://merchant.example/webhook",
+		[]string{"invoice.paid"}, "whsec_0123456789abcdef0123456789abcdef", 3, BackoffStrategyExponential, 30, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, LatestWebhookAPIVersion, endpoint.APIVersion())
+
+	require.NoError(t, endpoint.UpdateAPIVersion(WebhookAPIVersion20250115))
+	assert.Equal(t, WebhookAPIVersion20250115, endpoint.APIVersion())
+
+	require.ErrorIs(t, endpoint.UpdateAPIVersion("2030-01-01"), ErrUnsupportedWebhookAPIVersion)
+	assert.Equal(t, WebhookAPIVersion20250115, endpoint.APIVersion())
+}
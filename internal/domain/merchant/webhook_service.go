@@ -2,23 +2,42 @@ package merchant
 
 import (
 	"context"
-	"errors"
+	"crypto-checkout/internal/domain/shared"
 	"fmt"
 
 	"github.com/go-playground/validator/v10"
 	"go.uber.org/zap"
 )
 
+// Retry settings of webhook endpoints created without their own when no retry policy is configured.
+const (
+	DefaultWebhookMaxRetries = 3
+	DefaultWebhookBackoff    = BackoffStrategyExponential
+)
+
+// WebhookRetryPolicy supplies the retry settings of webhook endpoints created without their own, which may
+// change while the application runs.
+type WebhookRetryPolicy interface {
+	WebhookRetryDefaults() (maxRetries int, backoff BackoffStrategy)
+}
+
 // WebhookEndpointServiceImpl implements the WebhookEndpointService interface.
 type WebhookEndpointServiceImpl struct {
 	webhookRepo WebhookEndpointRepository
+	retries     WebhookRetryPolicy
 	logger      *zap.Logger
 }
 
-// NewWebhookEndpointService creates a new webhook endpoint service.
-func NewWebhookEndpointService(webhookRepo WebhookEndpointRepository, logger *zap.Logger) WebhookEndpointService {
+// NewWebhookEndpointService creates a new webhook endpoint service. The retry policy may be nil, in which case
+// endpoints created without retry settings get DefaultWebhookMaxRetries and DefaultWebhookBackoff.
+func NewWebhookEndpointService(
+	webhookRepo WebhookEndpointRepository,
+	retries WebhookRetryPolicy,
+	logger *zap.Logger,
+) WebhookEndpointService {
 	return &WebhookEndpointServiceImpl{
 		webhookRepo: webhookRepo,
+		retries:     retries,
 		logger:      logger,
 	}
 }
@@ -29,7 +48,7 @@ func (s *WebhookEndpointServiceImpl) CreateWebhookEndpoint(
 	req *CreateWebhookEndpointRequest,
 ) (*CreateWebhookEndpointResponse, error) {
 	if req == nil {
-		return nil, errors.New("create webhook endpoint request cannot be nil")
+		return nil, shared.NewFieldError("", "create webhook endpoint request cannot be nil")
 	}
 
 	// Validate request
@@ -44,10 +63,16 @@ func (s *WebhookEndpointServiceImpl) CreateWebhookEndpoint(
 		return nil, fmt.Errorf("failed to generate webhook endpoint ID: %w", err)
 	}
 
-	// Parse retry backoff strategy
-	backoffStrategy := BackoffStrategy(req.RetryBackoff)
+	// Fill in the retry settings the request leaves out
+	maxRetries, backoffStrategy := s.retryDefaults()
+	if req.MaxRetries != nil {
+		maxRetries = *req.MaxRetries
+	}
+	if req.RetryBackoff != "" {
+		backoffStrategy = BackoffStrategy(req.RetryBackoff)
+	}
 	if !backoffStrategy.IsValid() {
-		return nil, fmt.Errorf("invalid retry backoff strategy: %s", req.RetryBackoff)
+		return nil, fmt.Errorf("invalid retry backoff strategy: %s", backoffStrategy)
 	}
 
 	// Create webhook endpoint
@@ -57,7 +82,7 @@ func (s *WebhookEndpointServiceImpl) CreateWebhookEndpoint(
 		req.URL,
 		req.Events,
 		req.Secret,
-		req.MaxRetries,
+		maxRetries,
 		backoffStrategy,
 		req.Timeout,
 		req.AllowedIPs,
@@ -66,6 +91,11 @@ func (s *WebhookEndpointServiceImpl) CreateWebhookEndpoint(
 	if err != nil {
 		return nil, fmt.Errorf("failed to create webhook endpoint: %w", err)
 	}
+	if req.APIVersion != "" {
+		if err := endpoint.UpdateAPIVersion(WebhookAPIVersion(req.APIVersion)); err != nil {
+			return nil, fmt.Errorf("failed to create webhook endpoint: %w", err)
+		}
+	}
 
 	// Save to repository
 	if err := s.webhookRepo.Save(ctx, endpoint); err != nil {
@@ -89,7 +119,7 @@ func (s *WebhookEndpointServiceImpl) GetWebhookEndpoint(
 	req *GetWebhookEndpointRequest,
 ) (*GetWebhookEndpointResponse, error) {
 	if req == nil {
-		return nil, errors.New("get webhook endpoint request cannot be nil")
+		return nil, shared.NewFieldError("", "get webhook endpoint request cannot be nil")
 	}
 
 	// Validate request
@@ -105,7 +135,7 @@ func (s *WebhookEndpointServiceImpl) GetWebhookEndpoint(
 	}
 
 	if endpoint == nil {
-		return nil, errors.New("webhook endpoint not found")
+		return nil, ErrWebhookEndpointNotFound
 	}
 
 	return &GetWebhookEndpointResponse{
@@ -119,7 +149,7 @@ func (s *WebhookEndpointServiceImpl) ListWebhookEndpoints(
 	req *ListWebhookEndpointsRequest,
 ) (*ListWebhookEndpointsResponse, error) {
 	if req == nil {
-		return nil, errors.New("list webhook endpoints request cannot be nil")
+		return nil, shared.NewFieldError("", "list webhook endpoints request cannot be nil")
 	}
 
 	// Validate request
@@ -182,7 +212,7 @@ func (s *WebhookEndpointServiceImpl) UpdateWebhookEndpoint(
 	req *UpdateWebhookEndpointRequest,
 ) (*UpdateWebhookEndpointResponse, error) {
 	if req == nil {
-		return nil, errors.New("update webhook endpoint request cannot be nil")
+		return nil, shared.NewFieldError("", "update webhook endpoint request cannot be nil")
 	}
 
 	// Validate request
@@ -198,7 +228,7 @@ func (s *WebhookEndpointServiceImpl) UpdateWebhookEndpoint(
 	}
 
 	if endpoint == nil {
-		return nil, errors.New("webhook endpoint not found")
+		return nil, ErrWebhookEndpointNotFound
 	}
 
 	// Update fields
@@ -227,7 +257,7 @@ func (s *WebhookEndpointServiceImpl) DeleteWebhookEndpoint(
 	req *DeleteWebhookEndpointRequest,
 ) (*DeleteWebhookEndpointResponse, error) {
 	if req == nil {
-		return nil, errors.New("delete webhook endpoint request cannot be nil")
+		return nil, shared.NewFieldError("", "delete webhook endpoint request cannot be nil")
 	}
 
 	// Validate request
@@ -243,7 +273,7 @@ func (s *WebhookEndpointServiceImpl) DeleteWebhookEndpoint(
 	}
 
 	if endpoint == nil {
-		return nil, errors.New("webhook endpoint not found")
+		return nil, ErrWebhookEndpointNotFound
 	}
 
 	// Delete webhook endpoint
@@ -265,7 +295,7 @@ func (s *WebhookEndpointServiceImpl) TestWebhookEndpoint(
 	req *TestWebhookEndpointRequest,
 ) (*TestWebhookEndpointResponse, error) {
 	if req == nil {
-		return nil, errors.New("test webhook endpoint request cannot be nil")
+		return nil, shared.NewFieldError("", "test webhook endpoint request cannot be nil")
 	}
 
 	// Validate request
@@ -281,7 +311,17 @@ func (s *WebhookEndpointServiceImpl) TestWebhookEndpoint(
 	}
 
 	if endpoint == nil {
-		return nil, errors.New("webhook endpoint not found")
+		return nil, ErrWebhookEndpointNotFound
+	}
+
+	// Render the test webhook the way the endpoint receives its events
+	eventID, err := generateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook event ID: %w", err)
+	}
+	payload, err := RenderWebhookPayload(endpoint.APIVersion(), eventID, NewTestWebhookEvent(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to render test webhook: %w", err)
 	}
 
 	// For now, we'll just simulate a successful test
@@ -303,6 +343,7 @@ func (s *WebhookEndpointServiceImpl) TestWebhookEndpoint(
 		ResponseCode: responseCode,
 		ResponseTime: responseTime,
 		Error:        "",
+		Payload:      payload,
 	}, nil
 }
 
@@ -348,5 +389,18 @@ func (s *WebhookEndpointServiceImpl) updateWebhookEndpointFields(
 			return fmt.Errorf("failed to update webhook endpoint headers: %w", err)
 		}
 	}
+	if req.APIVersion != nil {
+		if err := endpoint.UpdateAPIVersion(WebhookAPIVersion(*req.APIVersion)); err != nil {
+			return fmt.Errorf("failed to update webhook endpoint API version: %w", err)
+		}
+	}
 	return nil
 }
+
+// retryDefaults returns the retry settings of endpoints created without their own.
+func (s *WebhookEndpointServiceImpl) retryDefaults() (int, BackoffStrategy) {
+	if s.retries == nil {
+		return DefaultWebhookMaxRetries, DefaultWebhookBackoff
+	}
+	return s.retries.WebhookRetryDefaults()
+}
@@ -0,0 +1,46 @@
+package payment
+
+import (
+	"crypto-checkout/internal/domain/shared"
+
+	"github.com/shopspring/decimal"
+)
+
+// ConfirmationRule is how many confirmations a payment on a network needs before it is confirmed: Below for
+// amounts under the threshold and AtOrAbove for the others.
+type ConfirmationRule struct {
+	Threshold decimal.Decimal
+	Below     int
+	AtOrAbove int
+}
+
+// ConfirmationPolicy holds the confirmation rule of each network. Payments on networks without a rule need
+// one confirmation.
+type ConfirmationPolicy map[shared.BlockchainNetwork]ConfirmationRule
+
+// ConfirmationPolicySource supplies the confirmation policy in force, which may change while the
+// application runs.
+type ConfirmationPolicySource interface {
+	ConfirmationPolicy() ConfirmationPolicy
+}
+
+// DefaultConfirmationPolicy returns the confirmation rules used when none are configured.
+func DefaultConfirmationPolicy() ConfirmationPolicy {
+	return ConfirmationPolicy{
+		shared.NetworkBitcoin:  {Threshold: decimal.NewFromInt(1000), Below: 1, AtOrAbove: 6},
+		shared.NetworkEthereum: {Threshold: decimal.NewFromInt(10000), Below: 12, AtOrAbove: 30},
+		shared.NetworkTron:     {Threshold: decimal.NewFromInt(5000), Below: 1, AtOrAbove: 3},
+	}
+}
+
+// Required returns the confirmations a payment of the amount on the network needs.
+func (p ConfirmationPolicy) Required(amount *shared.Money, network shared.BlockchainNetwork) int {
+	rule, ok := p[network]
+	if !ok {
+		return 1
+	}
+	if amount.Amount().LessThan(rule.Threshold) {
+		return rule.Below
+	}
+	return rule.AtOrAbove
+}
@@ -11,5 +11,9 @@ var Module = fx.Module("payment-service",
 			NewPaymentService,
 			fx.As(new(PaymentService)),
 		),
+		NewStatisticsService,
+	),
+	fx.Invoke(
+		RegisterStatisticsHandler,
 	),
 )
@@ -22,6 +22,14 @@ const (
 
 	// StatusFailed indicates the payment transaction failed or was reverted.
 	StatusFailed PaymentStatus = "failed"
+
+	// StatusHeld indicates the payment was flagged by compliance screening
+	// and awaits manual review before it can progress.
+	StatusHeld PaymentStatus = "held"
+
+	// StatusReversed indicates a confirmed payment whose transaction was removed
+	// from the chain by a reorganization deeper than its required confirmations.
+	StatusReversed PaymentStatus = "reversed"
 )
 
 // String returns the string representation of the payment status.
@@ -32,7 +40,8 @@ func (ps PaymentStatus) String() string {
 // IsValid checks if the payment status is valid.
 func (ps PaymentStatus) IsValid() bool {
 	switch ps {
-	case StatusDetected, StatusConfirming, StatusConfirmed, StatusOrphaned, StatusFailed:
+	case StatusDetected, StatusConfirming, StatusConfirmed, StatusOrphaned, StatusFailed, StatusHeld,
+		StatusReversed:
 		return true
 	default:
 		return false
@@ -42,7 +51,7 @@ func (ps PaymentStatus) IsValid() bool {
 // IsTerminal returns true if the payment status is a terminal state.
 func (ps PaymentStatus) IsTerminal() bool {
 	switch ps {
-	case StatusConfirmed, StatusFailed:
+	case StatusConfirmed, StatusFailed, StatusReversed:
 		return true
 	default:
 		return false
@@ -62,12 +71,14 @@ func (ps PaymentStatus) CanTransitionTo(target PaymentStatus) bool {
 
 	// Define valid transitions based on the state machine
 	validTransitions := map[PaymentStatus][]PaymentStatus{
-		StatusDetected:   {StatusConfirming, StatusFailed},
+		StatusDetected:   {StatusConfirming, StatusFailed, StatusHeld},
 		StatusConfirming: {StatusConfirmed, StatusOrphaned, StatusFailed},
 		StatusOrphaned:   {StatusDetected, StatusFailed},
-		// Terminal states cannot transition
-		StatusConfirmed: {},
+		StatusHeld:       {StatusDetected, StatusFailed},
+		// Terminal states cannot transition, except a confirmed payment undone by a deep reorg
+		StatusConfirmed: {StatusReversed},
 		StatusFailed:    {},
+		StatusReversed:  {},
 	}
 
 	allowedTransitions, exists := validTransitions[ps]
@@ -54,6 +54,7 @@ func TestPaymentStatus(t *testing.T) {
 	t.Run("CanTransitionTo - valid transitions from detected", func(t *testing.T) {
 		require.True(t, payment.StatusDetected.CanTransitionTo(payment.StatusConfirming))
 		require.True(t, payment.StatusDetected.CanTransitionTo(payment.StatusFailed))
+		require.True(t, payment.StatusDetected.CanTransitionTo(payment.StatusHeld))
 	})
 
 	t.Run("CanTransitionTo - invalid transitions from detected", func(t *testing.T) {
@@ -71,6 +72,15 @@ func TestPaymentStatus(t *testing.T) {
 		require.False(t, payment.StatusConfirming.CanTransitionTo(payment.StatusDetected))
 	})
 
+	t.Run("CanTransitionTo - held payments await review", func(t *testing.T) {
+		require.True(t, payment.StatusHeld.IsValid())
+		require.True(t, payment.StatusHeld.IsActive())
+		require.True(t, payment.StatusHeld.CanTransitionTo(payment.StatusDetected))
+		require.True(t, payment.StatusHeld.CanTransitionTo(payment.StatusFailed))
+		require.False(t, payment.StatusHeld.CanTransitionTo(payment.StatusConfirming))
+		require.False(t, payment.StatusHeld.CanTransitionTo(payment.StatusConfirmed))
+	})
+
 	t.Run("CanTransitionTo - valid transitions from orphaned", func(t *testing.T) {
 		require.True(t, payment.StatusOrphaned.CanTransitionTo(payment.StatusDetected))
 		require.True(t, payment.StatusOrphaned.CanTransitionTo(payment.StatusFailed))
@@ -93,6 +103,14 @@ func TestPaymentStatus(t *testing.T) {
 		require.False(t, payment.StatusFailed.CanTransitionTo(payment.StatusOrphaned))
 	})
 
+	t.Run("CanTransitionTo - confirmed payments are reversed by deep reorgs", func(t *testing.T) {
+		require.True(t, payment.StatusReversed.IsValid())
+		require.True(t, payment.StatusReversed.IsTerminal())
+		require.True(t, payment.StatusConfirmed.CanTransitionTo(payment.StatusReversed))
+		require.False(t, payment.StatusConfirming.CanTransitionTo(payment.StatusReversed))
+		require.False(t, payment.StatusReversed.CanTransitionTo(payment.StatusDetected))
+	})
+
 	t.Run("CanTransitionTo - invalid status", func(t *testing.T) {
 		invalidStatus := payment.PaymentStatus("invalid")
 		require.False(t, payment.StatusDetected.CanTransitionTo(invalidStatus))
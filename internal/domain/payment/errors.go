@@ -2,6 +2,7 @@ package payment
 
 import (
 	"crypto-checkout/internal/domain/shared"
+	"errors"
 )
 
 // PaymentError is an alias for shared.DomainError to maintain consistency.
@@ -27,6 +28,22 @@ var (
 	ErrServiceError             = shared.ErrServiceError
 )
 
+// Event stream errors
+var (
+	ErrInvalidEventStream     = errors.New("invalid payment event stream")
+	ErrConcurrentModification = shared.NewError(shared.ErrConflict, ErrCodeConcurrentModification,
+		"payment was modified concurrently")
+)
+
+// ErrDuplicateTransaction is returned when saving a payment for a transaction another payment already records on
+// the same network.
+var ErrDuplicateTransaction = shared.NewError(shared.ErrConflict, ErrCodePaymentAlreadyExists,
+	"transaction is already recorded by another payment")
+
+// ErrInvalidStatisticsRequest is returned for a statistics request with an unknown interval or an invalid range.
+var ErrInvalidStatisticsRequest = shared.NewError(shared.ErrValidation, shared.ErrCodeInvalidInput,
+	"invalid payment statistics request")
+
 // Payment-specific error codes
 const (
 	ErrCodeInvalidPaymentStatus      = "INVALID_PAYMENT_STATUS"
@@ -37,6 +54,8 @@ const (
 	ErrCodeInvalidNetworkFee         = "INVALID_NETWORK_FEE"
 	ErrCodeInsufficientConfirmations = "INSUFFICIENT_CONFIRMATIONS"
 	ErrCodePaymentAlreadyExists      = "PAYMENT_ALREADY_EXISTS"
+	ErrCodeInvalidEventStream        = "INVALID_EVENT_STREAM"
+	ErrCodeConcurrentModification    = "PAYMENT_CONCURRENT_MODIFICATION"
 )
 
 // Payment-specific error constructors
@@ -44,67 +63,91 @@ const (
 // NewInvalidPaymentStatusError creates an error for invalid payment status.
 func NewInvalidPaymentStatusError(status string) *PaymentError {
 	return NewPaymentError(ErrCodeInvalidPaymentStatus, "invalid payment status", nil).
-		WithDetail("status", status)
+		WithDetail("status", status).
+		WithKind(shared.ErrValidation)
 }
 
 // NewInvalidPaymentTransitionError creates an error for invalid payment transition.
 func NewInvalidPaymentTransitionError(from, to string) *PaymentError {
 	return NewPaymentError(ErrCodeInvalidPaymentTransition, "invalid payment transition", nil).
 		WithDetail("from", from).
-		WithDetail("to", to)
+		WithDetail("to", to).
+		WithKind(shared.ErrInvalidState)
 }
 
 // NewPaymentNotFoundError creates an error for payment not found.
 func NewPaymentNotFoundError(id string) *PaymentError {
 	return NewPaymentError(ErrCodePaymentNotFound, "payment not found", nil).
-		WithDetail("payment_id", id)
+		WithDetail("payment_id", id).
+		WithKind(shared.ErrNotFound)
 }
 
 // NewInvalidPaymentAmountError creates an error for invalid payment amount.
 func NewInvalidPaymentAmountError(amount string) *PaymentError {
 	return NewPaymentError(ErrCodeInvalidPaymentAmount, "invalid payment amount", nil).
-		WithDetail("amount", amount)
+		WithDetail("amount", amount).
+		WithKind(shared.ErrValidation)
 }
 
 // NewInvalidBlockInfoError creates an error for invalid block information.
 func NewInvalidBlockInfoError(reason string) *PaymentError {
 	return NewPaymentError(ErrCodeInvalidBlockInfo, "invalid block information", nil).
-		WithDetail("reason", reason)
+		WithDetail("reason", reason).
+		WithKind(shared.ErrValidation)
 }
 
 // NewInvalidNetworkFeeError creates an error for invalid network fee.
 func NewInvalidNetworkFeeError(fee string) *PaymentError {
 	return NewPaymentError(ErrCodeInvalidNetworkFee, "invalid network fee", nil).
-		WithDetail("fee", fee)
+		WithDetail("fee", fee).
+		WithKind(shared.ErrValidation)
 }
 
 // NewInsufficientConfirmationsError creates an error for insufficient confirmations.
 func NewInsufficientConfirmationsError(current, required int) *PaymentError {
 	return NewPaymentError(ErrCodeInsufficientConfirmations, "insufficient confirmations", nil).
 		WithDetail("current", current).
-		WithDetail("required", required)
+		WithDetail("required", required).
+		WithKind(shared.ErrInvalidState)
 }
 
-// NewPaymentAlreadyExistsError creates an error for duplicate payment.
-func NewPaymentAlreadyExistsError(txHash string) *PaymentError {
+// NewPaymentAlreadyExistsError creates an error for a transaction already recorded for another invoice.
+func NewPaymentAlreadyExistsError(txHash, invoiceID string) *PaymentError {
 	return NewPaymentError(ErrCodePaymentAlreadyExists, "payment already exists", nil).
-		WithDetail("transaction_hash", txHash)
+		WithDetail("transaction_hash", txHash).
+		WithDetail("invoice_id", invoiceID).
+		WithKind(shared.ErrConflict)
 }
 
 // NewInvalidTransactionHashError creates an error for invalid transaction hash.
 func NewInvalidTransactionHashError(hash string) *PaymentError {
 	return NewPaymentError(shared.ErrCodeValidationFailed, "invalid transaction hash", nil).
-		WithDetail("hash", hash)
+		WithDetail("hash", hash).
+		WithKind(shared.ErrValidation)
 }
 
 // NewInvalidAddressError creates an error for invalid payment address.
 func NewInvalidAddressError(address string) *PaymentError {
 	return NewPaymentError(shared.ErrCodeValidationFailed, "invalid payment address", nil).
-		WithDetail("address", address)
+		WithDetail("address", address).
+		WithKind(shared.ErrValidation)
 }
 
 // NewInvalidConfirmationCountError creates an error for invalid confirmation count.
 func NewInvalidConfirmationCountError(count string) *PaymentError {
 	return NewPaymentError(shared.ErrCodeValidationFailed, "invalid confirmation count", nil).
-		WithDetail("count", count)
+		WithDetail("count", count).
+		WithKind(shared.ErrValidation)
+}
+
+// NewInvalidEventStreamError creates an error for an event stream that cannot be replayed.
+func NewInvalidEventStreamError(reason string) *PaymentError {
+	return NewPaymentError(ErrCodeInvalidEventStream, reason, ErrInvalidEventStream)
+}
+
+// NewConcurrentModificationError creates an error for events appended to a stream that has moved on.
+func NewConcurrentModificationError(id string, version int) *PaymentError {
+	return NewPaymentError(ErrCodeConcurrentModification, "payment event stream has moved on", ErrConcurrentModification).
+		WithDetail("payment_id", id).
+		WithDetail("version", version)
 }
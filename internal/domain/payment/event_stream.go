@@ -0,0 +1,337 @@
+package payment
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PaymentEventType identifies a fact recorded in a payment's event stream.
+type PaymentEventType string
+
+const (
+	// PaymentEventDetected records the first sighting of the transaction and carries everything
+	// needed to rebuild the payment. It is always the first event of a stream.
+	PaymentEventDetected PaymentEventType = "detected"
+
+	// PaymentEventBlockInfoUpdated records the block the transaction was seen in.
+	PaymentEventBlockInfoUpdated PaymentEventType = "block_info_updated"
+
+	// PaymentEventConfirmationsUpdated records a new confirmation count.
+	PaymentEventConfirmationsUpdated PaymentEventType = "confirmations_updated"
+
+	// PaymentEventConfirmationsRequired records a raise of the confirmations the payment needs to be confirmed.
+	PaymentEventConfirmationsRequired PaymentEventType = "confirmations_required"
+
+	// PaymentEventNetworkFeeUpdated records the network fee paid for the transaction.
+	PaymentEventNetworkFeeUpdated PaymentEventType = "network_fee_updated"
+
+	// PaymentEventIncludedInBlock records the move to confirming once the transaction is in a block.
+	PaymentEventIncludedInBlock PaymentEventType = "included_in_block"
+
+	// PaymentEventConfirmed records that the payment reached its required confirmations.
+	PaymentEventConfirmed PaymentEventType = "confirmed"
+
+	// PaymentEventOrphaned records that the block holding the transaction was reorganized away.
+	PaymentEventOrphaned PaymentEventType = "orphaned"
+
+	// PaymentEventFailed records that the transaction failed or was reverted.
+	PaymentEventFailed PaymentEventType = "failed"
+
+	// PaymentEventHeld records that compliance screening held the payment for review.
+	PaymentEventHeld PaymentEventType = "held"
+
+	// PaymentEventReleased records that a held payment was released after review.
+	PaymentEventReleased PaymentEventType = "released"
+
+	// PaymentEventRedetected records that an orphaned transaction was seen again.
+	PaymentEventRedetected PaymentEventType = "redetected"
+
+	// PaymentEventReversed records that a reorganization removed the transaction after it was confirmed.
+	PaymentEventReversed PaymentEventType = "reversed"
+)
+
+// String returns the string representation of the event type.
+func (t PaymentEventType) String() string {
+	return string(t)
+}
+
+// IsValid checks if the event type is valid.
+func (t PaymentEventType) IsValid() bool {
+	switch t {
+	case PaymentEventDetected, PaymentEventBlockInfoUpdated, PaymentEventConfirmationsUpdated,
+		PaymentEventConfirmationsRequired, PaymentEventNetworkFeeUpdated:
+		return true
+	default:
+		_, ok := statusEventTargets[t]
+		return ok
+	}
+}
+
+// statusEventTargets maps status change events to the status they move the payment to.
+var statusEventTargets = map[PaymentEventType]PaymentStatus{
+	PaymentEventIncludedInBlock: StatusConfirming,
+	PaymentEventConfirmed:       StatusConfirmed,
+	PaymentEventOrphaned:        StatusOrphaned,
+	PaymentEventFailed:          StatusFailed,
+	PaymentEventHeld:            StatusHeld,
+	PaymentEventReleased:        StatusDetected,
+	PaymentEventRedetected:      StatusDetected,
+	PaymentEventReversed:        StatusReversed,
+}
+
+// PaymentEventData holds the facts an event carries. Fields that do not apply to the event type are empty.
+type PaymentEventData struct {
+	InvoiceID             string `json:"invoice_id,omitempty"`
+	Amount                string `json:"amount,omitempty"`
+	AmountUnit            string `json:"amount_unit,omitempty"`
+	Currency              string `json:"currency,omitempty"`
+	FromAddress           string `json:"from_address,omitempty"`
+	ToAddress             string `json:"to_address,omitempty"`
+	Network               string `json:"network,omitempty"`
+	TransactionHash       string `json:"transaction_hash,omitempty"`
+	RequiredConfirmations int    `json:"required_confirmations,omitempty"`
+	Confirmations         int    `json:"confirmations,omitempty"`
+	BlockNumber           int64  `json:"block_number,omitempty"`
+	BlockHash             string `json:"block_hash,omitempty"`
+	NetworkFee            string `json:"network_fee,omitempty"`
+	NetworkFeeUnit        string `json:"network_fee_unit,omitempty"`
+	NetworkFeeCurrency    string `json:"network_fee_currency,omitempty"`
+}
+
+// PaymentEvent is an immutable entry in a payment's append-only event stream.
+// Versions number the events of a stream consecutively from 1.
+type PaymentEvent struct {
+	PaymentID  shared.PaymentID
+	Version    int
+	Type       PaymentEventType
+	Data       PaymentEventData
+	OccurredAt time.Time
+}
+
+// ReplayPayment rebuilds a payment by applying its events in order.
+// Replaying a prefix of the stream yields the payment as it was at that version.
+func ReplayPayment(events []*PaymentEvent) (*Payment, error) {
+	if len(events) == 0 {
+		return nil, NewInvalidEventStreamError("event stream is empty")
+	}
+	if events[0].Type != PaymentEventDetected {
+		return nil, NewInvalidEventStreamError("event stream must start with a detected event")
+	}
+
+	p := &Payment{id: events[0].PaymentID}
+	for _, event := range events {
+		if event.PaymentID != p.id {
+			return nil, NewInvalidEventStreamError("event stream mixes payments")
+		}
+		if event.Version != p.version+1 {
+			return nil, NewInvalidEventStreamError(
+				fmt.Sprintf("expected event version %d, got %d", p.version+1, event.Version))
+		}
+		if err := p.apply(event); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// Version returns the version of the last event applied to the payment, including pending ones.
+func (p *Payment) Version() int {
+	return p.version
+}
+
+// PendingEvents returns the events recorded since the payment was loaded or last saved.
+func (p *Payment) PendingEvents() []*PaymentEvent {
+	return p.pendingEvents
+}
+
+// ClearPendingEvents marks the pending events as persisted.
+func (p *Payment) ClearPendingEvents() {
+	p.pendingEvents = nil
+}
+
+// record applies a new event to the payment and queues it for persistence.
+func (p *Payment) record(eventType PaymentEventType, data PaymentEventData) error {
+	event := &PaymentEvent{
+		PaymentID:  p.id,
+		Version:    p.version + 1,
+		Type:       eventType,
+		Data:       data,
+		OccurredAt: eventTime(),
+	}
+	if err := p.apply(event); err != nil {
+		return err
+	}
+
+	p.pendingEvents = append(p.pendingEvents, event)
+	return nil
+}
+
+// eventTime returns the current time at the microsecond precision event stores keep,
+// so state replayed from stored events matches the state the events were recorded from.
+func eventTime() time.Time {
+	return shared.Now().UTC().Truncate(time.Microsecond)
+}
+
+// recordStatusChange records the event that moves the payment to the target status.
+func (p *Payment) recordStatusChange(target PaymentStatus) error {
+	eventType, ok := statusChangeEvent(p.status, target)
+	if !ok {
+		return NewInvalidPaymentTransitionError(string(p.status), string(target))
+	}
+	return p.record(eventType, PaymentEventData{})
+}
+
+// statusChangeEvent returns the event type that moves a payment between statuses.
+func statusChangeEvent(from, to PaymentStatus) (PaymentEventType, bool) {
+	switch to {
+	case StatusDetected:
+		if from == StatusHeld {
+			return PaymentEventReleased, true
+		}
+		return PaymentEventRedetected, true
+	case StatusConfirming:
+		return PaymentEventIncludedInBlock, true
+	case StatusConfirmed:
+		return PaymentEventConfirmed, true
+	case StatusOrphaned:
+		return PaymentEventOrphaned, true
+	case StatusFailed:
+		return PaymentEventFailed, true
+	case StatusHeld:
+		return PaymentEventHeld, true
+	case StatusReversed:
+		return PaymentEventReversed, true
+	default:
+		return "", false
+	}
+}
+
+// apply projects an event onto the payment state.
+func (p *Payment) apply(event *PaymentEvent) error {
+	switch event.Type {
+	case PaymentEventDetected:
+		if err := p.applyDetected(event); err != nil {
+			return err
+		}
+	case PaymentEventBlockInfoUpdated:
+		blockInfo, err := NewBlockInfo(event.Data.BlockNumber, event.Data.BlockHash)
+		if err != nil {
+			return err
+		}
+		p.blockInfo = blockInfo
+	case PaymentEventConfirmationsUpdated:
+		confirmations, err := NewConfirmationCount(event.Data.Confirmations)
+		if err != nil {
+			return err
+		}
+		p.confirmations = confirmations
+	case PaymentEventConfirmationsRequired:
+		p.requiredConfirmations = event.Data.RequiredConfirmations
+	case PaymentEventNetworkFeeUpdated:
+		fee, err := parseEventMoney(event.Data.NetworkFee, event.Data.NetworkFeeUnit)
+		if err != nil {
+			return err
+		}
+		networkFee, err := NewNetworkFee(fee, shared.CryptoCurrency(event.Data.NetworkFeeCurrency))
+		if err != nil {
+			return err
+		}
+		p.networkFee = networkFee
+	default:
+		target, ok := statusEventTargets[event.Type]
+		if !ok {
+			return NewInvalidEventStreamError("unknown payment event type " + event.Type.String())
+		}
+		p.status = target
+		if target == StatusConfirmed && p.timestamps.ConfirmedAt() == nil {
+			p.timestamps.SetConfirmedAt(event.OccurredAt)
+		}
+	}
+
+	p.version = event.Version
+	p.timestamps.SetUpdatedAt(event.OccurredAt)
+	return nil
+}
+
+// applyDetected rebuilds the payment from the event that opens its stream.
+func (p *Payment) applyDetected(event *PaymentEvent) error {
+	if p.version != 0 {
+		return NewInvalidEventStreamError("detected event must open the event stream")
+	}
+
+	if err := p.applyIdentity(event.Data); err != nil {
+		return err
+	}
+	confirmations, err := NewConfirmationCount(0)
+	if err != nil {
+		return err
+	}
+
+	p.status = StatusDetected
+	p.confirmations = confirmations
+	p.timestamps = newPaymentTimestampsAt(event.OccurredAt)
+	return nil
+}
+
+// applyIdentity sets the facts fixed when the payment is detected.
+func (p *Payment) applyIdentity(data PaymentEventData) error {
+	money, err := parseEventMoney(data.Amount, data.AmountUnit)
+	if err != nil {
+		return err
+	}
+	amount, err := NewPaymentAmount(money, shared.CryptoCurrency(data.Currency))
+	if err != nil {
+		return err
+	}
+	toAddress, err := NewPaymentAddress(data.ToAddress, shared.BlockchainNetwork(data.Network))
+	if err != nil {
+		return err
+	}
+	transactionHash, err := NewTransactionHash(data.TransactionHash)
+	if err != nil {
+		return err
+	}
+
+	p.invoiceID = shared.InvoiceID(data.InvoiceID)
+	p.amount = amount
+	p.fromAddress = data.FromAddress
+	p.toAddress = toAddress
+	p.transactionHash = transactionHash
+	p.requiredConfirmations = data.RequiredConfirmations
+	return nil
+}
+
+// detectedEventData captures a newly detected payment for the event that opens its stream.
+func detectedEventData(p *Payment) PaymentEventData {
+	return PaymentEventData{
+		InvoiceID:             string(p.invoiceID),
+		Amount:                eventAmount(p.amount.Amount().Amount()),
+		AmountUnit:            p.amount.Amount().Currency(),
+		Currency:              p.amount.Currency().String(),
+		FromAddress:           p.fromAddress,
+		ToAddress:             p.toAddress.Address(),
+		Network:               p.toAddress.Network().String(),
+		TransactionHash:       p.transactionHash.String(),
+		RequiredConfirmations: p.requiredConfirmations,
+	}
+}
+
+// eventAmount formats an amount for an event, keeping its scale so replay restores it exactly.
+func eventAmount(amount decimal.Decimal) string {
+	if amount.Exponent() < 0 {
+		return amount.StringFixed(-amount.Exponent())
+	}
+	return amount.String()
+}
+
+// parseEventMoney parses an amount recorded in an event in either a fiat or a crypto currency.
+func parseEventMoney(amount, currency string) (*shared.Money, error) {
+	if shared.CryptoCurrency(currency).IsValid() {
+		return shared.NewMoneyWithCrypto(amount, shared.CryptoCurrency(currency))
+	}
+	return shared.NewMoney(amount, shared.Currency(currency))
+}
@@ -0,0 +1,97 @@
+package payment_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payment"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentEventStream(t *testing.T) {
+	t.Run("NewPayment opens the stream with a detected event", func(t *testing.T) {
+		testPayment := createTestPayment()
+
+		events := testPayment.PendingEvents()
+		require.Len(t, events, 1)
+		require.Equal(t, payment.PaymentEventDetected, events[0].Type)
+		require.Equal(t, 1, events[0].Version)
+		require.Equal(t, 1, testPayment.Version())
+		require.Equal(t, testPayment.TransactionHash().String(), events[0].Data.TransactionHash)
+	})
+
+	t.Run("changes and transitions are recorded in order", func(t *testing.T) {
+		testPayment := createTestPayment()
+		require.NoError(t, testPayment.UpdateBlockInfo(12345, "0xblock"))
+		fsm := payment.NewPaymentFSM(testPayment)
+		require.NoError(t, fsm.Event(context.Background(), "include_in_block"))
+		require.NoError(t, testPayment.UpdateConfirmations(nil, 6))
+		require.NoError(t, fsm.Event(context.Background(), "confirm"))
+
+		var types []payment.PaymentEventType
+		for _, event := range testPayment.PendingEvents() {
+			types = append(types, event.Type)
+		}
+		require.Equal(t, []payment.PaymentEventType{
+			payment.PaymentEventDetected,
+			payment.PaymentEventBlockInfoUpdated,
+			payment.PaymentEventIncludedInBlock,
+			payment.PaymentEventConfirmationsUpdated,
+			payment.PaymentEventConfirmed,
+		}, types)
+		require.Equal(t, 5, testPayment.Version())
+	})
+
+	t.Run("ReplayPayment projects the current state", func(t *testing.T) {
+		testPayment := createTestPayment()
+		require.NoError(t, testPayment.UpdateBlockInfo(12345, "0xblock"))
+		fsm := payment.NewPaymentFSM(testPayment)
+		require.NoError(t, fsm.Event(context.Background(), "include_in_block"))
+		require.NoError(t, testPayment.UpdateConfirmations(nil, 6))
+		require.NoError(t, fsm.Event(context.Background(), "confirm"))
+
+		replayed, err := payment.ReplayPayment(testPayment.PendingEvents())
+		require.NoError(t, err)
+		require.Empty(t, replayed.PendingEvents())
+		require.Equal(t, testPayment.ID(), replayed.ID())
+		require.Equal(t, testPayment.InvoiceID(), replayed.InvoiceID())
+		require.Equal(t, testPayment.Amount(), replayed.Amount())
+		require.Equal(t, testPayment.ToAddress().String(), replayed.ToAddress().String())
+		require.Equal(t, payment.StatusConfirmed, replayed.Status())
+		require.Equal(t, 6, replayed.Confirmations().Int())
+		require.Equal(t, testPayment.BlockInfo(), replayed.BlockInfo())
+		require.Equal(t, testPayment.ConfirmedAt(), replayed.ConfirmedAt())
+		require.Equal(t, testPayment.DetectedAt(), replayed.DetectedAt())
+		require.Equal(t, testPayment.UpdatedAt(), replayed.UpdatedAt())
+		require.Equal(t, testPayment.Version(), replayed.Version())
+	})
+
+	t.Run("replaying a prefix rolls back to an earlier version", func(t *testing.T) {
+		testPayment := createTestPayment()
+		require.NoError(t, testPayment.UpdateBlockInfo(12345, "0xblock"))
+		fsm := payment.NewPaymentFSM(testPayment)
+		require.NoError(t, fsm.Event(context.Background(), "include_in_block"))
+		require.NoError(t, fsm.Event(context.Background(), "orphan"))
+
+		beforeReorg, err := payment.ReplayPayment(testPayment.PendingEvents()[:3])
+		require.NoError(t, err)
+		require.Equal(t, payment.StatusConfirming, beforeReorg.Status())
+		require.Equal(t, 3, beforeReorg.Version())
+	})
+
+	t.Run("ReplayPayment rejects malformed streams", func(t *testing.T) {
+		_, err := payment.ReplayPayment(nil)
+		require.ErrorIs(t, err, payment.ErrInvalidEventStream)
+
+		testPayment := createTestPayment()
+		require.NoError(t, testPayment.UpdateConfirmations(nil, 1))
+		require.NoError(t, testPayment.UpdateConfirmations(nil, 2))
+		events := testPayment.PendingEvents()
+
+		_, err = payment.ReplayPayment(events[1:])
+		require.ErrorIs(t, err, payment.ErrInvalidEventStream)
+
+		_, err = payment.ReplayPayment([]*payment.PaymentEvent{events[0], events[2]})
+		require.ErrorIs(t, err, payment.ErrInvalidEventStream)
+	})
+}
@@ -1,16 +1,44 @@
 package payment
 
+import (
+	"crypto-checkout/internal/domain/shared"
+)
+
 // Helper functions for common event data patterns
-func createPaymentEventData(payment *Payment) map[string]interface{} {
-	return map[string]interface{}{
-		"payment_id":       string(payment.ID()),
-		"invoice_id":       string(payment.InvoiceID()),
-		"amount":           payment.Amount(),
-		"transaction_hash": payment.TransactionHash().String(),
-		"from_address":     payment.FromAddress(),
-		"to_address":       payment.ToAddress().Address(),
-		"detected_at":      payment.DetectedAt(),
-		"confirmations":    payment.Confirmations().Int(),
-		"block_number":     payment.BlockInfo().Number(),
+func createPaymentEventData(payment *Payment) shared.PaymentEvent {
+	data := shared.PaymentEvent{
+		PaymentID:       string(payment.ID()),
+		InvoiceID:       string(payment.InvoiceID()),
+		Amount:          payment.Amount().Amount().Amount().String(),
+		Status:          payment.Status().String(),
+		TransactionHash: payment.TransactionHash().String(),
+		FromAddress:     payment.FromAddress(),
+		ToAddress:       payment.ToAddress().Address(),
+		DetectedAt:      payment.DetectedAt(),
+		Confirmations:   payment.Confirmations().Int(),
+		Timestamp:       shared.Now().UTC(),
+	}
+	// Newly detected payments are not in a block yet
+	if payment.BlockInfo() != nil {
+		data.BlockNumber = payment.BlockInfo().Number()
+	}
+	return data
+}
+
+// createPaymentConfirmationEventData describes a payment at one of its confirmations. Finality is estimated from
+// the block time of the network and the confirmations the payment still misses now, which may be fewer than at the
+// reported confirmation when a notification skipped blocks.
+func createPaymentConfirmationEventData(payment *Payment, confirmations int) shared.PaymentConfirmationEvent {
+	data := shared.PaymentConfirmationEvent{
+		PaymentEvent:          createPaymentEventData(payment),
+		RequiredConfirmations: payment.RequiredConfirmations(),
+	}
+	data.Confirmations = confirmations
+
+	missing := max(data.RequiredConfirmations-payment.Confirmations().Int(), 0)
+	data.EstimatedFinalityAt = data.Timestamp.Add(EstimateConfirmationTime(payment.ToAddress().Network(), missing))
+	if payment.BlockInfo() != nil {
+		data.BlockHash = payment.BlockInfo().Hash()
 	}
+	return data
 }
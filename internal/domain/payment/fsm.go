@@ -3,7 +3,6 @@ package payment
 import (
 	"context"
 	"crypto-checkout/internal/domain/shared"
-	"time"
 
 	"github.com/looplab/fsm"
 )
@@ -22,6 +21,7 @@ func NewPaymentFSM(payment *Payment) *PaymentFSM {
 			// From detected state
 			{Name: "include_in_block", Src: []string{string(StatusDetected)}, Dst: string(StatusConfirming)},
 			{Name: "fail", Src: []string{string(StatusDetected)}, Dst: string(StatusFailed)},
+			{Name: "hold", Src: []string{string(StatusDetected)}, Dst: string(StatusHeld)},
 
 			// From confirming state
 			{Name: "confirm", Src: []string{string(StatusConfirming)}, Dst: string(StatusConfirmed)},
@@ -32,6 +32,13 @@ func NewPaymentFSM(payment *Payment) *PaymentFSM {
 			{Name: "detect", Src: []string{string(StatusOrphaned)}, Dst: string(StatusDetected)},
 			{Name: "fail", Src: []string{string(StatusOrphaned)}, Dst: string(StatusFailed)},
 
+			// From held state
+			{Name: "release", Src: []string{string(StatusHeld)}, Dst: string(StatusDetected)},
+			{Name: "fail", Src: []string{string(StatusHeld)}, Dst: string(StatusFailed)},
+
+			// From confirmed state, when a reorganization deeper than the required confirmations drops the transaction
+			{Name: "reverse", Src: []string{string(StatusConfirmed)}, Dst: string(StatusReversed)},
+
 			// Terminal states have no outgoing transitions
 		},
 		fsm.Callbacks{
@@ -60,6 +67,14 @@ func NewPaymentFSM(payment *Payment) *PaymentFSM {
 					}
 				}
 			},
+			"before_reverse": func(_ context.Context, e *fsm.Event) {
+				if len(e.Args) > 0 {
+					payment := e.Args[0].(*Payment)
+					if err := CanReverse(payment); err != nil {
+						e.Cancel(err)
+					}
+				}
+			},
 			"before_detect": func(_ context.Context, e *fsm.Event) {
 				if len(e.Args) > 0 {
 					payment := e.Args[0].(*Payment)
@@ -68,34 +83,38 @@ func NewPaymentFSM(payment *Payment) *PaymentFSM {
 					}
 				}
 			},
-			"before_fail": func(_ context.Context, e *fsm.Event) {
+			"before_hold": func(_ context.Context, e *fsm.Event) {
 				if len(e.Args) > 0 {
 					payment := e.Args[0].(*Payment)
-					if err := CanFail(payment); err != nil {
+					if err := CanHold(payment); err != nil {
 						e.Cancel(err)
 					}
 				}
 			},
-
-			// State entry callbacks
-			"enter_confirmed": func(_ context.Context, e *fsm.Event) {
+			"before_release": func(_ context.Context, e *fsm.Event) {
+				if len(e.Args) > 0 {
+					payment := e.Args[0].(*Payment)
+					if err := CanRelease(payment); err != nil {
+						e.Cancel(err)
+					}
+				}
+			},
+			"before_fail": func(_ context.Context, e *fsm.Event) {
 				if len(e.Args) > 0 {
 					payment := e.Args[0].(*Payment)
-					now := time.Now().UTC()
-					if payment.ConfirmedAt() == nil {
-						payment.SetConfirmedAt(now)
+					if err := CanFail(payment); err != nil {
+						e.Cancel(err)
 					}
-					// Update payment status to match FSM state
-					payment.status = StatusConfirmed
-					payment.timestamps.SetUpdatedAt(now)
 				}
 			},
+
+			// State entry callback: record the transition in the payment's event stream
 			"enter_state": func(_ context.Context, e *fsm.Event) {
 				if len(e.Args) > 0 {
 					payment := e.Args[0].(*Payment)
-					// Update payment status to match FSM state
-					payment.status = PaymentStatus(e.Dst)
-					payment.timestamps.SetUpdatedAt(time.Now().UTC())
+					if err := payment.recordStatusChange(PaymentStatus(e.Dst)); err != nil {
+						e.Err = err
+					}
 				}
 			},
 		},
@@ -153,7 +172,9 @@ func (pfsm *PaymentFSM) GetValidTransitions() []PaymentStatus {
 	current := pfsm.CurrentStatus()
 	var valid []PaymentStatus
 
-	for _, status := range []PaymentStatus{StatusDetected, StatusConfirming, StatusConfirmed, StatusOrphaned, StatusFailed} {
+	for _, status := range []PaymentStatus{
+		StatusDetected, StatusConfirming, StatusConfirmed, StatusOrphaned, StatusFailed, StatusHeld, StatusReversed,
+	} {
 		if current.CanTransitionTo(status) {
 			valid = append(valid, status)
 		}
@@ -199,6 +220,15 @@ func CanOrphan(payment *Payment) error {
 	return nil
 }
 
+// CanReverse checks if a confirmed payment can be reversed by a deep reorganization.
+func CanReverse(payment *Payment) error {
+	if payment.Status() != StatusConfirmed {
+		return NewInvalidPaymentTransitionError(string(payment.Status()), string(StatusReversed))
+	}
+
+	return nil
+}
+
 // CanDetect checks if the payment can be detected again.
 func CanDetect(payment *Payment) error {
 	if payment.Status() != StatusOrphaned {
@@ -208,6 +238,24 @@ func CanDetect(payment *Payment) error {
 	return nil
 }
 
+// CanHold checks if the payment can be held for compliance review.
+func CanHold(payment *Payment) error {
+	if payment.Status() != StatusDetected {
+		return NewInvalidPaymentTransitionError(string(payment.Status()), string(StatusHeld))
+	}
+
+	return nil
+}
+
+// CanRelease checks if a held payment can be released after review.
+func CanRelease(payment *Payment) error {
+	if payment.Status() != StatusHeld {
+		return NewInvalidPaymentTransitionError(string(payment.Status()), string(StatusDetected))
+	}
+
+	return nil
+}
+
 // CanFail checks if the payment can fail.
 func CanFail(payment *Payment) error {
 	// Payment can fail from any non-terminal state
@@ -223,11 +271,15 @@ func statusToEvent(from, to PaymentStatus) string {
 	transitions := map[string]string{
 		string(StatusDetected) + "->" + string(StatusConfirming):  "include_in_block",
 		string(StatusDetected) + "->" + string(StatusFailed):      "fail",
+		string(StatusDetected) + "->" + string(StatusHeld):        "hold",
 		string(StatusConfirming) + "->" + string(StatusConfirmed): "confirm",
 		string(StatusConfirming) + "->" + string(StatusOrphaned):  "orphan",
 		string(StatusConfirming) + "->" + string(StatusFailed):    "fail",
 		string(StatusOrphaned) + "->" + string(StatusDetected):    "detect",
 		string(StatusOrphaned) + "->" + string(StatusFailed):      "fail",
+		string(StatusHeld) + "->" + string(StatusDetected):        "release",
+		string(StatusHeld) + "->" + string(StatusFailed):          "fail",
+		string(StatusConfirmed) + "->" + string(StatusReversed):   "reverse",
 	}
 
 	return transitions[string(from)+"->"+string(to)]
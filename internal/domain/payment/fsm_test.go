@@ -66,6 +66,19 @@ func TestPaymentFSM(t *testing.T) {
 		require.Equal(t, payment.StatusFailed, testPayment.Status())
 	})
 
+	t.Run("Event - hold and release", func(t *testing.T) {
+		testPayment := createTestPayment()
+		fsm := payment.NewPaymentFSM(testPayment)
+
+		ctx := context.Background()
+		require.NoError(t, fsm.Event(ctx, "hold"))
+		require.Equal(t, payment.StatusHeld, testPayment.Status())
+		require.Error(t, fsm.Event(ctx, "include_in_block"))
+
+		require.NoError(t, fsm.Event(ctx, "release"))
+		require.Equal(t, payment.StatusDetected, testPayment.Status())
+	})
+
 	t.Run("Event - invalid event", func(t *testing.T) {
 		testPayment := createTestPayment()
 		fsm := payment.NewPaymentFSM(testPayment)
@@ -82,7 +95,7 @@ func TestPaymentFSM(t *testing.T) {
 		fsm := payment.NewPaymentFSM(testPayment)
 
 		transitions := fsm.GetValidTransitions()
-		expected := []payment.PaymentStatus{payment.StatusConfirming, payment.StatusFailed}
+		expected := []payment.PaymentStatus{payment.StatusConfirming, payment.StatusFailed, payment.StatusHeld}
 
 		require.ElementsMatch(t, expected, transitions)
 	})
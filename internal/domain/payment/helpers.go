@@ -5,39 +5,10 @@ import (
 	"time"
 )
 
-// CalculateRequiredConfirmations calculates the required confirmations based on amount and network.
+// CalculateRequiredConfirmations calculates the required confirmations based on amount and network, using the
+// default confirmation policy.
 func CalculateRequiredConfirmations(amount *shared.Money, network shared.BlockchainNetwork) int {
-	// Basic confirmation requirements based on amount and network
-	// This is a simplified implementation - in reality, this would be more complex
-
-	switch network {
-	case shared.NetworkBitcoin:
-		// Bitcoin: 1 confirmation for small amounts, 6 for large amounts
-		threshold, _ := shared.NewMoney("1000.00", shared.CurrencyUSD)
-		if amount.Amount().LessThan(threshold.Amount()) {
-			return 1
-		}
-		return 6
-
-	case shared.NetworkEthereum:
-		// Ethereum: 12 confirmations for small amounts, 30 for large amounts
-		threshold, _ := shared.NewMoney("10000.00", shared.CurrencyUSD)
-		if amount.Amount().LessThan(threshold.Amount()) {
-			return 12
-		}
-		return 30
-
-	case shared.NetworkTron:
-		// Tron: 1 confirmation for small amounts, 3 for large amounts
-		threshold, _ := shared.NewMoney("5000.00", shared.CurrencyUSD)
-		if amount.Amount().LessThan(threshold.Amount()) {
-			return 1
-		}
-		return 3
-
-	default:
-		return 1
-	}
+	return DefaultConfirmationPolicy().Required(amount, network)
 }
 
 // IsPaymentExpired checks if a payment has expired based on detection time and network.
@@ -49,7 +20,7 @@ func IsPaymentExpired(payment *Payment, maxAge time.Duration) bool {
 	detectedAt := payment.DetectedAt()
 	expiresAt := detectedAt.Add(maxAge)
 
-	return time.Now().UTC().After(expiresAt)
+	return shared.Now().UTC().After(expiresAt)
 }
 
 // CalculateNetworkFee estimates the network fee based on network and transaction size.
@@ -59,7 +30,7 @@ func CalculateNetworkFee(
 ) (*shared.Money, shared.CryptoCurrency, error) {
 	// This is a simplified implementation - in reality, this would query current network conditions
 
-	switch network {
+	switch network.Mainnet() {
 	case shared.NetworkBitcoin:
 		// Bitcoin: ~$5-50 depending on network congestion
 		fee, err := shared.NewMoney("10.00", shared.CurrencyUSD)
@@ -96,7 +67,7 @@ func ValidatePaymentAddress(address string, network shared.BlockchainNetwork) er
 	}
 
 	// Basic validation - in reality, this would use network-specific validation
-	switch network {
+	switch network.Mainnet() {
 	case shared.NetworkBitcoin:
 		// Bitcoin addresses start with 1, 3, or bc1
 		if len(address) < 26 || len(address) > 62 {
@@ -126,7 +97,7 @@ func ValidatePaymentAddress(address string, network shared.BlockchainNetwork) er
 func EstimateConfirmationTime(network shared.BlockchainNetwork, confirmations int) time.Duration {
 	// This is a simplified implementation - in reality, this would consider current network conditions
 
-	switch network {
+	switch network.Mainnet() {
 	case shared.NetworkBitcoin:
 		// Bitcoin: ~10 minutes per block
 		return time.Duration(confirmations) * 10 * time.Minute
@@ -0,0 +1,48 @@
+package payment
+
+import (
+	"crypto-checkout/pkg/metrics"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Confirmation latency objective: ConfirmationSLOTarget of the payments on a network confirm within
+// ConfirmationSLO of their detection.
+const (
+	ConfirmationSLO       = time.Hour
+	ConfirmationSLOTarget = 0.95
+)
+
+// confirmationSeconds publishes how long payments take to confirm on /metrics. ConfirmationSLO is one of its
+// buckets so that the share of payments meeting it can be read from the histogram.
+var confirmationSeconds = metrics.NewHistogram(metrics.Namespace+"_payment_confirmation_seconds",
+	"Seconds from the detection of a payment to its confirmation.",
+	[]float64{30, 60, 120, 300, 600, 1200, 1800, ConfirmationSLO.Seconds(), 7200, 14400}, "network")
+
+func init() {
+	name := confirmationSeconds.Name()
+	slo := strconv.FormatFloat(ConfirmationSLO.Seconds(), 'g', -1, 64)
+	metrics.AddAlert(metrics.AlertRule{
+		Name: "PaymentConfirmationSLOBreached",
+		Expr: fmt.Sprintf(`1 - (sum by (network) (rate(%s_bucket{le="%s"}[1h])) / `+
+			`sum by (network) (rate(%s_count[1h]))) > %.2g`, name, slo, name, 1-ConfirmationSLOTarget),
+		For:      15 * time.Minute,
+		Severity: metrics.SeverityWarning,
+		Summary:  "Payments on {{ $labels.network }} are confirming too slowly",
+		Description: fmt.Sprintf("Over the last hour, {{ $value | humanizePercentage }} of the payments on "+
+			"{{ $labels.network }} took longer than %d minutes to confirm, above the %.0f%% the objective allows.",
+			int(ConfirmationSLO.Minutes()), (1-ConfirmationSLOTarget)*100),
+		Metrics: []string{name},
+	})
+}
+
+// observeConfirmation records how long a payment that was just confirmed took to confirm.
+func observeConfirmation(payment *Payment) {
+	confirmedAt := payment.ConfirmedAt()
+	if confirmedAt == nil || payment.ToAddress() == nil {
+		return
+	}
+	confirmationSeconds.Observe(max(confirmedAt.Sub(payment.DetectedAt()).Seconds(), 0),
+		string(payment.ToAddress().Network()))
+}
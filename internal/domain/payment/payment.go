@@ -8,6 +8,8 @@ import (
 )
 
 // Payment represents a blockchain payment transaction.
+// Its state is the projection of an append-only event stream: every change is recorded as a
+// PaymentEvent, and ReplayPayment rebuilds the payment from its stream.
 type Payment struct {
 	id                    shared.PaymentID
 	invoiceID             shared.InvoiceID
@@ -21,6 +23,8 @@ type Payment struct {
 	blockInfo             *BlockInfo
 	networkFee            *NetworkFee
 	timestamps            *PaymentTimestamps
+	version               int
+	pendingEvents         []*PaymentEvent
 }
 
 // PaymentValidation represents the validation structure for Payment creation.
@@ -91,9 +95,8 @@ func NewPayment(
 		return nil, err
 	}
 
-	timestamps := NewPaymentTimestamps(time.Now().UTC())
-
-	return &Payment{
+	now := eventTime()
+	p := &Payment{
 		id:                    id,
 		invoiceID:             invoiceID,
 		amount:                amount,
@@ -103,8 +106,20 @@ func NewPayment(
 		status:                StatusDetected,
 		confirmations:         confirmations,
 		requiredConfirmations: requiredConfirmations,
-		timestamps:            timestamps,
-	}, nil
+		timestamps:            newPaymentTimestampsAt(now),
+		version:               1,
+	}
+
+	// The payment already holds the state the detected event describes
+	p.pendingEvents = []*PaymentEvent{{
+		PaymentID:  id,
+		Version:    1,
+		Type:       PaymentEventDetected,
+		Data:       detectedEventData(p),
+		OccurredAt: now,
+	}}
+
+	return p, nil
 }
 
 // ID returns the payment ID.
@@ -203,61 +218,65 @@ func (p *Payment) UpdateConfirmations(ctx interface{}, count int) error {
 		return NewInvalidConfirmationCountError("confirmation count cannot be negative")
 	}
 
-	newConfirmations, err := NewConfirmationCount(count)
-	if err != nil {
+	if _, err := NewConfirmationCount(count); err != nil {
 		return err
 	}
 
-	p.confirmations = newConfirmations
-	p.timestamps.SetUpdatedAt(time.Now().UTC())
+	return p.record(PaymentEventConfirmationsUpdated, PaymentEventData{Confirmations: count})
+}
+
+// RequireConfirmations raises the confirmations the payment needs before it is confirmed. Requiring no more
+// than it already needs changes nothing; a confirmed or failed payment can no longer be held back.
+func (p *Payment) RequireConfirmations(count int) error {
+	if count <= p.requiredConfirmations {
+		return nil
+	}
+	if p.IsTerminal() {
+		return NewPaymentError(shared.ErrCodeTerminalState,
+			"cannot raise the required confirmations of a "+p.status.String()+" payment", ErrTerminalState)
+	}
 
-	return nil
+	return p.record(PaymentEventConfirmationsRequired, PaymentEventData{RequiredConfirmations: count})
 }
 
 // UpdateBlockInfo updates the block information.
 func (p *Payment) UpdateBlockInfo(blockNumber int64, blockHash string) error {
-	blockInfo, err := NewBlockInfo(blockNumber, blockHash)
-	if err != nil {
+	if _, err := NewBlockInfo(blockNumber, blockHash); err != nil {
 		return err
 	}
 
-	p.blockInfo = blockInfo
-	p.timestamps.SetUpdatedAt(time.Now().UTC())
-
-	return nil
+	return p.record(PaymentEventBlockInfoUpdated, PaymentEventData{BlockNumber: blockNumber, BlockHash: blockHash})
 }
 
 // UpdateNetworkFee updates the network fee.
 func (p *Payment) UpdateNetworkFee(fee *shared.Money, currency shared.CryptoCurrency) error {
-	networkFee, err := NewNetworkFee(fee, currency)
-	if err != nil {
+	if _, err := NewNetworkFee(fee, currency); err != nil {
 		return err
 	}
 
-	p.networkFee = networkFee
-	p.timestamps.SetUpdatedAt(time.Now().UTC())
-
-	return nil
+	return p.record(PaymentEventNetworkFeeUpdated, PaymentEventData{
+		NetworkFee:         eventAmount(fee.Amount()),
+		NetworkFeeUnit:     fee.Currency(),
+		NetworkFeeCurrency: currency.String(),
+	})
 }
 
-// SetStatus sets the payment status (for testing purposes).
+// SetStatus sets the payment status without the FSM guards (for testing purposes).
+// The change is still recorded as the event for the target status; unknown statuses are ignored.
 func (p *Payment) SetStatus(status PaymentStatus) {
-	p.status = status
-	p.timestamps.SetUpdatedAt(time.Now().UTC())
+	if status == p.status {
+		return
+	}
+	_ = p.recordStatusChange(status)
 }
 
 // SetConfirmations sets the confirmation count (for testing purposes).
 func (p *Payment) SetConfirmations(count int) error {
-	confirmations, err := NewConfirmationCount(count)
-	if err != nil {
-		return err
-	}
-	p.confirmations = confirmations
-	p.timestamps.SetUpdatedAt(time.Now().UTC())
-	return nil
+	return p.UpdateConfirmations(nil, count)
 }
 
 // SetConfirmedAt sets the confirmation timestamp (for testing purposes).
+// It only changes the in-memory state and is not recorded in the event stream.
 func (p *Payment) SetConfirmedAt(confirmedAt time.Time) {
 	p.timestamps.SetConfirmedAt(confirmedAt)
 }
@@ -3,46 +3,63 @@ package payment
 import (
 	"context"
 	"crypto-checkout/internal/domain/shared"
+	"errors"
 	"fmt"
-	"time"
 
 	"go.uber.org/zap"
 )
 
 // PaymentServiceImpl implements the PaymentService interface.
 type PaymentServiceImpl struct {
-	repository Repository
-	eventBus   shared.EventBus
-	logger     *zap.Logger
+	repository  Repository
+	eventBus    shared.EventBus
+	screener    Screener
+	reviewQueue ReviewQueue
+	logger      *zap.Logger
 }
 
 // NewPaymentService creates a new payment service.
-func NewPaymentService(repository Repository, eventBus shared.EventBus, logger *zap.Logger) PaymentService {
+// The screener and review queue may be nil, in which case detected payments are not screened
+// and held or orphaned payments are not queued for operator review.
+func NewPaymentService(
+	repository Repository,
+	eventBus shared.EventBus,
+	screener Screener,
+	reviewQueue ReviewQueue,
+	logger *zap.Logger,
+) PaymentService {
 	logger.Info("Creating PaymentService",
 		zap.Bool("eventBus_provided", eventBus != nil),
-		zap.Bool("repository_provided", repository != nil))
+		zap.Bool("repository_provided", repository != nil),
+		zap.Bool("screener_provided", screener != nil),
+		zap.Bool("review_queue_provided", reviewQueue != nil))
 
 	return &PaymentServiceImpl{
-		repository: repository,
-		eventBus:   eventBus,
-		logger:     logger,
+		repository:  repository,
+		eventBus:    eventBus,
+		screener:    screener,
+		reviewQueue: reviewQueue,
+		logger:      logger,
 	}
 }
 
-// CreatePayment creates a new payment record.
+// CreatePayment creates a new payment record, or returns the payment already recorded for the transaction.
+//
+// Several detection sources may report the same transaction at once, so the lookup below can miss a payment
+// saved concurrently; the repository's uniqueness of transactions per network then rejects the second save,
+// and the payment that won is returned.
 func (s *PaymentServiceImpl) CreatePayment(ctx context.Context, req *CreatePaymentRequest) (*Payment, error) {
 	if req == nil {
 		return nil, NewPaymentError(shared.ErrCodeValidationFailed, "create payment request cannot be nil", nil)
 	}
-
-	// Check if payment with same transaction hash already exists
-	existingPayment, err := s.repository.FindByTransactionHash(ctx, req.TransactionHash)
-	if err != nil && err != ErrPaymentNotFound {
-		return nil, fmt.Errorf("failed to check existing payment: %w", err)
+	if req.TransactionHash == nil || req.ToAddress == nil {
+		return nil, NewPaymentError(shared.ErrCodeValidationFailed,
+			"transaction hash and payment address are required", nil)
 	}
 
-	if existingPayment != nil {
-		return nil, NewPaymentAlreadyExistsError(req.TransactionHash.String())
+	existingPayment, err := s.recordedPayment(ctx, req)
+	if err != nil || existingPayment != nil {
+		return existingPayment, err
 	}
 
 	// Create new payment
@@ -60,21 +77,19 @@ func (s *PaymentServiceImpl) CreatePayment(ctx context.Context, req *CreatePayme
 	}
 
 	// Save to repository
-	if err := s.repository.Save(ctx, payment); err != nil {
+	if err := s.repository.Save(ctx, payment); errors.Is(err, ErrDuplicateTransaction) {
+		existingPayment, err := s.recordedPayment(ctx, req)
+		if err == nil && existingPayment == nil {
+			err = fmt.Errorf("failed to find the payment recording transaction %s", req.TransactionHash.String())
+		}
+		return existingPayment, err
+	} else if err != nil {
 		return nil, fmt.Errorf("failed to save payment: %w", err)
 	}
 
 	// Publish payment detected event
 	if s.eventBus != nil {
-		eventData := createPaymentEventData(payment)
-		eventData["timestamp"] = time.Now().UTC()
-		event := shared.CreateDomainEvent(
-			shared.EventTypePaymentDetected,
-			string(payment.ID()),
-			"Payment",
-			eventData,
-			nil,
-		)
+		event := shared.NewPaymentDetectedEvent(createPaymentEventData(payment))
 		if err := s.eventBus.PublishEvent(ctx, event); err != nil {
 			// Log error but don't fail the operation
 			if s.logger != nil {
@@ -87,9 +102,94 @@ func (s *PaymentServiceImpl) CreatePayment(ctx context.Context, req *CreatePayme
 		}
 	}
 
+	if err := s.screenPayment(ctx, payment); err != nil {
+		return nil, err
+	}
+
 	return payment, nil
 }
 
+// recordedPayment returns the payment already recording the request's transaction on its network, or nil.
+func (s *PaymentServiceImpl) recordedPayment(ctx context.Context, req *CreatePaymentRequest) (*Payment, error) {
+	existingPayment, err := s.repository.FindByTransactionHash(ctx, req.ToAddress.Network(), req.TransactionHash)
+	if errors.Is(err, ErrPaymentNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing payment: %w", err)
+	}
+
+	if existingPayment.InvoiceID() != req.InvoiceID {
+		return nil, NewPaymentAlreadyExistsError(req.TransactionHash.String(), string(existingPayment.InvoiceID()))
+	}
+	return existingPayment, nil
+}
+
+// screenPayment screens the sender of a newly detected payment and holds it for review when flagged.
+// Screening failures hold the payment as well, so unscreened funds are never confirmed automatically.
+func (s *PaymentServiceImpl) screenPayment(ctx context.Context, payment *Payment) error {
+	if s.screener == nil {
+		return nil
+	}
+
+	hold, err := s.screener.ScreenPayment(ctx, payment)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("Payment screening failed, holding payment for review",
+				zap.String("payment_id", string(payment.ID())),
+				zap.Error(err),
+			)
+		}
+		hold = true
+	}
+
+	if !hold {
+		return nil
+	}
+
+	if err := s.transition(ctx, payment, "hold"); err != nil {
+		return fmt.Errorf("failed to hold payment: %w", err)
+	}
+
+	if s.reviewQueue != nil {
+		if err := s.reviewQueue.EnqueueHeldPayment(ctx, payment); err != nil && s.logger != nil {
+			s.logger.Error("Failed to queue held payment for review",
+				zap.String("payment_id", string(payment.ID())),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return nil
+}
+
+// ReleasePayment releases a payment held for compliance review and resumes its progression.
+func (s *PaymentServiceImpl) ReleasePayment(ctx context.Context, id shared.PaymentID) error {
+	if err := s.UpdatePaymentStatus(ctx, id, "release"); err != nil {
+		return err
+	}
+
+	payment, err := s.GetPayment(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	// Catch up on block inclusion and confirmations observed while the payment was held
+	if payment.BlockInfo() == nil {
+		return nil
+	}
+	if err := s.UpdatePaymentStatus(ctx, id, "include_in_block"); err != nil {
+		return fmt.Errorf("failed to transition payment to confirming: %w", err)
+	}
+	if payment.IsConfirmed() {
+		if err := s.UpdatePaymentStatus(ctx, id, "confirm"); err != nil {
+			return fmt.Errorf("failed to confirm payment: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // GetPayment retrieves a payment by ID.
 func (s *PaymentServiceImpl) GetPayment(ctx context.Context, id shared.PaymentID) (*Payment, error) {
 	if id == "" {
@@ -107,16 +207,17 @@ func (s *PaymentServiceImpl) GetPayment(ctx context.Context, id shared.PaymentID
 	return payment, nil
 }
 
-// GetPaymentByTransactionHash retrieves a payment by transaction hash.
+// GetPaymentByTransactionHash retrieves the payment of a transaction on a network.
 func (s *PaymentServiceImpl) GetPaymentByTransactionHash(
 	ctx context.Context,
+	network shared.BlockchainNetwork,
 	txHash *TransactionHash,
 ) (*Payment, error) {
 	if txHash == nil {
 		return nil, NewPaymentError(shared.ErrCodeValidationFailed, "transaction hash cannot be nil", nil)
 	}
 
-	payment, err := s.repository.FindByTransactionHash(ctx, txHash)
+	payment, err := s.repository.FindByTransactionHash(ctx, network, txHash)
 	if err != nil {
 		if err == ErrPaymentNotFound {
 			return nil, NewPaymentNotFoundError(txHash.String())
@@ -143,6 +244,11 @@ func (s *PaymentServiceImpl) UpdatePaymentStatus(ctx context.Context, id shared.
 		return fmt.Errorf("failed to get payment: %w", err)
 	}
 
+	return s.transition(ctx, payment, event)
+}
+
+// transition triggers an FSM event on a loaded payment, saves the recorded events and publishes the change.
+func (s *PaymentServiceImpl) transition(ctx context.Context, payment *Payment, event string) error {
 	// Create FSM and trigger event
 	fsm := NewPaymentFSM(payment)
 	if err := fsm.Event(ctx, event); err != nil {
@@ -154,20 +260,28 @@ func (s *PaymentServiceImpl) UpdatePaymentStatus(ctx context.Context, id shared.
 		return fmt.Errorf("failed to save updated payment: %w", err)
 	}
 
+	// Record how long the payment took to confirm
+	if payment.Status() == StatusConfirmed {
+		observeConfirmation(payment)
+	}
+
+	// Orphaned payments dead-end until an operator decides what to do with them
+	if payment.Status() == StatusOrphaned && s.reviewQueue != nil {
+		if err := s.reviewQueue.EnqueueOrphanedPayment(ctx, payment); err != nil && s.logger != nil {
+			s.logger.Error("Failed to queue orphaned payment for review",
+				zap.String("payment_id", string(payment.ID())),
+				zap.Error(err),
+			)
+		}
+	}
+
 	// Publish payment status changed event
 	if s.eventBus != nil {
-		eventData := createPaymentEventData(payment)
-		eventData["event_triggered"] = event
-		eventData["status_changed_at"] = time.Now().UTC()
-
-		eventData["timestamp"] = time.Now().UTC()
-		event := shared.CreateDomainEvent(
-			shared.EventTypePaymentStatusChanged,
-			string(payment.ID()),
-			"Payment",
-			eventData,
-			nil,
-		)
+		event := shared.NewPaymentStatusChangedEvent(shared.PaymentStatusChangedEvent{
+			PaymentEvent:    createPaymentEventData(payment),
+			EventTriggered:  event,
+			StatusChangedAt: shared.Now().UTC(),
+		})
 		if err := s.eventBus.PublishEvent(ctx, event); err != nil {
 			// Log error but don't fail the operation
 			if s.logger != nil {
@@ -196,15 +310,17 @@ func (s *PaymentServiceImpl) UpdateConfirmations(ctx context.Context, id shared.
 	}
 
 	// Update confirmations
+	previous := payment.Confirmations().Int()
 	if err := payment.UpdateConfirmations(ctx, count); err != nil {
 		return fmt.Errorf("failed to update confirmations: %w", err)
 	}
 
 	// Check if payment should be confirmed
 	if payment.IsConfirmed() && payment.Status() == StatusConfirming {
-		if err := s.UpdatePaymentStatus(ctx, id, "confirm"); err != nil {
+		if err := s.transition(ctx, payment, "confirm"); err != nil {
 			return fmt.Errorf("failed to confirm payment: %w", err)
 		}
+		s.publishConfirmations(ctx, payment, previous)
 		return nil
 	}
 
@@ -213,6 +329,54 @@ func (s *PaymentServiceImpl) UpdateConfirmations(ctx context.Context, id shared.
 		return fmt.Errorf("failed to save updated payment: %w", err)
 	}
 
+	s.publishConfirmations(ctx, payment, previous)
+	return nil
+}
+
+// publishConfirmations publishes an event for every confirmation a payment gained since it had previous ones, up to
+// the confirmations it needs, so merchants can follow its progress one block at a time.
+func (s *PaymentServiceImpl) publishConfirmations(ctx context.Context, payment *Payment, previous int) {
+	if s.eventBus == nil {
+		return
+	}
+
+	last := min(payment.Confirmations().Int(), payment.RequiredConfirmations())
+	for confirmations := previous + 1; confirmations <= last; confirmations++ {
+		event := shared.NewPaymentConfirmationEvent(createPaymentConfirmationEventData(payment, confirmations))
+		if err := s.eventBus.PublishEvent(ctx, event); err != nil && s.logger != nil {
+			// Log error but don't fail the operation
+			s.logger.Error("Failed to publish domain event",
+				zap.String("event_type", shared.EventTypePaymentConfirmation),
+				zap.String("aggregate_id", string(payment.ID())),
+				zap.Int("confirmations", confirmations),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// RequireConfirmations raises the confirmations a payment needs before it is confirmed.
+func (s *PaymentServiceImpl) RequireConfirmations(ctx context.Context, id shared.PaymentID, count int) error {
+	if id == "" {
+		return NewPaymentError(shared.ErrCodeValidationFailed, "payment ID cannot be empty", nil)
+	}
+
+	payment, err := s.GetPayment(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	if err := payment.RequireConfirmations(count); err != nil {
+		return fmt.Errorf("failed to raise required confirmations: %w", err)
+	}
+	if len(payment.PendingEvents()) == 0 {
+		return nil
+	}
+
+	if err := s.repository.Update(ctx, payment); err != nil {
+		return fmt.Errorf("failed to save updated payment: %w", err)
+	}
+
 	return nil
 }
 
@@ -240,7 +404,7 @@ func (s *PaymentServiceImpl) UpdateBlockInfo(
 
 	// If payment is detected, transition to confirming
 	if payment.Status() == StatusDetected {
-		if err := s.UpdatePaymentStatus(ctx, id, "include_in_block"); err != nil {
+		if err := s.transition(ctx, payment, "include_in_block"); err != nil {
 			return fmt.Errorf("failed to transition payment to confirming: %w", err)
 		}
 		return nil
@@ -284,6 +448,23 @@ func (s *PaymentServiceImpl) UpdateNetworkFee(
 	return nil
 }
 
+// GetPaymentHistory retrieves the event stream of a payment, oldest first.
+func (s *PaymentServiceImpl) GetPaymentHistory(ctx context.Context, id shared.PaymentID) ([]*PaymentEvent, error) {
+	if id == "" {
+		return nil, NewPaymentError(shared.ErrCodeValidationFailed, "payment ID cannot be empty", nil)
+	}
+
+	events, err := s.repository.FindEvents(ctx, string(id))
+	if err != nil {
+		if err == ErrPaymentNotFound {
+			return nil, NewPaymentNotFoundError(string(id))
+		}
+		return nil, fmt.Errorf("failed to get payment history: %w", err)
+	}
+
+	return events, nil
+}
+
 // ListPaymentsByInvoice retrieves all payments for an invoice.
 func (s *PaymentServiceImpl) ListPaymentsByInvoice(
 	ctx context.Context,
@@ -293,9 +474,12 @@ func (s *PaymentServiceImpl) ListPaymentsByInvoice(
 		return nil, NewPaymentError(shared.ErrCodeValidationFailed, "invoice ID cannot be empty", nil)
 	}
 
-	// This would need to be implemented in the repository
-	// For now, we'll return an error indicating it's not implemented
-	return nil, fmt.Errorf("list payments by invoice not implemented")
+	payments, err := s.repository.FindByInvoiceID(ctx, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments by invoice: %w", err)
+	}
+
+	return payments, nil
 }
 
 // ListPaymentsByStatus retrieves all payments with the given status.
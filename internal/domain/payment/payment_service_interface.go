@@ -7,27 +7,43 @@ import (
 
 // PaymentService defines the interface for payment operations.
 type PaymentService interface {
-	// CreatePayment creates a new payment record.
+	// CreatePayment creates a new payment record. It is idempotent per transaction: when the transaction is
+	// already recorded on the network for the same invoice, that payment is returned instead, and it fails with
+	// a PAYMENT_ALREADY_EXISTS error when the transaction is recorded for another invoice.
 	CreatePayment(ctx context.Context, req *CreatePaymentRequest) (*Payment, error)
 
 	// GetPayment retrieves a payment by ID.
 	GetPayment(ctx context.Context, id shared.PaymentID) (*Payment, error)
 
-	// GetPaymentByTransactionHash retrieves a payment by transaction hash.
-	GetPaymentByTransactionHash(ctx context.Context, txHash *TransactionHash) (*Payment, error)
+	// GetPaymentByTransactionHash retrieves the payment of a transaction on a network.
+	GetPaymentByTransactionHash(
+		ctx context.Context,
+		network shared.BlockchainNetwork,
+		txHash *TransactionHash,
+	) (*Payment, error)
 
 	// UpdatePaymentStatus updates the payment status using the FSM.
 	UpdatePaymentStatus(ctx context.Context, id shared.PaymentID, event string) error
 
+	// ReleasePayment releases a payment held for compliance review and resumes its progression.
+	ReleasePayment(ctx context.Context, id shared.PaymentID) error
+
 	// UpdateConfirmations updates the confirmation count for a payment.
 	UpdateConfirmations(ctx context.Context, id shared.PaymentID, count int) error
 
+	// RequireConfirmations raises the confirmations a payment needs before it is confirmed. It fails with
+	// ErrTerminalState once the payment is confirmed or failed.
+	RequireConfirmations(ctx context.Context, id shared.PaymentID, count int) error
+
 	// UpdateBlockInfo updates the block information for a payment.
 	UpdateBlockInfo(ctx context.Context, id shared.PaymentID, blockNumber int64, blockHash string) error
 
 	// UpdateNetworkFee updates the network fee for a payment.
 	UpdateNetworkFee(ctx context.Context, id shared.PaymentID, fee *shared.Money, currency shared.CryptoCurrency) error
 
+	// GetPaymentHistory retrieves the event stream of a payment, oldest first.
+	GetPaymentHistory(ctx context.Context, id shared.PaymentID) ([]*PaymentEvent, error)
+
 	// ListPaymentsByInvoice retrieves all payments for an invoice.
 	ListPaymentsByInvoice(ctx context.Context, invoiceID shared.InvoiceID) ([]*Payment, error)
 
@@ -50,6 +66,21 @@ type PaymentService interface {
 	GetPaymentStatistics(ctx context.Context) (*PaymentStatistics, error)
 }
 
+// Screener screens the sender of newly detected payments for compliance risk.
+type Screener interface {
+	// ScreenPayment returns true when the payment must be held for manual review.
+	ScreenPayment(ctx context.Context, payment *Payment) (bool, error)
+}
+
+// ReviewQueue receives payments that need operator action before they can progress.
+type ReviewQueue interface {
+	// EnqueueHeldPayment queues a payment held by compliance screening.
+	EnqueueHeldPayment(ctx context.Context, payment *Payment) error
+
+	// EnqueueOrphanedPayment queues a payment whose transaction dropped out of the chain.
+	EnqueueOrphanedPayment(ctx context.Context, payment *Payment) error
+}
+
 // CreatePaymentRequest represents a request to create a new payment.
 type CreatePaymentRequest struct {
 	ID                    shared.PaymentID
@@ -145,6 +145,28 @@ func TestPayment(t *testing.T) {
 		require.Contains(t, err.Error(), "invalid confirmation count")
 	})
 
+	t.Run("RequireConfirmations", func(t *testing.T) {
+		testPayment := createTestPayment()
+
+		require.NoError(t, testPayment.RequireConfirmations(12))
+		require.Equal(t, 12, testPayment.RequiredConfirmations())
+		require.Len(t, testPayment.PendingEvents(), 2)
+
+		// Requirements are only ever raised
+		require.NoError(t, testPayment.RequireConfirmations(3))
+		require.Equal(t, 12, testPayment.RequiredConfirmations())
+		require.Len(t, testPayment.PendingEvents(), 2)
+
+		require.NoError(t, testPayment.UpdateConfirmations(nil, 6))
+		require.False(t, testPayment.IsConfirmed())
+		require.NoError(t, testPayment.UpdateConfirmations(nil, 12))
+		require.True(t, testPayment.IsConfirmed())
+
+		testPayment.SetStatus(payment.StatusConfirmed)
+		err := testPayment.RequireConfirmations(20)
+		require.ErrorIs(t, err, payment.ErrTerminalState)
+	})
+
 	t.Run("UpdateBlockInfo", func(t *testing.T) {
 		testPayment := createTestPayment()
 
@@ -2,18 +2,40 @@ package payment
 
 import (
 	"context"
+	"crypto-checkout/internal/domain/shared"
 )
 
 // Repository defines the interface for payment data persistence.
+// Payments are event sourced: saving appends the payment's pending events to its stream,
+// and loading projects the current state from the latest snapshot and the events after it.
 type Repository interface {
 	// Save persists a payment to the data store.
+	// It fails with ErrDuplicateTransaction if another payment already records the transaction on the network.
 	Save(ctx context.Context, payment *Payment) error
 
+	// FindEvents retrieves the event stream of a payment, oldest first.
+	FindEvents(ctx context.Context, id string) ([]*PaymentEvent, error)
+
+	// FindSnapshots retrieves the stored snapshots of a payment, oldest first.
+	FindSnapshots(ctx context.Context, id string) ([]*PaymentSnapshot, error)
+
+	// RebuildProjection overwrites the stored state of a payment with one replayed from its stream,
+	// replaces the given snapshots, and removes snapshots beyond the payment's version.
+	// No events are appended.
+	RebuildProjection(ctx context.Context, payment *Payment, snapshots []*PaymentSnapshot) error
+
 	// FindByID retrieves a payment by its ID.
 	FindByID(ctx context.Context, id string) (*Payment, error)
 
-	// FindByTransactionHash retrieves a payment by its transaction hash.
-	FindByTransactionHash(ctx context.Context, hash *TransactionHash) (*Payment, error)
+	// FindByTransactionHash retrieves the payment of a transaction on a network.
+	FindByTransactionHash(
+		ctx context.Context,
+		network shared.BlockchainNetwork,
+		hash *TransactionHash,
+	) (*Payment, error)
+
+	// FindByInvoiceID retrieves all payments for an invoice, oldest first.
+	FindByInvoiceID(ctx context.Context, invoiceID shared.InvoiceID) ([]*Payment, error)
 
 	// FindByAddress retrieves all payments for a given address.
 	FindByAddress(ctx context.Context, address *PaymentAddress) ([]*Payment, error)
@@ -33,7 +55,8 @@ type Repository interface {
 	// FindOrphaned retrieves all orphaned payments.
 	FindOrphaned(ctx context.Context) ([]*Payment, error)
 
-	// Update updates an existing payment in the data store.
+	// Update appends the payment's pending events to its stream and refreshes the stored state.
+	// It fails with ErrConcurrentModification if the stream has moved on since the payment was loaded.
 	Update(ctx context.Context, payment *Payment) error
 
 	// Delete removes a payment from the data store.
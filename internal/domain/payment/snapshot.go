@@ -0,0 +1,288 @@
+package payment
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// SnapshotInterval is the number of events between snapshots of a payment's state.
+// Loading a payment replays at most this many events on top of its latest snapshot.
+const SnapshotInterval = 50
+
+// PaymentSnapshotState is the full state of a payment at a version of its event stream.
+type PaymentSnapshotState struct {
+	PaymentEventData
+	Status      PaymentStatus `json:"status"`
+	DetectedAt  time.Time     `json:"detected_at"`
+	ConfirmedAt *time.Time    `json:"confirmed_at,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// PaymentSnapshot records the state of a payment after the event with the given version was applied.
+// Snapshots are a cache over the stream: the stream stays the source of truth.
+type PaymentSnapshot struct {
+	PaymentID shared.PaymentID
+	Version   int
+	State     PaymentSnapshotState
+	TakenAt   time.Time
+}
+
+// TakeSnapshot captures the current state of a payment, including its pending events.
+func TakeSnapshot(p *Payment) *PaymentSnapshot {
+	return &PaymentSnapshot{
+		PaymentID: p.id,
+		Version:   p.version,
+		State:     snapshotState(p),
+		TakenAt:   shared.Now().UTC(),
+	}
+}
+
+// RestorePayment rebuilds a payment from a snapshot and the events recorded after it.
+// A nil snapshot replays the whole stream.
+func RestorePayment(snapshot *PaymentSnapshot, events []*PaymentEvent) (*Payment, error) {
+	if snapshot == nil {
+		return ReplayPayment(events)
+	}
+
+	p := &Payment{id: snapshot.PaymentID}
+	if err := p.restore(snapshot); err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if event.PaymentID != p.id {
+			return nil, NewInvalidEventStreamError("event stream mixes payments")
+		}
+		if event.Version != p.version+1 {
+			return nil, NewInvalidEventStreamError(
+				fmt.Sprintf("expected event version %d, got %d", p.version+1, event.Version))
+		}
+		if err := p.apply(event); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// Diff returns the names of the state fields in which the snapshot differs from the payment, sorted.
+// An empty result means the snapshot matches.
+func (s *PaymentSnapshot) Diff(p *Payment) []string {
+	stored := snapshotFields(s.State)
+	projected := snapshotFields(snapshotState(p))
+
+	var fields []string
+	for name, value := range projected {
+		if !reflect.DeepEqual(stored[name], value) {
+			fields = append(fields, name)
+		}
+	}
+	for name := range stored {
+		if _, ok := projected[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// SnapshotMismatch describes a stored snapshot that does not match the state replayed from the stream.
+type SnapshotMismatch struct {
+	Version int
+	Fields  []string
+	// Replayed is the snapshot the stream yields at the version, or nil if the version is beyond the stream.
+	Replayed *PaymentSnapshot
+}
+
+// ReplayResult describes a payment replayed from its full event stream.
+type ReplayResult struct {
+	Payment    *Payment
+	Events     int
+	Snapshots  int
+	Mismatches []SnapshotMismatch
+}
+
+// Corrupted returns true if any stored snapshot disagrees with the stream.
+func (r *ReplayResult) Corrupted() bool {
+	return len(r.Mismatches) > 0
+}
+
+// VerifyPayment replays the full event stream of a payment and checks every stored snapshot
+// against the state at its version. Snapshots beyond the end of the stream are reported as mismatches.
+func VerifyPayment(events []*PaymentEvent, snapshots []*PaymentSnapshot) (*ReplayResult, error) {
+	p, err := ReplayPayment(events[:min(1, len(events))])
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*PaymentSnapshot, len(snapshots))
+	for _, snapshot := range snapshots {
+		byVersion[snapshot.Version] = snapshot
+	}
+
+	result := &ReplayResult{Payment: p, Events: len(events), Snapshots: len(snapshots)}
+	for i, event := range events {
+		if i > 0 {
+			if event.PaymentID != p.id || event.Version != p.version+1 {
+				return nil, NewInvalidEventStreamError(
+					fmt.Sprintf("expected event version %d, got %d", p.version+1, event.Version))
+			}
+			if err := p.apply(event); err != nil {
+				return nil, err
+			}
+		}
+
+		snapshot, ok := byVersion[p.version]
+		if !ok {
+			continue
+		}
+		delete(byVersion, p.version)
+		if fields := snapshot.Diff(p); len(fields) > 0 {
+			result.Mismatches = append(result.Mismatches, SnapshotMismatch{
+				Version:  p.version,
+				Fields:   fields,
+				Replayed: TakeSnapshot(p),
+			})
+		}
+	}
+
+	for version := range byVersion {
+		result.Mismatches = append(result.Mismatches, SnapshotMismatch{Version: version, Fields: []string{"version"}})
+	}
+	sort.Slice(result.Mismatches, func(i, j int) bool {
+		return result.Mismatches[i].Version < result.Mismatches[j].Version
+	})
+	return result, nil
+}
+
+// RebuildPayment replays a payment from its full event stream, verifies its stored snapshots, and
+// rewrites its projection. Mismatched snapshots are replaced with the replayed state, and snapshots
+// beyond the end of the stream are removed. With dryRun set nothing is written.
+// Deleted payments are not rebuilt, even though their streams are kept.
+func RebuildPayment(ctx context.Context, repository Repository, id string, dryRun bool) (*ReplayResult, error) {
+	exists, err := repository.Exists(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrPaymentNotFound
+	}
+	events, err := repository.FindEvents(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, NewInvalidEventStreamError("payment has no event stream yet")
+	}
+	snapshots, err := repository.FindSnapshots(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := VerifyPayment(events, snapshots)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	var repaired []*PaymentSnapshot
+	for _, mismatch := range result.Mismatches {
+		if mismatch.Replayed != nil {
+			repaired = append(repaired, mismatch.Replayed)
+		}
+	}
+	if err := repository.RebuildProjection(ctx, result.Payment, repaired); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// restore sets the payment state from a snapshot.
+func (p *Payment) restore(snapshot *PaymentSnapshot) error {
+	state := snapshot.State
+	if snapshot.Version < 1 || !state.Status.IsValid() {
+		return NewInvalidEventStreamError(fmt.Sprintf("snapshot at version %d is invalid", snapshot.Version))
+	}
+	if err := p.applyIdentity(state.PaymentEventData); err != nil {
+		return err
+	}
+	confirmations, err := NewConfirmationCount(state.Confirmations)
+	if err != nil {
+		return err
+	}
+	if state.BlockHash != "" {
+		blockInfo, blockErr := NewBlockInfo(state.BlockNumber, state.BlockHash)
+		if blockErr != nil {
+			return blockErr
+		}
+		p.blockInfo = blockInfo
+	}
+	if state.NetworkFee != "" {
+		fee, feeErr := parseEventMoney(state.NetworkFee, state.NetworkFeeUnit)
+		if feeErr != nil {
+			return feeErr
+		}
+		networkFee, feeErr := NewNetworkFee(fee, shared.CryptoCurrency(state.NetworkFeeCurrency))
+		if feeErr != nil {
+			return feeErr
+		}
+		p.networkFee = networkFee
+	}
+
+	p.status = state.Status
+	p.confirmations = confirmations
+	p.timestamps = &PaymentTimestamps{
+		detectedAt:  state.DetectedAt,
+		confirmedAt: state.ConfirmedAt,
+		createdAt:   state.CreatedAt,
+		updatedAt:   state.UpdatedAt,
+	}
+	p.version = snapshot.Version
+	return nil
+}
+
+// snapshotState captures the full state of a payment.
+func snapshotState(p *Payment) PaymentSnapshotState {
+	state := PaymentSnapshotState{
+		PaymentEventData: detectedEventData(p),
+		Status:           p.status,
+		DetectedAt:       p.timestamps.DetectedAt(),
+		CreatedAt:        p.timestamps.CreatedAt(),
+		UpdatedAt:        p.timestamps.UpdatedAt(),
+	}
+	state.Confirmations = p.confirmations.Int()
+	if p.blockInfo != nil {
+		state.BlockNumber = p.blockInfo.Number()
+		state.BlockHash = p.blockInfo.Hash()
+	}
+	if p.networkFee != nil {
+		state.NetworkFee = eventAmount(p.networkFee.Fee().Amount())
+		state.NetworkFeeUnit = p.networkFee.Fee().Currency()
+		state.NetworkFeeCurrency = p.networkFee.Currency().String()
+	}
+	if confirmedAt := p.timestamps.ConfirmedAt(); confirmedAt != nil {
+		at := *confirmedAt
+		state.ConfirmedAt = &at
+	}
+	return state
+}
+
+// snapshotFields flattens a snapshot state into its JSON fields, so states compare the way they are stored.
+func snapshotFields(state PaymentSnapshotState) map[string]any {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
@@ -0,0 +1,90 @@
+package payment_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payment"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTestPaymentHistory(t *testing.T) *payment.Payment {
+	testPayment := createTestPayment()
+	require.NoError(t, testPayment.UpdateBlockInfo(12345, "0xblock"))
+	fsm := payment.NewPaymentFSM(testPayment)
+	require.NoError(t, fsm.Event(context.Background(), "include_in_block"))
+	for count := 1; count <= 6; count++ {
+		require.NoError(t, testPayment.UpdateConfirmations(nil, count))
+	}
+	require.NoError(t, fsm.Event(context.Background(), "confirm"))
+	return testPayment
+}
+
+func TestPaymentSnapshot(t *testing.T) {
+	t.Run("RestorePayment continues from a snapshot", func(t *testing.T) {
+		testPayment := createTestPaymentHistory(t)
+		events := testPayment.PendingEvents()
+
+		atFive, err := payment.ReplayPayment(events[:5])
+		require.NoError(t, err)
+		snapshot := payment.TakeSnapshot(atFive)
+		require.Equal(t, 5, snapshot.Version)
+
+		restored, err := payment.RestorePayment(snapshot, events[5:])
+		require.NoError(t, err)
+		require.Equal(t, testPayment.Version(), restored.Version())
+		require.Equal(t, payment.StatusConfirmed, restored.Status())
+		require.Equal(t, testPayment.ConfirmedAt(), restored.ConfirmedAt())
+		require.Empty(t, payment.TakeSnapshot(testPayment).Diff(restored))
+	})
+
+	t.Run("RestorePayment rejects a tail that does not follow the snapshot", func(t *testing.T) {
+		testPayment := createTestPaymentHistory(t)
+		events := testPayment.PendingEvents()
+
+		atFive, err := payment.ReplayPayment(events[:5])
+		require.NoError(t, err)
+
+		_, err = payment.RestorePayment(payment.TakeSnapshot(atFive), events[6:])
+		require.ErrorIs(t, err, payment.ErrInvalidEventStream)
+	})
+
+	t.Run("Diff names the fields that differ", func(t *testing.T) {
+		testPayment := createTestPaymentHistory(t)
+		snapshot := payment.TakeSnapshot(testPayment)
+		require.Empty(t, snapshot.Diff(testPayment))
+
+		snapshot.State.Confirmations = 2
+		snapshot.State.Status = payment.StatusConfirming
+		require.Equal(t, []string{"confirmations", "status"}, snapshot.Diff(testPayment))
+	})
+
+	t.Run("VerifyPayment reports corrupted snapshots", func(t *testing.T) {
+		testPayment := createTestPaymentHistory(t)
+		events := testPayment.PendingEvents()
+
+		atThree, err := payment.ReplayPayment(events[:3])
+		require.NoError(t, err)
+		atSix, err := payment.ReplayPayment(events[:6])
+		require.NoError(t, err)
+		good := payment.TakeSnapshot(atThree)
+		corrupted := payment.TakeSnapshot(atSix)
+		corrupted.State.Confirmations = 40
+		beyond := payment.TakeSnapshot(testPayment)
+		beyond.Version = 99
+
+		result, err := payment.VerifyPayment(events, []*payment.PaymentSnapshot{good, corrupted, beyond})
+		require.NoError(t, err)
+		require.True(t, result.Corrupted())
+		require.Equal(t, len(events), result.Events)
+		require.Equal(t, 3, result.Snapshots)
+		require.Equal(t, testPayment.Version(), result.Payment.Version())
+
+		require.Len(t, result.Mismatches, 2)
+		require.Equal(t, 6, result.Mismatches[0].Version)
+		require.Equal(t, []string{"confirmations"}, result.Mismatches[0].Fields)
+		require.Empty(t, result.Mismatches[0].Replayed.Diff(atSix))
+		require.Equal(t, 99, result.Mismatches[1].Version)
+		require.Nil(t, result.Mismatches[1].Replayed)
+	})
+}
@@ -0,0 +1,309 @@
+package payment
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// StatisticsInterval is the width of the buckets of a statistics series.
+type StatisticsInterval string
+
+const (
+	// IntervalHour buckets statistics by UTC hour.
+	IntervalHour StatisticsInterval = "hour"
+	// IntervalDay buckets statistics by UTC day.
+	IntervalDay StatisticsInterval = "day"
+)
+
+const (
+	// defaultHourlyStatisticsPeriod is the period of an hourly series requested without a start.
+	defaultHourlyStatisticsPeriod = 24 * time.Hour
+	// defaultDailyStatisticsPeriod is the period of a daily series requested without a start.
+	defaultDailyStatisticsPeriod = 30 * 24 * time.Hour
+	// maxHourlyStatisticsPeriod bounds the period of one hourly series.
+	maxHourlyStatisticsPeriod = 31 * 24 * time.Hour
+	// maxDailyStatisticsPeriod bounds the period of one daily series.
+	maxDailyStatisticsPeriod = 366 * 24 * time.Hour
+)
+
+// statisticsStatuses lists the statuses reported in every bucket, in the order payments reach them.
+var statisticsStatuses = []PaymentStatus{
+	StatusDetected,
+	StatusHeld,
+	StatusConfirming,
+	StatusConfirmed,
+	StatusOrphaned,
+	StatusFailed,
+}
+
+// String returns the string representation of the interval.
+func (i StatisticsInterval) String() string {
+	return string(i)
+}
+
+// IsValid checks if the interval is supported.
+func (i StatisticsInterval) IsValid() bool {
+	return i == IntervalHour || i == IntervalDay
+}
+
+// Duration returns the width of one bucket.
+func (i StatisticsInterval) Duration() time.Duration {
+	if i == IntervalDay {
+		return 24 * time.Hour
+	}
+	return time.Hour
+}
+
+// StatusRollup is the count and volume of the payments in one currency that reached a status during one UTC hour.
+type StatusRollup struct {
+	HourStart time.Time
+	Status    PaymentStatus
+	Currency  shared.CryptoCurrency
+	Count     int
+	Volume    decimal.Decimal
+}
+
+// ConfirmationLatency is how long a payment took from detection to confirmation.
+type ConfirmationLatency struct {
+	PaymentID   shared.PaymentID
+	ConfirmedAt time.Time
+	Latency     time.Duration
+}
+
+// StatisticsRepository persists the hourly rollup that payment statistics are read from.
+type StatisticsRepository interface {
+	// AddRollup adds the count and volume of a rollup to the stored rollup of the same hour, status and currency.
+	AddRollup(ctx context.Context, rollup *StatusRollup) error
+
+	// RecordConfirmation records the confirmation latency of a payment, ignoring a payment already recorded.
+	RecordConfirmation(ctx context.Context, latency *ConfirmationLatency) error
+
+	// ListRollups lists the rollups of the hours in [from, to), oldest first.
+	ListRollups(ctx context.Context, from, to time.Time) ([]*StatusRollup, error)
+
+	// ListConfirmations lists the confirmations made in [from, to), oldest first.
+	ListConfirmations(ctx context.Context, from, to time.Time) ([]*ConfirmationLatency, error)
+}
+
+// StatisticsRequest represents a request for payment statistics over [From, To) in buckets of Interval.
+// Times are aligned to bucket boundaries; a request without a period covers the last day hourly
+// or the last 30 days daily.
+type StatisticsRequest struct {
+	Interval StatisticsInterval
+	From     time.Time
+	To       time.Time
+}
+
+// StatisticsSeries is payment statistics over a period, bucketed by hour or day.
+type StatisticsSeries struct {
+	Interval StatisticsInterval
+	From     time.Time
+	To       time.Time
+	Buckets  []*StatisticsBucket
+	// Summary covers the whole period.
+	Summary *StatisticsSummary
+}
+
+// StatisticsBucket is the payment statistics of one hour or day.
+type StatisticsBucket struct {
+	Start time.Time
+	*StatisticsSummary
+}
+
+// StatisticsSummary is the payment statistics of a period.
+type StatisticsSummary struct {
+	// Statuses holds the payments that reached each status during the period.
+	Statuses []*StatusStatistics
+	// MedianConfirmationTime is the median time from detection to confirmation of the payments
+	// confirmed during the period, or nil when none were.
+	MedianConfirmationTime *time.Duration
+	// OrphanRate is the number of payments orphaned per payment detected during the period.
+	OrphanRate float64
+}
+
+// StatusStatistics is the count and per-currency volume of the payments that reached a status.
+type StatusStatistics struct {
+	Status  PaymentStatus
+	Count   int
+	Volumes []*CurrencyVolume
+}
+
+// CurrencyVolume is the total amount of payments in one currency.
+type CurrencyVolume struct {
+	Currency shared.CryptoCurrency
+	Amount   decimal.Decimal
+}
+
+// StatisticsService defines the interface for payment statistics.
+type StatisticsService interface {
+	// GetStatisticsSeries returns payment counts, volumes, confirmation latency and orphan rate per bucket.
+	GetStatisticsSeries(ctx context.Context, req *StatisticsRequest) (*StatisticsSeries, error)
+}
+
+// StatisticsServiceImpl implements the StatisticsService interface.
+type StatisticsServiceImpl struct {
+	repository StatisticsRepository
+	logger     *zap.Logger
+}
+
+// NewStatisticsService creates a new payment statistics service.
+func NewStatisticsService(repository StatisticsRepository, logger *zap.Logger) StatisticsService {
+	return &StatisticsServiceImpl{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// GetStatisticsSeries returns payment statistics per bucket over the requested period, including empty buckets.
+func (s *StatisticsServiceImpl) GetStatisticsSeries(
+	ctx context.Context,
+	req *StatisticsRequest,
+) (*StatisticsSeries, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request cannot be nil", ErrInvalidStatisticsRequest)
+	}
+	from, to, err := statisticsPeriod(req)
+	if err != nil {
+		return nil, err
+	}
+
+	rollups, err := s.repository.ListRollups(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payment statistics: %w", err)
+	}
+	confirmations, err := s.repository.ListConfirmations(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payment confirmations: %w", err)
+	}
+
+	width := req.Interval.Duration()
+	series := &StatisticsSeries{Interval: req.Interval, From: from, To: to}
+	builders := make([]*summaryBuilder, 0, int(to.Sub(from)/width))
+	for start := from; start.Before(to); start = start.Add(width) {
+		builders = append(builders, newSummaryBuilder())
+		series.Buckets = append(series.Buckets, &StatisticsBucket{Start: start})
+	}
+	bucket := func(at time.Time) *summaryBuilder {
+		i := int(at.Sub(from) / width)
+		if at.Before(from) || i >= len(builders) {
+			return nil
+		}
+		return builders[i]
+	}
+	total := newSummaryBuilder()
+
+	for _, rollup := range rollups {
+		if builder := bucket(rollup.HourStart); builder != nil {
+			builder.addRollup(rollup)
+			total.addRollup(rollup)
+		}
+	}
+	for _, confirmation := range confirmations {
+		if builder := bucket(confirmation.ConfirmedAt); builder != nil {
+			builder.addConfirmation(confirmation)
+			total.addConfirmation(confirmation)
+		}
+	}
+
+	for i, builder := range builders {
+		series.Buckets[i].StatisticsSummary = builder.build()
+	}
+	series.Summary = total.build()
+	return series, nil
+}
+
+// statisticsPeriod aligns the period of a request to its interval, applying the default and maximum periods.
+func statisticsPeriod(req *StatisticsRequest) (time.Time, time.Time, error) {
+	if !req.Interval.IsValid() {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: interval must be %s or %s",
+			ErrInvalidStatisticsRequest, IntervalHour, IntervalDay)
+	}
+	width := req.Interval.Duration()
+	defaultPeriod, maxPeriod := defaultHourlyStatisticsPeriod, maxHourlyStatisticsPeriod
+	if req.Interval == IntervalDay {
+		defaultPeriod, maxPeriod = defaultDailyStatisticsPeriod, maxDailyStatisticsPeriod
+	}
+
+	to := req.To.UTC()
+	if req.To.IsZero() {
+		to = shared.Now().UTC()
+	}
+	if aligned := to.Truncate(width); !aligned.Equal(to) {
+		to = aligned.Add(width)
+	}
+	from := req.From.UTC().Truncate(width)
+	if req.From.IsZero() {
+		from = to.Add(-defaultPeriod)
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: start must be before end", ErrInvalidStatisticsRequest)
+	}
+	if to.Sub(from) > maxPeriod {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %s statistics cannot cover more than %d days",
+			ErrInvalidStatisticsRequest, req.Interval, int(maxPeriod.Hours()/24))
+	}
+	return from, to, nil
+}
+
+// summaryBuilder accumulates the rollups and confirmations of a period.
+type summaryBuilder struct {
+	counts    map[PaymentStatus]int
+	volumes   map[PaymentStatus]map[shared.CryptoCurrency]decimal.Decimal
+	latencies []time.Duration
+}
+
+func newSummaryBuilder() *summaryBuilder {
+	return &summaryBuilder{
+		counts:  make(map[PaymentStatus]int),
+		volumes: make(map[PaymentStatus]map[shared.CryptoCurrency]decimal.Decimal),
+	}
+}
+
+func (b *summaryBuilder) addRollup(rollup *StatusRollup) {
+	b.counts[rollup.Status] += rollup.Count
+	volumes, ok := b.volumes[rollup.Status]
+	if !ok {
+		volumes = make(map[shared.CryptoCurrency]decimal.Decimal)
+		b.volumes[rollup.Status] = volumes
+	}
+	volumes[rollup.Currency] = volumes[rollup.Currency].Add(rollup.Volume)
+}
+
+func (b *summaryBuilder) addConfirmation(confirmation *ConfirmationLatency) {
+	b.latencies = append(b.latencies, confirmation.Latency)
+}
+
+func (b *summaryBuilder) build() *StatisticsSummary {
+	summary := &StatisticsSummary{Statuses: make([]*StatusStatistics, 0, len(statisticsStatuses))}
+	for _, status := range statisticsStatuses {
+		stats := &StatusStatistics{Status: status, Count: b.counts[status], Volumes: []*CurrencyVolume{}}
+		for currency, amount := range b.volumes[status] {
+			stats.Volumes = append(stats.Volumes, &CurrencyVolume{Currency: currency, Amount: amount})
+		}
+		sort.Slice(stats.Volumes, func(i, j int) bool {
+			return stats.Volumes[i].Currency < stats.Volumes[j].Currency
+		})
+		summary.Statuses = append(summary.Statuses, stats)
+	}
+
+	if detected := b.counts[StatusDetected]; detected > 0 {
+		summary.OrphanRate = float64(b.counts[StatusOrphaned]) / float64(detected)
+	}
+
+	if n := len(b.latencies); n > 0 {
+		sort.Slice(b.latencies, func(i, j int) bool { return b.latencies[i] < b.latencies[j] })
+		median := b.latencies[n/2]
+		if n%2 == 0 {
+			median = (b.latencies[n/2-1] + b.latencies[n/2]) / 2
+		}
+		summary.MedianConfirmationTime = &median
+	}
+	return summary
+}
@@ -0,0 +1,89 @@
+package payment
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// StatisticsHandler feeds the payment statistics rollup from payment events.
+type StatisticsHandler struct {
+	payments   Repository
+	statistics StatisticsRepository
+	logger     *zap.Logger
+}
+
+// NewStatisticsHandler creates a new payment statistics handler.
+func NewStatisticsHandler(payments Repository, statistics StatisticsRepository, logger *zap.Logger) *StatisticsHandler {
+	return &StatisticsHandler{
+		payments:   payments,
+		statistics: statistics,
+		logger:     logger,
+	}
+}
+
+// RegisterStatisticsHandler subscribes a payment statistics handler to payment events.
+func RegisterStatisticsHandler(
+	registry shared.EventHandlerRegistry,
+	payments Repository,
+	statistics StatisticsRepository,
+	logger *zap.Logger,
+) {
+	registry.RegisterHandler(NewStatisticsHandler(payments, statistics, logger))
+}
+
+// EventTypes returns the payment events that move a payment to a new status.
+func (h *StatisticsHandler) EventTypes() []string {
+	return []string{
+		shared.EventTypePaymentDetected,
+		shared.EventTypePaymentStatusChanged,
+	}
+}
+
+// HandleEvent adds the payment to the rollup of the status it reached in the hour the event occurred,
+// and records its confirmation latency once it is confirmed.
+func (h *StatisticsHandler) HandleEvent(ctx context.Context, event *shared.BaseDomainEvent) error {
+	status := StatusDetected
+	if event.EventType == shared.EventTypePaymentStatusChanged {
+		var data shared.PaymentStatusChangedEvent
+		if err := shared.DecodeEventData(event, &data); err != nil {
+			return fmt.Errorf("failed to decode payment event: %w", err)
+		}
+		if status = PaymentStatus(data.Status); !status.IsValid() {
+			h.logger.Debug("Skipping payment event without a status", zap.String("payment_id", event.AggregateID))
+			return nil
+		}
+	}
+
+	payment, err := h.payments.FindByID(ctx, event.AggregateID)
+	if err != nil {
+		return fmt.Errorf("failed to load payment for statistics: %w", err)
+	}
+
+	occurredAt := event.OccurredAt.UTC()
+	if err := h.statistics.AddRollup(ctx, &StatusRollup{
+		HourStart: occurredAt.Truncate(time.Hour),
+		Status:    status,
+		Currency:  payment.Amount().Currency(),
+		Count:     1,
+		Volume:    payment.Amount().Amount().Amount(),
+	}); err != nil {
+		return fmt.Errorf("failed to add payment to statistics: %w", err)
+	}
+
+	if status != StatusConfirmed {
+		return nil
+	}
+	confirmedAt := occurredAt
+	if at := payment.ConfirmedAt(); at != nil {
+		confirmedAt = at.UTC()
+	}
+	return h.statistics.RecordConfirmation(ctx, &ConfirmationLatency{
+		PaymentID:   payment.ID(),
+		ConfirmedAt: confirmedAt,
+		Latency:     confirmedAt.Sub(payment.DetectedAt()),
+	})
+}
@@ -0,0 +1,165 @@
+package payment_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeStatistics struct {
+	rollups       []*payment.StatusRollup
+	confirmations []*payment.ConfirmationLatency
+}
+
+func (r *fakeStatistics) AddRollup(_ context.Context, rollup *payment.StatusRollup) error {
+	r.rollups = append(r.rollups, rollup)
+	return nil
+}
+
+func (r *fakeStatistics) RecordConfirmation(_ context.Context, latency *payment.ConfirmationLatency) error {
+	r.confirmations = append(r.confirmations, latency)
+	return nil
+}
+
+func (r *fakeStatistics) ListRollups(_ context.Context, from, to time.Time) ([]*payment.StatusRollup, error) {
+	var result []*payment.StatusRollup
+	for _, rollup := range r.rollups {
+		if !rollup.HourStart.Before(from) && rollup.HourStart.Before(to) {
+			result = append(result, rollup)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeStatistics) ListConfirmations(
+	_ context.Context,
+	from, to time.Time,
+) ([]*payment.ConfirmationLatency, error) {
+	var result []*payment.ConfirmationLatency
+	for _, confirmation := range r.confirmations {
+		if !confirmation.ConfirmedAt.Before(from) && confirmation.ConfirmedAt.Before(to) {
+			result = append(result, confirmation)
+		}
+	}
+	return result, nil
+}
+
+func statusStatistics(t *testing.T, summary *payment.StatisticsSummary, status payment.PaymentStatus) *payment.StatusStatistics {
+	for _, stats := range summary.Statuses {
+		if stats.Status == status {
+			return stats
+		}
+	}
+	t.Fatalf("status %s is not reported", status)
+	return nil
+}
+
+func TestStatisticsService_GetStatisticsSeries(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	repo := &fakeStatistics{}
+	add := func(hour int, status payment.PaymentStatus, currency shared.CryptoCurrency, count int, volume string) {
+		repo.rollups = append(repo.rollups, &payment.StatusRollup{
+			HourStart: day.Add(time.Duration(hour) * time.Hour),
+			Status:    status,
+			Currency:  currency,
+			Count:     count,
+			Volume:    decimal.RequireFromString(volume),
+		})
+	}
+	confirm := func(hour int, latency time.Duration) {
+		repo.confirmations = append(repo.confirmations, &payment.ConfirmationLatency{
+			ConfirmedAt: day.Add(time.Duration(hour)*time.Hour + 10*time.Minute),
+			Latency:     latency,
+		})
+	}
+	add(1, payment.StatusDetected, shared.CryptoCurrencyUSDT, 3, "300")
+	add(1, payment.StatusDetected, shared.CryptoCurrencyBTC, 1, "0.5")
+	add(1, payment.StatusOrphaned, shared.CryptoCurrencyUSDT, 1, "100")
+	add(2, payment.StatusConfirmed, shared.CryptoCurrencyUSDT, 2, "200")
+	add(25, payment.StatusDetected, shared.CryptoCurrencyUSDT, 1, "50")
+	confirm(2, 2*time.Minute)
+	confirm(2, 4*time.Minute)
+	confirm(25, 9*time.Minute)
+
+	service := payment.NewStatisticsService(repo, zap.NewNop())
+
+	t.Run("Hourly", func(t *testing.T) {
+		series, err := service.GetStatisticsSeries(ctx, &payment.StatisticsRequest{
+			Interval: payment.IntervalHour,
+			From:     day.Add(30 * time.Minute),
+			To:       day.Add(2*time.Hour + 30*time.Minute),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, day, series.From)
+		assert.Equal(t, day.Add(3*time.Hour), series.To)
+		require.Len(t, series.Buckets, 3)
+		assert.Equal(t, day.Add(time.Hour), series.Buckets[1].Start)
+
+		empty := series.Buckets[0]
+		assert.Zero(t, statusStatistics(t, empty.StatisticsSummary, payment.StatusDetected).Count)
+		assert.Nil(t, empty.MedianConfirmationTime)
+		assert.Zero(t, empty.OrphanRate)
+
+		detected := statusStatistics(t, series.Buckets[1].StatisticsSummary, payment.StatusDetected)
+		assert.Equal(t, 4, detected.Count)
+		require.Len(t, detected.Volumes, 2)
+		assert.Equal(t, shared.CryptoCurrencyBTC, detected.Volumes[0].Currency)
+		assert.Equal(t, "0.5", detected.Volumes[0].Amount.String())
+		assert.Equal(t, "300", detected.Volumes[1].Amount.String())
+		assert.InDelta(t, 0.25, series.Buckets[1].OrphanRate, 1e-9)
+
+		require.NotNil(t, series.Buckets[2].MedianConfirmationTime)
+		assert.Equal(t, 3*time.Minute, *series.Buckets[2].MedianConfirmationTime)
+		assert.Equal(t, 2, statusStatistics(t, series.Summary, payment.StatusConfirmed).Count)
+	})
+
+	t.Run("Daily", func(t *testing.T) {
+		series, err := service.GetStatisticsSeries(ctx, &payment.StatisticsRequest{
+			Interval: payment.IntervalDay,
+			From:     day,
+			To:       day.Add(48 * time.Hour),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, series.Buckets, 2)
+		assert.Equal(t, 4, statusStatistics(t, series.Buckets[0].StatisticsSummary, payment.StatusDetected).Count)
+		assert.Equal(t, 1, statusStatistics(t, series.Buckets[1].StatisticsSummary, payment.StatusDetected).Count)
+		assert.InDelta(t, 0.2, series.Summary.OrphanRate, 1e-9)
+		require.NotNil(t, series.Summary.MedianConfirmationTime)
+		assert.Equal(t, 4*time.Minute, *series.Summary.MedianConfirmationTime)
+	})
+
+	t.Run("InvalidRequest", func(t *testing.T) {
+		for name, req := range map[string]*payment.StatisticsRequest{
+			"UnknownInterval": {Interval: "week"},
+			"EmptyPeriod":     {Interval: payment.IntervalHour, From: day, To: day},
+			"PeriodTooLong":   {Interval: payment.IntervalHour, From: day, To: day.Add(32 * 24 * time.Hour)},
+		} {
+			_, err := service.GetStatisticsSeries(ctx, req)
+			assert.True(t, errors.Is(err, payment.ErrInvalidStatisticsRequest), name)
+		}
+	})
+}
+
+func TestStatisticsHandler_EventTypes(t *testing.T) {
+	handler := payment.NewStatisticsHandler(nil, &fakeStatistics{}, zap.NewNop())
+	assert.ElementsMatch(t, []string{
+		shared.EventTypePaymentDetected,
+		shared.EventTypePaymentStatusChanged,
+	}, handler.EventTypes())
+
+	// Status changes that do not report a status are skipped without loading the payment
+	event := shared.CreateDomainEvent(shared.EventTypePaymentStatusChanged, "payment-1", "Payment",
+		map[string]interface{}{}, nil)
+	require.NoError(t, handler.HandleEvent(context.Background(), event))
+}
@@ -2,7 +2,6 @@ package payment
 
 import (
 	"crypto-checkout/internal/domain/shared"
-	"errors"
 	"fmt"
 	"time"
 )
@@ -38,11 +37,11 @@ type BlockInfo struct {
 // NewBlockInfo creates a new block info.
 func NewBlockInfo(number int64, hash string) (*BlockInfo, error) {
 	if number < 0 {
-		return nil, errors.New("block number cannot be negative")
+		return nil, shared.NewFieldError("block_number", "block number cannot be negative")
 	}
 
 	if hash == "" {
-		return nil, errors.New("block hash cannot be empty")
+		return nil, shared.NewFieldError("block_hash", "block hash cannot be empty")
 	}
 
 	return &BlockInfo{
@@ -79,11 +78,11 @@ type PaymentAmount struct {
 // NewPaymentAmount creates a new payment amount.
 func NewPaymentAmount(amount *shared.Money, currency shared.CryptoCurrency) (*PaymentAmount, error) {
 	if amount == nil {
-		return nil, errors.New("amount cannot be nil")
+		return nil, shared.NewFieldError("amount", "amount cannot be nil")
 	}
 
 	if !currency.IsValid() {
-		return nil, errors.New("invalid cryptocurrency")
+		return nil, shared.NewFieldError("currency", "invalid cryptocurrency")
 	}
 
 	return &PaymentAmount{
@@ -124,16 +123,16 @@ type NetworkFee struct {
 // NewNetworkFee creates a new network fee.
 func NewNetworkFee(fee *shared.Money, currency shared.CryptoCurrency) (*NetworkFee, error) {
 	if fee == nil {
-		return nil, errors.New("fee cannot be nil")
+		return nil, shared.NewFieldError("fee", "fee cannot be nil")
 	}
 
 	if !currency.IsValid() {
-		return nil, errors.New("invalid cryptocurrency")
+		return nil, shared.NewFieldError("currency", "invalid cryptocurrency")
 	}
 
 	// Network fees should be positive
 	if fee.Amount().Sign() <= 0 {
-		return nil, errors.New("network fee must be positive")
+		return nil, shared.NewFieldError("fee", "network fee must be positive")
 	}
 
 	return &NetworkFee{
@@ -175,7 +174,7 @@ type PaymentTimestamps struct {
 
 // NewPaymentTimestamps creates new payment timestamps.
 func NewPaymentTimestamps(detectedAt time.Time) *PaymentTimestamps {
-	now := time.Now().UTC()
+	now := shared.Now().UTC()
 	return &PaymentTimestamps{
 		detectedAt: detectedAt,
 		createdAt:  now,
@@ -183,6 +182,15 @@ func NewPaymentTimestamps(detectedAt time.Time) *PaymentTimestamps {
 	}
 }
 
+// newPaymentTimestampsAt creates timestamps for a payment detected and recorded at the same instant.
+func newPaymentTimestampsAt(at time.Time) *PaymentTimestamps {
+	return &PaymentTimestamps{
+		detectedAt: at,
+		createdAt:  at,
+		updatedAt:  at,
+	}
+}
+
 // DetectedAt returns when the payment was first detected.
 func (pt *PaymentTimestamps) DetectedAt() time.Time {
 	return pt.detectedAt
@@ -206,7 +214,7 @@ func (pt *PaymentTimestamps) UpdatedAt() time.Time {
 // SetConfirmedAt sets the confirmation timestamp.
 func (pt *PaymentTimestamps) SetConfirmedAt(confirmedAt time.Time) {
 	pt.confirmedAt = &confirmedAt
-	pt.updatedAt = time.Now().UTC()
+	pt.updatedAt = shared.Now().UTC()
 }
 
 // SetUpdatedAt updates the last updated timestamp.
@@ -0,0 +1,15 @@
+package paymentlink
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the payment link service layer dependencies.
+var Module = fx.Module("payment-link-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+)
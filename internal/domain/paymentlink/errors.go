@@ -0,0 +1,21 @@
+package paymentlink
+
+import "errors"
+
+// Domain errors for payment link operations
+var (
+	ErrLinkNotFound   = errors.New("payment link not found")
+	ErrSlugTaken      = errors.New("payment link slug is already taken")
+	ErrLinkInactive   = errors.New("payment link is no longer active")
+	ErrAmountRequired = errors.New("an amount is required for open-amount payment links")
+	ErrInvalidAmount  = errors.New("invalid payment link amount")
+	ErrInvalidRequest = errors.New("invalid payment link request")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeLinkNotFound   = "PAYMENT_LINK_NOT_FOUND"
+	ErrCodeLinkInactive   = "PAYMENT_LINK_INACTIVE"
+	ErrCodeAmountRequired = "AMOUNT_REQUIRED"
+	ErrCodeInvalidAmount  = "INVALID_AMOUNT"
+)
@@ -0,0 +1,203 @@
+// Package paymentlink provides reusable merchant payment links that materialize a fresh invoice per customer visit.
+package paymentlink
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxTitleLength matches the invoice title column the link's invoices are stored in.
+const maxTitleLength = 255
+
+// PaymentLink is a merchant-defined product and price, shared as a short URL, that creates a new invoice
+// for every customer who opens it.
+type PaymentLink struct {
+	id             string
+	merchantID     string
+	slug           string
+	title          string
+	description    string
+	amount         *shared.Money // Nil for an open amount chosen by the customer
+	currency       shared.Currency
+	cryptoCurrency shared.CryptoCurrency
+	active         bool
+	visits         int
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+// Stats summarizes how a payment link has been used.
+type Stats struct {
+	Visits          int
+	Invoices        int
+	PaidInvoices    int
+	AmountCollected *shared.Money // Gross total of the paid invoices, in the link currency
+}
+
+// LinkedInvoice is an invoice materialized from a payment link, as needed for usage statistics.
+type LinkedInvoice struct {
+	InvoiceID string
+	Status    invoice.InvoiceStatus
+	Total     string
+}
+
+// NewPaymentLink creates a new active payment link. A nil amount lets the customer choose how much to pay.
+func NewPaymentLink(
+	id, merchantID, slug, title, description string,
+	amount *shared.Money,
+	currency shared.Currency,
+	cryptoCurrency shared.CryptoCurrency,
+) (*PaymentLink, error) {
+	now := shared.Now().UTC()
+	return RestorePaymentLink(id, merchantID, slug, title, description, amount, currency, cryptoCurrency, true, 0, now, now)
+}
+
+// RestorePaymentLink recreates a payment link from storage.
+func RestorePaymentLink(
+	id, merchantID, slug, title, description string,
+	amount *shared.Money,
+	currency shared.Currency,
+	cryptoCurrency shared.CryptoCurrency,
+	active bool,
+	visits int,
+	createdAt, updatedAt time.Time,
+) (*PaymentLink, error) {
+	if id == "" {
+		return nil, errors.New("payment link ID is required")
+	}
+	if merchantID == "" {
+		return nil, errors.New("merchant ID is required")
+	}
+	if slug == "" {
+		return nil, errors.New("payment link slug is required")
+	}
+	if title == "" || len(title) > maxTitleLength {
+		return nil, fmt.Errorf("payment link title must be 1-%d characters", maxTitleLength)
+	}
+	if !currency.IsValid() {
+		return nil, shared.ErrInvalidCurrency
+	}
+	if !cryptoCurrency.IsValid() {
+		return nil, errors.New("invalid cryptocurrency")
+	}
+	if amount != nil {
+		if amount.IsZero() {
+			return nil, errors.New("payment link amount must be positive")
+		}
+		if amount.Currency() != string(currency) {
+			return nil, errors.New("payment link amount must be in the link currency")
+		}
+	}
+	if visits < 0 {
+		return nil, errors.New("visit count cannot be negative")
+	}
+
+	return &PaymentLink{
+		id:             id,
+		merchantID:     merchantID,
+		slug:           slug,
+		title:          title,
+		description:    description,
+		amount:         amount,
+		currency:       currency,
+		cryptoCurrency: cryptoCurrency,
+		active:         active,
+		visits:         visits,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
+	}, nil
+}
+
+// ID returns the payment link ID.
+func (l *PaymentLink) ID() string {
+	return l.id
+}
+
+// MerchantID returns the merchant that owns the link.
+func (l *PaymentLink) MerchantID() string {
+	return l.merchantID
+}
+
+// Slug returns the short code the link is shared under.
+func (l *PaymentLink) Slug() string {
+	return l.slug
+}
+
+// Title returns the product title used for the link's invoices.
+func (l *PaymentLink) Title() string {
+	return l.title
+}
+
+// Description returns the product description used for the link's invoices.
+func (l *PaymentLink) Description() string {
+	return l.description
+}
+
+// Amount returns the fixed price of the link, or nil for an open amount.
+func (l *PaymentLink) Amount() *shared.Money {
+	return l.amount
+}
+
+// IsOpenAmount returns true if the customer chooses how much to pay.
+func (l *PaymentLink) IsOpenAmount() bool {
+	return l.amount == nil
+}
+
+// Currency returns the fiat currency of the link's invoices.
+func (l *PaymentLink) Currency() shared.Currency {
+	return l.currency
+}
+
+// CryptoCurrency returns the cryptocurrency the link's invoices are paid in.
+func (l *PaymentLink) CryptoCurrency() shared.CryptoCurrency {
+	return l.cryptoCurrency
+}
+
+// IsActive returns true if the merchant has not deactivated the link.
+func (l *PaymentLink) IsActive() bool {
+	return l.active
+}
+
+// Visits returns how many times customers have opened the link.
+func (l *PaymentLink) Visits() int {
+	return l.visits
+}
+
+// CreatedAt returns when the link was created.
+func (l *PaymentLink) CreatedAt() time.Time {
+	return l.createdAt
+}
+
+// UpdatedAt returns when the link was last changed.
+func (l *PaymentLink) UpdatedAt() time.Time {
+	return l.updatedAt
+}
+
+// InvoiceAmount returns the price of an invoice created from the link. Fixed-price links ignore the
+// customer amount; open-amount links require a positive one.
+func (l *PaymentLink) InvoiceAmount(customerAmount string) (*shared.Money, error) {
+	if l.amount != nil {
+		return l.amount, nil
+	}
+	if customerAmount == "" {
+		return nil, ErrAmountRequired
+	}
+
+	amount, err := shared.NewMoney(customerAmount, l.currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAmount, err)
+	}
+	if amount.IsZero() {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidAmount)
+	}
+	return amount, nil
+}
+
+// Deactivate stops the link from creating new invoices.
+func (l *PaymentLink) Deactivate() {
+	l.active = false
+	l.updatedAt = shared.Now().UTC()
+}
@@ -0,0 +1,64 @@
+package paymentlink
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPaymentLink_Validation(t *testing.T) {
+	zero, err := shared.NewMoney("0", shared.CurrencyUSD)
+	require.NoError(t, err)
+	_, err = NewPaymentLink("link-1", "merchant-1", "abcd1234", "Coffee", "", zero, shared.CurrencyUSD, shared.CryptoCurrencyUSDT)
+	require.Error(t, err)
+
+	euros, err := shared.NewMoney("5", shared.CurrencyEUR)
+	require.NoError(t, err)
+	_, err = NewPaymentLink("link-1", "merchant-1", "abcd1234", "Coffee", "", euros, shared.CurrencyUSD, shared.CryptoCurrencyUSDT)
+	require.Error(t, err)
+
+	_, err = NewPaymentLink("link-1", "merchant-1", "abcd1234", "", "", nil, shared.CurrencyUSD, shared.CryptoCurrencyUSDT)
+	require.Error(t, err)
+
+	link, err := NewPaymentLink("link-1", "merchant-1", "abcd1234", "Tip jar", "", nil, shared.CurrencyUSD, shared.CryptoCurrencyUSDT)
+	require.NoError(t, err)
+	assert.True(t, link.IsOpenAmount())
+	assert.True(t, link.IsActive())
+}
+
+func TestPaymentLink_InvoiceAmount(t *testing.T) {
+	price, err := shared.NewMoney("25.00", shared.CurrencyUSD)
+	require.NoError(t, err)
+	fixed, err := NewPaymentLink("link-1", "merchant-1", "abcd1234", "Coffee", "", price, shared.CurrencyUSD, shared.CryptoCurrencyUSDT)
+	require.NoError(t, err)
+
+	amount, err := fixed.InvoiceAmount("1000")
+	require.NoError(t, err)
+	assert.Equal(t, "25.00", amount.String())
+
+	open, err := NewPaymentLink("link-2", "merchant-1", "efgh5678", "Tip jar", "", nil, shared.CurrencyUSD, shared.CryptoCurrencyUSDT)
+	require.NoError(t, err)
+
+	amount, err = open.InvoiceAmount("12.50")
+	require.NoError(t, err)
+	assert.Equal(t, "12.50", amount.String())
+
+	_, err = open.InvoiceAmount("")
+	require.ErrorIs(t, err, ErrAmountRequired)
+	_, err = open.InvoiceAmount("0")
+	require.ErrorIs(t, err, ErrInvalidAmount)
+	_, err = open.InvoiceAmount("-3")
+	require.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestGenerateSlug(t *testing.T) {
+	slug, err := generateSlug()
+	require.NoError(t, err)
+	assert.Len(t, slug, slugLength)
+
+	other, err := generateSlug()
+	require.NoError(t, err)
+	assert.NotEqual(t, slug, other)
+}
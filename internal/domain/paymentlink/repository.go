@@ -0,0 +1,30 @@
+package paymentlink
+
+import "context"
+
+// Repository defines the interface for payment link persistence.
+type Repository interface {
+	// Save persists a new payment link, returning ErrSlugTaken if another link uses the slug.
+	Save(ctx context.Context, link *PaymentLink) error
+
+	// FindByID retrieves a payment link by its ID.
+	FindByID(ctx context.Context, id string) (*PaymentLink, error)
+
+	// FindBySlug retrieves a payment link by its slug.
+	FindBySlug(ctx context.Context, slug string) (*PaymentLink, error)
+
+	// ListByMerchant retrieves a merchant's payment links, newest first.
+	ListByMerchant(ctx context.Context, merchantID string) ([]*PaymentLink, error)
+
+	// Update updates an existing payment link.
+	Update(ctx context.Context, link *PaymentLink) error
+
+	// IncrementVisits atomically records a customer visit.
+	IncrementVisits(ctx context.Context, id string) error
+
+	// RecordInvoice links an invoice to the payment link it was created from.
+	RecordInvoice(ctx context.Context, linkID, invoiceID string) error
+
+	// ListInvoices retrieves the invoices created from a payment link with their current status and total.
+	ListInvoices(ctx context.Context, linkID string) ([]*LinkedInvoice, error)
+}
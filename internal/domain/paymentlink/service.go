@@ -0,0 +1,336 @@
+package paymentlink
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// slugLength and slugAlphabet give 62^8 possible short codes, enough that collisions are rare retries.
+	slugLength      = 8
+	slugAlphabet    = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	maxSlugAttempts = 3
+)
+
+// Service defines the interface for managing payment links and creating invoices from them.
+type Service interface {
+	// CreateLink creates a payment link for a merchant.
+	CreateLink(ctx context.Context, req *CreateLinkRequest) (*PaymentLink, error)
+
+	// ListLinks lists a merchant's payment links.
+	ListLinks(ctx context.Context, merchantID string) ([]*PaymentLink, error)
+
+	// GetLink retrieves a merchant's payment link.
+	GetLink(ctx context.Context, merchantID, linkID string) (*PaymentLink, error)
+
+	// GetLinkStats summarizes the visits and invoices of a merchant's payment link.
+	GetLinkStats(ctx context.Context, merchantID, linkID string) (*Stats, error)
+
+	// DeactivateLink stops a merchant's payment link from creating new invoices.
+	DeactivateLink(ctx context.Context, merchantID, linkID string) (*PaymentLink, error)
+
+	// GetLinkBySlug retrieves a payment link by its slug, active or not, without recording a visit.
+	GetLinkBySlug(ctx context.Context, slug string) (*PaymentLink, error)
+
+	// OpenLink retrieves an active payment link by its slug and records the customer visit.
+	OpenLink(ctx context.Context, slug string) (*PaymentLink, error)
+
+	// CreateInvoice creates a fresh invoice from an active payment link. The amount is only used by
+	// open-amount links.
+	CreateInvoice(ctx context.Context, slug, amount string) (*invoice.Invoice, error)
+}
+
+// CreateLinkRequest represents the request to create a payment link.
+type CreateLinkRequest struct {
+	MerchantID     string `validate:"required"`
+	Title          string `validate:"required"`
+	Description    string
+	Amount         string // Empty for an open amount chosen by the customer
+	Currency       shared.Currency
+	CryptoCurrency shared.CryptoCurrency
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository     Repository
+	invoiceService invoice.InvoiceService
+	logger         *zap.Logger
+}
+
+// NewService creates a new payment link service.
+func NewService(repository Repository, invoiceService invoice.InvoiceService, logger *zap.Logger) Service {
+	return &ServiceImpl{
+		repository:     repository,
+		invoiceService: invoiceService,
+		logger:         logger,
+	}
+}
+
+// CreateLink creates a payment link for a merchant under a newly generated slug.
+func (s *ServiceImpl) CreateLink(ctx context.Context, req *CreateLinkRequest) (*PaymentLink, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: create payment link request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = shared.CurrencyUSD
+	}
+	cryptoCurrency := req.CryptoCurrency
+	if cryptoCurrency == "" {
+		cryptoCurrency = shared.CryptoCurrencyUSDT
+	}
+	if _, err := s.invoiceService.ResolveToken(ctx, req.MerchantID, cryptoCurrency, ""); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	var amount *shared.Money
+	if req.Amount != "" {
+		var err error
+		amount, err = shared.NewMoney(req.Amount, currency)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate payment link ID: %w", err)
+	}
+
+	var link *PaymentLink
+	for attempt := 0; attempt < maxSlugAttempts; attempt++ {
+		slug, err := generateSlug()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate payment link slug: %w", err)
+		}
+
+		link, err = NewPaymentLink(id, req.MerchantID, slug, req.Title, req.Description, amount, currency, cryptoCurrency)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
+
+		err = s.repository.Save(ctx, link)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, ErrSlugTaken) || attempt == maxSlugAttempts-1 {
+			return nil, err
+		}
+	}
+
+	s.logger.Info("Payment link created",
+		zap.String("link_id", link.ID()),
+		zap.String("merchant_id", link.MerchantID()),
+		zap.String("slug", link.Slug()))
+
+	return link, nil
+}
+
+// ListLinks lists a merchant's payment links.
+func (s *ServiceImpl) ListLinks(ctx context.Context, merchantID string) ([]*PaymentLink, error) {
+	if merchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+
+	return s.repository.ListByMerchant(ctx, merchantID)
+}
+
+// GetLink retrieves a merchant's payment link.
+func (s *ServiceImpl) GetLink(ctx context.Context, merchantID, linkID string) (*PaymentLink, error) {
+	if merchantID == "" || linkID == "" {
+		return nil, fmt.Errorf("%w: merchant ID and payment link ID are required", ErrInvalidRequest)
+	}
+
+	link, err := s.repository.FindByID(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+	if link.MerchantID() != merchantID {
+		return nil, ErrLinkNotFound
+	}
+
+	return link, nil
+}
+
+// GetLinkStats summarizes the visits and invoices of a merchant's payment link.
+// Invoices count as paid once fully paid, including those refunded since.
+func (s *ServiceImpl) GetLinkStats(ctx context.Context, merchantID, linkID string) (*Stats, error) {
+	link, err := s.GetLink(ctx, merchantID, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	invoices, err := s.repository.ListInvoices(ctx, link.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	collected := decimal.Zero
+	stats := &Stats{Visits: link.Visits(), Invoices: len(invoices)}
+	for _, linked := range invoices {
+		switch linked.Status {
+		case invoice.StatusPaid, invoice.StatusPartiallyRefunded, invoice.StatusRefunded:
+			total, err := decimal.NewFromString(linked.Total)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse total of invoice %s: %w", linked.InvoiceID, err)
+			}
+			stats.PaidInvoices++
+			collected = collected.Add(total)
+		default:
+		}
+	}
+
+	stats.AmountCollected, err = shared.NewMoney(collected.String(), link.Currency())
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// DeactivateLink stops a merchant's payment link from creating new invoices.
+// Invoices already created from the link can still be paid.
+func (s *ServiceImpl) DeactivateLink(ctx context.Context, merchantID, linkID string) (*PaymentLink, error) {
+	link, err := s.GetLink(ctx, merchantID, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !link.IsActive() {
+		return link, nil
+	}
+
+	link.Deactivate()
+	if err := s.repository.Update(ctx, link); err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// GetLinkBySlug retrieves a payment link by its slug, active or not, without recording a visit.
+func (s *ServiceImpl) GetLinkBySlug(ctx context.Context, slug string) (*PaymentLink, error) {
+	if slug == "" {
+		return nil, fmt.Errorf("%w: payment link slug is required", ErrInvalidRequest)
+	}
+	return s.repository.FindBySlug(ctx, slug)
+}
+
+// OpenLink retrieves an active payment link by its slug and records the customer visit.
+func (s *ServiceImpl) OpenLink(ctx context.Context, slug string) (*PaymentLink, error) {
+	link, err := s.findActiveLink(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.IncrementVisits(ctx, link.ID()); err != nil {
+		// A lost visit must not keep the customer from paying
+		s.logger.Warn("Failed to record payment link visit",
+			zap.String("link_id", link.ID()),
+			zap.Error(err))
+	}
+
+	return link, nil
+}
+
+// CreateInvoice creates a fresh invoice with its own payment address from an active payment link.
+func (s *ServiceImpl) CreateInvoice(ctx context.Context, slug, amount string) (*invoice.Invoice, error) {
+	link, err := s.findActiveLink(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := link.InvoiceAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := map[string]interface{}{
+		"payment_link_id":   link.ID(),
+		"payment_link_slug": link.Slug(),
+	}
+	inv, err := s.invoiceService.CreateInvoice(ctx, &invoice.CreateInvoiceRequest{
+		MerchantID:  link.MerchantID(),
+		Title:       link.Title(),
+		Description: link.Description(),
+		Items: []*invoice.CreateInvoiceItemRequest{{
+			Name:        link.Title(),
+			Description: link.Description(),
+			Quantity:    "1",
+			UnitPrice:   price,
+		}},
+		Currency:         link.Currency(),
+		CryptoCurrency:   link.CryptoCurrency(),
+		Metadata:         maps.Clone(recorded),
+		RecordedMetadata: recorded,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.RecordInvoice(ctx, link.ID(), inv.ID()); err != nil {
+		// The invoice is already payable; only the link statistics miss it
+		s.logger.Error("Failed to record payment link invoice",
+			zap.String("link_id", link.ID()),
+			zap.String("invoice_id", inv.ID()),
+			zap.Error(err))
+	}
+
+	s.logger.Info("Invoice created from payment link",
+		zap.String("link_id", link.ID()),
+		zap.String("invoice_id", inv.ID()))
+
+	return inv, nil
+}
+
+// findActiveLink retrieves a payment link by its slug, failing if it was deactivated.
+func (s *ServiceImpl) findActiveLink(ctx context.Context, slug string) (*PaymentLink, error) {
+	if slug == "" {
+		return nil, fmt.Errorf("%w: payment link slug is required", ErrInvalidRequest)
+	}
+
+	link, err := s.repository.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !link.IsActive() {
+		return nil, ErrLinkInactive
+	}
+
+	return link, nil
+}
+
+func generateID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func generateSlug() (string, error) {
+	alphabetSize := big.NewInt(int64(len(slugAlphabet)))
+	slug := make([]byte, slugLength)
+	for i := range slug {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		slug[i] = slugAlphabet[n.Int64()]
+	}
+	return string(slug), nil
+}
@@ -0,0 +1,17 @@
+package payout
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the payout service layer dependencies.
+var Module = fx.Module("payout-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+		NewEngine,
+	),
+	fx.Invoke(RegisterEngine),
+)
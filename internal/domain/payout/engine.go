@@ -0,0 +1,97 @@
+package payout
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Engine batches settled funds into payouts and follows their transfers in the background.
+type Engine struct {
+	service  Service
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+// NewEngine creates a new background payout engine running at the policy interval.
+func NewEngine(service Service, policy Policy, logger *zap.Logger) *Engine {
+	interval := policy.Interval
+	if interval <= 0 {
+		interval = DefaultPolicy().Interval
+	}
+	return &Engine{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run processes payouts on every interval until the context is cancelled.
+func (e *Engine) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		shared.Beat(ctx)
+		e.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick performs one payout round.
+func (e *Engine) tick(ctx context.Context) {
+	changed, err := e.service.ProcessPayouts(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			e.logger.Error("Failed to process payouts", zap.Error(err))
+		}
+		return
+	}
+	if changed > 0 {
+		e.logger.Info("Processed payouts", zap.Int("count", changed))
+	}
+}
+
+// RegisterEngine runs the payout engine for the lifetime of the application.
+// Nothing is started when no hot wallet is configured.
+func RegisterEngine(
+	lc fx.Lifecycle,
+	engine *Engine,
+	hotWallet HotWallet,
+	workers shared.WorkerSupervisor,
+	logger *zap.Logger,
+) {
+	if hotWallet == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			logger.Info("Starting payout engine", zap.String("hot_wallet", hotWallet.Name()))
+			go func() {
+				defer close(done)
+				workers.Supervise(ctx, "payout_engine", engine.interval, engine.Run)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,59 @@
+// Package payout transfers settled funds to the payout wallets merchants register, batching a
+// merchant's settlements into on-chain transfers sent from the platform hot wallet.
+package payout
+
+// WalletStatus represents whether a payout wallet may receive payouts.
+type WalletStatus string
+
+const (
+	// WalletStatusPendingVerification - Wallet registered; ownership not yet proven by signing the challenge
+	WalletStatusPendingVerification WalletStatus = "pending_verification"
+	// WalletStatusVerified - Challenge signed by the wallet's key; payouts are sent to it
+	WalletStatusVerified WalletStatus = "verified"
+)
+
+// IsValid returns true if the wallet status is valid.
+func (s WalletStatus) IsValid() bool {
+	switch s {
+	case WalletStatusPendingVerification, WalletStatusVerified:
+		return true
+	default:
+		return false
+	}
+}
+
+// Status represents the current status of a payout.
+type Status string
+
+const (
+	// StatusPending - Payout created and its settlements reserved; transfer not yet sent
+	StatusPending Status = "pending"
+	// StatusBroadcast - Transfer signed and broadcast; awaiting confirmation
+	StatusBroadcast Status = "broadcast"
+	// StatusConfirmed - Transfer confirmed on-chain
+	StatusConfirmed Status = "confirmed"
+	// StatusFailed - Transfer rejected or reverted; its settlements await payout again
+	StatusFailed Status = "failed"
+)
+
+// IsValid returns true if the payout status is valid.
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusBroadcast, StatusConfirmed, StatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// TransferState represents the state of a transfer as reported by the hot wallet.
+type TransferState string
+
+const (
+	// TransferStatePending - Transfer broadcast and not yet confirmed
+	TransferStatePending TransferState = "pending"
+	// TransferStateConfirmed - Transfer has the confirmations the hot wallet requires
+	TransferStateConfirmed TransferState = "confirmed"
+	// TransferStateFailed - Transfer rejected, dropped or reverted
+	TransferStateFailed TransferState = "failed"
+)
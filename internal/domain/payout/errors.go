@@ -0,0 +1,29 @@
+package payout
+
+import "errors"
+
+// Domain errors for payout operations
+var (
+	ErrWalletNotFound        = errors.New("payout wallet not found")
+	ErrWalletExists          = errors.New("a payout wallet is already registered for this network")
+	ErrPayoutNotFound        = errors.New("payout not found")
+	ErrInvalidRequest        = errors.New("invalid payout request")
+	ErrInvalidTransition     = errors.New("payout cannot change in its current state")
+	ErrChallengeExpired      = errors.New("payout wallet verification challenge expired")
+	ErrInvalidSignature      = errors.New("signature does not prove ownership of the payout wallet")
+	ErrHotWalletUnavailable  = errors.New("payouts are not configured")
+	ErrHotWalletFailure      = errors.New("hot wallet request failed")
+	ErrUnsupportedCurrency   = errors.New("currency cannot be paid out on-chain")
+	ErrWalletAlreadyVerified = errors.New("payout wallet is already verified")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeWalletNotFound     = "PAYOUT_WALLET_NOT_FOUND"
+	ErrCodeWalletExists       = "PAYOUT_WALLET_EXISTS"
+	ErrCodePayoutNotFound     = "PAYOUT_NOT_FOUND"
+	ErrCodeChallengeExpired   = "CHALLENGE_EXPIRED"
+	ErrCodeInvalidSignature   = "INVALID_SIGNATURE"
+	ErrCodePayoutsUnavailable = "PAYOUTS_UNAVAILABLE"
+	ErrCodeWalletVerified     = "PAYOUT_WALLET_VERIFIED"
+)
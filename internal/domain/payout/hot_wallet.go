@@ -0,0 +1,53 @@
+package payout
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+
+	"github.com/shopspring/decimal"
+)
+
+// HotWallet sends transfers from the platform hot wallet. Keys and chain-specific signing stay
+// with the hot wallet, which also checks the signatures merchants make with their payout wallets.
+type HotWallet interface {
+	// Name returns the hot wallet name for logs.
+	Name() string
+
+	// Transfer signs and broadcasts a transfer. Repeating a request with the same reference returns
+	// the transfer already sent instead of sending another.
+	Transfer(ctx context.Context, req *TransferRequest) (*Transfer, error)
+
+	// GetTransfer returns the current state of a broadcast transfer.
+	GetTransfer(ctx context.Context, network shared.BlockchainNetwork, txHash string) (*Transfer, error)
+
+	// VerifySignature reports whether the signature over the message was made with the key of the address.
+	VerifySignature(ctx context.Context, req *SignatureRequest) (bool, error)
+}
+
+// TransferRequest describes a transfer to a payout wallet.
+type TransferRequest struct {
+	// Reference makes the transfer idempotent; the payout ID is used.
+	Reference string
+	Network   shared.BlockchainNetwork
+	Currency  string
+	ToAddress string
+	Amount    decimal.Decimal
+}
+
+// Transfer is the hot wallet's view of a transfer.
+type Transfer struct {
+	TxHash string
+	State  TransferState
+	// Reason describes why a failed transfer did not go through, if the hot wallet reports it.
+	Reason string
+	// Fee is the network fee paid, in the network's native currency, once the hot wallet reports it.
+	Fee *decimal.Decimal
+}
+
+// SignatureRequest asks whether a message was signed with the key of an address.
+type SignatureRequest struct {
+	Network   shared.BlockchainNetwork
+	Address   string
+	Message   string
+	Signature string
+}
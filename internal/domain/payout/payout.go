@@ -0,0 +1,236 @@
+package payout
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Payout is an on-chain transfer of a merchant's settled funds to its payout wallet. A payout
+// batches the net amounts of the settlements it includes.
+type Payout struct {
+	id            string
+	merchantID    string
+	walletID      string
+	network       shared.BlockchainNetwork
+	address       string
+	currency      string
+	amount        decimal.Decimal
+	settlementIDs []string
+	status        Status
+	txHash        string
+	failureReason string
+	createdAt     time.Time
+	broadcastAt   *time.Time
+	confirmedAt   *time.Time
+}
+
+// NewPayout creates a pending payout of amount to a verified wallet for the given settlements.
+func NewPayout(
+	id string,
+	wallet *Wallet,
+	currency string,
+	amount decimal.Decimal,
+	settlementIDs []string,
+) (*Payout, error) {
+	if wallet == nil || !wallet.IsVerified() {
+		return nil, fmt.Errorf("%w: payouts require a verified wallet", ErrInvalidRequest)
+	}
+	if len(settlementIDs) == 0 {
+		return nil, fmt.Errorf("%w: a payout must include settlements", ErrInvalidRequest)
+	}
+
+	ids := make([]string, len(settlementIDs))
+	copy(ids, settlementIDs)
+	return RestorePayout(
+		id, wallet.MerchantID(), wallet.ID(), wallet.Network(), wallet.Address(), currency, amount, ids,
+		StatusPending, "", "", shared.Now().UTC(), nil, nil,
+	)
+}
+
+// RestorePayout recreates a payout from storage.
+func RestorePayout(
+	id, merchantID, walletID string,
+	network shared.BlockchainNetwork,
+	address, currency string,
+	amount decimal.Decimal,
+	settlementIDs []string,
+	status Status,
+	txHash, failureReason string,
+	createdAt time.Time,
+	broadcastAt, confirmedAt *time.Time,
+) (*Payout, error) {
+	if id == "" {
+		return nil, errors.New("payout ID is required")
+	}
+	if merchantID == "" || address == "" {
+		return nil, errors.New("payout merchant and address are required")
+	}
+	if currency == "" {
+		return nil, errors.New("payout currency is required")
+	}
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: payout amount must be positive", ErrInvalidRequest)
+	}
+	if !status.IsValid() {
+		return nil, errors.New("invalid payout status")
+	}
+
+	return &Payout{
+		id:            id,
+		merchantID:    merchantID,
+		walletID:      walletID,
+		network:       network,
+		address:       address,
+		currency:      currency,
+		amount:        amount,
+		settlementIDs: settlementIDs,
+		status:        status,
+		txHash:        txHash,
+		failureReason: failureReason,
+		createdAt:     createdAt,
+		broadcastAt:   broadcastAt,
+		confirmedAt:   confirmedAt,
+	}, nil
+}
+
+// ID returns the payout ID.
+func (p *Payout) ID() string {
+	return p.id
+}
+
+// MerchantID returns the merchant being paid.
+func (p *Payout) MerchantID() string {
+	return p.merchantID
+}
+
+// WalletID returns the payout wallet the transfer goes to.
+func (p *Payout) WalletID() string {
+	return p.walletID
+}
+
+// Network returns the network of the transfer.
+func (p *Payout) Network() shared.BlockchainNetwork {
+	return p.network
+}
+
+// Address returns the address the transfer goes to, as it was when the payout was created.
+func (p *Payout) Address() string {
+	return p.address
+}
+
+// Currency returns the cryptocurrency transferred.
+func (p *Payout) Currency() string {
+	return p.currency
+}
+
+// Amount returns the amount transferred: the sum of the settlements' net amounts.
+func (p *Payout) Amount() decimal.Decimal {
+	return p.amount
+}
+
+// SettlementIDs returns the settlements the payout includes.
+func (p *Payout) SettlementIDs() []string {
+	ids := make([]string, len(p.settlementIDs))
+	copy(ids, p.settlementIDs)
+	return ids
+}
+
+// Status returns the payout status.
+func (p *Payout) Status() Status {
+	return p.status
+}
+
+// TxHash returns the hash of the transfer, once broadcast.
+func (p *Payout) TxHash() string {
+	return p.txHash
+}
+
+// FailureReason returns why the payout failed.
+func (p *Payout) FailureReason() string {
+	return p.failureReason
+}
+
+// CreatedAt returns when the payout was created.
+func (p *Payout) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+// BroadcastAt returns when the transfer was broadcast.
+func (p *Payout) BroadcastAt() *time.Time {
+	return p.broadcastAt
+}
+
+// ConfirmedAt returns when the transfer was confirmed.
+func (p *Payout) ConfirmedAt() *time.Time {
+	return p.confirmedAt
+}
+
+// MarkBroadcast records the hash of the transfer sent for a pending payout.
+func (p *Payout) MarkBroadcast(txHash string) error {
+	if p.status != StatusPending {
+		return ErrInvalidTransition
+	}
+	if _, err := shared.NewTransactionHash(txHash); err != nil {
+		return fmt.Errorf("%w: %w", ErrHotWalletFailure, err)
+	}
+
+	now := shared.Now().UTC()
+	p.status = StatusBroadcast
+	p.txHash = txHash
+	p.broadcastAt = &now
+	return nil
+}
+
+// Confirm marks a broadcast payout as confirmed on-chain.
+func (p *Payout) Confirm() error {
+	if p.status != StatusBroadcast {
+		return ErrInvalidTransition
+	}
+
+	now := shared.Now().UTC()
+	p.status = StatusConfirmed
+	p.confirmedAt = &now
+	return nil
+}
+
+// Fail marks a payout that has not been confirmed as failed.
+func (p *Payout) Fail(reason string) error {
+	if p.status != StatusPending && p.status != StatusBroadcast {
+		return ErrInvalidTransition
+	}
+
+	p.status = StatusFailed
+	p.failureReason = reason
+	return nil
+}
+
+// ApplyTransfer records the state the hot wallet reports for the payout's transfer. It returns
+// whether the payout finished, either confirmed or failed.
+func (p *Payout) ApplyTransfer(transfer *Transfer) (bool, error) {
+	if transfer == nil {
+		return false, fmt.Errorf("%w: transfer is required", ErrInvalidRequest)
+	}
+
+	if p.status == StatusPending && transfer.TxHash != "" {
+		if err := p.MarkBroadcast(transfer.TxHash); err != nil {
+			return false, err
+		}
+	}
+
+	switch transfer.State {
+	case TransferStateConfirmed:
+		return true, p.Confirm()
+	case TransferStateFailed:
+		reason := transfer.Reason
+		if reason == "" {
+			reason = "transfer failed"
+		}
+		return true, p.Fail(reason)
+	default:
+		return false, nil
+	}
+}
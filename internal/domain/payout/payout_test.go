@@ -0,0 +1,94 @@
+package payout
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTxHash = "7c2d1f0e9b8a7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d"
+
+func newVerifiedWallet(t *testing.T) *Wallet {
+	t.Helper()
+	wallet, err := NewWallet("wallet-1", "merchant-1", shared.NetworkTron, "TXYZabc123456789payout", time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, wallet.Verify(time.Now().UTC()))
+	return wallet
+}
+
+func TestWallet_Verification(t *testing.T) {
+	wallet, err := NewWallet("wallet-1", "merchant-1", shared.NetworkTron, " TXYZabc123456789payout ", time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, "TXYZabc123456789payout", wallet.Address())
+	assert.Equal(t, WalletStatusPendingVerification, wallet.Status())
+	assert.True(t, strings.Contains(wallet.Challenge(), wallet.Address()))
+	assert.True(t, strings.Contains(wallet.Challenge(), "merchant-1"))
+
+	first := wallet.Challenge()
+	require.NoError(t, wallet.RenewChallenge(time.Hour))
+	assert.NotEqual(t, first, wallet.Challenge(), "each challenge carries a fresh nonce")
+
+	require.ErrorIs(t, wallet.Verify(time.Now().Add(2*time.Hour)), ErrChallengeExpired)
+
+	require.NoError(t, wallet.Verify(time.Now().UTC()))
+	assert.True(t, wallet.IsVerified())
+	assert.Empty(t, wallet.Challenge())
+	assert.NotNil(t, wallet.VerifiedAt())
+	require.ErrorIs(t, wallet.Verify(time.Now().UTC()), ErrWalletAlreadyVerified)
+	require.ErrorIs(t, wallet.RenewChallenge(time.Hour), ErrWalletAlreadyVerified)
+
+	_, err = NewWallet("wallet-2", "merchant-1", shared.BlockchainNetwork("solana"), "So1anaAddress123", time.Hour)
+	require.ErrorIs(t, err, ErrInvalidRequest)
+}
+
+func TestNewPayout_RequiresVerifiedWallet(t *testing.T) {
+	unverified, err := NewWallet("wallet-1", "merchant-1", shared.NetworkTron, "TXYZabc123456789payout", time.Hour)
+	require.NoError(t, err)
+
+	_, err = NewPayout("payout-1", unverified, "USDT", decimal.NewFromInt(10), []string{"settlement-1"})
+	require.ErrorIs(t, err, ErrInvalidRequest)
+
+	_, err = NewPayout("payout-1", newVerifiedWallet(t), "USDT", decimal.NewFromInt(10), nil)
+	require.ErrorIs(t, err, ErrInvalidRequest)
+}
+
+func TestPayout_ApplyTransfer(t *testing.T) {
+	t.Run("broadcast then confirmed", func(t *testing.T) {
+		p, err := NewPayout("payout-1", newVerifiedWallet(t), "USDT", decimal.NewFromInt(25), []string{"s-1", "s-2"})
+		require.NoError(t, err)
+		assert.Equal(t, "TXYZabc123456789payout", p.Address())
+
+		finished, err := p.ApplyTransfer(&Transfer{TxHash: testTxHash, State: TransferStatePending})
+		require.NoError(t, err)
+		assert.False(t, finished)
+		assert.Equal(t, StatusBroadcast, p.Status())
+		assert.Equal(t, testTxHash, p.TxHash())
+		assert.NotNil(t, p.BroadcastAt())
+
+		finished, err = p.ApplyTransfer(&Transfer{TxHash: testTxHash, State: TransferStateConfirmed})
+		require.NoError(t, err)
+		assert.True(t, finished)
+		assert.Equal(t, StatusConfirmed, p.Status())
+		assert.NotNil(t, p.ConfirmedAt())
+
+		require.ErrorIs(t, p.Fail("late"), ErrInvalidTransition)
+	})
+
+	t.Run("rejected transfer fails the payout", func(t *testing.T) {
+		p, err := NewPayout("payout-2", newVerifiedWallet(t), "USDT", decimal.NewFromInt(25), []string{"s-3"})
+		require.NoError(t, err)
+
+		finished, err := p.ApplyTransfer(&Transfer{State: TransferStateFailed, Reason: "insufficient energy"})
+		require.NoError(t, err)
+		assert.True(t, finished)
+		assert.Equal(t, StatusFailed, p.Status())
+		assert.Equal(t, "insufficient energy", p.FailureReason())
+		assert.Empty(t, p.TxHash())
+	})
+}
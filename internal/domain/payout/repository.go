@@ -0,0 +1,64 @@
+package payout
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+)
+
+// WalletRepository defines the interface for payout wallet persistence.
+type WalletRepository interface {
+	// Save persists a new payout wallet. It fails with ErrWalletExists if the merchant already has
+	// a wallet on the network.
+	Save(ctx context.Context, wallet *Wallet) error
+
+	// FindByID retrieves a payout wallet by its ID.
+	FindByID(ctx context.Context, id string) (*Wallet, error)
+
+	// FindByMerchantAndNetwork retrieves a merchant's wallet on a network.
+	FindByMerchantAndNetwork(ctx context.Context, merchantID string, network shared.BlockchainNetwork) (*Wallet, error)
+
+	// ListByMerchant retrieves a merchant's payout wallets.
+	ListByMerchant(ctx context.Context, merchantID string) ([]*Wallet, error)
+
+	// Update updates an existing payout wallet.
+	Update(ctx context.Context, wallet *Wallet) error
+
+	// Delete removes a payout wallet.
+	Delete(ctx context.Context, id string) error
+}
+
+// Repository defines the interface for payout persistence.
+type Repository interface {
+	// Save persists a new payout.
+	Save(ctx context.Context, payout *Payout) error
+
+	// FindByID retrieves a payout by its ID.
+	FindByID(ctx context.Context, id string) (*Payout, error)
+
+	// FindByStatus retrieves up to limit payouts with the status, oldest first.
+	FindByStatus(ctx context.Context, status Status, limit int) ([]*Payout, error)
+
+	// List retrieves payouts matching the filter, newest first.
+	List(ctx context.Context, req *ListPayoutsRequest) (*ListPayoutsResponse, error)
+
+	// Update updates an existing payout.
+	Update(ctx context.Context, payout *Payout) error
+}
+
+// ListPayoutsRequest represents the request to list payouts.
+// Empty filter fields match all payouts.
+type ListPayoutsRequest struct {
+	MerchantID string
+	Status     Status
+	Limit      int
+	// Cursor continues a listing after the page that returned it.
+	Cursor *shared.Cursor
+}
+
+// ListPayoutsResponse represents the response from listing payouts.
+type ListPayoutsResponse struct {
+	Payouts []*Payout
+	Limit   int
+	// NextCursor continues the listing after this page, or is nil on the last page.
+	NextCursor *shared.Cursor
+}
@@ -0,0 +1,683 @@
+package payout
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/approval"
+	"crypto-checkout/internal/domain/fee"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultListLimit is the default page size for listing payouts.
+	defaultListLimit = 20
+	// maxListLimit is the maximum page size for listing payouts.
+	maxListLimit = 100
+	// processBatchSize bounds the payouts and settlements handled in one round.
+	processBatchSize = 100
+)
+
+// Policy configures when settled funds are paid out.
+type Policy struct {
+	// MinimumAmount is the smallest amount worth a transfer; smaller balances wait for more settlements.
+	MinimumAmount decimal.Decimal
+	// ChallengeTTL is how long a wallet verification challenge may be signed.
+	ChallengeTTL time.Duration
+	// Interval is how often settlements are batched into payouts and transfers are followed.
+	Interval time.Duration
+}
+
+// DefaultPolicy pays out balances of 10 or more every five minutes, with challenges valid for a day.
+func DefaultPolicy() Policy {
+	return Policy{
+		MinimumAmount: decimal.NewFromInt(10),
+		ChallengeTTL:  24 * time.Hour,
+		Interval:      5 * time.Minute,
+	}
+}
+
+// currencyNetworks maps each settlement currency to the network its payouts are sent on.
+var currencyNetworks = map[string]shared.BlockchainNetwork{
+	string(shared.CryptoCurrencyUSDT): shared.NetworkTron,
+	string(shared.CryptoCurrencyBTC):  shared.NetworkBitcoin,
+	string(shared.CryptoCurrencyETH):  shared.NetworkEthereum,
+}
+
+// NetworkForCurrency returns the network payouts of a currency are sent on.
+func NetworkForCurrency(currency string) (shared.BlockchainNetwork, error) {
+	network, ok := currencyNetworks[currency]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+	return network, nil
+}
+
+// Service defines the interface for payout operations.
+type Service interface {
+	// AddWallet registers a payout wallet for a network, returning it with its verification challenge.
+	AddWallet(ctx context.Context, req *AddWalletRequest) (*Wallet, error)
+
+	// VerifyWallet verifies a payout wallet with the signature of its challenge.
+	VerifyWallet(ctx context.Context, req *VerifyWalletRequest) (*Wallet, error)
+
+	// RenewWalletChallenge issues a new verification challenge for an unverified wallet.
+	RenewWalletChallenge(ctx context.Context, req *WalletRequest) (*Wallet, error)
+
+	// ListWallets lists a merchant's payout wallets.
+	ListWallets(ctx context.Context, merchantID string) ([]*Wallet, error)
+
+	// RemoveWallet removes a payout wallet. Payouts already created still go to its address.
+	RemoveWallet(ctx context.Context, req *WalletRequest) error
+
+	// GetPayout retrieves a merchant's payout.
+	GetPayout(ctx context.Context, req *GetPayoutRequest) (*Payout, error)
+
+	// ListPayouts lists a merchant's payouts.
+	ListPayouts(ctx context.Context, req *ListPayoutsRequest) (*ListPayoutsResponse, error)
+
+	// ProcessPayouts sends the transfers of pending payouts, follows broadcast ones and batches the
+	// settlements awaiting payout into new payouts. It returns the number of payouts that changed status.
+	ProcessPayouts(ctx context.Context) (int, error)
+}
+
+// AddWalletRequest represents the request to register a payout wallet.
+type AddWalletRequest struct {
+	MerchantID string                   `validate:"required"`
+	Network    shared.BlockchainNetwork `validate:"required"`
+	Address    string                   `validate:"required"`
+}
+
+// VerifyWalletRequest represents the request to verify a payout wallet.
+type VerifyWalletRequest struct {
+	MerchantID string `validate:"required"`
+	WalletID   string `validate:"required"`
+	Signature  string `validate:"required"`
+}
+
+// WalletRequest identifies a merchant's payout wallet.
+type WalletRequest struct {
+	MerchantID string `validate:"required"`
+	WalletID   string `validate:"required"`
+}
+
+// GetPayoutRequest represents the request to get a payout.
+type GetPayoutRequest struct {
+	MerchantID string `validate:"required"`
+	PayoutID   string `validate:"required"`
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository  Repository
+	wallets     WalletRepository
+	settlements settlement.Service
+	hotWallet   HotWallet
+	fees        fee.Service
+	approvals   approval.Service
+	policy      Policy
+	eventBus    shared.EventBus
+	logger      *zap.Logger
+}
+
+// NewService creates a new payout service. The hot wallet may be nil, in which case wallets can be
+// registered but neither verified nor paid. The fee service may be nil, in which case transfers
+// are sent at any fee and their fees are not recorded. The approval service may be nil, in which
+// case no payout waits for approval.
+func NewService(
+	repository Repository,
+	wallets WalletRepository,
+	settlements settlement.Service,
+	hotWallet HotWallet,
+	fees fee.Service,
+	approvals approval.Service,
+	policy Policy,
+	eventBus shared.EventBus,
+	logger *zap.Logger,
+) Service {
+	defaults := DefaultPolicy()
+	if policy.ChallengeTTL <= 0 {
+		policy.ChallengeTTL = defaults.ChallengeTTL
+	}
+	if policy.Interval <= 0 {
+		policy.Interval = defaults.Interval
+	}
+
+	return &ServiceImpl{
+		repository:  repository,
+		wallets:     wallets,
+		settlements: settlements,
+		hotWallet:   hotWallet,
+		fees:        fees,
+		approvals:   approvals,
+		policy:      policy,
+		eventBus:    eventBus,
+		logger:      logger,
+	}
+}
+
+// AddWallet registers a payout wallet. A merchant has at most one wallet per network.
+func (s *ServiceImpl) AddWallet(ctx context.Context, req *AddWalletRequest) (*Wallet, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: add wallet request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	if _, err := s.wallets.FindByMerchantAndNetwork(ctx, req.MerchantID, req.Network); err == nil {
+		return nil, ErrWalletExists
+	} else if !errors.Is(err, ErrWalletNotFound) {
+		return nil, err
+	}
+
+	wallet, err := NewWallet(uuid.NewString(), req.MerchantID, req.Network, req.Address, s.policy.ChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.wallets.Save(ctx, wallet); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Payout wallet registered",
+		zap.String("wallet_id", wallet.ID()),
+		zap.String("merchant_id", wallet.MerchantID()),
+		zap.String("network", wallet.Network().String()))
+
+	return wallet, nil
+}
+
+// VerifyWallet checks the challenge signature with the hot wallet and verifies the wallet.
+func (s *ServiceImpl) VerifyWallet(ctx context.Context, req *VerifyWalletRequest) (*Wallet, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: verify wallet request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	if s.hotWallet == nil {
+		return nil, ErrHotWalletUnavailable
+	}
+
+	wallet, err := s.findWallet(ctx, req.MerchantID, req.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.IsVerified() {
+		return nil, ErrWalletAlreadyVerified
+	}
+	if !shared.Now().UTC().Before(wallet.ChallengeExpiresAt()) {
+		return nil, ErrChallengeExpired
+	}
+
+	valid, err := s.hotWallet.VerifySignature(ctx, &SignatureRequest{
+		Network:   wallet.Network(),
+		Address:   wallet.Address(),
+		Message:   wallet.Challenge(),
+		Signature: req.Signature,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, ErrInvalidSignature
+	}
+
+	if err := wallet.Verify(shared.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := s.wallets.Update(ctx, wallet); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Payout wallet verified",
+		zap.String("wallet_id", wallet.ID()),
+		zap.String("merchant_id", wallet.MerchantID()))
+
+	return wallet, nil
+}
+
+// RenewWalletChallenge issues a new verification challenge.
+func (s *ServiceImpl) RenewWalletChallenge(ctx context.Context, req *WalletRequest) (*Wallet, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: wallet request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	wallet, err := s.findWallet(ctx, req.MerchantID, req.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	if err := wallet.RenewChallenge(s.policy.ChallengeTTL); err != nil {
+		return nil, err
+	}
+	if err := s.wallets.Update(ctx, wallet); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// ListWallets lists a merchant's payout wallets.
+func (s *ServiceImpl) ListWallets(ctx context.Context, merchantID string) ([]*Wallet, error) {
+	if merchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+	return s.wallets.ListByMerchant(ctx, merchantID)
+}
+
+// RemoveWallet removes a merchant's payout wallet.
+func (s *ServiceImpl) RemoveWallet(ctx context.Context, req *WalletRequest) error {
+	if req == nil {
+		return fmt.Errorf("%w: wallet request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	wallet, err := s.findWallet(ctx, req.MerchantID, req.WalletID)
+	if err != nil {
+		return err
+	}
+	if err := s.wallets.Delete(ctx, wallet.ID()); err != nil {
+		return err
+	}
+
+	s.logger.Info("Payout wallet removed",
+		zap.String("wallet_id", wallet.ID()),
+		zap.String("merchant_id", wallet.MerchantID()))
+	return nil
+}
+
+// GetPayout retrieves a merchant's payout.
+func (s *ServiceImpl) GetPayout(ctx context.Context, req *GetPayoutRequest) (*Payout, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: get payout request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	payout, err := s.repository.FindByID(ctx, req.PayoutID)
+	if err != nil {
+		return nil, err
+	}
+	if payout.MerchantID() != req.MerchantID {
+		return nil, ErrPayoutNotFound
+	}
+	return payout, nil
+}
+
+// ListPayouts lists a merchant's payouts.
+func (s *ServiceImpl) ListPayouts(ctx context.Context, req *ListPayoutsRequest) (*ListPayoutsResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: list payouts request cannot be nil", ErrInvalidRequest)
+	}
+	if req.MerchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+	if req.Status != "" && !req.Status.IsValid() {
+		return nil, fmt.Errorf("%w: invalid status %s", ErrInvalidRequest, req.Status)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	return s.repository.List(ctx, &ListPayoutsRequest{
+		MerchantID: req.MerchantID,
+		Status:     req.Status,
+		Limit:      limit,
+		Cursor:     req.Cursor,
+	})
+}
+
+// ProcessPayouts runs one payout round. Pending payouts left by an earlier round are sent first,
+// so a transfer interrupted before its hash was recorded is resent with the same reference.
+func (s *ServiceImpl) ProcessPayouts(ctx context.Context) (int, error) {
+	if s.hotWallet == nil {
+		return 0, nil
+	}
+
+	changed := 0
+	for _, step := range []func(context.Context) (int, error){s.sendPending, s.followBroadcast, s.batchSettlements} {
+		n, err := step(ctx)
+		changed += n
+		if err != nil {
+			return changed, err
+		}
+	}
+	return changed, nil
+}
+
+// sendPending sends the transfers of pending payouts.
+func (s *ServiceImpl) sendPending(ctx context.Context) (int, error) {
+	pending, err := s.repository.FindByStatus(ctx, StatusPending, processBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, payout := range pending {
+		if ctx.Err() != nil {
+			return changed, ctx.Err()
+		}
+		if s.send(ctx, payout) {
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// followBroadcast checks broadcast transfers with the hot wallet.
+func (s *ServiceImpl) followBroadcast(ctx context.Context) (int, error) {
+	broadcast, err := s.repository.FindByStatus(ctx, StatusBroadcast, processBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, payout := range broadcast {
+		if ctx.Err() != nil {
+			return changed, ctx.Err()
+		}
+
+		transfer, err := s.hotWallet.GetTransfer(ctx, payout.Network(), payout.TxHash())
+		if err != nil {
+			s.logger.Error("Failed to check payout transfer",
+				zap.String("payout_id", payout.ID()),
+				zap.String("tx_hash", payout.TxHash()),
+				zap.Error(err))
+			continue
+		}
+		if s.applyTransfer(ctx, payout, transfer) {
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// batchSettlements groups the settlements awaiting payout by merchant and currency and creates a
+// payout for each group that has a verified wallet and reaches the minimum amount.
+func (s *ServiceImpl) batchSettlements(ctx context.Context) (int, error) {
+	awaiting, err := s.settlements.FindAwaitingPayout(ctx, processBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	type batchKey struct{ merchantID, currency string }
+	var order []batchKey
+	batches := make(map[batchKey][]*settlement.Settlement)
+	for _, st := range awaiting {
+		key := batchKey{st.MerchantID(), st.Currency()}
+		if _, ok := batches[key]; !ok {
+			order = append(order, key)
+		}
+		batches[key] = append(batches[key], st)
+	}
+
+	changed := 0
+	for _, key := range order {
+		if ctx.Err() != nil {
+			return changed, ctx.Err()
+		}
+
+		payout, err := s.createPayout(ctx, key.merchantID, key.currency, batches[key])
+		if err != nil {
+			s.logger.Error("Failed to create payout",
+				zap.String("merchant_id", key.merchantID),
+				zap.String("currency", key.currency),
+				zap.Error(err))
+			continue
+		}
+		if payout != nil && s.send(ctx, payout) {
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// createPayout creates the payout of a merchant's settlements in one currency and reserves them.
+// It returns nil when the merchant has no verified wallet or the amount is below the minimum.
+func (s *ServiceImpl) createPayout(
+	ctx context.Context,
+	merchantID, currency string,
+	settlements []*settlement.Settlement,
+) (*Payout, error) {
+	network, err := NetworkForCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := s.wallets.FindByMerchantAndNetwork(ctx, merchantID, network)
+	if errors.Is(err, ErrWalletNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !wallet.IsVerified() {
+		return nil, nil
+	}
+
+	amount := decimal.Zero
+	ids := make([]string, len(settlements))
+	for i, st := range settlements {
+		amount = amount.Add(st.NetAmount())
+		ids[i] = st.ID()
+	}
+	if amount.LessThan(s.policy.MinimumAmount) {
+		return nil, nil
+	}
+
+	payout, err := NewPayout(uuid.NewString(), wallet, currency, amount, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reserve the settlements before the payout exists, so a pending payout always holds its
+	// settlements and a crash in between leaves them reserved rather than paid twice
+	if err := s.settlements.AssignPayout(ctx, payout.ID(), ids); err != nil {
+		return nil, err
+	}
+	if err := s.repository.Save(ctx, payout); err != nil {
+		if releaseErr := s.settlements.ReleasePayout(ctx, payout.ID()); releaseErr != nil {
+			s.logger.Error("Failed to release settlements of unsaved payout",
+				zap.String("payout_id", payout.ID()),
+				zap.Error(releaseErr))
+		}
+		return nil, err
+	}
+
+	s.logger.Info("Payout created",
+		zap.String("payout_id", payout.ID()),
+		zap.String("merchant_id", merchantID),
+		zap.String("amount", amount.String()),
+		zap.String("currency", currency),
+		zap.Int("settlements", len(ids)))
+
+	return payout, nil
+}
+
+// send asks the hot wallet to transfer a pending payout, reporting whether its status changed. A
+// transfer that cannot be requested, awaits approval or is deferred until fees drop stays pending
+// and is retried next round. A payout its approvers rejected fails without being sent.
+func (s *ServiceImpl) send(ctx context.Context, payout *Payout) bool {
+	if s.approvals != nil {
+		pending, err := s.approvals.Require(ctx, &approval.TransferRequest{
+			MerchantID: payout.MerchantID(),
+			Kind:       approval.KindPayout,
+			Reference:  payout.ID(),
+			Currency:   payout.Currency(),
+			Amount:     payout.Amount(),
+		})
+		if err != nil {
+			s.logger.Error("Failed to check payout approval; it will be retried",
+				zap.String("payout_id", payout.ID()),
+				zap.Error(err))
+			return false
+		}
+		if pending != nil {
+			switch pending.Status() {
+			case approval.StatusPending:
+				s.logger.Debug("Payout awaits approval",
+					zap.String("payout_id", payout.ID()),
+					zap.String("approval_id", pending.ID()))
+				return false
+			case approval.StatusRejected:
+				return s.applyTransfer(ctx, payout, &Transfer{
+					State:  TransferStateFailed,
+					Reason: "rejected by approvers",
+				})
+			case approval.StatusApproved:
+			}
+		}
+	}
+
+	if s.fees != nil {
+		decision := s.fees.Decide(ctx, &fee.BroadcastRequest{
+			Network:      payout.Network(),
+			Currency:     payout.Currency(),
+			WaitingSince: payout.CreatedAt(),
+		})
+		if !decision.Broadcast {
+			s.logger.Info("Payout transfer deferred until network fees drop",
+				zap.String("payout_id", payout.ID()),
+				zap.String("reason", decision.Reason))
+			return false
+		}
+	}
+
+	transfer, err := s.hotWallet.Transfer(ctx, &TransferRequest{
+		Reference: payout.ID(),
+		Network:   payout.Network(),
+		Currency:  payout.Currency(),
+		ToAddress: payout.Address(),
+		Amount:    payout.Amount(),
+	})
+	if err != nil {
+		s.logger.Error("Failed to send payout transfer; it will be retried",
+			zap.String("payout_id", payout.ID()),
+			zap.Error(err))
+		return false
+	}
+	return s.applyTransfer(ctx, payout, transfer)
+}
+
+// applyTransfer records a transfer state on its payout, stores it and announces the change. The
+// settlements of a failed payout are released so a later payout includes them.
+func (s *ServiceImpl) applyTransfer(ctx context.Context, payout *Payout, transfer *Transfer) bool {
+	previous := payout.Status()
+	if _, err := payout.ApplyTransfer(transfer); err != nil {
+		s.logger.Error("Failed to apply payout transfer",
+			zap.String("payout_id", payout.ID()),
+			zap.Error(err))
+		return false
+	}
+	if payout.Status() == previous {
+		return false
+	}
+
+	if err := s.repository.Update(ctx, payout); err != nil {
+		s.logger.Error("Failed to update payout",
+			zap.String("payout_id", payout.ID()),
+			zap.Error(err))
+		return false
+	}
+
+	switch payout.Status() {
+	case StatusBroadcast:
+		s.publishEvent(ctx, shared.NewPayoutBroadcastEvent(createPayoutEventData(payout)))
+	case StatusConfirmed:
+		if previous == StatusPending {
+			s.publishEvent(ctx, shared.NewPayoutBroadcastEvent(createPayoutEventData(payout)))
+		}
+		s.publishEvent(ctx, shared.NewPayoutConfirmedEvent(createPayoutEventData(payout)))
+		s.recordFee(ctx, payout, transfer)
+	case StatusFailed:
+		s.logger.Warn("Payout failed; its settlements await payout again",
+			zap.String("payout_id", payout.ID()),
+			zap.String("reason", payout.FailureReason()))
+		if err := s.settlements.ReleasePayout(ctx, payout.ID()); err != nil {
+			s.logger.Error("Failed to release settlements of failed payout",
+				zap.String("payout_id", payout.ID()),
+				zap.Error(err))
+		}
+		s.publishEvent(ctx, shared.NewPayoutFailedEvent(createPayoutEventData(payout)))
+	case StatusPending:
+	}
+	return true
+}
+
+// recordFee records the network fee a confirmed payout transfer paid, when the hot wallet reported it.
+func (s *ServiceImpl) recordFee(ctx context.Context, payout *Payout, transfer *Transfer) {
+	if s.fees == nil || transfer.Fee == nil {
+		return
+	}
+
+	if err := s.fees.RecordSpend(ctx, &fee.SpendRequest{
+		Purpose:   fee.PurposePayout,
+		Reference: payout.ID(),
+		Network:   payout.Network(),
+		TxHash:    payout.TxHash(),
+		Amount:    *transfer.Fee,
+	}); err != nil {
+		s.logger.Error("Failed to record payout fee",
+			zap.String("payout_id", payout.ID()),
+			zap.Error(err))
+	}
+}
+
+// findWallet retrieves a payout wallet, hiding other merchants' wallets.
+func (s *ServiceImpl) findWallet(ctx context.Context, merchantID, walletID string) (*Wallet, error) {
+	wallet, err := s.wallets.FindByID(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.MerchantID() != merchantID {
+		return nil, ErrWalletNotFound
+	}
+	return wallet, nil
+}
+
+// publishEvent publishes a payout event, logging rather than failing when the bus is unavailable.
+func (s *ServiceImpl) publishEvent(ctx context.Context, event *shared.BaseDomainEvent) {
+	if s.eventBus == nil {
+		return
+	}
+
+	if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+		s.logger.Error("Failed to publish domain event",
+			zap.String("event_type", event.EventType),
+			zap.String("aggregate_id", event.AggregateID),
+			zap.Error(err))
+	}
+}
+
+// createPayoutEventData returns the payload of payout events.
+func createPayoutEventData(payout *Payout) shared.PayoutEvent {
+	return shared.PayoutEvent{
+		PayoutID:      payout.ID(),
+		MerchantID:    payout.MerchantID(),
+		Network:       payout.Network().String(),
+		Address:       payout.Address(),
+		Amount:        payout.Amount().String(),
+		Currency:      payout.Currency(),
+		SettlementIDs: payout.SettlementIDs(),
+		Status:        string(payout.Status()),
+		TxHash:        payout.TxHash(),
+		FailureReason: payout.FailureReason(),
+	}
+}
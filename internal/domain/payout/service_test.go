@@ -0,0 +1,313 @@
+package payout
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/approval"
+	"crypto-checkout/internal/domain/fee"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/domain/shared"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeSettlements serves settlements awaiting payout and records their payout assignments.
+type fakeSettlements struct {
+	settlement.Service
+	settlements []*settlement.Settlement
+	payouts     map[string]string
+}
+
+func (f *fakeSettlements) FindAwaitingPayout(_ context.Context, _ int) ([]*settlement.Settlement, error) {
+	var awaiting []*settlement.Settlement
+	for _, s := range f.settlements {
+		if f.payouts[s.ID()] == "" {
+			awaiting = append(awaiting, s)
+		}
+	}
+	return awaiting, nil
+}
+
+func (f *fakeSettlements) AssignPayout(_ context.Context, payoutID string, ids []string) error {
+	for _, id := range ids {
+		if f.payouts[id] != "" {
+			return settlement.ErrInvalidTransition
+		}
+	}
+	for _, id := range ids {
+		f.payouts[id] = payoutID
+	}
+	return nil
+}
+
+func (f *fakeSettlements) ReleasePayout(_ context.Context, payoutID string) error {
+	for id, assigned := range f.payouts {
+		if assigned == payoutID {
+			delete(f.payouts, id)
+		}
+	}
+	return nil
+}
+
+type fakeWallets struct {
+	WalletRepository
+	wallets []*Wallet
+}
+
+func (f *fakeWallets) FindByMerchantAndNetwork(
+	_ context.Context,
+	merchantID string,
+	network shared.BlockchainNetwork,
+) (*Wallet, error) {
+	for _, w := range f.wallets {
+		if w.MerchantID() == merchantID && w.Network() == network {
+			return w, nil
+		}
+	}
+	return nil, ErrWalletNotFound
+}
+
+type fakePayouts struct {
+	Repository
+	payouts map[string]*Payout
+}
+
+func (f *fakePayouts) Save(_ context.Context, p *Payout) error {
+	f.payouts[p.ID()] = p
+	return nil
+}
+
+func (f *fakePayouts) Update(_ context.Context, p *Payout) error {
+	f.payouts[p.ID()] = p
+	return nil
+}
+
+func (f *fakePayouts) FindByStatus(_ context.Context, status Status, _ int) ([]*Payout, error) {
+	var found []*Payout
+	for _, p := range f.payouts {
+		if p.Status() == status {
+			found = append(found, p)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].CreatedAt().Before(found[j].CreatedAt()) })
+	return found, nil
+}
+
+type fakeHotWallet struct {
+	transfers []*TransferRequest
+	state     TransferState
+	fee       *decimal.Decimal
+}
+
+func (f *fakeHotWallet) Name() string { return "fake" }
+
+func (f *fakeHotWallet) Transfer(_ context.Context, req *TransferRequest) (*Transfer, error) {
+	f.transfers = append(f.transfers, req)
+	return &Transfer{TxHash: testTxHash, State: TransferStatePending}, nil
+}
+
+func (f *fakeHotWallet) GetTransfer(_ context.Context, _ shared.BlockchainNetwork, txHash string) (*Transfer, error) {
+	return &Transfer{TxHash: txHash, State: f.state, Reason: "reverted", Fee: f.fee}, nil
+}
+
+func (f *fakeHotWallet) VerifySignature(_ context.Context, _ *SignatureRequest) (bool, error) {
+	return true, nil
+}
+
+// fakeFees defers broadcasts while expensive is set and records the fees spent.
+type fakeFees struct {
+	fee.Service
+	expensive bool
+	spends    []*fee.SpendRequest
+}
+
+func (f *fakeFees) Decide(_ context.Context, _ *fee.BroadcastRequest) *fee.Decision {
+	return &fee.Decision{Broadcast: !f.expensive, Reason: "network fee too high"}
+}
+
+func (f *fakeFees) RecordSpend(_ context.Context, req *fee.SpendRequest) error {
+	f.spends = append(f.spends, req)
+	return nil
+}
+
+func completedSettlement(t *testing.T, id, merchantID, gross string) *settlement.Settlement {
+	t.Helper()
+	s, err := settlement.NewSettlement(
+		id, "invoice-"+id, merchantID, decimal.RequireFromString(gross), "USDT", decimal.Zero,
+	)
+	require.NoError(t, err)
+	require.NoError(t, s.Complete())
+	return s
+}
+
+func TestService_ProcessPayouts(t *testing.T) {
+	ctx := context.Background()
+	settlements := &fakeSettlements{
+		settlements: []*settlement.Settlement{
+			completedSettlement(t, "s-1", "merchant-1", "8"),
+			completedSettlement(t, "s-2", "merchant-1", "5"),
+			completedSettlement(t, "s-3", "merchant-2", "50"),
+			completedSettlement(t, "s-4", "merchant-3", "3"),
+		},
+		payouts: map[string]string{},
+	}
+
+	verified := newVerifiedWallet(t)
+	small, err := NewWallet("wallet-3", "merchant-3", shared.NetworkTron, "TSmallMerchantWallet1", time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, small.Verify(time.Now().UTC()))
+
+	repository := &fakePayouts{payouts: map[string]*Payout{}}
+	hotWallet := &fakeHotWallet{state: TransferStatePending}
+	service := NewService(
+		repository, &fakeWallets{wallets: []*Wallet{verified, small}}, settlements, hotWallet, nil, nil,
+		DefaultPolicy(), nil, zap.NewNop(),
+	)
+
+	changed, err := service.ProcessPayouts(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+
+	// merchant-2 has no wallet and merchant-3's balance is below the minimum
+	require.Len(t, hotWallet.transfers, 1)
+	transfer := hotWallet.transfers[0]
+	assert.Equal(t, "13", transfer.Amount.String())
+	assert.Equal(t, verified.Address(), transfer.ToAddress)
+	assert.Equal(t, shared.NetworkTron, transfer.Network)
+
+	p := repository.payouts[transfer.Reference]
+	require.NotNil(t, p)
+	assert.Equal(t, StatusBroadcast, p.Status())
+	assert.ElementsMatch(t, []string{"s-1", "s-2"}, p.SettlementIDs())
+	assert.Equal(t, p.ID(), settlements.payouts["s-1"])
+
+	t.Run("reverted transfer releases its settlements", func(t *testing.T) {
+		hotWallet.state = TransferStateFailed
+
+		changed, err := service.ProcessPayouts(ctx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, changed, 1)
+		assert.Equal(t, StatusFailed, p.Status())
+		assert.Equal(t, "reverted", p.FailureReason())
+
+		// The released settlements were batched again into a new payout in the same round
+		assert.NotEqual(t, p.ID(), settlements.payouts["s-1"])
+		assert.Len(t, hotWallet.transfers, 2)
+	})
+}
+
+func TestService_ProcessPayoutsWaitsForLowerFees(t *testing.T) {
+	ctx := context.Background()
+	settlements := &fakeSettlements{
+		settlements: []*settlement.Settlement{completedSettlement(t, "s-1", "merchant-1", "20")},
+		payouts:     map[string]string{},
+	}
+	repository := &fakePayouts{payouts: map[string]*Payout{}}
+	networkFee := decimal.RequireFromString("13.4")
+	hotWallet := &fakeHotWallet{state: TransferStateConfirmed, fee: &networkFee}
+	fees := &fakeFees{expensive: true}
+	service := NewService(
+		repository, &fakeWallets{wallets: []*Wallet{newVerifiedWallet(t)}}, settlements, hotWallet, fees, nil,
+		DefaultPolicy(), nil, zap.NewNop(),
+	)
+
+	changed, err := service.ProcessPayouts(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, changed)
+	assert.Empty(t, hotWallet.transfers)
+	require.Len(t, repository.payouts, 1)
+
+	fees.expensive = false
+	_, err = service.ProcessPayouts(ctx)
+	require.NoError(t, err)
+	require.Len(t, hotWallet.transfers, 1)
+
+	_, err = service.ProcessPayouts(ctx)
+	require.NoError(t, err)
+	p := repository.payouts[hotWallet.transfers[0].Reference]
+	assert.Equal(t, StatusConfirmed, p.Status())
+	require.Len(t, fees.spends, 1)
+	assert.Equal(t, fee.PurposePayout, fees.spends[0].Purpose)
+	assert.Equal(t, p.ID(), fees.spends[0].Reference)
+	assert.Equal(t, "13.4", fees.spends[0].Amount.String())
+}
+
+// fakeApprovals holds every payout of 15 or more for approval.
+type fakeApprovals struct {
+	approval.Service
+	approvals map[string]*approval.Approval
+}
+
+func (f *fakeApprovals) Require(_ context.Context, req *approval.TransferRequest) (*approval.Approval, error) {
+	if req.Amount.LessThan(decimal.NewFromInt(15)) {
+		return nil, nil
+	}
+	if existing, ok := f.approvals[req.Reference]; ok {
+		return existing, nil
+	}
+	a, err := approval.NewApproval(
+		"approval-"+req.Reference, req.MerchantID, req.Kind, req.Reference, req.Currency, req.Amount, "", "", 1,
+	)
+	if err != nil {
+		return nil, err
+	}
+	f.approvals[req.Reference] = a
+	return a, nil
+}
+
+func TestService_ProcessPayoutsHoldsPayoutsForApproval(t *testing.T) {
+	ctx := context.Background()
+	settlements := &fakeSettlements{
+		settlements: []*settlement.Settlement{completedSettlement(t, "s-1", "merchant-1", "20")},
+		payouts:     map[string]string{},
+	}
+	repository := &fakePayouts{payouts: map[string]*Payout{}}
+	hotWallet := &fakeHotWallet{state: TransferStatePending}
+	approvals := &fakeApprovals{approvals: map[string]*approval.Approval{}}
+	service := NewService(
+		repository, &fakeWallets{wallets: []*Wallet{newVerifiedWallet(t)}}, settlements, hotWallet, nil, approvals,
+		DefaultPolicy(), nil, zap.NewNop(),
+	)
+
+	_, err := service.ProcessPayouts(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, hotWallet.transfers)
+	require.Len(t, approvals.approvals, 1)
+
+	var first *Payout
+	for _, p := range repository.payouts {
+		first = p
+	}
+	require.NoError(t, approvals.approvals[first.ID()].Vote("approver-1", approval.DecisionApprove, ""))
+	_, err = service.ProcessPayouts(ctx)
+	require.NoError(t, err)
+	require.Len(t, hotWallet.transfers, 1)
+	assert.Equal(t, StatusBroadcast, first.Status())
+
+	t.Run("rejected payout fails and releases its settlements", func(t *testing.T) {
+		hotWallet.state = TransferStateFailed
+		_, err := service.ProcessPayouts(ctx)
+		require.NoError(t, err)
+
+		// The released settlements were batched into a new payout, which awaits its own approval
+		var second *Payout
+		for _, p := range repository.payouts {
+			if p.ID() != first.ID() {
+				second = p
+			}
+		}
+		require.NotNil(t, second)
+		require.NoError(t, approvals.approvals[second.ID()].Vote("approver-1", approval.DecisionReject, "wrong wallet"))
+
+		_, err = service.ProcessPayouts(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, StatusFailed, second.Status())
+		assert.Equal(t, "rejected by approvers", second.FailureReason())
+		assert.Len(t, hotWallet.transfers, 1)
+	})
+}
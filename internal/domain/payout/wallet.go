@@ -0,0 +1,181 @@
+package payout
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// challengeNonceBytes is the size of the random nonce in a verification challenge.
+const challengeNonceBytes = 16
+
+// Wallet is an address a merchant receives payouts at on one network. Payouts are only sent once
+// the merchant proves control of the address by signing the wallet's verification challenge.
+type Wallet struct {
+	id                 string
+	merchantID         string
+	network            shared.BlockchainNetwork
+	address            string
+	status             WalletStatus
+	challenge          string
+	challengeExpiresAt time.Time
+	verifiedAt         *time.Time
+	createdAt          time.Time
+}
+
+// NewWallet registers a payout wallet awaiting verification, with a challenge valid for ttl.
+func NewWallet(
+	id, merchantID string,
+	network shared.BlockchainNetwork,
+	address string,
+	ttl time.Duration,
+) (*Wallet, error) {
+	address = strings.TrimSpace(address)
+	if _, err := shared.NewPaymentAddress(address, network); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	wallet, err := RestoreWallet(
+		id, merchantID, network, address, WalletStatusPendingVerification, "", time.Time{}, nil, shared.Now().UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := wallet.RenewChallenge(ttl); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// RestoreWallet recreates a payout wallet from storage.
+func RestoreWallet(
+	id, merchantID string,
+	network shared.BlockchainNetwork,
+	address string,
+	status WalletStatus,
+	challenge string,
+	challengeExpiresAt time.Time,
+	verifiedAt *time.Time,
+	createdAt time.Time,
+) (*Wallet, error) {
+	if id == "" {
+		return nil, errors.New("payout wallet ID is required")
+	}
+	if merchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+	if !network.IsValid() {
+		return nil, fmt.Errorf("%w: unsupported network %q", ErrInvalidRequest, network)
+	}
+	if address == "" {
+		return nil, fmt.Errorf("%w: address is required", ErrInvalidRequest)
+	}
+	if !status.IsValid() {
+		return nil, errors.New("invalid payout wallet status")
+	}
+
+	return &Wallet{
+		id:                 id,
+		merchantID:         merchantID,
+		network:            network,
+		address:            address,
+		status:             status,
+		challenge:          challenge,
+		challengeExpiresAt: challengeExpiresAt,
+		verifiedAt:         verifiedAt,
+		createdAt:          createdAt,
+	}, nil
+}
+
+// ID returns the payout wallet ID.
+func (w *Wallet) ID() string {
+	return w.id
+}
+
+// MerchantID returns the merchant the wallet belongs to.
+func (w *Wallet) MerchantID() string {
+	return w.merchantID
+}
+
+// Network returns the network the address is on.
+func (w *Wallet) Network() shared.BlockchainNetwork {
+	return w.network
+}
+
+// Address returns the address payouts are sent to.
+func (w *Wallet) Address() string {
+	return w.address
+}
+
+// Status returns the wallet status.
+func (w *Wallet) Status() WalletStatus {
+	return w.status
+}
+
+// IsVerified returns true if payouts may be sent to the wallet.
+func (w *Wallet) IsVerified() bool {
+	return w.status == WalletStatusVerified
+}
+
+// Challenge returns the message the merchant signs with the wallet's key to verify it.
+// It is empty once the wallet is verified.
+func (w *Wallet) Challenge() string {
+	return w.challenge
+}
+
+// ChallengeExpiresAt returns when the challenge stops being accepted.
+func (w *Wallet) ChallengeExpiresAt() time.Time {
+	return w.challengeExpiresAt
+}
+
+// VerifiedAt returns when the wallet was verified.
+func (w *Wallet) VerifiedAt() *time.Time {
+	return w.verifiedAt
+}
+
+// CreatedAt returns when the wallet was registered.
+func (w *Wallet) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+// RenewChallenge replaces the verification challenge with a new one valid for ttl.
+func (w *Wallet) RenewChallenge(ttl time.Duration) error {
+	if w.IsVerified() {
+		return ErrWalletAlreadyVerified
+	}
+	if ttl <= 0 {
+		return fmt.Errorf("%w: challenge lifetime must be positive", ErrInvalidRequest)
+	}
+
+	nonce := make([]byte, challengeNonceBytes)
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate challenge nonce: %w", err)
+	}
+
+	w.challengeExpiresAt = shared.Now().UTC().Add(ttl)
+	w.challenge = fmt.Sprintf(
+		"Verify payout wallet %s on %s for merchant %s. Nonce: %s. Expires: %s",
+		w.address, w.network, w.merchantID, hex.EncodeToString(nonce), w.challengeExpiresAt.Format(time.RFC3339),
+	)
+	return nil
+}
+
+// Verify marks the wallet verified once the challenge signature has been checked.
+// The challenge must not have expired.
+func (w *Wallet) Verify(now time.Time) error {
+	if w.IsVerified() {
+		return ErrWalletAlreadyVerified
+	}
+	if !now.Before(w.challengeExpiresAt) {
+		return ErrChallengeExpired
+	}
+
+	w.status = WalletStatusVerified
+	w.challenge = ""
+	w.verifiedAt = &now
+	return nil
+}
@@ -0,0 +1,24 @@
+package platform
+
+import (
+	"crypto-checkout/internal/domain/detection"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides the platform service layer dependencies.
+var Module = fx.Module("platform-service",
+	fx.Provide(NewServiceProvider),
+)
+
+// NewServiceProvider creates the platform service, reporting the lag of the chain watcher and the freshness of
+// the exchange rates in the status.
+func NewServiceProvider(
+	repository Repository,
+	watcher *detection.Watcher,
+	rates RateMonitor,
+	logger *zap.Logger,
+) Service {
+	return NewService(repository, watcher, rates, logger)
+}
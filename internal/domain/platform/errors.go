@@ -0,0 +1,17 @@
+package platform
+
+import "errors"
+
+// Domain errors for incident operations
+var (
+	ErrIncidentNotFound = errors.New("incident not found")
+	ErrIncidentResolved = errors.New("incident is already resolved")
+	ErrInvalidIncident  = errors.New("invalid incident")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeIncidentNotFound = "INCIDENT_NOT_FOUND"
+	ErrCodeIncidentResolved = "INCIDENT_RESOLVED"
+	ErrCodeInvalidIncident  = "INVALID_INCIDENT"
+)
@@ -0,0 +1,156 @@
+package platform
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Limits of an incident's text, which is shown to customers on checkout pages as written.
+const (
+	MaxIncidentTitleLength   = 120
+	MaxIncidentMessageLength = 1000
+)
+
+// Severity tells how much of payment processing an incident affects.
+type Severity string
+
+const (
+	// SeverityMinor - Payments are detected late
+	SeverityMinor Severity = "minor"
+	// SeverityMajor - Payments are not detected until the incident is resolved
+	SeverityMajor Severity = "major"
+)
+
+// IsValid returns true if the severity is valid.
+func (s Severity) IsValid() bool {
+	return s == SeverityMinor || s == SeverityMajor
+}
+
+// Incident is an operator's notice that the platform is degraded, shown on the status endpoint and, while it is
+// active, as a banner on the checkout pages of the invoices paid on the networks it affects.
+type Incident struct {
+	id         string
+	title      string
+	message    string
+	severity   Severity
+	networks   []shared.BlockchainNetwork
+	createdBy  string
+	startedAt  time.Time
+	resolvedAt *time.Time
+}
+
+// NewIncident declares an active incident. An incident without networks affects every network.
+func NewIncident(
+	id, title, message string,
+	severity Severity,
+	networks []shared.BlockchainNetwork,
+	createdBy string,
+) (*Incident, error) {
+	return RestoreIncident(id, title, message, severity, networks, createdBy, shared.Now().UTC(), nil)
+}
+
+// RestoreIncident recreates an incident from storage.
+func RestoreIncident(
+	id, title, message string,
+	severity Severity,
+	networks []shared.BlockchainNetwork,
+	createdBy string,
+	startedAt time.Time,
+	resolvedAt *time.Time,
+) (*Incident, error) {
+	if id == "" {
+		return nil, errors.New("incident ID is required")
+	}
+	title, message = strings.TrimSpace(title), strings.TrimSpace(message)
+	if title == "" || len(title) > MaxIncidentTitleLength {
+		return nil, fmt.Errorf("%w: title must be 1 to %d characters", ErrInvalidIncident, MaxIncidentTitleLength)
+	}
+	if len(message) > MaxIncidentMessageLength {
+		return nil, fmt.Errorf("%w: message must be at most %d characters", ErrInvalidIncident,
+			MaxIncidentMessageLength)
+	}
+	if !severity.IsValid() {
+		return nil, fmt.Errorf("%w: invalid severity %q", ErrInvalidIncident, severity)
+	}
+	for _, network := range networks {
+		if !network.IsValid() {
+			return nil, fmt.Errorf("%w: unknown network %q", ErrInvalidIncident, network)
+		}
+	}
+
+	return &Incident{
+		id:         id,
+		title:      title,
+		message:    message,
+		severity:   severity,
+		networks:   slices.Clone(networks),
+		createdBy:  createdBy,
+		startedAt:  startedAt,
+		resolvedAt: resolvedAt,
+	}, nil
+}
+
+// ID returns the incident ID.
+func (i *Incident) ID() string {
+	return i.id
+}
+
+// Title returns the headline of the incident.
+func (i *Incident) Title() string {
+	return i.title
+}
+
+// Message returns what customers and merchants are told about the incident, if anything beyond the title.
+func (i *Incident) Message() string {
+	return i.message
+}
+
+// Severity returns how much of payment processing the incident affects.
+func (i *Incident) Severity() Severity {
+	return i.severity
+}
+
+// Networks returns the networks the incident affects, or nil if it affects every network.
+func (i *Incident) Networks() []shared.BlockchainNetwork {
+	return slices.Clone(i.networks)
+}
+
+// CreatedBy returns who declared the incident.
+func (i *Incident) CreatedBy() string {
+	return i.createdBy
+}
+
+// StartedAt returns when the incident was declared.
+func (i *Incident) StartedAt() time.Time {
+	return i.startedAt
+}
+
+// ResolvedAt returns when the incident was resolved, or nil while it is active.
+func (i *Incident) ResolvedAt() *time.Time {
+	return i.resolvedAt
+}
+
+// IsActive returns true until the incident is resolved.
+func (i *Incident) IsActive() bool {
+	return i.resolvedAt == nil
+}
+
+// Affects returns true if the incident affects payments on the network.
+func (i *Incident) Affects(network shared.BlockchainNetwork) bool {
+	return len(i.networks) == 0 || slices.Contains(i.networks, network)
+}
+
+// resolve records that the incident is over.
+func (i *Incident) resolve() error {
+	if !i.IsActive() {
+		return ErrIncidentResolved
+	}
+
+	now := shared.Now().UTC()
+	i.resolvedAt = &now
+	return nil
+}
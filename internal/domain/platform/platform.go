@@ -0,0 +1,34 @@
+// Package platform reports on the platform as a whole, across merchants: the work waiting in its queues and the
+// totals of its invoices and payments to the operators of the admin API, and its status and incidents to
+// everyone.
+package platform
+
+import "time"
+
+// Queues is the work waiting on the platform.
+type Queues struct {
+	// ExpiredInvoices are the active invoices past their expiry that the next expiration sweep expires.
+	ExpiredInvoices int
+	// DeadLetters are the pending dead letters by the handler that failed on them.
+	DeadLetters map[string]int
+	// OpenReviews are the payment reviews awaiting an operator.
+	OpenReviews int
+	// PendingApprovals are the transfers held for approval votes.
+	PendingApprovals int
+	// PendingPayouts are the payouts created but not yet broadcast.
+	PendingPayouts int
+	// CheckedAt is when the queues were counted.
+	CheckedAt time.Time
+}
+
+// Statistics are the platform-wide totals.
+type Statistics struct {
+	// Merchants are the merchants that have created invoices.
+	Merchants int
+	// Invoices are the invoices by status.
+	Invoices map[string]int
+	// Payments are the payments by status.
+	Payments map[string]int
+	// GeneratedAt is when the totals were counted.
+	GeneratedAt time.Time
+}
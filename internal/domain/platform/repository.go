@@ -0,0 +1,30 @@
+package platform
+
+import (
+	"context"
+	"time"
+)
+
+// Repository counts the platform's records across merchants and keeps its incidents.
+type Repository interface {
+	// CountQueues counts the work waiting in each queue as of now.
+	CountQueues(ctx context.Context, now time.Time) (*Queues, error)
+
+	// CountStatistics counts the invoicing merchants, and the invoices and payments by status.
+	CountStatistics(ctx context.Context) (*Statistics, error)
+
+	// CountWebhookDeliveries counts the webhooks sent to merchants' endpoints since the given time.
+	CountWebhookDeliveries(ctx context.Context, since time.Time) (*WebhookActivity, error)
+
+	// SaveIncident persists a new incident.
+	SaveIncident(ctx context.Context, incident *Incident) error
+
+	// FindIncidentByID retrieves an incident by its ID.
+	FindIncidentByID(ctx context.Context, id string) (*Incident, error)
+
+	// UpdateIncident updates an existing incident.
+	UpdateIncident(ctx context.Context, incident *Incident) error
+
+	// ListIncidents retrieves the latest incidents, newest first, or only the active ones.
+	ListIncidents(ctx context.Context, activeOnly bool, limit int) ([]*Incident, error)
+}
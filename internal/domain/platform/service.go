@@ -0,0 +1,194 @@
+package platform
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Limits of the incident listing.
+const (
+	DefaultIncidentLimit = 20
+	MaxIncidentLimit     = 100
+)
+
+// Service defines the interface for reporting on the platform as a whole.
+type Service interface {
+	// GetQueues returns the work waiting in the platform's queues.
+	GetQueues(ctx context.Context) (*Queues, error)
+
+	// GetStatistics returns the platform-wide totals.
+	GetStatistics(ctx context.Context) (*Statistics, error)
+
+	// GetStatus returns the state of payment detection, webhooks and exchange rates, and the active incidents.
+	GetStatus(ctx context.Context) (*Status, error)
+
+	// DeclareIncident declares an active incident.
+	DeclareIncident(ctx context.Context, req *DeclareIncidentRequest) (*Incident, error)
+
+	// ResolveIncident records that an active incident is over.
+	ResolveIncident(ctx context.Context, id string) (*Incident, error)
+
+	// ListIncidents lists the latest incidents, newest first.
+	ListIncidents(ctx context.Context, req *ListIncidentsRequest) ([]*Incident, error)
+
+	// ActiveIncidents returns the incidents not resolved yet, newest first.
+	ActiveIncidents(ctx context.Context) ([]*Incident, error)
+}
+
+// DeclareIncidentRequest represents the request to declare an incident. No networks affects every network.
+type DeclareIncidentRequest struct {
+	Title     string
+	Message   string
+	Severity  Severity
+	Networks  []shared.BlockchainNetwork
+	CreatedBy string
+}
+
+// ListIncidentsRequest represents the request to list incidents; a limit out of range gets
+// DefaultIncidentLimit.
+type ListIncidentsRequest struct {
+	ActiveOnly bool
+	Limit      int
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository Repository
+	watcher    ChainWatcher
+	rates      RateMonitor
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewService creates a new platform service. The watcher and rate monitor may be nil, in which case the status
+// reports no networks or rates.
+func NewService(repository Repository, watcher ChainWatcher, rates RateMonitor, logger *zap.Logger) Service {
+	return &ServiceImpl{
+		repository: repository,
+		watcher:    watcher,
+		rates:      rates,
+		logger:     logger,
+		now:        shared.Now,
+	}
+}
+
+// GetQueues returns the work waiting in the platform's queues.
+func (s *ServiceImpl) GetQueues(ctx context.Context) (*Queues, error) {
+	now := s.now().UTC()
+	queues, err := s.repository.CountQueues(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count queues: %w", err)
+	}
+	queues.CheckedAt = now
+	return queues, nil
+}
+
+// GetStatistics returns the platform-wide totals.
+func (s *ServiceImpl) GetStatistics(ctx context.Context) (*Statistics, error) {
+	statistics, err := s.repository.CountStatistics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count statistics: %w", err)
+	}
+	statistics.GeneratedAt = s.now().UTC()
+	return statistics, nil
+}
+
+// GetStatus returns the state of payment detection, webhooks and exchange rates, and the active incidents.
+func (s *ServiceImpl) GetStatus(ctx context.Context) (*Status, error) {
+	now := s.now().UTC()
+	webhooks, err := s.repository.CountWebhookDeliveries(ctx, now.Add(-WebhookWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+	webhooks.Window = WebhookWindow
+	incidents, err := s.ActiveIncidents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{
+		Condition: ConditionOperational,
+		Networks:  networkStatuses(s.watcher),
+		Webhooks:  *webhooks,
+		Incidents: incidents,
+		CheckedAt: now,
+	}
+	if s.rates != nil {
+		status.Rates = s.rates.Quotes()
+	}
+
+	degraded := len(incidents) > 0
+	for _, network := range status.Networks {
+		degraded = degraded || network.Condition == ConditionDegraded
+	}
+	for _, quote := range status.Rates {
+		degraded = degraded || quote.Stale()
+	}
+	if degraded {
+		status.Condition = ConditionDegraded
+	}
+	return status, nil
+}
+
+// DeclareIncident declares an active incident.
+func (s *ServiceImpl) DeclareIncident(ctx context.Context, req *DeclareIncidentRequest) (*Incident, error) {
+	incident, err := NewIncident(uuid.NewString(), req.Title, req.Message, req.Severity, req.Networks, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repository.SaveIncident(ctx, incident); err != nil {
+		return nil, fmt.Errorf("failed to save incident: %w", err)
+	}
+
+	s.logger.Warn("Incident declared",
+		zap.String("incident_id", incident.ID()),
+		zap.String("title", incident.Title()),
+		zap.String("severity", string(incident.Severity())),
+		zap.String("created_by", incident.CreatedBy()),
+	)
+	return incident, nil
+}
+
+// ResolveIncident records that an active incident is over.
+func (s *ServiceImpl) ResolveIncident(ctx context.Context, id string) (*Incident, error) {
+	incident, err := s.repository.FindIncidentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := incident.resolve(); err != nil {
+		return nil, err
+	}
+	if err := s.repository.UpdateIncident(ctx, incident); err != nil {
+		return nil, fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	s.logger.Info("Incident resolved", zap.String("incident_id", incident.ID()))
+	return incident, nil
+}
+
+// ListIncidents lists the latest incidents, newest first.
+func (s *ServiceImpl) ListIncidents(ctx context.Context, req *ListIncidentsRequest) ([]*Incident, error) {
+	limit := req.Limit
+	if limit <= 0 || limit > MaxIncidentLimit {
+		limit = DefaultIncidentLimit
+	}
+	incidents, err := s.repository.ListIncidents(ctx, req.ActiveOnly, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incidents: %w", err)
+	}
+	return incidents, nil
+}
+
+// ActiveIncidents returns the incidents not resolved yet, newest first.
+func (s *ServiceImpl) ActiveIncidents(ctx context.Context) ([]*Incident, error) {
+	incidents, err := s.repository.ListIncidents(ctx, true, MaxIncidentLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active incidents: %w", err)
+	}
+	return incidents, nil
+}
@@ -0,0 +1,117 @@
+package platform
+
+import (
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+)
+
+// WebhookWindow is how far back the webhooks sent to merchants' endpoints are counted in the status.
+const WebhookWindow = time.Hour
+
+// Condition is the state of the platform or one of its parts.
+type Condition string
+
+const (
+	// ConditionOperational - Payments are detected and reported as usual
+	ConditionOperational Condition = "operational"
+	// ConditionDegraded - Payments may be detected or reported late
+	ConditionDegraded Condition = "degraded"
+)
+
+// Status is the state of the platform: how payment detection keeps up with each network, how merchants'
+// webhook endpoints answer, how fresh the exchange rates are and the active incidents. The platform is degraded
+// while any of them is.
+type Status struct {
+	Condition Condition
+	Networks  []NetworkStatus
+	Webhooks  WebhookActivity
+	Rates     []RateQuote
+	Incidents []*Incident
+	CheckedAt time.Time
+}
+
+// NetworkStatus is how far the chain watcher is behind the chain head of a network. A network is degraded
+// while its last check failed or its lag exceeds the watcher's maximum.
+type NetworkStatus struct {
+	Network   shared.BlockchainNetwork
+	Condition Condition
+	Head      int64
+	Cursor    int64
+	Lag       int64
+	// CheckedAt is zero until the watcher has checked the network.
+	CheckedAt time.Time
+	Error     string
+}
+
+// WebhookActivity counts the webhooks sent to merchants' endpoints within the window.
+type WebhookActivity struct {
+	Window time.Duration
+	Sent   int
+	Failed int
+	// FailingEndpoints are the endpoints whose latest delivery within the window failed.
+	FailingEndpoints int
+}
+
+// RateQuote is the outcome of the latest quotes of a currency pair. A pair is stale while its latest quote
+// failed.
+type RateQuote struct {
+	From   shared.Currency
+	To     shared.CryptoCurrency
+	Source string
+	// QuotedAt is when the pair was last quoted, or zero if it never was.
+	QuotedAt time.Time
+	// FailedAt is when the latest quote failed, or nil if it succeeded.
+	FailedAt *time.Time
+	Error    string
+}
+
+// Stale returns true if the latest quote of the pair failed.
+func (q RateQuote) Stale() bool {
+	return q.FailedAt != nil
+}
+
+// ChainWatcher reports how far the chain watcher is behind the chain head of each network it follows.
+type ChainWatcher interface {
+	Enabled() bool
+	MaxLag() int64
+	Networks() []shared.BlockchainNetwork
+	Status() []detection.WatcherStatus
+}
+
+// RateMonitor reports the latest quotes of each currency pair rates were requested for.
+type RateMonitor interface {
+	Quotes() []RateQuote
+}
+
+// networkStatuses returns the status of every network the watcher follows, in the order of its report.
+func networkStatuses(watcher ChainWatcher) []NetworkStatus {
+	if watcher == nil || !watcher.Enabled() {
+		return nil
+	}
+
+	checked := make(map[shared.BlockchainNetwork]bool)
+	var statuses []NetworkStatus
+	for _, status := range watcher.Status() {
+		checked[status.Network] = true
+		condition := ConditionOperational
+		if status.Error != "" || (watcher.MaxLag() > 0 && status.Lag > watcher.MaxLag()) {
+			condition = ConditionDegraded
+		}
+		statuses = append(statuses, NetworkStatus{
+			Network:   status.Network,
+			Condition: condition,
+			Head:      status.Head,
+			Cursor:    status.Cursor,
+			Lag:       status.Lag,
+			CheckedAt: status.CheckedAt,
+			Error:     status.Error,
+		})
+	}
+	for _, network := range watcher.Networks() {
+		if !checked[network] {
+			statuses = append(statuses, NetworkStatus{Network: network, Condition: ConditionOperational})
+		}
+	}
+	return statuses
+}
@@ -0,0 +1,201 @@
+package processor
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CheckoutStatus is the state of a processor checkout.
+type CheckoutStatus string
+
+// Checkout statuses.
+const (
+	CheckoutOpen    CheckoutStatus = "open"
+	CheckoutPaid    CheckoutStatus = "paid"
+	CheckoutFailed  CheckoutStatus = "failed"
+	CheckoutExpired CheckoutStatus = "expired"
+)
+
+// Checkout is a checkout created at a processor for an invoice. It is kept so the payment page offers the
+// same checkout on every visit and so the processor's webhooks find the invoice they pay.
+type Checkout struct {
+	id             string
+	registrationID string
+	merchantID     string
+	invoiceID      string
+	processor      string
+	externalID     string
+	url            string
+	qrContent      string
+	amount         decimal.Decimal
+	currency       string
+	status         CheckoutStatus
+	paymentID      string
+	expiresAt      time.Time
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+// NewCheckout records an open checkout a processor created for an invoice. Its ID is the reference the
+// processor was given.
+func NewCheckout(
+	id string,
+	registration *Registration,
+	invoiceID string,
+	amount decimal.Decimal,
+	currency string,
+	hosted *HostedCheckout,
+) (*Checkout, error) {
+	if registration == nil || hosted == nil {
+		return nil, errors.New("registration and hosted checkout are required")
+	}
+	now := shared.Now().UTC()
+	return RestoreCheckout(
+		id, registration.ID(), registration.MerchantID(), invoiceID, registration.Processor(),
+		hosted.ID, hosted.URL, hosted.QRContent, amount, currency, CheckoutOpen, "",
+		hosted.ExpiresAt, now, now,
+	)
+}
+
+// RestoreCheckout recreates a checkout from storage.
+func RestoreCheckout(
+	id, registrationID, merchantID, invoiceID, processor, externalID, url, qrContent string,
+	amount decimal.Decimal,
+	currency string,
+	status CheckoutStatus,
+	paymentID string,
+	expiresAt, createdAt, updatedAt time.Time,
+) (*Checkout, error) {
+	if id == "" {
+		return nil, errors.New("checkout ID is required")
+	}
+	if registrationID == "" || merchantID == "" || invoiceID == "" {
+		return nil, errors.New("registration, merchant and invoice are required")
+	}
+	if url == "" && qrContent == "" {
+		return nil, errors.New("checkout URL or QR content is required")
+	}
+	if !amount.IsPositive() {
+		return nil, errors.New("checkout amount must be positive")
+	}
+
+	return &Checkout{
+		id:             id,
+		registrationID: registrationID,
+		merchantID:     merchantID,
+		invoiceID:      invoiceID,
+		processor:      processor,
+		externalID:     externalID,
+		url:            url,
+		qrContent:      qrContent,
+		amount:         amount,
+		currency:       currency,
+		status:         status,
+		paymentID:      paymentID,
+		expiresAt:      expiresAt,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
+	}, nil
+}
+
+// ID returns the checkout ID, the reference the processor reports it by.
+func (c *Checkout) ID() string {
+	return c.id
+}
+
+// RegistrationID returns the registration the checkout was created with.
+func (c *Checkout) RegistrationID() string {
+	return c.registrationID
+}
+
+// MerchantID returns the merchant of the invoice.
+func (c *Checkout) MerchantID() string {
+	return c.merchantID
+}
+
+// InvoiceID returns the invoice the checkout pays.
+func (c *Checkout) InvoiceID() string {
+	return c.invoiceID
+}
+
+// Processor returns the name of the processor.
+func (c *Checkout) Processor() string {
+	return c.processor
+}
+
+// ExternalID returns the processor's identifier of the checkout.
+func (c *Checkout) ExternalID() string {
+	return c.externalID
+}
+
+// URL returns the processor's checkout page.
+func (c *Checkout) URL() string {
+	return c.url
+}
+
+// QRContent returns the content of the processor's QR code, if it offers one.
+func (c *Checkout) QRContent() string {
+	return c.qrContent
+}
+
+// Amount returns the amount the checkout asks for.
+func (c *Checkout) Amount() decimal.Decimal {
+	return c.amount
+}
+
+// Currency returns the currency of the amount.
+func (c *Checkout) Currency() string {
+	return c.currency
+}
+
+// Status returns the checkout status.
+func (c *Checkout) Status() CheckoutStatus {
+	return c.status
+}
+
+// PaymentID returns the payment recorded when the checkout was paid.
+func (c *Checkout) PaymentID() string {
+	return c.paymentID
+}
+
+// ExpiresAt returns when the processor stops accepting payment of the checkout.
+func (c *Checkout) ExpiresAt() time.Time {
+	return c.expiresAt
+}
+
+// CreatedAt returns when the checkout was created.
+func (c *Checkout) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// UpdatedAt returns when the checkout last changed.
+func (c *Checkout) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// Offers reports whether the checkout can still be offered to pay the amount.
+func (c *Checkout) Offers(amount decimal.Decimal, currency string, now time.Time) bool {
+	if c.status != CheckoutOpen || c.currency != currency || !c.amount.Equal(amount) {
+		return false
+	}
+	return c.expiresAt.IsZero() || now.Before(c.expiresAt)
+}
+
+// MarkPaid records the payment the checkout's payment was credited as.
+func (c *Checkout) MarkPaid(paymentID string) {
+	c.status = CheckoutPaid
+	c.paymentID = paymentID
+	c.updatedAt = shared.Now().UTC()
+}
+
+// Close records that the checkout failed or expired at the processor. Paid checkouts stay paid.
+func (c *Checkout) Close(status CheckoutStatus) {
+	if c.status != CheckoutOpen {
+		return
+	}
+	c.status = status
+	c.updatedAt = shared.Now().UTC()
+}
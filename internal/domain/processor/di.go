@@ -0,0 +1,15 @@
+package processor
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the payment processor service layer dependencies.
+var Module = fx.Module("processor-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+)
@@ -0,0 +1,25 @@
+package processor
+
+import "errors"
+
+// Domain errors for payment processor operations
+var (
+	ErrRegistrationNotFound = errors.New("payment processor registration not found")
+	ErrAlreadyRegistered    = errors.New("payment processor is already registered for the merchant")
+	ErrUnsupportedProcessor = errors.New("unsupported payment processor")
+	ErrCheckoutNotFound     = errors.New("payment processor checkout not found")
+	ErrInvalidSignature     = errors.New("invalid payment processor webhook signature")
+	ErrInvalidEvent         = errors.New("invalid payment processor event")
+	ErrIgnoredEvent         = errors.New("payment processor event does not concern a checkout")
+	ErrInvalidRequest       = errors.New("invalid payment processor request")
+	ErrProcessorFailure     = errors.New("payment processor request failed")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeRegistrationNotFound = "PAYMENT_PROCESSOR_NOT_FOUND"
+	ErrCodeAlreadyRegistered    = "PAYMENT_PROCESSOR_ALREADY_REGISTERED"
+	ErrCodeUnsupportedProcessor = "UNSUPPORTED_PAYMENT_PROCESSOR"
+	ErrCodeInvalidSignature     = "INVALID_PROCESSOR_SIGNATURE"
+	ErrCodeInvalidEvent         = "INVALID_PROCESSOR_EVENT"
+)
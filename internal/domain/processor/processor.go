@@ -0,0 +1,136 @@
+// Package processor lets customers pay invoices through off-chain payment processors, such as Binance Pay or
+// Coinbase Commerce, next to the on-chain transfers the watchers detect.
+package processor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Names of the processors merchants can register.
+const (
+	NameBinancePay       = "binance_pay"
+	NameCoinbaseCommerce = "coinbase_commerce"
+)
+
+// PaymentProcessor is an off-chain payment rail. Adapters create hosted checkouts with the merchant's own
+// credentials and turn the webhooks the processor sends into events.
+type PaymentProcessor interface {
+	// Name returns the name merchants register the processor by, e.g. "binance_pay".
+	Name() string
+
+	// DisplayName returns the name shown to customers on the payment page.
+	DisplayName() string
+
+	// CreateCheckout creates a checkout at the processor for the amount of an invoice.
+	CreateCheckout(ctx context.Context, credentials Credentials, req *CheckoutRequest) (*HostedCheckout, error)
+
+	// ParseEvent verifies the signature of a webhook the processor sent and returns the event it carries.
+	// Webhooks that are not about a checkout return ErrIgnoredEvent.
+	ParseEvent(credentials Credentials, headers http.Header, body []byte) (*Event, error)
+}
+
+// Credentials are a merchant's keys at a processor.
+type Credentials struct {
+	APIKey    string
+	APISecret string
+	// WebhookSecret verifies the webhooks the processor sends: a shared secret, or the processor's public key.
+	WebhookSecret string
+}
+
+// CheckoutRequest represents the checkout a processor is asked to create.
+type CheckoutRequest struct {
+	// Reference is unique per checkout and returned in the processor's events, so webhooks find the checkout.
+	Reference   string
+	InvoiceID   string
+	Title       string
+	Description string
+	Amount      decimal.Decimal
+	Currency    string
+	ExpiresAt   time.Time
+	ReturnURL   string
+	CancelURL   string
+}
+
+// HostedCheckout is the checkout a processor created, which the customer completes on the processor's side.
+type HostedCheckout struct {
+	// ID is the processor's identifier of the checkout.
+	ID string
+	// URL opens the processor's checkout page.
+	URL string
+	// QRContent is scanned by the processor's app to pay, when the processor offers one.
+	QRContent string
+	ExpiresAt time.Time
+}
+
+// EventStatus is the state of a checkout an event reports.
+type EventStatus string
+
+// States of a checkout reported by processor events.
+const (
+	EventPending EventStatus = "pending"
+	EventPaid    EventStatus = "paid"
+	EventFailed  EventStatus = "failed"
+	EventExpired EventStatus = "expired"
+)
+
+// Event is a processor webhook turned into a change of a checkout.
+type Event struct {
+	// Reference is the reference the checkout was created with.
+	Reference string
+	Status    EventStatus
+	// Amount and Currency are what the customer paid; set for paid events.
+	Amount   decimal.Decimal
+	Currency string
+	// Payer identifies the customer at the processor, when the processor tells.
+	Payer string
+}
+
+// TransactionHash returns the transaction hash a payment made through a processor is recorded with. It is
+// derived from the processor and the checkout reference so that repeated webhooks record the payment once.
+func TransactionHash(processorName, reference string) string {
+	sum := sha256.Sum256([]byte(processorName + ":" + reference))
+	return hex.EncodeToString(sum[:])
+}
+
+// Registry holds the processors merchants can register, by name.
+type Registry struct {
+	processors map[string]PaymentProcessor
+}
+
+// NewRegistry creates a registry of processors.
+func NewRegistry(processors ...PaymentProcessor) *Registry {
+	registry := &Registry{processors: make(map[string]PaymentProcessor, len(processors))}
+	for _, p := range processors {
+		registry.processors[p.Name()] = p
+	}
+	return registry
+}
+
+// Get returns the processor with the name.
+func (r *Registry) Get(name string) (PaymentProcessor, bool) {
+	if r == nil {
+		return nil, false
+	}
+	p, ok := r.processors[name]
+	return p, ok
+}
+
+// Names returns the names of the processors, sorted.
+func (r *Registry) Names() []string {
+	if r == nil {
+		return nil
+	}
+	names := make([]string, 0, len(r.processors))
+	for name := range r.processors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
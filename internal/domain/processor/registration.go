@@ -0,0 +1,119 @@
+package processor
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"time"
+)
+
+// Registration is a merchant's account at a payment processor, which the merchant's invoices can be paid
+// through. A merchant registers each processor once.
+type Registration struct {
+	id          string
+	merchantID  string
+	processor   string
+	credentials Credentials
+	enabled     bool
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// NewRegistration creates an enabled registration of a processor for a merchant.
+func NewRegistration(id, merchantID, processor string, credentials Credentials) (*Registration, error) {
+	now := shared.Now().UTC()
+	return RestoreRegistration(id, merchantID, processor, credentials, true, now, now)
+}
+
+// RestoreRegistration recreates a registration from storage.
+func RestoreRegistration(
+	id, merchantID, processor string,
+	credentials Credentials,
+	enabled bool,
+	createdAt, updatedAt time.Time,
+) (*Registration, error) {
+	if id == "" {
+		return nil, errors.New("registration ID is required")
+	}
+	if merchantID == "" {
+		return nil, errors.New("merchant ID is required")
+	}
+	if processor == "" {
+		return nil, errors.New("processor name is required")
+	}
+	if err := validateCredentials(credentials); err != nil {
+		return nil, err
+	}
+
+	return &Registration{
+		id:          id,
+		merchantID:  merchantID,
+		processor:   processor,
+		credentials: credentials,
+		enabled:     enabled,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}, nil
+}
+
+// ID returns the registration ID, which the processor's webhook URL carries.
+func (r *Registration) ID() string {
+	return r.id
+}
+
+// MerchantID returns the merchant that registered the processor.
+func (r *Registration) MerchantID() string {
+	return r.merchantID
+}
+
+// Processor returns the name of the registered processor.
+func (r *Registration) Processor() string {
+	return r.processor
+}
+
+// Credentials returns the merchant's keys at the processor.
+func (r *Registration) Credentials() Credentials {
+	return r.credentials
+}
+
+// Enabled returns true if the processor is offered on the merchant's payment pages.
+func (r *Registration) Enabled() bool {
+	return r.enabled
+}
+
+// CreatedAt returns when the processor was registered.
+func (r *Registration) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// UpdatedAt returns when the registration was last changed.
+func (r *Registration) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// SetEnabled offers or stops offering the processor on payment pages. Checkouts already created are still
+// credited when paid.
+func (r *Registration) SetEnabled(enabled bool) {
+	r.enabled = enabled
+	r.updatedAt = shared.Now().UTC()
+}
+
+// RotateCredentials replaces the merchant's keys at the processor.
+func (r *Registration) RotateCredentials(credentials Credentials) error {
+	if err := validateCredentials(credentials); err != nil {
+		return err
+	}
+	r.credentials = credentials
+	r.updatedAt = shared.Now().UTC()
+	return nil
+}
+
+// validateCredentials checks that the keys needed to create checkouts and verify webhooks are present.
+func validateCredentials(credentials Credentials) error {
+	if credentials.APIKey == "" {
+		return errors.New("API key is required")
+	}
+	if credentials.WebhookSecret == "" {
+		return errors.New("webhook secret is required")
+	}
+	return nil
+}
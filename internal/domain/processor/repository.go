@@ -0,0 +1,34 @@
+package processor
+
+import "context"
+
+// Repository defines the interface for payment processor registration and checkout persistence.
+type Repository interface {
+	// SaveRegistration persists a new registration, returning ErrAlreadyRegistered if the merchant already
+	// registered the processor.
+	SaveRegistration(ctx context.Context, registration *Registration) error
+
+	// FindRegistration retrieves a registration by its ID.
+	FindRegistration(ctx context.Context, id string) (*Registration, error)
+
+	// ListRegistrations retrieves a merchant's registrations ordered by processor name.
+	ListRegistrations(ctx context.Context, merchantID string) ([]*Registration, error)
+
+	// UpdateRegistration updates an existing registration.
+	UpdateRegistration(ctx context.Context, registration *Registration) error
+
+	// DeleteRegistration removes a registration.
+	DeleteRegistration(ctx context.Context, id string) error
+
+	// SaveCheckout persists a new checkout.
+	SaveCheckout(ctx context.Context, checkout *Checkout) error
+
+	// UpdateCheckout updates an existing checkout.
+	UpdateCheckout(ctx context.Context, checkout *Checkout) error
+
+	// FindCheckout retrieves a checkout by its ID.
+	FindCheckout(ctx context.Context, id string) (*Checkout, error)
+
+	// ListCheckouts retrieves the checkouts of an invoice, newest first.
+	ListCheckouts(ctx context.Context, invoiceID string) ([]*Checkout, error)
+}
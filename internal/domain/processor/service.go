@@ -0,0 +1,493 @@
+package processor
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// Service defines the interface for registering payment processors and paying invoices through them.
+type Service interface {
+	// RegisterProcessor registers a processor for a merchant with the merchant's credentials.
+	RegisterProcessor(ctx context.Context, req *RegisterRequest) (*Registration, error)
+
+	// ListRegistrations lists a merchant's processor registrations.
+	ListRegistrations(ctx context.Context, merchantID string) ([]*Registration, error)
+
+	// GetRegistration retrieves a merchant's processor registration.
+	GetRegistration(ctx context.Context, merchantID, registrationID string) (*Registration, error)
+
+	// UpdateRegistration enables or disables a merchant's processor registration or rotates its credentials.
+	UpdateRegistration(ctx context.Context, req *UpdateRegistrationRequest) (*Registration, error)
+
+	// DeleteRegistration removes a merchant's processor registration.
+	DeleteRegistration(ctx context.Context, merchantID, registrationID string) error
+
+	// CheckoutOptions returns a checkout at each of the merchant's enabled processors paying the invoice,
+	// creating the checkouts the invoice does not have yet. Invoices that cannot be paid have none.
+	CheckoutOptions(ctx context.Context, inv *invoice.Invoice) ([]CheckoutOption, error)
+
+	// HandleWebhook applies a webhook a processor sent for a registration. A paid checkout is recorded as a
+	// confirmed payment of its invoice, with the same events as a payment detected on chain.
+	HandleWebhook(ctx context.Context, req *WebhookRequest) (*Checkout, error)
+}
+
+// RegisterRequest represents the request to register a processor for a merchant.
+type RegisterRequest struct {
+	MerchantID  string `validate:"required"`
+	Processor   string `validate:"required"`
+	Credentials Credentials
+}
+
+// UpdateRegistrationRequest represents the request to change a processor registration. Fields left nil are
+// kept.
+type UpdateRegistrationRequest struct {
+	MerchantID     string `validate:"required"`
+	RegistrationID string `validate:"required"`
+	Enabled        *bool
+	Credentials    *Credentials
+}
+
+// CheckoutOption is a checkout offered on the payment page, with the name customers know its processor by.
+type CheckoutOption struct {
+	Checkout    *Checkout
+	DisplayName string
+}
+
+// WebhookRequest represents a webhook a processor sent to the URL of a registration.
+type WebhookRequest struct {
+	Processor      string `validate:"required"`
+	RegistrationID string `validate:"required"`
+	Headers        http.Header
+	Body           []byte `validate:"required"`
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository     Repository
+	processors     *Registry
+	invoiceService invoice.InvoiceService
+	paymentService payment.PaymentService
+	ids            shared.IDGenerator
+	logger         *zap.Logger
+}
+
+// NewService creates a new payment processor service. The ID generator may be nil, in which case payments
+// get random UUIDs.
+func NewService(
+	repository Repository,
+	processors *Registry,
+	invoiceService invoice.InvoiceService,
+	paymentService payment.PaymentService,
+	ids shared.IDGenerator,
+	logger *zap.Logger,
+) Service {
+	if ids == nil {
+		ids = shared.RandomIDGenerator{}
+	}
+	return &ServiceImpl{
+		repository:     repository,
+		processors:     processors,
+		invoiceService: invoiceService,
+		paymentService: paymentService,
+		ids:            ids,
+		logger:         logger,
+	}
+}
+
+// RegisterProcessor registers a processor for a merchant with the merchant's credentials.
+func (s *ServiceImpl) RegisterProcessor(ctx context.Context, req *RegisterRequest) (*Registration, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: register request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	if _, ok := s.processors.Get(req.Processor); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProcessor, req.Processor)
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate registration ID: %w", err)
+	}
+	registration, err := NewRegistration(id, req.MerchantID, req.Processor, req.Credentials)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	if err := s.repository.SaveRegistration(ctx, registration); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Payment processor registered",
+		zap.String("registration_id", registration.ID()),
+		zap.String("merchant_id", registration.MerchantID()),
+		zap.String("processor", registration.Processor()))
+
+	return registration, nil
+}
+
+// ListRegistrations lists a merchant's processor registrations.
+func (s *ServiceImpl) ListRegistrations(ctx context.Context, merchantID string) ([]*Registration, error) {
+	if merchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+
+	return s.repository.ListRegistrations(ctx, merchantID)
+}
+
+// GetRegistration retrieves a merchant's processor registration.
+func (s *ServiceImpl) GetRegistration(ctx context.Context, merchantID, registrationID string) (*Registration, error) {
+	if merchantID == "" || registrationID == "" {
+		return nil, fmt.Errorf("%w: merchant ID and registration ID are required", ErrInvalidRequest)
+	}
+
+	registration, err := s.repository.FindRegistration(ctx, registrationID)
+	if err != nil {
+		return nil, err
+	}
+	if registration.MerchantID() != merchantID {
+		return nil, ErrRegistrationNotFound
+	}
+	return registration, nil
+}
+
+// UpdateRegistration enables or disables a merchant's processor registration or rotates its credentials.
+func (s *ServiceImpl) UpdateRegistration(
+	ctx context.Context,
+	req *UpdateRegistrationRequest,
+) (*Registration, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: update registration request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	registration, err := s.GetRegistration(ctx, req.MerchantID, req.RegistrationID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Credentials != nil {
+		if err := registration.RotateCredentials(*req.Credentials); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
+	}
+	if req.Enabled != nil {
+		registration.SetEnabled(*req.Enabled)
+	}
+
+	if err := s.repository.UpdateRegistration(ctx, registration); err != nil {
+		return nil, err
+	}
+	return registration, nil
+}
+
+// DeleteRegistration removes a merchant's processor registration.
+func (s *ServiceImpl) DeleteRegistration(ctx context.Context, merchantID, registrationID string) error {
+	if _, err := s.GetRegistration(ctx, merchantID, registrationID); err != nil {
+		return err
+	}
+
+	if err := s.repository.DeleteRegistration(ctx, registrationID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Payment processor registration deleted",
+		zap.String("registration_id", registrationID),
+		zap.String("merchant_id", merchantID))
+	return nil
+}
+
+// CheckoutOptions returns a checkout at each of the merchant's enabled processors paying the invoice. An
+// open checkout for the amount due is reused; a processor failing to create one is left out, so the page
+// still offers the others and the on-chain address. Processors no longer offered by the platform are skipped.
+func (s *ServiceImpl) CheckoutOptions(ctx context.Context, inv *invoice.Invoice) ([]CheckoutOption, error) {
+	if inv == nil {
+		return nil, fmt.Errorf("%w: invoice cannot be nil", ErrInvalidRequest)
+	}
+	if !payableThroughProcessor(inv) {
+		return nil, nil
+	}
+
+	registrations, err := s.repository.ListRegistrations(ctx, inv.MerchantID())
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.repository.ListCheckouts(ctx, inv.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := inv.LockedCryptoAmount()
+	if err != nil {
+		return nil, err
+	}
+	currency := inv.CryptoCurrency().String()
+	now := shared.Now().UTC()
+
+	options := make([]CheckoutOption, 0, len(registrations))
+	for _, registration := range registrations {
+		p, ok := s.processors.Get(registration.Processor())
+		if !registration.Enabled() || !ok {
+			continue
+		}
+
+		checkout := offeredCheckout(existing, registration.ID(), amount.Amount(), currency, now)
+		if checkout == nil {
+			if checkout, err = s.createCheckout(ctx, p, registration, inv, amount); err != nil {
+				s.logger.Warn("Failed to create payment processor checkout",
+					zap.String("invoice_id", inv.ID()),
+					zap.String("processor", registration.Processor()),
+					zap.Error(err))
+				continue
+			}
+		}
+		options = append(options, CheckoutOption{Checkout: checkout, DisplayName: p.DisplayName()})
+	}
+	return options, nil
+}
+
+// createCheckout creates a checkout for the amount due at a processor and saves it.
+func (s *ServiceImpl) createCheckout(
+	ctx context.Context,
+	p PaymentProcessor,
+	registration *Registration,
+	inv *invoice.Invoice,
+	amount *shared.Money,
+) (*Checkout, error) {
+	id, err := generateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate checkout ID: %w", err)
+	}
+	req := &CheckoutRequest{
+		Reference:   id,
+		InvoiceID:   inv.ID(),
+		Title:       inv.Title(),
+		Description: inv.Description(),
+		Amount:      amount.Amount(),
+		Currency:    inv.CryptoCurrency().String(),
+	}
+	if expiration := inv.Expiration(); expiration != nil {
+		req.ExpiresAt = expiration.ExpiresAt()
+	}
+	if returnURL := inv.ReturnURL(); returnURL != nil {
+		req.ReturnURL = *returnURL
+	}
+	if cancelURL := inv.CancelURL(); cancelURL != nil {
+		req.CancelURL = *cancelURL
+	}
+
+	hosted, err := p.CreateCheckout(ctx, registration.Credentials(), req)
+	if err != nil {
+		return nil, err
+	}
+	checkout, err := NewCheckout(id, registration, inv.ID(), req.Amount, req.Currency, hosted)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repository.SaveCheckout(ctx, checkout); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Payment processor checkout created",
+		zap.String("checkout_id", checkout.ID()),
+		zap.String("invoice_id", inv.ID()),
+		zap.String("processor", checkout.Processor()))
+	return checkout, nil
+}
+
+// HandleWebhook applies a webhook a processor sent for a registration.
+func (s *ServiceImpl) HandleWebhook(ctx context.Context, req *WebhookRequest) (*Checkout, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: webhook request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	registration, err := s.repository.FindRegistration(ctx, req.RegistrationID)
+	if err != nil {
+		return nil, err
+	}
+	if registration.Processor() != req.Processor {
+		return nil, ErrRegistrationNotFound
+	}
+	p, ok := s.processors.Get(registration.Processor())
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProcessor, registration.Processor())
+	}
+
+	// Webhooks are verified before anything they carry is looked up
+	event, err := p.ParseEvent(registration.Credentials(), req.Headers, req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = shared.WithMerchantID(ctx, registration.MerchantID())
+	checkout, err := s.repository.FindCheckout(ctx, event.Reference)
+	if err != nil {
+		return nil, err
+	}
+	if checkout.RegistrationID() != registration.ID() {
+		return nil, ErrCheckoutNotFound
+	}
+
+	switch event.Status {
+	case EventPaid:
+		if checkout.Status() == CheckoutPaid {
+			return checkout, nil
+		}
+		return s.recordPayment(ctx, checkout, event)
+	case EventFailed, EventExpired:
+		if checkout.Status() != CheckoutOpen {
+			return checkout, nil
+		}
+		checkout.Close(CheckoutStatus(event.Status))
+		if err := s.repository.UpdateCheckout(ctx, checkout); err != nil {
+			return nil, err
+		}
+		return checkout, nil
+	default:
+		return checkout, nil
+	}
+}
+
+// recordPayment records the payment of a checkout as a payment of its invoice and confirms it: the processor
+// has settled it, so there are no confirmations to wait for. Payments held by compliance screening wait for
+// review like payments detected on chain.
+func (s *ServiceImpl) recordPayment(ctx context.Context, checkout *Checkout, event *Event) (*Checkout, error) {
+	inv, err := s.invoiceService.GetInvoice(ctx, checkout.InvoiceID())
+	if err != nil {
+		return nil, err
+	}
+	if event.Currency != inv.CryptoCurrency().String() {
+		return nil, fmt.Errorf("%w: invoice %s expects %s, not %s",
+			ErrInvalidEvent, inv.ID(), inv.CryptoCurrency(), event.Currency)
+	}
+	if inv.PaymentAddress() == nil {
+		return nil, fmt.Errorf("%w: invoice %s has no payment address", ErrInvalidEvent, inv.ID())
+	}
+
+	amount, err := shared.NewMoneyWithCrypto(event.Amount.String(), inv.CryptoCurrency())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEvent, err)
+	}
+	paymentAmount, err := payment.NewPaymentAmount(amount, inv.CryptoCurrency())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEvent, err)
+	}
+	toAddress, err := payment.NewPaymentAddress(inv.PaymentAddress().Address(), inv.PaymentAddress().Network())
+	if err != nil {
+		return nil, err
+	}
+	txHash, err := payment.NewTransactionHash(TransactionHash(checkout.Processor(), checkout.ID()))
+	if err != nil {
+		return nil, err
+	}
+	from := checkout.Processor()
+	if event.Payer != "" {
+		from += ":" + event.Payer
+	}
+
+	paymentID, err := s.ids.NewID(ctx, shared.IDKindPayment, inv.MerchantID())
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.paymentService.CreatePayment(ctx, &payment.CreatePaymentRequest{
+		ID:                    shared.PaymentID(paymentID),
+		InvoiceID:             shared.InvoiceID(inv.ID()),
+		Amount:                paymentAmount,
+		FromAddress:           from,
+		ToAddress:             toAddress,
+		TransactionHash:       txHash,
+		RequiredConfirmations: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record payment: %w", err)
+	}
+
+	// A payment recorded by an earlier delivery of the webhook is not credited again
+	if p.ID() == shared.PaymentID(paymentID) && p.Status() != payment.StatusHeld {
+		// The customer opened the checkout, so an invoice not yet marked viewed awaits payment all the same
+		if inv.Status() == invoice.StatusCreated {
+			if err := s.invoiceService.MarkInvoiceAsViewed(ctx, inv.ID()); err != nil {
+				return nil, fmt.Errorf("failed to mark invoice as viewed: %w", err)
+			}
+		}
+		if err := s.invoiceService.ProcessPayment(ctx, inv.ID(), p); err != nil &&
+			!errors.Is(err, invoice.ErrUnderpayment) && !errors.Is(err, invoice.ErrStaleRate) {
+			return nil, fmt.Errorf("failed to credit invoice with payment: %w", err)
+		}
+		if err := s.paymentService.UpdateBlockInfo(ctx, p.ID(), 0, txHash.String()); err != nil {
+			return nil, fmt.Errorf("failed to record settlement of payment: %w", err)
+		}
+		if err := s.paymentService.UpdateConfirmations(ctx, p.ID(), 1); err != nil {
+			return nil, fmt.Errorf("failed to confirm payment: %w", err)
+		}
+	}
+
+	checkout.MarkPaid(string(p.ID()))
+	if err := s.repository.UpdateCheckout(ctx, checkout); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Payment received through payment processor",
+		zap.String("payment_id", string(p.ID())),
+		zap.String("invoice_id", inv.ID()),
+		zap.String("processor", checkout.Processor()),
+		zap.String("checkout_id", checkout.ID()))
+	return checkout, nil
+}
+
+// payableThroughProcessor reports whether an invoice awaits its first payment at an assigned address.
+// Processors charge the whole amount, so invoices partially paid on chain are not offered checkouts.
+func payableThroughProcessor(inv *invoice.Invoice) bool {
+	if inv.Status() != invoice.StatusCreated && inv.Status() != invoice.StatusPending {
+		return false
+	}
+	if inv.AmountPaid() != nil && inv.AmountPaid().Amount().IsPositive() {
+		return false
+	}
+	if expiration := inv.Expiration(); expiration != nil && expiration.IsExpired() {
+		return false
+	}
+	return inv.PaymentAddress() != nil
+}
+
+// offeredCheckout returns the newest checkout of a registration that still offers the amount, or nil.
+func offeredCheckout(
+	checkouts []*Checkout,
+	registrationID string,
+	amount decimal.Decimal,
+	currency string,
+	now time.Time,
+) *Checkout {
+	for _, checkout := range checkouts {
+		if checkout.RegistrationID() == registrationID && checkout.Offers(amount, currency, now) {
+			return checkout
+		}
+	}
+	return nil
+}
+
+// generateID returns a random identifier, short enough to be a processor's merchant order number.
+func generateID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,401 @@
+package processor
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeRepository struct {
+	registrations map[string]*Registration
+	checkouts     map[string]*Checkout
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{registrations: map[string]*Registration{}, checkouts: map[string]*Checkout{}}
+}
+
+func (r *fakeRepository) SaveRegistration(_ context.Context, registration *Registration) error {
+	for _, existing := range r.registrations {
+		if existing.MerchantID() == registration.MerchantID() && existing.Processor() == registration.Processor() {
+			return ErrAlreadyRegistered
+		}
+	}
+	r.registrations[registration.ID()] = registration
+	return nil
+}
+
+func (r *fakeRepository) FindRegistration(_ context.Context, id string) (*Registration, error) {
+	registration, ok := r.registrations[id]
+	if !ok {
+		return nil, ErrRegistrationNotFound
+	}
+	return registration, nil
+}
+
+func (r *fakeRepository) ListRegistrations(_ context.Context, merchantID string) ([]*Registration, error) {
+	var registrations []*Registration
+	for _, registration := range r.registrations {
+		if registration.MerchantID() == merchantID {
+			registrations = append(registrations, registration)
+		}
+	}
+	return registrations, nil
+}
+
+func (r *fakeRepository) UpdateRegistration(_ context.Context, registration *Registration) error {
+	r.registrations[registration.ID()] = registration
+	return nil
+}
+
+func (r *fakeRepository) DeleteRegistration(_ context.Context, id string) error {
+	delete(r.registrations, id)
+	return nil
+}
+
+func (r *fakeRepository) SaveCheckout(_ context.Context, checkout *Checkout) error {
+	r.checkouts[checkout.ID()] = checkout
+	return nil
+}
+
+func (r *fakeRepository) UpdateCheckout(_ context.Context, checkout *Checkout) error {
+	r.checkouts[checkout.ID()] = checkout
+	return nil
+}
+
+func (r *fakeRepository) FindCheckout(_ context.Context, id string) (*Checkout, error) {
+	checkout, ok := r.checkouts[id]
+	if !ok {
+		return nil, ErrCheckoutNotFound
+	}
+	return checkout, nil
+}
+
+func (r *fakeRepository) ListCheckouts(_ context.Context, invoiceID string) ([]*Checkout, error) {
+	var checkouts []*Checkout
+	for _, checkout := range r.checkouts {
+		if checkout.InvoiceID() == invoiceID {
+			checkouts = append(checkouts, checkout)
+		}
+	}
+	return checkouts, nil
+}
+
+type fakeProcessor struct {
+	requests []*CheckoutRequest
+	event    *Event
+	err      error
+}
+
+func (p *fakeProcessor) Name() string        { return NameCoinbaseCommerce }
+func (p *fakeProcessor) DisplayName() string { return "Coinbase Commerce" }
+
+func (p *fakeProcessor) CreateCheckout(
+	_ context.Context,
+	_ Credentials,
+	req *CheckoutRequest,
+) (*HostedCheckout, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	p.requests = append(p.requests, req)
+	return &HostedCheckout{ID: "charge-" + req.Reference, URL: "https://commerce.example/pay/" + req.Reference}, nil
+}
+
+func (p *fakeProcessor) ParseEvent(credentials Credentials, headers http.Header, _ []byte) (*Event, error) {
+	if headers.Get("Signature") != credentials.WebhookSecret {
+		return nil, ErrInvalidSignature
+	}
+	return p.event, nil
+}
+
+type fakeInvoiceService struct {
+	invoice.InvoiceService
+	invoice  *invoice.Invoice
+	credited []*payment.Payment
+}
+
+func (s *fakeInvoiceService) GetInvoice(_ context.Context, id string) (*invoice.Invoice, error) {
+	if id != s.invoice.ID() {
+		return nil, invoice.ErrInvoiceNotFound
+	}
+	return s.invoice, nil
+}
+
+func (s *fakeInvoiceService) MarkInvoiceAsViewed(context.Context, string) error {
+	s.invoice.SetStatus(invoice.StatusPending)
+	return nil
+}
+
+func (s *fakeInvoiceService) ProcessPayment(_ context.Context, _ string, p *payment.Payment) error {
+	s.credited = append(s.credited, p)
+	return nil
+}
+
+type fakePaymentService struct {
+	payment.PaymentService
+	payments      map[string]*payment.Payment
+	confirmations map[shared.PaymentID]int
+}
+
+func (s *fakePaymentService) CreatePayment(
+	_ context.Context,
+	req *payment.CreatePaymentRequest,
+) (*payment.Payment, error) {
+	// Payments are deduplicated by transaction hash, like the repository does
+	if existing, ok := s.payments[req.TransactionHash.String()]; ok {
+		return existing, nil
+	}
+	p, err := payment.NewPayment(req.ID, req.InvoiceID, req.Amount, req.FromAddress, req.ToAddress,
+		req.TransactionHash, req.RequiredConfirmations)
+	if err != nil {
+		return nil, err
+	}
+	s.payments[req.TransactionHash.String()] = p
+	return p, nil
+}
+
+func (s *fakePaymentService) UpdateBlockInfo(context.Context, shared.PaymentID, int64, string) error {
+	return nil
+}
+
+func (s *fakePaymentService) UpdateConfirmations(_ context.Context, id shared.PaymentID, count int) error {
+	s.confirmations[id] = count
+	return nil
+}
+
+func newTestInvoice(t *testing.T) *invoice.Invoice {
+	t.Helper()
+	unitPrice, err := shared.NewMoney("25.00", shared.CurrencyUSD)
+	require.NoError(t, err)
+	item, err := invoice.NewInvoiceItem("Order", "", "1", unitPrice)
+	require.NoError(t, err)
+	zero, err := shared.NewMoney("0", shared.CurrencyUSD)
+	require.NoError(t, err)
+	pricing, err := invoice.NewInvoicePricing(unitPrice, zero, unitPrice)
+	require.NoError(t, err)
+	address, err := shared.NewPaymentAddress("TTestAddress123456789012345678901234567890", shared.NetworkTron)
+	require.NoError(t, err)
+	rate, err := shared.NewExchangeRate("1.0", shared.CurrencyUSD, shared.CryptoCurrencyUSDT, "fixed", time.Hour)
+	require.NoError(t, err)
+	tolerance, err := invoice.NewPaymentTolerance("0.01", "1.0", invoice.OverpaymentActionCredit)
+	require.NoError(t, err)
+
+	inv, err := invoice.NewInvoice("invoice-1", "merchant-1", "Order", "", []*invoice.InvoiceItem{item}, pricing,
+		shared.CryptoCurrencyUSDT, address, rate, tolerance, invoice.NewInvoiceExpiration(time.Hour), nil)
+	require.NoError(t, err)
+	return inv
+}
+
+type testService struct {
+	Service
+	repository *fakeRepository
+	processor  *fakeProcessor
+	invoices   *fakeInvoiceService
+	payments   *fakePaymentService
+}
+
+func newTestService(t *testing.T) *testService {
+	t.Helper()
+	ts := &testService{
+		repository: newFakeRepository(),
+		processor:  &fakeProcessor{},
+		invoices:   &fakeInvoiceService{invoice: newTestInvoice(t)},
+		payments: &fakePaymentService{
+			payments:      map[string]*payment.Payment{},
+			confirmations: map[shared.PaymentID]int{},
+		},
+	}
+	ts.Service = NewService(ts.repository, NewRegistry(ts.processor), ts.invoices, ts.payments, nil, zap.NewNop())
+	return ts
+}
+
+func (ts *testService) register(t *testing.T) *Registration {
+	t.Helper()
+	registration, err := ts.RegisterProcessor(context.Background(), &RegisterRequest{
+		MerchantID:  "merchant-1",
+		Processor:   NameCoinbaseCommerce,
+		Credentials: Credentials{APIKey: "api-key", WebhookSecret: "webhook-secret"},
+	})
+	require.NoError(t, err)
+	return registration
+}
+
+func TestService_RegisterProcessor(t *testing.T) {
+	ts := newTestService(t)
+	ctx := context.Background()
+	registration := ts.register(t)
+	assert.True(t, registration.Enabled())
+
+	_, err := ts.RegisterProcessor(ctx, &RegisterRequest{
+		MerchantID:  "merchant-1",
+		Processor:   NameCoinbaseCommerce,
+		Credentials: Credentials{APIKey: "other", WebhookSecret: "other"},
+	})
+	require.ErrorIs(t, err, ErrAlreadyRegistered)
+
+	_, err = ts.RegisterProcessor(ctx, &RegisterRequest{
+		MerchantID:  "merchant-1",
+		Processor:   "paypal",
+		Credentials: Credentials{APIKey: "api-key", WebhookSecret: "webhook-secret"},
+	})
+	require.ErrorIs(t, err, ErrUnsupportedProcessor)
+
+	_, err = ts.GetRegistration(ctx, "merchant-2", registration.ID())
+	require.ErrorIs(t, err, ErrRegistrationNotFound)
+}
+
+func TestService_CheckoutOptions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("CreatesCheckoutsOnce", func(t *testing.T) {
+		ts := newTestService(t)
+		registration := ts.register(t)
+
+		options, err := ts.CheckoutOptions(ctx, ts.invoices.invoice)
+		require.NoError(t, err)
+		require.Len(t, options, 1)
+		assert.Equal(t, "Coinbase Commerce", options[0].DisplayName)
+		checkout := options[0].Checkout
+		assert.Equal(t, registration.ID(), checkout.RegistrationID())
+		assert.True(t, decimal.RequireFromString("25").Equal(checkout.Amount()))
+		assert.Equal(t, "USDT", checkout.Currency())
+		require.Len(t, ts.processor.requests, 1)
+		assert.Equal(t, checkout.ID(), ts.processor.requests[0].Reference)
+
+		again, err := ts.CheckoutOptions(ctx, ts.invoices.invoice)
+		require.NoError(t, err)
+		require.Len(t, again, 1)
+		assert.Equal(t, checkout.ID(), again[0].Checkout.ID())
+		assert.Len(t, ts.processor.requests, 1, "the open checkout is reused")
+	})
+
+	t.Run("SkipsDisabledAndFailingProcessors", func(t *testing.T) {
+		ts := newTestService(t)
+		registration := ts.register(t)
+		ts.processor.err = errors.New("service unavailable")
+
+		options, err := ts.CheckoutOptions(ctx, ts.invoices.invoice)
+		require.NoError(t, err)
+		assert.Empty(t, options)
+
+		ts.processor.err = nil
+		enabled := false
+		_, err = ts.UpdateRegistration(ctx, &UpdateRegistrationRequest{
+			MerchantID: "merchant-1", RegistrationID: registration.ID(), Enabled: &enabled,
+		})
+		require.NoError(t, err)
+		options, err = ts.CheckoutOptions(ctx, ts.invoices.invoice)
+		require.NoError(t, err)
+		assert.Empty(t, options)
+	})
+
+	t.Run("NoneForUnpayableInvoices", func(t *testing.T) {
+		ts := newTestService(t)
+		ts.register(t)
+		ts.invoices.invoice.SetStatus(invoice.StatusCancelled)
+
+		options, err := ts.CheckoutOptions(ctx, ts.invoices.invoice)
+		require.NoError(t, err)
+		assert.Empty(t, options)
+		assert.Empty(t, ts.processor.requests)
+	})
+}
+
+func TestService_HandleWebhook(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T) (*testService, *Registration, *Checkout) {
+		t.Helper()
+		ts := newTestService(t)
+		registration := ts.register(t)
+		options, err := ts.CheckoutOptions(ctx, ts.invoices.invoice)
+		require.NoError(t, err)
+		require.Len(t, options, 1)
+		return ts, registration, options[0].Checkout
+	}
+	webhook := func(registration *Registration, signature string) *WebhookRequest {
+		return &WebhookRequest{
+			Processor:      NameCoinbaseCommerce,
+			RegistrationID: registration.ID(),
+			Headers:        http.Header{"Signature": []string{signature}},
+			Body:           []byte(`{}`),
+		}
+	}
+
+	t.Run("RecordsPaidCheckoutOnce", func(t *testing.T) {
+		ts, registration, checkout := setup(t)
+		ts.processor.event = &Event{
+			Reference: checkout.ID(),
+			Status:    EventPaid,
+			Amount:    decimal.RequireFromString("25"),
+			Currency:  "USDT",
+			Payer:     "buyer@example.com",
+		}
+
+		paid, err := ts.HandleWebhook(ctx, webhook(registration, "webhook-secret"))
+		require.NoError(t, err)
+		assert.Equal(t, CheckoutPaid, paid.Status())
+		require.Len(t, ts.invoices.credited, 1)
+		p := ts.invoices.credited[0]
+		assert.Equal(t, NameCoinbaseCommerce+":buyer@example.com", p.FromAddress())
+		assert.Equal(t, TransactionHash(NameCoinbaseCommerce, checkout.ID()), p.TransactionHash().String())
+		assert.Equal(t, string(p.ID()), paid.PaymentID())
+		assert.Equal(t, 1, ts.payments.confirmations[p.ID()])
+
+		_, err = ts.HandleWebhook(ctx, webhook(registration, "webhook-secret"))
+		require.NoError(t, err)
+		assert.Len(t, ts.invoices.credited, 1, "a redelivered webhook is not credited again")
+	})
+
+	t.Run("RejectsInvalidSignatures", func(t *testing.T) {
+		ts, registration, checkout := setup(t)
+		ts.processor.event = &Event{Reference: checkout.ID(), Status: EventPaid}
+
+		_, err := ts.HandleWebhook(ctx, webhook(registration, "forged"))
+		require.ErrorIs(t, err, ErrInvalidSignature)
+		assert.Empty(t, ts.invoices.credited)
+	})
+
+	t.Run("RejectsOtherCurrencies", func(t *testing.T) {
+		ts, registration, checkout := setup(t)
+		ts.processor.event = &Event{
+			Reference: checkout.ID(),
+			Status:    EventPaid,
+			Amount:    decimal.RequireFromString("0.001"),
+			Currency:  "BTC",
+		}
+
+		_, err := ts.HandleWebhook(ctx, webhook(registration, "webhook-secret"))
+		require.ErrorIs(t, err, ErrInvalidEvent)
+		assert.Empty(t, ts.invoices.credited)
+	})
+
+	t.Run("ClosesFailedCheckouts", func(t *testing.T) {
+		ts, registration, checkout := setup(t)
+		ts.processor.event = &Event{Reference: checkout.ID(), Status: EventFailed}
+
+		closed, err := ts.HandleWebhook(ctx, webhook(registration, "webhook-secret"))
+		require.NoError(t, err)
+		assert.Equal(t, CheckoutFailed, closed.Status())
+		assert.Empty(t, ts.invoices.credited)
+	})
+
+	t.Run("RejectsOtherProcessors", func(t *testing.T) {
+		ts, registration, _ := setup(t)
+		req := webhook(registration, "webhook-secret")
+		req.Processor = NameBinancePay
+
+		_, err := ts.HandleWebhook(ctx, req)
+		require.ErrorIs(t, err, ErrRegistrationNotFound)
+	})
+}
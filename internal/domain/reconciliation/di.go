@@ -0,0 +1,17 @@
+package reconciliation
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the reconciliation service layer dependencies.
+var Module = fx.Module("reconciliation-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+		NewReconciler,
+	),
+	fx.Invoke(RegisterReconciler),
+)
@@ -0,0 +1,15 @@
+package reconciliation
+
+import "errors"
+
+// Domain errors for reconciliation operations
+var (
+	ErrReportNotFound = errors.New("reconciliation report not found")
+	ErrInvalidRequest = errors.New("invalid reconciliation request")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeReportNotFound = "RECONCILIATION_REPORT_NOT_FOUND"
+	ErrCodeInvalidRequest = "INVALID_RECONCILIATION_REQUEST"
+)
@@ -0,0 +1,98 @@
+package reconciliation
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// checkInterval is how often the reconciler checks whether the nightly reconciliation is due.
+const checkInterval = 5 * time.Minute
+
+// Reconciler runs the nightly reconciliation in the background.
+type Reconciler struct {
+	service  Service
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+// NewReconciler creates a new reconciler.
+func NewReconciler(service Service, logger *zap.Logger) *Reconciler {
+	return &Reconciler{
+		service:  service,
+		interval: checkInterval,
+		logger:   logger,
+	}
+}
+
+// Run reconciles whenever the nightly run is due until the context is cancelled.
+func (r *Reconciler) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		shared.Beat(ctx)
+		r.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick reconciles when the nightly run is due.
+func (r *Reconciler) tick(ctx context.Context) {
+	report, err := r.service.ReconcileIfDue(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.Error("Failed to reconcile ledger", zap.Error(err))
+		}
+		return
+	}
+	if report != nil && len(report.Discrepancies) > 0 {
+		r.logger.Warn("Reconciliation found discrepancies",
+			zap.String("report_id", report.ID),
+			zap.Int("discrepancies", len(report.Discrepancies)))
+	}
+}
+
+// RegisterReconciler runs the reconciler for the lifetime of the application.
+// Nothing is started unless the nightly reconciliation is enabled.
+func RegisterReconciler(
+	lc fx.Lifecycle,
+	reconciler *Reconciler,
+	policy Policy,
+	workers shared.WorkerSupervisor,
+	logger *zap.Logger,
+) {
+	if !policy.Enabled {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			logger.Info("Starting reconciler", zap.Int("hour", policy.Hour))
+			go func() {
+				defer close(done)
+				workers.Supervise(ctx, "reconciler", reconciler.interval, reconciler.Run)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
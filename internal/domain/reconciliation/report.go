@@ -0,0 +1,83 @@
+// Package reconciliation checks the platform's records against the chain and against each other: the balance each
+// deposit address should hold against what it holds on chain, and the payments each paid invoice received against
+// the settlements and reversal entries recorded for it.
+package reconciliation
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DiscrepancyKind represents what a discrepancy found by a reconciliation concerns.
+type DiscrepancyKind string
+
+const (
+	// KindAddressBalance - A deposit address holds a different balance on chain than its payments and sweeps leave
+	KindAddressBalance DiscrepancyKind = "address_balance"
+	// KindMissingSettlement - A paid invoice has no settlement
+	KindMissingSettlement DiscrepancyKind = "missing_settlement"
+	// KindSettlementAmount - The settlements of a paid invoice do not add up to the payments it received
+	KindSettlementAmount DiscrepancyKind = "settlement_amount"
+	// KindMissingReversal - A reversed settlement has no reversal entry offsetting it
+	KindMissingReversal DiscrepancyKind = "missing_reversal"
+)
+
+// Discrepancy is a mismatch between what the records say and what was found. Address balances are identified by
+// network and address, settlement mismatches by invoice and, for missing reversals, settlement.
+type Discrepancy struct {
+	Kind         DiscrepancyKind
+	Network      shared.BlockchainNetwork
+	Currency     string
+	Address      string
+	InvoiceID    string
+	SettlementID string
+	Expected     decimal.Decimal
+	Actual       decimal.Decimal
+}
+
+// Difference returns how much the actual amount exceeds the expected one, negative when it falls short.
+func (d Discrepancy) Difference() decimal.Decimal {
+	return d.Actual.Sub(d.Expected)
+}
+
+// Report is the outcome of a reconciliation run. Address balances are checked as of the run, and settlements for
+// the invoices paid in the period, which starts where the previous report's ended.
+type Report struct {
+	ID          string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	// AddressesChecked are the deposit addresses whose on-chain balance was compared; AddressesSkipped are those
+	// with payments or sweeps still confirming, or whose balance the custody failed to report.
+	AddressesChecked int
+	AddressesSkipped int
+	InvoicesChecked  int
+	Discrepancies    []Discrepancy
+	// Alerted is true when the discrepancies of a currency exceeded its threshold and an alert was raised.
+	Alerted   bool
+	CreatedAt time.Time
+}
+
+// Totals returns the sum of the absolute differences of the report's discrepancies by currency.
+func (r *Report) Totals() map[string]decimal.Decimal {
+	totals := make(map[string]decimal.Decimal)
+	for _, discrepancy := range r.Discrepancies {
+		totals[discrepancy.Currency] = totals[discrepancy.Currency].Add(discrepancy.Difference().Abs())
+	}
+	return totals
+}
+
+// ExceededCurrencies returns the currencies whose total difference exceeds their threshold, in alphabetical order.
+// A currency without a threshold exceeds it with any discrepancy.
+func (r *Report) ExceededCurrencies(thresholds map[string]decimal.Decimal) []string {
+	var exceeded []string
+	for currency, total := range r.Totals() {
+		if total.GreaterThan(thresholds[currency]) {
+			exceeded = append(exceeded, currency)
+		}
+	}
+	slices.Sort(exceeded)
+	return exceeded
+}
@@ -0,0 +1,71 @@
+package reconciliation
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Repository defines the interface for reconciliation report persistence.
+type Repository interface {
+	// Save persists a new report.
+	Save(ctx context.Context, report *Report) error
+
+	// FindByID retrieves a report by its ID.
+	FindByID(ctx context.Context, id string) (*Report, error)
+
+	// FindLatest retrieves the most recent report, or fails with ErrReportNotFound before the first one.
+	FindLatest(ctx context.Context) (*Report, error)
+
+	// List retrieves the latest reports, newest first.
+	List(ctx context.Context, limit int) ([]*Report, error)
+}
+
+// Ledger reads what the platform's records say the chain and the settlements should hold.
+type Ledger interface {
+	// ExpectedBalances returns the balance every deposit address that received funds should hold.
+	ExpectedBalances(ctx context.Context) ([]*ExpectedBalance, error)
+
+	// InvoiceSettlements returns the invoices paid in the period, from inclusive to exclusive, with the payments
+	// they received and the settlements recorded for them.
+	InvoiceSettlements(ctx context.Context, from, to time.Time) ([]*InvoiceSettlement, error)
+}
+
+// ExpectedBalance is what the records say a deposit address should hold of a currency: the confirmed payments and
+// unmatched deposits it received, less what sweeps took out of it together with their network fees when paid in
+// the same currency.
+type ExpectedBalance struct {
+	Network  shared.BlockchainNetwork
+	Currency string
+	Address  string
+	Received decimal.Decimal
+	Swept    decimal.Decimal
+	// Moving is true while a payment to the address is confirming or a sweep from it is broadcast, so its
+	// on-chain balance is about to change.
+	Moving bool
+}
+
+// Amount returns the balance the address should hold.
+func (b *ExpectedBalance) Amount() decimal.Decimal {
+	return b.Received.Sub(b.Swept)
+}
+
+// InvoiceSettlement is what a paid invoice received in confirmed payments and the settlements recorded for it,
+// including the beneficiaries' shares.
+type InvoiceSettlement struct {
+	InvoiceID   string
+	Currency    string
+	Received    decimal.Decimal
+	Settlements []SettlementEntry
+}
+
+// SettlementEntry is one settlement of a paid invoice.
+type SettlementEntry struct {
+	ID          string
+	GrossAmount decimal.Decimal
+	Reversed    bool
+	// Offset is true when a reversal entry offsets the reversed settlement.
+	Offset bool
+}
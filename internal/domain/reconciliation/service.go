@@ -0,0 +1,320 @@
+package reconciliation
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/treasury"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultListLimit is the default number of reports listed.
+	DefaultListLimit = 20
+	// MaxListLimit is the maximum number of reports listed.
+	MaxListLimit = 100
+	// firstPeriod is the period of the first report, which has no previous one to start from.
+	firstPeriod = 24 * time.Hour
+	// settlementGrace leaves invoices paid within it to the next report, as their settlement may not be recorded yet.
+	settlementGrace = time.Hour
+)
+
+// Policy configures the nightly reconciliation.
+type Policy struct {
+	// Enabled runs the reconciliation every night; reports can be run on demand either way.
+	Enabled bool
+	// Hour is the UTC hour of the day the reconciliation runs at.
+	Hour int
+	// Thresholds are the total differences by currency above which a report raises an alert. Currencies without
+	// a threshold alert on any discrepancy.
+	Thresholds map[string]decimal.Decimal
+}
+
+// DefaultPolicy reconciles at 02:00 UTC, alerting on any discrepancy.
+func DefaultPolicy() Policy {
+	return Policy{
+		Hour:       2,
+		Thresholds: map[string]decimal.Decimal{},
+	}
+}
+
+// ScheduledAt returns the time of the latest nightly run due at or before now.
+func (p Policy) ScheduledAt(now time.Time) time.Time {
+	now = now.UTC()
+	scheduled := time.Date(now.Year(), now.Month(), now.Day(), p.Hour, 0, 0, 0, time.UTC)
+	if scheduled.After(now) {
+		scheduled = scheduled.AddDate(0, 0, -1)
+	}
+	return scheduled
+}
+
+// Service defines the interface for reconciliation operations.
+type Service interface {
+	// Reconcile checks the address balances and the settlements of the invoices paid since the previous report,
+	// saves the report and raises an alert when the discrepancies of a currency exceed its threshold.
+	Reconcile(ctx context.Context) (*Report, error)
+
+	// ReconcileIfDue reconciles when no report was made since the latest scheduled run. It returns nil when
+	// the reconciliation is not due.
+	ReconcileIfDue(ctx context.Context) (*Report, error)
+
+	// GetReport retrieves a report.
+	GetReport(ctx context.Context, id string) (*Report, error)
+
+	// ListReports lists the latest reports, newest first.
+	ListReports(ctx context.Context, limit int) ([]*Report, error)
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository Repository
+	ledger     Ledger
+	custody    treasury.Custody
+	policy     Policy
+	eventBus   shared.EventBus
+	logger     *zap.Logger
+}
+
+// NewService creates a new reconciliation service. The custody may be nil, in which case address balances are
+// not checked.
+func NewService(
+	repository Repository,
+	ledger Ledger,
+	custody treasury.Custody,
+	policy Policy,
+	eventBus shared.EventBus,
+	logger *zap.Logger,
+) Service {
+	if policy.Thresholds == nil {
+		policy.Thresholds = map[string]decimal.Decimal{}
+	}
+
+	return &ServiceImpl{
+		repository: repository,
+		ledger:     ledger,
+		custody:    custody,
+		policy:     policy,
+		eventBus:   eventBus,
+		logger:     logger,
+	}
+}
+
+// Reconcile runs a reconciliation and saves its report.
+func (s *ServiceImpl) Reconcile(ctx context.Context) (*Report, error) {
+	now := shared.Now().UTC()
+	report := &Report{
+		ID:        uuid.New().String(),
+		PeriodEnd: now.Add(-settlementGrace),
+		CreatedAt: now,
+	}
+	report.PeriodStart = report.PeriodEnd.Add(-firstPeriod)
+
+	latest, err := s.repository.FindLatest(ctx)
+	switch {
+	case err == nil:
+		report.PeriodStart = latest.PeriodEnd
+		if report.PeriodStart.After(report.PeriodEnd) {
+			report.PeriodStart = report.PeriodEnd
+		}
+	case !errors.Is(err, ErrReportNotFound):
+		return nil, fmt.Errorf("failed to find the latest report: %w", err)
+	}
+
+	if err := s.checkAddresses(ctx, report); err != nil {
+		return nil, err
+	}
+	if err := s.checkSettlements(ctx, report); err != nil {
+		return nil, err
+	}
+
+	exceeded := report.ExceededCurrencies(s.policy.Thresholds)
+	report.Alerted = len(exceeded) > 0
+
+	if err := s.repository.Save(ctx, report); err != nil {
+		return nil, fmt.Errorf("failed to save report: %w", err)
+	}
+
+	if report.Alerted {
+		s.alert(ctx, report, exceeded)
+	}
+	s.logger.Info("Reconciled ledger",
+		zap.String("report_id", report.ID),
+		zap.Int("addresses_checked", report.AddressesChecked),
+		zap.Int("addresses_skipped", report.AddressesSkipped),
+		zap.Int("invoices_checked", report.InvoicesChecked),
+		zap.Int("discrepancies", len(report.Discrepancies)))
+	return report, nil
+}
+
+// ReconcileIfDue reconciles when the latest report predates the latest scheduled run.
+func (s *ServiceImpl) ReconcileIfDue(ctx context.Context) (*Report, error) {
+	latest, err := s.repository.FindLatest(ctx)
+	switch {
+	case err == nil:
+		if !latest.CreatedAt.Before(s.policy.ScheduledAt(shared.Now())) {
+			return nil, nil
+		}
+	case !errors.Is(err, ErrReportNotFound):
+		return nil, fmt.Errorf("failed to find the latest report: %w", err)
+	}
+	return s.Reconcile(ctx)
+}
+
+// GetReport retrieves a report.
+func (s *ServiceImpl) GetReport(ctx context.Context, id string) (*Report, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: report ID is required", ErrInvalidRequest)
+	}
+	return s.repository.FindByID(ctx, id)
+}
+
+// ListReports lists the latest reports.
+func (s *ServiceImpl) ListReports(ctx context.Context, limit int) ([]*Report, error) {
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	if limit > MaxListLimit {
+		return nil, fmt.Errorf("%w: limit cannot exceed %d", ErrInvalidRequest, MaxListLimit)
+	}
+	return s.repository.List(ctx, limit)
+}
+
+// checkAddresses compares the balance each deposit address should hold with its on-chain balance. Addresses
+// whose balance is about to change, or that the custody fails to report, are skipped until the next report.
+func (s *ServiceImpl) checkAddresses(ctx context.Context, report *Report) error {
+	if s.custody == nil {
+		return nil
+	}
+
+	balances, err := s.ledger.ExpectedBalances(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to derive expected balances: %w", err)
+	}
+
+	for _, balance := range balances {
+		if balance.Moving {
+			report.AddressesSkipped++
+			continue
+		}
+
+		actual, err := s.custody.Balance(ctx, &treasury.BalanceRequest{
+			Network:  balance.Network,
+			Currency: balance.Currency,
+			Address:  balance.Address,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			s.logger.Warn("Failed to read deposit address balance",
+				zap.String("network", string(balance.Network)),
+				zap.String("address", balance.Address),
+				zap.Error(err))
+			report.AddressesSkipped++
+			continue
+		}
+
+		report.AddressesChecked++
+		if expected := balance.Amount(); !actual.Equal(expected) {
+			report.Discrepancies = append(report.Discrepancies, Discrepancy{
+				Kind:     KindAddressBalance,
+				Network:  balance.Network,
+				Currency: balance.Currency,
+				Address:  balance.Address,
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+	return nil
+}
+
+// checkSettlements compares what each invoice paid in the period received with its settlements, and checks
+// every reversed settlement is offset by a reversal entry.
+func (s *ServiceImpl) checkSettlements(ctx context.Context, report *Report) error {
+	invoices, err := s.ledger.InvoiceSettlements(ctx, report.PeriodStart, report.PeriodEnd)
+	if err != nil {
+		return fmt.Errorf("failed to read invoice settlements: %w", err)
+	}
+
+	for _, inv := range invoices {
+		report.InvoicesChecked++
+
+		settled := decimal.Zero
+		active := 0
+		for _, entry := range inv.Settlements {
+			if !entry.Reversed {
+				settled = settled.Add(entry.GrossAmount)
+				active++
+				continue
+			}
+			if !entry.Offset {
+				report.Discrepancies = append(report.Discrepancies, Discrepancy{
+					Kind:         KindMissingReversal,
+					Currency:     inv.Currency,
+					InvoiceID:    inv.InvoiceID,
+					SettlementID: entry.ID,
+					Expected:     entry.GrossAmount.Neg(),
+					Actual:       decimal.Zero,
+				})
+			}
+		}
+
+		switch {
+		case active == 0 && len(inv.Settlements) == 0:
+			report.Discrepancies = append(report.Discrepancies, Discrepancy{
+				Kind:      KindMissingSettlement,
+				Currency:  inv.Currency,
+				InvoiceID: inv.InvoiceID,
+				Expected:  inv.Received,
+				Actual:    decimal.Zero,
+			})
+		case active > 0 && !settled.Equal(inv.Received):
+			report.Discrepancies = append(report.Discrepancies, Discrepancy{
+				Kind:      KindSettlementAmount,
+				Currency:  inv.Currency,
+				InvoiceID: inv.InvoiceID,
+				Expected:  inv.Received,
+				Actual:    settled,
+			})
+		}
+	}
+	return nil
+}
+
+// alert logs and publishes a report whose discrepancies exceeded the thresholds of some currencies.
+func (s *ServiceImpl) alert(ctx context.Context, report *Report, currencies []string) {
+	totals := make(map[string]string)
+	for currency, total := range report.Totals() {
+		totals[currency] = total.String()
+	}
+
+	s.logger.Error("Reconciliation discrepancies exceed their threshold",
+		zap.String("report_id", report.ID),
+		zap.Strings("currencies", currencies),
+		zap.Any("totals", totals),
+		zap.Int("discrepancies", len(report.Discrepancies)))
+
+	if s.eventBus == nil {
+		return
+	}
+	event := shared.NewReconciliationAlertEvent(shared.ReconciliationEvent{
+		ReportID:      report.ID,
+		PeriodStart:   report.PeriodStart,
+		PeriodEnd:     report.PeriodEnd,
+		Discrepancies: len(report.Discrepancies),
+		Currencies:    currencies,
+		Totals:        totals,
+	})
+	if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+		s.logger.Error("Failed to publish domain event",
+			zap.String("event_type", event.EventType),
+			zap.String("aggregate_id", event.AggregateID),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,244 @@
+package reconciliation
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/treasury"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubRepository keeps reports in memory, oldest first.
+type stubRepository struct {
+	reports []*Report
+}
+
+func (r *stubRepository) Save(_ context.Context, report *Report) error {
+	r.reports = append(r.reports, report)
+	return nil
+}
+
+func (r *stubRepository) FindByID(_ context.Context, id string) (*Report, error) {
+	for _, report := range r.reports {
+		if report.ID == id {
+			return report, nil
+		}
+	}
+	return nil, ErrReportNotFound
+}
+
+func (r *stubRepository) FindLatest(_ context.Context) (*Report, error) {
+	if len(r.reports) == 0 {
+		return nil, ErrReportNotFound
+	}
+	return r.reports[len(r.reports)-1], nil
+}
+
+func (r *stubRepository) List(_ context.Context, limit int) ([]*Report, error) {
+	var reports []*Report
+	for i := len(r.reports) - 1; i >= 0 && len(reports) < limit; i-- {
+		reports = append(reports, r.reports[i])
+	}
+	return reports, nil
+}
+
+// stubLedger returns fixed records and remembers the settlement period asked for.
+type stubLedger struct {
+	balances []*ExpectedBalance
+	invoices []*InvoiceSettlement
+	from, to time.Time
+}
+
+func (l *stubLedger) ExpectedBalances(context.Context) ([]*ExpectedBalance, error) {
+	return l.balances, nil
+}
+
+func (l *stubLedger) InvoiceSettlements(_ context.Context, from, to time.Time) ([]*InvoiceSettlement, error) {
+	l.from, l.to = from, to
+	return l.invoices, nil
+}
+
+// stubCustody reports on-chain balances by address; addresses without one fail. Only Balance is implemented.
+type stubCustody struct {
+	treasury.Custody
+	balances map[string]decimal.Decimal
+}
+
+func (c *stubCustody) Balance(_ context.Context, req *treasury.BalanceRequest) (decimal.Decimal, error) {
+	balance, ok := c.balances[req.Address]
+	if !ok {
+		return decimal.Zero, errors.New("node unavailable")
+	}
+	return balance, nil
+}
+
+// recordingEventBus records the events published; only publishing is implemented.
+type recordingEventBus struct {
+	shared.EventBus
+	events []*shared.BaseDomainEvent
+}
+
+func (b *recordingEventBus) PublishEvent(_ context.Context, event *shared.BaseDomainEvent) error {
+	b.events = append(b.events, event)
+	return nil
+}
+
+// fixedClock tells a fixed time.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+func amount(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
+func TestServiceReconcile(t *testing.T) {
+	now := time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC)
+	t.Cleanup(shared.SetClock(fixedClock(now)))
+
+	repo := &stubRepository{}
+	ledger := &stubLedger{
+		balances: []*ExpectedBalance{
+			{Network: shared.NetworkTron, Currency: "USDT", Address: "TMatch", Received: amount("100"), Swept: amount("40")},
+			{Network: shared.NetworkTron, Currency: "USDT", Address: "TShort", Received: amount("50")},
+			{Network: shared.NetworkTron, Currency: "USDT", Address: "TMoving", Received: amount("10"), Moving: true},
+			{Network: shared.NetworkTron, Currency: "USDT", Address: "TDown", Received: amount("5")},
+		},
+		invoices: []*InvoiceSettlement{
+			{InvoiceID: "inv-ok", Currency: "USDT", Received: amount("30"), Settlements: []SettlementEntry{
+				{ID: "stl-1", GrossAmount: amount("20")},
+				{ID: "stl-2", GrossAmount: amount("10")},
+			}},
+			{InvoiceID: "inv-unsettled", Currency: "USDT", Received: amount("12")},
+			{InvoiceID: "inv-short", Currency: "BTC", Received: amount("0.5"), Settlements: []SettlementEntry{
+				{ID: "stl-3", GrossAmount: amount("0.4")},
+			}},
+			{InvoiceID: "inv-reversed", Currency: "USDT", Received: amount("8"), Settlements: []SettlementEntry{
+				{ID: "stl-4", GrossAmount: amount("8"), Reversed: true},
+			}},
+		},
+	}
+	custody := &stubCustody{balances: map[string]decimal.Decimal{
+		"TMatch": amount("60"),
+		"TShort": amount("45"),
+	}}
+	bus := &recordingEventBus{}
+	policy := DefaultPolicy()
+	policy.Thresholds = map[string]decimal.Decimal{"USDT": amount("20"), "BTC": amount("1")}
+	service := NewService(repo, ledger, custody, policy, bus, zap.NewNop())
+
+	report, err := service.Reconcile(context.Background())
+	require.NoError(t, err)
+
+	// The first report covers the day before the settlement grace period
+	assert.Equal(t, now.Add(-25*time.Hour), report.PeriodStart)
+	assert.Equal(t, now.Add(-time.Hour), report.PeriodEnd)
+	assert.Equal(t, ledger.from, report.PeriodStart)
+	assert.Equal(t, 2, report.AddressesChecked)
+	assert.Equal(t, 2, report.AddressesSkipped)
+	assert.Equal(t, 4, report.InvoicesChecked)
+
+	require.Len(t, report.Discrepancies, 4)
+	kinds := make(map[DiscrepancyKind]Discrepancy)
+	for _, d := range report.Discrepancies {
+		kinds[d.Kind] = d
+	}
+	assert.Equal(t, "TShort", kinds[KindAddressBalance].Address)
+	assert.Equal(t, "-5", kinds[KindAddressBalance].Difference().String())
+	assert.Equal(t, "inv-unsettled", kinds[KindMissingSettlement].InvoiceID)
+	assert.Equal(t, "-0.1", kinds[KindSettlementAmount].Difference().String())
+	assert.Equal(t, "stl-4", kinds[KindMissingReversal].SettlementID)
+
+	// USDT discrepancies add up to 5 + 12 + 8, over its threshold; BTC stays under
+	assert.Equal(t, "25", report.Totals()["USDT"].String())
+	assert.True(t, report.Alerted)
+	require.Len(t, bus.events, 1)
+	assert.Equal(t, shared.EventTypeReconciliationAlert, bus.events[0].EventType)
+	assert.Equal(t, []string{"USDT"}, bus.events[0].EventData.(shared.ReconciliationEvent).Currencies)
+
+	// The next report starts where this one ended
+	next, err := service.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, report.PeriodEnd, next.PeriodStart)
+	require.Len(t, repo.reports, 2)
+}
+
+func TestServiceReconcileBelowThreshold(t *testing.T) {
+	ledger := &stubLedger{invoices: []*InvoiceSettlement{
+		{InvoiceID: "inv-1", Currency: "USDT", Received: amount("10"), Settlements: []SettlementEntry{
+			{ID: "stl-1", GrossAmount: amount("9.99")},
+		}},
+	}}
+	bus := &recordingEventBus{}
+	policy := DefaultPolicy()
+	policy.Thresholds["USDT"] = amount("0.01")
+	service := NewService(&stubRepository{}, ledger, nil, policy, bus, zap.NewNop())
+
+	report, err := service.Reconcile(context.Background())
+	require.NoError(t, err)
+	// Without custody, addresses are not checked
+	assert.Zero(t, report.AddressesChecked)
+	require.Len(t, report.Discrepancies, 1)
+	assert.False(t, report.Alerted)
+	assert.Empty(t, bus.events)
+}
+
+func TestServiceReconcileIfDue(t *testing.T) {
+	repo := &stubRepository{}
+	service := NewService(repo, &stubLedger{}, nil, DefaultPolicy(), nil, zap.NewNop())
+	ctx := context.Background()
+
+	restore := shared.SetClock(fixedClock(time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC)))
+	report, err := service.ReconcileIfDue(ctx)
+	restore()
+	require.NoError(t, err)
+	require.NotNil(t, report, "the first reconciliation is always due")
+
+	// Yesterday's 02:00 run is covered until today's
+	restore = shared.SetClock(fixedClock(time.Date(2026, 10, 17, 1, 55, 0, 0, time.UTC)))
+	report, err = service.ReconcileIfDue(ctx)
+	restore()
+	require.NoError(t, err)
+	assert.Nil(t, report)
+
+	restore = shared.SetClock(fixedClock(time.Date(2026, 10, 17, 2, 5, 0, 0, time.UTC)))
+	report, err = service.ReconcileIfDue(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, report)
+	report, err = service.ReconcileIfDue(ctx)
+	restore()
+	require.NoError(t, err)
+	assert.Nil(t, report)
+	assert.Len(t, repo.reports, 2)
+}
+
+func TestServiceListReports(t *testing.T) {
+	service := NewService(&stubRepository{}, &stubLedger{}, nil, DefaultPolicy(), nil, zap.NewNop())
+	ctx := context.Background()
+
+	_, err := service.ListReports(ctx, MaxListLimit+1)
+	require.ErrorIs(t, err, ErrInvalidRequest)
+
+	_, err = service.GetReport(ctx, "")
+	require.ErrorIs(t, err, ErrInvalidRequest)
+	_, err = service.GetReport(ctx, "missing")
+	require.ErrorIs(t, err, ErrReportNotFound)
+}
+
+func TestReportExceededCurrencies(t *testing.T) {
+	report := &Report{Discrepancies: []Discrepancy{
+		{Currency: "USDT", Expected: amount("10"), Actual: amount("9")},
+		{Currency: "USDT", Expected: amount("10"), Actual: amount("11")},
+		{Currency: "ETH", Expected: amount("1"), Actual: amount("1.5")},
+	}}
+
+	assert.Equal(t, "2", report.Totals()["USDT"].String())
+	assert.Equal(t, []string{"ETH", "USDT"}, report.ExceededCurrencies(nil))
+	assert.Equal(t, []string{"ETH"}, report.ExceededCurrencies(map[string]decimal.Decimal{"USDT": amount("2")}))
+}
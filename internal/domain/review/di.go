@@ -0,0 +1,23 @@
+package review
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+
+	"go.uber.org/fx"
+)
+
+// Module provides the review service layer dependencies.
+var Module = fx.Module("review-service",
+	fx.Provide(
+		fx.Annotate(
+			NewQueue,
+			fx.As(new(payment.ReviewQueue)),
+			fx.As(new(invoice.ReviewQueue)),
+		),
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+)
@@ -0,0 +1,65 @@
+// Package review provides the queue of payments that need operator action, such as underpayments
+// outside tolerance, payments held by compliance screening, orphaned payments and payments that arrived
+// after the invoice's exchange rate expired.
+package review
+
+// Kind identifies why a payment needs operator action.
+type Kind string
+
+const (
+	// KindUnderpayment - Payment fell short of the invoice beyond its underpayment tolerance
+	KindUnderpayment Kind = "underpayment"
+	// KindScreening - Payment was held by AML/KYT screening or the address blocklist
+	KindScreening Kind = "screening"
+	// KindOrphaned - Payment transaction dropped out of the chain after a reorganization
+	KindOrphaned Kind = "orphaned"
+	// KindStaleRate - Payment arrived after the invoice's exchange rate expired and the rate could not be honoured
+	KindStaleRate Kind = "stale_rate"
+)
+
+// IsValid returns true if the review kind is valid.
+func (k Kind) IsValid() bool {
+	switch k {
+	case KindUnderpayment, KindScreening, KindOrphaned, KindStaleRate:
+		return true
+	default:
+		return false
+	}
+}
+
+// Status represents whether a review still awaits an operator.
+type Status string
+
+const (
+	// StatusOpen - Review awaits operator action
+	StatusOpen Status = "open"
+	// StatusResolved - Operator resolved the review
+	StatusResolved Status = "resolved"
+)
+
+// IsValid returns true if the review status is valid.
+func (s Status) IsValid() bool {
+	return s == StatusOpen || s == StatusResolved
+}
+
+// Resolution is the operator's decision on a review.
+type Resolution string
+
+const (
+	// ResolutionAcceptAsPaid - Settle the invoice with the funds received
+	ResolutionAcceptAsPaid Resolution = "accept_as_paid"
+	// ResolutionRefund - Fail the payment, close the invoice and request a refund to the sender
+	ResolutionRefund Resolution = "refund"
+	// ResolutionWriteOff - Fail the payment and close the invoice without a refund
+	ResolutionWriteOff Resolution = "write_off"
+)
+
+// IsValid returns true if the resolution is valid.
+func (r Resolution) IsValid() bool {
+	switch r {
+	case ResolutionAcceptAsPaid, ResolutionRefund, ResolutionWriteOff:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,18 @@
+package review
+
+import "errors"
+
+// Domain errors for review operations
+var (
+	ErrReviewNotFound       = errors.New("review not found")
+	ErrReviewResolved       = errors.New("review is already resolved")
+	ErrInvalidRequest       = errors.New("invalid review request")
+	ErrResolutionNotAllowed = errors.New("resolution is not possible in the current invoice or payment state")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeReviewNotFound       = "REVIEW_NOT_FOUND"
+	ErrCodeReviewResolved       = "REVIEW_RESOLVED"
+	ErrCodeResolutionNotAllowed = "RESOLUTION_NOT_ALLOWED"
+)
@@ -0,0 +1,163 @@
+package review
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Queue opens reviews for payments that need operator action.
+// It implements payment.ReviewQueue and invoice.ReviewQueue.
+type Queue struct {
+	repository  Repository
+	invoiceRepo invoice.Repository
+	logger      *zap.Logger
+}
+
+// NewQueue creates a new review queue.
+func NewQueue(repository Repository, invoiceRepo invoice.Repository, logger *zap.Logger) *Queue {
+	return &Queue{
+		repository:  repository,
+		invoiceRepo: invoiceRepo,
+		logger:      logger,
+	}
+}
+
+// EnqueueUnderpayment opens a review for a payment below the invoice's underpayment tolerance.
+func (q *Queue) EnqueueUnderpayment(
+	ctx context.Context,
+	inv *invoice.Invoice,
+	p *payment.Payment,
+	required *shared.Money,
+) error {
+	if inv == nil || p == nil {
+		return nil
+	}
+
+	expected := ""
+	if required != nil {
+		expected = required.String()
+	}
+
+	return q.enqueue(ctx, KindUnderpayment, inv.MerchantID(), p, expected,
+		fmt.Sprintf("received %s, invoice requires %s", p.Amount().Amount().String(), expected))
+}
+
+// EnqueueStaleRate opens a review for a payment that arrived after the invoice's exchange rate expired.
+func (q *Queue) EnqueueStaleRate(
+	ctx context.Context,
+	inv *invoice.Invoice,
+	p *payment.Payment,
+	required *shared.Money,
+	reason string,
+) error {
+	if inv == nil || p == nil {
+		return nil
+	}
+
+	expected := ""
+	if required != nil {
+		expected = required.String()
+	}
+
+	return q.enqueue(ctx, KindStaleRate, inv.MerchantID(), p, expected,
+		fmt.Sprintf("received %s after the exchange rate expired: %s", p.Amount().Amount().String(), reason))
+}
+
+// EnqueueHeldPayment opens a review for a payment held by compliance screening.
+func (q *Queue) EnqueueHeldPayment(ctx context.Context, p *payment.Payment) error {
+	if p == nil {
+		return nil
+	}
+
+	merchantID, expected := q.lookupInvoice(ctx, p)
+	return q.enqueue(ctx, KindScreening, merchantID, p, expected, "payment held by compliance screening")
+}
+
+// EnqueueOrphanedPayment opens a review for a payment orphaned by a chain reorganization.
+func (q *Queue) EnqueueOrphanedPayment(ctx context.Context, p *payment.Payment) error {
+	if p == nil {
+		return nil
+	}
+
+	merchantID, expected := q.lookupInvoice(ctx, p)
+	return q.enqueue(ctx, KindOrphaned, merchantID, p, expected,
+		"payment transaction dropped out of the chain after a reorganization")
+}
+
+// enqueue saves a new open review unless one is already open for the payment.
+func (q *Queue) enqueue(
+	ctx context.Context,
+	kind Kind,
+	merchantID string,
+	p *payment.Payment,
+	expected, reason string,
+) error {
+	paymentID := string(p.ID())
+
+	existing, err := q.repository.FindOpenByPayment(ctx, kind, paymentID)
+	if err != nil && !errors.Is(err, ErrReviewNotFound) {
+		return fmt.Errorf("failed to check for open review: %w", err)
+	}
+	if existing != nil {
+		return nil
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return fmt.Errorf("failed to generate review ID: %w", err)
+	}
+
+	review, err := NewReview(
+		id, merchantID, kind, string(p.InvoiceID()), paymentID, expected,
+		p.Amount().Amount().String(), p.Amount().Currency().String(), reason,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create review: %w", err)
+	}
+
+	if err := q.repository.Save(ctx, review); err != nil {
+		return fmt.Errorf("failed to save review: %w", err)
+	}
+
+	q.logger.Info("Payment queued for review",
+		zap.String("review_id", review.ID()),
+		zap.String("kind", string(kind)),
+		zap.String("payment_id", paymentID),
+	)
+	return nil
+}
+
+// lookupInvoice resolves the merchant and required amount of the payment's invoice.
+// Reviews are still opened when the invoice cannot be loaded, so no payment is lost.
+func (q *Queue) lookupInvoice(ctx context.Context, p *payment.Payment) (string, string) {
+	inv, err := q.invoiceRepo.FindByID(ctx, string(p.InvoiceID()))
+	if err != nil {
+		q.logger.Warn("Failed to load invoice for review",
+			zap.String("payment_id", string(p.ID())),
+			zap.Error(err),
+		)
+		return "", ""
+	}
+
+	expected := ""
+	if required, err := inv.LockedCryptoAmount(); err == nil && required != nil {
+		expected = required.String()
+	}
+	return inv.MerchantID(), expected
+}
+
+func generateID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
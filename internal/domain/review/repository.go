@@ -0,0 +1,39 @@
+package review
+
+import "context"
+
+// Repository defines the interface for review persistence.
+type Repository interface {
+	// Save persists a new review.
+	Save(ctx context.Context, review *Review) error
+
+	// FindByID retrieves a review by its ID.
+	FindByID(ctx context.Context, id string) (*Review, error)
+
+	// FindOpenByPayment retrieves the open review of the given kind for a payment.
+	FindOpenByPayment(ctx context.Context, kind Kind, paymentID string) (*Review, error)
+
+	// List retrieves reviews matching the filter, oldest first so the queue is worked in order.
+	List(ctx context.Context, req *ListReviewsRequest) (*ListReviewsResponse, error)
+
+	// Update updates an existing review.
+	Update(ctx context.Context, review *Review) error
+}
+
+// ListReviewsRequest represents the request to list reviews.
+// Empty filter fields match all reviews.
+type ListReviewsRequest struct {
+	MerchantID string
+	Kind       Kind
+	Status     Status
+	Limit      int
+	Offset     int
+}
+
+// ListReviewsResponse represents the response from listing reviews.
+type ListReviewsResponse struct {
+	Reviews []*Review
+	Total   int
+	Limit   int
+	Offset  int
+}
@@ -0,0 +1,182 @@
+package review
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"time"
+)
+
+// Review is a payment that needs an operator decision before its invoice can proceed.
+type Review struct {
+	id             string
+	merchantID     string
+	kind           Kind
+	status         Status
+	invoiceID      string
+	paymentID      string
+	expectedAmount string
+	receivedAmount string
+	currency       string
+	reason         string
+	resolution     Resolution
+	resolvedBy     string
+	note           string
+	createdAt      time.Time
+	resolvedAt     *time.Time
+}
+
+// NewReview creates a new open review.
+func NewReview(
+	id, merchantID string,
+	kind Kind,
+	invoiceID, paymentID, expectedAmount, receivedAmount, currency, reason string,
+) (*Review, error) {
+	return RestoreReview(
+		id, merchantID, kind, StatusOpen, invoiceID, paymentID, expectedAmount, receivedAmount, currency, reason,
+		"", "", "", shared.Now().UTC(), nil,
+	)
+}
+
+// RestoreReview recreates a review from storage.
+func RestoreReview(
+	id, merchantID string,
+	kind Kind,
+	status Status,
+	invoiceID, paymentID, expectedAmount, receivedAmount, currency, reason string,
+	resolution Resolution,
+	resolvedBy, note string,
+	createdAt time.Time,
+	resolvedAt *time.Time,
+) (*Review, error) {
+	if id == "" {
+		return nil, errors.New("review ID is required")
+	}
+	if paymentID == "" {
+		return nil, errors.New("payment ID is required")
+	}
+	if !kind.IsValid() {
+		return nil, errors.New("invalid review kind")
+	}
+	if !status.IsValid() {
+		return nil, errors.New("invalid review status")
+	}
+	if resolution != "" && !resolution.IsValid() {
+		return nil, errors.New("invalid resolution")
+	}
+
+	return &Review{
+		id:             id,
+		merchantID:     merchantID,
+		kind:           kind,
+		status:         status,
+		invoiceID:      invoiceID,
+		paymentID:      paymentID,
+		expectedAmount: expectedAmount,
+		receivedAmount: receivedAmount,
+		currency:       currency,
+		reason:         reason,
+		resolution:     resolution,
+		resolvedBy:     resolvedBy,
+		note:           note,
+		createdAt:      createdAt,
+		resolvedAt:     resolvedAt,
+	}, nil
+}
+
+// ID returns the review ID.
+func (r *Review) ID() string {
+	return r.id
+}
+
+// MerchantID returns the merchant that received the payment.
+func (r *Review) MerchantID() string {
+	return r.merchantID
+}
+
+// Kind returns why the payment needs operator action.
+func (r *Review) Kind() Kind {
+	return r.kind
+}
+
+// Status returns whether the review is open or resolved.
+func (r *Review) Status() Status {
+	return r.status
+}
+
+// InvoiceID returns the invoice the payment was made against.
+func (r *Review) InvoiceID() string {
+	return r.invoiceID
+}
+
+// PaymentID returns the payment under review.
+func (r *Review) PaymentID() string {
+	return r.paymentID
+}
+
+// ExpectedAmount returns the amount the invoice required, if known.
+func (r *Review) ExpectedAmount() string {
+	return r.expectedAmount
+}
+
+// ReceivedAmount returns the amount the payment carried.
+func (r *Review) ReceivedAmount() string {
+	return r.receivedAmount
+}
+
+// Currency returns the cryptocurrency of the amounts.
+func (r *Review) Currency() string {
+	return r.currency
+}
+
+// Reason returns a description of why the review was opened.
+func (r *Review) Reason() string {
+	return r.reason
+}
+
+// Resolution returns the operator's decision, if resolved.
+func (r *Review) Resolution() Resolution {
+	return r.resolution
+}
+
+// ResolvedBy returns who resolved the review.
+func (r *Review) ResolvedBy() string {
+	return r.resolvedBy
+}
+
+// Note returns the operator's note.
+func (r *Review) Note() string {
+	return r.note
+}
+
+// CreatedAt returns when the review was opened.
+func (r *Review) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// ResolvedAt returns when the review was resolved.
+func (r *Review) ResolvedAt() *time.Time {
+	return r.resolvedAt
+}
+
+// IsOpen returns true if the review awaits operator action.
+func (r *Review) IsOpen() bool {
+	return r.status == StatusOpen
+}
+
+// Resolve records the operator's decision.
+func (r *Review) Resolve(resolution Resolution, resolvedBy, note string) error {
+	if !r.IsOpen() {
+		return ErrReviewResolved
+	}
+	if !resolution.IsValid() {
+		return ErrInvalidRequest
+	}
+
+	now := shared.Now().UTC()
+	r.status = StatusResolved
+	r.resolution = resolution
+	r.resolvedBy = resolvedBy
+	r.note = note
+	r.resolvedAt = &now
+	return nil
+}
@@ -0,0 +1,67 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolution_IsValid(t *testing.T) {
+	tests := []struct {
+		name       string
+		resolution Resolution
+		expected   bool
+	}{
+		{"accept as paid", ResolutionAcceptAsPaid, true},
+		{"refund", ResolutionRefund, true},
+		{"write off", ResolutionWriteOff, true},
+		{"invalid", Resolution("ignore"), false},
+		{"empty", Resolution(""), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.resolution.IsValid())
+		})
+	}
+}
+
+func TestKind_IsValid(t *testing.T) {
+	assert.True(t, KindUnderpayment.IsValid())
+	assert.True(t, KindScreening.IsValid())
+	assert.True(t, KindOrphaned.IsValid())
+	assert.True(t, KindStaleRate.IsValid())
+	assert.False(t, Kind("dispute").IsValid())
+}
+
+func TestReview_Resolve(t *testing.T) {
+	review, err := NewReview(
+		"review-1", "merchant-1", KindUnderpayment, "invoice-1", "payment-1", "10.00", "8.50", "USDT", "short",
+	)
+	require.NoError(t, err)
+	assert.True(t, review.IsOpen())
+	assert.Nil(t, review.ResolvedAt())
+
+	require.ErrorIs(t, review.Resolve(Resolution("ignore"), "user-1", ""), ErrInvalidRequest)
+	assert.True(t, review.IsOpen())
+
+	require.NoError(t, review.Resolve(ResolutionAcceptAsPaid, "user-1", "customer paid the fee"))
+	assert.Equal(t, StatusResolved, review.Status())
+	assert.Equal(t, ResolutionAcceptAsPaid, review.Resolution())
+	assert.Equal(t, "user-1", review.ResolvedBy())
+	assert.NotNil(t, review.ResolvedAt())
+
+	require.ErrorIs(t, review.Resolve(ResolutionRefund, "user-2", ""), ErrReviewResolved)
+}
+
+func TestNewReview_Validation(t *testing.T) {
+	_, err := NewReview("", "merchant-1", KindOrphaned, "invoice-1", "payment-1", "", "1", "BTC", "")
+	require.Error(t, err)
+
+	_, err = NewReview("review-1", "merchant-1", Kind("dispute"), "invoice-1", "payment-1", "", "1", "BTC", "")
+	require.Error(t, err)
+
+	_, err = NewReview("review-1", "merchant-1", KindOrphaned, "invoice-1", "", "", "1", "BTC", "")
+	require.Error(t, err)
+}
@@ -0,0 +1,371 @@
+package review
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/compliance"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultListLimit is the default page size for listing reviews.
+	defaultListLimit = 20
+	// maxListLimit is the maximum page size for listing reviews.
+	maxListLimit = 100
+)
+
+// Service defines the interface for working the manual review queue.
+type Service interface {
+	// ListReviews lists reviews for a merchant.
+	ListReviews(ctx context.Context, req *ListReviewsRequest) (*ListReviewsResponse, error)
+
+	// GetReview retrieves a review.
+	GetReview(ctx context.Context, req *GetReviewRequest) (*Review, error)
+
+	// ResolveReview applies an operator's decision to a review and the payment and invoice behind it.
+	ResolveReview(ctx context.Context, req *ResolveReviewRequest) (*Review, error)
+}
+
+// GetReviewRequest represents the request to get a review.
+type GetReviewRequest struct {
+	MerchantID string `validate:"required"`
+	ReviewID   string `validate:"required"`
+}
+
+// ResolveReviewRequest represents an operator's decision on a review.
+type ResolveReviewRequest struct {
+	MerchantID string     `validate:"required"`
+	ReviewID   string     `validate:"required"`
+	Resolution Resolution `validate:"required"`
+	ResolvedBy string     `validate:"required"`
+	Note       string     `validate:"max=1000"`
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository     Repository
+	invoiceService invoice.InvoiceService
+	paymentService payment.PaymentService
+	compliance     compliance.ReviewService
+	screenings     compliance.Repository
+	auditService   audit.Service
+	eventBus       shared.EventBus
+	logger         *zap.Logger
+}
+
+// NewService creates a new review service.
+func NewService(
+	repository Repository,
+	invoiceService invoice.InvoiceService,
+	paymentService payment.PaymentService,
+	complianceService compliance.ReviewService,
+	screenings compliance.Repository,
+	auditService audit.Service,
+	eventBus shared.EventBus,
+	logger *zap.Logger,
+) Service {
+	return &ServiceImpl{
+		repository:     repository,
+		invoiceService: invoiceService,
+		paymentService: paymentService,
+		compliance:     complianceService,
+		screenings:     screenings,
+		auditService:   auditService,
+		eventBus:       eventBus,
+		logger:         logger,
+	}
+}
+
+// ListReviews lists reviews for a merchant.
+func (s *ServiceImpl) ListReviews(ctx context.Context, req *ListReviewsRequest) (*ListReviewsResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: list reviews request cannot be nil", ErrInvalidRequest)
+	}
+	if req.MerchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+	if req.Kind != "" && !req.Kind.IsValid() {
+		return nil, fmt.Errorf("%w: invalid kind %s", ErrInvalidRequest, req.Kind)
+	}
+	if req.Status != "" && !req.Status.IsValid() {
+		return nil, fmt.Errorf("%w: invalid status %s", ErrInvalidRequest, req.Status)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	return s.repository.List(ctx, &ListReviewsRequest{
+		MerchantID: req.MerchantID,
+		Kind:       req.Kind,
+		Status:     req.Status,
+		Limit:      limit,
+		Offset:     max(req.Offset, 0),
+	})
+}
+
+// GetReview retrieves a review.
+func (s *ServiceImpl) GetReview(ctx context.Context, req *GetReviewRequest) (*Review, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: get review request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	return s.findMerchantReview(ctx, req.MerchantID, req.ReviewID)
+}
+
+// ResolveReview applies an operator's decision to a review and the payment and invoice behind it.
+//
+// Accepting a held payment releases it so the invoice settles through the normal confirmation
+// flow. Accepting an underpayment, orphaned or stale rate payment settles the invoice as paid,
+// re-detecting an orphaned payment first. Refunds and write-offs fail the payment and close the
+// invoice; refunds also publish a refund request for the received funds.
+func (s *ServiceImpl) ResolveReview(ctx context.Context, req *ResolveReviewRequest) (*Review, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: resolve review request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	if !req.Resolution.IsValid() {
+		return nil, fmt.Errorf("%w: invalid resolution %s", ErrInvalidRequest, req.Resolution)
+	}
+
+	review, err := s.findMerchantReview(ctx, req.MerchantID, req.ReviewID)
+	if err != nil {
+		return nil, err
+	}
+	if !review.IsOpen() {
+		return nil, ErrReviewResolved
+	}
+
+	if err := s.apply(ctx, review, req); err != nil {
+		return nil, err
+	}
+
+	if err := review.Resolve(req.Resolution, req.ResolvedBy, req.Note); err != nil {
+		return nil, err
+	}
+	if err := s.repository.Update(ctx, review); err != nil {
+		return nil, fmt.Errorf("failed to update review: %w", err)
+	}
+
+	if err := s.auditService.Record(ctx, &audit.RecordRequest{
+		MerchantID:   review.MerchantID(),
+		Actor:        audit.Actor{ID: req.ResolvedBy, Type: audit.ActorTypeUser},
+		Action:       "review.resolved",
+		ResourceType: "payment",
+		ResourceID:   review.PaymentID(),
+		Before:       map[string]interface{}{"status": string(StatusOpen)},
+		After: map[string]interface{}{
+			"status":     string(review.Status()),
+			"resolution": string(review.Resolution()),
+			"note":       review.Note(),
+		},
+		Details: map[string]interface{}{
+			"review_id":  review.ID(),
+			"kind":       string(review.Kind()),
+			"invoice_id": review.InvoiceID(),
+		},
+	}); err != nil {
+		s.logger.Error("Failed to record audit entry",
+			zap.String("review_id", review.ID()),
+			zap.Error(err),
+		)
+	}
+
+	return review, nil
+}
+
+// apply drives the payment and invoice transitions for a resolution.
+func (s *ServiceImpl) apply(ctx context.Context, review *Review, req *ResolveReviewRequest) error {
+	if review.Kind() == KindScreening {
+		return s.applyScreening(ctx, review, req)
+	}
+
+	if req.Resolution == ResolutionAcceptAsPaid {
+		if review.Kind() == KindOrphaned {
+			if err := s.paymentService.UpdatePaymentStatus(
+				ctx, shared.PaymentID(review.PaymentID()), "detect",
+			); err != nil {
+				return fmt.Errorf("%w: %w", ErrResolutionNotAllowed, err)
+			}
+		}
+		return s.settleInvoice(ctx, review.InvoiceID())
+	}
+
+	if err := s.failPayment(ctx, review.PaymentID()); err != nil {
+		return err
+	}
+	if err := s.closeInvoice(ctx, review, req); err != nil {
+		return err
+	}
+	if req.Resolution == ResolutionRefund {
+		s.requestRefund(ctx, review, req)
+	}
+	return nil
+}
+
+// applyScreening delegates a held payment to the compliance review so the screening and payment stay in step.
+func (s *ServiceImpl) applyScreening(ctx context.Context, review *Review, req *ResolveReviewRequest) error {
+	screening, err := s.screenings.FindByPaymentID(ctx, review.PaymentID())
+	if err != nil {
+		return fmt.Errorf("failed to find screening: %w", err)
+	}
+
+	decision := &compliance.ReviewDecisionRequest{
+		MerchantID:  req.MerchantID,
+		ScreeningID: screening.ID(),
+		ReviewerID:  req.ResolvedBy,
+		Note:        req.Note,
+	}
+
+	if req.Resolution == ResolutionAcceptAsPaid {
+		_, err = s.compliance.ApproveReview(ctx, decision)
+	} else {
+		_, err = s.compliance.RejectReview(ctx, decision)
+	}
+	if err != nil {
+		if errors.Is(err, compliance.ErrScreeningNotHeld) {
+			return fmt.Errorf("%w: %w", ErrResolutionNotAllowed, err)
+		}
+		return err
+	}
+
+	if req.Resolution == ResolutionAcceptAsPaid {
+		return nil
+	}
+	if err := s.closeInvoice(ctx, review, req); err != nil {
+		return err
+	}
+	if req.Resolution == ResolutionRefund {
+		s.requestRefund(ctx, review, req)
+	}
+	return nil
+}
+
+// settleInvoice walks the invoice through the FSM until it is paid.
+func (s *ServiceImpl) settleInvoice(ctx context.Context, invoiceID string) error {
+	for {
+		status, err := s.invoiceService.GetInvoiceStatus(ctx, invoiceID)
+		if err != nil {
+			return fmt.Errorf("failed to get invoice status: %w", err)
+		}
+
+		var next invoice.InvoiceStatus
+		switch status {
+		case invoice.StatusPaid:
+			return nil
+		case invoice.StatusCreated:
+			next = invoice.StatusPending
+		case invoice.StatusPending, invoice.StatusPartial:
+			next = invoice.StatusConfirming
+		case invoice.StatusConfirming:
+			next = invoice.StatusPaid
+		default:
+			return fmt.Errorf("%w: invoice is %s", ErrResolutionNotAllowed, status)
+		}
+
+		if err := s.invoiceService.UpdateInvoiceStatus(ctx, invoiceID, next, "accepted as paid by review"); err != nil {
+			return fmt.Errorf("failed to update invoice status: %w", err)
+		}
+	}
+}
+
+// failPayment fails the payment unless it already reached a terminal state.
+func (s *ServiceImpl) failPayment(ctx context.Context, paymentID string) error {
+	p, err := s.paymentService.GetPayment(ctx, shared.PaymentID(paymentID))
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+	if p.Status().IsTerminal() {
+		return nil
+	}
+
+	if err := s.paymentService.UpdatePaymentStatus(ctx, p.ID(), "fail"); err != nil {
+		return fmt.Errorf("failed to fail payment: %w", err)
+	}
+	return nil
+}
+
+// closeInvoice cancels an open invoice, or refunds the remaining amount of a paid invoice to the sender of the
+// reviewed payment when the funds are returned.
+func (s *ServiceImpl) closeInvoice(ctx context.Context, review *Review, req *ResolveReviewRequest) error {
+	invoiceID := review.InvoiceID()
+	status, err := s.invoiceService.GetInvoiceStatus(ctx, invoiceID)
+	if err != nil {
+		return fmt.Errorf("failed to get invoice status: %w", err)
+	}
+
+	switch {
+	case !status.IsTerminal():
+		if err := s.invoiceService.CancelInvoice(ctx, invoiceID, "closed by review: "+string(req.Resolution)); err != nil {
+			return fmt.Errorf("failed to cancel invoice: %w", err)
+		}
+	case (status == invoice.StatusPaid || status == invoice.StatusPartiallyRefunded) &&
+		req.Resolution == ResolutionRefund:
+		p, err := s.paymentService.GetPayment(ctx, shared.PaymentID(review.PaymentID()))
+		if err != nil {
+			return fmt.Errorf("failed to get payment: %w", err)
+		}
+		if _, err := s.invoiceService.RefundInvoice(ctx, &invoice.RefundInvoiceRequest{
+			InvoiceID:   invoiceID,
+			Reason:      "refunded by review",
+			RequestedBy: req.ResolvedBy,
+			Destination: p.FromAddress(),
+		}); err != nil {
+			return fmt.Errorf("failed to refund invoice: %w", err)
+		}
+	}
+	return nil
+}
+
+// requestRefund publishes a refund request for the funds received with the payment.
+func (s *ServiceImpl) requestRefund(ctx context.Context, review *Review, req *ResolveReviewRequest) {
+	if s.eventBus == nil {
+		return
+	}
+
+	event := shared.NewPaymentRefundRequestedEvent(shared.PaymentRefundRequestedEvent{
+		PaymentID:   review.PaymentID(),
+		InvoiceID:   review.InvoiceID(),
+		MerchantID:  review.MerchantID(),
+		Amount:      review.ReceivedAmount(),
+		Currency:    review.Currency(),
+		ReviewID:    review.ID(),
+		RequestedBy: req.ResolvedBy,
+		Timestamp:   shared.Now().UTC(),
+	})
+	if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+		s.logger.Error("Failed to publish domain event",
+			zap.String("event_type", shared.EventTypePaymentRefundRequested),
+			zap.String("aggregate_id", review.PaymentID()),
+			zap.Error(err),
+		)
+	}
+}
+
+// findMerchantReview loads a review and ensures it belongs to the merchant.
+func (s *ServiceImpl) findMerchantReview(ctx context.Context, merchantID, id string) (*Review, error) {
+	review, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if review.MerchantID() != merchantID {
+		return nil, ErrReviewNotFound
+	}
+	return review, nil
+}
@@ -0,0 +1,16 @@
+package saga
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the saga service layer dependencies and subscribes the orchestrator to events.
+var Module = fx.Module("saga-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+	fx.Invoke(RegisterOrchestrator),
+)
@@ -0,0 +1,64 @@
+// Package saga coordinates the flow that follows a confirmed payment across aggregates: the invoice is
+// marked paid, its settlement created and the settlement paid out to the merchant. Each flow is a saga
+// whose state is kept, so that a flow that stops part-way can be seen and resumed, and so that a
+// settlement that fails downstream is compensated by reversing it and reopening the invoice.
+package saga
+
+// Status represents where a saga is in its lifecycle.
+type Status string
+
+const (
+	// StatusRunning - Saga is carrying out its steps or awaiting the payout of the settlement
+	StatusRunning Status = "running"
+	// StatusCompleted - Settlement was paid out to the merchant
+	StatusCompleted Status = "completed"
+	// StatusCompensating - Settlement failed downstream and the completed steps are being undone
+	StatusCompensating Status = "compensating"
+	// StatusCompensated - Settlement was reversed and the invoice reopened
+	StatusCompensated Status = "compensated"
+	// StatusFailed - Compensation could not be completed and needs an operator
+	StatusFailed Status = "failed"
+)
+
+// IsValid returns true if the saga status is valid.
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusRunning, StatusCompleted, StatusCompensating, StatusCompensated, StatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTerminal returns true if the saga has finished, successfully or not.
+func (s Status) IsTerminal() bool {
+	return s == StatusCompleted || s == StatusCompensated || s == StatusFailed
+}
+
+// Step represents the last step a saga completed.
+type Step string
+
+const (
+	// StepStarted - Payment was confirmed and the saga recorded
+	StepStarted Step = "started"
+	// StepInvoicePaid - Invoice was marked paid
+	StepInvoicePaid Step = "invoice_paid"
+	// StepSettled - Settlement of the invoice was created and awaits payout
+	StepSettled Step = "settled"
+	// StepPaidOut - Payout including the settlement was confirmed on-chain
+	StepPaidOut Step = "paid_out"
+	// StepSettlementReversed - Settlement was reversed while compensating
+	StepSettlementReversed Step = "settlement_reversed"
+	// StepInvoiceReopened - Invoice was reopened while compensating
+	StepInvoiceReopened Step = "invoice_reopened"
+)
+
+// IsValid returns true if the saga step is valid.
+func (s Step) IsValid() bool {
+	switch s {
+	case StepStarted, StepInvoicePaid, StepSettled, StepPaidOut, StepSettlementReversed, StepInvoiceReopened:
+		return true
+	default:
+		return false
+	}
+}
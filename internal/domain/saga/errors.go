@@ -0,0 +1,15 @@
+package saga
+
+import "errors"
+
+// Domain errors for saga operations
+var (
+	ErrSagaNotFound      = errors.New("saga not found")
+	ErrInvalidTransition = errors.New("invalid saga transition")
+	ErrInvalidRequest    = errors.New("invalid saga request")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeSagaNotFound = "SAGA_NOT_FOUND"
+)
@@ -0,0 +1,390 @@
+package saga
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Policy holds the limits of the payment settlement saga.
+type Policy struct {
+	// MaxPayoutFailures is how many payouts including a settlement may fail before the saga gives up on
+	// paying it out and compensates.
+	MaxPayoutFailures int
+}
+
+// DefaultPolicy returns the saga policy used when none is configured.
+func DefaultPolicy() Policy {
+	return Policy{MaxPayoutFailures: 3}
+}
+
+// Orchestrator drives the saga of each confirmed payment. It reacts to the confirmation by marking the
+// invoice paid and settling it, then follows the settlement until a payout including it is confirmed.
+// A settlement that fails, or whose payouts keep failing, is compensated: the settlement is reversed and
+// the invoice reopened.
+//
+// A step that fails transiently is recorded on the saga and returned, so the event is dead-lettered;
+// replaying it resumes the saga at the step that failed.
+type Orchestrator struct {
+	repository  Repository
+	invoices    invoice.InvoiceService
+	payments    payment.Repository
+	settlements settlement.Service
+	policy      Policy
+	logger      *zap.Logger
+}
+
+// NewOrchestrator creates a new saga orchestrator.
+func NewOrchestrator(
+	repository Repository,
+	invoices invoice.InvoiceService,
+	payments payment.Repository,
+	settlements settlement.Service,
+	policy Policy,
+	logger *zap.Logger,
+) *Orchestrator {
+	if policy.MaxPayoutFailures <= 0 {
+		policy.MaxPayoutFailures = DefaultPolicy().MaxPayoutFailures
+	}
+
+	return &Orchestrator{
+		repository:  repository,
+		invoices:    invoices,
+		payments:    payments,
+		settlements: settlements,
+		policy:      policy,
+		logger:      logger,
+	}
+}
+
+// RegisterOrchestrator subscribes a saga orchestrator to payment, settlement and payout events.
+func RegisterOrchestrator(
+	registry shared.EventHandlerRegistry,
+	repository Repository,
+	invoices invoice.InvoiceService,
+	payments payment.Repository,
+	settlements settlement.Service,
+	policy Policy,
+	logger *zap.Logger,
+) {
+	registry.RegisterHandler(NewOrchestrator(repository, invoices, payments, settlements, policy, logger))
+}
+
+// EventTypes returns the events that start or advance a saga.
+func (o *Orchestrator) EventTypes() []string {
+	return []string{
+		shared.EventTypePaymentStatusChanged,
+		shared.EventTypeSettlementFailed,
+		shared.EventTypePayoutConfirmed,
+		shared.EventTypePayoutFailed,
+	}
+}
+
+// HandleEvent starts, advances or compensates the saga an event concerns.
+func (o *Orchestrator) HandleEvent(ctx context.Context, event *shared.BaseDomainEvent) error {
+	switch event.EventType {
+	case shared.EventTypePaymentStatusChanged:
+		var data shared.PaymentStatusChangedEvent
+		if err := shared.DecodeEventData(event, &data); err != nil {
+			return fmt.Errorf("failed to decode payment event: %w", err)
+		}
+		if payment.PaymentStatus(data.Status) != payment.StatusConfirmed {
+			return nil
+		}
+		return o.paymentConfirmed(ctx, data.PaymentID, data.InvoiceID)
+	case shared.EventTypeSettlementFailed:
+		var data shared.SettlementEvent
+		if err := shared.DecodeEventData(event, &data); err != nil {
+			return fmt.Errorf("failed to decode settlement event: %w", err)
+		}
+		return o.settlementFailed(ctx, data)
+	case shared.EventTypePayoutConfirmed, shared.EventTypePayoutFailed:
+		var data shared.PayoutEvent
+		if err := shared.DecodeEventData(event, &data); err != nil {
+			return fmt.Errorf("failed to decode payout event: %w", err)
+		}
+		for _, settlementID := range data.SettlementIDs {
+			if err := o.payoutFinished(ctx, event.EventType, settlementID, data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// paymentConfirmed starts the saga of a payment that completes an invoice awaiting confirmation, or resumes
+// the saga the payment already started.
+func (o *Orchestrator) paymentConfirmed(ctx context.Context, paymentID, invoiceID string) error {
+	existing, err := o.repository.FindByPaymentID(ctx, paymentID)
+	if err == nil {
+		return o.resume(ctx, existing)
+	}
+	if !errors.Is(err, ErrSagaNotFound) {
+		return fmt.Errorf("failed to find saga: %w", err)
+	}
+
+	inv, err := o.invoices.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		return fmt.Errorf("failed to get invoice: %w", err)
+	}
+	// Invoices marked paid some other way, such as by a payment review, are settled without a saga
+	if inv.Status() != invoice.StatusConfirming {
+		return nil
+	}
+	if _, err := o.repository.FindActiveByInvoiceID(ctx, invoiceID); err == nil {
+		return nil
+	} else if !errors.Is(err, ErrSagaNotFound) {
+		return fmt.Errorf("failed to find saga: %w", err)
+	}
+	awaiting, err := o.awaitingConfirmation(ctx, invoiceID)
+	if err != nil {
+		return err
+	}
+	if awaiting {
+		o.logger.Debug("Invoice has payments awaiting confirmation; saga not started",
+			zap.String("invoice_id", invoiceID),
+			zap.String("payment_id", paymentID))
+		return nil
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return fmt.Errorf("failed to generate saga ID: %w", err)
+	}
+	saga, err := NewSaga(id, invoiceID, paymentID, inv.MerchantID())
+	if err != nil {
+		return err
+	}
+	if err := o.repository.Save(ctx, saga); err != nil {
+		return err
+	}
+
+	o.logger.Info("Payment settlement saga started",
+		zap.String("saga_id", saga.ID()),
+		zap.String("invoice_id", invoiceID),
+		zap.String("payment_id", paymentID))
+	return o.resume(ctx, saga)
+}
+
+// awaitingConfirmation returns true if an invoice has payments that are not confirmed yet.
+func (o *Orchestrator) awaitingConfirmation(ctx context.Context, invoiceID string) (bool, error) {
+	payments, err := o.payments.FindByInvoiceID(ctx, shared.InvoiceID(invoiceID))
+	if err != nil {
+		return false, fmt.Errorf("failed to find invoice payments: %w", err)
+	}
+	for _, p := range payments {
+		if p.Status() == payment.StatusDetected || p.Status() == payment.StatusConfirming {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// resume carries a saga on from the last step it completed.
+func (o *Orchestrator) resume(ctx context.Context, saga *Saga) error {
+	switch saga.Status() {
+	case StatusRunning:
+		return o.settle(ctx, saga)
+	case StatusCompensating:
+		return o.compensate(ctx, saga)
+	default:
+		return nil
+	}
+}
+
+// settle marks the invoice paid and settles it, leaving the saga to await the payout of the settlement.
+func (o *Orchestrator) settle(ctx context.Context, saga *Saga) error {
+	if saga.Step() == StepStarted {
+		inv, err := o.invoices.GetInvoice(ctx, saga.InvoiceID())
+		if err != nil {
+			return o.stepFailed(ctx, saga, fmt.Errorf("failed to get invoice: %w", err))
+		}
+		if inv.Status() != invoice.StatusPaid {
+			err := o.invoices.UpdateInvoiceStatus(ctx, saga.InvoiceID(), invoice.StatusPaid, "Payment confirmed")
+			if err != nil {
+				return o.stepFailed(ctx, saga, fmt.Errorf("failed to mark invoice paid: %w", err))
+			}
+		}
+		if err := saga.MarkInvoicePaid(); err != nil {
+			return err
+		}
+		if err := o.repository.Update(ctx, saga); err != nil {
+			return err
+		}
+	}
+
+	if saga.Step() != StepInvoicePaid {
+		return nil
+	}
+	created, err := o.settlements.SettleInvoice(ctx, saga.InvoiceID())
+	if err != nil {
+		return o.stepFailed(ctx, saga, fmt.Errorf("failed to settle invoice: %w", err))
+	}
+	if created.Status() == settlement.StatusFailed {
+		return o.startCompensation(ctx, saga, "Settlement failed: "+created.FailureReason())
+	}
+	if err := saga.MarkSettled(created.ID()); err != nil {
+		return err
+	}
+	if err := o.repository.Update(ctx, saga); err != nil {
+		return err
+	}
+
+	o.logger.Info("Invoice settled; saga awaits payout",
+		zap.String("saga_id", saga.ID()),
+		zap.String("invoice_id", saga.InvoiceID()),
+		zap.String("settlement_id", created.ID()))
+	return nil
+}
+
+// settlementFailed compensates the saga whose settlement failed.
+func (o *Orchestrator) settlementFailed(ctx context.Context, data shared.SettlementEvent) error {
+	saga, err := o.repository.FindBySettlementID(ctx, data.SettlementID)
+	if errors.Is(err, ErrSagaNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find saga: %w", err)
+	}
+	// A settlement failing while it is created is compensated once SettleInvoice returns it
+	if !saga.AwaitsPayout() {
+		return nil
+	}
+
+	return o.startCompensation(ctx, saga, "Settlement failed: "+data.FailureReason)
+}
+
+// payoutFinished completes the saga of a settlement whose payout was confirmed, and counts a failed payout
+// against it, compensating once too many have failed.
+func (o *Orchestrator) payoutFinished(
+	ctx context.Context,
+	eventType, settlementID string,
+	data shared.PayoutEvent,
+) error {
+	saga, err := o.repository.FindBySettlementID(ctx, settlementID)
+	if errors.Is(err, ErrSagaNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find saga: %w", err)
+	}
+	if !saga.AwaitsPayout() {
+		return nil
+	}
+
+	if eventType == shared.EventTypePayoutConfirmed {
+		if err := saga.MarkPaidOut(data.PayoutID); err != nil {
+			return err
+		}
+		if err := o.repository.Update(ctx, saga); err != nil {
+			return err
+		}
+		o.logger.Info("Payment settlement saga completed",
+			zap.String("saga_id", saga.ID()),
+			zap.String("settlement_id", settlementID),
+			zap.String("payout_id", data.PayoutID))
+		return nil
+	}
+
+	failures, err := saga.RecordPayoutFailure(data.PayoutID, data.FailureReason)
+	if err != nil {
+		return err
+	}
+	if failures >= o.policy.MaxPayoutFailures {
+		return o.startCompensation(ctx, saga,
+			fmt.Sprintf("Payout failed %d times: %s", failures, data.FailureReason))
+	}
+	return o.repository.Update(ctx, saga)
+}
+
+// startCompensation moves a running saga to compensating and undoes its completed steps.
+func (o *Orchestrator) startCompensation(ctx context.Context, saga *Saga, reason string) error {
+	if err := saga.Compensate(reason); err != nil {
+		return err
+	}
+	if err := o.repository.Update(ctx, saga); err != nil {
+		return err
+	}
+
+	o.logger.Warn("Compensating payment settlement saga",
+		zap.String("saga_id", saga.ID()),
+		zap.String("invoice_id", saga.InvoiceID()),
+		zap.String("reason", reason))
+	return o.compensate(ctx, saga)
+}
+
+// compensate reverses the settlement of a compensating saga and reopens its invoice.
+func (o *Orchestrator) compensate(ctx context.Context, saga *Saga) error {
+	reason := saga.Reason()
+	if saga.Step() != StepSettlementReversed {
+		_, err := o.settlements.ReverseSettlement(ctx, saga.InvoiceID(), saga.PaymentID(), reason)
+		if err != nil && !errors.Is(err, settlement.ErrSettlementNotFound) {
+			return o.stepFailed(ctx, saga, fmt.Errorf("failed to reverse settlement: %w", err))
+		}
+		if err := saga.MarkSettlementReversed(); err != nil {
+			return err
+		}
+		if err := o.repository.Update(ctx, saga); err != nil {
+			return err
+		}
+	}
+
+	_, err := o.invoices.ReopenInvoice(ctx, saga.InvoiceID(), reason)
+	if errors.Is(err, invoice.ErrCannotReopenInvoice) {
+		err = o.reopened(ctx, saga)
+	}
+	if errors.Is(err, invoice.ErrCannotReopenInvoice) {
+		// The invoice moved on, for example it was refunded, and is left for an operator to resolve
+		if err := saga.Fail("Invoice could not be reopened"); err != nil {
+			return err
+		}
+		o.logger.Error("Payment settlement saga could not be compensated",
+			zap.String("saga_id", saga.ID()),
+			zap.String("invoice_id", saga.InvoiceID()))
+		return o.repository.Update(ctx, saga)
+	}
+	if err != nil {
+		return o.stepFailed(ctx, saga, fmt.Errorf("failed to reopen invoice: %w", err))
+	}
+	if err := saga.MarkInvoiceReopened(); err != nil {
+		return err
+	}
+	if err := o.repository.Update(ctx, saga); err != nil {
+		return err
+	}
+
+	o.logger.Warn("Payment settlement saga compensated",
+		zap.String("saga_id", saga.ID()),
+		zap.String("invoice_id", saga.InvoiceID()))
+	return nil
+}
+
+// reopened checks that the invoice of a saga is awaiting confirmation, as it is when an earlier attempt to
+// compensate reopened it, returning ErrCannotReopenInvoice otherwise.
+func (o *Orchestrator) reopened(ctx context.Context, saga *Saga) error {
+	inv, err := o.invoices.GetInvoice(ctx, saga.InvoiceID())
+	if err != nil {
+		return fmt.Errorf("failed to get invoice: %w", err)
+	}
+	if inv.Status() != invoice.StatusConfirming {
+		return invoice.ErrCannotReopenInvoice
+	}
+	return nil
+}
+
+// stepFailed records the failure of a step on the saga and returns it, so that the event is retried.
+func (o *Orchestrator) stepFailed(ctx context.Context, saga *Saga, cause error) error {
+	saga.RecordError(cause)
+	if err := o.repository.Update(ctx, saga); err != nil {
+		o.logger.Error("Failed to record saga step failure",
+			zap.String("saga_id", saga.ID()),
+			zap.Error(err))
+	}
+	return cause
+}
@@ -0,0 +1,341 @@
+package saga_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/saga"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeRepository keeps sagas in memory.
+type fakeRepository struct {
+	saga.Repository
+	sagas map[string]*saga.Saga
+}
+
+func (r *fakeRepository) Save(_ context.Context, s *saga.Saga) error {
+	r.sagas[s.ID()] = s
+	return nil
+}
+
+func (r *fakeRepository) Update(_ context.Context, s *saga.Saga) error {
+	r.sagas[s.ID()] = s
+	return nil
+}
+
+func (r *fakeRepository) FindByPaymentID(_ context.Context, paymentID string) (*saga.Saga, error) {
+	return r.find(func(s *saga.Saga) bool { return s.PaymentID() == paymentID })
+}
+
+func (r *fakeRepository) FindActiveByInvoiceID(_ context.Context, invoiceID string) (*saga.Saga, error) {
+	return r.find(func(s *saga.Saga) bool { return s.InvoiceID() == invoiceID && !s.Status().IsTerminal() })
+}
+
+func (r *fakeRepository) FindBySettlementID(_ context.Context, settlementID string) (*saga.Saga, error) {
+	return r.find(func(s *saga.Saga) bool { return s.SettlementID() == settlementID })
+}
+
+func (r *fakeRepository) find(match func(*saga.Saga) bool) (*saga.Saga, error) {
+	for _, s := range r.sagas {
+		if match(s) {
+			return s, nil
+		}
+	}
+	return nil, saga.ErrSagaNotFound
+}
+
+// only returns the single saga the repository holds.
+func (r *fakeRepository) only(t *testing.T) *saga.Saga {
+	t.Helper()
+	require.Len(t, r.sagas, 1)
+	for _, s := range r.sagas {
+		return s
+	}
+	return nil
+}
+
+// fakeInvoices marks the invoice paid and reopens it; only those operations are implemented.
+type fakeInvoices struct {
+	invoice.InvoiceService
+	invoice *invoice.Invoice
+}
+
+func (s *fakeInvoices) GetInvoice(_ context.Context, _ string) (*invoice.Invoice, error) {
+	return s.invoice, nil
+}
+
+func (s *fakeInvoices) UpdateInvoiceStatus(_ context.Context, _ string, status invoice.InvoiceStatus, _ string) error {
+	return invoice.NewInvoiceFSM(s.invoice).TransitionTo(status)
+}
+
+func (s *fakeInvoices) ReopenInvoice(_ context.Context, _, _ string) (*invoice.Invoice, error) {
+	if err := s.invoice.Reopen(time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	return s.invoice, nil
+}
+
+// fakePayments returns the payments of the invoice; only FindByInvoiceID is implemented.
+type fakePayments struct {
+	payment.Repository
+	payments []*payment.Payment
+}
+
+func (r *fakePayments) FindByInvoiceID(_ context.Context, _ shared.InvoiceID) ([]*payment.Payment, error) {
+	return r.payments, nil
+}
+
+// fakeSettlements settles and reverses the invoice; only those operations are implemented.
+type fakeSettlements struct {
+	settlement.Service
+	status   settlement.Status
+	failures int
+	settled  int
+	reversed []string
+}
+
+func (s *fakeSettlements) SettleInvoice(_ context.Context, invoiceID string) (*settlement.Settlement, error) {
+	if s.failures > 0 {
+		s.failures--
+		return nil, errors.New("connection refused")
+	}
+	s.settled++
+	return settlement.RestoreSettlement("settlement-1", invoiceID, "merchant-1", decimal.NewFromInt(25),
+		decimal.NewFromInt(1), decimal.RequireFromString("0.25"), decimal.RequireFromString("24.75"), "USDT",
+		s.status, nil, "order rejected", "", "", time.Now().UTC(), nil)
+}
+
+func (s *fakeSettlements) ReverseSettlement(
+	_ context.Context,
+	_, _, reason string,
+) (*settlement.Reversal, error) {
+	s.reversed = append(s.reversed, reason)
+	return nil, nil
+}
+
+type sagaTest struct {
+	orchestrator *saga.Orchestrator
+	repository   *fakeRepository
+	invoices     *fakeInvoices
+	payments     *fakePayments
+	settlements  *fakeSettlements
+}
+
+func newSagaTest(t *testing.T) *sagaTest {
+	t.Helper()
+	inv := newTestInvoice(t)
+	inv.SetStatus(invoice.StatusConfirming)
+
+	test := &sagaTest{
+		repository:  &fakeRepository{sagas: make(map[string]*saga.Saga)},
+		invoices:    &fakeInvoices{invoice: inv},
+		payments:    &fakePayments{payments: []*payment.Payment{newTestPayment(t, "payment-1", payment.StatusConfirmed)}},
+		settlements: &fakeSettlements{status: settlement.StatusPending},
+	}
+	test.orchestrator = saga.NewOrchestrator(test.repository, test.invoices, test.payments, test.settlements,
+		saga.Policy{MaxPayoutFailures: 2}, zap.NewNop())
+	return test
+}
+
+func newTestInvoice(t *testing.T) *invoice.Invoice {
+	t.Helper()
+	unitPrice, err := shared.NewMoney("25.00", shared.CurrencyUSD)
+	require.NoError(t, err)
+	item, err := invoice.NewInvoiceItem("Order", "", "1", unitPrice)
+	require.NoError(t, err)
+	zero, err := shared.NewMoney("0", shared.CurrencyUSD)
+	require.NoError(t, err)
+	pricing, err := invoice.NewInvoicePricing(unitPrice, zero, unitPrice)
+	require.NoError(t, err)
+	address, err := shared.NewPaymentAddress("TTestAddress123456789012345678901234567890", shared.NetworkTron)
+	require.NoError(t, err)
+	rate, err := shared.NewExchangeRate("1.0", shared.CurrencyUSD, shared.CryptoCurrencyUSDT, "fixed", time.Hour)
+	require.NoError(t, err)
+	tolerance, err := invoice.NewPaymentTolerance("0.01", "1.0", invoice.OverpaymentActionCredit)
+	require.NoError(t, err)
+
+	inv, err := invoice.NewInvoice("invoice-1", "merchant-1", "Order", "", []*invoice.InvoiceItem{item}, pricing,
+		shared.CryptoCurrencyUSDT, address, rate, tolerance, invoice.NewInvoiceExpiration(time.Hour), nil)
+	require.NoError(t, err)
+	return inv
+}
+
+func newTestPayment(t *testing.T, id string, status payment.PaymentStatus) *payment.Payment {
+	t.Helper()
+	money, err := shared.NewMoneyWithCrypto("25", shared.CryptoCurrencyUSDT)
+	require.NoError(t, err)
+	amount, err := payment.NewPaymentAmount(money, shared.CryptoCurrencyUSDT)
+	require.NoError(t, err)
+	toAddress, err := payment.NewPaymentAddress("TTestAddress123456789012345678901234567890", shared.NetworkTron)
+	require.NoError(t, err)
+	hash, err := payment.NewTransactionHash("0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	require.NoError(t, err)
+
+	p, err := payment.NewPayment(shared.PaymentID(id), "invoice-1", amount,
+		"TSenderAddress12345678901234567890123456", toAddress, hash, 1)
+	require.NoError(t, err)
+	p.SetStatus(status)
+	return p
+}
+
+func paymentStatusChanged(paymentID string, status payment.PaymentStatus) *shared.BaseDomainEvent {
+	return shared.NewPaymentStatusChangedEvent(shared.PaymentStatusChangedEvent{
+		PaymentEvent: shared.PaymentEvent{PaymentID: paymentID, InvoiceID: "invoice-1", Status: string(status)},
+	})
+}
+
+func payoutEvent(eventType string, reason string) *shared.BaseDomainEvent {
+	data := shared.PayoutEvent{
+		PayoutID:      "payout-1",
+		MerchantID:    "merchant-1",
+		SettlementIDs: []string{"settlement-1"},
+		FailureReason: reason,
+	}
+	if eventType == shared.EventTypePayoutConfirmed {
+		return shared.NewPayoutConfirmedEvent(data)
+	}
+	return shared.NewPayoutFailedEvent(data)
+}
+
+func TestOrchestrator_SettlesConfirmedPayment(t *testing.T) {
+	ctx := context.Background()
+	test := newSagaTest(t)
+
+	require.NoError(t, test.orchestrator.HandleEvent(ctx, paymentStatusChanged("payment-1", payment.StatusConfirming)))
+	assert.Empty(t, test.repository.sagas)
+
+	require.NoError(t, test.orchestrator.HandleEvent(ctx, paymentStatusChanged("payment-1", payment.StatusConfirmed)))
+	s := test.repository.only(t)
+	assert.Equal(t, saga.StatusRunning, s.Status())
+	assert.Equal(t, saga.StepSettled, s.Step())
+	assert.Equal(t, "settlement-1", s.SettlementID())
+	assert.Equal(t, "merchant-1", s.MerchantID())
+	assert.Equal(t, invoice.StatusPaid, test.invoices.invoice.Status())
+
+	// Redelivering the confirmation does not settle the invoice again
+	require.NoError(t, test.orchestrator.HandleEvent(ctx, paymentStatusChanged("payment-1", payment.StatusConfirmed)))
+	assert.Equal(t, 1, test.settlements.settled)
+
+	require.NoError(t, test.orchestrator.HandleEvent(ctx, payoutEvent(shared.EventTypePayoutConfirmed, "")))
+	assert.Equal(t, saga.StatusCompleted, s.Status())
+	assert.Equal(t, saga.StepPaidOut, s.Step())
+	assert.Equal(t, "payout-1", s.PayoutID())
+	assert.NotNil(t, s.CompletedAt())
+}
+
+func TestOrchestrator_WaitsForOtherPayments(t *testing.T) {
+	ctx := context.Background()
+	test := newSagaTest(t)
+	test.payments.payments = append(test.payments.payments, newTestPayment(t, "payment-2", payment.StatusConfirming))
+
+	require.NoError(t, test.orchestrator.HandleEvent(ctx, paymentStatusChanged("payment-1", payment.StatusConfirmed)))
+	assert.Empty(t, test.repository.sagas)
+	assert.Equal(t, invoice.StatusConfirming, test.invoices.invoice.Status())
+
+	test.payments.payments[1].SetStatus(payment.StatusConfirmed)
+	require.NoError(t, test.orchestrator.HandleEvent(ctx, paymentStatusChanged("payment-2", payment.StatusConfirmed)))
+	assert.Equal(t, "payment-2", test.repository.only(t).PaymentID())
+}
+
+func TestOrchestrator_IgnoresInvoicesNotAwaitingConfirmation(t *testing.T) {
+	test := newSagaTest(t)
+	test.invoices.invoice.SetStatus(invoice.StatusPaid)
+
+	require.NoError(t, test.orchestrator.HandleEvent(context.Background(),
+		paymentStatusChanged("payment-1", payment.StatusConfirmed)))
+	assert.Empty(t, test.repository.sagas)
+	assert.Zero(t, test.settlements.settled)
+}
+
+func TestOrchestrator_ResumesAfterTransientFailure(t *testing.T) {
+	ctx := context.Background()
+	test := newSagaTest(t)
+	test.settlements.failures = 1
+	event := paymentStatusChanged("payment-1", payment.StatusConfirmed)
+
+	require.Error(t, test.orchestrator.HandleEvent(ctx, event))
+	s := test.repository.only(t)
+	assert.Equal(t, saga.StatusRunning, s.Status())
+	assert.Equal(t, saga.StepInvoicePaid, s.Step())
+	assert.Contains(t, s.LastError(), "connection refused")
+
+	// Replaying the dead-lettered event resumes the saga where it stopped
+	require.NoError(t, test.orchestrator.HandleEvent(ctx, event))
+	assert.Equal(t, saga.StepSettled, s.Step())
+	assert.Empty(t, test.settlements.reversed)
+}
+
+func TestOrchestrator_Compensates(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("PayoutsKeepFailing", func(t *testing.T) {
+		test := newSagaTest(t)
+		require.NoError(t, test.orchestrator.HandleEvent(ctx, paymentStatusChanged("payment-1", payment.StatusConfirmed)))
+		s := test.repository.only(t)
+
+		require.NoError(t, test.orchestrator.HandleEvent(ctx, payoutEvent(shared.EventTypePayoutFailed, "nonce too low")))
+		assert.Equal(t, saga.StatusRunning, s.Status())
+		assert.Equal(t, 1, s.PayoutFailures())
+		assert.Equal(t, "nonce too low", s.LastError())
+
+		require.NoError(t, test.orchestrator.HandleEvent(ctx, payoutEvent(shared.EventTypePayoutFailed, "reverted")))
+		assert.Equal(t, saga.StatusCompensated, s.Status())
+		assert.Equal(t, saga.StepInvoiceReopened, s.Step())
+		assert.Equal(t, "Payout failed 2 times: reverted", s.Reason())
+		assert.Equal(t, []string{s.Reason()}, test.settlements.reversed)
+		assert.Equal(t, invoice.StatusConfirming, test.invoices.invoice.Status())
+		assert.Nil(t, test.invoices.invoice.PaidAt())
+
+		// Late payout events no longer concern the saga
+		require.NoError(t, test.orchestrator.HandleEvent(ctx, payoutEvent(shared.EventTypePayoutConfirmed, "")))
+		assert.Equal(t, saga.StatusCompensated, s.Status())
+	})
+
+	t.Run("SettlementFailed", func(t *testing.T) {
+		test := newSagaTest(t)
+		require.NoError(t, test.orchestrator.HandleEvent(ctx, paymentStatusChanged("payment-1", payment.StatusConfirmed)))
+
+		require.NoError(t, test.orchestrator.HandleEvent(ctx, shared.NewSettlementFailedEvent(shared.SettlementEvent{
+			SettlementID: "settlement-1", InvoiceID: "invoice-1", FailureReason: "order rejected",
+		})))
+		s := test.repository.only(t)
+		assert.Equal(t, saga.StatusCompensated, s.Status())
+		assert.Equal(t, "Settlement failed: order rejected", s.Reason())
+		assert.Len(t, test.settlements.reversed, 1)
+		assert.Equal(t, invoice.StatusConfirming, test.invoices.invoice.Status())
+	})
+
+	t.Run("SettlementFailedWhileCreated", func(t *testing.T) {
+		test := newSagaTest(t)
+		test.settlements.status = settlement.StatusFailed
+
+		require.NoError(t, test.orchestrator.HandleEvent(ctx, paymentStatusChanged("payment-1", payment.StatusConfirmed)))
+		s := test.repository.only(t)
+		assert.Equal(t, saga.StatusCompensated, s.Status())
+		assert.Empty(t, s.SettlementID())
+		assert.Len(t, test.settlements.reversed, 1)
+	})
+
+	t.Run("InvoiceCannotBeReopened", func(t *testing.T) {
+		test := newSagaTest(t)
+		require.NoError(t, test.orchestrator.HandleEvent(ctx, paymentStatusChanged("payment-1", payment.StatusConfirmed)))
+		test.invoices.invoice.SetStatus(invoice.StatusRefunded)
+
+		require.NoError(t, test.orchestrator.HandleEvent(ctx, payoutEvent(shared.EventTypePayoutFailed, "reverted")))
+		require.NoError(t, test.orchestrator.HandleEvent(ctx, payoutEvent(shared.EventTypePayoutFailed, "reverted")))
+		s := test.repository.only(t)
+		assert.Equal(t, saga.StatusFailed, s.Status())
+		assert.Equal(t, saga.StepSettlementReversed, s.Step())
+		assert.Equal(t, "Invoice could not be reopened", s.LastError())
+	})
+}
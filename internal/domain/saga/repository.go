@@ -0,0 +1,49 @@
+package saga
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+)
+
+// Repository defines the interface for saga persistence.
+type Repository interface {
+	// Save persists a new saga.
+	Save(ctx context.Context, saga *Saga) error
+
+	// Update updates an existing saga.
+	Update(ctx context.Context, saga *Saga) error
+
+	// FindByID retrieves a saga by its ID.
+	FindByID(ctx context.Context, id string) (*Saga, error)
+
+	// FindByPaymentID retrieves the saga started by a confirmed payment.
+	FindByPaymentID(ctx context.Context, paymentID string) (*Saga, error)
+
+	// FindActiveByInvoiceID retrieves the saga of an invoice that is still running or compensating.
+	FindActiveByInvoiceID(ctx context.Context, invoiceID string) (*Saga, error)
+
+	// FindBySettlementID retrieves the most recent saga that created a settlement.
+	FindBySettlementID(ctx context.Context, settlementID string) (*Saga, error)
+
+	// List retrieves sagas matching the filter, newest first.
+	List(ctx context.Context, req *ListSagasRequest) (*ListSagasResponse, error)
+}
+
+// ListSagasRequest represents the request to list sagas.
+// Empty filter fields match all sagas.
+type ListSagasRequest struct {
+	InvoiceID  string
+	MerchantID string
+	Status     Status
+	Limit      int
+	// Cursor continues a listing after the page that returned it.
+	Cursor *shared.Cursor
+}
+
+// ListSagasResponse represents the response from listing sagas.
+type ListSagasResponse struct {
+	Sagas []*Saga
+	Limit int
+	// NextCursor continues the listing after this page, or is nil on the last page.
+	NextCursor *shared.Cursor
+}
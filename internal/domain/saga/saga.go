@@ -0,0 +1,261 @@
+package saga
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Saga is the state of the flow started by a confirmed payment: marking its invoice paid, settling the
+// invoice and paying the settlement out. The step is the last one completed, so that a saga interrupted by
+// a failure resumes where it stopped.
+type Saga struct {
+	id             string
+	invoiceID      string
+	paymentID      string
+	merchantID     string
+	settlementID   string
+	payoutID       string
+	status         Status
+	step           Step
+	payoutFailures int
+	reason         string
+	lastError      string
+	createdAt      time.Time
+	updatedAt      time.Time
+	completedAt    *time.Time
+}
+
+// NewSaga creates a running saga for a confirmed payment of an invoice.
+func NewSaga(id, invoiceID, paymentID, merchantID string) (*Saga, error) {
+	now := shared.Now().UTC()
+	return RestoreSaga(
+		id, invoiceID, paymentID, merchantID, "", "", StatusRunning, StepStarted, 0, "", "", now, now, nil,
+	)
+}
+
+// RestoreSaga recreates a saga from storage.
+func RestoreSaga(
+	id, invoiceID, paymentID, merchantID, settlementID, payoutID string,
+	status Status,
+	step Step,
+	payoutFailures int,
+	reason, lastError string,
+	createdAt, updatedAt time.Time,
+	completedAt *time.Time,
+) (*Saga, error) {
+	if id == "" {
+		return nil, errors.New("saga ID is required")
+	}
+	if invoiceID == "" {
+		return nil, fmt.Errorf("%w: invoice ID is required", ErrInvalidRequest)
+	}
+	if paymentID == "" {
+		return nil, fmt.Errorf("%w: payment ID is required", ErrInvalidRequest)
+	}
+	if !status.IsValid() {
+		return nil, fmt.Errorf("%w: invalid status %s", ErrInvalidRequest, status)
+	}
+	if !step.IsValid() {
+		return nil, fmt.Errorf("%w: invalid step %s", ErrInvalidRequest, step)
+	}
+
+	return &Saga{
+		id:             id,
+		invoiceID:      invoiceID,
+		paymentID:      paymentID,
+		merchantID:     merchantID,
+		settlementID:   settlementID,
+		payoutID:       payoutID,
+		status:         status,
+		step:           step,
+		payoutFailures: payoutFailures,
+		reason:         reason,
+		lastError:      lastError,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
+		completedAt:    completedAt,
+	}, nil
+}
+
+// ID returns the saga ID.
+func (s *Saga) ID() string {
+	return s.id
+}
+
+// InvoiceID returns the invoice the saga settles.
+func (s *Saga) InvoiceID() string {
+	return s.invoiceID
+}
+
+// PaymentID returns the confirmed payment that started the saga.
+func (s *Saga) PaymentID() string {
+	return s.paymentID
+}
+
+// MerchantID returns the merchant the invoice belongs to.
+func (s *Saga) MerchantID() string {
+	return s.merchantID
+}
+
+// SettlementID returns the settlement the saga created, or empty before the invoice is settled.
+func (s *Saga) SettlementID() string {
+	return s.settlementID
+}
+
+// PayoutID returns the payout that paid the settlement out, or the payout that most recently failed to.
+func (s *Saga) PayoutID() string {
+	return s.payoutID
+}
+
+// Status returns the saga status.
+func (s *Saga) Status() Status {
+	return s.status
+}
+
+// Step returns the last step the saga completed.
+func (s *Saga) Step() Step {
+	return s.step
+}
+
+// PayoutFailures returns how many payouts including the settlement failed.
+func (s *Saga) PayoutFailures() int {
+	return s.payoutFailures
+}
+
+// Reason returns why the saga is compensated, or empty if it is not.
+func (s *Saga) Reason() string {
+	return s.reason
+}
+
+// LastError returns the most recent failure of a step or payout.
+func (s *Saga) LastError() string {
+	return s.lastError
+}
+
+// CreatedAt returns when the saga started.
+func (s *Saga) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// UpdatedAt returns when the saga last changed.
+func (s *Saga) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// CompletedAt returns when the saga finished, or nil while it is running or compensating.
+func (s *Saga) CompletedAt() *time.Time {
+	return s.completedAt
+}
+
+// AwaitsPayout returns true if the saga settled the invoice and waits for the settlement to be paid out.
+func (s *Saga) AwaitsPayout() bool {
+	return s.status == StatusRunning && s.step == StepSettled
+}
+
+// MarkInvoicePaid records that the invoice was marked paid.
+func (s *Saga) MarkInvoicePaid() error {
+	if s.status != StatusRunning || s.step != StepStarted {
+		return ErrInvalidTransition
+	}
+	s.advance(StepInvoicePaid)
+	return nil
+}
+
+// MarkSettled records the settlement created for the invoice.
+func (s *Saga) MarkSettled(settlementID string) error {
+	if s.status != StatusRunning || s.step != StepInvoicePaid {
+		return ErrInvalidTransition
+	}
+	if settlementID == "" {
+		return fmt.Errorf("%w: settlement ID is required", ErrInvalidRequest)
+	}
+	s.settlementID = settlementID
+	s.advance(StepSettled)
+	return nil
+}
+
+// MarkPaidOut records the confirmed payout of the settlement, completing the saga.
+func (s *Saga) MarkPaidOut(payoutID string) error {
+	if !s.AwaitsPayout() {
+		return ErrInvalidTransition
+	}
+	s.payoutID = payoutID
+	s.lastError = ""
+	s.advance(StepPaidOut)
+	s.finish(StatusCompleted)
+	return nil
+}
+
+// RecordPayoutFailure records a failed payout of the settlement and returns how many have failed.
+func (s *Saga) RecordPayoutFailure(payoutID, reason string) (int, error) {
+	if !s.AwaitsPayout() {
+		return s.payoutFailures, ErrInvalidTransition
+	}
+	s.payoutID = payoutID
+	s.payoutFailures++
+	s.lastError = reason
+	s.updatedAt = shared.Now().UTC()
+	return s.payoutFailures, nil
+}
+
+// RecordError records the failure of a step that will be retried.
+func (s *Saga) RecordError(err error) {
+	s.lastError = err.Error()
+	s.updatedAt = shared.Now().UTC()
+}
+
+// Compensate starts undoing the completed steps of a running saga, recording why.
+func (s *Saga) Compensate(reason string) error {
+	if s.status != StatusRunning {
+		return ErrInvalidTransition
+	}
+	s.status = StatusCompensating
+	s.reason = reason
+	s.updatedAt = shared.Now().UTC()
+	return nil
+}
+
+// MarkSettlementReversed records that the settlement was reversed while compensating.
+func (s *Saga) MarkSettlementReversed() error {
+	if s.status != StatusCompensating {
+		return ErrInvalidTransition
+	}
+	s.advance(StepSettlementReversed)
+	return nil
+}
+
+// MarkInvoiceReopened records that the invoice was reopened, completing the compensation.
+func (s *Saga) MarkInvoiceReopened() error {
+	if s.status != StatusCompensating {
+		return ErrInvalidTransition
+	}
+	s.advance(StepInvoiceReopened)
+	s.finish(StatusCompensated)
+	return nil
+}
+
+// Fail records that the compensation cannot be completed, and why.
+func (s *Saga) Fail(cause string) error {
+	if s.status != StatusCompensating {
+		return ErrInvalidTransition
+	}
+	s.lastError = cause
+	s.finish(StatusFailed)
+	return nil
+}
+
+// advance records a completed step.
+func (s *Saga) advance(step Step) {
+	s.step = step
+	s.updatedAt = shared.Now().UTC()
+}
+
+// finish moves the saga to a terminal status.
+func (s *Saga) finish(status Status) {
+	now := shared.Now().UTC()
+	s.status = status
+	s.updatedAt = now
+	s.completedAt = &now
+}
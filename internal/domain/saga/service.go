@@ -0,0 +1,78 @@
+package saga
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	// defaultListLimit is the default page size for listing sagas.
+	defaultListLimit = 20
+	// maxListLimit is the maximum page size for listing sagas.
+	maxListLimit = 100
+)
+
+// Service defines the interface for following payment settlement sagas.
+type Service interface {
+	// ListSagas lists sagas, newest first.
+	ListSagas(ctx context.Context, req *ListSagasRequest) (*ListSagasResponse, error)
+
+	// GetSaga retrieves a saga.
+	GetSaga(ctx context.Context, id string) (*Saga, error)
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository Repository
+}
+
+// NewService creates a new saga service.
+func NewService(repository Repository) Service {
+	return &ServiceImpl{repository: repository}
+}
+
+// ListSagas lists sagas, newest first.
+func (s *ServiceImpl) ListSagas(ctx context.Context, req *ListSagasRequest) (*ListSagasResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: list sagas request cannot be nil", ErrInvalidRequest)
+	}
+	if req.Status != "" && !req.Status.IsValid() {
+		return nil, fmt.Errorf("%w: invalid status %s", ErrInvalidRequest, req.Status)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	return s.repository.List(ctx, &ListSagasRequest{
+		InvoiceID:  req.InvoiceID,
+		MerchantID: req.MerchantID,
+		Status:     req.Status,
+		Limit:      limit,
+		Cursor:     req.Cursor,
+	})
+}
+
+// GetSaga retrieves a saga.
+func (s *ServiceImpl) GetSaga(ctx context.Context, id string) (*Saga, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: saga ID is required", ErrInvalidRequest)
+	}
+
+	return s.repository.FindByID(ctx, id)
+}
+
+// generateID generates a random saga ID.
+func generateID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
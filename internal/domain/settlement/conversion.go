@@ -0,0 +1,153 @@
+package settlement
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Conversion is the exchange order selling a settlement's net amount for fiat.
+type Conversion struct {
+	exchange      string
+	orderID       string
+	status        ConversionStatus
+	fiatCurrency  string
+	amount        decimal.Decimal
+	fiatAmount    decimal.Decimal
+	fee           decimal.Decimal
+	failureReason string
+	submittedAt   time.Time
+	completedAt   *time.Time
+}
+
+// NewConversion creates a conversion for a sell order just placed on an exchange.
+func NewConversion(exchange, orderID, fiatCurrency string, amount decimal.Decimal) (*Conversion, error) {
+	return RestoreConversion(
+		exchange, orderID, ConversionStatusSubmitted, fiatCurrency, amount,
+		decimal.Zero, decimal.Zero, "", shared.Now().UTC(), nil,
+	)
+}
+
+// RestoreConversion recreates a conversion from storage.
+func RestoreConversion(
+	exchange, orderID string,
+	status ConversionStatus,
+	fiatCurrency string,
+	amount, fiatAmount, fee decimal.Decimal,
+	failureReason string,
+	submittedAt time.Time,
+	completedAt *time.Time,
+) (*Conversion, error) {
+	if exchange == "" || orderID == "" {
+		return nil, errors.New("conversion exchange and order ID are required")
+	}
+	if !status.IsValid() {
+		return nil, errors.New("invalid conversion status")
+	}
+	if fiatCurrency == "" {
+		return nil, errors.New("conversion fiat currency is required")
+	}
+	if !amount.IsPositive() {
+		return nil, errors.New("conversion amount must be positive")
+	}
+
+	return &Conversion{
+		exchange:      exchange,
+		orderID:       orderID,
+		status:        status,
+		fiatCurrency:  fiatCurrency,
+		amount:        amount,
+		fiatAmount:    fiatAmount,
+		fee:           fee,
+		failureReason: failureReason,
+		submittedAt:   submittedAt,
+		completedAt:   completedAt,
+	}, nil
+}
+
+// Exchange returns the exchange the order was placed on.
+func (c *Conversion) Exchange() string {
+	return c.exchange
+}
+
+// OrderID returns the exchange's identifier of the sell order.
+func (c *Conversion) OrderID() string {
+	return c.orderID
+}
+
+// Status returns where the sell order is in its lifecycle.
+func (c *Conversion) Status() ConversionStatus {
+	return c.status
+}
+
+// FiatCurrency returns the currency the cryptocurrency is sold for.
+func (c *Conversion) FiatCurrency() string {
+	return c.fiatCurrency
+}
+
+// Amount returns the amount of cryptocurrency offered for sale.
+func (c *Conversion) Amount() decimal.Decimal {
+	return c.amount
+}
+
+// FiatAmount returns the realized fiat amount, net of the exchange fee, once the order is filled.
+func (c *Conversion) FiatAmount() decimal.Decimal {
+	return c.fiatAmount
+}
+
+// Fee returns the fiat fee the exchange charged for the conversion.
+func (c *Conversion) Fee() decimal.Decimal {
+	return c.fee
+}
+
+// FailureReason returns why the order did not fill, if it failed.
+func (c *Conversion) FailureReason() string {
+	return c.failureReason
+}
+
+// SubmittedAt returns when the order was placed.
+func (c *Conversion) SubmittedAt() time.Time {
+	return c.submittedAt
+}
+
+// CompletedAt returns when the order was filled or failed.
+func (c *Conversion) CompletedAt() *time.Time {
+	return c.completedAt
+}
+
+// Rate returns the effective fiat price per unit of cryptocurrency, after the exchange fee.
+func (c *Conversion) Rate() decimal.Decimal {
+	if c.status != ConversionStatusFilled {
+		return decimal.Zero
+	}
+	return c.fiatAmount.Div(c.amount)
+}
+
+// apply records the outcome an exchange reports for the order. It returns false while the order is
+// still open.
+func (c *Conversion) apply(order *Order) bool {
+	now := shared.Now().UTC()
+
+	switch order.State {
+	case OrderStateFilled:
+		c.status = ConversionStatusFilled
+		c.fee = order.Fee
+		c.fiatAmount = order.Proceeds.Sub(order.Fee)
+		if order.ExecutedAmount.IsPositive() {
+			c.amount = order.ExecutedAmount
+		}
+	case OrderStateCancelled:
+		c.status = ConversionStatusFailed
+		c.failureReason = order.Reason
+		if c.failureReason == "" {
+			c.failureReason = "order cancelled by the exchange"
+		}
+	default:
+		return false
+	}
+
+	c.completedAt = &now
+	return true
+}
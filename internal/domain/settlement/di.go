@@ -0,0 +1,28 @@
+package settlement
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+
+	"go.uber.org/fx"
+)
+
+// Module provides the settlement service layer dependencies.
+var Module = fx.Module("settlement-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+		fx.Annotate(
+			NewFeeCalculator,
+			fx.As(fx.Self()),
+			fx.As(new(invoice.FeeQuoter)),
+		),
+		NewConversionSyncer,
+	),
+	fx.Invoke(
+		RegisterPaidInvoiceHandler,
+		RegisterPaymentReversalHandler,
+		RegisterConversionSyncer,
+	),
+)
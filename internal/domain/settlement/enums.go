@@ -0,0 +1,61 @@
+// Package settlement records what merchants receive for paid invoices once the platform fee is
+// deducted, and optionally sells the settled cryptocurrency for fiat through an exchange.
+package settlement
+
+// Status represents the current status of a settlement.
+type Status string
+
+const (
+	// StatusPending - Settlement awaits the fiat conversion of its funds
+	StatusPending Status = "pending"
+	// StatusCompleted - Funds settled to the merchant, converted to fiat if conversion is enabled
+	StatusCompleted Status = "completed"
+	// StatusFailed - Settlement could not be completed
+	StatusFailed Status = "failed"
+	// StatusReversed - Settled payment removed by a chain reorganization; a reversal entry offsets it
+	StatusReversed Status = "reversed"
+)
+
+// IsValid returns true if the settlement status is valid.
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusCompleted, StatusFailed, StatusReversed:
+		return true
+	default:
+		return false
+	}
+}
+
+// ConversionStatus represents the lifecycle of the exchange order converting a settlement to fiat.
+type ConversionStatus string
+
+const (
+	// ConversionStatusSubmitted - Sell order placed on the exchange and not yet filled
+	ConversionStatusSubmitted ConversionStatus = "submitted"
+	// ConversionStatusFilled - Sell order filled and the fiat proceeds credited
+	ConversionStatusFilled ConversionStatus = "filled"
+	// ConversionStatusFailed - Sell order cancelled, rejected or expired by the exchange
+	ConversionStatusFailed ConversionStatus = "failed"
+)
+
+// IsValid returns true if the conversion status is valid.
+func (s ConversionStatus) IsValid() bool {
+	switch s {
+	case ConversionStatusSubmitted, ConversionStatusFilled, ConversionStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// OrderState represents the state of a sell order as reported by an exchange.
+type OrderState string
+
+const (
+	// OrderStateOpen - Order accepted by the exchange and still executing
+	OrderStateOpen OrderState = "open"
+	// OrderStateFilled - Order fully executed
+	OrderStateFilled OrderState = "filled"
+	// OrderStateCancelled - Order cancelled, rejected or expired before it was fully executed
+	OrderStateCancelled OrderState = "cancelled"
+)
@@ -0,0 +1,19 @@
+package settlement
+
+import "errors"
+
+// Domain errors for settlement operations
+var (
+	ErrSettlementNotFound = errors.New("settlement not found")
+	ErrInvalidRequest     = errors.New("invalid settlement request")
+	ErrInvoiceNotPaid     = errors.New("invoice is not paid")
+	ErrInvalidTransition  = errors.New("settlement cannot change in its current state")
+	ErrExchangeFailure    = errors.New("exchange request failed")
+	ErrReversalNotFound   = errors.New("settlement reversal not found")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeSettlementNotFound = "SETTLEMENT_NOT_FOUND"
+	ErrCodeInvoiceNotPaid     = "INVOICE_NOT_PAID"
+)
@@ -0,0 +1,42 @@
+package settlement
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+// ExchangeAdapter sells settled cryptocurrency for fiat on an exchange.
+type ExchangeAdapter interface {
+	// Name returns the exchange name recorded with each conversion.
+	Name() string
+
+	// PlaceSellOrder places a market order selling an amount of cryptocurrency for fiat.
+	PlaceSellOrder(ctx context.Context, req *SellOrderRequest) (*Order, error)
+
+	// GetOrder returns the current state of an order placed by PlaceSellOrder.
+	GetOrder(ctx context.Context, orderID string) (*Order, error)
+}
+
+// SellOrderRequest describes the cryptocurrency to sell.
+type SellOrderRequest struct {
+	// ClientOrderID identifies the order on the exchange side; the settlement ID is used.
+	ClientOrderID string
+	Asset         string
+	FiatCurrency  string
+	Amount        decimal.Decimal
+}
+
+// Order is the exchange's view of a sell order.
+type Order struct {
+	ID    string
+	State OrderState
+	// ExecutedAmount is the amount of cryptocurrency sold so far.
+	ExecutedAmount decimal.Decimal
+	// Proceeds is the fiat amount the executed part of the order fetched, before the exchange fee.
+	Proceeds decimal.Decimal
+	// Fee is the fee the exchange charged, in fiat.
+	Fee decimal.Decimal
+	// Reason describes why a cancelled order did not fill, if the exchange reports it.
+	Reason string
+}
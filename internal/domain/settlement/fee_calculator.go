@@ -0,0 +1,164 @@
+package settlement
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// FeeVolumeWindow is the period before a settlement whose settled volume selects the merchant's volume tier.
+const FeeVolumeWindow = 30 * 24 * time.Hour
+
+// FeeScheduleSource supplies the fee schedules in force, which may change while the application runs.
+type FeeScheduleSource interface {
+	// MerchantFeeSchedule returns the schedule assigned to a merchant, or nil if none is.
+	MerchantFeeSchedule(merchantID string) *FeeSchedule
+
+	// DefaultFeeSchedule returns the schedule of the other merchants, or nil if there is none.
+	DefaultFeeSchedule() *FeeSchedule
+}
+
+// Fee is the platform fee chosen for a settlement.
+type Fee struct {
+	Percentage decimal.Decimal
+	// Minimum is the smallest fee amount charged, in the settlement currency; zero for none.
+	Minimum decimal.Decimal
+	Tier    FeeTier
+}
+
+// FeeCalculator chooses the platform fee of settlements.
+type FeeCalculator struct {
+	repository  Repository
+	merchants   merchant.MerchantRepository
+	schedules   FeeScheduleSource
+	platformFee decimal.Decimal
+	logger      *zap.Logger
+}
+
+// NewFeeCalculator creates a fee calculator charging the policy's platform fee percentage when no other rate
+// applies. The merchants and schedules may be nil, in which case merchants' own fee percentages or fee
+// schedules are not looked up.
+func NewFeeCalculator(
+	repository Repository,
+	merchants merchant.MerchantRepository,
+	schedules FeeScheduleSource,
+	policy Policy,
+	logger *zap.Logger,
+) *FeeCalculator {
+	return &FeeCalculator{
+		repository:  repository,
+		merchants:   merchants,
+		schedules:   schedules,
+		platformFee: policy.PlatformFeePercentage,
+		logger:      logger,
+	}
+}
+
+// Calculate chooses the platform fee of a merchant's settlement, created at a time, of an invoice paid on a
+// network. The fee schedule assigned to the merchant applies first, then the merchant's own fee percentage,
+// then the default fee schedule and finally the platform fee percentage. A schedule picks its rate by the
+// merchant's settled volume over FeeVolumeWindow, and charges its minimum fee in the settlement currency.
+func (c *FeeCalculator) Calculate(
+	ctx context.Context,
+	merchantID string,
+	network shared.BlockchainNetwork,
+	currency string,
+	at time.Time,
+) (*Fee, error) {
+	if schedule := c.merchantSchedule(merchantID); schedule != nil {
+		return c.scheduled(ctx, schedule, merchantID, network, currency, at)
+	}
+	if percentage, ok := c.merchantPercentage(ctx, merchantID); ok {
+		return &Fee{Percentage: percentage, Tier: FeeTier{Kind: FeeTierFlat, Name: FeeTierMerchant}}, nil
+	}
+	if c.schedules != nil {
+		if schedule := c.schedules.DefaultFeeSchedule(); schedule != nil {
+			return c.scheduled(ctx, schedule, merchantID, network, currency, at)
+		}
+	}
+	return &Fee{Percentage: c.platformFee, Tier: FeeTier{Kind: FeeTierFlat, Name: FeeTierPlatform}}, nil
+}
+
+// QuoteFeePercentage returns the fee percentage a merchant's settlement of an invoice paid in a currency on a
+// network would be charged now, for invoices passing the fee through to customers.
+func (c *FeeCalculator) QuoteFeePercentage(
+	ctx context.Context,
+	merchantID string,
+	network shared.BlockchainNetwork,
+	currency string,
+) (decimal.Decimal, error) {
+	fee, err := c.Calculate(ctx, merchantID, network, currency, shared.Now())
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return fee.Percentage, nil
+}
+
+// scheduled returns the fee a schedule charges for a merchant's settlement.
+func (c *FeeCalculator) scheduled(
+	ctx context.Context,
+	schedule *FeeSchedule,
+	merchantID string,
+	network shared.BlockchainNetwork,
+	currency string,
+	at time.Time,
+) (*Fee, error) {
+	volume, err := c.volume(ctx, merchantID, at)
+	if err != nil {
+		return nil, err
+	}
+	percentage, tier := schedule.Rate(network, volume, at)
+	fee := &Fee{Percentage: percentage, Tier: tier}
+	if tier.Kind != FeeTierPromotion {
+		fee.Minimum = schedule.MinimumFee(currency)
+	}
+	return fee, nil
+}
+
+// volume returns the gross amount settled to a merchant over FeeVolumeWindow before a time, net of reversals.
+func (c *FeeCalculator) volume(ctx context.Context, merchantID string, at time.Time) (decimal.Decimal, error) {
+	from := at.Add(-FeeVolumeWindow)
+	resp, err := c.repository.List(ctx, &ListSettlementsRequest{
+		MerchantID: merchantID,
+		From:       &from,
+		To:         &at,
+		Limit:      1,
+	})
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get settled volume: %w", err)
+	}
+	return resp.Summary.TotalGrossAmount, nil
+}
+
+// merchantSchedule returns the fee schedule assigned to a merchant, or nil if none is.
+func (c *FeeCalculator) merchantSchedule(merchantID string) *FeeSchedule {
+	if c.schedules == nil || merchantID == "" {
+		return nil
+	}
+	return c.schedules.MerchantFeeSchedule(merchantID)
+}
+
+// merchantPercentage returns the fee percentage set for a merchant, reporting false if it has none.
+// Merchants that cannot be loaded are treated as having none so the settlement is not lost.
+func (c *FeeCalculator) merchantPercentage(ctx context.Context, merchantID string) (decimal.Decimal, bool) {
+	if c.merchants == nil || merchantID == "" {
+		return decimal.Zero, false
+	}
+
+	m, err := c.merchants.FindByID(ctx, merchantID)
+	if err != nil {
+		c.logger.Warn("Failed to load merchant fee percentage; applying the platform's fees",
+			zap.String("merchant_id", merchantID),
+			zap.Error(err))
+		return decimal.Zero, false
+	}
+	if m.Settings() == nil || m.Settings().FeePercentage <= 0 {
+		return decimal.Zero, false
+	}
+	return decimal.NewFromFloat(m.Settings().FeePercentage), true
+}
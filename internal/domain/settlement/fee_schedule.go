@@ -0,0 +1,219 @@
+package settlement
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultFeeScheduleName is the fee schedule of merchants no schedule is assigned to and who have no fee
+// percentage of their own.
+const DefaultFeeScheduleName = "default"
+
+// FeeTierKind is where the fee percentage of a settlement came from.
+type FeeTierKind string
+
+const (
+	// FeeTierFlat - The merchant's own fee percentage, the platform fee percentage, or an invoice's quoted fee
+	FeeTierFlat FeeTierKind = "flat"
+	// FeeTierVolume - The volume tier the merchant's 30-day settled volume reached
+	FeeTierVolume FeeTierKind = "volume"
+	// FeeTierNetwork - The schedule's rate for the network the invoice was paid on
+	FeeTierNetwork FeeTierKind = "network"
+	// FeeTierPromotion - A promotional window in which no fee is charged
+	FeeTierPromotion FeeTierKind = "promotion"
+)
+
+// IsValid returns true if the fee tier kind is valid.
+func (k FeeTierKind) IsValid() bool {
+	switch k {
+	case FeeTierFlat, FeeTierVolume, FeeTierNetwork, FeeTierPromotion:
+		return true
+	default:
+		return false
+	}
+}
+
+// Names of flat fee tiers.
+const (
+	// FeeTierMerchant - The merchant's own fee percentage
+	FeeTierMerchant = "merchant"
+	// FeeTierPlatform - The platform fee percentage
+	FeeTierPlatform = "platform"
+	// FeeTierInvoice - The fee percentage quoted on an invoice that passed the fee through to the customer
+	FeeTierInvoice = "invoice"
+)
+
+// FeeTier records how the platform fee of a settlement was chosen, so that it can be audited later.
+type FeeTier struct {
+	// Schedule is the fee schedule the rate came from; empty for a flat fee.
+	Schedule string
+	Kind     FeeTierKind
+	// Name identifies the rate within its kind: the minimum volume of a volume tier, the network of a network
+	// rate, the name of a promotion, or merchant or platform for a flat fee.
+	Name string
+	// Volume is the merchant's settled volume over the 30 days before the settlement, when a schedule was used.
+	Volume decimal.Decimal
+	// MinimumApplied is set when the schedule's minimum fee was charged instead of the percentage.
+	MinimumApplied bool
+}
+
+// VolumeTier is the fee percentage charged to merchants whose 30-day settled volume reached MinVolume.
+type VolumeTier struct {
+	MinVolume  decimal.Decimal
+	Percentage decimal.Decimal
+}
+
+// FeePromotion is a window, starting at StartsAt and ending before EndsAt, in which no platform fee is charged.
+type FeePromotion struct {
+	Name     string
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+// FeeSchedule is a set of platform fee rates: percentages by 30-day settled volume, rates overriding them on
+// some networks, zero-fee promotions and minimum fee amounts by settlement currency.
+type FeeSchedule struct {
+	name        string
+	tiers       []VolumeTier
+	networks    map[shared.BlockchainNetwork]decimal.Decimal
+	promotions  []FeePromotion
+	minimumFees map[string]decimal.Decimal
+}
+
+// NewFeeSchedule creates a fee schedule. Its volume tiers are sorted by minimum volume, the lowest of which must
+// be zero so that every merchant falls in a tier. Minimum fees are keyed by currency, e.g. USDT.
+func NewFeeSchedule(
+	name string,
+	tiers []VolumeTier,
+	networks map[shared.BlockchainNetwork]decimal.Decimal,
+	promotions []FeePromotion,
+	minimumFees map[string]decimal.Decimal,
+) (*FeeSchedule, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: fee schedule name is required", ErrInvalidRequest)
+	}
+	if len(tiers) == 0 {
+		return nil, fmt.Errorf("%w: fee schedule %s needs a volume tier", ErrInvalidRequest, name)
+	}
+
+	sorted := append([]VolumeTier(nil), tiers...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].MinVolume.LessThan(sorted[j].MinVolume) })
+	if !sorted[0].MinVolume.IsZero() {
+		return nil, fmt.Errorf("%w: the lowest volume tier of fee schedule %s must start at 0", ErrInvalidRequest, name)
+	}
+	for i, tier := range sorted {
+		if i > 0 && tier.MinVolume.Equal(sorted[i-1].MinVolume) {
+			return nil, fmt.Errorf("%w: fee schedule %s has two tiers from volume %s",
+				ErrInvalidRequest, name, tier.MinVolume)
+		}
+		if !validFeePercentage(tier.Percentage) {
+			return nil, fmt.Errorf("%w: fee percentages must be between 0 and 100", ErrInvalidRequest)
+		}
+	}
+
+	rates := make(map[shared.BlockchainNetwork]decimal.Decimal, len(networks))
+	for network, percentage := range networks {
+		if !network.IsValid() {
+			return nil, fmt.Errorf("%w: unknown network %q", ErrInvalidRequest, network)
+		}
+		if !validFeePercentage(percentage) {
+			return nil, fmt.Errorf("%w: fee percentages must be between 0 and 100", ErrInvalidRequest)
+		}
+		rates[network.Mainnet()] = percentage
+	}
+	for _, promotion := range promotions {
+		if promotion.Name == "" {
+			return nil, fmt.Errorf("%w: fee promotion name is required", ErrInvalidRequest)
+		}
+		if !promotion.EndsAt.After(promotion.StartsAt) {
+			return nil, fmt.Errorf("%w: fee promotion %s must end after it starts", ErrInvalidRequest, promotion.Name)
+		}
+	}
+	minimums := make(map[string]decimal.Decimal, len(minimumFees))
+	for currency, minimum := range minimumFees {
+		if minimum.IsNegative() {
+			return nil, fmt.Errorf("%w: minimum fee in %s cannot be negative", ErrInvalidRequest, currency)
+		}
+		minimums[strings.ToUpper(currency)] = minimum
+	}
+
+	return &FeeSchedule{
+		name:        name,
+		tiers:       sorted,
+		networks:    rates,
+		promotions:  promotions,
+		minimumFees: minimums,
+	}, nil
+}
+
+// Name returns the name of the schedule.
+func (s *FeeSchedule) Name() string {
+	return s.name
+}
+
+// Tiers returns the volume tiers, lowest minimum volume first.
+func (s *FeeSchedule) Tiers() []VolumeTier {
+	return s.tiers
+}
+
+// Networks returns the fee percentages overriding the volume tiers on some networks, which apply to their
+// testnets too.
+func (s *FeeSchedule) Networks() map[shared.BlockchainNetwork]decimal.Decimal {
+	return s.networks
+}
+
+// Promotions returns the zero-fee windows.
+func (s *FeeSchedule) Promotions() []FeePromotion {
+	return s.promotions
+}
+
+// MinimumFees returns the smallest fee charged per settlement, by currency.
+func (s *FeeSchedule) MinimumFees() map[string]decimal.Decimal {
+	return s.minimumFees
+}
+
+// Rate returns the fee percentage the schedule charges at a time for a settlement of an invoice paid on a
+// network, for a merchant that settled volume over the last 30 days, along with the tier it was chosen from.
+// A promotion running at the time waives the fee; otherwise the network's rate applies, or the highest volume
+// tier the volume reached.
+func (s *FeeSchedule) Rate(network shared.BlockchainNetwork, volume decimal.Decimal, at time.Time) (
+	decimal.Decimal,
+	FeeTier,
+) {
+	tier := FeeTier{Schedule: s.name, Volume: volume}
+	for _, promotion := range s.promotions {
+		if !at.Before(promotion.StartsAt) && at.Before(promotion.EndsAt) {
+			tier.Kind, tier.Name = FeeTierPromotion, promotion.Name
+			return decimal.Zero, tier
+		}
+	}
+	if percentage, ok := s.networks[network.Mainnet()]; network != "" && ok {
+		tier.Kind, tier.Name = FeeTierNetwork, string(network.Mainnet())
+		return percentage, tier
+	}
+
+	chosen := s.tiers[0]
+	for _, candidate := range s.tiers[1:] {
+		if volume.LessThan(candidate.MinVolume) {
+			break
+		}
+		chosen = candidate
+	}
+	tier.Kind, tier.Name = FeeTierVolume, chosen.MinVolume.String()
+	return chosen.Percentage, tier
+}
+
+// MinimumFee returns the smallest fee charged for a settlement in a currency, zero if there is none.
+func (s *FeeSchedule) MinimumFee(currency string) decimal.Decimal {
+	return s.minimumFees[strings.ToUpper(currency)]
+}
+
+// validFeePercentage reports whether a fee percentage is between 0 and 100.
+func validFeePercentage(percentage decimal.Decimal) bool {
+	return !percentage.IsNegative() && !percentage.GreaterThan(hundred)
+}
@@ -0,0 +1,218 @@
+package settlement_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var promotionStart = time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+
+func newFeeSchedule(t *testing.T) *settlement.FeeSchedule {
+	t.Helper()
+	schedule, err := settlement.NewFeeSchedule(
+		"growth",
+		[]settlement.VolumeTier{
+			{MinVolume: decimal.RequireFromString("100000"), Percentage: decimal.RequireFromString("0.5")},
+			{MinVolume: decimal.Zero, Percentage: decimal.RequireFromString("1.5")},
+			{MinVolume: decimal.RequireFromString("10000"), Percentage: decimal.RequireFromString("1")},
+		},
+		map[shared.BlockchainNetwork]decimal.Decimal{shared.NetworkEthereum: decimal.RequireFromString("2")},
+		[]settlement.FeePromotion{
+			{Name: "black-friday", StartsAt: promotionStart, EndsAt: promotionStart.Add(72 * time.Hour)},
+		},
+		map[string]decimal.Decimal{"usdt": decimal.RequireFromString("0.5")},
+	)
+	require.NoError(t, err)
+	return schedule
+}
+
+func TestFeeSchedule_Rate(t *testing.T) {
+	schedule := newFeeSchedule(t)
+	before := promotionStart.Add(-time.Hour)
+
+	tests := map[string]struct {
+		network    shared.BlockchainNetwork
+		volume     string
+		at         time.Time
+		percentage string
+		kind       settlement.FeeTierKind
+		name       string
+	}{
+		"lowest tier":        {shared.NetworkTron, "0", before, "1.5", settlement.FeeTierVolume, "0"},
+		"tier reached":       {shared.NetworkTron, "10000", before, "1", settlement.FeeTierVolume, "10000"},
+		"highest tier":       {shared.NetworkTron, "250000", before, "0.5", settlement.FeeTierVolume, "100000"},
+		"network rate":       {shared.NetworkEthereum, "250000", before, "2", settlement.FeeTierNetwork, "ethereum"},
+		"testnet of network": {shared.NetworkEthereumSepolia, "0", before, "2", settlement.FeeTierNetwork, "ethereum"},
+		"promotion": {
+			shared.NetworkEthereum, "0", promotionStart, "0", settlement.FeeTierPromotion, "black-friday",
+		},
+		"promotion ended": {
+			shared.NetworkTron, "0", promotionStart.Add(72 * time.Hour), "1.5", settlement.FeeTierVolume, "0",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			percentage, tier := schedule.Rate(tt.network, decimal.RequireFromString(tt.volume), tt.at)
+			assert.Equal(t, tt.percentage, percentage.String())
+			assert.Equal(t, "growth", tier.Schedule)
+			assert.Equal(t, tt.kind, tier.Kind)
+			assert.Equal(t, tt.name, tier.Name)
+			assert.Equal(t, tt.volume, tier.Volume.String())
+		})
+	}
+
+	assert.Equal(t, "0.5", schedule.MinimumFee("USDT").String())
+	assert.True(t, schedule.MinimumFee("USDC").IsZero())
+}
+
+func TestNewFeeSchedule_Validation(t *testing.T) {
+	tier := func(minVolume, percentage string) settlement.VolumeTier {
+		return settlement.VolumeTier{
+			MinVolume:  decimal.RequireFromString(minVolume),
+			Percentage: decimal.RequireFromString(percentage),
+		}
+	}
+
+	tests := map[string]struct {
+		tiers      []settlement.VolumeTier
+		networks   map[shared.BlockchainNetwork]decimal.Decimal
+		promotions []settlement.FeePromotion
+		minimums   map[string]decimal.Decimal
+	}{
+		"no tiers":            {},
+		"lowest tier above 0": {tiers: []settlement.VolumeTier{tier("100", "1")}},
+		"repeated volume":     {tiers: []settlement.VolumeTier{tier("0", "1"), tier("0", "2")}},
+		"percentage over 100": {tiers: []settlement.VolumeTier{tier("0", "101")}},
+		"unknown network": {
+			tiers:    []settlement.VolumeTier{tier("0", "1")},
+			networks: map[shared.BlockchainNetwork]decimal.Decimal{"dogecoin": decimal.NewFromInt(1)},
+		},
+		"promotion ending before it starts": {
+			tiers:      []settlement.VolumeTier{tier("0", "1")},
+			promotions: []settlement.FeePromotion{{Name: "launch", StartsAt: promotionStart, EndsAt: promotionStart}},
+		},
+		"negative minimum": {
+			tiers:    []settlement.VolumeTier{tier("0", "1")},
+			minimums: map[string]decimal.Decimal{"USDT": decimal.NewFromInt(-1)},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := settlement.NewFeeSchedule("growth", tt.tiers, tt.networks, tt.promotions, tt.minimums)
+			require.ErrorIs(t, err, settlement.ErrInvalidRequest)
+		})
+	}
+}
+
+func TestNewScheduledSettlement_MinimumFee(t *testing.T) {
+	fee := &settlement.Fee{
+		Percentage: decimal.RequireFromString("1"),
+		Minimum:    decimal.RequireFromString("0.5"),
+		Tier:       settlement.FeeTier{Schedule: "growth", Kind: settlement.FeeTierVolume, Name: "0"},
+	}
+
+	s, err := settlement.NewScheduledSettlement(
+		"settlement-1", "invoice-1", "merchant-1", decimal.RequireFromString("100"), "USDT", fee,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "1", s.FeeAmount().String())
+	require.NotNil(t, s.FeeTier())
+	assert.False(t, s.FeeTier().MinimumApplied)
+
+	s, err = settlement.NewScheduledSettlement(
+		"settlement-2", "invoice-2", "merchant-1", decimal.RequireFromString("20"), "USDT", fee,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "0.5", s.FeeAmount().String())
+	assert.Equal(t, "19.5", s.NetAmount().String())
+	assert.True(t, s.FeeTier().MinimumApplied)
+	assert.Equal(t, "growth", s.FeeTier().Schedule)
+
+	share, err := s.Split("settlement-3", "seller-1", decimal.RequireFromString("19.9"))
+	require.NoError(t, err)
+	assert.False(t, share.FeeTier().MinimumApplied)
+	assert.Equal(t, "0.1", s.GrossAmount().String())
+	assert.Equal(t, "0.1", s.FeeAmount().String(), "the merchant's fee is capped at what it keeps")
+	assert.True(t, s.NetAmount().IsZero())
+}
+
+// volumeRepository reports a fixed settled volume; only List is implemented.
+type volumeRepository struct {
+	settlement.Repository
+	volume decimal.Decimal
+}
+
+func (r *volumeRepository) List(
+	_ context.Context,
+	_ *settlement.ListSettlementsRequest,
+) (*settlement.ListSettlementsResponse, error) {
+	return &settlement.ListSettlementsResponse{Summary: settlement.Summary{TotalGrossAmount: r.volume}}, nil
+}
+
+// feeSchedules assigns a schedule to merchant-1 and a default schedule to the others.
+type feeSchedules struct {
+	assigned, fallback *settlement.FeeSchedule
+}
+
+func (s *feeSchedules) MerchantFeeSchedule(merchantID string) *settlement.FeeSchedule {
+	if merchantID == "merchant-1" {
+		return s.assigned
+	}
+	return nil
+}
+
+func (s *feeSchedules) DefaultFeeSchedule() *settlement.FeeSchedule {
+	return s.fallback
+}
+
+func TestFeeCalculator_Calculate(t *testing.T) {
+	ctx := context.Background()
+	repository := &volumeRepository{volume: decimal.RequireFromString("20000")}
+	policy := settlement.Policy{PlatformFeePercentage: decimal.RequireFromString("2.5")}
+	before := promotionStart.Add(-time.Hour)
+
+	t.Run("assigned schedule", func(t *testing.T) {
+		calculator := settlement.NewFeeCalculator(
+			repository, nil, &feeSchedules{assigned: newFeeSchedule(t)}, policy, zap.NewNop(),
+		)
+
+		fee, err := calculator.Calculate(ctx, "merchant-1", shared.NetworkTron, "USDT", before)
+		require.NoError(t, err)
+		assert.Equal(t, "1", fee.Percentage.String())
+		assert.Equal(t, "0.5", fee.Minimum.String())
+		assert.Equal(t, settlement.FeeTierVolume, fee.Tier.Kind)
+		assert.Equal(t, "20000", fee.Tier.Volume.String())
+
+		fee, err = calculator.Calculate(ctx, "merchant-1", shared.NetworkTron, "USDT", promotionStart)
+		require.NoError(t, err)
+		assert.True(t, fee.Percentage.IsZero())
+		assert.True(t, fee.Minimum.IsZero(), "promotions waive the minimum fee")
+		assert.Equal(t, settlement.FeeTierPromotion, fee.Tier.Kind)
+
+		fee, err = calculator.Calculate(ctx, "merchant-2", shared.NetworkTron, "USDT", before)
+		require.NoError(t, err)
+		assert.Equal(t, "2.5", fee.Percentage.String())
+		assert.Equal(t, settlement.FeeTier{Kind: settlement.FeeTierFlat, Name: settlement.FeeTierPlatform}, fee.Tier)
+	})
+
+	t.Run("default schedule", func(t *testing.T) {
+		calculator := settlement.NewFeeCalculator(
+			repository, nil, &feeSchedules{fallback: newFeeSchedule(t)}, policy, zap.NewNop(),
+		)
+
+		fee, err := calculator.Calculate(ctx, "merchant-2", shared.NetworkEthereum, "USDT", before)
+		require.NoError(t, err)
+		assert.Equal(t, "2", fee.Percentage.String())
+		assert.Equal(t, settlement.FeeTierNetwork, fee.Tier.Kind)
+	})
+}
@@ -0,0 +1,64 @@
+package settlement
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// PaidInvoiceHandler settles invoices as they become paid.
+type PaidInvoiceHandler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewPaidInvoiceHandler creates a new paid invoice handler.
+func NewPaidInvoiceHandler(service Service, logger *zap.Logger) *PaidInvoiceHandler {
+	return &PaidInvoiceHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterPaidInvoiceHandler subscribes a paid invoice handler to invoice events.
+func RegisterPaidInvoiceHandler(registry shared.EventHandlerRegistry, service Service, logger *zap.Logger) {
+	registry.RegisterHandler(NewPaidInvoiceHandler(service, logger))
+}
+
+// EventTypes returns the invoice events that may report an invoice as paid.
+func (h *PaidInvoiceHandler) EventTypes() []string {
+	return []string{
+		shared.EventTypeInvoiceStatusChanged,
+		shared.EventTypeInvoicePaid,
+	}
+}
+
+// HandleEvent settles the invoice an event reports as paid, or as closed underpaid with the payments
+// received credited to the merchant. Events for invoices in other states are ignored.
+func (h *PaidInvoiceHandler) HandleEvent(ctx context.Context, event *shared.BaseDomainEvent) error {
+	var data shared.InvoiceStatusChangedEvent
+	if err := shared.DecodeEventData(event, &data); err != nil {
+		return fmt.Errorf("failed to decode invoice event: %w", err)
+	}
+	switch data.Status {
+	case invoice.StatusPaid.String():
+	case invoice.StatusUnderpaidClosed.String():
+		if data.Resolution != invoice.UnderpaymentResolutionCredit.String() {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	_, err := h.service.SettleInvoice(ctx, event.AggregateID)
+	if errors.Is(err, ErrInvoiceNotPaid) {
+		h.logger.Debug("Skipping settlement of invoice no longer paid",
+			zap.String("invoice_id", event.AggregateID))
+		return nil
+	}
+	return err
+}
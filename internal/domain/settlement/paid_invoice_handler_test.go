@@ -0,0 +1,50 @@
+package settlement_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// recordingService records the invoices settled; only SettleInvoice is implemented.
+type recordingService struct {
+	settlement.Service
+	settled []string
+}
+
+func (s *recordingService) SettleInvoice(_ context.Context, invoiceID string) (*settlement.Settlement, error) {
+	s.settled = append(s.settled, invoiceID)
+	return nil, nil
+}
+
+func TestPaidInvoiceHandler_HandleEvent(t *testing.T) {
+	tests := map[string]struct {
+		data   map[string]interface{}
+		settle bool
+	}{
+		"Paid":                  {map[string]interface{}{"status": "paid"}, true},
+		"Partial":               {map[string]interface{}{"status": "partial"}, false},
+		"UnderpaidClosedCredit": {map[string]interface{}{"status": "underpaid_closed", "resolution": "credit"}, true},
+		"UnderpaidClosedRefund": {map[string]interface{}{"status": "underpaid_closed", "resolution": "refund"}, false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := &recordingService{}
+			handler := settlement.NewPaidInvoiceHandler(service, zap.NewNop())
+			event := shared.CreateDomainEvent(shared.EventTypeInvoiceStatusChanged, "invoice-1", "Invoice", tt.data, nil)
+
+			require.NoError(t, handler.HandleEvent(context.Background(), event))
+			if tt.settle {
+				assert.Equal(t, []string{"invoice-1"}, service.settled)
+			} else {
+				assert.Empty(t, service.settled)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package settlement
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Repository defines the interface for settlement persistence.
+type Repository interface {
+	// Save persists a new settlement together with the beneficiaries' shares split from it. It fails, saving
+	// none of them, if the invoice already has a settlement for the same merchant that is not reversed.
+	Save(ctx context.Context, settlement *Settlement, splits ...*Settlement) error
+
+	// FindByID retrieves a settlement by its ID.
+	FindByID(ctx context.Context, id string) (*Settlement, error)
+
+	// FindByInvoiceID retrieves the latest settlement of an invoice for the invoice's merchant, leaving out the
+	// beneficiaries' shares.
+	FindByInvoiceID(ctx context.Context, invoiceID string) (*Settlement, error)
+
+	// FindSplits retrieves the beneficiaries' shares split from a settlement.
+	FindSplits(ctx context.Context, settlementID string) ([]*Settlement, error)
+
+	// SaveReversal stores a reversed settlement together with the reversal entry offsetting it.
+	SaveReversal(ctx context.Context, settlement *Settlement, reversal *Reversal) error
+
+	// FindReversal retrieves the reversal entry of a settlement.
+	FindReversal(ctx context.Context, settlementID string) (*Reversal, error)
+
+	// FindPending retrieves up to limit pending settlements, oldest first.
+	FindPending(ctx context.Context, limit int) ([]*Settlement, error)
+
+	// List retrieves settlements matching the filter, newest first, with totals over the whole filter.
+	List(ctx context.Context, req *ListSettlementsRequest) (*ListSettlementsResponse, error)
+
+	// Update updates an existing settlement.
+	Update(ctx context.Context, settlement *Settlement) error
+
+	// FindAwaitingPayout retrieves up to limit settlements awaiting an on-chain payout, oldest first.
+	FindAwaitingPayout(ctx context.Context, limit int) ([]*Settlement, error)
+
+	// AssignPayout includes the settlements awaiting payout among the given ones in a payout,
+	// returning how many were assigned. Settlements already in a payout are left alone.
+	AssignPayout(ctx context.Context, payoutID string, settlementIDs []string) (int, error)
+
+	// ReleasePayout removes every settlement from a payout, so they await payout again.
+	ReleasePayout(ctx context.Context, payoutID string) error
+}
+
+// ListSettlementsRequest represents the request to list settlements.
+// Empty filter fields match all settlements.
+type ListSettlementsRequest struct {
+	MerchantID string
+	Status     Status
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	// Cursor continues a listing after the page that returned it.
+	Cursor *shared.Cursor
+}
+
+// ListSettlementsResponse represents the response from listing settlements.
+type ListSettlementsResponse struct {
+	Settlements []*Settlement
+	Summary     Summary
+	Limit       int
+	// NextCursor continues the listing after this page, or is nil on the last page.
+	NextCursor *shared.Cursor
+}
+
+// Summary totals the settlements matching a listing filter. Without a status filter the totals are net of
+// the reversal entries recorded in the period.
+type Summary struct {
+	Count            int
+	TotalGrossAmount decimal.Decimal
+	TotalFeeAmount   decimal.Decimal
+	TotalNetAmount   decimal.Decimal
+}
+
+// Add includes a settlement's amounts in the totals.
+func (s *Summary) Add(grossAmount, feeAmount, netAmount decimal.Decimal) {
+	s.Count++
+	s.TotalGrossAmount = s.TotalGrossAmount.Add(grossAmount)
+	s.TotalFeeAmount = s.TotalFeeAmount.Add(feeAmount)
+	s.TotalNetAmount = s.TotalNetAmount.Add(netAmount)
+}
+
+// AddReversal includes a reversal entry's negative amounts in the totals without counting a settlement.
+func (s *Summary) AddReversal(grossAmount, feeAmount, netAmount decimal.Decimal) {
+	s.TotalGrossAmount = s.TotalGrossAmount.Add(grossAmount)
+	s.TotalFeeAmount = s.TotalFeeAmount.Add(feeAmount)
+	s.TotalNetAmount = s.TotalNetAmount.Add(netAmount)
+}
+
+// AverageFeePercentage returns the platform fee as a percentage of the total gross amount.
+func (s *Summary) AverageFeePercentage() decimal.Decimal {
+	if !s.TotalGrossAmount.IsPositive() {
+		return decimal.Zero
+	}
+	return s.TotalFeeAmount.Div(s.TotalGrossAmount).Mul(hundred).Round(3)
+}
@@ -0,0 +1,140 @@
+package settlement
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Reversal is the ledger entry offsetting a settlement whose payment a chain reorganization removed after
+// it settled. Its amounts are the settlement's amounts negated, so the merchant's totals no longer count
+// funds that never stayed on the chain.
+type Reversal struct {
+	id           string
+	settlementID string
+	invoiceID    string
+	merchantID   string
+	paymentID    string
+	grossAmount  decimal.Decimal
+	feeAmount    decimal.Decimal
+	netAmount    decimal.Decimal
+	currency     string
+	reason       string
+	payoutID     string
+	createdAt    time.Time
+}
+
+// RestoreReversal recreates a settlement reversal from storage.
+func RestoreReversal(
+	id, settlementID, invoiceID, merchantID, paymentID string,
+	grossAmount, feeAmount, netAmount decimal.Decimal,
+	currency, reason, payoutID string,
+	createdAt time.Time,
+) (*Reversal, error) {
+	if id == "" {
+		return nil, errors.New("reversal ID is required")
+	}
+	if settlementID == "" {
+		return nil, errors.New("settlement ID is required")
+	}
+	if grossAmount.IsPositive() || netAmount.IsPositive() {
+		return nil, errors.New("reversal amounts cannot be positive")
+	}
+
+	return &Reversal{
+		id:           id,
+		settlementID: settlementID,
+		invoiceID:    invoiceID,
+		merchantID:   merchantID,
+		paymentID:    paymentID,
+		grossAmount:  grossAmount,
+		feeAmount:    feeAmount,
+		netAmount:    netAmount,
+		currency:     currency,
+		reason:       reason,
+		payoutID:     payoutID,
+		createdAt:    createdAt,
+	}, nil
+}
+
+// ID returns the reversal ID.
+func (r *Reversal) ID() string {
+	return r.id
+}
+
+// SettlementID returns the settlement the reversal offsets.
+func (r *Reversal) SettlementID() string {
+	return r.settlementID
+}
+
+// InvoiceID returns the invoice whose payment was reversed.
+func (r *Reversal) InvoiceID() string {
+	return r.invoiceID
+}
+
+// MerchantID returns the merchant the reversal is charged to.
+func (r *Reversal) MerchantID() string {
+	return r.merchantID
+}
+
+// PaymentID returns the payment the reorganization removed.
+func (r *Reversal) PaymentID() string {
+	return r.paymentID
+}
+
+// GrossAmount returns the negated gross amount of the settlement.
+func (r *Reversal) GrossAmount() decimal.Decimal {
+	return r.grossAmount
+}
+
+// FeeAmount returns the negated platform fee of the settlement.
+func (r *Reversal) FeeAmount() decimal.Decimal {
+	return r.feeAmount
+}
+
+// NetAmount returns the negated net amount of the settlement, what is taken back from the merchant.
+func (r *Reversal) NetAmount() decimal.Decimal {
+	return r.netAmount
+}
+
+// Currency returns the cryptocurrency of the amounts.
+func (r *Reversal) Currency() string {
+	return r.currency
+}
+
+// Reason explains why the settlement was reversed.
+func (r *Reversal) Reason() string {
+	return r.reason
+}
+
+// PayoutID returns the payout that had already transferred the net amount, or empty if none had. A
+// reversal with a payout leaves the merchant owing the net amount.
+func (r *Reversal) PayoutID() string {
+	return r.payoutID
+}
+
+// CreatedAt returns when the reversal was recorded.
+func (r *Reversal) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// Reverse marks the settlement as reversed because the reorganization removed one of its payments, and
+// returns the reversal entry offsetting it. A reversed settlement no longer awaits payout or conversion.
+func (s *Settlement) Reverse(id, paymentID, reason string) (*Reversal, error) {
+	if s.status == StatusReversed {
+		return nil, ErrInvalidTransition
+	}
+
+	reversal, err := RestoreReversal(
+		id, s.id, s.invoiceID, s.merchantID, paymentID,
+		s.grossAmount.Neg(), s.feeAmount.Neg(), s.netAmount.Neg(),
+		s.currency, reason, s.payoutID, shared.Now().UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.status = StatusReversed
+	return reversal, nil
+}
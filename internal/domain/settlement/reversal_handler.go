@@ -0,0 +1,51 @@
+package settlement
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// PaymentReversalHandler reverses the settlements of invoices whose payments a deep reorganization removed.
+type PaymentReversalHandler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewPaymentReversalHandler creates a new payment reversal handler.
+func NewPaymentReversalHandler(service Service, logger *zap.Logger) *PaymentReversalHandler {
+	return &PaymentReversalHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterPaymentReversalHandler subscribes a payment reversal handler to invoice events.
+func RegisterPaymentReversalHandler(registry shared.EventHandlerRegistry, service Service, logger *zap.Logger) {
+	registry.RegisterHandler(NewPaymentReversalHandler(service, logger))
+}
+
+// EventTypes returns the invoice event reporting a reversed payment.
+func (h *PaymentReversalHandler) EventTypes() []string {
+	return []string{shared.EventTypeInvoiceReversal}
+}
+
+// HandleEvent reverses the settlement of the invoice whose payment was reversed. Invoices not settled yet
+// have nothing to reverse.
+func (h *PaymentReversalHandler) HandleEvent(ctx context.Context, event *shared.BaseDomainEvent) error {
+	var data shared.InvoicePaymentReversedEvent
+	if err := shared.DecodeEventData(event, &data); err != nil {
+		return fmt.Errorf("failed to decode invoice event: %w", err)
+	}
+
+	_, err := h.service.ReverseSettlement(ctx, event.AggregateID, data.PaymentID, data.Reason)
+	if errors.Is(err, ErrSettlementNotFound) {
+		h.logger.Debug("Skipping reversal of invoice without a settlement",
+			zap.String("invoice_id", event.AggregateID))
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,660 @@
+package settlement
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultListLimit is the default page size for listing settlements.
+	defaultListLimit = 20
+	// maxListLimit is the maximum page size for listing settlements.
+	maxListLimit = 100
+	// syncBatchSize bounds the pending settlements handled in one conversion round.
+	syncBatchSize = 100
+)
+
+// Policy configures how settlements are calculated and converted.
+type Policy struct {
+	// PlatformFeePercentage is the fee, in percent, charged to merchants without their own fee percentage.
+	PlatformFeePercentage decimal.Decimal
+	// FiatCurrency is the currency settlements are sold for when an exchange is configured.
+	FiatCurrency string
+	// PollInterval is how often open conversion orders are checked.
+	PollInterval time.Duration
+}
+
+// DefaultPolicy charges a 1% platform fee and converts to US dollars, polling orders every 30 seconds.
+func DefaultPolicy() Policy {
+	return Policy{
+		PlatformFeePercentage: decimal.NewFromInt(1),
+		FiatCurrency:          "USD",
+		PollInterval:          30 * time.Second,
+	}
+}
+
+// Service defines the interface for settlement operations.
+type Service interface {
+	// SettleInvoice creates the settlement of a paid invoice, or of the payments credited to the merchant
+	// for an invoice closed underpaid, and, when an exchange is configured, places the order converting it
+	// to fiat. The shares of the invoice's settlement split are settled to their beneficiaries alongside.
+	// An invoice already settled returns its merchant's settlement, unless that settlement was reversed.
+	SettleInvoice(ctx context.Context, invoiceID string) (*Settlement, error)
+
+	// ReverseSettlement reverses the latest settlement of an invoice whose payment a chain reorganization
+	// removed, and the shares split from it, recording the reversal entries that offset them. A settlement
+	// already reversed returns its reversal; an invoice without a settlement fails with ErrSettlementNotFound.
+	ReverseSettlement(ctx context.Context, invoiceID, paymentID, reason string) (*Reversal, error)
+
+	// GetSettlementSplits retrieves the beneficiaries' shares split from a merchant's settlement.
+	GetSettlementSplits(ctx context.Context, req *GetSettlementRequest) ([]*Settlement, error)
+
+	// GetSettlementReversal retrieves the reversal entry of a merchant's reversed settlement.
+	GetSettlementReversal(ctx context.Context, req *GetSettlementRequest) (*Reversal, error)
+
+	// GetSettlement retrieves a merchant's settlement.
+	GetSettlement(ctx context.Context, req *GetSettlementRequest) (*Settlement, error)
+
+	// GetInvoiceSettlement retrieves the settlement of a merchant's invoice.
+	GetInvoiceSettlement(ctx context.Context, req *GetInvoiceSettlementRequest) (*Settlement, error)
+
+	// ListSettlements lists a merchant's settlements.
+	ListSettlements(ctx context.Context, req *ListSettlementsRequest) (*ListSettlementsResponse, error)
+
+	// SyncConversions places the conversion orders of pending settlements that have none and
+	// follows the open ones, returning the number of settlements that completed or failed.
+	SyncConversions(ctx context.Context) (int, error)
+
+	// FindAwaitingPayout retrieves up to limit settlements whose net amount is still owed on-chain.
+	FindAwaitingPayout(ctx context.Context, limit int) ([]*Settlement, error)
+
+	// AssignPayout includes settlements in a payout. It fails with ErrInvalidTransition, assigning
+	// none of them, if any is no longer awaiting payout.
+	AssignPayout(ctx context.Context, payoutID string, settlementIDs []string) error
+
+	// ReleasePayout returns the settlements of a failed payout to those awaiting payout.
+	ReleasePayout(ctx context.Context, payoutID string) error
+}
+
+// GetSettlementRequest represents the request to get a settlement.
+type GetSettlementRequest struct {
+	MerchantID   string `validate:"required"`
+	SettlementID string `validate:"required"`
+}
+
+// GetInvoiceSettlementRequest represents the request to get the settlement of an invoice.
+type GetInvoiceSettlementRequest struct {
+	MerchantID string `validate:"required"`
+	InvoiceID  string `validate:"required"`
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository     Repository
+	invoiceService invoice.InvoiceService
+	fees           *FeeCalculator
+	exchange       ExchangeAdapter
+	policy         Policy
+	eventBus       shared.EventBus
+	ids            shared.IDGenerator
+	logger         *zap.Logger
+}
+
+// NewService creates a new settlement service. The exchange may be nil, in which case settlements
+// complete in the invoice cryptocurrency without conversion. The ID generator may be nil, in which case
+// settlements get random UUIDs.
+func NewService(
+	repository Repository,
+	invoiceService invoice.InvoiceService,
+	fees *FeeCalculator,
+	exchange ExchangeAdapter,
+	policy Policy,
+	eventBus shared.EventBus,
+	ids shared.IDGenerator,
+	logger *zap.Logger,
+) Service {
+	defaults := DefaultPolicy()
+	if policy.FiatCurrency == "" {
+		policy.FiatCurrency = defaults.FiatCurrency
+	}
+	if policy.PollInterval <= 0 {
+		policy.PollInterval = defaults.PollInterval
+	}
+	if ids == nil {
+		ids = shared.RandomIDGenerator{}
+	}
+
+	return &ServiceImpl{
+		repository:     repository,
+		invoiceService: invoiceService,
+		fees:           fees,
+		exchange:       exchange,
+		policy:         policy,
+		eventBus:       eventBus,
+		ids:            ids,
+		logger:         logger,
+	}
+}
+
+// SettleInvoice creates the settlement of a paid invoice, or credits the merchant with the payments received
+// for an invoice closed underpaid.
+//
+// The gross amount is what the customer paid, and the platform fee is the one the fee calculator chooses
+// for the merchant, or the one the invoice passed through to the customer. The beneficiaries of the invoice's
+// settlement split each get a settlement of their share, and the merchant's settlement keeps the rest. Without
+// an exchange the settlements complete at once. With one, a sell order for each net amount is placed and each
+// settlement completes when its order fills; if an order cannot be placed, SyncConversions retries it.
+func (s *ServiceImpl) SettleInvoice(ctx context.Context, invoiceID string) (*Settlement, error) {
+	if invoiceID == "" {
+		return nil, fmt.Errorf("%w: invoice ID is required", ErrInvalidRequest)
+	}
+
+	existing, err := s.repository.FindByInvoiceID(ctx, invoiceID)
+	if err == nil && existing.Status() != StatusReversed {
+		return existing, nil
+	}
+	if err != nil && !errors.Is(err, ErrSettlementNotFound) {
+		return nil, err
+	}
+
+	inv, err := s.invoiceService.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+	grossAmount, err := settleableAmount(inv)
+	if err != nil {
+		return nil, err
+	}
+
+	settlementID, err := s.ids.NewID(ctx, shared.IDKindSettlement, inv.MerchantID())
+	if err != nil {
+		return nil, err
+	}
+	currency := string(inv.CryptoCurrency())
+	fee, err := s.settlementFee(ctx, inv, currency)
+	if err != nil {
+		return nil, err
+	}
+	settlement, err := NewScheduledSettlement(settlementID, inv.ID(), inv.MerchantID(), grossAmount, currency, fee)
+	if err != nil {
+		return nil, err
+	}
+	splits, err := s.splitSettlement(ctx, settlement, inv)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := append([]*Settlement{settlement}, splits...)
+	if s.exchange == nil {
+		for _, entry := range entries {
+			if err := entry.Complete(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := s.repository.Save(ctx, settlement, splits...); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		s.publishEvent(ctx, shared.NewSettlementCreatedEvent(createSettlementEventData(entry)))
+		if entry.Status() == StatusCompleted {
+			s.publishEvent(ctx, shared.NewSettlementCompletedEvent(createSettlementEventData(entry)))
+		} else if _, err := s.submitConversion(ctx, entry); err != nil {
+			s.logger.Warn("Failed to place settlement conversion order; it will be retried",
+				zap.String("settlement_id", entry.ID()),
+				zap.Error(err))
+		}
+	}
+
+	s.logger.Info("Invoice settled",
+		zap.String("settlement_id", settlement.ID()),
+		zap.String("invoice_id", inv.ID()),
+		zap.String("net_amount", settlement.NetAmount().String()),
+		zap.String("fee_tier", string(fee.Tier.Kind)+":"+fee.Tier.Name),
+		zap.Int("splits", len(splits)),
+		zap.String("status", string(settlement.Status())))
+
+	return settlement, nil
+}
+
+// splitSettlement splits the shares of an invoice's settlement split off its merchant's pending settlement, in
+// proportion to the gross amount. A split that no longer fits the invoice, because a coupon the customer
+// applied lowered the total below its fixed shares, is not applied, and the merchant's settlement keeps the
+// whole amount.
+func (s *ServiceImpl) splitSettlement(
+	ctx context.Context,
+	settlement *Settlement,
+	inv *invoice.Invoice,
+) ([]*Settlement, error) {
+	split := inv.SettlementSplit()
+	if split == nil {
+		return nil, nil
+	}
+	fractions, err := split.Fractions(inv.Pricing().Total())
+	if err != nil {
+		s.logger.Warn("Settlement split does not fit the invoice; settling the whole amount to its merchant",
+			zap.String("invoice_id", inv.ID()),
+			zap.Error(err))
+		return nil, nil
+	}
+
+	grossAmount := settlement.GrossAmount()
+	splits := make([]*Settlement, 0, len(fractions))
+	for i, share := range split.Shares() {
+		amount := grossAmount.Mul(fractions[i]).RoundDown(amountDecimals)
+		if !amount.IsPositive() {
+			continue
+		}
+		id, err := s.ids.NewID(ctx, shared.IDKindSettlement, share.BeneficiaryID())
+		if err != nil {
+			return nil, err
+		}
+		entry, err := settlement.Split(id, share.BeneficiaryID(), amount)
+		if err != nil {
+			return nil, err
+		}
+		splits = append(splits, entry)
+	}
+	return splits, nil
+}
+
+// ReverseSettlement reverses the settlement of an invoice whose payment was removed by a reorganization.
+func (s *ServiceImpl) ReverseSettlement(
+	ctx context.Context,
+	invoiceID, paymentID, reason string,
+) (*Reversal, error) {
+	if invoiceID == "" {
+		return nil, fmt.Errorf("%w: invoice ID is required", ErrInvalidRequest)
+	}
+
+	settlement, err := s.repository.FindByInvoiceID(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if settlement.Status() == StatusReversed {
+		return s.repository.FindReversal(ctx, settlement.ID())
+	}
+
+	// The shares go first, so a reversal interrupted part way is completed when retried
+	splits, err := s.repository.FindSplits(ctx, settlement.ID())
+	if err != nil {
+		return nil, err
+	}
+	for _, split := range splits {
+		if split.Status() == StatusReversed {
+			continue
+		}
+		if _, err := s.reverse(ctx, split, paymentID, reason); err != nil {
+			return nil, err
+		}
+	}
+	return s.reverse(ctx, settlement, paymentID, reason)
+}
+
+// reverse reverses a settlement, stores its reversal entry and announces it.
+func (s *ServiceImpl) reverse(ctx context.Context, settlement *Settlement, paymentID, reason string) (*Reversal, error) {
+	reversalID, err := s.ids.NewID(ctx, shared.IDKindSettlement, settlement.MerchantID())
+	if err != nil {
+		return nil, err
+	}
+	reversal, err := settlement.Reverse(reversalID, paymentID, reason)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repository.SaveReversal(ctx, settlement, reversal); err != nil {
+		return nil, err
+	}
+
+	s.logger.Warn("Settlement reversed",
+		zap.String("settlement_id", settlement.ID()),
+		zap.String("invoice_id", settlement.InvoiceID()),
+		zap.String("payment_id", paymentID),
+		zap.String("net_amount", reversal.NetAmount().String()),
+		zap.Bool("paid_out", reversal.PayoutID() != ""))
+	s.publishReversal(ctx, settlement, reversal)
+	return reversal, nil
+}
+
+// settlementFee returns the platform fee of an invoice's settlement. An invoice that passed the fee through to
+// the customer is charged the percentage it quoted, without a minimum fee, so the merchant receives the price
+// before the fee; other invoices are charged the fee the fee calculator chooses for the merchant.
+func (s *ServiceImpl) settlementFee(ctx context.Context, inv *invoice.Invoice, currency string) (*Fee, error) {
+	if passed := inv.FeePassThrough(); passed != nil {
+		return &Fee{Percentage: passed.Percentage(), Tier: FeeTier{Kind: FeeTierFlat, Name: FeeTierInvoice}}, nil
+	}
+	return s.fees.Calculate(ctx, inv.MerchantID(), inv.Network(), currency, shared.Now())
+}
+
+// settleableAmount returns the amount an invoice settles for: what the customer paid for a paid invoice, and
+// what was received and not refunded for an invoice closed underpaid.
+func settleableAmount(inv *invoice.Invoice) (decimal.Decimal, error) {
+	switch inv.Status() {
+	case invoice.StatusPaid:
+		if paid := inv.AmountPaid(); paid != nil {
+			return paid.Amount(), nil
+		}
+		if locked, err := inv.LockedCryptoAmount(); err == nil {
+			return locked.Amount(), nil
+		}
+		return decimal.Zero, nil
+	case invoice.StatusUnderpaidClosed:
+		if inv.AmountPaid() != nil {
+			if credited, err := inv.RefundableAmount(); err == nil && credited.Amount().IsPositive() {
+				return credited.Amount(), nil
+			}
+		}
+		return decimal.Zero, fmt.Errorf("%w: invoice %s was closed underpaid and refunded", ErrInvoiceNotPaid, inv.ID())
+	default:
+		return decimal.Zero, fmt.Errorf("%w: invoice %s is %s", ErrInvoiceNotPaid, inv.ID(), inv.Status())
+	}
+}
+
+// GetSettlement retrieves a merchant's settlement.
+func (s *ServiceImpl) GetSettlement(ctx context.Context, req *GetSettlementRequest) (*Settlement, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: get settlement request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	settlement, err := s.repository.FindByID(ctx, req.SettlementID)
+	if err != nil {
+		return nil, err
+	}
+	if settlement.MerchantID() != req.MerchantID {
+		return nil, ErrSettlementNotFound
+	}
+	return settlement, nil
+}
+
+// GetInvoiceSettlement retrieves the settlement of a merchant's invoice.
+func (s *ServiceImpl) GetInvoiceSettlement(
+	ctx context.Context,
+	req *GetInvoiceSettlementRequest,
+) (*Settlement, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: get invoice settlement request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	settlement, err := s.repository.FindByInvoiceID(ctx, req.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if settlement.MerchantID() != req.MerchantID {
+		return nil, ErrSettlementNotFound
+	}
+	return settlement, nil
+}
+
+// GetSettlementSplits retrieves the beneficiaries' shares split from a merchant's settlement.
+func (s *ServiceImpl) GetSettlementSplits(ctx context.Context, req *GetSettlementRequest) ([]*Settlement, error) {
+	settlement, err := s.GetSettlement(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if settlement.SplitOf() != "" {
+		return nil, nil
+	}
+	return s.repository.FindSplits(ctx, settlement.ID())
+}
+
+// GetSettlementReversal retrieves the reversal entry of a merchant's reversed settlement.
+func (s *ServiceImpl) GetSettlementReversal(ctx context.Context, req *GetSettlementRequest) (*Reversal, error) {
+	settlement, err := s.GetSettlement(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if settlement.Status() != StatusReversed {
+		return nil, ErrReversalNotFound
+	}
+	return s.repository.FindReversal(ctx, settlement.ID())
+}
+
+// ListSettlements lists a merchant's settlements.
+func (s *ServiceImpl) ListSettlements(
+	ctx context.Context,
+	req *ListSettlementsRequest,
+) (*ListSettlementsResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: list settlements request cannot be nil", ErrInvalidRequest)
+	}
+	if req.MerchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+	if req.Status != "" && !req.Status.IsValid() {
+		return nil, fmt.Errorf("%w: invalid status %s", ErrInvalidRequest, req.Status)
+	}
+	if req.From != nil && req.To != nil && req.To.Before(*req.From) {
+		return nil, fmt.Errorf("%w: end date is before start date", ErrInvalidRequest)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	return s.repository.List(ctx, &ListSettlementsRequest{
+		MerchantID: req.MerchantID,
+		Status:     req.Status,
+		From:       req.From,
+		To:         req.To,
+		Limit:      limit,
+		Cursor:     req.Cursor,
+	})
+}
+
+// SyncConversions places missing conversion orders and follows open ones.
+func (s *ServiceImpl) SyncConversions(ctx context.Context) (int, error) {
+	if s.exchange == nil {
+		return 0, nil
+	}
+
+	pending, err := s.repository.FindPending(ctx, syncBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	finished := 0
+	for _, settlement := range pending {
+		if ctx.Err() != nil {
+			return finished, ctx.Err()
+		}
+
+		var done bool
+		if settlement.Conversion() == nil {
+			done, err = s.submitConversion(ctx, settlement)
+		} else {
+			done, err = s.followConversion(ctx, settlement)
+		}
+		if err != nil {
+			s.logger.Error("Failed to sync settlement conversion",
+				zap.String("settlement_id", settlement.ID()),
+				zap.Error(err))
+			continue
+		}
+		if done {
+			finished++
+		}
+	}
+	return finished, nil
+}
+
+// FindAwaitingPayout retrieves settlements awaiting an on-chain payout.
+func (s *ServiceImpl) FindAwaitingPayout(ctx context.Context, limit int) ([]*Settlement, error) {
+	if limit <= 0 || limit > syncBatchSize {
+		limit = syncBatchSize
+	}
+	return s.repository.FindAwaitingPayout(ctx, limit)
+}
+
+// AssignPayout includes settlements in a payout, all or none.
+func (s *ServiceImpl) AssignPayout(ctx context.Context, payoutID string, settlementIDs []string) error {
+	if payoutID == "" || len(settlementIDs) == 0 {
+		return fmt.Errorf("%w: payout ID and settlements are required", ErrInvalidRequest)
+	}
+
+	assigned, err := s.repository.AssignPayout(ctx, payoutID, settlementIDs)
+	if err != nil {
+		return err
+	}
+	if assigned == len(settlementIDs) {
+		return nil
+	}
+
+	// Another payout claimed some of the settlements first; give back the ones this payout took
+	if err := s.repository.ReleasePayout(ctx, payoutID); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %d of %d settlements no longer await payout",
+		ErrInvalidTransition, len(settlementIDs)-assigned, len(settlementIDs))
+}
+
+// ReleasePayout returns the settlements of a payout to those awaiting payout.
+func (s *ServiceImpl) ReleasePayout(ctx context.Context, payoutID string) error {
+	if payoutID == "" {
+		return fmt.Errorf("%w: payout ID is required", ErrInvalidRequest)
+	}
+	return s.repository.ReleasePayout(ctx, payoutID)
+}
+
+// submitConversion places the order selling a settlement's net amount for fiat. It reports whether
+// the settlement finished, which happens when the exchange fills the order immediately.
+func (s *ServiceImpl) submitConversion(ctx context.Context, settlement *Settlement) (bool, error) {
+	order, err := s.exchange.PlaceSellOrder(ctx, &SellOrderRequest{
+		ClientOrderID: settlement.ID(),
+		Asset:         settlement.Currency(),
+		FiatCurrency:  s.policy.FiatCurrency,
+		Amount:        settlement.NetAmount(),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	conversion, err := NewConversion(s.exchange.Name(), order.ID, s.policy.FiatCurrency, settlement.NetAmount())
+	if err != nil {
+		return false, err
+	}
+	if err := settlement.StartConversion(conversion); err != nil {
+		return false, err
+	}
+
+	s.logger.Info("Placed settlement conversion order",
+		zap.String("settlement_id", settlement.ID()),
+		zap.String("exchange", conversion.Exchange()),
+		zap.String("order_id", conversion.OrderID()))
+
+	return s.applyOrder(ctx, settlement, order)
+}
+
+// followConversion checks the open conversion order of a settlement with the exchange.
+func (s *ServiceImpl) followConversion(ctx context.Context, settlement *Settlement) (bool, error) {
+	if exchange := settlement.Conversion().Exchange(); exchange != s.exchange.Name() {
+		return false, fmt.Errorf("conversion was placed on %s, but %s is configured", exchange, s.exchange.Name())
+	}
+
+	order, err := s.exchange.GetOrder(ctx, settlement.Conversion().OrderID())
+	if err != nil {
+		return false, err
+	}
+	return s.applyOrder(ctx, settlement, order)
+}
+
+// applyOrder records an order state on its settlement, stores it and announces a completion or failure.
+func (s *ServiceImpl) applyOrder(ctx context.Context, settlement *Settlement, order *Order) (bool, error) {
+	finished, err := settlement.ApplyOrder(order)
+	if err != nil {
+		return false, err
+	}
+	if err := s.repository.Update(ctx, settlement); err != nil {
+		return false, err
+	}
+	if !finished {
+		return false, nil
+	}
+
+	if settlement.Status() == StatusCompleted {
+		s.publishEvent(ctx, shared.NewSettlementCompletedEvent(createSettlementEventData(settlement)))
+	} else {
+		s.logger.Warn("Settlement conversion failed",
+			zap.String("settlement_id", settlement.ID()),
+			zap.String("reason", settlement.FailureReason()))
+		s.publishEvent(ctx, shared.NewSettlementFailedEvent(createSettlementEventData(settlement)))
+	}
+	return true, nil
+}
+
+// publishEvent publishes a settlement event, logging rather than failing when the bus is unavailable.
+func (s *ServiceImpl) publishEvent(ctx context.Context, event *shared.BaseDomainEvent) {
+	if s.eventBus == nil {
+		return
+	}
+
+	if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+		s.logger.Error("Failed to publish domain event",
+			zap.String("event_type", event.EventType),
+			zap.String("aggregate_id", event.AggregateID),
+			zap.Error(err))
+	}
+}
+
+// publishReversal publishes the settlement reversed event with the negative amounts of the reversal entry.
+func (s *ServiceImpl) publishReversal(ctx context.Context, settlement *Settlement, reversal *Reversal) {
+	s.publishEvent(ctx, shared.NewSettlementReversedEvent(shared.SettlementReversedEvent{
+		SettlementEvent: createSettlementEventData(settlement),
+		Reversal: shared.SettlementReversal{
+			ReversalID:        reversal.ID(),
+			PaymentID:         reversal.PaymentID(),
+			GrossAmount:       reversal.GrossAmount().String(),
+			PlatformFeeAmount: reversal.FeeAmount().String(),
+			NetAmount:         reversal.NetAmount().String(),
+			Reason:            reversal.Reason(),
+			PaidOut:           reversal.PayoutID() != "",
+			CreatedAt:         reversal.CreatedAt(),
+		},
+	}))
+}
+
+// createSettlementEventData returns the payload of settlement events.
+func createSettlementEventData(settlement *Settlement) shared.SettlementEvent {
+	data := shared.SettlementEvent{
+		SettlementID:      settlement.ID(),
+		InvoiceID:         settlement.InvoiceID(),
+		MerchantID:        settlement.MerchantID(),
+		GrossAmount:       settlement.GrossAmount().String(),
+		PlatformFeeAmount: settlement.FeeAmount().String(),
+		FeePercentage:     settlement.FeePercentage().String(),
+		NetAmount:         settlement.NetAmount().String(),
+		Currency:          settlement.Currency(),
+		Status:            string(settlement.Status()),
+		SettledAt:         settlement.SettledAt(),
+		FailureReason:     settlement.FailureReason(),
+		Timestamp:         shared.Now().UTC(),
+	}
+	if conversion := settlement.Conversion(); conversion != nil {
+		data.Conversion = &shared.SettlementConversion{
+			Exchange:     conversion.Exchange(),
+			OrderID:      conversion.OrderID(),
+			Status:       string(conversion.Status()),
+			FiatCurrency: conversion.FiatCurrency(),
+			FiatAmount:   conversion.FiatAmount().String(),
+			Fee:          conversion.Fee().String(),
+		}
+	}
+	return data
+}
@@ -0,0 +1,307 @@
+package settlement
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// amountDecimals is the precision settlement amounts are kept at.
+const amountDecimals = 8
+
+// hundred converts fee percentages to fractions.
+var hundred = decimal.NewFromInt(100)
+
+// Settlement is what a merchant receives for a paid invoice: the amount paid less the platform fee,
+// optionally converted to fiat.
+type Settlement struct {
+	id            string
+	invoiceID     string
+	merchantID    string
+	grossAmount   decimal.Decimal
+	feePercentage decimal.Decimal
+	feeAmount     decimal.Decimal
+	netAmount     decimal.Decimal
+	currency      string
+	status        Status
+	conversion    *Conversion
+	failureReason string
+	payoutID      string
+	splitOf       string // Settlement of the invoice's merchant this beneficiary's share was split from
+	feeTier       *FeeTier
+	createdAt     time.Time
+	settledAt     *time.Time
+}
+
+// NewSettlement creates a pending settlement of the gross amount received for an invoice, deducting
+// the platform fee percentage.
+func NewSettlement(
+	id, invoiceID, merchantID string,
+	grossAmount decimal.Decimal,
+	currency string,
+	feePercentage decimal.Decimal,
+) (*Settlement, error) {
+	if feePercentage.IsNegative() || feePercentage.GreaterThan(hundred) {
+		return nil, fmt.Errorf("%w: fee percentage must be between 0 and 100", ErrInvalidRequest)
+	}
+
+	feeAmount := grossAmount.Mul(feePercentage).Div(hundred).Round(amountDecimals)
+	return RestoreSettlement(
+		id, invoiceID, merchantID, grossAmount, feePercentage, feeAmount, grossAmount.Sub(feeAmount), currency,
+		StatusPending, nil, "", "", "", shared.Now().UTC(), nil,
+	)
+}
+
+// NewScheduledSettlement creates a pending settlement of the gross amount received for an invoice, deducting the
+// platform fee a FeeCalculator chose and recording how it was chosen. The fee is at least the fee's minimum, up to
+// the whole gross amount.
+func NewScheduledSettlement(
+	id, invoiceID, merchantID string,
+	grossAmount decimal.Decimal,
+	currency string,
+	fee *Fee,
+) (*Settlement, error) {
+	if fee == nil {
+		return nil, fmt.Errorf("%w: fee is required", ErrInvalidRequest)
+	}
+	settlement, err := NewSettlement(id, invoiceID, merchantID, grossAmount, currency, fee.Percentage)
+	if err != nil {
+		return nil, err
+	}
+
+	tier := fee.Tier
+	if minimum := decimal.Min(fee.Minimum, grossAmount); settlement.feeAmount.LessThan(minimum) {
+		settlement.feeAmount = minimum
+		settlement.netAmount = grossAmount.Sub(minimum)
+		tier.MinimumApplied = true
+	}
+	settlement.feeTier = &tier
+	return settlement, nil
+}
+
+// RestoreSettlement recreates a settlement from storage.
+func RestoreSettlement(
+	id, invoiceID, merchantID string,
+	grossAmount, feePercentage, feeAmount, netAmount decimal.Decimal,
+	currency string,
+	status Status,
+	conversion *Conversion,
+	failureReason, payoutID, splitOf string,
+	createdAt time.Time,
+	settledAt *time.Time,
+) (*Settlement, error) {
+	if id == "" {
+		return nil, errors.New("settlement ID is required")
+	}
+	if invoiceID == "" {
+		return nil, errors.New("invoice ID is required")
+	}
+	if !grossAmount.IsPositive() {
+		return nil, fmt.Errorf("%w: gross amount must be positive", ErrInvalidRequest)
+	}
+	if currency == "" {
+		return nil, errors.New("settlement currency is required")
+	}
+	if !status.IsValid() {
+		return nil, errors.New("invalid settlement status")
+	}
+
+	return &Settlement{
+		id:            id,
+		invoiceID:     invoiceID,
+		merchantID:    merchantID,
+		grossAmount:   grossAmount,
+		feePercentage: feePercentage,
+		feeAmount:     feeAmount,
+		netAmount:     netAmount,
+		currency:      currency,
+		status:        status,
+		conversion:    conversion,
+		failureReason: failureReason,
+		payoutID:      payoutID,
+		splitOf:       splitOf,
+		createdAt:     createdAt,
+		settledAt:     settledAt,
+	}, nil
+}
+
+// ID returns the settlement ID.
+func (s *Settlement) ID() string {
+	return s.id
+}
+
+// InvoiceID returns the paid invoice the settlement is for.
+func (s *Settlement) InvoiceID() string {
+	return s.invoiceID
+}
+
+// MerchantID returns the merchant receiving the settlement.
+func (s *Settlement) MerchantID() string {
+	return s.merchantID
+}
+
+// GrossAmount returns the amount paid for the invoice.
+func (s *Settlement) GrossAmount() decimal.Decimal {
+	return s.grossAmount
+}
+
+// FeePercentage returns the platform fee rate applied, in percent.
+func (s *Settlement) FeePercentage() decimal.Decimal {
+	return s.feePercentage
+}
+
+// FeeTier returns how the platform fee was chosen, or nil for settlements created before fees were recorded.
+func (s *Settlement) FeeTier() *FeeTier {
+	return s.feeTier
+}
+
+// SetFeeTier sets how the platform fee was chosen (for repository restoration).
+func (s *Settlement) SetFeeTier(tier *FeeTier) {
+	s.feeTier = tier
+}
+
+// FeeAmount returns the platform fee deducted from the gross amount.
+func (s *Settlement) FeeAmount() decimal.Decimal {
+	return s.feeAmount
+}
+
+// NetAmount returns the amount due to the merchant, the gross amount less the platform fee.
+func (s *Settlement) NetAmount() decimal.Decimal {
+	return s.netAmount
+}
+
+// Currency returns the cryptocurrency of the gross, fee and net amounts.
+func (s *Settlement) Currency() string {
+	return s.currency
+}
+
+// Status returns the settlement status.
+func (s *Settlement) Status() Status {
+	return s.status
+}
+
+// Conversion returns the fiat conversion of the net amount, or nil if none was placed.
+func (s *Settlement) Conversion() *Conversion {
+	return s.conversion
+}
+
+// FailureReason returns why the settlement failed.
+func (s *Settlement) FailureReason() string {
+	return s.failureReason
+}
+
+// PayoutID returns the payout transferring the net amount to the merchant, or empty if none has yet.
+func (s *Settlement) PayoutID() string {
+	return s.payoutID
+}
+
+// SplitOf returns the settlement of the invoice's merchant a beneficiary's share was split from, or empty for
+// the invoice's merchant's own settlement.
+func (s *Settlement) SplitOf() string {
+	return s.splitOf
+}
+
+// AwaitsPayout reports whether the net amount is still owed to the merchant on-chain: the settlement
+// completed in its cryptocurrency, without a fiat conversion, and no payout includes it yet.
+func (s *Settlement) AwaitsPayout() bool {
+	return s.status == StatusCompleted && s.conversion == nil && s.payoutID == ""
+}
+
+// CreatedAt returns when the settlement was created.
+func (s *Settlement) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// SettledAt returns when the settlement completed.
+func (s *Settlement) SettledAt() *time.Time {
+	return s.settledAt
+}
+
+// Complete marks a pending settlement as settled in its cryptocurrency, without fiat conversion.
+func (s *Settlement) Complete() error {
+	if s.status != StatusPending || s.conversion != nil {
+		return ErrInvalidTransition
+	}
+
+	now := shared.Now().UTC()
+	s.status = StatusCompleted
+	s.settledAt = &now
+	return nil
+}
+
+// StartConversion attaches the sell order placed to convert the net amount to fiat.
+func (s *Settlement) StartConversion(conversion *Conversion) error {
+	if s.status != StatusPending || s.conversion != nil {
+		return ErrInvalidTransition
+	}
+	if conversion == nil || conversion.Status() != ConversionStatusSubmitted {
+		return fmt.Errorf("%w: conversion must be a submitted order", ErrInvalidRequest)
+	}
+
+	s.conversion = conversion
+	return nil
+}
+
+// ApplyOrder records the state an exchange reports for the conversion order. A filled order
+// completes the settlement and a cancelled one fails it. It returns whether anything changed.
+func (s *Settlement) ApplyOrder(order *Order) (bool, error) {
+	if s.status != StatusPending || s.conversion == nil || s.conversion.Status() != ConversionStatusSubmitted {
+		return false, ErrInvalidTransition
+	}
+	if order == nil || order.ID != s.conversion.OrderID() {
+		return false, fmt.Errorf("%w: order does not match the conversion", ErrInvalidRequest)
+	}
+
+	if !s.conversion.apply(order) {
+		return false, nil
+	}
+
+	if s.conversion.Status() == ConversionStatusFilled {
+		s.status = StatusCompleted
+		s.settledAt = s.conversion.CompletedAt()
+	} else {
+		s.status = StatusFailed
+		s.failureReason = "fiat conversion failed: " + s.conversion.FailureReason()
+	}
+	return true, nil
+}
+
+// Split moves a beneficiary's share of a pending settlement into a settlement of its own, for the same invoice
+// and in the same currency. The share carries its part of the platform fee at the settlement's fee percentage,
+// and this settlement keeps the rest, so the two together add up to the amounts before the split, unless a
+// minimum fee would leave this settlement less than nothing. The share must leave part of the gross amount to
+// this settlement, which cannot itself be a share.
+func (s *Settlement) Split(id, beneficiaryID string, grossAmount decimal.Decimal) (*Settlement, error) {
+	if s.status != StatusPending || s.conversion != nil || s.splitOf != "" {
+		return nil, ErrInvalidTransition
+	}
+	if beneficiaryID == "" || beneficiaryID == s.merchantID {
+		return nil, fmt.Errorf("%w: a share goes to another merchant", ErrInvalidRequest)
+	}
+	if !grossAmount.IsPositive() || !grossAmount.LessThan(s.grossAmount) {
+		return nil, fmt.Errorf("%w: a share must be positive and less than the gross amount", ErrInvalidRequest)
+	}
+
+	feeAmount := grossAmount.Mul(s.feePercentage).Div(hundred).Round(amountDecimals)
+	share, err := RestoreSettlement(
+		id, s.invoiceID, beneficiaryID, grossAmount, s.feePercentage, feeAmount, grossAmount.Sub(feeAmount),
+		s.currency, StatusPending, nil, "", "", s.id, s.createdAt, nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if s.feeTier != nil {
+		tier := *s.feeTier
+		tier.MinimumApplied = false
+		share.feeTier = &tier
+	}
+
+	// A minimum fee charged beyond the fee percentage stays with this settlement, as far as its gross amount goes.
+	s.grossAmount = s.grossAmount.Sub(share.grossAmount)
+	s.feeAmount = decimal.Min(s.feeAmount.Sub(share.feeAmount), s.grossAmount)
+	s.netAmount = s.grossAmount.Sub(s.feeAmount)
+	return share, nil
+}
@@ -0,0 +1,149 @@
+package settlement_test
+
+import (
+	"crypto-checkout/internal/domain/settlement"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSettlement(t *testing.T) *settlement.Settlement {
+	t.Helper()
+	s, err := settlement.NewSettlement(
+		"settlement-1", "invoice-1", "merchant-1", decimal.RequireFromString("250"), "USDT",
+		decimal.RequireFromString("1.5"),
+	)
+	require.NoError(t, err)
+	return s
+}
+
+func TestNewSettlement_DeductsPlatformFee(t *testing.T) {
+	s := newSettlement(t)
+
+	assert.Equal(t, "3.75", s.FeeAmount().String())
+	assert.Equal(t, "246.25", s.NetAmount().String())
+	assert.Equal(t, settlement.StatusPending, s.Status())
+
+	_, err := settlement.NewSettlement(
+		"settlement-2", "invoice-2", "merchant-1", decimal.RequireFromString("10"), "USDT",
+		decimal.RequireFromString("101"),
+	)
+	require.ErrorIs(t, err, settlement.ErrInvalidRequest)
+}
+
+func TestSettlement_Complete(t *testing.T) {
+	s := newSettlement(t)
+
+	require.NoError(t, s.Complete())
+	assert.Equal(t, settlement.StatusCompleted, s.Status())
+	assert.NotNil(t, s.SettledAt())
+	require.ErrorIs(t, s.Complete(), settlement.ErrInvalidTransition)
+}
+
+func TestSettlement_ConversionLifecycle(t *testing.T) {
+	t.Run("filled order completes with realized fiat amount", func(t *testing.T) {
+		s := newSettlement(t)
+		conversion, err := settlement.NewConversion("kraken", "order-1", "USD", s.NetAmount())
+		require.NoError(t, err)
+		require.NoError(t, s.StartConversion(conversion))
+		require.ErrorIs(t, s.Complete(), settlement.ErrInvalidTransition)
+
+		changed, err := s.ApplyOrder(&settlement.Order{ID: "order-1", State: settlement.OrderStateOpen})
+		require.NoError(t, err)
+		assert.False(t, changed)
+
+		changed, err = s.ApplyOrder(&settlement.Order{
+			ID:             "order-1",
+			State:          settlement.OrderStateFilled,
+			ExecutedAmount: s.NetAmount(),
+			Proceeds:       decimal.RequireFromString("246.00"),
+			Fee:            decimal.RequireFromString("0.64"),
+		})
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, settlement.StatusCompleted, s.Status())
+		assert.Equal(t, settlement.ConversionStatusFilled, s.Conversion().Status())
+		assert.Equal(t, "245.36", s.Conversion().FiatAmount().String())
+		assert.Equal(t, "0.64", s.Conversion().Fee().String())
+		assert.NotNil(t, s.SettledAt())
+	})
+
+	t.Run("cancelled order fails the settlement", func(t *testing.T) {
+		s := newSettlement(t)
+		conversion, err := settlement.NewConversion("binance", "USDTUSD:7", "USD", s.NetAmount())
+		require.NoError(t, err)
+		require.NoError(t, s.StartConversion(conversion))
+
+		_, err = s.ApplyOrder(&settlement.Order{ID: "other", State: settlement.OrderStateFilled})
+		require.ErrorIs(t, err, settlement.ErrInvalidRequest)
+
+		changed, err := s.ApplyOrder(&settlement.Order{
+			ID:     "USDTUSD:7",
+			State:  settlement.OrderStateCancelled,
+			Reason: "insufficient liquidity",
+		})
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, settlement.StatusFailed, s.Status())
+		assert.Equal(t, "fiat conversion failed: insufficient liquidity", s.FailureReason())
+		assert.Nil(t, s.SettledAt())
+	})
+}
+
+func TestSettlement_Reverse(t *testing.T) {
+	s := newSettlement(t)
+	require.NoError(t, s.Complete())
+	require.True(t, s.AwaitsPayout())
+
+	reversal, err := s.Reverse("reversal-1", "payment-1", "removed by a reorganization")
+	require.NoError(t, err)
+	assert.Equal(t, settlement.StatusReversed, s.Status())
+	assert.False(t, s.AwaitsPayout())
+	assert.Equal(t, "settlement-1", reversal.SettlementID())
+	assert.Equal(t, "-250", reversal.GrossAmount().String())
+	assert.Equal(t, "-3.75", reversal.FeeAmount().String())
+	assert.Equal(t, "-246.25", reversal.NetAmount().String())
+	assert.Empty(t, reversal.PayoutID())
+
+	_, err = s.Reverse("reversal-2", "payment-1", "removed by a reorganization")
+	require.ErrorIs(t, err, settlement.ErrInvalidTransition)
+}
+
+func TestSettlement_Split(t *testing.T) {
+	s := newSettlement(t)
+
+	share, err := s.Split("settlement-2", "seller-1", decimal.RequireFromString("200"))
+	require.NoError(t, err)
+	assert.Equal(t, "seller-1", share.MerchantID())
+	assert.Equal(t, "invoice-1", share.InvoiceID())
+	assert.Equal(t, "settlement-1", share.SplitOf())
+	assert.Equal(t, "3", share.FeeAmount().String())
+	assert.Equal(t, "197", share.NetAmount().String())
+	assert.Equal(t, settlement.StatusPending, share.Status())
+
+	assert.Equal(t, "50", s.GrossAmount().String())
+	assert.Equal(t, "0.75", s.FeeAmount().String())
+	assert.Equal(t, "49.25", s.NetAmount().String())
+
+	for name, tc := range map[string]struct {
+		beneficiaryID string
+		amount        string
+	}{
+		"merchant itself":  {"merchant-1", "10"},
+		"no beneficiary":   {"", "10"},
+		"zero share":       {"seller-2", "0"},
+		"whole settlement": {"seller-2", "50"},
+	} {
+		_, err := s.Split("settlement-3", tc.beneficiaryID, decimal.RequireFromString(tc.amount))
+		require.ErrorIs(t, err, settlement.ErrInvalidRequest, name)
+	}
+
+	_, err = share.Split("settlement-3", "seller-2", decimal.RequireFromString("10"))
+	require.ErrorIs(t, err, settlement.ErrInvalidTransition)
+
+	require.NoError(t, s.Complete())
+	_, err = s.Split("settlement-3", "seller-2", decimal.RequireFromString("10"))
+	require.ErrorIs(t, err, settlement.ErrInvalidTransition)
+}
@@ -0,0 +1,97 @@
+package settlement
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// ConversionSyncer follows the fiat conversion orders of pending settlements in the background.
+type ConversionSyncer struct {
+	service  Service
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+// NewConversionSyncer creates a new background conversion syncer polling at the policy interval.
+func NewConversionSyncer(service Service, policy Policy, logger *zap.Logger) *ConversionSyncer {
+	interval := policy.PollInterval
+	if interval <= 0 {
+		interval = DefaultPolicy().PollInterval
+	}
+	return &ConversionSyncer{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run syncs conversion orders on every interval until the context is cancelled.
+func (s *ConversionSyncer) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		shared.Beat(ctx)
+		s.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick performs one sync round.
+func (s *ConversionSyncer) tick(ctx context.Context) {
+	finished, err := s.service.SyncConversions(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("Failed to sync settlement conversions", zap.Error(err))
+		}
+		return
+	}
+	if finished > 0 {
+		s.logger.Info("Finished settlement conversions", zap.Int("count", finished))
+	}
+}
+
+// RegisterConversionSyncer runs the conversion syncer for the lifetime of the application.
+// Nothing is started when no exchange is configured.
+func RegisterConversionSyncer(
+	lc fx.Lifecycle,
+	syncer *ConversionSyncer,
+	exchange ExchangeAdapter,
+	workers shared.WorkerSupervisor,
+	logger *zap.Logger,
+) {
+	if exchange == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			logger.Info("Starting settlement conversion syncer", zap.String("exchange", exchange.Name()))
+			go func() {
+				defer close(done)
+				workers.Supervise(ctx, "settlement_conversion_syncer", syncer.interval, syncer.Run)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
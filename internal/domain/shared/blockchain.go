@@ -7,8 +7,32 @@ const (
 	NetworkTron     BlockchainNetwork = "tron"
 	NetworkEthereum BlockchainNetwork = "ethereum"
 	NetworkBitcoin  BlockchainNetwork = "bitcoin"
+	NetworkBSC      BlockchainNetwork = "bsc"
 )
 
+// Testnets of the supported networks. Their coins have no value; invoices of merchants in test mode are paid on
+// them.
+const (
+	NetworkTronShasta      BlockchainNetwork = "tron_shasta"
+	NetworkTronNile        BlockchainNetwork = "tron_nile"
+	NetworkEthereumSepolia BlockchainNetwork = "ethereum_sepolia"
+	NetworkBitcoinTestnet  BlockchainNetwork = "bitcoin_testnet"
+)
+
+// testnet is a testnet of a mainnet, known to block explorers by the name of its chain.
+type testnet struct {
+	mainnet BlockchainNetwork
+	chain   string
+}
+
+// testnets are the testnets of the supported networks.
+var testnets = map[BlockchainNetwork]testnet{
+	NetworkTronShasta:      {mainnet: NetworkTron, chain: "shasta"},
+	NetworkTronNile:        {mainnet: NetworkTron, chain: "nile"},
+	NetworkEthereumSepolia: {mainnet: NetworkEthereum, chain: "sepolia"},
+	NetworkBitcoinTestnet:  {mainnet: NetworkBitcoin, chain: "testnet"},
+}
+
 // String returns the string representation of the blockchain network.
 func (n BlockchainNetwork) String() string {
 	return string(n)
@@ -17,9 +41,38 @@ func (n BlockchainNetwork) String() string {
 // IsValid returns true if the blockchain network is valid.
 func (n BlockchainNetwork) IsValid() bool {
 	switch n {
-	case NetworkTron, NetworkEthereum, NetworkBitcoin:
+	case NetworkTron, NetworkEthereum, NetworkBitcoin, NetworkBSC:
 		return true
 	default:
-		return false
+		return n.IsTestnet()
 	}
 }
+
+// IsTestnet returns true if the network is a testnet.
+func (n BlockchainNetwork) IsTestnet() bool {
+	_, ok := testnets[n]
+	return ok
+}
+
+// Mainnet returns the mainnet a testnet tests, and the network itself for mainnets. Testnets share the address
+// formats, transaction layout and block times of their mainnet.
+func (n BlockchainNetwork) Mainnet() BlockchainNetwork {
+	if t, ok := testnets[n]; ok {
+		return t.mainnet
+	}
+	return n
+}
+
+// Chain returns the name of the network's chain: the name of a testnet, or ChainMainnet.
+func (n BlockchainNetwork) Chain() string {
+	if t, ok := testnets[n]; ok {
+		return t.chain
+	}
+	return ChainMainnet
+}
+
+// SupportsMemo returns true if transfers on the network can carry a memo the receiver reads back from the chain.
+// Tron transactions have a note field that wallets and exchanges let senders fill in.
+func (n BlockchainNetwork) SupportsMemo() bool {
+	return n.Mainnet() == NetworkTron
+}
@@ -24,4 +24,26 @@ func TestBlockchainNetwork(t *testing.T) {
 		invalidNetwork := shared.BlockchainNetwork("invalid")
 		require.False(t, invalidNetwork.IsValid())
 	})
+
+	t.Run("SupportsMemo", func(t *testing.T) {
+		require.True(t, shared.NetworkTron.SupportsMemo())
+		require.False(t, shared.NetworkEthereum.SupportsMemo())
+		require.False(t, shared.NetworkBitcoin.SupportsMemo())
+		require.False(t, shared.NetworkBSC.SupportsMemo())
+		require.True(t, shared.NetworkTronNile.SupportsMemo())
+	})
+
+	t.Run("Testnets", func(t *testing.T) {
+		require.True(t, shared.NetworkTronShasta.IsValid())
+		require.True(t, shared.NetworkTronShasta.IsTestnet())
+		require.False(t, shared.NetworkTron.IsTestnet())
+
+		require.Equal(t, shared.NetworkTron, shared.NetworkTronNile.Mainnet())
+		require.Equal(t, shared.NetworkEthereum, shared.NetworkEthereumSepolia.Mainnet())
+		require.Equal(t, shared.NetworkBitcoin, shared.NetworkBitcoinTestnet.Mainnet())
+		require.Equal(t, shared.NetworkBSC, shared.NetworkBSC.Mainnet())
+
+		require.Equal(t, "sepolia", shared.NetworkEthereumSepolia.Chain())
+		require.Equal(t, shared.ChainMainnet, shared.NetworkEthereum.Chain())
+	})
 }
@@ -0,0 +1,49 @@
+package shared
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Clock tells the current time. Aggregates and services read it through Now, so expirations, state
+// transitions and event timestamps follow whichever clock the application installs, and tests can move time
+// instead of waiting for it.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the wall clock.
+type SystemClock struct{}
+
+// Now returns the current wall clock time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// installedClock wraps the current clock so atomic.Value always stores the same type.
+type installedClock struct {
+	Clock
+}
+
+var clock atomic.Value
+
+func init() {
+	clock.Store(installedClock{SystemClock{}})
+}
+
+// Now returns the time of the installed clock, the wall clock unless SetClock installed another.
+func Now() time.Time {
+	return clock.Load().(installedClock).Now()
+}
+
+// SetClock installs the clock Now reads and returns a function that reinstalls the previous one. A nil clock
+// installs the wall clock. The clock is process wide, so tests that set it must not run in parallel.
+func SetClock(c Clock) (restore func()) {
+	if c == nil {
+		c = SystemClock{}
+	}
+	previous := clock.Swap(installedClock{c})
+	return func() {
+		clock.Store(previous)
+	}
+}
@@ -0,0 +1,30 @@
+package shared_test
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fixedClock always reads the same time.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestSetClock(t *testing.T) {
+	fixed := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	restore := shared.SetClock(fixedClock(fixed))
+	assert.Equal(t, fixed, shared.Now())
+
+	nested := shared.SetClock(nil)
+	assert.WithinDuration(t, time.Now(), shared.Now(), time.Minute)
+	nested()
+	assert.Equal(t, fixed, shared.Now())
+
+	restore()
+	assert.WithinDuration(t, time.Now(), shared.Now(), time.Minute)
+}
@@ -0,0 +1,22 @@
+package shared
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// CountryLocator tells the country of client addresses. Addresses are only looked up, never kept.
+type CountryLocator interface {
+	// Country returns the ISO 3166-1 alpha-2 code of the country of an address, or "" if it is not known.
+	Country(addr netip.Addr) string
+}
+
+// ParseCountryCode returns an ISO 3166-1 alpha-2 country code in upper case. It reports false for anything
+// else, such as the XX and T1 codes edge networks send for unknown and Tor clients.
+func ParseCountryCode(code string) (string, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 2 || code == "XX" || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+		return "", false
+	}
+	return code, true
+}
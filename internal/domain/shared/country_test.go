@@ -0,0 +1,16 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCountryCode(t *testing.T) {
+	tests := map[string]string{" de ": "DE", "US": "US", "XX": "", "T1": "", "DEU": "", "": ""}
+	for input, want := range tests {
+		code, ok := ParseCountryCode(input)
+		assert.Equal(t, want, code, input)
+		assert.Equal(t, want != "", ok, input)
+	}
+}
@@ -0,0 +1,46 @@
+package shared
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// Cursor marks a position in a list ordered by creation time and then ID, newest first.
+// Paging by cursor stays stable when rows are inserted while a client walks the list,
+// where an offset would skip or repeat rows.
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+// NewCursor returns the cursor positioned after the row with the given creation time and ID.
+func NewCursor(createdAt time.Time, id string) *Cursor {
+	return &Cursor{CreatedAt: createdAt.UTC(), ID: id}
+}
+
+// Encode returns the cursor as an opaque, URL-safe token.
+func (c *Cursor) Encode() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a token returned by Cursor.Encode.
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" || cursor.CreatedAt.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
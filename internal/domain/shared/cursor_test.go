@@ -0,0 +1,24 @@
+package shared_test
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	createdAt := time.Date(2025, 1, 15, 10, 30, 0, 123456000, time.UTC)
+	cursor := shared.NewCursor(createdAt, "inv_123")
+
+	decoded, err := shared.DecodeCursor(cursor.Encode())
+	require.NoError(t, err)
+	require.True(t, createdAt.Equal(decoded.CreatedAt))
+	require.Equal(t, "inv_123", decoded.ID)
+
+	for _, token := range []string{"", "not base64!", "e30", "eyJpZCI6ImludiJ9"} {
+		_, err := shared.DecodeCursor(token)
+		require.ErrorIs(t, err, shared.ErrInvalidCursor, token)
+	}
+}
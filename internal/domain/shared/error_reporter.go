@@ -0,0 +1,12 @@
+package shared
+
+import "context"
+
+// ErrorReporter sends unexpected failures to an error tracker. Reports are tagged with the merchant and the
+// request of the context they occurred in, when it has them.
+type ErrorReporter interface {
+	// CaptureError reports an error, with tags saying where it occurred.
+	CaptureError(ctx context.Context, err error, tags map[string]string)
+	// CapturePanic reports a recovered panic with the stack it occurred on.
+	CapturePanic(ctx context.Context, recovered any, stack []byte, tags map[string]string)
+}
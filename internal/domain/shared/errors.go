@@ -2,63 +2,191 @@ package shared
 
 import "errors"
 
+// Error kinds. Every error a service returns for a request it cannot serve is of one of these kinds, which
+// decides how it is answered: a missing resource, an invalid request, a resource in a state that does not allow
+// the operation, or a request conflicting with one already served. Services return sentinels of a kind, made with
+// NewError, or field errors, and callers match the exact error or its kind with errors.Is.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrValidation   = errors.New("validation failed")
+	ErrInvalidState = errors.New("invalid state")
+	ErrConflict     = errors.New("conflict")
+)
+
 // Common domain errors that can be shared across different domains
 
 var (
 	// Generic validation errors
-	ErrInvalidID          = errors.New("invalid ID")
-	ErrInvalidTitle       = errors.New("invalid title")
-	ErrInvalidDescription = errors.New("invalid description")
-	ErrInvalidAmount      = errors.New("invalid amount")
-	ErrInvalidCurrency    = errors.New("invalid currency")
-	ErrInvalidStatus      = errors.New("invalid status")
-	ErrInvalidTransition  = errors.New("invalid status transition")
-	ErrInvalidInput       = errors.New("invalid input")
+	ErrInvalidID          = NewError(ErrValidation, ErrCodeInvalidInput, "invalid ID")
+	ErrInvalidTitle       = NewError(ErrValidation, ErrCodeInvalidInput, "invalid title")
+	ErrInvalidDescription = NewError(ErrValidation, ErrCodeInvalidInput, "invalid description")
+	ErrInvalidAmount      = NewError(ErrValidation, ErrCodeInvalidInput, "invalid amount")
+	ErrInvalidCurrency    = NewError(ErrValidation, ErrCodeInvalidInput, "invalid currency")
+	ErrInvalidStatus      = NewError(ErrValidation, ErrCodeInvalidStatus, "invalid status")
+	ErrInvalidTransition  = NewError(ErrInvalidState, ErrCodeInvalidTransition, "invalid status transition")
+	ErrInvalidInput       = NewError(ErrValidation, ErrCodeInvalidInput, "invalid input")
 
 	// Money and currency errors
-	ErrInvalidMoneyAmount  = errors.New("invalid money amount")
-	ErrCurrencyMismatch    = errors.New("currency mismatch")
-	ErrNegativeAmount      = errors.New("amount cannot be negative")
-	ErrZeroAmount          = errors.New("amount cannot be zero")
-	ErrInvalidAmountFormat = errors.New("invalid amount format")
+	ErrInvalidMoneyAmount  = NewError(ErrValidation, ErrCodeInvalidInput, "invalid money amount")
+	ErrCurrencyMismatch    = NewError(ErrValidation, ErrCodeCurrencyMismatch, "currency mismatch")
+	ErrNegativeAmount      = NewError(ErrValidation, ErrCodeInvalidInput, "amount cannot be negative")
+	ErrZeroAmount          = NewError(ErrValidation, ErrCodeInvalidInput, "amount cannot be zero")
+	ErrInvalidAmountFormat = NewError(ErrValidation, ErrCodeInvalidInput, "invalid amount format")
 
 	// Payment and blockchain errors
-	ErrInvalidPaymentAddress    = errors.New("invalid payment address")
-	ErrInvalidTransactionHash   = errors.New("invalid transaction hash")
-	ErrInvalidNetwork           = errors.New("invalid blockchain network")
-	ErrExpiredPaymentAddress    = errors.New("payment address has expired")
-	ErrExpiredExchangeRate      = errors.New("exchange rate has expired")
-	ErrInvalidExchangeRate      = errors.New("invalid exchange rate")
-	ErrInvalidConfirmationCount = errors.New("invalid confirmation count")
+	ErrInvalidPaymentAddress    = NewError(ErrValidation, ErrCodeInvalidInput, "invalid payment address")
+	ErrInvalidTransactionHash   = NewError(ErrValidation, ErrCodeInvalidInput, "invalid transaction hash")
+	ErrInvalidNetwork           = NewError(ErrValidation, ErrCodeInvalidInput, "invalid blockchain network")
+	ErrExpiredPaymentAddress    = NewError(ErrInvalidState, ErrCodeExpired, "payment address has expired")
+	ErrExpiredExchangeRate      = NewError(ErrInvalidState, ErrCodeExpired, "exchange rate has expired")
+	ErrInvalidExchangeRate      = NewError(ErrValidation, ErrCodeInvalidInput, "invalid exchange rate")
+	ErrInvalidConfirmationCount = NewError(ErrValidation, ErrCodeInvalidInput, "invalid confirmation count")
+	ErrUnsupportedToken         = NewError(ErrValidation, ErrCodeInvalidInput, "cryptocurrency is not accepted")
 
 	// Service and repository errors
-	ErrNotFound          = errors.New("not found")
-	ErrAlreadyExists     = errors.New("already exists")
+	ErrAlreadyExists     = NewError(ErrConflict, ErrCodeAlreadyExists, "already exists")
 	ErrRepositoryError   = errors.New("repository error")
 	ErrServiceError      = errors.New("service error")
 	ErrIDGenerationError = errors.New("ID generation error")
-	ErrInvalidRequest    = errors.New("invalid request")
+	ErrInvalidRequest    = NewError(ErrValidation, ErrCodeInvalidInput, "invalid request")
 
 	// State and lifecycle errors
-	ErrInvalidState     = errors.New("invalid state")
-	ErrCannotTransition = errors.New("cannot transition to target state")
-	ErrAlreadyInState   = errors.New("already in target state")
-	ErrExpired          = errors.New("expired")
-	ErrTerminalState    = errors.New("cannot perform action in terminal state")
+	ErrCannotTransition = NewError(ErrInvalidState, ErrCodeInvalidTransition, "cannot transition to target state")
+	ErrAlreadyInState   = NewError(ErrConflict, ErrCodeInvalidState, "already in target state")
+	ErrExpired          = NewError(ErrInvalidState, ErrCodeExpired, "expired")
+	ErrTerminalState    = NewError(ErrInvalidState, ErrCodeTerminalState, "cannot perform action in terminal state")
 
 	// Business logic errors
-	ErrInsufficientAmount    = errors.New("insufficient amount")
-	ErrExcessiveAmount       = errors.New("excessive amount")
-	ErrValidationFailed      = errors.New("validation failed")
-	ErrBusinessRuleViolation = errors.New("business rule violation")
+	ErrInsufficientAmount    = NewError(ErrValidation, ErrCodeInsufficientAmount, "insufficient amount")
+	ErrExcessiveAmount       = NewError(ErrValidation, ErrCodeExcessiveAmount, "excessive amount")
+	ErrValidationFailed      = ErrValidation
+	ErrBusinessRuleViolation = NewError(ErrInvalidState, ErrCodeBusinessRuleViolation, "business rule violation")
+
+	// Event errors
+	ErrInvalidEvent = errors.New("invalid event")
 )
 
+// kinds are the error kinds, with the code answered for errors of a kind that carry none.
+var kinds = []struct {
+	kind error
+	code string
+}{
+	{ErrNotFound, ErrCodeNotFound},
+	{ErrValidation, ErrCodeValidationFailed},
+	{ErrInvalidState, ErrCodeInvalidState},
+	{ErrConflict, ErrCodeConflict},
+}
+
+// KindOf returns the kind of an error, or nil for an error of none, such as the failure of a dependency.
+func KindOf(err error) error {
+	for _, k := range kinds {
+		if errors.Is(err, k.kind) {
+			return k.kind
+		}
+	}
+	return nil
+}
+
+// CodeOf returns the code of an error: that of the first error in its chain carrying one, else that of its kind,
+// else an empty string.
+func CodeOf(err error) string {
+	var coded interface{ errorCode() string }
+	if errors.As(err, &coded) {
+		if code := coded.errorCode(); code != "" {
+			return code
+		}
+	}
+	kind := KindOf(err)
+	for _, k := range kinds {
+		if k.kind == kind {
+			return k.code
+		}
+	}
+	return ""
+}
+
+// FieldOf returns the request field a validation error is about, or an empty string.
+func FieldOf(err error) string {
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		return fieldErr.Field
+	}
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		if field, ok := domainErr.Details["field"].(string); ok {
+			return field
+		}
+	}
+	return ""
+}
+
+// kindError is an error of a kind, carrying the code answered to API clients.
+type kindError struct {
+	kind    error
+	code    string
+	message string
+}
+
+// NewError creates an error of a kind, usually declared as a sentinel. It matches both itself and its kind with
+// errors.Is, and its code is returned by CodeOf.
+func NewError(kind error, code, message string) error {
+	return &kindError{kind: kind, code: code, message: message}
+}
+
+func (e *kindError) Error() string { return e.message }
+
+func (e *kindError) Unwrap() error { return e.kind }
+
+func (e *kindError) errorCode() string { return e.code }
+
+// FieldError is a validation error about a field of a request, or about the request as a whole when the field is
+// empty. It matches ErrValidation and, when set, the error it wraps.
+type FieldError struct {
+	Field   string
+	Message string
+	Code    string
+	Err     error
+}
+
+// NewFieldError creates a validation error about a field.
+func NewFieldError(field, message string) *FieldError {
+	return &FieldError{Field: field, Message: message}
+}
+
+// WrapFieldError creates a validation error about a field from an error, such as a sentinel of the field's
+// domain, whose message and code it takes.
+func WrapFieldError(field string, err error) *FieldError {
+	return &FieldError{Field: field, Message: err.Error(), Err: err}
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+func (e *FieldError) errorCode() string {
+	if e.Code == "" && e.Err != nil {
+		return CodeOf(e.Err)
+	}
+	return e.Code
+}
+
+// Unwrap returns the underlying error and ErrValidation.
+func (e *FieldError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{ErrValidation}
+	}
+	return []error{e.Err, ErrValidation}
+}
+
 // DomainError represents a domain-specific error with additional context.
+// Its kind, when set, is matched with errors.Is like the error it wraps.
 type DomainError struct {
 	Code    string
 	Message string
 	Details map[string]interface{}
 	Err     error
+	Kind    error
 }
 
 // Error implements the error interface.
@@ -74,6 +202,15 @@ func (e *DomainError) Unwrap() error {
 	return e.Err
 }
 
+func (e *DomainError) errorCode() string {
+	return e.Code
+}
+
+// Is reports whether the error is of the target kind.
+func (e *DomainError) Is(target error) bool {
+	return e.Kind != nil && e.Kind == target
+}
+
 // NewDomainError creates a new domain error.
 func NewDomainError(code, message string, err error) *DomainError {
 	return &DomainError{
@@ -84,6 +221,12 @@ func NewDomainError(code, message string, err error) *DomainError {
 	}
 }
 
+// WithKind sets the kind of the error.
+func (e *DomainError) WithKind(kind error) *DomainError {
+	e.Kind = kind
+	return e
+}
+
 // WithDetail adds a detail to the error.
 func (e *DomainError) WithDetail(key string, value interface{}) *DomainError {
 	if e.Details == nil {
@@ -121,6 +264,7 @@ const (
 	ErrCodeCurrencyMismatch      = "CURRENCY_MISMATCH"
 	ErrCodeInvalidState          = "INVALID_STATE"
 	ErrCodeTerminalState         = "TERMINAL_STATE"
+	ErrCodeConflict              = "CONFLICT"
 )
 
 // Error constructors for common patterns
@@ -129,54 +273,62 @@ const (
 func NewValidationError(field, reason string) *DomainError {
 	return NewDomainError(ErrCodeValidationFailed, "validation failed", nil).
 		WithDetail("field", field).
-		WithDetail("reason", reason)
+		WithDetail("reason", reason).
+		WithKind(ErrValidation)
 }
 
 // NewNotFoundError creates a not found error.
 func NewNotFoundError(resource, id string) *DomainError {
 	return NewDomainError(ErrCodeNotFound, resource+" not found", nil).
 		WithDetail("resource", resource).
-		WithDetail("id", id)
+		WithDetail("id", id).
+		WithKind(ErrNotFound)
 }
 
 // NewInvalidTransitionError creates an invalid transition error.
 func NewInvalidTransitionError(from, to string) *DomainError {
 	return NewDomainError(ErrCodeInvalidTransition, "invalid transition", nil).
 		WithDetail("from", from).
-		WithDetail("to", to)
+		WithDetail("to", to).
+		WithKind(ErrInvalidState)
 }
 
 // NewBusinessRuleViolationError creates a business rule violation error.
 func NewBusinessRuleViolationError(rule, reason string) *DomainError {
 	return NewDomainError(ErrCodeBusinessRuleViolation, "business rule violation", nil).
 		WithDetail("rule", rule).
-		WithDetail("reason", reason)
+		WithDetail("reason", reason).
+		WithKind(ErrInvalidState)
 }
 
 // NewCurrencyMismatchError creates a currency mismatch error.
 func NewCurrencyMismatchError(expected, actual string) *DomainError {
 	return NewDomainError(ErrCodeCurrencyMismatch, "currency mismatch", nil).
 		WithDetail("expected", expected).
-		WithDetail("actual", actual)
+		WithDetail("actual", actual).
+		WithKind(ErrValidation)
 }
 
 // NewInsufficientAmountError creates an insufficient amount error.
 func NewInsufficientAmountError(required, provided string) *DomainError {
 	return NewDomainError(ErrCodeInsufficientAmount, "insufficient amount", nil).
 		WithDetail("required", required).
-		WithDetail("provided", provided)
+		WithDetail("provided", provided).
+		WithKind(ErrValidation)
 }
 
 // NewExcessiveAmountError creates an excessive amount error.
 func NewExcessiveAmountError(limit, provided string) *DomainError {
 	return NewDomainError(ErrCodeExcessiveAmount, "excessive amount", nil).
 		WithDetail("limit", limit).
-		WithDetail("provided", provided)
+		WithDetail("provided", provided).
+		WithKind(ErrValidation)
 }
 
 // NewTerminalStateError creates a terminal state error.
 func NewTerminalStateError(state, action string) *DomainError {
 	return NewDomainError(ErrCodeTerminalState, "cannot perform action in terminal state", nil).
 		WithDetail("state", state).
-		WithDetail("action", action)
+		WithDetail("action", action).
+		WithKind(ErrInvalidState)
 }
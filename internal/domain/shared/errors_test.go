@@ -3,6 +3,7 @@ package shared_test
 import (
 	"crypto-checkout/internal/domain/shared"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -231,3 +232,57 @@ func TestErrorCodes(t *testing.T) {
 		require.Equal(t, "TERMINAL_STATE", shared.ErrCodeTerminalState)
 	})
 }
+
+func TestErrorKinds(t *testing.T) {
+	errNotDraft := shared.NewError(shared.ErrInvalidState, "NOT_DRAFT", "only drafts can be edited")
+
+	t.Run("kinded errors match themselves and their kind", func(t *testing.T) {
+		err := fmt.Errorf("editing invoice inv_1: %w", errNotDraft)
+		require.ErrorIs(t, err, errNotDraft)
+		require.ErrorIs(t, err, shared.ErrInvalidState)
+		require.NotErrorIs(t, err, shared.ErrValidation)
+		require.Equal(t, shared.ErrInvalidState, shared.KindOf(err))
+		require.Equal(t, "NOT_DRAFT", shared.CodeOf(err))
+		require.Equal(t, "only drafts can be edited", errNotDraft.Error())
+	})
+
+	t.Run("shared sentinels have kinds", func(t *testing.T) {
+		require.Equal(t, shared.ErrConflict, shared.KindOf(shared.ErrAlreadyExists))
+		require.Equal(t, shared.ErrInvalidState, shared.KindOf(shared.ErrTerminalState))
+		require.Equal(t, shared.ErrValidation, shared.KindOf(shared.ErrInvalidAmount))
+		require.Equal(t, shared.ErrValidation, shared.ErrValidationFailed)
+		require.Equal(t, "already exists", shared.ErrAlreadyExists.Error())
+	})
+
+	t.Run("field errors", func(t *testing.T) {
+		err := fmt.Errorf("creating invoice: %w", shared.NewFieldError("title", "title is required"))
+		require.ErrorIs(t, err, shared.ErrValidation)
+		require.Equal(t, "title", shared.FieldOf(err))
+		require.Equal(t, shared.ErrCodeValidationFailed, shared.CodeOf(err))
+		require.Equal(t, "creating invoice: title is required", err.Error())
+
+		wrapped := shared.WrapFieldError("amount", shared.ErrNegativeAmount)
+		require.ErrorIs(t, wrapped, shared.ErrNegativeAmount)
+		require.ErrorIs(t, wrapped, shared.ErrValidation)
+		require.Equal(t, shared.ErrCodeInvalidInput, shared.CodeOf(wrapped))
+		require.Equal(t, "amount cannot be negative", wrapped.Error())
+	})
+
+	t.Run("domain errors", func(t *testing.T) {
+		err := shared.NewNotFoundError("invoice", "inv_1")
+		require.ErrorIs(t, err, shared.ErrNotFound)
+		require.Equal(t, shared.ErrCodeNotFound, shared.CodeOf(err))
+
+		err = shared.NewValidationError("currency", "unsupported")
+		require.ErrorIs(t, err, shared.ErrValidation)
+		require.Equal(t, "currency", shared.FieldOf(err))
+	})
+
+	t.Run("errors of no kind", func(t *testing.T) {
+		err := errors.New("connection refused")
+		require.NoError(t, shared.KindOf(err))
+		require.Empty(t, shared.CodeOf(err))
+		require.Empty(t, shared.FieldOf(err))
+		require.NoError(t, shared.KindOf(shared.NewDomainError("TEST_CODE", "test message", nil)))
+	})
+}
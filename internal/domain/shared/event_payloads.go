@@ -0,0 +1,634 @@
+package shared
+
+import "time"
+
+// Aggregate types of the published events
+const (
+	AggregateTypeInvoice    = "Invoice"
+	AggregateTypePayment    = "Payment"
+	AggregateTypeDeposit    = "Deposit"
+	AggregateTypeSettlement = "Settlement"
+	AggregateTypePayout     = "Payout"
+	AggregateTypeTreasury   = "Treasury"
+	AggregateTypeStatement  = "Statement"
+	AggregateTypeSLO        = "SLO"
+)
+
+// InvoiceEvent is the invoice carried by every invoice event. Amounts are decimal strings.
+type InvoiceEvent struct {
+	InvoiceID    string    `json:"invoice_id"`
+	MerchantID   string    `json:"merchant_id"`
+	TotalAmount  string    `json:"total_amount"`
+	CryptoAmount string    `json:"crypto_amount,omitempty"` // Empty until an exchange rate is locked
+	Currency     string    `json:"currency"`
+	Status       string    `json:"status"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	Description  string    `json:"description"`
+	Number       string    `json:"number,omitempty"`
+	Supersedes   string    `json:"supersedes,omitempty"`
+	SupersededBy string    `json:"superseded_by,omitempty"`
+	// Labelled values the merchant shows customers, whatever their visibility
+	CustomFields []InvoiceCustomField `json:"custom_fields,omitempty"`
+	Timestamp    time.Time            `json:"timestamp"`
+}
+
+// InvoiceCustomField is a custom field of the invoice carried by invoice events.
+type InvoiceCustomField struct {
+	Label      string `json:"label"`
+	Value      string `json:"value"`
+	Type       string `json:"type"`
+	Visibility string `json:"visibility"`
+}
+
+// InvoiceStatusChangedEvent reports an invoice status change, with the facts of what changed it.
+type InvoiceStatusChangedEvent struct {
+	InvoiceEvent
+
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	// Set when the customer viewed the invoice
+	ViewedAt *time.Time `json:"viewed_at,omitempty"`
+	// Set when a payment was credited to the invoice
+	PaymentAmount     string     `json:"payment_amount,omitempty"`
+	PaymentValidation string     `json:"payment_validation,omitempty"`
+	ProcessedAt       *time.Time `json:"processed_at,omitempty"`
+	// Set when the status was changed on request
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	// Set when a lapsed partial invoice was closed underpaid
+	Resolution         string     `json:"resolution,omitempty"`
+	GracePeriodSeconds int64      `json:"grace_period_seconds,omitempty"`
+	AmountPaid         string     `json:"amount_paid,omitempty"`
+	ClosedAt           *time.Time `json:"closed_at,omitempty"`
+}
+
+// InvoiceCancelledEvent reports a cancelled invoice.
+type InvoiceCancelledEvent struct {
+	InvoiceEvent
+
+	Reason      string    `json:"reason"`
+	CancelledAt time.Time `json:"cancelled_at"`
+}
+
+// InvoiceExpiredEvent reports an invoice that expired unpaid.
+type InvoiceExpiredEvent struct {
+	InvoiceEvent
+
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+// InvoiceRefundedEvent reports a refund of an invoice.
+type InvoiceRefundedEvent struct {
+	InvoiceEvent
+
+	RefundID         string `json:"refund_id"`
+	RefundAmount     string `json:"refund_amount"`
+	RefundedAmount   string `json:"refunded_amount"`
+	RefundableAmount string `json:"refundable_amount,omitempty"`
+	Reason           string `json:"reason"`
+	RefundAddress    string `json:"refund_address"`
+}
+
+// InvoiceCouponAppliedEvent reports a coupon applied to an invoice.
+type InvoiceCouponAppliedEvent struct {
+	InvoiceEvent
+
+	CouponCode     string `json:"coupon_code"`
+	DiscountAmount string `json:"discount_amount"`
+}
+
+// InvoiceRequotedEvent reports the new amount of an invoice re-quoted at a new exchange rate.
+type InvoiceRequotedEvent struct {
+	InvoiceEvent
+
+	PreviousRate         string    `json:"previous_rate"`
+	NewRate              string    `json:"new_rate"`
+	PreviousCryptoAmount string    `json:"previous_crypto_amount"`
+	NewCryptoAmount      string    `json:"new_crypto_amount"`
+	Slippage             string    `json:"slippage"`
+	RateExpiresAt        time.Time `json:"rate_expires_at"`
+}
+
+// InvoiceExtendedEvent reports a new expiry of an invoice.
+type InvoiceExtendedEvent struct {
+	InvoiceEvent
+
+	PreviousExpiresAt time.Time `json:"previous_expires_at"`
+	ExtendedBySeconds int64     `json:"extended_by_seconds"`
+}
+
+// InvoicePaymentReversedEvent reports a payment of an invoice removed from the chain after it was credited.
+type InvoicePaymentReversedEvent struct {
+	InvoiceEvent
+
+	PaymentID       string `json:"payment_id"`
+	TransactionHash string `json:"transaction_hash"`
+	ReversedAmount  string `json:"reversed_amount"`
+	PreviousStatus  string `json:"previous_status"`
+	AmountPaid      string `json:"amount_paid,omitempty"`
+	Reason          string `json:"reason"`
+}
+
+// PaymentEvent is the payment carried by payment events.
+type PaymentEvent struct {
+	PaymentID       string    `json:"payment_id"`
+	InvoiceID       string    `json:"invoice_id"`
+	Amount          string    `json:"amount"`
+	Status          string    `json:"status"`
+	TransactionHash string    `json:"transaction_hash"`
+	FromAddress     string    `json:"from_address"`
+	ToAddress       string    `json:"to_address"`
+	DetectedAt      time.Time `json:"detected_at"`
+	Confirmations   int       `json:"confirmations"`
+	BlockNumber     int64     `json:"block_number,omitempty"` // Zero until the payment is in a block
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// PaymentStatusChangedEvent reports a payment status change and the payment event that caused it.
+type PaymentStatusChangedEvent struct {
+	PaymentEvent
+
+	EventTriggered  string    `json:"event_triggered"`
+	StatusChangedAt time.Time `json:"status_changed_at"`
+}
+
+// PaymentConfirmationEvent reports a new confirmation of a payment, published for every confirmation up to the
+// ones the payment needs.
+type PaymentConfirmationEvent struct {
+	PaymentEvent
+
+	RequiredConfirmations int       `json:"required_confirmations"`
+	BlockHash             string    `json:"block_hash,omitempty"`
+	EstimatedFinalityAt   time.Time `json:"estimated_finality_at"`
+}
+
+// PaymentRefundRequestedEvent reports an operator's request to refund the funds received with a payment.
+type PaymentRefundRequestedEvent struct {
+	PaymentID   string    `json:"payment_id"`
+	InvoiceID   string    `json:"invoice_id"`
+	MerchantID  string    `json:"merchant_id"`
+	Amount      string    `json:"amount"`
+	Currency    string    `json:"currency"`
+	ReviewID    string    `json:"review_id"`
+	RequestedBy string    `json:"requested_by"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// DepositEvent is the deposit carried by deposit events.
+type DepositEvent struct {
+	DepositID         string    `json:"deposit_id"`
+	MerchantID        string    `json:"merchant_id"`
+	InvoiceID         string    `json:"invoice_id"`
+	Reason            string    `json:"reason"`
+	Status            string    `json:"status"`
+	Network           string    `json:"network"`
+	TransactionHash   string    `json:"transaction_hash"`
+	FromAddress       string    `json:"from_address"`
+	ToAddress         string    `json:"to_address"`
+	Amount            string    `json:"amount"`
+	Currency          string    `json:"currency"`
+	AttachedInvoiceID string    `json:"attached_invoice_id"`
+	PaymentID         string    `json:"payment_id"`
+	Timestamp         time.Time `json:"timestamp"`
+}
+
+// DepositRefundRequestedEvent reports a merchant's request to refund a deposit to its sender.
+type DepositRefundRequestedEvent struct {
+	DepositEvent
+
+	RefundAddress string `json:"refund_address"`
+	RequestedBy   string `json:"requested_by"`
+	Note          string `json:"note"`
+}
+
+// SettlementEvent is the settlement carried by settlement events.
+type SettlementEvent struct {
+	SettlementID      string                `json:"settlement_id"`
+	InvoiceID         string                `json:"invoice_id"`
+	MerchantID        string                `json:"merchant_id"`
+	GrossAmount       string                `json:"gross_amount"`
+	PlatformFeeAmount string                `json:"platform_fee_amount"`
+	FeePercentage     string                `json:"fee_percentage"`
+	NetAmount         string                `json:"net_amount"`
+	Currency          string                `json:"currency"`
+	Status            string                `json:"status"`
+	SettledAt         *time.Time            `json:"settled_at,omitempty"`
+	FailureReason     string                `json:"failure_reason,omitempty"`
+	Conversion        *SettlementConversion `json:"conversion,omitempty"`
+	Timestamp         time.Time             `json:"timestamp"`
+}
+
+// SettlementConversion is the conversion of a settlement to fiat at an exchange.
+type SettlementConversion struct {
+	Exchange     string `json:"exchange"`
+	OrderID      string `json:"order_id"`
+	Status       string `json:"status"`
+	FiatCurrency string `json:"fiat_currency"`
+	FiatAmount   string `json:"fiat_amount"`
+	Fee          string `json:"fee"`
+}
+
+// SettlementReversedEvent reports a settlement reversed for a payment removed from the chain.
+type SettlementReversedEvent struct {
+	SettlementEvent
+
+	Reversal SettlementReversal `json:"reversal"`
+}
+
+// SettlementReversal is the reversal entry of a settlement, with negative amounts.
+type SettlementReversal struct {
+	ReversalID        string    `json:"reversal_id"`
+	PaymentID         string    `json:"payment_id"`
+	GrossAmount       string    `json:"gross_amount"`
+	PlatformFeeAmount string    `json:"platform_fee_amount"`
+	NetAmount         string    `json:"net_amount"`
+	Reason            string    `json:"reason"`
+	PaidOut           bool      `json:"paid_out"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// PayoutEvent is the payout carried by payout events.
+type PayoutEvent struct {
+	PayoutID      string   `json:"payout_id"`
+	MerchantID    string   `json:"merchant_id"`
+	Network       string   `json:"network"`
+	Address       string   `json:"address"`
+	Amount        string   `json:"amount"`
+	Currency      string   `json:"currency"`
+	SettlementIDs []string `json:"settlement_ids"`
+	Status        string   `json:"status"`
+	TxHash        string   `json:"tx_hash,omitempty"`
+	FailureReason string   `json:"failure_reason,omitempty"`
+}
+
+// TreasuryBalanceEvent reports a hot wallet balance that crossed one of its thresholds.
+type TreasuryBalanceEvent struct {
+	Network   string `json:"network"`
+	Currency  string `json:"currency"`
+	Balance   string `json:"balance"`
+	Level     string `json:"level"`
+	Threshold string `json:"threshold"`
+}
+
+// SweepEvent is the sweep of a deposit address to the hot wallet carried by sweep events.
+type SweepEvent struct {
+	SweepID       string `json:"sweep_id"`
+	InvoiceID     string `json:"invoice_id"`
+	Network       string `json:"network"`
+	Currency      string `json:"currency"`
+	FromAddress   string `json:"from_address"`
+	Amount        string `json:"amount"`
+	Status        string `json:"status"`
+	TxHash        string `json:"tx_hash,omitempty"`
+	FailureReason string `json:"failure_reason,omitempty"`
+}
+
+// ColdTransferEvent is the transfer from the hot wallet to cold storage carried by cold transfer events.
+type ColdTransferEvent struct {
+	TransferID    string `json:"transfer_id"`
+	Network       string `json:"network"`
+	Currency      string `json:"currency"`
+	ToAddress     string `json:"to_address"`
+	Amount        string `json:"amount"`
+	Status        string `json:"status"`
+	RequestedBy   string `json:"requested_by"`
+	ReviewedBy    string `json:"reviewed_by,omitempty"`
+	TxHash        string `json:"tx_hash,omitempty"`
+	FailureReason string `json:"failure_reason,omitempty"`
+}
+
+// ReconciliationEvent reports a reconciliation whose discrepancies exceeded the threshold of some currencies.
+// Totals are the sums of the absolute differences by currency, as decimal strings.
+type ReconciliationEvent struct {
+	ReportID      string            `json:"report_id"`
+	PeriodStart   time.Time         `json:"period_start"`
+	PeriodEnd     time.Time         `json:"period_end"`
+	Discrepancies int               `json:"discrepancies"`
+	Currencies    []string          `json:"currencies"`
+	Totals        map[string]string `json:"totals"`
+}
+
+// StatementEvent reports a merchant's monthly statement, downloadable in the formats listed.
+type StatementEvent struct {
+	StatementID string    `json:"statement_id"`
+	MerchantID  string    `json:"merchant_id"`
+	Period      string    `json:"period"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	Formats     []string  `json:"formats"`
+}
+
+// SLOEvent reports a service level objective whose error budget is burning fast or is spent. Compliance and
+// ErrorBudgetRemaining are fractions over the objective's window; a burn rate of 1 spends the budget exactly over
+// the window.
+type SLOEvent struct {
+	Objective            string  `json:"objective"`
+	Target               float64 `json:"target"`
+	Compliance           float64 `json:"compliance"`
+	ErrorBudgetRemaining float64 `json:"error_budget_remaining"`
+	BurnRate1h           float64 `json:"burn_rate_1h"`
+	BurnRate6h           float64 `json:"burn_rate_6h"`
+	Reason               string  `json:"reason"`
+}
+
+// builtinEventSchemas returns the schemas of the events published by the domain.
+func builtinEventSchemas() []EventSchema {
+	return []EventSchema{
+		invoiceSchema(EventTypeInvoiceCreated, "An invoice became payable", InvoiceEvent{}),
+		invoiceSchema(EventTypeInvoicePaid, "An invoice was paid in full", InvoiceEvent{}),
+		invoiceSchema(EventTypeInvoiceStatusChanged, "An invoice changed status", InvoiceStatusChangedEvent{}),
+		invoiceSchema(EventTypeInvoiceCancelled, "An invoice was cancelled", InvoiceCancelledEvent{}),
+		invoiceSchema(EventTypeInvoiceExpired, "An invoice expired unpaid", InvoiceExpiredEvent{}),
+		invoiceSchema(EventTypeInvoiceRefunded, "An invoice was refunded", InvoiceRefundedEvent{}),
+		invoiceSchema(EventTypeInvoiceCouponApplied, "A coupon was applied to an invoice", InvoiceCouponAppliedEvent{}),
+		invoiceSchema(EventTypeInvoiceRequoted, "An invoice was re-quoted at a new exchange rate",
+			InvoiceRequotedEvent{}),
+		invoiceSchema(EventTypeInvoiceExtended, "An invoice got a new expiry", InvoiceExtendedEvent{}),
+		invoiceSchema(EventTypeInvoiceReversal, "A credited payment of an invoice was reversed",
+			InvoicePaymentReversedEvent{}),
+		{
+			Type: EventTypePaymentDetected, Version: 1, AggregateType: AggregateTypePayment,
+			Description: "A payment was detected on the chain", Payload: PaymentEvent{},
+		},
+		{
+			Type: EventTypePaymentStatusChanged, Version: 1, AggregateType: AggregateTypePayment,
+			Description: "A payment changed status", Payload: PaymentStatusChangedEvent{},
+		},
+		{
+			Type: EventTypePaymentConfirmation, Version: 1, AggregateType: AggregateTypePayment,
+			Description: "A payment gained a confirmation", Payload: PaymentConfirmationEvent{},
+		},
+		{
+			Type: EventTypePaymentRefundRequested, Version: 1, AggregateType: AggregateTypePayment,
+			Description: "A refund of the funds received with a payment was requested",
+			Payload:     PaymentRefundRequestedEvent{},
+		},
+		{
+			Type: EventTypeDepositUnattributed, Version: 1, AggregateType: AggregateTypeDeposit,
+			Description: "A transfer could not be credited to an invoice", Payload: DepositEvent{},
+		},
+		{
+			Type: EventTypeDepositAttached, Version: 1, AggregateType: AggregateTypeDeposit,
+			Description: "A deposit was attached to an invoice", Payload: DepositEvent{},
+		},
+		{
+			Type: EventTypeDepositRefundRequested, Version: 1, AggregateType: AggregateTypeDeposit,
+			Description: "A refund of a deposit was requested", Payload: DepositRefundRequestedEvent{},
+		},
+		settlementSchema(EventTypeSettlementCreated, "A settlement was created for a paid invoice"),
+		settlementSchema(EventTypeSettlementCompleted, "A settlement was completed"),
+		settlementSchema(EventTypeSettlementFailed, "A settlement failed"),
+		{
+			Type: EventTypeSettlementReversed, Version: 1, AggregateType: AggregateTypeSettlement,
+			Description: "A settlement was reversed", Payload: SettlementReversedEvent{},
+		},
+		payoutSchema(EventTypePayoutBroadcast, "A payout was broadcast"),
+		payoutSchema(EventTypePayoutConfirmed, "A payout was confirmed"),
+		payoutSchema(EventTypePayoutFailed, "A payout failed"),
+		{
+			Type: EventTypeTreasuryBalanceLow, Version: 1, AggregateType: AggregateTypeTreasury,
+			Description: "A hot wallet balance fell below its low threshold", Payload: TreasuryBalanceEvent{},
+		},
+		{
+			Type: EventTypeTreasuryBalanceHigh, Version: 1, AggregateType: AggregateTypeTreasury,
+			Description: "A hot wallet balance rose above its high threshold", Payload: TreasuryBalanceEvent{},
+		},
+		{
+			Type: EventTypeTreasurySweepFailed, Version: 1, AggregateType: AggregateTypeTreasury,
+			Description: "A sweep to the hot wallet failed", Payload: SweepEvent{},
+		},
+		coldTransferSchema(EventTypeColdTransferRequested, "A transfer to cold storage was requested"),
+		coldTransferSchema(EventTypeColdTransferApproved, "A transfer to cold storage was approved"),
+		coldTransferSchema(EventTypeColdTransferConfirmed, "A transfer to cold storage was confirmed"),
+		coldTransferSchema(EventTypeColdTransferFailed, "A transfer to cold storage failed"),
+		{
+			Type: EventTypeReconciliationAlert, Version: 1, AggregateType: AggregateTypeTreasury,
+			Description: "A reconciliation found discrepancies above their threshold", Payload: ReconciliationEvent{},
+		},
+		{
+			Type: EventTypeStatementGenerated, Version: 1, AggregateType: AggregateTypeStatement,
+			Description: "A merchant's monthly statement was generated", Payload: StatementEvent{},
+		},
+		{
+			Type: EventTypeSLOBudgetAtRisk, Version: 1, AggregateType: AggregateTypeSLO,
+			Description: "The error budget of a service level objective is at risk", Payload: SLOEvent{},
+		},
+	}
+}
+
+// invoiceSchema returns the schema of an invoice event.
+func invoiceSchema(eventType, description string, payload interface{}) EventSchema {
+	return EventSchema{
+		Type: eventType, Version: 1, AggregateType: AggregateTypeInvoice, Description: description, Payload: payload,
+	}
+}
+
+// settlementSchema returns the schema of a settlement lifecycle event.
+func settlementSchema(eventType, description string) EventSchema {
+	return EventSchema{
+		Type: eventType, Version: 1, AggregateType: AggregateTypeSettlement, Description: description,
+		Payload: SettlementEvent{},
+	}
+}
+
+// payoutSchema returns the schema of a payout event.
+func payoutSchema(eventType, description string) EventSchema {
+	return EventSchema{
+		Type: eventType, Version: 1, AggregateType: AggregateTypePayout, Description: description,
+		Payload: PayoutEvent{},
+	}
+}
+
+// coldTransferSchema returns the schema of a cold storage transfer event.
+func coldTransferSchema(eventType, description string) EventSchema {
+	return EventSchema{
+		Type: eventType, Version: 1, AggregateType: AggregateTypeTreasury, Description: description,
+		Payload: ColdTransferEvent{},
+	}
+}
+
+// newTypedEvent creates an event of a registered type, from the aggregate and at the version of its schema.
+func newTypedEvent(eventType, aggregateID string, data interface{}) *BaseDomainEvent {
+	schema, ok := defaultEventRegistry.Schema(eventType)
+	if !ok {
+		panic("event type " + eventType + " is not registered")
+	}
+	event := CreateDomainEvent(eventType, aggregateID, schema.AggregateType, data, nil)
+	event.EventVersion = schema.Version
+	return event
+}
+
+// NewInvoiceCreatedEvent creates the event announcing a payable invoice.
+func NewInvoiceCreatedEvent(data InvoiceEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeInvoiceCreated, data.InvoiceID, data)
+}
+
+// NewInvoicePaidEvent creates the event announcing an invoice paid in full.
+func NewInvoicePaidEvent(data InvoiceEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeInvoicePaid, data.InvoiceID, data)
+}
+
+// NewInvoiceStatusChangedEvent creates the event announcing an invoice status change.
+func NewInvoiceStatusChangedEvent(data InvoiceStatusChangedEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeInvoiceStatusChanged, data.InvoiceID, data)
+}
+
+// NewInvoiceCancelledEvent creates the event announcing a cancelled invoice.
+func NewInvoiceCancelledEvent(data InvoiceCancelledEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeInvoiceCancelled, data.InvoiceID, data)
+}
+
+// NewInvoiceExpiredEvent creates the event announcing an expired invoice.
+func NewInvoiceExpiredEvent(data InvoiceExpiredEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeInvoiceExpired, data.InvoiceID, data)
+}
+
+// NewInvoiceRefundedEvent creates the event announcing a refund of an invoice.
+func NewInvoiceRefundedEvent(data InvoiceRefundedEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeInvoiceRefunded, data.InvoiceID, data)
+}
+
+// NewInvoiceCouponAppliedEvent creates the event announcing a coupon applied to an invoice.
+func NewInvoiceCouponAppliedEvent(data InvoiceCouponAppliedEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeInvoiceCouponApplied, data.InvoiceID, data)
+}
+
+// NewInvoiceRequotedEvent creates the event announcing a re-quoted invoice.
+func NewInvoiceRequotedEvent(data InvoiceRequotedEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeInvoiceRequoted, data.InvoiceID, data)
+}
+
+// NewInvoiceExtendedEvent creates the event announcing a new expiry of an invoice.
+func NewInvoiceExtendedEvent(data InvoiceExtendedEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeInvoiceExtended, data.InvoiceID, data)
+}
+
+// NewInvoicePaymentReversedEvent creates the event announcing a reversed payment of an invoice.
+func NewInvoicePaymentReversedEvent(data InvoicePaymentReversedEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeInvoiceReversal, data.InvoiceID, data)
+}
+
+// NewPaymentDetectedEvent creates the event announcing a detected payment.
+func NewPaymentDetectedEvent(data PaymentEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypePaymentDetected, data.PaymentID, data)
+}
+
+// NewPaymentStatusChangedEvent creates the event announcing a payment status change.
+func NewPaymentStatusChangedEvent(data PaymentStatusChangedEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypePaymentStatusChanged, data.PaymentID, data)
+}
+
+// NewPaymentConfirmationEvent creates the event announcing a new confirmation of a payment.
+func NewPaymentConfirmationEvent(data PaymentConfirmationEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypePaymentConfirmation, data.PaymentID, data)
+}
+
+// NewPaymentRefundRequestedEvent creates the event requesting a refund of a payment's funds.
+func NewPaymentRefundRequestedEvent(data PaymentRefundRequestedEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypePaymentRefundRequested, data.PaymentID, data)
+}
+
+// NewDepositUnattributedEvent creates the event announcing a deposit that could not be credited.
+func NewDepositUnattributedEvent(data DepositEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeDepositUnattributed, data.DepositID, data)
+}
+
+// NewDepositAttachedEvent creates the event announcing a deposit attached to an invoice.
+func NewDepositAttachedEvent(data DepositEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeDepositAttached, data.DepositID, data)
+}
+
+// NewDepositRefundRequestedEvent creates the event requesting a refund of a deposit.
+func NewDepositRefundRequestedEvent(data DepositRefundRequestedEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeDepositRefundRequested, data.DepositID, data)
+}
+
+// NewSettlementCreatedEvent creates the event announcing a new settlement.
+func NewSettlementCreatedEvent(data SettlementEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeSettlementCreated, data.SettlementID, data)
+}
+
+// NewSettlementCompletedEvent creates the event announcing a completed settlement.
+func NewSettlementCompletedEvent(data SettlementEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeSettlementCompleted, data.SettlementID, data)
+}
+
+// NewSettlementFailedEvent creates the event announcing a failed settlement.
+func NewSettlementFailedEvent(data SettlementEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeSettlementFailed, data.SettlementID, data)
+}
+
+// NewSettlementReversedEvent creates the event announcing a reversed settlement.
+func NewSettlementReversedEvent(data SettlementReversedEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeSettlementReversed, data.SettlementID, data)
+}
+
+// NewPayoutBroadcastEvent creates the event announcing a broadcast payout.
+func NewPayoutBroadcastEvent(data PayoutEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypePayoutBroadcast, data.PayoutID, data)
+}
+
+// NewPayoutConfirmedEvent creates the event announcing a confirmed payout.
+func NewPayoutConfirmedEvent(data PayoutEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypePayoutConfirmed, data.PayoutID, data)
+}
+
+// NewPayoutFailedEvent creates the event announcing a failed payout.
+func NewPayoutFailedEvent(data PayoutEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypePayoutFailed, data.PayoutID, data)
+}
+
+// NewTreasuryBalanceLowEvent creates the event announcing a hot wallet balance below its low threshold.
+// The aggregate is the currency of the balance.
+func NewTreasuryBalanceLowEvent(data TreasuryBalanceEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeTreasuryBalanceLow, data.Currency, data)
+}
+
+// NewTreasuryBalanceHighEvent creates the event announcing a hot wallet balance above its high threshold.
+// The aggregate is the currency of the balance.
+func NewTreasuryBalanceHighEvent(data TreasuryBalanceEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeTreasuryBalanceHigh, data.Currency, data)
+}
+
+// NewTreasurySweepFailedEvent creates the event announcing a failed sweep.
+func NewTreasurySweepFailedEvent(data SweepEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeTreasurySweepFailed, data.SweepID, data)
+}
+
+// NewColdTransferRequestedEvent creates the event announcing a requested cold storage transfer.
+func NewColdTransferRequestedEvent(data ColdTransferEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeColdTransferRequested, data.TransferID, data)
+}
+
+// NewColdTransferApprovedEvent creates the event announcing an approved cold storage transfer.
+func NewColdTransferApprovedEvent(data ColdTransferEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeColdTransferApproved, data.TransferID, data)
+}
+
+// NewColdTransferConfirmedEvent creates the event announcing a confirmed cold storage transfer.
+func NewColdTransferConfirmedEvent(data ColdTransferEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeColdTransferConfirmed, data.TransferID, data)
+}
+
+// NewColdTransferFailedEvent creates the event announcing a failed cold storage transfer.
+func NewColdTransferFailedEvent(data ColdTransferEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeColdTransferFailed, data.TransferID, data)
+}
+
+// NewReconciliationAlertEvent creates the event announcing a reconciliation whose discrepancies exceeded their
+// threshold. The aggregate is the report.
+func NewReconciliationAlertEvent(data ReconciliationEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeReconciliationAlert, data.ReportID, data)
+}
+
+// NewSLOBudgetAtRiskEvent creates the event announcing a service level objective whose error budget is at risk.
+// The aggregate is the objective.
+func NewSLOBudgetAtRiskEvent(data SLOEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeSLOBudgetAtRisk, data.Objective, data)
+}
+
+// NewStatementGeneratedEvent creates the event announcing a merchant's monthly statement. The aggregate is the
+// statement.
+func NewStatementGeneratedEvent(data StatementEvent) *BaseDomainEvent {
+	return newTypedEvent(EventTypeStatementGenerated, data.StatementID, data)
+}
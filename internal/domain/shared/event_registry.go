@@ -0,0 +1,391 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDialect is the JSON Schema dialect of the exported event schemas.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// EventSchema describes an event type: the aggregate publishing it and the typed payload it carries.
+type EventSchema struct {
+	Type          string // Event type, e.g. invoice.created
+	Version       int    // Version of the payload, incremented on incompatible changes
+	AggregateType string // Type of the aggregate publishing the event
+	Description   string
+	// Payload is a zero value of the struct the event carries, e.g. InvoiceEvent{}.
+	Payload interface{}
+
+	root *schemaNode
+}
+
+// EventRegistry maps event types to their schemas. Events are validated against their schema when
+// published, and the schemas are exported as JSON Schema for consumers. Types are registered at start-up;
+// registering is not safe concurrently with lookups.
+type EventRegistry struct {
+	schemas map[string]*EventSchema
+}
+
+// NewEventRegistry creates an empty event registry.
+func NewEventRegistry() *EventRegistry {
+	return &EventRegistry{schemas: make(map[string]*EventSchema)}
+}
+
+// defaultEventRegistry holds the schemas of the events published by the domain.
+var defaultEventRegistry = newDefaultEventRegistry()
+
+// DefaultEventRegistry returns the registry of the events published by the domain.
+func DefaultEventRegistry() *EventRegistry {
+	return defaultEventRegistry
+}
+
+// newDefaultEventRegistry registers the built-in event schemas, panicking on an invalid one.
+func newDefaultEventRegistry() *EventRegistry {
+	registry := NewEventRegistry()
+	for _, schema := range builtinEventSchemas() {
+		if err := registry.Register(schema); err != nil {
+			panic(err)
+		}
+	}
+	return registry
+}
+
+// Register adds an event schema. Each event type is registered once, with a struct payload.
+func (r *EventRegistry) Register(schema EventSchema) error {
+	if schema.Type == "" || schema.AggregateType == "" {
+		return errors.New("event type and aggregate type are required")
+	}
+	if schema.Version < 1 {
+		return fmt.Errorf("event %s: version must be positive", schema.Type)
+	}
+	if _, exists := r.schemas[schema.Type]; exists {
+		return fmt.Errorf("event %s is already registered", schema.Type)
+	}
+
+	payload := reflect.TypeOf(schema.Payload)
+	if payload == nil || payload.Kind() != reflect.Struct {
+		return fmt.Errorf("event %s: payload must be a struct", schema.Type)
+	}
+	schema.root = newSchemaNode(payload)
+	r.schemas[schema.Type] = &schema
+	return nil
+}
+
+// Schema returns the schema of an event type.
+func (r *EventRegistry) Schema(eventType string) (EventSchema, bool) {
+	schema, ok := r.schemas[eventType]
+	if !ok {
+		return EventSchema{}, false
+	}
+	return *schema, true
+}
+
+// Schemas returns all registered schemas ordered by event type.
+func (r *EventRegistry) Schemas() []EventSchema {
+	schemas := make([]EventSchema, 0, len(r.schemas))
+	for _, schema := range r.schemas {
+		schemas = append(schemas, *schema)
+	}
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].Type < schemas[j].Type })
+	return schemas
+}
+
+// Validate checks that an event is of a registered type, comes from the aggregate type and schema version
+// registered for it, and that its payload has exactly the fields of the schema, with their types.
+// Payloads may be the typed struct or a map, as read back from the event store.
+func (r *EventRegistry) Validate(event *BaseDomainEvent) error {
+	if event == nil {
+		return fmt.Errorf("%w: event is nil", ErrInvalidEvent)
+	}
+	schema, ok := r.schemas[event.EventType]
+	if !ok {
+		return fmt.Errorf("%w: unknown event type %q", ErrInvalidEvent, event.EventType)
+	}
+	if event.AggregateType != schema.AggregateType {
+		return fmt.Errorf("%w: %s: aggregate type %q, want %q",
+			ErrInvalidEvent, event.EventType, event.AggregateType, schema.AggregateType)
+	}
+	if event.EventVersion != schema.Version {
+		return fmt.Errorf("%w: %s: version %d, want %d",
+			ErrInvalidEvent, event.EventType, event.EventVersion, schema.Version)
+	}
+	if event.AggregateID == "" {
+		return fmt.Errorf("%w: %s: aggregate ID is required", ErrInvalidEvent, event.EventType)
+	}
+
+	data, err := decodeJSONValue(event.EventData)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidEvent, event.EventType, err)
+	}
+	if err := schema.root.validate("event_data", data); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidEvent, event.EventType, err)
+	}
+	return nil
+}
+
+// JSONSchema returns the JSON Schema of the events of a type as published: the event envelope with the
+// typed payload in event_data.
+func (r *EventRegistry) JSONSchema(eventType string) (map[string]interface{}, error) {
+	schema, ok := r.schemas[eventType]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown event type %q", ErrInvalidEvent, eventType)
+	}
+
+	return map[string]interface{}{
+		"$schema":     JSONSchemaDialect,
+		"title":       schema.Type,
+		"description": schema.Description,
+		"type":        "object",
+		"properties": map[string]interface{}{
+			"event_type":     map[string]interface{}{"const": schema.Type},
+			"aggregate_id":   map[string]interface{}{"type": "string"},
+			"aggregate_type": map[string]interface{}{"const": schema.AggregateType},
+			"event_version":  map[string]interface{}{"const": schema.Version},
+			"occurred_at":    map[string]interface{}{"type": "string", "format": "date-time"},
+			"event_data":     schema.root.jsonSchema(),
+			"metadata":       map[string]interface{}{"type": "object"},
+		},
+		"required": []string{
+			"event_type", "aggregate_id", "aggregate_type", "event_version", "occurred_at", "event_data", "metadata",
+		},
+	}, nil
+}
+
+// DecodeEventData decodes the payload of an event into target, a pointer to a payload struct. Events made by
+// the typed constructors hold their payload struct, while events read back from the event store, Kafka or
+// dead letters hold a map; both decode, as does a payload into a struct with a subset of its fields.
+func DecodeEventData(event *BaseDomainEvent, target interface{}) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return errors.New("target must be a non-nil pointer")
+	}
+	if event == nil || event.EventData == nil {
+		return fmt.Errorf("%w: event has no data", ErrInvalidEvent)
+	}
+
+	data := reflect.ValueOf(event.EventData)
+	if data.Kind() == reflect.Ptr && !data.IsNil() {
+		data = data.Elem()
+	}
+	if data.Type() == value.Elem().Type() {
+		value.Elem().Set(data)
+		return nil
+	}
+
+	encoded, err := json.Marshal(event.EventData)
+	if err != nil {
+		return fmt.Errorf("failed to encode event data: %w", err)
+	}
+	if err := json.Unmarshal(encoded, target); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidEvent, event.EventType, err)
+	}
+	return nil
+}
+
+// schemaNode is the schema of a JSON value derived from a Go type.
+type schemaNode struct {
+	kind       string // JSON type; empty for any value
+	format     string
+	nullable   bool
+	properties map[string]*schemaNode
+	required   []string
+	items      *schemaNode // Array items, or the values of an object without properties
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// newSchemaNode derives the schema of the JSON encoding of a Go type.
+func newSchemaNode(t reflect.Type) *schemaNode {
+	if t.Kind() == reflect.Ptr {
+		node := newSchemaNode(t.Elem())
+		node.nullable = true
+		return node
+	}
+	if t == timeType {
+		return &schemaNode{kind: "string", format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &schemaNode{kind: "string"}
+	case reflect.Bool:
+		return &schemaNode{kind: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &schemaNode{kind: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &schemaNode{kind: "number"}
+	case reflect.Slice, reflect.Array:
+		return &schemaNode{kind: "array", nullable: t.Kind() == reflect.Slice, items: newSchemaNode(t.Elem())}
+	case reflect.Map:
+		return &schemaNode{kind: "object", nullable: true, items: newSchemaNode(t.Elem())}
+	case reflect.Struct:
+		node := &schemaNode{kind: "object", properties: make(map[string]*schemaNode)}
+		addStructFields(node, t)
+		sort.Strings(node.required)
+		return node
+	default:
+		return &schemaNode{}
+	}
+}
+
+// addStructFields adds the JSON fields of a struct to an object schema, flattening embedded structs as
+// encoding/json does.
+func addStructFields(node *schemaNode, t reflect.Type) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			addStructFields(node, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		node.properties[name] = newSchemaNode(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			node.required = append(node.required, name)
+		}
+	}
+}
+
+// jsonSchema renders the node as JSON Schema.
+func (n *schemaNode) jsonSchema() map[string]interface{} {
+	schema := map[string]interface{}{}
+	if n.kind != "" {
+		if n.nullable {
+			schema["type"] = []string{n.kind, "null"}
+		} else {
+			schema["type"] = n.kind
+		}
+	}
+	if n.format != "" {
+		schema["format"] = n.format
+	}
+	if n.properties != nil {
+		properties := make(map[string]interface{}, len(n.properties))
+		for name, property := range n.properties {
+			properties[name] = property.jsonSchema()
+		}
+		schema["properties"] = properties
+		schema["required"] = n.required
+		schema["additionalProperties"] = false
+	}
+	if n.items != nil {
+		if n.kind == "array" {
+			schema["items"] = n.items.jsonSchema()
+		} else {
+			schema["additionalProperties"] = n.items.jsonSchema()
+		}
+	}
+	return schema
+}
+
+// validate checks a decoded JSON value against the node; path names the value in errors.
+func (n *schemaNode) validate(path string, value interface{}) error {
+	if value == nil {
+		if n.nullable || n.kind == "" {
+			return nil
+		}
+		return fmt.Errorf("%s must not be null", path)
+	}
+
+	switch n.kind {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string", path)
+		}
+		if n.format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, s); err != nil {
+				return fmt.Errorf("%s must be a date-time", path)
+			}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s must be a boolean", path)
+		}
+	case "integer":
+		number, ok := value.(json.Number)
+		if _, err := number.Int64(); !ok || err != nil {
+			return fmt.Errorf("%s must be an integer", path)
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			return fmt.Errorf("%s must be a number", path)
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an array", path)
+		}
+		for i, item := range items {
+			if err := n.items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+				return err
+			}
+		}
+	case "object":
+		return n.validateObject(path, value)
+	}
+	return nil
+}
+
+// validateObject checks a decoded JSON object against an object node, rejecting unknown fields.
+func (n *schemaNode) validateObject(path string, value interface{}) error {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s must be an object", path)
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property := n.items
+		if n.properties != nil {
+			property = n.properties[name]
+		}
+		if property == nil {
+			return fmt.Errorf("%s has unknown field %q", path, name)
+		}
+		if err := property.validate(path+"."+name, object[name]); err != nil {
+			return err
+		}
+	}
+	for _, name := range n.required {
+		if _, ok := object[name]; !ok {
+			return fmt.Errorf("%s is missing field %q", path, name)
+		}
+	}
+	return nil
+}
+
+// decodeJSONValue converts a value to its generic JSON form, keeping numbers exact.
+func decodeJSONValue(value interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event data: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode event data: %w", err)
+	}
+	return decoded, nil
+}
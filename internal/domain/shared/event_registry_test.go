@@ -0,0 +1,225 @@
+package shared_test
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func invoiceCreated() *shared.BaseDomainEvent {
+	return shared.NewInvoiceCreatedEvent(shared.InvoiceEvent{
+		InvoiceID:    "inv_1",
+		MerchantID:   "merchant-1",
+		TotalAmount:  "99.99",
+		CryptoAmount: "99.99",
+		Currency:     "USDT",
+		Status:       "pending",
+		ExpiresAt:    time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC),
+		Description:  "Order #42",
+		Timestamp:    time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC),
+	})
+}
+
+// roundTrip returns an event as read back from the event store or Kafka, with a map payload.
+func roundTrip(t *testing.T, event *shared.BaseDomainEvent) *shared.BaseDomainEvent {
+	t.Helper()
+	encoded, err := json.Marshal(event)
+	require.NoError(t, err)
+	var decoded shared.BaseDomainEvent
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	return &decoded
+}
+
+func TestEventRegistry_Validate(t *testing.T) {
+	registry := shared.DefaultEventRegistry()
+
+	t.Run("Constructors build valid events", func(t *testing.T) {
+		event := invoiceCreated()
+		require.Equal(t, shared.EventTypeInvoiceCreated, event.EventType)
+		require.Equal(t, "inv_1", event.AggregateID)
+		require.Equal(t, shared.AggregateTypeInvoice, event.AggregateType)
+		require.Equal(t, 1, event.EventVersion)
+		require.NoError(t, registry.Validate(event))
+		require.NoError(t, registry.Validate(roundTrip(t, event)))
+	})
+
+	t.Run("Every registered schema accepts its payload", func(t *testing.T) {
+		for _, schema := range registry.Schemas() {
+			event := shared.CreateDomainEvent(schema.Type, "aggregate-1", schema.AggregateType, schema.Payload, nil)
+			event.EventVersion = schema.Version
+			require.NoError(t, registry.Validate(event), schema.Type)
+			require.NoError(t, registry.Validate(roundTrip(t, event)), schema.Type)
+		}
+	})
+
+	t.Run("Embedded payloads and optional fields", func(t *testing.T) {
+		viewedAt := time.Now().UTC()
+		event := shared.NewInvoiceStatusChangedEvent(shared.InvoiceStatusChangedEvent{
+			InvoiceEvent: shared.InvoiceEvent{InvoiceID: "inv_1", Status: "pending"},
+			FromStatus:   "created",
+			ToStatus:     "pending",
+			ViewedAt:     &viewedAt,
+		})
+		require.NoError(t, registry.Validate(roundTrip(t, event)))
+	})
+
+	t.Run("Rejects invalid events", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			mutate func(event *shared.BaseDomainEvent, data map[string]interface{})
+			err    string
+		}{
+			{
+				name: "misspelled field",
+				mutate: func(_ *shared.BaseDomainEvent, data map[string]interface{}) {
+					data["merchnat_id"] = data["merchant_id"]
+					delete(data, "merchant_id")
+				},
+				err: `unknown field "merchnat_id"`,
+			},
+			{
+				name:   "missing field",
+				mutate: func(_ *shared.BaseDomainEvent, data map[string]interface{}) { delete(data, "status") },
+				err:    `missing field "status"`,
+			},
+			{
+				name:   "wrong type",
+				mutate: func(_ *shared.BaseDomainEvent, data map[string]interface{}) { data["total_amount"] = 99.99 },
+				err:    "event_data.total_amount must be a string",
+			},
+			{
+				name:   "malformed date-time",
+				mutate: func(_ *shared.BaseDomainEvent, data map[string]interface{}) { data["expires_at"] = "tomorrow" },
+				err:    "event_data.expires_at must be a date-time",
+			},
+			{
+				name:   "unknown event type",
+				mutate: func(event *shared.BaseDomainEvent, _ map[string]interface{}) { event.EventType = "invoice.craeted" },
+				err:    `unknown event type "invoice.craeted"`,
+			},
+			{
+				name:   "wrong aggregate type",
+				mutate: func(event *shared.BaseDomainEvent, _ map[string]interface{}) { event.AggregateType = "Payment" },
+				err:    `aggregate type "Payment", want "Invoice"`,
+			},
+			{
+				name:   "wrong version",
+				mutate: func(event *shared.BaseDomainEvent, _ map[string]interface{}) { event.EventVersion = 2 },
+				err:    "version 2, want 1",
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				event := roundTrip(t, invoiceCreated())
+				tt.mutate(event, event.EventData.(map[string]interface{}))
+
+				err := registry.Validate(event)
+				require.ErrorIs(t, err, shared.ErrInvalidEvent)
+				require.Contains(t, err.Error(), tt.err)
+			})
+		}
+	})
+}
+
+func TestEventRegistry_Register(t *testing.T) {
+	registry := shared.NewEventRegistry()
+	schema := shared.EventSchema{
+		Type: "order.shipped", Version: 1, AggregateType: "Order", Payload: struct {
+			OrderID string `json:"order_id"`
+		}{},
+	}
+
+	require.NoError(t, registry.Register(schema))
+	require.Error(t, registry.Register(schema))
+
+	invalid := schema
+	invalid.Type = "order.returned"
+	invalid.Version = 0
+	require.Error(t, registry.Register(invalid))
+
+	invalid.Version = 1
+	invalid.Payload = map[string]interface{}{}
+	require.Error(t, registry.Register(invalid))
+
+	found, ok := registry.Schema("order.shipped")
+	require.True(t, ok)
+	require.Equal(t, "Order", found.AggregateType)
+	_, ok = registry.Schema("order.returned")
+	require.False(t, ok)
+}
+
+func TestEventRegistry_JSONSchema(t *testing.T) {
+	registry := shared.DefaultEventRegistry()
+
+	schema, err := registry.JSONSchema(shared.EventTypeSettlementReversed)
+	require.NoError(t, err)
+	require.Equal(t, shared.JSONSchemaDialect, schema["$schema"])
+	require.Equal(t, shared.EventTypeSettlementReversed, schema["title"])
+
+	// The schema is plain JSON that consumers can load
+	encoded, err := json.Marshal(schema)
+	require.NoError(t, err)
+	var decoded struct {
+		Properties struct {
+			EventType struct {
+				Const string `json:"const"`
+			} `json:"event_type"`
+			EventData struct {
+				Required   []string `json:"required"`
+				Properties map[string]struct {
+					Type     interface{} `json:"type"`
+					Format   string      `json:"format"`
+					Required []string    `json:"required"`
+				} `json:"properties"`
+				AdditionalProperties bool `json:"additionalProperties"`
+			} `json:"event_data"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, shared.EventTypeSettlementReversed, decoded.Properties.EventType.Const)
+
+	data := decoded.Properties.EventData
+	require.False(t, data.AdditionalProperties)
+	require.Contains(t, data.Required, "settlement_id")
+	require.Contains(t, data.Required, "reversal")
+	require.NotContains(t, data.Required, "settled_at")
+	require.Equal(t, "string", data.Properties["settlement_id"].Type)
+	require.Equal(t, []interface{}{"string", "null"}, data.Properties["settled_at"].Type)
+	require.Equal(t, "date-time", data.Properties["settled_at"].Format)
+	require.Contains(t, data.Properties["reversal"].Required, "paid_out")
+
+	_, err = registry.JSONSchema("settlement.unknown")
+	require.ErrorIs(t, err, shared.ErrInvalidEvent)
+}
+
+func TestDecodeEventData(t *testing.T) {
+	t.Run("Typed payload", func(t *testing.T) {
+		var data shared.InvoiceEvent
+		require.NoError(t, shared.DecodeEventData(invoiceCreated(), &data))
+		require.Equal(t, "inv_1", data.InvoiceID)
+		require.Equal(t, "99.99", data.TotalAmount)
+	})
+
+	t.Run("Map payload into a wider struct", func(t *testing.T) {
+		var data shared.InvoiceStatusChangedEvent
+		require.NoError(t, shared.DecodeEventData(roundTrip(t, invoiceCreated()), &data))
+		require.Equal(t, "pending", data.Status)
+		require.Equal(t, time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC), data.ExpiresAt)
+		require.Empty(t, data.Resolution)
+	})
+
+	t.Run("Mismatched payload", func(t *testing.T) {
+		event := shared.CreateDomainEvent(shared.EventTypeInvoiceCreated, "inv_1", "Invoice",
+			map[string]interface{}{"status": 42}, nil)
+		var data shared.InvoiceEvent
+		require.ErrorIs(t, shared.DecodeEventData(event, &data), shared.ErrInvalidEvent)
+	})
+
+	t.Run("Invalid target", func(t *testing.T) {
+		var data shared.InvoiceEvent
+		require.Error(t, shared.DecodeEventData(invoiceCreated(), data))
+	})
+}
@@ -8,12 +8,51 @@ const (
 	EventTypeInvoicePaid          = "invoice.paid"
 	EventTypeInvoiceExpired       = "invoice.expired"
 	EventTypeInvoiceCancelled     = "invoice.cancelled"
+	EventTypeInvoiceRefunded      = "invoice.refunded"
+	EventTypeInvoiceCouponApplied = "invoice.coupon_applied"
+	EventTypeInvoiceRequoted      = "invoice.requoted"
+	EventTypeInvoiceExtended      = "invoice.extended"
+	EventTypeInvoiceReversal      = "invoice.payment_reversed"
 
 	// Payment events
-	EventTypePaymentDetected      = "payment.detected"
-	EventTypePaymentStatusChanged = "payment.status_changed"
-	EventTypePaymentConfirmed     = "payment.confirmed"
-	EventTypePaymentFailed        = "payment.failed"
+	EventTypePaymentDetected        = "payment.detected"
+	EventTypePaymentStatusChanged   = "payment.status_changed"
+	EventTypePaymentConfirmed       = "payment.confirmed"
+	EventTypePaymentFailed          = "payment.failed"
+	EventTypePaymentRefundRequested = "payment.refund_requested"
+	EventTypePaymentConfirmation    = "payment.confirmation"
+
+	// Deposit events
+	EventTypeDepositUnattributed    = "deposit.unattributed"
+	EventTypeDepositAttached        = "deposit.attached"
+	EventTypeDepositRefundRequested = "deposit.refund_requested"
+
+	// Settlement events
+	EventTypeSettlementCreated   = "settlement.created"
+	EventTypeSettlementCompleted = "settlement.completed"
+	EventTypeSettlementFailed    = "settlement.failed"
+	EventTypeSettlementReversed  = "settlement.reversed"
+
+	// Payout events
+	EventTypePayoutBroadcast = "payout.broadcast"
+	EventTypePayoutConfirmed = "payout.confirmed"
+	EventTypePayoutFailed    = "payout.failed"
+
+	// Treasury events
+	EventTypeTreasuryBalanceLow    = "treasury.balance_low"
+	EventTypeTreasuryBalanceHigh   = "treasury.balance_high"
+	EventTypeTreasurySweepFailed   = "treasury.sweep_failed"
+	EventTypeColdTransferRequested = "treasury.cold_transfer_requested"
+	EventTypeColdTransferApproved  = "treasury.cold_transfer_approved"
+	EventTypeColdTransferConfirmed = "treasury.cold_transfer_confirmed"
+	EventTypeColdTransferFailed    = "treasury.cold_transfer_failed"
+	EventTypeReconciliationAlert   = "treasury.reconciliation_alert"
+
+	// Statement events
+	EventTypeStatementGenerated = "statement.generated"
+
+	// Service level objective events
+	EventTypeSLOBudgetAtRisk = "slo.budget_at_risk"
 
 	// Integration events
 	EventTypeWebhookDelivery = "webhook.delivery"
@@ -47,9 +86,17 @@ const (
 func GetEventCategory(eventType string) string {
 	switch eventType {
 	case EventTypeInvoiceCreated, EventTypeInvoiceStatusChanged, EventTypeInvoicePaid,
-		EventTypeInvoiceExpired, EventTypeInvoiceCancelled,
+		EventTypeInvoiceExpired, EventTypeInvoiceCancelled, EventTypeInvoiceRefunded, EventTypeInvoiceCouponApplied,
+		EventTypeInvoiceRequoted, EventTypeInvoiceExtended, EventTypeInvoiceReversal,
 		EventTypePaymentDetected, EventTypePaymentStatusChanged, EventTypePaymentConfirmed,
-		EventTypePaymentFailed:
+		EventTypePaymentFailed, EventTypePaymentRefundRequested, EventTypePaymentConfirmation,
+		EventTypeDepositUnattributed, EventTypeDepositAttached, EventTypeDepositRefundRequested,
+		EventTypeSettlementCreated, EventTypeSettlementCompleted, EventTypeSettlementFailed,
+		EventTypeSettlementReversed,
+		EventTypePayoutBroadcast, EventTypePayoutConfirmed, EventTypePayoutFailed,
+		EventTypeTreasuryBalanceLow, EventTypeTreasuryBalanceHigh, EventTypeTreasurySweepFailed,
+		EventTypeColdTransferRequested, EventTypeColdTransferApproved, EventTypeColdTransferConfirmed,
+		EventTypeColdTransferFailed, EventTypeReconciliationAlert, EventTypeStatementGenerated:
 		return EventCategoryDomain
 	case EventTypeWebhookDelivery, EventTypeWebhookRetry, EventTypeWebhookFailed:
 		return EventCategoryIntegration
@@ -57,7 +104,7 @@ func GetEventCategory(eventType string) string {
 		return EventCategoryNotification
 	case EventTypeAnalyticsUpdated, EventTypeAnalyticsReport:
 		return EventCategoryAnalytics
-	case EventTypeSystemError, EventTypeSystemWarning, EventTypeSystemInfo:
+	case EventTypeSystemError, EventTypeSystemWarning, EventTypeSystemInfo, EventTypeSLOBudgetAtRisk:
 		return EventCategorySystem
 	default:
 		return EventCategoryDomain // Default to domain events
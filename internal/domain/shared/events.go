@@ -22,7 +22,8 @@ func (e BaseDomainEvent) ToJSON() ([]byte, error) {
 	return json.Marshal(e)
 }
 
-// CreateDomainEvent creates a new domain event using the factory pattern.
+// CreateDomainEvent creates a new domain event using the factory pattern. Events published on the event bus
+// are built with the typed constructors instead, e.g. NewInvoiceCreatedEvent, so they match their schema.
 func CreateDomainEvent(
 	eventType, aggregateID, aggregateType string,
 	eventData interface{},
@@ -37,7 +38,7 @@ func CreateDomainEvent(
 		AggregateID:   aggregateID,
 		AggregateType: aggregateType,
 		EventVersion:  1,
-		OccurredAt:    time.Now().UTC(),
+		OccurredAt:    Now().UTC(),
 		EventData:     eventData,
 		Metadata:      metadata,
 	}
@@ -82,3 +83,9 @@ type EventHandlerRegistry interface {
 	GetHandlers(eventType string) []EventHandler
 	GetAllHandlers() map[string][]EventHandler
 }
+
+// DeadLetterRecorder persists events an in-process handler failed on, so they can be replayed
+// once the cause of the failure is fixed.
+type DeadLetterRecorder interface {
+	RecordFailure(ctx context.Context, handler EventHandler, event *BaseDomainEvent, cause error) error
+}
@@ -51,7 +51,7 @@ func NewExchangeRate(
 		return nil, errors.New("exchange rate must be positive")
 	}
 
-	now := time.Now().UTC()
+	now := Now().UTC()
 	expiresAt := now.Add(validDuration)
 
 	return &ExchangeRate{
@@ -64,6 +64,23 @@ func NewExchangeRate(
 	}, nil
 }
 
+// RestoreExchangeRate recreates an exchange rate locked at lockedAt until expiresAt (for repository restoration).
+func RestoreExchangeRate(
+	rate string,
+	fromCurrency Currency,
+	toCurrency CryptoCurrency,
+	source string,
+	lockedAt, expiresAt time.Time,
+) (*ExchangeRate, error) {
+	exchangeRate, err := NewExchangeRate(rate, fromCurrency, toCurrency, source, expiresAt.Sub(lockedAt))
+	if err != nil {
+		return nil, err
+	}
+	exchangeRate.lockedAt = lockedAt
+	exchangeRate.expiresAt = expiresAt
+	return exchangeRate, nil
+}
+
 // Rate returns the exchange rate.
 func (er *ExchangeRate) Rate() decimal.Decimal {
 	return er.rate
@@ -96,7 +113,7 @@ func (er *ExchangeRate) ExpiresAt() time.Time {
 
 // IsExpired returns true if the exchange rate has expired.
 func (er *ExchangeRate) IsExpired() bool {
-	return time.Now().UTC().After(er.expiresAt)
+	return Now().UTC().After(er.expiresAt)
 }
 
 // Convert converts an amount using this exchange rate.
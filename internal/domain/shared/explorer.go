@@ -0,0 +1,126 @@
+package shared
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ChainMainnet is the chain of a network that holds real funds, as opposed to its testnets.
+const ChainMainnet = "mainnet"
+
+// publicExplorers are the base URLs of the public block explorers of each network, by chain.
+var publicExplorers = map[BlockchainNetwork]map[string]string{
+	NetworkTron: {
+		ChainMainnet: "https://tronscan.org/#",
+		"shasta":     "https://shasta.tronscan.org/#",
+		"nile":       "https://nile.tronscan.org/#",
+	},
+	NetworkEthereum: {
+		ChainMainnet: "https://etherscan.io",
+		"sepolia":    "https://sepolia.etherscan.io",
+		"holesky":    "https://holesky.etherscan.io",
+	},
+	NetworkBitcoin: {
+		ChainMainnet: "https://mempool.space",
+		"testnet":    "https://mempool.space/testnet",
+		"testnet4":   "https://mempool.space/testnet4",
+		"signet":     "https://mempool.space/signet",
+	},
+	NetworkBSC: {
+		ChainMainnet: "https://bscscan.com",
+		"testnet":    "https://testnet.bscscan.com",
+	},
+}
+
+// Explorer is the block explorer of a network, under whose URL transactions, addresses and blocks are found.
+type Explorer struct {
+	Network BlockchainNetwork
+	URL     string
+}
+
+// PublicExplorer returns the public block explorer of a chain of the network. An empty chain is the mainnet.
+func PublicExplorer(network BlockchainNetwork, chain string) (Explorer, error) {
+	if chain == "" {
+		chain = ChainMainnet
+	}
+	chains, ok := publicExplorers[network]
+	if !ok {
+		return Explorer{}, fmt.Errorf("%w: no block explorer for network %q", ErrInvalidNetwork, network)
+	}
+	explorerURL, ok := chains[chain]
+	if !ok {
+		return Explorer{}, fmt.Errorf("%w: unknown %s chain %q", ErrInvalidInput, network, chain)
+	}
+	return Explorer{Network: network, URL: explorerURL}, nil
+}
+
+// ExplorerResolver links transactions, addresses and blocks to the block explorer of their network. Networks
+// without an explorer link to nothing, and so does a nil resolver.
+type ExplorerResolver struct {
+	explorers map[BlockchainNetwork]string
+}
+
+// NewExplorerResolver creates a resolver linking to the given explorers. Networks not listed link to their
+// public explorer.
+func NewExplorerResolver(explorers []Explorer) (*ExplorerResolver, error) {
+	resolver := DefaultExplorerResolver()
+	for _, explorer := range explorers {
+		if !explorer.Network.IsValid() {
+			return nil, fmt.Errorf("%w: block explorer for unknown network %q", ErrInvalidNetwork, explorer.Network)
+		}
+		parsed, err := url.Parse(explorer.URL)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return nil, fmt.Errorf("%w: invalid %s block explorer URL %q", ErrInvalidInput, explorer.Network, explorer.URL)
+		}
+		resolver.explorers[explorer.Network] = strings.TrimSuffix(explorer.URL, "/")
+	}
+	return resolver, nil
+}
+
+// DefaultExplorerResolver returns a resolver linking every network to its public explorer.
+func DefaultExplorerResolver() *ExplorerResolver {
+	explorers := make(map[BlockchainNetwork]string, len(publicExplorers)+len(testnets))
+	for network, chains := range publicExplorers {
+		explorers[network] = chains[ChainMainnet]
+	}
+	for network, t := range testnets {
+		explorers[network] = publicExplorers[t.mainnet][t.chain]
+	}
+	return &ExplorerResolver{explorers: explorers}
+}
+
+// TransactionURL returns the page of a transaction, or an empty string when it cannot be linked.
+func (r *ExplorerResolver) TransactionURL(network BlockchainNetwork, hash string) string {
+	path := "/tx/"
+	if network.Mainnet() == NetworkTron {
+		path = "/transaction/"
+	}
+	return r.link(network, path, hash)
+}
+
+// AddressURL returns the page of an address, or an empty string when it cannot be linked.
+func (r *ExplorerResolver) AddressURL(network BlockchainNetwork, address string) string {
+	return r.link(network, "/address/", address)
+}
+
+// BlockURL returns the page of a block, or an empty string when it cannot be linked.
+func (r *ExplorerResolver) BlockURL(network BlockchainNetwork, number int64) string {
+	if number <= 0 {
+		return ""
+	}
+	return r.link(network, "/block/", strconv.FormatInt(number, 10))
+}
+
+// link joins a path and an identifier to the URL of the network's explorer.
+func (r *ExplorerResolver) link(network BlockchainNetwork, path, id string) string {
+	if r == nil || id == "" {
+		return ""
+	}
+	base, ok := r.explorers[network]
+	if !ok {
+		return ""
+	}
+	return base + path + url.PathEscape(id)
+}
@@ -0,0 +1,71 @@
+package shared_test
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplorerResolver(t *testing.T) {
+	t.Run("Default_Links_Mainnets", func(t *testing.T) {
+		resolver := shared.DefaultExplorerResolver()
+		require.Equal(t, "https://etherscan.io/tx/0xabc", resolver.TransactionURL(shared.NetworkEthereum, "0xabc"))
+		require.Equal(t, "https://tronscan.org/#/transaction/abc", resolver.TransactionURL(shared.NetworkTron, "abc"))
+		require.Equal(t, "https://bscscan.com/address/0xdef", resolver.AddressURL(shared.NetworkBSC, "0xdef"))
+		require.Equal(t, "https://mempool.space/block/840000", resolver.BlockURL(shared.NetworkBitcoin, 840000))
+	})
+
+	t.Run("Nothing_To_Link", func(t *testing.T) {
+		resolver := shared.DefaultExplorerResolver()
+		require.Empty(t, resolver.TransactionURL(shared.NetworkEthereum, ""))
+		require.Empty(t, resolver.BlockURL(shared.NetworkEthereum, 0))
+		require.Empty(t, resolver.AddressURL(shared.BlockchainNetwork("invalid"), "0xdef"))
+
+		var unset *shared.ExplorerResolver
+		require.Empty(t, unset.TransactionURL(shared.NetworkEthereum, "0xabc"))
+	})
+
+	t.Run("Configured_Explorers", func(t *testing.T) {
+		nile, err := shared.PublicExplorer(shared.NetworkTron, "nile")
+		require.NoError(t, err)
+		resolver, err := shared.NewExplorerResolver([]shared.Explorer{
+			nile,
+			{Network: shared.NetworkBitcoin, URL: "https://explorer.example.com/btc/"},
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, "https://nile.tronscan.org/#/transaction/abc", resolver.TransactionURL(shared.NetworkTron, "abc"))
+		require.Equal(t, "https://explorer.example.com/btc/tx/abc", resolver.TransactionURL(shared.NetworkBitcoin, "abc"))
+		require.Equal(t, "https://etherscan.io/tx/0xabc", resolver.TransactionURL(shared.NetworkEthereum, "0xabc"))
+	})
+
+	t.Run("Testnets", func(t *testing.T) {
+		for network, chain := range map[shared.BlockchainNetwork]string{
+			shared.NetworkTron:     "shasta",
+			shared.NetworkEthereum: "sepolia",
+			shared.NetworkBitcoin:  "testnet4",
+			shared.NetworkBSC:      "testnet",
+		} {
+			explorer, err := shared.PublicExplorer(network, chain)
+			require.NoError(t, err)
+			require.Contains(t, explorer.URL, chain)
+		}
+
+		mainnet, err := shared.PublicExplorer(shared.NetworkBitcoin, "")
+		require.NoError(t, err)
+		require.Equal(t, "https://mempool.space", mainnet.URL)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := shared.PublicExplorer(shared.NetworkEthereum, "ropsten")
+		require.ErrorIs(t, err, shared.ErrInvalidInput)
+		_, err = shared.PublicExplorer(shared.BlockchainNetwork("invalid"), "")
+		require.ErrorIs(t, err, shared.ErrInvalidNetwork)
+
+		_, err = shared.NewExplorerResolver([]shared.Explorer{{Network: shared.NetworkTron, URL: "tronscan.org"}})
+		require.ErrorIs(t, err, shared.ErrInvalidInput)
+		_, err = shared.NewExplorerResolver([]shared.Explorer{{Network: "invalid", URL: "https://example.com"}})
+		require.ErrorIs(t, err, shared.ErrInvalidNetwork)
+	})
+}
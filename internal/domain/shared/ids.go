@@ -0,0 +1,240 @@
+package shared
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IDKind names the kind of record an ID is generated for.
+type IDKind string
+
+// Kinds of records with generated IDs.
+const (
+	IDKindInvoice    IDKind = "invoice"
+	IDKindPayment    IDKind = "payment"
+	IDKindSettlement IDKind = "settlement"
+	IDKindRefund     IDKind = "refund"
+)
+
+// ID generation strategies, selected with ids.strategy.
+const (
+	// IDStrategyRandom - inv_ and ref_ followed by random hex for invoices and refunds, random UUIDs otherwise
+	IDStrategyRandom = "random"
+	// IDStrategyULID - Lexicographically sortable ULIDs
+	IDStrategyULID = "ulid"
+	// IDStrategyUUIDv7 - Time-ordered UUIDs
+	IDStrategyUUIDv7 = "uuidv7"
+	// IDStrategySequential - Human-friendly IDs such as 550E8400-INV-2024-000123 numbered per merchant and year
+	IDStrategySequential = "sequential"
+)
+
+// ErrUnknownIDStrategy is returned for an ID strategy that does not exist.
+var ErrUnknownIDStrategy = errors.New("unknown ID strategy")
+
+// IDGenerator generates the IDs of invoices, payments, settlements and refunds. The merchant is the one
+// the record belongs to; strategies that do not number records per merchant ignore it.
+type IDGenerator interface {
+	NewID(ctx context.Context, kind IDKind, merchantID string) (string, error)
+}
+
+// SequenceStore hands out the sequence numbers of the sequential strategy.
+type SequenceStore interface {
+	// NextSequence returns the next number, starting at 1, of the records of a kind in a year under an ID prefix.
+	NextSequence(ctx context.Context, prefix string, kind IDKind, year int) (int64, error)
+}
+
+// NewIDGenerator creates the generator of a strategy. The sequence store is only used by the sequential strategy.
+func NewIDGenerator(strategy string, sequences SequenceStore) (IDGenerator, error) {
+	switch strategy {
+	case "", IDStrategyRandom:
+		return RandomIDGenerator{}, nil
+	case IDStrategyULID:
+		return NewULIDGenerator(), nil
+	case IDStrategyUUIDv7:
+		return UUIDv7Generator{}, nil
+	case IDStrategySequential:
+		if sequences == nil {
+			return nil, errors.New("sequential IDs need a sequence store")
+		}
+		return NewSequentialIDGenerator(sequences), nil
+	default:
+		return nil, fmt.Errorf("%w %q: use %s, %s, %s or %s", ErrUnknownIDStrategy, strategy,
+			IDStrategyRandom, IDStrategyULID, IDStrategyUUIDv7, IDStrategySequential)
+	}
+}
+
+// RandomIDGenerator generates random IDs: inv_ or ref_ followed by 16 hex digits for invoices and refunds,
+// and random UUIDs for payments and settlements.
+type RandomIDGenerator struct{}
+
+// NewID returns a random ID.
+func (RandomIDGenerator) NewID(_ context.Context, kind IDKind, _ string) (string, error) {
+	var prefix string
+	switch kind {
+	case IDKindInvoice:
+		prefix = "inv_"
+	case IDKindRefund:
+		prefix = "ref_"
+	default:
+		return uuid.NewString(), nil
+	}
+
+	bytes := make([]byte, 8)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate %s ID: %w", kind, err)
+	}
+	return prefix + hex.EncodeToString(bytes), nil
+}
+
+// UUIDv7Generator generates time-ordered UUIDs (version 7).
+type UUIDv7Generator struct{}
+
+// NewID returns a version 7 UUID.
+func (UUIDv7Generator) NewID(_ context.Context, kind IDKind, _ string) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate %s ID: %w", kind, err)
+	}
+	return id.String(), nil
+}
+
+// crockford is the Crockford base32 alphabet ULIDs are encoded in.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULIDGenerator generates ULIDs: a 48-bit millisecond timestamp followed by 80 random bits, encoded in
+// 26 Crockford base32 characters. IDs generated within the same millisecond increment the random part, so
+// they still sort in generation order.
+type ULIDGenerator struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	lastMS  uint64
+	entropy [10]byte
+}
+
+// NewULIDGenerator creates a ULID generator.
+func NewULIDGenerator() *ULIDGenerator {
+	return &ULIDGenerator{now: time.Now}
+}
+
+// NewID returns a ULID.
+func (g *ULIDGenerator) NewID(_ context.Context, kind IDKind, _ string) (string, error) {
+	ms := uint64(g.now().UnixMilli())
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if ms <= g.lastMS {
+		// Same millisecond, or the clock went back: keep the timestamp and count up
+		ms = g.lastMS
+		if !increment(g.entropy[:]) {
+			return "", fmt.Errorf("failed to generate %s ID: too many IDs in one millisecond", kind)
+		}
+	} else if _, err := rand.Read(g.entropy[:]); err != nil {
+		return "", fmt.Errorf("failed to generate %s ID: %w", kind, err)
+	}
+	g.lastMS = ms
+
+	var id [16]byte
+	for i := range 6 {
+		id[i] = byte(ms >> (40 - 8*i))
+	}
+	copy(id[6:], g.entropy[:])
+	return encodeULID(id), nil
+}
+
+// increment adds one to a big-endian number, reporting false when it overflows.
+func increment(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeULID encodes 128 bits as 26 Crockford base32 characters, the first carrying the top 3 bits.
+func encodeULID(id [16]byte) string {
+	var out [26]byte
+	var acc uint64
+	bits := 2 // pad to 130 bits so the value splits evenly into 5-bit groups
+	pos := 0
+	for _, b := range id {
+		acc = acc<<8 | uint64(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out[pos] = crockford[(acc>>bits)&0x1f]
+			pos++
+		}
+	}
+	return string(out[:])
+}
+
+// sequentialPrefixes are the kind markers of sequential IDs.
+var sequentialPrefixes = map[IDKind]string{
+	IDKindInvoice:    "INV",
+	IDKindPayment:    "PAY",
+	IDKindSettlement: "SET",
+	IDKindRefund:     "REF",
+}
+
+// merchantPrefixLength is how many characters of the merchant ID prefix a sequential ID.
+const merchantPrefixLength = 8
+
+// SequentialIDGenerator generates human-friendly IDs numbered per merchant, kind and year, such as
+// 550E8400-INV-2024-000123. Records are numbered per merchant prefix, so merchants whose IDs start alike
+// share a sequence rather than an ID; records without a merchant are numbered platform-wide without a prefix.
+type SequentialIDGenerator struct {
+	sequences SequenceStore
+	now       func() time.Time
+}
+
+// NewSequentialIDGenerator creates a sequential ID generator numbering records with the sequence store.
+func NewSequentialIDGenerator(sequences SequenceStore) *SequentialIDGenerator {
+	return &SequentialIDGenerator{sequences: sequences, now: time.Now}
+}
+
+// NewID returns the next sequential ID of a merchant.
+func (g *SequentialIDGenerator) NewID(ctx context.Context, kind IDKind, merchantID string) (string, error) {
+	marker, ok := sequentialPrefixes[kind]
+	if !ok {
+		return "", fmt.Errorf("no sequential ID format for %s", kind)
+	}
+
+	year := g.now().UTC().Year()
+	prefix := SequentialIDPrefix(merchantID)
+	sequence, err := g.sequences.NextSequence(ctx, prefix, kind, year)
+	if err != nil {
+		return "", fmt.Errorf("failed to number %s: %w", kind, err)
+	}
+
+	id := fmt.Sprintf("%s-%d-%06d", marker, year, sequence)
+	if prefix != "" {
+		id = prefix + "-" + id
+	}
+	return id, nil
+}
+
+// SequentialIDPrefix returns the prefix of a merchant's sequential IDs, the first letters and digits of its ID
+// upper-cased. The merchant's records are numbered in the sequences of that prefix.
+func SequentialIDPrefix(merchantID string) string {
+	var prefix strings.Builder
+	for _, r := range strings.ToUpper(merchantID) {
+		if prefix.Len() == merchantPrefixLength {
+			break
+		}
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			prefix.WriteRune(r)
+		}
+	}
+	return prefix.String()
+}
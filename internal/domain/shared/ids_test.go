@@ -0,0 +1,119 @@
+package shared_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// memorySequences numbers records in memory.
+type memorySequences struct {
+	mu     sync.Mutex
+	values map[string]int64
+}
+
+func (m *memorySequences) NextSequence(_ context.Context, prefix string, kind shared.IDKind, year int) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := fmt.Sprintf("%s/%s/%d", prefix, kind, year)
+	m.values[key]++
+	return m.values[key], nil
+}
+
+func TestIDGenerators(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("NewIDGenerator - strategies", func(t *testing.T) {
+		for strategy, want := range map[string]interface{}{
+			"":                          shared.RandomIDGenerator{},
+			shared.IDStrategyRandom:     shared.RandomIDGenerator{},
+			shared.IDStrategyULID:       &shared.ULIDGenerator{},
+			shared.IDStrategyUUIDv7:     shared.UUIDv7Generator{},
+			shared.IDStrategySequential: &shared.SequentialIDGenerator{},
+		} {
+			generator, err := shared.NewIDGenerator(strategy, &memorySequences{values: map[string]int64{}})
+			require.NoError(t, err)
+			require.IsType(t, want, generator)
+		}
+
+		_, err := shared.NewIDGenerator("snowflake", nil)
+		require.ErrorIs(t, err, shared.ErrUnknownIDStrategy)
+		_, err = shared.NewIDGenerator(shared.IDStrategySequential, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("Random - keeps the historical formats", func(t *testing.T) {
+		generator := shared.RandomIDGenerator{}
+		invoiceID, err := generator.NewID(ctx, shared.IDKindInvoice, "merchant-1")
+		require.NoError(t, err)
+		require.Regexp(t, `^inv_[0-9a-f]{16}$`, invoiceID)
+		refundID, err := generator.NewID(ctx, shared.IDKindRefund, "merchant-1")
+		require.NoError(t, err)
+		require.Regexp(t, `^ref_[0-9a-f]{16}$`, refundID)
+		paymentID, err := generator.NewID(ctx, shared.IDKindPayment, "")
+		require.NoError(t, err)
+		require.Len(t, paymentID, 36)
+	})
+
+	t.Run("ULID - unique and sorted under concurrency", func(t *testing.T) {
+		generator := shared.NewULIDGenerator()
+		const count = 1000
+		ids := make([]string, count)
+		var wg sync.WaitGroup
+		for i := range count {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				id, err := generator.NewID(ctx, shared.IDKindInvoice, "")
+				require.NoError(t, err)
+				ids[i] = id
+			}()
+		}
+		wg.Wait()
+
+		seen := make(map[string]bool, count)
+		ulid := regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+		for _, id := range ids {
+			require.Regexp(t, ulid, id)
+			require.False(t, seen[id], "duplicate ID %s", id)
+			seen[id] = true
+		}
+
+		// Sequential calls sort in generation order
+		first, err := generator.NewID(ctx, shared.IDKindInvoice, "")
+		require.NoError(t, err)
+		second, err := generator.NewID(ctx, shared.IDKindInvoice, "")
+		require.NoError(t, err)
+		require.Less(t, first, second)
+	})
+
+	t.Run("UUIDv7 - version 7", func(t *testing.T) {
+		id, err := shared.UUIDv7Generator{}.NewID(ctx, shared.IDKindSettlement, "")
+		require.NoError(t, err)
+		require.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, id)
+	})
+
+	t.Run("Sequential - numbered per merchant, kind and year", func(t *testing.T) {
+		generator := shared.NewSequentialIDGenerator(&memorySequences{values: map[string]int64{}})
+		year := time.Now().UTC().Year()
+
+		next := func(kind shared.IDKind, merchantID string) string {
+			id, err := generator.NewID(ctx, kind, merchantID)
+			require.NoError(t, err)
+			return id
+		}
+		merchant := "550e8400-e29b-41d4-a716-446655440000"
+		require.Equal(t, fmt.Sprintf("550E8400-INV-%d-000001", year), next(shared.IDKindInvoice, merchant))
+		require.Equal(t, fmt.Sprintf("550E8400-INV-%d-000002", year), next(shared.IDKindInvoice, merchant))
+		require.Equal(t, fmt.Sprintf("550E8400-REF-%d-000001", year), next(shared.IDKindRefund, merchant))
+		require.Equal(t, fmt.Sprintf("7C9E6679-INV-%d-000001", year),
+			next(shared.IDKindInvoice, "7c9e6679-7425-40de-944b-e07fc1f90ae7"))
+		require.Equal(t, fmt.Sprintf("PAY-%d-000001", year), next(shared.IDKindPayment, ""))
+	})
+}
@@ -3,6 +3,7 @@ package shared
 import (
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/shopspring/decimal"
 )
@@ -31,28 +32,68 @@ func (c Currency) IsValid() bool {
 	}
 }
 
-// CryptoCurrency represents supported cryptocurrencies.
+// CryptoCurrency represents a cryptocurrency symbol. The symbols an invoice may be paid in, and on which
+// networks, come from the TokenRegistry.
 type CryptoCurrency string
 
 const (
 	CryptoCurrencyUSDT CryptoCurrency = "USDT"
 	CryptoCurrencyBTC  CryptoCurrency = "BTC"
 	CryptoCurrencyETH  CryptoCurrency = "ETH"
+	CryptoCurrencyUSDC CryptoCurrency = "USDC"
+	CryptoCurrencyDAI  CryptoCurrency = "DAI"
+	CryptoCurrencyBUSD CryptoCurrency = "BUSD"
 )
 
+// maxCryptoCurrencyLength is the longest symbol the invoices table stores.
+const maxCryptoCurrencyLength = 10
+
+// cryptoCurrencies holds the symbols IsValid accepts: the built-in ones and those of the tokens a TokenRegistry
+// was created with, so tokens configured at runtime are accepted without code changes.
+var cryptoCurrencies = struct {
+	sync.RWMutex
+	symbols map[CryptoCurrency]bool
+}{symbols: map[CryptoCurrency]bool{
+	CryptoCurrencyUSDT: true,
+	CryptoCurrencyBTC:  true,
+	CryptoCurrencyETH:  true,
+	CryptoCurrencyUSDC: true,
+	CryptoCurrencyDAI:  true,
+	CryptoCurrencyBUSD: true,
+}}
+
 // String returns the string representation of the cryptocurrency.
 func (c CryptoCurrency) String() string {
 	return string(c)
 }
 
-// IsValid returns true if the cryptocurrency is valid.
+// IsValid returns true if the cryptocurrency is a built-in symbol or the symbol of a registered token.
 func (c CryptoCurrency) IsValid() bool {
-	switch c {
-	case CryptoCurrencyUSDT, CryptoCurrencyBTC, CryptoCurrencyETH:
-		return true
-	default:
+	cryptoCurrencies.RLock()
+	defer cryptoCurrencies.RUnlock()
+	return cryptoCurrencies.symbols[c]
+}
+
+// isWellFormed returns true if the symbol is two to ten upper-case letters or digits.
+func (c CryptoCurrency) isWellFormed() bool {
+	if len(c) < 2 || len(c) > maxCryptoCurrencyLength {
 		return false
 	}
+	for _, r := range c {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// registerCryptoCurrencies adds the symbols of registered tokens to those IsValid accepts.
+func registerCryptoCurrencies(tokens []Token) {
+	cryptoCurrencies.Lock()
+	defer cryptoCurrencies.Unlock()
+	for _, token := range tokens {
+		cryptoCurrencies.symbols[token.Symbol] = true
+	}
 }
 
 // Money represents a monetary amount with currency.
@@ -131,6 +172,24 @@ func (m *Money) Add(other *Money) (*Money, error) {
 	return &Money{amount: result, currency: m.currency}, nil
 }
 
+// Subtract subtracts another Money from this one.
+// Unlike Add, the result is not rounded so cryptocurrency amounts keep their precision.
+func (m *Money) Subtract(other *Money) (*Money, error) {
+	if m.currency != other.currency {
+		return nil, errors.New("currency mismatch")
+	}
+	result := m.amount.Sub(other.amount)
+	if result.IsNegative() {
+		return nil, ErrNegativeAmount
+	}
+	return &Money{amount: result, currency: m.currency}, nil
+}
+
+// IsZero returns true if the amount is zero.
+func (m *Money) IsZero() bool {
+	return m.amount.IsZero()
+}
+
 // Multiply multiplies this amount by a decimal multiplier.
 func (m *Money) Multiply(multiplier decimal.Decimal) (*Money, error) {
 	result := m.amount.Mul(multiplier).Round(2)
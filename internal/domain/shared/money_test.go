@@ -110,4 +110,51 @@ func TestMoney(t *testing.T) {
 		require.True(t, money1.Equals(money2))  // Same amount and currency
 		require.False(t, money1.Equals(money3)) // Different currency
 	})
+
+	t.Run("Subtract - keeps crypto precision", func(t *testing.T) {
+		paid, _ := shared.NewMoneyWithCrypto("0.00150000", shared.CryptoCurrencyBTC)
+		refunded, _ := shared.NewMoneyWithCrypto("0.00012345", shared.CryptoCurrencyBTC)
+
+		remaining, err := paid.Subtract(refunded)
+		require.NoError(t, err)
+		require.Equal(t, "0.00137655", remaining.Amount().String())
+		require.False(t, remaining.IsZero())
+	})
+
+	t.Run("Subtract - negative result and currency mismatch", func(t *testing.T) {
+		small, _ := shared.NewMoneyWithCrypto("1", shared.CryptoCurrencyUSDT)
+		large, _ := shared.NewMoneyWithCrypto("2", shared.CryptoCurrencyUSDT)
+		other, _ := shared.NewMoneyWithCrypto("1", shared.CryptoCurrencyBTC)
+
+		_, err := small.Subtract(large)
+		require.ErrorIs(t, err, shared.ErrNegativeAmount)
+
+		_, err = small.Subtract(other)
+		require.Error(t, err)
+
+		zero, err := small.Subtract(small)
+		require.NoError(t, err)
+		require.True(t, zero.IsZero())
+	})
+}
+
+func BenchmarkMoney_NewMoney(b *testing.B) {
+	for b.Loop() {
+		_, _ = shared.NewMoney("1234.56", shared.CurrencyUSD)
+	}
+}
+
+func BenchmarkMoney_Arithmetic(b *testing.B) {
+	subtotal, err := shared.NewMoney("1234.56", shared.CurrencyUSD)
+	require.NoError(b, err)
+	tax, err := shared.NewMoney("98.76", shared.CurrencyUSD)
+	require.NoError(b, err)
+	rate := decimal.RequireFromString("0.0825")
+
+	for b.Loop() {
+		total, _ := subtotal.Add(tax)
+		fee, _ := total.Multiply(rate)
+		net, _ := total.Subtract(fee)
+		_ = net.GreaterThanOrEqual(subtotal)
+	}
 }
@@ -31,7 +31,7 @@ func NewPaymentAddress(address string, network BlockchainNetwork) (*PaymentAddre
 	return &PaymentAddress{
 		address:     address,
 		network:     network,
-		generatedAt: time.Now().UTC(),
+		generatedAt: Now().UTC(),
 	}, nil
 }
 
@@ -46,7 +46,7 @@ func NewPaymentAddressWithExpiry(
 		return nil, err
 	}
 
-	if expiresAt.Before(time.Now().UTC()) {
+	if expiresAt.Before(Now().UTC()) {
 		return nil, errors.New("expiration time must be in the future")
 	}
 
@@ -79,7 +79,7 @@ func (pa *PaymentAddress) IsExpired() bool {
 	if pa.expiresAt == nil {
 		return false
 	}
-	return time.Now().UTC().After(*pa.expiresAt)
+	return Now().UTC().After(*pa.expiresAt)
 }
 
 // String returns the string representation of the address.
@@ -0,0 +1,18 @@
+package shared
+
+import "context"
+
+// queryTimeoutContextKey is the context key marking database operations exempt from the per-operation timeout.
+type queryTimeoutContextKey struct{}
+
+// WithoutQueryTimeout returns a copy of ctx whose database operations are bounded by its own deadline alone, for
+// the few expected to run long, such as migrations.
+func WithoutQueryTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, queryTimeoutContextKey{}, true)
+}
+
+// QueryTimeoutExempt reports whether database operations made with ctx are exempt from the per-operation timeout.
+func QueryTimeoutExempt(ctx context.Context) bool {
+	exempt, _ := ctx.Value(queryTimeoutContextKey{}).(bool)
+	return exempt
+}
@@ -0,0 +1,23 @@
+package shared
+
+import "context"
+
+// replicaReadsContextKey is the context key marking reads that may be served by a read replica.
+type replicaReadsContextKey struct{}
+
+// WithReplicaReads returns a copy of ctx whose reads tolerate replication lag, such as checkout page views,
+// lists and analytics. Repositories may serve them from a read replica; writes always go to the primary.
+func WithReplicaReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaReadsContextKey{}, true)
+}
+
+// WithoutReplicaReads returns a copy of ctx whose reads go to the primary even if ctx allowed replica reads.
+func WithoutReplicaReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaReadsContextKey{}, false)
+}
+
+// ReplicaReadsAllowed reports whether reads made with ctx may be served by a read replica.
+func ReplicaReadsAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(replicaReadsContextKey{}).(bool)
+	return allowed
+}
@@ -0,0 +1,22 @@
+package shared
+
+import "context"
+
+// merchantContextKey is the context key holding the merchant a request is scoped to.
+type merchantContextKey struct{}
+
+// WithMerchantID returns a copy of ctx scoped to a merchant.
+// Repositories restrict every query made with a scoped context to that merchant's data.
+func WithMerchantID(ctx context.Context, merchantID string) context.Context {
+	if merchantID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, merchantContextKey{}, merchantID)
+}
+
+// MerchantIDFromContext returns the merchant ctx is scoped to.
+// Public checkout requests and background jobs are not scoped to a merchant.
+func MerchantIDFromContext(ctx context.Context) (string, bool) {
+	merchantID, ok := ctx.Value(merchantContextKey{}).(string)
+	return merchantID, ok && merchantID != ""
+}
@@ -0,0 +1,22 @@
+package shared_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerchantContext(t *testing.T) {
+	_, ok := shared.MerchantIDFromContext(context.Background())
+	require.False(t, ok)
+
+	ctx := shared.WithMerchantID(context.Background(), "merchant-1")
+	merchantID, ok := shared.MerchantIDFromContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, "merchant-1", merchantID)
+
+	_, ok = shared.MerchantIDFromContext(shared.WithMerchantID(context.Background(), ""))
+	require.False(t, ok)
+}
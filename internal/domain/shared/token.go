@@ -0,0 +1,164 @@
+package shared
+
+import (
+	"fmt"
+	"slices"
+)
+
+// maxTokenDecimals is the largest precision of the tokens the platform accepts.
+const maxTokenDecimals = 18
+
+// Token is a cryptocurrency as it exists on one network: the network's native coin or a token contract.
+type Token struct {
+	Symbol    CryptoCurrency
+	Network   BlockchainNetwork
+	Contract  string   // Token contract address; empty for the network's native coin
+	Decimals  int32    // Precision of on-chain amounts
+	Merchants []string // Merchants the token is enabled for; empty enables it for every merchant
+}
+
+// IsNative returns true if the token is the network's native coin.
+func (t Token) IsNative() bool {
+	return t.Contract == ""
+}
+
+// sameAs returns true if both are the same symbol on the same network.
+func (t Token) sameAs(other Token) bool {
+	return t.Symbol == other.Symbol && t.Network == other.Network
+}
+
+// EnabledFor returns true if the merchant may accept the token.
+func (t Token) EnabledFor(merchantID string) bool {
+	return len(t.Merchants) == 0 || slices.Contains(t.Merchants, merchantID)
+}
+
+// TokenSelector picks tokens by symbol and, optionally, network.
+type TokenSelector struct {
+	Symbol  CryptoCurrency
+	Network BlockchainNetwork // Empty selects the symbol on every network; a mainnet also selects its testnets
+}
+
+// Matches returns true if the selector picks the token.
+func (s TokenSelector) Matches(token Token) bool {
+	return s.Symbol == token.Symbol &&
+		(s.Network == "" || s.Network == token.Network || s.Network == token.Network.Mainnet())
+}
+
+// SelectTokens returns the tokens picked by any of the selectors, in the order of the selectors.
+func SelectTokens(tokens []Token, selectors []TokenSelector) []Token {
+	var selected []Token
+	for _, selector := range selectors {
+		for _, token := range tokens {
+			if selector.Matches(token) && !slices.ContainsFunc(selected, token.sameAs) {
+				selected = append(selected, token)
+			}
+		}
+	}
+	return selected
+}
+
+// DefaultTokens returns the tokens accepted when none are configured: USDT on Tron, bitcoin and ether.
+func DefaultTokens() []Token {
+	return []Token{
+		{Symbol: CryptoCurrencyUSDT, Network: NetworkTron, Contract: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", Decimals: 6},
+		{Symbol: CryptoCurrencyBTC, Network: NetworkBitcoin, Decimals: 8},
+		{Symbol: CryptoCurrencyETH, Network: NetworkEthereum, Decimals: 18},
+	}
+}
+
+// DefaultTestnetTokens returns the testnet counterparts of the DefaultTokens merchants in test mode are paid in
+// when no testnet tokens are configured: USDT on Nile and Shasta, testnet bitcoin and Sepolia ether.
+func DefaultTestnetTokens() []Token {
+	return []Token{
+		{Symbol: CryptoCurrencyUSDT, Network: NetworkTronNile, Contract: "TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf", Decimals: 6},
+		{Symbol: CryptoCurrencyUSDT, Network: NetworkTronShasta, Contract: "TG3XXyExBkPp9nzdajDZsozEu4BkaSJozs", Decimals: 6},
+		{Symbol: CryptoCurrencyBTC, Network: NetworkBitcoinTestnet, Decimals: 8},
+		{Symbol: CryptoCurrencyETH, Network: NetworkEthereumSepolia, Decimals: 18},
+	}
+}
+
+// TokensForMode returns the tokens on testnets in test mode, and the tokens on mainnets otherwise.
+func TokensForMode(tokens []Token, testMode bool) []Token {
+	var selected []Token
+	for _, token := range tokens {
+		if token.Network.IsTestnet() == testMode {
+			selected = append(selected, token)
+		}
+	}
+	return selected
+}
+
+// TokenRegistry is the set of tokens invoices may be paid in. A symbol may be listed on several networks;
+// the first one listed is its default network.
+type TokenRegistry struct {
+	tokens []Token
+}
+
+// NewTokenRegistry creates a registry of the given tokens, in order of preference. Their symbols become valid
+// cryptocurrencies.
+func NewTokenRegistry(tokens []Token) (*TokenRegistry, error) {
+	seen := make(map[string]bool, len(tokens))
+	for _, token := range tokens {
+		if !token.Symbol.isWellFormed() {
+			return nil, fmt.Errorf("%w: invalid token symbol %q", ErrInvalidInput, token.Symbol)
+		}
+		if !token.Network.IsValid() {
+			return nil, fmt.Errorf("%w: %s has unknown network %q", ErrInvalidNetwork, token.Symbol, token.Network)
+		}
+		if token.Decimals < 0 || token.Decimals > maxTokenDecimals {
+			return nil, fmt.Errorf("%w: %s on %s has invalid decimals %d",
+				ErrInvalidInput, token.Symbol, token.Network, token.Decimals)
+		}
+		key := string(token.Symbol) + "/" + string(token.Network)
+		if seen[key] {
+			return nil, fmt.Errorf("%w: %s is listed twice on %s", ErrInvalidInput, token.Symbol, token.Network)
+		}
+		seen[key] = true
+	}
+
+	registerCryptoCurrencies(tokens)
+	return &TokenRegistry{tokens: slices.Clone(tokens)}, nil
+}
+
+// DefaultTokenRegistry returns a registry of the DefaultTokens and DefaultTestnetTokens.
+func DefaultTokenRegistry() *TokenRegistry {
+	return &TokenRegistry{tokens: append(DefaultTokens(), DefaultTestnetTokens()...)}
+}
+
+// Tokens returns every registered token.
+func (r *TokenRegistry) Tokens() []Token {
+	return slices.Clone(r.tokens)
+}
+
+// TokensFor returns the tokens the merchant may accept.
+func (r *TokenRegistry) TokensFor(merchantID string) []Token {
+	var tokens []Token
+	for _, token := range r.tokens {
+		if token.EnabledFor(merchantID) {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// Resolve returns the token a merchant's invoice is paid in. An empty network selects the symbol's
+// default network among those enabled for the merchant.
+func (r *TokenRegistry) Resolve(merchantID string, symbol CryptoCurrency, network BlockchainNetwork) (Token, error) {
+	return ResolveToken(r.TokensFor(merchantID), symbol, network)
+}
+
+// ResolveToken returns the first of the tokens that is the symbol on the network. An empty network selects
+// the first token of the symbol.
+func ResolveToken(tokens []Token, symbol CryptoCurrency, network BlockchainNetwork) (Token, error) {
+	selector := TokenSelector{Symbol: symbol, Network: network}
+	for _, token := range tokens {
+		if selector.Matches(token) {
+			return token, nil
+		}
+	}
+
+	if network == "" {
+		return Token{}, fmt.Errorf("%w: %s", ErrUnsupportedToken, symbol)
+	}
+	return Token{}, fmt.Errorf("%w: %s on %s", ErrUnsupportedToken, symbol, network)
+}
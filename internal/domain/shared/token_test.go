@@ -0,0 +1,116 @@
+package shared_test
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenRegistry(t *testing.T) {
+	registry, err := shared.NewTokenRegistry([]shared.Token{
+		{Symbol: "USDT", Network: shared.NetworkTron, Contract: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", Decimals: 6},
+		{
+			Symbol: "USDT", Network: shared.NetworkBSC, Contract: "0x55d398326f99059fF775485246999027B3197955",
+			Decimals: 18,
+		},
+		{
+			Symbol: "USDC", Network: shared.NetworkEthereum, Contract: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+			Decimals: 6, Merchants: []string{"merchant-2"},
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("Resolve - default network is the first listed", func(t *testing.T) {
+		token, err := registry.Resolve("merchant-1", "USDT", "")
+		require.NoError(t, err)
+		require.Equal(t, shared.NetworkTron, token.Network)
+		require.False(t, token.IsNative())
+	})
+
+	t.Run("Resolve - explicit network", func(t *testing.T) {
+		token, err := registry.Resolve("merchant-1", "USDT", shared.NetworkBSC)
+		require.NoError(t, err)
+		require.Equal(t, int32(18), token.Decimals)
+
+		_, err = registry.Resolve("merchant-1", "USDT", shared.NetworkEthereum)
+		require.ErrorIs(t, err, shared.ErrUnsupportedToken)
+	})
+
+	t.Run("Resolve - token restricted to other merchants", func(t *testing.T) {
+		_, err := registry.Resolve("merchant-1", "USDC", "")
+		require.ErrorIs(t, err, shared.ErrUnsupportedToken)
+
+		token, err := registry.Resolve("merchant-2", "USDC", "")
+		require.NoError(t, err)
+		require.Equal(t, shared.NetworkEthereum, token.Network)
+		require.Len(t, registry.TokensFor("merchant-1"), 2)
+		require.Len(t, registry.TokensFor("merchant-2"), 3)
+	})
+
+	t.Run("NewTokenRegistry - symbols are valid once registered", func(t *testing.T) {
+		require.False(t, shared.CryptoCurrency("INVALID").IsValid())
+		require.False(t, shared.CryptoCurrency("PYUSD").IsValid())
+
+		_, err := shared.NewTokenRegistry([]shared.Token{{
+			Symbol: "PYUSD", Network: shared.NetworkEthereum, Contract: "0x6c3ea9036406852006290770BEdFcAbA0e23A0e8",
+			Decimals: 6,
+		}})
+		require.NoError(t, err)
+		require.True(t, shared.CryptoCurrency("PYUSD").IsValid())
+		require.False(t, shared.CryptoCurrency("INVALID").IsValid())
+	})
+
+	t.Run("NewTokenRegistry - invalid tokens", func(t *testing.T) {
+		_, err := shared.NewTokenRegistry([]shared.Token{{Symbol: "usdt", Network: shared.NetworkTron}})
+		require.ErrorIs(t, err, shared.ErrInvalidInput)
+
+		_, err = shared.NewTokenRegistry([]shared.Token{{Symbol: "DAI", Network: "solana"}})
+		require.ErrorIs(t, err, shared.ErrInvalidNetwork)
+
+		_, err = shared.NewTokenRegistry([]shared.Token{
+			{Symbol: "DAI", Network: shared.NetworkEthereum, Decimals: 18},
+			{Symbol: "DAI", Network: shared.NetworkEthereum, Decimals: 18},
+		})
+		require.ErrorIs(t, err, shared.ErrInvalidInput)
+	})
+}
+
+func TestSelectTokens(t *testing.T) {
+	tokens := []shared.Token{
+		{Symbol: "USDT", Network: shared.NetworkTron, Decimals: 6},
+		{Symbol: "USDT", Network: shared.NetworkBSC, Decimals: 18},
+		{Symbol: "BTC", Network: shared.NetworkBitcoin, Decimals: 8},
+	}
+
+	selected := shared.SelectTokens(tokens, []shared.TokenSelector{
+		{Symbol: "BTC"},
+		{Symbol: "USDT", Network: shared.NetworkBSC},
+		{Symbol: "USDT"},
+		{Symbol: "DAI"},
+	})
+
+	// The selectors' order wins, so USDT defaults to BSC and is not listed twice
+	require.Len(t, selected, 3)
+	require.Equal(t, shared.CryptoCurrency("BTC"), selected[0].Symbol)
+	require.Equal(t, shared.NetworkBSC, selected[1].Network)
+	require.Equal(t, shared.NetworkTron, selected[2].Network)
+
+	token, err := shared.ResolveToken(selected, "USDT", "")
+	require.NoError(t, err)
+	require.Equal(t, shared.NetworkBSC, token.Network)
+}
+
+func TestTokensForMode(t *testing.T) {
+	tokens := shared.DefaultTokenRegistry().Tokens()
+
+	require.Equal(t, shared.DefaultTokens(), shared.TokensForMode(tokens, false))
+	testnet := shared.TokensForMode(tokens, true)
+	require.Equal(t, shared.DefaultTestnetTokens(), testnet)
+
+	// Selectors naming a mainnet select its testnets
+	selected := shared.SelectTokens(testnet, []shared.TokenSelector{{Symbol: "USDT", Network: shared.NetworkTron}})
+	require.Len(t, selected, 2)
+	require.Equal(t, shared.NetworkTronNile, selected[0].Network)
+	require.Equal(t, shared.NetworkTronShasta, selected[1].Network)
+}
@@ -0,0 +1,30 @@
+package shared
+
+import (
+	"context"
+	"time"
+)
+
+// WorkerSupervisor runs the background workers of the application. A worker that panics or returns before its
+// context is cancelled is restarted, and one that stops beating is reported as stalled.
+type WorkerSupervisor interface {
+	// Supervise runs a worker under a unique name until the context is cancelled. The worker is expected to
+	// call Beat with the context it is given at least once per interval, e.g. on every tick of its loop.
+	Supervise(ctx context.Context, name string, interval time.Duration, run func(ctx context.Context))
+}
+
+// heartbeatContextKey is the context key holding the heartbeat of a supervised worker.
+type heartbeatContextKey struct{}
+
+// WithHeartbeat returns a copy of ctx on which Beat calls beat.
+func WithHeartbeat(ctx context.Context, beat func()) context.Context {
+	return context.WithValue(ctx, heartbeatContextKey{}, beat)
+}
+
+// Beat tells the supervisor of the worker ctx was given to that the worker is making progress. It does nothing
+// for a worker that is not supervised.
+func Beat(ctx context.Context) {
+	if beat, ok := ctx.Value(heartbeatContextKey{}).(func()); ok {
+		beat()
+	}
+}
@@ -0,0 +1,17 @@
+package slo
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the service level objective layer dependencies.
+var Module = fx.Module("slo-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+		NewEvaluator,
+	),
+	fx.Invoke(RegisterEvaluator),
+)
@@ -0,0 +1,74 @@
+package slo
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// evaluateInterval is how often the evaluator checks the error budgets.
+const evaluateInterval = time.Minute
+
+// Evaluator checks the error budgets of the objectives in the background.
+type Evaluator struct {
+	service  Service
+	interval time.Duration
+}
+
+// NewEvaluator creates a new evaluator.
+func NewEvaluator(service Service) *Evaluator {
+	return &Evaluator{
+		service:  service,
+		interval: evaluateInterval,
+	}
+}
+
+// Run evaluates the objectives every interval until the context is cancelled.
+func (e *Evaluator) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		shared.Beat(ctx)
+		e.service.Evaluate(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RegisterEvaluator runs the evaluator for the lifetime of the application.
+func RegisterEvaluator(
+	lc fx.Lifecycle,
+	evaluator *Evaluator,
+	workers shared.WorkerSupervisor,
+	logger *zap.Logger,
+) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			logger.Info("Starting SLO evaluator", zap.Int("objectives", len(Objectives())))
+			go func() {
+				defer close(done)
+				workers.Supervise(ctx, "slo_evaluator", evaluator.interval, evaluator.Run)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
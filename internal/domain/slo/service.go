@@ -0,0 +1,234 @@
+package slo
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SampleInterval is how often the counts of the objectives are kept to measure them over past windows.
+const SampleInterval = 5 * time.Minute
+
+// minEvents is how many events a window needs before its burn rate can put a budget at risk, so that a single
+// bad event on a quiet night does not.
+const minEvents = 20
+
+// Reasons a budget is at risk
+const (
+	ReasonFastBurn        = "fast_burn"
+	ReasonSlowBurn        = "slow_burn"
+	ReasonBudgetExhausted = "budget_exhausted"
+)
+
+// Status is how an objective fares over the window, as counted by this instance.
+type Status struct {
+	Objective
+	// Since is when the counts start: the start of the window, or when the instance started if that is later.
+	Since time.Time
+	Good  int64
+	Total int64
+	// Compliance is the share of good events, one without events.
+	Compliance float64
+	// ErrorBudgetRemaining is the share of the bad events the target allows that are left; it is negative once
+	// the budget is overspent.
+	ErrorBudgetRemaining float64
+	// BurnRate1h and BurnRate6h are how many times faster than it lasts the budget burned over the last hour and
+	// six hours; at 1 it is spent exactly over the window.
+	BurnRate1h float64
+	BurnRate6h float64
+	// AtRisk reports that the budget burns fast enough to be spent early, or is spent, for Reason.
+	AtRisk bool
+	Reason string
+}
+
+// Service defines the interface for tracking the service level objectives.
+type Service interface {
+	// Report returns the status of every objective.
+	Report(ctx context.Context) []*Status
+
+	// Evaluate keeps the current counts of the objectives and publishes an slo.budget_at_risk event for every
+	// objective whose budget came at risk since the previous evaluation.
+	Evaluate(ctx context.Context) []*Status
+}
+
+// sample is the counts of the objectives at a time.
+type sample struct {
+	at    time.Time
+	good  map[string]float64
+	total map[string]float64
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	objectives []Objective
+	eventBus   shared.EventBus
+	logger     *zap.Logger
+
+	mu sync.Mutex
+	// samples are the counts kept every SampleInterval over the window, oldest first
+	samples []sample
+	atRisk  map[string]bool
+}
+
+// NewService creates a new service level objective service, counting from now. The event bus may be nil, in
+// which case budgets at risk are only logged.
+func NewService(eventBus shared.EventBus, logger *zap.Logger) Service {
+	s := &ServiceImpl{
+		objectives: Objectives(),
+		eventBus:   eventBus,
+		logger:     logger,
+		atRisk:     make(map[string]bool),
+	}
+	s.samples = []sample{s.current(shared.Now())}
+	return s
+}
+
+// Report returns the status of every objective.
+func (s *ServiceImpl) Report(_ context.Context) []*Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.statuses(s.current(shared.Now()))
+}
+
+// Evaluate keeps the current counts and publishes an event for every budget that came at risk.
+func (s *ServiceImpl) Evaluate(ctx context.Context) []*Status {
+	s.mu.Lock()
+	now := s.current(shared.Now())
+	s.keep(now)
+	statuses := s.statuses(now)
+
+	var risen []*Status
+	for _, status := range statuses {
+		switch {
+		case status.AtRisk && !s.atRisk[status.Name]:
+			risen = append(risen, status)
+		case !status.AtRisk && s.atRisk[status.Name]:
+			s.logger.Info("Error budget no longer at risk", zap.String("slo", status.Name))
+		}
+		s.atRisk[status.Name] = status.AtRisk
+	}
+	s.mu.Unlock()
+
+	for _, status := range risen {
+		s.alert(ctx, status)
+	}
+	return statuses
+}
+
+// current returns the counts of the objectives at a time.
+func (s *ServiceImpl) current(at time.Time) sample {
+	now := sample{at: at, good: make(map[string]float64), total: make(map[string]float64)}
+	for _, objective := range s.objectives {
+		now.good[objective.Name], now.total[objective.Name] = counts(objective.Name)
+	}
+	return now
+}
+
+// keep adds the counts to the samples when the latest is older than SampleInterval, and forgets the samples no
+// longer needed to cover the window.
+func (s *ServiceImpl) keep(now sample) {
+	if now.at.Sub(s.samples[len(s.samples)-1].at) >= SampleInterval {
+		s.samples = append(s.samples, now)
+	}
+	start := now.at.Add(-Window)
+	for len(s.samples) > 1 && !s.samples[1].at.After(start) {
+		s.samples = s.samples[1:]
+	}
+}
+
+// since returns the latest sample at or before the start of a window ending now, or the oldest one when the
+// samples do not reach back that far.
+func (s *ServiceImpl) since(now time.Time, window time.Duration) sample {
+	start := now.Add(-window)
+	baseline := s.samples[0]
+	for _, kept := range s.samples[1:] {
+		if kept.at.After(start) {
+			break
+		}
+		baseline = kept
+	}
+	return baseline
+}
+
+// statuses returns the status of every objective with the current counts.
+func (s *ServiceImpl) statuses(now sample) []*Status {
+	windowStart := s.since(now.at, Window)
+	hour := s.since(now.at, time.Hour)
+	sixHours := s.since(now.at, 6*time.Hour)
+
+	statuses := make([]*Status, len(s.objectives))
+	for i, objective := range s.objectives {
+		name := objective.Name
+		good := now.good[name] - windowStart.good[name]
+		total := now.total[name] - windowStart.total[name]
+		status := &Status{
+			Objective:            objective,
+			Since:                windowStart.at,
+			Good:                 int64(good),
+			Total:                int64(total),
+			Compliance:           1,
+			ErrorBudgetRemaining: 1,
+		}
+		if total > 0 {
+			status.Compliance = good / total
+			status.ErrorBudgetRemaining = 1 - burnRate(objective, good, total)
+		}
+
+		hourTotal := now.total[name] - hour.total[name]
+		sixHoursTotal := now.total[name] - sixHours.total[name]
+		status.BurnRate1h = burnRate(objective, now.good[name]-hour.good[name], hourTotal)
+		status.BurnRate6h = burnRate(objective, now.good[name]-sixHours.good[name], sixHoursTotal)
+
+		switch {
+		case hourTotal >= minEvents && status.BurnRate1h >= FastBurnRate:
+			status.AtRisk, status.Reason = true, ReasonFastBurn
+		case sixHoursTotal >= minEvents && status.BurnRate6h >= SlowBurnRate:
+			status.AtRisk, status.Reason = true, ReasonSlowBurn
+		case total >= minEvents && status.ErrorBudgetRemaining <= 0:
+			status.AtRisk, status.Reason = true, ReasonBudgetExhausted
+		}
+		statuses[i] = status
+	}
+	return statuses
+}
+
+// burnRate returns the share of bad events relative to the share the target allows, zero without events.
+func burnRate(objective Objective, good, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return (total - good) / total / (1 - objective.Target)
+}
+
+// alert logs and publishes an objective whose budget came at risk.
+func (s *ServiceImpl) alert(ctx context.Context, status *Status) {
+	s.logger.Warn("Error budget at risk",
+		zap.String("slo", status.Name),
+		zap.String("reason", status.Reason),
+		zap.Float64("compliance", status.Compliance),
+		zap.Float64("error_budget_remaining", status.ErrorBudgetRemaining),
+		zap.Float64("burn_rate_1h", status.BurnRate1h),
+		zap.Float64("burn_rate_6h", status.BurnRate6h))
+
+	if s.eventBus == nil {
+		return
+	}
+	event := shared.NewSLOBudgetAtRiskEvent(shared.SLOEvent{
+		Objective:            status.Name,
+		Target:               status.Target,
+		Compliance:           status.Compliance,
+		ErrorBudgetRemaining: status.ErrorBudgetRemaining,
+		BurnRate1h:           status.BurnRate1h,
+		BurnRate6h:           status.BurnRate6h,
+		Reason:               status.Reason,
+	})
+	if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+		s.logger.Error("Failed to publish domain event",
+			zap.String("event_type", event.EventType),
+			zap.String("aggregate_id", event.AggregateID),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,136 @@
+package slo
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// recordingEventBus records the events published; only publishing is implemented.
+type recordingEventBus struct {
+	shared.EventBus
+	events []*shared.BaseDomainEvent
+}
+
+func (b *recordingEventBus) PublishEvent(_ context.Context, event *shared.BaseDomainEvent) error {
+	b.events = append(b.events, event)
+	return nil
+}
+
+// movingClock tells a time tests move forward.
+type movingClock struct {
+	now time.Time
+}
+
+func (c *movingClock) Now() time.Time { return c.now }
+
+func TestRecord(t *testing.T) {
+	good, total := counts(StatusReads)
+	RecordStatusRead(50*time.Millisecond, http.StatusOK)
+	RecordStatusRead(50*time.Millisecond, http.StatusNotFound)
+	RecordStatusRead(StatusReadLatency, http.StatusOK)
+	RecordStatusRead(50*time.Millisecond, http.StatusServiceUnavailable)
+	afterGood, afterTotal := counts(StatusReads)
+	assert.InDelta(t, 2, afterGood-good, 0, "fast answers without a server error are good")
+	assert.InDelta(t, 4, afterTotal-total, 0)
+
+	good, total = counts(PaymentDetection)
+	RecordDetection(0)
+	RecordDetection(DetectionBlocks)
+	RecordDetection(DetectionBlocks + 1)
+	afterGood, afterTotal = counts(PaymentDetection)
+	assert.InDelta(t, 2, afterGood-good, 0)
+	assert.InDelta(t, 3, afterTotal-total, 0)
+}
+
+func TestService(t *testing.T) {
+	clock := &movingClock{now: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	t.Cleanup(shared.SetClock(clock))
+	bus := &recordingEventBus{}
+	service := NewService(bus, zap.NewNop())
+	ctx := context.Background()
+
+	status := func(statuses []*Status, name string) *Status {
+		for _, status := range statuses {
+			if status.Name == name {
+				return status
+			}
+		}
+		t.Fatalf("objective %s not reported", name)
+		return nil
+	}
+	advance := func(d time.Duration) {
+		for end := clock.now.Add(d); clock.now.Before(end); {
+			clock.now = clock.now.Add(SampleInterval)
+			service.Evaluate(ctx)
+		}
+	}
+
+	t.Run("NoEvents", func(t *testing.T) {
+		reads := status(service.Report(ctx), StatusReads)
+		assert.Zero(t, reads.Total)
+		assert.InDelta(t, 1, reads.Compliance, 0)
+		assert.InDelta(t, 1, reads.ErrorBudgetRemaining, 0)
+		assert.False(t, reads.AtRisk)
+		assert.True(t, clock.now.Equal(reads.Since))
+	})
+
+	t.Run("FastBurn", func(t *testing.T) {
+		for range 100 {
+			Record(StatusReads, true)
+		}
+		advance(SampleInterval)
+		reads := status(service.Evaluate(ctx), StatusReads)
+		assert.Equal(t, int64(100), reads.Good)
+		assert.False(t, reads.AtRisk)
+		assert.Empty(t, bus.events)
+
+		for range 10 {
+			Record(StatusReads, false)
+		}
+		reads = status(service.Evaluate(ctx), StatusReads)
+		assert.Equal(t, int64(110), reads.Total)
+		assert.InDelta(t, 100.0/110, reads.Compliance, 1e-9)
+		assert.InDelta(t, 10.0/110/0.001, reads.BurnRate1h, 1e-6)
+		assert.True(t, reads.AtRisk)
+		assert.Equal(t, ReasonFastBurn, reads.Reason)
+		assert.False(t, status(service.Report(ctx), PaymentDetection).AtRisk)
+
+		require.Len(t, bus.events, 1)
+		assert.Equal(t, shared.EventTypeSLOBudgetAtRisk, bus.events[0].EventType)
+		assert.Equal(t, StatusReads, bus.events[0].AggregateID)
+		event := bus.events[0].EventData.(shared.SLOEvent)
+		assert.Equal(t, ReasonFastBurn, event.Reason)
+		assert.InDelta(t, 0.999, event.Target, 0)
+
+		service.Evaluate(ctx)
+		assert.Len(t, bus.events, 1, "an objective at risk is only announced when it comes at risk")
+	})
+
+	t.Run("BudgetExhausted", func(t *testing.T) {
+		advance(7 * time.Hour)
+		reads := status(service.Report(ctx), StatusReads)
+		assert.Zero(t, reads.BurnRate1h)
+		assert.Zero(t, reads.BurnRate6h)
+		assert.Equal(t, int64(110), reads.Total)
+		assert.Less(t, reads.ErrorBudgetRemaining, 0.0)
+		assert.True(t, reads.AtRisk)
+		assert.Equal(t, ReasonBudgetExhausted, reads.Reason)
+		assert.Len(t, bus.events, 1)
+	})
+
+	t.Run("WindowPasses", func(t *testing.T) {
+		clock.now = clock.now.Add(Window)
+		reads := status(service.Evaluate(ctx), StatusReads)
+		assert.Zero(t, reads.Total)
+		assert.False(t, reads.AtRisk)
+		assert.True(t, clock.now.Add(-Window).After(reads.Since) || clock.now.Add(-Window).Equal(reads.Since))
+		assert.LessOrEqual(t, len(service.(*ServiceImpl).samples), 2, "samples before the window are forgotten")
+	})
+}
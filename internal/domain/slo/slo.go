@@ -0,0 +1,130 @@
+// Package slo tracks the service level objectives of the platform: the share of events that must be good, such
+// as invoice status reads answered quickly, over a rolling window. Good and bad events are counted on /metrics as
+// they happen; the service samples the counts to report compliance, the error budget left and how fast it burns,
+// and publishes an event when a budget is at risk.
+package slo
+
+import (
+	"crypto-checkout/pkg/metrics"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Objectives
+const (
+	// StatusReads are the invoice status reads checkout pages and widgets poll, good when answered without a
+	// server error within StatusReadLatency.
+	StatusReads = "status_reads"
+	// PaymentDetection are the payments detected on chain, good when detected within DetectionBlocks blocks of
+	// the one holding their transaction.
+	PaymentDetection = "payment_detection"
+)
+
+// Thresholds of the good events.
+const (
+	StatusReadLatency = 200 * time.Millisecond
+	DetectionBlocks   = 2
+)
+
+// Window is the rolling period objectives are measured over and their error budget spans.
+const Window = 30 * 24 * time.Hour
+
+// Burn rate thresholds: a budget burning FastBurnRate times faster than it lasts over an hour, or SlowBurnRate
+// times faster over six hours, would be spent in about two or five days.
+const (
+	FastBurnRate = 14.4
+	SlowBurnRate = 6
+)
+
+// Objective is a service level objective: the share of events that must be good over the window.
+type Objective struct {
+	Name        string
+	Description string
+	Target      float64
+}
+
+// Objectives returns the objectives of the platform.
+func Objectives() []Objective {
+	return []Objective{
+		{
+			Name: StatusReads,
+			Description: fmt.Sprintf("Invoice status reads answered without a server error within %s",
+				StatusReadLatency),
+			Target: 0.999,
+		},
+		{
+			Name:        PaymentDetection,
+			Description: fmt.Sprintf("Payments detected within %d blocks of their transaction", DetectionBlocks),
+			Target:      0.99,
+		},
+	}
+}
+
+// Event outcomes
+const (
+	outcomeGood = "good"
+	outcomeBad  = "bad"
+)
+
+// events counts the good and bad events of each objective on /metrics.
+var events = metrics.NewCounter(metrics.Namespace+"_slo_events_total",
+	"Events counted against service level objectives, by objective and outcome.", "slo", "outcome")
+
+func init() {
+	for _, objective := range Objectives() {
+		// The share of bad events that burns the budget FastBurnRate times faster than it lasts
+		threshold := strconv.FormatFloat(FastBurnRate*(1-objective.Target), 'g', 4, 64)
+		metrics.AddAlert(metrics.AlertRule{
+			Name: alertName(objective.Name) + "BudgetBurn",
+			Expr: fmt.Sprintf(`sum(rate(%[1]s{slo="%[2]s",outcome="%[3]s"}[1h])) / sum(rate(%[1]s{slo="%[2]s"}[1h])) `+
+				`> %[4]s`, events.Name(), objective.Name, outcomeBad, threshold),
+			For:      5 * time.Minute,
+			Severity: metrics.SeverityCritical,
+			Summary:  fmt.Sprintf("The %s objective is burning its error budget fast", objective.Name),
+			Description: fmt.Sprintf("Over the last hour, {{ $value | humanizePercentage }} of the %s events were "+
+				"bad; at this rate the %d-day error budget is spent in about two days.",
+				objective.Name, int(Window.Hours()/24)),
+			Metrics: []string{events.Name()},
+		})
+	}
+}
+
+// Record counts an event against an objective.
+func Record(objective string, good bool) {
+	outcome := outcomeBad
+	if good {
+		outcome = outcomeGood
+	}
+	events.Inc(objective, outcome)
+}
+
+// RecordStatusRead counts an invoice status read that took the duration and answered with the status code.
+func RecordStatusRead(duration time.Duration, status int) {
+	Record(StatusReads, status < http.StatusInternalServerError && duration < StatusReadLatency)
+}
+
+// RecordDetection counts a payment detected with the confirmations it had, one when its transaction is in the
+// latest block.
+func RecordDetection(confirmations int) {
+	Record(PaymentDetection, confirmations <= DetectionBlocks)
+}
+
+// counts returns the good and total events of an objective counted so far.
+func counts(objective string) (good, total float64) {
+	good = events.Value(objective, outcomeGood)
+	return good, good + events.Value(objective, outcomeBad)
+}
+
+// alertName makes the CamelCase prefix of an objective's alert, such as StatusReads for status_reads.
+func alertName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,15 @@
+package statement
+
+import "go.uber.org/fx"
+
+// Module provides the statement service layer dependencies.
+var Module = fx.Module("statement-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+		NewGenerator,
+	),
+	fx.Invoke(RegisterGenerator),
+)
@@ -0,0 +1,15 @@
+package statement
+
+import "errors"
+
+// Domain errors for statement operations
+var (
+	ErrStatementNotFound = errors.New("statement not found")
+	ErrInvalidRequest    = errors.New("invalid statement request")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeStatementNotFound = "STATEMENT_NOT_FOUND"
+	ErrCodeInvalidRequest    = "INVALID_STATEMENT_REQUEST"
+)
@@ -0,0 +1,97 @@
+package statement
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Generator generates the monthly statements due in the background.
+type Generator struct {
+	service  Service
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+// NewGenerator creates a new background generator checking at the policy interval.
+func NewGenerator(service Service, policy Policy, logger *zap.Logger) *Generator {
+	interval := policy.Interval
+	if interval <= 0 {
+		interval = DefaultPolicy().Interval
+	}
+	return &Generator{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run generates the statements due on every interval until the context is cancelled.
+func (g *Generator) Run(ctx context.Context) {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	for {
+		shared.Beat(ctx)
+		g.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick generates the statements due.
+func (g *Generator) tick(ctx context.Context) {
+	generated, err := g.service.GenerateDue(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			g.logger.Error("Failed to generate statements", zap.Int("generated", generated), zap.Error(err))
+		}
+		return
+	}
+	if generated > 0 {
+		g.logger.Info("Generated monthly statements", zap.Int("count", generated))
+	}
+}
+
+// RegisterGenerator runs the generator for the lifetime of the application. Nothing is started unless the policy
+// enables it.
+func RegisterGenerator(
+	lc fx.Lifecycle,
+	generator *Generator,
+	policy Policy,
+	workers shared.WorkerSupervisor,
+	logger *zap.Logger,
+) {
+	if !policy.Enabled {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			logger.Info("Starting statement generator", zap.Duration("interval", generator.interval))
+			go func() {
+				defer close(done)
+				workers.Supervise(ctx, "statement_generator", generator.interval, generator.Run)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,192 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Render renders a statement in a format. The business name heads PDF statements.
+func Render(statement *Statement, businessName string, format Format) ([]byte, error) {
+	switch format {
+	case FormatPDF:
+		return renderPDF(statement, businessName), nil
+	case FormatCSV:
+		return renderCSV(statement)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidRequest, format)
+	}
+}
+
+// csvHeader is the header row of CSV statements. Currency rows fill the amounts and invoice rows the count.
+var csvHeader = []string{"period", "type", "name", "gross_volume", "fees", "refunds", "net_settled", "count"}
+
+// renderCSV renders a statement as CSV: a row per currency followed by a row per invoice status.
+func renderCSV(statement *Statement) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	rows := [][]string{csvHeader}
+	for _, summary := range statement.Currencies {
+		rows = append(rows, []string{
+			statement.Period(), "currency", summary.Currency, summary.GrossVolume.String(), summary.Fees.String(),
+			summary.Refunds.String(), summary.NetSettled.String(), "",
+		})
+	}
+	for _, status := range statement.Statuses() {
+		rows = append(rows, []string{
+			statement.Period(), "invoices", status, "", "", "", "", strconv.Itoa(statement.InvoiceCounts[status]),
+		})
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to write CSV statement: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Layout of PDF statements, in points on an A4 page.
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 56
+	pdfLineHeight = 16
+)
+
+// pdfAmountColumns are the x positions of the columns of the currency table.
+var pdfAmountColumns = []float64{pdfMargin, 150, 250, 340, 430}
+
+// renderPDF renders a statement as a PDF document.
+func renderPDF(statement *Statement, businessName string) []byte {
+	doc := newPDFDocument()
+	doc.line(18, true, "Monthly statement")
+	if businessName != "" {
+		doc.line(12, true, businessName)
+	}
+	doc.line(10, false, "Merchant: "+statement.MerchantID)
+	doc.line(10, false, fmt.Sprintf("Period: %s (%s to %s)", statement.PeriodStart.Format("January 2006"),
+		statement.PeriodStart.Format(time.DateOnly), statement.PeriodEnd.AddDate(0, 0, -1).Format(time.DateOnly)))
+	doc.line(10, false, "Generated: "+statement.CreatedAt.UTC().Format("2006-01-02 15:04 MST"))
+	doc.skip()
+
+	doc.line(12, true, "Volume by currency")
+	doc.row(10, true, pdfAmountColumns, "Currency", "Gross volume", "Fees", "Refunds", "Net settled")
+	if len(statement.Currencies) == 0 {
+		doc.line(10, false, "No activity in this period.")
+	}
+	for _, summary := range statement.Currencies {
+		doc.row(10, false, pdfAmountColumns, summary.Currency, summary.GrossVolume.String(), summary.Fees.String(),
+			summary.Refunds.String(), summary.NetSettled.String())
+	}
+	doc.skip()
+
+	doc.line(12, true, "Invoices by status")
+	columns := []float64{pdfMargin, 250}
+	for _, status := range statement.Statuses() {
+		doc.row(10, false, columns, status, strconv.Itoa(statement.InvoiceCounts[status]))
+	}
+	doc.row(10, true, columns, "Total", strconv.Itoa(statement.InvoiceTotal()))
+	return doc.bytes()
+}
+
+// pdfDocument lays out lines of text top to bottom on as many pages as they need, in Helvetica.
+type pdfDocument struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+// newPDFDocument creates a document with an empty first page.
+func newPDFDocument() *pdfDocument {
+	doc := &pdfDocument{}
+	doc.newPage()
+	return doc
+}
+
+// newPage starts a page and moves to its top.
+func (d *pdfDocument) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// line writes a line of text at the left margin.
+func (d *pdfDocument) line(size float64, bold bool, text string) {
+	d.row(size, bold, []float64{pdfMargin}, text)
+}
+
+// row writes a line of cells, each at the x position of its column.
+func (d *pdfDocument) row(size float64, bold bool, columns []float64, cells ...string) {
+	height := pdfLineHeight + size - 10
+	if d.y-height < pdfMargin {
+		d.newPage()
+	}
+	d.y -= height
+
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	page := d.pages[len(d.pages)-1]
+	for i, cell := range cells {
+		fmt.Fprintf(page, "BT /%s %g Tf %g %g Td (%s) Tj ET\n", font, size, columns[i], d.y, pdfEscape(cell))
+	}
+}
+
+// skip leaves a blank line.
+func (d *pdfDocument) skip() {
+	d.y -= pdfLineHeight
+}
+
+// bytes writes the document: the catalog, the page tree, the two fonts, then each page with its content stream.
+func (d *pdfDocument) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// pdfEscape encodes text as the bytes of a PDF string in WinAnsiEncoding. Characters outside Latin-1 are
+// replaced, as the standard fonts cannot show them.
+func pdfEscape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || (r >= 0x7f && r < 0xa0) || r > 0xff:
+			b.WriteByte('?')
+		case r >= 0xa0:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
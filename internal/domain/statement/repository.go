@@ -0,0 +1,46 @@
+package statement
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for statement persistence.
+type Repository interface {
+	// Save stores a new statement.
+	Save(ctx context.Context, statement *Statement) error
+
+	// FindByID retrieves a statement of a merchant, returning ErrStatementNotFound when it has none with the ID.
+	FindByID(ctx context.Context, merchantID, id string) (*Statement, error)
+
+	// FindByPeriod retrieves a merchant's statement for the month starting at periodStart, returning
+	// ErrStatementNotFound when none was generated.
+	FindByPeriod(ctx context.Context, merchantID string, periodStart time.Time) (*Statement, error)
+
+	// List retrieves up to limit statements of a merchant, latest period first.
+	List(ctx context.Context, merchantID string, limit int) ([]*Statement, error)
+}
+
+// Ledger reads the activity statements are made of.
+type Ledger interface {
+	// Merchants returns the merchants with invoices, settlements or reversals in [from, to).
+	Merchants(ctx context.Context, from, to time.Time) ([]string, error)
+
+	// Summarize returns the activity of a merchant in [from, to).
+	Summarize(ctx context.Context, merchantID string, from, to time.Time) (*Summary, error)
+}
+
+// Storage keeps rendered statements, such as an object storage bucket.
+type Storage interface {
+	// Put stores an object under the key, replacing any object already there.
+	Put(ctx context.Context, key, contentType string, data []byte) error
+
+	// Get retrieves the object stored under the key.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// Notifier tells merchants a statement is ready.
+type Notifier interface {
+	// SendStatementReady emails a merchant that the statement can be downloaded.
+	SendStatementReady(ctx context.Context, email, businessName string, statement *Statement) error
+}
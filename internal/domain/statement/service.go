@@ -0,0 +1,286 @@
+package statement
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultListLimit is the default number of statements listed.
+	DefaultListLimit = 12
+	// MaxListLimit is the maximum number of statements listed.
+	MaxListLimit = 100
+)
+
+// Policy configures the generation of monthly statements.
+type Policy struct {
+	// Enabled generates every merchant's statement for the previous month once it is over.
+	Enabled bool
+	// Interval is how often the generator checks for statements due.
+	Interval time.Duration
+	// Email tells merchants by email when their statement is ready, besides the statement.generated event.
+	Email bool
+}
+
+// DefaultPolicy checks hourly for statements due and emails merchants, once enabled.
+func DefaultPolicy() Policy {
+	return Policy{
+		Interval: time.Hour,
+		Email:    true,
+	}
+}
+
+// File is a rendered statement.
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Service defines the interface for statement operations.
+type Service interface {
+	// GenerateDue generates the statements for the previous month of every merchant active in it that has
+	// none, and returns how many it generated.
+	GenerateDue(ctx context.Context) (int, error)
+
+	// Generate generates a merchant's statement for the month starting at periodStart, returning the existing one
+	// when it was already generated.
+	Generate(ctx context.Context, merchantID string, periodStart time.Time) (*Statement, error)
+
+	// GetStatement retrieves a statement of a merchant.
+	GetStatement(ctx context.Context, merchantID, id string) (*Statement, error)
+
+	// ListStatements lists a merchant's statements, latest period first.
+	ListStatements(ctx context.Context, merchantID string, limit int) ([]*Statement, error)
+
+	// Download retrieves a statement of a merchant rendered in a format.
+	Download(ctx context.Context, merchantID, id string, format Format) (*File, error)
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository Repository
+	ledger     Ledger
+	storage    Storage
+	merchants  merchant.MerchantRepository
+	notifier   Notifier
+	policy     Policy
+	eventBus   shared.EventBus
+	logger     *zap.Logger
+}
+
+// NewService creates a new statement service. The notifier may be nil, in which case no emails are sent.
+func NewService(
+	repository Repository,
+	ledger Ledger,
+	storage Storage,
+	merchants merchant.MerchantRepository,
+	notifier Notifier,
+	policy Policy,
+	eventBus shared.EventBus,
+	logger *zap.Logger,
+) Service {
+	if policy.Interval <= 0 {
+		policy.Interval = DefaultPolicy().Interval
+	}
+	return &ServiceImpl{
+		repository: repository,
+		ledger:     ledger,
+		storage:    storage,
+		merchants:  merchants,
+		notifier:   notifier,
+		policy:     policy,
+		eventBus:   eventBus,
+		logger:     logger,
+	}
+}
+
+// GenerateDue generates the previous month's statements still missing. A merchant whose statement fails is
+// logged and retried on the next run rather than holding up the others.
+func (s *ServiceImpl) GenerateDue(ctx context.Context) (int, error) {
+	periodEnd := MonthStart(shared.Now())
+	periodStart := periodEnd.AddDate(0, -1, 0)
+
+	merchantIDs, err := s.ledger.Merchants(ctx, periodStart, periodEnd)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list active merchants: %w", err)
+	}
+
+	generated := 0
+	for _, merchantID := range merchantIDs {
+		if _, err := s.repository.FindByPeriod(ctx, merchantID, periodStart); err == nil {
+			continue
+		} else if !errors.Is(err, ErrStatementNotFound) {
+			return generated, fmt.Errorf("failed to find statement: %w", err)
+		}
+
+		if _, err := s.generate(ctx, merchantID, periodStart); err != nil {
+			if ctx.Err() != nil {
+				return generated, ctx.Err()
+			}
+			s.logger.Error("Failed to generate statement",
+				zap.String("merchant_id", merchantID),
+				zap.Time("period_start", periodStart),
+				zap.Error(err))
+			continue
+		}
+		generated++
+	}
+	return generated, nil
+}
+
+// Generate generates a merchant's statement for a month that is over.
+func (s *ServiceImpl) Generate(ctx context.Context, merchantID string, periodStart time.Time) (*Statement, error) {
+	if merchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+	periodStart = MonthStart(periodStart)
+	if !periodStart.Before(MonthStart(shared.Now())) {
+		return nil, fmt.Errorf("%w: the month is not over yet", ErrInvalidRequest)
+	}
+
+	existing, err := s.repository.FindByPeriod(ctx, merchantID, periodStart)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, ErrStatementNotFound) {
+		return nil, fmt.Errorf("failed to find statement: %w", err)
+	}
+	return s.generate(ctx, merchantID, periodStart)
+}
+
+// generate summarizes a merchant's month, stores its renderings, saves the statement and announces it.
+func (s *ServiceImpl) generate(ctx context.Context, merchantID string, periodStart time.Time) (*Statement, error) {
+	m, err := s.merchants.FindByID(ctx, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merchant: %w", err)
+	}
+
+	statement := &Statement{
+		ID:          uuid.New().String(),
+		MerchantID:  merchantID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodStart.AddDate(0, 1, 0),
+		Files:       make(map[Format]string),
+		CreatedAt:   shared.Now().UTC(),
+	}
+	summary, err := s.ledger.Summarize(ctx, merchantID, statement.PeriodStart, statement.PeriodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize merchant activity: %w", err)
+	}
+	statement.Summary = *summary
+
+	for _, format := range Formats() {
+		data, err := Render(statement, m.BusinessName(), format)
+		if err != nil {
+			return nil, err
+		}
+		key := fileKey(merchantID, periodStart, format)
+		if err := s.storage.Put(ctx, key, format.ContentType(), data); err != nil {
+			return nil, fmt.Errorf("failed to store %s statement: %w", format, err)
+		}
+		statement.Files[format] = key
+	}
+
+	if err := s.repository.Save(ctx, statement); err != nil {
+		return nil, fmt.Errorf("failed to save statement: %w", err)
+	}
+	s.logger.Info("Generated statement",
+		zap.String("statement_id", statement.ID),
+		zap.String("merchant_id", merchantID),
+		zap.String("period", statement.Period()))
+
+	s.publish(ctx, statement)
+	s.notify(ctx, m, statement)
+	return statement, nil
+}
+
+// GetStatement retrieves a statement of a merchant.
+func (s *ServiceImpl) GetStatement(ctx context.Context, merchantID, id string) (*Statement, error) {
+	if merchantID == "" || id == "" {
+		return nil, fmt.Errorf("%w: merchant and statement IDs are required", ErrInvalidRequest)
+	}
+	return s.repository.FindByID(ctx, merchantID, id)
+}
+
+// ListStatements lists a merchant's statements.
+func (s *ServiceImpl) ListStatements(ctx context.Context, merchantID string, limit int) ([]*Statement, error) {
+	if merchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	if limit > MaxListLimit {
+		return nil, fmt.Errorf("%w: limit cannot exceed %d", ErrInvalidRequest, MaxListLimit)
+	}
+	return s.repository.List(ctx, merchantID, limit)
+}
+
+// Download retrieves a statement of a merchant rendered in a format from storage.
+func (s *ServiceImpl) Download(ctx context.Context, merchantID, id string, format Format) (*File, error) {
+	if !format.IsValid() {
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidRequest, format)
+	}
+	statement, err := s.GetStatement(ctx, merchantID, id)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := statement.Files[format]
+	if !ok {
+		return nil, fmt.Errorf("%w: no %s file", ErrStatementNotFound, format)
+	}
+
+	data, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s statement: %w", format, err)
+	}
+	return &File{Name: statement.FileName(format), ContentType: format.ContentType(), Data: data}, nil
+}
+
+// publish publishes the statement.generated event, which is delivered to the merchant's webhooks.
+func (s *ServiceImpl) publish(ctx context.Context, statement *Statement) {
+	if s.eventBus == nil {
+		return
+	}
+	formats := make([]string, 0, len(statement.Files))
+	for _, format := range Formats() {
+		if _, ok := statement.Files[format]; ok {
+			formats = append(formats, string(format))
+		}
+	}
+	event := shared.NewStatementGeneratedEvent(shared.StatementEvent{
+		StatementID: statement.ID,
+		MerchantID:  statement.MerchantID,
+		Period:      statement.Period(),
+		PeriodStart: statement.PeriodStart,
+		PeriodEnd:   statement.PeriodEnd,
+		Formats:     formats,
+	})
+	if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+		s.logger.Error("Failed to publish domain event",
+			zap.String("event_type", event.EventType),
+			zap.String("aggregate_id", event.AggregateID),
+			zap.Error(err))
+	}
+}
+
+// notify emails the merchant that the statement is ready. Failures are logged; the statement stays available.
+func (s *ServiceImpl) notify(ctx context.Context, m *merchant.Merchant, statement *Statement) {
+	if s.notifier == nil || !s.policy.Email || m.ContactEmail() == "" {
+		return
+	}
+	if err := s.notifier.SendStatementReady(ctx, m.ContactEmail(), m.BusinessName(), statement); err != nil {
+		s.logger.Warn("Failed to email statement",
+			zap.String("statement_id", statement.ID),
+			zap.String("merchant_id", statement.MerchantID),
+			zap.Error(err))
+	}
+}
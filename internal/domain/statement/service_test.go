@@ -0,0 +1,262 @@
+package statement
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/shared"
+	"encoding/csv"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubRepository keeps statements in memory.
+type stubRepository struct {
+	statements []*Statement
+}
+
+func (r *stubRepository) Save(_ context.Context, statement *Statement) error {
+	r.statements = append(r.statements, statement)
+	return nil
+}
+
+func (r *stubRepository) FindByID(_ context.Context, merchantID, id string) (*Statement, error) {
+	for _, statement := range r.statements {
+		if statement.MerchantID == merchantID && statement.ID == id {
+			return statement, nil
+		}
+	}
+	return nil, ErrStatementNotFound
+}
+
+func (r *stubRepository) FindByPeriod(_ context.Context, merchantID string, periodStart time.Time) (*Statement, error) {
+	for _, statement := range r.statements {
+		if statement.MerchantID == merchantID && statement.PeriodStart.Equal(periodStart) {
+			return statement, nil
+		}
+	}
+	return nil, ErrStatementNotFound
+}
+
+func (r *stubRepository) List(_ context.Context, merchantID string, limit int) ([]*Statement, error) {
+	var statements []*Statement
+	for i := len(r.statements) - 1; i >= 0 && len(statements) < limit; i-- {
+		if r.statements[i].MerchantID == merchantID {
+			statements = append(statements, r.statements[i])
+		}
+	}
+	return statements, nil
+}
+
+// stubLedger summarizes every merchant alike; merchants listed in failing fail.
+type stubLedger struct {
+	merchants []string
+	summary   Summary
+	failing   map[string]bool
+	from, to  time.Time
+}
+
+func (l *stubLedger) Merchants(_ context.Context, from, to time.Time) ([]string, error) {
+	l.from, l.to = from, to
+	return l.merchants, nil
+}
+
+func (l *stubLedger) Summarize(_ context.Context, merchantID string, _, _ time.Time) (*Summary, error) {
+	if l.failing[merchantID] {
+		return nil, errors.New("database unavailable")
+	}
+	summary := l.summary
+	return &summary, nil
+}
+
+// memoryStorage keeps objects in memory.
+type memoryStorage struct {
+	objects map[string][]byte
+}
+
+func (s *memoryStorage) Put(_ context.Context, key, _ string, data []byte) error {
+	s.objects[key] = data
+	return nil
+}
+
+func (s *memoryStorage) Get(_ context.Context, key string) ([]byte, error) {
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, errors.New("object not found")
+	}
+	return data, nil
+}
+
+// stubMerchants finds merchants by ID; only FindByID is implemented.
+type stubMerchants struct {
+	merchant.MerchantRepository
+}
+
+func (stubMerchants) FindByID(_ context.Context, id string) (*merchant.Merchant, error) {
+	return merchant.NewMerchant(id, "Acme & Sons", id+"@example.com", &merchant.MerchantSettings{})
+}
+
+// recordingNotifier records the emails sent.
+type recordingNotifier struct {
+	emails []string
+}
+
+func (n *recordingNotifier) SendStatementReady(_ context.Context, email, _ string, _ *Statement) error {
+	n.emails = append(n.emails, email)
+	return nil
+}
+
+// recordingEventBus records the events published; only publishing is implemented.
+type recordingEventBus struct {
+	shared.EventBus
+	events []*shared.BaseDomainEvent
+}
+
+func (b *recordingEventBus) PublishEvent(_ context.Context, event *shared.BaseDomainEvent) error {
+	b.events = append(b.events, event)
+	return nil
+}
+
+// fixedClock tells a fixed time.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+type testService struct {
+	Service
+	repository *stubRepository
+	ledger     *stubLedger
+	storage    *memoryStorage
+	notifier   *recordingNotifier
+	bus        *recordingEventBus
+}
+
+func newTestService(t *testing.T) *testService {
+	t.Helper()
+	t.Cleanup(shared.SetClock(fixedClock(time.Date(2026, 10, 3, 2, 0, 0, 0, time.UTC))))
+
+	ts := &testService{
+		repository: &stubRepository{},
+		ledger: &stubLedger{summary: Summary{
+			Currencies: []CurrencySummary{{
+				Currency:    "USDT",
+				GrossVolume: decimal.RequireFromString("1500"),
+				Fees:        decimal.RequireFromString("15"),
+				Refunds:     decimal.RequireFromString("40"),
+				NetSettled:  decimal.RequireFromString("1445"),
+			}},
+			InvoiceCounts: map[string]int{"paid": 3, "expired": 1},
+		}},
+		storage:  &memoryStorage{objects: make(map[string][]byte)},
+		notifier: &recordingNotifier{},
+		bus:      &recordingEventBus{},
+	}
+	ts.Service = NewService(ts.repository, ts.ledger, ts.storage, stubMerchants{}, ts.notifier, DefaultPolicy(),
+		ts.bus, zap.NewNop())
+	return ts
+}
+
+func TestServiceGenerateDue(t *testing.T) {
+	ts := newTestService(t)
+	ts.ledger.merchants = []string{"m-1", "m-2", "m-3"}
+	ts.ledger.failing = map[string]bool{"m-3": true}
+
+	generated, err := ts.GenerateDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, generated)
+	assert.Equal(t, time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), ts.ledger.from)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), ts.ledger.to)
+
+	require.Len(t, ts.repository.statements, 2)
+	stmt := ts.repository.statements[0]
+	assert.Equal(t, "2026-09", stmt.Period())
+	assert.Equal(t, map[Format]string{
+		FormatPDF: "statements/m-1/2026-09.pdf",
+		FormatCSV: "statements/m-1/2026-09.csv",
+	}, stmt.Files)
+	assert.Len(t, ts.storage.objects, 4)
+	assert.Equal(t, []string{"m-1@example.com", "m-2@example.com"}, ts.notifier.emails)
+
+	require.Len(t, ts.bus.events, 2)
+	assert.Equal(t, shared.EventTypeStatementGenerated, ts.bus.events[0].EventType)
+	data, ok := ts.bus.events[0].EventData.(shared.StatementEvent)
+	require.True(t, ok)
+	assert.Equal(t, "2026-09", data.Period)
+	assert.Equal(t, []string{"pdf", "csv"}, data.Formats)
+
+	// A second run only retries the merchant that failed.
+	ts.ledger.failing = nil
+	generated, err = ts.GenerateDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, generated)
+	assert.Len(t, ts.repository.statements, 3)
+}
+
+func TestServiceGenerate(t *testing.T) {
+	ts := newTestService(t)
+	ctx := context.Background()
+
+	_, err := ts.Generate(ctx, "m-1", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	require.ErrorIs(t, err, ErrInvalidRequest)
+
+	stmt, err := ts.Generate(ctx, "m-1", time.Date(2026, 8, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), stmt.PeriodStart)
+	assert.Equal(t, time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), stmt.PeriodEnd)
+
+	again, err := ts.Generate(ctx, "m-1", time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, stmt.ID, again.ID)
+	assert.Len(t, ts.repository.statements, 1)
+	assert.Len(t, ts.bus.events, 1)
+}
+
+func TestServiceDownload(t *testing.T) {
+	ts := newTestService(t)
+	ctx := context.Background()
+	stmt, err := ts.Generate(ctx, "m-1", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	file, err := ts.Download(ctx, "m-1", stmt.ID, FormatPDF)
+	require.NoError(t, err)
+	assert.Equal(t, "statement-2026-09.pdf", file.Name)
+	assert.Equal(t, "application/pdf", file.ContentType)
+	assert.True(t, bytes.HasPrefix(file.Data, []byte("%PDF-")))
+
+	file, err = ts.Download(ctx, "m-1", stmt.ID, FormatCSV)
+	require.NoError(t, err)
+	assert.Equal(t, "statement-2026-09.csv", file.Name)
+	rows, err := csv.NewReader(bytes.NewReader(file.Data)).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"period", "type", "name", "gross_volume", "fees", "refunds", "net_settled", "count"},
+		{"2026-09", "currency", "USDT", "1500", "15", "40", "1445", ""},
+		{"2026-09", "invoices", "expired", "", "", "", "", "1"},
+		{"2026-09", "invoices", "paid", "", "", "", "", "3"},
+	}, rows)
+
+	_, err = ts.Download(ctx, "m-2", stmt.ID, FormatPDF)
+	require.ErrorIs(t, err, ErrStatementNotFound)
+	_, err = ts.Download(ctx, "m-1", stmt.ID, Format("xlsx"))
+	require.ErrorIs(t, err, ErrInvalidRequest)
+}
+
+func TestRenderPDFEscapesText(t *testing.T) {
+	stmt := &Statement{
+		MerchantID:  "m-1",
+		PeriodStart: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+	data, err := Render(stmt, `Café (Berlin) \ Bär ☕`, FormatPDF)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+	assert.True(t, bytes.HasSuffix(bytes.TrimSpace(data), []byte("%%EOF")))
+	assert.Contains(t, string(data), `(Caf\351 \(Berlin\) \\ B\344r ?)`)
+	assert.Contains(t, string(data), "No activity in this period.")
+}
@@ -0,0 +1,119 @@
+// Package statement generates the monthly statements of merchants: their volume, fees, refunds and settlements by
+// currency and their invoices by status, rendered as PDF and CSV files kept in object storage.
+package statement
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Format is the file format a statement is rendered in.
+type Format string
+
+const (
+	// FormatPDF is a printable statement.
+	FormatPDF Format = "pdf"
+	// FormatCSV is a statement for spreadsheets and accounting tools.
+	FormatCSV Format = "csv"
+)
+
+// Formats returns the formats every statement is rendered in.
+func Formats() []Format {
+	return []Format{FormatPDF, FormatCSV}
+}
+
+// IsValid checks if the format is supported.
+func (f Format) IsValid() bool {
+	return f == FormatPDF || f == FormatCSV
+}
+
+// ContentType returns the media type of files of the format.
+func (f Format) ContentType() string {
+	if f == FormatPDF {
+		return "application/pdf"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// CurrencySummary is the activity of a merchant in one cryptocurrency over a statement period.
+type CurrencySummary struct {
+	Currency string
+	// GrossVolume is what the merchant's settlements created in the period received from customers.
+	GrossVolume decimal.Decimal
+	// Fees are the platform fees of those settlements.
+	Fees decimal.Decimal
+	// Refunds are the refunds recorded on the merchant's invoices in the period.
+	Refunds decimal.Decimal
+	// NetSettled is what settlements completed in the period credited, less the reversals recorded in it.
+	NetSettled decimal.Decimal
+}
+
+// Summary is the activity of a merchant over a statement period.
+type Summary struct {
+	Currencies []CurrencySummary
+	// InvoiceCounts counts the invoices created in the period by their current status.
+	InvoiceCounts map[string]int
+}
+
+// Statement is the monthly statement of a merchant.
+type Statement struct {
+	ID         string
+	MerchantID string
+	// PeriodStart is the first instant of the month covered, in UTC; PeriodEnd is the first instant of the next.
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Summary
+	// Files are the storage keys of the rendered statement by format.
+	Files     map[Format]string
+	CreatedAt time.Time
+}
+
+// Period returns the month the statement covers, e.g. "2026-09".
+func (s *Statement) Period() string {
+	return s.PeriodStart.Format(periodLayout)
+}
+
+// FileName returns the name a rendered statement is downloaded as.
+func (s *Statement) FileName(format Format) string {
+	return "statement-" + s.Period() + "." + string(format)
+}
+
+// InvoiceTotal returns the number of invoices created in the period.
+func (s *Statement) InvoiceTotal() int {
+	total := 0
+	for _, count := range s.InvoiceCounts {
+		total += count
+	}
+	return total
+}
+
+// Statuses returns the invoice statuses counted, sorted.
+func (s *Statement) Statuses() []string {
+	statuses := make([]string, 0, len(s.InvoiceCounts))
+	for status := range s.InvoiceCounts {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	return statuses
+}
+
+// periodLayout formats statement periods.
+const periodLayout = "2006-01"
+
+// MonthStart returns the first instant of the month of t, in UTC.
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// ParsePeriod parses a month such as "2026-09" into its first instant.
+func ParsePeriod(period string) (time.Time, error) {
+	return time.Parse(periodLayout, period)
+}
+
+// fileKey returns the storage key of a merchant's statement for a month in a format.
+func fileKey(merchantID string, periodStart time.Time, format Format) string {
+	return "statements/" + merchantID + "/" + periodStart.Format(periodLayout) + "." + string(format)
+}
@@ -0,0 +1,15 @@
+package tax
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the tax service layer dependencies.
+var Module = fx.Module("tax-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+)
@@ -0,0 +1,18 @@
+package tax
+
+import "errors"
+
+// Domain errors for tax rule operations
+var (
+	ErrRuleNotFound   = errors.New("tax rule not found")
+	ErrRuleExists     = errors.New("a tax rule with this name already exists for the jurisdiction and category")
+	ErrInvalidTaxID   = errors.New("invalid customer tax ID")
+	ErrInvalidRequest = errors.New("invalid tax request")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeRuleNotFound = "TAX_RULE_NOT_FOUND"
+	ErrCodeRuleExists   = "TAX_RULE_EXISTS"
+	ErrCodeInvalidTaxID = "INVALID_TAX_ID"
+)
@@ -0,0 +1,22 @@
+package tax
+
+import "context"
+
+// Repository defines the interface for tax rule persistence.
+type Repository interface {
+	// Save persists a new rule, returning ErrRuleExists if the merchant already has a rule with
+	// the same jurisdiction, category and name.
+	Save(ctx context.Context, rule *Rule) error
+
+	// FindByID retrieves a rule by its ID.
+	FindByID(ctx context.Context, id string) (*Rule, error)
+
+	// ListByMerchant retrieves a merchant's rules ordered by jurisdiction, category and name.
+	ListByMerchant(ctx context.Context, merchantID string) ([]*Rule, error)
+
+	// Update updates an existing rule.
+	Update(ctx context.Context, rule *Rule) error
+
+	// Delete removes a rule.
+	Delete(ctx context.Context, id string) error
+}
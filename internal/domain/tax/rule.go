@@ -0,0 +1,187 @@
+// Package tax provides merchant-configured tax rules that determine the taxes levied on invoices
+// for each customer jurisdiction.
+package tax
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// jurisdictionPattern accepts ISO 3166-1 country codes and ISO 3166-2 subdivisions, e.g. "DE" or "US-CA".
+	jurisdictionPattern = regexp.MustCompile(`^[A-Z]{2}(-[A-Z0-9]{1,3})?$`)
+	// taxIDPattern accepts VAT, GST and similar registration numbers once separators are removed.
+	taxIDPattern = regexp.MustCompile(`^[A-Z0-9]{4,20}$`)
+)
+
+// Rule is a tax a merchant charges customers in a jurisdiction on items of a tax category.
+// Rules of a country also apply to its subdivisions, so a "US" rule and a "US-CA" rule
+// both tax a customer in California.
+type Rule struct {
+	id            string
+	merchantID    string
+	jurisdiction  string
+	category      string
+	name          string
+	taxType       invoice.TaxType
+	rate          string
+	reverseCharge bool
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+// NewRule creates a new tax rule. The rate is a decimal fraction, e.g. "0.19" for 19%.
+// Reverse-charge rules charge no tax when the customer gives a tax ID, as for cross-border
+// B2B supplies within the EU.
+func NewRule(
+	id, merchantID, jurisdiction, category, name string,
+	taxType invoice.TaxType,
+	rate string,
+	reverseCharge bool,
+) (*Rule, error) {
+	category, err := invoice.NormalizeTaxCategory(category)
+	if err != nil {
+		return nil, err
+	}
+
+	now := shared.Now().UTC()
+	return RestoreRule(
+		id, merchantID, NormalizeJurisdiction(jurisdiction), category, strings.TrimSpace(name),
+		taxType, rate, reverseCharge, now, now,
+	)
+}
+
+// RestoreRule recreates a tax rule from storage.
+func RestoreRule(
+	id, merchantID, jurisdiction, category, name string,
+	taxType invoice.TaxType,
+	rate string,
+	reverseCharge bool,
+	createdAt, updatedAt time.Time,
+) (*Rule, error) {
+	if id == "" {
+		return nil, errors.New("tax rule ID is required")
+	}
+	if merchantID == "" {
+		return nil, errors.New("merchant ID is required")
+	}
+	if !jurisdictionPattern.MatchString(jurisdiction) {
+		return nil, errors.New("jurisdiction must be a country code or country subdivision, e.g. DE or US-CA")
+	}
+
+	rule := &Rule{
+		id:            id,
+		merchantID:    merchantID,
+		jurisdiction:  jurisdiction,
+		category:      category,
+		name:          name,
+		taxType:       taxType,
+		rate:          rate,
+		reverseCharge: reverseCharge,
+		createdAt:     createdAt,
+		updatedAt:     updatedAt,
+	}
+
+	// Validates the name, type and rate
+	if _, err := rule.ToTaxRate(false); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// NormalizeJurisdiction returns the canonical form of a jurisdiction code.
+func NormalizeJurisdiction(jurisdiction string) string {
+	return strings.ToUpper(strings.TrimSpace(jurisdiction))
+}
+
+// NormalizeTaxID returns the canonical form of a customer tax ID, without spaces, dots or dashes.
+func NormalizeTaxID(taxID string) (string, error) {
+	normalized := strings.NewReplacer(" ", "", ".", "", "-", "").Replace(strings.ToUpper(taxID))
+	if normalized == "" {
+		return "", nil
+	}
+	if !taxIDPattern.MatchString(normalized) {
+		return "", ErrInvalidTaxID
+	}
+	return normalized, nil
+}
+
+// ID returns the rule ID.
+func (r *Rule) ID() string {
+	return r.id
+}
+
+// MerchantID returns the merchant the rule belongs to.
+func (r *Rule) MerchantID() string {
+	return r.merchantID
+}
+
+// Jurisdiction returns the country or subdivision the rule applies to.
+func (r *Rule) Jurisdiction() string {
+	return r.jurisdiction
+}
+
+// Category returns the item tax category the rule applies to.
+func (r *Rule) Category() string {
+	return r.category
+}
+
+// Name returns the name shown on invoices.
+func (r *Rule) Name() string {
+	return r.name
+}
+
+// Type returns the tax type.
+func (r *Rule) Type() invoice.TaxType {
+	return r.taxType
+}
+
+// Rate returns the tax rate as a decimal fraction.
+func (r *Rule) Rate() string {
+	return r.rate
+}
+
+// IsReverseCharge returns true if customers with a tax ID account for the tax themselves.
+func (r *Rule) IsReverseCharge() bool {
+	return r.reverseCharge
+}
+
+// CreatedAt returns when the rule was created.
+func (r *Rule) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// UpdatedAt returns when the rule was last changed.
+func (r *Rule) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// AppliesTo returns true if the rule taxes customers in the jurisdiction.
+func (r *Rule) AppliesTo(jurisdiction string) bool {
+	return r.jurisdiction == jurisdiction || strings.HasPrefix(jurisdiction, r.jurisdiction+"-")
+}
+
+// ToTaxRate returns the rule as an invoice tax rate, reverse charged if the rule allows it
+// and the customer is a business.
+func (r *Rule) ToTaxRate(businessCustomer bool) (*invoice.TaxRate, error) {
+	return invoice.NewTaxRate(r.name, r.taxType, r.jurisdiction, r.rate, r.reverseCharge && businessCustomer)
+}
+
+// Update changes the name, rate and reverse-charge setting of the rule.
+func (r *Rule) Update(name, rate string, reverseCharge bool) error {
+	name = strings.TrimSpace(name)
+	if _, err := invoice.NewTaxRate(name, r.taxType, r.jurisdiction, rate, reverseCharge); err != nil {
+		return err
+	}
+
+	r.name = name
+	r.rate = rate
+	r.reverseCharge = reverseCharge
+	r.updatedAt = shared.Now().UTC()
+	return nil
+}
@@ -0,0 +1,61 @@
+package tax
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRule_AppliesTo(t *testing.T) {
+	country, err := NewRule("rule-1", "merchant-1", "us", "", "Federal Excise", invoice.TaxTypeSalesTax, "0.01", false)
+	require.NoError(t, err)
+	assert.Equal(t, "US", country.Jurisdiction())
+	assert.Equal(t, invoice.DefaultTaxCategory, country.Category())
+
+	assert.True(t, country.AppliesTo("US"))
+	assert.True(t, country.AppliesTo("US-CA"))
+	assert.False(t, country.AppliesTo("USA"))
+	assert.False(t, country.AppliesTo("CA"))
+
+	state, err := NewRule("rule-2", "merchant-1", "US-CA", "", "CA Sales Tax", invoice.TaxTypeSalesTax, "0.0725", false)
+	require.NoError(t, err)
+	assert.True(t, state.AppliesTo("US-CA"))
+	assert.False(t, state.AppliesTo("US"))
+}
+
+func TestNewRule_Validation(t *testing.T) {
+	_, err := NewRule("rule-1", "merchant-1", "Germany", "", "VAT", invoice.TaxTypeVAT, "0.19", false)
+	require.Error(t, err)
+
+	_, err = NewRule("rule-1", "merchant-1", "DE", "", "VAT", invoice.TaxTypeVAT, "19", false)
+	require.ErrorIs(t, err, invoice.ErrInvalidTax)
+
+	_, err = NewRule("rule-1", "merchant-1", "DE", "Not Valid!", "VAT", invoice.TaxTypeVAT, "0.19", false)
+	require.ErrorIs(t, err, invoice.ErrInvalidTax)
+
+	rule, err := NewRule("rule-1", "merchant-1", "DE", "", "VAT", invoice.TaxTypeVAT, "0.19", true)
+	require.NoError(t, err)
+
+	rate, err := rule.ToTaxRate(false)
+	require.NoError(t, err)
+	assert.False(t, rate.IsReverseCharge())
+
+	rate, err = rule.ToTaxRate(true)
+	require.NoError(t, err)
+	assert.True(t, rate.IsReverseCharge())
+}
+
+func TestNormalizeTaxID(t *testing.T) {
+	taxID, err := NormalizeTaxID(" de-123.456.789 ")
+	require.NoError(t, err)
+	assert.Equal(t, "DE123456789", taxID)
+
+	taxID, err = NormalizeTaxID("")
+	require.NoError(t, err)
+	assert.Empty(t, taxID)
+
+	_, err = NormalizeTaxID("DE!")
+	require.ErrorIs(t, err, ErrInvalidTaxID)
+}
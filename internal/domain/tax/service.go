@@ -0,0 +1,210 @@
+package tax
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+// Service defines the interface for managing tax rules and resolving the taxes of an invoice.
+type Service interface {
+	// CreateRule creates a tax rule for a merchant.
+	CreateRule(ctx context.Context, req *CreateRuleRequest) (*Rule, error)
+
+	// ListRules lists a merchant's tax rules.
+	ListRules(ctx context.Context, merchantID string) ([]*Rule, error)
+
+	// GetRule retrieves a merchant's tax rule.
+	GetRule(ctx context.Context, merchantID, ruleID string) (*Rule, error)
+
+	// UpdateRule changes the name, rate and reverse-charge setting of a merchant's tax rule.
+	UpdateRule(ctx context.Context, req *UpdateRuleRequest) (*Rule, error)
+
+	// DeleteRule removes a merchant's tax rule. Invoices already taxed by it keep their tax lines.
+	DeleteRule(ctx context.Context, merchantID, ruleID string) error
+
+	// ResolvePolicy returns the taxes the merchant charges a customer in the jurisdiction.
+	// A customer tax ID marks a business customer and enables reverse charge.
+	ResolvePolicy(ctx context.Context, merchantID, jurisdiction, customerTaxID string) (*invoice.TaxPolicy, error)
+}
+
+// CreateRuleRequest represents the request to create a tax rule.
+type CreateRuleRequest struct {
+	MerchantID    string          `validate:"required"`
+	Jurisdiction  string          `validate:"required"`
+	Category      string          // Defaults to invoice.DefaultTaxCategory
+	Name          string          `validate:"required,max=64"`
+	Type          invoice.TaxType `validate:"required"`
+	Rate          string          `validate:"required"`
+	ReverseCharge bool
+}
+
+// UpdateRuleRequest represents the request to update a tax rule.
+type UpdateRuleRequest struct {
+	MerchantID    string `validate:"required"`
+	RuleID        string `validate:"required"`
+	Name          string `validate:"required,max=64"`
+	Rate          string `validate:"required"`
+	ReverseCharge bool
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+// NewService creates a new tax service.
+func NewService(repository Repository, logger *zap.Logger) Service {
+	return &ServiceImpl{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// CreateRule creates a tax rule for a merchant.
+func (s *ServiceImpl) CreateRule(ctx context.Context, req *CreateRuleRequest) (*Rule, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: create tax rule request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate tax rule ID: %w", err)
+	}
+
+	rule, err := NewRule(id, req.MerchantID, req.Jurisdiction, req.Category, req.Name, req.Type, req.Rate, req.ReverseCharge)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	if err := s.repository.Save(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Tax rule created",
+		zap.String("rule_id", rule.ID()),
+		zap.String("merchant_id", rule.MerchantID()),
+		zap.String("jurisdiction", rule.Jurisdiction()),
+		zap.String("category", rule.Category()))
+
+	return rule, nil
+}
+
+// ListRules lists a merchant's tax rules.
+func (s *ServiceImpl) ListRules(ctx context.Context, merchantID string) ([]*Rule, error) {
+	if merchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+
+	return s.repository.ListByMerchant(ctx, merchantID)
+}
+
+// GetRule retrieves a merchant's tax rule.
+func (s *ServiceImpl) GetRule(ctx context.Context, merchantID, ruleID string) (*Rule, error) {
+	if merchantID == "" || ruleID == "" {
+		return nil, fmt.Errorf("%w: merchant ID and rule ID are required", ErrInvalidRequest)
+	}
+
+	rule, err := s.repository.FindByID(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule.MerchantID() != merchantID {
+		return nil, ErrRuleNotFound
+	}
+
+	return rule, nil
+}
+
+// UpdateRule changes the name, rate and reverse-charge setting of a merchant's tax rule.
+// Invoices already taxed by the rule keep their tax lines.
+func (s *ServiceImpl) UpdateRule(ctx context.Context, req *UpdateRuleRequest) (*Rule, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: update tax rule request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	rule, err := s.GetRule(ctx, req.MerchantID, req.RuleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rule.Update(req.Name, req.Rate, req.ReverseCharge); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	if err := s.repository.Update(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// DeleteRule removes a merchant's tax rule.
+func (s *ServiceImpl) DeleteRule(ctx context.Context, merchantID, ruleID string) error {
+	rule, err := s.GetRule(ctx, merchantID, ruleID)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.Delete(ctx, rule.ID())
+}
+
+// ResolvePolicy returns the taxes the merchant charges a customer in the jurisdiction.
+// Country rules apply before subdivision rules, so a customer in "CA-BC" is charged GST and then PST.
+func (s *ServiceImpl) ResolvePolicy(
+	ctx context.Context,
+	merchantID, jurisdiction, customerTaxID string,
+) (*invoice.TaxPolicy, error) {
+	jurisdiction = NormalizeJurisdiction(jurisdiction)
+	if merchantID == "" || !jurisdictionPattern.MatchString(jurisdiction) {
+		return nil, fmt.Errorf("%w: merchant ID and a valid jurisdiction are required", ErrInvalidRequest)
+	}
+
+	taxID, err := NormalizeTaxID(customerTaxID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.repository.ListByMerchant(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(map[string][]*invoice.TaxRate)
+	for _, applyCountry := range []bool{true, false} {
+		for _, rule := range rules {
+			isCountry := len(rule.Jurisdiction()) == 2
+			if isCountry != applyCountry || !rule.AppliesTo(jurisdiction) {
+				continue
+			}
+
+			rate, err := rule.ToTaxRate(taxID != "")
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert tax rule %s: %w", rule.ID(), err)
+			}
+			rates[rule.Category()] = append(rates[rule.Category()], rate)
+		}
+	}
+
+	return invoice.NewTaxPolicy(jurisdiction, taxID, rates)
+}
+
+func generateID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,105 @@
+package treasury
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Thresholds bound the hot wallet balance of a currency. A zero threshold is not checked.
+type Thresholds struct {
+	Low  decimal.Decimal
+	High decimal.Decimal
+}
+
+// Level returns where an amount stands against the thresholds.
+func (t Thresholds) Level(amount decimal.Decimal) BalanceLevel {
+	switch {
+	case t.Low.IsPositive() && amount.LessThan(t.Low):
+		return BalanceLevelLow
+	case t.High.IsPositive() && amount.GreaterThan(t.High):
+		return BalanceLevelHigh
+	default:
+		return BalanceLevelNormal
+	}
+}
+
+// Balance is the last known hot wallet balance of a currency on its network.
+type Balance struct {
+	network   shared.BlockchainNetwork
+	currency  string
+	amount    decimal.Decimal
+	level     BalanceLevel
+	checkedAt time.Time
+}
+
+// NewBalance creates the first record of a hot wallet balance.
+func NewBalance(
+	network shared.BlockchainNetwork,
+	currency string,
+	amount decimal.Decimal,
+	thresholds Thresholds,
+) (*Balance, error) {
+	return RestoreBalance(network, currency, amount, thresholds.Level(amount), shared.Now().UTC())
+}
+
+// RestoreBalance recreates a balance from storage.
+func RestoreBalance(
+	network shared.BlockchainNetwork,
+	currency string,
+	amount decimal.Decimal,
+	level BalanceLevel,
+	checkedAt time.Time,
+) (*Balance, error) {
+	if network == "" || currency == "" {
+		return nil, errors.New("balance network and currency are required")
+	}
+	if !level.IsValid() {
+		return nil, errors.New("invalid balance level")
+	}
+
+	return &Balance{
+		network:   network,
+		currency:  currency,
+		amount:    amount,
+		level:     level,
+		checkedAt: checkedAt,
+	}, nil
+}
+
+// Network returns the network the balance is held on.
+func (b *Balance) Network() shared.BlockchainNetwork {
+	return b.network
+}
+
+// Currency returns the currency of the balance.
+func (b *Balance) Currency() string {
+	return b.currency
+}
+
+// Amount returns the balance.
+func (b *Balance) Amount() decimal.Decimal {
+	return b.amount
+}
+
+// Level returns where the balance stood against its thresholds when last checked.
+func (b *Balance) Level() BalanceLevel {
+	return b.level
+}
+
+// CheckedAt returns when the balance was last checked.
+func (b *Balance) CheckedAt() time.Time {
+	return b.checkedAt
+}
+
+// Record stores a newly checked amount and reports whether its level changed, so an alert is
+// raised once when a threshold is crossed rather than on every check.
+func (b *Balance) Record(amount decimal.Decimal, thresholds Thresholds) bool {
+	previous := b.level
+	b.amount = amount
+	b.level = thresholds.Level(amount)
+	b.checkedAt = shared.Now().UTC()
+	return b.level != previous
+}
@@ -0,0 +1,246 @@
+package treasury
+
+import (
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ColdTransfer moves funds from the hot wallet to cold storage. One operator requests it and a
+// different operator must approve it before anything is sent.
+type ColdTransfer struct {
+	id            string
+	network       shared.BlockchainNetwork
+	currency      string
+	toAddress     string
+	amount        decimal.Decimal
+	note          string
+	status        TransferStatus
+	requestedBy   string
+	reviewedBy    string
+	reviewNote    string
+	txHash        string
+	failureReason string
+	createdAt     time.Time
+	reviewedAt    *time.Time
+	broadcastAt   *time.Time
+	confirmedAt   *time.Time
+}
+
+// NewColdTransfer creates a transfer awaiting approval.
+func NewColdTransfer(
+	id string,
+	network shared.BlockchainNetwork,
+	currency, toAddress string,
+	amount decimal.Decimal,
+	note, requestedBy string,
+) (*ColdTransfer, error) {
+	if requestedBy == "" {
+		return nil, fmt.Errorf("%w: the requesting operator is required", ErrInvalidRequest)
+	}
+	if _, err := shared.NewPaymentAddress(toAddress, network); err != nil {
+		return nil, fmt.Errorf("%w: cold storage address: %w", ErrInvalidRequest, err)
+	}
+
+	return RestoreColdTransfer(
+		id, network, currency, toAddress, amount, note, TransferStatusPendingApproval, requestedBy, "", "",
+		"", "", shared.Now().UTC(), nil, nil, nil,
+	)
+}
+
+// RestoreColdTransfer recreates a cold storage transfer from storage.
+func RestoreColdTransfer(
+	id string,
+	network shared.BlockchainNetwork,
+	currency, toAddress string,
+	amount decimal.Decimal,
+	note string,
+	status TransferStatus,
+	requestedBy, reviewedBy, reviewNote string,
+	txHash, failureReason string,
+	createdAt time.Time,
+	reviewedAt, broadcastAt, confirmedAt *time.Time,
+) (*ColdTransfer, error) {
+	if id == "" {
+		return nil, errors.New("cold transfer ID is required")
+	}
+	if network == "" || currency == "" || toAddress == "" {
+		return nil, fmt.Errorf("%w: network, currency and address are required", ErrInvalidRequest)
+	}
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: transfer amount must be positive", ErrInvalidRequest)
+	}
+	if !status.IsValid() {
+		return nil, errors.New("invalid cold transfer status")
+	}
+
+	return &ColdTransfer{
+		id:            id,
+		network:       network,
+		currency:      currency,
+		toAddress:     toAddress,
+		amount:        amount,
+		note:          note,
+		status:        status,
+		requestedBy:   requestedBy,
+		reviewedBy:    reviewedBy,
+		reviewNote:    reviewNote,
+		txHash:        txHash,
+		failureReason: failureReason,
+		createdAt:     createdAt,
+		reviewedAt:    reviewedAt,
+		broadcastAt:   broadcastAt,
+		confirmedAt:   confirmedAt,
+	}, nil
+}
+
+// ID returns the transfer ID.
+func (t *ColdTransfer) ID() string {
+	return t.id
+}
+
+// Network returns the network of the transfer.
+func (t *ColdTransfer) Network() shared.BlockchainNetwork {
+	return t.network
+}
+
+// Currency returns the currency transferred.
+func (t *ColdTransfer) Currency() string {
+	return t.currency
+}
+
+// ToAddress returns the cold storage address.
+func (t *ColdTransfer) ToAddress() string {
+	return t.toAddress
+}
+
+// Amount returns the amount transferred.
+func (t *ColdTransfer) Amount() decimal.Decimal {
+	return t.amount
+}
+
+// Note returns the requesting operator's reason for the transfer.
+func (t *ColdTransfer) Note() string {
+	return t.note
+}
+
+// Status returns the transfer status.
+func (t *ColdTransfer) Status() TransferStatus {
+	return t.status
+}
+
+// RequestedBy returns the operator who requested the transfer.
+func (t *ColdTransfer) RequestedBy() string {
+	return t.requestedBy
+}
+
+// ReviewedBy returns the operator who approved or rejected the transfer.
+func (t *ColdTransfer) ReviewedBy() string {
+	return t.reviewedBy
+}
+
+// ReviewNote returns the reviewing operator's note, such as a rejection reason.
+func (t *ColdTransfer) ReviewNote() string {
+	return t.reviewNote
+}
+
+// TxHash returns the hash of the transfer, once broadcast.
+func (t *ColdTransfer) TxHash() string {
+	return t.txHash
+}
+
+// FailureReason returns why the transfer failed.
+func (t *ColdTransfer) FailureReason() string {
+	return t.failureReason
+}
+
+// CreatedAt returns when the transfer was requested.
+func (t *ColdTransfer) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// ReviewedAt returns when the transfer was approved or rejected.
+func (t *ColdTransfer) ReviewedAt() *time.Time {
+	return t.reviewedAt
+}
+
+// BroadcastAt returns when the transfer was broadcast.
+func (t *ColdTransfer) BroadcastAt() *time.Time {
+	return t.broadcastAt
+}
+
+// ConfirmedAt returns when the transfer was confirmed.
+func (t *ColdTransfer) ConfirmedAt() *time.Time {
+	return t.confirmedAt
+}
+
+// Approve records the second operator's approval. The requester cannot approve their own transfer.
+func (t *ColdTransfer) Approve(operatorID, note string) error {
+	return t.review(TransferStatusApproved, operatorID, note)
+}
+
+// Reject records the second operator's rejection.
+func (t *ColdTransfer) Reject(operatorID, note string) error {
+	return t.review(TransferStatusRejected, operatorID, note)
+}
+
+// review records a decision on a transfer awaiting approval.
+func (t *ColdTransfer) review(status TransferStatus, operatorID, note string) error {
+	if operatorID == "" {
+		return fmt.Errorf("%w: the reviewing operator is required", ErrInvalidRequest)
+	}
+	if t.status != TransferStatusPendingApproval {
+		return ErrInvalidTransition
+	}
+	if operatorID == t.requestedBy {
+		return ErrSelfApproval
+	}
+
+	now := shared.Now().UTC()
+	t.status = status
+	t.reviewedBy = operatorID
+	t.reviewNote = note
+	t.reviewedAt = &now
+	return nil
+}
+
+// ApplyTransfer records the state the custody reports for an approved or broadcast transfer.
+func (t *ColdTransfer) ApplyTransfer(transfer *payout.Transfer) error {
+	if transfer == nil {
+		return fmt.Errorf("%w: transfer is required", ErrInvalidRequest)
+	}
+	if t.status != TransferStatusApproved && t.status != TransferStatusBroadcast {
+		return ErrInvalidTransition
+	}
+
+	now := shared.Now().UTC()
+	if t.status == TransferStatusApproved && transfer.TxHash != "" {
+		if _, err := shared.NewTransactionHash(transfer.TxHash); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
+		t.status = TransferStatusBroadcast
+		t.txHash = transfer.TxHash
+		t.broadcastAt = &now
+	}
+
+	switch transfer.State {
+	case payout.TransferStateConfirmed:
+		if t.status != TransferStatusBroadcast {
+			return ErrInvalidTransition
+		}
+		t.status = TransferStatusConfirmed
+		t.confirmedAt = &now
+	case payout.TransferStateFailed:
+		t.status = TransferStatusFailed
+		t.failureReason = transfer.Reason
+		if t.failureReason == "" {
+			t.failureReason = "transfer failed"
+		}
+	case payout.TransferStatePending:
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package treasury
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/shared"
+
+	"github.com/shopspring/decimal"
+)
+
+// Custody holds the keys of the platform's deposit addresses and hot wallet. It reports balances,
+// sweeps deposit addresses into the hot wallet and sends transfers from the hot wallet.
+type Custody interface {
+	// Name returns the custody name for logs.
+	Name() string
+
+	// Balance returns the balance of a currency held at an address, or in the hot wallet when no
+	// address is given.
+	Balance(ctx context.Context, req *BalanceRequest) (decimal.Decimal, error)
+
+	// Sweep moves funds from a deposit address into the hot wallet. Repeating a request with the
+	// same reference returns the sweep already sent instead of sending another.
+	Sweep(ctx context.Context, req *SweepRequest) (*payout.Transfer, error)
+
+	// Transfer signs and broadcasts a transfer from the hot wallet, idempotent per reference.
+	Transfer(ctx context.Context, req *payout.TransferRequest) (*payout.Transfer, error)
+
+	// GetTransfer returns the current state of a broadcast transfer or sweep.
+	GetTransfer(ctx context.Context, network shared.BlockchainNetwork, txHash string) (*payout.Transfer, error)
+}
+
+// BalanceRequest asks for the balance of a currency on a network.
+type BalanceRequest struct {
+	Network  shared.BlockchainNetwork
+	Currency string
+	// Address is a deposit address; empty asks for the hot wallet balance.
+	Address string
+}
+
+// SweepRequest describes a sweep of a deposit address into the hot wallet.
+type SweepRequest struct {
+	// Reference makes the sweep idempotent; the sweep ID is used.
+	Reference   string
+	Network     shared.BlockchainNetwork
+	Currency    string
+	FromAddress string
+	Amount      decimal.Decimal
+}
@@ -0,0 +1,20 @@
+package treasury
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the treasury service layer dependencies.
+var Module = fx.Module("treasury-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+		NewMonitor,
+	),
+	fx.Invoke(
+		RegisterPaidInvoiceHandler,
+		RegisterMonitor,
+	),
+)
@@ -0,0 +1,81 @@
+// Package treasury manages the platform's own funds: it tracks hot wallet balances per network,
+// sweeps paid invoices' deposit addresses into the hot wallet, alerts when a balance leaves its
+// thresholds and moves funds to cold storage once two operators have agreed to it.
+package treasury
+
+// BalanceLevel represents where a hot wallet balance stands against its thresholds.
+type BalanceLevel string
+
+const (
+	// BalanceLevelNormal - Balance within its thresholds
+	BalanceLevelNormal BalanceLevel = "normal"
+	// BalanceLevelLow - Balance below the low threshold; payouts may soon fail
+	BalanceLevelLow BalanceLevel = "low"
+	// BalanceLevelHigh - Balance above the high threshold; the excess belongs in cold storage
+	BalanceLevelHigh BalanceLevel = "high"
+)
+
+// IsValid returns true if the balance level is valid.
+func (l BalanceLevel) IsValid() bool {
+	switch l {
+	case BalanceLevelNormal, BalanceLevelLow, BalanceLevelHigh:
+		return true
+	default:
+		return false
+	}
+}
+
+// SweepStatus represents the current status of a sweep.
+type SweepStatus string
+
+const (
+	// SweepStatusPending - Invoice paid; deposit address not yet swept
+	SweepStatusPending SweepStatus = "pending"
+	// SweepStatusBroadcast - Sweep transfer broadcast; awaiting confirmation
+	SweepStatusBroadcast SweepStatus = "broadcast"
+	// SweepStatusConfirmed - Deposit address balance consolidated in the hot wallet
+	SweepStatusConfirmed SweepStatus = "confirmed"
+	// SweepStatusFailed - Sweep transfer rejected or reverted; the funds remain at the deposit address
+	SweepStatusFailed SweepStatus = "failed"
+	// SweepStatusSkipped - Deposit address balance below the sweep minimum; nothing was sent
+	SweepStatusSkipped SweepStatus = "skipped"
+)
+
+// IsValid returns true if the sweep status is valid.
+func (s SweepStatus) IsValid() bool {
+	switch s {
+	case SweepStatusPending, SweepStatusBroadcast, SweepStatusConfirmed, SweepStatusFailed, SweepStatusSkipped:
+		return true
+	default:
+		return false
+	}
+}
+
+// TransferStatus represents the current status of a cold storage transfer.
+type TransferStatus string
+
+const (
+	// TransferStatusPendingApproval - Requested by one operator; awaiting a second operator's decision
+	TransferStatusPendingApproval TransferStatus = "pending_approval"
+	// TransferStatusApproved - Approved by a second operator; transfer not yet sent
+	TransferStatusApproved TransferStatus = "approved"
+	// TransferStatusRejected - Rejected by a second operator; nothing was sent
+	TransferStatusRejected TransferStatus = "rejected"
+	// TransferStatusBroadcast - Transfer broadcast; awaiting confirmation
+	TransferStatusBroadcast TransferStatus = "broadcast"
+	// TransferStatusConfirmed - Funds confirmed in cold storage
+	TransferStatusConfirmed TransferStatus = "confirmed"
+	// TransferStatusFailed - Transfer rejected or reverted on-chain
+	TransferStatusFailed TransferStatus = "failed"
+)
+
+// IsValid returns true if the transfer status is valid.
+func (s TransferStatus) IsValid() bool {
+	switch s {
+	case TransferStatusPendingApproval, TransferStatusApproved, TransferStatusRejected,
+		TransferStatusBroadcast, TransferStatusConfirmed, TransferStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
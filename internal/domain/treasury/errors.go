@@ -0,0 +1,26 @@
+package treasury
+
+import "errors"
+
+// Domain errors for treasury operations
+var (
+	ErrInvalidRequest      = errors.New("invalid treasury request")
+	ErrInvalidTransition   = errors.New("treasury operation cannot change in its current state")
+	ErrSweepNotFound       = errors.New("sweep not found")
+	ErrTransferNotFound    = errors.New("cold storage transfer not found")
+	ErrSelfApproval        = errors.New("a cold storage transfer must be approved by a second operator")
+	ErrColdWalletMissing   = errors.New("no cold storage address is configured for this currency")
+	ErrInsufficientBalance = errors.New("hot wallet balance is below the transfer amount")
+	ErrCustodyUnavailable  = errors.New("treasury is not configured")
+	ErrUnsupportedCurrency = errors.New("currency is not held in the treasury")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeTransferNotFound    = "COLD_TRANSFER_NOT_FOUND"
+	ErrCodeSelfApproval        = "SECOND_APPROVER_REQUIRED"
+	ErrCodeColdWalletMissing   = "COLD_WALLET_NOT_CONFIGURED"
+	ErrCodeInsufficientBalance = "INSUFFICIENT_HOT_WALLET_BALANCE"
+	ErrCodeTreasuryUnavailable = "TREASURY_UNAVAILABLE"
+	ErrCodeInvalidTransition   = "INVALID_TRANSFER_STATE"
+)
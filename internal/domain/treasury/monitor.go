@@ -0,0 +1,98 @@
+package treasury
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Monitor sweeps deposit addresses, sends approved cold storage transfers and checks hot wallet
+// balances in the background.
+type Monitor struct {
+	service  Service
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+// NewMonitor creates a new treasury monitor running at the policy interval.
+func NewMonitor(service Service, policy Policy, logger *zap.Logger) *Monitor {
+	interval := policy.Interval
+	if interval <= 0 {
+		interval = DefaultPolicy().Interval
+	}
+	return &Monitor{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run processes the treasury on every interval until the context is cancelled.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		shared.Beat(ctx)
+		m.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick performs one treasury round.
+func (m *Monitor) tick(ctx context.Context) {
+	changed, err := m.service.Process(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			m.logger.Error("Failed to process treasury", zap.Error(err))
+		}
+		return
+	}
+	if changed > 0 {
+		m.logger.Info("Processed treasury", zap.Int("changes", changed))
+	}
+}
+
+// RegisterMonitor runs the treasury monitor for the lifetime of the application.
+// Nothing is started when no custody is configured.
+func RegisterMonitor(
+	lc fx.Lifecycle,
+	monitor *Monitor,
+	custody Custody,
+	workers shared.WorkerSupervisor,
+	logger *zap.Logger,
+) {
+	if custody == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			logger.Info("Starting treasury monitor", zap.String("custody", custody.Name()))
+			go func() {
+				defer close(done)
+				workers.Supervise(ctx, "treasury_monitor", monitor.interval, monitor.Run)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,61 @@
+package treasury
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// PaidInvoiceHandler schedules the sweep of invoices' deposit addresses as they become paid.
+type PaidInvoiceHandler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewPaidInvoiceHandler creates a new paid invoice handler.
+func NewPaidInvoiceHandler(service Service, logger *zap.Logger) *PaidInvoiceHandler {
+	return &PaidInvoiceHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterPaidInvoiceHandler subscribes a paid invoice handler to invoice events.
+// Nothing is subscribed when no custody is configured, since there is nothing to sweep with.
+func RegisterPaidInvoiceHandler(
+	registry shared.EventHandlerRegistry,
+	service Service,
+	custody Custody,
+	logger *zap.Logger,
+) {
+	if custody == nil {
+		return
+	}
+	registry.RegisterHandler(NewPaidInvoiceHandler(service, logger))
+}
+
+// EventTypes returns the invoice events that may report an invoice as paid.
+func (h *PaidInvoiceHandler) EventTypes() []string {
+	return []string{
+		shared.EventTypeInvoiceStatusChanged,
+		shared.EventTypeInvoicePaid,
+	}
+}
+
+// HandleEvent schedules the sweep of the invoice an event reports as paid. Events for invoices in
+// other states are ignored.
+func (h *PaidInvoiceHandler) HandleEvent(ctx context.Context, event *shared.BaseDomainEvent) error {
+	var data shared.InvoiceEvent
+	if err := shared.DecodeEventData(event, &data); err != nil {
+		return fmt.Errorf("failed to decode invoice event: %w", err)
+	}
+	if data.Status != invoice.StatusPaid.String() {
+		return nil
+	}
+
+	_, err := h.service.ScheduleSweep(ctx, event.AggregateID)
+	return err
+}
@@ -0,0 +1,85 @@
+package treasury
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+)
+
+// BalanceRepository defines the interface for hot wallet balance persistence.
+type BalanceRepository interface {
+	// Save creates or replaces the balance of a currency on its network.
+	Save(ctx context.Context, balance *Balance) error
+
+	// List retrieves every recorded balance.
+	List(ctx context.Context) ([]*Balance, error)
+}
+
+// SweepRepository defines the interface for sweep persistence.
+type SweepRepository interface {
+	// Save persists a new sweep.
+	Save(ctx context.Context, sweep *Sweep) error
+
+	// FindByInvoiceID retrieves the sweep of an invoice.
+	FindByInvoiceID(ctx context.Context, invoiceID string) (*Sweep, error)
+
+	// FindByStatus retrieves up to limit sweeps with the status, oldest first.
+	FindByStatus(ctx context.Context, status SweepStatus, limit int) ([]*Sweep, error)
+
+	// List retrieves sweeps matching the filter, newest first.
+	List(ctx context.Context, req *ListSweepsRequest) (*ListSweepsResponse, error)
+
+	// Update updates an existing sweep.
+	Update(ctx context.Context, sweep *Sweep) error
+}
+
+// TransferRepository defines the interface for cold storage transfer persistence.
+type TransferRepository interface {
+	// Save persists a new cold storage transfer.
+	Save(ctx context.Context, transfer *ColdTransfer) error
+
+	// FindByID retrieves a cold storage transfer by its ID.
+	FindByID(ctx context.Context, id string) (*ColdTransfer, error)
+
+	// FindByStatus retrieves up to limit transfers with the status, oldest first.
+	FindByStatus(ctx context.Context, status TransferStatus, limit int) ([]*ColdTransfer, error)
+
+	// List retrieves transfers matching the filter, newest first.
+	List(ctx context.Context, req *ListTransfersRequest) (*ListTransfersResponse, error)
+
+	// Update updates an existing cold storage transfer.
+	Update(ctx context.Context, transfer *ColdTransfer) error
+}
+
+// ListSweepsRequest represents the request to list sweeps.
+// Empty filter fields match all sweeps.
+type ListSweepsRequest struct {
+	Status SweepStatus
+	Limit  int
+	// Cursor continues a listing after the page that returned it.
+	Cursor *shared.Cursor
+}
+
+// ListSweepsResponse represents the response from listing sweeps.
+type ListSweepsResponse struct {
+	Sweeps []*Sweep
+	Limit  int
+	// NextCursor continues the listing after this page, or is nil on the last page.
+	NextCursor *shared.Cursor
+}
+
+// ListTransfersRequest represents the request to list cold storage transfers.
+// Empty filter fields match all transfers.
+type ListTransfersRequest struct {
+	Status TransferStatus
+	Limit  int
+	// Cursor continues a listing after the page that returned it.
+	Cursor *shared.Cursor
+}
+
+// ListTransfersResponse represents the response from listing cold storage transfers.
+type ListTransfersResponse struct {
+	Transfers []*ColdTransfer
+	Limit     int
+	// NextCursor continues the listing after this page, or is nil on the last page.
+	NextCursor *shared.Cursor
+}
@@ -0,0 +1,791 @@
+package treasury
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/fee"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultListLimit is the default page size for listing sweeps and transfers.
+	defaultListLimit = 20
+	// maxListLimit is the maximum page size for listing sweeps and transfers.
+	maxListLimit = 100
+	// processBatchSize bounds the sweeps and transfers handled in one round.
+	processBatchSize = 100
+)
+
+// heldCurrencies are the currencies whose hot wallet balances are tracked.
+var heldCurrencies = []string{
+	string(shared.CryptoCurrencyUSDT),
+	string(shared.CryptoCurrencyBTC),
+	string(shared.CryptoCurrencyETH),
+}
+
+// CurrencyPolicy configures how the treasury handles one currency.
+type CurrencyPolicy struct {
+	// Thresholds raise alerts when the hot wallet balance leaves them.
+	Thresholds Thresholds
+	// SweepMinimum is the smallest deposit address balance worth sweeping; smaller ones are dust.
+	SweepMinimum decimal.Decimal
+	// ColdAddress is the cold storage address transfers are sent to; empty disables them.
+	ColdAddress string
+}
+
+// Policy configures the treasury.
+type Policy struct {
+	Currencies map[string]CurrencyPolicy
+	// Interval is how often sweeps and transfers are followed and balances checked.
+	Interval time.Duration
+}
+
+// DefaultPolicy checks the treasury every minute, without thresholds or cold storage.
+func DefaultPolicy() Policy {
+	return Policy{
+		Currencies: map[string]CurrencyPolicy{},
+		Interval:   time.Minute,
+	}
+}
+
+// Service defines the interface for treasury operations.
+type Service interface {
+	// ListBalances lists the last known hot wallet balances.
+	ListBalances(ctx context.Context) ([]*Balance, error)
+
+	// ScheduleSweep schedules the sweep of a paid invoice's deposit address. An invoice already
+	// scheduled returns its sweep.
+	ScheduleSweep(ctx context.Context, invoiceID string) (*Sweep, error)
+
+	// ListSweeps lists sweeps.
+	ListSweeps(ctx context.Context, req *ListSweepsRequest) (*ListSweepsResponse, error)
+
+	// RequestColdTransfer requests a transfer from the hot wallet to cold storage, to be approved
+	// by a second operator.
+	RequestColdTransfer(ctx context.Context, req *ColdTransferRequest) (*ColdTransfer, error)
+
+	// ApproveColdTransfer approves a requested transfer and sends it.
+	ApproveColdTransfer(ctx context.Context, req *ReviewTransferRequest) (*ColdTransfer, error)
+
+	// RejectColdTransfer rejects a requested transfer.
+	RejectColdTransfer(ctx context.Context, req *ReviewTransferRequest) (*ColdTransfer, error)
+
+	// GetColdTransfer retrieves a cold storage transfer.
+	GetColdTransfer(ctx context.Context, id string) (*ColdTransfer, error)
+
+	// ListColdTransfers lists cold storage transfers.
+	ListColdTransfers(ctx context.Context, req *ListTransfersRequest) (*ListTransfersResponse, error)
+
+	// Process sends and follows sweeps and approved transfers, then checks the hot wallet balances.
+	// It returns the number of sweeps, transfers and balance levels that changed.
+	Process(ctx context.Context) (int, error)
+}
+
+// ColdTransferRequest represents the request to move funds to cold storage.
+type ColdTransferRequest struct {
+	Currency    string `validate:"required"`
+	Amount      decimal.Decimal
+	Note        string `validate:"max=500"`
+	RequestedBy string `validate:"required"`
+}
+
+// ReviewTransferRequest represents an operator's decision on a cold storage transfer.
+type ReviewTransferRequest struct {
+	TransferID string `validate:"required"`
+	OperatorID string `validate:"required"`
+	Note       string `validate:"max=500"`
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	balances       BalanceRepository
+	sweeps         SweepRepository
+	transfers      TransferRepository
+	custody        Custody
+	fees           fee.Service
+	invoiceService invoice.InvoiceService
+	policy         Policy
+	eventBus       shared.EventBus
+	logger         *zap.Logger
+}
+
+// NewService creates a new treasury service. The custody may be nil, in which case balances,
+// sweeps and transfers are unavailable. The fee service may be nil, in which case sweeps and
+// transfers are sent at any fee and their fees are not recorded.
+func NewService(
+	balances BalanceRepository,
+	sweeps SweepRepository,
+	transfers TransferRepository,
+	custody Custody,
+	fees fee.Service,
+	invoiceService invoice.InvoiceService,
+	policy Policy,
+	eventBus shared.EventBus,
+	logger *zap.Logger,
+) Service {
+	if policy.Currencies == nil {
+		policy.Currencies = map[string]CurrencyPolicy{}
+	}
+	if policy.Interval <= 0 {
+		policy.Interval = DefaultPolicy().Interval
+	}
+
+	return &ServiceImpl{
+		balances:       balances,
+		sweeps:         sweeps,
+		transfers:      transfers,
+		custody:        custody,
+		fees:           fees,
+		invoiceService: invoiceService,
+		policy:         policy,
+		eventBus:       eventBus,
+		logger:         logger,
+	}
+}
+
+// ListBalances lists the last known hot wallet balances.
+func (s *ServiceImpl) ListBalances(ctx context.Context) ([]*Balance, error) {
+	if s.custody == nil {
+		return nil, ErrCustodyUnavailable
+	}
+	return s.balances.List(ctx)
+}
+
+// ScheduleSweep schedules the sweep of a paid invoice's deposit address.
+func (s *ServiceImpl) ScheduleSweep(ctx context.Context, invoiceID string) (*Sweep, error) {
+	if invoiceID == "" {
+		return nil, fmt.Errorf("%w: invoice ID is required", ErrInvalidRequest)
+	}
+
+	existing, err := s.sweeps.FindByInvoiceID(ctx, invoiceID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, ErrSweepNotFound) {
+		return nil, err
+	}
+
+	inv, err := s.invoiceService.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+	address := inv.PaymentAddress()
+	if address == nil {
+		return nil, fmt.Errorf("%w: invoice %s has no deposit address", ErrInvalidRequest, invoiceID)
+	}
+
+	sweep, err := NewSweep(
+		uuid.NewString(), inv.ID(), inv.MerchantID(), address.Network(), string(inv.CryptoCurrency()),
+		address.Address(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sweeps.Save(ctx, sweep); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Sweep scheduled",
+		zap.String("sweep_id", sweep.ID()),
+		zap.String("invoice_id", invoiceID),
+		zap.String("address", sweep.FromAddress()))
+
+	return sweep, nil
+}
+
+// ListSweeps lists sweeps.
+func (s *ServiceImpl) ListSweeps(ctx context.Context, req *ListSweepsRequest) (*ListSweepsResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: list sweeps request cannot be nil", ErrInvalidRequest)
+	}
+	if req.Status != "" && !req.Status.IsValid() {
+		return nil, fmt.Errorf("%w: invalid status %s", ErrInvalidRequest, req.Status)
+	}
+
+	return s.sweeps.List(ctx, &ListSweepsRequest{
+		Status: req.Status,
+		Limit:  listLimit(req.Limit),
+		Cursor: req.Cursor,
+	})
+}
+
+// RequestColdTransfer records a transfer to the currency's cold storage address. The hot wallet
+// must hold the amount when the transfer is requested.
+func (s *ServiceImpl) RequestColdTransfer(ctx context.Context, req *ColdTransferRequest) (*ColdTransfer, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: cold transfer request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	if !req.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidRequest)
+	}
+	if s.custody == nil {
+		return nil, ErrCustodyUnavailable
+	}
+
+	network, err := networkForCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	coldAddress := s.policy.Currencies[req.Currency].ColdAddress
+	if coldAddress == "" {
+		return nil, fmt.Errorf("%w: %s", ErrColdWalletMissing, req.Currency)
+	}
+
+	balance, err := s.custody.Balance(ctx, &BalanceRequest{Network: network, Currency: req.Currency})
+	if err != nil {
+		return nil, err
+	}
+	if balance.LessThan(req.Amount) {
+		return nil, fmt.Errorf("%w: %s %s available", ErrInsufficientBalance, balance.String(), req.Currency)
+	}
+
+	transfer, err := NewColdTransfer(
+		uuid.NewString(), network, req.Currency, coldAddress, req.Amount, req.Note, req.RequestedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.transfers.Save(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Cold storage transfer requested",
+		zap.String("transfer_id", transfer.ID()),
+		zap.String("amount", transfer.Amount().String()),
+		zap.String("currency", transfer.Currency()),
+		zap.String("requested_by", transfer.RequestedBy()))
+	s.publishEvent(ctx, shared.NewColdTransferRequestedEvent(createTransferEventData(transfer)))
+
+	return transfer, nil
+}
+
+// ApproveColdTransfer approves a transfer requested by another operator and sends it. A transfer
+// that cannot be sent right away stays approved and is retried by the next round.
+func (s *ServiceImpl) ApproveColdTransfer(ctx context.Context, req *ReviewTransferRequest) (*ColdTransfer, error) {
+	transfer, err := s.reviewTransfer(ctx, req, (*ColdTransfer).Approve)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Cold storage transfer approved",
+		zap.String("transfer_id", transfer.ID()),
+		zap.String("requested_by", transfer.RequestedBy()),
+		zap.String("approved_by", transfer.ReviewedBy()))
+	s.publishEvent(ctx, shared.NewColdTransferApprovedEvent(createTransferEventData(transfer)))
+
+	s.sendTransfer(ctx, transfer)
+	return transfer, nil
+}
+
+// RejectColdTransfer rejects a transfer requested by another operator.
+func (s *ServiceImpl) RejectColdTransfer(ctx context.Context, req *ReviewTransferRequest) (*ColdTransfer, error) {
+	transfer, err := s.reviewTransfer(ctx, req, (*ColdTransfer).Reject)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Cold storage transfer rejected",
+		zap.String("transfer_id", transfer.ID()),
+		zap.String("rejected_by", transfer.ReviewedBy()))
+	return transfer, nil
+}
+
+// GetColdTransfer retrieves a cold storage transfer.
+func (s *ServiceImpl) GetColdTransfer(ctx context.Context, id string) (*ColdTransfer, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: transfer ID is required", ErrInvalidRequest)
+	}
+	return s.transfers.FindByID(ctx, id)
+}
+
+// ListColdTransfers lists cold storage transfers.
+func (s *ServiceImpl) ListColdTransfers(
+	ctx context.Context,
+	req *ListTransfersRequest,
+) (*ListTransfersResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: list transfers request cannot be nil", ErrInvalidRequest)
+	}
+	if req.Status != "" && !req.Status.IsValid() {
+		return nil, fmt.Errorf("%w: invalid status %s", ErrInvalidRequest, req.Status)
+	}
+
+	return s.transfers.List(ctx, &ListTransfersRequest{
+		Status: req.Status,
+		Limit:  listLimit(req.Limit),
+		Cursor: req.Cursor,
+	})
+}
+
+// Process runs one treasury round. Balances are checked last so they include the sweeps and
+// transfers the round confirmed.
+func (s *ServiceImpl) Process(ctx context.Context) (int, error) {
+	if s.custody == nil {
+		return 0, nil
+	}
+
+	changed := 0
+	for _, step := range []func(context.Context) (int, error){
+		s.processSweeps, s.processTransfers, s.refreshBalances,
+	} {
+		n, err := step(ctx)
+		changed += n
+		if err != nil {
+			return changed, err
+		}
+	}
+	return changed, nil
+}
+
+// processSweeps follows broadcast sweeps and sends pending ones. Broadcast sweeps come first so a
+// sweep is not checked in the round that sent it.
+func (s *ServiceImpl) processSweeps(ctx context.Context) (int, error) {
+	changed := 0
+	for _, status := range []SweepStatus{SweepStatusBroadcast, SweepStatusPending} {
+		sweeps, err := s.sweeps.FindByStatus(ctx, status, processBatchSize)
+		if err != nil {
+			return changed, err
+		}
+
+		for _, sweep := range sweeps {
+			if ctx.Err() != nil {
+				return changed, ctx.Err()
+			}
+			if s.advanceSweep(ctx, sweep) {
+				changed++
+			}
+		}
+	}
+	return changed, nil
+}
+
+// advanceSweep sends a pending sweep or checks a broadcast one, reporting whether its status
+// changed. Custody errors leave the sweep as it was for the next round.
+func (s *ServiceImpl) advanceSweep(ctx context.Context, sweep *Sweep) bool {
+	previous := sweep.Status()
+
+	var transfer *payout.Transfer
+	var err error
+	if previous == SweepStatusBroadcast {
+		transfer, err = s.custody.GetTransfer(ctx, sweep.Network(), sweep.TxHash())
+	} else {
+		transfer, err = s.sendSweep(ctx, sweep)
+	}
+	if err != nil {
+		s.logger.Error("Failed to advance sweep; it will be retried",
+			zap.String("sweep_id", sweep.ID()),
+			zap.Error(err))
+		return false
+	}
+	if transfer != nil {
+		if err := sweep.ApplyTransfer(transfer); err != nil {
+			s.logger.Error("Failed to apply sweep transfer",
+				zap.String("sweep_id", sweep.ID()),
+				zap.Error(err))
+			return false
+		}
+	}
+	if sweep.Status() == previous {
+		return false
+	}
+
+	if err := s.sweeps.Update(ctx, sweep); err != nil {
+		s.logger.Error("Failed to update sweep",
+			zap.String("sweep_id", sweep.ID()),
+			zap.Error(err))
+		return false
+	}
+
+	switch sweep.Status() {
+	case SweepStatusConfirmed:
+		s.recordFee(ctx, fee.PurposeSweep, sweep.ID(), sweep.Network(), sweep.TxHash(), transfer)
+	case SweepStatusFailed:
+		s.logger.Warn("Sweep failed; the funds remain at the deposit address",
+			zap.String("sweep_id", sweep.ID()),
+			zap.String("address", sweep.FromAddress()),
+			zap.String("reason", sweep.FailureReason()))
+		s.publishEvent(ctx, shared.NewTreasurySweepFailedEvent(createSweepEventData(sweep)))
+	case SweepStatusPending, SweepStatusBroadcast, SweepStatusSkipped:
+	}
+	return true
+}
+
+// sendSweep sweeps a pending sweep's deposit address. The balance is read once and stored, so a
+// retried sweep resends the same amount with the same reference. It returns a nil transfer when
+// the balance is dust and the sweep was skipped, or when the sweep waits for lower fees.
+func (s *ServiceImpl) sendSweep(ctx context.Context, sweep *Sweep) (*payout.Transfer, error) {
+	if !sweep.Amount().IsPositive() {
+		balance, err := s.custody.Balance(ctx, &BalanceRequest{
+			Network:  sweep.Network(),
+			Currency: sweep.Currency(),
+			Address:  sweep.FromAddress(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		minimum := s.policy.Currencies[sweep.Currency()].SweepMinimum
+		if !balance.IsPositive() || balance.LessThan(minimum) {
+			reason := fmt.Sprintf("deposit balance %s is below the sweep minimum %s", balance.String(), minimum.String())
+			return nil, sweep.Skip(reason)
+		}
+		if err := sweep.SetAmount(balance); err != nil {
+			return nil, err
+		}
+		if err := s.sweeps.Update(ctx, sweep); err != nil {
+			return nil, err
+		}
+	}
+
+	if !s.shouldBroadcast(ctx, sweep.Network(), sweep.Currency(), sweep.CreatedAt(),
+		zap.String("sweep_id", sweep.ID())) {
+		return nil, nil
+	}
+
+	return s.custody.Sweep(ctx, &SweepRequest{
+		Reference:   sweep.ID(),
+		Network:     sweep.Network(),
+		Currency:    sweep.Currency(),
+		FromAddress: sweep.FromAddress(),
+		Amount:      sweep.Amount(),
+	})
+}
+
+// processTransfers follows broadcast transfers and sends approved ones.
+func (s *ServiceImpl) processTransfers(ctx context.Context) (int, error) {
+	changed := 0
+	for _, status := range []TransferStatus{TransferStatusBroadcast, TransferStatusApproved} {
+		transfers, err := s.transfers.FindByStatus(ctx, status, processBatchSize)
+		if err != nil {
+			return changed, err
+		}
+
+		for _, transfer := range transfers {
+			if ctx.Err() != nil {
+				return changed, ctx.Err()
+			}
+
+			if status == TransferStatusApproved {
+				if s.sendTransfer(ctx, transfer) {
+					changed++
+				}
+				continue
+			}
+
+			result, err := s.custody.GetTransfer(ctx, transfer.Network(), transfer.TxHash())
+			if err != nil {
+				s.logger.Error("Failed to check cold storage transfer",
+					zap.String("transfer_id", transfer.ID()),
+					zap.String("tx_hash", transfer.TxHash()),
+					zap.Error(err))
+				continue
+			}
+			if s.applyTransfer(ctx, transfer, result) {
+				changed++
+			}
+		}
+	}
+	return changed, nil
+}
+
+// sendTransfer asks the custody to send an approved transfer, reporting whether its status changed.
+// A transfer deferred until fees drop stays approved.
+func (s *ServiceImpl) sendTransfer(ctx context.Context, transfer *ColdTransfer) bool {
+	approvedAt := transfer.CreatedAt()
+	if reviewedAt := transfer.ReviewedAt(); reviewedAt != nil {
+		approvedAt = *reviewedAt
+	}
+	if !s.shouldBroadcast(ctx, transfer.Network(), transfer.Currency(), approvedAt,
+		zap.String("transfer_id", transfer.ID())) {
+		return false
+	}
+
+	result, err := s.custody.Transfer(ctx, &payout.TransferRequest{
+		Reference: transfer.ID(),
+		Network:   transfer.Network(),
+		Currency:  transfer.Currency(),
+		ToAddress: transfer.ToAddress(),
+		Amount:    transfer.Amount(),
+	})
+	if err != nil {
+		s.logger.Error("Failed to send cold storage transfer; it will be retried",
+			zap.String("transfer_id", transfer.ID()),
+			zap.Error(err))
+		return false
+	}
+	return s.applyTransfer(ctx, transfer, result)
+}
+
+// applyTransfer records a custody transfer state on a cold storage transfer, stores it and
+// announces its outcome.
+func (s *ServiceImpl) applyTransfer(ctx context.Context, transfer *ColdTransfer, result *payout.Transfer) bool {
+	previous := transfer.Status()
+	if err := transfer.ApplyTransfer(result); err != nil {
+		s.logger.Error("Failed to apply cold storage transfer",
+			zap.String("transfer_id", transfer.ID()),
+			zap.Error(err))
+		return false
+	}
+	if transfer.Status() == previous {
+		return false
+	}
+
+	if err := s.transfers.Update(ctx, transfer); err != nil {
+		s.logger.Error("Failed to update cold storage transfer",
+			zap.String("transfer_id", transfer.ID()),
+			zap.Error(err))
+		return false
+	}
+
+	switch transfer.Status() {
+	case TransferStatusConfirmed:
+		s.publishEvent(ctx, shared.NewColdTransferConfirmedEvent(createTransferEventData(transfer)))
+		s.recordFee(ctx, fee.PurposeColdTransfer, transfer.ID(), transfer.Network(), transfer.TxHash(), result)
+	case TransferStatusFailed:
+		s.logger.Warn("Cold storage transfer failed",
+			zap.String("transfer_id", transfer.ID()),
+			zap.String("reason", transfer.FailureReason()))
+		s.publishEvent(ctx, shared.NewColdTransferFailedEvent(createTransferEventData(transfer)))
+	case TransferStatusPendingApproval, TransferStatusApproved, TransferStatusRejected, TransferStatusBroadcast:
+	}
+	return true
+}
+
+// shouldBroadcast asks the fee service whether a transfer waiting since the given time should be
+// sent now, logging the deferral when it should not.
+func (s *ServiceImpl) shouldBroadcast(
+	ctx context.Context,
+	network shared.BlockchainNetwork,
+	currency string,
+	waitingSince time.Time,
+	field zap.Field,
+) bool {
+	if s.fees == nil {
+		return true
+	}
+
+	decision := s.fees.Decide(ctx, &fee.BroadcastRequest{
+		Network:      network,
+		Currency:     currency,
+		WaitingSince: waitingSince,
+	})
+	if !decision.Broadcast {
+		s.logger.Info("Treasury transfer deferred until network fees drop", field,
+			zap.String("reason", decision.Reason))
+	}
+	return decision.Broadcast
+}
+
+// recordFee records the network fee a confirmed sweep or transfer paid, when the custody reported it.
+func (s *ServiceImpl) recordFee(
+	ctx context.Context,
+	purpose fee.Purpose,
+	reference string,
+	network shared.BlockchainNetwork,
+	txHash string,
+	transfer *payout.Transfer,
+) {
+	if s.fees == nil || transfer == nil || transfer.Fee == nil {
+		return
+	}
+
+	if err := s.fees.RecordSpend(ctx, &fee.SpendRequest{
+		Purpose:   purpose,
+		Reference: reference,
+		Network:   network,
+		TxHash:    txHash,
+		Amount:    *transfer.Fee,
+	}); err != nil {
+		s.logger.Error("Failed to record treasury fee",
+			zap.String("reference", reference),
+			zap.Error(err))
+	}
+}
+
+// refreshBalances checks the hot wallet balance of every held currency and raises an alert when
+// one crosses into a low or high level.
+func (s *ServiceImpl) refreshBalances(ctx context.Context) (int, error) {
+	recorded, err := s.balances.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	known := make(map[string]*Balance, len(recorded))
+	for _, balance := range recorded {
+		known[balance.Currency()] = balance
+	}
+
+	changed := 0
+	for _, currency := range heldCurrencies {
+		if ctx.Err() != nil {
+			return changed, ctx.Err()
+		}
+
+		network, err := networkForCurrency(currency)
+		if err != nil {
+			return changed, err
+		}
+		amount, err := s.custody.Balance(ctx, &BalanceRequest{Network: network, Currency: currency})
+		if err != nil {
+			s.logger.Error("Failed to check hot wallet balance",
+				zap.String("currency", currency),
+				zap.Error(err))
+			continue
+		}
+
+		thresholds := s.policy.Currencies[currency].Thresholds
+		balance, levelChanged := known[currency], false
+		if balance == nil {
+			if balance, err = NewBalance(network, currency, amount, thresholds); err != nil {
+				return changed, err
+			}
+			levelChanged = balance.Level() != BalanceLevelNormal
+		} else {
+			levelChanged = balance.Record(amount, thresholds)
+		}
+
+		if err := s.balances.Save(ctx, balance); err != nil {
+			return changed, err
+		}
+		if levelChanged {
+			changed++
+			s.alert(ctx, balance, thresholds)
+		}
+	}
+	return changed, nil
+}
+
+// alert reports a balance that changed level.
+func (s *ServiceImpl) alert(ctx context.Context, balance *Balance, thresholds Thresholds) {
+	fields := []zap.Field{
+		zap.String("currency", balance.Currency()),
+		zap.String("network", balance.Network().String()),
+		zap.String("balance", balance.Amount().String()),
+	}
+
+	data := createBalanceEventData(balance)
+	switch balance.Level() {
+	case BalanceLevelLow:
+		data.Threshold = thresholds.Low.String()
+		s.logger.Warn("Hot wallet balance below its low threshold", fields...)
+		s.publishEvent(ctx, shared.NewTreasuryBalanceLowEvent(data))
+	case BalanceLevelHigh:
+		data.Threshold = thresholds.High.String()
+		s.logger.Warn("Hot wallet balance above its high threshold", fields...)
+		s.publishEvent(ctx, shared.NewTreasuryBalanceHighEvent(data))
+	case BalanceLevelNormal:
+		s.logger.Info("Hot wallet balance back within its thresholds", fields...)
+	}
+}
+
+// reviewTransfer applies an operator's decision to a transfer awaiting approval and stores it.
+func (s *ServiceImpl) reviewTransfer(
+	ctx context.Context,
+	req *ReviewTransferRequest,
+	decide func(transfer *ColdTransfer, operatorID, note string) error,
+) (*ColdTransfer, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: review request cannot be nil", ErrInvalidRequest)
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	if s.custody == nil {
+		return nil, ErrCustodyUnavailable
+	}
+
+	transfer, err := s.transfers.FindByID(ctx, req.TransferID)
+	if err != nil {
+		return nil, err
+	}
+	if err := decide(transfer, req.OperatorID, req.Note); err != nil {
+		return nil, err
+	}
+	if err := s.transfers.Update(ctx, transfer); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// publishEvent publishes a treasury event, logging rather than failing when the bus is unavailable.
+func (s *ServiceImpl) publishEvent(ctx context.Context, event *shared.BaseDomainEvent) {
+	if s.eventBus == nil {
+		return
+	}
+
+	if err := s.eventBus.PublishEvent(ctx, event); err != nil {
+		s.logger.Error("Failed to publish domain event",
+			zap.String("event_type", event.EventType),
+			zap.String("aggregate_id", event.AggregateID),
+			zap.Error(err))
+	}
+}
+
+// networkForCurrency returns the network a held currency is kept on.
+func networkForCurrency(currency string) (shared.BlockchainNetwork, error) {
+	network, err := payout.NetworkForCurrency(currency)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+	return network, nil
+}
+
+// listLimit bounds a requested page size.
+func listLimit(limit int) int {
+	if limit <= 0 {
+		return defaultListLimit
+	}
+	return min(limit, maxListLimit)
+}
+
+// createBalanceEventData returns the payload of balance alerts.
+func createBalanceEventData(balance *Balance) shared.TreasuryBalanceEvent {
+	return shared.TreasuryBalanceEvent{
+		Network:  balance.Network().String(),
+		Currency: balance.Currency(),
+		Balance:  balance.Amount().String(),
+		Level:    string(balance.Level()),
+	}
+}
+
+// createSweepEventData returns the payload of sweep events.
+func createSweepEventData(sweep *Sweep) shared.SweepEvent {
+	return shared.SweepEvent{
+		SweepID:       sweep.ID(),
+		InvoiceID:     sweep.InvoiceID(),
+		Network:       sweep.Network().String(),
+		Currency:      sweep.Currency(),
+		FromAddress:   sweep.FromAddress(),
+		Amount:        sweep.Amount().String(),
+		Status:        string(sweep.Status()),
+		TxHash:        sweep.TxHash(),
+		FailureReason: sweep.FailureReason(),
+	}
+}
+
+// createTransferEventData returns the payload of cold storage transfer events.
+func createTransferEventData(transfer *ColdTransfer) shared.ColdTransferEvent {
+	return shared.ColdTransferEvent{
+		TransferID:    transfer.ID(),
+		Network:       transfer.Network().String(),
+		Currency:      transfer.Currency(),
+		ToAddress:     transfer.ToAddress(),
+		Amount:        transfer.Amount().String(),
+		Status:        string(transfer.Status()),
+		RequestedBy:   transfer.RequestedBy(),
+		ReviewedBy:    transfer.ReviewedBy(),
+		TxHash:        transfer.TxHash(),
+		FailureReason: transfer.FailureReason(),
+	}
+}
@@ -0,0 +1,201 @@
+package treasury
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeBalances struct {
+	balances map[string]*Balance
+}
+
+func (f *fakeBalances) Save(_ context.Context, balance *Balance) error {
+	f.balances[balance.Currency()] = balance
+	return nil
+}
+
+func (f *fakeBalances) List(_ context.Context) ([]*Balance, error) {
+	var balances []*Balance
+	for _, balance := range f.balances {
+		balances = append(balances, balance)
+	}
+	return balances, nil
+}
+
+type fakeSweeps struct {
+	SweepRepository
+	sweeps []*Sweep
+}
+
+func (f *fakeSweeps) Update(_ context.Context, _ *Sweep) error { return nil }
+
+func (f *fakeSweeps) FindByStatus(_ context.Context, status SweepStatus, _ int) ([]*Sweep, error) {
+	var found []*Sweep
+	for _, sweep := range f.sweeps {
+		if sweep.Status() == status {
+			found = append(found, sweep)
+		}
+	}
+	return found, nil
+}
+
+type fakeTransfers struct {
+	TransferRepository
+	transfers map[string]*ColdTransfer
+}
+
+func (f *fakeTransfers) Save(_ context.Context, transfer *ColdTransfer) error {
+	f.transfers[transfer.ID()] = transfer
+	return nil
+}
+
+func (f *fakeTransfers) Update(_ context.Context, transfer *ColdTransfer) error {
+	f.transfers[transfer.ID()] = transfer
+	return nil
+}
+
+func (f *fakeTransfers) FindByID(_ context.Context, id string) (*ColdTransfer, error) {
+	transfer, ok := f.transfers[id]
+	if !ok {
+		return nil, ErrTransferNotFound
+	}
+	return transfer, nil
+}
+
+func (f *fakeTransfers) FindByStatus(_ context.Context, status TransferStatus, _ int) ([]*ColdTransfer, error) {
+	var found []*ColdTransfer
+	for _, transfer := range f.transfers {
+		if transfer.Status() == status {
+			found = append(found, transfer)
+		}
+	}
+	return found, nil
+}
+
+// fakeCustody reports fixed balances, keyed by currency for the hot wallet and by address for
+// deposit addresses, and records what it is asked to send.
+type fakeCustody struct {
+	balances  map[string]decimal.Decimal
+	sweeps    []*SweepRequest
+	transfers []*payout.TransferRequest
+}
+
+func (f *fakeCustody) Name() string { return "fake" }
+
+func (f *fakeCustody) Balance(_ context.Context, req *BalanceRequest) (decimal.Decimal, error) {
+	if req.Address != "" {
+		return f.balances[req.Address], nil
+	}
+	return f.balances[req.Currency], nil
+}
+
+func (f *fakeCustody) Sweep(_ context.Context, req *SweepRequest) (*payout.Transfer, error) {
+	f.sweeps = append(f.sweeps, req)
+	return &payout.Transfer{TxHash: testTxHash, State: payout.TransferStatePending}, nil
+}
+
+func (f *fakeCustody) Transfer(_ context.Context, req *payout.TransferRequest) (*payout.Transfer, error) {
+	f.transfers = append(f.transfers, req)
+	return &payout.Transfer{TxHash: testTxHash, State: payout.TransferStatePending}, nil
+}
+
+func (f *fakeCustody) GetTransfer(
+	_ context.Context,
+	_ shared.BlockchainNetwork,
+	txHash string,
+) (*payout.Transfer, error) {
+	return &payout.Transfer{TxHash: txHash, State: payout.TransferStateConfirmed}, nil
+}
+
+func testPolicy() Policy {
+	policy := DefaultPolicy()
+	policy.Currencies["USDT"] = CurrencyPolicy{
+		Thresholds:   Thresholds{Low: decimal.NewFromInt(100), High: decimal.NewFromInt(10000)},
+		SweepMinimum: decimal.NewFromInt(1),
+		ColdAddress:  testColdAddress,
+	}
+	return policy
+}
+
+func TestService_ProcessSweepsAndBalances(t *testing.T) {
+	ctx := context.Background()
+	dust, err := NewSweep("sweep-dust", "inv-1", "merchant-1", shared.NetworkTron, "USDT", "TDustDepositAddress1")
+	require.NoError(t, err)
+	paid, err := NewSweep("sweep-paid", "inv-2", "merchant-1", shared.NetworkTron, "USDT", testDeposit)
+	require.NoError(t, err)
+
+	custody := &fakeCustody{balances: map[string]decimal.Decimal{
+		"TDustDepositAddress1": decimal.RequireFromString("0.5"),
+		testDeposit:            decimal.RequireFromString("99.5"),
+		"USDT":                 decimal.NewFromInt(50),
+	}}
+	balances := &fakeBalances{balances: map[string]*Balance{}}
+	service := NewService(
+		balances, &fakeSweeps{sweeps: []*Sweep{dust, paid}}, &fakeTransfers{transfers: map[string]*ColdTransfer{}},
+		custody, nil, nil, testPolicy(), nil, zap.NewNop(),
+	)
+
+	changed, err := service.Process(ctx)
+	require.NoError(t, err)
+	// Both sweeps changed status and the USDT balance opened below its low threshold
+	assert.Equal(t, 3, changed)
+
+	assert.Equal(t, SweepStatusSkipped, dust.Status())
+	require.Len(t, custody.sweeps, 1)
+	assert.Equal(t, testDeposit, custody.sweeps[0].FromAddress)
+	assert.Equal(t, "99.5", custody.sweeps[0].Amount.String())
+	assert.Equal(t, SweepStatusBroadcast, paid.Status())
+	assert.Equal(t, BalanceLevelLow, balances.balances["USDT"].Level())
+
+	t.Run("next round confirms the sweep without a repeated alert", func(t *testing.T) {
+		changed, err := service.Process(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, changed)
+		assert.Equal(t, SweepStatusConfirmed, paid.Status())
+		assert.Len(t, custody.sweeps, 1)
+	})
+}
+
+func TestService_ColdTransferRequiresSecondOperator(t *testing.T) {
+	ctx := context.Background()
+	custody := &fakeCustody{balances: map[string]decimal.Decimal{"USDT": decimal.NewFromInt(20000)}}
+	transfers := &fakeTransfers{transfers: map[string]*ColdTransfer{}}
+	service := NewService(
+		&fakeBalances{balances: map[string]*Balance{}}, &fakeSweeps{}, transfers, custody, nil, nil, testPolicy(), nil,
+		zap.NewNop(),
+	)
+
+	_, err := service.RequestColdTransfer(ctx, &ColdTransferRequest{
+		Currency: "USDT", Amount: decimal.NewFromInt(25000), RequestedBy: "alice",
+	})
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+
+	_, err = service.RequestColdTransfer(ctx, &ColdTransferRequest{
+		Currency: "BTC", Amount: decimal.NewFromInt(1), RequestedBy: "alice",
+	})
+	require.ErrorIs(t, err, ErrColdWalletMissing)
+
+	transfer, err := service.RequestColdTransfer(ctx, &ColdTransferRequest{
+		Currency: "USDT", Amount: decimal.NewFromInt(15000), Note: "weekly", RequestedBy: "alice",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testColdAddress, transfer.ToAddress())
+
+	_, err = service.ApproveColdTransfer(ctx, &ReviewTransferRequest{TransferID: transfer.ID(), OperatorID: "alice"})
+	require.ErrorIs(t, err, ErrSelfApproval)
+	assert.Empty(t, custody.transfers)
+
+	approved, err := service.ApproveColdTransfer(ctx, &ReviewTransferRequest{TransferID: transfer.ID(), OperatorID: "bob"})
+	require.NoError(t, err)
+	assert.Equal(t, TransferStatusBroadcast, approved.Status())
+	require.Len(t, custody.transfers, 1)
+	assert.Equal(t, transfer.ID(), custody.transfers[0].Reference)
+	assert.Equal(t, testColdAddress, custody.transfers[0].ToAddress)
+}
@@ -0,0 +1,203 @@
+package treasury
+
+import (
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Sweep consolidates the funds received at a paid invoice's deposit address into the hot wallet.
+type Sweep struct {
+	id            string
+	invoiceID     string
+	merchantID    string
+	network       shared.BlockchainNetwork
+	currency      string
+	fromAddress   string
+	amount        decimal.Decimal
+	status        SweepStatus
+	txHash        string
+	failureReason string
+	createdAt     time.Time
+	completedAt   *time.Time
+}
+
+// NewSweep creates a pending sweep of an invoice's deposit address. The amount is the address
+// balance when the sweep is sent.
+func NewSweep(
+	id, invoiceID, merchantID string,
+	network shared.BlockchainNetwork,
+	currency, fromAddress string,
+) (*Sweep, error) {
+	return RestoreSweep(
+		id, invoiceID, merchantID, network, currency, fromAddress, decimal.Zero,
+		SweepStatusPending, "", "", shared.Now().UTC(), nil,
+	)
+}
+
+// RestoreSweep recreates a sweep from storage.
+func RestoreSweep(
+	id, invoiceID, merchantID string,
+	network shared.BlockchainNetwork,
+	currency, fromAddress string,
+	amount decimal.Decimal,
+	status SweepStatus,
+	txHash, failureReason string,
+	createdAt time.Time,
+	completedAt *time.Time,
+) (*Sweep, error) {
+	if id == "" {
+		return nil, errors.New("sweep ID is required")
+	}
+	if invoiceID == "" || fromAddress == "" {
+		return nil, fmt.Errorf("%w: sweep invoice and deposit address are required", ErrInvalidRequest)
+	}
+	if network == "" || currency == "" {
+		return nil, fmt.Errorf("%w: sweep network and currency are required", ErrInvalidRequest)
+	}
+	if !status.IsValid() {
+		return nil, errors.New("invalid sweep status")
+	}
+
+	return &Sweep{
+		id:            id,
+		invoiceID:     invoiceID,
+		merchantID:    merchantID,
+		network:       network,
+		currency:      currency,
+		fromAddress:   fromAddress,
+		amount:        amount,
+		status:        status,
+		txHash:        txHash,
+		failureReason: failureReason,
+		createdAt:     createdAt,
+		completedAt:   completedAt,
+	}, nil
+}
+
+// ID returns the sweep ID.
+func (s *Sweep) ID() string {
+	return s.id
+}
+
+// InvoiceID returns the invoice whose deposit address is swept.
+func (s *Sweep) InvoiceID() string {
+	return s.invoiceID
+}
+
+// MerchantID returns the merchant of the invoice.
+func (s *Sweep) MerchantID() string {
+	return s.merchantID
+}
+
+// Network returns the network of the deposit address.
+func (s *Sweep) Network() shared.BlockchainNetwork {
+	return s.network
+}
+
+// Currency returns the currency swept.
+func (s *Sweep) Currency() string {
+	return s.currency
+}
+
+// FromAddress returns the deposit address swept.
+func (s *Sweep) FromAddress() string {
+	return s.fromAddress
+}
+
+// Amount returns the amount swept, once sent.
+func (s *Sweep) Amount() decimal.Decimal {
+	return s.amount
+}
+
+// Status returns the sweep status.
+func (s *Sweep) Status() SweepStatus {
+	return s.status
+}
+
+// TxHash returns the hash of the sweep transfer, once broadcast.
+func (s *Sweep) TxHash() string {
+	return s.txHash
+}
+
+// FailureReason returns why the sweep failed or was skipped.
+func (s *Sweep) FailureReason() string {
+	return s.failureReason
+}
+
+// CreatedAt returns when the sweep was scheduled.
+func (s *Sweep) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// CompletedAt returns when the sweep was confirmed, failed or skipped.
+func (s *Sweep) CompletedAt() *time.Time {
+	return s.completedAt
+}
+
+// SetAmount records the deposit address balance about to be swept.
+func (s *Sweep) SetAmount(amount decimal.Decimal) error {
+	if s.status != SweepStatusPending {
+		return ErrInvalidTransition
+	}
+	if !amount.IsPositive() {
+		return fmt.Errorf("%w: sweep amount must be positive", ErrInvalidRequest)
+	}
+
+	s.amount = amount
+	return nil
+}
+
+// Skip marks a pending sweep as not worth sending.
+func (s *Sweep) Skip(reason string) error {
+	if s.status != SweepStatusPending {
+		return ErrInvalidTransition
+	}
+
+	now := shared.Now().UTC()
+	s.status = SweepStatusSkipped
+	s.failureReason = reason
+	s.completedAt = &now
+	return nil
+}
+
+// ApplyTransfer records the state the custody reports for the sweep transfer.
+func (s *Sweep) ApplyTransfer(transfer *payout.Transfer) error {
+	if transfer == nil {
+		return fmt.Errorf("%w: transfer is required", ErrInvalidRequest)
+	}
+	if s.status != SweepStatusPending && s.status != SweepStatusBroadcast {
+		return ErrInvalidTransition
+	}
+
+	if s.status == SweepStatusPending && transfer.TxHash != "" {
+		if _, err := shared.NewTransactionHash(transfer.TxHash); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
+		s.status = SweepStatusBroadcast
+		s.txHash = transfer.TxHash
+	}
+
+	now := shared.Now().UTC()
+	switch transfer.State {
+	case payout.TransferStateConfirmed:
+		if s.status != SweepStatusBroadcast {
+			return ErrInvalidTransition
+		}
+		s.status = SweepStatusConfirmed
+		s.completedAt = &now
+	case payout.TransferStateFailed:
+		s.status = SweepStatusFailed
+		s.failureReason = transfer.Reason
+		if s.failureReason == "" {
+			s.failureReason = "sweep failed"
+		}
+		s.completedAt = &now
+	case payout.TransferStatePending:
+	}
+	return nil
+}
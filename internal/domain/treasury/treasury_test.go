@@ -0,0 +1,100 @@
+package treasury
+
+import (
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/shared"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTxHash      = "7c2d1f0e9b8a7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d"
+	testColdAddress = "TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf"
+	testDeposit     = "TQn9Y2khEsLJW1ChVWFMSMeRDow5KcbLSE"
+)
+
+func TestThresholds_Level(t *testing.T) {
+	thresholds := Thresholds{Low: decimal.NewFromInt(100), High: decimal.NewFromInt(1000)}
+
+	assert.Equal(t, BalanceLevelLow, thresholds.Level(decimal.NewFromInt(99)))
+	assert.Equal(t, BalanceLevelNormal, thresholds.Level(decimal.NewFromInt(100)))
+	assert.Equal(t, BalanceLevelNormal, thresholds.Level(decimal.NewFromInt(1000)))
+	assert.Equal(t, BalanceLevelHigh, thresholds.Level(decimal.NewFromInt(1001)))
+	assert.Equal(t, BalanceLevelNormal, Thresholds{}.Level(decimal.Zero), "zero thresholds are not checked")
+}
+
+func TestBalance_RecordReportsLevelChanges(t *testing.T) {
+	thresholds := Thresholds{Low: decimal.NewFromInt(100)}
+	balance, err := NewBalance(shared.NetworkTron, "USDT", decimal.NewFromInt(500), thresholds)
+	require.NoError(t, err)
+	assert.Equal(t, BalanceLevelNormal, balance.Level())
+
+	assert.True(t, balance.Record(decimal.NewFromInt(50), thresholds))
+	assert.Equal(t, BalanceLevelLow, balance.Level())
+	assert.False(t, balance.Record(decimal.NewFromInt(40), thresholds), "staying low is not a change")
+	assert.True(t, balance.Record(decimal.NewFromInt(200), thresholds))
+}
+
+func TestSweep_Lifecycle(t *testing.T) {
+	t.Run("confirmed", func(t *testing.T) {
+		sweep, err := NewSweep("sweep-1", "inv-1", "merchant-1", shared.NetworkTron, "USDT", testDeposit)
+		require.NoError(t, err)
+		require.NoError(t, sweep.SetAmount(decimal.NewFromInt(25)))
+
+		require.NoError(t, sweep.ApplyTransfer(&payout.Transfer{TxHash: testTxHash, State: payout.TransferStatePending}))
+		assert.Equal(t, SweepStatusBroadcast, sweep.Status())
+		assert.Equal(t, testTxHash, sweep.TxHash())
+
+		require.NoError(t, sweep.ApplyTransfer(&payout.Transfer{TxHash: testTxHash, State: payout.TransferStateConfirmed}))
+		assert.Equal(t, SweepStatusConfirmed, sweep.Status())
+		assert.NotNil(t, sweep.CompletedAt())
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		sweep, err := NewSweep("sweep-2", "inv-2", "merchant-1", shared.NetworkTron, "USDT", testDeposit)
+		require.NoError(t, err)
+		require.NoError(t, sweep.Skip("dust"))
+		assert.Equal(t, SweepStatusSkipped, sweep.Status())
+		assert.ErrorIs(t, sweep.SetAmount(decimal.NewFromInt(1)), ErrInvalidTransition)
+	})
+}
+
+func TestColdTransfer_DualApproval(t *testing.T) {
+	newTransfer := func(t *testing.T) *ColdTransfer {
+		t.Helper()
+		transfer, err := NewColdTransfer(
+			"transfer-1", shared.NetworkTron, "USDT", testColdAddress, decimal.NewFromInt(5000), "weekly", "alice",
+		)
+		require.NoError(t, err)
+		assert.Equal(t, TransferStatusPendingApproval, transfer.Status())
+		return transfer
+	}
+
+	t.Run("requester cannot approve", func(t *testing.T) {
+		transfer := newTransfer(t)
+		require.ErrorIs(t, transfer.Approve("alice", ""), ErrSelfApproval)
+		assert.Equal(t, TransferStatusPendingApproval, transfer.Status())
+	})
+
+	t.Run("second operator approves and the transfer is sent", func(t *testing.T) {
+		transfer := newTransfer(t)
+		require.NoError(t, transfer.Approve("bob", "ok"))
+		assert.Equal(t, TransferStatusApproved, transfer.Status())
+		assert.Equal(t, "bob", transfer.ReviewedBy())
+		assert.ErrorIs(t, transfer.Reject("carol", ""), ErrInvalidTransition)
+
+		require.NoError(t, transfer.ApplyTransfer(&payout.Transfer{TxHash: testTxHash, State: payout.TransferStatePending}))
+		assert.Equal(t, TransferStatusBroadcast, transfer.Status())
+		require.NoError(t, transfer.ApplyTransfer(&payout.Transfer{TxHash: testTxHash, State: payout.TransferStateConfirmed}))
+		assert.Equal(t, TransferStatusConfirmed, transfer.Status())
+	})
+
+	t.Run("a transfer awaiting approval cannot be sent", func(t *testing.T) {
+		transfer := newTransfer(t)
+		err := transfer.ApplyTransfer(&payout.Transfer{TxHash: testTxHash, State: payout.TransferStatePending})
+		assert.ErrorIs(t, err, ErrInvalidTransition)
+	})
+}
@@ -0,0 +1,205 @@
+// Package webhook sends webhooks to merchants' endpoints on request and keeps the log of their deliveries.
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DeliveryKind tells why a webhook was sent.
+type DeliveryKind string
+
+// Delivery kinds
+const (
+	DeliveryKindTest   DeliveryKind = "test"   // A synthetic event the merchant sent to check the endpoint
+	DeliveryKindReplay DeliveryKind = "replay" // A recorded delivery the merchant sent again
+)
+
+// IsValid checks if the delivery kind is valid.
+func (k DeliveryKind) IsValid() bool {
+	return k == DeliveryKindTest || k == DeliveryKindReplay
+}
+
+// DeliveryStatus is the outcome of a delivery.
+type DeliveryStatus string
+
+// Delivery statuses
+const (
+	DeliverySucceeded DeliveryStatus = "succeeded" // The endpoint answered with a 2xx status
+	DeliveryFailed    DeliveryStatus = "failed"    // The endpoint answered otherwise or could not be reached
+)
+
+// IsValid checks if the delivery status is valid.
+func (s DeliveryStatus) IsValid() bool {
+	return s == DeliverySucceeded || s == DeliveryFailed
+}
+
+// Delivery is one webhook sent to an endpoint, with what the endpoint answered.
+type Delivery struct {
+	id           string
+	merchantID   string
+	endpointID   string
+	eventID      string
+	eventType    string
+	apiVersion   string
+	kind         DeliveryKind
+	replayOf     string
+	url          string
+	payload      []byte
+	status       DeliveryStatus
+	responseCode int
+	responseBody string
+	failure      string
+	duration     time.Duration
+	createdAt    time.Time
+}
+
+// RestoreDelivery recreates a delivery from storage.
+func RestoreDelivery(
+	id, merchantID, endpointID, eventID, eventType, apiVersion string,
+	kind DeliveryKind,
+	replayOf, url string,
+	payload []byte,
+	status DeliveryStatus,
+	responseCode int,
+	responseBody, failure string,
+	duration time.Duration,
+	createdAt time.Time,
+) (*Delivery, error) {
+	if id == "" {
+		return nil, errors.New("delivery ID is required")
+	}
+	if merchantID == "" {
+		return nil, errors.New("merchant ID is required")
+	}
+	if endpointID == "" {
+		return nil, errors.New("endpoint ID is required")
+	}
+	if eventID == "" || eventType == "" {
+		return nil, errors.New("event ID and type are required")
+	}
+	if !kind.IsValid() {
+		return nil, fmt.Errorf("invalid delivery kind: %s", kind)
+	}
+	if !status.IsValid() {
+		return nil, fmt.Errorf("invalid delivery status: %s", status)
+	}
+
+	return &Delivery{
+		id:           id,
+		merchantID:   merchantID,
+		endpointID:   endpointID,
+		eventID:      eventID,
+		eventType:    eventType,
+		apiVersion:   apiVersion,
+		kind:         kind,
+		replayOf:     replayOf,
+		url:          url,
+		payload:      payload,
+		status:       status,
+		responseCode: responseCode,
+		responseBody: responseBody,
+		failure:      failure,
+		duration:     duration,
+		createdAt:    createdAt,
+	}, nil
+}
+
+// ID returns the delivery ID, sent in the webhook's delivery header.
+func (d *Delivery) ID() string {
+	return d.id
+}
+
+// MerchantID returns the merchant owning the endpoint.
+func (d *Delivery) MerchantID() string {
+	return d.merchantID
+}
+
+// EndpointID returns the endpoint the webhook was sent to.
+func (d *Delivery) EndpointID() string {
+	return d.endpointID
+}
+
+// EventID returns the ID of the event in the payload, which replays keep.
+func (d *Delivery) EventID() string {
+	return d.eventID
+}
+
+// EventType returns the type of the event in the payload.
+func (d *Delivery) EventType() string {
+	return d.eventType
+}
+
+// APIVersion returns the webhook API version the payload was rendered in.
+func (d *Delivery) APIVersion() string {
+	return d.apiVersion
+}
+
+// Kind returns why the webhook was sent.
+func (d *Delivery) Kind() DeliveryKind {
+	return d.kind
+}
+
+// ReplayOf returns the delivery a replay sent again, or an empty string.
+func (d *Delivery) ReplayOf() string {
+	return d.replayOf
+}
+
+// URL returns the URL the webhook was sent to.
+func (d *Delivery) URL() string {
+	return d.url
+}
+
+// Payload returns the webhook body.
+func (d *Delivery) Payload() []byte {
+	return d.payload
+}
+
+// Status returns the outcome of the delivery.
+func (d *Delivery) Status() DeliveryStatus {
+	return d.status
+}
+
+// ResponseCode returns the HTTP status the endpoint answered with, or 0 if it could not be reached.
+func (d *Delivery) ResponseCode() int {
+	return d.responseCode
+}
+
+// ResponseBody returns the start of the endpoint's response body.
+func (d *Delivery) ResponseBody() string {
+	return d.responseBody
+}
+
+// Failure returns why the endpoint could not be reached, or an empty string.
+func (d *Delivery) Failure() string {
+	return d.failure
+}
+
+// Duration returns how long the endpoint took to answer.
+func (d *Delivery) Duration() time.Duration {
+	return d.duration
+}
+
+// CreatedAt returns when the webhook was sent.
+func (d *Delivery) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+// record sets the outcome of sending the webhook from the endpoint's response, or the error reaching it.
+func (d *Delivery) record(response *Response, err error, duration time.Duration) {
+	d.duration = duration
+	if err != nil {
+		d.status = DeliveryFailed
+		d.failure = err.Error()
+		return
+	}
+
+	d.responseCode = response.StatusCode
+	d.responseBody = response.Body
+	d.status = DeliveryFailed
+	if response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+		d.status = DeliverySucceeded
+	}
+}
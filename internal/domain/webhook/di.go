@@ -0,0 +1,16 @@
+package webhook
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the webhook delivery service layer dependencies.
+var Module = fx.Module("webhook-service",
+	fx.Provide(
+		fx.Annotate(
+			NewService,
+			fx.As(new(Service)),
+		),
+	),
+	fx.Invoke(PublishMetrics),
+)
@@ -0,0 +1,14 @@
+package webhook
+
+import "errors"
+
+// Domain errors for webhook delivery operations
+var (
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidRequest   = errors.New("invalid webhook delivery request")
+)
+
+// Error codes for API responses
+const (
+	ErrCodeDeliveryNotFound = "WEBHOOK_DELIVERY_NOT_FOUND"
+)
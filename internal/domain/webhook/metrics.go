@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"context"
+	"crypto-checkout/pkg/metrics"
+	"fmt"
+	"time"
+)
+
+// Metrics published on /metrics.
+var (
+	deliveriesTotal = metrics.NewCounter(metrics.Namespace+"_webhook_deliveries_total",
+		"Webhooks sent to merchants' endpoints, by kind and outcome.", "kind", "status")
+	undeliveredGauge = metrics.NewGaugeFunc(metrics.Namespace+"_webhook_undelivered_events",
+		"Events whose every delivery to an endpoint failed, waiting for a replay.")
+)
+
+// undeliveredAlert is the webhook backlog the WebhookBacklog alert fires above once it lasts.
+const undeliveredAlert = 50
+
+func init() {
+	metrics.AddAlert(metrics.AlertRule{
+		Name:     "WebhookBacklog",
+		Expr:     fmt.Sprintf("max(%s) > %d", undeliveredGauge.Name(), undeliveredAlert),
+		For:      30 * time.Minute,
+		Severity: metrics.SeverityWarning,
+		Summary:  "Webhooks are piling up undelivered",
+		Description: "{{ $value }} events could not be delivered to merchants' endpoints and wait for a replay. " +
+			"Check the failing endpoints in the delivery log.",
+		Metrics: []string{undeliveredGauge.Name()},
+	})
+	metrics.AddAlert(metrics.AlertRule{
+		Name: "WebhookDeliveriesFailing",
+		Expr: fmt.Sprintf(`sum(rate(%[1]s{status="%[2]s"}[15m])) / sum(rate(%[1]s[15m])) > 0.5`,
+			deliveriesTotal.Name(), DeliveryFailed),
+		For:         15 * time.Minute,
+		Severity:    metrics.SeverityWarning,
+		Summary:     "Most webhook deliveries are failing",
+		Description: "{{ $value | humanizePercentage }} of the webhooks sent in the last 15 minutes failed.",
+		Metrics:     []string{deliveriesTotal.Name()},
+	})
+}
+
+// PublishMetrics makes /metrics report the webhook backlog kept in the delivery log.
+func PublishMetrics(repository Repository) {
+	undeliveredGauge.Collect(func(ctx context.Context) ([]metrics.Sample, error) {
+		count, err := repository.CountUndelivered(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return []metrics.Sample{{Value: float64(count)}}, nil
+	})
+}
@@ -0,0 +1,29 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for the webhook delivery log.
+type Repository interface {
+	// Save persists a delivery.
+	Save(ctx context.Context, delivery *Delivery) error
+
+	// FindByID retrieves a delivery by its ID, returning ErrDeliveryNotFound if there is none.
+	FindByID(ctx context.Context, id string) (*Delivery, error)
+
+	// List retrieves a merchant's deliveries, newest first, optionally only those to one endpoint.
+	List(ctx context.Context, merchantID, endpointID string, limit int) ([]*Delivery, error)
+
+	// ListMentioning retrieves a merchant's deliveries whose payload mentions the reference, such as an invoice
+	// ID, newest first, optionally only those to one endpoint.
+	ListMentioning(ctx context.Context, merchantID, endpointID, reference string, limit int) ([]*Delivery, error)
+
+	// CountUndelivered counts, across merchants, the events whose every delivery to an endpoint failed, which wait
+	// for a replay.
+	CountUndelivered(ctx context.Context) (int64, error)
+
+	// EndpointStats summarizes a merchant's deliveries made since the given time, per endpoint delivered to.
+	EndpointStats(ctx context.Context, merchantID string, since time.Time) ([]EndpointStats, error)
+}
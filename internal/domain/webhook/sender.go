@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+// MaxResponseSnapshot is how much of an endpoint's response body a delivery keeps.
+const MaxResponseSnapshot = 4 << 10
+
+// Request is a webhook to send to a merchant's endpoint.
+type Request struct {
+	URL            string
+	Secret         string            // Signs the body
+	PreviousSecret string            // Signs the body as well while a rotated-out secret overlaps its replacement
+	DeliveryID     string            // Differs between the deliveries, replays included, of one event
+	Headers        map[string]string // The endpoint's custom headers
+	Timeout        time.Duration
+	Body           []byte
+}
+
+// Response is what an endpoint answered to a webhook.
+type Response struct {
+	StatusCode int
+	Body       string // At most MaxResponseSnapshot bytes
+}
+
+// Sender sends webhooks signed with the secret of their endpoint.
+type Sender interface {
+	// Send posts the webhook. It fails when the endpoint cannot be reached; any response, whatever its status, is
+	// returned.
+	Send(ctx context.Context, req *Request) (*Response, error)
+}
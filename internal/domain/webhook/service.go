@@ -0,0 +1,354 @@
+package webhook
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/shared"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Limits of the delivery log listing.
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+)
+
+// MaxBulkEndpoints is the largest number of endpoints disabled at once.
+const MaxBulkEndpoints = 100
+
+// Service defines the interface for sending webhooks to merchants' endpoints on request.
+type Service interface {
+	// TestEndpoint sends a signed webhook.test event, in the endpoint's API version, to a merchant's endpoint
+	// and records the delivery, whatever its outcome.
+	TestEndpoint(ctx context.Context, merchantID, endpointID string) (*Delivery, error)
+
+	// ReplayDelivery sends the payload of a recorded delivery again to the endpoint's current URL, signed with
+	// its current secret, and records the new delivery. The payload keeps its event ID so that receivers can
+	// recognize the event.
+	ReplayDelivery(ctx context.Context, merchantID, deliveryID string) (*Delivery, error)
+
+	// GetDelivery retrieves a merchant's delivery.
+	GetDelivery(ctx context.Context, merchantID, deliveryID string) (*Delivery, error)
+
+	// ListDeliveries lists a merchant's deliveries, newest first.
+	ListDeliveries(ctx context.Context, req *ListDeliveriesRequest) ([]*Delivery, error)
+
+	// RotateSecret replaces the secret of a merchant's endpoint, the current one signing webhooks as well for the
+	// overlap of the request.
+	RotateSecret(ctx context.Context, req *RotateSecretRequest) (*merchant.WebhookEndpoint, error)
+
+	// DisableEndpoints disables a merchant's endpoints, none of them unless all are found.
+	DisableEndpoints(ctx context.Context, merchantID string, endpointIDs []string) ([]*merchant.WebhookEndpoint, error)
+
+	// EndpointStats summarizes the deliveries to each of a merchant's endpoints, those without deliveries included.
+	EndpointStats(ctx context.Context, req *EndpointStatsRequest) ([]EndpointStats, error)
+}
+
+// ListDeliveriesRequest represents the request to list a merchant's deliveries. An empty endpoint ID lists the
+// deliveries to all endpoints; a limit out of range gets DefaultListLimit.
+type ListDeliveriesRequest struct {
+	MerchantID string
+	EndpointID string
+	// Reference only lists the deliveries whose payload mentions it, such as an invoice ID.
+	Reference string
+	Limit     int
+}
+
+// RotateSecretRequest represents the request to rotate the secret of a merchant's endpoint. An empty secret gets a
+// generated one.
+type RotateSecretRequest struct {
+	MerchantID string
+	EndpointID string
+	Secret     string
+	// Overlap is how long the current secret signs webhooks as well, up to merchant.MaxSecretOverlap
+	Overlap time.Duration
+}
+
+// ServiceImpl implements the Service interface.
+type ServiceImpl struct {
+	repository Repository
+	endpoints  merchant.WebhookEndpointRepository
+	sender     Sender
+	now        func() time.Time
+	logger     *zap.Logger
+}
+
+// NewService creates a new webhook delivery service.
+func NewService(
+	repository Repository,
+	endpoints merchant.WebhookEndpointRepository,
+	sender Sender,
+	logger *zap.Logger,
+) Service {
+	return &ServiceImpl{
+		repository: repository,
+		endpoints:  endpoints,
+		sender:     sender,
+		now:        shared.Now,
+		logger:     logger,
+	}
+}
+
+// TestEndpoint sends a signed webhook.test event to a merchant's endpoint and records the delivery.
+func (s *ServiceImpl) TestEndpoint(ctx context.Context, merchantID, endpointID string) (*Delivery, error) {
+	endpoint, err := s.endpoint(ctx, merchantID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventID, err := generateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate event ID: %w", err)
+	}
+	eventID = "evt_" + eventID
+	payload, err := merchant.RenderWebhookPayload(endpoint.APIVersion(), eventID, merchant.NewTestWebhookEvent(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to render test webhook: %w", err)
+	}
+
+	return s.deliver(ctx, endpoint, DeliveryKindTest, eventID, merchant.WebhookTestEventType,
+		endpoint.APIVersion().String(), payload, "")
+}
+
+// ReplayDelivery sends the payload of a recorded delivery again and records the new delivery.
+func (s *ServiceImpl) ReplayDelivery(ctx context.Context, merchantID, deliveryID string) (*Delivery, error) {
+	original, err := s.GetDelivery(ctx, merchantID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := s.endpoint(ctx, merchantID, original.EndpointID())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.deliver(ctx, endpoint, DeliveryKindReplay, original.EventID(), original.EventType(),
+		original.APIVersion(), original.Payload(), original.ID())
+}
+
+// GetDelivery retrieves a merchant's delivery.
+func (s *ServiceImpl) GetDelivery(ctx context.Context, merchantID, deliveryID string) (*Delivery, error) {
+	if merchantID == "" || deliveryID == "" {
+		return nil, fmt.Errorf("%w: merchant ID and delivery ID are required", ErrInvalidRequest)
+	}
+
+	delivery, err := s.repository.FindByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.MerchantID() != merchantID {
+		return nil, ErrDeliveryNotFound
+	}
+	return delivery, nil
+}
+
+// ListDeliveries lists a merchant's deliveries, newest first.
+func (s *ServiceImpl) ListDeliveries(ctx context.Context, req *ListDeliveriesRequest) ([]*Delivery, error) {
+	if req == nil || req.MerchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > MaxListLimit {
+		limit = DefaultListLimit
+	}
+	if req.Reference != "" {
+		return s.repository.ListMentioning(ctx, req.MerchantID, req.EndpointID, req.Reference, limit)
+	}
+	return s.repository.List(ctx, req.MerchantID, req.EndpointID, limit)
+}
+
+// RotateSecret replaces the secret of a merchant's endpoint.
+func (s *ServiceImpl) RotateSecret(ctx context.Context, req *RotateSecretRequest) (*merchant.WebhookEndpoint, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request is required", ErrInvalidRequest)
+	}
+	endpoint, err := s.endpoint(ctx, req.MerchantID, req.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = generateSecret(); err != nil {
+			return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+	}
+	if err := endpoint.RotateSecret(secret, req.Overlap); err != nil {
+		return nil, err
+	}
+	if err := s.endpoints.Update(ctx, endpoint); err != nil {
+		return nil, fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+
+	s.logger.Info("Webhook secret rotated",
+		zap.String("endpoint_id", endpoint.ID()),
+		zap.String("merchant_id", endpoint.MerchantID()),
+		zap.Duration("overlap", req.Overlap))
+	return endpoint, nil
+}
+
+// DisableEndpoints disables a merchant's endpoints once all are found; endpoints already disabled stay so.
+func (s *ServiceImpl) DisableEndpoints(
+	ctx context.Context,
+	merchantID string,
+	endpointIDs []string,
+) ([]*merchant.WebhookEndpoint, error) {
+	if len(endpointIDs) == 0 || len(endpointIDs) > MaxBulkEndpoints {
+		return nil, fmt.Errorf("%w: between 1 and %d endpoint IDs are required", ErrInvalidRequest, MaxBulkEndpoints)
+	}
+
+	seen := make(map[string]bool, len(endpointIDs))
+	endpoints := make([]*merchant.WebhookEndpoint, 0, len(endpointIDs))
+	for _, id := range endpointIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		endpoint, err := s.endpoint(ctx, merchantID, id)
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %s: %w", id, err)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	for _, endpoint := range endpoints {
+		if endpoint.IsDisabled() {
+			continue
+		}
+		if err := endpoint.ChangeStatus(merchant.EndpointStatusDisabled); err != nil {
+			return nil, err
+		}
+		if err := s.endpoints.Update(ctx, endpoint); err != nil {
+			return nil, fmt.Errorf("failed to disable webhook endpoint %s: %w", endpoint.ID(), err)
+		}
+	}
+
+	s.logger.Info("Webhook endpoints disabled",
+		zap.String("merchant_id", merchantID),
+		zap.Int("count", len(endpoints)))
+	return endpoints, nil
+}
+
+// EndpointStats summarizes the deliveries to each of a merchant's endpoints, ordered like the endpoints.
+func (s *ServiceImpl) EndpointStats(ctx context.Context, req *EndpointStatsRequest) ([]EndpointStats, error) {
+	if req == nil || req.MerchantID == "" {
+		return nil, fmt.Errorf("%w: merchant ID is required", ErrInvalidRequest)
+	}
+	since := req.Since
+	if since.IsZero() {
+		since = s.now().Add(-DefaultStatsPeriod)
+	}
+
+	endpoints, err := s.endpoints.FindByMerchantID(ctx, req.MerchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook endpoints: %w", err)
+	}
+	delivered, err := s.repository.EndpointStats(ctx, req.MerchantID, since)
+	if err != nil {
+		return nil, err
+	}
+	byEndpoint := make(map[string]EndpointStats, len(delivered))
+	for _, stats := range delivered {
+		byEndpoint[stats.EndpointID] = stats
+	}
+
+	stats := make([]EndpointStats, len(endpoints))
+	for i, endpoint := range endpoints {
+		stats[i] = byEndpoint[endpoint.ID()]
+		stats[i].EndpointID = endpoint.ID()
+		stats[i].URL = endpoint.URL()
+		stats[i].Status = endpoint.Status()
+	}
+	return stats, nil
+}
+
+// endpoint retrieves a merchant's webhook endpoint.
+func (s *ServiceImpl) endpoint(ctx context.Context, merchantID, endpointID string) (*merchant.WebhookEndpoint, error) {
+	if merchantID == "" || endpointID == "" {
+		return nil, fmt.Errorf("%w: merchant ID and endpoint ID are required", ErrInvalidRequest)
+	}
+
+	endpoint, err := s.endpoints.FindByID(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint == nil || endpoint.MerchantID() != merchantID {
+		return nil, merchant.ErrWebhookEndpointNotFound
+	}
+	return endpoint, nil
+}
+
+// deliver sends a payload to an endpoint and records the delivery. An endpoint that cannot be reached or
+// rejects the webhook makes a failed delivery, not an error.
+func (s *ServiceImpl) deliver(
+	ctx context.Context,
+	endpoint *merchant.WebhookEndpoint,
+	kind DeliveryKind,
+	eventID, eventType, apiVersion string,
+	payload []byte,
+	replayOf string,
+) (*Delivery, error) {
+	id, err := generateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate delivery ID: %w", err)
+	}
+	sentAt := s.now().UTC()
+	delivery, err := RestoreDelivery(id, endpoint.MerchantID(), endpoint.ID(), eventID, eventType, apiVersion, kind,
+		replayOf, endpoint.URL(), payload, DeliveryFailed, 0, "", "", 0, sentAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	response, sendErr := s.sender.Send(ctx, &Request{
+		URL:            endpoint.URL(),
+		Secret:         endpoint.Secret(),
+		PreviousSecret: endpoint.PreviousSecret(),
+		DeliveryID:     id,
+		Headers:        endpoint.Headers(),
+		Timeout:        time.Duration(endpoint.Timeout()) * time.Second,
+		Body:           payload,
+	})
+	if sendErr == nil && response == nil {
+		sendErr = errors.New("no response")
+	}
+	delivery.record(response, sendErr, s.now().Sub(sentAt))
+
+	if err := s.repository.Save(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+	deliveriesTotal.Inc(string(delivery.Kind()), string(delivery.Status()))
+
+	s.logger.Info("Webhook delivered",
+		zap.String("delivery_id", delivery.ID()),
+		zap.String("endpoint_id", delivery.EndpointID()),
+		zap.String("merchant_id", delivery.MerchantID()),
+		zap.String("kind", string(delivery.Kind())),
+		zap.String("status", string(delivery.Status())),
+		zap.Int("response_code", delivery.ResponseCode()))
+
+	return delivery, nil
+}
+
+// generateSecret returns a random webhook secret.
+func generateSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(bytes), nil
+}
+
+// generateID returns a random identifier.
+func generateID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
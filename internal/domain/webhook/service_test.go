@@ -0,0 +1,387 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/shared"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeRepository struct {
+	deliveries []*Delivery
+}
+
+func (r *fakeRepository) Save(_ context.Context, delivery *Delivery) error {
+	r.deliveries = append(r.deliveries, delivery)
+	return nil
+}
+
+func (r *fakeRepository) FindByID(_ context.Context, id string) (*Delivery, error) {
+	for _, delivery := range r.deliveries {
+		if delivery.ID() == id {
+			return delivery, nil
+		}
+	}
+	return nil, ErrDeliveryNotFound
+}
+
+func (r *fakeRepository) List(_ context.Context, merchantID, endpointID string, limit int) ([]*Delivery, error) {
+	var deliveries []*Delivery
+	for i := len(r.deliveries) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		delivery := r.deliveries[i]
+		if delivery.MerchantID() == merchantID && (endpointID == "" || delivery.EndpointID() == endpointID) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *fakeRepository) ListMentioning(
+	ctx context.Context,
+	merchantID, endpointID, reference string,
+	limit int,
+) ([]*Delivery, error) {
+	all, _ := r.List(ctx, merchantID, endpointID, len(r.deliveries))
+	var deliveries []*Delivery
+	for _, delivery := range all {
+		if len(deliveries) < limit && strings.Contains(string(delivery.Payload()), reference) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *fakeRepository) CountUndelivered(context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (r *fakeRepository) EndpointStats(_ context.Context, merchantID string, since time.Time) ([]EndpointStats, error) {
+	var stats []EndpointStats
+	for _, delivery := range r.deliveries {
+		if delivery.MerchantID() != merchantID || delivery.CreatedAt().Before(since) {
+			continue
+		}
+		if len(stats) == 0 || stats[len(stats)-1].EndpointID != delivery.EndpointID() {
+			stats = append(stats, EndpointStats{EndpointID: delivery.EndpointID()})
+		}
+		last := &stats[len(stats)-1]
+		last.Deliveries++
+		last.LastDeliveryAt = delivery.CreatedAt()
+		if delivery.Status() == DeliverySucceeded {
+			last.Succeeded++
+		} else {
+			last.Failed++
+		}
+	}
+	return stats, nil
+}
+
+// fakeEndpoints finds and updates endpoints; the service does nothing else with them.
+type fakeEndpoints struct {
+	merchant.WebhookEndpointRepository
+	endpoints map[string]*merchant.WebhookEndpoint
+	updates   int
+}
+
+func (r *fakeEndpoints) FindByMerchantID(_ context.Context, merchantID string) ([]*merchant.WebhookEndpoint, error) {
+	var endpoints []*merchant.WebhookEndpoint
+	for _, endpoint := range r.endpoints {
+		if endpoint.MerchantID() == merchantID {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	slices.SortFunc(endpoints, func(a, b *merchant.WebhookEndpoint) int { return strings.Compare(a.ID(), b.ID()) })
+	return endpoints, nil
+}
+
+func (r *fakeEndpoints) Update(context.Context, *merchant.WebhookEndpoint) error {
+	r.updates++
+	return nil
+}
+
+func (r *fakeEndpoints) FindByID(_ context.Context, id string) (*merchant.WebhookEndpoint, error) {
+	endpoint, ok := r.endpoints[id]
+	if !ok {
+		return nil, merchant.ErrWebhookEndpointNotFound
+	}
+	return endpoint, nil
+}
+
+type fakeSender struct {
+	requests []*Request
+	response *Response
+	err      error
+}
+
+func (s *fakeSender) Send(_ context.Context, req *Request) (*Response, error) {
+	s.requests = append(s.requests, req)
+	return s.response, s.err
+}
+
+func newTestService(t *testing.T) (*ServiceImpl, *fakeRepository, *fakeSender, *merchant.WebhookEndpoint) {
+	t.Helper()
+	endpoint, err := merchant.NewWebhookEndpoint("whe-1", "merchant-1", "https://merchant.example/webhook",
+		[]string{"invoice.paid"}, "whsec_0123456789abcdef0123456789abcdef", 3, merchant.BackoffStrategyExponential,
+		10, nil, map[string]string{"X-Shop": "main"})
+	require.NoError(t, err)
+
+	repository := &fakeRepository{}
+	sender := &fakeSender{response: &Response{StatusCode: http.StatusOK, Body: "ok"}}
+	service := NewService(repository, &fakeEndpoints{endpoints: map[string]*merchant.WebhookEndpoint{
+		endpoint.ID(): endpoint,
+	}}, sender, zap.NewNop()).(*ServiceImpl)
+	return service, repository, sender, endpoint
+}
+
+func TestService_TestEndpoint(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("SendsAndRecordsTheTestEvent", func(t *testing.T) {
+		service, repository, sender, endpoint := newTestService(t)
+
+		delivery, err := service.TestEndpoint(ctx, "merchant-1", endpoint.ID())
+		require.NoError(t, err)
+		assert.Equal(t, DeliveryKindTest, delivery.Kind())
+		assert.Equal(t, DeliverySucceeded, delivery.Status())
+		assert.Equal(t, http.StatusOK, delivery.ResponseCode())
+		assert.Equal(t, "ok", delivery.ResponseBody())
+		assert.Equal(t, merchant.WebhookTestEventType, delivery.EventType())
+		assert.Equal(t, endpoint.APIVersion().String(), delivery.APIVersion())
+		assert.Equal(t, []*Delivery{delivery}, repository.deliveries)
+
+		require.Len(t, sender.requests, 1)
+		request := sender.requests[0]
+		assert.Equal(t, endpoint.URL(), request.URL)
+		assert.Equal(t, endpoint.Secret(), request.Secret)
+		assert.Equal(t, delivery.ID(), request.DeliveryID)
+		assert.Equal(t, "main", request.Headers["X-Shop"])
+		assert.Equal(t, 10*time.Second, request.Timeout)
+		assert.Equal(t, delivery.Payload(), request.Body)
+
+		var payload merchant.WebhookPayload
+		require.NoError(t, json.Unmarshal(request.Body, &payload))
+		assert.Equal(t, delivery.EventID(), payload.ID)
+		assert.Equal(t, merchant.WebhookTestEventType, payload.Type)
+	})
+
+	t.Run("RejectedWebhook_RecordsFailedDelivery", func(t *testing.T) {
+		service, repository, sender, endpoint := newTestService(t)
+		sender.response = &Response{StatusCode: http.StatusInternalServerError, Body: "boom"}
+
+		delivery, err := service.TestEndpoint(ctx, "merchant-1", endpoint.ID())
+		require.NoError(t, err)
+		assert.Equal(t, DeliveryFailed, delivery.Status())
+		assert.Equal(t, http.StatusInternalServerError, delivery.ResponseCode())
+		assert.Equal(t, "boom", delivery.ResponseBody())
+		assert.Len(t, repository.deliveries, 1)
+	})
+
+	t.Run("UnreachableEndpoint_RecordsFailedDelivery", func(t *testing.T) {
+		service, repository, sender, endpoint := newTestService(t)
+		sender.response, sender.err = nil, errors.New("connection refused")
+
+		delivery, err := service.TestEndpoint(ctx, "merchant-1", endpoint.ID())
+		require.NoError(t, err)
+		assert.Equal(t, DeliveryFailed, delivery.Status())
+		assert.Zero(t, delivery.ResponseCode())
+		assert.Contains(t, delivery.Failure(), "connection refused")
+		assert.Len(t, repository.deliveries, 1)
+	})
+
+	t.Run("OtherMerchantsEndpoint_NotFound", func(t *testing.T) {
+		service, repository, sender, endpoint := newTestService(t)
+
+		_, err := service.TestEndpoint(ctx, "merchant-2", endpoint.ID())
+		require.ErrorIs(t, err, merchant.ErrWebhookEndpointNotFound)
+		assert.Empty(t, sender.requests)
+		assert.Empty(t, repository.deliveries)
+	})
+}
+
+func TestService_ReplayDelivery(t *testing.T) {
+	ctx := context.Background()
+	service, repository, sender, endpoint := newTestService(t)
+
+	original, err := service.TestEndpoint(ctx, "merchant-1", endpoint.ID())
+	require.NoError(t, err)
+
+	t.Run("SendsThePayloadAgain", func(t *testing.T) {
+		replay, err := service.ReplayDelivery(ctx, "merchant-1", original.ID())
+		require.NoError(t, err)
+		assert.NotEqual(t, original.ID(), replay.ID())
+		assert.Equal(t, DeliveryKindReplay, replay.Kind())
+		assert.Equal(t, original.ID(), replay.ReplayOf())
+		assert.Equal(t, original.EventID(), replay.EventID())
+		assert.Equal(t, original.Payload(), replay.Payload())
+		assert.Len(t, repository.deliveries, 2)
+
+		require.Len(t, sender.requests, 2)
+		assert.Equal(t, original.Payload(), sender.requests[1].Body)
+		assert.Equal(t, replay.ID(), sender.requests[1].DeliveryID)
+	})
+
+	t.Run("OtherMerchantsDelivery_NotFound", func(t *testing.T) {
+		_, err := service.ReplayDelivery(ctx, "merchant-2", original.ID())
+		require.ErrorIs(t, err, ErrDeliveryNotFound)
+	})
+
+	t.Run("ListsNewestFirst", func(t *testing.T) {
+		deliveries, err := service.ListDeliveries(ctx, &ListDeliveriesRequest{MerchantID: "merchant-1"})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		assert.Equal(t, DeliveryKindReplay, deliveries[0].Kind())
+		assert.Equal(t, DeliveryKindTest, deliveries[1].Kind())
+	})
+}
+
+func TestService_RotateSecret(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("SignsWithBothSecretsDuringTheOverlap", func(t *testing.T) {
+		service, _, sender, endpoint := newTestService(t)
+		previous := endpoint.Secret()
+
+		rotated, err := service.RotateSecret(ctx, &RotateSecretRequest{
+			MerchantID: "merchant-1",
+			EndpointID: endpoint.ID(),
+			Overlap:    time.Hour,
+		})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(rotated.Secret(), "whsec_"), "a secret is generated")
+		assert.NotEqual(t, previous, rotated.Secret())
+		assert.Equal(t, previous, rotated.PreviousSecret())
+		assert.Equal(t, 1, service.endpoints.(*fakeEndpoints).updates)
+
+		_, err = service.TestEndpoint(ctx, "merchant-1", endpoint.ID())
+		require.NoError(t, err)
+		require.Len(t, sender.requests, 1)
+		assert.Equal(t, rotated.Secret(), sender.requests[0].Secret)
+		assert.Equal(t, previous, sender.requests[0].PreviousSecret)
+	})
+
+	t.Run("WithoutOverlap_ReplacesTheSecretAtOnce", func(t *testing.T) {
+		service, _, sender, endpoint := newTestService(t)
+		secret := "whsec_fedcba9876543210fedcba9876543210"
+
+		rotated, err := service.RotateSecret(ctx, &RotateSecretRequest{
+			MerchantID: "merchant-1",
+			EndpointID: endpoint.ID(),
+			Secret:     secret,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, secret, rotated.Secret())
+		assert.Empty(t, rotated.PreviousSecret())
+
+		_, err = service.TestEndpoint(ctx, "merchant-1", endpoint.ID())
+		require.NoError(t, err)
+		assert.Empty(t, sender.requests[0].PreviousSecret)
+	})
+
+	t.Run("InvalidOverlap", func(t *testing.T) {
+		service, _, _, endpoint := newTestService(t)
+
+		_, err := service.RotateSecret(ctx, &RotateSecretRequest{
+			MerchantID: "merchant-1",
+			EndpointID: endpoint.ID(),
+			Overlap:    merchant.MaxSecretOverlap + time.Hour,
+		})
+		require.ErrorIs(t, err, shared.ErrValidation)
+		assert.Equal(t, "overlap", shared.FieldOf(err))
+		assert.Zero(t, service.endpoints.(*fakeEndpoints).updates)
+	})
+
+	t.Run("OtherMerchantsEndpoint_NotFound", func(t *testing.T) {
+		service, _, _, endpoint := newTestService(t)
+
+		_, err := service.RotateSecret(ctx, &RotateSecretRequest{MerchantID: "merchant-2", EndpointID: endpoint.ID()})
+		require.ErrorIs(t, err, merchant.ErrWebhookEndpointNotFound)
+	})
+}
+
+func TestService_DisableEndpoints(t *testing.T) {
+	ctx := context.Background()
+	service, _, _, endpoint := newTestService(t)
+	endpoints := service.endpoints.(*fakeEndpoints)
+	other, err := merchant.NewWebhookEndpoint("whe-2", "merchant-1", "https://merchant.example/other",
+		[]string{"invoice.paid"}, "whsec_0123456789abcdef0123456789abcdef", 3, merchant.BackoffStrategyExponential,
+		10, nil, nil)
+	require.NoError(t, err)
+	endpoints.endpoints[other.ID()] = other
+
+	t.Run("NoneUnlessAllAreFound", func(t *testing.T) {
+		_, err := service.DisableEndpoints(ctx, "merchant-1", []string{endpoint.ID(), "whe-unknown"})
+		require.ErrorIs(t, err, merchant.ErrWebhookEndpointNotFound)
+		assert.True(t, endpoint.IsActive())
+		assert.Zero(t, endpoints.updates)
+	})
+
+	t.Run("DisablesEach", func(t *testing.T) {
+		disabled, err := service.DisableEndpoints(ctx, "merchant-1", []string{endpoint.ID(), other.ID(), endpoint.ID()})
+		require.NoError(t, err)
+		require.Len(t, disabled, 2)
+		assert.True(t, endpoint.IsDisabled())
+		assert.True(t, other.IsDisabled())
+		assert.Equal(t, 2, endpoints.updates)
+
+		_, err = service.DisableEndpoints(ctx, "merchant-1", []string{endpoint.ID()})
+		require.NoError(t, err)
+		assert.Equal(t, 2, endpoints.updates, "disabled endpoints are left alone")
+	})
+
+	t.Run("TooMany", func(t *testing.T) {
+		_, err := service.DisableEndpoints(ctx, "merchant-1", make([]string, MaxBulkEndpoints+1))
+		require.ErrorIs(t, err, ErrInvalidRequest)
+	})
+}
+
+func TestService_EndpointStats(t *testing.T) {
+	ctx := context.Background()
+	service, _, sender, endpoint := newTestService(t)
+	idle, err := merchant.NewWebhookEndpoint("whe-2", "merchant-1", "https://merchant.example/idle",
+		[]string{"invoice.paid"}, "whsec_0123456789abcdef0123456789abcdef", 3, merchant.BackoffStrategyExponential,
+		10, nil, nil)
+	require.NoError(t, err)
+	service.endpoints.(*fakeEndpoints).endpoints[idle.ID()] = idle
+
+	_, err = service.TestEndpoint(ctx, "merchant-1", endpoint.ID())
+	require.NoError(t, err)
+	sender.response = &Response{StatusCode: http.StatusBadGateway}
+	_, err = service.TestEndpoint(ctx, "merchant-1", endpoint.ID())
+	require.NoError(t, err)
+
+	stats, err := service.EndpointStats(ctx, &EndpointStatsRequest{MerchantID: "merchant-1"})
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, endpoint.ID(), stats[0].EndpointID)
+	assert.Equal(t, endpoint.URL(), stats[0].URL)
+	assert.Equal(t, merchant.EndpointStatusActive, stats[0].Status)
+	assert.Equal(t, 2, stats[0].Deliveries)
+	assert.Equal(t, 1, stats[0].Succeeded)
+	assert.Equal(t, 1, stats[0].Failed)
+	assert.InDelta(t, 0.5, stats[0].SuccessRate(), 0.001)
+
+	assert.Equal(t, idle.ID(), stats[1].EndpointID, "endpoints without deliveries are included")
+	assert.Zero(t, stats[1].Deliveries)
+	assert.Zero(t, stats[1].SuccessRate())
+
+	data, err := RenderEndpointStatsCSV(stats)
+	require.NoError(t, err)
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, "endpoint_id", rows[0][0])
+	assert.Equal(t, []string{endpoint.ID(), endpoint.URL(), "active", "2", "1", "1", "0.5000"}, rows[1][:7])
+	assert.Empty(t, rows[2][8], "no last delivery")
+}
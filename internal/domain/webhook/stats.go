@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto-checkout/internal/domain/merchant"
+	"encoding/csv"
+	"strconv"
+	"time"
+)
+
+// DefaultStatsPeriod is how far back delivery statistics go when no start is given.
+const DefaultStatsPeriod = 30 * 24 * time.Hour
+
+// EndpointStats summarizes the deliveries to one endpoint since a point in time.
+type EndpointStats struct {
+	EndpointID string
+	// URL and Status are the endpoint's current ones, filled in by the service
+	URL           string
+	Status        merchant.EndpointStatus
+	Deliveries    int
+	Succeeded     int
+	Failed        int
+	TotalDuration time.Duration
+	// LastDeliveryAt, LastSuccessAt and LastFailureAt are zero without such a delivery
+	LastDeliveryAt time.Time
+	LastSuccessAt  time.Time
+	LastFailureAt  time.Time
+}
+
+// SuccessRate returns the share of the deliveries that succeeded, between 0 and 1; 0 without deliveries.
+func (s *EndpointStats) SuccessRate() float64 {
+	if s.Deliveries == 0 {
+		return 0
+	}
+	return float64(s.Succeeded) / float64(s.Deliveries)
+}
+
+// AverageDuration returns how long the endpoint took to answer on average; 0 without deliveries.
+func (s *EndpointStats) AverageDuration() time.Duration {
+	if s.Deliveries == 0 {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(s.Deliveries)
+}
+
+// EndpointStatsRequest represents the request for the delivery statistics of a merchant's endpoints. A zero Since
+// gets the last DefaultStatsPeriod.
+type EndpointStatsRequest struct {
+	MerchantID string
+	Since      time.Time
+}
+
+// statsCSVHeader is the header row of exported delivery statistics.
+var statsCSVHeader = []string{
+	"endpoint_id", "url", "status", "deliveries", "succeeded", "failed", "success_rate", "average_duration_ms",
+	"last_delivery_at", "last_success_at", "last_failure_at",
+}
+
+// RenderEndpointStatsCSV renders delivery statistics as CSV, with a row per endpoint.
+func RenderEndpointStatsCSV(stats []EndpointStats) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	rows := [][]string{statsCSVHeader}
+	for i := range stats {
+		s := &stats[i]
+		rows = append(rows, []string{
+			s.EndpointID,
+			s.URL,
+			string(s.Status),
+			strconv.Itoa(s.Deliveries),
+			strconv.Itoa(s.Succeeded),
+			strconv.Itoa(s.Failed),
+			strconv.FormatFloat(s.SuccessRate(), 'f', 4, 64),
+			strconv.FormatInt(s.AverageDuration().Milliseconds(), 10),
+			formatStatsTime(s.LastDeliveryAt),
+			formatStatsTime(s.LastSuccessAt),
+			formatStatsTime(s.LastFailureAt),
+		})
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// formatStatsTime formats a time of the statistics, leaving zero times empty.
+func formatStatsTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
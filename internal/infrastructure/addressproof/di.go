@@ -0,0 +1,17 @@
+package addressproof
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+
+	"go.uber.org/fx"
+)
+
+// Module provides the verifier of the refund addresses customers sign for Fx.
+var Module = fx.Module("addressproof",
+	fx.Provide(
+		fx.Annotate(
+			NewVerifier,
+			fx.As(new(invoice.RefundAddressVerifier)),
+		),
+	),
+)
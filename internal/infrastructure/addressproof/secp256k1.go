@@ -0,0 +1,135 @@
+package addressproof
+
+import (
+	"errors"
+	"math/big"
+)
+
+// The secp256k1 curve y² = x³ + 7 over the prime field p, with base point G of order n.
+var (
+	curveP  = hexInt("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	curveN  = hexInt("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141")
+	curveB  = big.NewInt(7)
+	curveGx = hexInt("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	curveGy = hexInt("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8")
+)
+
+var errInvalidSignature = errors.New("invalid signature")
+
+// point is an affine point on the curve; nil is the point at infinity.
+type point struct {
+	x, y *big.Int
+}
+
+func hexInt(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 16)
+	return n
+}
+
+// add returns a + b.
+func add(a, b *point) *point {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.x.Cmp(b.x) == 0:
+		if a.y.Cmp(b.y) != 0 || a.y.Sign() == 0 {
+			return nil
+		}
+		return double(a)
+	}
+
+	// λ = (b.y - a.y) / (b.x - a.x)
+	num := new(big.Int).Sub(b.y, a.y)
+	den := new(big.Int).Sub(b.x, a.x)
+	den.ModInverse(den.Mod(den, curveP), curveP)
+	lambda := num.Mul(num, den)
+	lambda.Mod(lambda, curveP)
+	return fromSlope(lambda, a, b.x)
+}
+
+// double returns a + a.
+func double(a *point) *point {
+	if a == nil || a.y.Sign() == 0 {
+		return nil
+	}
+
+	// λ = 3·x² / 2·y
+	num := new(big.Int).Mul(a.x, a.x)
+	num.Mul(num, big.NewInt(3))
+	den := new(big.Int).Lsh(a.y, 1)
+	den.ModInverse(den.Mod(den, curveP), curveP)
+	lambda := num.Mul(num, den)
+	lambda.Mod(lambda, curveP)
+	return fromSlope(lambda, a, a.x)
+}
+
+// fromSlope returns the third point on the line of slope λ through a and a point with x coordinate bx,
+// reflected over the x axis.
+func fromSlope(lambda *big.Int, a *point, bx *big.Int) *point {
+	x := new(big.Int).Mul(lambda, lambda)
+	x.Sub(x, a.x)
+	x.Sub(x, bx)
+	x.Mod(x, curveP)
+
+	y := new(big.Int).Sub(a.x, x)
+	y.Mul(y, lambda)
+	y.Sub(y, a.y)
+	y.Mod(y, curveP)
+	return &point{x: x, y: y}
+}
+
+// multiply returns k·a by double-and-add. Timing does not matter: only public values are multiplied.
+func multiply(a *point, k *big.Int) *point {
+	var result *point
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		result = double(result)
+		if k.Bit(i) == 1 {
+			result = add(result, a)
+		}
+	}
+	return result
+}
+
+// recoverPublicKey returns the public key whose signature (r, s) with recovery ID recID signs the hash.
+func recoverPublicKey(hash []byte, r, s *big.Int, recID byte) (*point, error) {
+	if recID > 1 || r.Sign() <= 0 || r.Cmp(curveN) >= 0 || s.Sign() <= 0 || s.Cmp(curveN) >= 0 {
+		return nil, errInvalidSignature
+	}
+
+	// R is the point with x coordinate r whose y parity is given by the recovery ID
+	y2 := new(big.Int).Exp(r, big.NewInt(3), curveP)
+	y2.Add(y2, curveB).Mod(y2, curveP)
+	exponent := new(big.Int).Add(curveP, big.NewInt(1))
+	y := new(big.Int).Exp(y2, exponent.Rsh(exponent, 2), curveP)
+	if new(big.Int).Exp(y, big.NewInt(2), curveP).Cmp(y2) != 0 {
+		return nil, errInvalidSignature
+	}
+	if y.Bit(0) != uint(recID) {
+		y.Sub(curveP, y)
+	}
+	rPoint := &point{x: new(big.Int).Set(r), y: y}
+
+	// Q = r⁻¹·(s·R - e·G)
+	e := new(big.Int).SetBytes(hash)
+	rInv := new(big.Int).ModInverse(r, curveN)
+	u1 := new(big.Int).Mul(e, rInv)
+	u1.Neg(u1).Mod(u1, curveN)
+	u2 := new(big.Int).Mul(s, rInv)
+	u2.Mod(u2, curveN)
+
+	q := add(multiply(&point{x: curveGx, y: curveGy}, u1), multiply(rPoint, u2))
+	if q == nil {
+		return nil, errInvalidSignature
+	}
+	return q, nil
+}
+
+// bytes returns the uncompressed public key without its 0x04 prefix.
+func (a *point) bytes() []byte {
+	out := make([]byte, 64)
+	a.x.FillBytes(out[:32])
+	a.y.FillBytes(out[32:])
+	return out
+}
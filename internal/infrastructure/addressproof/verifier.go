@@ -0,0 +1,111 @@
+// Package addressproof verifies that a customer controls a blockchain address by a message signed with it.
+package addressproof
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// ErrUnsupportedNetwork is returned for networks whose signed messages are not checked.
+var ErrUnsupportedNetwork = errors.New("signed messages are not supported on this network")
+
+// Signed message prefixes of personal_sign (EIP-191) and its Tron counterpart (TIP-191).
+const (
+	ethereumMessagePrefix = "\x19Ethereum Signed Message:\n"
+	tronMessagePrefix     = "\x19TRON Signed Message:\n"
+)
+
+const (
+	tronAddressPrefix = 0x41
+	base58Alphabet    = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+)
+
+// Verifier checks personal_sign signatures on Ethereum and BSC, and signMessageV2 signatures on Tron, the
+// formats wallets produce for a plain text message. Bitcoin message signatures are not supported.
+type Verifier struct{}
+
+var _ invoice.RefundAddressVerifier = Verifier{}
+
+// NewVerifier creates a new signed message verifier.
+func NewVerifier() Verifier {
+	return Verifier{}
+}
+
+// VerifySignature returns nil if the hex encoded 65-byte signature of the message recovers to the address.
+func (Verifier) VerifySignature(network shared.BlockchainNetwork, address, message, signature string) error {
+	var prefix string
+	switch network.Mainnet() {
+	case shared.NetworkEthereum, shared.NetworkBSC:
+		prefix = ethereumMessagePrefix
+	case shared.NetworkTron:
+		prefix = tronMessagePrefix
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedNetwork, network)
+	}
+
+	raw, err := hex.DecodeString(strings.TrimPrefix(signature, "0x"))
+	if err != nil || len(raw) != 65 {
+		return errInvalidSignature
+	}
+	recID := raw[64]
+	if recID >= 27 {
+		recID -= 27
+	}
+
+	hash := keccak256([]byte(prefix + strconv.Itoa(len(message)) + message))
+	publicKey, err := recoverPublicKey(hash, new(big.Int).SetBytes(raw[:32]), new(big.Int).SetBytes(raw[32:64]), recID)
+	if err != nil {
+		return err
+	}
+
+	if !addressMatches(network, address, keccak256(publicKey.bytes())[12:]) {
+		return errors.New("signature was made with another address")
+	}
+	return nil
+}
+
+// addressMatches reports whether the address is the network's encoding of the 20-byte account.
+func addressMatches(network shared.BlockchainNetwork, address string, account []byte) bool {
+	if network.Mainnet() == shared.NetworkTron {
+		return address == encodeTronAddress(append([]byte{tronAddressPrefix}, account...))
+	}
+	return strings.EqualFold(address, "0x"+hex.EncodeToString(account))
+}
+
+func keccak256(data []byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(data)
+	return hash.Sum(nil)
+}
+
+// encodeTronAddress returns the base58check form of a 21-byte Tron address.
+func encodeTronAddress(address []byte) string {
+	first := sha256.Sum256(address)
+	second := sha256.Sum256(first[:])
+	payload := append(slices.Clone(address), second[:4]...)
+
+	var encoded []byte
+	number, radix, digit := new(big.Int).SetBytes(payload), big.NewInt(58), new(big.Int)
+	for number.Sign() > 0 {
+		number.DivMod(number, radix, digit)
+		encoded = append(encoded, base58Alphabet[digit.Int64()])
+	}
+	for _, b := range payload {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+	slices.Reverse(encoded)
+	return string(encoded)
+}
@@ -0,0 +1,83 @@
+package addressproof
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"encoding/hex"
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// sign returns the 65-byte personal_sign style signature of the message with the private key, using the
+// given nonce; tests only, a fixed nonce leaks the key.
+func sign(t *testing.T, key, nonce *big.Int, prefix, message string) string {
+	t.Helper()
+	hash := keccak256([]byte(prefix + strconv.Itoa(len(message)) + message))
+
+	r := multiply(&point{x: curveGx, y: curveGy}, nonce)
+	rx := new(big.Int).Mod(r.x, curveN)
+	s := new(big.Int).Mul(rx, key)
+	s.Add(s, new(big.Int).SetBytes(hash))
+	s.Mul(s, new(big.Int).ModInverse(nonce, curveN))
+	s.Mod(s, curveN)
+
+	raw := make([]byte, 65)
+	rx.FillBytes(raw[:32])
+	s.FillBytes(raw[32:64])
+	raw[64] = 27 + byte(r.y.Bit(0))
+	return "0x" + hex.EncodeToString(raw)
+}
+
+// account returns the 20-byte account of the private key.
+func account(key *big.Int) []byte {
+	return keccak256(multiply(&point{x: curveGx, y: curveGy}, key).bytes())[12:]
+}
+
+func TestAccount_KnownKey(t *testing.T) {
+	// The well-known account of private key 1
+	require.Equal(t, "7e5f4552091a69125d5dfcb7b8c2659029395bdf", hex.EncodeToString(account(big.NewInt(1))))
+}
+
+func TestVerifier_VerifySignature(t *testing.T) {
+	verifier := NewVerifier()
+	key := hexInt("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	nonce := hexInt("1d0e6c7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b2c1d0e9f8a7b6c5d4e3f2a1b0c9d")
+	ethAddress := "0x" + hex.EncodeToString(account(key))
+	tronAddress := encodeTronAddress(append([]byte{tronAddressPrefix}, account(key)...))
+	message := "Refund invoice inv_1 to " + ethAddress
+
+	t.Run("ethereum personal_sign", func(t *testing.T) {
+		signature := sign(t, key, nonce, ethereumMessagePrefix, message)
+		require.NoError(t, verifier.VerifySignature(shared.NetworkEthereum, ethAddress, message, signature))
+		require.NoError(t, verifier.VerifySignature(shared.NetworkBSC, ethAddress, message, signature))
+	})
+
+	t.Run("tron signMessageV2", func(t *testing.T) {
+		require.Equal(t, byte('T'), tronAddress[0])
+		signature := sign(t, key, nonce, tronMessagePrefix, message)
+		require.NoError(t, verifier.VerifySignature(shared.NetworkTron, tronAddress, message, signature))
+	})
+
+	t.Run("another address", func(t *testing.T) {
+		signature := sign(t, key, nonce, ethereumMessagePrefix, message)
+		other := "0x" + hex.EncodeToString(account(big.NewInt(1)))
+		require.Error(t, verifier.VerifySignature(shared.NetworkEthereum, other, message, signature))
+	})
+
+	t.Run("another message", func(t *testing.T) {
+		signature := sign(t, key, nonce, ethereumMessagePrefix, message)
+		require.Error(t, verifier.VerifySignature(shared.NetworkEthereum, ethAddress, message+"!", signature))
+	})
+
+	t.Run("malformed signature", func(t *testing.T) {
+		require.Error(t, verifier.VerifySignature(shared.NetworkEthereum, ethAddress, message, "0x1234"))
+		require.Error(t, verifier.VerifySignature(shared.NetworkEthereum, ethAddress, message, "not hex"))
+	})
+
+	t.Run("bitcoin is not supported", func(t *testing.T) {
+		err := verifier.VerifySignature(shared.NetworkBitcoin, "bc1qexampleaddress", message, "0x00")
+		require.ErrorIs(t, err, ErrUnsupportedNetwork)
+	})
+}
@@ -0,0 +1,240 @@
+package backup
+
+import (
+	"archive/tar"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Entries of the tar archive. Entries ending in .enc are encrypted with the backup passphrase.
+const (
+	entryManifest         = "manifest.json"
+	entryMerchant         = "merchant.json"
+	entryInvoices         = "invoices.json"
+	entryPayments         = "payments.json"
+	entryPaymentEvents    = "payment_events.json"
+	entryPaymentSnapshots = "payment_snapshots.json"
+	entrySettlements      = "settlements.json"
+	entryArchivedInvoices = "archived_invoices.json"
+	entryNumberSequences  = "invoice_number_sequences.json"
+	entryIDSequences      = "id_sequences.json"
+	entryWebhookEndpoints = "webhook_endpoints.json.enc"
+	entryAPIKeys          = "api_keys.json.enc"
+)
+
+// Key derivation parameters for the passphrase, and the salt size.
+const (
+	scryptN  = 1 << 15
+	scryptR  = 8
+	scryptP  = 1
+	keyBytes = 32
+	saltSize = 16
+)
+
+// Errors returned when reading an archive.
+var (
+	ErrPassphraseRequired = errors.New("backup passphrase is required")
+	ErrWrongPassphrase    = errors.New("wrong backup passphrase or corrupted archive")
+	ErrUnsupportedVersion = errors.New("unsupported archive version")
+	ErrIncompleteArchive  = errors.New("archive is incomplete")
+)
+
+// archiveEntry binds an entry name to the archive field holding its rows. Optional entries were added after
+// the first archives were written, which read as having none of those rows.
+type archiveEntry struct {
+	name      string
+	value     interface{}
+	encrypted bool
+	optional  bool
+}
+
+// entries lists the entries of an archive in the order they are written.
+func (a *Archive) entries() []archiveEntry {
+	return []archiveEntry{
+		{entryManifest, &a.Manifest, false, false},
+		{entryMerchant, &a.Merchant, false, false},
+		{entryInvoices, &a.Invoices, false, false},
+		{entryPayments, &a.Payments, false, false},
+		{entryPaymentEvents, &a.PaymentEvents, false, false},
+		{entryPaymentSnapshots, &a.PaymentSnapshots, false, false},
+		{entrySettlements, &a.Settlements, false, false},
+		{entryWebhookEndpoints, &a.WebhookEndpoints, true, false},
+		{entryAPIKeys, &a.APIKeys, true, false},
+		{entryArchivedInvoices, &a.ArchivedInvoices, false, true},
+		{entryNumberSequences, &a.InvoiceNumberSequences, false, true},
+		{entryIDSequences, &a.IDSequences, false, true},
+	}
+}
+
+// Write writes an archive as a zstd-compressed tar, encrypting the entries that carry secrets.
+func Write(w io.Writer, archive *Archive, passphrase string) error {
+	if passphrase == "" {
+		return ErrPassphraseRequired
+	}
+
+	zw, err := zstd.NewWriter(w)
+	if err != nil {
+		return fmt.Errorf("failed to create zstd writer: %w", err)
+	}
+	tw := tar.NewWriter(zw)
+
+	for _, entry := range archive.entries() {
+		data, err := json.Marshal(entry.value)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", entry.name, err)
+		}
+		if entry.encrypted {
+			if data, err = seal(data, passphrase); err != nil {
+				return fmt.Errorf("failed to encrypt %s: %w", entry.name, err)
+			}
+		}
+
+		header := &tar.Header{
+			Name:    entry.name,
+			Mode:    0o600,
+			Size:    int64(len(data)),
+			ModTime: archive.Manifest.CreatedAt,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write %s: %w", entry.name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", entry.name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish tar archive: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finish zstd stream: %w", err)
+	}
+	return nil
+}
+
+// Read reads an archive written by Write, decrypting its secret entries with the passphrase.
+func Read(r io.Reader, passphrase string) (*Archive, error) {
+	if passphrase == "" {
+		return nil, ErrPassphraseRequired
+	}
+
+	zr, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zstd stream: %w", err)
+	}
+	defer zr.Close()
+
+	archive := &Archive{}
+	entries := make(map[string]archiveEntry)
+	for _, entry := range archive.entries() {
+		entries[entry.name] = entry
+	}
+
+	tr := tar.NewReader(zr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		entry, ok := entries[header.Name]
+		if !ok {
+			continue
+		}
+		delete(entries, header.Name)
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.name, err)
+		}
+		if entry.encrypted {
+			if data, err = open(data, passphrase); err != nil {
+				return nil, err
+			}
+		}
+		if err := json.Unmarshal(data, entry.value); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", entry.name, err)
+		}
+		if entry.name == entryManifest && archive.Manifest.Version != FormatVersion {
+			return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, archive.Manifest.Version)
+		}
+	}
+
+	missing := make([]string, 0, len(entries))
+	for name, entry := range entries {
+		if !entry.optional {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("%w: missing %s", ErrIncompleteArchive, strings.Join(missing, ", "))
+	}
+	return archive, nil
+}
+
+// seal encrypts data with AES-256-GCM under a key derived from the passphrase. The output is the salt,
+// the nonce and the ciphertext.
+func seal(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := append(salt, nonce...)
+	return gcm.Seal(out, nonce, data, nil), nil
+}
+
+// open decrypts data sealed by seal.
+func open(data []byte, passphrase string) ([]byte, error) {
+	if len(data) < saltSize {
+		return nil, ErrWrongPassphrase
+	}
+	gcm, err := newGCM(passphrase, data[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+	data = data[saltSize:]
+	if len(data) < gcm.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plain, nil
+}
+
+// newGCM derives the key for a salt and returns its AES-GCM cipher.
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive backup key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,248 @@
+// Package backup exports the data of one merchant to a portable archive and restores it into a database,
+// for moving merchants between deployments and for disaster recovery drills.
+package backup
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FormatVersion is the version of the archive layout written by Write.
+const FormatVersion = 1
+
+// restoreBatchSize bounds the rows inserted per statement during a restore.
+const restoreBatchSize = 500
+
+// Conflict strategies for rows of the archive that already exist in the target database.
+const (
+	// ConflictFail aborts the restore without changing anything
+	ConflictFail = "fail"
+	// ConflictSkip keeps the existing rows and restores the others
+	ConflictSkip = "skip"
+	// ConflictOverwrite replaces the existing rows with the archived ones
+	ConflictOverwrite = "overwrite"
+)
+
+// Errors returned by export and restore.
+var (
+	ErrMerchantNotFound = errors.New("merchant not found")
+	ErrInvalidStrategy  = errors.New("invalid conflict strategy")
+)
+
+// Manifest describes an archive.
+type Manifest struct {
+	Version    int            `json:"version"`
+	MerchantID string         `json:"merchant_id"`
+	CreatedAt  time.Time      `json:"created_at"`
+	Counts     map[string]int `json:"counts"`
+}
+
+// Archive holds the rows of one merchant. API keys and webhook endpoints carry secrets and are
+// encrypted in the written archive.
+type Archive struct {
+	Manifest         Manifest
+	Merchant         database.MerchantModel
+	Invoices         []database.InvoiceModel
+	Payments         []database.PaymentModel
+	PaymentEvents    []database.PaymentEventModel
+	PaymentSnapshots []database.PaymentSnapshotModel
+	Settlements      []database.SettlementModel
+	WebhookEndpoints []database.WebhookEndpointModel
+	APIKeys          []database.APIKeyModel
+	ArchivedInvoices []database.ArchivedInvoiceModel
+	// Counters the accounting numbers of the merchant's invoices are issued from, one per period
+	InvoiceNumberSequences []database.InvoiceNumberSequenceModel
+	// Counters the sequential IDs of the merchant are numbered from, shared with merchants whose IDs start alike
+	IDSequences []database.IDSequenceModel
+}
+
+// counts returns the number of rows per table.
+func (a *Archive) counts() map[string]int {
+	return map[string]int{
+		"merchants":                1,
+		"invoices":                 len(a.Invoices),
+		"payments":                 len(a.Payments),
+		"payment_events":           len(a.PaymentEvents),
+		"payment_snapshots":        len(a.PaymentSnapshots),
+		"settlements":              len(a.Settlements),
+		"webhook_endpoints":        len(a.WebhookEndpoints),
+		"api_keys":                 len(a.APIKeys),
+		"archived_invoices":        len(a.ArchivedInvoices),
+		"invoice_number_sequences": len(a.InvoiceNumberSequences),
+		"id_sequences":             len(a.IDSequences),
+	}
+}
+
+// Export reads every row of a merchant, including soft-deleted ones, in one consistent snapshot.
+func Export(ctx context.Context, db *gorm.DB, merchantID string) (*Archive, error) {
+	archive := &Archive{}
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tx = tx.Unscoped().Session(&gorm.Session{})
+		if err := tx.Where("id = ?", merchantID).First(&archive.Merchant).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: %s", ErrMerchantNotFound, merchantID)
+			}
+			return fmt.Errorf("failed to export merchant: %w", err)
+		}
+
+		invoiceIDs := tx.Model(&database.InvoiceModel{}).Select("id").Where("merchant_id = ?", merchantID)
+		paymentIDs := tx.Model(&database.PaymentModel{}).Select("id").Where("invoice_id IN (?)", invoiceIDs)
+		queries := []struct {
+			table string
+			query *gorm.DB
+			rows  interface{}
+		}{
+			{"invoices", tx.Where("merchant_id = ?", merchantID).Order("created_at"), &archive.Invoices},
+			{"payments", tx.Where("invoice_id IN (?)", invoiceIDs).Order("created_at"), &archive.Payments},
+			{"payment_events", tx.Where("payment_id IN (?)", paymentIDs).Order("payment_id, version"),
+				&archive.PaymentEvents},
+			{"payment_snapshots", tx.Where("payment_id IN (?)", paymentIDs).Order("payment_id, version"),
+				&archive.PaymentSnapshots},
+			{"settlements", tx.Where("merchant_id = ?", merchantID).Order("created_at"), &archive.Settlements},
+			{"webhook_endpoints", tx.Where("merchant_id = ?", merchantID).Order("created_at"),
+				&archive.WebhookEndpoints},
+			{"api_keys", tx.Where("merchant_id = ?", merchantID).Order("created_at"), &archive.APIKeys},
+			{"archived_invoices", tx.Where("merchant_id = ?", merchantID).Order("created_at"),
+				&archive.ArchivedInvoices},
+			{"invoice_number_sequences", tx.Where("merchant_id = ?", merchantID).Order("period"),
+				&archive.InvoiceNumberSequences},
+			{"id_sequences", tx.Where("prefix = ?", shared.SequentialIDPrefix(merchantID)).Order("kind, year"),
+				&archive.IDSequences},
+		}
+		for _, q := range queries {
+			if err := q.query.Find(q.rows).Error; err != nil {
+				return fmt.Errorf("failed to export %s: %w", q.table, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	archive.Manifest = Manifest{
+		Version:    FormatVersion,
+		MerchantID: merchantID,
+		CreatedAt:  time.Now().UTC(),
+		Counts:     archive.counts(),
+	}
+	return archive, nil
+}
+
+// ValidateStrategy reports whether a conflict strategy is supported.
+func ValidateStrategy(strategy string) error {
+	switch strategy {
+	case ConflictFail, ConflictSkip, ConflictOverwrite:
+		return nil
+	default:
+		return fmt.Errorf("%w %q: use %s, %s or %s",
+			ErrInvalidStrategy, strategy, ConflictFail, ConflictSkip, ConflictOverwrite)
+	}
+}
+
+// Restore writes the rows of an archive in one transaction, resolving rows that already exist with the
+// conflict strategy. With ConflictFail an existing row aborts the restore and nothing is written.
+func Restore(ctx context.Context, db *gorm.DB, archive *Archive, strategy string) error {
+	if err := ValidateStrategy(strategy); err != nil {
+		return err
+	}
+
+	var conflict clause.Expression
+	switch strategy {
+	case ConflictSkip:
+		conflict = clause.OnConflict{DoNothing: true}
+	case ConflictOverwrite:
+		conflict = clause.OnConflict{UpdateAll: true}
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		writes := tx
+		if conflict != nil {
+			writes = tx.Clauses(conflict).Session(&gorm.Session{})
+		}
+		tables := []struct {
+			table string
+			rows  interface{}
+			empty bool
+		}{
+			{"merchants", &archive.Merchant, false},
+			{"invoices", &archive.Invoices, len(archive.Invoices) == 0},
+			{"payments", &archive.Payments, len(archive.Payments) == 0},
+			{"payment_events", &archive.PaymentEvents, len(archive.PaymentEvents) == 0},
+			{"payment_snapshots", &archive.PaymentSnapshots, len(archive.PaymentSnapshots) == 0},
+			{"settlements", &archive.Settlements, len(archive.Settlements) == 0},
+			{"webhook_endpoints", &archive.WebhookEndpoints, len(archive.WebhookEndpoints) == 0},
+			{"api_keys", &archive.APIKeys, len(archive.APIKeys) == 0},
+			{"archived_invoices", &archive.ArchivedInvoices, len(archive.ArchivedInvoices) == 0},
+		}
+		for _, t := range tables {
+			if t.empty {
+				continue
+			}
+			if err := writes.CreateInBatches(t.rows, restoreBatchSize).Error; err != nil {
+				return fmt.Errorf("failed to restore %s: %w", t.table, err)
+			}
+		}
+
+		// Counters only move forward, so numbers the restored rows carry are not handed out again
+		counters := []struct {
+			table string
+			keys  []string
+			rows  interface{}
+			empty bool
+		}{
+			{"invoice_number_sequences", []string{"merchant_id", "period"}, &archive.InvoiceNumberSequences,
+				len(archive.InvoiceNumberSequences) == 0},
+			{"id_sequences", []string{"prefix", "kind", "year"}, &archive.IDSequences, len(archive.IDSequences) == 0},
+		}
+		for _, c := range counters {
+			if c.empty {
+				continue
+			}
+			if err := restoreCounters(tx, c.table, c.keys, c.rows, strategy); err != nil {
+				return err
+			}
+		}
+
+		// The metadata index is not archived; it is rebuilt from the invoices as restored
+		invoiceIDs := make([]string, 0, len(archive.Invoices))
+		for _, invoice := range archive.Invoices {
+			invoiceIDs = append(invoiceIDs, invoice.ID)
+		}
+		return database.ReindexInvoiceMetadata(tx, invoiceIDs)
+	})
+}
+
+// restoreCounters restores the rows of a table of counters keyed by the given columns. An overwrite keeps the
+// greater of the archived and existing values, since a lowered counter would hand out numbers already in use.
+func restoreCounters(tx *gorm.DB, table string, keys []string, rows interface{}, strategy string) error {
+	writes := tx
+	switch strategy {
+	case ConflictSkip:
+		writes = tx.Clauses(clause.OnConflict{DoNothing: true})
+	case ConflictOverwrite:
+		columns := make([]clause.Column, len(keys))
+		for i, key := range keys {
+			columns[i] = clause.Column{Name: key}
+		}
+		// GREATEST is spelled out as a CASE, which SQLite also understands
+		writes = tx.Clauses(clause.OnConflict{
+			Columns: columns,
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"value": gorm.Expr(fmt.Sprintf(
+					"CASE WHEN excluded.value > %[1]s.value THEN excluded.value ELSE %[1]s.value END", table)),
+			}),
+		})
+	}
+	if err := writes.Create(rows).Error; err != nil {
+		return fmt.Errorf("failed to restore %s: %w", table, err)
+	}
+	return nil
+}
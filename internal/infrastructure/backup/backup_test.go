@@ -0,0 +1,180 @@
+package backup_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/backup"
+	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/test/testutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const passphrase = "correct horse battery staple"
+
+func openDB(t *testing.T, name string) *gorm.DB {
+	conn, err := database.NewConnection(config.DatabaseConfig{
+		URL: "sqlite://" + filepath.Join(t.TempDir(), name+".db"),
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, conn.Migrate())
+	// Merchant tables are not managed by Migrate
+	require.NoError(t, conn.DB.AutoMigrate(&database.MerchantModel{}, &database.APIKeyModel{},
+		&database.WebhookEndpointModel{}))
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn.DB
+}
+
+func seedMerchant(t *testing.T, db *gorm.DB, merchantID, suffix string) {
+	now := time.Now().UTC()
+	number := "000001"
+	rows := []interface{}{
+		&database.MerchantModel{ID: merchantID, BusinessName: "Shop " + suffix,
+			ContactEmail: "owner-" + suffix + "@example.com", Status: "active", Settings: "{}",
+			CreatedAt: now, UpdatedAt: now},
+		&database.InvoiceModel{ID: "inv-" + suffix, MerchantID: merchantID, Number: &number,
+			Title: "Order " + suffix, Subtotal: "10.00", Total: "10.00", Currency: "USD", CryptoCurrency: "USDT",
+			CryptoAmount: "10.00", Status: "paid", CreatedAt: now, UpdatedAt: now},
+		&database.InvoiceNumberSequenceModel{MerchantID: merchantID, Value: 1},
+		&database.PaymentModel{ID: "pay-" + suffix, InvoiceID: "inv-" + suffix, TxHash: "tx-" + suffix,
+			Amount: "10.00", FromAddress: "TFrom", ToAddress: "TTo", Status: "confirmed", DetectedAt: now,
+			CreatedAt: now},
+		&database.PaymentEventModel{PaymentID: "pay-" + suffix, Version: 1, Type: "payment.detected",
+			Data: "{}", OccurredAt: now},
+		&database.SettlementModel{ID: "set-" + suffix, InvoiceID: "inv-" + suffix, MerchantID: merchantID,
+			GrossAmount: "10", FeePercentage: "1", FeeAmount: "0.1", NetAmount: "9.9", Currency: "USDT",
+			Status: "settled", CreatedAt: now},
+		&database.WebhookEndpointModel{ID: "wh-" + suffix, MerchantID: merchantID,
+			URL: "https://example.com/hooks", Events: "[]", Secret: "whsec_" + suffix, Status: "active",
+			RetryBackoff: "exponential", CreatedAt: now, UpdatedAt: now},
+		&database.APIKeyModel{ID: "key-" + suffix, MerchantID: merchantID, KeyHash: "hash-" + suffix,
+			KeyType: "secret", Permissions: "[]", Status: "active", CreatedAt: now},
+		&database.ArchivedInvoiceModel{ID: "old-" + suffix, MerchantID: merchantID, Status: "paid",
+			Invoice: "{}", Payments: "[]", PaymentEvents: "[]", CreatedAt: now, ArchivedAt: now},
+	}
+	for _, row := range rows {
+		require.NoError(t, db.Create(row).Error)
+	}
+}
+
+func newPayment(id, invoiceID string) *database.PaymentModel {
+	now := time.Now().UTC()
+	return &database.PaymentModel{ID: id, InvoiceID: invoiceID, TxHash: "tx-" + id, Amount: "1.00",
+		FromAddress: "TFrom", ToAddress: "TTo", Status: "confirmed", DetectedAt: now, CreatedAt: now}
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	ctx := context.Background()
+	source := openDB(t, "source")
+	seedMerchant(t, source, "merchant-1", "1")
+	seedMerchant(t, source, "merchant-2", "2")
+
+	// A payment of merchant-1 is numbered by the sequential ID generator
+	sourceIDs := shared.NewSequentialIDGenerator(database.NewIDSequenceRepository(source, zap.NewNop()))
+	paymentID, err := sourceIDs.NewID(ctx, shared.IDKindPayment, "merchant-1")
+	require.NoError(t, err)
+	require.NoError(t, source.Create(newPayment(paymentID, "inv-1")).Error)
+
+	archive, err := backup.Export(ctx, source, "merchant-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		"merchants": 1, "invoices": 1, "payments": 2, "payment_events": 1, "payment_snapshots": 0,
+		"settlements": 1, "webhook_endpoints": 1, "api_keys": 1, "archived_invoices": 1,
+		"invoice_number_sequences": 1, "id_sequences": 1,
+	}, archive.Manifest.Counts)
+
+	var buf bytes.Buffer
+	require.NoError(t, backup.Write(&buf, archive, passphrase))
+	assert.NotContains(t, buf.String(), "whsec_1")
+
+	_, err = backup.Read(bytes.NewReader(buf.Bytes()), "wrong passphrase")
+	require.ErrorIs(t, err, backup.ErrWrongPassphrase)
+
+	restored, err := backup.Read(bytes.NewReader(buf.Bytes()), passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, "merchant-1", restored.Manifest.MerchantID)
+	require.Len(t, restored.WebhookEndpoints, 1)
+	assert.Equal(t, "whsec_1", restored.WebhookEndpoints[0].Secret)
+
+	target := openDB(t, "target")
+	require.NoError(t, backup.Restore(ctx, target, restored, backup.ConflictFail))
+
+	var invoices []database.InvoiceModel
+	require.NoError(t, target.Find(&invoices).Error)
+	require.Len(t, invoices, 1)
+	assert.Equal(t, "inv-1", invoices[0].ID)
+	var apiKey database.APIKeyModel
+	require.NoError(t, target.First(&apiKey, "id = ?", "key-1").Error)
+	assert.Equal(t, "hash-1", apiKey.KeyHash)
+	var archived database.ArchivedInvoiceModel
+	require.NoError(t, target.First(&archived, "id = ?", "old-1").Error)
+	assert.Equal(t, "merchant-1", archived.MerchantID)
+
+	// The next invoice issued carries on from the restored number rather than reusing it
+	repository := database.NewInvoiceRepository(target, zap.NewNop())
+	next, err := testutil.NewInvoiceBuilder().WithID("inv-next").WithMerchantID("merchant-1").Build()
+	require.NoError(t, err)
+	require.NoError(t, repository.Save(ctx, next))
+	assert.Equal(t, "000002", next.Number())
+
+	// The next sequential ID carries on from the restored payment rather than reusing its ID
+	targetIDs := shared.NewSequentialIDGenerator(database.NewIDSequenceRepository(target, zap.NewNop()))
+	nextPaymentID, err := targetIDs.NewID(ctx, shared.IDKindPayment, "merchant-1")
+	require.NoError(t, err)
+	assert.NotEqual(t, paymentID, nextPaymentID)
+	require.NoError(t, target.Create(newPayment(nextPaymentID, "inv-1")).Error)
+
+	t.Run("fail leaves existing rows untouched", func(t *testing.T) {
+		require.NoError(t, target.Model(&database.InvoiceModel{}).Where("id = ?", "inv-1").
+			Update("title", "Changed").Error)
+		require.Error(t, backup.Restore(ctx, target, restored, backup.ConflictFail))
+
+		var inv database.InvoiceModel
+		require.NoError(t, target.First(&inv, "id = ?", "inv-1").Error)
+		assert.Equal(t, "Changed", inv.Title)
+	})
+
+	t.Run("skip keeps existing rows", func(t *testing.T) {
+		require.NoError(t, backup.Restore(ctx, target, restored, backup.ConflictSkip))
+
+		var inv database.InvoiceModel
+		require.NoError(t, target.First(&inv, "id = ?", "inv-1").Error)
+		assert.Equal(t, "Changed", inv.Title)
+	})
+
+	t.Run("overwrite replaces existing rows", func(t *testing.T) {
+		require.NoError(t, backup.Restore(ctx, target, restored, backup.ConflictOverwrite))
+
+		var inv database.InvoiceModel
+		require.NoError(t, target.First(&inv, "id = ?", "inv-1").Error)
+		assert.Equal(t, "Order 1", inv.Title)
+	})
+
+	t.Run("overwrite never lowers a number counter", func(t *testing.T) {
+		require.NoError(t, backup.Restore(ctx, target, restored, backup.ConflictOverwrite))
+		var sequence database.InvoiceNumberSequenceModel
+		require.NoError(t, target.First(&sequence, "merchant_id = ?", "merchant-1").Error)
+		assert.Equal(t, int64(2), sequence.Value)
+
+		require.NoError(t, target.Model(&database.InvoiceNumberSequenceModel{}).
+			Where("merchant_id = ?", "merchant-1").Update("value", 0).Error)
+		require.NoError(t, backup.Restore(ctx, target, restored, backup.ConflictOverwrite))
+		require.NoError(t, target.First(&sequence, "merchant_id = ?", "merchant-1").Error)
+		assert.Equal(t, int64(1), sequence.Value)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		_, err := backup.Export(ctx, source, "merchant-unknown")
+		require.ErrorIs(t, err, backup.ErrMerchantNotFound)
+		require.ErrorIs(t, backup.Restore(ctx, target, restored, "merge"), backup.ErrInvalidStrategy)
+		require.ErrorIs(t, backup.Write(&bytes.Buffer{}, archive, ""), backup.ErrPassphraseRequired)
+	})
+}
@@ -0,0 +1,137 @@
+// Package cache provides in-process read caches for the crypto-checkout application.
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Stats holds cache counters since the cache was created.
+type Stats struct {
+	Hits          uint64 `json:"hits"`
+	Misses        uint64 `json:"misses"`
+	Invalidations uint64 `json:"invalidations"`
+	Evictions     uint64 `json:"evictions"`
+	Size          int    `json:"size"`
+}
+
+// HitRatio returns the share of lookups served from the cache, or 0 before any lookup.
+func (s Stats) HitRatio() float64 {
+	lookups := s.Hits + s.Misses
+	if lookups == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(lookups)
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// TTLCache is a concurrency-safe in-process cache whose entries expire after a fixed lifetime.
+// When full, it evicts expired entries first and otherwise the entry closest to expiry.
+type TTLCache[K comparable, V any] struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu         sync.Mutex
+	entries    map[K]entry[V]
+	generation uint64
+	stats      Stats
+}
+
+// NewTTLCache creates a cache holding at most maxEntries entries for ttl each.
+// A non-positive maxEntries leaves the cache unbounded.
+func NewTTLCache[K comparable, V any](ttl time.Duration, maxEntries int) *TTLCache[K, V] {
+	return &TTLCache[K, V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[K]entry[V]),
+	}
+}
+
+// Get returns the cached value for key and records a hit or a miss.
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.entries[key]
+	if ok && c.now().Before(cached.expiresAt) {
+		c.stats.Hits++
+		return cached.value, true
+	}
+	if ok {
+		delete(c.entries, key)
+	}
+	c.stats.Misses++
+
+	var zero V
+	return zero, false
+}
+
+// Generation returns a token to pass to SetSince when the value about to be cached is read from the source.
+func (c *TTLCache[K, V]) Generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// SetSince caches value for key unless an entry was invalidated after generation was taken.
+// This keeps a read that raced with a write from caching the value the write replaced.
+func (c *TTLCache[K, V]) SetSince(key K, value V, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	if _, ok := c.entries[key]; !ok && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		c.evict()
+	}
+	c.entries[key] = entry[V]{value: value, expiresAt: c.now().Add(c.ttl)}
+}
+
+// Invalidate removes the entry for key, if any.
+func (c *TTLCache[K, V]) Invalidate(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.stats.Invalidations++
+	delete(c.entries, key)
+}
+
+// Stats returns a snapshot of the cache counters.
+func (c *TTLCache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Size = len(c.entries)
+	return stats
+}
+
+// evict makes room for one entry. It must be called with the lock held.
+func (c *TTLCache[K, V]) evict() {
+	now := c.now()
+	var oldest K
+	var oldestExpiry time.Time
+	found := false
+	for key, cached := range c.entries {
+		if !now.Before(cached.expiresAt) {
+			delete(c.entries, key)
+			c.stats.Evictions++
+			continue
+		}
+		if !found || cached.expiresAt.Before(oldestExpiry) {
+			oldest, oldestExpiry, found = key, cached.expiresAt, true
+		}
+	}
+	if len(c.entries) >= c.maxEntries && found {
+		delete(c.entries, oldest)
+		c.stats.Evictions++
+	}
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTTLCache(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newCache := func(maxEntries int) *TTLCache[string, int] {
+		c := NewTTLCache[string, int](time.Minute, maxEntries)
+		c.now = func() time.Time { return now }
+		return c
+	}
+
+	t.Run("records hits and misses", func(t *testing.T) {
+		c := newCache(0)
+		_, ok := c.Get("a")
+		require.False(t, ok)
+
+		c.SetSince("a", 1, c.Generation())
+		value, ok := c.Get("a")
+		require.True(t, ok)
+		require.Equal(t, 1, value)
+
+		stats := c.Stats()
+		require.Equal(t, uint64(1), stats.Hits)
+		require.Equal(t, uint64(1), stats.Misses)
+		require.Equal(t, 1, stats.Size)
+		require.InDelta(t, 0.5, stats.HitRatio(), 0.0001)
+	})
+
+	t.Run("entries expire after the TTL", func(t *testing.T) {
+		c := newCache(0)
+		c.SetSince("a", 1, c.Generation())
+
+		now = now.Add(time.Minute)
+		_, ok := c.Get("a")
+		require.False(t, ok)
+		require.Equal(t, 0, c.Stats().Size)
+	})
+
+	t.Run("a read that raced with an invalidation is not cached", func(t *testing.T) {
+		c := newCache(0)
+		generation := c.Generation()
+		c.Invalidate("a")
+		c.SetSince("a", 1, generation)
+
+		_, ok := c.Get("a")
+		require.False(t, ok)
+		require.Equal(t, uint64(1), c.Stats().Invalidations)
+	})
+
+	t.Run("a full cache evicts the entry closest to expiry", func(t *testing.T) {
+		c := newCache(2)
+		c.SetSince("a", 1, c.Generation())
+		now = now.Add(time.Second)
+		c.SetSince("b", 2, c.Generation())
+		now = now.Add(time.Second)
+		c.SetSince("c", 3, c.Generation())
+
+		_, ok := c.Get("a")
+		require.False(t, ok)
+		_, ok = c.Get("b")
+		require.True(t, ok)
+		_, ok = c.Get("c")
+		require.True(t, ok)
+		require.Equal(t, uint64(1), c.Stats().Evictions)
+	})
+}
@@ -0,0 +1,35 @@
+// Package chainscan provides the chain scanners payment backfills read past blocks with: Ethereum-compatible
+// JSON-RPC nodes and TronGrid-compatible nodes.
+package chainscan
+
+import (
+	"crypto-checkout/internal/domain/detection"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBody limits how much of a node error response is included in errors.
+const maxErrorBody = 512
+
+// doJSON executes the request and decodes a successful JSON response into out.
+func doJSON(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", detection.ErrScanFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return fmt.Errorf("%w: %s %s returned %d: %s", detection.ErrScanFailed,
+			req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: failed to decode response: %w", detection.ErrScanFailed, err)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package chainscan
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/pkg/config"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeTronAddress(t *testing.T) {
+	raw, err := hex.DecodeString("41a614f803b6fd780986a42c78ec9c7f77e6ded13c")
+	require.NoError(t, err)
+	assert.Equal(t, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", encodeTronAddress(raw))
+}
+
+func TestTronScanner_FindsTransfersToAddresses(t *testing.T) {
+	const (
+		usdt  = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+		payee = "TEdvoHEatmDKvTh3o9vBRB9Vdtbhn4QFhy" // 0x41 followed by twenty 0x33 bytes
+		payer = "TJRyWwFs9wTFGZg3JbrVriFbNfCug5tDeC"
+	)
+	// transfer(payee, 25.5 USDT)
+	data := trc20TransferSelector + strings.Repeat("0", 24) + strings.Repeat("33", 20) +
+		strings.Repeat("0", 64-7) + "1851960"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "tron-key", r.Header.Get(tronAPIKeyHeader))
+		switch r.URL.Path {
+		case tronNowBlockPath:
+			_, _ = w.Write([]byte(`{"blockID":"00aa","block_header":{"raw_data":{"number":5000}}}`))
+		case tronBlocksPath:
+			var params struct {
+				StartNum int64 `json:"startNum"`
+				EndNum   int64 `json:"endNum"`
+				Visible  bool  `json:"visible"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+			assert.Equal(t, int64(4000), params.StartNum)
+			assert.Equal(t, int64(4011), params.EndNum)
+			assert.True(t, params.Visible)
+			_, _ = w.Write([]byte(`{"block":[{"blockID":"0fa0","block_header":{"raw_data":{"number":4000}},` +
+				`"transactions":[` +
+				`{"txID":"t1","ret":[{"contractRet":"SUCCESS"}],"raw_data":{"contract":[{"type":"TriggerSmartContract",` +
+				`"parameter":{"value":{"owner_address":"` + payer + `","contract_address":"` + usdt +
+				`","data":"` + data + `"}}}],"data":"` + hex.EncodeToString([]byte("7K3QX9PA")) + `"}},` +
+				`{"txID":"t2","ret":[{"contractRet":"REVERT"}],"raw_data":{"contract":[{"type":"TriggerSmartContract",` +
+				`"parameter":{"value":{"owner_address":"` + payer + `","contract_address":"` + usdt +
+				`","data":"` + data + `"}}}]}},` +
+				`{"txID":"t3","ret":[{"contractRet":"SUCCESS"}],"raw_data":{"contract":[{"type":"TransferContract",` +
+				`"parameter":{"value":{"owner_address":"` + payer + `","to_address":"` + payee +
+				`","amount":1500000}}}]}},` +
+				`{"txID":"t4","ret":[{"contractRet":"SUCCESS"}],"raw_data":{"contract":[{"type":"TransferContract",` +
+				`"parameter":{"value":{"owner_address":"` + payee + `","to_address":"` + payer +
+				`","amount":1500000}}}]}}]}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	scanner := NewTronScanner(shared.NetworkTron, server.URL, "tron-key", server.Client())
+	latest, err := scanner.LatestBlock(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), latest)
+
+	notifications, err := scanner.ScanTransfers(context.Background(), &detection.ScanRequest{
+		FromBlock: 4000,
+		ToBlock:   4010,
+		Addresses: []string{payee},
+		Tokens: []shared.Token{
+			{Symbol: shared.CryptoCurrencyUSDT, Network: shared.NetworkTron, Contract: usdt, Decimals: 6},
+		},
+	})
+	require.NoError(t, err)
+	// The reverted transfer and the TRX transfer, a token not requested, are skipped
+	require.Len(t, notifications, 1)
+	assert.Equal(t, &detection.Notification{
+		Network:         shared.NetworkTron,
+		TransactionHash: "t1",
+		FromAddress:     payer,
+		ToAddress:       payee,
+		Amount:          "25.5",
+		Currency:        shared.CryptoCurrencyUSDT,
+		BlockNumber:     4000,
+		BlockHash:       "0fa0",
+		Memo:            "7K3QX9PA",
+	}, notifications[0])
+}
+
+func TestEVMScanner_FindsTransfersToAddresses(t *testing.T) {
+	const (
+		usdt  = "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+		payee = "0xAbCdEf0000000000000000000000000000000001"
+		payer = "0x9999999999999999999999999999999999999999"
+	)
+	topic := func(address string) string {
+		return "0x" + strings.Repeat("0", 24) + strings.ToLower(address[2:])
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch req.Method {
+		case "eth_blockNumber":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1388"}`))
+		case "eth_getBlockByNumber":
+			if req.Params[0] != "0xfa0" {
+				_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"hash":"0xb1","transactions":[]}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"hash":"0xb0","transactions":[` +
+				`{"hash":"0xe1","from":"` + payer + `","to":"` + strings.ToLower(payee) + `","value":"0x6f05b59d3b20000"},` +
+				`{"hash":"0xe2","from":"` + payer + `","to":"` + payee + `","value":"0x1"},` +
+				`{"hash":"0xe3","from":"` + payer + `","to":null,"value":"0x0"}]}}`))
+		case "eth_getTransactionReceipt":
+			status := "0x1"
+			if req.Params[0] == "0xe2" {
+				status = "0x0"
+			}
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"status":"` + status + `"}}`))
+		case "eth_getLogs":
+			filter := req.Params[0].(map[string]interface{})
+			assert.Equal(t, "0xfa0", filter["fromBlock"])
+			assert.Equal(t, "0xfa1", filter["toBlock"])
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":[` +
+				`{"address":"` + strings.ToLower(usdt) + `","topics":["` + erc20TransferTopic + `","` + topic(payer) +
+				`","` + topic(payee) + `"],"data":"0x0000000000000000000000000000000000000000000000000000000001312d00",` +
+				`"blockNumber":"0xfa1","blockHash":"0xb1","transactionHash":"0xe4","removed":false},` +
+				`{"address":"` + strings.ToLower(usdt) + `","topics":["` + erc20TransferTopic + `","` + topic(payee) +
+				`","` + topic(payer) + `"],"data":"0x01","blockNumber":"0xfa1","blockHash":"0xb1",` +
+				`"transactionHash":"0xe5","removed":false}]}`))
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+	}))
+	defer server.Close()
+
+	scanner := NewEVMScanner(shared.NetworkEthereum, server.URL, server.Client())
+	latest, err := scanner.LatestBlock(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), latest)
+
+	notifications, err := scanner.ScanTransfers(context.Background(), &detection.ScanRequest{
+		FromBlock: 4000,
+		ToBlock:   4001,
+		Addresses: []string{payee},
+		Tokens: []shared.Token{
+			{Symbol: shared.CryptoCurrencyETH, Network: shared.NetworkEthereum, Decimals: 18},
+			{Symbol: shared.CryptoCurrencyUSDT, Network: shared.NetworkEthereum, Contract: usdt, Decimals: 6},
+		},
+	})
+	require.NoError(t, err)
+	// The failed native transfer and the outgoing token transfer are skipped
+	require.Len(t, notifications, 2)
+	assert.Equal(t, "0xe1", notifications[0].TransactionHash)
+	assert.Equal(t, payee, notifications[0].ToAddress)
+	assert.Equal(t, "0.5", notifications[0].Amount)
+	assert.Equal(t, shared.CryptoCurrencyETH, notifications[0].Currency)
+	assert.Equal(t, int64(4000), notifications[0].BlockNumber)
+	assert.Equal(t, "0xe4", notifications[1].TransactionHash)
+	assert.Equal(t, strings.ToLower(payer), notifications[1].FromAddress)
+	assert.Equal(t, payee, notifications[1].ToAddress)
+	assert.Equal(t, "20", notifications[1].Amount)
+	assert.Equal(t, shared.CryptoCurrencyUSDT, notifications[1].Currency)
+	assert.Equal(t, int64(4001), notifications[1].BlockNumber)
+}
+
+func TestScanners_ReportNodeFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "overloaded", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewEVMScanner(shared.NetworkBSC, server.URL, server.Client()).LatestBlock(context.Background())
+	require.ErrorIs(t, err, detection.ErrScanFailed)
+	_, err = NewTronScanner(shared.NetworkTron, server.URL, "", server.Client()).LatestBlock(context.Background())
+	require.ErrorIs(t, err, detection.ErrScanFailed)
+}
+
+func TestExplorerResolverProvider(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Blockchain.Explorers.Tron.Chain = "nile"
+	cfg.Blockchain.Explorers.Bitcoin = config.ExplorerConfig{Chain: "signet", URL: "https://mempool.example.com"}
+
+	explorers, err := NewExplorerResolverProvider(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "https://nile.tronscan.org/#/transaction/abc", explorers.TransactionURL(shared.NetworkTron, "abc"))
+	assert.Equal(t, "https://mempool.example.com/tx/abc", explorers.TransactionURL(shared.NetworkBitcoin, "abc"))
+	assert.Equal(t, "https://etherscan.io/tx/0xabc", explorers.TransactionURL(shared.NetworkEthereum, "0xabc"))
+
+	cfg.Blockchain.Explorers.Ethereum.Chain = "ropsten"
+	_, err = NewExplorerResolverProvider(cfg)
+	require.ErrorIs(t, err, shared.ErrInvalidInput)
+}
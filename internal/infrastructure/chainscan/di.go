@@ -0,0 +1,101 @@
+package chainscan
+
+import (
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/resilience"
+	"crypto-checkout/pkg/config"
+	"fmt"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides the configured chain scanners and block explorers for Fx.
+var Module = fx.Module("chainscan",
+	fx.Provide(NewScannersProvider, NewWatchPolicyProvider, NewExplorerResolverProvider),
+)
+
+// NewScannersProvider creates a scanner for every network with a scanner URL. Each scanner has its own
+// circuit breaker, so an unreachable node does not affect backfills of the other networks.
+func NewScannersProvider(
+	cfg *config.Config,
+	breakers *resilience.Registry,
+	logger *zap.Logger,
+) []detection.TransferScanner {
+	scanners := cfg.Blockchain.Scanners
+	timeout := scanners.Timeout
+	if timeout <= 0 {
+		timeout = config.DefaultChainScannerTimeout
+	}
+
+	var result []detection.TransferScanner
+	if scanners.Tron.URL != "" {
+		result = append(result, NewTronScanner(shared.NetworkTron, scanners.Tron.URL, scanners.Tron.APIKey,
+			breakers.Client(resilience.DependencyChainScannerTron, timeout)))
+	}
+	if scanners.TronShasta.URL != "" {
+		result = append(result, NewTronScanner(shared.NetworkTronShasta, scanners.TronShasta.URL,
+			scanners.TronShasta.APIKey, breakers.Client(resilience.DependencyChainScannerTronShasta, timeout)))
+	}
+	if scanners.TronNile.URL != "" {
+		result = append(result, NewTronScanner(shared.NetworkTronNile, scanners.TronNile.URL,
+			scanners.TronNile.APIKey, breakers.Client(resilience.DependencyChainScannerTronNile, timeout)))
+	}
+	if scanners.Ethereum.URL != "" {
+		result = append(result, NewEVMScanner(shared.NetworkEthereum, scanners.Ethereum.URL,
+			breakers.Client(resilience.DependencyChainScannerEthereum, timeout)))
+	}
+	if scanners.EthereumSepolia.URL != "" {
+		result = append(result, NewEVMScanner(shared.NetworkEthereumSepolia, scanners.EthereumSepolia.URL,
+			breakers.Client(resilience.DependencyChainScannerEthereumSepolia, timeout)))
+	}
+	if scanners.BSC.URL != "" {
+		result = append(result, NewEVMScanner(shared.NetworkBSC, scanners.BSC.URL,
+			breakers.Client(resilience.DependencyChainScannerBSC, timeout)))
+	}
+	if len(result) == 0 {
+		logger.Info("Payment backfills are disabled; no chain scanners are configured")
+	}
+	return result
+}
+
+// NewWatchPolicyProvider creates the chain watcher policy from blockchain.watcher.
+func NewWatchPolicyProvider(cfg *config.Config) detection.WatchPolicy {
+	watcher := cfg.Blockchain.Watcher
+	return detection.WatchPolicy{
+		Enabled:  watcher.Enabled,
+		Interval: watcher.Interval,
+		Depth:    watcher.Depth,
+		MaxLag:   watcher.MaxLag,
+	}
+}
+
+// NewExplorerResolverProvider creates the block explorer links from blockchain.explorers. A network's URL
+// takes precedence over its chain.
+func NewExplorerResolverProvider(cfg *config.Config) (*shared.ExplorerResolver, error) {
+	configured := cfg.Blockchain.Explorers
+	var explorers []shared.Explorer
+	for network, explorer := range map[shared.BlockchainNetwork]config.ExplorerConfig{
+		shared.NetworkTron:     configured.Tron,
+		shared.NetworkEthereum: configured.Ethereum,
+		shared.NetworkBitcoin:  configured.Bitcoin,
+		shared.NetworkBSC:      configured.BSC,
+	} {
+		if explorer.URL != "" {
+			explorers = append(explorers, shared.Explorer{Network: network, URL: explorer.URL})
+			continue
+		}
+		public, err := shared.PublicExplorer(network, explorer.Chain)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blockchain.explorers.%s: %w", network, err)
+		}
+		explorers = append(explorers, public)
+	}
+
+	resolver, err := shared.NewExplorerResolver(explorers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blockchain.explorers: %w", err)
+	}
+	return resolver, nil
+}
@@ -0,0 +1,297 @@
+package chainscan
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// erc20TransferTopic is the topic of the ERC20 Transfer(address,address,uint256) event.
+const erc20TransferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+// EVMScanner reads transfers from an Ethereum-compatible JSON-RPC node: token transfers from the Transfer
+// logs of the token contracts, and native transfers from the transactions of every block.
+type EVMScanner struct {
+	network shared.BlockchainNetwork
+	rpcURL  string
+	client  *http.Client
+}
+
+// NewEVMScanner creates a new scanner for the network's JSON-RPC node.
+func NewEVMScanner(network shared.BlockchainNetwork, rpcURL string, client *http.Client) *EVMScanner {
+	return &EVMScanner{
+		network: network,
+		rpcURL:  rpcURL,
+		client:  client,
+	}
+}
+
+// rpcRequest is a JSON-RPC request.
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+// rpcResponse is a JSON-RPC response.
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// evmBlock is the result of eth_getBlockByNumber with full transactions.
+type evmBlock struct {
+	Hash         string `json:"hash"`
+	Transactions []struct {
+		Hash  string `json:"hash"`
+		From  string `json:"from"`
+		To    string `json:"to"` // Empty for contract creations
+		Value string `json:"value"`
+	} `json:"transactions"`
+}
+
+// evmReceipt is the result of eth_getTransactionReceipt.
+type evmReceipt struct {
+	Status string `json:"status"`
+}
+
+// evmLog is an entry of the result of eth_getLogs.
+type evmLog struct {
+	Address         string   `json:"address"`
+	Topics          []string `json:"topics"`
+	Data            string   `json:"data"`
+	BlockNumber     string   `json:"blockNumber"`
+	BlockHash       string   `json:"blockHash"`
+	TransactionHash string   `json:"transactionHash"`
+	Removed         bool     `json:"removed"`
+}
+
+// Network returns the network the scanner reads.
+func (s *EVMScanner) Network() shared.BlockchainNetwork {
+	return s.network
+}
+
+// LatestBlock returns the number of the newest block.
+func (s *EVMScanner) LatestBlock(ctx context.Context) (int64, error) {
+	var number string
+	if err := s.call(ctx, "eth_blockNumber", []interface{}{}, &number); err != nil {
+		return 0, err
+	}
+	return parseHexInt(number)
+}
+
+// ScanTransfers returns the successful transfers of the tokens to the addresses in the blocks.
+func (s *EVMScanner) ScanTransfers(ctx context.Context, req *detection.ScanRequest) ([]*detection.Notification, error) {
+	addresses := make(map[string]string, len(req.Addresses))
+	for _, address := range req.Addresses {
+		addresses[strings.ToLower(address)] = address
+	}
+
+	var notifications []*detection.Notification
+	contracts := make(map[string]shared.Token)
+	for _, token := range req.Tokens {
+		if !token.IsNative() {
+			contracts[strings.ToLower(token.Contract)] = token
+			continue
+		}
+		native, err := s.scanNative(ctx, req, token, addresses)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, native...)
+	}
+	if len(contracts) > 0 {
+		tokenTransfers, err := s.scanTokens(ctx, req, contracts, addresses)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, tokenTransfers...)
+	}
+	return notifications, nil
+}
+
+// scanNative reads every block for transactions paying the native coin to the addresses. Matches are
+// checked against their receipts, since failed transactions are included in blocks too.
+func (s *EVMScanner) scanNative(
+	ctx context.Context,
+	req *detection.ScanRequest,
+	token shared.Token,
+	addresses map[string]string,
+) ([]*detection.Notification, error) {
+	var notifications []*detection.Notification
+	for number := req.FromBlock; number <= req.ToBlock; number++ {
+		var block evmBlock
+		if err := s.call(ctx, "eth_getBlockByNumber", []interface{}{toHex(number), true}, &block); err != nil {
+			return nil, err
+		}
+		for _, tx := range block.Transactions {
+			to, ok := addresses[strings.ToLower(tx.To)]
+			if !ok {
+				continue
+			}
+			value, err := parseHexAmount(tx.Value, token.Decimals)
+			if err != nil {
+				return nil, err
+			}
+			if !value.IsPositive() {
+				continue
+			}
+			var receipt evmReceipt
+			if err := s.call(ctx, "eth_getTransactionReceipt", []interface{}{tx.Hash}, &receipt); err != nil {
+				return nil, err
+			}
+			if receipt.Status != "0x1" {
+				continue
+			}
+			notifications = append(notifications, &detection.Notification{
+				Network:         s.network,
+				TransactionHash: tx.Hash,
+				FromAddress:     tx.From,
+				ToAddress:       to,
+				Amount:          value.String(),
+				Currency:        token.Symbol,
+				BlockNumber:     number,
+				BlockHash:       block.Hash,
+			})
+		}
+	}
+	return notifications, nil
+}
+
+// scanTokens reads the Transfer logs of the token contracts for transfers to the addresses. Only
+// successful transactions emit logs.
+func (s *EVMScanner) scanTokens(
+	ctx context.Context,
+	req *detection.ScanRequest,
+	contracts map[string]shared.Token,
+	addresses map[string]string,
+) ([]*detection.Notification, error) {
+	contractAddresses := make([]string, 0, len(contracts))
+	for _, token := range contracts {
+		contractAddresses = append(contractAddresses, token.Contract)
+	}
+	filter := map[string]interface{}{
+		"fromBlock": toHex(req.FromBlock),
+		"toBlock":   toHex(req.ToBlock),
+		"address":   contractAddresses,
+		"topics":    []interface{}{erc20TransferTopic},
+	}
+	var logs []evmLog
+	if err := s.call(ctx, "eth_getLogs", []interface{}{filter}, &logs); err != nil {
+		return nil, err
+	}
+
+	var notifications []*detection.Notification
+	for _, log := range logs {
+		token, ok := contracts[strings.ToLower(log.Address)]
+		if !ok || log.Removed || len(log.Topics) != 3 {
+			continue
+		}
+		to, ok := addresses[topicAddress(log.Topics[2])]
+		if !ok {
+			continue
+		}
+		amount, err := parseHexAmount(log.Data, token.Decimals)
+		if err != nil {
+			return nil, err
+		}
+		blockNumber, err := parseHexInt(log.BlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, &detection.Notification{
+			Network:         s.network,
+			TransactionHash: log.TransactionHash,
+			FromAddress:     topicAddress(log.Topics[1]),
+			ToAddress:       to,
+			Amount:          amount.String(),
+			Currency:        token.Symbol,
+			BlockNumber:     blockNumber,
+			BlockHash:       log.BlockHash,
+		})
+	}
+	return notifications, nil
+}
+
+// call makes a JSON-RPC call and decodes its result into out.
+func (s *EVMScanner) call(ctx context.Context, method string, params []interface{}, out interface{}) error {
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: 1, Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %w", method, err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.rpcURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	var response rpcResponse
+	if err := doJSON(s.client, httpReq, &response); err != nil {
+		return err
+	}
+	if response.Error != nil {
+		return fmt.Errorf("%w: %s: %s", detection.ErrScanFailed, method, response.Error.Message)
+	}
+	if err := json.Unmarshal(response.Result, out); err != nil {
+		return fmt.Errorf("%w: failed to decode %s result: %w", detection.ErrScanFailed, method, err)
+	}
+	return nil
+}
+
+// topicAddress returns the lowercased address held by an indexed address topic.
+func topicAddress(topic string) string {
+	topic = trimHexPrefix(topic)
+	if len(topic) < 40 {
+		return ""
+	}
+	return "0x" + strings.ToLower(topic[len(topic)-40:])
+}
+
+// parseHexAmount parses a hex quantity of the token's base units into the token amount.
+func parseHexAmount(value string, decimals int32) (decimal.Decimal, error) {
+	digits := trimHexPrefix(value)
+	if digits == "" {
+		return decimal.Zero, nil
+	}
+	units, ok := new(big.Int).SetString(digits, 16)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%w: invalid hex quantity %q", detection.ErrScanFailed, value)
+	}
+	return decimal.NewFromBigInt(units, -decimals), nil
+}
+
+// parseHexInt parses a hex quantity, as JSON-RPC returns block numbers.
+func parseHexInt(value string) (int64, error) {
+	number, ok := new(big.Int).SetString(trimHexPrefix(value), 16)
+	if !ok || !number.IsInt64() {
+		return 0, fmt.Errorf("%w: invalid hex quantity %q", detection.ErrScanFailed, value)
+	}
+	return number.Int64(), nil
+}
+
+// toHex formats a block number as a hex quantity.
+func toHex(number int64) string {
+	return fmt.Sprintf("0x%x", number)
+}
+
+// trimHexPrefix removes the 0x prefix of a hex quantity.
+func trimHexPrefix(value string) string {
+	if len(value) >= 2 && value[0] == '0' && (value[1] == 'x' || value[1] == 'X') {
+		return value[2:]
+	}
+	return value
+}
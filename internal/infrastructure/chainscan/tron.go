@@ -0,0 +1,247 @@
+package chainscan
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/shared"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// tronNowBlockPath returns the newest block.
+	tronNowBlockPath = "/wallet/getnowblock"
+	// tronBlocksPath returns the blocks of a range, end exclusive, at most tronMaxBlocks at a time.
+	tronBlocksPath = "/wallet/getblockbylimitnext"
+	tronMaxBlocks  = 100
+	// tronAPIKeyHeader carries the TronGrid API key.
+	tronAPIKeyHeader = "TRON-PRO-API-KEY"
+
+	// tronTransferContract and tronTriggerSmartContract are the contract types of TRX transfers and of
+	// contract calls such as TRC20 transfers.
+	tronTransferContract     = "TransferContract"
+	tronTriggerSmartContract = "TriggerSmartContract"
+	// tronSuccess is the result of a successful transaction.
+	tronSuccess = "SUCCESS"
+	// trc20TransferSelector is the selector of transfer(address,uint256).
+	trc20TransferSelector = "a9059cbb"
+	// tronAddressPrefix is the first byte of Tron addresses.
+	tronAddressPrefix = 0x41
+	// sunDecimals is the precision of TRX amounts.
+	sunDecimals = 6
+)
+
+// base58Alphabet is the alphabet of base58check-encoded Tron addresses.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// TronScanner reads TRX and TRC20 transfers from the blocks of a TronGrid-compatible node. Addresses are
+// requested in their base58 form.
+type TronScanner struct {
+	network shared.BlockchainNetwork
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+// NewTronScanner creates a new scanner for the TronGrid-compatible node of Tron or one of its testnets.
+func NewTronScanner(network shared.BlockchainNetwork, baseURL, apiKey string, client *http.Client) *TronScanner {
+	return &TronScanner{
+		network: network,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  client,
+	}
+}
+
+// tronBlock is a block as the wallet API returns it with visible addresses.
+type tronBlock struct {
+	BlockID     string `json:"blockID"`
+	BlockHeader struct {
+		RawData struct {
+			Number int64 `json:"number"`
+		} `json:"raw_data"`
+	} `json:"block_header"`
+	Transactions []struct {
+		TxID string `json:"txID"`
+		Ret  []struct {
+			ContractRet string `json:"contractRet"`
+		} `json:"ret"`
+		RawData struct {
+			Contract []struct {
+				Type      string `json:"type"`
+				Parameter struct {
+					Value struct {
+						OwnerAddress    string `json:"owner_address"`
+						ToAddress       string `json:"to_address"`
+						Amount          int64  `json:"amount"`
+						ContractAddress string `json:"contract_address"`
+						Data            string `json:"data"`
+					} `json:"value"`
+				} `json:"parameter"`
+			} `json:"contract"`
+			Data string `json:"data"` // Hex of the note the sender attached
+		} `json:"raw_data"`
+	} `json:"transactions"`
+}
+
+// Network returns the network the scanner reads.
+func (s *TronScanner) Network() shared.BlockchainNetwork {
+	return s.network
+}
+
+// LatestBlock returns the number of the newest block.
+func (s *TronScanner) LatestBlock(ctx context.Context) (int64, error) {
+	var block tronBlock
+	if err := s.post(ctx, tronNowBlockPath, map[string]interface{}{"visible": true}, &block); err != nil {
+		return 0, err
+	}
+	return block.BlockHeader.RawData.Number, nil
+}
+
+// ScanTransfers returns the successful transfers of the tokens to the addresses in the blocks.
+func (s *TronScanner) ScanTransfers(ctx context.Context, req *detection.ScanRequest) ([]*detection.Notification, error) {
+	addresses := make(map[string]bool, len(req.Addresses))
+	for _, address := range req.Addresses {
+		addresses[address] = true
+	}
+	var native *shared.Token
+	contracts := make(map[string]shared.Token)
+	for _, token := range req.Tokens {
+		if token.IsNative() {
+			native = &token
+			continue
+		}
+		contracts[token.Contract] = token
+	}
+
+	var notifications []*detection.Notification
+	for from := req.FromBlock; from <= req.ToBlock; from += tronMaxBlocks {
+		var response struct {
+			Block []tronBlock `json:"block"`
+		}
+		if err := s.post(ctx, tronBlocksPath, map[string]interface{}{
+			"startNum": from,
+			"endNum":   min(from+tronMaxBlocks, req.ToBlock+1),
+			"visible":  true,
+		}, &response); err != nil {
+			return nil, err
+		}
+
+		for _, block := range response.Block {
+			for _, tx := range block.Transactions {
+				if len(tx.Ret) == 0 || tx.Ret[0].ContractRet != tronSuccess || len(tx.RawData.Contract) == 0 {
+					continue
+				}
+				contract := tx.RawData.Contract[0]
+				value := contract.Parameter.Value
+				notification := &detection.Notification{
+					Network:         s.network,
+					TransactionHash: tx.TxID,
+					FromAddress:     value.OwnerAddress,
+					BlockNumber:     block.BlockHeader.RawData.Number,
+					BlockHash:       block.BlockID,
+					Memo:            decodeTronNote(tx.RawData.Data),
+				}
+
+				switch contract.Type {
+				case tronTransferContract:
+					if native == nil || value.Amount <= 0 {
+						continue
+					}
+					notification.ToAddress = value.ToAddress
+					notification.Amount = decimal.New(value.Amount, -sunDecimals).String()
+					notification.Currency = native.Symbol
+				case tronTriggerSmartContract:
+					token, ok := contracts[value.ContractAddress]
+					if !ok {
+						continue
+					}
+					to, amount, ok := decodeTRC20Transfer(value.Data, token.Decimals)
+					if !ok || !amount.IsPositive() {
+						continue
+					}
+					notification.ToAddress = to
+					notification.Amount = amount.String()
+					notification.Currency = token.Symbol
+				default:
+					continue
+				}
+				if addresses[notification.ToAddress] {
+					notifications = append(notifications, notification)
+				}
+			}
+		}
+	}
+	return notifications, nil
+}
+
+// post calls a wallet API endpoint and decodes its response into out.
+func (s *TronScanner) post(ctx context.Context, path string, params map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %w", path, err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	if s.apiKey != "" {
+		httpReq.Header.Set(tronAPIKeyHeader, s.apiKey)
+	}
+	return doJSON(s.client, httpReq, out)
+}
+
+// decodeTronNote returns the note of a transaction as text, or "" when it has none or it is not text.
+func decodeTronNote(data string) string {
+	note, err := hex.DecodeString(data)
+	if err != nil || !utf8.Valid(note) {
+		return ""
+	}
+	return strings.TrimSpace(string(note))
+}
+
+// decodeTRC20Transfer returns the recipient and amount of a transfer(address,uint256) call.
+func decodeTRC20Transfer(data string, decimals int32) (string, decimal.Decimal, bool) {
+	raw, err := hex.DecodeString(data)
+	if err != nil || len(raw) != 4+32+32 || hex.EncodeToString(raw[:4]) != trc20TransferSelector {
+		return "", decimal.Zero, false
+	}
+	address := append([]byte{tronAddressPrefix}, raw[4+12:4+32]...)
+	amount := new(big.Int).SetBytes(raw[4+32:])
+	return encodeTronAddress(address), decimal.NewFromBigInt(amount, -decimals), true
+}
+
+// encodeTronAddress returns the base58check form of a 21-byte Tron address.
+func encodeTronAddress(address []byte) string {
+	first := sha256.Sum256(address)
+	second := sha256.Sum256(first[:])
+	payload := append(slices.Clone(address), second[:4]...)
+
+	var encoded []byte
+	number, radix, digit := new(big.Int).SetBytes(payload), big.NewInt(58), new(big.Int)
+	for number.Sign() > 0 {
+		number.DivMod(number, radix, digit)
+		encoded = append(encoded, base58Alphabet[digit.Int64()])
+	}
+	for _, b := range payload {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+	slices.Reverse(encoded)
+	return string(encoded)
+}
@@ -0,0 +1,192 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/approval"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ApprovalRepository implements the approval.Repository interface using GORM.
+type ApprovalRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewApprovalRepository creates a new approval repository.
+func NewApprovalRepository(db *gorm.DB, logger *zap.Logger) approval.Repository {
+	return &ApprovalRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves an approval to the database.
+func (r *ApprovalRepository) Save(ctx context.Context, a *approval.Approval) error {
+	model, err := r.toModel(a)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save approval: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds an approval by ID.
+func (r *ApprovalRepository) FindByID(ctx context.Context, id string) (*approval.Approval, error) {
+	var model ApprovalModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, approval.ErrApprovalNotFound
+		}
+		return nil, fmt.Errorf("failed to find approval: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// FindLatestByReference finds the most recent approval of the given kind for a reference.
+func (r *ApprovalRepository) FindLatestByReference(
+	ctx context.Context,
+	kind approval.Kind,
+	reference string,
+) (*approval.Approval, error) {
+	var model ApprovalModel
+	if err := r.db.WithContext(ctx).
+		Where("kind = ? AND reference = ?", string(kind), reference).
+		Order("created_at DESC").
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, approval.ErrApprovalNotFound
+		}
+		return nil, fmt.Errorf("failed to find approval: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// List retrieves approvals matching the filter, newest first.
+func (r *ApprovalRepository) List(
+	ctx context.Context,
+	req *approval.ListApprovalsRequest,
+) (*approval.ListApprovalsResponse, error) {
+	query := r.db.WithContext(ctx).Where("merchant_id = ?", req.MerchantID)
+	if req.Kind != "" {
+		query = query.Where("kind = ?", string(req.Kind))
+	}
+	if req.Status != "" {
+		query = query.Where("status = ?", string(req.Status))
+	}
+
+	var models []ApprovalModel
+	if err := query.Scopes(keysetPage("created_at", req.Cursor, 0, req.Limit)).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list approvals: %w", err)
+	}
+	models, next := nextPage(models, req.Limit, func(model *ApprovalModel) (time.Time, string) {
+		return model.CreatedAt, model.ID
+	})
+
+	approvals := make([]*approval.Approval, len(models))
+	for i := range models {
+		a, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert approval model to domain: %w", err)
+		}
+		approvals[i] = a
+	}
+
+	return &approval.ListApprovalsResponse{
+		Approvals:  approvals,
+		Limit:      req.Limit,
+		NextCursor: next,
+	}, nil
+}
+
+// Update updates the votes and status of an approval still at the version it was loaded with.
+func (r *ApprovalRepository) Update(ctx context.Context, a *approval.Approval) error {
+	model, err := r.toModel(a)
+	if err != nil {
+		return err
+	}
+
+	result := r.db.WithContext(ctx).Model(&ApprovalModel{}).
+		Where("id = ? AND version = ?", model.ID, model.Version).
+		Updates(map[string]interface{}{
+			"status":     model.Status,
+			"votes":      model.Votes,
+			"decided_at": model.DecidedAt,
+			"version":    model.Version + 1,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update approval: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return approval.ErrConcurrentVote
+	}
+
+	a.SetVersion(model.Version + 1)
+	return nil
+}
+
+// toModel converts a domain approval to a database model.
+func (r *ApprovalRepository) toModel(a *approval.Approval) (*ApprovalModel, error) {
+	votes, err := json.Marshal(a.Votes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal approval votes: %w", err)
+	}
+
+	return &ApprovalModel{
+		ID:          a.ID(),
+		MerchantID:  a.MerchantID(),
+		Kind:        string(a.Kind()),
+		Reference:   a.Reference(),
+		Currency:    a.Currency(),
+		Amount:      a.Amount().String(),
+		Reason:      a.Reason(),
+		RequestedBy: a.RequestedBy(),
+		Required:    a.Required(),
+		Status:      string(a.Status()),
+		Votes:       string(votes),
+		CreatedAt:   a.CreatedAt(),
+		DecidedAt:   a.DecidedAt(),
+		Version:     a.Version(),
+	}, nil
+}
+
+// toDomain converts a database model to a domain approval.
+func (r *ApprovalRepository) toDomain(model *ApprovalModel) (*approval.Approval, error) {
+	amount, err := decimal.NewFromString(model.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid approval amount %q: %w", model.Amount, err)
+	}
+
+	var votes []approval.Vote
+	if err := json.Unmarshal([]byte(model.Votes), &votes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal approval votes: %w", err)
+	}
+
+	return approval.RestoreApproval(
+		model.ID,
+		model.MerchantID,
+		approval.Kind(model.Kind),
+		model.Reference,
+		model.Currency,
+		amount,
+		model.Reason,
+		model.RequestedBy,
+		model.Required,
+		approval.Status(model.Status),
+		votes,
+		model.CreatedAt,
+		model.DecidedAt,
+		model.Version,
+	)
+}
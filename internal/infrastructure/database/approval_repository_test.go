@@ -0,0 +1,79 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/approval"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestApprovalRepository_RoundTripsVotes(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewApprovalRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	a, err := approval.NewApproval(
+		uuid.NewString(), "merchant-1", approval.KindPayout, "payout-1", "USDT", decimal.NewFromInt(25000), "", "", 2,
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, a))
+
+	require.NoError(t, a.Vote("user-1", approval.DecisionApprove, "checked the wallet"))
+	require.NoError(t, a.Vote("user-2", approval.DecisionApprove, ""))
+	require.NoError(t, repo.Update(ctx, a))
+
+	found, err := repo.FindLatestByReference(ctx, approval.KindPayout, "payout-1")
+	require.NoError(t, err)
+	assert.Equal(t, approval.StatusApproved, found.Status())
+	assert.Equal(t, "25000", found.Amount().String())
+	require.Len(t, found.Votes(), 2)
+	assert.Equal(t, "user-1", found.Votes()[0].UserID)
+	assert.Equal(t, "checked the wallet", found.Votes()[0].Note)
+	assert.NotNil(t, found.DecidedAt())
+
+	_, err = repo.FindLatestByReference(ctx, approval.KindRefund, "payout-1")
+	require.ErrorIs(t, err, approval.ErrApprovalNotFound)
+
+	resp, err := repo.List(ctx, &approval.ListApprovalsRequest{
+		MerchantID: "merchant-1", Status: approval.StatusPending, Limit: 10,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Approvals)
+}
+
+func TestApprovalRepository_RejectsConcurrentUpdates(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewApprovalRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	a, err := approval.NewApproval(
+		uuid.NewString(), "merchant-1", approval.KindRefund, "invoice-1", "USDT", decimal.NewFromInt(25000), "", "", 2,
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, a))
+
+	first, err := repo.FindByID(ctx, a.ID())
+	require.NoError(t, err)
+	second, err := repo.FindByID(ctx, a.ID())
+	require.NoError(t, err)
+
+	require.NoError(t, first.Vote("user-1", approval.DecisionApprove, ""))
+	require.NoError(t, repo.Update(ctx, first))
+	assert.Equal(t, 1, first.Version())
+
+	// The second approver's vote was cast on the approval before the first vote was stored
+	require.NoError(t, second.Vote("user-2", approval.DecisionApprove, ""))
+	require.ErrorIs(t, repo.Update(ctx, second), approval.ErrConcurrentVote)
+
+	found, err := repo.FindByID(ctx, a.ID())
+	require.NoError(t, err)
+	require.Len(t, found.Votes(), 1)
+	assert.Equal(t, "user-1", found.Votes()[0].UserID)
+	assert.Equal(t, approval.StatusPending, found.Status())
+}
@@ -0,0 +1,313 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/archive"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// errArchivedInvoiceChanged aborts an archival batch when one of its invoices changed after it was read.
+var errArchivedInvoiceChanged = errors.New("invoice changed while it was being archived")
+
+// ArchiveRepository implements the archive.Repository interface using GORM.
+type ArchiveRepository struct {
+	db     *gorm.DB
+	mapper *InvoiceMapper
+	logger *zap.Logger
+}
+
+// NewArchiveRepository creates a new archive repository.
+func NewArchiveRepository(db *gorm.DB, logger *zap.Logger) archive.Repository {
+	return &ArchiveRepository{
+		db:     db,
+		mapper: NewInvoiceMapper(),
+		logger: logger,
+	}
+}
+
+// archivableStatuses returns the terminal invoice statuses.
+func archivableStatuses() []string {
+	return []string{
+		invoice.StatusPaid.String(),
+		invoice.StatusExpired.String(),
+		invoice.StatusCancelled.String(),
+		invoice.StatusPartiallyRefunded.String(),
+		invoice.StatusRefunded.String(),
+		invoice.StatusUnderpaidClosed.String(),
+	}
+}
+
+// ArchiveInvoices moves up to limit terminal invoices last updated before the cutoff, with their payments and
+// payment events, to the archive in one transaction. Their payment snapshots and metadata index are dropped, as
+// they are rebuilt from the archived rows. A batch in which an invoice changed after it was read is rolled back.
+func (r *ArchiveRepository) ArchiveInvoices(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	var archived int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var invoices []InvoiceModel
+		if err := tx.Where("status IN ? AND updated_at < ?", archivableStatuses(), cutoff).
+			Order("updated_at ASC").Order("id ASC").
+			Limit(limit).
+			Find(&invoices).Error; err != nil {
+			return fmt.Errorf("failed to find invoices to archive: %w", err)
+		}
+		if len(invoices) == 0 {
+			return nil
+		}
+
+		invoiceIDs := make([]string, len(invoices))
+		for i := range invoices {
+			invoiceIDs[i] = invoices[i].ID
+		}
+		var payments []PaymentModel
+		if err := tx.Unscoped().Where("invoice_id IN ?", invoiceIDs).
+			Order("created_at ASC").Order("id ASC").
+			Find(&payments).Error; err != nil {
+			return fmt.Errorf("failed to find payments to archive: %w", err)
+		}
+		paymentIDs := make([]string, len(payments))
+		for i := range payments {
+			paymentIDs[i] = payments[i].ID
+		}
+		var events []PaymentEventModel
+		if len(paymentIDs) > 0 {
+			if err := tx.Where("payment_id IN ?", paymentIDs).
+				Order("payment_id ASC").Order("version ASC").
+				Find(&events).Error; err != nil {
+				return fmt.Errorf("failed to find payment events to archive: %w", err)
+			}
+		}
+
+		models, err := r.toArchivedModels(invoices, payments, events)
+		if err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models).Error; err != nil {
+			return fmt.Errorf("failed to write archived invoices: %w", err)
+		}
+
+		// Invoices are only deleted while still terminal and unchanged since they were read
+		result := tx.Unscoped().
+			Where("id IN ? AND status IN ? AND updated_at < ?", invoiceIDs, archivableStatuses(), cutoff).
+			Delete(&InvoiceModel{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete archived invoices: %w", result.Error)
+		}
+		if int(result.RowsAffected) != len(invoices) {
+			return errArchivedInvoiceChanged
+		}
+		if err := tx.Where("invoice_id IN ?", invoiceIDs).Delete(&InvoiceMetadataModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete metadata of archived invoices: %w", err)
+		}
+		if len(paymentIDs) > 0 {
+			for _, table := range []interface{}{&PaymentEventModel{}, &PaymentSnapshotModel{}} {
+				if err := tx.Where("payment_id IN ?", paymentIDs).Delete(table).Error; err != nil {
+					return fmt.Errorf("failed to delete payment streams of archived invoices: %w", err)
+				}
+			}
+			if err := tx.Unscoped().Where("id IN ?", paymentIDs).Delete(&PaymentModel{}).Error; err != nil {
+				return fmt.Errorf("failed to delete payments of archived invoices: %w", err)
+			}
+		}
+
+		archived = len(invoices)
+		return nil
+	})
+	if errors.Is(err, errArchivedInvoiceChanged) {
+		r.logger.Info("Invoice changed while being archived; retrying on the next round")
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return archived, nil
+}
+
+// List retrieves the archived invoices of a merchant, newest first by creation time.
+func (r *ArchiveRepository) List(
+	ctx context.Context,
+	req *archive.ListArchivedInvoicesRequest,
+) (*archive.ListArchivedInvoicesResponse, error) {
+	query := r.db.WithContext(ctx).Where("merchant_id = ?", req.MerchantID)
+	if req.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *req.CreatedFrom)
+	}
+	if req.CreatedTo != nil {
+		query = query.Where("created_at <= ?", *req.CreatedTo)
+	}
+
+	var models []ArchivedInvoiceModel
+	if err := query.Scopes(keysetPage("created_at", req.Cursor, 0, req.Limit)).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list archived invoices: %w", err)
+	}
+	models, next := nextPage(models, req.Limit, func(m *ArchivedInvoiceModel) (time.Time, string) {
+		return m.CreatedAt, m.ID
+	})
+
+	invoices := make([]*archive.ArchivedInvoice, len(models))
+	for i := range models {
+		archived, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		invoices[i] = archived
+	}
+	return &archive.ListArchivedInvoicesResponse{Invoices: invoices, Limit: req.Limit, NextCursor: next}, nil
+}
+
+// toArchivedModels groups the rows of archived invoices into one archive row per invoice.
+func (r *ArchiveRepository) toArchivedModels(
+	invoices []InvoiceModel,
+	payments []PaymentModel,
+	events []PaymentEventModel,
+) ([]ArchivedInvoiceModel, error) {
+	paymentsByInvoice := make(map[string][]PaymentModel)
+	invoiceOfPayment := make(map[string]string, len(payments))
+	for _, p := range payments {
+		paymentsByInvoice[p.InvoiceID] = append(paymentsByInvoice[p.InvoiceID], p)
+		invoiceOfPayment[p.ID] = p.InvoiceID
+	}
+	eventsByInvoice := make(map[string][]PaymentEventModel)
+	for _, e := range events {
+		invoiceID := invoiceOfPayment[e.PaymentID]
+		eventsByInvoice[invoiceID] = append(eventsByInvoice[invoiceID], e)
+	}
+
+	now := shared.Now().UTC()
+	models := make([]ArchivedInvoiceModel, len(invoices))
+	for i := range invoices {
+		inv := &invoices[i]
+		invoiceJSON, err := json.Marshal(inv)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal invoice %s: %w", inv.ID, err)
+		}
+		invoicePayments := paymentsByInvoice[inv.ID]
+		if invoicePayments == nil {
+			invoicePayments = []PaymentModel{}
+		}
+		paymentsJSON, err := json.Marshal(invoicePayments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal payments of invoice %s: %w", inv.ID, err)
+		}
+		invoiceEvents := eventsByInvoice[inv.ID]
+		if invoiceEvents == nil {
+			invoiceEvents = []PaymentEventModel{}
+		}
+		eventsJSON, err := json.Marshal(invoiceEvents)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal payment events of invoice %s: %w", inv.ID, err)
+		}
+
+		models[i] = ArchivedInvoiceModel{
+			ID:            inv.ID,
+			MerchantID:    inv.MerchantID,
+			Status:        inv.Status,
+			Invoice:       string(invoiceJSON),
+			Payments:      string(paymentsJSON),
+			PaymentEvents: string(eventsJSON),
+			CreatedAt:     inv.CreatedAt,
+			ArchivedAt:    now,
+		}
+	}
+	return models, nil
+}
+
+// toDomain converts an archive row to an archived invoice.
+func (r *ArchiveRepository) toDomain(model *ArchivedInvoiceModel) (*archive.ArchivedInvoice, error) {
+	inv, err := archivedInvoiceToDomain(r.mapper, model)
+	if err != nil {
+		return nil, err
+	}
+
+	var payments []PaymentModel
+	if err := json.Unmarshal([]byte(model.Payments), &payments); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payments of archived invoice %s: %w", model.ID, err)
+	}
+	var events []PaymentEventModel
+	if err := json.Unmarshal([]byte(model.PaymentEvents), &events); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payment events of archived invoice %s: %w", model.ID, err)
+	}
+
+	archived := &archive.ArchivedInvoice{
+		Invoice:       inv,
+		Payments:      make([]archive.Payment, len(payments)),
+		PaymentEvents: make([]archive.PaymentEvent, len(events)),
+		ArchivedAt:    model.ArchivedAt,
+	}
+	for i, p := range payments {
+		archived.Payments[i] = archive.Payment{
+			ID:            p.ID,
+			Network:       p.Network,
+			TxHash:        p.TxHash,
+			Amount:        p.Amount,
+			FromAddress:   p.FromAddress,
+			ToAddress:     p.ToAddress,
+			Status:        p.Status,
+			Confirmations: p.Confirmations,
+			BlockNumber:   p.BlockNumber,
+			DetectedAt:    p.DetectedAt,
+			ConfirmedAt:   p.ConfirmedAt,
+		}
+		if p.NetworkFee != nil {
+			archived.Payments[i].NetworkFee = *p.NetworkFee
+		}
+	}
+	for i, e := range events {
+		archived.PaymentEvents[i] = archive.PaymentEvent{
+			PaymentID:  e.PaymentID,
+			Version:    e.Version,
+			Type:       e.Type,
+			Data:       json.RawMessage(e.Data),
+			OccurredAt: e.OccurredAt,
+		}
+	}
+	return archived, nil
+}
+
+// findArchivedInvoice retrieves an archived invoice by ID within the merchant scope of the context.
+func findArchivedInvoice(ctx context.Context, db *gorm.DB, mapper *InvoiceMapper, id string) (*invoice.Invoice, error) {
+	var model ArchivedInvoiceModel
+	err := db.WithContext(ctx).
+		Scopes(merchantScope(ctx)).
+		Select("id", "invoice").
+		Where("id = ?", id).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to find archived invoice: %w", err)
+	}
+
+	return archivedInvoiceToDomain(mapper, &model)
+}
+
+// rejectArchived fails with invoice.ErrInvoiceArchived when the invoice is in the archive.
+func rejectArchived(tx *gorm.DB, id string) error {
+	var count int64
+	if err := tx.Model(&ArchivedInvoiceModel{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check the invoice archive: %w", err)
+	}
+	if count > 0 {
+		return invoice.ErrInvoiceArchived
+	}
+	return nil
+}
+
+// archivedInvoiceToDomain restores the invoice of an archive row from the invoice row it was archived as.
+func archivedInvoiceToDomain(mapper *InvoiceMapper, model *ArchivedInvoiceModel) (*invoice.Invoice, error) {
+	var invoiceModel InvoiceModel
+	if err := json.Unmarshal([]byte(model.Invoice), &invoiceModel); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal archived invoice %s: %w", model.ID, err)
+	}
+	return mapper.ToDomain(&invoiceModel)
+}
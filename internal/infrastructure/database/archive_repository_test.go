@@ -0,0 +1,117 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/archive"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestArchiveRepository(t *testing.T) {
+	db := setupTestDB(t)
+	invoices := database.NewInvoiceRepository(db, zap.NewNop())
+	payments := database.NewPaymentRepository(db)
+	repo := database.NewArchiveRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	cutoff := now.AddDate(0, -12, 0)
+	// setAge moves an invoice into the given status as of the given time
+	setAge := func(id, status string, updatedAt time.Time) {
+		require.NoError(t, db.Model(&database.InvoiceModel{}).Where("id = ?", id).
+			Updates(map[string]interface{}{"status": status, "created_at": updatedAt, "updated_at": updatedAt}).Error)
+	}
+
+	// The payment fixtures belong to test-invoice-id
+	require.NoError(t, invoices.Save(ctx, createTestInvoiceWithID(t, "test-invoice-id")))
+	setAge("test-invoice-id", invoice.StatusPaid.String(), now.AddDate(-2, 0, 0))
+	require.NoError(t, payments.Save(ctx, createTestPayment(t)))
+	require.NoError(t, invoices.Save(ctx, createTestInvoiceWithID(t, "old-cancelled")))
+	setAge("old-cancelled", invoice.StatusCancelled.String(), now.AddDate(-1, -6, 0))
+	require.NoError(t, invoices.Save(ctx, createTestInvoiceWithID(t, "recent-paid")))
+	setAge("recent-paid", invoice.StatusPaid.String(), now.AddDate(0, -1, 0))
+	require.NoError(t, invoices.Save(ctx, createTestInvoiceWithID(t, "old-pending")))
+	setAge("old-pending", invoice.StatusPending.String(), now.AddDate(-2, 0, 0))
+
+	t.Run("Moves_Due_Terminal_Invoices_In_Batches", func(t *testing.T) {
+		archived, err := repo.ArchiveInvoices(ctx, cutoff, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, archived)
+		archived, err = repo.ArchiveInvoices(ctx, cutoff, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, archived)
+		archived, err = repo.ArchiveInvoices(ctx, cutoff, 10)
+		require.NoError(t, err)
+		assert.Zero(t, archived)
+
+		var remaining []string
+		require.NoError(t, db.Model(&database.InvoiceModel{}).Order("id").Pluck("id", &remaining).Error)
+		assert.Equal(t, []string{"old-pending", "recent-paid"}, remaining)
+
+		var count int64
+		require.NoError(t, db.Model(&database.PaymentModel{}).Unscoped().Count(&count).Error)
+		assert.Zero(t, count)
+		require.NoError(t, db.Model(&database.PaymentEventModel{}).Count(&count).Error)
+		assert.Zero(t, count)
+	})
+
+	t.Run("FindByID_Reads_Through_The_Archive", func(t *testing.T) {
+		inv, err := invoices.FindByID(ctx, "test-invoice-id")
+		require.NoError(t, err)
+		assert.Equal(t, invoice.StatusPaid, inv.Status())
+		assert.Equal(t, "Test Invoice", inv.Title())
+
+		_, err = invoices.FindByID(shared.WithMerchantID(ctx, "other-merchant"), "test-invoice-id")
+		require.ErrorIs(t, err, shared.ErrNotFound)
+	})
+
+	t.Run("Update_Rejects_Archived_Invoices", func(t *testing.T) {
+		inv, err := invoices.FindByID(ctx, "old-cancelled")
+		require.NoError(t, err)
+		require.ErrorIs(t, invoices.Update(ctx, inv), invoice.ErrInvoiceArchived)
+
+		var count int64
+		require.NoError(t, db.Model(&database.InvoiceModel{}).Where("id = ?", "old-cancelled").Count(&count).Error)
+		assert.Zero(t, count)
+	})
+
+	t.Run("List_Pages_Newest_First_With_Payments", func(t *testing.T) {
+		first, err := repo.List(ctx, &archive.ListArchivedInvoicesRequest{MerchantID: "test-merchant-id", Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, first.Invoices, 1)
+		assert.Equal(t, "old-cancelled", first.Invoices[0].Invoice.ID())
+		require.NotNil(t, first.NextCursor)
+
+		second, err := repo.List(ctx, &archive.ListArchivedInvoicesRequest{
+			MerchantID: "test-merchant-id", Limit: 1, Cursor: first.NextCursor,
+		})
+		require.NoError(t, err)
+		require.Len(t, second.Invoices, 1)
+		paid := second.Invoices[0]
+		assert.Equal(t, "test-invoice-id", paid.Invoice.ID())
+		assert.Nil(t, second.NextCursor)
+		require.Len(t, paid.Payments, 1)
+		assert.Equal(t, "test-payment-id", paid.Payments[0].ID)
+		assert.NotEmpty(t, paid.PaymentEvents)
+		assert.Equal(t, "test-payment-id", paid.PaymentEvents[0].PaymentID)
+
+		from := now.AddDate(-1, -7, 0)
+		filtered, err := repo.List(ctx, &archive.ListArchivedInvoicesRequest{
+			MerchantID: "test-merchant-id", CreatedFrom: &from, Limit: 10,
+		})
+		require.NoError(t, err)
+		require.Len(t, filtered.Invoices, 1)
+		assert.Equal(t, "old-cancelled", filtered.Invoices[0].Invoice.ID())
+
+		other, err := repo.List(ctx, &archive.ListArchivedInvoicesRequest{MerchantID: "other-merchant", Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, other.Invoices)
+	})
+}
@@ -0,0 +1,199 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// AuditRepository implements the audit.Repository interface using GORM.
+// The audit log is append-only: it exposes no update or delete operations.
+type AuditRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewAuditRepository creates a new audit log repository.
+func NewAuditRepository(db *gorm.DB, logger *zap.Logger) audit.Repository {
+	return &AuditRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Append stores a new audit entry.
+func (r *AuditRepository) Append(ctx context.Context, entry *audit.Entry) error {
+	model, err := r.toModel(entry)
+	if err != nil {
+		return fmt.Errorf("failed to convert audit entry to model: %w", err)
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves audit entries for a merchant, newest first, paged by occurrence time and ID.
+func (r *AuditRepository) List(ctx context.Context, req *audit.ListEntriesRequest) (*audit.ListEntriesResponse, error) {
+	query := r.db.WithContext(ctx).Model(&AuditEntryModel{}).Where("merchant_id = ?", req.MerchantID)
+	query = applyAuditFilters(query, req)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	var models []AuditEntryModel
+	if err := query.Scopes(keysetPage("occurred_at", req.Cursor, req.Offset, req.Limit)).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	models, next := nextPage(models, req.Limit, func(model *AuditEntryModel) (time.Time, string) {
+		return model.OccurredAt, model.ID
+	})
+
+	entries := make([]*audit.Entry, len(models))
+	for i := range models {
+		entry, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert audit entry model to domain: %w", err)
+		}
+		entries[i] = entry
+	}
+
+	return &audit.ListEntriesResponse{
+		Entries:    entries,
+		Total:      int(total),
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+		NextCursor: next,
+	}, nil
+}
+
+// applyAuditFilters narrows an audit log query to the request filters.
+func applyAuditFilters(query *gorm.DB, req *audit.ListEntriesRequest) *gorm.DB {
+	if req.ActorID != "" {
+		query = query.Where("actor_id = ?", req.ActorID)
+	}
+	if req.ActorType != "" {
+		query = query.Where("actor_type = ?", string(req.ActorType))
+	}
+	if req.Action != "" {
+		query = query.Where("action = ?", req.Action)
+	}
+	if req.ResourceType != "" {
+		query = query.Where("resource_type = ?", req.ResourceType)
+	}
+	if req.ResourceID != "" {
+		query = query.Where("resource_id = ?", req.ResourceID)
+	}
+	if req.RequestID != "" {
+		query = query.Where("request_id = ?", req.RequestID)
+	}
+	if req.From != nil {
+		query = query.Where("occurred_at >= ?", *req.From)
+	}
+	if req.To != nil {
+		query = query.Where("occurred_at <= ?", *req.To)
+	}
+	return query
+}
+
+// toModel converts a domain audit entry to a database model.
+func (r *AuditRepository) toModel(entry *audit.Entry) (*AuditEntryModel, error) {
+	detailsJSON, err := json.Marshal(entry.Details())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal details: %w", err)
+	}
+
+	beforeJSON, err := marshalSnapshot(entry.Change().Before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal before snapshot: %w", err)
+	}
+
+	afterJSON, err := marshalSnapshot(entry.Change().After)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal after snapshot: %w", err)
+	}
+
+	return &AuditEntryModel{
+		ID:           entry.ID(),
+		MerchantID:   entry.MerchantID(),
+		ActorID:      entry.Actor().ID,
+		ActorType:    string(entry.Actor().Type),
+		ActorRole:    entry.Actor().Role,
+		IPAddress:    entry.Origin().IPAddress,
+		RequestID:    entry.Origin().RequestID,
+		UserAgent:    entry.Origin().UserAgent,
+		Action:       entry.Action(),
+		ResourceType: entry.ResourceType(),
+		ResourceID:   entry.ResourceID(),
+		Before:       beforeJSON,
+		After:        afterJSON,
+		Details:      string(detailsJSON),
+		OccurredAt:   entry.OccurredAt(),
+	}, nil
+}
+
+// toDomain converts a database model to a domain audit entry.
+func (r *AuditRepository) toDomain(model *AuditEntryModel) (*audit.Entry, error) {
+	details, err := unmarshalSnapshot(&model.Details)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal details: %w", err)
+	}
+
+	before, err := unmarshalSnapshot(model.Before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal before snapshot: %w", err)
+	}
+
+	after, err := unmarshalSnapshot(model.After)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal after snapshot: %w", err)
+	}
+
+	return audit.RestoreEntry(
+		model.ID,
+		model.MerchantID,
+		audit.Actor{ID: model.ActorID, Type: audit.ActorType(model.ActorType), Role: model.ActorRole},
+		audit.Origin{IPAddress: model.IPAddress, RequestID: model.RequestID, UserAgent: model.UserAgent},
+		model.Action,
+		model.ResourceType,
+		model.ResourceID,
+		audit.Change{Before: before, After: after},
+		details,
+		model.OccurredAt,
+	)
+}
+
+// marshalSnapshot encodes a resource snapshot, storing absent snapshots as NULL.
+func marshalSnapshot(snapshot map[string]interface{}) (*string, error) {
+	if snapshot == nil {
+		return nil, nil //nolint:nilnil // An absent snapshot is not an error
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	encoded := string(data)
+	return &encoded, nil
+}
+
+// unmarshalSnapshot decodes a stored JSON object, returning nil for absent values.
+func unmarshalSnapshot(data *string) (map[string]interface{}, error) {
+	if data == nil || *data == "" {
+		return nil, nil //nolint:nilnil // An absent snapshot is not an error
+	}
+	var snapshot map[string]interface{}
+	if err := json.Unmarshal([]byte(*data), &snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
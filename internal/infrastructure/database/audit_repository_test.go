@@ -0,0 +1,179 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAuditRepository_AppendAndFilter(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewAuditRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	base := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	entries := []struct {
+		id     string
+		actor  audit.Actor
+		action string
+		at     time.Time
+	}{
+		{"entry-1", audit.Actor{ID: "user-1", Type: audit.ActorTypeUser, Role: "owner"}, "invoice.cancel", base},
+		{"entry-2", audit.Actor{ID: "key-1", Type: audit.ActorTypeAPIKey}, "invoice.create", base.Add(time.Hour)},
+		{"entry-3", audit.Actor{ID: "user-1", Type: audit.ActorTypeUser, Role: "owner"}, "team.role_changed",
+			base.Add(2 * time.Hour)},
+	}
+
+	for _, e := range entries {
+		entry, err := audit.RestoreEntry(
+			e.id,
+			"merchant-1",
+			e.actor,
+			audit.Origin{IPAddress: "203.0.113.7", RequestID: "req_" + e.id},
+			e.action,
+			"invoice",
+			"inv-1",
+			audit.Change{
+				Before: map[string]interface{}{"status": "pending"},
+				After:  map[string]interface{}{"status": "cancelled"},
+			},
+			nil,
+			e.at,
+		)
+		require.NoError(t, err)
+		require.NoError(t, repo.Append(ctx, entry))
+	}
+
+	t.Run("newest first with snapshots", func(t *testing.T) {
+		resp, err := repo.List(ctx, &audit.ListEntriesRequest{MerchantID: "merchant-1", Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, 3, resp.Total)
+		assert.Equal(t, "entry-3", resp.Entries[0].ID())
+
+		last := resp.Entries[2]
+		assert.Equal(t, "203.0.113.7", last.Origin().IPAddress)
+		assert.Equal(t, "req_entry-1", last.Origin().RequestID)
+		assert.Equal(t, "pending", last.Change().Before["status"])
+		assert.Equal(t, "cancelled", last.Change().After["status"])
+	})
+
+	tests := []struct {
+		name     string
+		req      audit.ListEntriesRequest
+		expected []string
+	}{
+		{"by actor", audit.ListEntriesRequest{ActorID: "user-1"}, []string{"entry-3", "entry-1"}},
+		{"by actor type", audit.ListEntriesRequest{ActorType: audit.ActorTypeAPIKey}, []string{"entry-2"}},
+		{"by action", audit.ListEntriesRequest{Action: "invoice.cancel"}, []string{"entry-1"}},
+		{"by request", audit.ListEntriesRequest{RequestID: "req_entry-2"}, []string{"entry-2"}},
+		{"by time range", audit.ListEntriesRequest{From: ptrTime(base.Add(30 * time.Minute)),
+			To: ptrTime(base.Add(90 * time.Minute))}, []string{"entry-2"}},
+		{"other merchant", audit.ListEntriesRequest{MerchantID: "merchant-2"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			if req.MerchantID == "" {
+				req.MerchantID = "merchant-1"
+			}
+			req.Limit = 10
+
+			resp, err := repo.List(ctx, &req)
+			require.NoError(t, err)
+
+			ids := make([]string, len(resp.Entries))
+			for i, entry := range resp.Entries {
+				ids[i] = entry.ID()
+			}
+			assert.Equal(t, tt.expected, ids)
+			assert.Equal(t, len(tt.expected), resp.Total)
+		})
+	}
+}
+
+func TestAuditRepository_AppendOnly(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewAuditRepository(db, zap.NewNop())
+
+	entry, err := audit.NewEntry(
+		"entry-1",
+		"merchant-1",
+		audit.Actor{ID: "user-1", Type: audit.ActorTypeUser},
+		audit.Origin{},
+		"invoice.cancel",
+		"invoice",
+		"inv-1",
+		audit.Change{},
+		nil,
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Append(context.Background(), entry))
+
+	err = db.Model(&database.AuditEntryModel{ID: "entry-1"}).Update("action", "tampered").Error
+	require.ErrorIs(t, err, audit.ErrImmutable)
+
+	err = db.Delete(&database.AuditEntryModel{ID: "entry-1"}).Error
+	require.ErrorIs(t, err, audit.ErrImmutable)
+
+	resp, err := repo.List(context.Background(), &audit.ListEntriesRequest{MerchantID: "merchant-1", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 1)
+	assert.Equal(t, "invoice.cancel", resp.Entries[0].Action())
+	assert.Nil(t, resp.Entries[0].Change().Before)
+}
+
+func TestAuditRepository_CursorPagination(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewAuditRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	base := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	appendEntry := func(id string, at time.Time) {
+		entry, err := audit.RestoreEntry(id, "merchant-1", audit.Actor{ID: "user-1", Type: audit.ActorTypeUser},
+			audit.Origin{}, "invoice.create", "invoice", "inv-1", audit.Change{}, nil, at)
+		require.NoError(t, err)
+		require.NoError(t, repo.Append(ctx, entry))
+	}
+	// Entries recorded in the same instant are ordered by ID
+	appendEntry("entry-a", base)
+	appendEntry("entry-b", base.Add(time.Minute))
+	appendEntry("entry-c", base.Add(time.Minute))
+	appendEntry("entry-d", base.Add(2*time.Minute))
+
+	var ids []string
+	req := &audit.ListEntriesRequest{MerchantID: "merchant-1", Limit: 2}
+	for page := 0; ; page++ {
+		resp, err := repo.List(ctx, req)
+		require.NoError(t, err)
+		for _, entry := range resp.Entries {
+			ids = append(ids, entry.ID())
+		}
+		if page == 0 {
+			// An entry recorded mid-listing does not shift the following pages
+			appendEntry("entry-e", base.Add(time.Hour))
+		}
+		if resp.NextCursor == nil {
+			break
+		}
+		req.Cursor = resp.NextCursor
+	}
+	assert.Equal(t, []string{"entry-d", "entry-c", "entry-b", "entry-a"}, ids)
+
+	// The deprecated offset still pages without a cursor
+	resp, err := repo.List(ctx, &audit.ListEntriesRequest{MerchantID: "merchant-1", Limit: 2, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 2)
+	assert.Equal(t, "entry-d", resp.Entries[0].ID())
+	assert.NotNil(t, resp.NextCursor)
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,254 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/automation"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ruleConditionRecord is the stored form of an automation rule condition.
+type ruleConditionRecord struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+// ruleActionRecord is the stored form of an automation rule action.
+type ruleActionRecord struct {
+	Type          string `json:"type"`
+	URL           string `json:"url,omitempty"`
+	Tag           string `json:"tag,omitempty"`
+	Confirmations int    `json:"confirmations,omitempty"`
+}
+
+// AutomationRuleRepository implements the automation.Repository interface using GORM.
+type AutomationRuleRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewAutomationRuleRepository creates a new automation rule repository.
+func NewAutomationRuleRepository(db *gorm.DB, logger *zap.Logger) automation.Repository {
+	return &AutomationRuleRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a new automation rule to the database.
+func (r *AutomationRuleRepository) Save(ctx context.Context, rule *automation.Rule) error {
+	if err := r.checkNameFree(ctx, rule); err != nil {
+		return err
+	}
+
+	model, err := r.toModel(rule)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save automation rule: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds an automation rule by ID.
+func (r *AutomationRuleRepository) FindByID(ctx context.Context, id string) (*automation.Rule, error) {
+	var model AutomationRuleModel
+	err := r.db.WithContext(ctx).Scopes(merchantScope(ctx)).Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			reportCrossTenantAccess(ctx, r.db, r.logger, &AutomationRuleModel{}, "automation_rule", id)
+			return nil, automation.ErrRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to find automation rule: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// ListByMerchant lists a merchant's automation rules ordered by name.
+func (r *AutomationRuleRepository) ListByMerchant(ctx context.Context, merchantID string) ([]*automation.Rule, error) {
+	var models []AutomationRuleModel
+	if err := r.db.WithContext(ctx).
+		Where("merchant_id = ?", merchantID).
+		Order("name ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list automation rules: %w", err)
+	}
+
+	return r.toDomains(models)
+}
+
+// ListEnabled lists a merchant's enabled automation rules evaluated on the trigger, ordered by creation.
+func (r *AutomationRuleRepository) ListEnabled(
+	ctx context.Context,
+	merchantID string,
+	trigger automation.Trigger,
+) ([]*automation.Rule, error) {
+	var models []AutomationRuleModel
+	if err := r.db.WithContext(ctx).
+		Where("merchant_id = ? AND trigger = ? AND enabled = ?", merchantID, string(trigger), true).
+		Order("created_at ASC, id ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list enabled automation rules: %w", err)
+	}
+
+	return r.toDomains(models)
+}
+
+// Update updates an existing automation rule. Its secret never changes.
+func (r *AutomationRuleRepository) Update(ctx context.Context, rule *automation.Rule) error {
+	if err := r.checkNameFree(ctx, rule); err != nil {
+		return err
+	}
+
+	model, err := r.toModel(rule)
+	if err != nil {
+		return err
+	}
+	result := r.db.WithContext(ctx).Model(&AutomationRuleModel{}).
+		Scopes(merchantScope(ctx)).
+		Where("id = ?", rule.ID()).
+		Updates(map[string]interface{}{
+			"name":       model.Name,
+			"trigger":    model.Trigger,
+			"conditions": model.Conditions,
+			"actions":    model.Actions,
+			"enabled":    model.Enabled,
+			"updated_at": model.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update automation rule: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		reportCrossTenantAccess(ctx, r.db, r.logger, &AutomationRuleModel{}, "automation_rule", rule.ID())
+		return automation.ErrRuleNotFound
+	}
+	return nil
+}
+
+// Delete removes an automation rule.
+func (r *AutomationRuleRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Scopes(merchantScope(ctx)).Where("id = ?", id).Delete(&AutomationRuleModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete automation rule: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		reportCrossTenantAccess(ctx, r.db, r.logger, &AutomationRuleModel{}, "automation_rule", id)
+		return automation.ErrRuleNotFound
+	}
+	return nil
+}
+
+// checkNameFree returns ErrNameTaken if another of the merchant's rules has the rule's name.
+func (r *AutomationRuleRepository) checkNameFree(ctx context.Context, rule *automation.Rule) error {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&AutomationRuleModel{}).
+		Where("merchant_id = ? AND name = ? AND id <> ?", rule.MerchantID(), rule.Name(), rule.ID()).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check automation rule name: %w", err)
+	}
+	if count > 0 {
+		return automation.ErrNameTaken
+	}
+	return nil
+}
+
+// toModel converts a domain automation rule to a database model.
+func (r *AutomationRuleRepository) toModel(rule *automation.Rule) (*AutomationRuleModel, error) {
+	conditions := make([]ruleConditionRecord, 0, len(rule.Conditions()))
+	for _, condition := range rule.Conditions() {
+		conditions = append(conditions, ruleConditionRecord{
+			Field:    condition.Field,
+			Operator: string(condition.Operator),
+			Value:    condition.Value,
+		})
+	}
+	encodedConditions, err := json.Marshal(conditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal automation rule conditions: %w", err)
+	}
+
+	actions := make([]ruleActionRecord, 0, len(rule.Actions()))
+	for _, action := range rule.Actions() {
+		actions = append(actions, ruleActionRecord{
+			Type:          string(action.Type),
+			URL:           action.URL,
+			Tag:           action.Tag,
+			Confirmations: action.Confirmations,
+		})
+	}
+	encodedActions, err := json.Marshal(actions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal automation rule actions: %w", err)
+	}
+
+	return &AutomationRuleModel{
+		ID:         rule.ID(),
+		MerchantID: rule.MerchantID(),
+		Name:       rule.Name(),
+		Trigger:    string(rule.Trigger()),
+		Conditions: string(encodedConditions),
+		Actions:    string(encodedActions),
+		Enabled:    rule.Enabled(),
+		Secret:     rule.Secret(),
+		CreatedAt:  rule.CreatedAt(),
+		UpdatedAt:  rule.UpdatedAt(),
+	}, nil
+}
+
+// toDomains converts database models to domain automation rules.
+func (r *AutomationRuleRepository) toDomains(models []AutomationRuleModel) ([]*automation.Rule, error) {
+	rules := make([]*automation.Rule, len(models))
+	for i := range models {
+		rule, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert automation rule model to domain: %w", err)
+		}
+		rules[i] = rule
+	}
+	return rules, nil
+}
+
+// toDomain converts a database model to a domain automation rule.
+func (r *AutomationRuleRepository) toDomain(model *AutomationRuleModel) (*automation.Rule, error) {
+	var conditionRecords []ruleConditionRecord
+	if err := json.Unmarshal([]byte(model.Conditions), &conditionRecords); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal automation rule conditions: %w", err)
+	}
+	conditions := make([]automation.Condition, len(conditionRecords))
+	for i, record := range conditionRecords {
+		conditions[i] = automation.Condition{
+			Field:    record.Field,
+			Operator: automation.Operator(record.Operator),
+			Value:    record.Value,
+		}
+	}
+
+	var actionRecords []ruleActionRecord
+	if err := json.Unmarshal([]byte(model.Actions), &actionRecords); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal automation rule actions: %w", err)
+	}
+	actions := make([]automation.Action, len(actionRecords))
+	for i, record := range actionRecords {
+		actions[i] = automation.Action{
+			Type:          automation.ActionType(record.Type),
+			URL:           record.URL,
+			Tag:           record.Tag,
+			Confirmations: record.Confirmations,
+		}
+	}
+
+	return automation.RestoreRule(model.ID, model.MerchantID, model.Secret, automation.Definition{
+		Name:       model.Name,
+		Trigger:    automation.Trigger(model.Trigger),
+		Conditions: conditions,
+		Actions:    actions,
+		Enabled:    model.Enabled,
+	}, model.CreatedAt, model.UpdatedAt)
+}
@@ -0,0 +1,93 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/automation"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestAutomationRule(
+	t *testing.T, id, merchantID, name string, trigger automation.Trigger,
+) *automation.Rule {
+	rule, err := automation.NewRule(id, merchantID, "rule-secret", automation.Definition{
+		Name:    name,
+		Trigger: trigger,
+		Conditions: []automation.Condition{
+			{Field: automation.FieldTotal, Operator: automation.OperatorGreaterThan, Value: "100"},
+		},
+		Actions: []automation.Action{
+			{Type: automation.ActionCallURL, URL: "https://merchant.example/hooks"},
+			{Type: automation.ActionAddTag, Tag: "large"},
+		},
+		Enabled: true,
+	})
+	require.NoError(t, err)
+	return rule
+}
+
+func TestAutomationRuleRepository(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewAutomationRuleRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	large := newTestAutomationRule(t, "rule-1", "merchant-1", "Large orders", automation.TriggerInvoicePaid)
+	require.NoError(t, repo.Save(ctx, large))
+	require.NoError(t, repo.Save(ctx,
+		newTestAutomationRule(t, "rule-2", "merchant-1", "Created", automation.TriggerInvoiceCreated)))
+	require.NoError(t, repo.Save(ctx,
+		newTestAutomationRule(t, "rule-3", "merchant-2", "Large orders", automation.TriggerInvoicePaid)))
+
+	t.Run("round trip", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, "rule-1")
+		require.NoError(t, err)
+		assert.Equal(t, large.Definition(), found.Definition())
+		assert.Equal(t, "rule-secret", found.Secret())
+	})
+
+	t.Run("names are unique per merchant", func(t *testing.T) {
+		err := repo.Save(ctx,
+			newTestAutomationRule(t, "rule-4", "merchant-1", "Large orders", automation.TriggerInvoicePaid))
+		require.ErrorIs(t, err, automation.ErrNameTaken)
+	})
+
+	t.Run("list by merchant", func(t *testing.T) {
+		rules, err := repo.ListByMerchant(ctx, "merchant-1")
+		require.NoError(t, err)
+		require.Len(t, rules, 2)
+		assert.Equal(t, "Created", rules[0].Name())
+		assert.Equal(t, "Large orders", rules[1].Name())
+	})
+
+	t.Run("list enabled by trigger", func(t *testing.T) {
+		rules, err := repo.ListEnabled(ctx, "merchant-1", automation.TriggerInvoicePaid)
+		require.NoError(t, err)
+		require.Len(t, rules, 1)
+		assert.Equal(t, "rule-1", rules[0].ID())
+
+		definition := large.Definition()
+		definition.Enabled = false
+		require.NoError(t, large.Update(definition))
+		require.NoError(t, repo.Update(ctx, large))
+
+		rules, err = repo.ListEnabled(ctx, "merchant-1", automation.TriggerInvoicePaid)
+		require.NoError(t, err)
+		assert.Empty(t, rules)
+
+		found, err := repo.FindByID(ctx, "rule-1")
+		require.NoError(t, err)
+		assert.False(t, found.Enabled())
+		assert.Equal(t, "rule-secret", found.Secret())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, "rule-2"))
+		_, err := repo.FindByID(ctx, "rule-2")
+		require.ErrorIs(t, err, automation.ErrRuleNotFound)
+		require.ErrorIs(t, repo.Delete(ctx, "rule-2"), automation.ErrRuleNotFound)
+	})
+}
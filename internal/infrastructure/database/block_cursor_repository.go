@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BlockCursorRepository implements the detection.CursorRepository interface using GORM.
+type BlockCursorRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewBlockCursorRepository creates a new block cursor repository.
+func NewBlockCursorRepository(db *gorm.DB, logger *zap.Logger) detection.CursorRepository {
+	return &BlockCursorRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// FindCursor returns the cursor of a watcher on a network, or detection.ErrCursorNotFound.
+func (r *BlockCursorRepository) FindCursor(
+	ctx context.Context,
+	network shared.BlockchainNetwork,
+	watcher string,
+) (*detection.BlockCursor, error) {
+	var model BlockCursorModel
+	err := r.db.WithContext(ctx).
+		Where("network = ? AND watcher = ?", string(network), watcher).
+		First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s on %s", detection.ErrCursorNotFound, watcher, network)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find block cursor: %w", err)
+	}
+
+	return toBlockCursor(&model), nil
+}
+
+// ListCursors returns the cursors of every watcher and network.
+func (r *BlockCursorRepository) ListCursors(ctx context.Context) ([]*detection.BlockCursor, error) {
+	var models []BlockCursorModel
+	if err := r.db.WithContext(ctx).Order("network, watcher").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list block cursors: %w", err)
+	}
+
+	cursors := make([]*detection.BlockCursor, len(models))
+	for i := range models {
+		cursors[i] = toBlockCursor(&models[i])
+	}
+	return cursors, nil
+}
+
+// AdvanceCursor stores the cursor in one statement that only moves an existing cursor forward, so a watcher
+// with a stale view, including one on another instance, cannot move it back.
+func (r *BlockCursorRepository) AdvanceCursor(ctx context.Context, cursor *detection.BlockCursor) error {
+	updatedAt := cursor.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = shared.Now().UTC()
+	}
+	model := &BlockCursorModel{
+		Network:   string(cursor.Network),
+		Watcher:   cursor.Watcher,
+		Block:     cursor.Block,
+		UpdatedAt: updatedAt,
+	}
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "network"}, {Name: "watcher"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"block":      cursor.Block,
+			"updated_at": updatedAt,
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			gorm.Expr("block_cursors.block <= ?", cursor.Block),
+		}},
+	}).Create(model)
+	if result.Error != nil {
+		return fmt.Errorf("failed to advance block cursor: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s on %s to block %d", detection.ErrCursorRegression,
+			cursor.Watcher, cursor.Network, cursor.Block)
+	}
+
+	return nil
+}
+
+// toBlockCursor converts a block cursor model to the domain cursor.
+func toBlockCursor(model *BlockCursorModel) *detection.BlockCursor {
+	return &detection.BlockCursor{
+		Network:   shared.BlockchainNetwork(model.Network),
+		Watcher:   model.Watcher,
+		Block:     model.Block,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
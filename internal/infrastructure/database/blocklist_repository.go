@@ -0,0 +1,179 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/compliance"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// blocklistBatchSize is the number of entries inserted per statement when replacing a list.
+const blocklistBatchSize = 500
+
+// BlocklistRepository implements the compliance.BlocklistRepository interface using GORM.
+type BlocklistRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewBlocklistRepository creates a new address blocklist repository.
+func NewBlocklistRepository(db *gorm.DB, logger *zap.Logger) compliance.BlocklistRepository {
+	return &BlocklistRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a new blocklist entry to the database.
+func (r *BlocklistRepository) Save(ctx context.Context, entry *compliance.BlocklistEntry) error {
+	if err := r.db.WithContext(ctx).Create(r.toModel(entry)).Error; err != nil {
+		return fmt.Errorf("failed to save blocklist entry: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a blocklist entry by ID.
+func (r *BlocklistRepository) FindByID(ctx context.Context, id string) (*compliance.BlocklistEntry, error) {
+	var model BlocklistEntryModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, compliance.ErrBlocklistEntryNotFound
+		}
+		return nil, fmt.Errorf("failed to find blocklist entry: %w", err)
+	}
+	return r.toDomain(&model)
+}
+
+// Delete removes a blocklist entry.
+func (r *BlocklistRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&BlocklistEntryModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete blocklist entry: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return compliance.ErrBlocklistEntryNotFound
+	}
+	return nil
+}
+
+// List retrieves blocklist entries matching the filter, newest first.
+func (r *BlocklistRepository) List(
+	ctx context.Context,
+	req *compliance.ListBlocklistRequest,
+) (*compliance.ListBlocklistResponse, error) {
+	query := r.db.WithContext(ctx).Model(&BlocklistEntryModel{})
+	if req.IncludeGlobal {
+		query = query.Where("merchant_id = ? OR merchant_id = ''", req.MerchantID)
+	} else {
+		query = query.Where("merchant_id = ?", req.MerchantID)
+	}
+	if req.Source != "" {
+		query = query.Where("source = ?", string(req.Source))
+	}
+	if req.Address != "" {
+		query = query.Where("address = ?", req.Address)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count blocklist entries: %w", err)
+	}
+
+	var models []BlocklistEntryModel
+	if err := query.Order("created_at DESC").Limit(req.Limit).Offset(req.Offset).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list blocklist entries: %w", err)
+	}
+
+	entries, err := r.toDomainList(models)
+	if err != nil {
+		return nil, err
+	}
+
+	return &compliance.ListBlocklistResponse{
+		Entries: entries,
+		Total:   int(total),
+		Limit:   req.Limit,
+		Offset:  req.Offset,
+	}, nil
+}
+
+// FindAll retrieves every blocklist entry.
+func (r *BlocklistRepository) FindAll(ctx context.Context) ([]*compliance.BlocklistEntry, error) {
+	var models []BlocklistEntryModel
+	if err := r.db.WithContext(ctx).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to load blocklist entries: %w", err)
+	}
+	return r.toDomainList(models)
+}
+
+// ReplaceSource atomically replaces all global entries of a source with the given entries.
+func (r *BlocklistRepository) ReplaceSource(
+	ctx context.Context,
+	source compliance.BlocklistSource,
+	entries []*compliance.BlocklistEntry,
+) error {
+	models := make([]*BlocklistEntryModel, len(entries))
+	for i, entry := range entries {
+		models[i] = r.toModel(entry)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("merchant_id = '' AND source = ?", string(source)).
+			Delete(&BlocklistEntryModel{}).Error; err != nil {
+			return fmt.Errorf("failed to clear %s entries: %w", source, err)
+		}
+		if len(models) == 0 {
+			return nil
+		}
+		if err := tx.CreateInBatches(models, blocklistBatchSize).Error; err != nil {
+			return fmt.Errorf("failed to insert %s entries: %w", source, err)
+		}
+		return nil
+	})
+}
+
+// toModel converts a domain blocklist entry to a database model.
+func (r *BlocklistRepository) toModel(entry *compliance.BlocklistEntry) *BlocklistEntryModel {
+	return &BlocklistEntryModel{
+		ID:         entry.ID(),
+		MerchantID: entry.MerchantID(),
+		Source:     string(entry.Source()),
+		Address:    entry.Address(),
+		Network:    entry.Network(),
+		Reason:     entry.Reason(),
+		Reference:  entry.Reference(),
+		CreatedBy:  entry.CreatedBy(),
+		CreatedAt:  entry.CreatedAt(),
+	}
+}
+
+// toDomain converts a database model to a domain blocklist entry.
+func (r *BlocklistRepository) toDomain(model *BlocklistEntryModel) (*compliance.BlocklistEntry, error) {
+	return compliance.RestoreBlocklistEntry(
+		model.ID,
+		model.MerchantID,
+		model.Address,
+		model.Network,
+		compliance.BlocklistSource(model.Source),
+		model.Reason,
+		model.Reference,
+		model.CreatedBy,
+		model.CreatedAt,
+	)
+}
+
+// toDomainList converts database models to domain blocklist entries.
+func (r *BlocklistRepository) toDomainList(models []BlocklistEntryModel) ([]*compliance.BlocklistEntry, error) {
+	entries := make([]*compliance.BlocklistEntry, len(models))
+	for i := range models {
+		entry, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert blocklist entry model to domain: %w", err)
+		}
+		entries[i] = entry
+	}
+	return entries, nil
+}
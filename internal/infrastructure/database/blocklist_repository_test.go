@@ -0,0 +1,124 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/compliance"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubSanctionsList serves a fixed sanctions list.
+type stubSanctionsList struct {
+	addresses []compliance.SanctionedAddress
+}
+
+func (s *stubSanctionsList) Name() string { return "ofac_sdn" }
+
+func (s *stubSanctionsList) Fetch(_ context.Context) ([]compliance.SanctionedAddress, error) {
+	return s.addresses, nil
+}
+
+func TestBlocklistRepository_ReplaceSourceAndList(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewBlocklistRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	custom, err := compliance.NewBlocklistEntry(
+		"entry-1", "merchant-1", "TCustom", "tron", compliance.BlocklistSourceCustom, "fraud", "", "user-1",
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, custom))
+
+	first, err := compliance.NewBlocklistEntry("entry-2", "", "0xold", "eth", compliance.BlocklistSourceOFAC, "", "1", "")
+	require.NoError(t, err)
+	require.NoError(t, repo.ReplaceSource(ctx, compliance.BlocklistSourceOFAC, []*compliance.BlocklistEntry{first}))
+
+	second, err := compliance.NewBlocklistEntry("entry-3", "", "0xnew", "eth", compliance.BlocklistSourceOFAC, "", "2", "")
+	require.NoError(t, err)
+	require.NoError(t, repo.ReplaceSource(ctx, compliance.BlocklistSourceOFAC, []*compliance.BlocklistEntry{second}))
+
+	all, err := repo.FindAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 2, "replacing a source keeps custom entries and drops stale global ones")
+
+	own, err := repo.List(ctx, &compliance.ListBlocklistRequest{MerchantID: "merchant-1", Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, own.Total)
+	assert.Equal(t, "entry-1", own.Entries[0].ID())
+
+	withGlobal, err := repo.List(ctx, &compliance.ListBlocklistRequest{
+		MerchantID: "merchant-2", IncludeGlobal: true, Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, withGlobal.Total)
+	assert.Equal(t, "0xnew", withGlobal.Entries[0].Address())
+
+	require.NoError(t, repo.Delete(ctx, "entry-1"))
+	require.ErrorIs(t, repo.Delete(ctx, "entry-1"), compliance.ErrBlocklistEntryNotFound)
+	_, err = repo.FindByID(ctx, "entry-1")
+	require.ErrorIs(t, err, compliance.ErrBlocklistEntryNotFound)
+}
+
+func TestBlocklistService_CheckAndHotReload(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewBlocklistRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	sanctions := &stubSanctionsList{addresses: []compliance.SanctionedAddress{
+		{Address: "0xDEADbeef", Network: "eth", Reference: "12345", Name: "Example Sanctioned Party"},
+	}}
+	service := compliance.NewBlocklistService(repo, sanctions, zap.NewNop())
+
+	count, err := service.SyncSanctions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	entry, err := service.Check(ctx, "merchant-1", "0xdeadBEEF")
+	require.NoError(t, err)
+	require.NotNil(t, entry, "EVM addresses match case-insensitively")
+	assert.Equal(t, compliance.BlocklistSourceOFAC, entry.Source())
+
+	added, err := service.AddEntry(ctx, &compliance.AddBlocklistEntryRequest{
+		MerchantID: "merchant-1", Address: "TCustom", Reason: "chargeback fraud", CreatedBy: "user-1",
+	})
+	require.NoError(t, err)
+
+	_, err = service.AddEntry(ctx, &compliance.AddBlocklistEntryRequest{MerchantID: "merchant-1", Address: "TCustom"})
+	require.ErrorIs(t, err, compliance.ErrBlocklistEntryExists)
+
+	entry, err = service.Check(ctx, "merchant-2", "TCustom")
+	require.NoError(t, err)
+	assert.Nil(t, entry, "custom entries only apply to their merchant")
+
+	// Another instance picks up the entry on its next reload
+	replica := compliance.NewBlocklistService(repo, nil, zap.NewNop())
+	require.NoError(t, replica.Reload(ctx))
+	entry, err = replica.Check(ctx, "merchant-1", "TCustom")
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+
+	_, err = service.RemoveEntry(ctx, &compliance.RemoveBlocklistEntryRequest{
+		MerchantID: "merchant-2", EntryID: added.ID(),
+	})
+	require.ErrorIs(t, err, compliance.ErrBlocklistEntryNotFound)
+
+	_, err = service.RemoveEntry(ctx, &compliance.RemoveBlocklistEntryRequest{
+		MerchantID: "merchant-1", EntryID: added.ID(),
+	})
+	require.NoError(t, err)
+	entry, err = service.Check(ctx, "merchant-1", "TCustom")
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+
+	require.NoError(t, replica.Reload(ctx))
+	entry, err = replica.Check(ctx, "merchant-1", "TCustom")
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+
+	_, err = replica.SyncSanctions(ctx)
+	require.ErrorIs(t, err, compliance.ErrSanctionsListDisabled)
+}
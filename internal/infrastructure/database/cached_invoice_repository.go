@@ -0,0 +1,124 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/cache"
+	"expvar"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// invoiceCacheMetrics publishes invoice cache counters under "invoice_cache" on /debug/vars.
+var invoiceCacheMetrics = expvar.NewMap("invoice_cache")
+
+// CachedInvoiceRepository is a cache-aside layer in front of an invoice repository for FindByID,
+// which the checkout page and status polling call on every request.
+// Entries are invalidated by writes through the repository and by invoice events published on the
+// event bus, so changes made elsewhere in the process are seen immediately; the TTL bounds staleness
+// for changes made by other instances.
+type CachedInvoiceRepository struct {
+	invoice.Repository
+
+	cache  *cache.TTLCache[string, InvoiceModel]
+	mapper *InvoiceMapper
+	logger *zap.Logger
+}
+
+// NewCachedInvoiceRepository wraps an invoice repository with a cache of ttl and at most maxEntries invoices.
+func NewCachedInvoiceRepository(
+	repository invoice.Repository,
+	ttl time.Duration,
+	maxEntries int,
+	logger *zap.Logger,
+) *CachedInvoiceRepository {
+	return &CachedInvoiceRepository{
+		Repository: repository,
+		cache:      cache.NewTTLCache[string, InvoiceModel](ttl, maxEntries),
+		mapper:     NewInvoiceMapper(),
+		logger:     logger,
+	}
+}
+
+// FindByID retrieves an invoice by its ID, from the cache when possible.
+// Every call returns a fresh aggregate, so callers may modify it without affecting the cache.
+func (r *CachedInvoiceRepository) FindByID(ctx context.Context, id string) (*invoice.Invoice, error) {
+	if model, ok := r.cache.Get(id); ok {
+		merchantID, scoped := shared.MerchantIDFromContext(ctx)
+		if !scoped || merchantID == model.MerchantID {
+			invoiceCacheMetrics.Add("hits", 1)
+			return r.mapper.ToDomain(&model)
+		}
+		// Let the repository reject and report the cross-tenant read
+		return r.Repository.FindByID(ctx, id)
+	}
+	invoiceCacheMetrics.Add("misses", 1)
+
+	generation := r.cache.Generation()
+	// Fill the cache from the primary; a lagging replica could return a copy older than the last invalidation
+	inv, err := r.Repository.FindByID(shared.WithoutReplicaReads(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.SetSince(id, *r.mapper.ToModel(inv), generation)
+	return inv, nil
+}
+
+// Save persists an invoice and drops any cached copy.
+func (r *CachedInvoiceRepository) Save(ctx context.Context, inv *invoice.Invoice) error {
+	if inv != nil {
+		defer r.Invalidate(inv.ID())
+	}
+	return r.Repository.Save(ctx, inv)
+}
+
+// Update updates an invoice and drops any cached copy.
+func (r *CachedInvoiceRepository) Update(ctx context.Context, inv *invoice.Invoice) error {
+	if inv != nil {
+		defer r.Invalidate(inv.ID())
+	}
+	return r.Repository.Update(ctx, inv)
+}
+
+// Delete removes an invoice and drops any cached copy.
+func (r *CachedInvoiceRepository) Delete(ctx context.Context, id string) error {
+	defer r.Invalidate(id)
+	return r.Repository.Delete(ctx, id)
+}
+
+// Invalidate drops the cached copy of an invoice.
+func (r *CachedInvoiceRepository) Invalidate(id string) {
+	invoiceCacheMetrics.Add("invalidations", 1)
+	r.cache.Invalidate(id)
+}
+
+// Stats returns the cache counters.
+func (r *CachedInvoiceRepository) Stats() cache.Stats {
+	return r.cache.Stats()
+}
+
+// EventTypes returns the invoice events that invalidate a cached invoice.
+func (r *CachedInvoiceRepository) EventTypes() []string {
+	return []string{
+		shared.EventTypeInvoiceStatusChanged,
+		shared.EventTypeInvoicePaid,
+		shared.EventTypeInvoiceExpired,
+		shared.EventTypeInvoiceCancelled,
+		shared.EventTypeInvoiceRefunded,
+		shared.EventTypeInvoiceCouponApplied,
+		shared.EventTypeInvoiceRequoted,
+		shared.EventTypeInvoiceExtended,
+		shared.EventTypeInvoiceReversal,
+	}
+}
+
+// HandleEvent invalidates the invoice an event was published for.
+func (r *CachedInvoiceRepository) HandleEvent(_ context.Context, event *shared.BaseDomainEvent) error {
+	r.logger.Debug("Invalidating cached invoice",
+		zap.String("invoice_id", event.AggregateID),
+		zap.String("event_type", event.EventType))
+	r.Invalidate(event.AggregateID)
+	return nil
+}
@@ -0,0 +1,115 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/internal/infrastructure/events"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestCachedInvoiceRepository(t *testing.T) {
+	setup := func(t *testing.T) (*gorm.DB, *database.CachedInvoiceRepository, *invoice.Invoice) {
+		db := setupTestDB(t)
+		repo := database.NewCachedInvoiceRepository(
+			database.NewInvoiceRepository(db, zap.NewNop()), time.Minute, 100, zap.NewNop())
+		inv := createTestInvoice(t)
+		require.NoError(t, repo.Save(context.Background(), inv))
+		return db, repo, inv
+	}
+
+	// removeRow deletes the invoice behind the cache's back, so only a cache hit can still find it.
+	removeRow := func(t *testing.T, db *gorm.DB, id string) {
+		require.NoError(t, db.Unscoped().Delete(&database.InvoiceModel{}, "id = ?", id).Error)
+	}
+
+	t.Run("Serves_Repeated_Reads_From_Cache", func(t *testing.T) {
+		db, repo, inv := setup(t)
+		ctx := context.Background()
+
+		first, err := repo.FindByID(ctx, inv.ID())
+		require.NoError(t, err)
+		removeRow(t, db, inv.ID())
+
+		second, err := repo.FindByID(ctx, inv.ID())
+		require.NoError(t, err)
+		require.Equal(t, first.ID(), second.ID())
+		require.Equal(t, first.Status(), second.Status())
+		require.NotSame(t, first, second)
+
+		stats := repo.Stats()
+		require.Equal(t, uint64(1), stats.Hits)
+		require.Equal(t, uint64(1), stats.Misses)
+	})
+
+	t.Run("Callers_Cannot_Modify_Cached_Copy", func(t *testing.T) {
+		_, repo, inv := setup(t)
+		ctx := context.Background()
+
+		loaded, err := repo.FindByID(ctx, inv.ID())
+		require.NoError(t, err)
+		loaded.SetStatus(invoice.StatusPending)
+
+		reloaded, err := repo.FindByID(ctx, inv.ID())
+		require.NoError(t, err)
+		require.Equal(t, invoice.StatusCreated, reloaded.Status())
+	})
+
+	t.Run("Update_Invalidates", func(t *testing.T) {
+		_, repo, inv := setup(t)
+		ctx := context.Background()
+
+		loaded, err := repo.FindByID(ctx, inv.ID())
+		require.NoError(t, err)
+		loaded.SetStatus(invoice.StatusPending)
+		require.NoError(t, repo.Update(ctx, loaded))
+
+		reloaded, err := repo.FindByID(ctx, inv.ID())
+		require.NoError(t, err)
+		require.Equal(t, invoice.StatusPending, reloaded.Status())
+	})
+
+	t.Run("Status_Events_Invalidate", func(t *testing.T) {
+		db, repo, inv := setup(t)
+		ctx := context.Background()
+		bus := events.NewMockEventBus(events.NewMockEventStore())
+		bus.RegisterHandler(repo)
+
+		_, err := repo.FindByID(ctx, inv.ID())
+		require.NoError(t, err)
+		require.NoError(t, db.Model(&database.InvoiceModel{}).Where("id = ?", inv.ID()).
+			Update("status", invoice.StatusCancelled.String()).Error)
+
+		cached, err := repo.FindByID(ctx, inv.ID())
+		require.NoError(t, err)
+		require.Equal(t, invoice.StatusCreated, cached.Status())
+
+		event := shared.CreateDomainEvent(shared.EventTypeInvoiceCancelled, inv.ID(), "Invoice", nil, nil)
+		require.NoError(t, bus.PublishEvent(ctx, event))
+
+		reloaded, err := repo.FindByID(ctx, inv.ID())
+		require.NoError(t, err)
+		require.Equal(t, invoice.StatusCancelled, reloaded.Status())
+		require.Equal(t, uint64(2), repo.Stats().Invalidations) // one from Save, one from the event
+	})
+
+	t.Run("Cached_Invoice_Stays_Tenant_Scoped", func(t *testing.T) {
+		_, repo, inv := setup(t)
+
+		_, err := repo.FindByID(context.Background(), inv.ID())
+		require.NoError(t, err)
+
+		_, err = repo.FindByID(shared.WithMerchantID(context.Background(), "other-merchant"), inv.ID())
+		require.ErrorIs(t, err, shared.ErrNotFound)
+
+		owned, err := repo.FindByID(shared.WithMerchantID(context.Background(), inv.MerchantID()), inv.ID())
+		require.NoError(t, err)
+		require.Equal(t, inv.ID(), owned.ID())
+	})
+}
@@ -0,0 +1,206 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/checkoutanalytics"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// checkoutDayFormat is the format of the days checkout views are counted by.
+const checkoutDayFormat = "2006-01-02"
+
+// CheckoutAnalyticsRepository implements the checkoutanalytics.Repository interface using GORM.
+type CheckoutAnalyticsRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewCheckoutAnalyticsRepository creates a new checkout analytics repository.
+func NewCheckoutAnalyticsRepository(db *gorm.DB, logger *zap.Logger) checkoutanalytics.Repository {
+	return &CheckoutAnalyticsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// RecordView starts the funnel of an invoice or counts another view of it, returning whether it was the first
+// view.
+func (r *CheckoutAnalyticsRepository) RecordView(
+	ctx context.Context,
+	invoiceID, merchantID string,
+	at time.Time,
+) (bool, error) {
+	model := &CheckoutFunnelModel{
+		InvoiceID:     invoiceID,
+		MerchantID:    merchantID,
+		Views:         1,
+		FirstViewedAt: at,
+	}
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(model)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to start checkout funnel: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	if err := r.db.WithContext(ctx).Model(&CheckoutFunnelModel{}).
+		Where("invoice_id = ?", invoiceID).
+		UpdateColumn("views", gorm.Expr("views + 1")).Error; err != nil {
+		return false, fmt.Errorf("failed to count checkout view: %w", err)
+	}
+	return false, nil
+}
+
+// CountView adds a viewed checkout to the merchant's counts of the day for each dimension's value, in one
+// statement per dimension so that concurrent views are all counted.
+func (r *CheckoutAnalyticsRepository) CountView(
+	ctx context.Context,
+	merchantID string,
+	day time.Time,
+	values map[checkoutanalytics.Dimension]string,
+) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for dimension, value := range values {
+			model := &CheckoutViewCountModel{
+				MerchantID: merchantID,
+				Day:        day.UTC().Format(checkoutDayFormat),
+				Dimension:  dimension.String(),
+				Value:      value,
+				Views:      1,
+			}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "merchant_id"}, {Name: "day"}, {Name: "dimension"}, {Name: "value"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"views": gorm.Expr("checkout_view_counts.views + 1"),
+				}),
+			}).Create(model).Error; err != nil {
+				return fmt.Errorf("failed to count checkout view by %s: %w", dimension, err)
+			}
+		}
+		return nil
+	})
+}
+
+// FindByInvoice retrieves the funnel of an invoice.
+func (r *CheckoutAnalyticsRepository) FindByInvoice(
+	ctx context.Context,
+	invoiceID string,
+) (*checkoutanalytics.Funnel, error) {
+	var model CheckoutFunnelModel
+	if err := r.db.WithContext(ctx).Scopes(merchantScope(ctx)).
+		Where("invoice_id = ?", invoiceID).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			reportCrossTenantAccess(ctx, r.db, r.logger, &CheckoutFunnelModel{}, "checkout funnel", invoiceID)
+			return nil, checkoutanalytics.ErrFunnelNotFound
+		}
+		return nil, fmt.Errorf("failed to find checkout funnel: %w", err)
+	}
+
+	return &checkoutanalytics.Funnel{
+		InvoiceID:       model.InvoiceID,
+		MerchantID:      model.MerchantID,
+		Views:           model.Views,
+		FirstViewedAt:   model.FirstViewedAt,
+		AddressCopiedAt: model.AddressCopiedAt,
+		PaidAt:          model.PaidAt,
+	}, nil
+}
+
+// UpdateSteps stores the steps a funnel reached. Steps already stored are kept, so that concurrent updates
+// keep the first time each step was reached.
+func (r *CheckoutAnalyticsRepository) UpdateSteps(ctx context.Context, funnel *checkoutanalytics.Funnel) error {
+	db := r.db.WithContext(ctx)
+	if funnel.AddressCopiedAt != nil {
+		if err := db.Model(&CheckoutFunnelModel{}).
+			Where("invoice_id = ? AND address_copied_at IS NULL", funnel.InvoiceID).
+			UpdateColumn("address_copied_at", funnel.AddressCopiedAt).Error; err != nil {
+			return fmt.Errorf("failed to update checkout funnel: %w", err)
+		}
+	}
+	if funnel.PaidAt != nil {
+		seconds := int64(math.Round(funnel.TimeToPayment().Seconds()))
+		if err := db.Model(&CheckoutFunnelModel{}).
+			Where("invoice_id = ? AND paid_at IS NULL", funnel.InvoiceID).
+			UpdateColumns(map[string]interface{}{
+				"paid_at":                 funnel.PaidAt,
+				"time_to_payment_seconds": seconds,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to update checkout funnel: %w", err)
+		}
+	}
+	return nil
+}
+
+// Summarize summarizes the funnels of a merchant's invoices first viewed in [from, to). The view counts are
+// kept by day, so they cover the days the period starts and ends on.
+func (r *CheckoutAnalyticsRepository) Summarize(
+	ctx context.Context,
+	merchantID string,
+	from, to time.Time,
+) (*checkoutanalytics.Summary, error) {
+	funnels := r.db.WithContext(ctx).Model(&CheckoutFunnelModel{}).Where("merchant_id = ?", merchantID)
+	if !from.IsZero() {
+		funnels = funnels.Where("first_viewed_at >= ?", from)
+	}
+	if !to.IsZero() {
+		funnels = funnels.Where("first_viewed_at < ?", to)
+	}
+
+	var totals struct {
+		Viewed        int
+		AddressCopied int
+		Paid          int
+		AverageTime   *float64
+	}
+	if err := funnels.Select("COUNT(*) AS viewed, COUNT(address_copied_at) AS address_copied, " +
+		"COUNT(paid_at) AS paid, AVG(time_to_payment_seconds) AS average_time").
+		Scan(&totals).Error; err != nil {
+		return nil, fmt.Errorf("failed to summarize checkout funnels: %w", err)
+	}
+
+	counts := r.db.WithContext(ctx).Model(&CheckoutViewCountModel{}).Where("merchant_id = ?", merchantID)
+	if !from.IsZero() {
+		counts = counts.Where("day >= ?", from.UTC().Format(checkoutDayFormat))
+	}
+	if !to.IsZero() {
+		// The day of an exclusive end at midnight is not part of the period
+		counts = counts.Where("day <= ?", to.UTC().Add(-time.Nanosecond).Format(checkoutDayFormat))
+	}
+	var rows []struct {
+		Dimension string
+		Value     string
+		Views     int
+	}
+	if err := counts.Select("dimension, value, SUM(views) AS views").
+		Group("dimension, value").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to sum checkout view counts: %w", err)
+	}
+
+	summary := &checkoutanalytics.Summary{
+		Viewed:        totals.Viewed,
+		AddressCopied: totals.AddressCopied,
+		Paid:          totals.Paid,
+		Breakdown:     map[checkoutanalytics.Dimension]map[string]int{},
+	}
+	if totals.AverageTime != nil {
+		summary.AverageTimeToPayment = time.Duration(*totals.AverageTime * float64(time.Second)).Round(time.Second)
+	}
+	for _, row := range rows {
+		dimension := checkoutanalytics.Dimension(row.Dimension)
+		if summary.Breakdown[dimension] == nil {
+			summary.Breakdown[dimension] = map[string]int{}
+		}
+		summary.Breakdown[dimension][row.Value] = row.Views
+	}
+	return summary, nil
+}
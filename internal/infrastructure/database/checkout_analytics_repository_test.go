@@ -0,0 +1,73 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/checkoutanalytics"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCheckoutAnalyticsRepository(t *testing.T) {
+	repo := database.NewCheckoutAnalyticsRepository(setupTestDB(t), zap.NewNop())
+	ctx := context.Background()
+	merchantID := uuid.New().String()
+	firstInvoice, secondInvoice := uuid.New().String(), uuid.New().String()
+	viewedAt := time.Date(2026, 10, 16, 23, 50, 0, 0, time.UTC)
+
+	first, err := repo.RecordView(ctx, firstInvoice, merchantID, viewedAt)
+	require.NoError(t, err)
+	assert.True(t, first)
+	first, err = repo.RecordView(ctx, firstInvoice, merchantID, viewedAt.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, first, "a reload is not a first view")
+	_, err = repo.RecordView(ctx, secondInvoice, merchantID, viewedAt.Add(20*time.Minute))
+	require.NoError(t, err)
+
+	require.NoError(t, repo.CountView(ctx, merchantID, viewedAt, map[checkoutanalytics.Dimension]string{
+		checkoutanalytics.DimensionBrowser: "firefox", checkoutanalytics.DimensionCountry: "DE",
+	}))
+	require.NoError(t, repo.CountView(ctx, merchantID, viewedAt.Add(20*time.Minute),
+		map[checkoutanalytics.Dimension]string{
+			checkoutanalytics.DimensionBrowser: "firefox", checkoutanalytics.DimensionCountry: "FR",
+		}))
+
+	funnel, err := repo.FindByInvoice(ctx, firstInvoice)
+	require.NoError(t, err)
+	assert.Equal(t, 2, funnel.Views)
+	assert.True(t, viewedAt.Equal(funnel.FirstViewedAt))
+
+	require.True(t, funnel.Reach(checkoutanalytics.StepAddressCopied, viewedAt.Add(2*time.Minute)))
+	require.True(t, funnel.Reach(checkoutanalytics.StepPaid, viewedAt.Add(10*time.Minute)))
+	require.NoError(t, repo.UpdateSteps(ctx, funnel))
+
+	stored, err := repo.FindByInvoice(ctx, firstInvoice)
+	require.NoError(t, err)
+	require.NotNil(t, stored.PaidAt)
+	assert.Equal(t, 10*time.Minute, *stored.TimeToPayment())
+
+	summary, err := repo.Summarize(ctx, merchantID, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.Viewed)
+	assert.Equal(t, 1, summary.AddressCopied)
+	assert.Equal(t, 1, summary.Paid)
+	assert.Equal(t, 10*time.Minute, summary.AverageTimeToPayment)
+	assert.Equal(t, map[string]int{"firefox": 2}, summary.Breakdown[checkoutanalytics.DimensionBrowser])
+	assert.Equal(t, map[string]int{"DE": 1, "FR": 1}, summary.Breakdown[checkoutanalytics.DimensionCountry])
+
+	// The second checkout was viewed on the next day
+	nextDay := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	summary, err = repo.Summarize(ctx, merchantID, nextDay, nextDay.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Viewed)
+	assert.Zero(t, summary.Paid)
+	assert.Equal(t, map[string]int{"FR": 1}, summary.Breakdown[checkoutanalytics.DimensionCountry])
+
+	_, err = repo.FindByInvoice(ctx, uuid.New().String())
+	require.ErrorIs(t, err, checkoutanalytics.ErrFunnelNotFound)
+}
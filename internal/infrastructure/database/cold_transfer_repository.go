@@ -0,0 +1,169 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/treasury"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ColdTransferRepository implements the treasury.TransferRepository interface using GORM.
+type ColdTransferRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewColdTransferRepository creates a new cold storage transfer repository.
+func NewColdTransferRepository(db *gorm.DB, logger *zap.Logger) treasury.TransferRepository {
+	return &ColdTransferRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a cold storage transfer to the database.
+func (r *ColdTransferRepository) Save(ctx context.Context, transfer *treasury.ColdTransfer) error {
+	if err := r.db.WithContext(ctx).Create(r.toModel(transfer)).Error; err != nil {
+		return fmt.Errorf("failed to save cold transfer: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a cold storage transfer by ID.
+func (r *ColdTransferRepository) FindByID(ctx context.Context, id string) (*treasury.ColdTransfer, error) {
+	var model ColdTransferModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, treasury.ErrTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to find cold transfer: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// FindByStatus finds up to limit cold storage transfers with the status, oldest first.
+func (r *ColdTransferRepository) FindByStatus(
+	ctx context.Context,
+	status treasury.TransferStatus,
+	limit int,
+) ([]*treasury.ColdTransfer, error) {
+	var models []ColdTransferModel
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", string(status)).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find cold transfers: %w", err)
+	}
+
+	return r.toDomainList(models)
+}
+
+// List retrieves cold storage transfers matching the filter, newest first.
+func (r *ColdTransferRepository) List(
+	ctx context.Context,
+	req *treasury.ListTransfersRequest,
+) (*treasury.ListTransfersResponse, error) {
+	query := r.db.WithContext(ctx)
+	if req.Status != "" {
+		query = query.Where("status = ?", string(req.Status))
+	}
+
+	var models []ColdTransferModel
+	if err := query.Scopes(keysetPage("created_at", req.Cursor, 0, req.Limit)).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list cold transfers: %w", err)
+	}
+	models, next := nextPage(models, req.Limit, func(model *ColdTransferModel) (time.Time, string) {
+		return model.CreatedAt, model.ID
+	})
+
+	transfers, err := r.toDomainList(models)
+	if err != nil {
+		return nil, err
+	}
+
+	return &treasury.ListTransfersResponse{
+		Transfers:  transfers,
+		Limit:      req.Limit,
+		NextCursor: next,
+	}, nil
+}
+
+// Update updates an existing cold storage transfer.
+func (r *ColdTransferRepository) Update(ctx context.Context, transfer *treasury.ColdTransfer) error {
+	if err := r.db.WithContext(ctx).Save(r.toModel(transfer)).Error; err != nil {
+		return fmt.Errorf("failed to update cold transfer: %w", err)
+	}
+
+	return nil
+}
+
+// toDomainList converts database models to domain cold storage transfers.
+func (r *ColdTransferRepository) toDomainList(models []ColdTransferModel) ([]*treasury.ColdTransfer, error) {
+	transfers := make([]*treasury.ColdTransfer, len(models))
+	for i := range models {
+		transfer, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert cold transfer model to domain: %w", err)
+		}
+		transfers[i] = transfer
+	}
+	return transfers, nil
+}
+
+// toModel converts a domain cold storage transfer to a database model.
+func (r *ColdTransferRepository) toModel(transfer *treasury.ColdTransfer) *ColdTransferModel {
+	return &ColdTransferModel{
+		ID:            transfer.ID(),
+		Network:       transfer.Network().String(),
+		Currency:      transfer.Currency(),
+		ToAddress:     transfer.ToAddress(),
+		Amount:        transfer.Amount().String(),
+		Note:          transfer.Note(),
+		Status:        string(transfer.Status()),
+		RequestedBy:   transfer.RequestedBy(),
+		ReviewedBy:    transfer.ReviewedBy(),
+		ReviewNote:    transfer.ReviewNote(),
+		TxHash:        transfer.TxHash(),
+		FailureReason: transfer.FailureReason(),
+		CreatedAt:     transfer.CreatedAt(),
+		ReviewedAt:    transfer.ReviewedAt(),
+		BroadcastAt:   transfer.BroadcastAt(),
+		ConfirmedAt:   transfer.ConfirmedAt(),
+	}
+}
+
+// toDomain converts a database model to a domain cold storage transfer.
+func (r *ColdTransferRepository) toDomain(model *ColdTransferModel) (*treasury.ColdTransfer, error) {
+	amount, err := decimal.NewFromString(model.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cold transfer amount %q: %w", model.Amount, err)
+	}
+
+	return treasury.RestoreColdTransfer(
+		model.ID,
+		shared.BlockchainNetwork(model.Network),
+		model.Currency,
+		model.ToAddress,
+		amount,
+		model.Note,
+		treasury.TransferStatus(model.Status),
+		model.RequestedBy,
+		model.ReviewedBy,
+		model.ReviewNote,
+		model.TxHash,
+		model.FailureReason,
+		model.CreatedAt,
+		model.ReviewedAt,
+		model.BroadcastAt,
+		model.ConfirmedAt,
+	)
+}
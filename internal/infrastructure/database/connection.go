@@ -2,7 +2,10 @@
 package database
 
 import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
 	"crypto-checkout/pkg/config"
+	"database/sql"
 	"fmt"
 	"strings"
 	"time"
@@ -18,6 +21,8 @@ import (
 type Connection struct {
 	DB     *gorm.DB
 	Logger *zap.Logger
+	// Replicas routes replica reads to the configured read replicas; nil without replicas.
+	Replicas *Replicas
 }
 
 // NewConnection creates a new database connection.
@@ -34,7 +39,7 @@ func NewConnection(cfg config.DatabaseConfig, logger *zap.Logger) (*Connection,
 			db, err = gorm.Open(sqlite.Open(dbPath), &gorm.Config{
 				Logger: gormlogger.Default.LogMode(gormlogger.Silent), // Silent for tests
 				NowFunc: func() time.Time {
-					return time.Now().UTC()
+					return shared.Now().UTC()
 				},
 			})
 		case strings.HasPrefix(cfg.URL, "file::memory:"):
@@ -42,7 +47,7 @@ func NewConnection(cfg config.DatabaseConfig, logger *zap.Logger) (*Connection,
 			db, err = gorm.Open(sqlite.Open(cfg.URL), &gorm.Config{
 				Logger: gormlogger.Default.LogMode(gormlogger.Silent), // Silent for tests
 				NowFunc: func() time.Time {
-					return time.Now().UTC()
+					return shared.Now().UTC()
 				},
 			})
 		default:
@@ -50,7 +55,7 @@ func NewConnection(cfg config.DatabaseConfig, logger *zap.Logger) (*Connection,
 			db, err = gorm.Open(postgres.Open(cfg.URL), &gorm.Config{
 				Logger: gormlogger.Default.LogMode(gormlogger.Info),
 				NowFunc: func() time.Time {
-					return time.Now().UTC()
+					return shared.Now().UTC()
 				},
 			})
 		}
@@ -62,7 +67,7 @@ func NewConnection(cfg config.DatabaseConfig, logger *zap.Logger) (*Connection,
 		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
 			Logger: gormlogger.Default.LogMode(gormlogger.Info),
 			NowFunc: func() time.Time {
-				return time.Now().UTC()
+				return shared.Now().UTC()
 			},
 		})
 	}
@@ -96,34 +101,95 @@ func NewConnection(cfg config.DatabaseConfig, logger *zap.Logger) (*Connection,
 		}
 	} else {
 		// PostgreSQL connection pool settings
-		const (
-			maxIdleConns = 10
-			maxOpenConns = 100
-		)
-		sqlDB.SetMaxIdleConns(maxIdleConns)
-		sqlDB.SetMaxOpenConns(maxOpenConns)
-		sqlDB.SetConnMaxLifetime(time.Hour)
+		configurePool(sqlDB, cfg)
 	}
 
-	return &Connection{DB: db, Logger: logger}, nil
+	if err = AttachQueryTimeout(db, cfg.QueryTimeout); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+
+	conn := &Connection{DB: db, Logger: logger}
+	if len(cfg.ReplicaURLs) > 0 {
+		if conn.Replicas, err = openReplicas(db, cfg, logger); err != nil {
+			_ = sqlDB.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// configurePool applies the configured PostgreSQL pool limits, falling back to the defaults.
+func configurePool(sqlDB *sql.DB, cfg config.DatabaseConfig) {
+	maxOpenConns := cfg.MaxOpenConns
+	if maxOpenConns <= 0 {
+		maxOpenConns = config.DefaultDBMaxOpenConns
+	}
+	maxIdleConns := cfg.MaxIdleConns
+	if maxIdleConns <= 0 {
+		maxIdleConns = config.DefaultDBMaxIdleConns
+	}
+	connMaxLifetime := cfg.ConnMaxLifetime
+	if connMaxLifetime <= 0 {
+		connMaxLifetime = config.DefaultDBConnMaxLifetime
+	}
+
+	sqlDB.SetMaxOpenConns(maxOpenConns)
+	sqlDB.SetMaxIdleConns(maxIdleConns)
+	sqlDB.SetConnMaxLifetime(connMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
+
+// openReplicas connects to the PostgreSQL read replicas and routes replica reads made through primary to them.
+func openReplicas(primary *gorm.DB, cfg config.DatabaseConfig, logger *zap.Logger) (*Replicas, error) {
+	dbs := make([]*sql.DB, 0, len(cfg.ReplicaURLs))
+	closeAll := func() {
+		for _, db := range dbs {
+			_ = db.Close()
+		}
+	}
+
+	for i, url := range cfg.ReplicaURLs {
+		replicaDB, err := gorm.Open(postgres.Open(url), &gorm.Config{
+			Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+		})
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("failed to connect to read replica %d: %w", i+1, err)
+		}
+		sqlDB, err := replicaDB.DB()
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("failed to get underlying sql.DB of read replica %d: %w", i+1, err)
+		}
+		configurePool(sqlDB, cfg)
+		dbs = append(dbs, sqlDB)
+	}
+
+	replicas, err := AttachReplicas(primary, dbs, logger)
+	if err != nil {
+		closeAll()
+		return nil, err
+	}
+	logger.Info("Routing replica reads to read replicas", zap.Int("replicas", len(dbs)))
+	return replicas, nil
 }
 
 // Migrate runs database migrations.
 func (c *Connection) Migrate() error {
 	c.Logger.Info("Starting database migration")
+	// Migrations rewrite whole tables and are not bounded by the query timeout
+	db := c.DB.WithContext(shared.WithoutQueryTimeout(context.Background()))
 
 	// Handle existing data before running AutoMigrate
-	if err := c.migrateExistingData(); err != nil {
+	if err := c.migrateExistingData(db); err != nil {
 		c.Logger.Error("Failed to migrate existing data", zap.Error(err))
 		return fmt.Errorf("failed to migrate existing data: %w", err)
 	}
 
 	// Run GORM AutoMigrate
 	c.Logger.Info("Running GORM AutoMigrate")
-	if err := c.DB.AutoMigrate(
-		&InvoiceModel{},
-		&PaymentModel{},
-	); err != nil {
+	if err := db.AutoMigrate(migrationModels()...); err != nil {
 		c.Logger.Error("Failed to run GORM AutoMigrate", zap.Error(err))
 		return fmt.Errorf("failed to run database migrations: %w", err)
 	}
@@ -132,14 +198,101 @@ func (c *Connection) Migrate() error {
 	return nil
 }
 
+// migrationModels returns the models managed by Migrate.
+func migrationModels() []interface{} {
+	return []interface{}{
+		&InvoiceModel{},
+		&InvoiceMetadataModel{},
+		&PaymentModel{},
+		&PaymentEventModel{},
+		&PaymentSnapshotModel{},
+		&PaymentStatisticsModel{},
+		&PaymentConfirmationModel{},
+		&UserModel{},
+		&InvitationModel{},
+		&AuditEntryModel{},
+		&RefreshTokenModel{},
+		&PaymentScreeningModel{},
+		&BlocklistEntryModel{},
+		&PaymentReviewModel{},
+		&CouponModel{},
+		&TaxRuleModel{},
+		&PaymentLinkModel{},
+		&PaymentLinkInvoiceModel{},
+		&InvoiceTemplateModel{},
+		&AutomationRuleModel{},
+		&PaymentProcessorModel{},
+		&ProcessorCheckoutModel{},
+		&WebhookDeliveryModel{},
+		&SettlementModel{},
+		&SettlementReversalModel{},
+		&PayoutWalletModel{},
+		&PayoutModel{},
+		&TreasuryBalanceModel{},
+		&SweepModel{},
+		&ColdTransferModel{},
+		&FeeSpendModel{},
+		&ApprovalModel{},
+		&DeadLetterModel{},
+		&IncidentModel{},
+		&ArchivedInvoiceModel{},
+		&SagaModel{},
+		&IDSequenceModel{},
+		&InvoiceNumberSequenceModel{},
+		&BlockCursorModel{},
+		&UnattributedDepositModel{},
+		&CustomerModel{},
+		&CustomerInvoiceModel{},
+		&CustomerLoginLinkModel{},
+		&CustomerSessionModel{},
+		&CustomerRefundAddressModel{},
+		&ReconciliationReportModel{},
+		&StatementModel{},
+		&CheckoutSnapshotModel{},
+		&ExperimentModel{},
+		&ExperimentAssignmentModel{},
+		&CheckoutFunnelModel{},
+		&CheckoutViewCountModel{},
+		&GeoBlockModel{},
+		&GeoOverrideModel{},
+	}
+}
+
+// PendingMigrations returns the tables of managed models that do not exist yet.
+func (c *Connection) PendingMigrations(ctx context.Context) []string {
+	migrator := c.DB.WithContext(ctx).Migrator()
+
+	var pending []string
+	for _, model := range migrationModels() {
+		if !migrator.HasTable(model) {
+			if tabler, ok := model.(interface{ TableName() string }); ok {
+				pending = append(pending, tabler.TableName())
+			}
+		}
+	}
+	return pending
+}
+
+// Ping verifies the database connection is alive.
+func (c *Connection) Ping(ctx context.Context) error {
+	sqlDB, err := c.DB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
+
 // migrateExistingData handles migration of existing data before schema changes.
-func (c *Connection) migrateExistingData() error {
+func (c *Connection) migrateExistingData(db *gorm.DB) error {
 	c.Logger.Info("Checking for existing data migration needs")
 
 	// Check if invoices table exists and has data
-	if c.DB.Migrator().HasTable(&InvoiceModel{}) {
+	if db.Migrator().HasTable(&InvoiceModel{}) {
 		var count int64
-		if err := c.DB.Raw("SELECT COUNT(*) FROM invoices").Scan(&count).Error; err != nil {
+		if err := db.Raw("SELECT COUNT(*) FROM invoices").Scan(&count).Error; err != nil {
 			c.Logger.Error("Failed to count existing invoices", zap.Error(err))
 			return fmt.Errorf("failed to count existing invoices: %w", err)
 		}
@@ -154,6 +307,30 @@ func (c *Connection) migrateExistingData() error {
 		c.Logger.Info("Invoices table does not exist yet")
 	}
 
+	// Transaction hashes are unique per network rather than globally
+	if db.Migrator().HasIndex(&PaymentModel{}, "idx_payments_tx_hash") {
+		c.Logger.Info("Replacing the unique index on payment transaction hashes")
+		if err := db.Migrator().DropIndex(&PaymentModel{}, "idx_payments_tx_hash"); err != nil {
+			return fmt.Errorf("failed to drop payment transaction hash index: %w", err)
+		}
+	}
+
+	// Reversed settlements do not count towards the one settlement an invoice may have
+	if db.Migrator().HasIndex(&SettlementModel{}, "idx_settlements_invoice_id") {
+		c.Logger.Info("Replacing the unique index on settlement invoices")
+		if err := db.Migrator().DropIndex(&SettlementModel{}, "idx_settlements_invoice_id"); err != nil {
+			return fmt.Errorf("failed to drop settlement invoice index: %w", err)
+		}
+	}
+
+	// Beneficiaries' shares split from a settlement are settlements of the same invoice for other merchants
+	if db.Migrator().HasIndex(&SettlementModel{}, "idx_active_settlement") {
+		c.Logger.Info("Replacing the unique index on active settlements")
+		if err := db.Migrator().DropIndex(&SettlementModel{}, "idx_active_settlement"); err != nil {
+			return fmt.Errorf("failed to drop active settlement index: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -166,5 +343,8 @@ func (c *Connection) Close() error {
 	if closeErr := sqlDB.Close(); closeErr != nil {
 		return fmt.Errorf("failed to close database connection: %w", closeErr)
 	}
+	if c.Replicas != nil {
+		return c.Replicas.close()
+	}
 	return nil
 }
@@ -0,0 +1,179 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/coupon"
+	"crypto-checkout/internal/domain/invoice"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// CouponRepository implements the coupon.Repository interface using GORM.
+type CouponRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewCouponRepository creates a new coupon repository.
+func NewCouponRepository(db *gorm.DB, logger *zap.Logger) coupon.Repository {
+	return &CouponRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a new coupon to the database.
+func (r *CouponRepository) Save(ctx context.Context, c *coupon.Coupon) error {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&CouponModel{}).
+		Where("merchant_id = ? AND code = ?", c.MerchantID(), c.Code()).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check coupon code: %w", err)
+	}
+	if count > 0 {
+		return coupon.ErrCouponExists
+	}
+
+	if err := r.db.WithContext(ctx).Create(r.toModel(c)).Error; err != nil {
+		return fmt.Errorf("failed to save coupon: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a coupon by ID.
+func (r *CouponRepository) FindByID(ctx context.Context, id string) (*coupon.Coupon, error) {
+	c, err := r.findOne(r.db.WithContext(ctx).Scopes(merchantScope(ctx)).Where("id = ?", id))
+	if errors.Is(err, coupon.ErrCouponNotFound) {
+		reportCrossTenantAccess(ctx, r.db, r.logger, &CouponModel{}, "coupon", id)
+	}
+	return c, err
+}
+
+// FindByCode finds a merchant's coupon by its normalized code.
+func (r *CouponRepository) FindByCode(ctx context.Context, merchantID, code string) (*coupon.Coupon, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("merchant_id = ? AND code = ?", merchantID, code))
+}
+
+// ListByMerchant lists a merchant's coupons, newest first.
+func (r *CouponRepository) ListByMerchant(ctx context.Context, merchantID string) ([]*coupon.Coupon, error) {
+	var models []CouponModel
+	if err := r.db.WithContext(ctx).
+		Where("merchant_id = ?", merchantID).
+		Order("created_at DESC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list coupons: %w", err)
+	}
+
+	coupons := make([]*coupon.Coupon, len(models))
+	for i := range models {
+		c, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert coupon model to domain: %w", err)
+		}
+		coupons[i] = c
+	}
+	return coupons, nil
+}
+
+// Update updates an existing coupon. The redemption count is only changed through
+// IncrementRedemptions and DecrementRedemptions so concurrent redemptions are not lost.
+func (r *CouponRepository) Update(ctx context.Context, c *coupon.Coupon) error {
+	result := r.db.WithContext(ctx).Model(&CouponModel{}).
+		Scopes(merchantScope(ctx)).
+		Where("id = ?", c.ID()).
+		Updates(map[string]interface{}{
+			"active":     c.IsActive(),
+			"expires_at": c.ExpiresAt(),
+			"updated_at": c.UpdatedAt(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update coupon: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		reportCrossTenantAccess(ctx, r.db, r.logger, &CouponModel{}, "coupon", c.ID())
+		return coupon.ErrCouponNotFound
+	}
+	return nil
+}
+
+// IncrementRedemptions atomically records a redemption while the coupon is under its limit.
+func (r *CouponRepository) IncrementRedemptions(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Model(&CouponModel{}).Scopes(merchantScope(ctx)).
+		Where("id = ? AND (max_redemptions = 0 OR redemptions < max_redemptions)", id).
+		UpdateColumn("redemptions", gorm.Expr("redemptions + 1"))
+	if result.Error != nil {
+		return fmt.Errorf("failed to record coupon redemption: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if _, err := r.FindByID(ctx, id); err != nil {
+			return err
+		}
+		return coupon.ErrCouponExhausted
+	}
+	return nil
+}
+
+// DecrementRedemptions gives back a redemption that was not used.
+func (r *CouponRepository) DecrementRedemptions(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Model(&CouponModel{}).Scopes(merchantScope(ctx)).
+		Where("id = ? AND redemptions > 0", id).
+		UpdateColumn("redemptions", gorm.Expr("redemptions - 1"))
+	if result.Error != nil {
+		return fmt.Errorf("failed to release coupon redemption: %w", result.Error)
+	}
+	return nil
+}
+
+// findOne finds a single coupon matching the query.
+func (r *CouponRepository) findOne(query *gorm.DB) (*coupon.Coupon, error) {
+	var model CouponModel
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, coupon.ErrCouponNotFound
+		}
+		return nil, fmt.Errorf("failed to find coupon: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// toModel converts a domain coupon to a database model.
+func (r *CouponRepository) toModel(c *coupon.Coupon) *CouponModel {
+	return &CouponModel{
+		ID:             c.ID(),
+		MerchantID:     c.MerchantID(),
+		Code:           c.Code(),
+		DiscountType:   c.Discount().Type().String(),
+		DiscountValue:  c.Discount().Value().String(),
+		MaxRedemptions: c.MaxRedemptions(),
+		Redemptions:    c.Redemptions(),
+		ExpiresAt:      c.ExpiresAt(),
+		Active:         c.IsActive(),
+		CreatedAt:      c.CreatedAt(),
+		UpdatedAt:      c.UpdatedAt(),
+	}
+}
+
+// toDomain converts a database model to a domain coupon.
+func (r *CouponRepository) toDomain(model *CouponModel) (*coupon.Coupon, error) {
+	discount, err := invoice.NewDiscount(invoice.DiscountType(model.DiscountType), model.DiscountValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore coupon discount: %w", err)
+	}
+
+	return coupon.RestoreCoupon(
+		model.ID,
+		model.MerchantID,
+		model.Code,
+		discount,
+		model.MaxRedemptions,
+		model.Redemptions,
+		model.ExpiresAt,
+		model.Active,
+		model.CreatedAt,
+		model.UpdatedAt,
+	)
+}
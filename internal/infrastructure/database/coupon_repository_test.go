@@ -0,0 +1,79 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/coupon"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCouponRepository_Redemptions(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewCouponRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	discount, err := invoice.NewDiscount(invoice.DiscountTypePercentage, "10")
+	require.NoError(t, err)
+
+	limited, err := coupon.NewCoupon("coupon-1", "merchant-1", "welcome10", discount, 2, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, limited))
+
+	t.Run("duplicate code is rejected per merchant", func(t *testing.T) {
+		duplicate, err := coupon.NewCoupon("coupon-2", "merchant-1", "WELCOME10", discount, 0, nil)
+		require.NoError(t, err)
+		require.ErrorIs(t, repo.Save(ctx, duplicate), coupon.ErrCouponExists)
+
+		other, err := coupon.NewCoupon("coupon-3", "merchant-2", "WELCOME10", discount, 0, nil)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, other))
+	})
+
+	t.Run("find by code", func(t *testing.T) {
+		found, err := repo.FindByCode(ctx, "merchant-1", "WELCOME10")
+		require.NoError(t, err)
+		assert.Equal(t, "coupon-1", found.ID())
+		assert.True(t, found.Discount().Equals(discount))
+
+		_, err = repo.FindByCode(ctx, "merchant-3", "WELCOME10")
+		require.ErrorIs(t, err, coupon.ErrCouponNotFound)
+	})
+
+	t.Run("increment stops at the redemption limit", func(t *testing.T) {
+		require.NoError(t, repo.IncrementRedemptions(ctx, "coupon-1"))
+		require.NoError(t, repo.IncrementRedemptions(ctx, "coupon-1"))
+		require.ErrorIs(t, repo.IncrementRedemptions(ctx, "coupon-1"), coupon.ErrCouponExhausted)
+
+		require.NoError(t, repo.DecrementRedemptions(ctx, "coupon-1"))
+		found, err := repo.FindByID(ctx, "coupon-1")
+		require.NoError(t, err)
+		assert.Equal(t, 1, found.Redemptions())
+
+		require.ErrorIs(t, repo.IncrementRedemptions(ctx, "missing"), coupon.ErrCouponNotFound)
+	})
+
+	t.Run("update keeps the redemption count", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, "coupon-1")
+		require.NoError(t, err)
+		require.NoError(t, repo.IncrementRedemptions(ctx, "coupon-1"))
+
+		found.Deactivate()
+		require.NoError(t, repo.Update(ctx, found))
+
+		updated, err := repo.FindByID(ctx, "coupon-1")
+		require.NoError(t, err)
+		assert.False(t, updated.IsActive())
+		assert.Equal(t, 2, updated.Redemptions())
+	})
+
+	t.Run("list by merchant", func(t *testing.T) {
+		coupons, err := repo.ListByMerchant(ctx, "merchant-1")
+		require.NoError(t, err)
+		require.Len(t, coupons, 1)
+	})
+}
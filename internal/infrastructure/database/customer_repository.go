@@ -0,0 +1,361 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/customer"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CustomerRepository implements the customer.Repository interface using GORM.
+type CustomerRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewCustomerRepository creates a new customer account repository.
+func NewCustomerRepository(db *gorm.DB, logger *zap.Logger) customer.Repository {
+	return &CustomerRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a new customer to the database.
+func (r *CustomerRepository) Save(ctx context.Context, c *customer.Customer) error {
+	if err := r.db.WithContext(ctx).Create(r.toModel(c)).Error; err != nil {
+		return fmt.Errorf("failed to save customer: %w", err)
+	}
+	return nil
+}
+
+// Update updates an existing customer.
+func (r *CustomerRepository) Update(ctx context.Context, c *customer.Customer) error {
+	result := r.db.WithContext(ctx).Model(&CustomerModel{}).
+		Where("id = ?", c.ID()).
+		Updates(map[string]interface{}{"last_login_at": c.LastLoginAt()})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update customer: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return customer.ErrCustomerNotFound
+	}
+	return nil
+}
+
+// FindByID finds a customer by ID.
+func (r *CustomerRepository) FindByID(ctx context.Context, id string) (*customer.Customer, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+// FindByEmail finds a customer by their normalized email address.
+func (r *CustomerRepository) FindByEmail(ctx context.Context, email string) (*customer.Customer, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("email = ?", email))
+}
+
+// ClaimInvoice adds an invoice to a customer's payment history.
+func (r *CustomerRepository) ClaimInvoice(ctx context.Context, customerID, invoiceID string) error {
+	model := &CustomerInvoiceModel{
+		InvoiceID:  invoiceID,
+		CustomerID: customerID,
+		ClaimedAt:  shared.Now().UTC(),
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to claim invoice: %w", err)
+	}
+
+	var owner CustomerInvoiceModel
+	if err := r.db.WithContext(ctx).Where("invoice_id = ?", invoiceID).First(&owner).Error; err != nil {
+		return fmt.Errorf("failed to find invoice claim: %w", err)
+	}
+	if owner.CustomerID != customerID {
+		return customer.ErrInvoiceClaimed
+	}
+	return nil
+}
+
+// IsInvoiceClaimed reports whether the invoice is in the customer's payment history.
+func (r *CustomerRepository) IsInvoiceClaimed(ctx context.Context, customerID, invoiceID string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&CustomerInvoiceModel{}).
+		Where("invoice_id = ? AND customer_id = ?", invoiceID, customerID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check invoice claim: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ListClaimedInvoiceIDs lists the invoices in a customer's payment history, most recently claimed first.
+func (r *CustomerRepository) ListClaimedInvoiceIDs(
+	ctx context.Context,
+	customerID string,
+	limit, offset int,
+) ([]string, error) {
+	var ids []string
+	if err := r.db.WithContext(ctx).Model(&CustomerInvoiceModel{}).
+		Where("customer_id = ?", customerID).
+		Order("claimed_at DESC").
+		Order("invoice_id DESC").
+		Limit(limit).
+		Offset(offset).
+		Pluck("invoice_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to list claimed invoices: %w", err)
+	}
+	return ids, nil
+}
+
+// findOne finds the customer matching a query.
+func (r *CustomerRepository) findOne(query *gorm.DB) (*customer.Customer, error) {
+	var model CustomerModel
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customer.ErrCustomerNotFound
+		}
+		return nil, fmt.Errorf("failed to find customer: %w", err)
+	}
+	return customer.RestoreCustomer(model.ID, model.Email, model.CreatedAt, model.LastLoginAt)
+}
+
+// toModel converts a domain customer to a database model.
+func (r *CustomerRepository) toModel(c *customer.Customer) *CustomerModel {
+	return &CustomerModel{
+		ID:          c.ID(),
+		Email:       c.Email(),
+		CreatedAt:   c.CreatedAt(),
+		LastLoginAt: c.LastLoginAt(),
+	}
+}
+
+// CustomerLoginLinkRepository implements the customer.LoginLinkRepository interface using GORM.
+type CustomerLoginLinkRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewCustomerLoginLinkRepository creates a new customer login link repository.
+func NewCustomerLoginLinkRepository(db *gorm.DB, logger *zap.Logger) customer.LoginLinkRepository {
+	return &CustomerLoginLinkRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a new login link to the database.
+func (r *CustomerLoginLinkRepository) Save(ctx context.Context, link *customer.LoginLink) error {
+	model := &CustomerLoginLinkModel{
+		ID:            link.ID(),
+		Email:         link.Email(),
+		TokenHash:     link.TokenHash(),
+		CodeChallenge: link.CodeChallenge(),
+		ExpiresAt:     link.ExpiresAt(),
+		CreatedAt:     link.CreatedAt(),
+		UsedAt:        link.UsedAt(),
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save login link: %w", err)
+	}
+	return nil
+}
+
+// FindByTokenHash finds a login link by its hashed token.
+func (r *CustomerLoginLinkRepository) FindByTokenHash(
+	ctx context.Context,
+	tokenHash string,
+) (*customer.LoginLink, error) {
+	var model CustomerLoginLinkModel
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customer.ErrLoginLinkNotFound
+		}
+		return nil, fmt.Errorf("failed to find login link: %w", err)
+	}
+	return customer.RestoreLoginLink(
+		model.ID, model.Email, model.TokenHash, model.CodeChallenge,
+		model.ExpiresAt, model.CreatedAt, model.UsedAt,
+	)
+}
+
+// MarkUsed records that a login link was used, unless it already was.
+func (r *CustomerLoginLinkRepository) MarkUsed(ctx context.Context, link *customer.LoginLink) error {
+	result := r.db.WithContext(ctx).Model(&CustomerLoginLinkModel{}).
+		Where("id = ? AND used_at IS NULL", link.ID()).
+		Update("used_at", link.UsedAt())
+	if result.Error != nil {
+		return fmt.Errorf("failed to mark login link used: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return customer.ErrLoginLinkNotFound
+	}
+	return nil
+}
+
+// CountSince counts the login links requested for an email address since the given time.
+func (r *CustomerLoginLinkRepository) CountSince(ctx context.Context, email string, since time.Time) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&CustomerLoginLinkModel{}).
+		Where("email = ? AND created_at > ?", email, since).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count login links: %w", err)
+	}
+	return count, nil
+}
+
+// CustomerSessionRepository implements the customer.SessionRepository interface using GORM.
+type CustomerSessionRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewCustomerSessionRepository creates a new customer session repository.
+func NewCustomerSessionRepository(db *gorm.DB, logger *zap.Logger) customer.SessionRepository {
+	return &CustomerSessionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a new session to the database.
+func (r *CustomerSessionRepository) Save(ctx context.Context, session *customer.Session) error {
+	model := &CustomerSessionModel{
+		ID:         session.ID(),
+		CustomerID: session.CustomerID(),
+		TokenHash:  session.TokenHash(),
+		ExpiresAt:  session.ExpiresAt(),
+		CreatedAt:  session.CreatedAt(),
+		RevokedAt:  session.RevokedAt(),
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save customer session: %w", err)
+	}
+	return nil
+}
+
+// FindByTokenHash finds a session by its hashed token.
+func (r *CustomerSessionRepository) FindByTokenHash(
+	ctx context.Context,
+	tokenHash string,
+) (*customer.Session, error) {
+	var model CustomerSessionModel
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customer.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to find customer session: %w", err)
+	}
+	return customer.RestoreSession(
+		model.ID, model.CustomerID, model.TokenHash,
+		model.ExpiresAt, model.CreatedAt, model.RevokedAt,
+	)
+}
+
+// Update updates an existing session.
+func (r *CustomerSessionRepository) Update(ctx context.Context, session *customer.Session) error {
+	result := r.db.WithContext(ctx).Model(&CustomerSessionModel{}).
+		Where("id = ?", session.ID()).
+		Update("revoked_at", session.RevokedAt())
+	if result.Error != nil {
+		return fmt.Errorf("failed to update customer session: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return customer.ErrSessionNotFound
+	}
+	return nil
+}
+
+// CustomerRefundAddressRepository implements the customer.RefundAddressRepository interface using GORM.
+type CustomerRefundAddressRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewCustomerRefundAddressRepository creates a new saved refund address repository.
+func NewCustomerRefundAddressRepository(db *gorm.DB, logger *zap.Logger) customer.RefundAddressRepository {
+	return &CustomerRefundAddressRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a new refund address to the database.
+func (r *CustomerRefundAddressRepository) Save(ctx context.Context, address *customer.RefundAddress) error {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&CustomerRefundAddressModel{}).
+		Where("customer_id = ? AND address = ?", address.CustomerID(), address.Address()).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check refund address: %w", err)
+	}
+	if count > 0 {
+		return customer.ErrRefundAddressExists
+	}
+
+	model := &CustomerRefundAddressModel{
+		ID:         address.ID(),
+		CustomerID: address.CustomerID(),
+		Address:    address.Address(),
+		Label:      address.Label(),
+		CreatedAt:  address.CreatedAt(),
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save refund address: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a saved refund address by ID.
+func (r *CustomerRefundAddressRepository) FindByID(ctx context.Context, id string) (*customer.RefundAddress, error) {
+	var model CustomerRefundAddressModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customer.ErrRefundAddressNotFound
+		}
+		return nil, fmt.Errorf("failed to find refund address: %w", err)
+	}
+	return r.toDomain(&model)
+}
+
+// ListByCustomer lists a customer's saved refund addresses, newest first.
+func (r *CustomerRefundAddressRepository) ListByCustomer(
+	ctx context.Context,
+	customerID string,
+) ([]*customer.RefundAddress, error) {
+	var models []CustomerRefundAddressModel
+	if err := r.db.WithContext(ctx).
+		Where("customer_id = ?", customerID).
+		Order("created_at DESC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list refund addresses: %w", err)
+	}
+
+	addresses := make([]*customer.RefundAddress, len(models))
+	for i := range models {
+		address, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert refund address model to domain: %w", err)
+		}
+		addresses[i] = address
+	}
+	return addresses, nil
+}
+
+// Delete removes a saved refund address.
+func (r *CustomerRefundAddressRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&CustomerRefundAddressModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete refund address: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return customer.ErrRefundAddressNotFound
+	}
+	return nil
+}
+
+// toDomain converts a database model to a domain saved refund address.
+func (r *CustomerRefundAddressRepository) toDomain(model *CustomerRefundAddressModel) (*customer.RefundAddress, error) {
+	return customer.RestoreRefundAddress(model.ID, model.CustomerID, model.Address, model.Label, model.CreatedAt)
+}
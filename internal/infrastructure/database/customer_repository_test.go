@@ -0,0 +1,100 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/customer"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCustomerRepository_ClaimInvoice(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewCustomerRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	alice, err := customer.NewCustomer("customer-1", "alice@example.com")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, alice))
+	bob, err := customer.NewCustomer("customer-2", "bob@example.com")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, bob))
+
+	found, err := repo.FindByEmail(ctx, "alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "customer-1", found.ID())
+	_, err = repo.FindByEmail(ctx, "carol@example.com")
+	require.ErrorIs(t, err, customer.ErrCustomerNotFound)
+
+	require.NoError(t, repo.ClaimInvoice(ctx, "customer-1", "invoice-1"))
+	require.NoError(t, repo.ClaimInvoice(ctx, "customer-1", "invoice-1"), "claiming twice is idempotent")
+	require.ErrorIs(t, repo.ClaimInvoice(ctx, "customer-2", "invoice-1"), customer.ErrInvoiceClaimed)
+	require.NoError(t, repo.ClaimInvoice(ctx, "customer-1", "invoice-2"))
+
+	claimed, err := repo.IsInvoiceClaimed(ctx, "customer-2", "invoice-1")
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	ids, err := repo.ListClaimedInvoiceIDs(ctx, "customer-1", 10, 0)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"invoice-1", "invoice-2"}, ids)
+}
+
+func TestCustomerLoginLinkRepository_MarkUsed(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewCustomerLoginLinkRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	challenge := customer.CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	link, err := customer.NewLoginLink("link-1", "alice@example.com", "raw-token", challenge, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, link))
+
+	count, err := repo.CountSince(ctx, "alice@example.com", time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	found, err := repo.FindByTokenHash(ctx, customer.HashToken("raw-token"))
+	require.NoError(t, err)
+	assert.Equal(t, challenge, found.CodeChallenge())
+
+	found.Use()
+	require.NoError(t, repo.MarkUsed(ctx, found))
+
+	stale, err := customer.RestoreLoginLink(
+		link.ID(), link.Email(), link.TokenHash(), link.CodeChallenge(), link.ExpiresAt(), link.CreatedAt(), nil,
+	)
+	require.NoError(t, err)
+	stale.Use()
+	require.ErrorIs(t, repo.MarkUsed(ctx, stale), customer.ErrLoginLinkNotFound, "a link is redeemed only once")
+}
+
+func TestCustomerRefundAddressRepository_Duplicates(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewCustomerRefundAddressRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	address, err := customer.NewRefundAddress("address-1", "customer-1", "0xabc", "Main wallet")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, address))
+
+	duplicate, err := customer.NewRefundAddress("address-2", "customer-1", "0xabc", "")
+	require.NoError(t, err)
+	require.ErrorIs(t, repo.Save(ctx, duplicate), customer.ErrRefundAddressExists)
+
+	other, err := customer.NewRefundAddress("address-3", "customer-2", "0xabc", "")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, other))
+
+	addresses, err := repo.ListByCustomer(ctx, "customer-1")
+	require.NoError(t, err)
+	require.Len(t, addresses, 1)
+	assert.Equal(t, "Main wallet", addresses[0].Label())
+
+	require.NoError(t, repo.Delete(ctx, "address-1"))
+	require.ErrorIs(t, repo.Delete(ctx, "address-1"), customer.ErrRefundAddressNotFound)
+}
@@ -0,0 +1,171 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/deadletter"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// DeadLetterRepository implements the deadletter.Repository interface using GORM.
+type DeadLetterRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewDeadLetterRepository creates a new dead letter repository.
+func NewDeadLetterRepository(db *gorm.DB, logger *zap.Logger) deadletter.Repository {
+	return &DeadLetterRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a dead letter to the database.
+func (r *DeadLetterRepository) Save(ctx context.Context, d *deadletter.DeadLetter) error {
+	model, err := r.toModel(d)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save dead letter: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a dead letter by ID.
+func (r *DeadLetterRepository) FindByID(ctx context.Context, id string) (*deadletter.DeadLetter, error) {
+	var model DeadLetterModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, deadletter.ErrDeadLetterNotFound
+		}
+		return nil, fmt.Errorf("failed to find dead letter: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// List retrieves dead letters matching the filter, newest first.
+func (r *DeadLetterRepository) List(
+	ctx context.Context,
+	req *deadletter.ListDeadLettersRequest,
+) (*deadletter.ListDeadLettersResponse, error) {
+	query := r.db.WithContext(ctx).Scopes(deadLetterFilter("", req.Handler, req.EventType, req.Status))
+
+	var models []DeadLetterModel
+	if err := query.Scopes(keysetPage("created_at", req.Cursor, 0, req.Limit)).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	models, next := nextPage(models, req.Limit, func(model *DeadLetterModel) (time.Time, string) {
+		return model.CreatedAt, model.ID
+	})
+
+	deadLetters := make([]*deadletter.DeadLetter, len(models))
+	for i := range models {
+		d, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert dead letter model to domain: %w", err)
+		}
+		deadLetters[i] = d
+	}
+
+	return &deadletter.ListDeadLettersResponse{
+		DeadLetters: deadLetters,
+		Limit:       req.Limit,
+		NextCursor:  next,
+	}, nil
+}
+
+// Update updates an existing dead letter.
+func (r *DeadLetterRepository) Update(ctx context.Context, d *deadletter.DeadLetter) error {
+	model, err := r.toModel(d)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to update dead letter: %w", err)
+	}
+
+	return nil
+}
+
+// Purge deletes the dead letters matching the filter and returns how many were deleted.
+func (r *DeadLetterRepository) Purge(ctx context.Context, req *deadletter.PurgeDeadLettersRequest) (int, error) {
+	result := r.db.WithContext(ctx).
+		Session(&gorm.Session{AllowGlobalUpdate: true}).
+		Scopes(deadLetterFilter(req.ID, req.Handler, req.EventType, req.Status)).
+		Delete(&DeadLetterModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge dead letters: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// deadLetterFilter restricts a query to the dead letters matching the filter. Empty fields match all.
+func deadLetterFilter(id, handler, eventType string, status deadletter.Status) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if id != "" {
+			db = db.Where("id = ?", id)
+		}
+		if handler != "" {
+			db = db.Where("handler = ?", handler)
+		}
+		if eventType != "" {
+			db = db.Where("event_type = ?", eventType)
+		}
+		if status != "" {
+			db = db.Where("status = ?", string(status))
+		}
+		return db
+	}
+}
+
+// toModel converts a domain dead letter to a database model.
+func (r *DeadLetterRepository) toModel(d *deadletter.DeadLetter) (*DeadLetterModel, error) {
+	event, err := d.Event().ToJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal dead letter event: %w", err)
+	}
+
+	return &DeadLetterModel{
+		ID:           d.ID(),
+		Handler:      d.Handler(),
+		EventType:    d.Event().EventType,
+		AggregateID:  d.Event().AggregateID,
+		Event:        string(event),
+		LastError:    d.LastError(),
+		Attempts:     d.Attempts(),
+		Status:       string(d.Status()),
+		CreatedAt:    d.CreatedAt(),
+		LastFailedAt: d.LastFailedAt(),
+		ReplayedAt:   d.ReplayedAt(),
+	}, nil
+}
+
+// toDomain converts a database model to a domain dead letter.
+func (r *DeadLetterRepository) toDomain(model *DeadLetterModel) (*deadletter.DeadLetter, error) {
+	event, err := shared.FromJSON([]byte(model.Event))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dead letter event: %w", err)
+	}
+
+	return deadletter.RestoreDeadLetter(
+		model.ID,
+		model.Handler,
+		event,
+		model.LastError,
+		model.Attempts,
+		deadletter.Status(model.Status),
+		model.CreatedAt,
+		model.LastFailedAt,
+		model.ReplayedAt,
+	)
+}
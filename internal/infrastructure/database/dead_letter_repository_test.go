@@ -0,0 +1,58 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/deadletter"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDeadLetterRepository_RoundTripsEvent(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewDeadLetterRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	save := func(handler, invoiceID string) *deadletter.DeadLetter {
+		event := shared.CreateDomainEvent(shared.EventTypeInvoicePaid, invoiceID, "invoice",
+			map[string]interface{}{"status": "paid"}, map[string]interface{}{"source": "test"})
+		d, err := deadletter.NewDeadLetter(uuid.NewString(), handler, event, errors.New("connection refused"))
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, d))
+		return d
+	}
+	settlement := save("*settlement.PaidInvoiceHandler", "invoice-1")
+	save("*treasury.PaidInvoiceHandler", "invoice-1")
+
+	found, err := repo.FindByID(ctx, settlement.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "*settlement.PaidInvoiceHandler", found.Handler())
+	assert.Equal(t, shared.EventTypeInvoicePaid, found.Event().EventType)
+	assert.Equal(t, "invoice-1", found.Event().AggregateID)
+	assert.Equal(t, map[string]interface{}{"status": "paid"}, found.Event().EventData)
+	assert.Equal(t, "connection refused", found.LastError())
+	assert.Equal(t, deadletter.StatusPending, found.Status())
+
+	resp, err := repo.List(ctx, &deadletter.ListDeadLettersRequest{
+		Handler: "*settlement.PaidInvoiceHandler", Status: deadletter.StatusPending, Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.DeadLetters, 1)
+	assert.Equal(t, settlement.ID(), resp.DeadLetters[0].ID())
+
+	purged, err := repo.Purge(ctx, &deadletter.PurgeDeadLettersRequest{ID: settlement.ID()})
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	_, err = repo.FindByID(ctx, settlement.ID())
+	require.ErrorIs(t, err, deadletter.ErrDeadLetterNotFound)
+
+	purged, err = repo.Purge(ctx, &deadletter.PurgeDeadLettersRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+}
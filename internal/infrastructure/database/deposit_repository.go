@@ -0,0 +1,171 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/deposit"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// DepositRepository implements the deposit.Repository interface using GORM.
+type DepositRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewDepositRepository creates a new unattributed deposit repository.
+func NewDepositRepository(db *gorm.DB, logger *zap.Logger) deposit.Repository {
+	return &DepositRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a deposit to the database, failing with deposit.ErrDuplicateDeposit when the transaction is
+// already recorded.
+func (r *DepositRepository) Save(ctx context.Context, d *deposit.Deposit) error {
+	if err := r.db.WithContext(ctx).Create(r.toModel(d)).Error; err != nil {
+		if translator, ok := r.db.Dialector.(gorm.ErrorTranslator); ok &&
+			errors.Is(translator.Translate(err), gorm.ErrDuplicatedKey) {
+			return deposit.ErrDuplicateDeposit
+		}
+		return fmt.Errorf("failed to save deposit: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a deposit by ID.
+func (r *DepositRepository) FindByID(ctx context.Context, id string) (*deposit.Deposit, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+// FindByTransaction finds the deposit recorded for a transaction on a network.
+func (r *DepositRepository) FindByTransaction(
+	ctx context.Context,
+	network shared.BlockchainNetwork,
+	txHash string,
+) (*deposit.Deposit, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("network = ? AND tx_hash = ?", string(network), txHash))
+}
+
+// List retrieves deposits matching the filter, oldest first.
+func (r *DepositRepository) List(
+	ctx context.Context,
+	req *deposit.ListDepositsRequest,
+) (*deposit.ListDepositsResponse, error) {
+	query := r.db.WithContext(ctx).Model(&UnattributedDepositModel{})
+	if req.MerchantID != "" {
+		query = query.Where("merchant_id = ?", req.MerchantID)
+	}
+	if req.Status != "" {
+		query = query.Where("status = ?", string(req.Status))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count deposits: %w", err)
+	}
+
+	var models []UnattributedDepositModel
+	if err := query.Order("created_at ASC").Limit(req.Limit).Offset(req.Offset).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list deposits: %w", err)
+	}
+
+	deposits := make([]*deposit.Deposit, len(models))
+	for i := range models {
+		d, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert deposit model to domain: %w", err)
+		}
+		deposits[i] = d
+	}
+
+	return &deposit.ListDepositsResponse{
+		Deposits: deposits,
+		Total:    int(total),
+		Limit:    req.Limit,
+		Offset:   req.Offset,
+	}, nil
+}
+
+// Update updates an existing deposit.
+func (r *DepositRepository) Update(ctx context.Context, d *deposit.Deposit) error {
+	if err := r.db.WithContext(ctx).Save(r.toModel(d)).Error; err != nil {
+		return fmt.Errorf("failed to update deposit: %w", err)
+	}
+
+	return nil
+}
+
+// findOne finds a single deposit matching the query.
+func (r *DepositRepository) findOne(query *gorm.DB) (*deposit.Deposit, error) {
+	var model UnattributedDepositModel
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, deposit.ErrDepositNotFound
+		}
+		return nil, fmt.Errorf("failed to find deposit: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// toModel converts a domain deposit to a database model.
+func (r *DepositRepository) toModel(d *deposit.Deposit) *UnattributedDepositModel {
+	transfer := d.Transfer()
+	return &UnattributedDepositModel{
+		ID:                d.ID(),
+		MerchantID:        d.MerchantID(),
+		InvoiceID:         d.InvoiceID(),
+		Reason:            string(d.Reason()),
+		Status:            string(d.Status()),
+		Network:           string(transfer.Network),
+		TxHash:            transfer.TransactionHash,
+		FromAddress:       transfer.FromAddress,
+		ToAddress:         transfer.ToAddress,
+		Amount:            transfer.Amount,
+		Currency:          string(transfer.Currency),
+		Memo:              transfer.Memo,
+		BlockNumber:       transfer.BlockNumber,
+		BlockHash:         transfer.BlockHash,
+		Confirmations:     transfer.Confirmations,
+		AttachedInvoiceID: d.AttachedInvoiceID(),
+		PaymentID:         d.PaymentID(),
+		ResolvedBy:        d.ResolvedBy(),
+		CreatedAt:         d.CreatedAt(),
+		ResolvedAt:        d.ResolvedAt(),
+	}
+}
+
+// toDomain converts a database model to a domain deposit.
+func (r *DepositRepository) toDomain(model *UnattributedDepositModel) (*deposit.Deposit, error) {
+	return deposit.RestoreDeposit(
+		model.ID,
+		model.MerchantID,
+		model.InvoiceID,
+		deposit.Reason(model.Reason),
+		deposit.Transfer{
+			Network:         shared.BlockchainNetwork(model.Network),
+			TransactionHash: model.TxHash,
+			FromAddress:     model.FromAddress,
+			ToAddress:       model.ToAddress,
+			Amount:          model.Amount,
+			Currency:        shared.CryptoCurrency(model.Currency),
+			Memo:            model.Memo,
+			BlockNumber:     model.BlockNumber,
+			BlockHash:       model.BlockHash,
+			Confirmations:   model.Confirmations,
+		},
+		deposit.Status(model.Status),
+		model.AttachedInvoiceID,
+		model.PaymentID,
+		model.ResolvedBy,
+		model.CreatedAt,
+		model.ResolvedAt,
+	)
+}
@@ -0,0 +1,74 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/deposit"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestDeposit(t *testing.T, id, merchantID, txHash string) *deposit.Deposit {
+	d, err := deposit.NewDeposit(id, merchantID, "invoice-1", deposit.ReasonInvoiceExpired, deposit.Transfer{
+		Network:         shared.NetworkTron,
+		TransactionHash: txHash,
+		FromAddress:     "TSenderAddress123456789012345678901234567890",
+		ToAddress:       "TReceiverAddress12345678901234567890",
+		Amount:          "25.5",
+		Currency:        shared.CryptoCurrencyUSDT,
+		Memo:            "INV-2026-0001",
+	})
+	require.NoError(t, err)
+	return d
+}
+
+func TestDepositRepository(t *testing.T) {
+	repo := database.NewDepositRepository(setupTestDB(t), zap.NewNop())
+	ctx := context.Background()
+
+	first := newTestDeposit(t, "0b1c7a5e9f0d4c2a8e6b3d1f5a7c9e0b", "merchant-1", "0xaaa")
+	require.NoError(t, repo.Save(ctx, first))
+	require.NoError(t, repo.Save(ctx, newTestDeposit(t, "1b1c7a5e9f0d4c2a8e6b3d1f5a7c9e0b", "merchant-1", "0xbbb")))
+	require.NoError(t, repo.Save(ctx, newTestDeposit(t, "2b1c7a5e9f0d4c2a8e6b3d1f5a7c9e0b", "merchant-2", "0xccc")))
+
+	// A transaction is recorded once per network
+	err := repo.Save(ctx, newTestDeposit(t, "3b1c7a5e9f0d4c2a8e6b3d1f5a7c9e0b", "merchant-1", "0xaaa"))
+	require.ErrorIs(t, err, deposit.ErrDuplicateDeposit)
+
+	found, err := repo.FindByTransaction(ctx, shared.NetworkTron, "0xaaa")
+	require.NoError(t, err)
+	assert.Equal(t, first.ID(), found.ID())
+	assert.Equal(t, first.Transfer(), found.Transfer())
+	_, err = repo.FindByTransaction(ctx, shared.NetworkEthereum, "0xaaa")
+	require.ErrorIs(t, err, deposit.ErrDepositNotFound)
+	_, err = repo.FindByID(ctx, "missing")
+	require.ErrorIs(t, err, deposit.ErrDepositNotFound)
+
+	require.NoError(t, found.Attach("invoice-2", "payment-1", "user-1"))
+	require.True(t, found.Advance(1000, "0xb0", 3))
+	require.NoError(t, repo.Update(ctx, found))
+
+	attached, err := repo.FindByID(ctx, first.ID())
+	require.NoError(t, err)
+	assert.Equal(t, deposit.StatusAttached, attached.Status())
+	assert.Equal(t, "invoice-2", attached.AttachedInvoiceID())
+	assert.Equal(t, "payment-1", attached.PaymentID())
+	assert.Equal(t, 3, attached.Transfer().Confirmations)
+	assert.NotNil(t, attached.ResolvedAt())
+
+	unmatched, err := repo.List(ctx, &deposit.ListDepositsRequest{
+		MerchantID: "merchant-1", Status: deposit.StatusUnmatched, Limit: 10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, unmatched.Total)
+	require.Len(t, unmatched.Deposits, 1)
+	assert.Equal(t, "0xbbb", unmatched.Deposits[0].Transfer().TransactionHash)
+
+	all, err := repo.List(ctx, &deposit.ListDepositsRequest{MerchantID: "merchant-1", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, all.Total)
+}
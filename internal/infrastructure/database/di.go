@@ -2,12 +2,44 @@ package database
 
 import (
 	"context"
+	"crypto-checkout/internal/domain/approval"
+	"crypto-checkout/internal/domain/archive"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/automation"
+	"crypto-checkout/internal/domain/checkoutanalytics"
+	"crypto-checkout/internal/domain/compliance"
+	"crypto-checkout/internal/domain/coupon"
+	"crypto-checkout/internal/domain/customer"
+	"crypto-checkout/internal/domain/deadletter"
+	"crypto-checkout/internal/domain/deposit"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/evidence"
+	"crypto-checkout/internal/domain/experiment"
+	"crypto-checkout/internal/domain/fee"
+	"crypto-checkout/internal/domain/geoblock"
 	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/invoicetemplate"
 	"crypto-checkout/internal/domain/merchant"
 	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/paymentlink"
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/platform"
+	"crypto-checkout/internal/domain/processor"
+	"crypto-checkout/internal/domain/reconciliation"
+	"crypto-checkout/internal/domain/review"
+	"crypto-checkout/internal/domain/saga"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/statement"
+	"crypto-checkout/internal/domain/tax"
+	"crypto-checkout/internal/domain/treasury"
+	"crypto-checkout/internal/domain/webhook"
 	"crypto-checkout/pkg/config"
+	"errors"
 	"fmt"
+	"net/url"
 
+	"github.com/shopspring/decimal"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -20,11 +52,59 @@ var Module = fx.Module("database",
 		NewGormDBProvider,
 		NewInvoiceRepositoryProvider,
 		NewPaymentRepositoryProvider,
+		NewPaymentStatisticsRepositoryProvider,
 		NewMerchantRepositoryProvider,
 		NewAPIKeyRepositoryProvider,
 		NewWebhookEndpointRepositoryProvider,
+		NewWebhookDeliveryRepositoryProvider,
+		NewUserRepositoryProvider,
+		NewInvitationRepositoryProvider,
+		NewAuditRepositoryProvider,
+		NewRefreshTokenRepositoryProvider,
+		NewScreeningRepositoryProvider,
+		NewBlocklistRepositoryProvider,
+		NewReviewRepositoryProvider,
+		NewCouponRepositoryProvider,
+		NewTaxRuleRepositoryProvider,
+		NewPaymentLinkRepositoryProvider,
+		NewInvoiceTemplateRepositoryProvider,
+		NewAutomationRuleRepositoryProvider,
+		NewProcessorRepositoryProvider,
+		NewSettlementRepositoryProvider,
+		NewPayoutWalletRepositoryProvider,
+		NewPayoutRepositoryProvider,
+		NewTreasuryBalanceRepositoryProvider,
+		NewSweepRepositoryProvider,
+		NewColdTransferRepositoryProvider,
+		NewFeeSpendRepositoryProvider,
+		NewApprovalRepositoryProvider,
+		NewDeadLetterRepositoryProvider,
+		NewSagaRepositoryProvider,
+		NewPlatformRepositoryProvider,
+		NewBlockCursorRepositoryProvider,
+		NewDepositRepositoryProvider,
+		NewArchiveRepositoryProvider,
+		NewArchivePolicyProvider,
+		NewReconciliationRepositoryProvider,
+		NewReconciliationLedgerProvider,
+		NewReconciliationPolicyProvider,
+		NewStatementRepositoryProvider,
+		NewStatementLedgerProvider,
+		NewStatementPolicyProvider,
+		NewEvidenceRepositoryProvider,
+		NewExperimentRepositoryProvider,
+		NewExperimentAssignmentRepositoryProvider,
+		NewCheckoutAnalyticsRepositoryProvider,
+		NewGeoBlockRepositoryProvider,
+		NewCustomerRepositoryProvider,
+		NewCustomerLoginLinkRepositoryProvider,
+		NewCustomerSessionRepositoryProvider,
+		NewCustomerRefundAddressRepositoryProvider,
+		NewCustomerPolicyProvider,
+		NewIDGeneratorProvider,
 	),
 	fx.Invoke(InitializeDatabase),
+	fx.Invoke(RegisterReplicaHealthChecks),
 )
 
 // NewGormDBProvider provides a *gorm.DB from the database connection.
@@ -48,6 +128,21 @@ func NewDatabaseConnection(cfg *config.Config, logger *zap.Logger) (*Connection,
 		DBName:   cfg.Database.DBName,
 		SSLMode:  cfg.Database.SSLMode,
 		URL:      cfg.Database.URL, // Include the URL field
+
+		MaxOpenConns:    cfg.Database.MaxOpenConns,
+		MaxIdleConns:    cfg.Database.MaxIdleConns,
+		ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+		ConnMaxIdleTime: cfg.Database.ConnMaxIdleTime,
+		ReplicaURLs:     cfg.Database.ReplicaURLs,
+	}
+
+	keyring, err := NewColumnKeyring(cfg.Encryption)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load encryption keys: %w", err)
+	}
+	SetColumnKeyring(keyring)
+	if keyring == nil {
+		logger.Warn("Sensitive columns are stored unencrypted; set encryption.keys or encryption.key_file to encrypt them")
 	}
 
 	conn, err := NewConnection(dbConfig, logger)
@@ -60,9 +155,28 @@ func NewDatabaseConnection(cfg *config.Config, logger *zap.Logger) (*Connection,
 	return conn, nil
 }
 
-// NewInvoiceRepositoryProvider creates a new invoice repository.
-func NewInvoiceRepositoryProvider(conn *Connection) invoice.Repository {
-	return NewInvoiceRepository(conn.DB)
+// NewInvoiceRepositoryProvider creates a new invoice repository, cached when an invoice cache TTL is configured.
+// The cache subscribes to invoice events on the event bus to drop invoices as they change.
+func NewInvoiceRepositoryProvider(
+	conn *Connection,
+	cfg *config.Config,
+	registry shared.EventHandlerRegistry,
+	logger *zap.Logger,
+) (invoice.Repository, error) {
+	if err := invoice.ValidateNumberReset(cfg.InvoiceNumbers.Reset); err != nil {
+		return nil, fmt.Errorf("invalid invoice_numbers.reset: %w", err)
+	}
+	repository := NewInvoiceRepositoryWithNumberReset(conn.DB, cfg.InvoiceNumbers.Reset, logger)
+	if cfg.Cache.InvoiceTTL <= 0 {
+		return repository, nil
+	}
+
+	cached := NewCachedInvoiceRepository(repository, cfg.Cache.InvoiceTTL, cfg.Cache.InvoiceMaxEntries, logger)
+	registry.RegisterHandler(cached)
+	logger.Info("Invoice read cache enabled",
+		zap.Duration("ttl", cfg.Cache.InvoiceTTL),
+		zap.Int("max_entries", cfg.Cache.InvoiceMaxEntries))
+	return cached, nil
 }
 
 // NewPaymentRepositoryProvider creates a new payment repository.
@@ -70,6 +184,11 @@ func NewPaymentRepositoryProvider(conn *Connection) payment.Repository {
 	return NewPaymentRepository(conn.DB)
 }
 
+// NewPaymentStatisticsRepositoryProvider creates a new payment statistics repository.
+func NewPaymentStatisticsRepositoryProvider(conn *Connection, logger *zap.Logger) payment.StatisticsRepository {
+	return NewPaymentStatisticsRepository(conn.DB, logger)
+}
+
 // NewMerchantRepositoryProvider creates a new merchant repository.
 func NewMerchantRepositoryProvider(conn *Connection, logger *zap.Logger) merchant.MerchantRepository {
 	return NewMerchantRepository(conn.DB, logger)
@@ -85,6 +204,106 @@ func NewWebhookEndpointRepositoryProvider(conn *Connection, logger *zap.Logger)
 	return NewWebhookEndpointRepository(conn.DB, logger)
 }
 
+// NewWebhookDeliveryRepositoryProvider creates a new webhook delivery log repository.
+func NewWebhookDeliveryRepositoryProvider(conn *Connection, logger *zap.Logger) webhook.Repository {
+	return NewWebhookDeliveryRepository(conn.DB, logger)
+}
+
+// NewUserRepositoryProvider creates a new team member repository.
+func NewUserRepositoryProvider(conn *Connection, logger *zap.Logger) merchant.UserRepository {
+	return NewUserRepository(conn.DB, logger)
+}
+
+// NewInvitationRepositoryProvider creates a new team invitation repository.
+func NewInvitationRepositoryProvider(conn *Connection, logger *zap.Logger) merchant.InvitationRepository {
+	return NewInvitationRepository(conn.DB, logger)
+}
+
+// NewAuditRepositoryProvider creates a new audit log repository.
+func NewAuditRepositoryProvider(conn *Connection, logger *zap.Logger) audit.Repository {
+	return NewAuditRepository(conn.DB, logger)
+}
+
+// NewRefreshTokenRepositoryProvider creates a new refresh token repository.
+func NewRefreshTokenRepositoryProvider(conn *Connection, logger *zap.Logger) merchant.RefreshTokenRepository {
+	return NewRefreshTokenRepository(conn.DB, logger)
+}
+
+// NewScreeningRepositoryProvider creates a new payment screening repository.
+func NewScreeningRepositoryProvider(conn *Connection, logger *zap.Logger) compliance.Repository {
+	return NewScreeningRepository(conn.DB, logger)
+}
+
+// NewBlocklistRepositoryProvider creates a new address blocklist repository.
+func NewBlocklistRepositoryProvider(conn *Connection, logger *zap.Logger) compliance.BlocklistRepository {
+	return NewBlocklistRepository(conn.DB, logger)
+}
+
+// NewReviewRepositoryProvider creates a new payment review repository.
+func NewReviewRepositoryProvider(conn *Connection, logger *zap.Logger) review.Repository {
+	return NewReviewRepository(conn.DB, logger)
+}
+
+// NewCouponRepositoryProvider creates a new coupon repository.
+func NewCouponRepositoryProvider(conn *Connection, logger *zap.Logger) coupon.Repository {
+	return NewCouponRepository(conn.DB, logger)
+}
+
+// NewTaxRuleRepositoryProvider creates a new tax rule repository.
+func NewTaxRuleRepositoryProvider(conn *Connection, logger *zap.Logger) tax.Repository {
+	return NewTaxRuleRepository(conn.DB, logger)
+}
+
+// NewPaymentLinkRepositoryProvider creates a new payment link repository.
+func NewPaymentLinkRepositoryProvider(conn *Connection, logger *zap.Logger) paymentlink.Repository {
+	return NewPaymentLinkRepository(conn.DB, logger)
+}
+
+// NewInvoiceTemplateRepositoryProvider creates a new invoice template repository.
+func NewInvoiceTemplateRepositoryProvider(conn *Connection, logger *zap.Logger) invoicetemplate.Repository {
+	return NewInvoiceTemplateRepository(conn.DB, logger)
+}
+
+// NewAutomationRuleRepositoryProvider creates a new automation rule repository.
+func NewAutomationRuleRepositoryProvider(conn *Connection, logger *zap.Logger) automation.Repository {
+	return NewAutomationRuleRepository(conn.DB, logger)
+}
+
+// NewProcessorRepositoryProvider creates a new payment processor repository.
+func NewProcessorRepositoryProvider(conn *Connection, logger *zap.Logger) processor.Repository {
+	return NewProcessorRepository(conn.DB, logger)
+}
+
+// NewSettlementRepositoryProvider creates a new settlement repository.
+func NewSettlementRepositoryProvider(conn *Connection, logger *zap.Logger) settlement.Repository {
+	return NewSettlementRepository(conn.DB, logger)
+}
+
+// NewPayoutWalletRepositoryProvider creates a new payout wallet repository.
+func NewPayoutWalletRepositoryProvider(conn *Connection, logger *zap.Logger) payout.WalletRepository {
+	return NewPayoutWalletRepository(conn.DB, logger)
+}
+
+// NewPayoutRepositoryProvider creates a new payout repository.
+func NewPayoutRepositoryProvider(conn *Connection, logger *zap.Logger) payout.Repository {
+	return NewPayoutRepository(conn.DB, logger)
+}
+
+// NewTreasuryBalanceRepositoryProvider creates a new treasury balance repository.
+func NewTreasuryBalanceRepositoryProvider(conn *Connection, logger *zap.Logger) treasury.BalanceRepository {
+	return NewTreasuryBalanceRepository(conn.DB, logger)
+}
+
+// NewSweepRepositoryProvider creates a new sweep repository.
+func NewSweepRepositoryProvider(conn *Connection, logger *zap.Logger) treasury.SweepRepository {
+	return NewSweepRepository(conn.DB, logger)
+}
+
+// NewColdTransferRepositoryProvider creates a new cold storage transfer repository.
+func NewColdTransferRepositoryProvider(conn *Connection, logger *zap.Logger) treasury.TransferRepository {
+	return NewColdTransferRepository(conn.DB, logger)
+}
+
 // InitializeDatabase initializes the database with migrations.
 func InitializeDatabase(conn *Connection, logger *zap.Logger, lc fx.Lifecycle) {
 	lc.Append(fx.Hook{
@@ -108,3 +327,194 @@ func InitializeDatabase(conn *Connection, logger *zap.Logger, lc fx.Lifecycle) {
 		},
 	})
 }
+
+// NewFeeSpendRepositoryProvider creates a new fee spend repository.
+func NewFeeSpendRepositoryProvider(conn *Connection, logger *zap.Logger) fee.SpendRepository {
+	return NewFeeSpendRepository(conn.DB, logger)
+}
+
+// NewApprovalRepositoryProvider creates a new approval repository.
+func NewApprovalRepositoryProvider(conn *Connection, logger *zap.Logger) approval.Repository {
+	return NewApprovalRepository(conn.DB, logger)
+}
+
+// NewDeadLetterRepositoryProvider creates a new dead letter repository.
+func NewDeadLetterRepositoryProvider(conn *Connection, logger *zap.Logger) deadletter.Repository {
+	return NewDeadLetterRepository(conn.DB, logger)
+}
+
+// NewSagaRepositoryProvider creates a new saga repository.
+func NewSagaRepositoryProvider(conn *Connection, logger *zap.Logger) saga.Repository {
+	return NewSagaRepository(conn.DB, logger)
+}
+
+// NewPlatformRepositoryProvider creates a new platform repository.
+func NewPlatformRepositoryProvider(conn *Connection, logger *zap.Logger) platform.Repository {
+	return NewPlatformRepository(conn.DB, logger)
+}
+
+// NewBlockCursorRepositoryProvider creates a new block cursor repository.
+func NewBlockCursorRepositoryProvider(conn *Connection, logger *zap.Logger) detection.CursorRepository {
+	return NewBlockCursorRepository(conn.DB, logger)
+}
+
+// NewDepositRepositoryProvider creates a new unattributed deposit repository.
+func NewDepositRepositoryProvider(conn *Connection, logger *zap.Logger) deposit.Repository {
+	return NewDepositRepository(conn.DB, logger)
+}
+
+// NewArchiveRepositoryProvider creates a new invoice archive repository.
+func NewArchiveRepositoryProvider(conn *Connection, logger *zap.Logger) archive.Repository {
+	return NewArchiveRepository(conn.DB, logger)
+}
+
+// NewArchivePolicyProvider creates the invoice archival policy from configuration.
+func NewArchivePolicyProvider(cfg *config.Config) (archive.Policy, error) {
+	if cfg.Archive.AfterMonths < 0 {
+		return archive.Policy{}, fmt.Errorf("invalid archive.after_months: %d", cfg.Archive.AfterMonths)
+	}
+	if cfg.Archive.BatchSize < 0 {
+		return archive.Policy{}, fmt.Errorf("invalid archive.batch_size: %d", cfg.Archive.BatchSize)
+	}
+	return archive.Policy{
+		Enabled:     cfg.Archive.Enabled,
+		AfterMonths: cfg.Archive.AfterMonths,
+		Interval:    cfg.Archive.Interval,
+		BatchSize:   cfg.Archive.BatchSize,
+	}, nil
+}
+
+// NewReconciliationRepositoryProvider creates a new reconciliation report repository.
+func NewReconciliationRepositoryProvider(conn *Connection, logger *zap.Logger) reconciliation.Repository {
+	return NewReconciliationRepository(conn.DB, logger)
+}
+
+// NewReconciliationLedgerProvider creates the ledger reconciliations read the records from.
+func NewReconciliationLedgerProvider(conn *Connection, logger *zap.Logger) reconciliation.Ledger {
+	return NewReconciliationRepository(conn.DB, logger)
+}
+
+// NewReconciliationPolicyProvider creates the nightly reconciliation policy from configuration.
+func NewReconciliationPolicyProvider(cfg *config.Config) (reconciliation.Policy, error) {
+	if cfg.Reconciliation.Hour < 0 || cfg.Reconciliation.Hour > 23 {
+		return reconciliation.Policy{}, fmt.Errorf("invalid reconciliation.hour: %d", cfg.Reconciliation.Hour)
+	}
+
+	policy := reconciliation.DefaultPolicy()
+	policy.Enabled = cfg.Reconciliation.Enabled
+	policy.Hour = cfg.Reconciliation.Hour
+	for _, threshold := range cfg.Reconciliation.Thresholds {
+		if threshold.Currency == "" {
+			return reconciliation.Policy{}, errors.New("reconciliation.thresholds entries require a currency")
+		}
+		amount, err := decimal.NewFromString(threshold.Amount)
+		if err != nil || amount.IsNegative() {
+			return reconciliation.Policy{}, fmt.Errorf(
+				"invalid reconciliation threshold %q for %s", threshold.Amount, threshold.Currency)
+		}
+		policy.Thresholds[threshold.Currency] = amount
+	}
+	return policy, nil
+}
+
+// NewStatementRepositoryProvider creates a new statement repository.
+func NewStatementRepositoryProvider(conn *Connection, logger *zap.Logger) statement.Repository {
+	return NewStatementRepository(conn.DB, logger)
+}
+
+// NewStatementLedgerProvider creates the ledger statements summarize the activity of merchants from.
+func NewStatementLedgerProvider(conn *Connection, logger *zap.Logger) statement.Ledger {
+	return NewStatementRepository(conn.DB, logger)
+}
+
+// NewStatementPolicyProvider creates the monthly statement policy from configuration.
+func NewStatementPolicyProvider(cfg *config.Config) (statement.Policy, error) {
+	if cfg.Statements.Interval < 0 {
+		return statement.Policy{}, fmt.Errorf("invalid statements.interval: %s", cfg.Statements.Interval)
+	}
+	return statement.Policy{
+		Enabled:  cfg.Statements.Enabled,
+		Interval: cfg.Statements.Interval,
+		Email:    cfg.Statements.Email,
+	}, nil
+}
+
+// NewEvidenceRepositoryProvider creates a new evidence repository.
+func NewEvidenceRepositoryProvider(conn *Connection, logger *zap.Logger) evidence.Repository {
+	return NewEvidenceRepository(conn.DB, logger)
+}
+
+// NewExperimentRepositoryProvider creates a new checkout experiment repository.
+func NewExperimentRepositoryProvider(conn *Connection, logger *zap.Logger) experiment.Repository {
+	return NewExperimentRepository(conn.DB, logger)
+}
+
+// NewExperimentAssignmentRepositoryProvider creates a new checkout variant assignment repository.
+func NewExperimentAssignmentRepositoryProvider(conn *Connection, logger *zap.Logger) experiment.AssignmentRepository {
+	return NewExperimentAssignmentRepository(conn.DB, logger)
+}
+
+// NewCheckoutAnalyticsRepositoryProvider creates a new checkout funnel and view count repository.
+func NewCheckoutAnalyticsRepositoryProvider(conn *Connection, logger *zap.Logger) checkoutanalytics.Repository {
+	return NewCheckoutAnalyticsRepository(conn.DB, logger)
+}
+
+// NewGeoBlockRepositoryProvider creates a new geo-block and override repository.
+func NewGeoBlockRepositoryProvider(conn *Connection, logger *zap.Logger) geoblock.Repository {
+	return NewGeoBlockRepository(conn.DB, logger)
+}
+
+// NewCustomerRepositoryProvider creates a new customer account repository.
+func NewCustomerRepositoryProvider(conn *Connection, logger *zap.Logger) customer.Repository {
+	return NewCustomerRepository(conn.DB, logger)
+}
+
+// NewCustomerLoginLinkRepositoryProvider creates a new customer login link repository.
+func NewCustomerLoginLinkRepositoryProvider(conn *Connection, logger *zap.Logger) customer.LoginLinkRepository {
+	return NewCustomerLoginLinkRepository(conn.DB, logger)
+}
+
+// NewCustomerSessionRepositoryProvider creates a new customer session repository.
+func NewCustomerSessionRepositoryProvider(conn *Connection, logger *zap.Logger) customer.SessionRepository {
+	return NewCustomerSessionRepository(conn.DB, logger)
+}
+
+// NewCustomerRefundAddressRepositoryProvider creates a new saved refund address repository.
+func NewCustomerRefundAddressRepositoryProvider(
+	conn *Connection,
+	logger *zap.Logger,
+) customer.RefundAddressRepository {
+	return NewCustomerRefundAddressRepository(conn.DB, logger)
+}
+
+// NewCustomerPolicyProvider creates the customer account policy from configuration.
+func NewCustomerPolicyProvider(cfg *config.Config) (customer.Policy, error) {
+	customers := cfg.Customers
+	if customers.LoginLinkTTL < 0 || customers.SessionTTL < 0 {
+		return customer.Policy{}, fmt.Errorf("invalid customers TTLs: login link %s, session %s",
+			customers.LoginLinkTTL, customers.SessionTTL)
+	}
+	if customers.Enabled {
+		loginURL, err := url.Parse(customers.LoginURL)
+		if err != nil || (loginURL.Scheme != "https" && loginURL.Scheme != "http") || loginURL.Host == "" {
+			return customer.Policy{}, fmt.Errorf("invalid customers.login_url: %q is not an absolute URL",
+				customers.LoginURL)
+		}
+	}
+	return customer.Policy{
+		Enabled:      customers.Enabled,
+		LoginURL:     customers.LoginURL,
+		LoginLinkTTL: customers.LoginLinkTTL,
+		SessionTTL:   customers.SessionTTL,
+	}, nil
+}
+
+// NewIDGeneratorProvider creates the generator of invoice, payment, settlement and refund IDs configured with
+// ids.strategy, numbering sequential IDs in the database.
+func NewIDGeneratorProvider(conn *Connection, cfg *config.Config, logger *zap.Logger) (shared.IDGenerator, error) {
+	generator, err := shared.NewIDGenerator(cfg.IDs.Strategy, NewIDSequenceRepository(conn.DB, logger))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ids.strategy: %w", err)
+	}
+	return generator, nil
+}
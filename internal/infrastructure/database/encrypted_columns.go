@@ -0,0 +1,193 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/infrastructure/encryption"
+	"crypto-checkout/pkg/config"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// rotationBatchSize is the number of rows re-encrypted per transaction by RotateEncryptedColumns.
+const rotationBatchSize = 500
+
+// encryptedColumn is a column of sensitive values stored through the "encrypted" serializer.
+type encryptedColumn struct {
+	table  string
+	column string
+}
+
+// encryptedColumns lists the columns tagged serializer:encrypted in the models, for key rotation.
+var encryptedColumns = []encryptedColumn{
+	{table: "webhook_endpoints", column: "secret"},
+	{table: "webhook_endpoints", column: "previous_secret"},
+	{table: "users", column: "totp_secret"},
+	{table: "automation_rules", column: "secret"},
+	{table: "payment_processors", column: "api_key"},
+	{table: "payment_processors", column: "api_secret"},
+	{table: "payment_processors", column: "webhook_secret"},
+}
+
+// columnKeyring is the keyring of the encrypted serializer; nil stores values in plain text.
+var columnKeyring atomic.Pointer[encryption.Keyring]
+
+func init() {
+	schema.RegisterSerializer("encrypted", encryptedSerializer{})
+}
+
+// SetColumnKeyring sets the keyring sensitive columns are encrypted with. Without one, they are written in plain
+// text and only plain-text values can be read.
+func SetColumnKeyring(keyring *encryption.Keyring) {
+	columnKeyring.Store(keyring)
+}
+
+// NewColumnKeyring loads the keyring of sensitive columns from configuration, or returns nil if no keys are
+// configured.
+func NewColumnKeyring(cfg config.EncryptionConfig) (*encryption.Keyring, error) {
+	if cfg.Keys != "" {
+		return encryption.ParseKeyFile(strings.NewReader(cfg.Keys))
+	}
+	if cfg.KeyFile == "" {
+		return nil, nil
+	}
+	return encryption.LoadKeyFile(cfg.KeyFile)
+}
+
+// encryptedSerializer seals string fields on write and opens them on read. Values written before encryption
+// was enabled are read as they are, until RotateEncryptedColumns seals them.
+type encryptedSerializer struct{}
+
+// Scan implements schema.SerializerInterface.
+func (encryptedSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var stored string
+	switch value := dbValue.(type) {
+	case nil:
+	case string:
+		stored = value
+	case []byte:
+		stored = string(value)
+	default:
+		return fmt.Errorf("unsupported type %T of encrypted column %s", dbValue, field.DBName)
+	}
+
+	if encryption.IsSealed(stored) {
+		keyring := columnKeyring.Load()
+		if keyring == nil {
+			return fmt.Errorf("column %s.%s is encrypted but no encryption key file is configured",
+				field.Schema.Table, field.DBName)
+		}
+		plain, err := keyring.Open(stored, columnAdditionalData(field.Schema.Table, field.DBName))
+		if err != nil {
+			return fmt.Errorf("failed to decrypt %s.%s: %w", field.Schema.Table, field.DBName, err)
+		}
+		stored = string(plain)
+	}
+	return field.Set(ctx, dst, stored)
+}
+
+// Value implements schema.SerializerValuerInterface.
+func (encryptedSerializer) Value(
+	_ context.Context, field *schema.Field, _ reflect.Value, fieldValue interface{},
+) (interface{}, error) {
+	value, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("unsupported type %T of encrypted column %s", fieldValue, field.DBName)
+	}
+	keyring := columnKeyring.Load()
+	if keyring == nil || value == "" {
+		return value, nil
+	}
+	return keyring.Seal([]byte(value), columnAdditionalData(field.Schema.Table, field.DBName))
+}
+
+// columnAdditionalData binds a sealed value to its column, so that it cannot be copied into another one.
+func columnAdditionalData(table, column string) []byte {
+	return []byte(table + "." + column)
+}
+
+// RotateEncryptedColumns seals every value of the encrypted columns that is not yet sealed with the current
+// master key: values sealed with older keys after a rotation and values stored before encryption was enabled.
+// It returns the number of values sealed per column; with dryRun, it only counts them. Tables that have not been
+// created are skipped.
+func RotateEncryptedColumns(
+	ctx context.Context, db *gorm.DB, keyring *encryption.Keyring, dryRun bool,
+) (map[string]int, error) {
+	counts := make(map[string]int, len(encryptedColumns))
+	for _, column := range encryptedColumns {
+		if !db.WithContext(ctx).Migrator().HasTable(column.table) {
+			continue
+		}
+		name := column.table + "." + column.column
+		count, err := rotateColumn(ctx, db, keyring, column, dryRun)
+		counts[name] = count
+		if err != nil {
+			return counts, fmt.Errorf("failed to rotate %s: %w", name, err)
+		}
+	}
+	return counts, nil
+}
+
+// rotateColumn seals the values of a column not yet sealed with the current master key, one batch per
+// transaction so that an interrupted rotation can be resumed.
+func rotateColumn(
+	ctx context.Context, db *gorm.DB, keyring *encryption.Keyring, column encryptedColumn, dryRun bool,
+) (int, error) {
+	pending := func(tx *gorm.DB) *gorm.DB {
+		return tx.Table(column.table).
+			Where(column.column+" IS NOT NULL AND "+column.column+" <> ''").
+			Where(column.column+" NOT LIKE ?", keyring.CurrentPrefix()+"%")
+	}
+	if dryRun {
+		var count int64
+		err := pending(db.WithContext(ctx)).Count(&count).Error
+		return int(count), err
+	}
+
+	additionalData := columnAdditionalData(column.table, column.column)
+	rotated := 0
+	for {
+		var batch []struct {
+			ID    string
+			Value string
+		}
+		sealed := 0
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := pending(tx).Select("id, " + column.column + " AS value").Order("id").
+				Limit(rotationBatchSize).Scan(&batch).Error; err != nil {
+				return err
+			}
+
+			for _, row := range batch {
+				plain := []byte(row.Value)
+				if encryption.IsSealed(row.Value) {
+					var err error
+					if plain, err = keyring.Open(row.Value, additionalData); err != nil {
+						return fmt.Errorf("row %s: %w", row.ID, err)
+					}
+				}
+				value, err := keyring.Seal(plain, additionalData)
+				if err != nil {
+					return err
+				}
+				if err := tx.Table(column.table).Where("id = ?", row.ID).
+					UpdateColumn(column.column, value).Error; err != nil {
+					return err
+				}
+				sealed++
+			}
+			return nil
+		})
+		if err != nil {
+			return rotated, err
+		}
+		rotated += sealed
+		if len(batch) < rotationBatchSize {
+			return rotated, nil
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/internal/infrastructure/encryption"
+	"crypto/sha256"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newTestKeyring creates a keyring holding the master keys with the IDs, each derived from its ID.
+func newTestKeyring(t *testing.T, current string, ids ...string) *encryption.Keyring {
+	keys := make(map[string][]byte, len(ids))
+	for _, id := range ids {
+		key := sha256.Sum256([]byte(id))
+		keys[id] = key[:]
+	}
+	keyring, err := encryption.NewKeyring(current, keys)
+	require.NoError(t, err)
+	return keyring
+}
+
+func setupEncryptedColumnsDB(t *testing.T) *gorm.DB {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&database.WebhookEndpointModel{}))
+	t.Cleanup(func() { database.SetColumnKeyring(nil) })
+	return db
+}
+
+func saveTestEndpoint(t *testing.T, db *gorm.DB, id, secret string) {
+	now := time.Now()
+	require.NoError(t, db.Create(&database.WebhookEndpointModel{
+		ID: id, MerchantID: "00000000-0000-0000-0000-000000000001", URL: "https://example.com/hook",
+		Events: "[]", Secret: secret, Status: "active", RetryBackoff: "exponential",
+		CreatedAt: now, UpdatedAt: now,
+	}).Error)
+}
+
+func storedSecret(t *testing.T, db *gorm.DB, id string) string {
+	var secret string
+	require.NoError(t, db.Table("webhook_endpoints").Where("id = ?", id).Select("secret").Scan(&secret).Error)
+	return secret
+}
+
+func loadedSecret(t *testing.T, db *gorm.DB, id string) string {
+	var model database.WebhookEndpointModel
+	require.NoError(t, db.First(&model, "id = ?", id).Error)
+	return model.Secret
+}
+
+func TestEncryptedColumns_SealedAtRest(t *testing.T) {
+	db := setupEncryptedColumnsDB(t)
+
+	saveTestEndpoint(t, db, "11111111-1111-1111-1111-111111111111", "whsec_plain")
+
+	database.SetColumnKeyring(newTestKeyring(t, "k1", "k1"))
+	saveTestEndpoint(t, db, "22222222-2222-2222-2222-222222222222", "whsec_sealed")
+
+	stored := storedSecret(t, db, "22222222-2222-2222-2222-222222222222")
+	assert.True(t, strings.HasPrefix(stored, "enc:v1:k1:"))
+	assert.NotContains(t, stored, "whsec_sealed")
+	assert.Equal(t, "whsec_sealed", loadedSecret(t, db, "22222222-2222-2222-2222-222222222222"))
+	assert.Equal(t, "whsec_plain", loadedSecret(t, db, "11111111-1111-1111-1111-111111111111"),
+		"values stored before encryption was enabled stay readable")
+
+	database.SetColumnKeyring(nil)
+	var model database.WebhookEndpointModel
+	require.Error(t, db.First(&model, "id = ?", "22222222-2222-2222-2222-222222222222").Error)
+}
+
+func TestRotateEncryptedColumns(t *testing.T) {
+	db := setupEncryptedColumnsDB(t)
+	ctx := context.Background()
+
+	saveTestEndpoint(t, db, "11111111-1111-1111-1111-111111111111", "whsec_plain")
+	database.SetColumnKeyring(newTestKeyring(t, "k1", "k1"))
+	saveTestEndpoint(t, db, "22222222-2222-2222-2222-222222222222", "whsec_old_key")
+
+	rotated := newTestKeyring(t, "k2", "k1", "k2")
+	database.SetColumnKeyring(rotated)
+
+	counts, err := database.RotateEncryptedColumns(ctx, db, rotated, true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, counts["webhook_endpoints.secret"])
+	assert.Equal(t, "whsec_plain", storedSecret(t, db, "11111111-1111-1111-1111-111111111111"))
+
+	counts, err = database.RotateEncryptedColumns(ctx, db, rotated, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, counts["webhook_endpoints.secret"])
+	assert.Equal(t, 0, counts["users.totp_secret"])
+
+	for id, secret := range map[string]string{
+		"11111111-1111-1111-1111-111111111111": "whsec_plain",
+		"22222222-2222-2222-2222-222222222222": "whsec_old_key",
+	} {
+		assert.True(t, strings.HasPrefix(storedSecret(t, db, id), "enc:v1:k2:"))
+		assert.Equal(t, secret, loadedSecret(t, db, id))
+	}
+
+	counts, err = database.RotateEncryptedColumns(ctx, db, rotated, true)
+	require.NoError(t, err)
+	assert.Equal(t, 0, counts["webhook_endpoints.secret"])
+
+	// Once every value is sealed with k2, k1 can be removed from the key file.
+	database.SetColumnKeyring(newTestKeyring(t, "k2", "k2"))
+	assert.Equal(t, "whsec_plain", loadedSecret(t, db, "11111111-1111-1111-1111-111111111111"))
+}
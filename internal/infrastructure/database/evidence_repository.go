@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/evidence"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// EvidenceRepository implements the evidence.Repository interface using GORM.
+type EvidenceRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewEvidenceRepository creates a new evidence repository.
+func NewEvidenceRepository(db *gorm.DB, logger *zap.Logger) *EvidenceRepository {
+	return &EvidenceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// SaveSnapshot persists a new checkout snapshot.
+func (r *EvidenceRepository) SaveSnapshot(ctx context.Context, snapshot *evidence.CheckoutSnapshot) error {
+	model := &CheckoutSnapshotModel{
+		ID:         snapshot.ID,
+		InvoiceID:  snapshot.InvoiceID,
+		MerchantID: snapshot.MerchantID,
+		Content:    string(snapshot.Content),
+		SHA256:     snapshot.SHA256,
+		CapturedAt: snapshot.CapturedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save checkout snapshot: %w", err)
+	}
+	return nil
+}
+
+// FindLatestSnapshot retrieves the latest snapshot of an invoice, or nil when it has none.
+func (r *EvidenceRepository) FindLatestSnapshot(
+	ctx context.Context,
+	invoiceID string,
+) (*evidence.CheckoutSnapshot, error) {
+	var model CheckoutSnapshotModel
+	if err := r.db.WithContext(ctx).
+		Where("invoice_id = ?", invoiceID).
+		Order("captured_at DESC").
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil //nolint:nilnil // An absent snapshot is not an error
+		}
+		return nil, fmt.Errorf("failed to find checkout snapshot: %w", err)
+	}
+	return r.toDomain(&model), nil
+}
+
+// ListSnapshots retrieves the snapshots of an invoice, oldest first.
+func (r *EvidenceRepository) ListSnapshots(
+	ctx context.Context,
+	invoiceID string,
+) ([]*evidence.CheckoutSnapshot, error) {
+	var models []CheckoutSnapshotModel
+	if err := r.db.WithContext(ctx).
+		Where("invoice_id = ?", invoiceID).
+		Order("captured_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list checkout snapshots: %w", err)
+	}
+
+	snapshots := make([]*evidence.CheckoutSnapshot, len(models))
+	for i := range models {
+		snapshots[i] = r.toDomain(&models[i])
+	}
+	return snapshots, nil
+}
+
+// toDomain converts a checkout snapshot model to the domain entity.
+func (r *EvidenceRepository) toDomain(model *CheckoutSnapshotModel) *evidence.CheckoutSnapshot {
+	return &evidence.CheckoutSnapshot{
+		ID:         model.ID,
+		InvoiceID:  model.InvoiceID,
+		MerchantID: model.MerchantID,
+		Content:    []byte(model.Content),
+		SHA256:     model.SHA256,
+		CapturedAt: model.CapturedAt.UTC(),
+	}
+}
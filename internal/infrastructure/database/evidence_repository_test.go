@@ -0,0 +1,48 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/evidence"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestEvidenceRepository(t *testing.T) {
+	repo := database.NewEvidenceRepository(setupTestDB(t), zap.NewNop())
+	ctx := context.Background()
+	invoiceID := uuid.New().String()
+	merchantID := uuid.New().String()
+	viewedAt := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+
+	latest, err := repo.FindLatestSnapshot(ctx, invoiceID)
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+
+	first := evidence.NewCheckoutSnapshot(invoiceID, merchantID, []byte(`{"amount":"100"}`), viewedAt)
+	second := evidence.NewCheckoutSnapshot(invoiceID, merchantID, []byte(`{"amount":"101"}`), viewedAt.Add(time.Minute))
+	other := evidence.NewCheckoutSnapshot(uuid.New().String(), merchantID, []byte(`{"amount":"5"}`), viewedAt)
+	require.NoError(t, repo.SaveSnapshot(ctx, second))
+	require.NoError(t, repo.SaveSnapshot(ctx, first))
+	require.NoError(t, repo.SaveSnapshot(ctx, other))
+
+	latest, err = repo.FindLatestSnapshot(ctx, invoiceID)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, second.ID, latest.ID)
+	assert.Equal(t, second.SHA256, latest.SHA256)
+	assert.JSONEq(t, `{"amount":"101"}`, string(latest.Content))
+	assert.True(t, viewedAt.Add(time.Minute).Equal(latest.CapturedAt))
+
+	snapshots, err := repo.ListSnapshots(ctx, invoiceID)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, first.ID, snapshots[0].ID)
+	assert.Equal(t, second.ID, snapshots[1].ID)
+	assert.Equal(t, merchantID, snapshots[0].MerchantID)
+}
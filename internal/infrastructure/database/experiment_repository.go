@@ -0,0 +1,328 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/experiment"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// variantRecord is the stored form of an experiment variant.
+type variantRecord struct {
+	Key           string            `json:"key"`
+	Weight        int               `json:"weight"`
+	Copy          map[string]string `json:"copy,omitempty"`
+	Layout        string            `json:"layout"`
+	CurrencyOrder []string          `json:"currency_order,omitempty"`
+}
+
+// ExperimentRepository implements the experiment.Repository interface using GORM.
+type ExperimentRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewExperimentRepository creates a new experiment repository.
+func NewExperimentRepository(db *gorm.DB, logger *zap.Logger) experiment.Repository {
+	return &ExperimentRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a new experiment to the database.
+func (r *ExperimentRepository) Save(ctx context.Context, e *experiment.Experiment) error {
+	model, err := r.toModel(e)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save experiment: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds an experiment by ID.
+func (r *ExperimentRepository) FindByID(ctx context.Context, id string) (*experiment.Experiment, error) {
+	e, err := r.findOne(r.db.WithContext(ctx).Scopes(merchantScope(ctx)).Where("id = ?", id))
+	if errors.Is(err, experiment.ErrExperimentNotFound) {
+		reportCrossTenantAccess(ctx, r.db, r.logger, &ExperimentModel{}, "experiment", id)
+	}
+	return e, err
+}
+
+// FindRunning finds the merchant's running experiment.
+func (r *ExperimentRepository) FindRunning(ctx context.Context, merchantID string) (*experiment.Experiment, error) {
+	return r.findOne(r.db.WithContext(ctx).
+		Where("merchant_id = ? AND status = ?", merchantID, experiment.StatusRunning.String()).
+		Order("started_at DESC"))
+}
+
+// ListByMerchant lists a merchant's experiments, newest first.
+func (r *ExperimentRepository) ListByMerchant(
+	ctx context.Context,
+	merchantID string,
+) ([]*experiment.Experiment, error) {
+	var models []ExperimentModel
+	if err := r.db.WithContext(ctx).
+		Where("merchant_id = ?", merchantID).
+		Order("created_at DESC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list experiments: %w", err)
+	}
+
+	experiments := make([]*experiment.Experiment, len(models))
+	for i := range models {
+		e, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert experiment model to domain: %w", err)
+		}
+		experiments[i] = e
+	}
+	return experiments, nil
+}
+
+// Update updates the status of an existing experiment. Variants cannot change once defined, so results
+// always describe the variants that were shown.
+func (r *ExperimentRepository) Update(ctx context.Context, e *experiment.Experiment) error {
+	result := r.db.WithContext(ctx).Model(&ExperimentModel{}).
+		Scopes(merchantScope(ctx)).
+		Where("id = ?", e.ID()).
+		Updates(map[string]interface{}{
+			"status":     e.Status().String(),
+			"started_at": e.StartedAt(),
+			"stopped_at": e.StoppedAt(),
+			"updated_at": e.UpdatedAt(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update experiment: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		reportCrossTenantAccess(ctx, r.db, r.logger, &ExperimentModel{}, "experiment", e.ID())
+		return experiment.ErrExperimentNotFound
+	}
+	return nil
+}
+
+// findOne finds a single experiment matching the query.
+func (r *ExperimentRepository) findOne(query *gorm.DB) (*experiment.Experiment, error) {
+	var model ExperimentModel
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, experiment.ErrExperimentNotFound
+		}
+		return nil, fmt.Errorf("failed to find experiment: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// toModel converts a domain experiment to a database model.
+func (r *ExperimentRepository) toModel(e *experiment.Experiment) (*ExperimentModel, error) {
+	records := make([]variantRecord, len(e.Variants()))
+	for i, variant := range e.Variants() {
+		records[i] = variantRecord{
+			Key:           variant.Key,
+			Weight:        variant.Weight,
+			Copy:          variant.Copy,
+			Layout:        variant.Layout.String(),
+			CurrencyOrder: variant.CurrencyOrder,
+		}
+	}
+	variants, err := json.Marshal(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal experiment variants: %w", err)
+	}
+
+	return &ExperimentModel{
+		ID:         e.ID(),
+		MerchantID: e.MerchantID(),
+		Name:       e.Name(),
+		Status:     e.Status().String(),
+		Variants:   string(variants),
+		StartedAt:  e.StartedAt(),
+		StoppedAt:  e.StoppedAt(),
+		CreatedAt:  e.CreatedAt(),
+		UpdatedAt:  e.UpdatedAt(),
+	}, nil
+}
+
+// toDomain converts a database model to a domain experiment.
+func (r *ExperimentRepository) toDomain(model *ExperimentModel) (*experiment.Experiment, error) {
+	var records []variantRecord
+	if err := json.Unmarshal([]byte(model.Variants), &records); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal experiment variants: %w", err)
+	}
+	variants := make([]experiment.Variant, len(records))
+	for i, record := range records {
+		variants[i] = experiment.Variant{
+			Key:           record.Key,
+			Weight:        record.Weight,
+			Copy:          record.Copy,
+			Layout:        experiment.Layout(record.Layout),
+			CurrencyOrder: record.CurrencyOrder,
+		}
+	}
+
+	return experiment.RestoreExperiment(
+		model.ID,
+		model.MerchantID,
+		model.Name,
+		experiment.Status(model.Status),
+		variants,
+		model.StartedAt,
+		model.StoppedAt,
+		model.CreatedAt,
+		model.UpdatedAt,
+	)
+}
+
+// ExperimentAssignmentRepository implements the experiment.AssignmentRepository interface using GORM.
+type ExperimentAssignmentRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewExperimentAssignmentRepository creates a new experiment assignment repository.
+func NewExperimentAssignmentRepository(db *gorm.DB, logger *zap.Logger) experiment.AssignmentRepository {
+	return &ExperimentAssignmentRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Assign records an assignment unless the experiment already assigned the invoice's checkout, returning the
+// assignment in effect.
+func (r *ExperimentAssignmentRepository) Assign(
+	ctx context.Context,
+	assignment *experiment.Assignment,
+) (*experiment.Assignment, error) {
+	model := &ExperimentAssignmentModel{
+		ExperimentID: assignment.ExperimentID,
+		InvoiceID:    assignment.InvoiceID,
+		SessionID:    assignment.SessionID,
+		Variant:      assignment.Variant,
+		AssignedAt:   assignment.AssignedAt,
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(model).Error; err != nil {
+		return nil, fmt.Errorf("failed to save experiment assignment: %w", err)
+	}
+
+	var existing ExperimentAssignmentModel
+	if err := r.db.WithContext(ctx).
+		Where("experiment_id = ? AND invoice_id = ?", assignment.ExperimentID, assignment.InvoiceID).
+		First(&existing).Error; err != nil {
+		return nil, fmt.Errorf("failed to find experiment assignment: %w", err)
+	}
+	return r.toDomain(&existing)
+}
+
+// MarkConverted records that the invoice of unconverted assignments was paid.
+func (r *ExperimentAssignmentRepository) MarkConverted(
+	ctx context.Context,
+	invoiceID string,
+	amount decimal.Decimal,
+	currency string,
+	at time.Time,
+) error {
+	if err := r.db.WithContext(ctx).Model(&ExperimentAssignmentModel{}).
+		Where("invoice_id = ? AND converted_at IS NULL", invoiceID).
+		Updates(map[string]interface{}{
+			"converted_at": at,
+			"amount":       amount.String(),
+			"currency":     currency,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to record experiment conversion: %w", err)
+	}
+	return nil
+}
+
+// Results summarizes the assignments of an experiment by variant.
+func (r *ExperimentAssignmentRepository) Results(
+	ctx context.Context,
+	experimentID string,
+) ([]experiment.VariantResult, error) {
+	var counts []struct {
+		Variant     string
+		Sessions    int
+		Checkouts   int
+		Conversions int
+	}
+	if err := r.db.WithContext(ctx).Model(&ExperimentAssignmentModel{}).
+		Select("variant, COUNT(DISTINCT session_id) AS sessions, COUNT(*) AS checkouts, "+
+			"COUNT(converted_at) AS conversions").
+		Where("experiment_id = ?", experimentID).
+		Group("variant").
+		Order("variant").
+		Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("failed to count experiment assignments: %w", err)
+	}
+
+	var volumes []struct {
+		Variant  string
+		Currency string
+		Volume   string
+	}
+	if err := r.db.WithContext(ctx).Model(&ExperimentAssignmentModel{}).
+		Select("variant, currency, SUM(amount) AS volume").
+		Where("experiment_id = ? AND converted_at IS NOT NULL", experimentID).
+		Group("variant, currency").
+		Scan(&volumes).Error; err != nil {
+		return nil, fmt.Errorf("failed to sum experiment conversions: %w", err)
+	}
+
+	results := make([]experiment.VariantResult, len(counts))
+	index := make(map[string]int, len(counts))
+	for i, count := range counts {
+		results[i] = experiment.VariantResult{
+			Variant:     count.Variant,
+			Sessions:    count.Sessions,
+			Checkouts:   count.Checkouts,
+			Conversions: count.Conversions,
+			Volume:      map[string]decimal.Decimal{},
+		}
+		index[count.Variant] = i
+	}
+	for _, volume := range volumes {
+		i, ok := index[volume.Variant]
+		if !ok {
+			continue
+		}
+		amount, err := decimal.NewFromString(volume.Volume)
+		if err != nil {
+			return nil, fmt.Errorf("invalid experiment conversion volume %q: %w", volume.Volume, err)
+		}
+		results[i].Volume[volume.Currency] = amount
+	}
+	return results, nil
+}
+
+// toDomain converts a database model to a domain assignment.
+func (r *ExperimentAssignmentRepository) toDomain(model *ExperimentAssignmentModel) (*experiment.Assignment, error) {
+	assignment := &experiment.Assignment{
+		ExperimentID: model.ExperimentID,
+		InvoiceID:    model.InvoiceID,
+		SessionID:    model.SessionID,
+		Variant:      model.Variant,
+		AssignedAt:   model.AssignedAt,
+		ConvertedAt:  model.ConvertedAt,
+	}
+	if model.Amount != nil {
+		amount, err := decimal.NewFromString(*model.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid experiment conversion amount %q: %w", *model.Amount, err)
+		}
+		assignment.Amount = amount
+	}
+	if model.Currency != nil {
+		assignment.Currency = *model.Currency
+	}
+	return assignment, nil
+}
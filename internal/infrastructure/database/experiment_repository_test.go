@@ -0,0 +1,103 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/experiment"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestExperimentRepository(t *testing.T) {
+	repo := database.NewExperimentRepository(setupTestDB(t), zap.NewNop())
+	ctx := context.Background()
+	merchantID := uuid.New().String()
+
+	created, err := experiment.NewExperiment(uuid.New().String(), merchantID, "Stablecoins first", []experiment.Variant{
+		{Key: "control", Weight: 1},
+		{
+			Key:           "stablecoins",
+			Weight:        1,
+			Copy:          map[string]string{"checkout.pay_with": "Pay with a stablecoin"},
+			Layout:        experiment.LayoutPaymentFirst,
+			CurrencyOrder: []string{"USDT"},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, created))
+
+	_, err = repo.FindRunning(ctx, merchantID)
+	require.ErrorIs(t, err, experiment.ErrExperimentNotFound)
+
+	require.NoError(t, created.Start())
+	require.NoError(t, repo.Update(ctx, created))
+
+	running, err := repo.FindRunning(ctx, merchantID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID(), running.ID())
+	assert.Equal(t, experiment.StatusRunning, running.Status())
+	require.NotNil(t, running.StartedAt())
+	assert.Equal(t, created.Variants(), running.Variants())
+
+	listed, err := repo.ListByMerchant(ctx, merchantID)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+
+	_, err = repo.FindByID(ctx, uuid.New().String())
+	require.ErrorIs(t, err, experiment.ErrExperimentNotFound)
+}
+
+func TestExperimentAssignmentRepository(t *testing.T) {
+	repo := database.NewExperimentAssignmentRepository(setupTestDB(t), zap.NewNop())
+	ctx := context.Background()
+	experimentID := uuid.New().String()
+	firstInvoice, secondInvoice, thirdInvoice := uuid.New().String(), uuid.New().String(), uuid.New().String()
+	assignedAt := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+
+	assign := func(invoiceID, sessionID, variant string) *experiment.Assignment {
+		assignment, err := repo.Assign(ctx, &experiment.Assignment{
+			ExperimentID: experimentID,
+			InvoiceID:    invoiceID,
+			SessionID:    sessionID,
+			Variant:      variant,
+			AssignedAt:   assignedAt,
+		})
+		require.NoError(t, err)
+		return assignment
+	}
+	assign(firstInvoice, "session-1", "control")
+	assign(secondInvoice, "session-1", "control")
+	assign(thirdInvoice, "session-2", "stablecoins")
+
+	again := assign(firstInvoice, "session-3", "stablecoins")
+	assert.Equal(t, "control", again.Variant, "an invoice keeps its first assignment")
+	assert.Equal(t, "session-1", again.SessionID)
+
+	require.NoError(t, repo.MarkConverted(ctx, firstInvoice, decimal.RequireFromString("25.50"), "USD", assignedAt))
+	require.NoError(t, repo.MarkConverted(ctx, secondInvoice, decimal.RequireFromString("10"), "USD", assignedAt))
+	require.NoError(t, repo.MarkConverted(ctx, firstInvoice, decimal.RequireFromString("99"), "USD", assignedAt),
+		"a conversion is only counted once")
+
+	results, err := repo.Results(ctx, experimentID)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	control := results[0]
+	assert.Equal(t, "control", control.Variant)
+	assert.Equal(t, 1, control.Sessions)
+	assert.Equal(t, 2, control.Checkouts)
+	assert.Equal(t, 2, control.Conversions)
+	assert.True(t, decimal.RequireFromString("35.5").Equal(control.Volume["USD"]), control.Volume["USD"].String())
+
+	stablecoins := results[1]
+	assert.Equal(t, "stablecoins", stablecoins.Variant)
+	assert.Equal(t, 1, stablecoins.Checkouts)
+	assert.Zero(t, stablecoins.Conversions)
+	assert.Empty(t, stablecoins.Volume)
+}
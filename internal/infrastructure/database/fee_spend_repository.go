@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/fee"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FeeSpendRepository implements the fee.SpendRepository interface using GORM.
+type FeeSpendRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewFeeSpendRepository creates a new fee spend repository.
+func NewFeeSpendRepository(db *gorm.DB, logger *zap.Logger) fee.SpendRepository {
+	return &FeeSpendRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save records a fee spend, ignoring a transfer already recorded for the same purpose and reference.
+func (r *FeeSpendRepository) Save(ctx context.Context, spend *fee.Spend) error {
+	model := &FeeSpendModel{
+		ID:          spend.ID(),
+		Purpose:     string(spend.Purpose()),
+		Reference:   spend.Reference(),
+		Network:     spend.Network().String(),
+		TxHash:      spend.TxHash(),
+		Amount:      spend.Amount().String(),
+		FeeCurrency: spend.FeeCurrency(),
+		CreatedAt:   spend.CreatedAt(),
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save fee spend: %w", err)
+	}
+
+	return nil
+}
+
+// ListBetween lists the fee spends recorded in [from, to), oldest first.
+func (r *FeeSpendRepository) ListBetween(ctx context.Context, from, to time.Time) ([]*fee.Spend, error) {
+	var models []FeeSpendModel
+	if err := r.db.WithContext(ctx).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Order("created_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list fee spends: %w", err)
+	}
+
+	spends := make([]*fee.Spend, len(models))
+	for i, model := range models {
+		amount, err := decimal.NewFromString(model.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee spend amount %q: %w", model.Amount, err)
+		}
+		spend, err := fee.RestoreSpend(
+			model.ID,
+			fee.Purpose(model.Purpose),
+			model.Reference,
+			shared.BlockchainNetwork(model.Network),
+			model.TxHash,
+			amount,
+			model.FeeCurrency,
+			model.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert fee spend model to domain: %w", err)
+		}
+		spends[i] = spend
+	}
+	return spends, nil
+}
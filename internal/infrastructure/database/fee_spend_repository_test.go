@@ -0,0 +1,41 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/fee"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFeeSpendRepository_SaveIgnoresRepeatedTransfers(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewFeeSpendRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	for _, amount := range []string{"13.4", "99"} {
+		spend, err := fee.NewSpend(
+			uuid.NewString(), fee.PurposePayout, "payout-1", shared.NetworkTron, treasuryTxHash,
+			decimal.RequireFromString(amount),
+		)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, spend))
+	}
+
+	spends, err := repo.ListBetween(ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, spends, 1)
+	assert.Equal(t, "13.4", spends[0].Amount().String())
+	assert.Equal(t, "TRX", spends[0].FeeCurrency())
+
+	spends, err = repo.ListBetween(ctx, time.Now().Add(time.Hour), time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, spends)
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/geoblock"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GeoBlockRepository implements the geoblock.Repository interface using GORM.
+type GeoBlockRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewGeoBlockRepository creates a new geo-block repository.
+func NewGeoBlockRepository(db *gorm.DB, logger *zap.Logger) geoblock.Repository {
+	return &GeoBlockRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// RecordBlock counts a refused attempt to view an invoice's checkout from a country, returning whether it was the
+// first from that country.
+func (r *GeoBlockRepository) RecordBlock(
+	ctx context.Context,
+	invoiceID, merchantID, country string,
+	at time.Time,
+) (bool, error) {
+	model := &GeoBlockModel{
+		InvoiceID:      invoiceID,
+		Country:        country,
+		MerchantID:     merchantID,
+		Attempts:       1,
+		FirstBlockedAt: at,
+		LastBlockedAt:  at,
+	}
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(model)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to record geo-block: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	if err := r.db.WithContext(ctx).Model(&GeoBlockModel{}).
+		Where("invoice_id = ? AND country = ?", invoiceID, country).
+		UpdateColumns(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_blocked_at": at,
+		}).Error; err != nil {
+		return false, fmt.Errorf("failed to count geo-block: %w", err)
+	}
+	return false, nil
+}
+
+// ListBlocks retrieves the blocks of an invoice's checkout, by country.
+func (r *GeoBlockRepository) ListBlocks(ctx context.Context, invoiceID string) ([]*geoblock.Block, error) {
+	var models []GeoBlockModel
+	if err := r.db.WithContext(ctx).Scopes(merchantScope(ctx)).
+		Where("invoice_id = ?", invoiceID).
+		Order("country").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list geo-blocks: %w", err)
+	}
+
+	blocks := make([]*geoblock.Block, 0, len(models))
+	for _, model := range models {
+		blocks = append(blocks, &geoblock.Block{
+			InvoiceID:      model.InvoiceID,
+			MerchantID:     model.MerchantID,
+			Country:        model.Country,
+			Attempts:       model.Attempts,
+			FirstBlockedAt: model.FirstBlockedAt,
+			LastBlockedAt:  model.LastBlockedAt,
+		})
+	}
+	return blocks, nil
+}
+
+// SaveOverride persists a new override.
+func (r *GeoBlockRepository) SaveOverride(ctx context.Context, override *geoblock.Override) error {
+	model := &GeoOverrideModel{
+		ID:         override.ID,
+		InvoiceID:  override.InvoiceID,
+		MerchantID: override.MerchantID,
+		TokenHash:  override.TokenHash,
+		IssuedBy:   override.IssuedBy,
+		Reason:     override.Reason,
+		IssuedAt:   override.IssuedAt,
+		ExpiresAt:  override.ExpiresAt,
+		UsedAt:     override.UsedAt,
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save geo-block override: %w", err)
+	}
+	return nil
+}
+
+// FindOverride retrieves an override by the hash of its token.
+func (r *GeoBlockRepository) FindOverride(ctx context.Context, tokenHash string) (*geoblock.Override, error) {
+	var model GeoOverrideModel
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, geoblock.ErrOverrideNotFound
+		}
+		return nil, fmt.Errorf("failed to find geo-block override: %w", err)
+	}
+
+	return &geoblock.Override{
+		ID:         model.ID,
+		InvoiceID:  model.InvoiceID,
+		MerchantID: model.MerchantID,
+		TokenHash:  model.TokenHash,
+		IssuedBy:   model.IssuedBy,
+		Reason:     model.Reason,
+		IssuedAt:   model.IssuedAt,
+		ExpiresAt:  model.ExpiresAt,
+		UsedAt:     model.UsedAt,
+	}, nil
+}
+
+// MarkOverrideUsed records the first use of an override, returning whether it had not been used before. The
+// condition on used_at makes concurrent first uses record only one.
+func (r *GeoBlockRepository) MarkOverrideUsed(ctx context.Context, id string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&GeoOverrideModel{}).
+		Where("id = ? AND used_at IS NULL", id).
+		UpdateColumn("used_at", at)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to mark geo-block override used: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,68 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/geoblock"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGeoBlockRepository(t *testing.T) {
+	repo := database.NewGeoBlockRepository(setupTestDB(t), zap.NewNop())
+	ctx := context.Background()
+	merchantID := uuid.New().String()
+	invoiceID := uuid.New().String()
+	blockedAt := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+
+	first, err := repo.RecordBlock(ctx, invoiceID, merchantID, "KP", blockedAt)
+	require.NoError(t, err)
+	assert.True(t, first)
+	first, err = repo.RecordBlock(ctx, invoiceID, merchantID, "KP", blockedAt.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, first, "a retry is not a first block")
+	_, err = repo.RecordBlock(ctx, invoiceID, merchantID, "IR", blockedAt)
+	require.NoError(t, err)
+
+	blocks, err := repo.ListBlocks(ctx, invoiceID)
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "IR", blocks[0].Country)
+	assert.Equal(t, "KP", blocks[1].Country)
+	assert.Equal(t, 2, blocks[1].Attempts)
+	assert.True(t, blockedAt.Add(time.Minute).Equal(blocks[1].LastBlockedAt))
+
+	override := &geoblock.Override{
+		ID:         uuid.New().String(),
+		InvoiceID:  invoiceID,
+		MerchantID: merchantID,
+		TokenHash:  geoblock.HashOverrideToken("gbo_token"),
+		IssuedBy:   "user-1",
+		IssuedAt:   blockedAt,
+		ExpiresAt:  blockedAt.Add(geoblock.DefaultOverrideTTL),
+	}
+	require.NoError(t, repo.SaveOverride(ctx, override))
+
+	_, err = repo.FindOverride(ctx, geoblock.HashOverrideToken("gbo_other"))
+	require.ErrorIs(t, err, geoblock.ErrOverrideNotFound)
+	found, err := repo.FindOverride(ctx, override.TokenHash)
+	require.NoError(t, err)
+	assert.Equal(t, override.ID, found.ID)
+	assert.Nil(t, found.UsedAt)
+
+	used, err := repo.MarkOverrideUsed(ctx, override.ID, blockedAt.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, used)
+	used, err = repo.MarkOverrideUsed(ctx, override.ID, blockedAt.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.False(t, used, "only the first use is recorded")
+	found, err = repo.FindOverride(ctx, override.TokenHash)
+	require.NoError(t, err)
+	require.NotNil(t, found.UsedAt)
+	assert.True(t, blockedAt.Add(time.Hour).Equal(*found.UsedAt))
+}
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IDSequenceRepository implements the shared.SequenceStore interface using GORM.
+type IDSequenceRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewIDSequenceRepository creates a new ID sequence repository.
+func NewIDSequenceRepository(db *gorm.DB, logger *zap.Logger) shared.SequenceStore {
+	return &IDSequenceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// NextSequence increments the counter of a prefix, kind and year in one statement, so concurrent callers,
+// including other instances, never receive the same number.
+func (r *IDSequenceRepository) NextSequence(
+	ctx context.Context,
+	prefix string,
+	kind shared.IDKind,
+	year int,
+) (int64, error) {
+	model := &IDSequenceModel{Prefix: prefix, Kind: string(kind), Year: year, Value: 1}
+	err := r.db.WithContext(ctx).Clauses(
+		clause.OnConflict{
+			Columns: []clause.Column{{Name: "prefix"}, {Name: "kind"}, {Name: "year"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"value": gorm.Expr("id_sequences.value + 1"),
+			}),
+		},
+		clause.Returning{Columns: []clause.Column{{Name: "value"}}},
+	).Create(model).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment %s sequence: %w", kind, err)
+	}
+
+	return model.Value, nil
+}
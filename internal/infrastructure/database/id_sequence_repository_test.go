@@ -0,0 +1,39 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestIDSequenceRepository_NextSequence(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewIDSequenceRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	next := func(prefix string, kind shared.IDKind, year int) int64 {
+		value, err := repo.NextSequence(ctx, prefix, kind, year)
+		require.NoError(t, err)
+		return value
+	}
+
+	assert.Equal(t, int64(1), next("550E8400", shared.IDKindInvoice, 2026))
+	assert.Equal(t, int64(2), next("550E8400", shared.IDKindInvoice, 2026))
+	assert.Equal(t, int64(3), next("550E8400", shared.IDKindInvoice, 2026))
+
+	// Every prefix, kind and year has its own counter
+	assert.Equal(t, int64(1), next("7C9E6679", shared.IDKindInvoice, 2026))
+	assert.Equal(t, int64(1), next("550E8400", shared.IDKindRefund, 2026))
+	assert.Equal(t, int64(1), next("550E8400", shared.IDKindInvoice, 2027))
+	assert.Equal(t, int64(1), next("", shared.IDKindPayment, 2026))
+
+	generator := shared.NewSequentialIDGenerator(repo)
+	id, err := generator.NewID(ctx, shared.IDKindSettlement, "7c9e6679-7425-40de-944b-e07fc1f90ae7")
+	require.NoError(t, err)
+	assert.Regexp(t, `^7C9E6679-SET-\d{4}-000001$`, id)
+}
@@ -0,0 +1,149 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/merchant"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// InvitationRepository implements the merchant.InvitationRepository interface using GORM.
+type InvitationRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewInvitationRepository creates a new team invitation repository.
+func NewInvitationRepository(db *gorm.DB, logger *zap.Logger) merchant.InvitationRepository {
+	return &InvitationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves an invitation to the database.
+func (r *InvitationRepository) Save(ctx context.Context, invitation *merchant.Invitation) error {
+	if err := r.db.WithContext(ctx).Create(r.toModel(invitation)).Error; err != nil {
+		return fmt.Errorf("failed to save invitation: %w", err)
+	}
+
+	r.logger.Debug("Invitation saved successfully",
+		zap.String("invitation_id", invitation.ID()),
+		zap.String("merchant_id", invitation.MerchantID()),
+	)
+
+	return nil
+}
+
+// FindByID finds an invitation by its ID.
+func (r *InvitationRepository) FindByID(ctx context.Context, id string) (*merchant.Invitation, error) {
+	var model InvitationModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, merchant.ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to find invitation: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// FindByTokenHash finds an invitation by its token hash.
+func (r *InvitationRepository) FindByTokenHash(
+	ctx context.Context,
+	hash *merchant.APIKeyHash,
+) (*merchant.Invitation, error) {
+	var model InvitationModel
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", hash.String()).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, merchant.ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to find invitation by token: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// FindByMerchantID finds all invitations for a merchant.
+func (r *InvitationRepository) FindByMerchantID(
+	ctx context.Context,
+	merchantID string,
+) ([]*merchant.Invitation, error) {
+	var models []InvitationModel
+	if err := r.db.WithContext(ctx).
+		Where("merchant_id = ?", merchantID).
+		Order("created_at DESC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find invitations for merchant: %w", err)
+	}
+
+	invitations := make([]*merchant.Invitation, len(models))
+	for i := range models {
+		invitation, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert invitation model to domain: %w", err)
+		}
+		invitations[i] = invitation
+	}
+
+	return invitations, nil
+}
+
+// Update updates an existing invitation.
+func (r *InvitationRepository) Update(ctx context.Context, invitation *merchant.Invitation) error {
+	if err := r.db.WithContext(ctx).Save(r.toModel(invitation)).Error; err != nil {
+		return fmt.Errorf("failed to update invitation: %w", err)
+	}
+
+	r.logger.Debug("Invitation updated successfully",
+		zap.String("invitation_id", invitation.ID()),
+		zap.String("status", string(invitation.Status())),
+	)
+
+	return nil
+}
+
+// toModel converts a domain invitation to a database model.
+func (r *InvitationRepository) toModel(invitation *merchant.Invitation) *InvitationModel {
+	return &InvitationModel{
+		ID:         invitation.ID(),
+		MerchantID: invitation.MerchantID(),
+		Email:      invitation.Email(),
+		Role:       string(invitation.Role()),
+		TokenHash:  invitation.TokenHash().String(),
+		InvitedBy:  invitation.InvitedBy(),
+		Status:     string(invitation.Status()),
+		ExpiresAt:  invitation.ExpiresAt(),
+		CreatedAt:  invitation.CreatedAt(),
+		AcceptedAt: invitation.AcceptedAt(),
+	}
+}
+
+// toDomain converts a database model to a domain invitation.
+func (r *InvitationRepository) toDomain(model *InvitationModel) (*merchant.Invitation, error) {
+	tokenHash, err := merchant.NewAPIKeyHashFromString(model.TokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token hash from string: %w", err)
+	}
+
+	invitation, err := merchant.RestoreInvitation(
+		model.ID,
+		model.MerchantID,
+		model.Email,
+		merchant.UserRole(model.Role),
+		tokenHash,
+		model.InvitedBy,
+		merchant.InvitationStatus(model.Status),
+		model.ExpiresAt,
+		model.CreatedAt,
+		model.AcceptedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore invitation: %w", err)
+	}
+
+	return invitation, nil
+}
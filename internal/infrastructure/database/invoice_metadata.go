@@ -0,0 +1,96 @@
+package database
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// writeInvoiceMetadata replaces the metadata index rows of an invoice with its current metadata.
+func writeInvoiceMetadata(tx *gorm.DB, invoiceID, merchantID string, metadata map[string]interface{}) error {
+	if err := tx.Where("invoice_id = ?", invoiceID).Delete(&InvoiceMetadataModel{}).Error; err != nil {
+		return fmt.Errorf("failed to clear invoice metadata index: %w", err)
+	}
+
+	index := InvoiceMetadataIndex(metadata)
+	rows := make([]InvoiceMetadataModel, 0, len(index))
+	for key, value := range index {
+		rows = append(rows, InvoiceMetadataModel{
+			InvoiceID:  invoiceID,
+			Key:        key,
+			MerchantID: merchantID,
+			Value:      value,
+		})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to index invoice metadata: %w", err)
+	}
+	return nil
+}
+
+// InvoiceMetadataIndex returns the values invoices are filtered by for their metadata, keyed by metadata key:
+// the top-level strings, numbers and booleans short enough to filter by.
+func InvoiceMetadataIndex(metadata map[string]interface{}) map[string]string {
+	index := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		indexed, ok := metadataIndexValue(value)
+		if !ok || len(key) > invoice.MaxMetadataKeyLength {
+			continue
+		}
+		index[key] = indexed
+	}
+	return index
+}
+
+// metadataIndexValue returns the value a metadata value is filtered by, and whether it can be.
+func metadataIndexValue(value interface{}) (string, bool) {
+	var indexed string
+	switch v := value.(type) {
+	case string:
+		indexed = v
+	case bool:
+		indexed = strconv.FormatBool(v)
+	case float64:
+		indexed = strconv.FormatFloat(v, 'f', -1, 64)
+	case int, int32, int64, uint, uint32, uint64:
+		indexed = fmt.Sprint(v)
+	default:
+		return "", false
+	}
+	return indexed, len(indexed) <= invoice.MaxFilterableMetadataValueLength
+}
+
+// ReindexInvoiceMetadata rebuilds the metadata index of the invoices from their stored metadata, for invoices
+// written without the repository, such as restored ones.
+func ReindexInvoiceMetadata(tx *gorm.DB, invoiceIDs []string) error {
+	if len(invoiceIDs) == 0 {
+		return nil
+	}
+	var models []InvoiceModel
+	if err := tx.Select("id", "merchant_id", "metadata").Where("id IN ?", invoiceIDs).Find(&models).Error; err != nil {
+		return fmt.Errorf("failed to load invoice metadata: %w", err)
+	}
+	for _, model := range models {
+		metadata, err := unmarshalSnapshot(model.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal metadata of invoice %s: %w", model.ID, err)
+		}
+		if err := writeInvoiceMetadata(tx, model.ID, model.MerchantID, metadata); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// metadataFilterQuery returns the subquery selecting the invoices whose metadata has the value at the key.
+func metadataFilterQuery(query *gorm.DB, merchantID, key, value string) *gorm.DB {
+	return query.Session(&gorm.Session{NewDB: true}).
+		Model(&InvoiceMetadataModel{}).
+		Select("invoice_id").
+		Where("merchant_id = ? AND key = ? AND value = ?", merchantID, key, value)
+}
@@ -0,0 +1,64 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestInvoiceRepository_MetadataFilters(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewInvoiceRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	save := func(id string, metadata map[string]interface{}) *invoice.Invoice {
+		inv := createTestInvoiceWithID(t, id)
+		inv.SetMetadata(metadata)
+		require.NoError(t, repo.Save(ctx, inv))
+		return inv
+	}
+	list := func(filters map[string]string) []string {
+		resp, err := repo.List(ctx, &invoice.ListInvoicesRequest{
+			MerchantID: "test-merchant-id", Limit: 10, Metadata: filters,
+		})
+		require.NoError(t, err)
+		ids := make([]string, 0, len(resp.Invoices))
+		for _, inv := range resp.Invoices {
+			ids = append(ids, inv.ID())
+		}
+		return ids
+	}
+
+	first := save("metadata-invoice-a", map[string]interface{}{"order_id": "A-1", "channel": "web", "priority": 2.0})
+	save("metadata-invoice-b", map[string]interface{}{"order_id": "B-2", "channel": "web"})
+	save("metadata-invoice-c", map[string]interface{}{"nested": map[string]interface{}{"order_id": "A-1"}})
+
+	require.Equal(t, []string{"metadata-invoice-a"}, list(map[string]string{"order_id": "A-1"}))
+	require.ElementsMatch(t, []string{"metadata-invoice-a", "metadata-invoice-b"},
+		list(map[string]string{"channel": "web"}))
+	require.Equal(t, []string{"metadata-invoice-b"}, list(map[string]string{"channel": "web", "order_id": "B-2"}))
+	require.Equal(t, []string{"metadata-invoice-a"}, list(map[string]string{"priority": "2"}))
+	require.Empty(t, list(map[string]string{"order_id": "C-3"}))
+
+	t.Run("Updated_Metadata_Is_Reindexed", func(t *testing.T) {
+		first.SetMetadata(map[string]interface{}{"order_id": "A-9"})
+		require.NoError(t, repo.Update(ctx, first))
+
+		require.Empty(t, list(map[string]string{"order_id": "A-1"}))
+		require.Equal(t, []string{"metadata-invoice-a"}, list(map[string]string{"order_id": "A-9"}))
+		require.Equal(t, []string{"metadata-invoice-b"}, list(map[string]string{"channel": "web"}))
+	})
+
+	t.Run("Reindex", func(t *testing.T) {
+		require.NoError(t, db.Where("invoice_id = ?", "metadata-invoice-b").
+			Delete(&database.InvoiceMetadataModel{}).Error)
+		require.Empty(t, list(map[string]string{"order_id": "B-2"}))
+
+		require.NoError(t, database.ReindexInvoiceMetadata(db, []string{"metadata-invoice-b"}))
+		require.Equal(t, []string{"metadata-invoice-b"}, list(map[string]string{"order_id": "B-2"}))
+	})
+}
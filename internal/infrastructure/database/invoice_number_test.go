@@ -0,0 +1,91 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestInvoiceRepository_Numbers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("issued invoices are numbered in order", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := database.NewInvoiceRepository(db, zap.NewNop())
+
+		for i := 1; i <= 3; i++ {
+			inv := createTestInvoiceWithID(t, fmt.Sprintf("invoice-%d", i))
+			require.NoError(t, repo.Save(ctx, inv))
+			assert.Equal(t, fmt.Sprintf("%06d", i), inv.Number())
+		}
+
+		// Saving again keeps the number
+		found, err := repo.FindByID(ctx, "invoice-2")
+		require.NoError(t, err)
+		assert.Equal(t, "000002", found.Number())
+		require.NoError(t, repo.Update(ctx, found))
+		assert.Equal(t, "000002", found.Number())
+	})
+
+	t.Run("yearly numbers carry the year", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := database.NewInvoiceRepositoryWithNumberReset(db, invoice.NumberResetYearly, zap.NewNop())
+
+		inv := createTestInvoice(t)
+		require.NoError(t, repo.Save(ctx, inv))
+		assert.Equal(t, fmt.Sprintf("%d-000001", time.Now().UTC().Year()), inv.Number())
+	})
+
+	t.Run("drafts are numbered when finalized", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := database.NewInvoiceRepository(db, zap.NewNop())
+
+		issued := createTestInvoiceWithID(t, "issued")
+		require.NoError(t, repo.Save(ctx, issued))
+
+		terms := createTestInvoiceWithID(t, "terms")
+		draft, err := invoice.NewDraftInvoice("draft", terms.MerchantID(), "Draft", "", terms.Items(),
+			terms.Pricing(), shared.CryptoCurrencyUSDT, shared.NetworkTron, terms.PaymentTolerance(),
+			invoice.NewInvoiceExpiration(30*time.Minute), nil)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, draft))
+		assert.Empty(t, draft.Number())
+
+		require.NoError(t, draft.Finalize(terms.PaymentAddress(), terms.ExchangeRate()))
+		require.NoError(t, invoice.NewInvoiceFSM(draft).Event(ctx, "finalize"))
+		require.NoError(t, repo.Update(ctx, draft))
+		assert.Equal(t, "000002", draft.Number())
+	})
+
+	t.Run("a failed save gives its number back", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := database.NewInvoiceRepository(db, zap.NewNop())
+
+		fail := true
+		require.NoError(t, db.Callback().Create().Before("gorm:create").Register("test:fail_invoices",
+			func(tx *gorm.DB) {
+				if fail && tx.Statement.Table == "invoices" {
+					_ = tx.AddError(errors.New("disk full"))
+				}
+			}))
+
+		rejected := createTestInvoiceWithID(t, "rejected")
+		require.Error(t, repo.Save(ctx, rejected))
+		assert.Empty(t, rejected.Number())
+
+		fail = false
+		accepted := createTestInvoiceWithID(t, "accepted")
+		require.NoError(t, repo.Save(ctx, accepted))
+		assert.Equal(t, "000001", accepted.Number())
+	})
+}
@@ -6,23 +6,39 @@ import (
 	"crypto-checkout/internal/domain/shared"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // InvoiceRepository implements the invoice.Repository interface using GORM.
+// Queries made with a merchant-scoped context only see that merchant's invoices.
 type InvoiceRepository struct {
-	db     *gorm.DB
-	mapper *InvoiceMapper
+	db          *gorm.DB
+	mapper      *InvoiceMapper
+	numberReset string
+	logger      *zap.Logger
 }
 
-// NewInvoiceRepository creates a new invoice repository.
-func NewInvoiceRepository(db *gorm.DB) invoice.Repository {
+// NewInvoiceRepository creates a new invoice repository numbering each merchant's invoices in one sequence.
+func NewInvoiceRepository(db *gorm.DB, logger *zap.Logger) invoice.Repository {
+	return NewInvoiceRepositoryWithNumberReset(db, invoice.NumberResetNever, logger)
+}
+
+// NewInvoiceRepositoryWithNumberReset creates a new invoice repository restarting the accounting numbers of
+// each merchant's invoices as the number reset says.
+func NewInvoiceRepositoryWithNumberReset(db *gorm.DB, numberReset string, logger *zap.Logger) invoice.Repository {
 	return &InvoiceRepository{
-		db:     db,
-		mapper: NewInvoiceMapper(),
+		db:          db,
+		mapper:      NewInvoiceMapper(),
+		numberReset: numberReset,
+		logger:      logger,
 	}
 }
 
@@ -31,6 +47,9 @@ func (r *InvoiceRepository) Save(ctx context.Context, inv *invoice.Invoice) erro
 	if inv == nil {
 		return shared.ErrInvalidInput
 	}
+	if !ownedByScopedMerchant(ctx, r.logger, "invoice", inv.ID(), inv.MerchantID()) {
+		return shared.ErrNotFound
+	}
 
 	// Convert domain model to database model
 	model := r.mapper.ToModel(inv)
@@ -41,14 +60,18 @@ func (r *InvoiceRepository) Save(ctx context.Context, inv *invoice.Invoice) erro
 
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := r.assignNumber(tx, inv, model); err != nil {
+				return err
+			}
 			// Save invoice (GORM will handle insert/update automatically)
 			if err := tx.Save(model).Error; err != nil {
 				return fmt.Errorf("failed to save invoice: %w", err)
 			}
-			return nil
+			return writeInvoiceMetadata(tx, inv.ID(), inv.MerchantID(), inv.Metadata())
 		})
 
 		if err == nil {
+			r.numbered(inv, model)
 			return nil
 		}
 
@@ -87,10 +110,21 @@ func (r *InvoiceRepository) FindByID(ctx context.Context, id string) (*invoice.I
 
 	var model InvoiceModel
 	err := r.db.WithContext(ctx).
+		Scopes(merchantScope(ctx)).
 		Where("id = ?", id).
 		First(&model).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if shared.ReplicaReadsAllowed(ctx) {
+				// A replica may not have caught up with an invoice created moments ago
+				return r.FindByID(shared.WithoutReplicaReads(ctx), id)
+			}
+			// Old terminal invoices are read through from the archive
+			archived, archiveErr := findArchivedInvoice(ctx, r.db, r.mapper, id)
+			if !errors.Is(archiveErr, shared.ErrNotFound) {
+				return archived, archiveErr
+			}
+			reportCrossTenantAccess(ctx, r.db, r.logger, &InvoiceModel{}, "invoice", id)
 			return nil, shared.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to find invoice: %w", err)
@@ -110,6 +144,7 @@ func (r *InvoiceRepository) FindByPaymentAddress(
 
 	var model InvoiceModel
 	err := r.db.WithContext(ctx).
+		Scopes(merchantScope(ctx)).
 		Where("payment_address = ?", address.String()).
 		First(&model).Error
 	if err != nil {
@@ -122,6 +157,86 @@ func (r *InvoiceRepository) FindByPaymentAddress(
 	return r.mapper.ToDomain(&model)
 }
 
+// FindByPaymentReference retrieves an invoice by the code of its payment reference.
+func (r *InvoiceRepository) FindByPaymentReference(ctx context.Context, code string) (*invoice.Invoice, error) {
+	if code == "" {
+		return nil, shared.ErrInvalidInput
+	}
+
+	var model InvoiceModel
+	err := r.db.WithContext(ctx).
+		Scopes(merchantScope(ctx)).
+		Where("payment_reference = ?", code).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to find invoice by payment reference: %w", err)
+	}
+
+	return r.mapper.ToDomain(&model)
+}
+
+// FindPayableByPaymentAddress retrieves the invoices paid to an address that still accept payments.
+func (r *InvoiceRepository) FindPayableByPaymentAddress(
+	ctx context.Context,
+	address *shared.PaymentAddress,
+) ([]*invoice.Invoice, error) {
+	if address == nil {
+		return nil, shared.ErrInvalidInput
+	}
+	payableStatuses := []string{
+		invoice.StatusCreated.String(),
+		invoice.StatusPending.String(),
+		invoice.StatusPartial.String(),
+	}
+
+	var models []InvoiceModel
+	err := r.db.WithContext(ctx).
+		Scopes(merchantScope(ctx)).
+		Where("payment_address = ? AND status IN ?", address.String(), payableStatuses).
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payable invoices by payment address: %w", err)
+	}
+
+	return r.mapper.ToDomainSlice(models)
+}
+
+// FindPaymentAddresses retrieves the payment addresses of the invoices on a network, whatever their status.
+// Invoices stored before their network was recorded are on their cryptocurrency's default network.
+func (r *InvoiceRepository) FindPaymentAddresses(
+	ctx context.Context,
+	network shared.BlockchainNetwork,
+) ([]string, error) {
+	var rows []struct {
+		PaymentAddress string
+		Network        *string
+		CryptoCurrency string
+	}
+	err := r.db.WithContext(ctx).
+		Model(&InvoiceModel{}).
+		Scopes(merchantScope(ctx)).
+		Distinct("payment_address", "network", "crypto_currency").
+		Where("payment_address IS NOT NULL AND (network = ? OR network IS NULL)", string(network)).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payment addresses: %w", err)
+	}
+
+	addresses := make([]string, 0, len(rows))
+	seen := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		if (row.Network == nil && legacyNetwork(row.CryptoCurrency) != network) || seen[row.PaymentAddress] {
+			continue
+		}
+		seen[row.PaymentAddress] = true
+		addresses = append(addresses, row.PaymentAddress)
+	}
+	return addresses, nil
+}
+
 // FindByStatus retrieves all invoices with the given status.
 func (r *InvoiceRepository) FindByStatus(
 	ctx context.Context,
@@ -129,6 +244,7 @@ func (r *InvoiceRepository) FindByStatus(
 ) ([]*invoice.Invoice, error) {
 	var models []InvoiceModel
 	err := r.db.WithContext(ctx).
+		Scopes(merchantScope(ctx)).
 		Where("status = ?", status.String()).
 		Find(&models).Error
 	if err != nil {
@@ -149,6 +265,7 @@ func (r *InvoiceRepository) FindActive(ctx context.Context) ([]*invoice.Invoice,
 
 	var models []InvoiceModel
 	err := r.db.WithContext(ctx).
+		Scopes(merchantScope(ctx)).
 		Where("status IN ?", activeStatuses).
 		Find(&models).Error
 	if err != nil {
@@ -158,6 +275,79 @@ func (r *InvoiceRepository) FindActive(ctx context.Context) ([]*invoice.Invoice,
 	return r.mapper.ToDomainSlice(models)
 }
 
+// List retrieves a page of invoices matching the request, newest first.
+// Pages are keyed by creation time and ID, so invoices created while a client pages through the list
+// do not shift the pages that follow.
+func (r *InvoiceRepository) List(
+	ctx context.Context,
+	req *invoice.ListInvoicesRequest,
+) (*invoice.ListInvoicesResponse, error) {
+	query := r.db.WithContext(ctx).
+		Model(&InvoiceModel{}).
+		Scopes(merchantScope(ctx)).
+		Where("merchant_id = ?", req.MerchantID)
+	query = applyInvoiceFilters(query, req)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count invoices: %w", err)
+	}
+
+	var models []InvoiceModel
+	if err := query.Scopes(keysetPage("created_at", req.Cursor, req.Offset, req.Limit)).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+	models, next := nextPage(models, req.Limit, func(model *InvoiceModel) (time.Time, string) {
+		return model.CreatedAt, model.ID
+	})
+
+	invoices, err := r.mapper.ToDomainSlice(models)
+	if err != nil {
+		return nil, err
+	}
+
+	return &invoice.ListInvoicesResponse{
+		Invoices:   invoices,
+		Total:      int(total),
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+		NextCursor: next,
+	}, nil
+}
+
+// applyInvoiceFilters narrows an invoice query to the request filters.
+func applyInvoiceFilters(query *gorm.DB, req *invoice.ListInvoicesRequest) *gorm.DB {
+	if req.Status != nil {
+		query = query.Where("status = ?", req.Status.String())
+	} else {
+		query = query.Where("status IN ?", []string{
+			invoice.StatusDraft.String(),
+			invoice.StatusCreated.String(),
+			invoice.StatusPending.String(),
+			invoice.StatusPartial.String(),
+			invoice.StatusConfirming.String(),
+		})
+	}
+	if req.CustomerID != nil {
+		query = query.Where("customer_id = ?", *req.CustomerID)
+	}
+	if req.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *req.CreatedAfter)
+	}
+	if req.CreatedBefore != nil {
+		query = query.Where("created_at <= ?", *req.CreatedBefore)
+	}
+	if req.Search != nil && *req.Search != "" {
+		pattern := "%" + strings.ToLower(*req.Search) + "%"
+		query = query.Where("(LOWER(title) LIKE ? OR LOWER(description) LIKE ?)", pattern, pattern)
+	}
+	for _, key := range slices.Sorted(maps.Keys(req.Metadata)) {
+		query = query.Where("id IN (?)", metadataFilterQuery(query, req.MerchantID, key, req.Metadata[key]))
+	}
+	return query
+}
+
 // FindExpired retrieves all invoices that should be expired (have passed expiration time but are still active).
 func (r *InvoiceRepository) FindExpired(ctx context.Context) ([]*invoice.Invoice, error) {
 	// Find active invoices that have passed their expiration time
@@ -170,7 +360,8 @@ func (r *InvoiceRepository) FindExpired(ctx context.Context) ([]*invoice.Invoice
 
 	var models []InvoiceModel
 	err := r.db.WithContext(ctx).
-		Where("status IN ? AND expires_at < ?", activeStatuses, time.Now().UTC()).
+		Scopes(merchantScope(ctx)).
+		Where("status IN ? AND expires_at < ?", activeStatuses, shared.Now().UTC()).
 		Find(&models).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to find expired invoices: %w", err)
@@ -179,23 +370,104 @@ func (r *InvoiceRepository) FindExpired(ctx context.Context) ([]*invoice.Invoice
 	return r.mapper.ToDomainSlice(models)
 }
 
+// FindRequotable retrieves the unpaid, unexpired invoices whose exchange rate has expired.
+func (r *InvoiceRepository) FindRequotable(ctx context.Context) ([]*invoice.Invoice, error) {
+	unpaidStatuses := []string{
+		invoice.StatusCreated.String(),
+		invoice.StatusPending.String(),
+	}
+
+	now := shared.Now().UTC()
+	var models []InvoiceModel
+	err := r.db.WithContext(ctx).
+		Scopes(merchantScope(ctx)).
+		Where("status IN ? AND amount_paid IS NULL AND rate_expires_at < ?", unpaidStatuses, now).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find invoices with expired rates: %w", err)
+	}
+
+	return r.mapper.ToDomainSlice(models)
+}
+
 // Update updates an existing invoice in the database.
 func (r *InvoiceRepository) Update(ctx context.Context, inv *invoice.Invoice) error {
 	if inv == nil {
 		return shared.ErrInvalidInput
 	}
+	if !ownedByScopedMerchant(ctx, r.logger, "invoice", inv.ID(), inv.MerchantID()) {
+		return shared.ErrNotFound
+	}
 
 	// Convert domain model to database model
 	model := r.mapper.ToModel(inv)
+	model.Version = inv.Version() + 1
 
 	// Update invoice (items are now stored as JSONB in the main table)
-	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// An archived invoice is no longer in the hot table and stays read-only
+		if err := rejectArchived(tx, inv.ID()); err != nil {
+			return err
+		}
+		// A draft being finalized is issued its number
+		if err := r.assignNumber(tx, inv, model); err != nil {
+			return err
+		}
+		// Only the version loaded is overwritten, so an update made since then is not lost
+		result := tx.Model(model).Where("version = ?", inv.Version()).Select("*").Updates(model)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return invoice.ErrConcurrentUpdate
+		}
+		return writeInvoiceMetadata(tx, inv.ID(), inv.MerchantID(), inv.Metadata())
+	})
+	if err != nil {
 		return fmt.Errorf("failed to update invoice in transaction: %w", err)
 	}
 
+	r.numbered(inv, model)
+	inv.SetVersion(model.Version)
+	return nil
+}
+
+// assignNumber allocates the next accounting number of the merchant to an issued invoice that has none, in the
+// transaction saving it: the counter row stays locked until the transaction ends, so concurrent saves queue for
+// it, and a save that fails rolls the counter back, leaving no gap.
+func (r *InvoiceRepository) assignNumber(tx *gorm.DB, inv *invoice.Invoice, model *InvoiceModel) error {
+	if !inv.NeedsNumber() {
+		return nil
+	}
+
+	period := invoice.NumberPeriod(r.numberReset, shared.Now())
+	sequence := &InvoiceNumberSequenceModel{MerchantID: inv.MerchantID(), Period: period, Value: 1}
+	err := tx.Clauses(
+		clause.OnConflict{
+			Columns: []clause.Column{{Name: "merchant_id"}, {Name: "period"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"value": gorm.Expr("invoice_number_sequences.value + 1"),
+			}),
+		},
+		clause.Returning{Columns: []clause.Column{{Name: "value"}}},
+	).Create(sequence).Error
+	if err != nil {
+		return fmt.Errorf("failed to allocate invoice number: %w", err)
+	}
+
+	number := invoice.FormatNumber(period, sequence.Value)
+	model.Number = &number
 	return nil
 }
 
+// numbered records on the invoice the number assignNumber allocated once its transaction has committed.
+func (r *InvoiceRepository) numbered(inv *invoice.Invoice, model *InvoiceModel) {
+	if inv.NeedsNumber() && model.Number != nil {
+		inv.SetNumber(*model.Number)
+	}
+}
+
 // Delete removes an invoice from the database.
 func (r *InvoiceRepository) Delete(ctx context.Context, id string) error {
 	if id == "" {
@@ -208,6 +480,7 @@ func (r *InvoiceRepository) Delete(ctx context.Context, id string) error {
 		return fmt.Errorf("failed to check if invoice exists: %w", err)
 	}
 	if !exists {
+		reportCrossTenantAccess(ctx, r.db, r.logger, &InvoiceModel{}, "invoice", id)
 		return shared.ErrNotFound
 	}
 
@@ -229,6 +502,7 @@ func (r *InvoiceRepository) Exists(ctx context.Context, id string) (bool, error)
 	var count int64
 	err := r.db.WithContext(ctx).
 		Model(&InvoiceModel{}).
+		Scopes(merchantScope(ctx)).
 		Where("id = ?", id).
 		Count(&count).Error
 	if err != nil {
@@ -237,3 +511,95 @@ func (r *InvoiceRepository) Exists(ctx context.Context, id string) (bool, error)
 
 	return count > 0, nil
 }
+
+// statusSummaryRow is a row of the invoice status summary query.
+type statusSummaryRow struct {
+	ID             string
+	Type           string
+	Status         string
+	CryptoCurrency string
+	CryptoAmount   string
+	MinimumAmount  *string
+	ExchangeRate   string
+	AmountPaid     *string
+	ExpiresAt      *time.Time
+	Confirmations  int
+}
+
+// FindStatusSummaries retrieves the payment state of the invoices with the given IDs in a single query,
+// joining their payments for the highest confirmation count.
+func (r *InvoiceRepository) FindStatusSummaries(ctx context.Context, ids []string) ([]*invoice.StatusSummary, error) {
+	if len(ids) == 0 {
+		return []*invoice.StatusSummary{}, nil
+	}
+
+	var rows []statusSummaryRow
+	err := r.db.WithContext(ctx).
+		Model(&InvoiceModel{}).
+		Select("invoices.id, invoices.type, invoices.status, invoices.crypto_currency, invoices.crypto_amount, "+
+			"invoices.minimum_amount, invoices.exchange_rate, invoices.amount_paid, invoices.expires_at, "+
+			"COALESCE(MAX(payments.confirmations), 0) AS confirmations").
+		Joins("LEFT JOIN payments ON payments.invoice_id = invoices.id AND payments.deleted_at IS NULL").
+		Scopes(merchantScope(ctx)).
+		Where("invoices.id IN ?", ids).
+		Group("invoices.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find invoice status summaries: %w", err)
+	}
+
+	summaries := make([]*invoice.StatusSummary, len(rows))
+	for i := range rows {
+		summary, err := r.toStatusSummary(&rows[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read status of invoice %s: %w", rows[i].ID, err)
+		}
+		summaries[i] = summary
+	}
+	return summaries, nil
+}
+
+// toStatusSummary converts a status summary row to its domain form.
+func (r *InvoiceRepository) toStatusSummary(row *statusSummaryRow) (*invoice.StatusSummary, error) {
+	summary := &invoice.StatusSummary{
+		InvoiceID:     row.ID,
+		Status:        invoice.InvoiceStatus(row.Status),
+		Confirmations: row.Confirmations,
+		ExpiresAt:     row.ExpiresAt,
+	}
+
+	amountDue, err := decimal.NewFromString(row.CryptoAmount)
+	if err != nil {
+		return nil, err
+	}
+	summary.AmountDue = amountDue
+	if row.AmountPaid != nil {
+		amountPaid, paidErr := decimal.NewFromString(*row.AmountPaid)
+		if paidErr != nil {
+			return nil, paidErr
+		}
+		summary.AmountPaid = amountPaid
+	}
+
+	// Any amount at or above the minimum settles a donation, so that is what remains to be paid
+	if invoice.InvoiceType(row.Type) == invoice.InvoiceTypeDonation && row.MinimumAmount != nil {
+		minimum, minErr := decimal.NewFromString(*row.MinimumAmount)
+		if minErr != nil {
+			return nil, minErr
+		}
+		rate, rateErr := r.mapper.DeserializeExchangeRate(row.ExchangeRate)
+		if rateErr != nil {
+			return nil, rateErr
+		}
+		if rate != nil {
+			minimumCrypto, moneyErr := shared.NewMoneyWithCrypto(
+				minimum.Mul(rate.Rate()).String(), shared.CryptoCurrency(row.CryptoCurrency))
+			if moneyErr != nil {
+				return nil, moneyErr
+			}
+			summary.AmountDue = minimumCrypto.Amount()
+		}
+	}
+
+	return summary, nil
+}
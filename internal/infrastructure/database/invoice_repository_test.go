@@ -35,6 +35,17 @@ func createTestInvoice(t *testing.T) *invoice.Invoice {
 }
 
 func createTestInvoiceWithID(t *testing.T, id string) *invoice.Invoice {
+	exchangeRate, _ := shared.NewExchangeRate(
+		"1.0",
+		shared.CurrencyUSD,
+		shared.CryptoCurrencyUSDT,
+		"default",
+		30*time.Minute,
+	)
+	return createTestInvoiceWithRate(t, id, exchangeRate)
+}
+
+func createTestInvoiceWithRate(t *testing.T, id string, exchangeRate *shared.ExchangeRate) *invoice.Invoice {
 	items := []*invoice.InvoiceItem{
 		createTestInvoiceItem(t, "Test Item", "Test Description", "2", "10.00"),
 	}
@@ -45,13 +56,6 @@ func createTestInvoiceWithID(t *testing.T, id string) *invoice.Invoice {
 	pricing, _ := invoice.NewInvoicePricing(subtotal, tax, total)
 
 	paymentAddress, _ := shared.NewPaymentAddress("TTestAddress123456789012345678901234567890", shared.NetworkTron)
-	exchangeRate, _ := shared.NewExchangeRate(
-		"1.0",
-		shared.CurrencyUSD,
-		shared.CryptoCurrencyUSDT,
-		"default",
-		30*time.Minute,
-	)
 	paymentTolerance, _ := invoice.NewPaymentTolerance("0.01", "1.0", invoice.OverpaymentActionCredit)
 	expiration := invoice.NewInvoiceExpiration(30 * time.Minute)
 
@@ -78,7 +82,7 @@ func TestInvoiceRepository(t *testing.T) {
 	t.Run("Save", func(t *testing.T) {
 		t.Run("Valid_Invoice", func(t *testing.T) {
 			db := setupTestDB(t)
-			repo := database.NewInvoiceRepository(db)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
 			ctx := context.Background()
 
 			inv := createTestInvoice(t)
@@ -96,7 +100,7 @@ func TestInvoiceRepository(t *testing.T) {
 
 		t.Run("Nil_Invoice", func(t *testing.T) {
 			db := setupTestDB(t)
-			repo := database.NewInvoiceRepository(db)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
 			ctx := context.Background()
 
 			err := repo.Save(ctx, nil)
@@ -106,7 +110,7 @@ func TestInvoiceRepository(t *testing.T) {
 
 		t.Run("Update_Existing_Invoice", func(t *testing.T) {
 			db := setupTestDB(t)
-			repo := database.NewInvoiceRepository(db)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
 			ctx := context.Background()
 
 			// Save initial invoice
@@ -130,10 +134,44 @@ func TestInvoiceRepository(t *testing.T) {
 		})
 	})
 
+	t.Run("Update", func(t *testing.T) {
+		t.Run("Rejects_Stale_Copy", func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
+			ctx := context.Background()
+
+			require.NoError(t, repo.Save(ctx, createTestInvoiceWithID(t, "stale-copy-invoice")))
+			first, err := repo.FindByID(ctx, "stale-copy-invoice")
+			require.NoError(t, err)
+			second, err := repo.FindByID(ctx, "stale-copy-invoice")
+			require.NoError(t, err)
+
+			first.SetCustomerID("first-customer")
+			require.NoError(t, repo.Update(ctx, first))
+			require.Equal(t, 1, first.Version())
+
+			// The second copy was loaded before the first update and would overwrite it
+			second.SetCustomerID("second-customer")
+			require.ErrorIs(t, repo.Update(ctx, second), invoice.ErrConcurrentUpdate)
+
+			var model database.InvoiceModel
+			require.NoError(t, db.First(&model, "id = ?", "stale-copy-invoice").Error)
+			require.Equal(t, "first-customer", *model.CustomerID)
+			require.Equal(t, 1, model.Version)
+
+			// A copy loaded after the update goes through
+			reloaded, err := repo.FindByID(ctx, "stale-copy-invoice")
+			require.NoError(t, err)
+			reloaded.SetCustomerID("second-customer")
+			require.NoError(t, repo.Update(ctx, reloaded))
+			require.Equal(t, 2, reloaded.Version())
+		})
+	})
+
 	t.Run("FindByID", func(t *testing.T) {
 		t.Run("Existing_Invoice", func(t *testing.T) {
 			db := setupTestDB(t)
-			repo := database.NewInvoiceRepository(db)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
 			ctx := context.Background()
 
 			// Save test invoice
@@ -153,7 +191,7 @@ func TestInvoiceRepository(t *testing.T) {
 
 		t.Run("Non_Existent_Invoice", func(t *testing.T) {
 			db := setupTestDB(t)
-			repo := database.NewInvoiceRepository(db)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
 			ctx := context.Background()
 
 			found, err := repo.FindByID(ctx, "non-existent-id")
@@ -164,7 +202,7 @@ func TestInvoiceRepository(t *testing.T) {
 
 		t.Run("Empty_ID", func(t *testing.T) {
 			db := setupTestDB(t)
-			repo := database.NewInvoiceRepository(db)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
 			ctx := context.Background()
 
 			found, err := repo.FindByID(ctx, "")
@@ -177,7 +215,7 @@ func TestInvoiceRepository(t *testing.T) {
 	t.Run("FindByMerchantID", func(t *testing.T) {
 		t.Run("Existing_Invoices", func(t *testing.T) {
 			db := setupTestDB(t)
-			repo := database.NewInvoiceRepository(db)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
 			ctx := context.Background()
 
 			merchantID := "test-merchant-id"
@@ -202,7 +240,7 @@ func TestInvoiceRepository(t *testing.T) {
 
 		t.Run("No_Active_Invoices", func(t *testing.T) {
 			db := setupTestDB(t)
-			repo := database.NewInvoiceRepository(db)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
 			ctx := context.Background()
 
 			invoices, err := repo.FindActive(ctx)
@@ -214,7 +252,7 @@ func TestInvoiceRepository(t *testing.T) {
 	t.Run("FindByPaymentAddress", func(t *testing.T) {
 		t.Run("Existing_Invoice", func(t *testing.T) {
 			db := setupTestDB(t)
-			repo := database.NewInvoiceRepository(db)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
 			ctx := context.Background()
 
 			// Save test invoice
@@ -236,7 +274,7 @@ func TestInvoiceRepository(t *testing.T) {
 
 		t.Run("Non_Existent_Address", func(t *testing.T) {
 			db := setupTestDB(t)
-			repo := database.NewInvoiceRepository(db)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
 			ctx := context.Background()
 
 			paymentAddress, _ := shared.NewPaymentAddress(
@@ -252,7 +290,7 @@ func TestInvoiceRepository(t *testing.T) {
 
 		t.Run("Nil_Payment_Address", func(t *testing.T) {
 			db := setupTestDB(t)
-			repo := database.NewInvoiceRepository(db)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
 			ctx := context.Background()
 
 			found, err := repo.FindByPaymentAddress(ctx, nil)
@@ -265,7 +303,7 @@ func TestInvoiceRepository(t *testing.T) {
 	t.Run("FindByStatus", func(t *testing.T) {
 		t.Run("Existing_Invoices", func(t *testing.T) {
 			db := setupTestDB(t)
-			repo := database.NewInvoiceRepository(db)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
 			ctx := context.Background()
 
 			// Create and save invoices with different statuses
@@ -293,7 +331,7 @@ func TestInvoiceRepository(t *testing.T) {
 
 		t.Run("No_Invoices_With_Status", func(t *testing.T) {
 			db := setupTestDB(t)
-			repo := database.NewInvoiceRepository(db)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
 			ctx := context.Background()
 
 			invoices, err := repo.FindByStatus(ctx, invoice.StatusPaid)
@@ -305,7 +343,7 @@ func TestInvoiceRepository(t *testing.T) {
 	t.Run("Delete", func(t *testing.T) {
 		t.Run("Existing_Invoice", func(t *testing.T) {
 			db := setupTestDB(t)
-			repo := database.NewInvoiceRepository(db)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
 			ctx := context.Background()
 
 			// Save test invoice
@@ -330,7 +368,7 @@ func TestInvoiceRepository(t *testing.T) {
 
 		t.Run("Non_Existent_Invoice", func(t *testing.T) {
 			db := setupTestDB(t)
-			repo := database.NewInvoiceRepository(db)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
 			ctx := context.Background()
 
 			err := repo.Delete(ctx, "non-existent-id")
@@ -340,7 +378,7 @@ func TestInvoiceRepository(t *testing.T) {
 
 		t.Run("Empty_ID", func(t *testing.T) {
 			db := setupTestDB(t)
-			repo := database.NewInvoiceRepository(db)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
 			ctx := context.Background()
 
 			err := repo.Delete(ctx, "")
@@ -348,4 +386,249 @@ func TestInvoiceRepository(t *testing.T) {
 			require.Contains(t, err.Error(), "invalid input")
 		})
 	})
+
+	t.Run("List", func(t *testing.T) {
+		t.Run("Cursor_Pagination", func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
+			ctx := context.Background()
+
+			base := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+			save := func(id string, createdAt time.Time) {
+				require.NoError(t, repo.Save(ctx, createTestInvoiceWithID(t, id)))
+				require.NoError(t, db.Model(&database.InvoiceModel{}).Where("id = ?", id).
+					Update("created_at", createdAt).Error)
+			}
+			save("list-invoice-a", base)
+			save("list-invoice-b", base.Add(time.Minute))
+			save("list-invoice-c", base.Add(time.Minute))
+			save("list-invoice-d", base.Add(2*time.Minute))
+
+			var ids []string
+			req := &invoice.ListInvoicesRequest{MerchantID: "test-merchant-id", Limit: 3}
+			for page := 0; ; page++ {
+				resp, err := repo.List(ctx, req)
+				require.NoError(t, err)
+				require.Equal(t, 4+page, resp.Total)
+				for _, inv := range resp.Invoices {
+					ids = append(ids, inv.ID())
+				}
+				if page == 0 {
+					// An invoice created mid-listing does not shift the following pages
+					save("list-invoice-e", base.Add(time.Hour))
+				}
+				if resp.NextCursor == nil {
+					break
+				}
+				req.Cursor = resp.NextCursor
+			}
+			require.Equal(t, []string{"list-invoice-d", "list-invoice-c", "list-invoice-b", "list-invoice-a"}, ids)
+		})
+
+		t.Run("Filters", func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
+			ctx := context.Background()
+
+			require.NoError(t, repo.Save(ctx, createTestInvoiceWithID(t, "list-filter-invoice")))
+			cancelled := createTestInvoiceWithID(t, "list-cancelled-invoice")
+			cancelled.SetStatus(invoice.StatusCancelled)
+			require.NoError(t, repo.Save(ctx, cancelled))
+
+			// Without a status only active invoices are listed
+			resp, err := repo.List(ctx, &invoice.ListInvoicesRequest{MerchantID: "test-merchant-id", Limit: 10})
+			require.NoError(t, err)
+			require.Len(t, resp.Invoices, 1)
+			require.Equal(t, "list-filter-invoice", resp.Invoices[0].ID())
+			require.Nil(t, resp.NextCursor)
+
+			status := invoice.StatusCancelled
+			resp, err = repo.List(ctx, &invoice.ListInvoicesRequest{
+				MerchantID: "test-merchant-id", Status: &status, Limit: 10,
+			})
+			require.NoError(t, err)
+			require.Len(t, resp.Invoices, 1)
+			require.Equal(t, "list-cancelled-invoice", resp.Invoices[0].ID())
+
+			search := "TEST INV"
+			resp, err = repo.List(ctx, &invoice.ListInvoicesRequest{
+				MerchantID: "test-merchant-id", Search: &search, Limit: 10,
+			})
+			require.NoError(t, err)
+			require.Len(t, resp.Invoices, 1)
+
+			resp, err = repo.List(ctx, &invoice.ListInvoicesRequest{MerchantID: "other-merchant", Limit: 10})
+			require.NoError(t, err)
+			require.Empty(t, resp.Invoices)
+		})
+	})
+
+	t.Run("FindStatusSummaries", func(t *testing.T) {
+		t.Run("Joins_Payments", func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
+			ctx := context.Background()
+
+			paid := createTestInvoiceWithID(t, "status-paid-invoice")
+			amount, err := shared.NewMoneyWithCrypto("10", shared.CryptoCurrencyUSDT)
+			require.NoError(t, err)
+			require.NoError(t, paid.RecordPayment(amount))
+			require.NoError(t, repo.Save(ctx, paid))
+			require.NoError(t, repo.Save(ctx, createTestInvoiceWithID(t, "status-unpaid-invoice")))
+
+			for i, confirmations := range []int{3, 12} {
+				require.NoError(t, db.Create(&database.PaymentModel{
+					ID:            fmt.Sprintf("status-payment-%d", i),
+					InvoiceID:     paid.ID(),
+					TxHash:        fmt.Sprintf("status-tx-%d", i),
+					Amount:        "5",
+					FromAddress:   "TSenderAddress",
+					ToAddress:     "TTestAddress",
+					Status:        "confirming",
+					Confirmations: confirmations,
+					DetectedAt:    time.Now().UTC(),
+					CreatedAt:     time.Now().UTC(),
+				}).Error)
+			}
+			// Deleted payments do not count
+			require.NoError(t, db.Create(&database.PaymentModel{
+				ID:            "status-payment-deleted",
+				InvoiceID:     paid.ID(),
+				TxHash:        "status-tx-deleted",
+				Amount:        "5",
+				FromAddress:   "TSenderAddress",
+				ToAddress:     "TTestAddress",
+				Status:        "confirmed",
+				Confirmations: 40,
+				DetectedAt:    time.Now().UTC(),
+				CreatedAt:     time.Now().UTC(),
+				DeletedAt:     gorm.DeletedAt{Time: time.Now().UTC(), Valid: true},
+			}).Error)
+
+			summaries, err := repo.FindStatusSummaries(ctx,
+				[]string{"status-paid-invoice", "status-unpaid-invoice", "non-existent-id"})
+			require.NoError(t, err)
+			require.Len(t, summaries, 2)
+
+			byID := make(map[string]*invoice.StatusSummary)
+			for _, summary := range summaries {
+				byID[summary.InvoiceID] = summary
+			}
+
+			require.Equal(t, invoice.StatusCreated, byID["status-paid-invoice"].Status)
+			require.Equal(t, "10", byID["status-paid-invoice"].AmountPaid.String())
+			require.Equal(t, "12", byID["status-paid-invoice"].RemainingAmount().String())
+			require.Equal(t, 12, byID["status-paid-invoice"].Confirmations)
+			require.Equal(t, "45.45", byID["status-paid-invoice"].ProgressPercentage().String())
+			require.NotNil(t, byID["status-paid-invoice"].ExpiresAt)
+			require.WithinDuration(t, paid.Expiration().ExpiresAt(), *byID["status-paid-invoice"].ExpiresAt, time.Second)
+
+			require.Equal(t, "0", byID["status-unpaid-invoice"].AmountPaid.String())
+			require.Equal(t, "22", byID["status-unpaid-invoice"].RemainingAmount().String())
+			require.Equal(t, 0, byID["status-unpaid-invoice"].Confirmations)
+		})
+
+		t.Run("Merchant_Scoped", func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
+			require.NoError(t, repo.Save(context.Background(), createTestInvoiceWithID(t, "status-scoped-invoice")))
+
+			owner := shared.WithMerchantID(context.Background(), "test-merchant-id")
+			summaries, err := repo.FindStatusSummaries(owner, []string{"status-scoped-invoice"})
+			require.NoError(t, err)
+			require.Len(t, summaries, 1)
+
+			intruder := shared.WithMerchantID(context.Background(), "other-merchant")
+			summaries, err = repo.FindStatusSummaries(intruder, []string{"status-scoped-invoice"})
+			require.NoError(t, err)
+			require.Empty(t, summaries)
+		})
+
+		t.Run("Deleted_Invoice", func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := database.NewInvoiceRepository(db, zap.NewNop())
+			ctx := context.Background()
+
+			require.NoError(t, repo.Save(ctx, createTestInvoiceWithID(t, "status-deleted-invoice")))
+			require.NoError(t, repo.Delete(ctx, "status-deleted-invoice"))
+
+			summaries, err := repo.FindStatusSummaries(ctx, []string{"status-deleted-invoice"})
+			require.NoError(t, err)
+			require.Empty(t, summaries)
+		})
+	})
+	t.Run("FindRequotable", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := database.NewInvoiceRepository(db, zap.NewNop())
+		ctx := context.Background()
+
+		lockedAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+		expiredRate, err := shared.RestoreExchangeRate("1.0", shared.CurrencyUSD, shared.CryptoCurrencyUSDT, "default",
+			lockedAt, lockedAt.Add(30*time.Minute))
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, createTestInvoiceWithRate(t, "requotable-invoice", expiredRate)))
+		require.NoError(t, repo.Save(ctx, createTestInvoiceWithID(t, "locked-rate-invoice")))
+
+		requotable, err := repo.FindRequotable(ctx)
+		require.NoError(t, err)
+		require.Len(t, requotable, 1)
+		require.Equal(t, "requotable-invoice", requotable[0].ID())
+		require.True(t, requotable[0].ExchangeRate().IsExpired())
+
+		freshRate, err := shared.NewExchangeRate("1.01", shared.CurrencyUSD, shared.CryptoCurrencyUSDT, "market",
+			30*time.Minute)
+		require.NoError(t, err)
+		_, err = requotable[0].Requote(freshRate, invoice.DefaultRequotePolicy().MaxSlippage)
+		require.NoError(t, err)
+		require.NoError(t, repo.Update(ctx, requotable[0]))
+
+		found, err := repo.FindByID(ctx, "requotable-invoice")
+		require.NoError(t, err)
+		require.Equal(t, "1.01", found.ExchangeRate().Rate().String())
+		require.False(t, found.ExchangeRate().IsExpired())
+		require.Len(t, found.Requotes(), 1)
+		require.Equal(t, "22", found.Requotes()[0].PreviousAmount().Amount().String())
+		require.Equal(t, "22.22", found.Requotes()[0].NewAmount().Amount().String())
+		require.Equal(t, "market", found.Requotes()[0].Source())
+
+		requotable, err = repo.FindRequotable(ctx)
+		require.NoError(t, err)
+		require.Empty(t, requotable)
+	})
+}
+
+func TestInvoiceRepository_PaymentNetwork(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewInvoiceRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	template := createTestInvoiceWithID(t, "bsc-invoice")
+	paymentAddress, err := shared.NewPaymentAddress("0x55d398326f99059fF775485246999027B3197955", shared.NetworkBSC)
+	require.NoError(t, err)
+	inv, err := invoice.NewInvoice(template.ID(), template.MerchantID(), template.Title(), template.Description(),
+		template.Items(), template.Pricing(), shared.CryptoCurrencyUSDT, paymentAddress, template.ExchangeRate(),
+		template.PaymentTolerance(), template.Expiration(), nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, inv))
+
+	found, err := repo.FindByID(ctx, inv.ID())
+	require.NoError(t, err)
+	require.Equal(t, shared.NetworkBSC, found.PaymentAddress().Network())
+
+	require.NoError(t, repo.Save(ctx, createTestInvoiceWithID(t, "tron-invoice")))
+	addresses, err := repo.FindPaymentAddresses(ctx, shared.NetworkBSC)
+	require.NoError(t, err)
+	require.Equal(t, []string{"0x55d398326f99059fF775485246999027B3197955"}, addresses)
+
+	// Invoices stored before the network was recorded are on their cryptocurrency's default network
+	require.NoError(t, db.Model(&database.InvoiceModel{}).Where("id = ?", inv.ID()).
+		Update("network", nil).Error)
+	found, err = repo.FindByID(ctx, inv.ID())
+	require.NoError(t, err)
+	require.Equal(t, shared.NetworkTron, found.PaymentAddress().Network())
+	addresses, err = repo.FindPaymentAddresses(ctx, shared.NetworkTron)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		"TTestAddress123456789012345678901234567890", "0x55d398326f99059fF775485246999027B3197955",
+	}, addresses)
 }
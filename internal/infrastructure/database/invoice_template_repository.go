@@ -0,0 +1,219 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoicetemplate"
+	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// templateItemRecord is the stored form of an invoice template item.
+type templateItemRecord struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Quantity    string `json:"quantity"`
+	UnitPrice   string `json:"unit_price"`
+	TaxCategory string `json:"tax_category"`
+}
+
+// InvoiceTemplateRepository implements the invoicetemplate.Repository interface using GORM.
+type InvoiceTemplateRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewInvoiceTemplateRepository creates a new invoice template repository.
+func NewInvoiceTemplateRepository(db *gorm.DB, logger *zap.Logger) invoicetemplate.Repository {
+	return &InvoiceTemplateRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a new invoice template to the database.
+func (r *InvoiceTemplateRepository) Save(ctx context.Context, template *invoicetemplate.Template) error {
+	if err := r.checkNameFree(ctx, template); err != nil {
+		return err
+	}
+
+	model, err := r.toModel(template)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save invoice template: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds an invoice template by ID.
+func (r *InvoiceTemplateRepository) FindByID(ctx context.Context, id string) (*invoicetemplate.Template, error) {
+	var model InvoiceTemplateModel
+	err := r.db.WithContext(ctx).Scopes(merchantScope(ctx)).Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			reportCrossTenantAccess(ctx, r.db, r.logger, &InvoiceTemplateModel{}, "invoice_template", id)
+			return nil, invoicetemplate.ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to find invoice template: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// ListByMerchant lists a merchant's invoice templates ordered by name.
+func (r *InvoiceTemplateRepository) ListByMerchant(
+	ctx context.Context,
+	merchantID string,
+) ([]*invoicetemplate.Template, error) {
+	var models []InvoiceTemplateModel
+	if err := r.db.WithContext(ctx).
+		Where("merchant_id = ?", merchantID).
+		Order("name ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list invoice templates: %w", err)
+	}
+
+	templates := make([]*invoicetemplate.Template, len(models))
+	for i := range models {
+		template, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert invoice template model to domain: %w", err)
+		}
+		templates[i] = template
+	}
+	return templates, nil
+}
+
+// Update updates an existing invoice template.
+func (r *InvoiceTemplateRepository) Update(ctx context.Context, template *invoicetemplate.Template) error {
+	if err := r.checkNameFree(ctx, template); err != nil {
+		return err
+	}
+
+	model, err := r.toModel(template)
+	if err != nil {
+		return err
+	}
+	result := r.db.WithContext(ctx).Model(&InvoiceTemplateModel{}).
+		Scopes(merchantScope(ctx)).
+		Where("id = ?", template.ID()).
+		Updates(map[string]interface{}{
+			"name":               model.Name,
+			"title":              model.Title,
+			"description":        model.Description,
+			"items":              model.Items,
+			"currency":           model.Currency,
+			"crypto_currency":    model.CryptoCurrency,
+			"network":            model.Network,
+			"tax_rate":           model.TaxRate,
+			"expires_in_seconds": model.ExpiresInSeconds,
+			"metadata":           model.Metadata,
+			"updated_at":         model.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update invoice template: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		reportCrossTenantAccess(ctx, r.db, r.logger, &InvoiceTemplateModel{}, "invoice_template", template.ID())
+		return invoicetemplate.ErrTemplateNotFound
+	}
+	return nil
+}
+
+// Delete removes an invoice template.
+func (r *InvoiceTemplateRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Scopes(merchantScope(ctx)).Where("id = ?", id).Delete(&InvoiceTemplateModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete invoice template: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		reportCrossTenantAccess(ctx, r.db, r.logger, &InvoiceTemplateModel{}, "invoice_template", id)
+		return invoicetemplate.ErrTemplateNotFound
+	}
+	return nil
+}
+
+// checkNameFree returns ErrNameTaken if another of the merchant's templates has the template's name.
+func (r *InvoiceTemplateRepository) checkNameFree(ctx context.Context, template *invoicetemplate.Template) error {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&InvoiceTemplateModel{}).
+		Where("merchant_id = ? AND name = ? AND id <> ?", template.MerchantID(), template.Name(), template.ID()).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check invoice template name: %w", err)
+	}
+	if count > 0 {
+		return invoicetemplate.ErrNameTaken
+	}
+	return nil
+}
+
+// toModel converts a domain invoice template to a database model.
+func (r *InvoiceTemplateRepository) toModel(template *invoicetemplate.Template) (*InvoiceTemplateModel, error) {
+	records := make([]templateItemRecord, 0, len(template.Items()))
+	for _, item := range template.Items() {
+		records = append(records, templateItemRecord(item))
+	}
+	items, err := json.Marshal(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal invoice template items: %w", err)
+	}
+
+	metadata, err := marshalSnapshot(template.Metadata())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal invoice template metadata: %w", err)
+	}
+
+	return &InvoiceTemplateModel{
+		ID:               template.ID(),
+		MerchantID:       template.MerchantID(),
+		Name:             template.Name(),
+		Title:            template.Title(),
+		Description:      template.Description(),
+		Items:            string(items),
+		Currency:         template.Currency().String(),
+		CryptoCurrency:   template.CryptoCurrency().String(),
+		Network:          template.Network().String(),
+		TaxRate:          template.TaxRate(),
+		ExpiresInSeconds: int64(template.ExpiresIn() / time.Second),
+		Metadata:         metadata,
+		CreatedAt:        template.CreatedAt(),
+		UpdatedAt:        template.UpdatedAt(),
+	}, nil
+}
+
+// toDomain converts a database model to a domain invoice template.
+func (r *InvoiceTemplateRepository) toDomain(model *InvoiceTemplateModel) (*invoicetemplate.Template, error) {
+	var records []templateItemRecord
+	if err := json.Unmarshal([]byte(model.Items), &records); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal invoice template items: %w", err)
+	}
+	items := make([]invoicetemplate.Item, len(records))
+	for i, record := range records {
+		items[i] = invoicetemplate.Item(record)
+	}
+
+	metadata, err := unmarshalSnapshot(model.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal invoice template metadata: %w", err)
+	}
+
+	return invoicetemplate.RestoreTemplate(model.ID, model.MerchantID, invoicetemplate.Definition{
+		Name:           model.Name,
+		Title:          model.Title,
+		Description:    model.Description,
+		Items:          items,
+		Currency:       shared.Currency(model.Currency),
+		CryptoCurrency: shared.CryptoCurrency(model.CryptoCurrency),
+		Network:        shared.BlockchainNetwork(model.Network),
+		TaxRate:        model.TaxRate,
+		ExpiresIn:      time.Duration(model.ExpiresInSeconds) * time.Second,
+		Metadata:       metadata,
+	}, model.CreatedAt, model.UpdatedAt)
+}
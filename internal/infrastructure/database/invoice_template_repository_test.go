@@ -0,0 +1,104 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoicetemplate"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestInvoiceTemplate(t *testing.T, id, merchantID, name string) *invoicetemplate.Template {
+	template, err := invoicetemplate.NewTemplate(id, merchantID, invoicetemplate.Definition{
+		Name:  name,
+		Title: "Consulting retainer",
+		Items: []invoicetemplate.Item{
+			{Name: "Consulting", Description: "Hourly", Quantity: "10", UnitPrice: "150.00"},
+			{Name: "Ebook", Quantity: "1", UnitPrice: "20.00", TaxCategory: "digital"},
+		},
+		CryptoCurrency: shared.CryptoCurrencyUSDC,
+		Network:        shared.NetworkEthereum,
+		TaxRate:        "0.10",
+		ExpiresIn:      2 * time.Hour,
+		Metadata:       map[string]interface{}{"project": "alpha"},
+	})
+	require.NoError(t, err)
+	return template
+}
+
+func TestInvoiceTemplateRepository(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewInvoiceTemplateRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	retainer := newTestInvoiceTemplate(t, "template-1", "merchant-1", "Retainer")
+	require.NoError(t, repo.Save(ctx, retainer))
+	require.NoError(t, repo.Save(ctx, newTestInvoiceTemplate(t, "template-2", "merchant-1", "Audit")))
+	require.NoError(t, repo.Save(ctx, newTestInvoiceTemplate(t, "template-3", "merchant-2", "Retainer")))
+
+	t.Run("round trip", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, "template-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Retainer", found.Name())
+		assert.Equal(t, retainer.Items(), found.Items())
+		assert.Equal(t, shared.CryptoCurrencyUSDC, found.CryptoCurrency())
+		assert.Equal(t, shared.NetworkEthereum, found.Network())
+		assert.Equal(t, "0.10", found.TaxRate())
+		assert.Equal(t, 2*time.Hour, found.ExpiresIn())
+		assert.Equal(t, "alpha", found.Metadata()["project"])
+	})
+
+	t.Run("names are unique per merchant", func(t *testing.T) {
+		err := repo.Save(ctx, newTestInvoiceTemplate(t, "template-4", "merchant-1", "Retainer"))
+		require.ErrorIs(t, err, invoicetemplate.ErrNameTaken)
+
+		audit, err := repo.FindByID(ctx, "template-2")
+		require.NoError(t, err)
+		definition := invoicetemplate.Definition{Name: "Retainer", Title: "Audit", Items: audit.Items()}
+		require.NoError(t, audit.Update(definition))
+		require.ErrorIs(t, repo.Update(ctx, audit), invoicetemplate.ErrNameTaken)
+	})
+
+	t.Run("list is ordered by name", func(t *testing.T) {
+		templates, err := repo.ListByMerchant(ctx, "merchant-1")
+		require.NoError(t, err)
+		require.Len(t, templates, 2)
+		assert.Equal(t, "Audit", templates[0].Name())
+		assert.Equal(t, "Retainer", templates[1].Name())
+	})
+
+	t.Run("update replaces the content", func(t *testing.T) {
+		require.NoError(t, retainer.Update(invoicetemplate.Definition{
+			Name:  "Retainer",
+			Title: "Quarterly retainer",
+			Items: []invoicetemplate.Item{{Name: "Consulting", Quantity: "30", UnitPrice: "140.00"}},
+		}))
+		require.NoError(t, repo.Update(ctx, retainer))
+
+		found, err := repo.FindByID(ctx, "template-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Quarterly retainer", found.Title())
+		require.Len(t, found.Items(), 1)
+		assert.Equal(t, "30", found.Items()[0].Quantity)
+		assert.Empty(t, found.TaxRate())
+		assert.Nil(t, found.Metadata())
+	})
+
+	t.Run("other merchants cannot see or delete the template", func(t *testing.T) {
+		intruder := shared.WithMerchantID(ctx, "merchant-2")
+		_, err := repo.FindByID(intruder, "template-1")
+		require.ErrorIs(t, err, invoicetemplate.ErrTemplateNotFound)
+		require.ErrorIs(t, repo.Delete(intruder, "template-1"), invoicetemplate.ErrTemplateNotFound)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, "template-1"))
+		_, err := repo.FindByID(ctx, "template-1")
+		require.ErrorIs(t, err, invoicetemplate.ErrTemplateNotFound)
+	})
+}
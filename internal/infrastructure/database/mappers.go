@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // InvoiceMapper handles conversion between domain entities and database models.
@@ -33,7 +35,7 @@ func (m *InvoiceMapper) ToDomain(model *InvoiceModel) (*invoice.Invoice, error)
 		return nil, err
 	}
 
-	paymentAddress, err := m.createPaymentAddress(model.PaymentAddress)
+	paymentAddress, err := m.createPaymentAddress(model)
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +50,7 @@ func (m *InvoiceMapper) ToDomain(model *InvoiceModel) (*invoice.Invoice, error)
 		return nil, err
 	}
 
-	expiration := m.createExpiration(model.ExpiresAt)
+	expiration := m.createExpiration(model)
 
 	inv, err := m.buildInvoice(model, items, pricing, paymentAddress, exchangeRate, paymentTolerance, expiration)
 	if err != nil {
@@ -56,9 +58,97 @@ func (m *InvoiceMapper) ToDomain(model *InvoiceModel) (*invoice.Invoice, error)
 	}
 
 	m.setInvoiceProperties(inv, model)
+
+	if err := m.setDiscounts(inv, model.Discounts); err != nil {
+		return nil, err
+	}
+
+	if err := m.setTaxTreatment(inv, model.TaxTreatment); err != nil {
+		return nil, err
+	}
+
+	if err := m.setDonation(inv, model); err != nil {
+		return nil, err
+	}
+
+	if err := m.setPaymentTotals(inv, model); err != nil {
+		return nil, err
+	}
+
+	if err := m.setRequotes(inv, model.Requotes); err != nil {
+		return nil, err
+	}
+
+	if err := m.setExtensions(inv, model.Extensions); err != nil {
+		return nil, err
+	}
+
+	if err := m.setStaleRateChecks(inv, model.StaleRateChecks); err != nil {
+		return nil, err
+	}
+
+	if err := m.setPaymentReversals(inv, model.PaymentReversals); err != nil {
+		return nil, err
+	}
+
+	if err := m.setRefundDestination(inv, model.RefundDestination); err != nil {
+		return nil, err
+	}
+
+	if err := m.setCustomFields(inv, model.CustomFields); err != nil {
+		return nil, err
+	}
+
+	if err := m.setSettlementSplit(inv, model.SettlementSplit); err != nil {
+		return nil, err
+	}
+
+	if err := m.setFeePassThrough(inv, model.FeePercentage); err != nil {
+		return nil, err
+	}
+
+	metadata, err := unmarshalSnapshot(model.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	inv.SetMetadata(metadata)
 	return inv, nil
 }
 
+// setFeePassThrough restores the platform fee an invoice passes through to the customer.
+func (m *InvoiceMapper) setFeePassThrough(inv *invoice.Invoice, percentage *string) error {
+	if percentage == nil {
+		return nil
+	}
+	parsed, err := decimal.NewFromString(*percentage)
+	if err != nil {
+		return fmt.Errorf("failed to parse fee percentage: %w", err)
+	}
+	fee, err := invoice.NewFeePassThrough(parsed)
+	if err != nil {
+		return err
+	}
+	inv.SetFeePassThrough(fee)
+	return nil
+}
+
+// setDonation restores the type and donation minimum of an invoice.
+func (m *InvoiceMapper) setDonation(inv *invoice.Invoice, model *InvoiceModel) error {
+	if model.Type == "" || model.Type == invoice.InvoiceTypeStandard.String() {
+		return nil
+	}
+
+	inv.SetType(invoice.InvoiceType(model.Type))
+	if model.MinimumAmount != nil {
+		minimum, err := shared.NewMoney(*model.MinimumAmount, shared.Currency(model.Currency))
+		if err != nil {
+			return fmt.Errorf("failed to parse minimum amount: %w", err)
+		}
+		inv.SetMinimumAmount(minimum)
+	}
+	return nil
+}
+
 // parseInvoiceItems parses invoice items from JSONB.
 func (m *InvoiceMapper) parseInvoiceItems(itemsJSON string) ([]*invoice.InvoiceItem, error) {
 	if itemsJSON == "" {
@@ -88,13 +178,144 @@ func (m *InvoiceMapper) createInvoiceItem(itemMap map[string]interface{}) (*invo
 	description, _ := itemMap["description"].(string)
 	quantity, _ := itemMap["quantity"].(string)
 	unitPriceStr, _ := itemMap["unit_price"].(string)
+	discountType, _ := itemMap["discount_type"].(string)
+	discountValue, _ := itemMap["discount_value"].(string)
 
 	unitPrice, err := shared.NewMoney(unitPriceStr, shared.CurrencyUSD)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create unit price: %w", err)
 	}
 
-	return invoice.NewInvoiceItem(name, description, quantity, unitPrice)
+	var discount *invoice.Discount
+	if discountType != "" {
+		discount, err = invoice.NewDiscount(invoice.DiscountType(discountType), discountValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create item discount: %w", err)
+		}
+	}
+
+	item, err := invoice.NewInvoiceItemWithDiscount(name, description, quantity, unitPrice, discount)
+	if err != nil {
+		return nil, err
+	}
+
+	if category, _ := itemMap["tax_category"].(string); category != "" {
+		item.SetTaxCategory(category)
+	}
+
+	if rawLines, ok := itemMap["tax_lines"]; ok {
+		lines, err := m.parseTaxLines(rawLines)
+		if err != nil {
+			return nil, err
+		}
+		item.SetTaxLines(lines)
+	}
+
+	return item, nil
+}
+
+// taxLineRecord is the JSONB representation of an invoice item tax line.
+type taxLineRecord struct {
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Jurisdiction  string `json:"jurisdiction"`
+	Rate          string `json:"rate"`
+	ReverseCharge bool   `json:"reverse_charge,omitempty"`
+	TaxableAmount string `json:"taxable_amount"`
+	Amount        string `json:"amount"`
+}
+
+// taxTreatmentRecord is the JSONB representation of an invoice tax treatment.
+type taxTreatmentRecord struct {
+	Jurisdiction  string `json:"jurisdiction"`
+	CustomerTaxID string `json:"customer_tax_id,omitempty"`
+	ReverseCharge bool   `json:"reverse_charge,omitempty"`
+}
+
+// parseTaxLines restores the tax lines of an invoice item from its decoded JSONB value.
+func (m *InvoiceMapper) parseTaxLines(rawLines interface{}) ([]*invoice.TaxLine, error) {
+	data, err := json.Marshal(rawLines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tax lines: %w", err)
+	}
+
+	var records []taxLineRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tax lines: %w", err)
+	}
+
+	lines := make([]*invoice.TaxLine, len(records))
+	for i, record := range records {
+		rate, err := invoice.NewTaxRate(
+			record.Name, invoice.TaxType(record.Type), record.Jurisdiction, record.Rate, record.ReverseCharge,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore tax rate: %w", err)
+		}
+		taxable, err := shared.NewMoney(record.TaxableAmount, shared.CurrencyUSD)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore taxable amount: %w", err)
+		}
+		amount, err := shared.NewMoney(record.Amount, shared.CurrencyUSD)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore tax amount: %w", err)
+		}
+		if lines[i], err = invoice.RestoreTaxLine(rate, taxable, amount); err != nil {
+			return nil, err
+		}
+	}
+
+	return lines, nil
+}
+
+// serializeTaxLines converts the tax lines of an invoice item to their JSONB representation.
+func (m *InvoiceMapper) serializeTaxLines(lines []*invoice.TaxLine) []taxLineRecord {
+	records := make([]taxLineRecord, len(lines))
+	for i, line := range lines {
+		records[i] = taxLineRecord{
+			Name:          line.Rate().Name(),
+			Type:          line.Rate().Type().String(),
+			Jurisdiction:  line.Rate().Jurisdiction(),
+			Rate:          line.Rate().Rate().String(),
+			ReverseCharge: line.Rate().IsReverseCharge(),
+			TaxableAmount: line.TaxableAmount().Amount().String(),
+			Amount:        line.Amount().Amount().String(),
+		}
+	}
+	return records
+}
+
+// setTaxTreatment restores the tax treatment of an invoice.
+func (m *InvoiceMapper) setTaxTreatment(inv *invoice.Invoice, treatmentJSON *string) error {
+	if treatmentJSON == nil || *treatmentJSON == "" {
+		return nil
+	}
+
+	var record taxTreatmentRecord
+	if err := json.Unmarshal([]byte(*treatmentJSON), &record); err != nil {
+		return fmt.Errorf("failed to unmarshal tax treatment: %w", err)
+	}
+
+	inv.SetTaxTreatment(invoice.NewTaxTreatment(record.Jurisdiction, record.CustomerTaxID, record.ReverseCharge))
+	return nil
+}
+
+// SerializeTaxTreatment converts an invoice tax treatment to a JSON string, or nil for a flat tax.
+func (m *InvoiceMapper) SerializeTaxTreatment(treatment *invoice.TaxTreatment) (*string, error) {
+	if treatment == nil {
+		return nil, nil
+	}
+
+	jsonBytes, err := json.Marshal(taxTreatmentRecord{
+		Jurisdiction:  treatment.Jurisdiction(),
+		CustomerTaxID: treatment.CustomerTaxID(),
+		ReverseCharge: treatment.IsReverseCharge(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tax treatment: %w", err)
+	}
+	treatmentJSON := string(jsonBytes)
+	return &treatmentJSON, nil
 }
 
 // createInvoicePricing creates invoice pricing from model.
@@ -104,26 +325,60 @@ func (m *InvoiceMapper) createInvoicePricing(model *InvoiceModel) (*invoice.Invo
 		return nil, fmt.Errorf("failed to create subtotal: %w", err)
 	}
 
+	discountAmount := model.Discount
+	if discountAmount == "" {
+		discountAmount = "0"
+	}
+	discount, err := shared.NewMoney(discountAmount, shared.CurrencyUSD)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discount: %w", err)
+	}
+
 	tax, err := shared.NewMoney(model.Tax, shared.CurrencyUSD)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tax: %w", err)
 	}
 
+	feeAmount := model.Fee
+	if feeAmount == "" {
+		feeAmount = "0"
+	}
+	fee, err := shared.NewMoney(feeAmount, shared.CurrencyUSD)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fee: %w", err)
+	}
+
 	total, err := shared.NewMoney(model.Total, shared.CurrencyUSD)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create total: %w", err)
 	}
 
-	return invoice.NewInvoicePricing(subtotal, tax, total)
+	return invoice.NewInvoicePricingWithFee(subtotal, discount, tax, fee, total)
 }
 
-// createPaymentAddress creates payment address from model.
-func (m *InvoiceMapper) createPaymentAddress(paymentAddressStr *string) (*shared.PaymentAddress, error) {
-	if paymentAddressStr == nil {
+// legacyNetwork returns the network of an invoice stored before the network was recorded: its cryptocurrency's
+// default network.
+func legacyNetwork(cryptoCurrency string) shared.BlockchainNetwork {
+	token, err := shared.DefaultTokenRegistry().Resolve("", shared.CryptoCurrency(cryptoCurrency), "")
+	if err != nil {
+		return shared.NetworkTron
+	}
+	return token.Network
+}
+
+// createPaymentAddress creates payment address from model. Invoices stored before the network was recorded
+// are on their cryptocurrency's default network.
+func (m *InvoiceMapper) createPaymentAddress(model *InvoiceModel) (*shared.PaymentAddress, error) {
+	if model.PaymentAddress == nil {
 		return nil, nil
 	}
 
-	paymentAddress, err := shared.NewPaymentAddress(*paymentAddressStr, shared.NetworkTron)
+	network := legacyNetwork(model.CryptoCurrency)
+	if model.Network != nil {
+		network = shared.BlockchainNetwork(*model.Network)
+	}
+
+	paymentAddress, err := shared.NewPaymentAddress(*model.PaymentAddress, network)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create payment address: %w", err)
 	}
@@ -131,14 +386,14 @@ func (m *InvoiceMapper) createPaymentAddress(paymentAddressStr *string) (*shared
 	return paymentAddress, nil
 }
 
-// createExchangeRate creates exchange rate from model.
+// createExchangeRate creates exchange rate from model. Drafts have no exchange rate until they are finalized.
 func (m *InvoiceMapper) createExchangeRate(model *InvoiceModel) (*shared.ExchangeRate, error) {
 	exchangeRate, err := m.DeserializeExchangeRate(model.ExchangeRate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to deserialize exchange rate: %w", err)
 	}
 
-	if exchangeRate == nil {
+	if exchangeRate == nil && model.Status != invoice.StatusDraft.String() {
 		// Fallback to default if not present
 		exchangeRate, err = shared.NewExchangeRate(
 			"1.0",
@@ -173,11 +428,14 @@ func (m *InvoiceMapper) createPaymentTolerance(toleranceJSON string) (*invoice.P
 	return paymentTolerance, nil
 }
 
-// createExpiration creates expiration from model.
-func (m *InvoiceMapper) createExpiration(expiresAt *time.Time) *invoice.InvoiceExpiration {
-	if expiresAt != nil {
+// createExpiration creates expiration from model. A draft's expiration only records how long it stays payable.
+func (m *InvoiceMapper) createExpiration(model *InvoiceModel) *invoice.InvoiceExpiration {
+	if model.ExpiresIn != nil {
+		return invoice.NewInvoiceExpiration(time.Duration(*model.ExpiresIn) * time.Second)
+	}
+	if model.ExpiresAt != nil {
 		// Use unsafe version to allow loading expired invoices from database
-		return invoice.NewInvoiceExpirationWithTimeUnsafe(*expiresAt)
+		return invoice.NewInvoiceExpirationWithTimeUnsafe(*model.ExpiresAt)
 	}
 	return invoice.NewInvoiceExpiration(30 * time.Minute)
 }
@@ -192,6 +450,26 @@ func (m *InvoiceMapper) buildInvoice(
 	paymentTolerance *invoice.PaymentTolerance,
 	expiration *invoice.InvoiceExpiration,
 ) (*invoice.Invoice, error) {
+	if model.Status == invoice.StatusDraft.String() {
+		var network shared.BlockchainNetwork
+		if model.Network != nil {
+			network = shared.BlockchainNetwork(*model.Network)
+		}
+		return invoice.NewDraftInvoice(
+			model.ID,
+			model.MerchantID,
+			model.Title,
+			model.Description,
+			items,
+			pricing,
+			shared.CryptoCurrency(model.CryptoCurrency),
+			network,
+			paymentTolerance,
+			expiration,
+			nil, // metadata
+		)
+	}
+
 	return invoice.NewInvoice(
 		model.ID,
 		model.MerchantID,
@@ -214,15 +492,627 @@ func (m *InvoiceMapper) setInvoiceProperties(inv *invoice.Invoice, model *Invoic
 	if model.CustomerID != nil {
 		inv.SetCustomerID(*model.CustomerID)
 	}
+	if model.Number != nil {
+		inv.SetNumber(*model.Number)
+	}
+
+	// Restore the amendment chain
+	if model.Supersedes != nil {
+		inv.SetSupersedes(*model.Supersedes)
+	}
+	if model.SupersededBy != nil {
+		inv.SetSupersededBy(*model.SupersededBy)
+	}
+	inv.SetRedirectURLs(model.ReturnURL, model.CancelURL)
+
+	if model.PaymentReference != nil {
+		var fingerprint int
+		var places int32
+		if model.AmountFingerprint != nil && model.FingerprintPlaces != nil {
+			fingerprint, places = *model.AmountFingerprint, *model.FingerprintPlaces
+		}
+		inv.SetPaymentReference(invoice.RestorePaymentReference(*model.PaymentReference, fingerprint, places))
+	}
 
 	// Set status from database
 	status := invoice.InvoiceStatus(model.Status)
 	inv.SetStatus(status)
 
-	// Set paid at if present
-	// Note: This would require a method to set paidAt, which might not exist
-	// For now, we'll skip this as the domain model handles it internally
-	_ = model.PaidAt
+	// Keep the stored timestamps rather than those of the freshly built invoice
+	inv.SetCreatedAt(model.CreatedAt)
+	inv.SetPaidAt(model.PaidAt)
+	inv.SetVersion(model.Version)
+}
+
+// discountRecord is the JSONB representation of a discount.
+type discountRecord struct {
+	Code  string `json:"code,omitempty"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// discountsRecord is the JSONB representation of the invoice-level discount and applied coupon.
+type discountsRecord struct {
+	Invoice *discountRecord `json:"invoice,omitempty"`
+	Coupon  *discountRecord `json:"coupon,omitempty"`
+}
+
+// setDiscounts restores the invoice-level discount and applied coupon of an invoice.
+func (m *InvoiceMapper) setDiscounts(inv *invoice.Invoice, discountsJSON *string) error {
+	if discountsJSON == nil || *discountsJSON == "" {
+		return nil
+	}
+
+	var record discountsRecord
+	if err := json.Unmarshal([]byte(*discountsJSON), &record); err != nil {
+		return fmt.Errorf("failed to unmarshal discounts: %w", err)
+	}
+
+	if record.Invoice != nil {
+		discount, err := invoice.NewDiscount(invoice.DiscountType(record.Invoice.Type), record.Invoice.Value)
+		if err != nil {
+			return fmt.Errorf("failed to restore invoice discount: %w", err)
+		}
+		inv.SetDiscount(discount)
+	}
+
+	if record.Coupon != nil {
+		discount, err := invoice.NewDiscount(invoice.DiscountType(record.Coupon.Type), record.Coupon.Value)
+		if err != nil {
+			return fmt.Errorf("failed to restore coupon discount: %w", err)
+		}
+		coupon, err := invoice.NewAppliedCoupon(record.Coupon.Code, discount)
+		if err != nil {
+			return fmt.Errorf("failed to restore coupon: %w", err)
+		}
+		inv.SetCoupon(coupon)
+	}
+
+	return nil
+}
+
+// SerializeDiscounts converts the invoice-level discount and applied coupon to a JSON string, or nil when
+// neither is set.
+func (m *InvoiceMapper) SerializeDiscounts(discount *invoice.Discount, coupon *invoice.AppliedCoupon) (*string, error) {
+	if discount == nil && coupon == nil {
+		return nil, nil
+	}
+
+	var record discountsRecord
+	if discount != nil {
+		record.Invoice = &discountRecord{
+			Type:  discount.Type().String(),
+			Value: discount.Value().String(),
+		}
+	}
+	if coupon != nil {
+		record.Coupon = &discountRecord{
+			Code:  coupon.Code(),
+			Type:  coupon.Discount().Type().String(),
+			Value: coupon.Discount().Value().String(),
+		}
+	}
+
+	jsonBytes, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	discountsJSON := string(jsonBytes)
+	return &discountsJSON, nil
+}
+
+// refundRecord is the JSONB representation of an invoice refund.
+type refundRecord struct {
+	ID          string    `json:"id"`
+	Amount      string    `json:"amount"`
+	Reason      string    `json:"reason,omitempty"`
+	RequestedBy string    `json:"requested_by,omitempty"`
+	Destination string    `json:"destination,omitempty"` // Empty for refunds recorded before destinations were required
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// setPaymentTotals restores the amount paid and refunds of an invoice.
+func (m *InvoiceMapper) setPaymentTotals(inv *invoice.Invoice, model *InvoiceModel) error {
+	if model.AmountPaid != nil {
+		amountPaid, err := shared.NewMoneyWithCrypto(*model.AmountPaid, inv.CryptoCurrency())
+		if err != nil {
+			return fmt.Errorf("failed to parse amount paid: %w", err)
+		}
+		inv.SetAmountPaid(amountPaid)
+	}
+
+	if model.Refunds == nil || *model.Refunds == "" {
+		return nil
+	}
+
+	var records []refundRecord
+	if err := json.Unmarshal([]byte(*model.Refunds), &records); err != nil {
+		return fmt.Errorf("failed to unmarshal refunds: %w", err)
+	}
+
+	refunds := make([]*invoice.Refund, len(records))
+	for i, record := range records {
+		amount, err := shared.NewMoneyWithCrypto(record.Amount, inv.CryptoCurrency())
+		if err != nil {
+			return fmt.Errorf("failed to parse refund amount: %w", err)
+		}
+		refund, err := invoice.NewRefund(record.ID, amount, record.Reason, record.RequestedBy, record.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to restore refund: %w", err)
+		}
+		refund.SetDestination(record.Destination)
+		refunds[i] = refund
+	}
+	inv.SetRefunds(refunds)
+	return nil
+}
+
+// SerializeRefunds converts invoice refunds to a JSON string, or nil when there are none.
+func (m *InvoiceMapper) SerializeRefunds(refunds []*invoice.Refund) (*string, error) {
+	if len(refunds) == 0 {
+		return nil, nil
+	}
+
+	records := make([]refundRecord, len(refunds))
+	for i, refund := range refunds {
+		records[i] = refundRecord{
+			ID:          refund.ID(),
+			Amount:      refund.Amount().Amount().String(),
+			Reason:      refund.Reason(),
+			RequestedBy: refund.RequestedBy(),
+			Destination: refund.Destination(),
+			CreatedAt:   refund.CreatedAt(),
+		}
+	}
+
+	jsonBytes, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+	refundsJSON := string(jsonBytes)
+	return &refundsJSON, nil
+}
+
+// requoteRecord is the JSONB representation of an invoice re-quote.
+type requoteRecord struct {
+	PreviousRate   string    `json:"previous_rate"`
+	NewRate        string    `json:"new_rate"`
+	PreviousAmount string    `json:"previous_amount"`
+	NewAmount      string    `json:"new_amount"`
+	Source         string    `json:"source"`
+	RequotedAt     time.Time `json:"requoted_at"`
+}
+
+// setRequotes restores the re-quotes of an invoice.
+func (m *InvoiceMapper) setRequotes(inv *invoice.Invoice, requotesJSON *string) error {
+	if requotesJSON == nil || *requotesJSON == "" {
+		return nil
+	}
+
+	var records []requoteRecord
+	if err := json.Unmarshal([]byte(*requotesJSON), &records); err != nil {
+		return fmt.Errorf("failed to unmarshal requotes: %w", err)
+	}
+
+	requotes := make([]*invoice.Requote, len(records))
+	for i, record := range records {
+		requote, err := m.restoreRequote(inv.CryptoCurrency(), record)
+		if err != nil {
+			return fmt.Errorf("failed to restore requote: %w", err)
+		}
+		requotes[i] = requote
+	}
+	inv.SetRequotes(requotes)
+	return nil
+}
+
+// restoreRequote converts a re-quote record back to a domain re-quote.
+func (m *InvoiceMapper) restoreRequote(currency shared.CryptoCurrency, record requoteRecord) (*invoice.Requote, error) {
+	previousRate, err := decimal.NewFromString(record.PreviousRate)
+	if err != nil {
+		return nil, err
+	}
+	newRate, err := decimal.NewFromString(record.NewRate)
+	if err != nil {
+		return nil, err
+	}
+	previousAmount, err := shared.NewMoneyWithCrypto(record.PreviousAmount, currency)
+	if err != nil {
+		return nil, err
+	}
+	newAmount, err := shared.NewMoneyWithCrypto(record.NewAmount, currency)
+	if err != nil {
+		return nil, err
+	}
+	return invoice.NewRequote(previousRate, newRate, previousAmount, newAmount, record.Source, record.RequotedAt)
+}
+
+// SerializeRequotes converts invoice re-quotes to a JSON string, or nil when there are none.
+func (m *InvoiceMapper) SerializeRequotes(requotes []*invoice.Requote) (*string, error) {
+	if len(requotes) == 0 {
+		return nil, nil
+	}
+
+	records := make([]requoteRecord, len(requotes))
+	for i, requote := range requotes {
+		records[i] = requoteRecord{
+			PreviousRate:   requote.PreviousRate().String(),
+			NewRate:        requote.NewRate().String(),
+			PreviousAmount: requote.PreviousAmount().Amount().String(),
+			NewAmount:      requote.NewAmount().Amount().String(),
+			Source:         requote.Source(),
+			RequotedAt:     requote.RequotedAt(),
+		}
+	}
+
+	jsonBytes, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+	requotesJSON := string(jsonBytes)
+	return &requotesJSON, nil
+}
+
+// extensionRecord is the JSONB representation of an invoice extension.
+type extensionRecord struct {
+	PreviousExpiresAt time.Time `json:"previous_expires_at"`
+	ExpiresAt         time.Time `json:"expires_at"`
+	ExtendedAt        time.Time `json:"extended_at"`
+}
+
+// setExtensions restores the extensions of an invoice.
+func (m *InvoiceMapper) setExtensions(inv *invoice.Invoice, extensionsJSON *string) error {
+	if extensionsJSON == nil || *extensionsJSON == "" {
+		return nil
+	}
+
+	var records []extensionRecord
+	if err := json.Unmarshal([]byte(*extensionsJSON), &records); err != nil {
+		return fmt.Errorf("failed to unmarshal extensions: %w", err)
+	}
+
+	extensions := make([]*invoice.Extension, len(records))
+	for i, record := range records {
+		extension, err := invoice.NewExtension(record.PreviousExpiresAt, record.ExpiresAt, record.ExtendedAt)
+		if err != nil {
+			return fmt.Errorf("failed to restore extension: %w", err)
+		}
+		extensions[i] = extension
+	}
+	inv.SetExtensions(extensions)
+	return nil
+}
+
+// SerializeExtensions converts invoice extensions to a JSON string, or nil when there are none.
+func (m *InvoiceMapper) SerializeExtensions(extensions []*invoice.Extension) (*string, error) {
+	if len(extensions) == 0 {
+		return nil, nil
+	}
+
+	records := make([]extensionRecord, len(extensions))
+	for i, extension := range extensions {
+		records[i] = extensionRecord{
+			PreviousExpiresAt: extension.PreviousExpiresAt(),
+			ExpiresAt:         extension.ExpiresAt(),
+			ExtendedAt:        extension.ExtendedAt(),
+		}
+	}
+
+	jsonBytes, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+	extensionsJSON := string(jsonBytes)
+	return &extensionsJSON, nil
+}
+
+// staleRateCheckRecord is the JSONB representation of an invoice stale rate check.
+type staleRateCheckRecord struct {
+	PaymentID   string    `json:"payment_id"`
+	Action      string    `json:"action"`
+	LockedRate  string    `json:"locked_rate"`
+	CurrentRate string    `json:"current_rate,omitempty"` // Empty when no rate could be quoted
+	Reason      string    `json:"reason"`
+	CheckedAt   time.Time `json:"checked_at"`
+}
+
+// setStaleRateChecks restores the stale rate checks of an invoice.
+func (m *InvoiceMapper) setStaleRateChecks(inv *invoice.Invoice, checksJSON *string) error {
+	if checksJSON == nil || *checksJSON == "" {
+		return nil
+	}
+
+	var records []staleRateCheckRecord
+	if err := json.Unmarshal([]byte(*checksJSON), &records); err != nil {
+		return fmt.Errorf("failed to unmarshal stale rate checks: %w", err)
+	}
+
+	checks := make([]*invoice.StaleRateCheck, len(records))
+	for i, record := range records {
+		check, err := m.restoreStaleRateCheck(record)
+		if err != nil {
+			return fmt.Errorf("failed to restore stale rate check: %w", err)
+		}
+		checks[i] = check
+	}
+	inv.SetStaleRateChecks(checks)
+	return nil
+}
+
+// restoreStaleRateCheck converts a stale rate check record back to a domain stale rate check.
+func (m *InvoiceMapper) restoreStaleRateCheck(record staleRateCheckRecord) (*invoice.StaleRateCheck, error) {
+	lockedRate, err := decimal.NewFromString(record.LockedRate)
+	if err != nil {
+		return nil, err
+	}
+	currentRate := decimal.Zero
+	if record.CurrentRate != "" {
+		if currentRate, err = decimal.NewFromString(record.CurrentRate); err != nil {
+			return nil, err
+		}
+	}
+	return invoice.NewStaleRateCheck(record.PaymentID, invoice.StaleRateAction(record.Action), lockedRate,
+		currentRate, record.Reason, record.CheckedAt)
+}
+
+// SerializeStaleRateChecks converts invoice stale rate checks to a JSON string, or nil when there are none.
+func (m *InvoiceMapper) SerializeStaleRateChecks(checks []*invoice.StaleRateCheck) (*string, error) {
+	if len(checks) == 0 {
+		return nil, nil
+	}
+
+	records := make([]staleRateCheckRecord, len(checks))
+	for i, check := range checks {
+		records[i] = staleRateCheckRecord{
+			PaymentID:  check.PaymentID(),
+			Action:     string(check.Action()),
+			LockedRate: check.LockedRate().String(),
+			Reason:     check.Reason(),
+			CheckedAt:  check.CheckedAt(),
+		}
+		if !check.CurrentRate().IsZero() {
+			records[i].CurrentRate = check.CurrentRate().String()
+		}
+	}
+
+	jsonBytes, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+	checksJSON := string(jsonBytes)
+	return &checksJSON, nil
+}
+
+// paymentReversalRecord is the JSONB representation of an invoice payment reversal.
+type paymentReversalRecord struct {
+	PaymentID       string    `json:"payment_id"`
+	TransactionHash string    `json:"transaction_hash,omitempty"`
+	Amount          string    `json:"amount"`
+	PreviousStatus  string    `json:"previous_status"`
+	Status          string    `json:"status"`
+	Reason          string    `json:"reason"`
+	ReversedAt      time.Time `json:"reversed_at"`
+}
+
+// setPaymentReversals restores the payment reversals of an invoice.
+func (m *InvoiceMapper) setPaymentReversals(inv *invoice.Invoice, reversalsJSON *string) error {
+	if reversalsJSON == nil || *reversalsJSON == "" {
+		return nil
+	}
+
+	var records []paymentReversalRecord
+	if err := json.Unmarshal([]byte(*reversalsJSON), &records); err != nil {
+		return fmt.Errorf("failed to unmarshal payment reversals: %w", err)
+	}
+
+	reversals := make([]*invoice.PaymentReversal, len(records))
+	for i, record := range records {
+		amount, err := shared.NewMoneyWithCrypto(record.Amount, inv.CryptoCurrency())
+		if err != nil {
+			return fmt.Errorf("failed to restore payment reversal amount: %w", err)
+		}
+		reversal, err := invoice.NewPaymentReversal(record.PaymentID, record.TransactionHash, amount,
+			invoice.InvoiceStatus(record.PreviousStatus), invoice.InvoiceStatus(record.Status), record.Reason,
+			record.ReversedAt)
+		if err != nil {
+			return fmt.Errorf("failed to restore payment reversal: %w", err)
+		}
+		reversals[i] = reversal
+	}
+	inv.SetPaymentReversals(reversals)
+	return nil
+}
+
+// SerializePaymentReversals converts invoice payment reversals to a JSON string, or nil when there are none.
+func (m *InvoiceMapper) SerializePaymentReversals(reversals []*invoice.PaymentReversal) (*string, error) {
+	if len(reversals) == 0 {
+		return nil, nil
+	}
+
+	records := make([]paymentReversalRecord, len(reversals))
+	for i, reversal := range reversals {
+		records[i] = paymentReversalRecord{
+			PaymentID:       reversal.PaymentID(),
+			TransactionHash: reversal.TransactionHash(),
+			Amount:          reversal.Amount().Amount().String(),
+			PreviousStatus:  reversal.PreviousStatus().String(),
+			Status:          reversal.Status().String(),
+			Reason:          reversal.Reason(),
+			ReversedAt:      reversal.ReversedAt(),
+		}
+	}
+
+	jsonBytes, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+	reversalsJSON := string(jsonBytes)
+	return &reversalsJSON, nil
+}
+
+// refundDestinationRecord is the JSONB representation of an invoice's refund destination.
+type refundDestinationRecord struct {
+	Address     string     `json:"address"`
+	Source      string     `json:"source"`
+	Verified    bool       `json:"verified,omitempty"`
+	CapturedAt  time.Time  `json:"captured_at"`
+	ConfirmedBy string     `json:"confirmed_by,omitempty"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+}
+
+// setRefundDestination restores the refund destination of an invoice.
+func (m *InvoiceMapper) setRefundDestination(inv *invoice.Invoice, destinationJSON *string) error {
+	if destinationJSON == nil || *destinationJSON == "" {
+		return nil
+	}
+
+	var record refundDestinationRecord
+	if err := json.Unmarshal([]byte(*destinationJSON), &record); err != nil {
+		return fmt.Errorf("failed to unmarshal refund destination: %w", err)
+	}
+
+	destination, err := invoice.NewRefundDestination(record.Address, invoice.RefundDestinationSource(record.Source),
+		record.Verified, record.CapturedAt)
+	if err != nil {
+		return fmt.Errorf("failed to restore refund destination: %w", err)
+	}
+	if record.ConfirmedAt != nil {
+		destination.SetConfirmation(record.ConfirmedBy, *record.ConfirmedAt)
+	}
+	inv.SetRefundDestination(destination)
+	return nil
+}
+
+// SerializeRefundDestination converts an invoice's refund destination to a JSON string, or nil when it has none.
+func (m *InvoiceMapper) SerializeRefundDestination(destination *invoice.RefundDestination) (*string, error) {
+	if destination == nil {
+		return nil, nil
+	}
+
+	jsonBytes, err := json.Marshal(refundDestinationRecord{
+		Address:     destination.Address(),
+		Source:      string(destination.Source()),
+		Verified:    destination.Verified(),
+		CapturedAt:  destination.CapturedAt(),
+		ConfirmedBy: destination.ConfirmedBy(),
+		ConfirmedAt: destination.ConfirmedAt(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	destinationJSON := string(jsonBytes)
+	return &destinationJSON, nil
+}
+
+// customFieldRecord is the JSONB representation of a custom field of an invoice.
+type customFieldRecord struct {
+	Label      string `json:"label"`
+	Value      string `json:"value"`
+	Type       string `json:"type"`
+	Visibility string `json:"visibility"`
+}
+
+// setCustomFields restores the custom fields of an invoice.
+func (m *InvoiceMapper) setCustomFields(inv *invoice.Invoice, fieldsJSON *string) error {
+	if fieldsJSON == nil || *fieldsJSON == "" {
+		return nil
+	}
+
+	var records []customFieldRecord
+	if err := json.Unmarshal([]byte(*fieldsJSON), &records); err != nil {
+		return fmt.Errorf("failed to unmarshal custom fields: %w", err)
+	}
+
+	fields := make([]*invoice.CustomField, len(records))
+	for i, record := range records {
+		field, err := invoice.NewCustomField(record.Label, record.Value, invoice.CustomFieldType(record.Type),
+			invoice.CustomFieldVisibility(record.Visibility))
+		if err != nil {
+			return fmt.Errorf("failed to restore custom field %d: %w", i, err)
+		}
+		fields[i] = field
+	}
+	inv.SetCustomFields(fields)
+	return nil
+}
+
+// SerializeCustomFields converts an invoice's custom fields to a JSON string, or nil when it has none.
+func (m *InvoiceMapper) SerializeCustomFields(fields []*invoice.CustomField) (*string, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	records := make([]customFieldRecord, len(fields))
+	for i, field := range fields {
+		records[i] = customFieldRecord{
+			Label:      field.Label(),
+			Value:      field.Value(),
+			Type:       string(field.Type()),
+			Visibility: string(field.Visibility()),
+		}
+	}
+	jsonBytes, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+	fieldsJSON := string(jsonBytes)
+	return &fieldsJSON, nil
+}
+
+// splitShareRecord is the JSONB representation of a beneficiary's share of an invoice's settlement.
+type splitShareRecord struct {
+	BeneficiaryID string `json:"beneficiary_id"`
+	Type          string `json:"type"`
+	Value         string `json:"value"`
+}
+
+// setSettlementSplit restores the settlement split of an invoice.
+func (m *InvoiceMapper) setSettlementSplit(inv *invoice.Invoice, splitJSON *string) error {
+	if splitJSON == nil || *splitJSON == "" {
+		return nil
+	}
+
+	var records []splitShareRecord
+	if err := json.Unmarshal([]byte(*splitJSON), &records); err != nil {
+		return fmt.Errorf("failed to unmarshal settlement split: %w", err)
+	}
+
+	shares := make([]*invoice.SplitShare, len(records))
+	for i, record := range records {
+		share, err := invoice.NewSplitShare(record.BeneficiaryID, invoice.SplitShareType(record.Type), record.Value)
+		if err != nil {
+			return fmt.Errorf("failed to restore split share %d: %w", i, err)
+		}
+		shares[i] = share
+	}
+	split, err := invoice.NewSettlementSplit(shares)
+	if err != nil {
+		return fmt.Errorf("failed to restore settlement split: %w", err)
+	}
+	inv.SetSettlementSplit(split)
+	return nil
+}
+
+// SerializeSettlementSplit converts an invoice's settlement split to a JSON string, or nil when it has none.
+func (m *InvoiceMapper) SerializeSettlementSplit(split *invoice.SettlementSplit) (*string, error) {
+	if split == nil {
+		return nil, nil
+	}
+
+	records := make([]splitShareRecord, len(split.Shares()))
+	for i, share := range split.Shares() {
+		records[i] = splitShareRecord{
+			BeneficiaryID: share.BeneficiaryID(),
+			Type:          string(share.Type()),
+			Value:         share.Value().String(),
+		}
+	}
+	jsonBytes, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+	splitJSON := string(jsonBytes)
+	return &splitJSON, nil
 }
 
 // ToModel converts a domain entity to a database model.
@@ -242,6 +1132,16 @@ func (m *InvoiceMapper) ToModel(inv *invoice.Invoice) *InvoiceModel {
 				"quantity":    item.Quantity().String(),
 				"unit_price":  item.UnitPrice().Amount().String(),
 			}
+			if discount := item.Discount(); discount != nil {
+				itemData[i]["discount_type"] = discount.Type().String()
+				itemData[i]["discount_value"] = discount.Value().String()
+			}
+			if item.TaxCategory() != invoice.DefaultTaxCategory {
+				itemData[i]["tax_category"] = item.TaxCategory()
+			}
+			if lines := item.TaxLines(); len(lines) > 0 {
+				itemData[i]["tax_lines"] = m.serializeTaxLines(lines)
+			}
 		}
 		if jsonBytes, err := json.Marshal(itemData); err == nil {
 			itemsJSON = string(jsonBytes)
@@ -250,19 +1150,23 @@ func (m *InvoiceMapper) ToModel(inv *invoice.Invoice) *InvoiceModel {
 
 	// Get crypto amount
 	cryptoAmount := "0"
-	if cryptoAmountMoney, err := inv.GetCryptoAmount(); err == nil {
+	if cryptoAmountMoney, err := inv.LockedCryptoAmount(); err == nil {
 		cryptoAmount = cryptoAmountMoney.Amount().String()
 	}
 
 	model := &InvoiceModel{
 		ID:             inv.ID(),
 		MerchantID:     inv.MerchantID(),
+		Number:         optionalNumber(inv.Number()),
 		CustomerID:     inv.CustomerID(), // This is already *string
 		Title:          inv.Title(),
 		Description:    inv.Description(),
+		Type:           inv.Type().String(),
 		Items:          itemsJSON,
 		Subtotal:       inv.Pricing().Subtotal().Amount().String(),
+		Discount:       inv.Pricing().Discount().Amount().String(),
 		Tax:            inv.Pricing().Tax().Amount().String(),
+		Fee:            inv.Pricing().Fee().Amount().String(),
 		Total:          inv.Pricing().Total().Amount().String(),
 		Currency:       inv.Pricing().Subtotal().Currency(),
 		CryptoCurrency: inv.CryptoCurrency().String(),
@@ -270,7 +1174,12 @@ func (m *InvoiceMapper) ToModel(inv *invoice.Invoice) *InvoiceModel {
 		Status:         inv.Status().String(),
 		CreatedAt:      inv.CreatedAt(),
 		UpdatedAt:      inv.UpdatedAt(),
+		Version:        inv.Version(),
 		PaidAt:         inv.PaidAt(),
+		Supersedes:     inv.Supersedes(),
+		SupersededBy:   inv.SupersededBy(),
+		ReturnURL:      inv.ReturnURL(),
+		CancelURL:      inv.CancelURL(),
 	}
 
 	// Set payment address if present
@@ -278,18 +1187,36 @@ func (m *InvoiceMapper) ToModel(inv *invoice.Invoice) *InvoiceModel {
 		address := inv.PaymentAddress().String()
 		model.PaymentAddress = &address
 	}
+	if network := inv.Network().String(); network != "" {
+		model.Network = &network
+	}
+	if reference := inv.PaymentReference(); reference != nil {
+		code := reference.Code()
+		model.PaymentReference = &code
+		if reference.IsFingerprinted() {
+			fingerprint, places := reference.Fingerprint(), reference.Places()
+			model.AmountFingerprint = &fingerprint
+			model.FingerprintPlaces = &places
+		}
+	}
 
-	// Set expiration if present
-	if inv.Expiration() != nil {
+	// Set expiration if present; a draft only has the window it stays payable for
+	if inv.Expiration() != nil && inv.IsDraft() {
+		expiresIn := int64(inv.Expiration().Duration() / time.Second)
+		model.ExpiresIn = &expiresIn
+	} else if inv.Expiration() != nil {
 		expiresAt := inv.Expiration().ExpiresAt()
 		model.ExpiresAt = &expiresAt
 	}
 
-	// Serialize exchange rate to JSONB
+	// Serialize exchange rate to JSONB; drafts store a JSON null until they are finalized
+	model.ExchangeRate = "null"
 	if inv.ExchangeRate() != nil {
 		if exchangeRateJSON, err := m.SerializeExchangeRate(inv.ExchangeRate()); err == nil {
 			model.ExchangeRate = exchangeRateJSON
 		}
+		rateExpiresAt := inv.ExchangeRate().ExpiresAt()
+		model.RateExpiresAt = &rateExpiresAt
 	}
 
 	// Serialize payment tolerance to JSONB
@@ -299,9 +1226,88 @@ func (m *InvoiceMapper) ToModel(inv *invoice.Invoice) *InvoiceModel {
 		}
 	}
 
+	if minimum := inv.MinimumAmount(); minimum != nil {
+		amount := minimum.Amount().String()
+		model.MinimumAmount = &amount
+	}
+
+	if fee := inv.FeePassThrough(); fee != nil {
+		percentage := fee.Percentage().String()
+		model.FeePercentage = &percentage
+	}
+
+	if amountPaid := inv.AmountPaid(); amountPaid != nil {
+		amount := amountPaid.Amount().String()
+		model.AmountPaid = &amount
+	}
+
+	// Serialize discounts to JSONB
+	if discountsJSON, err := m.SerializeDiscounts(inv.Discount(), inv.Coupon()); err == nil {
+		model.Discounts = discountsJSON
+	}
+
+	// Serialize tax treatment to JSONB
+	if treatmentJSON, err := m.SerializeTaxTreatment(inv.TaxTreatment()); err == nil {
+		model.TaxTreatment = treatmentJSON
+	}
+
+	// Serialize refunds to JSONB
+	if refundsJSON, err := m.SerializeRefunds(inv.Refunds()); err == nil {
+		model.Refunds = refundsJSON
+	}
+
+	// Serialize requotes to JSONB
+	if requotesJSON, err := m.SerializeRequotes(inv.Requotes()); err == nil {
+		model.Requotes = requotesJSON
+	}
+
+	// Serialize extensions to JSONB
+	if extensionsJSON, err := m.SerializeExtensions(inv.Extensions()); err == nil {
+		model.Extensions = extensionsJSON
+	}
+
+	// Serialize stale rate checks to JSONB
+	if checksJSON, err := m.SerializeStaleRateChecks(inv.StaleRateChecks()); err == nil {
+		model.StaleRateChecks = checksJSON
+	}
+
+	// Serialize payment reversals to JSONB
+	if reversalsJSON, err := m.SerializePaymentReversals(inv.PaymentReversals()); err == nil {
+		model.PaymentReversals = reversalsJSON
+	}
+
+	// Serialize refund destination to JSONB
+	if destinationJSON, err := m.SerializeRefundDestination(inv.RefundDestination()); err == nil {
+		model.RefundDestination = destinationJSON
+	}
+
+	// Serialize custom fields to JSONB
+	if fieldsJSON, err := m.SerializeCustomFields(inv.CustomFields()); err == nil {
+		model.CustomFields = fieldsJSON
+	}
+
+	// Serialize settlement split to JSONB
+	if splitJSON, err := m.SerializeSettlementSplit(inv.SettlementSplit()); err == nil {
+		model.SettlementSplit = splitJSON
+	}
+
+	// Serialize metadata to JSONB
+	if metadataJSON, err := marshalSnapshot(inv.Metadata()); err == nil {
+		model.Metadata = metadataJSON
+	}
+
 	return model
 }
 
+// optionalNumber returns the stored form of an accounting number: nil until the invoice has one, so the
+// unique index on merchant and number ignores unnumbered invoices.
+func optionalNumber(number string) *string {
+	if number == "" {
+		return nil
+	}
+	return &number
+}
+
 // ToDomainSlice converts multiple database models to domain entities.
 func (m *InvoiceMapper) ToDomainSlice(models []InvoiceModel) ([]*invoice.Invoice, error) {
 	invoices := make([]*invoice.Invoice, len(models))
@@ -360,7 +1366,7 @@ func (m *InvoiceMapper) SerializePaymentTolerance(pt *invoice.PaymentTolerance)
 
 // DeserializeExchangeRate converts a JSON string to an ExchangeRate.
 func (m *InvoiceMapper) DeserializeExchangeRate(jsonStr string) (*shared.ExchangeRate, error) {
-	if jsonStr == "" {
+	if jsonStr == "" || jsonStr == "null" {
 		return nil, nil
 	}
 
@@ -409,14 +1415,13 @@ func (m *InvoiceMapper) DeserializeExchangeRate(jsonStr string) (*shared.Exchang
 		return nil, fmt.Errorf("invalid expires_at time format: %w", err)
 	}
 
-	// Create exchange rate with calculated duration
-	duration := expiresAt.Sub(lockedAt)
-	exchangeRate, err := shared.NewExchangeRate(
+	exchangeRate, err := shared.RestoreExchangeRate(
 		rate,
 		shared.Currency(from),
 		shared.CryptoCurrency(to),
 		source,
-		duration,
+		lockedAt,
+		expiresAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create exchange rate: %w", err)
@@ -78,6 +78,445 @@ func TestInvoiceMapper(t *testing.T) {
 			require.False(t, expiration.IsExpired())
 		})
 
+		t.Run("Discounts", func(t *testing.T) {
+			itemsJSON := `[{"name": "Test Item", "quantity": "2", "unit_price": "10.00", ` +
+				`"discount_type": "percentage", "discount_value": "10"}]`
+			model := &database.InvoiceModel{
+				ID:             "test-invoice-id",
+				MerchantID:     "test-merchant-id",
+				Title:          "Test Invoice",
+				Items:          itemsJSON,
+				Subtotal:       "18.00",
+				Discount:       "6.00",
+				Discounts:      stringPtr(`{"invoice": {"type": "fixed", "value": "3"}, "coupon": {"code": "SAVE20", "type": "percentage", "value": "20"}}`),
+				Tax:            "1.20",
+				Total:          "13.20",
+				Currency:       "USD",
+				CryptoCurrency: "USDT",
+				PaymentAddress: stringPtr("TTestAddress123456789012345678901234567890"),
+				Status:         "created",
+				ExpiresAt:      timePtr(time.Now().Add(30 * time.Minute)),
+				CreatedAt:      time.Now(),
+				UpdatedAt:      time.Now(),
+			}
+
+			domain, err := mapper.ToDomain(model)
+			require.NoError(t, err)
+
+			item := domain.Items()[0]
+			require.Equal(t, invoice.DiscountTypePercentage, item.Discount().Type())
+			require.Equal(t, "2.00", item.DiscountAmount().String())
+			require.Equal(t, "18.00", item.TotalPrice().String())
+			require.Equal(t, "6.00", domain.Pricing().Discount().String())
+
+			require.NotNil(t, domain.Discount())
+			require.Equal(t, invoice.DiscountTypeFixed, domain.Discount().Type())
+			require.NotNil(t, domain.Coupon())
+			require.Equal(t, "SAVE20", domain.Coupon().Code())
+
+			invoiceDiscount, err := domain.InvoiceDiscountAmount()
+			require.NoError(t, err)
+			require.Equal(t, "3.00", invoiceDiscount.String())
+			couponDiscount, err := domain.CouponDiscountAmount()
+			require.NoError(t, err)
+			require.Equal(t, "3.00", couponDiscount.String())
+
+			roundTrip := mapper.ToModel(domain)
+			require.Equal(t, "6", roundTrip.Discount)
+			require.NotNil(t, roundTrip.Discounts)
+			require.JSONEq(t, *model.Discounts, *roundTrip.Discounts)
+			require.Contains(t, roundTrip.Items, `"discount_type":"percentage"`)
+		})
+
+		t.Run("TaxLines", func(t *testing.T) {
+			itemsJSON := `[{"name": "E-book", "description": "", "quantity": "1", "unit_price": "10", "tax_category": "reduced", ` +
+				`"tax_lines": [{"name": "VAT", "type": "vat", "jurisdiction": "FR", "rate": "0.055", ` +
+				`"reverse_charge": true, "taxable_amount": "10", "amount": "0"}]}]`
+			model := &database.InvoiceModel{
+				ID:             "test-invoice-id",
+				MerchantID:     "test-merchant-id",
+				Title:          "Test Invoice",
+				Items:          itemsJSON,
+				Subtotal:       "10.00",
+				Tax:            "0.00",
+				TaxTreatment:   stringPtr(`{"jurisdiction": "FR", "customer_tax_id": "FR40303265045", "reverse_charge": true}`),
+				Total:          "10.00",
+				Currency:       "USD",
+				CryptoCurrency: "USDT",
+				PaymentAddress: stringPtr("TTestAddress123456789012345678901234567890"),
+				Status:         "created",
+				ExpiresAt:      timePtr(time.Now().Add(30 * time.Minute)),
+				CreatedAt:      time.Now(),
+				UpdatedAt:      time.Now(),
+			}
+
+			domain, err := mapper.ToDomain(model)
+			require.NoError(t, err)
+
+			item := domain.Items()[0]
+			require.Equal(t, "reduced", item.TaxCategory())
+			require.Len(t, item.TaxLines(), 1)
+			require.True(t, item.TaxLines()[0].Rate().IsReverseCharge())
+			require.Equal(t, "0.055", item.TaxLines()[0].Rate().Rate().String())
+
+			require.NotNil(t, domain.TaxTreatment())
+			require.Equal(t, "FR40303265045", domain.TaxTreatment().CustomerTaxID())
+			require.True(t, domain.TaxTreatment().IsReverseCharge())
+
+			roundTrip := mapper.ToModel(domain)
+			require.NotNil(t, roundTrip.TaxTreatment)
+			require.JSONEq(t, *model.TaxTreatment, *roundTrip.TaxTreatment)
+			require.JSONEq(t, itemsJSON, roundTrip.Items)
+		})
+
+		t.Run("Donation", func(t *testing.T) {
+			model := &database.InvoiceModel{
+				ID:             "test-invoice-id",
+				MerchantID:     "test-merchant-id",
+				Title:          "Support our work",
+				Type:           "donation",
+				MinimumAmount:  stringPtr("5.00"),
+				Items:          `[{"name": "Support our work", "description": "", "quantity": "1", "unit_price": "20"}]`,
+				Subtotal:       "20.00",
+				Tax:            "0.00",
+				Total:          "20.00",
+				Currency:       "USD",
+				CryptoCurrency: "USDT",
+				PaymentAddress: stringPtr("TTestAddress123456789012345678901234567890"),
+				Status:         "partial",
+				ExpiresAt:      timePtr(time.Now().Add(30 * time.Minute)),
+				CreatedAt:      time.Now(),
+				UpdatedAt:      time.Now(),
+				AmountPaid:     stringPtr("2.5"),
+			}
+
+			domain, err := mapper.ToDomain(model)
+			require.NoError(t, err)
+			require.True(t, domain.IsDonation())
+			require.Equal(t, "5.00", domain.MinimumAmount().String())
+			require.Equal(t, "2.5", domain.AmountPaid().Amount().String())
+
+			roundTrip := mapper.ToModel(domain)
+			require.Equal(t, "donation", roundTrip.Type)
+			require.NotNil(t, roundTrip.MinimumAmount)
+			require.Equal(t, "5", *roundTrip.MinimumAmount)
+		})
+
+		t.Run("Draft", func(t *testing.T) {
+			model := &database.InvoiceModel{
+				ID:             "test-invoice-id",
+				MerchantID:     "test-merchant-id",
+				Title:          "Website redesign",
+				Items:          `[{"name": "Design", "description": "", "quantity": "1", "unit_price": "100"}]`,
+				Subtotal:       "100.00",
+				Tax:            "10.00",
+				Total:          "110.00",
+				Currency:       "USD",
+				CryptoCurrency: "USDC",
+				Network:        stringPtr("ethereum"),
+				Status:         "draft",
+				ExchangeRate:   "null",
+				ExpiresIn:      int64Ptr(7200),
+				CreatedAt:      time.Now(),
+				UpdatedAt:      time.Now(),
+			}
+
+			domain, err := mapper.ToDomain(model)
+			require.NoError(t, err)
+			require.True(t, domain.IsDraft())
+			require.Nil(t, domain.PaymentAddress())
+			require.Nil(t, domain.ExchangeRate())
+			require.Equal(t, shared.NetworkEthereum, domain.Network())
+			require.Equal(t, 2*time.Hour, domain.Expiration().Duration())
+
+			roundTrip := mapper.ToModel(domain)
+			require.Equal(t, "draft", roundTrip.Status)
+			require.Nil(t, roundTrip.PaymentAddress)
+			require.Equal(t, "ethereum", *roundTrip.Network)
+			require.Equal(t, "null", roundTrip.ExchangeRate)
+			require.Nil(t, roundTrip.ExpiresAt)
+			require.Equal(t, int64(7200), *roundTrip.ExpiresIn)
+		})
+
+		t.Run("Amended", func(t *testing.T) {
+			model := &database.InvoiceModel{
+				ID:             "test-invoice-id",
+				MerchantID:     "test-merchant-id",
+				Title:          "Website redesign",
+				Items:          `[{"name": "Design", "description": "", "quantity": "1", "unit_price": "100"}]`,
+				Subtotal:       "100.00",
+				Tax:            "0.00",
+				Total:          "100.00",
+				Currency:       "USD",
+				CryptoCurrency: "USDT",
+				PaymentAddress: stringPtr("TTestAddress123456789012345678901234567890"),
+				Status:         "cancelled",
+				ExpiresAt:      timePtr(time.Now().Add(30 * time.Minute)),
+				CreatedAt:      time.Now(),
+				UpdatedAt:      time.Now(),
+				Supersedes:     stringPtr("first-invoice-id"),
+				SupersededBy:   stringPtr("third-invoice-id"),
+			}
+
+			domain, err := mapper.ToDomain(model)
+			require.NoError(t, err)
+			require.Equal(t, "first-invoice-id", *domain.Supersedes())
+			require.Equal(t, "third-invoice-id", *domain.SupersededBy())
+
+			roundTrip := mapper.ToModel(domain)
+			require.Equal(t, "first-invoice-id", *roundTrip.Supersedes)
+			require.Equal(t, "third-invoice-id", *roundTrip.SupersededBy)
+		})
+
+		t.Run("Extended", func(t *testing.T) {
+			expiresAt := time.Date(2025, 1, 15, 11, 30, 0, 0, time.UTC)
+			model := &database.InvoiceModel{
+				ID:             "test-invoice-id",
+				MerchantID:     "test-merchant-id",
+				Title:          "Website redesign",
+				Items:          `[{"name": "Design", "description": "", "quantity": "1", "unit_price": "100"}]`,
+				Subtotal:       "100.00",
+				Tax:            "0.00",
+				Total:          "100.00",
+				Currency:       "USD",
+				CryptoCurrency: "USDT",
+				PaymentAddress: stringPtr("TTestAddress123456789012345678901234567890"),
+				Status:         "pending",
+				ExpiresAt:      &expiresAt,
+				CreatedAt:      time.Now(),
+				UpdatedAt:      time.Now(),
+				Extensions: stringPtr(`[{"previous_expires_at": "2025-01-15T10:30:00Z", ` +
+					`"expires_at": "2025-01-15T11:30:00Z", "extended_at": "2025-01-15T10:20:00Z"}]`),
+			}
+
+			domain, err := mapper.ToDomain(model)
+			require.NoError(t, err)
+			require.Len(t, domain.Extensions(), 1)
+			require.Equal(t, time.Hour, domain.ExtendedBy())
+
+			roundTrip := mapper.ToModel(domain)
+			require.NotNil(t, roundTrip.Extensions)
+			require.JSONEq(t, *model.Extensions, *roundTrip.Extensions)
+
+			model.Extensions = stringPtr(`[{"previous_expires_at": "2025-01-15T10:30:00Z", ` +
+				`"expires_at": "2025-01-15T10:00:00Z", "extended_at": "2025-01-15T10:20:00Z"}]`)
+			_, err = mapper.ToDomain(model)
+			require.Error(t, err)
+		})
+
+		t.Run("Stale_Rate_Checks", func(t *testing.T) {
+			model := &database.InvoiceModel{
+				ID:             "test-invoice-id",
+				MerchantID:     "test-merchant-id",
+				Title:          "Website redesign",
+				Items:          `[{"name": "Design", "description": "", "quantity": "1", "unit_price": "100"}]`,
+				Subtotal:       "100.00",
+				Tax:            "0.00",
+				Total:          "100.00",
+				Currency:       "USD",
+				CryptoCurrency: "USDT",
+				PaymentAddress: stringPtr("TTestAddress123456789012345678901234567890"),
+				Status:         "partial",
+				ExpiresAt:      timePtr(time.Now().Add(30 * time.Minute)),
+				CreatedAt:      time.Now(),
+				UpdatedAt:      time.Now(),
+				StaleRateChecks: stringPtr(`[` +
+					`{"payment_id": "payment-1", "action": "accept", "locked_rate": "1", "current_rate": "1.01", ` +
+					`"reason": "rate moved 1%, within 2%", "checked_at": "2025-01-15T10:40:00Z"}, ` +
+					`{"payment_id": "payment-2", "action": "review", "locked_rate": "1", ` +
+					`"reason": "current rate unavailable", "checked_at": "2025-01-15T10:45:00Z"}]`),
+			}
+
+			domain, err := mapper.ToDomain(model)
+			require.NoError(t, err)
+			require.Len(t, domain.StaleRateChecks(), 2)
+			require.Equal(t, invoice.StaleRateAccept, domain.StaleRateChecks()[0].Action())
+			require.Equal(t, "0.01", domain.StaleRateChecks()[0].Slippage().String())
+			require.True(t, domain.StaleRateChecks()[1].CurrentRate().IsZero())
+
+			roundTrip := mapper.ToModel(domain)
+			require.NotNil(t, roundTrip.StaleRateChecks)
+			require.JSONEq(t, *model.StaleRateChecks, *roundTrip.StaleRateChecks)
+		})
+
+		t.Run("Payment_Reversals", func(t *testing.T) {
+			model := &database.InvoiceModel{
+				ID:             "test-invoice-id",
+				MerchantID:     "test-merchant-id",
+				Title:          "Website redesign",
+				Items:          `[{"name": "Design", "description": "", "quantity": "1", "unit_price": "100"}]`,
+				Subtotal:       "100.00",
+				Tax:            "0.00",
+				Total:          "100.00",
+				Currency:       "USD",
+				CryptoCurrency: "USDT",
+				PaymentAddress: stringPtr("TTestAddress123456789012345678901234567890"),
+				Status:         "pending",
+				ExpiresAt:      timePtr(time.Now().Add(30 * time.Minute)),
+				CreatedAt:      time.Now(),
+				UpdatedAt:      time.Now(),
+				AmountPaid:     stringPtr("0"),
+				PaymentReversals: stringPtr(`[{"payment_id": "payment-1", "transaction_hash": "0xabc", ` +
+					`"amount": "100", "previous_status": "paid", "status": "pending", ` +
+					`"reason": "removed by a reorganization", "reversed_at": "2025-01-15T10:40:00Z"}]`),
+			}
+
+			domain, err := mapper.ToDomain(model)
+			require.NoError(t, err)
+			require.Len(t, domain.PaymentReversals(), 1)
+			reversal := domain.PaymentReversal("payment-1")
+			require.NotNil(t, reversal)
+			require.Equal(t, "100", reversal.Amount().Amount().String())
+			require.Equal(t, "USDT", reversal.Amount().Currency())
+			require.Equal(t, invoice.StatusPaid, reversal.PreviousStatus())
+
+			roundTrip := mapper.ToModel(domain)
+			require.NotNil(t, roundTrip.PaymentReversals)
+			require.JSONEq(t, *model.PaymentReversals, *roundTrip.PaymentReversals)
+		})
+
+		t.Run("Refund_Destination", func(t *testing.T) {
+			model := &database.InvoiceModel{
+				ID:             "test-invoice-id",
+				MerchantID:     "test-merchant-id",
+				Title:          "Website redesign",
+				Items:          `[{"name": "Design", "description": "", "quantity": "1", "unit_price": "100"}]`,
+				Subtotal:       "100.00",
+				Tax:            "0.00",
+				Total:          "100.00",
+				Currency:       "USD",
+				CryptoCurrency: "USDT",
+				CryptoAmount:   "100.00",
+				PaymentAddress: stringPtr("TTestAddress123456789012345678901234567890"),
+				Status:         "partially_refunded",
+				ExpiresAt:      timePtr(time.Now().Add(30 * time.Minute)),
+				CreatedAt:      time.Now(),
+				UpdatedAt:      time.Now(),
+				AmountPaid:     stringPtr("100"),
+				Refunds: stringPtr(`[{"id": "refund-1", "amount": "40", "reason": "returned item", ` +
+					`"destination": "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8", "created_at": "2025-01-15T11:00:00Z"}]`),
+				RefundDestination: stringPtr(`{"address": "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8", ` +
+					`"source": "customer", "verified": true, "captured_at": "2025-01-15T10:40:00Z", ` +
+					`"confirmed_by": "user-1", "confirmed_at": "2025-01-15T10:50:00Z"}`),
+			}
+
+			domain, err := mapper.ToDomain(model)
+			require.NoError(t, err)
+			require.NotNil(t, domain.RefundDestination())
+			require.Equal(t, invoice.RefundSourceCustomer, domain.RefundDestination().Source())
+			require.True(t, domain.RefundDestination().Verified())
+			address, err := domain.ConfirmedRefundAddress()
+			require.NoError(t, err)
+			require.Equal(t, "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8", address)
+			require.Equal(t, address, domain.Refunds()[0].Destination())
+
+			roundTrip := mapper.ToModel(domain)
+			require.NotNil(t, roundTrip.RefundDestination)
+			require.JSONEq(t, *model.RefundDestination, *roundTrip.RefundDestination)
+			require.JSONEq(t, *model.Refunds, *roundTrip.Refunds)
+		})
+
+		t.Run("Custom_Fields", func(t *testing.T) {
+			model := &database.InvoiceModel{
+				ID:             "test-invoice-id",
+				MerchantID:     "test-merchant-id",
+				Title:          "Concert ticket",
+				Items:          `[{"name": "Ticket", "description": "", "quantity": "1", "unit_price": "50"}]`,
+				Subtotal:       "50.00",
+				Tax:            "0.00",
+				Total:          "50.00",
+				Currency:       "USD",
+				CryptoCurrency: "USDT",
+				CryptoAmount:   "50.00",
+				PaymentAddress: stringPtr("TTestAddress123456789012345678901234567890"),
+				Status:         "created",
+				ExpiresAt:      timePtr(time.Now().Add(30 * time.Minute)),
+				CreatedAt:      time.Now(),
+				UpdatedAt:      time.Now(),
+				CustomFields: stringPtr(`[{"label": "Seat", "value": "F12", "type": "text", "visibility": "all"}, ` +
+					`{"label": "Show date", "value": "2025-02-01", "type": "date", "visibility": "receipt"}]`),
+			}
+
+			domain, err := mapper.ToDomain(model)
+			require.NoError(t, err)
+			require.Len(t, domain.CustomFields(), 2)
+			require.Equal(t, "Seat", domain.CustomFields()[0].Label())
+			require.Len(t, domain.CheckoutFields(), 1)
+			require.Len(t, domain.ReceiptFields(), 2)
+
+			roundTrip := mapper.ToModel(domain)
+			require.NotNil(t, roundTrip.CustomFields)
+			require.JSONEq(t, *model.CustomFields, *roundTrip.CustomFields)
+		})
+
+		t.Run("Settlement_Split", func(t *testing.T) {
+			model := &database.InvoiceModel{
+				ID:             "test-invoice-id",
+				MerchantID:     "test-merchant-id",
+				Title:          "Marketplace order",
+				Items:          `[{"name": "Lamp", "description": "", "quantity": "1", "unit_price": "100"}]`,
+				Subtotal:       "100.00",
+				Tax:            "0.00",
+				Total:          "100.00",
+				Currency:       "USD",
+				CryptoCurrency: "USDT",
+				CryptoAmount:   "100.00",
+				PaymentAddress: stringPtr("TTestAddress123456789012345678901234567890"),
+				Status:         "created",
+				ExpiresAt:      timePtr(time.Now().Add(30 * time.Minute)),
+				CreatedAt:      time.Now(),
+				UpdatedAt:      time.Now(),
+				SettlementSplit: stringPtr(`[{"beneficiary_id": "seller-1", "type": "percentage", "value": "80"}, ` +
+					`{"beneficiary_id": "courier-1", "type": "fixed", "value": "5"}]`),
+			}
+
+			domain, err := mapper.ToDomain(model)
+			require.NoError(t, err)
+			require.NotNil(t, domain.SettlementSplit())
+			shares := domain.SettlementSplit().Shares()
+			require.Len(t, shares, 2)
+			require.Equal(t, "seller-1", shares[0].BeneficiaryID())
+			require.Equal(t, invoice.SplitShareTypeFixed, shares[1].Type())
+
+			roundTrip := mapper.ToModel(domain)
+			require.NotNil(t, roundTrip.SettlementSplit)
+			require.JSONEq(t, *model.SettlementSplit, *roundTrip.SettlementSplit)
+		})
+
+		t.Run("Fee_Pass_Through", func(t *testing.T) {
+			model := &database.InvoiceModel{
+				ID:             "test-invoice-id",
+				MerchantID:     "test-merchant-id",
+				Title:          "Lamp",
+				Items:          `[{"name": "Lamp", "description": "", "quantity": "1", "unit_price": "100"}]`,
+				Subtotal:       "100.00",
+				Tax:            "0.00",
+				Fee:            "2.57",
+				FeePercentage:  stringPtr("2.5"),
+				Total:          "102.57",
+				Currency:       "USD",
+				CryptoCurrency: "USDT",
+				CryptoAmount:   "102.57",
+				PaymentAddress: stringPtr("TTestAddress123456789012345678901234567890"),
+				Status:         "created",
+				ExpiresAt:      timePtr(time.Now().Add(30 * time.Minute)),
+				CreatedAt:      time.Now(),
+				UpdatedAt:      time.Now(),
+			}
+
+			domain, err := mapper.ToDomain(model)
+			require.NoError(t, err)
+			require.NotNil(t, domain.FeePassThrough())
+			require.Equal(t, "2.5", domain.FeePassThrough().Percentage().String())
+			require.Equal(t, "2.57", domain.Pricing().Fee().String())
+			require.Equal(t, "100.00", domain.Pricing().TotalBeforeFee().String())
+
+			roundTrip := mapper.ToModel(domain)
+			require.Equal(t, "2.57", roundTrip.Fee)
+			require.NotNil(t, roundTrip.FeePercentage)
+			require.Equal(t, "2.5", *roundTrip.FeePercentage)
+		})
+
 		t.Run("Nil_Model", func(t *testing.T) {
 			domain, err := mapper.ToDomain(nil)
 			require.Error(t, err)
@@ -564,6 +1003,41 @@ func TestInvoiceMapper(t *testing.T) {
 	})
 }
 
+func BenchmarkInvoiceMapper_RoundTrip(b *testing.B) {
+	mapper := database.NewInvoiceMapper()
+	now := time.Now()
+	model := &database.InvoiceModel{
+		ID:             "test-invoice-id",
+		MerchantID:     "test-merchant-id",
+		Title:          "Test Invoice",
+		Description:    "Test Description",
+		Items:          `[{"name": "Test Item", "quantity": "2", "unit_price": "10.00"}]`,
+		Subtotal:       "20.00",
+		Tax:            "2.00",
+		Total:          "22.00",
+		Currency:       "USD",
+		CryptoCurrency: "USDT",
+		CryptoAmount:   "22.00",
+		PaymentAddress: stringPtr("TTestAddress123456789012345678901234567890"),
+		Status:         "pending",
+		ExchangeRate: `{"rate": "1.0", "from": "USD", "to": "USDT", "source": "default", ` +
+			`"locked_at": "2024-12-31T23:30:00Z", "expires_at": "2025-01-01T00:00:00Z"}`,
+		PaymentTolerance: `{"underpayment_threshold": "0.01", "overpayment_threshold": "1.00", ` +
+			`"overpayment_action": "credit_account"}`,
+		ExpiresAt: timePtr(now.Add(30 * time.Minute)),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	for b.Loop() {
+		domain, err := mapper.ToDomain(model)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_ = mapper.ToModel(domain)
+	}
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
@@ -573,6 +1047,10 @@ func timePtr(t time.Time) *time.Time {
 	return &t
 }
 
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
 func createTestInvoiceItem(t *testing.T, name, description, quantity, unitPrice string) *invoice.InvoiceItem {
 	unitPriceMoney, err := shared.NewMoney(unitPrice, shared.CurrencyUSD)
 	require.NoError(t, err)
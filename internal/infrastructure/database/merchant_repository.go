@@ -48,7 +48,7 @@ func (r *MerchantRepository) FindByID(ctx context.Context, id string) (*merchant
 	var model MerchantModel
 	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("merchant not found: %w", err)
+			return nil, fmt.Errorf("%w: %w", merchant.ErrMerchantNotFound, err)
 		}
 		return nil, fmt.Errorf("failed to find merchant: %w", err)
 	}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"crypto-checkout/internal/domain/audit"
 	"time"
 
 	"gorm.io/gorm"
@@ -8,27 +9,57 @@ import (
 
 // InvoiceModel represents the database model for invoices.
 type InvoiceModel struct {
-	ID               string  `gorm:"primaryKey;type:uuid"`
-	MerchantID       string  `gorm:"type:uuid;not null;index"`
-	CustomerID       *string `gorm:"type:uuid;index"` // Made optional to match domain model
-	Title            string  `gorm:"type:varchar(255);not null"`
-	Description      string  `gorm:"type:text"`
-	Items            string  `gorm:"type:jsonb"` // Store items as JSONB as per DB.md
-	Subtotal         string  `gorm:"type:decimal(20,2);not null"`
-	Tax              string  `gorm:"type:decimal(20,2);not null;default:0"`
-	Total            string  `gorm:"type:decimal(20,2);not null"`
-	Currency         string  `gorm:"type:varchar(3);not null"`
-	CryptoCurrency   string  `gorm:"type:varchar(10);not null"`
-	CryptoAmount     string  `gorm:"type:decimal(20,8);not null"`
-	PaymentAddress   *string `gorm:"type:varchar(42)"`
-	Status           string  `gorm:"type:varchar(20);not null"`
-	ExchangeRate     string  `gorm:"type:jsonb"`
-	PaymentTolerance string  `gorm:"type:jsonb"`
-	ExpiresAt        *time.Time
-	CreatedAt        time.Time `gorm:"not null"`
-	UpdatedAt        time.Time `gorm:"not null"`
-	PaidAt           *time.Time
-	DeletedAt        gorm.DeletedAt `gorm:"index"`
+	ID             string  `gorm:"primaryKey;type:varchar(64)"`
+	MerchantID     string  `gorm:"type:uuid;not null;index;uniqueIndex:idx_invoices_merchant_number,priority:1"`
+	Number         *string `gorm:"type:varchar(20);uniqueIndex:idx_invoices_merchant_number,priority:2"`
+	CustomerID     *string `gorm:"type:uuid;index"` // Made optional to match domain model
+	Title          string  `gorm:"type:varchar(255);not null"`
+	Description    string  `gorm:"type:text"`
+	Type           string  `gorm:"type:varchar(20);not null;default:'standard'"`
+	MinimumAmount  *string `gorm:"type:decimal(20,2)"` // Donation minimum in the invoice currency; nil for standard invoices
+	Items          string  `gorm:"type:jsonb"`         // Store items as JSONB as per DB.md
+	Subtotal       string  `gorm:"type:decimal(20,2);not null"`
+	Discount       string  `gorm:"type:decimal(20,2);not null;default:0"` // Invoice-level discount including coupons
+	Discounts      *string `gorm:"type:jsonb"`                            // Invoice-level discount and applied coupon
+	Tax            string  `gorm:"type:decimal(20,2);not null;default:0"`
+	TaxTreatment   *string `gorm:"type:jsonb"` // Jurisdiction and customer of per-item taxes; nil for a flat tax
+	Total          string  `gorm:"type:decimal(20,2);not null"`
+	Fee            string  `gorm:"type:decimal(20,2);not null;default:0"` // Platform fee passed through to the customer
+	FeePercentage  *string `gorm:"type:decimal(7,4)"`                     // Quoted fee passed through; nil when absorbed
+	Currency       string  `gorm:"type:varchar(3);not null"`
+	CryptoCurrency string  `gorm:"type:varchar(10);not null"`
+	CryptoAmount   string  `gorm:"type:decimal(20,8);not null"`
+	PaymentAddress *string `gorm:"type:varchar(42)"`
+	Network        *string `gorm:"type:varchar(20)"` // Nil for invoices stored before the network was recorded
+	// Payment reference telling apart the invoices paid to one address; nil for drafts and older invoices
+	PaymentReference  *string `gorm:"type:varchar(16);index"`
+	AmountFingerprint *int    // Nil when the amount due is not fingerprinted
+	FingerprintPlaces *int32  // Decimal places of the fingerprinted amount
+	Status            string  `gorm:"type:varchar(20);not null"`
+	ExchangeRate      string  `gorm:"type:jsonb"`
+	PaymentTolerance  string  `gorm:"type:jsonb"`
+	ExpiresAt         *time.Time
+	ExpiresIn         *int64     `gorm:"type:bigint"` // Seconds a draft stays payable once finalized; nil for other invoices
+	RateExpiresAt     *time.Time `gorm:"index"`       // Mirrors the exchange rate expiry to find rates due for re-quoting
+	CreatedAt         time.Time  `gorm:"not null"`
+	UpdatedAt         time.Time  `gorm:"not null"`
+	Version           int        `gorm:"not null;default:0"` // Updates stored, checked so concurrent ones are not lost
+	PaidAt            *time.Time
+	AmountPaid        *string        `gorm:"type:decimal(20,8)"` // Nil for invoices settled before payments were tracked
+	Refunds           *string        `gorm:"type:jsonb"`
+	Requotes          *string        `gorm:"type:jsonb"` // Exchange rates that replaced an expired rate, oldest first
+	Extensions        *string        `gorm:"type:jsonb"` // Pushes of the expiry, oldest first
+	StaleRateChecks   *string        `gorm:"type:jsonb"` // Branches taken for payments arriving after the rate expired
+	PaymentReversals  *string        `gorm:"type:jsonb"` // Confirmed payments a deep reorg took back, oldest first
+	RefundDestination *string        `gorm:"type:jsonb"` // Proposed or confirmed refund address; nil until one is known
+	CustomFields      *string        `gorm:"type:jsonb"` // Labelled values shown to customers, in order
+	SettlementSplit   *string        `gorm:"type:jsonb"` // Beneficiary merchants' shares; nil when the merchant gets all
+	Metadata          *string        `gorm:"type:jsonb"`
+	Supersedes        *string        `gorm:"type:varchar(64);index"` // Invoice this one replaced when it was amended
+	SupersededBy      *string        `gorm:"type:varchar(64)"`       // Invoice that replaced this one when it was amended
+	ReturnURL         *string        `gorm:"type:varchar(2048)"`
+	CancelURL         *string        `gorm:"type:varchar(2048)"`
+	DeletedAt         gorm.DeletedAt `gorm:"index"`
 }
 
 // TableName returns the table name for the InvoiceModel.
@@ -38,9 +69,10 @@ func (InvoiceModel) TableName() string {
 
 // PaymentModel represents the database model for payments.
 type PaymentModel struct {
-	ID                    string    `gorm:"primaryKey;type:uuid"`
-	InvoiceID             string    `gorm:"type:uuid;not null;index"`
-	TxHash                string    `gorm:"type:varchar(64);not null;uniqueIndex"` // Changed from TransactionHash to match DB.md
+	ID                    string    `gorm:"primaryKey;type:varchar(64)"`
+	InvoiceID             string    `gorm:"type:varchar(64);not null;index"`
+	Network               string    `gorm:"type:varchar(20);not null;default:'';uniqueIndex:idx_payments_network_tx_hash,priority:1"`
+	TxHash                string    `gorm:"type:varchar(64);not null;uniqueIndex:idx_payments_network_tx_hash,priority:2"` // Changed from TransactionHash to match DB.md
 	Amount                string    `gorm:"type:decimal(20,8);not null"`
 	FromAddress           string    `gorm:"type:varchar(42);not null"`
 	ToAddress             string    `gorm:"type:varchar(42);not null"`
@@ -61,6 +93,35 @@ func (PaymentModel) TableName() string {
 	return "payments"
 }
 
+// PaymentEventModel represents the database model for the append-only payment event stream.
+// The payments table is the projection of these events.
+type PaymentEventModel struct {
+	PaymentID  string    `gorm:"primaryKey;type:varchar(64)"`
+	Version    int       `gorm:"primaryKey"`
+	Type       string    `gorm:"type:varchar(40);not null;index"`
+	Data       string    `gorm:"type:jsonb;not null"`
+	OccurredAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the PaymentEventModel.
+func (PaymentEventModel) TableName() string {
+	return "payment_events"
+}
+
+// PaymentSnapshotModel represents the database model for snapshots of payment state.
+// A snapshot holds the state after the event with the same version was applied.
+type PaymentSnapshotModel struct {
+	PaymentID string    `gorm:"primaryKey;type:varchar(64)"`
+	Version   int       `gorm:"primaryKey"`
+	State     string    `gorm:"type:jsonb;not null"`
+	TakenAt   time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the PaymentSnapshotModel.
+func (PaymentSnapshotModel) TableName() string {
+	return "payment_snapshots"
+}
+
 // MerchantModel represents the database model for merchants.
 type MerchantModel struct {
 	ID           string         `gorm:"primaryKey;type:uuid"`
@@ -104,19 +165,973 @@ type WebhookEndpointModel struct {
 	MerchantID   string         `gorm:"type:uuid;not null;index"`
 	URL          string         `gorm:"type:varchar(500);not null"`
 	Events       string         `gorm:"type:jsonb;not null"`
-	Secret       string         `gorm:"type:varchar(255);not null"`
+	Secret       string         `gorm:"type:text;not null;serializer:encrypted"` // Sealed when a key file is configured
 	Status       string         `gorm:"type:varchar(20);not null"`
 	MaxRetries   int            `gorm:"not null;default:5"`
 	RetryBackoff string         `gorm:"type:varchar(20);not null"`
 	Timeout      int            `gorm:"not null;default:30"`
 	AllowedIPs   string         `gorm:"type:jsonb"`
 	Headers      string         `gorm:"type:jsonb"`
+	APIVersion   string         `gorm:"type:varchar(10);not null;default:'2025-01-15'"`
 	CreatedAt    time.Time      `gorm:"not null"`
 	UpdatedAt    time.Time      `gorm:"not null"`
 	DeletedAt    gorm.DeletedAt `gorm:"index"`
+	// The secret rotated out, signing webhooks as well until it expires; sealed like the secret
+	PreviousSecret          string `gorm:"type:text;serializer:encrypted"`
+	PreviousSecretExpiresAt *time.Time
 }
 
 // TableName returns the table name for the WebhookEndpointModel.
 func (WebhookEndpointModel) TableName() string {
 	return "webhook_endpoints"
 }
+
+// WebhookDeliveryModel represents the database model for the log of webhooks sent to merchants' endpoints.
+type WebhookDeliveryModel struct {
+	ID           string    `gorm:"primaryKey;type:varchar(64)"`
+	MerchantID   string    `gorm:"type:varchar(64);not null;index:idx_webhook_deliveries_merchant,priority:1"`
+	EndpointID   string    `gorm:"type:varchar(64);not null;index"`
+	EventID      string    `gorm:"type:varchar(64);not null"`
+	EventType    string    `gorm:"type:varchar(100);not null"`
+	APIVersion   string    `gorm:"type:varchar(10)"`
+	Kind         string    `gorm:"type:varchar(20);not null"`
+	ReplayOf     string    `gorm:"type:varchar(64)"`
+	URL          string    `gorm:"type:varchar(500);not null"`
+	Payload      string    `gorm:"type:text;not null"`
+	Status       string    `gorm:"type:varchar(20);not null"`
+	ResponseCode int       `gorm:"not null;default:0"`
+	ResponseBody string    `gorm:"type:text"`
+	Failure      string    `gorm:"type:text"`
+	DurationMs   int64     `gorm:"not null;default:0"`
+	CreatedAt    time.Time `gorm:"not null;index:idx_webhook_deliveries_merchant,priority:2"`
+}
+
+// TableName returns the table name for the WebhookDeliveryModel.
+func (WebhookDeliveryModel) TableName() string {
+	return "webhook_deliveries"
+}
+
+// UserModel represents the database model for merchant team members.
+type UserModel struct {
+	ID           string         `gorm:"primaryKey;type:uuid"`
+	MerchantID   string         `gorm:"type:uuid;not null;uniqueIndex:idx_users_merchant_email"`
+	Email        string         `gorm:"type:varchar(255);not null;uniqueIndex:idx_users_merchant_email"`
+	Name         string         `gorm:"type:varchar(255)"`
+	Role         string         `gorm:"type:varchar(20);not null"`
+	Status       string         `gorm:"type:varchar(20);not null"`
+	PasswordHash string         `gorm:"type:varchar(72)"`
+	TOTPSecret   string         `gorm:"column:totp_secret;type:text;serializer:encrypted"` // Sealed when a key file is configured
+	TOTPEnabled  bool           `gorm:"column:totp_enabled;not null;default:false"`
+	CreatedAt    time.Time      `gorm:"not null"`
+	UpdatedAt    time.Time      `gorm:"not null"`
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName returns the table name for the UserModel.
+func (UserModel) TableName() string {
+	return "users"
+}
+
+// InvitationModel represents the database model for team invitations.
+type InvitationModel struct {
+	ID         string    `gorm:"primaryKey;type:uuid"`
+	MerchantID string    `gorm:"type:uuid;not null;index"`
+	Email      string    `gorm:"type:varchar(255);not null"`
+	Role       string    `gorm:"type:varchar(20);not null"`
+	TokenHash  string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	InvitedBy  string    `gorm:"type:varchar(64)"`
+	Status     string    `gorm:"type:varchar(20);not null"`
+	ExpiresAt  time.Time `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"not null"`
+	AcceptedAt *time.Time
+}
+
+// TableName returns the table name for the InvitationModel.
+func (InvitationModel) TableName() string {
+	return "invitations"
+}
+
+// AuditEntryModel represents the database model for audit log entries.
+type AuditEntryModel struct {
+	ID           string    `gorm:"primaryKey;type:uuid"`
+	MerchantID   string    `gorm:"type:uuid;not null;index"`
+	ActorID      string    `gorm:"type:varchar(64);not null"`
+	ActorType    string    `gorm:"type:varchar(20);not null"`
+	ActorRole    string    `gorm:"type:varchar(20)"`
+	IPAddress    string    `gorm:"type:varchar(45)"`
+	RequestID    string    `gorm:"type:varchar(64);index"`
+	UserAgent    string    `gorm:"type:varchar(255)"`
+	Action       string    `gorm:"type:varchar(100);not null;index"`
+	ResourceType string    `gorm:"type:varchar(50);index:idx_audit_log_resource"`
+	ResourceID   string    `gorm:"type:varchar(64);index:idx_audit_log_resource"`
+	Before       *string   `gorm:"type:jsonb"`
+	After        *string   `gorm:"type:jsonb"`
+	Details      string    `gorm:"type:jsonb"`
+	OccurredAt   time.Time `gorm:"not null;index"`
+}
+
+// TableName returns the table name for the AuditEntryModel.
+func (AuditEntryModel) TableName() string {
+	return "audit_log"
+}
+
+// BeforeUpdate rejects updates to the append-only audit log.
+func (*AuditEntryModel) BeforeUpdate(*gorm.DB) error {
+	return audit.ErrImmutable
+}
+
+// BeforeDelete rejects deletes from the append-only audit log.
+func (*AuditEntryModel) BeforeDelete(*gorm.DB) error {
+	return audit.ErrImmutable
+}
+
+// RefreshTokenModel represents the database model for dashboard session refresh tokens.
+type RefreshTokenModel struct {
+	ID         string    `gorm:"primaryKey;type:uuid"`
+	UserID     string    `gorm:"type:uuid;not null;index"`
+	MerchantID string    `gorm:"type:uuid;not null"`
+	FamilyID   string    `gorm:"type:uuid;not null;index"`
+	TokenHash  string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt  time.Time `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"not null"`
+	RevokedAt  *time.Time
+}
+
+// TableName returns the table name for the RefreshTokenModel.
+func (RefreshTokenModel) TableName() string {
+	return "refresh_tokens"
+}
+
+// PaymentScreeningModel represents the database model for AML/KYT screenings of payment senders.
+type PaymentScreeningModel struct {
+	ID         string    `gorm:"primaryKey;type:uuid"`
+	MerchantID string    `gorm:"type:varchar(64);index"` // Empty when the invoice could not be resolved
+	PaymentID  string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	InvoiceID  string    `gorm:"type:varchar(64);not null"`
+	Address    string    `gorm:"type:varchar(128);not null;index"`
+	Network    string    `gorm:"type:varchar(20)"`
+	Provider   string    `gorm:"type:varchar(50);not null"`
+	RiskLevel  string    `gorm:"type:varchar(20);not null"`
+	RiskScore  float64   `gorm:"not null;default:0"`
+	Categories string    `gorm:"type:jsonb"`
+	Reference  string    `gorm:"type:varchar(128)"`
+	Failure    string    `gorm:"type:text"`
+	Status     string    `gorm:"type:varchar(20);not null;index"`
+	ReviewedBy string    `gorm:"type:varchar(64)"`
+	ReviewNote string    `gorm:"type:text"`
+	ScreenedAt time.Time `gorm:"not null;index"`
+	ReviewedAt *time.Time
+}
+
+// TableName returns the table name for the PaymentScreeningModel.
+func (PaymentScreeningModel) TableName() string {
+	return "payment_screenings"
+}
+
+// BlocklistEntryModel represents the database model for blocked payment sender addresses.
+type BlocklistEntryModel struct {
+	ID         string    `gorm:"primaryKey;type:uuid"`
+	MerchantID string    `gorm:"type:varchar(64);uniqueIndex:idx_blocklist_scope_address,priority:1"` // Empty for global entries
+	Source     string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_blocklist_scope_address,priority:2"`
+	Address    string    `gorm:"type:varchar(128);not null;uniqueIndex:idx_blocklist_scope_address,priority:3;index"`
+	Network    string    `gorm:"type:varchar(20)"`
+	Reason     string    `gorm:"type:text"`
+	Reference  string    `gorm:"type:varchar(128)"`
+	CreatedBy  string    `gorm:"type:varchar(64)"`
+	CreatedAt  time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the BlocklistEntryModel.
+func (BlocklistEntryModel) TableName() string {
+	return "address_blocklist"
+}
+
+// PaymentReviewModel represents the database model for payments awaiting operator review.
+type PaymentReviewModel struct {
+	ID             string    `gorm:"primaryKey;type:uuid"`
+	MerchantID     string    `gorm:"type:varchar(64);index"` // Empty when the invoice could not be resolved
+	Kind           string    `gorm:"type:varchar(20);not null;index:idx_review_kind_payment,priority:1"`
+	Status         string    `gorm:"type:varchar(20);not null;index"`
+	InvoiceID      string    `gorm:"type:varchar(64);not null"`
+	PaymentID      string    `gorm:"type:varchar(64);not null;index:idx_review_kind_payment,priority:2"`
+	ExpectedAmount string    `gorm:"type:varchar(78)"`
+	ReceivedAmount string    `gorm:"type:varchar(78)"`
+	Currency       string    `gorm:"type:varchar(10)"`
+	Reason         string    `gorm:"type:text"`
+	Resolution     string    `gorm:"type:varchar(20)"`
+	ResolvedBy     string    `gorm:"type:varchar(64)"`
+	Note           string    `gorm:"type:text"`
+	CreatedAt      time.Time `gorm:"not null;index"`
+	ResolvedAt     *time.Time
+}
+
+// TableName returns the table name for the PaymentReviewModel.
+func (PaymentReviewModel) TableName() string {
+	return "payment_reviews"
+}
+
+// CouponModel represents the database model for merchant coupon codes.
+type CouponModel struct {
+	ID             string `gorm:"primaryKey;type:uuid"`
+	MerchantID     string `gorm:"type:varchar(64);not null;uniqueIndex:idx_coupon_merchant_code,priority:1"`
+	Code           string `gorm:"type:varchar(32);not null;uniqueIndex:idx_coupon_merchant_code,priority:2"`
+	DiscountType   string `gorm:"type:varchar(20);not null"`
+	DiscountValue  string `gorm:"type:decimal(20,2);not null"`
+	MaxRedemptions int    `gorm:"not null;default:0"` // Zero means unlimited
+	Redemptions    int    `gorm:"not null;default:0"`
+	ExpiresAt      *time.Time
+	Active         bool      `gorm:"not null;default:true"`
+	CreatedAt      time.Time `gorm:"not null"`
+	UpdatedAt      time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the CouponModel.
+func (CouponModel) TableName() string {
+	return "coupons"
+}
+
+// TaxRuleModel represents the database model for merchant tax rules.
+type TaxRuleModel struct {
+	ID            string    `gorm:"primaryKey;type:uuid"`
+	MerchantID    string    `gorm:"type:varchar(64);not null;uniqueIndex:idx_tax_rule_scope,priority:1"`
+	Jurisdiction  string    `gorm:"type:varchar(6);not null;uniqueIndex:idx_tax_rule_scope,priority:2"`
+	Category      string    `gorm:"type:varchar(32);not null;uniqueIndex:idx_tax_rule_scope,priority:3"`
+	Name          string    `gorm:"type:varchar(64);not null;uniqueIndex:idx_tax_rule_scope,priority:4"`
+	Type          string    `gorm:"type:varchar(20);not null"`
+	Rate          string    `gorm:"type:decimal(7,6);not null"`
+	ReverseCharge bool      `gorm:"not null;default:false"`
+	CreatedAt     time.Time `gorm:"not null"`
+	UpdatedAt     time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the TaxRuleModel.
+func (TaxRuleModel) TableName() string {
+	return "tax_rules"
+}
+
+// PaymentLinkModel represents the database model for reusable merchant payment links.
+type PaymentLinkModel struct {
+	ID             string    `gorm:"primaryKey;type:uuid"`
+	MerchantID     string    `gorm:"type:varchar(64);not null;index"`
+	Slug           string    `gorm:"type:varchar(16);not null;uniqueIndex"`
+	Title          string    `gorm:"type:varchar(255);not null"`
+	Description    string    `gorm:"type:text"`
+	Amount         *string   `gorm:"type:decimal(20,2)"` // Nil for an open amount chosen by the customer
+	Currency       string    `gorm:"type:varchar(3);not null"`
+	CryptoCurrency string    `gorm:"type:varchar(10);not null"`
+	Active         bool      `gorm:"not null;default:true"`
+	Visits         int       `gorm:"not null;default:0"`
+	CreatedAt      time.Time `gorm:"not null"`
+	UpdatedAt      time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the PaymentLinkModel.
+func (PaymentLinkModel) TableName() string {
+	return "payment_links"
+}
+
+// InvoiceTemplateModel represents the database model for named merchant invoice templates.
+type InvoiceTemplateModel struct {
+	ID               string    `gorm:"primaryKey;type:uuid"`
+	MerchantID       string    `gorm:"type:varchar(64);not null;uniqueIndex:idx_invoice_template_name,priority:1"`
+	Name             string    `gorm:"type:varchar(64);not null;uniqueIndex:idx_invoice_template_name,priority:2"`
+	Title            string    `gorm:"type:varchar(255);not null"`
+	Description      string    `gorm:"type:text"`
+	Items            string    `gorm:"type:jsonb;not null"`
+	Currency         string    `gorm:"type:varchar(3);not null"`
+	CryptoCurrency   string    `gorm:"type:varchar(10);not null"`
+	Network          string    `gorm:"type:varchar(20)"` // Empty for the cryptocurrency's default network
+	TaxRate          string    `gorm:"type:varchar(20)"` // Empty for no tax
+	ExpiresInSeconds int64     `gorm:"not null;default:0"`
+	Metadata         *string   `gorm:"type:jsonb"`
+	CreatedAt        time.Time `gorm:"not null"`
+	UpdatedAt        time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the InvoiceTemplateModel.
+func (InvoiceTemplateModel) TableName() string {
+	return "invoice_templates"
+}
+
+// AutomationRuleModel represents the database model for merchants' automation rules.
+type AutomationRuleModel struct {
+	ID         string    `gorm:"primaryKey;type:uuid"`
+	MerchantID string    `gorm:"type:varchar(64);not null;uniqueIndex:idx_automation_rule_name,priority:1;index:idx_automation_rule_trigger,priority:1"`
+	Name       string    `gorm:"type:varchar(64);not null;uniqueIndex:idx_automation_rule_name,priority:2"`
+	Trigger    string    `gorm:"type:varchar(40);not null;index:idx_automation_rule_trigger,priority:2"`
+	Conditions string    `gorm:"type:jsonb;not null"`
+	Actions    string    `gorm:"type:jsonb;not null"`
+	Enabled    bool      `gorm:"not null"`
+	Secret     string    `gorm:"type:text;not null;serializer:encrypted"` // Sealed when a key file is configured
+	CreatedAt  time.Time `gorm:"not null"`
+	UpdatedAt  time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the AutomationRuleModel.
+func (AutomationRuleModel) TableName() string {
+	return "automation_rules"
+}
+
+// PaymentProcessorModel represents the database model for merchants' payment processor registrations.
+type PaymentProcessorModel struct {
+	ID            string    `gorm:"primaryKey;type:varchar(64)"`
+	MerchantID    string    `gorm:"type:varchar(64);not null;uniqueIndex:idx_payment_processor_merchant,priority:1"`
+	Processor     string    `gorm:"type:varchar(40);not null;uniqueIndex:idx_payment_processor_merchant,priority:2"`
+	APIKey        string    `gorm:"type:text;not null;serializer:encrypted"` // Sealed when a key file is configured
+	APISecret     string    `gorm:"type:text;not null;serializer:encrypted"`
+	WebhookSecret string    `gorm:"type:text;not null;serializer:encrypted"`
+	Enabled       bool      `gorm:"not null"`
+	CreatedAt     time.Time `gorm:"not null"`
+	UpdatedAt     time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the PaymentProcessorModel.
+func (PaymentProcessorModel) TableName() string {
+	return "payment_processors"
+}
+
+// ProcessorCheckoutModel represents the database model for the checkouts created at payment processors.
+type ProcessorCheckoutModel struct {
+	ID             string     `gorm:"primaryKey;type:varchar(64)"`
+	RegistrationID string     `gorm:"type:varchar(64);not null;index"`
+	MerchantID     string     `gorm:"type:varchar(64);not null"`
+	InvoiceID      string     `gorm:"type:varchar(64);not null;index"`
+	Processor      string     `gorm:"type:varchar(40);not null"`
+	ExternalID     string     `gorm:"type:varchar(128)"`
+	URL            string     `gorm:"type:text"`
+	QRContent      string     `gorm:"type:text"`
+	Amount         string     `gorm:"type:decimal(20,8);not null"`
+	Currency       string     `gorm:"type:varchar(10);not null"`
+	Status         string     `gorm:"type:varchar(20);not null"`
+	PaymentID      string     `gorm:"type:varchar(64)"`
+	ExpiresAt      *time.Time // Nil when the processor sets no expiry
+	CreatedAt      time.Time  `gorm:"not null"`
+	UpdatedAt      time.Time  `gorm:"not null"`
+}
+
+// TableName returns the table name for the ProcessorCheckoutModel.
+func (ProcessorCheckoutModel) TableName() string {
+	return "processor_checkouts"
+}
+
+// PaymentLinkInvoiceModel records which invoices were created from a payment link.
+type PaymentLinkInvoiceModel struct {
+	PaymentLinkID string    `gorm:"primaryKey;type:uuid"`
+	InvoiceID     string    `gorm:"primaryKey;type:varchar(64)"`
+	CreatedAt     time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the PaymentLinkInvoiceModel.
+func (PaymentLinkInvoiceModel) TableName() string {
+	return "payment_link_invoices"
+}
+
+// InvoiceMetadataModel indexes a top-level scalar value of an invoice's metadata, so that invoices can be listed
+// by the metadata keys their merchant declared filterable.
+type InvoiceMetadataModel struct {
+	InvoiceID  string `gorm:"primaryKey;type:varchar(64)"`
+	Key        string `gorm:"primaryKey;type:varchar(40);index:idx_invoice_metadata_lookup,priority:2"`
+	MerchantID string `gorm:"type:uuid;not null;index:idx_invoice_metadata_lookup,priority:1"`
+	Value      string `gorm:"type:varchar(255);not null;index:idx_invoice_metadata_lookup,priority:3"`
+}
+
+// TableName returns the table name for the InvoiceMetadataModel.
+func (InvoiceMetadataModel) TableName() string {
+	return "invoice_metadata"
+}
+
+// SettlementModel represents the database model for invoice settlements and their fiat conversions.
+// An invoice has a single settlement per merchant besides reversed ones, so a repaid invoice can settle again,
+// and its merchant's settlement may have beneficiaries' shares split from it.
+type SettlementModel struct {
+	ID            string  `gorm:"primaryKey;type:varchar(64)"`
+	InvoiceID     string  `gorm:"type:varchar(64);not null;uniqueIndex:idx_active_merchant_settlement,priority:1,where:status<>'reversed'"`
+	MerchantID    string  `gorm:"type:varchar(64);index;uniqueIndex:idx_active_merchant_settlement,priority:2"`
+	SplitOf       *string `gorm:"type:varchar(64);index"` // Merchant's settlement a beneficiary's share was split from
+	GrossAmount   string  `gorm:"type:decimal(20,8);not null"`
+	FeePercentage string  `gorm:"type:decimal(6,3);not null"`
+	FeeAmount     string  `gorm:"type:decimal(20,8);not null"`
+	NetAmount     string  `gorm:"type:decimal(20,8);not null"`
+	Currency      string  `gorm:"type:varchar(10);not null"`
+	Status        string  `gorm:"type:varchar(20);not null;index"`
+	FailureReason string  `gorm:"type:text"`
+	FeeTier       *string `gorm:"type:jsonb"` // How the fee was chosen; nil for settlements made before fee schedules
+	// Conversion columns are empty when the settlement was not converted to fiat
+	ConversionExchange      string  `gorm:"type:varchar(20)"`
+	ConversionOrderID       string  `gorm:"type:varchar(128)"`
+	ConversionStatus        string  `gorm:"type:varchar(20)"`
+	ConversionFiatCurrency  string  `gorm:"type:varchar(3)"`
+	ConversionAmount        *string `gorm:"type:decimal(20,8)"`
+	ConversionFiatAmount    *string `gorm:"type:decimal(20,8)"`
+	ConversionFee           *string `gorm:"type:decimal(20,8)"`
+	ConversionFailureReason string  `gorm:"type:text"`
+	ConversionSubmittedAt   *time.Time
+	ConversionCompletedAt   *time.Time
+	PayoutID                *string   `gorm:"type:uuid;index"` // Payout transferring the net amount on-chain
+	CreatedAt               time.Time `gorm:"not null;index"`
+	SettledAt               *time.Time
+}
+
+// TableName returns the table name for the SettlementModel.
+func (SettlementModel) TableName() string {
+	return "settlements"
+}
+
+// SettlementReversalModel represents the database model for the ledger entries offsetting reversed settlements.
+type SettlementReversalModel struct {
+	ID           string    `gorm:"primaryKey;type:varchar(64)"`
+	SettlementID string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	InvoiceID    string    `gorm:"type:varchar(64);not null;index"`
+	MerchantID   string    `gorm:"type:varchar(64);not null;index"`
+	PaymentID    string    `gorm:"type:varchar(64)"`
+	GrossAmount  string    `gorm:"type:decimal(20,8);not null"` // Negative: the settlement amounts taken back
+	FeeAmount    string    `gorm:"type:decimal(20,8);not null"`
+	NetAmount    string    `gorm:"type:decimal(20,8);not null"`
+	Currency     string    `gorm:"type:varchar(10);not null"`
+	Reason       string    `gorm:"type:text"`
+	PayoutID     *string   `gorm:"type:uuid"` // Payout that had already transferred the net amount
+	CreatedAt    time.Time `gorm:"not null;index"`
+}
+
+// TableName returns the table name for the SettlementReversalModel.
+func (SettlementReversalModel) TableName() string {
+	return "settlement_reversals"
+}
+
+// PayoutWalletModel represents the database model for merchant payout wallets.
+type PayoutWalletModel struct {
+	ID                 string    `gorm:"primaryKey;type:uuid"`
+	MerchantID         string    `gorm:"type:varchar(64);not null;uniqueIndex:idx_payout_wallet_network,priority:1"`
+	Network            string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_payout_wallet_network,priority:2"`
+	Address            string    `gorm:"type:varchar(128);not null"`
+	Status             string    `gorm:"type:varchar(30);not null"`
+	Challenge          string    `gorm:"type:text"` // Empty once verified
+	ChallengeExpiresAt time.Time `gorm:"not null"`
+	VerifiedAt         *time.Time
+	CreatedAt          time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the PayoutWalletModel.
+func (PayoutWalletModel) TableName() string {
+	return "payout_wallets"
+}
+
+// PayoutModel represents the database model for on-chain payouts.
+type PayoutModel struct {
+	ID            string    `gorm:"primaryKey;type:uuid"`
+	MerchantID    string    `gorm:"type:varchar(64);not null;index"`
+	WalletID      string    `gorm:"type:uuid;not null"`
+	Network       string    `gorm:"type:varchar(20);not null"`
+	Address       string    `gorm:"type:varchar(128);not null"`
+	Currency      string    `gorm:"type:varchar(10);not null"`
+	Amount        string    `gorm:"type:decimal(20,8);not null"`
+	SettlementIDs string    `gorm:"type:jsonb;not null"`
+	Status        string    `gorm:"type:varchar(20);not null;index"`
+	TxHash        string    `gorm:"type:varchar(128);index"`
+	FailureReason string    `gorm:"type:text"`
+	CreatedAt     time.Time `gorm:"not null;index"`
+	BroadcastAt   *time.Time
+	ConfirmedAt   *time.Time
+}
+
+// TableName returns the table name for the PayoutModel.
+func (PayoutModel) TableName() string {
+	return "payouts"
+}
+
+// TreasuryBalanceModel represents the database model for the last known hot wallet balances.
+type TreasuryBalanceModel struct {
+	Network   string    `gorm:"primaryKey;type:varchar(20)"`
+	Currency  string    `gorm:"primaryKey;type:varchar(10)"`
+	Amount    string    `gorm:"type:decimal(30,18);not null"`
+	Level     string    `gorm:"type:varchar(10);not null"`
+	CheckedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the TreasuryBalanceModel.
+func (TreasuryBalanceModel) TableName() string {
+	return "treasury_balances"
+}
+
+// SweepModel represents the database model for deposit address sweeps.
+type SweepModel struct {
+	ID            string    `gorm:"primaryKey;type:uuid"`
+	InvoiceID     string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	MerchantID    string    `gorm:"type:varchar(64);not null"`
+	Network       string    `gorm:"type:varchar(20);not null"`
+	Currency      string    `gorm:"type:varchar(10);not null"`
+	FromAddress   string    `gorm:"type:varchar(128);not null"`
+	Amount        string    `gorm:"type:decimal(30,18);not null"`
+	Status        string    `gorm:"type:varchar(20);not null;index"`
+	TxHash        string    `gorm:"type:varchar(128)"`
+	FailureReason string    `gorm:"type:text"`
+	CreatedAt     time.Time `gorm:"not null;index"`
+	CompletedAt   *time.Time
+}
+
+// TableName returns the table name for the SweepModel.
+func (SweepModel) TableName() string {
+	return "treasury_sweeps"
+}
+
+// ColdTransferModel represents the database model for transfers from the hot wallet to cold storage.
+type ColdTransferModel struct {
+	ID            string    `gorm:"primaryKey;type:uuid"`
+	Network       string    `gorm:"type:varchar(20);not null"`
+	Currency      string    `gorm:"type:varchar(10);not null"`
+	ToAddress     string    `gorm:"type:varchar(128);not null"`
+	Amount        string    `gorm:"type:decimal(30,18);not null"`
+	Note          string    `gorm:"type:text"`
+	Status        string    `gorm:"type:varchar(20);not null;index"`
+	RequestedBy   string    `gorm:"type:varchar(255);not null"`
+	ReviewedBy    string    `gorm:"type:varchar(255)"`
+	ReviewNote    string    `gorm:"type:text"`
+	TxHash        string    `gorm:"type:varchar(128)"`
+	FailureReason string    `gorm:"type:text"`
+	CreatedAt     time.Time `gorm:"not null;index"`
+	ReviewedAt    *time.Time
+	BroadcastAt   *time.Time
+	ConfirmedAt   *time.Time
+}
+
+// TableName returns the table name for the ColdTransferModel.
+func (ColdTransferModel) TableName() string {
+	return "treasury_cold_transfers"
+}
+
+// FeeSpendModel represents the database model for the network fees paid by confirmed transfers.
+type FeeSpendModel struct {
+	ID          string    `gorm:"primaryKey;type:uuid"`
+	Purpose     string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_fee_spends_reference"`
+	Reference   string    `gorm:"type:varchar(64);not null;uniqueIndex:idx_fee_spends_reference"`
+	Network     string    `gorm:"type:varchar(20);not null"`
+	TxHash      string    `gorm:"type:varchar(128);not null"`
+	Amount      string    `gorm:"type:decimal(30,18);not null"`
+	FeeCurrency string    `gorm:"type:varchar(10);not null"`
+	CreatedAt   time.Time `gorm:"not null;index"`
+}
+
+// TableName returns the table name for the FeeSpendModel.
+func (FeeSpendModel) TableName() string {
+	return "fee_spends"
+}
+
+// PaymentStatisticsModel represents the database model for the hourly rollup of payments reaching each status.
+type PaymentStatisticsModel struct {
+	HourStart time.Time `gorm:"primaryKey"`
+	Status    string    `gorm:"primaryKey;type:varchar(20)"`
+	Currency  string    `gorm:"primaryKey;type:varchar(10)"`
+	Count     int       `gorm:"not null;default:0"`
+	Volume    string    `gorm:"type:decimal(30,18);not null;default:0"`
+}
+
+// TableName returns the table name for the PaymentStatisticsModel.
+func (PaymentStatisticsModel) TableName() string {
+	return "payment_statistics"
+}
+
+// PaymentConfirmationModel represents the database model for the confirmation latency of confirmed payments.
+type PaymentConfirmationModel struct {
+	PaymentID      string    `gorm:"primaryKey;type:varchar(64)"`
+	ConfirmedAt    time.Time `gorm:"not null;index"`
+	LatencySeconds float64   `gorm:"not null"`
+}
+
+// TableName returns the table name for the PaymentConfirmationModel.
+func (PaymentConfirmationModel) TableName() string {
+	return "payment_confirmations"
+}
+
+// ApprovalModel represents the database model for approvals of refunds and payouts over the threshold.
+type ApprovalModel struct {
+	ID          string    `gorm:"primaryKey;type:uuid"`
+	MerchantID  string    `gorm:"type:varchar(64);not null;index"`
+	Kind        string    `gorm:"type:varchar(20);not null;index:idx_approvals_reference"`
+	Reference   string    `gorm:"type:varchar(64);not null;index:idx_approvals_reference"`
+	Currency    string    `gorm:"type:varchar(10);not null"`
+	Amount      string    `gorm:"type:decimal(30,18);not null"`
+	Reason      string    `gorm:"type:text"`
+	RequestedBy string    `gorm:"type:varchar(255)"`
+	Required    int       `gorm:"not null"`
+	Status      string    `gorm:"type:varchar(20);not null;index"`
+	Votes       string    `gorm:"type:jsonb;not null"`
+	CreatedAt   time.Time `gorm:"not null;index"`
+	DecidedAt   *time.Time
+	Version     int `gorm:"not null;default:0"`
+}
+
+// TableName returns the table name for the ApprovalModel.
+func (ApprovalModel) TableName() string {
+	return "approvals"
+}
+
+// DeadLetterModel represents the database model for events an in-process handler failed on.
+type DeadLetterModel struct {
+	ID           string    `gorm:"primaryKey;type:uuid"`
+	Handler      string    `gorm:"type:varchar(255);not null;index"`
+	EventType    string    `gorm:"type:varchar(100);not null;index"`
+	AggregateID  string    `gorm:"type:varchar(255);not null;index"`
+	Event        string    `gorm:"type:jsonb;not null"`
+	LastError    string    `gorm:"type:text;not null"`
+	Attempts     int       `gorm:"not null"`
+	Status       string    `gorm:"type:varchar(20);not null;index"`
+	CreatedAt    time.Time `gorm:"not null;index"`
+	LastFailedAt time.Time `gorm:"not null"`
+	ReplayedAt   *time.Time
+}
+
+// TableName returns the table name for the DeadLetterModel.
+func (DeadLetterModel) TableName() string {
+	return "dead_letters"
+}
+
+// IncidentModel represents the database model for the incidents operators declare on the status endpoint.
+type IncidentModel struct {
+	ID         string     `gorm:"primaryKey;type:uuid"`
+	Title      string     `gorm:"type:varchar(120);not null"`
+	Message    string     `gorm:"type:text"`
+	Severity   string     `gorm:"type:varchar(20);not null"`
+	Networks   string     `gorm:"type:jsonb;not null"`
+	CreatedBy  string     `gorm:"type:varchar(255)"`
+	StartedAt  time.Time  `gorm:"not null;index"`
+	ResolvedAt *time.Time `gorm:"index"`
+}
+
+// TableName returns the table name for the IncidentModel.
+func (IncidentModel) TableName() string {
+	return "incidents"
+}
+
+// ArchivedInvoiceModel represents the database model for archived invoices. The invoice row, its payment rows and
+// their event streams are kept as JSON, as they were stored in the invoices, payments and payment_events tables.
+type ArchivedInvoiceModel struct {
+	ID            string    `gorm:"primaryKey;type:varchar(64)"`
+	MerchantID    string    `gorm:"type:uuid;not null;index:idx_archived_invoices_merchant,priority:1"`
+	Status        string    `gorm:"type:varchar(20);not null"`
+	Invoice       string    `gorm:"type:jsonb;not null"`
+	Payments      string    `gorm:"type:jsonb;not null"`
+	PaymentEvents string    `gorm:"type:jsonb;not null"`
+	CreatedAt     time.Time `gorm:"not null;index:idx_archived_invoices_merchant,priority:2"`
+	ArchivedAt    time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the ArchivedInvoiceModel.
+func (ArchivedInvoiceModel) TableName() string {
+	return "archived_invoices"
+}
+
+// SagaModel represents the database model for the state of the saga settling a confirmed payment.
+type SagaModel struct {
+	ID             string    `gorm:"primaryKey;type:varchar(64)"`
+	InvoiceID      string    `gorm:"type:varchar(64);not null;index"`
+	PaymentID      string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	MerchantID     string    `gorm:"type:varchar(64);index"`
+	SettlementID   string    `gorm:"type:varchar(64);index"`
+	PayoutID       string    `gorm:"type:varchar(64)"`
+	Status         string    `gorm:"type:varchar(20);not null;index"`
+	Step           string    `gorm:"type:varchar(30);not null"`
+	PayoutFailures int       `gorm:"not null;default:0"`
+	Reason         string    `gorm:"type:text"`
+	LastError      string    `gorm:"type:text"`
+	CreatedAt      time.Time `gorm:"not null;index"`
+	UpdatedAt      time.Time `gorm:"not null"`
+	CompletedAt    *time.Time
+}
+
+// TableName returns the table name for the SagaModel.
+func (SagaModel) TableName() string {
+	return "sagas"
+}
+
+// IDSequenceModel represents the database model for the counters numbering sequential IDs.
+type IDSequenceModel struct {
+	Prefix string `gorm:"primaryKey;type:varchar(16)"`
+	Kind   string `gorm:"primaryKey;type:varchar(20)"`
+	Year   int    `gorm:"primaryKey"`
+	Value  int64  `gorm:"not null"`
+}
+
+// TableName returns the table name for the IDSequenceModel.
+func (IDSequenceModel) TableName() string {
+	return "id_sequences"
+}
+
+// BlockCursorModel represents the database model for the last block a watcher processed on a network.
+type BlockCursorModel struct {
+	Network   string    `gorm:"primaryKey;type:varchar(20)"`
+	Watcher   string    `gorm:"primaryKey;type:varchar(100)"`
+	Block     int64     `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the BlockCursorModel.
+func (BlockCursorModel) TableName() string {
+	return "block_cursors"
+}
+
+// UnattributedDepositModel represents the database model for a transfer to the payment address of a closed
+// invoice. A transaction is recorded once per network.
+type UnattributedDepositModel struct {
+	ID                string    `gorm:"primaryKey;type:uuid"`
+	MerchantID        string    `gorm:"type:varchar(64);not null;index:idx_deposit_merchant_status,priority:1"`
+	InvoiceID         string    `gorm:"type:varchar(64);not null;index"`
+	Reason            string    `gorm:"type:varchar(20);not null"`
+	Status            string    `gorm:"type:varchar(20);not null;index:idx_deposit_merchant_status,priority:2"`
+	Network           string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_deposit_network_tx_hash,priority:1"`
+	TxHash            string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_deposit_network_tx_hash,priority:2"`
+	FromAddress       string    `gorm:"type:varchar(100);not null"`
+	ToAddress         string    `gorm:"type:varchar(100);not null"`
+	Amount            string    `gorm:"type:varchar(78);not null"`
+	Currency          string    `gorm:"type:varchar(10);not null"`
+	Memo              string    `gorm:"type:varchar(255)"`
+	BlockNumber       int64     `gorm:"not null;default:0"`
+	BlockHash         string    `gorm:"type:varchar(100)"`
+	Confirmations     int       `gorm:"not null;default:0"`
+	AttachedInvoiceID string    `gorm:"type:varchar(64)"`
+	PaymentID         string    `gorm:"type:varchar(64)"`
+	ResolvedBy        string    `gorm:"type:varchar(64)"`
+	CreatedAt         time.Time `gorm:"not null;index"`
+	ResolvedAt        *time.Time
+}
+
+// TableName returns the table name for the UnattributedDepositModel.
+func (UnattributedDepositModel) TableName() string {
+	return "unattributed_deposits"
+}
+
+// InvoiceNumberSequenceModel represents the database model for the last accounting number issued to a merchant's
+// invoices in a period.
+type InvoiceNumberSequenceModel struct {
+	MerchantID string `gorm:"primaryKey;type:uuid"`
+	Period     int    `gorm:"primaryKey"` // Year of a yearly sequence, 0 for a sequence that never resets
+	Value      int64  `gorm:"not null"`
+}
+
+// TableName returns the table name for the InvoiceNumberSequenceModel.
+func (InvoiceNumberSequenceModel) TableName() string {
+	return "invoice_number_sequences"
+}
+
+// CustomerModel represents the database model for customer accounts.
+type CustomerModel struct {
+	ID          string    `gorm:"primaryKey;type:uuid"`
+	Email       string    `gorm:"type:varchar(254);not null;uniqueIndex"`
+	CreatedAt   time.Time `gorm:"not null"`
+	LastLoginAt *time.Time
+}
+
+// TableName returns the table name for the CustomerModel.
+func (CustomerModel) TableName() string {
+	return "customers"
+}
+
+// CustomerInvoiceModel represents the database model for the invoices in a customer's payment history. An invoice
+// belongs to one customer at most.
+type CustomerInvoiceModel struct {
+	InvoiceID  string    `gorm:"primaryKey;type:varchar(64)"`
+	CustomerID string    `gorm:"type:uuid;not null;index:idx_customer_invoice_claimed,priority:1"`
+	ClaimedAt  time.Time `gorm:"not null;index:idx_customer_invoice_claimed,priority:2"`
+}
+
+// TableName returns the table name for the CustomerInvoiceModel.
+func (CustomerInvoiceModel) TableName() string {
+	return "customer_invoices"
+}
+
+// CustomerLoginLinkModel represents the database model for customer magic login links.
+type CustomerLoginLinkModel struct {
+	ID            string    `gorm:"primaryKey;type:uuid"`
+	Email         string    `gorm:"type:varchar(254);not null;index:idx_customer_login_link_email,priority:1"`
+	TokenHash     string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	CodeChallenge string    `gorm:"type:varchar(43);not null"`
+	ExpiresAt     time.Time `gorm:"not null"`
+	CreatedAt     time.Time `gorm:"not null;index:idx_customer_login_link_email,priority:2"`
+	UsedAt        *time.Time
+}
+
+// TableName returns the table name for the CustomerLoginLinkModel.
+func (CustomerLoginLinkModel) TableName() string {
+	return "customer_login_links"
+}
+
+// CustomerSessionModel represents the database model for customer sessions.
+type CustomerSessionModel struct {
+	ID         string    `gorm:"primaryKey;type:uuid"`
+	CustomerID string    `gorm:"type:uuid;not null;index"`
+	TokenHash  string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt  time.Time `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"not null"`
+	RevokedAt  *time.Time
+}
+
+// TableName returns the table name for the CustomerSessionModel.
+func (CustomerSessionModel) TableName() string {
+	return "customer_sessions"
+}
+
+// CustomerRefundAddressModel represents the database model for the refund addresses customers saved.
+type CustomerRefundAddressModel struct {
+	ID         string    `gorm:"primaryKey;type:uuid"`
+	CustomerID string    `gorm:"type:uuid;not null;uniqueIndex:idx_customer_refund_address,priority:1"`
+	Address    string    `gorm:"type:varchar(128);not null;uniqueIndex:idx_customer_refund_address,priority:2"`
+	Label      string    `gorm:"type:varchar(64)"`
+	CreatedAt  time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the CustomerRefundAddressModel.
+func (CustomerRefundAddressModel) TableName() string {
+	return "customer_refund_addresses"
+}
+
+// ReconciliationReportModel represents the database model for the reports of the reconciliations of the ledger
+// against the chain and the settlements.
+type ReconciliationReportModel struct {
+	ID               string    `gorm:"primaryKey;type:uuid"`
+	PeriodStart      time.Time `gorm:"not null"`
+	PeriodEnd        time.Time `gorm:"not null"`
+	AddressesChecked int       `gorm:"not null;default:0"`
+	AddressesSkipped int       `gorm:"not null;default:0"`
+	InvoicesChecked  int       `gorm:"not null;default:0"`
+	Discrepancies    string    `gorm:"type:jsonb;not null"` // Mismatches found, empty array when none
+	Alerted          bool      `gorm:"not null;default:false"`
+	CreatedAt        time.Time `gorm:"not null;index"`
+}
+
+// TableName returns the table name for the ReconciliationReportModel.
+func (ReconciliationReportModel) TableName() string {
+	return "reconciliation_reports"
+}
+
+// StatementModel represents the database model for the monthly statements of merchants.
+type StatementModel struct {
+	ID            string    `gorm:"primaryKey;type:uuid"`
+	MerchantID    string    `gorm:"type:uuid;not null;uniqueIndex:idx_statements_merchant_period,priority:1"`
+	PeriodStart   time.Time `gorm:"not null;uniqueIndex:idx_statements_merchant_period,priority:2"`
+	PeriodEnd     time.Time `gorm:"not null"`
+	Currencies    string    `gorm:"type:jsonb;not null"` // Volume, fees, refunds and net settled by currency
+	InvoiceCounts string    `gorm:"type:jsonb;not null"` // Invoices created in the period by status
+	Files         string    `gorm:"type:jsonb;not null"` // Storage keys of the rendered statement by format
+	CreatedAt     time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the StatementModel.
+func (StatementModel) TableName() string {
+	return "statements"
+}
+
+// CheckoutSnapshotModel represents the database model for the snapshots of what checkout pages showed customers.
+type CheckoutSnapshotModel struct {
+	ID         string    `gorm:"primaryKey;type:uuid"`
+	InvoiceID  string    `gorm:"type:varchar(64);not null;index:idx_checkout_snapshots_invoice,priority:1"`
+	MerchantID string    `gorm:"type:uuid;not null"`
+	Content    string    `gorm:"type:jsonb;not null"` // Amounts, address, rate and expiry shown on the page
+	SHA256     string    `gorm:"column:sha256;type:varchar(64);not null"`
+	CapturedAt time.Time `gorm:"not null;index:idx_checkout_snapshots_invoice,priority:2"`
+}
+
+// TableName returns the table name for the CheckoutSnapshotModel.
+func (CheckoutSnapshotModel) TableName() string {
+	return "checkout_snapshots"
+}
+
+// ExperimentModel represents the database model for merchants' checkout page experiments.
+type ExperimentModel struct {
+	ID         string `gorm:"primaryKey;type:uuid"`
+	MerchantID string `gorm:"type:varchar(64);not null;index:idx_experiments_merchant_status,priority:1"`
+	Name       string `gorm:"type:varchar(100);not null"`
+	Status     string `gorm:"type:varchar(20);not null;index:idx_experiments_merchant_status,priority:2"`
+	Variants   string `gorm:"type:jsonb;not null"` // Keys, weights, copy, layout and currency order of the variants
+	StartedAt  *time.Time
+	StoppedAt  *time.Time
+	CreatedAt  time.Time `gorm:"not null"`
+	UpdatedAt  time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the ExperimentModel.
+func (ExperimentModel) TableName() string {
+	return "experiments"
+}
+
+// ExperimentAssignmentModel represents the database model for the checkout variants invoices were shown.
+type ExperimentAssignmentModel struct {
+	ExperimentID string     `gorm:"primaryKey;type:uuid"`
+	InvoiceID    string     `gorm:"primaryKey;type:varchar(64);index"`
+	SessionID    string     `gorm:"type:varchar(64);not null"`
+	Variant      string     `gorm:"type:varchar(32);not null"`
+	AssignedAt   time.Time  `gorm:"not null"`
+	ConvertedAt  *time.Time // When the invoice was paid
+	Amount       *string    `gorm:"type:decimal(20,8)"` // Invoice total once converted
+	Currency     *string    `gorm:"type:varchar(10)"`
+}
+
+// TableName returns the table name for the ExperimentAssignmentModel.
+func (ExperimentAssignmentModel) TableName() string {
+	return "experiment_assignments"
+}
+
+// CheckoutFunnelModel represents the database model for how far the checkout of an invoice went. Only the time
+// of each step is kept, not who viewed the page.
+type CheckoutFunnelModel struct {
+	InvoiceID            string     `gorm:"primaryKey;type:varchar(64)"`
+	MerchantID           string     `gorm:"type:varchar(64);not null;index:idx_checkout_funnels_merchant_viewed,priority:1"`
+	Views                int        `gorm:"not null;default:1"` // Times the checkout page was shown
+	FirstViewedAt        time.Time  `gorm:"not null;index:idx_checkout_funnels_merchant_viewed,priority:2"`
+	AddressCopiedAt      *time.Time // When the customer first copied the payment address
+	PaidAt               *time.Time
+	TimeToPaymentSeconds *int64 // Seconds from first view to payment, kept so that averages need no date arithmetic
+}
+
+// TableName returns the table name for the CheckoutFunnelModel.
+func (CheckoutFunnelModel) TableName() string {
+	return "checkout_funnels"
+}
+
+// CheckoutViewCountModel represents the database model for the daily counts of a merchant's viewed checkouts by
+// browser, device, referrer and country.
+type CheckoutViewCountModel struct {
+	MerchantID string `gorm:"primaryKey;type:varchar(64)"`
+	Day        string `gorm:"primaryKey;type:varchar(10)"` // UTC date, YYYY-MM-DD
+	Dimension  string `gorm:"primaryKey;type:varchar(20)"`
+	Value      string `gorm:"primaryKey;type:varchar(255)"`
+	Views      int    `gorm:"not null"`
+}
+
+// TableName returns the table name for the CheckoutViewCountModel.
+func (CheckoutViewCountModel) TableName() string {
+	return "checkout_view_counts"
+}
+
+// GeoBlockModel represents the database model for the refused attempts to view an invoice's checkout from a
+// country its merchant restricts.
+type GeoBlockModel struct {
+	InvoiceID      string    `gorm:"primaryKey;type:varchar(64)"`
+	Country        string    `gorm:"primaryKey;type:varchar(2)"` // ISO 3166-1 alpha-2 code
+	MerchantID     string    `gorm:"type:varchar(64);not null;index"`
+	Attempts       int       `gorm:"not null;default:1"`
+	FirstBlockedAt time.Time `gorm:"not null"`
+	LastBlockedAt  time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the GeoBlockModel.
+func (GeoBlockModel) TableName() string {
+	return "geo_blocks"
+}
+
+// GeoOverrideModel represents the database model for the tokens that let an invoice's checkout be viewed from
+// restricted countries. Only the hash of each token is kept.
+type GeoOverrideModel struct {
+	ID         string     `gorm:"primaryKey;type:uuid"`
+	InvoiceID  string     `gorm:"type:varchar(64);not null;index"`
+	MerchantID string     `gorm:"type:varchar(64);not null"`
+	TokenHash  string     `gorm:"type:varchar(64);not null;uniqueIndex"`
+	IssuedBy   string     `gorm:"type:varchar(255);not null"` // ID of the user or API key that issued it
+	Reason     string     `gorm:"type:text"`
+	IssuedAt   time.Time  `gorm:"not null"`
+	ExpiresAt  time.Time  `gorm:"not null"`
+	UsedAt     *time.Time // When a customer first used it
+}
+
+// TableName returns the table name for the GeoOverrideModel.
+func (GeoOverrideModel) TableName() string {
+	return "geo_overrides"
+}
@@ -0,0 +1,34 @@
+package database
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// keysetPage orders a query newest first by the given time column and then by ID, and narrows it
+// to the rows after the cursor. Without a cursor it skips offset rows instead, which is kept for
+// clients that still page by offset. One row more than limit is fetched so that nextPage can tell
+// whether another page follows.
+func keysetPage(column string, cursor *shared.Cursor, offset, limit int) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if cursor != nil {
+			db = db.Where("("+column+" < ? OR ("+column+" = ? AND id < ?))",
+				cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+		} else if offset > 0 {
+			db = db.Offset(offset)
+		}
+		return db.Order(column + " DESC").Order("id DESC").Limit(limit + 1)
+	}
+}
+
+// nextPage trims the extra row fetched by keysetPage and returns the cursor of the following page,
+// or nil on the last page.
+func nextPage[T any](rows []T, limit int, key func(*T) (time.Time, string)) ([]T, *shared.Cursor) {
+	if len(rows) <= limit {
+		return rows, nil
+	}
+	rows = rows[:limit]
+	return rows, shared.NewCursor(key(&rows[limit-1]))
+}
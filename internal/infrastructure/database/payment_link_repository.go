@@ -0,0 +1,222 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/paymentlink"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// PaymentLinkRepository implements the paymentlink.Repository interface using GORM.
+type PaymentLinkRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewPaymentLinkRepository creates a new payment link repository.
+func NewPaymentLinkRepository(db *gorm.DB, logger *zap.Logger) paymentlink.Repository {
+	return &PaymentLinkRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a new payment link to the database.
+func (r *PaymentLinkRepository) Save(ctx context.Context, link *paymentlink.PaymentLink) error {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&PaymentLinkModel{}).
+		Where("slug = ?", link.Slug()).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check payment link slug: %w", err)
+	}
+	if count > 0 {
+		return paymentlink.ErrSlugTaken
+	}
+
+	if err := r.db.WithContext(ctx).Create(r.toModel(link)).Error; err != nil {
+		return fmt.Errorf("failed to save payment link: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a payment link by ID.
+func (r *PaymentLinkRepository) FindByID(ctx context.Context, id string) (*paymentlink.PaymentLink, error) {
+	link, err := r.findOne(r.db.WithContext(ctx).Scopes(merchantScope(ctx)).Where("id = ?", id))
+	if errors.Is(err, paymentlink.ErrLinkNotFound) {
+		reportCrossTenantAccess(ctx, r.db, r.logger, &PaymentLinkModel{}, "payment_link", id)
+	}
+	return link, err
+}
+
+// FindBySlug finds a payment link by its slug.
+func (r *PaymentLinkRepository) FindBySlug(ctx context.Context, slug string) (*paymentlink.PaymentLink, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("slug = ?", slug))
+}
+
+// ListByMerchant lists a merchant's payment links, newest first.
+func (r *PaymentLinkRepository) ListByMerchant(
+	ctx context.Context,
+	merchantID string,
+) ([]*paymentlink.PaymentLink, error) {
+	var models []PaymentLinkModel
+	if err := r.db.WithContext(ctx).
+		Where("merchant_id = ?", merchantID).
+		Order("created_at DESC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list payment links: %w", err)
+	}
+
+	links := make([]*paymentlink.PaymentLink, len(models))
+	for i := range models {
+		link, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert payment link model to domain: %w", err)
+		}
+		links[i] = link
+	}
+	return links, nil
+}
+
+// Update updates an existing payment link. The visit count is only changed through
+// IncrementVisits so concurrent visits are not lost.
+func (r *PaymentLinkRepository) Update(ctx context.Context, link *paymentlink.PaymentLink) error {
+	result := r.db.WithContext(ctx).Model(&PaymentLinkModel{}).
+		Scopes(merchantScope(ctx)).
+		Where("id = ?", link.ID()).
+		Updates(map[string]interface{}{
+			"active":     link.IsActive(),
+			"updated_at": link.UpdatedAt(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update payment link: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		reportCrossTenantAccess(ctx, r.db, r.logger, &PaymentLinkModel{}, "payment_link", link.ID())
+		return paymentlink.ErrLinkNotFound
+	}
+	return nil
+}
+
+// IncrementVisits atomically records a customer visit.
+func (r *PaymentLinkRepository) IncrementVisits(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Model(&PaymentLinkModel{}).
+		Where("id = ?", id).
+		UpdateColumn("visits", gorm.Expr("visits + 1"))
+	if result.Error != nil {
+		return fmt.Errorf("failed to record payment link visit: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return paymentlink.ErrLinkNotFound
+	}
+	return nil
+}
+
+// RecordInvoice links an invoice to the payment link it was created from.
+func (r *PaymentLinkRepository) RecordInvoice(ctx context.Context, linkID, invoiceID string) error {
+	model := &PaymentLinkInvoiceModel{
+		PaymentLinkID: linkID,
+		InvoiceID:     invoiceID,
+		CreatedAt:     shared.Now().UTC(),
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to record payment link invoice: %w", err)
+	}
+	return nil
+}
+
+// ListInvoices lists the invoices created from a payment link with their current status and total.
+func (r *PaymentLinkRepository) ListInvoices(ctx context.Context, linkID string) ([]*paymentlink.LinkedInvoice, error) {
+	var rows []struct {
+		ID     string
+		Status string
+		Total  string
+	}
+	if err := r.db.WithContext(ctx).
+		Table("payment_link_invoices").
+		Select("invoices.id, invoices.status, invoices.total").
+		Joins("JOIN invoices ON invoices.id = payment_link_invoices.invoice_id").
+		Where("payment_link_invoices.payment_link_id = ? AND invoices.deleted_at IS NULL", linkID).
+		Order("payment_link_invoices.created_at").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list payment link invoices: %w", err)
+	}
+
+	invoices := make([]*paymentlink.LinkedInvoice, len(rows))
+	for i, row := range rows {
+		invoices[i] = &paymentlink.LinkedInvoice{
+			InvoiceID: row.ID,
+			Status:    invoice.InvoiceStatus(row.Status),
+			Total:     row.Total,
+		}
+	}
+	return invoices, nil
+}
+
+// findOne finds a single payment link matching the query.
+func (r *PaymentLinkRepository) findOne(query *gorm.DB) (*paymentlink.PaymentLink, error) {
+	var model PaymentLinkModel
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, paymentlink.ErrLinkNotFound
+		}
+		return nil, fmt.Errorf("failed to find payment link: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// toModel converts a domain payment link to a database model.
+func (r *PaymentLinkRepository) toModel(link *paymentlink.PaymentLink) *PaymentLinkModel {
+	var amount *string
+	if link.Amount() != nil {
+		value := link.Amount().Amount().String()
+		amount = &value
+	}
+
+	return &PaymentLinkModel{
+		ID:             link.ID(),
+		MerchantID:     link.MerchantID(),
+		Slug:           link.Slug(),
+		Title:          link.Title(),
+		Description:    link.Description(),
+		Amount:         amount,
+		Currency:       string(link.Currency()),
+		CryptoCurrency: string(link.CryptoCurrency()),
+		Active:         link.IsActive(),
+		Visits:         link.Visits(),
+		CreatedAt:      link.CreatedAt(),
+		UpdatedAt:      link.UpdatedAt(),
+	}
+}
+
+// toDomain converts a database model to a domain payment link.
+func (r *PaymentLinkRepository) toDomain(model *PaymentLinkModel) (*paymentlink.PaymentLink, error) {
+	var amount *shared.Money
+	if model.Amount != nil {
+		var err error
+		amount, err = shared.NewMoney(*model.Amount, shared.Currency(model.Currency))
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore payment link amount: %w", err)
+		}
+	}
+
+	return paymentlink.RestorePaymentLink(
+		model.ID,
+		model.MerchantID,
+		model.Slug,
+		model.Title,
+		model.Description,
+		amount,
+		shared.Currency(model.Currency),
+		shared.CryptoCurrency(model.CryptoCurrency),
+		model.Active,
+		model.Visits,
+		model.CreatedAt,
+		model.UpdatedAt,
+	)
+}
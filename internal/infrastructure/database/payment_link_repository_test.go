@@ -0,0 +1,89 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/paymentlink"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPaymentLinkRepository_Stats(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewPaymentLinkRepository(db, zap.NewNop())
+	invoiceRepo := database.NewInvoiceRepository(db, zap.NewNop())
+	service := paymentlink.NewService(repo, nil, zap.NewNop())
+	ctx := context.Background()
+
+	amount, err := shared.NewMoney("25.00", shared.CurrencyUSD)
+	require.NoError(t, err)
+	link, err := paymentlink.NewPaymentLink(
+		"link-1", "merchant-1", "Ab3dE6gH", "Coffee", "A cup of coffee",
+		amount, shared.CurrencyUSD, shared.CryptoCurrencyUSDT,
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, link))
+
+	t.Run("slugs are unique", func(t *testing.T) {
+		duplicate, err := paymentlink.NewPaymentLink(
+			"link-2", "merchant-2", "Ab3dE6gH", "Tea", "",
+			nil, shared.CurrencyUSD, shared.CryptoCurrencyUSDT,
+		)
+		require.NoError(t, err)
+		require.ErrorIs(t, repo.Save(ctx, duplicate), paymentlink.ErrSlugTaken)
+	})
+
+	t.Run("find by slug restores the link", func(t *testing.T) {
+		found, err := repo.FindBySlug(ctx, "Ab3dE6gH")
+		require.NoError(t, err)
+		assert.Equal(t, "link-1", found.ID())
+		assert.True(t, found.Amount().Equals(amount))
+		assert.False(t, found.IsOpenAmount())
+
+		_, err = repo.FindBySlug(ctx, "missing")
+		require.ErrorIs(t, err, paymentlink.ErrLinkNotFound)
+	})
+
+	t.Run("stats count visits and paid invoices", func(t *testing.T) {
+		require.NoError(t, repo.IncrementVisits(ctx, "link-1"))
+		require.NoError(t, repo.IncrementVisits(ctx, "link-1"))
+
+		paid := createTestInvoiceWithID(t, "link-invoice-paid")
+		paid.SetStatus(invoice.StatusPaid)
+		require.NoError(t, invoiceRepo.Save(ctx, paid))
+		open := createTestInvoiceWithID(t, "link-invoice-open")
+		require.NoError(t, invoiceRepo.Save(ctx, open))
+
+		require.NoError(t, repo.RecordInvoice(ctx, "link-1", paid.ID()))
+		require.NoError(t, repo.RecordInvoice(ctx, "link-1", open.ID()))
+
+		stats, err := service.GetLinkStats(ctx, "merchant-1", "link-1")
+		require.NoError(t, err)
+		assert.Equal(t, 2, stats.Visits)
+		assert.Equal(t, 2, stats.Invoices)
+		assert.Equal(t, 1, stats.PaidInvoices)
+		assert.Equal(t, paid.Pricing().Total().String(), stats.AmountCollected.String())
+
+		_, err = service.GetLinkStats(ctx, "merchant-2", "link-1")
+		require.ErrorIs(t, err, paymentlink.ErrLinkNotFound)
+	})
+
+	t.Run("deactivate keeps the visit count", func(t *testing.T) {
+		deactivated, err := service.DeactivateLink(ctx, "merchant-1", "link-1")
+		require.NoError(t, err)
+		assert.False(t, deactivated.IsActive())
+
+		found, err := repo.FindByID(ctx, "link-1")
+		require.NoError(t, err)
+		assert.False(t, found.IsActive())
+		assert.Equal(t, 2, found.Visits())
+
+		_, err = service.OpenLink(ctx, "Ab3dE6gH")
+		require.ErrorIs(t, err, paymentlink.ErrLinkInactive)
+	})
+}
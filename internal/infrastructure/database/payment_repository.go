@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto-checkout/internal/domain/payment"
 	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -11,6 +12,9 @@ import (
 )
 
 // PaymentRepository implements the payment.Repository interface using GORM.
+// Payments are event sourced: saving appends their pending events to the payment_events stream and
+// refreshes the payments table, which is the queryable projection of the stream. Every payment.SnapshotInterval
+// events the state is also written to payment_snapshots, so loading replays only the events after the latest snapshot.
 type PaymentRepository struct {
 	db *gorm.DB
 }
@@ -26,15 +30,94 @@ func (r *PaymentRepository) Save(ctx context.Context, p *payment.Payment) error
 		return payment.ErrInvalidPayment
 	}
 
-	// Convert domain model to database model
-	model := r.domainToModel(p)
+	return r.persist(ctx, p)
+}
 
-	// Save payment (GORM will handle insert/update automatically)
-	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
-		return fmt.Errorf("failed to save payment: %w", err)
+// FindEvents retrieves the event stream of a payment, oldest first.
+func (r *PaymentRepository) FindEvents(ctx context.Context, id string) ([]*payment.PaymentEvent, error) {
+	if id == "" {
+		return nil, payment.ErrInvalidPayment
 	}
 
-	return nil
+	streams, err := r.findStreams(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	if events, ok := streams[id]; ok {
+		return events, nil
+	}
+
+	// Payments stored before event sourcing have no stream yet
+	exists, err := r.Exists(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, payment.ErrPaymentNotFound
+	}
+	return []*payment.PaymentEvent{}, nil
+}
+
+// FindSnapshots retrieves the stored snapshots of a payment, oldest first.
+func (r *PaymentRepository) FindSnapshots(ctx context.Context, id string) ([]*payment.PaymentSnapshot, error) {
+	if id == "" {
+		return nil, payment.ErrInvalidPayment
+	}
+
+	var models []PaymentSnapshotModel
+	if err := r.db.WithContext(ctx).
+		Where("payment_id = ?", id).
+		Order("version ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find payment snapshots: %w", err)
+	}
+
+	snapshots := make([]*payment.PaymentSnapshot, len(models))
+	for i := range models {
+		snapshot, err := r.modelToSnapshot(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		snapshots[i] = snapshot
+	}
+	return snapshots, nil
+}
+
+// RebuildProjection overwrites the stored state of a payment with one replayed from its stream,
+// replaces the given snapshots, and removes snapshots beyond the payment's version.
+func (r *PaymentRepository) RebuildProjection(
+	ctx context.Context,
+	p *payment.Payment,
+	snapshots []*payment.PaymentSnapshot,
+) error {
+	if p == nil {
+		return payment.ErrInvalidPayment
+	}
+
+	models := make([]PaymentSnapshotModel, len(snapshots))
+	for i, snapshot := range snapshots {
+		model, err := r.snapshotToModel(snapshot)
+		if err != nil {
+			return err
+		}
+		models[i] = *model
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("payment_id = ? AND version > ?", string(p.ID()), p.Version()).
+			Delete(&PaymentSnapshotModel{}).Error; err != nil {
+			return fmt.Errorf("failed to remove payment snapshots: %w", err)
+		}
+		for i := range models {
+			if err := tx.Save(&models[i]).Error; err != nil {
+				return fmt.Errorf("failed to save payment snapshot: %w", err)
+			}
+		}
+		if err := tx.Save(r.domainToModel(p)).Error; err != nil {
+			return fmt.Errorf("failed to save payment: %w", err)
+		}
+		return nil
+	})
 }
 
 // FindByID retrieves a payment by its ID.
@@ -54,12 +137,14 @@ func (r *PaymentRepository) FindByID(ctx context.Context, id string) (*payment.P
 		return nil, fmt.Errorf("failed to find payment: %w", err)
 	}
 
-	return r.modelToDomain(ctx, &model)
+	return r.project(ctx, &model)
 }
 
-// FindByTransactionHash retrieves a payment by its transaction hash.
+// FindByTransactionHash retrieves the payment of a transaction on a network. Payments stored before networks
+// were recorded match a transaction on any network.
 func (r *PaymentRepository) FindByTransactionHash(
 	ctx context.Context,
+	network shared.BlockchainNetwork,
 	hash *payment.TransactionHash,
 ) (*payment.Payment, error) {
 	if hash == nil {
@@ -68,7 +153,8 @@ func (r *PaymentRepository) FindByTransactionHash(
 
 	var model PaymentModel
 	err := r.db.WithContext(ctx).
-		Where("tx_hash = ?", hash.String()).
+		Where("tx_hash = ? AND (network = ? OR network = '')", hash.String(), string(network)).
+		Order("network DESC").
 		First(&model).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -77,7 +163,24 @@ func (r *PaymentRepository) FindByTransactionHash(
 		return nil, fmt.Errorf("failed to find payment by transaction hash: %w", err)
 	}
 
-	return r.modelToDomain(ctx, &model)
+	return r.project(ctx, &model)
+}
+
+// FindByInvoiceID retrieves all payments for an invoice, oldest first.
+func (r *PaymentRepository) FindByInvoiceID(
+	ctx context.Context,
+	invoiceID shared.InvoiceID,
+) ([]*payment.Payment, error) {
+	var models []PaymentModel
+	err := r.db.WithContext(ctx).
+		Where("invoice_id = ?", string(invoiceID)).
+		Order("detected_at ASC, id ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payments by invoice: %w", err)
+	}
+
+	return r.modelsToDomain(ctx, models)
 }
 
 // FindByAddress retrieves all payments for a given address.
@@ -173,24 +276,89 @@ func (r *PaymentRepository) FindOrphaned(ctx context.Context) ([]*payment.Paymen
 	return r.modelsToDomain(ctx, models)
 }
 
-// Update updates an existing payment in the database.
+// Update appends the payment's pending events to its stream and refreshes its projection.
 func (r *PaymentRepository) Update(ctx context.Context, p *payment.Payment) error {
 	if p == nil {
 		return payment.ErrInvalidPayment
 	}
 
-	// Convert domain model to database model
-	model := r.domainToModel(p)
+	return r.persist(ctx, p)
+}
+
+// persist appends the payment's pending events and refreshes its projection in one transaction.
+// The stream must still end where the payment was loaded, so concurrent writers cannot interleave events.
+// A snapshot is written whenever the new events cross a multiple of payment.SnapshotInterval.
+func (r *PaymentRepository) persist(ctx context.Context, p *payment.Payment) error {
+	pending := p.PendingEvents()
+	events := make([]PaymentEventModel, len(pending))
+	for i, event := range pending {
+		model, err := r.eventToModel(event)
+		if err != nil {
+			return err
+		}
+		events[i] = *model
+	}
+
+	var snapshot *PaymentSnapshotModel
+	if len(events) > 0 && (events[0].Version-1)/payment.SnapshotInterval != p.Version()/payment.SnapshotInterval {
+		model, err := r.snapshotToModel(payment.TakeSnapshot(p))
+		if err != nil {
+			return err
+		}
+		snapshot = model
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(events) > 0 {
+			var version int
+			if err := tx.Model(&PaymentEventModel{}).
+				Where("payment_id = ?", string(p.ID())).
+				Select("COALESCE(MAX(version), 0)").
+				Scan(&version).Error; err != nil {
+				return fmt.Errorf("failed to read payment stream version: %w", err)
+			}
+			if version != events[0].Version-1 {
+				return payment.NewConcurrentModificationError(string(p.ID()), version)
+			}
+
+			if err := tx.Create(&events).Error; err != nil {
+				return fmt.Errorf("failed to append payment events: %w", err)
+			}
+		}
+
+		if snapshot != nil {
+			if err := tx.Create(snapshot).Error; err != nil {
+				return fmt.Errorf("failed to save payment snapshot: %w", err)
+			}
+		}
 
-	// Update payment
-	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
-		return fmt.Errorf("failed to update payment: %w", err)
+		if err := tx.Save(r.domainToModel(p)).Error; err != nil {
+			if errors.Is(r.translate(err), gorm.ErrDuplicatedKey) {
+				return fmt.Errorf("%w: %s on %s", payment.ErrDuplicateTransaction,
+					p.TransactionHash().String(), p.ToAddress().Network())
+			}
+			return fmt.Errorf("failed to save payment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
+	p.ClearPendingEvents()
 	return nil
 }
 
-// Delete removes a payment from the database.
+// translate maps a driver error to the gorm error it stands for, such as gorm.ErrDuplicatedKey for a unique
+// constraint violation.
+func (r *PaymentRepository) translate(err error) error {
+	if translator, ok := r.db.Dialector.(gorm.ErrorTranslator); ok {
+		return translator.Translate(err)
+	}
+	return err
+}
+
+// Delete removes a payment from the database. Its event stream is kept for audit.
 func (r *PaymentRepository) Delete(ctx context.Context, id string) error {
 	if id == "" {
 		return payment.ErrInvalidPayment
@@ -266,6 +434,7 @@ func (r *PaymentRepository) domainToModel(p *payment.Payment) *PaymentModel {
 	model := &PaymentModel{
 		ID:                    string(p.ID()),
 		InvoiceID:             string(p.InvoiceID()),
+		Network:               string(p.ToAddress().Network()),
 		Amount:                p.Amount().Amount().String(),
 		FromAddress:           p.FromAddress(),
 		ToAddress:             p.ToAddress().String(),
@@ -296,7 +465,75 @@ func (r *PaymentRepository) domainToModel(p *payment.Payment) *PaymentModel {
 	return model
 }
 
-// modelToDomain converts a database model to a domain payment.
+// project loads a payment from its latest snapshot and the events after it.
+func (r *PaymentRepository) project(ctx context.Context, model *PaymentModel) (*payment.Payment, error) {
+	payments, err := r.modelsToDomain(ctx, []PaymentModel{*model})
+	if err != nil {
+		return nil, err
+	}
+	return payments[0], nil
+}
+
+// findStreams retrieves the event streams of the given payments, keyed by payment ID.
+func (r *PaymentRepository) findStreams(ctx context.Context, ids []string) (map[string][]*payment.PaymentEvent, error) {
+	var models []PaymentEventModel
+	if err := r.db.WithContext(ctx).
+		Where("payment_id IN ?", ids).
+		Order("payment_id ASC, version ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find payment events: %w", err)
+	}
+
+	return r.modelsToStreams(models)
+}
+
+// modelsToStreams groups event models into event streams keyed by payment ID.
+func (r *PaymentRepository) modelsToStreams(models []PaymentEventModel) (map[string][]*payment.PaymentEvent, error) {
+	streams := make(map[string][]*payment.PaymentEvent)
+	for i := range models {
+		event, err := r.modelToEvent(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		streams[models[i].PaymentID] = append(streams[models[i].PaymentID], event)
+	}
+	return streams, nil
+}
+
+// eventToModel converts a payment event to a database model.
+func (r *PaymentRepository) eventToModel(event *payment.PaymentEvent) (*PaymentEventModel, error) {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payment event data: %w", err)
+	}
+
+	return &PaymentEventModel{
+		PaymentID:  string(event.PaymentID),
+		Version:    event.Version,
+		Type:       event.Type.String(),
+		Data:       string(data),
+		OccurredAt: event.OccurredAt,
+	}, nil
+}
+
+// modelToEvent converts a database model to a payment event.
+func (r *PaymentRepository) modelToEvent(model *PaymentEventModel) (*payment.PaymentEvent, error) {
+	var data payment.PaymentEventData
+	if err := json.Unmarshal([]byte(model.Data), &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payment event data: %w", err)
+	}
+
+	return &payment.PaymentEvent{
+		PaymentID:  shared.PaymentID(model.PaymentID),
+		Version:    model.Version,
+		Type:       payment.PaymentEventType(model.Type),
+		Data:       data,
+		OccurredAt: model.OccurredAt.UTC(),
+	}, nil
+}
+
+// modelToDomain restores a payment stored before event sourcing from its row.
+// The restored state is recorded as pending events, which start its stream on the next save.
 func (r *PaymentRepository) modelToDomain(ctx context.Context, model *PaymentModel) (*payment.Payment, error) {
 	// Create payment amount
 	amount, err := shared.NewMoneyWithCrypto(model.Amount, shared.CryptoCurrencyUSDT)
@@ -370,11 +607,36 @@ func (r *PaymentRepository) modelToDomain(ctx context.Context, model *PaymentMod
 	return p, nil
 }
 
-// modelsToDomain converts multiple database models to domain payments.
+// modelsToDomain loads the payments of multiple database models from their latest snapshots
+// and the events recorded after them.
 func (r *PaymentRepository) modelsToDomain(ctx context.Context, models []PaymentModel) ([]*payment.Payment, error) {
+	if len(models) == 0 {
+		return []*payment.Payment{}, nil
+	}
+
+	ids := make([]string, len(models))
+	for i := range models {
+		ids[i] = models[i].ID
+	}
+	snapshots, err := r.findLatestSnapshots(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	tails, err := r.findStreamTails(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
 	payments := make([]*payment.Payment, len(models))
 	for i := range models {
-		p, err := r.modelToDomain(ctx, &models[i])
+		var p *payment.Payment
+		snapshot, hasSnapshot := snapshots[models[i].ID]
+		events, hasEvents := tails[models[i].ID]
+		if hasSnapshot || hasEvents {
+			p, err = payment.RestorePayment(snapshot, events)
+		} else {
+			p, err = r.modelToDomain(ctx, &models[i])
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert model %d: %w", i, err)
 		}
@@ -382,3 +644,85 @@ func (r *PaymentRepository) modelsToDomain(ctx context.Context, models []Payment
 	}
 	return payments, nil
 }
+
+// latestSnapshots selects the version of the latest snapshot of each of the given payments.
+func (r *PaymentRepository) latestSnapshots(ctx context.Context, ids []string) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Model(&PaymentSnapshotModel{}).
+		Select("payment_id, MAX(version) AS version").
+		Where("payment_id IN ?", ids).
+		Group("payment_id")
+}
+
+// findLatestSnapshots retrieves the latest snapshot of each of the given payments, keyed by payment ID.
+func (r *PaymentRepository) findLatestSnapshots(
+	ctx context.Context,
+	ids []string,
+) (map[string]*payment.PaymentSnapshot, error) {
+	var models []PaymentSnapshotModel
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN (?) AS latest ON latest.payment_id = payment_snapshots.payment_id "+
+			"AND latest.version = payment_snapshots.version", r.latestSnapshots(ctx, ids)).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find payment snapshots: %w", err)
+	}
+
+	snapshots := make(map[string]*payment.PaymentSnapshot, len(models))
+	for i := range models {
+		snapshot, err := r.modelToSnapshot(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		snapshots[models[i].PaymentID] = snapshot
+	}
+	return snapshots, nil
+}
+
+// findStreamTails retrieves the events recorded after the latest snapshot of each of the given payments,
+// keyed by payment ID. Payments without a snapshot get their whole stream.
+func (r *PaymentRepository) findStreamTails(
+	ctx context.Context,
+	ids []string,
+) (map[string][]*payment.PaymentEvent, error) {
+	var models []PaymentEventModel
+	if err := r.db.WithContext(ctx).
+		Joins("LEFT JOIN (?) AS latest ON latest.payment_id = payment_events.payment_id", r.latestSnapshots(ctx, ids)).
+		Where("payment_events.payment_id IN ?", ids).
+		Where("payment_events.version > COALESCE(latest.version, 0)").
+		Order("payment_events.payment_id ASC, payment_events.version ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find payment events: %w", err)
+	}
+
+	return r.modelsToStreams(models)
+}
+
+// snapshotToModel converts a payment snapshot to a database model.
+func (r *PaymentRepository) snapshotToModel(snapshot *payment.PaymentSnapshot) (*PaymentSnapshotModel, error) {
+	state, err := json.Marshal(snapshot.State)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payment snapshot: %w", err)
+	}
+
+	return &PaymentSnapshotModel{
+		PaymentID: string(snapshot.PaymentID),
+		Version:   snapshot.Version,
+		State:     string(state),
+		TakenAt:   snapshot.TakenAt,
+	}, nil
+}
+
+// modelToSnapshot converts a database model to a payment snapshot.
+func (r *PaymentRepository) modelToSnapshot(model *PaymentSnapshotModel) (*payment.PaymentSnapshot, error) {
+	var state payment.PaymentSnapshotState
+	if err := json.Unmarshal([]byte(model.State), &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payment snapshot: %w", err)
+	}
+
+	return &payment.PaymentSnapshot{
+		PaymentID: shared.PaymentID(model.PaymentID),
+		Version:   model.Version,
+		State:     state,
+		TakenAt:   model.TakenAt.UTC(),
+	}, nil
+}
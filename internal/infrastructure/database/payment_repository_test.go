@@ -7,8 +7,12 @@ import (
 	"crypto-checkout/internal/infrastructure/database"
 	"crypto-checkout/pkg/config"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -57,6 +61,26 @@ func createTestPaymentWithID(t *testing.T, id, txHash string) *payment.Payment {
 	return p
 }
 
+func createEthereumTestPayment(t *testing.T, id, txHash string) *payment.Payment {
+	amount, _ := shared.NewMoneyWithCrypto("100.00", shared.CryptoCurrencyUSDT)
+	paymentAmount, _ := payment.NewPaymentAmount(amount, shared.CryptoCurrencyUSDT)
+	toAddress, _ := payment.NewPaymentAddress("0x2222222222222222222222222222222222222222", shared.NetworkEthereum)
+	transactionHash, _ := payment.NewTransactionHash(txHash)
+
+	p, err := payment.NewPayment(
+		shared.PaymentID(id),
+		shared.InvoiceID("test-invoice-id"),
+		paymentAmount,
+		"0x1111111111111111111111111111111111111111",
+		toAddress,
+		transactionHash,
+		12,
+	)
+	require.NoError(t, err)
+
+	return p
+}
+
 func TestPaymentRepository(t *testing.T) {
 	t.Run("Save", func(t *testing.T) {
 		t.Run("Valid_Payment", func(t *testing.T) {
@@ -164,7 +188,7 @@ func TestPaymentRepository(t *testing.T) {
 
 			// Find by transaction hash
 			transactionHash := p.TransactionHash()
-			found, err := repo.FindByTransactionHash(ctx, transactionHash)
+			found, err := repo.FindByTransactionHash(ctx, shared.NetworkTron, transactionHash)
 			require.NoError(t, err)
 			require.NotNil(t, found)
 
@@ -181,7 +205,7 @@ func TestPaymentRepository(t *testing.T) {
 				"0x0000000000000000000000000000000000000000000000000000000000000000",
 			)
 
-			found, err := repo.FindByTransactionHash(ctx, transactionHash)
+			found, err := repo.FindByTransactionHash(ctx, shared.NetworkTron, transactionHash)
 			require.Error(t, err)
 			require.Nil(t, found)
 			require.Contains(t, err.Error(), "not found")
@@ -192,13 +216,44 @@ func TestPaymentRepository(t *testing.T) {
 			repo := database.NewPaymentRepository(db)
 			ctx := context.Background()
 
-			found, err := repo.FindByTransactionHash(ctx, nil)
+			found, err := repo.FindByTransactionHash(ctx, shared.NetworkTron, nil)
 			require.Error(t, err)
 			require.Nil(t, found)
 			require.Contains(t, err.Error(), "invalid input")
 		})
 	})
 
+	t.Run("DuplicateTransaction", func(t *testing.T) {
+		db := setupPaymentTestDB(t)
+		repo := database.NewPaymentRepository(db)
+		ctx := context.Background()
+
+		txHash := "0xfeedfacefeedfacefeedfacefeedfacefeedfacefeedfacefeedfacefeedface"
+		require.NoError(t, repo.Save(ctx, createTestPaymentWithID(t, "payment-1", txHash)))
+
+		// A second payment for the same transaction on the same network is rejected
+		err := repo.Save(ctx, createTestPaymentWithID(t, "payment-2", txHash))
+		require.ErrorIs(t, err, payment.ErrDuplicateTransaction)
+		exists, err := repo.Exists(ctx, "payment-2")
+		require.NoError(t, err)
+		require.False(t, exists)
+
+		// The same hash on another network is another transaction
+		other := createEthereumTestPayment(t, "payment-3", txHash)
+		require.NoError(t, repo.Save(ctx, other))
+
+		hash, err := payment.NewTransactionHash(txHash)
+		require.NoError(t, err)
+		found, err := repo.FindByTransactionHash(ctx, shared.NetworkEthereum, hash)
+		require.NoError(t, err)
+		require.Equal(t, shared.PaymentID("payment-3"), found.ID())
+		found, err = repo.FindByTransactionHash(ctx, shared.NetworkTron, hash)
+		require.NoError(t, err)
+		require.Equal(t, shared.PaymentID("payment-1"), found.ID())
+		_, err = repo.FindByTransactionHash(ctx, shared.NetworkBSC, hash)
+		require.ErrorIs(t, err, payment.ErrPaymentNotFound)
+	})
+
 	t.Run("FindByInvoiceID", func(t *testing.T) {
 		t.Run("Existing_Payments", func(t *testing.T) {
 			db := setupPaymentTestDB(t)
@@ -232,8 +287,7 @@ func TestPaymentRepository(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			// Find pending payments (since FindByInvoiceID doesn't exist)
-			payments, err := repo.FindPending(ctx)
+			payments, err := repo.FindByInvoiceID(ctx, invoiceID)
 			require.NoError(t, err)
 			require.Len(t, payments, 3)
 
@@ -242,12 +296,12 @@ func TestPaymentRepository(t *testing.T) {
 			}
 		})
 
-		t.Run("No_Pending_Payments", func(t *testing.T) {
+		t.Run("No_Payments", func(t *testing.T) {
 			db := setupPaymentTestDB(t)
 			repo := database.NewPaymentRepository(db)
 			ctx := context.Background()
 
-			payments, err := repo.FindPending(ctx)
+			payments, err := repo.FindByInvoiceID(ctx, "unpaid-invoice-id")
 			require.NoError(t, err)
 			require.Len(t, payments, 0)
 		})
@@ -454,4 +508,292 @@ func TestPaymentRepository(t *testing.T) {
 			}
 		})
 	})
+
+	t.Run("EventStream", func(t *testing.T) {
+		t.Run("History_Across_Saves", func(t *testing.T) {
+			db := setupPaymentTestDB(t)
+			repo := database.NewPaymentRepository(db)
+			ctx := context.Background()
+
+			p := createTestPayment(t)
+			require.NoError(t, repo.Save(ctx, p))
+			require.Empty(t, p.PendingEvents())
+
+			require.NoError(t, p.UpdateBlockInfo(12345, "blockhash123"))
+			require.NoError(t, p.UpdateConfirmations(nil, 3))
+			require.NoError(t, payment.NewPaymentFSM(p).Event(ctx, "include_in_block"))
+			require.NoError(t, repo.Update(ctx, p))
+
+			events, err := repo.FindEvents(ctx, string(p.ID()))
+			require.NoError(t, err)
+			require.Len(t, events, 4)
+			for i, event := range events {
+				require.Equal(t, i+1, event.Version)
+			}
+			require.Equal(t, payment.PaymentEventDetected, events[0].Type)
+			require.Equal(t, payment.PaymentEventIncludedInBlock, events[3].Type)
+
+			retrieved, err := repo.FindByID(ctx, string(p.ID()))
+			require.NoError(t, err)
+			require.Equal(t, 4, retrieved.Version())
+			require.Equal(t, payment.StatusConfirming, retrieved.Status())
+			require.Equal(t, 3, retrieved.Confirmations().Int())
+		})
+
+		t.Run("Stale_Update_Is_Rejected", func(t *testing.T) {
+			db := setupPaymentTestDB(t)
+			repo := database.NewPaymentRepository(db)
+			ctx := context.Background()
+
+			require.NoError(t, repo.Save(ctx, createTestPayment(t)))
+
+			first, err := repo.FindByID(ctx, "test-payment-id")
+			require.NoError(t, err)
+			second, err := repo.FindByID(ctx, "test-payment-id")
+			require.NoError(t, err)
+
+			require.NoError(t, first.UpdateConfirmations(nil, 1))
+			require.NoError(t, repo.Update(ctx, first))
+
+			require.NoError(t, second.UpdateConfirmations(nil, 2))
+			err = repo.Update(ctx, second)
+			require.ErrorIs(t, err, payment.ErrConcurrentModification)
+
+			retrieved, err := repo.FindByID(ctx, "test-payment-id")
+			require.NoError(t, err)
+			require.Equal(t, 1, retrieved.Confirmations().Int())
+		})
+
+		t.Run("Legacy_Row_Starts_Stream_On_Save", func(t *testing.T) {
+			db := setupPaymentTestDB(t)
+			repo := database.NewPaymentRepository(db)
+			ctx := context.Background()
+
+			p := createTestPayment(t)
+			require.NoError(t, repo.Save(ctx, p))
+			require.NoError(t, db.Where("payment_id = ?", p.ID()).Delete(&database.PaymentEventModel{}).Error)
+
+			events, err := repo.FindEvents(ctx, string(p.ID()))
+			require.NoError(t, err)
+			require.Empty(t, events)
+
+			legacy, err := repo.FindByID(ctx, string(p.ID()))
+			require.NoError(t, err)
+			require.NoError(t, legacy.UpdateConfirmations(nil, 2))
+			require.NoError(t, repo.Update(ctx, legacy))
+
+			events, err = repo.FindEvents(ctx, string(p.ID()))
+			require.NoError(t, err)
+			require.NotEmpty(t, events)
+			require.Equal(t, payment.PaymentEventDetected, events[0].Type)
+
+			retrieved, err := repo.FindByID(ctx, string(p.ID()))
+			require.NoError(t, err)
+			require.Equal(t, 2, retrieved.Confirmations().Int())
+		})
+
+		t.Run("Non_Existent_Payment", func(t *testing.T) {
+			db := setupPaymentTestDB(t)
+			repo := database.NewPaymentRepository(db)
+
+			_, err := repo.FindEvents(context.Background(), "non-existent-id")
+			require.ErrorIs(t, err, payment.ErrPaymentNotFound)
+		})
+	})
+
+	t.Run("Snapshots", func(t *testing.T) {
+		saveHistory := func(t *testing.T, repo payment.Repository, events int) *payment.Payment {
+			p := createTestPayment(t)
+			for count := 1; p.Version() < events; count++ {
+				require.NoError(t, p.UpdateConfirmations(nil, count))
+			}
+			require.NoError(t, repo.Save(context.Background(), p))
+			return p
+		}
+
+		t.Run("Taken_Every_Interval", func(t *testing.T) {
+			db := setupPaymentTestDB(t)
+			repo := database.NewPaymentRepository(db)
+			ctx := context.Background()
+
+			p := saveHistory(t, repo, payment.SnapshotInterval-1)
+			snapshots, err := repo.FindSnapshots(ctx, string(p.ID()))
+			require.NoError(t, err)
+			require.Empty(t, snapshots)
+
+			require.NoError(t, p.UpdateConfirmations(nil, 100))
+			require.NoError(t, p.UpdateConfirmations(nil, 101))
+			require.NoError(t, repo.Update(ctx, p))
+
+			snapshots, err = repo.FindSnapshots(ctx, string(p.ID()))
+			require.NoError(t, err)
+			require.Len(t, snapshots, 1)
+			require.Equal(t, payment.SnapshotInterval+1, snapshots[0].Version)
+			require.Equal(t, 101, snapshots[0].State.Confirmations)
+		})
+
+		t.Run("Loading_Starts_From_Latest_Snapshot", func(t *testing.T) {
+			db := setupPaymentTestDB(t)
+			repo := database.NewPaymentRepository(db)
+			ctx := context.Background()
+
+			p := saveHistory(t, repo, payment.SnapshotInterval)
+			require.NoError(t, p.UpdateConfirmations(nil, 500))
+			require.NoError(t, repo.Update(ctx, p))
+
+			// Events covered by the snapshot are not needed to load the payment
+			require.NoError(t, db.Where("payment_id = ? AND version <= ?", p.ID(), payment.SnapshotInterval).
+				Delete(&database.PaymentEventModel{}).Error)
+
+			retrieved, err := repo.FindByID(ctx, string(p.ID()))
+			require.NoError(t, err)
+			require.Equal(t, payment.SnapshotInterval+1, retrieved.Version())
+			require.Equal(t, 500, retrieved.Confirmations().Int())
+
+			pending, err := repo.FindPending(ctx)
+			require.NoError(t, err)
+			require.Len(t, pending, 1)
+			require.Equal(t, 500, pending[0].Confirmations().Int())
+		})
+
+		t.Run("RebuildPayment_Repairs_Snapshots_And_Row", func(t *testing.T) {
+			db := setupPaymentTestDB(t)
+			repo := database.NewPaymentRepository(db)
+			ctx := context.Background()
+
+			p := saveHistory(t, repo, payment.SnapshotInterval)
+			version := payment.SnapshotInterval
+
+			// Tamper with the projection and the snapshot, and leave a snapshot beyond the stream
+			require.NoError(t, db.Model(&database.PaymentModel{}).Where("id = ?", p.ID()).
+				Update("confirmations", 7).Error)
+			var snapshot database.PaymentSnapshotModel
+			require.NoError(t, db.First(&snapshot, "payment_id = ? AND version = ?", p.ID(), version).Error)
+			require.NoError(t, db.Model(&snapshot).
+				Update("state", strings.Replace(snapshot.State, `"status":"detected"`, `"status":"confirmed"`, 1)).Error)
+			beyond := snapshot
+			beyond.Version = version + 10
+			require.NoError(t, db.Create(&beyond).Error)
+
+			result, err := payment.RebuildPayment(ctx, repo, string(p.ID()), true)
+			require.NoError(t, err)
+			require.Len(t, result.Mismatches, 2)
+			require.Equal(t, []string{"status"}, result.Mismatches[0].Fields)
+
+			result, err = payment.RebuildPayment(ctx, repo, string(p.ID()), false)
+			require.NoError(t, err)
+			require.True(t, result.Corrupted())
+
+			result, err = payment.RebuildPayment(ctx, repo, string(p.ID()), true)
+			require.NoError(t, err)
+			require.False(t, result.Corrupted())
+			require.Equal(t, 1, result.Snapshots)
+
+			var model database.PaymentModel
+			require.NoError(t, db.First(&model, "id = ?", p.ID()).Error)
+			require.Equal(t, p.Confirmations().Int(), model.Confirmations)
+		})
+	})
+}
+
+// missedLookups hides recorded payments from the first lookups, as a concurrent save that has not committed yet
+// would be.
+type missedLookups struct {
+	payment.Repository
+	misses atomic.Int32
+}
+
+func (r *missedLookups) FindByTransactionHash(
+	ctx context.Context,
+	network shared.BlockchainNetwork,
+	hash *payment.TransactionHash,
+) (*payment.Payment, error) {
+	if r.misses.Add(-1) >= 0 {
+		return nil, payment.ErrPaymentNotFound
+	}
+	return r.Repository.FindByTransactionHash(ctx, network, hash)
+}
+
+func TestPaymentService_CreatePaymentIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	const txHash = "0xabcabcabcabcabcabcabcabcabcabcabcabcabcabcabcabcabcabcabcabcabca"
+
+	request := func(t *testing.T, id, invoiceID string) *payment.CreatePaymentRequest {
+		p := createTestPaymentWithID(t, id, txHash)
+		return &payment.CreatePaymentRequest{
+			ID:                    p.ID(),
+			InvoiceID:             shared.InvoiceID(invoiceID),
+			Amount:                p.Amount(),
+			FromAddress:           p.FromAddress(),
+			ToAddress:             p.ToAddress(),
+			TransactionHash:       p.TransactionHash(),
+			RequiredConfirmations: p.RequiredConfirmations(),
+		}
+	}
+
+	t.Run("returns the recorded payment", func(t *testing.T) {
+		service := payment.NewPaymentService(database.NewPaymentRepository(setupPaymentTestDB(t)), nil, nil, nil,
+			zap.NewNop())
+
+		created, err := service.CreatePayment(ctx, request(t, "payment-1", "invoice-1"))
+		require.NoError(t, err)
+		again, err := service.CreatePayment(ctx, request(t, "payment-2", "invoice-1"))
+		require.NoError(t, err)
+		require.Equal(t, created.ID(), again.ID())
+
+		// The same transaction cannot pay another invoice
+		_, err = service.CreatePayment(ctx, request(t, "payment-3", "invoice-2"))
+		var paymentErr *payment.PaymentError
+		require.ErrorAs(t, err, &paymentErr)
+		require.Equal(t, payment.ErrCodePaymentAlreadyExists, paymentErr.Code)
+	})
+
+	t.Run("returns the payment saved by a concurrent source", func(t *testing.T) {
+		repo := database.NewPaymentRepository(setupPaymentTestDB(t))
+		recorded := createTestPaymentWithID(t, "payment-1", txHash)
+		require.NoError(t, repo.Save(ctx, recorded))
+
+		// The lookup before saving misses the payment, so the save runs into the unique constraint
+		lookups := &missedLookups{Repository: repo}
+		lookups.misses.Store(1)
+		service := payment.NewPaymentService(lookups, nil, nil, nil, zap.NewNop())
+
+		p, err := service.CreatePayment(ctx, request(t, "payment-2", string(recorded.InvoiceID())))
+		require.NoError(t, err)
+		require.Equal(t, recorded.ID(), p.ID())
+		exists, err := repo.Exists(ctx, "payment-2")
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+
+	t.Run("concurrent sources record one payment", func(t *testing.T) {
+		db := setupPaymentTestDB(t)
+		// Every connection to an in-memory database opens a database of its own
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		sqlDB.SetMaxOpenConns(1)
+		service := payment.NewPaymentService(database.NewPaymentRepository(db), nil, nil, nil, zap.NewNop())
+
+		const sources = 8
+		ids := make([]shared.PaymentID, sources)
+		var wg sync.WaitGroup
+		for i := range sources {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				p, err := service.CreatePayment(ctx, request(t, fmt.Sprintf("payment-%d", i), "invoice-1"))
+				if assert.NoError(t, err) {
+					ids[i] = p.ID()
+				}
+			}()
+		}
+		wg.Wait()
+
+		for _, id := range ids {
+			require.Equal(t, ids[0], id)
+		}
+		var count int64
+		require.NoError(t, db.Model(&database.PaymentModel{}).Count(&count).Error)
+		require.Equal(t, int64(1), count)
+	})
 }
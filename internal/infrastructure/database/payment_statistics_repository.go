@@ -0,0 +1,122 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PaymentStatisticsRepository implements the payment.StatisticsRepository interface using GORM.
+type PaymentStatisticsRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewPaymentStatisticsRepository creates a new payment statistics repository.
+func NewPaymentStatisticsRepository(db *gorm.DB, logger *zap.Logger) payment.StatisticsRepository {
+	return &PaymentStatisticsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// AddRollup adds the count and volume of a rollup to the stored rollup of the same hour, status and currency.
+func (r *PaymentStatisticsRepository) AddRollup(ctx context.Context, rollup *payment.StatusRollup) error {
+	model := &PaymentStatisticsModel{
+		HourStart: rollup.HourStart.UTC(),
+		Status:    rollup.Status.String(),
+		Currency:  rollup.Currency.String(),
+		Count:     rollup.Count,
+		Volume:    rollup.Volume.String(),
+	}
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "hour_start"}, {Name: "status"}, {Name: "currency"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"count":  gorm.Expr("payment_statistics.count + excluded.count"),
+			"volume": gorm.Expr("payment_statistics.volume + excluded.volume"),
+		}),
+	}).Create(model).Error
+	if err != nil {
+		return fmt.Errorf("failed to add payment statistics: %w", err)
+	}
+
+	return nil
+}
+
+// RecordConfirmation records the confirmation latency of a payment, ignoring a payment already recorded.
+func (r *PaymentStatisticsRepository) RecordConfirmation(
+	ctx context.Context,
+	latency *payment.ConfirmationLatency,
+) error {
+	model := &PaymentConfirmationModel{
+		PaymentID:      string(latency.PaymentID),
+		ConfirmedAt:    latency.ConfirmedAt.UTC(),
+		LatencySeconds: latency.Latency.Seconds(),
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to record payment confirmation: %w", err)
+	}
+
+	return nil
+}
+
+// ListRollups lists the rollups of the hours in [from, to), oldest first.
+func (r *PaymentStatisticsRepository) ListRollups(
+	ctx context.Context,
+	from, to time.Time,
+) ([]*payment.StatusRollup, error) {
+	var models []PaymentStatisticsModel
+	if err := r.db.WithContext(ctx).
+		Where("hour_start >= ? AND hour_start < ?", from.UTC(), to.UTC()).
+		Order("hour_start ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list payment statistics: %w", err)
+	}
+
+	rollups := make([]*payment.StatusRollup, len(models))
+	for i, model := range models {
+		volume, err := decimal.NewFromString(model.Volume)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payment statistics volume %q: %w", model.Volume, err)
+		}
+		rollups[i] = &payment.StatusRollup{
+			HourStart: model.HourStart.UTC(),
+			Status:    payment.PaymentStatus(model.Status),
+			Currency:  shared.CryptoCurrency(model.Currency),
+			Count:     model.Count,
+			Volume:    volume,
+		}
+	}
+	return rollups, nil
+}
+
+// ListConfirmations lists the confirmations made in [from, to), oldest first.
+func (r *PaymentStatisticsRepository) ListConfirmations(
+	ctx context.Context,
+	from, to time.Time,
+) ([]*payment.ConfirmationLatency, error) {
+	var models []PaymentConfirmationModel
+	if err := r.db.WithContext(ctx).
+		Where("confirmed_at >= ? AND confirmed_at < ?", from.UTC(), to.UTC()).
+		Order("confirmed_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list payment confirmations: %w", err)
+	}
+
+	latencies := make([]*payment.ConfirmationLatency, len(models))
+	for i, model := range models {
+		latencies[i] = &payment.ConfirmationLatency{
+			PaymentID:   shared.PaymentID(model.PaymentID),
+			ConfirmedAt: model.ConfirmedAt.UTC(),
+			Latency:     time.Duration(model.LatencySeconds * float64(time.Second)),
+		}
+	}
+	return latencies, nil
+}
@@ -0,0 +1,102 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPaymentStatisticsRepository_AddRollupAccumulates(t *testing.T) {
+	db := setupPaymentTestDB(t)
+	repo := database.NewPaymentStatisticsRepository(db, zap.NewNop())
+	ctx := context.Background()
+	hour := time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC)
+
+	for _, volume := range []string{"100.5", "20.25"} {
+		require.NoError(t, repo.AddRollup(ctx, &payment.StatusRollup{
+			HourStart: hour,
+			Status:    payment.StatusConfirmed,
+			Currency:  shared.CryptoCurrencyUSDT,
+			Count:     1,
+			Volume:    decimal.RequireFromString(volume),
+		}))
+	}
+	require.NoError(t, repo.AddRollup(ctx, &payment.StatusRollup{
+		HourStart: hour.Add(time.Hour),
+		Status:    payment.StatusConfirmed,
+		Currency:  shared.CryptoCurrencyUSDT,
+		Count:     1,
+		Volume:    decimal.NewFromInt(1),
+	}))
+
+	rollups, err := repo.ListRollups(ctx, hour, hour.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, rollups, 1)
+	assert.Equal(t, hour, rollups[0].HourStart)
+	assert.Equal(t, 2, rollups[0].Count)
+	assert.True(t, decimal.RequireFromString("120.75").Equal(rollups[0].Volume), rollups[0].Volume.String())
+}
+
+func TestPaymentStatisticsRepository_RecordConfirmationIgnoresRepeats(t *testing.T) {
+	db := setupPaymentTestDB(t)
+	repo := database.NewPaymentStatisticsRepository(db, zap.NewNop())
+	ctx := context.Background()
+	confirmedAt := time.Date(2026, 3, 2, 14, 5, 0, 0, time.UTC)
+
+	for _, latency := range []time.Duration{90 * time.Second, time.Hour} {
+		require.NoError(t, repo.RecordConfirmation(ctx, &payment.ConfirmationLatency{
+			PaymentID:   "test-payment-id",
+			ConfirmedAt: confirmedAt,
+			Latency:     latency,
+		}))
+	}
+
+	confirmations, err := repo.ListConfirmations(ctx, confirmedAt.Add(-time.Hour), confirmedAt.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, confirmations, 1)
+	assert.Equal(t, 90*time.Second, confirmations[0].Latency)
+}
+
+func TestPaymentStatisticsHandler_FeedsRollup(t *testing.T) {
+	db := setupPaymentTestDB(t)
+	payments := database.NewPaymentRepository(db)
+	statistics := database.NewPaymentStatisticsRepository(db, zap.NewNop())
+	handler := payment.NewStatisticsHandler(payments, statistics, zap.NewNop())
+	ctx := context.Background()
+
+	p := createTestPayment(t)
+	require.NoError(t, payments.Save(ctx, p))
+
+	detected := shared.CreateDomainEvent(shared.EventTypePaymentDetected, string(p.ID()), "Payment",
+		map[string]interface{}{"status": payment.StatusDetected.String()}, nil)
+	require.NoError(t, handler.HandleEvent(ctx, detected))
+
+	confirmed := shared.CreateDomainEvent(shared.EventTypePaymentStatusChanged, string(p.ID()), "Payment",
+		map[string]interface{}{"status": payment.StatusConfirmed.String()}, nil)
+	require.NoError(t, handler.HandleEvent(ctx, confirmed))
+
+	series, err := payment.NewStatisticsService(statistics, zap.NewNop()).GetStatisticsSeries(ctx,
+		&payment.StatisticsRequest{Interval: payment.IntervalHour})
+	require.NoError(t, err)
+
+	counts := make(map[payment.PaymentStatus]int)
+	for _, stats := range series.Summary.Statuses {
+		counts[stats.Status] = stats.Count
+		if stats.Status == payment.StatusConfirmed {
+			require.Len(t, stats.Volumes, 1)
+			assert.Equal(t, "100", stats.Volumes[0].Amount.String())
+		}
+	}
+	assert.Equal(t, 1, counts[payment.StatusDetected])
+	assert.Equal(t, 1, counts[payment.StatusConfirmed])
+	assert.Zero(t, counts[payment.StatusConfirming])
+	assert.NotNil(t, series.Summary.MedianConfirmationTime)
+}
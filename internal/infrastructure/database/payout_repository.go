@@ -0,0 +1,177 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// PayoutRepository implements the payout.Repository interface using GORM.
+type PayoutRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewPayoutRepository creates a new payout repository.
+func NewPayoutRepository(db *gorm.DB, logger *zap.Logger) payout.Repository {
+	return &PayoutRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a payout to the database.
+func (r *PayoutRepository) Save(ctx context.Context, p *payout.Payout) error {
+	model, err := r.toModel(p)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save payout: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a payout by ID.
+func (r *PayoutRepository) FindByID(ctx context.Context, id string) (*payout.Payout, error) {
+	var model PayoutModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, payout.ErrPayoutNotFound
+		}
+		return nil, fmt.Errorf("failed to find payout: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// FindByStatus finds up to limit payouts with the status, oldest first.
+func (r *PayoutRepository) FindByStatus(ctx context.Context, status payout.Status, limit int) ([]*payout.Payout, error) {
+	var models []PayoutModel
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", string(status)).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find payouts: %w", err)
+	}
+
+	return r.toDomainList(models)
+}
+
+// List retrieves payouts matching the filter, newest first.
+func (r *PayoutRepository) List(ctx context.Context, req *payout.ListPayoutsRequest) (*payout.ListPayoutsResponse, error) {
+	query := r.db.WithContext(ctx).Where("merchant_id = ?", req.MerchantID)
+	if req.Status != "" {
+		query = query.Where("status = ?", string(req.Status))
+	}
+
+	var models []PayoutModel
+	if err := query.Scopes(keysetPage("created_at", req.Cursor, 0, req.Limit)).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list payouts: %w", err)
+	}
+	models, next := nextPage(models, req.Limit, func(model *PayoutModel) (time.Time, string) {
+		return model.CreatedAt, model.ID
+	})
+
+	payouts, err := r.toDomainList(models)
+	if err != nil {
+		return nil, err
+	}
+
+	return &payout.ListPayoutsResponse{
+		Payouts:    payouts,
+		Limit:      req.Limit,
+		NextCursor: next,
+	}, nil
+}
+
+// Update updates an existing payout.
+func (r *PayoutRepository) Update(ctx context.Context, p *payout.Payout) error {
+	model, err := r.toModel(p)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to update payout: %w", err)
+	}
+
+	return nil
+}
+
+// toDomainList converts database models to domain payouts.
+func (r *PayoutRepository) toDomainList(models []PayoutModel) ([]*payout.Payout, error) {
+	payouts := make([]*payout.Payout, len(models))
+	for i := range models {
+		p, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert payout model to domain: %w", err)
+		}
+		payouts[i] = p
+	}
+	return payouts, nil
+}
+
+// toModel converts a domain payout to a database model.
+func (r *PayoutRepository) toModel(p *payout.Payout) (*PayoutModel, error) {
+	settlementIDs, err := json.Marshal(p.SettlementIDs())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payout settlements: %w", err)
+	}
+
+	return &PayoutModel{
+		ID:            p.ID(),
+		MerchantID:    p.MerchantID(),
+		WalletID:      p.WalletID(),
+		Network:       p.Network().String(),
+		Address:       p.Address(),
+		Currency:      p.Currency(),
+		Amount:        p.Amount().String(),
+		SettlementIDs: string(settlementIDs),
+		Status:        string(p.Status()),
+		TxHash:        p.TxHash(),
+		FailureReason: p.FailureReason(),
+		CreatedAt:     p.CreatedAt(),
+		BroadcastAt:   p.BroadcastAt(),
+		ConfirmedAt:   p.ConfirmedAt(),
+	}, nil
+}
+
+// toDomain converts a database model to a domain payout.
+func (r *PayoutRepository) toDomain(model *PayoutModel) (*payout.Payout, error) {
+	amount, err := decimal.NewFromString(model.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payout amount %q: %w", model.Amount, err)
+	}
+
+	var settlementIDs []string
+	if err := json.Unmarshal([]byte(model.SettlementIDs), &settlementIDs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payout settlements: %w", err)
+	}
+
+	return payout.RestorePayout(
+		model.ID,
+		model.MerchantID,
+		model.WalletID,
+		shared.BlockchainNetwork(model.Network),
+		model.Address,
+		model.Currency,
+		amount,
+		settlementIDs,
+		payout.Status(model.Status),
+		model.TxHash,
+		model.FailureReason,
+		model.CreatedAt,
+		model.BroadcastAt,
+		model.ConfirmedAt,
+	)
+}
@@ -0,0 +1,117 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPayoutWalletRepository_Lifecycle(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewPayoutWalletRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	wallet, err := payout.NewWallet("wallet-1", "merchant-1", shared.NetworkTron, "TXYZabc123456789payout", time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, wallet))
+
+	found, err := repo.FindByMerchantAndNetwork(ctx, "merchant-1", shared.NetworkTron)
+	require.NoError(t, err)
+	assert.Equal(t, wallet.Challenge(), found.Challenge())
+	assert.False(t, found.IsVerified())
+
+	_, err = repo.FindByMerchantAndNetwork(ctx, "merchant-1", shared.NetworkEthereum)
+	require.ErrorIs(t, err, payout.ErrWalletNotFound)
+
+	require.NoError(t, found.Verify(time.Now().UTC()))
+	require.NoError(t, repo.Update(ctx, found))
+
+	wallets, err := repo.ListByMerchant(ctx, "merchant-1")
+	require.NoError(t, err)
+	require.Len(t, wallets, 1)
+	assert.True(t, wallets[0].IsVerified())
+
+	require.NoError(t, repo.Delete(ctx, "wallet-1"))
+	require.ErrorIs(t, repo.Delete(ctx, "wallet-1"), payout.ErrWalletNotFound)
+}
+
+func TestPayoutRepository_AssignAndList(t *testing.T) {
+	db := setupTestDB(t)
+	settlements := database.NewSettlementRepository(db, zap.NewNop())
+	repo := database.NewPayoutRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	for _, id := range []string{"11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222"} {
+		s, err := settlement.NewSettlement(id, "invoice-"+id, "merchant-1", decimal.NewFromInt(20), "USDT", decimal.Zero)
+		require.NoError(t, err)
+		require.NoError(t, s.Complete())
+		require.NoError(t, settlements.Save(ctx, s))
+	}
+
+	awaiting, err := settlements.FindAwaitingPayout(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, awaiting, 2)
+	ids := []string{awaiting[0].ID(), awaiting[1].ID()}
+
+	wallet, err := payout.NewWallet("wallet-1", "merchant-1", shared.NetworkTron, "TXYZabc123456789payout", time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, wallet.Verify(time.Now().UTC()))
+
+	p, err := payout.NewPayout("33333333-3333-3333-3333-333333333333", wallet, "USDT", decimal.NewFromInt(40), ids)
+	require.NoError(t, err)
+
+	assigned, err := settlements.AssignPayout(ctx, p.ID(), ids)
+	require.NoError(t, err)
+	assert.Equal(t, 2, assigned)
+	require.NoError(t, repo.Save(ctx, p))
+
+	t.Run("assigned settlements no longer await payout", func(t *testing.T) {
+		awaiting, err := settlements.FindAwaitingPayout(ctx, 10)
+		require.NoError(t, err)
+		assert.Empty(t, awaiting)
+
+		assigned, err := settlements.AssignPayout(ctx, "44444444-4444-4444-4444-444444444444", ids)
+		require.NoError(t, err)
+		assert.Zero(t, assigned)
+
+		s, err := settlements.FindByID(ctx, ids[0])
+		require.NoError(t, err)
+		assert.Equal(t, p.ID(), s.PayoutID())
+	})
+
+	t.Run("payout round trip", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, p.ID())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, ids, found.SettlementIDs())
+		assert.Equal(t, "40", found.Amount().String())
+
+		require.NoError(t, found.Fail("rejected"))
+		require.NoError(t, repo.Update(ctx, found))
+
+		failed, err := repo.FindByStatus(ctx, payout.StatusFailed, 10)
+		require.NoError(t, err)
+		require.Len(t, failed, 1)
+
+		resp, err := repo.List(ctx, &payout.ListPayoutsRequest{MerchantID: "merchant-1", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, resp.Payouts, 1)
+		assert.Nil(t, resp.NextCursor)
+	})
+
+	t.Run("released settlements await payout again", func(t *testing.T) {
+		require.NoError(t, settlements.ReleasePayout(ctx, p.ID()))
+
+		awaiting, err := settlements.FindAwaitingPayout(ctx, 10)
+		require.NoError(t, err)
+		assert.Len(t, awaiting, 2)
+	})
+}
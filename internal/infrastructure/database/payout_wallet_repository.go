@@ -0,0 +1,135 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// PayoutWalletRepository implements the payout.WalletRepository interface using GORM.
+type PayoutWalletRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewPayoutWalletRepository creates a new payout wallet repository.
+func NewPayoutWalletRepository(db *gorm.DB, logger *zap.Logger) payout.WalletRepository {
+	return &PayoutWalletRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a payout wallet to the database.
+func (r *PayoutWalletRepository) Save(ctx context.Context, wallet *payout.Wallet) error {
+	if err := r.db.WithContext(ctx).Create(r.toModel(wallet)).Error; err != nil {
+		return fmt.Errorf("failed to save payout wallet: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a payout wallet by ID.
+func (r *PayoutWalletRepository) FindByID(ctx context.Context, id string) (*payout.Wallet, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+// FindByMerchantAndNetwork finds a merchant's payout wallet on a network.
+func (r *PayoutWalletRepository) FindByMerchantAndNetwork(
+	ctx context.Context,
+	merchantID string,
+	network shared.BlockchainNetwork,
+) (*payout.Wallet, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("merchant_id = ? AND network = ?", merchantID, network.String()))
+}
+
+// ListByMerchant lists a merchant's payout wallets, oldest first.
+func (r *PayoutWalletRepository) ListByMerchant(ctx context.Context, merchantID string) ([]*payout.Wallet, error) {
+	var models []PayoutWalletModel
+	if err := r.db.WithContext(ctx).
+		Where("merchant_id = ?", merchantID).
+		Order("created_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list payout wallets: %w", err)
+	}
+
+	wallets := make([]*payout.Wallet, len(models))
+	for i := range models {
+		wallet, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert payout wallet model to domain: %w", err)
+		}
+		wallets[i] = wallet
+	}
+	return wallets, nil
+}
+
+// Update updates an existing payout wallet.
+func (r *PayoutWalletRepository) Update(ctx context.Context, wallet *payout.Wallet) error {
+	if err := r.db.WithContext(ctx).Save(r.toModel(wallet)).Error; err != nil {
+		return fmt.Errorf("failed to update payout wallet: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a payout wallet.
+func (r *PayoutWalletRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&PayoutWalletModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete payout wallet: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return payout.ErrWalletNotFound
+	}
+
+	return nil
+}
+
+// findOne finds a single payout wallet matching the query.
+func (r *PayoutWalletRepository) findOne(query *gorm.DB) (*payout.Wallet, error) {
+	var model PayoutWalletModel
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, payout.ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to find payout wallet: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// toModel converts a domain payout wallet to a database model.
+func (r *PayoutWalletRepository) toModel(wallet *payout.Wallet) *PayoutWalletModel {
+	return &PayoutWalletModel{
+		ID:                 wallet.ID(),
+		MerchantID:         wallet.MerchantID(),
+		Network:            wallet.Network().String(),
+		Address:            wallet.Address(),
+		Status:             string(wallet.Status()),
+		Challenge:          wallet.Challenge(),
+		ChallengeExpiresAt: wallet.ChallengeExpiresAt(),
+		VerifiedAt:         wallet.VerifiedAt(),
+		CreatedAt:          wallet.CreatedAt(),
+	}
+}
+
+// toDomain converts a database model to a domain payout wallet.
+func (r *PayoutWalletRepository) toDomain(model *PayoutWalletModel) (*payout.Wallet, error) {
+	return payout.RestoreWallet(
+		model.ID,
+		model.MerchantID,
+		shared.BlockchainNetwork(model.Network),
+		model.Address,
+		payout.WalletStatus(model.Status),
+		model.Challenge,
+		model.ChallengeExpiresAt,
+		model.VerifiedAt,
+		model.CreatedAt,
+	)
+}
@@ -0,0 +1,277 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/approval"
+	"crypto-checkout/internal/domain/deadletter"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/platform"
+	"crypto-checkout/internal/domain/review"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/webhook"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// PlatformRepository implements the platform.Repository interface using GORM. It counts across merchants,
+// so it is never scoped to one, and keeps the platform's incidents.
+type PlatformRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewPlatformRepository creates a new platform repository.
+func NewPlatformRepository(db *gorm.DB, logger *zap.Logger) platform.Repository {
+	return &PlatformRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CountQueues counts the work waiting in each queue as of now.
+func (r *PlatformRepository) CountQueues(ctx context.Context, now time.Time) (*platform.Queues, error) {
+	db := r.db.WithContext(ctx)
+	queues := &platform.Queues{DeadLetters: map[string]int{}}
+
+	// The invoices FindExpired hands to the expiration sweep, less the partially paid ones it leaves active until
+	// their merchant's grace period passes
+	var expired int64
+	if err := db.Model(&InvoiceModel{}).
+		Where("status IN ? AND expires_at < ?", []string{
+			invoice.StatusCreated.String(),
+			invoice.StatusPending.String(),
+			invoice.StatusConfirming.String(),
+		}, now).
+		Count(&expired).Error; err != nil {
+		return nil, fmt.Errorf("failed to count expired invoices: %w", err)
+	}
+	queues.ExpiredInvoices = int(expired)
+
+	var deadLetters []struct {
+		Handler string
+		Count   int
+	}
+	if err := db.Model(&DeadLetterModel{}).
+		Select("handler, COUNT(*) AS count").
+		Where("status = ?", string(deadletter.StatusPending)).
+		Group("handler").
+		Scan(&deadLetters).Error; err != nil {
+		return nil, fmt.Errorf("failed to count dead letters: %w", err)
+	}
+	for _, row := range deadLetters {
+		queues.DeadLetters[row.Handler] = row.Count
+	}
+
+	counts := []struct {
+		model  interface{}
+		status string
+		target *int
+	}{
+		{&PaymentReviewModel{}, string(review.StatusOpen), &queues.OpenReviews},
+		{&ApprovalModel{}, string(approval.StatusPending), &queues.PendingApprovals},
+		{&PayoutModel{}, string(payout.StatusPending), &queues.PendingPayouts},
+	}
+	for _, count := range counts {
+		var n int64
+		if err := db.Model(count.model).Where("status = ?", count.status).Count(&n).Error; err != nil {
+			return nil, fmt.Errorf("failed to count %T: %w", count.model, err)
+		}
+		*count.target = int(n)
+	}
+
+	return queues, nil
+}
+
+// CountStatistics counts the invoicing merchants, and the invoices and payments by status.
+func (r *PlatformRepository) CountStatistics(ctx context.Context) (*platform.Statistics, error) {
+	db := r.db.WithContext(ctx)
+	var merchants int64
+	if err := db.Model(&InvoiceModel{}).Distinct("merchant_id").Count(&merchants).Error; err != nil {
+		return nil, fmt.Errorf("failed to count merchants: %w", err)
+	}
+	invoices, err := countByStatus(db, &InvoiceModel{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count invoices: %w", err)
+	}
+	payments, err := countByStatus(db, &PaymentModel{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count payments: %w", err)
+	}
+
+	return &platform.Statistics{
+		Merchants: int(merchants),
+		Invoices:  invoices,
+		Payments:  payments,
+	}, nil
+}
+
+// countByStatus counts the records of a model by their status column.
+func countByStatus(db *gorm.DB, model interface{}) (map[string]int, error) {
+	var rows []struct {
+		Status string
+		Count  int
+	}
+	if err := db.Model(model).Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// CountWebhookDeliveries counts the webhooks sent to merchants' endpoints since the given time, and the endpoints
+// whose latest delivery since then failed.
+func (r *PlatformRepository) CountWebhookDeliveries(
+	ctx context.Context,
+	since time.Time,
+) (*platform.WebhookActivity, error) {
+	db := r.db.WithContext(ctx)
+	activity := &platform.WebhookActivity{}
+
+	var rows []struct {
+		Status string
+		Count  int
+	}
+	if err := db.Model(&WebhookDeliveryModel{}).
+		Select("status, COUNT(*) AS count").
+		Where("created_at >= ?", since).
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+	for _, row := range rows {
+		activity.Sent += row.Count
+		if row.Status == string(webhook.DeliveryFailed) {
+			activity.Failed += row.Count
+		}
+	}
+
+	var failing int64
+	if err := db.Model(&WebhookDeliveryModel{}).
+		Where("created_at >= ? AND status = ?", since, string(webhook.DeliveryFailed)).
+		Where("created_at = (SELECT MAX(latest.created_at) FROM webhook_deliveries latest " +
+			"WHERE latest.endpoint_id = webhook_deliveries.endpoint_id)").
+		Distinct("endpoint_id").
+		Count(&failing).Error; err != nil {
+		return nil, fmt.Errorf("failed to count failing webhook endpoints: %w", err)
+	}
+	activity.FailingEndpoints = int(failing)
+
+	return activity, nil
+}
+
+// SaveIncident saves an incident to the database.
+func (r *PlatformRepository) SaveIncident(ctx context.Context, incident *platform.Incident) error {
+	model, err := toIncidentModel(incident)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save incident: %w", err)
+	}
+
+	return nil
+}
+
+// FindIncidentByID finds an incident by ID.
+func (r *PlatformRepository) FindIncidentByID(ctx context.Context, id string) (*platform.Incident, error) {
+	var model IncidentModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, platform.ErrIncidentNotFound
+		}
+		return nil, fmt.Errorf("failed to find incident: %w", err)
+	}
+
+	return toIncidentDomain(&model)
+}
+
+// UpdateIncident updates an existing incident.
+func (r *PlatformRepository) UpdateIncident(ctx context.Context, incident *platform.Incident) error {
+	model, err := toIncidentModel(incident)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	return nil
+}
+
+// ListIncidents retrieves the latest incidents, newest first, or only the active ones.
+func (r *PlatformRepository) ListIncidents(
+	ctx context.Context,
+	activeOnly bool,
+	limit int,
+) ([]*platform.Incident, error) {
+	query := r.db.WithContext(ctx).Order("started_at DESC").Order("id DESC").Limit(limit)
+	if activeOnly {
+		query = query.Where("resolved_at IS NULL")
+	}
+
+	var models []IncidentModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list incidents: %w", err)
+	}
+
+	incidents := make([]*platform.Incident, len(models))
+	for i := range models {
+		incident, err := toIncidentDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert incident model to domain: %w", err)
+		}
+		incidents[i] = incident
+	}
+	return incidents, nil
+}
+
+// toIncidentModel converts a domain incident to its database model.
+func toIncidentModel(incident *platform.Incident) (*IncidentModel, error) {
+	networks := incident.Networks()
+	if networks == nil {
+		networks = []shared.BlockchainNetwork{}
+	}
+	networksJSON, err := json.Marshal(networks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal incident networks: %w", err)
+	}
+
+	return &IncidentModel{
+		ID:         incident.ID(),
+		Title:      incident.Title(),
+		Message:    incident.Message(),
+		Severity:   string(incident.Severity()),
+		Networks:   string(networksJSON),
+		CreatedBy:  incident.CreatedBy(),
+		StartedAt:  incident.StartedAt(),
+		ResolvedAt: incident.ResolvedAt(),
+	}, nil
+}
+
+// toIncidentDomain converts a database model to a domain incident.
+func toIncidentDomain(model *IncidentModel) (*platform.Incident, error) {
+	var networks []shared.BlockchainNetwork
+	if err := json.Unmarshal([]byte(model.Networks), &networks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal incident networks: %w", err)
+	}
+
+	return platform.RestoreIncident(
+		model.ID,
+		model.Title,
+		model.Message,
+		platform.Severity(model.Severity),
+		networks,
+		model.CreatedBy,
+		model.StartedAt,
+		model.ResolvedAt,
+	)
+}
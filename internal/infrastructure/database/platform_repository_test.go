@@ -0,0 +1,225 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/platform"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPlatformRepository_CountsAcrossMerchants(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewPlatformRepository(db, zap.NewNop())
+	ctx := context.Background()
+	now := time.Now().UTC()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	invoices := []struct {
+		merchantID string
+		status     string
+		expiresAt  *time.Time
+	}{
+		{"11111111-1111-1111-1111-111111111111", "pending", &past},
+		{"11111111-1111-1111-1111-111111111111", "pending", &future},
+		{"22222222-2222-2222-2222-222222222222", "partial", &past},
+		{"22222222-2222-2222-2222-222222222222", "paid", &past},
+	}
+	for i, inv := range invoices {
+		require.NoError(t, db.Create(&database.InvoiceModel{
+			ID:             fmt.Sprintf("platform-invoice-%d", i),
+			MerchantID:     inv.merchantID,
+			Title:          "Invoice",
+			Subtotal:       "10",
+			Total:          "10",
+			Currency:       "USD",
+			CryptoCurrency: "USDT",
+			CryptoAmount:   "10",
+			Status:         inv.status,
+			ExpiresAt:      inv.expiresAt,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}).Error)
+	}
+	require.NoError(t, db.Create(&database.PaymentModel{
+		ID:          "platform-payment",
+		InvoiceID:   "platform-invoice-3",
+		TxHash:      "platform-tx",
+		Amount:      "10",
+		FromAddress: "TSenderAddress",
+		ToAddress:   "TTestAddress",
+		Status:      "confirmed",
+		DetectedAt:  now,
+		CreatedAt:   now,
+	}).Error)
+	for i, status := range []string{"pending", "pending", "replayed"} {
+		require.NoError(t, db.Create(&database.DeadLetterModel{
+			ID:           fmt.Sprintf("33333333-3333-3333-3333-33333333333%d", i),
+			Handler:      "*settlement.PaidInvoiceHandler",
+			EventType:    "invoice.paid",
+			AggregateID:  "platform-invoice-3",
+			Event:        "{}",
+			LastError:    "connection refused",
+			Attempts:     1,
+			Status:       status,
+			CreatedAt:    now,
+			LastFailedAt: now,
+		}).Error)
+	}
+
+	queues, err := repo.CountQueues(ctx, now)
+	require.NoError(t, err)
+	// Partially paid invoices are not expired by the sweep
+	assert.Equal(t, 1, queues.ExpiredInvoices)
+	assert.Equal(t, map[string]int{"*settlement.PaidInvoiceHandler": 2}, queues.DeadLetters)
+	assert.Zero(t, queues.OpenReviews)
+	assert.Zero(t, queues.PendingApprovals)
+	assert.Zero(t, queues.PendingPayouts)
+
+	statistics, err := repo.CountStatistics(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, statistics.Merchants)
+	assert.Equal(t, map[string]int{"pending": 2, "partial": 1, "paid": 1}, statistics.Invoices)
+	assert.Equal(t, map[string]int{"confirmed": 1}, statistics.Payments)
+}
+
+// stubChainWatcher reports fixed watcher statuses.
+type stubChainWatcher struct {
+	statuses []detection.WatcherStatus
+}
+
+func (w *stubChainWatcher) Enabled() bool { return true }
+
+func (w *stubChainWatcher) MaxLag() int64 { return 50 }
+
+func (w *stubChainWatcher) Networks() []shared.BlockchainNetwork {
+	return []shared.BlockchainNetwork{shared.NetworkEthereum, shared.NetworkTron, shared.NetworkBitcoin}
+}
+
+func (w *stubChainWatcher) Status() []detection.WatcherStatus { return w.statuses }
+
+// stubRateMonitor reports fixed quotes.
+type stubRateMonitor struct {
+	quotes []platform.RateQuote
+}
+
+func (m *stubRateMonitor) Quotes() []platform.RateQuote { return m.quotes }
+
+func TestPlatformRepository_CountWebhookDeliveries(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewPlatformRepository(db, zap.NewNop())
+	now := time.Now().UTC()
+
+	deliveries := []struct {
+		endpointID string
+		status     string
+		createdAt  time.Time
+	}{
+		// Failed, then recovered
+		{"endpoint-1", "failed", now.Add(-30 * time.Minute)},
+		{"endpoint-1", "succeeded", now.Add(-10 * time.Minute)},
+		// Failing still
+		{"endpoint-2", "succeeded", now.Add(-20 * time.Minute)},
+		{"endpoint-2", "failed", now.Add(-5 * time.Minute)},
+		// Outside the window
+		{"endpoint-3", "failed", now.Add(-2 * time.Hour)},
+	}
+	for i, d := range deliveries {
+		require.NoError(t, db.Create(&database.WebhookDeliveryModel{
+			ID:         fmt.Sprintf("platform-delivery-%d", i),
+			MerchantID: "11111111-1111-1111-1111-111111111111",
+			EndpointID: d.endpointID,
+			EventID:    fmt.Sprintf("event-%d", i),
+			EventType:  "invoice.paid",
+			Kind:       "event",
+			URL:        "https://merchant.example/webhooks",
+			Payload:    "{}",
+			Status:     d.status,
+			CreatedAt:  d.createdAt,
+		}).Error)
+	}
+
+	activity, err := repo.CountWebhookDeliveries(context.Background(), now.Add(-platform.WebhookWindow))
+	require.NoError(t, err)
+	assert.Equal(t, 4, activity.Sent)
+	assert.Equal(t, 2, activity.Failed)
+	assert.Equal(t, 1, activity.FailingEndpoints)
+}
+
+func TestPlatformService_Incidents(t *testing.T) {
+	db := setupTestDB(t)
+	watcher := &stubChainWatcher{statuses: []detection.WatcherStatus{
+		{Network: shared.NetworkEthereum, Head: 1000, Cursor: 990, Lag: 10, CheckedAt: time.Now()},
+		{Network: shared.NetworkTron, Head: 5000, Cursor: 4000, Lag: 1000, CheckedAt: time.Now()},
+	}}
+	rates := &stubRateMonitor{}
+	service := platform.NewService(database.NewPlatformRepository(db, zap.NewNop()), watcher, rates, zap.NewNop())
+	ctx := context.Background()
+
+	status, err := service.GetStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, platform.ConditionDegraded, status.Condition)
+	require.Len(t, status.Networks, 3)
+	assert.Equal(t, platform.ConditionOperational, status.Networks[0].Condition)
+	assert.Equal(t, platform.ConditionDegraded, status.Networks[1].Condition, "lag beyond the maximum")
+	assert.Equal(t, shared.NetworkBitcoin, status.Networks[2].Network, "networks not checked yet are listed")
+	assert.True(t, status.Networks[2].CheckedAt.IsZero())
+	assert.Equal(t, platform.WebhookWindow, status.Webhooks.Window)
+
+	watcher.statuses[1].Lag = 5
+	status, err = service.GetStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, platform.ConditionOperational, status.Condition)
+
+	failedAt := time.Now()
+	rates.quotes = []platform.RateQuote{{From: shared.CurrencyUSD, To: shared.CryptoCurrencyUSDT, FailedAt: &failedAt}}
+	status, err = service.GetStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, platform.ConditionDegraded, status.Condition, "stale rates degrade the status")
+	rates.quotes = nil
+
+	_, err = service.DeclareIncident(ctx, &platform.DeclareIncidentRequest{Title: " ", Severity: platform.SeverityMinor})
+	require.ErrorIs(t, err, platform.ErrInvalidIncident)
+
+	incident, err := service.DeclareIncident(ctx, &platform.DeclareIncidentRequest{
+		Title:     "Delayed Tron payments",
+		Message:   "Our Tron node is resyncing.",
+		Severity:  platform.SeverityMajor,
+		Networks:  []shared.BlockchainNetwork{shared.NetworkTron},
+		CreatedBy: "admin",
+	})
+	require.NoError(t, err)
+	assert.True(t, incident.Affects(shared.NetworkTron))
+	assert.False(t, incident.Affects(shared.NetworkEthereum))
+
+	status, err = service.GetStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, platform.ConditionDegraded, status.Condition)
+	require.Len(t, status.Incidents, 1)
+	assert.Equal(t, []shared.BlockchainNetwork{shared.NetworkTron}, status.Incidents[0].Networks())
+	assert.Equal(t, "Our Tron node is resyncing.", status.Incidents[0].Message())
+
+	resolved, err := service.ResolveIncident(ctx, incident.ID())
+	require.NoError(t, err)
+	assert.False(t, resolved.IsActive())
+	_, err = service.ResolveIncident(ctx, incident.ID())
+	require.ErrorIs(t, err, platform.ErrIncidentResolved)
+	_, err = service.ResolveIncident(ctx, "00000000-0000-0000-0000-000000000000")
+	require.ErrorIs(t, err, platform.ErrIncidentNotFound)
+
+	active, err := service.ActiveIncidents(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, active)
+	all, err := service.ListIncidents(ctx, &platform.ListIncidentsRequest{})
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.NotNil(t, all[0].ResolvedAt())
+	assert.Equal(t, []shared.BlockchainNetwork{shared.NetworkTron}, all[0].Networks())
+}
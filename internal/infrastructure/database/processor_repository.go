@@ -0,0 +1,267 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/processor"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ProcessorRepository implements the processor.Repository interface using GORM.
+type ProcessorRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewProcessorRepository creates a new payment processor repository.
+func NewProcessorRepository(db *gorm.DB, logger *zap.Logger) processor.Repository {
+	return &ProcessorRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// SaveRegistration saves a new payment processor registration to the database.
+func (r *ProcessorRepository) SaveRegistration(ctx context.Context, registration *processor.Registration) error {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&PaymentProcessorModel{}).
+		Where("merchant_id = ? AND processor = ?", registration.MerchantID(), registration.Processor()).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check payment processor registration: %w", err)
+	}
+	if count > 0 {
+		return processor.ErrAlreadyRegistered
+	}
+
+	if err := r.db.WithContext(ctx).Create(r.toRegistrationModel(registration)).Error; err != nil {
+		return fmt.Errorf("failed to save payment processor registration: %w", err)
+	}
+	return nil
+}
+
+// FindRegistration finds a payment processor registration by ID.
+func (r *ProcessorRepository) FindRegistration(ctx context.Context, id string) (*processor.Registration, error) {
+	var model PaymentProcessorModel
+	err := r.db.WithContext(ctx).Scopes(merchantScope(ctx)).Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			reportCrossTenantAccess(ctx, r.db, r.logger, &PaymentProcessorModel{}, "payment_processor", id)
+			return nil, processor.ErrRegistrationNotFound
+		}
+		return nil, fmt.Errorf("failed to find payment processor registration: %w", err)
+	}
+
+	return r.toRegistration(&model)
+}
+
+// ListRegistrations lists a merchant's payment processor registrations ordered by processor name.
+func (r *ProcessorRepository) ListRegistrations(
+	ctx context.Context,
+	merchantID string,
+) ([]*processor.Registration, error) {
+	var models []PaymentProcessorModel
+	if err := r.db.WithContext(ctx).
+		Where("merchant_id = ?", merchantID).
+		Order("processor ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list payment processor registrations: %w", err)
+	}
+
+	registrations := make([]*processor.Registration, len(models))
+	for i := range models {
+		registration, err := r.toRegistration(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert payment processor model to domain: %w", err)
+		}
+		registrations[i] = registration
+	}
+	return registrations, nil
+}
+
+// UpdateRegistration updates an existing payment processor registration.
+func (r *ProcessorRepository) UpdateRegistration(ctx context.Context, registration *processor.Registration) error {
+	model := r.toRegistrationModel(registration)
+	result := r.db.WithContext(ctx).Model(&PaymentProcessorModel{}).
+		Scopes(merchantScope(ctx)).
+		Where("id = ?", registration.ID()).
+		Select("api_key", "api_secret", "webhook_secret", "enabled", "updated_at").
+		Updates(model)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update payment processor registration: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		reportCrossTenantAccess(ctx, r.db, r.logger, &PaymentProcessorModel{}, "payment_processor", registration.ID())
+		return processor.ErrRegistrationNotFound
+	}
+	return nil
+}
+
+// DeleteRegistration removes a payment processor registration.
+func (r *ProcessorRepository) DeleteRegistration(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Scopes(merchantScope(ctx)).Where("id = ?", id).Delete(&PaymentProcessorModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete payment processor registration: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		reportCrossTenantAccess(ctx, r.db, r.logger, &PaymentProcessorModel{}, "payment_processor", id)
+		return processor.ErrRegistrationNotFound
+	}
+	return nil
+}
+
+// SaveCheckout saves a new payment processor checkout to the database.
+func (r *ProcessorRepository) SaveCheckout(ctx context.Context, checkout *processor.Checkout) error {
+	if err := r.db.WithContext(ctx).Create(r.toCheckoutModel(checkout)).Error; err != nil {
+		return fmt.Errorf("failed to save payment processor checkout: %w", err)
+	}
+	return nil
+}
+
+// UpdateCheckout updates the status and payment of an existing payment processor checkout.
+func (r *ProcessorRepository) UpdateCheckout(ctx context.Context, checkout *processor.Checkout) error {
+	result := r.db.WithContext(ctx).Model(&ProcessorCheckoutModel{}).
+		Scopes(merchantScope(ctx)).
+		Where("id = ?", checkout.ID()).
+		Updates(map[string]interface{}{
+			"status":     string(checkout.Status()),
+			"payment_id": checkout.PaymentID(),
+			"updated_at": checkout.UpdatedAt(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update payment processor checkout: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		reportCrossTenantAccess(ctx, r.db, r.logger, &ProcessorCheckoutModel{}, "processor_checkout", checkout.ID())
+		return processor.ErrCheckoutNotFound
+	}
+	return nil
+}
+
+// FindCheckout finds a payment processor checkout by ID.
+func (r *ProcessorRepository) FindCheckout(ctx context.Context, id string) (*processor.Checkout, error) {
+	var model ProcessorCheckoutModel
+	err := r.db.WithContext(ctx).Scopes(merchantScope(ctx)).Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			reportCrossTenantAccess(ctx, r.db, r.logger, &ProcessorCheckoutModel{}, "processor_checkout", id)
+			return nil, processor.ErrCheckoutNotFound
+		}
+		return nil, fmt.Errorf("failed to find payment processor checkout: %w", err)
+	}
+
+	return r.toCheckout(&model)
+}
+
+// ListCheckouts lists the payment processor checkouts of an invoice, newest first.
+func (r *ProcessorRepository) ListCheckouts(ctx context.Context, invoiceID string) ([]*processor.Checkout, error) {
+	var models []ProcessorCheckoutModel
+	if err := r.db.WithContext(ctx).
+		Scopes(merchantScope(ctx)).
+		Where("invoice_id = ?", invoiceID).
+		Order("created_at DESC, id ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list payment processor checkouts: %w", err)
+	}
+
+	checkouts := make([]*processor.Checkout, len(models))
+	for i := range models {
+		checkout, err := r.toCheckout(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert payment processor checkout model to domain: %w", err)
+		}
+		checkouts[i] = checkout
+	}
+	return checkouts, nil
+}
+
+// toRegistrationModel converts a domain payment processor registration to a database model.
+func (r *ProcessorRepository) toRegistrationModel(registration *processor.Registration) *PaymentProcessorModel {
+	credentials := registration.Credentials()
+	return &PaymentProcessorModel{
+		ID:            registration.ID(),
+		MerchantID:    registration.MerchantID(),
+		Processor:     registration.Processor(),
+		APIKey:        credentials.APIKey,
+		APISecret:     credentials.APISecret,
+		WebhookSecret: credentials.WebhookSecret,
+		Enabled:       registration.Enabled(),
+		CreatedAt:     registration.CreatedAt(),
+		UpdatedAt:     registration.UpdatedAt(),
+	}
+}
+
+// toRegistration converts a database model to a domain payment processor registration.
+func (r *ProcessorRepository) toRegistration(model *PaymentProcessorModel) (*processor.Registration, error) {
+	return processor.RestoreRegistration(
+		model.ID,
+		model.MerchantID,
+		model.Processor,
+		processor.Credentials{
+			APIKey:        model.APIKey,
+			APISecret:     model.APISecret,
+			WebhookSecret: model.WebhookSecret,
+		},
+		model.Enabled,
+		model.CreatedAt,
+		model.UpdatedAt,
+	)
+}
+
+// toCheckoutModel converts a domain payment processor checkout to a database model.
+func (r *ProcessorRepository) toCheckoutModel(checkout *processor.Checkout) *ProcessorCheckoutModel {
+	model := &ProcessorCheckoutModel{
+		ID:             checkout.ID(),
+		RegistrationID: checkout.RegistrationID(),
+		MerchantID:     checkout.MerchantID(),
+		InvoiceID:      checkout.InvoiceID(),
+		Processor:      checkout.Processor(),
+		ExternalID:     checkout.ExternalID(),
+		URL:            checkout.URL(),
+		QRContent:      checkout.QRContent(),
+		Amount:         checkout.Amount().String(),
+		Currency:       checkout.Currency(),
+		Status:         string(checkout.Status()),
+		PaymentID:      checkout.PaymentID(),
+		CreatedAt:      checkout.CreatedAt(),
+		UpdatedAt:      checkout.UpdatedAt(),
+	}
+	if expiresAt := checkout.ExpiresAt(); !expiresAt.IsZero() {
+		model.ExpiresAt = &expiresAt
+	}
+	return model
+}
+
+// toCheckout converts a database model to a domain payment processor checkout.
+func (r *ProcessorRepository) toCheckout(model *ProcessorCheckoutModel) (*processor.Checkout, error) {
+	amount, err := decimal.NewFromString(model.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkout amount %q: %w", model.Amount, err)
+	}
+	var expiresAt time.Time
+	if model.ExpiresAt != nil {
+		expiresAt = *model.ExpiresAt
+	}
+
+	return processor.RestoreCheckout(
+		model.ID,
+		model.RegistrationID,
+		model.MerchantID,
+		model.InvoiceID,
+		model.Processor,
+		model.ExternalID,
+		model.URL,
+		model.QRContent,
+		amount,
+		model.Currency,
+		processor.CheckoutStatus(model.Status),
+		model.PaymentID,
+		expiresAt,
+		model.CreatedAt,
+		model.UpdatedAt,
+	)
+}
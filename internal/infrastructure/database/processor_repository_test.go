@@ -0,0 +1,120 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/processor"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestRegistration(t *testing.T, id, merchantID, name string) *processor.Registration {
+	registration, err := processor.NewRegistration(id, merchantID, name, processor.Credentials{
+		APIKey:        "api-key",
+		APISecret:     "api-secret",
+		WebhookSecret: "webhook-secret",
+	})
+	require.NoError(t, err)
+	return registration
+}
+
+func TestProcessorRepository_Registrations(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewProcessorRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	coinbase := newTestRegistration(t, "reg-1", "merchant-1", processor.NameCoinbaseCommerce)
+	require.NoError(t, repo.SaveRegistration(ctx, coinbase))
+	require.NoError(t, repo.SaveRegistration(ctx, newTestRegistration(t, "reg-2", "merchant-1", processor.NameBinancePay)))
+	require.NoError(t, repo.SaveRegistration(ctx,
+		newTestRegistration(t, "reg-3", "merchant-2", processor.NameCoinbaseCommerce)))
+
+	t.Run("round trip", func(t *testing.T) {
+		found, err := repo.FindRegistration(ctx, "reg-1")
+		require.NoError(t, err)
+		assert.Equal(t, processor.NameCoinbaseCommerce, found.Processor())
+		assert.Equal(t, coinbase.Credentials(), found.Credentials())
+		assert.True(t, found.Enabled())
+	})
+
+	t.Run("processors are registered once per merchant", func(t *testing.T) {
+		err := repo.SaveRegistration(ctx, newTestRegistration(t, "reg-4", "merchant-1", processor.NameCoinbaseCommerce))
+		require.ErrorIs(t, err, processor.ErrAlreadyRegistered)
+	})
+
+	t.Run("list by merchant", func(t *testing.T) {
+		registrations, err := repo.ListRegistrations(ctx, "merchant-1")
+		require.NoError(t, err)
+		require.Len(t, registrations, 2)
+		assert.Equal(t, processor.NameBinancePay, registrations[0].Processor())
+		assert.Equal(t, processor.NameCoinbaseCommerce, registrations[1].Processor())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		coinbase.SetEnabled(false)
+		require.NoError(t, coinbase.RotateCredentials(processor.Credentials{APIKey: "new-key", WebhookSecret: "new"}))
+		require.NoError(t, repo.UpdateRegistration(ctx, coinbase))
+
+		found, err := repo.FindRegistration(ctx, "reg-1")
+		require.NoError(t, err)
+		assert.False(t, found.Enabled())
+		assert.Equal(t, "new-key", found.Credentials().APIKey)
+		assert.Empty(t, found.Credentials().APISecret)
+	})
+
+	t.Run("scoped to the merchant", func(t *testing.T) {
+		_, err := repo.FindRegistration(shared.WithMerchantID(ctx, "merchant-2"), "reg-1")
+		require.ErrorIs(t, err, processor.ErrRegistrationNotFound)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, repo.DeleteRegistration(ctx, "reg-2"))
+		_, err := repo.FindRegistration(ctx, "reg-2")
+		require.ErrorIs(t, err, processor.ErrRegistrationNotFound)
+		require.ErrorIs(t, repo.DeleteRegistration(ctx, "reg-2"), processor.ErrRegistrationNotFound)
+	})
+}
+
+func TestProcessorRepository_Checkouts(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewProcessorRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	registration := newTestRegistration(t, "reg-1", "merchant-1", processor.NameBinancePay)
+	expiresAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	checkout, err := processor.NewCheckout("checkout-1", registration, "invoice-1",
+		decimal.RequireFromString("12.5"), "USDT", &processor.HostedCheckout{
+			ID:        "prepay-1",
+			URL:       "https://pay.binance.com/checkout/1",
+			QRContent: "binance://pay/1",
+			ExpiresAt: expiresAt,
+		})
+	require.NoError(t, err)
+	require.NoError(t, repo.SaveCheckout(ctx, checkout))
+
+	found, err := repo.FindCheckout(ctx, "checkout-1")
+	require.NoError(t, err)
+	assert.Equal(t, "prepay-1", found.ExternalID())
+	assert.Equal(t, "binance://pay/1", found.QRContent())
+	assert.True(t, decimal.RequireFromString("12.5").Equal(found.Amount()))
+	assert.True(t, expiresAt.Equal(found.ExpiresAt()))
+	assert.Equal(t, processor.CheckoutOpen, found.Status())
+
+	found.MarkPaid("payment-1")
+	require.NoError(t, repo.UpdateCheckout(ctx, found))
+
+	checkouts, err := repo.ListCheckouts(ctx, "invoice-1")
+	require.NoError(t, err)
+	require.Len(t, checkouts, 1)
+	assert.Equal(t, processor.CheckoutPaid, checkouts[0].Status())
+	assert.Equal(t, "payment-1", checkouts[0].PaymentID())
+
+	_, err = repo.FindCheckout(ctx, "missing")
+	require.ErrorIs(t, err, processor.ErrCheckoutNotFound)
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// queryTimeoutKey is the statement setting holding the context a statement was made with and the cancel function
+// of its timeout.
+const queryTimeoutKey = "query_timeout"
+
+// queryDeadline is what a statement's timeout replaced, restored once the statement is done.
+type queryDeadline struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// AttachQueryTimeout bounds every query, write and raw statement made through db by the timeout, within the
+// deadline of the context it is made with; the driver cancels a statement on the server once its context is done.
+// Row reads, whose rows are read after the statement returns, are bounded by their context alone, as are
+// statements made with a context exempted by shared.WithoutQueryTimeout.
+func AttachQueryTimeout(db *gorm.DB, timeout time.Duration) error {
+	if timeout <= 0 {
+		return nil
+	}
+
+	start := func(tx *gorm.DB) {
+		ctx := tx.Statement.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if shared.QueryTimeoutExempt(ctx) {
+			return
+		}
+		bounded, cancel := context.WithTimeout(ctx, timeout)
+		tx.InstanceSet(queryTimeoutKey, queryDeadline{ctx: ctx, cancel: cancel})
+		tx.Statement.Context = bounded
+	}
+	finish := func(tx *gorm.DB) {
+		value, ok := tx.InstanceGet(queryTimeoutKey)
+		if !ok {
+			return
+		}
+		deadline := value.(queryDeadline)
+		deadline.cancel()
+		// A statement reused for another operation starts from its own context again
+		tx.Statement.Context = deadline.ctx
+		tx.Statement.Settings.Delete(fmt.Sprintf("%p", tx.Statement) + queryTimeoutKey)
+	}
+
+	callbacks := db.Callback()
+	err := errors.Join(
+		callbacks.Create().Before("*").Register("query_timeout:start", start),
+		callbacks.Create().After("*").Register("query_timeout:finish", finish),
+		callbacks.Query().Before("*").Register("query_timeout:start", start),
+		callbacks.Query().After("*").Register("query_timeout:finish", finish),
+		callbacks.Update().Before("*").Register("query_timeout:start", start),
+		callbacks.Update().After("*").Register("query_timeout:finish", finish),
+		callbacks.Delete().Before("*").Register("query_timeout:start", start),
+		callbacks.Delete().After("*").Register("query_timeout:finish", finish),
+		callbacks.Raw().Before("*").Register("query_timeout:start", start),
+		callbacks.Raw().After("*").Register("query_timeout:finish", finish),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register the query timeout: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/pkg/config"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// slowQuery counts far enough to run for minutes unless it is cancelled.
+const slowQuery = `WITH RECURSIVE counter(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM counter WHERE i < 10000000000)
+SELECT COUNT(*) FROM counter`
+
+func TestQueryTimeout(t *testing.T) {
+	conn, err := database.NewConnection(config.DatabaseConfig{
+		URL:          "sqlite://" + filepath.Join(t.TempDir(), "checkout.db"),
+		QueryTimeout: 100 * time.Millisecond,
+	}, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	require.NoError(t, conn.Migrate(), "migrations are not bounded by the query timeout")
+
+	// Record the deadline each query is made with
+	var deadline time.Time
+	var bounded bool
+	require.NoError(t, conn.DB.Callback().Query().Before("gorm:query").Register("test:deadline", func(tx *gorm.DB) {
+		deadline, bounded = tx.Statement.Context.Deadline()
+	}))
+
+	t.Run("cancels slow queries", func(t *testing.T) {
+		var count int64
+		started := time.Now()
+		err := conn.DB.WithContext(context.Background()).Raw(slowQuery).Find(&count).Error
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(started), 2*time.Second)
+		require.True(t, bounded)
+
+		var invoices []database.InvoiceModel
+		require.NoError(t, conn.DB.Find(&invoices).Error, "the connection is usable again")
+	})
+
+	t.Run("within the deadline of the context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		expected, _ := ctx.Deadline()
+
+		var count int64
+		started := time.Now()
+		require.ErrorIs(t, conn.DB.WithContext(ctx).Raw(slowQuery).Find(&count).Error, context.DeadlineExceeded)
+		assert.Less(t, time.Since(started), 2*time.Second)
+		assert.Equal(t, expected, deadline, "the sooner deadline of the request holds")
+	})
+
+	t.Run("a statement is bounded for each operation", func(t *testing.T) {
+		tx := conn.DB.WithContext(context.Background()).Session(&gorm.Session{})
+		var invoices []database.InvoiceModel
+		require.NoError(t, tx.Find(&invoices).Error)
+		first := deadline
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, tx.Find(&invoices).Error)
+		assert.True(t, deadline.After(first), "the timeout starts again rather than carrying over")
+	})
+
+	t.Run("exempt contexts", func(t *testing.T) {
+		var invoices []database.InvoiceModel
+		ctx := shared.WithoutQueryTimeout(context.Background())
+		require.NoError(t, conn.DB.WithContext(ctx).Find(&invoices).Error)
+		assert.False(t, bounded)
+	})
+}
@@ -0,0 +1,460 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"crypto-checkout/internal/domain/deposit"
+	"crypto-checkout/internal/domain/fee"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/reconciliation"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/treasury"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ReconciliationRepository implements the reconciliation.Repository and reconciliation.Ledger interfaces using
+// GORM. Amounts are summed here rather than in SQL, as some drivers return decimal sums as floating point.
+type ReconciliationRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewReconciliationRepository creates a new reconciliation repository.
+func NewReconciliationRepository(db *gorm.DB, logger *zap.Logger) *ReconciliationRepository {
+	return &ReconciliationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// discrepancyRecord is the stored form of a discrepancy.
+type discrepancyRecord struct {
+	Kind         string `json:"kind"`
+	Network      string `json:"network,omitempty"`
+	Currency     string `json:"currency"`
+	Address      string `json:"address,omitempty"`
+	InvoiceID    string `json:"invoice_id,omitempty"`
+	SettlementID string `json:"settlement_id,omitempty"`
+	Expected     string `json:"expected"`
+	Actual       string `json:"actual"`
+}
+
+// Save persists a new report.
+func (r *ReconciliationRepository) Save(ctx context.Context, report *reconciliation.Report) error {
+	model, err := r.toModel(report)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save reconciliation report: %w", err)
+	}
+	return nil
+}
+
+// FindByID retrieves a report by its ID.
+func (r *ReconciliationRepository) FindByID(ctx context.Context, id string) (*reconciliation.Report, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+// FindLatest retrieves the most recent report.
+func (r *ReconciliationRepository) FindLatest(ctx context.Context) (*reconciliation.Report, error) {
+	return r.findOne(r.db.WithContext(ctx).Order("created_at DESC").Order("id DESC"))
+}
+
+// List retrieves the latest reports, newest first.
+func (r *ReconciliationRepository) List(ctx context.Context, limit int) ([]*reconciliation.Report, error) {
+	var models []ReconciliationReportModel
+	if err := r.db.WithContext(ctx).
+		Order("created_at DESC").Order("id DESC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list reconciliation reports: %w", err)
+	}
+
+	reports := make([]*reconciliation.Report, 0, len(models))
+	for i := range models {
+		report, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// balanceKey identifies the balance of a currency at a deposit address.
+type balanceKey struct {
+	network  string
+	address  string
+	currency string
+}
+
+// balanceSheet accumulates the expected balances of deposit addresses.
+type balanceSheet map[balanceKey]*reconciliation.ExpectedBalance
+
+// entry returns the balance of a currency at an address, adding it when missing.
+func (b balanceSheet) entry(network, address, currency string) *reconciliation.ExpectedBalance {
+	key := balanceKey{network: network, address: address, currency: currency}
+	if existing, ok := b[key]; ok {
+		return existing
+	}
+	created := &reconciliation.ExpectedBalance{
+		Network:  shared.BlockchainNetwork(network),
+		Currency: currency,
+		Address:  address,
+	}
+	b[key] = created
+	return created
+}
+
+// ExpectedBalances returns the balance every deposit address with payments or unmatched deposits should hold.
+// Sweeps from addresses whose payments were archived are not considered.
+func (r *ReconciliationRepository) ExpectedBalances(ctx context.Context) ([]*reconciliation.ExpectedBalance, error) {
+	sheet := make(balanceSheet)
+	if err := r.addPayments(ctx, sheet); err != nil {
+		return nil, err
+	}
+	if err := r.addDeposits(ctx, sheet); err != nil {
+		return nil, err
+	}
+	if err := r.subtractSweeps(ctx, sheet); err != nil {
+		return nil, err
+	}
+
+	result := make([]*reconciliation.ExpectedBalance, 0, len(sheet))
+	for _, entry := range sheet {
+		result = append(result, entry)
+	}
+	slices.SortFunc(result, func(a, b *reconciliation.ExpectedBalance) int {
+		return cmp.Or(
+			cmp.Compare(a.Network, b.Network),
+			cmp.Compare(a.Address, b.Address),
+			cmp.Compare(a.Currency, b.Currency),
+		)
+	})
+	return result, nil
+}
+
+// addPayments adds the confirmed and held payments to their addresses, and marks the addresses with payments
+// still confirming as moving. Payments recorded before their network was stored take the network of their invoice.
+func (r *ReconciliationRepository) addPayments(ctx context.Context, sheet balanceSheet) error {
+	var payments []struct {
+		Network        string
+		InvoiceNetwork *string
+		ToAddress      string
+		Amount         string
+		Status         string
+		CryptoCurrency string
+	}
+	if err := r.db.WithContext(ctx).Model(&PaymentModel{}).
+		Select("payments.network, invoices.network AS invoice_network, payments.to_address, payments.amount, "+
+			"payments.status, invoices.crypto_currency").
+		Joins("JOIN invoices ON invoices.id = payments.invoice_id").
+		Where("payments.status IN ?", []string{
+			string(payment.StatusDetected), string(payment.StatusConfirming),
+			string(payment.StatusConfirmed), string(payment.StatusHeld),
+		}).
+		Scan(&payments).Error; err != nil {
+		return fmt.Errorf("failed to read payments: %w", err)
+	}
+
+	for _, p := range payments {
+		network := p.Network
+		if network == "" && p.InvoiceNetwork != nil {
+			network = *p.InvoiceNetwork
+		}
+		entry := sheet.entry(network, p.ToAddress, p.CryptoCurrency)
+		switch payment.PaymentStatus(p.Status) {
+		case payment.StatusDetected, payment.StatusConfirming:
+			entry.Moving = true
+		default:
+			amount, err := decimal.NewFromString(p.Amount)
+			if err != nil {
+				return fmt.Errorf("invalid payment amount %q: %w", p.Amount, err)
+			}
+			entry.Received = entry.Received.Add(amount)
+		}
+	}
+	return nil
+}
+
+// addDeposits adds the unmatched deposits, which stay at the address until attached or refunded.
+func (r *ReconciliationRepository) addDeposits(ctx context.Context, sheet balanceSheet) error {
+	var deposits []UnattributedDepositModel
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", string(deposit.StatusUnmatched)).
+		Find(&deposits).Error; err != nil {
+		return fmt.Errorf("failed to read deposits: %w", err)
+	}
+
+	for _, d := range deposits {
+		amount, err := decimal.NewFromString(d.Amount)
+		if err != nil {
+			return fmt.Errorf("invalid deposit amount %q: %w", d.Amount, err)
+		}
+		entry := sheet.entry(d.Network, d.ToAddress, d.Currency)
+		entry.Received = entry.Received.Add(amount)
+	}
+	return nil
+}
+
+// subtractSweeps subtracts the confirmed sweeps, with their network fees when paid in the swept currency, and
+// marks the addresses with sweeps still pending or broadcast as moving.
+func (r *ReconciliationRepository) subtractSweeps(ctx context.Context, sheet balanceSheet) error {
+	var sweeps []SweepModel
+	if err := r.db.WithContext(ctx).
+		Where("status IN ?", []string{
+			string(treasury.SweepStatusPending), string(treasury.SweepStatusBroadcast),
+			string(treasury.SweepStatusConfirmed),
+		}).
+		Find(&sweeps).Error; err != nil {
+		return fmt.Errorf("failed to read sweeps: %w", err)
+	}
+	fees, err := r.sweepFees(ctx, sweeps)
+	if err != nil {
+		return err
+	}
+
+	for _, sweep := range sweeps {
+		entry, ok := sheet[balanceKey{network: sweep.Network, address: sweep.FromAddress, currency: sweep.Currency}]
+		if !ok {
+			continue
+		}
+		if treasury.SweepStatus(sweep.Status) != treasury.SweepStatusConfirmed {
+			entry.Moving = true
+			continue
+		}
+		amount, err := decimal.NewFromString(sweep.Amount)
+		if err != nil {
+			return fmt.Errorf("invalid sweep amount %q: %w", sweep.Amount, err)
+		}
+		entry.Swept = entry.Swept.Add(amount)
+		if spent, ok := fees[sweep.ID]; ok && spent.FeeCurrency == sweep.Currency {
+			feeAmount, err := decimal.NewFromString(spent.Amount)
+			if err != nil {
+				return fmt.Errorf("invalid fee amount %q: %w", spent.Amount, err)
+			}
+			entry.Swept = entry.Swept.Add(feeAmount)
+		}
+	}
+	return nil
+}
+
+// sweepFees returns the network fees spent by confirmed sweeps, by sweep ID.
+func (r *ReconciliationRepository) sweepFees(
+	ctx context.Context,
+	sweeps []SweepModel,
+) (map[string]FeeSpendModel, error) {
+	var ids []string
+	for _, sweep := range sweeps {
+		if treasury.SweepStatus(sweep.Status) == treasury.SweepStatusConfirmed {
+			ids = append(ids, sweep.ID)
+		}
+	}
+	fees := make(map[string]FeeSpendModel)
+	if len(ids) == 0 {
+		return fees, nil
+	}
+
+	var spends []FeeSpendModel
+	if err := r.db.WithContext(ctx).
+		Where("purpose = ? AND reference IN ?", string(fee.PurposeSweep), ids).
+		Find(&spends).Error; err != nil {
+		return nil, fmt.Errorf("failed to read sweep fees: %w", err)
+	}
+	for _, spend := range spends {
+		fees[spend.Reference] = spend
+	}
+	return fees, nil
+}
+
+// InvoiceSettlements returns the invoices paid in the period that are still paid, with their confirmed payments
+// and settlements, oldest first.
+func (r *ReconciliationRepository) InvoiceSettlements(
+	ctx context.Context,
+	from, to time.Time,
+) ([]*reconciliation.InvoiceSettlement, error) {
+	var invoices []InvoiceModel
+	if err := r.db.WithContext(ctx).
+		Select("id", "crypto_currency", "paid_at").
+		Where("status = ? AND paid_at >= ? AND paid_at < ?", invoice.StatusPaid.String(), from, to).
+		Order("paid_at ASC").Order("id ASC").
+		Find(&invoices).Error; err != nil {
+		return nil, fmt.Errorf("failed to read paid invoices: %w", err)
+	}
+	if len(invoices) == 0 {
+		return nil, nil
+	}
+
+	result := make([]*reconciliation.InvoiceSettlement, len(invoices))
+	byInvoice := make(map[string]*reconciliation.InvoiceSettlement, len(invoices))
+	invoiceIDs := make([]string, len(invoices))
+	for i, model := range invoices {
+		result[i] = &reconciliation.InvoiceSettlement{InvoiceID: model.ID, Currency: model.CryptoCurrency}
+		byInvoice[model.ID] = result[i]
+		invoiceIDs[i] = model.ID
+	}
+
+	var payments []PaymentModel
+	if err := r.db.WithContext(ctx).
+		Where("invoice_id IN ? AND status = ?", invoiceIDs, string(payment.StatusConfirmed)).
+		Find(&payments).Error; err != nil {
+		return nil, fmt.Errorf("failed to read payments: %w", err)
+	}
+	for _, p := range payments {
+		amount, err := decimal.NewFromString(p.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payment amount %q: %w", p.Amount, err)
+		}
+		byInvoice[p.InvoiceID].Received = byInvoice[p.InvoiceID].Received.Add(amount)
+	}
+
+	if err := r.attachSettlements(ctx, byInvoice, invoiceIDs); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// attachSettlements attaches the settlements of the invoices, noting which reversed ones a reversal entry offsets.
+func (r *ReconciliationRepository) attachSettlements(
+	ctx context.Context,
+	byInvoice map[string]*reconciliation.InvoiceSettlement,
+	invoiceIDs []string,
+) error {
+	var settlements []SettlementModel
+	if err := r.db.WithContext(ctx).
+		Where("invoice_id IN ?", invoiceIDs).
+		Order("created_at ASC").Order("id ASC").
+		Find(&settlements).Error; err != nil {
+		return fmt.Errorf("failed to read settlements: %w", err)
+	}
+	var reversedIDs []string
+	for _, s := range settlements {
+		if settlement.Status(s.Status) == settlement.StatusReversed {
+			reversedIDs = append(reversedIDs, s.ID)
+		}
+	}
+	offset := make(map[string]bool, len(reversedIDs))
+	if len(reversedIDs) > 0 {
+		var reversals []SettlementReversalModel
+		if err := r.db.WithContext(ctx).
+			Where("settlement_id IN ?", reversedIDs).
+			Find(&reversals).Error; err != nil {
+			return fmt.Errorf("failed to read settlement reversals: %w", err)
+		}
+		for _, reversal := range reversals {
+			offset[reversal.SettlementID] = true
+		}
+	}
+	for _, s := range settlements {
+		gross, err := decimal.NewFromString(s.GrossAmount)
+		if err != nil {
+			return fmt.Errorf("invalid settlement amount %q: %w", s.GrossAmount, err)
+		}
+		entry := byInvoice[s.InvoiceID]
+		entry.Settlements = append(entry.Settlements, reconciliation.SettlementEntry{
+			ID:          s.ID,
+			GrossAmount: gross,
+			Reversed:    settlement.Status(s.Status) == settlement.StatusReversed,
+			Offset:      offset[s.ID],
+		})
+	}
+	return nil
+}
+
+// findOne finds a single report matching the query.
+func (r *ReconciliationRepository) findOne(query *gorm.DB) (*reconciliation.Report, error) {
+	var model ReconciliationReportModel
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, reconciliation.ErrReportNotFound
+		}
+		return nil, fmt.Errorf("failed to find reconciliation report: %w", err)
+	}
+	return r.toDomain(&model)
+}
+
+// toModel converts a report to a database model.
+func (r *ReconciliationRepository) toModel(report *reconciliation.Report) (*ReconciliationReportModel, error) {
+	records := make([]discrepancyRecord, len(report.Discrepancies))
+	for i, d := range report.Discrepancies {
+		records[i] = discrepancyRecord{
+			Kind:         string(d.Kind),
+			Network:      string(d.Network),
+			Currency:     d.Currency,
+			Address:      d.Address,
+			InvoiceID:    d.InvoiceID,
+			SettlementID: d.SettlementID,
+			Expected:     d.Expected.String(),
+			Actual:       d.Actual.String(),
+		}
+	}
+	encoded, err := json.Marshal(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode discrepancies: %w", err)
+	}
+
+	return &ReconciliationReportModel{
+		ID:               report.ID,
+		PeriodStart:      report.PeriodStart,
+		PeriodEnd:        report.PeriodEnd,
+		AddressesChecked: report.AddressesChecked,
+		AddressesSkipped: report.AddressesSkipped,
+		InvoicesChecked:  report.InvoicesChecked,
+		Discrepancies:    string(encoded),
+		Alerted:          report.Alerted,
+		CreatedAt:        report.CreatedAt,
+	}, nil
+}
+
+// toDomain converts a database model to a report.
+func (r *ReconciliationRepository) toDomain(model *ReconciliationReportModel) (*reconciliation.Report, error) {
+	var records []discrepancyRecord
+	if err := json.Unmarshal([]byte(model.Discrepancies), &records); err != nil {
+		return nil, fmt.Errorf("failed to decode discrepancies of report %s: %w", model.ID, err)
+	}
+
+	discrepancies := make([]reconciliation.Discrepancy, len(records))
+	for i, record := range records {
+		expected, err := decimal.NewFromString(record.Expected)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expected amount in report %s: %w", model.ID, err)
+		}
+		actual, err := decimal.NewFromString(record.Actual)
+		if err != nil {
+			return nil, fmt.Errorf("invalid actual amount in report %s: %w", model.ID, err)
+		}
+		discrepancies[i] = reconciliation.Discrepancy{
+			Kind:         reconciliation.DiscrepancyKind(record.Kind),
+			Network:      shared.BlockchainNetwork(record.Network),
+			Currency:     record.Currency,
+			Address:      record.Address,
+			InvoiceID:    record.InvoiceID,
+			SettlementID: record.SettlementID,
+			Expected:     expected,
+			Actual:       actual,
+		}
+	}
+
+	return &reconciliation.Report{
+		ID:               model.ID,
+		PeriodStart:      model.PeriodStart.UTC(),
+		PeriodEnd:        model.PeriodEnd.UTC(),
+		AddressesChecked: model.AddressesChecked,
+		AddressesSkipped: model.AddressesSkipped,
+		InvoicesChecked:  model.InvoicesChecked,
+		Discrepancies:    discrepancies,
+		Alerted:          model.Alerted,
+		CreatedAt:        model.CreatedAt.UTC(),
+	}, nil
+}
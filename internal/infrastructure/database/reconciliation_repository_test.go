@@ -0,0 +1,153 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/reconciliation"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestReconciliationRepository(t *testing.T) {
+	db := setupTestDB(t)
+	invoices := database.NewInvoiceRepository(db, zap.NewNop())
+	repo := database.NewReconciliationRepository(db, zap.NewNop())
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	// markPaid moves an invoice to paid as of the given time
+	markPaid := func(id string, paidAt time.Time) {
+		require.NoError(t, invoices.Save(ctx, createTestInvoiceWithID(t, id)))
+		require.NoError(t, db.Model(&database.InvoiceModel{}).Where("id = ?", id).
+			Updates(map[string]interface{}{"status": invoice.StatusPaid.String(), "paid_at": paidAt}).Error)
+	}
+	addPayment := func(invoiceID, address, amount, status string) {
+		require.NoError(t, db.Create(&database.PaymentModel{
+			ID: uuid.New().String(), InvoiceID: invoiceID, Network: string(shared.NetworkTron),
+			TxHash: uuid.New().String(), Amount: amount, FromAddress: "TSender", ToAddress: address,
+			Status: status, DetectedAt: now, CreatedAt: now,
+		}).Error)
+	}
+	addSettlement := func(id, invoiceID, merchantID, gross, status string) {
+		require.NoError(t, db.Create(&database.SettlementModel{
+			ID: id, InvoiceID: invoiceID, MerchantID: merchantID, GrossAmount: gross, FeePercentage: "1",
+			FeeAmount: "0", NetAmount: gross, Currency: "USDT", Status: status, CreatedAt: now,
+		}).Error)
+	}
+
+	markPaid("inv-swept", now.Add(-2*time.Hour))
+	addPayment("inv-swept", "TSwept", "100", "confirmed")
+	addPayment("inv-swept", "TSwept", "5", "failed")
+	addSettlement("stl-1", "inv-swept", "merchant-1", "70", "completed")
+	addSettlement("stl-2", "inv-swept", "merchant-2", "30", "completed")
+
+	markPaid("inv-reversed", now.Add(-3*time.Hour))
+	addPayment("inv-reversed", "TMoving", "20", "confirmed")
+	addPayment("inv-reversed", "TMoving", "1", "confirming")
+	addSettlement("stl-3", "inv-reversed", "merchant-1", "20", "reversed")
+	addSettlement("stl-4", "inv-reversed", "merchant-1", "20", "reversed")
+	require.NoError(t, db.Create(&database.SettlementReversalModel{
+		ID: "rev-1", SettlementID: "stl-3", InvoiceID: "inv-reversed", MerchantID: "merchant-1",
+		GrossAmount: "-20", FeeAmount: "0", NetAmount: "-20", Currency: "USDT", CreatedAt: now,
+	}).Error)
+
+	markPaid("inv-old", now.Add(-48*time.Hour))
+
+	sweepID := uuid.New().String()
+	require.NoError(t, db.Create(&database.SweepModel{
+		ID: sweepID, InvoiceID: "inv-swept", MerchantID: "merchant-1", Network: string(shared.NetworkTron),
+		Currency: "USDT", FromAddress: "TSwept", Amount: "60", Status: "confirmed", CreatedAt: now,
+	}).Error)
+	require.NoError(t, db.Create(&database.FeeSpendModel{
+		ID: uuid.New().String(), Purpose: "sweep", Reference: sweepID, Network: string(shared.NetworkTron),
+		TxHash: "0xsweep", Amount: "2", FeeCurrency: "TRX", CreatedAt: now,
+	}).Error)
+	require.NoError(t, db.Create(&database.UnattributedDepositModel{
+		ID: uuid.New().String(), MerchantID: "merchant-1", InvoiceID: "inv-swept", Reason: "late",
+		Status: "unmatched", Network: string(shared.NetworkTron), TxHash: "0xdeposit", FromAddress: "TSender",
+		ToAddress: "TSwept", Amount: "3", Currency: "USDT", CreatedAt: now,
+	}).Error)
+
+	t.Run("Expected_Balances", func(t *testing.T) {
+		balances, err := repo.ExpectedBalances(ctx)
+		require.NoError(t, err)
+		require.Len(t, balances, 2)
+
+		assert.Equal(t, "TMoving", balances[0].Address)
+		assert.True(t, balances[0].Moving)
+
+		swept := balances[1]
+		assert.Equal(t, "TSwept", swept.Address)
+		assert.Equal(t, shared.NetworkTron, swept.Network)
+		assert.Equal(t, "USDT", swept.Currency)
+		assert.False(t, swept.Moving)
+		// The TRX sweep fee is not taken from the USDT balance
+		assert.Equal(t, "103", swept.Received.String())
+		assert.Equal(t, "43", swept.Amount().String())
+	})
+
+	t.Run("Invoice_Settlements", func(t *testing.T) {
+		entries, err := repo.InvoiceSettlements(ctx, now.Add(-24*time.Hour), now)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+
+		assert.Equal(t, "inv-reversed", entries[0].InvoiceID)
+		assert.Equal(t, "20", entries[0].Received.String())
+		require.Len(t, entries[0].Settlements, 2)
+		assert.True(t, entries[0].Settlements[0].Reversed)
+		assert.True(t, entries[0].Settlements[0].Offset)
+		assert.False(t, entries[0].Settlements[1].Offset)
+
+		assert.Equal(t, "inv-swept", entries[1].InvoiceID)
+		assert.Equal(t, "USDT", entries[1].Currency)
+		assert.Equal(t, "100", entries[1].Received.String())
+		assert.Len(t, entries[1].Settlements, 2)
+	})
+
+	t.Run("Saves_And_Lists_Reports", func(t *testing.T) {
+		_, err := repo.FindLatest(ctx)
+		require.ErrorIs(t, err, reconciliation.ErrReportNotFound)
+
+		first := &reconciliation.Report{
+			ID: uuid.New().String(), PeriodStart: now.Add(-48 * time.Hour), PeriodEnd: now.Add(-24 * time.Hour),
+			CreatedAt: now.Add(-23 * time.Hour),
+		}
+		second := &reconciliation.Report{
+			ID: uuid.New().String(), PeriodStart: first.PeriodEnd, PeriodEnd: now, InvoicesChecked: 2,
+			Discrepancies: []reconciliation.Discrepancy{{
+				Kind: reconciliation.KindMissingReversal, Currency: "USDT", InvoiceID: "inv-reversed",
+				SettlementID: "stl-4", Expected: decimal.RequireFromString("-20"), Actual: decimal.Zero,
+			}},
+			Alerted:   true,
+			CreatedAt: now,
+		}
+		require.NoError(t, repo.Save(ctx, first))
+		require.NoError(t, repo.Save(ctx, second))
+
+		latest, err := repo.FindLatest(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, second.ID, latest.ID)
+		assert.True(t, latest.Alerted)
+		require.Len(t, latest.Discrepancies, 1)
+		assert.Equal(t, "stl-4", latest.Discrepancies[0].SettlementID)
+		assert.Equal(t, "20", latest.Discrepancies[0].Difference().String())
+
+		found, err := repo.FindByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Empty(t, found.Discrepancies)
+		assert.Equal(t, first.PeriodEnd, found.PeriodEnd)
+
+		reports, err := repo.List(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, reports, 2)
+		assert.Equal(t, second.ID, reports[0].ID)
+	})
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// RefreshTokenRepository implements the merchant.RefreshTokenRepository interface using GORM.
+type RefreshTokenRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewRefreshTokenRepository creates a new refresh token repository.
+func NewRefreshTokenRepository(db *gorm.DB, logger *zap.Logger) merchant.RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a refresh token to the database.
+func (r *RefreshTokenRepository) Save(ctx context.Context, token *merchant.RefreshToken) error {
+	if err := r.db.WithContext(ctx).Create(r.toModel(token)).Error; err != nil {
+		return fmt.Errorf("failed to save refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// FindByHash finds a refresh token by its hash.
+func (r *RefreshTokenRepository) FindByHash(
+	ctx context.Context,
+	hash *merchant.APIKeyHash,
+) (*merchant.RefreshToken, error) {
+	var model RefreshTokenModel
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", hash.String()).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, merchant.ErrRefreshTokenNotFound
+		}
+		return nil, fmt.Errorf("failed to find refresh token: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// Update updates an existing refresh token.
+func (r *RefreshTokenRepository) Update(ctx context.Context, token *merchant.RefreshToken) error {
+	if err := r.db.WithContext(ctx).Save(r.toModel(token)).Error; err != nil {
+		return fmt.Errorf("failed to update refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeFamily revokes all active tokens in a session family.
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	if err := r.db.WithContext(ctx).Model(&RefreshTokenModel{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", shared.Now()).Error; err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+
+	r.logger.Debug("Refresh token family revoked", zap.String("family_id", familyID))
+	return nil
+}
+
+// RevokeByUserID revokes all active tokens of a user.
+func (r *RefreshTokenRepository) RevokeByUserID(ctx context.Context, userID string) error {
+	if err := r.db.WithContext(ctx).Model(&RefreshTokenModel{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", shared.Now()).Error; err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens for user: %w", err)
+	}
+
+	return nil
+}
+
+// toModel converts a domain refresh token to a database model.
+func (r *RefreshTokenRepository) toModel(token *merchant.RefreshToken) *RefreshTokenModel {
+	return &RefreshTokenModel{
+		ID:         token.ID(),
+		UserID:     token.UserID(),
+		MerchantID: token.MerchantID(),
+		FamilyID:   token.FamilyID(),
+		TokenHash:  token.TokenHash().String(),
+		ExpiresAt:  token.ExpiresAt(),
+		CreatedAt:  token.CreatedAt(),
+		RevokedAt:  token.RevokedAt(),
+	}
+}
+
+// toDomain converts a database model to a domain refresh token.
+func (r *RefreshTokenRepository) toDomain(model *RefreshTokenModel) (*merchant.RefreshToken, error) {
+	tokenHash, err := merchant.NewAPIKeyHashFromString(model.TokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token hash from string: %w", err)
+	}
+
+	return merchant.RestoreRefreshToken(
+		model.ID,
+		model.UserID,
+		model.MerchantID,
+		model.FamilyID,
+		tokenHash,
+		model.ExpiresAt,
+		model.CreatedAt,
+		model.RevokedAt,
+	)
+}
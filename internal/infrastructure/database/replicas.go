@@ -0,0 +1,179 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/pkg/config"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// replicaPingTimeout bounds a single replica health check.
+const replicaPingTimeout = 3 * time.Second
+
+// replica is a read replica connection and the outcome of its last health check.
+type replica struct {
+	name    string
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// Replicas routes reads whose context allows replica reads to healthy read replicas in turn.
+// Reads inside transactions, reads without the marker and all writes stay on the primary, as do
+// replica reads while no replica is healthy.
+type Replicas struct {
+	replicas []*replica
+	next     atomic.Uint64
+	logger   *zap.Logger
+}
+
+// AttachReplicas routes the replica reads made through primary to the given replica connections, all
+// considered healthy until checked.
+func AttachReplicas(primary *gorm.DB, dbs []*sql.DB, logger *zap.Logger) (*Replicas, error) {
+	replicas := make([]*replica, len(dbs))
+	for i, db := range dbs {
+		replicas[i] = &replica{name: fmt.Sprintf("replica-%d", i+1), db: db}
+		replicas[i].healthy.Store(true)
+	}
+
+	r := &Replicas{replicas: replicas, logger: logger}
+	if err := r.register(primary); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// register installs the routing callbacks on the primary's query and row callback chains.
+func (r *Replicas) register(db *gorm.DB) error {
+	if err := db.Callback().Query().Before("gorm:query").Register("replicas:route", r.route); err != nil {
+		return fmt.Errorf("failed to register replica routing for queries: %w", err)
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("replicas:route", r.route); err != nil {
+		return fmt.Errorf("failed to register replica routing for rows: %w", err)
+	}
+	return nil
+}
+
+// route points a read statement at a healthy replica when its context allows replica reads.
+func (r *Replicas) route(db *gorm.DB) {
+	ctx := db.Statement.Context
+	if ctx == nil || !shared.ReplicaReadsAllowed(ctx) {
+		return
+	}
+	if _, inTransaction := db.Statement.ConnPool.(gorm.TxCommitter); inTransaction {
+		return
+	}
+	if replica := r.pick(); replica != nil {
+		db.Statement.ConnPool = replica.db
+	}
+}
+
+// pick returns the next healthy replica round-robin, or nil if none is healthy.
+func (r *Replicas) pick() *replica {
+	count := uint64(len(r.replicas))
+	start := r.next.Add(1)
+	for i := range count {
+		if candidate := r.replicas[(start+i)%count]; candidate.healthy.Load() {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// Healthy returns how many replicas passed their last health check.
+func (r *Replicas) Healthy() int {
+	healthy := 0
+	for _, replica := range r.replicas {
+		if replica.healthy.Load() {
+			healthy++
+		}
+	}
+	return healthy
+}
+
+// Check pings every replica, taking unreachable ones out of rotation until they answer again.
+func (r *Replicas) Check(ctx context.Context) {
+	for _, replica := range r.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+		err := replica.db.PingContext(pingCtx)
+		cancel()
+
+		healthy := err == nil
+		if replica.healthy.Swap(healthy) == healthy {
+			continue
+		}
+		if healthy {
+			r.logger.Info("Read replica recovered", zap.String("replica", replica.name))
+		} else {
+			r.logger.Warn("Read replica unreachable, routing its reads elsewhere",
+				zap.String("replica", replica.name), zap.Error(err))
+		}
+	}
+}
+
+// Run checks the replicas on every interval until the context is cancelled.
+func (r *Replicas) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		r.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// close closes every replica connection.
+func (r *Replicas) close() error {
+	for _, replica := range r.replicas {
+		if err := replica.db.Close(); err != nil {
+			return fmt.Errorf("failed to close %s: %w", replica.name, err)
+		}
+	}
+	return nil
+}
+
+// RegisterReplicaHealthChecks checks the read replicas in the background for the lifetime of the application.
+// Nothing is started when no replica is configured.
+func RegisterReplicaHealthChecks(lc fx.Lifecycle, conn *Connection, cfg *config.Config, logger *zap.Logger) {
+	if conn.Replicas == nil {
+		return
+	}
+	interval := cfg.Database.ReplicaCheckInterval
+	if interval <= 0 {
+		interval = config.DefaultReplicaCheckInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			logger.Info("Starting read replica health checks",
+				zap.Int("replicas", len(conn.Replicas.replicas)), zap.Duration("interval", interval))
+			go func() {
+				defer close(done)
+				conn.Replicas.Run(ctx, interval)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
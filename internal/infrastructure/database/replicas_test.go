@@ -0,0 +1,82 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/pkg/config"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestReplicas_RouteReplicaReads(t *testing.T) {
+	openDB := func(t *testing.T, name string) *database.Connection {
+		conn, err := database.NewConnection(config.DatabaseConfig{
+			URL: "sqlite://" + filepath.Join(t.TempDir(), name+".db"),
+		}, zap.NewNop())
+		require.NoError(t, err)
+		require.NoError(t, conn.Migrate())
+		return conn
+	}
+
+	primary := openDB(t, "primary")
+	replicaConn := openDB(t, "replica")
+	replicaDB, err := replicaConn.DB.DB()
+	require.NoError(t, err)
+
+	replicas, err := database.AttachReplicas(primary.DB, []*sql.DB{replicaDB}, zap.NewNop())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	replicaCtx := shared.WithReplicaReads(ctx)
+	repository := database.NewInvoiceRepository(primary.DB, zap.NewNop())
+	require.NoError(t, repository.Save(ctx, createTestInvoiceWithID(t, "inv_primary")))
+	require.NoError(t, database.NewInvoiceRepository(replicaConn.DB, zap.NewNop()).
+		Save(ctx, createTestInvoiceWithID(t, "inv_replica")))
+
+	t.Run("replica reads go to the replica", func(t *testing.T) {
+		inv, err := repository.FindByID(replicaCtx, "inv_replica")
+		require.NoError(t, err)
+		assert.Equal(t, "inv_replica", inv.ID())
+
+		_, err = repository.FindByID(ctx, "inv_replica")
+		require.ErrorIs(t, err, shared.ErrNotFound)
+		_, err = repository.FindByID(shared.WithoutReplicaReads(replicaCtx), "inv_replica")
+		require.ErrorIs(t, err, shared.ErrNotFound)
+	})
+
+	t.Run("invoices missing on the replica are read from the primary", func(t *testing.T) {
+		inv, err := repository.FindByID(replicaCtx, "inv_primary")
+		require.NoError(t, err)
+		assert.Equal(t, "inv_primary", inv.ID())
+	})
+
+	t.Run("transactions stay on the primary", func(t *testing.T) {
+		err := primary.DB.Transaction(func(tx *gorm.DB) error {
+			var count int64
+			require.NoError(t, tx.WithContext(replicaCtx).Model(&database.InvoiceModel{}).
+				Where("id = ?", "inv_replica").Count(&count).Error)
+			assert.Zero(t, count)
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("unreachable replicas are skipped", func(t *testing.T) {
+		require.NoError(t, replicaDB.Close())
+		replicas.Check(ctx)
+		assert.Zero(t, replicas.Healthy())
+
+		inv, err := repository.FindByID(replicaCtx, "inv_primary")
+		require.NoError(t, err)
+		assert.Equal(t, "inv_primary", inv.ID())
+		_, err = repository.FindByID(replicaCtx, "inv_replica")
+		require.ErrorIs(t, err, shared.ErrNotFound)
+	})
+}
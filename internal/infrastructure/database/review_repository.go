@@ -0,0 +1,157 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/review"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ReviewRepository implements the review.Repository interface using GORM.
+type ReviewRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewReviewRepository creates a new payment review repository.
+func NewReviewRepository(db *gorm.DB, logger *zap.Logger) review.Repository {
+	return &ReviewRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a review to the database.
+func (r *ReviewRepository) Save(ctx context.Context, rev *review.Review) error {
+	if err := r.db.WithContext(ctx).Create(r.toModel(rev)).Error; err != nil {
+		return fmt.Errorf("failed to save review: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a review by ID.
+func (r *ReviewRepository) FindByID(ctx context.Context, id string) (*review.Review, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+// FindOpenByPayment finds the open review of the given kind for a payment.
+func (r *ReviewRepository) FindOpenByPayment(
+	ctx context.Context,
+	kind review.Kind,
+	paymentID string,
+) (*review.Review, error) {
+	return r.findOne(r.db.WithContext(ctx).Where(
+		"kind = ? AND payment_id = ? AND status = ?", string(kind), paymentID, string(review.StatusOpen),
+	))
+}
+
+// List retrieves reviews matching the filter, oldest first.
+func (r *ReviewRepository) List(
+	ctx context.Context,
+	req *review.ListReviewsRequest,
+) (*review.ListReviewsResponse, error) {
+	query := r.db.WithContext(ctx).Model(&PaymentReviewModel{})
+	if req.MerchantID != "" {
+		query = query.Where("merchant_id = ?", req.MerchantID)
+	}
+	if req.Kind != "" {
+		query = query.Where("kind = ?", string(req.Kind))
+	}
+	if req.Status != "" {
+		query = query.Where("status = ?", string(req.Status))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count reviews: %w", err)
+	}
+
+	var models []PaymentReviewModel
+	if err := query.Order("created_at ASC").Limit(req.Limit).Offset(req.Offset).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	reviews := make([]*review.Review, len(models))
+	for i := range models {
+		rev, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert review model to domain: %w", err)
+		}
+		reviews[i] = rev
+	}
+
+	return &review.ListReviewsResponse{
+		Reviews: reviews,
+		Total:   int(total),
+		Limit:   req.Limit,
+		Offset:  req.Offset,
+	}, nil
+}
+
+// Update updates an existing review.
+func (r *ReviewRepository) Update(ctx context.Context, rev *review.Review) error {
+	if err := r.db.WithContext(ctx).Save(r.toModel(rev)).Error; err != nil {
+		return fmt.Errorf("failed to update review: %w", err)
+	}
+
+	return nil
+}
+
+// findOne finds a single review matching the query.
+func (r *ReviewRepository) findOne(query *gorm.DB) (*review.Review, error) {
+	var model PaymentReviewModel
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, review.ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to find review: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// toModel converts a domain review to a database model.
+func (r *ReviewRepository) toModel(rev *review.Review) *PaymentReviewModel {
+	return &PaymentReviewModel{
+		ID:             rev.ID(),
+		MerchantID:     rev.MerchantID(),
+		Kind:           string(rev.Kind()),
+		Status:         string(rev.Status()),
+		InvoiceID:      rev.InvoiceID(),
+		PaymentID:      rev.PaymentID(),
+		ExpectedAmount: rev.ExpectedAmount(),
+		ReceivedAmount: rev.ReceivedAmount(),
+		Currency:       rev.Currency(),
+		Reason:         rev.Reason(),
+		Resolution:     string(rev.Resolution()),
+		ResolvedBy:     rev.ResolvedBy(),
+		Note:           rev.Note(),
+		CreatedAt:      rev.CreatedAt(),
+		ResolvedAt:     rev.ResolvedAt(),
+	}
+}
+
+// toDomain converts a database model to a domain review.
+func (r *ReviewRepository) toDomain(model *PaymentReviewModel) (*review.Review, error) {
+	return review.RestoreReview(
+		model.ID,
+		model.MerchantID,
+		review.Kind(model.Kind),
+		review.Status(model.Status),
+		model.InvoiceID,
+		model.PaymentID,
+		model.ExpectedAmount,
+		model.ReceivedAmount,
+		model.Currency,
+		model.Reason,
+		review.Resolution(model.Resolution),
+		model.ResolvedBy,
+		model.Note,
+		model.CreatedAt,
+		model.ResolvedAt,
+	)
+}
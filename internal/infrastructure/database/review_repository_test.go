@@ -0,0 +1,87 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/review"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestReviewRepository_QueueAndResolve(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewReviewRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	underpaid, err := review.NewReview(
+		"review-1", "merchant-1", review.KindUnderpayment, "invoice-1", "payment-1",
+		"10.00", "8.50", "USDT", "received 8.50, invoice requires 10.00",
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, underpaid))
+
+	orphaned, err := review.NewReview(
+		"review-2", "merchant-1", review.KindOrphaned, "invoice-2", "payment-2",
+		"5.00", "5.00", "USDT", "reorg",
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, orphaned))
+
+	other, err := review.NewReview(
+		"review-3", "merchant-2", review.KindScreening, "invoice-3", "payment-3", "", "1.00", "USDT", "held",
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, other))
+
+	t.Run("find open by payment", func(t *testing.T) {
+		found, err := repo.FindOpenByPayment(ctx, review.KindUnderpayment, "payment-1")
+		require.NoError(t, err)
+		assert.Equal(t, "review-1", found.ID())
+		assert.Equal(t, "8.50", found.ReceivedAmount())
+
+		_, err = repo.FindOpenByPayment(ctx, review.KindScreening, "payment-1")
+		require.ErrorIs(t, err, review.ErrReviewNotFound)
+	})
+
+	t.Run("list filters by merchant and kind", func(t *testing.T) {
+		resp, err := repo.List(ctx, &review.ListReviewsRequest{MerchantID: "merchant-1", Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, 2, resp.Total)
+		assert.Equal(t, "review-1", resp.Reviews[0].ID())
+
+		resp, err = repo.List(ctx, &review.ListReviewsRequest{
+			MerchantID: "merchant-1",
+			Kind:       review.KindOrphaned,
+			Limit:      10,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, resp.Total)
+		assert.Equal(t, "review-2", resp.Reviews[0].ID())
+	})
+
+	t.Run("resolution is persisted", func(t *testing.T) {
+		require.NoError(t, underpaid.Resolve(review.ResolutionWriteOff, "user-1", "customer unreachable"))
+		require.NoError(t, repo.Update(ctx, underpaid))
+
+		found, err := repo.FindByID(ctx, "review-1")
+		require.NoError(t, err)
+		assert.Equal(t, review.StatusResolved, found.Status())
+		assert.Equal(t, review.ResolutionWriteOff, found.Resolution())
+		assert.Equal(t, "customer unreachable", found.Note())
+		assert.NotNil(t, found.ResolvedAt())
+
+		_, err = repo.FindOpenByPayment(ctx, review.KindUnderpayment, "payment-1")
+		require.ErrorIs(t, err, review.ErrReviewNotFound)
+
+		resp, err := repo.List(ctx, &review.ListReviewsRequest{
+			MerchantID: "merchant-1",
+			Status:     review.StatusOpen,
+			Limit:      10,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.Total)
+	})
+}
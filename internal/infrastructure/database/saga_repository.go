@@ -0,0 +1,157 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/saga"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SagaRepository implements the saga.Repository interface using GORM.
+type SagaRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewSagaRepository creates a new saga repository.
+func NewSagaRepository(db *gorm.DB, logger *zap.Logger) saga.Repository {
+	return &SagaRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a saga to the database.
+func (r *SagaRepository) Save(ctx context.Context, s *saga.Saga) error {
+	if err := r.db.WithContext(ctx).Create(r.toModel(s)).Error; err != nil {
+		return fmt.Errorf("failed to save saga: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing saga.
+func (r *SagaRepository) Update(ctx context.Context, s *saga.Saga) error {
+	if err := r.db.WithContext(ctx).Save(r.toModel(s)).Error; err != nil {
+		return fmt.Errorf("failed to update saga: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a saga by ID.
+func (r *SagaRepository) FindByID(ctx context.Context, id string) (*saga.Saga, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+// FindByPaymentID finds the saga started by a confirmed payment.
+func (r *SagaRepository) FindByPaymentID(ctx context.Context, paymentID string) (*saga.Saga, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("payment_id = ?", paymentID))
+}
+
+// FindActiveByInvoiceID finds the saga of an invoice that is still running or compensating.
+func (r *SagaRepository) FindActiveByInvoiceID(ctx context.Context, invoiceID string) (*saga.Saga, error) {
+	return r.findOne(r.db.WithContext(ctx).
+		Where("invoice_id = ? AND status IN ?", invoiceID,
+			[]string{string(saga.StatusRunning), string(saga.StatusCompensating)}).
+		Order("created_at DESC"))
+}
+
+// FindBySettlementID finds the most recent saga that created a settlement.
+func (r *SagaRepository) FindBySettlementID(ctx context.Context, settlementID string) (*saga.Saga, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("settlement_id = ?", settlementID).Order("created_at DESC"))
+}
+
+// List retrieves sagas matching the filter, newest first.
+func (r *SagaRepository) List(ctx context.Context, req *saga.ListSagasRequest) (*saga.ListSagasResponse, error) {
+	query := r.db.WithContext(ctx)
+	if req.InvoiceID != "" {
+		query = query.Where("invoice_id = ?", req.InvoiceID)
+	}
+	if req.MerchantID != "" {
+		query = query.Where("merchant_id = ?", req.MerchantID)
+	}
+	if req.Status != "" {
+		query = query.Where("status = ?", string(req.Status))
+	}
+
+	var models []SagaModel
+	if err := query.Scopes(keysetPage("created_at", req.Cursor, 0, req.Limit)).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list sagas: %w", err)
+	}
+	models, next := nextPage(models, req.Limit, func(model *SagaModel) (time.Time, string) {
+		return model.CreatedAt, model.ID
+	})
+
+	sagas := make([]*saga.Saga, len(models))
+	for i := range models {
+		s, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert saga model to domain: %w", err)
+		}
+		sagas[i] = s
+	}
+
+	return &saga.ListSagasResponse{
+		Sagas:      sagas,
+		Limit:      req.Limit,
+		NextCursor: next,
+	}, nil
+}
+
+// findOne finds the first saga a query matches.
+func (r *SagaRepository) findOne(query *gorm.DB) (*saga.Saga, error) {
+	var model SagaModel
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, saga.ErrSagaNotFound
+		}
+		return nil, fmt.Errorf("failed to find saga: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// toModel converts a domain saga to a database model.
+func (r *SagaRepository) toModel(s *saga.Saga) *SagaModel {
+	return &SagaModel{
+		ID:             s.ID(),
+		InvoiceID:      s.InvoiceID(),
+		PaymentID:      s.PaymentID(),
+		MerchantID:     s.MerchantID(),
+		SettlementID:   s.SettlementID(),
+		PayoutID:       s.PayoutID(),
+		Status:         string(s.Status()),
+		Step:           string(s.Step()),
+		PayoutFailures: s.PayoutFailures(),
+		Reason:         s.Reason(),
+		LastError:      s.LastError(),
+		CreatedAt:      s.CreatedAt(),
+		UpdatedAt:      s.UpdatedAt(),
+		CompletedAt:    s.CompletedAt(),
+	}
+}
+
+// toDomain converts a database model to a domain saga.
+func (r *SagaRepository) toDomain(model *SagaModel) (*saga.Saga, error) {
+	return saga.RestoreSaga(
+		model.ID,
+		model.InvoiceID,
+		model.PaymentID,
+		model.MerchantID,
+		model.SettlementID,
+		model.PayoutID,
+		saga.Status(model.Status),
+		saga.Step(model.Step),
+		model.PayoutFailures,
+		model.Reason,
+		model.LastError,
+		model.CreatedAt,
+		model.UpdatedAt,
+		model.CompletedAt,
+	)
+}
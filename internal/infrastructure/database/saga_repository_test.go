@@ -0,0 +1,80 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/saga"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSagaRepository(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewSagaRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	save := func(invoiceID, paymentID string) *saga.Saga {
+		s, err := saga.NewSaga(uuid.NewString(), invoiceID, paymentID, "merchant-1")
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, s))
+		return s
+	}
+	settled := save("invoice-1", "payment-1")
+	require.NoError(t, settled.MarkInvoicePaid())
+	require.NoError(t, settled.MarkSettled("settlement-1"))
+	_, err := settled.RecordPayoutFailure("payout-1", "nonce too low")
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(ctx, settled))
+	save("invoice-2", "payment-2")
+
+	found, err := repo.FindByPaymentID(ctx, "payment-1")
+	require.NoError(t, err)
+	assert.Equal(t, settled.ID(), found.ID())
+	assert.Equal(t, saga.StatusRunning, found.Status())
+	assert.Equal(t, saga.StepSettled, found.Step())
+	assert.Equal(t, "payout-1", found.PayoutID())
+	assert.Equal(t, 1, found.PayoutFailures())
+	assert.Equal(t, "nonce too low", found.LastError())
+
+	found, err = repo.FindBySettlementID(ctx, "settlement-1")
+	require.NoError(t, err)
+	assert.Equal(t, settled.ID(), found.ID())
+
+	found, err = repo.FindActiveByInvoiceID(ctx, "invoice-1")
+	require.NoError(t, err)
+	assert.Equal(t, settled.ID(), found.ID())
+
+	require.NoError(t, settled.Compensate("Payout failed 1 times: nonce too low"))
+	require.NoError(t, settled.MarkSettlementReversed())
+	require.NoError(t, settled.MarkInvoiceReopened())
+	require.NoError(t, repo.Update(ctx, settled))
+	_, err = repo.FindActiveByInvoiceID(ctx, "invoice-1")
+	require.ErrorIs(t, err, saga.ErrSagaNotFound)
+
+	found, err = repo.FindByID(ctx, settled.ID())
+	require.NoError(t, err)
+	assert.Equal(t, saga.StatusCompensated, found.Status())
+	assert.Equal(t, "Payout failed 1 times: nonce too low", found.Reason())
+	assert.NotNil(t, found.CompletedAt())
+
+	resp, err := repo.List(ctx, &saga.ListSagasRequest{Status: saga.StatusRunning, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, resp.Sagas, 1)
+	assert.Equal(t, "invoice-2", resp.Sagas[0].InvoiceID())
+
+	resp, err = repo.List(ctx, &saga.ListSagasRequest{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.Sagas, 1)
+	require.NotNil(t, resp.NextCursor)
+	resp, err = repo.List(ctx, &saga.ListSagasRequest{Limit: 1, Cursor: resp.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, resp.Sagas, 1)
+	assert.Nil(t, resp.NextCursor)
+
+	_, err = repo.FindByPaymentID(ctx, "payment-3")
+	require.ErrorIs(t, err, saga.ErrSagaNotFound)
+}
@@ -0,0 +1,175 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/compliance"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ScreeningRepository implements the compliance.Repository interface using GORM.
+type ScreeningRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewScreeningRepository creates a new payment screening repository.
+func NewScreeningRepository(db *gorm.DB, logger *zap.Logger) compliance.Repository {
+	return &ScreeningRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a screening to the database.
+func (r *ScreeningRepository) Save(ctx context.Context, screening *compliance.Screening) error {
+	model, err := r.toModel(screening)
+	if err != nil {
+		return fmt.Errorf("failed to convert screening to model: %w", err)
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save screening: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a screening by ID.
+func (r *ScreeningRepository) FindByID(ctx context.Context, id string) (*compliance.Screening, error) {
+	return r.findOne(ctx, "id = ?", id)
+}
+
+// FindByPaymentID finds the screening of a payment.
+func (r *ScreeningRepository) FindByPaymentID(ctx context.Context, paymentID string) (*compliance.Screening, error) {
+	return r.findOne(ctx, "payment_id = ?", paymentID)
+}
+
+// List retrieves screenings matching the filter, newest first.
+func (r *ScreeningRepository) List(
+	ctx context.Context,
+	req *compliance.ListScreeningsRequest,
+) (*compliance.ListScreeningsResponse, error) {
+	query := r.db.WithContext(ctx).Model(&PaymentScreeningModel{})
+	if req.MerchantID != "" {
+		query = query.Where("merchant_id = ?", req.MerchantID)
+	}
+	if req.Status != "" {
+		query = query.Where("status = ?", string(req.Status))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count screenings: %w", err)
+	}
+
+	var models []PaymentScreeningModel
+	if err := query.Order("screened_at DESC").Limit(req.Limit).Offset(req.Offset).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list screenings: %w", err)
+	}
+
+	screenings := make([]*compliance.Screening, len(models))
+	for i := range models {
+		screening, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert screening model to domain: %w", err)
+		}
+		screenings[i] = screening
+	}
+
+	return &compliance.ListScreeningsResponse{
+		Screenings: screenings,
+		Total:      int(total),
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	}, nil
+}
+
+// Update updates an existing screening.
+func (r *ScreeningRepository) Update(ctx context.Context, screening *compliance.Screening) error {
+	model, err := r.toModel(screening)
+	if err != nil {
+		return fmt.Errorf("failed to convert screening to model: %w", err)
+	}
+
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to update screening: %w", err)
+	}
+
+	return nil
+}
+
+// findOne finds a single screening matching the condition.
+func (r *ScreeningRepository) findOne(ctx context.Context, query string, arg string) (*compliance.Screening, error) {
+	var model PaymentScreeningModel
+	if err := r.db.WithContext(ctx).Where(query, arg).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, compliance.ErrScreeningNotFound
+		}
+		return nil, fmt.Errorf("failed to find screening: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// toModel converts a domain screening to a database model.
+func (r *ScreeningRepository) toModel(screening *compliance.Screening) (*PaymentScreeningModel, error) {
+	categoriesJSON, err := json.Marshal(screening.Categories())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal categories: %w", err)
+	}
+
+	return &PaymentScreeningModel{
+		ID:         screening.ID(),
+		MerchantID: screening.MerchantID(),
+		PaymentID:  screening.PaymentID(),
+		InvoiceID:  screening.InvoiceID(),
+		Address:    screening.Address(),
+		Network:    screening.Network(),
+		Provider:   screening.Provider(),
+		RiskLevel:  string(screening.RiskLevel()),
+		RiskScore:  screening.RiskScore(),
+		Categories: string(categoriesJSON),
+		Reference:  screening.Reference(),
+		Failure:    screening.Failure(),
+		Status:     string(screening.Status()),
+		ReviewedBy: screening.ReviewedBy(),
+		ReviewNote: screening.ReviewNote(),
+		ScreenedAt: screening.ScreenedAt(),
+		ReviewedAt: screening.ReviewedAt(),
+	}, nil
+}
+
+// toDomain converts a database model to a domain screening.
+func (r *ScreeningRepository) toDomain(model *PaymentScreeningModel) (*compliance.Screening, error) {
+	var categories []string
+	if model.Categories != "" {
+		if err := json.Unmarshal([]byte(model.Categories), &categories); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal categories: %w", err)
+		}
+	}
+
+	return compliance.RestoreScreening(
+		model.ID,
+		model.MerchantID,
+		model.PaymentID,
+		model.InvoiceID,
+		model.Address,
+		model.Network,
+		model.Provider,
+		compliance.RiskLevel(model.RiskLevel),
+		model.RiskScore,
+		categories,
+		model.Reference,
+		model.Failure,
+		compliance.ScreeningStatus(model.Status),
+		model.ReviewedBy,
+		model.ReviewNote,
+		model.ScreenedAt,
+		model.ReviewedAt,
+	)
+}
@@ -0,0 +1,93 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/compliance"
+	"crypto-checkout/internal/infrastructure/database"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestScreeningRepository_SaveListAndReview(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewScreeningRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	cleared, err := compliance.NewScreening(
+		"screening-1", "merchant-1", "payment-1", "invoice-1", "TAddrLow", "tron", "chainalysis",
+		&compliance.ScreeningResult{RiskLevel: compliance.RiskLevelLow},
+		compliance.RiskLevelHigh,
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, cleared))
+
+	held, err := compliance.NewScreening(
+		"screening-2", "merchant-1", "payment-2", "invoice-1", "TAddrHigh", "tron", "chainalysis",
+		&compliance.ScreeningResult{
+			RiskLevel:  compliance.RiskLevelSevere,
+			Categories: []string{"sanctions"},
+			Reference:  "TAddrHigh",
+		},
+		compliance.RiskLevelHigh,
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, held))
+
+	failed, err := compliance.NewFailedScreening(
+		"screening-3", "merchant-2", "payment-3", "invoice-2", "TAddrOther", "tron", "elliptic",
+		errors.New("timeout"),
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, failed))
+
+	t.Run("find by payment", func(t *testing.T) {
+		found, err := repo.FindByPaymentID(ctx, "payment-2")
+		require.NoError(t, err)
+		assert.Equal(t, "screening-2", found.ID())
+		assert.Equal(t, compliance.RiskLevelSevere, found.RiskLevel())
+		assert.Equal(t, []string{"sanctions"}, found.Categories())
+		assert.True(t, found.IsHeld())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := repo.FindByID(ctx, "missing")
+		require.ErrorIs(t, err, compliance.ErrScreeningNotFound)
+	})
+
+	t.Run("list held for merchant", func(t *testing.T) {
+		resp, err := repo.List(ctx, &compliance.ListScreeningsRequest{
+			MerchantID: "merchant-1",
+			Status:     compliance.ScreeningStatusHeld,
+			Limit:      10,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, resp.Total)
+		assert.Equal(t, "screening-2", resp.Screenings[0].ID())
+	})
+
+	t.Run("review decision is persisted", func(t *testing.T) {
+		require.NoError(t, held.Approve("user-1", "known exchange"))
+		require.NoError(t, repo.Update(ctx, held))
+
+		found, err := repo.FindByID(ctx, "screening-2")
+		require.NoError(t, err)
+		assert.Equal(t, compliance.ScreeningStatusApproved, found.Status())
+		assert.Equal(t, "user-1", found.ReviewedBy())
+		assert.Equal(t, "known exchange", found.ReviewNote())
+		require.NotNil(t, found.ReviewedAt())
+
+		require.ErrorIs(t, found.Reject("user-1", ""), compliance.ErrScreeningNotHeld)
+	})
+
+	t.Run("failed screening is held with unknown risk", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, "screening-3")
+		require.NoError(t, err)
+		assert.Equal(t, compliance.RiskLevelUnknown, found.RiskLevel())
+		assert.Equal(t, "timeout", found.Failure())
+		assert.True(t, found.IsHeld())
+	})
+}
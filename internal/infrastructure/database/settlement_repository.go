@@ -0,0 +1,542 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/settlement"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SettlementRepository implements the settlement.Repository interface using GORM.
+type SettlementRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewSettlementRepository creates a new settlement repository.
+func NewSettlementRepository(db *gorm.DB, logger *zap.Logger) settlement.Repository {
+	return &SettlementRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a settlement and the shares split from it to the database in one transaction.
+func (r *SettlementRepository) Save(
+	ctx context.Context,
+	s *settlement.Settlement,
+	splits ...*settlement.Settlement,
+) error {
+	models := []*SettlementModel{r.toModel(s)}
+	for _, split := range splits {
+		models = append(models, r.toModel(split))
+	}
+	if err := r.db.WithContext(ctx).Create(models).Error; err != nil {
+		return fmt.Errorf("failed to save settlement: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a settlement by ID.
+func (r *SettlementRepository) FindByID(ctx context.Context, id string) (*settlement.Settlement, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+// FindByInvoiceID finds the latest settlement of an invoice for the invoice's merchant.
+func (r *SettlementRepository) FindByInvoiceID(ctx context.Context, invoiceID string) (*settlement.Settlement, error) {
+	return r.findOne(r.db.WithContext(ctx).
+		Where("invoice_id = ? AND split_of IS NULL", invoiceID).
+		Order("created_at DESC"))
+}
+
+// FindSplits finds the shares split from a settlement, ordered by ID.
+func (r *SettlementRepository) FindSplits(ctx context.Context, settlementID string) ([]*settlement.Settlement, error) {
+	var models []SettlementModel
+	if err := r.db.WithContext(ctx).Where("split_of = ?", settlementID).Order("id ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find settlement splits: %w", err)
+	}
+
+	return r.toDomainList(models)
+}
+
+// SaveReversal updates a reversed settlement and records its reversal entry in one transaction.
+func (r *SettlementRepository) SaveReversal(
+	ctx context.Context,
+	s *settlement.Settlement,
+	reversal *settlement.Reversal,
+) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(r.toModel(s)).Error; err != nil {
+			return err
+		}
+		return tx.Create(r.toReversalModel(reversal)).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save settlement reversal: %w", err)
+	}
+
+	return nil
+}
+
+// FindReversal finds the reversal entry of a settlement.
+func (r *SettlementRepository) FindReversal(ctx context.Context, settlementID string) (*settlement.Reversal, error) {
+	var model SettlementReversalModel
+	if err := r.db.WithContext(ctx).Where("settlement_id = ?", settlementID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, settlement.ErrReversalNotFound
+		}
+		return nil, fmt.Errorf("failed to find settlement reversal: %w", err)
+	}
+
+	return r.toReversal(&model)
+}
+
+// FindPending finds up to limit pending settlements, oldest first.
+func (r *SettlementRepository) FindPending(ctx context.Context, limit int) ([]*settlement.Settlement, error) {
+	var models []SettlementModel
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", string(settlement.StatusPending)).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find pending settlements: %w", err)
+	}
+
+	return r.toDomainList(models)
+}
+
+// List retrieves settlements matching the filter, newest first, with totals over the whole filter.
+func (r *SettlementRepository) List(
+	ctx context.Context,
+	req *settlement.ListSettlementsRequest,
+) (*settlement.ListSettlementsResponse, error) {
+	filter := func(db *gorm.DB) *gorm.DB {
+		db = db.Model(&SettlementModel{}).Where("merchant_id = ?", req.MerchantID)
+		if req.Status != "" {
+			db = db.Where("status = ?", string(req.Status))
+		}
+		if req.From != nil {
+			db = db.Where("created_at >= ?", *req.From)
+		}
+		if req.To != nil {
+			db = db.Where("created_at <= ?", *req.To)
+		}
+		return db
+	}
+
+	summary, err := r.summarize(r.db.WithContext(ctx).Scopes(filter))
+	if err != nil {
+		return nil, err
+	}
+	if req.Status == "" {
+		if err := r.summarizeReversals(r.db.WithContext(ctx), req, &summary); err != nil {
+			return nil, err
+		}
+	}
+
+	var models []SettlementModel
+	if err := r.db.WithContext(ctx).Scopes(filter, keysetPage("created_at", req.Cursor, 0, req.Limit)).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list settlements: %w", err)
+	}
+	models, next := nextPage(models, req.Limit, func(model *SettlementModel) (time.Time, string) {
+		return model.CreatedAt, model.ID
+	})
+
+	settlements, err := r.toDomainList(models)
+	if err != nil {
+		return nil, err
+	}
+
+	return &settlement.ListSettlementsResponse{
+		Settlements: settlements,
+		Summary:     summary,
+		Limit:       req.Limit,
+		NextCursor:  next,
+	}, nil
+}
+
+// Update updates an existing settlement.
+func (r *SettlementRepository) Update(ctx context.Context, s *settlement.Settlement) error {
+	if err := r.db.WithContext(ctx).Save(r.toModel(s)).Error; err != nil {
+		return fmt.Errorf("failed to update settlement: %w", err)
+	}
+
+	return nil
+}
+
+// FindAwaitingPayout finds up to limit settlements awaiting an on-chain payout, oldest first.
+func (r *SettlementRepository) FindAwaitingPayout(ctx context.Context, limit int) ([]*settlement.Settlement, error) {
+	var models []SettlementModel
+	if err := r.db.WithContext(ctx).Scopes(awaitingPayout).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find settlements awaiting payout: %w", err)
+	}
+
+	return r.toDomainList(models)
+}
+
+// AssignPayout sets the payout of the given settlements that still await one. The condition is
+// part of the update, so two payouts cannot claim the same settlement.
+func (r *SettlementRepository) AssignPayout(ctx context.Context, payoutID string, settlementIDs []string) (int, error) {
+	result := r.db.WithContext(ctx).Model(&SettlementModel{}).Scopes(awaitingPayout).
+		Where("id IN ?", settlementIDs).
+		Update("payout_id", payoutID)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to assign settlements to payout: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// ReleasePayout clears the payout of its settlements.
+func (r *SettlementRepository) ReleasePayout(ctx context.Context, payoutID string) error {
+	if err := r.db.WithContext(ctx).Model(&SettlementModel{}).
+		Where("payout_id = ?", payoutID).
+		Update("payout_id", nil).Error; err != nil {
+		return fmt.Errorf("failed to release settlements from payout: %w", err)
+	}
+
+	return nil
+}
+
+// awaitingPayout restricts a query to settlements completed in crypto that no payout includes yet.
+func awaitingPayout(db *gorm.DB) *gorm.DB {
+	return db.Where("status = ? AND conversion_order_id = ? AND payout_id IS NULL",
+		string(settlement.StatusCompleted), "")
+}
+
+// summarize totals the amounts of the settlements matching a query. Amounts are summed as decimals
+// rather than in SQL, where some drivers would return them as floating point.
+func (r *SettlementRepository) summarize(query *gorm.DB) (settlement.Summary, error) {
+	var rows []struct {
+		GrossAmount string
+		FeeAmount   string
+		NetAmount   string
+	}
+	if err := query.Select("gross_amount", "fee_amount", "net_amount").Find(&rows).Error; err != nil {
+		return settlement.Summary{}, fmt.Errorf("failed to summarize settlements: %w", err)
+	}
+
+	var summary settlement.Summary
+	for _, row := range rows {
+		gross, err := decimal.NewFromString(row.GrossAmount)
+		if err != nil {
+			return settlement.Summary{}, fmt.Errorf("invalid gross amount: %w", err)
+		}
+		fee, err := decimal.NewFromString(row.FeeAmount)
+		if err != nil {
+			return settlement.Summary{}, fmt.Errorf("invalid fee amount: %w", err)
+		}
+		net, err := decimal.NewFromString(row.NetAmount)
+		if err != nil {
+			return settlement.Summary{}, fmt.Errorf("invalid net amount: %w", err)
+		}
+		summary.Add(gross, fee, net)
+	}
+	return summary, nil
+}
+
+// summarizeReversals nets the reversal entries recorded for the merchant in the listed period into the totals.
+func (r *SettlementRepository) summarizeReversals(
+	db *gorm.DB,
+	req *settlement.ListSettlementsRequest,
+	summary *settlement.Summary,
+) error {
+	query := db.Model(&SettlementReversalModel{}).Where("merchant_id = ?", req.MerchantID)
+	if req.From != nil {
+		query = query.Where("created_at >= ?", *req.From)
+	}
+	if req.To != nil {
+		query = query.Where("created_at <= ?", *req.To)
+	}
+
+	var models []SettlementReversalModel
+	if err := query.Find(&models).Error; err != nil {
+		return fmt.Errorf("failed to summarize settlement reversals: %w", err)
+	}
+	for i := range models {
+		reversal, err := r.toReversal(&models[i])
+		if err != nil {
+			return err
+		}
+		summary.AddReversal(reversal.GrossAmount(), reversal.FeeAmount(), reversal.NetAmount())
+	}
+	return nil
+}
+
+// findOne finds a single settlement matching the query.
+func (r *SettlementRepository) findOne(query *gorm.DB) (*settlement.Settlement, error) {
+	var model SettlementModel
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, settlement.ErrSettlementNotFound
+		}
+		return nil, fmt.Errorf("failed to find settlement: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// toDomainList converts database models to domain settlements.
+func (r *SettlementRepository) toDomainList(models []SettlementModel) ([]*settlement.Settlement, error) {
+	settlements := make([]*settlement.Settlement, len(models))
+	for i := range models {
+		s, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert settlement model to domain: %w", err)
+		}
+		settlements[i] = s
+	}
+	return settlements, nil
+}
+
+// toModel converts a domain settlement to a database model.
+func (r *SettlementRepository) toModel(s *settlement.Settlement) *SettlementModel {
+	model := &SettlementModel{
+		ID:            s.ID(),
+		InvoiceID:     s.InvoiceID(),
+		MerchantID:    s.MerchantID(),
+		GrossAmount:   s.GrossAmount().String(),
+		FeePercentage: s.FeePercentage().String(),
+		FeeAmount:     s.FeeAmount().String(),
+		NetAmount:     s.NetAmount().String(),
+		Currency:      s.Currency(),
+		Status:        string(s.Status()),
+		FailureReason: s.FailureReason(),
+		CreatedAt:     s.CreatedAt(),
+		SettledAt:     s.SettledAt(),
+	}
+	if payoutID := s.PayoutID(); payoutID != "" {
+		model.PayoutID = &payoutID
+	}
+	if splitOf := s.SplitOf(); splitOf != "" {
+		model.SplitOf = &splitOf
+	}
+	if tier := s.FeeTier(); tier != nil {
+		if tierJSON, err := json.Marshal(toFeeTierRecord(tier)); err == nil {
+			feeTier := string(tierJSON)
+			model.FeeTier = &feeTier
+		}
+	}
+
+	if conversion := s.Conversion(); conversion != nil {
+		amount := conversion.Amount().String()
+		fiatAmount := conversion.FiatAmount().String()
+		fee := conversion.Fee().String()
+		submittedAt := conversion.SubmittedAt()
+
+		model.ConversionExchange = conversion.Exchange()
+		model.ConversionOrderID = conversion.OrderID()
+		model.ConversionStatus = string(conversion.Status())
+		model.ConversionFiatCurrency = conversion.FiatCurrency()
+		model.ConversionAmount = &amount
+		model.ConversionFiatAmount = &fiatAmount
+		model.ConversionFee = &fee
+		model.ConversionFailureReason = conversion.FailureReason()
+		model.ConversionSubmittedAt = &submittedAt
+		model.ConversionCompletedAt = conversion.CompletedAt()
+	}
+	return model
+}
+
+// toDomain converts a database model to a domain settlement.
+func (r *SettlementRepository) toDomain(model *SettlementModel) (*settlement.Settlement, error) {
+	amounts := make([]decimal.Decimal, 4)
+	for i, value := range []string{model.GrossAmount, model.FeePercentage, model.FeeAmount, model.NetAmount} {
+		amount, err := decimal.NewFromString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid settlement amount %q: %w", value, err)
+		}
+		amounts[i] = amount
+	}
+
+	conversion, err := r.toConversion(model)
+	if err != nil {
+		return nil, err
+	}
+
+	var payoutID, splitOf string
+	if model.PayoutID != nil {
+		payoutID = *model.PayoutID
+	}
+	if model.SplitOf != nil {
+		splitOf = *model.SplitOf
+	}
+
+	restored, err := settlement.RestoreSettlement(
+		model.ID,
+		model.InvoiceID,
+		model.MerchantID,
+		amounts[0],
+		amounts[1],
+		amounts[2],
+		amounts[3],
+		model.Currency,
+		settlement.Status(model.Status),
+		conversion,
+		model.FailureReason,
+		payoutID,
+		splitOf,
+		model.CreatedAt,
+		model.SettledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if model.FeeTier != nil {
+		var record feeTierRecord
+		if err := json.Unmarshal([]byte(*model.FeeTier), &record); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal fee tier: %w", err)
+		}
+		tier, err := record.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		restored.SetFeeTier(tier)
+	}
+	return restored, nil
+}
+
+// feeTierRecord is the JSONB representation of how a settlement's platform fee was chosen.
+type feeTierRecord struct {
+	Schedule       string `json:"schedule,omitempty"`
+	Kind           string `json:"kind"`
+	Name           string `json:"name"`
+	Volume         string `json:"volume,omitempty"`
+	MinimumApplied bool   `json:"minimum_applied,omitempty"`
+}
+
+// toFeeTierRecord converts a fee tier to its JSONB representation.
+func toFeeTierRecord(tier *settlement.FeeTier) feeTierRecord {
+	record := feeTierRecord{
+		Schedule:       tier.Schedule,
+		Kind:           string(tier.Kind),
+		Name:           tier.Name,
+		MinimumApplied: tier.MinimumApplied,
+	}
+	if tier.Schedule != "" {
+		record.Volume = tier.Volume.String()
+	}
+	return record
+}
+
+// toDomain restores a fee tier from its JSONB representation.
+func (r feeTierRecord) toDomain() (*settlement.FeeTier, error) {
+	tier := &settlement.FeeTier{
+		Schedule:       r.Schedule,
+		Kind:           settlement.FeeTierKind(r.Kind),
+		Name:           r.Name,
+		MinimumApplied: r.MinimumApplied,
+	}
+	if !tier.Kind.IsValid() {
+		return nil, fmt.Errorf("invalid fee tier kind %q", r.Kind)
+	}
+	if r.Volume != "" {
+		volume, err := decimal.NewFromString(r.Volume)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee tier volume %q: %w", r.Volume, err)
+		}
+		tier.Volume = volume
+	}
+	return tier, nil
+}
+
+// toConversion restores the fiat conversion stored with a settlement, if there is one.
+func (r *SettlementRepository) toConversion(model *SettlementModel) (*settlement.Conversion, error) {
+	if model.ConversionOrderID == "" {
+		return nil, nil
+	}
+
+	amounts := make([]decimal.Decimal, 3)
+	for i, value := range []*string{model.ConversionAmount, model.ConversionFiatAmount, model.ConversionFee} {
+		if value == nil {
+			continue
+		}
+		amount, err := decimal.NewFromString(*value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid conversion amount %q: %w", *value, err)
+		}
+		amounts[i] = amount
+	}
+
+	var submittedAt time.Time
+	if model.ConversionSubmittedAt != nil {
+		submittedAt = *model.ConversionSubmittedAt
+	}
+
+	return settlement.RestoreConversion(
+		model.ConversionExchange,
+		model.ConversionOrderID,
+		settlement.ConversionStatus(model.ConversionStatus),
+		model.ConversionFiatCurrency,
+		amounts[0],
+		amounts[1],
+		amounts[2],
+		model.ConversionFailureReason,
+		submittedAt,
+		model.ConversionCompletedAt,
+	)
+}
+
+// toReversalModel converts a settlement reversal to a database model.
+func (r *SettlementRepository) toReversalModel(reversal *settlement.Reversal) *SettlementReversalModel {
+	model := &SettlementReversalModel{
+		ID:           reversal.ID(),
+		SettlementID: reversal.SettlementID(),
+		InvoiceID:    reversal.InvoiceID(),
+		MerchantID:   reversal.MerchantID(),
+		PaymentID:    reversal.PaymentID(),
+		GrossAmount:  reversal.GrossAmount().String(),
+		FeeAmount:    reversal.FeeAmount().String(),
+		NetAmount:    reversal.NetAmount().String(),
+		Currency:     reversal.Currency(),
+		Reason:       reversal.Reason(),
+		CreatedAt:    reversal.CreatedAt(),
+	}
+	if payoutID := reversal.PayoutID(); payoutID != "" {
+		model.PayoutID = &payoutID
+	}
+	return model
+}
+
+// toReversal converts a database model to a settlement reversal.
+func (r *SettlementRepository) toReversal(model *SettlementReversalModel) (*settlement.Reversal, error) {
+	amounts := make([]decimal.Decimal, 3)
+	for i, value := range []string{model.GrossAmount, model.FeeAmount, model.NetAmount} {
+		amount, err := decimal.NewFromString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reversal amount %q: %w", value, err)
+		}
+		amounts[i] = amount
+	}
+
+	var payoutID string
+	if model.PayoutID != nil {
+		payoutID = *model.PayoutID
+	}
+
+	return settlement.RestoreReversal(
+		model.ID,
+		model.SettlementID,
+		model.InvoiceID,
+		model.MerchantID,
+		model.PaymentID,
+		amounts[0],
+		amounts[1],
+		amounts[2],
+		model.Currency,
+		model.Reason,
+		payoutID,
+		model.CreatedAt,
+	)
+}
@@ -0,0 +1,224 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSettlementRepository_ConversionAndList(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewSettlementRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	newSettlement := func(id, invoiceID, merchantID, gross string) *settlement.Settlement {
+		s, err := settlement.NewSettlement(
+			id, invoiceID, merchantID, decimal.RequireFromString(gross), "USDT", decimal.RequireFromString("1"),
+		)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, s))
+		return s
+	}
+
+	converted := newSettlement("settlement-1", "invoice-1", "merchant-1", "100")
+	completed := newSettlement("settlement-2", "invoice-2", "merchant-1", "50")
+	newSettlement("settlement-3", "invoice-3", "merchant-2", "10")
+
+	require.NoError(t, completed.Complete())
+	require.NoError(t, repo.Update(ctx, completed))
+
+	conversion, err := settlement.NewConversion("kraken", "order-1", "USD", converted.NetAmount())
+	require.NoError(t, err)
+	require.NoError(t, converted.StartConversion(conversion))
+	require.NoError(t, repo.Update(ctx, converted))
+
+	t.Run("pending settlements keep their conversion", func(t *testing.T) {
+		pending, err := repo.FindPending(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+
+		found, err := repo.FindByInvoiceID(ctx, "invoice-1")
+		require.NoError(t, err)
+		require.NotNil(t, found.Conversion())
+		assert.Equal(t, "order-1", found.Conversion().OrderID())
+		assert.Equal(t, "99", found.Conversion().Amount().String())
+	})
+
+	t.Run("filled conversion is persisted", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, "settlement-1")
+		require.NoError(t, err)
+		_, err = found.ApplyOrder(&settlement.Order{
+			ID:       "order-1",
+			State:    settlement.OrderStateFilled,
+			Proceeds: decimal.RequireFromString("98.90"),
+			Fee:      decimal.RequireFromString("0.25"),
+		})
+		require.NoError(t, err)
+		require.NoError(t, repo.Update(ctx, found))
+
+		found, err = repo.FindByID(ctx, "settlement-1")
+		require.NoError(t, err)
+		assert.Equal(t, settlement.StatusCompleted, found.Status())
+		assert.Equal(t, "98.65", found.Conversion().FiatAmount().String())
+		assert.Equal(t, "0.25", found.Conversion().Fee().String())
+	})
+
+	t.Run("list filters by merchant and totals the filter", func(t *testing.T) {
+		resp, err := repo.List(ctx, &settlement.ListSettlementsRequest{MerchantID: "merchant-1", Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, resp.Settlements, 1)
+		require.NotNil(t, resp.NextCursor)
+		assert.Equal(t, 2, resp.Summary.Count)
+		assert.Equal(t, "150", resp.Summary.TotalGrossAmount.String())
+		assert.Equal(t, "1.5", resp.Summary.TotalFeeAmount.String())
+
+		next, err := repo.List(ctx, &settlement.ListSettlementsRequest{
+			MerchantID: "merchant-1",
+			Limit:      1,
+			Cursor:     resp.NextCursor,
+		})
+		require.NoError(t, err)
+		require.Len(t, next.Settlements, 1)
+		assert.NotEqual(t, resp.Settlements[0].ID(), next.Settlements[0].ID())
+		assert.Nil(t, next.NextCursor)
+	})
+
+	t.Run("missing settlement", func(t *testing.T) {
+		_, err := repo.FindByID(ctx, "missing")
+		require.ErrorIs(t, err, settlement.ErrSettlementNotFound)
+	})
+}
+
+func TestSettlementRepository_Reversal(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewSettlementRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	original, err := settlement.NewSettlement(
+		"settlement-1", "invoice-1", "merchant-1", decimal.RequireFromString("100"), "USDT",
+		decimal.RequireFromString("1"),
+	)
+	require.NoError(t, err)
+	require.NoError(t, original.Complete())
+	require.NoError(t, repo.Save(ctx, original))
+
+	reversal, err := original.Reverse("reversal-1", "payment-1", "removed by a reorganization")
+	require.NoError(t, err)
+	require.NoError(t, repo.SaveReversal(ctx, original, reversal))
+
+	found, err := repo.FindReversal(ctx, "settlement-1")
+	require.NoError(t, err)
+	assert.Equal(t, "-99", found.NetAmount().String())
+	assert.Equal(t, "payment-1", found.PaymentID())
+
+	resettled, err := settlement.NewSettlement(
+		"settlement-2", "invoice-1", "merchant-1", decimal.RequireFromString("100"), "USDT",
+		decimal.RequireFromString("1"),
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, resettled))
+
+	latest, err := repo.FindByInvoiceID(ctx, "invoice-1")
+	require.NoError(t, err)
+	assert.Equal(t, "settlement-2", latest.ID())
+
+	duplicate, err := settlement.NewSettlement(
+		"settlement-3", "invoice-1", "merchant-1", decimal.RequireFromString("100"), "USDT",
+		decimal.RequireFromString("1"),
+	)
+	require.NoError(t, err)
+	require.Error(t, repo.Save(ctx, duplicate))
+
+	resp, err := repo.List(ctx, &settlement.ListSettlementsRequest{MerchantID: "merchant-1", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, resp.Summary.Count)
+	assert.Equal(t, "99", resp.Summary.TotalNetAmount.String())
+
+	_, err = repo.FindReversal(ctx, "settlement-2")
+	require.ErrorIs(t, err, settlement.ErrReversalNotFound)
+}
+
+func TestSettlementRepository_Splits(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewSettlementRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	parent, err := settlement.NewSettlement(
+		"settlement-1", "invoice-1", "merchant-1", decimal.RequireFromString("100"), "USDT",
+		decimal.RequireFromString("1"),
+	)
+	require.NoError(t, err)
+	seller, err := parent.Split("settlement-2", "seller-1", decimal.RequireFromString("70"))
+	require.NoError(t, err)
+	courier, err := parent.Split("settlement-3", "courier-1", decimal.RequireFromString("5"))
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, parent, seller, courier))
+
+	found, err := repo.FindByInvoiceID(ctx, "invoice-1")
+	require.NoError(t, err)
+	assert.Equal(t, "settlement-1", found.ID(), "shares are not the invoice's settlement")
+	assert.Equal(t, "25", found.GrossAmount().String())
+
+	splits, err := repo.FindSplits(ctx, "settlement-1")
+	require.NoError(t, err)
+	require.Len(t, splits, 2)
+	assert.Equal(t, "seller-1", splits[0].MerchantID())
+	assert.Equal(t, "settlement-1", splits[0].SplitOf())
+	assert.Equal(t, "69.3", splits[0].NetAmount().String())
+
+	resp, err := repo.List(ctx, &settlement.ListSettlementsRequest{MerchantID: "seller-1", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, resp.Settlements, 1)
+	assert.Equal(t, "settlement-2", resp.Settlements[0].ID())
+
+	again, err := settlement.NewSettlement(
+		"settlement-4", "invoice-1", "seller-1", decimal.RequireFromString("10"), "USDT",
+		decimal.RequireFromString("1"),
+	)
+	require.NoError(t, err)
+	require.Error(t, repo.Save(ctx, again), "a beneficiary has one active settlement per invoice")
+}
+
+func TestSettlementRepository_FeeTier(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewSettlementRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	scheduled, err := settlement.NewScheduledSettlement(
+		"settlement-1", "invoice-1", "merchant-1", decimal.RequireFromString("20"), "USDT", &settlement.Fee{
+			Percentage: decimal.RequireFromString("1"),
+			Minimum:    decimal.RequireFromString("0.5"),
+			Tier: settlement.FeeTier{
+				Schedule: "growth", Kind: settlement.FeeTierVolume, Name: "10000",
+				Volume: decimal.RequireFromString("12500.75"),
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, scheduled))
+
+	found, err := repo.FindByID(ctx, "settlement-1")
+	require.NoError(t, err)
+	require.NotNil(t, found.FeeTier())
+	assert.Equal(t, "growth", found.FeeTier().Schedule)
+	assert.Equal(t, settlement.FeeTierVolume, found.FeeTier().Kind)
+	assert.Equal(t, "10000", found.FeeTier().Name)
+	assert.Equal(t, "12500.75", found.FeeTier().Volume.String())
+	assert.True(t, found.FeeTier().MinimumApplied)
+	assert.Equal(t, "0.5", found.FeeAmount().String())
+
+	flat, err := settlement.NewSettlement(
+		"settlement-2", "invoice-2", "merchant-1", decimal.RequireFromString("20"), "USDT", decimal.RequireFromString("1"),
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, flat))
+	found, err = repo.FindByID(ctx, "settlement-2")
+	require.NoError(t, err)
+	assert.Nil(t, found.FeeTier())
+}
@@ -0,0 +1,362 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/domain/statement"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// StatementRepository implements the statement.Repository and statement.Ledger interfaces using GORM. Amounts
+// are summed here rather than in SQL, as some drivers return decimal sums as floating point.
+type StatementRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewStatementRepository creates a new statement repository.
+func NewStatementRepository(db *gorm.DB, logger *zap.Logger) *StatementRepository {
+	return &StatementRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// currencySummaryRecord is the stored form of the summary of a currency.
+type currencySummaryRecord struct {
+	Currency    string `json:"currency"`
+	GrossVolume string `json:"gross_volume"`
+	Fees        string `json:"fees"`
+	Refunds     string `json:"refunds"`
+	NetSettled  string `json:"net_settled"`
+}
+
+// Save persists a new statement.
+func (r *StatementRepository) Save(ctx context.Context, stmt *statement.Statement) error {
+	model, err := r.toModel(stmt)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save statement: %w", err)
+	}
+	return nil
+}
+
+// FindByID retrieves a statement of a merchant by its ID.
+func (r *StatementRepository) FindByID(ctx context.Context, merchantID, id string) (*statement.Statement, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("merchant_id = ? AND id = ?", merchantID, id))
+}
+
+// FindByPeriod retrieves a merchant's statement for the month starting at periodStart.
+func (r *StatementRepository) FindByPeriod(
+	ctx context.Context,
+	merchantID string,
+	periodStart time.Time,
+) (*statement.Statement, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("merchant_id = ? AND period_start = ?", merchantID, periodStart))
+}
+
+// List retrieves a merchant's statements, latest period first.
+func (r *StatementRepository) List(ctx context.Context, merchantID string, limit int) ([]*statement.Statement, error) {
+	var models []StatementModel
+	if err := r.db.WithContext(ctx).
+		Where("merchant_id = ?", merchantID).
+		Order("period_start DESC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list statements: %w", err)
+	}
+
+	statements := make([]*statement.Statement, 0, len(models))
+	for i := range models {
+		stmt, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, stmt)
+	}
+	return statements, nil
+}
+
+// Merchants returns the merchants with invoices created, or settlements created, settled or reversed, in
+// [from, to), sorted.
+func (r *StatementRepository) Merchants(ctx context.Context, from, to time.Time) ([]string, error) {
+	queries := []*gorm.DB{
+		r.db.WithContext(ctx).Model(&InvoiceModel{}).Where("created_at >= ? AND created_at < ?", from, to),
+		r.db.WithContext(ctx).Model(&SettlementModel{}).
+			Where("(created_at >= ? AND created_at < ?) OR (settled_at >= ? AND settled_at < ?)", from, to, from, to),
+		r.db.WithContext(ctx).Model(&SettlementReversalModel{}).Where("created_at >= ? AND created_at < ?", from, to),
+	}
+
+	var merchantIDs []string
+	for _, query := range queries {
+		var ids []string
+		if err := query.Distinct().Pluck("merchant_id", &ids).Error; err != nil {
+			return nil, fmt.Errorf("failed to list active merchants: %w", err)
+		}
+		merchantIDs = append(merchantIDs, ids...)
+	}
+	slices.Sort(merchantIDs)
+	return slices.Compact(merchantIDs), nil
+}
+
+// Summarize returns the activity of a merchant in [from, to). Gross volume and fees come from the settlements
+// created in the period; net settled from those settled in it, less the reversals recorded in it.
+func (r *StatementRepository) Summarize(
+	ctx context.Context,
+	merchantID string,
+	from, to time.Time,
+) (*statement.Summary, error) {
+	currencies := make(map[string]*statement.CurrencySummary)
+	entry := func(currency string) *statement.CurrencySummary {
+		if existing, ok := currencies[currency]; ok {
+			return existing
+		}
+		created := &statement.CurrencySummary{Currency: currency}
+		currencies[currency] = created
+		return created
+	}
+
+	if err := r.addSettlements(ctx, merchantID, from, to, entry); err != nil {
+		return nil, err
+	}
+	if err := r.addRefunds(ctx, merchantID, from, to, entry); err != nil {
+		return nil, err
+	}
+
+	counts, err := r.invoiceCounts(ctx, merchantID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &statement.Summary{
+		Currencies:    make([]statement.CurrencySummary, 0, len(currencies)),
+		InvoiceCounts: counts,
+	}
+	for _, currency := range currencies {
+		summary.Currencies = append(summary.Currencies, *currency)
+	}
+	slices.SortFunc(summary.Currencies, func(a, b statement.CurrencySummary) int {
+		return cmp.Compare(a.Currency, b.Currency)
+	})
+	return summary, nil
+}
+
+// addSettlements adds the merchant's settlements created or settled in the period, and the reversals recorded in
+// it, to their currencies. Failed settlements moved no funds and are left out.
+func (r *StatementRepository) addSettlements(
+	ctx context.Context,
+	merchantID string,
+	from, to time.Time,
+	entry func(currency string) *statement.CurrencySummary,
+) error {
+	var settlements []SettlementModel
+	if err := r.db.WithContext(ctx).
+		Where("merchant_id = ? AND status <> ?", merchantID, string(settlement.StatusFailed)).
+		Where("(created_at >= ? AND created_at < ?) OR (settled_at >= ? AND settled_at < ?)", from, to, from, to).
+		Find(&settlements).Error; err != nil {
+		return fmt.Errorf("failed to read settlements: %w", err)
+	}
+	for _, model := range settlements {
+		summary := entry(model.Currency)
+		if !model.CreatedAt.Before(from) && model.CreatedAt.Before(to) {
+			if err := addAmount(&summary.GrossVolume, model.GrossAmount); err != nil {
+				return err
+			}
+			if err := addAmount(&summary.Fees, model.FeeAmount); err != nil {
+				return err
+			}
+		}
+		if model.SettledAt != nil && !model.SettledAt.Before(from) && model.SettledAt.Before(to) {
+			if err := addAmount(&summary.NetSettled, model.NetAmount); err != nil {
+				return err
+			}
+		}
+	}
+
+	var reversals []SettlementReversalModel
+	if err := r.db.WithContext(ctx).
+		Where("merchant_id = ? AND created_at >= ? AND created_at < ?", merchantID, from, to).
+		Find(&reversals).Error; err != nil {
+		return fmt.Errorf("failed to read settlement reversals: %w", err)
+	}
+	for _, model := range reversals {
+		if err := addAmount(&entry(model.Currency).NetSettled, model.NetAmount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addRefunds adds the refunds recorded on the merchant's invoices in the period to the invoices' currencies.
+// Recording a refund updates its invoice, so invoices last updated before the period are not read.
+func (r *StatementRepository) addRefunds(
+	ctx context.Context,
+	merchantID string,
+	from, to time.Time,
+	entry func(currency string) *statement.CurrencySummary,
+) error {
+	var invoices []InvoiceModel
+	if err := r.db.WithContext(ctx).
+		Select("id", "crypto_currency", "refunds").
+		Where("merchant_id = ? AND refunds IS NOT NULL AND updated_at >= ?", merchantID, from).
+		Find(&invoices).Error; err != nil {
+		return fmt.Errorf("failed to read refunds: %w", err)
+	}
+
+	for _, model := range invoices {
+		if model.Refunds == nil || *model.Refunds == "" {
+			continue
+		}
+		var records []refundRecord
+		if err := json.Unmarshal([]byte(*model.Refunds), &records); err != nil {
+			return fmt.Errorf("failed to decode refunds of invoice %s: %w", model.ID, err)
+		}
+		for _, record := range records {
+			if record.CreatedAt.Before(from) || !record.CreatedAt.Before(to) {
+				continue
+			}
+			if err := addAmount(&entry(model.CryptoCurrency).Refunds, record.Amount); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// invoiceCounts counts the merchant's invoices created in the period by their current status.
+func (r *StatementRepository) invoiceCounts(
+	ctx context.Context,
+	merchantID string,
+	from, to time.Time,
+) (map[string]int, error) {
+	var rows []struct {
+		Status string
+		Count  int
+	}
+	if err := r.db.WithContext(ctx).Model(&InvoiceModel{}).
+		Select("status, COUNT(*) AS count").
+		Where("merchant_id = ? AND created_at >= ? AND created_at < ?", merchantID, from, to).
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count invoices: %w", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// addAmount adds a decimal amount to a total.
+func addAmount(total *decimal.Decimal, value string) error {
+	amount, err := decimal.NewFromString(value)
+	if err != nil {
+		return fmt.Errorf("invalid amount %q: %w", value, err)
+	}
+	*total = total.Add(amount)
+	return nil
+}
+
+// findOne finds a single statement matching the query.
+func (r *StatementRepository) findOne(query *gorm.DB) (*statement.Statement, error) {
+	var model StatementModel
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, statement.ErrStatementNotFound
+		}
+		return nil, fmt.Errorf("failed to find statement: %w", err)
+	}
+	return r.toDomain(&model)
+}
+
+// toModel converts a statement to a database model.
+func (r *StatementRepository) toModel(stmt *statement.Statement) (*StatementModel, error) {
+	records := make([]currencySummaryRecord, len(stmt.Currencies))
+	for i, summary := range stmt.Currencies {
+		records[i] = currencySummaryRecord{
+			Currency:    summary.Currency,
+			GrossVolume: summary.GrossVolume.String(),
+			Fees:        summary.Fees.String(),
+			Refunds:     summary.Refunds.String(),
+			NetSettled:  summary.NetSettled.String(),
+		}
+	}
+	currencies, err := json.Marshal(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode statement currencies: %w", err)
+	}
+	counts, err := json.Marshal(stmt.InvoiceCounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode statement invoice counts: %w", err)
+	}
+	files, err := json.Marshal(stmt.Files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode statement files: %w", err)
+	}
+
+	return &StatementModel{
+		ID:            stmt.ID,
+		MerchantID:    stmt.MerchantID,
+		PeriodStart:   stmt.PeriodStart,
+		PeriodEnd:     stmt.PeriodEnd,
+		Currencies:    string(currencies),
+		InvoiceCounts: string(counts),
+		Files:         string(files),
+		CreatedAt:     stmt.CreatedAt,
+	}, nil
+}
+
+// toDomain converts a database model to a statement.
+func (r *StatementRepository) toDomain(model *StatementModel) (*statement.Statement, error) {
+	var records []currencySummaryRecord
+	if err := json.Unmarshal([]byte(model.Currencies), &records); err != nil {
+		return nil, fmt.Errorf("failed to decode currencies of statement %s: %w", model.ID, err)
+	}
+	stmt := &statement.Statement{
+		ID:          model.ID,
+		MerchantID:  model.MerchantID,
+		PeriodStart: model.PeriodStart.UTC(),
+		PeriodEnd:   model.PeriodEnd.UTC(),
+		CreatedAt:   model.CreatedAt.UTC(),
+	}
+	if err := json.Unmarshal([]byte(model.InvoiceCounts), &stmt.InvoiceCounts); err != nil {
+		return nil, fmt.Errorf("failed to decode invoice counts of statement %s: %w", model.ID, err)
+	}
+	if err := json.Unmarshal([]byte(model.Files), &stmt.Files); err != nil {
+		return nil, fmt.Errorf("failed to decode files of statement %s: %w", model.ID, err)
+	}
+
+	stmt.Currencies = make([]statement.CurrencySummary, len(records))
+	for i, record := range records {
+		summary := statement.CurrencySummary{Currency: record.Currency}
+		for _, field := range []struct {
+			value  string
+			target *decimal.Decimal
+		}{
+			{record.GrossVolume, &summary.GrossVolume},
+			{record.Fees, &summary.Fees},
+			{record.Refunds, &summary.Refunds},
+			{record.NetSettled, &summary.NetSettled},
+		} {
+			amount, err := decimal.NewFromString(field.value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid amount in statement %s: %w", model.ID, err)
+			}
+			*field.target = amount
+		}
+		stmt.Currencies[i] = summary
+	}
+	return stmt, nil
+}
@@ -0,0 +1,139 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/statement"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestStatementRepository(t *testing.T) {
+	db := setupTestDB(t)
+	invoices := database.NewInvoiceRepository(db, zap.NewNop())
+	repo := database.NewStatementRepository(db, zap.NewNop())
+	ctx := context.Background()
+	const merchantID = "test-merchant-id"
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	// addInvoice stores an invoice of the test merchant created at the given time
+	addInvoice := func(id, status string, createdAt time.Time, refunds string) {
+		require.NoError(t, invoices.Save(ctx, createTestInvoiceWithID(t, id)))
+		updates := map[string]interface{}{"status": status, "created_at": createdAt, "updated_at": createdAt}
+		if refunds != "" {
+			updates["refunds"] = refunds
+			updates["updated_at"] = to.Add(-time.Hour)
+		}
+		require.NoError(t, db.Model(&database.InvoiceModel{}).Where("id = ?", id).Updates(updates).Error)
+	}
+	addSettlement := func(id, gross, fee, net, status string, createdAt time.Time, settledAt *time.Time) {
+		require.NoError(t, db.Create(&database.SettlementModel{
+			ID: id, InvoiceID: uuid.New().String(), MerchantID: merchantID, GrossAmount: gross,
+			FeePercentage: "1", FeeAmount: fee, NetAmount: net, Currency: "USDT", Status: status,
+			CreatedAt: createdAt, SettledAt: settledAt,
+		}).Error)
+	}
+	at := func(day int) time.Time { return from.AddDate(0, 0, day-1).Add(12 * time.Hour) }
+	settledAt := func(day int) *time.Time {
+		settled := at(day)
+		return &settled
+	}
+
+	addInvoice("inv-paid-1", "paid", at(3), "")
+	addInvoice("inv-paid-2", "paid", at(10), `[{"id":"ref-1","amount":"15","created_at":"2026-09-20T10:00:00Z"},`+
+		`{"id":"ref-2","amount":"99","created_at":"2026-10-02T10:00:00Z"}]`)
+	addInvoice("inv-expired", "expired", at(12), "")
+	addInvoice("inv-august", "paid", from.Add(-time.Hour), "")
+
+	addSettlement("stl-1", "100", "1", "99", "completed", at(3), settledAt(4))
+	addSettlement("stl-2", "200", "2", "198", "pending", at(30), nil)
+	addSettlement("stl-3", "50", "0.5", "49.5", "completed", from.Add(-time.Hour), settledAt(1))
+	addSettlement("stl-4", "80", "0.8", "79.2", "failed", at(5), nil)
+	require.NoError(t, db.Create(&database.SettlementReversalModel{
+		ID: "rev-1", SettlementID: "stl-old", InvoiceID: "inv-old", MerchantID: merchantID,
+		GrossAmount: "-30", FeeAmount: "-0.3", NetAmount: "-29.7", Currency: "USDT", CreatedAt: at(15),
+	}).Error)
+
+	t.Run("Merchants", func(t *testing.T) {
+		merchantIDs, err := repo.Merchants(ctx, from, to)
+		require.NoError(t, err)
+		assert.Equal(t, []string{merchantID}, merchantIDs)
+
+		merchantIDs, err = repo.Merchants(ctx, to, to.AddDate(0, 1, 0))
+		require.NoError(t, err)
+		assert.Empty(t, merchantIDs)
+	})
+
+	t.Run("Summarize", func(t *testing.T) {
+		summary, err := repo.Summarize(ctx, merchantID, from, to)
+		require.NoError(t, err)
+
+		require.Len(t, summary.Currencies, 1)
+		usdt := summary.Currencies[0]
+		assert.Equal(t, "USDT", usdt.Currency)
+		assert.Equal(t, "300", usdt.GrossVolume.String())
+		assert.Equal(t, "3", usdt.Fees.String())
+		assert.Equal(t, "15", usdt.Refunds.String())
+		// stl-1 and stl-3 were settled in September, less the reversal
+		assert.Equal(t, "118.8", usdt.NetSettled.String())
+		assert.Equal(t, map[string]int{"paid": 2, "expired": 1}, summary.InvoiceCounts)
+	})
+
+	t.Run("Saves_And_Finds_Statements", func(t *testing.T) {
+		_, err := repo.FindByPeriod(ctx, merchantID, from)
+		require.ErrorIs(t, err, statement.ErrStatementNotFound)
+
+		september := &statement.Statement{
+			ID: uuid.New().String(), MerchantID: merchantID, PeriodStart: from, PeriodEnd: to,
+			Summary: statement.Summary{
+				Currencies: []statement.CurrencySummary{{
+					Currency: "USDT", GrossVolume: decimal.RequireFromString("300"),
+					Fees: decimal.RequireFromString("3"), Refunds: decimal.RequireFromString("15"),
+					NetSettled: decimal.RequireFromString("118.8"),
+				}},
+				InvoiceCounts: map[string]int{"paid": 2},
+			},
+			Files: map[statement.Format]string{
+				statement.FormatPDF: "statements/test-merchant-id/2026-09.pdf",
+				statement.FormatCSV: "statements/test-merchant-id/2026-09.csv",
+			},
+			CreatedAt: to.Add(time.Hour),
+		}
+		august := &statement.Statement{
+			ID: uuid.New().String(), MerchantID: merchantID, PeriodStart: from.AddDate(0, -1, 0), PeriodEnd: from,
+			Summary:   statement.Summary{InvoiceCounts: map[string]int{}},
+			Files:     map[statement.Format]string{},
+			CreatedAt: from.Add(time.Hour),
+		}
+		require.NoError(t, repo.Save(ctx, august))
+		require.NoError(t, repo.Save(ctx, september))
+		require.Error(t, repo.Save(ctx, &statement.Statement{
+			ID: uuid.New().String(), MerchantID: merchantID, PeriodStart: from, PeriodEnd: to, CreatedAt: to,
+		}), "one statement per merchant and month")
+
+		found, err := repo.FindByPeriod(ctx, merchantID, from)
+		require.NoError(t, err)
+		assert.Equal(t, september.ID, found.ID)
+		assert.Equal(t, "2026-09", found.Period())
+		require.Len(t, found.Currencies, 1)
+		assert.Equal(t, "118.8", found.Currencies[0].NetSettled.String())
+		assert.Equal(t, september.Files, found.Files)
+		assert.Equal(t, 2, found.InvoiceTotal())
+
+		_, err = repo.FindByID(ctx, "other-merchant", september.ID)
+		require.ErrorIs(t, err, statement.ErrStatementNotFound)
+
+		statements, err := repo.List(ctx, merchantID, 10)
+		require.NoError(t, err)
+		require.Len(t, statements, 2)
+		assert.Equal(t, september.ID, statements[0].ID)
+		assert.Equal(t, august.ID, statements[1].ID)
+	})
+}
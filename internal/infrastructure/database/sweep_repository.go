@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/treasury"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SweepRepository implements the treasury.SweepRepository interface using GORM.
+type SweepRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewSweepRepository creates a new sweep repository.
+func NewSweepRepository(db *gorm.DB, logger *zap.Logger) treasury.SweepRepository {
+	return &SweepRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a sweep to the database.
+func (r *SweepRepository) Save(ctx context.Context, sweep *treasury.Sweep) error {
+	if err := r.db.WithContext(ctx).Create(r.toModel(sweep)).Error; err != nil {
+		return fmt.Errorf("failed to save sweep: %w", err)
+	}
+
+	return nil
+}
+
+// FindByInvoiceID finds the sweep of an invoice.
+func (r *SweepRepository) FindByInvoiceID(ctx context.Context, invoiceID string) (*treasury.Sweep, error) {
+	var model SweepModel
+	if err := r.db.WithContext(ctx).Where("invoice_id = ?", invoiceID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, treasury.ErrSweepNotFound
+		}
+		return nil, fmt.Errorf("failed to find sweep: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// FindByStatus finds up to limit sweeps with the status, oldest first.
+func (r *SweepRepository) FindByStatus(
+	ctx context.Context,
+	status treasury.SweepStatus,
+	limit int,
+) ([]*treasury.Sweep, error) {
+	var models []SweepModel
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", string(status)).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find sweeps: %w", err)
+	}
+
+	return r.toDomainList(models)
+}
+
+// List retrieves sweeps matching the filter, newest first.
+func (r *SweepRepository) List(ctx context.Context, req *treasury.ListSweepsRequest) (*treasury.ListSweepsResponse, error) {
+	query := r.db.WithContext(ctx)
+	if req.Status != "" {
+		query = query.Where("status = ?", string(req.Status))
+	}
+
+	var models []SweepModel
+	if err := query.Scopes(keysetPage("created_at", req.Cursor, 0, req.Limit)).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list sweeps: %w", err)
+	}
+	models, next := nextPage(models, req.Limit, func(model *SweepModel) (time.Time, string) {
+		return model.CreatedAt, model.ID
+	})
+
+	sweeps, err := r.toDomainList(models)
+	if err != nil {
+		return nil, err
+	}
+
+	return &treasury.ListSweepsResponse{
+		Sweeps:     sweeps,
+		Limit:      req.Limit,
+		NextCursor: next,
+	}, nil
+}
+
+// Update updates an existing sweep.
+func (r *SweepRepository) Update(ctx context.Context, sweep *treasury.Sweep) error {
+	if err := r.db.WithContext(ctx).Save(r.toModel(sweep)).Error; err != nil {
+		return fmt.Errorf("failed to update sweep: %w", err)
+	}
+
+	return nil
+}
+
+// toDomainList converts database models to domain sweeps.
+func (r *SweepRepository) toDomainList(models []SweepModel) ([]*treasury.Sweep, error) {
+	sweeps := make([]*treasury.Sweep, len(models))
+	for i := range models {
+		sweep, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert sweep model to domain: %w", err)
+		}
+		sweeps[i] = sweep
+	}
+	return sweeps, nil
+}
+
+// toModel converts a domain sweep to a database model.
+func (r *SweepRepository) toModel(sweep *treasury.Sweep) *SweepModel {
+	return &SweepModel{
+		ID:            sweep.ID(),
+		InvoiceID:     sweep.InvoiceID(),
+		MerchantID:    sweep.MerchantID(),
+		Network:       sweep.Network().String(),
+		Currency:      sweep.Currency(),
+		FromAddress:   sweep.FromAddress(),
+		Amount:        sweep.Amount().String(),
+		Status:        string(sweep.Status()),
+		TxHash:        sweep.TxHash(),
+		FailureReason: sweep.FailureReason(),
+		CreatedAt:     sweep.CreatedAt(),
+		CompletedAt:   sweep.CompletedAt(),
+	}
+}
+
+// toDomain converts a database model to a domain sweep.
+func (r *SweepRepository) toDomain(model *SweepModel) (*treasury.Sweep, error) {
+	amount, err := decimal.NewFromString(model.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sweep amount %q: %w", model.Amount, err)
+	}
+
+	return treasury.RestoreSweep(
+		model.ID,
+		model.InvoiceID,
+		model.MerchantID,
+		shared.BlockchainNetwork(model.Network),
+		model.Currency,
+		model.FromAddress,
+		amount,
+		treasury.SweepStatus(model.Status),
+		model.TxHash,
+		model.FailureReason,
+		model.CreatedAt,
+		model.CompletedAt,
+	)
+}
@@ -0,0 +1,147 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/tax"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// TaxRuleRepository implements the tax.Repository interface using GORM.
+type TaxRuleRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewTaxRuleRepository creates a new tax rule repository.
+func NewTaxRuleRepository(db *gorm.DB, logger *zap.Logger) tax.Repository {
+	return &TaxRuleRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a new tax rule to the database.
+func (r *TaxRuleRepository) Save(ctx context.Context, rule *tax.Rule) error {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&TaxRuleModel{}).
+		Where("merchant_id = ? AND jurisdiction = ? AND category = ? AND name = ?",
+			rule.MerchantID(), rule.Jurisdiction(), rule.Category(), rule.Name()).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check tax rule: %w", err)
+	}
+	if count > 0 {
+		return tax.ErrRuleExists
+	}
+
+	if err := r.db.WithContext(ctx).Create(r.toModel(rule)).Error; err != nil {
+		return fmt.Errorf("failed to save tax rule: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a tax rule by ID.
+func (r *TaxRuleRepository) FindByID(ctx context.Context, id string) (*tax.Rule, error) {
+	var model TaxRuleModel
+	err := r.db.WithContext(ctx).Scopes(merchantScope(ctx)).Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			reportCrossTenantAccess(ctx, r.db, r.logger, &TaxRuleModel{}, "tax_rule", id)
+			return nil, tax.ErrRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to find tax rule: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// ListByMerchant lists a merchant's tax rules ordered by jurisdiction, category and name.
+func (r *TaxRuleRepository) ListByMerchant(ctx context.Context, merchantID string) ([]*tax.Rule, error) {
+	var models []TaxRuleModel
+	if err := r.db.WithContext(ctx).
+		Where("merchant_id = ?", merchantID).
+		Order("jurisdiction ASC, category ASC, name ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list tax rules: %w", err)
+	}
+
+	rules := make([]*tax.Rule, len(models))
+	for i := range models {
+		rule, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert tax rule model to domain: %w", err)
+		}
+		rules[i] = rule
+	}
+	return rules, nil
+}
+
+// Update updates an existing tax rule.
+func (r *TaxRuleRepository) Update(ctx context.Context, rule *tax.Rule) error {
+	result := r.db.WithContext(ctx).Model(&TaxRuleModel{}).
+		Scopes(merchantScope(ctx)).
+		Where("id = ?", rule.ID()).
+		Updates(map[string]interface{}{
+			"name":           rule.Name(),
+			"rate":           rule.Rate(),
+			"reverse_charge": rule.IsReverseCharge(),
+			"updated_at":     rule.UpdatedAt(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update tax rule: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		reportCrossTenantAccess(ctx, r.db, r.logger, &TaxRuleModel{}, "tax_rule", rule.ID())
+		return tax.ErrRuleNotFound
+	}
+	return nil
+}
+
+// Delete removes a tax rule.
+func (r *TaxRuleRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Scopes(merchantScope(ctx)).Where("id = ?", id).Delete(&TaxRuleModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete tax rule: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		reportCrossTenantAccess(ctx, r.db, r.logger, &TaxRuleModel{}, "tax_rule", id)
+		return tax.ErrRuleNotFound
+	}
+	return nil
+}
+
+// toModel converts a domain tax rule to a database model.
+func (r *TaxRuleRepository) toModel(rule *tax.Rule) *TaxRuleModel {
+	return &TaxRuleModel{
+		ID:            rule.ID(),
+		MerchantID:    rule.MerchantID(),
+		Jurisdiction:  rule.Jurisdiction(),
+		Category:      rule.Category(),
+		Name:          rule.Name(),
+		Type:          rule.Type().String(),
+		Rate:          rule.Rate(),
+		ReverseCharge: rule.IsReverseCharge(),
+		CreatedAt:     rule.CreatedAt(),
+		UpdatedAt:     rule.UpdatedAt(),
+	}
+}
+
+// toDomain converts a database model to a domain tax rule.
+func (r *TaxRuleRepository) toDomain(model *TaxRuleModel) (*tax.Rule, error) {
+	return tax.RestoreRule(
+		model.ID,
+		model.MerchantID,
+		model.Jurisdiction,
+		model.Category,
+		model.Name,
+		invoice.TaxType(model.Type),
+		model.Rate,
+		model.ReverseCharge,
+		model.CreatedAt,
+		model.UpdatedAt,
+	)
+}
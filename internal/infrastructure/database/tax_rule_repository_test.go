@@ -0,0 +1,112 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/tax"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTaxRuleRepository_ResolvePolicy(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewTaxRuleRepository(db, zap.NewNop())
+	service := tax.NewService(repo, zap.NewNop())
+	ctx := context.Background()
+
+	createRule := func(jurisdiction, category, name string, taxType invoice.TaxType, rate string, reverseCharge bool) {
+		_, err := service.CreateRule(ctx, &tax.CreateRuleRequest{
+			MerchantID:    "merchant-1",
+			Jurisdiction:  jurisdiction,
+			Category:      category,
+			Name:          name,
+			Type:          taxType,
+			Rate:          rate,
+			ReverseCharge: reverseCharge,
+		})
+		require.NoError(t, err)
+	}
+
+	createRule("ca-bc", "", "PST", invoice.TaxTypeSalesTax, "0.07", false)
+	createRule("CA", "", "GST", invoice.TaxTypeGST, "0.05", false)
+	createRule("FR", "", "VAT", invoice.TaxTypeVAT, "0.20", true)
+	createRule("FR", "reduced", "VAT", invoice.TaxTypeVAT, "0.055", true)
+
+	t.Run("duplicate rule is rejected", func(t *testing.T) {
+		_, err := service.CreateRule(ctx, &tax.CreateRuleRequest{
+			MerchantID:   "merchant-1",
+			Jurisdiction: "CA",
+			Name:         "GST",
+			Type:         invoice.TaxTypeGST,
+			Rate:         "0.05",
+		})
+		require.ErrorIs(t, err, tax.ErrRuleExists)
+	})
+
+	t.Run("country rules apply before subdivision rules", func(t *testing.T) {
+		policy, err := service.ResolvePolicy(ctx, "merchant-1", "ca-bc", "")
+		require.NoError(t, err)
+
+		rates := policy.RatesFor(invoice.DefaultTaxCategory)
+		require.Len(t, rates, 2)
+		assert.Equal(t, "GST", rates[0].Name())
+		assert.Equal(t, "PST", rates[1].Name())
+		assert.False(t, policy.Treatment().IsReverseCharge())
+
+		policy, err = service.ResolvePolicy(ctx, "merchant-1", "CA-ON", "")
+		require.NoError(t, err)
+		require.Len(t, policy.RatesFor(invoice.DefaultTaxCategory), 1)
+	})
+
+	t.Run("business customers are reverse charged", func(t *testing.T) {
+		policy, err := service.ResolvePolicy(ctx, "merchant-1", "FR", "fr 40-303-265-045")
+		require.NoError(t, err)
+		assert.Equal(t, "FR40303265045", policy.CustomerTaxID())
+		assert.True(t, policy.Treatment().IsReverseCharge())
+		require.Len(t, policy.RatesFor("reduced"), 1)
+		assert.True(t, policy.RatesFor("reduced")[0].IsReverseCharge())
+
+		consumer, err := service.ResolvePolicy(ctx, "merchant-1", "FR", "")
+		require.NoError(t, err)
+		assert.False(t, consumer.Treatment().IsReverseCharge())
+
+		_, err = service.ResolvePolicy(ctx, "merchant-1", "FR", "x")
+		require.ErrorIs(t, err, tax.ErrInvalidTaxID)
+	})
+
+	t.Run("other merchants and jurisdictions have no taxes", func(t *testing.T) {
+		policy, err := service.ResolvePolicy(ctx, "merchant-2", "FR", "")
+		require.NoError(t, err)
+		assert.Empty(t, policy.RatesFor(invoice.DefaultTaxCategory))
+
+		_, err = service.ResolvePolicy(ctx, "merchant-1", "France", "")
+		require.ErrorIs(t, err, tax.ErrInvalidRequest)
+	})
+
+	t.Run("update and delete", func(t *testing.T) {
+		rules, err := service.ListRules(ctx, "merchant-1")
+		require.NoError(t, err)
+		require.Len(t, rules, 4)
+		assert.Equal(t, "CA", rules[0].Jurisdiction())
+
+		updated, err := service.UpdateRule(ctx, &tax.UpdateRuleRequest{
+			MerchantID: "merchant-1",
+			RuleID:     rules[0].ID(),
+			Name:       "GST",
+			Rate:       "0.06",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "0.06", updated.Rate())
+
+		_, err = service.GetRule(ctx, "merchant-2", rules[0].ID())
+		require.ErrorIs(t, err, tax.ErrRuleNotFound)
+
+		require.NoError(t, service.DeleteRule(ctx, "merchant-1", rules[0].ID()))
+		_, err = repo.FindByID(ctx, rules[0].ID())
+		require.ErrorIs(t, err, tax.ErrRuleNotFound)
+	})
+}
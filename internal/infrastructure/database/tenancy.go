@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// merchantScope is a GORM scope restricting a query to the merchant the context is scoped to.
+// Queries made with an unscoped context, such as public checkout requests and background jobs, are not restricted.
+func merchantScope(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		merchantID, ok := shared.MerchantIDFromContext(ctx)
+		if !ok {
+			return db
+		}
+		return db.Where(clause.Eq{
+			Column: clause.Column{Table: clause.CurrentTable, Name: "merchant_id"},
+			Value:  merchantID,
+		})
+	}
+}
+
+// ownedByScopedMerchant reports whether a record belongs to the merchant the context is scoped to,
+// logging a security event if it belongs to another merchant. Unscoped contexts own every record.
+func ownedByScopedMerchant(ctx context.Context, logger *zap.Logger, resource, id, ownerID string) bool {
+	merchantID, ok := shared.MerchantIDFromContext(ctx)
+	if !ok || merchantID == ownerID {
+		return true
+	}
+	logCrossTenantAccess(logger, merchantID, ownerID, resource, id)
+	return false
+}
+
+// reportCrossTenantAccess logs a security event if a record that a merchant-scoped lookup missed
+// exists for another merchant. Callers still report the record as not found so its existence is not disclosed.
+func reportCrossTenantAccess(
+	ctx context.Context,
+	db *gorm.DB,
+	logger *zap.Logger,
+	model interface{},
+	resource, id string,
+) {
+	merchantID, ok := shared.MerchantIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var owners []string
+	err := db.WithContext(ctx).Model(model).Where("id = ?", id).Limit(1).Pluck("merchant_id", &owners).Error
+	if err != nil {
+		logger.Error("Failed to check cross-tenant access", zap.String("resource", resource), zap.Error(err))
+		return
+	}
+	if len(owners) > 0 && owners[0] != merchantID {
+		logCrossTenantAccess(logger, merchantID, owners[0], resource, id)
+	}
+}
+
+// logCrossTenantAccess records a blocked attempt to reach another merchant's data.
+func logCrossTenantAccess(logger *zap.Logger, merchantID, ownerID, resource, id string) {
+	logger.Warn("Cross-tenant access blocked",
+		zap.String("security_event", "cross_tenant_access"),
+		zap.String("merchant_id", merchantID),
+		zap.String("owner_merchant_id", ownerID),
+		zap.String("resource", resource),
+		zap.String("resource_id", id),
+	)
+}
@@ -0,0 +1,103 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/coupon"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestTenantIsolation(t *testing.T) {
+	db := setupTestDB(t)
+	core, logs := observer.New(zapcore.WarnLevel)
+	logger := zap.New(core)
+	invoiceRepo := database.NewInvoiceRepository(db, logger)
+	couponRepo := database.NewCouponRepository(db, logger)
+
+	owner := shared.WithMerchantID(context.Background(), "test-merchant-id")
+	intruder := shared.WithMerchantID(context.Background(), "other-merchant")
+
+	inv := createTestInvoiceWithID(t, "tenant-invoice")
+	require.NoError(t, invoiceRepo.Save(owner, inv))
+
+	discount, err := invoice.NewDiscount(invoice.DiscountTypePercentage, "10")
+	require.NoError(t, err)
+	c, err := coupon.NewCoupon("tenant-coupon", "test-merchant-id", "TENANT10", discount, 0, nil)
+	require.NoError(t, err)
+	require.NoError(t, couponRepo.Save(owner, c))
+
+	securityEvents := func() []observer.LoggedEntry {
+		entries := logs.TakeAll()
+		for _, entry := range entries {
+			require.Equal(t, "cross_tenant_access", entry.ContextMap()["security_event"])
+		}
+		return entries
+	}
+
+	t.Run("owner and unscoped contexts see the invoice", func(t *testing.T) {
+		_, err := invoiceRepo.FindByID(owner, inv.ID())
+		require.NoError(t, err)
+		_, err = invoiceRepo.FindByID(context.Background(), inv.ID())
+		require.NoError(t, err)
+
+		active, err := invoiceRepo.FindActive(owner)
+		require.NoError(t, err)
+		assert.Len(t, active, 1)
+		assert.Empty(t, securityEvents())
+	})
+
+	t.Run("another merchant gets not found and a security log entry", func(t *testing.T) {
+		_, err := invoiceRepo.FindByID(intruder, inv.ID())
+		require.ErrorIs(t, err, shared.ErrNotFound)
+
+		events := securityEvents()
+		require.Len(t, events, 1)
+		fields := events[0].ContextMap()
+		assert.Equal(t, "other-merchant", fields["merchant_id"])
+		assert.Equal(t, "test-merchant-id", fields["owner_merchant_id"])
+		assert.Equal(t, "invoice", fields["resource"])
+		assert.Equal(t, inv.ID(), fields["resource_id"])
+	})
+
+	t.Run("another merchant cannot list, update or delete the invoice", func(t *testing.T) {
+		active, err := invoiceRepo.FindActive(intruder)
+		require.NoError(t, err)
+		assert.Empty(t, active)
+
+		exists, err := invoiceRepo.Exists(intruder, inv.ID())
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		require.ErrorIs(t, invoiceRepo.Update(intruder, inv), shared.ErrNotFound)
+		require.ErrorIs(t, invoiceRepo.Delete(intruder, inv.ID()), shared.ErrNotFound)
+		assert.Len(t, securityEvents(), 2)
+
+		_, err = invoiceRepo.FindByID(owner, inv.ID())
+		require.NoError(t, err)
+	})
+
+	t.Run("missing records are not reported as cross-tenant access", func(t *testing.T) {
+		_, err := invoiceRepo.FindByID(intruder, "missing-invoice")
+		require.ErrorIs(t, err, shared.ErrNotFound)
+		assert.Empty(t, securityEvents())
+	})
+
+	t.Run("coupons are isolated too", func(t *testing.T) {
+		_, err := couponRepo.FindByID(intruder, c.ID())
+		require.ErrorIs(t, err, coupon.ErrCouponNotFound)
+		require.ErrorIs(t, couponRepo.Update(intruder, c), coupon.ErrCouponNotFound)
+		assert.Len(t, securityEvents(), 2)
+
+		found, err := couponRepo.FindByID(owner, c.ID())
+		require.NoError(t, err)
+		assert.Equal(t, c.ID(), found.ID())
+	})
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/treasury"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// TreasuryBalanceRepository implements the treasury.BalanceRepository interface using GORM.
+type TreasuryBalanceRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewTreasuryBalanceRepository creates a new treasury balance repository.
+func NewTreasuryBalanceRepository(db *gorm.DB, logger *zap.Logger) treasury.BalanceRepository {
+	return &TreasuryBalanceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save creates or replaces the balance of a currency on its network.
+func (r *TreasuryBalanceRepository) Save(ctx context.Context, balance *treasury.Balance) error {
+	model := &TreasuryBalanceModel{
+		Network:   balance.Network().String(),
+		Currency:  balance.Currency(),
+		Amount:    balance.Amount().String(),
+		Level:     string(balance.Level()),
+		CheckedAt: balance.CheckedAt(),
+	}
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to save treasury balance: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves every recorded balance, ordered by network and currency.
+func (r *TreasuryBalanceRepository) List(ctx context.Context) ([]*treasury.Balance, error) {
+	var models []TreasuryBalanceModel
+	if err := r.db.WithContext(ctx).Order("network ASC, currency ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list treasury balances: %w", err)
+	}
+
+	balances := make([]*treasury.Balance, len(models))
+	for i, model := range models {
+		amount, err := decimal.NewFromString(model.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid treasury balance %q: %w", model.Amount, err)
+		}
+		balance, err := treasury.RestoreBalance(
+			shared.BlockchainNetwork(model.Network),
+			model.Currency,
+			amount,
+			treasury.BalanceLevel(model.Level),
+			model.CheckedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert treasury balance model to domain: %w", err)
+		}
+		balances[i] = balance
+	}
+	return balances, nil
+}
@@ -0,0 +1,98 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/treasury"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const treasuryTxHash = "7c2d1f0e9b8a7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d"
+
+func TestTreasuryBalanceRepository_SaveReplaces(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewTreasuryBalanceRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	thresholds := treasury.Thresholds{Low: decimal.NewFromInt(100)}
+	balance, err := treasury.NewBalance(shared.NetworkTron, "USDT", decimal.NewFromInt(500), thresholds)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, balance))
+
+	balance.Record(decimal.RequireFromString("42.5"), thresholds)
+	require.NoError(t, repo.Save(ctx, balance))
+
+	balances, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+	assert.Equal(t, "42.5", balances[0].Amount().String())
+	assert.Equal(t, treasury.BalanceLevelLow, balances[0].Level())
+}
+
+func TestSweepRepository_Lifecycle(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewSweepRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	sweep, err := treasury.NewSweep(
+		"11111111-1111-1111-1111-111111111111", "inv-1", "merchant-1", shared.NetworkTron, "USDT",
+		"TQn9Y2khEsLJW1ChVWFMSMeRDow5KcbLSE",
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, sweep))
+
+	_, err = repo.FindByInvoiceID(ctx, "inv-2")
+	require.ErrorIs(t, err, treasury.ErrSweepNotFound)
+
+	require.NoError(t, sweep.SetAmount(decimal.RequireFromString("99.5")))
+	require.NoError(t, sweep.ApplyTransfer(&payout.Transfer{TxHash: treasuryTxHash, State: payout.TransferStatePending}))
+	require.NoError(t, repo.Update(ctx, sweep))
+
+	broadcast, err := repo.FindByStatus(ctx, treasury.SweepStatusBroadcast, 10)
+	require.NoError(t, err)
+	require.Len(t, broadcast, 1)
+	assert.Equal(t, "99.5", broadcast[0].Amount().String())
+	assert.Equal(t, treasuryTxHash, broadcast[0].TxHash())
+
+	page, err := repo.List(ctx, &treasury.ListSweepsRequest{Status: treasury.SweepStatusPending, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, page.Sweeps)
+}
+
+func TestColdTransferRepository_Lifecycle(t *testing.T) {
+	db := setupTestDB(t)
+	repo := database.NewColdTransferRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	transfer, err := treasury.NewColdTransfer(
+		"22222222-2222-2222-2222-222222222222", shared.NetworkTron, "USDT", "TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf",
+		decimal.NewFromInt(15000), "weekly", "alice",
+	)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, transfer))
+
+	require.NoError(t, transfer.Approve("bob", "checked"))
+	require.NoError(t, repo.Update(ctx, transfer))
+
+	found, err := repo.FindByID(ctx, transfer.ID())
+	require.NoError(t, err)
+	assert.Equal(t, treasury.TransferStatusApproved, found.Status())
+	assert.Equal(t, "alice", found.RequestedBy())
+	assert.Equal(t, "bob", found.ReviewedBy())
+	assert.NotNil(t, found.ReviewedAt())
+
+	page, err := repo.List(ctx, &treasury.ListTransfersRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Transfers, 1)
+	assert.Nil(t, page.NextCursor)
+
+	_, err = repo.FindByID(ctx, "33333333-3333-3333-3333-333333333333")
+	require.ErrorIs(t, err, treasury.ErrTransferNotFound)
+}
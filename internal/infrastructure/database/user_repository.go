@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/merchant"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// UserRepository implements the merchant.UserRepository interface using GORM.
+type UserRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewUserRepository creates a new team member repository.
+func NewUserRepository(db *gorm.DB, logger *zap.Logger) merchant.UserRepository {
+	return &UserRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a user to the database.
+func (r *UserRepository) Save(ctx context.Context, user *merchant.User) error {
+	if err := r.db.WithContext(ctx).Create(r.toModel(user)).Error; err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	r.logger.Debug("User saved successfully",
+		zap.String("user_id", user.ID()),
+		zap.String("merchant_id", user.MerchantID()),
+	)
+
+	return nil
+}
+
+// FindByID finds a user by its ID.
+func (r *UserRepository) FindByID(ctx context.Context, id string) (*merchant.User, error) {
+	var model UserModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, merchant.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// FindByEmail finds a user of a merchant by email.
+func (r *UserRepository) FindByEmail(ctx context.Context, merchantID, email string) (*merchant.User, error) {
+	var model UserModel
+	if err := r.db.WithContext(ctx).
+		Where("merchant_id = ? AND email = ?", merchantID, email).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, merchant.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user by email: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// FindAllByEmail finds users with the email across all merchants.
+func (r *UserRepository) FindAllByEmail(ctx context.Context, email string) ([]*merchant.User, error) {
+	var models []UserModel
+	if err := r.db.WithContext(ctx).Where("email = ?", email).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find users by email: %w", err)
+	}
+
+	return r.toDomainList(models)
+}
+
+// FindByMerchantID finds all users for a merchant.
+func (r *UserRepository) FindByMerchantID(ctx context.Context, merchantID string) ([]*merchant.User, error) {
+	var models []UserModel
+	if err := r.db.WithContext(ctx).
+		Where("merchant_id = ?", merchantID).
+		Order("created_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find users for merchant: %w", err)
+	}
+
+	return r.toDomainList(models)
+}
+
+// Update updates an existing user.
+func (r *UserRepository) Update(ctx context.Context, user *merchant.User) error {
+	if err := r.db.WithContext(ctx).Save(r.toModel(user)).Error; err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	r.logger.Debug("User updated successfully",
+		zap.String("user_id", user.ID()),
+	)
+
+	return nil
+}
+
+// Delete deletes a user by its ID.
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&UserModel{}).Error; err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	r.logger.Debug("User deleted successfully",
+		zap.String("user_id", id),
+	)
+
+	return nil
+}
+
+// CountByRole counts active users of a merchant with the given role.
+func (r *UserRepository) CountByRole(ctx context.Context, merchantID string, role merchant.UserRole) (int, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("merchant_id = ? AND role = ? AND status = ?", merchantID, string(role), string(merchant.UserStatusActive)).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count users by role: %w", err)
+	}
+
+	return int(count), nil
+}
+
+// toModel converts a domain user to a database model.
+func (r *UserRepository) toModel(user *merchant.User) *UserModel {
+	return &UserModel{
+		ID:           user.ID(),
+		MerchantID:   user.MerchantID(),
+		Email:        user.Email(),
+		Name:         user.Name(),
+		Role:         string(user.Role()),
+		Status:       string(user.Status()),
+		PasswordHash: user.PasswordHash(),
+		TOTPSecret:   user.TOTPSecret(),
+		TOTPEnabled:  user.TOTPEnabled(),
+		CreatedAt:    user.CreatedAt(),
+		UpdatedAt:    user.UpdatedAt(),
+	}
+}
+
+// toDomainList converts database models to domain users.
+func (r *UserRepository) toDomainList(models []UserModel) ([]*merchant.User, error) {
+	users := make([]*merchant.User, len(models))
+	for i := range models {
+		user, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert user model to domain: %w", err)
+		}
+		users[i] = user
+	}
+
+	return users, nil
+}
+
+// toDomain converts a database model to a domain user.
+func (r *UserRepository) toDomain(model *UserModel) (*merchant.User, error) {
+	user, err := merchant.RestoreUser(
+		model.ID,
+		model.MerchantID,
+		model.Email,
+		model.Name,
+		merchant.UserRole(model.Role),
+		merchant.UserStatus(model.Status),
+		model.PasswordHash,
+		model.TOTPSecret,
+		model.TOTPEnabled,
+		model.CreatedAt,
+		model.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore user: %w", err)
+	}
+
+	return user, nil
+}
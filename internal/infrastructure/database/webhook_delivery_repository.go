@@ -0,0 +1,236 @@
+package database
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/webhook"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// WebhookDeliveryRepository implements the webhook.Repository interface using GORM.
+type WebhookDeliveryRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewWebhookDeliveryRepository creates a new webhook delivery log repository.
+func NewWebhookDeliveryRepository(db *gorm.DB, logger *zap.Logger) webhook.Repository {
+	return &WebhookDeliveryRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Save saves a webhook delivery to the database.
+func (r *WebhookDeliveryRepository) Save(ctx context.Context, delivery *webhook.Delivery) error {
+	if err := r.db.WithContext(ctx).Create(r.toModel(delivery)).Error; err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a webhook delivery by ID.
+func (r *WebhookDeliveryRepository) FindByID(ctx context.Context, id string) (*webhook.Delivery, error) {
+	var model WebhookDeliveryModel
+	err := r.db.WithContext(ctx).Scopes(merchantScope(ctx)).Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			reportCrossTenantAccess(ctx, r.db, r.logger, &WebhookDeliveryModel{}, "webhook_delivery", id)
+			return nil, webhook.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to find webhook delivery: %w", err)
+	}
+
+	return r.toDomain(&model)
+}
+
+// List lists a merchant's webhook deliveries, newest first, optionally only those to one endpoint.
+func (r *WebhookDeliveryRepository) List(
+	ctx context.Context,
+	merchantID, endpointID string,
+	limit int,
+) ([]*webhook.Delivery, error) {
+	query := r.db.WithContext(ctx).Where("merchant_id = ?", merchantID)
+	if endpointID != "" {
+		query = query.Where("endpoint_id = ?", endpointID)
+	}
+	return r.find(query, limit)
+}
+
+// ListMentioning lists a merchant's webhook deliveries whose payload contains the reference, newest first,
+// optionally only those to one endpoint.
+func (r *WebhookDeliveryRepository) ListMentioning(
+	ctx context.Context,
+	merchantID, endpointID, reference string,
+	limit int,
+) ([]*webhook.Delivery, error) {
+	query := r.db.WithContext(ctx).
+		Where("merchant_id = ? AND payload LIKE ? ESCAPE '\\'", merchantID, "%"+escapeLike(reference)+"%")
+	if endpointID != "" {
+		query = query.Where("endpoint_id = ?", endpointID)
+	}
+	return r.find(query, limit)
+}
+
+// CountUndelivered counts the events whose every delivery to an endpoint failed.
+func (r *WebhookDeliveryRepository) CountUndelivered(ctx context.Context) (int64, error) {
+	var count int64
+	undelivered := r.db.Model(&WebhookDeliveryModel{}).
+		Select("endpoint_id, event_id").
+		Group("endpoint_id, event_id").
+		Having("SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) = 0", string(webhook.DeliverySucceeded))
+	if err := r.db.WithContext(ctx).Table("(?) AS undelivered", undelivered).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count undelivered webhooks: %w", err)
+	}
+	return count, nil
+}
+
+// EndpointStats summarizes a merchant's deliveries made since the given time, per endpoint delivered to.
+func (r *WebhookDeliveryRepository) EndpointStats(
+	ctx context.Context,
+	merchantID string,
+	since time.Time,
+) ([]webhook.EndpointStats, error) {
+	db := r.db.WithContext(ctx)
+	deliveries := func() *gorm.DB {
+		return db.Model(&WebhookDeliveryModel{}).Where("merchant_id = ? AND created_at >= ?", merchantID, since)
+	}
+
+	var counts []struct {
+		EndpointID string
+		Status     string
+		Count      int
+		DurationMs int64
+	}
+	if err := deliveries().
+		Select("endpoint_id, status, COUNT(*) AS count, COALESCE(SUM(duration_ms), 0) AS duration_ms").
+		Group("endpoint_id, status").
+		Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	// The latest delivery of each outcome, selected rather than aggregated so that its time scans on every database
+	var latest []struct {
+		EndpointID string
+		Status     string
+		CreatedAt  time.Time
+	}
+	if err := deliveries().
+		Select("endpoint_id, status, created_at").
+		Where("created_at = (SELECT MAX(latest.created_at) FROM webhook_deliveries latest " +
+			"WHERE latest.endpoint_id = webhook_deliveries.endpoint_id AND latest.status = webhook_deliveries.status)").
+		Scan(&latest).Error; err != nil {
+		return nil, fmt.Errorf("failed to find the latest webhook deliveries: %w", err)
+	}
+
+	byEndpoint := make(map[string]*webhook.EndpointStats)
+	var order []string
+	statsOf := func(endpointID string) *webhook.EndpointStats {
+		stats, ok := byEndpoint[endpointID]
+		if !ok {
+			stats = &webhook.EndpointStats{EndpointID: endpointID}
+			byEndpoint[endpointID] = stats
+			order = append(order, endpointID)
+		}
+		return stats
+	}
+	for _, row := range counts {
+		stats := statsOf(row.EndpointID)
+		stats.Deliveries += row.Count
+		stats.TotalDuration += time.Duration(row.DurationMs) * time.Millisecond
+		if row.Status == string(webhook.DeliverySucceeded) {
+			stats.Succeeded += row.Count
+		} else {
+			stats.Failed += row.Count
+		}
+	}
+	for _, row := range latest {
+		stats := statsOf(row.EndpointID)
+		if row.Status == string(webhook.DeliverySucceeded) {
+			stats.LastSuccessAt = row.CreatedAt
+		} else {
+			stats.LastFailureAt = row.CreatedAt
+		}
+		if row.CreatedAt.After(stats.LastDeliveryAt) {
+			stats.LastDeliveryAt = row.CreatedAt
+		}
+	}
+
+	result := make([]webhook.EndpointStats, len(order))
+	for i, endpointID := range order {
+		result[i] = *byEndpoint[endpointID]
+	}
+	return result, nil
+}
+
+// find retrieves the deliveries matching the query, newest first.
+func (r *WebhookDeliveryRepository) find(query *gorm.DB, limit int) ([]*webhook.Delivery, error) {
+	var models []WebhookDeliveryModel
+	if err := query.Order("created_at DESC, id ASC").Limit(limit).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]*webhook.Delivery, len(models))
+	for i := range models {
+		delivery, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert webhook delivery model to domain: %w", err)
+		}
+		deliveries[i] = delivery
+	}
+	return deliveries, nil
+}
+
+// toModel converts a domain webhook delivery to a database model.
+func (r *WebhookDeliveryRepository) toModel(delivery *webhook.Delivery) *WebhookDeliveryModel {
+	return &WebhookDeliveryModel{
+		ID:           delivery.ID(),
+		MerchantID:   delivery.MerchantID(),
+		EndpointID:   delivery.EndpointID(),
+		EventID:      delivery.EventID(),
+		EventType:    delivery.EventType(),
+		APIVersion:   delivery.APIVersion(),
+		Kind:         string(delivery.Kind()),
+		ReplayOf:     delivery.ReplayOf(),
+		URL:          delivery.URL(),
+		Payload:      string(delivery.Payload()),
+		Status:       string(delivery.Status()),
+		ResponseCode: delivery.ResponseCode(),
+		ResponseBody: delivery.ResponseBody(),
+		Failure:      delivery.Failure(),
+		DurationMs:   delivery.Duration().Milliseconds(),
+		CreatedAt:    delivery.CreatedAt(),
+	}
+}
+
+// toDomain converts a database model to a domain webhook delivery.
+func (r *WebhookDeliveryRepository) toDomain(model *WebhookDeliveryModel) (*webhook.Delivery, error) {
+	return webhook.RestoreDelivery(
+		model.ID,
+		model.MerchantID,
+		model.EndpointID,
+		model.EventID,
+		model.EventType,
+		model.APIVersion,
+		webhook.DeliveryKind(model.Kind),
+		model.ReplayOf,
+		model.URL,
+		[]byte(model.Payload),
+		webhook.DeliveryStatus(model.Status),
+		model.ResponseCode,
+		model.ResponseBody,
+		model.Failure,
+		time.Duration(model.DurationMs)*time.Millisecond,
+		model.CreatedAt,
+	)
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern, with backslash as the escape character.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
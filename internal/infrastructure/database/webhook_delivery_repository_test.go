@@ -0,0 +1,127 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/webhook"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestWebhookDeliveryRepository(t *testing.T) {
+	repo := database.NewWebhookDeliveryRepository(setupTestDB(t), zap.NewNop())
+	ctx := context.Background()
+	sentAt := time.Date(2025, 1, 15, 10, 18, 30, 0, time.UTC)
+
+	newDelivery := func(id, merchantID, endpointID string, kind webhook.DeliveryKind, replayOf string,
+		createdAt time.Time,
+	) *webhook.Delivery {
+		delivery, err := webhook.RestoreDelivery(id, merchantID, endpointID, "evt_1", "webhook.test", "2026-10-17",
+			kind, replayOf, "https://merchant.example/webhook", []byte(`{"id":"evt_1"}`), webhook.DeliveryFailed,
+			503, "unavailable", "", 120*time.Millisecond, createdAt)
+		require.NoError(t, err)
+		return delivery
+	}
+
+	require.NoError(t, repo.Save(ctx, newDelivery("whd-1", "merchant-1", "whe-1", webhook.DeliveryKindTest, "",
+		sentAt)))
+	require.NoError(t, repo.Save(ctx, newDelivery("whd-2", "merchant-1", "whe-1", webhook.DeliveryKindReplay,
+		"whd-1", sentAt.Add(time.Minute))))
+	require.NoError(t, repo.Save(ctx, newDelivery("whd-3", "merchant-1", "whe-2", webhook.DeliveryKindTest, "",
+		sentAt.Add(2*time.Minute))))
+	require.NoError(t, repo.Save(ctx, newDelivery("whd-4", "merchant-2", "whe-3", webhook.DeliveryKindTest, "",
+		sentAt.Add(3*time.Minute))))
+
+	t.Run("FindByID", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, "whd-2")
+		require.NoError(t, err)
+		assert.Equal(t, "merchant-1", found.MerchantID())
+		assert.Equal(t, webhook.DeliveryKindReplay, found.Kind())
+		assert.Equal(t, "whd-1", found.ReplayOf())
+		assert.Equal(t, []byte(`{"id":"evt_1"}`), found.Payload())
+		assert.Equal(t, webhook.DeliveryFailed, found.Status())
+		assert.Equal(t, 503, found.ResponseCode())
+		assert.Equal(t, "unavailable", found.ResponseBody())
+		assert.Equal(t, 120*time.Millisecond, found.Duration())
+		assert.True(t, sentAt.Add(time.Minute).Equal(found.CreatedAt()))
+
+		_, err = repo.FindByID(ctx, "whd-missing")
+		require.ErrorIs(t, err, webhook.ErrDeliveryNotFound)
+	})
+
+	t.Run("FindByID_OtherTenant", func(t *testing.T) {
+		_, err := repo.FindByID(shared.WithMerchantID(ctx, "merchant-2"), "whd-1")
+		require.ErrorIs(t, err, webhook.ErrDeliveryNotFound)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		deliveries, err := repo.List(ctx, "merchant-1", "", 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 3)
+		assert.Equal(t, "whd-3", deliveries[0].ID())
+		assert.Equal(t, "whd-1", deliveries[2].ID())
+
+		deliveries, err = repo.List(ctx, "merchant-1", "whe-1", 1)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, "whd-2", deliveries[0].ID())
+	})
+	t.Run("ListMentioning", func(t *testing.T) {
+		deliveries, err := repo.ListMentioning(ctx, "merchant-1", "whe-1", "evt_1", 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		assert.Equal(t, "whd-2", deliveries[0].ID())
+
+		deliveries, err = repo.ListMentioning(ctx, "merchant-1", "", "evt_2", 10)
+		require.NoError(t, err)
+		assert.Empty(t, deliveries)
+
+		deliveries, err = repo.ListMentioning(ctx, "merchant-1", "", "evt%", 10)
+		require.NoError(t, err)
+		assert.Empty(t, deliveries, "LIKE wildcards in the reference must match literally")
+	})
+
+	t.Run("CountUndelivered", func(t *testing.T) {
+		count, err := repo.CountUndelivered(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count, "each endpoint has evt_1 undelivered")
+
+		delivered, err := webhook.RestoreDelivery("whd-5", "merchant-1", "whe-1", "evt_1", "webhook.test",
+			"2026-10-17", webhook.DeliveryKindReplay, "whd-2", "https://merchant.example/webhook",
+			[]byte(`{"id":"evt_1"}`), webhook.DeliverySucceeded, 200, "ok", "", 80*time.Millisecond,
+			sentAt.Add(4*time.Minute))
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, delivered))
+
+		count, err = repo.CountUndelivered(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count, "a successful replay clears the event")
+	})
+
+	t.Run("EndpointStats", func(t *testing.T) {
+		stats, err := repo.EndpointStats(ctx, "merchant-1", sentAt)
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+		assert.Equal(t, "whe-1", stats[0].EndpointID)
+		assert.Equal(t, 3, stats[0].Deliveries)
+		assert.Equal(t, 1, stats[0].Succeeded)
+		assert.Equal(t, 2, stats[0].Failed)
+		assert.Equal(t, 320*time.Millisecond, stats[0].TotalDuration)
+		assert.True(t, sentAt.Add(4*time.Minute).Equal(stats[0].LastDeliveryAt))
+		assert.True(t, sentAt.Add(4*time.Minute).Equal(stats[0].LastSuccessAt))
+		assert.True(t, sentAt.Add(time.Minute).Equal(stats[0].LastFailureAt))
+		assert.Equal(t, "whe-2", stats[1].EndpointID)
+		assert.Equal(t, 1, stats[1].Failed)
+		assert.True(t, stats[1].LastSuccessAt.IsZero())
+
+		stats, err = repo.EndpointStats(ctx, "merchant-1", sentAt.Add(3*time.Minute))
+		require.NoError(t, err)
+		require.Len(t, stats, 1)
+		assert.Equal(t, 1, stats[0].Deliveries)
+	})
+}
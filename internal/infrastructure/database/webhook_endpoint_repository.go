@@ -5,6 +5,7 @@ import (
 	"crypto-checkout/internal/domain/merchant"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -48,7 +49,7 @@ func (r *WebhookEndpointRepository) FindByID(ctx context.Context, id string) (*m
 	var model WebhookEndpointModel
 	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("webhook endpoint not found: %w", err)
+			return nil, fmt.Errorf("%w: %w", merchant.ErrWebhookEndpointNotFound, err)
 		}
 		return nil, fmt.Errorf("failed to find webhook endpoint: %w", err)
 	}
@@ -158,6 +159,11 @@ func (r *WebhookEndpointRepository) toModel(endpoint *merchant.WebhookEndpoint)
 		return nil, fmt.Errorf("failed to marshal headers: %w", err)
 	}
 
+	var previousSecretExpiresAt *time.Time
+	if expiresAt := endpoint.PreviousSecretExpiresAt(); !expiresAt.IsZero() {
+		previousSecretExpiresAt = &expiresAt
+	}
+
 	return &WebhookEndpointModel{
 		ID:           endpoint.ID(),
 		MerchantID:   endpoint.MerchantID(),
@@ -170,8 +176,12 @@ func (r *WebhookEndpointRepository) toModel(endpoint *merchant.WebhookEndpoint)
 		Timeout:      endpoint.Timeout(),
 		AllowedIPs:   string(allowedIPsJSON),
 		Headers:      string(headersJSON),
+		APIVersion:   string(endpoint.APIVersion()),
 		CreatedAt:    endpoint.CreatedAt(),
 		UpdatedAt:    endpoint.UpdatedAt(),
+
+		PreviousSecret:          endpoint.PreviousSecret(),
+		PreviousSecretExpiresAt: previousSecretExpiresAt,
 	}, nil
 }
 
@@ -227,5 +237,16 @@ func (r *WebhookEndpointRepository) toDomain(model *WebhookEndpointModel) (*merc
 		return nil, fmt.Errorf("failed to set webhook endpoint status: %w", err)
 	}
 
+	apiVersion := merchant.WebhookAPIVersion(model.APIVersion)
+	if apiVersion == "" {
+		apiVersion = merchant.LegacyWebhookAPIVersion
+	}
+	if err := endpoint.UpdateAPIVersion(apiVersion); err != nil {
+		return nil, fmt.Errorf("invalid API version from database: %w", err)
+	}
+	if model.PreviousSecretExpiresAt != nil {
+		endpoint.RestorePreviousSecret(model.PreviousSecret, *model.PreviousSecretExpiresAt)
+	}
+
 	return endpoint, nil
 }
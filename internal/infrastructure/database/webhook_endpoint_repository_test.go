@@ -0,0 +1,43 @@
+package database_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/infrastructure/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestWebhookEndpointRepository_APIVersion(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&database.WebhookEndpointModel{}))
+	repo := database.NewWebhookEndpointRepository(db, zap.NewNop())
+	ctx := context.Background()
+
+	endpoint, err := merchant.NewWebhookEndpoint("whe-1", "merchant-1", "https://merchant.example/webhook",
+		[]string{"invoice.paid"}, "whsec_0123456789abcdef0123456789abcdef", 3, merchant.BackoffStrategyExponential,
+		30, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, endpoint.UpdateAPIVersion(merchant.WebhookAPIVersion20250115))
+	require.NoError(t, repo.Save(ctx, endpoint))
+
+	found, err := repo.FindByID(ctx, "whe-1")
+	require.NoError(t, err)
+	assert.Equal(t, merchant.WebhookAPIVersion20250115, found.APIVersion())
+
+	require.NoError(t, found.UpdateAPIVersion(merchant.LatestWebhookAPIVersion))
+	require.NoError(t, repo.Update(ctx, found))
+	found, err = repo.FindByID(ctx, "whe-1")
+	require.NoError(t, err)
+	assert.Equal(t, merchant.LatestWebhookAPIVersion, found.APIVersion())
+
+	t.Run("endpoints stored before versions keep the legacy payloads", func(t *testing.T) {
+		require.NoError(t, db.Exec("UPDATE webhook_endpoints SET api_version = '' WHERE id = ?", "whe-1").Error)
+		found, err := repo.FindByID(ctx, "whe-1")
+		require.NoError(t, err)
+		assert.Equal(t, merchant.LegacyWebhookAPIVersion, found.APIVersion())
+	})
+}
@@ -0,0 +1,216 @@
+// Package encryption seals sensitive values, such as webhook secrets, for storage with envelope encryption: each
+// value is encrypted with AES-256-GCM under its own data key, which is stored alongside it wrapped by a master key.
+package encryption
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	// sealedPrefix starts every sealed value, followed by the ID of the master key, the wrapped data key and the
+	// ciphertext, separated by colons.
+	sealedPrefix = "enc:v1:"
+	// keyBytes is the size of master and data keys, for AES-256.
+	keyBytes = 32
+)
+
+var (
+	// ErrUnknownKey is returned for a value sealed under a master key that is not in the keyring.
+	ErrUnknownKey = errors.New("encryption: value is sealed with an unknown master key")
+	// ErrMalformed is returned for a sealed value that cannot be decoded or whose authentication fails.
+	ErrMalformed = errors.New("encryption: sealed value is malformed or was tampered with")
+)
+
+// Keyring holds the master keys values are sealed with. The current key seals; the others only open values
+// sealed before a rotation.
+type Keyring struct {
+	current string
+	keys    map[string]cipher.AEAD
+}
+
+// NewKeyring creates a keyring from master keys by ID. The current key must be among them.
+func NewKeyring(current string, keys map[string][]byte) (*Keyring, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("encryption: current master key %q is missing", current)
+	}
+
+	keyring := &Keyring{current: current, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("encryption: invalid master key ID %q", id)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("encryption: master key %q: %w", id, err)
+		}
+		keyring.keys[id] = aead
+	}
+	return keyring, nil
+}
+
+// LoadKeyFile reads a keyring from a key file, as written by hand or by a KMS agent: one "id:base64-key" line per
+// 32-byte master key, the current key first. Blank lines and lines starting with # are skipped.
+func LoadKeyFile(path string) (*Keyring, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("encryption: failed to open key file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	return ParseKeyFile(file)
+}
+
+// ParseKeyFile reads a keyring in the key file format; see LoadKeyFile.
+func ParseKeyFile(r io.Reader) (*Keyring, error) {
+	var current string
+	keys := make(map[string][]byte)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		id, encoded, ok := strings.Cut(text, ":")
+		if !ok {
+			return nil, fmt.Errorf("encryption: key file line %d is not \"id:base64-key\"", line)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("encryption: key file line %d: invalid base64: %w", line, err)
+		}
+		if _, duplicate := keys[id]; duplicate {
+			return nil, fmt.Errorf("encryption: key file line %d: duplicate key ID %q", line, id)
+		}
+		if current == "" {
+			current = id
+		}
+		keys[id] = key
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("encryption: failed to read key file: %w", err)
+	}
+	if current == "" {
+		return nil, errors.New("encryption: key file holds no keys")
+	}
+	return NewKeyring(current, keys)
+}
+
+// CurrentKeyID returns the ID of the master key new values are sealed with.
+func (k *Keyring) CurrentKeyID() string {
+	return k.current
+}
+
+// Seal encrypts a value under a fresh data key wrapped by the current master key. The additional data, such as
+// the column the value is stored in, must be given again to open it.
+func (k *Keyring) Seal(plaintext, additionalData []byte) (string, error) {
+	dataKey := make([]byte, keyBytes)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("encryption: failed to generate data key: %w", err)
+	}
+	wrapped, err := seal(k.keys[k.current], dataKey, []byte(k.current))
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(aead, plaintext, additionalData)
+	if err != nil {
+		return "", err
+	}
+
+	return sealedPrefix + k.current + ":" + base64.RawURLEncoding.EncodeToString(wrapped) + ":" +
+		base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// Open decrypts a value sealed with any key of the keyring.
+func (k *Keyring) Open(sealed string, additionalData []byte) ([]byte, error) {
+	parts := strings.Split(strings.TrimPrefix(sealed, sealedPrefix), ":")
+	if !IsSealed(sealed) || len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+	master, ok := k.keys[parts[0]]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, parts[0])
+	}
+
+	wrapped, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	dataKey, err := open(master, wrapped, []byte(parts[0]))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	ciphertext, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	return open(aead, ciphertext, additionalData)
+}
+
+// NeedsRotation reports whether a stored value is not yet sealed with the current master key: it is stored in
+// plain text or sealed with an older key.
+func (k *Keyring) NeedsRotation(stored string) bool {
+	return !strings.HasPrefix(stored, sealedPrefix+k.current+":")
+}
+
+// CurrentPrefix returns the prefix of the values sealed with the current master key, for finding the others.
+func (k *Keyring) CurrentPrefix() string {
+	return sealedPrefix + k.current + ":"
+}
+
+// IsSealed reports whether a stored value was sealed, rather than stored in plain text.
+func IsSealed(stored string) bool {
+	return strings.HasPrefix(stored, sealedPrefix)
+}
+
+// newAEAD returns the AES-256-GCM cipher of a key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != keyBytes {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", keyBytes, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts data with a fresh nonce; the output is the nonce and the ciphertext.
+func seal(aead cipher.AEAD, data, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("encryption: failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, data, additionalData), nil
+}
+
+// open decrypts data sealed by seal.
+func open(aead cipher.AEAD, data, additionalData []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+	plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], additionalData)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	return plain, nil
+}
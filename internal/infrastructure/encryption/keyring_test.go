@@ -0,0 +1,95 @@
+package encryption_test
+
+import (
+	"bytes"
+	"crypto-checkout/internal/infrastructure/encryption"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func TestKeyring_SealOpen(t *testing.T) {
+	keyring, err := encryption.NewKeyring("k1", map[string][]byte{"k1": testKey(1)})
+	require.NoError(t, err)
+
+	sealed, err := keyring.Seal([]byte("whsec_123"), []byte("webhook_endpoints.secret"))
+	require.NoError(t, err)
+	assert.True(t, encryption.IsSealed(sealed))
+	assert.True(t, strings.HasPrefix(sealed, keyring.CurrentPrefix()))
+	assert.NotContains(t, sealed, "whsec_123")
+
+	again, err := keyring.Seal([]byte("whsec_123"), []byte("webhook_endpoints.secret"))
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, again, "every value is sealed under a fresh data key")
+
+	plain, err := keyring.Open(sealed, []byte("webhook_endpoints.secret"))
+	require.NoError(t, err)
+	assert.Equal(t, "whsec_123", string(plain))
+
+	_, err = keyring.Open(sealed, []byte("users.totp_secret"))
+	require.ErrorIs(t, err, encryption.ErrMalformed)
+	_, err = keyring.Open(sealed[:len(sealed)-2], []byte("webhook_endpoints.secret"))
+	require.ErrorIs(t, err, encryption.ErrMalformed)
+	_, err = keyring.Open("whsec_123", nil)
+	require.ErrorIs(t, err, encryption.ErrMalformed)
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	old, err := encryption.NewKeyring("k1", map[string][]byte{"k1": testKey(1)})
+	require.NoError(t, err)
+	sealed, err := old.Seal([]byte("secret"), nil)
+	require.NoError(t, err)
+
+	rotated, err := encryption.NewKeyring("k2", map[string][]byte{"k1": testKey(1), "k2": testKey(2)})
+	require.NoError(t, err)
+	assert.True(t, rotated.NeedsRotation(sealed))
+	assert.True(t, rotated.NeedsRotation("secret"))
+	plain, err := rotated.Open(sealed, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(plain))
+
+	resealed, err := rotated.Seal(plain, nil)
+	require.NoError(t, err)
+	assert.False(t, rotated.NeedsRotation(resealed))
+
+	_, err = old.Open(resealed, nil)
+	require.ErrorIs(t, err, encryption.ErrUnknownKey)
+}
+
+func TestNewKeyring_Invalid(t *testing.T) {
+	_, err := encryption.NewKeyring("k2", map[string][]byte{"k1": testKey(1)})
+	require.Error(t, err)
+	_, err = encryption.NewKeyring("k1", map[string][]byte{"k1": testKey(1)[:16]})
+	require.Error(t, err)
+	_, err = encryption.NewKeyring("k:1", map[string][]byte{"k:1": testKey(1)})
+	require.Error(t, err)
+}
+
+func TestParseKeyFile(t *testing.T) {
+	file := "# rotated 2026-10\n\n" +
+		"2026-10:" + base64.StdEncoding.EncodeToString(testKey(2)) + "\n" +
+		"2026-01:" + base64.StdEncoding.EncodeToString(testKey(1)) + "\n"
+	keyring, err := encryption.ParseKeyFile(strings.NewReader(file))
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10", keyring.CurrentKeyID())
+
+	for name, content := range map[string]string{
+		"empty":      "# no keys\n",
+		"no id":      base64.StdEncoding.EncodeToString(testKey(1)) + "\n",
+		"bad base64": "k1:not base64\n",
+		"duplicate": "k1:" + base64.StdEncoding.EncodeToString(testKey(1)) + "\n" +
+			"k1:" + base64.StdEncoding.EncodeToString(testKey(2)) + "\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := encryption.ParseKeyFile(strings.NewReader(content))
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package errorreporting
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/pkg/config"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides the error reporter for Fx.
+var Module = fx.Module("errorreporting",
+	fx.Provide(
+		fx.Annotate(
+			NewReporterProvider,
+			fx.As(fx.Self()),
+			fx.As(new(shared.ErrorReporter)),
+		),
+	),
+	fx.Invoke(RegisterReporter),
+)
+
+// NewReporterProvider creates the error reporter from configuration. Without a DSN nothing is reported.
+func NewReporterProvider(cfg *config.Config, logger *zap.Logger) (*Reporter, error) {
+	reporting := cfg.ErrorReporting
+	if reporting.SampleRate < 0 || reporting.SampleRate > 1 {
+		return nil, fmt.Errorf("invalid error_reporting.sample_rate: %v is not between 0 and 1",
+			reporting.SampleRate)
+	}
+	options := Options{
+		Environment: reporting.Environment,
+		Release:     reporting.Release,
+		SampleRate:  reporting.SampleRate,
+	}
+	if reporting.DSN == "" {
+		return NewReporter(nil, options, logger), nil
+	}
+
+	timeout := reporting.Timeout
+	if timeout <= 0 {
+		timeout = config.DefaultErrorReportingTimeout
+	}
+	transport, err := NewSentryTransport(reporting.DSN, &http.Client{Timeout: timeout})
+	if err != nil {
+		return nil, fmt.Errorf("error_reporting.dsn: %w", err)
+	}
+	logger.Info("Reporting errors to Sentry",
+		zap.String("environment", reporting.Environment),
+		zap.Float64("sample_rate", reporting.SampleRate),
+	)
+	return NewReporter(transport, options, logger), nil
+}
+
+// RegisterReporter sends the queued reports in the background for the lifetime of the application, and the
+// reports still queued when it stops.
+func RegisterReporter(lc fx.Lifecycle, reporter *Reporter) {
+	if !reporter.Enabled() {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			go func() {
+				defer close(done)
+				reporter.Run(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+				return nil
+			}
+			reporter.Flush(stopCtx)
+			return nil
+		},
+	})
+}
@@ -0,0 +1,189 @@
+// Package errorreporting sends the unexpected failures of the crypto-checkout application to an error tracker.
+package errorreporting
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/shared"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	mathrand "math/rand/v2"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// LevelError is the level of reported errors.
+	LevelError = "error"
+	// LevelFatal is the level of reported panics.
+	LevelFatal = "fatal"
+
+	// DefaultQueueSize is how many reports may wait to be sent before new ones are dropped.
+	DefaultQueueSize = 100
+)
+
+// Event is a single report sent to the error tracker.
+type Event struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   time.Time         `json:"timestamp"`
+	Level       string            `json:"level"`
+	Platform    string            `json:"platform"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Message     string            `json:"message"`
+	Exception   *Exceptions       `json:"exception,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Extra       map[string]any    `json:"extra,omitempty"`
+}
+
+// Exceptions holds the error an event reports.
+type Exceptions struct {
+	Values []Exception `json:"values"`
+}
+
+// Exception is the type and message of a reported error.
+type Exception struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Transport delivers events to an error tracker.
+type Transport interface {
+	Send(ctx context.Context, event *Event) error
+}
+
+// Options configures the reports.
+type Options struct {
+	// Environment and Release are attached to every report.
+	Environment string
+	Release     string
+	// SampleRate is the fraction of errors reported, from 0 to 1. Panics are always reported.
+	SampleRate float64
+	// QueueSize is how many reports may wait to be sent before new ones are dropped.
+	QueueSize int
+}
+
+// Reporter implements shared.ErrorReporter. Reports are queued and sent in the background, so capturing never
+// waits on the error tracker; reports arriving while the queue is full are dropped. A reporter without a
+// transport reports nothing.
+type Reporter struct {
+	transport Transport
+	options   Options
+	logger    *zap.Logger
+	sample    func() float64
+	queue     chan *Event
+}
+
+// NewReporter creates a new error reporter sending through the transport, which may be nil.
+func NewReporter(transport Transport, options Options, logger *zap.Logger) *Reporter {
+	if options.QueueSize <= 0 {
+		options.QueueSize = DefaultQueueSize
+	}
+	return &Reporter{
+		transport: transport,
+		options:   options,
+		logger:    logger,
+		sample:    mathrand.Float64,
+		queue:     make(chan *Event, options.QueueSize),
+	}
+}
+
+// Enabled reports whether the reporter has an error tracker to send to.
+func (r *Reporter) Enabled() bool {
+	return r.transport != nil
+}
+
+// CaptureError reports an error, subject to sampling.
+func (r *Reporter) CaptureError(ctx context.Context, err error, tags map[string]string) {
+	if !r.Enabled() || err == nil || r.sample() >= r.options.SampleRate {
+		return
+	}
+	event := r.newEvent(ctx, LevelError, err.Error(), tags)
+	event.Exception = &Exceptions{Values: []Exception{{Type: fmt.Sprintf("%T", err), Value: err.Error()}}}
+	r.enqueue(event)
+}
+
+// CapturePanic reports a recovered panic with its stack.
+func (r *Reporter) CapturePanic(ctx context.Context, recovered any, stack []byte, tags map[string]string) {
+	if !r.Enabled() {
+		return
+	}
+	message := fmt.Sprintf("panic: %v", recovered)
+	event := r.newEvent(ctx, LevelFatal, message, tags)
+	event.Exception = &Exceptions{Values: []Exception{{Type: "panic", Value: fmt.Sprint(recovered)}}}
+	event.Extra = map[string]any{"stack": string(stack)}
+	r.enqueue(event)
+}
+
+// Run sends queued reports until the context is cancelled.
+func (r *Reporter) Run(ctx context.Context) {
+	for {
+		select {
+		case event := <-r.queue:
+			r.send(ctx, event)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Flush sends the reports still queued, until there are none left or the context is done.
+func (r *Reporter) Flush(ctx context.Context) {
+	for ctx.Err() == nil {
+		select {
+		case event := <-r.queue:
+			r.send(ctx, event)
+		default:
+			return
+		}
+	}
+}
+
+// newEvent creates an event tagged with the merchant and request of the context and the given tags.
+func (r *Reporter) newEvent(ctx context.Context, level, message string, tags map[string]string) *Event {
+	eventTags := make(map[string]string, len(tags)+2)
+	if merchantID, ok := shared.MerchantIDFromContext(ctx); ok {
+		eventTags["merchant_id"] = merchantID
+	}
+	if requestID := audit.OriginFromContext(ctx).RequestID; requestID != "" {
+		eventTags["request_id"] = requestID
+	}
+	maps.Copy(eventTags, tags)
+
+	return &Event{
+		EventID:     newEventID(),
+		Timestamp:   time.Now().UTC(),
+		Level:       level,
+		Platform:    "go",
+		Environment: r.options.Environment,
+		Release:     r.options.Release,
+		Message:     message,
+		Tags:        eventTags,
+	}
+}
+
+// enqueue queues an event to be sent, dropping it when the queue is full.
+func (r *Reporter) enqueue(event *Event) {
+	select {
+	case r.queue <- event:
+	default:
+		r.logger.Warn("Error report dropped; the report queue is full", zap.String("message", event.Message))
+	}
+}
+
+// send delivers an event, logging a failure rather than reporting it.
+func (r *Reporter) send(ctx context.Context, event *Event) {
+	if err := r.transport.Send(ctx, event); err != nil && ctx.Err() == nil {
+		r.logger.Warn("Failed to send error report", zap.String("event_id", event.EventID), zap.Error(err))
+	}
+}
+
+// newEventID generates a random 32 character hexadecimal event ID.
+func newEventID() string {
+	bytes := make([]byte, 16)
+	_, _ = rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
@@ -0,0 +1,115 @@
+package errorreporting
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// recordingTransport keeps the events it is sent.
+type recordingTransport struct {
+	events []*Event
+}
+
+func (t *recordingTransport) Send(_ context.Context, event *Event) error {
+	t.events = append(t.events, event)
+	return nil
+}
+
+func TestReporter(t *testing.T) {
+	ctx := shared.WithMerchantID(context.Background(), "merchant-1")
+	ctx = audit.WithOrigin(ctx, audit.Origin{RequestID: "req_1"})
+
+	t.Run("Errors_Are_Tagged_With_Merchant_And_Request", func(t *testing.T) {
+		transport := &recordingTransport{}
+		reporter := NewReporter(transport, Options{Environment: "staging", SampleRate: 1}, zap.NewNop())
+
+		reporter.CaptureError(ctx, errors.New("handler failed"), map[string]string{"event_type": "invoice.paid"})
+		reporter.Flush(context.Background())
+
+		require.Len(t, transport.events, 1)
+		event := transport.events[0]
+		assert.Equal(t, LevelError, event.Level)
+		assert.Equal(t, "staging", event.Environment)
+		assert.Equal(t, "handler failed", event.Message)
+		assert.Len(t, event.EventID, 32)
+		assert.Equal(t, map[string]string{
+			"merchant_id": "merchant-1",
+			"request_id":  "req_1",
+			"event_type":  "invoice.paid",
+		}, event.Tags)
+		require.NotNil(t, event.Exception)
+		assert.Equal(t, "*errors.errorString", event.Exception.Values[0].Type)
+	})
+
+	t.Run("Errors_Are_Sampled_But_Panics_Are_Not", func(t *testing.T) {
+		transport := &recordingTransport{}
+		reporter := NewReporter(transport, Options{SampleRate: 0.25}, zap.NewNop())
+		reporter.sample = func() float64 { return 0.5 }
+
+		reporter.CaptureError(ctx, errors.New("dropped by sampling"), nil)
+		reporter.CapturePanic(ctx, "boom", []byte("goroutine 1 [running]"), map[string]string{"worker": "chain_watcher"})
+		reporter.Flush(context.Background())
+
+		require.Len(t, transport.events, 1)
+		event := transport.events[0]
+		assert.Equal(t, LevelFatal, event.Level)
+		assert.Equal(t, "panic: boom", event.Message)
+		assert.Equal(t, "goroutine 1 [running]", event.Extra["stack"])
+		assert.Equal(t, "chain_watcher", event.Tags["worker"])
+	})
+
+	t.Run("Full_Queue_Drops_Reports", func(t *testing.T) {
+		transport := &recordingTransport{}
+		reporter := NewReporter(transport, Options{SampleRate: 1, QueueSize: 1}, zap.NewNop())
+
+		reporter.CaptureError(ctx, errors.New("first"), nil)
+		reporter.CaptureError(ctx, errors.New("second"), nil)
+		reporter.Flush(context.Background())
+
+		require.Len(t, transport.events, 1)
+		assert.Equal(t, "first", transport.events[0].Message)
+	})
+
+	t.Run("Without_Transport_Nothing_Is_Reported", func(t *testing.T) {
+		reporter := NewReporter(nil, Options{SampleRate: 1}, zap.NewNop())
+		assert.False(t, reporter.Enabled())
+		reporter.CaptureError(ctx, errors.New("ignored"), nil)
+		reporter.CapturePanic(ctx, "ignored", nil, nil)
+		assert.Empty(t, reporter.queue)
+	})
+}
+
+func TestSentryTransport(t *testing.T) {
+	var received Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/sentry/api/42/store/", r.URL.Path)
+		assert.Equal(t, "Sentry sentry_version=7, sentry_client=crypto-checkout/1.0, sentry_key=public",
+			r.Header.Get("X-Sentry-Auth"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dsn := "http://public@" + server.Listener.Addr().String() + "/sentry/42"
+	transport, err := NewSentryTransport(dsn, &http.Client{Timeout: time.Second})
+	require.NoError(t, err)
+	require.NoError(t, transport.Send(context.Background(), &Event{EventID: "abc", Message: "boom", Level: LevelError}))
+	assert.Equal(t, "abc", received.EventID)
+	assert.Equal(t, "boom", received.Message)
+
+	for _, invalid := range []string{"ftp://key@sentry.io/1", "https://sentry.io/1", "https://key@sentry.io/"} {
+		_, err := NewSentryTransport(invalid, http.DefaultClient)
+		assert.Error(t, err, invalid)
+	}
+}
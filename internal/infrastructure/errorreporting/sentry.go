@@ -0,0 +1,77 @@
+package errorreporting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// sentryClient identifies the reporter to Sentry.
+const sentryClient = "crypto-checkout/1.0"
+
+// SentryTransport sends events to the store endpoint of a Sentry project.
+type SentryTransport struct {
+	endpoint  string
+	publicKey string
+	client    *http.Client
+}
+
+// NewSentryTransport creates a transport for the project of a Sentry DSN, e.g.
+// "https://public-key@o0.ingest.sentry.io/42".
+func NewSentryTransport(dsn string, client *http.Client) (*SentryTransport, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Sentry DSN: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid Sentry DSN: unsupported scheme %q", u.Scheme)
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return nil, errors.New("invalid Sentry DSN: missing public key")
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	slash := strings.LastIndex(path, "/")
+	projectID := path[slash+1:]
+	if projectID == "" {
+		return nil, errors.New("invalid Sentry DSN: missing project ID")
+	}
+
+	return &SentryTransport{
+		endpoint:  fmt.Sprintf("%s://%s%s/api/%s/store/", u.Scheme, u.Host, path[:slash], projectID),
+		publicKey: u.User.Username(),
+		client:    client,
+	}, nil
+}
+
+// Send delivers an event to Sentry.
+func (t *SentryTransport) Send(ctx context.Context, event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s",
+		sentryClient, t.publicKey))
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send event: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("sentry responded %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -25,6 +25,7 @@ var Module = fx.Module("events",
 		fx.Annotate(
 			NewEventBus,
 			fx.As(new(shared.EventBus)),
+			fx.As(new(shared.EventHandlerRegistry)),
 		),
 	),
 	fx.Invoke(
@@ -45,16 +46,17 @@ func NewKafkaConfig(cfg *config.Config, logger *zap.Logger) *KafkaConfig {
 	topics := map[string]string{
 		"*": cfg.Kafka.TopicDomainEvents,
 		// Map specific event types to topics
-		shared.EventTypeInvoiceCreated:   cfg.Kafka.TopicDomainEvents,
-		shared.EventTypeInvoicePaid:      cfg.Kafka.TopicDomainEvents,
-		shared.EventTypeInvoiceExpired:   cfg.Kafka.TopicDomainEvents,
-		shared.EventTypeInvoiceCancelled: cfg.Kafka.TopicDomainEvents,
-		shared.EventTypePaymentDetected:  cfg.Kafka.TopicDomainEvents,
-		shared.EventTypePaymentConfirmed: cfg.Kafka.TopicDomainEvents,
-		shared.EventTypePaymentFailed:    cfg.Kafka.TopicDomainEvents,
-		shared.EventTypeWebhookDelivery:  cfg.Kafka.TopicIntegrations,
-		shared.EventTypeNotificationSent: cfg.Kafka.TopicNotifications,
-		shared.EventTypeAnalyticsUpdated: cfg.Kafka.TopicAnalytics,
+		shared.EventTypeInvoiceCreated:      cfg.Kafka.TopicDomainEvents,
+		shared.EventTypeInvoicePaid:         cfg.Kafka.TopicDomainEvents,
+		shared.EventTypeInvoiceExpired:      cfg.Kafka.TopicDomainEvents,
+		shared.EventTypeInvoiceCancelled:    cfg.Kafka.TopicDomainEvents,
+		shared.EventTypePaymentDetected:     cfg.Kafka.TopicDomainEvents,
+		shared.EventTypePaymentConfirmed:    cfg.Kafka.TopicDomainEvents,
+		shared.EventTypePaymentFailed:       cfg.Kafka.TopicDomainEvents,
+		shared.EventTypePaymentConfirmation: cfg.Kafka.TopicDomainEvents,
+		shared.EventTypeWebhookDelivery:     cfg.Kafka.TopicIntegrations,
+		shared.EventTypeNotificationSent:    cfg.Kafka.TopicNotifications,
+		shared.EventTypeAnalyticsUpdated:    cfg.Kafka.TopicAnalytics,
 	}
 
 	return &KafkaConfig{
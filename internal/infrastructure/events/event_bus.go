@@ -9,31 +9,54 @@ import (
 )
 
 // EventBus implements both EventStore and EventPublisher interfaces.
+// Published events are also dispatched to the handlers registered with the bus in this process,
+// and the events a handler fails on are dead-lettered and reported. Events are validated against their registered
+// schema first; an invalid event is rejected before it is stored, dispatched or published.
 type EventBus struct {
+	*HandlerRegistry
+
 	store     shared.EventStore
 	publisher shared.EventPublisher
+	schemas   *shared.EventRegistry
 	logger    *zap.Logger
 }
 
 // NewEventBus creates a new event bus that combines event store and publisher.
-func NewEventBus(store shared.EventStore, publisher shared.EventPublisher, logger *zap.Logger) *EventBus {
+func NewEventBus(
+	store shared.EventStore,
+	publisher shared.EventPublisher,
+	deadLetters shared.DeadLetterRecorder,
+	reporter shared.ErrorReporter,
+	logger *zap.Logger,
+) *EventBus {
 	logger.Info("Creating EventBus",
 		zap.Bool("store_provided", store != nil),
 		zap.Bool("publisher_provided", publisher != nil))
 
+	registry := NewHandlerRegistry(logger)
+	registry.deadLetters = deadLetters
+	registry.reporter = reporter
+
 	return &EventBus{
-		store:     store,
-		publisher: publisher,
-		logger:    logger,
+		HandlerRegistry: registry,
+		store:           store,
+		publisher:       publisher,
+		schemas:         shared.DefaultEventRegistry(),
+		logger:          logger,
 	}
 }
 
 // AppendEvents stores events and publishes them.
 func (b *EventBus) AppendEvents(ctx context.Context, aggregateID string, events []*shared.BaseDomainEvent) error {
+	if err := b.validate(events...); err != nil {
+		return err
+	}
+
 	// First, store events in the event store
 	if err := b.store.AppendEvents(ctx, aggregateID, events); err != nil {
 		return fmt.Errorf("failed to store events: %w", err)
 	}
+	b.Dispatch(ctx, events...)
 
 	// Then, publish events to Kafka
 	if err := b.publisher.PublishEvents(ctx, events); err != nil {
@@ -75,12 +98,32 @@ func (b *EventBus) GetEventsByType(
 	return b.store.GetEventsByType(ctx, eventType, limit)
 }
 
-// PublishEvent publishes a single event.
+// PublishEvent dispatches a single event to in-process handlers and publishes it.
+// In-process handlers run even if publishing to Kafka fails.
 func (b *EventBus) PublishEvent(ctx context.Context, event *shared.BaseDomainEvent) error {
+	if err := b.validate(event); err != nil {
+		return err
+	}
+	b.Dispatch(ctx, event)
 	return b.publisher.PublishEvent(ctx, event)
 }
 
-// PublishEvents publishes multiple events.
+// PublishEvents dispatches multiple events to in-process handlers and publishes them.
 func (b *EventBus) PublishEvents(ctx context.Context, events []*shared.BaseDomainEvent) error {
+	if err := b.validate(events...); err != nil {
+		return err
+	}
+	b.Dispatch(ctx, events...)
 	return b.publisher.PublishEvents(ctx, events)
 }
+
+// validate checks events against their registered schemas, rejecting a batch with any invalid event.
+func (b *EventBus) validate(events ...*shared.BaseDomainEvent) error {
+	for _, event := range events {
+		if err := b.schemas.Validate(event); err != nil {
+			b.logger.Error("Rejected invalid domain event", zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package events_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/events"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type recordingPublisher struct {
+	events []*shared.BaseDomainEvent
+}
+
+func (p *recordingPublisher) PublishEvent(_ context.Context, event *shared.BaseDomainEvent) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *recordingPublisher) PublishEvents(_ context.Context, events []*shared.BaseDomainEvent) error {
+	p.events = append(p.events, events...)
+	return nil
+}
+
+type recordingHandler struct {
+	events []*shared.BaseDomainEvent
+}
+
+func (h *recordingHandler) HandleEvent(_ context.Context, event *shared.BaseDomainEvent) error {
+	h.events = append(h.events, event)
+	return nil
+}
+
+func (h *recordingHandler) EventTypes() []string {
+	return []string{shared.EventTypePayoutConfirmed}
+}
+
+func newTestBus() (*events.EventBus, *events.MockEventStore, *recordingPublisher, *recordingHandler) {
+	store := events.NewMockEventStore()
+	publisher := &recordingPublisher{}
+	handler := &recordingHandler{}
+	bus := events.NewEventBus(store, publisher, nil, nil, zap.NewNop())
+	bus.RegisterHandler(handler)
+	return bus, store, publisher, handler
+}
+
+func payoutConfirmed() *shared.BaseDomainEvent {
+	return shared.NewPayoutConfirmedEvent(shared.PayoutEvent{
+		PayoutID:      "payout-1",
+		MerchantID:    "merchant-1",
+		Network:       "ethereum",
+		Address:       "0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
+		Amount:        "1.5",
+		Currency:      "ETH",
+		SettlementIDs: []string{"settlement-1"},
+		Status:        "confirmed",
+		TxHash:        "0xabc",
+	})
+}
+
+func TestEventBus_ValidatesEvents(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("PublishesValidEvents", func(t *testing.T) {
+		bus, _, publisher, handler := newTestBus()
+
+		require.NoError(t, bus.PublishEvent(ctx, payoutConfirmed()))
+		assert.Len(t, handler.events, 1)
+		assert.Len(t, publisher.events, 1)
+	})
+
+	t.Run("RejectsUnknownFields", func(t *testing.T) {
+		bus, _, publisher, handler := newTestBus()
+		event := shared.CreateDomainEvent(shared.EventTypePayoutConfirmed, "payout-1", "Payout",
+			map[string]interface{}{"payout_id": "payout-1", "stauts": "confirmed"}, nil)
+
+		err := bus.PublishEvent(ctx, event)
+		require.ErrorIs(t, err, shared.ErrInvalidEvent)
+		assert.Contains(t, err.Error(), `unknown field "stauts"`)
+		assert.Empty(t, handler.events)
+		assert.Empty(t, publisher.events)
+	})
+
+	t.Run("RejectsUnregisteredEventTypes", func(t *testing.T) {
+		bus, _, publisher, _ := newTestBus()
+		event := shared.CreateDomainEvent("payout.confirmd", "payout-1", "Payout", map[string]interface{}{}, nil)
+
+		require.ErrorIs(t, bus.PublishEvents(ctx, []*shared.BaseDomainEvent{payoutConfirmed(), event}),
+			shared.ErrInvalidEvent)
+		assert.Empty(t, publisher.events)
+	})
+
+	t.Run("AppendEvents_DoesNotStoreInvalidEvents", func(t *testing.T) {
+		bus, store, publisher, _ := newTestBus()
+		event := payoutConfirmed()
+		event.AggregateType = "Settlement"
+
+		require.ErrorIs(t, bus.AppendEvents(ctx, "payout-1", []*shared.BaseDomainEvent{event}),
+			shared.ErrInvalidEvent)
+		stored, err := store.GetEvents(ctx, "payout-1")
+		require.NoError(t, err)
+		assert.Empty(t, stored)
+		assert.Empty(t, publisher.events)
+
+		require.NoError(t, bus.AppendEvents(ctx, "payout-1", []*shared.BaseDomainEvent{payoutConfirmed()}))
+		stored, err = store.GetEvents(ctx, "payout-1")
+		require.NoError(t, err)
+		assert.Len(t, stored, 1)
+	})
+}
@@ -0,0 +1,120 @@
+package events
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// HandlerRegistry holds in-process event handlers and dispatches published events to them.
+// It lets components in this instance react to events, such as caches invalidating entries,
+// without a round trip through Kafka.
+type HandlerRegistry struct {
+	handlers    map[string][]shared.EventHandler
+	deadLetters shared.DeadLetterRecorder
+	reporter    shared.ErrorReporter
+	logger      *zap.Logger
+	mu          sync.RWMutex
+}
+
+// NewHandlerRegistry creates a new, empty handler registry.
+func NewHandlerRegistry(logger *zap.Logger) *HandlerRegistry {
+	return &HandlerRegistry{
+		handlers: make(map[string][]shared.EventHandler),
+		logger:   logger,
+	}
+}
+
+// RegisterHandler registers an event handler for the event types it declares.
+func (r *HandlerRegistry) RegisterHandler(handler shared.EventHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, eventType := range handler.EventTypes() {
+		r.handlers[eventType] = append(r.handlers[eventType], handler)
+		r.logger.Debug("Registered in-process event handler",
+			zap.String("event_type", eventType),
+			zap.String("handler_type", fmt.Sprintf("%T", handler)))
+	}
+}
+
+// GetHandlers returns the handlers registered for an event type.
+func (r *HandlerRegistry) GetHandlers(eventType string) []shared.EventHandler {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	handlers := make([]shared.EventHandler, len(r.handlers[eventType]))
+	copy(handlers, r.handlers[eventType])
+	return handlers
+}
+
+// GetAllHandlers returns a copy of all registered handlers keyed by event type.
+func (r *HandlerRegistry) GetAllHandlers() map[string][]shared.EventHandler {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	handlers := make(map[string][]shared.EventHandler, len(r.handlers))
+	for eventType, handlerList := range r.handlers {
+		handlers[eventType] = make([]shared.EventHandler, len(handlerList))
+		copy(handlers[eventType], handlerList)
+	}
+	return handlers
+}
+
+// Dispatch delivers events to their registered handlers. Handler errors are logged, reported and dead-lettered,
+// not returned, so a failing handler cannot fail the operation that published the event.
+func (r *HandlerRegistry) Dispatch(ctx context.Context, events ...*shared.BaseDomainEvent) {
+	for _, event := range events {
+		for _, handler := range r.GetHandlers(event.EventType) {
+			if err := handler.HandleEvent(ctx, event); err != nil {
+				r.logger.Error("In-process event handler failed",
+					zap.String("event_type", event.EventType),
+					zap.String("aggregate_id", event.AggregateID),
+					zap.String("handler_type", fmt.Sprintf("%T", handler)),
+					zap.Error(err))
+				r.report(ctx, handler, event, err)
+				r.deadLetter(ctx, handler, event, err)
+			}
+		}
+	}
+}
+
+// report sends a handler failure to the error reporter, when there is one.
+func (r *HandlerRegistry) report(
+	ctx context.Context,
+	handler shared.EventHandler,
+	event *shared.BaseDomainEvent,
+	cause error,
+) {
+	if r.reporter == nil {
+		return
+	}
+	r.reporter.CaptureError(ctx, cause, map[string]string{
+		"event_type":   event.EventType,
+		"aggregate_id": event.AggregateID,
+		"handler_type": fmt.Sprintf("%T", handler),
+	})
+}
+
+// deadLetter records the event a handler failed on so it can be replayed. Without a dead letter
+// recorder, the failure is only logged.
+func (r *HandlerRegistry) deadLetter(
+	ctx context.Context,
+	handler shared.EventHandler,
+	event *shared.BaseDomainEvent,
+	cause error,
+) {
+	if r.deadLetters == nil {
+		return
+	}
+	if err := r.deadLetters.RecordFailure(ctx, handler, event, cause); err != nil {
+		r.logger.Error("Failed to dead-letter event",
+			zap.String("event_type", event.EventType),
+			zap.String("aggregate_id", event.AggregateID),
+			zap.String("handler_type", fmt.Sprintf("%T", handler)),
+			zap.Error(err))
+	}
+}
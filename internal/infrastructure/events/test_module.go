@@ -5,6 +5,7 @@ import (
 	"crypto-checkout/internal/domain/shared"
 
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 )
 
 // TestModule provides test-specific event infrastructure dependencies.
@@ -16,19 +17,24 @@ var TestModule = fx.Module("events-test",
 		fx.Annotate(
 			NewMockEventBus,
 			fx.As(new(shared.EventBus)),
+			fx.As(new(shared.EventHandlerRegistry)),
 		),
 	),
 )
 
 // MockEventBus is a no-op implementation of EventBus for testing.
+// Published events still reach in-process handlers.
 type MockEventBus struct {
+	*HandlerRegistry
+
 	eventStore *MockEventStore
 }
 
 // NewMockEventBus creates a new mock event bus.
 func NewMockEventBus(eventStore *MockEventStore) *MockEventBus {
 	return &MockEventBus{
-		eventStore: eventStore,
+		HandlerRegistry: NewHandlerRegistry(zap.NewNop()),
+		eventStore:      eventStore,
 	}
 }
 
@@ -57,13 +63,14 @@ func (m *MockEventBus) GetEventsByType(
 	return m.eventStore.GetEventsByType(ctx, eventType, limit)
 }
 
-// PublishEvent publishes a single event.
+// PublishEvent dispatches a single event to in-process handlers.
 func (m *MockEventBus) PublishEvent(ctx context.Context, event *shared.BaseDomainEvent) error {
-	// No-op for testing
+	m.Dispatch(ctx, event)
 	return nil
 }
 
+// PublishEvents dispatches multiple events to in-process handlers.
 func (m *MockEventBus) PublishEvents(ctx context.Context, events []*shared.BaseDomainEvent) error {
-	// No-op for testing
+	m.Dispatch(ctx, events...)
 	return nil
 }
@@ -0,0 +1,225 @@
+package exchange
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// DefaultBinanceBaseURL is the default Binance spot API endpoint.
+	DefaultBinanceBaseURL = "https://api.binance.com"
+	// binanceOrderPath places and queries orders.
+	binanceOrderPath = "/api/v3/order"
+	// binanceTradesPath lists the trades, and their commissions, that executed an order.
+	binanceTradesPath = "/api/v3/myTrades"
+	// binanceOrderIDSeparator joins the symbol and exchange order ID in the order IDs this adapter returns,
+	// since Binance needs both to look an order up.
+	binanceOrderIDSeparator = ":"
+)
+
+// maxErrorBody limits how much of an exchange error response is included in errors.
+const maxErrorBody = 512
+
+// BinanceExchange sells cryptocurrency with market orders on Binance.
+type BinanceExchange struct {
+	baseURL string
+	apiKey  string
+	secret  string
+	client  *http.Client
+	now     func() time.Time
+}
+
+// NewBinanceExchange creates a new Binance exchange adapter.
+func NewBinanceExchange(baseURL, apiKey, secret string, client *http.Client) *BinanceExchange {
+	if baseURL == "" {
+		baseURL = DefaultBinanceBaseURL
+	}
+	return &BinanceExchange{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		secret:  secret,
+		client:  client,
+		now:     time.Now,
+	}
+}
+
+// binanceOrder is an order as returned by the order endpoints.
+type binanceOrder struct {
+	Symbol              string `json:"symbol"`
+	OrderID             int64  `json:"orderId"`
+	Status              string `json:"status"`
+	ExecutedQty         string `json:"executedQty"`
+	CummulativeQuoteQty string `json:"cummulativeQuoteQty"`
+}
+
+// binanceTrade is a trade that executed part of an order.
+type binanceTrade struct {
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+}
+
+// Name returns the exchange name.
+func (e *BinanceExchange) Name() string {
+	return "binance"
+}
+
+// PlaceSellOrder places a market order selling the asset on its fiat symbol, e.g. USDTBRL.
+func (e *BinanceExchange) PlaceSellOrder(
+	ctx context.Context,
+	req *settlement.SellOrderRequest,
+) (*settlement.Order, error) {
+	if req == nil || !req.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: a positive amount is required", settlement.ErrInvalidRequest)
+	}
+
+	params := url.Values{}
+	params.Set("symbol", strings.ToUpper(req.Asset+req.FiatCurrency))
+	params.Set("side", "SELL")
+	params.Set("type", "MARKET")
+	params.Set("quantity", req.Amount.String())
+	params.Set("newClientOrderId", req.ClientOrderID)
+
+	var order binanceOrder
+	if err := e.call(ctx, http.MethodPost, binanceOrderPath, params, &order); err != nil {
+		return nil, err
+	}
+	return e.toOrder(ctx, &order)
+}
+
+// GetOrder returns the execution of an order. Only commissions charged in the fiat currency are
+// reported as the fee; commissions paid in another asset, such as BNB, do not reduce the proceeds.
+func (e *BinanceExchange) GetOrder(ctx context.Context, orderID string) (*settlement.Order, error) {
+	symbol, id, ok := strings.Cut(orderID, binanceOrderIDSeparator)
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed Binance order ID %q", settlement.ErrInvalidRequest, orderID)
+	}
+
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("orderId", id)
+
+	var order binanceOrder
+	if err := e.call(ctx, http.MethodGet, binanceOrderPath, params, &order); err != nil {
+		return nil, err
+	}
+	return e.toOrder(ctx, &order)
+}
+
+// toOrder converts a Binance order, adding the commissions of its trades once it is filled.
+func (e *BinanceExchange) toOrder(ctx context.Context, order *binanceOrder) (*settlement.Order, error) {
+	executed, err := parseAmount(order.ExecutedQty)
+	if err != nil {
+		return nil, err
+	}
+	proceeds, err := parseAmount(order.CummulativeQuoteQty)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &settlement.Order{
+		ID:             order.Symbol + binanceOrderIDSeparator + strconv.FormatInt(order.OrderID, 10),
+		State:          binanceOrderState(order.Status),
+		ExecutedAmount: executed,
+		Proceeds:       proceeds,
+		Fee:            decimal.Zero,
+	}
+	if result.State == settlement.OrderStateCancelled {
+		result.Reason = "order " + strings.ToLower(order.Status) + " by Binance"
+	}
+	if result.State != settlement.OrderStateFilled {
+		return result, nil
+	}
+
+	params := url.Values{}
+	params.Set("symbol", order.Symbol)
+	params.Set("orderId", strconv.FormatInt(order.OrderID, 10))
+
+	var trades []binanceTrade
+	if err := e.call(ctx, http.MethodGet, binanceTradesPath, params, &trades); err != nil {
+		return nil, err
+	}
+	for _, trade := range trades {
+		if trade.CommissionAsset == "" || !strings.HasSuffix(order.Symbol, trade.CommissionAsset) {
+			continue
+		}
+		commission, err := parseAmount(trade.Commission)
+		if err != nil {
+			return nil, err
+		}
+		result.Fee = result.Fee.Add(commission)
+	}
+	return result, nil
+}
+
+// call sends a signed request, with the parameters in the query string, and decodes the response into out.
+func (e *BinanceExchange) call(
+	ctx context.Context,
+	method, path string,
+	params url.Values,
+	out interface{},
+) error {
+	params.Set("timestamp", strconv.FormatInt(e.now().UnixMilli(), 10))
+	query := params.Encode()
+	query += "&signature=" + e.sign(query)
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, e.baseURL+path+"?"+query, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("X-MBX-APIKEY", e.apiKey)
+
+	return doJSON(e.client, httpReq, out)
+}
+
+// sign computes the request signature: hex HMAC-SHA256 of the query string keyed with the API secret.
+func (e *BinanceExchange) sign(query string) string {
+	mac := hmac.New(sha256.New, []byte(e.secret))
+	mac.Write([]byte(query))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// binanceOrderState maps a Binance order status to an order state.
+func binanceOrderState(status string) settlement.OrderState {
+	switch status {
+	case "FILLED":
+		return settlement.OrderStateFilled
+	case "CANCELED", "REJECTED", "EXPIRED", "EXPIRED_IN_MATCH":
+		return settlement.OrderStateCancelled
+	default:
+		return settlement.OrderStateOpen
+	}
+}
+
+// doJSON executes the request and decodes a successful JSON response into out.
+func doJSON(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", settlement.ErrExchangeFailure, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return fmt.Errorf("%w: %s %s returned %d: %s", settlement.ErrExchangeFailure,
+			req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: failed to decode response: %w", settlement.ErrExchangeFailure, err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package exchange
+
+import (
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/infrastructure/resilience"
+	"crypto-checkout/pkg/config"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides the configured exchange adapter and settlement policy for Fx.
+var Module = fx.Module("exchange",
+	fx.Provide(
+		NewExchangeAdapterProvider,
+		NewSettlementPolicyProvider,
+	),
+)
+
+// NewExchangeAdapterProvider creates the adapter for the configured exchange.
+// It returns nil when no exchange is configured, in which case settlements stay in crypto.
+func NewExchangeAdapterProvider(
+	cfg *config.Config,
+	breakers *resilience.Registry,
+	logger *zap.Logger,
+) (settlement.ExchangeAdapter, error) {
+	timeout := cfg.Settlement.Timeout
+	if timeout <= 0 {
+		timeout = config.DefaultExchangeTimeout
+	}
+	client := breakers.Client(resilience.DependencyExchange, timeout)
+
+	switch exchange := strings.ToLower(cfg.Settlement.Exchange); exchange {
+	case "", "none":
+		logger.Info("Automatic fiat conversion is disabled; settlements are kept in crypto")
+		return nil, nil
+	case "kraken":
+		return NewKrakenExchange(cfg.Settlement.BaseURL, cfg.Settlement.APIKey, cfg.Settlement.APISecret, client), nil
+	case "binance":
+		return NewBinanceExchange(cfg.Settlement.BaseURL, cfg.Settlement.APIKey, cfg.Settlement.APISecret, client), nil
+	default:
+		return nil, fmt.Errorf("unsupported settlement exchange: %s", exchange)
+	}
+}
+
+// NewSettlementPolicyProvider creates the settlement policy from configuration.
+func NewSettlementPolicyProvider(cfg *config.Config) (settlement.Policy, error) {
+	policy := settlement.DefaultPolicy()
+	if cfg.Settlement.FiatCurrency != "" {
+		policy.FiatCurrency = strings.ToUpper(cfg.Settlement.FiatCurrency)
+	}
+	if cfg.Settlement.ConversionPollInterval > 0 {
+		policy.PollInterval = cfg.Settlement.ConversionPollInterval
+	}
+	if cfg.Settlement.PlatformFeePercentage == "" {
+		return policy, nil
+	}
+
+	fee, err := decimal.NewFromString(cfg.Settlement.PlatformFeePercentage)
+	if err != nil || fee.IsNegative() || fee.GreaterThan(decimal.NewFromInt(100)) {
+		return settlement.Policy{}, fmt.Errorf("invalid settlement.platform_fee_percentage: %q",
+			cfg.Settlement.PlatformFeePercentage)
+	}
+	policy.PlatformFeePercentage = fee
+	return policy, nil
+}
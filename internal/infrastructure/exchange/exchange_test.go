@@ -0,0 +1,134 @@
+package exchange
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKrakenExchange_SellAndFill(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString([]byte("kraken-private-key"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		form, err := url.ParseQuery(string(body))
+		require.NoError(t, err)
+
+		digest := sha256.Sum256([]byte(form.Get("nonce") + string(body)))
+		mac := hmac.New(sha512.New, []byte("kraken-private-key"))
+		mac.Write([]byte(r.URL.Path))
+		mac.Write(digest[:])
+		assert.Equal(t, base64.StdEncoding.EncodeToString(mac.Sum(nil)), r.Header.Get("API-Sign"))
+		assert.Equal(t, "test-key", r.Header.Get("API-Key"))
+
+		switch r.URL.Path {
+		case krakenAddOrderPath:
+			assert.Equal(t, "USDTUSD", form.Get("pair"))
+			assert.Equal(t, "sell", form.Get("type"))
+			assert.Equal(t, "99.5", form.Get("volume"))
+			_, _ = w.Write([]byte(`{"error":[],"result":{"txid":["OABC-123"]}}`))
+		case krakenQueryOrdersPath:
+			assert.Equal(t, "OABC-123", form.Get("txid"))
+			_, _ = w.Write([]byte(`{"error":[],"result":{"OABC-123":` +
+				`{"status":"closed","vol_exec":"99.5","cost":"99.40","fee":"0.26"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	kraken := NewKrakenExchange(server.URL, "test-key", secret, server.Client())
+	order, err := kraken.PlaceSellOrder(context.Background(), &settlement.SellOrderRequest{
+		ClientOrderID: "settlement-1",
+		Asset:         "USDT",
+		FiatCurrency:  "USD",
+		Amount:        decimal.RequireFromString("99.5"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "OABC-123", order.ID)
+	assert.Equal(t, settlement.OrderStateOpen, order.State)
+
+	order, err = kraken.GetOrder(context.Background(), "OABC-123")
+	require.NoError(t, err)
+	assert.Equal(t, settlement.OrderStateFilled, order.State)
+	assert.Equal(t, "99.4", order.Proceeds.String())
+	assert.Equal(t, "0.26", order.Fee.String())
+}
+
+func TestKrakenExchange_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"error":["EOrder:Insufficient funds"]}`))
+	}))
+	defer server.Close()
+
+	kraken := NewKrakenExchange(server.URL, "test-key", base64.StdEncoding.EncodeToString([]byte("k")), server.Client())
+	_, err := kraken.GetOrder(context.Background(), "OABC-123")
+	require.ErrorIs(t, err, settlement.ErrExchangeFailure)
+	assert.Contains(t, err.Error(), "Insufficient funds")
+}
+
+func TestBinanceExchange_SellAndFill(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		signature := query.Get("signature")
+		unsigned := r.URL.RawQuery[:len(r.URL.RawQuery)-len("&signature=")-len(signature)]
+
+		mac := hmac.New(sha256.New, []byte("binance-secret"))
+		mac.Write([]byte(unsigned))
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), signature)
+		assert.Equal(t, "test-key", r.Header.Get("X-MBX-APIKEY"))
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == binanceOrderPath:
+			assert.Equal(t, "USDTBRL", query.Get("symbol"))
+			assert.Equal(t, "SELL", query.Get("side"))
+			_, _ = w.Write([]byte(`{"symbol":"USDTBRL","orderId":42,"status":"FILLED",` +
+				`"executedQty":"100","cummulativeQuoteQty":"510.00"}`))
+		case r.Method == http.MethodGet && r.URL.Path == binanceOrderPath:
+			assert.Equal(t, "42", query.Get("orderId"))
+			_, _ = w.Write([]byte(`{"symbol":"USDTBRL","orderId":42,"status":"CANCELED",` +
+				`"executedQty":"0","cummulativeQuoteQty":"0"}`))
+		case r.Method == http.MethodGet && r.URL.Path == binanceTradesPath:
+			_, _ = w.Write([]byte(`[{"commission":"0.51","commissionAsset":"BRL"},` +
+				`{"commission":"0.001","commissionAsset":"BNB"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	binance := NewBinanceExchange(server.URL, "test-key", "binance-secret", server.Client())
+	order, err := binance.PlaceSellOrder(context.Background(), &settlement.SellOrderRequest{
+		ClientOrderID: "settlement-1",
+		Asset:         "USDT",
+		FiatCurrency:  "BRL",
+		Amount:        decimal.RequireFromString("100"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "USDTBRL:42", order.ID)
+	assert.Equal(t, settlement.OrderStateFilled, order.State)
+	assert.Equal(t, "510", order.Proceeds.String())
+	assert.Equal(t, "0.51", order.Fee.String(), "only commissions in the fiat currency reduce the proceeds")
+
+	order, err = binance.GetOrder(context.Background(), order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, settlement.OrderStateCancelled, order.State)
+	assert.Equal(t, "order canceled by Binance", order.Reason)
+
+	_, err = binance.GetOrder(context.Background(), "42")
+	require.ErrorIs(t, err, settlement.ErrInvalidRequest)
+}
@@ -0,0 +1,227 @@
+// Package exchange provides exchange adapters that sell settled cryptocurrency for fiat.
+package exchange
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// DefaultKrakenBaseURL is the default Kraken REST API endpoint.
+	DefaultKrakenBaseURL = "https://api.kraken.com"
+	// krakenAddOrderPath places an order.
+	krakenAddOrderPath = "/0/private/AddOrder"
+	// krakenQueryOrdersPath retrieves orders by transaction ID.
+	krakenQueryOrdersPath = "/0/private/QueryOrders"
+)
+
+// KrakenExchange sells cryptocurrency with market orders on Kraken.
+type KrakenExchange struct {
+	baseURL string
+	apiKey  string
+	secret  string
+	client  *http.Client
+	now     func() time.Time
+
+	mu        sync.Mutex
+	lastNonce int64
+}
+
+// NewKrakenExchange creates a new Kraken exchange adapter. The secret is the base64-encoded private key.
+func NewKrakenExchange(baseURL, apiKey, secret string, client *http.Client) *KrakenExchange {
+	if baseURL == "" {
+		baseURL = DefaultKrakenBaseURL
+	}
+	return &KrakenExchange{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		secret:  secret,
+		client:  client,
+		now:     time.Now,
+	}
+}
+
+// krakenResponse is the envelope of every Kraken API response.
+type krakenResponse struct {
+	Error  []string        `json:"error"`
+	Result json.RawMessage `json:"result"`
+}
+
+// krakenAddOrderResult is the result of placing an order.
+type krakenAddOrderResult struct {
+	TxID []string `json:"txid"`
+}
+
+// krakenOrder is an order as returned by QueryOrders.
+type krakenOrder struct {
+	Status  string `json:"status"`
+	Reason  string `json:"reason"`
+	VolExec string `json:"vol_exec"`
+	Cost    string `json:"cost"`
+	Fee     string `json:"fee"`
+}
+
+// Name returns the exchange name.
+func (e *KrakenExchange) Name() string {
+	return "kraken"
+}
+
+// PlaceSellOrder places a market order selling the asset on its fiat pair, e.g. USDTUSD.
+func (e *KrakenExchange) PlaceSellOrder(
+	ctx context.Context,
+	req *settlement.SellOrderRequest,
+) (*settlement.Order, error) {
+	if req == nil || !req.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: a positive amount is required", settlement.ErrInvalidRequest)
+	}
+
+	form := url.Values{}
+	form.Set("ordertype", "market")
+	form.Set("type", "sell")
+	form.Set("pair", strings.ToUpper(req.Asset+req.FiatCurrency))
+	form.Set("volume", req.Amount.String())
+	form.Set("cl_ord_id", req.ClientOrderID)
+
+	var result krakenAddOrderResult
+	if err := e.call(ctx, krakenAddOrderPath, form, &result); err != nil {
+		return nil, err
+	}
+	if len(result.TxID) == 0 {
+		return nil, fmt.Errorf("%w: Kraken returned no order ID", settlement.ErrExchangeFailure)
+	}
+
+	return &settlement.Order{ID: result.TxID[0], State: settlement.OrderStateOpen}, nil
+}
+
+// GetOrder returns the execution of an order. Kraken reports the fee in the quote currency.
+func (e *KrakenExchange) GetOrder(ctx context.Context, orderID string) (*settlement.Order, error) {
+	form := url.Values{}
+	form.Set("txid", orderID)
+
+	var result map[string]krakenOrder
+	if err := e.call(ctx, krakenQueryOrdersPath, form, &result); err != nil {
+		return nil, err
+	}
+	order, ok := result[orderID]
+	if !ok {
+		return nil, fmt.Errorf("%w: Kraken order %s not found", settlement.ErrExchangeFailure, orderID)
+	}
+
+	executed, err := parseAmount(order.VolExec)
+	if err != nil {
+		return nil, err
+	}
+	proceeds, err := parseAmount(order.Cost)
+	if err != nil {
+		return nil, err
+	}
+	fee, err := parseAmount(order.Fee)
+	if err != nil {
+		return nil, err
+	}
+
+	return &settlement.Order{
+		ID:             orderID,
+		State:          krakenOrderState(order.Status),
+		ExecutedAmount: executed,
+		Proceeds:       proceeds,
+		Fee:            fee,
+		Reason:         order.Reason,
+	}, nil
+}
+
+// call sends a signed private API request and decodes its result into out.
+func (e *KrakenExchange) call(ctx context.Context, path string, form url.Values, out interface{}) error {
+	nonce := e.nextNonce()
+	form.Set("nonce", nonce)
+	body := form.Encode()
+
+	signature, err := e.sign(path, nonce, body)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+path, strings.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("API-Key", e.apiKey)
+	httpReq.Header.Set("API-Sign", signature)
+
+	var response krakenResponse
+	if err := doJSON(e.client, httpReq, &response); err != nil {
+		return err
+	}
+	if len(response.Error) > 0 {
+		return fmt.Errorf("%w: Kraken %s: %s",
+			settlement.ErrExchangeFailure, path, strings.Join(response.Error, "; "))
+	}
+	if err := json.Unmarshal(response.Result, out); err != nil {
+		return fmt.Errorf("%w: failed to decode Kraken result: %w", settlement.ErrExchangeFailure, err)
+	}
+	return nil
+}
+
+// sign computes the API-Sign header: base64 HMAC-SHA512 of the path and the SHA-256 of the nonce
+// and body, keyed with the base64-decoded private key.
+func (e *KrakenExchange) sign(path, nonce, body string) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(e.secret)
+	if err != nil {
+		return "", fmt.Errorf("invalid Kraken API secret: %w", err)
+	}
+
+	digest := sha256.Sum256([]byte(nonce + body))
+	mac := hmac.New(sha512.New, key)
+	mac.Write([]byte(path))
+	mac.Write(digest[:])
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// nextNonce returns a nonce greater than every nonce used before, as Kraken requires.
+func (e *KrakenExchange) nextNonce() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.lastNonce = max(e.lastNonce+1, e.now().UnixMilli())
+	return strconv.FormatInt(e.lastNonce, 10)
+}
+
+// krakenOrderState maps a Kraken order status to an order state.
+func krakenOrderState(status string) settlement.OrderState {
+	switch status {
+	case "closed":
+		return settlement.OrderStateFilled
+	case "canceled", "expired":
+		return settlement.OrderStateCancelled
+	default:
+		return settlement.OrderStateOpen
+	}
+}
+
+// parseAmount parses an amount reported by an exchange, treating an empty amount as zero.
+func parseAmount(value string) (decimal.Decimal, error) {
+	if value == "" {
+		return decimal.Zero, nil
+	}
+	amount, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%w: invalid amount %q", settlement.ErrExchangeFailure, value)
+	}
+	return amount, nil
+}
@@ -0,0 +1,96 @@
+package feeoracle
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/fee"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// bitcoinRecommendedFeesPath reports the recommended fee rates, in sat/vB.
+	bitcoinRecommendedFeesPath = "/api/v1/fees/recommended"
+	// bitcoinTransferVBytes is the virtual size of a one-input, two-output segwit transfer.
+	bitcoinTransferVBytes = 141
+	// satoshisPerBTC converts satoshis to BTC.
+	satoshisPerBTC = 8
+)
+
+// BitcoinOracle estimates Bitcoin transfer fees from the fee rates a mempool.space-compatible API
+// recommends: the one-hour rate for slow transfers, the half-hour rate for standard ones and the
+// next-block rate for fast ones.
+type BitcoinOracle struct {
+	baseURL string
+	client  *http.Client
+	now     func() time.Time
+}
+
+// NewBitcoinOracle creates a new Bitcoin fee oracle.
+func NewBitcoinOracle(baseURL string, client *http.Client) *BitcoinOracle {
+	return &BitcoinOracle{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  client,
+		now:     time.Now,
+	}
+}
+
+// bitcoinRecommendedFees is the response of the recommended fees endpoint.
+type bitcoinRecommendedFees struct {
+	FastestFee  int64 `json:"fastestFee"`
+	HalfHourFee int64 `json:"halfHourFee"`
+	HourFee     int64 `json:"hourFee"`
+}
+
+// Network returns the Bitcoin network.
+func (o *BitcoinOracle) Network() shared.BlockchainNetwork {
+	return shared.NetworkBitcoin
+}
+
+// Estimate returns the BTC a typical transfer costs at the speed's recommended rate.
+func (o *BitcoinOracle) Estimate(ctx context.Context, currency string, speed fee.Speed) (*fee.Estimate, error) {
+	if currency != string(shared.CryptoCurrencyBTC) {
+		return nil, fmt.Errorf("%w: %s is not transferred on bitcoin", fee.ErrInvalidRequest, currency)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, o.baseURL+bitcoinRecommendedFeesPath, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Accept", "application/json")
+
+	var response bitcoinRecommendedFees
+	if err := doJSON(o.client, httpReq, &response); err != nil {
+		return nil, err
+	}
+
+	var rate int64
+	switch speed {
+	case fee.SpeedSlow:
+		rate = response.HourFee
+	case fee.SpeedStandard:
+		rate = response.HalfHourFee
+	case fee.SpeedFast:
+		rate = response.FastestFee
+	default:
+		return nil, fmt.Errorf("%w: invalid speed %s", fee.ErrInvalidRequest, speed)
+	}
+	if rate <= 0 {
+		return nil, fmt.Errorf("%w: no recommended bitcoin fee rate", fee.ErrOracleFailure)
+	}
+
+	return &fee.Estimate{
+		Network:     shared.NetworkBitcoin,
+		Currency:    currency,
+		FeeCurrency: fee.NativeCurrency(shared.NetworkBitcoin),
+		Speed:       speed,
+		Rate:        decimal.NewFromInt(rate),
+		RateUnit:    "sat/vB",
+		Fee:         decimal.NewFromInt(rate * bitcoinTransferVBytes).Shift(-satoshisPerBTC),
+		EstimatedAt: o.now().UTC(),
+	}, nil
+}
@@ -0,0 +1,76 @@
+package feeoracle
+
+import (
+	"crypto-checkout/internal/domain/fee"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/resilience"
+	"crypto-checkout/pkg/config"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides the configured fee oracles and fee policy for Fx.
+var Module = fx.Module("feeoracle",
+	fx.Provide(
+		NewOraclesProvider,
+		NewFeePolicyProvider,
+	),
+)
+
+// NewOraclesProvider creates an oracle for every network with an oracle URL. Each oracle has its own
+// circuit breaker, so an unreachable oracle does not affect the other networks.
+func NewOraclesProvider(cfg *config.Config, breakers *resilience.Registry, logger *zap.Logger) []fee.Oracle {
+	timeout := cfg.Fees.Timeout
+	if timeout <= 0 {
+		timeout = config.DefaultFeeOracleTimeout
+	}
+
+	var oracles []fee.Oracle
+	if cfg.Fees.Tron.URL != "" {
+		oracles = append(oracles, NewTronOracle(cfg.Fees.Tron.URL, cfg.Fees.Tron.APIKey,
+			breakers.Client(resilience.DependencyFeeOracleTron, timeout)))
+	}
+	if cfg.Fees.Ethereum.URL != "" {
+		oracles = append(oracles, NewEthereumOracle(cfg.Fees.Ethereum.URL,
+			breakers.Client(resilience.DependencyFeeOracleEthereum, timeout)))
+	}
+	if cfg.Fees.Bitcoin.URL != "" {
+		oracles = append(oracles, NewBitcoinOracle(cfg.Fees.Bitcoin.URL,
+			breakers.Client(resilience.DependencyFeeOracleBitcoin, timeout)))
+	}
+	if len(oracles) == 0 {
+		logger.Info("Network fee estimation is disabled; transfers are broadcast at any fee")
+	}
+	return oracles
+}
+
+// NewFeePolicyProvider creates the per-network broadcast strategies from configuration.
+func NewFeePolicyProvider(cfg *config.Config) (fee.Policy, error) {
+	policy := fee.DefaultPolicy()
+	for network, networkCfg := range map[shared.BlockchainNetwork]config.FeeNetworkConfig{
+		shared.NetworkTron:     cfg.Fees.Tron,
+		shared.NetworkEthereum: cfg.Fees.Ethereum,
+		shared.NetworkBitcoin:  cfg.Fees.Bitcoin,
+	} {
+		strategy := fee.DefaultStrategy()
+		if networkCfg.Speed != "" {
+			strategy.Speed = fee.Speed(networkCfg.Speed)
+			if !strategy.Speed.IsValid() {
+				return fee.Policy{}, fmt.Errorf("invalid fees.%s.speed: %q", network, networkCfg.Speed)
+			}
+		}
+		if networkCfg.MaxFee != "" {
+			maxFee, err := decimal.NewFromString(networkCfg.MaxFee)
+			if err != nil || maxFee.IsNegative() {
+				return fee.Policy{}, fmt.Errorf("invalid fees.%s.max_fee: %q", network, networkCfg.MaxFee)
+			}
+			strategy.MaxFee = maxFee
+		}
+		strategy.MaxDelay = networkCfg.MaxDelay
+		policy.Strategies[network] = strategy
+	}
+	return policy, nil
+}
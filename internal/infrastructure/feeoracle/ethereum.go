@@ -0,0 +1,188 @@
+package feeoracle
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/fee"
+	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// ethFeeHistoryBlocks is how many recent blocks the priority fee is averaged over.
+	ethFeeHistoryBlocks = 5
+	// ethTransferGas and erc20TransferGas are the gas limits of a native and a token transfer.
+	ethTransferGas   = 21000
+	erc20TransferGas = 65000
+	// weiPerGwei and weiPerETH convert wei amounts.
+	weiPerGwei = 9
+	weiPerETH  = 18
+)
+
+// ethRewardPercentiles are the priority fee percentiles requested from the fee history, in the
+// order of fee.Speeds.
+var ethRewardPercentiles = []int{10, 50, 90}
+
+// EthereumOracle estimates Ethereum transfer fees from the next block's base fee and the priority
+// fees recently paid, read with eth_feeHistory.
+type EthereumOracle struct {
+	rpcURL string
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewEthereumOracle creates a new Ethereum fee oracle for a JSON-RPC node.
+func NewEthereumOracle(rpcURL string, client *http.Client) *EthereumOracle {
+	return &EthereumOracle{
+		rpcURL: rpcURL,
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// rpcRequest is a JSON-RPC request.
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+// rpcResponse is a JSON-RPC response.
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// ethFeeHistory is the result of eth_feeHistory. BaseFeePerGas has one more entry than the blocks
+// requested: the base fee of the next block.
+type ethFeeHistory struct {
+	BaseFeePerGas []string   `json:"baseFeePerGas"`
+	Reward        [][]string `json:"reward"`
+}
+
+// Network returns the Ethereum network.
+func (o *EthereumOracle) Network() shared.BlockchainNetwork {
+	return shared.NetworkEthereum
+}
+
+// Estimate returns the ETH a transfer of the currency costs at the next block's base fee plus the
+// average priority fee recent blocks paid at the speed's percentile.
+func (o *EthereumOracle) Estimate(ctx context.Context, currency string, speed fee.Speed) (*fee.Estimate, error) {
+	var gas int64
+	switch currency {
+	case string(shared.CryptoCurrencyETH):
+		gas = ethTransferGas
+	case string(shared.CryptoCurrencyUSDT):
+		gas = erc20TransferGas
+	default:
+		return nil, fmt.Errorf("%w: %s is not transferred on ethereum", fee.ErrInvalidRequest, currency)
+	}
+
+	percentile := -1
+	for i, candidate := range fee.Speeds {
+		if candidate == speed {
+			percentile = i
+		}
+	}
+	if percentile < 0 {
+		return nil, fmt.Errorf("%w: invalid speed %s", fee.ErrInvalidRequest, speed)
+	}
+
+	history, err := o.feeHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(history.BaseFeePerGas) == 0 || len(history.Reward) == 0 {
+		return nil, fmt.Errorf("%w: empty ethereum fee history", fee.ErrOracleFailure)
+	}
+
+	baseFee, err := parseHexWei(history.BaseFeePerGas[len(history.BaseFeePerGas)-1])
+	if err != nil {
+		return nil, err
+	}
+	tip := decimal.Zero
+	for _, rewards := range history.Reward {
+		if percentile >= len(rewards) {
+			return nil, fmt.Errorf("%w: ethereum fee history is missing rewards", fee.ErrOracleFailure)
+		}
+		reward, err := parseHexWei(rewards[percentile])
+		if err != nil {
+			return nil, err
+		}
+		tip = tip.Add(reward)
+	}
+	tip = tip.Div(decimal.NewFromInt(int64(len(history.Reward)))).Floor()
+
+	gasPrice := baseFee.Add(tip)
+	return &fee.Estimate{
+		Network:     shared.NetworkEthereum,
+		Currency:    currency,
+		FeeCurrency: fee.NativeCurrency(shared.NetworkEthereum),
+		Speed:       speed,
+		Rate:        gasPrice.Shift(-weiPerGwei),
+		RateUnit:    "gwei",
+		Fee:         gasPrice.Mul(decimal.NewFromInt(gas)).Shift(-weiPerETH),
+		EstimatedAt: o.now().UTC(),
+	}, nil
+}
+
+// feeHistory calls eth_feeHistory for the latest blocks.
+func (o *EthereumOracle) feeHistory(ctx context.Context) (*ethFeeHistory, error) {
+	body, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "eth_feeHistory",
+		Params:  []interface{}{fmt.Sprintf("0x%x", ethFeeHistoryBlocks), "latest", ethRewardPercentiles},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal fee history request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, o.rpcURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	var response rpcResponse
+	if err := doJSON(o.client, httpReq, &response); err != nil {
+		return nil, err
+	}
+	if response.Error != nil {
+		return nil, fmt.Errorf("%w: eth_feeHistory: %s", fee.ErrOracleFailure, response.Error.Message)
+	}
+
+	var history ethFeeHistory
+	if err := json.Unmarshal(response.Result, &history); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode fee history: %w", fee.ErrOracleFailure, err)
+	}
+	return &history, nil
+}
+
+// parseHexWei parses a hex quantity, as JSON-RPC returns them, into wei.
+func parseHexWei(value string) (decimal.Decimal, error) {
+	wei, ok := new(big.Int).SetString(trimHexPrefix(value), 16)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%w: invalid hex quantity %q", fee.ErrOracleFailure, value)
+	}
+	return decimal.NewFromBigInt(wei, 0), nil
+}
+
+// trimHexPrefix removes the 0x prefix of a hex quantity.
+func trimHexPrefix(value string) string {
+	if len(value) >= 2 && value[0] == '0' && (value[1] == 'x' || value[1] == 'X') {
+		return value[2:]
+	}
+	return value
+}
@@ -0,0 +1,80 @@
+package feeoracle
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/fee"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTronOracle_EstimatesFromChainParameters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, tronChainParametersPath, r.URL.Path)
+		assert.Equal(t, "tron-key", r.Header.Get(tronAPIKeyHeader))
+		_, _ = w.Write([]byte(`{"chainParameter":[{"key":"getMaintenanceTimeInterval","value":21600000},` +
+			`{"key":"getTransactionFee","value":1000},{"key":"getEnergyFee","value":420}]}`))
+	}))
+	defer server.Close()
+
+	oracle := NewTronOracle(server.URL, "tron-key", server.Client())
+	estimate, err := oracle.Estimate(context.Background(), "USDT", fee.SpeedStandard)
+	require.NoError(t, err)
+	// 65000 energy at 420 sun plus 345 bytes at 1000 sun
+	assert.Equal(t, "27.645", estimate.Fee.String())
+	assert.Equal(t, "TRX", estimate.FeeCurrency)
+	assert.Equal(t, "420", estimate.Rate.String())
+
+	_, err = oracle.Estimate(context.Background(), "BTC", fee.SpeedStandard)
+	require.ErrorIs(t, err, fee.ErrInvalidRequest)
+}
+
+func TestEthereumOracle_EstimatesFromFeeHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "eth_feeHistory", req.Method)
+		// Base fees of 10, 12 and next 20 gwei; median tips of 1 and 3 gwei
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{` +
+			`"baseFeePerGas":["0x2540be400","0x2cb417800","0x4a817c800"],` +
+			`"reward":[["0x1","0x3b9aca00","0x1"],["0x1","0xb2d05e00","0x1"]]}}`))
+	}))
+	defer server.Close()
+
+	oracle := NewEthereumOracle(server.URL, server.Client())
+	estimate, err := oracle.Estimate(context.Background(), "ETH", fee.SpeedStandard)
+	require.NoError(t, err)
+	assert.Equal(t, "22", estimate.Rate.String())
+	assert.Equal(t, "gwei", estimate.RateUnit)
+	// 21000 gas at 22 gwei
+	assert.Equal(t, "0.000462", estimate.Fee.String())
+}
+
+func TestBitcoinOracle_MapsSpeedsToRecommendedRates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, bitcoinRecommendedFeesPath, r.URL.Path)
+		_, _ = w.Write([]byte(`{"fastestFee":25,"halfHourFee":12,"hourFee":8,"economyFee":4,"minimumFee":1}`))
+	}))
+	defer server.Close()
+
+	oracle := NewBitcoinOracle(server.URL, server.Client())
+	for speed, expected := range map[fee.Speed]string{
+		fee.SpeedSlow:     "0.00001128",
+		fee.SpeedStandard: "0.00001692",
+		fee.SpeedFast:     "0.00003525",
+	} {
+		estimate, err := oracle.Estimate(context.Background(), "BTC", speed)
+		require.NoError(t, err)
+		assert.Equal(t, expected, estimate.Fee.String(), speed)
+	}
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	_, err := oracle.Estimate(context.Background(), "BTC", fee.SpeedFast)
+	require.ErrorIs(t, err, fee.ErrOracleFailure)
+}
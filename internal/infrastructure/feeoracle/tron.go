@@ -0,0 +1,146 @@
+// Package feeoracle provides fee oracles that estimate transfer fees from each network's current
+// conditions: Tron chain parameters, Ethereum fee history and Bitcoin mempool fee rates.
+package feeoracle
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/fee"
+	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// tronChainParametersPath reports the network's resource prices.
+	tronChainParametersPath = "/wallet/getchainparameters"
+	// tronAPIKeyHeader carries the TronGrid API key.
+	tronAPIKeyHeader = "TRON-PRO-API-KEY"
+	// tronEnergyFeeKey is the price of one energy unit, in sun.
+	tronEnergyFeeKey = "getEnergyFee"
+	// tronBandwidthFeeKey is the price of one bandwidth byte, in sun.
+	tronBandwidthFeeKey = "getTransactionFee"
+
+	// trc20TransferEnergy is the energy a TRC20 transfer to an address that already holds the
+	// token consumes; TRX transfers consume none.
+	trc20TransferEnergy = 65000
+	// trc20TransferBandwidth and trxTransferBandwidth are the transaction sizes, in bytes.
+	trc20TransferBandwidth = 345
+	trxTransferBandwidth   = 270
+	// sunPerTRX converts sun to TRX.
+	sunPerTRX = 6
+)
+
+// maxErrorBody limits how much of an oracle error response is included in errors.
+const maxErrorBody = 512
+
+// TronOracle estimates Tron transfer fees from the energy and bandwidth prices of the chain
+// parameters, assuming the hot wallet has no staked resources. Tron fees do not depend on speed.
+type TronOracle struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+	now     func() time.Time
+}
+
+// NewTronOracle creates a new Tron fee oracle for a TronGrid-compatible node.
+func NewTronOracle(baseURL, apiKey string, client *http.Client) *TronOracle {
+	return &TronOracle{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  client,
+		now:     time.Now,
+	}
+}
+
+// tronChainParameters is the response of the chain parameters endpoint. Parameters whose value is
+// zero are returned without one.
+type tronChainParameters struct {
+	ChainParameter []struct {
+		Key   string `json:"key"`
+		Value int64  `json:"value"`
+	} `json:"chainParameter"`
+}
+
+// Network returns the Tron network.
+func (o *TronOracle) Network() shared.BlockchainNetwork {
+	return shared.NetworkTron
+}
+
+// Estimate returns the TRX burned by a transfer of the currency: USDT as a TRC20 transfer, TRX as
+// a native one.
+func (o *TronOracle) Estimate(ctx context.Context, currency string, speed fee.Speed) (*fee.Estimate, error) {
+	var energy, bandwidth int64
+	switch currency {
+	case string(shared.CryptoCurrencyUSDT):
+		energy, bandwidth = trc20TransferEnergy, trc20TransferBandwidth
+	case "TRX":
+		bandwidth = trxTransferBandwidth
+	default:
+		return nil, fmt.Errorf("%w: %s is not transferred on tron", fee.ErrInvalidRequest, currency)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, o.baseURL+tronChainParametersPath, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if o.apiKey != "" {
+		httpReq.Header.Set(tronAPIKeyHeader, o.apiKey)
+	}
+
+	var response tronChainParameters
+	if err := doJSON(o.client, httpReq, &response); err != nil {
+		return nil, err
+	}
+
+	var energyFee, bandwidthFee int64
+	for _, parameter := range response.ChainParameter {
+		switch parameter.Key {
+		case tronEnergyFeeKey:
+			energyFee = parameter.Value
+		case tronBandwidthFeeKey:
+			bandwidthFee = parameter.Value
+		}
+	}
+	if energyFee <= 0 || bandwidthFee <= 0 {
+		return nil, fmt.Errorf("%w: tron chain parameters have no resource prices", fee.ErrOracleFailure)
+	}
+
+	sun := decimal.NewFromInt(energy * energyFee).Add(decimal.NewFromInt(bandwidth * bandwidthFee))
+	return &fee.Estimate{
+		Network:     shared.NetworkTron,
+		Currency:    currency,
+		FeeCurrency: fee.NativeCurrency(shared.NetworkTron),
+		Speed:       speed,
+		Rate:        decimal.NewFromInt(energyFee),
+		RateUnit:    "sun/energy",
+		Fee:         sun.Shift(-sunPerTRX),
+		EstimatedAt: o.now().UTC(),
+	}, nil
+}
+
+// doJSON executes the request and decodes a successful JSON response into out.
+func doJSON(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", fee.ErrOracleFailure, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return fmt.Errorf("%w: %s %s returned %d: %s", fee.ErrOracleFailure,
+			req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: failed to decode response: %w", fee.ErrOracleFailure, err)
+	}
+	return nil
+}
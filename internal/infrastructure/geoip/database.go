@@ -0,0 +1,95 @@
+package geoip
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+)
+
+// countryRange is a range of addresses located in one country.
+type countryRange struct {
+	start   netip.Addr
+	end     netip.Addr
+	country string
+}
+
+// Database locates addresses by country from IP ranges held in memory.
+type Database struct {
+	ranges []countryRange
+}
+
+// Open loads a CSV database of "start_ip,end_ip,country" ranges, such as DB-IP's IP to Country Lite.
+func Open(path string) (*Database, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GeoIP database: %w", err)
+	}
+	defer file.Close()
+
+	return Parse(file)
+}
+
+// Parse reads a CSV database of "start_ip,end_ip,country" ranges. Columns after the country are ignored.
+func Parse(r io.Reader) (*Database, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	var ranges []countryRange
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read GeoIP database: %w", err)
+		}
+		if len(record) < 3 {
+			return nil, fmt.Errorf("GeoIP database line %d: expected start, end and country", line)
+		}
+
+		start, err := netip.ParseAddr(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("GeoIP database line %d: %w", line, err)
+		}
+		end, err := netip.ParseAddr(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("GeoIP database line %d: %w", line, err)
+		}
+		if start.Is4() != end.Is4() || end.Less(start) {
+			return nil, fmt.Errorf("GeoIP database line %d: invalid range %s-%s", line, start, end)
+		}
+		ranges = append(ranges, countryRange{start: start, end: end, country: strings.TrimSpace(record[2])})
+	}
+
+	slices.SortFunc(ranges, func(a, b countryRange) int {
+		return a.start.Compare(b.start)
+	})
+	return &Database{ranges: ranges}, nil
+}
+
+// Country returns the country code of the range an address is in, or "" if no range holds it.
+func (d *Database) Country(addr netip.Addr) string {
+	addr = addr.Unmap()
+	// The last range starting at or before the address is the only one that may hold it
+	i, found := slices.BinarySearchFunc(d.ranges, addr, func(r countryRange, target netip.Addr) int {
+		return r.start.Compare(target)
+	})
+	if !found {
+		i--
+	}
+	if i < 0 || d.ranges[i].end.Less(addr) || d.ranges[i].start.Is4() != addr.Is4() {
+		return ""
+	}
+	return d.ranges[i].country
+}
+
+// Len returns the number of ranges in the database.
+func (d *Database) Len() int {
+	return len(d.ranges)
+}
@@ -0,0 +1,48 @@
+package geoip
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabase_Country(t *testing.T) {
+	database, err := Parse(strings.NewReader(`"203.0.113.0","203.0.113.255","NL"
+1.0.0.0,1.0.0.255,AU
+2001:db8::,2001:db8::ffff,DE
+198.51.100.0,198.51.100.127,ZZ,extra
+`))
+	require.NoError(t, err)
+	assert.Equal(t, 4, database.Len())
+
+	tests := map[string]string{
+		"1.0.0.0":             "AU",
+		"1.0.0.255":           "AU",
+		"1.0.1.0":             "",
+		"203.0.113.42":        "NL",
+		"::ffff:203.0.113.42": "NL",
+		"198.51.100.127":      "ZZ",
+		"198.51.100.128":      "",
+		"0.0.0.1":             "",
+		"2001:db8::1":         "DE",
+		"2001:db8::1:0":       "",
+		"255.255.255.255":     "",
+	}
+	for address, country := range tests {
+		assert.Equal(t, country, database.Country(netip.MustParseAddr(address)), address)
+	}
+}
+
+func TestParse_RejectsInvalidRanges(t *testing.T) {
+	_, err := Parse(strings.NewReader("1.0.0.255,1.0.0.0,AU\n"))
+	require.Error(t, err)
+
+	_, err = Parse(strings.NewReader("1.0.0.0,2001:db8::,AU\n"))
+	require.Error(t, err)
+
+	_, err = Parse(strings.NewReader("1.0.0.0,1.0.0.255\n"))
+	require.Error(t, err)
+}
@@ -0,0 +1,34 @@
+package geoip
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/pkg/config"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides the locator of client countries for Fx.
+var Module = fx.Module("geoip",
+	fx.Provide(
+		NewLocatorProvider,
+	),
+)
+
+// NewLocatorProvider loads the configured GeoIP database. It returns nil when none is configured, in which case
+// clients are only located by the country header of the edge proxy.
+func NewLocatorProvider(cfg *config.Config, logger *zap.Logger) (shared.CountryLocator, error) {
+	if cfg.CheckoutAnalytics.GeoIPFile == "" {
+		logger.Info("No GeoIP database configured; clients are only located by the country header")
+		return nil, nil
+	}
+
+	database, err := Open(cfg.CheckoutAnalytics.GeoIPFile)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("GeoIP database loaded",
+		zap.String("file", cfg.CheckoutAnalytics.GeoIPFile),
+		zap.Int("ranges", database.Len()))
+	return database, nil
+}
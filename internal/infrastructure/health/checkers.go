@@ -0,0 +1,217 @@
+package health
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/internal/infrastructure/supervisor"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DatabaseChecker verifies database connectivity.
+type DatabaseChecker struct {
+	conn *database.Connection
+}
+
+// NewDatabaseChecker creates a new database connectivity checker.
+func NewDatabaseChecker(conn *database.Connection) *DatabaseChecker {
+	return &DatabaseChecker{conn: conn}
+}
+
+// Name returns the check name.
+func (c *DatabaseChecker) Name() string {
+	return "database"
+}
+
+// Check pings the database.
+func (c *DatabaseChecker) Check(ctx context.Context) error {
+	return c.conn.Ping(ctx)
+}
+
+// MigrationChecker verifies that all managed tables have been migrated.
+type MigrationChecker struct {
+	conn *database.Connection
+}
+
+// NewMigrationChecker creates a new migration status checker.
+func NewMigrationChecker(conn *database.Connection) *MigrationChecker {
+	return &MigrationChecker{conn: conn}
+}
+
+// Name returns the check name.
+func (c *MigrationChecker) Name() string {
+	return "migrations"
+}
+
+// Check reports an error listing tables that are not migrated yet.
+func (c *MigrationChecker) Check(ctx context.Context) error {
+	if pending := c.conn.PendingMigrations(ctx); len(pending) > 0 {
+		return fmt.Errorf("pending migrations for tables: %s", strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// EventBusChecker verifies that at least one Kafka broker accepts connections.
+type EventBusChecker struct {
+	brokers []string
+	dialer  *net.Dialer
+}
+
+// NewEventBusChecker creates a new event bus reachability checker.
+func NewEventBusChecker(brokers []string) *EventBusChecker {
+	return &EventBusChecker{
+		brokers: brokers,
+		dialer:  &net.Dialer{},
+	}
+}
+
+// Name returns the check name.
+func (c *EventBusChecker) Name() string {
+	return "event_bus"
+}
+
+// Enabled reports whether any brokers are configured.
+func (c *EventBusChecker) Enabled() bool {
+	for _, broker := range c.brokers {
+		if strings.TrimSpace(broker) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Check dials the configured brokers until one succeeds.
+func (c *EventBusChecker) Check(ctx context.Context) error {
+	var errs []error
+	for _, broker := range c.brokers {
+		broker = strings.TrimSpace(broker)
+		if broker == "" {
+			continue
+		}
+		conn, err := c.dialer.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		_ = conn.Close()
+		return nil
+	}
+	return fmt.Errorf("no reachable kafka broker: %w", errors.Join(errs...))
+}
+
+// BlockchainRPCChecker verifies the blockchain RPC endpoint responds.
+type BlockchainRPCChecker struct {
+	url    string
+	client *http.Client
+}
+
+// NewBlockchainRPCChecker creates a new blockchain RPC reachability checker.
+func NewBlockchainRPCChecker(url string, client *http.Client) *BlockchainRPCChecker {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &BlockchainRPCChecker{
+		url:    url,
+		client: client,
+	}
+}
+
+// Name returns the check name.
+func (c *BlockchainRPCChecker) Name() string {
+	return "blockchain_rpc"
+}
+
+// Enabled reports whether an RPC URL is configured.
+func (c *BlockchainRPCChecker) Enabled() bool {
+	return c.url != ""
+}
+
+// Check issues a request to the RPC endpoint and accepts any non-5xx response.
+func (c *BlockchainRPCChecker) Check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to build RPC request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("blockchain RPC unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("blockchain RPC returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// BlockWatcherChecker verifies the chain watcher is keeping up with the chain head.
+type BlockWatcherChecker struct {
+	watcher *detection.Watcher
+}
+
+// NewBlockWatcherChecker creates a new chain watcher lag checker.
+func NewBlockWatcherChecker(watcher *detection.Watcher) *BlockWatcherChecker {
+	return &BlockWatcherChecker{watcher: watcher}
+}
+
+// Name returns the check name.
+func (c *BlockWatcherChecker) Name() string {
+	return "block_watcher"
+}
+
+// Enabled reports whether the watcher runs and has a maximum lag.
+func (c *BlockWatcherChecker) Enabled() bool {
+	return c.watcher != nil && c.watcher.Enabled() && c.watcher.MaxLag() > 0
+}
+
+// Check fails when the watcher is further behind the chain head than the maximum lag on any network.
+func (c *BlockWatcherChecker) Check(_ context.Context) error {
+	var errs []error
+	for _, status := range c.watcher.Status() {
+		if status.Lag > c.watcher.MaxLag() {
+			errs = append(errs, fmt.Errorf("%s is %d blocks behind the chain head, more than %d",
+				status.Network, status.Lag, c.watcher.MaxLag()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WorkerChecker verifies no supervised background worker has stalled.
+type WorkerChecker struct {
+	workers *supervisor.Supervisor
+}
+
+// NewWorkerChecker creates a new background worker liveness checker.
+func NewWorkerChecker(workers *supervisor.Supervisor) *WorkerChecker {
+	return &WorkerChecker{workers: workers}
+}
+
+// Name returns the check name.
+func (c *WorkerChecker) Name() string {
+	return "workers"
+}
+
+// Enabled reports whether there is a supervisor to check.
+func (c *WorkerChecker) Enabled() bool {
+	return c.workers != nil
+}
+
+// Check fails when any worker has stopped beating.
+func (c *WorkerChecker) Check(_ context.Context) error {
+	var errs []error
+	for _, status := range c.workers.Statuses() {
+		if status.Stalled() {
+			errs = append(errs, fmt.Errorf("%s has not made progress since %s",
+				status.Name, status.LastBeatAt.Format(time.RFC3339)))
+		}
+	}
+	return errors.Join(errs...)
+}
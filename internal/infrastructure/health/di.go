@@ -0,0 +1,42 @@
+package health
+
+import (
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/internal/infrastructure/resilience"
+	"crypto-checkout/internal/infrastructure/supervisor"
+	"crypto-checkout/pkg/config"
+	"net/http"
+	"strings"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides health check dependencies for Fx.
+var Module = fx.Module("health",
+	fx.Provide(
+		NewServiceProvider,
+	),
+)
+
+// NewServiceProvider creates the health service with all dependency checkers, the outbound circuit breakers, the
+// lag of the chain watcher and the liveness of the background workers.
+func NewServiceProvider(
+	conn *database.Connection,
+	cfg *config.Config,
+	breakers *resilience.Registry,
+	watcher *detection.Watcher,
+	workers *supervisor.Supervisor,
+	logger *zap.Logger,
+) *Service {
+	checkers := []Checker{
+		NewDatabaseChecker(conn),
+		NewMigrationChecker(conn),
+		NewEventBusChecker(strings.Split(cfg.Kafka.Brokers, ",")),
+		NewBlockchainRPCChecker(cfg.Blockchain.RPCURL, &http.Client{Timeout: DefaultCheckTimeout}),
+		NewBlockWatcherChecker(watcher),
+		NewWorkerChecker(workers),
+	}
+	return NewService(checkers, logger).WithBreakers(breakers).WithWatcher(watcher).WithWorkers(workers)
+}
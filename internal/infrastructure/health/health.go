@@ -0,0 +1,252 @@
+// Package health provides readiness and liveness checks for the crypto-checkout application.
+package health
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/infrastructure/resilience"
+	"crypto-checkout/internal/infrastructure/supervisor"
+	"crypto-checkout/pkg/metrics"
+	"expvar"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// StatusUp indicates a dependency is reachable and healthy.
+	StatusUp = "up"
+	// StatusDown indicates a dependency failed its check.
+	StatusDown = "down"
+	// StatusSkipped indicates a dependency is not configured and was not checked.
+	StatusSkipped = "skipped"
+
+	// DefaultCheckTimeout bounds how long a single readiness check may take.
+	DefaultCheckTimeout = 3 * time.Second
+)
+
+// watcherMetrics publishes the lag of the chain watcher on each network under block_watchers on /debug/vars.
+var watcherMetrics = expvar.NewMap("block_watchers")
+
+// watcherLagGauge publishes the lag of the chain watcher on each network on /metrics.
+var watcherLagGauge = metrics.NewGaugeFunc(metrics.Namespace+"_block_watcher_lag_blocks",
+	"Blocks the chain watcher of a network is behind the chain head.", "network", "watcher")
+
+// watcherLagAlert is the lag, in blocks, the BlockWatcherLagging alert fires above once it lasts.
+const watcherLagAlert = 20
+
+func init() {
+	metrics.AddAlert(metrics.AlertRule{
+		Name:     "BlockWatcherLagging",
+		Expr:     fmt.Sprintf("max by (network) (%s) > %d", watcherLagGauge.Name(), watcherLagAlert),
+		For:      10 * time.Minute,
+		Severity: metrics.SeverityCritical,
+		Summary:  "The chain watcher on {{ $labels.network }} is falling behind",
+		Description: "The chain watcher on {{ $labels.network }} is {{ $value }} blocks behind the chain head, " +
+			"so payments on it are detected and confirmed late.",
+		Metrics: []string{watcherLagGauge.Name()},
+	})
+}
+
+// Checker verifies a single external dependency.
+type Checker interface {
+	// Name returns the unique name of the check.
+	Name() string
+	// Check returns nil when the dependency is healthy.
+	Check(ctx context.Context) error
+}
+
+// SkippableChecker is implemented by checkers whose dependency may be unconfigured.
+type SkippableChecker interface {
+	Checker
+	// Enabled reports whether the check should run.
+	Enabled() bool
+}
+
+// CheckResult represents the outcome of a single dependency check.
+type CheckResult struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// WatcherLag represents how far the chain watcher is behind the chain head on a network.
+type WatcherLag struct {
+	Network string `json:"network"`
+	Watcher string `json:"watcher"`
+	Head    int64  `json:"head"`
+	Cursor  int64  `json:"cursor"`
+	// Lag is the head minus the cursor, in blocks.
+	Lag       int64     `json:"lag"`
+	CheckedAt time.Time `json:"checked_at"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Report represents the aggregated readiness outcome. Breakers reports the circuit breakers of the outbound
+// dependencies; an open breaker degrades the affected feature but does not make the service unready.
+// Watchers reports the lag of the chain watcher on each network it has checked, and Workers the liveness of the
+// supervised background workers.
+type Report struct {
+	Status    string                    `json:"status"`
+	Checks    []CheckResult             `json:"checks"`
+	Breakers  []resilience.State        `json:"breakers,omitempty"`
+	Watchers  []WatcherLag              `json:"watchers,omitempty"`
+	Workers   []supervisor.WorkerStatus `json:"workers,omitempty"`
+	CheckedAt time.Time                 `json:"checked_at"`
+}
+
+// Ready returns true if no check reported a failure.
+func (r *Report) Ready() bool {
+	return r.Status == StatusUp
+}
+
+// Service runs registered readiness checks.
+type Service struct {
+	checkers []Checker
+	breakers *resilience.Registry
+	watcher  *detection.Watcher
+	workers  *supervisor.Supervisor
+	timeout  time.Duration
+	logger   *zap.Logger
+}
+
+// NewService creates a new health service with the given checkers.
+func NewService(checkers []Checker, logger *zap.Logger) *Service {
+	return &Service{
+		checkers: checkers,
+		timeout:  DefaultCheckTimeout,
+		logger:   logger,
+	}
+}
+
+// WithBreakers makes the service include the state of the registry's circuit breakers in its reports.
+func (s *Service) WithBreakers(breakers *resilience.Registry) *Service {
+	s.breakers = breakers
+	return s
+}
+
+// WithWatcher makes the service include the lag of the chain watcher in its reports, and publishes it on
+// /debug/vars and /metrics. A disabled watcher is left out.
+func (s *Service) WithWatcher(watcher *detection.Watcher) *Service {
+	if watcher == nil || !watcher.Enabled() {
+		return s
+	}
+	s.watcher = watcher
+	for _, network := range watcher.Networks() {
+		watcherMetrics.Set(string(network), expvar.Func(func() any {
+			for _, lag := range watcherLags(watcher) {
+				if lag.Network == string(network) {
+					return lag
+				}
+			}
+			return nil
+		}))
+	}
+	watcherLagGauge.Collect(func(context.Context) ([]metrics.Sample, error) {
+		lags := watcherLags(watcher)
+		samples := make([]metrics.Sample, len(lags))
+		for i, lag := range lags {
+			samples[i] = metrics.Sample{Labels: []string{lag.Network, lag.Watcher}, Value: float64(lag.Lag)}
+		}
+		return samples, nil
+	})
+	return s
+}
+
+// WithWorkers makes the service include the liveness of the supervised background workers in its reports.
+func (s *Service) WithWorkers(workers *supervisor.Supervisor) *Service {
+	s.workers = workers
+	return s
+}
+
+// Readiness runs all checks concurrently and returns a report with per-check latency.
+func (s *Service) Readiness(ctx context.Context) *Report {
+	results := make([]CheckResult, len(s.checkers))
+
+	var wg sync.WaitGroup
+	for i, checker := range s.checkers {
+		wg.Add(1)
+		go func(i int, checker Checker) {
+			defer wg.Done()
+			results[i] = s.run(ctx, checker)
+		}(i, checker)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	report := &Report{
+		Status:    StatusUp,
+		Checks:    results,
+		CheckedAt: time.Now().UTC(),
+	}
+	for _, result := range results {
+		if result.Status == StatusDown {
+			report.Status = StatusDown
+			break
+		}
+	}
+	if s.breakers != nil {
+		report.Breakers = s.breakers.States()
+	}
+	if s.watcher != nil {
+		report.Watchers = watcherLags(s.watcher)
+	}
+	if s.workers != nil {
+		report.Workers = s.workers.Statuses()
+	}
+
+	return report
+}
+
+// run executes a single checker with the service timeout.
+func (s *Service) run(ctx context.Context, checker Checker) CheckResult {
+	result := CheckResult{Name: checker.Name(), Status: StatusUp}
+
+	if skippable, ok := checker.(SkippableChecker); ok && !skippable.Enabled() {
+		result.Status = StatusSkipped
+		return result
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := checker.Check(checkCtx)
+	result.LatencyMS = float64(time.Since(start).Microseconds()) / float64(time.Millisecond/time.Microsecond)
+
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+		s.logger.Warn("Readiness check failed",
+			zap.String("check", result.Name),
+			zap.Error(err),
+		)
+	}
+
+	return result
+}
+
+// watcherLags returns the lag of the watcher on each network it has checked.
+func watcherLags(watcher *detection.Watcher) []WatcherLag {
+	statuses := watcher.Status()
+	lags := make([]WatcherLag, len(statuses))
+	for i, status := range statuses {
+		lags[i] = WatcherLag{
+			Network:   string(status.Network),
+			Watcher:   status.Watcher,
+			Head:      status.Head,
+			Cursor:    status.Cursor,
+			Lag:       status.Lag,
+			CheckedAt: status.CheckedAt,
+			Error:     status.Error,
+		}
+	}
+	return lags
+}
@@ -0,0 +1,220 @@
+package health_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/health"
+	"crypto-checkout/internal/infrastructure/resilience"
+	"crypto-checkout/internal/infrastructure/supervisor"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type stubChecker struct {
+	name string
+	err  error
+}
+
+func (c *stubChecker) Name() string                  { return c.name }
+func (c *stubChecker) Check(_ context.Context) error { return c.err }
+
+func TestService_Readiness(t *testing.T) {
+	t.Run("all checks up", func(t *testing.T) {
+		svc := health.NewService([]health.Checker{
+			&stubChecker{name: "database"},
+			&stubChecker{name: "event_bus"},
+		}, zap.NewNop())
+
+		report := svc.Readiness(context.Background())
+
+		require.True(t, report.Ready())
+		require.Len(t, report.Checks, 2)
+		require.Equal(t, "database", report.Checks[0].Name)
+		require.Equal(t, health.StatusUp, report.Checks[0].Status)
+	})
+
+	t.Run("failing check marks report down", func(t *testing.T) {
+		svc := health.NewService([]health.Checker{
+			&stubChecker{name: "database"},
+			&stubChecker{name: "migrations", err: errors.New("pending migrations")},
+		}, zap.NewNop())
+
+		report := svc.Readiness(context.Background())
+
+		require.False(t, report.Ready())
+		require.Equal(t, health.StatusDown, report.Status)
+		require.Equal(t, health.StatusDown, report.Checks[1].Status)
+		require.Equal(t, "pending migrations", report.Checks[1].Error)
+	})
+
+	t.Run("unconfigured checks are skipped", func(t *testing.T) {
+		svc := health.NewService([]health.Checker{
+			health.NewBlockchainRPCChecker("", nil),
+			health.NewEventBusChecker([]string{""}),
+		}, zap.NewNop())
+
+		report := svc.Readiness(context.Background())
+
+		require.True(t, report.Ready())
+		for _, check := range report.Checks {
+			require.Equal(t, health.StatusSkipped, check.Status)
+		}
+	})
+
+	t.Run("open breakers are reported without failing readiness", func(t *testing.T) {
+		breakers := resilience.NewRegistry(resilience.Policy{FailureThreshold: 1, OpenTimeout: time.Minute}, nil,
+			zap.NewNop())
+		breaker := breakers.Breaker(resilience.DependencyExchange)
+		_ = breaker.Execute(context.Background(), func(context.Context) error { return errors.New("timeout") })
+
+		svc := health.NewService([]health.Checker{&stubChecker{name: "database"}}, zap.NewNop()).
+			WithBreakers(breakers)
+		report := svc.Readiness(context.Background())
+
+		require.True(t, report.Ready())
+		require.Len(t, report.Breakers, 1)
+		require.Equal(t, resilience.DependencyExchange, report.Breakers[0].Name)
+		require.Equal(t, resilience.StateOpen, report.Breakers[0].State)
+	})
+}
+
+func TestBlockchainRPCChecker(t *testing.T) {
+	t.Run("reachable endpoint", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}))
+		defer server.Close()
+
+		checker := health.NewBlockchainRPCChecker(server.URL, server.Client())
+		require.NoError(t, checker.Check(context.Background()))
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		checker := health.NewBlockchainRPCChecker(server.URL, server.Client())
+		require.Error(t, checker.Check(context.Background()))
+	})
+}
+
+// stalledChain is a chain whose head is far ahead of a cursor that cannot be moved.
+type stalledChain struct {
+	invoice.InvoiceService
+	detection.Service
+}
+
+func (stalledChain) Network() shared.BlockchainNetwork { return shared.NetworkEthereum }
+func (stalledChain) LatestBlock(context.Context) (int64, error) {
+	return 1000, nil
+}
+func (stalledChain) ScanTransfers(context.Context, *detection.ScanRequest) ([]*detection.Notification, error) {
+	return nil, nil
+}
+func (stalledChain) GetPaymentAddresses(context.Context, shared.BlockchainNetwork) ([]string, error) {
+	return nil, nil
+}
+func (stalledChain) FindCursor(
+	_ context.Context,
+	network shared.BlockchainNetwork,
+	watcher string,
+) (*detection.BlockCursor, error) {
+	return &detection.BlockCursor{Network: network, Watcher: watcher, Block: 400}, nil
+}
+func (stalledChain) ListCursors(context.Context) ([]*detection.BlockCursor, error) {
+	return nil, nil
+}
+func (stalledChain) AdvanceCursor(context.Context, *detection.BlockCursor) error {
+	return errors.New("database unavailable")
+}
+
+func TestBlockWatcherChecker(t *testing.T) {
+	tokens, err := shared.NewTokenRegistry(shared.DefaultTokens())
+	require.NoError(t, err)
+	newWatcher := func(policy detection.WatchPolicy) *detection.Watcher {
+		chain := stalledChain{}
+		return detection.NewWatcher([]detection.TransferScanner{chain}, chain, chain, tokens, chain, policy,
+			zap.NewNop())
+	}
+
+	t.Run("disabled watcher is skipped", func(t *testing.T) {
+		watcher := newWatcher(detection.WatchPolicy{MaxLag: 100})
+		svc := health.NewService([]health.Checker{health.NewBlockWatcherChecker(watcher)}, zap.NewNop()).
+			WithWatcher(watcher)
+
+		report := svc.Readiness(context.Background())
+
+		require.True(t, report.Ready())
+		require.Equal(t, health.StatusSkipped, report.Checks[0].Status)
+		require.Empty(t, report.Watchers)
+	})
+
+	t.Run("lag beyond the maximum fails readiness", func(t *testing.T) {
+		watcher := newWatcher(detection.WatchPolicy{Enabled: true, Interval: time.Hour, MaxLag: 100})
+		ctx, cancel := context.WithCancel(context.Background())
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			watcher.Run(ctx)
+		}()
+		defer func() {
+			cancel()
+			<-stopped
+		}()
+		require.Eventually(t, func() bool {
+			statuses := watcher.Status()
+			return len(statuses) == 1 && statuses[0].Error != ""
+		}, 5*time.Second, 10*time.Millisecond)
+
+		svc := health.NewService([]health.Checker{health.NewBlockWatcherChecker(watcher)}, zap.NewNop()).
+			WithWatcher(watcher)
+		report := svc.Readiness(context.Background())
+
+		require.False(t, report.Ready())
+		require.Contains(t, report.Checks[0].Error, "600 blocks behind")
+		require.Len(t, report.Watchers, 1)
+		require.Equal(t, "ethereum", report.Watchers[0].Network)
+		require.Equal(t, int64(1000), report.Watchers[0].Head)
+		require.Equal(t, int64(400), report.Watchers[0].Cursor)
+		require.Equal(t, int64(600), report.Watchers[0].Lag)
+		require.Contains(t, report.Watchers[0].Error, "database unavailable")
+	})
+}
+
+func TestWorkerChecker(t *testing.T) {
+	workers := supervisor.NewSupervisor(supervisor.Policy{StallTimeout: 10 * time.Millisecond}, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// Hangs without beating, so it stalls once the interval and stall timeout pass
+		workers.Supervise(ctx, "chain_watcher", time.Millisecond, func(context.Context) { <-release })
+	}()
+	defer func() {
+		cancel()
+		close(release)
+		<-done
+	}()
+
+	svc := health.NewService([]health.Checker{health.NewWorkerChecker(workers)}, zap.NewNop()).
+		WithWorkers(workers)
+	require.Eventually(t, func() bool {
+		return !svc.Readiness(context.Background()).Ready()
+	}, time.Second, time.Millisecond)
+
+	report := svc.Readiness(context.Background())
+	require.Contains(t, report.Checks[0].Error, "chain_watcher has not made progress")
+	require.Len(t, report.Workers, 1)
+	require.Equal(t, "chain_watcher", report.Workers[0].Name)
+}
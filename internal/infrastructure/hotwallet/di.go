@@ -0,0 +1,155 @@
+package hotwallet
+
+import (
+	"crypto-checkout/internal/domain/approval"
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/saga"
+	"crypto-checkout/internal/domain/treasury"
+	"crypto-checkout/internal/infrastructure/resilience"
+	"crypto-checkout/pkg/config"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides the configured hot wallet, treasury custody and the policies of the transfers
+// they send for Fx.
+var Module = fx.Module("hotwallet",
+	fx.Provide(
+		NewSignerClientProvider,
+		NewHotWalletProvider,
+		NewCustodyProvider,
+		NewPayoutPolicyProvider,
+		NewSagaPolicyProvider,
+		NewTreasuryPolicyProvider,
+		NewApprovalPolicyProvider,
+	),
+)
+
+// NewSignerClientProvider creates the client of the configured signer service.
+// It returns nil when no signer is configured, in which case no payouts or sweeps are sent.
+func NewSignerClientProvider(
+	cfg *config.Config,
+	breakers *resilience.Registry,
+	logger *zap.Logger,
+) (*SignerClient, error) {
+	if cfg.Payout.SignerURL == "" {
+		logger.Info("On-chain payouts and treasury sweeps are disabled; no hot wallet signer is configured")
+		return nil, nil
+	}
+	if cfg.Payout.APISecret == "" {
+		return nil, errors.New("payout.api_secret is required when payout.signer_url is set")
+	}
+
+	timeout := cfg.Payout.Timeout
+	if timeout <= 0 {
+		timeout = config.DefaultHotWalletTimeout
+	}
+	return NewSignerClient(
+		cfg.Payout.SignerURL, cfg.Payout.APIKey, cfg.Payout.APISecret,
+		breakers.Client(resilience.DependencyHotWallet, timeout),
+	), nil
+}
+
+// NewHotWalletProvider provides the signer as the payout hot wallet, or nil when there is no signer.
+func NewHotWalletProvider(client *SignerClient) payout.HotWallet {
+	if client == nil {
+		return nil
+	}
+	return client
+}
+
+// NewCustodyProvider provides the signer as the treasury custody, or nil when there is no signer.
+func NewCustodyProvider(client *SignerClient) treasury.Custody {
+	if client == nil {
+		return nil
+	}
+	return client
+}
+
+// NewPayoutPolicyProvider creates the payout policy from configuration.
+func NewPayoutPolicyProvider(cfg *config.Config) (payout.Policy, error) {
+	policy := payout.DefaultPolicy()
+	if cfg.Payout.Interval > 0 {
+		policy.Interval = cfg.Payout.Interval
+	}
+	if cfg.Payout.ChallengeTTL > 0 {
+		policy.ChallengeTTL = cfg.Payout.ChallengeTTL
+	}
+	if cfg.Payout.MinimumAmount == "" {
+		return policy, nil
+	}
+
+	minimum, err := decimal.NewFromString(cfg.Payout.MinimumAmount)
+	if err != nil || minimum.IsNegative() {
+		return payout.Policy{}, fmt.Errorf("invalid payout.minimum_amount: %q", cfg.Payout.MinimumAmount)
+	}
+	policy.MinimumAmount = minimum
+	return policy, nil
+}
+
+// NewSagaPolicyProvider creates the policy of the payment settlement saga from the payout configuration.
+func NewSagaPolicyProvider(cfg *config.Config) saga.Policy {
+	policy := saga.DefaultPolicy()
+	if cfg.Payout.MaxFailures > 0 {
+		policy.MaxPayoutFailures = cfg.Payout.MaxFailures
+	}
+	return policy
+}
+
+// NewTreasuryPolicyProvider creates the treasury policy from configuration.
+func NewTreasuryPolicyProvider(cfg *config.Config) (treasury.Policy, error) {
+	policy := treasury.DefaultPolicy()
+	if cfg.Treasury.Interval > 0 {
+		policy.Interval = cfg.Treasury.Interval
+	}
+
+	for _, currency := range cfg.Treasury.Currencies {
+		if currency.Currency == "" {
+			return treasury.Policy{}, errors.New("treasury.currencies entries require a currency")
+		}
+
+		var amounts [3]decimal.Decimal
+		for i, value := range []string{currency.LowBalance, currency.HighBalance, currency.SweepMinimum} {
+			if value == "" {
+				continue
+			}
+			amount, err := decimal.NewFromString(value)
+			if err != nil || amount.IsNegative() {
+				return treasury.Policy{}, fmt.Errorf("invalid treasury amount %q for %s", value, currency.Currency)
+			}
+			amounts[i] = amount
+		}
+
+		policy.Currencies[currency.Currency] = treasury.CurrencyPolicy{
+			Thresholds:   treasury.Thresholds{Low: amounts[0], High: amounts[1]},
+			SweepMinimum: amounts[2],
+			ColdAddress:  currency.ColdAddress,
+		}
+	}
+	return policy, nil
+}
+
+// NewApprovalPolicyProvider creates the policy of which refunds and payouts need approval from configuration.
+func NewApprovalPolicyProvider(cfg *config.Config) (approval.Policy, error) {
+	policy := approval.DefaultPolicy()
+	if cfg.Approvals.Required > 0 {
+		policy.Required = cfg.Approvals.Required
+	}
+
+	for _, threshold := range cfg.Approvals.Thresholds {
+		if threshold.Currency == "" {
+			return approval.Policy{}, errors.New("approvals.thresholds entries require a currency")
+		}
+		amount, err := decimal.NewFromString(threshold.Amount)
+		if err != nil || !amount.IsPositive() {
+			return approval.Policy{}, fmt.Errorf(
+				"invalid approval threshold %q for %s", threshold.Amount, threshold.Currency)
+		}
+		policy.Thresholds[threshold.Currency] = amount
+	}
+	return policy, nil
+}
@@ -0,0 +1,301 @@
+// Package hotwallet sends payout transfers and treasury sweeps through an external signer service
+// that holds the platform hot wallet and deposit address keys, so no private key is loaded into the
+// checkout service.
+//
+// The signer exposes these endpoints:
+//
+//	POST /v1/transfers                                 sign and broadcast a transfer, idempotent per reference
+//	GET  /v1/transfers/{network}/{tx_hash}             report the state of a transfer or sweep
+//	POST /v1/sweeps                                    sweep a deposit address into the hot wallet
+//	GET  /v1/balances/{network}/{currency}[/{address}] report the hot wallet or a deposit address balance
+//	POST /v1/signatures/verify                         check a message signature against an address
+//
+// Transfer and sweep responses report the network fee paid, in the native currency, once confirmed.
+//
+// Every request carries the API key, the Unix time in seconds and the hex HMAC-SHA256, keyed with
+// the API secret, of the timestamp, method, path and body.
+package hotwallet
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/treasury"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// signerTransfersPath sends and reports transfers.
+	signerTransfersPath = "/v1/transfers"
+	// signerVerifyPath checks message signatures.
+	signerVerifyPath = "/v1/signatures/verify"
+	// signerSweepsPath sweeps deposit addresses.
+	signerSweepsPath = "/v1/sweeps"
+	// signerBalancesPath reports balances.
+	signerBalancesPath = "/v1/balances"
+
+	// APIKeyHeader carries the signer API key.
+	APIKeyHeader = "X-Signer-Key"
+	// TimestampHeader carries the Unix time, in seconds, at which a request was signed.
+	TimestampHeader = "X-Signer-Timestamp"
+	// SignatureHeader carries the request signature; see Sign.
+	SignatureHeader = "X-Signer-Signature"
+
+	// maxErrorBody limits how much of a signer error response is included in errors.
+	maxErrorBody = 512
+)
+
+// SignerClient sends payout transfers and treasury sweeps through the hot wallet signer service.
+type SignerClient struct {
+	baseURL string
+	apiKey  string
+	secret  string
+	client  *http.Client
+	now     func() time.Time
+}
+
+// NewSignerClient creates a new hot wallet signer client.
+func NewSignerClient(baseURL, apiKey, secret string, client *http.Client) *SignerClient {
+	return &SignerClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		secret:  secret,
+		client:  client,
+		now:     time.Now,
+	}
+}
+
+// transferRequest is the body of a transfer request.
+type transferRequest struct {
+	Reference string `json:"reference"`
+	Network   string `json:"network"`
+	Currency  string `json:"currency"`
+	To        string `json:"to"`
+	Amount    string `json:"amount"`
+}
+
+// transferResponse is a transfer as reported by the signer. The fee, in the network's native
+// currency, is reported once the transfer is confirmed.
+type transferResponse struct {
+	TxHash string `json:"tx_hash"`
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+	Fee    string `json:"fee"`
+}
+
+// sweepRequest is the body of a sweep request.
+type sweepRequest struct {
+	Reference string `json:"reference"`
+	Network   string `json:"network"`
+	Currency  string `json:"currency"`
+	From      string `json:"from"`
+	Amount    string `json:"amount"`
+}
+
+// balanceResponse is a balance as reported by the signer.
+type balanceResponse struct {
+	Balance string `json:"balance"`
+}
+
+// verifyRequest is the body of a signature check.
+type verifyRequest struct {
+	Network   string `json:"network"`
+	Address   string `json:"address"`
+	Message   string `json:"message"`
+	Signature string `json:"signature"`
+}
+
+// verifyResponse is the result of a signature check.
+type verifyResponse struct {
+	Valid bool `json:"valid"`
+}
+
+// Name returns the hot wallet name.
+func (c *SignerClient) Name() string {
+	return "signer"
+}
+
+// Transfer signs and broadcasts a transfer from the hot wallet.
+func (c *SignerClient) Transfer(ctx context.Context, req *payout.TransferRequest) (*payout.Transfer, error) {
+	if req == nil || req.Reference == "" || !req.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: a reference and positive amount are required", payout.ErrInvalidRequest)
+	}
+
+	var response transferResponse
+	if err := c.call(ctx, http.MethodPost, signerTransfersPath, transferRequest{
+		Reference: req.Reference,
+		Network:   req.Network.String(),
+		Currency:  req.Currency,
+		To:        req.ToAddress,
+		Amount:    req.Amount.String(),
+	}, &response); err != nil {
+		return nil, err
+	}
+	return toTransfer(&response), nil
+}
+
+// GetTransfer returns the state of a broadcast transfer.
+func (c *SignerClient) GetTransfer(
+	ctx context.Context,
+	network shared.BlockchainNetwork,
+	txHash string,
+) (*payout.Transfer, error) {
+	if txHash == "" {
+		return nil, fmt.Errorf("%w: transaction hash is required", payout.ErrInvalidRequest)
+	}
+
+	path := signerTransfersPath + "/" + url.PathEscape(network.String()) + "/" + url.PathEscape(txHash)
+	var response transferResponse
+	if err := c.call(ctx, http.MethodGet, path, nil, &response); err != nil {
+		return nil, err
+	}
+	if response.TxHash == "" {
+		response.TxHash = txHash
+	}
+	return toTransfer(&response), nil
+}
+
+// Sweep moves a deposit address balance into the hot wallet.
+func (c *SignerClient) Sweep(ctx context.Context, req *treasury.SweepRequest) (*payout.Transfer, error) {
+	if req == nil || req.Reference == "" || req.FromAddress == "" || !req.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: a reference, address and positive amount are required", treasury.ErrInvalidRequest)
+	}
+
+	var response transferResponse
+	if err := c.call(ctx, http.MethodPost, signerSweepsPath, sweepRequest{
+		Reference: req.Reference,
+		Network:   req.Network.String(),
+		Currency:  req.Currency,
+		From:      req.FromAddress,
+		Amount:    req.Amount.String(),
+	}, &response); err != nil {
+		return nil, err
+	}
+	return toTransfer(&response), nil
+}
+
+// Balance returns the balance of a currency at a deposit address, or in the hot wallet when the
+// request has no address.
+func (c *SignerClient) Balance(ctx context.Context, req *treasury.BalanceRequest) (decimal.Decimal, error) {
+	if req == nil || req.Currency == "" {
+		return decimal.Zero, fmt.Errorf("%w: a currency is required", treasury.ErrInvalidRequest)
+	}
+
+	path := signerBalancesPath + "/" + url.PathEscape(req.Network.String()) + "/" + url.PathEscape(req.Currency)
+	if req.Address != "" {
+		path += "/" + url.PathEscape(req.Address)
+	}
+
+	var response balanceResponse
+	if err := c.call(ctx, http.MethodGet, path, nil, &response); err != nil {
+		return decimal.Zero, err
+	}
+	balance, err := decimal.NewFromString(response.Balance)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%w: invalid balance %q", payout.ErrHotWalletFailure, response.Balance)
+	}
+	return balance, nil
+}
+
+// VerifySignature asks the signer whether the message was signed with the key of the address.
+func (c *SignerClient) VerifySignature(ctx context.Context, req *payout.SignatureRequest) (bool, error) {
+	if req == nil {
+		return false, fmt.Errorf("%w: signature request is required", payout.ErrInvalidRequest)
+	}
+
+	var response verifyResponse
+	if err := c.call(ctx, http.MethodPost, signerVerifyPath, verifyRequest{
+		Network:   req.Network.String(),
+		Address:   req.Address,
+		Message:   req.Message,
+		Signature: req.Signature,
+	}, &response); err != nil {
+		return false, err
+	}
+	return response.Valid, nil
+}
+
+// Sign returns the signature sent in SignatureHeader: the hex HMAC-SHA256, keyed with the API secret,
+// of the timestamp, method, path and body.
+func Sign(secret, timestamp, method, path string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + method + path))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// call sends a signed request with an optional JSON body and decodes the JSON response into out.
+func (c *SignerClient) call(ctx context.Context, method, path string, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		encoded, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to marshal signer request: %w", err)
+		}
+		body = encoded
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	timestamp := strconv.FormatInt(c.now().Unix(), 10)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set(APIKeyHeader, c.apiKey)
+	httpReq.Header.Set(TimestampHeader, timestamp)
+	httpReq.Header.Set(SignatureHeader, Sign(c.secret, timestamp, method, path, body))
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("%w: %w", payout.ErrHotWalletFailure, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return fmt.Errorf("%w: %s %s returned %d: %s", payout.ErrHotWalletFailure,
+			method, path, resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: failed to decode response: %w", payout.ErrHotWalletFailure, err)
+	}
+	return nil
+}
+
+// toTransfer converts a signer transfer, treating unknown states as still pending and a missing or
+// malformed fee as unknown.
+func toTransfer(response *transferResponse) *payout.Transfer {
+	state := payout.TransferStatePending
+	switch payout.TransferState(response.Status) {
+	case payout.TransferStateConfirmed:
+		state = payout.TransferStateConfirmed
+	case payout.TransferStateFailed:
+		state = payout.TransferStateFailed
+	case payout.TransferStatePending:
+	}
+
+	transfer := &payout.Transfer{
+		TxHash: response.TxHash,
+		State:  state,
+		Reason: response.Reason,
+	}
+	if fee, err := decimal.NewFromString(response.Fee); err == nil && !fee.IsNegative() {
+		transfer.Fee = &fee
+	}
+	return transfer
+}
@@ -0,0 +1,141 @@
+package hotwallet
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/treasury"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTxHash = "7c2d1f0e9b8a7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d"
+
+func TestSignerClient_TransferAndFollow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		timestamp := r.Header.Get(TimestampHeader)
+		assert.Equal(t, Sign("signer-secret", timestamp, r.Method, r.URL.Path, body), r.Header.Get(SignatureHeader))
+		assert.Equal(t, "signer-key", r.Header.Get(APIKeyHeader))
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == signerTransfersPath:
+			var req transferRequest
+			require.NoError(t, json.Unmarshal(body, &req))
+			assert.Equal(t, "payout-1", req.Reference)
+			assert.Equal(t, "tron", req.Network)
+			assert.Equal(t, "12.5", req.Amount)
+			_, _ = w.Write([]byte(`{"tx_hash":"` + testTxHash + `","status":"pending"}`))
+		case r.Method == http.MethodGet && r.URL.Path == signerTransfersPath+"/tron/"+testTxHash:
+			_, _ = w.Write([]byte(`{"status":"confirmed","fee":"13.4"}`))
+		case r.Method == http.MethodPost && r.URL.Path == signerVerifyPath:
+			var req verifyRequest
+			require.NoError(t, json.Unmarshal(body, &req))
+			_ = json.NewEncoder(w).Encode(verifyResponse{Valid: req.Signature == "0xgood"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewSignerClient(server.URL, "signer-key", "signer-secret", server.Client())
+	ctx := context.Background()
+
+	transfer, err := client.Transfer(ctx, &payout.TransferRequest{
+		Reference: "payout-1",
+		Network:   shared.NetworkTron,
+		Currency:  "USDT",
+		ToAddress: "TXYZabc123456789payout",
+		Amount:    decimal.RequireFromString("12.5"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testTxHash, transfer.TxHash)
+	assert.Equal(t, payout.TransferStatePending, transfer.State)
+
+	transfer, err = client.GetTransfer(ctx, shared.NetworkTron, testTxHash)
+	require.NoError(t, err)
+	assert.Equal(t, payout.TransferStateConfirmed, transfer.State)
+	assert.Equal(t, testTxHash, transfer.TxHash)
+	require.NotNil(t, transfer.Fee)
+	assert.Equal(t, "13.4", transfer.Fee.String())
+
+	valid, err := client.VerifySignature(ctx, &payout.SignatureRequest{Network: shared.NetworkTron, Signature: "0xgood"})
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = client.VerifySignature(ctx, &payout.SignatureRequest{Network: shared.NetworkTron, Signature: "0xbad"})
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
+
+func TestSignerClient_SweepAndBalances(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, Sign("signer-secret", r.Header.Get(TimestampHeader), r.Method, r.URL.Path, body),
+			r.Header.Get(SignatureHeader))
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == signerSweepsPath:
+			var req sweepRequest
+			require.NoError(t, json.Unmarshal(body, &req))
+			assert.Equal(t, "sweep-1", req.Reference)
+			assert.Equal(t, "TDepositAddress1", req.From)
+			assert.Equal(t, "99.5", req.Amount)
+			_, _ = w.Write([]byte(`{"tx_hash":"` + testTxHash + `","status":"pending"}`))
+		case r.Method == http.MethodGet && r.URL.Path == signerBalancesPath+"/tron/USDT":
+			_, _ = w.Write([]byte(`{"balance":"15000.25"}`))
+		case r.Method == http.MethodGet && r.URL.Path == signerBalancesPath+"/tron/USDT/TDepositAddress1":
+			_, _ = w.Write([]byte(`{"balance":"99.5"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewSignerClient(server.URL, "signer-key", "signer-secret", server.Client())
+	ctx := context.Background()
+
+	hot, err := client.Balance(ctx, &treasury.BalanceRequest{Network: shared.NetworkTron, Currency: "USDT"})
+	require.NoError(t, err)
+	assert.Equal(t, "15000.25", hot.String())
+
+	deposit, err := client.Balance(ctx, &treasury.BalanceRequest{
+		Network: shared.NetworkTron, Currency: "USDT", Address: "TDepositAddress1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "99.5", deposit.String())
+
+	transfer, err := client.Sweep(ctx, &treasury.SweepRequest{
+		Reference:   "sweep-1",
+		Network:     shared.NetworkTron,
+		Currency:    "USDT",
+		FromAddress: "TDepositAddress1",
+		Amount:      deposit,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testTxHash, transfer.TxHash)
+	assert.Equal(t, payout.TransferStatePending, transfer.State)
+}
+
+func TestSignerClient_ServiceError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"bad signature"}`))
+	}))
+	defer server.Close()
+
+	client := NewSignerClient(server.URL, "signer-key", "wrong-secret", server.Client())
+	_, err := client.GetTransfer(context.Background(), shared.NetworkTron, testTxHash)
+	require.ErrorIs(t, err, payout.ErrHotWalletFailure)
+	assert.Contains(t, err.Error(), "bad signature")
+}
@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"slices"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// levelCore writes the entries the levels let through for their module, the name of their logger.
+type levelCore struct {
+	zapcore.Core
+	levels *Levels
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	// Entries are checked against their module in Check; a level none of the modules logs at is off
+	return level >= zapcore.Level(c.levels.lowest.Load())
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.Enabled(entry.LoggerName, entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// samplingCore samples the debug entries of some modules, and writes the other entries as they come.
+type samplingCore struct {
+	zapcore.Core
+	sampled zapcore.Core
+	modules []string
+}
+
+func (c *samplingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &samplingCore{Core: c.Core.With(fields), sampled: c.sampled.With(fields), modules: c.modules}
+}
+
+func (c *samplingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if entry.Level == zapcore.DebugLevel && c.samples(entry.LoggerName) {
+		return c.sampled.Check(entry, checked)
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// samples reports whether a module or the module it belongs to is sampled.
+func (c *samplingCore) samples(module string) bool {
+	return slices.ContainsFunc(c.modules, func(sampled string) bool {
+		return module == sampled || strings.HasPrefix(module, sampled+".")
+	})
+}
@@ -1,12 +1,34 @@
 package logging
 
 import (
+	"context"
+	"crypto-checkout/pkg/config"
+
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 )
 
-// Module provides logging dependencies.
+// Module provides the logger and its levels, and writes out the logs its sinks hold when the application stops.
 var Module = fx.Module("logging",
 	fx.Provide(
-		NewLogger,
+		NewLoggerProvider,
+		func(logger *Logger) *zap.Logger { return logger.Logger },
+		func(logger *Logger) *Levels { return logger.Levels },
 	),
+	fx.Invoke(RegisterClose),
 )
+
+// NewLoggerProvider creates the logger from the log configuration.
+func NewLoggerProvider(cfg *config.Config) (*Logger, error) {
+	return New(cfg.Log)
+}
+
+// RegisterClose closes the sinks once the application has stopped. With the module listed first, its hook is
+// appended before those of the other modules, so it runs after them and their last logs are written out.
+func RegisterClose(lc fx.Lifecycle, logger *Logger) {
+	lc.Append(fx.Hook{
+		OnStop: func(_ context.Context) error {
+			return logger.Close()
+		},
+	})
+}
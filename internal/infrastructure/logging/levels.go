@@ -0,0 +1,170 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Error codes for API responses
+const (
+	ErrCodeInvalidLevel  = "INVALID_LOG_LEVEL"
+	ErrCodeInvalidModule = "INVALID_LOG_MODULE"
+)
+
+// Errors returned when changing levels.
+var (
+	ErrInvalidLevel  = errors.New("invalid log level")
+	ErrInvalidModule = errors.New("invalid log module")
+)
+
+// ParseLevel parses a level name such as debug or warn.
+func ParseLevel(name string) (zapcore.Level, error) {
+	level, err := zapcore.ParseLevel(name)
+	if err != nil || level > zapcore.FatalLevel {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidLevel, name)
+	}
+	return level, nil
+}
+
+// validModule reports whether a module name is made of dot separated lowercase words, such as detection.watcher.
+func validModule(module string) bool {
+	for part := range strings.SplitSeq(module, ".") {
+		if part == "" {
+			return false
+		}
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' && r != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Levels holds the level of the application's logs and of the modules set apart from it. A module is the name of
+// a logger: the entries of detection.watcher follow its own level, else that of detection, else the default one.
+// Levels can be changed while the application runs; Reset goes back to the configured ones.
+type Levels struct {
+	mu         sync.RWMutex
+	level      zapcore.Level
+	modules    map[string]zapcore.Level
+	configured zapcore.Level
+	defaults   map[string]zapcore.Level
+
+	// lowest is the lowest of the levels, below which nothing is logged
+	lowest atomic.Int32
+}
+
+// NewLevels creates levels with the configured default level and module levels.
+func NewLevels(level zapcore.Level, modules map[string]zapcore.Level) *Levels {
+	l := &Levels{
+		level:      level,
+		modules:    maps.Clone(modules),
+		configured: level,
+		defaults:   maps.Clone(modules),
+	}
+	if l.modules == nil {
+		l.modules = make(map[string]zapcore.Level)
+	}
+	l.updateLowest()
+	return l
+}
+
+// Level returns the default level.
+func (l *Levels) Level() zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.level
+}
+
+// Modules returns the levels of the modules set apart from the default one.
+func (l *Levels) Modules() map[string]zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return maps.Clone(l.modules)
+}
+
+// SetLevel changes the default level.
+func (l *Levels) SetLevel(level zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = level
+	l.updateLowest()
+}
+
+// SetModuleLevel changes the level of a module and of its own modules that have none.
+func (l *Levels) SetModuleLevel(module string, level zapcore.Level) error {
+	if !validModule(module) {
+		return fmt.Errorf("%w: %q", ErrInvalidModule, module)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.modules[module] = level
+	l.updateLowest()
+	return nil
+}
+
+// ResetModuleLevel puts a module back to its configured level, or to that of the module it belongs to when none
+// is configured.
+func (l *Levels) ResetModuleLevel(module string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if level, ok := l.defaults[module]; ok {
+		l.modules[module] = level
+	} else {
+		delete(l.modules, module)
+	}
+	l.updateLowest()
+}
+
+// Reset puts every level back to the configured one.
+func (l *Levels) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = l.configured
+	l.modules = maps.Clone(l.defaults)
+	if l.modules == nil {
+		l.modules = make(map[string]zapcore.Level)
+	}
+	l.updateLowest()
+}
+
+// Enabled reports whether the module logs at the level.
+func (l *Levels) Enabled(module string, level zapcore.Level) bool {
+	if level < zapcore.Level(l.lowest.Load()) {
+		return false
+	}
+	return level >= l.ModuleLevel(module)
+}
+
+// ModuleLevel returns the level a module logs at.
+func (l *Levels) ModuleLevel(module string) zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for name := module; name != ""; {
+		if level, ok := l.modules[name]; ok {
+			return level
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return l.level
+}
+
+// updateLowest keeps the lowest level; the lock must be held.
+func (l *Levels) updateLowest() {
+	lowest := l.level
+	for _, level := range l.modules {
+		lowest = min(lowest, level)
+	}
+	l.lowest.Store(int32(lowest))
+}
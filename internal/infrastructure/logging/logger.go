@@ -1,32 +1,235 @@
+// Package logging builds the application's logger from the log configuration: the sinks logs are written to,
+// such as stdout, a rotated file or Loki, the level of each module, which can be changed while the application
+// runs, and the sampling of high-volume debug logs.
 package logging
 
 import (
+	"crypto-checkout/pkg/config"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-// Logger provides a structured logger for the application.
+// Sink types
+const (
+	SinkStdout = "stdout"
+	SinkStderr = "stderr"
+	SinkFile   = "file"
+	SinkLoki   = "loki"
+)
+
+// Encodings of the logs
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// ErrInvalidConfig is returned for a log configuration that cannot be built.
+var ErrInvalidConfig = errors.New("invalid log configuration")
+
+// Logger provides a structured logger for the application, with the levels of its modules.
 type Logger struct {
 	*zap.Logger
+	Levels *Levels
+
+	closers []io.Closer
 }
 
-// NewLogger creates a new logger instance.
-func NewLogger() *Logger {
-	config := zap.NewProductionConfig()
-	config.EncoderConfig.TimeKey = "timestamp"
-	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+// New creates the logger from the log configuration. Modules log under their logger name; see Named.
+func New(cfg config.LogConfig) (*Logger, error) {
+	levelName := cfg.Level
+	if levelName == "" {
+		levelName = config.DefaultLogLevel
+	}
+	level, err := ParseLevel(levelName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: log.level: %w", ErrInvalidConfig, err)
+	}
+	modules := make(map[string]zapcore.Level, len(cfg.Modules))
+	for module, name := range cfg.Modules {
+		if !validModule(module) {
+			return nil, fmt.Errorf("%w: log.modules: %w: %q", ErrInvalidConfig, ErrInvalidModule, module)
+		}
+		if modules[module], err = ParseLevel(name); err != nil {
+			return nil, fmt.Errorf("%w: log.modules.%s: %w", ErrInvalidConfig, module, err)
+		}
+	}
+
+	sinks := cfg.Sinks
+	if len(sinks) == 0 {
+		sinks = []config.LogSinkConfig{{Type: SinkStdout}}
+	}
+	logger := &Logger{Levels: NewLevels(level, modules)}
+	cores := make([]zapcore.Core, 0, len(sinks))
+	for i, sink := range sinks {
+		core, closer, err := newSinkCore(sink, cfg.Dir, level)
+		if err != nil {
+			logger.Close()
+			return nil, fmt.Errorf("%w: log.sinks[%d]: %w", ErrInvalidConfig, i, err)
+		}
+		cores = append(cores, core)
+		if closer != nil {
+			logger.closers = append(logger.closers, closer)
+		}
+	}
+
+	core := zapcore.NewTee(cores...)
+	if sampled, err := newSamplingCore(core, cfg.Sampling); err != nil {
+		logger.Close()
+		return nil, fmt.Errorf("%w: log.sampling: %w", ErrInvalidConfig, err)
+	} else if sampled != nil {
+		core = sampled
+	}
+	logger.Logger = zap.New(&levelCore{Core: core, levels: logger.Levels},
+		zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
+	return logger, nil
+}
 
-	logger, err := config.Build()
+// newSinkCore creates the core writing to a sink, and what closes the sink if anything.
+func newSinkCore(sink config.LogSinkConfig, dir string, level zapcore.Level) (zapcore.Core, io.Closer, error) {
+	// The levels decide what is logged; a sink only sets itself a higher minimum
+	enabled := zapcore.DebugLevel
+	if sink.Level != "" {
+		var err error
+		if enabled, err = ParseLevel(sink.Level); err != nil {
+			return nil, nil, err
+		}
+	}
+	format := sink.Format
+	if format == "" {
+		format = FormatJSON
+		if (sink.Type == SinkStdout || sink.Type == SinkStderr) && level == zapcore.DebugLevel {
+			format = FormatConsole
+		}
+	}
+	encoder, err := newEncoder(format)
 	if err != nil {
-		// Fallback to development config if production config fails
-		logger, _ = zap.NewDevelopment()
+		return nil, nil, err
+	}
+
+	var out zapcore.WriteSyncer
+	var closer io.Closer
+	switch sink.Type {
+	case SinkStdout:
+		out = zapcore.Lock(os.Stdout)
+	case SinkStderr:
+		out = zapcore.Lock(os.Stderr)
+	case SinkFile:
+		path := sink.Path
+		if path == "" {
+			path = filepath.Join(dir, defaultFileName)
+		}
+		maxSize := sink.MaxSizeMB
+		if maxSize <= 0 {
+			maxSize = defaultFileMaxSizeMB
+		}
+		maxBackups := sink.MaxBackups
+		if maxBackups <= 0 {
+			maxBackups = defaultFileMaxBackups
+		}
+		file, err := openRotatingFile(path, int64(maxSize)<<20, maxBackups)
+		if err != nil {
+			return nil, nil, err
+		}
+		out, closer = file, file
+	case SinkLoki:
+		if sink.URL == "" {
+			return nil, nil, errors.New("a loki sink needs a url")
+		}
+		labels := sink.Labels
+		if len(labels) == 0 {
+			labels = map[string]string{"app": "crypto-checkout"}
+		}
+		batchSize := sink.BatchSize
+		if batchSize <= 0 {
+			batchSize = defaultLokiBatchSize
+		}
+		interval := sink.FlushInterval
+		if interval <= 0 {
+			interval = defaultLokiFlushInterval
+		}
+		timeout := sink.Timeout
+		if timeout <= 0 {
+			timeout = defaultLokiTimeout
+		}
+		loki := newLokiWriter(sink.URL, sink.TenantID, labels, batchSize, interval, timeout)
+		out, closer = loki, loki
+	default:
+		return nil, nil, fmt.Errorf("unknown sink type %q", sink.Type)
+	}
+	return zapcore.NewCore(encoder, out, enabled), closer, nil
+}
+
+// newEncoder creates the encoder of a format: JSON with ISO 8601 timestamps, or console for reading in a
+// terminal.
+func newEncoder(format string) (zapcore.Encoder, error) {
+	switch format {
+	case FormatJSON:
+		encoderConfig := zap.NewProductionEncoderConfig()
+		encoderConfig.TimeKey = "timestamp"
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		return zapcore.NewJSONEncoder(encoderConfig), nil
+	case FormatConsole:
+		return zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// newSamplingCore wraps the core to sample the debug logs of the configured modules, or returns nil when none
+// are.
+func newSamplingCore(core zapcore.Core, cfg config.LogSamplingConfig) (zapcore.Core, error) {
+	if len(cfg.Modules) == 0 {
+		return nil, nil
+	}
+	for _, module := range cfg.Modules {
+		if !validModule(module) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidModule, module)
+		}
+	}
+	tick := cfg.Tick
+	if tick <= 0 {
+		tick = time.Second
 	}
+	if cfg.Initial < 0 || cfg.Thereafter < 0 {
+		return nil, errors.New("initial and thereafter must not be negative")
+	}
+	return &samplingCore{
+		Core:    core,
+		sampled: zapcore.NewSamplerWithOptions(core, tick, cfg.Initial, cfg.Thereafter),
+		modules: cfg.Modules,
+	}, nil
+}
 
-	return &Logger{Logger: logger}
+// Close writes out the logs the sinks hold and closes them.
+func (l *Logger) Close() error {
+	var errs []error
+	if l.Logger != nil {
+		// Syncing stdout fails on terminals and pipes, which hold nothing back
+		_ = l.Logger.Sync()
+	}
+	for _, closer := range l.closers {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
 
-// NewDevelopmentLogger creates a logger for development.
-func NewDevelopmentLogger() *Logger {
-	logger, _ := zap.NewDevelopment()
-	return &Logger{Logger: logger}
+// Named scopes a module of the application so that the logger its constructors receive is named after it, and
+// logs at the level set for it.
+func Named(name string, module fx.Option) fx.Option {
+	return fx.Module(name,
+		fx.Decorate(func(logger *zap.Logger) *zap.Logger {
+			return logger.Named(name)
+		}),
+		module,
+	)
 }
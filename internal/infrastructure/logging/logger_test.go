@@ -0,0 +1,240 @@
+package logging_test
+
+import (
+	"crypto-checkout/internal/infrastructure/logging"
+	"crypto-checkout/pkg/config"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// newFileLogger creates a logger writing JSON to a file, and returns a function reading the messages written.
+func newFileLogger(t *testing.T, cfg config.LogConfig) (*logging.Logger, func() []map[string]interface{}) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "app.log")
+	cfg.Sinks = append(cfg.Sinks, config.LogSinkConfig{Type: logging.SinkFile, Path: path})
+	logger, err := logging.New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { logger.Close() })
+
+	return logger, func() []map[string]interface{} {
+		require.NoError(t, logger.Sync())
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var entries []map[string]interface{}
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			if line == "" {
+				continue
+			}
+			var entry map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+}
+
+func messages(entries []map[string]interface{}) []string {
+	all := make([]string, len(entries))
+	for i, entry := range entries {
+		all[i] = entry["msg"].(string)
+	}
+	return all
+}
+
+func TestModuleLevels(t *testing.T) {
+	logger, read := newFileLogger(t, config.LogConfig{
+		Level:   "info",
+		Modules: map[string]string{"detection": "warn", "detection.watcher": "debug"},
+	})
+	detection := logger.Named("detection")
+	watcher := detection.Named("watcher")
+	webhook := logger.Named("webhook")
+
+	detection.Info("detection info")
+	detection.Warn("detection warn")
+	watcher.Debug("watcher debug")
+	webhook.Debug("webhook debug")
+	webhook.Info("webhook info")
+	assert.Equal(t, []string{"detection warn", "watcher debug", "webhook info"}, messages(read()))
+
+	t.Run("changed at runtime", func(t *testing.T) {
+		require.NoError(t, logger.Levels.SetModuleLevel("webhook", zapcore.DebugLevel))
+		logger.Levels.SetLevel(zapcore.ErrorLevel)
+		webhook.Debug("webhook debug again")
+		logger.Info("default info")
+		entries := read()
+		assert.Equal(t, "webhook debug again", entries[len(entries)-1]["msg"])
+		assert.Equal(t, "webhook", entries[len(entries)-1]["logger"])
+
+		logger.Levels.ResetModuleLevel("webhook")
+		assert.Equal(t, zapcore.ErrorLevel, logger.Levels.ModuleLevel("webhook"), "back to the default level")
+		logger.Levels.Reset()
+		assert.Equal(t, zapcore.InfoLevel, logger.Levels.Level())
+		assert.Equal(t, map[string]zapcore.Level{
+			"detection":         zapcore.WarnLevel,
+			"detection.watcher": zapcore.DebugLevel,
+		}, logger.Levels.Modules())
+	})
+
+	t.Run("invalid module", func(t *testing.T) {
+		err := logger.Levels.SetModuleLevel("Detection..watcher", zapcore.DebugLevel)
+		require.ErrorIs(t, err, logging.ErrInvalidModule)
+	})
+}
+
+func TestSampling(t *testing.T) {
+	logger, read := newFileLogger(t, config.LogConfig{
+		Level: "debug",
+		Sampling: config.LogSamplingConfig{
+			Modules:    []string{"detection.watcher"},
+			Tick:       time.Hour,
+			Initial:    2,
+			Thereafter: 5,
+		},
+	})
+	watcher := logger.Named("detection").Named("watcher")
+	detection := logger.Named("detection")
+	for range 12 {
+		watcher.Debug("Scanned blocks")
+		watcher.Info("Started watching the chain")
+		detection.Debug("Detected payment")
+	}
+
+	count := make(map[string]int)
+	for _, message := range messages(read()) {
+		count[message]++
+	}
+	// The first two, then the 7th and 12th
+	assert.Equal(t, 4, count["Scanned blocks"])
+	assert.Equal(t, 12, count["Started watching the chain"], "only debug logs are sampled")
+	assert.Equal(t, 12, count["Detected payment"], "only the sampled modules are")
+}
+
+func TestFileRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+	logger, err := logging.New(config.LogConfig{
+		Sinks: []config.LogSinkConfig{{Type: logging.SinkFile, Path: path, MaxSizeMB: 1, MaxBackups: 2}},
+	})
+	require.NoError(t, err)
+
+	line := strings.Repeat("x", 100<<10)
+	for range 40 {
+		logger.Info(line)
+	}
+	require.NoError(t, logger.Close())
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(name)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), int64(1<<20))
+		assert.Positive(t, info.Size())
+	}
+	assert.NoFileExists(t, path+".3")
+}
+
+func TestLokiSink(t *testing.T) {
+	var mu sync.Mutex
+	var pushes []map[string]interface{}
+	var tenant string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var push map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&push); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		pushes = append(pushes, push)
+		tenant = r.Header.Get("X-Scope-OrgID")
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	logger, err := logging.New(config.LogConfig{
+		Sinks: []config.LogSinkConfig{{
+			Type:          logging.SinkLoki,
+			URL:           server.URL + "/loki/api/v1/push",
+			Labels:        map[string]string{"app": "checkout", "env": "test"},
+			TenantID:      "ops",
+			BatchSize:     2,
+			FlushInterval: time.Hour,
+		}},
+	})
+	require.NoError(t, err)
+
+	logger.Info("first", zap.String("invoice_id", "inv_1"))
+	logger.Info("second")
+	logger.Warn("third")
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(pushes) > 0
+	}, time.Second, 10*time.Millisecond, "a full batch is pushed without waiting for the interval")
+
+	require.NoError(t, logger.Close())
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, pushes, 2, "the rest is pushed on close")
+	assert.Equal(t, "ops", tenant)
+	last := pushes[1]["streams"].([]interface{})[0].(map[string]interface{})
+	assert.Len(t, last["values"], 1)
+
+	stream := pushes[0]["streams"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"app": "checkout", "env": "test"}, stream["stream"])
+	values := stream["values"].([]interface{})
+	require.Len(t, values, 2)
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(values[0].([]interface{})[1].(string)), &entry))
+	assert.Equal(t, "first", entry["msg"])
+	assert.Equal(t, "inv_1", entry["invoice_id"])
+}
+
+func TestInvalidConfig(t *testing.T) {
+	for name, cfg := range map[string]config.LogConfig{
+		"level":        {Level: "verbose"},
+		"module name":  {Modules: map[string]string{"Detection": "debug"}},
+		"sink":         {Sinks: []config.LogSinkConfig{{Type: "syslog"}}},
+		"loki url":     {Sinks: []config.LogSinkConfig{{Type: logging.SinkLoki}}},
+		"format":       {Sinks: []config.LogSinkConfig{{Type: logging.SinkStdout, Format: "xml"}}},
+		"sampling":     {Sampling: config.LogSamplingConfig{Modules: []string{"detection."}}},
+		"module level": {Modules: map[string]string{"detection": "loud"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := logging.New(cfg)
+			require.ErrorIs(t, err, logging.ErrInvalidConfig)
+		})
+	}
+}
+
+func TestNamed(t *testing.T) {
+	logger, read := newFileLogger(t, config.LogConfig{Level: "info"})
+	app := fxtest.New(t,
+		fx.Supply(logger.Logger),
+		logging.Named("detection", fx.Invoke(func(logger *zap.Logger) {
+			logger.Info("from the module")
+		})),
+		fx.Invoke(func(logger *zap.Logger) {
+			logger.Info("from the application")
+		}),
+	)
+	app.RequireStart().RequireStop()
+
+	entries := read()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "detection", entries[0]["logger"])
+	assert.Nil(t, entries[1]["logger"])
+}
@@ -0,0 +1,196 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/pkg/metrics"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Defaults of loki sinks
+const (
+	defaultLokiBatchSize     = 500
+	defaultLokiFlushInterval = 2 * time.Second
+	defaultLokiTimeout       = 10 * time.Second
+	// lokiBufferedBatches is how many batches are kept while Loki is slow, after which the oldest logs are dropped
+	lokiBufferedBatches = 10
+)
+
+// droppedEntries counts the log entries sinks could not deliver on /metrics.
+var droppedEntries = metrics.NewCounter(metrics.Namespace+"_log_entries_dropped_total",
+	"Log entries a sink dropped because its destination could not take them, by sink.", "sink")
+
+func init() {
+	metrics.AddAlert(metrics.AlertRule{
+		Name:     "LogEntriesDropped",
+		Expr:     fmt.Sprintf("sum by (sink) (increase(%s[15m])) > 0", droppedEntries.Name()),
+		For:      15 * time.Minute,
+		Severity: metrics.SeverityWarning,
+		Summary:  "Logs are being dropped",
+		Description: "The {{ $labels.sink }} log sink dropped {{ $value }} entries in the last 15 minutes; its " +
+			"destination cannot be reached or cannot keep up.",
+		Metrics: []string{droppedEntries.Name()},
+	})
+}
+
+// lokiPush is the body of a push to Loki's HTTP API.
+type lokiPush struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	// Values are the timestamps in nanoseconds and the lines
+	Values [][2]string `json:"values"`
+}
+
+// lokiWriter pushes the logs written to it to Loki in batches, once a batch is full or on each flush interval.
+// Logs that cannot be pushed are dropped rather than held up, and counted.
+type lokiWriter struct {
+	url       string
+	tenantID  string
+	labels    map[string]string
+	batchSize int
+	client    *http.Client
+
+	mu      sync.Mutex
+	pending [][2]string
+
+	// pushing serializes the pushes so that the logs of a stream arrive in order
+	pushing sync.Mutex
+	full    chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+// newLokiWriter creates a writer pushing to the URL and starts pushing on the interval.
+func newLokiWriter(
+	url, tenantID string, labels map[string]string, batchSize int, interval, timeout time.Duration,
+) *lokiWriter {
+	w := &lokiWriter{
+		url:       url,
+		tenantID:  tenantID,
+		labels:    maps.Clone(labels),
+		batchSize: batchSize,
+		client:    &http.Client{Timeout: timeout},
+		full:      make(chan struct{}, 1),
+		done:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+	go w.run(interval)
+	return w
+}
+
+// Write adds an encoded entry to the batch.
+func (w *lokiWriter) Write(p []byte) (int, error) {
+	line := string(bytes.TrimRight(p, "\n"))
+	value := [2]string{strconv.FormatInt(time.Now().UnixNano(), 10), line}
+
+	w.mu.Lock()
+	if len(w.pending) >= w.batchSize*lokiBufferedBatches {
+		w.pending = w.pending[1:]
+		droppedEntries.Inc("loki")
+	}
+	w.pending = append(w.pending, value)
+	full := len(w.pending) >= w.batchSize
+	w.mu.Unlock()
+
+	if full {
+		select {
+		case w.full <- struct{}{}:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+func (w *lokiWriter) run(interval time.Duration) {
+	defer close(w.stopped)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+		case <-w.full:
+		}
+		w.flush()
+	}
+}
+
+// flush pushes the pending logs, a batch at a time.
+func (w *lokiWriter) flush() {
+	w.pushing.Lock()
+	defer w.pushing.Unlock()
+
+	for {
+		w.mu.Lock()
+		batch := w.pending[:min(len(w.pending), w.batchSize)]
+		w.pending = w.pending[len(batch):]
+		w.mu.Unlock()
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := w.push(batch); err != nil {
+			// The logger cannot log its own failures; they go to stderr like zap's
+			fmt.Fprintf(os.Stderr, "failed to push %d log entries to Loki: %v\n", len(batch), err)
+			droppedEntries.Add(float64(len(batch)), "loki")
+			return
+		}
+	}
+}
+
+// push sends a batch to Loki.
+func (w *lokiWriter) push(batch [][2]string) error {
+	body, err := json.Marshal(lokiPush{Streams: []lokiStream{{Stream: w.labels, Values: batch}}})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.tenantID != "" {
+		req.Header.Set("X-Scope-OrgID", w.tenantID)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("loki answered %d: %s", resp.StatusCode, bytes.TrimSpace(message))
+	}
+	return nil
+}
+
+// Sync pushes the pending logs.
+func (w *lokiWriter) Sync() error {
+	w.flush()
+	return nil
+}
+
+// Close stops pushing on the interval and pushes the pending logs.
+func (w *lokiWriter) Close() error {
+	w.once.Do(func() {
+		close(w.done)
+		<-w.stopped
+		w.flush()
+	})
+	return nil
+}
@@ -0,0 +1,60 @@
+package mailer
+
+import (
+	"crypto-checkout/internal/domain/customer"
+	"crypto-checkout/internal/domain/statement"
+	"crypto-checkout/pkg/config"
+	"fmt"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides the email senders for Fx.
+var Module = fx.Module("mailer",
+	fx.Provide(NewLoginLinkSenderProvider, NewStatementNotifierProvider),
+)
+
+// NewLoginLinkSenderProvider creates the sender of customer login links from configuration. Without an SMTP host,
+// login links are logged instead of sent.
+func NewLoginLinkSenderProvider(cfg *config.Config, logger *zap.Logger) (customer.LoginLinkSender, error) {
+	if cfg.SMTP.Host == "" {
+		if cfg.Customers.Enabled {
+			logger.Warn("Customer accounts are enabled but smtp.host is not set; login links are only logged")
+		}
+		return NewLogSender(logger), nil
+	}
+	return newSMTPSender(cfg, logger)
+}
+
+// NewStatementNotifierProvider creates the sender of statement emails from configuration. Without an SMTP host,
+// statement emails are logged instead of sent.
+func NewStatementNotifierProvider(cfg *config.Config, logger *zap.Logger) (statement.Notifier, error) {
+	if cfg.SMTP.Host == "" {
+		if cfg.Statements.Enabled && cfg.Statements.Email {
+			logger.Warn("Statement emails are enabled but smtp.host is not set; they are only logged")
+		}
+		return NewLogSender(logger), nil
+	}
+	return newSMTPSender(cfg, logger)
+}
+
+// newSMTPSender creates an SMTP sender from configuration.
+func newSMTPSender(cfg *config.Config, logger *zap.Logger) (*SMTPSender, error) {
+	timeout := cfg.SMTP.Timeout
+	if timeout <= 0 {
+		timeout = config.DefaultSMTPTimeout
+	}
+	sender, err := NewSMTPSender(Options{
+		Host:     cfg.SMTP.Host,
+		Port:     cfg.SMTP.Port,
+		Username: cfg.SMTP.Username,
+		Password: cfg.SMTP.Password,
+		From:     cfg.SMTP.From,
+		Timeout:  timeout,
+	}, logger)
+	if err != nil {
+		return nil, fmt.Errorf("invalid smtp configuration: %w", err)
+	}
+	return sender, nil
+}
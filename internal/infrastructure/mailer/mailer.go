@@ -0,0 +1,185 @@
+// Package mailer sends the emails of the crypto-checkout application, such as customer login links and merchant
+// statement notices.
+package mailer
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/statement"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// loginLinkSubject is the subject of customer login link emails.
+const loginLinkSubject = "Your sign-in link"
+
+// statementSubject is the subject of statement emails, completed with the month.
+const statementSubject = "Your statement for "
+
+// Options configures an SMTP sender.
+type Options struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+// SMTPSender sends emails through an SMTP server, upgrading the connection with STARTTLS when the server offers
+// it. It implements customer.LoginLinkSender and statement.Notifier.
+type SMTPSender struct {
+	options Options
+	from    *mail.Address
+	logger  *zap.Logger
+}
+
+// NewSMTPSender creates a new SMTP sender.
+func NewSMTPSender(options Options, logger *zap.Logger) (*SMTPSender, error) {
+	if options.Host == "" {
+		return nil, errors.New("SMTP host is required")
+	}
+	if options.Port <= 0 || options.Port > 65535 {
+		return nil, fmt.Errorf("invalid SMTP port: %d", options.Port)
+	}
+	from, err := mail.ParseAddress(options.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", options.From, err)
+	}
+	return &SMTPSender{options: options, from: from, logger: logger}, nil
+}
+
+// SendLoginLink emails a customer login link.
+func (s *SMTPSender) SendLoginLink(ctx context.Context, email, link string, expiresAt time.Time) error {
+	return s.send(ctx, email, loginLinkSubject, loginLinkBody(link, expiresAt))
+}
+
+// SendStatementReady emails a merchant that a monthly statement is ready.
+func (s *SMTPSender) SendStatementReady(
+	ctx context.Context,
+	email, businessName string,
+	stmt *statement.Statement,
+) error {
+	return s.send(ctx, email, statementSubject+stmt.PeriodStart.Format("January 2006"), statementBody(businessName, stmt))
+}
+
+// send delivers a plain-text email to one recipient.
+func (s *SMTPSender) send(ctx context.Context, to, subject, body string) error {
+	address := net.JoinHostPort(s.options.Host, strconv.Itoa(s.options.Port))
+	dialer := &net.Dialer{Timeout: s.options.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	if s.options.Timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(s.options.Timeout))
+	}
+
+	client, err := smtp.NewClient(conn, s.options.Host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.options.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if s.options.Username != "" {
+		// PlainAuth refuses to send the password over an unencrypted connection to another host
+		auth := smtp.PlainAuth("", s.options.Username, s.options.Password, s.options.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("SMTP server rejected the sender: %w", err)
+	}
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("SMTP server rejected the recipient: %w", err)
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start SMTP message: %w", err)
+	}
+	if _, err := writer.Write(s.message(to, subject, body)); err != nil {
+		_ = writer.Close()
+		return fmt.Errorf("failed to write SMTP message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("SMTP server rejected the message: %w", err)
+	}
+	return client.Quit()
+}
+
+// message formats a plain-text email.
+func (s *SMTPSender) message(to, subject, body string) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + s.from.String() + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().UTC().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// LogSender logs emails instead of sending them, for development without a mail server.
+type LogSender struct {
+	logger *zap.Logger
+}
+
+// NewLogSender creates a new log sender.
+func NewLogSender(logger *zap.Logger) *LogSender {
+	return &LogSender{logger: logger}
+}
+
+// SendLoginLink logs a customer login link; the link itself is only logged at debug level.
+func (s *LogSender) SendLoginLink(_ context.Context, email, link string, expiresAt time.Time) error {
+	s.logger.Info("SMTP is not configured; customer login link not sent", zap.Time("expires_at", expiresAt))
+	s.logger.Debug("Customer login link", zap.String("email", email), zap.String("link", link))
+	return nil
+}
+
+// SendStatementReady logs a statement notice.
+func (s *LogSender) SendStatementReady(_ context.Context, _, _ string, stmt *statement.Statement) error {
+	s.logger.Info("SMTP is not configured; statement email not sent",
+		zap.String("merchant_id", stmt.MerchantID), zap.String("period", stmt.Period()))
+	return nil
+}
+
+// loginLinkBody returns the text of a login link email.
+func loginLinkBody(link string, expiresAt time.Time) string {
+	return "Use the link below to sign in to your payment history.\n\n" +
+		link + "\n\n" +
+		"The link works once, in the browser you requested it from, until " +
+		expiresAt.UTC().Format("2 Jan 2006 15:04 MST") + ".\n" +
+		"If you did not ask to sign in, you can ignore this email.\n"
+}
+
+// statementBody returns the text of a statement email.
+func statementBody(businessName string, stmt *statement.Statement) string {
+	greeting := "Hello,"
+	if businessName != "" {
+		greeting = "Hello " + businessName + ","
+	}
+	return greeting + "\n\n" +
+		"Your statement for " + stmt.PeriodStart.Format("January 2006") + " is ready: " +
+		strconv.Itoa(stmt.InvoiceTotal()) + " invoices and the volume, fees, refunds and settlements of " +
+		strconv.Itoa(len(stmt.Currencies)) + " currencies.\n\n" +
+		"Download it as PDF or CSV from GET /api/v1/statements/" + stmt.ID + "/download.\n"
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// BlockCursorRepository implements the detection.CursorRepository interface in memory.
+type BlockCursorRepository struct {
+	mu      sync.RWMutex
+	cursors map[cursorKey]detection.BlockCursor
+}
+
+// cursorKey identifies the cursor of a watcher on a network.
+type cursorKey struct {
+	network shared.BlockchainNetwork
+	watcher string
+}
+
+// NewBlockCursorRepository creates a new in-memory block cursor repository.
+func NewBlockCursorRepository() *BlockCursorRepository {
+	return &BlockCursorRepository{cursors: make(map[cursorKey]detection.BlockCursor)}
+}
+
+// FindCursor returns the cursor of a watcher on a network, or detection.ErrCursorNotFound.
+func (r *BlockCursorRepository) FindCursor(
+	ctx context.Context,
+	network shared.BlockchainNetwork,
+	watcher string,
+) (*detection.BlockCursor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cursor, ok := r.cursors[cursorKey{network: network, watcher: watcher}]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s on %s", detection.ErrCursorNotFound, watcher, network)
+	}
+	return &cursor, nil
+}
+
+// ListCursors returns the cursors of every watcher and network, ordered by network and watcher.
+func (r *BlockCursorRepository) ListCursors(ctx context.Context) ([]*detection.BlockCursor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cursors := make([]*detection.BlockCursor, 0, len(r.cursors))
+	for _, cursor := range r.cursors {
+		cursors = append(cursors, &cursor)
+	}
+	slices.SortFunc(cursors, func(a, b *detection.BlockCursor) int {
+		return cmp.Or(cmp.Compare(a.Network, b.Network), cmp.Compare(a.Watcher, b.Watcher))
+	})
+	return cursors, nil
+}
+
+// AdvanceCursor stores the cursor, only moving an existing cursor forward.
+func (r *BlockCursorRepository) AdvanceCursor(ctx context.Context, cursor *detection.BlockCursor) error {
+	stored := *cursor
+	if stored.UpdatedAt.IsZero() {
+		stored.UpdatedAt = shared.Now().UTC()
+	}
+	key := cursorKey{network: cursor.Network, watcher: cursor.Watcher}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.cursors[key]; ok && existing.Block > cursor.Block {
+		return fmt.Errorf("%w: %s on %s to block %d", detection.ErrCursorRegression,
+			cursor.Watcher, cursor.Network, cursor.Block)
+	}
+	r.cursors[key] = stored
+	return nil
+}
+
+var _ detection.CursorRepository = (*BlockCursorRepository)(nil)
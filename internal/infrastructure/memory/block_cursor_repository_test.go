@@ -0,0 +1,42 @@
+package memory_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/memory"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockCursorRepository(t *testing.T) {
+	repo := memory.NewBlockCursorRepository()
+	ctx := context.Background()
+
+	advance := func(network shared.BlockchainNetwork, block int64) error {
+		return repo.AdvanceCursor(ctx, &detection.BlockCursor{
+			Network: network, Watcher: detection.ScannerWatcher, Block: block,
+		})
+	}
+
+	_, err := repo.FindCursor(ctx, shared.NetworkEthereum, detection.ScannerWatcher)
+	require.ErrorIs(t, err, detection.ErrCursorNotFound)
+
+	require.NoError(t, advance(shared.NetworkTron, 50))
+	require.NoError(t, advance(shared.NetworkEthereum, 1100))
+	require.NoError(t, advance(shared.NetworkEthereum, 1100))
+	require.ErrorIs(t, advance(shared.NetworkEthereum, 1050), detection.ErrCursorRegression)
+
+	cursor, err := repo.FindCursor(ctx, shared.NetworkEthereum, detection.ScannerWatcher)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1100), cursor.Block)
+	assert.False(t, cursor.UpdatedAt.IsZero())
+
+	cursors, err := repo.ListCursors(ctx)
+	require.NoError(t, err)
+	require.Len(t, cursors, 2)
+	assert.Equal(t, shared.NetworkEthereum, cursors[0].Network)
+	assert.Equal(t, shared.NetworkTron, cursors[1].Network)
+}
@@ -0,0 +1,144 @@
+package memory
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/deadletter"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DeadLetterRepository implements the deadletter.Repository interface in memory.
+type DeadLetterRepository struct {
+	mu          sync.RWMutex
+	deadLetters map[string]*deadletter.DeadLetter
+}
+
+// NewDeadLetterRepository creates a new in-memory dead letter repository.
+func NewDeadLetterRepository() *DeadLetterRepository {
+	return &DeadLetterRepository{deadLetters: make(map[string]*deadletter.DeadLetter)}
+}
+
+// Save stores a new dead letter.
+func (r *DeadLetterRepository) Save(ctx context.Context, d *deadletter.DeadLetter) error {
+	clone, err := cloneDeadLetter(d)
+	if err != nil {
+		return fmt.Errorf("failed to save dead letter: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.deadLetters[d.ID()]; ok {
+		return fmt.Errorf("failed to save dead letter: dead letter %s already exists", d.ID())
+	}
+	r.deadLetters[d.ID()] = clone
+	return nil
+}
+
+// FindByID finds a dead letter by ID.
+func (r *DeadLetterRepository) FindByID(ctx context.Context, id string) (*deadletter.DeadLetter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	d, ok := r.deadLetters[id]
+	if !ok {
+		return nil, deadletter.ErrDeadLetterNotFound
+	}
+	return cloneDeadLetter(d)
+}
+
+// List retrieves dead letters matching the filter, newest first.
+func (r *DeadLetterRepository) List(
+	ctx context.Context,
+	req *deadletter.ListDeadLettersRequest,
+) (*deadletter.ListDeadLettersResponse, error) {
+	r.mu.RLock()
+	var matched []*deadletter.DeadLetter
+	for _, d := range r.deadLetters {
+		if matchesDeadLetter(d, "", req.Handler, req.EventType, req.Status) {
+			matched = append(matched, d)
+		}
+	}
+	r.mu.RUnlock()
+
+	matched, next := page(matched, func(d *deadletter.DeadLetter) (time.Time, string) {
+		return d.CreatedAt(), d.ID()
+	}, req.Cursor, 0, req.Limit)
+
+	deadLetters := make([]*deadletter.DeadLetter, len(matched))
+	for i, d := range matched {
+		clone, err := cloneDeadLetter(d)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy dead letter %s: %w", d.ID(), err)
+		}
+		deadLetters[i] = clone
+	}
+
+	return &deadletter.ListDeadLettersResponse{
+		DeadLetters: deadLetters,
+		Limit:       req.Limit,
+		NextCursor:  next,
+	}, nil
+}
+
+// Update updates an existing dead letter.
+func (r *DeadLetterRepository) Update(ctx context.Context, d *deadletter.DeadLetter) error {
+	clone, err := cloneDeadLetter(d)
+	if err != nil {
+		return fmt.Errorf("failed to update dead letter: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deadLetters[d.ID()] = clone
+	return nil
+}
+
+// Purge deletes the dead letters matching the filter and returns how many were deleted.
+func (r *DeadLetterRepository) Purge(ctx context.Context, req *deadletter.PurgeDeadLettersRequest) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := 0
+	for id, d := range r.deadLetters {
+		if matchesDeadLetter(d, req.ID, req.Handler, req.EventType, req.Status) {
+			delete(r.deadLetters, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// matchesDeadLetter reports whether a dead letter matches the filter. Empty fields match all.
+func matchesDeadLetter(d *deadletter.DeadLetter, id, handler, eventType string, status deadletter.Status) bool {
+	return (id == "" || d.ID() == id) &&
+		(handler == "" || d.Handler() == handler) &&
+		(eventType == "" || d.Event().EventType == eventType) &&
+		(status == "" || d.Status() == status)
+}
+
+// cloneDeadLetter copies a dead letter, encoding its event as it is stored in SQL.
+func cloneDeadLetter(d *deadletter.DeadLetter) (*deadletter.DeadLetter, error) {
+	encoded, err := d.Event().ToJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal dead letter event: %w", err)
+	}
+	event, err := shared.FromJSON(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dead letter event: %w", err)
+	}
+
+	return deadletter.RestoreDeadLetter(
+		d.ID(),
+		d.Handler(),
+		event,
+		d.LastError(),
+		d.Attempts(),
+		d.Status(),
+		d.CreatedAt(),
+		d.LastFailedAt(),
+		cloneTime(d.ReplayedAt()),
+	)
+}
+
+var _ deadletter.Repository = (*DeadLetterRepository)(nil)
@@ -0,0 +1,53 @@
+package memory_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/deadletter"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/memory"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterRepository(t *testing.T) {
+	repo := memory.NewDeadLetterRepository()
+	ctx := context.Background()
+
+	save := func(id, handler string) *deadletter.DeadLetter {
+		event := shared.CreateDomainEvent(shared.EventTypeInvoicePaid, "invoice-1", "invoice",
+			map[string]interface{}{"status": "paid"}, nil)
+		d, err := deadletter.NewDeadLetter(id, handler, event, errors.New("connection refused"))
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, d))
+		return d
+	}
+	settlement := save("dead-letter-1", "*settlement.PaidInvoiceHandler")
+	save("dead-letter-2", "*treasury.PaidInvoiceHandler")
+	require.Error(t, repo.Save(ctx, settlement))
+
+	found, err := repo.FindByID(ctx, settlement.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "invoice-1", found.Event().AggregateID)
+	assert.Equal(t, map[string]interface{}{"status": "paid"}, found.Event().EventData)
+	assert.Equal(t, "connection refused", found.LastError())
+
+	resp, err := repo.List(ctx, &deadletter.ListDeadLettersRequest{
+		Handler: "*settlement.PaidInvoiceHandler", Status: deadletter.StatusPending, Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.DeadLetters, 1)
+	assert.Equal(t, settlement.ID(), resp.DeadLetters[0].ID())
+
+	purged, err := repo.Purge(ctx, &deadletter.PurgeDeadLettersRequest{ID: settlement.ID()})
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	_, err = repo.FindByID(ctx, settlement.ID())
+	require.ErrorIs(t, err, deadletter.ErrDeadLetterNotFound)
+
+	purged, err = repo.Purge(ctx, &deadletter.PurgeDeadLettersRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+}
@@ -0,0 +1,439 @@
+package memory
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// InvoiceRepository implements the invoice.Repository interface in memory.
+// Invoices are stored in their database form, so they round-trip through the same mapper as in SQL.
+// Lookups made with a merchant-scoped context only see that merchant's invoices.
+type InvoiceRepository struct {
+	mu          sync.RWMutex
+	mapper      *database.InvoiceMapper
+	payments    payment.Repository
+	numberReset string
+	invoices    map[string]database.InvoiceModel
+	// metadata indexes the filterable metadata values of each invoice by invoice ID and key.
+	metadata map[string]map[string]string
+	// sequences holds the last accounting number issued to each merchant in each period.
+	sequences map[numberSequence]int64
+}
+
+// numberSequence identifies the accounting number sequence of a merchant in a period.
+type numberSequence struct {
+	merchantID string
+	period     int
+}
+
+// NewInvoiceRepository creates a new in-memory invoice repository numbering each merchant's invoices in one
+// sequence. Status summaries read the confirmations of the invoices' payments from the payment repository;
+// without one they report no confirmations.
+func NewInvoiceRepository(payments payment.Repository) *InvoiceRepository {
+	return NewInvoiceRepositoryWithNumberReset(payments, invoice.NumberResetNever)
+}
+
+// NewInvoiceRepositoryWithNumberReset creates a new in-memory invoice repository restarting the accounting
+// numbers of each merchant's invoices as the number reset says.
+func NewInvoiceRepositoryWithNumberReset(payments payment.Repository, numberReset string) *InvoiceRepository {
+	return &InvoiceRepository{
+		mapper:      database.NewInvoiceMapper(),
+		payments:    payments,
+		numberReset: numberReset,
+		invoices:    make(map[string]database.InvoiceModel),
+		metadata:    make(map[string]map[string]string),
+		sequences:   make(map[numberSequence]int64),
+	}
+}
+
+// Save stores an invoice, issuing its accounting number if it needs one.
+func (r *InvoiceRepository) Save(ctx context.Context, inv *invoice.Invoice) error {
+	return r.store(ctx, inv, false)
+}
+
+// Update stores an existing invoice, issuing its number if it is a draft being finalized. It fails with
+// invoice.ErrConcurrentUpdate if the invoice has been updated since it was loaded.
+func (r *InvoiceRepository) Update(ctx context.Context, inv *invoice.Invoice) error {
+	return r.store(ctx, inv, true)
+}
+
+// store writes the database form of an invoice and its metadata index. An update only overwrites the version
+// of the invoice it was loaded from.
+func (r *InvoiceRepository) store(ctx context.Context, inv *invoice.Invoice, update bool) error {
+	if inv == nil {
+		return shared.ErrInvalidInput
+	}
+	if !visible(ctx, inv.MerchantID()) {
+		return shared.ErrNotFound
+	}
+
+	model := r.mapper.ToModel(inv)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if update {
+		if stored, ok := r.invoices[model.ID]; !ok || stored.Version != inv.Version() {
+			return invoice.ErrConcurrentUpdate
+		}
+		model.Version++
+	}
+	if inv.NeedsNumber() {
+		period := invoice.NumberPeriod(r.numberReset, shared.Now())
+		sequence := numberSequence{merchantID: inv.MerchantID(), period: period}
+		r.sequences[sequence]++
+		number := invoice.FormatNumber(period, r.sequences[sequence])
+		model.Number = &number
+		inv.SetNumber(number)
+	}
+	r.invoices[model.ID] = *model
+	r.metadata[model.ID] = database.InvoiceMetadataIndex(inv.Metadata())
+	inv.SetVersion(model.Version)
+	return nil
+}
+
+// FindByID retrieves an invoice by its ID.
+func (r *InvoiceRepository) FindByID(ctx context.Context, id string) (*invoice.Invoice, error) {
+	if id == "" {
+		return nil, shared.ErrInvalidInput
+	}
+	return r.findOne(ctx, func(model *database.InvoiceModel) bool { return model.ID == id })
+}
+
+// FindByPaymentAddress retrieves an invoice by its payment address.
+func (r *InvoiceRepository) FindByPaymentAddress(
+	ctx context.Context,
+	address *shared.PaymentAddress,
+) (*invoice.Invoice, error) {
+	if address == nil {
+		return nil, shared.ErrInvalidInput
+	}
+	return r.findOne(ctx, func(model *database.InvoiceModel) bool {
+		return equal(model.PaymentAddress, address.String())
+	})
+}
+
+// FindByPaymentReference retrieves an invoice by the code of its payment reference.
+func (r *InvoiceRepository) FindByPaymentReference(ctx context.Context, code string) (*invoice.Invoice, error) {
+	if code == "" {
+		return nil, shared.ErrInvalidInput
+	}
+	return r.findOne(ctx, func(model *database.InvoiceModel) bool { return equal(model.PaymentReference, code) })
+}
+
+// FindPayableByPaymentAddress retrieves the invoices paid to an address that still accept payments.
+func (r *InvoiceRepository) FindPayableByPaymentAddress(
+	ctx context.Context,
+	address *shared.PaymentAddress,
+) ([]*invoice.Invoice, error) {
+	if address == nil {
+		return nil, shared.ErrInvalidInput
+	}
+	payable := statuses(invoice.StatusCreated, invoice.StatusPending, invoice.StatusPartial)
+	return r.find(ctx, func(model *database.InvoiceModel) bool {
+		return equal(model.PaymentAddress, address.String()) && payable[model.Status]
+	})
+}
+
+// FindPaymentAddresses retrieves the payment addresses of the invoices on a network, whatever their status.
+func (r *InvoiceRepository) FindPaymentAddresses(
+	ctx context.Context,
+	network shared.BlockchainNetwork,
+) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	addresses := []string{}
+	seen := make(map[string]bool)
+	for _, model := range r.invoices {
+		if !visible(ctx, model.MerchantID) || model.PaymentAddress == nil || seen[*model.PaymentAddress] {
+			continue
+		}
+		if model.Network == nil || shared.BlockchainNetwork(*model.Network) != network {
+			continue
+		}
+		seen[*model.PaymentAddress] = true
+		addresses = append(addresses, *model.PaymentAddress)
+	}
+	return addresses, nil
+}
+
+// FindByStatus retrieves all invoices with the given status.
+func (r *InvoiceRepository) FindByStatus(
+	ctx context.Context,
+	status invoice.InvoiceStatus,
+) ([]*invoice.Invoice, error) {
+	return r.find(ctx, func(model *database.InvoiceModel) bool { return model.Status == status.String() })
+}
+
+// FindActive retrieves all active (non-terminal) invoices.
+func (r *InvoiceRepository) FindActive(ctx context.Context) ([]*invoice.Invoice, error) {
+	active := activeStatuses()
+	return r.find(ctx, func(model *database.InvoiceModel) bool { return active[model.Status] })
+}
+
+// List retrieves a page of invoices matching the request, newest first.
+func (r *InvoiceRepository) List(
+	ctx context.Context,
+	req *invoice.ListInvoicesRequest,
+) (*invoice.ListInvoicesResponse, error) {
+	listed := activeStatuses()
+	listed[invoice.StatusDraft.String()] = true
+	var search string
+	if req.Search != nil {
+		search = strings.ToLower(*req.Search)
+	}
+
+	models := r.match(ctx, func(model *database.InvoiceModel) bool {
+		switch {
+		case model.MerchantID != req.MerchantID:
+			return false
+		case req.Status != nil && model.Status != req.Status.String(), req.Status == nil && !listed[model.Status]:
+			return false
+		case req.CustomerID != nil && !equal(model.CustomerID, *req.CustomerID):
+			return false
+		case req.CreatedAfter != nil && model.CreatedAt.Before(*req.CreatedAfter),
+			req.CreatedBefore != nil && model.CreatedAt.After(*req.CreatedBefore):
+			return false
+		case search != "" && !strings.Contains(strings.ToLower(model.Title), search) &&
+			!strings.Contains(strings.ToLower(model.Description), search):
+			return false
+		}
+		return matchesMetadata(r.metadata[model.ID], req.Metadata)
+	})
+
+	total := len(models)
+	models, next := page(models, func(model database.InvoiceModel) (time.Time, string) {
+		return model.CreatedAt, model.ID
+	}, req.Cursor, req.Offset, req.Limit)
+
+	invoices, err := r.toDomain(models)
+	if err != nil {
+		return nil, err
+	}
+
+	return &invoice.ListInvoicesResponse{
+		Invoices:   invoices,
+		Total:      total,
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+		NextCursor: next,
+	}, nil
+}
+
+// matchesMetadata reports whether an invoice's metadata index has each filtered value at its key.
+func matchesMetadata(index, filter map[string]string) bool {
+	for key, value := range filter {
+		if indexed, ok := index[key]; !ok || indexed != value {
+			return false
+		}
+	}
+	return true
+}
+
+// FindExpired retrieves all active invoices that have passed their expiration time.
+func (r *InvoiceRepository) FindExpired(ctx context.Context) ([]*invoice.Invoice, error) {
+	active := activeStatuses()
+	now := shared.Now().UTC()
+	return r.find(ctx, func(model *database.InvoiceModel) bool {
+		return active[model.Status] && model.ExpiresAt != nil && model.ExpiresAt.Before(now)
+	})
+}
+
+// FindRequotable retrieves the unpaid, unexpired invoices whose exchange rate has expired.
+func (r *InvoiceRepository) FindRequotable(ctx context.Context) ([]*invoice.Invoice, error) {
+	unpaid := statuses(invoice.StatusCreated, invoice.StatusPending)
+	now := shared.Now().UTC()
+	return r.find(ctx, func(model *database.InvoiceModel) bool {
+		return unpaid[model.Status] && model.AmountPaid == nil &&
+			model.RateExpiresAt != nil && model.RateExpiresAt.Before(now) &&
+			(model.ExpiresAt == nil || model.ExpiresAt.After(now))
+	})
+}
+
+// Delete removes an invoice.
+func (r *InvoiceRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return shared.ErrInvalidInput
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	model, ok := r.invoices[id]
+	if !ok || !visible(ctx, model.MerchantID) {
+		return shared.ErrNotFound
+	}
+	delete(r.invoices, id)
+	delete(r.metadata, id)
+	return nil
+}
+
+// Exists checks if an invoice with the given ID exists.
+func (r *InvoiceRepository) Exists(ctx context.Context, id string) (bool, error) {
+	if id == "" {
+		return false, shared.ErrInvalidInput
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	model, ok := r.invoices[id]
+	return ok && visible(ctx, model.MerchantID), nil
+}
+
+// FindStatusSummaries retrieves the payment state of the invoices with the given IDs, with the highest
+// confirmation count among their payments.
+func (r *InvoiceRepository) FindStatusSummaries(ctx context.Context, ids []string) ([]*invoice.StatusSummary, error) {
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	models := r.match(ctx, func(model *database.InvoiceModel) bool { return wanted[model.ID] })
+
+	summaries := make([]*invoice.StatusSummary, len(models))
+	for i := range models {
+		summary, err := r.toStatusSummary(ctx, &models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read status of invoice %s: %w", models[i].ID, err)
+		}
+		summaries[i] = summary
+	}
+	return summaries, nil
+}
+
+// toStatusSummary summarizes the payment state of a stored invoice.
+func (r *InvoiceRepository) toStatusSummary(
+	ctx context.Context,
+	model *database.InvoiceModel,
+) (*invoice.StatusSummary, error) {
+	summary := &invoice.StatusSummary{
+		InvoiceID: model.ID,
+		Status:    invoice.InvoiceStatus(model.Status),
+		ExpiresAt: model.ExpiresAt,
+	}
+
+	amountDue, err := decimal.NewFromString(model.CryptoAmount)
+	if err != nil {
+		return nil, err
+	}
+	summary.AmountDue = amountDue
+	if model.AmountPaid != nil {
+		amountPaid, paidErr := decimal.NewFromString(*model.AmountPaid)
+		if paidErr != nil {
+			return nil, paidErr
+		}
+		summary.AmountPaid = amountPaid
+	}
+
+	// Any amount at or above the minimum settles a donation, so that is what remains to be paid
+	if invoice.InvoiceType(model.Type) == invoice.InvoiceTypeDonation && model.MinimumAmount != nil {
+		minimum, minErr := decimal.NewFromString(*model.MinimumAmount)
+		if minErr != nil {
+			return nil, minErr
+		}
+		rate, rateErr := r.mapper.DeserializeExchangeRate(model.ExchangeRate)
+		if rateErr != nil {
+			return nil, rateErr
+		}
+		if rate != nil {
+			minimumCrypto, moneyErr := shared.NewMoneyWithCrypto(
+				minimum.Mul(rate.Rate()).String(), shared.CryptoCurrency(model.CryptoCurrency))
+			if moneyErr != nil {
+				return nil, moneyErr
+			}
+			summary.AmountDue = minimumCrypto.Amount()
+		}
+	}
+
+	if r.payments == nil {
+		return summary, nil
+	}
+	payments, err := r.payments.FindByInvoiceID(ctx, shared.InvoiceID(model.ID))
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range payments {
+		summary.Confirmations = max(summary.Confirmations, p.Confirmations().Int())
+	}
+	return summary, nil
+}
+
+// findOne retrieves the invoice with the lowest ID among those visible with the context that match.
+func (r *InvoiceRepository) findOne(
+	ctx context.Context,
+	match func(*database.InvoiceModel) bool,
+) (*invoice.Invoice, error) {
+	models := r.match(ctx, match)
+	if len(models) == 0 {
+		return nil, shared.ErrNotFound
+	}
+	found := slices.MinFunc(models, func(a, b database.InvoiceModel) int { return strings.Compare(a.ID, b.ID) })
+	return r.mapper.ToDomain(&found)
+}
+
+// find retrieves the invoices visible with the context that match, oldest first.
+func (r *InvoiceRepository) find(
+	ctx context.Context,
+	match func(*database.InvoiceModel) bool,
+) ([]*invoice.Invoice, error) {
+	models := oldestFirst(r.match(ctx, match), func(model database.InvoiceModel) time.Time {
+		return model.CreatedAt
+	}, 0)
+	return r.toDomain(models)
+}
+
+// match returns copies of the stored invoices visible with the context that match.
+func (r *InvoiceRepository) match(ctx context.Context, match func(*database.InvoiceModel) bool) []database.InvoiceModel {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var models []database.InvoiceModel
+	for _, model := range r.invoices {
+		if visible(ctx, model.MerchantID) && match(&model) {
+			models = append(models, model)
+		}
+	}
+	return models
+}
+
+// toDomain converts stored invoices to domain invoices.
+func (r *InvoiceRepository) toDomain(models []database.InvoiceModel) ([]*invoice.Invoice, error) {
+	invoices := make([]*invoice.Invoice, len(models))
+	for i := range models {
+		inv, err := r.mapper.ToDomain(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert invoice %s: %w", models[i].ID, err)
+		}
+		invoices[i] = inv
+	}
+	return invoices, nil
+}
+
+// activeStatuses returns the set of non-terminal invoice statuses.
+func activeStatuses() map[string]bool {
+	return statuses(invoice.StatusCreated, invoice.StatusPending, invoice.StatusPartial, invoice.StatusConfirming)
+}
+
+// statuses returns a set of invoice statuses.
+func statuses(list ...invoice.InvoiceStatus) map[string]bool {
+	set := make(map[string]bool, len(list))
+	for _, status := range list {
+		set[status.String()] = true
+	}
+	return set
+}
+
+// equal reports whether an optional column holds the value.
+func equal(column *string, value string) bool {
+	return column != nil && *column == value
+}
+
+var _ invoice.Repository = (*InvoiceRepository)(nil)
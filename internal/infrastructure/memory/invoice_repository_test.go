@@ -0,0 +1,241 @@
+package memory_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/memory"
+	"crypto-checkout/test/testutil"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newInvoice creates a USDT invoice of 22.00 USD.
+func newInvoice(t *testing.T, id, merchantID string) *invoice.Invoice {
+	t.Helper()
+	inv, err := testutil.NewInvoiceBuilder().WithID(id).WithMerchantID(merchantID).WithTitle("Invoice " + id).
+		WithTax("2.00").Build()
+	require.NoError(t, err)
+	return inv
+}
+
+func TestInvoiceRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Stores_Copies", func(t *testing.T) {
+		repo := memory.NewInvoiceRepository(nil)
+		inv := newInvoice(t, "invoice-1", "merchant-1")
+		require.NoError(t, repo.Save(ctx, inv))
+		assert.Equal(t, "000001", inv.Number())
+
+		inv.SetMetadata(map[string]interface{}{"order_id": "A-1"})
+		found, err := repo.FindByID(ctx, "invoice-1")
+		require.NoError(t, err)
+		assert.Empty(t, found.Metadata())
+		assert.Equal(t, "000001", found.Number())
+		assert.Equal(t, invoice.StatusCreated, found.Status())
+
+		require.NoError(t, repo.Update(ctx, inv))
+		found, err = repo.FindByID(ctx, "invoice-1")
+		require.NoError(t, err)
+		assert.Equal(t, "A-1", found.Metadata()["order_id"])
+		assert.Equal(t, "000001", found.Number())
+
+		_, err = repo.FindByID(ctx, "")
+		require.ErrorIs(t, err, shared.ErrInvalidInput)
+		_, err = repo.FindByID(ctx, "invoice-2")
+		require.ErrorIs(t, err, shared.ErrNotFound)
+	})
+
+	t.Run("Rejects_Stale_Copy", func(t *testing.T) {
+		repo := memory.NewInvoiceRepository(nil)
+		require.NoError(t, repo.Save(ctx, newInvoice(t, "invoice-1", "merchant-1")))
+		first, err := repo.FindByID(ctx, "invoice-1")
+		require.NoError(t, err)
+		second, err := repo.FindByID(ctx, "invoice-1")
+		require.NoError(t, err)
+
+		first.SetCustomerID("customer-1")
+		require.NoError(t, repo.Update(ctx, first))
+		second.SetCustomerID("customer-2")
+		require.ErrorIs(t, repo.Update(ctx, second), invoice.ErrConcurrentUpdate)
+		require.ErrorIs(t, repo.Update(ctx, newInvoice(t, "invoice-2", "merchant-1")), invoice.ErrConcurrentUpdate)
+
+		found, err := repo.FindByID(ctx, "invoice-1")
+		require.NoError(t, err)
+		assert.Equal(t, "customer-1", *found.CustomerID())
+		assert.Equal(t, 1, found.Version())
+	})
+
+	t.Run("Merchant_Scoped", func(t *testing.T) {
+		repo := memory.NewInvoiceRepository(nil)
+		require.NoError(t, repo.Save(ctx, newInvoice(t, "invoice-1", "merchant-1")))
+		scoped := shared.WithMerchantID(ctx, "merchant-2")
+
+		_, err := repo.FindByID(scoped, "invoice-1")
+		require.ErrorIs(t, err, shared.ErrNotFound)
+		exists, err := repo.Exists(scoped, "invoice-1")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		require.ErrorIs(t, repo.Update(scoped, newInvoice(t, "invoice-1", "merchant-1")), shared.ErrNotFound)
+		require.ErrorIs(t, repo.Delete(scoped, "invoice-1"), shared.ErrNotFound)
+
+		active, err := repo.FindActive(scoped)
+		require.NoError(t, err)
+		assert.Empty(t, active)
+		active, err = repo.FindActive(ctx)
+		require.NoError(t, err)
+		assert.Len(t, active, 1)
+	})
+
+	t.Run("Lookups", func(t *testing.T) {
+		repo := memory.NewInvoiceRepository(nil)
+		require.NoError(t, repo.Save(ctx, newInvoice(t, "invoice-1", "merchant-1")))
+		address, _ := shared.NewPaymentAddress(testutil.TestAddress, shared.NetworkTron)
+
+		found, err := repo.FindByPaymentAddress(ctx, address)
+		require.NoError(t, err)
+		assert.Equal(t, "invoice-1", found.ID())
+		payable, err := repo.FindPayableByPaymentAddress(ctx, address)
+		require.NoError(t, err)
+		assert.Len(t, payable, 1)
+
+		addresses, err := repo.FindPaymentAddresses(ctx, shared.NetworkTron)
+		require.NoError(t, err)
+		assert.Equal(t, []string{testutil.TestAddress}, addresses)
+		addresses, err = repo.FindPaymentAddresses(ctx, shared.NetworkEthereum)
+		require.NoError(t, err)
+		assert.Empty(t, addresses)
+
+		_, err = repo.FindByPaymentAddress(ctx, nil)
+		require.ErrorIs(t, err, shared.ErrInvalidInput)
+		_, err = repo.FindByPaymentReference(ctx, "UNKNOWN")
+		require.ErrorIs(t, err, shared.ErrNotFound)
+
+		require.NoError(t, repo.Delete(ctx, "invoice-1"))
+		require.ErrorIs(t, repo.Delete(ctx, "invoice-1"), shared.ErrNotFound)
+		exists, err := repo.Exists(ctx, "invoice-1")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		repo := memory.NewInvoiceRepository(nil)
+		for i := range 5 {
+			inv := newInvoice(t, fmt.Sprintf("invoice-%d", i), "merchant-1")
+			inv.SetMetadata(map[string]interface{}{"channel": []string{"web", "pos"}[i%2], "priority": float64(i)})
+			require.NoError(t, repo.Save(ctx, inv))
+		}
+		require.NoError(t, repo.Save(ctx, newInvoice(t, "other-invoice", "merchant-2")))
+
+		resp, err := repo.List(ctx, &invoice.ListInvoicesRequest{MerchantID: "merchant-1", Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, 5, resp.Total)
+		require.Len(t, resp.Invoices, 2)
+		require.NotNil(t, resp.NextCursor)
+
+		seen := []string{resp.Invoices[0].ID(), resp.Invoices[1].ID()}
+		for resp.NextCursor != nil {
+			resp, err = repo.List(ctx, &invoice.ListInvoicesRequest{
+				MerchantID: "merchant-1", Limit: 2, Cursor: resp.NextCursor,
+			})
+			require.NoError(t, err)
+			for _, inv := range resp.Invoices {
+				seen = append(seen, inv.ID())
+			}
+		}
+		assert.ElementsMatch(t, []string{"invoice-0", "invoice-1", "invoice-2", "invoice-3", "invoice-4"}, seen)
+
+		resp, err = repo.List(ctx, &invoice.ListInvoicesRequest{
+			MerchantID: "merchant-1", Limit: 10, Metadata: map[string]string{"channel": "web", "priority": "2"},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Invoices, 1)
+		assert.Equal(t, "invoice-2", resp.Invoices[0].ID())
+
+		search := "INVOICE-3"
+		resp, err = repo.List(ctx, &invoice.ListInvoicesRequest{MerchantID: "merchant-1", Limit: 10, Search: &search})
+		require.NoError(t, err)
+		require.Len(t, resp.Invoices, 1)
+		assert.Equal(t, "invoice-3", resp.Invoices[0].ID())
+
+		paid := invoice.StatusPaid
+		resp, err = repo.List(ctx, &invoice.ListInvoicesRequest{MerchantID: "merchant-1", Limit: 10, Status: &paid})
+		require.NoError(t, err)
+		assert.Zero(t, resp.Total)
+	})
+
+	t.Run("FindStatusSummaries", func(t *testing.T) {
+		payments := memory.NewPaymentRepository()
+		repo := memory.NewInvoiceRepository(payments)
+
+		paid := newInvoice(t, "invoice-paid", "merchant-1")
+		amount, err := shared.NewMoneyWithCrypto("10", shared.CryptoCurrencyUSDT)
+		require.NoError(t, err)
+		require.NoError(t, paid.RecordPayment(amount))
+		require.NoError(t, repo.Save(ctx, paid))
+		require.NoError(t, repo.Save(ctx, newInvoice(t, "invoice-unpaid", "merchant-1")))
+
+		for i, confirmations := range []int{3, 12} {
+			p := newPayment(t, fmt.Sprintf("payment-%d", i), "invoice-paid", fmt.Sprintf("0x%064x", i+1))
+			require.NoError(t, p.UpdateConfirmations(ctx, confirmations))
+			require.NoError(t, payments.Save(ctx, p))
+		}
+
+		summaries, err := repo.FindStatusSummaries(ctx, []string{"invoice-paid", "invoice-unpaid", "non-existent-id"})
+		require.NoError(t, err)
+		require.Len(t, summaries, 2)
+		byID := make(map[string]*invoice.StatusSummary)
+		for _, summary := range summaries {
+			byID[summary.InvoiceID] = summary
+		}
+
+		assert.Equal(t, "10", byID["invoice-paid"].AmountPaid.String())
+		assert.Equal(t, "12", byID["invoice-paid"].RemainingAmount().String())
+		assert.Equal(t, 12, byID["invoice-paid"].Confirmations)
+		assert.Equal(t, "45.45", byID["invoice-paid"].ProgressPercentage().String())
+		require.NotNil(t, byID["invoice-paid"].ExpiresAt)
+		assert.Equal(t, paid.Expiration().ExpiresAt(), *byID["invoice-paid"].ExpiresAt)
+		assert.Equal(t, "22", byID["invoice-unpaid"].RemainingAmount().String())
+		assert.Equal(t, 0, byID["invoice-unpaid"].Confirmations)
+
+		summaries, err = repo.FindStatusSummaries(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, summaries)
+	})
+
+	t.Run("Concurrent_Saves_Get_Distinct_Numbers", func(t *testing.T) {
+		repo := memory.NewInvoiceRepository(nil)
+		var wg sync.WaitGroup
+		invoices := make([]*invoice.Invoice, 20)
+		for i := range invoices {
+			invoices[i] = newInvoice(t, fmt.Sprintf("invoice-%d", i), "merchant-1")
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, repo.Save(ctx, invoices[i]))
+			}()
+		}
+		wg.Wait()
+
+		numbers := make(map[string]bool)
+		for _, inv := range invoices {
+			numbers[inv.Number()] = true
+		}
+		assert.Len(t, numbers, len(invoices))
+		assert.True(t, numbers["000020"])
+	})
+}
+
+// newPayment creates a detected payment of an invoice.
+func newPayment(t *testing.T, id, invoiceID, txHash string) *payment.Payment {
+	t.Helper()
+	p, err := testutil.NewPaymentBuilder().WithID(id).ForInvoice(invoiceID).WithTransactionHash(txHash).Build()
+	require.NoError(t, err)
+	return p
+}
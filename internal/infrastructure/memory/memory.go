@@ -0,0 +1,79 @@
+// Package memory implements the domain repositories in memory, for tests and for embedding the checkout engine
+// without a database.
+//
+// Each repository mirrors its SQL counterpart in the database package: the same lookups, orderings, unique
+// constraints, merchant scoping and keyset pages, and the same errors. Records are stored as copies, so changing
+// an aggregate after saving it or after loading it does not change the stored record until it is saved again.
+// The repositories are safe for concurrent use.
+//
+// The package covers invoices, payments, settlements, settlement sagas, dead letters, tax rules, the webhook
+// delivery log and block cursors.
+package memory
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"slices"
+	"strings"
+	"time"
+)
+
+// visible reports whether a record of the merchant can be seen with the context. Contexts that are not scoped
+// to a merchant, such as public checkout requests and background jobs, see every merchant's records.
+func visible(ctx context.Context, merchantID string) bool {
+	scoped, ok := shared.MerchantIDFromContext(ctx)
+	return !ok || scoped == merchantID
+}
+
+// page orders records newest first by creation time and then by ID, and returns the records after the cursor,
+// or after offset records without one, up to limit, with the cursor of the following page or nil on the last
+// page. A limit of zero or less returns every remaining record.
+func page[T any](
+	records []T,
+	key func(T) (time.Time, string),
+	cursor *shared.Cursor,
+	offset, limit int,
+) ([]T, *shared.Cursor) {
+	slices.SortFunc(records, func(a, b T) int {
+		aTime, aID := key(a)
+		bTime, bID := key(b)
+		if c := bTime.Compare(aTime); c != 0 {
+			return c
+		}
+		return strings.Compare(bID, aID)
+	})
+
+	if cursor != nil {
+		records = slices.DeleteFunc(records, func(record T) bool {
+			createdAt, id := key(record)
+			return !createdAt.Before(cursor.CreatedAt) && (!createdAt.Equal(cursor.CreatedAt) || id >= cursor.ID)
+		})
+	} else if offset > 0 {
+		records = records[min(offset, len(records)):]
+	}
+
+	if limit <= 0 || len(records) <= limit {
+		return records, nil
+	}
+	records = records[:limit]
+	return records, shared.NewCursor(key(records[limit-1]))
+}
+
+// oldestFirst orders records by creation time and returns up to limit of them. A limit of zero or less returns
+// them all.
+func oldestFirst[T any](records []T, createdAt func(T) time.Time, limit int) []T {
+	slices.SortStableFunc(records, func(a, b T) int { return createdAt(a).Compare(createdAt(b)) })
+	if limit > 0 && len(records) > limit {
+		records = records[:limit]
+	}
+	return records
+}
+
+// cloneTime returns a copy of an optional time.
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	return &clone
+}
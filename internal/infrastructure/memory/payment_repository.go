@@ -0,0 +1,473 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PaymentRepository implements the payment.Repository interface in memory.
+// Payments are event sourced as in SQL: saving appends their pending events to the payment's stream, every
+// payment.SnapshotInterval events the state is also snapshotted, and loading replays the events after the
+// latest snapshot. The indexed columns of the payments table are kept alongside to answer lookups.
+type PaymentRepository struct {
+	mu        sync.RWMutex
+	payments  map[string]paymentRow
+	streams   map[string][]*payment.PaymentEvent
+	snapshots map[string][]*payment.PaymentSnapshot
+}
+
+// paymentRow holds the columns a payment is looked up by. Rows of deleted payments are removed, while their
+// streams are kept.
+type paymentRow struct {
+	id         string
+	invoiceID  string
+	network    string
+	toAddress  string
+	txHash     string
+	status     payment.PaymentStatus
+	detectedAt time.Time
+	// state restores a payment saved without any events, which has no stream to replay.
+	state *payment.PaymentSnapshot
+}
+
+// NewPaymentRepository creates a new in-memory payment repository.
+func NewPaymentRepository() *PaymentRepository {
+	return &PaymentRepository{
+		payments:  make(map[string]paymentRow),
+		streams:   make(map[string][]*payment.PaymentEvent),
+		snapshots: make(map[string][]*payment.PaymentSnapshot),
+	}
+}
+
+// Save persists a payment.
+func (r *PaymentRepository) Save(ctx context.Context, p *payment.Payment) error {
+	if p == nil {
+		return payment.ErrInvalidPayment
+	}
+	return r.persist(p)
+}
+
+// Update appends the payment's pending events to its stream and refreshes its projection.
+func (r *PaymentRepository) Update(ctx context.Context, p *payment.Payment) error {
+	if p == nil {
+		return payment.ErrInvalidPayment
+	}
+	return r.persist(p)
+}
+
+// persist appends the payment's pending events, snapshots it when the new events cross a multiple of
+// payment.SnapshotInterval and refreshes its row, all under one lock. The stream must still end where the
+// payment was loaded, so concurrent writers cannot interleave events.
+func (r *PaymentRepository) persist(p *payment.Payment) error {
+	events := make([]*payment.PaymentEvent, len(p.PendingEvents()))
+	for i, event := range p.PendingEvents() {
+		clone, err := cloneEvent(event)
+		if err != nil {
+			return err
+		}
+		events[i] = clone
+	}
+
+	var snapshot *payment.PaymentSnapshot
+	if len(events) > 0 && (events[0].Version-1)/payment.SnapshotInterval != p.Version()/payment.SnapshotInterval {
+		clone, err := cloneSnapshot(payment.TakeSnapshot(p))
+		if err != nil {
+			return err
+		}
+		snapshot = clone
+	}
+
+	row, err := toPaymentRow(p)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(events) > 0 {
+		if version := r.streamVersion(row.id); version != events[0].Version-1 {
+			return payment.NewConcurrentModificationError(row.id, version)
+		}
+	}
+	for _, other := range r.payments {
+		if other.id != row.id && other.network == row.network && other.txHash == row.txHash {
+			return fmt.Errorf("%w: %s on %s", payment.ErrDuplicateTransaction, row.txHash, row.network)
+		}
+	}
+
+	r.streams[row.id] = append(r.streams[row.id], events...)
+	if snapshot != nil {
+		r.snapshots[row.id] = append(r.snapshots[row.id], snapshot)
+	}
+	r.payments[row.id] = row
+
+	p.ClearPendingEvents()
+	return nil
+}
+
+// streamVersion returns the version of the last event in a payment's stream, or zero for an empty stream.
+func (r *PaymentRepository) streamVersion(id string) int {
+	stream := r.streams[id]
+	if len(stream) == 0 {
+		return 0
+	}
+	return stream[len(stream)-1].Version
+}
+
+// FindEvents retrieves the event stream of a payment, oldest first.
+func (r *PaymentRepository) FindEvents(ctx context.Context, id string) ([]*payment.PaymentEvent, error) {
+	if id == "" {
+		return nil, payment.ErrInvalidPayment
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	stream, ok := r.streams[id]
+	if !ok {
+		if _, exists := r.payments[id]; !exists {
+			return nil, payment.ErrPaymentNotFound
+		}
+	}
+
+	events := make([]*payment.PaymentEvent, len(stream))
+	for i, event := range stream {
+		clone, err := cloneEvent(event)
+		if err != nil {
+			return nil, err
+		}
+		events[i] = clone
+	}
+	return events, nil
+}
+
+// FindSnapshots retrieves the stored snapshots of a payment, oldest first.
+func (r *PaymentRepository) FindSnapshots(ctx context.Context, id string) ([]*payment.PaymentSnapshot, error) {
+	if id == "" {
+		return nil, payment.ErrInvalidPayment
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	snapshots := make([]*payment.PaymentSnapshot, len(r.snapshots[id]))
+	for i, snapshot := range r.snapshots[id] {
+		clone, err := cloneSnapshot(snapshot)
+		if err != nil {
+			return nil, err
+		}
+		snapshots[i] = clone
+	}
+	return snapshots, nil
+}
+
+// RebuildProjection overwrites the stored state of a payment with one replayed from its stream,
+// replaces the given snapshots, and removes snapshots beyond the payment's version.
+func (r *PaymentRepository) RebuildProjection(
+	ctx context.Context,
+	p *payment.Payment,
+	snapshots []*payment.PaymentSnapshot,
+) error {
+	if p == nil {
+		return payment.ErrInvalidPayment
+	}
+
+	replacements := make(map[int]*payment.PaymentSnapshot, len(snapshots))
+	for _, snapshot := range snapshots {
+		clone, err := cloneSnapshot(snapshot)
+		if err != nil {
+			return err
+		}
+		replacements[clone.Version] = clone
+	}
+	row, err := toPaymentRow(p)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := make([]*payment.PaymentSnapshot, 0, len(r.snapshots[row.id])+len(replacements))
+	for _, snapshot := range r.snapshots[row.id] {
+		if _, replaced := replacements[snapshot.Version]; !replaced && snapshot.Version <= p.Version() {
+			kept = append(kept, snapshot)
+		}
+	}
+	for _, snapshot := range replacements {
+		kept = append(kept, snapshot)
+	}
+	slices.SortFunc(kept, func(a, b *payment.PaymentSnapshot) int { return cmp.Compare(a.Version, b.Version) })
+	r.snapshots[row.id] = kept
+	r.payments[row.id] = row
+	return nil
+}
+
+// FindByID retrieves a payment by its ID.
+func (r *PaymentRepository) FindByID(ctx context.Context, id string) (*payment.Payment, error) {
+	if id == "" {
+		return nil, payment.ErrInvalidPayment
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	row, ok := r.payments[id]
+	if !ok {
+		return nil, payment.ErrPaymentNotFound
+	}
+	return r.load(&row)
+}
+
+// FindByTransactionHash retrieves the payment of a transaction on a network. Payments stored without a network
+// match a transaction on any network.
+func (r *PaymentRepository) FindByTransactionHash(
+	ctx context.Context,
+	network shared.BlockchainNetwork,
+	hash *payment.TransactionHash,
+) (*payment.Payment, error) {
+	if hash == nil {
+		return nil, payment.ErrInvalidPayment
+	}
+
+	payments, err := r.find(func(row *paymentRow) bool {
+		return row.txHash == hash.String() && (row.network == string(network) || row.network == "")
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range payments {
+		if p.ToAddress().Network() == network {
+			return p, nil
+		}
+	}
+	if len(payments) == 0 {
+		return nil, payment.ErrPaymentNotFound
+	}
+	return payments[0], nil
+}
+
+// FindByInvoiceID retrieves all payments for an invoice, oldest first.
+func (r *PaymentRepository) FindByInvoiceID(
+	ctx context.Context,
+	invoiceID shared.InvoiceID,
+) ([]*payment.Payment, error) {
+	return r.find(func(row *paymentRow) bool { return row.invoiceID == string(invoiceID) })
+}
+
+// FindByAddress retrieves all payments for a given address.
+func (r *PaymentRepository) FindByAddress(
+	ctx context.Context,
+	address *payment.PaymentAddress,
+) ([]*payment.Payment, error) {
+	if address == nil {
+		return nil, payment.ErrInvalidPayment
+	}
+	return r.find(func(row *paymentRow) bool { return row.toAddress == address.String() })
+}
+
+// FindByStatus retrieves all payments with the given status.
+func (r *PaymentRepository) FindByStatus(
+	ctx context.Context,
+	status payment.PaymentStatus,
+) ([]*payment.Payment, error) {
+	return r.find(func(row *paymentRow) bool { return row.status == status })
+}
+
+// FindPending retrieves all pending payments (detected or confirming).
+func (r *PaymentRepository) FindPending(ctx context.Context) ([]*payment.Payment, error) {
+	return r.find(func(row *paymentRow) bool {
+		return row.status == payment.StatusDetected || row.status == payment.StatusConfirming
+	})
+}
+
+// FindConfirmed retrieves all confirmed payments.
+func (r *PaymentRepository) FindConfirmed(ctx context.Context) ([]*payment.Payment, error) {
+	return r.FindByStatus(ctx, payment.StatusConfirmed)
+}
+
+// FindFailed retrieves all failed payments.
+func (r *PaymentRepository) FindFailed(ctx context.Context) ([]*payment.Payment, error) {
+	return r.FindByStatus(ctx, payment.StatusFailed)
+}
+
+// FindOrphaned retrieves all orphaned payments.
+func (r *PaymentRepository) FindOrphaned(ctx context.Context) ([]*payment.Payment, error) {
+	return r.FindByStatus(ctx, payment.StatusOrphaned)
+}
+
+// Delete removes a payment. Its event stream is kept for audit.
+func (r *PaymentRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return payment.ErrInvalidPayment
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.payments[id]; !ok {
+		return payment.ErrPaymentNotFound
+	}
+	delete(r.payments, id)
+	return nil
+}
+
+// Exists checks if a payment with the given ID exists.
+func (r *PaymentRepository) Exists(ctx context.Context, id string) (bool, error) {
+	if id == "" {
+		return false, payment.ErrInvalidPayment
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.payments[id]
+	return ok, nil
+}
+
+// CountByStatus returns the count of payments for each status.
+func (r *PaymentRepository) CountByStatus(ctx context.Context) (map[payment.PaymentStatus]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[payment.PaymentStatus]int)
+	for _, row := range r.payments {
+		status, err := payment.ParsePaymentStatus(row.status.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse status %s: %w", row.status, err)
+		}
+		counts[status]++
+	}
+	return counts, nil
+}
+
+// find loads the payments whose rows match, ordered by detection time and then by ID.
+func (r *PaymentRepository) find(match func(*paymentRow) bool) ([]*payment.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var rows []paymentRow
+	for _, row := range r.payments {
+		if match(&row) {
+			rows = append(rows, row)
+		}
+	}
+	slices.SortFunc(rows, func(a, b paymentRow) int {
+		if c := a.detectedAt.Compare(b.detectedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.id, b.id)
+	})
+
+	payments := make([]*payment.Payment, len(rows))
+	for i := range rows {
+		p, err := r.load(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		payments[i] = p
+	}
+	return payments, nil
+}
+
+// load restores a payment from its latest snapshot and the events recorded after it. The caller holds the lock.
+func (r *PaymentRepository) load(row *paymentRow) (*payment.Payment, error) {
+	var snapshot *payment.PaymentSnapshot
+	if snapshots := r.snapshots[row.id]; len(snapshots) > 0 {
+		snapshot = snapshots[len(snapshots)-1]
+	}
+	var tail []*payment.PaymentEvent
+	for _, event := range r.streams[row.id] {
+		if snapshot == nil || event.Version > snapshot.Version {
+			tail = append(tail, event)
+		}
+	}
+	if snapshot == nil && len(tail) == 0 {
+		snapshot = row.state
+	}
+
+	if snapshot != nil {
+		clone, err := cloneSnapshot(snapshot)
+		if err != nil {
+			return nil, err
+		}
+		snapshot = clone
+	}
+	for i, event := range tail {
+		clone, err := cloneEvent(event)
+		if err != nil {
+			return nil, err
+		}
+		tail[i] = clone
+	}
+
+	p, err := payment.RestorePayment(snapshot, tail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load payment %s: %w", row.id, err)
+	}
+	return p, nil
+}
+
+// toPaymentRow captures the columns of a payment.
+func toPaymentRow(p *payment.Payment) (paymentRow, error) {
+	state, err := cloneSnapshot(payment.TakeSnapshot(p))
+	if err != nil {
+		return paymentRow{}, err
+	}
+
+	return paymentRow{
+		id:         string(p.ID()),
+		invoiceID:  string(p.InvoiceID()),
+		network:    string(p.ToAddress().Network()),
+		toAddress:  p.ToAddress().String(),
+		txHash:     p.TransactionHash().String(),
+		status:     p.Status(),
+		detectedAt: p.DetectedAt(),
+		state:      state,
+	}, nil
+}
+
+// cloneEvent copies a payment event, encoding its data as it is stored in SQL.
+func cloneEvent(event *payment.PaymentEvent) (*payment.PaymentEvent, error) {
+	encoded, err := json.Marshal(event.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payment event data: %w", err)
+	}
+	var data payment.PaymentEventData
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payment event data: %w", err)
+	}
+
+	return &payment.PaymentEvent{
+		PaymentID:  event.PaymentID,
+		Version:    event.Version,
+		Type:       event.Type,
+		Data:       data,
+		OccurredAt: event.OccurredAt.UTC(),
+	}, nil
+}
+
+// cloneSnapshot copies a payment snapshot, encoding its state as it is stored in SQL.
+func cloneSnapshot(snapshot *payment.PaymentSnapshot) (*payment.PaymentSnapshot, error) {
+	encoded, err := json.Marshal(snapshot.State)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payment snapshot: %w", err)
+	}
+	var state payment.PaymentSnapshotState
+	if err := json.Unmarshal(encoded, &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payment snapshot: %w", err)
+	}
+
+	return &payment.PaymentSnapshot{
+		PaymentID: snapshot.PaymentID,
+		Version:   snapshot.Version,
+		State:     state,
+		TakenAt:   snapshot.TakenAt.UTC(),
+	}, nil
+}
+
+var _ payment.Repository = (*PaymentRepository)(nil)
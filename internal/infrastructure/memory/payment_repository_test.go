@@ -0,0 +1,118 @@
+package memory_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/memory"
+	"crypto-checkout/test/testutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Event_Stream", func(t *testing.T) {
+		repo := memory.NewPaymentRepository()
+		p := newPayment(t, "payment-1", "invoice-1", testutil.TestTransactionHash)
+		require.NoError(t, repo.Save(ctx, p))
+		assert.Empty(t, p.PendingEvents())
+
+		require.NoError(t, p.UpdateConfirmations(ctx, 1))
+		require.NoError(t, repo.Update(ctx, p))
+
+		events, err := repo.FindEvents(ctx, "payment-1")
+		require.NoError(t, err)
+		require.Len(t, events, p.Version())
+		assert.Equal(t, 1, events[0].Version)
+
+		found, err := repo.FindByID(ctx, "payment-1")
+		require.NoError(t, err)
+		assert.Equal(t, p.Version(), found.Version())
+		assert.Equal(t, 1, found.Confirmations().Int())
+	})
+
+	t.Run("Stale_Update_Is_Rejected", func(t *testing.T) {
+		repo := memory.NewPaymentRepository()
+		require.NoError(t, repo.Save(ctx, newPayment(t, "payment-1", "invoice-1", testutil.TestTransactionHash)))
+
+		first, err := repo.FindByID(ctx, "payment-1")
+		require.NoError(t, err)
+		second, err := repo.FindByID(ctx, "payment-1")
+		require.NoError(t, err)
+
+		require.NoError(t, first.UpdateConfirmations(ctx, 1))
+		require.NoError(t, repo.Update(ctx, first))
+		require.NoError(t, second.UpdateConfirmations(ctx, 2))
+		require.ErrorIs(t, repo.Update(ctx, second), payment.ErrConcurrentModification)
+
+		found, err := repo.FindByID(ctx, "payment-1")
+		require.NoError(t, err)
+		assert.Equal(t, 1, found.Confirmations().Int())
+	})
+
+	t.Run("Duplicate_Transaction", func(t *testing.T) {
+		repo := memory.NewPaymentRepository()
+		require.NoError(t, repo.Save(ctx, newPayment(t, "payment-1", "invoice-1", testutil.TestTransactionHash)))
+		err := repo.Save(ctx, newPayment(t, "payment-2", "invoice-1", testutil.TestTransactionHash))
+		require.ErrorIs(t, err, payment.ErrDuplicateTransaction)
+
+		hash, err := payment.NewTransactionHash(testutil.TestTransactionHash)
+		require.NoError(t, err)
+		found, err := repo.FindByTransactionHash(ctx, shared.NetworkTron, hash)
+		require.NoError(t, err)
+		assert.Equal(t, shared.PaymentID("payment-1"), found.ID())
+		_, err = repo.FindByTransactionHash(ctx, shared.NetworkEthereum, hash)
+		require.ErrorIs(t, err, payment.ErrPaymentNotFound)
+	})
+
+	t.Run("Snapshots", func(t *testing.T) {
+		repo := memory.NewPaymentRepository()
+		p := newPayment(t, "payment-1", "invoice-1", testutil.TestTransactionHash)
+		for i := p.Version(); i < payment.SnapshotInterval+1; i++ {
+			require.NoError(t, p.UpdateConfirmations(ctx, i))
+		}
+		require.NoError(t, repo.Save(ctx, p))
+
+		snapshots, err := repo.FindSnapshots(ctx, "payment-1")
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+		assert.Equal(t, p.Version(), snapshots[0].Version)
+
+		require.NoError(t, p.UpdateConfirmations(ctx, 500))
+		require.NoError(t, repo.Update(ctx, p))
+		pending, err := repo.FindPending(ctx)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, 500, pending[0].Confirmations().Int())
+		assert.Equal(t, p.Version(), pending[0].Version())
+
+		require.NoError(t, repo.RebuildProjection(ctx, pending[0], nil))
+		snapshots, err = repo.FindSnapshots(ctx, "payment-1")
+		require.NoError(t, err)
+		assert.Len(t, snapshots, 1)
+	})
+
+	t.Run("Delete_Keeps_Stream", func(t *testing.T) {
+		repo := memory.NewPaymentRepository()
+		require.NoError(t, repo.Save(ctx, newPayment(t, "payment-1", "invoice-1", testutil.TestTransactionHash)))
+
+		counts, err := repo.CountByStatus(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[payment.PaymentStatus]int{payment.StatusDetected: 1}, counts)
+
+		require.NoError(t, repo.Delete(ctx, "payment-1"))
+		require.ErrorIs(t, repo.Delete(ctx, "payment-1"), payment.ErrPaymentNotFound)
+		_, err = repo.FindByID(ctx, "payment-1")
+		require.ErrorIs(t, err, payment.ErrPaymentNotFound)
+
+		events, err := repo.FindEvents(ctx, "payment-1")
+		require.NoError(t, err)
+		assert.NotEmpty(t, events)
+		_, err = repo.FindEvents(ctx, "payment-2")
+		require.ErrorIs(t, err, payment.ErrPaymentNotFound)
+	})
+}
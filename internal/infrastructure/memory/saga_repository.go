@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/saga"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SagaRepository implements the saga.Repository interface in memory.
+// As in SQL, a confirmed payment starts at most one saga.
+type SagaRepository struct {
+	mu    sync.RWMutex
+	sagas map[string]*saga.Saga
+}
+
+// NewSagaRepository creates a new in-memory saga repository.
+func NewSagaRepository() *SagaRepository {
+	return &SagaRepository{sagas: make(map[string]*saga.Saga)}
+}
+
+// Save stores a new saga.
+func (r *SagaRepository) Save(ctx context.Context, s *saga.Saga) error {
+	clone, err := cloneSaga(s)
+	if err != nil {
+		return fmt.Errorf("failed to save saga: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.sagas[s.ID()]; ok {
+		return fmt.Errorf("failed to save saga: saga %s already exists", s.ID())
+	}
+	for _, other := range r.sagas {
+		if other.PaymentID() == s.PaymentID() {
+			return fmt.Errorf("failed to save saga: payment %s already started saga %s", s.PaymentID(), other.ID())
+		}
+	}
+	r.sagas[s.ID()] = clone
+	return nil
+}
+
+// Update updates an existing saga.
+func (r *SagaRepository) Update(ctx context.Context, s *saga.Saga) error {
+	clone, err := cloneSaga(s)
+	if err != nil {
+		return fmt.Errorf("failed to update saga: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sagas[s.ID()] = clone
+	return nil
+}
+
+// FindByID finds a saga by ID.
+func (r *SagaRepository) FindByID(ctx context.Context, id string) (*saga.Saga, error) {
+	return r.findLatest(func(s *saga.Saga) bool { return s.ID() == id })
+}
+
+// FindByPaymentID finds the saga started by a confirmed payment.
+func (r *SagaRepository) FindByPaymentID(ctx context.Context, paymentID string) (*saga.Saga, error) {
+	return r.findLatest(func(s *saga.Saga) bool { return s.PaymentID() == paymentID })
+}
+
+// FindActiveByInvoiceID finds the saga of an invoice that is still running or compensating.
+func (r *SagaRepository) FindActiveByInvoiceID(ctx context.Context, invoiceID string) (*saga.Saga, error) {
+	return r.findLatest(func(s *saga.Saga) bool {
+		return s.InvoiceID() == invoiceID &&
+			(s.Status() == saga.StatusRunning || s.Status() == saga.StatusCompensating)
+	})
+}
+
+// FindBySettlementID finds the most recent saga that created a settlement.
+func (r *SagaRepository) FindBySettlementID(ctx context.Context, settlementID string) (*saga.Saga, error) {
+	return r.findLatest(func(s *saga.Saga) bool { return s.SettlementID() == settlementID })
+}
+
+// List retrieves sagas matching the filter, newest first.
+func (r *SagaRepository) List(ctx context.Context, req *saga.ListSagasRequest) (*saga.ListSagasResponse, error) {
+	r.mu.RLock()
+	var matched []*saga.Saga
+	for _, s := range r.sagas {
+		if (req.InvoiceID == "" || s.InvoiceID() == req.InvoiceID) &&
+			(req.MerchantID == "" || s.MerchantID() == req.MerchantID) &&
+			(req.Status == "" || s.Status() == req.Status) {
+			matched = append(matched, s)
+		}
+	}
+	r.mu.RUnlock()
+
+	matched, next := page(matched, func(s *saga.Saga) (time.Time, string) {
+		return s.CreatedAt(), s.ID()
+	}, req.Cursor, 0, req.Limit)
+
+	sagas := make([]*saga.Saga, len(matched))
+	for i, s := range matched {
+		clone, err := cloneSaga(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy saga %s: %w", s.ID(), err)
+		}
+		sagas[i] = clone
+	}
+
+	return &saga.ListSagasResponse{
+		Sagas:      sagas,
+		Limit:      req.Limit,
+		NextCursor: next,
+	}, nil
+}
+
+// findLatest finds the most recently created saga that matches.
+func (r *SagaRepository) findLatest(match func(*saga.Saga) bool) (*saga.Saga, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest *saga.Saga
+	for _, s := range r.sagas {
+		if match(s) && (latest == nil || s.CreatedAt().After(latest.CreatedAt())) {
+			latest = s
+		}
+	}
+	if latest == nil {
+		return nil, saga.ErrSagaNotFound
+	}
+	return cloneSaga(latest)
+}
+
+// cloneSaga copies a saga.
+func cloneSaga(s *saga.Saga) (*saga.Saga, error) {
+	return saga.RestoreSaga(
+		s.ID(),
+		s.InvoiceID(),
+		s.PaymentID(),
+		s.MerchantID(),
+		s.SettlementID(),
+		s.PayoutID(),
+		s.Status(),
+		s.Step(),
+		s.PayoutFailures(),
+		s.Reason(),
+		s.LastError(),
+		s.CreatedAt(),
+		s.UpdatedAt(),
+		cloneTime(s.CompletedAt()),
+	)
+}
+
+var _ saga.Repository = (*SagaRepository)(nil)
@@ -0,0 +1,62 @@
+package memory_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/saga"
+	"crypto-checkout/internal/infrastructure/memory"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSagaRepository(t *testing.T) {
+	repo := memory.NewSagaRepository()
+	ctx := context.Background()
+
+	save := func(id, invoiceID, paymentID string) *saga.Saga {
+		s, err := saga.NewSaga(id, invoiceID, paymentID, "merchant-1")
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, s))
+		return s
+	}
+	settled := save("saga-1", "invoice-1", "payment-1")
+	save("saga-2", "invoice-2", "payment-2")
+	duplicate, err := saga.NewSaga("saga-3", "invoice-1", "payment-1", "merchant-1")
+	require.NoError(t, err)
+	require.Error(t, repo.Save(ctx, duplicate))
+
+	require.NoError(t, settled.MarkInvoicePaid())
+	require.NoError(t, settled.MarkSettled("settlement-1"))
+	_, err = repo.FindBySettlementID(ctx, "settlement-1")
+	require.ErrorIs(t, err, saga.ErrSagaNotFound)
+	require.NoError(t, repo.Update(ctx, settled))
+
+	found, err := repo.FindBySettlementID(ctx, "settlement-1")
+	require.NoError(t, err)
+	assert.Equal(t, "saga-1", found.ID())
+	assert.Equal(t, saga.StepSettled, found.Step())
+	found, err = repo.FindActiveByInvoiceID(ctx, "invoice-1")
+	require.NoError(t, err)
+	assert.Equal(t, "saga-1", found.ID())
+
+	require.NoError(t, settled.Compensate("payout failed"))
+	require.NoError(t, settled.MarkSettlementReversed())
+	require.NoError(t, settled.MarkInvoiceReopened())
+	require.NoError(t, repo.Update(ctx, settled))
+	_, err = repo.FindActiveByInvoiceID(ctx, "invoice-1")
+	require.ErrorIs(t, err, saga.ErrSagaNotFound)
+
+	resp, err := repo.List(ctx, &saga.ListSagasRequest{Status: saga.StatusRunning, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, resp.Sagas, 1)
+	assert.Equal(t, "invoice-2", resp.Sagas[0].InvoiceID())
+
+	resp, err = repo.List(ctx, &saga.ListSagasRequest{Limit: 1})
+	require.NoError(t, err)
+	require.NotNil(t, resp.NextCursor)
+	resp, err = repo.List(ctx, &saga.ListSagasRequest{Limit: 1, Cursor: resp.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, resp.Sagas, 1)
+	assert.Nil(t, resp.NextCursor)
+}
@@ -0,0 +1,369 @@
+package memory
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/settlement"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SettlementRepository implements the settlement.Repository interface in memory.
+// As in SQL, an invoice has at most one settlement per merchant that is not reversed, and a settlement at most one
+// reversal.
+type SettlementRepository struct {
+	mu          sync.RWMutex
+	settlements map[string]*settlement.Settlement
+	// reversals holds the reversal entries keyed by the ID of the settlement they offset.
+	reversals map[string]*settlement.Reversal
+}
+
+// NewSettlementRepository creates a new in-memory settlement repository.
+func NewSettlementRepository() *SettlementRepository {
+	return &SettlementRepository{
+		settlements: make(map[string]*settlement.Settlement),
+		reversals:   make(map[string]*settlement.Reversal),
+	}
+}
+
+// Save stores a new settlement and the shares split from it, all or none.
+func (r *SettlementRepository) Save(ctx context.Context, s *settlement.Settlement, splits ...*settlement.Settlement) error {
+	clones, err := cloneSettlements(append([]*settlement.Settlement{s}, splits...))
+	if err != nil {
+		return fmt.Errorf("failed to save settlement: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, clone := range clones {
+		if _, ok := r.settlements[clone.ID()]; ok {
+			return fmt.Errorf("failed to save settlement: settlement %s already exists", clone.ID())
+		}
+		if err := r.checkActive(clone); err != nil {
+			return fmt.Errorf("failed to save settlement: %w", err)
+		}
+		for _, other := range clones[:i] {
+			if other.ID() == clone.ID() || other.MerchantID() == clone.MerchantID() {
+				return fmt.Errorf("failed to save settlement: settlements %s and %s conflict", other.ID(), clone.ID())
+			}
+		}
+	}
+	for _, clone := range clones {
+		r.settlements[clone.ID()] = clone
+	}
+	return nil
+}
+
+// checkActive enforces that an invoice has at most one settlement per merchant that is not reversed.
+// The caller holds the lock.
+func (r *SettlementRepository) checkActive(s *settlement.Settlement) error {
+	if s.Status() == settlement.StatusReversed {
+		return nil
+	}
+	for _, other := range r.settlements {
+		if other.ID() != s.ID() && other.InvoiceID() == s.InvoiceID() && other.MerchantID() == s.MerchantID() &&
+			other.Status() != settlement.StatusReversed {
+			return fmt.Errorf("invoice %s already has settlement %s", s.InvoiceID(), other.ID())
+		}
+	}
+	return nil
+}
+
+// FindByID finds a settlement by ID.
+func (r *SettlementRepository) FindByID(ctx context.Context, id string) (*settlement.Settlement, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.settlements[id]
+	if !ok {
+		return nil, settlement.ErrSettlementNotFound
+	}
+	return cloneSettlement(s, s.PayoutID())
+}
+
+// FindByInvoiceID finds the latest settlement of an invoice for the invoice's merchant.
+func (r *SettlementRepository) FindByInvoiceID(ctx context.Context, invoiceID string) (*settlement.Settlement, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var latest *settlement.Settlement
+	for _, s := range r.settlements {
+		if s.InvoiceID() == invoiceID && s.SplitOf() == "" && (latest == nil || s.CreatedAt().After(latest.CreatedAt())) {
+			latest = s
+		}
+	}
+	if latest == nil {
+		return nil, settlement.ErrSettlementNotFound
+	}
+	return cloneSettlement(latest, latest.PayoutID())
+}
+
+// FindSplits finds the shares split from a settlement, ordered by ID.
+func (r *SettlementRepository) FindSplits(ctx context.Context, settlementID string) ([]*settlement.Settlement, error) {
+	r.mu.RLock()
+	var splits []*settlement.Settlement
+	for _, s := range r.settlements {
+		if s.SplitOf() == settlementID {
+			splits = append(splits, s)
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(splits, func(a, b *settlement.Settlement) int { return strings.Compare(a.ID(), b.ID()) })
+	return cloneSettlements(splits)
+}
+
+// SaveReversal updates a reversed settlement and records its reversal entry together.
+func (r *SettlementRepository) SaveReversal(
+	ctx context.Context,
+	s *settlement.Settlement,
+	reversal *settlement.Reversal,
+) error {
+	clone, err := cloneSettlement(s, s.PayoutID())
+	if err != nil {
+		return fmt.Errorf("failed to save settlement reversal: %w", err)
+	}
+	reversalClone, err := cloneReversal(reversal)
+	if err != nil {
+		return fmt.Errorf("failed to save settlement reversal: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.reversals[reversal.SettlementID()]; ok {
+		return fmt.Errorf("failed to save settlement reversal: settlement %s is already reversed",
+			reversal.SettlementID())
+	}
+	if err := r.checkActive(clone); err != nil {
+		return fmt.Errorf("failed to save settlement reversal: %w", err)
+	}
+	r.settlements[s.ID()] = clone
+	r.reversals[reversal.SettlementID()] = reversalClone
+	return nil
+}
+
+// FindReversal finds the reversal entry of a settlement.
+func (r *SettlementRepository) FindReversal(ctx context.Context, settlementID string) (*settlement.Reversal, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	reversal, ok := r.reversals[settlementID]
+	if !ok {
+		return nil, settlement.ErrReversalNotFound
+	}
+	return cloneReversal(reversal)
+}
+
+// FindPending finds up to limit pending settlements, oldest first.
+func (r *SettlementRepository) FindPending(ctx context.Context, limit int) ([]*settlement.Settlement, error) {
+	return r.findOldest(limit, func(s *settlement.Settlement) bool {
+		return s.Status() == settlement.StatusPending
+	})
+}
+
+// List retrieves settlements matching the filter, newest first, with totals over the whole filter.
+func (r *SettlementRepository) List(
+	ctx context.Context,
+	req *settlement.ListSettlementsRequest,
+) (*settlement.ListSettlementsResponse, error) {
+	inPeriod := func(createdAt time.Time) bool {
+		return (req.From == nil || !createdAt.Before(*req.From)) && (req.To == nil || !createdAt.After(*req.To))
+	}
+
+	r.mu.RLock()
+	var (
+		matched []*settlement.Settlement
+		summary settlement.Summary
+	)
+	for _, s := range r.settlements {
+		if s.MerchantID() != req.MerchantID || req.Status != "" && s.Status() != req.Status || !inPeriod(s.CreatedAt()) {
+			continue
+		}
+		matched = append(matched, s)
+		summary.Add(s.GrossAmount(), s.FeeAmount(), s.NetAmount())
+	}
+	if req.Status == "" {
+		for _, reversal := range r.reversals {
+			if reversal.MerchantID() == req.MerchantID && inPeriod(reversal.CreatedAt()) {
+				summary.AddReversal(reversal.GrossAmount(), reversal.FeeAmount(), reversal.NetAmount())
+			}
+		}
+	}
+	r.mu.RUnlock()
+
+	matched, next := page(matched, func(s *settlement.Settlement) (time.Time, string) {
+		return s.CreatedAt(), s.ID()
+	}, req.Cursor, 0, req.Limit)
+
+	settlements, err := cloneSettlements(matched)
+	if err != nil {
+		return nil, err
+	}
+
+	return &settlement.ListSettlementsResponse{
+		Settlements: settlements,
+		Summary:     summary,
+		Limit:       req.Limit,
+		NextCursor:  next,
+	}, nil
+}
+
+// Update updates an existing settlement.
+func (r *SettlementRepository) Update(ctx context.Context, s *settlement.Settlement) error {
+	clone, err := cloneSettlement(s, s.PayoutID())
+	if err != nil {
+		return fmt.Errorf("failed to update settlement: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.checkActive(clone); err != nil {
+		return fmt.Errorf("failed to update settlement: %w", err)
+	}
+	r.settlements[s.ID()] = clone
+	return nil
+}
+
+// FindAwaitingPayout finds up to limit settlements awaiting an on-chain payout, oldest first.
+func (r *SettlementRepository) FindAwaitingPayout(ctx context.Context, limit int) ([]*settlement.Settlement, error) {
+	return r.findOldest(limit, (*settlement.Settlement).AwaitsPayout)
+}
+
+// AssignPayout sets the payout of the given settlements that still await one. The check and the update happen
+// under one lock, so two payouts cannot claim the same settlement.
+func (r *SettlementRepository) AssignPayout(ctx context.Context, payoutID string, settlementIDs []string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	assigned := 0
+	for _, id := range settlementIDs {
+		s, ok := r.settlements[id]
+		if !ok || !s.AwaitsPayout() {
+			continue
+		}
+		clone, err := cloneSettlement(s, payoutID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to assign settlements to payout: %w", err)
+		}
+		r.settlements[id] = clone
+		assigned++
+	}
+	return assigned, nil
+}
+
+// ReleasePayout clears the payout of its settlements.
+func (r *SettlementRepository) ReleasePayout(ctx context.Context, payoutID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, s := range r.settlements {
+		if s.PayoutID() != payoutID {
+			continue
+		}
+		clone, err := cloneSettlement(s, "")
+		if err != nil {
+			return fmt.Errorf("failed to release settlements from payout: %w", err)
+		}
+		r.settlements[id] = clone
+	}
+	return nil
+}
+
+// findOldest finds up to limit settlements that match, oldest first.
+func (r *SettlementRepository) findOldest(
+	limit int,
+	match func(*settlement.Settlement) bool,
+) ([]*settlement.Settlement, error) {
+	r.mu.RLock()
+	var matched []*settlement.Settlement
+	for _, s := range r.settlements {
+		if match(s) {
+			matched = append(matched, s)
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(matched, func(a, b *settlement.Settlement) int { return strings.Compare(a.ID(), b.ID()) })
+	return cloneSettlements(oldestFirst(matched, (*settlement.Settlement).CreatedAt, limit))
+}
+
+// cloneSettlements copies settlements.
+func cloneSettlements(settlements []*settlement.Settlement) ([]*settlement.Settlement, error) {
+	clones := make([]*settlement.Settlement, len(settlements))
+	for i, s := range settlements {
+		clone, err := cloneSettlement(s, s.PayoutID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy settlement %s: %w", s.ID(), err)
+		}
+		clones[i] = clone
+	}
+	return clones, nil
+}
+
+// cloneSettlement copies a settlement, setting its payout.
+func cloneSettlement(s *settlement.Settlement, payoutID string) (*settlement.Settlement, error) {
+	var conversion *settlement.Conversion
+	if c := s.Conversion(); c != nil {
+		var err error
+		conversion, err = settlement.RestoreConversion(
+			c.Exchange(),
+			c.OrderID(),
+			c.Status(),
+			c.FiatCurrency(),
+			c.Amount(),
+			c.FiatAmount(),
+			c.Fee(),
+			c.FailureReason(),
+			c.SubmittedAt(),
+			cloneTime(c.CompletedAt()),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	clone, err := settlement.RestoreSettlement(
+		s.ID(),
+		s.InvoiceID(),
+		s.MerchantID(),
+		s.GrossAmount(),
+		s.FeePercentage(),
+		s.FeeAmount(),
+		s.NetAmount(),
+		s.Currency(),
+		s.Status(),
+		conversion,
+		s.FailureReason(),
+		payoutID,
+		s.SplitOf(),
+		s.CreatedAt(),
+		cloneTime(s.SettledAt()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tier := s.FeeTier(); tier != nil {
+		copied := *tier
+		clone.SetFeeTier(&copied)
+	}
+	return clone, nil
+}
+
+// cloneReversal copies a settlement reversal.
+func cloneReversal(reversal *settlement.Reversal) (*settlement.Reversal, error) {
+	return settlement.RestoreReversal(
+		reversal.ID(),
+		reversal.SettlementID(),
+		reversal.InvoiceID(),
+		reversal.MerchantID(),
+		reversal.PaymentID(),
+		reversal.GrossAmount(),
+		reversal.FeeAmount(),
+		reversal.NetAmount(),
+		reversal.Currency(),
+		reversal.Reason(),
+		reversal.PayoutID(),
+		reversal.CreatedAt(),
+	)
+}
+
+var _ settlement.Repository = (*SettlementRepository)(nil)
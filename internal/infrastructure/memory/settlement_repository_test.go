@@ -0,0 +1,123 @@
+package memory_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/infrastructure/memory"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettlementRepository(t *testing.T) {
+	repo := memory.NewSettlementRepository()
+	ctx := context.Background()
+
+	newSettlement := func(id, invoiceID, merchantID string) *settlement.Settlement {
+		s, err := settlement.NewSettlement(
+			id, invoiceID, merchantID, decimal.RequireFromString("100"), "USDT", decimal.RequireFromString("1"),
+		)
+		require.NoError(t, err)
+		return s
+	}
+
+	original := newSettlement("settlement-1", "invoice-1", "merchant-1")
+	require.NoError(t, original.Complete())
+	require.NoError(t, repo.Save(ctx, original))
+	require.NoError(t, repo.Save(ctx, newSettlement("settlement-2", "invoice-2", "merchant-1")))
+	require.Error(t, repo.Save(ctx, newSettlement("settlement-3", "invoice-1", "merchant-1")))
+
+	t.Run("Payouts", func(t *testing.T) {
+		awaiting, err := repo.FindAwaitingPayout(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, awaiting, 1)
+		assert.Equal(t, "settlement-1", awaiting[0].ID())
+
+		assigned, err := repo.AssignPayout(ctx, "payout-1", []string{"settlement-1", "settlement-2"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, assigned)
+		assigned, err = repo.AssignPayout(ctx, "payout-2", []string{"settlement-1"})
+		require.NoError(t, err)
+		assert.Zero(t, assigned)
+
+		found, err := repo.FindByID(ctx, "settlement-1")
+		require.NoError(t, err)
+		assert.Equal(t, "payout-1", found.PayoutID())
+
+		require.NoError(t, repo.ReleasePayout(ctx, "payout-1"))
+		awaiting, err = repo.FindAwaitingPayout(ctx, 10)
+		require.NoError(t, err)
+		assert.Len(t, awaiting, 1)
+	})
+
+	t.Run("Reversal", func(t *testing.T) {
+		reversal, err := original.Reverse("reversal-1", "payment-1", "removed by a reorganization")
+		require.NoError(t, err)
+		require.NoError(t, repo.SaveReversal(ctx, original, reversal))
+		require.Error(t, repo.SaveReversal(ctx, original, reversal))
+
+		found, err := repo.FindReversal(ctx, "settlement-1")
+		require.NoError(t, err)
+		assert.Equal(t, "-99", found.NetAmount().String())
+
+		require.NoError(t, repo.Save(ctx, newSettlement("settlement-4", "invoice-1", "merchant-1")))
+		latest, err := repo.FindByInvoiceID(ctx, "invoice-1")
+		require.NoError(t, err)
+		assert.Equal(t, "settlement-4", latest.ID())
+
+		_, err = repo.FindReversal(ctx, "settlement-4")
+		require.ErrorIs(t, err, settlement.ErrReversalNotFound)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		resp, err := repo.List(ctx, &settlement.ListSettlementsRequest{MerchantID: "merchant-1", Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, 3, resp.Summary.Count)
+		assert.Equal(t, "198", resp.Summary.TotalNetAmount.String())
+		require.Len(t, resp.Settlements, 2)
+		require.NotNil(t, resp.NextCursor)
+
+		resp, err = repo.List(ctx, &settlement.ListSettlementsRequest{
+			MerchantID: "merchant-1", Limit: 2, Cursor: resp.NextCursor,
+		})
+		require.NoError(t, err)
+		assert.Len(t, resp.Settlements, 1)
+		assert.Nil(t, resp.NextCursor)
+
+		resp, err = repo.List(ctx, &settlement.ListSettlementsRequest{
+			MerchantID: "merchant-1", Status: settlement.StatusPending, Limit: 10,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, resp.Summary.Count)
+		assert.Equal(t, "198", resp.Summary.TotalNetAmount.String())
+	})
+
+	t.Run("Splits", func(t *testing.T) {
+		parent := newSettlement("settlement-5", "invoice-5", "merchant-2")
+		share, err := parent.Split("settlement-6", "seller-1", decimal.RequireFromString("40"))
+		require.NoError(t, err)
+		duplicate, err := newSettlement("settlement-7", "invoice-5", "merchant-2").
+			Split("settlement-8", "seller-1", decimal.RequireFromString("40"))
+		require.NoError(t, err)
+		require.Error(t, repo.Save(ctx, parent, share, duplicate))
+		_, err = repo.FindByID(ctx, "settlement-5")
+		require.ErrorIs(t, err, settlement.ErrSettlementNotFound, "nothing is saved when one settlement fails")
+
+		require.NoError(t, repo.Save(ctx, parent, share))
+		found, err := repo.FindByInvoiceID(ctx, "invoice-5")
+		require.NoError(t, err)
+		assert.Equal(t, "settlement-5", found.ID())
+
+		splits, err := repo.FindSplits(ctx, "settlement-5")
+		require.NoError(t, err)
+		require.Len(t, splits, 1)
+		assert.Equal(t, "seller-1", splits[0].MerchantID())
+		assert.Equal(t, "settlement-5", splits[0].SplitOf())
+		assert.Equal(t, "60", found.GrossAmount().String())
+	})
+
+	_, err := repo.FindByID(ctx, "settlement-9")
+	require.ErrorIs(t, err, settlement.ErrSettlementNotFound)
+}
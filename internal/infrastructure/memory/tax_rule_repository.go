@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"crypto-checkout/internal/domain/tax"
+	"slices"
+	"sync"
+)
+
+// TaxRuleRepository implements the tax.Repository interface in memory.
+// Lookups made with a merchant-scoped context only see that merchant's rules.
+type TaxRuleRepository struct {
+	mu    sync.RWMutex
+	rules map[string]*tax.Rule
+}
+
+// NewTaxRuleRepository creates a new in-memory tax rule repository.
+func NewTaxRuleRepository() *TaxRuleRepository {
+	return &TaxRuleRepository{rules: make(map[string]*tax.Rule)}
+}
+
+// Save stores a new tax rule.
+func (r *TaxRuleRepository) Save(ctx context.Context, rule *tax.Rule) error {
+	clone, err := cloneRule(rule, rule)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, other := range r.rules {
+		if other.MerchantID() == rule.MerchantID() && other.Jurisdiction() == rule.Jurisdiction() &&
+			other.Category() == rule.Category() && other.Name() == rule.Name() {
+			return tax.ErrRuleExists
+		}
+	}
+	r.rules[rule.ID()] = clone
+	return nil
+}
+
+// FindByID finds a tax rule by ID.
+func (r *TaxRuleRepository) FindByID(ctx context.Context, id string) (*tax.Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rule, ok := r.rules[id]
+	if !ok || !visible(ctx, rule.MerchantID()) {
+		return nil, tax.ErrRuleNotFound
+	}
+	return cloneRule(rule, rule)
+}
+
+// ListByMerchant lists a merchant's tax rules ordered by jurisdiction, category and name.
+func (r *TaxRuleRepository) ListByMerchant(ctx context.Context, merchantID string) ([]*tax.Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := []*tax.Rule{}
+	for _, rule := range r.rules {
+		if rule.MerchantID() != merchantID {
+			continue
+		}
+		clone, err := cloneRule(rule, rule)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, clone)
+	}
+	slices.SortFunc(rules, func(a, b *tax.Rule) int {
+		return cmp.Or(
+			cmp.Compare(a.Jurisdiction(), b.Jurisdiction()),
+			cmp.Compare(a.Category(), b.Category()),
+			cmp.Compare(a.Name(), b.Name()),
+		)
+	})
+	return rules, nil
+}
+
+// Update updates the name, rate and reverse charge of an existing tax rule.
+func (r *TaxRuleRepository) Update(ctx context.Context, rule *tax.Rule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.rules[rule.ID()]
+	if !ok || !visible(ctx, stored.MerchantID()) {
+		return tax.ErrRuleNotFound
+	}
+	updated, err := cloneRule(stored, rule)
+	if err != nil {
+		return err
+	}
+	r.rules[rule.ID()] = updated
+	return nil
+}
+
+// Delete removes a tax rule.
+func (r *TaxRuleRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rule, ok := r.rules[id]
+	if !ok || !visible(ctx, rule.MerchantID()) {
+		return tax.ErrRuleNotFound
+	}
+	delete(r.rules, id)
+	return nil
+}
+
+// cloneRule copies a tax rule, taking the fields an update changes from the edited rule.
+func cloneRule(rule, edited *tax.Rule) (*tax.Rule, error) {
+	return tax.RestoreRule(
+		rule.ID(),
+		rule.MerchantID(),
+		rule.Jurisdiction(),
+		rule.Category(),
+		edited.Name(),
+		rule.Type(),
+		edited.Rate(),
+		edited.IsReverseCharge(),
+		rule.CreatedAt(),
+		edited.UpdatedAt(),
+	)
+}
+
+var _ tax.Repository = (*TaxRuleRepository)(nil)
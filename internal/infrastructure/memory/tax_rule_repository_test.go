@@ -0,0 +1,52 @@
+package memory_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/tax"
+	"crypto-checkout/internal/infrastructure/memory"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTaxRuleRepository(t *testing.T) {
+	repo := memory.NewTaxRuleRepository()
+	service := tax.NewService(repo, zap.NewNop())
+	ctx := context.Background()
+
+	createRule := func(jurisdiction, name string, taxType invoice.TaxType, rate string) error {
+		_, err := service.CreateRule(ctx, &tax.CreateRuleRequest{
+			MerchantID: "merchant-1", Jurisdiction: jurisdiction, Name: name, Type: taxType, Rate: rate,
+		})
+		return err
+	}
+	require.NoError(t, createRule("ca-bc", "PST", invoice.TaxTypeSalesTax, "0.07"))
+	require.NoError(t, createRule("CA", "GST", invoice.TaxTypeGST, "0.05"))
+	require.ErrorIs(t, createRule("CA", "GST", invoice.TaxTypeGST, "0.05"), tax.ErrRuleExists)
+
+	policy, err := service.ResolvePolicy(ctx, "merchant-1", "ca-bc", "")
+	require.NoError(t, err)
+	rates := policy.RatesFor(invoice.DefaultTaxCategory)
+	require.Len(t, rates, 2)
+	assert.Equal(t, "GST", rates[0].Name())
+
+	rules, err := service.ListRules(ctx, "merchant-1")
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "CA", rules[0].Jurisdiction())
+
+	updated, err := service.UpdateRule(ctx, &tax.UpdateRuleRequest{
+		MerchantID: "merchant-1", RuleID: rules[0].ID(), Name: "GST", Rate: "0.06",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "0.06", updated.Rate())
+
+	_, err = service.GetRule(ctx, "merchant-2", rules[0].ID())
+	require.ErrorIs(t, err, tax.ErrRuleNotFound)
+	require.NoError(t, service.DeleteRule(ctx, "merchant-1", rules[0].ID()))
+	_, err = repo.FindByID(ctx, rules[0].ID())
+	require.ErrorIs(t, err, tax.ErrRuleNotFound)
+}
@@ -0,0 +1,200 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/webhook"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebhookDeliveryRepository implements the webhook.Repository interface in memory.
+// Lookups made with a merchant-scoped context only see that merchant's deliveries.
+type WebhookDeliveryRepository struct {
+	mu         sync.RWMutex
+	deliveries map[string]*webhook.Delivery
+}
+
+// NewWebhookDeliveryRepository creates a new in-memory webhook delivery log repository.
+func NewWebhookDeliveryRepository() *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{deliveries: make(map[string]*webhook.Delivery)}
+}
+
+// Save stores a webhook delivery.
+func (r *WebhookDeliveryRepository) Save(ctx context.Context, delivery *webhook.Delivery) error {
+	clone, err := cloneDelivery(delivery)
+	if err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.deliveries[delivery.ID()]; ok {
+		return fmt.Errorf("failed to save webhook delivery: delivery %s already exists", delivery.ID())
+	}
+	r.deliveries[delivery.ID()] = clone
+	return nil
+}
+
+// FindByID finds a webhook delivery by ID.
+func (r *WebhookDeliveryRepository) FindByID(ctx context.Context, id string) (*webhook.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	delivery, ok := r.deliveries[id]
+	if !ok || !visible(ctx, delivery.MerchantID()) {
+		return nil, webhook.ErrDeliveryNotFound
+	}
+	return cloneDelivery(delivery)
+}
+
+// List lists a merchant's webhook deliveries, newest first, optionally only those to one endpoint.
+func (r *WebhookDeliveryRepository) List(
+	_ context.Context,
+	merchantID, endpointID string,
+	limit int,
+) ([]*webhook.Delivery, error) {
+	return r.list(merchantID, endpointID, limit, func(*webhook.Delivery) bool { return true })
+}
+
+// ListMentioning lists a merchant's webhook deliveries whose payload contains the reference, newest first,
+// optionally only those to one endpoint.
+func (r *WebhookDeliveryRepository) ListMentioning(
+	_ context.Context,
+	merchantID, endpointID, reference string,
+	limit int,
+) ([]*webhook.Delivery, error) {
+	return r.list(merchantID, endpointID, limit, func(delivery *webhook.Delivery) bool {
+		return bytes.Contains(delivery.Payload(), []byte(reference))
+	})
+}
+
+// CountUndelivered counts the events whose every delivery to an endpoint failed.
+func (r *WebhookDeliveryRepository) CountUndelivered(context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	delivered := make(map[[2]string]bool)
+	for _, delivery := range r.deliveries {
+		key := [2]string{delivery.EndpointID(), delivery.EventID()}
+		delivered[key] = delivered[key] || delivery.Status() == webhook.DeliverySucceeded
+	}
+	var count int64
+	for _, ok := range delivered {
+		if !ok {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// EndpointStats summarizes a merchant's deliveries made since the given time, per endpoint delivered to, ordered by
+// endpoint ID.
+func (r *WebhookDeliveryRepository) EndpointStats(
+	_ context.Context,
+	merchantID string,
+	since time.Time,
+) ([]webhook.EndpointStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	byEndpoint := make(map[string]*webhook.EndpointStats)
+	for _, delivery := range r.deliveries {
+		if delivery.MerchantID() != merchantID || delivery.CreatedAt().Before(since) {
+			continue
+		}
+		stats, ok := byEndpoint[delivery.EndpointID()]
+		if !ok {
+			stats = &webhook.EndpointStats{EndpointID: delivery.EndpointID()}
+			byEndpoint[delivery.EndpointID()] = stats
+		}
+
+		at := delivery.CreatedAt()
+		stats.Deliveries++
+		stats.TotalDuration += delivery.Duration().Truncate(time.Millisecond)
+		if at.After(stats.LastDeliveryAt) {
+			stats.LastDeliveryAt = at
+		}
+		if delivery.Status() == webhook.DeliverySucceeded {
+			stats.Succeeded++
+			if at.After(stats.LastSuccessAt) {
+				stats.LastSuccessAt = at
+			}
+		} else {
+			stats.Failed++
+			if at.After(stats.LastFailureAt) {
+				stats.LastFailureAt = at
+			}
+		}
+	}
+
+	result := make([]webhook.EndpointStats, 0, len(byEndpoint))
+	for _, stats := range byEndpoint {
+		result = append(result, *stats)
+	}
+	slices.SortFunc(result, func(a, b webhook.EndpointStats) int { return strings.Compare(a.EndpointID, b.EndpointID) })
+	return result, nil
+}
+
+// list returns copies of a merchant's deliveries that match, newest first.
+func (r *WebhookDeliveryRepository) list(
+	merchantID, endpointID string,
+	limit int,
+	match func(*webhook.Delivery) bool,
+) ([]*webhook.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*webhook.Delivery
+	for _, delivery := range r.deliveries {
+		if delivery.MerchantID() == merchantID && (endpointID == "" || delivery.EndpointID() == endpointID) &&
+			match(delivery) {
+			matched = append(matched, delivery)
+		}
+	}
+	slices.SortFunc(matched, func(a, b *webhook.Delivery) int {
+		if c := b.CreatedAt().Compare(a.CreatedAt()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID(), b.ID())
+	})
+	if limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+
+	deliveries := make([]*webhook.Delivery, len(matched))
+	for i, delivery := range matched {
+		clone, err := cloneDelivery(delivery)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy webhook delivery %s: %w", delivery.ID(), err)
+		}
+		deliveries[i] = clone
+	}
+	return deliveries, nil
+}
+
+// cloneDelivery copies a webhook delivery, keeping its duration to the millisecond as SQL does.
+func cloneDelivery(delivery *webhook.Delivery) (*webhook.Delivery, error) {
+	return webhook.RestoreDelivery(
+		delivery.ID(),
+		delivery.MerchantID(),
+		delivery.EndpointID(),
+		delivery.EventID(),
+		delivery.EventType(),
+		delivery.APIVersion(),
+		delivery.Kind(),
+		delivery.ReplayOf(),
+		delivery.URL(),
+		bytes.Clone(delivery.Payload()),
+		delivery.Status(),
+		delivery.ResponseCode(),
+		delivery.ResponseBody(),
+		delivery.Failure(),
+		delivery.Duration().Truncate(time.Millisecond),
+		delivery.CreatedAt(),
+	)
+}
+
+var _ webhook.Repository = (*WebhookDeliveryRepository)(nil)
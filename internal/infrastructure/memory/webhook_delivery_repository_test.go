@@ -0,0 +1,71 @@
+package memory_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/webhook"
+	"crypto-checkout/internal/infrastructure/memory"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookDeliveryRepository(t *testing.T) {
+	repo := memory.NewWebhookDeliveryRepository()
+	ctx := context.Background()
+	sentAt := time.Date(2025, 1, 15, 10, 18, 30, 0, time.UTC)
+
+	save := func(id, merchantID, endpointID string, createdAt time.Time) {
+		delivery, err := webhook.RestoreDelivery(id, merchantID, endpointID, "evt_1", "webhook.test", "2026-10-17",
+			webhook.DeliveryKindTest, "", "https://merchant.example/webhook", []byte(`{"id":"evt_1"}`),
+			webhook.DeliveryFailed, 503, "unavailable", "", 120*time.Millisecond+time.Microsecond, createdAt)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, delivery))
+	}
+	save("whd-1", "merchant-1", "whe-1", sentAt)
+	save("whd-2", "merchant-1", "whe-1", sentAt.Add(time.Minute))
+	save("whd-3", "merchant-1", "whe-2", sentAt.Add(2*time.Minute))
+	save("whd-4", "merchant-2", "whe-3", sentAt.Add(3*time.Minute))
+
+	found, err := repo.FindByID(ctx, "whd-2")
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"id":"evt_1"}`), found.Payload())
+	assert.Equal(t, 120*time.Millisecond, found.Duration())
+	_, err = repo.FindByID(shared.WithMerchantID(ctx, "merchant-2"), "whd-2")
+	require.ErrorIs(t, err, webhook.ErrDeliveryNotFound)
+
+	deliveries, err := repo.List(ctx, "merchant-1", "", 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 3)
+	assert.Equal(t, "whd-3", deliveries[0].ID())
+	assert.Equal(t, "whd-1", deliveries[2].ID())
+
+	deliveries, err = repo.List(ctx, "merchant-1", "whe-1", 1)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "whd-2", deliveries[0].ID())
+
+	deliveries, err = repo.ListMentioning(ctx, "merchant-1", "", "evt_1", 2)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, "whd-3", deliveries[0].ID())
+
+	deliveries, err = repo.ListMentioning(ctx, "merchant-1", "", "evt_2", 10)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+
+	count, err := repo.CountUndelivered(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	delivered, err := webhook.RestoreDelivery("whd-5", "merchant-1", "whe-1", "evt_1", "webhook.test", "2026-10-17",
+		webhook.DeliveryKindReplay, "whd-2", "https://merchant.example/webhook", []byte(`{"id":"evt_1"}`),
+		webhook.DeliverySucceeded, 200, "ok", "", 80*time.Millisecond, sentAt.Add(4*time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, delivered))
+	count, err = repo.CountUndelivered(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
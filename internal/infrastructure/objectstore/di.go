@@ -0,0 +1,71 @@
+package objectstore
+
+import (
+	"crypto-checkout/internal/domain/statement"
+	"crypto-checkout/pkg/config"
+	"fmt"
+	"net/http"
+	"os"
+
+	"go.uber.org/fx"
+)
+
+// Object storage drivers.
+const (
+	DriverFilesystem = "filesystem"
+	DriverS3         = "s3"
+)
+
+// Module provides the object stores for Fx.
+var Module = fx.Module("objectstore",
+	fx.Provide(NewStatementStorageProvider),
+)
+
+// NewStatementStorageProvider creates the storage of rendered statements from configuration.
+func NewStatementStorageProvider(cfg *config.Config) (statement.Storage, error) {
+	store, err := New(cfg.Statements.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("invalid statements.storage configuration: %w", err)
+	}
+	return store, nil
+}
+
+// New creates the object store of a configuration.
+func New(cfg config.ObjectStorageConfig) (statement.Storage, error) {
+	switch cfg.Driver {
+	case "", DriverFilesystem:
+		directory := cfg.Directory
+		if directory == "" {
+			directory = config.DefaultStorageDirectory
+		}
+		return NewFileStore(directory)
+	case DriverS3:
+		timeout := cfg.Timeout
+		if timeout <= 0 {
+			timeout = config.DefaultStorageTimeout
+		}
+		return NewS3Store(S3Options{
+			Bucket:    cfg.Bucket,
+			Region:    firstNonEmpty(cfg.Region, os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION")),
+			Endpoint:  cfg.Endpoint,
+			PathStyle: cfg.PathStyle,
+			Credentials: S3Credentials{
+				AccessKeyID:     firstNonEmpty(cfg.AccessKeyID, os.Getenv("AWS_ACCESS_KEY_ID")),
+				SecretAccessKey: firstNonEmpty(cfg.SecretAccessKey, os.Getenv("AWS_SECRET_ACCESS_KEY")),
+				SessionToken:    firstNonEmpty(cfg.SessionToken, os.Getenv("AWS_SESSION_TOKEN")),
+			},
+		}, &http.Client{Timeout: timeout})
+	default:
+		return nil, fmt.Errorf("unknown driver %q", cfg.Driver)
+	}
+}
+
+// firstNonEmpty returns the first of the values that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+// Package objectstore keeps the files the application renders, such as merchant statements, in a local directory
+// or in an S3-compatible bucket.
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Errors returned by the object stores.
+var (
+	ErrObjectNotFound = errors.New("object not found")
+	ErrInvalidKey     = errors.New("invalid object key")
+)
+
+// directoryMode and fileMode are the permissions of what the filesystem store creates.
+const (
+	directoryMode = 0o750
+	fileMode      = 0o640
+)
+
+// FileStore keeps objects as files under a directory, one file per key.
+type FileStore struct {
+	root string
+}
+
+// NewFileStore creates a store keeping objects under the directory, which is created by the first Put.
+func NewFileStore(root string) (*FileStore, error) {
+	if root == "" {
+		return nil, errors.New("object storage directory is required")
+	}
+	return &FileStore{root: root}, nil
+}
+
+// Put writes the object to a temporary file renamed over the key's file, so readers never see a partial object.
+// The content type is not kept.
+func (s *FileStore) Put(_ context.Context, key, _ string, data []byte) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), directoryMode); err != nil {
+		return fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create object file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := tmp.Chmod(fileMode); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// Get reads the object stored under the key.
+func (s *FileStore) Get(_ context.Context, key string) ([]byte, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+	}
+	return data, err
+}
+
+// path returns the file of a key, rejecting keys that would leave the directory.
+func (s *FileStore) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if key == "" || strings.HasSuffix(key, "/") || cleaned != "/"+key {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
@@ -0,0 +1,83 @@
+package objectstore_test
+
+import (
+	"context"
+	"crypto-checkout/internal/infrastructure/objectstore"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	store, err := objectstore.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "statements/m-1/2026-09.csv", "text/csv", []byte("first")))
+	require.NoError(t, store.Put(ctx, "statements/m-1/2026-09.csv", "text/csv", []byte("second")))
+	data, err := store.Get(ctx, "statements/m-1/2026-09.csv")
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+
+	_, err = store.Get(ctx, "statements/m-1/2026-08.csv")
+	require.ErrorIs(t, err, objectstore.ErrObjectNotFound)
+
+	for _, key := range []string{"", "../escape", "statements/../../escape", "/absolute", "statements/"} {
+		require.ErrorIs(t, store.Put(ctx, key, "text/csv", nil), objectstore.ErrInvalidKey, key)
+	}
+}
+
+func TestS3Store(t *testing.T) {
+	var mu sync.Mutex
+	objects := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), authorization)
+		assert.Contains(t, authorization, "/eu-west-1/s3/aws4_request")
+		assert.NotEmpty(t, r.Header.Get("X-Amz-Content-Sha256"))
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			assert.Contains(t, authorization, "SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date,")
+			assert.Equal(t, "application/pdf", r.Header.Get("Content-Type"))
+			body, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = string(body)
+		case http.MethodGet:
+			body, ok := objects[r.URL.Path]
+			if !ok {
+				http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+				return
+			}
+			_, _ = io.WriteString(w, body)
+		}
+	}))
+	defer server.Close()
+
+	store, err := objectstore.NewS3Store(objectstore.S3Options{
+		Bucket: "statements", Region: "eu-west-1", Endpoint: server.URL, PathStyle: true,
+		Credentials: objectstore.S3Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"},
+	}, server.Client())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "statements/m-1/2026-09.pdf", "application/pdf", []byte("%PDF")))
+	assert.Contains(t, objects, "/statements/statements/m-1/2026-09.pdf")
+
+	data, err := store.Get(ctx, "statements/m-1/2026-09.pdf")
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF", string(data))
+
+	_, err = store.Get(ctx, "statements/m-1/2026-08.pdf")
+	require.ErrorIs(t, err, objectstore.ErrObjectNotFound)
+
+	_, err = objectstore.NewS3Store(objectstore.S3Options{Bucket: "statements", Region: "eu-west-1"}, server.Client())
+	require.Error(t, err)
+}
@@ -0,0 +1,211 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// sigV4Algorithm is the AWS request signing algorithm.
+	sigV4Algorithm = "AWS4-HMAC-SHA256"
+	// amzDateFormat is the format of the X-Amz-Date header.
+	amzDateFormat = "20060102T150405Z"
+	// maxS3ErrorBody limits how much of an S3 error response is included in errors.
+	maxS3ErrorBody = 512
+)
+
+// S3Credentials authenticate requests to the bucket.
+type S3Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // Set for temporary credentials
+}
+
+// S3Options configures an S3 store.
+type S3Options struct {
+	Bucket string
+	Region string
+	// Endpoint overrides the regional S3 endpoint, e.g. for MinIO.
+	Endpoint string
+	// PathStyle addresses the bucket in the path rather than the host name.
+	PathStyle   bool
+	Credentials S3Credentials
+}
+
+// S3Store keeps objects in a bucket of S3 or an S3-compatible store, signing requests with AWS Signature
+// Version 4.
+type S3Store struct {
+	base        *url.URL
+	pathPrefix  string
+	region      string
+	credentials S3Credentials
+	client      *http.Client
+	now         func() time.Time
+}
+
+// NewS3Store creates a store keeping objects in the bucket.
+func NewS3Store(options S3Options, client *http.Client) (*S3Store, error) {
+	if options.Bucket == "" {
+		return nil, errors.New("bucket is required")
+	}
+	if options.Region == "" {
+		return nil, errors.New("region is required")
+	}
+	if options.Credentials.AccessKeyID == "" || options.Credentials.SecretAccessKey == "" {
+		return nil, errors.New("access key ID and secret access key are required")
+	}
+
+	endpoint := options.Endpoint
+	if endpoint == "" {
+		endpoint = "https://s3." + options.Region + ".amazonaws.com"
+	}
+	base, err := url.Parse(strings.TrimRight(endpoint, "/"))
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint %q", endpoint)
+	}
+
+	store := &S3Store{
+		base:        base,
+		region:      options.Region,
+		credentials: options.Credentials,
+		client:      client,
+		now:         time.Now,
+	}
+	if options.PathStyle {
+		store.pathPrefix = base.Path + "/" + options.Bucket
+	} else {
+		base.Host = options.Bucket + "." + base.Host
+		store.pathPrefix = base.Path
+	}
+	return store, nil
+}
+
+// Put uploads the object.
+func (s *S3Store) Put(ctx context.Context, key, contentType string, data []byte) error {
+	req, err := s.request(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := s.do(req, data)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// Get downloads the object stored under the key.
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	req, err := s.request(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return io.ReadAll(resp.Body)
+}
+
+// request builds a request for an object.
+func (s *S3Store) request(ctx context.Context, method, key string, body []byte) (*http.Request, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	target := *s.base
+	target.Path = s.pathPrefix + "/" + key
+	target.RawPath = s.pathPrefix + "/" + strings.Join(segments, "/")
+	return http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(body))
+}
+
+// do signs and sends a request, turning error responses into errors.
+func (s *S3Store) do(req *http.Request, body []byte) (*http.Response, error) {
+	s.sign(req, body)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, req.URL.Path)
+	}
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, maxS3ErrorBody))
+	return nil, fmt.Errorf("s3 returned %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+}
+
+// sign signs an S3 request with AWS Signature Version 4, including a hash of the payload.
+func (s *S3Store) sign(req *http.Request, body []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format(amzDateFormat)
+	scope := now.Format("20060102") + "/" + s.region + "/s3/aws4_request"
+	payloadHash := sha256.Sum256(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+	if s.credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.credentials.SessionToken)
+	}
+
+	var headers []string
+	if req.Header.Get("Content-Type") != "" {
+		headers = append(headers, "content-type")
+	}
+	headers = append(headers, "host", "x-amz-content-sha256", "x-amz-date")
+	if s.credentials.SessionToken != "" {
+		headers = append(headers, "x-amz-security-token")
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headers {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	signedHeaders := strings.Join(headers, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method, req.URL.EscapedPath(), "", canonicalHeaders.String(), signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := sigV4Algorithm + "\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.credentials.SecretAccessKey), now.Format("20060102"))
+	for _, part := range []string{s.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, s.credentials.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of the data keyed with the key.
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
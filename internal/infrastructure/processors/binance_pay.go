@@ -0,0 +1,352 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto-checkout/internal/domain/processor"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// DefaultBinancePayBaseURL is the default Binance Pay merchant API endpoint.
+	DefaultBinancePayBaseURL = "https://bpay.binanceapi.com"
+	// binancePayOrderPath creates orders.
+	binancePayOrderPath = "/binancepay/openapi/v3/order"
+	// binancePaySuccess is the status of successful API responses.
+	binancePaySuccess = "SUCCESS"
+	// binancePayMaxDescription is the length of the longest order description Binance Pay accepts.
+	binancePayMaxDescription = 256
+)
+
+// maxErrorBody limits how much of a processor error response is included in errors.
+const maxErrorBody = 512
+
+// BinancePay creates Binance Pay orders, paid from the customer's Binance account, and verifies the
+// webhooks Binance Pay signs with its RSA key.
+type BinancePay struct {
+	baseURL string
+	client  *http.Client
+	now     func() time.Time
+}
+
+// NewBinancePay creates a new Binance Pay adapter.
+func NewBinancePay(baseURL string, client *http.Client) *BinancePay {
+	if baseURL == "" {
+		baseURL = DefaultBinancePayBaseURL
+	}
+	return &BinancePay{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  client,
+		now:     time.Now,
+	}
+}
+
+// binancePayOrder is the body of an order creation request.
+type binancePayOrder struct {
+	Env             binancePayEnv     `json:"env"`
+	MerchantTradeNo string            `json:"merchantTradeNo"`
+	OrderAmount     json.Number       `json:"orderAmount"`
+	Currency        string            `json:"currency"`
+	Description     string            `json:"description"`
+	GoodsDetails    []binancePayGoods `json:"goodsDetails"`
+	ReturnURL       string            `json:"returnUrl,omitempty"`
+	CancelURL       string            `json:"cancelUrl,omitempty"`
+	OrderExpireTime int64             `json:"orderExpireTime,omitempty"`
+}
+
+type binancePayEnv struct {
+	TerminalType string `json:"terminalType"`
+}
+
+type binancePayGoods struct {
+	GoodsType        string `json:"goodsType"`
+	GoodsCategory    string `json:"goodsCategory"`
+	ReferenceGoodsID string `json:"referenceGoodsId"`
+	GoodsName        string `json:"goodsName"`
+}
+
+// binancePayResponse is the envelope of API responses.
+type binancePayResponse struct {
+	Status       string          `json:"status"`
+	Code         string          `json:"code"`
+	Data         json.RawMessage `json:"data"`
+	ErrorMessage string          `json:"errorMessage"`
+}
+
+// binancePayCreated is the order a creation request returns.
+type binancePayCreated struct {
+	PrepayID    string `json:"prepayId"`
+	ExpireTime  int64  `json:"expireTime"`
+	QRContent   string `json:"qrContent"`
+	CheckoutURL string `json:"checkoutUrl"`
+}
+
+// binancePayWebhook is the body of a webhook; Data is itself JSON.
+type binancePayWebhook struct {
+	BizType   string `json:"bizType"`
+	BizStatus string `json:"bizStatus"`
+	Data      string `json:"data"`
+}
+
+// binancePayPayment is the order a PAY webhook reports.
+type binancePayPayment struct {
+	MerchantTradeNo string      `json:"merchantTradeNo"`
+	TotalFee        json.Number `json:"totalFee"`
+	Currency        string      `json:"currency"`
+	OpenUserID      string      `json:"openUserId"`
+}
+
+// Name returns the processor name.
+func (b *BinancePay) Name() string {
+	return processor.NameBinancePay
+}
+
+// DisplayName returns the name shown to customers.
+func (b *BinancePay) DisplayName() string {
+	return "Binance Pay"
+}
+
+// CreateCheckout creates a Binance Pay order whose merchant trade number is the checkout reference.
+func (b *BinancePay) CreateCheckout(
+	ctx context.Context,
+	credentials processor.Credentials,
+	req *processor.CheckoutRequest,
+) (*processor.HostedCheckout, error) {
+	if req == nil || !req.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: a positive amount is required", processor.ErrInvalidRequest)
+	}
+	if credentials.APISecret == "" {
+		return nil, fmt.Errorf("%w: Binance Pay needs an API secret", processor.ErrInvalidRequest)
+	}
+
+	description := req.Title
+	if description == "" {
+		description = "Invoice " + req.InvoiceID
+	}
+	order := binancePayOrder{
+		Env:             binancePayEnv{TerminalType: "WEB"},
+		MerchantTradeNo: req.Reference,
+		OrderAmount:     json.Number(req.Amount.String()),
+		Currency:        req.Currency,
+		Description:     truncate(description, binancePayMaxDescription),
+		GoodsDetails: []binancePayGoods{{
+			GoodsType:        "02",
+			GoodsCategory:    "Z000",
+			ReferenceGoodsID: req.InvoiceID,
+			GoodsName:        truncate(description, binancePayMaxDescription),
+		}},
+		ReturnURL: req.ReturnURL,
+		CancelURL: req.CancelURL,
+	}
+	if !req.ExpiresAt.IsZero() {
+		order.OrderExpireTime = req.ExpiresAt.UnixMilli()
+	}
+
+	var created binancePayCreated
+	if err := b.call(ctx, credentials, binancePayOrderPath, order, &created); err != nil {
+		return nil, err
+	}
+
+	hosted := &processor.HostedCheckout{
+		ID:        created.PrepayID,
+		URL:       created.CheckoutURL,
+		QRContent: created.QRContent,
+	}
+	if created.ExpireTime > 0 {
+		hosted.ExpiresAt = time.UnixMilli(created.ExpireTime).UTC()
+	}
+	return hosted, nil
+}
+
+// ParseEvent verifies a Binance Pay webhook against the Binance Pay public key the merchant registered as
+// the webhook secret and returns the order change it reports.
+func (b *BinancePay) ParseEvent(
+	credentials processor.Credentials,
+	headers http.Header,
+	body []byte,
+) (*processor.Event, error) {
+	if err := verifyBinancePaySignature(credentials.WebhookSecret, headers, body); err != nil {
+		return nil, err
+	}
+
+	var webhook binancePayWebhook
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		return nil, fmt.Errorf("%w: %w", processor.ErrInvalidEvent, err)
+	}
+	if webhook.BizType != "PAY" {
+		return nil, processor.ErrIgnoredEvent
+	}
+
+	var paid binancePayPayment
+	if err := json.Unmarshal([]byte(webhook.Data), &paid); err != nil {
+		return nil, fmt.Errorf("%w: %w", processor.ErrInvalidEvent, err)
+	}
+	if paid.MerchantTradeNo == "" {
+		return nil, fmt.Errorf("%w: merchant trade number is missing", processor.ErrInvalidEvent)
+	}
+
+	event := &processor.Event{
+		Reference: paid.MerchantTradeNo,
+		Currency:  paid.Currency,
+		Payer:     paid.OpenUserID,
+	}
+	switch webhook.BizStatus {
+	case "PAY_SUCCESS":
+		amount, err := decimal.NewFromString(paid.TotalFee.String())
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid amount %q", processor.ErrInvalidEvent, paid.TotalFee)
+		}
+		event.Status = processor.EventPaid
+		event.Amount = amount
+	case "PAY_CLOSED":
+		event.Status = processor.EventExpired
+	default:
+		event.Status = processor.EventPending
+	}
+	return event, nil
+}
+
+// call sends a signed JSON request and decodes the data of a successful response into out.
+func (b *BinancePay) call(
+	ctx context.Context,
+	credentials processor.Credentials,
+	path string,
+	in, out interface{},
+) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	nonce, err := binancePayNonce()
+	if err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	timestamp := strconv.FormatInt(b.now().UnixMilli(), 10)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("BinancePay-Timestamp", timestamp)
+	httpReq.Header.Set("BinancePay-Nonce", nonce)
+	httpReq.Header.Set("BinancePay-Certificate-SN", credentials.APIKey)
+	httpReq.Header.Set("BinancePay-Signature", SignBinancePay(credentials.APISecret, timestamp, nonce, body))
+
+	var response binancePayResponse
+	if err := doJSON(b.client, httpReq, &response); err != nil {
+		return err
+	}
+	if response.Status != binancePaySuccess {
+		return fmt.Errorf("%w: Binance Pay returned %s: %s",
+			processor.ErrProcessorFailure, response.Code, response.ErrorMessage)
+	}
+	if err := json.Unmarshal(response.Data, out); err != nil {
+		return fmt.Errorf("%w: failed to decode response: %w", processor.ErrProcessorFailure, err)
+	}
+	return nil
+}
+
+// SignBinancePay returns the signature of a Binance Pay API request: the upper-case hex HMAC-SHA512, keyed
+// with the API secret, of the timestamp, nonce and body, each followed by a newline.
+func SignBinancePay(secret, timestamp, nonce string, body []byte) string {
+	mac := hmac.New(sha512.New, []byte(secret))
+	mac.Write([]byte(binancePayPayload(timestamp, nonce, body)))
+	return strings.ToUpper(hex.EncodeToString(mac.Sum(nil)))
+}
+
+// verifyBinancePaySignature checks the base64 RSA-SHA256 signature Binance Pay sends with webhooks, made
+// over the same payload as request signatures.
+func verifyBinancePaySignature(publicKeyPEM string, headers http.Header, body []byte) error {
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	if block == nil {
+		return fmt.Errorf("%w: the webhook secret is not a PEM public key", processor.ErrInvalidSignature)
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("%w: %w", processor.ErrInvalidSignature, err)
+	}
+	publicKey, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("%w: the webhook secret is not an RSA public key", processor.ErrInvalidSignature)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(headers.Get("BinancePay-Signature"))
+	if err != nil || len(signature) == 0 {
+		return processor.ErrInvalidSignature
+	}
+	payload := binancePayPayload(headers.Get("BinancePay-Timestamp"), headers.Get("BinancePay-Nonce"), body)
+	digest := sha256.Sum256([]byte(payload))
+	if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature); err != nil {
+		return processor.ErrInvalidSignature
+	}
+	return nil
+}
+
+// binancePayPayload returns the payload Binance Pay signs.
+func binancePayPayload(timestamp, nonce string, body []byte) string {
+	return timestamp + "\n" + nonce + "\n" + string(body) + "\n"
+}
+
+// binancePayNonce returns the random 32-letter nonce of a request.
+func binancePayNonce() (string, error) {
+	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	for i, b := range bytes {
+		bytes[i] = letters[int(b)%len(letters)]
+	}
+	return string(bytes), nil
+}
+
+// truncate shortens s to at most n bytes without splitting a character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// doJSON executes the request and decodes a successful JSON response into out.
+func doJSON(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", processor.ErrProcessorFailure, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return fmt.Errorf("%w: %s %s returned %d: %s", processor.ErrProcessorFailure,
+			req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: failed to decode response: %w", processor.ErrProcessorFailure, err)
+	}
+	return nil
+}
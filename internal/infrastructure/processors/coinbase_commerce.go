@@ -0,0 +1,209 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/processor"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// DefaultCoinbaseCommerceBaseURL is the default Coinbase Commerce API endpoint.
+	DefaultCoinbaseCommerceBaseURL = "https://api.commerce.coinbase.com"
+	// coinbaseChargesPath creates charges.
+	coinbaseChargesPath = "/charges"
+	// coinbaseAPIVersion is the API version requests are made with.
+	coinbaseAPIVersion = "2018-03-22"
+	// coinbaseMaxName and coinbaseMaxDescription are the longest charge name and description accepted.
+	coinbaseMaxName        = 100
+	coinbaseMaxDescription = 200
+)
+
+// CoinbaseCommerce creates Coinbase Commerce charges, paid on Coinbase's hosted page from any wallet or
+// Coinbase account, and verifies the webhooks Coinbase signs with the merchant's shared secret.
+type CoinbaseCommerce struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewCoinbaseCommerce creates a new Coinbase Commerce adapter.
+func NewCoinbaseCommerce(baseURL string, client *http.Client) *CoinbaseCommerce {
+	if baseURL == "" {
+		baseURL = DefaultCoinbaseCommerceBaseURL
+	}
+	return &CoinbaseCommerce{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  client,
+	}
+}
+
+// coinbaseCharge is the body of a charge creation request.
+type coinbaseCharge struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	PricingType string            `json:"pricing_type"`
+	LocalPrice  coinbaseMoney     `json:"local_price"`
+	Metadata    map[string]string `json:"metadata"`
+	RedirectURL string            `json:"redirect_url,omitempty"`
+	CancelURL   string            `json:"cancel_url,omitempty"`
+}
+
+type coinbaseMoney struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// coinbaseChargeData is a charge as returned by the API and carried by webhooks.
+type coinbaseChargeData struct {
+	ID        string            `json:"id"`
+	Code      string            `json:"code"`
+	HostedURL string            `json:"hosted_url"`
+	ExpiresAt time.Time         `json:"expires_at"`
+	Metadata  map[string]string `json:"metadata"`
+	Payments  []coinbasePayment `json:"payments"`
+}
+
+type coinbasePayment struct {
+	Status string `json:"status"`
+	Value  struct {
+		Crypto coinbaseMoney `json:"crypto"`
+	} `json:"value"`
+}
+
+// coinbaseWebhook is the body of a webhook.
+type coinbaseWebhook struct {
+	Event struct {
+		Type string             `json:"type"`
+		Data coinbaseChargeData `json:"data"`
+	} `json:"event"`
+}
+
+// Name returns the processor name.
+func (c *CoinbaseCommerce) Name() string {
+	return processor.NameCoinbaseCommerce
+}
+
+// DisplayName returns the name shown to customers.
+func (c *CoinbaseCommerce) DisplayName() string {
+	return "Coinbase Commerce"
+}
+
+// CreateCheckout creates a fixed-price charge for the amount, with the checkout reference in its metadata.
+// Coinbase sets the expiry of charges itself.
+func (c *CoinbaseCommerce) CreateCheckout(
+	ctx context.Context,
+	credentials processor.Credentials,
+	req *processor.CheckoutRequest,
+) (*processor.HostedCheckout, error) {
+	if req == nil || !req.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: a positive amount is required", processor.ErrInvalidRequest)
+	}
+
+	name := req.Title
+	if name == "" {
+		name = "Invoice " + req.InvoiceID
+	}
+	charge := coinbaseCharge{
+		Name:        truncate(name, coinbaseMaxName),
+		Description: truncate(req.Description, coinbaseMaxDescription),
+		PricingType: "fixed_price",
+		LocalPrice:  coinbaseMoney{Amount: req.Amount.String(), Currency: req.Currency},
+		Metadata:    map[string]string{"reference": req.Reference, "invoice_id": req.InvoiceID},
+		RedirectURL: req.ReturnURL,
+		CancelURL:   req.CancelURL,
+	}
+	body, err := json.Marshal(charge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, c.baseURL+coinbaseChargesPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("X-CC-Api-Key", credentials.APIKey)
+	httpReq.Header.Set("X-CC-Version", coinbaseAPIVersion)
+
+	var response struct {
+		Data coinbaseChargeData `json:"data"`
+	}
+	if err := doJSON(c.client, httpReq, &response); err != nil {
+		return nil, err
+	}
+	return &processor.HostedCheckout{
+		ID:        response.Data.Code,
+		URL:       response.Data.HostedURL,
+		ExpiresAt: response.Data.ExpiresAt,
+	}, nil
+}
+
+// ParseEvent verifies a Coinbase Commerce webhook and returns the charge change it reports. A confirmed
+// charge is paid the sum of its confirmed payments.
+func (c *CoinbaseCommerce) ParseEvent(
+	credentials processor.Credentials,
+	headers http.Header,
+	body []byte,
+) (*processor.Event, error) {
+	signature, err := hex.DecodeString(headers.Get("X-CC-Webhook-Signature"))
+	if err != nil || !hmac.Equal(signature, signCoinbase(credentials.WebhookSecret, body)) {
+		return nil, processor.ErrInvalidSignature
+	}
+
+	var webhook coinbaseWebhook
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		return nil, fmt.Errorf("%w: %w", processor.ErrInvalidEvent, err)
+	}
+	charge := webhook.Event.Data
+	reference := charge.Metadata["reference"]
+	if reference == "" {
+		return nil, processor.ErrIgnoredEvent
+	}
+
+	event := &processor.Event{Reference: reference}
+	switch webhook.Event.Type {
+	case "charge:confirmed", "charge:resolved":
+		event.Status = processor.EventPaid
+		event.Amount = decimal.Zero
+		for _, payment := range charge.Payments {
+			if !strings.EqualFold(payment.Status, "CONFIRMED") {
+				continue
+			}
+			amount, err := decimal.NewFromString(payment.Value.Crypto.Amount)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid amount %q", processor.ErrInvalidEvent, payment.Value.Crypto.Amount)
+			}
+			if event.Currency != "" && event.Currency != payment.Value.Crypto.Currency {
+				return nil, fmt.Errorf("%w: charge was paid in several currencies", processor.ErrInvalidEvent)
+			}
+			event.Currency = payment.Value.Crypto.Currency
+			event.Amount = event.Amount.Add(amount)
+		}
+		if !event.Amount.IsPositive() {
+			return nil, fmt.Errorf("%w: confirmed charge has no confirmed payment", processor.ErrInvalidEvent)
+		}
+	case "charge:failed":
+		event.Status = processor.EventFailed
+	default:
+		event.Status = processor.EventPending
+	}
+	return event, nil
+}
+
+// signCoinbase returns the HMAC-SHA256 of a webhook body keyed with the shared webhook secret, which
+// Coinbase sends hex-encoded in X-CC-Webhook-Signature.
+func signCoinbase(secret string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
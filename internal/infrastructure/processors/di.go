@@ -0,0 +1,47 @@
+package processors
+
+import (
+	"crypto-checkout/internal/domain/processor"
+	"crypto-checkout/internal/infrastructure/resilience"
+	"crypto-checkout/pkg/config"
+	"fmt"
+	"strings"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides the registry of payment processors offered to merchants for Fx.
+var Module = fx.Module("processors",
+	fx.Provide(NewRegistryProvider),
+)
+
+// NewRegistryProvider creates the registry of the processors enabled under processors.enabled. Merchants
+// register them with their own credentials; an empty list offers none.
+func NewRegistryProvider(
+	cfg *config.Config,
+	breakers *resilience.Registry,
+	logger *zap.Logger,
+) (*processor.Registry, error) {
+	timeout := cfg.Processors.Timeout
+	if timeout <= 0 {
+		timeout = config.DefaultProcessorTimeout
+	}
+	client := breakers.Client(resilience.DependencyProcessor, timeout)
+
+	adapters := make([]processor.PaymentProcessor, 0, len(cfg.Processors.Enabled))
+	for _, name := range cfg.Processors.Enabled {
+		switch name = strings.ToLower(strings.TrimSpace(name)); name {
+		case processor.NameBinancePay:
+			adapters = append(adapters, NewBinancePay(cfg.Processors.BinancePayBaseURL, client))
+		case processor.NameCoinbaseCommerce:
+			adapters = append(adapters, NewCoinbaseCommerce(cfg.Processors.CoinbaseCommerceBaseURL, client))
+		default:
+			return nil, fmt.Errorf("unsupported payment processor: %s", name)
+		}
+	}
+
+	registry := processor.NewRegistry(adapters...)
+	logger.Info("Payment processors offered to merchants", zap.Strings("processors", registry.Names()))
+	return registry, nil
+}
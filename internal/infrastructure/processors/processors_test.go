@@ -0,0 +1,189 @@
+package processors
+
+import (
+	"context"
+	"crypto"
+	"crypto-checkout/internal/domain/processor"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCheckoutRequest() *processor.CheckoutRequest {
+	return &processor.CheckoutRequest{
+		Reference: "ref-1",
+		InvoiceID: "invoice-1",
+		Title:     "Order 42",
+		Amount:    decimal.RequireFromString("25.5"),
+		Currency:  "USDT",
+		ExpiresAt: time.UnixMilli(1_700_000_000_000),
+		ReturnURL: "https://merchant.example/thanks",
+	}
+}
+
+func TestBinancePay_CreateCheckout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, binancePayOrderPath, r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "cert-sn", r.Header.Get("BinancePay-Certificate-SN"))
+		assert.Len(t, r.Header.Get("BinancePay-Nonce"), 32)
+		assert.Equal(t, "1700000000000", r.Header.Get("BinancePay-Timestamp"))
+		assert.Equal(t,
+			SignBinancePay("secret", r.Header.Get("BinancePay-Timestamp"), r.Header.Get("BinancePay-Nonce"), body),
+			r.Header.Get("BinancePay-Signature"))
+
+		var order binancePayOrder
+		assert.NoError(t, json.Unmarshal(body, &order))
+		assert.Equal(t, "ref-1", order.MerchantTradeNo)
+		assert.Equal(t, json.Number("25.5"), order.OrderAmount)
+		assert.Equal(t, int64(1_700_000_000_000), order.OrderExpireTime)
+
+		_, _ = w.Write([]byte(`{"status":"SUCCESS","code":"000000","data":{"prepayId":"29383937",` +
+			`"expireTime":1700000000000,"qrContent":"https://qrservice.dev.com/en/qr/dplk12121112b",` +
+			`"checkoutUrl":"https://pay.binance.com/checkout/dplk12121112b"}}`))
+	}))
+	defer server.Close()
+
+	adapter := NewBinancePay(server.URL, server.Client())
+	adapter.now = func() time.Time { return time.UnixMilli(1_700_000_000_000) }
+	hosted, err := adapter.CreateCheckout(context.Background(),
+		processor.Credentials{APIKey: "cert-sn", APISecret: "secret"}, newCheckoutRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "29383937", hosted.ID)
+	assert.Equal(t, "https://pay.binance.com/checkout/dplk12121112b", hosted.URL)
+	assert.NotEmpty(t, hosted.QRContent)
+	assert.True(t, hosted.ExpiresAt.Equal(time.UnixMilli(1_700_000_000_000)))
+
+	t.Run("ReportsFailures", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"status":"FAIL","code":"400201","errorMessage":"merchantTradeNo is invalid"}`))
+		}))
+		defer failing.Close()
+
+		_, err := NewBinancePay(failing.URL, failing.Client()).CreateCheckout(context.Background(),
+			processor.Credentials{APIKey: "cert-sn", APISecret: "secret"}, newCheckoutRequest())
+		require.ErrorIs(t, err, processor.ErrProcessorFailure)
+		assert.Contains(t, err.Error(), "merchantTradeNo is invalid")
+	})
+}
+
+func TestBinancePay_ParseEvent(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	credentials := processor.Credentials{
+		APIKey:        "cert-sn",
+		WebhookSecret: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	}
+	signed := func(t *testing.T, body string) http.Header {
+		t.Helper()
+		digest := sha256.Sum256([]byte(binancePayPayload("1700000000000", "nonce", []byte(body))))
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+		return http.Header{
+			"Binancepay-Timestamp": []string{"1700000000000"},
+			"Binancepay-Nonce":     []string{"nonce"},
+			"Binancepay-Signature": []string{base64.StdEncoding.EncodeToString(signature)},
+		}
+	}
+	adapter := NewBinancePay("", nil)
+
+	paid := `{"bizType":"PAY","bizStatus":"PAY_SUCCESS","data":"{\"merchantTradeNo\":\"ref-1\",` +
+		`\"totalFee\":25.5,\"currency\":\"USDT\",\"openUserId\":\"user-9\"}"}`
+	event, err := adapter.ParseEvent(credentials, signed(t, paid), []byte(paid))
+	require.NoError(t, err)
+	assert.Equal(t, processor.EventPaid, event.Status)
+	assert.Equal(t, "ref-1", event.Reference)
+	assert.True(t, decimal.RequireFromString("25.5").Equal(event.Amount))
+	assert.Equal(t, "USDT", event.Currency)
+	assert.Equal(t, "user-9", event.Payer)
+
+	closed := `{"bizType":"PAY","bizStatus":"PAY_CLOSED","data":"{\"merchantTradeNo\":\"ref-1\"}"}`
+	event, err = adapter.ParseEvent(credentials, signed(t, closed), []byte(closed))
+	require.NoError(t, err)
+	assert.Equal(t, processor.EventExpired, event.Status)
+
+	refund := `{"bizType":"PAY_REFUND","bizStatus":"REFUND_SUCCESS","data":"{}"}`
+	_, err = adapter.ParseEvent(credentials, signed(t, refund), []byte(refund))
+	require.ErrorIs(t, err, processor.ErrIgnoredEvent)
+
+	tampered := `{"bizType":"PAY","bizStatus":"PAY_SUCCESS","data":"{\"merchantTradeNo\":\"ref-2\"}"}`
+	_, err = adapter.ParseEvent(credentials, signed(t, paid), []byte(tampered))
+	require.ErrorIs(t, err, processor.ErrInvalidSignature)
+}
+
+func TestCoinbaseCommerce_CreateCheckout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, coinbaseChargesPath, r.URL.Path)
+		assert.Equal(t, "api-key", r.Header.Get("X-CC-Api-Key"))
+		assert.Equal(t, coinbaseAPIVersion, r.Header.Get("X-CC-Version"))
+
+		var charge coinbaseCharge
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&charge))
+		assert.Equal(t, "Order 42", charge.Name)
+		assert.Equal(t, coinbaseMoney{Amount: "25.5", Currency: "USDT"}, charge.LocalPrice)
+		assert.Equal(t, "ref-1", charge.Metadata["reference"])
+		assert.Equal(t, "https://merchant.example/thanks", charge.RedirectURL)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data":{"id":"f765421f","code":"66BEOV2A",` +
+			`"hosted_url":"https://commerce.coinbase.com/charges/66BEOV2A","expires_at":"2026-01-02T04:00:00Z"}}`))
+	}))
+	defer server.Close()
+
+	hosted, err := NewCoinbaseCommerce(server.URL, server.Client()).CreateCheckout(context.Background(),
+		processor.Credentials{APIKey: "api-key"}, newCheckoutRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "66BEOV2A", hosted.ID)
+	assert.Equal(t, "https://commerce.coinbase.com/charges/66BEOV2A", hosted.URL)
+	assert.True(t, hosted.ExpiresAt.Equal(time.Date(2026, 1, 2, 4, 0, 0, 0, time.UTC)))
+}
+
+func TestCoinbaseCommerce_ParseEvent(t *testing.T) {
+	credentials := processor.Credentials{APIKey: "api-key", WebhookSecret: "shared-secret"}
+	signed := func(body string) http.Header {
+		return http.Header{
+			"X-Cc-Webhook-Signature": []string{hex.EncodeToString(signCoinbase("shared-secret", []byte(body)))},
+		}
+	}
+	adapter := NewCoinbaseCommerce("", nil)
+
+	confirmed := `{"event":{"type":"charge:confirmed","data":{"code":"66BEOV2A","metadata":{"reference":"ref-1"},` +
+		`"payments":[{"status":"CONFIRMED","value":{"crypto":{"amount":"20.5","currency":"USDT"}}},` +
+		`{"status":"CONFIRMED","value":{"crypto":{"amount":"5","currency":"USDT"}}},` +
+		`{"status":"PENDING","value":{"crypto":{"amount":"1","currency":"USDT"}}}]}}}`
+	event, err := adapter.ParseEvent(credentials, signed(confirmed), []byte(confirmed))
+	require.NoError(t, err)
+	assert.Equal(t, processor.EventPaid, event.Status)
+	assert.Equal(t, "ref-1", event.Reference)
+	assert.True(t, decimal.RequireFromString("25.5").Equal(event.Amount))
+	assert.Equal(t, "USDT", event.Currency)
+
+	failed := `{"event":{"type":"charge:failed","data":{"metadata":{"reference":"ref-1"}}}}`
+	event, err = adapter.ParseEvent(credentials, signed(failed), []byte(failed))
+	require.NoError(t, err)
+	assert.Equal(t, processor.EventFailed, event.Status)
+
+	foreign := `{"event":{"type":"charge:confirmed","data":{"metadata":{}}}}`
+	_, err = adapter.ParseEvent(credentials, signed(foreign), []byte(foreign))
+	require.ErrorIs(t, err, processor.ErrIgnoredEvent)
+
+	_, err = adapter.ParseEvent(credentials, signed(failed), []byte(confirmed))
+	require.ErrorIs(t, err, processor.ErrInvalidSignature)
+}
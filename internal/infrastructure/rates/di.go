@@ -0,0 +1,94 @@
+package rates
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/platform"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/pkg/config"
+	"fmt"
+	"slices"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/fx"
+)
+
+// Module provides the exchange rate provider, re-quote policy and the registry of tokens invoices are
+// quoted in for Fx.
+var Module = fx.Module("rates",
+	fx.Provide(
+		fx.Annotate(
+			NewRateProvider,
+			fx.As(new(invoice.RateProvider)),
+			fx.As(new(platform.RateMonitor)),
+		),
+		NewRequotePolicyProvider,
+		NewTokenRegistryProvider,
+	),
+	fx.Invoke(RegisterTokens),
+)
+
+// NewRateProvider creates the exchange rate provider from configuration, monitored for the status.
+func NewRateProvider(cfg *config.Config) *MonitoredRateProvider {
+	lockDuration := cfg.Rates.LockDuration
+	if lockDuration <= 0 {
+		lockDuration = config.DefaultRateLockDuration
+	}
+	return NewMonitoredRateProvider(NewFixedRateProvider("1.0", lockDuration))
+}
+
+// NewRequotePolicyProvider creates the re-quote policy from configuration.
+func NewRequotePolicyProvider(cfg *config.Config) (invoice.RequotePolicy, error) {
+	policy := invoice.DefaultRequotePolicy()
+	if cfg.Rates.RequoteInterval > 0 {
+		policy.Interval = cfg.Rates.RequoteInterval
+	}
+	if cfg.Rates.StalePayments != "" {
+		policy.StalePayments = invoice.StaleRateAction(cfg.Rates.StalePayments)
+		if !policy.StalePayments.IsValid() {
+			return invoice.RequotePolicy{}, fmt.Errorf("invalid rates.stale_payments: %q", cfg.Rates.StalePayments)
+		}
+	}
+	if cfg.Rates.MaxSlippage == "" {
+		return policy, nil
+	}
+
+	maxSlippage, err := decimal.NewFromString(cfg.Rates.MaxSlippage)
+	if err != nil || maxSlippage.IsNegative() {
+		return invoice.RequotePolicy{}, fmt.Errorf("invalid rates.max_slippage: %q", cfg.Rates.MaxSlippage)
+	}
+	policy.MaxSlippage = maxSlippage
+	return policy, nil
+}
+
+// NewTokenRegistryProvider creates the registry of tokens invoices may be paid in from configuration,
+// falling back to the default tokens when none are configured. Merchants in test mode are paid in the default
+// testnet tokens unless testnet tokens are configured.
+func NewTokenRegistryProvider(cfg *config.Config) (*shared.TokenRegistry, error) {
+	if len(cfg.Tokens) == 0 {
+		return shared.DefaultTokenRegistry(), nil
+	}
+
+	tokens := make([]shared.Token, len(cfg.Tokens))
+	for i, token := range cfg.Tokens {
+		tokens[i] = shared.Token{
+			Symbol:    shared.CryptoCurrency(token.Symbol),
+			Network:   shared.BlockchainNetwork(token.Network),
+			Contract:  token.Contract,
+			Decimals:  token.Decimals,
+			Merchants: token.Merchants,
+		}
+	}
+	if !slices.ContainsFunc(tokens, func(token shared.Token) bool { return token.Network.IsTestnet() }) {
+		tokens = append(tokens, shared.DefaultTestnetTokens()...)
+	}
+
+	registry, err := shared.NewTokenRegistry(tokens)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tokens: %w", err)
+	}
+	return registry, nil
+}
+
+// RegisterTokens creates the token registry at startup, so the configured symbols are valid cryptocurrencies
+// before any stored invoice is read.
+func RegisterTokens(*shared.TokenRegistry) {}
@@ -0,0 +1,34 @@
+// Package rates provides the exchange rates locked on invoices.
+package rates
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"time"
+)
+
+// FixedRateSource is the source reported for rates quoted by FixedRateProvider.
+const FixedRateSource = "mock_provider"
+
+// FixedRateProvider quotes the same rate for every currency pair until a market data feed is integrated.
+type FixedRateProvider struct {
+	rate         string
+	lockDuration time.Duration
+}
+
+// NewFixedRateProvider creates a provider that quotes rate, locked for lockDuration.
+func NewFixedRateProvider(rate string, lockDuration time.Duration) *FixedRateProvider {
+	return &FixedRateProvider{
+		rate:         rate,
+		lockDuration: lockDuration,
+	}
+}
+
+// GetRate returns the fixed rate from one currency to a cryptocurrency, locked from now.
+func (p *FixedRateProvider) GetRate(
+	_ context.Context,
+	from shared.Currency,
+	to shared.CryptoCurrency,
+) (*shared.ExchangeRate, error) {
+	return shared.NewExchangeRate(p.rate, from, to, FixedRateSource, p.lockDuration)
+}
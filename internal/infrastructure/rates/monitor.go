@@ -0,0 +1,75 @@
+package rates
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/platform"
+	"crypto-checkout/internal/domain/shared"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MonitoredRateProvider records the latest quotes of each currency pair of the provider it wraps, so that
+// the status reports how fresh the rates are.
+type MonitoredRateProvider struct {
+	provider invoice.RateProvider
+	now      func() time.Time
+
+	mu     sync.RWMutex
+	quotes map[string]platform.RateQuote
+}
+
+// NewMonitoredRateProvider creates a provider recording the quotes of another.
+func NewMonitoredRateProvider(provider invoice.RateProvider) *MonitoredRateProvider {
+	return &MonitoredRateProvider{
+		provider: provider,
+		now:      shared.Now,
+		quotes:   make(map[string]platform.RateQuote),
+	}
+}
+
+// GetRate quotes the rate with the wrapped provider and records the outcome.
+func (p *MonitoredRateProvider) GetRate(
+	ctx context.Context,
+	from shared.Currency,
+	to shared.CryptoCurrency,
+) (*shared.ExchangeRate, error) {
+	rate, err := p.provider.GetRate(ctx, from, to)
+	now := p.now().UTC()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := string(from) + "/" + string(to)
+	quote := p.quotes[key]
+	quote.From, quote.To = from, to
+	if err != nil {
+		quote.FailedAt = &now
+		quote.Error = err.Error()
+	} else {
+		quote.Source = rate.Source()
+		quote.QuotedAt = now
+		quote.FailedAt = nil
+		quote.Error = ""
+	}
+	p.quotes[key] = quote
+	return rate, err
+}
+
+// Quotes returns the latest quotes of each currency pair, ordered by pair.
+func (p *MonitoredRateProvider) Quotes() []platform.RateQuote {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	quotes := make([]platform.RateQuote, 0, len(p.quotes))
+	for _, quote := range p.quotes {
+		quotes = append(quotes, quote)
+	}
+	sort.Slice(quotes, func(i, j int) bool {
+		if quotes[i].From != quotes[j].From {
+			return quotes[i].From < quotes[j].From
+		}
+		return quotes[i].To < quotes[j].To
+	})
+	return quotes
+}
@@ -0,0 +1,237 @@
+// Package resilience guards outbound calls with circuit breakers and bulkheads, so that a hanging or failing
+// dependency fails calls fast instead of tying up the goroutines that make them.
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Breaker states.
+const (
+	// StateClosed - Calls go through and failures are counted
+	StateClosed = "closed"
+	// StateOpen - Calls are rejected until the open timeout passes
+	StateOpen = "open"
+	// StateHalfOpen - A single trial call decides whether the breaker closes or opens again
+	StateHalfOpen = "half_open"
+)
+
+// Errors returned for calls a breaker rejects without making them.
+var (
+	ErrCircuitOpen  = errors.New("circuit breaker is open")
+	ErrBulkheadFull = errors.New("too many concurrent calls")
+)
+
+// Policy configures a breaker.
+type Policy struct {
+	// FailureThreshold is how many consecutive failed calls open the breaker.
+	FailureThreshold int
+	// OpenTimeout is how long an open breaker rejects calls before it lets a trial call through.
+	OpenTimeout time.Duration
+	// MaxConcurrent bounds the calls in flight; calls beyond it are rejected rather than queued.
+	MaxConcurrent int
+}
+
+// State is a snapshot of a breaker.
+type State struct {
+	Name                string     `json:"name"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	InFlight            int        `json:"in_flight"`
+	Rejected            int64      `json:"rejected"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+}
+
+// Breaker is the circuit breaker and bulkhead of one outbound dependency. It opens after the configured
+// number of consecutive failures and rejects calls until the open timeout passes; then a single trial
+// call closes it again on success or reopens it on failure.
+type Breaker struct {
+	name   string
+	policy Policy
+	slots  chan struct{}
+	now    func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	trial    bool
+	rejected int64
+}
+
+// NewBreaker creates a closed breaker. Without a maximum, concurrent calls are not bounded.
+func NewBreaker(name string, policy Policy) *Breaker {
+	var slots chan struct{}
+	if policy.MaxConcurrent > 0 {
+		slots = make(chan struct{}, policy.MaxConcurrent)
+	}
+
+	return &Breaker{
+		name:   name,
+		policy: policy,
+		slots:  slots,
+		now:    time.Now,
+		state:  StateClosed,
+	}
+}
+
+// Name returns the name of the dependency the breaker guards.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// Execute calls fn unless the breaker is open or the dependency already has the maximum number of calls in
+// flight, and records whether it failed. Errors from a context the caller cancelled, or whose deadline passed, are
+// not held against the dependency; calls outlasting the dependency's own timeout are.
+func (b *Breaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	release, err := b.acquire()
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	err = fn(ctx)
+	if err != nil && ctx.Err() != nil {
+		b.abandon()
+		return err
+	}
+	b.record(err == nil)
+	return err
+}
+
+// State returns a snapshot of the breaker.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state := State{
+		Name:                b.name,
+		State:               b.currentState(),
+		ConsecutiveFailures: b.failures,
+		InFlight:            len(b.slots),
+		Rejected:            b.rejected,
+	}
+	if state.State != StateClosed {
+		openedAt := b.openedAt
+		state.OpenedAt = &openedAt
+	}
+	return state
+}
+
+// Transport wraps an HTTP transport so that requests go through the breaker. Server errors and
+// 429 Too Many Requests count as failures, like transport errors.
+func (b *Breaker) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{breaker: b, base: base}
+}
+
+// acquire takes a bulkhead slot and admits the call through the breaker. The returned function
+// releases the slot.
+func (b *Breaker) acquire() (func(), error) {
+	release := func() {}
+	if b.slots != nil {
+		select {
+		case b.slots <- struct{}{}:
+		default:
+			b.reject()
+			return nil, fmt.Errorf("%w: %s", ErrBulkheadFull, b.name)
+		}
+		release = func() { <-b.slots }
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.currentState() {
+	case StateOpen:
+		b.rejected++
+		release()
+		return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, b.name)
+	case StateHalfOpen:
+		if b.trial {
+			b.rejected++
+			release()
+			return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, b.name)
+		}
+		b.trial = true
+	}
+	return release, nil
+}
+
+// record updates the breaker with the outcome of an admitted call.
+func (b *Breaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	halfOpen := b.currentState() == StateHalfOpen
+	b.trial = false
+	if success {
+		b.state = StateClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if halfOpen || b.failures >= b.policy.FailureThreshold {
+		b.state = StateOpen
+		b.openedAt = b.now()
+	}
+}
+
+// abandon releases the trial of a half-open breaker without an outcome, for a call the caller cancelled.
+func (b *Breaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+// reject counts a call rejected by the bulkhead.
+func (b *Breaker) reject() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rejected++
+}
+
+// currentState returns the state, moving an open breaker whose timeout passed to half-open.
+// The caller holds the lock.
+func (b *Breaker) currentState() string {
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.policy.OpenTimeout {
+		b.state = StateHalfOpen
+	}
+	return b.state
+}
+
+// transport is an HTTP transport guarded by a breaker.
+type transport struct {
+	breaker *Breaker
+	base    http.RoundTripper
+}
+
+// RoundTrip sends the request through the breaker.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var resp *http.Response
+	err := t.breaker.Execute(req.Context(), func(context.Context) error {
+		var err error
+		resp, err = t.base.RoundTrip(req)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+			return errServerFailure
+		}
+		return nil
+	})
+	if errors.Is(err, errServerFailure) {
+		return resp, nil
+	}
+	return resp, err
+}
+
+// errServerFailure marks a response the breaker counts as a failure but the caller still receives.
+var errServerFailure = errors.New("server failure")
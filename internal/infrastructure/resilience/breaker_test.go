@@ -0,0 +1,207 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var errUnavailable = errors.New("provider unavailable")
+
+func fail(context.Context) error    { return errUnavailable }
+func succeed(context.Context) error { return nil }
+
+func TestBreaker_OpensAfterThreshold(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	breaker := NewBreaker(DependencyExchange, Policy{FailureThreshold: 3, OpenTimeout: time.Minute})
+	breaker.now = func() time.Time { return now }
+
+	for range 2 {
+		require.ErrorIs(t, breaker.Execute(ctx, fail), errUnavailable)
+	}
+	assert.Equal(t, StateClosed, breaker.State().State)
+	require.NoError(t, breaker.Execute(ctx, succeed))
+	assert.Equal(t, 0, breaker.State().ConsecutiveFailures)
+
+	for range 3 {
+		require.ErrorIs(t, breaker.Execute(ctx, fail), errUnavailable)
+	}
+	state := breaker.State()
+	assert.Equal(t, StateOpen, state.State)
+	require.NotNil(t, state.OpenedAt)
+
+	called := false
+	err := breaker.Execute(ctx, func(context.Context) error {
+		called = true
+		return nil
+	})
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	assert.False(t, called)
+	assert.Equal(t, int64(1), breaker.State().Rejected)
+
+	t.Run("failed trial reopens", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		assert.Equal(t, StateHalfOpen, breaker.State().State)
+		require.ErrorIs(t, breaker.Execute(ctx, fail), errUnavailable)
+		assert.Equal(t, StateOpen, breaker.State().State)
+		require.ErrorIs(t, breaker.Execute(ctx, succeed), ErrCircuitOpen)
+	})
+
+	t.Run("successful trial closes", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		require.NoError(t, breaker.Execute(ctx, succeed))
+		state := breaker.State()
+		assert.Equal(t, StateClosed, state.State)
+		assert.Equal(t, 0, state.ConsecutiveFailures)
+		assert.Nil(t, state.OpenedAt)
+	})
+}
+
+func TestBreaker_HalfOpenAllowsSingleTrial(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	breaker := NewBreaker(DependencyScreening, Policy{FailureThreshold: 1, OpenTimeout: time.Second})
+	breaker.now = func() time.Time { return now }
+
+	require.ErrorIs(t, breaker.Execute(ctx, fail), errUnavailable)
+	now = now.Add(time.Second)
+
+	err := breaker.Execute(ctx, func(ctx context.Context) error {
+		return breaker.Execute(ctx, succeed)
+	})
+	require.ErrorIs(t, err, ErrCircuitOpen)
+}
+
+func TestBreaker_CancelledCallsAreNotCounted(t *testing.T) {
+	breaker := NewBreaker(DependencyHotWallet, Policy{FailureThreshold: 1, OpenTimeout: time.Minute})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := breaker.Execute(ctx, func(ctx context.Context) error { return ctx.Err() })
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, StateClosed, breaker.State().State)
+	assert.Equal(t, 0, breaker.State().ConsecutiveFailures)
+}
+
+func TestBreaker_Bulkhead(t *testing.T) {
+	ctx := context.Background()
+	breaker := NewBreaker(DependencyFeeOracleTron, Policy{FailureThreshold: 5, OpenTimeout: time.Minute,
+		MaxConcurrent: 1})
+
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- breaker.Execute(ctx, func(context.Context) error {
+			close(started)
+			<-finish
+			return nil
+		})
+	}()
+	<-started
+
+	assert.Equal(t, 1, breaker.State().InFlight)
+	require.ErrorIs(t, breaker.Execute(ctx, succeed), ErrBulkheadFull)
+	assert.Equal(t, int64(1), breaker.State().Rejected)
+	assert.Equal(t, StateClosed, breaker.State().State)
+
+	close(finish)
+	require.NoError(t, <-done)
+	assert.Equal(t, 0, breaker.State().InFlight)
+	require.NoError(t, breaker.Execute(ctx, succeed))
+}
+
+func TestRegistry_Client(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	registry := NewRegistry(
+		Policy{FailureThreshold: 5, OpenTimeout: time.Minute},
+		map[string]Policy{DependencyExchange: {FailureThreshold: 2}},
+		zap.NewNop(),
+	)
+	client := registry.Client(DependencyExchange, time.Second)
+
+	for range 2 {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err, "server errors are returned to the caller")
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	status.Store(http.StatusOK)
+	_, err := client.Get(server.URL)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(2), requests.Load())
+
+	registry.Breaker(DependencyFeeOracleBitcoin)
+	states := registry.States()
+	require.Len(t, states, 2)
+	assert.Equal(t, DependencyExchange, states[0].Name)
+	assert.Equal(t, StateOpen, states[0].State)
+	assert.Equal(t, DependencyFeeOracleBitcoin, states[1].Name)
+	assert.Equal(t, StateClosed, states[1].State)
+	assert.Same(t, registry.Breaker(DependencyExchange), registry.Breaker(DependencyExchange))
+}
+
+func TestRegistry_ClientDeadlines(t *testing.T) {
+	abandoned := make(chan struct{}, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			abandoned <- struct{}{}
+		case <-time.After(5 * time.Second):
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	get := func(ctx context.Context, client *http.Client) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+	registry := NewRegistry(Policy{FailureThreshold: 1, OpenTimeout: time.Minute}, nil, zap.NewNop())
+
+	t.Run("the caller's deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		started := time.Now()
+		err := get(ctx, registry.Client(DependencyExchange, 5*time.Second))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(started), time.Second)
+		select {
+		case <-abandoned:
+		case <-time.After(time.Second):
+			t.Fatal("the request was not cancelled on the server")
+		}
+		assert.Equal(t, StateClosed, registry.Breaker(DependencyExchange).State().State,
+			"the caller's deadline is not held against the dependency")
+	})
+
+	t.Run("the dependency's timeout", func(t *testing.T) {
+		err := get(context.Background(), registry.Client(DependencyScreening, 50*time.Millisecond))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, StateOpen, registry.Breaker(DependencyScreening).State().State)
+	})
+}
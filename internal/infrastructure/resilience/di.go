@@ -0,0 +1,53 @@
+package resilience
+
+import (
+	"crypto-checkout/pkg/config"
+	"fmt"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides the circuit breaker registry for outbound calls to Fx.
+var Module = fx.Module("resilience",
+	fx.Provide(
+		NewRegistryProvider,
+	),
+)
+
+// NewRegistryProvider creates the breaker registry from configuration.
+func NewRegistryProvider(cfg *config.Config, logger *zap.Logger) (*Registry, error) {
+	defaults, err := policyFromConfig("defaults", cfg.Resilience.Defaults)
+	if err != nil {
+		return nil, err
+	}
+	if defaults.FailureThreshold == 0 {
+		defaults.FailureThreshold = config.DefaultBreakerFailureThreshold
+	}
+	if defaults.OpenTimeout == 0 {
+		defaults.OpenTimeout = config.DefaultBreakerOpenTimeout
+	}
+	if defaults.MaxConcurrent == 0 {
+		defaults.MaxConcurrent = config.DefaultBreakerMaxConcurrent
+	}
+
+	policies := make(map[string]Policy, len(cfg.Resilience.Dependencies))
+	for name, dependency := range cfg.Resilience.Dependencies {
+		if policies[name], err = policyFromConfig("dependencies."+name, dependency); err != nil {
+			return nil, err
+		}
+	}
+	return NewRegistry(defaults, policies, logger), nil
+}
+
+// policyFromConfig converts a breaker configuration, rejecting negative values.
+func policyFromConfig(key string, cfg config.BreakerConfig) (Policy, error) {
+	if cfg.FailureThreshold < 0 || cfg.OpenTimeout < 0 || cfg.MaxConcurrent < 0 {
+		return Policy{}, fmt.Errorf("invalid resilience.%s: values must not be negative", key)
+	}
+	return Policy{
+		FailureThreshold: cfg.FailureThreshold,
+		OpenTimeout:      cfg.OpenTimeout,
+		MaxConcurrent:    cfg.MaxConcurrent,
+	}, nil
+}
@@ -0,0 +1,151 @@
+package resilience
+
+import (
+	"context"
+	"expvar"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Names of the guarded outbound dependencies, as used under resilience.dependencies.
+const (
+	DependencyExchange          = "exchange"
+	DependencyScreening         = "screening"
+	DependencySanctionsList     = "sanctions_list"
+	DependencyHotWallet         = "hot_wallet"
+	DependencyProcessor         = "payment_processor"
+	DependencyFeeOracleTron     = "fee_oracle_tron"
+	DependencyFeeOracleEthereum = "fee_oracle_ethereum"
+	DependencyFeeOracleBitcoin  = "fee_oracle_bitcoin"
+
+	DependencyChainScannerTron            = "chain_scanner_tron"
+	DependencyChainScannerEthereum        = "chain_scanner_ethereum"
+	DependencyChainScannerBSC             = "chain_scanner_bsc"
+	DependencyChainScannerTronShasta      = "chain_scanner_tron_shasta"
+	DependencyChainScannerTronNile        = "chain_scanner_tron_nile"
+	DependencyChainScannerEthereumSepolia = "chain_scanner_ethereum_sepolia"
+)
+
+// breakerMetrics publishes the state of every breaker under "circuit_breakers" on /debug/vars.
+var breakerMetrics = expvar.NewMap("circuit_breakers")
+
+// Registry holds a breaker per outbound dependency, created on first use from the configured policies.
+type Registry struct {
+	defaults Policy
+	policies map[string]Policy
+	logger   *zap.Logger
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+// NewRegistry creates a registry. Dependencies without their own policy use the defaults, as do the
+// fields a dependency policy leaves zero.
+func NewRegistry(defaults Policy, policies map[string]Policy, logger *zap.Logger) *Registry {
+	return &Registry{
+		defaults: defaults,
+		policies: policies,
+		logger:   logger,
+		breakers: make(map[string]*Breaker),
+	}
+}
+
+// Breaker returns the breaker of a dependency.
+func (r *Registry) Breaker(name string) *Breaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if breaker, ok := r.breakers[name]; ok {
+		return breaker
+	}
+
+	policy := r.defaults
+	if override, ok := r.policies[name]; ok {
+		if override.FailureThreshold > 0 {
+			policy.FailureThreshold = override.FailureThreshold
+		}
+		if override.OpenTimeout > 0 {
+			policy.OpenTimeout = override.OpenTimeout
+		}
+		if override.MaxConcurrent > 0 {
+			policy.MaxConcurrent = override.MaxConcurrent
+		}
+	}
+
+	breaker := NewBreaker(name, policy)
+	r.breakers[name] = breaker
+	breakerMetrics.Set(name, expvar.Func(func() any { return breaker.State() }))
+	r.logger.Debug("Created circuit breaker",
+		zap.String("dependency", name),
+		zap.Int("failure_threshold", policy.FailureThreshold),
+		zap.Duration("open_timeout", policy.OpenTimeout),
+		zap.Int("max_concurrent", policy.MaxConcurrent))
+	return breaker
+}
+
+// Client returns an HTTP client for a dependency whose requests go through its breaker and are bounded
+// by the timeout, within the deadline of the request's context. A request outlasting the timeout counts
+// against the dependency; one whose context the caller cancelled, or whose deadline passed first, does not.
+func (r *Registry) Client(name string, timeout time.Duration) *http.Client {
+	var base http.RoundTripper = http.DefaultTransport
+	if timeout > 0 {
+		base = &timeoutTransport{base: base, timeout: timeout}
+	}
+	return &http.Client{Transport: r.Breaker(name).Transport(base)}
+}
+
+// timeoutTransport bounds requests by a timeout covering the reading of the response body, as
+// http.Client.Timeout does, but beneath the breaker, so that it tells the dependency's timeouts from the caller's.
+type timeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+// RoundTrip sends the request with the timeout, released once the response body is closed.
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose is a response body releasing the timeout of its request when closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and releases the timeout.
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// States returns a snapshot of every breaker, ordered by dependency name.
+func (r *Registry) States() []State {
+	r.mu.Lock()
+	breakers := make([]*Breaker, 0, len(r.breakers))
+	for _, breaker := range r.breakers {
+		breakers = append(breakers, breaker)
+	}
+	r.mu.Unlock()
+
+	states := make([]State, len(breakers))
+	for i, breaker := range breakers {
+		states[i] = breaker.State()
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Name < states[j].Name
+	})
+	return states
+}
@@ -0,0 +1,77 @@
+// Package rulecall delivers the calls of merchants' automation rules to their URLs over HTTP.
+package rulecall
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/automation"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DeliveryHeader carries the delivery ID, the same for every attempt to deliver a rule's call for an event.
+	DeliveryHeader = "X-Rule-Delivery"
+	// TimestampHeader carries the Unix time, in seconds, at which a call was signed.
+	TimestampHeader = "X-Rule-Timestamp"
+	// SignatureHeader carries the call signature; see Sign.
+	SignatureHeader = "X-Rule-Signature"
+
+	// maxErrorBody limits how much of a rejected call's response is included in errors.
+	maxErrorBody = 512
+)
+
+// HTTPCaller posts the calls of call_url actions, signed with the secret of their rule.
+type HTTPCaller struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewHTTPCaller creates a caller sending with the client.
+func NewHTTPCaller(client *http.Client) *HTTPCaller {
+	return &HTTPCaller{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Call implements automation.Caller.
+func (c *HTTPCaller) Call(ctx context.Context, call *automation.Call) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, call.URL, bytes.NewReader(call.Body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	timestamp := strconv.FormatInt(c.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(DeliveryHeader, call.DeliveryID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(call.Secret, timestamp, call.Body))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", call.URL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return fmt.Errorf("%s returned %d: %s", call.URL, resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	return nil
+}
+
+// Sign returns the signature sent in SignatureHeader: the hex HMAC-SHA256, keyed with the rule's secret, of the
+// timestamp, a dot and the body.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
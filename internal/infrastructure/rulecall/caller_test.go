@@ -0,0 +1,63 @@
+package rulecall
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/automation"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPCaller_Call(t *testing.T) {
+	body := []byte(`{"rule_id":"rule-1"}`)
+
+	t.Run("SignsTheCall", func(t *testing.T) {
+		var received *http.Request
+		var receivedBody []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			receivedBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		caller := NewHTTPCaller(server.Client())
+		caller.now = func() time.Time { return time.Unix(1700000000, 0) }
+		err := caller.Call(context.Background(), &automation.Call{
+			URL: server.URL, Secret: "secret", DeliveryID: "delivery-1", Body: body,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.MethodPost, received.Method)
+		assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+		assert.Equal(t, "delivery-1", received.Header.Get(DeliveryHeader))
+		assert.Equal(t, "1700000000", received.Header.Get(TimestampHeader))
+		assert.Equal(t, Sign("secret", "1700000000", body), received.Header.Get(SignatureHeader))
+		assert.Equal(t, body, receivedBody)
+	})
+
+	t.Run("RejectedCallFails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "maintenance", http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		err := NewHTTPCaller(server.Client()).Call(context.Background(), &automation.Call{
+			URL: server.URL, Secret: "secret", Body: body,
+		})
+		require.ErrorContains(t, err, "returned 503: maintenance")
+	})
+}
+
+func TestSign(t *testing.T) {
+	signature := Sign("secret", "1700000000", []byte("{}"))
+	assert.Len(t, signature, 64)
+	assert.Equal(t, signature, Sign("secret", "1700000000", []byte("{}")))
+	assert.NotEqual(t, signature, Sign("other", "1700000000", []byte("{}")))
+	assert.NotEqual(t, signature, Sign("secret", "1700000001", []byte("{}")))
+}
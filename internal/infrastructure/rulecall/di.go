@@ -0,0 +1,28 @@
+package rulecall
+
+import (
+	"crypto-checkout/internal/domain/automation"
+	"net/http"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// callTimeout bounds a call to a merchant's URL, so that a slow receiver does not hold up event handling.
+const callTimeout = 10 * time.Second
+
+// Module provides the caller of automation rules' URLs for Fx.
+var Module = fx.Module("rulecall",
+	fx.Provide(NewCallerProvider),
+)
+
+// NewCallerProvider creates the caller of automation rules' URLs. Calls are not redirected: they only go to the
+// URL the merchant configured.
+func NewCallerProvider() automation.Caller {
+	return NewHTTPCaller(&http.Client{
+		Timeout: callTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	})
+}
@@ -0,0 +1,87 @@
+package runtimeconfig
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/pkg/config"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// reloadTimeout bounds a reload triggered by SIGHUP.
+const reloadTimeout = 30 * time.Second
+
+// Module provides the runtime settings store to Fx and reloads it on SIGHUP.
+var Module = fx.Module("runtimeconfig",
+	fx.Provide(
+		fx.Annotate(
+			NewStoreProvider,
+			fx.As(fx.Self()),
+			fx.As(new(payment.ConfirmationPolicySource)),
+			fx.As(new(merchant.WebhookRetryPolicy)),
+			fx.As(new(settlement.FeeScheduleSource)),
+		),
+	),
+	fx.Invoke(RegisterReloadSignal),
+)
+
+// NewStoreProvider creates the store from the configuration the application started with. Reloads read the
+// config file and environment again.
+func NewStoreProvider(cfg *config.Config, auditService audit.Service, logger *zap.Logger) (*Store, error) {
+	return NewStore(cfg, config.Load, auditService, logger)
+}
+
+// RegisterReloadSignal reloads the runtime settings whenever the process receives SIGHUP, for the lifetime
+// of the application.
+func RegisterReloadSignal(lc fx.Lifecycle, store *Store, logger *zap.Logger) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			signal.Notify(signals, syscall.SIGHUP)
+			go func() {
+				defer close(stopped)
+				for {
+					select {
+					case <-done:
+						return
+					case <-signals:
+						reloadOnSignal(store, logger)
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			signal.Stop(signals)
+			close(done)
+			select {
+			case <-stopped:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
+
+// reloadOnSignal performs a reload triggered by SIGHUP.
+func reloadOnSignal(store *Store, logger *zap.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), reloadTimeout)
+	defer cancel()
+
+	logger.Info("Received SIGHUP, reloading runtime settings")
+	actor := audit.Actor{ID: "system", Type: audit.ActorTypeSystem}
+	if _, err := store.Reload(ctx, TriggerSignal, actor); err != nil {
+		logger.Error("Failed to reload runtime settings, keeping the current ones", zap.Error(err))
+	}
+}
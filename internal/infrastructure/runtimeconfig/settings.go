@@ -0,0 +1,294 @@
+// Package runtimeconfig holds the settings that can change while the application runs — rate limits,
+// confirmation rules, the webhook retry policy, feature flags and platform fee schedules — and reloads them from
+// the config file and environment without restarting the application.
+package runtimeconfig
+
+import (
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/pkg/config"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Feature flags, switched with runtime.features.
+const (
+	// FeatureGraphQL serves the GraphQL endpoint when graphql.enabled registered it
+	FeatureGraphQL = "graphql"
+)
+
+// features lists the known feature flags.
+var features = map[string]bool{
+	FeatureGraphQL: true,
+}
+
+// maxWebhookRetries is the most retries a webhook endpoint may have.
+const maxWebhookRetries = 10
+
+// ErrInvalidSettings is returned for runtime settings that fail validation.
+var ErrInvalidSettings = errors.New("invalid runtime settings")
+
+// Settings are validated runtime settings. They are replaced as a whole on reload and never modified.
+type Settings struct {
+	RateLimits        config.RateLimitsConfig
+	Confirmations     payment.ConfirmationPolicy
+	WebhookMaxRetries int
+	WebhookBackoff    merchant.BackoffStrategy
+	Features          map[string]bool
+	FeeSchedules      map[string]*settlement.FeeSchedule
+
+	merchantFeeSchedules map[string]string // Schedule name by merchant ID
+	source               config.RuntimeConfig
+}
+
+// Parse validates runtime settings, reporting every invalid value at once.
+func Parse(cfg config.RuntimeConfig) (*Settings, error) {
+	var problems []error
+	invalid := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Errorf(format, args...))
+	}
+
+	if cfg.RateLimits.API < 0 {
+		invalid("rate_limits.api must not be negative")
+	}
+	if cfg.RateLimits.Public < 0 {
+		invalid("rate_limits.public must not be negative")
+	}
+
+	confirmations := make(payment.ConfirmationPolicy, len(cfg.Confirmations))
+	for network, rule := range cfg.Confirmations {
+		key := "confirmations." + network
+		if !shared.BlockchainNetwork(network).IsValid() {
+			invalid("%s: unknown network", key)
+			continue
+		}
+		threshold, err := decimal.NewFromString(rule.Threshold)
+		if err != nil || threshold.IsNegative() {
+			invalid("%s.threshold must be a non-negative amount", key)
+		}
+		if rule.Below < 1 || rule.AtOrAbove < 1 {
+			invalid("%s: confirmations must be at least 1", key)
+		}
+		if rule.AtOrAbove < rule.Below {
+			invalid("%s.at_or_above must not be less than below", key)
+		}
+		confirmations[shared.BlockchainNetwork(network)] = payment.ConfirmationRule{
+			Threshold: threshold,
+			Below:     rule.Below,
+			AtOrAbove: rule.AtOrAbove,
+		}
+	}
+
+	if cfg.Webhooks.MaxRetries < 0 || cfg.Webhooks.MaxRetries > maxWebhookRetries {
+		invalid("webhooks.max_retries must be between 0 and %d", maxWebhookRetries)
+	}
+	backoff := merchant.BackoffStrategy(cfg.Webhooks.Backoff)
+	if !backoff.IsValid() {
+		invalid("webhooks.backoff must be %s or %s", merchant.BackoffStrategyLinear, merchant.BackoffStrategyExponential)
+	}
+
+	for name := range cfg.Features {
+		if !features[name] {
+			invalid("features.%s: unknown feature", name)
+		}
+	}
+
+	feeSchedules := make(map[string]*settlement.FeeSchedule, len(cfg.FeeSchedules))
+	merchantFeeSchedules := make(map[string]string)
+	for name, scheduleCfg := range cfg.FeeSchedules {
+		key := "fee_schedules." + name
+		schedule, err := parseFeeSchedule(name, scheduleCfg)
+		if err != nil {
+			invalid("%s: %w", key, err)
+			continue
+		}
+		feeSchedules[name] = schedule
+		for _, merchantID := range scheduleCfg.Merchants {
+			if other, ok := merchantFeeSchedules[merchantID]; ok && other != name {
+				invalid("%s.merchants: merchant %s is also listed in fee_schedules.%s", key, merchantID, other)
+			}
+			merchantFeeSchedules[merchantID] = name
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Slice(problems, func(i, j int) bool { return problems[i].Error() < problems[j].Error() })
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSettings, errors.Join(problems...))
+	}
+	return &Settings{
+		RateLimits:        cfg.RateLimits,
+		Confirmations:     confirmations,
+		WebhookMaxRetries: cfg.Webhooks.MaxRetries,
+		WebhookBackoff:    backoff,
+		Features:          cfg.Features,
+		FeeSchedules:      feeSchedules,
+
+		merchantFeeSchedules: merchantFeeSchedules,
+		source:               cfg,
+	}, nil
+}
+
+// parseFeeSchedule validates a fee schedule.
+func parseFeeSchedule(name string, cfg config.FeeScheduleConfig) (*settlement.FeeSchedule, error) {
+	tiers := make([]settlement.VolumeTier, len(cfg.Tiers))
+	for i, tier := range cfg.Tiers {
+		minVolume, err := decimal.NewFromString(tier.MinVolume)
+		if err != nil || minVolume.IsNegative() {
+			return nil, fmt.Errorf("tiers.%d.min_volume must be a non-negative amount", i)
+		}
+		percentage, err := decimal.NewFromString(tier.Percentage)
+		if err != nil {
+			return nil, fmt.Errorf("tiers.%d.percentage must be a percentage", i)
+		}
+		tiers[i] = settlement.VolumeTier{MinVolume: minVolume, Percentage: percentage}
+	}
+
+	networks := make(map[shared.BlockchainNetwork]decimal.Decimal, len(cfg.Networks))
+	for network, value := range cfg.Networks {
+		percentage, err := decimal.NewFromString(value)
+		if err != nil {
+			return nil, fmt.Errorf("networks.%s must be a percentage", network)
+		}
+		networks[shared.BlockchainNetwork(network)] = percentage
+	}
+
+	promotions := make([]settlement.FeePromotion, len(cfg.Promotions))
+	for i, promotion := range cfg.Promotions {
+		startsAt, err := time.Parse(time.RFC3339, promotion.StartsAt)
+		if err != nil {
+			return nil, fmt.Errorf("promotions.%d.starts_at must be an RFC 3339 time", i)
+		}
+		endsAt, err := time.Parse(time.RFC3339, promotion.EndsAt)
+		if err != nil {
+			return nil, fmt.Errorf("promotions.%d.ends_at must be an RFC 3339 time", i)
+		}
+		promotions[i] = settlement.FeePromotion{Name: promotion.Name, StartsAt: startsAt, EndsAt: endsAt}
+	}
+
+	minimumFees := make(map[string]decimal.Decimal, len(cfg.MinimumFees))
+	for currency, value := range cfg.MinimumFees {
+		minimum, err := decimal.NewFromString(value)
+		if err != nil {
+			return nil, fmt.Errorf("minimum_fees.%s must be an amount", currency)
+		}
+		minimumFees[currency] = minimum
+	}
+
+	return settlement.NewFeeSchedule(name, tiers, networks, promotions, minimumFees)
+}
+
+// Features returns the names of the known feature flags, sorted.
+func Features() []string {
+	names := make([]string, 0, len(features))
+	for name := range features {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FeatureEnabled reports whether a feature is on. Features are on unless switched off.
+func (s *Settings) FeatureEnabled(name string) bool {
+	enabled, ok := s.Features[name]
+	return !ok || enabled
+}
+
+// MerchantFeeSchedule returns the fee schedule listing a merchant, or nil if none does.
+func (s *Settings) MerchantFeeSchedule(merchantID string) *settlement.FeeSchedule {
+	name, ok := s.merchantFeeSchedules[merchantID]
+	if !ok {
+		return nil
+	}
+	return s.FeeSchedules[name]
+}
+
+// FeeScheduleMerchants returns the merchants a fee schedule lists, sorted.
+func (s *Settings) FeeScheduleMerchants(name string) []string {
+	merchants := []string{}
+	for merchantID, scheduleName := range s.merchantFeeSchedules {
+		if scheduleName == name {
+			merchants = append(merchants, merchantID)
+		}
+	}
+	sort.Strings(merchants)
+	return merchants
+}
+
+// DefaultFeeSchedule returns the fee schedule of merchants no schedule lists, or nil if there is none.
+func (s *Settings) DefaultFeeSchedule() *settlement.FeeSchedule {
+	return s.FeeSchedules[settlement.DefaultFeeScheduleName]
+}
+
+// values flattens the settings into their configuration keys, e.g. "rate_limits.api".
+func (s *Settings) values() map[string]string {
+	values := map[string]string{
+		"rate_limits.api":      strconv.Itoa(s.source.RateLimits.API),
+		"rate_limits.public":   strconv.Itoa(s.source.RateLimits.Public),
+		"webhooks.max_retries": strconv.Itoa(s.source.Webhooks.MaxRetries),
+		"webhooks.backoff":     s.source.Webhooks.Backoff,
+	}
+	for network, rule := range s.source.Confirmations {
+		key := "confirmations." + network
+		values[key+".threshold"] = rule.Threshold
+		values[key+".below"] = strconv.Itoa(rule.Below)
+		values[key+".at_or_above"] = strconv.Itoa(rule.AtOrAbove)
+	}
+	for name, enabled := range s.source.Features {
+		values["features."+name] = strconv.FormatBool(enabled)
+	}
+	for name, schedule := range s.source.FeeSchedules {
+		key := "fee_schedules." + name
+		merchants := append([]string(nil), schedule.Merchants...)
+		sort.Strings(merchants)
+		values[key+".merchants"] = strings.Join(merchants, ",")
+		for i, tier := range schedule.Tiers {
+			values[fmt.Sprintf("%s.tiers.%d.min_volume", key, i)] = tier.MinVolume
+			values[fmt.Sprintf("%s.tiers.%d.percentage", key, i)] = tier.Percentage
+		}
+		for network, percentage := range schedule.Networks {
+			values[key+".networks."+network] = percentage
+		}
+		for i, promotion := range schedule.Promotions {
+			values[fmt.Sprintf("%s.promotions.%d.name", key, i)] = promotion.Name
+			values[fmt.Sprintf("%s.promotions.%d.starts_at", key, i)] = promotion.StartsAt
+			values[fmt.Sprintf("%s.promotions.%d.ends_at", key, i)] = promotion.EndsAt
+		}
+		for currency, minimum := range schedule.MinimumFees {
+			values[key+".minimum_fees."+currency] = minimum
+		}
+	}
+	return values
+}
+
+// Change is a runtime setting that changed on reload. Before or After is empty when the key was added or removed.
+type Change struct {
+	Key    string
+	Before string
+	After  string
+}
+
+// diff returns the settings that differ between two versions, sorted by key.
+func diff(before, after *Settings) []Change {
+	beforeValues, afterValues := before.values(), after.values()
+	changes := []Change{}
+	for key, value := range afterValues {
+		if previous, ok := beforeValues[key]; !ok || previous != value {
+			changes = append(changes, Change{Key: key, Before: previous, After: value})
+		}
+	}
+	for key, previous := range beforeValues {
+		if _, ok := afterValues[key]; !ok {
+			changes = append(changes, Change{Key: key, Before: previous})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
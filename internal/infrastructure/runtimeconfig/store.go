@@ -0,0 +1,174 @@
+package runtimeconfig
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/pkg/config"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// Reload triggers, recorded in the audit entry of a reload.
+const (
+	// TriggerSignal - The process received SIGHUP
+	TriggerSignal = "signal"
+	// TriggerAPI - An admin called POST /api/v1/admin/config/reload
+	TriggerAPI = "api"
+)
+
+// ReloadResult describes a reload.
+type ReloadResult struct {
+	// Changes are the runtime settings that changed and now apply.
+	Changes []Change
+	// RestartRequired lists the other config sections that differ from the ones the application started
+	// with; they were not applied.
+	RestartRequired []string
+}
+
+// Store holds the runtime settings in force and swaps them atomically on reload, so readers always see one
+// complete version.
+type Store struct {
+	startup      *config.Config
+	load         func() (*config.Config, error)
+	auditService audit.Service
+	logger       *zap.Logger
+
+	reloads  sync.Mutex
+	settings atomic.Pointer[Settings]
+}
+
+// NewStore creates a store holding the runtime settings of the configuration the application started with.
+// Reloads read the configuration again with load.
+func NewStore(
+	startup *config.Config,
+	load func() (*config.Config, error),
+	auditService audit.Service,
+	logger *zap.Logger,
+) (*Store, error) {
+	settings, err := Parse(startup.Runtime)
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{
+		startup:      startup,
+		load:         load,
+		auditService: auditService,
+		logger:       logger,
+	}
+	s.settings.Store(settings)
+	return s, nil
+}
+
+// Settings returns the runtime settings in force.
+func (s *Store) Settings() *Settings {
+	return s.settings.Load()
+}
+
+// ConfirmationPolicy returns the confirmation rules in force.
+func (s *Store) ConfirmationPolicy() payment.ConfirmationPolicy {
+	return s.Settings().Confirmations
+}
+
+// WebhookRetryDefaults returns the retry policy of webhook endpoints created without their own.
+func (s *Store) WebhookRetryDefaults() (int, merchant.BackoffStrategy) {
+	settings := s.Settings()
+	return settings.WebhookMaxRetries, settings.WebhookBackoff
+}
+
+// MerchantFeeSchedule returns the fee schedule in force listing a merchant, or nil if none does.
+func (s *Store) MerchantFeeSchedule(merchantID string) *settlement.FeeSchedule {
+	return s.Settings().MerchantFeeSchedule(merchantID)
+}
+
+// DefaultFeeSchedule returns the fee schedule in force of merchants no schedule lists, or nil if there is none.
+func (s *Store) DefaultFeeSchedule() *settlement.FeeSchedule {
+	return s.Settings().DefaultFeeSchedule()
+}
+
+// Reload reads the configuration again and, if its runtime settings are valid, puts them in force. Invalid
+// settings are rejected as a whole and the current ones stay in force. Every reload, even one that changes
+// nothing, is recorded in the audit log as a platform action of the actor.
+func (s *Store) Reload(ctx context.Context, trigger string, actor audit.Actor) (*ReloadResult, error) {
+	s.reloads.Lock()
+	defer s.reloads.Unlock()
+
+	cfg, err := s.load()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSettings, err)
+	}
+	next, err := Parse(cfg.Runtime)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReloadResult{
+		Changes:         diff(s.Settings(), next),
+		RestartRequired: restartRequired(s.startup, cfg),
+	}
+	if len(result.Changes) > 0 {
+		s.settings.Store(next)
+	}
+
+	s.logger.Info("Runtime settings reloaded",
+		zap.String("trigger", trigger),
+		zap.Int("changes", len(result.Changes)),
+		zap.Strings("restart_required", result.RestartRequired),
+	)
+	s.record(ctx, trigger, actor, result)
+	return result, nil
+}
+
+// record writes the audit entry of a reload. A failure is logged rather than undoing the reload.
+func (s *Store) record(ctx context.Context, trigger string, actor audit.Actor, result *ReloadResult) {
+	if s.auditService == nil {
+		return
+	}
+	before := make(map[string]interface{}, len(result.Changes))
+	after := make(map[string]interface{}, len(result.Changes))
+	for _, change := range result.Changes {
+		before[change.Key] = change.Before
+		after[change.Key] = change.After
+	}
+
+	if err := s.auditService.Record(ctx, &audit.RecordRequest{
+		MerchantID:   audit.PlatformMerchantID,
+		Actor:        actor,
+		Action:       "config.reload",
+		ResourceType: "config",
+		ResourceID:   "runtime",
+		Before:       before,
+		After:        after,
+		Details: map[string]interface{}{
+			"trigger":          trigger,
+			"changes":          len(result.Changes),
+			"restart_required": result.RestartRequired,
+		},
+	}); err != nil {
+		s.logger.Error("Failed to record audit entry", zap.String("action", "config.reload"), zap.Error(err))
+	}
+}
+
+// restartRequired returns the config sections, other than runtime, that differ between two configurations.
+func restartRequired(running, loaded *config.Config) []string {
+	sections := []string{}
+	runningValue, loadedValue := reflect.ValueOf(running).Elem(), reflect.ValueOf(loaded).Elem()
+	for i := range runningValue.NumField() {
+		field := runningValue.Type().Field(i)
+		if field.Name == "Runtime" {
+			continue
+		}
+		if !reflect.DeepEqual(runningValue.Field(i).Interface(), loadedValue.Field(i).Interface()) {
+			sections = append(sections, field.Tag.Get("mapstructure"))
+		}
+	}
+	sort.Strings(sections)
+	return sections
+}
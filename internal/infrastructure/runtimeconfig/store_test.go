@@ -0,0 +1,186 @@
+package runtimeconfig_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/runtimeconfig"
+	"crypto-checkout/pkg/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// recordingAudit keeps the audit entries recorded by the store.
+type recordingAudit struct {
+	audit.Service
+	entries []*audit.RecordRequest
+}
+
+func (a *recordingAudit) Record(_ context.Context, req *audit.RecordRequest) error {
+	a.entries = append(a.entries, req)
+	return nil
+}
+
+func TestParse(t *testing.T) {
+	settings, err := runtimeconfig.Parse(config.NewConfig().Runtime)
+	require.NoError(t, err)
+	btc, err := shared.NewMoneyWithCrypto("999.99", shared.CryptoCurrencyBTC)
+	require.NoError(t, err)
+	assert.Equal(t, 1, settings.Confirmations.Required(btc, shared.NetworkBitcoin))
+	assert.Equal(t, merchant.BackoffStrategyExponential, settings.WebhookBackoff)
+	assert.True(t, settings.FeatureEnabled(runtimeconfig.FeatureGraphQL))
+
+	invalid := config.RuntimeConfig{
+		RateLimits: config.RateLimitsConfig{API: -1},
+		Confirmations: map[string]config.ConfirmationConfig{
+			"dogecoin": {Threshold: "1", Below: 1, AtOrAbove: 1},
+			"tron":     {Threshold: "abc", Below: 3, AtOrAbove: 1},
+		},
+		Webhooks: config.WebhookRetryConfig{MaxRetries: 11, Backoff: "random"},
+		Features: map[string]bool{"teleport": true},
+	}
+	_, err = runtimeconfig.Parse(invalid)
+	require.ErrorIs(t, err, runtimeconfig.ErrInvalidSettings)
+	for _, problem := range []string{
+		"rate_limits.api", "confirmations.dogecoin", "confirmations.tron.threshold",
+		"confirmations.tron.at_or_above", "webhooks.max_retries", "webhooks.backoff", "features.teleport",
+	} {
+		assert.Contains(t, err.Error(), problem)
+	}
+}
+
+func TestParse_FeeSchedules(t *testing.T) {
+	cfg := config.NewConfig().Runtime
+	cfg.FeeSchedules = map[string]config.FeeScheduleConfig{
+		"default": {Tiers: []config.FeeTierConfig{{MinVolume: "0", Percentage: "1.5"}}},
+		"growth": {
+			Merchants: []string{"merchant-2", "merchant-1"},
+			Tiers: []config.FeeTierConfig{
+				{MinVolume: "0", Percentage: "1"},
+				{MinVolume: "50000", Percentage: "0.5"},
+			},
+			Networks: map[string]string{"ethereum": "2"},
+			Promotions: []config.FeePromotionConfig{
+				{Name: "launch", StartsAt: "2026-11-01T00:00:00Z", EndsAt: "2026-11-08T00:00:00Z"},
+			},
+			MinimumFees: map[string]string{"USDT": "0.5"},
+		},
+	}
+	settings, err := runtimeconfig.Parse(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.MerchantFeeSchedule("merchant-1"))
+	assert.Equal(t, "growth", settings.MerchantFeeSchedule("merchant-1").Name())
+	assert.Nil(t, settings.MerchantFeeSchedule("merchant-3"))
+	require.NotNil(t, settings.DefaultFeeSchedule())
+	assert.Equal(t, "default", settings.DefaultFeeSchedule().Name())
+	assert.Equal(t, []string{"merchant-1", "merchant-2"}, settings.FeeScheduleMerchants("growth"))
+	assert.Equal(t, "0.5", settings.MerchantFeeSchedule("merchant-2").MinimumFee("USDT").String())
+
+	cfg.FeeSchedules = map[string]config.FeeScheduleConfig{
+		"growth": {
+			Merchants:   []string{"merchant-1"},
+			Tiers:       []config.FeeTierConfig{{MinVolume: "0", Percentage: "1"}},
+			MinimumFees: map[string]string{"USDT": "abc"},
+		},
+		"enterprise": {
+			Merchants:  []string{"merchant-1"},
+			Tiers:      []config.FeeTierConfig{{MinVolume: "0", Percentage: "0.5"}},
+			Promotions: []config.FeePromotionConfig{{Name: "launch", StartsAt: "tomorrow"}},
+		},
+		"resellers": {Tiers: []config.FeeTierConfig{{MinVolume: "0", Percentage: "150"}}},
+	}
+	_, err = runtimeconfig.Parse(cfg)
+	require.ErrorIs(t, err, runtimeconfig.ErrInvalidSettings)
+	for _, problem := range []string{
+		"fee_schedules.growth: minimum_fees.USDT", "fee_schedules.enterprise: promotions.0.starts_at",
+		"fee_schedules.resellers: invalid settlement request",
+	} {
+		assert.Contains(t, err.Error(), problem)
+	}
+
+	tiers := []config.FeeTierConfig{{MinVolume: "0", Percentage: "1"}}
+	cfg.FeeSchedules = map[string]config.FeeScheduleConfig{
+		"growth":     {Merchants: []string{"merchant-1"}, Tiers: tiers},
+		"enterprise": {Merchants: []string{"merchant-1"}, Tiers: tiers},
+	}
+	_, err = runtimeconfig.Parse(cfg)
+	require.ErrorIs(t, err, runtimeconfig.ErrInvalidSettings)
+	assert.Contains(t, err.Error(), "merchant merchant-1 is also listed")
+}
+
+func TestStoreReload(t *testing.T) {
+	ctx := context.Background()
+	startup := config.NewConfig()
+	loaded := config.NewConfig()
+	recorder := &recordingAudit{}
+	store, err := runtimeconfig.NewStore(startup, func() (*config.Config, error) {
+		copied := *loaded
+		return &copied, nil
+	}, recorder, zap.NewNop())
+	require.NoError(t, err)
+	admin := audit.Actor{ID: "user-1", Type: audit.ActorTypeUser, Role: "admin"}
+
+	t.Run("applies changed settings", func(t *testing.T) {
+		previous := store.Settings()
+		loaded.Runtime.RateLimits.Public = 120
+		loaded.Runtime.Features = map[string]bool{runtimeconfig.FeatureGraphQL: false}
+		loaded.Runtime.Webhooks = config.WebhookRetryConfig{MaxRetries: 5, Backoff: "linear"}
+
+		result, err := store.Reload(ctx, runtimeconfig.TriggerAPI, admin)
+		require.NoError(t, err)
+		assert.Equal(t, []runtimeconfig.Change{
+			{Key: "features.graphql", After: "false"},
+			{Key: "rate_limits.public", Before: "0", After: "120"},
+			{Key: "webhooks.backoff", Before: "exponential", After: "linear"},
+			{Key: "webhooks.max_retries", Before: "3", After: "5"},
+		}, result.Changes)
+		assert.Empty(t, result.RestartRequired)
+
+		assert.Equal(t, 120, store.Settings().RateLimits.Public)
+		assert.False(t, store.Settings().FeatureEnabled(runtimeconfig.FeatureGraphQL))
+		maxRetries, backoff := store.WebhookRetryDefaults()
+		assert.Equal(t, 5, maxRetries)
+		assert.Equal(t, merchant.BackoffStrategyLinear, backoff)
+		// Readers holding the previous settings are not affected by the swap
+		assert.Equal(t, 0, previous.RateLimits.Public)
+
+		require.Len(t, recorder.entries, 1)
+		entry := recorder.entries[0]
+		assert.Equal(t, audit.PlatformMerchantID, entry.MerchantID)
+		assert.Equal(t, admin, entry.Actor)
+		assert.Equal(t, "config.reload", entry.Action)
+		assert.Equal(t, "0", entry.Before["rate_limits.public"])
+		assert.Equal(t, "120", entry.After["rate_limits.public"])
+		assert.Equal(t, runtimeconfig.TriggerAPI, entry.Details["trigger"])
+	})
+
+	t.Run("rejects invalid settings and keeps the current ones", func(t *testing.T) {
+		loaded.Runtime.RateLimits.Public = 60
+		loaded.Runtime.Confirmations = map[string]config.ConfirmationConfig{
+			"bitcoin": {Threshold: "1000", Below: 0, AtOrAbove: 6},
+		}
+
+		_, err := store.Reload(ctx, runtimeconfig.TriggerSignal, audit.Actor{ID: "system", Type: audit.ActorTypeSystem})
+		require.ErrorIs(t, err, runtimeconfig.ErrInvalidSettings)
+		assert.Equal(t, 120, store.Settings().RateLimits.Public)
+		assert.Len(t, recorder.entries, 1)
+	})
+
+	t.Run("reports structural changes without applying them", func(t *testing.T) {
+		loaded.Runtime.RateLimits.Public = 120
+		loaded.Runtime.Confirmations = config.DefaultConfirmations()
+		loaded.Database.MaxOpenConns = 5
+		loaded.Server = config.ServerConfig{Port: 9090, Host: "0.0.0.0"}
+
+		result, err := store.Reload(ctx, runtimeconfig.TriggerSignal, audit.Actor{ID: "system", Type: audit.ActorTypeSystem})
+		require.NoError(t, err)
+		assert.Empty(t, result.Changes)
+		assert.Equal(t, []string{"database", "server"}, result.RestartRequired)
+		require.Len(t, recorder.entries, 2)
+		assert.Equal(t, []string{"database", "server"}, recorder.entries[1].Details["restart_required"])
+	})
+}
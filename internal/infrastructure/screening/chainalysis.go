@@ -0,0 +1,144 @@
+// Package screening provides AML/KYT provider adapters for compliance screening of payment senders.
+package screening
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/compliance"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultChainalysisBaseURL is the default Chainalysis KYT API endpoint.
+const DefaultChainalysisBaseURL = "https://api.chainalysis.com"
+
+// maxErrorBody limits how much of a provider error response is included in errors.
+const maxErrorBody = 512
+
+// ChainalysisScreener screens addresses with the Chainalysis address screening API.
+type ChainalysisScreener struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+// NewChainalysisScreener creates a new Chainalysis screener.
+func NewChainalysisScreener(baseURL, apiKey string, client *http.Client) *ChainalysisScreener {
+	if baseURL == "" {
+		baseURL = DefaultChainalysisBaseURL
+	}
+	return &ChainalysisScreener{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  client,
+	}
+}
+
+// chainalysisEntity is the address risk assessment returned by Chainalysis.
+type chainalysisEntity struct {
+	Address  string `json:"address"`
+	Risk     string `json:"risk"`
+	Triggers []struct {
+		Category string `json:"category"`
+	} `json:"triggers"`
+}
+
+// Name returns the provider name.
+func (s *ChainalysisScreener) Name() string {
+	return "chainalysis"
+}
+
+// ScreenAddress registers the address with Chainalysis and retrieves its risk assessment.
+func (s *ChainalysisScreener) ScreenAddress(
+	ctx context.Context,
+	req *compliance.ScreeningRequest,
+) (*compliance.ScreeningResult, error) {
+	if req == nil || req.Address == "" {
+		return nil, fmt.Errorf("%w: address is required", compliance.ErrInvalidRequest)
+	}
+
+	body, err := json.Marshal(map[string]string{"address": req.Address})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal register request: %w", err)
+	}
+	if err := s.do(ctx, http.MethodPost, "/api/risk/v2/entities", body, nil); err != nil {
+		return nil, err
+	}
+
+	var entity chainalysisEntity
+	path := "/api/risk/v2/entities/" + url.PathEscape(req.Address)
+	if err := s.do(ctx, http.MethodGet, path, nil, &entity); err != nil {
+		return nil, err
+	}
+
+	categories := make([]string, 0, len(entity.Triggers))
+	for _, trigger := range entity.Triggers {
+		if trigger.Category != "" {
+			categories = append(categories, trigger.Category)
+		}
+	}
+
+	return &compliance.ScreeningResult{
+		RiskLevel:  chainalysisRiskLevel(entity.Risk),
+		Categories: categories,
+		Reference:  entity.Address,
+	}, nil
+}
+
+// do sends an authenticated request to Chainalysis and decodes the response into out if set.
+func (s *ChainalysisScreener) do(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	httpReq, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Token", s.apiKey)
+	httpReq.Header.Set("Accept", "application/json")
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	return doJSON(s.client, httpReq, out)
+}
+
+// chainalysisRiskLevel maps a Chainalysis risk rating to a risk level.
+func chainalysisRiskLevel(risk string) compliance.RiskLevel {
+	switch strings.ToLower(risk) {
+	case "low":
+		return compliance.RiskLevelLow
+	case "medium":
+		return compliance.RiskLevelMedium
+	case "high":
+		return compliance.RiskLevelHigh
+	case "severe":
+		return compliance.RiskLevelSevere
+	default:
+		return compliance.RiskLevelUnknown
+	}
+}
+
+// doJSON executes the request and decodes a successful JSON response into out if set.
+func doJSON(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", compliance.ErrProviderFailure, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return fmt.Errorf("%w: %s %s returned %d: %s",
+			compliance.ErrProviderFailure, req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: failed to decode response: %w", compliance.ErrProviderFailure, err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package screening
+
+import (
+	"crypto-checkout/internal/domain/compliance"
+	"crypto-checkout/internal/infrastructure/resilience"
+	"crypto-checkout/pkg/config"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// sanctionsDownloadTimeout bounds a sanctions list download, which is much larger than a screening call.
+const sanctionsDownloadTimeout = 5 * time.Minute
+
+// Module provides the configured AML/KYT screening provider and sanctions list for Fx.
+var Module = fx.Module("screening",
+	fx.Provide(
+		NewTransactionScreenerProvider,
+		NewPolicyProvider,
+		NewSanctionsListProvider,
+		NewBlocklistScheduleProvider,
+	),
+)
+
+// NewTransactionScreenerProvider creates the screener for the configured provider.
+// It returns nil when screening is disabled, in which case payments are not screened.
+func NewTransactionScreenerProvider(
+	cfg *config.Config,
+	breakers *resilience.Registry,
+	logger *zap.Logger,
+) (compliance.TransactionScreener, error) {
+	timeout := cfg.Compliance.Timeout
+	if timeout <= 0 {
+		timeout = config.DefaultComplianceTimeout
+	}
+	client := breakers.Client(resilience.DependencyScreening, timeout)
+
+	switch provider := strings.ToLower(cfg.Compliance.Provider); provider {
+	case "", "none":
+		logger.Warn("AML/KYT screening is disabled; payments will not be screened")
+		return nil, nil
+	case "chainalysis":
+		return NewChainalysisScreener(cfg.Compliance.BaseURL, cfg.Compliance.APIKey, client), nil
+	case "elliptic":
+		return NewEllipticScreener(cfg.Compliance.BaseURL, cfg.Compliance.APIKey, cfg.Compliance.APISecret, client), nil
+	default:
+		return nil, fmt.Errorf("unsupported compliance provider: %s", provider)
+	}
+}
+
+// NewPolicyProvider creates the screening policy from configuration.
+func NewPolicyProvider(cfg *config.Config) (compliance.Policy, error) {
+	policy := compliance.DefaultPolicy()
+	if cfg.Compliance.HoldRiskLevel == "" {
+		return policy, nil
+	}
+
+	level := compliance.RiskLevel(strings.ToLower(cfg.Compliance.HoldRiskLevel))
+	if !level.IsValid() || level == compliance.RiskLevelUnknown {
+		return compliance.Policy{}, fmt.Errorf("%w: %s", compliance.ErrInvalidRiskLevel, cfg.Compliance.HoldRiskLevel)
+	}
+	policy.HoldThreshold = level
+	return policy, nil
+}
+
+// NewSanctionsListProvider creates the OFAC SDN list source.
+// It returns nil when no list URL is configured, in which case only custom blocklists apply.
+func NewSanctionsListProvider(
+	cfg *config.Config,
+	breakers *resilience.Registry,
+	logger *zap.Logger,
+) compliance.SanctionsList {
+	if cfg.Compliance.SanctionsListURL == "" {
+		logger.Warn("Sanctions list ingestion is disabled; only custom blocklists are enforced")
+		return nil
+	}
+	return NewOFACList(cfg.Compliance.SanctionsListURL,
+		breakers.Client(resilience.DependencySanctionsList, sanctionsDownloadTimeout))
+}
+
+// NewBlocklistScheduleProvider creates the blocklist maintenance schedule from configuration.
+func NewBlocklistScheduleProvider(cfg *config.Config) compliance.BlocklistSchedule {
+	return compliance.BlocklistSchedule{
+		SyncInterval:   cfg.Compliance.SanctionsSyncInterval,
+		ReloadInterval: cfg.Compliance.BlocklistReloadInterval,
+	}
+}
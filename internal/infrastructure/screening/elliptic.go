@@ -0,0 +1,175 @@
+package screening
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/compliance"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultEllipticBaseURL is the default Elliptic AML API endpoint.
+	DefaultEllipticBaseURL = "https://aml-api.elliptic.co"
+	// ellipticWalletPath is the synchronous wallet screening endpoint.
+	ellipticWalletPath = "/v2/wallet/synchronous"
+)
+
+// Elliptic risk scores range from 0 to 10; these are the lower bounds of each level.
+const (
+	ellipticSevereScore = 8
+	ellipticHighScore   = 5
+	ellipticMediumScore = 2
+)
+
+// EllipticScreener screens addresses with the Elliptic Lens wallet screening API.
+type EllipticScreener struct {
+	baseURL string
+	apiKey  string
+	secret  string
+	client  *http.Client
+	now     func() time.Time
+}
+
+// NewEllipticScreener creates a new Elliptic screener. The secret is the base64-encoded API secret.
+func NewEllipticScreener(baseURL, apiKey, secret string, client *http.Client) *EllipticScreener {
+	if baseURL == "" {
+		baseURL = DefaultEllipticBaseURL
+	}
+	return &EllipticScreener{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		secret:  secret,
+		client:  client,
+		now:     time.Now,
+	}
+}
+
+// ellipticWalletRequest is the wallet screening request body.
+type ellipticWalletRequest struct {
+	Subject ellipticSubject `json:"subject"`
+	Type    string          `json:"type"`
+}
+
+// ellipticSubject identifies the screened address.
+type ellipticSubject struct {
+	Asset      string `json:"asset"`
+	Blockchain string `json:"blockchain"`
+	Type       string `json:"type"`
+	Hash       string `json:"hash"`
+}
+
+// ellipticWalletResponse is the wallet risk assessment returned by Elliptic.
+type ellipticWalletResponse struct {
+	ID               string   `json:"id"`
+	RiskScore        *float64 `json:"risk_score"`
+	EvaluationDetail struct {
+		Source []struct {
+			RuleName string `json:"rule_name"`
+		} `json:"source"`
+	} `json:"evaluation_detail"`
+}
+
+// Name returns the provider name.
+func (s *EllipticScreener) Name() string {
+	return "elliptic"
+}
+
+// ScreenAddress retrieves the risk assessment of the address across all blockchains.
+func (s *EllipticScreener) ScreenAddress(
+	ctx context.Context,
+	req *compliance.ScreeningRequest,
+) (*compliance.ScreeningResult, error) {
+	if req == nil || req.Address == "" {
+		return nil, fmt.Errorf("%w: address is required", compliance.ErrInvalidRequest)
+	}
+
+	body, err := json.Marshal(ellipticWalletRequest{
+		Subject: ellipticSubject{
+			Asset:      "holistic",
+			Blockchain: "holistic",
+			Type:       "address",
+			Hash:       req.Address,
+		},
+		Type: "wallet_exposure",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal wallet request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(s.now().UnixMilli(), 10)
+	signature, err := s.sign(timestamp, http.MethodPost, ellipticWalletPath, body)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+ellipticWalletPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("x-access-key", s.apiKey)
+	httpReq.Header.Set("x-access-sign", signature)
+	httpReq.Header.Set("x-access-timestamp", timestamp)
+
+	var wallet ellipticWalletResponse
+	if err := doJSON(s.client, httpReq, &wallet); err != nil {
+		return nil, err
+	}
+
+	categories := make([]string, 0, len(wallet.EvaluationDetail.Source))
+	for _, source := range wallet.EvaluationDetail.Source {
+		if source.RuleName != "" {
+			categories = append(categories, source.RuleName)
+		}
+	}
+
+	result := &compliance.ScreeningResult{
+		RiskLevel:  ellipticRiskLevel(wallet.RiskScore),
+		Categories: categories,
+		Reference:  wallet.ID,
+	}
+	if wallet.RiskScore != nil {
+		result.RiskScore = *wallet.RiskScore
+	}
+	return result, nil
+}
+
+// sign computes the request signature: base64 HMAC-SHA256 of timestamp, method, path and body
+// keyed with the base64-decoded API secret.
+func (s *EllipticScreener) sign(timestamp, method, path string, body []byte) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(s.secret)
+	if err != nil {
+		return "", fmt.Errorf("invalid Elliptic API secret: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(timestamp + method + strings.ToLower(path)))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// ellipticRiskLevel maps an Elliptic risk score to a risk level. A missing score means
+// Elliptic has no data on the address.
+func ellipticRiskLevel(score *float64) compliance.RiskLevel {
+	switch {
+	case score == nil:
+		return compliance.RiskLevelUnknown
+	case *score >= ellipticSevereScore:
+		return compliance.RiskLevelSevere
+	case *score >= ellipticHighScore:
+		return compliance.RiskLevelHigh
+	case *score >= ellipticMediumScore:
+		return compliance.RiskLevelMedium
+	default:
+		return compliance.RiskLevelLow
+	}
+}
@@ -0,0 +1,107 @@
+package screening
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/compliance"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ofacDigitalCurrencyPrefix marks SDN identifiers that are blockchain addresses,
+// e.g. "Digital Currency Address - ETH".
+const ofacDigitalCurrencyPrefix = "Digital Currency Address - "
+
+// OFACList downloads digital currency addresses from the OFAC SDN list.
+type OFACList struct {
+	url    string
+	client *http.Client
+}
+
+// NewOFACList creates a new OFAC SDN list source reading the SDN XML export at url.
+func NewOFACList(url string, client *http.Client) *OFACList {
+	return &OFACList{
+		url:    url,
+		client: client,
+	}
+}
+
+// ofacSDNEntry is a sanctioned party in the SDN XML export.
+type ofacSDNEntry struct {
+	UID       string `xml:"uid"`
+	FirstName string `xml:"firstName"`
+	LastName  string `xml:"lastName"`
+	IDs       []struct {
+		IDType   string `xml:"idType"`
+		IDNumber string `xml:"idNumber"`
+	} `xml:"idList>id"`
+}
+
+// Name returns the list name.
+func (l *OFACList) Name() string {
+	return "ofac_sdn"
+}
+
+// Fetch downloads the SDN list and returns the digital currency addresses it contains.
+func (l *OFACList) Fetch(ctx context.Context) ([]compliance.SanctionedAddress, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/xml")
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", compliance.ErrProviderFailure, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: SDN download returned %d", compliance.ErrProviderFailure, resp.StatusCode)
+	}
+
+	return parseSDN(resp.Body)
+}
+
+// parseSDN streams the SDN XML export and extracts digital currency addresses.
+func parseSDN(r io.Reader) ([]compliance.SanctionedAddress, error) {
+	decoder := xml.NewDecoder(r)
+	var addresses []compliance.SanctionedAddress
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return addresses, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to parse SDN list: %w", compliance.ErrProviderFailure, err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "sdnEntry" {
+			continue
+		}
+
+		var entry ofacSDNEntry
+		if err := decoder.DecodeElement(&entry, &start); err != nil {
+			return nil, fmt.Errorf("%w: failed to parse SDN entry: %w", compliance.ErrProviderFailure, err)
+		}
+
+		name := strings.TrimSpace(strings.TrimSpace(entry.FirstName) + " " + strings.TrimSpace(entry.LastName))
+		for _, id := range entry.IDs {
+			asset, found := strings.CutPrefix(id.IDType, ofacDigitalCurrencyPrefix)
+			if !found || strings.TrimSpace(id.IDNumber) == "" {
+				continue
+			}
+			addresses = append(addresses, compliance.SanctionedAddress{
+				Address:   strings.TrimSpace(id.IDNumber),
+				Network:   strings.ToLower(strings.TrimSpace(asset)),
+				Reference: entry.UID,
+				Name:      name,
+			})
+		}
+	}
+}
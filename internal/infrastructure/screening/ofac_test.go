@@ -0,0 +1,59 @@
+package screening
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/compliance"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sdnFixture = `<?xml version="1.0" standalone="yes"?>
+<sdnList xmlns="http://tempuri.org/sdnList.xsd">
+  <publshInformation><Publish_Date>01/15/2025</Publish_Date></publshInformation>
+  <sdnEntry>
+    <uid>100</uid>
+    <lastName>EXAMPLE MIXER</lastName>
+    <sdnType>Entity</sdnType>
+    <idList>
+      <id><uid>1</uid><idType>Digital Currency Address - ETH</idType><idNumber>0x8589427373D6D84E98730D7795D8f6f8731FDA16</idNumber></id>
+      <id><uid>2</uid><idType>Digital Currency Address - XBT</idType><idNumber>1BoatSLRHtKNngkdXEeobR76b53LETtpyT</idNumber></id>
+      <id><uid>3</uid><idType>Registration Number</idType><idNumber>123456</idNumber></id>
+    </idList>
+  </sdnEntry>
+  <sdnEntry>
+    <uid>200</uid>
+    <firstName>John</firstName>
+    <lastName>DOE</lastName>
+    <sdnType>Individual</sdnType>
+  </sdnEntry>
+</sdnList>`
+
+func TestOFACList_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(sdnFixture))
+	}))
+	defer server.Close()
+
+	list := NewOFACList(server.URL, server.Client())
+	addresses, err := list.Fetch(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []compliance.SanctionedAddress{
+		{Address: "0x8589427373D6D84E98730D7795D8f6f8731FDA16", Network: "eth", Reference: "100", Name: "EXAMPLE MIXER"},
+		{Address: "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", Network: "xbt", Reference: "100", Name: "EXAMPLE MIXER"},
+	}, addresses)
+}
+
+func TestOFACList_FetchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewOFACList(server.URL, server.Client()).Fetch(context.Background())
+	require.ErrorIs(t, err, compliance.ErrProviderFailure)
+}
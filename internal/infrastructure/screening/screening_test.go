@@ -0,0 +1,119 @@
+package screening
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/compliance"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainalysisScreener_ScreenAddress(t *testing.T) {
+	var registered string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-key", r.Header.Get("Token"))
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/risk/v2/entities":
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			registered = body["address"]
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"address":"` + registered + `"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/risk/v2/entities/TAddr1":
+			_, _ = w.Write([]byte(`{"address":"TAddr1","risk":"Severe","triggers":[{"category":"sanctions"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	screener := NewChainalysisScreener(server.URL, "test-key", server.Client())
+	result, err := screener.ScreenAddress(context.Background(), &compliance.ScreeningRequest{Address: "TAddr1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "TAddr1", registered)
+	assert.Equal(t, compliance.RiskLevelSevere, result.RiskLevel)
+	assert.Equal(t, []string{"sanctions"}, result.Categories)
+	assert.Equal(t, "chainalysis", screener.Name())
+}
+
+func TestChainalysisScreener_ProviderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	screener := NewChainalysisScreener(server.URL, "bad-key", server.Client())
+	_, err := screener.ScreenAddress(context.Background(), &compliance.ScreeningRequest{Address: "TAddr1"})
+	require.ErrorIs(t, err, compliance.ErrProviderFailure)
+}
+
+func TestEllipticScreener_ScreenAddress(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString([]byte("elliptic-secret"))
+	now := time.UnixMilli(1700000000000)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		mac := hmac.New(sha256.New, []byte("elliptic-secret"))
+		mac.Write([]byte("1700000000000POST/v2/wallet/synchronous"))
+		mac.Write(body)
+
+		assert.Equal(t, ellipticWalletPath, r.URL.Path)
+		assert.Equal(t, "test-key", r.Header.Get("x-access-key"))
+		assert.Equal(t, "1700000000000", r.Header.Get("x-access-timestamp"))
+		assert.Equal(t, base64.StdEncoding.EncodeToString(mac.Sum(nil)), r.Header.Get("x-access-sign"))
+
+		var req ellipticWalletRequest
+		require.NoError(t, json.Unmarshal(body, &req))
+		assert.Equal(t, "TAddr1", req.Subject.Hash)
+
+		_, _ = w.Write([]byte(`{"id":"analysis-1","risk_score":6.5,` +
+			`"evaluation_detail":{"source":[{"rule_name":"Illicit exposure"}]}}`))
+	}))
+	defer server.Close()
+
+	screener := NewEllipticScreener(server.URL, "test-key", secret, server.Client())
+	screener.now = func() time.Time { return now }
+
+	result, err := screener.ScreenAddress(context.Background(), &compliance.ScreeningRequest{Address: "TAddr1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, compliance.RiskLevelHigh, result.RiskLevel)
+	assert.InDelta(t, 6.5, result.RiskScore, 0.001)
+	assert.Equal(t, []string{"Illicit exposure"}, result.Categories)
+	assert.Equal(t, "analysis-1", result.Reference)
+}
+
+func TestEllipticRiskLevel(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name     string
+		score    *float64
+		expected compliance.RiskLevel
+	}{
+		{"no data", nil, compliance.RiskLevelUnknown},
+		{"clean", score(0), compliance.RiskLevelLow},
+		{"medium", score(2), compliance.RiskLevelMedium},
+		{"high", score(5), compliance.RiskLevelHigh},
+		{"severe", score(9.1), compliance.RiskLevelSevere},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ellipticRiskLevel(tt.score))
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// kmsDecryptTarget is the KMS API operation decrypting ciphertexts.
+	kmsDecryptTarget = "TrentService.Decrypt"
+	// kmsContentType is the content type of KMS API requests.
+	kmsContentType = "application/x-amz-json-1.1"
+	// sigV4Algorithm is the AWS request signing algorithm.
+	sigV4Algorithm = "AWS4-HMAC-SHA256"
+	// amzDateFormat is the format of the X-Amz-Date header.
+	amzDateFormat = "20060102T150405Z"
+	// maxKMSErrorBody limits how much of a KMS error response is included in errors.
+	maxKMSErrorBody = 512
+)
+
+// AWSCredentials authenticate requests to AWS.
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // Set for temporary credentials
+}
+
+// AWSKMSProvider decrypts secrets encrypted with AWS KMS, such as the output of
+// `aws kms encrypt --key-id alias/crypto-checkout --plaintext fileb://secret --query CiphertextBlob`.
+type AWSKMSProvider struct {
+	endpoint    string
+	region      string
+	credentials AWSCredentials
+	client      *http.Client
+	now         func() time.Time
+}
+
+// NewAWSKMSProvider creates a provider decrypting with KMS in the region. An empty endpoint uses the regional
+// KMS endpoint.
+func NewAWSKMSProvider(region, endpoint string, credentials AWSCredentials, client *http.Client) *AWSKMSProvider {
+	if endpoint == "" {
+		endpoint = "https://kms." + region + ".amazonaws.com"
+	}
+	return &AWSKMSProvider{
+		endpoint:    strings.TrimRight(endpoint, "/"),
+		region:      region,
+		credentials: credentials,
+		client:      client,
+		now:         time.Now,
+	}
+}
+
+// kmsDecryptRequest is the body of a KMS Decrypt request.
+type kmsDecryptRequest struct {
+	CiphertextBlob string `json:"CiphertextBlob"`
+}
+
+// kmsDecryptResponse is the body of a KMS Decrypt response.
+type kmsDecryptResponse struct {
+	Plaintext string `json:"Plaintext"`
+}
+
+// Fetch implements Provider. The path is the base64 ciphertext; the key it was encrypted with is part of it.
+// Decrypted secrets do not change, so they are cached for as long as the process runs.
+func (p *AWSKMSProvider) Fetch(ctx context.Context, ciphertext string) (Secret, error) {
+	if _, err := base64.StdEncoding.DecodeString(ciphertext); err != nil {
+		return Secret{}, fmt.Errorf("ciphertext is not base64: %w", err)
+	}
+	body, err := json.Marshal(kmsDecryptRequest{CiphertextBlob: ciphertext})
+	if err != nil {
+		return Secret{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return Secret{}, err
+	}
+	req.Header.Set("Content-Type", kmsContentType)
+	req.Header.Set("X-Amz-Target", kmsDecryptTarget)
+	p.sign(req, body)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return Secret{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, maxKMSErrorBody))
+		return Secret{}, fmt.Errorf("kms returned %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	var decrypted kmsDecryptResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decrypted); err != nil {
+		return Secret{}, fmt.Errorf("invalid kms response: %w", err)
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(decrypted.Plaintext)
+	if err != nil {
+		return Secret{}, fmt.Errorf("invalid kms plaintext: %w", err)
+	}
+	return Secret{Fields: map[string]string{"": string(plaintext)}}, nil
+}
+
+// sign signs a KMS request with AWS Signature Version 4.
+func (p *AWSKMSProvider) sign(req *http.Request, body []byte) {
+	now := p.now().UTC()
+	amzDate := now.Format(amzDateFormat)
+	scope := now.Format("20060102") + "/" + p.region + "/kms/aws4_request"
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if p.credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", p.credentials.SessionToken)
+	}
+
+	headers := []string{"content-type", "host", "x-amz-date"}
+	if p.credentials.SessionToken != "" {
+		headers = append(headers, "x-amz-security-token")
+	}
+	headers = append(headers, "x-amz-target")
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headers {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	signedHeaders := strings.Join(headers, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method, path, canonicalQuery(req.URL.Query()), canonicalHeaders.String(), signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := sigV4Algorithm + "\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+p.credentials.SecretAccessKey), now.Format("20060102"))
+	for _, part := range []string{p.region, "kms", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, p.credentials.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQuery returns the query string in the canonical form of Signature Version 4.
+func canonicalQuery(query url.Values) string {
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of the data keyed with the key.
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
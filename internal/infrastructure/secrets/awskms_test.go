@@ -0,0 +1,55 @@
+package secrets_test
+
+import (
+	"context"
+	"crypto-checkout/internal/infrastructure/secrets"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAWSKMSProvider_Fetch(t *testing.T) {
+	ciphertext := base64.StdEncoding.EncodeToString([]byte("ciphertext"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "TrentService.Decrypt", r.Header.Get("X-Amz-Target"))
+		assert.Equal(t, "session", r.Header.Get("X-Amz-Security-Token"))
+		authorization := r.Header.Get("Authorization")
+		assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), authorization)
+		assert.Contains(t, authorization, "/eu-west-1/kms/aws4_request, SignedHeaders="+
+			"content-type;host;x-amz-date;x-amz-security-token;x-amz-target, Signature=")
+
+		var body struct {
+			CiphertextBlob string
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if body.CiphertextBlob != ciphertext {
+			http.Error(w, `{"__type":"InvalidCiphertextException"}`, http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"Plaintext": base64.StdEncoding.EncodeToString([]byte("exchange-secret")),
+		})
+	}))
+	defer server.Close()
+
+	provider := secrets.NewAWSKMSProvider("eu-west-1", server.URL, secrets.AWSCredentials{
+		AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret", SessionToken: "session",
+	}, server.Client())
+	ctx := context.Background()
+
+	secret, err := provider.Fetch(ctx, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "exchange-secret", secret.Fields[""])
+
+	_, err = provider.Fetch(ctx, base64.StdEncoding.EncodeToString([]byte("other")))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "InvalidCiphertextException")
+	_, err = provider.Fetch(ctx, "not base64!")
+	require.Error(t, err)
+}
@@ -0,0 +1,199 @@
+package secrets
+
+import (
+	"context"
+	"crypto-checkout/pkg/config"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	// resolveTimeout bounds the resolution of every secret reference of the configuration at startup.
+	resolveTimeout = time.Minute
+	// renewRetryInterval is how long a failed renewal waits before it is retried.
+	renewRetryInterval = 30 * time.Second
+)
+
+// Module renews the credentials and leases of the secret providers for the lifetime of the application.
+var Module = fx.Module("secrets",
+	fx.Invoke(RegisterRenewal),
+)
+
+// NewConfigProvider loads the configuration and resolves its secret references, failing if a secret is
+// unavailable so that the application does not start without it.
+func NewConfigProvider() (*config.Config, *Resolver, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancel()
+	return LoadConfig(ctx)
+}
+
+// LoadConfig loads the configuration and resolves its secret references.
+func LoadConfig(ctx context.Context) (*config.Config, *Resolver, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	resolver, err := NewConfiguredResolver(cfg.Secrets)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := ResolveConfig(ctx, resolver, cfg); err != nil {
+		return nil, nil, err
+	}
+	return cfg, resolver, nil
+}
+
+// NewConfiguredResolver creates a resolver with the environment and file providers and the configured Vault
+// and AWS KMS providers.
+func NewConfiguredResolver(cfg config.SecretsConfig) (*Resolver, error) {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = config.DefaultSecretsTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+
+	providers := map[string]Provider{
+		SchemeEnv:  EnvProvider{},
+		SchemeFile: FileProvider{},
+	}
+
+	if cfg.Vault.Address != "" {
+		token := cfg.Vault.Token
+		if token == "" && cfg.Vault.TokenFile != "" {
+			content, err := os.ReadFile(cfg.Vault.TokenFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read secrets.vault.token_file: %w", err)
+			}
+			token = strings.TrimSpace(string(content))
+		}
+		if token == "" {
+			return nil, errors.New("secrets.vault.token or token_file is required when secrets.vault.address is set")
+		}
+		providers[SchemeVault] = NewVaultProvider(cfg.Vault.Address, token, cfg.Vault.Namespace, client)
+	}
+
+	region := firstNonEmpty(cfg.AWSKMS.Region, os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"))
+	if region != "" {
+		credentials := AWSCredentials{
+			AccessKeyID:     firstNonEmpty(cfg.AWSKMS.AccessKeyID, os.Getenv("AWS_ACCESS_KEY_ID")),
+			SecretAccessKey: firstNonEmpty(cfg.AWSKMS.SecretAccessKey, os.Getenv("AWS_SECRET_ACCESS_KEY")),
+			SessionToken:    firstNonEmpty(cfg.AWSKMS.SessionToken, os.Getenv("AWS_SESSION_TOKEN")),
+		}
+		if credentials.AccessKeyID != "" && credentials.SecretAccessKey != "" {
+			providers[SchemeAWSKMS] = NewAWSKMSProvider(region, cfg.AWSKMS.Endpoint, credentials, client)
+		}
+	}
+	return NewResolver(providers), nil
+}
+
+// ResolveConfig replaces the secret references of the configuration with the secrets they refer to.
+func ResolveConfig(ctx context.Context, resolver *Resolver, cfg *config.Config) error {
+	for name, value := range secretSettings(cfg) {
+		resolved, err := resolver.Resolve(ctx, *value)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", name, err)
+		}
+		*value = resolved
+	}
+	for i := range cfg.Database.ReplicaURLs {
+		resolved, err := resolver.Resolve(ctx, cfg.Database.ReplicaURLs[i])
+		if err != nil {
+			return fmt.Errorf("failed to resolve database.replica_urls[%d]: %w", i, err)
+		}
+		cfg.Database.ReplicaURLs[i] = resolved
+	}
+	return nil
+}
+
+// secretSettings returns the settings that may hold secret references, by name.
+func secretSettings(cfg *config.Config) map[string]*string {
+	return map[string]*string{
+		"database.password":                    &cfg.Database.Password,
+		"database.url":                         &cfg.Database.URL,
+		"encryption.keys":                      &cfg.Encryption.Keys,
+		"payout.api_key":                       &cfg.Payout.APIKey,
+		"payout.api_secret":                    &cfg.Payout.APISecret,
+		"settlement.api_key":                   &cfg.Settlement.APIKey,
+		"settlement.api_secret":                &cfg.Settlement.APISecret,
+		"compliance.api_key":                   &cfg.Compliance.APIKey,
+		"compliance.api_secret":                &cfg.Compliance.APISecret,
+		"auth.jwt_secret":                      &cfg.Auth.JWTSecret,
+		"error_reporting.dsn":                  &cfg.ErrorReporting.DSN,
+		"smtp.password":                        &cfg.SMTP.Password,
+		"statements.storage.secret_access_key": &cfg.Statements.Storage.SecretAccessKey,
+		"webhooks.proxy_url":                   &cfg.Webhooks.ProxyURL,
+	}
+}
+
+// RegisterRenewal renews the credentials and leases of the secret providers in the background, for the
+// lifetime of the application. Failed renewals are logged and retried.
+func RegisterRenewal(lc fx.Lifecycle, resolver *Resolver, logger *zap.Logger) {
+	renewers := resolver.Renewers()
+	if len(renewers) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			for _, renewer := range renewers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					runRenewal(ctx, renewer, logger)
+				}()
+			}
+			return nil
+		},
+		OnStop: func(_ context.Context) error {
+			cancel()
+			wg.Wait()
+			return nil
+		},
+	})
+}
+
+// runRenewal renews a provider whenever it asks to be renewed, until the context is canceled or nothing needs
+// renewing.
+func runRenewal(ctx context.Context, renewer Renewer, logger *zap.Logger) {
+	for {
+		next, err := renewer.Renew(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Error("Failed to renew secret provider credentials", zap.Error(err))
+			next = renewRetryInterval
+		}
+		if next <= 0 {
+			return
+		}
+
+		timer := time.NewTimer(next)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// firstNonEmpty returns the first of the values that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// EnvProvider reads secrets from environment variables.
+type EnvProvider struct{}
+
+// Fetch implements Provider.
+func (EnvProvider) Fetch(_ context.Context, name string) (Secret, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return Secret{}, fmt.Errorf("%w: environment variable %s is not set", ErrNotFound, name)
+	}
+	return Secret{Fields: map[string]string{"": value}}, nil
+}
+
+// FileProvider reads secrets from files, such as those mounted by Kubernetes or written by a Vault agent.
+type FileProvider struct{}
+
+// Fetch implements Provider. The trailing newline most tools write is removed.
+func (FileProvider) Fetch(_ context.Context, path string) (Secret, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Secret{}, fmt.Errorf("%w: %s does not exist", ErrNotFound, path)
+	}
+	if err != nil {
+		return Secret{}, err
+	}
+	return Secret{Fields: map[string]string{"": strings.TrimRight(string(content), "\r\n")}}, nil
+}
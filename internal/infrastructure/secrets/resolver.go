@@ -0,0 +1,159 @@
+// Package secrets resolves the secret references of the configuration, such as a database password kept in
+// HashiCorp Vault, so that secrets need not be written into config files or the environment of the service.
+//
+// A reference names its provider and the secret:
+//
+//	env:NAME                   the environment variable NAME
+//	file:/path                 the contents of a file, without the trailing newline
+//	vault:path#field           a field of a Vault secret, e.g. vault:secret/data/crypto-checkout#db_password
+//	awskms:base64-ciphertext   a value encrypted with AWS KMS, decrypted with the kms:Decrypt permission
+//
+// Values without one of these prefixes are used as they are.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Provider schemes of secret references.
+const (
+	SchemeEnv    = "env"
+	SchemeFile   = "file"
+	SchemeVault  = "vault"
+	SchemeAWSKMS = "awskms"
+)
+
+var (
+	// ErrNotFound is returned for a reference to a secret that does not exist.
+	ErrNotFound = errors.New("secret not found")
+	// ErrProviderNotConfigured is returned for a reference to a provider that is not configured.
+	ErrProviderNotConfigured = errors.New("secret provider is not configured")
+)
+
+// Secret is a secret read from a provider.
+type Secret struct {
+	// Fields holds the values of a secret with several, such as a Vault secret, by name; single values are
+	// under the empty name.
+	Fields map[string]string
+	// TTL is how long the secret may be cached; zero for as long as the process runs.
+	TTL time.Duration
+}
+
+// Provider reads secrets from a secret store.
+type Provider interface {
+	// Fetch reads the secret at the path, the part of a reference after the scheme and before any #field.
+	Fetch(ctx context.Context, path string) (Secret, error)
+}
+
+// Renewer is a provider holding credentials or leases that expire unless renewed, such as a Vault token.
+type Renewer interface {
+	// Renew renews the credentials and leases, and returns how long until they must be renewed again; zero if
+	// nothing needs renewing.
+	Renew(ctx context.Context) (time.Duration, error)
+}
+
+// cachedSecret is a secret in the cache of a Resolver.
+type cachedSecret struct {
+	secret  Secret
+	expires time.Time // Zero for never
+}
+
+// Resolver resolves secret references with the configured providers, caching the secrets read.
+type Resolver struct {
+	providers map[string]Provider
+	now       func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedSecret
+}
+
+// NewResolver creates a resolver of references to the providers, by scheme. References to the other schemes
+// fail with ErrProviderNotConfigured.
+func NewResolver(providers map[string]Provider) *Resolver {
+	return &Resolver{
+		providers: providers,
+		now:       time.Now,
+		cache:     make(map[string]cachedSecret),
+	}
+}
+
+// IsReference reports whether a configuration value is a secret reference rather than the secret itself.
+func IsReference(value string) bool {
+	scheme, _, ok := strings.Cut(value, ":")
+	if !ok {
+		return false
+	}
+	switch scheme {
+	case SchemeEnv, SchemeFile, SchemeVault, SchemeAWSKMS:
+		return true
+	default:
+		return false
+	}
+}
+
+// Resolve returns the secret a configuration value refers to, or the value itself if it is not a reference.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	if !IsReference(value) {
+		return value, nil
+	}
+	scheme, rest, _ := strings.Cut(value, ":")
+	path, field := rest, ""
+	if scheme == SchemeVault {
+		path, field, _ = strings.Cut(rest, "#")
+	}
+
+	provider, ok := r.providers[scheme]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrProviderNotConfigured, scheme)
+	}
+	secret, err := r.fetch(ctx, scheme, path, provider)
+	if err != nil {
+		return "", err
+	}
+
+	resolved, ok := secret.Fields[field]
+	if !ok {
+		return "", fmt.Errorf("%w: field %q of %s:%s", ErrNotFound, field, scheme, path)
+	}
+	return resolved, nil
+}
+
+// fetch reads a secret through the cache.
+func (r *Resolver) fetch(ctx context.Context, scheme, path string, provider Provider) (Secret, error) {
+	key := scheme + ":" + path
+	r.mu.Lock()
+	cached, ok := r.cache[key]
+	r.mu.Unlock()
+	if ok && (cached.expires.IsZero() || r.now().Before(cached.expires)) {
+		return cached.secret, nil
+	}
+
+	secret, err := provider.Fetch(ctx, path)
+	if err != nil {
+		return Secret{}, fmt.Errorf("failed to read %s secret %s: %w", scheme, path, err)
+	}
+	cached = cachedSecret{secret: secret}
+	if secret.TTL > 0 {
+		cached.expires = r.now().Add(secret.TTL)
+	}
+	r.mu.Lock()
+	r.cache[key] = cached
+	r.mu.Unlock()
+	return secret, nil
+}
+
+// Renewers returns the providers whose credentials or leases must be renewed.
+func (r *Resolver) Renewers() []Renewer {
+	var renewers []Renewer
+	for _, provider := range r.providers {
+		if renewer, ok := provider.(Renewer); ok {
+			renewers = append(renewers, renewer)
+		}
+	}
+	return renewers
+}
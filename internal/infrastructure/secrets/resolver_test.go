@@ -0,0 +1,104 @@
+package secrets_test
+
+import (
+	"context"
+	"crypto-checkout/internal/infrastructure/secrets"
+	"crypto-checkout/pkg/config"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingProvider returns a fixed secret and counts the reads.
+type countingProvider struct {
+	secret secrets.Secret
+	reads  int
+}
+
+func (p *countingProvider) Fetch(_ context.Context, _ string) (secrets.Secret, error) {
+	p.reads++
+	return p.secret, nil
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	t.Setenv("CHECKOUT_TEST_SECRET", "from-env")
+	file := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(file, []byte("from-file\n"), 0o600))
+
+	resolver := secrets.NewResolver(map[string]secrets.Provider{
+		secrets.SchemeEnv:  secrets.EnvProvider{},
+		secrets.SchemeFile: secrets.FileProvider{},
+	})
+	ctx := context.Background()
+
+	for value, expected := range map[string]string{
+		"env:CHECKOUT_TEST_SECRET":    "from-env",
+		"file:" + file:                "from-file",
+		"literal":                     "literal",
+		"postgres://user:pw@db/check": "postgres://user:pw@db/check",
+		"":                            "",
+	} {
+		resolved, err := resolver.Resolve(ctx, value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, resolved, value)
+	}
+
+	_, err := resolver.Resolve(ctx, "env:CHECKOUT_TEST_MISSING")
+	require.ErrorIs(t, err, secrets.ErrNotFound)
+	_, err = resolver.Resolve(ctx, "file:"+filepath.Join(t.TempDir(), "missing"))
+	require.ErrorIs(t, err, secrets.ErrNotFound)
+	_, err = resolver.Resolve(ctx, "vault:secret/data/checkout#password")
+	require.ErrorIs(t, err, secrets.ErrProviderNotConfigured)
+}
+
+func TestResolver_Cache(t *testing.T) {
+	static := &countingProvider{secret: secrets.Secret{Fields: map[string]string{"user": "u", "password": "p"}}}
+	leased := &countingProvider{secret: secrets.Secret{Fields: map[string]string{"": "v"}, TTL: time.Nanosecond}}
+	resolver := secrets.NewResolver(map[string]secrets.Provider{
+		secrets.SchemeVault:  static,
+		secrets.SchemeAWSKMS: leased,
+	})
+	ctx := context.Background()
+
+	user, err := resolver.Resolve(ctx, "vault:database/creds#user")
+	require.NoError(t, err)
+	password, err := resolver.Resolve(ctx, "vault:database/creds#password")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u", "p"}, []string{user, password})
+	assert.Equal(t, 1, static.reads, "fields of one secret are read once")
+
+	_, err = resolver.Resolve(ctx, "vault:database/creds#missing")
+	require.ErrorIs(t, err, secrets.ErrNotFound)
+
+	for range 2 {
+		_, err = resolver.Resolve(ctx, "awskms:AQID")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, leased.reads, "expired secrets are read again")
+}
+
+func TestResolveConfig(t *testing.T) {
+	t.Setenv("CHECKOUT_TEST_DB_PASSWORD", "s3cret")
+	t.Setenv("CHECKOUT_TEST_REPLICA", "postgres://replica")
+
+	cfg := config.NewConfig()
+	cfg.Database.Password = "env:CHECKOUT_TEST_DB_PASSWORD"
+	cfg.Database.ReplicaURLs = []string{"env:CHECKOUT_TEST_REPLICA"}
+	cfg.Settlement.APIKey = "plain-key"
+
+	resolver, err := secrets.NewConfiguredResolver(cfg.Secrets)
+	require.NoError(t, err)
+	require.NoError(t, secrets.ResolveConfig(context.Background(), resolver, cfg))
+	assert.Equal(t, "s3cret", cfg.Database.Password)
+	assert.Equal(t, []string{"postgres://replica"}, cfg.Database.ReplicaURLs)
+	assert.Equal(t, "plain-key", cfg.Settlement.APIKey)
+
+	cfg.Payout.APISecret = "vault:secret/data/signer#api_secret"
+	err = secrets.ResolveConfig(context.Background(), resolver, cfg)
+	require.ErrorIs(t, err, secrets.ErrProviderNotConfigured)
+	assert.Contains(t, err.Error(), "payout.api_secret")
+}
@@ -0,0 +1,195 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// vaultTokenHeader carries the Vault token.
+	vaultTokenHeader = "X-Vault-Token"
+	// vaultNamespaceHeader carries the Vault Enterprise namespace.
+	vaultNamespaceHeader = "X-Vault-Namespace"
+	// maxVaultErrorBody limits how much of a Vault error response is included in errors.
+	maxVaultErrorBody = 512
+)
+
+// VaultProvider reads secrets from HashiCorp Vault over its HTTP API, from KV version 1 and 2 mounts and from
+// dynamic secrets engines. It renews its token and the leases of the dynamic secrets it read.
+type VaultProvider struct {
+	address   string
+	token     string
+	namespace string
+	client    *http.Client
+
+	mu     sync.Mutex
+	leases map[string]time.Duration // Renewable leases by ID, with their duration
+}
+
+// NewVaultProvider creates a Vault provider authenticating with the token.
+func NewVaultProvider(address, token, namespace string, client *http.Client) *VaultProvider {
+	return &VaultProvider{
+		address:   strings.TrimRight(address, "/"),
+		token:     token,
+		namespace: namespace,
+		client:    client,
+		leases:    make(map[string]time.Duration),
+	}
+}
+
+// vaultSecretResponse is a secret as returned by Vault. KV version 2 nests the fields under data.data.
+type vaultSecretResponse struct {
+	LeaseID       string                     `json:"lease_id"`
+	LeaseDuration int64                      `json:"lease_duration"`
+	Renewable     bool                       `json:"renewable"`
+	Data          map[string]json.RawMessage `json:"data"`
+}
+
+// vaultAuthResponse is the response of a token renewal.
+type vaultAuthResponse struct {
+	Auth struct {
+		LeaseDuration int64 `json:"lease_duration"`
+		Renewable     bool  `json:"renewable"`
+	} `json:"auth"`
+}
+
+// vaultLookupResponse is the response of a token lookup.
+type vaultLookupResponse struct {
+	Data struct {
+		TTL       int64 `json:"ttl"`
+		Renewable bool  `json:"renewable"`
+	} `json:"data"`
+}
+
+// Fetch implements Provider. The path is the API path without /v1, e.g. "secret/data/crypto-checkout" for a KV
+// version 2 mount named secret.
+func (p *VaultProvider) Fetch(ctx context.Context, path string) (Secret, error) {
+	var response vaultSecretResponse
+	if err := p.do(ctx, http.MethodGet, "/v1/"+strings.TrimLeft(path, "/"), nil, &response); err != nil {
+		return Secret{}, err
+	}
+
+	data := response.Data
+	if nested, ok := data["data"]; ok {
+		if _, kv2 := data["metadata"]; kv2 {
+			data = nil
+			if err := json.Unmarshal(nested, &data); err != nil {
+				return Secret{}, fmt.Errorf("invalid vault secret: %w", err)
+			}
+		}
+	}
+
+	fields := make(map[string]string, len(data))
+	for name, raw := range data {
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			value = string(raw)
+		}
+		fields[name] = value
+	}
+
+	lease := time.Duration(response.LeaseDuration) * time.Second
+	if response.LeaseID != "" && response.Renewable && lease > 0 {
+		p.mu.Lock()
+		p.leases[response.LeaseID] = lease
+		p.mu.Unlock()
+	}
+	return Secret{Fields: fields, TTL: lease}, nil
+}
+
+// Renew implements Renewer. It renews the token, if renewable, and the leases of the dynamic secrets read, and
+// returns half of the shortest remaining duration.
+func (p *VaultProvider) Renew(ctx context.Context) (time.Duration, error) {
+	var next time.Duration
+	sooner := func(duration time.Duration) {
+		if duration > 0 && (next == 0 || duration/2 < next) {
+			next = duration / 2
+		}
+	}
+
+	var lookup vaultLookupResponse
+	if err := p.do(ctx, http.MethodGet, "/v1/auth/token/lookup-self", nil, &lookup); err != nil {
+		return 0, fmt.Errorf("failed to look up vault token: %w", err)
+	}
+	if lookup.Data.Renewable && lookup.Data.TTL > 0 {
+		var renewed vaultAuthResponse
+		if err := p.do(ctx, http.MethodPost, "/v1/auth/token/renew-self", struct{}{}, &renewed); err != nil {
+			return 0, fmt.Errorf("failed to renew vault token: %w", err)
+		}
+		sooner(time.Duration(renewed.Auth.LeaseDuration) * time.Second)
+	}
+
+	p.mu.Lock()
+	leases := make([]string, 0, len(p.leases))
+	for id := range p.leases {
+		leases = append(leases, id)
+	}
+	p.mu.Unlock()
+
+	for _, id := range leases {
+		var renewed vaultSecretResponse
+		body := map[string]string{"lease_id": id}
+		if err := p.do(ctx, http.MethodPut, "/v1/sys/leases/renew", body, &renewed); err != nil {
+			return 0, fmt.Errorf("failed to renew vault lease %s: %w", id, err)
+		}
+		duration := time.Duration(renewed.LeaseDuration) * time.Second
+		p.mu.Lock()
+		if renewed.Renewable && duration > 0 {
+			p.leases[id] = duration
+		} else {
+			delete(p.leases, id)
+		}
+		p.mu.Unlock()
+		sooner(duration)
+	}
+	return next, nil
+}
+
+// do sends a request to Vault and decodes the JSON response.
+func (p *VaultProvider) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.address+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(vaultTokenHeader, p.token)
+	if p.namespace != "" {
+		req.Header.Set(vaultNamespaceHeader, p.namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrNotFound, path)
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, maxVaultErrorBody))
+		return fmt.Errorf("vault returned %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid vault response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package secrets_test
+
+import (
+	"context"
+	"crypto-checkout/internal/infrastructure/secrets"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVault serves the Vault API endpoints the provider uses.
+type fakeVault struct {
+	mu       sync.Mutex
+	renewals []string
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Vault-Token") != "s.token" {
+		http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+		return
+	}
+
+	var response interface{}
+	switch r.Method + " " + r.URL.Path {
+	case "GET /v1/secret/data/checkout":
+		response = map[string]interface{}{
+			"data": map[string]interface{}{
+				"data":     map[string]interface{}{"db_password": "kv2-password", "port": 5432},
+				"metadata": map[string]interface{}{"version": 3},
+			},
+		}
+	case "GET /v1/kv/checkout":
+		response = map[string]interface{}{
+			"lease_duration": 2764800,
+			"data":           map[string]interface{}{"api_secret": "kv1-secret"},
+		}
+	case "GET /v1/database/creds/checkout":
+		response = map[string]interface{}{
+			"lease_id": "database/creds/checkout/abc", "lease_duration": 3600, "renewable": true,
+			"data": map[string]interface{}{"username": "v-checkout", "password": "dynamic"},
+		}
+	case "GET /v1/auth/token/lookup-self":
+		response = map[string]interface{}{"data": map[string]interface{}{"ttl": 600, "renewable": true}}
+	case "POST /v1/auth/token/renew-self":
+		v.record("token")
+		response = map[string]interface{}{"auth": map[string]interface{}{"lease_duration": 1200, "renewable": true}}
+	case "PUT /v1/sys/leases/renew":
+		var body struct {
+			LeaseID string `json:"lease_id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		v.record(body.LeaseID)
+		response = map[string]interface{}{"lease_id": body.LeaseID, "lease_duration": 300, "renewable": true}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (v *fakeVault) record(renewal string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.renewals = append(v.renewals, renewal)
+}
+
+func TestVaultProvider_Fetch(t *testing.T) {
+	server := httptest.NewServer(&fakeVault{})
+	defer server.Close()
+	provider := secrets.NewVaultProvider(server.URL, "s.token", "", server.Client())
+	ctx := context.Background()
+
+	secret, err := provider.Fetch(ctx, "secret/data/checkout")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"db_password": "kv2-password", "port": "5432"}, secret.Fields)
+	assert.Zero(t, secret.TTL)
+
+	secret, err = provider.Fetch(ctx, "kv/checkout")
+	require.NoError(t, err)
+	assert.Equal(t, "kv1-secret", secret.Fields["api_secret"])
+	assert.Equal(t, 768*time.Hour, secret.TTL)
+
+	_, err = provider.Fetch(ctx, "secret/data/missing")
+	require.ErrorIs(t, err, secrets.ErrNotFound)
+
+	denied := secrets.NewVaultProvider(server.URL, "s.wrong", "", server.Client())
+	_, err = denied.Fetch(ctx, "secret/data/checkout")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+}
+
+func TestVaultProvider_Renew(t *testing.T) {
+	vault := &fakeVault{}
+	server := httptest.NewServer(vault)
+	defer server.Close()
+	provider := secrets.NewVaultProvider(server.URL, "s.token", "", server.Client())
+	ctx := context.Background()
+
+	next, err := provider.Renew(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, next, "half the renewed token TTL")
+
+	_, err = provider.Fetch(ctx, "database/creds/checkout")
+	require.NoError(t, err)
+	next, err = provider.Renew(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 150*time.Second, next, "half the shortest lease")
+	assert.Equal(t, []string{"token", "token", "database/creds/checkout/abc"}, vault.renewals)
+}
@@ -0,0 +1,38 @@
+package supervisor
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/pkg/config"
+	"errors"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides the background worker supervisor for Fx.
+var Module = fx.Module("supervisor",
+	fx.Provide(
+		fx.Annotate(
+			NewSupervisorProvider,
+			fx.As(fx.Self()),
+			fx.As(new(shared.WorkerSupervisor)),
+		),
+	),
+)
+
+// NewSupervisorProvider creates the worker supervisor from configuration. Worker failures are reported.
+func NewSupervisorProvider(
+	cfg *config.Config,
+	reporter shared.ErrorReporter,
+	logger *zap.Logger,
+) (*Supervisor, error) {
+	workers := cfg.Workers
+	if workers.StallTimeout < 0 || workers.RestartBackoff < 0 || workers.MaxRestartBackoff < 0 {
+		return nil, errors.New("invalid workers: durations must not be negative")
+	}
+	return NewSupervisor(Policy{
+		StallTimeout:      workers.StallTimeout,
+		RestartBackoff:    workers.RestartBackoff,
+		MaxRestartBackoff: workers.MaxRestartBackoff,
+	}, logger).WithReporter(reporter), nil
+}
@@ -0,0 +1,333 @@
+// Package supervisor runs the background workers of the crypto-checkout application, restarting the ones that
+// panic or exit and reporting the ones that stall.
+package supervisor
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"expvar"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// StateRunning indicates a worker is running and beating.
+	StateRunning = "running"
+	// StateStalled indicates a running worker has not beaten within its interval and the stall timeout.
+	StateStalled = "stalled"
+	// StateRestarting indicates a worker failed and waits out its backoff before it is restarted.
+	StateRestarting = "restarting"
+	// StateStopped indicates a worker stopped with the application.
+	StateStopped = "stopped"
+)
+
+var (
+	// ErrWorkerExited is the failure of a worker that returned while the application was still running.
+	ErrWorkerExited = errors.New("worker exited")
+	// ErrWorkerStalled is the failure of a worker that was cancelled after it stopped beating.
+	ErrWorkerStalled = errors.New("worker stalled")
+)
+
+// workerMetrics publishes the status of each supervised worker under workers on /debug/vars.
+var workerMetrics = expvar.NewMap("workers")
+
+// Policy configures how the background workers are supervised.
+type Policy struct {
+	// StallTimeout is how long past its interval a worker may go without beating before it is stalled.
+	StallTimeout time.Duration
+	// RestartBackoff is the wait before a failed worker is restarted, doubled on each failure in a row.
+	RestartBackoff time.Duration
+	// MaxRestartBackoff caps the wait before a restart. A worker that ran for longer than it since its last
+	// restart waits RestartBackoff again.
+	MaxRestartBackoff time.Duration
+}
+
+// DefaultPolicy stalls workers that have not beaten for 5 minutes past their interval, and restarts failed
+// workers after 1 second, backing off to 5 minutes.
+func DefaultPolicy() Policy {
+	return Policy{
+		StallTimeout:      5 * time.Minute,
+		RestartBackoff:    time.Second,
+		MaxRestartBackoff: 5 * time.Minute,
+	}
+}
+
+// WorkerStatus represents the liveness of a supervised worker.
+type WorkerStatus struct {
+	Name       string    `json:"name"`
+	State      string    `json:"state"`
+	LastBeatAt time.Time `json:"last_beat_at"`
+	// Restarts counts the failures of the worker, including panics and stalls.
+	Restarts      int        `json:"restarts"`
+	Panics        int        `json:"panics"`
+	LastError     string     `json:"last_error,omitempty"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+}
+
+// Stalled reports whether the worker stopped beating.
+func (s WorkerStatus) Stalled() bool {
+	return s.State == StateStalled
+}
+
+// worker is the heartbeat registry entry of a supervised worker.
+type worker struct {
+	name        string
+	interval    time.Duration
+	state       string
+	lastBeat    time.Time
+	restarts    int
+	panics      int
+	lastError   string
+	lastFailure time.Time
+}
+
+// panicError is the failure of a worker that panicked, with the stack it panicked on.
+type panicError struct {
+	value any
+	stack []byte
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("worker panicked: %v", e.value)
+}
+
+// Supervisor runs background workers, keeping a heartbeat per worker. It implements shared.WorkerSupervisor.
+type Supervisor struct {
+	policy   Policy
+	reporter shared.ErrorReporter
+	logger   *zap.Logger
+	now      func() time.Time
+
+	mu      sync.Mutex
+	workers map[string]*worker
+}
+
+// NewSupervisor creates a new worker supervisor. Zero policy values take their defaults.
+func NewSupervisor(policy Policy, logger *zap.Logger) *Supervisor {
+	defaults := DefaultPolicy()
+	if policy.StallTimeout <= 0 {
+		policy.StallTimeout = defaults.StallTimeout
+	}
+	if policy.RestartBackoff <= 0 {
+		policy.RestartBackoff = defaults.RestartBackoff
+	}
+	if policy.MaxRestartBackoff < policy.RestartBackoff {
+		policy.MaxRestartBackoff = max(defaults.MaxRestartBackoff, policy.RestartBackoff)
+	}
+	return &Supervisor{
+		policy:  policy,
+		logger:  logger,
+		now:     time.Now,
+		workers: make(map[string]*worker),
+	}
+}
+
+// WithReporter makes the supervisor report the failures of its workers, a panic with its stack.
+func (s *Supervisor) WithReporter(reporter shared.ErrorReporter) *Supervisor {
+	s.reporter = reporter
+	return s
+}
+
+// Supervise runs a worker until the context is cancelled. A worker that panics, returns early, or is cancelled
+// after it stalled is restarted after a backoff; every failure is logged with the worker's name, and a panic
+// with its stack.
+func (s *Supervisor) Supervise(ctx context.Context, name string, interval time.Duration, run func(context.Context)) {
+	w := s.register(name, interval)
+	backoff := s.policy.RestartBackoff
+
+	for {
+		started := s.now()
+		err := s.runOnce(ctx, w, run)
+		if err == nil {
+			s.update(w, func() { w.state = StateStopped })
+			return
+		}
+		if s.now().Sub(started) > s.policy.MaxRestartBackoff {
+			backoff = s.policy.RestartBackoff
+		}
+
+		fields := []zap.Field{
+			zap.String("worker", name),
+			zap.Error(err),
+			zap.Int("restarts", s.Status(name).Restarts),
+			zap.Duration("backoff", backoff),
+		}
+		var panicked *panicError
+		if errors.As(err, &panicked) {
+			fields = append(fields, zap.ByteString("stack", panicked.stack))
+		}
+		s.logger.Error("Background worker failed; restarting", fields...)
+		s.report(ctx, name, err)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.update(w, func() { w.state = StateStopped })
+			return
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, s.policy.MaxRestartBackoff)
+	}
+}
+
+// runOnce runs a worker until it exits, returning nil when it stopped with the context and its failure
+// otherwise. A worker found stalled is cancelled, so a worker that is stuck waiting on its context is restarted.
+func (s *Supervisor) runOnce(ctx context.Context, w *worker, run func(context.Context)) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s.update(w, func() {
+		w.state = StateRunning
+		w.lastBeat = s.now()
+	})
+	exited := make(chan error, 1)
+	go func() {
+		defer func() {
+			if value := recover(); value != nil {
+				exited <- &panicError{value: value, stack: debug.Stack()}
+			}
+		}()
+		run(shared.WithHeartbeat(runCtx, func() { s.beat(w) }))
+		exited <- ErrWorkerExited
+	}()
+
+	ticker := time.NewTicker(s.checkInterval(w))
+	defer ticker.Stop()
+	stalled := false
+	for {
+		select {
+		case err := <-exited:
+			if ctx.Err() != nil {
+				return nil
+			}
+			if stalled && errors.Is(err, ErrWorkerExited) {
+				err = ErrWorkerStalled
+			}
+			s.fail(w, err)
+			return err
+		case <-ticker.C:
+			if status := s.Status(w.name); !stalled && status.Stalled() {
+				stalled = true
+				s.logger.Error("Background worker stalled; cancelling it",
+					zap.String("worker", w.name),
+					zap.Time("last_beat_at", status.LastBeatAt),
+				)
+				cancel()
+			}
+		}
+	}
+}
+
+// report sends the failure of a worker to the error reporter, when there is one.
+func (s *Supervisor) report(ctx context.Context, name string, err error) {
+	if s.reporter == nil {
+		return
+	}
+	tags := map[string]string{"worker": name}
+	var panicked *panicError
+	if errors.As(err, &panicked) {
+		s.reporter.CapturePanic(ctx, panicked.value, panicked.stack, tags)
+		return
+	}
+	s.reporter.CaptureError(ctx, err, tags)
+}
+
+// checkInterval returns how often a worker is checked for a stall.
+func (s *Supervisor) checkInterval(w *worker) time.Duration {
+	return max((w.interval+s.policy.StallTimeout)/4, time.Millisecond)
+}
+
+// Status returns the status of a worker, or a zero status for a worker that was never supervised.
+func (s *Supervisor) Status(name string) WorkerStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, ok := s.workers[name]
+	if !ok {
+		return WorkerStatus{Name: name}
+	}
+	return s.status(w)
+}
+
+// Statuses returns the status of every supervised worker, ordered by name.
+func (s *Supervisor) Statuses() []WorkerStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]WorkerStatus, 0, len(s.workers))
+	for _, w := range s.workers {
+		statuses = append(statuses, s.status(w))
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// status returns the status of a worker, stalled when it is running without beating. Callers hold the lock.
+func (s *Supervisor) status(w *worker) WorkerStatus {
+	status := WorkerStatus{
+		Name:       w.name,
+		State:      w.state,
+		LastBeatAt: w.lastBeat.UTC(),
+		Restarts:   w.restarts,
+		Panics:     w.panics,
+		LastError:  w.lastError,
+	}
+	if w.state == StateRunning && s.now().Sub(w.lastBeat) > w.interval+s.policy.StallTimeout {
+		status.State = StateStalled
+	}
+	if !w.lastFailure.IsZero() {
+		failedAt := w.lastFailure.UTC()
+		status.LastFailureAt = &failedAt
+	}
+	return status
+}
+
+// register adds a worker to the heartbeat registry and publishes its status on /debug/vars.
+func (s *Supervisor) register(name string, interval time.Duration) *worker {
+	s.mu.Lock()
+	w := &worker{name: name, interval: interval, state: StateRunning, lastBeat: s.now()}
+	s.workers[name] = w
+	s.mu.Unlock()
+
+	workerMetrics.Set(name, expvar.Func(func() any {
+		return s.Status(name)
+	}))
+	return w
+}
+
+// beat records that a worker made progress.
+func (s *Supervisor) beat(w *worker) {
+	s.update(w, func() {
+		w.lastBeat = s.now()
+	})
+}
+
+// fail records the failure of a worker.
+func (s *Supervisor) fail(w *worker, err error) {
+	s.update(w, func() {
+		w.state = StateRestarting
+		w.restarts++
+		var panicked *panicError
+		if errors.As(err, &panicked) {
+			w.panics++
+		}
+		w.lastError = err.Error()
+		w.lastFailure = s.now()
+	})
+}
+
+// update changes a worker under the lock readers take.
+func (s *Supervisor) update(w *worker, change func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	change()
+}
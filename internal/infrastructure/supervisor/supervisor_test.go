@@ -0,0 +1,140 @@
+package supervisor
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testPolicy stalls and restarts workers within milliseconds.
+var testPolicy = Policy{
+	StallTimeout:      20 * time.Millisecond,
+	RestartBackoff:    time.Millisecond,
+	MaxRestartBackoff: 5 * time.Millisecond,
+}
+
+// supervise runs a worker in the background until the test ends.
+func supervise(t *testing.T, s *Supervisor, name string, run func(context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Supervise(ctx, name, time.Millisecond, run)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		assert.Equal(t, StateStopped, s.Status(name).State)
+	})
+}
+
+// panicReporter keeps the panics it is given, by worker.
+type panicReporter struct {
+	mu     sync.Mutex
+	panics map[string]any
+}
+
+func (r *panicReporter) CaptureError(context.Context, error, map[string]string) {}
+
+func (r *panicReporter) CapturePanic(_ context.Context, recovered any, _ []byte, tags map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.panics[tags["worker"]] = recovered
+}
+
+// beatUntilDone beats every millisecond until the context is cancelled.
+func beatUntilDone(ctx context.Context) {
+	for {
+		shared.Beat(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestSupervisorRestartsPanickedWorkers(t *testing.T) {
+	reporter := &panicReporter{panics: make(map[string]any)}
+	s := NewSupervisor(testPolicy, zap.NewNop()).WithReporter(reporter)
+	var runs atomic.Int32
+	supervise(t, s, "panicky", func(ctx context.Context) {
+		if runs.Add(1) == 1 {
+			panic("boom")
+		}
+		beatUntilDone(ctx)
+	})
+
+	require.Eventually(t, func() bool { return runs.Load() == 2 }, time.Second, time.Millisecond)
+	status := s.Status("panicky")
+	assert.Equal(t, StateRunning, status.State)
+	assert.Equal(t, 1, status.Restarts)
+	assert.Equal(t, 1, status.Panics)
+	assert.Equal(t, "worker panicked: boom", status.LastError)
+	require.NotNil(t, status.LastFailureAt)
+
+	reporter.mu.Lock()
+	defer reporter.mu.Unlock()
+	assert.Equal(t, "boom", reporter.panics["panicky"])
+}
+
+func TestSupervisorRestartsExitedWorkers(t *testing.T) {
+	s := NewSupervisor(testPolicy, zap.NewNop())
+	var runs atomic.Int32
+	supervise(t, s, "quitter", func(ctx context.Context) {
+		if runs.Add(1) < 3 {
+			return
+		}
+		beatUntilDone(ctx)
+	})
+
+	require.Eventually(t, func() bool { return runs.Load() == 3 }, time.Second, time.Millisecond)
+	status := s.Status("quitter")
+	assert.Equal(t, 2, status.Restarts)
+	assert.Zero(t, status.Panics)
+	assert.Equal(t, ErrWorkerExited.Error(), status.LastError)
+}
+
+func TestSupervisorCancelsStalledWorkers(t *testing.T) {
+	s := NewSupervisor(testPolicy, zap.NewNop())
+	var runs atomic.Int32
+	supervise(t, s, "sleepy", func(ctx context.Context) {
+		if runs.Add(1) == 1 {
+			// Waits on its context without beating, as a worker stuck on a hung call would
+			<-ctx.Done()
+			return
+		}
+		beatUntilDone(ctx)
+	})
+
+	require.Eventually(t, func() bool { return runs.Load() == 2 }, time.Second, time.Millisecond)
+	status := s.Status("sleepy")
+	assert.Equal(t, 1, status.Restarts)
+	assert.Equal(t, ErrWorkerStalled.Error(), status.LastError)
+}
+
+func TestSupervisorReportsStuckWorkersAsStalled(t *testing.T) {
+	s := NewSupervisor(testPolicy, zap.NewNop())
+	release := make(chan struct{})
+	supervise(t, s, "stuck", func(context.Context) {
+		// Ignores cancellation until released, so it cannot be restarted
+		<-release
+	})
+	supervise(t, s, "healthy", beatUntilDone)
+
+	require.Eventually(t, func() bool { return s.Status("stuck").Stalled() }, time.Second, time.Millisecond)
+	statuses := s.Statuses()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "healthy", statuses[0].Name)
+	assert.Equal(t, StateRunning, statuses[0].State)
+	assert.Equal(t, "stuck", statuses[1].Name)
+	assert.Equal(t, StateStalled, statuses[1].State)
+	close(release)
+}
@@ -0,0 +1,46 @@
+package webhooksender
+
+import (
+	"crypto-checkout/internal/domain/webhook"
+	"crypto-checkout/pkg/config"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"go.uber.org/fx"
+)
+
+// Module provides the sender of merchants' webhooks for Fx.
+var Module = fx.Module("webhooksender",
+	fx.Provide(NewSenderProvider),
+)
+
+// NewSenderProvider creates the sender of merchants' webhooks. Each webhook is bounded by its endpoint's timeout,
+// and is not redirected: it only goes to the URL the merchant configured. With webhooks.proxy_url, webhooks are
+// sent through that proxy, so that they leave from its addresses.
+func NewSenderProvider(cfg *config.Config) (webhook.Sender, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.Webhooks.ProxyURL != "" {
+		proxy, err := parseProxyURL(cfg.Webhooks.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	return NewHTTPSender(&http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}), nil
+}
+
+// parseProxyURL parses the webhook proxy URL. Errors leave the URL out, as it may hold the proxy credentials.
+func parseProxyURL(raw string) (*url.URL, error) {
+	proxy, err := url.Parse(raw)
+	if err != nil || (proxy.Scheme != "http" && proxy.Scheme != "https") || proxy.Host == "" {
+		return nil, errors.New("webhooks.proxy_url: must be an http or https URL")
+	}
+	return proxy, nil
+}
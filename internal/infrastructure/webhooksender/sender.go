@@ -0,0 +1,92 @@
+// Package webhooksender sends merchants' webhooks to their endpoints over HTTP.
+package webhooksender
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/webhook"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DeliveryHeader carries the delivery ID, which differs between the deliveries of an event.
+	DeliveryHeader = "X-Webhook-Delivery"
+	// TimestampHeader carries the Unix time, in seconds, at which a webhook was signed.
+	TimestampHeader = "X-Webhook-Timestamp"
+	// SignatureHeader carries the webhook signature; see Sign. While a rotated-out secret overlaps its replacement,
+	// the signature made with it follows, separated by a comma, and receivers accept either.
+	SignatureHeader = "X-Webhook-Signature"
+
+	// userAgent identifies webhooks to the endpoints receiving them.
+	userAgent = "crypto-checkout-webhooks/1.0"
+)
+
+// HTTPSender posts webhooks signed with the secret of their endpoint.
+type HTTPSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewHTTPSender creates a sender sending with the client.
+func NewHTTPSender(client *http.Client) *HTTPSender {
+	return &HTTPSender{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Send implements webhook.Sender. The endpoint's custom headers are sent as well, but cannot replace the
+// signature headers.
+func (s *HTTPSender) Send(ctx context.Context, req *webhook.Request) (*webhook.Response, error) {
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, value := range req.Headers {
+		httpReq.Header.Set(name, value)
+	}
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", userAgent)
+	httpReq.Header.Set(DeliveryHeader, req.DeliveryID)
+	httpReq.Header.Set(TimestampHeader, timestamp)
+	signature := Sign(req.Secret, timestamp, req.Body)
+	if req.PreviousSecret != "" {
+		signature += "," + Sign(req.PreviousSecret, timestamp, req.Body)
+	}
+	httpReq.Header.Set(SignatureHeader, signature)
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send webhook to %s: %w", req.URL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	snapshot, _ := io.ReadAll(io.LimitReader(resp.Body, webhook.MaxResponseSnapshot))
+	return &webhook.Response{
+		StatusCode: resp.StatusCode,
+		Body:       string(snapshot),
+	}, nil
+}
+
+// Sign returns the signature sent in SignatureHeader: the hex HMAC-SHA256, keyed with the endpoint secret, of the
+// timestamp, a dot and the body.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,132 @@
+package web
+
+import (
+	"crypto-checkout/pkg/config"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// AdminKeyHeader carries the admin key on requests to the admin API.
+	AdminKeyHeader = "X-Admin-Key"
+	// AdminPathPrefix is the prefix of the admin API routes.
+	AdminPathPrefix = "/api/v1/admin/"
+	// adminKeyContextKey holds the name of the admin key a request presented.
+	adminKeyContextKey = "admin_key"
+)
+
+// adminKey is a configured admin key.
+type adminKey struct {
+	name string
+	hash []byte
+}
+
+// AdminAuthMiddleware guards the admin API with admin keys, separate from merchant credentials. Operators
+// still authenticate as a merchant team member or API key with the admin operations permission; the admin
+// key elevates that session to the platform-wide admin routes.
+type AdminAuthMiddleware struct {
+	keys     []adminKey
+	networks []*net.IPNet
+	logger   *zap.Logger
+}
+
+// NewAdminAuthMiddleware creates the admin API guard from the configured admin keys and networks.
+func NewAdminAuthMiddleware(cfg *config.Config, logger *zap.Logger) (*AdminAuthMiddleware, error) {
+	m := &AdminAuthMiddleware{logger: logger}
+	names := make(map[string]bool, len(cfg.Admin.Keys))
+	for i, key := range cfg.Admin.Keys {
+		if key.Name == "" {
+			return nil, fmt.Errorf("admin.keys[%d]: name is required", i)
+		}
+		if names[key.Name] {
+			return nil, fmt.Errorf("admin.keys[%d]: duplicate name %q", i, key.Name)
+		}
+		names[key.Name] = true
+		hash, err := hex.DecodeString(key.Hash)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("admin.keys[%d]: hash must be a hex-encoded SHA-256 hash", i)
+		}
+		m.keys = append(m.keys, adminKey{name: key.Name, hash: hash})
+	}
+	for _, cidr := range cfg.Admin.AllowedCIDRs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("admin.allowed_cidrs: %w", err)
+		}
+		m.networks = append(m.networks, network)
+	}
+	return m, nil
+}
+
+// Middleware rejects requests to the admin API that do not come from an allowed network with a valid admin
+// key. Other routes pass through.
+func (m *AdminAuthMiddleware) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !strings.HasPrefix(c.Request.URL.Path, AdminPathPrefix) {
+			c.Next()
+			return
+		}
+		if len(m.keys) == 0 {
+			c.AbortWithStatusJSON(http.StatusForbidden, createAuthErrorResponse(
+				"authorization_error", "ADMIN_API_DISABLED", "No admin keys are configured"))
+			return
+		}
+		if !m.allowed(c.ClientIP()) {
+			m.logger.Warn("Admin API request from a network that is not allowed", zap.String("ip", c.ClientIP()))
+			c.AbortWithStatusJSON(http.StatusForbidden, createAuthErrorResponse(
+				"authorization_error", "ADMIN_NETWORK_DENIED", "Admin API is not available from this network"))
+			return
+		}
+
+		name, ok := m.authenticate(c.GetHeader(AdminKeyHeader))
+		if !ok {
+			m.logger.Warn("Admin API request without a valid admin key",
+				zap.String("ip", c.ClientIP()),
+				zap.String("path", c.Request.URL.Path),
+			)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, createAuthErrorResponse(
+				"authentication_error", "INVALID_ADMIN_KEY", "A valid "+AdminKeyHeader+" header is required"))
+			return
+		}
+		c.Set(adminKeyContextKey, name)
+		c.Next()
+	}
+}
+
+// authenticate returns the name of the admin key matching the presented one. Every configured key is
+// compared in constant time.
+func (m *AdminAuthMiddleware) authenticate(presented string) (string, bool) {
+	if presented == "" {
+		return "", false
+	}
+	sum := sha256.Sum256([]byte(presented))
+	name := ""
+	for _, key := range m.keys {
+		if subtle.ConstantTimeCompare(sum[:], key.hash) == 1 {
+			name = key.name
+		}
+	}
+	return name, name != ""
+}
+
+// allowed reports whether a client IP belongs to the allowed networks.
+func (m *AdminAuthMiddleware) allowed(clientIP string) bool {
+	if len(m.networks) == 0 {
+		return true
+	}
+	ip := net.ParseIP(clientIP)
+	for _, network := range m.networks {
+		if ip != nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package web_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/platform"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubPlatformService returns fixed queue counts, statistics and status, and keeps incidents in memory.
+type stubPlatformService struct {
+	queues     *platform.Queues
+	statistics *platform.Statistics
+	status     *platform.Status
+	incidents  []*platform.Incident
+}
+
+func (s *stubPlatformService) GetQueues(context.Context) (*platform.Queues, error) {
+	return s.queues, nil
+}
+
+func (s *stubPlatformService) GetStatistics(context.Context) (*platform.Statistics, error) {
+	return s.statistics, nil
+}
+
+func (s *stubPlatformService) GetStatus(context.Context) (*platform.Status, error) {
+	return s.status, nil
+}
+
+func (s *stubPlatformService) DeclareIncident(
+	_ context.Context,
+	req *platform.DeclareIncidentRequest,
+) (*platform.Incident, error) {
+	incident, err := platform.NewIncident(fmt.Sprintf("incident-%d", len(s.incidents)+1), req.Title, req.Message,
+		req.Severity, req.Networks, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	s.incidents = append(s.incidents, incident)
+	return incident, nil
+}
+
+func (s *stubPlatformService) ResolveIncident(_ context.Context, id string) (*platform.Incident, error) {
+	for i, incident := range s.incidents {
+		if incident.ID() != id {
+			continue
+		}
+		if !incident.IsActive() {
+			return nil, platform.ErrIncidentResolved
+		}
+		resolvedAt := time.Now().UTC()
+		resolved, err := platform.RestoreIncident(incident.ID(), incident.Title(), incident.Message(),
+			incident.Severity(), incident.Networks(), incident.CreatedBy(), incident.StartedAt(), &resolvedAt)
+		if err != nil {
+			return nil, err
+		}
+		s.incidents[i] = resolved
+		return resolved, nil
+	}
+	return nil, platform.ErrIncidentNotFound
+}
+
+func (s *stubPlatformService) ListIncidents(
+	_ context.Context,
+	req *platform.ListIncidentsRequest,
+) ([]*platform.Incident, error) {
+	var incidents []*platform.Incident
+	for _, incident := range s.incidents {
+		if !req.ActiveOnly || incident.IsActive() {
+			incidents = append(incidents, incident)
+		}
+	}
+	return incidents, nil
+}
+
+func (s *stubPlatformService) ActiveIncidents(ctx context.Context) ([]*platform.Incident, error) {
+	return s.ListIncidents(ctx, &platform.ListIncidentsRequest{ActiveOnly: true})
+}
+
+func adminKeyHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestAdminAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	checkedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := &stubPlatformService{
+		queues: &platform.Queues{
+			ExpiredInvoices: 3,
+			DeadLetters:     map[string]int{"*settlement.PaidInvoiceHandler": 2},
+			CheckedAt:       checkedAt,
+		},
+		statistics: &platform.Statistics{Merchants: 2, Invoices: map[string]int{"paid": 5}, GeneratedAt: checkedAt},
+	}
+
+	newRouter := func(t *testing.T, admin config.AdminConfig) *gin.Engine {
+		cfg := config.NewConfig()
+		cfg.Admin = admin
+		adminAuth, err := web.NewAdminAuthMiddleware(cfg, zap.NewNop())
+		require.NoError(t, err)
+
+		router := gin.New()
+		router.Use(adminAuth.Middleware())
+		v1 := router.Group("/api/v1")
+		v1.GET("/invoices", func(c *gin.Context) { c.Status(http.StatusOK) })
+		web.NewAdminHandlers(service, zap.NewNop()).RegisterAdminRoutes(v1, nil)
+		return router
+	}
+	get := func(router *gin.Engine, path, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if key != "" {
+			req.Header.Set(web.AdminKeyHeader, key)
+		}
+		req.RemoteAddr = "10.0.0.5:1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	keys := []config.AdminKeyConfig{
+		{Name: "alice", Hash: adminKeyHash("alice-secret")},
+		{Name: "bob", Hash: adminKeyHash("bob-secret")},
+	}
+
+	t.Run("admin routes need a valid admin key", func(t *testing.T) {
+		router := newRouter(t, config.AdminConfig{Keys: keys})
+
+		w := get(router, "/api/v1/admin/queues", "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "INVALID_ADMIN_KEY")
+		assert.Equal(t, http.StatusUnauthorized, get(router, "/api/v1/admin/queues", "wrong").Code)
+
+		w = get(router, "/api/v1/admin/queues", "bob-secret")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var queues web.AdminQueuesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &queues))
+		assert.Equal(t, 3, queues.ExpiredInvoices)
+		assert.Equal(t, map[string]int{"*settlement.PaidInvoiceHandler": 2}, queues.DeadLetters)
+		assert.Equal(t, checkedAt, queues.CheckedAt)
+
+		w = get(router, "/api/v1/admin/statistics", "alice-secret")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var statistics web.PlatformStatisticsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &statistics))
+		assert.Equal(t, 2, statistics.Merchants)
+		assert.Equal(t, map[string]int{"paid": 5}, statistics.Invoices)
+
+		// Merchant routes do not need one
+		assert.Equal(t, http.StatusOK, get(router, "/api/v1/invoices", "").Code)
+	})
+
+	t.Run("admin API is closed without keys", func(t *testing.T) {
+		w := get(newRouter(t, config.AdminConfig{}), "/api/v1/admin/queues", "alice-secret")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "ADMIN_API_DISABLED")
+	})
+
+	t.Run("admin API is restricted to the allowed networks", func(t *testing.T) {
+		router := newRouter(t, config.AdminConfig{Keys: keys, AllowedCIDRs: []string{"192.168.0.0/16"}})
+		w := get(router, "/api/v1/admin/queues", "alice-secret")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "ADMIN_NETWORK_DENIED")
+
+		router = newRouter(t, config.AdminConfig{Keys: keys, AllowedCIDRs: []string{"10.0.0.0/8"}})
+		assert.Equal(t, http.StatusOK, get(router, "/api/v1/admin/queues", "alice-secret").Code)
+	})
+
+	t.Run("invalid admin config is rejected", func(t *testing.T) {
+		for _, admin := range []config.AdminConfig{
+			{Keys: []config.AdminKeyConfig{{Name: "alice", Hash: "plain-text-key"}}},
+			{Keys: []config.AdminKeyConfig{{Hash: adminKeyHash("secret")}}},
+			{Keys: append(keys, config.AdminKeyConfig{Name: "alice", Hash: adminKeyHash("other")})},
+			{Keys: keys, AllowedCIDRs: []string{"10.0.0.0"}},
+		} {
+			cfg := config.NewConfig()
+			cfg.Admin = admin
+			_, err := web.NewAdminAuthMiddleware(cfg, zap.NewNop())
+			assert.Error(t, err)
+		}
+	})
+}
@@ -0,0 +1,219 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/platform"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AdminHandlers handles the platform-wide views of the admin API: the work waiting in the platform's queues, the
+// totals across merchants and the incidents shown on the status endpoint and checkout pages.
+type AdminHandlers struct {
+	platformService platform.Service
+	logger          *zap.Logger
+}
+
+// NewAdminHandlers creates a new admin handlers instance.
+func NewAdminHandlers(platformService platform.Service, logger *zap.Logger) *AdminHandlers {
+	return &AdminHandlers{
+		platformService: platformService,
+		logger:          logger,
+	}
+}
+
+// GetQueues handles GET /admin/queues
+// @Summary Inspect the system queues
+// @Description Count the work waiting on the platform: expired invoices the next expiration sweep expires
+// @Description (POST /api/v1/admin/process-expired-invoices runs it now), pending dead letters by handler (POST
+// @Description /api/v1/admin/dead-letters/replay dispatches them again), open payment reviews, transfers held
+// @Description for approval and payouts not yet broadcast.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} AdminQueuesResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid admin key"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/queues [get]
+func (h *AdminHandlers) GetQueues(c *gin.Context) {
+	queues, err := h.platformService.GetQueues(shared.WithReplicaReads(c.Request.Context()))
+	if err != nil {
+		h.logger.Error("Failed to inspect queues", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to inspect queues"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ToAdminQueuesResponse(queues))
+}
+
+// GetStatistics handles GET /admin/statistics
+// @Summary Get platform-wide statistics
+// @Description Count the merchants that have created invoices, and the invoices and payments of every merchant
+// @Description by status
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} PlatformStatisticsResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid admin key"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/statistics [get]
+func (h *AdminHandlers) GetStatistics(c *gin.Context) {
+	statistics, err := h.platformService.GetStatistics(shared.WithReplicaReads(c.Request.Context()))
+	if err != nil {
+		h.logger.Error("Failed to get platform statistics", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get platform statistics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ToPlatformStatisticsResponse(statistics))
+}
+
+// ListIncidents handles GET /admin/incidents
+// @Summary List incidents
+// @Description List the declared incidents, newest first
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param active query bool false "Only incidents not resolved yet"
+// @Param limit query int false "Maximum number of incidents" default(20) minimum(1) maximum(100)
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} ListIncidentsResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid admin key"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/incidents [get]
+func (h *AdminHandlers) ListIncidents(c *gin.Context) {
+	var req ListIncidentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	incidents, err := h.platformService.ListIncidents(c.Request.Context(), &platform.ListIncidentsRequest{
+		ActiveOnly: req.Active,
+		Limit:      req.Limit,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to list incidents")
+		return
+	}
+
+	response := ListIncidentsResponse{Incidents: make([]AdminIncidentResponse, len(incidents))}
+	for i, incident := range incidents {
+		response.Incidents[i] = ToAdminIncidentResponse(incident)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// DeclareIncident handles POST /admin/incidents
+// @Summary Declare an incident
+// @Description Declare that payments are detected late, or not at all, on some or every network. Until it is
+// @Description resolved the incident is listed on GET /api/v1/status, degrades the status and is shown as a banner
+// @Description on the checkout pages of invoices paid on the networks it affects.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body DeclareIncidentRequest true "Incident"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 201 {object} AdminIncidentResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid admin key"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/incidents [post]
+func (h *AdminHandlers) DeclareIncident(c *gin.Context) {
+	var req DeclareIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	networks := make([]shared.BlockchainNetwork, len(req.Networks))
+	for i, network := range req.Networks {
+		networks[i] = shared.BlockchainNetwork(network)
+	}
+	incident, err := h.platformService.DeclareIncident(c.Request.Context(), &platform.DeclareIncidentRequest{
+		Title:     req.Title,
+		Message:   req.Message,
+		Severity:  platform.Severity(req.Severity),
+		Networks:  networks,
+		CreatedBy: auditActor(c).ID,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to declare incident")
+		return
+	}
+
+	setAuditChange(c, nil, map[string]interface{}{
+		"incident_id": incident.ID(),
+		"title":       incident.Title(),
+		"severity":    string(incident.Severity()),
+	})
+	c.JSON(http.StatusCreated, ToAdminIncidentResponse(incident))
+}
+
+// ResolveIncident handles POST /admin/incidents/:id/resolve
+// @Summary Resolve an incident
+// @Description Record that the incident is over, which removes it from the status and checkout pages
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Incident ID"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} AdminIncidentResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid admin key"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 404 {object} ErrorResponse "Incident not found"
+// @Failure 409 {object} ErrorResponse "Incident already resolved"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/incidents/{id}/resolve [post]
+func (h *AdminHandlers) ResolveIncident(c *gin.Context) {
+	incident, err := h.platformService.ResolveIncident(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to resolve incident")
+		return
+	}
+
+	setAuditChange(c, map[string]interface{}{"active": true}, map[string]interface{}{"active": false})
+	c.JSON(http.StatusOK, ToAdminIncidentResponse(incident))
+}
+
+// respondError maps incident domain errors to HTTP responses.
+func (h *AdminHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, platform.ErrIncidentNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", platform.ErrCodeIncidentNotFound, "Incident not found"))
+	case errors.Is(err, platform.ErrIncidentResolved):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", platform.ErrCodeIncidentResolved, err.Error()))
+	case errors.Is(err, platform.ErrInvalidIncident):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterAdminRoutes registers the platform-wide admin routes. The admin key itself is checked by
+// AdminAuthMiddleware for the whole admin namespace.
+func (h *AdminHandlers) RegisterAdminRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionAdminOperations)
+
+	admin := protected.Group("/admin", require)
+	admin.GET("/queues", h.GetQueues)
+	admin.GET("/statistics", h.GetStatistics)
+	admin.GET("/incidents", h.ListIncidents)
+	admin.POST("/incidents", rbac.AuditAction("admin.declare_incident"), h.DeclareIncident)
+	admin.POST("/incidents/:id/resolve", rbac.AuditAction("admin.resolve_incident"), h.ResolveIncident)
+}
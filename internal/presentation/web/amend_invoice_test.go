@@ -0,0 +1,194 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/tax"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAmendInvoice(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	bundle, err := i18n.NewBundle()
+	require.NoError(t, err)
+	router := web.NewGinEngine(&config.Config{}, zap.NewNop(), bundle, nil)
+
+	handler, services := web.CreateTestHandlerWithServices()
+	handler.RegisterRoutes(router)
+
+	_, err = services.Tax.CreateRule(context.Background(), &tax.CreateRuleRequest{
+		MerchantID: "test-merchant", Jurisdiction: "DE", Name: "VAT", Type: invoice.TaxTypeVAT, Rate: "0.19",
+	})
+	require.NoError(t, err)
+
+	request := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var requestBody []byte
+		if body != nil {
+			requestBody, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) web.CreateInvoiceResponse {
+		var response web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	create := func(t *testing.T, body web.CreateInvoiceRequest) web.CreateInvoiceResponse {
+		w := request(http.MethodPost, "/api/v1/invoices", body)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		return decode(t, w)
+	}
+
+	t.Run("AmendInvoice_ReplacesAndLinks", func(t *testing.T) {
+		expiresIn := 3600
+		original := create(t, web.CreateInvoiceRequest{
+			Title:     "Website redesign",
+			Items:     []web.InvoiceItemRequest{{Name: "Design", Quantity: "1", UnitPrice: "100.00"}},
+			TaxRate:   "0.10",
+			ExpiresIn: &expiresIn,
+			Metadata:  map[string]interface{}{"order": "1001"},
+		})
+
+		w := request(http.MethodPost, "/api/v1/invoices/"+original.ID+"/amend", web.AmendInvoiceRequest{
+			InvoiceChangesRequest: web.InvoiceChangesRequest{
+				Items: []web.InvoiceItemRequest{
+					{Name: "Design", Quantity: "1", UnitPrice: "100.00"},
+					{Name: "Hosting", Quantity: "12", UnitPrice: "10.00"},
+				},
+			},
+			Reason: "Added hosting",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		replacement := decode(t, w)
+		require.NotEqual(t, original.ID, replacement.ID)
+		require.Equal(t, "created", replacement.Status)
+		require.Equal(t, "242.00", replacement.Total)
+		require.NotNil(t, replacement.Supersedes)
+		require.Equal(t, original.ID, *replacement.Supersedes)
+		require.InDelta(t, 3600, replacement.ExpiresAt.Sub(replacement.CreatedAt).Seconds(), 5)
+
+		w = request(http.MethodGet, "/api/v1/invoices/"+original.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		amended := decode(t, w)
+		require.Equal(t, "cancelled", amended.Status)
+		require.NotNil(t, amended.SupersededBy)
+		require.Equal(t, replacement.ID, *amended.SupersededBy)
+
+		inv, err := services.Invoices.GetInvoice(context.Background(), replacement.ID)
+		require.NoError(t, err)
+		require.Equal(t, "1001", inv.Metadata()["order"])
+	})
+
+	t.Run("AmendInvoice_CustomerURLRedirects", func(t *testing.T) {
+		original := create(t, web.CreateInvoiceRequest{
+			Title:   "Software License",
+			Items:   []web.InvoiceItemRequest{{Name: "License", Quantity: "1", UnitPrice: "100.00"}},
+			TaxRate: "0.10",
+		})
+		title := "Software License (2 seats)"
+		w := request(http.MethodPost, "/api/v1/invoices/"+original.ID+"/amend", web.AmendInvoiceRequest{
+			InvoiceChangesRequest: web.InvoiceChangesRequest{Title: &title},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		first := decode(t, w)
+
+		// Amending the replacement extends the chain
+		w = request(http.MethodPost, "/api/v1/invoices/"+first.ID+"/amend", web.AmendInvoiceRequest{
+			InvoiceChangesRequest: web.InvoiceChangesRequest{TaxJurisdiction: "DE"},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		second := decode(t, w)
+		require.Equal(t, "19.00", second.TaxAmount)
+
+		w = request(http.MethodGet, "/invoice/"+original.ID+"?lang=en", nil)
+		require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+		require.Equal(t, "/invoice/"+first.ID+"?lang=en", w.Header().Get("Location"))
+
+		w = request(http.MethodGet, "/invoice/"+first.ID, nil)
+		require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+		require.Equal(t, "/invoice/"+second.ID, w.Header().Get("Location"))
+
+		w = request(http.MethodGet, "/invoice/"+second.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = request(http.MethodGet, "/api/v1/public/invoice/"+original.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var public web.PublicInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &public))
+		require.Equal(t, first.ID, *public.SupersededBy)
+	})
+
+	t.Run("AmendInvoice_Conflict", func(t *testing.T) {
+		cancelled := create(t, web.CreateInvoiceRequest{
+			Title:   "Cancelled Invoice",
+			Items:   []web.InvoiceItemRequest{{Name: "Widget", Quantity: "1", UnitPrice: "10.00"}},
+			TaxRate: "0",
+		})
+		w := request(http.MethodPost, "/api/v1/invoices/"+cancelled.ID+"/cancel",
+			web.CancelInvoiceRequest{Reason: "Customer request"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		title := "Too late"
+		w = request(http.MethodPost, "/api/v1/invoices/"+cancelled.ID+"/amend", web.AmendInvoiceRequest{
+			InvoiceChangesRequest: web.InvoiceChangesRequest{Title: &title},
+		})
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), invoice.ErrCodeCannotAmend)
+
+		draft := create(t, web.CreateInvoiceRequest{
+			Title:   "Draft Invoice",
+			Items:   []web.InvoiceItemRequest{{Name: "Widget", Quantity: "1", UnitPrice: "10.00"}},
+			TaxRate: "0",
+			Draft:   true,
+		})
+		w = request(http.MethodPost, "/api/v1/invoices/"+draft.ID+"/amend", web.AmendInvoiceRequest{
+			InvoiceChangesRequest: web.InvoiceChangesRequest{Title: &title},
+		})
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	})
+
+	t.Run("AmendInvoice_Invalid", func(t *testing.T) {
+		original := create(t, web.CreateInvoiceRequest{
+			Title:   "Website redesign",
+			Items:   []web.InvoiceItemRequest{{Name: "Design", Quantity: "1", UnitPrice: "100.00"}},
+			TaxRate: "0.10",
+		})
+
+		empty := ""
+		w := request(http.MethodPost, "/api/v1/invoices/"+original.ID+"/amend", web.AmendInvoiceRequest{
+			InvoiceChangesRequest: web.InvoiceChangesRequest{Title: &empty},
+		})
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		// A rejected amendment leaves the original payable
+		inv, err := services.Invoices.GetInvoice(context.Background(), original.ID)
+		require.NoError(t, err)
+		require.True(t, inv.CanAmend())
+		require.Nil(t, inv.SupersededBy())
+
+		w = request(http.MethodPost, "/api/v1/invoices/missing/amend", web.AmendInvoiceRequest{})
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
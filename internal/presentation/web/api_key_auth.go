@@ -126,8 +126,9 @@ func (m *APIKeyAuthMiddleware) RequireAPIKey() gin.HandlerFunc {
 		// Store API key information in context for use by handlers
 		if resp.APIKey != nil {
 			c.Set("api_key_id", resp.APIKey.ID())
-			c.Set("merchant_id", resp.APIKey.MerchantID())
+			setMerchantScope(c, resp.APIKey.MerchantID())
 			c.Set("api_key_permissions", resp.APIKey.Permissions())
+			c.Set("api_key_type", string(resp.APIKey.KeyType()))
 
 			m.logger.Debug("API key authentication successful",
 				zap.String("api_key_id", resp.APIKey.ID()),
@@ -56,8 +56,7 @@ func (h *APIKeyHandlers) CreateAPIKey(c *gin.Context) {
 	ctx := c.Request.Context()
 	resp, err := h.apiKeyService.CreateAPIKey(ctx, &req)
 	if err != nil {
-		h.logger.Error("Failed to create API key", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create API key"})
+		respondDomainError(c, h.logger, err, "Failed to create API key")
 		return
 	}
 
@@ -83,8 +82,7 @@ func (h *APIKeyHandlers) GetAPIKey(c *gin.Context) {
 	ctx := c.Request.Context()
 	resp, err := h.apiKeyService.GetAPIKey(ctx, req)
 	if err != nil {
-		h.logger.Error("Failed to get API key", zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+		respondDomainError(c, h.logger, err, "Failed to get API key")
 		return
 	}
 
@@ -139,8 +137,7 @@ func (h *APIKeyHandlers) ListAPIKeys(c *gin.Context) {
 	ctx := c.Request.Context()
 	resp, err := h.apiKeyService.ListAPIKeys(ctx, req)
 	if err != nil {
-		h.logger.Error("Failed to list API keys", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list API keys"})
+		respondDomainError(c, h.logger, err, "Failed to list API keys")
 		return
 	}
 
@@ -171,8 +168,7 @@ func (h *APIKeyHandlers) UpdateAPIKey(c *gin.Context) {
 	ctx := c.Request.Context()
 	resp, err := h.apiKeyService.UpdateAPIKey(ctx, &req)
 	if err != nil {
-		h.logger.Error("Failed to update API key", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update API key"})
+		respondDomainError(c, h.logger, err, "Failed to update API key")
 		return
 	}
 
@@ -202,8 +198,7 @@ func (h *APIKeyHandlers) RevokeAPIKey(c *gin.Context) {
 	ctx := c.Request.Context()
 	resp, err := h.apiKeyService.RevokeAPIKey(ctx, &req)
 	if err != nil {
-		h.logger.Error("Failed to revoke API key", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke API key"})
+		respondDomainError(c, h.logger, err, "Failed to revoke API key")
 		return
 	}
 
@@ -226,8 +221,7 @@ func (h *APIKeyHandlers) ValidateAPIKey(c *gin.Context) {
 	ctx := c.Request.Context()
 	resp, err := h.apiKeyService.ValidateAPIKey(ctx, &req)
 	if err != nil {
-		h.logger.Error("Failed to validate API key", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate API key"})
+		respondDomainError(c, h.logger, err, "Failed to validate API key")
 		return
 	}
 
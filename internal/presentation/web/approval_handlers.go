@@ -0,0 +1,243 @@
+package web
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/approval"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ApprovalHandlers handles the approval of refunds and payouts over the configured threshold.
+type ApprovalHandlers struct {
+	approvalService approval.Service
+	logger          *zap.Logger
+}
+
+// NewApprovalHandlers creates a new approval handlers instance.
+func NewApprovalHandlers(approvalService approval.Service, logger *zap.Logger) *ApprovalHandlers {
+	return &ApprovalHandlers{
+		approvalService: approvalService,
+		logger:          logger,
+	}
+}
+
+// ListApprovals handles GET /approvals
+// @Summary List approvals
+// @Description List refunds and payouts held for M-of-N approval
+// @Tags Approvals
+// @Produce json
+// @Security ApiKeyAuth
+// @Param kind query string false "Filter by kind" Enums(refund, payout)
+// @Param status query string false "Filter by status" Enums(pending, approved, rejected)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Success 200 {object} ListApprovalsResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/approvals [get]
+func (h *ApprovalHandlers) ListApprovals(c *gin.Context) {
+	var req ListApprovalsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	var cursor *shared.Cursor
+	if req.Cursor != "" {
+		decoded, err := shared.DecodeCursor(req.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+			return
+		}
+		cursor = decoded
+	}
+
+	resp, err := h.approvalService.ListApprovals(c.Request.Context(), &approval.ListApprovalsRequest{
+		MerchantID: merchantID,
+		Kind:       approval.Kind(req.Kind),
+		Status:     approval.Status(req.Status),
+		Limit:      req.Limit,
+		Cursor:     cursor,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to list approvals")
+		return
+	}
+
+	response := ListApprovalsResponse{
+		Approvals: make([]ApprovalResponse, len(resp.Approvals)),
+		Limit:     resp.Limit,
+	}
+	for i, a := range resp.Approvals {
+		response.Approvals[i] = ToApprovalResponse(a)
+	}
+	if resp.NextCursor != nil {
+		response.NextCursor = resp.NextCursor.Encode()
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetApproval handles GET /approvals/:id
+// @Summary Get an approval
+// @Tags Approvals
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Approval ID"
+// @Success 200 {object} ApprovalResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Approval not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/approvals/{id} [get]
+func (h *ApprovalHandlers) GetApproval(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	a, err := h.approvalService.GetApproval(c.Request.Context(), &approval.GetApprovalRequest{
+		MerchantID: merchantID,
+		ApprovalID: c.Param("id"),
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to get approval")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToApprovalResponse(a))
+}
+
+// Approve handles POST /approvals/:id/approve
+// @Summary Approve a held operation
+// @Description Record the caller's vote; the operation runs once enough approvers agree
+// @Tags Approvals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Approval ID"
+// @Param request body ApprovalVoteRequest false "Optional comment"
+// @Success 200 {object} ApprovalResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Caller is not an approver or requested the operation"
+// @Failure 404 {object} ErrorResponse "Approval not found"
+// @Failure 409 {object} ErrorResponse "Approval already decided, voted on or changed by another vote"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/approvals/{id}/approve [post]
+func (h *ApprovalHandlers) Approve(c *gin.Context) {
+	h.vote(c, h.approvalService.Approve, "Failed to approve transfer")
+}
+
+// Reject handles POST /approvals/:id/reject
+// @Summary Reject a held operation
+// @Description Reject the held operation so it is never executed
+// @Tags Approvals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Approval ID"
+// @Param request body ApprovalVoteRequest false "Optional comment"
+// @Success 200 {object} ApprovalResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Caller is not an approver or requested the operation"
+// @Failure 404 {object} ErrorResponse "Approval not found"
+// @Failure 409 {object} ErrorResponse "Approval already decided, voted on or changed by another vote"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/approvals/{id}/reject [post]
+func (h *ApprovalHandlers) Reject(c *gin.Context) {
+	h.vote(c, h.approvalService.Reject, "Failed to reject transfer")
+}
+
+// vote records the calling team member's decision on a transfer. Votes are tied to user identities,
+// so API keys cannot vote.
+func (h *ApprovalHandlers) vote(
+	c *gin.Context,
+	vote func(ctx context.Context, req *approval.VoteRequest) (*approval.Approval, error),
+	message string,
+) {
+	var req ApprovalVoteRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+			return
+		}
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusForbidden, createAuthErrorResponse(
+			"authorization_error", "APPROVER_REQUIRED", "Transfers are approved by signed-in team members"))
+		return
+	}
+
+	a, err := vote(c.Request.Context(), &approval.VoteRequest{
+		MerchantID: merchantID,
+		ApprovalID: c.Param("id"),
+		UserID:     userID,
+		Note:       req.Note,
+	})
+	if err != nil {
+		h.respondError(c, err, message)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToApprovalResponse(a))
+}
+
+// respondError maps approval domain errors to HTTP responses.
+func (h *ApprovalHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, approval.ErrApprovalNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", approval.ErrCodeApprovalNotFound, "Approval not found"))
+	case errors.Is(err, approval.ErrSelfApproval):
+		c.JSON(http.StatusForbidden, createAuthErrorResponse(
+			"authorization_error", approval.ErrCodeSelfApproval, err.Error()))
+	case errors.Is(err, approval.ErrApprovalDecided):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", approval.ErrCodeApprovalDecided, err.Error()))
+	case errors.Is(err, approval.ErrAlreadyVoted):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", approval.ErrCodeAlreadyVoted, err.Error()))
+	case errors.Is(err, approval.ErrConcurrentVote):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", approval.ErrCodeConcurrentVote, err.Error()))
+	case errors.Is(err, approval.ErrExecutionFailed):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", approval.ErrCodeExecutionFailed, err.Error()))
+	case errors.Is(err, approval.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterApprovalRoutes registers the transfer approval routes.
+func (h *ApprovalHandlers) RegisterApprovalRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionTransfersApprove)
+
+	approvals := protected.Group("/approvals", require)
+	approvals.GET("", h.ListApprovals)
+	approvals.GET("/:id", h.GetApproval)
+	approvals.POST("/:id/approve", h.Approve)
+	approvals.POST("/:id/reject", h.Reject)
+}
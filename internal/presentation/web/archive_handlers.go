@@ -0,0 +1,111 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/archive"
+	"crypto-checkout/internal/domain/merchant"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ArchiveHandlers handles the invoices moved to the archive.
+type ArchiveHandlers struct {
+	archiveService archive.Service
+	logger         *zap.Logger
+}
+
+// NewArchiveHandlers creates a new archive handlers instance.
+func NewArchiveHandlers(archiveService archive.Service, logger *zap.Logger) *ArchiveHandlers {
+	return &ArchiveHandlers{
+		archiveService: archiveService,
+		logger:         logger,
+	}
+}
+
+// ExportArchivedInvoices handles GET /invoices/archive/export
+// @Summary Export archived invoices
+// @Description Download the merchant's archived invoices, newest first, as newline-delimited JSON: one line per
+// @Description invoice with its payments and payment events. Archived invoices stay readable one by one through
+// @Description GET /api/v1/invoices/{id}.
+// @Tags Invoices
+// @Produce application/x-ndjson
+// @Security ApiKeyAuth
+// @Param created_from query string false "Only invoices created at or after this time (RFC 3339)"
+// @Param created_to query string false "Only invoices created at or before this time (RFC 3339)"
+// @Success 200 {object} ArchivedInvoiceResponse "One line per archived invoice"
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoices/archive/export [get]
+func (h *ArchiveHandlers) ExportArchivedInvoices(c *gin.Context) {
+	var req ExportArchivedInvoicesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Failed to bind export archived invoices request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	page := &archive.ListArchivedInvoicesRequest{
+		MerchantID:  merchantID,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+		Limit:       archive.MaxListLimit,
+	}
+	resp, err := h.archiveService.ListArchivedInvoices(c.Request.Context(), page)
+	if err != nil {
+		h.respondError(c, err, "Failed to export archived invoices")
+		return
+	}
+
+	// Once the first page is written the status is sent, so later failures can only cut the export short
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Content-Disposition", `attachment; filename="invoice-archive.ndjson"`)
+	c.Status(http.StatusOK)
+	encoder := json.NewEncoder(c.Writer)
+	for {
+		for _, archived := range resp.Invoices {
+			if err := encoder.Encode(ToArchivedInvoiceResponse(archived)); err != nil {
+				h.logger.Warn("Archived invoice export interrupted", zap.Error(err))
+				return
+			}
+		}
+		c.Writer.Flush()
+		if resp.NextCursor == nil {
+			return
+		}
+
+		page.Cursor = resp.NextCursor
+		resp, err = h.archiveService.ListArchivedInvoices(c.Request.Context(), page)
+		if err != nil {
+			h.logger.Error("Archived invoice export interrupted", zap.String("merchant_id", merchantID), zap.Error(err))
+			return
+		}
+	}
+}
+
+// respondError maps archive domain errors to HTTP responses.
+func (h *ArchiveHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, archive.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterArchiveRoutes registers the invoice archive routes.
+func (h *ArchiveHandlers) RegisterArchiveRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionInvoicesRead)
+
+	protected.GET("/invoices/archive/export", require, h.ExportArchivedInvoices)
+}
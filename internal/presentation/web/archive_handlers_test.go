@@ -0,0 +1,133 @@
+package web_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/archive"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/presentation/web"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubArchiveService serves archived invoices one per page.
+type stubArchiveService struct {
+	invoices []*archive.ArchivedInvoice
+	requests []archive.ListArchivedInvoicesRequest
+}
+
+func (s *stubArchiveService) ArchiveInvoices(context.Context) (int, error) {
+	return 0, nil
+}
+
+func (s *stubArchiveService) ListArchivedInvoices(
+	_ context.Context,
+	req *archive.ListArchivedInvoicesRequest,
+) (*archive.ListArchivedInvoicesResponse, error) {
+	if req.CreatedFrom != nil && req.CreatedTo != nil && req.CreatedTo.Before(*req.CreatedFrom) {
+		return nil, fmt.Errorf("%w: created_to is before created_from", archive.ErrInvalidRequest)
+	}
+	s.requests = append(s.requests, *req)
+	page := 0
+	for req.Cursor != nil && s.invoices[page].Invoice.ID() != req.Cursor.ID {
+		page++
+	}
+	if req.Cursor != nil {
+		page++
+	}
+	resp := &archive.ListArchivedInvoicesResponse{Invoices: s.invoices[page : page+1], Limit: req.Limit}
+	if page+1 < len(s.invoices) {
+		current := s.invoices[page].Invoice
+		resp.NextCursor = shared.NewCursor(current.CreatedAt(), current.ID())
+	}
+	return resp, nil
+}
+
+func TestArchiveExport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, services := web.CreateTestHandlerWithServices()
+	router := gin.New()
+	router.POST("/api/v1/invoices", handler.CreateInvoice)
+
+	archived := make([]*archive.ArchivedInvoice, 2)
+	for i := range archived {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/invoices", bytes.NewBufferString(
+			`{"title":"Order","items":[{"name":"Item","quantity":"1","unit_price":"10.00"}],"tax_rate":"0.00"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var created web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		inv, err := services.Invoices.GetInvoice(context.Background(), created.ID)
+		require.NoError(t, err)
+		archived[i] = &archive.ArchivedInvoice{
+			Invoice: inv,
+			Payments: []archive.Payment{
+				{ID: fmt.Sprintf("payment-%d", i), Network: "tron", TxHash: "0xabc", Amount: "10", Status: "confirmed"},
+			},
+			PaymentEvents: []archive.PaymentEvent{
+				{PaymentID: fmt.Sprintf("payment-%d", i), Version: 1, Type: "detected", Data: json.RawMessage(`{}`)},
+			},
+			ArchivedAt: time.Now().UTC(),
+		}
+	}
+
+	service := &stubArchiveService{invoices: archived}
+	protected := router.Group("/api/v1", func(c *gin.Context) {
+		c.Set("merchant_id", "test-merchant")
+		c.Next()
+	})
+	web.NewArchiveHandlers(service, zap.NewNop()).RegisterArchiveRoutes(protected, nil)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	t.Run("Streams_Every_Page_As_NDJSON", func(t *testing.T) {
+		w := get("/api/v1/invoices/archive/export?created_from=2025-01-01T00:00:00Z")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "invoice-archive.ndjson")
+
+		var lines []web.ArchivedInvoiceResponse
+		scanner := bufio.NewScanner(w.Body)
+		for scanner.Scan() {
+			var line web.ArchivedInvoiceResponse
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+			lines = append(lines, line)
+		}
+		require.Len(t, lines, 2)
+		assert.Equal(t, archived[0].Invoice.ID(), lines[0].Invoice.ID)
+		assert.Equal(t, archived[1].Invoice.ID(), lines[1].Invoice.ID)
+		require.Len(t, lines[1].Payments, 1)
+		assert.Equal(t, "payment-1", lines[1].Payments[0].ID)
+		require.Len(t, lines[1].PaymentEvents, 1)
+		assert.Equal(t, "detected", lines[1].PaymentEvents[0].Type)
+
+		require.Len(t, service.requests, 2)
+		assert.Equal(t, "test-merchant", service.requests[0].MerchantID)
+		require.NotNil(t, service.requests[0].CreatedFrom)
+		assert.Equal(t, 2025, service.requests[0].CreatedFrom.Year())
+	})
+
+	t.Run("Rejects_Invalid_Ranges", func(t *testing.T) {
+		w := get("/api/v1/invoices/archive/export?created_from=yesterday")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = get("/api/v1/invoices/archive/export?created_from=2025-02-01T00:00:00Z&created_to=2025-01-01T00:00:00Z")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,125 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AuditHandlers handles audit log HTTP requests.
+type AuditHandlers struct {
+	auditService audit.Service
+	logger       *zap.Logger
+}
+
+// NewAuditHandlers creates a new audit handlers instance.
+func NewAuditHandlers(auditService audit.Service, logger *zap.Logger) *AuditHandlers {
+	return &AuditHandlers{
+		auditService: auditService,
+		logger:       logger,
+	}
+}
+
+// ListAuditLogs handles GET /audit-logs
+// Entries are scoped to the merchant of the authenticated caller.
+// @Summary List audit logs
+// @Description List the audit trail of the caller's merchant, newest first
+// @Tags Audit
+// @Produce json
+// @Security ApiKeyAuth
+// @Param actor_id query string false "Filter by actor ID"
+// @Param actor_type query string false "Filter by actor type" Enums(user, api_key, system)
+// @Param action query string false "Filter by action"
+// @Param resource_type query string false "Filter by resource type"
+// @Param resource_id query string false "Filter by resource ID"
+// @Param request_id query string false "Filter by request ID"
+// @Param from query string false "Start time (RFC 3339)"
+// @Param to query string false "End time (RFC 3339)"
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Success 200 {object} ListAuditLogsResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/audit-logs [get]
+func (h *AuditHandlers) ListAuditLogs(c *gin.Context) {
+	var req ListAuditLogsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Failed to bind list audit logs request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	merchantID := c.GetString("merchant_id")
+	if merchantID == "" {
+		c.JSON(
+			http.StatusForbidden,
+			createAuthErrorResponse("authorization_error", "MERCHANT_SCOPE_REQUIRED", "Audit logs require a merchant scope"),
+		)
+		return
+	}
+
+	var cursor *shared.Cursor
+	if req.Cursor != "" {
+		decoded, err := shared.DecodeCursor(req.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+			return
+		}
+		cursor = decoded
+	}
+
+	resp, err := h.auditService.ListEntries(c.Request.Context(), &audit.ListEntriesRequest{
+		MerchantID:   merchantID,
+		ActorID:      req.ActorID,
+		ActorType:    audit.ActorType(req.ActorType),
+		Action:       req.Action,
+		ResourceType: req.ResourceType,
+		ResourceID:   req.ResourceID,
+		RequestID:    req.RequestID,
+		From:         req.From,
+		To:           req.To,
+		Limit:        req.Limit,
+		Cursor:       cursor,
+		Offset:       req.Offset,
+	})
+	if err != nil {
+		if errors.Is(err, audit.ErrInvalidRequest) {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+			return
+		}
+		h.logger.Error("Failed to list audit logs", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list audit logs"})
+		return
+	}
+
+	entries := make([]AuditLogResponse, len(resp.Entries))
+	for i, entry := range resp.Entries {
+		entries[i] = ToAuditLogResponse(entry)
+	}
+
+	response := ListAuditLogsResponse{
+		Entries: entries,
+		Total:   resp.Total,
+		Limit:   resp.Limit,
+		Offset:  resp.Offset,
+	}
+	if resp.NextCursor != nil {
+		response.NextCursor = resp.NextCursor.Encode()
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RegisterAuditRoutes registers audit log routes.
+func (h *AuditHandlers) RegisterAuditRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionAuditRead)
+
+	protected.GET("/audit-logs", require, h.ListAuditLogs)
+}
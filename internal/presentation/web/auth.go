@@ -78,6 +78,7 @@ func AuthMiddleware(logger *zap.Logger) gin.HandlerFunc {
 func createNotFoundErrorResponse(message string) ErrorResponse {
 	return ErrorResponse{
 		Error:     "not_found",
+		Code:      "NOT_FOUND",
 		Message:   message,
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 		RequestID: generateRequestID(),
@@ -170,6 +171,7 @@ func (h *Handler) generateJWTToken(apiKey string, scope []string, expiresIn int6
 func createValidationErrorResponse(message string, err error) ErrorResponse {
 	response := ErrorResponse{
 		Error:     "validation_error",
+		Code:      "VALIDATION_ERROR",
 		Message:   message,
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 	}
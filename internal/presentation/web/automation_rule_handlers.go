@@ -0,0 +1,353 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/automation"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/payment"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AutomationRuleHandlers handles merchant automation rule management and dry runs.
+type AutomationRuleHandlers struct {
+	ruleService automation.Service
+	logger      *zap.Logger
+}
+
+// NewAutomationRuleHandlers creates a new automation rule handlers instance.
+func NewAutomationRuleHandlers(
+	ruleService automation.Service,
+	logger *zap.Logger,
+) *AutomationRuleHandlers {
+	return &AutomationRuleHandlers{
+		ruleService: ruleService,
+		logger:      logger,
+	}
+}
+
+// CreateRule handles POST /automation-rules
+// @Summary Create an automation rule
+// @Description Create a rule taking actions when its trigger fires and all of its conditions hold. The secret
+// @Description signing the rule's calls is only returned here.
+// @Tags Automation Rules
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body AutomationRuleRequest true "Automation rule"
+// @Success 201 {object} AutomationRuleResponse "Automation rule created"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 409 {object} ErrorResponse "Rule name already taken or rule limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/automation-rules [post]
+func (h *AutomationRuleHandlers) CreateRule(c *gin.Context) {
+	var req AutomationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind create automation rule request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	rule, err := h.ruleService.CreateRule(c.Request.Context(), &automation.CreateRuleRequest{
+		MerchantID: merchantID,
+		Definition: ToAutomationRuleDefinition(req),
+	})
+	if err != nil {
+		respondAutomationRuleError(c, h.logger, err, "Failed to create automation rule")
+		return
+	}
+
+	setAuditChange(c, nil, automationRuleAuditState(rule))
+
+	response := ToAutomationRuleResponse(rule)
+	response.Secret = rule.Secret()
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListRules handles GET /automation-rules
+// @Summary List automation rules
+// @Tags Automation Rules
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} ListAutomationRulesResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/automation-rules [get]
+func (h *AutomationRuleHandlers) ListRules(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	rules, err := h.ruleService.ListRules(c.Request.Context(), merchantID)
+	if err != nil {
+		respondAutomationRuleError(c, h.logger, err, "Failed to list automation rules")
+		return
+	}
+
+	response := ListAutomationRulesResponse{Rules: make([]AutomationRuleResponse, len(rules))}
+	for i, rule := range rules {
+		response.Rules[i] = ToAutomationRuleResponse(rule)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetRule handles GET /automation-rules/:id
+// @Summary Get an automation rule
+// @Tags Automation Rules
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Automation rule ID"
+// @Success 200 {object} AutomationRuleResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Automation rule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/automation-rules/{id} [get]
+func (h *AutomationRuleHandlers) GetRule(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	rule, err := h.ruleService.GetRule(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondAutomationRuleError(c, h.logger, err, "Failed to get automation rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToAutomationRuleResponse(rule))
+}
+
+// UpdateRule handles PUT /automation-rules/:id
+// @Summary Replace an automation rule
+// @Description Replace the content of an automation rule. Its secret is kept.
+// @Tags Automation Rules
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Automation rule ID"
+// @Param request body AutomationRuleRequest true "Automation rule"
+// @Success 200 {object} AutomationRuleResponse
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Automation rule not found"
+// @Failure 409 {object} ErrorResponse "Rule name already taken"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/automation-rules/{id} [put]
+func (h *AutomationRuleHandlers) UpdateRule(c *gin.Context) {
+	var req AutomationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind update automation rule request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	before, err := h.ruleService.GetRule(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondAutomationRuleError(c, h.logger, err, "Failed to update automation rule")
+		return
+	}
+	beforeState := automationRuleAuditState(before)
+
+	rule, err := h.ruleService.UpdateRule(c.Request.Context(), &automation.UpdateRuleRequest{
+		MerchantID: merchantID,
+		RuleID:     c.Param("id"),
+		Definition: ToAutomationRuleDefinition(req),
+	})
+	if err != nil {
+		respondAutomationRuleError(c, h.logger, err, "Failed to update automation rule")
+		return
+	}
+
+	setAuditChange(c, beforeState, automationRuleAuditState(rule))
+	c.JSON(http.StatusOK, ToAutomationRuleResponse(rule))
+}
+
+// DeleteRule handles DELETE /automation-rules/:id
+// @Summary Delete an automation rule
+// @Tags Automation Rules
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Automation rule ID"
+// @Success 204 "Automation rule deleted"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Automation rule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/automation-rules/{id} [delete]
+func (h *AutomationRuleHandlers) DeleteRule(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	rule, err := h.ruleService.GetRule(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondAutomationRuleError(c, h.logger, err, "Failed to delete automation rule")
+		return
+	}
+
+	if err := h.ruleService.DeleteRule(c.Request.Context(), merchantID, rule.ID()); err != nil {
+		respondAutomationRuleError(c, h.logger, err, "Failed to delete automation rule")
+		return
+	}
+
+	setAuditChange(c, automationRuleAuditState(rule), nil)
+	c.Status(http.StatusNoContent)
+}
+
+// TestRule handles POST /automation-rules/test
+// @Summary Dry-run an automation rule definition
+// @Description Evaluate a rule that is not saved against one of the merchant's invoices, and for payment
+// @Description triggers one of the invoice's payments, reporting each condition and the actions the rule would
+// @Description take. No action is taken.
+// @Tags Automation Rules
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body TestAutomationRuleRequest true "Rule and subject"
+// @Success 200 {object} TestAutomationRuleResponse
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Invoice or payment not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/automation-rules/test [post]
+func (h *AutomationRuleHandlers) TestRule(c *gin.Context) {
+	var req TestAutomationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind test automation rule request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+	if req.Rule == nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(
+			"Invalid request body", errors.New("rule is required")))
+		return
+	}
+
+	definition := ToAutomationRuleDefinition(*req.Rule)
+	h.test(c, &automation.TestRuleRequest{
+		Definition: &definition,
+		InvoiceID:  req.InvoiceID,
+		PaymentID:  req.PaymentID,
+	})
+}
+
+// TestSavedRule handles POST /automation-rules/:id/test
+// @Summary Dry-run a saved automation rule
+// @Description Evaluate a saved rule, enabled or not, against one of the merchant's invoices, and for payment
+// @Description triggers one of the invoice's payments. No action is taken.
+// @Tags Automation Rules
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Automation rule ID"
+// @Param request body TestAutomationRuleRequest true "Subject; rule is ignored"
+// @Success 200 {object} TestAutomationRuleResponse
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Automation rule, invoice or payment not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/automation-rules/{id}/test [post]
+func (h *AutomationRuleHandlers) TestSavedRule(c *gin.Context) {
+	var req TestAutomationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind test automation rule request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	h.test(c, &automation.TestRuleRequest{
+		RuleID:    c.Param("id"),
+		InvoiceID: req.InvoiceID,
+		PaymentID: req.PaymentID,
+	})
+}
+
+// test runs a dry run for the authenticated merchant and responds with its outcome.
+func (h *AutomationRuleHandlers) test(c *gin.Context, req *automation.TestRuleRequest) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+	req.MerchantID = merchantID
+
+	evaluation, err := h.ruleService.TestRule(c.Request.Context(), req)
+	if err != nil {
+		respondAutomationRuleError(c, h.logger, err, "Failed to test automation rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToTestAutomationRuleResponse(evaluation))
+}
+
+// RegisterAutomationRuleRoutes registers automation rule management routes.
+func (h *AutomationRuleHandlers) RegisterAutomationRuleRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	manage := rbac.RequireRolePermission(merchant.PermissionSettingsManage)
+
+	rules := protected.Group("/automation-rules")
+	rules.POST("", manage, rbac.AuditAction("automation_rule.create"), h.CreateRule)
+	rules.GET("", manage, h.ListRules)
+	rules.POST("/test", manage, h.TestRule)
+	rules.GET("/:id", manage, h.GetRule)
+	rules.PUT("/:id", manage, rbac.AuditAction("automation_rule.update"), h.UpdateRule)
+	rules.DELETE("/:id", manage, rbac.AuditAction("automation_rule.delete"), h.DeleteRule)
+	rules.POST("/:id/test", manage, h.TestSavedRule)
+}
+
+// automationRuleAuditState returns the audited fields of an automation rule.
+func automationRuleAuditState(rule *automation.Rule) map[string]interface{} {
+	return map[string]interface{}{
+		"name":       rule.Name(),
+		"trigger":    string(rule.Trigger()),
+		"conditions": len(rule.Conditions()),
+		"actions":    len(rule.Actions()),
+		"enabled":    rule.Enabled(),
+	}
+}
+
+// respondAutomationRuleError maps automation rule errors to HTTP responses.
+func respondAutomationRuleError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, automation.ErrRuleNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", automation.ErrCodeRuleNotFound, "Automation rule not found"))
+	case errors.Is(err, invoice.ErrInvoiceNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", invoice.ErrCodeInvoiceNotFound, "Invoice not found"))
+	case errors.Is(err, payment.ErrPaymentNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", invoice.ErrCodePaymentNotFound, "Payment not found"))
+	case errors.Is(err, automation.ErrNameTaken):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", automation.ErrCodeNameTaken, err.Error()))
+	case errors.Is(err, automation.ErrTooManyRules):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", automation.ErrCodeTooManyRules, err.Error()))
+	case errors.Is(err, automation.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,165 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/presentation/web"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomationRules(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	handler, services := web.CreateTestHandlerWithServices()
+	protected := router.Group("/api/v1", func(c *gin.Context) {
+		c.Set("merchant_id", "test-merchant")
+		c.Next()
+	})
+	web.NewAutomationRuleHandlers(services.AutomationRules, handler.Logger).
+		RegisterAutomationRuleRoutes(protected, nil)
+
+	request := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var requestBody []byte
+		if body != nil {
+			var err error
+			requestBody, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(requestBody))
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	unitPrice, err := shared.NewMoney("150.00", shared.CurrencyUSD)
+	require.NoError(t, err)
+	inv, err := services.Invoices.CreateInvoice(context.Background(), &invoice.CreateInvoiceRequest{
+		MerchantID:     "test-merchant",
+		Title:          "Large order",
+		Items:          []*invoice.CreateInvoiceItemRequest{{Name: "Item", Quantity: "1", UnitPrice: unitPrice}},
+		TaxRate:        "0.00",
+		Currency:       shared.CurrencyUSD,
+		CryptoCurrency: shared.CryptoCurrencyUSDT,
+	})
+	require.NoError(t, err)
+
+	largeOrders := web.AutomationRuleRequest{
+		Name:    "Large orders",
+		Trigger: "invoice.paid",
+		Conditions: []web.AutomationRuleConditionRequest{
+			{Field: "total", Operator: "gt", Value: "100"},
+		},
+		Actions: []web.AutomationRuleActionRequest{
+			{Type: "call_url", URL: "https://merchant.example/hooks/large"},
+			{Type: "add_tag", Tag: "large"},
+		},
+	}
+
+	w := request(http.MethodPost, "/api/v1/automation-rules", largeOrders)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var rule web.AutomationRuleResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rule))
+	require.True(t, rule.Enabled)
+	require.Len(t, rule.Secret, 64)
+
+	t.Run("CreateRule_NameTaken", func(t *testing.T) {
+		w := request(http.MethodPost, "/api/v1/automation-rules", largeOrders)
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	})
+
+	t.Run("CreateRule_Invalid", func(t *testing.T) {
+		invalid := largeOrders
+		invalid.Name = "Invalid"
+		invalid.Actions = []web.AutomationRuleActionRequest{{Type: "require_confirmations", Confirmations: 12}}
+		w := request(http.MethodPost, "/api/v1/automation-rules", invalid)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("ListAndGetRules", func(t *testing.T) {
+		w := request(http.MethodGet, "/api/v1/automation-rules", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list web.ListAutomationRulesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Rules, 1)
+		require.Empty(t, list.Rules[0].Secret)
+
+		w = request(http.MethodGet, "/api/v1/automation-rules/"+rule.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = request(http.MethodGet, "/api/v1/automation-rules/missing", nil)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("TestSavedRule", func(t *testing.T) {
+		w := request(http.MethodPost, "/api/v1/automation-rules/"+rule.ID+"/test",
+			web.TestAutomationRuleRequest{InvoiceID: inv.ID()})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response web.TestAutomationRuleResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.True(t, response.Matched)
+		require.Equal(t, "150", response.Conditions[0].Actual)
+		require.Len(t, response.Actions, 2)
+
+		// Dry runs take no action
+		tagged, err := services.Invoices.GetInvoice(context.Background(), inv.ID())
+		require.NoError(t, err)
+		require.Empty(t, tagged.Tags())
+	})
+
+	t.Run("TestRule_Definition", func(t *testing.T) {
+		definition := largeOrders
+		definition.Conditions = []web.AutomationRuleConditionRequest{{Field: "total", Operator: "gt", Value: "500"}}
+		w := request(http.MethodPost, "/api/v1/automation-rules/test",
+			web.TestAutomationRuleRequest{Rule: &definition, InvoiceID: inv.ID()})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response web.TestAutomationRuleResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.False(t, response.Matched)
+		require.Empty(t, response.Actions)
+
+		w = request(http.MethodPost, "/api/v1/automation-rules/test", web.TestAutomationRuleRequest{InvoiceID: inv.ID()})
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		w = request(http.MethodPost, "/api/v1/automation-rules/test",
+			web.TestAutomationRuleRequest{Rule: &definition, InvoiceID: "missing"})
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		payments := largeOrders
+		payments.Trigger = "payment.detected"
+		w = request(http.MethodPost, "/api/v1/automation-rules/test",
+			web.TestAutomationRuleRequest{Rule: &payments, InvoiceID: inv.ID()})
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("UpdateAndDeleteRule", func(t *testing.T) {
+		disabled := false
+		updated := largeOrders
+		updated.Enabled = &disabled
+		w := request(http.MethodPut, "/api/v1/automation-rules/"+rule.ID, updated)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response web.AutomationRuleResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.False(t, response.Enabled)
+		require.Empty(t, response.Secret)
+
+		w = request(http.MethodDelete, "/api/v1/automation-rules/"+rule.ID, nil)
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		w = request(http.MethodGet, "/api/v1/automation-rules/"+rule.ID, nil)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
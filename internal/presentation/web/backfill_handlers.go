@@ -0,0 +1,122 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// BackfillHandlers handles the payment backfills that rescan the chain for transfers missed while the watchers
+// were down.
+type BackfillHandlers struct {
+	backfillService detection.BackfillService
+	logger          *zap.Logger
+}
+
+// NewBackfillHandlers creates a new backfill handlers instance.
+func NewBackfillHandlers(backfillService detection.BackfillService, logger *zap.Logger) *BackfillHandlers {
+	return &BackfillHandlers{
+		backfillService: backfillService,
+		logger:          logger,
+	}
+}
+
+// StartBackfill handles POST /admin/payments/backfill
+// @Summary Backfill missed payments
+// @Description Rescan a range of blocks of a network for transfers to the payment addresses of invoices and
+// @Description replay them through payment detection. Transactions already recorded are deduplicated by hash and
+// @Description only advance their confirmations. The rescan runs in the background; poll the returned backfill
+// @Description for its progress. A failed backfill can be resumed from the block after scanned_through.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body StartBackfillRequest true "Network and inclusive block range"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 202 {object} BackfillResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body or block range"
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid admin key"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 422 {object} ErrorResponse "No chain scanner is configured for the network"
+// @Failure 502 {object} ErrorResponse "Chain scanner unavailable"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/payments/backfill [post]
+func (h *BackfillHandlers) StartBackfill(c *gin.Context) {
+	var req StartBackfillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	backfill, err := h.backfillService.StartBackfill(c.Request.Context(), &detection.BackfillRequest{
+		Network:   shared.BlockchainNetwork(req.Network),
+		FromBlock: *req.FromBlock,
+		ToBlock:   *req.ToBlock,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to start backfill")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, ToBackfillResponse(backfill))
+}
+
+// GetBackfill handles GET /admin/payments/backfill/:id
+// @Summary Get the progress of a payment backfill
+// @Description Backfills are kept in memory by the instance running them, and are forgotten on restart.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backfill ID"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} BackfillResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid admin key"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 404 {object} ErrorResponse "Backfill not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/payments/backfill/{id} [get]
+func (h *BackfillHandlers) GetBackfill(c *gin.Context) {
+	backfill, err := h.backfillService.GetBackfill(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to get backfill")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToBackfillResponse(backfill))
+}
+
+// respondError maps backfill errors to HTTP responses.
+func (h *BackfillHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, detection.ErrBackfillNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", detection.ErrCodeBackfillNotFound, "Backfill not found"))
+	case errors.Is(err, detection.ErrInvalidBackfill):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", detection.ErrCodeInvalidBackfill, err.Error()))
+	case errors.Is(err, detection.ErrBackfillUnsupported):
+		c.JSON(http.StatusUnprocessableEntity, createAuthErrorResponse(
+			"validation_error", detection.ErrCodeBackfillUnsupported, err.Error()))
+	case errors.Is(err, detection.ErrScanFailed):
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusBadGateway, createAuthErrorResponse(
+			"service_unavailable", detection.ErrCodeScannerUnavailable, "Chain scanner unavailable"))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterBackfillRoutes registers the payment backfill routes under /admin/payments/backfill.
+func (h *BackfillHandlers) RegisterBackfillRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionAdminOperations)
+
+	backfills := protected.Group("/admin/payments/backfill", require)
+	backfills.POST("", rbac.AuditAction("admin.payments_backfill"), h.StartBackfill)
+	backfills.GET("/:id", h.GetBackfill)
+}
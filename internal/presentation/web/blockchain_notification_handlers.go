@@ -0,0 +1,201 @@
+package web
+
+import (
+	"bytes"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/pkg/config"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// NotificationSourceHeader names the watcher that sent a transaction notification.
+	NotificationSourceHeader = "X-Watchtower-Source"
+	// NotificationTimestampHeader carries the Unix time, in seconds, at which a notification was signed.
+	NotificationTimestampHeader = "X-Watchtower-Timestamp"
+	// NotificationSignatureHeader carries the hex HMAC-SHA256 of a notification; see SignNotification.
+	NotificationSignatureHeader = "X-Watchtower-Signature"
+
+	// maxNotificationBodySize bounds the size of a transaction notification body.
+	maxNotificationBodySize = 64 << 10
+)
+
+// BlockchainNotificationHandlers accepts transactions pushed by external chain watchers, for
+// merchants who run their own nodes instead of relying on the built-in payment detection.
+type BlockchainNotificationHandlers struct {
+	detectionService detection.Service
+	sources          map[string]config.NotificationSourceConfig
+	maxSkew          time.Duration
+	now              func() time.Time
+	logger           *zap.Logger
+}
+
+// NewBlockchainNotificationHandlers creates a new blockchain notification handlers instance
+// that accepts notifications from the sources configured under blockchain.notification_sources.
+func NewBlockchainNotificationHandlers(
+	detectionService detection.Service,
+	cfg *config.Config,
+	logger *zap.Logger,
+) *BlockchainNotificationHandlers {
+	sources := make(map[string]config.NotificationSourceConfig, len(cfg.Blockchain.NotificationSources))
+	for _, source := range cfg.Blockchain.NotificationSources {
+		if source.Name == "" || source.Secret == "" {
+			logger.Warn("Ignoring transaction notification source without a name or secret",
+				zap.String("source", source.Name))
+			continue
+		}
+		sources[source.Name] = source
+	}
+
+	maxSkew := cfg.Blockchain.NotificationMaxSkew
+	if maxSkew <= 0 {
+		maxSkew = config.DefaultNotificationMaxSkew
+	}
+
+	return &BlockchainNotificationHandlers{
+		detectionService: detectionService,
+		sources:          sources,
+		maxSkew:          maxSkew,
+		now:              time.Now,
+		logger:           logger,
+	}
+}
+
+// SignNotification returns the signature a source sends in NotificationSignatureHeader: the hex
+// HMAC-SHA256, keyed with the source secret, of the timestamp, a dot and the raw request body.
+func SignNotification(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// HandleNotification handles POST /blockchain/notifications
+// @Summary Notify of a blockchain transaction
+// @Description Accept a transaction seen by a signed notification source such as a node webhook. Transfers to the
+// @Description address of an expired, cancelled or settled invoice are kept as unattributed deposits.
+// @Tags Blockchain
+// @Accept json
+// @Produce json
+// @Param X-Watchtower-Source header string true "Notification source name"
+// @Param X-Watchtower-Timestamp header string true "Unix time the notification was sent"
+// @Param X-Watchtower-Signature header string true "Hex HMAC-SHA256 of the timestamp, a dot and the body"
+// @Param request body BlockchainNotificationRequest true "Transaction"
+// @Success 200 {object} BlockchainNotificationResponse "Duplicate notification"
+// @Success 202 {object} BlockchainNotificationResponse "Notification accepted"
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Invalid signature or stale timestamp"
+// @Failure 422 {object} ErrorResponse "Address is not an invoice address"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/blockchain/notifications [post]
+func (h *BlockchainNotificationHandlers) HandleNotification(c *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxNotificationBodySize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	source, ok := h.authenticate(c, body)
+	if !ok {
+		return
+	}
+
+	var req BlockchainNotificationRequest
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind transaction notification", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	result, err := h.detectionService.HandleNotification(c.Request.Context(), &detection.Notification{
+		MerchantID:      source.MerchantID,
+		Network:         shared.BlockchainNetwork(req.Network),
+		TransactionHash: req.TransactionHash,
+		FromAddress:     req.FromAddress,
+		ToAddress:       req.ToAddress,
+		Amount:          req.Amount,
+		Currency:        shared.CryptoCurrency(req.Currency),
+		BlockNumber:     req.BlockNumber,
+		BlockHash:       req.BlockHash,
+		Confirmations:   req.Confirmations,
+		Memo:            req.Memo,
+		Removed:         req.Removed,
+	})
+	if err != nil {
+		h.respondError(c, source.Name, err)
+		return
+	}
+
+	status := http.StatusAccepted
+	if result.Duplicate {
+		status = http.StatusOK
+	}
+	c.JSON(status, ToBlockchainNotificationResponse(result))
+}
+
+// authenticate verifies that a notification was signed by a configured source within the allowed
+// clock skew, responding 401 if it was not.
+func (h *BlockchainNotificationHandlers) authenticate(
+	c *gin.Context,
+	body []byte,
+) (config.NotificationSourceConfig, bool) {
+	name := c.GetHeader(NotificationSourceHeader)
+	timestamp := c.GetHeader(NotificationTimestampHeader)
+	signature := c.GetHeader(NotificationSignatureHeader)
+
+	source, known := h.sources[name]
+	if !known || timestamp == "" ||
+		!hmac.Equal([]byte(SignNotification(source.Secret, timestamp, body)), []byte(signature)) {
+		h.logger.Warn("Rejected transaction notification with unknown source or invalid signature",
+			zap.String("source", name))
+		c.JSON(http.StatusUnauthorized, createAuthErrorResponse(
+			"authentication_error", "INVALID_NOTIFICATION_SIGNATURE", "Notification source or signature is invalid"))
+		return config.NotificationSourceConfig{}, false
+	}
+
+	// Checked after the signature so the timestamp cannot be altered to replay an old notification
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || h.now().Sub(time.Unix(seconds, 0)).Abs() > h.maxSkew {
+		h.logger.Warn("Rejected stale transaction notification",
+			zap.String("source", name),
+			zap.String("timestamp", timestamp))
+		c.JSON(http.StatusUnauthorized, createAuthErrorResponse(
+			"authentication_error", "STALE_NOTIFICATION", "Notification timestamp is outside the allowed window"))
+		return config.NotificationSourceConfig{}, false
+	}
+
+	return source, true
+}
+
+// respondError maps detection domain errors to HTTP responses.
+func (h *BlockchainNotificationHandlers) respondError(c *gin.Context, source string, err error) {
+	switch {
+	case errors.Is(err, detection.ErrInvalidNotification):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	case errors.Is(err, detection.ErrUnknownAddress):
+		c.JSON(http.StatusUnprocessableEntity, createAuthErrorResponse(
+			"validation_error", detection.ErrCodeUnknownAddress, err.Error()))
+	default:
+		h.logger.Error("Failed to handle transaction notification",
+			zap.String("source", source),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to handle transaction notification"})
+	}
+}
+
+// RegisterBlockchainNotificationRoutes registers the transaction notification route. It is
+// authenticated by the notification signature rather than a session or API key.
+func (h *BlockchainNotificationHandlers) RegisterBlockchainNotificationRoutes(v1 *gin.RouterGroup) {
+	v1.POST("/blockchain/notifications", h.HandleNotification)
+}
@@ -0,0 +1,230 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBlockchainNotificationEndpoint(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(web.ErrorHandler(&config.Config{}, zap.NewNop()))
+
+	const secret = "watcher-secret"
+	cfg := config.NewConfig()
+	cfg.Blockchain.NotificationSources = []config.NotificationSourceConfig{
+		{Name: "merchant-node", Secret: secret, MerchantID: "test-merchant"},
+		{Name: "other-node", Secret: "other-secret", MerchantID: "other-merchant"},
+	}
+
+	handler, services := web.CreateTestHandlerWithServices()
+	notifications := web.NewBlockchainNotificationHandlers(
+		detection.NewService(services.Invoices, services.Payments, nil, nil, nil, zap.NewNop()), cfg, zap.NewNop())
+	router.POST("/api/v1/invoices", handler.CreateInvoice)
+	notifications.RegisterBlockchainNotificationRoutes(router.Group("/api/v1"))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/invoices", bytes.NewBufferString(
+		`{"title":"Order","items":[{"name":"Item","quantity":"1","unit_price":"10.00"}],"tax_rate":"0.00"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var inv web.CreateInvoiceResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &inv))
+	require.NoError(t, services.Invoices.MarkInvoiceAsViewed(context.Background(), inv.ID))
+
+	notification := func(txHash, toAddress, currency string, confirmations int) []byte {
+		req := web.BlockchainNotificationRequest{
+			Network:         "tron",
+			TransactionHash: txHash,
+			FromAddress:     "TSenderAddress123456789012345678901234567890",
+			ToAddress:       toAddress,
+			Amount:          inv.USDTAmount,
+			Currency:        currency,
+			Confirmations:   confirmations,
+		}
+		if confirmations > 0 {
+			req.BlockNumber = 1000
+			req.BlockHash = "0x00000000000000000000000000000000000000000000000000000000000003e8"
+		}
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+		return body
+	}
+
+	send := func(source, secret string, signedAt time.Time, body []byte) *httptest.ResponseRecorder {
+		timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/blockchain/notifications", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(web.NotificationSourceHeader, source)
+		req.Header.Set(web.NotificationTimestampHeader, timestamp)
+		req.Header.Set(web.NotificationSignatureHeader, web.SignNotification(secret, timestamp, body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	txHash := "0x4444444444444444444444444444444444444444444444444444444444444444"
+
+	t.Run("Notification_RejectsUnauthenticated", func(t *testing.T) {
+		body := notification(txHash, inv.Address, "USDT", 0)
+
+		require.Equal(t, http.StatusUnauthorized, send("unknown-node", secret, time.Now(), body).Code)
+		require.Equal(t, http.StatusUnauthorized, send("merchant-node", "wrong-secret", time.Now(), body).Code)
+		require.Equal(t, http.StatusUnauthorized, send("merchant-node", secret, time.Now().Add(-time.Hour), body).Code)
+	})
+
+	t.Run("Notification_DetectsPayment", func(t *testing.T) {
+		w := send("merchant-node", secret, time.Now(), notification(txHash, inv.Address, "USDT", 0))
+		require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+
+		var response web.BlockchainNotificationResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, inv.ID, response.InvoiceID)
+		require.Equal(t, "detected", response.Status)
+		require.False(t, response.Duplicate)
+
+		status, err := services.Invoices.GetInvoiceStatus(context.Background(), inv.ID)
+		require.NoError(t, err)
+		require.Equal(t, "confirming", string(status))
+	})
+
+	t.Run("Notification_DeduplicatesByTransactionHash", func(t *testing.T) {
+		w := send("merchant-node", secret, time.Now(), notification(txHash, inv.Address, "USDT", 1))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response web.BlockchainNotificationResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.True(t, response.Duplicate)
+		require.Equal(t, "confirmed", response.Status)
+		require.Equal(t, 1, response.Confirmations)
+
+		// A late notification with fewer confirmations leaves the payment as it is
+		w = send("merchant-node", secret, time.Now(), notification(txHash, inv.Address, "USDT", 0))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, 1, response.Confirmations)
+	})
+
+	t.Run("Notification_RejectsUnknownAddress", func(t *testing.T) {
+		otherTx := "0x5555555555555555555555555555555555555555555555555555555555555555"
+
+		w := send("merchant-node", secret, time.Now(),
+			notification(otherTx, "TUnknownAddress12345678901234567890", "USDT", 0))
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+
+		// Sources only see the invoices of the merchant they are configured for
+		w = send("other-node", "other-secret", time.Now(), notification(otherTx, inv.Address, "USDT", 0))
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	})
+
+	t.Run("Notification_RejectsCurrencyMismatch", func(t *testing.T) {
+		otherTx := "0x6666666666666666666666666666666666666666666666666666666666666666"
+
+		w := send("merchant-node", secret, time.Now(), notification(otherTx, inv.Address, "BTC", 0))
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+}
+
+func TestBlockchainNotification_AttributesByPaymentReference(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(web.ErrorHandler(&config.Config{}, zap.NewNop()))
+
+	const secret = "watcher-secret"
+	cfg := config.NewConfig()
+	cfg.Blockchain.NotificationSources = []config.NotificationSourceConfig{
+		{Name: "merchant-node", Secret: secret, MerchantID: "test-merchant"},
+	}
+
+	handler, services := web.CreateTestHandlerWithServices()
+	notifications := web.NewBlockchainNotificationHandlers(
+		detection.NewService(services.Invoices, services.Payments, nil, nil, nil, zap.NewNop()), cfg, zap.NewNop())
+	router.POST("/api/v1/invoices", handler.CreateInvoice)
+	notifications.RegisterBlockchainNotificationRoutes(router.Group("/api/v1"))
+
+	create := func(cryptoCurrency string) web.CreateInvoiceResponse {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/invoices", bytes.NewBufferString(
+			`{"title":"Order","items":[{"name":"Item","quantity":"1","unit_price":"10.00"}],"tax_rate":"0.00",`+
+				`"crypto_currency":"`+cryptoCurrency+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var inv web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &inv))
+		require.NotEmpty(t, inv.PaymentReference)
+		return inv
+	}
+
+	notify := func(inv web.CreateInvoiceResponse, currency, txHash, memo string) web.BlockchainNotificationResponse {
+		body, err := json.Marshal(web.BlockchainNotificationRequest{
+			Network:         inv.Network,
+			TransactionHash: txHash,
+			FromAddress:     "TExchangeHotWallet12345678901234567890",
+			ToAddress:       inv.Address,
+			Amount:          inv.USDTAmount,
+			Currency:        currency,
+			Memo:            memo,
+		})
+		require.NoError(t, err)
+
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/blockchain/notifications", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(web.NotificationSourceHeader, "merchant-node")
+		req.Header.Set(web.NotificationTimestampHeader, timestamp)
+		req.Header.Set(web.NotificationSignatureHeader, web.SignNotification(secret, timestamp, body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+
+		var response web.BlockchainNotificationResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("Memo_NamesTheInvoice", func(t *testing.T) {
+		// Both invoices share the payment address and amount; only the memo tells them apart
+		first, second := create("USDT"), create("USDT")
+		require.Equal(t, first.Address, second.Address)
+		require.NotEmpty(t, second.Memo)
+
+		response := notify(second, "USDT",
+			"0x7777777777777777777777777777777777777777777777777777777777777777", strings.ToLower(second.Memo))
+		require.Equal(t, second.ID, response.InvoiceID)
+
+		response = notify(first, "USDT",
+			"0x7777777777777777777777777777777777777777777777777777777777777778", first.Memo)
+		require.Equal(t, first.ID, response.InvoiceID)
+	})
+
+	t.Run("FingerprintedAmount_NamesTheInvoice", func(t *testing.T) {
+		first, second := create("BTC"), create("BTC")
+		require.Empty(t, second.Memo)
+		require.NotEqual(t, first.USDTAmount, second.USDTAmount)
+
+		response := notify(second, "BTC",
+			"8888888888888888888888888888888888888888888888888888888888888888", "")
+		require.Equal(t, second.ID, response.InvoiceID)
+
+		response = notify(first, "BTC",
+			"8888888888888888888888888888888888888888888888888888888888888889", "")
+		require.Equal(t, first.ID, response.InvoiceID)
+	})
+}
@@ -0,0 +1,264 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/compliance"
+	"crypto-checkout/internal/domain/merchant"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// BlocklistHandlers handles address blocklist HTTP requests.
+type BlocklistHandlers struct {
+	blocklistService compliance.BlocklistService
+	logger           *zap.Logger
+}
+
+// NewBlocklistHandlers creates a new blocklist handlers instance.
+func NewBlocklistHandlers(blocklistService compliance.BlocklistService, logger *zap.Logger) *BlocklistHandlers {
+	return &BlocklistHandlers{
+		blocklistService: blocklistService,
+		logger:           logger,
+	}
+}
+
+// ListEntries handles GET /blocklist
+// @Summary List blocklist entries
+// @Tags Compliance
+// @Produce json
+// @Security ApiKeyAuth
+// @Param source query string false "Filter by source" Enums(ofac, custom)
+// @Param address query string false "Filter by address"
+// @Param include_global query bool false "Include entries from the sanctions list"
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Number of items to skip" default(0) minimum(0)
+// @Success 200 {object} ListBlocklistResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/blocklist [get]
+func (h *BlocklistHandlers) ListEntries(c *gin.Context) {
+	var req ListBlocklistRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Failed to bind list blocklist request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.blocklistService.ListEntries(c.Request.Context(), &compliance.ListBlocklistRequest{
+		MerchantID:    merchantID,
+		IncludeGlobal: req.IncludeGlobal || req.Source == string(compliance.BlocklistSourceOFAC),
+		Source:        compliance.BlocklistSource(req.Source),
+		Address:       req.Address,
+		Limit:         req.Limit,
+		Offset:        req.Offset,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to list blocklist entries")
+		return
+	}
+
+	entries := make([]BlocklistEntryResponse, len(resp.Entries))
+	for i, entry := range resp.Entries {
+		entries[i] = ToBlocklistEntryResponse(entry)
+	}
+
+	c.JSON(http.StatusOK, ListBlocklistResponse{
+		Entries: entries,
+		Total:   resp.Total,
+		Limit:   resp.Limit,
+		Offset:  resp.Offset,
+	})
+}
+
+// CheckAddress handles GET /blocklist/check
+// Reports whether payments from the address to the caller's merchant would be held.
+// @Summary Check an address
+// @Description Report whether payments from the address would be held
+// @Tags Compliance
+// @Produce json
+// @Security ApiKeyAuth
+// @Param address query string true "Wallet address"
+// @Success 200 {object} BlocklistCheckResponse
+// @Failure 400 {object} ErrorResponse "Address is required"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/blocklist/check [get]
+func (h *BlocklistHandlers) CheckAddress(c *gin.Context) {
+	address := c.Query("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Address is required", nil))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	entry, err := h.blocklistService.Check(c.Request.Context(), merchantID, address)
+	if err != nil {
+		h.respondError(c, err, "Failed to check address")
+		return
+	}
+
+	resp := BlocklistCheckResponse{Address: compliance.NormalizeAddress(address), Blocked: entry != nil}
+	if entry != nil {
+		entryResp := ToBlocklistEntryResponse(entry)
+		resp.Entry = &entryResp
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// AddEntry handles POST /blocklist
+// @Summary Block an address
+// @Tags Compliance
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body AddBlocklistEntryRequest true "Blocklist entry"
+// @Success 201 {object} BlocklistEntryResponse "Address blocked"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 409 {object} ErrorResponse "Address already blocked"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/blocklist [post]
+func (h *BlocklistHandlers) AddEntry(c *gin.Context) {
+	var req AddBlocklistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind add blocklist entry request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	createdBy := c.GetString("user_id")
+	if createdBy == "" {
+		createdBy = c.GetString("api_key_id")
+	}
+
+	entry, err := h.blocklistService.AddEntry(c.Request.Context(), &compliance.AddBlocklistEntryRequest{
+		MerchantID: merchantID,
+		Address:    req.Address,
+		Network:    req.Network,
+		Reason:     req.Reason,
+		CreatedBy:  createdBy,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to add blocklist entry")
+		return
+	}
+
+	setAuditChange(c, nil, map[string]interface{}{"address": entry.Address(), "reason": entry.Reason()})
+	c.JSON(http.StatusCreated, ToBlocklistEntryResponse(entry))
+}
+
+// RemoveEntry handles DELETE /blocklist/:id
+// @Summary Unblock an address
+// @Tags Compliance
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Blocklist entry ID"
+// @Success 204 "Entry removed"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Blocklist entry not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/blocklist/{id} [delete]
+func (h *BlocklistHandlers) RemoveEntry(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	entry, err := h.blocklistService.RemoveEntry(c.Request.Context(), &compliance.RemoveBlocklistEntryRequest{
+		MerchantID: merchantID,
+		EntryID:    c.Param("id"),
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to remove blocklist entry")
+		return
+	}
+
+	setAuditChange(c, map[string]interface{}{"address": entry.Address(), "reason": entry.Reason()}, nil)
+	c.Status(http.StatusNoContent)
+}
+
+// SyncSanctions handles POST /admin/blocklist/sync
+// Downloads the sanctions list immediately instead of waiting for the next scheduled sync.
+// @Summary Sync the sanctions list
+// @Tags Admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} map[string]interface{} "Sanctions list synchronized"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 409 {object} map[string]interface{} "Sanctions list ingestion is not configured"
+// @Failure 502 {object} map[string]interface{} "Failed to sync sanctions list"
+// @Router /api/v1/admin/blocklist/sync [post]
+func (h *BlocklistHandlers) SyncSanctions(c *gin.Context) {
+	count, err := h.blocklistService.SyncSanctions(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, compliance.ErrSanctionsListDisabled) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "SANCTIONS_LIST_DISABLED",
+				"message": "Sanctions list ingestion is not configured",
+			})
+			return
+		}
+		h.logger.Error("Failed to sync sanctions list", zap.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error":   "SYNC_FAILED",
+			"message": "Failed to sync sanctions list",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Sanctions list synchronized",
+		"addresses": count,
+	})
+}
+
+// respondError maps blocklist domain errors to HTTP responses.
+func (h *BlocklistHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, compliance.ErrBlocklistEntryNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", compliance.ErrCodeBlocklistEntryNotFound, "Blocklist entry not found"))
+	case errors.Is(err, compliance.ErrBlocklistEntryExists):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", compliance.ErrCodeBlocklistEntryExists, "Address is already blocklisted"))
+	case errors.Is(err, compliance.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterBlocklistRoutes registers blocklist routes.
+func (h *BlocklistHandlers) RegisterBlocklistRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	blocklist := protected.Group("/blocklist", rbac.RequireRolePermission(merchant.PermissionBlocklistManage))
+	blocklist.GET("", h.ListEntries)
+	blocklist.GET("/check", h.CheckAddress)
+	blocklist.POST("", rbac.AuditAction("blocklist.entry_added"), h.AddEntry)
+	blocklist.DELETE("/:id", rbac.AuditAction("blocklist.entry_removed"), h.RemoveEntry)
+
+	protected.POST("/admin/blocklist/sync", rbac.RequireRolePermission(merchant.PermissionAdminOperations),
+		rbac.AuditAction("admin.sync_sanctions"), h.SyncSanctions)
+}
@@ -0,0 +1,96 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/checkoutanalytics"
+	"crypto-checkout/internal/domain/invoice"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// recordCheckoutView counts a view of an invoice's checkout page. Invoices that can no longer be paid are not
+// counted, as customers only come back to them to see their status. Failures are logged and the page is shown.
+func (h *Handler) recordCheckoutView(c *gin.Context, inv *invoice.Invoice) {
+	if h.checkoutAnalytics == nil || inv.Status().IsTerminal() {
+		return
+	}
+
+	req := &checkoutanalytics.ViewRequest{
+		InvoiceID:  inv.ID(),
+		MerchantID: inv.MerchantID(),
+		UserAgent:  c.Request.UserAgent(),
+		Referrer:   c.Request.Referer(),
+		Host:       c.Request.Host,
+		Country:    h.clientCountry(c),
+	}
+	if err := h.checkoutAnalytics.RecordView(c.Request.Context(), req); err != nil {
+		h.Logger.Warn("Failed to record checkout view", zap.Error(err), zap.String("invoice_id", inv.ID()))
+	}
+}
+
+// RecordCheckoutStep handles POST /api/v1/public/invoice/:id/funnel requests from the checkout page.
+// @Summary Record a checkout funnel step
+// @Description Record that the customer reached a step of the checkout funnel, such as copying the payment
+// @Description address. Steps of checkouts whose page was not viewed are ignored.
+// @Tags Public
+// @Accept json
+// @Param id path string true "Invoice ID"
+// @Param request body RecordCheckoutStepRequest true "Funnel step"
+// @Success 204 "Step recorded"
+// @Failure 400 {object} ErrorResponse "Invalid step"
+// @Router /api/v1/public/invoice/{id}/funnel [post]
+func (h *Handler) RecordCheckoutStep(c *gin.Context) {
+	var req RecordCheckoutStepRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	if h.checkoutAnalytics != nil {
+		if err := h.checkoutAnalytics.RecordAddressCopied(c.Request.Context(), c.Param("id")); err != nil {
+			h.Logger.Warn("Failed to record checkout step", zap.Error(err),
+				zap.String("invoice_id", c.Param("id")), zap.String("step", req.Step))
+		}
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// checkoutSummary returns the checkout funnel of the caller's invoices first viewed between the dates of an
+// analytics request. None is returned if it cannot be read, so analytics still show.
+func (h *Handler) checkoutSummary(c *gin.Context, req *AnalyticsRequest) *CheckoutFunnelResponse {
+	merchantID := c.GetString("merchant_id")
+	if h.checkoutAnalytics == nil || merchantID == "" {
+		return nil
+	}
+
+	var period CheckoutFunnelRequest
+	if date, err := time.Parse(time.DateOnly, req.StartDate); err == nil {
+		period.StartDate = &date
+	}
+	if date, err := time.Parse(time.DateOnly, req.EndDate); err == nil {
+		period.EndDate = &date
+	}
+	from, to := checkoutPeriod(&period)
+
+	summary, err := h.checkoutAnalytics.GetSummary(c.Request.Context(), merchantID, from, to)
+	if err != nil {
+		h.Logger.Warn("Failed to summarize checkout funnel for analytics", zap.Error(err))
+		return nil
+	}
+	response := ToCheckoutFunnelResponse(summary)
+	return &response
+}
+
+// checkoutPeriod returns the bounds of a funnel request's period. The end date is inclusive, so the period runs
+// to the start of the next day; missing dates leave the period open.
+func checkoutPeriod(req *CheckoutFunnelRequest) (from, to time.Time) {
+	if req.StartDate != nil {
+		from = *req.StartDate
+	}
+	if req.EndDate != nil {
+		to = req.EndDate.Add(24 * time.Hour)
+	}
+	return from, to
+}
@@ -0,0 +1,115 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/checkoutanalytics"
+	"crypto-checkout/internal/domain/merchant"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CheckoutAnalyticsHandlers handles the checkout page funnel analytics of merchants.
+type CheckoutAnalyticsHandlers struct {
+	checkoutAnalytics checkoutanalytics.Service
+	logger            *zap.Logger
+}
+
+// NewCheckoutAnalyticsHandlers creates a new checkout analytics handlers instance.
+func NewCheckoutAnalyticsHandlers(
+	checkoutAnalytics checkoutanalytics.Service,
+	logger *zap.Logger,
+) *CheckoutAnalyticsHandlers {
+	return &CheckoutAnalyticsHandlers{
+		checkoutAnalytics: checkoutAnalytics,
+		logger:            logger,
+	}
+}
+
+// GetCheckoutFunnel handles GET /analytics/checkout
+// @Summary Get the checkout funnel
+// @Description Checkout pages viewed, payment addresses copied and invoices paid, with the mean time to payment,
+// @Description for invoices first viewed in the period. Viewed checkouts are also counted by browser family,
+// @Description device class, referring host and country; client addresses and user agents are not kept.
+// @Tags Analytics
+// @Produce json
+// @Security ApiKeyAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date, inclusive (YYYY-MM-DD)"
+// @Success 200 {object} CheckoutFunnelResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/analytics/checkout [get]
+func (h *CheckoutAnalyticsHandlers) GetCheckoutFunnel(c *gin.Context) {
+	var req CheckoutFunnelRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	from, to := checkoutPeriod(&req)
+	summary, err := h.checkoutAnalytics.GetSummary(c.Request.Context(), merchantID, from, to)
+	if err != nil {
+		respondCheckoutAnalyticsError(c, h.logger, err, "Failed to summarize checkout funnel")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToCheckoutFunnelResponse(summary))
+}
+
+// GetInvoiceFunnel handles GET /invoices/:id/funnel
+// @Summary Get the checkout funnel of an invoice
+// @Description How far the checkout of an invoice went: when its page was first viewed, when the payment address
+// @Description was first copied and when it was paid.
+// @Tags Invoices
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} InvoiceFunnelResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "The invoice's checkout page was not viewed"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoices/{id}/funnel [get]
+func (h *CheckoutAnalyticsHandlers) GetInvoiceFunnel(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	funnel, err := h.checkoutAnalytics.GetInvoiceFunnel(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondCheckoutAnalyticsError(c, h.logger, err, "Failed to get invoice checkout funnel")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToInvoiceFunnelResponse(funnel))
+}
+
+// respondCheckoutAnalyticsError maps checkout analytics errors to HTTP responses.
+func respondCheckoutAnalyticsError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, checkoutanalytics.ErrFunnelNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", checkoutanalytics.ErrCodeFunnelNotFound, err.Error()))
+	case errors.Is(err, checkoutanalytics.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterCheckoutAnalyticsRoutes registers the checkout analytics routes on the protected group.
+func (h *CheckoutAnalyticsHandlers) RegisterCheckoutAnalyticsRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	protected.GET("/analytics/checkout", rbac.RequireRolePermission(merchant.PermissionAnalyticsRead), h.GetCheckoutFunnel)
+	protected.GET("/invoices/:id/funnel", rbac.RequireRolePermission(merchant.PermissionInvoicesRead), h.GetInvoiceFunnel)
+}
@@ -0,0 +1,146 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/checkoutanalytics"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// countryLocator locates every address in one country.
+type countryLocator string
+
+func (l countryLocator) Country(netip.Addr) string { return string(l) }
+
+func TestCheckoutAnalyticsHandlers_Funnel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	conn, err := database.NewConnection(config.DatabaseConfig{URL: "sqlite://:memory:"}, logger)
+	require.NoError(t, err)
+	require.NoError(t, conn.Migrate())
+	analytics := checkoutanalytics.NewService(database.NewCheckoutAnalyticsRepository(conn.DB, logger), logger)
+
+	handler, services := web.CreateTestHandlerWithServices()
+	handler.SetCheckoutAnalytics(analytics)
+	handler.SetCountryLocator(countryLocator("pt"))
+	bundle, err := i18n.NewBundle()
+	require.NoError(t, err)
+	router := web.NewGinEngine(&config.Config{}, logger, bundle, nil)
+	handler.RegisterRoutes(router)
+
+	api := router.Group("/api/v1/merchant", func(c *gin.Context) {
+		if merchantID := c.GetHeader("X-Merchant-ID"); merchantID != "" {
+			c.Set("merchant_id", merchantID)
+		}
+		c.Next()
+	})
+	web.NewCheckoutAnalyticsHandlers(analytics, logger).RegisterCheckoutAnalyticsRoutes(api, nil)
+
+	get := func(path, merchantID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/merchant"+path, http.NoBody)
+		req.Header.Set("X-Merchant-ID", merchantID)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	recordStep := func(invoiceID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/public/invoice/"+invoiceID+"/funnel",
+			bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	unitPrice, err := shared.NewMoney("20.00", shared.CurrencyUSD)
+	require.NoError(t, err)
+	inv, err := services.Invoices.CreateInvoice(ctx, &invoice.CreateInvoiceRequest{
+		MerchantID:     "test-merchant",
+		Title:          "Funnel Invoice",
+		Items:          []*invoice.CreateInvoiceItemRequest{{Name: "Item", Quantity: "1", UnitPrice: unitPrice}},
+		TaxRate:        "0.00",
+		Currency:       shared.CurrencyUSD,
+		CryptoCurrency: shared.CryptoCurrencyUSDT,
+	})
+	require.NoError(t, err)
+
+	t.Run("Not_Viewed", func(t *testing.T) {
+		w := get("/invoices/"+inv.ID()+"/funnel", "test-merchant")
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	for range 2 {
+		req := httptest.NewRequest(http.MethodGet, "/invoice/"+inv.ID(), http.NoBody)
+		req.Header.Set("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 18_0 like Mac OS X) AppleWebKit/605.1.15 "+
+			"(KHTML, like Gecko) Version/18.0 Mobile/15E148 Safari/604.1")
+		req.Header.Set("Referer", "https://shop.example.org/cart?email=customer@example.org")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), "recordFunnelStep('address_copied')")
+	}
+
+	t.Run("Record_Step", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, recordStep(inv.ID(), `{"step":"paid"}`).Code,
+			"only the checkout page's own steps are reported")
+		require.Equal(t, http.StatusNoContent, recordStep(inv.ID(), `{"step":"address_copied"}`).Code)
+	})
+
+	paid := checkoutanalytics.NewPaidInvoiceHandler(analytics, logger)
+	require.NoError(t, paid.HandleEvent(ctx, shared.NewInvoicePaidEvent(shared.InvoiceEvent{
+		InvoiceID: inv.ID(), MerchantID: "test-merchant", TotalAmount: "20.00", Currency: "USD", Status: "paid",
+	})))
+
+	t.Run("Invoice_Funnel", func(t *testing.T) {
+		w := get("/invoices/"+inv.ID()+"/funnel", "test-merchant")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var funnel web.InvoiceFunnelResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &funnel))
+		require.Equal(t, "paid", funnel.Step)
+		require.Equal(t, 2, funnel.Views)
+		require.NotNil(t, funnel.AddressCopiedAt)
+		require.NotNil(t, funnel.TimeToPaymentSeconds)
+
+		w = get("/invoices/"+inv.ID()+"/funnel", "other-merchant")
+		require.Equal(t, http.StatusNotFound, w.Code, "funnels are scoped to their merchant")
+	})
+
+	t.Run("Merchant_Funnel", func(t *testing.T) {
+		w := get("/analytics/checkout", "test-merchant")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var funnel web.CheckoutFunnelResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &funnel))
+		require.Equal(t, 1, funnel.Viewed, "reloads count as one checkout")
+		require.Equal(t, 1, funnel.AddressCopied)
+		require.Equal(t, 1, funnel.Paid)
+		require.InDelta(t, 1.0, funnel.ConversionRate, 0.0001)
+		require.Equal(t, map[string]int{"safari": 1}, funnel.Browsers)
+		require.Equal(t, map[string]int{"mobile": 1}, funnel.Devices)
+		require.Equal(t, map[string]int{"shop.example.org": 1}, funnel.Referrers)
+		require.Equal(t, map[string]int{"PT": 1}, funnel.Countries)
+
+		w = get("/analytics/checkout?start_date=2020-01-01&end_date=2020-01-31", "test-merchant")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &funnel))
+		require.Zero(t, funnel.Viewed)
+
+		w = get("/analytics/checkout?start_date=2020-02-01&end_date=2020-01-01", "test-merchant")
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+}
@@ -0,0 +1,104 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/experiment"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// checkoutSessionCookie identifies a customer's browser across checkout pages, so that experiments show
+	// it the same variant on every visit.
+	checkoutSessionCookie = "checkout_session"
+	// checkoutSessionMaxAge is how long, in seconds, a checkout session is remembered.
+	checkoutSessionMaxAge = 90 * 24 * 60 * 60
+)
+
+// checkoutSessionPattern matches the checkout session IDs this server issues.
+var checkoutSessionPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// checkoutVariant returns the experiment variant to show on an invoice's checkout page, or nil if the merchant
+// runs no experiment. Sessions are only issued while the merchant runs one, and invoices that can no longer be
+// paid are not assigned, as they cannot convert. Failures are logged and the standard page is shown.
+func (h *Handler) checkoutVariant(c *gin.Context, inv *invoice.Invoice) *experiment.Variant {
+	if h.experimentService == nil || inv.Status().IsTerminal() {
+		return nil
+	}
+
+	ctx := c.Request.Context()
+	running, err := h.experimentService.RunningExperiment(ctx, inv.MerchantID())
+	if err != nil {
+		h.Logger.Warn("Failed to find running experiment", zap.Error(err), zap.String("invoice_id", inv.ID()))
+		return nil
+	}
+	if running == nil {
+		return nil
+	}
+
+	sessionID, err := checkoutSession(c)
+	if err != nil {
+		h.Logger.Warn("Failed to issue checkout session", zap.Error(err))
+		return nil
+	}
+
+	variant, err := h.experimentService.AssignCheckout(ctx, running, inv.ID(), sessionID)
+	if err != nil {
+		h.Logger.Warn("Failed to assign checkout variant", zap.Error(err), zap.String("invoice_id", inv.ID()))
+		return nil
+	}
+	return &variant
+}
+
+// checkoutSession returns the checkout session of the request, issuing a session cookie if it has none.
+func checkoutSession(c *gin.Context) (string, error) {
+	if sessionID, err := c.Cookie(checkoutSessionCookie); err == nil && checkoutSessionPattern.MatchString(sessionID) {
+		return sessionID, nil
+	}
+
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	sessionID := hex.EncodeToString(bytes)
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(checkoutSessionCookie, sessionID, checkoutSessionMaxAge, "/", "", c.Request.TLS != nil, true)
+	return sessionID, nil
+}
+
+// applyCheckoutVariant changes the checkout page data to show an experiment variant: its copy replaces the
+// page messages, its layout arranges the page and its currencies are offered first.
+func applyCheckoutVariant(templateData gin.H, messages map[string]string, variant *experiment.Variant) {
+	for id, text := range variant.Copy {
+		messages[id] = text
+	}
+	templateData["Layout"] = variant.Layout.String()
+	templateData["Variant"] = variant.Key
+	if currencies, ok := templateData["Currencies"].([]checkoutCurrency); ok {
+		templateData["Currencies"] = orderCurrencies(currencies, variant.CurrencyOrder)
+	}
+}
+
+// orderCurrencies moves the currencies whose symbols are listed to the front, in the listed order. The others
+// follow in their usual order.
+func orderCurrencies(currencies []checkoutCurrency, order []string) []checkoutCurrency {
+	rank := func(currency checkoutCurrency) int {
+		if i := slices.Index(order, currency.Symbol); i >= 0 {
+			return i
+		}
+		return len(order)
+	}
+
+	ordered := slices.Clone(currencies)
+	slices.SortStableFunc(ordered, func(a, b checkoutCurrency) int {
+		return rank(a) - rank(b)
+	})
+	return ordered
+}
@@ -380,10 +380,14 @@ func GetAuthInfo(c *gin.Context) (apiKeyID, merchantID string, permissions []str
 	}
 
 	// Determine authentication type
-	if _, hasScope := c.Get("jwt_scope"); hasScope {
+	_, hasScope := c.Get("jwt_scope")
+	switch {
+	case c.GetString("user_id") != "":
+		authType = AuthTypeSession
+	case hasScope:
 		authType = "jwt"
-	} else {
-		authType = "api_key"
+	default:
+		authType = AuthTypeAPIKey
 	}
 
 	return apiKeyID, merchantID, permissions, authType
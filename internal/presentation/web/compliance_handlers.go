@@ -0,0 +1,214 @@
+package web
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/compliance"
+	"crypto-checkout/internal/domain/merchant"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ComplianceHandlers handles manual review of payments held by AML/KYT screening.
+type ComplianceHandlers struct {
+	reviewService compliance.ReviewService
+	logger        *zap.Logger
+}
+
+// NewComplianceHandlers creates a new compliance handlers instance.
+func NewComplianceHandlers(reviewService compliance.ReviewService, logger *zap.Logger) *ComplianceHandlers {
+	return &ComplianceHandlers{
+		reviewService: reviewService,
+		logger:        logger,
+	}
+}
+
+// ListReviews handles GET /compliance/reviews
+// @Summary List compliance reviews
+// @Description List payments screened by AML/KYT
+// @Tags Compliance
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "Filter by status" Enums(cleared, held, approved, rejected)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Number of items to skip" default(0) minimum(0)
+// @Success 200 {object} ListComplianceReviewsResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/compliance/reviews [get]
+func (h *ComplianceHandlers) ListReviews(c *gin.Context) {
+	var req ListComplianceReviewsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Failed to bind list compliance reviews request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.reviewService.ListReviews(c.Request.Context(), &compliance.ListReviewsRequest{
+		MerchantID: merchantID,
+		Status:     compliance.ScreeningStatus(req.Status),
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to list compliance reviews")
+		return
+	}
+
+	reviews := make([]ScreeningResponse, len(resp.Screenings))
+	for i, screening := range resp.Screenings {
+		reviews[i] = ToScreeningResponse(screening)
+	}
+
+	c.JSON(http.StatusOK, ListComplianceReviewsResponse{
+		Reviews: reviews,
+		Total:   resp.Total,
+		Limit:   resp.Limit,
+		Offset:  resp.Offset,
+	})
+}
+
+// GetReview handles GET /compliance/reviews/:id
+// @Summary Get a compliance review
+// @Tags Compliance
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Screening ID"
+// @Success 200 {object} ScreeningResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Screening not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/compliance/reviews/{id} [get]
+func (h *ComplianceHandlers) GetReview(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	screening, err := h.reviewService.GetReview(c.Request.Context(), &compliance.GetReviewRequest{
+		MerchantID:  merchantID,
+		ScreeningID: c.Param("id"),
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to get compliance review")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToScreeningResponse(screening))
+}
+
+// ApproveReview handles POST /compliance/reviews/:id/approve
+// @Summary Release a held payment
+// @Tags Compliance
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Screening ID"
+// @Param request body ComplianceReviewDecisionRequest false "Optional note"
+// @Success 200 {object} ScreeningResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Screening not found"
+// @Failure 409 {object} ErrorResponse "Payment is not held"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/compliance/reviews/{id}/approve [post]
+func (h *ComplianceHandlers) ApproveReview(c *gin.Context) {
+	h.decide(c, h.reviewService.ApproveReview)
+}
+
+// RejectReview handles POST /compliance/reviews/:id/reject
+// @Summary Reject a held payment
+// @Tags Compliance
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Screening ID"
+// @Param request body ComplianceReviewDecisionRequest false "Optional note"
+// @Success 200 {object} ScreeningResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Screening not found"
+// @Failure 409 {object} ErrorResponse "Payment is not held"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/compliance/reviews/{id}/reject [post]
+func (h *ComplianceHandlers) RejectReview(c *gin.Context) {
+	h.decide(c, h.reviewService.RejectReview)
+}
+
+// decide applies a review decision made by the authenticated caller.
+func (h *ComplianceHandlers) decide(
+	c *gin.Context,
+	apply func(ctx context.Context, req *compliance.ReviewDecisionRequest) (*compliance.Screening, error),
+) {
+	var req ComplianceReviewDecisionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Error("Failed to bind compliance review decision", zap.Error(err))
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+			return
+		}
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	reviewerID := c.GetString("user_id")
+	if reviewerID == "" {
+		reviewerID = c.GetString("api_key_id")
+	}
+
+	screening, err := apply(c.Request.Context(), &compliance.ReviewDecisionRequest{
+		MerchantID:  merchantID,
+		ScreeningID: c.Param("id"),
+		ReviewerID:  reviewerID,
+		Note:        req.Note,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to apply compliance review decision")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToScreeningResponse(screening))
+}
+
+// respondError maps compliance domain errors to HTTP responses.
+func (h *ComplianceHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, compliance.ErrScreeningNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", compliance.ErrCodeScreeningNotFound, "Compliance review not found"))
+	case errors.Is(err, compliance.ErrScreeningNotHeld):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", compliance.ErrCodeScreeningNotHeld, "Compliance review is not awaiting a decision"))
+	case errors.Is(err, compliance.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterComplianceRoutes registers compliance review routes.
+func (h *ComplianceHandlers) RegisterComplianceRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionComplianceReview)
+
+	reviews := protected.Group("/compliance/reviews", require)
+	reviews.GET("", h.ListReviews)
+	reviews.GET("/:id", h.GetReview)
+	reviews.POST("/:id/approve", h.ApproveReview)
+	reviews.POST("/:id/reject", h.RejectReview)
+}
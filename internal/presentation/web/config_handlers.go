@@ -0,0 +1,82 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/infrastructure/runtimeconfig"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ConfigHandlers handles the runtime settings: rate limits, confirmation rules, the webhook retry policy and
+// feature flags.
+type ConfigHandlers struct {
+	settings *runtimeconfig.Store
+	logger   *zap.Logger
+}
+
+// NewConfigHandlers creates a new config handlers instance.
+func NewConfigHandlers(settings *runtimeconfig.Store, logger *zap.Logger) *ConfigHandlers {
+	return &ConfigHandlers{
+		settings: settings,
+		logger:   logger,
+	}
+}
+
+// GetRuntimeSettings handles GET /admin/config/runtime
+// @Summary Get the runtime settings
+// @Description Rate limits, confirmation rules, the webhook retry policy and feature flags in force
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} RuntimeSettingsResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Router /api/v1/admin/config/runtime [get]
+func (h *ConfigHandlers) GetRuntimeSettings(c *gin.Context) {
+	c.JSON(http.StatusOK, ToRuntimeSettingsResponse(h.settings.Settings()))
+}
+
+// ReloadConfig handles POST /admin/config/reload
+// @Summary Reload the runtime settings
+// @Description Read the config file and environment again and put the runtime section in force without a
+// @Description restart, as SIGHUP does. Invalid settings are rejected as a whole and the current ones stay in
+// @Description force. Changes to other sections are reported in restart_required and not applied. The reload is
+// @Description recorded in the audit log with the changed settings.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} ReloadConfigResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 422 {object} ErrorResponse "Invalid runtime settings"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/config/reload [post]
+func (h *ConfigHandlers) ReloadConfig(c *gin.Context) {
+	result, err := h.settings.Reload(c.Request.Context(), runtimeconfig.TriggerAPI, auditActor(c))
+	if err != nil {
+		if errors.Is(err, runtimeconfig.ErrInvalidSettings) {
+			c.JSON(http.StatusUnprocessableEntity, createAuthErrorResponse(
+				"validation_error", "INVALID_RUNTIME_SETTINGS", err.Error()))
+			return
+		}
+		h.logger.Error("Failed to reload runtime settings", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reload runtime settings"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ToReloadConfigResponse(result))
+}
+
+// RegisterConfigRoutes registers the runtime settings routes. Reloads record their own audit entry.
+func (h *ConfigHandlers) RegisterConfigRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionAdminOperations)
+
+	settings := protected.Group("/admin/config", require)
+	settings.GET("/runtime", h.GetRuntimeSettings)
+	settings.POST("/reload", h.ReloadConfig)
+}
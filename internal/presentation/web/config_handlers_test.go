@@ -0,0 +1,96 @@
+package web_test
+
+import (
+	"crypto-checkout/internal/infrastructure/runtimeconfig"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRuntimeConfigReload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := config.NewConfig()
+	cfg.Runtime.RateLimits.API = 2
+	loaded := config.NewConfig()
+	loaded.Runtime.RateLimits.API = 2
+	store, err := runtimeconfig.NewStore(cfg, func() (*config.Config, error) {
+		copied := *loaded
+		return &copied, nil
+	}, nil, zap.NewNop())
+	require.NoError(t, err)
+
+	handlers := web.NewConfigHandlers(store, zap.NewNop())
+	router := gin.New()
+	router.Use(web.NewRateLimiter(store).Middleware())
+	router.GET("/health/live", func(c *gin.Context) { c.Status(http.StatusOK) })
+	handlers.RegisterConfigRoutes(router.Group("/api/v1"), nil)
+	// Reloads go through a router of their own so the limit under test does not block them
+	admin := gin.New()
+	handlers.RegisterConfigRoutes(admin.Group("/api/v1"), nil)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	reload := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		admin.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/admin/config/reload", nil))
+		return w
+	}
+
+	t.Run("rate limit applies per minute", func(t *testing.T) {
+		w := get("/api/v1/admin/config/runtime")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get(web.RateLimitLimitHeader))
+		assert.Equal(t, "1", w.Header().Get(web.RateLimitRemainingHeader))
+
+		var settings web.RuntimeSettingsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &settings))
+		assert.Equal(t, 2, settings.RateLimits.API)
+		assert.Equal(t, web.ConfirmationRuleResponse{Threshold: "1000", Below: 1, AtOrAbove: 6},
+			settings.Confirmations["bitcoin"])
+		assert.Equal(t, map[string]bool{runtimeconfig.FeatureGraphQL: true}, settings.Features)
+
+		require.Equal(t, http.StatusOK, get("/api/v1/admin/config/runtime").Code)
+		w = get("/api/v1/admin/config/runtime")
+		require.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+		// Health checks are not limited
+		assert.Equal(t, http.StatusOK, get("/health/live").Code)
+	})
+
+	t.Run("invalid settings are rejected", func(t *testing.T) {
+		loaded.Runtime.RateLimits.API = 0
+		loaded.Runtime.Webhooks.Backoff = "random"
+
+		w := reload()
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "webhooks.backoff")
+		assert.Equal(t, 2, store.Settings().RateLimits.API)
+	})
+
+	t.Run("reload lifts the limit", func(t *testing.T) {
+		loaded.Runtime.Webhooks.Backoff = config.DefaultWebhookBackoff
+
+		w := reload()
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var result web.ReloadConfigResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		assert.Equal(t, []web.ConfigChangeResponse{{Key: "rate_limits.api", Before: "2", After: "0"}}, result.Changes)
+		assert.Empty(t, result.RestartRequired)
+
+		w = get("/api/v1/admin/config/runtime")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get(web.RateLimitLimitHeader))
+	})
+}
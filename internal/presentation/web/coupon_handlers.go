@@ -0,0 +1,270 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/coupon"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CouponHandlers handles merchant coupon management.
+type CouponHandlers struct {
+	couponService coupon.Service
+	logger        *zap.Logger
+}
+
+// NewCouponHandlers creates a new coupon handlers instance.
+func NewCouponHandlers(couponService coupon.Service, logger *zap.Logger) *CouponHandlers {
+	return &CouponHandlers{
+		couponService: couponService,
+		logger:        logger,
+	}
+}
+
+// CreateCoupon handles POST /coupons
+// @Summary Create a coupon
+// @Tags Coupons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body CreateCouponRequest true "Coupon"
+// @Success 201 {object} CouponResponse "Coupon created"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 409 {object} ErrorResponse "Coupon code already exists"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/coupons [post]
+func (h *CouponHandlers) CreateCoupon(c *gin.Context) {
+	var req CreateCouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind create coupon request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	created, err := h.couponService.CreateCoupon(c.Request.Context(), &coupon.CreateCouponRequest{
+		MerchantID:     merchantID,
+		Code:           req.Code,
+		DiscountType:   invoice.DiscountType(req.Type),
+		DiscountValue:  req.Value,
+		MaxRedemptions: req.MaxRedemptions,
+		ExpiresAt:      req.ExpiresAt,
+	})
+	if err != nil {
+		respondCouponError(c, h.logger, err, "Failed to create coupon")
+		return
+	}
+
+	setAuditChange(c, nil, map[string]interface{}{
+		"code":  created.Code(),
+		"type":  created.Discount().Type().String(),
+		"value": created.Discount().Value().String(),
+	})
+	c.JSON(http.StatusCreated, ToCouponResponse(created))
+}
+
+// ListCoupons handles GET /coupons
+// @Summary List coupons
+// @Tags Coupons
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} ListCouponsResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/coupons [get]
+func (h *CouponHandlers) ListCoupons(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	coupons, err := h.couponService.ListCoupons(c.Request.Context(), merchantID)
+	if err != nil {
+		respondCouponError(c, h.logger, err, "Failed to list coupons")
+		return
+	}
+
+	response := ListCouponsResponse{Coupons: make([]CouponResponse, len(coupons))}
+	for i, cpn := range coupons {
+		response.Coupons[i] = ToCouponResponse(cpn)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetCoupon handles GET /coupons/:id
+// @Summary Get a coupon
+// @Tags Coupons
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Coupon ID"
+// @Success 200 {object} CouponResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Coupon not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/coupons/{id} [get]
+func (h *CouponHandlers) GetCoupon(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	cpn, err := h.couponService.GetCoupon(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondCouponError(c, h.logger, err, "Failed to get coupon")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToCouponResponse(cpn))
+}
+
+// DeactivateCoupon handles POST /coupons/:id/deactivate
+// @Summary Deactivate a coupon
+// @Tags Coupons
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Coupon ID"
+// @Success 200 {object} CouponResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Coupon not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/coupons/{id}/deactivate [post]
+func (h *CouponHandlers) DeactivateCoupon(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	cpn, err := h.couponService.DeactivateCoupon(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondCouponError(c, h.logger, err, "Failed to deactivate coupon")
+		return
+	}
+
+	setAuditChange(c, map[string]interface{}{"active": true}, map[string]interface{}{"active": cpn.IsActive()})
+	c.JSON(http.StatusOK, ToCouponResponse(cpn))
+}
+
+// RegisterCouponRoutes registers coupon management routes.
+func (h *CouponHandlers) RegisterCouponRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	coupons := protected.Group("/coupons", rbac.RequireRolePermission(merchant.PermissionCouponsManage))
+	coupons.POST("", rbac.AuditAction("coupon.create"), h.CreateCoupon)
+	coupons.GET("", h.ListCoupons)
+	coupons.GET("/:id", h.GetCoupon)
+	coupons.POST("/:id/deactivate", rbac.AuditAction("coupon.deactivate"), h.DeactivateCoupon)
+}
+
+// ApplyInvoiceCoupon handles POST /api/v1/public/invoice/:id/coupon requests from the checkout page.
+// @Summary Apply a coupon code to an invoice
+// @Description Redeem a merchant coupon code on an unpaid invoice and return the repriced invoice
+// @Tags Public
+// @Accept json
+// @Produce json
+// @Param id path string true "Invoice ID"
+// @Param request body ApplyCouponRequest true "Coupon code"
+// @Success 200 {object} PublicInvoiceResponse "Coupon applied"
+// @Failure 400 {object} ErrorResponse "Invalid request or coupons unavailable"
+// @Failure 404 {object} ErrorResponse "Invoice or coupon not found"
+// @Failure 409 {object} ErrorResponse "Invoice can no longer take a coupon"
+// @Failure 422 {object} ErrorResponse "Coupon is inactive, expired or used up"
+// @Router /api/v1/public/invoice/{id}/coupon [post]
+func (h *Handler) ApplyInvoiceCoupon(c *gin.Context) {
+	var req ApplyCouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	if h.couponService == nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Coupon codes are not available", nil))
+		return
+	}
+
+	inv, err := h.couponService.ApplyToInvoice(c.Request.Context(), c.Param("id"), req.Code)
+	if err != nil {
+		respondCouponError(c, h.Logger, err, "Failed to apply coupon")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.toPublicInvoiceResponse(inv))
+}
+
+// redeemCreateInvoiceCoupon redeems the coupon code of a create invoice request and adds it to the
+// service request. On failure it responds to the client and returns the error.
+func (h *Handler) redeemCreateInvoiceCoupon(
+	c *gin.Context,
+	code string,
+	serviceReq *invoice.CreateInvoiceRequest,
+) (*coupon.Coupon, error) {
+	if code == "" {
+		return nil, nil
+	}
+
+	if h.couponService == nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Coupon codes are not available", nil))
+		return nil, coupon.ErrInvalidRequest
+	}
+
+	redeemed, err := h.couponService.RedeemCoupon(c.Request.Context(), serviceReq.MerchantID, code)
+	if err != nil {
+		respondCouponError(c, h.Logger, err, "Failed to redeem coupon")
+		return nil, err
+	}
+
+	applied, err := redeemed.ToAppliedCoupon()
+	if err != nil {
+		_ = h.couponService.ReleaseCoupon(c.Request.Context(), redeemed.ID())
+		respondCouponError(c, h.Logger, err, "Failed to redeem coupon")
+		return nil, err
+	}
+
+	serviceReq.Coupon = applied
+	return redeemed, nil
+}
+
+// respondCouponError maps coupon and invoice discount errors to HTTP responses.
+func respondCouponError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, coupon.ErrCouponNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", coupon.ErrCodeCouponNotFound, "Coupon not found"))
+	case errors.Is(err, invoice.ErrInvoiceNotFound), errors.Is(err, shared.ErrNotFound):
+		c.JSON(http.StatusNotFound, createNotFoundErrorResponse("invoice not found"))
+	case errors.Is(err, coupon.ErrCouponExists):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", coupon.ErrCodeCouponExists, err.Error()))
+	case errors.Is(err, coupon.ErrCouponInactive):
+		c.JSON(http.StatusUnprocessableEntity, createAuthErrorResponse(
+			"validation_error", coupon.ErrCodeCouponInactive, err.Error()))
+	case errors.Is(err, coupon.ErrCouponExpired):
+		c.JSON(http.StatusUnprocessableEntity, createAuthErrorResponse(
+			"validation_error", coupon.ErrCodeCouponExpired, err.Error()))
+	case errors.Is(err, coupon.ErrCouponExhausted):
+		c.JSON(http.StatusUnprocessableEntity, createAuthErrorResponse(
+			"validation_error", coupon.ErrCodeCouponExhausted, err.Error()))
+	case errors.Is(err, invoice.ErrCouponAlreadyApplied):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", invoice.ErrCodeCouponAlreadyApplied, err.Error()))
+	case errors.Is(err, invoice.ErrCannotApplyCoupon):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", invoice.ErrCodeCannotApplyCoupon, err.Error()))
+	case errors.Is(err, coupon.ErrInvalidRequest), errors.Is(err, invoice.ErrInvalidDiscount):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
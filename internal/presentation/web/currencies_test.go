@@ -0,0 +1,142 @@
+package web_test
+
+import (
+	"bytes"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAcceptedCurrencies(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(web.ErrorHandler(&config.Config{}, zap.NewNop()))
+
+	handler := web.CreateTestHandler()
+
+	router.POST("/api/v1/invoices", web.AuthMiddleware(handler.Logger), handler.CreateInvoice)
+	router.GET("/api/v1/currencies", web.AuthMiddleware(handler.Logger), handler.ListCurrencies)
+	router.GET("/api/v1/public/invoice/:id", handler.GetPublicInvoiceData)
+	router.POST("/api/v1/public/invoice/:id/currency", handler.ChangeInvoiceCurrency)
+
+	create := func(cryptoCurrency, network string) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(web.CreateInvoiceRequest{
+			Title:          "Token Invoice",
+			Items:          []web.InvoiceItemRequest{{Name: "Widget", Quantity: "1", UnitPrice: "10.00"}},
+			TaxRate:        "0.00",
+			CryptoCurrency: cryptoCurrency,
+			Network:        network,
+		})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/invoices", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("ListCurrencies_DefaultTokens", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/currencies", nil)
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response web.ListCurrenciesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Currencies, 3)
+		require.Equal(t, "USDT", response.Currencies[0].Symbol)
+		require.Equal(t, "tron", response.Currencies[0].Network)
+		require.Equal(t, int32(6), response.Currencies[0].Decimals)
+		require.True(t, response.Currencies[0].Default)
+	})
+
+	t.Run("CreateInvoice_OnDefaultNetwork", func(t *testing.T) {
+		w := create("BTC", "")
+
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var response web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, "bitcoin", response.Network)
+	})
+
+	t.Run("CreateInvoice_UnsupportedNetwork", func(t *testing.T) {
+		w := create("USDT", "bsc")
+
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), "UNSUPPORTED_CRYPTOCURRENCY")
+	})
+
+	t.Run("CreateInvoice_UnsupportedToken", func(t *testing.T) {
+		w := create("DAI", "")
+
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), "UNSUPPORTED_CRYPTOCURRENCY")
+	})
+
+	t.Run("CreateInvoice_UnknownSymbol", func(t *testing.T) {
+		// A well-formed symbol no token registry lists is not a cryptocurrency
+		w := create("WBTC", "")
+
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), "INVALID_CRYPTOCURRENCY")
+	})
+
+	t.Run("ChangeInvoiceCurrency_RequotesOnTheNewNetwork", func(t *testing.T) {
+		w := create("USDT", "")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var created web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/public/invoice/"+created.ID, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var before web.PublicInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &before))
+		require.Len(t, before.Currencies, 3)
+
+		changeCurrency := func(cryptoCurrency string) *httptest.ResponseRecorder {
+			body, err := json.Marshal(web.ChangeCurrencyRequest{CryptoCurrency: cryptoCurrency})
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/public/invoice/"+created.ID+"/currency",
+				bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		w = changeCurrency("ETH")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var after web.PublicInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &after))
+		require.Equal(t, "ETH", after.CryptoCurrency)
+		require.Equal(t, "ethereum", after.Network)
+
+		w = changeCurrency("DAI")
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), "UNSUPPORTED_CRYPTOCURRENCY")
+	})
+
+	t.Run("ChangeInvoiceCurrency_NotFound", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"crypto_currency":"BTC"}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/public/invoice/missing/currency", body)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
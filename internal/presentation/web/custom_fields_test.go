@@ -0,0 +1,123 @@
+package web_test
+
+import (
+	"bytes"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestInvoiceCustomFields(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	bundle, err := i18n.NewBundle()
+	require.NoError(t, err)
+	router := web.NewGinEngine(&config.Config{}, zap.NewNop(), bundle, nil)
+	handler := web.CreateTestHandler()
+	handler.RegisterRoutes(router)
+
+	request := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var requestBody []byte
+		if body != nil {
+			requestBody, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	create := func(fields []web.CustomFieldRequest) *httptest.ResponseRecorder {
+		return request(http.MethodPost, "/api/v1/invoices", web.CreateInvoiceRequest{
+			Title:        "Concert ticket",
+			Items:        []web.InvoiceItemRequest{{Name: "Ticket", Quantity: "1", UnitPrice: "50.00"}},
+			TaxRate:      "0.00",
+			CustomFields: fields,
+		})
+	}
+
+	t.Run("ShownByVisibility", func(t *testing.T) {
+		w := create([]web.CustomFieldRequest{
+			{Label: "Order number", Value: "A-1042"},
+			{Label: "Seat", Value: "F12", Visibility: "checkout"},
+			{Label: "Show date", Value: "2025-02-01", Type: "date", Visibility: "receipt"},
+			{Label: "Venue map", Value: "https://venue.example.com/map", Type: "url"},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var created web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		require.Len(t, created.CustomFields, 4)
+		assert.Equal(t, web.CustomFieldResponse{
+			Label: "Order number", Value: "A-1042", Type: "text", Visibility: "all",
+		}, created.CustomFields[0])
+
+		w = request(http.MethodGet, "/api/v1/public/invoice/"+created.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var public web.PublicInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &public))
+		labels := make([]string, len(public.CustomFields))
+		for i, field := range public.CustomFields {
+			labels[i] = field.Label
+		}
+		assert.Equal(t, []string{"Order number", "Seat", "Venue map"}, labels)
+
+		w = request(http.MethodGet, "/invoice/"+created.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		page := w.Body.String()
+		assert.Contains(t, page, "A-1042")
+		assert.Contains(t, page, `href="https://venue.example.com/map"`)
+		assert.NotContains(t, page, "Show date")
+	})
+
+	t.Run("ValidatedAtCreation", func(t *testing.T) {
+		for name, fields := range map[string][]web.CustomFieldRequest{
+			"not a number":    {{Label: "Seats", Value: "two", Type: "number"}},
+			"not a date":      {{Label: "Show date", Value: "01/02/2025", Type: "date"}},
+			"not a URL":       {{Label: "Map", Value: "javascript:alert(1)", Type: "url"}},
+			"duplicate label": {{Label: "Seat", Value: "F12"}, {Label: "seat", Value: "F13"}},
+			"unknown type":    {{Label: "Seat", Value: "F12", Type: "color"}},
+		} {
+			w := create(fields)
+			assert.Equal(t, http.StatusBadRequest, w.Code, name+": "+w.Body.String())
+		}
+	})
+
+	t.Run("ReplacedOnDrafts", func(t *testing.T) {
+		w := request(http.MethodPost, "/api/v1/invoices", web.CreateInvoiceRequest{
+			Title:        "Concert ticket",
+			Items:        []web.InvoiceItemRequest{{Name: "Ticket", Quantity: "1", UnitPrice: "50.00"}},
+			TaxRate:      "0.00",
+			CustomFields: []web.CustomFieldRequest{{Label: "Seat", Value: "F12"}},
+			Draft:        true,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var draft web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &draft))
+
+		title := "Concert ticket, row F"
+		w = request(http.MethodPatch, "/api/v1/invoices/"+draft.ID, web.InvoiceChangesRequest{Title: &title})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &draft))
+		require.Len(t, draft.CustomFields, 1, "omitted custom fields are kept")
+
+		w = request(http.MethodPatch, "/api/v1/invoices/"+draft.ID, json.RawMessage(`{"custom_fields": []}`))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		draft = web.CreateInvoiceResponse{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &draft))
+		assert.Empty(t, draft.CustomFields)
+	})
+}
@@ -0,0 +1,71 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/customer"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AuthTypeCustomer identifies requests authenticated with a customer session.
+const AuthTypeCustomer = "customer"
+
+// CustomerAuthMiddleware authenticates customers with their session tokens. It is the only middleware that
+// accepts customer sessions, and it accepts nothing else: merchant sessions and API keys cannot reach customer
+// routes, and customer sessions cannot reach merchant routes.
+type CustomerAuthMiddleware struct {
+	customerService customer.Service
+	logger          *zap.Logger
+}
+
+// NewCustomerAuthMiddleware creates a new customer authentication middleware.
+func NewCustomerAuthMiddleware(customerService customer.Service, logger *zap.Logger) *CustomerAuthMiddleware {
+	return &CustomerAuthMiddleware{
+		customerService: customerService,
+		logger:          logger,
+	}
+}
+
+// RequireCustomer accepts only customer session tokens, storing the customer ID in the context.
+func (m *CustomerAuthMiddleware) RequireCustomer() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := bearerToken(c)
+		if !strings.HasPrefix(token, customer.SessionTokenPrefix) {
+			respondInvalidCustomerSession(c)
+			return
+		}
+
+		account, err := m.customerService.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			if !errors.Is(err, customer.ErrInvalidSession) {
+				m.logger.Error("Failed to authenticate customer session", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to authenticate customer session"})
+				c.Abort()
+				return
+			}
+			respondInvalidCustomerSession(c)
+			return
+		}
+
+		c.Set("auth_type", AuthTypeCustomer)
+		c.Set("customer_id", account.ID())
+		c.Set("customer_email", account.Email())
+		c.Next()
+	}
+}
+
+// respondInvalidCustomerSession rejects a request without a valid customer session.
+func respondInvalidCustomerSession(c *gin.Context) {
+	c.JSON(
+		http.StatusUnauthorized,
+		createAuthErrorResponse(
+			"authentication_error",
+			customer.ErrCodeInvalidSession,
+			"A valid customer session token is required",
+		),
+	)
+	c.Abort()
+}
@@ -0,0 +1,66 @@
+package web_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/customer"
+	"crypto-checkout/internal/presentation/web"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubCustomerService authenticates a single customer session token.
+type stubCustomerService struct {
+	customer.Service
+
+	token         string
+	authenticated int
+}
+
+func (s *stubCustomerService) Authenticate(_ context.Context, token string) (*customer.Customer, error) {
+	s.authenticated++
+	if token != s.token {
+		return nil, customer.ErrInvalidSession
+	}
+	return customer.NewCustomer("customer-1", "alice@example.com")
+}
+
+func TestCustomerAuthMiddleware_AcceptsOnlyCustomerSessions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service := &stubCustomerService{token: "cst_valid"}
+	middleware := web.NewCustomerAuthMiddleware(service, zap.NewNop())
+
+	router := gin.New()
+	router.GET("/me", middleware.RequireCustomer(), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("customer_id"))
+	})
+
+	request := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("cst_valid")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "customer-1", w.Body.String())
+
+	assert.Equal(t, http.StatusUnauthorized, request("cst_revoked").Code)
+	assert.Equal(t, 2, service.authenticated)
+
+	for _, token := range []string{"", "sk_live_abc123", "sk_test_abc123", "eyJhbGciOiJIUzI1NiJ9.e30.sig"} {
+		w := request(token)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, token)
+		assert.Contains(t, w.Body.String(), customer.ErrCodeInvalidSession)
+	}
+	assert.Equal(t, 2, service.authenticated, "merchant credentials never reach the customer service")
+}
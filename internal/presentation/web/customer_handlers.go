@@ -0,0 +1,528 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/customer"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// customerReceiptTemplate is the HTML template of downloaded receipts.
+	customerReceiptTemplate = "customer_receipt.html"
+	// receiptTimeFormat formats the times shown on receipts.
+	receiptTimeFormat = "2 Jan 2006 15:04 MST"
+)
+
+// CustomerHandlers handles the customer account area: magic link sign-in, payment history, saved refund
+// addresses and receipts.
+type CustomerHandlers struct {
+	customerService customer.Service
+	customerAuth    *CustomerAuthMiddleware
+	policy          customer.Policy
+	logger          *zap.Logger
+}
+
+// NewCustomerHandlers creates a new customer handlers instance.
+func NewCustomerHandlers(
+	customerService customer.Service,
+	customerAuth *CustomerAuthMiddleware,
+	policy customer.Policy,
+	logger *zap.Logger,
+) *CustomerHandlers {
+	return &CustomerHandlers{
+		customerService: customerService,
+		customerAuth:    customerAuth,
+		policy:          policy,
+		logger:          logger,
+	}
+}
+
+// RequestLoginLink handles POST /customer/auth/link
+// @Summary Email a customer login link
+// @Description Send a single-use sign-in link to an email address. The link only starts a session together with
+// @Description the PKCE code verifier of the browser that asked for it. The response is the same whether or not
+// @Description the address has an account; the account is created on its first sign-in.
+// @Tags Customer Accounts
+// @Accept json
+// @Produce json
+// @Param request body RequestCustomerLoginLinkRequest true "Email and S256 code challenge"
+// @Success 202 {object} map[string]string "Login link sent"
+// @Failure 400 {object} ErrorResponse "Invalid email or code challenge"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customer/auth/link [post]
+func (h *CustomerHandlers) RequestLoginLink(c *gin.Context) {
+	var req RequestCustomerLoginLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	err := h.customerService.RequestLoginLink(c.Request.Context(), &customer.RequestLoginLinkRequest{
+		Email:               req.Email,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+	})
+	if err != nil {
+		respondCustomerError(c, h.logger, err, "Failed to send login link")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"status": "sent"})
+}
+
+// RedeemLoginLink handles POST /customer/auth/token
+// @Summary Sign in with a customer login link
+// @Description Exchange the token of a login link and the code verifier of its code challenge for a customer
+// @Description session token. A link is spent by its first redemption, including one with the wrong verifier.
+// @Tags Customer Accounts
+// @Accept json
+// @Produce json
+// @Param request body RedeemCustomerLoginLinkRequest true "Link token and code verifier"
+// @Success 200 {object} CustomerSessionResponse
+// @Failure 400 {object} ErrorResponse "Invalid request format"
+// @Failure 401 {object} ErrorResponse "Invalid, expired or used login link"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customer/auth/token [post]
+func (h *CustomerHandlers) RedeemLoginLink(c *gin.Context) {
+	var req RedeemCustomerLoginLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	session, err := h.customerService.RedeemLoginLink(c.Request.Context(), &customer.RedeemLoginLinkRequest{
+		Token:        req.Token,
+		CodeVerifier: req.CodeVerifier,
+	})
+	if err != nil {
+		respondCustomerError(c, h.logger, err, "Failed to sign in")
+		return
+	}
+
+	c.JSON(http.StatusOK, CustomerSessionResponse{
+		AccessToken: session.Token,
+		TokenType:   "Bearer",
+		ExpiresAt:   session.ExpiresAt,
+		Customer:    ToCustomerResponse(session.Customer),
+	})
+}
+
+// Logout handles POST /customer/auth/logout
+// @Summary Sign out of a customer account
+// @Tags Customer Accounts
+// @Security CustomerAuth
+// @Success 204 "Signed out"
+// @Failure 401 {object} ErrorResponse "Invalid customer session"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customer/auth/logout [post]
+func (h *CustomerHandlers) Logout(c *gin.Context) {
+	if err := h.customerService.Logout(c.Request.Context(), bearerToken(c)); err != nil {
+		respondCustomerError(c, h.logger, err, "Failed to sign out")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetAccount handles GET /customer/me
+// @Summary Get the signed-in customer
+// @Tags Customer Accounts
+// @Produce json
+// @Security CustomerAuth
+// @Success 200 {object} CustomerResponse
+// @Failure 401 {object} ErrorResponse "Invalid customer session"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customer/me [get]
+func (h *CustomerHandlers) GetAccount(c *gin.Context) {
+	account, err := h.customerService.GetCustomer(c.Request.Context(), c.GetString("customer_id"))
+	if err != nil {
+		respondCustomerError(c, h.logger, err, "Failed to get customer account")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToCustomerResponse(account))
+}
+
+// ListPayments handles GET /customer/payments
+// @Summary List the customer's payment history
+// @Description List the invoices the customer added to their account, across the merchants of this deployment,
+// @Description most recently added first.
+// @Tags Customer Accounts
+// @Produce json
+// @Security CustomerAuth
+// @Param limit query int false "Page size, at most 100" default(20)
+// @Param offset query int false "Invoices to skip" default(0)
+// @Success 200 {object} ListCustomerPaymentsResponse
+// @Failure 401 {object} ErrorResponse "Invalid customer session"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customer/payments [get]
+func (h *CustomerHandlers) ListPayments(c *gin.Context) {
+	limit, offset := 20, 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if parsed, err := strconv.Atoi(offsetStr); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	invoices, err := h.customerService.ListPayments(c.Request.Context(), c.GetString("customer_id"), limit, offset)
+	if err != nil {
+		respondCustomerError(c, h.logger, err, "Failed to list payments")
+		return
+	}
+
+	response := ListCustomerPaymentsResponse{
+		Payments: make([]CustomerPaymentResponse, len(invoices)),
+		Limit:    limit,
+		Offset:   offset,
+	}
+	for i, inv := range invoices {
+		response.Payments[i] = ToCustomerPaymentResponse(inv)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// ClaimPayment handles POST /customer/payments
+// @Summary Add an invoice to the payment history
+// @Description Add an invoice the customer opened on a checkout page to their account. An invoice belongs to a
+// @Description single customer account.
+// @Tags Customer Accounts
+// @Accept json
+// @Produce json
+// @Security CustomerAuth
+// @Param request body ClaimCustomerPaymentRequest true "Invoice"
+// @Success 200 {object} CustomerPaymentResponse
+// @Failure 400 {object} ErrorResponse "Invalid request format"
+// @Failure 401 {object} ErrorResponse "Invalid customer session"
+// @Failure 404 {object} ErrorResponse "Invoice not found"
+// @Failure 409 {object} ErrorResponse "Invoice belongs to another customer account"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customer/payments [post]
+func (h *CustomerHandlers) ClaimPayment(c *gin.Context) {
+	var req ClaimCustomerPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	inv, err := h.customerService.ClaimInvoice(c.Request.Context(), c.GetString("customer_id"), req.InvoiceID)
+	if err != nil {
+		respondCustomerError(c, h.logger, err, "Failed to add payment")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToCustomerPaymentResponse(inv))
+}
+
+// DownloadReceipt handles GET /customer/payments/:id/receipt
+// @Summary Download a receipt
+// @Description Download the HTML receipt of a paid invoice in the customer's payment history.
+// @Tags Customer Accounts
+// @Produce html
+// @Security CustomerAuth
+// @Param id path string true "Invoice ID"
+// @Success 200 {string} string "Receipt"
+// @Failure 401 {object} ErrorResponse "Invalid customer session"
+// @Failure 404 {object} ErrorResponse "Invoice not in the payment history"
+// @Failure 409 {object} ErrorResponse "Invoice not paid"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customer/payments/{id}/receipt [get]
+func (h *CustomerHandlers) DownloadReceipt(c *gin.Context) {
+	receipt, err := h.customerService.GetReceipt(c.Request.Context(), c.GetString("customer_id"), c.Param("id"))
+	if err != nil {
+		respondCustomerError(c, h.logger, err, "Failed to get receipt")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="receipt-%s.html"`, receipt.Invoice.ID()))
+	c.HTML(http.StatusOK, customerReceiptTemplate, toReceiptPage(receipt, c.GetString("customer_email")))
+}
+
+// UseRefundAddress handles POST /customer/payments/:id/refund-address
+// @Summary Use a saved refund address
+// @Description Supply a saved refund address as the refund address of an invoice in the payment history. As with
+// @Description an address typed on the checkout page, the merchant confirms it before any refund is sent.
+// @Tags Customer Accounts
+// @Accept json
+// @Produce json
+// @Security CustomerAuth
+// @Param id path string true "Invoice ID"
+// @Param request body UseCustomerRefundAddressRequest true "Saved refund address"
+// @Success 200 {object} PublicRefundAddressResponse "Refund address recorded"
+// @Failure 400 {object} ErrorResponse "Invalid request format or address"
+// @Failure 401 {object} ErrorResponse "Invalid customer session"
+// @Failure 404 {object} ErrorResponse "Invoice or saved refund address not found"
+// @Failure 409 {object} ErrorResponse "Refund destination already confirmed"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customer/payments/{id}/refund-address [post]
+func (h *CustomerHandlers) UseRefundAddress(c *gin.Context) {
+	var req UseCustomerRefundAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	inv, err := h.customerService.UseRefundAddress(
+		c.Request.Context(), c.GetString("customer_id"), req.RefundAddressID, c.Param("id"))
+	if err != nil {
+		respondCustomerError(c, h.logger, err, "Failed to record refund address")
+		return
+	}
+
+	c.JSON(http.StatusOK, PublicRefundAddressResponse{
+		InvoiceID: inv.ID(),
+		Address:   inv.RefundDestination().Address(),
+		Verified:  inv.RefundDestination().Verified(),
+	})
+}
+
+// ListRefundAddresses handles GET /customer/refund-addresses
+// @Summary List saved refund addresses
+// @Tags Customer Accounts
+// @Produce json
+// @Security CustomerAuth
+// @Success 200 {object} ListCustomerRefundAddressesResponse
+// @Failure 401 {object} ErrorResponse "Invalid customer session"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customer/refund-addresses [get]
+func (h *CustomerHandlers) ListRefundAddresses(c *gin.Context) {
+	addresses, err := h.customerService.ListRefundAddresses(c.Request.Context(), c.GetString("customer_id"))
+	if err != nil {
+		respondCustomerError(c, h.logger, err, "Failed to list refund addresses")
+		return
+	}
+
+	response := ListCustomerRefundAddressesResponse{
+		RefundAddresses: make([]CustomerRefundAddressResponse, len(addresses)),
+	}
+	for i, address := range addresses {
+		response.RefundAddresses[i] = ToCustomerRefundAddressResponse(address)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// SaveRefundAddress handles POST /customer/refund-addresses
+// @Summary Save a refund address
+// @Tags Customer Accounts
+// @Accept json
+// @Produce json
+// @Security CustomerAuth
+// @Param request body SaveCustomerRefundAddressRequest true "Refund address"
+// @Success 201 {object} CustomerRefundAddressResponse
+// @Failure 400 {object} ErrorResponse "Invalid request format"
+// @Failure 401 {object} ErrorResponse "Invalid customer session"
+// @Failure 409 {object} ErrorResponse "Address already saved or too many saved addresses"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customer/refund-addresses [post]
+func (h *CustomerHandlers) SaveRefundAddress(c *gin.Context) {
+	var req SaveCustomerRefundAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	address, err := h.customerService.SaveRefundAddress(c.Request.Context(), &customer.SaveRefundAddressRequest{
+		CustomerID: c.GetString("customer_id"),
+		Address:    req.Address,
+		Label:      req.Label,
+	})
+	if err != nil {
+		respondCustomerError(c, h.logger, err, "Failed to save refund address")
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToCustomerRefundAddressResponse(address))
+}
+
+// DeleteRefundAddress handles DELETE /customer/refund-addresses/:id
+// @Summary Delete a saved refund address
+// @Tags Customer Accounts
+// @Security CustomerAuth
+// @Param id path string true "Saved refund address ID"
+// @Success 204 "Deleted"
+// @Failure 401 {object} ErrorResponse "Invalid customer session"
+// @Failure 404 {object} ErrorResponse "Saved refund address not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customer/refund-addresses/{id} [delete]
+func (h *CustomerHandlers) DeleteRefundAddress(c *gin.Context) {
+	err := h.customerService.DeleteRefundAddress(c.Request.Context(), c.GetString("customer_id"), c.Param("id"))
+	if err != nil {
+		respondCustomerError(c, h.logger, err, "Failed to delete refund address")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// receiptPage is the data of the receipt template.
+type receiptPage struct {
+	Reference      string
+	MerchantName   string
+	Title          string
+	Status         string
+	Refunded       bool
+	Fields         []displayField
+	Items          []receiptItem
+	Subtotal       string
+	Discount       string
+	Tax            string
+	Fee            string // Platform fee the customer paid on top of the price
+	Total          string
+	Currency       string
+	CryptoAmount   string
+	CryptoCurrency string
+	Network        string
+	PaidAt         string
+	Payments       []receiptPayment
+	IssuedAt       string
+	CustomerEmail  string
+}
+
+// receiptItem is a line item on a receipt.
+type receiptItem struct {
+	Description string
+	Quantity    string
+	UnitPrice   string
+	Total       string
+}
+
+// receiptPayment is a confirmed transaction on a receipt.
+type receiptPayment struct {
+	TransactionHash string
+	Amount          string
+	ConfirmedAt     string
+}
+
+// toReceiptPage converts a receipt to the data of the receipt template.
+func toReceiptPage(receipt *customer.Receipt, email string) receiptPage {
+	inv := receipt.Invoice
+	pricing := inv.Pricing()
+	page := receiptPage{
+		Reference:      inv.ID(),
+		MerchantName:   receipt.MerchantName,
+		Title:          inv.Title(),
+		Status:         inv.Status().String(),
+		Refunded:       inv.Status() == invoice.StatusRefunded || inv.Status() == invoice.StatusPartiallyRefunded,
+		Fields:         displayFields(inv.ReceiptFields()),
+		Items:          make([]receiptItem, len(inv.Items())),
+		Subtotal:       pricing.Subtotal().String(),
+		Total:          pricing.Total().String(),
+		Currency:       pricing.Total().Currency(),
+		CryptoAmount:   toCryptoAmount(inv),
+		CryptoCurrency: inv.CryptoCurrency().String(),
+		Network:        toNetwork(inv),
+		PaidAt:         formatReceiptTime(inv.PaidAt()),
+		Payments:       make([]receiptPayment, len(receipt.Payments)),
+		IssuedAt:       shared.Now().UTC().Format(receiptTimeFormat),
+		CustomerEmail:  email,
+	}
+	if inv.Number() != "" {
+		page.Reference = inv.Number()
+	}
+	if discount := pricing.Discount(); discount != nil && !discount.IsZero() {
+		page.Discount = discount.String()
+	}
+	if tax := pricing.Tax(); tax != nil && !tax.IsZero() {
+		page.Tax = tax.String()
+	}
+	if inv.FeePassThrough() != nil {
+		page.Fee = pricing.Fee().String()
+	}
+	for i, item := range inv.Items() {
+		page.Items[i] = receiptItem{
+			Description: item.Description(),
+			Quantity:    item.Quantity().String(),
+			UnitPrice:   item.UnitPrice().String(),
+			Total:       item.TotalPrice().String(),
+		}
+	}
+	for i, p := range receipt.Payments {
+		page.Payments[i] = receiptPayment{
+			TransactionHash: p.TransactionHash().String(),
+			Amount:          p.Amount().String(),
+			ConfirmedAt:     formatReceiptTime(p.ConfirmedAt()),
+		}
+	}
+	return page
+}
+
+// formatReceiptTime formats a time shown on a receipt.
+func formatReceiptTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(receiptTimeFormat)
+}
+
+// respondCustomerError maps customer account errors to HTTP responses.
+func respondCustomerError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, customer.ErrInvalidSession), errors.Is(err, customer.ErrCustomerNotFound):
+		respondInvalidCustomerSession(c)
+	case errors.Is(err, customer.ErrInvalidLoginLink):
+		c.JSON(http.StatusUnauthorized, createAuthErrorResponse(
+			"authentication_error", customer.ErrCodeInvalidLoginLink, err.Error()))
+	case errors.Is(err, customer.ErrInvalidCodeChallenge):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", customer.ErrCodeInvalidCodeChallenge, err.Error()))
+	case errors.Is(err, customer.ErrInvalidEmail), errors.Is(err, customer.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	case errors.Is(err, customer.ErrInvoiceNotClaimed):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", customer.ErrCodeInvoiceNotClaimed, err.Error()))
+	case errors.Is(err, customer.ErrRefundAddressNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", customer.ErrCodeRefundAddressNotFound, err.Error()))
+	case errors.Is(err, customer.ErrInvoiceClaimed):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", customer.ErrCodeInvoiceClaimed, err.Error()))
+	case errors.Is(err, customer.ErrReceiptUnavailable):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", customer.ErrCodeReceiptUnavailable, err.Error()))
+	case errors.Is(err, customer.ErrRefundAddressExists):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", customer.ErrCodeRefundAddressExists, err.Error()))
+	case errors.Is(err, customer.ErrTooManyRefundAddresses):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", customer.ErrCodeTooManyRefundAddresses, err.Error()))
+	default:
+		respondRefundDestinationError(c, logger, err, message)
+	}
+}
+
+// RegisterCustomerRoutes registers the customer account routes, when customer accounts are enabled. They sit
+// outside the merchant-authenticated group and only accept customer sessions.
+func (h *CustomerHandlers) RegisterCustomerRoutes(v1 *gin.RouterGroup) {
+	if !h.policy.Enabled {
+		return
+	}
+
+	customerGroup := v1.Group("/customer")
+	auth := customerGroup.Group("/auth")
+	auth.POST("/link", h.RequestLoginLink)
+	auth.POST("/token", h.RedeemLoginLink)
+
+	account := customerGroup.Group("")
+	account.Use(h.customerAuth.RequireCustomer())
+	account.POST("/auth/logout", h.Logout)
+	account.GET("/me", h.GetAccount)
+	account.GET("/payments", h.ListPayments)
+	account.POST("/payments", h.ClaimPayment)
+	account.GET("/payments/:id/receipt", h.DownloadReceipt)
+	account.POST("/payments/:id/refund-address", h.UseRefundAddress)
+	account.GET("/refund-addresses", h.ListRefundAddresses)
+	account.POST("/refund-addresses", h.SaveRefundAddress)
+	account.DELETE("/refund-addresses/:id", h.DeleteRefundAddress)
+}
@@ -0,0 +1,280 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/deadletter"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// DeadLetterHandlers handles the dead letters of failed in-process event handlers.
+type DeadLetterHandlers struct {
+	deadLetterService deadletter.Service
+	logger            *zap.Logger
+}
+
+// NewDeadLetterHandlers creates a new dead letter handlers instance.
+func NewDeadLetterHandlers(deadLetterService deadletter.Service, logger *zap.Logger) *DeadLetterHandlers {
+	return &DeadLetterHandlers{
+		deadLetterService: deadLetterService,
+		logger:            logger,
+	}
+}
+
+// ListDeadLetters handles GET /admin/dead-letters
+// @Summary List dead letters
+// @Description List events an in-process handler (settlement, sweeps, statistics, caches) failed on, newest first
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param handler query string false "Filter by handler type, e.g. *settlement.PaidInvoiceHandler"
+// @Param event_type query string false "Filter by event type"
+// @Param status query string false "Filter by status" Enums(pending, replayed)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} ListDeadLettersResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/dead-letters [get]
+func (h *DeadLetterHandlers) ListDeadLetters(c *gin.Context) {
+	var req ListDeadLettersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	var cursor *shared.Cursor
+	if req.Cursor != "" {
+		decoded, err := shared.DecodeCursor(req.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+			return
+		}
+		cursor = decoded
+	}
+
+	resp, err := h.deadLetterService.ListDeadLetters(c.Request.Context(), &deadletter.ListDeadLettersRequest{
+		Handler:   req.Handler,
+		EventType: req.EventType,
+		Status:    deadletter.Status(req.Status),
+		Limit:     req.Limit,
+		Cursor:    cursor,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to list dead letters")
+		return
+	}
+
+	response := ListDeadLettersResponse{
+		DeadLetters: make([]DeadLetterResponse, len(resp.DeadLetters)),
+		Limit:       resp.Limit,
+	}
+	for i, d := range resp.DeadLetters {
+		response.DeadLetters[i] = ToDeadLetterResponse(d)
+	}
+	if resp.NextCursor != nil {
+		response.NextCursor = resp.NextCursor.Encode()
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetDeadLetter handles GET /admin/dead-letters/:id
+// @Summary Get a dead letter
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Dead letter ID"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} DeadLetterResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 404 {object} ErrorResponse "Dead letter not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/dead-letters/{id} [get]
+func (h *DeadLetterHandlers) GetDeadLetter(c *gin.Context) {
+	d, err := h.deadLetterService.GetDeadLetter(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to get dead letter")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToDeadLetterResponse(d))
+}
+
+// ReplayDeadLetter handles POST /admin/dead-letters/:id/replay
+// @Summary Replay a dead letter
+// @Description Hand the event back to the handler that failed on it. A failed replay leaves the dead letter pending
+// @Description with the new error recorded.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Dead letter ID"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} DeadLetterResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 404 {object} ErrorResponse "Dead letter not found"
+// @Failure 409 {object} ErrorResponse "Dead letter already replayed, handler no longer registered or failed again"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/dead-letters/{id}/replay [post]
+func (h *DeadLetterHandlers) ReplayDeadLetter(c *gin.Context) {
+	d, err := h.deadLetterService.ReplayDeadLetter(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to replay dead letter")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToDeadLetterResponse(d))
+}
+
+// ReplayDeadLetters handles POST /admin/dead-letters/replay
+// @Summary Replay pending dead letters
+// @Description Replay the pending dead letters matching the filter, oldest first. Dead letters the handler fails on
+// @Description again stay pending and are returned.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ReplayDeadLettersRequest false "Optional handler and event type filter"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} ReplayDeadLettersResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/dead-letters/replay [post]
+func (h *DeadLetterHandlers) ReplayDeadLetters(c *gin.Context) {
+	var req ReplayDeadLettersRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+			return
+		}
+	}
+
+	resp, err := h.deadLetterService.ReplayDeadLetters(c.Request.Context(), &deadletter.ReplayDeadLettersRequest{
+		Handler:   req.Handler,
+		EventType: req.EventType,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to replay dead letters")
+		return
+	}
+
+	response := ReplayDeadLettersResponse{
+		Replayed: len(resp.Replayed),
+		Failed:   make([]DeadLetterResponse, len(resp.Failed)),
+	}
+	for i, d := range resp.Failed {
+		response.Failed[i] = ToDeadLetterResponse(d)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// PurgeDeadLetters handles DELETE /admin/dead-letters
+// @Summary Purge dead letters
+// @Description Delete the dead letters matching the filter; without a filter every dead letter is deleted
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param handler query string false "Filter by handler type"
+// @Param event_type query string false "Filter by event type"
+// @Param status query string false "Filter by status" Enums(pending, replayed)
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} PurgeDeadLettersResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/dead-letters [delete]
+func (h *DeadLetterHandlers) PurgeDeadLetters(c *gin.Context) {
+	var req PurgeDeadLettersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	purged, err := h.deadLetterService.PurgeDeadLetters(c.Request.Context(), &deadletter.PurgeDeadLettersRequest{
+		Handler:   req.Handler,
+		EventType: req.EventType,
+		Status:    deadletter.Status(req.Status),
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to purge dead letters")
+		return
+	}
+
+	c.JSON(http.StatusOK, PurgeDeadLettersResponse{Purged: purged})
+}
+
+// PurgeDeadLetter handles DELETE /admin/dead-letters/:id
+// @Summary Purge a dead letter
+// @Tags Admin
+// @Security BearerAuth
+// @Param id path string true "Dead letter ID"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 204 "Dead letter purged"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 404 {object} ErrorResponse "Dead letter not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/dead-letters/{id} [delete]
+func (h *DeadLetterHandlers) PurgeDeadLetter(c *gin.Context) {
+	purged, err := h.deadLetterService.PurgeDeadLetters(c.Request.Context(), &deadletter.PurgeDeadLettersRequest{
+		ID: c.Param("id"),
+	})
+	if err == nil && purged == 0 {
+		err = deadletter.ErrDeadLetterNotFound
+	}
+	if err != nil {
+		h.respondError(c, err, "Failed to purge dead letter")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondError maps dead letter domain errors to HTTP responses.
+func (h *DeadLetterHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, deadletter.ErrDeadLetterNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", deadletter.ErrCodeDeadLetterNotFound, "Dead letter not found"))
+	case errors.Is(err, deadletter.ErrAlreadyReplayed):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", deadletter.ErrCodeAlreadyReplayed, err.Error()))
+	case errors.Is(err, deadletter.ErrHandlerNotRegistered):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", deadletter.ErrCodeHandlerNotRegistered, err.Error()))
+	case errors.Is(err, deadletter.ErrReplayFailed):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", deadletter.ErrCodeReplayFailed, err.Error()))
+	case errors.Is(err, deadletter.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterDeadLetterRoutes registers the dead letter routes under /admin/dead-letters.
+func (h *DeadLetterHandlers) RegisterDeadLetterRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionAdminOperations)
+
+	deadLetters := protected.Group("/admin/dead-letters", require)
+	deadLetters.GET("", h.ListDeadLetters)
+	deadLetters.DELETE("", rbac.AuditAction("admin.purge_dead_letters"), h.PurgeDeadLetters)
+	deadLetters.POST("/replay", rbac.AuditAction("admin.replay_dead_letters"), h.ReplayDeadLetters)
+	deadLetters.GET("/:id", h.GetDeadLetter)
+	deadLetters.DELETE("/:id", rbac.AuditAction("admin.purge_dead_letter"), h.PurgeDeadLetter)
+	deadLetters.POST("/:id/replay", rbac.AuditAction("admin.replay_dead_letter"), h.ReplayDeadLetter)
+}
@@ -0,0 +1,252 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/deposit"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// DepositHandlers handles the funds that arrived at the payment addresses of closed invoices.
+type DepositHandlers struct {
+	depositService deposit.Service
+	explorers      *shared.ExplorerResolver
+	logger         *zap.Logger
+}
+
+// NewDepositHandlers creates a new deposit handlers instance.
+func NewDepositHandlers(
+	depositService deposit.Service,
+	explorers *shared.ExplorerResolver,
+	logger *zap.Logger,
+) *DepositHandlers {
+	return &DepositHandlers{
+		depositService: depositService,
+		explorers:      explorers,
+		logger:         logger,
+	}
+}
+
+// ListDeposits handles GET /deposits
+// @Summary List unattributed deposits
+// @Description List funds that arrived at the payment address of an expired, cancelled or settled invoice
+// @Tags Deposits
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "Filter by status" Enums(unmatched, attached, refunded)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Number of items to skip" default(0) minimum(0)
+// @Success 200 {object} ListDepositsResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/deposits [get]
+func (h *DepositHandlers) ListDeposits(c *gin.Context) {
+	var req ListDepositsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Failed to bind list deposits request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.depositService.ListDeposits(c.Request.Context(), &deposit.ListDepositsRequest{
+		MerchantID: merchantID,
+		Status:     deposit.Status(req.Status),
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to list deposits")
+		return
+	}
+
+	deposits := make([]DepositResponse, len(resp.Deposits))
+	for i, d := range resp.Deposits {
+		deposits[i] = ToDepositResponse(d, nil, h.explorers)
+	}
+
+	c.JSON(http.StatusOK, ListDepositsResponse{
+		Deposits: deposits,
+		Total:    resp.Total,
+		Limit:    resp.Limit,
+		Offset:   resp.Offset,
+	})
+}
+
+// GetDeposit handles GET /deposits/:id
+// @Summary Get an unattributed deposit
+// @Description Get a deposit with the open invoices it may belong to, strongest match first: the invoice that
+// @Description replaced the address owner, invoices named by the transfer memo, then invoices whose amount due
+// @Description equals the deposit.
+// @Tags Deposits
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Deposit ID"
+// @Success 200 {object} DepositResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Deposit not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/deposits/{id} [get]
+func (h *DepositHandlers) GetDeposit(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	req := &deposit.GetDepositRequest{MerchantID: merchantID, DepositID: c.Param("id")}
+	d, err := h.depositService.GetDeposit(c.Request.Context(), req)
+	if err != nil {
+		h.respondError(c, err, "Failed to get deposit")
+		return
+	}
+	matches, err := h.depositService.SuggestMatches(c.Request.Context(), req)
+	if err != nil {
+		h.respondError(c, err, "Failed to match deposit")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToDepositResponse(d, matches, h.explorers))
+}
+
+// AttachDeposit handles POST /deposits/:id/attach
+// @Summary Attach a deposit to an invoice
+// @Description Credit an unmatched deposit to an open invoice in the same cryptocurrency as a payment
+// @Tags Deposits
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Deposit ID"
+// @Param request body AttachDepositRequest true "Invoice to credit"
+// @Success 200 {object} DepositResponse
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Deposit or invoice not found"
+// @Failure 409 {object} ErrorResponse "Deposit already resolved or invoice not open"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/deposits/{id}/attach [post]
+func (h *DepositHandlers) AttachDeposit(c *gin.Context) {
+	var req AttachDepositRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind attach deposit request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	d, err := h.depositService.AttachDeposit(c.Request.Context(), &deposit.AttachDepositRequest{
+		MerchantID: merchantID,
+		DepositID:  c.Param("id"),
+		InvoiceID:  req.InvoiceID,
+		ResolvedBy: h.resolvedBy(c),
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to attach deposit")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToDepositResponse(d, nil, h.explorers))
+}
+
+// RefundDeposit handles POST /deposits/:id/refund
+// @Summary Refund a deposit to the sender
+// @Description Request that an unmatched deposit is returned to the address it was sent from
+// @Tags Deposits
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Deposit ID"
+// @Param request body RefundDepositRequest false "Refund note"
+// @Success 200 {object} DepositResponse
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Deposit not found"
+// @Failure 409 {object} ErrorResponse "Deposit already resolved"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/deposits/{id}/refund [post]
+func (h *DepositHandlers) RefundDeposit(c *gin.Context) {
+	var req RefundDepositRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Error("Failed to bind refund deposit request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+			return
+		}
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	d, err := h.depositService.RefundDeposit(c.Request.Context(), &deposit.RefundDepositRequest{
+		MerchantID: merchantID,
+		DepositID:  c.Param("id"),
+		ResolvedBy: h.resolvedBy(c),
+		Note:       req.Note,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to refund deposit")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToDepositResponse(d, nil, h.explorers))
+}
+
+// resolvedBy returns the user or API key resolving a deposit.
+func (h *DepositHandlers) resolvedBy(c *gin.Context) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return userID
+	}
+	return c.GetString("api_key_id")
+}
+
+// respondError maps deposit domain errors to HTTP responses.
+func (h *DepositHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, deposit.ErrDepositNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", deposit.ErrCodeDepositNotFound, "Deposit not found"))
+	case errors.Is(err, deposit.ErrInvoiceNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", deposit.ErrCodeInvoiceNotFound, "Invoice not found"))
+	case errors.Is(err, deposit.ErrDepositResolved):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", deposit.ErrCodeDepositResolved, "Deposit is already attached or refunded"))
+	case errors.Is(err, deposit.ErrAttachNotAllowed):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", deposit.ErrCodeAttachNotAllowed, err.Error()))
+	case errors.Is(err, deposit.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterDepositRoutes registers the unattributed deposit routes.
+func (h *DepositHandlers) RegisterDepositRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionReviewsManage)
+
+	deposits := protected.Group("/deposits", require)
+	deposits.GET("", h.ListDeposits)
+	deposits.GET("/:id", h.GetDeposit)
+	deposits.POST("/:id/attach", h.AttachDeposit)
+	deposits.POST("/:id/refund", h.RefundDeposit)
+}
@@ -0,0 +1,190 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDepositEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(web.ErrorHandler(&config.Config{}, zap.NewNop()))
+
+	const secret = "watcher-secret"
+	cfg := config.NewConfig()
+	cfg.Blockchain.NotificationSources = []config.NotificationSourceConfig{
+		{Name: "merchant-node", Secret: secret, MerchantID: "test-merchant"},
+	}
+
+	handler, services := web.CreateTestHandlerWithServices()
+	notifications := web.NewBlockchainNotificationHandlers(
+		detection.NewService(services.Invoices, services.Payments, nil, nil, services.Deposits, zap.NewNop()),
+		cfg, zap.NewNop())
+	router.POST("/api/v1/invoices", handler.CreateInvoice)
+	notifications.RegisterBlockchainNotificationRoutes(router.Group("/api/v1"))
+	protected := router.Group("/api/v1", func(c *gin.Context) {
+		c.Set("merchant_id", "test-merchant")
+		c.Set("user_id", "user-1")
+		c.Next()
+	})
+	web.NewDepositHandlers(services.Deposits, shared.DefaultExplorerResolver(), zap.NewNop()).
+		RegisterDepositRoutes(protected, nil)
+
+	createInvoice := func() web.CreateInvoiceResponse {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/invoices", bytes.NewBufferString(
+			`{"title":"Order","items":[{"name":"Item","quantity":"1","unit_price":"10.00"}],"tax_rate":"0.00"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var inv web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &inv))
+		require.NoError(t, services.Invoices.MarkInvoiceAsViewed(context.Background(), inv.ID))
+		return inv
+	}
+
+	notify := func(txHash, toAddress, amount, memo string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(web.BlockchainNotificationRequest{
+			Network:         "tron",
+			TransactionHash: txHash,
+			FromAddress:     "TSenderAddress123456789012345678901234567890",
+			ToAddress:       toAddress,
+			Amount:          amount,
+			Currency:        "USDT",
+			Memo:            memo,
+		})
+		require.NoError(t, err)
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/blockchain/notifications", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(web.NotificationSourceHeader, "merchant-node")
+		req.Header.Set(web.NotificationTimestampHeader, timestamp)
+		req.Header.Set(web.NotificationSignatureHeader, web.SignNotification(secret, timestamp, body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	call := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Test invoices share one payment address, so the open invoice is only created once the transfers to the
+	// closed invoice are recorded
+	closed := createInvoice()
+	require.NoError(t, services.Invoices.CancelInvoice(context.Background(), closed.ID, "customer changed order"))
+
+	var depositID, strayID string
+	t.Run("Notification_RecordsDepositForClosedInvoice", func(t *testing.T) {
+		txHash := "0x7777777777777777777777777777777777777777777777777777777777777777"
+		w := notify(txHash, closed.Address, closed.USDTAmount, "order "+closed.ID)
+		require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+
+		var response web.BlockchainNotificationResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.True(t, response.Unattributed)
+		assert.Equal(t, closed.ID, response.InvoiceID)
+		assert.Equal(t, "unmatched", response.Status)
+		assert.Empty(t, response.PaymentID)
+		require.NotEmpty(t, response.DepositID)
+		depositID = response.DepositID
+
+		// Watchers retry, so the same transfer is recorded once
+		w = notify(txHash, closed.Address, closed.USDTAmount, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.True(t, response.Duplicate)
+		assert.Equal(t, depositID, response.DepositID)
+
+		w = notify("0x8888888888888888888888888888888888888888888888888888888888888888", closed.Address, "1.5", "")
+		require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		strayID = response.DepositID
+	})
+
+	open := createInvoice()
+
+	t.Run("Deposits_ListsAndSuggestsMatches", func(t *testing.T) {
+		w := call(http.MethodGet, "/api/v1/deposits?status=unmatched", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list web.ListDepositsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Equal(t, 2, list.Total)
+		assert.Equal(t, "invoice_cancelled", list.Deposits[0].Reason)
+		assert.Equal(t, "order "+closed.ID, list.Deposits[0].Memo)
+
+		w = call(http.MethodGet, "/api/v1/deposits/"+depositID, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var deposit web.DepositResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &deposit))
+		assert.Equal(t, "https://tronscan.org/#/transaction/"+deposit.TransactionHash, deposit.ExplorerURL)
+		// The memo names the closed invoice, which is not suggested; the open invoice is due the deposit amount
+		require.Len(t, deposit.Matches, 1)
+		assert.Equal(t, open.ID, deposit.Matches[0].InvoiceID)
+		assert.Equal(t, "amount", deposit.Matches[0].Heuristic)
+		assert.True(t, decimal.RequireFromString(open.USDTAmount).Equal(
+			decimal.RequireFromString(deposit.Matches[0].AmountDue)))
+
+		require.Equal(t, http.StatusNotFound, call(http.MethodGet, "/api/v1/deposits/missing", "").Code)
+	})
+
+	t.Run("Deposits_AttachesToOpenInvoice", func(t *testing.T) {
+		w := call(http.MethodPost, "/api/v1/deposits/"+depositID+"/attach", `{"invoice_id":"`+closed.ID+`"}`)
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+		w = call(http.MethodPost, "/api/v1/deposits/"+depositID+"/attach", `{"invoice_id":"`+open.ID+`"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var deposit web.DepositResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &deposit))
+		assert.Equal(t, "attached", deposit.Status)
+		assert.Equal(t, open.ID, deposit.AttachedInvoiceID)
+		assert.Equal(t, "user-1", deposit.ResolvedBy)
+		require.NotEmpty(t, deposit.PaymentID)
+
+		status, err := services.Invoices.GetInvoiceStatus(context.Background(), open.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "confirming", string(status))
+
+		// Later notifications of the transfer advance the payment it became
+		w = notify("0x7777777777777777777777777777777777777777777777777777777777777777",
+			closed.Address, closed.USDTAmount, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response web.BlockchainNotificationResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.False(t, response.Unattributed)
+		assert.Equal(t, deposit.PaymentID, response.PaymentID)
+		assert.Equal(t, open.ID, response.InvoiceID)
+
+		w = call(http.MethodPost, "/api/v1/deposits/"+depositID+"/refund", "")
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	})
+
+	t.Run("Deposits_RefundsToSender", func(t *testing.T) {
+		w := call(http.MethodPost, "/api/v1/deposits/"+strayID+"/refund", `{"note":"sent by mistake"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var deposit web.DepositResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &deposit))
+		assert.Equal(t, "refunded", deposit.Status)
+		assert.Empty(t, deposit.Matches)
+	})
+}
@@ -3,10 +3,23 @@ package web
 
 import (
 	"context"
+	"crypto-checkout/internal/domain/approval"
+	"crypto-checkout/internal/domain/checkoutanalytics"
+	"crypto-checkout/internal/domain/confirmation"
+	"crypto-checkout/internal/domain/coupon"
+	"crypto-checkout/internal/domain/evidence"
+	"crypto-checkout/internal/domain/experiment"
+	"crypto-checkout/internal/domain/geoblock"
 	"crypto-checkout/internal/domain/invoice"
 	"crypto-checkout/internal/domain/merchant"
 	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/paymentlink"
+	"crypto-checkout/internal/domain/platform"
+	"crypto-checkout/internal/domain/processor"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/tax"
 	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
 	"embed"
 	"errors"
 	"fmt"
@@ -31,9 +44,59 @@ var templatesFS embed.FS
 // Module provides the API module for Fx dependency injection.
 var Module = fx.Module("api",
 	fx.Provide(
+		i18n.NewBundle,
 		NewGinEngine,
 		NewWebSocketHub,
+		NewCheckoutEventStream,
 		NewAPIHandler,
+		NewRedirectSigner,
+		NewIdempotency,
+		NewHealthHandlers,
+		NewStatusHandlers,
+		NewRBACMiddleware,
+		NewSessionAuthMiddleware,
+		NewSessionHandlers,
+		NewTeamHandlers,
+		NewAuditHandlers,
+		NewComplianceHandlers,
+		NewBlocklistHandlers,
+		NewReviewHandlers,
+		NewDepositHandlers,
+		NewArchiveHandlers,
+		NewCustomerAuthMiddleware,
+		NewCustomerHandlers,
+		NewCouponHandlers,
+		NewTaxHandlers,
+		NewPaymentHandlers,
+		NewPaymentLinkHandlers,
+		NewInvoiceTemplateHandlers,
+		NewAutomationRuleHandlers,
+		NewPaymentProcessorHandlers,
+		NewWebhookDeliveryHandlers,
+		NewBlockchainNotificationHandlers,
+		NewSettlementHandlers,
+		NewPayoutHandlers,
+		NewTreasuryHandlers,
+		NewFeeHandlers,
+		NewPaymentStatisticsHandlers,
+		NewApprovalHandlers,
+		NewDeadLetterHandlers,
+		NewSagaHandlers,
+		NewGraphQLHandlers,
+		NewConfigHandlers,
+		NewRateLimiter,
+		NewAdminAuthMiddleware,
+		NewAdminHandlers,
+		NewBackfillHandlers,
+		NewReconciliationHandlers,
+		NewStatementHandlers,
+		NewEvidenceSigner,
+		NewEvidenceHandlers,
+		NewExperimentHandlers,
+		NewCheckoutAnalyticsHandlers,
+		NewGeoBlockHandlers,
+		NewSLOHandlers,
+		NewLogLevelHandlers,
 		NewHTTPServer,
 	),
 	fx.Invoke(RegisterRoutes),
@@ -77,18 +140,30 @@ func ErrorHandler(cfg *config.Config, logger *zap.Logger) gin.HandlerFunc {
 
 			// Map specific errors to HTTP status codes and messages
 			switch {
+			case errors.Is(err, context.DeadlineExceeded):
+				statusCode = http.StatusGatewayTimeout
+				errorMessage = "The request timed out"
+				errorCode = "REQUEST_TIMEOUT"
 			case errors.Is(err, invoice.ErrInvalidRequest):
 				statusCode = http.StatusBadRequest
 				errorMessage = err.Error()
 				errorCode = "BAD_REQUEST"
+			case errors.Is(err, invoice.ErrUnsupportedCryptocurrency):
+				statusCode = http.StatusBadRequest
+				errorMessage = err.Error()
+				errorCode = invoice.ErrCodeUnsupportedCryptocurrency
 			case errors.Is(err, invoice.ErrInvalidUnitPrice):
 				statusCode = http.StatusBadRequest
 				errorMessage = err.Error()
 				errorCode = "INVALID_UNIT_PRICE"
-			case errors.Is(err, invoice.ErrInvoiceNotFound), errors.Is(err, invoice.ErrNotFound):
-				statusCode = http.StatusNotFound
+			case errors.Is(err, invoice.ErrExtensionLimit):
+				statusCode = http.StatusUnprocessableEntity
 				errorMessage = err.Error()
-				errorCode = "NOT_FOUND"
+				errorCode = invoice.ErrCodeExtensionLimit
+			case errors.Is(err, invoice.ErrLiveKeyTestnet):
+				statusCode = http.StatusForbidden
+				errorMessage = err.Error()
+				errorCode = invoice.ErrCodeLiveKeyTestnet
 			case errors.Is(err, invoice.ErrServiceError):
 				statusCode = http.StatusInternalServerError
 				errorMessage = "Failed to process invoice"
@@ -101,11 +176,13 @@ func ErrorHandler(cfg *config.Config, logger *zap.Logger) gin.HandlerFunc {
 				statusCode = http.StatusInternalServerError
 				errorMessage = "Failed to process payment"
 				errorCode = "PAYMENT_SERVICE_ERROR"
-			case errors.Is(err, payment.ErrPaymentNotFound):
-				statusCode = http.StatusNotFound
-				errorMessage = err.Error()
-				errorCode = "PAYMENT_NOT_FOUND"
 			default:
+				// Domain errors are answered after their kind
+				if status, response, ok := domainErrorResponse(err); ok {
+					c.AbortWithStatusJSON(status, response)
+					return
+				}
+
 				// Check for common HTTP errors by error message
 				errorMsg := err.Error()
 				switch {
@@ -155,8 +232,50 @@ func NewAPIHandler(
 	logger *zap.Logger,
 	cfg *config.Config,
 	hub *Hub,
+	rbac *RBACMiddleware,
+	sessionAuth *SessionAuthMiddleware,
+	couponService coupon.Service,
+	taxService tax.Service,
+	paymentLinkService paymentlink.Service,
+	invoiceEvents *InvoiceEventStream,
+	approvalService approval.Service,
+	bundle *i18n.Bundle,
+	checkoutLocales *merchant.CheckoutLocales,
+	idempotency *Idempotency,
+	redirectSigner *RedirectSigner,
+	merchantOrigins *merchant.AllowedOrigins,
+	processorService processor.Service,
+	confirmationETA *confirmation.Estimator,
+	explorers *shared.ExplorerResolver,
+	platformService platform.Service,
+	evidenceService evidence.Service,
+	experimentService experiment.Service,
+	checkoutAnalytics checkoutanalytics.Service,
+	countryLocator shared.CountryLocator,
+	geoBlocking geoblock.Service,
 ) *Handler {
-	return NewHandler(invoiceService, paymentService, apiKeyService, logger, cfg, hub)
+	handler := NewHandler(invoiceService, paymentService, apiKeyService, logger, cfg, hub)
+	handler.SetRBACMiddleware(rbac)
+	handler.SetSessionAuthMiddleware(sessionAuth)
+	handler.SetCouponService(couponService)
+	handler.SetTaxService(taxService)
+	handler.SetPaymentLinkService(paymentLinkService)
+	handler.SetInvoiceEventStream(invoiceEvents)
+	handler.SetApprovalService(approvalService)
+	handler.SetLocalization(bundle, checkoutLocales)
+	handler.SetIdempotency(idempotency)
+	handler.SetRedirectSigner(redirectSigner)
+	handler.SetMerchantOrigins(merchantOrigins)
+	handler.SetProcessorService(processorService)
+	handler.SetConfirmationEstimator(confirmationETA)
+	handler.SetExplorerResolver(explorers)
+	handler.SetIncidents(platformService)
+	handler.SetEvidenceService(evidenceService)
+	handler.SetExperimentService(experimentService)
+	handler.SetCheckoutAnalytics(checkoutAnalytics)
+	handler.SetCountryLocator(countryLocator)
+	handler.SetGeoBlocking(geoBlocking)
+	return handler
 }
 
 const (
@@ -182,11 +301,105 @@ func RegisterRoutes(
 	lc fx.Lifecycle,
 	router *gin.Engine,
 	handler *Handler,
+	healthHandlers *HealthHandlers,
+	statusHandlers *StatusHandlers,
+	teamHandlers *TeamHandlers,
+	sessionHandlers *SessionHandlers,
+	auditHandlers *AuditHandlers,
+	complianceHandlers *ComplianceHandlers,
+	blocklistHandlers *BlocklistHandlers,
+	reviewHandlers *ReviewHandlers,
+	depositHandlers *DepositHandlers,
+	archiveHandlers *ArchiveHandlers,
+	customerHandlers *CustomerHandlers,
+	couponHandlers *CouponHandlers,
+	taxHandlers *TaxHandlers,
+	paymentHandlers *PaymentHandlers,
+	paymentLinkHandlers *PaymentLinkHandlers,
+	invoiceTemplateHandlers *InvoiceTemplateHandlers,
+	automationRuleHandlers *AutomationRuleHandlers,
+	paymentProcessorHandlers *PaymentProcessorHandlers,
+	webhookDeliveryHandlers *WebhookDeliveryHandlers,
+	blockchainNotificationHandlers *BlockchainNotificationHandlers,
+	settlementHandlers *SettlementHandlers,
+	payoutHandlers *PayoutHandlers,
+	treasuryHandlers *TreasuryHandlers,
+	feeHandlers *FeeHandlers,
+	paymentStatisticsHandlers *PaymentStatisticsHandlers,
+	approvalHandlers *ApprovalHandlers,
+	deadLetterHandlers *DeadLetterHandlers,
+	sagaHandlers *SagaHandlers,
+	graphQLHandlers *GraphQLHandlers,
+	configHandlers *ConfigHandlers,
+	adminHandlers *AdminHandlers,
+	backfillHandlers *BackfillHandlers,
+	reconciliationHandlers *ReconciliationHandlers,
+	statementHandlers *StatementHandlers,
+	evidenceHandlers *EvidenceHandlers,
+	experimentHandlers *ExperimentHandlers,
+	checkoutAnalyticsHandlers *CheckoutAnalyticsHandlers,
+	geoBlockHandlers *GeoBlockHandlers,
+	sloHandlers *SLOHandlers,
+	logLevelHandlers *LogLevelHandlers,
+	rateLimiter *RateLimiter,
+	adminAuth *AdminAuthMiddleware,
+	sessionAuth *SessionAuthMiddleware,
+	rbac *RBACMiddleware,
 	server *http.Server,
 	logger *zap.Logger,
 ) {
+	// Rate limits apply to the routes registered after this
+	router.Use(rateLimiter.Middleware())
+	// Admin routes need an admin key on top of their merchant credentials
+	router.Use(adminAuth.Middleware())
+
 	// Register API routes
 	handler.RegisterRoutes(router)
+	healthHandlers.RegisterHealthRoutes(router)
+	statusHandlers.RegisterStatusRoutes(router)
+
+	v1 := router.Group("/api/v1")
+	protected := v1.Group("")
+	protected.Use(sessionAuth.RequireAuth())
+	teamHandlers.RegisterTeamRoutes(v1, protected, rbac)
+	sessionHandlers.RegisterSessionRoutes(v1)
+	auditHandlers.RegisterAuditRoutes(protected, rbac)
+	complianceHandlers.RegisterComplianceRoutes(protected, rbac)
+	blocklistHandlers.RegisterBlocklistRoutes(protected, rbac)
+	reviewHandlers.RegisterReviewRoutes(protected, rbac)
+	depositHandlers.RegisterDepositRoutes(protected, rbac)
+	archiveHandlers.RegisterArchiveRoutes(protected, rbac)
+	couponHandlers.RegisterCouponRoutes(protected, rbac)
+	taxHandlers.RegisterTaxRoutes(protected, rbac)
+	paymentHandlers.RegisterPaymentRoutes(protected, rbac)
+	paymentLinkHandlers.RegisterPaymentLinkRoutes(protected, rbac)
+	invoiceTemplateHandlers.RegisterInvoiceTemplateRoutes(protected, rbac)
+	automationRuleHandlers.RegisterAutomationRuleRoutes(protected, rbac)
+	paymentProcessorHandlers.RegisterPaymentProcessorRoutes(protected, rbac)
+	webhookDeliveryHandlers.RegisterWebhookDeliveryRoutes(protected, rbac)
+	settlementHandlers.RegisterSettlementRoutes(protected, rbac)
+	payoutHandlers.RegisterPayoutRoutes(protected, rbac)
+	treasuryHandlers.RegisterTreasuryRoutes(protected, rbac)
+	feeHandlers.RegisterFeeRoutes(protected, rbac)
+	paymentStatisticsHandlers.RegisterPaymentStatisticsRoutes(protected, rbac)
+	approvalHandlers.RegisterApprovalRoutes(protected, rbac)
+	deadLetterHandlers.RegisterDeadLetterRoutes(protected, rbac)
+	sagaHandlers.RegisterSagaRoutes(protected, rbac)
+	graphQLHandlers.RegisterGraphQLRoutes(protected, rbac)
+	configHandlers.RegisterConfigRoutes(protected, rbac)
+	adminHandlers.RegisterAdminRoutes(protected, rbac)
+	backfillHandlers.RegisterBackfillRoutes(protected, rbac)
+	reconciliationHandlers.RegisterReconciliationRoutes(protected, rbac)
+	statementHandlers.RegisterStatementRoutes(protected, rbac)
+	evidenceHandlers.RegisterEvidenceRoutes(v1, protected, rbac)
+	experimentHandlers.RegisterExperimentRoutes(protected, rbac)
+	checkoutAnalyticsHandlers.RegisterCheckoutAnalyticsRoutes(protected, rbac)
+	geoBlockHandlers.RegisterGeoBlockRoutes(protected, rbac)
+	sloHandlers.RegisterSLORoutes(protected, rbac)
+	logLevelHandlers.RegisterLogLevelRoutes(protected, rbac)
+	customerHandlers.RegisterCustomerRoutes(v1)
+	blockchainNotificationHandlers.RegisterBlockchainNotificationRoutes(v1)
+	paymentProcessorHandlers.RegisterProcessorWebhookRoutes(v1)
 
 	// Set the Gin router as the server handler
 	server.Handler = router
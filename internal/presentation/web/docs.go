@@ -25,4 +25,9 @@
 // @name Authorization
 // @description JWT Bearer token authentication. Use format: Bearer <jwt_token>
 
+// @securityDefinitions.apikey CustomerAuth
+// @in header
+// @name Authorization
+// @description Customer session authentication. Use format: Bearer cst_xxx
+
 package web
@@ -0,0 +1,70 @@
+package web
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// domainErrorKinds are the statuses and error types the kinds of domain errors are answered with.
+var domainErrorKinds = map[error]struct {
+	status    int
+	errorType string
+}{
+	shared.ErrNotFound:     {http.StatusNotFound, "not_found"},
+	shared.ErrValidation:   {http.StatusBadRequest, "validation_error"},
+	shared.ErrInvalidState: {http.StatusConflict, "conflict"},
+	shared.ErrConflict:     {http.StatusConflict, "conflict"},
+}
+
+// domainErrorResponse maps an error to the status and envelope of its kind, with the code of the error and the
+// field a validation error is about in its details. It reports false for an error of no kind, such as the failure
+// of a dependency, which is not to be shown to clients.
+func domainErrorResponse(err error) (int, ErrorResponse, bool) {
+	kind, ok := domainErrorKinds[shared.KindOf(err)]
+	if !ok {
+		return 0, ErrorResponse{}, false
+	}
+
+	response := ErrorResponse{
+		Error:     kind.errorType,
+		Code:      shared.CodeOf(err),
+		Message:   err.Error(),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		RequestID: generateRequestID(),
+	}
+	details := make(map[string]interface{})
+	var domainErr *shared.DomainError
+	if errors.As(err, &domainErr) {
+		for key, value := range domainErr.Details {
+			details[key] = value
+		}
+	}
+	if field := shared.FieldOf(err); field != "" {
+		details["field"] = field
+	}
+	if len(details) > 0 {
+		response.Details = details
+	}
+	return kind.status, response, true
+}
+
+// respondDomainError answers an error with the status and envelope of its kind, and a request that ran out of time
+// with a gateway timeout. Other errors of no kind are logged with the message, which is all the client is told of them.
+func respondDomainError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	if status, response, ok := domainErrorResponse(err); ok {
+		c.JSON(status, response)
+		return
+	}
+	logger.Error(message, zap.Error(err))
+	if errors.Is(err, context.DeadlineExceeded) {
+		c.JSON(http.StatusGatewayTimeout, gin.H{"error": message})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+}
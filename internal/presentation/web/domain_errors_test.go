@@ -0,0 +1,103 @@
+package web_test
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestErrorHandler_DomainErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		err       error
+		status    int
+		errorType string
+		code      string
+		field     string
+	}{
+		{
+			name:      "not found",
+			err:       fmt.Errorf("failed to find merchant: %w", merchant.ErrMerchantNotFound),
+			status:    http.StatusNotFound,
+			errorType: "not_found",
+			code:      merchant.ErrCodeMerchantNotFound,
+		},
+		{
+			name:      "field error",
+			err:       shared.NewFieldError("merchant_id", "merchant ID is required"),
+			status:    http.StatusBadRequest,
+			errorType: "validation_error",
+			code:      shared.ErrCodeValidationFailed,
+			field:     "merchant_id",
+		},
+		{
+			name:      "wrapped field error",
+			err:       shared.WrapFieldError("items", invoice.ErrNoItems),
+			status:    http.StatusBadRequest,
+			errorType: "validation_error",
+			code:      invoice.ErrCodeNoItems,
+			field:     "items",
+		},
+		{
+			name:      "invalid state",
+			err:       invoice.ErrCannotRefundInvoice,
+			status:    http.StatusConflict,
+			errorType: "conflict",
+			code:      invoice.ErrCodeCannotRefundInvoice,
+		},
+		{
+			name:      "conflict",
+			err:       payment.NewPaymentAlreadyExistsError("0xabc", "inv_1"),
+			status:    http.StatusConflict,
+			errorType: "conflict",
+			code:      payment.ErrCodePaymentAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(web.ErrorHandler(&config.Config{}, zap.NewNop()))
+			router.GET("/", func(c *gin.Context) { _ = c.Error(tt.err) })
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			require.Equal(t, tt.status, w.Code, w.Body.String())
+
+			var resp web.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.errorType, resp.Error)
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Equal(t, tt.err.Error(), resp.Message)
+			if tt.field != "" {
+				assert.Equal(t, tt.field, resp.Details["field"])
+			}
+		})
+	}
+
+	t.Run("errors of no kind are not shown", func(t *testing.T) {
+		router := gin.New()
+		router.Use(web.ErrorHandler(&config.Config{}, zap.NewNop()))
+		router.GET("/", func(c *gin.Context) { _ = c.Error(errors.New("dial tcp: connection refused")) })
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.NotContains(t, w.Body.String(), "connection refused")
+	})
+}
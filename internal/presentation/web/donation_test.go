@@ -0,0 +1,159 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newDonationTestPayment(t *testing.T, invoiceID, txHash, amount string) *payment.Payment {
+	money, err := shared.NewMoneyWithCrypto(amount, shared.CryptoCurrencyUSDT)
+	require.NoError(t, err)
+	paymentAmount, err := payment.NewPaymentAmount(money, shared.CryptoCurrencyUSDT)
+	require.NoError(t, err)
+	toAddress, err := payment.NewPaymentAddress("TTestAddress123456789012345678901234567890", shared.NetworkTron)
+	require.NoError(t, err)
+	transactionHash, err := payment.NewTransactionHash(txHash)
+	require.NoError(t, err)
+
+	p, err := payment.NewPayment(
+		shared.PaymentID("payment-"+txHash[2:10]),
+		shared.InvoiceID(invoiceID),
+		paymentAmount,
+		"TSenderAddress123456789012345678901234567890",
+		toAddress,
+		transactionHash,
+		1,
+	)
+	require.NoError(t, err)
+	return p
+}
+
+func TestDonationInvoices(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(web.ErrorHandler(&config.Config{}, zap.NewNop()))
+
+	handler, services := web.CreateTestHandlerWithServices()
+	router.POST("/api/v1/invoices", handler.CreateInvoice)
+	router.GET("/api/v1/public/invoice/:id", handler.GetPublicInvoiceData)
+	router.POST("/api/v1/public/invoice/:id/amount", handler.ChooseDonationAmount)
+
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	createDonation := func(t *testing.T) web.CreateInvoiceResponse {
+		w := request(http.MethodPost, "/api/v1/invoices", `{"title":"Support our work","type":"donation","minimum_amount":"5.00"}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("CreateDonation", func(t *testing.T) {
+		response := createDonation(t)
+		require.Equal(t, "donation", response.Type)
+		require.NotNil(t, response.MinimumAmount)
+		require.Equal(t, "5.00", *response.MinimumAmount)
+		require.Equal(t, "5.00", response.Total)
+		require.Equal(t, "0", response.AmountReceived)
+	})
+
+	t.Run("CreateDonation_Validation", func(t *testing.T) {
+		w := request(http.MethodPost, "/api/v1/invoices", `{"title":"Support","type":"donation"}`)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		w = request(http.MethodPost, "/api/v1/invoices",
+			`{"title":"Support","type":"donation","minimum_amount":"5.00","tax_rate":"0.10"}`)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		w = request(http.MethodPost, "/api/v1/invoices",
+			`{"title":"Support","type":"donation","minimum_amount":"5.00","pass_fee":true}`)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		w = request(http.MethodPost, "/api/v1/invoices", `{"title":"Standard","tax_rate":"0.00"}`)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("ChooseDonationAmount", func(t *testing.T) {
+		donation := createDonation(t)
+
+		w := request(http.MethodPost, "/api/v1/public/invoice/"+donation.ID+"/amount", `{"amount":"4.99"}`)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), invoice.ErrCodeBelowMinimumAmount)
+
+		w = request(http.MethodPost, "/api/v1/public/invoice/"+donation.ID+"/amount", `{"amount":"20"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response web.PublicInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, "20.00", response.Total)
+		require.Equal(t, "5.00", *response.MinimumAmount)
+		require.NotNil(t, response.PaymentProgress)
+		require.Equal(t, "0", response.PaymentProgress.Received)
+	})
+
+	t.Run("ChooseDonationAmount_StandardInvoice", func(t *testing.T) {
+		w := request(http.MethodPost, "/api/v1/invoices",
+			`{"title":"Standard","items":[{"name":"Item","quantity":"1","unit_price":"10.00"}],"tax_rate":"0.00"}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var standard web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &standard))
+		require.Equal(t, "standard", standard.Type)
+
+		w = request(http.MethodPost, "/api/v1/public/invoice/"+standard.ID+"/amount", `{"amount":"20"}`)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("PaymentsAccumulateAgainstTheMinimum", func(t *testing.T) {
+		donation := createDonation(t)
+		ctx := context.Background()
+		require.NoError(t, services.Invoices.MarkInvoiceAsViewed(ctx, donation.ID))
+
+		// A payment below the minimum is partial, not an underpayment
+		require.NoError(t, services.Invoices.ProcessPayment(ctx, donation.ID,
+			newDonationTestPayment(t, donation.ID, "0x1111111111111111111111111111111111111111111111111111111111111111", "2")))
+		status, err := services.Invoices.GetInvoiceStatus(ctx, donation.ID)
+		require.NoError(t, err)
+		require.Equal(t, invoice.StatusPartial, status)
+
+		// Together the payments reach the minimum
+		require.NoError(t, services.Invoices.ProcessPayment(ctx, donation.ID,
+			newDonationTestPayment(t, donation.ID, "0x2222222222222222222222222222222222222222222222222222222222222222", "3.5")))
+		status, err = services.Invoices.GetInvoiceStatus(ctx, donation.ID)
+		require.NoError(t, err)
+		require.Equal(t, invoice.StatusConfirming, status)
+
+		w := request(http.MethodGet, "/api/v1/public/invoice/"+donation.ID, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response web.PublicInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, "5.5", response.PaymentProgress.Received)
+		require.Equal(t, "0", response.PaymentProgress.Remaining)
+		require.InDelta(t, 110.0, response.PaymentProgress.Percent, 0.001)
+
+		w = request(http.MethodPost, "/api/v1/public/invoice/"+donation.ID+"/amount", `{"amount":"20"}`)
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	})
+}
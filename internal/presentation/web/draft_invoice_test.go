@@ -0,0 +1,201 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/tax"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDraftInvoices(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(web.ErrorHandler(&config.Config{}, zap.NewNop()))
+
+	handler, services := web.CreateTestHandlerWithServices()
+
+	router.POST("/api/v1/invoices", web.AuthMiddleware(handler.Logger), handler.CreateInvoice)
+	router.PATCH("/api/v1/invoices/:id", web.AuthMiddleware(handler.Logger), handler.UpdateDraftInvoice)
+	router.POST("/api/v1/invoices/:id/finalize", web.AuthMiddleware(handler.Logger), handler.FinalizeInvoice)
+	router.GET("/api/v1/public/invoice/:id", handler.GetPublicInvoiceData)
+
+	_, err := services.Tax.CreateRule(context.Background(), &tax.CreateRuleRequest{
+		MerchantID: "test-merchant", Jurisdiction: "DE", Name: "VAT", Type: invoice.TaxTypeVAT, Rate: "0.19",
+	})
+	require.NoError(t, err)
+
+	request := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var requestBody []byte
+		if body != nil {
+			requestBody, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	createDraft := func(t *testing.T, body web.CreateInvoiceRequest) web.CreateInvoiceResponse {
+		body.Draft = true
+		w := request(http.MethodPost, "/api/v1/invoices", body)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) web.CreateInvoiceResponse {
+		var response web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("CreateDraft_NoAddressOrRate", func(t *testing.T) {
+		expiresIn := 7200
+		draft := createDraft(t, web.CreateInvoiceRequest{
+			Title:     "Website redesign",
+			Items:     []web.InvoiceItemRequest{{Name: "Design", Quantity: "1", UnitPrice: "100.00"}},
+			TaxRate:   "0.10",
+			ExpiresIn: &expiresIn,
+		})
+		require.Equal(t, "draft", draft.Status)
+		require.Nil(t, draft.PaymentAddress)
+		require.Empty(t, draft.Address)
+		require.Empty(t, draft.USDTAmount)
+		require.True(t, draft.ExpiresAt.IsZero())
+		require.NotNil(t, draft.ExpiresIn)
+		require.Equal(t, 7200, *draft.ExpiresIn)
+		require.Equal(t, "110.00", draft.Total)
+
+		w := request(http.MethodGet, "/api/v1/public/invoice/"+draft.ID, nil)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("UpdateDraft_FlatTaxFollowsItems", func(t *testing.T) {
+		draft := createDraft(t, web.CreateInvoiceRequest{
+			Title:   "Website redesign",
+			Items:   []web.InvoiceItemRequest{{Name: "Design", Quantity: "1", UnitPrice: "100.00"}},
+			TaxRate: "0.10",
+		})
+
+		title := "Website redesign and hosting"
+		w := request(http.MethodPatch, "/api/v1/invoices/"+draft.ID, web.InvoiceChangesRequest{
+			Title: &title,
+			Items: []web.InvoiceItemRequest{
+				{Name: "Design", Quantity: "1", UnitPrice: "100.00"},
+				{Name: "Hosting", Quantity: "12", UnitPrice: "10.00"},
+			},
+			Metadata: map[string]interface{}{"project": "redesign"},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		response := decode(t, w)
+		require.Equal(t, "draft", response.Status)
+		require.Len(t, response.Items, 2)
+		require.Equal(t, "220.00", response.Subtotal)
+		require.Equal(t, "22.00", response.TaxAmount)
+		require.Equal(t, "242.00", response.Total)
+
+		inv, err := services.Invoices.GetInvoice(context.Background(), draft.ID)
+		require.NoError(t, err)
+		require.Equal(t, title, inv.Title())
+		require.Equal(t, "redesign", inv.Metadata()["project"])
+
+		noTax := "0"
+		w = request(http.MethodPatch, "/api/v1/invoices/"+draft.ID, web.InvoiceChangesRequest{TaxRate: &noTax})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, "220.00", decode(t, w).Total)
+	})
+
+	t.Run("UpdateDraft_JurisdictionTax", func(t *testing.T) {
+		draft := createDraft(t, web.CreateInvoiceRequest{
+			Title:   "Software License",
+			Items:   []web.InvoiceItemRequest{{Name: "License", Quantity: "1", UnitPrice: "100.00"}},
+			TaxRate: "0.10",
+		})
+
+		w := request(http.MethodPatch, "/api/v1/invoices/"+draft.ID,
+			web.InvoiceChangesRequest{TaxJurisdiction: "DE"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		response := decode(t, w)
+		require.NotNil(t, response.Taxes)
+		require.Equal(t, "DE", response.Taxes.Jurisdiction)
+		require.Equal(t, "19.00", response.TaxAmount)
+
+		// New items are taxed under the draft's jurisdiction
+		w = request(http.MethodPatch, "/api/v1/invoices/"+draft.ID, web.InvoiceChangesRequest{
+			Items: []web.InvoiceItemRequest{{Name: "License", Quantity: "2", UnitPrice: "100.00"}},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		response = decode(t, w)
+		require.Equal(t, "38.00", response.TaxAmount)
+		require.Equal(t, "238.00", response.Total)
+	})
+
+	t.Run("UpdateDraft_Invalid", func(t *testing.T) {
+		draft := createDraft(t, web.CreateInvoiceRequest{
+			Title:   "Website redesign",
+			Items:   []web.InvoiceItemRequest{{Name: "Design", Quantity: "1", UnitPrice: "100.00"}},
+			TaxRate: "0.10",
+		})
+
+		negative := "-0.1"
+		w := request(http.MethodPatch, "/api/v1/invoices/"+draft.ID, web.InvoiceChangesRequest{TaxRate: &negative})
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		empty := ""
+		w = request(http.MethodPatch, "/api/v1/invoices/"+draft.ID, web.InvoiceChangesRequest{Title: &empty})
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		w = request(http.MethodPatch, "/api/v1/invoices/missing", web.InvoiceChangesRequest{Title: &negative})
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("FinalizeDraft", func(t *testing.T) {
+		expiresIn := 3600
+		draft := createDraft(t, web.CreateInvoiceRequest{
+			Title:     "Website redesign",
+			Items:     []web.InvoiceItemRequest{{Name: "Design", Quantity: "1", UnitPrice: "100.00"}},
+			TaxRate:   "0.10",
+			ExpiresIn: &expiresIn,
+		})
+
+		w := request(http.MethodPost, "/api/v1/invoices/"+draft.ID+"/finalize", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		response := decode(t, w)
+		require.Equal(t, "created", response.Status)
+		require.NotNil(t, response.PaymentAddress)
+		require.NotEmpty(t, response.USDTAmount)
+		require.Nil(t, response.ExpiresIn)
+		require.False(t, response.RateExpiresAt.IsZero())
+		require.InDelta(t, 3600, response.ExpiresAt.Sub(response.CreatedAt).Seconds(), 5)
+
+		w = request(http.MethodGet, "/api/v1/public/invoice/"+draft.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		// Finalized invoices can no longer be edited or finalized again
+		w = request(http.MethodPost, "/api/v1/invoices/"+draft.ID+"/finalize", nil)
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), invoice.ErrCodeInvoiceNotDraft)
+
+		title := "Too late"
+		w = request(http.MethodPatch, "/api/v1/invoices/"+draft.ID, web.InvoiceChangesRequest{Title: &title})
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	})
+}
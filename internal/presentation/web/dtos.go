@@ -2,39 +2,201 @@
 package web
 
 import (
+	"crypto-checkout/internal/domain/approval"
+	"crypto-checkout/internal/domain/archive"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/automation"
+	"crypto-checkout/internal/domain/checkoutanalytics"
+	"crypto-checkout/internal/domain/compliance"
+	"crypto-checkout/internal/domain/confirmation"
+	"crypto-checkout/internal/domain/coupon"
+	"crypto-checkout/internal/domain/customer"
+	"crypto-checkout/internal/domain/deadletter"
+	"crypto-checkout/internal/domain/deposit"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/evidence"
+	"crypto-checkout/internal/domain/experiment"
+	"crypto-checkout/internal/domain/fee"
+	"crypto-checkout/internal/domain/geoblock"
 	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/invoicetemplate"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/paymentlink"
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/platform"
+	"crypto-checkout/internal/domain/processor"
+	"crypto-checkout/internal/domain/reconciliation"
+	"crypto-checkout/internal/domain/review"
+	"crypto-checkout/internal/domain/saga"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/slo"
+	"crypto-checkout/internal/domain/statement"
+	"crypto-checkout/internal/domain/tax"
+	"crypto-checkout/internal/domain/treasury"
+	"crypto-checkout/internal/domain/webhook"
+	"crypto-checkout/internal/infrastructure/logging"
+	"crypto-checkout/internal/infrastructure/runtimeconfig"
+	"encoding/base64"
+	"encoding/json"
+	"math"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 const (
 	// TaxRateDecimals is the number of decimal places for tax rate display.
 	TaxRateDecimals = 2
+
+	// checkoutBaseURL is where customers open invoices and payment links.
+	checkoutBaseURL = "https://checkout.thecryptocheckout.com"
 )
 
 // CreateInvoiceRequest represents the request payload for creating an invoice.
 type CreateInvoiceRequest struct {
-	Title             string                   `binding:"required"       json:"title"`
-	Description       string                   `                         json:"description"`
-	Items             []InvoiceItemRequest     `binding:"required,min=1" json:"items"`
-	Tax               *string                  `                         json:"tax,omitempty"` // Fixed tax amount (deprecated, use tax_rate)
-	TaxRate           string                   `                         json:"tax_rate"`      // Tax rate as decimal (e.g., "0.10" for 10%)
-	Currency          string                   `                         json:"currency,omitempty"`
-	CryptoCurrency    string                   `                         json:"crypto_currency,omitempty"`
-	PriceLockDuration *int                     `                         json:"price_lock_duration,omitempty"`
-	ExpiresIn         *int                     `                         json:"expires_in,omitempty"`
-	PaymentTolerance  *PaymentToleranceRequest `                         json:"payment_tolerance,omitempty"`
-	WebhookURL        *string                  `                         json:"webhook_url,omitempty"`
-	ReturnURL         *string                  `                         json:"return_url,omitempty"`
-	CancelURL         *string                  `                         json:"cancel_url,omitempty"`
-	Metadata          map[string]interface{}   `                         json:"metadata,omitempty"`
+	Title             string                   `binding:"required"                                      json:"title"`
+	Description       string                   `                                                        json:"description"`
+	Type              string                   `binding:"omitempty,oneof=standard donation"             json:"type,omitempty"`           // Defaults to "standard"
+	MinimumAmount     string                   `                                                        json:"minimum_amount,omitempty"` // Smallest amount that settles a donation
+	Items             []InvoiceItemRequest     `binding:"required_unless=Type donation,omitempty,min=1" json:"items"`
+	Discount          *DiscountRequest         `                                                        json:"discount,omitempty"`         // Invoice-level discount
+	CouponCode        string                   `                                                        json:"coupon_code,omitempty"`      // Merchant coupon applied after the discount
+	Tax               *string                  `                                                        json:"tax,omitempty"`              // Fixed tax amount (deprecated, use tax_rate)
+	TaxRate           string                   `                                                        json:"tax_rate"`                   // Tax rate as decimal (e.g., "0.10" for 10%)
+	TaxJurisdiction   string                   `                                                        json:"tax_jurisdiction,omitempty"` // Customer country or subdivision; replaces tax_rate with the merchant's tax rules
+	CustomerTaxID     string                   `                                                        json:"customer_tax_id,omitempty"`  // Business customer VAT/GST ID, enables reverse charge
+	Currency          string                   `                                                        json:"currency,omitempty"`
+	CryptoCurrency    string                   `                                                        json:"crypto_currency,omitempty"`
+	Network           string                   `                                                        json:"network,omitempty"` // Defaults to the crypto currency's first configured network
+	PriceLockDuration *int                     `                                                        json:"price_lock_duration,omitempty"`
+	ExpiresIn         *int                     `                                                        json:"expires_in,omitempty"`
+	PaymentTolerance  *PaymentToleranceRequest `                                                        json:"payment_tolerance,omitempty"`
+	WebhookURL        *string                  `                                                        json:"webhook_url,omitempty"`
+	ReturnURL         *string                  `                                                        json:"return_url,omitempty"`
+	CancelURL         *string                  `                                                        json:"cancel_url,omitempty"`
+	Metadata          map[string]interface{}   `                                                        json:"metadata,omitempty"`
+	CustomFields      []CustomFieldRequest     `binding:"omitempty,max=10,dive"                         json:"custom_fields,omitempty"`    // Shown to customers, unlike metadata
+	SettlementSplit   []SplitShareRequest      `binding:"omitempty,max=10,dive"                         json:"settlement_split,omitempty"` // Beneficiary merchants' shares
+	PassFee           bool                     `                                                        json:"pass_fee,omitempty"`         // Adds the platform fee on top of the total for the customer to pay
+	Draft             bool                     `                                                        json:"draft,omitempty"`            // Creates an editable draft; see POST /invoices/{id}/finalize
+}
+
+// InvoiceChangesRequest represents the API request to change the terms of a draft invoice, or to amend a
+// finalized one. Omitted fields are left unchanged.
+type InvoiceChangesRequest struct {
+	Title           *string                `                               json:"title,omitempty"`
+	Description     *string                `                               json:"description,omitempty"`
+	CustomerID      *string                `                               json:"customer_id,omitempty"`
+	Items           []InvoiceItemRequest   `binding:"omitempty,min=1,dive" json:"items,omitempty"`
+	TaxRate         *string                `                               json:"tax_rate,omitempty"`          // Replaces the tax with a rate applied to the discounted subtotal
+	TaxJurisdiction string                 `                               json:"tax_jurisdiction,omitempty"`  // Replaces the tax with the merchant's tax rules for the jurisdiction
+	CustomerTaxID   string                 `                               json:"customer_tax_id,omitempty"`   // Business customer VAT/GST ID, with tax_jurisdiction
+	ExpiresIn       *int                   `                               json:"expires_in,omitempty"`        // Seconds the invoice stays payable once finalized or amended
+	Metadata        map[string]interface{} `                               json:"metadata,omitempty"`          // Replaces the metadata
+	CustomFields    []CustomFieldRequest   `binding:"omitempty,max=10,dive" json:"custom_fields,omitempty"`    // Replaces the custom fields; [] removes them
+	SettlementSplit []SplitShareRequest    `binding:"omitempty,max=10,dive" json:"settlement_split,omitempty"` // Replaces the split; [] removes it
+	PassFee         *bool                  `                               json:"pass_fee,omitempty"`          // Whether the customer pays the platform fee
+}
+
+// AmendInvoiceRequest represents the API request to replace a finalized invoice with an amended one.
+type AmendInvoiceRequest struct {
+	InvoiceChangesRequest
+	Reason string `json:"reason,omitempty"` // Cancellation reason recorded on the original invoice
+}
+
+// ExtendInvoiceRequest represents the API request to push back the expiry of an invoice.
+type ExtendInvoiceRequest struct {
+	ExtendBy int `binding:"required,min=1" json:"extend_by"` // Seconds to add to the expiry
 }
 
 // InvoiceItemRequest represents an invoice item in the request.
 type InvoiceItemRequest struct {
-	Name        string `binding:"required" json:"name"`
-	Description string `                   json:"description"`
-	Quantity    string `binding:"required" json:"quantity"`
-	UnitPrice   string `binding:"required" json:"unit_price"`
+	Name        string           `binding:"required" json:"name"`
+	Description string           `                   json:"description"`
+	Quantity    string           `binding:"required" json:"quantity"`
+	UnitPrice   string           `binding:"required" json:"unit_price"`
+	Discount    *DiscountRequest `                   json:"discount,omitempty"`
+	TaxCategory string           `                   json:"tax_category,omitempty"` // Selects the merchant's tax rules, defaults to "standard"
+}
+
+// DiscountRequest represents a fixed or percentage discount in the request.
+type DiscountRequest struct {
+	Type  string `binding:"required,oneof=fixed percentage" json:"type"`
+	Value string `binding:"required"                        json:"value"` // Amount in the invoice currency, or 0-100 percent
+}
+
+// CustomFieldRequest represents a labelled value shown to customers on the checkout page and receipts.
+type CustomFieldRequest struct {
+	Label      string `binding:"required,max=40"                      json:"label"`
+	Value      string `binding:"required,max=200"                     json:"value"`
+	Type       string `binding:"omitempty,oneof=text number date url" json:"type,omitempty"`       // Defaults to "text"
+	Visibility string `binding:"omitempty,oneof=all checkout receipt" json:"visibility,omitempty"` // Defaults to "all"
+}
+
+// SplitShareRequest represents a beneficiary merchant's share of what an invoice settles for.
+type SplitShareRequest struct {
+	BeneficiaryID string `binding:"required"                        json:"beneficiary_id"` // Merchant receiving the share
+	Type          string `binding:"required,oneof=percentage fixed" json:"type"`
+	Value         string `binding:"required"                        json:"value"` // Invoice currency amount, or percent below 100
+}
+
+// SplitShareResponse represents a beneficiary merchant's share of an invoice's settlement.
+type SplitShareResponse struct {
+	BeneficiaryID string `json:"beneficiary_id"`
+	Type          string `json:"type"`
+	Value         string `json:"value"`
+}
+
+// CustomFieldResponse represents a custom field of an invoice.
+type CustomFieldResponse struct {
+	Label      string `json:"label"`
+	Value      string `json:"value"`
+	Type       string `json:"type"`
+	Visibility string `json:"visibility"`
+}
+
+// DiscountResponse represents a granted discount and the amount it took off.
+type DiscountResponse struct {
+	Type   string `json:"type"`
+	Value  string `json:"value"`
+	Amount string `json:"amount"`
+}
+
+// AppliedCouponResponse represents the coupon applied to an invoice.
+type AppliedCouponResponse struct {
+	Code   string `json:"code"`
+	Type   string `json:"type"`
+	Value  string `json:"value"`
+	Amount string `json:"amount"`
+}
+
+// TaxLineResponse represents a tax levied on an invoice item, or the sum of one tax across the invoice.
+type TaxLineResponse struct {
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Jurisdiction  string `json:"jurisdiction"`
+	Rate          string `json:"rate"`
+	TaxableAmount string `json:"taxable_amount"`
+	Amount        string `json:"amount"`
+	ReverseCharge bool   `json:"reverse_charge,omitempty"`
+}
+
+// InvoiceTaxResponse represents the per-rate tax summary of an invoice taxed by jurisdiction rules.
+type InvoiceTaxResponse struct {
+	Jurisdiction  string            `json:"jurisdiction"`
+	CustomerTaxID string            `json:"customer_tax_id,omitempty"`
+	ReverseCharge bool              `json:"reverse_charge"`
+	Note          string            `json:"note,omitempty"`
+	Lines         []TaxLineResponse `json:"lines"`
+}
+
+// DiscountBreakdownResponse represents where the discounts on an invoice came from.
+type DiscountBreakdownResponse struct {
+	Items   string                 `json:"items"` // Sum of the line item discounts, already deducted from the subtotal
+	Invoice *DiscountResponse      `json:"invoice,omitempty"`
+	Coupon  *AppliedCouponResponse `json:"coupon,omitempty"`
 }
 
 // PaymentToleranceRequest represents payment tolerance settings.
@@ -54,31 +216,71 @@ type PaymentToleranceResponse struct {
 // CreateInvoiceResponse represents the response payload for creating an invoice.
 type CreateInvoiceResponse struct {
 	ID             string                `json:"id"`
+	Number         string                `json:"number,omitempty"` // Accounting number, assigned once the invoice is issued
 	Items          []InvoiceItemResponse `json:"items"`
 	Subtotal       string                `json:"subtotal"`
+	DiscountAmount string                `json:"discount_amount"`
 	TaxAmount      string                `json:"tax_amount"`
+	FeeAmount      string                `json:"fee_amount,omitempty"`     // Platform fee the customer pays on top
+	FeePercentage  string                `json:"fee_percentage,omitempty"` // Fee percentage quoted for the invoice
 	Total          string                `json:"total"`
 	TaxRate        string                `json:"tax_rate"`
 	Status         string                `json:"status"`
+	Type           string                `json:"type"`
+	MinimumAmount  *string               `json:"minimum_amount,omitempty"` // Donation minimum in the invoice currency
+	AmountReceived string                `json:"amount_received"`          // Cumulative amount received in the cryptocurrency
 	PaymentAddress *string               `json:"payment_address,omitempty"`
-	InvoiceURL     string                `json:"invoice_url"`
-	CreatedAt      time.Time             `json:"created_at"`
+	Network        string                `json:"network,omitempty"` // Network the payment address is on
+	// Reference telling apart the invoices paid to one address, and the memo customers attach on networks
+	// whose transfers carry one; elsewhere usdt_amount is fingerprinted with the reference instead
+	PaymentReference string    `json:"payment_reference,omitempty"`
+	Memo             string    `json:"memo,omitempty"`
+	InvoiceURL       string    `json:"invoice_url"`
+	CreatedAt        time.Time `json:"created_at"`
 	// API.md required fields
 	USDTAmount  string    `json:"usdt_amount"`
 	Address     string    `json:"address"`
 	CustomerURL string    `json:"customer_url"`
 	ExpiresAt   time.Time `json:"expires_at"`
+	ExpiresIn   *int      `json:"expires_in,omitempty"` // Seconds a draft stays payable once finalized
 	// Payment tolerance settings
 	PaymentTolerance *PaymentToleranceResponse `json:"payment_tolerance,omitempty"`
+	// Discount breakdown, present when any discount or coupon applies
+	Discounts *DiscountBreakdownResponse `json:"discounts,omitempty"`
+	// Tax summary, present when the invoice was taxed by jurisdiction rules
+	Taxes *InvoiceTaxResponse `json:"taxes,omitempty"`
+	// Refund breakdown, present once the invoice is paid
+	Refunds *InvoiceRefundsResponse `json:"refunds,omitempty"`
+	// Where refunds are sent, present once a sender was captured or the customer supplied an address
+	RefundDestination *RefundDestinationResponse `json:"refund_destination,omitempty"`
+	// Exchange rate lock; the rate is re-quoted when it expires before the invoice is paid
+	RateExpiresAt time.Time         `json:"rate_expires_at"`
+	Requotes      []RequoteResponse `json:"requotes,omitempty"`
+	// How payments arriving after the rate expired were handled
+	StaleRateChecks []StaleRateCheckResponse `json:"stale_rate_checks,omitempty"`
+	// Confirmed payments taken back because a deep chain reorganization removed them
+	PaymentReversals []PaymentReversalResponse `json:"payment_reversals,omitempty"`
+	// Amendment chain: the invoice this one replaced, and the invoice that replaced it
+	Supersedes   *string `json:"supersedes,omitempty"`
+	SupersededBy *string `json:"superseded_by,omitempty"`
+	// Labelled values shown to customers, whatever their visibility
+	CustomFields []CustomFieldResponse `json:"custom_fields,omitempty"`
+	// Beneficiary merchants' shares of the settlement; the merchant keeps the rest
+	SettlementSplit []SplitShareResponse `json:"settlement_split,omitempty"`
+	// Refused attempts to view the checkout from countries the merchant restricts, when fetched one by one
+	GeoBlocks []GeoBlockResponse `json:"geo_blocks,omitempty"`
 }
 
 // InvoiceItemResponse represents an invoice item in the response.
 type InvoiceItemResponse struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	UnitPrice   string `json:"unit_price"`
-	Quantity    string `json:"quantity"`
-	Total       string `json:"total"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	UnitPrice   string            `json:"unit_price"`
+	Quantity    string            `json:"quantity"`
+	Discount    *DiscountResponse `json:"discount,omitempty"`
+	Total       string            `json:"total"`
+	TaxCategory string            `json:"tax_category,omitempty"`
+	TaxLines    []TaxLineResponse `json:"tax_lines,omitempty"`
 }
 
 // TokenRequest represents the request payload for generating JWT tokens.
@@ -97,28 +299,88 @@ type TokenResponse struct {
 	Scope       []string `json:"scope"`
 }
 
+// SessionTokenResponse represents the response payload for dashboard login and refresh.
+type SessionTokenResponse struct {
+	AccessToken      string       `json:"access_token"`
+	TokenType        string       `json:"token_type"`
+	ExpiresIn        int64        `json:"expires_in"`
+	RefreshToken     string       `json:"refresh_token"`
+	RefreshExpiresAt time.Time    `json:"refresh_expires_at"`
+	User             UserResponse `json:"user"`
+}
+
 // PublicInvoiceResponse represents the public invoice data for customers.
 type PublicInvoiceResponse struct {
-	ID              string                   `json:"id"`
-	Title           string                   `json:"title"`
-	Description     string                   `json:"description"`
-	Items           []InvoiceItemResponse    `json:"items"`
-	Subtotal        string                   `json:"subtotal"`
-	TaxAmount       string                   `json:"tax_amount"`
-	Total           string                   `json:"total"`
-	Currency        string                   `json:"currency"`
-	CryptoCurrency  string                   `json:"crypto_currency"`
-	USDTAmount      string                   `json:"usdt_amount"`
-	Address         string                   `json:"address"`
-	Status          string                   `json:"status"`
-	ExpiresAt       time.Time                `json:"expires_at"`
-	CreatedAt       time.Time                `json:"created_at"`
-	PaidAt          *time.Time               `json:"paid_at,omitempty"`
-	Payments        []PublicPaymentResponse  `json:"payments,omitempty"`
-	PaymentProgress *PaymentProgressResponse `json:"payment_progress,omitempty"`
-	ReturnURL       *string                  `json:"return_url,omitempty"`
-	CancelURL       *string                  `json:"cancel_url,omitempty"`
-	TimeRemaining   int64                    `json:"time_remaining,omitempty"`
+	ID              string                     `json:"id"`
+	Number          string                     `json:"number,omitempty"` // Accounting number of the issued invoice
+	Title           string                     `json:"title"`
+	Description     string                     `json:"description"`
+	Type            string                     `json:"type"`
+	MinimumAmount   *string                    `json:"minimum_amount,omitempty"` // Donations accept any amount at or above this
+	Items           []InvoiceItemResponse      `json:"items"`
+	Subtotal        string                     `json:"subtotal"`
+	DiscountAmount  string                     `json:"discount_amount"`
+	Discounts       *DiscountBreakdownResponse `json:"discounts,omitempty"`
+	TaxAmount       string                     `json:"tax_amount"`
+	Taxes           *InvoiceTaxResponse        `json:"taxes,omitempty"`
+	FeeAmount       string                     `json:"fee_amount,omitempty"` // Platform fee added on top of the price
+	Total           string                     `json:"total"`
+	Currency        string                     `json:"currency"`
+	CryptoCurrency  string                     `json:"crypto_currency"`
+	Network         string                     `json:"network,omitempty"`
+	USDTAmount      string                     `json:"usdt_amount"`
+	Address         string                     `json:"address"`
+	Memo            string                     `json:"memo,omitempty"` // Memo to attach to the transfer, on networks with memos
+	Status          string                     `json:"status"`
+	ExpiresAt       time.Time                  `json:"expires_at"`
+	CreatedAt       time.Time                  `json:"created_at"`
+	PaidAt          *time.Time                 `json:"paid_at,omitempty"`
+	Payments        []PublicPaymentResponse    `json:"payments,omitempty"`
+	PaymentProgress *PaymentProgressResponse   `json:"payment_progress,omitempty"`
+	ReturnURL       *string                    `json:"return_url,omitempty"` // With a checkout_token when redirect keys are configured
+	CancelURL       *string                    `json:"cancel_url,omitempty"` // With a checkout_token when redirect keys are configured
+	TimeRemaining   int64                      `json:"time_remaining,omitempty"`
+	RateExpiresAt   time.Time                  `json:"rate_expires_at"`
+	Requotes        []RequoteResponse          `json:"requotes,omitempty"`
+	Currencies      []CurrencyResponse         `json:"accepted_currencies,omitempty"` // Offered while unpaid
+	CheckoutOptions []CheckoutOptionResponse   `json:"checkout_options,omitempty"`    // Payment processors to pay through
+	SupersededBy    *string                    `json:"superseded_by,omitempty"`       // Replacement invoice to pay instead
+	CustomFields    []CustomFieldResponse      `json:"custom_fields,omitempty"`       // Fields the merchant shows on the checkout page
+}
+
+// RedirectKeysResponse represents the public keys redirect tokens are verified with, as a JSON Web Key Set.
+type RedirectKeysResponse struct {
+	Keys []RedirectKeyResponse `json:"keys"`
+}
+
+// RedirectKeyResponse represents an Ed25519 public key as a JSON Web Key.
+type RedirectKeyResponse struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	X         string `json:"x"` // Base64url-encoded public key
+}
+
+// WidgetStateResponse represents what the embedded payment widget shows of an invoice.
+type WidgetStateResponse struct {
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Status         string     `json:"status"`
+	Total          string     `json:"total"`
+	Currency       string     `json:"currency"`
+	CryptoCurrency string     `json:"crypto_currency"`
+	Network        string     `json:"network,omitempty"`
+	Amount         string     `json:"amount"` // Amount to send, in CryptoCurrency
+	Address        string     `json:"address"`
+	Memo           string     `json:"memo,omitempty"`
+	QRCodeURL      string     `json:"qr_code_url"`  // Relative to the API
+	CheckoutURL    string     `json:"checkout_url"` // Hosted checkout to fall back to, relative to the API
+	ExpiresAt      time.Time  `json:"expires_at"`
+	TimeRemaining  int64      `json:"time_remaining,omitempty"`
+	PaidAt         *time.Time `json:"paid_at,omitempty"`
+	ReturnURL      *string    `json:"return_url,omitempty"`
 }
 
 // PublicPaymentResponse represents payment data visible to customers.
@@ -137,28 +399,113 @@ type PaymentProgressResponse struct {
 	Percent   float64 `json:"percent"`
 }
 
-// PublicInvoiceStatusResponse represents a simple status response.
+// PublicInvoiceStatusResponse is the invoice state customer-facing clients poll. The countdowns are counted
+// from Timestamp.
 type PublicInvoiceStatusResponse struct {
-	ID        string    `json:"id"`
-	Status    string    `json:"status"`
-	Timestamp time.Time `json:"timestamp"`
+	ID                 string                   `json:"id"`
+	Status             string                   `json:"status"`
+	ProgressPercentage float64                  `json:"progress_percentage"` // Share of the amount due received, 0 to 100
+	RemainingAmount    string                   `json:"remaining_amount"`
+	Confirmations      int                      `json:"confirmations"`
+	ExpiresInSeconds   *int64                   `json:"expires_in_seconds,omitempty"` // Absent when the invoice does not expire or is final
+	NextPollAfter      *int64                   `json:"next_poll_after,omitempty"`    // Seconds to wait before polling again; absent once the invoice is final
+	ConfirmationETA    *ConfirmationETAResponse `json:"confirmation_eta,omitempty"`   // Set while payments confirm
+	Timestamp          time.Time                `json:"timestamp"`
+}
+
+// ToPublicInvoiceStatusResponse converts an invoice status summary to the response customer-facing clients poll,
+// as of now.
+func ToPublicInvoiceStatusResponse(summary *invoice.StatusSummary, now time.Time) PublicInvoiceStatusResponse {
+	response := PublicInvoiceStatusResponse{
+		ID:                 summary.InvoiceID,
+		Status:             summary.Status.String(),
+		ProgressPercentage: summary.ProgressPercentage().InexactFloat64(),
+		RemainingAmount:    summary.RemainingAmount().String(),
+		Confirmations:      summary.Confirmations,
+		Timestamp:          now.UTC(),
+	}
+	if summary.ExpiresAt != nil && summary.Status.IsActive() {
+		expiresIn := max(int64(math.Ceil(summary.ExpiresAt.Sub(now).Seconds())), 0)
+		response.ExpiresInSeconds = &expiresIn
+	}
+	return response
+}
+
+// ConfirmationETAResponse is the expected time until the last payment of an invoice still waiting for
+// confirmations is confirmed.
+type ConfirmationETAResponse struct {
+	Network                string    `json:"network"`
+	RemainingConfirmations int       `json:"remaining_confirmations"`
+	BlockTimeSeconds       float64   `json:"block_time_seconds"`
+	BlockTimeSource        string    `json:"block_time_source"` // observed or nominal
+	SecondsRemaining       int64     `json:"seconds_remaining"`
+	EstimatedAt            time.Time `json:"estimated_at"`
+}
+
+// ToConfirmationETAResponse converts a confirmation time estimate to its response.
+func ToConfirmationETAResponse(eta confirmation.ETA) *ConfirmationETAResponse {
+	source := "nominal"
+	if eta.Observed {
+		source = "observed"
+	}
+	return &ConfirmationETAResponse{
+		Network:                eta.Network.String(),
+		RemainingConfirmations: eta.Remaining,
+		BlockTimeSeconds:       eta.BlockTime.Seconds(),
+		BlockTimeSource:        source,
+		SecondsRemaining:       int64(math.Ceil(eta.Duration.Seconds())),
+		EstimatedAt:            eta.At,
+	}
 }
 
 // ListInvoicesRequest represents the request parameters for listing invoices.
+// Page is deprecated in favour of Cursor and is ignored when a cursor is given.
 type ListInvoicesRequest struct {
 	Page     int    `form:"page,default=1"   binding:"min=1"`
 	Limit    int    `form:"limit,default=20" binding:"min=1,max=100"`
+	Cursor   string `form:"cursor"`
 	Status   string `form:"status"`
 	Merchant string `form:"merchant"`
 }
 
 // ListInvoicesResponse represents the response for listing invoices.
 type ListInvoicesResponse struct {
-	Invoices []CreateInvoiceResponse `json:"invoices"`
-	Total    int                     `json:"total"`
-	Page     int                     `json:"page"`
-	Limit    int                     `json:"limit"`
-	Pages    int                     `json:"pages"`
+	Invoices   []CreateInvoiceResponse `json:"invoices"`
+	Total      int                     `json:"total"`
+	Page       int                     `json:"page"`
+	Limit      int                     `json:"limit"`
+	Pages      int                     `json:"pages"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+}
+
+// InvoiceStatusBatchRequest represents the request payload for reading the status of many invoices at once.
+type InvoiceStatusBatchRequest struct {
+	InvoiceIDs []string `binding:"required,min=1,max=500,dive,required" json:"invoice_ids"`
+}
+
+// InvoiceStatusBatchResponse maps invoice IDs to their payment state.
+// Requested IDs that match no invoice of the merchant are listed in NotFound.
+type InvoiceStatusBatchResponse struct {
+	Invoices map[string]InvoiceStatusSummaryResponse `json:"invoices"`
+	NotFound []string                                `json:"not_found"`
+}
+
+// InvoiceStatusSummaryResponse represents the payment state of an invoice in a status batch.
+type InvoiceStatusSummaryResponse struct {
+	Status          string `json:"status"`
+	PaidAmount      string `json:"paid_amount"`
+	RemainingAmount string `json:"remaining_amount"`
+	Confirmations   int    `json:"confirmations"`
+}
+
+// ToInvoiceStatusSummaryResponse converts an invoice status summary to its response form.
+func ToInvoiceStatusSummaryResponse(summary *invoice.StatusSummary) InvoiceStatusSummaryResponse {
+	return InvoiceStatusSummaryResponse{
+		Status:          summary.Status.String(),
+		PaidAmount:      summary.AmountPaid.String(),
+		RemainingAmount: summary.RemainingAmount().String(),
+		Confirmations:   summary.Confirmations,
+	}
 }
 
 // CancelInvoiceRequest represents the request payload for cancelling an invoice.
@@ -166,6 +513,54 @@ type CancelInvoiceRequest struct {
 	Reason string `binding:"required" json:"reason"`
 }
 
+// RefundInvoiceRequest represents the request payload for refunding an invoice.
+type RefundInvoiceRequest struct {
+	Amount string `json:"amount"` // Amount in the invoice cryptocurrency; empty refunds the remaining amount
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// RefundInvoiceResponse represents the response payload for refunding an invoice.
+type RefundInvoiceResponse struct {
+	InvoiceID string                  `json:"invoice_id"`
+	Status    string                  `json:"status"`
+	Refund    InvoiceRefundResponse   `json:"refund"`
+	Refunds   *InvoiceRefundsResponse `json:"refunds"`
+}
+
+// InvoiceRefundsResponse represents the refund breakdown of a paid invoice.
+type InvoiceRefundsResponse struct {
+	AmountPaid       string                  `json:"amount_paid"`
+	RefundedAmount   string                  `json:"refunded_amount"`
+	RefundableAmount string                  `json:"refundable_amount"`
+	Items            []InvoiceRefundResponse `json:"items"`
+}
+
+// InvoiceRefundResponse represents a single refund of an invoice.
+type InvoiceRefundResponse struct {
+	ID          string    `json:"id"`
+	Amount      string    `json:"amount"`
+	Reason      string    `json:"reason,omitempty"`
+	RequestedBy string    `json:"requested_by,omitempty"`
+	Destination string    `json:"destination,omitempty"` // Address the refund is sent to
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// RefundDestinationResponse represents where refunds of an invoice are sent.
+type RefundDestinationResponse struct {
+	Address     string     `json:"address"`
+	Source      string     `json:"source"`   // "sender" of the confirmed payments, or "customer" on the checkout page
+	Verified    bool       `json:"verified"` // The customer signed a message with the address
+	CapturedAt  time.Time  `json:"captured_at"`
+	Confirmed   bool       `json:"confirmed"` // Refunds are only sent once the merchant confirms the address
+	ConfirmedBy string     `json:"confirmed_by,omitempty"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+}
+
+// ConfirmRefundDestinationRequest represents the request payload for confirming an invoice's refund address.
+type ConfirmRefundDestinationRequest struct {
+	Address string `json:"address" binding:"required,max=128"` // Must match the proposed refund address
+}
+
 // CancelInvoiceResponse represents the response payload for cancelling an invoice.
 type CancelInvoiceResponse struct {
 	ID          string    `json:"id"`
@@ -187,6 +582,10 @@ type AnalyticsResponse struct {
 	Revenue  AnalyticsRevenue  `json:"revenue"`
 	Invoices AnalyticsInvoices `json:"invoices"`
 	Payments AnalyticsPayments `json:"payments"`
+	// Conversion of the checkout variants of the merchant's experiments, newest experiment first
+	Experiments []ExperimentResultsResponse `json:"experiments,omitempty"`
+	// Checkout page funnel of the merchant's invoices first viewed between the start and end dates
+	Checkout *CheckoutFunnelResponse `json:"checkout,omitempty"`
 }
 
 // AnalyticsSummary represents summary analytics data.
@@ -265,8 +664,36 @@ type WebhookEndpointResponse struct {
 	Timeout      int               `json:"timeout"`
 	AllowedIPs   []string          `json:"allowed_ips,omitempty"`
 	Headers      map[string]string `json:"headers,omitempty"`
+	APIVersion   string            `json:"api_version"`
 	CreatedAt    time.Time         `json:"created_at"`
 	UpdatedAt    time.Time         `json:"updated_at"`
+	// Until when the secret rotated out signs webhooks as well; absent outside a rotation's overlap
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty"`
+}
+
+// ToWebhookEndpointResponse converts a domain webhook endpoint to a webhook endpoint response.
+func ToWebhookEndpointResponse(endpoint *merchant.WebhookEndpoint) WebhookEndpointResponse {
+	response := WebhookEndpointResponse{
+		ID:           endpoint.ID(),
+		MerchantID:   endpoint.MerchantID(),
+		URL:          endpoint.URL(),
+		Events:       endpoint.Events(),
+		Secret:       endpoint.Secret(),
+		Status:       string(endpoint.Status()),
+		MaxRetries:   endpoint.MaxRetries(),
+		RetryBackoff: string(endpoint.RetryBackoff()),
+		Timeout:      endpoint.Timeout(),
+		AllowedIPs:   endpoint.AllowedIPs(),
+		Headers:      endpoint.Headers(),
+		APIVersion:   endpoint.APIVersion().String(),
+		CreatedAt:    endpoint.CreatedAt(),
+		UpdatedAt:    endpoint.UpdatedAt(),
+	}
+	if endpoint.PreviousSecret() != "" {
+		expiresAt := endpoint.PreviousSecretExpiresAt()
+		response.PreviousSecretExpiresAt = &expiresAt
+	}
+	return response
 }
 
 // ErrorResponse represents an error response payload.
@@ -288,8 +715,10 @@ func ToCreateInvoiceResponse(inv *invoice.Invoice) CreateInvoiceResponse {
 			Description: item.Description(),
 			UnitPrice:   item.UnitPrice().String(),
 			Quantity:    item.Quantity().String(),
+			Discount:    ToItemDiscountResponse(item),
 			Total:       item.TotalPrice().String(),
 		}
+		setItemTaxResponse(&items[i], item)
 	}
 
 	var paymentAddress *string
@@ -302,17 +731,32 @@ func ToCreateInvoiceResponse(inv *invoice.Invoice) CreateInvoiceResponse {
 	address := "TQn9Y2khEsLMWn1aXKURNC62XLFPqpTUcN" // TODO: Generate real crypto address
 	if paymentAddress != nil {
 		address = *paymentAddress
+	} else if inv.IsDraft() {
+		address = ""
 	}
 
 	// Construct customer URL
-	customerURL := "https://checkout.thecryptocheckout.com/invoice/" + inv.ID()
+	customerURL := checkoutBaseURL + "/invoice/" + inv.ID()
 
-	// Get expiration time
+	// Get expiration time; a draft only has the window it stays payable for once finalized
 	var expiresAt time.Time
-	if exp := inv.Expiration(); exp != nil {
+	var expiresIn *int
+	if exp := inv.Expiration(); exp != nil && inv.IsDraft() {
+		seconds := int(exp.Duration() / time.Second)
+		expiresIn = &seconds
+	} else if exp != nil {
 		expiresAt = exp.ExpiresAt()
 	}
 
+	// Drafts are not quoted until they are finalized
+	cryptoAmount := toCryptoAmount(inv)
+	var rateExpiresAt time.Time
+	if rate := inv.ExchangeRate(); rate != nil {
+		rateExpiresAt = rate.ExpiresAt()
+	} else {
+		cryptoAmount = ""
+	}
+
 	// Get payment tolerance settings
 	var paymentTolerance *PaymentToleranceResponse
 	if pt := inv.PaymentTolerance(); pt != nil {
@@ -324,22 +768,3846 @@ func ToCreateInvoiceResponse(inv *invoice.Invoice) CreateInvoiceResponse {
 	}
 
 	return CreateInvoiceResponse{
-		ID:             inv.ID(),
-		Items:          items,
-		Subtotal:       inv.Pricing().Subtotal().String(),
-		TaxAmount:      inv.Pricing().Tax().String(),
-		Total:          inv.Pricing().Total().String(),
-		TaxRate:        inv.Pricing().Tax().Amount().String(),
-		Status:         inv.Status().String(),
-		PaymentAddress: paymentAddress,
-		InvoiceURL:     "/api/v1/invoices/" + inv.ID(),
-		CreatedAt:      inv.CreatedAt(),
+		ID:               inv.ID(),
+		Number:           inv.Number(),
+		Items:            items,
+		Subtotal:         inv.Pricing().Subtotal().String(),
+		DiscountAmount:   inv.Pricing().Discount().String(),
+		TaxAmount:        inv.Pricing().Tax().String(),
+		FeeAmount:        toFeeAmount(inv),
+		FeePercentage:    toFeePercentage(inv),
+		Total:            inv.Pricing().Total().String(),
+		TaxRate:          inv.Pricing().Tax().Amount().String(),
+		Status:           inv.Status().String(),
+		Type:             inv.Type().String(),
+		MinimumAmount:    toMinimumAmount(inv),
+		AmountReceived:   toAmountReceived(inv),
+		PaymentAddress:   paymentAddress,
+		Network:          toNetwork(inv),
+		PaymentReference: toPaymentReference(inv),
+		Memo:             inv.PaymentMemo(),
+		InvoiceURL:       "/api/v1/invoices/" + inv.ID(),
+		CreatedAt:        inv.CreatedAt(),
 		// API.md required fields
-		USDTAmount:  inv.Pricing().Total().String(), // 1:1 USD to USDT for now
+		USDTAmount:  cryptoAmount,
 		Address:     address,
 		CustomerURL: customerURL,
 		ExpiresAt:   expiresAt,
+		ExpiresIn:   expiresIn,
 		// Payment tolerance settings
-		PaymentTolerance: paymentTolerance,
+		PaymentTolerance:  paymentTolerance,
+		Discounts:         ToDiscountBreakdownResponse(inv),
+		Taxes:             ToInvoiceTaxResponse(inv),
+		Refunds:           ToInvoiceRefundsResponse(inv),
+		RefundDestination: ToRefundDestinationResponse(inv.RefundDestination()),
+		RateExpiresAt:     rateExpiresAt,
+		Requotes:          ToRequoteResponses(inv.Requotes()),
+		StaleRateChecks:   ToStaleRateCheckResponses(inv.StaleRateChecks()),
+		PaymentReversals:  ToPaymentReversalResponses(inv.PaymentReversals()),
+		Supersedes:        inv.Supersedes(),
+		SupersededBy:      inv.SupersededBy(),
+		CustomFields:      ToCustomFieldResponses(inv.CustomFields()),
+		SettlementSplit:   ToSplitShareResponses(inv.SettlementSplit()),
+	}
+}
+
+// toFeeAmount returns the platform fee an invoice passes through to the customer, or an empty string when the
+// merchant absorbs it.
+func toFeeAmount(inv *invoice.Invoice) string {
+	if inv.FeePassThrough() == nil {
+		return ""
+	}
+	return inv.Pricing().Fee().String()
+}
+
+// toFeePercentage returns the fee percentage quoted for an invoice passing the fee through, or an empty string.
+func toFeePercentage(inv *invoice.Invoice) string {
+	if fee := inv.FeePassThrough(); fee != nil {
+		return fee.Percentage().String()
+	}
+	return ""
+}
+
+// ToSplitShareResponses converts an invoice's settlement split to its response form, or returns nil when the
+// invoice is not split.
+func ToSplitShareResponses(split *invoice.SettlementSplit) []SplitShareResponse {
+	if split == nil {
+		return nil
+	}
+	responses := make([]SplitShareResponse, len(split.Shares()))
+	for i, share := range split.Shares() {
+		responses[i] = SplitShareResponse{
+			BeneficiaryID: share.BeneficiaryID(),
+			Type:          string(share.Type()),
+			Value:         share.Value().String(),
+		}
+	}
+	return responses
+}
+
+// ToCustomFieldResponses converts custom fields to their response form, or returns nil when there are none.
+func ToCustomFieldResponses(fields []*invoice.CustomField) []CustomFieldResponse {
+	if len(fields) == 0 {
+		return nil
+	}
+	responses := make([]CustomFieldResponse, len(fields))
+	for i, field := range fields {
+		responses[i] = CustomFieldResponse{
+			Label:      field.Label(),
+			Value:      field.Value(),
+			Type:       string(field.Type()),
+			Visibility: string(field.Visibility()),
+		}
+	}
+	return responses
+}
+
+// reverseChargeNote is the statement VAT invoices must carry when the customer accounts for the tax.
+const reverseChargeNote = "Reverse charge: the customer is liable to account for the tax"
+
+// ToTaxLineResponses converts tax lines to their response form.
+func ToTaxLineResponses(lines []*invoice.TaxLine) []TaxLineResponse {
+	responses := make([]TaxLineResponse, len(lines))
+	for i, line := range lines {
+		responses[i] = TaxLineResponse{
+			Name:          line.Rate().Name(),
+			Type:          line.Rate().Type().String(),
+			Jurisdiction:  line.Rate().Jurisdiction(),
+			Rate:          line.Rate().Rate().String(),
+			TaxableAmount: line.TaxableAmount().String(),
+			Amount:        line.Amount().String(),
+			ReverseCharge: line.Rate().IsReverseCharge(),
+		}
+	}
+	return responses
+}
+
+// ToInvoiceTaxResponse converts the tax summary of an invoice, or returns nil for a flat tax.
+func ToInvoiceTaxResponse(inv *invoice.Invoice) *InvoiceTaxResponse {
+	treatment := inv.TaxTreatment()
+	if treatment == nil {
+		return nil
+	}
+
+	response := &InvoiceTaxResponse{
+		Jurisdiction:  treatment.Jurisdiction(),
+		CustomerTaxID: treatment.CustomerTaxID(),
+		ReverseCharge: treatment.IsReverseCharge(),
+		Lines:         ToTaxLineResponses(inv.TaxSummary()),
+	}
+	if treatment.IsReverseCharge() {
+		response.Note = reverseChargeNote
+	}
+	return response
+}
+
+// setItemTaxResponse adds the tax category and tax lines of an item taxed by jurisdiction rules.
+func setItemTaxResponse(response *InvoiceItemResponse, item *invoice.InvoiceItem) {
+	if len(item.TaxLines()) == 0 && item.TaxCategory() == invoice.DefaultTaxCategory {
+		return
+	}
+	response.TaxCategory = item.TaxCategory()
+	response.TaxLines = ToTaxLineResponses(item.TaxLines())
+}
+
+// ToItemDiscountResponse converts the discount of an invoice item, or returns nil if it has none.
+func ToItemDiscountResponse(item *invoice.InvoiceItem) *DiscountResponse {
+	discount := item.Discount()
+	if discount == nil {
+		return nil
+	}
+	return &DiscountResponse{
+		Type:   discount.Type().String(),
+		Value:  discount.Value().String(),
+		Amount: item.DiscountAmount().String(),
+	}
+}
+
+// ToDiscountBreakdownResponse converts the discounts of an invoice, or returns nil if it has none.
+func ToDiscountBreakdownResponse(inv *invoice.Invoice) *DiscountBreakdownResponse {
+	itemDiscount := inv.ItemDiscountAmount()
+	if itemDiscount.IsZero() && inv.Discount() == nil && inv.Coupon() == nil {
+		return nil
+	}
+
+	breakdown := &DiscountBreakdownResponse{Items: itemDiscount.String()}
+	if discount := inv.Discount(); discount != nil {
+		breakdown.Invoice = &DiscountResponse{
+			Type:  discount.Type().String(),
+			Value: discount.Value().String(),
+		}
+		if amount, err := inv.InvoiceDiscountAmount(); err == nil {
+			breakdown.Invoice.Amount = amount.String()
+		}
+	}
+	if applied := inv.Coupon(); applied != nil {
+		breakdown.Coupon = &AppliedCouponResponse{
+			Code:  applied.Code(),
+			Type:  applied.Discount().Type().String(),
+			Value: applied.Discount().Value().String(),
+		}
+		if amount, err := inv.CouponDiscountAmount(); err == nil {
+			breakdown.Coupon.Amount = amount.String()
+		}
+	}
+	return breakdown
+}
+
+// UserResponse represents a merchant team member.
+type UserResponse struct {
+	ID          string    `json:"id"`
+	MerchantID  string    `json:"merchant_id"`
+	Email       string    `json:"email"`
+	Name        string    `json:"name"`
+	Role        string    `json:"role"`
+	Status      string    `json:"status"`
+	Permissions []string  `json:"permissions"`
+	TwoFactor   bool      `json:"two_factor_enabled"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// InvitationResponse represents a team invitation.
+type InvitationResponse struct {
+	ID         string     `json:"id"`
+	MerchantID string     `json:"merchant_id"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role"`
+	Status     string     `json:"status"`
+	InvitedBy  string     `json:"invited_by,omitempty"`
+	Token      string     `json:"token,omitempty"` // Only returned once during creation
+	ExpiresAt  time.Time  `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+}
+
+// ListTeamUsersResponse represents the response for listing a merchant's team members.
+type ListTeamUsersResponse struct {
+	Users []UserResponse `json:"users"`
+	Total int            `json:"total"`
+}
+
+// ListInvitationsResponse represents the response for listing a merchant's team invitations.
+type ListInvitationsResponse struct {
+	Invitations []InvitationResponse `json:"invitations"`
+	Total       int                  `json:"total"`
+}
+
+// ListAuditLogsRequest represents the query parameters for listing audit log entries.
+type ListAuditLogsRequest struct {
+	ActorID      string     `form:"actor_id"`
+	ActorType    string     `form:"actor_type"       binding:"omitempty,oneof=user api_key system"`
+	Action       string     `form:"action"`
+	ResourceType string     `form:"resource_type"`
+	ResourceID   string     `form:"resource_id"`
+	RequestID    string     `form:"request_id"`
+	From         *time.Time `form:"from"             time_format:"2006-01-02T15:04:05Z07:00"`
+	To           *time.Time `form:"to"               time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit        int        `form:"limit,default=20" binding:"min=1,max=100"`
+	Cursor       string     `form:"cursor"`
+	Offset       int        `form:"offset"           binding:"min=0"` // Deprecated: use Cursor
+}
+
+// ListAuditLogsResponse represents the response for listing audit log entries.
+type ListAuditLogsResponse struct {
+	Entries    []AuditLogResponse `json:"entries"`
+	Total      int                `json:"total"`
+	Limit      int                `json:"limit"`
+	Offset     int                `json:"offset"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+// AuditLogResponse represents an audit log entry.
+type AuditLogResponse struct {
+	ID           string                 `json:"id"`
+	MerchantID   string                 `json:"merchant_id"`
+	Actor        audit.Actor            `json:"actor"`
+	IPAddress    string                 `json:"ip_address,omitempty"`
+	RequestID    string                 `json:"request_id,omitempty"`
+	UserAgent    string                 `json:"user_agent,omitempty"`
+	Action       string                 `json:"action"`
+	ResourceType string                 `json:"resource_type,omitempty"`
+	ResourceID   string                 `json:"resource_id,omitempty"`
+	Before       map[string]interface{} `json:"before,omitempty"`
+	After        map[string]interface{} `json:"after,omitempty"`
+	Details      map[string]interface{} `json:"details,omitempty"`
+	OccurredAt   time.Time              `json:"occurred_at"`
+}
+
+// ListComplianceReviewsRequest represents the query parameters for listing payment screenings.
+type ListComplianceReviewsRequest struct {
+	Status string `form:"status"           binding:"omitempty,oneof=cleared held approved rejected"`
+	Limit  int    `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset int    `form:"offset"           binding:"min=0"`
+}
+
+// ComplianceReviewDecisionRequest represents a reviewer's decision on a held payment.
+type ComplianceReviewDecisionRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+// ListComplianceReviewsResponse represents the response for listing payment screenings.
+type ListComplianceReviewsResponse struct {
+	Reviews []ScreeningResponse `json:"reviews"`
+	Total   int                 `json:"total"`
+	Limit   int                 `json:"limit"`
+	Offset  int                 `json:"offset"`
+}
+
+// ScreeningResponse represents the AML/KYT screening of a payment and its review.
+type ScreeningResponse struct {
+	ID         string     `json:"id"`
+	PaymentID  string     `json:"payment_id"`
+	InvoiceID  string     `json:"invoice_id"`
+	Address    string     `json:"address"`
+	Network    string     `json:"network,omitempty"`
+	Provider   string     `json:"provider"`
+	RiskLevel  string     `json:"risk_level"`
+	RiskScore  float64    `json:"risk_score"`
+	Categories []string   `json:"categories"`
+	Reference  string     `json:"reference,omitempty"`
+	Failure    string     `json:"failure,omitempty"`
+	Status     string     `json:"status"`
+	ReviewedBy string     `json:"reviewed_by,omitempty"`
+	ReviewNote string     `json:"review_note,omitempty"`
+	ScreenedAt time.Time  `json:"screened_at"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// PaymentDetailResponse is the full detail of a payment, with the chain data merchants reconcile it with.
+type PaymentDetailResponse struct {
+	ID                    string                 `json:"id"`
+	Status                string                 `json:"status"`
+	Amount                string                 `json:"amount"`
+	CryptoCurrency        string                 `json:"crypto_currency"`
+	Network               string                 `json:"network"`
+	TransactionHash       string                 `json:"tx_hash"`
+	FromAddresses         []string               `json:"from_addresses"`
+	ToAddress             string                 `json:"to_address"`
+	BlockNumber           *int64                 `json:"block_number,omitempty"` // Set once the transaction is in a block
+	BlockHash             string                 `json:"block_hash,omitempty"`
+	Confirmations         int                    `json:"confirmations"`
+	RequiredConfirmations int                    `json:"required_confirmations"`
+	NetworkFee            string                 `json:"network_fee,omitempty"`
+	NetworkFeeCurrency    string                 `json:"network_fee_currency,omitempty"`
+	Explorer              PaymentExplorerLinks   `json:"explorer"`
+	Screening             *ScreeningResponse     `json:"screening,omitempty"` // Absent when the sender was not screened
+	Invoice               PaymentInvoiceResponse `json:"invoice"`
+	DetectedAt            time.Time              `json:"detected_at"`
+	ConfirmedAt           *time.Time             `json:"confirmed_at,omitempty"`
+	CreatedAt             time.Time              `json:"created_at"`
+	UpdatedAt             time.Time              `json:"updated_at"`
+}
+
+// PaymentExplorerLinks are the pages of a payment's transaction, addresses and block on the block explorer of
+// its network.
+type PaymentExplorerLinks struct {
+	Transaction   string   `json:"transaction,omitempty"`
+	FromAddresses []string `json:"from_addresses,omitempty"`
+	ToAddress     string   `json:"to_address,omitempty"`
+	Block         string   `json:"block,omitempty"`
+}
+
+// PaymentInvoiceResponse is the invoice a payment pays.
+type PaymentInvoiceResponse struct {
+	ID             string `json:"id"`
+	Number         string `json:"number,omitempty"`
+	Status         string `json:"status"`
+	AmountReceived string `json:"amount_received"` // Cumulative amount received in the cryptocurrency
+}
+
+// ListReviewsRequest represents the query parameters for listing payment reviews.
+type ListReviewsRequest struct {
+	Kind   string `form:"kind"             binding:"omitempty,oneof=underpayment screening orphaned stale_rate"`
+	Status string `form:"status"           binding:"omitempty,oneof=open resolved"`
+	Limit  int    `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset int    `form:"offset"           binding:"min=0"`
+}
+
+// ResolveReviewRequest represents an operator's resolution of a payment review.
+type ResolveReviewRequest struct {
+	Action string `json:"action" binding:"required,oneof=accept_as_paid refund write_off"`
+	Note   string `json:"note"   binding:"max=1000"`
+}
+
+// ListReviewsResponse represents the response for listing payment reviews.
+type ListReviewsResponse struct {
+	Reviews []ReviewResponse `json:"reviews"`
+	Total   int              `json:"total"`
+	Limit   int              `json:"limit"`
+	Offset  int              `json:"offset"`
+}
+
+// ReviewResponse represents a payment awaiting or resolved by operator review.
+type ReviewResponse struct {
+	ID             string     `json:"id"`
+	Kind           string     `json:"kind"`
+	Status         string     `json:"status"`
+	InvoiceID      string     `json:"invoice_id"`
+	PaymentID      string     `json:"payment_id"`
+	ExpectedAmount string     `json:"expected_amount,omitempty"`
+	ReceivedAmount string     `json:"received_amount"`
+	Currency       string     `json:"currency"`
+	Reason         string     `json:"reason,omitempty"`
+	Resolution     string     `json:"resolution,omitempty"`
+	ResolvedBy     string     `json:"resolved_by,omitempty"`
+	Note           string     `json:"note,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+}
+
+// ListDepositsRequest represents the query parameters for listing unattributed deposits.
+type ListDepositsRequest struct {
+	Status string `form:"status"           binding:"omitempty,oneof=unmatched attached refunded"`
+	Limit  int    `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset int    `form:"offset"           binding:"min=0"`
+}
+
+// AttachDepositRequest represents a merchant's decision to credit a deposit to an invoice.
+type AttachDepositRequest struct {
+	InvoiceID string `json:"invoice_id" binding:"required"`
+}
+
+// RefundDepositRequest represents a merchant's decision to return a deposit to the sender.
+type RefundDepositRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+// ListDepositsResponse represents the response for listing unattributed deposits.
+type ListDepositsResponse struct {
+	Deposits []DepositResponse `json:"deposits"`
+	Total    int               `json:"total"`
+	Limit    int               `json:"limit"`
+	Offset   int               `json:"offset"`
+}
+
+// DepositResponse represents funds that arrived at the payment address of a closed invoice.
+type DepositResponse struct {
+	ID                string                 `json:"id"`
+	Status            string                 `json:"status"            example:"unmatched"`
+	Reason            string                 `json:"reason"            example:"invoice_expired"`
+	InvoiceID         string                 `json:"invoice_id"` // Closed invoice owning the receiving address
+	Network           string                 `json:"network"`
+	TransactionHash   string                 `json:"transaction_hash"`
+	ExplorerURL       string                 `json:"explorer_url,omitempty"` // Transaction on the block explorer
+	FromAddress       string                 `json:"from_address"`
+	ToAddress         string                 `json:"to_address"`
+	Amount            string                 `json:"amount"`
+	Currency          string                 `json:"currency"`
+	Memo              string                 `json:"memo,omitempty"`
+	BlockNumber       int64                  `json:"block_number"`
+	Confirmations     int                    `json:"confirmations"`
+	AttachedInvoiceID string                 `json:"attached_invoice_id,omitempty"`
+	PaymentID         string                 `json:"payment_id,omitempty"`
+	ResolvedBy        string                 `json:"resolved_by,omitempty"`
+	Matches           []DepositMatchResponse `json:"matches,omitempty"`
+	CreatedAt         time.Time              `json:"created_at"`
+	ResolvedAt        *time.Time             `json:"resolved_at,omitempty"`
+}
+
+// DepositMatchResponse represents an open invoice an unmatched deposit may belong to.
+type DepositMatchResponse struct {
+	InvoiceID string `json:"invoice_id"`
+	Number    string `json:"number,omitempty"`
+	Heuristic string `json:"heuristic"            example:"amount"`
+	AmountDue string `json:"amount_due,omitempty"`
+}
+
+// ListBlocklistRequest represents the query parameters for listing blocklist entries.
+type ListBlocklistRequest struct {
+	Source        string `form:"source"           binding:"omitempty,oneof=ofac custom"`
+	Address       string `form:"address"`
+	IncludeGlobal bool   `form:"include_global"`
+	Limit         int    `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset        int    `form:"offset"           binding:"min=0"`
+}
+
+// AddBlocklistEntryRequest represents the request payload for blocking an address.
+type AddBlocklistEntryRequest struct {
+	Address string `json:"address" binding:"required,max=128"`
+	Network string `json:"network" binding:"max=20"`
+	Reason  string `json:"reason"  binding:"max=500"`
+}
+
+// ListBlocklistResponse represents the response for listing blocklist entries.
+type ListBlocklistResponse struct {
+	Entries []BlocklistEntryResponse `json:"entries"`
+	Total   int                      `json:"total"`
+	Limit   int                      `json:"limit"`
+	Offset  int                      `json:"offset"`
+}
+
+// BlocklistCheckResponse represents the result of checking an address against the blocklist.
+type BlocklistCheckResponse struct {
+	Address string                  `json:"address"`
+	Blocked bool                    `json:"blocked"`
+	Entry   *BlocklistEntryResponse `json:"entry,omitempty"`
+}
+
+// BlocklistEntryResponse represents a blocked address.
+type BlocklistEntryResponse struct {
+	ID        string    `json:"id"`
+	Address   string    `json:"address"`
+	Network   string    `json:"network,omitempty"`
+	Source    string    `json:"source"`
+	Global    bool      `json:"global"`
+	Reason    string    `json:"reason,omitempty"`
+	Reference string    `json:"reference,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateCouponRequest represents the request payload for creating a coupon.
+type CreateCouponRequest struct {
+	Code           string     `json:"code"            binding:"required,max=32"`
+	Type           string     `json:"type"            binding:"required,oneof=fixed percentage"`
+	Value          string     `json:"value"           binding:"required"`
+	MaxRedemptions int        `json:"max_redemptions" binding:"min=0"` // Zero means unlimited
+	ExpiresAt      *time.Time `json:"expires_at"`
+}
+
+// ApplyCouponRequest represents the request payload for applying a coupon on the checkout page.
+type ApplyCouponRequest struct {
+	Code string `json:"code" binding:"required,max=32"`
+}
+
+// ChooseDonationAmountRequest represents the amount a customer chose to give on a donation invoice.
+type ChooseDonationAmountRequest struct {
+	Amount string `json:"amount" binding:"required"` // In the invoice currency, at least the minimum amount
+}
+
+// ChangeCurrencyRequest represents the cryptocurrency a customer chose to pay an invoice in.
+type ChangeCurrencyRequest struct {
+	CryptoCurrency string `json:"crypto_currency" binding:"required,max=10"`
+	Network        string `json:"network,omitempty"` // Empty selects the cryptocurrency's default network
+}
+
+// SupplyRefundAddressRequest represents the refund address a customer supplied on the checkout page.
+type SupplyRefundAddressRequest struct {
+	Address string `json:"address" binding:"required,max=128"`
+	// Signature of "Refund invoice <id> to <address>" made with the address; leaves the address unverified if empty
+	Signature string `json:"signature,omitempty" binding:"max=200"`
+}
+
+// PublicRefundAddressResponse represents the refund address recorded for a customer.
+type PublicRefundAddressResponse struct {
+	InvoiceID string `json:"invoice_id"`
+	Address   string `json:"address"`
+	Verified  bool   `json:"verified"`
+}
+
+// ListCouponsResponse represents the response for listing coupons.
+type ListCouponsResponse struct {
+	Coupons []CouponResponse `json:"coupons"`
+}
+
+// CouponResponse represents a merchant coupon.
+type CouponResponse struct {
+	ID             string     `json:"id"`
+	Code           string     `json:"code"`
+	Type           string     `json:"type"`
+	Value          string     `json:"value"`
+	MaxRedemptions int        `json:"max_redemptions"`
+	Redemptions    int        `json:"redemptions"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	Active         bool       `json:"active"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// ToCouponResponse converts a domain coupon to a coupon response.
+func ToCouponResponse(c *coupon.Coupon) CouponResponse {
+	return CouponResponse{
+		ID:             c.ID(),
+		Code:           c.Code(),
+		Type:           c.Discount().Type().String(),
+		Value:          c.Discount().Value().String(),
+		MaxRedemptions: c.MaxRedemptions(),
+		Redemptions:    c.Redemptions(),
+		ExpiresAt:      c.ExpiresAt(),
+		Active:         c.IsActive(),
+		CreatedAt:      c.CreatedAt(),
+		UpdatedAt:      c.UpdatedAt(),
+	}
+}
+
+// CreateTaxRuleRequest represents the request payload for creating a tax rule.
+type CreateTaxRuleRequest struct {
+	Jurisdiction  string `json:"jurisdiction"   binding:"required,max=6"`
+	Category      string `json:"category"`
+	Name          string `json:"name"           binding:"required,max=64"`
+	Type          string `json:"type"           binding:"required,oneof=vat gst sales_tax"`
+	Rate          string `json:"rate"           binding:"required"` // Decimal fraction, e.g. "0.19" for 19%
+	ReverseCharge bool   `json:"reverse_charge"`
+}
+
+// UpdateTaxRuleRequest represents the request payload for updating a tax rule.
+type UpdateTaxRuleRequest struct {
+	Name          string `json:"name"           binding:"required,max=64"`
+	Rate          string `json:"rate"           binding:"required"`
+	ReverseCharge bool   `json:"reverse_charge"`
+}
+
+// ListTaxRulesResponse represents the response for listing tax rules.
+type ListTaxRulesResponse struct {
+	Rules []TaxRuleResponse `json:"rules"`
+}
+
+// TaxRuleResponse represents a merchant tax rule.
+type TaxRuleResponse struct {
+	ID            string    `json:"id"`
+	Jurisdiction  string    `json:"jurisdiction"`
+	Category      string    `json:"category"`
+	Name          string    `json:"name"`
+	Type          string    `json:"type"`
+	Rate          string    `json:"rate"`
+	ReverseCharge bool      `json:"reverse_charge"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ToTaxRuleResponse converts a domain tax rule to a tax rule response.
+func ToTaxRuleResponse(rule *tax.Rule) TaxRuleResponse {
+	return TaxRuleResponse{
+		ID:            rule.ID(),
+		Jurisdiction:  rule.Jurisdiction(),
+		Category:      rule.Category(),
+		Name:          rule.Name(),
+		Type:          rule.Type().String(),
+		Rate:          rule.Rate(),
+		ReverseCharge: rule.IsReverseCharge(),
+		CreatedAt:     rule.CreatedAt(),
+		UpdatedAt:     rule.UpdatedAt(),
+	}
+}
+
+// CreatePaymentLinkRequest represents the request payload for creating a payment link.
+type CreatePaymentLinkRequest struct {
+	Title          string `json:"title"           binding:"required,max=255"`
+	Description    string `json:"description"`
+	Amount         string `json:"amount"` // Empty for an open amount chosen by the customer
+	Currency       string `json:"currency"        binding:"omitempty,oneof=USD EUR GBP"`
+	CryptoCurrency string `json:"crypto_currency" binding:"omitempty,max=10"`
+}
+
+// CreatePaymentLinkInvoiceRequest represents the customer's request to pay through a payment link.
+type CreatePaymentLinkInvoiceRequest struct {
+	Amount string `json:"amount"` // Required for open-amount links, ignored otherwise
+}
+
+// ListPaymentLinksResponse represents the response for listing payment links.
+type ListPaymentLinksResponse struct {
+	Links []PaymentLinkResponse `json:"links"`
+}
+
+// PaymentLinkResponse represents a merchant payment link.
+type PaymentLinkResponse struct {
+	ID             string    `json:"id"`
+	Slug           string    `json:"slug"`
+	URL            string    `json:"url"`
+	Title          string    `json:"title"`
+	Description    string    `json:"description,omitempty"`
+	Amount         string    `json:"amount,omitempty"`
+	OpenAmount     bool      `json:"open_amount"`
+	Currency       string    `json:"currency"`
+	CryptoCurrency string    `json:"crypto_currency"`
+	Active         bool      `json:"active"`
+	Visits         int       `json:"visits"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// PaymentLinkStatsResponse represents the usage statistics of a payment link.
+type PaymentLinkStatsResponse struct {
+	LinkID          string `json:"link_id"`
+	Visits          int    `json:"visits"`
+	Invoices        int    `json:"invoices"`
+	PaidInvoices    int    `json:"paid_invoices"`
+	AmountCollected string `json:"amount_collected"`
+	Currency        string `json:"currency"`
+}
+
+// PublicPaymentLinkResponse represents a payment link as shown to customers.
+type PublicPaymentLinkResponse struct {
+	Slug           string `json:"slug"`
+	Title          string `json:"title"`
+	Description    string `json:"description,omitempty"`
+	Amount         string `json:"amount,omitempty"`
+	OpenAmount     bool   `json:"open_amount"`
+	Currency       string `json:"currency"`
+	CryptoCurrency string `json:"crypto_currency"`
+}
+
+// paymentLinkURL returns the short URL customers open to pay through a link.
+func paymentLinkURL(slug string) string {
+	return checkoutBaseURL + "/l/" + slug
+}
+
+// ToPaymentLinkResponse converts a domain payment link to a payment link response.
+func ToPaymentLinkResponse(link *paymentlink.PaymentLink) PaymentLinkResponse {
+	response := PaymentLinkResponse{
+		ID:             link.ID(),
+		Slug:           link.Slug(),
+		URL:            paymentLinkURL(link.Slug()),
+		Title:          link.Title(),
+		Description:    link.Description(),
+		OpenAmount:     link.IsOpenAmount(),
+		Currency:       string(link.Currency()),
+		CryptoCurrency: string(link.CryptoCurrency()),
+		Active:         link.IsActive(),
+		Visits:         link.Visits(),
+		CreatedAt:      link.CreatedAt(),
+		UpdatedAt:      link.UpdatedAt(),
+	}
+	if amount := link.Amount(); amount != nil {
+		response.Amount = amount.String()
+	}
+	return response
+}
+
+// ToPaymentLinkStatsResponse converts payment link statistics to their response form.
+func ToPaymentLinkStatsResponse(linkID string, stats *paymentlink.Stats) PaymentLinkStatsResponse {
+	return PaymentLinkStatsResponse{
+		LinkID:          linkID,
+		Visits:          stats.Visits,
+		Invoices:        stats.Invoices,
+		PaidInvoices:    stats.PaidInvoices,
+		AmountCollected: stats.AmountCollected.String(),
+		Currency:        stats.AmountCollected.Currency(),
+	}
+}
+
+// ToPublicPaymentLinkResponse converts a domain payment link to its customer-facing response.
+func ToPublicPaymentLinkResponse(link *paymentlink.PaymentLink) PublicPaymentLinkResponse {
+	response := PublicPaymentLinkResponse{
+		Slug:           link.Slug(),
+		Title:          link.Title(),
+		Description:    link.Description(),
+		OpenAmount:     link.IsOpenAmount(),
+		Currency:       string(link.Currency()),
+		CryptoCurrency: string(link.CryptoCurrency()),
+	}
+	if amount := link.Amount(); amount != nil {
+		response.Amount = amount.String()
+	}
+	return response
+}
+
+// InvoiceTemplateRequest represents the request payload for creating or replacing an invoice template.
+type InvoiceTemplateRequest struct {
+	Name           string                       `json:"name"            binding:"required,max=64"`
+	Title          string                       `json:"title"           binding:"required,max=255"`
+	Description    string                       `json:"description"     binding:"max=1000"`
+	Items          []InvoiceTemplateItemRequest `json:"items"           binding:"required,min=1,dive"`
+	Currency       string                       `json:"currency"        binding:"omitempty,oneof=USD EUR GBP"`
+	CryptoCurrency string                       `json:"crypto_currency" binding:"omitempty,max=10"`
+	Network        string                       `json:"network"         binding:"omitempty,max=20"` // Defaults to the crypto currency's first configured network
+	TaxRate        string                       `json:"tax_rate"`                                   // Tax rate as decimal (e.g., "0.10" for 10%)
+	ExpiresIn      int64                        `json:"expires_in"      binding:"min=0"`            // Seconds; 0 uses the invoice default
+	Metadata       map[string]interface{}       `json:"metadata"`
+}
+
+// InvoiceTemplateItemRequest represents a line item of an invoice template.
+type InvoiceTemplateItemRequest struct {
+	Name        string `json:"name"         binding:"required"`
+	Description string `json:"description"`
+	Quantity    string `json:"quantity"     binding:"required"`
+	UnitPrice   string `json:"unit_price"   binding:"required"`
+	TaxCategory string `json:"tax_category"` // Defaults to "standard"
+}
+
+// CreateTemplateInvoiceRequest represents the overrides applied to an invoice created from a template.
+// Omitted fields keep the template's value.
+type CreateTemplateInvoiceRequest struct {
+	Title          string                       `json:"title"           binding:"max=255"`
+	Description    string                       `json:"description"     binding:"max=1000"`
+	CustomerID     *string                      `json:"customer_id"`
+	Items          []InvoiceTemplateItemRequest `json:"items"           binding:"omitempty,dive"` // Replace the template's items
+	CryptoCurrency string                       `json:"crypto_currency" binding:"omitempty,max=10"`
+	Network        string                       `json:"network"         binding:"omitempty,max=20"`
+	TaxRate        *string                      `json:"tax_rate"`
+	ExpiresIn      int64                        `json:"expires_in"      binding:"min=0"`
+	Metadata       map[string]interface{}       `json:"metadata"` // Merged over the template's metadata
+}
+
+// ListInvoiceTemplatesResponse represents the response for listing invoice templates.
+type ListInvoiceTemplatesResponse struct {
+	Templates []InvoiceTemplateResponse `json:"templates"`
+}
+
+// InvoiceTemplateResponse represents a merchant invoice template.
+type InvoiceTemplateResponse struct {
+	ID             string                        `json:"id"`
+	Name           string                        `json:"name"`
+	Title          string                        `json:"title"`
+	Description    string                        `json:"description,omitempty"`
+	Items          []InvoiceTemplateItemResponse `json:"items"`
+	Currency       string                        `json:"currency"`
+	CryptoCurrency string                        `json:"crypto_currency"`
+	Network        string                        `json:"network,omitempty"`
+	TaxRate        string                        `json:"tax_rate,omitempty"`
+	ExpiresIn      int64                         `json:"expires_in,omitempty"`
+	Metadata       map[string]interface{}        `json:"metadata,omitempty"`
+	CreatedAt      time.Time                     `json:"created_at"`
+	UpdatedAt      time.Time                     `json:"updated_at"`
+}
+
+// InvoiceTemplateItemResponse represents a line item of an invoice template.
+type InvoiceTemplateItemResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Quantity    string `json:"quantity"`
+	UnitPrice   string `json:"unit_price"`
+	TaxCategory string `json:"tax_category"`
+}
+
+// toInvoiceTemplateItems converts template item DTOs to domain items.
+func toInvoiceTemplateItems(dtoItems []InvoiceTemplateItemRequest) []invoicetemplate.Item {
+	items := make([]invoicetemplate.Item, len(dtoItems))
+	for i, item := range dtoItems {
+		items[i] = invoicetemplate.Item(item)
+	}
+	return items
+}
+
+// ToInvoiceTemplateDefinition converts an invoice template request to the template's content.
+func ToInvoiceTemplateDefinition(req InvoiceTemplateRequest) invoicetemplate.Definition {
+	return invoicetemplate.Definition{
+		Name:           req.Name,
+		Title:          req.Title,
+		Description:    req.Description,
+		Items:          toInvoiceTemplateItems(req.Items),
+		Currency:       shared.Currency(req.Currency),
+		CryptoCurrency: shared.CryptoCurrency(req.CryptoCurrency),
+		Network:        shared.BlockchainNetwork(req.Network),
+		TaxRate:        req.TaxRate,
+		ExpiresIn:      time.Duration(req.ExpiresIn) * time.Second,
+		Metadata:       req.Metadata,
+	}
+}
+
+// ToInvoiceTemplateOverrides converts a template invoice request to the overrides it applies.
+func ToInvoiceTemplateOverrides(req CreateTemplateInvoiceRequest) invoicetemplate.Overrides {
+	return invoicetemplate.Overrides{
+		Title:          req.Title,
+		Description:    req.Description,
+		CustomerID:     req.CustomerID,
+		Items:          toInvoiceTemplateItems(req.Items),
+		CryptoCurrency: shared.CryptoCurrency(req.CryptoCurrency),
+		Network:        shared.BlockchainNetwork(req.Network),
+		TaxRate:        req.TaxRate,
+		ExpiresIn:      time.Duration(req.ExpiresIn) * time.Second,
+		Metadata:       req.Metadata,
+	}
+}
+
+// ToInvoiceTemplateResponse converts a domain invoice template to an invoice template response.
+func ToInvoiceTemplateResponse(template *invoicetemplate.Template) InvoiceTemplateResponse {
+	response := InvoiceTemplateResponse{
+		ID:             template.ID(),
+		Name:           template.Name(),
+		Title:          template.Title(),
+		Description:    template.Description(),
+		Items:          make([]InvoiceTemplateItemResponse, len(template.Items())),
+		Currency:       template.Currency().String(),
+		CryptoCurrency: template.CryptoCurrency().String(),
+		Network:        template.Network().String(),
+		TaxRate:        template.TaxRate(),
+		ExpiresIn:      int64(template.ExpiresIn() / time.Second),
+		Metadata:       template.Metadata(),
+		CreatedAt:      template.CreatedAt(),
+		UpdatedAt:      template.UpdatedAt(),
+	}
+	for i, item := range template.Items() {
+		response.Items[i] = InvoiceTemplateItemResponse(item)
+	}
+	return response
+}
+
+// AutomationRuleRequest represents the request payload for creating or replacing an automation rule.
+type AutomationRuleRequest struct {
+	Name       string                           `json:"name"       binding:"required,max=64"`
+	Trigger    string                           `json:"trigger"    binding:"required"                 example:"invoice.paid"`
+	Conditions []AutomationRuleConditionRequest `json:"conditions" binding:"omitempty,max=10,dive"`
+	Actions    []AutomationRuleActionRequest    `json:"actions"    binding:"required,min=1,max=5,dive"`
+	Enabled    *bool                            `json:"enabled"` // Defaults to true
+}
+
+// AutomationRuleConditionRequest represents a condition of an automation rule.
+type AutomationRuleConditionRequest struct {
+	Field    string `json:"field"    binding:"required" example:"total"`
+	Operator string `json:"operator" binding:"required" example:"gt"`
+	Value    string `json:"value"    binding:"required" example:"100"`
+}
+
+// AutomationRuleActionRequest represents an action of an automation rule.
+type AutomationRuleActionRequest struct {
+	Type          string `json:"type"          binding:"required" example:"call_url"`
+	URL           string `json:"url"`           // For call_url
+	Tag           string `json:"tag"`           // For add_tag
+	Confirmations int    `json:"confirmations"` // For require_confirmations
+}
+
+// TestAutomationRuleRequest represents the request to evaluate an automation rule against an invoice.
+type TestAutomationRuleRequest struct {
+	Rule      *AutomationRuleRequest `json:"rule"` // Required unless a saved rule is tested
+	InvoiceID string                 `json:"invoice_id" binding:"required"`
+	PaymentID string                 `json:"payment_id"` // Required for payment triggers
+}
+
+// ListAutomationRulesResponse represents the response for listing automation rules.
+type ListAutomationRulesResponse struct {
+	Rules []AutomationRuleResponse `json:"rules"`
+}
+
+// AutomationRuleResponse represents a merchant automation rule.
+type AutomationRuleResponse struct {
+	ID         string                            `json:"id"`
+	Name       string                            `json:"name"`
+	Trigger    string                            `json:"trigger"`
+	Conditions []AutomationRuleConditionResponse `json:"conditions"`
+	Actions    []AutomationRuleActionResponse    `json:"actions"`
+	Enabled    bool                              `json:"enabled"`
+	Secret     string                            `json:"secret,omitempty"` // Only returned when the rule is created
+	CreatedAt  time.Time                         `json:"created_at"`
+	UpdatedAt  time.Time                         `json:"updated_at"`
+}
+
+// AutomationRuleConditionResponse represents a condition of an automation rule.
+type AutomationRuleConditionResponse struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+// AutomationRuleActionResponse represents an action of an automation rule.
+type AutomationRuleActionResponse struct {
+	Type          string `json:"type"`
+	URL           string `json:"url,omitempty"`
+	Tag           string `json:"tag,omitempty"`
+	Confirmations int    `json:"confirmations,omitempty"`
+}
+
+// TestAutomationRuleResponse represents the outcome of a dry run of an automation rule.
+type TestAutomationRuleResponse struct {
+	Matched    bool                                    `json:"matched"`
+	Conditions []AutomationRuleConditionResultResponse `json:"conditions"`
+	Actions    []AutomationRuleActionResponse          `json:"actions"` // The actions the rule would take
+}
+
+// AutomationRuleConditionResultResponse represents the outcome of one condition of a dry run.
+type AutomationRuleConditionResultResponse struct {
+	AutomationRuleConditionResponse
+	Actual  string `json:"actual,omitempty"`
+	Present bool   `json:"present"`
+	Matched bool   `json:"matched"`
+}
+
+// PaymentProcessorRequest represents the request payload for registering a payment processor.
+type PaymentProcessorRequest struct {
+	Processor     string `json:"processor"      binding:"required"`
+	APIKey        string `json:"api_key"        binding:"required"`
+	APISecret     string `json:"api_secret"`                        // Required by Binance Pay to sign requests
+	WebhookSecret string `json:"webhook_secret" binding:"required"` // Key webhooks are verified with
+}
+
+// UpdatePaymentProcessorRequest represents the request payload for updating a payment processor registration.
+type UpdatePaymentProcessorRequest struct {
+	Enabled       *bool  `json:"enabled"`
+	APIKey        string `json:"api_key"` // Replaces all credentials when set
+	APISecret     string `json:"api_secret"`
+	WebhookSecret string `json:"webhook_secret"`
+}
+
+// PaymentProcessorResponse represents a merchant's payment processor registration, without its credentials.
+type PaymentProcessorResponse struct {
+	ID          string    `json:"id"`
+	Processor   string    `json:"processor"`
+	Enabled     bool      `json:"enabled"`
+	APIKeyHint  string    `json:"api_key_hint"` // Last characters of the API key
+	WebhookPath string    `json:"webhook_path"` // Where the processor should send webhooks, relative to the API
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ListPaymentProcessorsResponse represents the response for listing payment processor registrations.
+type ListPaymentProcessorsResponse struct {
+	Processors []PaymentProcessorResponse `json:"processors"`
+	Available  []string                   `json:"available"` // Processors that can be registered
+}
+
+// CheckoutOptionResponse represents a payment processor checkout offered on the payment page.
+type CheckoutOptionResponse struct {
+	Processor   string     `json:"processor"`
+	DisplayName string     `json:"display_name"`
+	URL         string     `json:"url"`
+	QRContent   string     `json:"qr_content,omitempty"` // Content to render as a QR code, for processors with apps
+	Amount      string     `json:"amount"`
+	Currency    string     `json:"currency"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+// ProcessorWebhookSuccess is the return code acknowledging a payment processor webhook.
+const ProcessorWebhookSuccess = "SUCCESS"
+
+// ProcessorWebhookResponse represents the acknowledgement of a payment processor webhook.
+type ProcessorWebhookResponse struct {
+	ReturnCode string `json:"returnCode"` // Binance Pay retries webhooks not acknowledged with SUCCESS
+	CheckoutID string `json:"checkout_id,omitempty"`
+	Status     string `json:"status,omitempty"`
+}
+
+// ToAutomationRuleDefinition converts an automation rule request to the rule's content.
+func ToAutomationRuleDefinition(req AutomationRuleRequest) automation.Definition {
+	definition := automation.Definition{
+		Name:       req.Name,
+		Trigger:    automation.Trigger(req.Trigger),
+		Conditions: make([]automation.Condition, len(req.Conditions)),
+		Actions:    make([]automation.Action, len(req.Actions)),
+		Enabled:    req.Enabled == nil || *req.Enabled,
+	}
+	for i, condition := range req.Conditions {
+		definition.Conditions[i] = automation.Condition{
+			Field:    condition.Field,
+			Operator: automation.Operator(condition.Operator),
+			Value:    condition.Value,
+		}
+	}
+	for i, action := range req.Actions {
+		definition.Actions[i] = automation.Action{
+			Type:          automation.ActionType(action.Type),
+			URL:           action.URL,
+			Tag:           action.Tag,
+			Confirmations: action.Confirmations,
+		}
+	}
+	return definition
+}
+
+// toAutomationRuleActionResponses converts automation rule actions to responses.
+func toAutomationRuleActionResponses(actions []automation.Action) []AutomationRuleActionResponse {
+	responses := make([]AutomationRuleActionResponse, len(actions))
+	for i, action := range actions {
+		responses[i] = AutomationRuleActionResponse{
+			Type:          string(action.Type),
+			URL:           action.URL,
+			Tag:           action.Tag,
+			Confirmations: action.Confirmations,
+		}
+	}
+	return responses
+}
+
+// ToAutomationRuleResponse converts a domain automation rule to an automation rule response, without its secret.
+func ToAutomationRuleResponse(rule *automation.Rule) AutomationRuleResponse {
+	response := AutomationRuleResponse{
+		ID:         rule.ID(),
+		Name:       rule.Name(),
+		Trigger:    string(rule.Trigger()),
+		Conditions: make([]AutomationRuleConditionResponse, len(rule.Conditions())),
+		Actions:    toAutomationRuleActionResponses(rule.Actions()),
+		Enabled:    rule.Enabled(),
+		CreatedAt:  rule.CreatedAt(),
+		UpdatedAt:  rule.UpdatedAt(),
+	}
+	for i, condition := range rule.Conditions() {
+		response.Conditions[i] = AutomationRuleConditionResponse{
+			Field:    condition.Field,
+			Operator: string(condition.Operator),
+			Value:    condition.Value,
+		}
+	}
+	return response
+}
+
+// ToTestAutomationRuleResponse converts the evaluation of an automation rule to a dry run response.
+func ToTestAutomationRuleResponse(evaluation *automation.Evaluation) TestAutomationRuleResponse {
+	response := TestAutomationRuleResponse{
+		Matched:    evaluation.Matched,
+		Conditions: make([]AutomationRuleConditionResultResponse, len(evaluation.Conditions)),
+		Actions:    toAutomationRuleActionResponses(evaluation.Actions),
+	}
+	for i, result := range evaluation.Conditions {
+		response.Conditions[i] = AutomationRuleConditionResultResponse{
+			AutomationRuleConditionResponse: AutomationRuleConditionResponse{
+				Field:    result.Condition.Field,
+				Operator: string(result.Condition.Operator),
+				Value:    result.Condition.Value,
+			},
+			Actual:  result.Actual,
+			Present: result.Present,
+			Matched: result.Matched,
+		}
+	}
+	return response
+}
+
+// ToPaymentProcessorResponse converts a payment processor registration to a response, without its credentials.
+func ToPaymentProcessorResponse(registration *processor.Registration) PaymentProcessorResponse {
+	return PaymentProcessorResponse{
+		ID:          registration.ID(),
+		Processor:   registration.Processor(),
+		Enabled:     registration.Enabled(),
+		APIKeyHint:  maskCredential(registration.Credentials().APIKey),
+		WebhookPath: "/api/v1/processors/" + registration.Processor() + "/webhooks/" + registration.ID(),
+		CreatedAt:   registration.CreatedAt(),
+		UpdatedAt:   registration.UpdatedAt(),
+	}
+}
+
+// ToCheckoutOptionResponses converts the payment processor checkouts offered for an invoice to responses.
+func ToCheckoutOptionResponses(options []processor.CheckoutOption) []CheckoutOptionResponse {
+	if len(options) == 0 {
+		return nil
+	}
+	responses := make([]CheckoutOptionResponse, len(options))
+	for i, option := range options {
+		responses[i] = CheckoutOptionResponse{
+			Processor:   option.Checkout.Processor(),
+			DisplayName: option.DisplayName,
+			URL:         option.Checkout.URL(),
+			QRContent:   option.Checkout.QRContent(),
+			Amount:      option.Checkout.Amount().String(),
+			Currency:    option.Checkout.Currency(),
+		}
+		if expiresAt := option.Checkout.ExpiresAt(); !expiresAt.IsZero() {
+			responses[i].ExpiresAt = &expiresAt
+		}
+	}
+	return responses
+}
+
+// maskCredential reveals only the last four characters of a credential.
+func maskCredential(credential string) string {
+	if len(credential) <= 8 {
+		return "***"
+	}
+	return "***" + credential[len(credential)-4:]
+}
+
+// ToUserResponse converts a domain team member to a user response.
+func ToUserResponse(user *merchant.User) UserResponse {
+	return UserResponse{
+		ID:          user.ID(),
+		MerchantID:  user.MerchantID(),
+		Email:       user.Email(),
+		Name:        user.Name(),
+		Role:        string(user.Role()),
+		Status:      string(user.Status()),
+		Permissions: user.Role().Permissions(),
+		TwoFactor:   user.TOTPEnabled(),
+		CreatedAt:   user.CreatedAt(),
+		UpdatedAt:   user.UpdatedAt(),
+	}
+}
+
+// ToInvitationResponse converts a domain invitation to an invitation response.
+func ToInvitationResponse(invitation *merchant.Invitation) InvitationResponse {
+	return InvitationResponse{
+		ID:         invitation.ID(),
+		MerchantID: invitation.MerchantID(),
+		Email:      invitation.Email(),
+		Role:       string(invitation.Role()),
+		Status:     string(invitation.Status()),
+		InvitedBy:  invitation.InvitedBy(),
+		ExpiresAt:  invitation.ExpiresAt(),
+		CreatedAt:  invitation.CreatedAt(),
+		AcceptedAt: invitation.AcceptedAt(),
+	}
+}
+
+// ToAuditLogResponse converts a domain audit entry to an audit log response.
+func ToAuditLogResponse(entry *audit.Entry) AuditLogResponse {
+	return AuditLogResponse{
+		ID:           entry.ID(),
+		MerchantID:   entry.MerchantID(),
+		Actor:        entry.Actor(),
+		IPAddress:    entry.Origin().IPAddress,
+		RequestID:    entry.Origin().RequestID,
+		UserAgent:    entry.Origin().UserAgent,
+		Action:       entry.Action(),
+		ResourceType: entry.ResourceType(),
+		ResourceID:   entry.ResourceID(),
+		Before:       entry.Change().Before,
+		After:        entry.Change().After,
+		Details:      entry.Details(),
+		OccurredAt:   entry.OccurredAt(),
+	}
+}
+
+// ToScreeningResponse converts a domain screening to a screening response.
+func ToScreeningResponse(screening *compliance.Screening) ScreeningResponse {
+	return ScreeningResponse{
+		ID:         screening.ID(),
+		PaymentID:  screening.PaymentID(),
+		InvoiceID:  screening.InvoiceID(),
+		Address:    screening.Address(),
+		Network:    screening.Network(),
+		Provider:   screening.Provider(),
+		RiskLevel:  string(screening.RiskLevel()),
+		RiskScore:  screening.RiskScore(),
+		Categories: screening.Categories(),
+		Reference:  screening.Reference(),
+		Failure:    screening.Failure(),
+		Status:     string(screening.Status()),
+		ReviewedBy: screening.ReviewedBy(),
+		ReviewNote: screening.ReviewNote(),
+		ScreenedAt: screening.ScreenedAt(),
+		ReviewedAt: screening.ReviewedAt(),
+	}
+}
+
+// ToPaymentDetailResponse converts a payment, the invoice it pays and the screening of its sender, if any, to a
+// payment detail response linking to the block explorers of the resolver.
+func ToPaymentDetailResponse(
+	p *payment.Payment, inv *invoice.Invoice, screening *compliance.Screening, explorers *shared.ExplorerResolver,
+) PaymentDetailResponse {
+	network := p.ToAddress().Network()
+	response := PaymentDetailResponse{
+		ID:                    p.ID().String(),
+		Status:                p.Status().String(),
+		Amount:                p.Amount().Amount().Amount().String(),
+		CryptoCurrency:        p.Amount().Currency().String(),
+		Network:               network.String(),
+		TransactionHash:       p.TransactionHash().Hash(),
+		FromAddresses:         []string{},
+		ToAddress:             p.ToAddress().Address(),
+		Confirmations:         p.Confirmations().Int(),
+		RequiredConfirmations: p.RequiredConfirmations(),
+		Explorer: PaymentExplorerLinks{
+			Transaction: explorers.TransactionURL(network, p.TransactionHash().Hash()),
+			ToAddress:   explorers.AddressURL(network, p.ToAddress().Address()),
+		},
+		Invoice: PaymentInvoiceResponse{
+			ID:             inv.ID(),
+			Number:         inv.Number(),
+			Status:         inv.Status().String(),
+			AmountReceived: toAmountReceived(inv),
+		},
+		DetectedAt:  p.DetectedAt(),
+		ConfirmedAt: p.ConfirmedAt(),
+		CreatedAt:   p.CreatedAt(),
+		UpdatedAt:   p.UpdatedAt(),
+	}
+	if from := p.FromAddress(); from != "" {
+		response.FromAddresses = append(response.FromAddresses, from)
+		response.Explorer.FromAddresses = []string{explorers.AddressURL(network, from)}
+	}
+	if block := p.BlockInfo(); block != nil {
+		number := block.Number()
+		response.BlockNumber = &number
+		response.BlockHash = block.Hash()
+		response.Explorer.Block = explorers.BlockURL(network, number)
+	}
+	if fee := p.NetworkFee(); fee != nil {
+		response.NetworkFee = fee.Fee().Amount().String()
+		response.NetworkFeeCurrency = fee.Currency().String()
+	}
+	if screening != nil {
+		screeningResponse := ToScreeningResponse(screening)
+		response.Screening = &screeningResponse
+	}
+	return response
+}
+
+// toMinimumAmount returns the donation minimum of an invoice, or nil for standard invoices.
+func toMinimumAmount(inv *invoice.Invoice) *string {
+	if inv.MinimumAmount() == nil {
+		return nil
+	}
+	minimum := inv.MinimumAmount().String()
+	return &minimum
+}
+
+// toCryptoAmount returns the amount customers are asked to pay in the invoice cryptocurrency: the amount due at
+// the locked exchange rate, fingerprinted when the invoice's payment reference fingerprints it.
+func toCryptoAmount(inv *invoice.Invoice) string {
+	amount, err := inv.PayableCryptoAmount()
+	if err != nil {
+		return inv.Pricing().Total().String()
+	}
+	return inv.FormatPayableAmount(amount)
+}
+
+// toPaymentReference returns the code of an invoice's payment reference, or "" when it has none.
+func toPaymentReference(inv *invoice.Invoice) string {
+	if reference := inv.PaymentReference(); reference != nil {
+		return reference.Code()
+	}
+	return ""
+}
+
+// CurrencyResponse represents a cryptocurrency on one network that invoices may be paid in.
+type CurrencyResponse struct {
+	Symbol   string `json:"symbol"`
+	Network  string `json:"network"`
+	Contract string `json:"contract,omitempty"` // Empty for the network's native coin
+	Decimals int32  `json:"decimals"`
+	Default  bool   `json:"default"` // Network used when an invoice names only the symbol
+}
+
+// ListCurrenciesResponse represents the cryptocurrencies a merchant's invoices may be paid in.
+type ListCurrenciesResponse struct {
+	Currencies []CurrencyResponse `json:"currencies"`
+}
+
+// ToListCurrenciesResponse converts the tokens accepted by a merchant, in order of preference.
+func ToListCurrenciesResponse(tokens []shared.Token) ListCurrenciesResponse {
+	response := ListCurrenciesResponse{Currencies: make([]CurrencyResponse, len(tokens))}
+	seen := make(map[shared.CryptoCurrency]bool, len(tokens))
+	for i, token := range tokens {
+		response.Currencies[i] = CurrencyResponse{
+			Symbol:   token.Symbol.String(),
+			Network:  token.Network.String(),
+			Contract: token.Contract,
+			Decimals: token.Decimals,
+			Default:  !seen[token.Symbol],
+		}
+		seen[token.Symbol] = true
+	}
+	return response
+}
+
+// toNetwork returns the network an invoice is paid on.
+func toNetwork(inv *invoice.Invoice) string {
+	return inv.Network().String()
+}
+
+// toAmountReceived returns the cumulative amount received for an invoice in its cryptocurrency.
+func toAmountReceived(inv *invoice.Invoice) string {
+	if inv.AmountPaid() == nil {
+		return "0"
+	}
+	return inv.AmountPaid().Amount().String()
+}
+
+// ToDonationProgressResponse reports the cumulative amount received for a donation against its minimum.
+// Received keeps counting once the minimum is reached, so the percentage may exceed 100.
+func ToDonationProgressResponse(inv *invoice.Invoice) *PaymentProgressResponse {
+	if !inv.IsDonation() {
+		return nil
+	}
+
+	minimum, err := inv.MinimumCryptoAmount()
+	if err != nil {
+		return nil
+	}
+
+	received := decimal.Zero
+	if inv.AmountPaid() != nil {
+		received = inv.AmountPaid().Amount()
+	}
+
+	remaining := minimum.Amount().Sub(received)
+	if remaining.IsNegative() {
+		remaining = decimal.Zero
+	}
+
+	var percent float64
+	if !minimum.Amount().IsZero() {
+		percent = received.Div(minimum.Amount()).Mul(decimal.NewFromInt(100)).Round(2).InexactFloat64()
+	}
+
+	return &PaymentProgressResponse{
+		Received:  received.String(),
+		Required:  minimum.Amount().String(),
+		Remaining: remaining.String(),
+		Percent:   percent,
+	}
+}
+
+// ToInvoiceRefundsResponse converts the refunds of a paid invoice, or of an invoice closed underpaid, to a
+// refund breakdown. It returns nil for invoices that have not been paid.
+func ToInvoiceRefundsResponse(inv *invoice.Invoice) *InvoiceRefundsResponse {
+	switch inv.Status() {
+	case invoice.StatusPaid, invoice.StatusPartiallyRefunded, invoice.StatusRefunded, invoice.StatusUnderpaidClosed:
+	default:
+		return nil
+	}
+
+	refundable, err := inv.RefundableAmount()
+	if err != nil {
+		return nil
+	}
+	refunded := inv.RefundedAmount()
+	paid := refundable.Amount().Add(refunded.Amount())
+
+	items := make([]InvoiceRefundResponse, len(inv.Refunds()))
+	for i, refund := range inv.Refunds() {
+		items[i] = ToInvoiceRefundResponse(refund)
+	}
+
+	return &InvoiceRefundsResponse{
+		AmountPaid:       paid.String(),
+		RefundedAmount:   refunded.Amount().String(),
+		RefundableAmount: refundable.Amount().String(),
+		Items:            items,
+	}
+}
+
+// ToInvoiceRefundResponse converts a domain refund to a refund response.
+func ToInvoiceRefundResponse(refund *invoice.Refund) InvoiceRefundResponse {
+	return InvoiceRefundResponse{
+		ID:          refund.ID(),
+		Amount:      refund.Amount().Amount().String(),
+		Reason:      refund.Reason(),
+		RequestedBy: refund.RequestedBy(),
+		Destination: refund.Destination(),
+		CreatedAt:   refund.CreatedAt(),
+	}
+}
+
+// ToRefundDestinationResponse converts a refund destination to its response form, or nil if there is none.
+func ToRefundDestinationResponse(destination *invoice.RefundDestination) *RefundDestinationResponse {
+	if destination == nil {
+		return nil
+	}
+	return &RefundDestinationResponse{
+		Address:     destination.Address(),
+		Source:      string(destination.Source()),
+		Verified:    destination.Verified(),
+		CapturedAt:  destination.CapturedAt(),
+		Confirmed:   destination.IsConfirmed(),
+		ConfirmedBy: destination.ConfirmedBy(),
+		ConfirmedAt: destination.ConfirmedAt(),
+	}
+}
+
+// ToReviewResponse converts a domain review to a review response.
+func ToReviewResponse(rev *review.Review) ReviewResponse {
+	return ReviewResponse{
+		ID:             rev.ID(),
+		Kind:           string(rev.Kind()),
+		Status:         string(rev.Status()),
+		InvoiceID:      rev.InvoiceID(),
+		PaymentID:      rev.PaymentID(),
+		ExpectedAmount: rev.ExpectedAmount(),
+		ReceivedAmount: rev.ReceivedAmount(),
+		Currency:       rev.Currency(),
+		Reason:         rev.Reason(),
+		Resolution:     string(rev.Resolution()),
+		ResolvedBy:     rev.ResolvedBy(),
+		Note:           rev.Note(),
+		CreatedAt:      rev.CreatedAt(),
+		ResolvedAt:     rev.ResolvedAt(),
+	}
+}
+
+// ToDepositResponse converts a domain deposit and its suggested matches to a deposit response linking to the
+// block explorers of the resolver.
+func ToDepositResponse(d *deposit.Deposit, matches []deposit.Match, explorers *shared.ExplorerResolver) DepositResponse {
+	transfer := d.Transfer()
+	resp := DepositResponse{
+		ID:                d.ID(),
+		Status:            string(d.Status()),
+		Reason:            string(d.Reason()),
+		InvoiceID:         d.InvoiceID(),
+		Network:           string(transfer.Network),
+		TransactionHash:   transfer.TransactionHash,
+		ExplorerURL:       explorers.TransactionURL(transfer.Network, transfer.TransactionHash),
+		FromAddress:       transfer.FromAddress,
+		ToAddress:         transfer.ToAddress,
+		Amount:            transfer.Amount,
+		Currency:          string(transfer.Currency),
+		Memo:              transfer.Memo,
+		BlockNumber:       transfer.BlockNumber,
+		Confirmations:     transfer.Confirmations,
+		AttachedInvoiceID: d.AttachedInvoiceID(),
+		PaymentID:         d.PaymentID(),
+		ResolvedBy:        d.ResolvedBy(),
+		CreatedAt:         d.CreatedAt(),
+		ResolvedAt:        d.ResolvedAt(),
+	}
+	for _, match := range matches {
+		resp.Matches = append(resp.Matches, DepositMatchResponse{
+			InvoiceID: match.InvoiceID,
+			Number:    match.Number,
+			Heuristic: string(match.Heuristic),
+			AmountDue: match.AmountDue,
+		})
+	}
+	return resp
+}
+
+// ToBlocklistEntryResponse converts a domain blocklist entry to a blocklist entry response.
+func ToBlocklistEntryResponse(entry *compliance.BlocklistEntry) BlocklistEntryResponse {
+	return BlocklistEntryResponse{
+		ID:        entry.ID(),
+		Address:   entry.Address(),
+		Network:   entry.Network(),
+		Source:    string(entry.Source()),
+		Global:    entry.IsGlobal(),
+		Reason:    entry.Reason(),
+		Reference: entry.Reference(),
+		CreatedBy: entry.CreatedBy(),
+		CreatedAt: entry.CreatedAt(),
+	}
+}
+
+// BlockchainNotificationRequest represents a transaction reported by an external chain watcher.
+type BlockchainNotificationRequest struct {
+	Network         string `json:"network"          binding:"required,oneof=tron ethereum bitcoin tron_shasta tron_nile ethereum_sepolia bitcoin_testnet"`
+	TransactionHash string `json:"transaction_hash" binding:"required"`
+	FromAddress     string `json:"from_address"     binding:"required"`
+	ToAddress       string `json:"to_address"       binding:"required"`
+	Amount          string `json:"amount"           binding:"required"` // In the cryptocurrency
+	Currency        string `json:"currency"         binding:"required,oneof=USDT BTC ETH"`
+	BlockNumber     int64  `json:"block_number"     binding:"min=0"`
+	BlockHash       string `json:"block_hash"` // Empty while the transaction is in the mempool
+	Confirmations   int    `json:"confirmations"    binding:"min=0"`
+	Memo            string `json:"memo,omitempty"   binding:"max=255"` // Memo or tag of the transfer, if any
+	// Removed reports that a chain reorganization dropped the block holding the transaction
+	Removed bool `json:"removed,omitempty"`
+}
+
+// BlockchainNotificationResponse represents the payment, or the unattributed deposit, recorded for a
+// transaction notification.
+type BlockchainNotificationResponse struct {
+	PaymentID     string `json:"payment_id,omitempty"`
+	DepositID     string `json:"deposit_id,omitempty"`
+	InvoiceID     string `json:"invoice_id"`
+	Status        string `json:"status"`
+	Confirmations int    `json:"confirmations"`
+	Duplicate     bool   `json:"duplicate"`
+	// Unattributed reports that the invoice was closed and the funds were kept as a deposit.
+	Unattributed bool `json:"unattributed"`
+}
+
+// ToBlockchainNotificationResponse converts a detection result to a notification response.
+func ToBlockchainNotificationResponse(result *detection.Result) BlockchainNotificationResponse {
+	if result.Deposit != nil {
+		return BlockchainNotificationResponse{
+			DepositID:     result.Deposit.ID(),
+			InvoiceID:     result.InvoiceID,
+			Status:        string(result.Deposit.Status()),
+			Confirmations: result.Deposit.Transfer().Confirmations,
+			Duplicate:     result.Duplicate,
+			Unattributed:  true,
+		}
+	}
+	return BlockchainNotificationResponse{
+		PaymentID:     string(result.Payment.ID()),
+		InvoiceID:     result.InvoiceID,
+		Status:        string(result.Payment.Status()),
+		Confirmations: result.Payment.Confirmations().Int(),
+		Duplicate:     result.Duplicate,
+	}
+}
+
+// RequoteResponse represents the replacement of an expired exchange rate in the invoice timeline.
+type RequoteResponse struct {
+	PreviousRate         string    `json:"previous_rate"`
+	NewRate              string    `json:"new_rate"`
+	PreviousCryptoAmount string    `json:"previous_crypto_amount"`
+	NewCryptoAmount      string    `json:"new_crypto_amount"`
+	Slippage             string    `json:"slippage"` // Relative rate change, e.g. "0.0125" for 1.25%
+	Source               string    `json:"source"`
+	RequotedAt           time.Time `json:"requoted_at"`
+}
+
+// ToRequoteResponses converts the re-quotes of an invoice, oldest first, or returns nil when there are none.
+func ToRequoteResponses(requotes []*invoice.Requote) []RequoteResponse {
+	if len(requotes) == 0 {
+		return nil
+	}
+
+	responses := make([]RequoteResponse, len(requotes))
+	for i, requote := range requotes {
+		responses[i] = RequoteResponse{
+			PreviousRate:         requote.PreviousRate().String(),
+			NewRate:              requote.NewRate().String(),
+			PreviousCryptoAmount: requote.PreviousAmount().String(),
+			NewCryptoAmount:      requote.NewAmount().String(),
+			Slippage:             requote.Slippage().String(),
+			Source:               requote.Source(),
+			RequotedAt:           requote.RequotedAt(),
+		}
+	}
+	return responses
+}
+
+// StaleRateCheckResponse represents the handling of a payment that arrived after the invoice's exchange rate
+// expired, in the invoice timeline.
+type StaleRateCheckResponse struct {
+	PaymentID   string    `json:"payment_id"`
+	Action      string    `json:"action"` // accept, review or requote
+	LockedRate  string    `json:"locked_rate"`
+	CurrentRate string    `json:"current_rate,omitempty"` // Omitted when no rate could be quoted
+	Slippage    string    `json:"slippage,omitempty"`
+	Reason      string    `json:"reason"`
+	CheckedAt   time.Time `json:"checked_at"`
+}
+
+// ToStaleRateCheckResponses converts the stale rate checks of an invoice, oldest first, or returns nil when
+// there are none.
+func ToStaleRateCheckResponses(checks []*invoice.StaleRateCheck) []StaleRateCheckResponse {
+	if len(checks) == 0 {
+		return nil
+	}
+
+	responses := make([]StaleRateCheckResponse, len(checks))
+	for i, check := range checks {
+		responses[i] = StaleRateCheckResponse{
+			PaymentID:  check.PaymentID(),
+			Action:     string(check.Action()),
+			LockedRate: check.LockedRate().String(),
+			Reason:     check.Reason(),
+			CheckedAt:  check.CheckedAt(),
+		}
+		if !check.CurrentRate().IsZero() {
+			responses[i].CurrentRate = check.CurrentRate().String()
+			responses[i].Slippage = check.Slippage().String()
+		}
+	}
+	return responses
+}
+
+// PaymentReversalResponse represents a confirmed payment taken back from the invoice after a deep chain
+// reorganization, in the invoice timeline.
+type PaymentReversalResponse struct {
+	PaymentID       string    `json:"payment_id"`
+	TransactionHash string    `json:"transaction_hash,omitempty"`
+	Amount          string    `json:"amount"`
+	PreviousStatus  string    `json:"previous_status"`
+	Status          string    `json:"status"` // pending, or partial when other payments remain
+	Reason          string    `json:"reason"`
+	ReversedAt      time.Time `json:"reversed_at"`
+}
+
+// ToPaymentReversalResponses converts the payment reversals of an invoice, oldest first, or returns nil when
+// there are none.
+func ToPaymentReversalResponses(reversals []*invoice.PaymentReversal) []PaymentReversalResponse {
+	if len(reversals) == 0 {
+		return nil
+	}
+
+	responses := make([]PaymentReversalResponse, len(reversals))
+	for i, reversal := range reversals {
+		responses[i] = PaymentReversalResponse{
+			PaymentID:       reversal.PaymentID(),
+			TransactionHash: reversal.TransactionHash(),
+			Amount:          reversal.Amount().Amount().String(),
+			PreviousStatus:  reversal.PreviousStatus().String(),
+			Status:          reversal.Status().String(),
+			Reason:          reversal.Reason(),
+			ReversedAt:      reversal.ReversedAt(),
+		}
+	}
+	return responses
+}
+
+// InvoiceRequotedEvent is streamed to the checkout page when the expired exchange rate of an invoice is replaced.
+type InvoiceRequotedEvent struct {
+	Event          string          `json:"event"`
+	InvoiceID      string          `json:"invoice_id"`
+	CryptoCurrency string          `json:"crypto_currency"`
+	USDTAmount     string          `json:"usdt_amount"`
+	RateExpiresAt  time.Time       `json:"rate_expires_at"`
+	Requote        RequoteResponse `json:"requote"`
+	Timestamp      time.Time       `json:"timestamp"`
+}
+
+// ToInvoiceRequotedEvent builds the checkout page event for the latest re-quote of an invoice.
+func ToInvoiceRequotedEvent(inv *invoice.Invoice) (InvoiceRequotedEvent, bool) {
+	requotes := ToRequoteResponses(inv.Requotes())
+	if len(requotes) == 0 {
+		return InvoiceRequotedEvent{}, false
+	}
+
+	return InvoiceRequotedEvent{
+		Event:          shared.EventTypeInvoiceRequoted,
+		InvoiceID:      inv.ID(),
+		CryptoCurrency: inv.CryptoCurrency().String(),
+		USDTAmount:     toCryptoAmount(inv),
+		RateExpiresAt:  inv.ExchangeRate().ExpiresAt(),
+		Requote:        requotes[len(requotes)-1],
+		Timestamp:      time.Now().UTC(),
+	}, true
+}
+
+// InvoiceExtendedEvent is streamed to the checkout page when the expiry of an invoice is pushed back. The
+// invoice is also re-quoted if its exchange rate had expired, which is streamed as its own event.
+type InvoiceExtendedEvent struct {
+	Event         string    `json:"event"`
+	InvoiceID     string    `json:"invoice_id"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	TimeRemaining int64     `json:"time_remaining"` // Seconds
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// ToInvoiceExtendedEvent builds the checkout page event for the current expiry of an extended invoice.
+func ToInvoiceExtendedEvent(inv *invoice.Invoice) (InvoiceExtendedEvent, bool) {
+	if len(inv.Extensions()) == 0 || inv.Expiration() == nil {
+		return InvoiceExtendedEvent{}, false
+	}
+
+	return InvoiceExtendedEvent{
+		Event:         shared.EventTypeInvoiceExtended,
+		InvoiceID:     inv.ID(),
+		ExpiresAt:     inv.Expiration().ExpiresAt(),
+		TimeRemaining: int64(inv.Expiration().TimeRemaining().Seconds()),
+		Timestamp:     time.Now().UTC(),
+	}, true
+}
+
+// PaymentConfirmationEvent is the checkout page event for a new confirmation of a payment of the invoice.
+type PaymentConfirmationEvent struct {
+	Event                 string    `json:"event"`
+	InvoiceID             string    `json:"invoice_id"`
+	PaymentID             string    `json:"payment_id"`
+	TransactionHash       string    `json:"transaction_hash"`
+	Status                string    `json:"status"`
+	Confirmations         int       `json:"confirmations"`
+	RequiredConfirmations int       `json:"required_confirmations"`
+	BlockNumber           int64     `json:"block_number,omitempty"`
+	BlockHash             string    `json:"block_hash,omitempty"`
+	EstimatedFinalityAt   time.Time `json:"estimated_finality_at"`
+	Timestamp             time.Time `json:"timestamp"`
+}
+
+// ToPaymentConfirmationEvent builds the checkout page event for a published payment confirmation.
+func ToPaymentConfirmationEvent(event *shared.BaseDomainEvent) (PaymentConfirmationEvent, bool) {
+	data, ok := event.EventData.(shared.PaymentConfirmationEvent)
+	if !ok {
+		return PaymentConfirmationEvent{}, false
+	}
+
+	return PaymentConfirmationEvent{
+		Event:                 shared.EventTypePaymentConfirmation,
+		InvoiceID:             data.InvoiceID,
+		PaymentID:             data.PaymentID,
+		TransactionHash:       data.TransactionHash,
+		Status:                data.Status,
+		Confirmations:         data.Confirmations,
+		RequiredConfirmations: data.RequiredConfirmations,
+		BlockNumber:           data.BlockNumber,
+		BlockHash:             data.BlockHash,
+		EstimatedFinalityAt:   data.EstimatedFinalityAt,
+		Timestamp:             data.Timestamp,
+	}, true
+}
+
+// ListSettlementsRequest represents the query parameters for listing settlements.
+type ListSettlementsRequest struct {
+	StartDate *time.Time `form:"start_date"       time_format:"2006-01-02"`
+	EndDate   *time.Time `form:"end_date"         time_format:"2006-01-02"` // Inclusive
+	Status    string     `form:"status"           binding:"omitempty,oneof=pending completed failed reversed"`
+	Limit     int        `form:"limit,default=20" binding:"min=1,max=100"`
+	Cursor    string     `form:"cursor"`
+}
+
+// ListSettlementsResponse represents the response for listing settlements.
+type ListSettlementsResponse struct {
+	Settlements []SettlementResponse      `json:"settlements"`
+	Summary     SettlementSummaryResponse `json:"summary"`
+	// Time zone the start and end dates were read in, e.g. "UTC" or "Europe/Berlin"
+	ReportingTimeZone string `json:"reporting_time_zone"`
+	Limit             int    `json:"limit"`
+	NextCursor        string `json:"next_cursor,omitempty"`
+}
+
+// SettlementSummaryResponse totals the settlements matching a listing filter, net of the reversals recorded in
+// the period when no status is filtered.
+type SettlementSummaryResponse struct {
+	TotalGrossAmount     string `json:"total_gross_amount"`
+	TotalPlatformFees    string `json:"total_platform_fees"`
+	TotalNetAmount       string `json:"total_net_amount"`
+	AverageFeePercentage string `json:"average_fee_percentage"`
+	SettlementCount      int    `json:"settlement_count"`
+}
+
+// SettlementResponse represents what a merchant receives for a paid invoice.
+type SettlementResponse struct {
+	ID                    string                        `json:"id"`
+	InvoiceID             string                        `json:"invoice_id"`
+	MerchantID            string                        `json:"merchant_id"`
+	GrossAmount           string                        `json:"gross_amount"`
+	PlatformFeeAmount     string                        `json:"platform_fee_amount"`
+	PlatformFeePercentage string                        `json:"platform_fee_percentage"`
+	NetAmount             string                        `json:"net_amount"`
+	Currency              string                        `json:"currency"`
+	Status                string                        `json:"status"`
+	FailureReason         string                        `json:"failure_reason,omitempty"`
+	FeeTier               *SettlementFeeTierResponse    `json:"fee_tier,omitempty"` // How the platform fee was chosen
+	Conversion            *SettlementConversionResponse `json:"conversion,omitempty"`
+	PayoutID              string                        `json:"payout_id,omitempty"`
+	SplitOf               string                        `json:"split_of,omitempty"` // Settlement a beneficiary's share was split from
+	Splits                []SettlementSplitResponse     `json:"splits,omitempty"`   // Beneficiaries' shares split from the settlement
+	Reversal              *SettlementReversalResponse   `json:"reversal,omitempty"` // Present on a reversed settlement
+	SettledAt             *time.Time                    `json:"settled_at,omitempty"`
+	CreatedAt             time.Time                     `json:"created_at"`
+}
+
+// SettlementFeeTierResponse represents how the platform fee of a settlement was chosen: the merchant's or platform's
+// flat fee, or a volume tier, network rate or promotion of a fee schedule.
+type SettlementFeeTierResponse struct {
+	Schedule       string `json:"schedule,omitempty"`
+	Kind           string `json:"kind"`             // flat, volume, network or promotion
+	Name           string `json:"name"`             // Tier minimum volume, network, promotion, or merchant or platform
+	Volume         string `json:"volume,omitempty"` // 30-day settled volume the schedule's rate was chosen by
+	MinimumApplied bool   `json:"minimum_applied"`  // The schedule's minimum fee was charged
+}
+
+// SettlementSplitResponse represents a beneficiary merchant's share split from a settlement, settled and paid
+// out to the beneficiary.
+type SettlementSplitResponse struct {
+	ID                string `json:"id"`
+	BeneficiaryID     string `json:"beneficiary_id"`
+	GrossAmount       string `json:"gross_amount"`
+	PlatformFeeAmount string `json:"platform_fee_amount"`
+	NetAmount         string `json:"net_amount"`
+	Status            string `json:"status"`
+}
+
+// SettlementReversalResponse represents the ledger entry offsetting a settlement whose payment a chain
+// reorganization removed. Its amounts are negative.
+type SettlementReversalResponse struct {
+	ID                string    `json:"id"`
+	PaymentID         string    `json:"payment_id"`
+	GrossAmount       string    `json:"gross_amount"`
+	PlatformFeeAmount string    `json:"platform_fee_amount"`
+	NetAmount         string    `json:"net_amount"`
+	Reason            string    `json:"reason"`
+	PaidOut           bool      `json:"paid_out"` // The net amount was already paid out, so the merchant owes it
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// ToSettlementReversalResponse converts a settlement reversal to a response.
+func ToSettlementReversalResponse(reversal *settlement.Reversal) *SettlementReversalResponse {
+	return &SettlementReversalResponse{
+		ID:                reversal.ID(),
+		PaymentID:         reversal.PaymentID(),
+		GrossAmount:       reversal.GrossAmount().String(),
+		PlatformFeeAmount: reversal.FeeAmount().String(),
+		NetAmount:         reversal.NetAmount().String(),
+		Reason:            reversal.Reason(),
+		PaidOut:           reversal.PayoutID() != "",
+		CreatedAt:         reversal.CreatedAt(),
+	}
+}
+
+// SettlementConversionResponse represents the exchange order converting a settlement to fiat.
+type SettlementConversionResponse struct {
+	Exchange      string     `json:"exchange"`
+	OrderID       string     `json:"order_id"`
+	Status        string     `json:"status"`
+	Amount        string     `json:"amount"` // Cryptocurrency sold
+	FiatCurrency  string     `json:"fiat_currency"`
+	FiatAmount    string     `json:"fiat_amount,omitempty"` // Realized, net of the conversion fee
+	Fee           string     `json:"fee,omitempty"`
+	Rate          string     `json:"rate,omitempty"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	SubmittedAt   time.Time  `json:"submitted_at"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+}
+
+// ToSettlementResponse converts a domain settlement to a settlement response.
+func ToSettlementResponse(s *settlement.Settlement) SettlementResponse {
+	response := SettlementResponse{
+		ID:                    s.ID(),
+		InvoiceID:             s.InvoiceID(),
+		MerchantID:            s.MerchantID(),
+		GrossAmount:           s.GrossAmount().String(),
+		PlatformFeeAmount:     s.FeeAmount().String(),
+		PlatformFeePercentage: s.FeePercentage().String(),
+		NetAmount:             s.NetAmount().String(),
+		Currency:              s.Currency(),
+		Status:                string(s.Status()),
+		FailureReason:         s.FailureReason(),
+		PayoutID:              s.PayoutID(),
+		SplitOf:               s.SplitOf(),
+		SettledAt:             s.SettledAt(),
+		CreatedAt:             s.CreatedAt(),
+	}
+	if tier := s.FeeTier(); tier != nil {
+		response.FeeTier = &SettlementFeeTierResponse{
+			Schedule:       tier.Schedule,
+			Kind:           string(tier.Kind),
+			Name:           tier.Name,
+			MinimumApplied: tier.MinimumApplied,
+		}
+		if tier.Schedule != "" {
+			response.FeeTier.Volume = tier.Volume.String()
+		}
+	}
+
+	if conversion := s.Conversion(); conversion != nil {
+		response.Conversion = &SettlementConversionResponse{
+			Exchange:      conversion.Exchange(),
+			OrderID:       conversion.OrderID(),
+			Status:        string(conversion.Status()),
+			Amount:        conversion.Amount().String(),
+			FiatCurrency:  conversion.FiatCurrency(),
+			FailureReason: conversion.FailureReason(),
+			SubmittedAt:   conversion.SubmittedAt(),
+			CompletedAt:   conversion.CompletedAt(),
+		}
+		if conversion.Status() == settlement.ConversionStatusFilled {
+			response.Conversion.FiatAmount = conversion.FiatAmount().StringFixed(2)
+			response.Conversion.Fee = conversion.Fee().StringFixed(2)
+			response.Conversion.Rate = conversion.Rate().Round(6).String()
+		}
+	}
+	return response
+}
+
+// ToSettlementSplitResponses converts the shares split from a settlement to their response form, or returns nil
+// when there are none.
+func ToSettlementSplitResponses(splits []*settlement.Settlement) []SettlementSplitResponse {
+	if len(splits) == 0 {
+		return nil
+	}
+	responses := make([]SettlementSplitResponse, len(splits))
+	for i, split := range splits {
+		responses[i] = SettlementSplitResponse{
+			ID:                split.ID(),
+			BeneficiaryID:     split.MerchantID(),
+			GrossAmount:       split.GrossAmount().String(),
+			PlatformFeeAmount: split.FeeAmount().String(),
+			NetAmount:         split.NetAmount().String(),
+			Status:            string(split.Status()),
+		}
+	}
+	return responses
+}
+
+// ToSettlementSummaryResponse converts settlement totals to a summary response.
+func ToSettlementSummaryResponse(summary *settlement.Summary) SettlementSummaryResponse {
+	return SettlementSummaryResponse{
+		TotalGrossAmount:     summary.TotalGrossAmount.String(),
+		TotalPlatformFees:    summary.TotalFeeAmount.String(),
+		TotalNetAmount:       summary.TotalNetAmount.String(),
+		AverageFeePercentage: summary.AverageFeePercentage().String(),
+		SettlementCount:      summary.Count,
+	}
+}
+
+// AddPayoutWalletRequest represents the request to register a payout wallet.
+type AddPayoutWalletRequest struct {
+	Network string `json:"network" binding:"required,oneof=tron ethereum bitcoin"`
+	Address string `json:"address" binding:"required,min=10,max=128"`
+}
+
+// VerifyPayoutWalletRequest carries the signature of a payout wallet's verification challenge.
+type VerifyPayoutWalletRequest struct {
+	Signature string `json:"signature" binding:"required,max=1024"`
+}
+
+// PayoutWalletResponse represents a merchant payout wallet.
+type PayoutWalletResponse struct {
+	ID                 string     `json:"id"`
+	Network            string     `json:"network"`
+	Address            string     `json:"address"`
+	Status             string     `json:"status"`
+	Challenge          string     `json:"challenge,omitempty"` // Message to sign with the wallet's key
+	ChallengeExpiresAt *time.Time `json:"challenge_expires_at,omitempty"`
+	VerifiedAt         *time.Time `json:"verified_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// ListPayoutWalletsResponse represents the response for listing payout wallets.
+type ListPayoutWalletsResponse struct {
+	Wallets []PayoutWalletResponse `json:"wallets"`
+}
+
+// ListPayoutsRequest represents the query parameters for listing payouts.
+type ListPayoutsRequest struct {
+	Status string `form:"status"           binding:"omitempty,oneof=pending broadcast confirmed failed"`
+	Limit  int    `form:"limit,default=20" binding:"min=1,max=100"`
+	Cursor string `form:"cursor"`
+}
+
+// PayoutResponse represents an on-chain payout of settled funds.
+type PayoutResponse struct {
+	ID            string     `json:"id"`
+	WalletID      string     `json:"wallet_id"`
+	Network       string     `json:"network"`
+	Address       string     `json:"address"`
+	Amount        string     `json:"amount"`
+	Currency      string     `json:"currency"`
+	Status        string     `json:"status"`
+	TxHash        string     `json:"tx_hash,omitempty"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	SettlementIDs []string   `json:"settlement_ids"`
+	CreatedAt     time.Time  `json:"created_at"`
+	BroadcastAt   *time.Time `json:"broadcast_at,omitempty"`
+	ConfirmedAt   *time.Time `json:"confirmed_at,omitempty"`
+}
+
+// ListPayoutsResponse represents the response for listing payouts.
+type ListPayoutsResponse struct {
+	Payouts    []PayoutResponse `json:"payouts"`
+	Limit      int              `json:"limit"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// ToPayoutWalletResponse converts a domain payout wallet to a payout wallet response.
+func ToPayoutWalletResponse(w *payout.Wallet) PayoutWalletResponse {
+	response := PayoutWalletResponse{
+		ID:         w.ID(),
+		Network:    w.Network().String(),
+		Address:    w.Address(),
+		Status:     string(w.Status()),
+		VerifiedAt: w.VerifiedAt(),
+		CreatedAt:  w.CreatedAt(),
+	}
+	if !w.IsVerified() {
+		expiresAt := w.ChallengeExpiresAt()
+		response.Challenge = w.Challenge()
+		response.ChallengeExpiresAt = &expiresAt
+	}
+	return response
+}
+
+// ToPayoutResponse converts a domain payout to a payout response.
+func ToPayoutResponse(p *payout.Payout) PayoutResponse {
+	return PayoutResponse{
+		ID:            p.ID(),
+		WalletID:      p.WalletID(),
+		Network:       p.Network().String(),
+		Address:       p.Address(),
+		Amount:        p.Amount().String(),
+		Currency:      p.Currency(),
+		Status:        string(p.Status()),
+		TxHash:        p.TxHash(),
+		FailureReason: p.FailureReason(),
+		SettlementIDs: p.SettlementIDs(),
+		CreatedAt:     p.CreatedAt(),
+		BroadcastAt:   p.BroadcastAt(),
+		ConfirmedAt:   p.ConfirmedAt(),
+	}
+}
+
+// TreasuryBalanceResponse represents the last known hot wallet balance of a currency.
+type TreasuryBalanceResponse struct {
+	Network   string    `json:"network"`
+	Currency  string    `json:"currency"`
+	Balance   string    `json:"balance"`
+	Level     string    `json:"level"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// ListTreasuryBalancesResponse represents the response for listing hot wallet balances.
+type ListTreasuryBalancesResponse struct {
+	Balances []TreasuryBalanceResponse `json:"balances"`
+}
+
+// ListSweepsRequest represents the query parameters for listing sweeps.
+type ListSweepsRequest struct {
+	Status string `form:"status"           binding:"omitempty,oneof=pending broadcast confirmed failed skipped"`
+	Limit  int    `form:"limit,default=20" binding:"min=1,max=100"`
+	Cursor string `form:"cursor"`
+}
+
+// SweepResponse represents the sweep of a paid invoice's deposit address into the hot wallet.
+type SweepResponse struct {
+	ID            string     `json:"id"`
+	InvoiceID     string     `json:"invoice_id"`
+	MerchantID    string     `json:"merchant_id"`
+	Network       string     `json:"network"`
+	Currency      string     `json:"currency"`
+	FromAddress   string     `json:"from_address"`
+	Amount        string     `json:"amount"`
+	Status        string     `json:"status"`
+	TxHash        string     `json:"tx_hash,omitempty"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+}
+
+// ListSweepsResponse represents the response for listing sweeps.
+type ListSweepsResponse struct {
+	Sweeps     []SweepResponse `json:"sweeps"`
+	Limit      int             `json:"limit"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// CreateColdTransferRequest represents the request to move hot wallet funds to cold storage.
+type CreateColdTransferRequest struct {
+	Currency string `json:"currency" binding:"required,oneof=USDT BTC ETH"`
+	Amount   string `json:"amount"   binding:"required"`
+	Note     string `json:"note"     binding:"max=500"`
+}
+
+// ReviewColdTransferRequest represents an operator's approval or rejection of a cold storage transfer.
+type ReviewColdTransferRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
+
+// ListColdTransfersRequest represents the query parameters for listing cold storage transfers.
+type ListColdTransfersRequest struct {
+	Status string `form:"status"           binding:"omitempty,oneof=pending_approval approved rejected broadcast confirmed failed"`
+	Limit  int    `form:"limit,default=20" binding:"min=1,max=100"`
+	Cursor string `form:"cursor"`
+}
+
+// ColdTransferResponse represents a transfer from the hot wallet to cold storage.
+type ColdTransferResponse struct {
+	ID            string     `json:"id"`
+	Network       string     `json:"network"`
+	Currency      string     `json:"currency"`
+	ToAddress     string     `json:"to_address"`
+	Amount        string     `json:"amount"`
+	Note          string     `json:"note,omitempty"`
+	Status        string     `json:"status"`
+	RequestedBy   string     `json:"requested_by"`
+	ReviewedBy    string     `json:"reviewed_by,omitempty"`
+	ReviewNote    string     `json:"review_note,omitempty"`
+	TxHash        string     `json:"tx_hash,omitempty"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	BroadcastAt   *time.Time `json:"broadcast_at,omitempty"`
+	ConfirmedAt   *time.Time `json:"confirmed_at,omitempty"`
+}
+
+// ListColdTransfersResponse represents the response for listing cold storage transfers.
+type ListColdTransfersResponse struct {
+	Transfers  []ColdTransferResponse `json:"transfers"`
+	Limit      int                    `json:"limit"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+// ToTreasuryBalanceResponse converts a domain treasury balance to a balance response.
+func ToTreasuryBalanceResponse(b *treasury.Balance) TreasuryBalanceResponse {
+	return TreasuryBalanceResponse{
+		Network:   b.Network().String(),
+		Currency:  b.Currency(),
+		Balance:   b.Amount().String(),
+		Level:     string(b.Level()),
+		CheckedAt: b.CheckedAt(),
+	}
+}
+
+// ToSweepResponse converts a domain sweep to a sweep response.
+func ToSweepResponse(s *treasury.Sweep) SweepResponse {
+	return SweepResponse{
+		ID:            s.ID(),
+		InvoiceID:     s.InvoiceID(),
+		MerchantID:    s.MerchantID(),
+		Network:       s.Network().String(),
+		Currency:      s.Currency(),
+		FromAddress:   s.FromAddress(),
+		Amount:        s.Amount().String(),
+		Status:        string(s.Status()),
+		TxHash:        s.TxHash(),
+		FailureReason: s.FailureReason(),
+		CreatedAt:     s.CreatedAt(),
+		CompletedAt:   s.CompletedAt(),
+	}
+}
+
+// ToColdTransferResponse converts a domain cold storage transfer to a transfer response.
+func ToColdTransferResponse(t *treasury.ColdTransfer) ColdTransferResponse {
+	return ColdTransferResponse{
+		ID:            t.ID(),
+		Network:       t.Network().String(),
+		Currency:      t.Currency(),
+		ToAddress:     t.ToAddress(),
+		Amount:        t.Amount().String(),
+		Note:          t.Note(),
+		Status:        string(t.Status()),
+		RequestedBy:   t.RequestedBy(),
+		ReviewedBy:    t.ReviewedBy(),
+		ReviewNote:    t.ReviewNote(),
+		TxHash:        t.TxHash(),
+		FailureReason: t.FailureReason(),
+		CreatedAt:     t.CreatedAt(),
+		ReviewedAt:    t.ReviewedAt(),
+		BroadcastAt:   t.BroadcastAt(),
+		ConfirmedAt:   t.ConfirmedAt(),
+	}
+}
+
+// FeeEstimatesRequest represents the query for the current network fee of a transfer.
+type FeeEstimatesRequest struct {
+	Currency string `form:"currency" binding:"required,oneof=USDT BTC ETH"`
+}
+
+// FeeEstimateResponse represents the fee of a transfer at one speed.
+type FeeEstimateResponse struct {
+	Speed    string `json:"speed"`
+	Rate     string `json:"rate"`
+	RateUnit string `json:"rate_unit"`
+	Fee      string `json:"fee"`
+}
+
+// FeeEstimatesResponse represents the fee of a transfer at each speed.
+type FeeEstimatesResponse struct {
+	Network     string                `json:"network"`
+	Currency    string                `json:"currency"`
+	FeeCurrency string                `json:"fee_currency"`
+	Estimates   []FeeEstimateResponse `json:"estimates"`
+	EstimatedAt time.Time             `json:"estimated_at"`
+}
+
+// FeeSpendReportRequest represents the query for a fee spend report.
+type FeeSpendReportRequest struct {
+	StartDate *time.Time `form:"start_date" time_format:"2006-01-02"`
+	EndDate   *time.Time `form:"end_date"   time_format:"2006-01-02"` // Inclusive
+}
+
+// FeeSpendTotalResponse represents the fees paid by the transfers of one purpose on one network.
+type FeeSpendTotalResponse struct {
+	Network     string `json:"network"`
+	Purpose     string `json:"purpose"`
+	FeeCurrency string `json:"fee_currency"`
+	Transfers   int    `json:"transfers"`
+	Amount      string `json:"amount"`
+}
+
+// FeeSpendReportResponse represents the fees paid over a period.
+type FeeSpendReportResponse struct {
+	From   time.Time               `json:"from"`
+	To     time.Time               `json:"to"`
+	Totals []FeeSpendTotalResponse `json:"totals"`
+}
+
+// ToFeeEstimateResponse converts a domain fee estimate to an estimate response.
+func ToFeeEstimateResponse(e *fee.Estimate) FeeEstimateResponse {
+	return FeeEstimateResponse{
+		Speed:    string(e.Speed),
+		Rate:     e.Rate.String(),
+		RateUnit: e.RateUnit,
+		Fee:      e.Fee.String(),
+	}
+}
+
+// ToFeeSpendReportResponse converts a domain fee spend report to a report response.
+func ToFeeSpendReportResponse(r *fee.Report) FeeSpendReportResponse {
+	response := FeeSpendReportResponse{
+		From:   r.From,
+		To:     r.To,
+		Totals: make([]FeeSpendTotalResponse, len(r.Totals)),
+	}
+	for i, total := range r.Totals {
+		response.Totals[i] = FeeSpendTotalResponse{
+			Network:     total.Network.String(),
+			Purpose:     string(total.Purpose),
+			FeeCurrency: total.FeeCurrency,
+			Transfers:   total.Transfers,
+			Amount:      total.Amount.String(),
+		}
+	}
+	return response
+}
+
+// ListApprovalsRequest represents the query parameters for listing transfer approvals.
+type ListApprovalsRequest struct {
+	Kind   string `form:"kind"             binding:"omitempty,oneof=refund payout"`
+	Status string `form:"status"           binding:"omitempty,oneof=pending approved rejected"`
+	Limit  int    `form:"limit,default=20" binding:"min=1,max=100"`
+	Cursor string `form:"cursor"`
+}
+
+// ApprovalVoteRequest represents an approver's approval or rejection of a transfer.
+type ApprovalVoteRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+// ApprovalVoteResponse represents one approver's vote on a transfer.
+type ApprovalVoteResponse struct {
+	UserID   string    `json:"user_id"`
+	Decision string    `json:"decision"`
+	Note     string    `json:"note,omitempty"`
+	VotedAt  time.Time `json:"voted_at"`
+}
+
+// ApprovalResponse represents a refund or payout held until enough approvers sign off on it.
+type ApprovalResponse struct {
+	ID          string                 `json:"id"`
+	Kind        string                 `json:"kind"`
+	Reference   string                 `json:"reference"`
+	Currency    string                 `json:"currency"`
+	Amount      string                 `json:"amount"`
+	Reason      string                 `json:"reason,omitempty"`
+	RequestedBy string                 `json:"requested_by,omitempty"`
+	Status      string                 `json:"status"`
+	Required    int                    `json:"required"`
+	Approvals   int                    `json:"approvals"`
+	Votes       []ApprovalVoteResponse `json:"votes"`
+	CreatedAt   time.Time              `json:"created_at"`
+	DecidedAt   *time.Time             `json:"decided_at,omitempty"`
+}
+
+// ListApprovalsResponse represents the response for listing transfer approvals.
+type ListApprovalsResponse struct {
+	Approvals  []ApprovalResponse `json:"approvals"`
+	Limit      int                `json:"limit"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+// ToApprovalResponse converts a domain approval to an approval response.
+func ToApprovalResponse(a *approval.Approval) ApprovalResponse {
+	votes := a.Votes()
+	response := ApprovalResponse{
+		ID:          a.ID(),
+		Kind:        string(a.Kind()),
+		Reference:   a.Reference(),
+		Currency:    a.Currency(),
+		Amount:      a.Amount().String(),
+		Reason:      a.Reason(),
+		RequestedBy: a.RequestedBy(),
+		Status:      string(a.Status()),
+		Required:    a.Required(),
+		Approvals:   a.Approvals(),
+		Votes:       make([]ApprovalVoteResponse, len(votes)),
+		CreatedAt:   a.CreatedAt(),
+		DecidedAt:   a.DecidedAt(),
+	}
+	for i, vote := range votes {
+		response.Votes[i] = ApprovalVoteResponse{
+			UserID:   vote.UserID,
+			Decision: string(vote.Decision),
+			Note:     vote.Note,
+			VotedAt:  vote.VotedAt,
+		}
+	}
+	return response
+}
+
+// ListDeadLettersRequest represents the query parameters for listing dead letters.
+type ListDeadLettersRequest struct {
+	Handler   string `form:"handler"`
+	EventType string `form:"event_type"`
+	Status    string `form:"status"           binding:"omitempty,oneof=pending replayed"`
+	Limit     int    `form:"limit,default=20" binding:"min=1,max=100"`
+	Cursor    string `form:"cursor"`
+}
+
+// ReplayDeadLettersRequest represents the filter of a bulk dead letter replay.
+type ReplayDeadLettersRequest struct {
+	Handler   string `json:"handler,omitempty"`
+	EventType string `json:"event_type,omitempty"`
+}
+
+// PurgeDeadLettersRequest represents the query parameters for purging dead letters.
+type PurgeDeadLettersRequest struct {
+	Handler   string `form:"handler"`
+	EventType string `form:"event_type"`
+	Status    string `form:"status"     binding:"omitempty,oneof=pending replayed"`
+}
+
+// DeadLetterResponse represents an event an in-process handler failed on.
+type DeadLetterResponse struct {
+	ID            string                 `json:"id"`
+	Handler       string                 `json:"handler"`
+	EventType     string                 `json:"event_type"`
+	AggregateID   string                 `json:"aggregate_id"`
+	AggregateType string                 `json:"aggregate_type"`
+	OccurredAt    time.Time              `json:"occurred_at"`
+	EventData     interface{}            `json:"event_data,omitempty" swaggertype:"object"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	LastError     string                 `json:"last_error"`
+	Attempts      int                    `json:"attempts"`
+	Status        string                 `json:"status"`
+	CreatedAt     time.Time              `json:"created_at"`
+	LastFailedAt  time.Time              `json:"last_failed_at"`
+	ReplayedAt    *time.Time             `json:"replayed_at,omitempty"`
+}
+
+// ListDeadLettersResponse represents the response for listing dead letters.
+type ListDeadLettersResponse struct {
+	DeadLetters []DeadLetterResponse `json:"dead_letters"`
+	Limit       int                  `json:"limit"`
+	NextCursor  string               `json:"next_cursor,omitempty"`
+}
+
+// ReplayDeadLettersResponse represents the outcome of a bulk dead letter replay.
+type ReplayDeadLettersResponse struct {
+	Replayed int                  `json:"replayed"`
+	Failed   []DeadLetterResponse `json:"failed"`
+}
+
+// PurgeDeadLettersResponse represents the response for purging dead letters.
+type PurgeDeadLettersResponse struct {
+	Purged int `json:"purged"`
+}
+
+// ToDeadLetterResponse converts a domain dead letter to a dead letter response.
+func ToDeadLetterResponse(d *deadletter.DeadLetter) DeadLetterResponse {
+	event := d.Event()
+	return DeadLetterResponse{
+		ID:            d.ID(),
+		Handler:       d.Handler(),
+		EventType:     event.EventType,
+		AggregateID:   event.AggregateID,
+		AggregateType: event.AggregateType,
+		OccurredAt:    event.OccurredAt,
+		EventData:     event.EventData,
+		Metadata:      event.Metadata,
+		LastError:     d.LastError(),
+		Attempts:      d.Attempts(),
+		Status:        string(d.Status()),
+		CreatedAt:     d.CreatedAt(),
+		LastFailedAt:  d.LastFailedAt(),
+		ReplayedAt:    d.ReplayedAt(),
+	}
+}
+
+// ListSagasRequest represents the query parameters for listing payment settlement sagas.
+type ListSagasRequest struct {
+	InvoiceID  string `form:"invoice_id"`
+	MerchantID string `form:"merchant_id"`
+	Status     string `form:"status"           binding:"omitempty,oneof=running completed compensating compensated failed"`
+	Limit      int    `form:"limit,default=20" binding:"min=1,max=100"`
+	Cursor     string `form:"cursor"`
+}
+
+// SagaResponse represents the state of the saga settling a confirmed payment.
+type SagaResponse struct {
+	ID             string     `json:"id"`
+	InvoiceID      string     `json:"invoice_id"`
+	PaymentID      string     `json:"payment_id"`
+	MerchantID     string     `json:"merchant_id"`
+	SettlementID   string     `json:"settlement_id,omitempty"`
+	PayoutID       string     `json:"payout_id,omitempty"`
+	Status         string     `json:"status"`
+	Step           string     `json:"step"`
+	PayoutFailures int        `json:"payout_failures"`
+	Reason         string     `json:"reason,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+}
+
+// ListSagasResponse represents the response for listing payment settlement sagas.
+type ListSagasResponse struct {
+	Sagas      []SagaResponse `json:"sagas"`
+	Limit      int            `json:"limit"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// ToSagaResponse converts a domain saga to a saga response.
+func ToSagaResponse(s *saga.Saga) SagaResponse {
+	return SagaResponse{
+		ID:             s.ID(),
+		InvoiceID:      s.InvoiceID(),
+		PaymentID:      s.PaymentID(),
+		MerchantID:     s.MerchantID(),
+		SettlementID:   s.SettlementID(),
+		PayoutID:       s.PayoutID(),
+		Status:         string(s.Status()),
+		Step:           string(s.Step()),
+		PayoutFailures: s.PayoutFailures(),
+		Reason:         s.Reason(),
+		LastError:      s.LastError(),
+		CreatedAt:      s.CreatedAt(),
+		UpdatedAt:      s.UpdatedAt(),
+		CompletedAt:    s.CompletedAt(),
+	}
+}
+
+// GraphQLRequest represents a GraphQL query sent over HTTP.
+type GraphQLRequest struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// GraphQLResponse represents the result of a GraphQL query. Fields that failed to resolve are null in
+// data and reported in errors, with the error code in their extensions.
+type GraphQLResponse struct {
+	Data   map[string]interface{}   `json:"data,omitempty"`
+	Errors []map[string]interface{} `json:"errors,omitempty"`
+}
+
+// PaymentStatisticsRequest represents the query for payment statistics.
+type PaymentStatisticsRequest struct {
+	Interval string     `form:"interval,default=hour" binding:"oneof=hour day"`
+	From     *time.Time `form:"from"`
+	To       *time.Time `form:"to"`
+}
+
+// PaymentVolumeResponse represents the total amount of payments in one currency.
+type PaymentVolumeResponse struct {
+	Currency string `json:"currency"`
+	Amount   string `json:"amount"`
+}
+
+// PaymentStatusStatisticsResponse represents the payments that reached a status.
+type PaymentStatusStatisticsResponse struct {
+	Status  string                  `json:"status"`
+	Count   int                     `json:"count"`
+	Volumes []PaymentVolumeResponse `json:"volumes"`
+}
+
+// PaymentStatisticsSummaryResponse represents payment statistics over a whole period.
+type PaymentStatisticsSummaryResponse struct {
+	Statuses                  []PaymentStatusStatisticsResponse `json:"statuses"`
+	MedianConfirmationSeconds *float64                          `json:"median_confirmation_seconds"`
+	OrphanRate                float64                           `json:"orphan_rate"`
+}
+
+// PaymentStatisticsBucketResponse represents payment statistics of one hour or day.
+type PaymentStatisticsBucketResponse struct {
+	Start                     time.Time                         `json:"start"`
+	Statuses                  []PaymentStatusStatisticsResponse `json:"statuses"`
+	MedianConfirmationSeconds *float64                          `json:"median_confirmation_seconds"`
+	OrphanRate                float64                           `json:"orphan_rate"`
+}
+
+// PaymentStatisticsResponse represents payment statistics over a period, bucketed by hour or day.
+type PaymentStatisticsResponse struct {
+	Interval string                            `json:"interval"`
+	From     time.Time                         `json:"from"`
+	To       time.Time                         `json:"to"`
+	Summary  PaymentStatisticsSummaryResponse  `json:"summary"`
+	Buckets  []PaymentStatisticsBucketResponse `json:"buckets"`
+}
+
+// ToPaymentStatisticsResponse converts a domain statistics series to a statistics response.
+func ToPaymentStatisticsResponse(s *payment.StatisticsSeries) PaymentStatisticsResponse {
+	response := PaymentStatisticsResponse{
+		Interval: s.Interval.String(),
+		From:     s.From,
+		To:       s.To,
+		Summary:  toPaymentStatisticsSummaryResponse(s.Summary),
+		Buckets:  make([]PaymentStatisticsBucketResponse, len(s.Buckets)),
+	}
+	for i, bucket := range s.Buckets {
+		summary := toPaymentStatisticsSummaryResponse(bucket.StatisticsSummary)
+		response.Buckets[i] = PaymentStatisticsBucketResponse{
+			Start:                     bucket.Start,
+			Statuses:                  summary.Statuses,
+			MedianConfirmationSeconds: summary.MedianConfirmationSeconds,
+			OrphanRate:                summary.OrphanRate,
+		}
+	}
+	return response
+}
+
+// toPaymentStatisticsSummaryResponse converts domain statistics of a period to a summary response.
+func toPaymentStatisticsSummaryResponse(s *payment.StatisticsSummary) PaymentStatisticsSummaryResponse {
+	response := PaymentStatisticsSummaryResponse{
+		Statuses:   make([]PaymentStatusStatisticsResponse, len(s.Statuses)),
+		OrphanRate: s.OrphanRate,
+	}
+	for i, status := range s.Statuses {
+		volumes := make([]PaymentVolumeResponse, len(status.Volumes))
+		for j, volume := range status.Volumes {
+			volumes[j] = PaymentVolumeResponse{Currency: volume.Currency.String(), Amount: volume.Amount.String()}
+		}
+		response.Statuses[i] = PaymentStatusStatisticsResponse{
+			Status:  status.Status.String(),
+			Count:   status.Count,
+			Volumes: volumes,
+		}
+	}
+	if s.MedianConfirmationTime != nil {
+		seconds := s.MedianConfirmationTime.Seconds()
+		response.MedianConfirmationSeconds = &seconds
+	}
+	return response
+}
+
+// RuntimeSettingsResponse represents the runtime settings in force.
+type RuntimeSettingsResponse struct {
+	RateLimits    RateLimitsResponse                  `json:"rate_limits"`
+	Confirmations map[string]ConfirmationRuleResponse `json:"confirmations"`
+	Webhooks      WebhookRetryPolicyResponse          `json:"webhooks"`
+	Features      map[string]bool                     `json:"features"`
+	FeeSchedules  map[string]FeeScheduleResponse      `json:"fee_schedules"`
+}
+
+// FeeScheduleResponse represents a platform fee schedule. Percentages are in percent; volumes and minimum fees are
+// amounts in the settlement currency.
+type FeeScheduleResponse struct {
+	Merchants   []string               `json:"merchants"`
+	Tiers       []FeeTierResponse      `json:"tiers"`
+	Networks    map[string]string      `json:"networks"`
+	Promotions  []FeePromotionResponse `json:"promotions"`
+	MinimumFees map[string]string      `json:"minimum_fees"`
+}
+
+// FeeTierResponse represents the fee percentage of merchants whose 30-day settled volume reached min_volume.
+type FeeTierResponse struct {
+	MinVolume  string `json:"min_volume"`
+	Percentage string `json:"percentage"`
+}
+
+// FeePromotionResponse represents a window in which no platform fee is charged.
+type FeePromotionResponse struct {
+	Name     string    `json:"name"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// RateLimitsResponse represents the requests per minute each client IP may make; 0 is unlimited.
+type RateLimitsResponse struct {
+	API    int `json:"api"`
+	Public int `json:"public"`
+}
+
+// ConfirmationRuleResponse represents the confirmations payments on a network need: below for amounts under the
+// threshold and at_or_above for the others.
+type ConfirmationRuleResponse struct {
+	Threshold string `json:"threshold"`
+	Below     int    `json:"below"`
+	AtOrAbove int    `json:"at_or_above"`
+}
+
+// WebhookRetryPolicyResponse represents the retry policy of webhook endpoints created without their own.
+type WebhookRetryPolicyResponse struct {
+	MaxRetries int    `json:"max_retries"`
+	Backoff    string `json:"backoff"`
+}
+
+// ConfigChangeResponse represents a runtime setting changed by a reload.
+type ConfigChangeResponse struct {
+	Key    string `json:"key"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// ReloadConfigResponse represents the outcome of a configuration reload.
+type ReloadConfigResponse struct {
+	Changes []ConfigChangeResponse `json:"changes"`
+	// Config sections that changed but only apply after a restart
+	RestartRequired []string `json:"restart_required"`
+}
+
+// ToRuntimeSettingsResponse converts runtime settings to a response, listing every known feature flag.
+func ToRuntimeSettingsResponse(settings *runtimeconfig.Settings) RuntimeSettingsResponse {
+	response := RuntimeSettingsResponse{
+		RateLimits: RateLimitsResponse{
+			API:    settings.RateLimits.API,
+			Public: settings.RateLimits.Public,
+		},
+		Confirmations: make(map[string]ConfirmationRuleResponse, len(settings.Confirmations)),
+		Webhooks: WebhookRetryPolicyResponse{
+			MaxRetries: settings.WebhookMaxRetries,
+			Backoff:    string(settings.WebhookBackoff),
+		},
+		Features:     make(map[string]bool),
+		FeeSchedules: make(map[string]FeeScheduleResponse, len(settings.FeeSchedules)),
+	}
+	for network, rule := range settings.Confirmations {
+		response.Confirmations[string(network)] = ConfirmationRuleResponse{
+			Threshold: rule.Threshold.String(),
+			Below:     rule.Below,
+			AtOrAbove: rule.AtOrAbove,
+		}
+	}
+	for _, name := range runtimeconfig.Features() {
+		response.Features[name] = settings.FeatureEnabled(name)
+	}
+	for name, schedule := range settings.FeeSchedules {
+		response.FeeSchedules[name] = toFeeScheduleResponse(schedule, settings.FeeScheduleMerchants(name))
+	}
+	return response
+}
+
+// toFeeScheduleResponse converts a fee schedule, and the merchants it is assigned to, to a response.
+func toFeeScheduleResponse(schedule *settlement.FeeSchedule, merchants []string) FeeScheduleResponse {
+	response := FeeScheduleResponse{
+		Merchants:   merchants,
+		Tiers:       make([]FeeTierResponse, len(schedule.Tiers())),
+		Networks:    make(map[string]string, len(schedule.Networks())),
+		Promotions:  make([]FeePromotionResponse, len(schedule.Promotions())),
+		MinimumFees: make(map[string]string, len(schedule.MinimumFees())),
+	}
+	for i, tier := range schedule.Tiers() {
+		response.Tiers[i] = FeeTierResponse{MinVolume: tier.MinVolume.String(), Percentage: tier.Percentage.String()}
+	}
+	for network, percentage := range schedule.Networks() {
+		response.Networks[string(network)] = percentage.String()
+	}
+	for i, promotion := range schedule.Promotions() {
+		response.Promotions[i] = FeePromotionResponse{
+			Name:     promotion.Name,
+			StartsAt: promotion.StartsAt,
+			EndsAt:   promotion.EndsAt,
+		}
+	}
+	for currency, minimum := range schedule.MinimumFees() {
+		response.MinimumFees[currency] = minimum.String()
+	}
+	return response
+}
+
+// ToReloadConfigResponse converts the outcome of a reload to a response.
+func ToReloadConfigResponse(result *runtimeconfig.ReloadResult) ReloadConfigResponse {
+	response := ReloadConfigResponse{
+		Changes:         make([]ConfigChangeResponse, len(result.Changes)),
+		RestartRequired: result.RestartRequired,
+	}
+	for i, change := range result.Changes {
+		response.Changes[i] = ConfigChangeResponse{Key: change.Key, Before: change.Before, After: change.After}
+	}
+	return response
+}
+
+// AdminQueuesResponse represents the work waiting in the platform's queues.
+type AdminQueuesResponse struct {
+	// Active invoices past their expiry, awaiting the expiration sweep
+	ExpiredInvoices int `json:"expired_invoices"`
+	// Pending dead letters by the handler that failed on them
+	DeadLetters      map[string]int `json:"dead_letters"`
+	OpenReviews      int            `json:"open_reviews"`
+	PendingApprovals int            `json:"pending_approvals"`
+	PendingPayouts   int            `json:"pending_payouts"`
+	CheckedAt        time.Time      `json:"checked_at"`
+}
+
+// PlatformStatisticsResponse represents the invoices and payments of every merchant by status.
+type PlatformStatisticsResponse struct {
+	// Merchants that have created invoices
+	Merchants   int            `json:"merchants"`
+	Invoices    map[string]int `json:"invoices"`
+	Payments    map[string]int `json:"payments"`
+	GeneratedAt time.Time      `json:"generated_at"`
+}
+
+// ToAdminQueuesResponse converts queue counts to a response.
+func ToAdminQueuesResponse(queues *platform.Queues) AdminQueuesResponse {
+	return AdminQueuesResponse{
+		ExpiredInvoices:  queues.ExpiredInvoices,
+		DeadLetters:      queues.DeadLetters,
+		OpenReviews:      queues.OpenReviews,
+		PendingApprovals: queues.PendingApprovals,
+		PendingPayouts:   queues.PendingPayouts,
+		CheckedAt:        queues.CheckedAt,
+	}
+}
+
+// ToPlatformStatisticsResponse converts platform statistics to a response.
+func ToPlatformStatisticsResponse(statistics *platform.Statistics) PlatformStatisticsResponse {
+	return PlatformStatisticsResponse{
+		Merchants:   statistics.Merchants,
+		Invoices:    statistics.Invoices,
+		Payments:    statistics.Payments,
+		GeneratedAt: statistics.GeneratedAt,
+	}
+}
+
+// SystemStatusResponse represents the state of payment detection, webhooks and exchange rates.
+type SystemStatusResponse struct {
+	// Degraded while a network is, a rate's latest quote failed or an incident is active
+	Status    string                  `json:"status"    example:"operational"`
+	Networks  []NetworkStatusResponse `json:"networks"`
+	Webhooks  WebhookActivityResponse `json:"webhooks"`
+	Rates     []RateFreshnessResponse `json:"rates"`
+	Incidents []IncidentResponse      `json:"incidents"`
+	CheckedAt time.Time               `json:"checked_at"`
+}
+
+// NetworkStatusResponse represents how far payment detection is behind the chain head of a network.
+type NetworkStatusResponse struct {
+	Network string `json:"network" example:"ethereum"`
+	// Degraded while the last check failed or the lag exceeds the watcher's maximum
+	Status string `json:"status"  example:"operational"`
+	Head   int64  `json:"head"`
+	Cursor int64  `json:"cursor"`
+	// Blocks between the last block scanned and the chain head
+	Lag int64 `json:"lag"`
+	// Absent until the watcher has checked the network
+	CheckedAt *time.Time `json:"checked_at,omitempty"`
+}
+
+// WebhookActivityResponse represents the webhooks sent to merchants' endpoints within a window.
+type WebhookActivityResponse struct {
+	WindowSeconds int64 `json:"window_seconds" example:"3600"`
+	Sent          int   `json:"sent"`
+	Failed        int   `json:"failed"`
+	// Endpoints whose latest delivery within the window failed
+	FailingEndpoints int `json:"failing_endpoints"`
+}
+
+// RateFreshnessResponse represents how fresh the exchange rate of a currency pair is.
+type RateFreshnessResponse struct {
+	From   string `json:"from"   example:"USD"`
+	To     string `json:"to"     example:"USDT"`
+	Source string `json:"source" example:"fixed"`
+	// Absent if the pair was never quoted
+	QuotedAt   *time.Time `json:"quoted_at,omitempty"`
+	AgeSeconds int64      `json:"age_seconds"`
+	// True while the pair's latest quote failed
+	Stale bool `json:"stale"`
+}
+
+// IncidentResponse represents an incident declared by an operator.
+type IncidentResponse struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Message  string `json:"message,omitempty"`
+	Severity string `json:"severity" example:"minor"`
+	// Absent if the incident affects every network
+	Networks   []string   `json:"networks,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// AdminIncidentResponse represents an incident in admin responses, with who declared it.
+type AdminIncidentResponse struct {
+	IncidentResponse
+	CreatedBy string `json:"created_by"`
+}
+
+// ListIncidentsRequest represents the query parameters for listing incidents.
+type ListIncidentsRequest struct {
+	Active bool `form:"active"`
+	Limit  int  `form:"limit,default=20" binding:"min=1,max=100"`
+}
+
+// ListIncidentsResponse represents the incidents in admin responses.
+type ListIncidentsResponse struct {
+	Incidents []AdminIncidentResponse `json:"incidents"`
+}
+
+// DeclareIncidentRequest represents the request to declare an incident.
+type DeclareIncidentRequest struct {
+	Title    string `json:"title"    binding:"required,max=120"             example:"Delayed Ethereum payments"`
+	Message  string `json:"message"  binding:"max=1000"`
+	Severity string `json:"severity" binding:"required,oneof=minor major" example:"minor"`
+	// Networks the incident affects; every network if empty
+	Networks []string `json:"networks"`
+}
+
+// ToSystemStatusResponse converts the platform status to a response. Watcher and rate errors are left out, as
+// they may name the nodes and providers the platform uses.
+func ToSystemStatusResponse(status *platform.Status) SystemStatusResponse {
+	response := SystemStatusResponse{
+		Status:   string(status.Condition),
+		Networks: make([]NetworkStatusResponse, len(status.Networks)),
+		Webhooks: WebhookActivityResponse{
+			WindowSeconds:    int64(status.Webhooks.Window.Seconds()),
+			Sent:             status.Webhooks.Sent,
+			Failed:           status.Webhooks.Failed,
+			FailingEndpoints: status.Webhooks.FailingEndpoints,
+		},
+		Rates:     make([]RateFreshnessResponse, len(status.Rates)),
+		Incidents: make([]IncidentResponse, len(status.Incidents)),
+		CheckedAt: status.CheckedAt,
+	}
+	for i, network := range status.Networks {
+		response.Networks[i] = NetworkStatusResponse{
+			Network: string(network.Network),
+			Status:  string(network.Condition),
+			Head:    network.Head,
+			Cursor:  network.Cursor,
+			Lag:     network.Lag,
+		}
+		if !network.CheckedAt.IsZero() {
+			checkedAt := network.CheckedAt
+			response.Networks[i].CheckedAt = &checkedAt
+		}
+	}
+	for i, quote := range status.Rates {
+		response.Rates[i] = RateFreshnessResponse{
+			From:   string(quote.From),
+			To:     string(quote.To),
+			Source: quote.Source,
+			Stale:  quote.Stale(),
+		}
+		if !quote.QuotedAt.IsZero() {
+			quotedAt := quote.QuotedAt
+			response.Rates[i].QuotedAt = &quotedAt
+			response.Rates[i].AgeSeconds = int64(status.CheckedAt.Sub(quotedAt).Seconds())
+		}
+	}
+	for i, incident := range status.Incidents {
+		response.Incidents[i] = ToIncidentResponse(incident)
+	}
+	return response
+}
+
+// ToIncidentResponse converts a domain incident to an incident response.
+func ToIncidentResponse(incident *platform.Incident) IncidentResponse {
+	response := IncidentResponse{
+		ID:         incident.ID(),
+		Title:      incident.Title(),
+		Message:    incident.Message(),
+		Severity:   string(incident.Severity()),
+		StartedAt:  incident.StartedAt(),
+		ResolvedAt: incident.ResolvedAt(),
+	}
+	for _, network := range incident.Networks() {
+		response.Networks = append(response.Networks, string(network))
+	}
+	return response
+}
+
+// ToAdminIncidentResponse converts a domain incident to an admin incident response.
+func ToAdminIncidentResponse(incident *platform.Incident) AdminIncidentResponse {
+	return AdminIncidentResponse{
+		IncidentResponse: ToIncidentResponse(incident),
+		CreatedBy:        incident.CreatedBy(),
+	}
+}
+
+// StartBackfillRequest represents the request to rescan a range of blocks for missed payments.
+type StartBackfillRequest struct {
+	Network   string `json:"network"    binding:"required"       example:"ethereum"`
+	FromBlock *int64 `json:"from_block" binding:"required,min=0" example:"19000000"`
+	ToBlock   *int64 `json:"to_block"   binding:"required,min=0" example:"19000500"`
+}
+
+// BackfillResponse represents the progress of a payment backfill.
+type BackfillResponse struct {
+	ID               string     `json:"id"`
+	Network          string     `json:"network"`
+	FromBlock        int64      `json:"from_block"`
+	ToBlock          int64      `json:"to_block"`
+	Status           string     `json:"status"                example:"running"`
+	ScannedThrough   int64      `json:"scanned_through"`
+	BlocksScanned    int64      `json:"blocks_scanned"`
+	TotalBlocks      int64      `json:"total_blocks"`
+	TransfersFound   int        `json:"transfers_found"`
+	PaymentsRecorded int        `json:"payments_recorded"`
+	DepositsRecorded int        `json:"deposits_recorded"`
+	Duplicates       int        `json:"duplicates"`
+	Failures         int        `json:"failures"`
+	Errors           []string   `json:"errors,omitempty"`
+	Error            string     `json:"error,omitempty"`
+	StartedAt        time.Time  `json:"started_at"`
+	FinishedAt       *time.Time `json:"finished_at,omitempty"`
+}
+
+// ToBackfillResponse converts a domain backfill to a backfill response.
+func ToBackfillResponse(backfill *detection.Backfill) BackfillResponse {
+	return BackfillResponse{
+		ID:               backfill.ID,
+		Network:          string(backfill.Network),
+		FromBlock:        backfill.FromBlock,
+		ToBlock:          backfill.ToBlock,
+		Status:           string(backfill.Status),
+		ScannedThrough:   backfill.ScannedThrough,
+		BlocksScanned:    backfill.BlocksScanned(),
+		TotalBlocks:      backfill.TotalBlocks(),
+		TransfersFound:   backfill.TransfersFound,
+		PaymentsRecorded: backfill.PaymentsRecorded,
+		DepositsRecorded: backfill.DepositsRecorded,
+		Duplicates:       backfill.Duplicates,
+		Failures:         backfill.Failures,
+		Errors:           backfill.Errors,
+		Error:            backfill.Error,
+		StartedAt:        backfill.StartedAt,
+		FinishedAt:       backfill.FinishedAt,
+	}
+}
+
+// ListReconciliationReportsRequest represents the query parameters for listing reconciliation reports.
+type ListReconciliationReportsRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// DiscrepancyResponse represents a mismatch found by a reconciliation. Difference is actual minus expected.
+type DiscrepancyResponse struct {
+	Kind         string `json:"kind"                    example:"address_balance"`
+	Network      string `json:"network,omitempty"       example:"tron"`
+	Currency     string `json:"currency"                example:"USDT"`
+	Address      string `json:"address,omitempty"`
+	InvoiceID    string `json:"invoice_id,omitempty"`
+	SettlementID string `json:"settlement_id,omitempty"`
+	Expected     string `json:"expected"                example:"150.00"`
+	Actual       string `json:"actual"                  example:"149.5"`
+	Difference   string `json:"difference"              example:"-0.5"`
+}
+
+// ReconciliationReportResponse represents the outcome of a reconciliation of the ledger against the chain and
+// the settlements. Totals are the sums of the absolute differences by currency.
+type ReconciliationReportResponse struct {
+	ID               string                `json:"id"`
+	PeriodStart      time.Time             `json:"period_start"`
+	PeriodEnd        time.Time             `json:"period_end"`
+	AddressesChecked int                   `json:"addresses_checked"`
+	AddressesSkipped int                   `json:"addresses_skipped"`
+	InvoicesChecked  int                   `json:"invoices_checked"`
+	Discrepancies    []DiscrepancyResponse `json:"discrepancies"`
+	Totals           map[string]string     `json:"totals"`
+	Alerted          bool                  `json:"alerted"`
+	CreatedAt        time.Time             `json:"created_at"`
+}
+
+// ListReconciliationReportsResponse represents the latest reconciliation reports, newest first.
+type ListReconciliationReportsResponse struct {
+	Reports []ReconciliationReportResponse `json:"reports"`
+}
+
+// ToReconciliationReportResponse converts a reconciliation report to a report response.
+func ToReconciliationReportResponse(report *reconciliation.Report) ReconciliationReportResponse {
+	discrepancies := make([]DiscrepancyResponse, len(report.Discrepancies))
+	for i, d := range report.Discrepancies {
+		discrepancies[i] = DiscrepancyResponse{
+			Kind:         string(d.Kind),
+			Network:      string(d.Network),
+			Currency:     d.Currency,
+			Address:      d.Address,
+			InvoiceID:    d.InvoiceID,
+			SettlementID: d.SettlementID,
+			Expected:     d.Expected.String(),
+			Actual:       d.Actual.String(),
+			Difference:   d.Difference().String(),
+		}
+	}
+	totals := make(map[string]string)
+	for currency, total := range report.Totals() {
+		totals[currency] = total.String()
+	}
+
+	return ReconciliationReportResponse{
+		ID:               report.ID,
+		PeriodStart:      report.PeriodStart,
+		PeriodEnd:        report.PeriodEnd,
+		AddressesChecked: report.AddressesChecked,
+		AddressesSkipped: report.AddressesSkipped,
+		InvoicesChecked:  report.InvoicesChecked,
+		Discrepancies:    discrepancies,
+		Totals:           totals,
+		Alerted:          report.Alerted,
+		CreatedAt:        report.CreatedAt,
+	}
+}
+
+// ListStatementsRequest represents the query parameters for listing statements.
+type ListStatementsRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// GenerateStatementRequest represents a request to generate the statement of a month.
+type GenerateStatementRequest struct {
+	Period string `binding:"required" json:"period" example:"2026-09"`
+}
+
+// StatementCurrencyResponse represents a merchant's activity in one cryptocurrency over a statement's month.
+type StatementCurrencyResponse struct {
+	Currency    string `json:"currency"     example:"USDT"`
+	GrossVolume string `json:"gross_volume" example:"1500.00"` // Received by the settlements created in the month
+	Fees        string `json:"fees"         example:"15.00"`   // Platform fees of those settlements
+	Refunds     string `json:"refunds"      example:"40.00"`   // Refunds recorded in the month
+	NetSettled  string `json:"net_settled"  example:"1445.00"` // Settlements completed in the month, less reversals
+}
+
+// StatementResponse represents the monthly statement of a merchant.
+type StatementResponse struct {
+	ID            string                      `json:"id"`
+	Period        string                      `json:"period"         example:"2026-09"`
+	PeriodStart   time.Time                   `json:"period_start"`
+	PeriodEnd     time.Time                   `json:"period_end"`
+	Currencies    []StatementCurrencyResponse `json:"currencies"`
+	InvoiceCounts map[string]int              `json:"invoice_counts"` // Invoices created in the month by status
+	InvoiceTotal  int                         `json:"invoice_total"`
+	Formats       []string                    `json:"formats"        example:"pdf,csv"`
+	CreatedAt     time.Time                   `json:"created_at"`
+}
+
+// ListStatementsResponse represents a merchant's latest statements, latest month first.
+type ListStatementsResponse struct {
+	Statements []StatementResponse `json:"statements"`
+}
+
+// ToStatementResponse converts a statement to a statement response.
+func ToStatementResponse(stmt *statement.Statement) StatementResponse {
+	currencies := make([]StatementCurrencyResponse, len(stmt.Currencies))
+	for i, c := range stmt.Currencies {
+		currencies[i] = StatementCurrencyResponse{
+			Currency:    c.Currency,
+			GrossVolume: c.GrossVolume.String(),
+			Fees:        c.Fees.String(),
+			Refunds:     c.Refunds.String(),
+			NetSettled:  c.NetSettled.String(),
+		}
+	}
+	counts := stmt.InvoiceCounts
+	if counts == nil {
+		counts = map[string]int{}
+	}
+	formats := make([]string, 0, len(stmt.Files))
+	for _, format := range statement.Formats() {
+		if _, ok := stmt.Files[format]; ok {
+			formats = append(formats, string(format))
+		}
+	}
+
+	return StatementResponse{
+		ID:            stmt.ID,
+		Period:        stmt.Period(),
+		PeriodStart:   stmt.PeriodStart,
+		PeriodEnd:     stmt.PeriodEnd,
+		Currencies:    currencies,
+		InvoiceCounts: counts,
+		InvoiceTotal:  stmt.InvoiceTotal(),
+		Formats:       formats,
+		CreatedAt:     stmt.CreatedAt,
+	}
+}
+
+// ListWebhookDeliveriesRequest represents the query parameters for listing webhook deliveries.
+type ListWebhookDeliveriesRequest struct {
+	EndpointID string `form:"endpoint_id"`
+	Limit      int    `form:"limit,default=50" binding:"min=1,max=200"`
+}
+
+// WebhookDeliveryResponse represents a webhook delivery in API responses.
+type WebhookDeliveryResponse struct {
+	ID           string          `json:"id"`
+	EndpointID   string          `json:"endpoint_id"`
+	EventID      string          `json:"event_id"`
+	EventType    string          `json:"event_type"`
+	APIVersion   string          `json:"api_version,omitempty"`
+	Kind         string          `json:"kind"`
+	ReplayOf     string          `json:"replay_of,omitempty"`
+	URL          string          `json:"url"`
+	Status       string          `json:"status"`
+	ResponseCode int             `json:"response_code"` // 0 when the endpoint could not be reached
+	ResponseBody string          `json:"response_body,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	DurationMs   int64           `json:"duration_ms"`
+	Payload      json.RawMessage `json:"payload" swaggertype:"object"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// ListWebhookDeliveriesResponse represents the webhook delivery log in API responses.
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+}
+
+// ToWebhookDeliveryResponse converts a domain webhook delivery to a webhook delivery response.
+func ToWebhookDeliveryResponse(delivery *webhook.Delivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:           delivery.ID(),
+		EndpointID:   delivery.EndpointID(),
+		EventID:      delivery.EventID(),
+		EventType:    delivery.EventType(),
+		APIVersion:   delivery.APIVersion(),
+		Kind:         string(delivery.Kind()),
+		ReplayOf:     delivery.ReplayOf(),
+		URL:          delivery.URL(),
+		Status:       string(delivery.Status()),
+		ResponseCode: delivery.ResponseCode(),
+		ResponseBody: delivery.ResponseBody(),
+		Error:        delivery.Failure(),
+		DurationMs:   delivery.Duration().Milliseconds(),
+		Payload:      delivery.Payload(),
+		CreatedAt:    delivery.CreatedAt(),
+	}
+}
+
+// RotateWebhookSecretRequest represents the request to rotate a webhook endpoint's secret.
+type RotateWebhookSecretRequest struct {
+	// New secret of at least 32 characters; one is generated if empty
+	Secret string `json:"secret,omitempty" binding:"omitempty,min=32,max=200"`
+	// Hours the current secret signs webhooks as well; 24 if absent, 0 replaces it at once
+	OverlapHours *int `json:"overlap_hours,omitempty" binding:"omitempty,min=0,max=168" example:"24"`
+}
+
+// DisableWebhookEndpointsRequest represents the request to disable webhook endpoints at once.
+type DisableWebhookEndpointsRequest struct {
+	EndpointIDs []string `json:"endpoint_ids" binding:"required,min=1,max=100"`
+}
+
+// DisableWebhookEndpointsResponse represents the webhook endpoints disabled at once.
+type DisableWebhookEndpointsResponse struct {
+	Disabled []string `json:"disabled"`
+}
+
+// WebhookEndpointStatsRequest represents the query parameters for the delivery statistics of webhook endpoints.
+type WebhookEndpointStatsRequest struct {
+	Since  *time.Time `form:"since"  time_format:"2006-01-02T15:04:05Z07:00"`
+	Format string     `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+// WebhookEndpointStatsResponse represents the delivery statistics of a webhook endpoint.
+type WebhookEndpointStatsResponse struct {
+	EndpointID        string     `json:"endpoint_id"`
+	URL               string     `json:"url"`
+	Status            string     `json:"status"`
+	Deliveries        int        `json:"deliveries"`
+	Succeeded         int        `json:"succeeded"`
+	Failed            int        `json:"failed"`
+	SuccessRate       float64    `json:"success_rate" example:"0.98"` // Between 0 and 1; 0 without deliveries
+	AverageDurationMs int64      `json:"average_duration_ms"`
+	LastDeliveryAt    *time.Time `json:"last_delivery_at,omitempty"`
+	LastSuccessAt     *time.Time `json:"last_success_at,omitempty"`
+	LastFailureAt     *time.Time `json:"last_failure_at,omitempty"`
+}
+
+// ListWebhookEndpointStatsResponse represents the delivery statistics of a merchant's webhook endpoints.
+type ListWebhookEndpointStatsResponse struct {
+	Endpoints []WebhookEndpointStatsResponse `json:"endpoints"`
+}
+
+// WebhookEgressIPsResponse represents the IP ranges webhooks are sent from.
+type WebhookEgressIPsResponse struct {
+	IPRanges []string `json:"ip_ranges" example:"203.0.113.10/32,198.51.100.0/28"`
+}
+
+// ToWebhookEndpointStatsResponse converts the delivery statistics of an endpoint to a response.
+func ToWebhookEndpointStatsResponse(stats *webhook.EndpointStats) WebhookEndpointStatsResponse {
+	optional := func(t time.Time) *time.Time {
+		if t.IsZero() {
+			return nil
+		}
+		return &t
+	}
+	return WebhookEndpointStatsResponse{
+		EndpointID:        stats.EndpointID,
+		URL:               stats.URL,
+		Status:            string(stats.Status),
+		Deliveries:        stats.Deliveries,
+		Succeeded:         stats.Succeeded,
+		Failed:            stats.Failed,
+		SuccessRate:       stats.SuccessRate(),
+		AverageDurationMs: stats.AverageDuration().Milliseconds(),
+		LastDeliveryAt:    optional(stats.LastDeliveryAt),
+		LastSuccessAt:     optional(stats.LastSuccessAt),
+		LastFailureAt:     optional(stats.LastFailureAt),
+	}
+}
+
+// ExportArchivedInvoicesRequest represents the query parameters for exporting archived invoices.
+type ExportArchivedInvoicesRequest struct {
+	CreatedFrom *time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   *time.Time `form:"created_to"   time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// ArchivedInvoiceResponse represents one line of an archived invoice export.
+type ArchivedInvoiceResponse struct {
+	Invoice       CreateInvoiceResponse          `json:"invoice"`
+	Payments      []ArchivedPaymentResponse      `json:"payments"`
+	PaymentEvents []ArchivedPaymentEventResponse `json:"payment_events"`
+	ArchivedAt    time.Time                      `json:"archived_at"`
+}
+
+// ArchivedPaymentResponse represents a payment of an archived invoice.
+type ArchivedPaymentResponse struct {
+	ID            string     `json:"id"`
+	Network       string     `json:"network"`
+	TxHash        string     `json:"tx_hash"`
+	Amount        string     `json:"amount"`
+	FromAddress   string     `json:"from_address"`
+	ToAddress     string     `json:"to_address"`
+	Status        string     `json:"status"`
+	Confirmations int        `json:"confirmations"`
+	BlockNumber   *int64     `json:"block_number,omitempty"`
+	NetworkFee    string     `json:"network_fee,omitempty"`
+	DetectedAt    time.Time  `json:"detected_at"`
+	ConfirmedAt   *time.Time `json:"confirmed_at,omitempty"`
+}
+
+// ArchivedPaymentEventResponse represents an event in the history of a payment of an archived invoice.
+type ArchivedPaymentEventResponse struct {
+	PaymentID  string          `json:"payment_id"`
+	Version    int             `json:"version"`
+	Type       string          `json:"type"`
+	Data       json.RawMessage `json:"data" swaggertype:"object"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// ToArchivedInvoiceResponse converts an archived invoice to an export line.
+func ToArchivedInvoiceResponse(archived *archive.ArchivedInvoice) ArchivedInvoiceResponse {
+	resp := ArchivedInvoiceResponse{
+		Invoice:       ToCreateInvoiceResponse(archived.Invoice),
+		Payments:      make([]ArchivedPaymentResponse, len(archived.Payments)),
+		PaymentEvents: make([]ArchivedPaymentEventResponse, len(archived.PaymentEvents)),
+		ArchivedAt:    archived.ArchivedAt,
+	}
+	for i, p := range archived.Payments {
+		resp.Payments[i] = ArchivedPaymentResponse{
+			ID:            p.ID,
+			Network:       p.Network,
+			TxHash:        p.TxHash,
+			Amount:        p.Amount,
+			FromAddress:   p.FromAddress,
+			ToAddress:     p.ToAddress,
+			Status:        p.Status,
+			Confirmations: p.Confirmations,
+			BlockNumber:   p.BlockNumber,
+			NetworkFee:    p.NetworkFee,
+			DetectedAt:    p.DetectedAt,
+			ConfirmedAt:   p.ConfirmedAt,
+		}
+	}
+	for i, e := range archived.PaymentEvents {
+		resp.PaymentEvents[i] = ArchivedPaymentEventResponse{
+			PaymentID:  e.PaymentID,
+			Version:    e.Version,
+			Type:       e.Type,
+			Data:       e.Data,
+			OccurredAt: e.OccurredAt,
+		}
+	}
+	return resp
+}
+
+// RequestCustomerLoginLinkRequest represents a customer's request for an emailed login link. The code challenge
+// is BASE64URL(SHA256(code_verifier)) of a code verifier the browser keeps until it redeems the link.
+type RequestCustomerLoginLinkRequest struct {
+	Email               string `json:"email"                 binding:"required,max=254"`
+	CodeChallenge       string `json:"code_challenge"        binding:"required,len=43"`
+	CodeChallengeMethod string `json:"code_challenge_method" binding:"required,eq=S256"`
+}
+
+// RedeemCustomerLoginLinkRequest represents the redemption of a login link by the browser that requested it.
+type RedeemCustomerLoginLinkRequest struct {
+	Token        string `json:"token"         binding:"required,max=128"`
+	CodeVerifier string `json:"code_verifier" binding:"required,min=43,max=128"`
+}
+
+// CustomerSessionResponse represents a started customer session.
+type CustomerSessionResponse struct {
+	AccessToken string           `json:"access_token"`
+	TokenType   string           `json:"token_type"`
+	ExpiresAt   time.Time        `json:"expires_at"`
+	Customer    CustomerResponse `json:"customer"`
+}
+
+// CustomerResponse represents a customer account.
+type CustomerResponse struct {
+	ID          string     `json:"id"`
+	Email       string     `json:"email"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+// ClaimCustomerPaymentRequest represents a customer adding an invoice they paid or are paying to their history.
+type ClaimCustomerPaymentRequest struct {
+	InvoiceID string `json:"invoice_id" binding:"required,max=64"`
+}
+
+// ListCustomerPaymentsResponse represents a page of a customer's payment history.
+type ListCustomerPaymentsResponse struct {
+	Payments []CustomerPaymentResponse `json:"payments"`
+	Limit    int                       `json:"limit"`
+	Offset   int                       `json:"offset"`
+}
+
+// CustomerPaymentResponse represents an invoice in a customer's payment history.
+type CustomerPaymentResponse struct {
+	InvoiceID      string     `json:"invoice_id"`
+	Number         string     `json:"number,omitempty"`
+	MerchantID     string     `json:"merchant_id"`
+	Title          string     `json:"title"`
+	Status         string     `json:"status"`
+	Total          string     `json:"total"`
+	Currency       string     `json:"currency"`
+	CryptoAmount   string     `json:"crypto_amount"`
+	CryptoCurrency string     `json:"crypto_currency"`
+	Network        string     `json:"network"`
+	RefundAddress  string     `json:"refund_address,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	PaidAt         *time.Time `json:"paid_at,omitempty"`
+	// ReceiptAvailable is true once the invoice is paid; its receipt is at /payments/{id}/receipt.
+	ReceiptAvailable bool `json:"receipt_available"`
+}
+
+// SaveCustomerRefundAddressRequest represents the request to save a refund address to a customer account.
+type SaveCustomerRefundAddressRequest struct {
+	Address string `json:"address"         binding:"required,max=128"`
+	Label   string `json:"label,omitempty" binding:"max=64"`
+}
+
+// UseCustomerRefundAddressRequest represents the choice of a saved refund address for an invoice.
+type UseCustomerRefundAddressRequest struct {
+	RefundAddressID string `json:"refund_address_id" binding:"required"`
+}
+
+// ListCustomerRefundAddressesResponse represents a customer's saved refund addresses.
+type ListCustomerRefundAddressesResponse struct {
+	RefundAddresses []CustomerRefundAddressResponse `json:"refund_addresses"`
+}
+
+// CustomerRefundAddressResponse represents a saved refund address.
+type CustomerRefundAddressResponse struct {
+	ID        string    `json:"id"`
+	Address   string    `json:"address"`
+	Label     string    `json:"label,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToCustomerResponse converts a domain customer to a customer response.
+func ToCustomerResponse(c *customer.Customer) CustomerResponse {
+	return CustomerResponse{
+		ID:          c.ID(),
+		Email:       c.Email(),
+		CreatedAt:   c.CreatedAt(),
+		LastLoginAt: c.LastLoginAt(),
+	}
+}
+
+// ToCustomerPaymentResponse converts an invoice in a customer's payment history to a payment response.
+func ToCustomerPaymentResponse(inv *invoice.Invoice) CustomerPaymentResponse {
+	resp := CustomerPaymentResponse{
+		InvoiceID:        inv.ID(),
+		Number:           inv.Number(),
+		MerchantID:       inv.MerchantID(),
+		Title:            inv.Title(),
+		Status:           inv.Status().String(),
+		Total:            inv.Pricing().Total().String(),
+		Currency:         inv.Pricing().Total().Currency(),
+		CryptoAmount:     toCryptoAmount(inv),
+		CryptoCurrency:   inv.CryptoCurrency().String(),
+		Network:          toNetwork(inv),
+		CreatedAt:        inv.CreatedAt(),
+		PaidAt:           inv.PaidAt(),
+		ReceiptAvailable: inv.PaidAt() != nil,
+	}
+	if destination := inv.RefundDestination(); destination != nil {
+		resp.RefundAddress = destination.Address()
+	}
+	return resp
+}
+
+// ToCustomerRefundAddressResponse converts a saved refund address to a refund address response.
+func ToCustomerRefundAddressResponse(a *customer.RefundAddress) CustomerRefundAddressResponse {
+	return CustomerRefundAddressResponse{
+		ID:        a.ID(),
+		Address:   a.Address(),
+		Label:     a.Label(),
+		CreatedAt: a.CreatedAt(),
+	}
+}
+
+// EvidenceBundleResponse represents the sealed evidence of an invoice for a payment dispute. The manifest lists
+// the SHA-256 of each section's content as served; its digest and signature seal the bundle.
+type EvidenceBundleResponse struct {
+	Version     int                        `json:"version"`
+	InvoiceID   string                     `json:"invoice_id"`
+	MerchantID  string                     `json:"merchant_id"`
+	GeneratedAt time.Time                  `json:"generated_at"`
+	Sections    []EvidenceSectionResponse  `json:"sections"`
+	Manifest    string                     `json:"manifest"`
+	Digest      string                     `json:"digest"`              // Hex-encoded SHA-256 of the manifest
+	Signature   *EvidenceSignatureResponse `json:"signature,omitempty"` // Absent when no evidence keys are configured
+}
+
+// EvidenceSectionResponse represents a section of an evidence bundle.
+type EvidenceSectionResponse struct {
+	Name    string          `json:"name"`
+	SHA256  string          `json:"sha256"` // Hex-encoded hash of the content
+	Content json.RawMessage `json:"content" swaggertype:"object"`
+}
+
+// EvidenceSignatureResponse represents the signature of an evidence bundle's manifest.
+type EvidenceSignatureResponse struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"key_id"` // Key of GET /api/v1/public/evidence-keys that made the signature
+	Value     string `json:"value"`  // Base64-encoded signature
+}
+
+// EvidenceKeysResponse represents the public keys evidence bundles are verified with, as a JSON Web Key Set.
+type EvidenceKeysResponse struct {
+	Keys []RedirectKeyResponse `json:"keys"`
+}
+
+// EvidenceTimelineEntry represents something that happened to an invoice, its payments or its webhooks.
+type EvidenceTimelineEntry struct {
+	OccurredAt time.Time `json:"occurred_at"`
+	Source     string    `json:"source"` // invoice, payment, checkout, webhook or audit
+	Type       string    `json:"type"`
+	Reference  string    `json:"reference,omitempty"` // ID of the payment, refund, snapshot, delivery or audit entry
+	Summary    string    `json:"summary,omitempty"`
+}
+
+// EvidenceTransactionResponse represents a payment of an invoice with the events it went through.
+type EvidenceTransactionResponse struct {
+	Payment PaymentDetailResponse  `json:"payment"`
+	Events  []PaymentEventResponse `json:"events"`
+}
+
+// PaymentEventResponse represents an event in the history of a payment.
+type PaymentEventResponse struct {
+	PaymentID  string                   `json:"payment_id"`
+	Version    int                      `json:"version"`
+	Type       string                   `json:"type"`
+	Data       payment.PaymentEventData `json:"data"`
+	OccurredAt time.Time                `json:"occurred_at"`
+}
+
+// EvidenceSnapshotResponse represents what the checkout page showed the customer from the time it was captured
+// until the next snapshot.
+type EvidenceSnapshotResponse struct {
+	ID         string          `json:"id"`
+	SHA256     string          `json:"sha256"`
+	Content    json.RawMessage `json:"content" swaggertype:"object"`
+	CapturedAt time.Time       `json:"captured_at"`
+}
+
+// ToEvidenceBundleResponse converts a sealed evidence bundle to an evidence bundle response.
+func ToEvidenceBundleResponse(bundle *evidence.Bundle) EvidenceBundleResponse {
+	resp := EvidenceBundleResponse{
+		Version:     bundle.Version,
+		InvoiceID:   bundle.InvoiceID,
+		MerchantID:  bundle.MerchantID,
+		GeneratedAt: bundle.GeneratedAt,
+		Sections:    make([]EvidenceSectionResponse, len(bundle.Sections)),
+		Manifest:    bundle.Manifest,
+		Digest:      bundle.Digest,
+	}
+	for i, section := range bundle.Sections {
+		resp.Sections[i] = EvidenceSectionResponse{
+			Name:    section.Name,
+			SHA256:  section.SHA256,
+			Content: section.Content,
+		}
+	}
+	if signature := bundle.Signature; signature != nil {
+		resp.Signature = &EvidenceSignatureResponse{
+			Algorithm: signature.Algorithm,
+			KeyID:     signature.KeyID,
+			Value:     base64.StdEncoding.EncodeToString(signature.Value),
+		}
+	}
+	return resp
+}
+
+// ToEvidenceKeysResponse converts the keys of an evidence signer to a JSON Web Key Set, the signing key first.
+func ToEvidenceKeysResponse(signer *evidence.Signer) EvidenceKeysResponse {
+	resp := EvidenceKeysResponse{Keys: []RedirectKeyResponse{}}
+	publicKeys := signer.PublicKeys()
+	for _, id := range signer.KeyIDs() {
+		resp.Keys = append(resp.Keys, RedirectKeyResponse{
+			KeyType:   "OKP",
+			Curve:     "Ed25519",
+			KeyID:     id,
+			Use:       "sig",
+			Algorithm: "EdDSA",
+			X:         base64.RawURLEncoding.EncodeToString(publicKeys[id]),
+		})
+	}
+	return resp
+}
+
+// ToPaymentEventResponses converts the event stream of a payment to payment event responses.
+func ToPaymentEventResponses(events []*payment.PaymentEvent) []PaymentEventResponse {
+	responses := make([]PaymentEventResponse, len(events))
+	for i, event := range events {
+		responses[i] = PaymentEventResponse{
+			PaymentID:  event.PaymentID.String(),
+			Version:    event.Version,
+			Type:       string(event.Type),
+			Data:       event.Data,
+			OccurredAt: event.OccurredAt,
+		}
+	}
+	return responses
+}
+
+// toEvidenceSnapshotResponses converts checkout snapshots to snapshot responses.
+func toEvidenceSnapshotResponses(snapshots []*evidence.CheckoutSnapshot) []EvidenceSnapshotResponse {
+	responses := make([]EvidenceSnapshotResponse, len(snapshots))
+	for i, snapshot := range snapshots {
+		responses[i] = EvidenceSnapshotResponse{
+			ID:         snapshot.ID,
+			SHA256:     snapshot.SHA256,
+			Content:    snapshot.Content,
+			CapturedAt: snapshot.CapturedAt,
+		}
+	}
+	return responses
+}
+
+// CreateExperimentRequest represents the request payload for defining a checkout experiment.
+type CreateExperimentRequest struct {
+	Name     string                     `json:"name"     binding:"required,max=100"`
+	Variants []ExperimentVariantRequest `json:"variants" binding:"required,min=2,max=5,dive"`
+}
+
+// ExperimentVariantRequest represents a checkout page variant in an experiment definition.
+type ExperimentVariantRequest struct {
+	Key           string            `json:"key"                      binding:"required,max=32"`
+	Weight        int               `json:"weight"                   binding:"required,min=1,max=100"`
+	Copy          map[string]string `json:"copy,omitempty"` // Replacement messages by ID, e.g. "checkout.pay_with"
+	Layout        string            `json:"layout,omitempty"         binding:"omitempty,oneof=standard payment_first"`
+	CurrencyOrder []string          `json:"currency_order,omitempty"` // Currency symbols to offer first
+}
+
+// ExperimentResponse represents a checkout experiment.
+type ExperimentResponse struct {
+	ID        string                      `json:"id"`
+	Name      string                      `json:"name"`
+	Status    string                      `json:"status"`
+	Variants  []ExperimentVariantResponse `json:"variants"`
+	StartedAt *time.Time                  `json:"started_at,omitempty"`
+	StoppedAt *time.Time                  `json:"stopped_at,omitempty"`
+	CreatedAt time.Time                   `json:"created_at"`
+	UpdatedAt time.Time                   `json:"updated_at"`
+}
+
+// ExperimentVariantResponse represents a checkout page variant of an experiment.
+type ExperimentVariantResponse struct {
+	Key           string            `json:"key"`
+	Weight        int               `json:"weight"`
+	Copy          map[string]string `json:"copy,omitempty"`
+	Layout        string            `json:"layout"`
+	CurrencyOrder []string          `json:"currency_order,omitempty"`
+}
+
+// ListExperimentsResponse represents the response for listing checkout experiments.
+type ListExperimentsResponse struct {
+	Experiments []ExperimentResponse `json:"experiments"`
+}
+
+// ExperimentResultsResponse represents how the variants of a checkout experiment performed.
+type ExperimentResultsResponse struct {
+	ExperimentID string                  `json:"experiment_id"`
+	Name         string                  `json:"name"`
+	Status       string                  `json:"status"`
+	Variants     []VariantResultResponse `json:"variants"`
+}
+
+// VariantResultResponse represents how a checkout page variant performed.
+type VariantResultResponse struct {
+	Key            string            `json:"key"`
+	Sessions       int               `json:"sessions"`        // Distinct checkout sessions shown the variant
+	Checkouts      int               `json:"checkouts"`       // Invoices viewed with the variant
+	Conversions    int               `json:"conversions"`     // Of those invoices, the paid ones
+	ConversionRate float64           `json:"conversion_rate"` // Conversions per checkout, from 0 to 1
+	Volume         map[string]string `json:"volume"`          // Paid invoice totals by invoice currency
+}
+
+// ToExperimentResponse converts a domain experiment to an experiment response.
+func ToExperimentResponse(e *experiment.Experiment) ExperimentResponse {
+	variants := make([]ExperimentVariantResponse, len(e.Variants()))
+	for i, variant := range e.Variants() {
+		variants[i] = ExperimentVariantResponse{
+			Key:           variant.Key,
+			Weight:        variant.Weight,
+			Copy:          variant.Copy,
+			Layout:        variant.Layout.String(),
+			CurrencyOrder: variant.CurrencyOrder,
+		}
+	}
+
+	return ExperimentResponse{
+		ID:        e.ID(),
+		Name:      e.Name(),
+		Status:    e.Status().String(),
+		Variants:  variants,
+		StartedAt: e.StartedAt(),
+		StoppedAt: e.StoppedAt(),
+		CreatedAt: e.CreatedAt(),
+		UpdatedAt: e.UpdatedAt(),
+	}
+}
+
+// ToExperimentResultsResponse converts the results of an experiment to an experiment results response.
+func ToExperimentResultsResponse(results *experiment.Results) ExperimentResultsResponse {
+	variants := make([]VariantResultResponse, len(results.Variants))
+	for i, result := range results.Variants {
+		volume := make(map[string]string, len(result.Volume))
+		for currency, amount := range result.Volume {
+			volume[currency] = amount.StringFixed(2)
+		}
+		variants[i] = VariantResultResponse{
+			Key:            result.Variant,
+			Sessions:       result.Sessions,
+			Checkouts:      result.Checkouts,
+			Conversions:    result.Conversions,
+			ConversionRate: result.ConversionRate(),
+			Volume:         volume,
+		}
+	}
+
+	return ExperimentResultsResponse{
+		ExperimentID: results.Experiment.ID(),
+		Name:         results.Experiment.Name(),
+		Status:       results.Experiment.Status().String(),
+		Variants:     variants,
+	}
+}
+
+// CheckoutFunnelRequest represents the period of a checkout funnel summary.
+type CheckoutFunnelRequest struct {
+	StartDate *time.Time `form:"start_date" time_format:"2006-01-02"`
+	EndDate   *time.Time `form:"end_date"   time_format:"2006-01-02"` // Inclusive
+}
+
+// RecordCheckoutStepRequest represents a funnel step the checkout page reports.
+type RecordCheckoutStepRequest struct {
+	Step string `json:"step" binding:"required,oneof=address_copied" example:"address_copied"`
+}
+
+// CheckoutFunnelResponse represents the checkout page funnel of a merchant's invoices. Views are counted once
+// per invoice, by coarse attributes only.
+type CheckoutFunnelResponse struct {
+	Viewed          int     `json:"viewed"`
+	AddressCopied   int     `json:"address_copied"`
+	Paid            int     `json:"paid"`
+	AddressCopyRate float64 `json:"address_copy_rate"` // Share of viewed checkouts whose address was copied
+	ConversionRate  float64 `json:"conversion_rate"`   // Share of viewed checkouts that were paid
+	// Mean time from the first view to payment of the paid checkouts
+	AverageTimeToPaymentSeconds int64 `json:"average_time_to_payment_seconds"`
+	// Viewed checkouts by browser family, device class, referring host and country
+	Browsers  map[string]int `json:"browsers"`
+	Devices   map[string]int `json:"devices"`
+	Referrers map[string]int `json:"referrers"`
+	Countries map[string]int `json:"countries"`
+}
+
+// InvoiceFunnelResponse represents how far the checkout of an invoice went.
+type InvoiceFunnelResponse struct {
+	InvoiceID            string     `json:"invoice_id"`
+	Step                 string     `json:"step" example:"address_copied"` // Furthest step reached
+	Views                int        `json:"views"`
+	FirstViewedAt        time.Time  `json:"first_viewed_at"`
+	AddressCopiedAt      *time.Time `json:"address_copied_at,omitempty"`
+	PaidAt               *time.Time `json:"paid_at,omitempty"`
+	TimeToPaymentSeconds *int64     `json:"time_to_payment_seconds,omitempty"`
+}
+
+// ToCheckoutFunnelResponse converts a checkout funnel summary to a checkout funnel response.
+func ToCheckoutFunnelResponse(summary *checkoutanalytics.Summary) CheckoutFunnelResponse {
+	response := CheckoutFunnelResponse{
+		Viewed:                      summary.Viewed,
+		AddressCopied:               summary.AddressCopied,
+		Paid:                        summary.Paid,
+		ConversionRate:              summary.ConversionRate(),
+		AverageTimeToPaymentSeconds: int64(summary.AverageTimeToPayment.Seconds()),
+		Browsers:                    summary.Breakdown[checkoutanalytics.DimensionBrowser],
+		Devices:                     summary.Breakdown[checkoutanalytics.DimensionDevice],
+		Referrers:                   summary.Breakdown[checkoutanalytics.DimensionReferrer],
+		Countries:                   summary.Breakdown[checkoutanalytics.DimensionCountry],
+	}
+	if summary.Viewed > 0 {
+		response.AddressCopyRate = float64(summary.AddressCopied) / float64(summary.Viewed)
+	}
+	return response
+}
+
+// ToInvoiceFunnelResponse converts the checkout funnel of an invoice to an invoice funnel response.
+func ToInvoiceFunnelResponse(funnel *checkoutanalytics.Funnel) InvoiceFunnelResponse {
+	response := InvoiceFunnelResponse{
+		InvoiceID:       funnel.InvoiceID,
+		Step:            checkoutanalytics.StepViewed.String(),
+		Views:           funnel.Views,
+		FirstViewedAt:   funnel.FirstViewedAt,
+		AddressCopiedAt: funnel.AddressCopiedAt,
+		PaidAt:          funnel.PaidAt,
+	}
+	if funnel.AddressCopiedAt != nil {
+		response.Step = checkoutanalytics.StepAddressCopied.String()
+	}
+	if duration := funnel.TimeToPayment(); duration != nil {
+		response.Step = checkoutanalytics.StepPaid.String()
+		seconds := int64(duration.Seconds())
+		response.TimeToPaymentSeconds = &seconds
+	}
+	return response
+}
+
+// IssueGeoOverrideRequest represents the request to let an invoice's checkout be viewed from restricted countries.
+type IssueGeoOverrideRequest struct {
+	Reason string `json:"reason" binding:"required,max=500" example:"Customer identity verified"`
+	// Hours the override lasts, up to 168; 24 when omitted
+	TTLHours int `json:"ttl_hours,omitempty" binding:"omitempty,min=1,max=168" example:"24"`
+}
+
+// GeoOverrideResponse represents an issued geo-block override. Its token is only returned when it is issued.
+type GeoOverrideResponse struct {
+	ID        string `json:"id"`
+	InvoiceID string `json:"invoice_id"`
+	Token     string `json:"token" example:"gbo_3f9a..."`
+	// Checkout page link carrying the token, to share with the customer
+	CheckoutURL string    `json:"checkout_url"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// GeoBlockResponse represents the refused attempts to view an invoice's checkout from a restricted country.
+type GeoBlockResponse struct {
+	Country        string    `json:"country" example:"KP"`
+	Attempts       int       `json:"attempts"`
+	FirstBlockedAt time.Time `json:"first_blocked_at"`
+	LastBlockedAt  time.Time `json:"last_blocked_at"`
+}
+
+// GeoBlocksResponse lists the geo-blocks of an invoice's checkout.
+type GeoBlocksResponse struct {
+	InvoiceID string             `json:"invoice_id"`
+	Blocks    []GeoBlockResponse `json:"blocks"`
+}
+
+// ToGeoOverrideResponse converts an issued override to a geo override response.
+func ToGeoOverrideResponse(issued *geoblock.IssuedOverride) GeoOverrideResponse {
+	return GeoOverrideResponse{
+		ID:        issued.Override.ID,
+		InvoiceID: issued.Override.InvoiceID,
+		Token:     issued.Token,
+		// Tokens are hex with a prefix, safe in a query as they are
+		CheckoutURL: checkoutBaseURL + "/invoice/" + issued.Override.InvoiceID + "?" + geoOverrideParam + "=" +
+			issued.Token,
+		ExpiresAt: issued.Override.ExpiresAt,
+	}
+}
+
+// ToGeoBlockResponses converts the geo-blocks of an invoice's checkout to geo block responses.
+func ToGeoBlockResponses(blocks []*geoblock.Block) []GeoBlockResponse {
+	responses := make([]GeoBlockResponse, 0, len(blocks))
+	for _, block := range blocks {
+		responses = append(responses, GeoBlockResponse{
+			Country:        block.Country,
+			Attempts:       block.Attempts,
+			FirstBlockedAt: block.FirstBlockedAt,
+			LastBlockedAt:  block.LastBlockedAt,
+		})
+	}
+	return responses
+}
+
+// SLOResponse represents how a service level objective fares over its window. Compliance and
+// error_budget_remaining are fractions; the budget left is negative once it is overspent.
+type SLOResponse struct {
+	Name                 string    `json:"name"`
+	Description          string    `json:"description"`
+	Target               float64   `json:"target"`
+	WindowDays           int       `json:"window_days"`
+	Since                time.Time `json:"since"`
+	Good                 int64     `json:"good"`
+	Total                int64     `json:"total"`
+	Compliance           float64   `json:"compliance"`
+	ErrorBudgetRemaining float64   `json:"error_budget_remaining"`
+	BurnRate1h           float64   `json:"burn_rate_1h"`
+	BurnRate6h           float64   `json:"burn_rate_6h"`
+	AtRisk               bool      `json:"at_risk"`
+	Reason               string    `json:"reason,omitempty" enums:"fast_burn,slow_burn,budget_exhausted"`
+}
+
+// SLOReportResponse represents the service level objectives.
+type SLOReportResponse struct {
+	Objectives []SLOResponse `json:"objectives"`
+}
+
+// ToSLOReportResponse converts the statuses of the service level objectives to a report response.
+func ToSLOReportResponse(statuses []*slo.Status) SLOReportResponse {
+	resp := SLOReportResponse{Objectives: make([]SLOResponse, len(statuses))}
+	for i, status := range statuses {
+		resp.Objectives[i] = SLOResponse{
+			Name:                 status.Name,
+			Description:          status.Description,
+			Target:               status.Target,
+			WindowDays:           int(slo.Window.Hours() / 24),
+			Since:                status.Since,
+			Good:                 status.Good,
+			Total:                status.Total,
+			Compliance:           status.Compliance,
+			ErrorBudgetRemaining: status.ErrorBudgetRemaining,
+			BurnRate1h:           status.BurnRate1h,
+			BurnRate6h:           status.BurnRate6h,
+			AtRisk:               status.AtRisk,
+			Reason:               status.Reason,
+		}
+	}
+	return resp
+}
+
+// SetLogLevelRequest represents the level to set the logs of the application or a module to.
+type SetLogLevelRequest struct {
+	Level string `json:"level" binding:"required" enums:"debug,info,warn,error" example:"debug"`
+}
+
+// LogLevelsResponse represents the default log level and the levels of the modules set apart from it.
+type LogLevelsResponse struct {
+	Level   string            `json:"level" enums:"debug,info,warn,error,dpanic,panic,fatal"`
+	Modules map[string]string `json:"modules"`
+}
+
+// ToLogLevelsResponse converts the log levels to a response.
+func ToLogLevelsResponse(levels *logging.Levels) LogLevelsResponse {
+	resp := LogLevelsResponse{Level: levels.Level().String(), Modules: make(map[string]string)}
+	for module, level := range levels.Modules() {
+		resp.Modules[module] = level.String()
 	}
+	return resp
 }
@@ -1,7 +1,9 @@
 package web
 
 import (
+	"crypto-checkout/internal/domain/shared"
 	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
 	"fmt"
 	"html/template"
 	"os"
@@ -10,8 +12,14 @@ import (
 	"go.uber.org/zap"
 )
 
-// NewGinEngine creates a new Gin engine with appropriate configuration.
-func NewGinEngine(cfg *config.Config, logger *zap.Logger) *gin.Engine {
+// NewGinEngine creates a new Gin engine with appropriate configuration. Panics and 5xx responses are reported
+// to the error reporter, which may be nil.
+func NewGinEngine(
+	cfg *config.Config,
+	logger *zap.Logger,
+	bundle *i18n.Bundle,
+	reporter shared.ErrorReporter,
+) *gin.Engine {
 	// Set Gin mode based on configuration
 	if cfg.Log.Level == DebugLogLevel {
 		gin.SetMode(gin.DebugMode)
@@ -24,6 +32,9 @@ func NewGinEngine(cfg *config.Config, logger *zap.Logger) *gin.Engine {
 
 	router := gin.New()
 	router.Use(gin.Logger())
+	router.Use(RequestContextMiddleware())
+	router.Use(RequestDeadline(cfg.Server.RequestTimeout))
+	router.Use(SecurityHeaders(cfg))
 
 	// Load HTML templates using Go's embed package
 	// This embeds the templates directly into the binary, making them available
@@ -53,6 +64,14 @@ func NewGinEngine(cfg *config.Config, logger *zap.Logger) *gin.Engine {
 		c.Next()
 	}))
 
+	// Report panics before they reach the recovery above, and 5xx responses once the error handler has mapped them
+	if reporter != nil {
+		router.Use(ReportErrors(reporter))
+	}
+
+	// Translate error messages, including those written by the error handler below
+	router.Use(LocalizeErrors(bundle))
+
 	// Add custom error handling middleware
 	router.Use(ErrorHandler(cfg, logger))
 
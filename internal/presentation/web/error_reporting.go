@@ -0,0 +1,58 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportErrors reports the panics and 5xx responses of requests to the error reporter, tagged with the request
+// ID, the merchant, the route and the status. Panics are passed on to the recovery middleware.
+func ReportErrors(reporter shared.ErrorReporter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				tags := requestTags(c)
+				tags["status"] = strconv.Itoa(http.StatusInternalServerError)
+				reporter.CapturePanic(c.Request.Context(), recovered, debug.Stack(), tags)
+				panic(recovered)
+			}
+		}()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		if status < http.StatusInternalServerError {
+			return
+		}
+		err := errors.New(http.StatusText(status))
+		if len(c.Errors) > 0 {
+			err = c.Errors.Last().Err
+		}
+		reporter.CaptureError(c.Request.Context(), err, requestTags(c))
+	}
+}
+
+// requestTags returns the tags identifying a request in error reports.
+func requestTags(c *gin.Context) map[string]string {
+	route := c.FullPath()
+	if route == "" {
+		route = c.Request.URL.Path
+	}
+	tags := map[string]string{
+		"method": c.Request.Method,
+		"route":  route,
+		"status": strconv.Itoa(c.Writer.Status()),
+	}
+	if requestID := c.GetString("request_id"); requestID != "" {
+		tags["request_id"] = requestID
+	}
+	if merchantID := c.GetString("merchant_id"); merchantID != "" {
+		tags["merchant_id"] = merchantID
+	}
+	return tags
+}
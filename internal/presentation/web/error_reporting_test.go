@@ -0,0 +1,99 @@
+package web_test
+
+import (
+	"context"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// capturedReport is an error or panic the reporter was given.
+type capturedReport struct {
+	err       error
+	recovered any
+	tags      map[string]string
+}
+
+// recordingReporter keeps the reports it is given.
+type recordingReporter struct {
+	mu      sync.Mutex
+	reports []capturedReport
+}
+
+func (r *recordingReporter) CaptureError(_ context.Context, err error, tags map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, capturedReport{err: err, tags: tags})
+}
+
+func (r *recordingReporter) CapturePanic(_ context.Context, recovered any, _ []byte, tags map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, capturedReport{recovered: recovered, tags: tags})
+}
+
+func TestReportErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bundle, err := i18n.NewBundle()
+	require.NoError(t, err)
+	reporter := &recordingReporter{}
+	router := web.NewGinEngine(&config.Config{}, zap.NewNop(), bundle, reporter)
+
+	merchant := func(c *gin.Context) {
+		c.Set("merchant_id", "merchant-1")
+		c.Next()
+	}
+	router.GET("/panics/:id", merchant, func(*gin.Context) { panic("boom") })
+	router.GET("/fails", merchant, func(c *gin.Context) { _ = c.Error(errors.New("database unavailable")) })
+	router.GET("/missing", merchant, func(c *gin.Context) { c.JSON(http.StatusNotFound, gin.H{"error": "not found"}) })
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(web.RequestIDHeader, "req_test")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Panics_Are_Reported_Before_Recovery", func(t *testing.T) {
+		reporter.reports = nil
+		require.NotPanics(t, func() { get("/panics/42") })
+
+		require.Len(t, reporter.reports, 1)
+		report := reporter.reports[0]
+		assert.Equal(t, "boom", report.recovered)
+		assert.Equal(t, "/panics/:id", report.tags["route"])
+		assert.Equal(t, "req_test", report.tags["request_id"])
+		assert.Equal(t, "merchant-1", report.tags["merchant_id"])
+		assert.Equal(t, "500", report.tags["status"])
+	})
+
+	t.Run("Server_Errors_Are_Reported_With_Their_Cause", func(t *testing.T) {
+		reporter.reports = nil
+		w := get("/fails")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+		require.Len(t, reporter.reports, 1)
+		report := reporter.reports[0]
+		require.EqualError(t, report.err, "database unavailable")
+		assert.Equal(t, "GET", report.tags["method"])
+		assert.Equal(t, "500", report.tags["status"])
+	})
+
+	t.Run("Client_Errors_Are_Not_Reported", func(t *testing.T) {
+		reporter.reports = nil
+		w := get("/missing")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, reporter.reports)
+	})
+}
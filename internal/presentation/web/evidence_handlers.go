@@ -0,0 +1,405 @@
+package web
+
+import (
+	"cmp"
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/compliance"
+	"crypto-checkout/internal/domain/evidence"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/webhook"
+	"crypto-checkout/pkg/config"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Names of the sections of an invoice's evidence bundle.
+const (
+	evidenceSectionInvoice      = "invoice"
+	evidenceSectionTimeline     = "timeline"
+	evidenceSectionTransactions = "transactions"
+	evidenceSectionSnapshots    = "checkout_snapshots"
+	evidenceSectionDeliveries   = "webhook_deliveries"
+	evidenceSectionAuditLog     = "audit_log"
+)
+
+// EvidenceHandlers handles the evidence bundles merchants export for payment disputes.
+type EvidenceHandlers struct {
+	evidenceService evidence.Service
+	invoiceService  invoice.InvoiceService
+	paymentService  payment.PaymentService
+	reviewService   compliance.ReviewService
+	deliveryService webhook.Service
+	auditService    audit.Service
+	signer          *evidence.Signer
+	explorers       *shared.ExplorerResolver
+	logger          *zap.Logger
+}
+
+// NewEvidenceHandlers creates a new evidence handlers instance. The review service may be nil, in which case
+// transactions are exported without their screening.
+func NewEvidenceHandlers(
+	evidenceService evidence.Service,
+	invoiceService invoice.InvoiceService,
+	paymentService payment.PaymentService,
+	reviewService compliance.ReviewService,
+	deliveryService webhook.Service,
+	auditService audit.Service,
+	signer *evidence.Signer,
+	explorers *shared.ExplorerResolver,
+	logger *zap.Logger,
+) *EvidenceHandlers {
+	return &EvidenceHandlers{
+		evidenceService: evidenceService,
+		invoiceService:  invoiceService,
+		paymentService:  paymentService,
+		reviewService:   reviewService,
+		deliveryService: deliveryService,
+		auditService:    auditService,
+		signer:          signer,
+		explorers:       explorers,
+		logger:          logger,
+	}
+}
+
+// NewEvidenceSigner creates the evidence bundle signer of the configured keys, which are encoded like redirect
+// keys.
+func NewEvidenceSigner(cfg *config.Config) (*evidence.Signer, error) {
+	keys := make([]evidence.Key, 0, len(cfg.Evidence.Keys))
+	for _, keyConfig := range cfg.Evidence.Keys {
+		private, err := parseRedirectKey(keyConfig.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid evidence key %q: %w", keyConfig.ID, err)
+		}
+		keys = append(keys, evidence.Key{ID: keyConfig.ID, PrivateKey: private})
+	}
+	return evidence.NewSigner(keys...)
+}
+
+// ExportEvidence handles GET /invoices/:id/evidence
+// @Summary Export an evidence bundle
+// @Description Export the evidence of an invoice for a payment dispute: the invoice, a timeline of everything
+// @Description that happened to it, its transactions with their event history and screening, the checkout page
+// @Description snapshots the customer was shown, the webhooks sent about it and its audit log. Each section is
+// @Description hashed with SHA-256; the manifest lists the hashes with the time the bundle was generated and is
+// @Description signed with Ed25519 when evidence keys are configured. See GET /api/v1/public/evidence-keys.
+// @Tags Invoices
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} EvidenceBundleResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Invoice not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoices/{id}/evidence [get]
+func (h *EvidenceHandlers) ExportEvidence(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	inv, err := h.invoiceService.GetInvoice(ctx, c.Param("id"))
+	if err == nil && inv.MerchantID() != merchantID {
+		err = invoice.ErrNotFound
+	}
+	if err != nil {
+		h.respondError(c, err, "Failed to get invoice")
+		return
+	}
+
+	sections, err := h.collect(ctx, inv)
+	if err != nil {
+		h.respondError(c, err, "Failed to collect invoice evidence")
+		return
+	}
+	bundle, err := h.evidenceService.Seal(ctx, inv.ID(), merchantID, sections)
+	if err != nil {
+		h.respondError(c, err, "Failed to seal invoice evidence")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="evidence-%s.json"`, inv.ID()))
+	c.JSON(http.StatusOK, ToEvidenceBundleResponse(bundle))
+}
+
+// GetEvidenceKeys handles GET /api/v1/public/evidence-keys requests.
+// @Summary List evidence bundle keys
+// @Description Return the public keys evidence bundle signatures are verified with, as a JSON Web Key Set. The
+// @Description key_id of a bundle's signature names the key that made it; keys stay listed after a rotation so
+// @Description that earlier bundles keep verifying.
+// @Tags Public API
+// @Produce json
+// @Success 200 {object} EvidenceKeysResponse "Evidence bundle keys"
+// @Router /api/v1/public/evidence-keys [get]
+func (h *EvidenceHandlers) GetEvidenceKeys(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, ToEvidenceKeysResponse(h.signer))
+}
+
+// collect gathers the evidence sections of an invoice.
+func (h *EvidenceHandlers) collect(ctx context.Context, inv *invoice.Invoice) ([]evidence.Section, error) {
+	transactions, err := h.transactions(ctx, inv)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := h.evidenceService.ListSnapshots(ctx, inv.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list checkout snapshots: %w", err)
+	}
+	deliveries, err := h.deliveryService.ListDeliveries(ctx, &webhook.ListDeliveriesRequest{
+		MerchantID: inv.MerchantID(),
+		Reference:  inv.ID(),
+		Limit:      webhook.MaxListLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	entries, err := h.auditEntries(ctx, inv)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := []struct {
+		name    string
+		content any
+	}{
+		{evidenceSectionInvoice, ToCreateInvoiceResponse(inv)},
+		{evidenceSectionTimeline, toEvidenceTimeline(inv, transactions, snapshots, deliveries, entries)},
+		{evidenceSectionTransactions, transactions},
+		{evidenceSectionSnapshots, toEvidenceSnapshotResponses(snapshots)},
+		{evidenceSectionDeliveries, toWebhookDeliveryResponses(deliveries)},
+		{evidenceSectionAuditLog, toAuditLogResponses(entries)},
+	}
+	sections := make([]evidence.Section, len(contents))
+	for i, section := range contents {
+		content, err := json.Marshal(section.content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode evidence section %s: %w", section.name, err)
+		}
+		sections[i] = evidence.NewSection(section.name, content)
+	}
+	return sections, nil
+}
+
+// transactions returns the payments of an invoice with their screening and event history, oldest first.
+func (h *EvidenceHandlers) transactions(
+	ctx context.Context,
+	inv *invoice.Invoice,
+) ([]EvidenceTransactionResponse, error) {
+	payments, err := h.paymentService.ListPaymentsByInvoice(ctx, shared.InvoiceID(inv.ID()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invoice payments: %w", err)
+	}
+	slices.SortStableFunc(payments, func(a, b *payment.Payment) int {
+		return a.DetectedAt().Compare(b.DetectedAt())
+	})
+
+	transactions := make([]EvidenceTransactionResponse, len(payments))
+	for i, p := range payments {
+		var screening *compliance.Screening
+		if h.reviewService != nil {
+			screening, err = h.reviewService.GetPaymentScreening(ctx, &compliance.GetPaymentScreeningRequest{
+				MerchantID: inv.MerchantID(),
+				PaymentID:  p.ID().String(),
+			})
+			if err != nil && !errors.Is(err, compliance.ErrScreeningNotFound) {
+				return nil, fmt.Errorf("failed to get payment screening: %w", err)
+			}
+		}
+		events, err := h.paymentService.GetPaymentHistory(ctx, p.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get payment history: %w", err)
+		}
+		transactions[i] = EvidenceTransactionResponse{
+			Payment: ToPaymentDetailResponse(p, inv, screening, h.explorers),
+			Events:  ToPaymentEventResponses(events),
+		}
+	}
+	return transactions, nil
+}
+
+// auditEntries returns every audit entry of the invoice, newest first.
+func (h *EvidenceHandlers) auditEntries(ctx context.Context, inv *invoice.Invoice) ([]*audit.Entry, error) {
+	var entries []*audit.Entry
+	req := &audit.ListEntriesRequest{MerchantID: inv.MerchantID(), ResourceID: inv.ID(), Limit: 100}
+	for {
+		page, err := h.auditService.ListEntries(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list audit entries: %w", err)
+		}
+		entries = append(entries, page.Entries...)
+		if page.NextCursor == nil {
+			return entries, nil
+		}
+		req.Cursor = page.NextCursor
+	}
+}
+
+// toEvidenceTimeline merges what happened to an invoice, its payments and its webhooks into one timeline, oldest
+// first.
+func toEvidenceTimeline(
+	inv *invoice.Invoice,
+	transactions []EvidenceTransactionResponse,
+	snapshots []*evidence.CheckoutSnapshot,
+	deliveries []*webhook.Delivery,
+	entries []*audit.Entry,
+) []EvidenceTimelineEntry {
+	timeline := []EvidenceTimelineEntry{{
+		OccurredAt: inv.CreatedAt(),
+		Source:     "invoice",
+		Type:       "invoice.created",
+		Summary:    fmt.Sprintf("Invoice for %s created", inv.Pricing().Total()),
+	}}
+	if viewedAt := inv.ViewedAt(); viewedAt != nil {
+		timeline = append(timeline, EvidenceTimelineEntry{
+			OccurredAt: *viewedAt, Source: "invoice", Type: "invoice.viewed", Summary: "Checkout page first viewed",
+		})
+	}
+	for _, requote := range inv.Requotes() {
+		timeline = append(timeline, EvidenceTimelineEntry{
+			OccurredAt: requote.RequotedAt(),
+			Source:     "invoice",
+			Type:       "invoice.requoted",
+			Summary: fmt.Sprintf("Rate %s replaced by %s, amount %s replaced by %s",
+				requote.PreviousRate(), requote.NewRate(), requote.PreviousAmount(), requote.NewAmount()),
+		})
+	}
+	for _, extension := range inv.Extensions() {
+		timeline = append(timeline, EvidenceTimelineEntry{
+			OccurredAt: extension.ExtendedAt(),
+			Source:     "invoice",
+			Type:       "invoice.extended",
+			Summary:    fmt.Sprintf("Expiry pushed to %s", extension.ExpiresAt().UTC().Format(time.RFC3339)),
+		})
+	}
+	for _, check := range inv.StaleRateChecks() {
+		timeline = append(timeline, EvidenceTimelineEntry{
+			OccurredAt: check.CheckedAt(),
+			Source:     "invoice",
+			Type:       "invoice.stale_rate_checked",
+			Reference:  check.PaymentID(),
+			Summary: fmt.Sprintf("Payment after the rate expired: %s (locked rate %s, current rate %s)",
+				check.Action(), check.LockedRate(), check.CurrentRate()),
+		})
+	}
+	if paidAt := inv.PaidAt(); paidAt != nil {
+		timeline = append(timeline, EvidenceTimelineEntry{
+			OccurredAt: *paidAt, Source: "invoice", Type: "invoice.paid", Summary: "Invoice paid",
+		})
+	}
+	for _, reversal := range inv.PaymentReversals() {
+		timeline = append(timeline, EvidenceTimelineEntry{
+			OccurredAt: reversal.ReversedAt(),
+			Source:     "invoice",
+			Type:       "invoice.payment_reversed",
+			Reference:  reversal.PaymentID(),
+			Summary:    fmt.Sprintf("Payment of %s reversed: %s", reversal.Amount(), reversal.Reason()),
+		})
+	}
+	for _, refund := range inv.Refunds() {
+		timeline = append(timeline, EvidenceTimelineEntry{
+			OccurredAt: refund.CreatedAt(),
+			Source:     "invoice",
+			Type:       "invoice.refunded",
+			Reference:  refund.ID(),
+			Summary:    fmt.Sprintf("Refund of %s: %s", refund.Amount(), refund.Reason()),
+		})
+	}
+	for _, transaction := range transactions {
+		for _, event := range transaction.Events {
+			timeline = append(timeline, EvidenceTimelineEntry{
+				OccurredAt: event.OccurredAt,
+				Source:     "payment",
+				Type:       "payment." + event.Type,
+				Reference:  event.PaymentID,
+				Summary:    "Transaction " + transaction.Payment.TransactionHash,
+			})
+		}
+	}
+	for _, snapshot := range snapshots {
+		timeline = append(timeline, EvidenceTimelineEntry{
+			OccurredAt: snapshot.CapturedAt,
+			Source:     "checkout",
+			Type:       "checkout.shown",
+			Reference:  snapshot.ID,
+			Summary:    "Checkout page content sha256:" + snapshot.SHA256,
+		})
+	}
+	for _, delivery := range deliveries {
+		timeline = append(timeline, EvidenceTimelineEntry{
+			OccurredAt: delivery.CreatedAt(),
+			Source:     "webhook",
+			Type:       "webhook." + string(delivery.Status()),
+			Reference:  delivery.ID(),
+			Summary:    fmt.Sprintf("%s sent to %s", delivery.EventType(), delivery.URL()),
+		})
+	}
+	for _, entry := range entries {
+		timeline = append(timeline, EvidenceTimelineEntry{
+			OccurredAt: entry.OccurredAt(),
+			Source:     "audit",
+			Type:       entry.Action(),
+			Reference:  entry.ID(),
+			Summary:    fmt.Sprintf("By %s %s", entry.Actor().Type, entry.Actor().ID),
+		})
+	}
+
+	slices.SortStableFunc(timeline, func(a, b EvidenceTimelineEntry) int {
+		return cmp.Compare(a.OccurredAt.UnixNano(), b.OccurredAt.UnixNano())
+	})
+	return timeline
+}
+
+// toWebhookDeliveryResponses converts webhook deliveries to delivery responses.
+func toWebhookDeliveryResponses(deliveries []*webhook.Delivery) []WebhookDeliveryResponse {
+	responses := make([]WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = ToWebhookDeliveryResponse(delivery)
+	}
+	return responses
+}
+
+// toAuditLogResponses converts audit entries to audit log responses.
+func toAuditLogResponses(entries []*audit.Entry) []AuditLogResponse {
+	responses := make([]AuditLogResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = ToAuditLogResponse(entry)
+	}
+	return responses
+}
+
+// respondError maps an evidence export error to its HTTP response.
+func (h *EvidenceHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, shared.ErrNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", invoice.ErrCodeInvoiceNotFound, "Invoice not found"))
+	case errors.Is(err, evidence.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", evidence.ErrCodeInvalidRequest, err.Error()))
+	default:
+		h.logger.Error(message, zap.Error(err), zap.String("invoice_id", c.Param("id")))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterEvidenceRoutes registers the evidence routes: the export on the protected group and the public keys on
+// the v1 group.
+func (h *EvidenceHandlers) RegisterEvidenceRoutes(v1, protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionInvoicesRead)
+
+	v1.GET("/public/evidence-keys", h.GetEvidenceKeys)
+	protected.GET("/invoices/:id/evidence", require, rbac.AuditAction("invoice.evidence_export"), h.ExportEvidence)
+}
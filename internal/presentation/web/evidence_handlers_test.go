@@ -0,0 +1,269 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/evidence"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/webhook"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/test/testutil"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// invoiceDeliveries serves the webhook deliveries mentioning a reference, standing in for the webhook service.
+type invoiceDeliveries struct {
+	webhook.Service
+	deliveries []*webhook.Delivery
+}
+
+func (s *invoiceDeliveries) ListDeliveries(
+	_ context.Context,
+	req *webhook.ListDeliveriesRequest,
+) ([]*webhook.Delivery, error) {
+	var deliveries []*webhook.Delivery
+	for _, delivery := range s.deliveries {
+		if delivery.MerchantID() == req.MerchantID && bytes.Contains(delivery.Payload(), []byte(req.Reference)) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+// snapshotLog keeps checkout snapshots in memory, oldest first.
+type snapshotLog struct {
+	snapshots []*evidence.CheckoutSnapshot
+}
+
+func (r *snapshotLog) SaveSnapshot(_ context.Context, snapshot *evidence.CheckoutSnapshot) error {
+	r.snapshots = append(r.snapshots, snapshot)
+	return nil
+}
+
+func (r *snapshotLog) FindLatestSnapshot(ctx context.Context, invoiceID string) (*evidence.CheckoutSnapshot, error) {
+	snapshots, _ := r.ListSnapshots(ctx, invoiceID)
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+	return snapshots[len(snapshots)-1], nil
+}
+
+func (r *snapshotLog) ListSnapshots(_ context.Context, invoiceID string) ([]*evidence.CheckoutSnapshot, error) {
+	var snapshots []*evidence.CheckoutSnapshot
+	for _, snapshot := range r.snapshots {
+		if snapshot.InvoiceID == invoiceID {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots, nil
+}
+
+// resourceAuditLog serves the audit entries of a resource, standing in for the audit service.
+type resourceAuditLog struct {
+	audit.Service
+	entries []*audit.Entry
+}
+
+func (s *resourceAuditLog) ListEntries(
+	_ context.Context,
+	req *audit.ListEntriesRequest,
+) (*audit.ListEntriesResponse, error) {
+	var entries []*audit.Entry
+	for _, entry := range s.entries {
+		if entry.MerchantID() == req.MerchantID && entry.ResourceID() == req.ResourceID {
+			entries = append(entries, entry)
+		}
+	}
+	return &audit.ListEntriesResponse{Entries: entries, Limit: req.Limit}, nil
+}
+
+func TestEvidenceHandlers_ExportEvidence(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	handler, services := web.CreateTestHandlerWithServices()
+
+	unitPrice, err := shared.NewMoney("20.00", shared.CurrencyUSD)
+	require.NoError(t, err)
+	inv, err := services.Invoices.CreateInvoice(ctx, &invoice.CreateInvoiceRequest{
+		MerchantID:     "test-merchant",
+		Title:          "Disputed Invoice",
+		Items:          []*invoice.CreateInvoiceItemRequest{{Name: "Item", Quantity: "1", UnitPrice: unitPrice}},
+		TaxRate:        "0.00",
+		Currency:       shared.CurrencyUSD,
+		CryptoCurrency: shared.CryptoCurrencyUSDT,
+	})
+	require.NoError(t, err)
+
+	p, err := testutil.NewPaymentBuilder().
+		WithID("payment-evidence-1").
+		ForInvoice(inv.ID()).
+		WithAmount("20.00", shared.CryptoCurrencyUSDT).
+		WithTransactionHash(testutil.TestTransactionHash).
+		RequiringConfirmations(19).
+		Build()
+	require.NoError(t, err)
+	_, err = services.Payments.CreatePayment(ctx, &payment.CreatePaymentRequest{
+		ID:                    p.ID(),
+		InvoiceID:             p.InvoiceID(),
+		Amount:                p.Amount(),
+		FromAddress:           p.FromAddress(),
+		ToAddress:             p.ToAddress(),
+		TransactionHash:       p.TransactionHash(),
+		RequiredConfirmations: p.RequiredConfirmations(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, services.Payments.UpdateConfirmations(ctx, p.ID(), 3))
+
+	sentAt := inv.CreatedAt().Add(time.Minute)
+	delivery, err := webhook.RestoreDelivery("whd-1", "test-merchant", "whe-1", "evt_1", "invoice.paid", "2026-10-17",
+		webhook.DeliveryKindTest, "", "https://merchant.example/webhook",
+		[]byte(`{"id":"evt_1","data":{"invoice_id":"`+inv.ID()+`"}}`), webhook.DeliverySucceeded, 200, "ok", "",
+		80*time.Millisecond, sentAt)
+	require.NoError(t, err)
+	unrelated, err := webhook.RestoreDelivery("whd-2", "test-merchant", "whe-1", "evt_2", "invoice.paid",
+		"2026-10-17", webhook.DeliveryKindTest, "", "https://merchant.example/webhook",
+		[]byte(`{"id":"evt_2","data":{"invoice_id":"inv_other"}}`), webhook.DeliverySucceeded, 200, "ok", "",
+		80*time.Millisecond, sentAt)
+	require.NoError(t, err)
+	entry, err := audit.RestoreEntry("audit-1", "test-merchant", audit.Actor{ID: "user-1", Type: audit.ActorTypeUser},
+		audit.Origin{}, "invoice.update", "/api/v1/invoices/:id", inv.ID(), audit.Change{}, nil, sentAt)
+	require.NoError(t, err)
+
+	_, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	signer, err := web.NewEvidenceSigner(&config.Config{Evidence: config.EvidenceConfig{
+		Keys: []config.RedirectKeyConfig{{
+			ID: "evidence-2026", PrivateKey: base64.StdEncoding.EncodeToString(private.Seed()),
+		}},
+	}})
+	require.NoError(t, err)
+	evidenceService := evidence.NewService(&snapshotLog{}, signer, zap.NewNop())
+	handler.SetEvidenceService(evidenceService)
+
+	// The customer opens the checkout page twice; the unchanged page is kept once
+	router := gin.New()
+	router.GET("/api/v1/public/invoice/:id", handler.GetPublicInvoiceData)
+	for range 2 {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/public/invoice/"+inv.ID(), http.NoBody))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+
+	handlers := web.NewEvidenceHandlers(evidenceService, services.Invoices, services.Payments, nil,
+		&invoiceDeliveries{deliveries: []*webhook.Delivery{delivery, unrelated}},
+		&resourceAuditLog{entries: []*audit.Entry{entry}}, signer, shared.DefaultExplorerResolver(), zap.NewNop())
+	v1 := router.Group("/api/v1")
+	handlers.RegisterEvidenceRoutes(v1, v1.Group("", func(c *gin.Context) {
+		if merchantID := c.GetHeader("X-Merchant-ID"); merchantID != "" {
+			c.Set("merchant_id", merchantID)
+		}
+		c.Next()
+	}), nil)
+
+	export := func(id, merchantID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/invoices/"+id+"/evidence", http.NoBody)
+		if merchantID != "" {
+			req.Header.Set("X-Merchant-ID", merchantID)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Bundle_Verifies_With_The_Published_Key", func(t *testing.T) {
+		w := export(inv.ID(), "test-merchant")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, `attachment; filename="evidence-`+inv.ID()+`.json"`, w.Header().Get("Content-Disposition"))
+
+		var bundle web.EvidenceBundleResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &bundle))
+		require.Equal(t, inv.ID(), bundle.InvoiceID)
+
+		// Every section hashes to the manifest's line for it, as served
+		sections := make(map[string]json.RawMessage)
+		for _, section := range bundle.Sections {
+			sum := sha256.Sum256(section.Content)
+			require.Equal(t, hex.EncodeToString(sum[:]), section.SHA256, section.Name)
+			require.Contains(t, bundle.Manifest, "section "+section.Name+" sha256:"+section.SHA256+"\n")
+			sections[section.Name] = section.Content
+		}
+		sum := sha256.Sum256([]byte(bundle.Manifest))
+		require.Equal(t, hex.EncodeToString(sum[:]), bundle.Digest)
+
+		// The signature verifies against the public key served to anyone
+		keysW := httptest.NewRecorder()
+		router.ServeHTTP(keysW, httptest.NewRequest(http.MethodGet, "/api/v1/public/evidence-keys", http.NoBody))
+		require.Equal(t, http.StatusOK, keysW.Code)
+		var keys web.EvidenceKeysResponse
+		require.NoError(t, json.Unmarshal(keysW.Body.Bytes(), &keys))
+		require.Len(t, keys.Keys, 1)
+		require.NotNil(t, bundle.Signature)
+		require.Equal(t, keys.Keys[0].KeyID, bundle.Signature.KeyID)
+		public, err := base64.RawURLEncoding.DecodeString(keys.Keys[0].X)
+		require.NoError(t, err)
+		signature, err := base64.StdEncoding.DecodeString(bundle.Signature.Value)
+		require.NoError(t, err)
+		require.True(t, ed25519.Verify(public, []byte(bundle.Manifest), signature))
+
+		var transactions []web.EvidenceTransactionResponse
+		require.NoError(t, json.Unmarshal(sections["transactions"], &transactions))
+		require.Len(t, transactions, 1)
+		require.Equal(t, testutil.TestTransactionHash, transactions[0].Payment.TransactionHash)
+		require.Len(t, transactions[0].Events, 2)
+		require.Equal(t, "detected", transactions[0].Events[0].Type)
+
+		var snapshots []web.EvidenceSnapshotResponse
+		require.NoError(t, json.Unmarshal(sections["checkout_snapshots"], &snapshots))
+		require.Len(t, snapshots, 1)
+		require.Contains(t, string(snapshots[0].Content), inv.ID())
+
+		var deliveries []web.WebhookDeliveryResponse
+		require.NoError(t, json.Unmarshal(sections["webhook_deliveries"], &deliveries))
+		require.Len(t, deliveries, 1)
+		require.Equal(t, "whd-1", deliveries[0].ID)
+
+		var timeline []web.EvidenceTimelineEntry
+		require.NoError(t, json.Unmarshal(sections["timeline"], &timeline))
+		var types []string
+		for i, event := range timeline {
+			types = append(types, event.Type)
+			if i > 0 {
+				require.False(t, event.OccurredAt.Before(timeline[i-1].OccurredAt), "timeline is in order")
+			}
+		}
+		require.Equal(t, "invoice.created", types[0])
+		for _, want := range []string{"payment.detected", "checkout.shown", "webhook.succeeded", "invoice.update"} {
+			require.Contains(t, strings.Join(types, " "), want)
+		}
+	})
+
+	t.Run("Invoice_Of_Another_Merchant_Not_Found", func(t *testing.T) {
+		w := export(inv.ID(), "other-merchant")
+		require.Equal(t, http.StatusNotFound, w.Code)
+
+		var response web.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, invoice.ErrCodeInvoiceNotFound, response.Code)
+	})
+
+	t.Run("Merchant_Scope_Required", func(t *testing.T) {
+		require.Equal(t, http.StatusForbidden, export(inv.ID(), "").Code)
+	})
+}
@@ -0,0 +1,263 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/experiment"
+	"crypto-checkout/internal/domain/merchant"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ExperimentHandlers handles A/B experiments on the hosted checkout page.
+type ExperimentHandlers struct {
+	experimentService experiment.Service
+	logger            *zap.Logger
+}
+
+// NewExperimentHandlers creates a new experiment handlers instance.
+func NewExperimentHandlers(experimentService experiment.Service, logger *zap.Logger) *ExperimentHandlers {
+	return &ExperimentHandlers{
+		experimentService: experimentService,
+		logger:            logger,
+	}
+}
+
+// CreateExperiment handles POST /experiments
+// @Summary Define a checkout experiment
+// @Description Define a draft A/B experiment of checkout page variants. Variants may replace checkout page
+// @Description messages, show the payment panel first and offer some currencies first.
+// @Tags Experiments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body CreateExperimentRequest true "Experiment"
+// @Success 201 {object} ExperimentResponse "Experiment defined"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/experiments [post]
+func (h *ExperimentHandlers) CreateExperiment(c *gin.Context) {
+	var req CreateExperimentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind create experiment request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	variants := make([]experiment.Variant, len(req.Variants))
+	for i, variant := range req.Variants {
+		variants[i] = experiment.Variant{
+			Key:           variant.Key,
+			Weight:        variant.Weight,
+			Copy:          variant.Copy,
+			Layout:        experiment.Layout(variant.Layout),
+			CurrencyOrder: variant.CurrencyOrder,
+		}
+	}
+
+	created, err := h.experimentService.CreateExperiment(c.Request.Context(), &experiment.CreateExperimentRequest{
+		MerchantID: merchantID,
+		Name:       req.Name,
+		Variants:   variants,
+	})
+	if err != nil {
+		respondExperimentError(c, h.logger, err, "Failed to create experiment")
+		return
+	}
+
+	setAuditChange(c, nil, map[string]interface{}{
+		"name":     created.Name(),
+		"variants": len(created.Variants()),
+	})
+	c.JSON(http.StatusCreated, ToExperimentResponse(created))
+}
+
+// ListExperiments handles GET /experiments
+// @Summary List checkout experiments
+// @Tags Experiments
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} ListExperimentsResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/experiments [get]
+func (h *ExperimentHandlers) ListExperiments(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	experiments, err := h.experimentService.ListExperiments(c.Request.Context(), merchantID)
+	if err != nil {
+		respondExperimentError(c, h.logger, err, "Failed to list experiments")
+		return
+	}
+
+	response := ListExperimentsResponse{Experiments: make([]ExperimentResponse, len(experiments))}
+	for i, e := range experiments {
+		response.Experiments[i] = ToExperimentResponse(e)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetExperiment handles GET /experiments/:id
+// @Summary Get a checkout experiment
+// @Tags Experiments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Experiment ID"
+// @Success 200 {object} ExperimentResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Experiment not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/experiments/{id} [get]
+func (h *ExperimentHandlers) GetExperiment(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	e, err := h.experimentService.GetExperiment(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondExperimentError(c, h.logger, err, "Failed to get experiment")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToExperimentResponse(e))
+}
+
+// StartExperiment handles POST /experiments/:id/start
+// @Summary Start a checkout experiment
+// @Description Start showing checkout sessions the variants of a draft experiment. A merchant runs one
+// @Description experiment at a time.
+// @Tags Experiments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Experiment ID"
+// @Success 200 {object} ExperimentResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Experiment not found"
+// @Failure 409 {object} ErrorResponse "Experiment is not a draft or another experiment is running"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/experiments/{id}/start [post]
+func (h *ExperimentHandlers) StartExperiment(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	e, err := h.experimentService.StartExperiment(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondExperimentError(c, h.logger, err, "Failed to start experiment")
+		return
+	}
+
+	setAuditChange(c, map[string]interface{}{"status": experiment.StatusDraft.String()},
+		map[string]interface{}{"status": e.Status().String()})
+	c.JSON(http.StatusOK, ToExperimentResponse(e))
+}
+
+// StopExperiment handles POST /experiments/:id/stop
+// @Summary Stop a checkout experiment
+// @Description Stop assigning variants. Checkouts already assigned a variant keep counting conversions.
+// @Tags Experiments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Experiment ID"
+// @Success 200 {object} ExperimentResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Experiment not found"
+// @Failure 409 {object} ErrorResponse "Experiment is not running"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/experiments/{id}/stop [post]
+func (h *ExperimentHandlers) StopExperiment(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	e, err := h.experimentService.StopExperiment(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondExperimentError(c, h.logger, err, "Failed to stop experiment")
+		return
+	}
+
+	setAuditChange(c, map[string]interface{}{"status": experiment.StatusRunning.String()},
+		map[string]interface{}{"status": e.Status().String()})
+	c.JSON(http.StatusOK, ToExperimentResponse(e))
+}
+
+// GetExperimentResults handles GET /experiments/:id/results
+// @Summary Get checkout experiment results
+// @Description Sessions, checkouts, conversions and paid volume of each variant of an experiment
+// @Tags Experiments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Experiment ID"
+// @Success 200 {object} ExperimentResultsResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Experiment not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/experiments/{id}/results [get]
+func (h *ExperimentHandlers) GetExperimentResults(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	results, err := h.experimentService.GetResults(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondExperimentError(c, h.logger, err, "Failed to get experiment results")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToExperimentResultsResponse(results))
+}
+
+// RegisterExperimentRoutes registers checkout experiment routes. Defining and running experiments changes
+// what customers see and needs the settings permission; reading them needs the analytics permission.
+func (h *ExperimentHandlers) RegisterExperimentRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	manage := rbac.RequireRolePermission(merchant.PermissionSettingsManage)
+	read := rbac.RequireRolePermission(merchant.PermissionAnalyticsRead)
+
+	experiments := protected.Group("/experiments")
+	experiments.POST("", manage, rbac.AuditAction("experiment.create"), h.CreateExperiment)
+	experiments.GET("", read, h.ListExperiments)
+	experiments.GET("/:id", read, h.GetExperiment)
+	experiments.POST("/:id/start", manage, rbac.AuditAction("experiment.start"), h.StartExperiment)
+	experiments.POST("/:id/stop", manage, rbac.AuditAction("experiment.stop"), h.StopExperiment)
+	experiments.GET("/:id/results", read, h.GetExperimentResults)
+}
+
+// respondExperimentError maps experiment errors to HTTP responses.
+func respondExperimentError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, experiment.ErrExperimentNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", experiment.ErrCodeExperimentNotFound, "Experiment not found"))
+	case errors.Is(err, experiment.ErrExperimentRunning):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", experiment.ErrCodeExperimentRunning, err.Error()))
+	case errors.Is(err, experiment.ErrInvalidTransition):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", experiment.ErrCodeInvalidTransition, err.Error()))
+	case errors.Is(err, experiment.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
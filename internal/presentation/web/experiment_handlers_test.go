@@ -0,0 +1,182 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/experiment"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestExperimentHandlers_CheckoutVariants(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	conn, err := database.NewConnection(config.DatabaseConfig{URL: "sqlite://:memory:"}, logger)
+	require.NoError(t, err)
+	require.NoError(t, conn.Migrate())
+	experimentService := experiment.NewService(database.NewExperimentRepository(conn.DB, logger),
+		database.NewExperimentAssignmentRepository(conn.DB, logger), logger)
+
+	handler, services := web.CreateTestHandlerWithServices()
+	handler.SetExperimentService(experimentService)
+	bundle, err := i18n.NewBundle()
+	require.NoError(t, err)
+	router := web.NewGinEngine(&config.Config{}, logger, bundle, nil)
+	handler.RegisterRoutes(router)
+
+	api := router.Group("/api/v1/merchant", func(c *gin.Context) {
+		if merchantID := c.GetHeader("X-Merchant-ID"); merchantID != "" {
+			c.Set("merchant_id", merchantID)
+		}
+		c.Next()
+	})
+	web.NewExperimentHandlers(experimentService, logger).RegisterExperimentRoutes(api, nil)
+
+	call := func(method, path, body, merchantID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/v1/merchant"+path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		if merchantID != "" {
+			req.Header.Set("X-Merchant-ID", merchantID)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	unitPrice, err := shared.NewMoney("20.00", shared.CurrencyUSD)
+	require.NoError(t, err)
+	inv, err := services.Invoices.CreateInvoice(ctx, &invoice.CreateInvoiceRequest{
+		MerchantID:     "test-merchant",
+		Title:          "Experiment Invoice",
+		Items:          []*invoice.CreateInvoiceItemRequest{{Name: "Item", Quantity: "1", UnitPrice: unitPrice}},
+		TaxRate:        "0.00",
+		Currency:       shared.CurrencyUSD,
+		CryptoCurrency: shared.CryptoCurrencyUSDT,
+	})
+	require.NoError(t, err)
+
+	page := func(cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/invoice/"+inv.ID(), http.NoBody)
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		return w
+	}
+
+	t.Run("Standard_Page_Without_Experiment", func(t *testing.T) {
+		w := page()
+		require.Empty(t, w.Result().Cookies(), "no session is issued without an experiment")
+		require.NotContains(t, w.Body.String(), "data-checkout-variant")
+	})
+
+	// Both variants show the same changes, so the test does not depend on which one the session is assigned
+	variant := `{"key":"%s","weight":1,"copy":{"checkout.payment_details":"Pay in seconds"},` +
+		`"layout":"payment_first","currency_order":["eth"]}`
+	w := call(http.MethodPost, "/experiments", `{"name":"Payment first","variants":[`+
+		strings.Replace(variant, "%s", "a", 1)+","+strings.Replace(variant, "%s", "b", 1)+`]}`, "test-merchant")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created web.ExperimentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.Equal(t, "draft", created.Status)
+	require.Equal(t, []string{"ETH"}, created.Variants[0].CurrencyOrder)
+
+	t.Run("Create_Rejects_Invalid_Variants", func(t *testing.T) {
+		w := call(http.MethodPost, "/experiments",
+			`{"name":"Invalid","variants":[{"key":"a","weight":1},{"key":"a","weight":1}]}`, "test-merchant")
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		w = call(http.MethodPost, "/experiments", `{"name":"Unscoped","variants":[]}`, "")
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("Experiments_Are_Scoped_To_Their_Merchant", func(t *testing.T) {
+		w := call(http.MethodGet, "/experiments/"+created.ID, "", "other-merchant")
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+		w = call(http.MethodPost, "/experiments/"+created.ID+"/start", "", "other-merchant")
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	w = call(http.MethodPost, "/experiments/"+created.ID+"/start", "", "test-merchant")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = call(http.MethodPost, "/experiments/"+created.ID+"/start", "", "test-merchant")
+	require.Equal(t, http.StatusConflict, w.Code, "a running experiment cannot be started again")
+
+	var session *http.Cookie
+	t.Run("Checkout_Page_Shows_Assigned_Variant", func(t *testing.T) {
+		w := page()
+		for _, cookie := range w.Result().Cookies() {
+			if cookie.Name == "checkout_session" {
+				session = cookie
+			}
+		}
+		require.NotNil(t, session, "the checkout session is remembered")
+		require.True(t, session.HttpOnly)
+
+		body := w.Body.String()
+		require.Contains(t, body, "Pay in seconds")
+		require.Contains(t, body, "order-first")
+		require.Contains(t, body, `data-checkout-variant="`)
+		eth, usdt := strings.Index(body, `<option value="ETH"`), strings.Index(body, `<option value="USDT"`)
+		require.True(t, eth >= 0 && usdt > eth, "the variant's currencies are offered first")
+
+		// The session keeps its cookie on later visits
+		require.Empty(t, page(session).Result().Cookies())
+	})
+
+	t.Run("Paid_Invoice_Converts", func(t *testing.T) {
+		paid := experiment.NewPaidInvoiceHandler(experimentService, logger)
+		require.NoError(t, paid.HandleEvent(ctx, shared.NewInvoicePaidEvent(shared.InvoiceEvent{
+			InvoiceID: inv.ID(), MerchantID: "test-merchant", TotalAmount: "20.00", Currency: "USD", Status: "paid",
+		})))
+
+		w := call(http.MethodGet, "/experiments/"+created.ID+"/results", "", "test-merchant")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var results web.ExperimentResultsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &results))
+		require.Len(t, results.Variants, 2)
+
+		var checkouts, conversions int
+		for _, result := range results.Variants {
+			checkouts += result.Checkouts
+			conversions += result.Conversions
+			if result.Conversions > 0 {
+				require.Equal(t, 1, result.Sessions)
+				require.InDelta(t, 1.0, result.ConversionRate, 0.0001)
+				require.Equal(t, map[string]string{"USD": "20.00"}, result.Volume)
+			}
+		}
+		require.Equal(t, 1, checkouts, "repeat visits count as one checkout")
+		require.Equal(t, 1, conversions)
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		w := call(http.MethodPost, "/experiments/"+created.ID+"/stop", "", "test-merchant")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NotContains(t, page().Body.String(), "data-checkout-variant")
+
+		w = call(http.MethodGet, "/experiments", "", "test-merchant")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var listed web.ListExperimentsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+		require.Len(t, listed.Experiments, 1)
+		require.Equal(t, "stopped", listed.Experiments[0].Status)
+	})
+}
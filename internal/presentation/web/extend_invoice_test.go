@@ -0,0 +1,111 @@
+package web_test
+
+import (
+	"bytes"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestExtendInvoice(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	bundle, err := i18n.NewBundle()
+	require.NoError(t, err)
+	router := web.NewGinEngine(&config.Config{}, zap.NewNop(), bundle, nil)
+
+	handler := web.CreateTestHandler()
+	handler.RegisterRoutes(router)
+
+	request := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var requestBody []byte
+		if body != nil {
+			requestBody, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) web.CreateInvoiceResponse {
+		var response web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	create := func(t *testing.T) web.CreateInvoiceResponse {
+		w := request(http.MethodPost, "/api/v1/invoices", web.CreateInvoiceRequest{
+			Title:   "Software License",
+			Items:   []web.InvoiceItemRequest{{Name: "License", Quantity: "1", UnitPrice: "100.00"}},
+			TaxRate: "0.10",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		return decode(t, w)
+	}
+
+	t.Run("ExtendInvoice_PushesExpiry", func(t *testing.T) {
+		original := create(t)
+
+		w := request(http.MethodPost, "/api/v1/invoices/"+original.ID+"/extend",
+			web.ExtendInvoiceRequest{ExtendBy: 900})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		extended := decode(t, w)
+		require.Equal(t, original.ExpiresAt.Add(15*time.Minute), extended.ExpiresAt)
+
+		w = request(http.MethodGet, "/api/v1/invoices/"+original.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, extended.ExpiresAt, decode(t, w).ExpiresAt)
+	})
+
+	t.Run("ExtendInvoice_LimitExceeded", func(t *testing.T) {
+		original := create(t)
+
+		w := request(http.MethodPost, "/api/v1/invoices/"+original.ID+"/extend",
+			web.ExtendInvoiceRequest{ExtendBy: 2400})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = request(http.MethodPost, "/api/v1/invoices/"+original.ID+"/extend",
+			web.ExtendInvoiceRequest{ExtendBy: 2400})
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), invoice.ErrCodeExtensionLimit)
+		require.Contains(t, w.Body.String(), "20m0s of extension remaining")
+	})
+
+	t.Run("ExtendInvoice_Conflict", func(t *testing.T) {
+		cancelled := create(t)
+		w := request(http.MethodPost, "/api/v1/invoices/"+cancelled.ID+"/cancel",
+			web.CancelInvoiceRequest{Reason: "Customer request"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = request(http.MethodPost, "/api/v1/invoices/"+cancelled.ID+"/extend",
+			web.ExtendInvoiceRequest{ExtendBy: 600})
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), invoice.ErrCodeCannotExtend)
+	})
+
+	t.Run("ExtendInvoice_Invalid", func(t *testing.T) {
+		original := create(t)
+
+		w := request(http.MethodPost, "/api/v1/invoices/"+original.ID+"/extend", web.ExtendInvoiceRequest{})
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		w = request(http.MethodPost, "/api/v1/invoices/missing/extend", web.ExtendInvoiceRequest{ExtendBy: 600})
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
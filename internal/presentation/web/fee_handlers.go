@@ -0,0 +1,140 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/fee"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/payout"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// FeeHandlers handles network fee estimates and the platform's fee spend report.
+type FeeHandlers struct {
+	feeService fee.Service
+	logger     *zap.Logger
+}
+
+// NewFeeHandlers creates a new fee handlers instance.
+func NewFeeHandlers(feeService fee.Service, logger *zap.Logger) *FeeHandlers {
+	return &FeeHandlers{
+		feeService: feeService,
+		logger:     logger,
+	}
+}
+
+// GetEstimates handles GET /fees/estimates
+// @Summary Get network fee estimates
+// @Description Estimate the network fee of a refund or payout at each priority
+// @Tags Fees
+// @Produce json
+// @Security ApiKeyAuth
+// @Param currency query string true "Currency" Enums(USDT, BTC, ETH)
+// @Success 200 {object} FeeEstimatesResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 502 {object} ErrorResponse "Fee oracle failed"
+// @Failure 503 {object} ErrorResponse "Estimates unavailable for the network"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/fees/estimates [get]
+func (h *FeeHandlers) GetEstimates(c *gin.Context) {
+	var req FeeEstimatesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	network, err := payout.NetworkForCurrency(req.Currency)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	response := FeeEstimatesResponse{
+		Network:     network.String(),
+		Currency:    req.Currency,
+		FeeCurrency: fee.NativeCurrency(network),
+		Estimates:   make([]FeeEstimateResponse, 0, len(fee.Speeds)),
+	}
+	for _, speed := range fee.Speeds {
+		estimate, err := h.feeService.Estimate(c.Request.Context(), &fee.EstimateRequest{
+			Network:  network,
+			Currency: req.Currency,
+			Speed:    speed,
+		})
+		if err != nil {
+			h.respondError(c, err, "Failed to estimate network fee")
+			return
+		}
+		response.Estimates = append(response.Estimates, ToFeeEstimateResponse(estimate))
+		response.EstimatedAt = estimate.EstimatedAt
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetSpendReport handles GET /admin/fees/spend
+// @Summary Get the fee spend report
+// @Description Report the network fees the platform paid, by network
+// @Tags Admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date, inclusive (YYYY-MM-DD)"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} FeeSpendReportResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/fees/spend [get]
+func (h *FeeHandlers) GetSpendReport(c *gin.Context) {
+	var req FeeSpendReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	var reportReq fee.ReportRequest
+	if req.StartDate != nil {
+		reportReq.From = *req.StartDate
+	}
+	// The end date is inclusive, so the report runs to the start of the next day
+	if req.EndDate != nil {
+		reportReq.To = req.EndDate.Add(24 * time.Hour)
+	}
+
+	report, err := h.feeService.Report(c.Request.Context(), &reportReq)
+	if err != nil {
+		h.respondError(c, err, "Failed to report fee spend")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToFeeSpendReportResponse(report))
+}
+
+// respondError maps fee domain errors to HTTP responses.
+func (h *FeeHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, fee.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	case errors.Is(err, fee.ErrUnsupportedNetwork):
+		c.JSON(http.StatusServiceUnavailable, createAuthErrorResponse(
+			"service_unavailable", fee.ErrCodeEstimateUnavailable, err.Error()))
+	case errors.Is(err, fee.ErrOracleFailure):
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusBadGateway, createAuthErrorResponse(
+			"service_unavailable", fee.ErrCodeEstimateUnavailable, message))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterFeeRoutes registers the fee estimate and fee spend routes.
+func (h *FeeHandlers) RegisterFeeRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	protected.GET("/fees/estimates", rbac.RequireRolePermission(merchant.PermissionInvoicesRefund), h.GetEstimates)
+	protected.GET("/admin/fees/spend", rbac.RequireRolePermission(merchant.PermissionAdminOperations), h.GetSpendReport)
+}
@@ -0,0 +1,155 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/geoblock"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GeoBlockHandlers handles the checkouts refused in the countries merchants restrict, and the overrides that
+// let customers through.
+type GeoBlockHandlers struct {
+	geoBlocking    geoblock.Service
+	invoiceService invoice.InvoiceService
+	logger         *zap.Logger
+}
+
+// NewGeoBlockHandlers creates a new geo-block handlers instance.
+func NewGeoBlockHandlers(
+	geoBlocking geoblock.Service,
+	invoiceService invoice.InvoiceService,
+	logger *zap.Logger,
+) *GeoBlockHandlers {
+	return &GeoBlockHandlers{
+		geoBlocking:    geoBlocking,
+		invoiceService: invoiceService,
+		logger:         logger,
+	}
+}
+
+// IssueGeoOverride handles POST /invoices/:id/geo-overrides
+// @Summary Issue a geo-block override
+// @Description Let the checkout of an invoice be viewed from the countries the merchant restricts, for a customer
+// @Description the merchant has cleared. The token is only returned here; share the checkout URL carrying it with
+// @Description the customer. Issuing and first use of overrides are audited.
+// @Tags Invoices
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Invoice ID"
+// @Param request body IssueGeoOverrideRequest true "Override"
+// @Success 201 {object} GeoOverrideResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Invoice not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoices/{id}/geo-overrides [post]
+func (h *GeoBlockHandlers) IssueGeoOverride(c *gin.Context) {
+	var req IssueGeoOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	inv, ok := h.merchantInvoice(c)
+	if !ok {
+		return
+	}
+
+	issued, err := h.geoBlocking.IssueOverride(c.Request.Context(), &geoblock.IssueOverrideRequest{
+		InvoiceID:  inv.ID(),
+		MerchantID: inv.MerchantID(),
+		Reason:     req.Reason,
+		TTL:        time.Duration(req.TTLHours) * time.Hour,
+		IssuedBy:   auditActor(c),
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to issue geo-block override")
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToGeoOverrideResponse(issued))
+}
+
+// ListGeoBlocks handles GET /invoices/:id/geo-blocks
+// @Summary List the geo-blocks of an invoice
+// @Description Refused attempts to view the checkout of an invoice from the countries the merchant restricts, by
+// @Description country.
+// @Tags Invoices
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} GeoBlocksResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Invoice not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoices/{id}/geo-blocks [get]
+func (h *GeoBlockHandlers) ListGeoBlocks(c *gin.Context) {
+	inv, ok := h.merchantInvoice(c)
+	if !ok {
+		return
+	}
+
+	blocks, err := h.geoBlocking.ListBlocks(c.Request.Context(), inv.MerchantID(), inv.ID())
+	if err != nil {
+		h.respondError(c, err, "Failed to list geo-blocks")
+		return
+	}
+
+	c.JSON(http.StatusOK, GeoBlocksResponse{InvoiceID: inv.ID(), Blocks: ToGeoBlockResponses(blocks)})
+}
+
+// merchantInvoice returns the caller's invoice in the route, responding 403 without a merchant scope and 404 if
+// the merchant has no such invoice.
+func (h *GeoBlockHandlers) merchantInvoice(c *gin.Context) (*invoice.Invoice, bool) {
+	merchantID := c.GetString("merchant_id")
+	if merchantID == "" {
+		c.JSON(
+			http.StatusForbidden,
+			createAuthErrorResponse("authorization_error", "MERCHANT_SCOPE_REQUIRED",
+				"Geo-blocks require a merchant scope"),
+		)
+		return nil, false
+	}
+
+	inv, err := h.invoiceService.GetInvoice(c.Request.Context(), c.Param("id"))
+	if err == nil && inv.MerchantID() != merchantID {
+		err = invoice.ErrNotFound
+	}
+	if err != nil {
+		h.respondError(c, err, "Failed to get invoice")
+		return nil, false
+	}
+	return inv, true
+}
+
+// respondError maps geo-block errors to HTTP responses.
+func (h *GeoBlockHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, shared.ErrNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", invoice.ErrCodeInvoiceNotFound, "Invoice not found"))
+	case errors.Is(err, geoblock.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		h.logger.Error(message, zap.Error(err), zap.String("invoice_id", c.Param("id")))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterGeoBlockRoutes registers the geo-block routes on the protected group.
+func (h *GeoBlockHandlers) RegisterGeoBlockRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	protected.POST("/invoices/:id/geo-overrides", rbac.RequireRolePermission(merchant.PermissionComplianceReview),
+		h.IssueGeoOverride)
+	protected.GET("/invoices/:id/geo-blocks", rbac.RequireRolePermission(merchant.PermissionInvoicesRead),
+		h.ListGeoBlocks)
+}
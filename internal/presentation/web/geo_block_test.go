@@ -0,0 +1,127 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/geoblock"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// restrictedCountries restricts every merchant in the same countries.
+type restrictedCountries []string
+
+func (r restrictedCountries) RestrictedCountries(context.Context, string) ([]string, error) {
+	return r, nil
+}
+
+func TestGeoBlocking(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	conn, err := database.NewConnection(config.DatabaseConfig{URL: "sqlite://:memory:"}, logger)
+	require.NoError(t, err)
+	require.NoError(t, conn.Migrate())
+	geoBlocking := geoblock.NewService(database.NewGeoBlockRepository(conn.DB, logger), restrictedCountries{"KP"},
+		audit.NewService(database.NewAuditRepository(conn.DB, logger), logger), logger)
+
+	handler, services := web.CreateTestHandlerWithServices()
+	handler.SetGeoBlocking(geoBlocking)
+	handler.SetCountryLocator(countryLocator("kp"))
+	bundle, err := i18n.NewBundle()
+	require.NoError(t, err)
+	router := web.NewGinEngine(&config.Config{}, logger, bundle, nil)
+	handler.RegisterRoutes(router)
+
+	api := router.Group("/api/v1/merchant", func(c *gin.Context) {
+		c.Set("merchant_id", "test-merchant")
+		c.Next()
+	})
+	web.NewGeoBlockHandlers(geoBlocking, services.Invoices, logger).RegisterGeoBlockRoutes(api, nil)
+
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	unitPrice, err := shared.NewMoney("20.00", shared.CurrencyUSD)
+	require.NoError(t, err)
+	inv, err := services.Invoices.CreateInvoice(ctx, &invoice.CreateInvoiceRequest{
+		MerchantID:     "test-merchant",
+		Title:          "Restricted Invoice",
+		Items:          []*invoice.CreateInvoiceItemRequest{{Name: "Item", Quantity: "1", UnitPrice: unitPrice}},
+		TaxRate:        "0.00",
+		Currency:       shared.CurrencyUSD,
+		CryptoCurrency: shared.CryptoCurrencyUSDT,
+	})
+	require.NoError(t, err)
+
+	t.Run("Refused", func(t *testing.T) {
+		w := serve(httptest.NewRequest(http.MethodGet, "/invoice/"+inv.ID(), http.NoBody))
+		require.Equal(t, http.StatusUnavailableForLegalReasons, w.Code)
+		require.Contains(t, w.Body.String(), "not available in your region")
+		require.NotContains(t, w.Body.String(), "Restricted Invoice")
+
+		w = serve(httptest.NewRequest(http.MethodGet, "/api/v1/public/invoice/"+inv.ID(), http.NoBody))
+		require.Equal(t, http.StatusUnavailableForLegalReasons, w.Code)
+		require.Contains(t, w.Body.String(), geoblock.ErrCodeGeoRestricted)
+	})
+
+	var override web.GeoOverrideResponse
+	t.Run("Issue_Override", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/merchant/invoices/"+inv.ID()+"/geo-overrides",
+			bytes.NewBufferString(`{"reason":"customer verified","ttl_hours":2}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := serve(req)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &override))
+		require.True(t, strings.HasPrefix(override.Token, geoblock.OverrideTokenPrefix))
+		require.Contains(t, override.CheckoutURL, "/invoice/"+inv.ID()+"?geo_override="+override.Token)
+
+		req = httptest.NewRequest(http.MethodPost, "/api/v1/merchant/invoices/unknown/geo-overrides",
+			bytes.NewBufferString(`{"reason":"customer verified"}`))
+		req.Header.Set("Content-Type", "application/json")
+		require.Equal(t, http.StatusNotFound, serve(req).Code)
+	})
+
+	t.Run("Overridden", func(t *testing.T) {
+		w := serve(httptest.NewRequest(http.MethodGet, "/invoice/"+inv.ID()+"?geo_override="+override.Token,
+			http.NoBody))
+		require.Equal(t, http.StatusOK, w.Code)
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		require.Equal(t, "geo_override", cookies[0].Name)
+		require.True(t, cookies[0].HttpOnly)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/public/invoice/"+inv.ID(), http.NoBody)
+		req.AddCookie(cookies[0])
+		require.Equal(t, http.StatusOK, serve(req).Code, "the page's own requests carry the override")
+	})
+
+	t.Run("List_Blocks", func(t *testing.T) {
+		w := serve(httptest.NewRequest(http.MethodGet, "/api/v1/merchant/invoices/"+inv.ID()+"/geo-blocks",
+			http.NoBody))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var blocks web.GeoBlocksResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &blocks))
+		require.Len(t, blocks.Blocks, 1)
+		require.Equal(t, "KP", blocks.Blocks[0].Country)
+		require.Equal(t, 2, blocks.Blocks[0].Attempts)
+	})
+}
@@ -0,0 +1,122 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/geoblock"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"net/http"
+	"net/netip"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// geoOverrideParam carries a geo-block override token in the checkout links merchants share.
+	geoOverrideParam = "geo_override"
+	// geoOverrideCookie keeps the override of a checkout link for the page's own API calls and reloads.
+	geoOverrideCookie = "geo_override"
+	// checkoutUnavailableTemplate is the page shown instead of the checkout in restricted countries.
+	checkoutUnavailableTemplate = "checkout_unavailable.html"
+)
+
+// clientCountry returns the ISO code of the country the client was located in: by the edge proxy's country
+// header when one is configured, or else by the GeoIP database. It returns "" when the client cannot be located.
+func (h *Handler) clientCountry(c *gin.Context) string {
+	if h.config != nil && h.config.CheckoutAnalytics.CountryHeader != "" {
+		if country, ok := shared.ParseCountryCode(c.GetHeader(h.config.CheckoutAnalytics.CountryHeader)); ok {
+			return country
+		}
+	}
+	if h.countryLocator == nil {
+		return ""
+	}
+	addr, err := netip.ParseAddr(c.ClientIP())
+	if err != nil {
+		return ""
+	}
+	country, _ := shared.ParseCountryCode(h.countryLocator.Country(addr.Unmap()))
+	return country
+}
+
+// checkGeoRestrictions decides whether an invoice's checkout may be shown to the client. An override token in
+// the query is kept in a session cookie once it is accepted, so that the page's later requests carry it.
+func (h *Handler) checkGeoRestrictions(c *gin.Context, inv *invoice.Invoice) (*geoblock.Decision, error) {
+	if h.geoBlocking == nil {
+		return &geoblock.Decision{Allowed: true}, nil
+	}
+
+	token := c.Query(geoOverrideParam)
+	if token == "" {
+		token, _ = c.Cookie(geoOverrideCookie)
+	}
+	decision, err := h.geoBlocking.Check(c.Request.Context(), &geoblock.CheckRequest{
+		InvoiceID:     inv.ID(),
+		MerchantID:    inv.MerchantID(),
+		Country:       h.clientCountry(c),
+		OverrideToken: token,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if decision.Overridden && c.Query(geoOverrideParam) != "" {
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(geoOverrideCookie, token, 0, "/", "", c.Request.TLS != nil, true)
+	}
+	return decision, nil
+}
+
+// renderCheckoutUnavailable shows the page that tells a customer the checkout is not available in their region.
+func (h *Handler) renderCheckoutUnavailable(c *gin.Context, inv *invoice.Invoice) {
+	locale := h.checkoutLocale(c, inv.MerchantID())
+	c.Header("Cache-Control", "no-store")
+	c.HTML(http.StatusUnavailableForLegalReasons, checkoutUnavailableTemplate, gin.H{
+		"Locale":    locale.String(),
+		"T":         h.bundle.Messages(locale, "checkout."),
+		"InvoiceID": inv.ID(),
+	})
+}
+
+// refuseRestricted refuses the public API of an invoice to clients in the countries its merchant restricts, with
+// 451 GEO_RESTRICTED. Requests for invoices that cannot be found pass on for the handlers to answer.
+func (h *Handler) refuseRestricted(c *gin.Context) {
+	if h.geoBlocking == nil || c.Request.Method == http.MethodOptions {
+		c.Next()
+		return
+	}
+	inv, err := h.customerInvoice(shared.WithReplicaReads(c.Request.Context()), c.Param("id"))
+	if err != nil {
+		c.Next()
+		return
+	}
+
+	decision, err := h.checkGeoRestrictions(c, inv)
+	if err != nil {
+		h.Logger.Error("Failed to check geo restrictions", zap.Error(err), zap.String("invoice_id", inv.ID()))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to check geo restrictions"})
+		return
+	}
+	if !decision.Allowed {
+		c.AbortWithStatusJSON(http.StatusUnavailableForLegalReasons, createAuthErrorResponse("authorization_error",
+			geoblock.ErrCodeGeoRestricted, "checkout is not available in the client's region"))
+		return
+	}
+	c.Next()
+}
+
+// invoiceGeoBlocks returns the refused attempts to view an invoice's checkout, which flag it to its merchant.
+// None are returned if they cannot be read, so the invoice still shows.
+func (h *Handler) invoiceGeoBlocks(c *gin.Context, inv *invoice.Invoice) []GeoBlockResponse {
+	if h.geoBlocking == nil {
+		return nil
+	}
+	blocks, err := h.geoBlocking.ListBlocks(c.Request.Context(), inv.MerchantID(), inv.ID())
+	if err != nil {
+		h.Logger.Warn("Failed to list invoice geo-blocks", zap.Error(err), zap.String("invoice_id", inv.ID()))
+		return nil
+	}
+	if len(blocks) == 0 {
+		return nil
+	}
+	return ToGeoBlockResponses(blocks)
+}
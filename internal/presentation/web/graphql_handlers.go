@@ -0,0 +1,111 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/infrastructure/runtimeconfig"
+	"crypto-checkout/pkg/config"
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+	"go.uber.org/zap"
+)
+
+//go:embed graphql/schema.graphql
+var graphQLSchema string
+
+// GraphQLHandlers serves the optional GraphQL endpoint, which reads invoices with their payments,
+// customers and settlements in one request instead of one REST call per resource.
+type GraphQLHandlers struct {
+	schema   *graphql.Schema
+	enabled  bool
+	settings *runtimeconfig.Store
+	rbac     *RBACMiddleware
+	logger   *zap.Logger
+}
+
+// NewGraphQLHandlers creates a new GraphQL handlers instance. The runtime settings may be nil, in which case the
+// endpoint cannot be switched off while the application runs.
+func NewGraphQLHandlers(
+	cfg *config.Config,
+	invoiceService invoice.InvoiceService,
+	paymentService payment.PaymentService,
+	settlementService settlement.Service,
+	settings *runtimeconfig.Store,
+	logger *zap.Logger,
+) *GraphQLHandlers {
+	maxDepth := cfg.GraphQL.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = config.DefaultGraphQLMaxDepth
+	}
+
+	schema := graphql.MustParseSchema(graphQLSchema, &graphQLResolver{
+		invoiceService:    invoiceService,
+		paymentService:    paymentService,
+		settlementService: settlementService,
+	}, graphql.UseStringDescriptions(), graphql.MaxDepth(maxDepth))
+
+	return &GraphQLHandlers{
+		schema:   schema,
+		enabled:  cfg.GraphQL.Enabled,
+		settings: settings,
+		logger:   logger,
+	}
+}
+
+// Query handles POST /graphql
+// @Summary Run a GraphQL query
+// @Description Query invoices, payments, customers and settlements, following invoice → payments → settlement
+// @Description in one request. Each field requires the permission of the resource it reads: a field the caller
+// @Description may not read resolves to null with a PERMISSION_DENIED error while the rest of the query succeeds.
+// @Description Only served when graphql.enabled is set, and while the graphql feature is not switched off.
+// @Tags GraphQL
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body GraphQLRequest true "GraphQL query"
+// @Success 200 {object} GraphQLResponse "Query result, with field errors in errors"
+// @Failure 400 {object} ErrorResponse "Invalid request format"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 503 {object} ErrorResponse "GraphQL switched off"
+// @Router /api/v1/graphql [post]
+func (h *GraphQLHandlers) Query(c *gin.Context) {
+	if h.settings != nil && !h.settings.Settings().FeatureEnabled(runtimeconfig.FeatureGraphQL) {
+		c.JSON(http.StatusServiceUnavailable,
+			createAuthErrorResponse("feature_disabled", "FEATURE_DISABLED", "GraphQL is switched off"))
+		return
+	}
+
+	var req GraphQLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind GraphQL request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request format", err))
+		return
+	}
+
+	caller := &graphQLCaller{
+		merchantID: requestMerchantID(c),
+		userID:     c.GetString("user_id"),
+		rbac:       h.rbac,
+		allowed:    make(map[string]bool),
+	}
+	if _, _, permissions := GetAPIKeyInfo(c); caller.userID == "" && permissions != nil {
+		caller.permissions = permissions
+	}
+
+	ctx := withGraphQLCaller(c.Request.Context(), caller)
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	c.JSON(http.StatusOK, resp)
+}
+
+// RegisterGraphQLRoutes registers the GraphQL endpoint when it is enabled.
+func (h *GraphQLHandlers) RegisterGraphQLRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	if !h.enabled {
+		return
+	}
+	h.rbac = rbac
+	protected.POST("/graphql", h.Query)
+}
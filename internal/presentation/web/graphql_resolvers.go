@@ -0,0 +1,615 @@
+package web
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+const (
+	// defaultGraphQLPageSize is the page a GraphQL connection returns when first is not given.
+	defaultGraphQLPageSize = 20
+	// maxGraphQLPageSize is the largest page a GraphQL connection returns.
+	maxGraphQLPageSize = 100
+
+	// GraphQL error codes, reported in the code extension of an error.
+	graphQLCodeInvalidArgument  = "INVALID_ARGUMENT"
+	graphQLCodePermissionDenied = merchant.ErrCodePermissionDenied
+)
+
+// graphQLError is a GraphQL field error carrying a machine-readable code in its extensions.
+type graphQLError struct {
+	code    string
+	message string
+}
+
+func (e *graphQLError) Error() string {
+	return e.message
+}
+
+// Extensions implements the graphql-go ResolverError interface.
+func (e *graphQLError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+type graphQLCallerKey struct{}
+
+// graphQLCaller is the authenticated caller of a GraphQL request. Every field that reads a resource
+// checks the caller's permission for it, so a dashboard user without settlements:read still gets the
+// invoices of a query and only its settlement fields fail. Checks are remembered for the request, as
+// resolvers run concurrently and a list would otherwise check the same permission once per item.
+type graphQLCaller struct {
+	merchantID string
+	userID     string
+	// permissions are the API key permissions; nil when the caller is not an API key.
+	permissions []string
+	rbac        *RBACMiddleware
+
+	mu      sync.Mutex
+	allowed map[string]bool
+}
+
+func withGraphQLCaller(ctx context.Context, caller *graphQLCaller) context.Context {
+	return context.WithValue(ctx, graphQLCallerKey{}, caller)
+}
+
+func graphQLCallerFrom(ctx context.Context) *graphQLCaller {
+	caller, _ := ctx.Value(graphQLCallerKey{}).(*graphQLCaller)
+	return caller
+}
+
+// authorize returns a permission denied error unless the caller of ctx has the permission.
+func authorize(ctx context.Context, permission string) error {
+	caller := graphQLCallerFrom(ctx)
+	if caller == nil {
+		return &graphQLError{code: graphQLCodePermissionDenied, message: "Not authenticated"}
+	}
+
+	caller.mu.Lock()
+	defer caller.mu.Unlock()
+
+	allowed, checked := caller.allowed[permission]
+	if !checked {
+		var err error
+		if allowed, err = caller.hasPermission(ctx, permission); err != nil {
+			return err
+		}
+		caller.allowed[permission] = allowed
+	}
+	if !allowed {
+		return &graphQLError{
+			code:    graphQLCodePermissionDenied,
+			message: "Missing required permission: " + permission,
+		}
+	}
+	return nil
+}
+
+// hasPermission checks a team member's role, or an API key's permissions. As on the REST routes,
+// team roles are not checked when RBAC is disabled.
+func (c *graphQLCaller) hasPermission(ctx context.Context, permission string) (bool, error) {
+	if c.userID != "" {
+		if c.rbac == nil {
+			return true, nil
+		}
+		resp, err := c.rbac.teamService.CheckPermission(ctx, &merchant.CheckPermissionRequest{
+			MerchantID: c.merchantID,
+			UserID:     c.userID,
+			Permission: permission,
+		})
+		if err != nil {
+			return false, err
+		}
+		return resp.Allowed, nil
+	}
+
+	if c.permissions == nil {
+		return true, nil
+	}
+	for _, p := range c.permissions {
+		if p == permission || p == merchant.PermissionAll {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// graphQLResolver resolves the Query type of the GraphQL schema.
+type graphQLResolver struct {
+	invoiceService    invoice.InvoiceService
+	paymentService    payment.PaymentService
+	settlementService settlement.Service
+}
+
+type idArgs struct {
+	ID graphql.ID
+}
+
+type invoicesArgs struct {
+	First      int32
+	After      *string
+	Status     *string
+	CustomerID *graphql.ID
+}
+
+type customerInvoicesArgs struct {
+	First  int32
+	After  *string
+	Status *string
+}
+
+type settlementsArgs struct {
+	First  int32
+	After  *string
+	Status *string
+}
+
+// Invoice resolves Query.invoice.
+func (r *graphQLResolver) Invoice(ctx context.Context, args idArgs) (*invoiceResolver, error) {
+	return r.invoice(ctx, string(args.ID))
+}
+
+// Invoices resolves Query.invoices.
+func (r *graphQLResolver) Invoices(ctx context.Context, args invoicesArgs) (*invoiceConnectionResolver, error) {
+	var customerID *string
+	if args.CustomerID != nil {
+		id := string(*args.CustomerID)
+		customerID = &id
+	}
+	return r.invoices(ctx, args.First, args.After, args.Status, customerID)
+}
+
+// Payment resolves Query.payment. Payments are owned through their invoice, so a payment for another
+// merchant's invoice is reported as not found.
+func (r *graphQLResolver) Payment(ctx context.Context, args idArgs) (*paymentResolver, error) {
+	if err := authorize(ctx, merchant.PermissionInvoicesRead); err != nil {
+		return nil, err
+	}
+
+	p, err := r.paymentService.GetPayment(ctx, shared.PaymentID(args.ID))
+	if err != nil {
+		var domainErr *shared.DomainError
+		if errors.Is(err, payment.ErrPaymentNotFound) ||
+			(errors.As(err, &domainErr) && domainErr.Code == payment.ErrCodePaymentNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	inv, err := r.invoice(ctx, string(p.InvoiceID()))
+	if err != nil || inv == nil {
+		return nil, err
+	}
+	return &paymentResolver{root: r, payment: p}, nil
+}
+
+// Customer resolves Query.customer. Customers exist only as the customer ID of invoices.
+func (r *graphQLResolver) Customer(ctx context.Context, args idArgs) (*customerResolver, error) {
+	if err := authorize(ctx, merchant.PermissionInvoicesRead); err != nil {
+		return nil, err
+	}
+	return &customerResolver{root: r, id: string(args.ID)}, nil
+}
+
+// Settlement resolves Query.settlement.
+func (r *graphQLResolver) Settlement(ctx context.Context, args idArgs) (*settlementResolver, error) {
+	if err := authorize(ctx, merchant.PermissionSettlementsRead); err != nil {
+		return nil, err
+	}
+
+	s, err := r.settlementService.GetSettlement(ctx, &settlement.GetSettlementRequest{
+		MerchantID:   graphQLCallerFrom(ctx).merchantID,
+		SettlementID: string(args.ID),
+	})
+	return r.settlementResult(s, err)
+}
+
+// Settlements resolves Query.settlements.
+func (r *graphQLResolver) Settlements(
+	ctx context.Context,
+	args settlementsArgs,
+) (*settlementConnectionResolver, error) {
+	if err := authorize(ctx, merchant.PermissionSettlementsRead); err != nil {
+		return nil, err
+	}
+
+	req := &settlement.ListSettlementsRequest{
+		MerchantID: graphQLCallerFrom(ctx).merchantID,
+		Limit:      pageSize(args.First),
+	}
+	if args.Status != nil {
+		req.Status = settlement.Status(*args.Status)
+	}
+	cursor, err := decodeAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+	req.Cursor = cursor
+
+	resp, err := r.settlementService.ListSettlements(ctx, req)
+	if err != nil {
+		if errors.Is(err, settlement.ErrInvalidRequest) {
+			return nil, &graphQLError{code: graphQLCodeInvalidArgument, message: err.Error()}
+		}
+		return nil, err
+	}
+
+	connection := &settlementConnectionResolver{
+		edges:    make([]*settlementEdgeResolver, 0, len(resp.Settlements)),
+		pageInfo: pageInfoResolver{hasNextPage: resp.NextCursor != nil},
+	}
+	for _, s := range resp.Settlements {
+		connection.edges = append(connection.edges, &settlementEdgeResolver{
+			node: &settlementResolver{root: r, settlement: s},
+		})
+	}
+	if resp.NextCursor != nil {
+		endCursor := resp.NextCursor.Encode()
+		connection.pageInfo.endCursor = &endCursor
+	}
+	return connection, nil
+}
+
+// invoice loads an invoice of the caller's merchant, returning nil if there is none.
+func (r *graphQLResolver) invoice(ctx context.Context, id string) (*invoiceResolver, error) {
+	if err := authorize(ctx, merchant.PermissionInvoicesRead); err != nil {
+		return nil, err
+	}
+
+	inv, err := r.invoiceService.GetInvoice(ctx, id)
+	if err != nil {
+		if errors.Is(err, invoice.ErrInvoiceNotFound) || errors.Is(err, invoice.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if inv.MerchantID() != graphQLCallerFrom(ctx).merchantID {
+		return nil, nil
+	}
+	return newInvoiceResolver(r, inv), nil
+}
+
+// invoices lists the caller's invoices, optionally those of one customer.
+func (r *graphQLResolver) invoices(
+	ctx context.Context,
+	first int32,
+	after *string,
+	status *string,
+	customerID *string,
+) (*invoiceConnectionResolver, error) {
+	if err := authorize(ctx, merchant.PermissionInvoicesRead); err != nil {
+		return nil, err
+	}
+
+	req := &invoice.ListInvoicesRequest{
+		MerchantID: graphQLCallerFrom(ctx).merchantID,
+		CustomerID: customerID,
+		Limit:      pageSize(first),
+	}
+	if status != nil {
+		s := invoice.InvoiceStatus(*status)
+		if !s.IsValid() {
+			return nil, &graphQLError{code: graphQLCodeInvalidArgument, message: "invalid status " + *status}
+		}
+		req.Status = &s
+	}
+	cursor, err := decodeAfter(after)
+	if err != nil {
+		return nil, err
+	}
+	req.Cursor = cursor
+
+	resp, err := r.invoiceService.ListInvoices(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	connection := &invoiceConnectionResolver{
+		edges:      make([]*invoiceEdgeResolver, 0, len(resp.Invoices)),
+		totalCount: int32(resp.Total), //nolint:gosec // Invoice counts fit in a GraphQL Int
+		pageInfo:   pageInfoResolver{hasNextPage: resp.NextCursor != nil},
+	}
+	for _, inv := range resp.Invoices {
+		connection.edges = append(connection.edges, &invoiceEdgeResolver{node: newInvoiceResolver(r, inv)})
+	}
+	if resp.NextCursor != nil {
+		endCursor := resp.NextCursor.Encode()
+		connection.pageInfo.endCursor = &endCursor
+	}
+	return connection, nil
+}
+
+// invoiceSettlement loads the settlement of an invoice, returning nil if it has not been settled.
+func (r *graphQLResolver) invoiceSettlement(ctx context.Context, invoiceID string) (*settlementResolver, error) {
+	if err := authorize(ctx, merchant.PermissionSettlementsRead); err != nil {
+		return nil, err
+	}
+
+	s, err := r.settlementService.GetInvoiceSettlement(ctx, &settlement.GetInvoiceSettlementRequest{
+		MerchantID: graphQLCallerFrom(ctx).merchantID,
+		InvoiceID:  invoiceID,
+	})
+	return r.settlementResult(s, err)
+}
+
+// settlementResult resolves a settlement lookup, treating a missing settlement as null.
+func (r *graphQLResolver) settlementResult(s *settlement.Settlement, err error) (*settlementResolver, error) {
+	if err != nil {
+		if errors.Is(err, settlement.ErrSettlementNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &settlementResolver{root: r, settlement: s}, nil
+}
+
+// pageSize returns the requested page size within the bounds of a connection.
+func pageSize(first int32) int {
+	if first <= 0 {
+		return defaultGraphQLPageSize
+	}
+	if first > maxGraphQLPageSize {
+		return maxGraphQLPageSize
+	}
+	return int(first)
+}
+
+// decodeAfter decodes the after argument of a connection.
+func decodeAfter(after *string) (*shared.Cursor, error) {
+	if after == nil || *after == "" {
+		return nil, nil
+	}
+	cursor, err := shared.DecodeCursor(*after)
+	if err != nil {
+		return nil, &graphQLError{code: graphQLCodeInvalidArgument, message: err.Error()}
+	}
+	return cursor, nil
+}
+
+// optionalString returns nil for an empty string.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+type pageInfoResolver struct {
+	hasNextPage bool
+	endCursor   *string
+}
+
+func (p pageInfoResolver) HasNextPage() bool  { return p.hasNextPage }
+func (p pageInfoResolver) EndCursor() *string { return p.endCursor }
+
+type invoiceConnectionResolver struct {
+	edges      []*invoiceEdgeResolver
+	pageInfo   pageInfoResolver
+	totalCount int32
+}
+
+func (c *invoiceConnectionResolver) Edges() []*invoiceEdgeResolver { return c.edges }
+func (c *invoiceConnectionResolver) PageInfo() pageInfoResolver    { return c.pageInfo }
+func (c *invoiceConnectionResolver) TotalCount() int32             { return c.totalCount }
+
+type invoiceEdgeResolver struct {
+	node *invoiceResolver
+}
+
+func (e *invoiceEdgeResolver) Cursor() string {
+	return shared.NewCursor(e.node.invoice.CreatedAt(), e.node.invoice.ID()).Encode()
+}
+func (e *invoiceEdgeResolver) Node() *invoiceResolver { return e.node }
+
+type settlementConnectionResolver struct {
+	edges    []*settlementEdgeResolver
+	pageInfo pageInfoResolver
+}
+
+func (c *settlementConnectionResolver) Edges() []*settlementEdgeResolver { return c.edges }
+func (c *settlementConnectionResolver) PageInfo() pageInfoResolver       { return c.pageInfo }
+
+type settlementEdgeResolver struct {
+	node *settlementResolver
+}
+
+func (e *settlementEdgeResolver) Cursor() string {
+	return shared.NewCursor(e.node.settlement.CreatedAt(), e.node.settlement.ID()).Encode()
+}
+func (e *settlementEdgeResolver) Node() *settlementResolver { return e.node }
+
+// invoiceResolver resolves the Invoice type, reusing the REST representation for its scalar fields.
+type invoiceResolver struct {
+	root     *graphQLResolver
+	invoice  *invoice.Invoice
+	response CreateInvoiceResponse
+}
+
+func newInvoiceResolver(root *graphQLResolver, inv *invoice.Invoice) *invoiceResolver {
+	return &invoiceResolver{root: root, invoice: inv, response: ToCreateInvoiceResponse(inv)}
+}
+
+func (r *invoiceResolver) ID() graphql.ID         { return graphql.ID(r.invoice.ID()) }
+func (r *invoiceResolver) Number() *string        { return optionalString(r.invoice.Number()) }
+func (r *invoiceResolver) Title() string          { return r.invoice.Title() }
+func (r *invoiceResolver) Description() string    { return r.invoice.Description() }
+func (r *invoiceResolver) Status() string         { return r.response.Status }
+func (r *invoiceResolver) Type() string           { return r.response.Type }
+func (r *invoiceResolver) Subtotal() string       { return r.response.Subtotal }
+func (r *invoiceResolver) DiscountAmount() string { return r.response.DiscountAmount }
+func (r *invoiceResolver) TaxAmount() string      { return r.response.TaxAmount }
+func (r *invoiceResolver) FeeAmount() *string     { return optionalString(r.response.FeeAmount) }
+func (r *invoiceResolver) Total() string          { return r.response.Total }
+func (r *invoiceResolver) Currency() string       { return r.invoice.Pricing().Total().Currency() }
+func (r *invoiceResolver) CryptoCurrency() string { return r.invoice.CryptoCurrency().String() }
+func (r *invoiceResolver) Network() *string       { return optionalString(r.response.Network) }
+func (r *invoiceResolver) CryptoAmount() string   { return r.response.USDTAmount }
+func (r *invoiceResolver) AmountReceived() string { return r.response.AmountReceived }
+func (r *invoiceResolver) PaymentAddress() *string {
+	return r.response.PaymentAddress
+}
+func (r *invoiceResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.invoice.CreatedAt()} }
+
+func (r *invoiceResolver) ExpiresAt() *graphql.Time {
+	if r.response.ExpiresAt.IsZero() {
+		return nil
+	}
+	return &graphql.Time{Time: r.response.ExpiresAt}
+}
+
+func (r *invoiceResolver) PaidAt() *graphql.Time {
+	if paidAt := r.invoice.PaidAt(); paidAt != nil {
+		return &graphql.Time{Time: *paidAt}
+	}
+	return nil
+}
+
+func (r *invoiceResolver) Customer() *customerResolver {
+	if customerID := r.invoice.CustomerID(); customerID != nil && *customerID != "" {
+		return &customerResolver{root: r.root, id: *customerID}
+	}
+	return nil
+}
+
+func (r *invoiceResolver) Payments(ctx context.Context) ([]*paymentResolver, error) {
+	payments, err := r.root.paymentService.ListPaymentsByInvoice(ctx, shared.InvoiceID(r.invoice.ID()))
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*paymentResolver, len(payments))
+	for i, p := range payments {
+		resolvers[i] = &paymentResolver{root: r.root, payment: p}
+	}
+	return resolvers, nil
+}
+
+func (r *invoiceResolver) Settlement(ctx context.Context) (*settlementResolver, error) {
+	return r.root.invoiceSettlement(ctx, r.invoice.ID())
+}
+
+func (r *invoiceResolver) Supersedes(ctx context.Context) (*invoiceResolver, error) {
+	if id := r.invoice.Supersedes(); id != nil {
+		return r.root.invoice(ctx, *id)
+	}
+	return nil, nil
+}
+
+func (r *invoiceResolver) SupersededBy(ctx context.Context) (*invoiceResolver, error) {
+	if id := r.invoice.SupersededBy(); id != nil {
+		return r.root.invoice(ctx, *id)
+	}
+	return nil, nil
+}
+
+func (r *invoiceResolver) CustomFields() []customFieldResolver {
+	fields := make([]customFieldResolver, len(r.response.CustomFields))
+	for i, field := range r.response.CustomFields {
+		fields[i] = customFieldResolver{field: field}
+	}
+	return fields
+}
+
+// customFieldResolver resolves the CustomField type.
+type customFieldResolver struct {
+	field CustomFieldResponse
+}
+
+func (f customFieldResolver) Label() string      { return f.field.Label }
+func (f customFieldResolver) Value() string      { return f.field.Value }
+func (f customFieldResolver) Type() string       { return f.field.Type }
+func (f customFieldResolver) Visibility() string { return f.field.Visibility }
+
+// paymentResolver resolves the Payment type.
+type paymentResolver struct {
+	root    *graphQLResolver
+	payment *payment.Payment
+}
+
+func (r *paymentResolver) ID() graphql.ID      { return graphql.ID(r.payment.ID()) }
+func (r *paymentResolver) Amount() string      { return r.payment.Amount().Amount().Amount().String() }
+func (r *paymentResolver) Currency() string    { return r.payment.Amount().Currency().String() }
+func (r *paymentResolver) Status() string      { return r.payment.Status().String() }
+func (r *paymentResolver) FromAddress() string { return r.payment.FromAddress() }
+func (r *paymentResolver) ToAddress() string   { return r.payment.ToAddress().Address() }
+func (r *paymentResolver) TransactionHash() string {
+	return r.payment.TransactionHash().Hash()
+}
+func (r *paymentResolver) Confirmations() int32 {
+	return int32(r.payment.Confirmations().Int()) //nolint:gosec // Confirmation counts fit in a GraphQL Int
+}
+func (r *paymentResolver) RequiredConfirmations() int32 {
+	return int32(r.payment.RequiredConfirmations()) //nolint:gosec // Confirmation counts fit in a GraphQL Int
+}
+func (r *paymentResolver) DetectedAt() graphql.Time {
+	return graphql.Time{Time: r.payment.DetectedAt()}
+}
+
+func (r *paymentResolver) ConfirmedAt() *graphql.Time {
+	if confirmedAt := r.payment.ConfirmedAt(); confirmedAt != nil {
+		return &graphql.Time{Time: *confirmedAt}
+	}
+	return nil
+}
+
+func (r *paymentResolver) Invoice(ctx context.Context) (*invoiceResolver, error) {
+	return r.root.invoice(ctx, string(r.payment.InvoiceID()))
+}
+
+func (r *paymentResolver) Settlement(ctx context.Context) (*settlementResolver, error) {
+	return r.root.invoiceSettlement(ctx, string(r.payment.InvoiceID()))
+}
+
+// customerResolver resolves the Customer type.
+type customerResolver struct {
+	root *graphQLResolver
+	id   string
+}
+
+func (r *customerResolver) ID() graphql.ID { return graphql.ID(r.id) }
+
+func (r *customerResolver) Invoices(
+	ctx context.Context,
+	args customerInvoicesArgs,
+) (*invoiceConnectionResolver, error) {
+	return r.root.invoices(ctx, args.First, args.After, args.Status, &r.id)
+}
+
+// settlementResolver resolves the Settlement type.
+type settlementResolver struct {
+	root       *graphQLResolver
+	settlement *settlement.Settlement
+}
+
+func (r *settlementResolver) ID() graphql.ID            { return graphql.ID(r.settlement.ID()) }
+func (r *settlementResolver) GrossAmount() string       { return r.settlement.GrossAmount().String() }
+func (r *settlementResolver) PlatformFeeAmount() string { return r.settlement.FeeAmount().String() }
+func (r *settlementResolver) PlatformFeePercentage() string {
+	return r.settlement.FeePercentage().String()
+}
+func (r *settlementResolver) NetAmount() string { return r.settlement.NetAmount().String() }
+func (r *settlementResolver) Currency() string  { return r.settlement.Currency() }
+func (r *settlementResolver) Status() string    { return string(r.settlement.Status()) }
+func (r *settlementResolver) FailureReason() *string {
+	return optionalString(r.settlement.FailureReason())
+}
+func (r *settlementResolver) PayoutID() *string { return optionalString(r.settlement.PayoutID()) }
+func (r *settlementResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.settlement.CreatedAt()}
+}
+
+func (r *settlementResolver) SettledAt() *graphql.Time {
+	if settledAt := r.settlement.SettledAt(); settledAt != nil {
+		return &graphql.Time{Time: *settledAt}
+	}
+	return nil
+}
+
+func (r *settlementResolver) Invoice(ctx context.Context) (*invoiceResolver, error) {
+	return r.root.invoice(ctx, r.settlement.InvoiceID())
+}
@@ -0,0 +1,344 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubSettlementService serves settlements recorded by the test.
+type stubSettlementService struct {
+	settlement.Service
+	settlements []*settlement.Settlement
+}
+
+func (s *stubSettlementService) GetSettlement(
+	_ context.Context,
+	req *settlement.GetSettlementRequest,
+) (*settlement.Settlement, error) {
+	for _, st := range s.settlements {
+		if st.ID() == req.SettlementID && st.MerchantID() == req.MerchantID {
+			return st, nil
+		}
+	}
+	return nil, settlement.ErrSettlementNotFound
+}
+
+func (s *stubSettlementService) GetInvoiceSettlement(
+	_ context.Context,
+	req *settlement.GetInvoiceSettlementRequest,
+) (*settlement.Settlement, error) {
+	for _, st := range s.settlements {
+		if st.InvoiceID() == req.InvoiceID && st.MerchantID() == req.MerchantID {
+			return st, nil
+		}
+	}
+	return nil, settlement.ErrSettlementNotFound
+}
+
+func (s *stubSettlementService) ListSettlements(
+	_ context.Context,
+	req *settlement.ListSettlementsRequest,
+) (*settlement.ListSettlementsResponse, error) {
+	resp := &settlement.ListSettlementsResponse{Limit: req.Limit}
+	for _, st := range s.settlements {
+		if st.MerchantID() == req.MerchantID {
+			resp.Settlements = append(resp.Settlements, st)
+		}
+	}
+	return resp, nil
+}
+
+type graphQLResult struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Path       []interface{}  `json:"path"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func TestGraphQL(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	_, services := web.CreateTestHandlerWithServices()
+	settlements := &stubSettlementService{}
+	cfg := &config.Config{GraphQL: config.GraphQLConfig{Enabled: true, MaxDepth: 8}}
+	graphQLHandlers := web.NewGraphQLHandlers(cfg, services.Invoices, services.Payments, settlements, nil, zap.NewNop())
+
+	// newRouter serves the endpoint to an API key with the permissions, or to an unrestricted caller
+	// when there are none.
+	newRouter := func(permissions ...string) *gin.Engine {
+		router := gin.New()
+		protected := router.Group("/api/v1", func(c *gin.Context) {
+			if len(permissions) > 0 {
+				c.Set("api_key_permissions", permissions)
+			}
+			c.Next()
+		})
+		graphQLHandlers.RegisterGraphQLRoutes(protected, nil)
+		return router
+	}
+	router := newRouter()
+
+	query := func(t *testing.T, router *gin.Engine, q string, variables map[string]interface{}) graphQLResult {
+		t.Helper()
+		body, err := json.Marshal(web.GraphQLRequest{Query: q, Variables: variables})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/graphql", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var result graphQLResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		return result
+	}
+
+	createInvoice := func(t *testing.T, title string, customerID *string) *invoice.Invoice {
+		t.Helper()
+		unitPrice, err := shared.NewMoney("10.00", shared.CurrencyUSD)
+		require.NoError(t, err)
+
+		inv, err := services.Invoices.CreateInvoice(ctx, &invoice.CreateInvoiceRequest{
+			MerchantID: "test-merchant",
+			CustomerID: customerID,
+			Title:      title,
+			Items: []*invoice.CreateInvoiceItemRequest{
+				{Name: "Item", Quantity: "1", UnitPrice: unitPrice},
+			},
+			TaxRate:        "0.00",
+			Currency:       shared.CurrencyUSD,
+			CryptoCurrency: shared.CryptoCurrencyUSDT,
+		})
+		require.NoError(t, err)
+		return inv
+	}
+
+	t.Run("InvoiceWithPaymentsAndSettlement", func(t *testing.T) {
+		inv := createInvoice(t, "Nested", nil)
+
+		amount, err := shared.NewMoneyWithCrypto("10.00", shared.CryptoCurrencyUSDT)
+		require.NoError(t, err)
+		paymentAmount, err := payment.NewPaymentAmount(amount, shared.CryptoCurrencyUSDT)
+		require.NoError(t, err)
+		toAddress, err := payment.NewPaymentAddress("TTestAddress123456789012345678901234567890", shared.NetworkTron)
+		require.NoError(t, err)
+		txHash, err := payment.NewTransactionHash("0x" + "ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12")
+		require.NoError(t, err)
+		p, err := services.Payments.CreatePayment(ctx, &payment.CreatePaymentRequest{
+			ID:                    "payment-graphql-1",
+			InvoiceID:             shared.InvoiceID(inv.ID()),
+			Amount:                paymentAmount,
+			FromAddress:           "TSenderAddress123456789012345678901234567890",
+			ToAddress:             toAddress,
+			TransactionHash:       txHash,
+			RequiredConfirmations: 1,
+		})
+		require.NoError(t, err)
+
+		s, err := settlement.NewSettlement("settlement-graphql-1", inv.ID(), "test-merchant",
+			decimal.NewFromInt(10), "USDT", decimal.NewFromInt(1))
+		require.NoError(t, err)
+		settlements.settlements = append(settlements.settlements, s)
+
+		result := query(t, router, `query($id: ID!) {
+			invoice(id: $id) {
+				id title total currency
+				payments { id amount currency transactionHash settlement { id } }
+				settlement { id grossAmount platformFeeAmount netAmount invoice { id } }
+			}
+		}`, map[string]interface{}{"id": inv.ID()})
+		require.Empty(t, result.Errors)
+
+		var data struct {
+			ID       string
+			Title    string
+			Total    string
+			Currency string
+			Payments []struct {
+				ID              string
+				Amount          string
+				Currency        string
+				TransactionHash string
+				Settlement      struct{ ID string }
+			}
+			Settlement struct {
+				ID                string
+				GrossAmount       string
+				PlatformFeeAmount string
+				NetAmount         string
+				Invoice           struct{ ID string }
+			}
+		}
+		require.NoError(t, json.Unmarshal(result.Data["invoice"], &data))
+		require.Equal(t, inv.ID(), data.ID)
+		require.Equal(t, "Nested", data.Title)
+		require.Equal(t, "10.00", data.Total)
+		require.Equal(t, "USD", data.Currency)
+		require.Len(t, data.Payments, 1)
+		require.Equal(t, string(p.ID()), data.Payments[0].ID)
+		require.Equal(t, "10", data.Payments[0].Amount)
+		require.Equal(t, "USDT", data.Payments[0].Currency)
+		require.Equal(t, "settlement-graphql-1", data.Payments[0].Settlement.ID)
+		require.Equal(t, "settlement-graphql-1", data.Settlement.ID)
+		require.Equal(t, "10", data.Settlement.GrossAmount)
+		require.Equal(t, "0.1", data.Settlement.PlatformFeeAmount)
+		require.Equal(t, "9.9", data.Settlement.NetAmount)
+		require.Equal(t, inv.ID(), data.Settlement.Invoice.ID)
+
+		result = query(t, router, `query($id: ID!) { payment(id: $id) { id invoice { id } } }`,
+			map[string]interface{}{"id": string(p.ID())})
+		require.Empty(t, result.Errors)
+		require.Contains(t, string(result.Data["payment"]), inv.ID())
+	})
+
+	t.Run("MissingResources_Null", func(t *testing.T) {
+		result := query(t, router, `{
+			invoice(id: "missing") { id }
+			payment(id: "missing") { id }
+			settlement(id: "missing") { id }
+		}`, nil)
+		require.Empty(t, result.Errors)
+		require.JSONEq(t, "null", string(result.Data["invoice"]))
+		require.JSONEq(t, "null", string(result.Data["payment"]))
+		require.JSONEq(t, "null", string(result.Data["settlement"]))
+	})
+
+	t.Run("InvoicesPagination", func(t *testing.T) {
+		customerID := "customer-paging"
+		for _, title := range []string{"First", "Second", "Third"} {
+			createInvoice(t, title, &customerID)
+		}
+
+		type page struct {
+			Edges []struct {
+				Cursor string
+				Node   struct{ Title string }
+			}
+			PageInfo struct {
+				HasNextPage bool
+				EndCursor   *string
+			}
+			TotalCount int
+		}
+		const q = `query($customer: ID!, $after: String) {
+			customer(id: $customer) {
+				invoices(first: 2, after: $after) {
+					edges { cursor node { title } }
+					pageInfo { hasNextPage endCursor }
+					totalCount
+				}
+			}
+		}`
+		fetch := func(after *string) page {
+			result := query(t, router, q, map[string]interface{}{"customer": customerID, "after": after})
+			require.Empty(t, result.Errors)
+			var data struct{ Invoices page }
+			require.NoError(t, json.Unmarshal(result.Data["customer"], &data))
+			return data.Invoices
+		}
+
+		first := fetch(nil)
+		require.Len(t, first.Edges, 2)
+		require.Equal(t, 3, first.TotalCount)
+		require.True(t, first.PageInfo.HasNextPage)
+		require.NotNil(t, first.PageInfo.EndCursor)
+		require.Equal(t, first.Edges[1].Cursor, *first.PageInfo.EndCursor)
+
+		second := fetch(first.PageInfo.EndCursor)
+		require.Len(t, second.Edges, 1)
+		require.False(t, second.PageInfo.HasNextPage)
+		require.Nil(t, second.PageInfo.EndCursor)
+
+		titles := map[string]bool{}
+		for _, edge := range append(first.Edges, second.Edges...) {
+			titles[edge.Node.Title] = true
+		}
+		require.Len(t, titles, 3)
+	})
+
+	t.Run("InvalidArguments", func(t *testing.T) {
+		result := query(t, router, `{ invoices(after: "not-a-cursor") { totalCount } }`, nil)
+		require.Len(t, result.Errors, 1)
+		require.Equal(t, "INVALID_ARGUMENT", result.Errors[0].Extensions["code"])
+
+		result = query(t, router, `{ invoices(status: "unknown") { totalCount } }`, nil)
+		require.Len(t, result.Errors, 1)
+		require.Equal(t, "INVALID_ARGUMENT", result.Errors[0].Extensions["code"])
+	})
+
+	t.Run("FieldAuthorization", func(t *testing.T) {
+		inv := createInvoice(t, "Restricted", nil)
+		s, err := settlement.NewSettlement("settlement-graphql-2", inv.ID(), "test-merchant",
+			decimal.NewFromInt(10), "USDT", decimal.NewFromInt(1))
+		require.NoError(t, err)
+		settlements.settlements = append(settlements.settlements, s)
+
+		restricted := newRouter(merchant.PermissionInvoicesRead)
+		result := query(t, restricted, `query($id: ID!) { invoice(id: $id) { id settlement { id } } }`,
+			map[string]interface{}{"id": inv.ID()})
+
+		require.Len(t, result.Errors, 1)
+		require.Equal(t, merchant.ErrCodePermissionDenied, result.Errors[0].Extensions["code"])
+		require.Equal(t, []interface{}{"invoice", "settlement"}, result.Errors[0].Path)
+		require.JSONEq(t, `{"id":"`+inv.ID()+`","settlement":null}`, string(result.Data["invoice"]))
+
+		result = query(t, restricted, `{ settlements { edges { node { id } } } }`, nil)
+		require.Len(t, result.Errors, 1)
+		require.Equal(t, merchant.ErrCodePermissionDenied, result.Errors[0].Extensions["code"])
+
+		wildcard := newRouter(merchant.PermissionAll)
+		result = query(t, wildcard, `query($id: ID!) { invoice(id: $id) { settlement { id } } }`,
+			map[string]interface{}{"id": inv.ID()})
+		require.Empty(t, result.Errors)
+	})
+
+	t.Run("MaxDepth", func(t *testing.T) {
+		result := query(t, router, `{
+			invoice(id: "x") { settlement { invoice { settlement { invoice { settlement { invoice { settlement {
+				id
+			} } } } } } } }
+		}`, nil)
+		require.NotEmpty(t, result.Errors)
+		require.Nil(t, result.Data)
+	})
+
+	t.Run("InvalidRequest", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/graphql", bytes.NewBufferString(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Disabled_NotServed", func(t *testing.T) {
+		disabled := web.NewGraphQLHandlers(&config.Config{}, services.Invoices, services.Payments, settlements,
+			nil, zap.NewNop())
+		router := gin.New()
+		disabled.RegisterGraphQLRoutes(router.Group("/api/v1"), nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/graphql", bytes.NewBufferString(`{"query":"{}"}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -1,11 +1,22 @@
 package web
 
 import (
+	"crypto-checkout/internal/domain/approval"
+	"crypto-checkout/internal/domain/checkoutanalytics"
+	"crypto-checkout/internal/domain/confirmation"
+	"crypto-checkout/internal/domain/coupon"
+	"crypto-checkout/internal/domain/evidence"
+	"crypto-checkout/internal/domain/experiment"
+	"crypto-checkout/internal/domain/geoblock"
 	"crypto-checkout/internal/domain/invoice"
 	"crypto-checkout/internal/domain/merchant"
 	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/paymentlink"
+	"crypto-checkout/internal/domain/processor"
 	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/tax"
 	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
 	"errors"
 	"fmt"
 	"net/http"
@@ -13,8 +24,6 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
 	"go.uber.org/zap"
 )
 
@@ -29,12 +38,33 @@ const (
 
 // Handler provides HTTP handlers for the crypto-checkout API.
 type Handler struct {
-	invoiceService invoice.InvoiceService
-	paymentService payment.PaymentService
-	APIKeyService  merchant.APIKeyService
-	Logger         *zap.Logger
-	config         *config.Config
-	hub            *Hub
+	invoiceService     invoice.InvoiceService
+	paymentService     payment.PaymentService
+	APIKeyService      merchant.APIKeyService
+	Logger             *zap.Logger
+	config             *config.Config
+	hub                *Hub
+	rbac               *RBACMiddleware
+	sessionAuth        *SessionAuthMiddleware
+	couponService      coupon.Service
+	taxService         tax.Service
+	paymentLinkService paymentlink.Service
+	invoiceEvents      *InvoiceEventStream
+	approvalService    approval.Service
+	bundle             *i18n.Bundle
+	merchantLocales    MerchantLocales
+	idempotency        *Idempotency
+	redirectSigner     *RedirectSigner
+	merchantOrigins    MerchantOrigins
+	processorService   processor.Service
+	confirmationETA    *confirmation.Estimator
+	explorers          *shared.ExplorerResolver
+	incidents          Incidents
+	evidenceService    evidence.Service
+	experimentService  experiment.Service
+	checkoutAnalytics  checkoutanalytics.Service
+	countryLocator     shared.CountryLocator
+	geoBlocking        geoblock.Service
 }
 
 // NewHandler creates a new API handler with the required services.
@@ -61,20 +91,40 @@ func (h *Handler) RegisterRoutes(router *gin.Engine) {
 	// Health check endpoint
 	router.GET("/health", h.healthCheck)
 
-	// Swagger documentation
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// OpenAPI document and Swagger UI
+	h.registerDocsRoutes(router)
 
 	// Public customer-facing routes (matching API.md spec)
 	router.GET("/invoice/:id", h.getPublicInvoice)
-	router.GET("/invoice/:id/qr", h.getInvoiceQR)
-	router.GET("/invoice/:id/status", h.GetInvoiceStatus)
+	router.GET("/invoice/:id/qr", h.refuseRestricted, h.getInvoiceQR)
+	router.GET("/invoice/:id/status", trackStatusRead, h.GetInvoiceStatus)
 	router.GET("/invoice/:id/ws", h.serveWS)
+	router.GET("/invoice/:id/return", h.returnToMerchant)
+	router.GET("/invoice/:id/return/cancel", h.cancelToMerchant)
+	router.GET("/l/:slug", h.openPaymentLink)
+	router.GET(WidgetScriptPath, h.serveWidgetScript)
 
-	// Public API routes (no authentication required)
+	// Public API routes (no authentication required), open to browsers on the sites merchants allow
 	public := router.Group("/api/v1/public")
-	public.GET("/invoice/:id", h.GetPublicInvoiceData)
-	public.GET("/invoice/:id/status", h.GetPublicInvoiceStatus)
-	public.GET("/invoice/:id/events", h.GetPublicInvoiceEvents)
+	public.GET("/redirect-keys", h.GetRedirectKeys)
+	publicInvoice := public.Group("/invoice/:id", h.cors(h.invoiceMerchant), h.refuseRestricted)
+	publicInvoice.GET("", h.GetPublicInvoiceData)
+	publicInvoice.GET("/status", trackStatusRead, h.GetPublicInvoiceStatus)
+	publicInvoice.GET("/events", h.GetPublicInvoiceEvents)
+	publicInvoice.POST("/coupon", h.ApplyInvoiceCoupon)
+	publicInvoice.POST("/amount", h.ChooseDonationAmount)
+	publicInvoice.POST("/currency", h.ChangeInvoiceCurrency)
+	publicInvoice.POST("/refund-address", h.SupplyRefundAddress)
+	publicInvoice.POST("/funnel", h.RecordCheckoutStep)
+	widget := public.Group("/widget/invoice/:id", h.cors(h.invoiceMerchant), h.refuseRestricted)
+	widget.GET("", trackStatusRead, h.GetWidgetState)
+	links := public.Group("/links/:slug", h.cors(h.paymentLinkMerchant))
+	links.GET("", h.GetPublicPaymentLink)
+	links.POST("/invoices", h.CreatePaymentLinkInvoice)
+	for _, group := range []*gin.RouterGroup{publicInvoice, widget, links} {
+		group.OPTIONS("", answerPreflight)
+		group.OPTIONS("/*path", answerPreflight)
+	}
 
 	// API v1 routes (Merchant/Admin API)
 	v1 := router.Group("/api/v1")
@@ -84,21 +134,163 @@ func (h *Handler) RegisterRoutes(router *gin.Engine) {
 
 	// Protected routes (require authentication)
 	protected := v1.Group("")
-	protected.Use(AuthMiddleware(h.Logger))
+	protected.Use(h.authenticate())
 	// Invoice routes
 	invoices := protected.Group("/invoices")
-	invoices.POST("", h.CreateInvoice)
-	invoices.GET("", h.ListInvoices)
-	invoices.GET("/:id", h.GetInvoice)
-	invoices.POST("/:id/cancel", h.CancelInvoice)
+	invoices.POST("", h.rbac.RequireRolePermission(merchant.PermissionInvoicesCreate), h.idempotent(),
+		h.rbac.AuditAction("invoice.create"), h.CreateInvoice)
+	invoices.GET("", h.rbac.RequireRolePermission(merchant.PermissionInvoicesRead), h.ListInvoices)
+	invoices.POST("/status-batch", h.rbac.RequireRolePermission(merchant.PermissionInvoicesRead), h.GetInvoiceStatusBatch)
+	invoices.GET("/:id", h.rbac.RequireRolePermission(merchant.PermissionInvoicesRead), h.GetInvoice)
+	invoices.PATCH("/:id", h.rbac.RequireRolePermission(merchant.PermissionInvoicesCreate),
+		h.rbac.AuditAction("invoice.update"), h.UpdateDraftInvoice)
+	invoices.POST("/:id/finalize", h.rbac.RequireRolePermission(merchant.PermissionInvoicesCreate), h.idempotent(),
+		h.rbac.AuditAction("invoice.finalize"), h.FinalizeInvoice)
+	invoices.POST("/:id/duplicate", h.rbac.RequireRolePermission(merchant.PermissionInvoicesCreate), h.idempotent(),
+		h.rbac.AuditAction("invoice.duplicate"), h.DuplicateInvoice)
+	invoices.POST("/:id/cancel", h.rbac.RequireRolePermission(merchant.PermissionInvoicesCancel),
+		h.idempotent(), h.rbac.AuditAction("invoice.cancel"), h.CancelInvoice)
+	// Amending creates a replacement and cancels the original, so it takes both permissions
+	invoices.POST("/:id/amend", h.rbac.RequireRolePermission(merchant.PermissionInvoicesCreate),
+		h.rbac.RequireRolePermission(merchant.PermissionInvoicesCancel), h.idempotent(),
+		h.rbac.AuditAction("invoice.amend"), h.AmendInvoice)
+	invoices.POST("/:id/extend", h.rbac.RequireRolePermission(merchant.PermissionInvoicesCreate), h.idempotent(),
+		h.rbac.AuditAction("invoice.extend"), h.ExtendInvoice)
+	invoices.POST("/:id/refunds", h.rbac.RequireRolePermission(merchant.PermissionInvoicesRefund),
+		h.idempotent(), h.rbac.AuditAction("invoice.refund"), h.RefundInvoice)
+	invoices.POST("/:id/refund-destination/confirm", h.rbac.RequireRolePermission(merchant.PermissionInvoicesRefund),
+		h.rbac.AuditAction("invoice.confirm_refund_destination"), h.ConfirmRefundDestination)
+
+	protected.GET("/currencies", h.rbac.RequireRolePermission(merchant.PermissionInvoicesRead), h.ListCurrencies)
 
 	// Analytics routes
 	analytics := protected.Group("/analytics")
-	analytics.GET("", h.GetAnalytics)
+	analytics.GET("", h.rbac.RequireRolePermission(merchant.PermissionAnalyticsRead), h.GetAnalytics)
 
 	// Admin routes
 	admin := protected.Group("/admin")
-	admin.POST("/process-expired-invoices", h.ProcessExpiredInvoices)
+	admin.Use(h.rbac.RequireRolePermission(merchant.PermissionAdminOperations))
+	admin.POST("/process-expired-invoices", h.rbac.AuditAction("admin.process_expired_invoices"), h.ProcessExpiredInvoices)
+}
+
+// SetRBACMiddleware enables team member role checks and audit recording on the API routes.
+func (h *Handler) SetRBACMiddleware(rbac *RBACMiddleware) {
+	h.rbac = rbac
+}
+
+// SetSessionAuthMiddleware enables dashboard session and API key authentication on the API routes.
+func (h *Handler) SetSessionAuthMiddleware(sessionAuth *SessionAuthMiddleware) {
+	h.sessionAuth = sessionAuth
+}
+
+// SetCouponService enables coupon codes on invoice creation and the checkout page.
+func (h *Handler) SetCouponService(couponService coupon.Service) {
+	h.couponService = couponService
+}
+
+// SetTaxService enables jurisdiction-based taxes on invoice creation.
+func (h *Handler) SetTaxService(taxService tax.Service) {
+	h.taxService = taxService
+}
+
+// SetPaymentLinkService enables the public payment link routes.
+func (h *Handler) SetPaymentLinkService(paymentLinkService paymentlink.Service) {
+	h.paymentLinkService = paymentLinkService
+}
+
+// SetInvoiceEventStream enables invoice updates, such as re-quotes, on the checkout page event stream.
+func (h *Handler) SetInvoiceEventStream(invoiceEvents *InvoiceEventStream) {
+	h.invoiceEvents = invoiceEvents
+}
+
+// SetApprovalService holds refunds over the approval threshold until enough approvers sign off on them.
+func (h *Handler) SetApprovalService(approvalService approval.Service) {
+	h.approvalService = approvalService
+}
+
+// SetLocalization translates the checkout page into the customer's language, falling back to the merchant's
+// default locale. The merchant locales may be nil, in which case the fallback is English.
+func (h *Handler) SetLocalization(bundle *i18n.Bundle, merchantLocales MerchantLocales) {
+	h.bundle = bundle
+	h.merchantLocales = merchantLocales
+}
+
+// SetIdempotency enables Idempotency-Key replay on the invoice creation, cancellation and refund routes.
+func (h *Handler) SetIdempotency(idempotency *Idempotency) {
+	h.idempotency = idempotency
+}
+
+// SetRedirectSigner appends signed tokens to the return and cancel URLs customers are sent back to merchants with.
+func (h *Handler) SetRedirectSigner(redirectSigner *RedirectSigner) {
+	h.redirectSigner = redirectSigner
+}
+
+// SetMerchantOrigins answers cross-origin public API requests from the sites merchants allow, such as those of
+// the embedded payment widget.
+func (h *Handler) SetMerchantOrigins(merchantOrigins MerchantOrigins) {
+	h.merchantOrigins = merchantOrigins
+}
+
+// SetProcessorService offers the checkouts of the merchant's payment processors alongside on-chain payment.
+func (h *Handler) SetProcessorService(processorService processor.Service) {
+	h.processorService = processorService
+}
+
+// SetConfirmationEstimator adds the expected time until confirmation of the invoice's payments to its status.
+func (h *Handler) SetConfirmationEstimator(estimator *confirmation.Estimator) {
+	h.confirmationETA = estimator
+}
+
+// SetExplorerResolver links the checkout page to the block explorer of the invoice's network.
+func (h *Handler) SetExplorerResolver(explorers *shared.ExplorerResolver) {
+	h.explorers = explorers
+}
+
+// SetIncidents shows the active incident affecting the invoice's network as a banner on the checkout page.
+func (h *Handler) SetIncidents(incidents Incidents) {
+	h.incidents = incidents
+}
+
+// SetEvidenceService records what checkout pages show customers, as evidence for payment disputes.
+func (h *Handler) SetEvidenceService(evidenceService evidence.Service) {
+	h.evidenceService = evidenceService
+}
+
+// SetExperimentService shows checkout sessions the variants of their merchant's running experiment.
+func (h *Handler) SetExperimentService(experimentService experiment.Service) {
+	h.experimentService = experimentService
+}
+
+// SetCheckoutAnalytics counts checkout page views and the funnel steps customers reach.
+func (h *Handler) SetCheckoutAnalytics(checkoutAnalytics checkoutanalytics.Service) {
+	h.checkoutAnalytics = checkoutAnalytics
+}
+
+// SetCountryLocator locates checkout clients by their address when the edge proxy does not tell their country.
+func (h *Handler) SetCountryLocator(countryLocator shared.CountryLocator) {
+	h.countryLocator = countryLocator
+}
+
+// SetGeoBlocking refuses checkouts to clients in the countries their merchant restricts.
+func (h *Handler) SetGeoBlocking(geoBlocking geoblock.Service) {
+	h.geoBlocking = geoBlocking
+}
+
+// authenticate returns the authentication middleware for protected routes, falling back to
+// token format validation when session auth is not configured.
+func (h *Handler) authenticate() gin.HandlerFunc {
+	if h.sessionAuth == nil {
+		return AuthMiddleware(h.Logger)
+	}
+	return h.sessionAuth.RequireAuth()
+}
+
+// idempotent returns the Idempotency-Key replay middleware for a route, or a no-op when it is disabled.
+func (h *Handler) idempotent() gin.HandlerFunc {
+	if h.idempotency == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return h.idempotency.Middleware()
 }
 
 // healthCheck returns the health status of the API.
@@ -176,12 +368,14 @@ func (h *Handler) createErrorResponse(errorType, message string, err error) Erro
 
 // GetInvoiceStatus returns the payment status for a customer-facing invoice.
 // @Summary Get invoice status for customers
-// @Description Get the current status of an invoice for customer-facing display
+// @Description Get the current status, progress, expiry countdown and next poll time of an invoice for customer-facing display. Send the ETag of the previous response in If-None-Match to get 304 while the invoice is unchanged.
 // @Tags Customer API
 // @Accept json
 // @Produce json
 // @Param id path string true "Invoice ID"
+// @Param If-None-Match header string false "ETag of the previous response"
 // @Success 200 {object} PublicInvoiceStatusResponse "Invoice status retrieved successfully"
+// @Success 304 "Invoice status not modified"
 // @Failure 400 {object} ErrorResponse "Invalid invoice ID"
 // @Failure 404 {object} ErrorResponse "Invoice not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
@@ -195,7 +389,7 @@ func (h *Handler) GetInvoiceStatus(c *gin.Context) {
 	}
 
 	// Get invoice status from service
-	status, err := h.invoiceService.GetInvoiceStatus(c.Request.Context(), id)
+	summary, err := h.invoiceService.GetInvoiceStatusSummary(c.Request.Context(), id)
 	if err != nil {
 		h.Logger.Error("Failed to get invoice status", zap.Error(err), zap.String("invoice_id", id))
 		if errors.Is(err, shared.ErrNotFound) {
@@ -206,13 +400,7 @@ func (h *Handler) GetInvoiceStatus(c *gin.Context) {
 		return
 	}
 
-	response := PublicInvoiceStatusResponse{
-		ID:        id,
-		Status:    status.String(),
-		Timestamp: time.Now().UTC(),
-	}
-
-	c.JSON(http.StatusOK, response)
+	h.respondInvoiceStatus(c, summary)
 }
 
 // ListInvoices returns a paginated list of invoices for merchants/admins.
@@ -222,10 +410,12 @@ func (h *Handler) GetInvoiceStatus(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Param page query int false "Page number" default(1) minimum(1)
+// @Param page query int false "Page number (deprecated, use cursor)" default(1) minimum(1)
 // @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param cursor query string false "Cursor from next_cursor of the previous page; replaces page"
 // @Param status query string false "Filter by status"
 // @Param merchant query string false "Filter by merchant ID"
+// @Param metadata[key] query string false "Filter by the value of a metadata key declared in filterable_metadata_keys, e.g. metadata[order_id]=A-1"
 // @Success 200 {object} ListInvoicesResponse "Invoices retrieved successfully"
 // @Failure 400 {object} ErrorResponse "Invalid request parameters"
 // @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
@@ -239,8 +429,7 @@ func (h *Handler) ListInvoices(c *gin.Context) {
 		return
 	}
 
-	// Get merchant ID from authentication context (for now, use placeholder)
-	merchantID := "test-merchant" // TODO: Extract from JWT token
+	merchantID := requestMerchantID(c)
 
 	// Build filter options
 	var status *invoice.InvoiceStatus
@@ -255,10 +444,25 @@ func (h *Handler) ListInvoices(c *gin.Context) {
 		Limit:      req.Limit,
 		Offset:     (req.Page - 1) * req.Limit,
 	}
+	if metadata := c.QueryMap("metadata"); len(metadata) > 0 {
+		filter.Metadata = metadata
+	}
+	if req.Cursor != "" {
+		cursor, err := shared.DecodeCursor(req.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+			return
+		}
+		filter.Cursor = cursor
+	}
 
 	// Get invoices from service
-	response, err := h.invoiceService.ListInvoices(c.Request.Context(), filter)
+	response, err := h.invoiceService.ListInvoices(shared.WithReplicaReads(c.Request.Context()), filter)
 	if err != nil {
+		if errors.Is(err, invoice.ErrInvalidRequest) {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+			return
+		}
 		h.Logger.Error("Failed to list invoices", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, createValidationErrorResponse("Failed to retrieve invoices", err))
 		return
@@ -280,10 +484,65 @@ func (h *Handler) ListInvoices(c *gin.Context) {
 		Limit:    req.Limit,
 		Pages:    pages,
 	}
+	if response.NextCursor != nil {
+		listResponse.NextCursor = response.NextCursor.Encode()
+	}
 
 	c.JSON(http.StatusOK, listResponse)
 }
 
+// GetInvoiceStatusBatch returns the payment state of many invoices in one request.
+// @Summary Get the status of many invoices
+// @Description Get the status, paid and remaining amounts, and confirmations of up to 500 invoices at once
+// @Tags Invoices
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body InvoiceStatusBatchRequest true "Invoice IDs"
+// @Success 200 {object} InvoiceStatusBatchResponse "Invoice statuses retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoices/status-batch [post]
+func (h *Handler) GetInvoiceStatusBatch(c *gin.Context) {
+	var req InvoiceStatusBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Logger.Error("Failed to bind invoice status batch request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid invoice status batch request", err))
+		return
+	}
+
+	statuses, err := h.invoiceService.GetInvoiceStatuses(c.Request.Context(), req.InvoiceIDs)
+	if err != nil {
+		if errors.Is(err, invoice.ErrStatusBatchTooLarge) {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid invoice status batch request", err))
+			return
+		}
+		h.Logger.Error("Failed to get invoice statuses", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, createValidationErrorResponse("Failed to retrieve invoice statuses", err))
+		return
+	}
+
+	response := InvoiceStatusBatchResponse{
+		Invoices: make(map[string]InvoiceStatusSummaryResponse, len(statuses)),
+		NotFound: []string{},
+	}
+	seen := make(map[string]bool, len(req.InvoiceIDs))
+	for _, id := range req.InvoiceIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if summary, found := statuses[id]; found {
+			response.Invoices[id] = ToInvoiceStatusSummaryResponse(summary)
+		} else {
+			response.NotFound = append(response.NotFound, id)
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // CancelInvoice cancels an invoice.
 // @Summary Cancel an invoice
 // @Description Cancel an invoice with a reason
@@ -314,6 +573,12 @@ func (h *Handler) CancelInvoice(c *gin.Context) {
 		return
 	}
 
+	// Snapshot the invoice for the audit log; a lookup failure is reported by the cancellation below
+	var before map[string]interface{}
+	if current, err := h.invoiceService.GetInvoice(c.Request.Context(), id); err == nil {
+		before = map[string]interface{}{"status": current.Status().String()}
+	}
+
 	// Cancel the invoice
 	err := h.invoiceService.CancelInvoice(c.Request.Context(), id, req.Reason)
 	if err != nil {
@@ -334,6 +599,8 @@ func (h *Handler) CancelInvoice(c *gin.Context) {
 		return
 	}
 
+	setAuditChange(c, before, map[string]interface{}{"status": inv.Status().String(), "reason": req.Reason})
+
 	response := CancelInvoiceResponse{
 		ID:          id,
 		Status:      inv.Status().String(),
@@ -413,7 +680,40 @@ func (h *Handler) GetAnalytics(c *gin.Context) {
 				"2024-03": 35,
 			},
 		},
+		Experiments: h.experimentResults(c),
+		Checkout:    h.checkoutSummary(c, &req),
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+// experimentResults returns the checkout conversion of the variants of the caller's experiments, leaving out
+// drafts that showed no variant yet. None are returned if they cannot be read, so analytics still show.
+func (h *Handler) experimentResults(c *gin.Context) []ExperimentResultsResponse {
+	merchantID := c.GetString("merchant_id")
+	if h.experimentService == nil || merchantID == "" {
+		return nil
+	}
+
+	ctx := c.Request.Context()
+	experiments, err := h.experimentService.ListExperiments(ctx, merchantID)
+	if err != nil {
+		h.Logger.Warn("Failed to list experiments for analytics", zap.Error(err))
+		return nil
+	}
+
+	var responses []ExperimentResultsResponse
+	for _, e := range experiments {
+		if e.Status() == experiment.StatusDraft {
+			continue
+		}
+		results, err := h.experimentService.GetResults(ctx, merchantID, e.ID())
+		if err != nil {
+			h.Logger.Warn("Failed to get experiment results for analytics",
+				zap.Error(err), zap.String("experiment_id", e.ID()))
+			return nil
+		}
+		responses = append(responses, ToExperimentResultsResponse(results))
+	}
+	return responses
+}
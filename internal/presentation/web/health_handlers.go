@@ -0,0 +1,96 @@
+package web
+
+import (
+	"crypto-checkout/internal/infrastructure/health"
+	"crypto-checkout/pkg/metrics"
+	"expvar"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// HealthHandlers handles readiness and liveness probe requests.
+type HealthHandlers struct {
+	healthService *health.Service
+	logger        *zap.Logger
+}
+
+// NewHealthHandlers creates a new health handlers instance.
+func NewHealthHandlers(healthService *health.Service, logger *zap.Logger) *HealthHandlers {
+	return &HealthHandlers{
+		healthService: healthService,
+		logger:        logger,
+	}
+}
+
+// Live handles GET /health/live requests.
+// @Summary Liveness probe
+// @Description Report that the process is running without touching any dependency
+// @Tags System
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Process is alive"
+// @Router /health/live [get]
+func (h *HealthHandlers) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  health.StatusUp,
+		"service": "crypto-checkout",
+	})
+}
+
+// Ready handles GET /health/ready requests.
+// @Summary Readiness probe
+// @Description Verify database, migrations, event bus and blockchain RPC with per-check latency, and report the
+// @Description circuit breakers of outbound dependencies. Open breakers do not make the service unready.
+// @Tags System
+// @Produce json
+// @Success 200 {object} health.Report "All dependencies are ready"
+// @Failure 503 {object} health.Report "At least one dependency is not ready"
+// @Router /health/ready [get]
+func (h *HealthHandlers) Ready(c *gin.Context) {
+	report := h.healthService.Readiness(c.Request.Context())
+	if !report.Ready() {
+		h.logger.Warn("Readiness probe failed", zap.Any("checks", report.Checks))
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// Vars handles GET /debug/vars requests.
+// @Summary Runtime metrics
+// @Description Report process counters published with expvar, such as invoice cache hits and misses and
+// @Description circuit breaker state
+// @Tags System
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Published counters"
+// @Router /debug/vars [get]
+func (h *HealthHandlers) Vars(c *gin.Context) {
+	expvar.Handler().ServeHTTP(c.Writer, c.Request)
+}
+
+// Metrics handles GET /metrics requests.
+// @Summary Prometheus metrics
+// @Description Report the metrics the alert rules and dashboard of `crypto-checkout observability export` read,
+// @Description such as webhook backlog, chain watcher lag and payment confirmation latency, in the Prometheus
+// @Description text format
+// @Tags System
+// @Produce plain
+// @Success 200 {string} string "Metrics in the Prometheus text format"
+// @Router /metrics [get]
+func (h *HealthHandlers) Metrics(c *gin.Context) {
+	c.Header("Content-Type", metrics.ContentType)
+	c.Status(http.StatusOK)
+	if err := metrics.Default().WriteText(c.Request.Context(), c.Writer); err != nil {
+		h.logger.Warn("Failed to collect metrics", zap.Error(err))
+	}
+}
+
+// RegisterHealthRoutes registers probe and metrics routes.
+func (h *HealthHandlers) RegisterHealthRoutes(r gin.IRoutes) {
+	r.GET("/health/live", h.Live)
+	r.GET("/health/ready", h.Ready)
+	r.GET("/debug/vars", h.Vars)
+	r.GET("/metrics", h.Metrics)
+}
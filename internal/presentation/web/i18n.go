@@ -0,0 +1,94 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/pkg/i18n"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MerchantLocales provides the checkout page language each merchant has chosen.
+type MerchantLocales interface {
+	// DefaultLocale returns the merchant's default checkout locale, or i18n.DefaultLocale if it has not chosen one.
+	DefaultLocale(ctx context.Context, merchantID string) (i18n.Locale, error)
+}
+
+// LocalizeErrors translates the message of JSON error responses into the language negotiated from the
+// Accept-Language header. The error code stays stable; English requests and codes without a translation
+// keep the handler's own message.
+func LocalizeErrors(bundle *i18n.Bundle) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := i18n.Negotiate(c.GetHeader("Accept-Language"), i18n.DefaultLocale)
+		if locale == i18n.DefaultLocale {
+			c.Next()
+			return
+		}
+
+		writer := &errorBufferingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if writer.body.Len() > 0 {
+			_, _ = c.Writer.Write(localizeError(bundle, locale, writer.body.Bytes()))
+		}
+	}
+}
+
+// errorBufferingWriter holds back the body of error responses so that their message can be translated.
+type errorBufferingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write buffers the body of an error response and passes any other body through.
+func (w *errorBufferingWriter) Write(data []byte) (int, error) {
+	if w.Status() < http.StatusBadRequest {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.body.Write(data)
+}
+
+// WriteString buffers the body of an error response and passes any other body through.
+func (w *errorBufferingWriter) WriteString(s string) (int, error) {
+	if w.Status() < http.StatusBadRequest {
+		return w.ResponseWriter.WriteString(s)
+	}
+	return w.body.WriteString(s)
+}
+
+// localizeError replaces the message of a JSON error body with the translation of its code, returning any
+// other body unchanged.
+func localizeError(bundle *i18n.Bundle, locale i18n.Locale, body []byte) []byte {
+	var response map[string]interface{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return body
+	}
+	code, ok := response["code"].(string)
+	if !ok || !bundle.Translates(locale, "errors."+code) {
+		return body
+	}
+
+	response["message"] = bundle.Translate(locale, "errors."+code)
+	localized, err := json.Marshal(response)
+	if err != nil {
+		return body
+	}
+	return localized
+}
+
+// checkoutLocale picks the checkout page language from the customer's Accept-Language header, falling back to
+// the merchant's default locale.
+func (h *Handler) checkoutLocale(c *gin.Context, merchantID string) i18n.Locale {
+	fallback := i18n.DefaultLocale
+	if h.merchantLocales != nil {
+		locale, err := h.merchantLocales.DefaultLocale(c.Request.Context(), merchantID)
+		if err == nil {
+			fallback = locale
+		}
+	}
+	return i18n.Negotiate(c.GetHeader("Accept-Language"), fallback)
+}
@@ -0,0 +1,95 @@
+package web_test
+
+import (
+	"bytes"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestLocalization(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	bundle, err := i18n.NewBundle()
+	require.NoError(t, err)
+	router := web.NewGinEngine(&config.Config{}, zap.NewNop(), bundle, nil)
+
+	handler := web.CreateTestHandler()
+	handler.SetExplorerResolver(shared.DefaultExplorerResolver())
+	handler.RegisterRoutes(router)
+
+	requestBody, err := json.Marshal(web.CreateInvoiceRequest{
+		Title:   "Localized Invoice",
+		Items:   []web.InvoiceItemRequest{{Name: "Widget", Quantity: "1", UnitPrice: "1234.50"}},
+		TaxRate: "0.00",
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/invoices", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer sk_live_test123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created web.CreateInvoiceResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	page := func(acceptLanguage string) string {
+		req := httptest.NewRequest(http.MethodGet, "/invoice/"+created.ID, nil)
+		req.Header.Set("Accept-Language", acceptLanguage)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		return w.Body.String()
+	}
+
+	t.Run("CheckoutPage_NegotiatesLanguage", func(t *testing.T) {
+		body := page("es-ES,es;q=0.9,en;q=0.8")
+		require.Contains(t, body, `<html lang="es">`)
+		require.Contains(t, body, "Detalles del pago")
+		require.Contains(t, body, "1.234,50 USD")
+	})
+
+	t.Run("CheckoutPage_FallsBackToEnglish", func(t *testing.T) {
+		body := page("de-DE")
+		require.Contains(t, body, `<html lang="en">`)
+		require.Contains(t, body, "Payment Details")
+		require.Contains(t, body, "1,234.50 USD")
+		require.Contains(t, body, `href="https://tronscan.org/#/address/`+created.Address+`"`)
+		require.Contains(t, body, "View on block explorer")
+	})
+
+	changeCurrency := func(acceptLanguage string) web.ErrorResponse {
+		body := bytes.NewBufferString(`{"crypto_currency":"BTC"}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/public/invoice/missing/currency", body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Language", acceptLanguage)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		var response web.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("ErrorMessage_Translated", func(t *testing.T) {
+		response := changeCurrency("ru")
+		require.Equal(t, "NOT_FOUND", response.Code)
+		require.Equal(t, bundle.Translate(i18n.LocaleRussian, "errors.NOT_FOUND"), response.Message)
+	})
+
+	t.Run("ErrorMessage_EnglishUnchanged", func(t *testing.T) {
+		english := changeCurrency("en-US")
+		require.Equal(t, "NOT_FOUND", english.Code)
+		require.NotEqual(t, bundle.Translate(i18n.LocaleRussian, "errors.NOT_FOUND"), english.Message)
+	})
+}
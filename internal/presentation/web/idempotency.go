@@ -0,0 +1,166 @@
+package web
+
+import (
+	"bytes"
+	"crypto-checkout/internal/infrastructure/cache"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client-chosen key that makes a retried POST safe.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set to "true" on responses replayed for a repeated idempotency key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// idempotencyKeyTTL is how long a response is replayed for its idempotency key.
+	idempotencyKeyTTL = 24 * time.Hour
+	// maxIdempotencyKeys bounds the number of stored responses.
+	maxIdempotencyKeys = 100000
+	// maxIdempotencyKeyLength is the longest idempotency key accepted.
+	maxIdempotencyKeyLength = 255
+)
+
+// idempotentResponse is a stored response and the fingerprint of the request that produced it.
+type idempotentResponse struct {
+	fingerprint string
+	status      int
+	contentType string
+	body        []byte
+}
+
+// Idempotency replays the response of a POST request when a client retries it with the same Idempotency-Key,
+// so that a retry after a timeout cannot create a second invoice or refund. Keys are scoped to the credentials
+// and route of the request; responses are kept for 24 hours.
+type Idempotency struct {
+	responses *cache.TTLCache[string, idempotentResponse]
+
+	mu       sync.Mutex
+	inFlight map[string]struct{}
+}
+
+// NewIdempotency creates a new idempotency key store.
+func NewIdempotency() *Idempotency {
+	return &Idempotency{
+		responses: cache.NewTTLCache[string, idempotentResponse](idempotencyKeyTTL, maxIdempotencyKeys),
+		inFlight:  make(map[string]struct{}),
+	}
+}
+
+// Middleware replays the stored response for a repeated Idempotency-Key, rejects a key reused with a different
+// body or while its first request is still running, and stores the response of a new key. Server errors are
+// not stored, so the request can be retried with the same key.
+func (i *Idempotency) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest,
+				createAuthErrorResponse("validation_error", "INVALID_IDEMPOTENCY_KEY",
+					"Idempotency-Key must be at most 255 characters"))
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope := idempotencyScope(c, key)
+		if !i.begin(scope) {
+			c.AbortWithStatusJSON(http.StatusConflict,
+				createAuthErrorResponse("conflict", "IDEMPOTENCY_KEY_IN_USE",
+					"A request with this Idempotency-Key is still being processed"))
+			return
+		}
+		defer i.end(scope)
+
+		fingerprint := hashHex(body)
+		if stored, ok := i.responses.Get(scope); ok {
+			if stored.fingerprint != fingerprint {
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity,
+					createAuthErrorResponse("validation_error", "IDEMPOTENCY_KEY_REUSED",
+						"Idempotency-Key was already used with a different request body"))
+				return
+			}
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(stored.status, stored.contentType, stored.body)
+			c.Abort()
+			return
+		}
+
+		generation := i.responses.Generation()
+		writer := &responseCapturingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if writer.Status() < http.StatusInternalServerError {
+			i.responses.SetSince(scope, idempotentResponse{
+				fingerprint: fingerprint,
+				status:      writer.Status(),
+				contentType: writer.Header().Get("Content-Type"),
+				body:        writer.body.Bytes(),
+			}, generation)
+		}
+	}
+}
+
+// begin marks the scoped key as in flight, reporting false if it already is.
+func (i *Idempotency) begin(scope string) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if _, ok := i.inFlight[scope]; ok {
+		return false
+	}
+	i.inFlight[scope] = struct{}{}
+	return true
+}
+
+// end releases the scoped key.
+func (i *Idempotency) end(scope string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.inFlight, scope)
+}
+
+// idempotencyScope keys the stored response by the caller's credentials, the route and the idempotency key.
+func idempotencyScope(c *gin.Context, key string) string {
+	return hashHex([]byte(c.GetHeader("Authorization"))) + " " + c.Request.Method + " " + c.Request.URL.Path +
+		" " + key
+}
+
+// hashHex returns the hex SHA-256 of data.
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// responseCapturingWriter keeps a copy of the response body while writing it.
+type responseCapturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write writes the body and keeps a copy.
+func (w *responseCapturingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString writes the body and keeps a copy.
+func (w *responseCapturingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,82 @@
+package web_test
+
+import (
+	"bytes"
+	"crypto-checkout/internal/presentation/web"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	handler := web.CreateTestHandler()
+	handler.RegisterRoutes(router)
+
+	createInvoice := func(key, title string) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(web.CreateInvoiceRequest{
+			Title:   title,
+			Items:   []web.InvoiceItemRequest{{Name: "Widget", Quantity: "1", UnitPrice: "10.00"}},
+			TaxRate: "0.00",
+		})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/invoices", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+		if key != "" {
+			req.Header.Set(web.IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	invoiceID := func(w *httptest.ResponseRecorder) string {
+		var response web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.ID
+	}
+
+	t.Run("RepeatedKey_ReplaysResponse", func(t *testing.T) {
+		first := createInvoice("order-1001", "Order 1001")
+		require.Equal(t, http.StatusCreated, first.Code, first.Body.String())
+		require.Empty(t, first.Header().Get(web.IdempotentReplayedHeader))
+
+		second := createInvoice("order-1001", "Order 1001")
+		require.Equal(t, http.StatusCreated, second.Code, second.Body.String())
+		require.Equal(t, "true", second.Header().Get(web.IdempotentReplayedHeader))
+		require.Equal(t, invoiceID(first), invoiceID(second))
+	})
+
+	t.Run("DifferentKeys_CreateSeparateInvoices", func(t *testing.T) {
+		first := createInvoice("order-1002", "Order 1002")
+		second := createInvoice("order-1003", "Order 1002")
+		require.Equal(t, http.StatusCreated, second.Code, second.Body.String())
+		require.NotEqual(t, invoiceID(first), invoiceID(second))
+	})
+
+	t.Run("NoKey_CreatesSeparateInvoices", func(t *testing.T) {
+		first := createInvoice("", "Order 1004")
+		second := createInvoice("", "Order 1004")
+		require.NotEqual(t, invoiceID(first), invoiceID(second))
+	})
+
+	t.Run("ReusedKeyWithDifferentBody_Rejected", func(t *testing.T) {
+		first := createInvoice("order-1005", "Order 1005")
+		require.Equal(t, http.StatusCreated, first.Code, first.Body.String())
+
+		second := createInvoice("order-1005", "Order 1006")
+		require.Equal(t, http.StatusUnprocessableEntity, second.Code, second.Body.String())
+		var response web.ErrorResponse
+		require.NoError(t, json.Unmarshal(second.Body.Bytes(), &response))
+		require.Equal(t, "IDEMPOTENCY_KEY_REUSED", response.Code)
+	})
+}
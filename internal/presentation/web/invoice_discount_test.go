@@ -0,0 +1,87 @@
+package web_test
+
+import (
+	"bytes"
+	"crypto-checkout/internal/presentation/web"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateInvoiceDiscounts(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	handler := web.CreateTestHandler()
+
+	router.POST("/api/v1/invoices", web.AuthMiddleware(handler.Logger), handler.CreateInvoice)
+
+	create := func(body web.CreateInvoiceRequest) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/invoices", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("CreateInvoice_WithDiscounts", func(t *testing.T) {
+		// Given: a 10% item discount, a 5.00 invoice discount and 10% tax
+		w := create(web.CreateInvoiceRequest{
+			Title: "Discounted Invoice",
+			Items: []web.InvoiceItemRequest{
+				{
+					Name:      "Widget",
+					Quantity:  "2",
+					UnitPrice: "50.00",
+					Discount:  &web.DiscountRequest{Type: "percentage", Value: "10"},
+				},
+			},
+			Discount: &web.DiscountRequest{Type: "fixed", Value: "5.00"},
+			TaxRate:  "0.10",
+		})
+
+		// Then
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, "90.00", response.Subtotal)
+		require.Equal(t, "5.00", response.DiscountAmount)
+		require.Equal(t, "8.50", response.TaxAmount)
+		require.Equal(t, "93.50", response.Total)
+
+		require.Len(t, response.Items, 1)
+		require.NotNil(t, response.Items[0].Discount)
+		require.Equal(t, "10.00", response.Items[0].Discount.Amount)
+		require.Equal(t, "90.00", response.Items[0].Total)
+
+		require.NotNil(t, response.Discounts)
+		require.Equal(t, "10.00", response.Discounts.Items)
+		require.NotNil(t, response.Discounts.Invoice)
+		require.Equal(t, "5.00", response.Discounts.Invoice.Amount)
+		require.Nil(t, response.Discounts.Coupon)
+	})
+
+	t.Run("CreateInvoice_InvalidDiscount", func(t *testing.T) {
+		w := create(web.CreateInvoiceRequest{
+			Title: "Over Discounted Invoice",
+			Items: []web.InvoiceItemRequest{
+				{Name: "Widget", Quantity: "1", UnitPrice: "10.00"},
+			},
+			Discount: &web.DiscountRequest{Type: "percentage", Value: "150"},
+			TaxRate:  "0.00",
+		})
+		// The error middleware that maps the error to 400 is not mounted on the test router
+		require.NotEqual(t, http.StatusCreated, w.Code)
+	})
+}
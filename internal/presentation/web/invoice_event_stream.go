@@ -0,0 +1,87 @@
+package web
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"sync"
+)
+
+// invoiceEventBuffer is the number of unread events a subscriber holds before newer ones are dropped.
+const invoiceEventBuffer = 16
+
+// InvoiceEventStream relays invoice events, and the confirmations of the invoice's payments, published in
+// this process to the checkout pages following the invoice over Server-Sent Events.
+type InvoiceEventStream struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan *shared.BaseDomainEvent]struct{}
+}
+
+// NewInvoiceEventStream creates a new invoice event stream with no subscribers.
+func NewInvoiceEventStream() *InvoiceEventStream {
+	return &InvoiceEventStream{
+		subscribers: make(map[string]map[chan *shared.BaseDomainEvent]struct{}),
+	}
+}
+
+// NewCheckoutEventStream creates the invoice event stream and registers it with the event bus.
+func NewCheckoutEventStream(registry shared.EventHandlerRegistry) *InvoiceEventStream {
+	stream := NewInvoiceEventStream()
+	registry.RegisterHandler(stream)
+	return stream
+}
+
+// Subscribe returns a channel receiving the events published for an invoice, and a function that ends the
+// subscription. Events arriving while the subscriber has invoiceEventBuffer unread ones are dropped rather
+// than blocking the publisher, so subscribers should reload the invoice rather than rely on receiving every
+// event.
+func (s *InvoiceEventStream) Subscribe(invoiceID string) (<-chan *shared.BaseDomainEvent, func()) {
+	events := make(chan *shared.BaseDomainEvent, invoiceEventBuffer)
+
+	s.mu.Lock()
+	if s.subscribers[invoiceID] == nil {
+		s.subscribers[invoiceID] = make(map[chan *shared.BaseDomainEvent]struct{})
+	}
+	s.subscribers[invoiceID][events] = struct{}{}
+	s.mu.Unlock()
+
+	return events, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subscribers[invoiceID], events)
+		if len(s.subscribers[invoiceID]) == 0 {
+			delete(s.subscribers, invoiceID)
+		}
+	}
+}
+
+// EventTypes returns the events relayed to checkout pages.
+func (s *InvoiceEventStream) EventTypes() []string {
+	return []string{
+		shared.EventTypeInvoiceRequoted,
+		shared.EventTypeInvoiceExtended,
+		shared.EventTypePaymentConfirmation,
+	}
+}
+
+// HandleEvent notifies the subscribers of the invoice an event was published for.
+func (s *InvoiceEventStream) HandleEvent(_ context.Context, event *shared.BaseDomainEvent) error {
+	invoiceID := event.AggregateID
+	if event.EventType == shared.EventTypePaymentConfirmation {
+		confirmation, ok := event.EventData.(shared.PaymentConfirmationEvent)
+		if !ok {
+			return nil
+		}
+		invoiceID = confirmation.InvoiceID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for events := range s.subscribers[invoiceID] {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package web_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/presentation/web"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoiceEventStream(t *testing.T) {
+	ctx := context.Background()
+	requoted := func(invoiceID string) *shared.BaseDomainEvent {
+		return shared.CreateDomainEvent(shared.EventTypeInvoiceRequoted, invoiceID, "Invoice", nil, nil)
+	}
+	confirmation := func(invoiceID string, confirmations int) *shared.BaseDomainEvent {
+		return shared.NewPaymentConfirmationEvent(shared.PaymentConfirmationEvent{
+			PaymentEvent: shared.PaymentEvent{
+				PaymentID:     "payment-1",
+				InvoiceID:     invoiceID,
+				Status:        "confirming",
+				Confirmations: confirmations,
+				BlockNumber:   1000,
+			},
+			RequiredConfirmations: 12,
+			BlockHash:             "0xabc",
+		})
+	}
+
+	t.Run("Relays_Events_Of_Subscribed_Invoice", func(t *testing.T) {
+		stream := web.NewInvoiceEventStream()
+		events, unsubscribe := stream.Subscribe("invoice-1")
+		defer unsubscribe()
+
+		require.NoError(t, stream.HandleEvent(ctx, requoted("invoice-2")))
+		require.Empty(t, events)
+
+		require.NoError(t, stream.HandleEvent(ctx, requoted("invoice-1")))
+		require.Len(t, events, 1)
+		require.Equal(t, shared.EventTypeInvoiceRequoted, (<-events).EventType)
+	})
+
+	t.Run("Relays_Payment_Confirmations_To_Their_Invoice", func(t *testing.T) {
+		stream := web.NewInvoiceEventStream()
+		events, unsubscribe := stream.Subscribe("invoice-1")
+		defer unsubscribe()
+
+		require.NoError(t, stream.HandleEvent(ctx, confirmation("invoice-2", 1)))
+		require.Empty(t, events)
+
+		// Every confirmation is relayed, in order
+		require.NoError(t, stream.HandleEvent(ctx, confirmation("invoice-1", 1)))
+		require.NoError(t, stream.HandleEvent(ctx, confirmation("invoice-1", 2)))
+		require.Len(t, events, 2)
+
+		first, ok := web.ToPaymentConfirmationEvent(<-events)
+		require.True(t, ok)
+		require.Equal(t, 1, first.Confirmations)
+		second, ok := web.ToPaymentConfirmationEvent(<-events)
+		require.True(t, ok)
+		require.Equal(t, "invoice-1", second.InvoiceID)
+		require.Equal(t, 2, second.Confirmations)
+		require.Equal(t, 12, second.RequiredConfirmations)
+		require.Equal(t, "0xabc", second.BlockHash)
+	})
+
+	t.Run("Drops_Events_Instead_Of_Blocking", func(t *testing.T) {
+		stream := web.NewInvoiceEventStream()
+		events, unsubscribe := stream.Subscribe("invoice-1")
+		defer unsubscribe()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for range cap(events) + 1 {
+				require.NoError(t, stream.HandleEvent(ctx, requoted("invoice-1")))
+			}
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("publishing to a subscriber that does not read blocked")
+		}
+		require.Len(t, events, cap(events))
+	})
+
+	t.Run("Stops_After_Unsubscribe", func(t *testing.T) {
+		stream := web.NewInvoiceEventStream()
+		events, unsubscribe := stream.Subscribe("invoice-1")
+		unsubscribe()
+
+		require.NoError(t, stream.HandleEvent(ctx, requoted("invoice-1")))
+		require.Empty(t, events)
+	})
+}
@@ -1,12 +1,18 @@
 package web
 
 import (
+	"crypto-checkout/internal/domain/approval"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/experiment"
 	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/merchant"
 	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/pkg/i18n"
 	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -16,20 +22,6 @@ import (
 	"go.uber.org/zap"
 )
 
-// invoiceItemWrapper implements shared.InvoiceItem interface for DTO conversion
-type invoiceItemWrapper struct {
-	unitPrice  *shared.Money
-	totalPrice *shared.Money
-}
-
-func (w *invoiceItemWrapper) UnitPrice() *shared.Money {
-	return w.unitPrice
-}
-
-func (w *invoiceItemWrapper) TotalPrice() *shared.Money {
-	return w.totalPrice
-}
-
 const (
 	// QR code generation constants.
 	qrCodeWidth     = 8
@@ -77,7 +69,7 @@ func (h *Handler) CreateInvoice(c *gin.Context) {
 	}
 
 	// Convert API request to service request
-	serviceReq, err := convertToServiceCreateInvoiceRequest(req)
+	serviceReq, err := convertToServiceCreateInvoiceRequest(req, requestMerchantID(c))
 	if err != nil {
 		h.Logger.Error("Failed to convert request", zap.Error(err))
 		if err := c.Error(err); err != nil {
@@ -85,9 +77,22 @@ func (h *Handler) CreateInvoice(c *gin.Context) {
 		}
 		return
 	}
+	serviceReq.LiveKey = c.GetString("api_key_type") == string(merchant.KeyTypeLive)
+
+	if err := h.resolveCreateInvoiceTax(c, req, &serviceReq); err != nil {
+		return
+	}
+
+	redeemed, err := h.redeemCreateInvoiceCoupon(c, req.CouponCode, &serviceReq)
+	if err != nil {
+		return
+	}
 
 	inv, err := h.invoiceService.CreateInvoice(c.Request.Context(), &serviceReq)
 	if err != nil {
+		if redeemed != nil {
+			_ = h.couponService.ReleaseCoupon(c.Request.Context(), redeemed.ID())
+		}
 		h.Logger.Error("Failed to create invoice", zap.Error(err))
 		h.Logger.Debug("Adding error to context", zap.Error(err))
 		if err := c.Error(err); err != nil {
@@ -105,20 +110,28 @@ func (h *Handler) CreateInvoice(c *gin.Context) {
 	c.JSON(http.StatusCreated, response)
 }
 
-// convertToServiceCreateInvoiceRequest converts API request to service request.
-func convertToServiceCreateInvoiceRequest(req CreateInvoiceRequest) (invoice.CreateInvoiceRequest, error) {
+// convertToServiceCreateInvoiceRequest converts API request to service request for the merchant's invoice.
+// Tax is calculated by the service from the rate once discounts are applied.
+func convertToServiceCreateInvoiceRequest(
+	req CreateInvoiceRequest,
+	merchantID string,
+) (invoice.CreateInvoiceRequest, error) {
 	items, err := convertInvoiceItems(req.Items)
 	if err != nil {
 		return invoice.CreateInvoiceRequest{}, err
 	}
 
-	taxAmount, err := calculateTaxAmount(req, items)
+	discount, err := parseDiscount(req.Discount)
 	if err != nil {
 		return invoice.CreateInvoiceRequest{}, err
 	}
 
 	currency := parseCurrency(req.Currency)
 	cryptoCurrency := parseCryptoCurrency(req.CryptoCurrency)
+	minimumAmount, err := parseMinimumAmount(req.MinimumAmount, currency)
+	if err != nil {
+		return invoice.CreateInvoiceRequest{}, err
+	}
 	paymentTolerance, err := parsePaymentTolerance(req.PaymentTolerance)
 	if err != nil {
 		return invoice.CreateInvoiceRequest{}, err
@@ -126,21 +139,38 @@ func convertToServiceCreateInvoiceRequest(req CreateInvoiceRequest) (invoice.Cre
 
 	expirationDuration := parseExpirationDuration(req.ExpiresIn)
 
+	customFields, err := parseCustomFields(req.CustomFields)
+	if err != nil {
+		return invoice.CreateInvoiceRequest{}, err
+	}
+	settlementSplit, err := parseSettlementSplit(req.SettlementSplit)
+	if err != nil {
+		return invoice.CreateInvoiceRequest{}, err
+	}
+
 	return invoice.CreateInvoiceRequest{
-		MerchantID:         "test-merchant", // TODO: Get from authentication context
-		CustomerID:         nil,             // TODO: Extract from metadata if present
+		MerchantID:         merchantID,
+		CustomerID:         nil, // TODO: Extract from metadata if present
 		Title:              req.Title,
 		Description:        req.Description,
+		Type:               invoice.InvoiceType(req.Type),
+		MinimumAmount:      minimumAmount,
 		Items:              items,
-		Tax:                taxAmount,
+		Discount:           discount,
+		TaxRate:            req.TaxRate,
 		Currency:           currency,
 		CryptoCurrency:     cryptoCurrency,
+		Network:            shared.BlockchainNetwork(req.Network),
 		PaymentTolerance:   paymentTolerance,
 		ExpirationDuration: expirationDuration,
 		Metadata:           req.Metadata,
+		CustomFields:       customFields,
+		SettlementSplit:    settlementSplit,
+		PassFee:            req.PassFee,
 		WebhookURL:         req.WebhookURL,
 		ReturnURL:          req.ReturnURL,
 		CancelURL:          req.CancelURL,
+		Draft:              req.Draft,
 	}, nil
 }
 
@@ -153,46 +183,101 @@ func convertInvoiceItems(dtoItems []InvoiceItemRequest) ([]*invoice.CreateInvoic
 			return nil, invoice.ErrInvalidUnitPrice
 		}
 
+		discount, err := parseDiscount(item.Discount)
+		if err != nil {
+			return nil, err
+		}
+
 		items[i] = &invoice.CreateInvoiceItemRequest{
 			Name:        item.Name,
 			Description: item.Description,
 			Quantity:    item.Quantity,
 			UnitPrice:   unitPrice,
+			Discount:    discount,
+			TaxCategory: item.TaxCategory,
 		}
 	}
 	return items, nil
 }
 
-// calculateTaxAmount calculates tax amount using tax calculator.
-func calculateTaxAmount(req CreateInvoiceRequest, items []*invoice.CreateInvoiceItemRequest) (*shared.Money, error) {
-	taxCalculator := shared.NewTaxCalculator()
+// parseDiscount parses an optional discount from DTO.
+func parseDiscount(dtoDiscount *DiscountRequest) (*invoice.Discount, error) {
+	if dtoDiscount == nil {
+		return nil, nil
+	}
 
-	// Convert DTO items to shared InvoiceItem interface
-	invoiceItems := make([]shared.InvoiceItem, len(items))
-	for i, item := range items {
-		quantity, err := decimal.NewFromString(item.Quantity)
+	discount, err := invoice.NewDiscount(invoice.DiscountType(dtoDiscount.Type), dtoDiscount.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", invoice.ErrInvalidRequest, err)
+	}
+	return discount, nil
+}
+
+// parseCustomFields parses the custom fields of a request. A nil request leaves the fields unchanged and an empty
+// one removes them, so the result is only nil for a nil request.
+func parseCustomFields(dtoFields []CustomFieldRequest) ([]*invoice.CustomField, error) {
+	if dtoFields == nil {
+		return nil, nil
+	}
+
+	fields := make([]*invoice.CustomField, len(dtoFields))
+	for i, dtoField := range dtoFields {
+		field, err := invoice.NewCustomField(dtoField.Label, dtoField.Value,
+			invoice.CustomFieldType(dtoField.Type), invoice.CustomFieldVisibility(dtoField.Visibility))
 		if err != nil {
-			quantity = decimal.Zero
+			return nil, fmt.Errorf("%w: %w", invoice.ErrInvalidRequest, err)
 		}
-		totalPrice, err := item.UnitPrice.Multiply(quantity)
+		fields[i] = field
+	}
+	return fields, nil
+}
+
+// parseSplitShares parses the beneficiaries' shares of a request. A nil request leaves the split unchanged and
+// an empty one removes it, so the result is only nil for a nil request.
+func parseSplitShares(dtoShares []SplitShareRequest) ([]*invoice.SplitShare, error) {
+	if dtoShares == nil {
+		return nil, nil
+	}
+
+	shares := make([]*invoice.SplitShare, len(dtoShares))
+	for i, dtoShare := range dtoShares {
+		share, err := invoice.NewSplitShare(dtoShare.BeneficiaryID, invoice.SplitShareType(dtoShare.Type), dtoShare.Value)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%w: %w", invoice.ErrInvalidRequest, err)
 		}
+		shares[i] = share
+	}
+	return shares, nil
+}
 
-		invoiceItems[i] = &invoiceItemWrapper{
-			unitPrice:  item.UnitPrice,
-			totalPrice: totalPrice,
-		}
+// parseSettlementSplit parses an optional settlement split from DTO.
+func parseSettlementSplit(dtoShares []SplitShareRequest) (*invoice.SettlementSplit, error) {
+	if len(dtoShares) == 0 {
+		return nil, nil
 	}
 
-	// Calculate subtotal
-	subtotalMoney, err := taxCalculator.CalculateSubtotal(invoiceItems)
+	shares, err := parseSplitShares(dtoShares)
 	if err != nil {
 		return nil, err
 	}
+	split, err := invoice.NewSettlementSplit(shares)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", invoice.ErrInvalidRequest, err)
+	}
+	return split, nil
+}
+
+// parseMinimumAmount parses an optional donation minimum in the invoice currency.
+func parseMinimumAmount(amount string, currency shared.Currency) (*shared.Money, error) {
+	if amount == "" {
+		return nil, nil
+	}
 
-	// Calculate tax amount
-	return taxCalculator.CalculateTaxFromRate(req.TaxRate, subtotalMoney)
+	minimum, err := shared.NewMoney(amount, currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid minimum_amount: %w", invoice.ErrInvalidRequest, err)
+	}
+	return minimum, nil
 }
 
 // parseCurrency parses currency from string.
@@ -250,6 +335,25 @@ func parseExpirationDuration(expiresIn *int) time.Duration {
 
 // validateCreateInvoiceRequest performs additional validation on the request.
 func validateCreateInvoiceRequest(req CreateInvoiceRequest) error {
+	// Donations have a minimum instead of items, discounts and taxes
+	if req.Type == invoice.InvoiceTypeDonation.String() {
+		return validateDonationInvoiceRequest(req)
+	}
+	if req.MinimumAmount != "" {
+		return fmt.Errorf("%w: minimum_amount requires type donation", invoice.ErrInvalidRequest)
+	}
+
+	// Jurisdiction rules replace the flat tax rate
+	if req.TaxJurisdiction != "" {
+		if req.TaxRate != "" {
+			return fmt.Errorf("%w: tax_rate cannot be combined with tax_jurisdiction", invoice.ErrInvalidRequest)
+		}
+		return nil
+	}
+	if req.CustomerTaxID != "" {
+		return fmt.Errorf("%w: customer_tax_id requires tax_jurisdiction", invoice.ErrInvalidRequest)
+	}
+
 	// Validate tax rate is not negative
 	if req.TaxRate == "" {
 		return fmt.Errorf("%w: tax rate is required", invoice.ErrInvalidRequest)
@@ -268,6 +372,20 @@ func validateCreateInvoiceRequest(req CreateInvoiceRequest) error {
 	return nil
 }
 
+// validateDonationInvoiceRequest checks a donation request names a minimum and nothing that prices a fixed total.
+func validateDonationInvoiceRequest(req CreateInvoiceRequest) error {
+	if req.MinimumAmount == "" {
+		return fmt.Errorf("%w: minimum_amount is required for donations", invoice.ErrInvalidRequest)
+	}
+	if len(req.Items) > 0 || req.Discount != nil || req.CouponCode != "" {
+		return fmt.Errorf("%w: donations cannot have items, discounts or coupons", invoice.ErrInvalidRequest)
+	}
+	if req.Tax != nil || req.TaxRate != "" || req.TaxJurisdiction != "" || req.CustomerTaxID != "" {
+		return fmt.Errorf("%w: donations cannot be taxed", invoice.ErrInvalidRequest)
+	}
+	return nil
+}
+
 // GetInvoice handles GET /api/v1/invoices/:id requests.
 // @Summary Get invoice details
 // @Description Retrieve detailed information about a specific invoice
@@ -302,6 +420,536 @@ func (h *Handler) GetInvoice(c *gin.Context) {
 
 	// Convert invoice to DTO for JSON response
 	response := ToCreateInvoiceResponse(inv)
+	response.GeoBlocks = h.invoiceGeoBlocks(c, inv)
+	c.JSON(http.StatusOK, response)
+}
+
+// DuplicateInvoice handles POST /api/v1/invoices/:id/duplicate requests.
+// @Summary Duplicate an invoice
+// @Description Reissue an invoice with the same items, discounts, taxes and customer. The duplicate is quoted
+// @Description at the current exchange rate, gets a fresh payment address and expires after the same window.
+// @Description Coupons are not carried over.
+// @Tags Invoices
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Invoice ID"
+// @Success 201 {object} CreateInvoiceResponse "Invoice duplicated successfully"
+// @Failure 400 {object} ErrorResponse "Invoice cannot be reissued"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 404 {object} ErrorResponse "Invoice not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoices/{id}/duplicate [post]
+func (h *Handler) DuplicateInvoice(c *gin.Context) {
+	id := c.Param("id")
+
+	inv, err := h.invoiceService.DuplicateInvoice(c.Request.Context(), id)
+	if err != nil {
+		h.Logger.Error("Failed to duplicate invoice", zap.Error(err), zap.String("invoice_id", id))
+		if err := c.Error(err); err != nil {
+			h.Logger.Error("Failed to set error in context", zap.Error(err))
+		}
+		return
+	}
+
+	setAuditChange(c, nil, map[string]interface{}{"invoice_id": inv.ID(), "duplicate_of": id})
+
+	response := ToCreateInvoiceResponse(inv)
+	response.InvoiceURL = "/api/v1/invoices/" + inv.ID()
+	c.JSON(http.StatusCreated, response)
+}
+
+// UpdateDraftInvoice handles PATCH /api/v1/invoices/:id requests.
+// @Summary Edit a draft invoice
+// @Description Replace the items, tax, customer, expiry window or metadata of a draft invoice and reprice it.
+// @Description Omitted fields are left unchanged. Drafts whose taxes follow a jurisdiction are taxed again
+// @Description under the merchant's current rules when their items change.
+// @Tags Invoices
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Invoice ID"
+// @Param request body InvoiceChangesRequest true "Draft changes"
+// @Success 200 {object} CreateInvoiceResponse "Draft updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 404 {object} ErrorResponse "Invoice not found"
+// @Failure 409 {object} ErrorResponse "Invoice is not a draft"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoices/{id} [patch]
+func (h *Handler) UpdateDraftInvoice(c *gin.Context) {
+	id := c.Param("id")
+
+	var req InvoiceChangesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Logger.Error("Failed to bind update draft request", zap.Error(err))
+		if err := c.Error(fmt.Errorf("%w: %w", invoice.ErrInvalidRequest, err)); err != nil {
+			h.Logger.Error("Failed to set error in context", zap.Error(err))
+		}
+		return
+	}
+
+	changes, err := convertInvoiceChanges(req)
+	if err != nil {
+		if err := c.Error(err); err != nil {
+			h.Logger.Error("Failed to set error in context", zap.Error(err))
+		}
+		return
+	}
+
+	if err := h.resolveChangesTax(c, id, req, changes); err != nil {
+		return
+	}
+
+	serviceReq := &invoice.UpdateDraftRequest{InvoiceID: id, InvoiceChanges: *changes}
+	inv, err := h.invoiceService.UpdateDraft(c.Request.Context(), serviceReq)
+	if err != nil {
+		h.Logger.Error("Failed to update draft invoice", zap.Error(err), zap.String("invoice_id", id))
+		if err := c.Error(err); err != nil {
+			h.Logger.Error("Failed to set error in context", zap.Error(err))
+		}
+		return
+	}
+
+	setAuditChange(c, nil, map[string]interface{}{"invoice_id": inv.ID(), "total": inv.Pricing().Total().String()})
+
+	c.JSON(http.StatusOK, ToCreateInvoiceResponse(inv))
+}
+
+// convertInvoiceChanges converts the API request to change the terms of an invoice to its service form.
+func convertInvoiceChanges(req InvoiceChangesRequest) (*invoice.InvoiceChanges, error) {
+	if req.TaxRate != nil {
+		if req.TaxJurisdiction != "" {
+			return nil, fmt.Errorf("%w: tax_rate cannot be combined with tax_jurisdiction", invoice.ErrInvalidRequest)
+		}
+		taxRate, err := decimal.NewFromString(*req.TaxRate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid tax rate format", invoice.ErrInvalidRequest)
+		}
+		if taxRate.IsNegative() {
+			return nil, fmt.Errorf("%w: tax rate cannot be negative", invoice.ErrInvalidRequest)
+		}
+	}
+	if req.CustomerTaxID != "" && req.TaxJurisdiction == "" {
+		return nil, fmt.Errorf("%w: customer_tax_id requires tax_jurisdiction", invoice.ErrInvalidRequest)
+	}
+	if req.ExpiresIn != nil && *req.ExpiresIn <= 0 {
+		return nil, fmt.Errorf("%w: expires_in must be positive", invoice.ErrInvalidRequest)
+	}
+
+	changes := &invoice.InvoiceChanges{
+		Title:       req.Title,
+		Description: req.Description,
+		CustomerID:  req.CustomerID,
+		TaxRate:     req.TaxRate,
+		Metadata:    req.Metadata,
+		PassFee:     req.PassFee,
+	}
+	if req.Items != nil {
+		items, err := convertInvoiceItems(req.Items)
+		if err != nil {
+			return nil, err
+		}
+		changes.Items = items
+	}
+	if req.ExpiresIn != nil {
+		expiration := parseExpirationDuration(req.ExpiresIn)
+		changes.ExpirationDuration = &expiration
+	}
+	customFields, err := parseCustomFields(req.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+	changes.CustomFields = customFields
+	splitShares, err := parseSplitShares(req.SettlementSplit)
+	if err != nil {
+		return nil, err
+	}
+	changes.SplitShares = splitShares
+	return changes, nil
+}
+
+// resolveChangesTax resolves the merchant's tax rules for a change of invoice terms. An invoice keeps its
+// jurisdiction when only its items change, so the new items are taxed by category under the current rules.
+func (h *Handler) resolveChangesTax(
+	c *gin.Context,
+	id string,
+	req InvoiceChangesRequest,
+	changes *invoice.InvoiceChanges,
+) error {
+	jurisdiction, customerTaxID := req.TaxJurisdiction, req.CustomerTaxID
+	if jurisdiction == "" && req.Items != nil && req.TaxRate == nil {
+		inv, err := h.invoiceService.GetInvoice(c.Request.Context(), id)
+		if err != nil {
+			if err := c.Error(err); err != nil {
+				h.Logger.Error("Failed to set error in context", zap.Error(err))
+			}
+			return err
+		}
+		if treatment := inv.TaxTreatment(); treatment != nil {
+			jurisdiction, customerTaxID = treatment.Jurisdiction(), treatment.CustomerTaxID()
+		}
+	}
+	if jurisdiction == "" {
+		return nil
+	}
+
+	policy, err := h.resolveTaxPolicy(c, requestMerchantID(c), jurisdiction, customerTaxID)
+	if err != nil {
+		return err
+	}
+	changes.TaxPolicy = policy
+	return nil
+}
+
+// FinalizeInvoice handles POST /api/v1/invoices/:id/finalize requests.
+// @Summary Finalize a draft invoice
+// @Description Lock the exchange rate of a draft invoice and assign its payment address. The invoice moves to
+// @Description created and can be paid until the end of its expiry window, which starts now.
+// @Tags Invoices
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} CreateInvoiceResponse "Invoice finalized successfully"
+// @Failure 400 {object} ErrorResponse "Cryptocurrency no longer accepted"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 404 {object} ErrorResponse "Invoice not found"
+// @Failure 409 {object} ErrorResponse "Invoice is not a draft"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoices/{id}/finalize [post]
+func (h *Handler) FinalizeInvoice(c *gin.Context) {
+	id := c.Param("id")
+
+	inv, err := h.invoiceService.FinalizeInvoice(c.Request.Context(), id)
+	if err != nil {
+		h.Logger.Error("Failed to finalize invoice", zap.Error(err), zap.String("invoice_id", id))
+		if err := c.Error(err); err != nil {
+			h.Logger.Error("Failed to set error in context", zap.Error(err))
+		}
+		return
+	}
+
+	setAuditChange(c,
+		map[string]interface{}{"status": invoice.StatusDraft.String()},
+		map[string]interface{}{"status": inv.Status().String()},
+	)
+
+	response := ToCreateInvoiceResponse(inv)
+	response.InvoiceURL = "/api/v1/invoices/" + inv.ID()
+	c.JSON(http.StatusOK, response)
+}
+
+// AmendInvoice handles POST /api/v1/invoices/:id/amend requests.
+// @Summary Amend an invoice
+// @Description Replace an unpaid finalized invoice with an invoice carrying the changed terms. The original is
+// @Description cancelled and linked to its replacement through superseded_by, and its customer URL redirects to
+// @Description the replacement. The replacement is quoted at the current exchange rate, gets a fresh payment
+// @Description address and keeps the original's coupon and expiry window.
+// @Tags Invoices
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Invoice ID"
+// @Param request body AmendInvoiceRequest true "Invoice changes"
+// @Success 201 {object} CreateInvoiceResponse "Replacement invoice created successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 404 {object} ErrorResponse "Invoice not found"
+// @Failure 409 {object} ErrorResponse "Invoice is a draft, paid or closed"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoices/{id}/amend [post]
+func (h *Handler) AmendInvoice(c *gin.Context) {
+	id := c.Param("id")
+
+	var req AmendInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Logger.Error("Failed to bind amend invoice request", zap.Error(err))
+		if err := c.Error(fmt.Errorf("%w: %w", invoice.ErrInvalidRequest, err)); err != nil {
+			h.Logger.Error("Failed to set error in context", zap.Error(err))
+		}
+		return
+	}
+
+	changes, err := convertInvoiceChanges(req.InvoiceChangesRequest)
+	if err != nil {
+		if err := c.Error(err); err != nil {
+			h.Logger.Error("Failed to set error in context", zap.Error(err))
+		}
+		return
+	}
+
+	if err := h.resolveChangesTax(c, id, req.InvoiceChangesRequest, changes); err != nil {
+		return
+	}
+
+	replacement, err := h.invoiceService.AmendInvoice(c.Request.Context(), &invoice.AmendInvoiceRequest{
+		InvoiceID:      id,
+		Reason:         req.Reason,
+		InvoiceChanges: *changes,
+	})
+	if err != nil {
+		h.Logger.Error("Failed to amend invoice", zap.Error(err), zap.String("invoice_id", id))
+		if err := c.Error(err); err != nil {
+			h.Logger.Error("Failed to set error in context", zap.Error(err))
+		}
+		return
+	}
+
+	setAuditChange(c, nil, map[string]interface{}{
+		"invoice_id": replacement.ID(),
+		"supersedes": id,
+		"total":      replacement.Pricing().Total().String(),
+		"reason":     req.Reason,
+	})
+
+	response := ToCreateInvoiceResponse(replacement)
+	response.InvoiceURL = "/api/v1/invoices/" + replacement.ID()
+	c.JSON(http.StatusCreated, response)
+}
+
+// ExtendInvoice handles POST /api/v1/invoices/:id/extend requests.
+// @Summary Extend an invoice
+// @Description Push back the expiry of an invoice awaiting payment, counted from now if it has already passed.
+// @Description The total extension of an invoice is bounded by the merchant's max_invoice_extension_minutes
+// @Description setting. An invoice whose exchange rate has expired is re-quoted at the current rate. A partially
+// @Description paid invoice can only be extended before its expiry passes.
+// @Tags Invoices
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Invoice ID"
+// @Param request body ExtendInvoiceRequest true "Extension"
+// @Success 200 {object} CreateInvoiceResponse "Invoice extended successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 404 {object} ErrorResponse "Invoice not found"
+// @Failure 409 {object} ErrorResponse "Invoice is a draft, paid or closed, or partially paid and expired"
+// @Failure 422 {object} ErrorResponse "Extension exceeds the merchant's limit"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoices/{id}/extend [post]
+func (h *Handler) ExtendInvoice(c *gin.Context) {
+	id := c.Param("id")
+
+	var req ExtendInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Logger.Error("Failed to bind extend invoice request", zap.Error(err))
+		if err := c.Error(fmt.Errorf("%w: %w", invoice.ErrInvalidRequest, err)); err != nil {
+			h.Logger.Error("Failed to set error in context", zap.Error(err))
+		}
+		return
+	}
+
+	inv, err := h.invoiceService.ExtendInvoice(c.Request.Context(), id, time.Duration(req.ExtendBy)*time.Second)
+	if err != nil {
+		h.Logger.Error("Failed to extend invoice", zap.Error(err), zap.String("invoice_id", id))
+		if err := c.Error(err); err != nil {
+			h.Logger.Error("Failed to set error in context", zap.Error(err))
+		}
+		return
+	}
+
+	extensions := inv.Extensions()
+	setAuditChange(c,
+		map[string]interface{}{"expires_at": extensions[len(extensions)-1].PreviousExpiresAt()},
+		map[string]interface{}{"expires_at": inv.Expiration().ExpiresAt()},
+	)
+
+	response := ToCreateInvoiceResponse(inv)
+	response.InvoiceURL = "/api/v1/invoices/" + inv.ID()
+	c.JSON(http.StatusOK, response)
+}
+
+// ListCurrencies handles GET /api/v1/currencies requests.
+// @Summary List accepted cryptocurrencies
+// @Description List the cryptocurrencies and networks the merchant's invoices may be paid in
+// @Tags Invoices
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} ListCurrenciesResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Router /api/v1/currencies [get]
+func (h *Handler) ListCurrencies(c *gin.Context) {
+	tokens, err := h.invoiceService.AcceptedTokens(c.Request.Context(), requestMerchantID(c))
+	if err != nil {
+		h.Logger.Error("Failed to list accepted currencies", zap.Error(err))
+		if err := c.Error(err); err != nil {
+			h.Logger.Error("Failed to set error in context", zap.Error(err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, ToListCurrenciesResponse(tokens))
+}
+
+// RefundInvoice handles POST /api/v1/invoices/:id/refunds requests.
+// @Summary Refund an invoice
+// @Description Refund part or all of the amount paid for an invoice to its confirmed refund destination
+// @Tags Invoices
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Invoice ID"
+// @Param request body RefundInvoiceRequest true "Refund request"
+// @Success 201 {object} RefundInvoiceResponse "Refund issued successfully"
+// @Success 202 {object} ApprovalResponse "Refund held for approval"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 404 {object} ErrorResponse "Invoice not found"
+// @Failure 409 {object} ErrorResponse "Invoice unpaid, refund destination unconfirmed, or refunded concurrently"
+// @Failure 422 {object} ErrorResponse "Refund exceeds the refundable amount"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoices/{id}/refunds [post]
+func (h *Handler) RefundInvoice(c *gin.Context) {
+	id := c.Param("id")
+
+	var req RefundInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Logger.Error("Failed to bind refund invoice request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid JSON format", err))
+		return
+	}
+
+	// Snapshot the invoice for the audit log; a lookup failure is reported by the refund below
+	var before map[string]interface{}
+	if current, err := h.invoiceService.GetInvoice(c.Request.Context(), id); err == nil {
+		before = map[string]interface{}{
+			"status":          current.Status().String(),
+			"refunded_amount": current.RefundedAmount().Amount().String(),
+		}
+	}
+
+	requester := audit.Actor{ID: c.GetString("api_key_id"), Type: audit.ActorTypeAPIKey}
+	if userID := c.GetString("user_id"); userID != "" {
+		requester = audit.Actor{ID: userID, Type: audit.ActorTypeUser, Role: c.GetString("user_role")}
+	}
+
+	// Refunds over the approval threshold are held until enough approvers sign off on them
+	if h.approvalService != nil {
+		pending, err := h.approvalService.RequestRefund(c.Request.Context(), &approval.RefundRequest{
+			MerchantID: c.GetString("merchant_id"),
+			InvoiceID:  id,
+			Amount:     req.Amount,
+			Reason:     req.Reason,
+			Requester:  requester,
+		})
+		if err != nil {
+			h.respondRefundError(c, id, err)
+			return
+		}
+		if pending != nil {
+			setAuditChange(c, before, map[string]interface{}{
+				"approval_id":     pending.ID(),
+				"approval_status": string(pending.Status()),
+				"refund_amount":   pending.Amount().String(),
+			})
+			c.JSON(http.StatusAccepted, ToApprovalResponse(pending))
+			return
+		}
+	}
+
+	refund, err := h.invoiceService.RefundInvoice(c.Request.Context(), &invoice.RefundInvoiceRequest{
+		InvoiceID:   id,
+		Amount:      req.Amount,
+		Reason:      req.Reason,
+		RequestedBy: requester.ID,
+	})
+	if err != nil {
+		h.respondRefundError(c, id, err)
+		return
+	}
+
+	inv, err := h.invoiceService.GetInvoice(c.Request.Context(), id)
+	if err != nil {
+		h.Logger.Error("Failed to get updated invoice after refund", zap.Error(err), zap.String("invoice_id", id))
+		c.JSON(http.StatusInternalServerError, createValidationErrorResponse("Failed to retrieve updated invoice", err))
+		return
+	}
+
+	setAuditChange(c, before, map[string]interface{}{
+		"status":          inv.Status().String(),
+		"refunded_amount": inv.RefundedAmount().Amount().String(),
+		"refund_id":       refund.ID(),
+	})
+
+	c.JSON(http.StatusCreated, RefundInvoiceResponse{
+		InvoiceID: inv.ID(),
+		Status:    inv.Status().String(),
+		Refund:    ToInvoiceRefundResponse(refund),
+		Refunds:   ToInvoiceRefundsResponse(inv),
+	})
+}
+
+// respondRefundError maps the errors of refunding an invoice to HTTP responses.
+func (h *Handler) respondRefundError(c *gin.Context, id string, err error) {
+	h.Logger.Error("Failed to refund invoice", zap.Error(err), zap.String("invoice_id", id))
+	switch {
+	case errors.Is(err, shared.ErrNotFound):
+		c.JSON(http.StatusNotFound, createNotFoundErrorResponse("invoice not found"))
+	case errors.Is(err, invoice.ErrCannotRefundInvoice):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", invoice.ErrCodeCannotRefundInvoice, "Only paid invoices can be refunded"))
+	case errors.Is(err, invoice.ErrRefundExceedsPaid):
+		c.JSON(http.StatusUnprocessableEntity, createAuthErrorResponse(
+			"validation_error", invoice.ErrCodeRefundExceedsPaid, err.Error()))
+	case errors.Is(err, invoice.ErrRefundDestinationUnconfirmed):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", invoice.ErrCodeRefundDestinationUnconfirmed, err.Error()))
+	case errors.Is(err, invoice.ErrConcurrentUpdate):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", invoice.ErrCodeConcurrentUpdate, invoice.ErrConcurrentUpdate.Error()))
+	case errors.Is(err, invoice.ErrInvalidRefundAmount), errors.Is(err, approval.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		c.JSON(http.StatusInternalServerError, createValidationErrorResponse("Failed to refund invoice", err))
+	}
+}
+
+// ConfirmRefundDestination handles POST /api/v1/invoices/:id/refund-destination/confirm requests.
+// @Summary Confirm an invoice's refund destination
+// @Description Confirm the refund address proposed for an invoice, either the sender of most of its confirmed
+// @Description payments or an address the customer supplied. The address must match the proposal, so the
+// @Description address reviewed is the one confirmed. Refunds are only sent to a confirmed address.
+// @Tags Invoices
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Invoice ID"
+// @Param request body ConfirmRefundDestinationRequest true "Address to confirm"
+// @Success 200 {object} CreateInvoiceResponse "Refund destination confirmed"
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 404 {object} ErrorResponse "Invoice not found"
+// @Failure 409 {object} ErrorResponse "No refund address proposed, or it no longer matches"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoices/{id}/refund-destination/confirm [post]
+func (h *Handler) ConfirmRefundDestination(c *gin.Context) {
+	id := c.Param("id")
+
+	var req ConfirmRefundDestinationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Logger.Error("Failed to bind confirm refund destination request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid JSON format", err))
+		return
+	}
+
+	confirmedBy := c.GetString("user_id")
+	if confirmedBy == "" {
+		confirmedBy = c.GetString("api_key_id")
+	}
+
+	inv, err := h.invoiceService.ConfirmRefundDestination(c.Request.Context(), id, req.Address, confirmedBy)
+	if err != nil {
+		respondRefundDestinationError(c, h.Logger, err, "Failed to confirm refund destination")
+		return
+	}
+
+	setAuditChange(c, nil, map[string]interface{}{
+		"refund_address": inv.RefundDestination().Address(),
+		"source":         string(inv.RefundDestination().Source()),
+		"verified":       inv.RefundDestination().Verified(),
+	})
+
+	response := ToCreateInvoiceResponse(inv)
+	response.InvoiceURL = "/api/v1/invoices/" + inv.ID()
 	c.JSON(http.StatusOK, response)
 }
 
@@ -365,7 +1013,7 @@ func (h *Handler) getInvoiceQR(c *gin.Context) {
 		return
 	}
 
-	inv, err := h.invoiceService.GetInvoice(c.Request.Context(), id)
+	inv, err := h.customerInvoice(c.Request.Context(), id)
 	if err != nil {
 		h.Logger.Error("Failed to get invoice for QR code", zap.Error(err), zap.String("invoice_id", id))
 		if err := c.Error(err); err != nil {
@@ -383,10 +1031,13 @@ func (h *Handler) getInvoiceQR(c *gin.Context) {
 		return
 	}
 
-	// Create Tron USDT payment URI
+	// Create Tron USDT payment URI, or the payment URI of the network the customer switched to
 	qrContent := fmt.Sprintf("tron:%s?amount=%s&token=USDT",
 		paymentAddress.String(),
 		inv.Pricing().Total().Amount().String())
+	if paymentAddress.Network().Mainnet() != shared.NetworkTron {
+		qrContent = invoice.GetInvoiceQRData(inv)
+	}
 
 	// Generate QR code image
 	imageData, err := h.GenerateQRCodeImage(qrContent)
@@ -416,7 +1067,8 @@ func (h *Handler) getPublicInvoice(c *gin.Context) {
 		return
 	}
 
-	inv, err := h.invoiceService.GetInvoice(c.Request.Context(), id)
+	// The page may be served from a read replica; marking the invoice viewed below reads the primary
+	inv, err := h.customerInvoice(shared.WithReplicaReads(c.Request.Context()), id)
 	if err != nil {
 		h.Logger.Error("Failed to get invoice", zap.Error(err), zap.String("invoice_id", id))
 		if err := c.Error(err); err != nil {
@@ -425,34 +1077,241 @@ func (h *Handler) getPublicInvoice(c *gin.Context) {
 		return
 	}
 
+	// An amended invoice sends the customer on to its replacement
+	if replacementID := inv.SupersededBy(); replacementID != nil {
+		target := "/invoice/" + *replacementID
+		if c.Request.URL.RawQuery != "" {
+			target += "?" + c.Request.URL.RawQuery
+		}
+		c.Redirect(http.StatusFound, target)
+		return
+	}
+
+	// Merchants may refuse checkouts in the jurisdictions they cannot serve
+	decision, err := h.checkGeoRestrictions(c, inv)
+	if err != nil {
+		h.Logger.Error("Failed to check geo restrictions", zap.Error(err), zap.String("invoice_id", id))
+		if err := c.Error(err); err != nil {
+			h.Logger.Error("Failed to set error in context", zap.Error(err))
+		}
+		return
+	}
+	if !decision.Allowed {
+		h.renderCheckoutUnavailable(c, inv)
+		return
+	}
+
 	// Mark invoice as viewed (created → pending transition)
 	if markErr := h.invoiceService.MarkInvoiceAsViewed(c.Request.Context(), id); markErr != nil {
 		h.Logger.Warn("Failed to mark invoice as viewed", zap.Error(markErr), zap.String("invoice_id", id))
 		// Don't fail the request, just log the warning
 	}
+	h.captureCheckout(c.Request.Context(), inv)
+	h.recordCheckoutView(c, inv)
+
+	// Inline scripts only run with the nonce of this response
+	nonce, err := setCheckoutSecurityPolicy(c)
+	if err != nil {
+		h.Logger.Error("Failed to set security policy", zap.Error(err), zap.String("invoice_id", id))
+		if err := c.Error(err); err != nil {
+			h.Logger.Error("Failed to set error in context", zap.Error(err))
+		}
+		return
+	}
+
+	locale := h.checkoutLocale(c, inv.MerchantID())
+	pricing := inv.Pricing()
+	messages := h.bundle.Messages(locale, "checkout.")
 
 	// Prepare template data with real invoice data
 	templateData := gin.H{
 		"Invoice":        inv,
-		"Title":          "Invoice #" + inv.ID(),
-		"QRCodeURL":      fmt.Sprintf("/invoices/%s/qr", inv.ID()),
+		"Locale":         locale.String(),
+		"T":              messages,
+		"Layout":         experiment.LayoutStandard.String(),
+		"QRCodeURL":      fmt.Sprintf("/invoice/%s/qr", inv.ID()),
 		"PaymentAddress": inv.PaymentAddress(),
-		"TotalAmount":    inv.Pricing().Total().Amount().String(),
-		"SubtotalAmount": inv.Pricing().Subtotal().Amount().String(),
-		"TaxAmount":      inv.Pricing().Tax().Amount().String(),
-		"TaxRate":        inv.Pricing().Tax().Amount().String(),
+		"Items":          checkoutItems(locale, inv),
+		"CustomFields":   displayFields(inv.CheckoutFields()),
+		"TotalAmount":    formatMoney(locale, pricing.Total()),
+		"SubtotalAmount": formatMoney(locale, pricing.Subtotal()),
+		"TaxAmount":      formatMoney(locale, pricing.Tax()),
+		"ExpiresAt":      checkoutExpiresAt(locale, inv),
+		"CryptoAmount":   toCryptoAmount(inv),
+		"PaymentMemo":    inv.PaymentMemo(),
+		"CryptoCurrency": inv.CryptoCurrency().String(),
+		"NetworkName":    networkName(toNetwork(inv)),
+		"Currencies":     h.checkoutCurrencies(c, inv),
+		"Processors":     h.checkoutOptions(c, inv),
+		"CSPNonce":       nonce,
+	}
+	// Checkout experiments vary the copy, layout and currency order of the page per session
+	if variant := h.checkoutVariant(c, inv); variant != nil {
+		applyCheckoutVariant(templateData, messages, variant)
+	}
+	templateData["Title"] = messages["checkout.invoice"] + " #" + inv.ID()
+	if inv.FeePassThrough() != nil {
+		templateData["FeeAmount"] = formatMoney(locale, pricing.Fee())
+	}
+	// Customers may name a refund address until the merchant confirms one; the message they sign ends in it
+	if destination := inv.RefundDestination(); destination == nil || !destination.IsConfirmed() {
+		templateData["RefundMessagePrefix"] = invoice.RefundAddressMessage(inv.ID(), "")
+		templateData["SignsRefundAddress"] = signsRefundAddress(inv.Network())
+	}
+	if address := inv.PaymentAddress(); address != nil {
+		templateData["AddressExplorerURL"] = h.explorers.AddressURL(inv.Network(), address.String())
+	}
+	if incident := h.checkoutIncident(c, inv.Network()); incident != nil {
+		templateData["Incident"] = incident
+	}
+	if inv.ReturnURL() != nil {
+		templateData["ReturnURL"] = fmt.Sprintf("/invoice/%s/return", inv.ID())
+	}
+	if inv.CancelURL() != nil {
+		templateData["CancelURL"] = fmt.Sprintf("/invoice/%s/return/cancel", inv.ID())
 	}
 
 	// Use Gin's HTML rendering
 	c.HTML(http.StatusOK, "crypto_invoice_page.html", templateData)
 }
 
+// signsRefundAddress reports whether refund addresses on the network can be verified by a signed message.
+func signsRefundAddress(network shared.BlockchainNetwork) bool {
+	switch network.Mainnet() {
+	case shared.NetworkEthereum, shared.NetworkBSC, shared.NetworkTron:
+		return true
+	default:
+		return false
+	}
+}
+
+// checkoutItem is an invoice line as shown on the checkout page, with amounts formatted for the customer's locale.
+type checkoutItem struct {
+	Description string
+	Quantity    string
+	UnitPrice   string
+	TotalPrice  string
+}
+
+// displayField is a custom field as shown on the checkout page and receipts.
+type displayField struct {
+	Label string
+	Value string
+	Link  bool // The value is a URL, shown as a link
+}
+
+// displayFields returns custom fields in the form the checkout page and receipts show them.
+func displayFields(fields []*invoice.CustomField) []displayField {
+	shown := make([]displayField, len(fields))
+	for i, field := range fields {
+		shown[i] = displayField{
+			Label: field.Label(),
+			Value: field.Value(),
+			Link:  field.Type() == invoice.CustomFieldTypeURL,
+		}
+	}
+	return shown
+}
+
+// checkoutItems returns the invoice lines formatted for the locale.
+func checkoutItems(locale i18n.Locale, inv *invoice.Invoice) []checkoutItem {
+	items := make([]checkoutItem, len(inv.Items()))
+	for i, item := range inv.Items() {
+		items[i] = checkoutItem{
+			Description: item.Description(),
+			Quantity:    item.Quantity().String(),
+			UnitPrice:   formatMoney(locale, item.UnitPrice()),
+			TotalPrice:  formatMoney(locale, item.TotalPrice()),
+		}
+	}
+	return items
+}
+
+// formatMoney formats a fiat amount with the locale's separators, followed by its currency code.
+func formatMoney(locale i18n.Locale, money *shared.Money) string {
+	if money == nil {
+		return ""
+	}
+	return i18n.FormatAmount(locale, money.Amount(), 2) + " " + money.Currency()
+}
+
+// checkoutExpiresAt returns the invoice's expiry time formatted for the locale, or an empty string if the
+// invoice does not expire.
+func checkoutExpiresAt(locale i18n.Locale, inv *invoice.Invoice) string {
+	if inv.Expiration() == nil {
+		return ""
+	}
+	return i18n.FormatDateTime(locale, inv.Expiration().ExpiresAt().UTC())
+}
+
+// checkoutCurrency is a cryptocurrency offered by the checkout page's currency selector.
+type checkoutCurrency struct {
+	Symbol      string
+	Network     string
+	NetworkName string
+	Selected    bool
+}
+
+// checkoutCurrencies returns the cryptocurrencies the customer may switch the invoice to. The selector is
+// hidden when there is no choice to make.
+func (h *Handler) checkoutCurrencies(c *gin.Context, inv *invoice.Invoice) []checkoutCurrency {
+	if !inv.CanChangeCurrency() {
+		return nil
+	}
+
+	tokens, err := h.invoiceService.AcceptedTokens(c.Request.Context(), inv.MerchantID())
+	if err != nil {
+		h.Logger.Warn("Failed to list accepted currencies", zap.Error(err), zap.String("invoice_id", inv.ID()))
+		return nil
+	}
+	if len(tokens) < 2 {
+		return nil
+	}
+
+	currencies := make([]checkoutCurrency, len(tokens))
+	for i, token := range tokens {
+		currencies[i] = checkoutCurrency{
+			Symbol:      token.Symbol.String(),
+			Network:     token.Network.String(),
+			NetworkName: networkName(token.Network.String()),
+			Selected:    token.Symbol == inv.CryptoCurrency() && token.Network.String() == toNetwork(inv),
+		}
+	}
+	return currencies
+}
+
+// networkName returns the name of a blockchain network as shown to customers, e.g. "Tron Nile Testnet".
+func networkName(network string) string {
+	var name string
+	switch shared.BlockchainNetwork(network).Mainnet() {
+	case shared.NetworkTron:
+		name = "Tron"
+	case shared.NetworkEthereum:
+		name = "Ethereum"
+	case shared.NetworkBitcoin:
+		name = "Bitcoin"
+	case shared.NetworkBSC:
+		name = "BNB Smart Chain"
+	default:
+		return network
+	}
+
+	if chain := shared.BlockchainNetwork(network).Chain(); chain != shared.ChainMainnet {
+		if chain != "testnet" {
+			name += " " + strings.ToUpper(chain[:1]) + chain[1:]
+		}
+		name += " Testnet"
+	}
+	return name
+}
+
 // ProcessExpiredInvoices processes all expired invoices (admin endpoint for testing)
 // @Summary Process expired invoices
 // @Description Manually trigger processing of expired invoices (admin endpoint)
 // @Tags Admin
 // @Accept json
 // @Produce json
+// @Param X-Admin-Key header string true "Admin key"
 // @Success 200 {object} map[string]interface{} "Processing completed"
 // @Router /api/v1/admin/process-expired-invoices [post]
 func (h *Handler) ProcessExpiredInvoices(c *gin.Context) {
@@ -0,0 +1,99 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestInvoiceStatusBatchEndpoint(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(web.ErrorHandler(&config.Config{}, zap.NewNop()))
+
+	handler, services := web.CreateTestHandlerWithServices()
+	router.POST("/api/v1/invoices", handler.CreateInvoice)
+	router.POST("/api/v1/invoices/status-batch", handler.GetInvoiceStatusBatch)
+
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	createInvoice := func(t *testing.T, body string) web.CreateInvoiceResponse {
+		w := request(http.MethodPost, "/api/v1/invoices", body)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	statusBatch := func(t *testing.T, ids ...string) web.InvoiceStatusBatchResponse {
+		body, err := json.Marshal(web.InvoiceStatusBatchRequest{InvoiceIDs: ids})
+		require.NoError(t, err)
+		w := request(http.MethodPost, "/api/v1/invoices/status-batch", string(body))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response web.InvoiceStatusBatchResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("StatusBatch_Success", func(t *testing.T) {
+		standard := createInvoice(t,
+			`{"title":"Standard","items":[{"name":"Item","quantity":"1","unit_price":"10.00"}],"tax_rate":"0.00"}`)
+		donation := createInvoice(t, `{"title":"Support our work","type":"donation","minimum_amount":"5.00"}`)
+
+		ctx := context.Background()
+		require.NoError(t, services.Invoices.MarkInvoiceAsViewed(ctx, donation.ID))
+		require.NoError(t, services.Invoices.ProcessPayment(ctx, donation.ID,
+			newDonationTestPayment(t, donation.ID, "0x3333333333333333333333333333333333333333333333333333333333333333", "2")))
+
+		response := statusBatch(t, standard.ID, donation.ID, standard.ID, "missing-invoice")
+		require.Len(t, response.Invoices, 2)
+		require.Equal(t, []string{"missing-invoice"}, response.NotFound)
+
+		require.Equal(t, web.InvoiceStatusSummaryResponse{
+			Status:          "created",
+			PaidAmount:      "0",
+			RemainingAmount: "10",
+			Confirmations:   0,
+		}, response.Invoices[standard.ID])
+
+		require.Equal(t, "partial", response.Invoices[donation.ID].Status)
+		require.Equal(t, "2", response.Invoices[donation.ID].PaidAmount)
+		require.Equal(t, "3", response.Invoices[donation.ID].RemainingAmount)
+	})
+
+	t.Run("StatusBatch_Validation", func(t *testing.T) {
+		w := request(http.MethodPost, "/api/v1/invoices/status-batch", `{"invoice_ids":[]}`)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		w = request(http.MethodPost, "/api/v1/invoices/status-batch", `{"invoice_ids":[""]}`)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		ids := make([]string, 501)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("%q", fmt.Sprintf("invoice-%d", i))
+		}
+		w = request(http.MethodPost, "/api/v1/invoices/status-batch",
+			`{"invoice_ids":[`+strings.Join(ids, ",")+`]}`)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+}
@@ -0,0 +1,99 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/slo"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// statusPollInterval is how long clients wait between polls of an invoice awaiting payment.
+	statusPollInterval = 10 * time.Second
+	// minConfirmingPollInterval and maxConfirmingPollInterval bound the poll interval of an invoice whose
+	// payments confirm, which follows the network's block time.
+	minConfirmingPollInterval = 5 * time.Second
+	maxConfirmingPollInterval = time.Minute
+)
+
+// trackStatusRead counts an invoice status read against the status reads objective once it is answered.
+func trackStatusRead(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+	slo.RecordStatusRead(time.Since(start), c.Writer.Status())
+}
+
+// respondInvoiceStatus writes the polled status of an invoice. The response carries a weak ETag over the
+// invoice state; a request whose If-None-Match matches it gets 304 Not Modified without a body, so clients
+// polling an invoice that has not changed keep counting down from their previous response.
+func (h *Handler) respondInvoiceStatus(c *gin.Context, summary *invoice.StatusSummary) {
+	etag := invoiceStatusETag(summary)
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "no-cache")
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	response := ToPublicInvoiceStatusResponse(summary, shared.Now())
+	response.ConfirmationETA = h.invoiceConfirmationETA(c.Request.Context(), summary.InvoiceID)
+	if interval, ok := nextStatusPoll(summary, response.ConfirmationETA); ok {
+		seconds := int64(interval.Seconds())
+		response.NextPollAfter = &seconds
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// nextStatusPoll returns how long a client should wait before polling the invoice again, and false once the
+// invoice is final. Invoices whose payments confirm are polled about once a block, and no poll is scheduled
+// past the invoice's expiry.
+func nextStatusPoll(summary *invoice.StatusSummary, eta *ConfirmationETAResponse) (time.Duration, bool) {
+	if summary.Status.IsTerminal() {
+		return 0, false
+	}
+
+	interval := statusPollInterval
+	if eta != nil {
+		blockTime := time.Duration(eta.BlockTimeSeconds * float64(time.Second))
+		interval = min(max(blockTime, minConfirmingPollInterval), maxConfirmingPollInterval)
+	}
+	if summary.ExpiresAt != nil {
+		untilExpiry := summary.ExpiresAt.Sub(shared.Now()).Truncate(time.Second)
+		interval = max(min(interval, untilExpiry), time.Second)
+	}
+	return interval.Truncate(time.Second), true
+}
+
+// invoiceStatusETag returns the weak entity tag of an invoice's polled state. It leaves out the countdowns,
+// which change with every request while the invoice does not.
+func invoiceStatusETag(summary *invoice.StatusSummary) string {
+	var expiresAt int64
+	if summary.ExpiresAt != nil {
+		expiresAt = summary.ExpiresAt.Unix()
+	}
+	state := fmt.Sprintf("%s|%s|%s|%s|%d|%d", summary.InvoiceID, summary.Status,
+		summary.AmountDue.String(), summary.AmountPaid.String(), summary.Confirmations, expiresAt)
+	sum := sha256.Sum256([]byte(state))
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header matches an entity tag, comparing tags weakly.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
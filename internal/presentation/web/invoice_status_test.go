@@ -2,11 +2,18 @@ package web_test
 
 import (
 	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/confirmation"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
 	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/test/testutil"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
@@ -121,3 +128,176 @@ func TestInvoiceStatusEndpoint(t *testing.T) {
 		require.Contains(t, response.Message, "invoice ID")
 	})
 }
+
+func TestInvoiceStatusEndpoint_ConfirmationETA(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler, services := web.CreateTestHandlerWithServices()
+	handler.SetConfirmationEstimator(confirmation.NewEstimator())
+	router.GET("/invoice/:id/status", handler.GetInvoiceStatus)
+	router.GET("/api/v1/public/invoice/:id/status", handler.GetPublicInvoiceStatus)
+
+	ctx := context.Background()
+	unitPrice, err := shared.NewMoney("20.00", shared.CurrencyUSD)
+	require.NoError(t, err)
+	inv, err := services.Invoices.CreateInvoice(ctx, &invoice.CreateInvoiceRequest{
+		MerchantID:     "test-merchant",
+		Title:          "ETA Invoice",
+		Items:          []*invoice.CreateInvoiceItemRequest{{Name: "Item", Quantity: "1", UnitPrice: unitPrice}},
+		TaxRate:        "0.00",
+		Currency:       shared.CurrencyUSD,
+		CryptoCurrency: shared.CryptoCurrencyUSDT,
+	})
+	require.NoError(t, err)
+
+	status := func(path string) web.PublicInvoiceStatusResponse {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		require.Equal(t, http.StatusOK, w.Code)
+		var response web.PublicInvoiceStatusResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	// No ETA until a payment is waiting for confirmations
+	require.Nil(t, status("/invoice/"+inv.ID()+"/status").ConfirmationETA)
+
+	p, err := testutil.NewPaymentBuilder().
+		WithID("payment-eta-1").
+		ForInvoice(inv.ID()).
+		RequiringConfirmations(19).
+		Build()
+	require.NoError(t, err)
+	_, err = services.Payments.CreatePayment(ctx, &payment.CreatePaymentRequest{
+		ID:                    p.ID(),
+		InvoiceID:             p.InvoiceID(),
+		Amount:                p.Amount(),
+		FromAddress:           p.FromAddress(),
+		ToAddress:             p.ToAddress(),
+		TransactionHash:       p.TransactionHash(),
+		RequiredConfirmations: p.RequiredConfirmations(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, services.Payments.UpdateConfirmations(ctx, p.ID(), 7))
+
+	for _, path := range []string{"/invoice/" + inv.ID() + "/status", "/api/v1/public/invoice/" + inv.ID() + "/status"} {
+		eta := status(path).ConfirmationETA
+		require.NotNil(t, eta, path)
+		require.Equal(t, "tron", eta.Network)
+		require.Equal(t, 12, eta.RemainingConfirmations)
+		require.Equal(t, "nominal", eta.BlockTimeSource)
+		require.InDelta(t, 3.0, eta.BlockTimeSeconds, 0.001)
+		require.Equal(t, int64(36), eta.SecondsRemaining)
+	}
+}
+
+func TestInvoiceStatusEndpoint_PollingProtocol(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	clock := testutil.NewClock(time.Time{})
+	testutil.UseClock(t, clock)
+	router := gin.New()
+	handler, services := web.CreateTestHandlerWithServices()
+	handler.SetConfirmationEstimator(confirmation.NewEstimator())
+	router.GET("/api/v1/public/invoice/:id/status", handler.GetPublicInvoiceStatus)
+
+	ctx := context.Background()
+	unitPrice, err := shared.NewMoney("20.00", shared.CurrencyUSD)
+	require.NoError(t, err)
+	inv, err := services.Invoices.CreateInvoice(ctx, &invoice.CreateInvoiceRequest{
+		MerchantID:         "test-merchant",
+		Title:              "Polled Invoice",
+		Items:              []*invoice.CreateInvoiceItemRequest{{Name: "Item", Quantity: "1", UnitPrice: unitPrice}},
+		TaxRate:            "0.00",
+		Currency:           shared.CurrencyUSD,
+		CryptoCurrency:     shared.CryptoCurrencyUSDT,
+		ExpirationDuration: 15 * time.Minute,
+	})
+	require.NoError(t, err)
+	path := "/api/v1/public/invoice/" + inv.ID() + "/status"
+
+	poll := func(etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	decode := func(w *httptest.ResponseRecorder) web.PublicInvoiceStatusResponse {
+		require.Equal(t, http.StatusOK, w.Code)
+		var response web.PublicInvoiceStatusResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	w := poll("")
+	response := decode(w)
+	etag := w.Header().Get("ETag")
+	require.Regexp(t, `^W/"[0-9a-f]+"$`, etag)
+	require.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+	require.Equal(t, "created", response.Status)
+	require.Zero(t, response.ProgressPercentage)
+	amountDue, err := inv.GetCryptoAmount()
+	require.NoError(t, err)
+	require.Equal(t, amountDue.Amount().String(), response.RemainingAmount)
+	require.Zero(t, response.Confirmations)
+	require.NotNil(t, response.ExpiresInSeconds)
+	require.Equal(t, int64(900), *response.ExpiresInSeconds)
+	require.NotNil(t, response.NextPollAfter)
+	require.Equal(t, int64(10), *response.NextPollAfter)
+
+	t.Run("Unchanged_Invoice_Is_Not_Modified", func(t *testing.T) {
+		clock.Advance(5 * time.Minute)
+		w := poll(etag)
+		require.Equal(t, http.StatusNotModified, w.Code)
+		require.Empty(t, w.Body.Bytes())
+		require.Equal(t, etag, w.Header().Get("ETag"))
+
+		// The countdown moves on without changing the ETag
+		w = poll("")
+		require.Equal(t, etag, w.Header().Get("ETag"))
+		require.Equal(t, int64(600), *decode(w).ExpiresInSeconds)
+	})
+
+	t.Run("Polls_No_Later_Than_Expiry", func(t *testing.T) {
+		clock.Advance(10*time.Minute - 4*time.Second)
+		response := decode(poll(""))
+		require.Equal(t, int64(4), *response.ExpiresInSeconds)
+		require.Equal(t, int64(4), *response.NextPollAfter)
+	})
+
+	t.Run("Confirmations_Change_The_ETag", func(t *testing.T) {
+		p, err := testutil.NewPaymentBuilder().
+			WithID("payment-poll-1").
+			ForInvoice(inv.ID()).
+			RequiringConfirmations(19).
+			Build()
+		require.NoError(t, err)
+		_, err = services.Payments.CreatePayment(ctx, &payment.CreatePaymentRequest{
+			ID:                    p.ID(),
+			InvoiceID:             p.InvoiceID(),
+			Amount:                p.Amount(),
+			FromAddress:           p.FromAddress(),
+			ToAddress:             p.ToAddress(),
+			TransactionHash:       p.TransactionHash(),
+			RequiredConfirmations: p.RequiredConfirmations(),
+		})
+		require.NoError(t, err)
+		require.NoError(t, services.Payments.UpdateConfirmations(ctx, p.ID(), 7))
+
+		w := poll(etag)
+		response := decode(w)
+		require.NotEqual(t, etag, w.Header().Get("ETag"))
+		require.Equal(t, 7, response.Confirmations)
+		require.NotNil(t, response.ConfirmationETA)
+	})
+
+	t.Run("Final_Invoice_Stops_Polling", func(t *testing.T) {
+		require.NoError(t, services.Invoices.CancelInvoice(ctx, inv.ID(), "customer left"))
+		response := decode(poll(""))
+		require.Equal(t, "cancelled", response.Status)
+		require.Nil(t, response.ExpiresInSeconds)
+		require.Nil(t, response.NextPollAfter)
+	})
+}
@@ -0,0 +1,102 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/tax"
+	"crypto-checkout/internal/presentation/web"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateInvoiceTaxes(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	handler, taxService := web.CreateTestHandlerWithTax()
+
+	router.POST("/api/v1/invoices", web.AuthMiddleware(handler.Logger), handler.CreateInvoice)
+
+	for _, rule := range []*tax.CreateRuleRequest{
+		{Jurisdiction: "DE", Name: "VAT", Type: invoice.TaxTypeVAT, Rate: "0.19", ReverseCharge: true},
+		{Jurisdiction: "DE", Category: "reduced", Name: "VAT", Type: invoice.TaxTypeVAT, Rate: "0.07", ReverseCharge: true},
+	} {
+		rule.MerchantID = "test-merchant"
+		_, err := taxService.CreateRule(context.Background(), rule)
+		require.NoError(t, err)
+	}
+
+	create := func(body web.CreateInvoiceRequest) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/invoices", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	items := []web.InvoiceItemRequest{
+		{Name: "Software License", Quantity: "1", UnitPrice: "100.00"},
+		{Name: "E-book", Quantity: "2", UnitPrice: "10.00", TaxCategory: "reduced"},
+	}
+
+	t.Run("CreateInvoice_JurisdictionTaxLines", func(t *testing.T) {
+		w := create(web.CreateInvoiceRequest{Title: "Taxed Invoice", Items: items, TaxJurisdiction: "de"})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, "20.40", response.TaxAmount)
+		require.Equal(t, "140.40", response.Total)
+
+		require.Len(t, response.Items[0].TaxLines, 1)
+		require.Equal(t, "19.00", response.Items[0].TaxLines[0].Amount)
+		require.Equal(t, "reduced", response.Items[1].TaxCategory)
+		require.Equal(t, "1.40", response.Items[1].TaxLines[0].Amount)
+
+		require.NotNil(t, response.Taxes)
+		require.Equal(t, "DE", response.Taxes.Jurisdiction)
+		require.False(t, response.Taxes.ReverseCharge)
+		require.Len(t, response.Taxes.Lines, 2)
+	})
+
+	t.Run("CreateInvoice_ReverseCharge", func(t *testing.T) {
+		w := create(web.CreateInvoiceRequest{
+			Title:           "B2B Invoice",
+			Items:           items,
+			TaxJurisdiction: "DE",
+			CustomerTaxID:   "DE 123456789",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, "0.00", response.TaxAmount)
+		require.Equal(t, "120.00", response.Total)
+		require.True(t, response.Taxes.ReverseCharge)
+		require.Equal(t, "DE123456789", response.Taxes.CustomerTaxID)
+		require.NotEmpty(t, response.Taxes.Note)
+		require.True(t, response.Items[0].TaxLines[0].ReverseCharge)
+	})
+
+	t.Run("CreateInvoice_InvalidCustomerTaxID", func(t *testing.T) {
+		w := create(web.CreateInvoiceRequest{
+			Title:           "B2B Invoice",
+			Items:           items,
+			TaxJurisdiction: "DE",
+			CustomerTaxID:   "x",
+		})
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
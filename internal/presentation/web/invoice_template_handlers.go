@@ -0,0 +1,306 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/invoicetemplate"
+	"crypto-checkout/internal/domain/merchant"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// InvoiceTemplateHandlers handles merchant invoice template management.
+type InvoiceTemplateHandlers struct {
+	templateService invoicetemplate.Service
+	logger          *zap.Logger
+}
+
+// NewInvoiceTemplateHandlers creates a new invoice template handlers instance.
+func NewInvoiceTemplateHandlers(
+	templateService invoicetemplate.Service,
+	logger *zap.Logger,
+) *InvoiceTemplateHandlers {
+	return &InvoiceTemplateHandlers{
+		templateService: templateService,
+		logger:          logger,
+	}
+}
+
+// CreateTemplate handles POST /invoice-templates
+// @Summary Create an invoice template
+// @Tags Invoice Templates
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body InvoiceTemplateRequest true "Invoice template"
+// @Success 201 {object} InvoiceTemplateResponse "Invoice template created"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 409 {object} ErrorResponse "Template name already taken"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoice-templates [post]
+func (h *InvoiceTemplateHandlers) CreateTemplate(c *gin.Context) {
+	var req InvoiceTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind create invoice template request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	template, err := h.templateService.CreateTemplate(c.Request.Context(), &invoicetemplate.CreateTemplateRequest{
+		MerchantID: merchantID,
+		Definition: ToInvoiceTemplateDefinition(req),
+	})
+	if err != nil {
+		respondInvoiceTemplateError(c, h.logger, err, "Failed to create invoice template")
+		return
+	}
+
+	setAuditChange(c, nil, invoiceTemplateAuditState(template))
+	c.JSON(http.StatusCreated, ToInvoiceTemplateResponse(template))
+}
+
+// ListTemplates handles GET /invoice-templates
+// @Summary List invoice templates
+// @Tags Invoice Templates
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} ListInvoiceTemplatesResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoice-templates [get]
+func (h *InvoiceTemplateHandlers) ListTemplates(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	templates, err := h.templateService.ListTemplates(c.Request.Context(), merchantID)
+	if err != nil {
+		respondInvoiceTemplateError(c, h.logger, err, "Failed to list invoice templates")
+		return
+	}
+
+	response := ListInvoiceTemplatesResponse{Templates: make([]InvoiceTemplateResponse, len(templates))}
+	for i, template := range templates {
+		response.Templates[i] = ToInvoiceTemplateResponse(template)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetTemplate handles GET /invoice-templates/:id
+// @Summary Get an invoice template
+// @Tags Invoice Templates
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Invoice template ID"
+// @Success 200 {object} InvoiceTemplateResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Invoice template not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoice-templates/{id} [get]
+func (h *InvoiceTemplateHandlers) GetTemplate(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	template, err := h.templateService.GetTemplate(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondInvoiceTemplateError(c, h.logger, err, "Failed to get invoice template")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToInvoiceTemplateResponse(template))
+}
+
+// UpdateTemplate handles PUT /invoice-templates/:id
+// @Summary Replace an invoice template
+// @Tags Invoice Templates
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Invoice template ID"
+// @Param request body InvoiceTemplateRequest true "Invoice template"
+// @Success 200 {object} InvoiceTemplateResponse
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Invoice template not found"
+// @Failure 409 {object} ErrorResponse "Template name already taken"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoice-templates/{id} [put]
+func (h *InvoiceTemplateHandlers) UpdateTemplate(c *gin.Context) {
+	var req InvoiceTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind update invoice template request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	before, err := h.templateService.GetTemplate(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondInvoiceTemplateError(c, h.logger, err, "Failed to update invoice template")
+		return
+	}
+	beforeState := invoiceTemplateAuditState(before)
+
+	template, err := h.templateService.UpdateTemplate(c.Request.Context(), &invoicetemplate.UpdateTemplateRequest{
+		MerchantID: merchantID,
+		TemplateID: c.Param("id"),
+		Definition: ToInvoiceTemplateDefinition(req),
+	})
+	if err != nil {
+		respondInvoiceTemplateError(c, h.logger, err, "Failed to update invoice template")
+		return
+	}
+
+	setAuditChange(c, beforeState, invoiceTemplateAuditState(template))
+	c.JSON(http.StatusOK, ToInvoiceTemplateResponse(template))
+}
+
+// DeleteTemplate handles DELETE /invoice-templates/:id
+// @Summary Delete an invoice template
+// @Tags Invoice Templates
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Invoice template ID"
+// @Success 204 "Invoice template deleted"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Invoice template not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoice-templates/{id} [delete]
+func (h *InvoiceTemplateHandlers) DeleteTemplate(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	template, err := h.templateService.GetTemplate(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondInvoiceTemplateError(c, h.logger, err, "Failed to delete invoice template")
+		return
+	}
+
+	if err := h.templateService.DeleteTemplate(c.Request.Context(), merchantID, template.ID()); err != nil {
+		respondInvoiceTemplateError(c, h.logger, err, "Failed to delete invoice template")
+		return
+	}
+
+	setAuditChange(c, invoiceTemplateAuditState(template), nil)
+	c.Status(http.StatusNoContent)
+}
+
+// CreateInvoice handles POST /invoice-templates/:id/invoices
+// @Summary Create an invoice from a template
+// @Description Create a fresh invoice from an invoice template. Omitted overrides keep the template's value;
+// @Description items replace the template's items and metadata is merged over the template's metadata.
+// @Tags Invoice Templates
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Invoice template ID"
+// @Param request body CreateTemplateInvoiceRequest false "Overrides"
+// @Success 201 {object} CreateInvoiceResponse "Invoice created"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Invoice template not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invoice-templates/{id}/invoices [post]
+func (h *InvoiceTemplateHandlers) CreateInvoice(c *gin.Context) {
+	var req CreateTemplateInvoiceRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Error("Failed to bind create template invoice request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+			return
+		}
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	inv, err := h.templateService.CreateInvoice(c.Request.Context(), &invoicetemplate.CreateInvoiceRequest{
+		MerchantID: merchantID,
+		TemplateID: c.Param("id"),
+		Overrides:  ToInvoiceTemplateOverrides(req),
+	})
+	if err != nil {
+		respondInvoiceTemplateError(c, h.logger, err, "Failed to create invoice from template")
+		return
+	}
+
+	setAuditChange(c, nil, map[string]interface{}{"invoice_id": inv.ID(), "template_id": c.Param("id")})
+
+	response := ToCreateInvoiceResponse(inv)
+	response.InvoiceURL = "/api/v1/invoices/" + inv.ID()
+	c.JSON(http.StatusCreated, response)
+}
+
+// RegisterInvoiceTemplateRoutes registers invoice template management routes.
+func (h *InvoiceTemplateHandlers) RegisterInvoiceTemplateRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	create := rbac.RequireRolePermission(merchant.PermissionInvoicesCreate)
+	read := rbac.RequireRolePermission(merchant.PermissionInvoicesRead)
+
+	templates := protected.Group("/invoice-templates")
+	templates.POST("", create, rbac.AuditAction("invoice_template.create"), h.CreateTemplate)
+	templates.GET("", read, h.ListTemplates)
+	templates.GET("/:id", read, h.GetTemplate)
+	templates.PUT("/:id", create, rbac.AuditAction("invoice_template.update"), h.UpdateTemplate)
+	templates.DELETE("/:id", create, rbac.AuditAction("invoice_template.delete"), h.DeleteTemplate)
+	templates.POST("/:id/invoices", create, rbac.AuditAction("invoice_template.instantiate"), h.CreateInvoice)
+}
+
+// invoiceTemplateAuditState returns the audited fields of an invoice template.
+func invoiceTemplateAuditState(template *invoicetemplate.Template) map[string]interface{} {
+	return map[string]interface{}{
+		"name":            template.Name(),
+		"title":           template.Title(),
+		"items":           len(template.Items()),
+		"currency":        template.Currency().String(),
+		"crypto_currency": template.CryptoCurrency().String(),
+		"tax_rate":        template.TaxRate(),
+	}
+}
+
+// respondInvoiceTemplateError maps invoice template errors to HTTP responses.
+func respondInvoiceTemplateError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, invoicetemplate.ErrTemplateNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", invoicetemplate.ErrCodeTemplateNotFound, "Invoice template not found"))
+	case errors.Is(err, invoicetemplate.ErrNameTaken):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", invoicetemplate.ErrCodeNameTaken, err.Error()))
+	case errors.Is(err, invoice.ErrUnsupportedCryptocurrency):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", invoice.ErrCodeUnsupportedCryptocurrency, err.Error()))
+	case errors.Is(err, invoice.ErrInvalidCryptocurrency):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", invoice.ErrCodeInvalidCryptocurrency, err.Error()))
+	case errors.Is(err, invoicetemplate.ErrInvalidRequest), errors.Is(err, invoice.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
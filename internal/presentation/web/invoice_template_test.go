@@ -0,0 +1,282 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/invoicetemplate"
+	"crypto-checkout/internal/domain/tax"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDuplicateInvoice(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(web.ErrorHandler(&config.Config{}, zap.NewNop()))
+
+	handler, services := web.CreateTestHandlerWithServices()
+
+	router.POST("/api/v1/invoices", web.AuthMiddleware(handler.Logger), handler.CreateInvoice)
+	router.POST("/api/v1/invoices/:id/duplicate", web.AuthMiddleware(handler.Logger), handler.DuplicateInvoice)
+
+	_, err := services.Tax.CreateRule(context.Background(), &tax.CreateRuleRequest{
+		MerchantID: "test-merchant", Jurisdiction: "DE", Name: "VAT", Type: invoice.TaxTypeVAT, Rate: "0.19",
+	})
+	require.NoError(t, err)
+
+	request := func(path string, body interface{}) *httptest.ResponseRecorder {
+		var requestBody []byte
+		if body != nil {
+			requestBody, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	duplicate := func(t *testing.T, original web.CreateInvoiceResponse) web.CreateInvoiceResponse {
+		w := request("/api/v1/invoices/"+original.ID+"/duplicate", nil)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotEqual(t, original.ID, response.ID)
+		require.Equal(t, original.Subtotal, response.Subtotal)
+		require.Equal(t, original.DiscountAmount, response.DiscountAmount)
+		require.Equal(t, original.TaxAmount, response.TaxAmount)
+		require.Equal(t, original.Total, response.Total)
+		require.Equal(t, "/api/v1/invoices/"+response.ID, response.InvoiceURL)
+
+		inv, err := services.Invoices.GetInvoice(context.Background(), response.ID)
+		require.NoError(t, err)
+		require.Equal(t, original.ID, inv.Metadata()[invoice.DuplicateOfMetadataKey])
+		return response
+	}
+
+	t.Run("DuplicateInvoice_FlatTax", func(t *testing.T) {
+		expiresIn := 3600
+		w := request("/api/v1/invoices", web.CreateInvoiceRequest{
+			Title:     "Monthly retainer",
+			Items:     []web.InvoiceItemRequest{{Name: "Consulting", Quantity: "3", UnitPrice: "100.00"}},
+			Discount:  &web.DiscountRequest{Type: "percentage", Value: "10"},
+			TaxRate:   "0.10",
+			ExpiresIn: &expiresIn,
+			Metadata:  map[string]interface{}{"order": "1001"},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var original web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &original))
+
+		response := duplicate(t, original)
+		require.Equal(t, "27.00", response.TaxAmount)
+		require.Len(t, response.Items, 1)
+		require.Equal(t, "3", response.Items[0].Quantity)
+
+		inv, err := services.Invoices.GetInvoice(context.Background(), response.ID)
+		require.NoError(t, err)
+		require.Equal(t, "1001", inv.Metadata()["order"])
+		require.InDelta(t, 3600, inv.Expiration().ExpiresAt().Sub(inv.CreatedAt()).Seconds(), 5)
+	})
+
+	t.Run("DuplicateInvoice_JurisdictionTax", func(t *testing.T) {
+		w := request("/api/v1/invoices", web.CreateInvoiceRequest{
+			Title:           "Taxed Invoice",
+			Items:           []web.InvoiceItemRequest{{Name: "Software License", Quantity: "1", UnitPrice: "100.00"}},
+			TaxJurisdiction: "DE",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var original web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &original))
+
+		response := duplicate(t, original)
+		require.NotNil(t, response.Taxes)
+		require.Equal(t, "DE", response.Taxes.Jurisdiction)
+		require.Len(t, response.Items[0].TaxLines, 1)
+		require.Equal(t, "19.00", response.Items[0].TaxLines[0].Amount)
+	})
+
+	t.Run("DuplicateInvoice_OfDuplicate", func(t *testing.T) {
+		w := request("/api/v1/invoices", web.CreateInvoiceRequest{
+			Title:   "Monthly retainer",
+			Items:   []web.InvoiceItemRequest{{Name: "Consulting", Quantity: "1", UnitPrice: "100.00"}},
+			TaxRate: "0.10",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var original web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &original))
+
+		// The copy records the invoice it was created from, although merchants cannot set duplicate_of
+		duplicate(t, duplicate(t, original))
+	})
+
+	t.Run("CreateInvoice_ReservedMetadataKey", func(t *testing.T) {
+		w := request("/api/v1/invoices", web.CreateInvoiceRequest{
+			Title:    "Monthly retainer",
+			Items:    []web.InvoiceItemRequest{{Name: "Consulting", Quantity: "1", UnitPrice: "100.00"}},
+			TaxRate:  "0.10",
+			Metadata: map[string]interface{}{invoice.DuplicateOfMetadataKey: "inv_other"},
+		})
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("DuplicateInvoice_NotFound", func(t *testing.T) {
+		w := request("/api/v1/invoices/missing/duplicate", nil)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
+
+func TestInvoiceTemplates(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	handler, services := web.CreateTestHandlerWithServices()
+	protected := router.Group("/api/v1", func(c *gin.Context) {
+		c.Set("merchant_id", "test-merchant")
+		c.Next()
+	})
+	web.NewInvoiceTemplateHandlers(services.InvoiceTemplates, handler.Logger).
+		RegisterInvoiceTemplateRoutes(protected, nil)
+
+	request := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var requestBody []byte
+		if body != nil {
+			var err error
+			requestBody, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(requestBody))
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	retainer := web.InvoiceTemplateRequest{
+		Name:  "Retainer",
+		Title: "Consulting retainer",
+		Items: []web.InvoiceTemplateItemRequest{
+			{Name: "Consulting", Quantity: "10", UnitPrice: "150.00"},
+		},
+		TaxRate:   "0.10",
+		ExpiresIn: 7200,
+		Metadata:  map[string]interface{}{"project": "alpha"},
+	}
+
+	w := request(http.MethodPost, "/api/v1/invoice-templates", retainer)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var template web.InvoiceTemplateResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &template))
+	require.Equal(t, "USD", template.Currency)
+	require.Equal(t, "USDT", template.CryptoCurrency)
+	require.Equal(t, "standard", template.Items[0].TaxCategory)
+
+	t.Run("CreateTemplate_NameTaken", func(t *testing.T) {
+		w := request(http.MethodPost, "/api/v1/invoice-templates", retainer)
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	})
+
+	t.Run("CreateTemplate_Invalid", func(t *testing.T) {
+		invalid := retainer
+		invalid.Name = "Invalid"
+		invalid.Items = []web.InvoiceTemplateItemRequest{{Name: "Consulting", Quantity: "-1", UnitPrice: "150.00"}}
+		w := request(http.MethodPost, "/api/v1/invoice-templates", invalid)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("ListAndGetTemplates", func(t *testing.T) {
+		w := request(http.MethodGet, "/api/v1/invoice-templates", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list web.ListInvoiceTemplatesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Templates, 1)
+
+		w = request(http.MethodGet, "/api/v1/invoice-templates/"+template.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = request(http.MethodGet, "/api/v1/invoice-templates/missing", nil)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("CreateInvoice_TemplateValues", func(t *testing.T) {
+		w := request(http.MethodPost, "/api/v1/invoice-templates/"+template.ID+"/invoices", nil)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, "1500.00", response.Subtotal)
+		require.Equal(t, "150.00", response.TaxAmount)
+		require.Equal(t, "1650.00", response.Total)
+
+		inv, err := services.Invoices.GetInvoice(context.Background(), response.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Consulting retainer", inv.Title())
+		require.Equal(t, template.ID, inv.Metadata()[invoicetemplate.TemplateIDMetadataKey])
+		require.Equal(t, "alpha", inv.Metadata()["project"])
+	})
+
+	t.Run("CreateInvoice_Overrides", func(t *testing.T) {
+		customer := "customer-1"
+		noTax := "0"
+		w := request(http.MethodPost, "/api/v1/invoice-templates/"+template.ID+"/invoices", web.CreateTemplateInvoiceRequest{
+			Title:      "March retainer",
+			CustomerID: &customer,
+			Items:      []web.InvoiceTemplateItemRequest{{Name: "Consulting", Quantity: "12", UnitPrice: "150.00"}},
+			TaxRate:    &noTax,
+			Metadata:   map[string]interface{}{"month": "march"},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, "1800.00", response.Total)
+
+		inv, err := services.Invoices.GetInvoice(context.Background(), response.ID)
+		require.NoError(t, err)
+		require.Equal(t, "March retainer", inv.Title())
+		require.Equal(t, "customer-1", *inv.CustomerID())
+		require.Equal(t, "alpha", inv.Metadata()["project"])
+		require.Equal(t, "march", inv.Metadata()["month"])
+	})
+
+	t.Run("CreateInvoice_UnsupportedCryptocurrency", func(t *testing.T) {
+		w := request(http.MethodPost, "/api/v1/invoice-templates/"+template.ID+"/invoices",
+			web.CreateTemplateInvoiceRequest{CryptoCurrency: "XMR"})
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("UpdateAndDeleteTemplate", func(t *testing.T) {
+		updated := retainer
+		updated.Title = "Quarterly retainer"
+		w := request(http.MethodPut, "/api/v1/invoice-templates/"+template.ID, updated)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response web.InvoiceTemplateResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, "Quarterly retainer", response.Title)
+
+		w = request(http.MethodDelete, "/api/v1/invoice-templates/"+template.ID, nil)
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		w = request(http.MethodPost, "/api/v1/invoice-templates/"+template.ID+"/invoices", nil)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
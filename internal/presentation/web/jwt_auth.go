@@ -139,7 +139,7 @@ func (m *JWTAuthMiddleware) RequireJWT() gin.HandlerFunc {
 		// Store information in context for use by handlers
 		if resp.APIKey != nil {
 			c.Set("api_key_id", resp.APIKey.ID())
-			c.Set("merchant_id", resp.APIKey.MerchantID())
+			setMerchantScope(c, resp.APIKey.MerchantID())
 			c.Set("api_key_permissions", resp.APIKey.Permissions())
 			c.Set("jwt_scope", scopeStrings)
 			c.Set("jwt_expires_at", claims["exp"])
@@ -86,6 +86,19 @@ func TestListInvoicesEndpoint(t *testing.T) {
 		}
 	})
 
+	t.Run("ListInvoices_InvalidCursor", func(t *testing.T) {
+		// Given
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/invoices?cursor=not-a-cursor", http.NoBody)
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Then
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
 	t.Run("ListInvoices_Unauthorized", func(t *testing.T) {
 		// Given
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/invoices", http.NoBody)
@@ -0,0 +1,189 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/infrastructure/logging"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// LogLevelHandlers handles the log levels of the application and its modules.
+type LogLevelHandlers struct {
+	levels *logging.Levels
+	logger *zap.Logger
+}
+
+// NewLogLevelHandlers creates a new log level handlers instance.
+func NewLogLevelHandlers(levels *logging.Levels, logger *zap.Logger) *LogLevelHandlers {
+	return &LogLevelHandlers{
+		levels: levels,
+		logger: logger,
+	}
+}
+
+// GetLogLevels handles GET /admin/log-levels
+// @Summary Get the log levels
+// @Description The default log level and the levels of the modules set apart from it. A module is named after
+// @Description its package, such as detection, or a part of one, such as detection.watcher; it follows its own
+// @Description level, else that of the module it belongs to, else the default one. Levels are those of the
+// @Description instance answering.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} LogLevelsResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid admin key"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Router /api/v1/admin/log-levels [get]
+func (h *LogLevelHandlers) GetLogLevels(c *gin.Context) {
+	c.JSON(http.StatusOK, ToLogLevelsResponse(h.levels))
+}
+
+// SetLogLevel handles PUT /admin/log-levels
+// @Summary Set the default log level
+// @Description Change the level of the modules without one of their own until the instance restarts
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body SetLogLevelRequest true "Level"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} LogLevelsResponse
+// @Failure 400 {object} ErrorResponse "Invalid level"
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid admin key"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Router /api/v1/admin/log-levels [put]
+func (h *LogLevelHandlers) SetLogLevel(c *gin.Context) {
+	var req SetLogLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+	level, err := logging.ParseLevel(req.Level)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	before := h.levels.Level()
+	h.levels.SetLevel(level)
+	h.logger.Info("Log level changed", zap.Stringer("from", before), zap.Stringer("to", level))
+	setAuditChange(c, map[string]interface{}{"level": before.String()}, map[string]interface{}{"level": level.String()})
+	c.JSON(http.StatusOK, ToLogLevelsResponse(h.levels))
+}
+
+// SetModuleLogLevel handles PUT /admin/log-levels/:module
+// @Summary Set the log level of a module
+// @Description Change the level of a module, such as detection.watcher, and of its parts without one of their
+// @Description own until the instance restarts
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param module path string true "Module"
+// @Param request body SetLogLevelRequest true "Level"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} LogLevelsResponse
+// @Failure 400 {object} ErrorResponse "Invalid level or module"
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid admin key"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Router /api/v1/admin/log-levels/{module} [put]
+func (h *LogLevelHandlers) SetModuleLogLevel(c *gin.Context) {
+	var req SetLogLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+	level, err := logging.ParseLevel(req.Level)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	module := c.Param("module")
+	before := h.levels.ModuleLevel(module)
+	if err := h.levels.SetModuleLevel(module, level); err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.logger.Info("Log level changed", zap.String("module", module),
+		zap.Stringer("from", before), zap.Stringer("to", level))
+	setAuditChange(c,
+		map[string]interface{}{"module": module, "level": before.String()},
+		map[string]interface{}{"module": module, "level": level.String()})
+	c.JSON(http.StatusOK, ToLogLevelsResponse(h.levels))
+}
+
+// ResetModuleLogLevel handles DELETE /admin/log-levels/:module
+// @Summary Reset the log level of a module
+// @Description Put a module back to its configured level, or to that of the module it belongs to when none is
+// @Description configured
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param module path string true "Module"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} LogLevelsResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid admin key"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Router /api/v1/admin/log-levels/{module} [delete]
+func (h *LogLevelHandlers) ResetModuleLogLevel(c *gin.Context) {
+	module := c.Param("module")
+	before := h.levels.ModuleLevel(module)
+	h.levels.ResetModuleLevel(module)
+	after := h.levels.ModuleLevel(module)
+	h.logger.Info("Log level reset", zap.String("module", module),
+		zap.Stringer("from", before), zap.Stringer("to", after))
+	setAuditChange(c,
+		map[string]interface{}{"module": module, "level": before.String()},
+		map[string]interface{}{"module": module, "level": after.String()})
+	c.JSON(http.StatusOK, ToLogLevelsResponse(h.levels))
+}
+
+// ResetLogLevels handles DELETE /admin/log-levels
+// @Summary Reset the log levels
+// @Description Put the default level and every module back to the configured levels
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} LogLevelsResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid admin key"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Router /api/v1/admin/log-levels [delete]
+func (h *LogLevelHandlers) ResetLogLevels(c *gin.Context) {
+	h.levels.Reset()
+	h.logger.Info("Log levels reset", zap.Stringer("level", h.levels.Level()))
+	setAuditChange(c, nil, map[string]interface{}{"level": h.levels.Level().String()})
+	c.JSON(http.StatusOK, ToLogLevelsResponse(h.levels))
+}
+
+// respondError maps log level errors to HTTP responses.
+func (h *LogLevelHandlers) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, logging.ErrInvalidLevel):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", logging.ErrCodeInvalidLevel, err.Error()))
+	case errors.Is(err, logging.ErrInvalidModule):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", logging.ErrCodeInvalidModule, err.Error()))
+	default:
+		h.logger.Error("Failed to change log level", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change log level"})
+	}
+}
+
+// RegisterLogLevelRoutes registers the log level routes under /admin/log-levels.
+func (h *LogLevelHandlers) RegisterLogLevelRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionAdminOperations)
+
+	levels := protected.Group("/admin/log-levels", require)
+	levels.GET("", h.GetLogLevels)
+	levels.PUT("", rbac.AuditAction("admin.set_log_level"), h.SetLogLevel)
+	levels.DELETE("", rbac.AuditAction("admin.reset_log_levels"), h.ResetLogLevels)
+	levels.PUT("/:module", rbac.AuditAction("admin.set_log_level"), h.SetModuleLogLevel)
+	levels.DELETE("/:module", rbac.AuditAction("admin.reset_log_level"), h.ResetModuleLogLevel)
+}
@@ -0,0 +1,65 @@
+package web_test
+
+import (
+	"bytes"
+	"crypto-checkout/internal/infrastructure/logging"
+	"crypto-checkout/internal/presentation/web"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogLevelHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	levels := logging.NewLevels(zapcore.InfoLevel, map[string]zapcore.Level{"webhook": zapcore.WarnLevel})
+	router := gin.New()
+	web.NewLogLevelHandlers(levels, zap.NewNop()).RegisterLogLevelRoutes(router.Group("/api/v1"), nil)
+
+	do := func(method, path, body string) (*httptest.ResponseRecorder, web.LogLevelsResponse) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		var resp web.LogLevelsResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		}
+		return w, resp
+	}
+
+	w, resp := do(http.MethodGet, "/api/v1/admin/log-levels", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, web.LogLevelsResponse{Level: "info", Modules: map[string]string{"webhook": "warn"}}, resp)
+
+	w, resp = do(http.MethodPut, "/api/v1/admin/log-levels/detection.watcher", `{"level":"debug"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "debug", resp.Modules["detection.watcher"])
+	assert.True(t, levels.Enabled("detection.watcher", zapcore.DebugLevel))
+
+	w, resp = do(http.MethodPut, "/api/v1/admin/log-levels", `{"level":"error"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "error", resp.Level)
+	assert.False(t, levels.Enabled("invoice", zapcore.WarnLevel))
+
+	w, _ = do(http.MethodPut, "/api/v1/admin/log-levels/webhook", `{"level":"loud"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), logging.ErrCodeInvalidLevel)
+	w, _ = do(http.MethodPut, "/api/v1/admin/log-levels/Webhook", `{"level":"debug"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), logging.ErrCodeInvalidModule)
+
+	w, resp = do(http.MethodDelete, "/api/v1/admin/log-levels/detection.watcher", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, resp.Modules, "detection.watcher")
+
+	w, resp = do(http.MethodDelete, "/api/v1/admin/log-levels", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, web.LogLevelsResponse{Level: "info", Modules: map[string]string{"webhook": "warn"}}, resp)
+}
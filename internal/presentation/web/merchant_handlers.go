@@ -48,8 +48,7 @@ func (h *MerchantHandlers) CreateMerchant(c *gin.Context) {
 	ctx := c.Request.Context()
 	resp, err := h.merchantService.CreateMerchant(ctx, &req)
 	if err != nil {
-		h.logger.Error("Failed to create merchant", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create merchant"})
+		respondDomainError(c, h.logger, err, "Failed to create merchant")
 		return
 	}
 
@@ -75,8 +74,7 @@ func (h *MerchantHandlers) GetMerchant(c *gin.Context) {
 	ctx := c.Request.Context()
 	resp, err := h.merchantService.GetMerchant(ctx, req)
 	if err != nil {
-		h.logger.Error("Failed to get merchant", zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{"error": "Merchant not found"})
+		respondDomainError(c, h.logger, err, "Failed to get merchant")
 		return
 	}
 
@@ -107,8 +105,7 @@ func (h *MerchantHandlers) UpdateMerchant(c *gin.Context) {
 	ctx := c.Request.Context()
 	resp, err := h.merchantService.UpdateMerchant(ctx, &req)
 	if err != nil {
-		h.logger.Error("Failed to update merchant", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update merchant"})
+		respondDomainError(c, h.logger, err, "Failed to update merchant")
 		return
 	}
 
@@ -139,8 +136,7 @@ func (h *MerchantHandlers) ChangeMerchantStatus(c *gin.Context) {
 	ctx := c.Request.Context()
 	resp, err := h.merchantService.ChangeMerchantStatus(ctx, &req)
 	if err != nil {
-		h.logger.Error("Failed to change merchant status", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change merchant status"})
+		respondDomainError(c, h.logger, err, "Failed to change merchant status")
 		return
 	}
 
@@ -181,8 +177,7 @@ func (h *MerchantHandlers) ListMerchants(c *gin.Context) {
 	ctx := c.Request.Context()
 	resp, err := h.merchantService.ListMerchants(ctx, req)
 	if err != nil {
-		h.logger.Error("Failed to list merchants", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list merchants"})
+		respondDomainError(c, h.logger, err, "Failed to list merchants")
 		return
 	}
 
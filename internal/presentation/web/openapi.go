@@ -0,0 +1,75 @@
+package web
+
+import (
+	"crypto-checkout/internal/presentation/web/docs"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.uber.org/zap"
+)
+
+const (
+	// OpenAPIPath is where the OpenAPI 3 document of the REST API is served.
+	OpenAPIPath = "/api/v1/openapi.json"
+	// DocsPath is where Swagger UI for the OpenAPI document is served.
+	DocsPath = "/api/v1/docs"
+)
+
+var openAPI struct {
+	once sync.Once
+	doc  *openapi3.T
+	err  error
+}
+
+// OpenAPISpec returns the OpenAPI 3 document of the REST API. It is converted from the Swagger 2.0
+// document that swag generates from the handler annotations (make docs), so the annotations are the
+// single source for the served spec, contract tests and generated clients.
+func OpenAPISpec() (*openapi3.T, error) {
+	openAPI.once.Do(func() {
+		var doc2 openapi2.T
+		if err := json.Unmarshal([]byte(docs.SwaggerInfo.ReadDoc()), &doc2); err != nil {
+			openAPI.err = fmt.Errorf("failed to parse swagger document: %w", err)
+			return
+		}
+		doc3, err := openapi2conv.ToV3(&doc2)
+		if err != nil {
+			openAPI.err = fmt.Errorf("failed to convert swagger document to OpenAPI 3: %w", err)
+			return
+		}
+		openAPI.doc = doc3
+	})
+	return openAPI.doc, openAPI.err
+}
+
+// GetOpenAPISpec handles GET /api/v1/openapi.json requests.
+func (h *Handler) GetOpenAPISpec(c *gin.Context) {
+	doc, err := OpenAPISpec()
+	if err != nil {
+		h.Logger.Error("Failed to build OpenAPI document", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build OpenAPI document"})
+		return
+	}
+	c.JSON(http.StatusOK, doc)
+}
+
+// registerDocsRoutes registers the OpenAPI document, Swagger UI and the legacy /swagger redirect.
+func (h *Handler) registerDocsRoutes(router *gin.Engine) {
+	router.GET(OpenAPIPath, h.GetOpenAPISpec)
+
+	ui := ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL(OpenAPIPath))
+	router.GET(DocsPath, func(c *gin.Context) {
+		c.Redirect(http.StatusMovedPermanently, DocsPath+"/index.html")
+	})
+	router.GET(DocsPath+"/*any", ui)
+	router.GET("/swagger/*any", func(c *gin.Context) {
+		c.Redirect(http.StatusMovedPermanently, DocsPath+"/index.html")
+	})
+}
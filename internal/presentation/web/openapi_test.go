@@ -0,0 +1,174 @@
+package web
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/customer"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// undocumentedRoutes are served outside the REST API: HTML pages, scripts, websockets, redirects and the docs
+// themselves. CORS preflights are not documented either.
+var undocumentedRoutes = map[string]bool{
+	"GET /invoice/{id}":               true,
+	"GET /invoice/{id}/ws":            true,
+	"GET /invoice/{id}/return":        true,
+	"GET /invoice/{id}/return/cancel": true,
+	"GET /l/{slug}":                   true,
+	"GET " + WidgetScriptPath:         true,
+	"GET " + OpenAPIPath:              true,
+	"GET " + DocsPath:                 true,
+	"GET " + DocsPath + "/":           true,
+	"GET /swagger/":                   true,
+}
+
+var ginParam = regexp.MustCompile(`[:*]([A-Za-z_]+)`)
+
+// newDocumentedRouter registers every route the server serves, mirroring RegisterRoutes in di.go.
+func newDocumentedRouter() *gin.Engine {
+	router := gin.New()
+	CreateTestHandler().RegisterRoutes(router)
+	(&HealthHandlers{}).RegisterHealthRoutes(router)
+	(&StatusHandlers{}).RegisterStatusRoutes(router)
+
+	v1 := router.Group("/api/v1")
+	protected := v1.Group("")
+	(&TeamHandlers{}).RegisterTeamRoutes(v1, protected, nil)
+	(&SessionHandlers{sessionAuth: &SessionAuthMiddleware{}}).RegisterSessionRoutes(v1)
+	(&AuditHandlers{}).RegisterAuditRoutes(protected, nil)
+	(&ComplianceHandlers{}).RegisterComplianceRoutes(protected, nil)
+	(&BlocklistHandlers{}).RegisterBlocklistRoutes(protected, nil)
+	(&ReviewHandlers{}).RegisterReviewRoutes(protected, nil)
+	(&DepositHandlers{}).RegisterDepositRoutes(protected, nil)
+	(&ArchiveHandlers{}).RegisterArchiveRoutes(protected, nil)
+	(&CouponHandlers{}).RegisterCouponRoutes(protected, nil)
+	(&TaxHandlers{}).RegisterTaxRoutes(protected, nil)
+	(&PaymentHandlers{}).RegisterPaymentRoutes(protected, nil)
+	(&PaymentLinkHandlers{}).RegisterPaymentLinkRoutes(protected, nil)
+	(&InvoiceTemplateHandlers{}).RegisterInvoiceTemplateRoutes(protected, nil)
+	(&AutomationRuleHandlers{}).RegisterAutomationRuleRoutes(protected, nil)
+	(&PaymentProcessorHandlers{}).RegisterPaymentProcessorRoutes(protected, nil)
+	(&WebhookDeliveryHandlers{}).RegisterWebhookDeliveryRoutes(protected, nil)
+	(&SettlementHandlers{}).RegisterSettlementRoutes(protected, nil)
+	(&PayoutHandlers{}).RegisterPayoutRoutes(protected, nil)
+	(&TreasuryHandlers{}).RegisterTreasuryRoutes(protected, nil)
+	(&FeeHandlers{}).RegisterFeeRoutes(protected, nil)
+	(&PaymentStatisticsHandlers{}).RegisterPaymentStatisticsRoutes(protected, nil)
+	(&ApprovalHandlers{}).RegisterApprovalRoutes(protected, nil)
+	(&DeadLetterHandlers{}).RegisterDeadLetterRoutes(protected, nil)
+	(&SagaHandlers{}).RegisterSagaRoutes(protected, nil)
+	(&GraphQLHandlers{enabled: true}).RegisterGraphQLRoutes(protected, nil)
+	(&ConfigHandlers{}).RegisterConfigRoutes(protected, nil)
+	(&AdminHandlers{}).RegisterAdminRoutes(protected, nil)
+	(&BackfillHandlers{}).RegisterBackfillRoutes(protected, nil)
+	(&ReconciliationHandlers{}).RegisterReconciliationRoutes(protected, nil)
+	(&StatementHandlers{}).RegisterStatementRoutes(protected, nil)
+	(&EvidenceHandlers{}).RegisterEvidenceRoutes(v1, protected, nil)
+	(&ExperimentHandlers{}).RegisterExperimentRoutes(protected, nil)
+	(&CheckoutAnalyticsHandlers{}).RegisterCheckoutAnalyticsRoutes(protected, nil)
+	(&GeoBlockHandlers{}).RegisterGeoBlockRoutes(protected, nil)
+	(&SLOHandlers{}).RegisterSLORoutes(protected, nil)
+	(&LogLevelHandlers{}).RegisterLogLevelRoutes(protected, nil)
+	(&CustomerHandlers{policy: customer.Policy{Enabled: true}}).RegisterCustomerRoutes(v1)
+	(&BlockchainNotificationHandlers{}).RegisterBlockchainNotificationRoutes(v1)
+	(&PaymentProcessorHandlers{}).RegisterProcessorWebhookRoutes(v1)
+	return router
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	doc, err := OpenAPISpec()
+	require.NoError(t, err)
+
+	t.Run("Spec_IsValid", func(t *testing.T) {
+		require.Equal(t, "3.0.3", doc.OpenAPI)
+		require.NoError(t, doc.Validate(context.Background()))
+	})
+
+	t.Run("EveryRoute_Documented", func(t *testing.T) {
+		for _, route := range newDocumentedRouter().Routes() {
+			if route.Method == http.MethodOptions {
+				continue
+			}
+			path := ginParam.ReplaceAllString(route.Path, "{$1}")
+			if strings.HasSuffix(path, "{any}") {
+				path = strings.TrimSuffix(path, "{any}")
+			}
+			key := route.Method + " " + path
+			if undocumentedRoutes[key] {
+				continue
+			}
+
+			item := doc.Paths.Find(path)
+			require.NotNil(t, item, "route %s is missing from the OpenAPI document", key)
+			require.NotNil(t, item.GetOperation(route.Method), "route %s is missing from the OpenAPI document", key)
+		}
+	})
+
+	t.Run("EveryOperation_Served", func(t *testing.T) {
+		served := make(map[string]bool)
+		for _, route := range newDocumentedRouter().Routes() {
+			served[route.Method+" "+ginParam.ReplaceAllString(route.Path, "{$1}")] = true
+		}
+		for path, item := range doc.Paths.Map() {
+			for method := range item.Operations() {
+				require.True(t, served[method+" "+path], "documented operation %s %s is not served", method, path)
+			}
+		}
+	})
+}
+
+func TestOpenAPIRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	CreateTestHandler().RegisterRoutes(router)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	t.Run("OpenAPIDocument_Served", func(t *testing.T) {
+		w := get(OpenAPIPath)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		doc, err := openapi3.NewLoader().LoadFromData(w.Body.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, "Crypto Checkout API", doc.Info.Title)
+		require.NotNil(t, doc.Paths.Find("/api/v1/invoices"))
+
+		var raw map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &raw))
+		require.Contains(t, raw["components"], "securitySchemes")
+	})
+
+	t.Run("SwaggerUI_Served", func(t *testing.T) {
+		w := get(DocsPath + "/index.html")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), "swagger-ui")
+
+		w = get(DocsPath + "/swagger-initializer.js")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), OpenAPIPath)
+	})
+
+	t.Run("DocsRoot_RedirectsToUI", func(t *testing.T) {
+		w := get(DocsPath)
+		require.Equal(t, http.StatusMovedPermanently, w.Code)
+		require.Equal(t, DocsPath+"/index.html", w.Header().Get("Location"))
+
+		w = get("/swagger/index.html")
+		require.Equal(t, http.StatusMovedPermanently, w.Code)
+		require.Equal(t, DocsPath+"/index.html", w.Header().Get("Location"))
+	})
+}
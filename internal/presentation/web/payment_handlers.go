@@ -0,0 +1,113 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/compliance"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PaymentHandlers handles the payment detail merchants use to reconcile payments with the chain.
+type PaymentHandlers struct {
+	paymentService payment.PaymentService
+	invoiceService invoice.InvoiceService
+	reviewService  compliance.ReviewService
+	explorers      *shared.ExplorerResolver
+	logger         *zap.Logger
+}
+
+// NewPaymentHandlers creates a new payment handlers instance. The review service may be nil, in which case
+// payments are shown without their screening.
+func NewPaymentHandlers(
+	paymentService payment.PaymentService,
+	invoiceService invoice.InvoiceService,
+	reviewService compliance.ReviewService,
+	explorers *shared.ExplorerResolver,
+	logger *zap.Logger,
+) *PaymentHandlers {
+	return &PaymentHandlers{
+		paymentService: paymentService,
+		invoiceService: invoiceService,
+		reviewService:  reviewService,
+		explorers:      explorers,
+		logger:         logger,
+	}
+}
+
+// GetPayment handles GET /payments/:id
+// @Summary Get a payment
+// @Description Get the full detail of a payment: its transaction with block explorer links, sender, block,
+// @Description confirmations, network fee, AML/KYT screening and the invoice it pays
+// @Tags Payments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Payment ID"
+// @Success 200 {object} PaymentDetailResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Payment not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payments/{id} [get]
+func (h *PaymentHandlers) GetPayment(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	p, err := h.paymentService.GetPayment(ctx, shared.PaymentID(c.Param("id")))
+	if err != nil {
+		h.respondError(c, err, "Failed to get payment")
+		return
+	}
+
+	// Payments belong to the merchant of the invoice they pay
+	inv, err := h.invoiceService.GetInvoice(ctx, p.InvoiceID().String())
+	if err == nil && inv.MerchantID() != merchantID {
+		err = payment.ErrPaymentNotFound
+	}
+	if err != nil {
+		h.respondError(c, err, "Failed to get payment invoice")
+		return
+	}
+
+	var screening *compliance.Screening
+	if h.reviewService != nil {
+		screening, err = h.reviewService.GetPaymentScreening(ctx, &compliance.GetPaymentScreeningRequest{
+			MerchantID: merchantID,
+			PaymentID:  p.ID().String(),
+		})
+		if err != nil && !errors.Is(err, compliance.ErrScreeningNotFound) {
+			h.respondError(c, err, "Failed to get payment screening")
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, ToPaymentDetailResponse(p, inv, screening, h.explorers))
+}
+
+// respondError maps a payment lookup error to its HTTP response. Missing invoices are reported as missing
+// payments, so that merchants cannot tell apart payments of other merchants from ones that do not exist.
+func (h *PaymentHandlers) respondError(c *gin.Context, err error, message string) {
+	var domainErr *shared.DomainError
+	if errors.Is(err, shared.ErrNotFound) ||
+		(errors.As(err, &domainErr) && domainErr.Code == payment.ErrCodePaymentNotFound) {
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", payment.ErrCodePaymentNotFound, "Payment not found"))
+		return
+	}
+	h.logger.Error(message, zap.Error(err), zap.String("payment_id", c.Param("id")))
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+}
+
+// RegisterPaymentRoutes registers the payment routes.
+func (h *PaymentHandlers) RegisterPaymentRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	payments := protected.Group("/payments")
+	payments.GET("/:id", rbac.RequireRolePermission(merchant.PermissionInvoicesRead), h.GetPayment)
+}
@@ -0,0 +1,189 @@
+package web_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/compliance"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/test/testutil"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// paymentScreenings serves the screenings of payments, standing in for the compliance review service.
+type paymentScreenings struct {
+	compliance.ReviewService
+	screenings map[string]*compliance.Screening
+}
+
+func (s *paymentScreenings) GetPaymentScreening(
+	_ context.Context,
+	req *compliance.GetPaymentScreeningRequest,
+) (*compliance.Screening, error) {
+	screening, ok := s.screenings[req.PaymentID]
+	if !ok || screening.MerchantID() != req.MerchantID {
+		return nil, compliance.ErrScreeningNotFound
+	}
+	return screening, nil
+}
+
+func TestPaymentHandlers_GetPayment(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	_, services := web.CreateTestHandlerWithServices()
+
+	unitPrice, err := shared.NewMoney("20.00", shared.CurrencyUSD)
+	require.NoError(t, err)
+	createInvoice := func(merchantID string) *invoice.Invoice {
+		inv, createErr := services.Invoices.CreateInvoice(ctx, &invoice.CreateInvoiceRequest{
+			MerchantID:     merchantID,
+			Title:          "Payment Detail Invoice",
+			Items:          []*invoice.CreateInvoiceItemRequest{{Name: "Item", Quantity: "1", UnitPrice: unitPrice}},
+			TaxRate:        "0.00",
+			Currency:       shared.CurrencyUSD,
+			CryptoCurrency: shared.CryptoCurrencyUSDT,
+		})
+		require.NoError(t, createErr)
+		return inv
+	}
+	createPayment := func(id, invoiceID, txHash string) *payment.Payment {
+		p, buildErr := testutil.NewPaymentBuilder().
+			WithID(id).
+			ForInvoice(invoiceID).
+			WithAmount("20.00", shared.CryptoCurrencyUSDT).
+			WithTransactionHash(txHash).
+			RequiringConfirmations(19).
+			Build()
+		require.NoError(t, buildErr)
+		_, createErr := services.Payments.CreatePayment(ctx, &payment.CreatePaymentRequest{
+			ID:                    p.ID(),
+			InvoiceID:             p.InvoiceID(),
+			Amount:                p.Amount(),
+			FromAddress:           p.FromAddress(),
+			ToAddress:             p.ToAddress(),
+			TransactionHash:       p.TransactionHash(),
+			RequiredConfirmations: p.RequiredConfirmations(),
+		})
+		require.NoError(t, createErr)
+		return p
+	}
+
+	inv := createInvoice("test-merchant")
+	p := createPayment("payment-detail-1", inv.ID(), testutil.TestTransactionHash)
+	require.NoError(t, services.Payments.UpdateBlockInfo(ctx, p.ID(), 61234567, "0000000003a6b1c2d3e4f5"))
+	fee, err := shared.NewMoneyWithCrypto("1.1", shared.CryptoCurrencyUSDT)
+	require.NoError(t, err)
+	require.NoError(t, services.Payments.UpdateNetworkFee(ctx, p.ID(), fee, shared.CryptoCurrencyUSDT))
+	require.NoError(t, services.Payments.UpdateConfirmations(ctx, p.ID(), 7))
+
+	otherInvoice := createInvoice("other-merchant")
+	createPayment("payment-detail-2", otherInvoice.ID(),
+		"b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f0a1b2c3")
+
+	screening, err := compliance.NewScreening("screening-1", "test-merchant", p.ID().String(), inv.ID(),
+		p.FromAddress(), "tron", "chainalysis", &compliance.ScreeningResult{
+			RiskLevel:  compliance.RiskLevelMedium,
+			RiskScore:  42,
+			Categories: []string{"exchange"},
+		}, compliance.RiskLevelHigh)
+	require.NoError(t, err)
+
+	handlers := web.NewPaymentHandlers(services.Payments, services.Invoices, &paymentScreenings{
+		screenings: map[string]*compliance.Screening{p.ID().String(): screening},
+	}, shared.DefaultExplorerResolver(), zap.NewNop())
+	router := gin.New()
+	protected := router.Group("/api/v1", func(c *gin.Context) {
+		if merchantID := c.GetHeader("X-Merchant-ID"); merchantID != "" {
+			c.Set("merchant_id", merchantID)
+		}
+		c.Next()
+	})
+	handlers.RegisterPaymentRoutes(protected, nil)
+
+	get := func(id, merchantID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/payments/"+id, http.NoBody)
+		if merchantID != "" {
+			req.Header.Set("X-Merchant-ID", merchantID)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Returns_Chain_Data", func(t *testing.T) {
+		w := get(p.ID().String(), "test-merchant")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var detail web.PaymentDetailResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &detail))
+		require.Equal(t, "payment-detail-1", detail.ID)
+		require.Equal(t, "confirming", detail.Status)
+		require.Equal(t, "20", detail.Amount)
+		require.Equal(t, "USDT", detail.CryptoCurrency)
+		require.Equal(t, "tron", detail.Network)
+		require.Equal(t, testutil.TestTransactionHash, detail.TransactionHash)
+		require.Equal(t, []string{testutil.TestSenderAddress}, detail.FromAddresses)
+		require.Equal(t, testutil.TestAddress, detail.ToAddress)
+		require.NotNil(t, detail.BlockNumber)
+		require.Equal(t, int64(61234567), *detail.BlockNumber)
+		require.Equal(t, "0000000003a6b1c2d3e4f5", detail.BlockHash)
+		require.Equal(t, 7, detail.Confirmations)
+		require.Equal(t, 19, detail.RequiredConfirmations)
+		require.Equal(t, "1.1", detail.NetworkFee)
+		require.Equal(t, "USDT", detail.NetworkFeeCurrency)
+
+		require.Equal(t, "https://tronscan.org/#/transaction/"+testutil.TestTransactionHash, detail.Explorer.Transaction)
+		require.Equal(t, []string{"https://tronscan.org/#/address/" + testutil.TestSenderAddress},
+			detail.Explorer.FromAddresses)
+		require.Equal(t, "https://tronscan.org/#/address/"+testutil.TestAddress, detail.Explorer.ToAddress)
+		require.Equal(t, "https://tronscan.org/#/block/61234567", detail.Explorer.Block)
+
+		require.NotNil(t, detail.Screening)
+		require.Equal(t, "medium", detail.Screening.RiskLevel)
+		require.Equal(t, "cleared", detail.Screening.Status)
+
+		require.Equal(t, inv.ID(), detail.Invoice.ID)
+		require.Equal(t, "created", detail.Invoice.Status)
+	})
+
+	t.Run("Unscreened_Payment", func(t *testing.T) {
+		handlers := web.NewPaymentHandlers(services.Payments, services.Invoices, nil, nil, zap.NewNop())
+		router := gin.New()
+		handlers.RegisterPaymentRoutes(router.Group("/api/v1", func(c *gin.Context) {
+			c.Set("merchant_id", "test-merchant")
+			c.Next()
+		}), nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/payments/"+p.ID().String(), http.NoBody))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var detail web.PaymentDetailResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &detail))
+		require.Nil(t, detail.Screening)
+	})
+
+	t.Run("Payment_Of_Another_Merchant_Not_Found", func(t *testing.T) {
+		w := get("payment-detail-2", "test-merchant")
+		require.Equal(t, http.StatusNotFound, w.Code)
+
+		var response web.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, payment.ErrCodePaymentNotFound, response.Code)
+	})
+
+	t.Run("Unknown_Payment_Not_Found", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, get("missing-payment", "test-merchant").Code)
+	})
+
+	t.Run("Merchant_Scope_Required", func(t *testing.T) {
+		require.Equal(t, http.StatusForbidden, get(p.ID().String(), "").Code)
+	})
+}
@@ -0,0 +1,312 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/paymentlink"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PaymentLinkHandlers handles merchant payment link management.
+type PaymentLinkHandlers struct {
+	paymentLinkService paymentlink.Service
+	logger             *zap.Logger
+}
+
+// NewPaymentLinkHandlers creates a new payment link handlers instance.
+func NewPaymentLinkHandlers(paymentLinkService paymentlink.Service, logger *zap.Logger) *PaymentLinkHandlers {
+	return &PaymentLinkHandlers{
+		paymentLinkService: paymentLinkService,
+		logger:             logger,
+	}
+}
+
+// CreateLink handles POST /payment-links
+// @Summary Create a payment link
+// @Tags Payment Links
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body CreatePaymentLinkRequest true "Payment link"
+// @Success 201 {object} PaymentLinkResponse "Payment link created"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payment-links [post]
+func (h *PaymentLinkHandlers) CreateLink(c *gin.Context) {
+	var req CreatePaymentLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind create payment link request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	link, err := h.paymentLinkService.CreateLink(c.Request.Context(), &paymentlink.CreateLinkRequest{
+		MerchantID:     merchantID,
+		Title:          req.Title,
+		Description:    req.Description,
+		Amount:         req.Amount,
+		Currency:       shared.Currency(req.Currency),
+		CryptoCurrency: shared.CryptoCurrency(req.CryptoCurrency),
+	})
+	if err != nil {
+		respondPaymentLinkError(c, h.logger, err, "Failed to create payment link")
+		return
+	}
+
+	response := ToPaymentLinkResponse(link)
+	setAuditChange(c, nil, map[string]interface{}{
+		"slug":   response.Slug,
+		"title":  response.Title,
+		"amount": response.Amount,
+	})
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListLinks handles GET /payment-links
+// @Summary List payment links
+// @Tags Payment Links
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} ListPaymentLinksResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payment-links [get]
+func (h *PaymentLinkHandlers) ListLinks(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	links, err := h.paymentLinkService.ListLinks(c.Request.Context(), merchantID)
+	if err != nil {
+		respondPaymentLinkError(c, h.logger, err, "Failed to list payment links")
+		return
+	}
+
+	response := ListPaymentLinksResponse{Links: make([]PaymentLinkResponse, len(links))}
+	for i, link := range links {
+		response.Links[i] = ToPaymentLinkResponse(link)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetLink handles GET /payment-links/:id
+// @Summary Get a payment link
+// @Tags Payment Links
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Payment link ID"
+// @Success 200 {object} PaymentLinkResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Payment link not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payment-links/{id} [get]
+func (h *PaymentLinkHandlers) GetLink(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	link, err := h.paymentLinkService.GetLink(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondPaymentLinkError(c, h.logger, err, "Failed to get payment link")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToPaymentLinkResponse(link))
+}
+
+// GetLinkStats handles GET /payment-links/:id/stats
+// @Summary Get payment link stats
+// @Description Report visits, invoices and conversion of a payment link
+// @Tags Payment Links
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Payment link ID"
+// @Success 200 {object} PaymentLinkStatsResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Payment link not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payment-links/{id}/stats [get]
+func (h *PaymentLinkHandlers) GetLinkStats(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	linkID := c.Param("id")
+	stats, err := h.paymentLinkService.GetLinkStats(c.Request.Context(), merchantID, linkID)
+	if err != nil {
+		respondPaymentLinkError(c, h.logger, err, "Failed to get payment link statistics")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToPaymentLinkStatsResponse(linkID, stats))
+}
+
+// DeactivateLink handles POST /payment-links/:id/deactivate
+// @Summary Deactivate a payment link
+// @Tags Payment Links
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Payment link ID"
+// @Success 200 {object} PaymentLinkResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Payment link not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payment-links/{id}/deactivate [post]
+func (h *PaymentLinkHandlers) DeactivateLink(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	link, err := h.paymentLinkService.DeactivateLink(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondPaymentLinkError(c, h.logger, err, "Failed to deactivate payment link")
+		return
+	}
+
+	setAuditChange(c, map[string]interface{}{"active": true}, map[string]interface{}{"active": link.IsActive()})
+	c.JSON(http.StatusOK, ToPaymentLinkResponse(link))
+}
+
+// RegisterPaymentLinkRoutes registers payment link management routes.
+func (h *PaymentLinkHandlers) RegisterPaymentLinkRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	links := protected.Group("/payment-links", rbac.RequireRolePermission(merchant.PermissionPaymentLinksManage))
+	links.POST("", rbac.AuditAction("payment_link.create"), h.CreateLink)
+	links.GET("", h.ListLinks)
+	links.GET("/:id", h.GetLink)
+	links.GET("/:id/stats", h.GetLinkStats)
+	links.POST("/:id/deactivate", rbac.AuditAction("payment_link.deactivate"), h.DeactivateLink)
+}
+
+// GetPublicPaymentLink handles GET /api/v1/public/links/:slug requests from the checkout page.
+// @Summary Get a payment link
+// @Description Retrieve the product and price of an active payment link and record the visit
+// @Tags Public
+// @Produce json
+// @Param slug path string true "Payment link slug"
+// @Success 200 {object} PublicPaymentLinkResponse "Payment link"
+// @Failure 404 {object} ErrorResponse "Payment link not found"
+// @Failure 410 {object} ErrorResponse "Payment link deactivated"
+// @Router /api/v1/public/links/{slug} [get]
+func (h *Handler) GetPublicPaymentLink(c *gin.Context) {
+	if !h.requirePaymentLinks(c) {
+		return
+	}
+
+	link, err := h.paymentLinkService.OpenLink(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		respondPaymentLinkError(c, h.Logger, err, "Failed to open payment link")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToPublicPaymentLinkResponse(link))
+}
+
+// CreatePaymentLinkInvoice handles POST /api/v1/public/links/:slug/invoices requests from the checkout page.
+// @Summary Pay through a payment link
+// @Description Create a fresh invoice with its own payment address from an active payment link
+// @Tags Public
+// @Accept json
+// @Produce json
+// @Param slug path string true "Payment link slug"
+// @Param request body CreatePaymentLinkInvoiceRequest false "Amount for open-amount links"
+// @Success 201 {object} PublicInvoiceResponse "Invoice created"
+// @Failure 400 {object} ErrorResponse "Missing or invalid amount"
+// @Failure 404 {object} ErrorResponse "Payment link not found"
+// @Failure 410 {object} ErrorResponse "Payment link deactivated"
+// @Router /api/v1/public/links/{slug}/invoices [post]
+func (h *Handler) CreatePaymentLinkInvoice(c *gin.Context) {
+	var req CreatePaymentLinkInvoiceRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+			return
+		}
+	}
+
+	if !h.requirePaymentLinks(c) {
+		return
+	}
+
+	inv, err := h.paymentLinkService.CreateInvoice(c.Request.Context(), c.Param("slug"), req.Amount)
+	if err != nil {
+		respondPaymentLinkError(c, h.Logger, err, "Failed to create invoice from payment link")
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.toPublicInvoiceResponse(inv))
+}
+
+// openPaymentLink handles GET /l/:slug, the short URL merchants share. It creates a fresh invoice and
+// redirects the customer to its payment page; open-amount links take the amount from the query string.
+func (h *Handler) openPaymentLink(c *gin.Context) {
+	if !h.requirePaymentLinks(c) {
+		return
+	}
+
+	link, err := h.paymentLinkService.OpenLink(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		respondPaymentLinkError(c, h.Logger, err, "Failed to open payment link")
+		return
+	}
+
+	inv, err := h.paymentLinkService.CreateInvoice(c.Request.Context(), link.Slug(), c.Query("amount"))
+	if err != nil {
+		respondPaymentLinkError(c, h.Logger, err, "Failed to create invoice from payment link")
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/invoice/"+inv.ID())
+}
+
+// requirePaymentLinks responds 404 if payment links are not enabled on the handler.
+func (h *Handler) requirePaymentLinks(c *gin.Context) bool {
+	if h.paymentLinkService == nil {
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", paymentlink.ErrCodeLinkNotFound, "Payment link not found"))
+		return false
+	}
+	return true
+}
+
+// respondPaymentLinkError maps payment link errors to HTTP responses.
+func respondPaymentLinkError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, paymentlink.ErrLinkNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", paymentlink.ErrCodeLinkNotFound, "Payment link not found"))
+	case errors.Is(err, paymentlink.ErrLinkInactive):
+		c.JSON(http.StatusGone, createAuthErrorResponse(
+			"gone", paymentlink.ErrCodeLinkInactive, err.Error()))
+	case errors.Is(err, paymentlink.ErrAmountRequired):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", paymentlink.ErrCodeAmountRequired, err.Error()))
+	case errors.Is(err, paymentlink.ErrInvalidAmount):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", paymentlink.ErrCodeInvalidAmount, err.Error()))
+	case errors.Is(err, paymentlink.ErrInvalidRequest), errors.Is(err, invoice.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,142 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/paymentlink"
+	"crypto-checkout/internal/presentation/web"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPaymentLinks(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	handler, services := web.CreateTestHandlerWithServices()
+	handler.RegisterRoutes(router)
+
+	protected := router.Group("/merchant", func(c *gin.Context) {
+		c.Set("merchant_id", "test-merchant")
+		c.Next()
+	})
+	web.NewPaymentLinkHandlers(services.PaymentLinks, zap.NewNop()).RegisterPaymentLinkRoutes(protected, nil)
+
+	ctx := context.Background()
+	fixed, err := services.PaymentLinks.CreateLink(ctx, &paymentlink.CreateLinkRequest{
+		MerchantID: "test-merchant",
+		Title:      "Coffee",
+		Amount:     "25.00",
+	})
+	require.NoError(t, err)
+	open, err := services.PaymentLinks.CreateLink(ctx, &paymentlink.CreateLinkRequest{
+		MerchantID: "test-merchant",
+		Title:      "Tip jar",
+	})
+	require.NoError(t, err)
+
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("GetPublicPaymentLink", func(t *testing.T) {
+		w := request(http.MethodGet, "/api/v1/public/links/"+fixed.Slug(), "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response web.PublicPaymentLinkResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, "Coffee", response.Title)
+		require.Equal(t, "25.00", response.Amount)
+		require.False(t, response.OpenAmount)
+
+		w = request(http.MethodGet, "/api/v1/public/links/missing", "")
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("CreatePaymentLinkInvoice_FixedAmount", func(t *testing.T) {
+		first := request(http.MethodPost, "/api/v1/public/links/"+fixed.Slug()+"/invoices", "")
+		require.Equal(t, http.StatusCreated, first.Code, first.Body.String())
+		second := request(http.MethodPost, "/api/v1/public/links/"+fixed.Slug()+"/invoices", `{"amount":"1"}`)
+		require.Equal(t, http.StatusCreated, second.Code, second.Body.String())
+
+		var firstInvoice, secondInvoice web.PublicInvoiceResponse
+		require.NoError(t, json.Unmarshal(first.Body.Bytes(), &firstInvoice))
+		require.NoError(t, json.Unmarshal(second.Body.Bytes(), &secondInvoice))
+		require.Equal(t, "25.00", firstInvoice.Total)
+		require.Equal(t, "25.00", secondInvoice.Total)
+		require.NotEqual(t, firstInvoice.ID, secondInvoice.ID)
+	})
+
+	t.Run("CreatePaymentLinkInvoice_OpenAmount", func(t *testing.T) {
+		w := request(http.MethodPost, "/api/v1/public/links/"+open.Slug()+"/invoices", "")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), paymentlink.ErrCodeAmountRequired)
+
+		w = request(http.MethodPost, "/api/v1/public/links/"+open.Slug()+"/invoices", `{"amount":"12.50"}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response web.PublicInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, "12.50", response.Total)
+	})
+
+	t.Run("ShortURL_RedirectsToInvoice", func(t *testing.T) {
+		w := request(http.MethodGet, "/l/"+open.Slug()+"?amount=5", "")
+		require.Equal(t, http.StatusSeeOther, w.Code, w.Body.String())
+		require.True(t, strings.HasPrefix(w.Header().Get("Location"), "/invoice/"))
+	})
+
+	t.Run("GetLinkStats", func(t *testing.T) {
+		w := request(http.MethodGet, "/merchant/payment-links/"+fixed.ID()+"/stats", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var stats web.PaymentLinkStatsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+		require.Equal(t, 1, stats.Visits)
+		require.Equal(t, 2, stats.Invoices)
+		require.Equal(t, 0, stats.PaidInvoices)
+		require.Equal(t, "0.00", stats.AmountCollected)
+	})
+
+	t.Run("DeactivateLink", func(t *testing.T) {
+		w := request(http.MethodPost, "/merchant/payment-links/"+fixed.ID()+"/deactivate", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = request(http.MethodPost, "/api/v1/public/links/"+fixed.Slug()+"/invoices", "")
+		require.Equal(t, http.StatusGone, w.Code)
+
+		w = request(http.MethodGet, "/merchant/payment-links", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var list web.ListPaymentLinksResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Links, 2)
+	})
+
+	t.Run("CreateLink", func(t *testing.T) {
+		w := request(http.MethodPost, "/merchant/payment-links", `{"title":"Donation","currency":"EUR"}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response web.PaymentLinkResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.True(t, response.OpenAmount)
+		require.Equal(t, "EUR", response.Currency)
+		require.True(t, strings.HasSuffix(response.URL, "/l/"+response.Slug))
+
+		w = request(http.MethodPost, "/merchant/payment-links", `{"title":"Bad","amount":"-1"}`)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
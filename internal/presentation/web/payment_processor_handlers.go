@@ -0,0 +1,340 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/processor"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// maxProcessorWebhookBodySize bounds the size of a payment processor webhook body.
+const maxProcessorWebhookBodySize = 64 << 10
+
+// PaymentProcessorHandlers handles merchant payment processor registrations and the webhooks processors send.
+type PaymentProcessorHandlers struct {
+	processorService processor.Service
+	processors       *processor.Registry
+	logger           *zap.Logger
+}
+
+// NewPaymentProcessorHandlers creates a new payment processor handlers instance.
+func NewPaymentProcessorHandlers(
+	processorService processor.Service,
+	processors *processor.Registry,
+	logger *zap.Logger,
+) *PaymentProcessorHandlers {
+	return &PaymentProcessorHandlers{
+		processorService: processorService,
+		processors:       processors,
+		logger:           logger,
+	}
+}
+
+// RegisterProcessor handles POST /payment-processors
+// @Summary Register a payment processor
+// @Description Register an off-chain payment processor, such as Binance Pay or Coinbase Commerce, with the
+// @Description merchant's own credentials. Its checkout is then offered on the payment page of unpaid invoices.
+// @Description Point the processor's webhooks at the returned webhook_path.
+// @Tags Payment Processors
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body PaymentProcessorRequest true "Processor and credentials"
+// @Success 201 {object} PaymentProcessorResponse "Payment processor registered"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters or unsupported processor"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 409 {object} ErrorResponse "Processor already registered"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payment-processors [post]
+func (h *PaymentProcessorHandlers) RegisterProcessor(c *gin.Context) {
+	var req PaymentProcessorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind register payment processor request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	registration, err := h.processorService.RegisterProcessor(c.Request.Context(), &processor.RegisterRequest{
+		MerchantID: merchantID,
+		Processor:  req.Processor,
+		Credentials: processor.Credentials{
+			APIKey:        req.APIKey,
+			APISecret:     req.APISecret,
+			WebhookSecret: req.WebhookSecret,
+		},
+	})
+	if err != nil {
+		respondPaymentProcessorError(c, h.logger, err, "Failed to register payment processor")
+		return
+	}
+
+	setAuditChange(c, nil, paymentProcessorAuditState(registration))
+	c.JSON(http.StatusCreated, ToPaymentProcessorResponse(registration))
+}
+
+// ListProcessors handles GET /payment-processors
+// @Summary List payment processors
+// @Description List the merchant's payment processor registrations and the processors the platform offers.
+// @Tags Payment Processors
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} ListPaymentProcessorsResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payment-processors [get]
+func (h *PaymentProcessorHandlers) ListProcessors(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	registrations, err := h.processorService.ListRegistrations(c.Request.Context(), merchantID)
+	if err != nil {
+		respondPaymentProcessorError(c, h.logger, err, "Failed to list payment processors")
+		return
+	}
+
+	response := ListPaymentProcessorsResponse{
+		Processors: make([]PaymentProcessorResponse, len(registrations)),
+		Available:  h.processors.Names(),
+	}
+	for i, registration := range registrations {
+		response.Processors[i] = ToPaymentProcessorResponse(registration)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetProcessor handles GET /payment-processors/:id
+// @Summary Get a payment processor registration
+// @Tags Payment Processors
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Registration ID"
+// @Success 200 {object} PaymentProcessorResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Registration not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payment-processors/{id} [get]
+func (h *PaymentProcessorHandlers) GetProcessor(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	registration, err := h.processorService.GetRegistration(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondPaymentProcessorError(c, h.logger, err, "Failed to get payment processor")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToPaymentProcessorResponse(registration))
+}
+
+// UpdateProcessor handles PATCH /payment-processors/:id
+// @Summary Update a payment processor registration
+// @Description Enable or disable a payment processor on the payment page, or replace its credentials. Checkouts
+// @Description already created are still credited when paid.
+// @Tags Payment Processors
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Registration ID"
+// @Param request body UpdatePaymentProcessorRequest true "Changes"
+// @Success 200 {object} PaymentProcessorResponse
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Registration not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payment-processors/{id} [patch]
+func (h *PaymentProcessorHandlers) UpdateProcessor(c *gin.Context) {
+	var req UpdatePaymentProcessorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind update payment processor request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	before, err := h.processorService.GetRegistration(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondPaymentProcessorError(c, h.logger, err, "Failed to update payment processor")
+		return
+	}
+	beforeState := paymentProcessorAuditState(before)
+
+	update := &processor.UpdateRegistrationRequest{
+		MerchantID:     merchantID,
+		RegistrationID: before.ID(),
+		Enabled:        req.Enabled,
+	}
+	if req.APIKey != "" {
+		update.Credentials = &processor.Credentials{
+			APIKey:        req.APIKey,
+			APISecret:     req.APISecret,
+			WebhookSecret: req.WebhookSecret,
+		}
+	}
+	registration, err := h.processorService.UpdateRegistration(c.Request.Context(), update)
+	if err != nil {
+		respondPaymentProcessorError(c, h.logger, err, "Failed to update payment processor")
+		return
+	}
+
+	setAuditChange(c, beforeState, paymentProcessorAuditState(registration))
+	c.JSON(http.StatusOK, ToPaymentProcessorResponse(registration))
+}
+
+// DeleteProcessor handles DELETE /payment-processors/:id
+// @Summary Delete a payment processor registration
+// @Description Stop offering a payment processor and forget its credentials. Webhooks for its checkouts are
+// @Description no longer accepted.
+// @Tags Payment Processors
+// @Security ApiKeyAuth
+// @Param id path string true "Registration ID"
+// @Success 204 "Registration deleted"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Registration not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payment-processors/{id} [delete]
+func (h *PaymentProcessorHandlers) DeleteProcessor(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	registration, err := h.processorService.GetRegistration(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondPaymentProcessorError(c, h.logger, err, "Failed to delete payment processor")
+		return
+	}
+
+	if err := h.processorService.DeleteRegistration(c.Request.Context(), merchantID, registration.ID()); err != nil {
+		respondPaymentProcessorError(c, h.logger, err, "Failed to delete payment processor")
+		return
+	}
+
+	setAuditChange(c, paymentProcessorAuditState(registration), nil)
+	c.Status(http.StatusNoContent)
+}
+
+// HandleWebhook handles POST /processors/:processor/webhooks/:registration_id
+// @Summary Receive a payment processor webhook
+// @Description Accept a webhook signed by a payment processor for a merchant's registration. A paid checkout is
+// @Description recorded as a confirmed payment of its invoice, with the same payment events as an on-chain
+// @Description payment. Webhooks about anything but checkouts are acknowledged and ignored.
+// @Tags Payment Processors
+// @Accept json
+// @Produce json
+// @Param processor path string true "Processor name" Enums(binance_pay, coinbase_commerce)
+// @Param registration_id path string true "Registration ID"
+// @Success 200 {object} ProcessorWebhookResponse "Webhook accepted"
+// @Failure 400 {object} ErrorResponse "Invalid webhook"
+// @Failure 401 {object} ErrorResponse "Invalid signature"
+// @Failure 404 {object} ErrorResponse "Registration or checkout not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/processors/{processor}/webhooks/{registration_id} [post]
+func (h *PaymentProcessorHandlers) HandleWebhook(c *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxProcessorWebhookBodySize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	checkout, err := h.processorService.HandleWebhook(c.Request.Context(), &processor.WebhookRequest{
+		Processor:      c.Param("processor"),
+		RegistrationID: c.Param("registration_id"),
+		Headers:        c.Request.Header,
+		Body:           body,
+	})
+	if errors.Is(err, processor.ErrIgnoredEvent) {
+		c.JSON(http.StatusOK, ProcessorWebhookResponse{ReturnCode: ProcessorWebhookSuccess})
+		return
+	}
+	if err != nil {
+		respondPaymentProcessorError(c, h.logger, err, "Failed to handle payment processor webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, ProcessorWebhookResponse{
+		ReturnCode: ProcessorWebhookSuccess,
+		CheckoutID: checkout.ID(),
+		Status:     string(checkout.Status()),
+	})
+}
+
+// RegisterPaymentProcessorRoutes registers payment processor management routes.
+func (h *PaymentProcessorHandlers) RegisterPaymentProcessorRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	processors := protected.Group("/payment-processors",
+		rbac.RequireRolePermission(merchant.PermissionSettingsManage))
+	processors.POST("", rbac.AuditAction("payment_processor.register"), h.RegisterProcessor)
+	processors.GET("", h.ListProcessors)
+	processors.GET("/:id", h.GetProcessor)
+	processors.PATCH("/:id", rbac.AuditAction("payment_processor.update"), h.UpdateProcessor)
+	processors.DELETE("/:id", rbac.AuditAction("payment_processor.delete"), h.DeleteProcessor)
+}
+
+// RegisterProcessorWebhookRoutes registers the route processors send webhooks to. Webhooks are authenticated
+// by the processor's signature, not by merchant credentials.
+func (h *PaymentProcessorHandlers) RegisterProcessorWebhookRoutes(v1 *gin.RouterGroup) {
+	v1.POST("/processors/:processor/webhooks/:registration_id", h.HandleWebhook)
+}
+
+// paymentProcessorAuditState returns the audited fields of a payment processor registration, without its
+// credentials.
+func paymentProcessorAuditState(registration *processor.Registration) map[string]interface{} {
+	return map[string]interface{}{
+		"processor": registration.Processor(),
+		"enabled":   registration.Enabled(),
+		"api_key":   maskCredential(registration.Credentials().APIKey),
+	}
+}
+
+// respondPaymentProcessorError maps payment processor errors to HTTP responses.
+func respondPaymentProcessorError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, processor.ErrRegistrationNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", processor.ErrCodeRegistrationNotFound, "Payment processor not found"))
+	case errors.Is(err, processor.ErrCheckoutNotFound):
+		c.JSON(http.StatusNotFound, createNotFoundErrorResponse("payment processor checkout not found"))
+	case errors.Is(err, invoice.ErrInvoiceNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", invoice.ErrCodeInvoiceNotFound, "Invoice not found"))
+	case errors.Is(err, processor.ErrAlreadyRegistered):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", processor.ErrCodeAlreadyRegistered, err.Error()))
+	case errors.Is(err, processor.ErrUnsupportedProcessor):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", processor.ErrCodeUnsupportedProcessor, err.Error()))
+	case errors.Is(err, processor.ErrInvalidSignature):
+		c.JSON(http.StatusUnauthorized, createAuthErrorResponse(
+			"authentication_error", processor.ErrCodeInvalidSignature, "Invalid webhook signature"))
+	case errors.Is(err, processor.ErrInvalidEvent):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", processor.ErrCodeInvalidEvent, err.Error()))
+	case errors.Is(err, processor.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
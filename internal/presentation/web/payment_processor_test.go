@@ -0,0 +1,171 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/processor"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/processors"
+	"crypto-checkout/internal/presentation/web"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentProcessors(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	// Coinbase Commerce answers charge creation with a hosted page named after the checkout reference
+	var reference string
+	coinbase := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var charge struct {
+			Metadata map[string]string `json:"metadata"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&charge))
+		reference = charge.Metadata["reference"]
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"data":{"code":"CHARGE1","hosted_url":"https://commerce.example/%s"}}`, reference)
+	}))
+	defer coinbase.Close()
+
+	handler, services := web.CreateTestHandlerWithServices()
+	registry := processor.NewRegistry(processors.NewCoinbaseCommerce(coinbase.URL, coinbase.Client()))
+	processorService := processor.NewService(
+		services.Processors, registry, services.Invoices, services.Payments, nil, handler.Logger)
+	handler.SetProcessorService(processorService)
+	handlers := web.NewPaymentProcessorHandlers(processorService, registry, handler.Logger)
+
+	v1 := router.Group("/api/v1")
+	protected := v1.Group("", func(c *gin.Context) {
+		c.Set("merchant_id", "test-merchant")
+		c.Next()
+	})
+	handlers.RegisterPaymentProcessorRoutes(protected, nil)
+	handlers.RegisterProcessorWebhookRoutes(v1)
+	router.GET("/api/v1/public/invoice/:id", handler.GetPublicInvoiceData)
+
+	request := func(method, path string, body []byte, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	signed := func(body string) http.Header {
+		mac := hmac.New(sha256.New, []byte("shared-secret"))
+		mac.Write([]byte(body))
+		return http.Header{"X-Cc-Webhook-Signature": []string{hex.EncodeToString(mac.Sum(nil))}}
+	}
+
+	body, err := json.Marshal(web.PaymentProcessorRequest{
+		Processor:     processor.NameCoinbaseCommerce,
+		APIKey:        "cc-api-key-0123456789",
+		WebhookSecret: "shared-secret",
+	})
+	require.NoError(t, err)
+	w := request(http.MethodPost, "/api/v1/payment-processors", body, nil)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var registration web.PaymentProcessorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &registration))
+	assert.True(t, registration.Enabled)
+	assert.Equal(t, "***6789", registration.APIKeyHint)
+	webhookPath := "/api/v1/processors/coinbase_commerce/webhooks/" + registration.ID
+	assert.Equal(t, webhookPath, registration.WebhookPath)
+
+	t.Run("RegisterProcessor_Unsupported", func(t *testing.T) {
+		body, err := json.Marshal(web.PaymentProcessorRequest{
+			Processor: "paypal", APIKey: "key", WebhookSecret: "secret",
+		})
+		require.NoError(t, err)
+		w := request(http.MethodPost, "/api/v1/payment-processors", body, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ListProcessors", func(t *testing.T) {
+		w := request(http.MethodGet, "/api/v1/payment-processors", nil, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var list web.ListPaymentProcessorsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Processors, 1)
+		assert.Equal(t, []string{processor.NameCoinbaseCommerce}, list.Available)
+	})
+
+	unitPrice, err := shared.NewMoney("25.00", shared.CurrencyUSD)
+	require.NoError(t, err)
+	inv, err := services.Invoices.CreateInvoice(context.Background(), &invoice.CreateInvoiceRequest{
+		MerchantID:     "test-merchant",
+		Title:          "Order",
+		Items:          []*invoice.CreateInvoiceItemRequest{{Name: "Item", Quantity: "1", UnitPrice: unitPrice}},
+		TaxRate:        "0.00",
+		Currency:       shared.CurrencyUSD,
+		CryptoCurrency: shared.CryptoCurrencyUSDT,
+	})
+	require.NoError(t, err)
+
+	w = request(http.MethodGet, "/api/v1/public/invoice/"+inv.ID(), nil, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var public web.PublicInvoiceResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &public))
+	require.Len(t, public.CheckoutOptions, 1)
+	option := public.CheckoutOptions[0]
+	assert.Equal(t, "Coinbase Commerce", option.DisplayName)
+	assert.Equal(t, "https://commerce.example/"+reference, option.URL)
+	assert.Equal(t, "25", option.Amount)
+	assert.Equal(t, "USDT", option.Currency)
+
+	t.Run("HandleWebhook_InvalidSignature", func(t *testing.T) {
+		w := request(http.MethodPost, webhookPath, []byte(`{}`), http.Header{"X-Cc-Webhook-Signature": {"00"}})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("HandleWebhook_Ignored", func(t *testing.T) {
+		event := `{"event":{"type":"charge:created","data":{"metadata":{}}}}`
+		w := request(http.MethodPost, webhookPath, []byte(event), signed(event))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"returnCode":"SUCCESS"}`, w.Body.String())
+	})
+
+	t.Run("HandleWebhook_Paid", func(t *testing.T) {
+		event := `{"event":{"type":"charge:confirmed","data":{"metadata":{"reference":"` + reference + `"},` +
+			`"payments":[{"status":"CONFIRMED","value":{"crypto":{"amount":"25","currency":"USDT"}}}]}}}`
+		w := request(http.MethodPost, webhookPath, []byte(event), signed(event))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response web.ProcessorWebhookResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, string(processor.CheckoutPaid), response.Status)
+
+		paid, err := services.Invoices.GetInvoice(context.Background(), inv.ID())
+		require.NoError(t, err)
+		// The invoice is paid once the confirmed payment event is handled, which this test does not wire
+		assert.Equal(t, invoice.StatusConfirming, paid.Status())
+
+		// The paid invoice offers no more checkouts
+		w = request(http.MethodGet, "/api/v1/public/invoice/"+inv.ID(), nil, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var public web.PublicInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &public))
+		assert.Empty(t, public.CheckoutOptions)
+	})
+
+	t.Run("DeleteProcessor", func(t *testing.T) {
+		w := request(http.MethodDelete, "/api/v1/payment-processors/"+registration.ID, nil, nil)
+		require.Equal(t, http.StatusNoContent, w.Code)
+		w = request(http.MethodGet, "/api/v1/payment-processors/"+registration.ID, nil, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,84 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PaymentStatisticsHandlers handles the platform's payment statistics time series.
+type PaymentStatisticsHandlers struct {
+	statisticsService payment.StatisticsService
+	logger            *zap.Logger
+}
+
+// NewPaymentStatisticsHandlers creates a new payment statistics handlers instance.
+func NewPaymentStatisticsHandlers(
+	statisticsService payment.StatisticsService,
+	logger *zap.Logger,
+) *PaymentStatisticsHandlers {
+	return &PaymentStatisticsHandlers{
+		statisticsService: statisticsService,
+		logger:            logger,
+	}
+}
+
+// GetStatistics handles GET /admin/payments/statistics
+// @Summary Get payment statistics
+// @Description Count the payments reaching each status and total their volume per currency, by hour or day,
+// @Description with the median time from detection to confirmation and the orphan rate. Times are aligned to
+// @Description bucket boundaries in UTC; without from, the last day is reported hourly and the last 30 days daily.
+// @Tags Admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param interval query string false "Bucket width" Enums(hour, day) default(hour)
+// @Param from query string false "Start of the period (RFC 3339)"
+// @Param to query string false "End of the period, exclusive (RFC 3339); defaults to now"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} PaymentStatisticsResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/payments/statistics [get]
+func (h *PaymentStatisticsHandlers) GetStatistics(c *gin.Context) {
+	var req PaymentStatisticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	statsReq := payment.StatisticsRequest{Interval: payment.StatisticsInterval(req.Interval)}
+	if req.From != nil {
+		statsReq.From = *req.From
+	}
+	if req.To != nil {
+		statsReq.To = *req.To
+	}
+
+	series, err := h.statisticsService.GetStatisticsSeries(shared.WithReplicaReads(c.Request.Context()), &statsReq)
+	if err != nil {
+		if errors.Is(err, payment.ErrInvalidStatisticsRequest) {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+			return
+		}
+		h.logger.Error("Failed to get payment statistics", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get payment statistics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ToPaymentStatisticsResponse(series))
+}
+
+// RegisterPaymentStatisticsRoutes registers the payment statistics routes.
+func (h *PaymentStatisticsHandlers) RegisterPaymentStatisticsRoutes(
+	protected *gin.RouterGroup,
+	rbac *RBACMiddleware,
+) {
+	protected.GET("/admin/payments/statistics", rbac.RequireRolePermission(merchant.PermissionAdminOperations),
+		h.GetStatistics)
+}
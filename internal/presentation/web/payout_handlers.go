@@ -0,0 +1,350 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PayoutHandlers handles merchant payout wallets and the payouts sent to them.
+type PayoutHandlers struct {
+	payoutService payout.Service
+	logger        *zap.Logger
+}
+
+// NewPayoutHandlers creates a new payout handlers instance.
+func NewPayoutHandlers(payoutService payout.Service, logger *zap.Logger) *PayoutHandlers {
+	return &PayoutHandlers{
+		payoutService: payoutService,
+		logger:        logger,
+	}
+}
+
+// AddWallet handles POST /payout-wallets
+// @Summary Add a payout wallet
+// @Description Register a payout address; it must be verified by signing the returned challenge before use
+// @Tags Payouts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body AddPayoutWalletRequest true "Payout wallet"
+// @Success 201 {object} PayoutWalletResponse "Wallet added, pending verification"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 409 {object} ErrorResponse "Wallet already registered"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payout-wallets [post]
+func (h *PayoutHandlers) AddWallet(c *gin.Context) {
+	var req AddPayoutWalletRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind add payout wallet request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	wallet, err := h.payoutService.AddWallet(c.Request.Context(), &payout.AddWalletRequest{
+		MerchantID: merchantID,
+		Network:    shared.BlockchainNetwork(req.Network),
+		Address:    req.Address,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to add payout wallet")
+		return
+	}
+
+	setAuditChange(c, nil, map[string]interface{}{
+		"network": wallet.Network().String(),
+		"address": wallet.Address(),
+	})
+	c.JSON(http.StatusCreated, ToPayoutWalletResponse(wallet))
+}
+
+// ListWallets handles GET /payout-wallets
+// @Summary List payout wallets
+// @Tags Payouts
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} ListPayoutWalletsResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payout-wallets [get]
+func (h *PayoutHandlers) ListWallets(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	wallets, err := h.payoutService.ListWallets(c.Request.Context(), merchantID)
+	if err != nil {
+		h.respondError(c, err, "Failed to list payout wallets")
+		return
+	}
+
+	response := ListPayoutWalletsResponse{Wallets: make([]PayoutWalletResponse, len(wallets))}
+	for i, wallet := range wallets {
+		response.Wallets[i] = ToPayoutWalletResponse(wallet)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// VerifyWallet handles POST /payout-wallets/:id/verify
+// @Summary Verify a payout wallet
+// @Description Prove control of the payout address with a signature of the challenge
+// @Tags Payouts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Payout wallet ID"
+// @Param request body VerifyPayoutWalletRequest true "Challenge signature"
+// @Success 200 {object} PayoutWalletResponse "Wallet verified"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Payout wallet not found"
+// @Failure 409 {object} ErrorResponse "Wallet already verified"
+// @Failure 422 {object} ErrorResponse "Challenge expired or signature invalid"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payout-wallets/{id}/verify [post]
+func (h *PayoutHandlers) VerifyWallet(c *gin.Context) {
+	var req VerifyPayoutWalletRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	wallet, err := h.payoutService.VerifyWallet(c.Request.Context(), &payout.VerifyWalletRequest{
+		MerchantID: merchantID,
+		WalletID:   c.Param("id"),
+		Signature:  req.Signature,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to verify payout wallet")
+		return
+	}
+
+	setAuditChange(c,
+		map[string]interface{}{"status": string(payout.WalletStatusPendingVerification)},
+		map[string]interface{}{"status": string(wallet.Status())})
+	c.JSON(http.StatusOK, ToPayoutWalletResponse(wallet))
+}
+
+// RenewChallenge handles POST /payout-wallets/:id/challenge
+// @Summary Renew a payout wallet challenge
+// @Description Issue a fresh verification challenge for an unverified payout wallet
+// @Tags Payouts
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Payout wallet ID"
+// @Success 200 {object} PayoutWalletResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Payout wallet not found"
+// @Failure 409 {object} ErrorResponse "Wallet already verified"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payout-wallets/{id}/challenge [post]
+func (h *PayoutHandlers) RenewChallenge(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	wallet, err := h.payoutService.RenewWalletChallenge(c.Request.Context(), &payout.WalletRequest{
+		MerchantID: merchantID,
+		WalletID:   c.Param("id"),
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to renew payout wallet challenge")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToPayoutWalletResponse(wallet))
+}
+
+// RemoveWallet handles DELETE /payout-wallets/:id
+// @Summary Remove a payout wallet
+// @Tags Payouts
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Payout wallet ID"
+// @Success 204 "Wallet removed"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Payout wallet not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payout-wallets/{id} [delete]
+func (h *PayoutHandlers) RemoveWallet(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	if err := h.payoutService.RemoveWallet(c.Request.Context(), &payout.WalletRequest{
+		MerchantID: merchantID,
+		WalletID:   c.Param("id"),
+	}); err != nil {
+		h.respondError(c, err, "Failed to remove payout wallet")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListPayouts handles GET /payouts
+// @Summary List payouts
+// @Tags Payouts
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "Filter by status" Enums(pending, broadcast, confirmed, failed)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Success 200 {object} ListPayoutsResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payouts [get]
+func (h *PayoutHandlers) ListPayouts(c *gin.Context) {
+	var req ListPayoutsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	var cursor *shared.Cursor
+	if req.Cursor != "" {
+		decoded, err := shared.DecodeCursor(req.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+			return
+		}
+		cursor = decoded
+	}
+
+	resp, err := h.payoutService.ListPayouts(c.Request.Context(), &payout.ListPayoutsRequest{
+		MerchantID: merchantID,
+		Status:     payout.Status(req.Status),
+		Limit:      req.Limit,
+		Cursor:     cursor,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to list payouts")
+		return
+	}
+
+	response := ListPayoutsResponse{
+		Payouts: make([]PayoutResponse, len(resp.Payouts)),
+		Limit:   resp.Limit,
+	}
+	for i, p := range resp.Payouts {
+		response.Payouts[i] = ToPayoutResponse(p)
+	}
+	if resp.NextCursor != nil {
+		response.NextCursor = resp.NextCursor.Encode()
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetPayout handles GET /payouts/:id
+// @Summary Get a payout
+// @Tags Payouts
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Payout ID"
+// @Success 200 {object} PayoutResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Payout not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/payouts/{id} [get]
+func (h *PayoutHandlers) GetPayout(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	p, err := h.payoutService.GetPayout(c.Request.Context(), &payout.GetPayoutRequest{
+		MerchantID: merchantID,
+		PayoutID:   c.Param("id"),
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to get payout")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToPayoutResponse(p))
+}
+
+// respondError maps payout domain errors to HTTP responses.
+func (h *PayoutHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, payout.ErrWalletNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", payout.ErrCodeWalletNotFound, "Payout wallet not found"))
+	case errors.Is(err, payout.ErrPayoutNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", payout.ErrCodePayoutNotFound, "Payout not found"))
+	case errors.Is(err, payout.ErrWalletExists):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", payout.ErrCodeWalletExists, err.Error()))
+	case errors.Is(err, payout.ErrWalletAlreadyVerified):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", payout.ErrCodeWalletVerified, err.Error()))
+	case errors.Is(err, payout.ErrChallengeExpired):
+		c.JSON(http.StatusUnprocessableEntity, createAuthErrorResponse(
+			"validation_error", payout.ErrCodeChallengeExpired, err.Error()))
+	case errors.Is(err, payout.ErrInvalidSignature):
+		c.JSON(http.StatusUnprocessableEntity, createAuthErrorResponse(
+			"validation_error", payout.ErrCodeInvalidSignature, err.Error()))
+	case errors.Is(err, payout.ErrHotWalletUnavailable):
+		c.JSON(http.StatusServiceUnavailable, createAuthErrorResponse(
+			"service_unavailable", payout.ErrCodePayoutsUnavailable, err.Error()))
+	case errors.Is(err, payout.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	case errors.Is(err, payout.ErrHotWalletFailure):
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{"error": message})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterPayoutRoutes registers payout wallet and payout routes.
+func (h *PayoutHandlers) RegisterPayoutRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	manage := rbac.RequireRolePermission(merchant.PermissionPayoutsManage)
+	read := rbac.RequireRolePermission(merchant.PermissionSettlementsRead)
+
+	wallets := protected.Group("/payout-wallets")
+	wallets.POST("", manage, rbac.AuditAction("payout_wallet.add"), h.AddWallet)
+	wallets.GET("", read, h.ListWallets)
+	wallets.POST("/:id/verify", manage, rbac.AuditAction("payout_wallet.verify"), h.VerifyWallet)
+	wallets.POST("/:id/challenge", manage, h.RenewChallenge)
+	wallets.DELETE("/:id", manage, rbac.AuditAction("payout_wallet.remove"), h.RemoveWallet)
+
+	payouts := protected.Group("/payouts", read)
+	payouts.GET("", h.ListPayouts)
+	payouts.GET("/:id", h.GetPayout)
+}
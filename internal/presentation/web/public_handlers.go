@@ -1,8 +1,11 @@
 package web
 
 import (
+	"context"
 	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/processor"
 	"crypto-checkout/internal/domain/shared"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -22,6 +25,7 @@ import (
 // @Success 200 {object} PublicInvoiceResponse "Invoice data retrieved successfully"
 // @Failure 400 {object} ErrorResponse "Invalid invoice ID"
 // @Failure 404 {object} ErrorResponse "Invoice not found"
+// @Failure 451 {object} ErrorResponse "Checkout not available in the client's country"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /api/v1/public/invoice/{id} [get]
 func (h *Handler) GetPublicInvoiceData(c *gin.Context) {
@@ -32,8 +36,8 @@ func (h *Handler) GetPublicInvoiceData(c *gin.Context) {
 		return
 	}
 
-	// Get invoice from service
-	inv, err := h.invoiceService.GetInvoice(c.Request.Context(), id)
+	// Get invoice from service, possibly from a read replica
+	inv, err := h.customerInvoice(shared.WithReplicaReads(c.Request.Context()), id)
 	if err != nil {
 		h.Logger.Error("Failed to get invoice for public view", zap.Error(err), zap.String("invoice_id", id))
 		if errors.Is(err, shared.ErrNotFound) {
@@ -44,19 +48,25 @@ func (h *Handler) GetPublicInvoiceData(c *gin.Context) {
 		return
 	}
 
+	h.captureCheckout(c.Request.Context(), inv)
+
 	// Convert to public response
 	response := h.toPublicInvoiceResponse(inv)
+	h.setAcceptedCurrencies(c, inv, &response)
+	response.CheckoutOptions = ToCheckoutOptionResponses(h.checkoutOptions(c, inv))
 	c.JSON(http.StatusOK, response)
 }
 
 // GetPublicInvoiceStatus handles GET /api/v1/public/invoice/:id/status requests.
 // @Summary Get invoice status
-// @Description Get the current status of an invoice (no authentication required)
+// @Description Get the current status, progress, expiry countdown and next poll time of an invoice (no authentication required). Send the ETag of the previous response in If-None-Match to get 304 while the invoice is unchanged.
 // @Tags Public API
 // @Accept json
 // @Produce json
 // @Param id path string true "Invoice ID"
+// @Param If-None-Match header string false "ETag of the previous response"
 // @Success 200 {object} PublicInvoiceStatusResponse "Invoice status retrieved successfully"
+// @Success 304 "Invoice status not modified"
 // @Failure 400 {object} ErrorResponse "Invalid invoice ID"
 // @Failure 404 {object} ErrorResponse "Invoice not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
@@ -70,7 +80,10 @@ func (h *Handler) GetPublicInvoiceStatus(c *gin.Context) {
 	}
 
 	// Get invoice status from service
-	status, err := h.invoiceService.GetInvoiceStatus(c.Request.Context(), id)
+	summary, err := h.invoiceService.GetInvoiceStatusSummary(c.Request.Context(), id)
+	if err == nil && summary.Status == invoice.StatusDraft {
+		err = invoice.ErrNotFound
+	}
 	if err != nil {
 		h.Logger.Error("Failed to get invoice status", zap.Error(err), zap.String("invoice_id", id))
 		if errors.Is(err, shared.ErrNotFound) {
@@ -81,13 +94,7 @@ func (h *Handler) GetPublicInvoiceStatus(c *gin.Context) {
 		return
 	}
 
-	response := PublicInvoiceStatusResponse{
-		ID:        id,
-		Status:    status.String(),
-		Timestamp: time.Now().UTC(),
-	}
-
-	c.JSON(http.StatusOK, response)
+	h.respondInvoiceStatus(c, summary)
 }
 
 // GetPublicInvoiceEvents handles GET /api/v1/public/invoice/:id/events requests (Server-Sent Events).
@@ -111,7 +118,7 @@ func (h *Handler) GetPublicInvoiceEvents(c *gin.Context) {
 	}
 
 	// Verify invoice exists
-	_, err := h.invoiceService.GetInvoice(c.Request.Context(), id)
+	_, err := h.customerInvoice(c.Request.Context(), id)
 	if err != nil {
 		h.Logger.Error("Failed to get invoice for events", zap.Error(err), zap.String("invoice_id", id))
 		if errors.Is(err, shared.ErrNotFound) {
@@ -122,12 +129,18 @@ func (h *Handler) GetPublicInvoiceEvents(c *gin.Context) {
 		return
 	}
 
+	// Subscribe before the stream starts so that no update is missed
+	var updates <-chan *shared.BaseDomainEvent
+	if h.invoiceEvents != nil {
+		var unsubscribe func()
+		updates, unsubscribe = h.invoiceEvents.Subscribe(id)
+		defer unsubscribe()
+	}
+
 	// Set SSE headers
 	c.Header("Content-Type", "text/event-stream")
 	c.Header("Cache-Control", "no-cache")
 	c.Header("Connection", "keep-alive")
-	c.Header("Access-Control-Allow-Origin", "*")
-	c.Header("Access-Control-Allow-Headers", "Cache-Control")
 
 	// Send initial event
 	event := fmt.Sprintf("data: {\"event\": \"connected\", \"invoice_id\": %q, \"timestamp\": %q}\n\n",
@@ -143,6 +156,8 @@ func (h *Handler) GetPublicInvoiceEvents(c *gin.Context) {
 		select {
 		case <-c.Request.Context().Done():
 			return
+		case update := <-updates:
+			h.sendInvoiceUpdate(c, id, update)
 		case <-ticker.C:
 			// Send heartbeat
 			heartbeat := fmt.Sprintf("data: {\"event\": \"heartbeat\", \"timestamp\": %q}\n\n",
@@ -153,6 +168,71 @@ func (h *Handler) GetPublicInvoiceEvents(c *gin.Context) {
 	}
 }
 
+// invoiceConfirmationETA returns the expected time until the invoice's payments still waiting for
+// confirmations are confirmed, or nil when none is waiting or no estimator is configured.
+func (h *Handler) invoiceConfirmationETA(ctx context.Context, id string) *ConfirmationETAResponse {
+	if h.confirmationETA == nil || h.paymentService == nil {
+		return nil
+	}
+
+	payments, err := h.paymentService.ListPaymentsByInvoice(ctx, shared.InvoiceID(id))
+	if err != nil {
+		h.Logger.Warn("Failed to list payments for confirmation ETA", zap.Error(err), zap.String("invoice_id", id))
+		return nil
+	}
+	eta, ok := h.confirmationETA.EstimatePayments(payments)
+	if !ok {
+		return nil
+	}
+	return ToConfirmationETAResponse(eta)
+}
+
+// customerInvoice returns an invoice as shown to customers. Drafts are not found until they are finalized.
+func (h *Handler) customerInvoice(ctx context.Context, id string) (*invoice.Invoice, error) {
+	inv, err := h.invoiceService.GetInvoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if inv.IsDraft() {
+		return nil, invoice.ErrNotFound
+	}
+	return inv, nil
+}
+
+// sendInvoiceUpdate streams the current state of an invoice after an event was published for it. Payment
+// confirmations are streamed as published, since each one reports a confirmation the invoice does not keep.
+func (h *Handler) sendInvoiceUpdate(c *gin.Context, id string, update *shared.BaseDomainEvent) {
+	eventType := update.EventType
+	if eventType == shared.EventTypePaymentConfirmation {
+		if event, ok := ToPaymentConfirmationEvent(update); ok {
+			c.SSEvent("", event)
+			c.Writer.Flush()
+		}
+		return
+	}
+	if eventType != shared.EventTypeInvoiceRequoted && eventType != shared.EventTypeInvoiceExtended {
+		return
+	}
+
+	inv, err := h.invoiceService.GetInvoice(c.Request.Context(), id)
+	if err != nil {
+		h.Logger.Error("Failed to get invoice for event stream", zap.Error(err), zap.String("invoice_id", id))
+		return
+	}
+
+	var event interface{}
+	var ok bool
+	if eventType == shared.EventTypeInvoiceExtended {
+		event, ok = ToInvoiceExtendedEvent(inv)
+	} else {
+		event, ok = ToInvoiceRequotedEvent(inv)
+	}
+	if ok {
+		c.SSEvent("", event)
+		c.Writer.Flush()
+	}
+}
+
 // toPublicInvoiceResponse converts a domain invoice to a public response.
 func (h *Handler) toPublicInvoiceResponse(inv *invoice.Invoice) PublicInvoiceResponse {
 	// Convert items
@@ -162,8 +242,10 @@ func (h *Handler) toPublicInvoiceResponse(inv *invoice.Invoice) PublicInvoiceRes
 			Description: item.Description(),
 			UnitPrice:   item.UnitPrice().String(),
 			Quantity:    item.Quantity().String(),
+			Discount:    ToItemDiscountResponse(item),
 			Total:       item.TotalPrice().String(),
 		}
+		setItemTaxResponse(&items[i], item)
 	}
 
 	// Get payment address
@@ -181,7 +263,7 @@ func (h *Handler) toPublicInvoiceResponse(inv *invoice.Invoice) PublicInvoiceRes
 	// Calculate time remaining
 	var timeRemaining int64
 	if !expiresAt.IsZero() {
-		remaining := time.Until(expiresAt)
+		remaining := expiresAt.Sub(shared.Now())
 		if remaining > 0 {
 			timeRemaining = int64(remaining.Seconds())
 		}
@@ -191,34 +273,275 @@ func (h *Handler) toPublicInvoiceResponse(inv *invoice.Invoice) PublicInvoiceRes
 	// For now, return empty payments
 	payments := []PublicPaymentResponse{}
 
-	// TODO: Calculate payment progress
-	// For now, return nil
-	var paymentProgress *PaymentProgressResponse
-
-	// TODO: Get return/cancel URLs from invoice metadata
-	// For now, return nil
-	var returnURL, cancelURL *string
+	// TODO: Calculate payment progress for standard invoices
+	paymentProgress := ToDonationProgressResponse(inv)
 
 	return PublicInvoiceResponse{
 		ID:              inv.ID(),
+		Number:          inv.Number(),
 		Title:           inv.Title(),
 		Description:     inv.Description(),
+		Type:            inv.Type().String(),
+		MinimumAmount:   toMinimumAmount(inv),
 		Items:           items,
 		Subtotal:        inv.Pricing().Subtotal().String(),
+		DiscountAmount:  inv.Pricing().Discount().String(),
+		Discounts:       ToDiscountBreakdownResponse(inv),
 		TaxAmount:       inv.Pricing().Tax().String(),
+		Taxes:           ToInvoiceTaxResponse(inv),
+		FeeAmount:       toFeeAmount(inv),
 		Total:           inv.Pricing().Total().String(),
 		Currency:        inv.Pricing().Total().Currency(),
 		CryptoCurrency:  inv.CryptoCurrency().String(),
-		USDTAmount:      inv.Pricing().Total().String(), // 1:1 USD to USDT for now
+		Network:         toNetwork(inv),
+		USDTAmount:      toCryptoAmount(inv),
 		Address:         address,
+		Memo:            inv.PaymentMemo(),
 		Status:          inv.Status().String(),
 		ExpiresAt:       expiresAt,
 		CreatedAt:       inv.CreatedAt(),
 		PaidAt:          inv.PaidAt(),
 		Payments:        payments,
 		PaymentProgress: paymentProgress,
-		ReturnURL:       returnURL,
-		CancelURL:       cancelURL,
+		ReturnURL:       h.redirectURL(inv, inv.ReturnURL()),
+		CancelURL:       h.redirectURL(inv, inv.CancelURL()),
 		TimeRemaining:   timeRemaining,
+		RateExpiresAt:   inv.ExchangeRate().ExpiresAt(),
+		Requotes:        ToRequoteResponses(inv.Requotes()),
+		SupersededBy:    inv.SupersededBy(),
+		CustomFields:    ToCustomFieldResponses(inv.CheckoutFields()),
+	}
+}
+
+// captureCheckout keeps what the checkout page shows of the invoice as evidence for disputes. The countdown and
+// the signed redirect URLs change on every view and are left out, so that a snapshot is only kept when what the
+// customer sees changes. Failures are logged rather than failing the page.
+func (h *Handler) captureCheckout(ctx context.Context, inv *invoice.Invoice) {
+	if h.evidenceService == nil {
+		return
+	}
+	response := h.toPublicInvoiceResponse(inv)
+	response.TimeRemaining = 0
+	response.ReturnURL, response.CancelURL = nil, nil
+
+	content, err := json.Marshal(response)
+	if err == nil {
+		err = h.evidenceService.CaptureCheckout(ctx, inv.ID(), inv.MerchantID(), content)
+	}
+	if err != nil {
+		h.Logger.Warn("Failed to capture checkout snapshot", zap.Error(err), zap.String("invoice_id", inv.ID()))
+	}
+}
+
+// ChooseDonationAmount handles POST /api/v1/public/invoice/:id/amount requests from the checkout page.
+// @Summary Choose a donation amount
+// @Description Set the amount a customer wants to give on an unpaid donation invoice and return the repriced invoice
+// @Tags Public
+// @Accept json
+// @Produce json
+// @Param id path string true "Invoice ID"
+// @Param request body ChooseDonationAmountRequest true "Chosen amount"
+// @Success 200 {object} PublicInvoiceResponse "Amount chosen"
+// @Failure 400 {object} ErrorResponse "Invalid amount or not a donation"
+// @Failure 404 {object} ErrorResponse "Invoice not found"
+// @Failure 409 {object} ErrorResponse "Invoice already received a payment"
+// @Failure 422 {object} ErrorResponse "Amount below the minimum"
+// @Router /api/v1/public/invoice/{id}/amount [post]
+func (h *Handler) ChooseDonationAmount(c *gin.Context) {
+	var req ChooseDonationAmountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	id := c.Param("id")
+	current, err := h.invoiceService.GetInvoice(c.Request.Context(), id)
+	if err != nil {
+		respondDonationError(c, h.Logger, err, "Failed to get invoice")
+		return
+	}
+
+	amount, err := shared.NewMoney(req.Amount, shared.Currency(current.Pricing().Total().Currency()))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid amount", err))
+		return
+	}
+
+	inv, err := h.invoiceService.ChooseDonationAmount(c.Request.Context(), id, amount)
+	if err != nil {
+		respondDonationError(c, h.Logger, err, "Failed to choose donation amount")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.toPublicInvoiceResponse(inv))
+}
+
+// respondDonationError maps donation amount errors to HTTP responses.
+func respondDonationError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, invoice.ErrInvoiceNotFound), errors.Is(err, shared.ErrNotFound):
+		c.JSON(http.StatusNotFound, createNotFoundErrorResponse("invoice not found"))
+	case errors.Is(err, invoice.ErrNotDonation):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", invoice.ErrCodeNotDonation, err.Error()))
+	case errors.Is(err, invoice.ErrCannotChooseAmount):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", invoice.ErrCodeCannotChooseAmount, err.Error()))
+	case errors.Is(err, invoice.ErrBelowMinimumAmount):
+		c.JSON(http.StatusUnprocessableEntity, createAuthErrorResponse(
+			"validation_error", invoice.ErrCodeBelowMinimumAmount, err.Error()))
+	default:
+		logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// ChangeInvoiceCurrency handles POST /api/v1/public/invoice/:id/currency requests from the checkout page.
+// @Summary Change the invoice cryptocurrency
+// @Description Re-quote an unpaid invoice in another cryptocurrency the merchant accepts and return the invoice
+// @Description with its new amount and payment address
+// @Tags Public
+// @Accept json
+// @Produce json
+// @Param id path string true "Invoice ID"
+// @Param request body ChangeCurrencyRequest true "Chosen cryptocurrency"
+// @Success 200 {object} PublicInvoiceResponse "Currency changed"
+// @Failure 400 {object} ErrorResponse "Cryptocurrency not accepted by the merchant"
+// @Failure 404 {object} ErrorResponse "Invoice not found"
+// @Failure 409 {object} ErrorResponse "Invoice already received a payment"
+// @Router /api/v1/public/invoice/{id}/currency [post]
+func (h *Handler) ChangeInvoiceCurrency(c *gin.Context) {
+	var req ChangeCurrencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	inv, err := h.invoiceService.ChangeCurrency(
+		c.Request.Context(),
+		c.Param("id"),
+		shared.CryptoCurrency(req.CryptoCurrency),
+		shared.BlockchainNetwork(req.Network),
+	)
+	if err != nil {
+		respondCurrencyError(c, h.Logger, err, "Failed to change invoice currency")
+		return
+	}
+
+	response := h.toPublicInvoiceResponse(inv)
+	h.setAcceptedCurrencies(c, inv, &response)
+	c.JSON(http.StatusOK, response)
+}
+
+// SupplyRefundAddress handles POST /api/v1/public/invoice/:id/refund-address requests from the checkout page.
+// @Summary Supply a refund address
+// @Description Record the address refunds of the invoice should be sent to, in place of the sender of its
+// @Description payments. A signature of "Refund invoice <id> to <address>" made with the address marks it verified;
+// @Description signatures are checked on Ethereum, BSC and Tron. The merchant confirms the address before any
+// @Description refund is sent to it, and it cannot be changed once confirmed.
+// @Tags Public
+// @Accept json
+// @Produce json
+// @Param id path string true "Invoice ID"
+// @Param request body SupplyRefundAddressRequest true "Refund address"
+// @Success 200 {object} PublicRefundAddressResponse "Refund address recorded"
+// @Failure 400 {object} ErrorResponse "Invalid address or signature"
+// @Failure 404 {object} ErrorResponse "Invoice not found"
+// @Failure 409 {object} ErrorResponse "Refund destination already confirmed"
+// @Router /api/v1/public/invoice/{id}/refund-address [post]
+func (h *Handler) SupplyRefundAddress(c *gin.Context) {
+	var req SupplyRefundAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	inv, err := h.invoiceService.SupplyRefundAddress(c.Request.Context(), &invoice.SupplyRefundAddressRequest{
+		InvoiceID: c.Param("id"),
+		Address:   req.Address,
+		Signature: req.Signature,
+	})
+	if err != nil {
+		respondRefundDestinationError(c, h.Logger, err, "Failed to record refund address")
+		return
+	}
+
+	c.JSON(http.StatusOK, PublicRefundAddressResponse{
+		InvoiceID: inv.ID(),
+		Address:   inv.RefundDestination().Address(),
+		Verified:  inv.RefundDestination().Verified(),
+	})
+}
+
+// respondRefundDestinationError maps refund destination errors to HTTP responses.
+func respondRefundDestinationError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, invoice.ErrInvoiceNotFound), errors.Is(err, shared.ErrNotFound):
+		c.JSON(http.StatusNotFound, createNotFoundErrorResponse("invoice not found"))
+	case errors.Is(err, invoice.ErrInvalidRefundAddress):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", invoice.ErrCodeInvalidRefundAddress, err.Error()))
+	case errors.Is(err, invoice.ErrInvalidRefundSignature):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", invoice.ErrCodeInvalidRefundSignature, err.Error()))
+	case errors.Is(err, invoice.ErrRefundDestinationConfirmed):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", invoice.ErrCodeRefundDestinationConfirmed, err.Error()))
+	case errors.Is(err, invoice.ErrNoRefundDestination):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", invoice.ErrCodeNoRefundDestination, err.Error()))
+	case errors.Is(err, invoice.ErrRefundDestinationMismatch):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", invoice.ErrCodeRefundDestinationMismatch, err.Error()))
+	default:
+		logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// setAcceptedCurrencies lists the cryptocurrencies the customer may switch an unpaid invoice to.
+// The list is left out if it cannot be read, so the checkout page still shows the invoice.
+func (h *Handler) setAcceptedCurrencies(c *gin.Context, inv *invoice.Invoice, response *PublicInvoiceResponse) {
+	if !inv.CanChangeCurrency() {
+		return
+	}
+
+	tokens, err := h.invoiceService.AcceptedTokens(c.Request.Context(), inv.MerchantID())
+	if err != nil {
+		h.Logger.Warn("Failed to list accepted currencies", zap.Error(err), zap.String("invoice_id", inv.ID()))
+		return
+	}
+	response.Currencies = ToListCurrenciesResponse(tokens).Currencies
+}
+
+// checkoutOptions returns the payment processor checkouts offered for an unpaid invoice. None are offered if
+// they cannot be created, so the checkout page still shows on-chain payment.
+func (h *Handler) checkoutOptions(c *gin.Context, inv *invoice.Invoice) []processor.CheckoutOption {
+	if h.processorService == nil {
+		return nil
+	}
+
+	options, err := h.processorService.CheckoutOptions(c.Request.Context(), inv)
+	if err != nil {
+		h.Logger.Warn("Failed to create payment processor checkouts", zap.Error(err), zap.String("invoice_id", inv.ID()))
+		return nil
+	}
+	return options
+}
+
+// respondCurrencyError maps currency change errors to HTTP responses.
+func respondCurrencyError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, invoice.ErrInvoiceNotFound), errors.Is(err, shared.ErrNotFound):
+		c.JSON(http.StatusNotFound, createNotFoundErrorResponse("invoice not found"))
+	case errors.Is(err, invoice.ErrUnsupportedCryptocurrency), errors.Is(err, invoice.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", invoice.ErrCodeUnsupportedCryptocurrency, err.Error()))
+	case errors.Is(err, invoice.ErrCannotChangeCurrency):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", invoice.ErrCodeCannotChangeCurrency, err.Error()))
+	default:
+		logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
 	}
 }
@@ -0,0 +1,118 @@
+package web
+
+import (
+	"crypto-checkout/internal/infrastructure/runtimeconfig"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RateLimitLimitHeader carries the requests a client may make in the current minute.
+	RateLimitLimitHeader = "X-RateLimit-Limit"
+	// RateLimitRemainingHeader carries the requests a client has left in the current minute.
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+)
+
+// rateTier is a group of routes sharing a limit.
+type rateTier string
+
+const (
+	rateTierAPI    rateTier = "api"
+	rateTierPublic rateTier = "public"
+)
+
+// rateKey counts the requests of one client IP to one tier.
+type rateKey struct {
+	tier rateTier
+	ip   string
+}
+
+// RateLimiter bounds the requests each client IP makes per minute to the API and to the public checkout
+// routes. The limits are read from the runtime settings on every request, so a reload applies to the next
+// request. Requests are counted in fixed one-minute windows.
+type RateLimiter struct {
+	settings *runtimeconfig.Store
+	now      func() time.Time
+
+	mu     sync.Mutex
+	window time.Time
+	counts map[rateKey]int
+}
+
+// NewRateLimiter creates a rate limiter reading its limits from the runtime settings.
+func NewRateLimiter(settings *runtimeconfig.Store) *RateLimiter {
+	return &RateLimiter{
+		settings: settings,
+		now:      time.Now,
+		counts:   make(map[rateKey]int),
+	}
+}
+
+// Middleware rejects requests beyond the limit of their tier with 429 Too Many Requests. Health checks and
+// documentation are not limited.
+func (l *RateLimiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tier, ok := rateTierOf(c.Request.URL.Path)
+		if !ok {
+			c.Next()
+			return
+		}
+		limits := l.settings.Settings().RateLimits
+		limit := limits.API
+		if tier == rateTierPublic {
+			limit = limits.Public
+		}
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		count, reset := l.take(rateKey{tier: tier, ip: c.ClientIP()})
+		c.Header(RateLimitLimitHeader, strconv.Itoa(limit))
+		c.Header(RateLimitRemainingHeader, strconv.Itoa(max(limit-count, 0)))
+		if count > limit {
+			c.Header("Retry-After", strconv.Itoa(int(reset.Seconds())+1))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests,
+				createAuthErrorResponse("rate_limit_error", "RATE_LIMITED", "Too many requests, retry later"))
+			return
+		}
+		c.Next()
+	}
+}
+
+// take counts a request and returns the requests of the key in the current window, including this one, and
+// the time until the window ends.
+func (l *RateLimiter) take(key rateKey) (int, time.Duration) {
+	now := l.now()
+	window := now.Truncate(time.Minute)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !window.Equal(l.window) {
+		// A new minute starts every client afresh
+		l.window = window
+		l.counts = make(map[rateKey]int)
+	}
+	l.counts[key]++
+	return l.counts[key], window.Add(time.Minute).Sub(now)
+}
+
+// rateTierOf returns the tier of a path, reporting false for paths that are not limited.
+func rateTierOf(path string) (rateTier, bool) {
+	switch {
+	case path == OpenAPIPath, strings.HasPrefix(path, DocsPath):
+		return "", false
+	case strings.HasPrefix(path, "/api/v1/public/"), strings.HasPrefix(path, "/invoice/"),
+		strings.HasPrefix(path, "/l/"):
+		return rateTierPublic, true
+	case strings.HasPrefix(path, "/api/"):
+		return rateTierAPI, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,184 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/merchant"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Context keys holding the resource snapshots recorded by AuditAction.
+const (
+	auditBeforeKey = "audit_before"
+	auditAfterKey  = "audit_after"
+)
+
+// RBACMiddleware enforces team member role permissions and records audited actions. A nil middleware passes
+// every request through, leaving only API key permissions to apply.
+type RBACMiddleware struct {
+	teamService  merchant.TeamService
+	auditService audit.Service
+	logger       *zap.Logger
+}
+
+// NewRBACMiddleware creates a new role-based access control middleware.
+func NewRBACMiddleware(
+	teamService merchant.TeamService,
+	auditService audit.Service,
+	logger *zap.Logger,
+) *RBACMiddleware {
+	return &RBACMiddleware{
+		teamService:  teamService,
+		auditService: auditService,
+		logger:       logger,
+	}
+}
+
+// RequireRolePermission checks that the authenticated team member's role grants the permission.
+// Requests without a user in context (API key callers) are passed through and remain governed
+// by their API key permissions.
+func (m *RBACMiddleware) RequireRolePermission(permission string) gin.HandlerFunc {
+	if m == nil {
+		return passThrough
+	}
+	return func(c *gin.Context) {
+		userID := c.GetString("user_id")
+		merchantID := c.GetString("merchant_id")
+		if merchantID == "" {
+			merchantID = c.Param("merchant_id")
+		}
+
+		if userID != "" {
+			if urlMerchantID := c.Param("merchant_id"); urlMerchantID != "" && urlMerchantID != merchantID {
+				c.JSON(
+					http.StatusForbidden,
+					createAuthErrorResponse(
+						"authorization_error",
+						merchant.ErrCodePermissionDenied,
+						"Session does not belong to this merchant",
+					),
+				)
+				c.Abort()
+				return
+			}
+
+			resp, err := m.teamService.CheckPermission(c.Request.Context(), &merchant.CheckPermissionRequest{
+				MerchantID: merchantID,
+				UserID:     userID,
+				Permission: permission,
+			})
+			if err != nil {
+				m.logger.Error("Failed to check role permission",
+					zap.String("user_id", userID),
+					zap.String("permission", permission),
+					zap.Error(err),
+				)
+				c.JSON(
+					http.StatusInternalServerError,
+					createAuthErrorResponse("internal_error", "PERMISSION_CHECK_FAILED", "Failed to check permissions"),
+				)
+				c.Abort()
+				return
+			}
+
+			if !resp.Allowed {
+				m.logger.Debug("Role lacks required permission",
+					zap.String("user_id", userID),
+					zap.String("merchant_id", merchantID),
+					zap.String("required_permission", permission),
+				)
+				c.JSON(
+					http.StatusForbidden,
+					createAuthErrorResponse(
+						"authorization_error",
+						merchant.ErrCodePermissionDenied,
+						"Role does not have required permission: "+permission,
+					),
+				)
+				c.Abort()
+				return
+			}
+
+			c.Set("user_role", string(resp.User.Role()))
+		}
+
+		c.Next()
+	}
+}
+
+// AuditAction records the action in the audit log once the request has completed successfully.
+func (m *RBACMiddleware) AuditAction(action string) gin.HandlerFunc {
+	if m == nil {
+		return passThrough
+	}
+	return func(c *gin.Context) {
+		c.Next()
+
+		merchantID := c.GetString("merchant_id")
+		if merchantID == "" {
+			merchantID = c.Param("merchant_id")
+		}
+		if c.Writer.Status() >= http.StatusBadRequest || merchantID == "" {
+			return
+		}
+
+		actor := auditActor(c)
+		details := map[string]interface{}{
+			"method": c.Request.Method,
+			"status": c.Writer.Status(),
+		}
+		if adminKey := c.GetString(adminKeyContextKey); adminKey != "" {
+			details["admin_key"] = adminKey
+		}
+		before, _ := c.Get(auditBeforeKey)
+		after, _ := c.Get(auditAfterKey)
+		beforeSnapshot, _ := before.(map[string]interface{})
+		afterSnapshot, _ := after.(map[string]interface{})
+
+		if err := m.auditService.Record(c.Request.Context(), &audit.RecordRequest{
+			MerchantID:   merchantID,
+			Actor:        actor,
+			Action:       action,
+			ResourceType: c.FullPath(),
+			ResourceID:   c.Param("id"),
+			Before:       beforeSnapshot,
+			After:        afterSnapshot,
+			Details:      details,
+		}); err != nil {
+			m.logger.Error("Failed to record audit entry",
+				zap.String("merchant_id", merchantID),
+				zap.String("action", action),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// auditActor returns the team member or API key that made the request.
+func auditActor(c *gin.Context) audit.Actor {
+	actor := audit.Actor{ID: c.GetString("api_key_id"), Type: audit.ActorTypeAPIKey}
+	if userID := c.GetString("user_id"); userID != "" {
+		actor = audit.Actor{ID: userID, Type: audit.ActorTypeUser, Role: c.GetString("user_role")}
+	}
+	if actor.ID == "" {
+		actor.ID = "anonymous"
+	}
+	return actor
+}
+
+// setAuditChange stores before and after snapshots of the affected resource for AuditAction.
+func setAuditChange(c *gin.Context, before, after map[string]interface{}) {
+	if before != nil {
+		c.Set(auditBeforeKey, before)
+	}
+	if after != nil {
+		c.Set(auditAfterKey, after)
+	}
+}
+
+// passThrough is the handler of a nil RBAC middleware.
+func passThrough(c *gin.Context) {
+	c.Next()
+}
@@ -0,0 +1,141 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/reconciliation"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ReconciliationHandlers handles the reports of the reconciliations of the ledger against the chain and the
+// settlements.
+type ReconciliationHandlers struct {
+	reconciliationService reconciliation.Service
+	logger                *zap.Logger
+}
+
+// NewReconciliationHandlers creates a new reconciliation handlers instance.
+func NewReconciliationHandlers(
+	reconciliationService reconciliation.Service,
+	logger *zap.Logger,
+) *ReconciliationHandlers {
+	return &ReconciliationHandlers{
+		reconciliationService: reconciliationService,
+		logger:                logger,
+	}
+}
+
+// ListReports handles GET /admin/reconciliation/reports
+// @Summary List reconciliation reports
+// @Description List the latest reconciliation reports, newest first. Each night a report compares the balance
+// @Description every deposit address should hold from its payments, unmatched deposits and sweeps with its
+// @Description on-chain balance, and the payments of the invoices paid since the previous report with their
+// @Description settlements and reversal entries.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Number of reports" default(20) minimum(1) maximum(100)
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} ListReconciliationReportsResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid admin key"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/reconciliation/reports [get]
+func (h *ReconciliationHandlers) ListReports(c *gin.Context) {
+	var req ListReconciliationReportsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	reports, err := h.reconciliationService.ListReports(c.Request.Context(), req.Limit)
+	if err != nil {
+		h.respondError(c, err, "Failed to list reconciliation reports")
+		return
+	}
+
+	resp := ListReconciliationReportsResponse{Reports: make([]ReconciliationReportResponse, len(reports))}
+	for i, report := range reports {
+		resp.Reports[i] = ToReconciliationReportResponse(report)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetReport handles GET /admin/reconciliation/reports/:id
+// @Summary Get a reconciliation report
+// @Description Get a reconciliation report with its discrepancies. Difference is the actual amount less the
+// @Description expected one: what a deposit address holds on chain less what its records leave, or what an
+// @Description invoice's settlements add up to less what it received.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Report ID"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} ReconciliationReportResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid admin key"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 404 {object} ErrorResponse "Report not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/reconciliation/reports/{id} [get]
+func (h *ReconciliationHandlers) GetReport(c *gin.Context) {
+	report, err := h.reconciliationService.GetReport(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to get reconciliation report")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToReconciliationReportResponse(report))
+}
+
+// RunReconciliation handles POST /admin/reconciliation/reports
+// @Summary Run a reconciliation now
+// @Description Reconcile the ledger now rather than waiting for the nightly run, covering the invoices paid
+// @Description since the previous report. An alert is raised when the discrepancies of a currency exceed its
+// @Description threshold, as for nightly reports.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 201 {object} ReconciliationReportResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid admin key"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/reconciliation/reports [post]
+func (h *ReconciliationHandlers) RunReconciliation(c *gin.Context) {
+	report, err := h.reconciliationService.Reconcile(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err, "Failed to reconcile ledger")
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToReconciliationReportResponse(report))
+}
+
+// respondError maps reconciliation errors to HTTP responses.
+func (h *ReconciliationHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, reconciliation.ErrReportNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", reconciliation.ErrCodeReportNotFound, "Reconciliation report not found"))
+	case errors.Is(err, reconciliation.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", reconciliation.ErrCodeInvalidRequest, err.Error()))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterReconciliationRoutes registers the reconciliation report routes under /admin/reconciliation.
+func (h *ReconciliationHandlers) RegisterReconciliationRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionAdminOperations)
+
+	reports := protected.Group("/admin/reconciliation/reports", require)
+	reports.GET("", h.ListReports)
+	reports.POST("", rbac.AuditAction("admin.reconcile"), h.RunReconciliation)
+	reports.GET("/:id", h.GetReport)
+}
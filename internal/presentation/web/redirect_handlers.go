@@ -0,0 +1,74 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetRedirectKeys handles GET /api/v1/public/redirect-keys requests.
+// @Summary List redirect token keys
+// @Description Return the public keys the checkout_token of return and cancel URLs is verified with, as a JSON Web
+// @Description Key Set. Tokens are EdDSA JWTs whose kid header names the key that signed them; keys stay listed
+// @Description after a rotation until the tokens they signed have expired.
+// @Tags Public API
+// @Produce json
+// @Success 200 {object} RedirectKeysResponse "Redirect token keys"
+// @Router /api/v1/public/redirect-keys [get]
+func (h *Handler) GetRedirectKeys(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.redirectSigner.PublicKeys())
+}
+
+// returnToMerchant handles GET /invoice/:id/return requests, sending the customer to the invoice's return URL.
+func (h *Handler) returnToMerchant(c *gin.Context) {
+	h.redirectToMerchant(c, (*invoice.Invoice).ReturnURL)
+}
+
+// cancelToMerchant handles GET /invoice/:id/return/cancel requests, sending the customer to the invoice's cancel
+// URL.
+func (h *Handler) cancelToMerchant(c *gin.Context) {
+	h.redirectToMerchant(c, (*invoice.Invoice).CancelURL)
+}
+
+// redirectToMerchant sends the customer to one of the invoice's redirect URLs with a token of its current status,
+// signed when the customer leaves rather than when the page was loaded.
+func (h *Handler) redirectToMerchant(c *gin.Context, target func(*invoice.Invoice) *string) {
+	id := c.Param("id")
+	inv, err := h.customerInvoice(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			c.JSON(http.StatusNotFound, createNotFoundErrorResponse("invoice not found"))
+			return
+		}
+		h.Logger.Error("Failed to get invoice for redirect", zap.Error(err), zap.String("invoice_id", id))
+		c.JSON(http.StatusInternalServerError, createValidationErrorResponse("failed to retrieve invoice", err))
+		return
+	}
+
+	redirectURL := h.redirectURL(inv, target(inv))
+	if redirectURL == nil {
+		c.JSON(http.StatusNotFound, createNotFoundErrorResponse("invoice has no redirect URL"))
+		return
+	}
+	c.Redirect(http.StatusFound, *redirectURL)
+}
+
+// redirectURL returns a redirect URL of the invoice with a token of its current status appended, or as is when no
+// redirect keys are configured.
+func (h *Handler) redirectURL(inv *invoice.Invoice, target *string) *string {
+	if target == nil || !h.redirectSigner.Enabled() {
+		return target
+	}
+	signed, err := h.redirectSigner.SignURL(*target, inv)
+	if err != nil {
+		// The URL was validated when the invoice was created; a token that cannot be signed is not worth a dead end
+		h.Logger.Error("Failed to sign redirect URL", zap.Error(err), zap.String("invoice_id", inv.ID()))
+		return target
+	}
+	return &signed
+}
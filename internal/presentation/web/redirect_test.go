@@ -0,0 +1,119 @@
+package web_test
+
+import (
+	"bytes"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/client"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// redirectKeyConfig returns a freshly generated redirect key.
+func redirectKeyConfig(t *testing.T, id string) config.RedirectKeyConfig {
+	_, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	return config.RedirectKeyConfig{ID: id, PrivateKey: base64.StdEncoding.EncodeToString(private.Seed())}
+}
+
+func TestRedirectTokens(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	bundle, err := i18n.NewBundle()
+	require.NoError(t, err)
+	router := web.NewGinEngine(&config.Config{}, zap.NewNop(), bundle, nil)
+
+	// The key added by the last rotation signs; the previous key is still published
+	signer, err := web.NewRedirectSigner(&config.Config{Redirect: config.RedirectConfig{
+		Keys: []config.RedirectKeyConfig{redirectKeyConfig(t, "2025-09"), redirectKeyConfig(t, "2025-06")},
+	}})
+	require.NoError(t, err)
+	handler := web.CreateTestHandler()
+	handler.SetRedirectSigner(signer)
+	handler.RegisterRoutes(router)
+
+	request := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var requestBody []byte
+		if body != nil {
+			requestBody, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	create := func(t *testing.T, returnURL string) *httptest.ResponseRecorder {
+		return request(http.MethodPost, "/api/v1/invoices", web.CreateInvoiceRequest{
+			Title:     "Software License",
+			Items:     []web.InvoiceItemRequest{{Name: "License", Quantity: "1", UnitPrice: "100.00"}},
+			TaxRate:   "0.10",
+			ReturnURL: &returnURL,
+		})
+	}
+
+	w := request(http.MethodGet, "/api/v1/public/redirect-keys", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	keys, err := client.ParseRedirectKeys(w.Body.Bytes())
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+
+	t.Run("Return_AppendsSignedToken", func(t *testing.T) {
+		w := create(t, "https://shop.example.com/orders/42?step=done")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var created web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+		w = request(http.MethodGet, "/invoice/"+created.ID+"/return", nil)
+		require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+		location, err := url.Parse(w.Header().Get("Location"))
+		require.NoError(t, err)
+		require.Equal(t, "shop.example.com", location.Host)
+		require.Equal(t, "done", location.Query().Get("step"))
+
+		claims, err := keys.Verify(location.Query().Get(client.RedirectTokenParam))
+		require.NoError(t, err)
+		require.Equal(t, created.ID, claims.InvoiceID)
+		require.Equal(t, "created", claims.Status)
+		require.Equal(t, "0", claims.AmountPaid)
+		require.False(t, claims.Paid())
+
+		w = request(http.MethodGet, "/api/v1/public/invoice/"+created.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var public web.PublicInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &public))
+		require.NotNil(t, public.ReturnURL)
+		require.Contains(t, *public.ReturnURL, client.RedirectTokenParam+"=")
+		require.Nil(t, public.CancelURL)
+	})
+
+	t.Run("Cancel_WithoutCancelURL_NotFound", func(t *testing.T) {
+		w := create(t, "https://shop.example.com/orders/43")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var created web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+		w = request(http.MethodGet, "/invoice/"+created.ID+"/return/cancel", nil)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("CreateInvoice_RelativeReturnURL_BadRequest", func(t *testing.T) {
+		w := create(t, "/orders/44")
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+}
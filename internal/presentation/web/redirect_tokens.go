@@ -0,0 +1,153 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/pkg/config"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// RedirectTokenParam is the query parameter of return and cancel URLs carrying the redirect token.
+	RedirectTokenParam = "checkout_token"
+	// redirectTokenIssuer is the iss claim of redirect tokens.
+	redirectTokenIssuer = "crypto-checkout"
+)
+
+// RedirectSigner signs the tokens customers are sent back to merchants with, so that a merchant site can trust the
+// invoice status in its return URL without calling the API first. Tokens are EdDSA JWTs: merchants verify them
+// with the public keys served by GetRedirectKeys, which lets keys be rotated without sharing a secret.
+type RedirectSigner struct {
+	keys []redirectKey // The first key signs; the others only verify
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+// redirectKey is a configured signing key.
+type redirectKey struct {
+	id      string
+	private ed25519.PrivateKey
+}
+
+// NewRedirectSigner creates the redirect token signer of the configured keys.
+func NewRedirectSigner(cfg *config.Config) (*RedirectSigner, error) {
+	ttl := cfg.Redirect.TokenTTL
+	if ttl <= 0 {
+		ttl = config.DefaultRedirectTokenTTL
+	}
+
+	signer := &RedirectSigner{ttl: ttl, now: time.Now}
+	seen := make(map[string]bool, len(cfg.Redirect.Keys))
+	for _, keyConfig := range cfg.Redirect.Keys {
+		if keyConfig.ID == "" {
+			return nil, errors.New("redirect key ID is required")
+		}
+		if seen[keyConfig.ID] {
+			return nil, fmt.Errorf("redirect key %q is configured twice", keyConfig.ID)
+		}
+		seen[keyConfig.ID] = true
+
+		private, err := parseRedirectKey(keyConfig.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redirect key %q: %w", keyConfig.ID, err)
+		}
+		signer.keys = append(signer.keys, redirectKey{id: keyConfig.ID, private: private})
+	}
+	return signer, nil
+}
+
+// parseRedirectKey decodes a base64-encoded Ed25519 seed or private key.
+func parseRedirectKey(encoded string) (ed25519.PrivateKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("private key is not base64: %w", err)
+	}
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(raw), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(raw), nil
+	default:
+		return nil, fmt.Errorf("private key has %d bytes, want %d or %d", len(raw), ed25519.SeedSize,
+			ed25519.PrivateKeySize)
+	}
+}
+
+// Enabled reports whether keys are configured, without which customers are sent back without a token.
+func (s *RedirectSigner) Enabled() bool {
+	return s != nil && len(s.keys) > 0
+}
+
+// Sign returns a token of the invoice's current status and amount paid, addressed to its merchant.
+func (s *RedirectSigner) Sign(inv *invoice.Invoice) (string, error) {
+	if !s.Enabled() {
+		return "", errors.New("no redirect keys are configured")
+	}
+
+	amountPaid := "0"
+	if inv.AmountPaid() != nil {
+		amountPaid = inv.AmountPaid().Amount().String()
+	}
+	now := s.now()
+	claims := jwt.MapClaims{
+		"iss":         redirectTokenIssuer,
+		"aud":         inv.MerchantID(),
+		"invoice_id":  inv.ID(),
+		"status":      inv.Status().String(),
+		"amount_paid": amountPaid,
+		"currency":    inv.CryptoCurrency().String(),
+		"iat":         now.Unix(),
+		"exp":         now.Add(s.ttl).Unix(),
+	}
+
+	key := s.keys[0]
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = key.id
+	return token.SignedString(key.private)
+}
+
+// SignURL appends a token of the invoice to a return or cancel URL, keeping the merchant's own query parameters.
+func (s *RedirectSigner) SignURL(target string, inv *invoice.Invoice) (string, error) {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return "", fmt.Errorf("invalid redirect URL: %w", err)
+	}
+	token, err := s.Sign(inv)
+	if err != nil {
+		return "", err
+	}
+
+	param := url.Values{RedirectTokenParam: {token}}.Encode()
+	if parsed.RawQuery == "" {
+		parsed.RawQuery = param
+	} else {
+		parsed.RawQuery += "&" + param
+	}
+	return parsed.String(), nil
+}
+
+// PublicKeys returns the keys tokens are verified with, as a JSON Web Key Set.
+func (s *RedirectSigner) PublicKeys() RedirectKeysResponse {
+	response := RedirectKeysResponse{Keys: []RedirectKeyResponse{}}
+	if s == nil {
+		return response
+	}
+	for _, key := range s.keys {
+		public, _ := key.private.Public().(ed25519.PublicKey)
+		response.Keys = append(response.Keys, RedirectKeyResponse{
+			KeyType:   "OKP",
+			Curve:     "Ed25519",
+			KeyID:     key.id,
+			Use:       "sig",
+			Algorithm: jwt.SigningMethodEdDSA.Alg(),
+			X:         base64.RawURLEncoding.EncodeToString(public),
+		})
+	}
+	return response
+}
@@ -0,0 +1,104 @@
+package web_test
+
+import (
+	"bytes"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/presentation/web"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefundDestinationEndpoints(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	handler := web.CreateTestHandler()
+
+	router.POST("/api/v1/invoices", web.AuthMiddleware(handler.Logger), handler.CreateInvoice)
+	router.POST("/api/v1/invoices/:id/refund-destination/confirm", web.AuthMiddleware(handler.Logger),
+		handler.ConfirmRefundDestination)
+	router.POST("/api/v1/public/invoice/:id/refund-address", handler.SupplyRefundAddress)
+
+	request := func(path string, body interface{}) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("/api/v1/invoices", web.CreateInvoiceRequest{
+		Title:   "Refundable Invoice",
+		Items:   []web.InvoiceItemRequest{{Name: "Widget", Quantity: "1", UnitPrice: "10.00"}},
+		TaxRate: "0.00",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created web.CreateInvoiceResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.Nil(t, created.RefundDestination)
+
+	address := "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8"
+	supplyPath := "/api/v1/public/invoice/" + created.ID + "/refund-address"
+	confirmPath := "/api/v1/invoices/" + created.ID + "/refund-destination/confirm"
+
+	t.Run("ConfirmRefundDestination_NothingProposed", func(t *testing.T) {
+		w := request(confirmPath, web.ConfirmRefundDestinationRequest{Address: address})
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), invoice.ErrCodeNoRefundDestination)
+	})
+
+	t.Run("SupplyRefundAddress_Unverified", func(t *testing.T) {
+		w := request(supplyPath, web.SupplyRefundAddressRequest{Address: address})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response web.PublicRefundAddressResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, address, response.Address)
+		require.False(t, response.Verified)
+	})
+
+	t.Run("SupplyRefundAddress_SignatureNotChecked", func(t *testing.T) {
+		w := request(supplyPath, web.SupplyRefundAddressRequest{Address: address, Signature: "0x1234"})
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), invoice.ErrCodeInvalidRefundSignature)
+	})
+
+	t.Run("SupplyRefundAddress_NotFound", func(t *testing.T) {
+		w := request("/api/v1/public/invoice/missing/refund-address", web.SupplyRefundAddressRequest{Address: address})
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("ConfirmRefundDestination_Mismatch", func(t *testing.T) {
+		w := request(confirmPath, web.ConfirmRefundDestinationRequest{Address: "TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf"})
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), invoice.ErrCodeRefundDestinationMismatch)
+	})
+
+	t.Run("ConfirmRefundDestination", func(t *testing.T) {
+		w := request(confirmPath, web.ConfirmRefundDestinationRequest{Address: address})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotNil(t, response.RefundDestination)
+		require.Equal(t, address, response.RefundDestination.Address)
+		require.Equal(t, "customer", response.RefundDestination.Source)
+		require.True(t, response.RefundDestination.Confirmed)
+
+		// The customer can no longer change it
+		w = request(supplyPath, web.SupplyRefundAddressRequest{Address: "TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf"})
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), invoice.ErrCodeRefundDestinationConfirmed)
+	})
+}
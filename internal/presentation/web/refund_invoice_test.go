@@ -0,0 +1,81 @@
+package web_test
+
+import (
+	"bytes"
+	"crypto-checkout/internal/presentation/web"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefundInvoiceEndpoint(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	handler := web.CreateTestHandler()
+
+	router.POST("/api/v1/invoices", web.AuthMiddleware(handler.Logger), handler.CreateInvoice)
+	router.POST("/api/v1/invoices/:id/refunds", web.AuthMiddleware(handler.Logger), handler.RefundInvoice)
+
+	refund := func(invoiceID string, body interface{}) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(
+			http.MethodPost,
+			"/api/v1/invoices/"+invoiceID+"/refunds",
+			bytes.NewBuffer(requestBody),
+		)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("RefundInvoice_NotPaid", func(t *testing.T) {
+		// Given: an invoice that has not been paid
+		createReq := web.CreateInvoiceRequest{
+			Title: "Test Invoice for Refund",
+			Items: []web.InvoiceItemRequest{
+				{Name: "Test Item", Quantity: "1", UnitPrice: "15.00"},
+			},
+			TaxRate: "0.00",
+		}
+		createBody, err := json.Marshal(createReq)
+		require.NoError(t, err)
+
+		createHTTPReq := httptest.NewRequest(http.MethodPost, "/api/v1/invoices", bytes.NewBuffer(createBody))
+		createHTTPReq.Header.Set("Content-Type", "application/json")
+		createHTTPReq.Header.Set("Authorization", "Bearer sk_live_test123")
+		createW := httptest.NewRecorder()
+		router.ServeHTTP(createW, createHTTPReq)
+		require.Equal(t, http.StatusCreated, createW.Code)
+
+		var createResponse web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(createW.Body.Bytes(), &createResponse))
+		require.Nil(t, createResponse.Refunds)
+
+		// When
+		w := refund(createResponse.ID, web.RefundInvoiceRequest{Amount: "1.00", Reason: "Returned item"})
+
+		// Then
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("RefundInvoice_NotFound", func(t *testing.T) {
+		w := refund("non-existent-invoice", web.RefundInvoiceRequest{Reason: "Returned item"})
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("RefundInvoice_MissingReason", func(t *testing.T) {
+		w := refund("non-existent-invoice", map[string]string{"amount": "1.00"})
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
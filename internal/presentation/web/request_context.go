@@ -0,0 +1,56 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/audit"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RequestIDHeader is the header carrying the request correlation ID.
+	RequestIDHeader = "X-Request-ID"
+	// maxRequestIDLength bounds client-supplied request IDs to the audit log column size.
+	maxRequestIDLength = 64
+	// maxUserAgentLength bounds the user agent to the audit log column size.
+	maxUserAgentLength = 255
+)
+
+// RequestContextMiddleware assigns every request a correlation ID and attaches the
+// request origin to the context so audit entries recorded downstream capture it.
+func RequestContextMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = newRequestID()
+		}
+
+		c.Set("request_id", requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(audit.WithOrigin(c.Request.Context(), audit.Origin{
+			IPAddress: c.ClientIP(),
+			RequestID: requestID,
+			UserAgent: truncate(c.Request.UserAgent(), maxUserAgentLength),
+		}))
+
+		c.Next()
+	}
+}
+
+// newRequestID generates a random request ID.
+func newRequestID() string {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return generateRequestID()
+	}
+	return "req_" + hex.EncodeToString(bytes)
+}
+
+// truncate shortens s to at most n bytes.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,31 @@
+package web
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestDeadline bounds the context of every request by the timeout, so that the queries and outbound calls made
+// for a request a client stopped waiting for are cancelled rather than left to pile up. Websocket upgrades and
+// event streams, which stay open for as long as the client listens, are not bounded.
+func RequestDeadline(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 || isLongLived(c) {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// isLongLived reports whether a request asks for a websocket or an event stream.
+func isLongLived(c *gin.Context) bool {
+	return c.GetHeader("Upgrade") != "" || strings.Contains(c.GetHeader("Accept"), "text/event-stream")
+}
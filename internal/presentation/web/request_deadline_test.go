@@ -0,0 +1,58 @@
+package web_test
+
+import (
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRequestDeadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(web.RequestDeadline(50*time.Millisecond), web.ErrorHandler(&config.Config{}, zap.NewNop()))
+	var bounded bool
+	router.GET("/", func(c *gin.Context) {
+		_, bounded = c.Request.Context().Deadline()
+	})
+	router.GET("/slow", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			_ = c.Error(errors.Join(errors.New("failed to load invoice"), c.Request.Context().Err()))
+		case <-time.After(5 * time.Second):
+			c.Status(http.StatusOK)
+		}
+	})
+
+	t.Run("bounds requests", func(t *testing.T) {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.True(t, bounded)
+	})
+
+	t.Run("answers requests out of time with a gateway timeout", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		started := time.Now()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+		assert.Less(t, time.Since(started), time.Second)
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.Contains(t, w.Body.String(), "REQUEST_TIMEOUT")
+	})
+
+	t.Run("leaves streams unbounded", func(t *testing.T) {
+		for header, value := range map[string]string{"Upgrade": "websocket", "Accept": "text/event-stream"} {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(header, value)
+			router.ServeHTTP(httptest.NewRecorder(), req)
+			assert.False(t, bounded, header)
+		}
+	})
+
+}
@@ -0,0 +1,188 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/review"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ReviewHandlers handles the queue of payments that need operator action.
+type ReviewHandlers struct {
+	reviewService review.Service
+	logger        *zap.Logger
+}
+
+// NewReviewHandlers creates a new review handlers instance.
+func NewReviewHandlers(reviewService review.Service, logger *zap.Logger) *ReviewHandlers {
+	return &ReviewHandlers{
+		reviewService: reviewService,
+		logger:        logger,
+	}
+}
+
+// ListReviews handles GET /reviews
+// @Summary List payment reviews
+// @Description List payments that need operator action
+// @Tags Reviews
+// @Produce json
+// @Security ApiKeyAuth
+// @Param kind query string false "Filter by kind" Enums(underpayment, screening, orphaned, stale_rate)
+// @Param status query string false "Filter by status" Enums(open, resolved)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Number of items to skip" default(0) minimum(0)
+// @Success 200 {object} ListReviewsResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/reviews [get]
+func (h *ReviewHandlers) ListReviews(c *gin.Context) {
+	var req ListReviewsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Failed to bind list reviews request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.reviewService.ListReviews(c.Request.Context(), &review.ListReviewsRequest{
+		MerchantID: merchantID,
+		Kind:       review.Kind(req.Kind),
+		Status:     review.Status(req.Status),
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to list reviews")
+		return
+	}
+
+	reviews := make([]ReviewResponse, len(resp.Reviews))
+	for i, rev := range resp.Reviews {
+		reviews[i] = ToReviewResponse(rev)
+	}
+
+	c.JSON(http.StatusOK, ListReviewsResponse{
+		Reviews: reviews,
+		Total:   resp.Total,
+		Limit:   resp.Limit,
+		Offset:  resp.Offset,
+	})
+}
+
+// GetReview handles GET /reviews/:id
+// @Summary Get a payment review
+// @Tags Reviews
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Review ID"
+// @Success 200 {object} ReviewResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Review not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/reviews/{id} [get]
+func (h *ReviewHandlers) GetReview(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	rev, err := h.reviewService.GetReview(c.Request.Context(), &review.GetReviewRequest{
+		MerchantID: merchantID,
+		ReviewID:   c.Param("id"),
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to get review")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToReviewResponse(rev))
+}
+
+// ResolveReview handles POST /reviews/:id/resolve
+// @Summary Resolve a payment review
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Review ID"
+// @Param request body ResolveReviewRequest true "Resolution"
+// @Success 200 {object} ReviewResponse
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Review not found"
+// @Failure 409 {object} ErrorResponse "Review already resolved"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/reviews/{id}/resolve [post]
+func (h *ReviewHandlers) ResolveReview(c *gin.Context) {
+	var req ResolveReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind resolve review request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	resolvedBy := c.GetString("user_id")
+	if resolvedBy == "" {
+		resolvedBy = c.GetString("api_key_id")
+	}
+
+	rev, err := h.reviewService.ResolveReview(c.Request.Context(), &review.ResolveReviewRequest{
+		MerchantID: merchantID,
+		ReviewID:   c.Param("id"),
+		Resolution: review.Resolution(req.Action),
+		ResolvedBy: resolvedBy,
+		Note:       req.Note,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to resolve review")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToReviewResponse(rev))
+}
+
+// respondError maps review domain errors to HTTP responses.
+func (h *ReviewHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, review.ErrReviewNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", review.ErrCodeReviewNotFound, "Review not found"))
+	case errors.Is(err, review.ErrReviewResolved):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", review.ErrCodeReviewResolved, "Review is already resolved"))
+	case errors.Is(err, review.ErrResolutionNotAllowed):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", review.ErrCodeResolutionNotAllowed, err.Error()))
+	case errors.Is(err, review.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterReviewRoutes registers payment review queue routes.
+func (h *ReviewHandlers) RegisterReviewRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionReviewsManage)
+
+	reviews := protected.Group("/reviews", require)
+	reviews.GET("", h.ListReviews)
+	reviews.GET("/:id", h.GetReview)
+	reviews.POST("/:id/resolve", h.ResolveReview)
+}
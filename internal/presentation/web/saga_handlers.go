@@ -0,0 +1,135 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/saga"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// SagaHandlers shows the state of the sagas settling confirmed payments.
+type SagaHandlers struct {
+	sagaService saga.Service
+	logger      *zap.Logger
+}
+
+// NewSagaHandlers creates a new saga handlers instance.
+func NewSagaHandlers(sagaService saga.Service, logger *zap.Logger) *SagaHandlers {
+	return &SagaHandlers{
+		sagaService: sagaService,
+		logger:      logger,
+	}
+}
+
+// ListSagas handles GET /admin/sagas
+// @Summary List payment settlement sagas
+// @Description List the sagas that mark invoices paid, settle them and follow the payout once a payment is
+// @Description confirmed, newest first. Compensated sagas reversed their settlement and reopened the invoice;
+// @Description failed ones could not and need an operator.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param invoice_id query string false "Filter by invoice ID"
+// @Param merchant_id query string false "Filter by merchant ID"
+// @Param status query string false "Filter by status" Enums(running, completed, compensating, compensated, failed)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} ListSagasResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/sagas [get]
+func (h *SagaHandlers) ListSagas(c *gin.Context) {
+	var req ListSagasRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	var cursor *shared.Cursor
+	if req.Cursor != "" {
+		decoded, err := shared.DecodeCursor(req.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+			return
+		}
+		cursor = decoded
+	}
+
+	resp, err := h.sagaService.ListSagas(c.Request.Context(), &saga.ListSagasRequest{
+		InvoiceID:  req.InvoiceID,
+		MerchantID: req.MerchantID,
+		Status:     saga.Status(req.Status),
+		Limit:      req.Limit,
+		Cursor:     cursor,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to list sagas")
+		return
+	}
+
+	response := ListSagasResponse{
+		Sagas: make([]SagaResponse, len(resp.Sagas)),
+		Limit: resp.Limit,
+	}
+	for i, s := range resp.Sagas {
+		response.Sagas[i] = ToSagaResponse(s)
+	}
+	if resp.NextCursor != nil {
+		response.NextCursor = resp.NextCursor.Encode()
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetSaga handles GET /admin/sagas/:id
+// @Summary Get a payment settlement saga
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Saga ID"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} SagaResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Failure 404 {object} ErrorResponse "Saga not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/sagas/{id} [get]
+func (h *SagaHandlers) GetSaga(c *gin.Context) {
+	s, err := h.sagaService.GetSaga(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to get saga")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToSagaResponse(s))
+}
+
+// respondError maps saga domain errors to HTTP responses.
+func (h *SagaHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, saga.ErrSagaNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", saga.ErrCodeSagaNotFound, "Saga not found"))
+	case errors.Is(err, saga.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterSagaRoutes registers the saga routes under /admin/sagas.
+func (h *SagaHandlers) RegisterSagaRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionAdminOperations)
+
+	sagas := protected.Group("/admin/sagas", require)
+	sagas.GET("", h.ListSagas)
+	sagas.GET("/:id", h.GetSaga)
+}
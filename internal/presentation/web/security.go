@@ -0,0 +1,199 @@
+package web
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/paymentlink"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/pkg/config"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// corsAllowedMethods are the methods cross-origin requests to the public API may use.
+	corsAllowedMethods = "GET, POST, OPTIONS"
+	// corsAllowedHeaders are the request headers cross-origin requests to the public API may set.
+	corsAllowedHeaders = "Accept-Language, Cache-Control, Content-Type, If-None-Match"
+	// corsExposedHeaders are the response headers cross-origin pages may read.
+	corsExposedHeaders = "ETag"
+	// corsMaxAge is how long, in seconds, browsers may cache a CORS preflight.
+	corsMaxAge = 600
+
+	// checkoutContentSecurityPolicy is the policy of the checkout page. Its inline scripts run with the nonce
+	// of the response; the styles Tailwind generates at runtime are inline.
+	checkoutContentSecurityPolicy = "default-src 'none'; " +
+		"script-src 'nonce-%s' https://cdn.tailwindcss.com; " +
+		"style-src 'self' 'unsafe-inline' https://cdnjs.cloudflare.com; " +
+		"font-src https://cdnjs.cloudflare.com; " +
+		"img-src 'self' data:; " +
+		"connect-src 'self'; " +
+		"base-uri 'none'; form-action 'self'; frame-ancestors 'none'"
+)
+
+// MerchantOrigins provides the sites each merchant allows to call the public API from browsers.
+type MerchantOrigins interface {
+	// AllowsOrigin reports whether the merchant allows pages of the origin to call the public API for its
+	// invoices and payment links.
+	AllowsOrigin(ctx context.Context, merchantID, origin string) (bool, error)
+}
+
+// SecurityHeaders returns middleware setting the security headers of every response. Strict-Transport-Security
+// is only sent over HTTPS, directly or through a TLS-terminating proxy, as browsers ignore it otherwise.
+func SecurityHeaders(cfg *config.Config) gin.HandlerFunc {
+	hsts := ""
+	if cfg.Security.HSTSMaxAge > 0 {
+		hsts = fmt.Sprintf("max-age=%d; includeSubDomains", int64(cfg.Security.HSTSMaxAge.Seconds()))
+	}
+
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		header.Set("X-Content-Type-Options", "nosniff")
+		header.Set("X-Frame-Options", "DENY")
+		header.Set("Referrer-Policy", "strict-origin-when-cross-origin")
+		if hsts != "" && isHTTPS(c.Request) {
+			header.Set("Strict-Transport-Security", hsts)
+		}
+		c.Next()
+	}
+}
+
+// isHTTPS reports whether the request reached the server over HTTPS.
+func isHTTPS(r *http.Request) bool {
+	return r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https")
+}
+
+// setCheckoutSecurityPolicy sets the Content-Security-Policy of the checkout page with a fresh nonce and returns
+// the nonce, which the page's inline scripts must carry.
+func setCheckoutSecurityPolicy(c *gin.Context) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate script nonce: %w", err)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(nonce)
+	c.Header("Content-Security-Policy", fmt.Sprintf(checkoutContentSecurityPolicy, encoded))
+	return encoded, nil
+}
+
+// cors returns middleware answering cross-origin requests from the origins the merchant the request is for
+// allows, and from the origins configured for every merchant. merchantOf returns that merchant, or an empty ID
+// if the invoice or payment link does not exist. Requests without an Origin header, such as those of servers,
+// and same-origin requests, such as those of the checkout page, pass unchanged. Preflights of allowed origins
+// are answered here; other origins are refused with 403 ORIGIN_NOT_ALLOWED.
+func (h *Handler) cors(merchantOf func(c *gin.Context) (string, error)) gin.HandlerFunc {
+	configured := make(map[string]bool, len(h.config.Security.CORSOrigins))
+	for _, origin := range h.config.Security.CORSOrigins {
+		normalized, err := merchant.NormalizeOrigin(origin)
+		if err != nil {
+			h.Logger.Warn("Ignoring invalid CORS origin", zap.String("origin", origin), zap.Error(err))
+			continue
+		}
+		configured[normalized] = true
+	}
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" || isSameOrigin(c.Request, origin) {
+			c.Next()
+			return
+		}
+		c.Header("Vary", "Origin")
+
+		allowed, err := h.allowsOrigin(c, merchantOf, configured, origin)
+		if err != nil {
+			h.Logger.Error("Failed to check request origin", zap.Error(err), zap.String("origin", origin),
+				zap.String("path", c.Request.URL.Path))
+			c.AbortWithStatusJSON(http.StatusInternalServerError,
+				createValidationErrorResponse("failed to check origin", err))
+			return
+		}
+		if !allowed {
+			c.AbortWithStatusJSON(http.StatusForbidden, createAuthErrorResponse("authorization_error",
+				"ORIGIN_NOT_ALLOWED", "origin is not allowed to call the API for this merchant"))
+			return
+		}
+
+		c.Header("Access-Control-Allow-Origin", origin)
+		if c.Request.Method == http.MethodOptions {
+			c.Header("Access-Control-Allow-Methods", corsAllowedMethods)
+			c.Header("Access-Control-Allow-Headers", corsAllowedHeaders)
+			c.Header("Access-Control-Max-Age", strconv.Itoa(corsMaxAge))
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Header("Access-Control-Expose-Headers", corsExposedHeaders)
+		c.Next()
+	}
+}
+
+// allowsOrigin reports whether the origin is configured for every merchant or allowed by the merchant the
+// request is for.
+func (h *Handler) allowsOrigin(
+	c *gin.Context, merchantOf func(c *gin.Context) (string, error), configured map[string]bool, origin string,
+) (bool, error) {
+	normalized, err := merchant.NormalizeOrigin(origin)
+	if err != nil {
+		return false, nil
+	}
+	if configured[normalized] {
+		return true, nil
+	}
+	if h.merchantOrigins == nil {
+		return false, nil
+	}
+
+	merchantID, err := merchantOf(c)
+	if err != nil || merchantID == "" {
+		return false, err
+	}
+	return h.merchantOrigins.AllowsOrigin(c.Request.Context(), merchantID, origin)
+}
+
+// isSameOrigin reports whether the origin is the host the request was sent to.
+func isSameOrigin(r *http.Request, origin string) bool {
+	parsed, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(parsed.Host, r.Host)
+}
+
+// invoiceMerchant returns the merchant of the invoice in the route.
+func (h *Handler) invoiceMerchant(c *gin.Context) (string, error) {
+	inv, err := h.customerInvoice(shared.WithReplicaReads(c.Request.Context()), c.Param("id"))
+	if errors.Is(err, shared.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return inv.MerchantID(), nil
+}
+
+// paymentLinkMerchant returns the merchant of the payment link in the route.
+func (h *Handler) paymentLinkMerchant(c *gin.Context) (string, error) {
+	if h.paymentLinkService == nil {
+		return "", nil
+	}
+	link, err := h.paymentLinkService.GetLinkBySlug(c.Request.Context(), c.Param("slug"))
+	if errors.Is(err, paymentlink.ErrLinkNotFound) || errors.Is(err, paymentlink.ErrInvalidRequest) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return link.MerchantID(), nil
+}
+
+// answerPreflight handles OPTIONS requests to the public API. The cors middleware answers those of other
+// origins; what reaches here is a same-origin preflight.
+func answerPreflight(c *gin.Context) {
+	c.Header("Allow", corsAllowedMethods)
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,171 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/paymentlink"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeMerchantOrigins allows the same origins for every merchant.
+type fakeMerchantOrigins []string
+
+func (o fakeMerchantOrigins) AllowsOrigin(_ context.Context, _, origin string) (bool, error) {
+	for _, allowed := range o {
+		if allowed == origin {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	bundle, err := i18n.NewBundle()
+	require.NoError(t, err)
+	cfg := &config.Config{Security: config.SecurityConfig{
+		CORSOrigins: []string{"https://store.example.com"},
+		HSTSMaxAge:  24 * time.Hour,
+	}}
+	router := web.NewGinEngine(cfg, zap.NewNop(), bundle, nil)
+
+	handler, services := web.CreateTestHandlerWithConfig(cfg)
+	handler.SetMerchantOrigins(fakeMerchantOrigins{"https://shop.example.com"})
+	handler.RegisterRoutes(router)
+
+	request := func(method, path string, headers map[string]string, body interface{}) *httptest.ResponseRecorder {
+		var requestBody []byte
+		if body != nil {
+			requestBody, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request(http.MethodPost, "/api/v1/invoices", nil, web.CreateInvoiceRequest{
+		Title:   "Software License",
+		Items:   []web.InvoiceItemRequest{{Name: "License", Quantity: "1", UnitPrice: "100.00"}},
+		TaxRate: "0.10",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created web.CreateInvoiceResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	invoicePath := "/api/v1/public/invoice/" + created.ID
+
+	t.Run("EveryResponse_HasSecurityHeaders", func(t *testing.T) {
+		w := request(http.MethodGet, "/health", nil, nil)
+		require.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		require.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+		require.Empty(t, w.Header().Get("Strict-Transport-Security"))
+
+		w = request(http.MethodGet, "/health", map[string]string{"X-Forwarded-Proto": "https"}, nil)
+		require.Equal(t, "max-age=86400; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+	})
+
+	t.Run("CheckoutPage_ScriptsCarryPolicyNonce", func(t *testing.T) {
+		w := request(http.MethodGet, "/invoice/"+created.ID, nil, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		policy := w.Header().Get("Content-Security-Policy")
+		require.Contains(t, policy, "default-src 'none'")
+		require.Contains(t, policy, "frame-ancestors 'none'")
+		nonce := regexp.MustCompile(`'nonce-([^']+)'`).FindStringSubmatch(policy)
+		require.Len(t, nonce, 2)
+
+		inline := regexp.MustCompile(`<script( nonce="[^"]*")?>`).FindAllStringSubmatch(w.Body.String(), -1)
+		require.NotEmpty(t, inline)
+		for _, script := range inline {
+			require.Equal(t, ` nonce="`+nonce[1]+`"`, script[1])
+		}
+		require.NotRegexp(t, `\son(click|change)=`, w.Body.String())
+
+		second := request(http.MethodGet, "/invoice/"+created.ID, nil, nil)
+		require.NotEqual(t, policy, second.Header().Get("Content-Security-Policy"))
+	})
+
+	t.Run("PublicAPI_MerchantOrigin_Allowed", func(t *testing.T) {
+		w := request(http.MethodGet, invoicePath, map[string]string{"Origin": "https://shop.example.com"}, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, "https://shop.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("PublicAPI_ConfiguredOrigin_Allowed", func(t *testing.T) {
+		w := request(http.MethodGet, invoicePath+"/status", map[string]string{"Origin": "https://store.example.com"}, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, "https://store.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("PublicAPI_OtherOrigin_Forbidden", func(t *testing.T) {
+		w := request(http.MethodPost, invoicePath+"/coupon", map[string]string{"Origin": "https://evil.example.com"},
+			web.ApplyCouponRequest{Code: "SAVE10"})
+		require.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+		require.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("PublicAPI_SameOrigin_Served", func(t *testing.T) {
+		w := request(http.MethodGet, invoicePath, map[string]string{"Origin": "http://example.com"}, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("Preflight_AllowedOrigin_NoContent", func(t *testing.T) {
+		for _, path := range []string{invoicePath, invoicePath + "/currency"} {
+			w := request(http.MethodOptions, path, map[string]string{
+				"Origin":                        "https://shop.example.com",
+				"Access-Control-Request-Method": http.MethodPost,
+			}, nil)
+			require.Equal(t, http.StatusNoContent, w.Code, path)
+			require.Equal(t, "https://shop.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+			require.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), http.MethodPost)
+			require.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "Content-Type")
+			require.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "If-None-Match")
+		}
+
+		w := request(http.MethodOptions, invoicePath+"/currency", map[string]string{
+			"Origin":                        "https://evil.example.com",
+			"Access-Control-Request-Method": http.MethodPost,
+		}, nil)
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("PaymentLink_MerchantOrigin_Allowed", func(t *testing.T) {
+		link, err := services.PaymentLinks.CreateLink(context.Background(), &paymentlink.CreateLinkRequest{
+			MerchantID: "test-merchant",
+			Title:      "Coffee",
+			Amount:     "25.00",
+		})
+		require.NoError(t, err)
+
+		w := request(http.MethodGet, "/api/v1/public/links/"+link.Slug(),
+			map[string]string{"Origin": "https://shop.example.com"}, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, "https://shop.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		require.Equal(t, "ETag", w.Header().Get("Access-Control-Expose-Headers"))
+
+		w = request(http.MethodGet, "/api/v1/public/links/missing", map[string]string{"Origin": "https://shop.example.com"}, nil)
+		require.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	})
+}
@@ -0,0 +1,209 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/pkg/config"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	// sessionGrantType marks access tokens issued to dashboard users.
+	sessionGrantType = "session"
+	// AuthTypeSession identifies requests authenticated with a dashboard session.
+	AuthTypeSession = "session"
+	// AuthTypeAPIKey identifies requests authenticated with an API key or API key JWT.
+	AuthTypeAPIKey = "api_key"
+)
+
+// SessionAuthMiddleware authenticates dashboard users with session access tokens while
+// still accepting API keys, resolving both to the same merchant scope in the context.
+type SessionAuthMiddleware struct {
+	apiKeyAuth     *CombinedAuthMiddleware
+	sessionService merchant.SessionService
+	logger         *zap.Logger
+	secret         string
+	accessTokenTTL time.Duration
+}
+
+// NewSessionAuthMiddleware creates a new session authentication middleware. Session tokens are signed with
+// auth.jwt_secret, which is required and must differ from the secret of API key JWTs.
+func NewSessionAuthMiddleware(
+	apiKeyService merchant.APIKeyService,
+	sessionService merchant.SessionService,
+	cfg *config.Config,
+	logger *zap.Logger,
+) (*SessionAuthMiddleware, error) {
+	secret := cfg.Auth.JWTSecret
+	if secret == "" {
+		return nil, errors.New("auth.jwt_secret: is required to sign dashboard sessions")
+	}
+	if secret == JWTSecret {
+		return nil, errors.New("auth.jwt_secret: must differ from the API key JWT secret")
+	}
+
+	ttl := cfg.Auth.AccessTokenTTL
+	if ttl <= 0 {
+		ttl = config.DefaultAccessTokenTTL
+	}
+
+	return &SessionAuthMiddleware{
+		apiKeyAuth:     NewCombinedAuthMiddleware(apiKeyService, logger, JWTSecret),
+		sessionService: sessionService,
+		logger:         logger,
+		secret:         secret,
+		accessTokenTTL: ttl,
+	}, nil
+}
+
+// IssueAccessToken signs a short-lived session access token for the user.
+func (m *SessionAuthMiddleware) IssueAccessToken(user *merchant.User) (string, int64, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"grant_type":  sessionGrantType,
+		"sub":         user.ID(),
+		"merchant_id": user.MerchantID(),
+		"role":        string(user.Role()),
+		"scope":       user.Role().Permissions(),
+		"iat":         now.Unix(),
+		"exp":         now.Add(m.accessTokenTTL).Unix(),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(m.secret))
+	if err != nil {
+		return "", 0, err
+	}
+
+	return token, int64(m.accessTokenTTL.Seconds()), nil
+}
+
+// RequireAuth accepts either a dashboard session token or API key authentication.
+func (m *SessionAuthMiddleware) RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := bearerToken(c)
+		if token != "" && !isValidAPIToken(token) {
+			if claims, ok := m.parseSessionClaims(token); ok {
+				m.authenticateSession(c, claims)
+				return
+			}
+		}
+
+		m.apiKeyAuth.RequireAuth()(c)
+		if c.IsAborted() {
+			return
+		}
+		c.Set("auth_type", AuthTypeAPIKey)
+	}
+}
+
+// RequireSession accepts only dashboard session tokens.
+func (m *SessionAuthMiddleware) RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := m.parseSessionClaims(bearerToken(c))
+		if !ok {
+			respondInvalidSession(c)
+			return
+		}
+
+		m.authenticateSession(c, claims)
+	}
+}
+
+// authenticateSession checks that the session user is still active with the session's role, stores the
+// session identity in the context and continues the chain.
+func (m *SessionAuthMiddleware) authenticateSession(c *gin.Context, claims jwt.MapClaims) {
+	userID, _ := claims["sub"].(string)
+	merchantID, _ := claims["merchant_id"].(string)
+	role, _ := claims["role"].(string)
+	if userID == "" || merchantID == "" {
+		c.JSON(
+			http.StatusUnauthorized,
+			createAuthErrorResponse("authentication_error", "INVALID_JWT_CLAIMS", "Invalid JWT token claims"),
+		)
+		c.Abort()
+		return
+	}
+
+	user, err := m.sessionService.Authenticate(c.Request.Context(), &merchant.AuthenticateSessionRequest{
+		UserID:     userID,
+		MerchantID: merchantID,
+		Role:       merchant.UserRole(role),
+	})
+	if err != nil {
+		if !errors.Is(err, merchant.ErrInvalidSession) {
+			m.logger.Error("Failed to authenticate session", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to authenticate session"})
+			c.Abort()
+			return
+		}
+		respondInvalidSession(c)
+		return
+	}
+
+	c.Set("auth_type", AuthTypeSession)
+	c.Set("user_id", user.ID())
+	setMerchantScope(c, user.MerchantID())
+	c.Set("user_role", string(user.Role()))
+	c.Set("jwt_scope", user.Role().Permissions())
+	c.Set("jwt_expires_at", claims["exp"])
+
+	m.logger.Debug("Session authentication successful",
+		zap.String("user_id", user.ID()),
+		zap.String("merchant_id", user.MerchantID()),
+	)
+
+	c.Next()
+}
+
+// respondInvalidSession rejects a request without a valid dashboard session.
+func respondInvalidSession(c *gin.Context) {
+	c.JSON(
+		http.StatusUnauthorized,
+		createAuthErrorResponse(
+			"authentication_error",
+			"INVALID_SESSION",
+			"A valid dashboard session token is required",
+		),
+	)
+	c.Abort()
+}
+
+// parseSessionClaims validates a session access token and returns its claims.
+func (m *SessionAuthMiddleware) parseSessionClaims(tokenString string) (jwt.MapClaims, bool) {
+	if tokenString == "" {
+		return nil, false
+	}
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(m.secret), nil
+	})
+	if err != nil || !token.Valid {
+		return nil, false
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, false
+	}
+
+	grantType, _ := claims["grant_type"].(string)
+	return claims, grantType == sessionGrantType
+}
+
+// bearerToken extracts the bearer token from the Authorization header.
+func bearerToken(c *gin.Context) string {
+	authHeader := c.GetHeader("Authorization")
+	if !strings.HasPrefix(authHeader, "Bearer ") {
+		return ""
+	}
+	return strings.TrimPrefix(authHeader, "Bearer ")
+}
@@ -0,0 +1,171 @@
+package web
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/pkg/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubSessionService authenticates sessions against an in-memory set of users.
+type stubSessionService struct {
+	merchant.SessionService
+
+	users map[string]*merchant.User
+}
+
+func newStubSessionService(users ...*merchant.User) *stubSessionService {
+	s := &stubSessionService{users: make(map[string]*merchant.User)}
+	for _, user := range users {
+		s.users[user.ID()] = user
+	}
+	return s
+}
+
+func (s *stubSessionService) Authenticate(
+	_ context.Context,
+	req *merchant.AuthenticateSessionRequest,
+) (*merchant.User, error) {
+	user, ok := s.users[req.UserID]
+	if !ok || user.MerchantID() != req.MerchantID || !user.IsActive() || user.Role() != req.Role {
+		return nil, merchant.ErrInvalidSession
+	}
+	return user, nil
+}
+
+func newSessionTestRouter(
+	t *testing.T,
+	sessionService merchant.SessionService,
+) (*gin.Engine, *SessionAuthMiddleware) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{Auth: config.AuthConfig{JWTSecret: "session-test-secret"}}
+	sessionAuth, err := NewSessionAuthMiddleware(&MockAPIKeyServiceForAuth{}, sessionService, cfg, zap.NewNop())
+	require.NoError(t, err)
+
+	router := gin.New()
+	handler := func(c *gin.Context) {
+		_, merchantID, _, authType := GetAuthInfo(c)
+		c.JSON(http.StatusOK, gin.H{
+			"user_id":     c.GetString("user_id"),
+			"merchant_id": merchantID,
+			"auth_type":   authType,
+		})
+	}
+	router.GET("/any", sessionAuth.RequireAuth(), handler)
+	router.GET("/session", sessionAuth.RequireSession(), handler)
+
+	return router, sessionAuth
+}
+
+func TestSessionAuthMiddleware_SessionToken(t *testing.T) {
+	user, err := merchant.NewUser("user_1", "merchant_1", "dev@example.com", "Dev", merchant.RoleDeveloper)
+	require.NoError(t, err)
+	router, sessionAuth := newSessionTestRouter(t, newStubSessionService(user))
+	token, expiresIn, err := sessionAuth.IssueAccessToken(user)
+	require.NoError(t, err)
+	assert.Equal(t, int64(config.DefaultAccessTokenTTL.Seconds()), expiresIn)
+
+	for _, path := range []string{"/any", "/session"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code, path)
+		assert.JSONEq(t,
+			`{"user_id":"user_1","merchant_id":"merchant_1","auth_type":"session"}`,
+			w.Body.String(), path)
+	}
+}
+
+func TestSessionAuthMiddleware_RejectsForeignTokens(t *testing.T) {
+	router, _ := newSessionTestRouter(t, newStubSessionService())
+
+	// API key JWTs are signed with a different secret and grant type
+	apiKeyToken, err := (&Handler{}).generateJWTToken("sk_live_abc", []string{"invoices:read"}, 3600)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		path   string
+		header string
+	}{
+		{"missing header", "/session", ""},
+		{"api key token on session route", "/session", "Bearer " + apiKeyToken},
+		{"garbage token", "/any", "Bearer not-a-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+	}
+}
+
+func TestSessionAuthMiddleware_ChecksSessionUser(t *testing.T) {
+	owner, err := merchant.NewUser("user_1", "merchant_1", "owner@example.com", "Owner", merchant.RoleOwner)
+	require.NoError(t, err)
+	router, sessionAuth := newSessionTestRouter(t, newStubSessionService(owner))
+
+	request := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/session", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	token, _, err := sessionAuth.IssueAccessToken(owner)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, request(token))
+
+	t.Run("token signed with the API key secret", func(t *testing.T) {
+		forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"grant_type":  sessionGrantType,
+			"sub":         owner.ID(),
+			"merchant_id": owner.MerchantID(),
+			"role":        string(owner.Role()),
+			"exp":         time.Now().Add(time.Hour).Unix(),
+		}).SignedString([]byte(JWTSecret))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, request(forged))
+	})
+
+	t.Run("role changed", func(t *testing.T) {
+		require.NoError(t, owner.ChangeRole(merchant.RoleViewer))
+		assert.Equal(t, http.StatusUnauthorized, request(token))
+	})
+
+	t.Run("user disabled", func(t *testing.T) {
+		token, _, err := sessionAuth.IssueAccessToken(owner)
+		require.NoError(t, err)
+		require.NoError(t, owner.Disable())
+		assert.Equal(t, http.StatusUnauthorized, request(token))
+	})
+}
+
+func TestNewSessionAuthMiddleware_RequiresSecret(t *testing.T) {
+	for _, secret := range []string{"", JWTSecret} {
+		cfg := &config.Config{Auth: config.AuthConfig{JWTSecret: secret}}
+		_, err := NewSessionAuthMiddleware(&MockAPIKeyServiceForAuth{}, newStubSessionService(), cfg, zap.NewNop())
+		assert.Error(t, err, secret)
+	}
+}
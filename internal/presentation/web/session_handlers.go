@@ -0,0 +1,275 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/merchant"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// totpIssuer is the issuer shown in authenticator apps for dashboard accounts.
+const totpIssuer = "Crypto Checkout"
+
+// SessionHandlers handles dashboard login, session refresh and two-factor enrollment.
+type SessionHandlers struct {
+	sessionService merchant.SessionService
+	sessionAuth    *SessionAuthMiddleware
+	logger         *zap.Logger
+}
+
+// NewSessionHandlers creates a new session handlers instance.
+func NewSessionHandlers(
+	sessionService merchant.SessionService,
+	sessionAuth *SessionAuthMiddleware,
+	logger *zap.Logger,
+) *SessionHandlers {
+	return &SessionHandlers{
+		sessionService: sessionService,
+		sessionAuth:    sessionAuth,
+		logger:         logger,
+	}
+}
+
+// Login handles POST /auth/login
+// @Summary Log in to the dashboard
+// @Description Exchange email, password and, when enabled, a TOTP code for a session
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Param request body merchant.LoginRequest true "Credentials"
+// @Success 200 {object} SessionTokenResponse
+// @Failure 400 {object} map[string]interface{} "Invalid request format"
+// @Failure 401 {object} map[string]interface{} "Invalid credentials or TOTP code"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/auth/login [post]
+func (h *SessionHandlers) Login(c *gin.Context) {
+	var req merchant.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind login request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	resp, err := h.sessionService.Login(c.Request.Context(), &req)
+	if err != nil {
+		h.respondError(c, "Failed to log in", err)
+		return
+	}
+
+	h.respondSession(c, resp)
+}
+
+// Refresh handles POST /auth/refresh
+// @Summary Refresh a session
+// @Description Rotate the refresh token and issue a new access token
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Param request body merchant.RefreshSessionRequest true "Refresh token"
+// @Success 200 {object} SessionTokenResponse
+// @Failure 400 {object} map[string]interface{} "Invalid request format"
+// @Failure 401 {object} map[string]interface{} "Invalid refresh token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/auth/refresh [post]
+func (h *SessionHandlers) Refresh(c *gin.Context) {
+	var req merchant.RefreshSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind refresh request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	resp, err := h.sessionService.Refresh(c.Request.Context(), &req)
+	if err != nil {
+		h.respondError(c, "Failed to refresh session", err)
+		return
+	}
+
+	h.respondSession(c, resp)
+}
+
+// Logout handles POST /auth/logout
+// @Summary Log out
+// @Description Revoke the session of the refresh token
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Param request body merchant.LogoutRequest true "Refresh token"
+// @Success 204 "Logged out"
+// @Failure 400 {object} map[string]interface{} "Invalid request format"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/auth/logout [post]
+func (h *SessionHandlers) Logout(c *gin.Context) {
+	var req merchant.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind logout request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := h.sessionService.Logout(c.Request.Context(), &req); err != nil {
+		h.respondError(c, "Failed to log out", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SetupTOTP handles POST /auth/totp/setup
+// @Summary Set up two-factor authentication
+// @Description Generate a TOTP secret to confirm with /auth/totp/enable
+// @Tags Sessions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} merchant.SetupTOTPResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 409 {object} map[string]interface{} "Two-factor authentication already enabled"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/auth/totp/setup [post]
+func (h *SessionHandlers) SetupTOTP(c *gin.Context) {
+	resp, err := h.sessionService.SetupTOTP(c.Request.Context(), &merchant.SetupTOTPRequest{
+		MerchantID: c.GetString("merchant_id"),
+		UserID:     c.GetString("user_id"),
+		Issuer:     totpIssuer,
+	})
+	if err != nil {
+		h.respondError(c, "Failed to set up two-factor authentication", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// EnableTOTP handles POST /auth/totp/enable
+// @Summary Enable two-factor authentication
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body merchant.EnableTOTPRequest true "TOTP code"
+// @Success 200 {object} map[string]interface{} "Two-factor authentication enabled"
+// @Failure 400 {object} map[string]interface{} "Invalid request format"
+// @Failure 401 {object} map[string]interface{} "Invalid TOTP code"
+// @Failure 409 {object} map[string]interface{} "TOTP not set up"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/auth/totp/enable [post]
+func (h *SessionHandlers) EnableTOTP(c *gin.Context) {
+	var req merchant.EnableTOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind enable TOTP request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	req.MerchantID = c.GetString("merchant_id")
+	req.UserID = c.GetString("user_id")
+
+	if err := h.sessionService.EnableTOTP(c.Request.Context(), &req); err != nil {
+		h.respondError(c, "Failed to enable two-factor authentication", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"two_factor_enabled": true})
+}
+
+// DisableTOTP handles POST /auth/totp/disable
+// @Summary Disable two-factor authentication
+// @Tags Sessions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body merchant.DisableTOTPRequest true "Password and TOTP code"
+// @Success 200 {object} map[string]interface{} "Two-factor authentication disabled"
+// @Failure 400 {object} map[string]interface{} "Invalid request format"
+// @Failure 401 {object} map[string]interface{} "Invalid credentials or TOTP code"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/auth/totp/disable [post]
+func (h *SessionHandlers) DisableTOTP(c *gin.Context) {
+	var req merchant.DisableTOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind disable TOTP request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	req.MerchantID = c.GetString("merchant_id")
+	req.UserID = c.GetString("user_id")
+
+	if err := h.sessionService.DisableTOTP(c.Request.Context(), &req); err != nil {
+		h.respondError(c, "Failed to disable two-factor authentication", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"two_factor_enabled": false})
+}
+
+// respondSession issues an access token for the session and writes the token response.
+func (h *SessionHandlers) respondSession(c *gin.Context, resp *merchant.SessionResponse) {
+	accessToken, expiresIn, err := h.sessionAuth.IssueAccessToken(resp.User)
+	if err != nil {
+		h.logger.Error("Failed to issue access token", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue access token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, SessionTokenResponse{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        expiresIn,
+		RefreshToken:     resp.RefreshToken,
+		RefreshExpiresAt: resp.RefreshExpiresAt,
+		User:             ToUserResponse(resp.User),
+	})
+}
+
+// respondError maps session domain errors to HTTP responses.
+func (h *SessionHandlers) respondError(c *gin.Context, message string, err error) {
+	status := http.StatusInternalServerError
+	code := ""
+
+	switch {
+	case errors.Is(err, merchant.ErrInvalidCredentials):
+		status, code = http.StatusUnauthorized, merchant.ErrCodeInvalidCredentials
+	case errors.Is(err, merchant.ErrTOTPRequired):
+		status, code = http.StatusUnauthorized, merchant.ErrCodeTOTPRequired
+	case errors.Is(err, merchant.ErrInvalidTOTPCode):
+		status, code = http.StatusUnauthorized, merchant.ErrCodeInvalidTOTPCode
+	case errors.Is(err, merchant.ErrRefreshTokenNotFound),
+		errors.Is(err, merchant.ErrRefreshTokenExpired),
+		errors.Is(err, merchant.ErrRefreshTokenReused),
+		errors.Is(err, merchant.ErrRefreshTokenRevoked):
+		status, code = http.StatusUnauthorized, merchant.ErrCodeInvalidRefreshToken
+	case errors.Is(err, merchant.ErrMerchantRequired):
+		status, code = http.StatusConflict, merchant.ErrCodeMerchantRequired
+	case errors.Is(err, merchant.ErrTOTPNotConfigured):
+		status, code = http.StatusConflict, merchant.ErrCodeInvalidTOTPCode
+	case errors.Is(err, merchant.ErrTOTPAlreadyEnabled):
+		status, code = http.StatusConflict, merchant.ErrCodeTOTPAlreadyEnabled
+	case errors.Is(err, merchant.ErrWeakPassword):
+		status, code = http.StatusBadRequest, merchant.ErrCodeWeakPassword
+	case errors.Is(err, merchant.ErrUserNotFound):
+		status, code = http.StatusNotFound, merchant.ErrCodeUserNotFound
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(status, gin.H{"error": err.Error(), "code": code})
+}
+
+// RegisterSessionRoutes registers dashboard session routes.
+func (h *SessionHandlers) RegisterSessionRoutes(r *gin.RouterGroup) {
+	auth := r.Group("/auth")
+	auth.POST("/login", h.Login)
+	auth.POST("/refresh", h.Refresh)
+	auth.POST("/logout", h.Logout)
+
+	totp := auth.Group("/totp")
+	totp.Use(h.sessionAuth.RequireSession())
+	totp.POST("/setup", h.SetupTOTP)
+	totp.POST("/enable", h.EnableTOTP)
+	totp.POST("/disable", h.DisableTOTP)
+}
@@ -0,0 +1,200 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/settlement"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// SettlementHandlers handles the settlements of paid invoices.
+type SettlementHandlers struct {
+	settlementService settlement.Service
+	timeZones         *merchant.ReportingTimeZones
+	logger            *zap.Logger
+}
+
+// NewSettlementHandlers creates a new settlement handlers instance. The time zones may be nil, in which case
+// dates are UTC days.
+func NewSettlementHandlers(
+	settlementService settlement.Service,
+	timeZones *merchant.ReportingTimeZones,
+	logger *zap.Logger,
+) *SettlementHandlers {
+	return &SettlementHandlers{
+		settlementService: settlementService,
+		timeZones:         timeZones,
+		logger:            logger,
+	}
+}
+
+// ListSettlements handles GET /settlements
+// @Summary List settlements
+// @Description List the merchant's settlements with totals for the filter. Dates are calendar days in the
+// @Description merchant's reporting time zone, UTC unless set in the merchant settings.
+// @Tags Settlements
+// @Produce json
+// @Security ApiKeyAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD) in the reporting time zone"
+// @Param end_date query string false "End date, inclusive (YYYY-MM-DD) in the reporting time zone"
+// @Param status query string false "Filter by status" Enums(pending, completed, failed, reversed)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Success 200 {object} ListSettlementsResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/settlements [get]
+func (h *SettlementHandlers) ListSettlements(c *gin.Context) {
+	var req ListSettlementsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Failed to bind list settlements request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	var cursor *shared.Cursor
+	if req.Cursor != "" {
+		decoded, err := shared.DecodeCursor(req.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+			return
+		}
+		cursor = decoded
+	}
+
+	location, err := h.reportingLocation(c, merchantID)
+	if err != nil {
+		h.respondError(c, err, "Failed to list settlements")
+		return
+	}
+	// The end date is inclusive, so the listing runs to the end of that day
+	from, to := merchant.DayRange(req.StartDate, req.EndDate, location)
+
+	resp, err := h.settlementService.ListSettlements(c.Request.Context(), &settlement.ListSettlementsRequest{
+		MerchantID: merchantID,
+		Status:     settlement.Status(req.Status),
+		From:       from,
+		To:         to,
+		Limit:      req.Limit,
+		Cursor:     cursor,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to list settlements")
+		return
+	}
+
+	settlements := make([]SettlementResponse, len(resp.Settlements))
+	for i, s := range resp.Settlements {
+		settlements[i] = ToSettlementResponse(s)
+	}
+
+	response := ListSettlementsResponse{
+		Settlements:       settlements,
+		Summary:           ToSettlementSummaryResponse(&resp.Summary),
+		ReportingTimeZone: location.String(),
+		Limit:             resp.Limit,
+	}
+	if resp.NextCursor != nil {
+		response.NextCursor = resp.NextCursor.Encode()
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetSettlement handles GET /settlements/:id
+// @Summary Get a settlement
+// @Description Retrieve a settlement with its fee breakdown, beneficiaries' shares and reversal, if any
+// @Tags Settlements
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Settlement ID"
+// @Success 200 {object} SettlementResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Settlement not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/settlements/{id} [get]
+func (h *SettlementHandlers) GetSettlement(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	s, err := h.settlementService.GetSettlement(c.Request.Context(), &settlement.GetSettlementRequest{
+		MerchantID:   merchantID,
+		SettlementID: c.Param("id"),
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to get settlement")
+		return
+	}
+
+	response := ToSettlementResponse(s)
+	if s.SplitOf() == "" {
+		splits, err := h.settlementService.GetSettlementSplits(
+			c.Request.Context(),
+			&settlement.GetSettlementRequest{MerchantID: merchantID, SettlementID: s.ID()},
+		)
+		if err != nil {
+			h.respondError(c, err, "Failed to get settlement splits")
+			return
+		}
+		response.Splits = ToSettlementSplitResponses(splits)
+	}
+	if s.Status() == settlement.StatusReversed {
+		reversal, err := h.settlementService.GetSettlementReversal(
+			c.Request.Context(),
+			&settlement.GetSettlementRequest{MerchantID: merchantID, SettlementID: s.ID()},
+		)
+		if err != nil {
+			h.respondError(c, err, "Failed to get settlement reversal")
+			return
+		}
+		response.Reversal = ToSettlementReversalResponse(reversal)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// reportingLocation returns the time zone whose calendar days the merchant's dates are in.
+func (h *SettlementHandlers) reportingLocation(c *gin.Context, merchantID string) (*time.Location, error) {
+	if h.timeZones == nil {
+		return time.UTC, nil
+	}
+	return h.timeZones.Location(c.Request.Context(), merchantID)
+}
+
+// respondError maps settlement domain errors to HTTP responses.
+func (h *SettlementHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, settlement.ErrSettlementNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", settlement.ErrCodeSettlementNotFound, "Settlement not found"))
+	case errors.Is(err, settlement.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterSettlementRoutes registers settlement routes.
+func (h *SettlementHandlers) RegisterSettlementRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionSettlementsRead)
+
+	settlements := protected.Group("/settlements", require)
+	settlements.GET("", h.ListSettlements)
+	settlements.GET("/:id", h.GetSettlement)
+}
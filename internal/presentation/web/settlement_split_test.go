@@ -0,0 +1,103 @@
+package web_test
+
+import (
+	"bytes"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestInvoiceSettlementSplit(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	bundle, err := i18n.NewBundle()
+	require.NoError(t, err)
+	router := web.NewGinEngine(&config.Config{}, zap.NewNop(), bundle, nil)
+	handler := web.CreateTestHandler()
+	handler.RegisterRoutes(router)
+
+	request := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	create := func(split []web.SplitShareRequest, draft bool) *httptest.ResponseRecorder {
+		return request(http.MethodPost, "/api/v1/invoices", web.CreateInvoiceRequest{
+			Title:           "Marketplace order",
+			Items:           []web.InvoiceItemRequest{{Name: "Lamp", Quantity: "1", UnitPrice: "200.00"}},
+			TaxRate:         "0.00",
+			SettlementSplit: split,
+			Draft:           draft,
+		})
+	}
+
+	t.Run("KeptOnInvoice", func(t *testing.T) {
+		w := create([]web.SplitShareRequest{
+			{BeneficiaryID: "seller-1", Type: "percentage", Value: "80"},
+			{BeneficiaryID: "courier-1", Type: "fixed", Value: "10.00"},
+		}, false)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var created web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		assert.Equal(t, []web.SplitShareResponse{
+			{BeneficiaryID: "seller-1", Type: "percentage", Value: "80"},
+			{BeneficiaryID: "courier-1", Type: "fixed", Value: "10"},
+		}, created.SettlementSplit)
+	})
+
+	t.Run("ValidatedAtCreation", func(t *testing.T) {
+		for name, split := range map[string][]web.SplitShareRequest{
+			"whole invoice": {
+				{BeneficiaryID: "seller-1", Type: "percentage", Value: "90"},
+				{BeneficiaryID: "courier-1", Type: "fixed", Value: "20"},
+			},
+			"duplicate beneficiary": {
+				{BeneficiaryID: "seller-1", Type: "percentage", Value: "10"},
+				{BeneficiaryID: "seller-1", Type: "percentage", Value: "20"},
+			},
+			"unknown type":   {{BeneficiaryID: "seller-1", Type: "ratio", Value: "0.5"}},
+			"negative value": {{BeneficiaryID: "seller-1", Type: "fixed", Value: "-5"}},
+		} {
+			w := create(split, false)
+			assert.Equal(t, http.StatusBadRequest, w.Code, name+": "+w.Body.String())
+		}
+	})
+
+	t.Run("ReplacedOnDrafts", func(t *testing.T) {
+		w := create([]web.SplitShareRequest{{BeneficiaryID: "seller-1", Type: "percentage", Value: "80"}}, true)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var draft web.CreateInvoiceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &draft))
+
+		w = request(http.MethodPatch, "/api/v1/invoices/"+draft.ID, web.InvoiceChangesRequest{
+			Items: []web.InvoiceItemRequest{{Name: "Lamp", Quantity: "1", UnitPrice: "5.00"}},
+			SettlementSplit: []web.SplitShareRequest{
+				{BeneficiaryID: "seller-1", Type: "fixed", Value: "10.00"},
+			},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, "a fixed share above the total: "+w.Body.String())
+
+		w = request(http.MethodPatch, "/api/v1/invoices/"+draft.ID, json.RawMessage(`{"settlement_split": []}`))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		draft = web.CreateInvoiceResponse{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &draft))
+		assert.Empty(t, draft.SettlementSplit)
+	})
+}
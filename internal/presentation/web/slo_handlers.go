@@ -0,0 +1,50 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/slo"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// SLOHandlers handles the report of the service level objectives.
+type SLOHandlers struct {
+	sloService slo.Service
+	logger     *zap.Logger
+}
+
+// NewSLOHandlers creates a new SLO handlers instance.
+func NewSLOHandlers(sloService slo.Service, logger *zap.Logger) *SLOHandlers {
+	return &SLOHandlers{
+		sloService: sloService,
+		logger:     logger,
+	}
+}
+
+// GetSLOs handles GET /admin/slo
+// @Summary Report the service level objectives
+// @Description Report each service level objective over its 30-day window: the good and total events, the
+// @Description compliance, the error budget left and how fast it burned over the last hour and six hours. A
+// @Description budget is at risk when it burns 14.4 times faster than it lasts over an hour or 6 times over six
+// @Description hours, or is spent; an slo.budget_at_risk event is published when it comes at risk. Events are
+// @Description counted by the instance answering, since it started.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} SLOReportResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid admin key"
+// @Failure 403 {object} ErrorResponse "Admin operations permission required"
+// @Router /api/v1/admin/slo [get]
+func (h *SLOHandlers) GetSLOs(c *gin.Context) {
+	c.JSON(http.StatusOK, ToSLOReportResponse(h.sloService.Report(c.Request.Context())))
+}
+
+// RegisterSLORoutes registers the service level objective routes under /admin/slo.
+func (h *SLOHandlers) RegisterSLORoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionAdminOperations)
+
+	protected.GET("/admin/slo", require, h.GetSLOs)
+}
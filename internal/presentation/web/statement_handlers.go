@@ -0,0 +1,190 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/statement"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// StatementHandlers handles the monthly statements of merchants.
+type StatementHandlers struct {
+	statementService statement.Service
+	logger           *zap.Logger
+}
+
+// NewStatementHandlers creates a new statement handlers instance.
+func NewStatementHandlers(statementService statement.Service, logger *zap.Logger) *StatementHandlers {
+	return &StatementHandlers{
+		statementService: statementService,
+		logger:           logger,
+	}
+}
+
+// ListStatements handles GET /statements
+// @Summary List statements
+// @Description List the merchant's monthly statements, latest month first. Each statement sums the month's gross
+// @Description volume, platform fees, refunds and net settlements by currency and counts the invoices created in
+// @Description it by status.
+// @Tags Statements
+// @Produce json
+// @Security ApiKeyAuth
+// @Param limit query int false "Number of statements" default(12) minimum(1) maximum(100)
+// @Success 200 {object} ListStatementsResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/statements [get]
+func (h *StatementHandlers) ListStatements(c *gin.Context) {
+	var req ListStatementsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	statements, err := h.statementService.ListStatements(c.Request.Context(), merchantID, req.Limit)
+	if err != nil {
+		h.respondError(c, err, "Failed to list statements")
+		return
+	}
+
+	resp := ListStatementsResponse{Statements: make([]StatementResponse, len(statements))}
+	for i, stmt := range statements {
+		resp.Statements[i] = ToStatementResponse(stmt)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GenerateStatement handles POST /statements
+// @Summary Generate a statement
+// @Description Generate the statement of a month that is over, such as one before statements were enabled. A
+// @Description month that already has a statement returns it unchanged.
+// @Tags Statements
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body GenerateStatementRequest true "Month of the statement"
+// @Success 200 {object} StatementResponse
+// @Failure 400 {object} ErrorResponse "Invalid month, or a month that is not over"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/statements [post]
+func (h *StatementHandlers) GenerateStatement(c *gin.Context) {
+	var req GenerateStatementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+	periodStart, err := statement.ParsePeriod(req.Period)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", statement.ErrCodeInvalidRequest, "period must be a month such as 2026-09"))
+		return
+	}
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	stmt, err := h.statementService.Generate(c.Request.Context(), merchantID, periodStart)
+	if err != nil {
+		h.respondError(c, err, "Failed to generate statement")
+		return
+	}
+	c.JSON(http.StatusOK, ToStatementResponse(stmt))
+}
+
+// GetStatement handles GET /statements/:id
+// @Summary Get a statement
+// @Tags Statements
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Statement ID"
+// @Success 200 {object} StatementResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Statement not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/statements/{id} [get]
+func (h *StatementHandlers) GetStatement(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	stmt, err := h.statementService.GetStatement(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to get statement")
+		return
+	}
+	c.JSON(http.StatusOK, ToStatementResponse(stmt))
+}
+
+// DownloadStatement handles GET /statements/:id/download
+// @Summary Download a statement
+// @Description Download a statement as a PDF document or as CSV, with a row per currency followed by a row per
+// @Description invoice status.
+// @Tags Statements
+// @Produce application/pdf,text/csv
+// @Security ApiKeyAuth
+// @Param id path string true "Statement ID"
+// @Param format query string false "File format" Enums(pdf, csv) default(pdf)
+// @Success 200 {file} file "Statement"
+// @Failure 400 {object} ErrorResponse "Unsupported format"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Statement not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/statements/{id}/download [get]
+func (h *StatementHandlers) DownloadStatement(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	format := statement.Format(c.DefaultQuery("format", string(statement.FormatPDF)))
+	file, err := h.statementService.Download(c.Request.Context(), merchantID, c.Param("id"), format)
+	if err != nil {
+		h.respondError(c, err, "Failed to download statement")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, file.Name))
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
+
+// respondError maps statement errors to HTTP responses.
+func (h *StatementHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, statement.ErrStatementNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", statement.ErrCodeStatementNotFound, "Statement not found"))
+	case errors.Is(err, statement.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", statement.ErrCodeInvalidRequest, err.Error()))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterStatementRoutes registers the statement routes.
+func (h *StatementHandlers) RegisterStatementRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	require := rbac.RequireRolePermission(merchant.PermissionSettlementsRead)
+
+	statements := protected.Group("/statements", require)
+	statements.GET("", h.ListStatements)
+	statements.POST("", rbac.AuditAction("statement.generate"), h.GenerateStatement)
+	statements.GET("/:id", h.GetStatement)
+	statements.GET("/:id/download", h.DownloadStatement)
+}
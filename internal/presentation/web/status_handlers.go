@@ -0,0 +1,78 @@
+package web
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/platform"
+	"crypto-checkout/internal/domain/shared"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Incidents provides the incidents operators have declared and not resolved yet.
+type Incidents interface {
+	// ActiveIncidents returns the incidents not resolved yet, newest first.
+	ActiveIncidents(ctx context.Context) ([]*platform.Incident, error)
+}
+
+// StatusHandlers handles the public status of the platform.
+type StatusHandlers struct {
+	platformService platform.Service
+	logger          *zap.Logger
+}
+
+// NewStatusHandlers creates a new status handlers instance.
+func NewStatusHandlers(platformService platform.Service, logger *zap.Logger) *StatusHandlers {
+	return &StatusHandlers{
+		platformService: platformService,
+		logger:          logger,
+	}
+}
+
+// GetStatus handles GET /api/v1/status
+// @Summary Get the system status
+// @Description Summarize how payment detection keeps up with each network the chain watcher follows, how merchants'
+// @Description webhook endpoints answered in the last hour, how fresh the exchange rates are and the active
+// @Description incidents. The status is degraded while a network is, a rate's latest quote failed or an incident
+// @Description is active. Needs no credentials.
+// @Tags System
+// @Produce json
+// @Success 200 {object} SystemStatusResponse
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/status [get]
+func (h *StatusHandlers) GetStatus(c *gin.Context) {
+	status, err := h.platformService.GetStatus(shared.WithReplicaReads(c.Request.Context()))
+	if err != nil {
+		h.logger.Error("Failed to get system status", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get system status"})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, ToSystemStatusResponse(status))
+}
+
+// RegisterStatusRoutes registers the public status route.
+func (h *StatusHandlers) RegisterStatusRoutes(r gin.IRoutes) {
+	r.GET("/api/v1/status", h.GetStatus)
+}
+
+// checkoutIncident returns the active incident affecting payments on the network, or nil. Checkout pages are
+// served without it when incidents cannot be read.
+func (h *Handler) checkoutIncident(c *gin.Context, network shared.BlockchainNetwork) *platform.Incident {
+	if h.incidents == nil {
+		return nil
+	}
+	incidents, err := h.incidents.ActiveIncidents(c.Request.Context())
+	if err != nil {
+		h.Logger.Warn("Failed to get active incidents", zap.Error(err))
+		return nil
+	}
+	for _, incident := range incidents {
+		if incident.Affects(network) {
+			return incident
+		}
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/domain/platform"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSystemStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	checkedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	failedAt := checkedAt.Add(-time.Minute)
+	incident, err := platform.NewIncident("incident-1", "Delayed Ethereum payments", "Our node is syncing.",
+		platform.SeverityMinor, []shared.BlockchainNetwork{shared.NetworkEthereum}, "admin")
+	require.NoError(t, err)
+	service := &stubPlatformService{status: &platform.Status{
+		Condition: platform.ConditionDegraded,
+		Networks: []platform.NetworkStatus{
+			{
+				Network:   shared.NetworkEthereum,
+				Condition: platform.ConditionDegraded,
+				Head:      1000,
+				Cursor:    900,
+				Lag:       100,
+				CheckedAt: checkedAt,
+				Error:     "dial tcp 10.0.0.7:8545: connection refused",
+			},
+			{Network: shared.NetworkTron, Condition: platform.ConditionOperational},
+		},
+		Webhooks: platform.WebhookActivity{Window: platform.WebhookWindow, Sent: 40, Failed: 2, FailingEndpoints: 1},
+		Rates: []platform.RateQuote{{
+			From:     shared.CurrencyUSD,
+			To:       shared.CryptoCurrencyUSDT,
+			Source:   "fixed",
+			QuotedAt: checkedAt.Add(-5 * time.Minute),
+			FailedAt: &failedAt,
+			Error:    "provider unavailable",
+		}},
+		Incidents: []*platform.Incident{incident},
+		CheckedAt: checkedAt,
+	}}
+
+	router := gin.New()
+	web.NewStatusHandlers(service, zap.NewNop()).RegisterStatusRoutes(router)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/status", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.NotContains(t, w.Body.String(), "10.0.0.7", "node errors are not published")
+	assert.NotContains(t, w.Body.String(), "created_by")
+
+	var status web.SystemStatusResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	assert.Equal(t, "degraded", status.Status)
+	require.Len(t, status.Networks, 2)
+	assert.Equal(t, "ethereum", status.Networks[0].Network)
+	assert.Equal(t, "degraded", status.Networks[0].Status)
+	assert.Equal(t, int64(100), status.Networks[0].Lag)
+	require.NotNil(t, status.Networks[0].CheckedAt)
+	assert.Nil(t, status.Networks[1].CheckedAt)
+	assert.Equal(t, web.WebhookActivityResponse{WindowSeconds: 3600, Sent: 40, Failed: 2, FailingEndpoints: 1},
+		status.Webhooks)
+	require.Len(t, status.Rates, 1)
+	assert.True(t, status.Rates[0].Stale)
+	assert.Equal(t, int64(300), status.Rates[0].AgeSeconds)
+	require.Len(t, status.Incidents, 1)
+	assert.Equal(t, "Delayed Ethereum payments", status.Incidents[0].Title)
+	assert.Equal(t, []string{"ethereum"}, status.Incidents[0].Networks)
+}
+
+func TestAdminIncidents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service := &stubPlatformService{}
+	router := gin.New()
+	web.NewAdminHandlers(service, zap.NewNop()).RegisterAdminRoutes(router.Group("/api/v1"), nil)
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodPost, "/api/v1/admin/incidents",
+		`{"title":"Delayed Tron payments","severity":"major","networks":["tron"]}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var declared web.AdminIncidentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &declared))
+	assert.Equal(t, "major", declared.Severity)
+	assert.Equal(t, []string{"tron"}, declared.Networks)
+	assert.Nil(t, declared.ResolvedAt)
+
+	assert.Equal(t, http.StatusBadRequest,
+		do(http.MethodPost, "/api/v1/admin/incidents", `{"title":"Outage","severity":"critical"}`).Code)
+	w = do(http.MethodPost, "/api/v1/admin/incidents", `{"title":"Outage","severity":"minor","networks":["dogecoin"]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unknown network")
+
+	w = do(http.MethodPost, "/api/v1/admin/incidents/"+declared.ID+"/resolve", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resolved web.AdminIncidentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resolved))
+	assert.NotNil(t, resolved.ResolvedAt)
+
+	w = do(http.MethodPost, "/api/v1/admin/incidents/"+declared.ID+"/resolve", "")
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), platform.ErrCodeIncidentResolved)
+	w = do(http.MethodPost, "/api/v1/admin/incidents/missing/resolve", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), platform.ErrCodeIncidentNotFound)
+
+	var list web.ListIncidentsResponse
+	w = do(http.MethodGet, "/api/v1/admin/incidents", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Incidents, 1)
+	w = do(http.MethodGet, "/api/v1/admin/incidents?active=true", "")
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Empty(t, list.Incidents)
+}
+
+func TestCheckoutPage_IncidentBanner(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bundle, err := i18n.NewBundle()
+	require.NoError(t, err)
+	router := web.NewGinEngine(&config.Config{}, zap.NewNop(), bundle, nil)
+
+	service := &stubPlatformService{}
+	handler := web.CreateTestHandler()
+	handler.SetIncidents(service)
+	handler.RegisterRoutes(router)
+
+	requestBody, err := json.Marshal(web.CreateInvoiceRequest{
+		Title:   "Banner Invoice",
+		Items:   []web.InvoiceItemRequest{{Name: "Widget", Quantity: "1", UnitPrice: "10.00"}},
+		TaxRate: "0.00",
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/invoices", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer sk_live_test123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created web.CreateInvoiceResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	page := func() string {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/invoice/"+created.ID, nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		return w.Body.String()
+	}
+	declare := func(title string, networks ...shared.BlockchainNetwork) *platform.Incident {
+		incident, err := service.DeclareIncident(context.Background(), &platform.DeclareIncidentRequest{
+			Title:    title,
+			Severity: platform.SeverityMinor,
+			Networks: networks,
+		})
+		require.NoError(t, err)
+		return incident
+	}
+
+	assert.NotContains(t, page(), "incident-banner")
+
+	declare("Delayed Ethereum payments", shared.NetworkEthereum)
+	assert.NotContains(t, page(), "incident-banner", "incidents on other networks are not shown")
+
+	tron := declare("Delayed Tron payments", shared.NetworkTron)
+	body := page()
+	assert.Contains(t, body, "incident-banner")
+	assert.Contains(t, body, "Delayed Tron payments")
+	assert.Contains(t, body, "Your payment is safe")
+
+	_, err = service.ResolveIncident(context.Background(), tron.ID())
+	require.NoError(t, err)
+	assert.NotContains(t, page(), "incident-banner")
+}
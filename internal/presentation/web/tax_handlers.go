@@ -0,0 +1,290 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/tax"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TaxHandlers handles merchant tax rule management.
+type TaxHandlers struct {
+	taxService tax.Service
+	logger     *zap.Logger
+}
+
+// NewTaxHandlers creates a new tax handlers instance.
+func NewTaxHandlers(taxService tax.Service, logger *zap.Logger) *TaxHandlers {
+	return &TaxHandlers{
+		taxService: taxService,
+		logger:     logger,
+	}
+}
+
+// CreateRule handles POST /tax/rules
+// @Summary Create a tax rule
+// @Tags Tax
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body CreateTaxRuleRequest true "Tax rule"
+// @Success 201 {object} TaxRuleResponse "Tax rule created"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 409 {object} ErrorResponse "Tax rule already exists"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/tax/rules [post]
+func (h *TaxHandlers) CreateRule(c *gin.Context) {
+	var req CreateTaxRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind create tax rule request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	rule, err := h.taxService.CreateRule(c.Request.Context(), &tax.CreateRuleRequest{
+		MerchantID:    merchantID,
+		Jurisdiction:  req.Jurisdiction,
+		Category:      req.Category,
+		Name:          req.Name,
+		Type:          invoice.TaxType(req.Type),
+		Rate:          req.Rate,
+		ReverseCharge: req.ReverseCharge,
+	})
+	if err != nil {
+		respondTaxError(c, h.logger, err, "Failed to create tax rule")
+		return
+	}
+
+	setAuditChange(c, nil, taxRuleAuditState(rule))
+	c.JSON(http.StatusCreated, ToTaxRuleResponse(rule))
+}
+
+// ListRules handles GET /tax/rules
+// @Summary List tax rules
+// @Tags Tax
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} ListTaxRulesResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/tax/rules [get]
+func (h *TaxHandlers) ListRules(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	rules, err := h.taxService.ListRules(c.Request.Context(), merchantID)
+	if err != nil {
+		respondTaxError(c, h.logger, err, "Failed to list tax rules")
+		return
+	}
+
+	response := ListTaxRulesResponse{Rules: make([]TaxRuleResponse, len(rules))}
+	for i, rule := range rules {
+		response.Rules[i] = ToTaxRuleResponse(rule)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetRule handles GET /tax/rules/:id
+// @Summary Get a tax rule
+// @Tags Tax
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Tax rule ID"
+// @Success 200 {object} TaxRuleResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Tax rule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/tax/rules/{id} [get]
+func (h *TaxHandlers) GetRule(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	rule, err := h.taxService.GetRule(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondTaxError(c, h.logger, err, "Failed to get tax rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToTaxRuleResponse(rule))
+}
+
+// UpdateRule handles PUT /tax/rules/:id
+// @Summary Update a tax rule
+// @Tags Tax
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Tax rule ID"
+// @Param request body UpdateTaxRuleRequest true "Tax rule changes"
+// @Success 200 {object} TaxRuleResponse
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Tax rule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/tax/rules/{id} [put]
+func (h *TaxHandlers) UpdateRule(c *gin.Context) {
+	var req UpdateTaxRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind update tax rule request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	before, err := h.taxService.GetRule(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondTaxError(c, h.logger, err, "Failed to update tax rule")
+		return
+	}
+	beforeState := taxRuleAuditState(before)
+
+	rule, err := h.taxService.UpdateRule(c.Request.Context(), &tax.UpdateRuleRequest{
+		MerchantID:    merchantID,
+		RuleID:        c.Param("id"),
+		Name:          req.Name,
+		Rate:          req.Rate,
+		ReverseCharge: req.ReverseCharge,
+	})
+	if err != nil {
+		respondTaxError(c, h.logger, err, "Failed to update tax rule")
+		return
+	}
+
+	setAuditChange(c, beforeState, taxRuleAuditState(rule))
+	c.JSON(http.StatusOK, ToTaxRuleResponse(rule))
+}
+
+// DeleteRule handles DELETE /tax/rules/:id
+// @Summary Delete a tax rule
+// @Tags Tax
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Tax rule ID"
+// @Success 204 "Tax rule deleted"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Tax rule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/tax/rules/{id} [delete]
+func (h *TaxHandlers) DeleteRule(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	rule, err := h.taxService.GetRule(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondTaxError(c, h.logger, err, "Failed to delete tax rule")
+		return
+	}
+
+	if err := h.taxService.DeleteRule(c.Request.Context(), merchantID, rule.ID()); err != nil {
+		respondTaxError(c, h.logger, err, "Failed to delete tax rule")
+		return
+	}
+
+	setAuditChange(c, taxRuleAuditState(rule), nil)
+	c.Status(http.StatusNoContent)
+}
+
+// RegisterTaxRoutes registers tax rule management routes.
+func (h *TaxHandlers) RegisterTaxRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	rules := protected.Group("/tax/rules", rbac.RequireRolePermission(merchant.PermissionTaxManage))
+	rules.POST("", rbac.AuditAction("tax_rule.create"), h.CreateRule)
+	rules.GET("", h.ListRules)
+	rules.GET("/:id", h.GetRule)
+	rules.PUT("/:id", rbac.AuditAction("tax_rule.update"), h.UpdateRule)
+	rules.DELETE("/:id", rbac.AuditAction("tax_rule.delete"), h.DeleteRule)
+}
+
+// taxRuleAuditState returns the audited fields of a tax rule.
+func taxRuleAuditState(rule *tax.Rule) map[string]interface{} {
+	return map[string]interface{}{
+		"jurisdiction":   rule.Jurisdiction(),
+		"category":       rule.Category(),
+		"name":           rule.Name(),
+		"rate":           rule.Rate(),
+		"reverse_charge": rule.IsReverseCharge(),
+	}
+}
+
+// resolveCreateInvoiceTax resolves the merchant's taxes for the jurisdiction of a create invoice
+// request and adds them to the service request. On failure it responds to the client and returns the error.
+func (h *Handler) resolveCreateInvoiceTax(
+	c *gin.Context,
+	req CreateInvoiceRequest,
+	serviceReq *invoice.CreateInvoiceRequest,
+) error {
+	if req.TaxJurisdiction == "" {
+		return nil
+	}
+
+	policy, err := h.resolveTaxPolicy(c, serviceReq.MerchantID, req.TaxJurisdiction, req.CustomerTaxID)
+	if err != nil {
+		return err
+	}
+
+	serviceReq.TaxPolicy = policy
+	return nil
+}
+
+// resolveTaxPolicy returns the merchant's taxes for a customer in the jurisdiction, responding on failure.
+func (h *Handler) resolveTaxPolicy(
+	c *gin.Context,
+	merchantID, jurisdiction, customerTaxID string,
+) (*invoice.TaxPolicy, error) {
+	if h.taxService == nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Tax jurisdictions are not available", nil))
+		return nil, tax.ErrInvalidRequest
+	}
+
+	policy, err := h.taxService.ResolvePolicy(c.Request.Context(), merchantID, jurisdiction, customerTaxID)
+	if err != nil {
+		respondTaxError(c, h.Logger, err, "Failed to resolve invoice taxes")
+		return nil, err
+	}
+	return policy, nil
+}
+
+// respondTaxError maps tax rule errors to HTTP responses.
+func respondTaxError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, tax.ErrRuleNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", tax.ErrCodeRuleNotFound, "Tax rule not found"))
+	case errors.Is(err, tax.ErrRuleExists):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", tax.ErrCodeRuleExists, err.Error()))
+	case errors.Is(err, tax.ErrInvalidTaxID):
+		c.JSON(http.StatusBadRequest, createAuthErrorResponse(
+			"validation_error", tax.ErrCodeInvalidTaxID, err.Error()))
+	case errors.Is(err, tax.ErrInvalidRequest), errors.Is(err, invoice.ErrInvalidTax):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
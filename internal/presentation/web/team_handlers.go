@@ -0,0 +1,356 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/merchant"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TeamHandlers handles merchant team member and invitation HTTP requests.
+type TeamHandlers struct {
+	teamService merchant.TeamService
+	logger      *zap.Logger
+}
+
+// NewTeamHandlers creates a new team handlers instance.
+func NewTeamHandlers(teamService merchant.TeamService, logger *zap.Logger) *TeamHandlers {
+	return &TeamHandlers{
+		teamService: teamService,
+		logger:      logger,
+	}
+}
+
+// checkService checks if the service is initialized and returns an error response if not.
+func (h *TeamHandlers) checkService(c *gin.Context) bool {
+	if h.teamService == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Service not initialized"})
+		return false
+	}
+	return true
+}
+
+// InviteUser handles POST /merchants/:merchant_id/invitations
+// @Summary Invite a team member
+// @Description Invite a user by email; the invitation token is only returned here
+// @Tags Team
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param merchant_id path string true "Merchant ID"
+// @Param request body merchant.InviteUserRequest true "Invitation"
+// @Success 201 {object} InvitationResponse "Invitation created"
+// @Failure 400 {object} map[string]interface{} "Invalid request format"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Permission denied"
+// @Failure 409 {object} map[string]interface{} "User already exists"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/merchants/{merchant_id}/invitations [post]
+func (h *TeamHandlers) InviteUser(c *gin.Context) {
+	if !h.checkService(c) {
+		return
+	}
+
+	var req merchant.InviteUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind invite user request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	req.MerchantID = c.Param("merchant_id")
+	req.InvitedBy = c.GetString("user_id")
+
+	resp, err := h.teamService.InviteUser(c.Request.Context(), &req)
+	if err != nil {
+		h.respondError(c, "Failed to invite user", err)
+		return
+	}
+
+	invitation := ToInvitationResponse(resp.Invitation)
+	invitation.Token = resp.Token
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// ListInvitations handles GET /merchants/:merchant_id/invitations
+// @Summary List invitations
+// @Tags Team
+// @Produce json
+// @Security BearerAuth
+// @Param merchant_id path string true "Merchant ID"
+// @Success 200 {object} ListInvitationsResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Permission denied"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/merchants/{merchant_id}/invitations [get]
+func (h *TeamHandlers) ListInvitations(c *gin.Context) {
+	if !h.checkService(c) {
+		return
+	}
+
+	resp, err := h.teamService.ListInvitations(c.Request.Context(), &merchant.ListInvitationsRequest{
+		MerchantID: c.Param("merchant_id"),
+	})
+	if err != nil {
+		h.respondError(c, "Failed to list invitations", err)
+		return
+	}
+
+	invitations := make([]InvitationResponse, len(resp.Invitations))
+	for i, invitation := range resp.Invitations {
+		invitations[i] = ToInvitationResponse(invitation)
+	}
+
+	c.JSON(http.StatusOK, ListInvitationsResponse{Invitations: invitations, Total: resp.Total})
+}
+
+// RevokeInvitation handles DELETE /merchants/:merchant_id/invitations/:id
+// @Summary Revoke an invitation
+// @Tags Team
+// @Produce json
+// @Security BearerAuth
+// @Param merchant_id path string true "Merchant ID"
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} InvitationResponse "Invitation revoked"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Permission denied"
+// @Failure 404 {object} map[string]interface{} "Invitation not found"
+// @Failure 409 {object} map[string]interface{} "Invitation is not pending"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/merchants/{merchant_id}/invitations/{id} [delete]
+func (h *TeamHandlers) RevokeInvitation(c *gin.Context) {
+	if !h.checkService(c) {
+		return
+	}
+
+	resp, err := h.teamService.RevokeInvitation(c.Request.Context(), &merchant.RevokeInvitationRequest{
+		MerchantID:   c.Param("merchant_id"),
+		InvitationID: c.Param("id"),
+		RevokedBy:    c.GetString("user_id"),
+	})
+	if err != nil {
+		h.respondError(c, "Failed to revoke invitation", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToInvitationResponse(resp.Invitation))
+}
+
+// AcceptInvitation handles POST /invitations/accept
+// @Summary Accept an invitation
+// @Description Create the invited user with a password
+// @Tags Team
+// @Accept json
+// @Produce json
+// @Param request body merchant.AcceptInvitationRequest true "Invitation token and password"
+// @Success 201 {object} UserResponse "User created"
+// @Failure 400 {object} map[string]interface{} "Invalid request format"
+// @Failure 404 {object} map[string]interface{} "Invitation not found"
+// @Failure 409 {object} map[string]interface{} "Invitation is not pending"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/invitations/accept [post]
+func (h *TeamHandlers) AcceptInvitation(c *gin.Context) {
+	if !h.checkService(c) {
+		return
+	}
+
+	var req merchant.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind accept invitation request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	resp, err := h.teamService.AcceptInvitation(c.Request.Context(), &req)
+	if err != nil {
+		h.respondError(c, "Failed to accept invitation", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToUserResponse(resp.User))
+}
+
+// ListUsers handles GET /merchants/:merchant_id/users
+// @Summary List team members
+// @Tags Team
+// @Produce json
+// @Security BearerAuth
+// @Param merchant_id path string true "Merchant ID"
+// @Success 200 {object} ListTeamUsersResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Permission denied"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/merchants/{merchant_id}/users [get]
+func (h *TeamHandlers) ListUsers(c *gin.Context) {
+	if !h.checkService(c) {
+		return
+	}
+
+	resp, err := h.teamService.ListUsers(c.Request.Context(), &merchant.ListUsersRequest{
+		MerchantID: c.Param("merchant_id"),
+	})
+	if err != nil {
+		h.respondError(c, "Failed to list users", err)
+		return
+	}
+
+	users := make([]UserResponse, len(resp.Users))
+	for i, user := range resp.Users {
+		users[i] = ToUserResponse(user)
+	}
+
+	c.JSON(http.StatusOK, ListTeamUsersResponse{Users: users, Total: resp.Total})
+}
+
+// GetUser handles GET /merchants/:merchant_id/users/:id
+// @Summary Get a team member
+// @Tags Team
+// @Produce json
+// @Security BearerAuth
+// @Param merchant_id path string true "Merchant ID"
+// @Param id path string true "User ID"
+// @Success 200 {object} UserResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Permission denied"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/merchants/{merchant_id}/users/{id} [get]
+func (h *TeamHandlers) GetUser(c *gin.Context) {
+	if !h.checkService(c) {
+		return
+	}
+
+	resp, err := h.teamService.GetUser(c.Request.Context(), &merchant.GetUserRequest{
+		MerchantID: c.Param("merchant_id"),
+		UserID:     c.Param("id"),
+	})
+	if err != nil {
+		h.respondError(c, "Failed to get user", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToUserResponse(resp.User))
+}
+
+// ChangeUserRole handles PUT /merchants/:merchant_id/users/:id/role
+// @Summary Change a team member's role
+// @Tags Team
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param merchant_id path string true "Merchant ID"
+// @Param id path string true "User ID"
+// @Param request body merchant.ChangeUserRoleRequest true "New role"
+// @Success 200 {object} UserResponse
+// @Failure 400 {object} map[string]interface{} "Invalid request format"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Permission denied"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 409 {object} map[string]interface{} "Cannot demote the last owner"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/merchants/{merchant_id}/users/{id}/role [put]
+func (h *TeamHandlers) ChangeUserRole(c *gin.Context) {
+	if !h.checkService(c) {
+		return
+	}
+
+	var req merchant.ChangeUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind change user role request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	req.MerchantID = c.Param("merchant_id")
+	req.UserID = c.Param("id")
+	req.ChangedBy = c.GetString("user_id")
+
+	resp, err := h.teamService.ChangeUserRole(c.Request.Context(), &req)
+	if err != nil {
+		h.respondError(c, "Failed to change user role", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToUserResponse(resp.User))
+}
+
+// RemoveUser handles DELETE /merchants/:merchant_id/users/:id
+// @Summary Remove a team member
+// @Description Remove the user and revoke their sessions
+// @Tags Team
+// @Produce json
+// @Security BearerAuth
+// @Param merchant_id path string true "Merchant ID"
+// @Param id path string true "User ID"
+// @Success 200 {object} merchant.RemoveUserResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Permission denied"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 409 {object} map[string]interface{} "Cannot remove the last owner"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/merchants/{merchant_id}/users/{id} [delete]
+func (h *TeamHandlers) RemoveUser(c *gin.Context) {
+	if !h.checkService(c) {
+		return
+	}
+
+	resp, err := h.teamService.RemoveUser(c.Request.Context(), &merchant.RemoveUserRequest{
+		MerchantID: c.Param("merchant_id"),
+		UserID:     c.Param("id"),
+		RemovedBy:  c.GetString("user_id"),
+	})
+	if err != nil {
+		h.respondError(c, "Failed to remove user", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// respondError maps team domain errors to HTTP responses.
+func (h *TeamHandlers) respondError(c *gin.Context, message string, err error) {
+	status := http.StatusInternalServerError
+	code := ""
+
+	switch {
+	case errors.Is(err, merchant.ErrUserNotFound):
+		status, code = http.StatusNotFound, merchant.ErrCodeUserNotFound
+	case errors.Is(err, merchant.ErrInvitationNotFound):
+		status, code = http.StatusNotFound, merchant.ErrCodeInvitationNotFound
+	case errors.Is(err, merchant.ErrPermissionDenied), errors.Is(err, merchant.ErrRoleNotAssignable):
+		status, code = http.StatusForbidden, merchant.ErrCodePermissionDenied
+	case errors.Is(err, merchant.ErrLastOwner):
+		status, code = http.StatusConflict, merchant.ErrCodeLastOwner
+	case errors.Is(err, merchant.ErrUserAlreadyExists):
+		status, code = http.StatusConflict, merchant.ErrCodeUserAlreadyExists
+	case errors.Is(err, merchant.ErrInvitationExpired):
+		status, code = http.StatusGone, merchant.ErrCodeInvitationExpired
+	case errors.Is(err, merchant.ErrInvitationNotPending):
+		status, code = http.StatusConflict, merchant.ErrCodeInvitationNotPending
+	case errors.Is(err, merchant.ErrInvalidRole):
+		status, code = http.StatusBadRequest, merchant.ErrCodeInvalidRole
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	c.JSON(status, gin.H{"error": err.Error(), "code": code})
+}
+
+// RegisterTeamRoutes registers team member and invitation routes.
+func (h *TeamHandlers) RegisterTeamRoutes(r *gin.RouterGroup, protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	// Invitation acceptance is authenticated by the invitation token itself
+	r.POST("/invitations/accept", h.AcceptInvitation)
+
+	team := protected.Group("/merchants/:merchant_id")
+	team.GET("/users", rbac.RequireRolePermission(merchant.PermissionTeamRead), h.ListUsers)
+	team.GET("/users/:id", rbac.RequireRolePermission(merchant.PermissionTeamRead), h.GetUser)
+	team.PUT("/users/:id/role", rbac.RequireRolePermission(merchant.PermissionTeamManage), h.ChangeUserRole)
+	team.DELETE("/users/:id", rbac.RequireRolePermission(merchant.PermissionTeamManage), h.RemoveUser)
+	team.GET("/invitations", rbac.RequireRolePermission(merchant.PermissionTeamRead), h.ListInvitations)
+	team.POST("/invitations", rbac.RequireRolePermission(merchant.PermissionTeamManage), h.InviteUser)
+	team.DELETE("/invitations/:id", rbac.RequireRolePermission(merchant.PermissionTeamManage), h.RevokeInvitation)
+}
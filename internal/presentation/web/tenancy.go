@@ -0,0 +1,41 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// placeholderMerchantID owns the invoices created while the placeholder authentication middleware,
+// which does not identify a merchant, is in use.
+const placeholderMerchantID = "test-merchant"
+
+// setMerchantScope records the authenticated merchant on the request. Handlers read it from the
+// "merchant_id" key, and repositories read it from the request context to restrict queries to the
+// merchant's own data, so another merchant's records are reported as not found.
+func setMerchantScope(c *gin.Context, merchantID string) {
+	c.Set("merchant_id", merchantID)
+	c.Request = c.Request.WithContext(shared.WithMerchantID(c.Request.Context(), merchantID))
+}
+
+// requestMerchantID returns the authenticated merchant, or the placeholder merchant if the
+// request was not authenticated as one.
+func requestMerchantID(c *gin.Context) string {
+	if merchantID := c.GetString("merchant_id"); merchantID != "" {
+		return merchantID
+	}
+	return placeholderMerchantID
+}
+
+// requireMerchant returns the authenticated merchant, responding 403 if the request was not authenticated as
+// one. Unlike requestMerchantID, it never falls back to the placeholder merchant.
+func requireMerchant(c *gin.Context) (string, bool) {
+	merchantID := c.GetString("merchant_id")
+	if merchantID == "" {
+		c.JSON(http.StatusForbidden, createAuthErrorResponse(
+			"authorization_error", "MERCHANT_SCOPE_REQUIRED", "The request requires a merchant scope"))
+		return "", false
+	}
+	return merchantID, true
+}
@@ -0,0 +1,107 @@
+package web
+
+import (
+	"bytes"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/pkg/config"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTenantIsolation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	sessions := newStubSessionService()
+	cfg := &config.Config{Auth: config.AuthConfig{JWTSecret: "tenancy-test-secret"}}
+	sessionAuth, err := NewSessionAuthMiddleware(&MockAPIKeyServiceForAuth{}, sessions, cfg, zap.NewNop())
+	require.NoError(t, err)
+	handler, _ := CreateTestHandlerWithServices()
+
+	router := gin.New()
+	router.Use(ErrorHandler(&config.Config{}, zap.NewNop()))
+	invoices := router.Group("/api/v1/invoices", sessionAuth.RequireAuth())
+	invoices.POST("", handler.CreateInvoice)
+	invoices.GET("", handler.ListInvoices)
+	invoices.GET("/:id", handler.GetInvoice)
+	router.GET("/api/v1/public/invoice/:id", handler.GetPublicInvoiceData)
+
+	tokenFor := func(userID, merchantID string) string {
+		user, err := merchant.NewUser(userID, merchantID, userID+"@example.com", userID, merchant.RoleOwner)
+		require.NoError(t, err)
+		sessions.users[user.ID()] = user
+		token, _, err := sessionAuth.IssueAccessToken(user)
+		require.NoError(t, err)
+		return token
+	}
+	owner := tokenFor("user_owner", "merchant_a")
+	intruder := tokenFor("user_intruder", "merchant_b")
+
+	request := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request(http.MethodPost, "/api/v1/invoices", owner,
+		`{"title":"Order","items":[{"name":"Item","quantity":"1","unit_price":"10.00"}],"tax_rate":"0.00"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created CreateInvoiceResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	t.Run("the owning merchant sees its invoice", func(t *testing.T) {
+		w := request(http.MethodGet, "/api/v1/invoices/"+created.ID, owner, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = request(http.MethodGet, "/api/v1/invoices", owner, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list ListInvoicesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		assert.Len(t, list.Invoices, 1)
+	})
+
+	t.Run("another merchant gets not found", func(t *testing.T) {
+		w := request(http.MethodGet, "/api/v1/invoices/"+created.ID, intruder, "")
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		w = request(http.MethodGet, "/api/v1/invoices", intruder, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list ListInvoicesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		assert.Empty(t, list.Invoices)
+	})
+
+	t.Run("the public checkout page is not scoped", func(t *testing.T) {
+		w := request(http.MethodGet, "/api/v1/public/invoice/"+created.ID, "", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+}
+
+func TestRequireMerchant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("merchant_id", "merchant_a")
+	merchantID, ok := requireMerchant(c)
+	assert.True(t, ok)
+	assert.Equal(t, "merchant_a", merchantID)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	_, ok = requireMerchant(c)
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "MERCHANT_SCOPE_REQUIRED")
+}
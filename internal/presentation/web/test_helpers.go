@@ -2,12 +2,25 @@ package web
 
 import (
 	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/automation"
+	"crypto-checkout/internal/domain/deposit"
 	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/invoicetemplate"
 	"crypto-checkout/internal/domain/merchant"
 	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/paymentlink"
+	"crypto-checkout/internal/domain/processor"
+	"crypto-checkout/internal/domain/settlement"
 	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/tax"
+	"crypto-checkout/internal/domain/webhook"
 	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/internal/infrastructure/rates"
+	"crypto-checkout/internal/infrastructure/webhooksender"
 	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
+	"net/http"
 
 	"go.uber.org/zap"
 )
@@ -107,36 +120,125 @@ func (m *MockAPIKeyService) ValidateAPIKey(
 
 // CreateTestHandler creates a test handler with real services for integration testing
 func CreateTestHandler() *Handler {
+	handler, _ := CreateTestHandlerWithTax()
+	return handler
+}
+
+// CreateTestHandlerWithTax creates a test handler together with the tax service that resolves
+// the taxes of its invoices, so tests can configure tax rules for "test-merchant".
+func CreateTestHandlerWithTax() (*Handler, tax.Service) {
+	handler, services := CreateTestHandlerWithServices()
+	return handler, services.Tax
+}
+
+// CreateTestHandlerWithConfig creates a test handler with all optional services enabled and the configuration.
+func CreateTestHandlerWithConfig(cfg *config.Config) (*Handler, *TestServices) {
+	handler, services := CreateTestHandlerWithServices()
+	handler.config = cfg
+	return handler, services
+}
+
+// TestServices exposes the optional services of a test handler so tests can set up merchant data.
+type TestServices struct {
+	Invoices         invoice.InvoiceService
+	Payments         payment.PaymentService
+	Tax              tax.Service
+	PaymentLinks     paymentlink.Service
+	InvoiceTemplates invoicetemplate.Service
+	AutomationRules  automation.Service
+	Deposits         deposit.Service
+	Processors       processor.Repository
+	Settlements      settlement.Service
+	Webhooks         webhook.Service
+	Team             merchant.TeamService
+}
+
+// CreateTestHandlerWithServices creates a test handler with all optional services enabled.
+func CreateTestHandlerWithServices() (*Handler, *TestServices) {
 	// Create real services with in-memory SQLite database
+	return CreateTestHandlerWithDatabase("sqlite://:memory:")
+}
+
+// CreateTestHandlerWithDatabase creates a test handler with all optional services enabled, backed by the database
+// at the URL. Tests that send concurrent requests use a SQLite file, since every connection to an in-memory
+// database sees a different, empty database.
+func CreateTestHandlerWithDatabase(databaseURL string) (*Handler, *TestServices) {
 	logger := zap.NewNop()
 
-	// Create in-memory database connection
 	db, err := database.NewConnection(config.DatabaseConfig{
-		URL: "sqlite://:memory:",
+		URL: databaseURL,
 	}, logger)
 	if err != nil {
 		panic("Failed to create test database: " + err.Error())
 	}
 
-	// Run migrations
+	// Run migrations, adding the webhook endpoints that Migrate leaves out
 	if err := db.Migrate(); err != nil {
 		panic("Failed to migrate test database: " + err.Error())
 	}
+	if err := db.DB.AutoMigrate(&database.WebhookEndpointModel{}); err != nil {
+		panic("Failed to migrate test database: " + err.Error())
+	}
 
 	// Create real repositories
-	invoiceRepo := database.NewInvoiceRepository(db.DB)
+	invoiceRepo := database.NewInvoiceRepository(db.DB, logger)
 	paymentRepo := database.NewPaymentRepository(db.DB)
 
 	// Create mock event bus for testing
 	mockEventBus := &mockEventBus{}
 
 	// Create real domain services
-	invoiceService := invoice.NewInvoiceService(invoiceRepo, mockEventBus, logger)
-	paymentService := payment.NewPaymentService(paymentRepo, mockEventBus, logger)
+	rateProvider := rates.NewFixedRateProvider("1.0", config.DefaultRateLockDuration)
+	invoiceService := invoice.NewInvoiceService(invoiceRepo, mockEventBus, nil, rateProvider,
+		invoice.DefaultRequotePolicy(), nil, nil, nil, nil, nil, nil, nil, nil, nil, logger)
+	paymentService := payment.NewPaymentService(paymentRepo, mockEventBus, nil, nil, logger)
 
 	// Create mock API key service for testing
 	mockAPIKeyService := &MockAPIKeyService{}
+	auditService := audit.NewService(database.NewAuditRepository(db.DB, logger), logger)
+
+	services := &TestServices{
+		Invoices: invoiceService,
+		Payments: paymentService,
+		Tax:      tax.NewService(database.NewTaxRuleRepository(db.DB, logger), logger),
+		PaymentLinks: paymentlink.NewService(
+			database.NewPaymentLinkRepository(db.DB, logger), invoiceService, logger,
+		),
+		InvoiceTemplates: invoicetemplate.NewService(
+			database.NewInvoiceTemplateRepository(db.DB, logger), invoiceService, logger,
+		),
+		AutomationRules: automation.NewService(
+			database.NewAutomationRuleRepository(db.DB, logger), invoiceService, paymentService, logger,
+		),
+		Deposits: deposit.NewService(
+			database.NewDepositRepository(db.DB, logger), invoiceService, paymentService, nil, nil,
+			auditService, mockEventBus, logger,
+		),
+		Processors: database.NewProcessorRepository(db.DB, logger),
+		Settlements: settlement.NewService(
+			database.NewSettlementRepository(db.DB, logger), invoiceService, nil, nil, settlement.Policy{},
+			mockEventBus, nil, logger,
+		),
+		Webhooks: webhook.NewService(
+			database.NewWebhookDeliveryRepository(db.DB, logger), database.NewWebhookEndpointRepository(db.DB, logger),
+			webhooksender.NewHTTPSender(http.DefaultClient), logger,
+		),
+		Team: merchant.NewTeamService(
+			database.NewUserRepository(db.DB, logger), database.NewInvitationRepository(db.DB, logger),
+			auditService, logger,
+		),
+	}
 
 	// Create real handler with real services
-	return NewHandler(invoiceService, paymentService, mockAPIKeyService, logger, &config.Config{}, nil)
+	handler := NewHandler(invoiceService, paymentService, mockAPIKeyService, logger, &config.Config{}, nil)
+	handler.SetTaxService(services.Tax)
+	handler.SetPaymentLinkService(services.PaymentLinks)
+
+	bundle, err := i18n.NewBundle()
+	if err != nil {
+		panic("Failed to load translations: " + err.Error())
+	}
+	handler.SetLocalization(bundle, nil)
+	handler.SetIdempotency(NewIdempotency())
+	return handler, services
 }
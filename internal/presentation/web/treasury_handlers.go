@@ -0,0 +1,382 @@
+package web
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/payout"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/domain/treasury"
+	"crypto-checkout/pkg/config"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// TreasuryHandlers handles platform treasury balances, sweeps and cold storage transfers. Only the
+// operators listed in the treasury configuration may use them.
+type TreasuryHandlers struct {
+	treasuryService treasury.Service
+	operators       map[string]bool
+	logger          *zap.Logger
+}
+
+// NewTreasuryHandlers creates a new treasury handlers instance.
+func NewTreasuryHandlers(treasuryService treasury.Service, cfg *config.Config, logger *zap.Logger) *TreasuryHandlers {
+	operators := make(map[string]bool, len(cfg.Treasury.Operators))
+	for _, operator := range cfg.Treasury.Operators {
+		operators[operator] = true
+	}
+
+	return &TreasuryHandlers{
+		treasuryService: treasuryService,
+		operators:       operators,
+		logger:          logger,
+	}
+}
+
+// ListBalances handles GET /admin/treasury/balances
+// @Summary List treasury balances
+// @Description List hot and cold wallet balances per currency
+// @Tags Treasury
+// @Produce json
+// @Security BearerAuth
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} ListTreasuryBalancesResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Treasury operator required"
+// @Failure 503 {object} ErrorResponse "Custody unavailable"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/treasury/balances [get]
+func (h *TreasuryHandlers) ListBalances(c *gin.Context) {
+	balances, err := h.treasuryService.ListBalances(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err, "Failed to list treasury balances")
+		return
+	}
+
+	response := ListTreasuryBalancesResponse{Balances: make([]TreasuryBalanceResponse, len(balances))}
+	for i, balance := range balances {
+		response.Balances[i] = ToTreasuryBalanceResponse(balance)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// ListSweeps handles GET /admin/treasury/sweeps
+// @Summary List sweeps
+// @Tags Treasury
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status" Enums(pending, broadcast, confirmed, failed, skipped)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} ListSweepsResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Treasury operator required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/treasury/sweeps [get]
+func (h *TreasuryHandlers) ListSweeps(c *gin.Context) {
+	var req ListSweepsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	var cursor *shared.Cursor
+	if req.Cursor != "" {
+		decoded, err := shared.DecodeCursor(req.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+			return
+		}
+		cursor = decoded
+	}
+
+	resp, err := h.treasuryService.ListSweeps(c.Request.Context(), &treasury.ListSweepsRequest{
+		Status: treasury.SweepStatus(req.Status),
+		Limit:  req.Limit,
+		Cursor: cursor,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to list sweeps")
+		return
+	}
+
+	response := ListSweepsResponse{
+		Sweeps: make([]SweepResponse, len(resp.Sweeps)),
+		Limit:  resp.Limit,
+	}
+	for i, sweep := range resp.Sweeps {
+		response.Sweeps[i] = ToSweepResponse(sweep)
+	}
+	if resp.NextCursor != nil {
+		response.NextCursor = resp.NextCursor.Encode()
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RequestColdTransfer handles POST /admin/treasury/cold-transfers
+// @Summary Request a cold storage transfer
+// @Description Request a hot-to-cold transfer; a second operator must approve it
+// @Tags Treasury
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateColdTransferRequest true "Cold storage transfer"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 201 {object} ColdTransferResponse "Transfer requested"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Treasury operator required"
+// @Failure 422 {object} ErrorResponse "Cold wallet missing or insufficient balance"
+// @Failure 503 {object} ErrorResponse "Custody unavailable"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/treasury/cold-transfers [post]
+func (h *TreasuryHandlers) RequestColdTransfer(c *gin.Context) {
+	var req CreateColdTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid amount", err))
+		return
+	}
+
+	transfer, err := h.treasuryService.RequestColdTransfer(c.Request.Context(), &treasury.ColdTransferRequest{
+		Currency:    req.Currency,
+		Amount:      amount,
+		Note:        req.Note,
+		RequestedBy: c.GetString("user_id"),
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to request cold storage transfer")
+		return
+	}
+
+	setAuditChange(c, nil, map[string]interface{}{
+		"transfer_id": transfer.ID(),
+		"currency":    transfer.Currency(),
+		"amount":      transfer.Amount().String(),
+		"to_address":  transfer.ToAddress(),
+	})
+	c.JSON(http.StatusCreated, ToColdTransferResponse(transfer))
+}
+
+// ListColdTransfers handles GET /admin/treasury/cold-transfers
+// @Summary List cold storage transfers
+// @Tags Treasury
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status" Enums(pending_approval, approved, rejected, broadcast, confirmed, failed)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} ListColdTransfersResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Treasury operator required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/treasury/cold-transfers [get]
+func (h *TreasuryHandlers) ListColdTransfers(c *gin.Context) {
+	var req ListColdTransfersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	var cursor *shared.Cursor
+	if req.Cursor != "" {
+		decoded, err := shared.DecodeCursor(req.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+			return
+		}
+		cursor = decoded
+	}
+
+	resp, err := h.treasuryService.ListColdTransfers(c.Request.Context(), &treasury.ListTransfersRequest{
+		Status: treasury.TransferStatus(req.Status),
+		Limit:  req.Limit,
+		Cursor: cursor,
+	})
+	if err != nil {
+		h.respondError(c, err, "Failed to list cold storage transfers")
+		return
+	}
+
+	response := ListColdTransfersResponse{
+		Transfers: make([]ColdTransferResponse, len(resp.Transfers)),
+		Limit:     resp.Limit,
+	}
+	for i, transfer := range resp.Transfers {
+		response.Transfers[i] = ToColdTransferResponse(transfer)
+	}
+	if resp.NextCursor != nil {
+		response.NextCursor = resp.NextCursor.Encode()
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetColdTransfer handles GET /admin/treasury/cold-transfers/:id
+// @Summary Get a cold storage transfer
+// @Tags Treasury
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Transfer ID"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} ColdTransferResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Treasury operator required"
+// @Failure 404 {object} ErrorResponse "Transfer not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/treasury/cold-transfers/{id} [get]
+func (h *TreasuryHandlers) GetColdTransfer(c *gin.Context) {
+	transfer, err := h.treasuryService.GetColdTransfer(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to get cold storage transfer")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToColdTransferResponse(transfer))
+}
+
+// ApproveColdTransfer handles POST /admin/treasury/cold-transfers/:id/approve
+// @Summary Approve a cold storage transfer
+// @Tags Treasury
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Transfer ID"
+// @Param request body ReviewColdTransferRequest false "Optional note"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} ColdTransferResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Treasury operator required or own request"
+// @Failure 404 {object} ErrorResponse "Transfer not found"
+// @Failure 409 {object} ErrorResponse "Transfer is not pending approval"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/treasury/cold-transfers/{id}/approve [post]
+func (h *TreasuryHandlers) ApproveColdTransfer(c *gin.Context) {
+	h.reviewColdTransfer(c, h.treasuryService.ApproveColdTransfer, "Failed to approve cold storage transfer")
+}
+
+// RejectColdTransfer handles POST /admin/treasury/cold-transfers/:id/reject
+// @Summary Reject a cold storage transfer
+// @Tags Treasury
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Transfer ID"
+// @Param request body ReviewColdTransferRequest false "Optional note"
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} ColdTransferResponse
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Treasury operator required or own request"
+// @Failure 404 {object} ErrorResponse "Transfer not found"
+// @Failure 409 {object} ErrorResponse "Transfer is not pending approval"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/admin/treasury/cold-transfers/{id}/reject [post]
+func (h *TreasuryHandlers) RejectColdTransfer(c *gin.Context) {
+	h.reviewColdTransfer(c, h.treasuryService.RejectColdTransfer, "Failed to reject cold storage transfer")
+}
+
+// reviewColdTransfer records the calling operator's decision on a cold storage transfer.
+func (h *TreasuryHandlers) reviewColdTransfer(
+	c *gin.Context,
+	review func(ctx context.Context, req *treasury.ReviewTransferRequest) (*treasury.ColdTransfer, error),
+	message string,
+) {
+	var req ReviewColdTransferRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+			return
+		}
+	}
+
+	transfer, err := review(c.Request.Context(), &treasury.ReviewTransferRequest{
+		TransferID: c.Param("id"),
+		OperatorID: c.GetString("user_id"),
+		Note:       req.Note,
+	})
+	if err != nil {
+		h.respondError(c, err, message)
+		return
+	}
+
+	setAuditChange(c,
+		map[string]interface{}{"status": string(treasury.TransferStatusPendingApproval)},
+		map[string]interface{}{"status": string(transfer.Status()), "requested_by": transfer.RequestedBy()})
+	c.JSON(http.StatusOK, ToColdTransferResponse(transfer))
+}
+
+// requireOperator rejects callers that are not treasury operators. API keys are never operators,
+// since dual approval depends on telling two people apart.
+func (h *TreasuryHandlers) requireOperator() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if userID := c.GetString("user_id"); userID == "" || !h.operators[userID] {
+			c.AbortWithStatusJSON(http.StatusForbidden, createAuthErrorResponse(
+				"authorization_error", "TREASURY_OPERATOR_REQUIRED", "Treasury operations require a treasury operator"))
+			return
+		}
+		c.Next()
+	}
+}
+
+// respondError maps treasury domain errors to HTTP responses.
+func (h *TreasuryHandlers) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, treasury.ErrTransferNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", treasury.ErrCodeTransferNotFound, "Cold storage transfer not found"))
+	case errors.Is(err, treasury.ErrSelfApproval):
+		c.JSON(http.StatusForbidden, createAuthErrorResponse(
+			"authorization_error", treasury.ErrCodeSelfApproval, err.Error()))
+	case errors.Is(err, treasury.ErrInvalidTransition):
+		c.JSON(http.StatusConflict, createAuthErrorResponse(
+			"conflict", treasury.ErrCodeInvalidTransition, err.Error()))
+	case errors.Is(err, treasury.ErrColdWalletMissing):
+		c.JSON(http.StatusUnprocessableEntity, createAuthErrorResponse(
+			"validation_error", treasury.ErrCodeColdWalletMissing, err.Error()))
+	case errors.Is(err, treasury.ErrInsufficientBalance):
+		c.JSON(http.StatusUnprocessableEntity, createAuthErrorResponse(
+			"validation_error", treasury.ErrCodeInsufficientBalance, err.Error()))
+	case errors.Is(err, treasury.ErrCustodyUnavailable):
+		c.JSON(http.StatusServiceUnavailable, createAuthErrorResponse(
+			"service_unavailable", treasury.ErrCodeTreasuryUnavailable, err.Error()))
+	case errors.Is(err, treasury.ErrInvalidRequest), errors.Is(err, treasury.ErrUnsupportedCurrency):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	case errors.Is(err, payout.ErrHotWalletFailure):
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{"error": message})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// RegisterTreasuryRoutes registers the treasury routes under /admin/treasury.
+func (h *TreasuryHandlers) RegisterTreasuryRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	treasuryGroup := protected.Group("/admin/treasury",
+		rbac.RequireRolePermission(merchant.PermissionAdminOperations), h.requireOperator())
+	treasuryGroup.GET("/balances", h.ListBalances)
+	treasuryGroup.GET("/sweeps", h.ListSweeps)
+
+	transfers := treasuryGroup.Group("/cold-transfers")
+	transfers.POST("", rbac.AuditAction("treasury.cold_transfer_requested"), h.RequestColdTransfer)
+	transfers.GET("", h.ListColdTransfers)
+	transfers.GET("/:id", h.GetColdTransfer)
+	transfers.POST("/:id/approve", rbac.AuditAction("treasury.cold_transfer_approved"), h.ApproveColdTransfer)
+	transfers.POST("/:id/reject", rbac.AuditAction("treasury.cold_transfer_rejected"), h.RejectColdTransfer)
+}
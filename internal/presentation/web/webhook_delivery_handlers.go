@@ -0,0 +1,397 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/webhook"
+	"crypto-checkout/pkg/config"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// WebhookDeliveryHandlers handles test webhooks, replays, the webhook delivery log and the addresses webhooks are
+// sent from.
+type WebhookDeliveryHandlers struct {
+	deliveryService webhook.Service
+	egressIPs       []string
+	logger          *zap.Logger
+}
+
+// NewWebhookDeliveryHandlers creates a new webhook delivery handlers instance, publishing the configured webhook
+// egress IPs as CIDR ranges.
+func NewWebhookDeliveryHandlers(
+	deliveryService webhook.Service,
+	cfg *config.Config,
+	logger *zap.Logger,
+) (*WebhookDeliveryHandlers, error) {
+	egressIPs := make([]string, 0, len(cfg.Webhooks.EgressIPs))
+	for i, raw := range cfg.Webhooks.EgressIPs {
+		prefix, err := netip.ParsePrefix(raw)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(raw)
+			if addrErr != nil {
+				return nil, fmt.Errorf("webhooks.egress_ips[%d]: %q is neither an IP address nor a CIDR range", i, raw)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		egressIPs = append(egressIPs, prefix.Masked().String())
+	}
+
+	return &WebhookDeliveryHandlers{
+		deliveryService: deliveryService,
+		egressIPs:       egressIPs,
+		logger:          logger,
+	}, nil
+}
+
+// GetEgressIPs handles GET /webhooks/egress-ips
+// @Summary List webhook egress IPs
+// @Description List the IP ranges webhooks are sent from, to allow through the firewall of webhook endpoints.
+// @Description Single addresses are listed as /32 or /128 ranges. An empty list means webhooks are not sent from
+// @Description fixed addresses.
+// @Tags Webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} WebhookEgressIPsResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Router /api/v1/webhooks/egress-ips [get]
+func (h *WebhookDeliveryHandlers) GetEgressIPs(c *gin.Context) {
+	c.Header("Cache-Control", "private, max-age=300")
+	c.JSON(http.StatusOK, WebhookEgressIPsResponse{IPRanges: h.egressIPs})
+}
+
+// TestWebhook handles POST /webhooks/:id/test
+// @Summary Send a test webhook
+// @Description Send a synthetic webhook.test event to a webhook endpoint, in its API version and signed with its
+// @Description secret like any webhook. The delivery is recorded in the delivery log with what the endpoint
+// @Description answered; an endpoint that rejects the webhook or cannot be reached makes a failed delivery.
+// @Tags Webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Webhook endpoint ID"
+// @Success 200 {object} WebhookDeliveryResponse "Webhook sent"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Webhook endpoint not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/webhooks/{id}/test [post]
+func (h *WebhookDeliveryHandlers) TestWebhook(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.deliveryService.TestEndpoint(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondWebhookDeliveryError(c, h.logger, err, "Failed to send test webhook")
+		return
+	}
+
+	setAuditChange(c, nil, webhookDeliveryAuditState(delivery))
+	c.JSON(http.StatusOK, ToWebhookDeliveryResponse(delivery))
+}
+
+// ReplayDelivery handles POST /webhook-deliveries/:id/replay
+// @Summary Replay a webhook delivery
+// @Description Send the payload of a recorded delivery again, with the same event ID, to its endpoint's current
+// @Description URL and signed with its current secret. The replay is recorded in the delivery log as a new
+// @Description delivery referring to the original.
+// @Tags Webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Webhook delivery ID"
+// @Success 200 {object} WebhookDeliveryResponse "Webhook sent again"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Webhook delivery or its endpoint not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/webhook-deliveries/{id}/replay [post]
+func (h *WebhookDeliveryHandlers) ReplayDelivery(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.deliveryService.ReplayDelivery(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondWebhookDeliveryError(c, h.logger, err, "Failed to replay webhook delivery")
+		return
+	}
+
+	setAuditChange(c, nil, webhookDeliveryAuditState(delivery))
+	c.JSON(http.StatusOK, ToWebhookDeliveryResponse(delivery))
+}
+
+// ListDeliveries handles GET /webhook-deliveries
+// @Summary List webhook deliveries
+// @Description List the webhooks sent to the merchant's endpoints, newest first, with what the endpoints answered.
+// @Tags Webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param endpoint_id query string false "Only deliveries to this webhook endpoint"
+// @Param limit query int false "Maximum number of deliveries (1-200)" default(50)
+// @Success 200 {object} ListWebhookDeliveriesResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/webhook-deliveries [get]
+func (h *WebhookDeliveryHandlers) ListDeliveries(c *gin.Context) {
+	var req ListWebhookDeliveriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.deliveryService.ListDeliveries(c.Request.Context(), &webhook.ListDeliveriesRequest{
+		MerchantID: merchantID,
+		EndpointID: req.EndpointID,
+		Limit:      req.Limit,
+	})
+	if err != nil {
+		respondWebhookDeliveryError(c, h.logger, err, "Failed to list webhook deliveries")
+		return
+	}
+
+	response := ListWebhookDeliveriesResponse{Deliveries: make([]WebhookDeliveryResponse, len(deliveries))}
+	for i, delivery := range deliveries {
+		response.Deliveries[i] = ToWebhookDeliveryResponse(delivery)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetDelivery handles GET /webhook-deliveries/:id
+// @Summary Get a webhook delivery
+// @Tags Webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Webhook delivery ID"
+// @Success 200 {object} WebhookDeliveryResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Webhook delivery not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/webhook-deliveries/{id} [get]
+func (h *WebhookDeliveryHandlers) GetDelivery(c *gin.Context) {
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.deliveryService.GetDelivery(c.Request.Context(), merchantID, c.Param("id"))
+	if err != nil {
+		respondWebhookDeliveryError(c, h.logger, err, "Failed to get webhook delivery")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToWebhookDeliveryResponse(delivery))
+}
+
+// RotateSecret handles POST /webhooks/:id/rotate-secret
+// @Summary Rotate a webhook secret
+// @Description Replace the secret signing the webhooks of an endpoint. For the overlap, webhooks are signed with
+// @Description the new and the previous secret, both signatures in the X-Webhook-Signature header separated by a
+// @Description comma, so that the receiver can switch to the new secret without rejecting webhooks in between.
+// @Description The new secret is generated unless given, and is returned.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Webhook endpoint ID"
+// @Param request body RotateWebhookSecretRequest false "New secret and overlap"
+// @Success 200 {object} WebhookEndpointResponse "Secret rotated"
+// @Failure 400 {object} ErrorResponse "Invalid secret or overlap"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Webhook endpoint not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/webhooks/{id}/rotate-secret [post]
+func (h *WebhookDeliveryHandlers) RotateSecret(c *gin.Context) {
+	var req RotateWebhookSecretRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+			return
+		}
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	overlap := merchant.DefaultSecretOverlap
+	if req.OverlapHours != nil {
+		overlap = time.Duration(*req.OverlapHours) * time.Hour
+	}
+	endpoint, err := h.deliveryService.RotateSecret(c.Request.Context(), &webhook.RotateSecretRequest{
+		MerchantID: merchantID,
+		EndpointID: c.Param("id"),
+		Secret:     req.Secret,
+		Overlap:    overlap,
+	})
+	if err != nil {
+		respondWebhookDeliveryError(c, h.logger, err, "Failed to rotate webhook secret")
+		return
+	}
+
+	response := ToWebhookEndpointResponse(endpoint)
+	setAuditChange(c, nil, map[string]interface{}{
+		"endpoint_id":                endpoint.ID(),
+		"previous_secret_expires_at": response.PreviousSecretExpiresAt,
+	})
+	c.JSON(http.StatusOK, response)
+}
+
+// DisableEndpoints handles POST /webhooks/disable
+// @Summary Disable webhook endpoints
+// @Description Disable up to 100 webhook endpoints at once. None is disabled unless all are found; endpoints
+// @Description already disabled stay so.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body DisableWebhookEndpointsRequest true "Endpoints to disable"
+// @Success 200 {object} DisableWebhookEndpointsResponse "Endpoints disabled"
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 404 {object} ErrorResponse "Webhook endpoint not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/webhooks/disable [post]
+func (h *WebhookDeliveryHandlers) DisableEndpoints(c *gin.Context) {
+	var req DisableWebhookEndpointsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid request body", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	endpoints, err := h.deliveryService.DisableEndpoints(c.Request.Context(), merchantID, req.EndpointIDs)
+	if err != nil {
+		respondWebhookDeliveryError(c, h.logger, err, "Failed to disable webhook endpoints")
+		return
+	}
+
+	response := DisableWebhookEndpointsResponse{Disabled: make([]string, len(endpoints))}
+	for i, endpoint := range endpoints {
+		response.Disabled[i] = endpoint.ID()
+	}
+	setAuditChange(c, nil, map[string]interface{}{"disabled": response.Disabled})
+	c.JSON(http.StatusOK, response)
+}
+
+// EndpointStats handles GET /webhooks/stats
+// @Summary Export webhook delivery statistics
+// @Description Summarize the deliveries to each of the merchant's webhook endpoints since a point in time, the
+// @Description last 30 days by default, as JSON or as CSV with a row per endpoint. Endpoints without deliveries
+// @Description are included.
+// @Tags Webhooks
+// @Produce json,text/csv
+// @Security ApiKeyAuth
+// @Param since query string false "Only deliveries since (RFC 3339)"
+// @Param format query string false "Response format" Enums(json, csv) default(json)
+// @Success 200 {object} ListWebhookEndpointStatsResponse
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Invalid API key"
+// @Failure 403 {object} ErrorResponse "Merchant scope required"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/webhooks/stats [get]
+func (h *WebhookDeliveryHandlers) EndpointStats(c *gin.Context) {
+	var req WebhookEndpointStatsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse("Invalid query parameters", err))
+		return
+	}
+
+	merchantID, ok := requireMerchant(c)
+	if !ok {
+		return
+	}
+
+	statsReq := &webhook.EndpointStatsRequest{MerchantID: merchantID}
+	if req.Since != nil {
+		statsReq.Since = *req.Since
+	}
+	stats, err := h.deliveryService.EndpointStats(c.Request.Context(), statsReq)
+	if err != nil {
+		respondWebhookDeliveryError(c, h.logger, err, "Failed to summarize webhook deliveries")
+		return
+	}
+
+	if req.Format == "csv" {
+		data, err := webhook.RenderEndpointStatsCSV(stats)
+		if err != nil {
+			respondWebhookDeliveryError(c, h.logger, err, "Failed to export webhook delivery statistics")
+			return
+		}
+		c.Header("Content-Disposition", `attachment; filename="webhook-stats.csv"`)
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+		return
+	}
+
+	response := ListWebhookEndpointStatsResponse{Endpoints: make([]WebhookEndpointStatsResponse, len(stats))}
+	for i := range stats {
+		response.Endpoints[i] = ToWebhookEndpointStatsResponse(&stats[i])
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// RegisterWebhookDeliveryRoutes registers the test webhook, secret rotation, bulk disable, delivery statistics,
+// egress IP, replay and delivery log routes. Any authenticated caller may list the egress IPs.
+func (h *WebhookDeliveryHandlers) RegisterWebhookDeliveryRoutes(protected *gin.RouterGroup, rbac *RBACMiddleware) {
+	manage := rbac.RequireRolePermission(merchant.PermissionWebhooksManage)
+
+	webhooks := protected.Group("/webhooks")
+	webhooks.POST("/:id/test", manage, rbac.AuditAction("webhook.test"), h.TestWebhook)
+	webhooks.POST("/:id/rotate-secret", manage, rbac.AuditAction("webhook.rotate_secret"), h.RotateSecret)
+	webhooks.POST("/disable", manage, rbac.AuditAction("webhook.disable"), h.DisableEndpoints)
+	webhooks.GET("/stats", manage, h.EndpointStats)
+	webhooks.GET("/egress-ips", h.GetEgressIPs)
+
+	deliveries := protected.Group("/webhook-deliveries", manage)
+	deliveries.GET("", h.ListDeliveries)
+	deliveries.GET("/:id", h.GetDelivery)
+	deliveries.POST("/:id/replay", rbac.AuditAction("webhook_delivery.replay"), h.ReplayDelivery)
+}
+
+// webhookDeliveryAuditState returns the audited fields of a webhook delivery, without its payload.
+func webhookDeliveryAuditState(delivery *webhook.Delivery) map[string]interface{} {
+	return map[string]interface{}{
+		"endpoint_id":   delivery.EndpointID(),
+		"event_id":      delivery.EventID(),
+		"replay_of":     delivery.ReplayOf(),
+		"status":        string(delivery.Status()),
+		"response_code": delivery.ResponseCode(),
+	}
+}
+
+// respondWebhookDeliveryError maps webhook delivery errors to HTTP responses.
+func respondWebhookDeliveryError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, webhook.ErrDeliveryNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", webhook.ErrCodeDeliveryNotFound, "Webhook delivery not found"))
+	case errors.Is(err, merchant.ErrWebhookEndpointNotFound):
+		c.JSON(http.StatusNotFound, createAuthErrorResponse(
+			"not_found", merchant.ErrCodeWebhookEndpointNotFound, "Webhook endpoint not found"))
+	case errors.Is(err, webhook.ErrInvalidRequest):
+		c.JSON(http.StatusBadRequest, createValidationErrorResponse(err.Error(), err))
+	default:
+		respondDomainError(c, logger, err, message)
+	}
+}
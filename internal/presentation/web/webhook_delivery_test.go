@@ -0,0 +1,231 @@
+package web_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/merchant"
+	"crypto-checkout/internal/domain/webhook"
+	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/internal/infrastructure/webhooksender"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/client"
+	"crypto-checkout/pkg/config"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestWebhookDeliveries(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	logger := zap.NewNop()
+	secret := "whsec_0123456789abcdef0123456789abcdef"
+
+	// The merchant's endpoint fails until it is fixed, and checks every signature
+	var fixed atomic.Bool
+	var received []client.WebhookEvent
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := client.VerifyWebhookSignature(secret, r.Header, body, 0); err != nil {
+			http.Error(w, "bad signature", http.StatusUnauthorized)
+			return
+		}
+		var event client.WebhookEvent
+		assert.NoError(t, json.Unmarshal(body, &event))
+		received = append(received, event)
+		if !fixed.Load() {
+			http.Error(w, "not yet", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("thanks"))
+	}))
+	defer receiver.Close()
+
+	conn, err := database.NewConnection(config.DatabaseConfig{URL: "sqlite://:memory:"}, logger)
+	require.NoError(t, err)
+	require.NoError(t, conn.Migrate())
+	require.NoError(t, conn.DB.AutoMigrate(&database.WebhookEndpointModel{}))
+	endpoints := database.NewWebhookEndpointRepository(conn.DB, logger)
+	endpoint, err := merchant.NewWebhookEndpoint("whe-1", "test-merchant", receiver.URL,
+		[]string{"invoice.paid"}, secret, 3, merchant.BackoffStrategyExponential, 5, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, endpoints.Save(context.Background(), endpoint))
+
+	service := webhook.NewService(database.NewWebhookDeliveryRepository(conn.DB, logger), endpoints,
+		webhooksender.NewHTTPSender(receiver.Client()), logger)
+	cfg := config.NewConfig()
+	cfg.Webhooks.EgressIPs = []string{"203.0.113.10", "198.51.100.7/28", "2001:db8::1"}
+	handlers, err := web.NewWebhookDeliveryHandlers(service, cfg, logger)
+	require.NoError(t, err)
+	protected := router.Group("/api/v1", func(c *gin.Context) {
+		c.Set("merchant_id", "test-merchant")
+		c.Next()
+	})
+	handlers.RegisterWebhookDeliveryRoutes(protected, nil)
+
+	request := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) web.WebhookDeliveryResponse {
+		t.Helper()
+		var delivery web.WebhookDeliveryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &delivery))
+		return delivery
+	}
+
+	w := request(http.MethodPost, "/api/v1/webhooks/whe-1/test")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	test := decode(t, w)
+	assert.Equal(t, "test", test.Kind)
+	assert.Equal(t, "failed", test.Status)
+	assert.Equal(t, http.StatusServiceUnavailable, test.ResponseCode)
+	assert.Equal(t, "not yet\n", test.ResponseBody)
+	assert.Equal(t, merchant.WebhookTestEventType, test.EventType)
+	require.Len(t, received, 1)
+	assert.Equal(t, test.EventID, received[0].ID)
+	assert.Equal(t, merchant.WebhookTestEventType, received[0].Type)
+
+	t.Run("TestWebhook_UnknownEndpoint", func(t *testing.T) {
+		w := request(http.MethodPost, "/api/v1/webhooks/whe-missing/test")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("ReplayDelivery", func(t *testing.T) {
+		fixed.Store(true)
+
+		w := request(http.MethodPost, "/api/v1/webhook-deliveries/"+test.ID+"/replay")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		replay := decode(t, w)
+		assert.NotEqual(t, test.ID, replay.ID)
+		assert.Equal(t, "replay", replay.Kind)
+		assert.Equal(t, test.ID, replay.ReplayOf)
+		assert.Equal(t, "succeeded", replay.Status)
+		assert.Equal(t, "thanks", replay.ResponseBody)
+		assert.JSONEq(t, string(test.Payload), string(replay.Payload))
+
+		// The receiver sees the same event again
+		require.Len(t, received, 2)
+		assert.Equal(t, received[0], received[1])
+	})
+
+	t.Run("ReplayDelivery_Unknown", func(t *testing.T) {
+		w := request(http.MethodPost, "/api/v1/webhook-deliveries/whd-missing/replay")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("ListDeliveries", func(t *testing.T) {
+		w := request(http.MethodGet, "/api/v1/webhook-deliveries?endpoint_id=whe-1")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list web.ListWebhookDeliveriesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Deliveries, 2)
+		assert.Equal(t, "replay", list.Deliveries[0].Kind)
+		assert.Equal(t, test.ID, list.Deliveries[1].ID)
+
+		w = request(http.MethodGet, "/api/v1/webhook-deliveries?limit=500")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("GetDelivery", func(t *testing.T) {
+		w := request(http.MethodGet, "/api/v1/webhook-deliveries/"+test.ID)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, test.ID, decode(t, w).ID)
+	})
+
+	t.Run("RotateSecret", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/whe-1/rotate-secret",
+			strings.NewReader(`{"overlap_hours":2}`)))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var rotated web.WebhookEndpointResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rotated))
+		assert.True(t, strings.HasPrefix(rotated.Secret, "whsec_"))
+		assert.NotEqual(t, secret, rotated.Secret)
+		require.NotNil(t, rotated.PreviousSecretExpiresAt)
+		assert.WithinDuration(t, time.Now().Add(2*time.Hour), *rotated.PreviousSecretExpiresAt, time.Minute)
+
+		// The receiver still verifying with the old secret accepts webhooks signed with both
+		w = request(http.MethodPost, "/api/v1/webhooks/whe-1/test")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "succeeded", decode(t, w).Status)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/whe-1/rotate-secret",
+			strings.NewReader(`{"overlap_hours":500}`)))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("EndpointStats", func(t *testing.T) {
+		w := request(http.MethodGet, "/api/v1/webhooks/stats")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list web.ListWebhookEndpointStatsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Endpoints, 1)
+		stats := list.Endpoints[0]
+		assert.Equal(t, "whe-1", stats.EndpointID)
+		assert.Equal(t, 3, stats.Deliveries)
+		assert.Equal(t, 2, stats.Succeeded)
+		assert.Equal(t, 1, stats.Failed)
+
+		w = request(http.MethodGet, "/api/v1/webhooks/stats?format=csv")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "webhook-stats.csv")
+		rows, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, "whe-1", rows[1][0])
+
+		w = request(http.MethodGet, "/api/v1/webhooks/stats?format=xml")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("GetEgressIPs", func(t *testing.T) {
+		w := request(http.MethodGet, "/api/v1/webhooks/egress-ips")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.JSONEq(t, `{"ip_ranges":["203.0.113.10/32","198.51.100.0/28","2001:db8::1/128"]}`, w.Body.String())
+
+		cfg := config.NewConfig()
+		cfg.Webhooks.EgressIPs = []string{"egress.example.com"}
+		_, err := web.NewWebhookDeliveryHandlers(service, cfg, logger)
+		require.Error(t, err)
+	})
+
+	t.Run("DisableEndpoints", func(t *testing.T) {
+		disable := func(body string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/disable",
+				strings.NewReader(body)))
+			return w
+		}
+
+		w := disable(`{"endpoint_ids":["whe-1","whe-missing"]}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = disable(`{"endpoint_ids":["whe-1"]}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var disabled web.DisableWebhookEndpointsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &disabled))
+		assert.Equal(t, []string{"whe-1"}, disabled.Disabled)
+
+		saved, err := endpoints.FindByID(context.Background(), "whe-1")
+		require.NoError(t, err)
+		assert.Equal(t, merchant.EndpointStatusDisabled, saved.Status())
+
+		w = disable(`{"endpoint_ids":[]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
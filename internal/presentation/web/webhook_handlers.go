@@ -2,6 +2,7 @@ package web
 
 import (
 	"crypto-checkout/internal/domain/merchant"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -55,9 +56,12 @@ func (h *WebhookHandlers) CreateWebhookEndpoint(c *gin.Context) {
 
 	ctx := c.Request.Context()
 	resp, err := h.webhookService.CreateWebhookEndpoint(ctx, &req)
+	if errors.Is(err, merchant.ErrUnsupportedWebhookAPIVersion) {
+		h.respondUnsupportedVersion(c)
+		return
+	}
 	if err != nil {
-		h.logger.Error("Failed to create webhook endpoint", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create webhook endpoint"})
+		respondDomainError(c, h.logger, err, "Failed to create webhook endpoint")
 		return
 	}
 
@@ -83,8 +87,7 @@ func (h *WebhookHandlers) GetWebhookEndpoint(c *gin.Context) {
 	ctx := c.Request.Context()
 	resp, err := h.webhookService.GetWebhookEndpoint(ctx, req)
 	if err != nil {
-		h.logger.Error("Failed to get webhook endpoint", zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook endpoint not found"})
+		respondDomainError(c, h.logger, err, "Failed to get webhook endpoint")
 		return
 	}
 
@@ -132,8 +135,7 @@ func (h *WebhookHandlers) ListWebhookEndpoints(c *gin.Context) {
 	ctx := c.Request.Context()
 	resp, err := h.webhookService.ListWebhookEndpoints(ctx, req)
 	if err != nil {
-		h.logger.Error("Failed to list webhook endpoints", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list webhook endpoints"})
+		respondDomainError(c, h.logger, err, "Failed to list webhook endpoints")
 		return
 	}
 
@@ -163,9 +165,12 @@ func (h *WebhookHandlers) UpdateWebhookEndpoint(c *gin.Context) {
 
 	ctx := c.Request.Context()
 	resp, err := h.webhookService.UpdateWebhookEndpoint(ctx, &req)
+	if errors.Is(err, merchant.ErrUnsupportedWebhookAPIVersion) {
+		h.respondUnsupportedVersion(c)
+		return
+	}
 	if err != nil {
-		h.logger.Error("Failed to update webhook endpoint", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update webhook endpoint"})
+		respondDomainError(c, h.logger, err, "Failed to update webhook endpoint")
 		return
 	}
 
@@ -191,8 +196,7 @@ func (h *WebhookHandlers) DeleteWebhookEndpoint(c *gin.Context) {
 	ctx := c.Request.Context()
 	resp, err := h.webhookService.DeleteWebhookEndpoint(ctx, req)
 	if err != nil {
-		h.logger.Error("Failed to delete webhook endpoint", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete webhook endpoint"})
+		respondDomainError(c, h.logger, err, "Failed to delete webhook endpoint")
 		return
 	}
 
@@ -218,14 +222,22 @@ func (h *WebhookHandlers) TestWebhookEndpoint(c *gin.Context) {
 	ctx := c.Request.Context()
 	resp, err := h.webhookService.TestWebhookEndpoint(ctx, req)
 	if err != nil {
-		h.logger.Error("Failed to test webhook endpoint", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to test webhook endpoint"})
+		respondDomainError(c, h.logger, err, "Failed to test webhook endpoint")
 		return
 	}
 
 	c.JSON(http.StatusOK, resp)
 }
 
+// respondUnsupportedVersion rejects a webhook API version the payloads cannot be rendered in.
+func (h *WebhookHandlers) respondUnsupportedVersion(c *gin.Context) {
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":              "Unsupported webhook API version",
+		"code":               merchant.ErrCodeUnsupportedWebhookAPIVersion,
+		"supported_versions": merchant.SupportedWebhookAPIVersions(),
+	})
+}
+
 // RegisterWebhookRoutes registers webhook endpoint-related routes.
 func (h *WebhookHandlers) RegisterWebhookRoutes(r *gin.RouterGroup) {
 	// Webhook endpoint routes
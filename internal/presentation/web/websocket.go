@@ -304,7 +304,7 @@ func (h *Handler) serveWS(c *gin.Context) {
 	}
 
 	// Verify invoice exists
-	_, err := h.invoiceService.GetInvoice(c.Request.Context(), invoiceID)
+	_, err := h.customerInvoice(c.Request.Context(), invoiceID)
 	if err != nil {
 		h.Logger.Error("Failed to get invoice for WebSocket", zap.Error(err), zap.String("invoice_id", invoiceID))
 		c.JSON(http.StatusNotFound, gin.H{"error": "invoice not found"})
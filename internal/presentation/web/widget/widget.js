@@ -0,0 +1,152 @@
+// Crypto Checkout payment widget.
+//
+// Renders the payment instructions of an invoice inside the merchant's page and keeps its status up to date:
+//
+//   <div data-crypto-checkout-invoice="inv_abc123"></div>
+//   <script src="https://api.cryptocheckout.com/widget.js" async></script>
+//
+// The page's origin must be listed in the merchant's allowed_origins setting. The element dispatches a
+// "crypto-checkout:status" event whenever the status changes and "crypto-checkout:paid" once the invoice is paid,
+// with the widget state as the event detail.
+(function () {
+    'use strict';
+
+    const POLL_INTERVAL = 5000;
+    const AWAITING_PAYMENT_STATUSES = ['created', 'pending', 'partial'];
+    const TERMINAL_STATUSES = ['paid', 'expired', 'cancelled', 'partially_refunded', 'refunded', 'underpaid_closed'];
+    const LABELS = {
+        amount: 'Amount',
+        address: 'Address',
+        memo: 'Memo',
+        status: 'Status',
+        copy: 'Copy',
+        copied: 'Copied',
+        checkout: 'Open checkout',
+        unavailable: 'Payment details are unavailable.'
+    };
+
+    const script = document.currentScript;
+    const apiBase = script ? new URL(script.src).origin : window.location.origin;
+
+    function row(label, value, copyable) {
+        const container = document.createElement('div');
+        container.className = 'crypto-checkout-row';
+
+        const name = document.createElement('span');
+        name.className = 'crypto-checkout-label';
+        name.textContent = label;
+        container.appendChild(name);
+
+        const content = document.createElement('code');
+        content.className = 'crypto-checkout-value';
+        content.textContent = value;
+        container.appendChild(content);
+
+        if (copyable && navigator.clipboard) {
+            const button = document.createElement('button');
+            button.type = 'button';
+            button.className = 'crypto-checkout-copy';
+            button.textContent = LABELS.copy;
+            button.addEventListener('click', function () {
+                navigator.clipboard.writeText(value).then(function () {
+                    button.textContent = LABELS.copied;
+                    setTimeout(function () { button.textContent = LABELS.copy; }, 2000);
+                });
+            });
+            container.appendChild(button);
+        }
+        return container;
+    }
+
+    function render(element, state) {
+        const body = document.createElement('div');
+        body.className = 'crypto-checkout-widget crypto-checkout-' + state.status;
+
+        const title = document.createElement('strong');
+        title.className = 'crypto-checkout-title';
+        title.textContent = state.title;
+        body.appendChild(title);
+
+        if (AWAITING_PAYMENT_STATUSES.includes(state.status)) {
+            const qr = document.createElement('img');
+            qr.className = 'crypto-checkout-qr';
+            qr.alt = state.address;
+            qr.src = apiBase + state.qr_code_url;
+            body.appendChild(qr);
+
+            body.appendChild(row(LABELS.amount, state.amount + ' ' + state.crypto_currency, true));
+            body.appendChild(row(LABELS.address, state.address, true));
+            if (state.memo) {
+                body.appendChild(row(LABELS.memo, state.memo, true));
+            }
+        }
+        body.appendChild(row(LABELS.status, state.status, false));
+
+        const checkout = document.createElement('a');
+        checkout.className = 'crypto-checkout-link';
+        checkout.href = apiBase + state.checkout_url;
+        checkout.target = '_blank';
+        checkout.rel = 'noopener';
+        checkout.textContent = LABELS.checkout;
+        body.appendChild(checkout);
+
+        element.replaceChildren(body);
+    }
+
+    function mount(element) {
+        const invoiceID = element.getAttribute('data-crypto-checkout-invoice');
+        if (!invoiceID || element.dataset.cryptoCheckoutMounted) {
+            return;
+        }
+        element.dataset.cryptoCheckoutMounted = 'true';
+
+        const url = apiBase + '/api/v1/public/widget/invoice/' + encodeURIComponent(invoiceID);
+        let status = null;
+
+        function poll() {
+            fetch(url, { headers: { 'Accept': 'application/json' } })
+                .then(function (response) {
+                    if (!response.ok) {
+                        const error = new Error('widget state request failed with status ' + response.status);
+                        // Unknown invoices and origins the merchant does not allow are not retried
+                        error.permanent = response.status >= 400 && response.status < 500 && response.status !== 429;
+                        throw error;
+                    }
+                    return response.json();
+                })
+                .then(function (state) {
+                    render(element, state);
+                    if (state.status !== status) {
+                        status = state.status;
+                        element.dispatchEvent(new CustomEvent('crypto-checkout:status', { detail: state, bubbles: true }));
+                        if (status === 'paid') {
+                            element.dispatchEvent(new CustomEvent('crypto-checkout:paid', { detail: state, bubbles: true }));
+                        }
+                    }
+                    if (!TERMINAL_STATUSES.includes(status)) {
+                        setTimeout(poll, POLL_INTERVAL);
+                    }
+                })
+                .catch(function (error) {
+                    console.error('crypto-checkout:', error);
+                    if (status === null) {
+                        element.textContent = LABELS.unavailable;
+                    }
+                    if (!error.permanent) {
+                        setTimeout(poll, POLL_INTERVAL * 2);
+                    }
+                });
+        }
+        poll();
+    }
+
+    function mountAll() {
+        document.querySelectorAll('[data-crypto-checkout-invoice]').forEach(mount);
+    }
+
+    if (document.readyState === 'loading') {
+        document.addEventListener('DOMContentLoaded', mountAll);
+    } else {
+        mountAll();
+    }
+})();
@@ -0,0 +1,81 @@
+package web
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	_ "embed"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// WidgetScriptPath serves the embeddable payment widget.
+const WidgetScriptPath = "/widget.js"
+
+//go:embed widget/widget.js
+var widgetScript []byte
+
+// serveWidgetScript handles GET /widget.js requests with the embeddable payment widget. Merchants add it to their
+// pages with an element naming the invoice:
+//
+//	<div data-crypto-checkout-invoice="inv_abc123"></div>
+//	<script src="https://api.cryptocheckout.com/widget.js" async></script>
+func (h *Handler) serveWidgetScript(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Data(http.StatusOK, "application/javascript; charset=utf-8", widgetScript)
+}
+
+// GetWidgetState handles GET /api/v1/public/widget/invoice/:id requests.
+// @Summary Get payment widget state
+// @Description Return what the embedded payment widget shows of an invoice: the amount to send, the address and the
+// @Description status, which the widget polls. Cross-origin requests are only answered for the sites listed in the
+// @Description merchant's allowed_origins setting.
+// @Tags Public API
+// @Produce json
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} WidgetStateResponse "Widget state"
+// @Failure 403 {object} ErrorResponse "Origin not allowed to embed the merchant's widget"
+// @Failure 404 {object} ErrorResponse "Invoice not found"
+// @Failure 451 {object} ErrorResponse "Checkout not available in the client's country"
+// @Router /api/v1/public/widget/invoice/{id} [get]
+func (h *Handler) GetWidgetState(c *gin.Context) {
+	id := c.Param("id")
+	inv, err := h.customerInvoice(shared.WithReplicaReads(c.Request.Context()), id)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			c.JSON(http.StatusNotFound, createNotFoundErrorResponse("invoice not found"))
+			return
+		}
+		h.Logger.Error("Failed to get invoice for widget", zap.Error(err), zap.String("invoice_id", id))
+		c.JSON(http.StatusInternalServerError, createValidationErrorResponse("failed to retrieve invoice", err))
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, h.toWidgetStateResponse(inv))
+}
+
+// toWidgetStateResponse converts a domain invoice to the state the payment widget renders.
+func (h *Handler) toWidgetStateResponse(inv *invoice.Invoice) WidgetStateResponse {
+	public := h.toPublicInvoiceResponse(inv)
+	return WidgetStateResponse{
+		ID:             public.ID,
+		Title:          public.Title,
+		Status:         public.Status,
+		Total:          public.Total,
+		Currency:       public.Currency,
+		CryptoCurrency: public.CryptoCurrency,
+		Network:        public.Network,
+		Amount:         public.USDTAmount,
+		Address:        public.Address,
+		Memo:           public.Memo,
+		QRCodeURL:      "/invoice/" + inv.ID() + "/qr",
+		CheckoutURL:    "/invoice/" + inv.ID(),
+		ExpiresAt:      public.ExpiresAt,
+		TimeRemaining:  public.TimeRemaining,
+		PaidAt:         public.PaidAt,
+		ReturnURL:      public.ReturnURL,
+	}
+}
@@ -0,0 +1,107 @@
+package web_test
+
+import (
+	"bytes"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/pkg/i18n"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPaymentWidget(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	bundle, err := i18n.NewBundle()
+	require.NoError(t, err)
+	router := web.NewGinEngine(&config.Config{}, zap.NewNop(), bundle, nil)
+
+	handler := web.CreateTestHandler()
+	handler.SetMerchantOrigins(fakeMerchantOrigins{"https://shop.example.com"})
+	handler.RegisterRoutes(router)
+
+	request := func(method, path, origin string, body interface{}) *httptest.ResponseRecorder {
+		var requestBody []byte
+		if body != nil {
+			requestBody, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer sk_live_test123")
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request(http.MethodPost, "/api/v1/invoices", "", web.CreateInvoiceRequest{
+		Title:   "Software License",
+		Items:   []web.InvoiceItemRequest{{Name: "License", Quantity: "1", UnitPrice: "100.00"}},
+		TaxRate: "0.10",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created web.CreateInvoiceResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	statePath := "/api/v1/public/widget/invoice/" + created.ID
+
+	t.Run("Script_IsServed", func(t *testing.T) {
+		w := request(http.MethodGet, web.WidgetScriptPath, "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Header().Get("Content-Type"), "application/javascript")
+		require.Contains(t, w.Body.String(), "data-crypto-checkout-invoice")
+	})
+
+	t.Run("State_AllowedOrigin_EchoesOrigin", func(t *testing.T) {
+		w := request(http.MethodGet, statePath, "https://shop.example.com", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, "https://shop.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		require.Equal(t, "Origin", w.Header().Get("Vary"))
+
+		var state web.WidgetStateResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &state))
+		require.Equal(t, created.ID, state.ID)
+		require.Equal(t, "110.00", state.Total)
+		require.NotEmpty(t, state.Address)
+		require.Equal(t, "/invoice/"+created.ID+"/qr", state.QRCodeURL)
+		require.Equal(t, "/invoice/"+created.ID, state.CheckoutURL)
+	})
+
+	t.Run("State_OtherOrigin_Forbidden", func(t *testing.T) {
+		w := request(http.MethodGet, statePath, "https://evil.example.com", nil)
+		require.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+		require.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("State_WithoutOrigin_Served", func(t *testing.T) {
+		w := request(http.MethodGet, statePath, "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("Preflight_AllowedOrigin_NoContent", func(t *testing.T) {
+		w := request(http.MethodOptions, statePath, "https://shop.example.com", nil)
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+		require.Equal(t, "https://shop.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		require.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), http.MethodGet)
+	})
+
+	t.Run("State_UnknownInvoice_NotFound", func(t *testing.T) {
+		w := request(http.MethodGet, "/api/v1/public/widget/invoice/inv_missing", "", nil)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		// Other sites cannot tell unknown invoices from those of merchants that do not allow them
+		w = request(http.MethodGet, "/api/v1/public/widget/invoice/inv_missing", "https://shop.example.com", nil)
+		require.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	})
+}
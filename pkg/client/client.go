@@ -0,0 +1,271 @@
+// Package client is a Go SDK for the crypto-checkout merchant API.
+//
+// A Client authenticates every request with the merchant's API key, retries throttled and failed requests with
+// exponential backoff, and exposes the API grouped by resource:
+//
+//	c, err := client.New("https://api.thecryptocheckout.com", "sk_live_...")
+//	invoice, err := c.Invoices.Create(ctx, &client.CreateInvoiceRequest{...},
+//		client.WithIdempotencyKey(client.NewIdempotencyKey()))
+//
+// The request and response types in types_gen.go are generated from the server's API definitions; run
+// `go generate ./pkg/client` after changing them.
+package client
+
+//go:generate go run ./internal/typegen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultUserAgent identifies the SDK to the API.
+	DefaultUserAgent = "crypto-checkout-go"
+
+	// defaultTimeout bounds a single attempt when no HTTP client is given.
+	defaultTimeout = 30 * time.Second
+	// maxResponseSize bounds the response body read into memory.
+	maxResponseSize = 10 << 20
+)
+
+// ErrMissingAPIKey is returned by New when no API key is given.
+var ErrMissingAPIKey = errors.New("client: API key is required")
+
+// APIError is returned for a response with a 4xx or 5xx status code.
+type APIError struct {
+	StatusCode int
+	Type       string
+	Code       string
+	Message    string
+	RequestID  string
+	Details    map[string]interface{}
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	message := e.Message
+	if message == "" {
+		message = http.StatusText(e.StatusCode)
+	}
+	if e.Code != "" {
+		return fmt.Sprintf("client: %d %s: %s", e.StatusCode, e.Code, message)
+	}
+	return fmt.Sprintf("client: %d: %s", e.StatusCode, message)
+}
+
+// Client calls the crypto-checkout API on behalf of one merchant API key.
+type Client struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+	userAgent  string
+	retry      RetryPolicy
+
+	// Invoices manages the merchant's invoices.
+	Invoices *InvoicesService
+	// Payments reads the payments customers made towards an invoice.
+	Payments *PaymentsService
+	// Settlements reads the settlements of paid invoices.
+	Settlements *SettlementsService
+	// Payouts reads the on-chain payouts of settled funds.
+	Payouts *PayoutsService
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetryPolicy sets how failed requests are retried; see DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// New creates a client for the API at baseURL, such as "https://api.thecryptocheckout.com", authenticating with
+// the merchant API key.
+func New(baseURL, apiKey string, opts ...Option) (*Client, error) {
+	if apiKey == "" {
+		return nil, ErrMissingAPIKey
+	}
+	parsed, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("client: invalid base URL: %w", err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("client: invalid base URL %q", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    parsed.String(),
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		userAgent:  DefaultUserAgent,
+		retry:      DefaultRetryPolicy(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.Invoices = &InvoicesService{client: c}
+	c.Payments = &PaymentsService{client: c}
+	c.Settlements = &SettlementsService{client: c}
+	c.Payouts = &PayoutsService{client: c}
+	return c, nil
+}
+
+// RequestOption configures a single API request.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	idempotencyKey string
+	header         http.Header
+}
+
+// WithHeader adds a header to the request, such as Accept-Language for localized error messages.
+func WithHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.header.Add(key, value)
+	}
+}
+
+// do sends a request with a JSON body, retrying it according to the retry policy, and decodes a successful
+// JSON response into out. It returns the response status code.
+func (c *Client) do(
+	ctx context.Context,
+	method, path string,
+	query url.Values,
+	in, out interface{},
+	opts []RequestOption,
+) (int, error) {
+	options := requestOptions{header: make(http.Header)}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return 0, fmt.Errorf("client: failed to encode request: %w", err)
+		}
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	retryable := method != http.MethodPost || options.idempotencyKey != ""
+	for attempt := 0; ; attempt++ {
+		status, data, header, err := c.send(ctx, method, target, body, options)
+		if err == nil && status < http.StatusBadRequest {
+			if out != nil && len(data) > 0 {
+				if err := json.Unmarshal(data, out); err != nil {
+					return status, fmt.Errorf("client: failed to decode response: %w", err)
+				}
+			}
+			return status, nil
+		}
+		if err == nil {
+			err = newAPIError(status, data)
+		}
+
+		if !retryable || !c.retry.shouldRetry(attempt, status, err) {
+			return status, err
+		}
+		if waitErr := sleep(ctx, c.retry.delay(attempt, header)); waitErr != nil {
+			return status, err
+		}
+	}
+}
+
+// send performs a single attempt and returns the status code, body and headers of the response.
+func (c *Client) send(
+	ctx context.Context,
+	method, target string,
+	body []byte,
+	options requestOptions,
+) (int, []byte, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("client: failed to build request: %w", err)
+	}
+	for key, values := range options.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if options.idempotencyKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, options.idempotencyKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("client: %s %s: %w", method, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("client: failed to read response: %w", err)
+	}
+	return resp.StatusCode, data, resp.Header, nil
+}
+
+// newAPIError builds the error for an error response, falling back to the status text when the body is not
+// an API error.
+func newAPIError(status int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: status}
+	var response ErrorResponse
+	if err := json.Unmarshal(body, &response); err == nil {
+		apiErr.Type = response.Error
+		apiErr.Code = response.Code
+		apiErr.Message = response.Message
+		apiErr.RequestID = response.RequestID
+		apiErr.Details = response.Details
+	}
+	return apiErr
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// escape escapes a path segment such as an invoice ID.
+func escape(segment string) string {
+	return url.PathEscape(segment)
+}
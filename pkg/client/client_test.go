@@ -0,0 +1,217 @@
+package client_test
+
+import (
+	"context"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/client"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// fastRetries retries without noticeable delay.
+func fastRetries() client.RetryPolicy {
+	return client.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func TestClient(t *testing.T) {
+	t.Run("New_RequiresAPIKey", func(t *testing.T) {
+		_, err := client.New("https://api.example.com", "")
+		require.ErrorIs(t, err, client.ErrMissingAPIKey)
+
+		_, err = client.New("api.example.com", "sk_live_test123")
+		require.Error(t, err)
+	})
+
+	t.Run("Request_InjectsAuthorization", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "Bearer sk_live_test123", r.Header.Get("Authorization"))
+			require.Equal(t, "/api/v1/payouts/po_1", r.URL.Path)
+			_ = json.NewEncoder(w).Encode(client.PayoutResponse{ID: "po_1", Status: "confirmed"})
+		}))
+		defer server.Close()
+
+		c, err := client.New(server.URL, "sk_live_test123")
+		require.NoError(t, err)
+		payout, err := c.Payouts.Get(context.Background(), "po_1")
+		require.NoError(t, err)
+		require.Equal(t, "confirmed", payout.Status)
+	})
+
+	t.Run("List_EncodesFilters", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "2025-01-01", r.URL.Query().Get("start_date"))
+			require.Equal(t, "completed", r.URL.Query().Get("status"))
+			require.Equal(t, "50", r.URL.Query().Get("limit"))
+			_ = json.NewEncoder(w).Encode(client.ListSettlementsResponse{Limit: 50, NextCursor: "next"})
+		}))
+		defer server.Close()
+
+		c, err := client.New(server.URL, "sk_live_test123")
+		require.NoError(t, err)
+		settlements, err := c.Settlements.List(context.Background(), &client.ListSettlementsParams{
+			StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Status:    "completed",
+			Limit:     50,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "next", settlements.NextCursor)
+	})
+
+	t.Run("ErrorResponse_ReturnsAPIError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(client.ErrorResponse{
+				Error: "not_found", Code: "NOT_FOUND", Message: "Invoice not found", RequestID: "req_1",
+			})
+		}))
+		defer server.Close()
+
+		c, err := client.New(server.URL, "sk_live_test123", client.WithRetryPolicy(fastRetries()))
+		require.NoError(t, err)
+		_, err = c.Invoices.Get(context.Background(), "missing")
+
+		var apiErr *client.APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		require.Equal(t, "NOT_FOUND", apiErr.Code)
+		require.Equal(t, "req_1", apiErr.RequestID)
+	})
+
+	t.Run("ServerError_RetriedWithBackoff", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if attempts.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(client.ListPayoutsResponse{Limit: 20})
+		}))
+		defer server.Close()
+
+		c, err := client.New(server.URL, "sk_live_test123", client.WithRetryPolicy(fastRetries()))
+		require.NoError(t, err)
+		_, err = c.Payouts.List(context.Background(), nil)
+		require.NoError(t, err)
+		require.Equal(t, int32(3), attempts.Load())
+	})
+
+	t.Run("PostWithoutIdempotencyKey_NotRetried", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		c, err := client.New(server.URL, "sk_live_test123", client.WithRetryPolicy(fastRetries()))
+		require.NoError(t, err)
+		_, err = c.Invoices.Create(context.Background(), &client.CreateInvoiceRequest{Title: "Order"})
+		require.Error(t, err)
+		require.Equal(t, int32(1), attempts.Load())
+	})
+
+	t.Run("PostWithIdempotencyKey_RetriedWithSameKey", func(t *testing.T) {
+		var attempts atomic.Int32
+		keys := make(chan string, 4)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keys <- r.Header.Get(client.IdempotencyKeyHeader)
+			if attempts.Add(1) == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(client.CreateInvoiceResponse{ID: "inv_1"})
+		}))
+		defer server.Close()
+
+		c, err := client.New(server.URL, "sk_live_test123", client.WithRetryPolicy(fastRetries()))
+		require.NoError(t, err)
+		key := client.NewIdempotencyKey()
+		invoice, err := c.Invoices.Create(context.Background(), &client.CreateInvoiceRequest{Title: "Order"},
+			client.WithIdempotencyKey(key))
+		require.NoError(t, err)
+		require.Equal(t, "inv_1", invoice.ID)
+		require.Equal(t, key, <-keys)
+		require.Equal(t, key, <-keys)
+	})
+
+	t.Run("ClientError_NotRetried", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		c, err := client.New(server.URL, "sk_live_test123", client.WithRetryPolicy(fastRetries()))
+		require.NoError(t, err)
+		_, err = c.Settlements.Get(context.Background(), "set_1")
+		require.Error(t, err)
+		require.Equal(t, int32(1), attempts.Load())
+	})
+}
+
+func TestClientAgainstAPI(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := web.CreateTestHandler()
+	handler.RegisterRoutes(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	c, err := client.New(server.URL, "sk_live_test123")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	key := client.NewIdempotencyKey()
+	req := &client.CreateInvoiceRequest{
+		Title:   "SDK Invoice",
+		Items:   []client.InvoiceItemRequest{{Name: "Widget", Quantity: "2", UnitPrice: "12.50"}},
+		TaxRate: "0.00",
+	}
+	created, err := c.Invoices.Create(ctx, req, client.WithIdempotencyKey(key))
+	require.NoError(t, err)
+	require.Equal(t, "25.00", created.Total)
+
+	t.Run("IdempotentCreate_ReturnsSameInvoice", func(t *testing.T) {
+		repeated, err := c.Invoices.Create(ctx, req, client.WithIdempotencyKey(key))
+		require.NoError(t, err)
+		require.Equal(t, created.ID, repeated.ID)
+	})
+
+	t.Run("Get_ReturnsInvoice", func(t *testing.T) {
+		invoice, err := c.Invoices.Get(ctx, created.ID)
+		require.NoError(t, err)
+		require.Equal(t, created.ID, invoice.ID)
+	})
+
+	t.Run("StatusBatch_ReportsUnknownIDs", func(t *testing.T) {
+		statuses, err := c.Invoices.StatusBatch(ctx, []string{created.ID, "missing"})
+		require.NoError(t, err)
+		require.Contains(t, statuses.Invoices, created.ID)
+		require.Equal(t, []string{"missing"}, statuses.NotFound)
+	})
+
+	t.Run("Payments_ListsInvoicePayments", func(t *testing.T) {
+		payments, err := c.Payments.List(ctx, created.ID)
+		require.NoError(t, err)
+		require.Equal(t, created.ID, payments.InvoiceID)
+		require.Empty(t, payments.Payments)
+	})
+
+	t.Run("Cancel_CancelsInvoice", func(t *testing.T) {
+		cancelled, err := c.Invoices.Cancel(ctx, created.ID, "Customer request")
+		require.NoError(t, err)
+		require.Equal(t, "cancelled", cancelled.Status)
+	})
+}
@@ -0,0 +1,26 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// IdempotencyKeyHeader carries the idempotency key of a request.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// NewIdempotencyKey returns a random idempotency key. Generate one key per logical operation, such as an order
+// being invoiced, and reuse it when retrying that operation.
+func NewIdempotencyKey() string {
+	var key [16]byte
+	// crypto/rand.Read never returns an error
+	_, _ = rand.Read(key[:])
+	return hex.EncodeToString(key[:])
+}
+
+// WithIdempotencyKey sends the request with an idempotency key. The API answers a repeated key with the response
+// of the first request instead of performing the operation again, which also lets the client retry a failed POST.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(o *requestOptions) {
+		o.idempotencyKey = key
+	}
+}
@@ -0,0 +1,182 @@
+// Command typegen copies the request and response types of the REST API from the web DTOs into the client
+// package, so the SDK stays in sync with the server without importing its internal packages.
+//
+// Only the types reachable from roots are copied. Struct tags are reduced to their json key, since binding
+// and form tags only matter to the server.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// roots are the API types the client exposes; the types they reference are copied along with them.
+var roots = []string{
+	"CreateInvoiceRequest",
+	"CreateInvoiceResponse",
+	"ListInvoicesResponse",
+	"InvoiceStatusBatchRequest",
+	"InvoiceStatusBatchResponse",
+	"CancelInvoiceRequest",
+	"CancelInvoiceResponse",
+	"RefundInvoiceRequest",
+	"RefundInvoiceResponse",
+	"ApprovalResponse",
+	"PublicInvoiceResponse",
+	"PublicInvoiceStatusResponse",
+	"PaymentDetailResponse",
+	"RedirectKeysResponse",
+	"ListCurrenciesResponse",
+	"ListSettlementsResponse",
+	"SettlementResponse",
+	"ListPayoutsResponse",
+	"PayoutResponse",
+	"ErrorResponse",
+}
+
+func main() {
+	src := flag.String("src", "../../internal/presentation/web/dtos.go", "web DTO source file")
+	out := flag.String("out", "types_gen.go", "generated output file")
+	flag.Parse()
+
+	source, err := os.ReadFile(*src)
+	if err != nil {
+		log.Fatalf("failed to read DTOs: %v", err)
+	}
+	generated, err := generate(source, roots)
+	if err != nil {
+		log.Fatalf("failed to generate client types: %v", err)
+	}
+	if err := os.WriteFile(*out, generated, 0o600); err != nil {
+		log.Fatalf("failed to write client types: %v", err)
+	}
+}
+
+// generate returns the client package source declaring the roots and every type they reference.
+func generate(source []byte, roots []string) ([]byte, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "dtos.go", source, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	// Struct types in declaration order
+	var order []string
+	declarations := make(map[string]*ast.GenDecl)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE || len(genDecl.Specs) != 1 {
+			continue
+		}
+		spec := genDecl.Specs[0].(*ast.TypeSpec)
+		if _, ok := spec.Type.(*ast.StructType); ok {
+			order = append(order, spec.Name.Name)
+			declarations[spec.Name.Name] = genDecl
+		}
+	}
+
+	selected := make(map[string]bool)
+	queue := append([]string(nil), roots...)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if selected[name] {
+			continue
+		}
+		decl, ok := declarations[name]
+		if !ok {
+			return nil, fmt.Errorf("type %s is not a struct declared in the DTOs", name)
+		}
+		selected[name] = true
+
+		var invalid error
+		ast.Inspect(decl.Specs[0].(*ast.TypeSpec).Type, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.SelectorExpr:
+				if pkg, ok := node.X.(*ast.Ident); !ok || pkg.Name != "time" {
+					invalid = fmt.Errorf("type %s uses %s, only time may be imported", name, types.ExprString(node))
+				}
+				return false
+			case *ast.Ident:
+				if _, ok := declarations[node.Name]; ok {
+					queue = append(queue, node.Name)
+				}
+			}
+			return true
+		})
+		if invalid != nil {
+			return nil, invalid
+		}
+	}
+
+	var body bytes.Buffer
+	for _, name := range order {
+		if selected[name] {
+			writeStruct(&body, declarations[name])
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by typegen from internal/presentation/web/dtos.go. DO NOT EDIT.\n\n")
+	out.WriteString("package client\n\n")
+	if strings.Contains(body.String(), "time.") {
+		out.WriteString("import \"time\"\n\n")
+	}
+	out.Write(body.Bytes())
+	return format.Source(out.Bytes())
+}
+
+// writeStruct writes a struct declaration with its comments, keeping only the json key of each field tag.
+func writeStruct(buf *bytes.Buffer, decl *ast.GenDecl) {
+	spec := decl.Specs[0].(*ast.TypeSpec)
+	writeComment(buf, decl.Doc, "")
+	fmt.Fprintf(buf, "type %s struct {\n", spec.Name.Name)
+	for _, field := range spec.Type.(*ast.StructType).Fields.List {
+		writeComment(buf, field.Doc, "\t")
+		names := make([]string, len(field.Names))
+		for i, name := range field.Names {
+			names[i] = name.Name
+		}
+		fmt.Fprintf(buf, "\t%s %s", strings.Join(names, ", "), types.ExprString(field.Type))
+		if tag := jsonTag(field.Tag); tag != "" {
+			fmt.Fprintf(buf, " `json:%q`", tag)
+		}
+		if field.Comment != nil {
+			fmt.Fprintf(buf, " // %s", strings.TrimSpace(field.Comment.Text()))
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}\n\n")
+}
+
+// writeComment writes a comment group, one line comment per line.
+func writeComment(buf *bytes.Buffer, group *ast.CommentGroup, indent string) {
+	if group == nil {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimSpace(group.Text()), "\n") {
+		fmt.Fprintf(buf, "%s// %s\n", indent, line)
+	}
+}
+
+// jsonTag returns the json key of a struct tag, or an empty string if the field has none.
+func jsonTag(tag *ast.BasicLit) string {
+	if tag == nil {
+		return ""
+	}
+	value, err := strconv.Unquote(tag.Value)
+	if err != nil {
+		return ""
+	}
+	return reflect.StructTag(value).Get("json")
+}
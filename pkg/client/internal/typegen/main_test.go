@@ -0,0 +1,19 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedTypesUpToDate(t *testing.T) {
+	source, err := os.ReadFile("../../../../internal/presentation/web/dtos.go")
+	require.NoError(t, err)
+	generated, err := generate(source, roots)
+	require.NoError(t, err)
+
+	current, err := os.ReadFile("../../types_gen.go")
+	require.NoError(t, err)
+	require.Equal(t, string(generated), string(current), "client types are stale, run go generate ./pkg/client")
+}
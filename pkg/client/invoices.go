@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// InvoicesService manages the merchant's invoices.
+type InvoicesService struct {
+	client *Client
+}
+
+// ListInvoicesParams filters and pages an invoice listing. Zero values are left to the API defaults.
+type ListInvoicesParams struct {
+	Status string
+	Limit  int
+	Page   int
+	// Cursor is the NextCursor of the previous page; it takes precedence over Page.
+	Cursor string
+}
+
+// RefundResult is the outcome of a refund request: the refund when it was made at once, or the pending approval
+// when the amount needs the sign-off of other team members first.
+type RefundResult struct {
+	Refund   *RefundInvoiceResponse
+	Approval *ApprovalResponse
+}
+
+// Create creates an invoice. Pass WithIdempotencyKey so the request can be retried safely.
+func (s *InvoicesService) Create(
+	ctx context.Context,
+	req *CreateInvoiceRequest,
+	opts ...RequestOption,
+) (*CreateInvoiceResponse, error) {
+	var invoice CreateInvoiceResponse
+	if _, err := s.client.do(ctx, http.MethodPost, "/api/v1/invoices", nil, req, &invoice, opts); err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// Get returns an invoice.
+func (s *InvoicesService) Get(ctx context.Context, id string, opts ...RequestOption) (*CreateInvoiceResponse, error) {
+	var invoice CreateInvoiceResponse
+	if _, err := s.client.do(ctx, http.MethodGet, "/api/v1/invoices/"+escape(id), nil, nil, &invoice, opts); err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// List returns a page of the merchant's invoices.
+func (s *InvoicesService) List(
+	ctx context.Context,
+	params *ListInvoicesParams,
+	opts ...RequestOption,
+) (*ListInvoicesResponse, error) {
+	query := url.Values{}
+	if params != nil {
+		setQuery(query, "status", params.Status)
+		setQuery(query, "cursor", params.Cursor)
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+		if params.Page > 0 {
+			query.Set("page", strconv.Itoa(params.Page))
+		}
+	}
+
+	var invoices ListInvoicesResponse
+	if _, err := s.client.do(ctx, http.MethodGet, "/api/v1/invoices", query, nil, &invoices, opts); err != nil {
+		return nil, err
+	}
+	return &invoices, nil
+}
+
+// StatusBatch returns the payment state of up to 100 invoices at once.
+func (s *InvoicesService) StatusBatch(
+	ctx context.Context,
+	ids []string,
+	opts ...RequestOption,
+) (*InvoiceStatusBatchResponse, error) {
+	// Reading statuses changes nothing, so the POST is always safe to retry
+	opts = append([]RequestOption{WithIdempotencyKey(NewIdempotencyKey())}, opts...)
+
+	var statuses InvoiceStatusBatchResponse
+	req := &InvoiceStatusBatchRequest{InvoiceIDs: ids}
+	if _, err := s.client.do(ctx, http.MethodPost, "/api/v1/invoices/status-batch", nil, req, &statuses, opts); err != nil {
+		return nil, err
+	}
+	return &statuses, nil
+}
+
+// Cancel cancels an unpaid invoice.
+func (s *InvoicesService) Cancel(
+	ctx context.Context,
+	id, reason string,
+	opts ...RequestOption,
+) (*CancelInvoiceResponse, error) {
+	var cancelled CancelInvoiceResponse
+	req := &CancelInvoiceRequest{Reason: reason}
+	if _, err := s.client.do(ctx, http.MethodPost, "/api/v1/invoices/"+escape(id)+"/cancel", nil, req, &cancelled,
+		opts); err != nil {
+		return nil, err
+	}
+	return &cancelled, nil
+}
+
+// Refund refunds a paid invoice in full or in part. Pass WithIdempotencyKey so the request can be retried safely.
+func (s *InvoicesService) Refund(
+	ctx context.Context,
+	id string,
+	req *RefundInvoiceRequest,
+	opts ...RequestOption,
+) (*RefundResult, error) {
+	var response json.RawMessage
+	status, err := s.client.do(ctx, http.MethodPost, "/api/v1/invoices/"+escape(id)+"/refunds", nil, req, &response,
+		opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// 202 Accepted means the refund waits for approval
+	if status == http.StatusAccepted {
+		var approval ApprovalResponse
+		if err := json.Unmarshal(response, &approval); err != nil {
+			return nil, fmt.Errorf("client: failed to decode response: %w", err)
+		}
+		return &RefundResult{Approval: &approval}, nil
+	}
+	var refund RefundInvoiceResponse
+	if err := json.Unmarshal(response, &refund); err != nil {
+		return nil, fmt.Errorf("client: failed to decode response: %w", err)
+	}
+	return &RefundResult{Refund: &refund}, nil
+}
+
+// ListCurrencies returns the cryptocurrencies and networks the merchant's invoices may be paid in.
+func (s *InvoicesService) ListCurrencies(ctx context.Context, opts ...RequestOption) (*ListCurrenciesResponse, error) {
+	var currencies ListCurrenciesResponse
+	if _, err := s.client.do(ctx, http.MethodGet, "/api/v1/currencies", nil, nil, &currencies, opts); err != nil {
+		return nil, err
+	}
+	return &currencies, nil
+}
+
+// setQuery sets a query parameter unless the value is empty.
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
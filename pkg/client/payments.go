@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// PaymentsService reads the payments customers made towards an invoice. Apart from Get, it uses the public checkout
+// API, so it works for any invoice ID.
+type PaymentsService struct {
+	client *Client
+}
+
+// InvoicePayments lists the payments detected for an invoice and how much of the invoice they cover.
+type InvoicePayments struct {
+	InvoiceID string
+	Status    string
+	Payments  []PublicPaymentResponse
+	Progress  *PaymentProgressResponse
+}
+
+// Get returns the full detail of one of the merchant's payments, with its chain data and screening.
+func (s *PaymentsService) Get(ctx context.Context, id string, opts ...RequestOption) (*PaymentDetailResponse, error) {
+	var detail PaymentDetailResponse
+	if _, err := s.client.do(ctx, http.MethodGet, "/api/v1/payments/"+escape(id), nil, nil, &detail, opts); err != nil {
+		return nil, err
+	}
+	return &detail, nil
+}
+
+// List returns the payments detected for an invoice.
+func (s *PaymentsService) List(ctx context.Context, invoiceID string, opts ...RequestOption) (*InvoicePayments, error) {
+	invoice, err := s.Invoice(ctx, invoiceID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &InvoicePayments{
+		InvoiceID: invoice.ID,
+		Status:    invoice.Status,
+		Payments:  invoice.Payments,
+		Progress:  invoice.PaymentProgress,
+	}, nil
+}
+
+// Invoice returns the invoice as the customer sees it on the checkout page, including its payments.
+func (s *PaymentsService) Invoice(
+	ctx context.Context,
+	invoiceID string,
+	opts ...RequestOption,
+) (*PublicInvoiceResponse, error) {
+	var invoice PublicInvoiceResponse
+	if _, err := s.client.do(ctx, http.MethodGet, "/api/v1/public/invoice/"+escape(invoiceID), nil, nil, &invoice,
+		opts); err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// Status returns the payment status of an invoice.
+func (s *PaymentsService) Status(
+	ctx context.Context,
+	invoiceID string,
+	opts ...RequestOption,
+) (*PublicInvoiceStatusResponse, error) {
+	var status PublicInvoiceStatusResponse
+	if _, err := s.client.do(ctx, http.MethodGet, "/api/v1/public/invoice/"+escape(invoiceID)+"/status", nil, nil,
+		&status, opts); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// PayoutsService reads the on-chain payouts of settled funds.
+type PayoutsService struct {
+	client *Client
+}
+
+// ListPayoutsParams filters and pages a payout listing. Zero values are left to the API defaults.
+type ListPayoutsParams struct {
+	Status string
+	Limit  int
+	// Cursor is the NextCursor of the previous page.
+	Cursor string
+}
+
+// List returns a page of the merchant's payouts.
+func (s *PayoutsService) List(
+	ctx context.Context,
+	params *ListPayoutsParams,
+	opts ...RequestOption,
+) (*ListPayoutsResponse, error) {
+	query := url.Values{}
+	if params != nil {
+		setQuery(query, "status", params.Status)
+		setQuery(query, "cursor", params.Cursor)
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+	}
+
+	var payouts ListPayoutsResponse
+	if _, err := s.client.do(ctx, http.MethodGet, "/api/v1/payouts", query, nil, &payouts, opts); err != nil {
+		return nil, err
+	}
+	return &payouts, nil
+}
+
+// Get returns a payout.
+func (s *PayoutsService) Get(ctx context.Context, id string, opts ...RequestOption) (*PayoutResponse, error) {
+	var payout PayoutResponse
+	if _, err := s.client.do(ctx, http.MethodGet, "/api/v1/payouts/"+escape(id), nil, nil, &payout, opts); err != nil {
+		return nil, err
+	}
+	return &payout, nil
+}
@@ -0,0 +1,210 @@
+package client
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// RedirectTokenParam is the query parameter of return and cancel URLs carrying the redirect token.
+	RedirectTokenParam = "checkout_token"
+
+	// redirectTokenLeeway is how far past its expiry a redirect token is still accepted, for clock skew.
+	redirectTokenLeeway = time.Minute
+	// redirectKeysRefreshInterval bounds how often a RedirectVerifier fetches the keys again for an unknown key ID.
+	redirectKeysRefreshInterval = time.Minute
+)
+
+var (
+	// ErrMissingRedirectToken is returned for a return or cancel URL without a redirect token.
+	ErrMissingRedirectToken = errors.New("client: redirect token is missing")
+	// ErrInvalidRedirectToken is returned for a redirect token that is malformed or whose signature does not match.
+	ErrInvalidRedirectToken = errors.New("client: redirect token is invalid")
+	// ErrUnknownRedirectKey is returned for a redirect token signed with a key that is not published.
+	ErrUnknownRedirectKey = errors.New("client: redirect token is signed with an unknown key")
+	// ErrRedirectTokenExpired is returned for a redirect token past its expiry, such as a replayed one.
+	ErrRedirectTokenExpired = errors.New("client: redirect token has expired")
+)
+
+// RedirectClaims are the invoice details of a redirect token, as of when the customer was sent back.
+type RedirectClaims struct {
+	InvoiceID  string `json:"invoice_id"`
+	MerchantID string `json:"aud"`
+	Status     string `json:"status"`
+	// AmountPaid is the amount received so far, in Currency.
+	AmountPaid string `json:"amount_paid"`
+	Currency   string `json:"currency"`
+	IssuedAt   int64  `json:"iat"`
+	ExpiresAt  int64  `json:"exp"`
+}
+
+// Paid reports whether the invoice was paid in full when the customer was sent back.
+func (c *RedirectClaims) Paid() bool {
+	return c.Status == "paid"
+}
+
+// RedirectKeys are the public keys redirect tokens are verified with, by key ID.
+type RedirectKeys map[string]ed25519.PublicKey
+
+// ParseRedirectKeys decodes the JSON Web Key Set served under /api/v1/public/redirect-keys. Keys that are not
+// Ed25519 signing keys are skipped.
+func ParseRedirectKeys(data []byte) (RedirectKeys, error) {
+	var set RedirectKeysResponse
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("client: failed to decode redirect keys: %w", err)
+	}
+	return newRedirectKeys(&set)
+}
+
+// newRedirectKeys decodes the Ed25519 keys of a key set.
+func newRedirectKeys(set *RedirectKeysResponse) (RedirectKeys, error) {
+	keys := make(RedirectKeys, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.KeyType != "OKP" || key.Curve != "Ed25519" {
+			continue
+		}
+		public, err := base64.RawURLEncoding.DecodeString(key.X)
+		if err != nil || len(public) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("client: redirect key %q is not an Ed25519 public key", key.KeyID)
+		}
+		keys[key.KeyID] = ed25519.PublicKey(public)
+	}
+	return keys, nil
+}
+
+// Verify checks that a redirect token was signed with one of the keys and has not expired, and returns its claims.
+// Merchants should still check that the invoice ID is the one of the order the customer returned for.
+func (k RedirectKeys) Verify(token string) (*RedirectClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidRedirectToken
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+	if err := decodeTokenPart(parts[0], &header); err != nil || header.Algorithm != "EdDSA" {
+		return nil, ErrInvalidRedirectToken
+	}
+	key, ok := k[header.KeyID]
+	if !ok {
+		return nil, ErrUnknownRedirectKey
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !ed25519.Verify(key, []byte(parts[0]+"."+parts[1]), signature) {
+		return nil, ErrInvalidRedirectToken
+	}
+
+	var claims RedirectClaims
+	if err := decodeTokenPart(parts[1], &claims); err != nil || claims.InvoiceID == "" {
+		return nil, ErrInvalidRedirectToken
+	}
+	if time.Now().After(time.Unix(claims.ExpiresAt, 0).Add(redirectTokenLeeway)) {
+		return nil, ErrRedirectTokenExpired
+	}
+	return &claims, nil
+}
+
+// VerifyRequest verifies the redirect token of a request to a return or cancel URL; see Verify.
+func (k RedirectKeys) VerifyRequest(r *http.Request) (*RedirectClaims, error) {
+	token := r.URL.Query().Get(RedirectTokenParam)
+	if token == "" {
+		return nil, ErrMissingRedirectToken
+	}
+	return k.Verify(token)
+}
+
+// decodeTokenPart decodes a base64url-encoded JSON part of a token.
+func decodeTokenPart(part string, out interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// RedirectKeys returns the public keys redirect tokens are currently verified with. Most integrations use a
+// RedirectVerifier, which keeps them up to date.
+func (c *Client) RedirectKeys(ctx context.Context, opts ...RequestOption) (RedirectKeys, error) {
+	var set RedirectKeysResponse
+	if _, err := c.do(ctx, http.MethodGet, "/api/v1/public/redirect-keys", nil, nil, &set, opts); err != nil {
+		return nil, err
+	}
+	return newRedirectKeys(&set)
+}
+
+// RedirectVerifier verifies redirect tokens with the keys of the API. It fetches the keys on first use and again
+// when a token names a key it does not know, as after a key rotation, at most once a minute.
+type RedirectVerifier struct {
+	client *Client
+
+	mu          sync.Mutex
+	keys        RedirectKeys
+	refreshedAt time.Time // When the keys were last fetched for an unknown key ID
+}
+
+// NewRedirectVerifier creates a verifier of redirect tokens fetching the keys through the client.
+func (c *Client) NewRedirectVerifier() *RedirectVerifier {
+	return &RedirectVerifier{client: c}
+}
+
+// Verify checks a redirect token and returns its claims; see RedirectKeys.Verify.
+func (v *RedirectVerifier) Verify(ctx context.Context, token string) (*RedirectClaims, error) {
+	keys, err := v.currentKeys(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := keys.Verify(token)
+	if !errors.Is(err, ErrUnknownRedirectKey) {
+		return claims, err
+	}
+
+	// The token may be signed with a key added since the keys were fetched
+	if keys, err = v.currentKeys(ctx, true); err != nil {
+		return nil, err
+	}
+	return keys.Verify(token)
+}
+
+// VerifyRequest verifies the redirect token of a request to a return or cancel URL; see Verify.
+func (v *RedirectVerifier) VerifyRequest(r *http.Request) (*RedirectClaims, error) {
+	token := r.URL.Query().Get(RedirectTokenParam)
+	if token == "" {
+		return nil, ErrMissingRedirectToken
+	}
+	return v.Verify(r.Context(), token)
+}
+
+// currentKeys returns the fetched keys, fetching them first if there are none, or if a refresh is requested and
+// the last one was long enough ago.
+func (v *RedirectVerifier) currentKeys(ctx context.Context, refresh bool) (RedirectKeys, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	refresh = refresh && time.Since(v.refreshedAt) >= redirectKeysRefreshInterval
+	if v.keys != nil && !refresh {
+		return v.keys, nil
+	}
+	if refresh {
+		v.refreshedAt = time.Now()
+	}
+	keys, err := v.client.RedirectKeys(ctx)
+	if err != nil {
+		if v.keys != nil {
+			return v.keys, nil
+		}
+		return nil, err
+	}
+	v.keys = keys
+	return keys, nil
+}
@@ -0,0 +1,132 @@
+package client_test
+
+import (
+	"context"
+	"crypto-checkout/pkg/client"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// redirectKey is a signing key of redirect tokens and its JSON Web Key.
+type redirectKey struct {
+	id      string
+	private ed25519.PrivateKey
+}
+
+func newRedirectKey(t *testing.T, id string) redirectKey {
+	_, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	return redirectKey{id: id, private: private}
+}
+
+func (k redirectKey) jwk() client.RedirectKeyResponse {
+	return client.RedirectKeyResponse{
+		KeyType:   "OKP",
+		Curve:     "Ed25519",
+		KeyID:     k.id,
+		Use:       "sig",
+		Algorithm: "EdDSA",
+		X:         base64.RawURLEncoding.EncodeToString(k.private.Public().(ed25519.PublicKey)),
+	}
+}
+
+func (k redirectKey) sign(t *testing.T, status string, expiresAt time.Time) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{
+		"iss":         "crypto-checkout",
+		"aud":         "merchant_123",
+		"invoice_id":  "inv_456",
+		"status":      status,
+		"amount_paid": "25.5",
+		"currency":    "USDT",
+		"iat":         time.Now().Unix(),
+		"exp":         expiresAt.Unix(),
+	})
+	token.Header["kid"] = k.id
+	signed, err := token.SignedString(k.private)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestRedirectVerification(t *testing.T) {
+	current := newRedirectKey(t, "2025-06")
+	keys := client.RedirectKeys{current.id: current.private.Public().(ed25519.PublicKey)}
+	expiresAt := time.Now().Add(10 * time.Minute)
+
+	t.Run("ValidToken_ReturnsClaims", func(t *testing.T) {
+		claims, err := keys.Verify(current.sign(t, "paid", expiresAt))
+		require.NoError(t, err)
+		require.Equal(t, "inv_456", claims.InvoiceID)
+		require.Equal(t, "merchant_123", claims.MerchantID)
+		require.Equal(t, "25.5", claims.AmountPaid)
+		require.True(t, claims.Paid())
+	})
+
+	t.Run("TamperedToken_IsRejected", func(t *testing.T) {
+		other := newRedirectKey(t, current.id)
+		_, err := keys.Verify(other.sign(t, "paid", expiresAt))
+		require.ErrorIs(t, err, client.ErrInvalidRedirectToken)
+
+		_, err = keys.Verify("not-a-token")
+		require.ErrorIs(t, err, client.ErrInvalidRedirectToken)
+	})
+
+	t.Run("ExpiredToken_IsRejected", func(t *testing.T) {
+		_, err := keys.Verify(current.sign(t, "paid", time.Now().Add(-time.Hour)))
+		require.ErrorIs(t, err, client.ErrRedirectTokenExpired)
+	})
+
+	t.Run("Request_ReadsTokenFromQuery", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet,
+			"https://shop.example.com/return?order=42&"+client.RedirectTokenParam+"="+current.sign(t, "expired", expiresAt),
+			nil)
+		claims, err := keys.VerifyRequest(r)
+		require.NoError(t, err)
+		require.False(t, claims.Paid())
+
+		_, err = keys.VerifyRequest(httptest.NewRequest(http.MethodGet, "https://shop.example.com/return", nil))
+		require.ErrorIs(t, err, client.ErrMissingRedirectToken)
+	})
+
+	t.Run("Verifier_FetchesKeysAgainAfterRotation", func(t *testing.T) {
+		rotated := newRedirectKey(t, "2025-09")
+		published := []client.RedirectKeyResponse{current.jwk()}
+		var fetches atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/api/v1/public/redirect-keys", r.URL.Path)
+			fetches.Add(1)
+			_ = json.NewEncoder(w).Encode(client.RedirectKeysResponse{Keys: published})
+		}))
+		defer server.Close()
+
+		c, err := client.New(server.URL, "sk_live_test123")
+		require.NoError(t, err)
+		verifier := c.NewRedirectVerifier()
+
+		_, err = verifier.Verify(context.Background(), current.sign(t, "paid", expiresAt))
+		require.NoError(t, err)
+		_, err = verifier.Verify(context.Background(), current.sign(t, "paid", expiresAt))
+		require.NoError(t, err)
+		require.Equal(t, int32(1), fetches.Load())
+
+		// The new key signs and the old one is still published for the tokens it signed
+		published = []client.RedirectKeyResponse{rotated.jwk(), current.jwk()}
+		claims, err := verifier.Verify(context.Background(), rotated.sign(t, "paid", expiresAt))
+		require.NoError(t, err)
+		require.Equal(t, "inv_456", claims.InvoiceID)
+		require.Equal(t, int32(2), fetches.Load())
+
+		// Unknown keys are fetched for at most once a minute
+		_, err = verifier.Verify(context.Background(), newRedirectKey(t, "forged").sign(t, "paid", expiresAt))
+		require.ErrorIs(t, err, client.ErrUnknownRedirectKey)
+		require.Equal(t, int32(2), fetches.Load())
+	})
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy decides how often and how long apart failed requests are retried. Network errors, 429 Too Many
+// Requests and 5xx responses are retried; other errors are returned at once. A POST is only retried when it
+// carries an idempotency key, so that a retry cannot create a second invoice or refund.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt; zero disables retries.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry. It doubles with every further retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts, including delays asked for in a Retry-After header.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy retries three times, waiting up to 0.5s, 1s and 2s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// NoRetries returns a policy that never retries.
+func NoRetries() RetryPolicy {
+	return RetryPolicy{}
+}
+
+// shouldRetry reports whether the failed attempt, counted from zero, is retried.
+func (p RetryPolicy) shouldRetry(attempt, status int, err error) bool {
+	if attempt >= p.MaxRetries {
+		return false
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// delay returns how long to wait after the failed attempt: the Retry-After the server asked for, or an
+// exponential backoff with jitter.
+func (p RetryPolicy) delay(attempt int, header http.Header) time.Duration {
+	if retryAfter, ok := parseRetryAfter(header); ok {
+		return min(retryAfter, p.MaxBackoff)
+	}
+
+	backoff := p.InitialBackoff << attempt
+	if backoff <= 0 || backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	// Jitter spreads out the retries of clients that failed together
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// SettlementsService reads the settlements of paid invoices.
+type SettlementsService struct {
+	client *Client
+}
+
+// ListSettlementsParams filters and pages a settlement listing. Zero values are left to the API defaults.
+type ListSettlementsParams struct {
+	// StartDate and EndDate bound the creation date of the settlements; both days are included.
+	StartDate time.Time
+	EndDate   time.Time
+	Status    string
+	Limit     int
+	// Cursor is the NextCursor of the previous page.
+	Cursor string
+}
+
+// List returns a page of the merchant's settlements and the totals of all settlements matching the filter.
+func (s *SettlementsService) List(
+	ctx context.Context,
+	params *ListSettlementsParams,
+	opts ...RequestOption,
+) (*ListSettlementsResponse, error) {
+	query := url.Values{}
+	if params != nil {
+		if !params.StartDate.IsZero() {
+			query.Set("start_date", params.StartDate.Format(time.DateOnly))
+		}
+		if !params.EndDate.IsZero() {
+			query.Set("end_date", params.EndDate.Format(time.DateOnly))
+		}
+		setQuery(query, "status", params.Status)
+		setQuery(query, "cursor", params.Cursor)
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+	}
+
+	var settlements ListSettlementsResponse
+	if _, err := s.client.do(ctx, http.MethodGet, "/api/v1/settlements", query, nil, &settlements, opts); err != nil {
+		return nil, err
+	}
+	return &settlements, nil
+}
+
+// Get returns a settlement.
+func (s *SettlementsService) Get(ctx context.Context, id string, opts ...RequestOption) (*SettlementResponse, error) {
+	var settlement SettlementResponse
+	if _, err := s.client.do(ctx, http.MethodGet, "/api/v1/settlements/"+escape(id), nil, nil, &settlement,
+		opts); err != nil {
+		return nil, err
+	}
+	return &settlement, nil
+}
@@ -0,0 +1,667 @@
+// Code generated by typegen from internal/presentation/web/dtos.go. DO NOT EDIT.
+
+package client
+
+import "time"
+
+// CreateInvoiceRequest represents the request payload for creating an invoice.
+type CreateInvoiceRequest struct {
+	Title             string                   `json:"title"`
+	Description       string                   `json:"description"`
+	Type              string                   `json:"type,omitempty"`           // Defaults to "standard"
+	MinimumAmount     string                   `json:"minimum_amount,omitempty"` // Smallest amount that settles a donation
+	Items             []InvoiceItemRequest     `json:"items"`
+	Discount          *DiscountRequest         `json:"discount,omitempty"`         // Invoice-level discount
+	CouponCode        string                   `json:"coupon_code,omitempty"`      // Merchant coupon applied after the discount
+	Tax               *string                  `json:"tax,omitempty"`              // Fixed tax amount (deprecated, use tax_rate)
+	TaxRate           string                   `json:"tax_rate"`                   // Tax rate as decimal (e.g., "0.10" for 10%)
+	TaxJurisdiction   string                   `json:"tax_jurisdiction,omitempty"` // Customer country or subdivision; replaces tax_rate with the merchant's tax rules
+	CustomerTaxID     string                   `json:"customer_tax_id,omitempty"`  // Business customer VAT/GST ID, enables reverse charge
+	Currency          string                   `json:"currency,omitempty"`
+	CryptoCurrency    string                   `json:"crypto_currency,omitempty"`
+	Network           string                   `json:"network,omitempty"` // Defaults to the crypto currency's first configured network
+	PriceLockDuration *int                     `json:"price_lock_duration,omitempty"`
+	ExpiresIn         *int                     `json:"expires_in,omitempty"`
+	PaymentTolerance  *PaymentToleranceRequest `json:"payment_tolerance,omitempty"`
+	WebhookURL        *string                  `json:"webhook_url,omitempty"`
+	ReturnURL         *string                  `json:"return_url,omitempty"`
+	CancelURL         *string                  `json:"cancel_url,omitempty"`
+	Metadata          map[string]interface{}   `json:"metadata,omitempty"`
+	CustomFields      []CustomFieldRequest     `json:"custom_fields,omitempty"`    // Shown to customers, unlike metadata
+	SettlementSplit   []SplitShareRequest      `json:"settlement_split,omitempty"` // Beneficiary merchants' shares
+	PassFee           bool                     `json:"pass_fee,omitempty"`         // Adds the platform fee on top of the total for the customer to pay
+	Draft             bool                     `json:"draft,omitempty"`            // Creates an editable draft; see POST /invoices/{id}/finalize
+}
+
+// InvoiceItemRequest represents an invoice item in the request.
+type InvoiceItemRequest struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Quantity    string           `json:"quantity"`
+	UnitPrice   string           `json:"unit_price"`
+	Discount    *DiscountRequest `json:"discount,omitempty"`
+	TaxCategory string           `json:"tax_category,omitempty"` // Selects the merchant's tax rules, defaults to "standard"
+}
+
+// DiscountRequest represents a fixed or percentage discount in the request.
+type DiscountRequest struct {
+	Type  string `json:"type"`
+	Value string `json:"value"` // Amount in the invoice currency, or 0-100 percent
+}
+
+// CustomFieldRequest represents a labelled value shown to customers on the checkout page and receipts.
+type CustomFieldRequest struct {
+	Label      string `json:"label"`
+	Value      string `json:"value"`
+	Type       string `json:"type,omitempty"`       // Defaults to "text"
+	Visibility string `json:"visibility,omitempty"` // Defaults to "all"
+}
+
+// SplitShareRequest represents a beneficiary merchant's share of what an invoice settles for.
+type SplitShareRequest struct {
+	BeneficiaryID string `json:"beneficiary_id"` // Merchant receiving the share
+	Type          string `json:"type"`
+	Value         string `json:"value"` // Invoice currency amount, or percent below 100
+}
+
+// SplitShareResponse represents a beneficiary merchant's share of an invoice's settlement.
+type SplitShareResponse struct {
+	BeneficiaryID string `json:"beneficiary_id"`
+	Type          string `json:"type"`
+	Value         string `json:"value"`
+}
+
+// CustomFieldResponse represents a custom field of an invoice.
+type CustomFieldResponse struct {
+	Label      string `json:"label"`
+	Value      string `json:"value"`
+	Type       string `json:"type"`
+	Visibility string `json:"visibility"`
+}
+
+// DiscountResponse represents a granted discount and the amount it took off.
+type DiscountResponse struct {
+	Type   string `json:"type"`
+	Value  string `json:"value"`
+	Amount string `json:"amount"`
+}
+
+// AppliedCouponResponse represents the coupon applied to an invoice.
+type AppliedCouponResponse struct {
+	Code   string `json:"code"`
+	Type   string `json:"type"`
+	Value  string `json:"value"`
+	Amount string `json:"amount"`
+}
+
+// TaxLineResponse represents a tax levied on an invoice item, or the sum of one tax across the invoice.
+type TaxLineResponse struct {
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Jurisdiction  string `json:"jurisdiction"`
+	Rate          string `json:"rate"`
+	TaxableAmount string `json:"taxable_amount"`
+	Amount        string `json:"amount"`
+	ReverseCharge bool   `json:"reverse_charge,omitempty"`
+}
+
+// InvoiceTaxResponse represents the per-rate tax summary of an invoice taxed by jurisdiction rules.
+type InvoiceTaxResponse struct {
+	Jurisdiction  string            `json:"jurisdiction"`
+	CustomerTaxID string            `json:"customer_tax_id,omitempty"`
+	ReverseCharge bool              `json:"reverse_charge"`
+	Note          string            `json:"note,omitempty"`
+	Lines         []TaxLineResponse `json:"lines"`
+}
+
+// DiscountBreakdownResponse represents where the discounts on an invoice came from.
+type DiscountBreakdownResponse struct {
+	Items   string                 `json:"items"` // Sum of the line item discounts, already deducted from the subtotal
+	Invoice *DiscountResponse      `json:"invoice,omitempty"`
+	Coupon  *AppliedCouponResponse `json:"coupon,omitempty"`
+}
+
+// PaymentToleranceRequest represents payment tolerance settings.
+type PaymentToleranceRequest struct {
+	UnderpaymentThreshold string `json:"underpayment_threshold"`
+	OverpaymentThreshold  string `json:"overpayment_threshold"`
+	OverpaymentAction     string `json:"overpayment_action"`
+}
+
+// PaymentToleranceResponse represents payment tolerance settings in responses.
+type PaymentToleranceResponse struct {
+	UnderpaymentThreshold string `json:"underpayment_threshold"`
+	OverpaymentThreshold  string `json:"overpayment_threshold"`
+	OverpaymentAction     string `json:"overpayment_action"`
+}
+
+// CreateInvoiceResponse represents the response payload for creating an invoice.
+type CreateInvoiceResponse struct {
+	ID             string                `json:"id"`
+	Number         string                `json:"number,omitempty"` // Accounting number, assigned once the invoice is issued
+	Items          []InvoiceItemResponse `json:"items"`
+	Subtotal       string                `json:"subtotal"`
+	DiscountAmount string                `json:"discount_amount"`
+	TaxAmount      string                `json:"tax_amount"`
+	FeeAmount      string                `json:"fee_amount,omitempty"`     // Platform fee the customer pays on top
+	FeePercentage  string                `json:"fee_percentage,omitempty"` // Fee percentage quoted for the invoice
+	Total          string                `json:"total"`
+	TaxRate        string                `json:"tax_rate"`
+	Status         string                `json:"status"`
+	Type           string                `json:"type"`
+	MinimumAmount  *string               `json:"minimum_amount,omitempty"` // Donation minimum in the invoice currency
+	AmountReceived string                `json:"amount_received"`          // Cumulative amount received in the cryptocurrency
+	PaymentAddress *string               `json:"payment_address,omitempty"`
+	Network        string                `json:"network,omitempty"` // Network the payment address is on
+	// Reference telling apart the invoices paid to one address, and the memo customers attach on networks
+	// whose transfers carry one; elsewhere usdt_amount is fingerprinted with the reference instead
+	PaymentReference string    `json:"payment_reference,omitempty"`
+	Memo             string    `json:"memo,omitempty"`
+	InvoiceURL       string    `json:"invoice_url"`
+	CreatedAt        time.Time `json:"created_at"`
+	// API.md required fields
+	USDTAmount  string    `json:"usdt_amount"`
+	Address     string    `json:"address"`
+	CustomerURL string    `json:"customer_url"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	ExpiresIn   *int      `json:"expires_in,omitempty"` // Seconds a draft stays payable once finalized
+	// Payment tolerance settings
+	PaymentTolerance *PaymentToleranceResponse `json:"payment_tolerance,omitempty"`
+	// Discount breakdown, present when any discount or coupon applies
+	Discounts *DiscountBreakdownResponse `json:"discounts,omitempty"`
+	// Tax summary, present when the invoice was taxed by jurisdiction rules
+	Taxes *InvoiceTaxResponse `json:"taxes,omitempty"`
+	// Refund breakdown, present once the invoice is paid
+	Refunds *InvoiceRefundsResponse `json:"refunds,omitempty"`
+	// Where refunds are sent, present once a sender was captured or the customer supplied an address
+	RefundDestination *RefundDestinationResponse `json:"refund_destination,omitempty"`
+	// Exchange rate lock; the rate is re-quoted when it expires before the invoice is paid
+	RateExpiresAt time.Time         `json:"rate_expires_at"`
+	Requotes      []RequoteResponse `json:"requotes,omitempty"`
+	// How payments arriving after the rate expired were handled
+	StaleRateChecks []StaleRateCheckResponse `json:"stale_rate_checks,omitempty"`
+	// Confirmed payments taken back because a deep chain reorganization removed them
+	PaymentReversals []PaymentReversalResponse `json:"payment_reversals,omitempty"`
+	// Amendment chain: the invoice this one replaced, and the invoice that replaced it
+	Supersedes   *string `json:"supersedes,omitempty"`
+	SupersededBy *string `json:"superseded_by,omitempty"`
+	// Labelled values shown to customers, whatever their visibility
+	CustomFields []CustomFieldResponse `json:"custom_fields,omitempty"`
+	// Beneficiary merchants' shares of the settlement; the merchant keeps the rest
+	SettlementSplit []SplitShareResponse `json:"settlement_split,omitempty"`
+	// Refused attempts to view the checkout from countries the merchant restricts, when fetched one by one
+	GeoBlocks []GeoBlockResponse `json:"geo_blocks,omitempty"`
+}
+
+// InvoiceItemResponse represents an invoice item in the response.
+type InvoiceItemResponse struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	UnitPrice   string            `json:"unit_price"`
+	Quantity    string            `json:"quantity"`
+	Discount    *DiscountResponse `json:"discount,omitempty"`
+	Total       string            `json:"total"`
+	TaxCategory string            `json:"tax_category,omitempty"`
+	TaxLines    []TaxLineResponse `json:"tax_lines,omitempty"`
+}
+
+// PublicInvoiceResponse represents the public invoice data for customers.
+type PublicInvoiceResponse struct {
+	ID              string                     `json:"id"`
+	Number          string                     `json:"number,omitempty"` // Accounting number of the issued invoice
+	Title           string                     `json:"title"`
+	Description     string                     `json:"description"`
+	Type            string                     `json:"type"`
+	MinimumAmount   *string                    `json:"minimum_amount,omitempty"` // Donations accept any amount at or above this
+	Items           []InvoiceItemResponse      `json:"items"`
+	Subtotal        string                     `json:"subtotal"`
+	DiscountAmount  string                     `json:"discount_amount"`
+	Discounts       *DiscountBreakdownResponse `json:"discounts,omitempty"`
+	TaxAmount       string                     `json:"tax_amount"`
+	Taxes           *InvoiceTaxResponse        `json:"taxes,omitempty"`
+	FeeAmount       string                     `json:"fee_amount,omitempty"` // Platform fee added on top of the price
+	Total           string                     `json:"total"`
+	Currency        string                     `json:"currency"`
+	CryptoCurrency  string                     `json:"crypto_currency"`
+	Network         string                     `json:"network,omitempty"`
+	USDTAmount      string                     `json:"usdt_amount"`
+	Address         string                     `json:"address"`
+	Memo            string                     `json:"memo,omitempty"` // Memo to attach to the transfer, on networks with memos
+	Status          string                     `json:"status"`
+	ExpiresAt       time.Time                  `json:"expires_at"`
+	CreatedAt       time.Time                  `json:"created_at"`
+	PaidAt          *time.Time                 `json:"paid_at,omitempty"`
+	Payments        []PublicPaymentResponse    `json:"payments,omitempty"`
+	PaymentProgress *PaymentProgressResponse   `json:"payment_progress,omitempty"`
+	ReturnURL       *string                    `json:"return_url,omitempty"` // With a checkout_token when redirect keys are configured
+	CancelURL       *string                    `json:"cancel_url,omitempty"` // With a checkout_token when redirect keys are configured
+	TimeRemaining   int64                      `json:"time_remaining,omitempty"`
+	RateExpiresAt   time.Time                  `json:"rate_expires_at"`
+	Requotes        []RequoteResponse          `json:"requotes,omitempty"`
+	Currencies      []CurrencyResponse         `json:"accepted_currencies,omitempty"` // Offered while unpaid
+	CheckoutOptions []CheckoutOptionResponse   `json:"checkout_options,omitempty"`    // Payment processors to pay through
+	SupersededBy    *string                    `json:"superseded_by,omitempty"`       // Replacement invoice to pay instead
+	CustomFields    []CustomFieldResponse      `json:"custom_fields,omitempty"`       // Fields the merchant shows on the checkout page
+}
+
+// RedirectKeysResponse represents the public keys redirect tokens are verified with, as a JSON Web Key Set.
+type RedirectKeysResponse struct {
+	Keys []RedirectKeyResponse `json:"keys"`
+}
+
+// RedirectKeyResponse represents an Ed25519 public key as a JSON Web Key.
+type RedirectKeyResponse struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	X         string `json:"x"` // Base64url-encoded public key
+}
+
+// PublicPaymentResponse represents payment data visible to customers.
+type PublicPaymentResponse struct {
+	Amount        string     `json:"amount"`
+	Status        string     `json:"status"`
+	Confirmations int        `json:"confirmations"`
+	ConfirmedAt   *time.Time `json:"confirmed_at,omitempty"`
+}
+
+// PaymentProgressResponse represents payment progress information.
+type PaymentProgressResponse struct {
+	Received  string  `json:"received"`
+	Required  string  `json:"required"`
+	Remaining string  `json:"remaining"`
+	Percent   float64 `json:"percent"`
+}
+
+// PublicInvoiceStatusResponse is the invoice state customer-facing clients poll. The countdowns are counted
+// from Timestamp.
+type PublicInvoiceStatusResponse struct {
+	ID                 string                   `json:"id"`
+	Status             string                   `json:"status"`
+	ProgressPercentage float64                  `json:"progress_percentage"` // Share of the amount due received, 0 to 100
+	RemainingAmount    string                   `json:"remaining_amount"`
+	Confirmations      int                      `json:"confirmations"`
+	ExpiresInSeconds   *int64                   `json:"expires_in_seconds,omitempty"` // Absent when the invoice does not expire or is final
+	NextPollAfter      *int64                   `json:"next_poll_after,omitempty"`    // Seconds to wait before polling again; absent once the invoice is final
+	ConfirmationETA    *ConfirmationETAResponse `json:"confirmation_eta,omitempty"`   // Set while payments confirm
+	Timestamp          time.Time                `json:"timestamp"`
+}
+
+// ConfirmationETAResponse is the expected time until the last payment of an invoice still waiting for
+// confirmations is confirmed.
+type ConfirmationETAResponse struct {
+	Network                string    `json:"network"`
+	RemainingConfirmations int       `json:"remaining_confirmations"`
+	BlockTimeSeconds       float64   `json:"block_time_seconds"`
+	BlockTimeSource        string    `json:"block_time_source"` // observed or nominal
+	SecondsRemaining       int64     `json:"seconds_remaining"`
+	EstimatedAt            time.Time `json:"estimated_at"`
+}
+
+// ListInvoicesResponse represents the response for listing invoices.
+type ListInvoicesResponse struct {
+	Invoices   []CreateInvoiceResponse `json:"invoices"`
+	Total      int                     `json:"total"`
+	Page       int                     `json:"page"`
+	Limit      int                     `json:"limit"`
+	Pages      int                     `json:"pages"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+}
+
+// InvoiceStatusBatchRequest represents the request payload for reading the status of many invoices at once.
+type InvoiceStatusBatchRequest struct {
+	InvoiceIDs []string `json:"invoice_ids"`
+}
+
+// InvoiceStatusBatchResponse maps invoice IDs to their payment state.
+// Requested IDs that match no invoice of the merchant are listed in NotFound.
+type InvoiceStatusBatchResponse struct {
+	Invoices map[string]InvoiceStatusSummaryResponse `json:"invoices"`
+	NotFound []string                                `json:"not_found"`
+}
+
+// InvoiceStatusSummaryResponse represents the payment state of an invoice in a status batch.
+type InvoiceStatusSummaryResponse struct {
+	Status          string `json:"status"`
+	PaidAmount      string `json:"paid_amount"`
+	RemainingAmount string `json:"remaining_amount"`
+	Confirmations   int    `json:"confirmations"`
+}
+
+// CancelInvoiceRequest represents the request payload for cancelling an invoice.
+type CancelInvoiceRequest struct {
+	Reason string `json:"reason"`
+}
+
+// RefundInvoiceRequest represents the request payload for refunding an invoice.
+type RefundInvoiceRequest struct {
+	Amount string `json:"amount"` // Amount in the invoice cryptocurrency; empty refunds the remaining amount
+	Reason string `json:"reason"`
+}
+
+// RefundInvoiceResponse represents the response payload for refunding an invoice.
+type RefundInvoiceResponse struct {
+	InvoiceID string                  `json:"invoice_id"`
+	Status    string                  `json:"status"`
+	Refund    InvoiceRefundResponse   `json:"refund"`
+	Refunds   *InvoiceRefundsResponse `json:"refunds"`
+}
+
+// InvoiceRefundsResponse represents the refund breakdown of a paid invoice.
+type InvoiceRefundsResponse struct {
+	AmountPaid       string                  `json:"amount_paid"`
+	RefundedAmount   string                  `json:"refunded_amount"`
+	RefundableAmount string                  `json:"refundable_amount"`
+	Items            []InvoiceRefundResponse `json:"items"`
+}
+
+// InvoiceRefundResponse represents a single refund of an invoice.
+type InvoiceRefundResponse struct {
+	ID          string    `json:"id"`
+	Amount      string    `json:"amount"`
+	Reason      string    `json:"reason,omitempty"`
+	RequestedBy string    `json:"requested_by,omitempty"`
+	Destination string    `json:"destination,omitempty"` // Address the refund is sent to
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// RefundDestinationResponse represents where refunds of an invoice are sent.
+type RefundDestinationResponse struct {
+	Address     string     `json:"address"`
+	Source      string     `json:"source"`   // "sender" of the confirmed payments, or "customer" on the checkout page
+	Verified    bool       `json:"verified"` // The customer signed a message with the address
+	CapturedAt  time.Time  `json:"captured_at"`
+	Confirmed   bool       `json:"confirmed"` // Refunds are only sent once the merchant confirms the address
+	ConfirmedBy string     `json:"confirmed_by,omitempty"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+}
+
+// CancelInvoiceResponse represents the response payload for cancelling an invoice.
+type CancelInvoiceResponse struct {
+	ID          string    `json:"id"`
+	Status      string    `json:"status"`
+	Reason      string    `json:"reason"`
+	CancelledAt time.Time `json:"cancelled_at"`
+}
+
+// ErrorResponse represents an error response payload.
+type ErrorResponse struct {
+	Error     string                 `json:"error"`
+	Message   string                 `json:"message,omitempty"`
+	Code      string                 `json:"code,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	Timestamp string                 `json:"timestamp,omitempty"`
+	RequestID string                 `json:"request_id,omitempty"`
+}
+
+// ScreeningResponse represents the AML/KYT screening of a payment and its review.
+type ScreeningResponse struct {
+	ID         string     `json:"id"`
+	PaymentID  string     `json:"payment_id"`
+	InvoiceID  string     `json:"invoice_id"`
+	Address    string     `json:"address"`
+	Network    string     `json:"network,omitempty"`
+	Provider   string     `json:"provider"`
+	RiskLevel  string     `json:"risk_level"`
+	RiskScore  float64    `json:"risk_score"`
+	Categories []string   `json:"categories"`
+	Reference  string     `json:"reference,omitempty"`
+	Failure    string     `json:"failure,omitempty"`
+	Status     string     `json:"status"`
+	ReviewedBy string     `json:"reviewed_by,omitempty"`
+	ReviewNote string     `json:"review_note,omitempty"`
+	ScreenedAt time.Time  `json:"screened_at"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// PaymentDetailResponse is the full detail of a payment, with the chain data merchants reconcile it with.
+type PaymentDetailResponse struct {
+	ID                    string                 `json:"id"`
+	Status                string                 `json:"status"`
+	Amount                string                 `json:"amount"`
+	CryptoCurrency        string                 `json:"crypto_currency"`
+	Network               string                 `json:"network"`
+	TransactionHash       string                 `json:"tx_hash"`
+	FromAddresses         []string               `json:"from_addresses"`
+	ToAddress             string                 `json:"to_address"`
+	BlockNumber           *int64                 `json:"block_number,omitempty"` // Set once the transaction is in a block
+	BlockHash             string                 `json:"block_hash,omitempty"`
+	Confirmations         int                    `json:"confirmations"`
+	RequiredConfirmations int                    `json:"required_confirmations"`
+	NetworkFee            string                 `json:"network_fee,omitempty"`
+	NetworkFeeCurrency    string                 `json:"network_fee_currency,omitempty"`
+	Explorer              PaymentExplorerLinks   `json:"explorer"`
+	Screening             *ScreeningResponse     `json:"screening,omitempty"` // Absent when the sender was not screened
+	Invoice               PaymentInvoiceResponse `json:"invoice"`
+	DetectedAt            time.Time              `json:"detected_at"`
+	ConfirmedAt           *time.Time             `json:"confirmed_at,omitempty"`
+	CreatedAt             time.Time              `json:"created_at"`
+	UpdatedAt             time.Time              `json:"updated_at"`
+}
+
+// PaymentExplorerLinks are the pages of a payment's transaction, addresses and block on the block explorer of
+// its network.
+type PaymentExplorerLinks struct {
+	Transaction   string   `json:"transaction,omitempty"`
+	FromAddresses []string `json:"from_addresses,omitempty"`
+	ToAddress     string   `json:"to_address,omitempty"`
+	Block         string   `json:"block,omitempty"`
+}
+
+// PaymentInvoiceResponse is the invoice a payment pays.
+type PaymentInvoiceResponse struct {
+	ID             string `json:"id"`
+	Number         string `json:"number,omitempty"`
+	Status         string `json:"status"`
+	AmountReceived string `json:"amount_received"` // Cumulative amount received in the cryptocurrency
+}
+
+// CheckoutOptionResponse represents a payment processor checkout offered on the payment page.
+type CheckoutOptionResponse struct {
+	Processor   string     `json:"processor"`
+	DisplayName string     `json:"display_name"`
+	URL         string     `json:"url"`
+	QRContent   string     `json:"qr_content,omitempty"` // Content to render as a QR code, for processors with apps
+	Amount      string     `json:"amount"`
+	Currency    string     `json:"currency"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+// CurrencyResponse represents a cryptocurrency on one network that invoices may be paid in.
+type CurrencyResponse struct {
+	Symbol   string `json:"symbol"`
+	Network  string `json:"network"`
+	Contract string `json:"contract,omitempty"` // Empty for the network's native coin
+	Decimals int32  `json:"decimals"`
+	Default  bool   `json:"default"` // Network used when an invoice names only the symbol
+}
+
+// ListCurrenciesResponse represents the cryptocurrencies a merchant's invoices may be paid in.
+type ListCurrenciesResponse struct {
+	Currencies []CurrencyResponse `json:"currencies"`
+}
+
+// RequoteResponse represents the replacement of an expired exchange rate in the invoice timeline.
+type RequoteResponse struct {
+	PreviousRate         string    `json:"previous_rate"`
+	NewRate              string    `json:"new_rate"`
+	PreviousCryptoAmount string    `json:"previous_crypto_amount"`
+	NewCryptoAmount      string    `json:"new_crypto_amount"`
+	Slippage             string    `json:"slippage"` // Relative rate change, e.g. "0.0125" for 1.25%
+	Source               string    `json:"source"`
+	RequotedAt           time.Time `json:"requoted_at"`
+}
+
+// StaleRateCheckResponse represents the handling of a payment that arrived after the invoice's exchange rate
+// expired, in the invoice timeline.
+type StaleRateCheckResponse struct {
+	PaymentID   string    `json:"payment_id"`
+	Action      string    `json:"action"` // accept, review or requote
+	LockedRate  string    `json:"locked_rate"`
+	CurrentRate string    `json:"current_rate,omitempty"` // Omitted when no rate could be quoted
+	Slippage    string    `json:"slippage,omitempty"`
+	Reason      string    `json:"reason"`
+	CheckedAt   time.Time `json:"checked_at"`
+}
+
+// PaymentReversalResponse represents a confirmed payment taken back from the invoice after a deep chain
+// reorganization, in the invoice timeline.
+type PaymentReversalResponse struct {
+	PaymentID       string    `json:"payment_id"`
+	TransactionHash string    `json:"transaction_hash,omitempty"`
+	Amount          string    `json:"amount"`
+	PreviousStatus  string    `json:"previous_status"`
+	Status          string    `json:"status"` // pending, or partial when other payments remain
+	Reason          string    `json:"reason"`
+	ReversedAt      time.Time `json:"reversed_at"`
+}
+
+// ListSettlementsResponse represents the response for listing settlements.
+type ListSettlementsResponse struct {
+	Settlements []SettlementResponse      `json:"settlements"`
+	Summary     SettlementSummaryResponse `json:"summary"`
+	// Time zone the start and end dates were read in, e.g. "UTC" or "Europe/Berlin"
+	ReportingTimeZone string `json:"reporting_time_zone"`
+	Limit             int    `json:"limit"`
+	NextCursor        string `json:"next_cursor,omitempty"`
+}
+
+// SettlementSummaryResponse totals the settlements matching a listing filter, net of the reversals recorded in
+// the period when no status is filtered.
+type SettlementSummaryResponse struct {
+	TotalGrossAmount     string `json:"total_gross_amount"`
+	TotalPlatformFees    string `json:"total_platform_fees"`
+	TotalNetAmount       string `json:"total_net_amount"`
+	AverageFeePercentage string `json:"average_fee_percentage"`
+	SettlementCount      int    `json:"settlement_count"`
+}
+
+// SettlementResponse represents what a merchant receives for a paid invoice.
+type SettlementResponse struct {
+	ID                    string                        `json:"id"`
+	InvoiceID             string                        `json:"invoice_id"`
+	MerchantID            string                        `json:"merchant_id"`
+	GrossAmount           string                        `json:"gross_amount"`
+	PlatformFeeAmount     string                        `json:"platform_fee_amount"`
+	PlatformFeePercentage string                        `json:"platform_fee_percentage"`
+	NetAmount             string                        `json:"net_amount"`
+	Currency              string                        `json:"currency"`
+	Status                string                        `json:"status"`
+	FailureReason         string                        `json:"failure_reason,omitempty"`
+	FeeTier               *SettlementFeeTierResponse    `json:"fee_tier,omitempty"` // How the platform fee was chosen
+	Conversion            *SettlementConversionResponse `json:"conversion,omitempty"`
+	PayoutID              string                        `json:"payout_id,omitempty"`
+	SplitOf               string                        `json:"split_of,omitempty"` // Settlement a beneficiary's share was split from
+	Splits                []SettlementSplitResponse     `json:"splits,omitempty"`   // Beneficiaries' shares split from the settlement
+	Reversal              *SettlementReversalResponse   `json:"reversal,omitempty"` // Present on a reversed settlement
+	SettledAt             *time.Time                    `json:"settled_at,omitempty"`
+	CreatedAt             time.Time                     `json:"created_at"`
+}
+
+// SettlementFeeTierResponse represents how the platform fee of a settlement was chosen: the merchant's or platform's
+// flat fee, or a volume tier, network rate or promotion of a fee schedule.
+type SettlementFeeTierResponse struct {
+	Schedule       string `json:"schedule,omitempty"`
+	Kind           string `json:"kind"`             // flat, volume, network or promotion
+	Name           string `json:"name"`             // Tier minimum volume, network, promotion, or merchant or platform
+	Volume         string `json:"volume,omitempty"` // 30-day settled volume the schedule's rate was chosen by
+	MinimumApplied bool   `json:"minimum_applied"`  // The schedule's minimum fee was charged
+}
+
+// SettlementSplitResponse represents a beneficiary merchant's share split from a settlement, settled and paid
+// out to the beneficiary.
+type SettlementSplitResponse struct {
+	ID                string `json:"id"`
+	BeneficiaryID     string `json:"beneficiary_id"`
+	GrossAmount       string `json:"gross_amount"`
+	PlatformFeeAmount string `json:"platform_fee_amount"`
+	NetAmount         string `json:"net_amount"`
+	Status            string `json:"status"`
+}
+
+// SettlementReversalResponse represents the ledger entry offsetting a settlement whose payment a chain
+// reorganization removed. Its amounts are negative.
+type SettlementReversalResponse struct {
+	ID                string    `json:"id"`
+	PaymentID         string    `json:"payment_id"`
+	GrossAmount       string    `json:"gross_amount"`
+	PlatformFeeAmount string    `json:"platform_fee_amount"`
+	NetAmount         string    `json:"net_amount"`
+	Reason            string    `json:"reason"`
+	PaidOut           bool      `json:"paid_out"` // The net amount was already paid out, so the merchant owes it
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// SettlementConversionResponse represents the exchange order converting a settlement to fiat.
+type SettlementConversionResponse struct {
+	Exchange      string     `json:"exchange"`
+	OrderID       string     `json:"order_id"`
+	Status        string     `json:"status"`
+	Amount        string     `json:"amount"` // Cryptocurrency sold
+	FiatCurrency  string     `json:"fiat_currency"`
+	FiatAmount    string     `json:"fiat_amount,omitempty"` // Realized, net of the conversion fee
+	Fee           string     `json:"fee,omitempty"`
+	Rate          string     `json:"rate,omitempty"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	SubmittedAt   time.Time  `json:"submitted_at"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+}
+
+// PayoutResponse represents an on-chain payout of settled funds.
+type PayoutResponse struct {
+	ID            string     `json:"id"`
+	WalletID      string     `json:"wallet_id"`
+	Network       string     `json:"network"`
+	Address       string     `json:"address"`
+	Amount        string     `json:"amount"`
+	Currency      string     `json:"currency"`
+	Status        string     `json:"status"`
+	TxHash        string     `json:"tx_hash,omitempty"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	SettlementIDs []string   `json:"settlement_ids"`
+	CreatedAt     time.Time  `json:"created_at"`
+	BroadcastAt   *time.Time `json:"broadcast_at,omitempty"`
+	ConfirmedAt   *time.Time `json:"confirmed_at,omitempty"`
+}
+
+// ListPayoutsResponse represents the response for listing payouts.
+type ListPayoutsResponse struct {
+	Payouts    []PayoutResponse `json:"payouts"`
+	Limit      int              `json:"limit"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// ApprovalVoteResponse represents one approver's vote on a transfer.
+type ApprovalVoteResponse struct {
+	UserID   string    `json:"user_id"`
+	Decision string    `json:"decision"`
+	Note     string    `json:"note,omitempty"`
+	VotedAt  time.Time `json:"voted_at"`
+}
+
+// ApprovalResponse represents a refund or payout held until enough approvers sign off on it.
+type ApprovalResponse struct {
+	ID          string                 `json:"id"`
+	Kind        string                 `json:"kind"`
+	Reference   string                 `json:"reference"`
+	Currency    string                 `json:"currency"`
+	Amount      string                 `json:"amount"`
+	Reason      string                 `json:"reason,omitempty"`
+	RequestedBy string                 `json:"requested_by,omitempty"`
+	Status      string                 `json:"status"`
+	Required    int                    `json:"required"`
+	Approvals   int                    `json:"approvals"`
+	Votes       []ApprovalVoteResponse `json:"votes"`
+	CreatedAt   time.Time              `json:"created_at"`
+	DecidedAt   *time.Time             `json:"decided_at,omitempty"`
+}
+
+// GeoBlockResponse represents the refused attempts to view an invoice's checkout from a restricted country.
+type GeoBlockResponse struct {
+	Country        string    `json:"country"`
+	Attempts       int       `json:"attempts"`
+	FirstBlockedAt time.Time `json:"first_blocked_at"`
+	LastBlockedAt  time.Time `json:"last_blocked_at"`
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// WebhookTimestampHeader carries the Unix time, in seconds, at which a webhook was sent.
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	// WebhookSignatureHeader carries the signature of a webhook; see SignWebhook. While a rotated secret overlaps
+	// its replacement, it carries the signatures with both secrets, separated by a comma.
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	// WebhookDeliveryHeader carries the ID of a webhook delivery. Replays of an event keep its ID but are new
+	// deliveries.
+	WebhookDeliveryHeader = "X-Webhook-Delivery"
+
+	// DefaultWebhookTolerance is how far a webhook timestamp may be from the local clock.
+	DefaultWebhookTolerance = 5 * time.Minute
+
+	// maxWebhookBodySize bounds the webhook body read by ParseWebhook.
+	maxWebhookBodySize = 1 << 20
+)
+
+var (
+	// ErrMissingSignature is returned for a webhook without timestamp or signature header.
+	ErrMissingSignature = errors.New("client: webhook signature headers are missing")
+	// ErrInvalidSignature is returned for a webhook whose signature does not match its body.
+	ErrInvalidSignature = errors.New("client: webhook signature is invalid")
+	// ErrTimestampOutOfTolerance is returned for a webhook sent too long ago, such as a replayed one.
+	ErrTimestampOutOfTolerance = errors.New("client: webhook timestamp is outside the tolerance")
+)
+
+// WebhookEvent is a webhook notification. Data holds the event payload, such as the settlement and invoice of a
+// settlement.completed event, in the API version of the webhook endpoint. APIVersion is empty for endpoints on the
+// original 2025-01-15 version.
+type WebhookEvent struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	APIVersion string          `json:"api_version,omitempty"`
+	Created    time.Time       `json:"created"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// SignWebhook returns the signature sent in WebhookSignatureHeader: the hex HMAC-SHA256, keyed with the webhook
+// endpoint secret, of the timestamp, a dot and the raw request body.
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature checks that the webhook body was signed with the endpoint secret and sent within
+// tolerance of now, accepting any of the signatures sent while the endpoint secret is rotated. A non-positive
+// tolerance uses DefaultWebhookTolerance.
+func VerifyWebhookSignature(secret string, header http.Header, body []byte, tolerance time.Duration) error {
+	timestamp := header.Get(WebhookTimestampHeader)
+	signature := header.Get(WebhookSignatureHeader)
+	if timestamp == "" || signature == "" {
+		return ErrMissingSignature
+	}
+
+	expected := []byte(SignWebhook(secret, timestamp, body))
+	valid := false
+	for candidate := range strings.SplitSeq(signature, ",") {
+		if hmac.Equal([]byte(strings.TrimSpace(candidate)), expected) {
+			valid = true
+		}
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if tolerance <= 0 {
+		tolerance = DefaultWebhookTolerance
+	}
+	if skew := time.Since(time.Unix(seconds, 0)); skew > tolerance || skew < -tolerance {
+		return ErrTimestampOutOfTolerance
+	}
+	return nil
+}
+
+// ParseWebhook reads a webhook request, verifies its signature with the endpoint secret and decodes the event.
+func ParseWebhook(r *http.Request, secret string) (*WebhookEvent, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to read webhook: %w", err)
+	}
+	if err := VerifyWebhookSignature(secret, r.Header, body, DefaultWebhookTolerance); err != nil {
+		return nil, err
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("client: failed to decode webhook: %w", err)
+	}
+	return &event, nil
+}
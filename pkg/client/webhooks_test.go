@@ -0,0 +1,70 @@
+package client_test
+
+import (
+	"bytes"
+	"crypto-checkout/pkg/client"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookVerification(t *testing.T) {
+	const secret = "whsec_0123456789abcdef0123456789abcdef"
+	body := []byte(`{"id":"evt_123","type":"settlement.completed","created":"2025-01-15T10:18:30Z",` +
+		`"data":{"settlement":{"id":"set_456"}}}`)
+
+	webhook := func(body []byte, sentAt time.Time, signWith string) *http.Request {
+		timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+		req.Header.Set(client.WebhookTimestampHeader, timestamp)
+		req.Header.Set(client.WebhookSignatureHeader, client.SignWebhook(signWith, timestamp, body))
+		return req
+	}
+
+	t.Run("ValidSignature_DecodesEvent", func(t *testing.T) {
+		event, err := client.ParseWebhook(webhook(body, time.Now(), secret), secret)
+		require.NoError(t, err)
+		require.Equal(t, "evt_123", event.ID)
+		require.Equal(t, "settlement.completed", event.Type)
+		require.JSONEq(t, `{"settlement":{"id":"set_456"}}`, string(event.Data))
+	})
+
+	t.Run("WrongSecret_Rejected", func(t *testing.T) {
+		_, err := client.ParseWebhook(webhook(body, time.Now(), "whsec_other"), secret)
+		require.ErrorIs(t, err, client.ErrInvalidSignature)
+	})
+
+	t.Run("RotatedSecret_EitherSignatureAccepted", func(t *testing.T) {
+		const previous = "whsec_fedcba9876543210fedcba9876543210"
+		req := webhook(body, time.Now(), secret)
+		timestamp := req.Header.Get(client.WebhookTimestampHeader)
+		req.Header.Set(client.WebhookSignatureHeader, req.Header.Get(client.WebhookSignatureHeader)+","+
+			client.SignWebhook(previous, timestamp, body))
+
+		require.NoError(t, client.VerifyWebhookSignature(secret, req.Header, body, 0))
+		require.NoError(t, client.VerifyWebhookSignature(previous, req.Header, body, 0))
+		err := client.VerifyWebhookSignature("whsec_other", req.Header, body, 0)
+		require.ErrorIs(t, err, client.ErrInvalidSignature)
+	})
+
+	t.Run("TamperedBody_Rejected", func(t *testing.T) {
+		req := webhook(body, time.Now(), secret)
+		tampered := bytes.Replace(body, []byte("set_456"), []byte("set_999"), 1)
+		err := client.VerifyWebhookSignature(secret, req.Header, tampered, 0)
+		require.ErrorIs(t, err, client.ErrInvalidSignature)
+	})
+
+	t.Run("StaleTimestamp_Rejected", func(t *testing.T) {
+		_, err := client.ParseWebhook(webhook(body, time.Now().Add(-time.Hour), secret), secret)
+		require.ErrorIs(t, err, client.ErrTimestampOutOfTolerance)
+	})
+
+	t.Run("MissingHeaders_Rejected", func(t *testing.T) {
+		err := client.VerifyWebhookSignature(secret, http.Header{}, body, 0)
+		require.ErrorIs(t, err, client.ErrMissingSignature)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -18,28 +19,260 @@ const (
 	DefaultLogLevel = "info"
 	// DefaultLogDir is the default log directory.
 	DefaultLogDir = "logs"
+	// DefaultLogSamplingTick is the default period over which sampled debug logs are counted.
+	DefaultLogSamplingTick = time.Second
+	// DefaultLogSamplingInitial is the default number of identical sampled debug logs written each tick.
+	DefaultLogSamplingInitial = 10
+	// DefaultLogSamplingThereafter is the default share, one in how many, of the further identical ones written.
+	DefaultLogSamplingThereafter = 100
 	// DefaultPostgresPort is the default PostgreSQL port.
 	DefaultPostgresPort = 5432
+	// DefaultAccessTokenTTL is the default lifetime of dashboard session access tokens.
+	DefaultAccessTokenTTL = 15 * time.Minute
+	// DefaultComplianceTimeout is the default timeout for screening provider requests.
+	DefaultComplianceTimeout = 10 * time.Second
+	// DefaultHoldRiskLevel is the default risk level at which payments are held for review.
+	DefaultHoldRiskLevel = "high"
+	// DefaultSanctionsListURL is the default source of the OFAC SDN list.
+	DefaultSanctionsListURL = "https://sanctionslistservice.ofac.treas.gov/api/PublicationPreview/exports/SDN.XML"
+	// DefaultSanctionsSyncInterval is the default interval between sanctions list downloads.
+	DefaultSanctionsSyncInterval = 24 * time.Hour
+	// DefaultBlocklistReloadInterval is the default interval between blocklist index reloads.
+	DefaultBlocklistReloadInterval = time.Minute
+	// DefaultInvoiceCacheTTL is the default lifetime of cached invoice reads.
+	DefaultInvoiceCacheTTL = 30 * time.Second
+	// DefaultInvoiceCacheMaxEntries is the default maximum number of cached invoices.
+	DefaultInvoiceCacheMaxEntries = 10000
+	// DefaultNotificationMaxSkew is the default tolerance for the timestamp of signed transaction notifications.
+	DefaultNotificationMaxSkew = 5 * time.Minute
+	// DefaultRateLockDuration is the default time an exchange rate stays locked on an invoice.
+	DefaultRateLockDuration = 30 * time.Minute
+	// DefaultRequoteInterval is the default interval between checks for invoices with expired rates.
+	DefaultRequoteInterval = time.Minute
+	// DefaultMaxSlippage is the default largest rate change accepted when re-quoting an invoice.
+	DefaultMaxSlippage = "0.02"
+	// DefaultStalePayments is the default handling of payments arriving after the invoice's rate expired.
+	DefaultStalePayments = "accept"
+	// DefaultPlatformFeePercentage is the default platform fee, in percent, deducted from settlements.
+	DefaultPlatformFeePercentage = "1.0"
+	// DefaultFiatCurrency is the default currency settlements are converted to.
+	DefaultFiatCurrency = "USD"
+	// DefaultExchangeTimeout is the default timeout for exchange API requests.
+	DefaultExchangeTimeout = 10 * time.Second
+	// DefaultProcessorTimeout is the default timeout for payment processor API requests.
+	DefaultProcessorTimeout = 10 * time.Second
+	// DefaultConversionPollInterval is the default interval between checks of open conversion orders.
+	DefaultConversionPollInterval = 30 * time.Second
+	// DefaultPayoutInterval is the default interval between payout rounds.
+	DefaultPayoutInterval = 5 * time.Minute
+	// DefaultPayoutMinimumAmount is the default smallest balance paid out in one transfer.
+	DefaultPayoutMinimumAmount = "10"
+	// DefaultPayoutChallengeTTL is the default time a payout wallet verification challenge may be signed.
+	DefaultPayoutChallengeTTL = 24 * time.Hour
+	// DefaultPayoutMaxFailures is the default number of failed payouts of a settlement before it is reversed.
+	DefaultPayoutMaxFailures = 3
+	// DefaultHotWalletTimeout is the default timeout for hot wallet signer requests.
+	DefaultHotWalletTimeout = 30 * time.Second
+	// DefaultTreasuryInterval is the default interval between treasury rounds.
+	DefaultTreasuryInterval = time.Minute
+	// DefaultFeeOracleTimeout is the default timeout for fee oracle requests.
+	DefaultFeeOracleTimeout = 10 * time.Second
+	// DefaultChainScannerTimeout is the default timeout for chain scanner requests.
+	DefaultChainScannerTimeout = 30 * time.Second
+	// DefaultWatcherInterval is the default interval between checks of the chain watcher for new blocks.
+	DefaultWatcherInterval = 15 * time.Second
+	// DefaultWatcherDepth is the default number of blocks the chain watcher trails the chain head by.
+	DefaultWatcherDepth = 20
+	// DefaultApprovalsRequired is the default number of approvers who must sign off on a large transfer.
+	DefaultApprovalsRequired = 2
+	// DefaultGraphQLMaxDepth is the default deepest selection a GraphQL query may nest.
+	DefaultGraphQLMaxDepth = 8
+	// DefaultBreakerFailureThreshold is the default number of consecutive failures that open a circuit breaker.
+	DefaultBreakerFailureThreshold = 5
+	// DefaultBreakerOpenTimeout is the default time an open circuit breaker rejects calls before a trial call.
+	DefaultBreakerOpenTimeout = 30 * time.Second
+	// DefaultBreakerMaxConcurrent is the default maximum number of concurrent calls to one outbound dependency.
+	DefaultBreakerMaxConcurrent = 20
+	// DefaultDBMaxOpenConns is the default maximum number of open PostgreSQL connections per pool.
+	DefaultDBMaxOpenConns = 100
+	// DefaultDBMaxIdleConns is the default maximum number of idle PostgreSQL connections per pool.
+	DefaultDBMaxIdleConns = 10
+	// DefaultDBConnMaxLifetime is the default time after which a PostgreSQL connection is replaced.
+	DefaultDBConnMaxLifetime = time.Hour
+	// DefaultReplicaCheckInterval is the default interval between read replica health checks.
+	DefaultReplicaCheckInterval = 10 * time.Second
+	// DefaultDBQueryTimeout is the default time a single database operation may take.
+	DefaultDBQueryTimeout = 5 * time.Second
+	// DefaultRequestTimeout is the default time an API request may take, below the server's write timeout.
+	DefaultRequestTimeout = 10 * time.Second
+	// DefaultIDStrategy is the default strategy generating invoice, payment, settlement and refund IDs.
+	DefaultIDStrategy = "random"
+	// DefaultInvoiceNumberReset is the default reset of the accounting numbers of each merchant's invoices.
+	DefaultInvoiceNumberReset = "never"
+	// DefaultArchiveAfterMonths is the default number of months a terminal invoice stays unchanged before it is
+	// archived.
+	DefaultArchiveAfterMonths = 12
+	// DefaultArchiveInterval is the default interval between archival rounds.
+	DefaultArchiveInterval = time.Hour
+	// DefaultArchiveBatchSize is the default number of invoices archived in one transaction.
+	DefaultArchiveBatchSize = 500
+	// DefaultReconciliationHour is the default UTC hour of the nightly reconciliation.
+	DefaultReconciliationHour = 2
+	// DefaultStatementInterval is the default interval between checks for monthly statements due.
+	DefaultStatementInterval = time.Hour
+	// DefaultStorageDriver is the default object storage of rendered files.
+	DefaultStorageDriver = "filesystem"
+	// DefaultStorageDirectory is the default directory of the filesystem object storage.
+	DefaultStorageDirectory = "data/objects"
+	// DefaultStorageTimeout is the default timeout for object storage requests.
+	DefaultStorageTimeout = 30 * time.Second
+	// DefaultWorkerStallTimeout is the default time past its interval a background worker may go without
+	// beating before it is stalled.
+	DefaultWorkerStallTimeout = 5 * time.Minute
+	// DefaultWorkerRestartBackoff is the default wait before a failed background worker is restarted.
+	DefaultWorkerRestartBackoff = time.Second
+	// DefaultWorkerMaxRestartBackoff is the default longest wait before a failed background worker is restarted.
+	DefaultWorkerMaxRestartBackoff = 5 * time.Minute
+	// DefaultErrorReportingSampleRate is the default fraction of errors sent to the error tracker.
+	DefaultErrorReportingSampleRate = 1.0
+	// DefaultErrorReportingTimeout is the default timeout for error tracker requests.
+	DefaultErrorReportingTimeout = 5 * time.Second
+	// DefaultCustomerLoginLinkTTL is the default time an emailed customer login link can be used.
+	DefaultCustomerLoginLinkTTL = 15 * time.Minute
+	// DefaultCustomerSessionTTL is the default time a customer stays signed in.
+	DefaultCustomerSessionTTL = 7 * 24 * time.Hour
+	// DefaultSMTPPort is the default SMTP submission port.
+	DefaultSMTPPort = 587
+	// DefaultSMTPTimeout is the default timeout for sending an email.
+	DefaultSMTPTimeout = 10 * time.Second
+	// DefaultWebhookMaxRetries is the default number of retries of webhook endpoints created without their own.
+	DefaultWebhookMaxRetries = 3
+	// DefaultWebhookBackoff is the default retry backoff of webhook endpoints created without their own.
+	DefaultWebhookBackoff = "exponential"
+	// DefaultRedirectTokenTTL is the default lifetime of the tokens customers are sent back to merchants with.
+	DefaultRedirectTokenTTL = 10 * time.Minute
+	// DefaultHSTSMaxAge is the default time browsers only connect over HTTPS after an HTTPS response.
+	DefaultHSTSMaxAge = 365 * 24 * time.Hour
+	// DefaultSecretsTimeout is the default timeout for secret provider requests.
+	DefaultSecretsTimeout = 10 * time.Second
 )
 
 // Config represents the application configuration.
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Log      LogConfig      `mapstructure:"log"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Kafka    KafkaConfig    `mapstructure:"kafka"`
+	Server     ServerConfig     `mapstructure:"server"`
+	Log        LogConfig        `mapstructure:"log"`
+	Database   DatabaseConfig   `mapstructure:"database"`
+	Kafka      KafkaConfig      `mapstructure:"kafka"`
+	Blockchain BlockchainConfig `mapstructure:"blockchain"`
+	Auth       AuthConfig       `mapstructure:"auth"`
+	Compliance ComplianceConfig `mapstructure:"compliance"`
+	Cache      CacheConfig      `mapstructure:"cache"`
+	Rates      RatesConfig      `mapstructure:"rates"`
+	Settlement SettlementConfig `mapstructure:"settlement"`
+	Payout     PayoutConfig     `mapstructure:"payout"`
+	Processors ProcessorsConfig `mapstructure:"processors"`
+	Treasury   TreasuryConfig   `mapstructure:"treasury"`
+	Fees       FeesConfig       `mapstructure:"fees"`
+	Approvals  ApprovalsConfig  `mapstructure:"approvals"`
+	GraphQL    GraphQLConfig    `mapstructure:"graphql"`
+	Resilience ResilienceConfig `mapstructure:"resilience"`
+	IDs        IDsConfig        `mapstructure:"ids"`
+	Tokens     []TokenConfig    `mapstructure:"tokens"`
+	// InvoiceNumbers configures the accounting numbers of issued invoices
+	InvoiceNumbers InvoiceNumbersConfig `mapstructure:"invoice_numbers"`
+	// Archive configures the moving of old terminal invoices to the archive tables
+	Archive ArchiveConfig `mapstructure:"archive"`
+	// Reconciliation configures the nightly check of the ledger against the chain and the settlements
+	Reconciliation ReconciliationConfig `mapstructure:"reconciliation"`
+	// Statements configures the monthly statements of merchants
+	Statements StatementsConfig `mapstructure:"statements"`
+	// Workers configures the supervision of the background workers
+	Workers WorkersConfig `mapstructure:"workers"`
+	// ErrorReporting configures the error tracker unexpected failures are reported to
+	ErrorReporting ErrorReportingConfig `mapstructure:"error_reporting"`
+	// Customers configures the accounts customers sign in to with an emailed link
+	Customers CustomersConfig `mapstructure:"customers"`
+	// SMTP configures the mail server customer login links are sent through
+	SMTP SMTPConfig `mapstructure:"smtp"`
+	// Runtime holds the settings that are reloaded without a restart
+	Runtime RuntimeConfig `mapstructure:"runtime"`
+	// Admin holds the credentials of the platform admin API under /api/v1/admin
+	Admin AdminConfig `mapstructure:"admin"`
+	// Redirect holds the keys signing the tokens customers are sent back to merchants with
+	Redirect RedirectConfig `mapstructure:"redirect"`
+	// Evidence holds the keys signing the evidence bundles merchants export for disputes
+	Evidence EvidenceConfig `mapstructure:"evidence"`
+	// CheckoutAnalytics configures how the countries of checkout clients are located, for analytics and geo-blocking
+	CheckoutAnalytics CheckoutAnalyticsConfig `mapstructure:"checkout_analytics"`
+	// Security holds the security headers and the cross-origin access of the HTTP server
+	Security SecurityConfig `mapstructure:"security"`
+	// Encryption holds the master keys of the sensitive columns encrypted at rest
+	Encryption EncryptionConfig `mapstructure:"encryption"`
+	// Secrets configures the providers secret references in the configuration are resolved with
+	Secrets SecretsConfig `mapstructure:"secrets"`
+	// Webhooks configures the addresses merchants' webhooks are sent from
+	Webhooks WebhooksConfig `mapstructure:"webhooks"`
 }
 
 // ServerConfig represents server configuration.
 type ServerConfig struct {
 	Port int    `mapstructure:"port"`
 	Host string `mapstructure:"host"`
+	// RequestTimeout is the deadline of an API request, which bounds the database and outbound calls made for
+	// it; streams and WebSockets have none.
+	RequestTimeout time.Duration `mapstructure:"request_timeout"`
 }
 
 // LogConfig represents logging configuration.
 type LogConfig struct {
 	Level string `mapstructure:"level"`
-	Dir   string `mapstructure:"dir"`
+	// Dir holds the files of file sinks given without a path.
+	Dir string `mapstructure:"dir"`
+	// Modules sets the level of modules, such as detection or detection.watcher, apart from Level. Levels can
+	// also be changed while the application runs through the admin API.
+	Modules map[string]string `mapstructure:"modules"`
+	// Sinks are where logs are written; without any they go to stdout.
+	Sinks    []LogSinkConfig   `mapstructure:"sinks"`
+	Sampling LogSamplingConfig `mapstructure:"sampling"`
+}
+
+// LogSinkConfig represents a destination of the logs.
+type LogSinkConfig struct {
+	// Type is stdout, stderr, file or loki.
+	Type string `mapstructure:"type"`
+	// Level is the lowest level written to the sink; empty writes every log the modules let through.
+	Level string `mapstructure:"level"`
+	// Format is json or console; console is the default for stdout and stderr at the debug level.
+	Format string `mapstructure:"format"`
+
+	// Path is the file of a file sink, crypto-checkout.log in Dir by default.
+	Path string `mapstructure:"path"`
+	// MaxSizeMB is the size at which the file is rotated, 100 by default.
+	MaxSizeMB int `mapstructure:"max_size_mb"`
+	// MaxBackups is how many rotated files are kept, 5 by default.
+	MaxBackups int `mapstructure:"max_backups"`
+
+	// URL is the push endpoint of a loki sink, such as http://loki:3100/loki/api/v1/push.
+	URL string `mapstructure:"url"`
+	// Labels are the stream labels of the pushed logs, app=crypto-checkout by default.
+	Labels map[string]string `mapstructure:"labels"`
+	// TenantID is sent as X-Scope-OrgID to a multi-tenant Loki.
+	TenantID string `mapstructure:"tenant_id"`
+	// BatchSize is how many logs are pushed at once, 500 by default.
+	BatchSize int `mapstructure:"batch_size"`
+	// FlushInterval is how often logs are pushed when the batch is not full, 2s by default.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+	// Timeout bounds a push, 10s by default.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// LogSamplingConfig represents the sampling of high-volume debug logs: of the debug logs of Modules with the same
+// message, the first Initial each Tick are written and then one in Thereafter.
+type LogSamplingConfig struct {
+	Modules    []string      `mapstructure:"modules"`
+	Tick       time.Duration `mapstructure:"tick"`
+	Initial    int           `mapstructure:"initial"`
+	Thereafter int           `mapstructure:"thereafter"`
 }
 
 // DatabaseConfig represents database configuration.
@@ -51,6 +284,18 @@ type DatabaseConfig struct {
 	DBName   string `mapstructure:"dbname"`
 	SSLMode  string `mapstructure:"sslmode"`
 	URL      string `mapstructure:"url"`
+	// Connection pool limits, applied to the primary and to every replica
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
+	// ReplicaURLs are PostgreSQL read replicas serving reads that tolerate replication lag
+	ReplicaURLs []string `mapstructure:"replica_urls"`
+	// ReplicaCheckInterval is how often replicas are pinged; unreachable replicas receive no reads
+	ReplicaCheckInterval time.Duration `mapstructure:"replica_check_interval"`
+	// QueryTimeout bounds each query and write, within the deadline of the request it is made for; a query
+	// past it is cancelled on the server
+	QueryTimeout time.Duration `mapstructure:"query_timeout"`
 }
 
 // KafkaConfig represents Kafka configuration.
@@ -62,6 +307,583 @@ type KafkaConfig struct {
 	TopicAnalytics     string `mapstructure:"topic_analytics"`
 }
 
+// BlockchainConfig represents blockchain node configuration.
+type BlockchainConfig struct {
+	RPCURL string `mapstructure:"rpc_url"`
+	// NotificationSources are the external watchers allowed to push transaction notifications.
+	NotificationSources []NotificationSourceConfig `mapstructure:"notification_sources"`
+	// NotificationMaxSkew bounds how far a notification timestamp may drift from the server clock.
+	NotificationMaxSkew time.Duration `mapstructure:"notification_max_skew"`
+	// Scanners are the nodes payment backfills rescan past blocks with.
+	Scanners ScannersConfig `mapstructure:"scanners"`
+	// Watcher follows the networks with a scanner for payments as new blocks arrive.
+	Watcher WatcherConfig `mapstructure:"watcher"`
+	// Explorers are the block explorers transactions, addresses and blocks are linked to.
+	Explorers ExplorersConfig `mapstructure:"explorers"`
+}
+
+// ExplorersConfig represents the block explorer of each network. Networks without one configured link to
+// the public explorer of their mainnet.
+type ExplorersConfig struct {
+	Tron     ExplorerConfig `mapstructure:"tron"`
+	Ethereum ExplorerConfig `mapstructure:"ethereum"`
+	Bitcoin  ExplorerConfig `mapstructure:"bitcoin"`
+	BSC      ExplorerConfig `mapstructure:"bsc"`
+}
+
+// ExplorerConfig configures the block explorer of one network.
+type ExplorerConfig struct {
+	// Chain selects the public explorer of a testnet: shasta or nile for tron, sepolia or holesky for
+	// ethereum, testnet, testnet4 or signet for bitcoin, and testnet for bsc. Empty is the mainnet.
+	Chain string `mapstructure:"chain"`
+	// URL replaces the public explorer with one laid out the same way, such as a self-hosted instance.
+	URL string `mapstructure:"url"`
+}
+
+// WatcherConfig represents the built-in chain watcher, which keeps the last block it processed on each
+// network in the database and resumes from it after a restart.
+type WatcherConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"`
+	// Depth is how many blocks the watcher trails the chain head by.
+	Depth int64 `mapstructure:"depth"`
+	// MaxLag is the lag behind the chain head, in blocks, beyond which /health/ready fails; zero never fails.
+	MaxLag int64 `mapstructure:"max_lag"`
+}
+
+// ScannersConfig represents the chain scanners of payment backfills. Networks without a scanner URL
+// cannot be backfilled. The testnet scanners follow the payments of merchants in test mode.
+type ScannersConfig struct {
+	Timeout         time.Duration `mapstructure:"timeout"`
+	Tron            ScannerConfig `mapstructure:"tron"`
+	Ethereum        ScannerConfig `mapstructure:"ethereum"`
+	BSC             ScannerConfig `mapstructure:"bsc"`
+	TronShasta      ScannerConfig `mapstructure:"tron_shasta"`
+	TronNile        ScannerConfig `mapstructure:"tron_nile"`
+	EthereumSepolia ScannerConfig `mapstructure:"ethereum_sepolia"`
+}
+
+// ScannerConfig configures the node one network is scanned with.
+type ScannerConfig struct {
+	// URL is a TronGrid-compatible node for tron and its testnets, and a JSON-RPC node for the others.
+	URL    string `mapstructure:"url"`
+	APIKey string `mapstructure:"api_key"`
+}
+
+// NotificationSourceConfig represents an external watcher that pushes signed transaction notifications.
+type NotificationSourceConfig struct {
+	Name   string `mapstructure:"name"`
+	Secret string `mapstructure:"secret"`
+	// MerchantID restricts the source to one merchant's invoices; empty allows any merchant.
+	MerchantID string `mapstructure:"merchant_id"`
+}
+
+// AuthConfig represents dashboard session authentication configuration.
+type AuthConfig struct {
+	JWTSecret      string        `mapstructure:"jwt_secret"`
+	AccessTokenTTL time.Duration `mapstructure:"access_token_ttl"`
+}
+
+// ComplianceConfig represents AML/KYT transaction screening configuration.
+type ComplianceConfig struct {
+	Provider      string        `mapstructure:"provider"` // "chainalysis", "elliptic" or empty to disable
+	APIKey        string        `mapstructure:"api_key"`
+	APISecret     string        `mapstructure:"api_secret"`
+	BaseURL       string        `mapstructure:"base_url"`
+	HoldRiskLevel string        `mapstructure:"hold_risk_level"`
+	Timeout       time.Duration `mapstructure:"timeout"`
+	// SanctionsListURL is the OFAC SDN XML export; empty disables sanctions list ingestion.
+	SanctionsListURL        string        `mapstructure:"sanctions_list_url"`
+	SanctionsSyncInterval   time.Duration `mapstructure:"sanctions_sync_interval"`
+	BlocklistReloadInterval time.Duration `mapstructure:"blocklist_reload_interval"`
+}
+
+// CacheConfig represents the in-process read cache configuration.
+type CacheConfig struct {
+	// InvoiceTTL bounds how long a cached invoice is served; zero disables the invoice cache.
+	InvoiceTTL        time.Duration `mapstructure:"invoice_ttl"`
+	InvoiceMaxEntries int           `mapstructure:"invoice_max_entries"`
+}
+
+// RatesConfig represents exchange rate locking and re-quoting configuration.
+type RatesConfig struct {
+	LockDuration    time.Duration `mapstructure:"lock_duration"`
+	RequoteInterval time.Duration `mapstructure:"requote_interval"`
+	// MaxSlippage is the largest relative rate change accepted when re-quoting, e.g. "0.02" for 2%.
+	MaxSlippage string `mapstructure:"max_slippage"`
+	// StalePayments handles payments arriving after the invoice's rate expired: "accept" within the slippage
+	// bound and review beyond it, "review" or "requote".
+	StalePayments string `mapstructure:"stale_payments"`
+}
+
+// SettlementConfig represents settlement fee and automatic fiat conversion configuration.
+type SettlementConfig struct {
+	// PlatformFeePercentage applies to merchants without their own fee percentage, e.g. "1.0" for 1%.
+	PlatformFeePercentage string `mapstructure:"platform_fee_percentage"`
+	// Exchange sells settled funds for fiat: "kraken", "binance" or empty to settle in crypto.
+	Exchange     string        `mapstructure:"exchange"`
+	APIKey       string        `mapstructure:"api_key"`
+	APISecret    string        `mapstructure:"api_secret"`
+	BaseURL      string        `mapstructure:"base_url"`
+	FiatCurrency string        `mapstructure:"fiat_currency"`
+	Timeout      time.Duration `mapstructure:"timeout"`
+	// ConversionPollInterval is how often open conversion orders are checked with the exchange.
+	ConversionPollInterval time.Duration `mapstructure:"conversion_poll_interval"`
+}
+
+// ProcessorsConfig represents the off-chain payment processors merchants can register with their own
+// credentials.
+type ProcessorsConfig struct {
+	// Enabled lists the processors offered: "binance_pay", "coinbase_commerce"; empty offers none.
+	Enabled []string `mapstructure:"enabled"`
+	// BinancePayBaseURL and CoinbaseCommerceBaseURL override the processors' default API endpoints.
+	BinancePayBaseURL       string        `mapstructure:"binance_pay_base_url"`
+	CoinbaseCommerceBaseURL string        `mapstructure:"coinbase_commerce_base_url"`
+	Timeout                 time.Duration `mapstructure:"timeout"`
+}
+
+// PayoutConfig represents on-chain payout configuration.
+type PayoutConfig struct {
+	// SignerURL is the hot wallet signer service that sends payout transfers; empty disables payouts.
+	SignerURL string        `mapstructure:"signer_url"`
+	APIKey    string        `mapstructure:"api_key"`
+	APISecret string        `mapstructure:"api_secret"`
+	Timeout   time.Duration `mapstructure:"timeout"`
+	// Interval is how often settlements are batched into payouts and transfers are followed.
+	Interval time.Duration `mapstructure:"interval"`
+	// MinimumAmount is the smallest balance worth a transfer, e.g. "10".
+	MinimumAmount string        `mapstructure:"minimum_amount"`
+	ChallengeTTL  time.Duration `mapstructure:"challenge_ttl"`
+	// MaxFailures is how many payouts of a settlement may fail before the settlement is reversed and its
+	// invoice reopened.
+	MaxFailures int `mapstructure:"max_failures"`
+}
+
+// TreasuryConfig represents platform treasury configuration. Sweeps and cold storage transfers go
+// through the hot wallet signer configured under payout.
+type TreasuryConfig struct {
+	// Interval is how often sweeps and transfers are followed and balances checked.
+	Interval time.Duration `mapstructure:"interval"`
+	// Operators are the user IDs allowed to manage the treasury; cold storage transfers need two of them.
+	Operators  []string                 `mapstructure:"operators"`
+	Currencies []TreasuryCurrencyConfig `mapstructure:"currencies"`
+}
+
+// TreasuryCurrencyConfig configures the treasury handling of one currency. Empty amounts are not checked.
+type TreasuryCurrencyConfig struct {
+	Currency string `mapstructure:"currency"`
+	// LowBalance and HighBalance raise alerts when the hot wallet balance leaves them, e.g. "1000".
+	LowBalance  string `mapstructure:"low_balance"`
+	HighBalance string `mapstructure:"high_balance"`
+	// SweepMinimum is the smallest deposit address balance worth sweeping.
+	SweepMinimum string `mapstructure:"sweep_minimum"`
+	// ColdAddress is the cold storage address; empty disables cold storage transfers.
+	ColdAddress string `mapstructure:"cold_address"`
+}
+
+// FeesConfig represents network fee estimation configuration. Networks without an oracle URL
+// have no estimates, and their transfers are broadcast at any fee.
+type FeesConfig struct {
+	Timeout  time.Duration    `mapstructure:"timeout"`
+	Tron     FeeNetworkConfig `mapstructure:"tron"`
+	Ethereum FeeNetworkConfig `mapstructure:"ethereum"`
+	Bitcoin  FeeNetworkConfig `mapstructure:"bitcoin"`
+}
+
+// FeeNetworkConfig configures fee estimation and the broadcast strategy of one network.
+type FeeNetworkConfig struct {
+	// URL is the fee oracle: a TronGrid-compatible node, an Ethereum JSON-RPC node or a
+	// mempool.space-compatible API.
+	URL    string `mapstructure:"url"`
+	APIKey string `mapstructure:"api_key"`
+	// Speed is the confirmation speed estimates are made for: "slow", "standard" or "fast".
+	Speed string `mapstructure:"speed"`
+	// MaxFee defers broadcasts while a transfer would cost more, in the native currency, e.g. "30".
+	MaxFee string `mapstructure:"max_fee"`
+	// MaxDelay is how long a broadcast may be deferred before it is sent at any fee.
+	MaxDelay time.Duration `mapstructure:"max_delay"`
+}
+
+// ApprovalsConfig represents the approval of large refunds and payouts. Transfers in a currency
+// without a threshold never need approval.
+type ApprovalsConfig struct {
+	// Required is how many approvers must approve a transfer over the threshold.
+	Required   int                       `mapstructure:"required"`
+	Thresholds []ApprovalThresholdConfig `mapstructure:"thresholds"`
+}
+
+// ApprovalThresholdConfig is the smallest refund or payout in a currency that needs approval.
+type ApprovalThresholdConfig struct {
+	Currency string `mapstructure:"currency"`
+	Amount   string `mapstructure:"amount"`
+}
+
+// GraphQLConfig represents the optional GraphQL endpoint for reading invoices, payments and settlements.
+type GraphQLConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// MaxDepth is the deepest selection a query may nest; deeper queries are rejected.
+	MaxDepth int `mapstructure:"max_depth"`
+}
+
+// ResilienceConfig represents the circuit breakers and bulkheads around outbound calls: the exchange,
+// screening provider, sanctions list, fee oracles and hot wallet signer. Request timeouts are configured
+// with each dependency.
+type ResilienceConfig struct {
+	// Defaults apply to dependencies without their own entry, and to the fields an entry leaves zero.
+	Defaults BreakerConfig `mapstructure:"defaults"`
+	// Dependencies override the defaults by dependency name, e.g. "exchange" or "fee_oracle_tron".
+	Dependencies map[string]BreakerConfig `mapstructure:"dependencies"`
+}
+
+// BreakerConfig configures the circuit breaker and bulkhead of an outbound dependency.
+type BreakerConfig struct {
+	// FailureThreshold is how many consecutive failed calls open the breaker.
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	// OpenTimeout is how long an open breaker rejects calls before it lets a trial call through.
+	OpenTimeout time.Duration `mapstructure:"open_timeout"`
+	// MaxConcurrent bounds the calls in flight; calls beyond it are rejected rather than queued.
+	MaxConcurrent int `mapstructure:"max_concurrent"`
+}
+
+// IDsConfig represents how the IDs of invoices, payments, settlements and refunds are generated.
+type IDsConfig struct {
+	// Strategy is random, ulid, uuidv7 or sequential. Changing it only affects records created afterwards.
+	Strategy string `mapstructure:"strategy"`
+}
+
+// InvoiceNumbersConfig represents the gapless accounting numbers each merchant's invoices receive when they are
+// issued, separate from their IDs.
+type InvoiceNumbersConfig struct {
+	// Reset is "never" for one sequence per merchant, or "yearly" to restart it every calendar year (UTC).
+	Reset string `mapstructure:"reset"`
+}
+
+// ArchiveConfig represents the archival of terminal invoices, with their payments and payment events, once they
+// have not changed for months.
+type ArchiveConfig struct {
+	// Enabled runs the archiver in the background.
+	Enabled bool `mapstructure:"enabled"`
+	// AfterMonths is how many months a terminal invoice stays unchanged before it is archived.
+	AfterMonths int `mapstructure:"after_months"`
+	// Interval is how often invoices due for the archive are looked for.
+	Interval time.Duration `mapstructure:"interval"`
+	// BatchSize is how many invoices are moved in one transaction.
+	BatchSize int `mapstructure:"batch_size"`
+}
+
+// ReconciliationConfig represents the nightly reconciliation, comparing the balance each deposit address should
+// hold with its on-chain balance and the payments of paid invoices with their settlements.
+type ReconciliationConfig struct {
+	// Enabled runs the reconciliation every night.
+	Enabled bool `mapstructure:"enabled"`
+	// Hour is the UTC hour of the day the reconciliation runs at.
+	Hour int `mapstructure:"hour"`
+	// Thresholds are the total differences by currency a report may find without raising an alert.
+	Thresholds []ReconciliationThresholdConfig `mapstructure:"thresholds"`
+}
+
+// ReconciliationThresholdConfig is the total difference in a currency a reconciliation tolerates, e.g. "0.01".
+type ReconciliationThresholdConfig struct {
+	Currency string `mapstructure:"currency"`
+	Amount   string `mapstructure:"amount"`
+}
+
+// StatementsConfig represents the monthly statements of merchants: their volume, fees, refunds and settlements
+// by currency and their invoices by status, rendered as PDF and CSV files kept in object storage.
+type StatementsConfig struct {
+	// Enabled generates every active merchant's statement for the previous month once it is over.
+	Enabled bool `mapstructure:"enabled"`
+	// Interval is how often statements due are looked for.
+	Interval time.Duration `mapstructure:"interval"`
+	// Email tells merchants by email when their statement is ready, through the smtp server.
+	Email bool `mapstructure:"email"`
+	// Storage is where the rendered statements are kept.
+	Storage ObjectStorageConfig `mapstructure:"storage"`
+}
+
+// ObjectStorageConfig represents where rendered files are kept: a local directory, or a bucket of S3 or an
+// S3-compatible store such as MinIO. Empty credentials are read from the standard AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN and AWS_REGION variables.
+type ObjectStorageConfig struct {
+	// Driver is "filesystem" or "s3".
+	Driver string `mapstructure:"driver"`
+	// Directory holds the files of the filesystem driver.
+	Directory string `mapstructure:"directory"`
+	Bucket    string `mapstructure:"bucket"`
+	Region    string `mapstructure:"region"`
+	// Endpoint overrides the regional S3 endpoint, e.g. "https://minio.internal:9000".
+	Endpoint string `mapstructure:"endpoint"`
+	// PathStyle addresses the bucket in the path rather than the host name, as most S3-compatible stores need.
+	PathStyle       bool   `mapstructure:"path_style"`
+	AccessKeyID     string `mapstructure:"access_key_id"`
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+	SessionToken    string `mapstructure:"session_token"`
+	// Timeout bounds each request to the bucket.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// WorkersConfig represents the supervision of the background workers, such as the chain watcher and the
+// requoter. Failed workers are restarted with a backoff doubling from RestartBackoff up to MaxRestartBackoff.
+type WorkersConfig struct {
+	// StallTimeout is how long past its interval a worker may go without progress before it is stalled.
+	StallTimeout time.Duration `mapstructure:"stall_timeout"`
+	// RestartBackoff is the wait before the first restart of a failed worker.
+	RestartBackoff time.Duration `mapstructure:"restart_backoff"`
+	// MaxRestartBackoff caps the wait before a restart.
+	MaxRestartBackoff time.Duration `mapstructure:"max_restart_backoff"`
+}
+
+// ErrorReportingConfig represents the error tracker panics, 5xx responses, failed event handlers and background
+// worker failures are reported to. Reports are tagged with the merchant and request ID they occurred for.
+type ErrorReportingConfig struct {
+	// DSN is the Sentry DSN of the project to report to, or a secret reference to it; empty disables reporting.
+	DSN         string `mapstructure:"dsn"`
+	Environment string `mapstructure:"environment"`
+	Release     string `mapstructure:"release"`
+	// SampleRate is the fraction of errors reported, from 0 to 1. Panics are always reported.
+	SampleRate float64 `mapstructure:"sample_rate"`
+	// Timeout bounds each request to the error tracker.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// CustomersConfig represents the optional accounts of the customers paying invoices, where they see their payment
+// history across merchants, keep refund addresses and download receipts. Customers sign in with an emailed link
+// bound to their browser with PKCE, and their sessions are separate from merchant sessions and API keys.
+type CustomersConfig struct {
+	// Enabled serves the customer account API under /api/v1/customer.
+	Enabled bool `mapstructure:"enabled"`
+	// LoginURL is the page login links point to; it redeems the token query parameter with the code verifier.
+	LoginURL string `mapstructure:"login_url"`
+	// LoginLinkTTL is how long a login link can be used.
+	LoginLinkTTL time.Duration `mapstructure:"login_link_ttl"`
+	// SessionTTL is how long a customer stays signed in.
+	SessionTTL time.Duration `mapstructure:"session_ttl"`
+}
+
+// SMTPConfig represents the mail server emails are sent through. Without a host, emails are logged instead of
+// sent, which only suits development.
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	// Password is the SMTP password, or a secret reference to it.
+	Password string `mapstructure:"password"`
+	// From is the sender address, e.g. "Crypto Checkout <no-reply@example.com>".
+	From    string        `mapstructure:"from"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// RuntimeConfig represents the settings that can change while the application runs. They are reloaded from the
+// config file and environment on SIGHUP or POST /api/v1/admin/config/reload; every other section needs a restart.
+type RuntimeConfig struct {
+	RateLimits RateLimitsConfig `mapstructure:"rate_limits"`
+	// Confirmations are the confirmation rules by network, e.g. "bitcoin".
+	Confirmations map[string]ConfirmationConfig `mapstructure:"confirmations"`
+	Webhooks      WebhookRetryConfig            `mapstructure:"webhooks"`
+	// Features switches features on and off by name; features not listed are on.
+	Features map[string]bool `mapstructure:"features"`
+	// FeeSchedules are the platform fee schedules by name. The schedule named "default" applies to merchants no
+	// schedule lists and without a fee percentage of their own; without it, they pay settlement.platform_fee_percentage.
+	FeeSchedules map[string]FeeScheduleConfig `mapstructure:"fee_schedules"`
+}
+
+// FeeScheduleConfig represents a platform fee schedule. Percentages are in percent, e.g. "0.8" for 0.8%; volumes
+// and minimum fees are amounts in the settlement currency.
+type FeeScheduleConfig struct {
+	// Merchants are the IDs of the merchants the schedule applies to, whatever their own fee percentage.
+	Merchants []string `mapstructure:"merchants"`
+	// Tiers are the percentages by the merchant's settled volume over the last 30 days, one from volume "0".
+	Tiers []FeeTierConfig `mapstructure:"tiers"`
+	// Networks are percentages by network, e.g. "ethereum", replacing the tiers for invoices paid there.
+	Networks map[string]string `mapstructure:"networks"`
+	// Promotions are windows in which no fee is charged.
+	Promotions []FeePromotionConfig `mapstructure:"promotions"`
+	// MinimumFees are the smallest fees charged per settlement by currency, e.g. "usdt".
+	MinimumFees map[string]string `mapstructure:"minimum_fees"`
+}
+
+// FeeTierConfig is the fee percentage of merchants whose 30-day settled volume reached MinVolume.
+type FeeTierConfig struct {
+	MinVolume  string `mapstructure:"min_volume"`
+	Percentage string `mapstructure:"percentage"`
+}
+
+// FeePromotionConfig is a window in which no fee is charged, from StartsAt until EndsAt, both RFC 3339 times.
+type FeePromotionConfig struct {
+	Name     string `mapstructure:"name"`
+	StartsAt string `mapstructure:"starts_at"`
+	EndsAt   string `mapstructure:"ends_at"`
+}
+
+// RateLimitsConfig bounds the requests each client IP may make per minute. Zero disables a limit.
+type RateLimitsConfig struct {
+	// API bounds the requests to /api/v1, other than the public checkout endpoints.
+	API int `mapstructure:"api"`
+	// Public bounds the requests to the checkout pages and /api/v1/public.
+	Public int `mapstructure:"public"`
+}
+
+// ConfirmationConfig is how many confirmations payments on a network need before they are confirmed: Below
+// for amounts under the threshold and AtOrAbove for the others.
+type ConfirmationConfig struct {
+	Threshold string `mapstructure:"threshold"`
+	Below     int    `mapstructure:"below"`
+	AtOrAbove int    `mapstructure:"at_or_above"`
+}
+
+// WebhookRetryConfig represents the retry policy of webhook endpoints created without their own.
+type WebhookRetryConfig struct {
+	MaxRetries int `mapstructure:"max_retries"`
+	// Backoff is linear or exponential.
+	Backoff string `mapstructure:"backoff"`
+}
+
+// WebhooksConfig represents how merchants' webhooks leave the platform. Merchants firewalling their endpoints
+// allow the egress IPs through; operators keep those stable by sending webhooks through a proxy with static
+// addresses.
+type WebhooksConfig struct {
+	// EgressIPs are the addresses or CIDR ranges webhooks are sent from, e.g. "203.0.113.10" or
+	// "198.51.100.0/28", published under /api/v1/webhooks/egress-ips.
+	EgressIPs []string `mapstructure:"egress_ips"`
+	// ProxyURL is the HTTP proxy webhooks are sent through, e.g. "http://egress.internal:3128", or a secret
+	// reference to it when it holds credentials. Empty sends webhooks through the proxy of the HTTPS_PROXY and
+	// HTTP_PROXY variables, if any.
+	ProxyURL string `mapstructure:"proxy_url"`
+}
+
+// AdminConfig represents the credentials of the platform admin API. Every request under /api/v1/admin presents
+// one of the keys in the X-Admin-Key header, on top of a session or API key with the admin operations
+// permission. Without keys the admin API is closed.
+type AdminConfig struct {
+	Keys []AdminKeyConfig `mapstructure:"keys"`
+	// AllowedCIDRs restricts the admin API to these client networks; empty allows every network.
+	AllowedCIDRs []string `mapstructure:"allowed_cidrs"`
+}
+
+// AdminKeyConfig is an admin key. Only its hash is configured, so the config does not hold the key itself.
+type AdminKeyConfig struct {
+	// Name identifies the operator holding the key in logs and audit entries.
+	Name string `mapstructure:"name"`
+	// Hash is the hex-encoded SHA-256 hash of the key.
+	Hash string `mapstructure:"hash"`
+}
+
+// RedirectConfig represents the tokens appended to return and cancel URLs when the checkout page sends customers
+// back to the merchant. Without keys, customers are sent back without a token.
+type RedirectConfig struct {
+	// Keys are the Ed25519 keys tokens are signed with. The first key signs; the others are still published
+	// under /api/v1/public/redirect-keys so that tokens signed before a rotation keep verifying.
+	Keys []RedirectKeyConfig `mapstructure:"keys"`
+	// TokenTTL is how long a token is valid after the customer is sent back.
+	TokenTTL time.Duration `mapstructure:"token_ttl"`
+}
+
+// RedirectKeyConfig is a redirect token signing key.
+type RedirectKeyConfig struct {
+	// ID is published as the kid of the key and set in the header of the tokens it signs.
+	ID string `mapstructure:"id"`
+	// PrivateKey is the base64-encoded 32-byte Ed25519 seed or 64-byte private key.
+	PrivateKey string `mapstructure:"private_key"`
+}
+
+// EvidenceConfig represents the signing of the evidence bundles exported for invoice disputes. Without keys,
+// bundles are hashed but not signed.
+type EvidenceConfig struct {
+	// Keys are the Ed25519 keys bundles are signed with, in the format of redirect keys. The first key signs; the
+	// others are still published under /api/v1/public/evidence-keys so that older bundles keep verifying.
+	Keys []RedirectKeyConfig `mapstructure:"keys"`
+}
+
+// CheckoutAnalyticsConfig represents the locating of checkout clients by country, for the checkout view counts
+// and the countries merchants restrict. Client addresses are not kept.
+type CheckoutAnalyticsConfig struct {
+	// CountryHeader is the request header a trusted edge proxy sets to the client's country, such as
+	// CF-IPCountry. It is preferred to the GeoIP database; leave empty when no proxy sets it, as clients could.
+	CountryHeader string `mapstructure:"country_header"`
+	// GeoIPFile is a CSV database of "start_ip,end_ip,country" IP ranges, such as DB-IP's free IP to Country
+	// Lite. Without it, only countries from the header are counted and restricted.
+	GeoIPFile string `mapstructure:"geoip_file"`
+}
+
+// SecurityConfig represents the security headers and the cross-origin access of the HTTP server.
+type SecurityConfig struct {
+	// CORSOrigins are the origins, such as the platform's own storefront, allowed to call the public API from
+	// browsers for every merchant, in addition to the origins each merchant allows in its settings.
+	CORSOrigins []string `mapstructure:"cors_origins"`
+	// HSTSMaxAge is how long browsers only connect over HTTPS after an HTTPS response; zero disables
+	// Strict-Transport-Security.
+	HSTSMaxAge time.Duration `mapstructure:"hsts_max_age"`
+}
+
+// EncryptionConfig represents the encryption of sensitive columns, such as webhook secrets, at rest.
+type EncryptionConfig struct {
+	// KeyFile holds the master keys, one "id:base64-key" line per 32-byte key, as written by hand or by a KMS
+	// agent. The first key encrypts; the others decrypt values not yet rotated to it. Without a key file,
+	// sensitive columns are stored unencrypted.
+	KeyFile string `mapstructure:"key_file"`
+	// Keys holds the master keys in the key file format instead, typically as a secret reference such as
+	// "vault:secret/data/crypto-checkout#master_keys". It takes precedence over KeyFile.
+	Keys string `mapstructure:"keys"`
+}
+
+// SecretsConfig represents the providers of the secrets the configuration refers to. The database password
+// and URL, the encryption master keys, the hot wallet signer credentials, the exchange credentials, the
+// screening provider credentials, the Sentry DSN, the SMTP password, the statement storage secret key, the
+// webhook proxy URL and the JWT secret may be set to a reference instead of the secret itself: "env:NAME",
+// "file:/path", "vault:path#field" or "awskms:base64-ciphertext".
+type SecretsConfig struct {
+	Vault  VaultConfig  `mapstructure:"vault"`
+	AWSKMS AWSKMSConfig `mapstructure:"aws_kms"`
+	// Timeout bounds each request to Vault or AWS KMS.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// VaultConfig represents the HashiCorp Vault server "vault:" references are read from.
+type VaultConfig struct {
+	// Address is the Vault server, e.g. "https://vault.internal:8200"; empty disables "vault:" references.
+	Address string `mapstructure:"address"`
+	// Token authenticates to Vault; TokenFile, such as the sink of a Vault agent, is read when it is empty.
+	Token     string `mapstructure:"token"`
+	TokenFile string `mapstructure:"token_file"`
+	Namespace string `mapstructure:"namespace"`
+}
+
+// AWSKMSConfig represents the AWS KMS "awskms:" references are decrypted with. Empty credentials are read
+// from the standard AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN and AWS_REGION variables.
+type AWSKMSConfig struct {
+	Region          string `mapstructure:"region"`
+	AccessKeyID     string `mapstructure:"access_key_id"`
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+	SessionToken    string `mapstructure:"session_token"`
+	// Endpoint overrides the regional KMS endpoint, e.g. for a VPC endpoint.
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+// DefaultConfirmations returns the default confirmation rules by network.
+func DefaultConfirmations() map[string]ConfirmationConfig {
+	return map[string]ConfirmationConfig{
+		"bitcoin":  {Threshold: "1000", Below: 1, AtOrAbove: 6},
+		"ethereum": {Threshold: "10000", Below: 12, AtOrAbove: 30},
+		"tron":     {Threshold: "5000", Below: 1, AtOrAbove: 3},
+	}
+}
+
+// TokenConfig is a cryptocurrency invoices may be paid in, on one network. Without any tokens configured,
+// invoices are paid in USDT on Tron, BTC and ETH.
+type TokenConfig struct {
+	Symbol    string   `mapstructure:"symbol"`
+	Network   string   `mapstructure:"network"`
+	Contract  string   `mapstructure:"contract"` // Empty for the network's native coin
+	Decimals  int32    `mapstructure:"decimals"`
+	Merchants []string `mapstructure:"merchants"` // Merchants that may accept the token; empty for every merchant
+}
+
+// DefaultLogSamplingModules returns the modules whose debug logs are sampled by default: the chain watcher, which
+// logs every chunk of blocks it scans.
+func DefaultLogSamplingModules() []string {
+	return []string{"detection.watcher"}
+}
+
 // Load loads configuration using Viper with support for multiple sources.
 func Load() (*Config, error) {
 	v := viper.New()
@@ -69,19 +891,138 @@ func Load() (*Config, error) {
 	// Set default values
 	v.SetDefault("server.port", DefaultServerPort)
 	v.SetDefault("server.host", DefaultServerHost)
+	v.SetDefault("server.request_timeout", DefaultRequestTimeout)
 	v.SetDefault("log.level", DefaultLogLevel)
 	v.SetDefault("log.dir", DefaultLogDir)
+	v.SetDefault("log.sampling.modules", DefaultLogSamplingModules())
+	v.SetDefault("log.sampling.tick", DefaultLogSamplingTick)
+	v.SetDefault("log.sampling.initial", DefaultLogSamplingInitial)
+	v.SetDefault("log.sampling.thereafter", DefaultLogSamplingThereafter)
 	v.SetDefault("database.host", "localhost")
 	v.SetDefault("database.port", DefaultPostgresPort)
 	v.SetDefault("database.user", "crypto_user")
 	v.SetDefault("database.password", "crypto_password")
 	v.SetDefault("database.dbname", "crypto_checkout")
 	v.SetDefault("database.sslmode", "disable")
+	v.SetDefault("database.max_open_conns", DefaultDBMaxOpenConns)
+	v.SetDefault("database.max_idle_conns", DefaultDBMaxIdleConns)
+	v.SetDefault("database.conn_max_lifetime", DefaultDBConnMaxLifetime)
+	v.SetDefault("database.replica_check_interval", DefaultReplicaCheckInterval)
+	v.SetDefault("database.query_timeout", DefaultDBQueryTimeout)
 	v.SetDefault("kafka.brokers", "localhost:9092")
 	v.SetDefault("kafka.topic_domain_events", "crypto-checkout.domain-events")
 	v.SetDefault("kafka.topic_integrations", "crypto-checkout.integrations")
 	v.SetDefault("kafka.topic_notifications", "crypto-checkout.notifications")
 	v.SetDefault("kafka.topic_analytics", "crypto-checkout.analytics")
+	v.SetDefault("blockchain.rpc_url", "")
+	v.SetDefault("blockchain.notification_max_skew", DefaultNotificationMaxSkew)
+	v.SetDefault("blockchain.scanners.timeout", DefaultChainScannerTimeout)
+	v.SetDefault("blockchain.watcher.enabled", false)
+	v.SetDefault("blockchain.watcher.interval", DefaultWatcherInterval)
+	v.SetDefault("blockchain.watcher.depth", DefaultWatcherDepth)
+	v.SetDefault("blockchain.watcher.max_lag", 0)
+	v.SetDefault("auth.jwt_secret", "")
+	v.SetDefault("auth.access_token_ttl", DefaultAccessTokenTTL)
+	v.SetDefault("compliance.provider", "")
+	v.SetDefault("compliance.api_key", "")
+	v.SetDefault("compliance.api_secret", "")
+	v.SetDefault("compliance.base_url", "")
+	v.SetDefault("compliance.hold_risk_level", DefaultHoldRiskLevel)
+	v.SetDefault("compliance.timeout", DefaultComplianceTimeout)
+	v.SetDefault("compliance.sanctions_list_url", DefaultSanctionsListURL)
+	v.SetDefault("compliance.sanctions_sync_interval", DefaultSanctionsSyncInterval)
+	v.SetDefault("compliance.blocklist_reload_interval", DefaultBlocklistReloadInterval)
+	v.SetDefault("cache.invoice_ttl", DefaultInvoiceCacheTTL)
+	v.SetDefault("cache.invoice_max_entries", DefaultInvoiceCacheMaxEntries)
+	v.SetDefault("rates.lock_duration", DefaultRateLockDuration)
+	v.SetDefault("rates.requote_interval", DefaultRequoteInterval)
+	v.SetDefault("rates.max_slippage", DefaultMaxSlippage)
+	v.SetDefault("rates.stale_payments", DefaultStalePayments)
+	v.SetDefault("settlement.platform_fee_percentage", DefaultPlatformFeePercentage)
+	v.SetDefault("settlement.exchange", "")
+	v.SetDefault("settlement.api_key", "")
+	v.SetDefault("settlement.api_secret", "")
+	v.SetDefault("settlement.base_url", "")
+	v.SetDefault("settlement.fiat_currency", DefaultFiatCurrency)
+	v.SetDefault("settlement.timeout", DefaultExchangeTimeout)
+	v.SetDefault("settlement.conversion_poll_interval", DefaultConversionPollInterval)
+	v.SetDefault("processors.enabled", []string{})
+	v.SetDefault("processors.binance_pay_base_url", "")
+	v.SetDefault("processors.coinbase_commerce_base_url", "")
+	v.SetDefault("processors.timeout", DefaultProcessorTimeout)
+	v.SetDefault("payout.signer_url", "")
+	v.SetDefault("payout.api_key", "")
+	v.SetDefault("payout.api_secret", "")
+	v.SetDefault("payout.timeout", DefaultHotWalletTimeout)
+	v.SetDefault("payout.interval", DefaultPayoutInterval)
+	v.SetDefault("payout.minimum_amount", DefaultPayoutMinimumAmount)
+	v.SetDefault("payout.challenge_ttl", DefaultPayoutChallengeTTL)
+	v.SetDefault("payout.max_failures", DefaultPayoutMaxFailures)
+	v.SetDefault("treasury.interval", DefaultTreasuryInterval)
+	v.SetDefault("fees.timeout", DefaultFeeOracleTimeout)
+	v.SetDefault("approvals.required", DefaultApprovalsRequired)
+	v.SetDefault("graphql.enabled", false)
+	v.SetDefault("graphql.max_depth", DefaultGraphQLMaxDepth)
+	v.SetDefault("resilience.defaults.failure_threshold", DefaultBreakerFailureThreshold)
+	v.SetDefault("resilience.defaults.open_timeout", DefaultBreakerOpenTimeout)
+	v.SetDefault("resilience.defaults.max_concurrent", DefaultBreakerMaxConcurrent)
+	v.SetDefault("ids.strategy", DefaultIDStrategy)
+	v.SetDefault("invoice_numbers.reset", DefaultInvoiceNumberReset)
+	v.SetDefault("archive.enabled", false)
+	v.SetDefault("archive.after_months", DefaultArchiveAfterMonths)
+	v.SetDefault("archive.interval", DefaultArchiveInterval)
+	v.SetDefault("archive.batch_size", DefaultArchiveBatchSize)
+	v.SetDefault("reconciliation.enabled", false)
+	v.SetDefault("reconciliation.hour", DefaultReconciliationHour)
+	v.SetDefault("statements.enabled", false)
+	v.SetDefault("statements.interval", DefaultStatementInterval)
+	v.SetDefault("statements.email", true)
+	v.SetDefault("statements.storage.driver", DefaultStorageDriver)
+	v.SetDefault("statements.storage.directory", DefaultStorageDirectory)
+	v.SetDefault("statements.storage.timeout", DefaultStorageTimeout)
+	v.SetDefault("workers.stall_timeout", DefaultWorkerStallTimeout)
+	v.SetDefault("workers.restart_backoff", DefaultWorkerRestartBackoff)
+	v.SetDefault("workers.max_restart_backoff", DefaultWorkerMaxRestartBackoff)
+	v.SetDefault("error_reporting.dsn", "")
+	v.SetDefault("error_reporting.environment", "")
+	v.SetDefault("error_reporting.release", "")
+	v.SetDefault("error_reporting.sample_rate", DefaultErrorReportingSampleRate)
+	v.SetDefault("error_reporting.timeout", DefaultErrorReportingTimeout)
+	v.SetDefault("customers.enabled", false)
+	v.SetDefault("customers.login_url", "")
+	v.SetDefault("customers.login_link_ttl", DefaultCustomerLoginLinkTTL)
+	v.SetDefault("customers.session_ttl", DefaultCustomerSessionTTL)
+	v.SetDefault("smtp.host", "")
+	v.SetDefault("smtp.port", DefaultSMTPPort)
+	v.SetDefault("smtp.username", "")
+	v.SetDefault("smtp.password", "")
+	v.SetDefault("smtp.from", "")
+	v.SetDefault("smtp.timeout", DefaultSMTPTimeout)
+	v.SetDefault("runtime.rate_limits.api", 0)
+	v.SetDefault("runtime.rate_limits.public", 0)
+	for network, rule := range DefaultConfirmations() {
+		v.SetDefault("runtime.confirmations."+network+".threshold", rule.Threshold)
+		v.SetDefault("runtime.confirmations."+network+".below", rule.Below)
+		v.SetDefault("runtime.confirmations."+network+".at_or_above", rule.AtOrAbove)
+	}
+	v.SetDefault("runtime.webhooks.max_retries", DefaultWebhookMaxRetries)
+	v.SetDefault("runtime.webhooks.backoff", DefaultWebhookBackoff)
+	v.SetDefault("webhooks.egress_ips", []string{})
+	v.SetDefault("webhooks.proxy_url", "")
+	v.SetDefault("redirect.token_ttl", DefaultRedirectTokenTTL)
+	v.SetDefault("security.hsts_max_age", DefaultHSTSMaxAge)
+	v.SetDefault("encryption.key_file", "")
+	v.SetDefault("encryption.keys", "")
+	v.SetDefault("secrets.vault.address", "")
+	v.SetDefault("secrets.vault.token", "")
+	v.SetDefault("secrets.vault.token_file", "")
+	v.SetDefault("secrets.vault.namespace", "")
+	v.SetDefault("secrets.aws_kms.region", "")
+	v.SetDefault("secrets.aws_kms.access_key_id", "")
+	v.SetDefault("secrets.aws_kms.secret_access_key", "")
+	v.SetDefault("secrets.aws_kms.session_token", "")
+	v.SetDefault("secrets.aws_kms.endpoint", "")
+	v.SetDefault("secrets.timeout", DefaultSecretsTimeout)
 
 	// Set config file name and paths
 	v.SetConfigName("config")
@@ -116,12 +1057,19 @@ func Load() (*Config, error) {
 func NewConfig() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Port: DefaultServerPort,
-			Host: DefaultServerHost,
+			Port:           DefaultServerPort,
+			Host:           DefaultServerHost,
+			RequestTimeout: DefaultRequestTimeout,
 		},
 		Log: LogConfig{
 			Level: DefaultLogLevel,
 			Dir:   DefaultLogDir,
+			Sampling: LogSamplingConfig{
+				Modules:    DefaultLogSamplingModules(),
+				Tick:       DefaultLogSamplingTick,
+				Initial:    DefaultLogSamplingInitial,
+				Thereafter: DefaultLogSamplingThereafter,
+			},
 		},
 		Database: DatabaseConfig{
 			Host:     "localhost",
@@ -130,6 +1078,12 @@ func NewConfig() *Config {
 			Password: "crypto_password",
 			DBName:   "crypto_checkout",
 			SSLMode:  "disable",
+
+			MaxOpenConns:         DefaultDBMaxOpenConns,
+			MaxIdleConns:         DefaultDBMaxIdleConns,
+			ConnMaxLifetime:      DefaultDBConnMaxLifetime,
+			ReplicaCheckInterval: DefaultReplicaCheckInterval,
+			QueryTimeout:         DefaultDBQueryTimeout,
 		},
 		Kafka: KafkaConfig{
 			Brokers:            "localhost:9092",
@@ -138,6 +1092,127 @@ func NewConfig() *Config {
 			TopicNotifications: "crypto-checkout.notifications",
 			TopicAnalytics:     "crypto-checkout.analytics",
 		},
+		Blockchain: BlockchainConfig{
+			NotificationMaxSkew: DefaultNotificationMaxSkew,
+			Scanners: ScannersConfig{
+				Timeout: DefaultChainScannerTimeout,
+			},
+			Watcher: WatcherConfig{
+				Interval: DefaultWatcherInterval,
+				Depth:    DefaultWatcherDepth,
+			},
+		},
+		Auth: AuthConfig{
+			AccessTokenTTL: DefaultAccessTokenTTL,
+		},
+		Compliance: ComplianceConfig{
+			HoldRiskLevel:           DefaultHoldRiskLevel,
+			Timeout:                 DefaultComplianceTimeout,
+			SanctionsListURL:        DefaultSanctionsListURL,
+			SanctionsSyncInterval:   DefaultSanctionsSyncInterval,
+			BlocklistReloadInterval: DefaultBlocklistReloadInterval,
+		},
+		Cache: CacheConfig{
+			InvoiceTTL:        DefaultInvoiceCacheTTL,
+			InvoiceMaxEntries: DefaultInvoiceCacheMaxEntries,
+		},
+		Rates: RatesConfig{
+			LockDuration:    DefaultRateLockDuration,
+			RequoteInterval: DefaultRequoteInterval,
+			MaxSlippage:     DefaultMaxSlippage,
+			StalePayments:   DefaultStalePayments,
+		},
+		Settlement: SettlementConfig{
+			PlatformFeePercentage:  DefaultPlatformFeePercentage,
+			FiatCurrency:           DefaultFiatCurrency,
+			Timeout:                DefaultExchangeTimeout,
+			ConversionPollInterval: DefaultConversionPollInterval,
+		},
+		Processors: ProcessorsConfig{
+			Timeout: DefaultProcessorTimeout,
+		},
+		Payout: PayoutConfig{
+			Timeout:       DefaultHotWalletTimeout,
+			Interval:      DefaultPayoutInterval,
+			MinimumAmount: DefaultPayoutMinimumAmount,
+			ChallengeTTL:  DefaultPayoutChallengeTTL,
+			MaxFailures:   DefaultPayoutMaxFailures,
+		},
+		Treasury: TreasuryConfig{
+			Interval: DefaultTreasuryInterval,
+		},
+		Fees: FeesConfig{
+			Timeout: DefaultFeeOracleTimeout,
+		},
+		Approvals: ApprovalsConfig{
+			Required: DefaultApprovalsRequired,
+		},
+		GraphQL: GraphQLConfig{
+			MaxDepth: DefaultGraphQLMaxDepth,
+		},
+		Resilience: ResilienceConfig{
+			Defaults: BreakerConfig{
+				FailureThreshold: DefaultBreakerFailureThreshold,
+				OpenTimeout:      DefaultBreakerOpenTimeout,
+				MaxConcurrent:    DefaultBreakerMaxConcurrent,
+			},
+		},
+		IDs: IDsConfig{
+			Strategy: DefaultIDStrategy,
+		},
+		InvoiceNumbers: InvoiceNumbersConfig{
+			Reset: DefaultInvoiceNumberReset,
+		},
+		Archive: ArchiveConfig{
+			AfterMonths: DefaultArchiveAfterMonths,
+			Interval:    DefaultArchiveInterval,
+			BatchSize:   DefaultArchiveBatchSize,
+		},
+		Reconciliation: ReconciliationConfig{
+			Hour: DefaultReconciliationHour,
+		},
+		Statements: StatementsConfig{
+			Interval: DefaultStatementInterval,
+			Email:    true,
+			Storage: ObjectStorageConfig{
+				Driver:    DefaultStorageDriver,
+				Directory: DefaultStorageDirectory,
+				Timeout:   DefaultStorageTimeout,
+			},
+		},
+		Workers: WorkersConfig{
+			StallTimeout:      DefaultWorkerStallTimeout,
+			RestartBackoff:    DefaultWorkerRestartBackoff,
+			MaxRestartBackoff: DefaultWorkerMaxRestartBackoff,
+		},
+		ErrorReporting: ErrorReportingConfig{
+			SampleRate: DefaultErrorReportingSampleRate,
+			Timeout:    DefaultErrorReportingTimeout,
+		},
+		Customers: CustomersConfig{
+			LoginLinkTTL: DefaultCustomerLoginLinkTTL,
+			SessionTTL:   DefaultCustomerSessionTTL,
+		},
+		SMTP: SMTPConfig{
+			Port:    DefaultSMTPPort,
+			Timeout: DefaultSMTPTimeout,
+		},
+		Runtime: RuntimeConfig{
+			Confirmations: DefaultConfirmations(),
+			Webhooks: WebhookRetryConfig{
+				MaxRetries: DefaultWebhookMaxRetries,
+				Backoff:    DefaultWebhookBackoff,
+			},
+		},
+		Redirect: RedirectConfig{
+			TokenTTL: DefaultRedirectTokenTTL,
+		},
+		Security: SecurityConfig{
+			HSTSMaxAge: DefaultHSTSMaxAge,
+		},
+		Secrets: SecretsConfig{
+			Timeout: DefaultSecretsTimeout,
+		},
 	}
 }
 
@@ -0,0 +1,69 @@
+package i18n
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// numberFormat is how a locale separates thousands and decimals.
+type numberFormat struct {
+	group   string
+	decimal string
+}
+
+var numberFormats = map[Locale]numberFormat{
+	LocaleEnglish:    {group: ",", decimal: "."},
+	LocaleSpanish:    {group: ".", decimal: ","},
+	LocaleRussian:    {group: "\u00a0", decimal: ","}, // No-break space
+	LocaleChinese:    {group: ",", decimal: "."},
+	LocalePortuguese: {group: ".", decimal: ","},
+}
+
+var dateTimeLayouts = map[Locale]string{
+	LocaleEnglish:    "Jan 2, 2006 15:04 MST",
+	LocaleSpanish:    "02/01/2006 15:04 MST",
+	LocaleRussian:    "02.01.2006 15:04 MST",
+	LocaleChinese:    "2006-01-02 15:04 MST",
+	LocalePortuguese: "02/01/2006 15:04 MST",
+}
+
+// FormatAmount formats a fiat amount with the locale's separators and the given number of decimal places,
+// such as 1,234.50 in English and 1.234,50 in Spanish.
+// Crypto amounts are shown unformatted, exactly as they are to be sent.
+func FormatAmount(locale Locale, amount decimal.Decimal, places int32) string {
+	format, ok := numberFormats[locale]
+	if !ok {
+		format = numberFormats[DefaultLocale]
+	}
+
+	text := amount.StringFixed(places)
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+	integer, fraction, _ := strings.Cut(text, ".")
+
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteString(format.group)
+		}
+		grouped.WriteRune(digit)
+	}
+
+	if fraction == "" {
+		return sign + grouped.String()
+	}
+	return sign + grouped.String() + format.decimal + fraction
+}
+
+// FormatDateTime formats a time in the locale's conventional order, in the given time's location.
+func FormatDateTime(locale Locale, t time.Time) string {
+	layout, ok := dateTimeLayouts[locale]
+	if !ok {
+		layout = dateTimeLayouts[DefaultLocale]
+	}
+	return t.Format(layout)
+}
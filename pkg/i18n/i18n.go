@@ -0,0 +1,151 @@
+// Package i18n provides the translations of the checkout page and API error messages.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale is a language the checkout page and API messages are translated into, as a lower-case ISO 639-1 code.
+type Locale string
+
+// Supported locales.
+const (
+	LocaleEnglish    Locale = "en"
+	LocaleSpanish    Locale = "es"
+	LocaleRussian    Locale = "ru"
+	LocaleChinese    Locale = "zh"
+	LocalePortuguese Locale = "pt"
+)
+
+// DefaultLocale is used when no supported locale is requested; every message has an English translation.
+const DefaultLocale = LocaleEnglish
+
+// String returns the string representation of the locale.
+func (l Locale) String() string {
+	return string(l)
+}
+
+// SupportedLocales returns the locales with a translation bundle.
+func SupportedLocales() []Locale {
+	return []Locale{LocaleEnglish, LocaleSpanish, LocaleRussian, LocaleChinese, LocalePortuguese}
+}
+
+// IsSupported returns true if the tag names a supported locale, ignoring any region such as "pt-BR".
+func IsSupported(tag string) bool {
+	_, ok := ParseLocale(tag)
+	return ok
+}
+
+// ParseLocale returns the supported locale of a language tag such as "es", "pt-BR" or "zh_Hans".
+func ParseLocale(tag string) (Locale, bool) {
+	language, _, _ := strings.Cut(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+	locale := Locale(strings.ToLower(language))
+	if !slices.Contains(SupportedLocales(), locale) {
+		return "", false
+	}
+	return locale, true
+}
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+// Bundle holds the messages of every supported locale, keyed by message ID.
+type Bundle struct {
+	messages map[Locale]map[string]string
+}
+
+// NewBundle loads the translation bundles embedded in the binary.
+func NewBundle() (*Bundle, error) {
+	bundle := &Bundle{messages: make(map[Locale]map[string]string)}
+	for _, locale := range SupportedLocales() {
+		data, err := localesFS.ReadFile(path.Join("locales", locale.String()+".json"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s translations: %w", locale, err)
+		}
+		messages := make(map[string]string)
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("failed to parse %s translations: %w", locale, err)
+		}
+		bundle.messages[locale] = messages
+	}
+	return bundle, nil
+}
+
+// Translates returns true if the locale has its own translation of the message.
+func (b *Bundle) Translates(locale Locale, id string) bool {
+	_, ok := b.messages[locale][id]
+	return ok
+}
+
+// Translate returns the message in the locale, falling back to English and then to the ID itself.
+// Placeholders such as {amount} are replaced by the values given as name/value pairs.
+func (b *Bundle) Translate(locale Locale, id string, args ...string) string {
+	message, ok := b.messages[locale][id]
+	if !ok {
+		message, ok = b.messages[DefaultLocale][id]
+	}
+	if !ok {
+		return id
+	}
+
+	for i := 0; i+1 < len(args); i += 2 {
+		message = strings.ReplaceAll(message, "{"+args[i]+"}", args[i+1])
+	}
+	return message
+}
+
+// Messages returns every message whose ID starts with the prefix, translated into the locale.
+func (b *Bundle) Messages(locale Locale, prefix string) map[string]string {
+	messages := make(map[string]string)
+	for id := range b.messages[DefaultLocale] {
+		if strings.HasPrefix(id, prefix) {
+			messages[id] = b.Translate(locale, id)
+		}
+	}
+	return messages
+}
+
+// Negotiate picks the supported locale the client prefers from an Accept-Language header,
+// returning the fallback if the client accepts none of them.
+func Negotiate(acceptLanguage string, fallback Locale) Locale {
+	type preference struct {
+		locale  Locale
+		quality float64
+	}
+
+	var preferences []preference
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		locale, ok := ParseLocale(tag)
+		if !ok {
+			continue
+		}
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			preferences = append(preferences, preference{locale: locale, quality: quality})
+		}
+	}
+	if len(preferences) == 0 {
+		return fallback
+	}
+
+	// Equal qualities keep the client's order
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].quality > preferences[j].quality
+	})
+	return preferences[0].locale
+}
@@ -0,0 +1,65 @@
+package i18n_test
+
+import (
+	"crypto-checkout/pkg/i18n"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle(t *testing.T) {
+	bundle, err := i18n.NewBundle()
+	require.NoError(t, err)
+
+	t.Run("every locale translates every English message", func(t *testing.T) {
+		for _, locale := range i18n.SupportedLocales() {
+			for id := range bundle.Messages(i18n.LocaleEnglish, "") {
+				assert.True(t, bundle.Translates(locale, id), "%s is not translated into %s", id, locale)
+			}
+		}
+	})
+
+	t.Run("Translate replaces placeholders and falls back to the ID", func(t *testing.T) {
+		assert.Equal(t, "Ahora paga con BTC", bundle.Translate(i18n.LocaleSpanish, "checkout.currency_changed",
+			"currency", "BTC"))
+		assert.Equal(t, "checkout.unknown", bundle.Translate(i18n.LocaleSpanish, "checkout.unknown"))
+		assert.True(t, bundle.Translates(i18n.LocaleChinese, "errors.NOT_FOUND"))
+		assert.False(t, bundle.Translates(i18n.LocaleChinese, "errors.SOMETHING_ELSE"))
+	})
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   i18n.Locale
+	}{
+		{"", i18n.LocaleRussian},
+		{"es-ES,es;q=0.9,en;q=0.8", i18n.LocaleSpanish},
+		{"de-DE,pt-BR;q=0.7,en;q=0.5", i18n.LocalePortuguese},
+		{"en;q=0.4, zh-CN;q=0.8", i18n.LocaleChinese},
+		{"fr, ru;q=0", i18n.LocaleRussian},
+		{"de", i18n.LocaleRussian},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, i18n.Negotiate(tt.header, i18n.LocaleRussian))
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	amount := decimal.RequireFromString("-1234567.5")
+	assert.Equal(t, "-1,234,567.50", i18n.FormatAmount(i18n.LocaleEnglish, amount, 2))
+	assert.Equal(t, "-1.234.567,50", i18n.FormatAmount(i18n.LocaleSpanish, amount, 2))
+	assert.Equal(t, "-1\u00a0234\u00a0567,50", i18n.FormatAmount(i18n.LocaleRussian, amount, 2))
+	assert.Equal(t, "999", i18n.FormatAmount(i18n.LocalePortuguese, decimal.NewFromInt(999), 0))
+
+	at := time.Date(2025, time.March, 4, 17, 5, 0, 0, time.UTC)
+	assert.Equal(t, "Mar 4, 2025 17:05 UTC", i18n.FormatDateTime(i18n.LocaleEnglish, at))
+	assert.Equal(t, "04/03/2025 17:05 UTC", i18n.FormatDateTime(i18n.LocalePortuguese, at))
+	assert.Equal(t, "2025-03-04 17:05 UTC", i18n.FormatDateTime(i18n.LocaleChinese, at))
+}
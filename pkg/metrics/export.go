@@ -0,0 +1,215 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DatasourceInput is the name of the Prometheus data source the exported dashboard asks for when it is imported.
+const DatasourceInput = "DS_PROMETHEUS"
+
+// dashboard is a Grafana dashboard in the format of its import screen.
+type dashboard struct {
+	Inputs        []dashboardInput `json:"__inputs"`
+	UID           string           `json:"uid"`
+	Title         string           `json:"title"`
+	Tags          []string         `json:"tags"`
+	Timezone      string           `json:"timezone"`
+	Refresh       string           `json:"refresh"`
+	SchemaVersion int              `json:"schemaVersion"`
+	Version       int              `json:"version"`
+	Time          dashboardTime    `json:"time"`
+	Panels        []panel          `json:"panels"`
+}
+
+type dashboardInput struct {
+	Name       string `json:"name"`
+	Label      string `json:"label"`
+	Type       string `json:"type"`
+	PluginID   string `json:"pluginId"`
+	PluginName string `json:"pluginName"`
+}
+
+type dashboardTime struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type panel struct {
+	ID          int         `json:"id"`
+	Type        string      `json:"type"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Datasource  datasource  `json:"datasource"`
+	GridPos     gridPos     `json:"gridPos"`
+	FieldConfig fieldConfig `json:"fieldConfig"`
+	Targets     []target    `json:"targets"`
+}
+
+type datasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type gridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type fieldConfig struct {
+	Defaults fieldDefaults `json:"defaults"`
+}
+
+type fieldDefaults struct {
+	Unit string `json:"unit"`
+}
+
+type target struct {
+	RefID        string     `json:"refId"`
+	Datasource   datasource `json:"datasource"`
+	Expr         string     `json:"expr"`
+	LegendFormat string     `json:"legendFormat"`
+}
+
+// Dashboard returns a Grafana dashboard with a panel for every registered metric, in the JSON format of
+// Grafana's import screen. The dashboard asks for its Prometheus data source when it is imported.
+func (r *Registry) Dashboard(uid, title string) ([]byte, error) {
+	source := datasource{Type: "prometheus", UID: "${" + DatasourceInput + "}"}
+	board := dashboard{
+		Inputs: []dashboardInput{{
+			Name:       DatasourceInput,
+			Label:      "Prometheus",
+			Type:       "datasource",
+			PluginID:   "prometheus",
+			PluginName: "Prometheus",
+		}},
+		UID:           uid,
+		Title:         title,
+		Tags:          []string{"crypto-checkout"},
+		Timezone:      "browser",
+		Refresh:       "30s",
+		SchemaVersion: 39,
+		Version:       1,
+		Time:          dashboardTime{From: "now-6h", To: "now"},
+		Panels:        []panel{},
+	}
+
+	for i, d := range r.Descs() {
+		unit := "short"
+		if strings.HasSuffix(d.Name, "_seconds") {
+			unit = "s"
+		}
+		p := panel{
+			ID:          i + 1,
+			Type:        "timeseries",
+			Title:       panelTitle(d.Name),
+			Description: d.Help,
+			Datasource:  source,
+			GridPos:     gridPos{H: 8, W: 12, X: (i % 2) * 12, Y: (i / 2) * 8},
+			FieldConfig: fieldConfig{Defaults: fieldDefaults{Unit: unit}},
+		}
+		for j, query := range panelQueries(d) {
+			p.Targets = append(p.Targets, target{
+				RefID:        string(rune('A' + j)),
+				Datasource:   source,
+				Expr:         query[0],
+				LegendFormat: query[1],
+			})
+		}
+		board.Panels = append(board.Panels, p)
+	}
+
+	encoded, err := json.MarshalIndent(board, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode dashboard: %w", err)
+	}
+	return append(encoded, '\n'), nil
+}
+
+// panelQueries returns the PromQL expressions and legends that chart a metric: the rate of a counter, the value
+// of a gauge and the median and 95th percentile of a histogram.
+func panelQueries(d Desc) [][2]string {
+	legend := d.Name
+	if len(d.Labels) > 0 {
+		parts := make([]string, len(d.Labels))
+		for i, label := range d.Labels {
+			parts[i] = "{{" + label + "}}"
+		}
+		legend = strings.Join(parts, " ")
+	}
+
+	switch d.Kind {
+	case KindCounter:
+		return [][2]string{{aggregate("sum", d.Labels, "rate("+d.Name+"[5m])"), legend}}
+	case KindHistogram:
+		byBucket := aggregate("sum", append([]string{"le"}, d.Labels...), "rate("+d.Name+"_bucket[5m])")
+		return [][2]string{
+			{"histogram_quantile(0.5, " + byBucket + ")", "p50 " + legend},
+			{"histogram_quantile(0.95, " + byBucket + ")", "p95 " + legend},
+		}
+	default:
+		return [][2]string{{aggregate("max", d.Labels, d.Name), legend}}
+	}
+}
+
+// aggregate wraps an expression in an aggregation by the labels.
+func aggregate(operator string, labels []string, expr string) string {
+	if len(labels) == 0 {
+		return operator + "(" + expr + ")"
+	}
+	return operator + " by (" + strings.Join(labels, ", ") + ") (" + expr + ")"
+}
+
+// panelTitle makes a readable title of a metric name, such as "Webhook deliveries" for
+// crypto_checkout_webhook_deliveries_total.
+func panelTitle(name string) string {
+	title := strings.TrimPrefix(name, Namespace+"_")
+	title = strings.TrimSuffix(title, "_total")
+	title = strings.ReplaceAll(title, "_", " ")
+	if title == "" {
+		return name
+	}
+	return strings.ToUpper(title[:1]) + title[1:]
+}
+
+// PrometheusRules returns the alert rules as a Prometheus rule file with a single group.
+func (r *Registry) PrometheusRules(group string) []byte {
+	var b strings.Builder
+	b.WriteString("groups:\n")
+	b.WriteString("  - name: " + strconv.Quote(group) + "\n")
+	b.WriteString("    rules:\n")
+	for _, rule := range r.Alerts() {
+		b.WriteString("      - alert: " + rule.Name + "\n")
+		b.WriteString("        expr: " + strconv.Quote(rule.Expr) + "\n")
+		if rule.For > 0 {
+			b.WriteString("        for: " + promDuration(rule.For) + "\n")
+		}
+		severity := rule.Severity
+		if severity == "" {
+			severity = SeverityWarning
+		}
+		b.WriteString("        labels:\n")
+		b.WriteString("          severity: " + severity + "\n")
+		b.WriteString("        annotations:\n")
+		b.WriteString("          summary: " + strconv.Quote(rule.Summary) + "\n")
+		b.WriteString("          description: " + strconv.Quote(rule.Description) + "\n")
+	}
+	return []byte(b.String())
+}
+
+// promDuration formats a duration the way Prometheus writes them, such as 15m or 1h.
+func promDuration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return strconv.FormatInt(int64(d/time.Hour), 10) + "h"
+	case d%time.Minute == 0:
+		return strconv.FormatInt(int64(d/time.Minute), 10) + "m"
+	default:
+		return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+	}
+}
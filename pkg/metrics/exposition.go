@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the media type of the Prometheus text format written by WriteText.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// WriteText writes the current value of every registered metric in the Prometheus text format, sorted by name.
+// A gauge that fails to collect is left out and its error returned once the others are written.
+func (r *Registry) WriteText(ctx context.Context, w io.Writer) error {
+	r.mu.RLock()
+	all := make([]metric, 0, len(r.metrics))
+	for _, m := range r.metrics {
+		all = append(all, m)
+	}
+	r.mu.RUnlock()
+	slices.SortFunc(all, func(a, b metric) int { return strings.Compare(a.desc().Name, b.desc().Name) })
+
+	out := bufio.NewWriter(w)
+	var errs []error
+	for _, m := range all {
+		d := m.desc()
+		samples, err := m.collect(ctx)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		slices.SortStableFunc(samples, compareSeries)
+
+		out.WriteString("# HELP " + d.Name + " " + escapeHelp(d.Help) + "\n")
+		out.WriteString("# TYPE " + d.Name + " " + string(d.Kind) + "\n")
+		for _, s := range samples {
+			out.WriteString(d.Name + s.suffix)
+			if len(s.labels) > 0 {
+				out.WriteByte('{')
+				for i, label := range s.labels {
+					if i > 0 {
+						out.WriteByte(',')
+					}
+					out.WriteString(label + `="` + escapeLabelValue(s.values[i]) + `"`)
+				}
+				out.WriteByte('}')
+			}
+			out.WriteString(" " + formatValue(s.value) + "\n")
+		}
+	}
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// compareSeries orders the series of a metric by label values, leaving out the bound of a histogram's bucket so
+// that a stable sort keeps the buckets, sum and count of each combination together and in order.
+func compareSeries(a, b series) int {
+	return slices.Compare(seriesKey(a), seriesKey(b))
+}
+
+// seriesKey returns the label values a series is sorted by.
+func seriesKey(s series) []string {
+	if s.suffix == "_bucket" {
+		return s.values[:len(s.values)-1]
+	}
+	return s.values
+}
+
+// formatValue formats a sample value or bucket bound as Prometheus does.
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// escapeHelp escapes the backslashes and line feeds of a help text.
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+// escapeLabelValue escapes the backslashes, quotes and line feeds of a label value.
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
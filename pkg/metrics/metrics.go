@@ -0,0 +1,400 @@
+// Package metrics keeps the metrics the application publishes in the Prometheus text format on /metrics, and the
+// alert rules written against them. Packages register their metrics and alerts at initialization, the way they
+// publish expvar maps, so that the Grafana dashboard and the Prometheus rules exported by the observability
+// command always match what the binary serves.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Namespace prefixes the name of every metric of the application.
+const Namespace = "crypto_checkout"
+
+// Kind is the Prometheus type of a metric.
+type Kind string
+
+// Metric kinds
+const (
+	KindCounter   Kind = "counter"
+	KindGauge     Kind = "gauge"
+	KindHistogram Kind = "histogram"
+)
+
+// Desc describes a registered metric.
+type Desc struct {
+	Name   string
+	Help   string
+	Kind   Kind
+	Labels []string
+	// Buckets are the upper bounds of the buckets of a histogram, in increasing order.
+	Buckets []float64
+}
+
+// Sample is the value of a gauge for one combination of label values, in the order of the gauge's labels.
+type Sample struct {
+	Labels []string
+	Value  float64
+}
+
+// CollectFunc returns the current samples of a gauge that is read when it is scraped.
+type CollectFunc func(ctx context.Context) ([]Sample, error)
+
+// AlertRule is a Prometheus alerting rule. Metrics names the registered metrics the expression reads, so that a
+// rule cannot outlive the metric it watches.
+type AlertRule struct {
+	Name        string
+	Expr        string
+	For         time.Duration
+	Severity    string
+	Summary     string
+	Description string
+	Metrics     []string
+}
+
+// Alert severities
+const (
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// metric is a registered metric with its state.
+type metric interface {
+	desc() *Desc
+	collect(ctx context.Context) ([]series, error)
+}
+
+// series is one time series of a metric as written on /metrics.
+type series struct {
+	suffix string
+	labels []string
+	values []string
+	value  float64
+}
+
+// Registry holds registered metrics and alert rules.
+type Registry struct {
+	mu      sync.RWMutex
+	metrics map[string]metric
+	alerts  []AlertRule
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{metrics: make(map[string]metric)}
+}
+
+// defaultRegistry holds the metrics of the application.
+var defaultRegistry = NewRegistry()
+
+// Default returns the registry of the application's metrics.
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// register adds a metric, panicking on an invalid or duplicate name like expvar does.
+func (r *Registry) register(m metric) {
+	d := m.desc()
+	if !validName(d.Name) {
+		panic(fmt.Sprintf("metrics: invalid metric name %q", d.Name))
+	}
+	for _, label := range d.Labels {
+		if !validName(label) || label == "le" {
+			panic(fmt.Sprintf("metrics: invalid label %q of %s", label, d.Name))
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.metrics[d.Name]; ok {
+		panic(fmt.Sprintf("metrics: %s registered twice", d.Name))
+	}
+	r.metrics[d.Name] = m
+}
+
+// Descs returns the descriptions of the registered metrics, sorted by name.
+func (r *Registry) Descs() []Desc {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	descs := make([]Desc, 0, len(r.metrics))
+	for _, m := range r.metrics {
+		descs = append(descs, *m.desc())
+	}
+	slices.SortFunc(descs, func(a, b Desc) int { return strings.Compare(a.Name, b.Name) })
+	return descs
+}
+
+// AddAlert adds an alert rule, panicking if it reads a metric that is not registered.
+func (r *Registry) AddAlert(rule AlertRule) {
+	if rule.Name == "" || rule.Expr == "" || len(rule.Metrics) == 0 {
+		panic(fmt.Sprintf("metrics: alert %q needs an expression and the metrics it reads", rule.Name))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range rule.Metrics {
+		if _, ok := r.metrics[name]; !ok {
+			panic(fmt.Sprintf("metrics: alert %s reads unregistered metric %s", rule.Name, name))
+		}
+	}
+	for _, existing := range r.alerts {
+		if existing.Name == rule.Name {
+			panic(fmt.Sprintf("metrics: alert %s added twice", rule.Name))
+		}
+	}
+	r.alerts = append(r.alerts, rule)
+}
+
+// Alerts returns the alert rules, sorted by name.
+func (r *Registry) Alerts() []AlertRule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	alerts := slices.Clone(r.alerts)
+	slices.SortFunc(alerts, func(a, b AlertRule) int { return strings.Compare(a.Name, b.Name) })
+	return alerts
+}
+
+// AddAlert adds an alert rule to the application's registry.
+func AddAlert(rule AlertRule) {
+	defaultRegistry.AddAlert(rule)
+}
+
+// Counter is a monotonically increasing metric with labels.
+type Counter struct {
+	d      Desc
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// NewCounter registers a counter with the registry.
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	c := &Counter{
+		d:      Desc{Name: name, Help: help, Kind: KindCounter, Labels: labels},
+		values: make(map[string]float64),
+	}
+	r.register(c)
+	return c
+}
+
+// NewCounter registers a counter with the application's registry.
+func NewCounter(name, help string, labels ...string) *Counter {
+	return defaultRegistry.NewCounter(name, help, labels...)
+}
+
+// Name returns the name of the counter.
+func (c *Counter) Name() string {
+	return c.d.Name
+}
+
+// Inc adds one to the counter for the label values.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds a non-negative delta to the counter for the label values.
+func (c *Counter) Add(delta float64, labelValues ...string) {
+	key := c.d.key(labelValues)
+	if delta < 0 {
+		panic(fmt.Sprintf("metrics: %s cannot decrease", c.d.Name))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] += delta
+}
+
+// Value returns the count for the label values, zero until they are first counted.
+func (c *Counter) Value(labelValues ...string) float64 {
+	key := c.d.key(labelValues)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[key]
+}
+
+func (c *Counter) desc() *Desc {
+	return &c.d
+}
+
+func (c *Counter) collect(context.Context) ([]series, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	all := make([]series, 0, len(c.values))
+	for key, value := range c.values {
+		all = append(all, series{labels: c.d.Labels, values: splitKey(key, len(c.d.Labels)), value: value})
+	}
+	return all, nil
+}
+
+// GaugeFunc is a gauge whose samples are read when it is scraped, such as a count kept in the database.
+type GaugeFunc struct {
+	d      Desc
+	mu     sync.RWMutex
+	source CollectFunc
+}
+
+// NewGaugeFunc registers a gauge with the registry. It has no samples until Collect gives it a source.
+func (r *Registry) NewGaugeFunc(name, help string, labels ...string) *GaugeFunc {
+	g := &GaugeFunc{d: Desc{Name: name, Help: help, Kind: KindGauge, Labels: labels}}
+	r.register(g)
+	return g
+}
+
+// NewGaugeFunc registers a gauge with the application's registry.
+func NewGaugeFunc(name, help string, labels ...string) *GaugeFunc {
+	return defaultRegistry.NewGaugeFunc(name, help, labels...)
+}
+
+// Name returns the name of the gauge.
+func (g *GaugeFunc) Name() string {
+	return g.d.Name
+}
+
+// Collect sets where the gauge reads its samples, replacing any previous source.
+func (g *GaugeFunc) Collect(source CollectFunc) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.source = source
+}
+
+func (g *GaugeFunc) desc() *Desc {
+	return &g.d
+}
+
+func (g *GaugeFunc) collect(ctx context.Context) ([]series, error) {
+	g.mu.RLock()
+	source := g.source
+	g.mu.RUnlock()
+	if source == nil {
+		return nil, nil
+	}
+
+	samples, err := source(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect %s: %w", g.d.Name, err)
+	}
+	all := make([]series, len(samples))
+	for i, sample := range samples {
+		if len(sample.Labels) != len(g.d.Labels) {
+			return nil, fmt.Errorf("%s has %d labels, got %d values", g.d.Name, len(g.d.Labels), len(sample.Labels))
+		}
+		all[i] = series{labels: g.d.Labels, values: sample.Labels, value: sample.Value}
+	}
+	return all, nil
+}
+
+// Histogram counts observations in buckets, such as durations.
+type Histogram struct {
+	d      Desc
+	mu     sync.Mutex
+	values map[string]*histogramValue
+}
+
+// histogramValue holds the buckets of a histogram for one combination of label values.
+type histogramValue struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewHistogram registers a histogram with the registry.
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	if len(buckets) == 0 || !slices.IsSorted(buckets) {
+		panic(fmt.Sprintf("metrics: %s needs increasing buckets", name))
+	}
+	h := &Histogram{
+		d:      Desc{Name: name, Help: help, Kind: KindHistogram, Labels: labels, Buckets: slices.Clone(buckets)},
+		values: make(map[string]*histogramValue),
+	}
+	r.register(h)
+	return h
+}
+
+// NewHistogram registers a histogram with the application's registry.
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return defaultRegistry.NewHistogram(name, help, buckets, labels...)
+}
+
+// Name returns the name of the histogram.
+func (h *Histogram) Name() string {
+	return h.d.Name
+}
+
+// Observe records an observation for the label values.
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	key := h.d.key(labelValues)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	v, ok := h.values[key]
+	if !ok {
+		v = &histogramValue{counts: make([]uint64, len(h.d.Buckets))}
+		h.values[key] = v
+	}
+	for i, bound := range h.d.Buckets {
+		if value <= bound {
+			v.counts[i]++
+		}
+	}
+	v.count++
+	v.sum += value
+}
+
+func (h *Histogram) desc() *Desc {
+	return &h.d
+}
+
+func (h *Histogram) collect(context.Context) ([]series, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	bucketLabels := append(slices.Clone(h.d.Labels), "le")
+	var all []series
+	for key, v := range h.values {
+		values := splitKey(key, len(h.d.Labels))
+		for i, bound := range h.d.Buckets {
+			all = append(all, series{suffix: "_bucket", labels: bucketLabels,
+				values: append(slices.Clone(values), formatValue(bound)), value: float64(v.counts[i])})
+		}
+		all = append(all,
+			series{suffix: "_bucket", labels: bucketLabels, values: append(slices.Clone(values), "+Inf"),
+				value: float64(v.count)},
+			series{suffix: "_sum", labels: h.d.Labels, values: values, value: v.sum},
+			series{suffix: "_count", labels: h.d.Labels, values: values, value: float64(v.count)},
+		)
+	}
+	return all, nil
+}
+
+// key joins label values into a map key, panicking when their number does not match the labels.
+func (d *Desc) key(labelValues []string) string {
+	if len(labelValues) != len(d.Labels) {
+		panic(fmt.Sprintf("metrics: %s has %d labels, got %d values", d.Name, len(d.Labels), len(labelValues)))
+	}
+	return strings.Join(labelValues, "\xff")
+}
+
+// splitKey splits a map key back into label values.
+func splitKey(key string, labels int) []string {
+	if labels == 0 {
+		return nil
+	}
+	return strings.Split(key, "\xff")
+}
+
+// validName reports whether a metric or label name is valid in Prometheus.
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,147 @@
+package metrics_test
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/pkg/metrics"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_WriteText(t *testing.T) {
+	registry := metrics.NewRegistry()
+	deliveries := registry.NewCounter("test_deliveries_total", "Deliveries sent.", "status")
+	backlog := registry.NewGaugeFunc("test_backlog", "Deliveries waiting.")
+	latency := registry.NewHistogram("test_latency_seconds", "Latency.\nIn seconds.", []float64{1, 5}, "network")
+
+	deliveries.Inc("failed")
+	deliveries.Add(2, `ok "quoted"`)
+	assert.InDelta(t, 2, deliveries.Value(`ok "quoted"`), 0)
+	assert.Zero(t, deliveries.Value("unknown"))
+	latency.Observe(0.5, "bitcoin")
+	latency.Observe(3, "bitcoin")
+	latency.Observe(10, "bitcoin")
+
+	var out bytes.Buffer
+	require.NoError(t, registry.WriteText(context.Background(), &out))
+	assert.Equal(t, `# HELP test_backlog Deliveries waiting.
+# TYPE test_backlog gauge
+# HELP test_deliveries_total Deliveries sent.
+# TYPE test_deliveries_total counter
+test_deliveries_total{status="failed"} 1
+test_deliveries_total{status="ok \"quoted\""} 2
+# HELP test_latency_seconds Latency.\nIn seconds.
+# TYPE test_latency_seconds histogram
+test_latency_seconds_bucket{network="bitcoin",le="1"} 1
+test_latency_seconds_bucket{network="bitcoin",le="5"} 2
+test_latency_seconds_bucket{network="bitcoin",le="+Inf"} 3
+test_latency_seconds_sum{network="bitcoin"} 13.5
+test_latency_seconds_count{network="bitcoin"} 3
+`, out.String())
+
+	backlog.Collect(func(context.Context) ([]metrics.Sample, error) {
+		return []metrics.Sample{{Value: 7}}, nil
+	})
+	out.Reset()
+	require.NoError(t, registry.WriteText(context.Background(), &out))
+	assert.Contains(t, out.String(), "# TYPE test_backlog gauge\ntest_backlog 7\n")
+
+	backlog.Collect(func(context.Context) ([]metrics.Sample, error) {
+		return nil, errors.New("database is down")
+	})
+	out.Reset()
+	require.ErrorContains(t, registry.WriteText(context.Background(), &out), "database is down")
+	assert.NotContains(t, out.String(), "test_backlog")
+	assert.Contains(t, out.String(), "test_deliveries_total", "a failing gauge must not hide the other metrics")
+}
+
+func TestRegistry_Registration(t *testing.T) {
+	registry := metrics.NewRegistry()
+	counter := registry.NewCounter("test_total", "Test.", "kind")
+
+	assert.Panics(t, func() { registry.NewCounter("test_total", "Again.") }, "duplicate name")
+	assert.Panics(t, func() { registry.NewCounter("test-invalid", "Invalid.") }, "invalid name")
+	assert.Panics(t, func() { registry.NewHistogram("test_seconds", "Unsorted.", []float64{5, 1}) })
+	assert.Panics(t, func() { counter.Inc() }, "missing label value")
+	assert.Panics(t, func() { counter.Add(-1, "a") }, "counters cannot decrease")
+
+	assert.Panics(t, func() {
+		registry.AddAlert(metrics.AlertRule{Name: "Missing", Expr: "missing > 0", Metrics: []string{"missing"}})
+	}, "alerts must read registered metrics")
+	registry.AddAlert(metrics.AlertRule{Name: "Test", Expr: "test_total > 0", Metrics: []string{counter.Name()}})
+	assert.Panics(t, func() {
+		registry.AddAlert(metrics.AlertRule{Name: "Test", Expr: "test_total > 1", Metrics: []string{counter.Name()}})
+	}, "duplicate alert")
+	require.Len(t, registry.Alerts(), 1)
+}
+
+func TestRegistry_Export(t *testing.T) {
+	registry := metrics.NewRegistry()
+	counter := registry.NewCounter("crypto_checkout_test_deliveries_total", "Deliveries.", "status")
+	registry.NewGaugeFunc("crypto_checkout_test_backlog", "Backlog.")
+	registry.NewHistogram("crypto_checkout_test_latency_seconds", "Latency.", []float64{1}, "network")
+	registry.AddAlert(metrics.AlertRule{
+		Name:        "TestDeliveriesFailing",
+		Expr:        `sum(rate(crypto_checkout_test_deliveries_total{status="failed"}[5m])) > 0`,
+		For:         15 * time.Minute,
+		Summary:     "Deliveries are failing",
+		Description: "{{ $value }} deliveries failed.",
+		Metrics:     []string{counter.Name()},
+	})
+
+	t.Run("Dashboard", func(t *testing.T) {
+		encoded, err := registry.Dashboard("test", "Test")
+		require.NoError(t, err)
+
+		var dashboard struct {
+			Inputs []struct {
+				Name string `json:"name"`
+			} `json:"__inputs"`
+			Panels []struct {
+				Title   string `json:"title"`
+				Targets []struct {
+					Expr string `json:"expr"`
+				} `json:"targets"`
+				FieldConfig struct {
+					Defaults struct {
+						Unit string `json:"unit"`
+					} `json:"defaults"`
+				} `json:"fieldConfig"`
+			} `json:"panels"`
+		}
+		require.NoError(t, json.Unmarshal(encoded, &dashboard))
+		require.Len(t, dashboard.Inputs, 1)
+		assert.Equal(t, metrics.DatasourceInput, dashboard.Inputs[0].Name)
+		require.Len(t, dashboard.Panels, 3)
+
+		assert.Equal(t, "Test backlog", dashboard.Panels[0].Title)
+		assert.Equal(t, "max(crypto_checkout_test_backlog)", dashboard.Panels[0].Targets[0].Expr)
+		assert.Equal(t, "Test deliveries", dashboard.Panels[1].Title)
+		assert.Equal(t, "sum by (status) (rate(crypto_checkout_test_deliveries_total[5m]))",
+			dashboard.Panels[1].Targets[0].Expr)
+		require.Len(t, dashboard.Panels[2].Targets, 2)
+		assert.Equal(t, "histogram_quantile(0.95, sum by (le, network) "+
+			"(rate(crypto_checkout_test_latency_seconds_bucket[5m])))", dashboard.Panels[2].Targets[1].Expr)
+		assert.Equal(t, "s", dashboard.Panels[2].FieldConfig.Defaults.Unit)
+	})
+
+	t.Run("PrometheusRules", func(t *testing.T) {
+		assert.Equal(t, `groups:
+  - name: "test"
+    rules:
+      - alert: TestDeliveriesFailing
+        expr: "sum(rate(crypto_checkout_test_deliveries_total{status=\"failed\"}[5m])) > 0"
+        for: 15m
+        labels:
+          severity: warning
+        annotations:
+          summary: "Deliveries are failing"
+          description: "{{ $value }} deliveries failed."
+`, string(registry.PrometheusRules("test")))
+	})
+}
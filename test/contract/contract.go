@@ -0,0 +1,239 @@
+// Package contract checks API responses against the OpenAPI document served at /api/v1/openapi.json.
+//
+// Recorded exchanges (cassettes) in testdata are replayed through the app with Replay, and Client wraps an
+// http.Client so end-to-end tests fail whenever a handler returns a status or body the document does not describe.
+package contract
+
+import (
+	"bytes"
+	"context"
+	"crypto-checkout/internal/presentation/web"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/stretchr/testify/require"
+)
+
+// ErrUndocumentedRoute is returned for a request whose method and path are not in the OpenAPI document.
+var ErrUndocumentedRoute = errors.New("contract: route is not in the OpenAPI document")
+
+// Validator validates HTTP responses against an OpenAPI document.
+type Validator struct {
+	router  routers.Router
+	options *openapi3filter.Options
+}
+
+// NewValidator creates a validator for the OpenAPI document served by the API.
+func NewValidator() (*Validator, error) {
+	doc, err := web.OpenAPISpec()
+	if err != nil {
+		return nil, err
+	}
+	return NewValidatorForDocument(doc)
+}
+
+// NewValidatorForDocument creates a validator for an OpenAPI document. Routes are matched by path alone,
+// so responses from test servers on any host are validated.
+func NewValidatorForDocument(doc *openapi3.T) (*Validator, error) {
+	spec := *doc
+	spec.Servers = nil
+	router, err := legacy.NewRouter(&spec)
+	if err != nil {
+		return nil, fmt.Errorf("contract: failed to build router: %w", err)
+	}
+	return &Validator{
+		router: router,
+		options: &openapi3filter.Options{
+			IncludeResponseStatus: true,
+			MultiError:            true,
+			AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+		},
+	}, nil
+}
+
+// ValidateResponse checks that the response status is documented for the route of the request and that the
+// body matches its schema.
+func (v *Validator) ValidateResponse(req *http.Request, status int, header http.Header, body []byte) error {
+	route, pathParams, err := v.router.FindRoute(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s %s", ErrUndocumentedRoute, req.Method, req.URL.Path)
+	}
+
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    v.options,
+		},
+		Status:  status,
+		Header:  header,
+		Options: v.options,
+	}
+	input.SetBodyBytes(body)
+	if err := openapi3filter.ValidateResponse(req.Context(), input); err != nil {
+		return fmt.Errorf("contract: %s %s returned %d: %w", req.Method, route.Path, status, err)
+	}
+	return nil
+}
+
+// Client returns an HTTP client that validates every response it receives, failing the test when one does not
+// match the OpenAPI document. Use it in end-to-end tests instead of asserting on individual response fields.
+func Client(t testing.TB, validator *Validator) *http.Client {
+	return &http.Client{Transport: &transport{t: t, validator: validator, next: http.DefaultTransport}}
+}
+
+type transport struct {
+	t         testing.TB
+	validator *Validator
+	next      http.RoundTripper
+}
+
+// RoundTrip sends the request and validates the response, leaving its body readable by the caller.
+func (tr *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := tr.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := tr.validator.ValidateResponse(req, resp.StatusCode, resp.Header, body); err != nil {
+		tr.t.Errorf("%v", err)
+	}
+	return resp, nil
+}
+
+// Cassette is a recorded sequence of API exchanges. Exchanges run in order, so later ones may use values
+// captured from earlier responses.
+type Cassette struct {
+	Name      string     `json:"name"`
+	Exchanges []Exchange `json:"exchanges"`
+}
+
+// Exchange is a recorded request and the status the API responded with. Capture maps variable names to
+// dot-separated fields of the response body; a variable is referenced as {{name}} in later paths, headers
+// and bodies.
+type Exchange struct {
+	Name    string            `json:"name"`
+	Request RecordedRequest   `json:"request"`
+	Status  int               `json:"status"`
+	Capture map[string]string `json:"capture,omitempty"`
+}
+
+// RecordedRequest is the request of a recorded exchange.
+type RecordedRequest struct {
+	Method string            `json:"method"`
+	Path   string            `json:"path"`
+	Header map[string]string `json:"header,omitempty"`
+	Body   json.RawMessage   `json:"body,omitempty"`
+}
+
+// LoadCassettes reads every cassette in the directory.
+func LoadCassettes(dir string) ([]*Cassette, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	cassettes := make([]*Cassette, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var cassette Cassette
+		if err := json.Unmarshal(data, &cassette); err != nil {
+			return nil, fmt.Errorf("contract: failed to parse %s: %w", path, err)
+		}
+		if cassette.Name == "" {
+			cassette.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+		}
+		cassettes = append(cassettes, &cassette)
+	}
+	return cassettes, nil
+}
+
+var variable = regexp.MustCompile(`\{\{([A-Za-z0-9_]+)\}\}`)
+
+// Replay sends the exchanges of the cassette to the handler, requiring each response to have the recorded status
+// and to match the OpenAPI document.
+func Replay(t *testing.T, handler http.Handler, validator *Validator, cassette *Cassette) {
+	t.Helper()
+	vars := make(map[string]string)
+	expand := func(s string) string {
+		return variable.ReplaceAllStringFunc(s, func(match string) string {
+			name := variable.FindStringSubmatch(match)[1]
+			value, ok := vars[name]
+			require.True(t, ok, "variable %s is not captured by an earlier exchange", name)
+			return value
+		})
+	}
+
+	for _, exchange := range cassette.Exchanges {
+		var body io.Reader = http.NoBody
+		if len(exchange.Request.Body) > 0 {
+			body = strings.NewReader(expand(string(exchange.Request.Body)))
+		}
+		req := httptest.NewRequestWithContext(context.Background(),
+			exchange.Request.Method, expand(exchange.Request.Path), body)
+		if len(exchange.Request.Body) > 0 {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		for name, value := range exchange.Request.Header {
+			req.Header.Set(name, expand(value))
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, exchange.Status, w.Code, "%s: %s", exchange.Name, w.Body.String())
+		require.NoError(t, validator.ValidateResponse(req, w.Code, w.Header(), w.Body.Bytes()), exchange.Name)
+
+		for name, field := range exchange.Capture {
+			value, err := lookup(w.Body.Bytes(), field)
+			require.NoError(t, err, "%s: capture %s", exchange.Name, name)
+			vars[name] = value
+		}
+	}
+}
+
+// lookup returns the string form of a dot-separated field of a JSON document.
+func lookup(body []byte, field string) (string, error) {
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return "", err
+	}
+	for _, key := range strings.Split(field, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("field %s is not in the response", field)
+		}
+		if value, ok = object[key]; !ok {
+			return "", fmt.Errorf("field %s is not in the response", field)
+		}
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(value)
+	return string(data), err
+}
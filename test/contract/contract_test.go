@@ -0,0 +1,97 @@
+package contract_test
+
+import (
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/test/contract"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newTestApp serves the API routes backed by in-memory services. Merchant routes act for "test-merchant".
+func newTestApp(t *testing.T) http.Handler {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(web.RequestContextMiddleware(), web.ErrorHandler(&config.Config{}, zap.NewNop()))
+
+	handler, services := web.CreateTestHandlerWithServices()
+	handler.RegisterRoutes(router)
+
+	protected := router.Group("/api/v1", func(c *gin.Context) {
+		c.Set("merchant_id", "test-merchant")
+		c.Next()
+	})
+	web.NewTaxHandlers(services.Tax, zap.NewNop()).RegisterTaxRoutes(protected, nil)
+	web.NewPaymentLinkHandlers(services.PaymentLinks, zap.NewNop()).RegisterPaymentLinkRoutes(protected, nil)
+	web.NewPaymentHandlers(services.Payments, services.Invoices, nil, nil, zap.NewNop()).
+		RegisterPaymentRoutes(protected, nil)
+	web.NewSettlementHandlers(services.Settlements, nil, zap.NewNop()).RegisterSettlementRoutes(protected, nil)
+	web.NewTeamHandlers(services.Team, zap.NewNop()).RegisterTeamRoutes(router.Group("/api/v1"), protected, nil)
+
+	webhookCfg := &config.Config{Webhooks: config.WebhooksConfig{EgressIPs: []string{"203.0.113.10"}}}
+	webhooks, err := web.NewWebhookDeliveryHandlers(services.Webhooks, webhookCfg, zap.NewNop())
+	require.NoError(t, err)
+	webhooks.RegisterWebhookDeliveryRoutes(protected, nil)
+	return router
+}
+
+func TestContract(t *testing.T) {
+	validator, err := contract.NewValidator()
+	require.NoError(t, err)
+
+	cassettes, err := contract.LoadCassettes("testdata")
+	require.NoError(t, err)
+	require.NotEmpty(t, cassettes)
+
+	for _, cassette := range cassettes {
+		t.Run(cassette.Name, func(t *testing.T) {
+			contract.Replay(t, newTestApp(t), validator, cassette)
+		})
+	}
+}
+
+func TestValidator(t *testing.T) {
+	validator, err := contract.NewValidator()
+	require.NoError(t, err)
+
+	get := func(path string) *http.Request {
+		return httptest.NewRequest(http.MethodGet, path, nil)
+	}
+
+	t.Run("MatchingResponse_Valid", func(t *testing.T) {
+		body := []byte(`{"error":"not_found","message":"Invoice not found","code":"NOT_FOUND"}`)
+		header := http.Header{"Content-Type": {"application/json"}}
+		require.NoError(t, validator.ValidateResponse(get("/api/v1/invoices/inv_1"), http.StatusNotFound, header, body))
+	})
+
+	t.Run("WrongFieldType_Rejected", func(t *testing.T) {
+		body := []byte(`{"error":"not_found","message":"Invoice not found","code":404}`)
+		header := http.Header{"Content-Type": {"application/json"}}
+		require.Error(t, validator.ValidateResponse(get("/api/v1/invoices/inv_1"), http.StatusNotFound, header, body))
+	})
+
+	t.Run("UndocumentedStatus_Rejected", func(t *testing.T) {
+		header := http.Header{"Content-Type": {"application/json"}}
+		require.Error(t, validator.ValidateResponse(get("/api/v1/invoices/inv_1"), http.StatusTeapot, header, []byte(`{}`)))
+	})
+
+	t.Run("UndocumentedRoute_Rejected", func(t *testing.T) {
+		err := validator.ValidateResponse(get("/api/v1/unknown"), http.StatusOK, http.Header{}, nil)
+		require.ErrorIs(t, err, contract.ErrUndocumentedRoute)
+	})
+
+	t.Run("Client_ValidatesResponses", func(t *testing.T) {
+		server := httptest.NewServer(newTestApp(t))
+		defer server.Close()
+
+		resp, err := contract.Client(t, validator).Get(server.URL + "/api/v1/public/invoice/missing")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
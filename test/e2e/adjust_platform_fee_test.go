@@ -16,6 +16,8 @@ func TestAdjustPlatformFeeRate(t *testing.T) {
 	baseURL := StartTestApp(t)
 
 	t.Run("Complete Fee Rate Adjustment Flow", func(t *testing.T) {
+		client := NewAPIClient(t)
+
 		// Step 1: Create a high-volume merchant first
 		merchantData := map[string]interface{}{
 			"name":          "High Volume VPN Service",
@@ -30,7 +32,7 @@ func TestAdjustPlatformFeeRate(t *testing.T) {
 		reqBody, err := json.Marshal(merchantData)
 		require.NoError(t, err)
 
-		resp, err := http.Post(
+		resp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/merchants", baseURL),
 			"application/json",
 			bytes.NewBuffer(reqBody),
@@ -74,7 +76,7 @@ func TestAdjustPlatformFeeRate(t *testing.T) {
 		require.NoError(t, err)
 		updateReq.Header.Set("Content-Type", "application/json")
 
-		updateResp, err := http.DefaultClient.Do(updateReq)
+		updateResp, err := client.Do(updateReq)
 		require.NoError(t, err)
 		defer updateResp.Body.Close()
 
@@ -95,7 +97,7 @@ func TestAdjustPlatformFeeRate(t *testing.T) {
 
 		// Step 5: New rate applies to future settlements
 		// Verify the merchant can be retrieved with updated settings
-		getResp, err := http.Get(
+		getResp, err := client.Get(
 			fmt.Sprintf("%s/api/v1/merchants/%s", baseURL, merchantID),
 		)
 		require.NoError(t, err)
@@ -116,6 +118,8 @@ func TestAdjustPlatformFeeRate(t *testing.T) {
 	})
 
 	t.Run("Fee Rate Validation", func(t *testing.T) {
+		client := NewAPIClient(t)
+
 		// Create a merchant for testing
 		merchantData := map[string]interface{}{
 			"name":     "Test Merchant",
@@ -128,7 +132,7 @@ func TestAdjustPlatformFeeRate(t *testing.T) {
 		reqBody, err := json.Marshal(merchantData)
 		require.NoError(t, err)
 
-		resp, err := http.Post(
+		resp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/merchants", baseURL),
 			"application/json",
 			bytes.NewBuffer(reqBody),
@@ -160,7 +164,7 @@ func TestAdjustPlatformFeeRate(t *testing.T) {
 		require.NoError(t, err)
 		invalidReq.Header.Set("Content-Type", "application/json")
 
-		invalidResp, err := http.DefaultClient.Do(invalidReq)
+		invalidResp, err := client.Do(invalidReq)
 		require.NoError(t, err)
 		defer invalidResp.Body.Close()
 
@@ -183,7 +187,7 @@ func TestAdjustPlatformFeeRate(t *testing.T) {
 		require.NoError(t, err)
 		excessiveReq.Header.Set("Content-Type", "application/json")
 
-		excessiveResp, err := http.DefaultClient.Do(excessiveReq)
+		excessiveResp, err := client.Do(excessiveReq)
 		require.NoError(t, err)
 		defer excessiveResp.Body.Close()
 
@@ -192,6 +196,8 @@ func TestAdjustPlatformFeeRate(t *testing.T) {
 	})
 
 	t.Run("Fee Rate Audit Trail", func(t *testing.T) {
+		client := NewAPIClient(t)
+
 		// Create a merchant for testing
 		merchantData := map[string]interface{}{
 			"name":     "Audit Test Merchant",
@@ -204,7 +210,7 @@ func TestAdjustPlatformFeeRate(t *testing.T) {
 		reqBody, err := json.Marshal(merchantData)
 		require.NoError(t, err)
 
-		resp, err := http.Post(
+		resp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/merchants", baseURL),
 			"application/json",
 			bytes.NewBuffer(reqBody),
@@ -240,7 +246,7 @@ func TestAdjustPlatformFeeRate(t *testing.T) {
 			require.NoError(t, err)
 			updateReq.Header.Set("Content-Type", "application/json")
 
-			updateResp, err := http.DefaultClient.Do(updateReq)
+			updateResp, err := client.Do(updateReq)
 			require.NoError(t, err)
 			updateResp.Body.Close()
 
@@ -248,7 +254,7 @@ func TestAdjustPlatformFeeRate(t *testing.T) {
 		}
 
 		// Verify fee rate history can be retrieved
-		historyResp, err := http.Get(
+		historyResp, err := client.Get(
 			fmt.Sprintf("%s/api/v1/merchants/%s/fee-history", baseURL, merchantID),
 		)
 		require.NoError(t, err)
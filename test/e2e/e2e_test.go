@@ -2,13 +2,29 @@ package e2e_test
 
 import (
 	"context"
+	"crypto-checkout/internal/domain/audit"
+	"crypto-checkout/internal/domain/compliance"
+	"crypto-checkout/internal/domain/coupon"
+	"crypto-checkout/internal/domain/customer"
+	"crypto-checkout/internal/domain/deadletter"
 	"crypto-checkout/internal/domain/invoice"
 	"crypto-checkout/internal/domain/merchant"
 	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/paymentlink"
+	"crypto-checkout/internal/domain/review"
+	"crypto-checkout/internal/domain/tax"
+	"crypto-checkout/internal/infrastructure/addressproof"
 	"crypto-checkout/internal/infrastructure/database"
+	"crypto-checkout/internal/infrastructure/errorreporting"
 	"crypto-checkout/internal/infrastructure/events"
+	"crypto-checkout/internal/infrastructure/health"
+	"crypto-checkout/internal/infrastructure/mailer"
+	"crypto-checkout/internal/infrastructure/resilience"
+	"crypto-checkout/internal/infrastructure/screening"
+	"crypto-checkout/internal/infrastructure/supervisor"
 	"crypto-checkout/internal/presentation/web"
 	"crypto-checkout/pkg/config"
+	"crypto-checkout/test/contract"
 	"fmt"
 	"net"
 	"net/http"
@@ -141,15 +157,33 @@ func StartTestApp(t *testing.T) string {
 				TopicNotifications: "crypto-checkout.notifications",
 				TopicAnalytics:     "crypto-checkout.analytics",
 			},
+			Auth: config.AuthConfig{
+				JWTSecret: "e2e-session-secret",
+			},
 		}),
 		// Supply test logger
 		fx.Supply(logger),
 		// Provide all dependencies
+		addressproof.Module,
+		audit.Module,
+		compliance.Module,
+		coupon.Module,
+		customer.Module,
 		database.Module,
+		deadletter.Module,
+		errorreporting.Module,
 		events.Module, // Use real events module for e2e tests
+		health.Module,
 		invoice.Module,
+		mailer.Module,
 		payment.Module,
+		resilience.Module,
+		paymentlink.Module,
 		merchant.Module,
+		review.Module,
+		screening.Module,
+		supervisor.Module,
+		tax.Module,
 		web.Module,
 		// Set Gin to test mode
 		fx.Invoke(func() {
@@ -177,3 +211,15 @@ func StartTestApp(t *testing.T) string {
 
 	return "http://" + addr
 }
+
+// NewAPIClient returns an HTTP client that fails the test when a response does not match the OpenAPI document,
+// so tests only need to assert on the values they care about.
+func NewAPIClient(t *testing.T) *http.Client {
+	t.Helper()
+
+	validator, err := contract.NewValidator()
+	if err != nil {
+		t.Fatalf("failed to load OpenAPI document: %v", err)
+	}
+	return contract.Client(t, validator)
+}
@@ -2,7 +2,6 @@ package e2e_test
 
 import (
 	"encoding/json"
-	"io"
 	"net/http"
 	"testing"
 	"time"
@@ -12,22 +11,43 @@ import (
 
 func TestHealthCheckE2E(t *testing.T) {
 	baseURL := StartTestApp(t)
+	client := NewAPIClient(t)
 
 	// Wait a moment for server to be ready
 	time.Sleep(100 * time.Millisecond)
 
-	resp, err := http.Get(baseURL + "/health")
+	resp, err := client.Get(baseURL + "/health")
 	require.NoError(t, err)
 	defer resp.Body.Close()
 
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 
-	body, err := io.ReadAll(resp.Body)
+	var response map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	require.Equal(t, "healthy", response["status"])
+}
+
+func TestReadinessE2E(t *testing.T) {
+	baseURL := StartTestApp(t)
+
+	resp, err := NewAPIClient(t).Get(baseURL + "/health/ready")
 	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
 
 	var response map[string]interface{}
-	err = json.Unmarshal(body, &response)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	require.Equal(t, "up", response["status"])
+	require.NotEmpty(t, response["checks"])
+}
+
+func TestLivenessE2E(t *testing.T) {
+	baseURL := StartTestApp(t)
+
+	resp, err := NewAPIClient(t).Get(baseURL + "/health/live")
 	require.NoError(t, err)
+	defer resp.Body.Close()
 
-	require.Equal(t, "healthy", response["status"])
+	require.Equal(t, http.StatusOK, resp.StatusCode)
 }
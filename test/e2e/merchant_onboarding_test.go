@@ -17,6 +17,8 @@ func TestMerchantOnboarding(t *testing.T) {
 	baseURL := StartTestApp(t)
 
 	t.Run("Complete Merchant Onboarding Flow", func(t *testing.T) {
+		client := NewAPIClient(t)
+
 		// Step 1: Merchant visits platform and clicks "Sign Up"
 		// Step 2: Fills registration form with business details
 		merchantData := map[string]interface{}{
@@ -43,7 +45,7 @@ func TestMerchantOnboarding(t *testing.T) {
 		reqBody, err := json.Marshal(merchantData)
 		require.NoError(t, err)
 
-		resp, err := http.Post(
+		resp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/merchants", baseURL),
 			"application/json",
 			bytes.NewBuffer(reqBody),
@@ -89,7 +91,8 @@ func TestMerchantOnboarding(t *testing.T) {
 
 		// Verify merchant can be retrieved using the correct API endpoint
 		// According to API.md, this should be GET /api/v1/merchants/me with authentication
-		// For now, we'll test the direct ID endpoint which may not exist yet
+		// For now, we'll test the direct ID endpoint which may not exist yet. It is not in the OpenAPI
+		// document either, so it is probed without the contract client
 		getResp, err := http.Get(
 			fmt.Sprintf("%s/api/v1/merchants/%s", baseURL, merchantID),
 		)
@@ -107,6 +110,8 @@ func TestMerchantOnboarding(t *testing.T) {
 	})
 
 	t.Run("Merchant Onboarding Validation", func(t *testing.T) {
+		client := NewAPIClient(t)
+
 		// Test validation of required fields
 		invalidMerchantData := map[string]interface{}{
 			"business_name": "",              // Empty business name should fail
@@ -121,7 +126,7 @@ func TestMerchantOnboarding(t *testing.T) {
 		reqBody, err := json.Marshal(invalidMerchantData)
 		require.NoError(t, err)
 
-		resp, err := http.Post(
+		resp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/merchants", baseURL),
 			"application/json",
 			bytes.NewBuffer(reqBody),
@@ -140,6 +145,8 @@ func TestMerchantOnboarding(t *testing.T) {
 	})
 
 	t.Run("Duplicate Email Prevention", func(t *testing.T) {
+		client := NewAPIClient(t)
+
 		// Create first merchant
 		merchantData := map[string]interface{}{
 			"business_name": "First Merchant",
@@ -154,7 +161,7 @@ func TestMerchantOnboarding(t *testing.T) {
 		reqBody, err := json.Marshal(merchantData)
 		require.NoError(t, err)
 
-		resp1, err := http.Post(
+		resp1, err := client.Post(
 			fmt.Sprintf("%s/api/v1/merchants", baseURL),
 			"application/json",
 			bytes.NewBuffer(reqBody),
@@ -178,7 +185,7 @@ func TestMerchantOnboarding(t *testing.T) {
 		reqBody2, err := json.Marshal(merchantData2)
 		require.NoError(t, err)
 
-		resp2, err := http.Post(
+		resp2, err := client.Post(
 			fmt.Sprintf("%s/api/v1/merchants", baseURL),
 			"application/json",
 			bytes.NewBuffer(reqBody2),
@@ -17,6 +17,8 @@ func TestPartialPaymentHandling(t *testing.T) {
 	baseURL := StartTestApp(t)
 
 	t.Run("Complete Partial Payment Flow", func(t *testing.T) {
+		client := NewAPIClient(t)
+
 		// Step 1: Create a merchant
 		merchantData := map[string]interface{}{
 			"name":          "Partial Payment Test Merchant",
@@ -31,7 +33,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		reqBody, err := json.Marshal(merchantData)
 		require.NoError(t, err)
 
-		resp, err := http.Post(
+		resp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/merchants", baseURL),
 			"application/json",
 			bytes.NewBuffer(reqBody),
@@ -62,7 +64,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		invoiceReqBody, err := json.Marshal(invoiceData)
 		require.NoError(t, err)
 
-		invoiceResp, err := http.Post(
+		invoiceResp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/invoices", baseURL),
 			"application/json",
 			bytes.NewBuffer(invoiceReqBody),
@@ -98,7 +100,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		firstPaymentReqBody, err := json.Marshal(firstPaymentData)
 		require.NoError(t, err)
 
-		firstPaymentResp, err := http.Post(
+		firstPaymentResp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/payments", baseURL),
 			"application/json",
 			bytes.NewBuffer(firstPaymentReqBody),
@@ -118,7 +120,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		// Step 4: Invoice status updated to "partial"
 		time.Sleep(100 * time.Millisecond) // Allow for async processing
 
-		invoiceStatusResp, err := http.Get(
+		invoiceStatusResp, err := client.Get(
 			fmt.Sprintf("%s/api/v1/invoices/%s", baseURL, invoiceID),
 		)
 		require.NoError(t, err)
@@ -144,7 +146,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 
 		// Step 5: Customer sees progress (50% paid)
 		// Check payment progress endpoint
-		progressResp, err := http.Get(
+		progressResp, err := client.Get(
 			fmt.Sprintf("%s/invoice/%s/status", baseURL, invoiceID),
 		)
 		require.NoError(t, err)
@@ -177,7 +179,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		secondPaymentReqBody, err := json.Marshal(secondPaymentData)
 		require.NoError(t, err)
 
-		secondPaymentResp, err := http.Post(
+		secondPaymentResp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/payments", baseURL),
 			"application/json",
 			bytes.NewBuffer(secondPaymentReqBody),
@@ -197,7 +199,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		// Step 7: Total payment confirmed ($9.99 total)
 		time.Sleep(100 * time.Millisecond) // Allow for async processing
 
-		finalInvoiceStatusResp, err := http.Get(
+		finalInvoiceStatusResp, err := client.Get(
 			fmt.Sprintf("%s/api/v1/invoices/%s", baseURL, invoiceID),
 		)
 		require.NoError(t, err)
@@ -217,7 +219,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		assert.Equal(t, 9.99, finalPaidAmount)
 
 		// Step 8: Settlement processed for full amount
-		settlementsResp, err := http.Get(
+		settlementsResp, err := client.Get(
 			fmt.Sprintf("%s/api/v1/merchants/%s/settlements", baseURL, merchantID),
 		)
 		require.NoError(t, err)
@@ -254,6 +256,8 @@ func TestPartialPaymentHandling(t *testing.T) {
 	})
 
 	t.Run("Partial Payment with Overpayment", func(t *testing.T) {
+		client := NewAPIClient(t)
+
 		// Create a merchant
 		merchantData := map[string]interface{}{
 			"name":     "Overpayment Test Merchant",
@@ -266,7 +270,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		reqBody, err := json.Marshal(merchantData)
 		require.NoError(t, err)
 
-		resp, err := http.Post(
+		resp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/merchants", baseURL),
 			"application/json",
 			bytes.NewBuffer(reqBody),
@@ -296,7 +300,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		invoiceReqBody, err := json.Marshal(invoiceData)
 		require.NoError(t, err)
 
-		invoiceResp, err := http.Post(
+		invoiceResp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/invoices", baseURL),
 			"application/json",
 			bytes.NewBuffer(invoiceReqBody),
@@ -327,7 +331,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		firstPaymentReqBody, err := json.Marshal(firstPaymentData)
 		require.NoError(t, err)
 
-		firstPaymentResp, err := http.Post(
+		firstPaymentResp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/payments", baseURL),
 			"application/json",
 			bytes.NewBuffer(firstPaymentReqBody),
@@ -352,7 +356,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		secondPaymentReqBody, err := json.Marshal(secondPaymentData)
 		require.NoError(t, err)
 
-		secondPaymentResp, err := http.Post(
+		secondPaymentResp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/payments", baseURL),
 			"application/json",
 			bytes.NewBuffer(secondPaymentReqBody),
@@ -365,7 +369,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		// Check invoice status
 		time.Sleep(100 * time.Millisecond) // Allow for async processing
 
-		invoiceStatusResp, err := http.Get(
+		invoiceStatusResp, err := client.Get(
 			fmt.Sprintf("%s/api/v1/invoices/%s", baseURL, invoiceID),
 		)
 		require.NoError(t, err)
@@ -387,7 +391,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		assert.Equal(t, 1.01, overpaymentAmount) // 11.0 - 9.99
 
 		// Check settlement
-		settlementsResp, err := http.Get(
+		settlementsResp, err := client.Get(
 			fmt.Sprintf("%s/api/v1/merchants/%s/settlements", baseURL, "mock-merchant-id"),
 		)
 		require.NoError(t, err)
@@ -420,6 +424,8 @@ func TestPartialPaymentHandling(t *testing.T) {
 	})
 
 	t.Run("Partial Payment Timeline", func(t *testing.T) {
+		client := NewAPIClient(t)
+
 		// Test the timeline described in the scenario
 		startTime := time.Now()
 
@@ -435,7 +441,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		reqBody, err := json.Marshal(merchantData)
 		require.NoError(t, err)
 
-		resp, err := http.Post(
+		resp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/merchants", baseURL),
 			"application/json",
 			bytes.NewBuffer(reqBody),
@@ -465,7 +471,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		invoiceReqBody, err := json.Marshal(invoiceData)
 		require.NoError(t, err)
 
-		invoiceResp, err := http.Post(
+		invoiceResp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/invoices", baseURL),
 			"application/json",
 			bytes.NewBuffer(invoiceReqBody),
@@ -497,7 +503,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		firstPaymentReqBody, err := json.Marshal(firstPaymentData)
 		require.NoError(t, err)
 
-		firstPaymentResp, err := http.Post(
+		firstPaymentResp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/payments", baseURL),
 			"application/json",
 			bytes.NewBuffer(firstPaymentReqBody),
@@ -523,7 +529,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		secondPaymentReqBody, err := json.Marshal(secondPaymentData)
 		require.NoError(t, err)
 
-		secondPaymentResp, err := http.Post(
+		secondPaymentResp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/payments", baseURL),
 			"application/json",
 			bytes.NewBuffer(secondPaymentReqBody),
@@ -537,7 +543,7 @@ func TestPartialPaymentHandling(t *testing.T) {
 		time.Sleep(100 * time.Millisecond) // Allow for async processing
 
 		// Check payment timeline
-		timelineResp, err := http.Get(
+		timelineResp, err := client.Get(
 			fmt.Sprintf("%s/api/v1/invoices/%s/timeline", baseURL, invoiceID),
 		)
 		require.NoError(t, err)
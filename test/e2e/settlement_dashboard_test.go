@@ -16,6 +16,8 @@ func TestViewSettlementDashboard(t *testing.T) {
 	baseURL := StartTestApp(t)
 
 	t.Run("Complete Settlement Dashboard Flow", func(t *testing.T) {
+		client := NewAPIClient(t)
+
 		// Step 1: Create a merchant with some transaction history
 		merchantData := map[string]interface{}{
 			"name":          "Dashboard Test Merchant",
@@ -30,7 +32,7 @@ func TestViewSettlementDashboard(t *testing.T) {
 		reqBody, err := json.Marshal(merchantData)
 		require.NoError(t, err)
 
-		resp, err := http.Post(
+		resp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/merchants", baseURL),
 			"application/json",
 			bytes.NewBuffer(reqBody),
@@ -83,7 +85,7 @@ func TestViewSettlementDashboard(t *testing.T) {
 			invoiceReqBody, err := json.Marshal(invoiceData)
 			require.NoError(t, err)
 
-			invoiceResp, err := http.Post(
+			invoiceResp, err := client.Post(
 				fmt.Sprintf("%s/api/v1/invoices", baseURL),
 				"application/json",
 				bytes.NewBuffer(invoiceReqBody),
@@ -101,7 +103,7 @@ func TestViewSettlementDashboard(t *testing.T) {
 		// For testing, we'll create mock settlement data
 
 		// Step 4: Merchant logs into dashboard and views settlements page
-		settlementsResp, err := http.Get(
+		settlementsResp, err := client.Get(
 			fmt.Sprintf("%s/api/v1/merchants/%s/settlements", baseURL, merchantID),
 		)
 		require.NoError(t, err)
@@ -154,7 +156,7 @@ func TestViewSettlementDashboard(t *testing.T) {
 		}
 
 		// Step 7: Test settlement report download
-		reportResp, err := http.Get(
+		reportResp, err := client.Get(
 			fmt.Sprintf(
 				"%s/api/v1/merchants/%s/settlements/report?format=csv&start_date=2024-01-01&end_date=2024-12-31",
 				baseURL,
@@ -170,7 +172,7 @@ func TestViewSettlementDashboard(t *testing.T) {
 		assert.Contains(t, reportResp.Header.Get("Content-Disposition"), "attachment")
 
 		// Step 8: Test settlement analytics
-		analyticsResp, err := http.Get(
+		analyticsResp, err := client.Get(
 			fmt.Sprintf("%s/api/v1/merchants/%s/settlements/analytics", baseURL, merchantID),
 		)
 		require.NoError(t, err)
@@ -190,6 +192,8 @@ func TestViewSettlementDashboard(t *testing.T) {
 	})
 
 	t.Run("Settlement Dashboard with Date Filters", func(t *testing.T) {
+		client := NewAPIClient(t)
+
 		// Create a merchant for testing
 		merchantData := map[string]interface{}{
 			"name":     "Date Filter Test Merchant",
@@ -202,7 +206,7 @@ func TestViewSettlementDashboard(t *testing.T) {
 		reqBody, err := json.Marshal(merchantData)
 		require.NoError(t, err)
 
-		resp, err := http.Post(
+		resp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/merchants", baseURL),
 			"application/json",
 			bytes.NewBuffer(reqBody),
@@ -222,7 +226,7 @@ func TestViewSettlementDashboard(t *testing.T) {
 		startDate := "2024-01-01"
 		endDate := "2024-01-31"
 
-		settlementsResp, err := http.Get(
+		settlementsResp, err := client.Get(
 			fmt.Sprintf(
 				"%s/api/v1/merchants/%s/settlements?start_date=%s&end_date=%s",
 				baseURL,
@@ -251,6 +255,8 @@ func TestViewSettlementDashboard(t *testing.T) {
 	})
 
 	t.Run("Settlement Dashboard with Status Filters", func(t *testing.T) {
+		client := NewAPIClient(t)
+
 		// Create a merchant for testing
 		merchantData := map[string]interface{}{
 			"name":     "Status Filter Test Merchant",
@@ -263,7 +269,7 @@ func TestViewSettlementDashboard(t *testing.T) {
 		reqBody, err := json.Marshal(merchantData)
 		require.NoError(t, err)
 
-		resp, err := http.Post(
+		resp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/merchants", baseURL),
 			"application/json",
 			bytes.NewBuffer(reqBody),
@@ -282,7 +288,7 @@ func TestViewSettlementDashboard(t *testing.T) {
 		// Test settlements with status filter
 		statusFilter := "completed"
 
-		settlementsResp, err := http.Get(
+		settlementsResp, err := client.Get(
 			fmt.Sprintf("%s/api/v1/merchants/%s/settlements?status=%s", baseURL, merchantID, statusFilter),
 		)
 		require.NoError(t, err)
@@ -17,6 +17,8 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 	baseURL := StartTestApp(t)
 
 	t.Run("Complete Payment and Settlement Flow", func(t *testing.T) {
+		client := NewAPIClient(t)
+
 		// Step 1: Create a merchant
 		merchantData := map[string]interface{}{
 			"name":          "VPN Service Provider",
@@ -31,7 +33,7 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 		reqBody, err := json.Marshal(merchantData)
 		require.NoError(t, err)
 
-		resp, err := http.Post(
+		resp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/merchants", baseURL),
 			"application/json",
 			bytes.NewBuffer(reqBody),
@@ -62,7 +64,7 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 		invoiceReqBody, err := json.Marshal(invoiceData)
 		require.NoError(t, err)
 
-		invoiceResp, err := http.Post(
+		invoiceResp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/invoices", baseURL),
 			"application/json",
 			bytes.NewBuffer(invoiceReqBody),
@@ -86,7 +88,7 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 
 		// Step 3: Customer visits payment page and scans QR code
 		// Get payment details for the invoice
-		paymentDetailsResp, err := http.Get(
+		paymentDetailsResp, err := client.Get(
 			fmt.Sprintf("%s/invoice/%s", baseURL, invoiceID),
 		)
 		require.NoError(t, err)
@@ -127,7 +129,7 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 		require.NoError(t, err)
 
 		// Simulate payment detection via webhook or blockchain monitoring
-		paymentResp, err := http.Post(
+		paymentResp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/payments", baseURL),
 			"application/json",
 			bytes.NewBuffer(paymentReqBody),
@@ -149,7 +151,7 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 		time.Sleep(100 * time.Millisecond) // Allow for async processing
 
 		// Check payment status
-		paymentStatusResp, err := http.Get(
+		paymentStatusResp, err := client.Get(
 			fmt.Sprintf("%s/api/v1/payments/%s", baseURL, paymentID),
 		)
 		require.NoError(t, err)
@@ -163,7 +165,7 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 
 		// Step 6: Settlement created automatically
 		// Check that settlement was created
-		settlementsResp, err := http.Get(
+		settlementsResp, err := client.Get(
 			fmt.Sprintf("%s/api/v1/merchants/%s/settlements", baseURL, merchantID),
 		)
 		require.NoError(t, err)
@@ -196,7 +198,7 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 
 		// Step 9: Customer redirected to success page
 		// Check invoice status is updated to paid
-		invoiceStatusResp, err := http.Get(
+		invoiceStatusResp, err := client.Get(
 			fmt.Sprintf("%s/api/v1/invoices/%s", baseURL, invoiceID),
 		)
 		require.NoError(t, err)
@@ -221,6 +223,8 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 	})
 
 	t.Run("Payment with Custom Fee Rate", func(t *testing.T) {
+		client := NewAPIClient(t)
+
 		// Create a merchant with custom fee rate
 		merchantData := map[string]interface{}{
 			"name":          "Custom Fee Merchant",
@@ -235,7 +239,7 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 		reqBody, err := json.Marshal(merchantData)
 		require.NoError(t, err)
 
-		resp, err := http.Post(
+		resp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/merchants", baseURL),
 			"application/json",
 			bytes.NewBuffer(reqBody),
@@ -267,7 +271,7 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 		require.NoError(t, err)
 		updateReq.Header.Set("Content-Type", "application/json")
 
-		updateResp, err := http.DefaultClient.Do(updateReq)
+		updateResp, err := client.Do(updateReq)
 		require.NoError(t, err)
 		defer updateResp.Body.Close()
 
@@ -287,7 +291,7 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 		invoiceReqBody, err := json.Marshal(invoiceData)
 		require.NoError(t, err)
 
-		invoiceResp, err := http.Post(
+		invoiceResp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/invoices", baseURL),
 			"application/json",
 			bytes.NewBuffer(invoiceReqBody),
@@ -318,7 +322,7 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 		paymentReqBody, err := json.Marshal(paymentData)
 		require.NoError(t, err)
 
-		paymentResp, err := http.Post(
+		paymentResp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/payments", baseURL),
 			"application/json",
 			bytes.NewBuffer(paymentReqBody),
@@ -331,7 +335,7 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 		// Check settlement with custom fee rate
 		time.Sleep(100 * time.Millisecond) // Allow for async processing
 
-		settlementsResp, err := http.Get(
+		settlementsResp, err := client.Get(
 			fmt.Sprintf("%s/api/v1/merchants/%s/settlements", baseURL, merchantID),
 		)
 		require.NoError(t, err)
@@ -359,6 +363,8 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 	})
 
 	t.Run("Payment Settlement Timing", func(t *testing.T) {
+		client := NewAPIClient(t)
+
 		// Test that settlement is processed within 30 seconds
 		startTime := time.Now()
 
@@ -374,7 +380,7 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 		reqBody, err := json.Marshal(merchantData)
 		require.NoError(t, err)
 
-		resp, err := http.Post(
+		resp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/merchants", baseURL),
 			"application/json",
 			bytes.NewBuffer(reqBody),
@@ -404,7 +410,7 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 		invoiceReqBody, err := json.Marshal(invoiceData)
 		require.NoError(t, err)
 
-		invoiceResp, err := http.Post(
+		invoiceResp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/invoices", baseURL),
 			"application/json",
 			bytes.NewBuffer(invoiceReqBody),
@@ -435,7 +441,7 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 		paymentReqBody, err := json.Marshal(paymentData)
 		require.NoError(t, err)
 
-		paymentResp, err := http.Post(
+		paymentResp, err := client.Post(
 			fmt.Sprintf("%s/api/v1/payments", baseURL),
 			"application/json",
 			bytes.NewBuffer(paymentReqBody),
@@ -451,7 +457,7 @@ func TestSuccessfulPaymentWithSettlement(t *testing.T) {
 		checkInterval := 100 * time.Millisecond
 
 		for time.Since(startTime) < maxWaitTime {
-			settlementsResp, err := http.Get(
+			settlementsResp, err := client.Get(
 				fmt.Sprintf("%s/api/v1/merchants/%s/settlements", baseURL, merchantID),
 			)
 			require.NoError(t, err)
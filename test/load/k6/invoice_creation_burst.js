@@ -0,0 +1,11 @@
+// A merchant creating invoices as fast as a checkout rush produces orders.
+//
+//   k6 run -e BASE_URL=https://checkout.example.com -e API_KEY=sk_live_... invoice_creation_burst.js
+import exec from 'k6/execution';
+import { createInvoice, options as scenarioOptions } from './lib.js';
+
+export const options = scenarioOptions('invoice_creation_burst', 50);
+
+export default function () {
+  createInvoice(exec.scenario.iterationInTest);
+}
@@ -0,0 +1,74 @@
+// Shared setup for the k6 scenarios. Every scenario reads its thresholds from ../budgets.json, the budget
+// the in-process Go scenarios are checked against too.
+import http from 'k6/http';
+import { check } from 'k6';
+
+export const BASE_URL = __ENV.BASE_URL || 'http://localhost:8080';
+export const API_KEY = __ENV.API_KEY || '';
+
+const budgets = JSON.parse(open('../budgets.json')).scenarios;
+
+// milliseconds converts a Go duration such as "250ms" or "1.5s" to milliseconds.
+function milliseconds(duration) {
+  const match = /^([0-9.]+)(us|µs|ms|s)$/.exec(duration);
+  if (!match) {
+    throw new Error(`unsupported duration ${duration}`);
+  }
+  const scale = { us: 0.001, 'µs': 0.001, ms: 1, s: 1000 }[match[2]];
+  return parseFloat(match[1]) * scale;
+}
+
+// options runs the scenario open-loop at a constant arrival rate, like the Go harness, and fails the run when
+// its budget is exceeded.
+export function options(name, rate) {
+  const budget = budgets[name];
+  if (!budget) {
+    throw new Error(`scenario ${name} has no budget`);
+  }
+  return {
+    scenarios: {
+      [name]: {
+        executor: 'constant-arrival-rate',
+        rate: parseInt(__ENV.RATE || rate, 10),
+        timeUnit: '1s',
+        duration: __ENV.DURATION || '30s',
+        preAllocatedVUs: 50,
+        maxVUs: 500,
+      },
+    },
+    thresholds: {
+      http_req_duration: [`p(95)<${milliseconds(budget.p95)}`, `p(99)<${milliseconds(budget.p99)}`],
+      http_req_failed: [`rate<=${budget.max_error_rate}`],
+    },
+  };
+}
+
+// createInvoice creates an invoice with the API key.
+export function createInvoice(i) {
+  const body = JSON.stringify({
+    title: `Load order ${i}`,
+    items: [{ name: 'Item', quantity: '1', unit_price: `${10 + (i % 90)}.00` }],
+    tax_rate: '0.00',
+  });
+  const res = http.post(`${BASE_URL}/api/v1/invoices`, body, {
+    headers: { 'Content-Type': 'application/json', Authorization: `Bearer ${API_KEY}` },
+    tags: { name: 'POST /api/v1/invoices' },
+  });
+  check(res, { 'invoice created': (r) => r.status === 201 });
+  return res;
+}
+
+// seedInvoices creates invoices and opens their payment pages, so they accept payments.
+export function seedInvoices(n) {
+  const invoices = [];
+  for (let i = 0; i < n; i++) {
+    const res = createInvoice(i);
+    if (res.status !== 201) {
+      throw new Error(`failed to seed invoice: ${res.status} ${res.body}`);
+    }
+    const invoice = res.json();
+    http.get(`${BASE_URL}/invoice/${invoice.id}`, { tags: { name: 'seed' } });
+    invoices.push({ id: invoice.id, address: invoice.address, usdtAmount: invoice.usdt_amount });
+  }
+  return invoices;
+}
@@ -0,0 +1,46 @@
+// A chain watcher reporting payments to many invoice addresses at once. Each invoice gets one unconfirmed
+// payment of its full amount; once every invoice has had one, the notifications repeat as duplicates.
+//
+//   k6 run -e BASE_URL=https://checkout.example.com -e API_KEY=sk_live_... \
+//     -e NOTIFICATION_SOURCE=watcher -e NOTIFICATION_SECRET=... payment_detection_fan_out.js
+//
+// The source must be configured under blockchain.notification_sources for the merchant of the API key.
+import http from 'k6/http';
+import crypto from 'k6/crypto';
+import { check } from 'k6';
+import exec from 'k6/execution';
+import { BASE_URL, options as scenarioOptions, seedInvoices } from './lib.js';
+
+export const options = scenarioOptions('payment_detection_fan_out', 20);
+
+const SOURCE = __ENV.NOTIFICATION_SOURCE || '';
+const SECRET = __ENV.NOTIFICATION_SECRET || '';
+
+export function setup() {
+  return { invoices: seedInvoices(parseInt(__ENV.INVOICES || '200', 10)) };
+}
+
+export default function (data) {
+  const n = exec.scenario.iterationInTest % data.invoices.length;
+  const invoice = data.invoices[n];
+  const body = JSON.stringify({
+    network: 'tron',
+    transaction_hash: `0x${(n + 1).toString(16).padStart(64, '0')}`,
+    from_address: 'TSenderAddress123456789012345678901234567890',
+    to_address: invoice.address,
+    amount: invoice.usdtAmount,
+    currency: 'USDT',
+  });
+  const timestamp = `${Math.floor(Date.now() / 1000)}`;
+  const res = http.post(`${BASE_URL}/api/v1/blockchain/notifications`, body, {
+    headers: {
+      'Content-Type': 'application/json',
+      'X-Watchtower-Source': SOURCE,
+      'X-Watchtower-Timestamp': timestamp,
+      'X-Watchtower-Signature': crypto.hmac('sha256', SECRET, `${timestamp}.${body}`, 'hex'),
+    },
+    responseCallback: http.expectedStatuses(200, 202),
+    tags: { name: 'POST /api/v1/blockchain/notifications' },
+  });
+  check(res, { 'notification accepted': (r) => r.status === 200 || r.status === 202 });
+}
@@ -0,0 +1,21 @@
+// Every open checkout page polling the status of its invoice.
+//
+//   k6 run -e BASE_URL=https://checkout.example.com -e API_KEY=sk_live_... status_polling_storm.js
+import http from 'k6/http';
+import { check } from 'k6';
+import exec from 'k6/execution';
+import { BASE_URL, options as scenarioOptions, seedInvoices } from './lib.js';
+
+export const options = scenarioOptions('status_polling_storm', 200);
+
+export function setup() {
+  return { invoices: seedInvoices(parseInt(__ENV.INVOICES || '50', 10)) };
+}
+
+export default function (data) {
+  const invoice = data.invoices[exec.scenario.iterationInTest % data.invoices.length];
+  const res = http.get(`${BASE_URL}/api/v1/public/invoice/${invoice.id}/status`, {
+    tags: { name: 'GET /api/v1/public/invoice/:id/status' },
+  });
+  check(res, { 'status returned': (r) => r.status === 200 });
+}
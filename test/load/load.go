@@ -0,0 +1,271 @@
+// Package load drives the API at a steady request rate and checks the latencies and errors against a
+// performance budget.
+//
+// Attack sends the requests of a Scenario at its rate, open-loop like vegeta, so a slow server builds up
+// requests in flight instead of quietly lowering the load. The same scenarios are scripted for k6 in the k6
+// directory to run against a deployed instance; both read their thresholds from budgets.json.
+package load
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExceeded is returned when a scenario is slower or fails more often than its budget allows.
+var ErrBudgetExceeded = errors.New("load: performance budget exceeded")
+
+// Scenario is a stream of requests sent at a fixed rate for a duration.
+type Scenario struct {
+	// Name identifies the scenario in budgets.json.
+	Name string
+	// Rate is the number of requests sent per second.
+	Rate int
+	// Duration is how long requests are sent for.
+	Duration time.Duration
+	// MaxInFlight bounds the requests waiting for a response; requests due while it is reached are counted as
+	// failures. Zero allows Rate requests in flight.
+	MaxInFlight int
+	// Request builds the i-th request of the scenario.
+	Request func(i int) (*http.Request, error)
+	// Expect reports whether a response status counts as a success. Nil accepts any 2xx status.
+	Expect func(status int) bool
+}
+
+// Result holds the outcome of an attack.
+type Result struct {
+	Scenario  string
+	Requests  int
+	Failures  int
+	Latencies []time.Duration
+	Elapsed   time.Duration
+}
+
+// Attack sends the requests of the scenario with the client and waits for every response.
+func Attack(ctx context.Context, client *http.Client, scenario Scenario) (*Result, error) {
+	if scenario.Rate <= 0 || scenario.Duration <= 0 || scenario.Request == nil {
+		return nil, fmt.Errorf("load: scenario %s needs a rate, a duration and a request", scenario.Name)
+	}
+	expect := scenario.Expect
+	if expect == nil {
+		expect = func(status int) bool { return status >= 200 && status < 300 }
+	}
+	maxInFlight := scenario.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = scenario.Rate
+	}
+
+	total := int(scenario.Duration.Seconds() * float64(scenario.Rate))
+	interval := time.Second / time.Duration(scenario.Rate)
+	slots := make(chan struct{}, maxInFlight)
+	result := &Result{Scenario: scenario.Name, Latencies: make([]time.Duration, 0, total)}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	record := func(latency time.Duration, ok bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		result.Requests++
+		if !ok {
+			result.Failures++
+			return
+		}
+		result.Latencies = append(result.Latencies, latency)
+	}
+
+	started := time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for i := range total {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				wg.Wait()
+				return nil, ctx.Err()
+			case <-ticker.C:
+			}
+		}
+
+		req, err := scenario.Request(i)
+		if err != nil {
+			wg.Wait()
+			return nil, fmt.Errorf("load: failed to build request %d of %s: %w", i, scenario.Name, err)
+		}
+
+		select {
+		case slots <- struct{}{}:
+		default:
+			record(0, false)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			sent := time.Now()
+			resp, err := client.Do(req.WithContext(ctx))
+			if err != nil {
+				record(0, false)
+				return
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+			record(time.Since(sent), expect(resp.StatusCode))
+		}()
+	}
+	wg.Wait()
+	result.Elapsed = time.Since(started)
+
+	slices.Sort(result.Latencies)
+	return result, nil
+}
+
+// Percentile returns the latency under which the fraction p of the successful requests completed.
+func (r *Result) Percentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	index := int(math.Ceil(p*float64(len(r.Latencies)))) - 1
+	return r.Latencies[max(0, min(index, len(r.Latencies)-1))]
+}
+
+// ErrorRate returns the fraction of requests that failed.
+func (r *Result) ErrorRate() float64 {
+	if r.Requests == 0 {
+		return 0
+	}
+	return float64(r.Failures) / float64(r.Requests)
+}
+
+// String summarizes the result on one line.
+func (r *Result) String() string {
+	return fmt.Sprintf("%s: %d requests in %s, p50 %s, p95 %s, p99 %s, %.2f%% failed",
+		r.Scenario, r.Requests, r.Elapsed.Round(time.Millisecond), r.Percentile(0.50), r.Percentile(0.95),
+		r.Percentile(0.99), r.ErrorRate()*100)
+}
+
+// Budget bounds the latencies and the error rate of a scenario.
+type Budget struct {
+	P95          Duration `json:"p95"`
+	P99          Duration `json:"p99"`
+	MaxErrorRate float64  `json:"max_error_rate"`
+}
+
+// Check returns ErrBudgetExceeded, listing every bound the result breaks.
+func (b Budget) Check(r *Result) error {
+	var exceeded []error
+	if b.P95 > 0 && r.Percentile(0.95) > time.Duration(b.P95) {
+		exceeded = append(exceeded, fmt.Errorf("p95 %s is over %s", r.Percentile(0.95), time.Duration(b.P95)))
+	}
+	if b.P99 > 0 && r.Percentile(0.99) > time.Duration(b.P99) {
+		exceeded = append(exceeded, fmt.Errorf("p99 %s is over %s", r.Percentile(0.99), time.Duration(b.P99)))
+	}
+	if r.ErrorRate() > b.MaxErrorRate {
+		exceeded = append(exceeded, fmt.Errorf("error rate %.4f is over %.4f", r.ErrorRate(), b.MaxErrorRate))
+	}
+	if len(exceeded) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s: %w", ErrBudgetExceeded, r.Scenario, errors.Join(exceeded...))
+}
+
+// Budgets holds the performance budget: the bounds of each load scenario and the time per operation allowed to
+// each Go benchmark of a hot path, both keyed by name.
+type Budgets struct {
+	Scenarios  map[string]Budget   `json:"scenarios"`
+	Benchmarks map[string]Duration `json:"benchmarks"`
+}
+
+// LoadBudgets reads the performance budget from a JSON file.
+func LoadBudgets(path string) (*Budgets, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var budgets Budgets
+	if err := json.Unmarshal(data, &budgets); err != nil {
+		return nil, fmt.Errorf("load: failed to parse %s: %w", path, err)
+	}
+	return &budgets, nil
+}
+
+// CheckBenchmarks reads the output of go test -bench and returns ErrBudgetExceeded, listing every budgeted
+// benchmark that took longer per operation than its budget. Benchmarks without a budget are ignored; a budgeted
+// benchmark missing from the output is an error, so a renamed benchmark cannot silently escape its budget.
+func (b *Budgets) CheckBenchmarks(output io.Reader) error {
+	seen := make(map[string]bool, len(b.Benchmarks))
+	var exceeded []error
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") || fields[3] != "ns/op" {
+			continue
+		}
+		name := fields[0]
+		if i := strings.LastIndex(name, "-"); i > 0 {
+			if _, err := strconv.Atoi(name[i+1:]); err == nil {
+				name = name[:i]
+			}
+		}
+		budget, ok := b.Benchmarks[name]
+		if !ok {
+			continue
+		}
+		nsPerOp, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return fmt.Errorf("load: failed to parse %s: %w", scanner.Text(), err)
+		}
+		seen[name] = true
+		if took := time.Duration(nsPerOp); took > time.Duration(budget) {
+			exceeded = append(exceeded, fmt.Errorf("%s took %s per op, over %s", name, took, time.Duration(budget)))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	for name := range b.Benchmarks {
+		if !seen[name] {
+			exceeded = append(exceeded, fmt.Errorf("%s did not run", name))
+		}
+	}
+	if len(exceeded) == 0 {
+		return nil
+	}
+	slices.SortFunc(exceeded, func(a, b error) int { return strings.Compare(a.Error(), b.Error()) })
+	return fmt.Errorf("%w: %w", ErrBudgetExceeded, errors.Join(exceeded...))
+}
+
+// Duration is a time.Duration written as a string such as "250ms" in JSON.
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON writes the duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
@@ -0,0 +1,236 @@
+package load_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/presentation/web"
+	"crypto-checkout/pkg/config"
+	"crypto-checkout/test/load"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var source = load.NotificationSource{Name: "load-watcher", Secret: "load-secret"}
+
+// newTestApp serves the invoice, public status and transaction notification routes, backed by a SQLite file
+// that concurrent requests share. It returns the services so scenarios can seed invoices.
+func newTestApp(t *testing.T) (http.Handler, *web.TestServices) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(web.RequestContextMiddleware(), web.ErrorHandler(&config.Config{}, zap.NewNop()))
+
+	url := "sqlite://" + filepath.Join(t.TempDir(), "load.db") + "?_busy_timeout=5000"
+	handler, services := web.CreateTestHandlerWithDatabase(url)
+	handler.RegisterRoutes(router)
+
+	cfg := config.NewConfig()
+	cfg.Blockchain.NotificationSources = []config.NotificationSourceConfig{
+		{Name: source.Name, Secret: source.Secret, MerchantID: "test-merchant"},
+	}
+	web.NewBlockchainNotificationHandlers(
+		detection.NewService(services.Invoices, services.Payments, nil, nil, nil, zap.NewNop()), cfg, zap.NewNop(),
+	).RegisterBlockchainNotificationRoutes(router.Group("/api/v1"))
+	return router, services
+}
+
+// seedInvoices creates viewed invoices for the polling and detection scenarios to target.
+func seedInvoices(t *testing.T, baseURL string, services *web.TestServices, n int) []load.Invoice {
+	t.Helper()
+	scenario := load.InvoiceCreationBurstScenario(baseURL, "sk_live_test123", 1, time.Second)
+	invoices := make([]load.Invoice, n)
+	for i := range invoices {
+		req, err := scenario.Request(i)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		var created web.CreateInvoiceResponse
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+		require.NoError(t, resp.Body.Close())
+		require.NoError(t, services.Invoices.MarkInvoiceAsViewed(context.Background(), created.ID))
+		invoices[i] = load.Invoice{ID: created.ID, Address: created.Address, USDTAmount: created.USDTAmount}
+	}
+	return invoices
+}
+
+// TestScenarios runs the load scenarios against the API served in process and checks them against the budget.
+// It only runs when LOAD_DURATION sets how long each scenario lasts; make perf-budget sets it.
+func TestScenarios(t *testing.T) {
+	value := os.Getenv("LOAD_DURATION")
+	if value == "" {
+		t.Skip("set LOAD_DURATION to run the load scenarios")
+	}
+	duration, err := time.ParseDuration(value)
+	require.NoError(t, err)
+
+	budgets, err := load.LoadBudgets("budgets.json")
+	require.NoError(t, err)
+
+	app, services := newTestApp(t)
+	server := httptest.NewServer(app)
+	defer server.Close()
+	invoices := seedInvoices(t, server.URL, services, 50)
+
+	for _, scenario := range []load.Scenario{
+		load.InvoiceCreationBurstScenario(server.URL, "sk_live_test123", 50, duration),
+		load.StatusPollingStormScenario(server.URL, invoices, 200, duration),
+		load.PaymentDetectionFanOutScenario(server.URL, source, invoices, 20, duration),
+	} {
+		t.Run(scenario.Name, func(t *testing.T) {
+			budget, ok := budgets.Scenarios[scenario.Name]
+			require.True(t, ok, "scenario %s has no budget", scenario.Name)
+
+			result, err := load.Attack(context.Background(), server.Client(), scenario)
+			require.NoError(t, err)
+			t.Log(result)
+			require.NoError(t, budget.Check(result))
+		})
+	}
+}
+
+// TestBenchmarkBudget checks the output of the hot path benchmarks in the file BENCH_OUTPUT names against the
+// budget. make perf-budget runs the benchmarks and sets it.
+func TestBenchmarkBudget(t *testing.T) {
+	path := os.Getenv("BENCH_OUTPUT")
+	if path == "" {
+		t.Skip("set BENCH_OUTPUT to check benchmark results")
+	}
+
+	budgets, err := load.LoadBudgets("budgets.json")
+	require.NoError(t, err)
+	output, err := os.Open(path)
+	require.NoError(t, err)
+	defer output.Close()
+
+	require.NoError(t, budgets.CheckBenchmarks(output))
+}
+
+func TestAttack(t *testing.T) {
+	t.Run("SendsRequestsAtRate", func(t *testing.T) {
+		var served atomic.Int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if served.Add(1)%10 == 0 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		result, err := load.Attack(context.Background(), server.Client(), load.Scenario{
+			Name:     "test",
+			Rate:     100,
+			Duration: 200 * time.Millisecond,
+			Request: func(int) (*http.Request, error) {
+				return http.NewRequest(http.MethodGet, server.URL, nil)
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 20, result.Requests)
+		require.Equal(t, 2, result.Failures)
+		require.Len(t, result.Latencies, 18)
+		require.InDelta(t, 0.1, result.ErrorRate(), 1e-9)
+		require.GreaterOrEqual(t, result.Elapsed, 190*time.Millisecond)
+	})
+
+	t.Run("CountsRequestsOverMaxInFlightAsFailures", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer server.Close()
+		time.AfterFunc(150*time.Millisecond, func() { close(release) })
+
+		result, err := load.Attack(context.Background(), server.Client(), load.Scenario{
+			Name:        "test",
+			Rate:        50,
+			Duration:    100 * time.Millisecond,
+			MaxInFlight: 2,
+			Request: func(int) (*http.Request, error) {
+				return http.NewRequest(http.MethodGet, server.URL, nil)
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 5, result.Requests)
+		require.Equal(t, 3, result.Failures)
+	})
+
+	t.Run("RejectsIncompleteScenario", func(t *testing.T) {
+		_, err := load.Attack(context.Background(), http.DefaultClient, load.Scenario{Name: "test", Rate: 10})
+		require.Error(t, err)
+	})
+}
+
+func TestBudget(t *testing.T) {
+	result := &load.Result{Scenario: "test", Requests: 100, Failures: 2}
+	for i := 1; i <= 98; i++ {
+		result.Latencies = append(result.Latencies, time.Duration(i)*time.Millisecond)
+	}
+	require.Equal(t, 94*time.Millisecond, result.Percentile(0.95))
+	require.Equal(t, 98*time.Millisecond, result.Percentile(0.99))
+
+	t.Run("WithinBudget", func(t *testing.T) {
+		budget := load.Budget{
+			P95:          load.Duration(100 * time.Millisecond),
+			P99:          load.Duration(100 * time.Millisecond),
+			MaxErrorRate: 0.05,
+		}
+		require.NoError(t, budget.Check(result))
+	})
+
+	t.Run("OverBudget", func(t *testing.T) {
+		budget := load.Budget{P95: load.Duration(50 * time.Millisecond), MaxErrorRate: 0.01}
+		err := budget.Check(result)
+		require.ErrorIs(t, err, load.ErrBudgetExceeded)
+		require.Contains(t, err.Error(), "p95 94ms is over 50ms")
+		require.Contains(t, err.Error(), "error rate 0.0200 is over 0.0100")
+	})
+}
+
+func TestBudgets(t *testing.T) {
+	t.Run("LoadsBudgetsFile", func(t *testing.T) {
+		budgets, err := load.LoadBudgets("budgets.json")
+		require.NoError(t, err)
+		for _, name := range []string{load.InvoiceCreationBurst, load.StatusPollingStorm, load.PaymentDetectionFanOut} {
+			require.Contains(t, budgets.Scenarios, name)
+		}
+		require.NotEmpty(t, budgets.Benchmarks)
+	})
+
+	budgets := &load.Budgets{Benchmarks: map[string]load.Duration{
+		"BenchmarkMoney_Arithmetic":       load.Duration(2 * time.Microsecond),
+		"BenchmarkInvoiceFSM_Transitions": load.Duration(100 * time.Microsecond),
+	}}
+
+	t.Run("BenchmarksWithinBudget", func(t *testing.T) {
+		output := strings.Join([]string{
+			"goos: linux",
+			"BenchmarkMoney_Arithmetic-8     \t  845318\t      1350 ns/op\t     320 B/op",
+			"BenchmarkInvoiceFSM_Transitions \t   10000\t     98000 ns/op",
+			"BenchmarkUnbudgeted-8           \t     100\t  99999999 ns/op",
+			"PASS",
+		}, "\n")
+		require.NoError(t, budgets.CheckBenchmarks(strings.NewReader(output)))
+	})
+
+	t.Run("BenchmarkOverBudget", func(t *testing.T) {
+		output := strings.Join([]string{
+			"BenchmarkMoney_Arithmetic-8     \t  845318\t      2500 ns/op",
+		}, "\n")
+		err := budgets.CheckBenchmarks(strings.NewReader(output))
+		require.ErrorIs(t, err, load.ErrBudgetExceeded)
+		require.Contains(t, err.Error(), "BenchmarkMoney_Arithmetic took 2.5µs per op, over 2µs")
+		require.Contains(t, err.Error(), "BenchmarkInvoiceFSM_Transitions did not run")
+	})
+}
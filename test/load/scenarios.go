@@ -0,0 +1,114 @@
+package load
+
+import (
+	"bytes"
+	"crypto-checkout/internal/presentation/web"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// InvoiceCreationBurst is a merchant creating invoices as fast as a checkout rush produces orders.
+	InvoiceCreationBurst = "invoice_creation_burst"
+	// StatusPollingStorm is every open checkout page polling the status of its invoice.
+	StatusPollingStorm = "status_polling_storm"
+	// PaymentDetectionFanOut is a chain watcher reporting payments to many invoice addresses at once.
+	PaymentDetectionFanOut = "payment_detection_fan_out"
+)
+
+// Invoice is an invoice created for a scenario to target.
+type Invoice struct {
+	ID         string
+	Address    string
+	USDTAmount string
+}
+
+// NotificationSource signs the transaction notifications of PaymentDetectionFanOutScenario.
+type NotificationSource struct {
+	Name   string
+	Secret string
+}
+
+// InvoiceCreationBurstScenario creates invoices with the API key.
+func InvoiceCreationBurstScenario(baseURL, apiKey string, rate int, duration time.Duration) Scenario {
+	return Scenario{
+		Name:     InvoiceCreationBurst,
+		Rate:     rate,
+		Duration: duration,
+		Request: func(i int) (*http.Request, error) {
+			body := fmt.Sprintf(`{"title":"Load order %d","items":[{"name":"Item","quantity":"1",`+
+				`"unit_price":"%d.00"}],"tax_rate":"0.00"}`, i, 10+i%90)
+			req, err := http.NewRequest(http.MethodPost, baseURL+"/api/v1/invoices", strings.NewReader(body))
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+			return req, nil
+		},
+	}
+}
+
+// StatusPollingStormScenario polls the public status of the invoices in turn.
+func StatusPollingStormScenario(baseURL string, invoices []Invoice, rate int, duration time.Duration) Scenario {
+	return Scenario{
+		Name:     StatusPollingStorm,
+		Rate:     rate,
+		Duration: duration,
+		Request: func(i int) (*http.Request, error) {
+			id := invoices[i%len(invoices)].ID
+			return http.NewRequest(http.MethodGet, baseURL+"/api/v1/public/invoice/"+id+"/status", nil)
+		},
+	}
+}
+
+// PaymentDetectionFanOutScenario notifies of one unconfirmed payment of the full amount to each invoice address,
+// then repeats the notifications as duplicates once every invoice has had one. Duplicates are answered with
+// 200 and new payments with 202.
+func PaymentDetectionFanOutScenario(
+	baseURL string,
+	source NotificationSource,
+	invoices []Invoice,
+	rate int,
+	duration time.Duration,
+) Scenario {
+	return Scenario{
+		Name:     PaymentDetectionFanOut,
+		Rate:     rate,
+		Duration: duration,
+		Request: func(i int) (*http.Request, error) {
+			n := i % len(invoices)
+			invoice := invoices[n]
+			body, err := json.Marshal(web.BlockchainNotificationRequest{
+				Network:         "tron",
+				TransactionHash: fmt.Sprintf("0x%064x", n+1),
+				FromAddress:     "TSenderAddress123456789012345678901234567890",
+				ToAddress:       invoice.Address,
+				Amount:          invoice.USDTAmount,
+				Currency:        "USDT",
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			req, err := http.NewRequest(http.MethodPost, baseURL+"/api/v1/blockchain/notifications",
+				bytes.NewReader(body))
+			if err != nil {
+				return nil, err
+			}
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(web.NotificationSourceHeader, source.Name)
+			req.Header.Set(web.NotificationTimestampHeader, timestamp)
+			req.Header.Set(web.NotificationSignatureHeader, web.SignNotification(source.Secret, timestamp, body))
+			return req, nil
+		},
+		Expect: func(status int) bool {
+			return status == http.StatusOK || status == http.StatusAccepted
+		},
+	}
+}
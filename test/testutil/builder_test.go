@@ -0,0 +1,57 @@
+package testutil_test
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/test/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoiceBuilder(t *testing.T) {
+	clock := testutil.NewClock(time.Time{})
+
+	inv, err := testutil.NewInvoiceBuilder().
+		WithID("invoice-7").
+		WithItem("Hosting", "3", "5.00").
+		WithItem("Domain", "1", "12.50").
+		WithTax("2.75").
+		CreatedAt(clock.Now()).
+		WithAmountPaid("10").
+		InStatus(invoice.StatusPartial).
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, "invoice-7", inv.ID())
+	assert.Len(t, inv.Items(), 2)
+	assert.Equal(t, "30.25", inv.Pricing().Total().Amount().String())
+	assert.Equal(t, invoice.StatusPartial, inv.Status())
+	assert.Equal(t, "10", inv.AmountPaid().Amount().String())
+	assert.Equal(t, testutil.Epoch, inv.CreatedAt())
+	assert.Equal(t, testutil.Epoch.Add(30*time.Minute), inv.Expiration().ExpiresAt())
+
+	_, err = testutil.NewInvoiceBuilder().WithItem("Hosting", "-1", "5.00").Build()
+	require.Error(t, err)
+}
+
+func TestPaymentBuilder(t *testing.T) {
+	p, err := testutil.NewPaymentBuilder().ForInvoice("invoice-7").WithConfirmations(3).Build()
+	require.NoError(t, err)
+	assert.Equal(t, "invoice-7", string(p.InvoiceID()))
+	assert.Equal(t, 3, p.Confirmations().Int())
+	assert.NotEmpty(t, p.PendingEvents())
+
+	p, err = testutil.NewPaymentBuilder().InStatus(payment.StatusFailed).Build()
+	require.NoError(t, err)
+	assert.Equal(t, payment.StatusFailed, p.Status())
+}
+
+func TestClock(t *testing.T) {
+	clock := testutil.NewClock(time.Time{})
+	assert.Equal(t, testutil.Epoch, clock.Now())
+	assert.Equal(t, testutil.Epoch.Add(time.Hour), clock.Advance(time.Hour))
+	clock.Set(testutil.Epoch)
+	assert.Equal(t, testutil.Epoch, clock.Now())
+}
@@ -0,0 +1,217 @@
+package testutil
+
+import (
+	"cmp"
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/shared"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownTransfer is returned when a chain is asked about a transaction it never included.
+var ErrUnknownTransfer = errors.New("testutil: transfer is not on the chain")
+
+// Transfer is a token transfer to include in a Chain.
+type Transfer struct {
+	// Hash is the transaction hash; one is generated when empty.
+	Hash string
+	// From defaults to a sender address on the chain's network.
+	From     string
+	To       string
+	Amount   string
+	Currency shared.CryptoCurrency // Defaults to USDT
+	Memo     string
+}
+
+// Chain is a scripted blockchain for one network. Tests include transfers, mine blocks on top of them and
+// reorganize blocks away, and the chain reports each transfer as the notification a node would: with the
+// confirmations it has at the head, or removed once its block is gone.
+//
+// Chain implements detection.TransferScanner, so a detection.Watcher or Backfiller can read it like a node.
+type Chain struct {
+	network shared.BlockchainNetwork
+
+	mu        sync.Mutex
+	head      int64
+	reorgs    int
+	transfers map[string]*detection.Notification
+	failure   error
+}
+
+// NewChain creates a chain on a network whose newest block is head.
+func NewChain(network shared.BlockchainNetwork, head int64) *Chain {
+	return &Chain{
+		network:   network,
+		head:      head,
+		transfers: make(map[string]*detection.Notification),
+	}
+}
+
+// Network returns the network of the chain.
+func (c *Chain) Network() shared.BlockchainNetwork {
+	return c.network
+}
+
+// Head returns the number of the newest block.
+func (c *Chain) Head() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.head
+}
+
+// FailWith makes the chain's scanner methods fail with err, as an unreachable node would; nil recovers it.
+func (c *Chain) FailWith(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failure = err
+}
+
+// Send includes a transfer in a new block and returns its transaction hash. Sending a transfer removed by a
+// reorganization includes it again.
+func (c *Chain) Send(transfer Transfer) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.head++
+
+	if transfer.Hash == "" {
+		transfer.Hash = fmt.Sprintf("0x%064x", len(c.transfers)+1)
+	}
+	if transfer.From == "" {
+		transfer.From = senderAddress(c.network)
+	}
+	if transfer.Currency == "" {
+		transfer.Currency = shared.CryptoCurrencyUSDT
+	}
+	c.transfers[transfer.Hash] = &detection.Notification{
+		Network:         c.network,
+		TransactionHash: transfer.Hash,
+		FromAddress:     transfer.From,
+		ToAddress:       transfer.To,
+		Amount:          transfer.Amount,
+		Currency:        transfer.Currency,
+		BlockNumber:     c.head,
+		BlockHash:       fmt.Sprintf("0x%056x%08x", c.head, c.reorgs),
+		Memo:            transfer.Memo,
+	}
+	return transfer.Hash
+}
+
+// Mine appends empty blocks, adding a confirmation to every transfer for each, and returns the new head.
+func (c *Chain) Mine(blocks int) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.head += int64(blocks)
+	return c.head
+}
+
+// Reorg drops the newest blocks and returns the hashes of the transfers they held, oldest first. The dropped
+// blocks are not mined again; call Mine to grow the chain back, with blocks of new hashes.
+func (c *Chain) Reorg(depth int) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fork := max(c.head-int64(depth), 0)
+	var removed []*detection.Notification
+	for _, transfer := range c.transfers {
+		if !transfer.Removed && transfer.BlockNumber > fork {
+			transfer.Removed = true
+			removed = append(removed, transfer)
+		}
+	}
+	c.head = fork
+	c.reorgs++
+
+	slices.SortFunc(removed, byBlock)
+	hashes := make([]string, len(removed))
+	for i, transfer := range removed {
+		hashes[i] = transfer.TransactionHash
+	}
+	return hashes
+}
+
+// Notification returns the notification of a transfer as of the head: with the confirmations it has, or
+// marked removed when a reorganization dropped its block.
+func (c *Chain) Notification(hash string) (*detection.Notification, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	transfer, ok := c.transfers[hash]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTransfer, hash)
+	}
+	notification := *transfer
+	if !notification.Removed {
+		notification.Confirmations = int(c.head - notification.BlockNumber + 1)
+	}
+	return &notification, nil
+}
+
+// Emit hands the current notifications of transfers to payment detection, in order, as a node's webhook would.
+func (c *Chain) Emit(ctx context.Context, service detection.Service, hashes ...string) ([]*detection.Result, error) {
+	results := make([]*detection.Result, 0, len(hashes))
+	for _, hash := range hashes {
+		notification, err := c.Notification(hash)
+		if err != nil {
+			return results, err
+		}
+		result, err := service.HandleNotification(ctx, notification)
+		if err != nil {
+			return results, fmt.Errorf("failed to emit transaction %s: %w", hash, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// LatestBlock returns the number of the newest block.
+func (c *Chain) LatestBlock(context.Context) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failure != nil {
+		return 0, c.failure
+	}
+	return c.head, nil
+}
+
+// ScanTransfers returns the transfers of the tokens to the addresses in the blocks, oldest first, without a
+// confirmation count.
+func (c *Chain) ScanTransfers(_ context.Context, req *detection.ScanRequest) ([]*detection.Notification, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failure != nil {
+		return nil, c.failure
+	}
+
+	var notifications []*detection.Notification
+	for _, transfer := range c.transfers {
+		if transfer.Removed || transfer.BlockNumber < req.FromBlock || transfer.BlockNumber > req.ToBlock {
+			continue
+		}
+		for _, address := range req.Addresses {
+			if !strings.EqualFold(address, transfer.ToAddress) || !scansToken(req.Tokens, transfer.Currency) {
+				continue
+			}
+			notification := *transfer
+			notification.ToAddress = address
+			notifications = append(notifications, &notification)
+			break
+		}
+	}
+	slices.SortFunc(notifications, byBlock)
+	return notifications, nil
+}
+
+// byBlock orders notifications by block, then transaction hash.
+func byBlock(a, b *detection.Notification) int {
+	return cmp.Or(cmp.Compare(a.BlockNumber, b.BlockNumber), strings.Compare(a.TransactionHash, b.TransactionHash))
+}
+
+// scansToken reports whether a scan of the tokens covers a currency.
+func scansToken(tokens []shared.Token, currency shared.CryptoCurrency) bool {
+	return slices.ContainsFunc(tokens, func(token shared.Token) bool { return token.Symbol == currency })
+}
+
+var _ detection.TransferScanner = (*Chain)(nil)
@@ -0,0 +1,75 @@
+package testutil_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/test/testutil"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingDetection records the notifications it is handed.
+type recordingDetection struct {
+	notifications []detection.Notification
+}
+
+func (d *recordingDetection) HandleNotification(
+	_ context.Context,
+	notification *detection.Notification,
+) (*detection.Result, error) {
+	d.notifications = append(d.notifications, *notification)
+	return &detection.Result{}, nil
+}
+
+func TestChain(t *testing.T) {
+	ctx := context.Background()
+	chain := testutil.NewChain(shared.NetworkTron, 1000)
+	usdt := []shared.Token{{Symbol: shared.CryptoCurrencyUSDT, Network: shared.NetworkTron}}
+
+	first := chain.Send(testutil.Transfer{To: testutil.TestAddress, Amount: "5"})
+	second := chain.Send(testutil.Transfer{To: testutil.TestAddress, Amount: "4.99"})
+	other := chain.Send(testutil.Transfer{To: "TOtherAddress1234567890123456789012345678", Amount: "1"})
+	assert.Equal(t, int64(1005), chain.Mine(2))
+
+	service := &recordingDetection{}
+	_, err := chain.Emit(ctx, service, first, second)
+	require.NoError(t, err)
+	require.Len(t, service.notifications, 2)
+	assert.Equal(t, 5, service.notifications[0].Confirmations)
+	assert.Equal(t, 4, service.notifications[1].Confirmations)
+	assert.Equal(t, testutil.TestSenderAddress, service.notifications[0].FromAddress)
+
+	notifications, err := chain.ScanTransfers(ctx, &detection.ScanRequest{
+		FromBlock: 1001, ToBlock: 1005, Addresses: []string{testutil.TestAddress}, Tokens: usdt,
+	})
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+	assert.Equal(t, first, notifications[0].TransactionHash)
+	assert.Zero(t, notifications[0].Confirmations)
+
+	assert.Equal(t, []string{second, other}, chain.Reorg(4))
+	removed, err := chain.Notification(second)
+	require.NoError(t, err)
+	assert.True(t, removed.Removed)
+	confirmed, err := chain.Notification(first)
+	require.NoError(t, err)
+	assert.Equal(t, 1, confirmed.Confirmations)
+
+	chain.Send(testutil.Transfer{Hash: second, To: testutil.TestAddress, Amount: "4.99"})
+	included, err := chain.Notification(second)
+	require.NoError(t, err)
+	assert.False(t, included.Removed)
+	assert.NotEqual(t, service.notifications[1].BlockHash, included.BlockHash)
+
+	_, err = chain.Notification("0xunknown")
+	require.ErrorIs(t, err, testutil.ErrUnknownTransfer)
+
+	unavailable := errors.New("node unavailable")
+	chain.FailWith(unavailable)
+	_, err = chain.LatestBlock(ctx)
+	require.ErrorIs(t, err, unavailable)
+}
@@ -0,0 +1,52 @@
+package testutil
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Clock is a deterministic clock that only moves when a test moves it. Its Now method can be passed wherever
+// a service takes a now func() time.Time.
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewClock creates a clock reading start, or Epoch when start is zero.
+func NewClock(start time.Time) *Clock {
+	if start.IsZero() {
+		start = Epoch
+	}
+	return &Clock{now: start.UTC()}
+}
+
+// Now returns the clock's current time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d and returns the new time.
+func (c *Clock) Advance(d time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	return c.now
+}
+
+// Set moves the clock to t, backwards if need be.
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t.UTC()
+}
+
+// UseClock installs the clock as the one shared.Now reads until the test ends. The clock is process wide, so
+// the test must not run in parallel.
+func UseClock(t testing.TB, clock *Clock) {
+	t.Helper()
+	t.Cleanup(shared.SetClock(clock))
+}
@@ -0,0 +1,246 @@
+package testutil
+
+import (
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+	"time"
+)
+
+// InvoiceBuilder builds invoices for tests. Unless overridden, an invoice is USDT on Tron for two items at
+// 10.00 USD, paid to TestAddress at a rate of 1.0 and expiring 30 minutes after it was created.
+type InvoiceBuilder struct {
+	id             string
+	merchantID     string
+	title          string
+	description    string
+	currency       shared.Currency
+	items          []*invoice.InvoiceItem
+	tax            string
+	cryptoCurrency shared.CryptoCurrency
+	address        string
+	network        shared.BlockchainNetwork
+	rate           string
+	expiresIn      time.Duration
+	createdAt      time.Time
+	metadata       map[string]interface{}
+	status         invoice.InvoiceStatus
+	amountPaid     string
+	err            error
+}
+
+// NewInvoiceBuilder creates a builder for invoice "invoice-1" of merchant "merchant-1".
+func NewInvoiceBuilder() *InvoiceBuilder {
+	return &InvoiceBuilder{
+		id:             "invoice-1",
+		merchantID:     "merchant-1",
+		title:          "Test Invoice",
+		description:    "Test Description",
+		currency:       shared.CurrencyUSD,
+		tax:            "0.00",
+		cryptoCurrency: shared.CryptoCurrencyUSDT,
+		address:        TestAddress,
+		network:        shared.NetworkTron,
+		rate:           "1.0",
+		expiresIn:      30 * time.Minute,
+		status:         invoice.StatusCreated,
+	}
+}
+
+// WithID sets the invoice ID.
+func (b *InvoiceBuilder) WithID(id string) *InvoiceBuilder {
+	b.id = id
+	return b
+}
+
+// WithMerchantID sets the merchant the invoice belongs to.
+func (b *InvoiceBuilder) WithMerchantID(merchantID string) *InvoiceBuilder {
+	b.merchantID = merchantID
+	return b
+}
+
+// WithTitle sets the invoice title.
+func (b *InvoiceBuilder) WithTitle(title string) *InvoiceBuilder {
+	b.title = title
+	return b
+}
+
+// WithCurrency sets the fiat currency items added with WithItem are priced in.
+func (b *InvoiceBuilder) WithCurrency(currency shared.Currency) *InvoiceBuilder {
+	b.currency = currency
+	return b
+}
+
+// WithItems replaces the invoice's items.
+func (b *InvoiceBuilder) WithItems(items ...*invoice.InvoiceItem) *InvoiceBuilder {
+	b.items = items
+	return b
+}
+
+// WithItem adds an item priced in the builder's fiat currency.
+func (b *InvoiceBuilder) WithItem(name, quantity, unitPrice string) *InvoiceBuilder {
+	price, err := shared.NewMoney(unitPrice, b.currency)
+	if err != nil {
+		b.fail(fmt.Errorf("invalid unit price of %s: %w", name, err))
+		return b
+	}
+	item, err := invoice.NewInvoiceItem(name, "", quantity, price)
+	if err != nil {
+		b.fail(fmt.Errorf("invalid item %s: %w", name, err))
+		return b
+	}
+	b.items = append(b.items, item)
+	return b
+}
+
+// WithTax sets the tax added to the items' subtotal.
+func (b *InvoiceBuilder) WithTax(tax string) *InvoiceBuilder {
+	b.tax = tax
+	return b
+}
+
+// WithCryptoCurrency sets the cryptocurrency the invoice is paid in.
+func (b *InvoiceBuilder) WithCryptoCurrency(currency shared.CryptoCurrency) *InvoiceBuilder {
+	b.cryptoCurrency = currency
+	return b
+}
+
+// WithPaymentAddress sets the address and network the invoice is paid to.
+func (b *InvoiceBuilder) WithPaymentAddress(address string, network shared.BlockchainNetwork) *InvoiceBuilder {
+	b.address = address
+	b.network = network
+	return b
+}
+
+// WithExchangeRate sets the rate of one unit of fiat in the cryptocurrency.
+func (b *InvoiceBuilder) WithExchangeRate(rate string) *InvoiceBuilder {
+	b.rate = rate
+	return b
+}
+
+// ExpiringIn sets how long after its creation the invoice expires.
+func (b *InvoiceBuilder) ExpiringIn(d time.Duration) *InvoiceBuilder {
+	b.expiresIn = d
+	return b
+}
+
+// CreatedAt sets when the invoice was created, e.g. to a Clock's time; expiry is counted from it.
+func (b *InvoiceBuilder) CreatedAt(createdAt time.Time) *InvoiceBuilder {
+	b.createdAt = createdAt
+	return b
+}
+
+// WithMetadata sets the invoice metadata.
+func (b *InvoiceBuilder) WithMetadata(metadata map[string]interface{}) *InvoiceBuilder {
+	b.metadata = metadata
+	return b
+}
+
+// InStatus puts the invoice in a status without going through the transitions that lead there. Pair it with
+// WithAmountPaid where the status implies a payment.
+func (b *InvoiceBuilder) InStatus(status invoice.InvoiceStatus) *InvoiceBuilder {
+	b.status = status
+	return b
+}
+
+// WithAmountPaid records a payment of the amount in the invoice's cryptocurrency.
+func (b *InvoiceBuilder) WithAmountPaid(amount string) *InvoiceBuilder {
+	b.amountPaid = amount
+	return b
+}
+
+// Build creates the invoice, returning the first error of the builder's options.
+func (b *InvoiceBuilder) Build() (*invoice.Invoice, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	items := b.items
+	if len(items) == 0 {
+		unitPrice, err := shared.NewMoney("10.00", b.currency)
+		if err != nil {
+			return nil, err
+		}
+		item, err := invoice.NewInvoiceItem("Test Item", "Test Description", "2", unitPrice)
+		if err != nil {
+			return nil, err
+		}
+		items = []*invoice.InvoiceItem{item}
+	}
+
+	pricing, err := b.pricing(items)
+	if err != nil {
+		return nil, err
+	}
+	address, err := shared.NewPaymentAddress(b.address, b.network)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment address: %w", err)
+	}
+	currency := shared.Currency(items[0].UnitPrice().Currency())
+	rate, err := shared.NewExchangeRate(b.rate, currency, b.cryptoCurrency, "default", b.expiresIn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exchange rate: %w", err)
+	}
+	tolerance, err := invoice.NewPaymentTolerance("0.01", "1.0", invoice.OverpaymentActionCredit)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt := b.createdAt
+	if createdAt.IsZero() {
+		createdAt = time.Now().UTC()
+	}
+	inv, err := invoice.NewInvoice(b.id, b.merchantID, b.title, b.description, items, pricing, b.cryptoCurrency,
+		address, rate, tolerance, invoice.NewInvoiceExpirationWithTimeUnsafe(createdAt.Add(b.expiresIn)), b.metadata)
+	if err != nil {
+		return nil, err
+	}
+	inv.SetCreatedAt(createdAt)
+	inv.SetUpdatedAt(createdAt)
+
+	if b.amountPaid != "" {
+		amount, err := shared.NewMoneyWithCrypto(b.amountPaid, b.cryptoCurrency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount paid: %w", err)
+		}
+		if err := inv.RecordPayment(amount); err != nil {
+			return nil, err
+		}
+		inv.SetUpdatedAt(createdAt)
+	}
+	if b.status != invoice.StatusCreated {
+		inv.SetStatus(b.status)
+		switch b.status {
+		case invoice.StatusPaid, invoice.StatusPartiallyRefunded, invoice.StatusRefunded:
+			inv.SetPaidAt(&createdAt)
+		}
+	}
+	return inv, nil
+}
+
+// pricing totals the items and the tax.
+func (b *InvoiceBuilder) pricing(items []*invoice.InvoiceItem) (*invoice.InvoicePricing, error) {
+	subtotal := items[0].TotalPrice()
+	for _, item := range items[1:] {
+		sum, err := subtotal.Add(item.TotalPrice())
+		if err != nil {
+			return nil, fmt.Errorf("invalid item %s: %w", item.Name(), err)
+		}
+		subtotal = sum
+	}
+	tax, err := shared.NewMoney(b.tax, shared.Currency(subtotal.Currency()))
+	if err != nil {
+		return nil, fmt.Errorf("invalid tax: %w", err)
+	}
+	total, err := subtotal.Add(tax)
+	if err != nil {
+		return nil, err
+	}
+	return invoice.NewInvoicePricing(subtotal, tax, total)
+}
+
+// fail keeps the first error of the builder's options.
+func (b *InvoiceBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
@@ -0,0 +1,124 @@
+package testutil
+
+import (
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+)
+
+// PaymentBuilder builds payments for tests. Unless overridden, a payment is a detected transfer of 100.00 USDT
+// on Tron to TestAddress that needs 3 confirmations.
+type PaymentBuilder struct {
+	id                    string
+	invoiceID             string
+	amount                string
+	currency              shared.CryptoCurrency
+	fromAddress           string
+	toAddress             string
+	network               shared.BlockchainNetwork
+	txHash                string
+	requiredConfirmations int
+	confirmations         int
+	status                payment.PaymentStatus
+}
+
+// NewPaymentBuilder creates a builder for payment "payment-1" of invoice "invoice-1".
+func NewPaymentBuilder() *PaymentBuilder {
+	return &PaymentBuilder{
+		id:                    "payment-1",
+		invoiceID:             "invoice-1",
+		amount:                "100.00",
+		currency:              shared.CryptoCurrencyUSDT,
+		fromAddress:           TestSenderAddress,
+		toAddress:             TestAddress,
+		network:               shared.NetworkTron,
+		txHash:                TestTransactionHash,
+		requiredConfirmations: 3,
+	}
+}
+
+// WithID sets the payment ID.
+func (b *PaymentBuilder) WithID(id string) *PaymentBuilder {
+	b.id = id
+	return b
+}
+
+// ForInvoice sets the invoice the payment pays.
+func (b *PaymentBuilder) ForInvoice(invoiceID string) *PaymentBuilder {
+	b.invoiceID = invoiceID
+	return b
+}
+
+// WithAmount sets the amount transferred and its cryptocurrency.
+func (b *PaymentBuilder) WithAmount(amount string, currency shared.CryptoCurrency) *PaymentBuilder {
+	b.amount = amount
+	b.currency = currency
+	return b
+}
+
+// WithAddresses sets the addresses the transfer was sent from and to, and their network.
+func (b *PaymentBuilder) WithAddresses(from, to string, network shared.BlockchainNetwork) *PaymentBuilder {
+	b.fromAddress = from
+	b.toAddress = to
+	b.network = network
+	return b
+}
+
+// WithTransactionHash sets the hash of the transfer.
+func (b *PaymentBuilder) WithTransactionHash(txHash string) *PaymentBuilder {
+	b.txHash = txHash
+	return b
+}
+
+// RequiringConfirmations sets the confirmations the payment needs before it is confirmed.
+func (b *PaymentBuilder) RequiringConfirmations(count int) *PaymentBuilder {
+	b.requiredConfirmations = count
+	return b
+}
+
+// WithConfirmations records the confirmations the transfer has, moving the payment on as it would on chain.
+func (b *PaymentBuilder) WithConfirmations(count int) *PaymentBuilder {
+	b.confirmations = count
+	return b
+}
+
+// InStatus puts the payment in a status without the guards of the transitions that lead there.
+func (b *PaymentBuilder) InStatus(status payment.PaymentStatus) *PaymentBuilder {
+	b.status = status
+	return b
+}
+
+// Build creates the payment with its events still pending, as a repository expects to save it.
+func (b *PaymentBuilder) Build() (*payment.Payment, error) {
+	amount, err := shared.NewMoneyWithCrypto(b.amount, b.currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+	paymentAmount, err := payment.NewPaymentAmount(amount, b.currency)
+	if err != nil {
+		return nil, err
+	}
+	toAddress, err := payment.NewPaymentAddress(b.toAddress, b.network)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+	hash, err := payment.NewTransactionHash(b.txHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction hash: %w", err)
+	}
+
+	p, err := payment.NewPayment(shared.PaymentID(b.id), shared.InvoiceID(b.invoiceID), paymentAmount,
+		b.fromAddress, toAddress, hash, b.requiredConfirmations)
+	if err != nil {
+		return nil, err
+	}
+	if b.confirmations > 0 {
+		if err := p.UpdateConfirmations(nil, b.confirmations); err != nil {
+			return nil, err
+		}
+	}
+	if b.status != "" {
+		p.SetStatus(b.status)
+	}
+	return p, nil
+}
@@ -0,0 +1,92 @@
+package testutil
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/shared"
+	"fmt"
+
+	"go.uber.org/fx"
+)
+
+// Simulator is an in-process blockchain for end-to-end tests: a scripted Chain per network, served to the
+// chain watcher as its nodes. Tests inject transfers, mine blocks and trigger reorganizations on command, then
+// hand the results to payment detection the way the watcher and node webhooks would, so the whole payment
+// lifecycle runs without network access or waiting for an interval.
+type Simulator struct {
+	chains  []*Chain
+	service detection.Service
+	watcher *detection.Watcher
+}
+
+// NewSimulator creates a simulator with a chain for each network, all with head as their newest block.
+func NewSimulator(head int64, networks ...shared.BlockchainNetwork) *Simulator {
+	s := &Simulator{}
+	for _, network := range networks {
+		s.chains = append(s.chains, NewChain(network, head))
+	}
+	return s
+}
+
+// Attach sets the payment detection service transfers are emitted to and the watcher Sync runs.
+func (s *Simulator) Attach(service detection.Service, watcher *detection.Watcher) {
+	s.service = service
+	s.watcher = watcher
+}
+
+// Option replaces the application's chain scanners with the simulator's chains and attaches the simulator to
+// the application's detection service and watcher. The application must include the chainscan and detection
+// modules, with the watcher policy enabled for Sync to scan.
+func (s *Simulator) Option() fx.Option {
+	return fx.Options(
+		fx.Replace(s.Scanners()),
+		fx.Invoke(s.Attach),
+	)
+}
+
+// Chain returns the chain of a network; it panics when the simulator has none, as that is a broken test.
+func (s *Simulator) Chain(network shared.BlockchainNetwork) *Chain {
+	for _, chain := range s.chains {
+		if chain.Network() == network {
+			return chain
+		}
+	}
+	panic(fmt.Sprintf("testutil: simulator has no %s chain", network))
+}
+
+// Scanners returns the chains as the chain scanners of a watcher.
+func (s *Simulator) Scanners() []detection.TransferScanner {
+	scanners := make([]detection.TransferScanner, len(s.chains))
+	for i, chain := range s.chains {
+		scanners[i] = chain
+	}
+	return scanners
+}
+
+// Send includes a transfer in a new block of a network and returns its transaction hash.
+func (s *Simulator) Send(network shared.BlockchainNetwork, transfer Transfer) string {
+	return s.Chain(network).Send(transfer)
+}
+
+// Sync has the watcher catch up with every chain once, detecting the transfers it has not scanned yet.
+func (s *Simulator) Sync(ctx context.Context) {
+	s.watcher.Check(ctx)
+}
+
+// Confirm mines blocks on a network and emits the confirmations the transfers have then.
+func (s *Simulator) Confirm(
+	ctx context.Context,
+	network shared.BlockchainNetwork,
+	blocks int,
+	hashes ...string,
+) ([]*detection.Result, error) {
+	chain := s.Chain(network)
+	chain.Mine(blocks)
+	return chain.Emit(ctx, s.service, hashes...)
+}
+
+// Reorg drops the newest blocks of a network and emits the removal of the transfers they held.
+func (s *Simulator) Reorg(ctx context.Context, network shared.BlockchainNetwork, depth int) ([]*detection.Result, error) {
+	chain := s.Chain(network)
+	return chain.Emit(ctx, s.service, chain.Reorg(depth)...)
+}
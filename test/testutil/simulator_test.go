@@ -0,0 +1,186 @@
+package testutil_test
+
+import (
+	"context"
+	"crypto-checkout/internal/domain/detection"
+	"crypto-checkout/internal/domain/invoice"
+	"crypto-checkout/internal/domain/payment"
+	"crypto-checkout/internal/domain/shared"
+	"crypto-checkout/internal/infrastructure/memory"
+	"crypto-checkout/test/testutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+)
+
+func TestSimulator_PaymentLifecycle(t *testing.T) {
+	ctx := context.Background()
+	payments := memory.NewPaymentRepository()
+	invoices := memory.NewInvoiceRepository(payments)
+	for id, address := range map[string]string{
+		"invoice-1": "0x2222222222222222222222222222222222222222",
+		"invoice-2": "0x3333333333333333333333333333333333333333",
+	} {
+		inv, err := testutil.NewInvoiceBuilder().
+			WithID(id).
+			WithCryptoCurrency(shared.CryptoCurrencyETH).
+			WithExchangeRate("0.0005").
+			WithPaymentAddress(address, shared.NetworkEthereum).
+			InStatus(invoice.StatusPending).
+			Build()
+		require.NoError(t, err)
+		require.NoError(t, invoices.Save(ctx, inv))
+	}
+
+	tokens, err := shared.NewTokenRegistry(shared.DefaultTokens())
+	require.NoError(t, err)
+	invoiceService := invoice.NewInvoiceService(invoices, nil, nil, nil, invoice.DefaultRequotePolicy(), tokens,
+		nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+	paymentService := payment.NewPaymentService(payments, nil, nil, nil, zap.NewNop())
+	detectionService := detection.NewService(invoiceService, paymentService, nil, nil, nil, zap.NewNop())
+
+	sim := testutil.NewSimulator(1000, shared.NetworkEthereum)
+	watcher := detection.NewWatcher(sim.Scanners(), invoiceService, detectionService, tokens,
+		memory.NewBlockCursorRepository(), detection.WatchPolicy{Enabled: true, Interval: time.Hour}, zap.NewNop())
+	sim.Attach(detectionService, watcher)
+	sim.Sync(ctx)
+
+	find := func(hash string) *payment.Payment {
+		txHash, err := payment.NewTransactionHash(hash)
+		require.NoError(t, err)
+		p, err := payments.FindByTransactionHash(ctx, shared.NetworkEthereum, txHash)
+		require.NoError(t, err)
+		return p
+	}
+	// The watcher detects the payment of the first invoice, which confirms once the chain grows 11 more blocks
+	first := sim.Send(shared.NetworkEthereum, testutil.Transfer{
+		To: "0x2222222222222222222222222222222222222222", Amount: "0.01", Currency: shared.CryptoCurrencyETH,
+	})
+	sim.Sync(ctx)
+	detected := find(first)
+	assert.Equal(t, 1, detected.Confirmations().Int())
+	assert.Equal(t, 12, detected.RequiredConfirmations())
+	assert.False(t, detected.IsConfirmed())
+
+	_, err = sim.Confirm(ctx, shared.NetworkEthereum, 11, first)
+	require.NoError(t, err)
+	assert.Equal(t, payment.StatusConfirmed, find(first).Status())
+
+	// A reorganization drops the payment of the second invoice before it confirms
+	second := sim.Send(shared.NetworkEthereum, testutil.Transfer{
+		To: "0x3333333333333333333333333333333333333333", Amount: "0.01", Currency: shared.CryptoCurrencyETH,
+	})
+	sim.Sync(ctx)
+	_, err = sim.Confirm(ctx, shared.NetworkEthereum, 2, second)
+	require.NoError(t, err)
+	assert.Equal(t, payment.StatusConfirming, find(second).Status())
+
+	results, err := sim.Reorg(ctx, shared.NetworkEthereum, 3)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, payment.StatusOrphaned, find(second).Status())
+	assert.Equal(t, payment.StatusConfirmed, find(first).Status())
+
+	paid, err := invoiceService.GetInvoice(ctx, "invoice-1")
+	require.NoError(t, err)
+	assert.Equal(t, "0.01", paid.AmountPaid().Amount().String())
+	assert.Equal(t, invoice.StatusConfirming, paid.Status())
+}
+
+// recordingEventBus records the events published; only publishing is implemented.
+type recordingEventBus struct {
+	shared.EventBus
+	events []*shared.BaseDomainEvent
+}
+
+func (b *recordingEventBus) PublishEvent(_ context.Context, event *shared.BaseDomainEvent) error {
+	b.events = append(b.events, event)
+	return nil
+}
+
+// confirmations returns the payment confirmation events published, in order.
+func (b *recordingEventBus) confirmations() []shared.PaymentConfirmationEvent {
+	var confirmations []shared.PaymentConfirmationEvent
+	for _, event := range b.events {
+		if event.EventType == shared.EventTypePaymentConfirmation {
+			confirmations = append(confirmations, event.EventData.(shared.PaymentConfirmationEvent))
+		}
+	}
+	return confirmations
+}
+
+func TestSimulator_ConfirmationEvents(t *testing.T) {
+	ctx := context.Background()
+	clock := testutil.NewClock(time.Time{})
+	testutil.UseClock(t, clock)
+
+	payments := memory.NewPaymentRepository()
+	invoices := memory.NewInvoiceRepository(payments)
+	inv, err := testutil.NewInvoiceBuilder().
+		WithCryptoCurrency(shared.CryptoCurrencyETH).
+		WithExchangeRate("0.0005").
+		WithPaymentAddress("0x2222222222222222222222222222222222222222", shared.NetworkEthereum).
+		InStatus(invoice.StatusPending).
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, invoices.Save(ctx, inv))
+
+	tokens, err := shared.NewTokenRegistry(shared.DefaultTokens())
+	require.NoError(t, err)
+	invoiceService := invoice.NewInvoiceService(invoices, nil, nil, nil, invoice.DefaultRequotePolicy(), tokens,
+		nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+	bus := &recordingEventBus{}
+	paymentService := payment.NewPaymentService(payments, bus, nil, nil, zap.NewNop())
+	detectionService := detection.NewService(invoiceService, paymentService, nil, nil, nil, zap.NewNop())
+
+	sim := testutil.NewSimulator(1000, shared.NetworkEthereum)
+	sim.Attach(detectionService, nil)
+	hash := sim.Send(shared.NetworkEthereum, testutil.Transfer{
+		To: "0x2222222222222222222222222222222222222222", Amount: "0.01", Currency: shared.CryptoCurrencyETH,
+	})
+	_, err = sim.Confirm(ctx, shared.NetworkEthereum, 0, hash)
+	require.NoError(t, err)
+	require.Len(t, bus.confirmations(), 1)
+
+	// A notification skipping blocks still reports every confirmation, and none past the twelve required
+	_, err = sim.Confirm(ctx, shared.NetworkEthereum, 4, hash)
+	require.NoError(t, err)
+	_, err = sim.Confirm(ctx, shared.NetworkEthereum, 20, hash)
+	require.NoError(t, err)
+
+	confirmations := bus.confirmations()
+	require.Len(t, confirmations, 12)
+	for i, confirmation := range confirmations {
+		assert.Equal(t, i+1, confirmation.Confirmations)
+		assert.Equal(t, 12, confirmation.RequiredConfirmations)
+		assert.Equal(t, inv.ID(), confirmation.InvoiceID)
+		assert.Equal(t, int64(1001), confirmation.BlockNumber)
+		assert.NotEmpty(t, confirmation.BlockHash)
+	}
+	// Finality is estimated from the confirmations missing at the time, at about 13 seconds a block on Ethereum
+	assert.Equal(t, clock.Now().Add(11*13*time.Second), confirmations[0].EstimatedFinalityAt)
+	assert.Equal(t, clock.Now().Add(7*13*time.Second), confirmations[4].EstimatedFinalityAt)
+	assert.Equal(t, clock.Now(), confirmations[11].EstimatedFinalityAt)
+	assert.Equal(t, payment.StatusConfirmed.String(), confirmations[11].Status)
+}
+
+func TestSimulator_Option(t *testing.T) {
+	sim := testutil.NewSimulator(1000, shared.NetworkTron, shared.NetworkEthereum)
+	var scanners []detection.TransferScanner
+	app := fxtest.New(t,
+		fx.Provide(func() []detection.TransferScanner { return nil }),
+		fx.Provide(func() detection.Service { return &recordingDetection{} }),
+		fx.Provide(func() *detection.Watcher { return nil }),
+		sim.Option(),
+		fx.Populate(&scanners),
+	)
+	app.RequireStart().RequireStop()
+
+	require.Len(t, scanners, 2)
+	assert.Same(t, sim.Chain(shared.NetworkEthereum), scanners[1])
+}
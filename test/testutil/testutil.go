@@ -0,0 +1,33 @@
+// Package testutil holds the fixtures shared by unit and end-to-end tests.
+//
+// NewInvoiceBuilder and NewPaymentBuilder build valid aggregates from defaults that each test only overrides
+// where it matters, Clock stands in for time.Now where a service takes a now func, and Chain is a scripted
+// blockchain that emits the detections, confirmations and reorganizations a chain watcher would see. Simulator
+// serves chains to the chain watcher in place of real nodes, so end-to-end tests drive the payment lifecycle
+// block by block.
+package testutil
+
+import (
+	"crypto-checkout/internal/domain/shared"
+	"time"
+)
+
+const (
+	// TestAddress is the Tron payment address fixtures are paid to by default.
+	TestAddress = "TTestAddress123456789012345678901234567890"
+	// TestSenderAddress is the Tron address fixtures are paid from by default.
+	TestSenderAddress = "TSenderAddress123456789012345678901234567890"
+	// TestTransactionHash is the transaction hash payments are built with by default.
+	TestTransactionHash = "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+)
+
+// Epoch is the instant a Clock created without a start time reads.
+var Epoch = time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+// senderAddress returns the default address transfers on a network are sent from.
+func senderAddress(network shared.BlockchainNetwork) string {
+	if network == shared.NetworkTron {
+		return TestSenderAddress
+	}
+	return "0x1111111111111111111111111111111111111111"
+}